@@ -60,6 +60,7 @@ func initBridge() {
 			return mcpResourceTemplates()
 		},
 		ResolveResourceContent: resolveResourceContent,
+		IsLiveResource:         isLiveResourceURI,
 
 		// Daemon lifecycle
 		DaemonProcessArgv0:  daemonProcessArgv0,
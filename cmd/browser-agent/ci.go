@@ -1,4 +1,4 @@
-// Purpose: Implements snapshot/test-boundary and CI webhook endpoints for capture-driven verification workflows.
+// Purpose: Implements snapshot/test-boundary/test-events and CI webhook endpoints for capture-driven verification workflows.
 // Why: Bridges CI signals and runtime snapshots into a single API surface for regression tooling.
 // Docs: docs/features/feature/ci-infrastructure/index.md
 
@@ -11,14 +11,27 @@ import (
 	"time"
 
 	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/testevents"
 )
 
+// maxTestEventsBodySize bounds one POST /test-events batch (500 events with stacks).
+const maxTestEventsBodySize = 4 << 20
+
 // ============================================
 // Handlers
 // ============================================
 
+// snapshotClientID identifies the registered client a snapshot is taken for.
+func snapshotClientID(r *http.Request) string {
+	if id := r.Header.Get("X-Kaboom-Client"); id != "" {
+		return id
+	}
+	return r.URL.Query().Get("client_id")
+}
+
 // handleSnapshot returns an HTTP handler for GET /snapshot.
-// Returns all captured state in a single response.
+// Returns all captured state in a single response, stamped with the requesting
+// client's git state when it registered one (X-Kaboom-Client or ?client_id=).
 // #lizard forgives
 func handleSnapshot(server *Server, cap *capture.Store) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -71,6 +84,7 @@ func handleSnapshot(server *Server, cap *capture.Store) http.HandlerFunc {
 			NetworkBodies:   networkBodies,
 			EnhancedActions: enhancedActions,
 			Stats:           stats,
+			SourceControl:   lookupSourceControl(cap, snapshotClientID(r)),
 		}
 
 		jsonResponse(w, http.StatusOK, snapshot)
@@ -142,3 +156,51 @@ func handleTestBoundary(cap *capture.Store) http.HandlerFunc {
 		})
 	}
 }
+
+// handleTestEvents returns an HTTP handler for POST /test-events.
+// Accepts test-runner lifecycle events (one object, an array, or {"events": [...]}) from
+// Vitest/Jest/Playwright reporters. test_start/test_end also open and close test boundaries,
+// so network, WebSocket, and action telemetry ingested meanwhile is tagged with the test ID.
+func handleTestEvents(cap *capture.Store) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			jsonResponse(w, http.StatusMethodNotAllowed, map[string]string{"error": "Method not allowed"})
+			return
+		}
+
+		body, err := io.ReadAll(io.LimitReader(r.Body, maxTestEventsBodySize+1))
+		if err != nil {
+			jsonResponse(w, http.StatusBadRequest, map[string]string{"error": "Failed to read body"})
+			return
+		}
+		if len(body) > maxTestEventsBodySize {
+			jsonResponse(w, http.StatusRequestEntityTooLarge, map[string]string{"error": "Batch too large; send fewer events per request"})
+			return
+		}
+
+		events, err := testevents.Parse(body, time.Now())
+		if err != nil {
+			jsonResponse(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+			return
+		}
+
+		store := cap.TestEvents()
+		result := store.Ingest(events)
+		for _, id := range result.Started {
+			cap.SetTestBoundaryStart(id)
+		}
+		for _, id := range result.Ended {
+			cap.SetTestBoundaryEnd(id)
+		}
+		for _, runID := range result.RunsEnded {
+			cap.EndTestRun(runID)
+		}
+
+		jsonResponse(w, http.StatusOK, map[string]any{
+			"accepted": result.Accepted,
+			"started":  result.Started,
+			"ended":    result.Ended,
+			"summary":  store.Summarize(),
+		})
+	}
+}
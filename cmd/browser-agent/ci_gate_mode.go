@@ -0,0 +1,165 @@
+// Purpose: Implements --ci, which runs the HTTP capture server headlessly until a test boundary ends or a duration elapses, then gates on a policy.
+// Why: Lets a pipeline fail on console errors, blown budgets, forbidden domains, or missing CSP without an agent in the loop.
+// Docs: docs/features/feature/ci-gate/index.md
+
+package main
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/lifecycle"
+)
+
+// CI gate exit codes.
+const (
+	ciGateExitPass      = 0
+	ciGateExitViolation = 1
+	ciGateExitError     = 2
+)
+
+// ciGateSettleDelay lets telemetry the extension batched before the stop signal arrive.
+const ciGateSettleDelay = 2 * time.Second
+
+// ciGateOptions are the --ci flags.
+type ciGateOptions struct {
+	port       int
+	apiKey     string
+	maxEntries int
+	policyPath string
+	reportPath string
+	testID     string
+	duration   time.Duration
+}
+
+// ciGateWaiter resolves once with the reason the run stopped.
+type ciGateWaiter struct {
+	once sync.Once
+	done chan string
+}
+
+func newCIGateWaiter() *ciGateWaiter {
+	return &ciGateWaiter{done: make(chan string, 1)}
+}
+
+func (w *ciGateWaiter) stop(reason string) {
+	w.once.Do(func() { w.done <- reason })
+}
+
+// watchTestBoundaries stops the waiter when testID's boundary ends. With no testID, the run
+// stops on a test runner's run_end, or on the first ended /test-boundary when no runner
+// reports per-test events (whose test_end would otherwise stop the run after one test).
+func (w *ciGateWaiter) watchTestBoundaries(cap *capture.Store, testID string) {
+	cap.SubscribeLifecycle(func(event lifecycle.Event, data map[string]any) {
+		switch event {
+		case lifecycle.EventTestBoundaryEnded:
+			id, _ := data["test_id"].(string)
+			if id == testID || (testID == "" && cap.TestEvents().Summarize().Tests == 0) {
+				w.stop("test_boundary")
+			}
+		case lifecycle.EventTestRunEnded:
+			if testID == "" {
+				w.stop("test_run_end")
+			}
+		}
+	})
+}
+
+// runCIGateMode serves the capture endpoints, waits for the stop condition, evaluates the
+// policy, and writes the report. Returns 0 on pass, 1 on violation, 2 on setup errors.
+func runCIGateMode(opts ciGateOptions) int {
+	if opts.policyPath == "" {
+		stderrf("[Kaboom] --ci requires --policy <file>\n")
+		return ciGateExitError
+	}
+	policy, err := loadCIGatePolicy(opts.policyPath)
+	if err != nil {
+		stderrf("[Kaboom] Cannot load CI policy %s: %v\n", opts.policyPath, err)
+		return ciGateExitError
+	}
+
+	// No log file: a CI run keeps telemetry in memory and reports through --ci-report.
+	server, err := NewServer("", opts.maxEntries)
+	if err != nil {
+		stderrf("[Kaboom] Error creating server: %v\n", err)
+		return ciGateExitError
+	}
+	server.setListenPort(opts.port)
+	cap := initCapture(server, opts.port)
+	mux, mcpHandler := setupHTTPRoutes(server, cap)
+	th := mcpHandler.toolHandler.(*ToolHandler)
+	defer th.Close()
+	defer cap.Close()
+
+	forbidden, err := th.registerCIGateInvariants(policy.ForbiddenDomains)
+	if err != nil {
+		stderrf("[Kaboom] Invalid CI policy: %v\n", err)
+		return ciGateExitError
+	}
+
+	waiter := newCIGateWaiter()
+	waiter.watchTestBoundaries(cap, opts.testID)
+	if opts.duration > 0 {
+		timer := time.AfterFunc(opts.duration, func() { waiter.stop("duration") })
+		defer timer.Stop()
+	}
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(sigCh)
+
+	if err := preflightPortCheck(server, opts.port); err != nil {
+		stderrf("[Kaboom] %v\n", err)
+		return ciGateExitError
+	}
+	srv, httpDone, err := startHTTPServer(server, opts.port, opts.apiKey, mux)
+	if err != nil {
+		stderrf("[Kaboom] %v\n", err)
+		return ciGateExitError
+	}
+	started := time.Now()
+	stderrf("[Kaboom] CI mode listening on port %d; %s\n", opts.port, describeCIGateWait(opts))
+
+	var stoppedBy string
+	select {
+	case stoppedBy = <-waiter.done:
+		time.Sleep(ciGateSettleDelay)
+	case <-sigCh:
+		stoppedBy = "signal"
+	case <-httpDone:
+		stderrf("[Kaboom] HTTP listener exited unexpectedly\n")
+		return ciGateExitError
+	}
+
+	_, _, pageURL := cap.GetTrackingStatus()
+	report := buildCIGateReport(th.evaluateCIGatePolicy(policy, forbidden), opts.policyPath, stoppedBy, pageURL, started, time.Now())
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), httpShutdownTimeout)
+	defer cancel()
+	_ = srv.Shutdown(shutdownCtx)
+
+	if err := writeCIGateReport(report, opts.reportPath); err != nil {
+		stderrf("[Kaboom] Cannot write CI report %s: %v\n", opts.reportPath, err)
+		return ciGateExitError
+	}
+	stderrf("[Kaboom] CI %s: %d check(s), %d failed, %d skipped (stopped by %s). Report: %s\n",
+		report.Verdict, report.Tests, report.Failures, report.Skipped, stoppedBy, opts.reportPath)
+	if report.Verdict != ciGateVerdictPass {
+		return ciGateExitViolation
+	}
+	return ciGateExitPass
+}
+
+func describeCIGateWait(opts ciGateOptions) string {
+	wait := "waiting for a test boundary or run_end"
+	if opts.testID != "" {
+		wait = "waiting for test boundary " + opts.testID
+	}
+	if opts.duration > 0 {
+		wait += " (at most " + opts.duration.String() + ")"
+	}
+	return wait
+}
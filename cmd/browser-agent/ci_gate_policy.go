@@ -0,0 +1,236 @@
+// Purpose: Loads the --ci policy file and evaluates it against captured telemetry as JUnit-style checks.
+// Why: A declarative policy turns a headless capture run into a pass/fail CI gate without an agent.
+// Docs: docs/features/feature/ci-gate/index.md
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/export"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/invariants"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/security"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/tools/observe"
+)
+
+// maxCIGatePolicyBytes bounds the policy file.
+const maxCIGatePolicyBytes = 1 << 20
+
+// CI gate verdicts.
+const (
+	ciGateVerdictPass = "pass"
+	ciGateVerdictFail = "fail"
+)
+
+// ciGatePolicy is the --policy file. Unset checks are not evaluated.
+type ciGatePolicy struct {
+	// MaxErrors is the number of console errors (known noise excluded) the run may log.
+	MaxErrors *int `json:"max_errors,omitempty"`
+	// Budget bounds page-load vitals, as in generate(what="junit").
+	Budget *observe.VitalsBudget `json:"budget,omitempty"`
+	// ForbiddenDomains fail the run on any request or WebSocket to the host or its subdomains.
+	ForbiddenDomains []string `json:"forbidden_domains,omitempty"`
+	// RequireCSP fails the run when the page serves no CSP or the served CSP blocks observed loads.
+	RequireCSP bool `json:"require_csp,omitempty"`
+}
+
+// loadCIGatePolicy reads and validates a policy file. Unknown fields are rejected so typos
+// do not silently disable a check.
+func loadCIGatePolicy(path string) (ciGatePolicy, error) {
+	var policy ciGatePolicy
+	// #nosec G304 -- operator-supplied --policy path
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return policy, err
+	}
+	if len(data) > maxCIGatePolicyBytes {
+		return policy, fmt.Errorf("policy file exceeds %d bytes", maxCIGatePolicyBytes)
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&policy); err != nil {
+		return policy, fmt.Errorf("invalid policy JSON: %w", err)
+	}
+	return policy, policy.validate()
+}
+
+func (p ciGatePolicy) validate() error {
+	if p.MaxErrors == nil && p.Budget == nil && len(p.ForbiddenDomains) == 0 && !p.RequireCSP {
+		return errors.New("policy checks nothing; set max_errors, budget, forbidden_domains, or require_csp")
+	}
+	if p.MaxErrors != nil && *p.MaxErrors < 0 {
+		return errors.New("max_errors must be >= 0")
+	}
+	for _, domain := range p.ForbiddenDomains {
+		if _, err := invariants.Parse(ciGateForbiddenExpr(domain)); err != nil || strings.TrimSpace(domain) == "" {
+			return fmt.Errorf("invalid forbidden domain %q", domain)
+		}
+	}
+	return nil
+}
+
+func ciGateForbiddenExpr(domain string) string {
+	return "no network request to " + strings.TrimSpace(domain)
+}
+
+// ciGateForbidden maps a forbidden domain to the invariants watching it.
+type ciGateForbidden struct {
+	Domain       string
+	InvariantIDs []string
+}
+
+// registerCIGateInvariants watches each forbidden domain with request and WebSocket invariants,
+// so matches are recorded at ingest even if the request later leaves the ring buffers.
+func (h *ToolHandler) registerCIGateInvariants(domains []string) ([]ciGateForbidden, error) {
+	out := make([]ciGateForbidden, 0, len(domains))
+	for _, domain := range domains {
+		domain = strings.TrimSpace(domain)
+		entry := ciGateForbidden{Domain: domain}
+		for _, expr := range []string{ciGateForbiddenExpr(domain), "no websocket to " + domain} {
+			inv, err := h.invariantMonitor.Add(expr, invariants.ScopeSession, "", time.Now())
+			if err != nil {
+				return nil, fmt.Errorf("forbidden domain %q: %w", domain, err)
+			}
+			entry.InvariantIDs = append(entry.InvariantIDs, inv.ID)
+		}
+		out = append(out, entry)
+	}
+	return out, nil
+}
+
+// ciGateReport is the JSON report written at the end of a --ci run.
+type ciGateReport struct {
+	Verdict    string           `json:"verdict"`
+	StoppedBy  string           `json:"stopped_by"`
+	Policy     string           `json:"policy"`
+	PageURL    string           `json:"page_url,omitempty"`
+	StartedAt  string           `json:"started_at"`
+	DurationMs int64            `json:"duration_ms"`
+	Tests      int              `json:"tests"`
+	Failures   int              `json:"failures"`
+	Skipped    int              `json:"skipped"`
+	Checks     []map[string]any `json:"checks"`
+	Version    string           `json:"kaboom_version"`
+
+	junit export.JUnitReport
+}
+
+// evaluateCIGatePolicy runs every configured check against the current capture state.
+// Skipped checks (no page load for a budget) do not fail the gate.
+func (h *ToolHandler) evaluateCIGatePolicy(policy ciGatePolicy, forbidden []ciGateForbidden) []export.JUnitTestCase {
+	var cases []export.JUnitTestCase
+	if policy.MaxErrors != nil {
+		limit := *policy.MaxErrors
+		items := h.consoleErrorMessages()
+		message := fmt.Sprintf("%d console error(s); the policy allows %d", len(items), limit)
+		if len(items) <= limit {
+			items = nil
+		}
+		cases = append(cases, junitCase("kaboom.console", fmt.Sprintf("console_errors_at_most_%d", limit), "ConsoleError", items, message))
+	}
+	if policy.Budget != nil {
+		cases = append(cases, junitVitalsBudgets(h.capture.GetPerformanceSnapshots(), *policy.Budget)...)
+	}
+	for _, f := range forbidden {
+		var items []string
+		for _, id := range f.InvariantIDs {
+			for _, v := range h.invariantMonitor.Violations(id, 0) {
+				subject := strings.TrimSpace(v.Evidence.Method + " " + v.Evidence.URL)
+				if v.Kind == invariants.KindWebSocket {
+					subject = "WebSocket " + v.Evidence.URL
+				}
+				items = append(items, fmt.Sprintf("%s (%d time(s))", subject, v.Count))
+			}
+		}
+		cases = append(cases, junitCase("kaboom.network", "no_requests_to "+f.Domain, "ForbiddenDomain", items,
+			fmt.Sprintf("%d distinct request(s) to forbidden domain %s", len(items), f.Domain)))
+	}
+	if policy.RequireCSP {
+		cases = append(cases, h.ciGateCSPCase())
+	}
+	return cases
+}
+
+// ciGateCSPCase fails when the tracked page serves no CSP or its CSP blocks observed loads.
+func (h *ToolHandler) ciGateCSPCase() export.JUnitTestCase {
+	const className, name = "kaboom.security", "csp_compliant"
+	_, _, tabURL := h.capture.GetTrackingStatus()
+	result := security.DiffCSP(security.CSPDiffInput{
+		PageURL:   tabURL,
+		Bodies:    h.capture.GetNetworkBodies(),
+		Waterfall: h.capture.GetNetworkWaterfallEntries(),
+	})
+	switch result.Status {
+	case security.CSPDiffNoPolicy:
+		return junitCase(className, name, "MissingCSP", result.Warnings, "The page serves no Content-Security-Policy")
+	case security.CSPDiffWouldBreak:
+		items := make([]string, 0, len(result.Missing))
+		for _, m := range result.Missing {
+			items = append(items, fmt.Sprintf("%s %s (governed by %s), e.g. %s", m.Directive, m.Source, m.GoverningDirective, strings.Join(m.Examples, ", ")))
+		}
+		return junitCase(className, name, "CSPViolation", items,
+			fmt.Sprintf("The served CSP blocks %d observed source(s)", len(items)))
+	}
+	return export.JUnitTestCase{Name: name, ClassName: className}
+}
+
+// buildCIGateReport summarizes evaluated cases. The gate fails on any failed case.
+func buildCIGateReport(cases []export.JUnitTestCase, policyPath, stoppedBy, pageURL string, started, now time.Time) ciGateReport {
+	var properties []export.JUnitProperty
+	if pageURL != "" {
+		properties = append(properties, export.JUnitProperty{Name: "page_url", Value: pageURL})
+	}
+	properties = append(properties,
+		export.JUnitProperty{Name: "kaboom_version", Value: version},
+		export.JUnitProperty{Name: "stopped_by", Value: stoppedBy})
+	junit := export.BuildJUnitReport("kaboom-ci", cases, properties, now)
+
+	checks := junitCheckSummaries(cases)
+	for i, c := range cases {
+		if c.Failure != nil && c.Failure.Text != "" {
+			checks[i]["details"] = strings.Split(c.Failure.Text, "\n")
+		}
+	}
+	verdict := ciGateVerdictPass
+	if junit.Failures > 0 {
+		verdict = ciGateVerdictFail
+	}
+	return ciGateReport{
+		Verdict:    verdict,
+		StoppedBy:  stoppedBy,
+		Policy:     policyPath,
+		PageURL:    pageURL,
+		StartedAt:  started.UTC().Format(time.RFC3339),
+		DurationMs: now.Sub(started).Milliseconds(),
+		Tests:      junit.Tests,
+		Failures:   junit.Failures,
+		Skipped:    junit.Skipped,
+		Checks:     checks,
+		Version:    version,
+		junit:      junit,
+	}
+}
+
+// writeCIGateReport writes JUnit XML when path ends in .xml, JSON otherwise.
+func writeCIGateReport(report ciGateReport, path string) error {
+	var data []byte
+	var err error
+	if strings.EqualFold(filepath.Ext(path), ".xml") {
+		data, err = export.MarshalJUnit(report.junit)
+	} else {
+		data, err = json.MarshalIndent(report, "", "  ")
+		data = append(data, '\n')
+	}
+	if err != nil {
+		return err
+	}
+	// #nosec G306 -- CI report is a build artifact meant to be read by other pipeline steps
+	return os.WriteFile(path, data, 0o644)
+}
@@ -0,0 +1,190 @@
+// Purpose: Tests --ci policy loading, policy evaluation, report output, and the test-boundary stop signal.
+// Docs: docs/features/feature/ci-gate/index.md
+
+package main
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/export"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/performance"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/testevents"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/tools/observe"
+)
+
+func writeCIGatePolicy(t *testing.T, body string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "policy.json")
+	if err := os.WriteFile(path, []byte(body), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadCIGatePolicy_Validation(t *testing.T) {
+	t.Parallel()
+	policy, err := loadCIGatePolicy(writeCIGatePolicy(t, `{"max_errors":0,"budget":{"lcp_ms":2500},"forbidden_domains":["prod-api.com"],"require_csp":true}`))
+	if err != nil {
+		t.Fatalf("valid policy rejected: %v", err)
+	}
+	if *policy.MaxErrors != 0 || *policy.Budget.LCPMs != 2500 || policy.ForbiddenDomains[0] != "prod-api.com" || !policy.RequireCSP {
+		t.Fatalf("policy = %+v", policy)
+	}
+
+	for name, body := range map[string]string{
+		"unknown field":   `{"max_error":0}`,
+		"checks nothing":  `{}`,
+		"negative limit":  `{"max_errors":-1}`,
+		"domain w/ space": `{"forbidden_domains":["prod api.com"]}`,
+		"blank domain":    `{"forbidden_domains":[" "]}`,
+		"not json":        `max_errors: 0`,
+	} {
+		if _, err := loadCIGatePolicy(writeCIGatePolicy(t, body)); err == nil {
+			t.Errorf("%s: policy %s should be rejected", name, body)
+		}
+	}
+	if _, err := loadCIGatePolicy(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("missing policy file should be an error")
+	}
+}
+
+func TestCIGatePolicy_FailsOnViolations(t *testing.T) {
+	t.Parallel()
+	h, server, cap := makeToolHandler(t)
+	ts := time.Now().UTC().Format(time.RFC3339Nano)
+	maxErrors := 1
+	lcpBudget := 2500.0
+	policy := ciGatePolicy{
+		MaxErrors:        &maxErrors,
+		Budget:           &observe.VitalsBudget{LCPMs: &lcpBudget},
+		ForbiddenDomains: []string{"prod-api.com"},
+		RequireCSP:       true,
+	}
+	forbidden, err := h.registerCIGateInvariants(policy.ForbiddenDomains)
+	if err != nil {
+		t.Fatalf("registerCIGateInvariants: %v", err)
+	}
+
+	server.logs.addEntries([]LogEntry{
+		{"level": "error", "message": "TypeError: cart is undefined", "ts": ts},
+		{"level": "error", "message": "Failed to load checkout", "ts": ts},
+	})
+	cap.AddNetworkBodies([]capture.NetworkBody{
+		{Timestamp: ts, Method: "POST", URL: "https://api.prod-api.com/orders", Status: 201},
+		{Timestamp: ts, Method: "GET", URL: "http://localhost:5173/", Status: 200, ContentType: "text/html", ResponseBody: "<html></html>"},
+	})
+	slow := 4100.0
+	cap.AddPerformanceSnapshots([]capture.PerformanceSnapshot{
+		{URL: "http://localhost:5173/", Timestamp: ts, Timing: performance.PerformanceTiming{LargestContentfulPaint: &slow}},
+	})
+
+	cases := h.evaluateCIGatePolicy(policy, forbidden)
+	report := buildCIGateReport(cases, "policy.json", "test_boundary", "", time.Now().Add(-time.Minute), time.Now())
+	if report.Verdict != ciGateVerdictFail || report.Tests != 4 || report.Failures != 4 {
+		t.Fatalf("report = %s %d/%d, want fail with 4/4 failed: %v", report.Verdict, report.Tests, report.Failures, report.Checks)
+	}
+	byName := map[string]map[string]any{}
+	for _, c := range report.Checks {
+		byName[c["name"].(string)] = c
+	}
+	if msg := byName["console_errors_at_most_1"]["message"]; msg != "2 console error(s); the policy allows 1" {
+		t.Errorf("console message = %v", msg)
+	}
+	details, _ := byName["no_requests_to prod-api.com"]["details"].([]string)
+	if len(details) != 1 || !strings.Contains(details[0], "POST https://api.prod-api.com/orders") {
+		t.Errorf("forbidden details = %v", details)
+	}
+	if byName["csp_compliant"]["status"] != "failed" || byName["vitals_within_budget http://localhost:5173/"]["status"] != "failed" {
+		t.Errorf("csp and budget checks should fail: %v", report.Checks)
+	}
+}
+
+func TestCIGatePolicy_CleanRunPassesAndWritesReports(t *testing.T) {
+	t.Parallel()
+	h, _, cap := makeToolHandler(t)
+	maxErrors := 0
+	policy := ciGatePolicy{MaxErrors: &maxErrors, ForbiddenDomains: []string{"prod-api.com"}, RequireCSP: true}
+	forbidden, err := h.registerCIGateInvariants(policy.ForbiddenDomains)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cap.AddNetworkBodies([]capture.NetworkBody{
+		{Method: "GET", URL: "http://localhost:5173/", Status: 200, ContentType: "text/html", ResponseBody: "<html></html>",
+			ResponseHeaders: map[string]string{"Content-Security-Policy": "default-src 'self' http://localhost:5173"}},
+		{Method: "GET", URL: "http://localhost:5173/api/cart", Status: 200},
+	})
+
+	report := buildCIGateReport(h.evaluateCIGatePolicy(policy, forbidden), "policy.json", "duration", "http://localhost:5173/", time.Now(), time.Now())
+	if report.Verdict != ciGateVerdictPass || report.Failures != 0 || report.Tests != 3 {
+		t.Fatalf("report = %+v, want pass with 3 checks", report)
+	}
+
+	dir := t.TempDir()
+	jsonPath, xmlPath := filepath.Join(dir, "ci.json"), filepath.Join(dir, "ci.xml")
+	if err := writeCIGateReport(report, jsonPath); err != nil {
+		t.Fatal(err)
+	}
+	if err := writeCIGateReport(report, xmlPath); err != nil {
+		t.Fatal(err)
+	}
+	raw, _ := os.ReadFile(jsonPath)
+	var decoded map[string]any
+	if err := json.Unmarshal(raw, &decoded); err != nil || decoded["verdict"] != "pass" || decoded["stopped_by"] != "duration" {
+		t.Fatalf("json report = %s (err %v)", raw, err)
+	}
+	raw, _ = os.ReadFile(xmlPath)
+	var junit export.JUnitReport
+	if err := xml.Unmarshal(raw, &junit); err != nil || junit.Tests != 3 || junit.Name != "kaboom-ci" {
+		t.Fatalf("xml report = %s (err %v)", raw, err)
+	}
+}
+
+func TestCIGateWaiter_StopsOnBoundaryOrRunEnd(t *testing.T) {
+	t.Parallel()
+	wait := func(w *ciGateWaiter) string {
+		select {
+		case reason := <-w.done:
+			return reason
+		case <-time.After(time.Second):
+			return ""
+		}
+	}
+
+	_, _, cap := makeToolHandler(t)
+	named := newCIGateWaiter()
+	named.watchTestBoundaries(cap, "checkout")
+	cap.SetTestBoundaryStart("login")
+	cap.SetTestBoundaryEnd("login")
+	cap.SetTestBoundaryStart("checkout")
+	cap.SetTestBoundaryEnd("checkout")
+	if reason := wait(named); reason != "test_boundary" {
+		t.Fatalf("named waiter stopped by %q, want test_boundary", reason)
+	}
+
+	// With a test runner reporting per-test events, only run_end stops the gate.
+	_, _, cap = makeToolHandler(t)
+	runner := newCIGateWaiter()
+	runner.watchTestBoundaries(cap, "")
+	result := cap.TestEvents().Ingest([]testevents.Event{
+		{Type: testevents.TypeTestStart, TestID: "t1", Timestamp: time.Now()},
+		{Type: testevents.TypeTestEnd, TestID: "t1", Status: testevents.StatusPassed, Timestamp: time.Now()},
+	})
+	cap.SetTestBoundaryStart("t1")
+	cap.SetTestBoundaryEnd(result.Ended[0])
+	select {
+	case reason := <-runner.done:
+		t.Fatalf("per-test end should not stop the gate, got %q", reason)
+	default:
+	}
+	cap.EndTestRun("")
+	if reason := wait(runner); reason != "test_run_end" {
+		t.Fatalf("runner waiter stopped by %q, want test_run_end", reason)
+	}
+}
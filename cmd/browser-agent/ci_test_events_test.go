@@ -0,0 +1,99 @@
+// Purpose: Tests POST /test-events ingestion, boundary tagging, and test-scoped timeline attribution.
+// Docs: docs/features/feature/test-runner-events/index.md
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+)
+
+func postTestEvents(t *testing.T, handler http.HandlerFunc, body string) (int, map[string]any) {
+	t.Helper()
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/test-events", bytes.NewBufferString(body)))
+	var resp map[string]any
+	_ = json.Unmarshal(rr.Body.Bytes(), &resp)
+	return rr.Code, resp
+}
+
+func TestTestEvents_SegmentTimelineByTest(t *testing.T) {
+	t.Parallel()
+	h, server, cap := makeToolHandler(t)
+	handler := handleTestEvents(cap)
+	now := time.Now()
+	ts := func(offset time.Duration) string { return now.Add(offset).UTC().Format(time.RFC3339Nano) }
+
+	code, resp := postTestEvents(t, handler, `{"events":[
+		{"type":"run_start","runner":"vitest","run_id":"r1","timestamp":"`+ts(-20*time.Second)+`"},
+		{"type":"test_start","runner":"vitest","run_id":"r1","file":"cart.test.ts","suite":"cart","name":"adds item","timestamp":"`+ts(-10*time.Second)+`"}]}`)
+	if code != http.StatusOK || resp["accepted"] != float64(2) {
+		t.Fatalf("start batch: %d %v", code, resp)
+	}
+	const testID = "cart.test.ts > cart > adds item"
+	active := strings.Join(cap.GetActiveTestIDs(), ",")
+	if active != testID {
+		t.Fatalf("active test IDs = %q, want %q", active, testID)
+	}
+
+	cap.AddEnhancedActions([]capture.EnhancedAction{{Type: "click", Timestamp: now.Add(-5 * time.Second).UnixMilli(), Selectors: map[string]any{"css": "#add"}}})
+	server.logs.addEntries([]LogEntry{{"level": "error", "message": "TypeError: qty is NaN", "ts": ts(-4 * time.Second)}})
+	cap.AddEnhancedActions([]capture.EnhancedAction{{Type: "click", Timestamp: now.Add(-30 * time.Second).UnixMilli(), Selectors: map[string]any{"css": "#before"}}})
+
+	code, resp = postTestEvents(t, handler, `{"type":"test_end","file":"cart.test.ts","suite":"cart","name":"adds item","status":"fail","error":{"message":"expected 2 items"},"timestamp":"`+ts(-1*time.Second)+`"}`)
+	if code != http.StatusOK || resp["summary"].(map[string]any)["failed"] != float64(1) {
+		t.Fatalf("end event: %d %v", code, resp)
+	}
+	if len(cap.GetActiveTestIDs()) != 0 {
+		t.Fatalf("test_end should close the boundary, active = %v", cap.GetActiveTestIDs())
+	}
+
+	req := JSONRPCRequest{JSONRPC: "2.0", ID: 1}
+	result := parseToolResult(t, h.toolObserve(req, json.RawMessage(`{"what":"timeline","test_id":"`+testID+`"}`)))
+	if result.IsError {
+		t.Fatalf("timeline failed: %s", firstText(result))
+	}
+	data := extractResultJSON(t, result)
+	if seg := data["test"].(map[string]any); seg["status"] != "failed" || seg["error"].(map[string]any)["message"] != "expected 2 items" {
+		t.Fatalf("test segment = %v", seg)
+	}
+	types := map[string]int{}
+	for _, raw := range data["entries"].([]any) {
+		entry := raw.(map[string]any)
+		if entry["test"] != testID {
+			t.Errorf("entry not attributed to the test: %v", entry)
+		}
+		types[entry["type"].(string)]++
+	}
+	if types["action"] != 1 || types["error"] != 1 || types["test"] != 2 {
+		t.Errorf("entry types in test window = %v, want 1 action, 1 error, 2 test events", types)
+	}
+
+	// Without test_id, every test event is interleaved and totals are reported.
+	data = extractResultJSON(t, parseToolResult(t, h.toolObserve(req, json.RawMessage(`{"what":"timeline","include":["tests"]}`))))
+	if data["count"] != float64(3) || data["tests"].(map[string]any)["tests"] != float64(1) {
+		t.Errorf("tests-only timeline = %v", data)
+	}
+}
+
+func TestTestEvents_RejectsInvalidPayloads(t *testing.T) {
+	t.Parallel()
+	handler := handleTestEvents(capture.NewCapture())
+	for _, body := range []string{`not json`, `{"type":"test_start"}`, `{"type":"hook_start","name":"x"}`} {
+		if code, _ := postTestEvents(t, handler, body); code != http.StatusBadRequest {
+			t.Errorf("POST %s status = %d, want 400", body, code)
+		}
+	}
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/test-events", nil))
+	if rr.Code != http.StatusMethodNotAllowed {
+		t.Errorf("GET status = %d, want 405", rr.Code)
+	}
+}
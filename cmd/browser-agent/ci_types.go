@@ -3,7 +3,10 @@
 
 package main
 
-import "github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+import (
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/types"
+)
 
 // SnapshotResponse is the aggregated state returned by GET /snapshot.
 type SnapshotResponse struct {
@@ -14,6 +17,7 @@ type SnapshotResponse struct {
 	NetworkBodies   []capture.NetworkBody    `json:"network_bodies"`
 	EnhancedActions []capture.EnhancedAction `json:"enhanced_actions,omitempty"`
 	Stats           SnapshotStats            `json:"stats"`
+	SourceControl   *types.SourceControl     `json:"source_control,omitempty"`
 }
 
 // SnapshotStats summarizes the snapshot contents.
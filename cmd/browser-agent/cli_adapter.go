@@ -18,5 +18,9 @@ func cliRuntimeConfig() cli.RuntimeConfig {
 		IsServerRunning:    bridge.IsServerRunning,
 		WaitForServer:      func(port int, timeout time.Duration) bool { return bridge.WaitForServer(port, timeout) },
 		DaemonProcessArgv0: daemonProcessArgv0,
+		StopDaemon:         runStopMode,
+		DaemonPID:          readPIDFile,
+		ProcessAlive:       isProcessAlive,
+		DefaultLogFile:     resolveLogFile,
 	}
 }
@@ -7,6 +7,7 @@ package main
 import (
 	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
 	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/session"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/types"
 )
 
 // sessionClientRegistryAdapter bridges session.ClientRegistry to capture.ClientRegistry.
@@ -42,3 +43,11 @@ func (a *sessionClientRegistryAdapter) Get(id string) any {
 func (a *sessionClientRegistryAdapter) Unregister(id string) bool {
 	return a.reg.Unregister(id)
 }
+
+func (a *sessionClientRegistryAdapter) SetSourceControl(id string, sc types.SourceControl) bool {
+	return a.reg.SetSourceControl(id, sc)
+}
+
+func (a *sessionClientRegistryAdapter) SourceControl(id string) *types.SourceControl {
+	return a.reg.SourceControl(id)
+}
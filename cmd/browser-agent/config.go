@@ -7,9 +7,13 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/cmd/browser-agent/internal/uploadhandler"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/queries"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/statevault"
 )
 
 // multiFlag implements flag.Value for repeatable string flags (e.g., --upload-deny-pattern).
@@ -45,15 +49,28 @@ const (
 type parsedFlags struct {
 	port, maxEntries                                                     *int
 	fastPathMinSamples                                                   *int
+	toolRateLimit, toolQuota                                             *int
 	logFile, apiKey, clientID, stateDir, uploadDir                       *string
+	listen                                                               *string
+	serveBundle                                                          *string
+	ciPolicy, ciReport, ciTestID                                         *string
+	recordProtocol, replay, mockBrowser                                  *string
+	stateKey                                                             *string
+	extensionRetry                                                       *string
+	ciDuration                                                           *time.Duration
 	fastPathMaxFailureRatio                                              *float64
 	showVersion, showHelp, checkSetup, doctorMode, stopMode, connectMode *bool
 	bridgeMode, daemonMode, enableOsUploadAutomation                     *bool
-	parallelMode                                                         *bool
+	enableCDPPassthrough                                                 *bool
+	parallelMode, autoPort                                               *bool
 	forceCleanup                                                         *bool
 	installMode                                                          *bool
+	ciMode                                                               *bool
 	uploadDenyPatterns                                                   multiFlag
 	ssrfAllowedHosts                                                     multiFlag
+	cdpAllowMethods                                                      multiFlag
+	retention                                                            multiFlag
+	remote                                                               *remoteFlags
 }
 
 // registerFlags defines all CLI flags and returns the parsed values.
@@ -71,23 +88,50 @@ func registerFlags() *parsedFlags {
 	f.doctorMode = flag.Bool("doctor", false, "Run full diagnostics (alias of --check)")
 	f.stopMode = flag.Bool("stop", false, "Stop the running server on the specified port")
 	f.connectMode = flag.Bool("connect", false, "Connect to existing server (multi-client mode)")
+	f.listen = flag.String("listen", os.Getenv("KABOOM_LISTEN"), "unix:/path/kaboom.sock serves the daemon HTTP endpoint on a Unix socket with no TCP port; with --connect, dials it (or KABOOM_LISTEN env)")
 	f.clientID = flag.String("client-id", "", "Override client ID (default: derived from CWD)")
 	f.bridgeMode = flag.Bool("bridge", false, "Run as stdio-to-HTTP bridge (spawns daemon if needed)")
 	f.daemonMode = flag.Bool("daemon", false, "Run as background server daemon (internal use)")
 	f.parallelMode = flag.Bool("parallel", false, "Enable isolated parallel daemon mode (skip takeover; requires unique port/state-dir)")
+	f.autoPort = flag.Bool("auto-port", truthyEnv("KABOOM_AUTO_PORT"), "Without --port, pick a free port and a project-scoped state dir when another project holds the default (or KABOOM_AUTO_PORT env)")
 	f.stateDir = flag.String("state-dir", "", "Directory for runtime state (default: OS app state directory)")
 	f.enableOsUploadAutomation = flag.Bool("enable-os-upload-automation", false, "Enable OS-level file upload automation (Stage 4: AppleScript/xdotool)")
 	f.uploadDir = flag.String("upload-dir", "", "Directory from which file uploads are allowed (required for Stages 2-4)")
 	f.forceCleanup = flag.Bool("force", false, "Force kill all running kaboom daemons (used during install to ensure clean upgrade)")
 	f.installMode = flag.Bool("install", false, "Auto-install Kaboom to all detected MCP clients")
+	f.serveBundle = flag.String("serve-bundle", "", "Serve MCP over stdio in read-only mode against a session bundle (no extension required)")
+	f.ciMode = flag.Bool("ci", false, "Run headless as a CI gate: capture until a test boundary ends or --ci-duration elapses, then evaluate --policy and exit non-zero on violation")
+	f.ciPolicy = flag.String("policy", "", "CI gate policy JSON file (max_errors, budget, forbidden_domains, require_csp)")
+	f.ciReport = flag.String("ci-report", "kaboom-ci-report.json", "CI gate report path (.xml writes JUnit, otherwise JSON)")
+	f.ciTestID = flag.String("ci-test-id", "", "Stop the CI gate when this test boundary ends (default: first boundary end or test run_end)")
+	f.ciDuration = flag.Duration("ci-duration", 0, "Stop the CI gate after this long (e.g. 90s); 0 waits for a test boundary only")
+	f.recordProtocol = flag.String("record-protocol", os.Getenv("KABOOM_RECORD_PROTOCOL"), "Append extension protocol traffic (commands, results, settings) to this JSONL file (or KABOOM_RECORD_PROTOCOL env)")
+	f.replay = flag.String("replay", os.Getenv("KABOOM_REPLAY"), "Simulate the extension by answering commands from a --record-protocol recording (or KABOOM_REPLAY env)")
+	f.mockBrowser = flag.String("mock-browser", os.Getenv("KABOOM_MOCK_BROWSER"), "Simulate the extension by answering DOM, a11y, and interact commands from an HTML or JSON DOM snapshot fixture (or KABOOM_MOCK_BROWSER env)")
+	f.stateKey = flag.String("state-key", os.Getenv("KABOOM_STATE_KEY"), "Key that encrypts saved login state for save_state/load_state vault=true: a base64 32-byte key or a passphrase of 16+ characters (or KABOOM_STATE_KEY env, preferred)")
+	f.extensionRetry = flag.String("extension-retry", os.Getenv("KABOOM_EXTENSION_RETRY"), "Re-offer commands the extension has not picked up: attempts=N,backoff=D,max_backoff=D,jitter=F, or off (default attempts=3,backoff=1s,max_backoff=4s,jitter=0.2; or KABOOM_EXTENSION_RETRY env)")
+	f.toolRateLimit = flag.Int("tool-rate-limit", envInt("KABOOM_TOOL_RATE_LIMIT", defaultToolCallsPerMinute), "Max tool calls per minute per client, 0 = unlimited (or KABOOM_TOOL_RATE_LIMIT env)")
+	f.enableCDPPassthrough = flag.Bool("enable-cdp-passthrough", truthyEnv("KABOOM_CDP_PASSTHROUGH"), "Allow interact(what='cdp') to send raw Chrome DevTools Protocol commands (or KABOOM_CDP_PASSTHROUGH env)")
+	f.toolQuota = flag.Int("tool-quota", envInt("KABOOM_TOOL_QUOTA", 0), "Max tool calls per hour per client, 0 = unlimited (or KABOOM_TOOL_QUOTA env)")
 	flag.Bool("mcp", false, "Run in MCP mode (default, kept for backwards compatibility)")
 	flag.Bool("persist", true, "Deprecated no-op (server persistence is default, kept for backwards compatibility)")
 	flag.Var(&f.uploadDenyPatterns, "upload-deny-pattern", "Additional sensitive path patterns to block (repeatable)")
+	flag.Var(&f.cdpAllowMethods, "cdp-allow-method", "CDP method or Domain.* pattern interact(what='cdp') may send (repeatable; default all)")
+	flag.Var(&f.retention, "retention", "Buffer retention as buffer=[max_entries][:ttl], e.g. network=500 or console=5000:30m (repeatable; buffers: console, network, websocket, actions, performance)")
+	f.remote = registerRemoteFlags()
 	flag.Var(&f.ssrfAllowedHosts, "ssrf-allow-host", "Host:port to allow for form submit SSRF (repeatable, test use)")
 	flag.Parse()
 	return f
 }
 
+// envInt reads an integer environment variable, falling back to def when unset or invalid.
+func envInt(name string, def int) int {
+	if v, err := strconv.Atoi(strings.TrimSpace(os.Getenv(name))); err == nil {
+		return v
+	}
+	return def
+}
+
 type setupCheckOptions struct {
 	minSamples      int
 	maxFailureRatio float64
@@ -98,14 +142,56 @@ func parseAndValidateFlags() *serverConfig {
 	f := registerFlags()
 
 	osUploadAutomationFlag = *f.enableOsUploadAutomation
+	toolCallLimitsConfig = ToolCallLimits{CallsPerMinute: max(*f.toolRateLimit, 0), HourlyQuota: max(*f.toolQuota, 0)}
+	cdpPassthroughConfig = CDPPassthroughPolicy{Enabled: *f.enableCDPPassthrough, AllowMethods: f.cdpAllowMethods}
+	retention, err := parseRetentionFlags(f.retention, *f.maxEntries)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "[Kaboom] Invalid --retention %v\n", err)
+		os.Exit(1)
+	}
+	retentionFlagConfig = retention
+	protocolRecordPath, protocolReplayPath = *f.recordProtocol, *f.replay
+	mockBrowserPath = *f.mockBrowser
+	if mockBrowserPath != "" && protocolReplayPath != "" {
+		fmt.Fprintln(os.Stderr, "[Kaboom] --mock-browser and --replay both simulate the extension; use one")
+		os.Exit(1)
+	}
+	if *f.extensionRetry != "" {
+		policy, err := queries.ParseRetryPolicy(*f.extensionRetry, queries.DefaultRetryPolicy())
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "[Kaboom] Invalid --extension-retry: %v\n", err)
+			os.Exit(1)
+		}
+		extensionRetryPolicy = policy
+	}
+	stateVaultKey = *f.stateKey
+	if stateVaultKey != "" {
+		if _, err := statevault.New(stateVaultKey, nil); err != nil {
+			fmt.Fprintf(os.Stderr, "[Kaboom] Invalid --state-key: %v\n", err)
+			os.Exit(1)
+		}
+	}
+	if err := applyRemoteFlags(f.remote, *f.apiKey); err != nil {
+		fmt.Fprintf(os.Stderr, "[Kaboom] Invalid remote mode: %v\n", err)
+		os.Exit(1)
+	}
+	if err := applyListenFlag(f); err != nil {
+		fmt.Fprintf(os.Stderr, "[Kaboom] Invalid --listen: %v\n", err)
+		os.Exit(1)
+	}
 	uploadhandler.SetSSRFAllowedHosts(f.ssrfAllowedHosts)
 	initUploadSecurity(*f.enableOsUploadAutomation, *f.uploadDir, f.uploadDenyPatterns)
 	validatePort(*f.port)
+	exportServerRegistryPath()
 	normalizeStateDir(f.stateDir)
 	if err := applyParallelModeStateDir(*f.parallelMode, f.stateDir); err != nil {
 		fmt.Fprintf(os.Stderr, "[Kaboom] Invalid --parallel setup: %v\n", err)
 		os.Exit(1)
 	}
+	if err := resolveProjectPort(f); err != nil {
+		fmt.Fprintf(os.Stderr, "[Kaboom] Port discovery failed: %v\n", err)
+		os.Exit(1)
+	}
 	handleEarlyExitModes(f)
 	resolveDefaultLogFile(f.logFile)
 
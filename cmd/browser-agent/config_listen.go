@@ -0,0 +1,47 @@
+// Purpose: Applies --listen unix:/path: the daemon serves HTTP on a Unix socket and connect mode dials it.
+// Why: Security-sensitive setups can run with no TCP port, leaving access to filesystem permissions.
+// Docs: docs/features/feature/unix-socket-transport/index.md
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/unixsock"
+)
+
+// applyListenFlag validates --listen and, for connect mode, points the connect target at the socket.
+func applyListenFlag(f *parsedFlags) error {
+	path, err := unixsock.ParseListen(*f.listen)
+	if err != nil {
+		return err
+	}
+	if path == "" {
+		return nil
+	}
+	if startsServer(f) && !*f.daemonMode {
+		return errors.New("the stdio bridge spawns a TCP daemon; run --daemon --listen unix:... and point MCP clients at --connect --listen unix:...")
+	}
+	if remoteAccessConfig.Enabled() {
+		return errors.New("--listen unix: and --remote-listen cannot be combined")
+	}
+	if connectRemoteURL != "" {
+		return errors.New("--listen unix: and --remote-url cannot be combined")
+	}
+	listenSocketPath = path
+	if *f.connectMode {
+		connectRemoteURL = unixsock.BaseURL
+		connectHTTPClient = unixsock.HTTPClient(path, 0)
+	}
+	return nil
+}
+
+// listenHTTP binds the daemon's HTTP listener: the --listen socket, or 127.0.0.1:port.
+func listenHTTP(port int) (net.Listener, error) {
+	if listenSocketPath != "" {
+		return unixsock.Listen(listenSocketPath)
+	}
+	return net.Listen("tcp", fmt.Sprintf("127.0.0.1:%d", port))
+}
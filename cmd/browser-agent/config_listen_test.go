@@ -0,0 +1,105 @@
+// Purpose: Tests --listen unix: validation, the daemon HTTP listener on a socket, and connect mode dialing it.
+// Docs: docs/features/feature/unix-socket-transport/index.md
+
+package main
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/remoteaccess"
+)
+
+// listenTestFlags returns parsedFlags with every field the --listen validation reads.
+func listenTestFlags(listen string, daemon, connect bool) *parsedFlags {
+	f := &parsedFlags{}
+	for _, p := range []**bool{&f.showVersion, &f.showHelp, &f.forceCleanup, &f.checkSetup, &f.doctorMode, &f.stopMode, &f.installMode, &f.ciMode} {
+		*p = new(bool)
+	}
+	f.daemonMode, f.connectMode = &daemon, &connect
+	f.serveBundle, f.listen = new(string), &listen
+	return f
+}
+
+// withListenState restores the listener and connect-target globals after a test.
+func withListenState(t *testing.T) {
+	t.Helper()
+	oldSocket, oldURL, oldClient, oldRemote := listenSocketPath, connectRemoteURL, connectHTTPClient, remoteAccessConfig
+	t.Cleanup(func() {
+		listenSocketPath, connectRemoteURL, connectHTTPClient, remoteAccessConfig = oldSocket, oldURL, oldClient, oldRemote
+	})
+}
+
+// shortSocketPath keeps the path under the ~104-byte sun_path limit on macOS.
+func shortSocketPath(t *testing.T) string {
+	t.Helper()
+	dir, err := os.MkdirTemp("", "kl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+	return filepath.Join(dir, "k.sock")
+}
+
+func TestApplyListenFlagValidation(t *testing.T) {
+	withListenState(t)
+
+	if err := applyListenFlag(listenTestFlags("", false, false)); err != nil || listenSocketPath != "" {
+		t.Fatalf("no --listen: err=%v socket=%q", err, listenSocketPath)
+	}
+	if err := applyListenFlag(listenTestFlags("127.0.0.1:7890", true, false)); err == nil {
+		t.Error("expected a non-unix spec to be rejected")
+	}
+	if err := applyListenFlag(listenTestFlags("unix:/tmp/k.sock", false, false)); err == nil || !strings.Contains(err.Error(), "bridge") {
+		t.Errorf("bridge mode: err = %v, want bridge rejection", err)
+	}
+	remoteAccessConfig = &remoteaccess.Config{ListenAddr: "0.0.0.0:7443"}
+	if err := applyListenFlag(listenTestFlags("unix:/tmp/k.sock", true, false)); err == nil {
+		t.Error("expected --listen unix: with --remote-listen to be rejected")
+	}
+	remoteAccessConfig = nil
+	if err := applyListenFlag(listenTestFlags("unix:/tmp/k.sock", true, false)); err != nil || listenSocketPath != "/tmp/k.sock" {
+		t.Errorf("daemon mode: err=%v socket=%q", err, listenSocketPath)
+	}
+	if connectRemoteURL != "" {
+		t.Error("daemon mode must not change the connect target")
+	}
+}
+
+func TestDaemonAndConnectOverSocket(t *testing.T) {
+	withListenState(t)
+	path := shortSocketPath(t)
+	if err := applyListenFlag(listenTestFlags("unix:"+path, true, false)); err != nil {
+		t.Fatal(err)
+	}
+
+	server := newTestServerForHandlers(t)
+	mux := http.NewServeMux()
+	mux.HandleFunc("/health", corsMiddleware(func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusOK) }))
+	srv, _, err := startHTTPServer(server, 0, "", mux)
+	if err != nil {
+		t.Fatalf("startHTTPServer on socket: %v", err)
+	}
+	t.Cleanup(func() { _ = srv.Shutdown(context.Background()) })
+	info, err := os.Stat(path)
+	if err != nil || info.Mode()&os.ModeSocket == 0 {
+		t.Fatalf("socket not created at %s: %v", path, err)
+	}
+
+	if err := applyListenFlag(listenTestFlags("unix:"+path, false, true)); err != nil {
+		t.Fatal(err)
+	}
+	req, _ := http.NewRequest(http.MethodGet, connectRemoteURL+"/health", nil)
+	resp, err := connectDo(req)
+	if err != nil {
+		t.Fatalf("connect over socket: %v", err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("health over socket = %d, want 200", resp.StatusCode)
+	}
+}
@@ -9,7 +9,7 @@ import (
 	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/session"
 )
 
-// handleEarlyExitModes handles --version, --help, --force, --check/--doctor, --stop, --connect.
+// handleEarlyExitModes handles --version, --help, --force, --check/--doctor, --stop, --install, --serve-bundle, --ci, --connect.
 // Calls os.Exit for any matched mode; returns normally if none matched.
 func handleEarlyExitModes(f *parsedFlags) {
 	if *f.showVersion {
@@ -42,6 +42,20 @@ func handleEarlyExitModes(f *parsedFlags) {
 		runNativeInstall()
 		os.Exit(0)
 	}
+	if *f.serveBundle != "" {
+		os.Exit(runServeBundleMode(*f.serveBundle, *f.maxEntries, os.Stdin, os.Stdout))
+	}
+	if *f.ciMode {
+		os.Exit(runCIGateMode(ciGateOptions{
+			port:       *f.port,
+			apiKey:     *f.apiKey,
+			maxEntries: *f.maxEntries,
+			policyPath: *f.ciPolicy,
+			reportPath: *f.ciReport,
+			testID:     *f.ciTestID,
+			duration:   *f.ciDuration,
+		}))
+	}
 	if *f.connectMode {
 		cwd, _ := os.Getwd()
 		id := *f.clientID
@@ -0,0 +1,124 @@
+// Purpose: Resolves the daemon port for the current project from servers.json and registers/unregisters running daemons.
+// Why: Two frontends each running a daemon collide on 7890; clients must find their project's server by CWD.
+// Docs: docs/features/feature/port-discovery/index.md
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/cmd/browser-agent/internal/bridge"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/serverregistry"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/state"
+)
+
+// exportServerRegistryPath pins the registry to the state root in effect before --state-dir,
+// --parallel, or --auto-port move this process (and the daemons it spawns) to another state dir.
+func exportServerRegistryPath() {
+	if os.Getenv(serverregistry.FileEnv) != "" {
+		return
+	}
+	if path, err := serverregistry.Path(); err == nil {
+		_ = os.Setenv(serverregistry.FileEnv, path)
+	}
+}
+
+// portFlagSet reports whether --port was given explicitly.
+func portFlagSet() bool {
+	set := false
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == "port" {
+			set = true
+		}
+	})
+	return set
+}
+
+// startsServer reports whether the flags run a daemon or a bridge that may spawn one.
+func startsServer(f *parsedFlags) bool {
+	return !*f.showVersion && !*f.showHelp && !*f.forceCleanup && !*f.checkSetup && !*f.doctorMode &&
+		!*f.stopMode && !*f.installMode && !*f.ciMode && !*f.connectMode && *f.serveBundle == ""
+}
+
+// resolveProjectPort applies port discovery when --port was not given:
+//  1. A live server registered for this project (or an enclosing directory) wins.
+//  2. Otherwise, with --auto-port, a daemon or bridge takes the first free port slot and a
+//     project-scoped state dir, so it neither collides with nor takes over another project's daemon.
+func resolveProjectPort(f *parsedFlags) error {
+	if portFlagSet() {
+		return nil
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		return nil
+	}
+	if path, err := serverregistry.Path(); err == nil {
+		servers, _ := serverregistry.Load(path)
+		if _, srv, ok := serverregistry.Lookup(servers, cwd); ok && bridge.IsServerRunning(srv.Port) {
+			*f.port = srv.Port
+			if *f.stateDir == "" && srv.StateDir != "" {
+				return useStateDir(f, srv.StateDir)
+			}
+			return nil
+		}
+	}
+	if !*f.autoPort || !startsServer(f) {
+		return nil
+	}
+	port, err := serverregistry.PickPort(defaultPort)
+	if err != nil {
+		return err
+	}
+	*f.port = port
+	if *f.stateDir != "" || *f.parallelMode {
+		return nil
+	}
+	projectDir, err := state.ProjectDir(cwd)
+	if err != nil {
+		return fmt.Errorf("cannot resolve project state dir: %w", err)
+	}
+	return useStateDir(f, filepath.Join(projectDir, "daemon"))
+}
+
+// useStateDir switches this process, and daemons it spawns, to dir.
+func useStateDir(f *parsedFlags, dir string) error {
+	// #nosec G301 -- runtime state directory: owner rwx, group rx for diagnostics
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return fmt.Errorf("cannot create state dir %q: %w", dir, err)
+	}
+	*f.stateDir = dir
+	return os.Setenv(state.StateDirEnv, dir)
+}
+
+// registerDaemonServer records this daemon in servers.json under its working directory.
+func registerDaemonServer(server *Server, port int) {
+	path, err := serverregistry.Path()
+	if err != nil {
+		return
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		return
+	}
+	stateDir, _ := state.RootDir()
+	if err := serverregistry.Register(path, cwd, serverregistry.Server{
+		Port:      port,
+		PID:       os.Getpid(),
+		StateDir:  stateDir,
+		Version:   version,
+		StartedAt: time.Now().UTC().Format(time.RFC3339),
+	}); err != nil {
+		server.logLifecycle("server_registry_write_failed", port, map[string]any{"error": err.Error(), "path": path})
+	}
+}
+
+// unregisterDaemonServer removes this daemon's servers.json entry (best-effort).
+func unregisterDaemonServer(port int) {
+	if path, err := serverregistry.Path(); err == nil {
+		_ = serverregistry.Unregister(path, port, os.Getpid())
+	}
+}
@@ -0,0 +1,190 @@
+// Purpose: Parses remote-mode flags, starts the TLS remote listener, and points connect mode at a remote daemon.
+// Why: Lets an agent on another machine reach the daemon without weakening the default localhost-only checks.
+// Docs: docs/features/feature/remote-mode/index.md
+
+package main
+
+import (
+	"crypto/tls"
+	"errors"
+	"flag"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/remoteaccess"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/state"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/util"
+)
+
+// remoteFlags holds the raw remote-mode flag values.
+type remoteFlags struct {
+	listen, tlsCert, tlsKey, url, fingerprint *string
+	allow, hosts                              multiFlag
+}
+
+// registerRemoteFlags defines the remote-mode flags. Called from registerFlags before flag.Parse.
+func registerRemoteFlags() *remoteFlags {
+	rf := &remoteFlags{}
+	rf.listen = flag.String("remote-listen", os.Getenv("KABOOM_REMOTE_LISTEN"), "Also serve HTTPS on this address (e.g. 0.0.0.0:7443) for allowlisted remote peers; requires --api-key and --remote-allow (or KABOOM_REMOTE_LISTEN env)")
+	rf.tlsCert = flag.String("tls-cert", os.Getenv("KABOOM_TLS_CERT"), "PEM certificate for --remote-listen (default: generated self-signed cert in the state dir)")
+	rf.tlsKey = flag.String("tls-key", os.Getenv("KABOOM_TLS_KEY"), "PEM private key for --tls-cert")
+	rf.url = flag.String("remote-url", os.Getenv("KABOOM_REMOTE_URL"), "With --connect, forward to this remote daemon (https://host:port) instead of localhost (or KABOOM_REMOTE_URL env)")
+	rf.fingerprint = flag.String("remote-fingerprint", os.Getenv("KABOOM_REMOTE_FINGERPRINT"), "SHA-256 fingerprint the --remote-url server certificate must match (or KABOOM_REMOTE_FINGERPRINT env)")
+	flag.Var(&rf.allow, "remote-allow", "Peer IP or CIDR allowed on --remote-listen (repeatable or comma-separated; or KABOOM_REMOTE_ALLOW env)")
+	flag.Var(&rf.hosts, "remote-host", "Host name remote peers use to reach this daemon, accepted in Host and Origin headers on --remote-listen (repeatable; or KABOOM_REMOTE_HOST env)")
+	return rf
+}
+
+// applyRemoteFlags validates the remote flags and installs the listener policy and connect target.
+func applyRemoteFlags(rf *remoteFlags, apiKey string) error {
+	allow, hosts := []string(rf.allow), []string(rf.hosts)
+	if len(allow) == 0 && os.Getenv("KABOOM_REMOTE_ALLOW") != "" {
+		allow = []string{os.Getenv("KABOOM_REMOTE_ALLOW")}
+	}
+	if len(hosts) == 0 && os.Getenv("KABOOM_REMOTE_HOST") != "" {
+		hosts = strings.Split(os.Getenv("KABOOM_REMOTE_HOST"), ",")
+	}
+	cfg, err := buildRemoteConfig(strings.TrimSpace(*rf.listen), allow, hosts, apiKey)
+	if err != nil {
+		return err
+	}
+	remoteAccessConfig = cfg
+	remoteTLSCertFile, remoteTLSKeyFile = *rf.tlsCert, *rf.tlsKey
+	return applyConnectTarget(strings.TrimSpace(*rf.url), *rf.fingerprint, apiKey)
+}
+
+// buildRemoteConfig returns nil when listen is empty. Otherwise remote mode is all-or-nothing:
+// an API key and at least one allowlisted peer are mandatory.
+func buildRemoteConfig(listen string, allow, hosts []string, apiKey string) (*remoteaccess.Config, error) {
+	if listen == "" {
+		if len(allow) > 0 || len(hosts) > 0 {
+			return nil, errors.New("--remote-allow and --remote-host require --remote-listen")
+		}
+		return nil, nil
+	}
+	bindHost, _, err := net.SplitHostPort(listen)
+	if err != nil {
+		return nil, fmt.Errorf("--remote-listen %q: %w", listen, err)
+	}
+	if apiKey == "" {
+		return nil, errors.New("--remote-listen requires --api-key (or KABOOM_API_KEY)")
+	}
+	peers, err := remoteaccess.ParsePeers(allow)
+	if err != nil {
+		return nil, fmt.Errorf("--remote-allow: %w", err)
+	}
+	if len(peers) == 0 {
+		return nil, errors.New("--remote-listen requires at least one --remote-allow peer")
+	}
+	cfg := &remoteaccess.Config{ListenAddr: listen, Peers: peers}
+	for _, h := range hosts {
+		if h = strings.TrimSpace(h); h != "" {
+			cfg.Hosts = append(cfg.Hosts, h)
+		}
+	}
+	if ip := net.ParseIP(bindHost); ip != nil && !ip.IsUnspecified() {
+		cfg.Hosts = append(cfg.Hosts, bindHost)
+	}
+	return cfg, nil
+}
+
+// applyConnectTarget points connect mode at a remote daemon. HTTPS targets must be pinned.
+func applyConnectTarget(rawURL, fingerprint, apiKey string) error {
+	if rawURL == "" {
+		if fingerprint != "" {
+			return errors.New("--remote-fingerprint requires --remote-url")
+		}
+		return nil
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return fmt.Errorf("--remote-url %q: expected https://host:port", rawURL)
+	}
+	if u.Scheme != "https" {
+		return fmt.Errorf("--remote-url %q: remote daemons are only reachable over https", rawURL)
+	}
+	if fingerprint == "" {
+		return errors.New("--remote-url requires --remote-fingerprint (printed by the remote daemon at startup)")
+	}
+	tlsCfg, err := remoteaccess.PinnedClientConfig(fingerprint)
+	if err != nil {
+		return fmt.Errorf("--remote-fingerprint: %w", err)
+	}
+	connectRemoteURL = strings.TrimSuffix(u.Scheme+"://"+u.Host+u.Path, "/")
+	connectHTTPClient = &http.Client{Transport: &http.Transport{TLSClientConfig: tlsCfg}}
+	connectRequestKey = apiKey
+	return nil
+}
+
+// isRemoteRequest reports whether a request arrived on the remote listener, the only TLS listener.
+func isRemoteRequest(r *http.Request) bool {
+	return r.TLS != nil && remoteAccessConfig.Enabled()
+}
+
+// remoteHostAllowed relaxes the Host check for remote requests naming a configured remote host.
+func remoteHostAllowed(r *http.Request) bool {
+	return isRemoteRequest(r) && remoteAccessConfig.HostAllowed(r.Host)
+}
+
+// remoteOriginAllowed relaxes the Origin check for remote requests from a configured remote host.
+func remoteOriginAllowed(r *http.Request, origin string) bool {
+	if !isRemoteRequest(r) {
+		return false
+	}
+	u, err := url.Parse(origin)
+	return err == nil && remoteAccessConfig.HostAllowed(u.Host)
+}
+
+// remotePeerGuard rejects remote-listener requests from peers outside the allowlist.
+// Localhost-listener requests pass through untouched.
+func remotePeerGuard(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if isRemoteRequest(r) && !remoteAccessConfig.PeerAllowed(r.RemoteAddr) {
+			jsonResponse(w, http.StatusForbidden, map[string]string{"error": "forbidden: peer not in --remote-allow"})
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// startRemoteListener serves srv over TLS on the remote address alongside the localhost listener.
+// srv.Shutdown closes both.
+func startRemoteListener(server *Server, srv *http.Server, port int) error {
+	if !remoteAccessConfig.Enabled() {
+		return nil
+	}
+	certDir, err := state.RootDir()
+	if err != nil {
+		return fmt.Errorf("remote mode: %w", err)
+	}
+	cert, created, err := remoteaccess.LoadOrCreateCertificate(remoteTLSCertFile, remoteTLSKeyFile, certDir, remoteAccessConfig.Hosts)
+	if err != nil {
+		return fmt.Errorf("remote mode certificate: %w", err)
+	}
+	ln, err := net.Listen("tcp", remoteAccessConfig.ListenAddr)
+	if err != nil {
+		server.logLifecycle("remote_bind_failed", port, map[string]any{"addr": remoteAccessConfig.ListenAddr, "error": err.Error()})
+		return fmt.Errorf("cannot bind remote listener %s: %w", remoteAccessConfig.ListenAddr, err)
+	}
+	tlsLn := tls.NewListener(ln, &tls.Config{Certificates: []tls.Certificate{cert}, MinVersion: tls.VersionTLS12})
+	fingerprint := remoteaccess.Fingerprint(cert)
+	server.logLifecycle("remote_listener_started", port, map[string]any{
+		"addr":              ln.Addr().String(),
+		"peers":             len(remoteAccessConfig.Peers),
+		"cert_fingerprint":  fingerprint,
+		"cert_generated":    created,
+		"cert_user_managed": remoteTLSCertFile != "",
+	})
+	stderrf("[Kaboom] Remote listener on https://%s (fingerprint %s)\n", ln.Addr(), fingerprint)
+	util.SafeGo(func() {
+		// #nosec G114 -- TLS-only, API key required, peers restricted by --remote-allow
+		if err := srv.Serve(tlsLn); err != nil && err != http.ErrServerClosed {
+			stderrf("[Kaboom] Remote listener error: %v\n", err)
+		}
+	})
+	return nil
+}
@@ -0,0 +1,164 @@
+// Purpose: Tests remote-mode flag validation, the peer guard, relaxed Host/Origin checks, and pinned connect targets.
+// Docs: docs/features/feature/remote-mode/index.md
+
+package main
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/remoteaccess"
+)
+
+// withRemoteConfig installs a remote listener policy for one test.
+func withRemoteConfig(t *testing.T, cfg *remoteaccess.Config) {
+	t.Helper()
+	old := remoteAccessConfig
+	remoteAccessConfig = cfg
+	t.Cleanup(func() { remoteAccessConfig = old })
+}
+
+func TestBuildRemoteConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		listen  string
+		allow   []string
+		hosts   []string
+		apiKey  string
+		wantErr string
+	}{
+		{name: "disabled", listen: ""},
+		{name: "allow without listen", allow: []string{"10.0.0.1"}, wantErr: "require --remote-listen"},
+		{name: "missing api key", listen: "0.0.0.0:7443", allow: []string{"10.0.0.1"}, wantErr: "--api-key"},
+		{name: "missing peers", listen: "0.0.0.0:7443", apiKey: "k", wantErr: "--remote-allow"},
+		{name: "bad peer", listen: "0.0.0.0:7443", allow: []string{"devbox"}, apiKey: "k", wantErr: "--remote-allow"},
+		{name: "bad listen", listen: "7443", allow: []string{"10.0.0.1"}, apiKey: "k", wantErr: "--remote-listen"},
+		{name: "valid", listen: "100.64.0.7:7443", allow: []string{"10.0.0.0/8"}, hosts: []string{" devbox "}, apiKey: "k"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := buildRemoteConfig(tt.listen, tt.allow, tt.hosts, tt.apiKey)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("err = %v, want containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.listen == "" {
+				if cfg.Enabled() {
+					t.Fatal("expected remote mode off")
+				}
+				return
+			}
+			if !cfg.HostAllowed("devbox") || !cfg.HostAllowed("100.64.0.7:7443") {
+				t.Errorf("hosts = %v, want trimmed --remote-host plus the bind IP", cfg.Hosts)
+			}
+		})
+	}
+}
+
+func TestApplyConnectTargetRequiresHTTPSAndPin(t *testing.T) {
+	oldURL, oldClient, oldKey := connectRemoteURL, connectHTTPClient, connectRequestKey
+	t.Cleanup(func() { connectRemoteURL, connectHTTPClient, connectRequestKey = oldURL, oldClient, oldKey })
+
+	pin := "sha256:" + strings.Repeat("ab", 32)
+	if err := applyConnectTarget("http://devbox:7443", pin, "k"); err == nil {
+		t.Error("expected plain http to be rejected")
+	}
+	if err := applyConnectTarget("https://devbox:7443", "", "k"); err == nil {
+		t.Error("expected a missing fingerprint to be rejected")
+	}
+	if err := applyConnectTarget("", pin, "k"); err == nil {
+		t.Error("expected a fingerprint without a URL to be rejected")
+	}
+	if err := applyConnectTarget("https://devbox:7443/", pin, "k"); err != nil {
+		t.Fatal(err)
+	}
+	if connectRemoteURL != "https://devbox:7443" || connectRequestKey != "k" || connectHTTPClient == http.DefaultClient {
+		t.Errorf("connect target not installed: url=%q key=%q", connectRemoteURL, connectRequestKey)
+	}
+}
+
+func TestCORSMiddlewareRemoteHostOnlyOverTLS(t *testing.T) {
+	withRemoteConfig(t, &remoteaccess.Config{ListenAddr: "0.0.0.0:7443", Hosts: []string{"devbox.internal"}})
+	handler := corsMiddleware(func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusOK) })
+
+	serve := func(host, origin string, overTLS bool) int {
+		req := httptest.NewRequest(http.MethodGet, "/health", nil)
+		req.Host = host
+		if origin != "" {
+			req.Header.Set("Origin", origin)
+		}
+		if overTLS {
+			req.TLS = &tls.ConnectionState{}
+		}
+		rec := httptest.NewRecorder()
+		handler(rec, req)
+		return rec.Code
+	}
+
+	if code := serve("devbox.internal:7443", "", false); code != http.StatusForbidden {
+		t.Errorf("remote host on the localhost listener: code = %d, want 403", code)
+	}
+	if code := serve("devbox.internal:7443", "https://devbox.internal:7443", true); code != http.StatusOK {
+		t.Errorf("remote host over TLS: code = %d, want 200", code)
+	}
+	if code := serve("attacker.example", "", true); code != http.StatusForbidden {
+		t.Errorf("unlisted host over TLS: code = %d, want 403", code)
+	}
+	if code := serve("devbox.internal", "https://attacker.example", true); code != http.StatusForbidden {
+		t.Errorf("unlisted origin over TLS: code = %d, want 403", code)
+	}
+}
+
+func TestRemoteListenerEndToEnd(t *testing.T) {
+	peers, _ := remoteaccess.ParsePeers([]string{"127.0.0.1"})
+	withRemoteConfig(t, &remoteaccess.Config{ListenAddr: "127.0.0.1:0", Peers: peers, Hosts: []string{"127.0.0.1"}})
+	cert, _, err := remoteaccess.LoadOrCreateCertificate("", "", t.TempDir(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/health", corsMiddleware(func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusOK) }))
+	srv := httptest.NewUnstartedServer(remotePeerGuard(AuthMiddleware("s3cret")(mux)))
+	srv.TLS = &tls.Config{Certificates: []tls.Certificate{cert}, MinVersion: tls.VersionTLS12}
+	srv.StartTLS()
+	defer srv.Close()
+
+	tlsCfg, err := remoteaccess.PinnedClientConfig(remoteaccess.Fingerprint(cert))
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := &http.Client{Transport: &http.Transport{TLSClientConfig: tlsCfg}}
+	get := func(key string) int {
+		req, _ := http.NewRequest(http.MethodGet, srv.URL+"/health", nil)
+		if key != "" {
+			req.Header.Set("X-Kaboom-Key", key)
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = resp.Body.Close()
+		return resp.StatusCode
+	}
+	if code := get(""); code != http.StatusUnauthorized {
+		t.Errorf("without key: code = %d, want 401", code)
+	}
+	if code := get("s3cret"); code != http.StatusOK {
+		t.Errorf("with key: code = %d, want 200", code)
+	}
+
+	other, _ := remoteaccess.ParsePeers([]string{"10.9.9.9"})
+	remoteAccessConfig.Peers = other
+	if code := get("s3cret"); code != http.StatusForbidden {
+		t.Errorf("peer outside allowlist: code = %d, want 403", code)
+	}
+}
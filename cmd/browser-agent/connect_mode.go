@@ -33,6 +33,9 @@ const (
 // The client ID is sent via X-Kaboom-Client header for state isolation.
 func runConnectMode(port int, clientID string, cwd string) {
 	serverURL := fmt.Sprintf("http://127.0.0.1:%d", port)
+	if connectRemoteURL != "" {
+		serverURL = connectRemoteURL
+	}
 
 	connectCheckHealth(serverURL, port)
 	connectRegisterClient(serverURL, clientID, cwd)
@@ -57,7 +60,7 @@ func connectCheckHealth(serverURL string, port int) {
 		os.Exit(1)
 	}
 
-	resp, err := http.DefaultClient.Do(req) // #nosec G107,G704 -- localhost URL constructed from trusted port flag
+	resp, err := connectDo(req)
 	if err != nil {
 		stderrf("[Kaboom] Cannot connect to server at %s: %v\n", serverURL, err)
 		stderrf("[Kaboom] Start a server first: kaboom --server --port %d\n", port)
@@ -71,10 +74,15 @@ func connectCheckHealth(serverURL string, port int) {
 	}
 }
 
-// connectRegisterClient registers this client with the server (best-effort).
+// connectRegisterClient registers this client with the server (best-effort),
+// including the git state of cwd so artifacts can be tied to a commit.
 func connectRegisterClient(serverURL, clientID, cwd string) {
-	// Error impossible: map contains only string values
-	regBody, _ := json.Marshal(map[string]string{"cwd": cwd})
+	reg := map[string]any{"cwd": cwd}
+	if sc := readSourceControl(cwd); sc != nil {
+		reg["source_control"] = sc
+	}
+	// Error impossible: map contains only strings and a plain struct
+	regBody, _ := json.Marshal(reg)
 
 	ctx, cancel := context.WithTimeout(context.Background(), connectModeRegisterTimeout)
 	defer cancel()
@@ -87,7 +95,7 @@ func connectRegisterClient(serverURL, clientID, cwd string) {
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("X-Kaboom-Client", clientID)
 
-	resp, err := http.DefaultClient.Do(req) // #nosec G704 -- request targets localhost-only serverURL
+	resp, err := connectDo(req)
 	if err != nil {
 		stderrf("[Kaboom] Warning: could not register client: %v\n", err)
 		return
@@ -124,7 +132,7 @@ func connectForwardRequest(mcpURL, clientID, line string) {
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("X-Kaboom-Client", clientID)
 
-	resp, err := http.DefaultClient.Do(req) // #nosec G704 -- request targets localhost-only serverURL
+	resp, err := connectDo(req)
 	if err != nil {
 		id := extractRequestID(line)
 		sendMCPError(id, -32603, "Server connection error: "+err.Error())
@@ -172,12 +180,20 @@ func connectUnregisterClient(serverURL, clientID string) {
 		return
 	}
 	req.Header.Set("X-Kaboom-Client", clientID)
-	resp, err := http.DefaultClient.Do(req) // #nosec G704 -- request targets localhost-only serverURL
+	resp, err := connectDo(req)
 	if err == nil {
 		_ = resp.Body.Close() //nolint:errcheck // best-effort cleanup after unregister
 	}
 }
 
+// connectDo sends a connect-mode request, adding the API key a remote daemon requires.
+func connectDo(req *http.Request) (*http.Response, error) {
+	if connectRequestKey != "" {
+		req.Header.Set("X-Kaboom-Key", connectRequestKey)
+	}
+	return connectHTTPClient.Do(req) // #nosec G704 -- localhost or the pinned --remote-url
+}
+
 // sendMCPError sends a JSON-RPC error response to stdout (used in connect mode)
 func sendMCPError(id any, code int, message string) {
 	resp := JSONRPCResponse{
@@ -77,7 +77,7 @@ func handleStatusAPI(server *Server, cap *capture.Store, mcpHandler *MCPHandler)
 
 		buffers := map[string]any{
 			"console_entries":  server.logs.getEntryCount(),
-			"console_capacity": server.logs.maxEntries,
+			"console_capacity": consoleCapacity(server.logs),
 		}
 
 		if cap != nil {
@@ -89,12 +89,13 @@ func handleStatusAPI(server *Server, cap *capture.Store, mcpHandler *MCPHandler)
 				resp["last_poll_at"] = snap.LastPollTime.Format(time.RFC3339)
 			}
 
+			retention := cap.GetRetention()
 			buffers["network_entries"] = snap.NetworkBodyCount
-			buffers["network_capacity"] = capture.MaxNetworkBodies
+			buffers["network_capacity"] = retention[capture.RetentionNetwork].MaxEntries
 			buffers["websocket_entries"] = snap.WebSocketCount
-			buffers["websocket_capacity"] = capture.MaxWSEvents
+			buffers["websocket_capacity"] = retention[capture.RetentionWebSocket].MaxEntries
 			buffers["action_entries"] = snap.ActionCount
-			buffers["action_capacity"] = capture.MaxEnhancedActions
+			buffers["action_capacity"] = retention[capture.RetentionActions].MaxEntries
 
 			resp["recent_commands"] = buildRecentCommands(cap.GetHTTPDebugLog())
 		} else {
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/cmd/browser-agent/internal/health"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/streaming"
 )
 
 // toolDoctor runs all live diagnostic checks and returns structured results.
@@ -25,6 +26,19 @@ func (h *ToolHandler) toolDoctor(req JSONRPCRequest) JSONRPCResponse {
 		})
 	}
 
+	// Active alert silences are informational: they suppress alerts, not capture.
+	var silences []streaming.AlertSilence
+	if h.alertBuffer != nil {
+		silences = h.alertBuffer.ActiveSilences(time.Now())
+		if len(silences) > 0 {
+			checks = append(checks, health.DoctorCheck{
+				Name:   "alert_silences",
+				Status: "pass",
+				Detail: fmt.Sprintf("%d alert silence(s) active; alerts suppressed, capture unaffected", len(silences)),
+			})
+		}
+	}
+
 	overallStatus := "healthy"
 	readyForInteraction := true
 	for _, c := range checks {
@@ -42,6 +56,7 @@ func (h *ToolHandler) toolDoctor(req JSONRPCRequest) JSONRPCResponse {
 		"status":                overallStatus,
 		"ready_for_interaction": readyForInteraction,
 		"checks":                checks,
+		"alert_silences":        silences,
 		"hint":                  h.DiagnosticHintString(),
 	})
 }
@@ -0,0 +1,43 @@
+// Purpose: Resolves observe(what="errors") entries to files in the calling client's working directory.
+// Why: Each MCP client registers its CWD; an error stack naming src/components/Login.tsx:42 should come back as a path the agent can open.
+// Docs: docs/features/feature/code-navigation-modification/index.md
+
+package main
+
+import (
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/lspdiag"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/session"
+)
+
+// LinkErrorSources implements observe.ErrorSourceLinker. Entries resolve under the calling
+// client's registered CWD; callers without a registration fall back to every known workspace root.
+func (h *ToolHandler) LinkErrorSources(clientID string, entries []LogEntry) []*lspdiag.SourceLink {
+	roots := h.errorSourceRoots(clientID)
+	if len(roots) == 0 {
+		return nil
+	}
+	resolver := lspdiag.NewResolver(roots)
+	links := make([]*lspdiag.SourceLink, len(entries))
+	for i, entry := range entries {
+		if link, ok := lspdiag.Link(entry, resolver); ok {
+			links[i] = &link
+		}
+	}
+	return links
+}
+
+// errorSourceRoots returns the calling client's CWD when it is registered, else the LSP workspace roots.
+func (h *ToolHandler) errorSourceRoots(clientID string) []string {
+	if h.capture == nil {
+		return nil
+	}
+	if reg := h.capture.GetClientRegistry(); reg != nil && clientID != "" {
+		if cs, ok := reg.Get(clientID).(*session.ClientState); ok && cs != nil && cs.CWD != "" {
+			return []string{cs.CWD}
+		}
+	}
+	if h.MCPHandler == nil || h.MCPHandler.server == nil {
+		return nil
+	}
+	return lspWorkspaceRoots(h.MCPHandler.server, h.capture)
+}
@@ -0,0 +1,73 @@
+// Purpose: Tests that observe(what="errors") links stack frames to files under the calling client's CWD.
+// Docs: docs/features/feature/code-navigation-modification/index.md
+
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/session"
+)
+
+func TestObserveErrors_LinksSourceUnderClientCWD(t *testing.T) {
+	t.Parallel()
+	h, server, cap := makeToolHandler(t)
+	reg := session.NewClientRegistry()
+	cap.SetClientRegistryForTest(newSessionClientRegistryAdapter(reg))
+
+	appDir, otherDir := t.TempDir(), t.TempDir()
+	path := filepath.Join(appDir, "src", "components", "Login.tsx")
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	lines := make([]string, 50)
+	for i := range lines {
+		lines[i] = "// line"
+	}
+	lines[41] = "  throw new Error('bad credentials')"
+	if err := os.WriteFile(path, []byte(strings.Join(lines, "\n")), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	app := reg.Register(appDir)
+	other := reg.Register(otherDir)
+
+	server.logs.addEntries([]LogEntry{{
+		"level":   "error",
+		"message": "Error: bad credentials",
+		"stack":   "Error: bad credentials\n    at submit (http://localhost:5173/src/components/Login.tsx:42:9)",
+	}})
+
+	observeAs := func(clientID string) map[string]any {
+		req := JSONRPCRequest{JSONRPC: "2.0", ID: 1, ClientID: clientID}
+		result := parseToolResult(t, h.toolObserve(req, json.RawMessage(`{"what":"errors"}`)))
+		if result.IsError {
+			t.Fatalf("errors should succeed, got: %s", firstText(result))
+		}
+		return extractResultJSON(t, result)["errors"].([]any)[0].(map[string]any)
+	}
+
+	linked := observeAs(app.ID)
+	src, ok := linked["source_file"].(map[string]any)
+	if !ok {
+		t.Fatalf("error should carry source_file: %v", linked)
+	}
+	if src["path"] != path || src["line"] != float64(42) || src["column"] != float64(9) {
+		t.Fatalf("source_file = %v", src)
+	}
+	snippet := src["snippet"].([]any)
+	if len(snippet) != 11 {
+		t.Fatalf("snippet should hold ±5 lines, got %d", len(snippet))
+	}
+	mid := snippet[5].(map[string]any)
+	if mid["line"] != float64(42) || mid["error"] != true || !strings.Contains(mid["text"].(string), "bad credentials") {
+		t.Fatalf("error line = %v", mid)
+	}
+
+	if unlinked := observeAs(other.ID); unlinked["source_file"] != nil {
+		t.Fatalf("file outside the caller's CWD should not resolve: %v", unlinked["source_file"])
+	}
+}
@@ -0,0 +1,288 @@
+// Purpose: Implements the /extension-ws WebSocket transport that pushes queued commands to the extension.
+// Why: /sync delivers commands on the next poll; a socket delivers them the moment an interact/analyze call queues them.
+// Docs: docs/features/feature/extension-websocket/index.md
+
+package main
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/util"
+)
+
+const (
+	// maxExtensionWSMessage matches the POST /sync body limit so results fit either transport.
+	maxExtensionWSMessage = 5 << 20
+	// extensionWSIdleTimeout closes sockets that send neither heartbeats nor pongs.
+	extensionWSIdleTimeout = 30 * time.Second
+	// extensionWSPingInterval keeps idle sockets alive; browsers answer pings automatically.
+	extensionWSPingInterval = 15 * time.Second
+	// extensionWSWriteTimeout bounds a single frame write to a stalled client.
+	extensionWSWriteTimeout = 10 * time.Second
+)
+
+// WebSocket close codes used by /extension-ws (RFC 6455 §7.4.1).
+const (
+	wsCloseNormal   = 1000
+	wsCloseTooLarge = 1009
+)
+
+// isExtensionWSClient authorizes a socket upgrade. Browsers cannot set custom headers on
+// WebSocket requests, so a valid extension Origin is accepted in place of X-Kaboom-Client.
+// Localhost page origins are rejected: only the extension may receive commands.
+func isExtensionWSClient(r *http.Request) bool {
+	if isExtensionClientHeader(r.Header.Get("X-Kaboom-Client")) {
+		return true
+	}
+	matched, allowed := isExtensionOrigin(r.Header.Get("Origin"))
+	return matched && allowed
+}
+
+// handleExtensionWS upgrades GET /extension-ws to a WebSocket carrying the /sync protocol:
+//   - client → server: SyncRequest heartbeats (settings, results, acks, in_progress) as text frames.
+//   - server → client: a SyncResponse reply per heartbeat, plus unsolicited SyncResponse pushes
+//     as soon as commands are queued.
+//
+// Clients that cannot upgrade keep polling POST /sync; both transports share one command queue.
+func handleExtensionWS(cap *capture.Store) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !isExtensionWSClient(r) {
+			jsonResponse(w, http.StatusForbidden, map[string]string{"error": "forbidden: extension origin or X-Kaboom-Client header required"})
+			return
+		}
+		key := r.Header.Get("Sec-WebSocket-Key")
+		if key == "" || strings.ToLower(r.Header.Get("Upgrade")) != "websocket" {
+			jsonResponse(w, http.StatusBadRequest, map[string]string{"error": "websocket upgrade required; poll POST /sync instead"})
+			return
+		}
+		hj, ok := w.(http.Hijacker)
+		if !ok {
+			jsonResponse(w, http.StatusInternalServerError, map[string]string{"error": "server does not support hijacking"})
+			return
+		}
+		conn, bufrw, err := hj.Hijack()
+		if err != nil {
+			jsonResponse(w, http.StatusInternalServerError, map[string]string{"error": err.Error()})
+			return
+		}
+		defer conn.Close()
+
+		handshake := "HTTP/1.1 101 Switching Protocols\r\n" +
+			"Upgrade: websocket\r\n" +
+			"Connection: Upgrade\r\n" +
+			"Sec-WebSocket-Accept: " + wsAcceptKey(key) + "\r\n\r\n"
+		if _, err := bufrw.WriteString(handshake); err != nil {
+			return
+		}
+		if err := bufrw.Flush(); err != nil {
+			return
+		}
+
+		clientID := r.Header.Get("X-Kaboom-Client")
+		if clientID == "" {
+			clientID = r.URL.Query().Get("client")
+		}
+		if !isExtensionClientHeader(clientID) {
+			clientID = "kaboom-extension"
+		}
+
+		session := &extensionWSSession{
+			conn:      conn,
+			rw:        bufrw,
+			cap:       cap,
+			clientID:  clientID,
+			userAgent: r.Header.Get("User-Agent"),
+			sent:      make(map[string]bool),
+			done:      make(chan struct{}),
+		}
+		session.run()
+	}
+}
+
+// extensionWSSession is one extension socket.
+//
+// Lock hierarchy: writeMu is a leaf lock guarding frame writes and the sent set;
+// capture calls are made without holding it.
+type extensionWSSession struct {
+	conn      net.Conn
+	rw        *bufio.ReadWriter
+	cap       *capture.Store
+	clientID  string
+	userAgent string
+
+	writeMu sync.Mutex
+	sent    map[string]bool // command IDs already delivered on this socket
+
+	done chan struct{}
+}
+
+func (s *extensionWSSession) run() {
+	s.cap.ExtensionWebSocketOpened()
+	defer s.cap.ExtensionWebSocketClosed()
+
+	pushDone := make(chan struct{})
+	util.SafeGo(func() {
+		defer close(pushDone)
+		s.pushLoop()
+	})
+	s.readLoop()
+	close(s.done)
+	<-pushDone
+}
+
+// readLoop handles heartbeats and control frames until the client leaves or goes idle.
+func (s *extensionWSSession) readLoop() {
+	var fragOpcode byte
+	var fragBuf []byte
+	for {
+		_ = s.conn.SetReadDeadline(time.Now().Add(extensionWSIdleTimeout))
+		fin, opcode, payload, err := wsReadFrameLimit(s.rw, maxExtensionWSMessage)
+		if err != nil {
+			if strings.Contains(err.Error(), "exceeds limit") {
+				_ = s.writeClose(wsCloseTooLarge, "message too large")
+			}
+			return
+		}
+
+		if opcode >= 0x8 {
+			switch opcode {
+			case 0x8: // Close
+				_ = s.writeClose(wsCloseNormal, "")
+				return
+			case 0x9: // Ping → Pong
+				if err := s.writeFrame(0xA, payload); err != nil {
+					return
+				}
+			}
+			continue // Pong only refreshes the idle deadline.
+		}
+
+		// Fragment reassembly (RFC 6455 §5.4).
+		if opcode != 0x0 && !fin {
+			fragOpcode = opcode
+			fragBuf = append(fragBuf[:0], payload...)
+			continue
+		}
+		if opcode == 0x0 {
+			fragBuf = append(fragBuf, payload...)
+			if len(fragBuf) > maxExtensionWSMessage {
+				_ = s.writeClose(wsCloseTooLarge, "message too large")
+				return
+			}
+			if !fin {
+				continue
+			}
+			opcode, payload = fragOpcode, fragBuf
+			fragBuf = nil
+		}
+		if opcode != 0x1 {
+			continue // Binary frames are not part of the protocol.
+		}
+
+		var req capture.SyncRequest
+		if err := json.Unmarshal(payload, &req); err != nil {
+			if s.writeJSON(map[string]string{"error": "Invalid JSON"}) != nil {
+				return
+			}
+			continue
+		}
+		resp := s.cap.ProcessSyncMessage(req, s.clientID, s.userAgent)
+		if err := s.deliver(resp, resp.Commands); err != nil {
+			return
+		}
+	}
+}
+
+// pushLoop sends newly queued commands immediately and pings idle sockets.
+func (s *extensionWSSession) pushLoop() {
+	ticker := time.NewTicker(extensionWSPingInterval)
+	defer ticker.Stop()
+	for {
+		// Take the wake-up channel before reading the queue so no enqueue is missed.
+		added := s.cap.PendingQueryAdded()
+		if fresh := s.unsent(s.cap.PendingSyncCommands()); len(fresh) > 0 {
+			if err := s.deliver(s.cap.SyncPush(fresh), fresh); err != nil {
+				return
+			}
+		}
+		select {
+		case <-added:
+		case <-ticker.C:
+			if err := s.writeFrame(0x9, nil); err != nil {
+				return
+			}
+		case <-s.done:
+			return
+		}
+	}
+}
+
+// unsent filters commands already delivered on this socket and forgets IDs no longer queued.
+func (s *extensionWSSession) unsent(commands []capture.SyncCommand) []capture.SyncCommand {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	queued := make(map[string]bool, len(commands))
+	var fresh []capture.SyncCommand
+	for _, cmd := range commands {
+		queued[cmd.ID] = true
+		if !s.sent[cmd.ID] {
+			fresh = append(fresh, cmd)
+		}
+	}
+	for id := range s.sent {
+		if !queued[id] {
+			delete(s.sent, id)
+		}
+	}
+	return fresh
+}
+
+// deliver writes resp and records its commands as sent.
+func (s *extensionWSSession) deliver(resp capture.SyncResponse, commands []capture.SyncCommand) error {
+	payload, err := json.Marshal(resp)
+	if err != nil {
+		return err
+	}
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	for _, cmd := range commands {
+		s.sent[cmd.ID] = true
+	}
+	return s.writeFrameLocked(0x1, payload)
+}
+
+func (s *extensionWSSession) writeJSON(v any) error {
+	payload, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return s.writeFrame(0x1, payload)
+}
+
+func (s *extensionWSSession) writeClose(code uint16, reason string) error {
+	payload := binary.BigEndian.AppendUint16(nil, code)
+	return s.writeFrame(0x8, append(payload, reason...))
+}
+
+func (s *extensionWSSession) writeFrame(opcode byte, payload []byte) error {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	return s.writeFrameLocked(opcode, payload)
+}
+
+func (s *extensionWSSession) writeFrameLocked(opcode byte, payload []byte) error {
+	_ = s.conn.SetWriteDeadline(time.Now().Add(extensionWSWriteTimeout))
+	if err := wsWriteFrame(s.rw, opcode, payload); err != nil {
+		return fmt.Errorf("extension-ws write: %w", err)
+	}
+	return nil
+}
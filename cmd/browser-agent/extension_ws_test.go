@@ -0,0 +1,127 @@
+// Purpose: Tests /extension-ws authorization, heartbeat replies, command push, and transport reporting.
+// Docs: docs/features/feature/extension-websocket/index.md
+
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/queries"
+)
+
+// dialExtensionWS performs a raw upgrade against srv and returns the open connection.
+func dialExtensionWS(t *testing.T, srv *httptest.Server, origin string) (net.Conn, *bufio.Reader) {
+	t.Helper()
+	conn, err := net.Dial("tcp", strings.TrimPrefix(srv.URL, "http://"))
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	req := "GET /extension-ws HTTP/1.1\r\nHost: localhost\r\nUpgrade: websocket\r\nConnection: Upgrade\r\n" +
+		"Sec-WebSocket-Key: dGhlIHNhbXBsZSBub25jZQ==\r\nSec-WebSocket-Version: 13\r\nOrigin: " + origin + "\r\n\r\n"
+	if _, err := conn.Write([]byte(req)); err != nil {
+		t.Fatalf("write handshake: %v", err)
+	}
+	br := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(br, nil)
+	if err != nil {
+		t.Fatalf("read handshake: %v", err)
+	}
+	if resp.StatusCode != http.StatusSwitchingProtocols {
+		t.Fatalf("handshake status = %d, want 101", resp.StatusCode)
+	}
+	return conn, br
+}
+
+// readSyncFrame reads the next text frame as a SyncResponse, skipping control frames.
+func readSyncFrame(t *testing.T, conn net.Conn, br *bufio.Reader) capture.SyncResponse {
+	t.Helper()
+	_ = conn.SetReadDeadline(time.Now().Add(3 * time.Second))
+	for {
+		_, opcode, payload, err := wsReadFrame(br)
+		if err != nil {
+			t.Fatalf("read frame: %v", err)
+		}
+		if opcode != 0x1 {
+			continue
+		}
+		var resp capture.SyncResponse
+		if err := json.Unmarshal(payload, &resp); err != nil {
+			t.Fatalf("decode %s: %v", payload, err)
+		}
+		return resp
+	}
+}
+
+func TestExtensionWS_RejectsNonExtensionClients(t *testing.T) {
+	t.Parallel()
+	handler := handleExtensionWS(capture.NewCapture())
+	for _, origin := range []string{"", "http://localhost:3000", "chrome-extension://BAD!"} {
+		req := httptest.NewRequest(http.MethodGet, "/extension-ws", nil)
+		req.Header.Set("Upgrade", "websocket")
+		req.Header.Set("Sec-WebSocket-Key", "dGhlIHNhbXBsZSBub25jZQ==")
+		if origin != "" {
+			req.Header.Set("Origin", origin)
+		}
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		if rr.Code != http.StatusForbidden {
+			t.Errorf("origin %q status = %d, want 403", origin, rr.Code)
+		}
+	}
+
+	// Authorized but not an upgrade: the client should fall back to polling.
+	req := httptest.NewRequest(http.MethodGet, "/extension-ws", nil)
+	req.Header.Set("X-Kaboom-Client", "kaboom-extension/1.0")
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("plain GET status = %d, want 400", rr.Code)
+	}
+}
+
+func TestExtensionWS_HeartbeatAndPush(t *testing.T) {
+	t.Parallel()
+	cap := capture.NewCapture()
+	srv := httptest.NewServer(handleExtensionWS(cap))
+	defer srv.Close()
+
+	conn, br := dialExtensionWS(t, srv, "chrome-extension://abcdefghijklmnop")
+	rw := bufio.NewReadWriter(br, bufio.NewWriter(conn))
+	if err := wsWriteFrame(rw, 0x1, []byte(`{"ext_session_id":"ws-test"}`)); err != nil {
+		t.Fatalf("write heartbeat: %v", err)
+	}
+	reply := readSyncFrame(t, conn, br)
+	if !reply.Ack || reply.NextPollMs != capture.ExtensionWSHeartbeatMs {
+		t.Errorf("heartbeat reply = %+v", reply)
+	}
+	if got := cap.ExtensionTransport(); got != capture.ExtensionTransportWebSocket {
+		t.Errorf("transport = %q, want websocket", got)
+	}
+
+	id, err := cap.CreatePendingQuery(queries.PendingQuery{Type: "dom", Params: json.RawMessage(`{"selector":"h1"}`)})
+	if err != nil {
+		t.Fatalf("CreatePendingQuery: %v", err)
+	}
+	push := readSyncFrame(t, conn, br)
+	if len(push.Commands) != 1 || push.Commands[0].ID != id {
+		t.Fatalf("push = %+v, want command %s", push, id)
+	}
+
+	_ = conn.Close()
+	deadline := time.Now().Add(3 * time.Second)
+	for cap.ExtensionTransport() == capture.ExtensionTransportWebSocket {
+		if time.Now().After(deadline) {
+			t.Fatal("transport still websocket after socket closed")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
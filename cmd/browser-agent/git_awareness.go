@@ -0,0 +1,171 @@
+// Purpose: Keeps each client's git state current across tool calls and maps commits to change-feed positions.
+// Why: A branch switch or new commit mid-session should show up in snapshots, baselines, and observe(what="changes", since_commit=...).
+// Docs: docs/features/feature/source-control-context/index.md
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/session"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/tools/observe"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/types"
+)
+
+// gitRefreshInterval is the minimum time between git reads for one working directory.
+const gitRefreshInterval = 5 * time.Second
+
+// commitPattern accepts full or abbreviated hex commit IDs.
+var commitPattern = regexp.MustCompile(`^[0-9a-fA-F]{4,40}$`)
+
+// gitDirState is what the last read of one working directory saw.
+type gitDirState struct {
+	readAt time.Time
+	// seq is the change-feed sequence at readAt. When the next read finds a new
+	// commit, the switch happened after this point.
+	seq int64
+}
+
+// gitAwareness rate-limits git reads per working directory and records commit
+// transitions on a timeline keyed to change-feed sequences.
+type gitAwareness struct {
+	mu       sync.Mutex
+	dirs     map[string]gitDirState
+	timeline *session.CommitTimeline
+	read     func(dir string) *types.SourceControl
+	// isAncestor reports whether ancestor is reachable from descendant in dir.
+	isAncestor func(dir, ancestor, descendant string) bool
+}
+
+func newGitAwareness(read func(dir string) *types.SourceControl) *gitAwareness {
+	return &gitAwareness{
+		dirs:       make(map[string]gitDirState),
+		timeline:   session.NewCommitTimeline(),
+		read:       read,
+		isAncestor: gitIsAncestor,
+	}
+}
+
+// refresh re-reads cwd when the last read is older than gitRefreshInterval.
+// Returns nil when cwd was read recently or is not a git work tree.
+func (g *gitAwareness) refresh(cwd string, seq int64, now time.Time) *types.SourceControl {
+	prevSeq, due := g.claimRead(cwd, now)
+	if !due {
+		return nil
+	}
+	sc := g.read(cwd)
+	g.recordRead(cwd, sc, prevSeq, seq, now)
+	return sc
+}
+
+// claimRead marks cwd as read at now when a read is due, so concurrent calls do not
+// all shell out to git. Returns the sequence seen by the previous read.
+func (g *gitAwareness) claimRead(cwd string, now time.Time) (prevSeq int64, due bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	prev, seen := g.dirs[cwd]
+	if seen && now.Sub(prev.readAt) < gitRefreshInterval {
+		return 0, false
+	}
+	g.dirs[cwd] = gitDirState{readAt: now, seq: prev.seq}
+	return prev.seq, true
+}
+
+// recordRead stores the result of a read. The first commit seen owns everything
+// retained; later ones start after the last read that still saw the previous commit.
+func (g *gitAwareness) recordRead(cwd string, sc *types.SourceControl, prevSeq, seq int64, now time.Time) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if sc != nil {
+		g.timeline.Observe(cwd, *sc, prevSeq, now)
+	}
+	g.dirs[cwd] = gitDirState{readAt: now, seq: seq}
+}
+
+// refreshSourceControl re-reads the calling client's git state and updates its registration.
+func (h *ToolHandler) refreshSourceControl(clientID string) {
+	cwd := h.clientCWD(clientID)
+	if cwd == "" || h.gitState == nil {
+		return
+	}
+	sc := h.gitState.refresh(cwd, h.capture.ChangeFeed().LatestSeq(), time.Now())
+	if sc == nil {
+		return
+	}
+	if reg := h.capture.GetClientRegistry(); reg != nil {
+		reg.SetSourceControl(clientID, *sc)
+	}
+}
+
+// clientCWD returns the working directory a client registered with, or "".
+func (h *ToolHandler) clientCWD(clientID string) string {
+	if h.capture == nil || clientID == "" {
+		return ""
+	}
+	reg := h.capture.GetClientRegistry()
+	if reg == nil {
+		return ""
+	}
+	if cs, ok := reg.Get(clientID).(*session.ClientState); ok && cs != nil {
+		return cs.CWD
+	}
+	return ""
+}
+
+// ResolveSinceCommit implements observe.CommitResolver. A commit checked out while the
+// daemon was running resolves to the sequence where it was first seen; an ancestor of
+// the earliest tracked commit resolves to the start of the retained feed.
+func (h *ToolHandler) ResolveSinceCommit(clientID, commit string) (observe.CommitPosition, error) {
+	if !commitPattern.MatchString(commit) {
+		return observe.CommitPosition{}, fmt.Errorf("since_commit %q is not a hex commit ID", commit)
+	}
+	cwd := h.clientCWD(clientID)
+	if cwd == "" || h.gitState == nil {
+		return observe.CommitPosition{}, errors.New("since_commit needs a client registered with a working directory")
+	}
+	timeline := h.gitState.timeline
+	mark, found, err := timeline.Resolve(cwd, commit)
+	if err != nil {
+		return observe.CommitPosition{}, err
+	}
+	if found {
+		return observe.CommitPosition{
+			Commit:    mark.Commit,
+			Branch:    mark.Branch,
+			FirstSeen: mark.FirstSeen.Format(time.RFC3339),
+			Seq:       mark.Seq,
+		}, nil
+	}
+
+	marks := timeline.Marks(cwd)
+	if len(marks) == 0 {
+		return observe.CommitPosition{}, errors.New("no commits tracked yet for this working directory")
+	}
+	if h.gitState.isAncestor(cwd, commit, marks[0].Commit) {
+		return observe.CommitPosition{
+			Commit: strings.ToLower(commit),
+			Seq:    0,
+			Note:   "commit predates tracking; returning all retained changes",
+		}, nil
+	}
+	known := make([]string, 0, len(marks))
+	for _, m := range marks {
+		known = append(known, types.SourceControl{Commit: m.Commit}.ShortCommit())
+	}
+	return observe.CommitPosition{}, fmt.Errorf("commit %s was not seen by this daemon (tracked: %s)", commit, strings.Join(known, ", "))
+}
+
+// gitIsAncestor runs git merge-base --is-ancestor; any failure counts as "not an ancestor".
+func gitIsAncestor(dir, ancestor, descendant string) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), sourceControlReadTimeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, "git", "-C", dir, "merge-base", "--is-ancestor", ancestor, descendant) // #nosec G204 -- commit IDs are validated hex; dir is the client's own CWD
+	return cmd.Run() == nil
+}
@@ -0,0 +1,92 @@
+// Purpose: Tests commit tracking across tool calls and observe(what="changes", since_commit=...).
+// Docs: docs/features/feature/source-control-context/index.md
+
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/session"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/types"
+)
+
+func TestObserveChanges_SinceCommit(t *testing.T) {
+	t.Parallel()
+	h, _, cap := makeToolHandler(t)
+	cap.SetClientRegistryForTest(newSessionClientRegistryAdapter(session.NewClientRegistry()))
+	cwd := "/work/app"
+	clientID := cap.GetClientRegistry().Register(cwd).(*session.ClientState).ID
+
+	head := &types.SourceControl{Branch: "main", Commit: "aaaa111122223333"}
+	h.gitState.read = func(string) *types.SourceControl { return head }
+	h.gitState.isAncestor = func(_, ancestor, _ string) bool { return ancestor == "cafe0000" }
+
+	h.refreshSourceControl(clientID)
+	start := time.Now()
+	cap.AddNetworkBodies([]capture.NetworkBody{{Method: "GET", URL: "https://app.test/old", Status: 200}})
+	h.gitState.refresh(cwd, cap.ChangeFeed().LatestSeq(), start.Add(gitRefreshInterval))
+	head = &types.SourceControl{Branch: "feat", Commit: "bbbb444455556666"}
+	cap.AddNetworkBodies([]capture.NetworkBody{{Method: "GET", URL: "https://app.test/new", Status: 500}})
+	if sc := h.gitState.refresh(cwd, cap.ChangeFeed().LatestSeq(), start.Add(2*gitRefreshInterval)); sc == nil {
+		t.Fatal("refresh after the interval should re-read git")
+	}
+
+	req := JSONRPCRequest{JSONRPC: "2.0", ID: 1, ClientID: clientID}
+	observeChanges := func(args string) (MCPToolResult, map[string]any) {
+		t.Helper()
+		result := parseToolResult(t, h.toolObserve(req, json.RawMessage(args)))
+		if result.IsError {
+			return result, nil
+		}
+		return result, extractResultJSON(t, result)
+	}
+
+	_, data := observeChanges(`{"what":"changes","since_commit":"bbbb4444"}`)
+	changes := data["changes"].([]any)
+	if len(changes) != 1 || !strings.Contains(jsonString(changes[0]), "/new") {
+		t.Fatalf("since feat commit = %v", changes)
+	}
+	if since := data["since_commit"].(map[string]any); since["branch"] != "feat" || since["commit"] != "bbbb444455556666" {
+		t.Fatalf("since_commit = %v", since)
+	}
+
+	_, data = observeChanges(`{"what":"changes","since_commit":"AAAA1111"}`)
+	if changes := data["changes"].([]any); len(changes) != 2 {
+		t.Fatalf("since main commit = %v", changes)
+	}
+
+	_, data = observeChanges(`{"what":"changes","since_commit":"cafe0000"}`)
+	if since := data["since_commit"].(map[string]any); since["note"] == nil || len(data["changes"].([]any)) != 2 {
+		t.Fatalf("ancestor commit = %v", data)
+	}
+
+	result, _ := observeChanges(`{"what":"changes","since_commit":"dead0000"}`)
+	if !result.IsError || !strings.Contains(firstText(result), "aaaa11112222") {
+		t.Fatalf("unknown commit should fail listing tracked commits, got: %s", firstText(result))
+	}
+	result, _ = observeChanges(`{"what":"changes","since_commit":"bbbb4444","after_seq":1}`)
+	if !result.IsError {
+		t.Fatal("since_commit with after_seq should fail")
+	}
+	result, _ = observeChanges(`{"what":"changes","since_commit":"not-a-sha"}`)
+	if !result.IsError {
+		t.Fatal("non-hex commit should fail")
+	}
+
+	if sc := cap.GetClientRegistry().SourceControl(clientID); sc == nil || sc.Branch != "main" {
+		t.Fatalf("registry source_control = %+v; only refreshSourceControl updates it", sc)
+	}
+	h.refreshSourceControl(clientID)
+	if sc := cap.GetClientRegistry().SourceControl(clientID); sc == nil || sc.Branch != "main" {
+		t.Fatalf("refresh inside the interval should not re-read: %+v", sc)
+	}
+}
+
+func jsonString(v any) string {
+	b, _ := json.Marshal(v)
+	return string(b)
+}
@@ -40,7 +40,7 @@ Key patterns:
 //
 // Invariants:
 // - toolHandler is expected to be set once during bootstrap before serving requests.
-// - telemetryCursors is guarded by telemetryMu.
+// - telemetryCursors is guarded by telemetryMu; protocolVersions by protocolMu.
 //
 // Failure semantics:
 // - Unknown methods/tools return JSON-RPC method-not-found errors.
@@ -52,6 +52,9 @@ type MCPHandler struct {
 
 	telemetryMu      sync.Mutex
 	telemetryCursors map[string]passiveTelemetryCursor
+
+	protocolMu       sync.Mutex
+	protocolVersions map[string]string // client ID -> version negotiated at initialize; created lazily
 }
 
 // ToolHandlerInterface defines the minimal tool handler interface.
@@ -63,9 +66,9 @@ type ToolHandlerInterface interface {
 	HandleToolCall(req JSONRPCRequest, name string, arguments json.RawMessage) (JSONRPCResponse, bool)
 }
 
-// RateLimiter interface for tool call rate limiting.
+// RateLimiter interface for per-client tool call rate limiting.
 type RateLimiter interface {
-	Allow() bool
+	Check(clientID string) RateLimitDecision
 }
 
 // RedactionEngine interface for response redaction.
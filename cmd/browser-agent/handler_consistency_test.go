@@ -36,6 +36,7 @@ func TestAllPOSTEndpoints_RejectGET(t *testing.T) {
 
 	postOnlyEndpoints := []string{
 		"/websocket-events",
+		"/sse-events",
 		"/network-bodies",
 		"/network-waterfall",
 		"/query-result",
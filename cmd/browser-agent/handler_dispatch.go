@@ -20,6 +20,7 @@ var mcpMethodHandlers = map[string]mcpMethodHandler{
 	"resources/list":           func(h *MCPHandler, req JSONRPCRequest) JSONRPCResponse { return h.handleResourcesList(req) },
 	"resources/read":           func(h *MCPHandler, req JSONRPCRequest) JSONRPCResponse { return h.handleResourcesRead(req) },
 	"resources/templates/list": func(h *MCPHandler, req JSONRPCRequest) JSONRPCResponse { return h.handleResourcesTemplatesList(req) },
+	"completion/complete":      func(h *MCPHandler, req JSONRPCRequest) JSONRPCResponse { return h.handleCompletionComplete(req) },
 }
 
 // mcpStaticResponses maps MCP methods to static JSON result bodies.
@@ -88,6 +89,7 @@ func (h *MCPHandler) HandleRequest(req JSONRPCRequest) *JSONRPCResponse {
 
 func (h *MCPHandler) handleInitialize(req JSONRPCRequest) JSONRPCResponse {
 	negotiatedVersion := negotiateProtocolVersion(req.Params)
+	h.rememberProtocolVersion(req.ClientID, negotiatedVersion)
 
 	result := MCPInitializeResult{
 		ProtocolVersion: negotiatedVersion,
@@ -95,10 +97,7 @@ func (h *MCPHandler) handleInitialize(req JSONRPCRequest) JSONRPCResponse {
 			Name:    mcpServerName,
 			Version: h.version,
 		},
-		Capabilities: MCPCapabilities{
-			Tools:     MCPToolsCapability{},
-			Resources: MCPResourcesCapability{},
-		},
+		Capabilities: mcp.CapabilitiesForProtocolVersion(negotiatedVersion),
 		Instructions: serverInstructions,
 	}
 
@@ -129,6 +128,10 @@ func (h *MCPHandler) handleResourcesRead(req JSONRPCRequest) JSONRPCResponse {
 		}
 	}
 
+	if isLiveResourceURI(params.URI) {
+		return h.handleLiveResourceRead(req, params.URI)
+	}
+
 	canonicalURI, text, ok := resolveResourceContent(params.URI)
 	if !ok {
 		return JSONRPCResponse{
@@ -149,6 +152,27 @@ func (h *MCPHandler) handleResourcesRead(req JSONRPCRequest) JSONRPCResponse {
 	return succeedRaw(req, resultJSON)
 }
 
+// handleLiveResourceRead renders a live capture snapshot through the tool handler.
+func (h *MCPHandler) handleLiveResourceRead(req JSONRPCRequest, uri string) JSONRPCResponse {
+	th, _ := h.toolHandler.(*ToolHandler)
+	if th == nil {
+		return JSONRPCResponse{JSONRPC: JSONRPCVersion, ID: req.ID, Error: &JSONRPCError{Code: -32603, Message: "Live resources unavailable: tool handler not initialized"}}
+	}
+	text, errMsg, found := th.readLiveResource(uri)
+	if !found {
+		return JSONRPCResponse{JSONRPC: JSONRPCVersion, ID: req.ID, Error: &JSONRPCError{Code: -32002, Message: "Resource not found: " + uri}}
+	}
+	if errMsg != "" {
+		return JSONRPCResponse{JSONRPC: JSONRPCVersion, ID: req.ID, Error: &JSONRPCError{Code: -32603, Message: "Resource read failed: " + errMsg}}
+	}
+	result := MCPResourcesReadResult{Contents: []MCPResourceContent{
+		{URI: uri, MimeType: liveResourceMimeType, Text: text},
+	}}
+	// Error impossible: MCPResourcesReadResult is a simple struct with no circular refs or unsupported types
+	resultJSON, _ := json.Marshal(result)
+	return succeedRaw(req, resultJSON)
+}
+
 func (h *MCPHandler) handleResourcesTemplatesList(req JSONRPCRequest) JSONRPCResponse {
 	result := MCPResourceTemplatesListResult{ResourceTemplates: mcpResourceTemplates()}
 	// Error impossible: MCPResourceTemplatesListResult is a simple struct with no circular refs or unsupported types
@@ -159,7 +183,7 @@ func (h *MCPHandler) handleResourcesTemplatesList(req JSONRPCRequest) JSONRPCRes
 func (h *MCPHandler) handleToolsList(req JSONRPCRequest) JSONRPCResponse {
 	var tools []MCPTool
 	if h.toolHandler != nil {
-		tools = h.toolHandler.ToolsList()
+		tools = mcp.ToolsForProtocolVersion(h.toolHandler.ToolsList(), h.protocolVersionFor(req))
 	}
 
 	result := MCPToolsListResult{Tools: tools}
@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/mcp"
 )
 
 // httpRequestContext collects metadata from an HTTP request for debug logging.
@@ -72,6 +73,18 @@ func (h *MCPHandler) logDebugEntry(ctx httpRequestContext, requestBody string, s
 	cap.LogHTTPDebugEntry(entry)
 }
 
+// responseErrorMessage returns the debug-log error for a 200 response that still failed:
+// the JSON-RPC error message, or "tool error" for an isError tool result.
+func responseErrorMessage(resp JSONRPCResponse) string {
+	if resp.Error != nil {
+		return resp.Error.Message
+	}
+	if isErrorResponse(resp) {
+		return "tool error"
+	}
+	return ""
+}
+
 // truncatePreview returns s truncated to 1000 characters with "..." suffix.
 func truncatePreview(s string) string {
 	if len(s) > 1000 {
@@ -83,11 +96,18 @@ func truncatePreview(s string) string {
 // HandleHTTP serves MCP-over-HTTP with bounded body size and debug logging.
 //
 // Failure semantics:
-// - Non-POST or malformed JSON requests return protocol errors without invoking tool handlers.
+// - GET with Accept: text/event-stream opens the notification stream (see serveNotificationStream).
+// - Other non-POST or malformed JSON requests return protocol errors without invoking tool handlers.
 // - Notification requests are acknowledged with HTTP 204 and no JSON-RPC body.
+// - An unsupported MCP-Protocol-Version header is rejected with HTTP 400 (SPEC:MCP 2025-06-18).
 func (h *MCPHandler) HandleHTTP(w http.ResponseWriter, r *http.Request) {
 	ctx := newHTTPRequestContext(r, h.version)
 
+	if r.Method == http.MethodGet && acceptsEventStream(r) {
+		h.serveNotificationStream(w, r)
+		return
+	}
+
 	if r.Method != "POST" {
 		jsonResponse(w, http.StatusMethodNotAllowed, map[string]string{"error": "Method not allowed"})
 		return
@@ -118,6 +138,17 @@ func (h *MCPHandler) HandleHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 
 	req.ClientID = ctx.clientID
+	req.ProtocolVersion = r.Header.Get("MCP-Protocol-Version")
+	if req.ProtocolVersion != "" && !mcp.IsSupportedProtocolVersion(req.ProtocolVersion) {
+		h.logDebugEntry(ctx, requestPreview, http.StatusBadRequest, "", "Unsupported MCP-Protocol-Version: "+req.ProtocolVersion)
+		jsonResponse(w, http.StatusBadRequest, JSONRPCResponse{
+			JSONRPC: JSONRPCVersion,
+			ID:      req.ID,
+			Error: &JSONRPCError{Code: -32600, Message: "Unsupported MCP-Protocol-Version: " + req.ProtocolVersion,
+				Data: map[string]any{"supported": mcp.SupportedProtocolVersions}},
+		})
+		return
+	}
 	resp := h.HandleRequest(req)
 
 	if resp == nil {
@@ -127,7 +158,7 @@ func (h *MCPHandler) HandleHTTP(w http.ResponseWriter, r *http.Request) {
 
 	// Error impossible: simple struct with no circular refs or unsupported types
 	responseJSON, _ := json.Marshal(resp)
-	h.logDebugEntry(ctx, requestPreview, http.StatusOK, truncatePreview(string(responseJSON)), "")
+	h.logDebugEntry(ctx, requestPreview, http.StatusOK, truncatePreview(string(responseJSON)), responseErrorMessage(*resp))
 
 	w.Header().Set("Content-Type", "application/json")
 	_ = json.NewEncoder(w).Encode(resp) //nolint:errcheck // best-effort HTTP response write
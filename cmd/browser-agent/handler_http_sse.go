@@ -0,0 +1,101 @@
+// Purpose: Serves GET /mcp as a server-sent events stream of MCP notifications for HTTP-transport clients.
+// Why: Lets agents react to console errors, 5xx responses, CI results, and circuit openings without polling observe.
+// Docs: docs/features/feature/push-alerts/index.md
+
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/notifyhub"
+)
+
+// sseKeepAliveInterval keeps idle streams open through proxies and detects dead clients.
+const sseKeepAliveInterval = 25 * time.Second
+
+// acceptsEventStream reports whether the request asked for an SSE stream.
+func acceptsEventStream(r *http.Request) bool {
+	return strings.Contains(r.Header.Get("Accept"), "text/event-stream")
+}
+
+// notifyHub returns the notification hub, or nil when toolHandler is a test double.
+func (h *MCPHandler) notifyHub() *notifyhub.Hub {
+	if th, ok := h.toolHandler.(*ToolHandler); ok {
+		return th.notifyHub
+	}
+	return nil
+}
+
+// serveNotificationStream holds the connection open and writes one SSE event per notification.
+//
+// Failure semantics:
+//   - Clients are identified by X-Kaboom-Client (or ?client_id= for browser EventSource);
+//     configure(action="subscribe") from the same client ID filters the stream.
+//   - The server WriteTimeout is lifted for this response only; a failed write ends the stream.
+//   - Notifications that arrive while the client is not reading are dropped, never queued unbounded.
+func (h *MCPHandler) serveNotificationStream(w http.ResponseWriter, r *http.Request) {
+	hub := h.notifyHub()
+	flusher, ok := w.(http.Flusher)
+	if hub == nil || !ok {
+		jsonResponse(w, http.StatusNotImplemented, map[string]string{"error": "Notification stream unavailable"})
+		return
+	}
+
+	clientID := r.Header.Get("X-Kaboom-Client")
+	if clientID == "" {
+		clientID = r.URL.Query().Get("client_id")
+	}
+	session := hub.Open(clientID)
+	defer hub.Close(session)
+
+	_ = http.NewResponseController(w).SetWriteDeadline(time.Time{})
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.Header().Set("X-Kaboom-Stream-Id", session.ID)
+	w.WriteHeader(http.StatusOK)
+	if _, err := fmt.Fprintf(w, ": connected %s\n\n", session.ID); err != nil {
+		return
+	}
+	flusher.Flush()
+
+	keepAlive := time.NewTicker(sseKeepAliveInterval)
+	defer keepAlive.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-keepAlive.C:
+			if _, err := fmt.Fprint(w, ": keepalive\n\n"); err != nil {
+				return
+			}
+		case n, open := <-session.Notifications():
+			if !open {
+				return
+			}
+			if err := writeSSENotification(w, n); err != nil {
+				return
+			}
+		}
+		flusher.Flush()
+	}
+}
+
+// writeSSENotification writes one notification as an SSE "message" event.
+// The change-feed sequence, when present, becomes the event id.
+func writeSSENotification(w http.ResponseWriter, n notifyhub.Notification) error {
+	frame, err := n.Frame()
+	if err != nil {
+		return nil // unencodable payloads are skipped, not fatal to the stream
+	}
+	if n.Seq > 0 {
+		if _, err := fmt.Fprintf(w, "id: %d\n", n.Seq); err != nil {
+			return err
+		}
+	}
+	_, err = fmt.Fprintf(w, "event: message\ndata: %s\n\n", frame)
+	return err
+}
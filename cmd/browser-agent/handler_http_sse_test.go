@@ -0,0 +1,135 @@
+// Purpose: Tests the GET /mcp notification stream end to end with per-client subscribe filters.
+// Docs: docs/features/feature/push-alerts/index.md
+
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+)
+
+// openNotificationStream connects to GET /mcp and returns a channel of SSE data payloads.
+func openNotificationStream(t *testing.T, baseURL, clientID string) <-chan string {
+	t.Helper()
+	req, _ := http.NewRequest(http.MethodGet, baseURL+"/mcp", nil)
+	req.Header.Set("Accept", "text/event-stream")
+	req.Header.Set("X-Kaboom-Client", clientID)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("open stream: %v", err)
+	}
+	t.Cleanup(func() { _ = resp.Body.Close() })
+	if ct := resp.Header.Get("Content-Type"); resp.StatusCode != http.StatusOK || ct != "text/event-stream" {
+		t.Fatalf("stream status=%d content-type=%q", resp.StatusCode, ct)
+	}
+
+	events := make(chan string, 16)
+	connected := make(chan struct{})
+	go func() {
+		defer close(events)
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			line := scanner.Text()
+			if strings.HasPrefix(line, ": connected") {
+				close(connected)
+			}
+			if data, ok := strings.CutPrefix(line, "data: "); ok {
+				events <- data
+			}
+		}
+	}()
+	select {
+	case <-connected:
+	case <-time.After(2 * time.Second):
+		t.Fatal("stream never sent the connected comment")
+	}
+	return events
+}
+
+func postConfigure(t *testing.T, baseURL, clientID, args string) map[string]any {
+	t.Helper()
+	body := `{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"configure","arguments":` + args + `}}`
+	req, _ := http.NewRequest(http.MethodPost, baseURL+"/mcp", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Kaboom-Client", clientID)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("post configure: %v", err)
+	}
+	defer resp.Body.Close()
+	var rpc JSONRPCResponse
+	if err := json.NewDecoder(resp.Body).Decode(&rpc); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	result := parseToolResult(t, rpc)
+	if result.IsError {
+		t.Fatalf("configure failed: %s", firstText(result))
+	}
+	return extractResultJSON(t, result)
+}
+
+func TestNotificationStream_PushesSubscribedEvents(t *testing.T) {
+	t.Parallel()
+	server, err := NewServer(t.TempDir()+"/test.jsonl", 100)
+	if err != nil {
+		t.Fatalf("NewServer: %v", err)
+	}
+	t.Cleanup(func() { server.Close() })
+	cap := capture.NewCapture()
+	mcp := NewToolHandler(server, cap)
+	ts := httptest.NewServer(http.HandlerFunc(mcp.HandleHTTP))
+	t.Cleanup(ts.Close)
+
+	events := openNotificationStream(t, ts.URL, "agent-a")
+	data := postConfigure(t, ts.URL, "agent-a", `{"what":"subscribe","events":["network_error"]}`)
+	sub := data["subscription"].(map[string]any)
+	if sub["client_sessions"] != float64(1) || sub["events"].([]any)[0] != "network_error" {
+		t.Fatalf("subscription = %v", sub)
+	}
+
+	server.logs.addEntries([]LogEntry{{"level": "error", "message": "filtered out"}})
+	cap.AddNetworkBodies([]capture.NetworkBody{{Method: "GET", URL: "https://app.test/api/cart", Status: 200}, {Method: "POST", URL: "https://app.test/api/checkout", Status: 502}})
+
+	select {
+	case raw := <-events:
+		var frame struct {
+			Method string `json:"method"`
+			Params struct {
+				Level string         `json:"level"`
+				Data  map[string]any `json:"data"`
+			} `json:"params"`
+		}
+		if err := json.Unmarshal([]byte(raw), &frame); err != nil {
+			t.Fatalf("frame %q: %v", raw, err)
+		}
+		if frame.Method != "notifications/message" || frame.Params.Data["event"] != "network_error" || frame.Params.Data["url"] != "https://app.test/api/checkout" {
+			t.Fatalf("frame = %s", raw)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("no notification pushed for the 502")
+	}
+
+	select {
+	case raw := <-events:
+		t.Fatalf("console_error should be filtered for this client, got %s", raw)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestNotificationStream_RequiresEventStreamAccept(t *testing.T) {
+	t.Parallel()
+	h, _, _ := makeToolHandler(t)
+	mcp := &MCPHandler{toolHandler: h}
+	rec := httptest.NewRecorder()
+	mcp.HandleHTTP(rec, httptest.NewRequest(http.MethodGet, "/mcp", nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Fatalf("plain GET status = %d, want 405", rec.Code)
+	}
+}
@@ -7,7 +7,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"sort"
+	"time"
 
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/mcp"
 	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/telemetry"
 )
 
@@ -15,8 +17,10 @@ import (
 //
 // Failure semantics:
 // - Invalid JSON args, missing tool handler, unknown tool, and rate-limit breaches are explicit errors.
-// - Tool post-processing (redaction/warnings/telemetry) is best-effort and never blocks success path.
+// - Tool post-processing (redaction/warnings/telemetry/timing) is best-effort and never blocks success path.
+// - structuredContent is removed for clients that negotiated a version older than 2025-06-18.
 func (h *MCPHandler) handleToolsCall(req JSONRPCRequest) JSONRPCResponse {
+	start := time.Now()
 	var params struct {
 		Name      string          `json:"name"`
 		Arguments json.RawMessage `json:"arguments"`
@@ -38,12 +42,13 @@ func (h *MCPHandler) handleToolsCall(req JSONRPCRequest) JSONRPCResponse {
 
 	h.warnUnknownToolArguments(params.Name, params.Arguments)
 
-	if err := h.checkToolRateLimit(); err != nil {
+	if err := h.checkToolRateLimit(req.ClientID); err != nil {
 		telemetry.AppError("tool_rate_limited", nil)
 		return JSONRPCResponse{JSONRPC: JSONRPCVersion, ID: req.ID, Error: err}
 	}
 
 	resp, handled := h.toolHandler.HandleToolCall(req, params.Name, params.Arguments)
+	dispatched := time.Now()
 	if !handled {
 		return JSONRPCResponse{
 			JSONRPC: JSONRPCVersion, ID: req.ID,
@@ -52,23 +57,36 @@ func (h *MCPHandler) handleToolsCall(req JSONRPCRequest) JSONRPCResponse {
 	}
 
 	telemetryModeOverride := parseTelemetryModeOverride(params.Arguments)
+	raw := resp
 	resp = h.applyToolResponsePostProcessing(resp, req.ClientID, params.Name, telemetryModeOverride)
-	return resp
+	resp = h.attachToolTiming(resp, raw, params.Name, params.Arguments, start, dispatched)
+	return mcp.ToolResultForProtocolVersion(resp, h.protocolVersionFor(req))
 }
 
-// checkToolRateLimit enforces per-process tool call throttling.
+// checkToolRateLimit enforces per-client tool call throttling.
 //
 // Failure semantics:
 // - Nil limiter means unlimited mode.
-func (h *MCPHandler) checkToolRateLimit() *JSONRPCError {
+// - Rejections carry the RateLimitDecision (limit, remaining, reset_at, retry_after_ms) as error data.
+func (h *MCPHandler) checkToolRateLimit(clientID string) *JSONRPCError {
 	limiter := h.toolHandler.GetToolCallLimiter()
-	if limiter != nil && !limiter.Allow() {
-		return &JSONRPCError{
-			Code:    -32603,
-			Message: "Tool call rate limit exceeded (500 calls/minute). Please wait before retrying.",
-		}
+	if limiter == nil {
+		return nil
+	}
+	decision := limiter.Check(clientID)
+	if decision.Allowed {
+		return nil
+	}
+	what := "rate limit"
+	if decision.Scope == rateLimitScopeQuota {
+		what = "quota"
+	}
+	return &JSONRPCError{
+		Code: -32603,
+		Message: fmt.Sprintf("Tool call %s exceeded (%d calls per %s for client %s). Retry after %s.",
+			what, decision.Limit, decision.Window, decision.ClientID, time.Duration(decision.RetryAfterMs)*time.Millisecond),
+		Data: decision,
 	}
-	return nil
 }
 
 func (h *MCPHandler) warnUnknownToolArguments(toolName string, args json.RawMessage) {
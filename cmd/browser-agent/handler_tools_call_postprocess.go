@@ -11,7 +11,8 @@ import (
 	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/mcp"
 )
 
-// applyToolResponsePostProcessing applies redaction and operator warnings to tool output.
+// applyToolResponsePostProcessing applies redaction and operator warnings to tool output,
+// then derives structuredContent from the final text so both carry the same redacted data.
 func (h *MCPHandler) applyToolResponsePostProcessing(resp JSONRPCResponse, clientID, toolName, telemetryModeOverride string) JSONRPCResponse {
 	redactor := h.toolHandler.GetRedactionEngine()
 	if redactor != nil && resp.Result != nil {
@@ -25,7 +26,9 @@ func (h *MCPHandler) applyToolResponsePostProcessing(resp JSONRPCResponse, clien
 	resp = maybeAddUpdateAvailableWarning(resp)
 	resp = maybeAddUpgradeWarning(resp)
 	resp = h.maybeAddPendingIntents(resp)
-	return h.maybeAddTelemetrySummary(resp, clientID, toolName, telemetryModeOverride)
+	resp = h.maybeStampSourceControl(resp, clientID, toolName)
+	resp = h.maybeAddTelemetrySummary(resp, clientID, toolName, telemetryModeOverride)
+	return mcp.AttachStructuredContent(resp)
 }
 
 // maybeAddPendingIntents prepends a strong nudge when the user has requested an audit
@@ -19,7 +19,9 @@ type testLimiter struct {
 	allowed bool
 }
 
-func (l testLimiter) Allow() bool { return l.allowed }
+func (l testLimiter) Check(clientID string) RateLimitDecision {
+	return RateLimitDecision{Allowed: l.allowed, ClientID: clientID}
+}
 
 type testRedactor struct {
 	replacement json.RawMessage
@@ -218,8 +220,8 @@ func TestMCPHandlerResourceAndToolMethods(t *testing.T) {
 		t.Fatalf("resources/list response = %+v, want success", resources)
 	}
 	resourceData := mustDecodeJSON[MCPResourcesListResult](t, resources.Result)
-	if len(resourceData.Resources) != 3 {
-		t.Fatalf("resources/list result = %+v, want 3 resources", resourceData)
+	if len(resourceData.Resources) != 6 {
+		t.Fatalf("resources/list result = %+v, want 6 resources", resourceData)
 	}
 	if resourceData.Resources[0].URI != "kaboom://capabilities" {
 		t.Fatalf("resources/list first resource = %q, want kaboom://capabilities", resourceData.Resources[0].URI)
@@ -230,6 +232,9 @@ func TestMCPHandlerResourceAndToolMethods(t *testing.T) {
 	if resourceData.Resources[2].URI != "kaboom://quickstart" {
 		t.Fatalf("resources/list third resource = %q, want kaboom://quickstart", resourceData.Resources[2].URI)
 	}
+	if resourceData.Resources[3].URI != "kaboom://errors/latest" || resourceData.Resources[3].MimeType != "application/json" {
+		t.Fatalf("resources/list fourth resource = %+v, want live kaboom://errors/latest", resourceData.Resources[3])
+	}
 
 	readCapabilities := h.HandleRequest(JSONRPCRequest{
 		JSONRPC: "2.0",
@@ -634,4 +639,37 @@ func TestMCPHandlerToolRateLimit(t *testing.T) {
 	}
 }
 
+func TestMCPHandlerToolRateLimit_ErrorData(t *testing.T) {
+	t.Parallel()
+
+	h := NewMCPHandler(nil, "v")
+	h.SetToolHandler(&fakeToolHandlerForMCP{
+		cap:     capture.NewCapture(),
+		limiter: NewToolCallLimiter(1, time.Minute),
+	})
+
+	call := func() *JSONRPCResponse {
+		return h.HandleRequest(JSONRPCRequest{
+			JSONRPC:  "2.0",
+			ID:       1,
+			Method:   "tools/call",
+			Params:   json.RawMessage(`{"name":"observe","arguments":{}}`),
+			ClientID: "agent-a",
+		})
+	}
+	call()
+	resp := call()
+	if resp == nil || resp.Error == nil {
+		t.Fatalf("second call = %+v, want rate-limit error", resp)
+	}
+	d, ok := resp.Error.Data.(RateLimitDecision)
+	if !ok || d.ClientID != "agent-a" || d.Remaining != 0 || d.RetryAfterMs <= 0 || d.ResetAt.IsZero() {
+		t.Fatalf("error data = %#v, want structured decision for agent-a", resp.Error.Data)
+	}
+	raw, _ := json.Marshal(resp.Error)
+	if !strings.Contains(string(raw), `"reset_at"`) || !strings.Contains(string(raw), `"retry_after_ms"`) {
+		t.Fatalf("serialized error missing reset metadata: %s", raw)
+	}
+}
+
 // Warning tests (upgrade/update) moved to handler_warning_test.go
@@ -28,9 +28,9 @@ func (a *serverDepsAdapter) GetConsoleStats() (int, int, int64) {
 		return 0, defaultMaxEntries, 0
 	}
 	a.s.logs.mu.RLock()
-	entries := len(a.s.logs.entries)
+	entries, capacity := len(a.s.logs.entries), a.s.logs.maxEntries
 	a.s.logs.mu.RUnlock()
-	return entries, a.s.logs.maxEntries, a.s.logs.getLogDropCount()
+	return entries, capacity, a.s.logs.getLogDropCount()
 }
 
 // defaultMaxEntries mirrors the health package's default for nil servers.
@@ -4,6 +4,8 @@
 
 package main
 
+import "github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/cmd/browser-agent/internal/health"
+
 // toolGetHealth is the MCP tool handler for get_health.
 // It returns comprehensive server health metrics.
 func (h *ToolHandler) toolGetHealth(req JSONRPCRequest) JSONRPCResponse {
@@ -12,5 +14,14 @@ func (h *ToolHandler) toolGetHealth(req JSONRPCRequest) JSONRPCResponse {
 	}
 
 	response := getHealthResponse(h.healthMetrics, h.capture, h.server, version)
+	if h.readOnly != nil {
+		response.ReadOnly = &health.ReadOnlyInfo{
+			Enabled:         true,
+			Reason:          h.readOnly.Reason,
+			BundlePath:      h.readOnly.BundlePath,
+			BundleCreatedAt: h.readOnly.BundleCreatedAt,
+			BundleLabel:     h.readOnly.BundleLabel,
+		}
+	}
 	return succeed(req, "Server health", response)
 }
@@ -6,6 +6,7 @@ package bridge
 
 import (
 	"encoding/json"
+	"sync/atomic"
 
 	internbridge "github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/bridge"
 	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/mcp"
@@ -18,6 +19,17 @@ var fastPathResponses = map[string]string{
 	"prompts/list": `{"prompts":[]}`,
 }
 
+// bridgeProtocolVersion holds the version negotiated by the stdio client's initialize.
+var bridgeProtocolVersion atomic.Pointer[string]
+
+// negotiatedProtocolVersion returns the stdio client's negotiated version, or "" before initialize.
+func negotiatedProtocolVersion() string {
+	if v := bridgeProtocolVersion.Load(); v != nil {
+		return *v
+	}
+	return ""
+}
+
 // sendFastResponse marshals and sends a JSON-RPC response for the fast path.
 func sendFastResponse(id any, result json.RawMessage, framing internbridge.StdioFraming) {
 	resp := mcp.JSONRPCResponse{JSONRPC: mcp.JSONRPCVersion, ID: id, Result: result}
@@ -56,10 +68,13 @@ func handleFastPath(req mcp.JSONRPCRequest, toolsList []mcp.MCPTool, framing int
 		deps.SetPushClientCapabilities(caps)
 		deps.StoreBridgeFraming(framing)
 
+		version := deps.NegotiateProtocolVersion(req.Params)
+		bridgeProtocolVersion.Store(&version)
+
 		result := map[string]any{
-			"protocolVersion": deps.NegotiateProtocolVersion(req.Params),
+			"protocolVersion": version,
 			"serverInfo":      map[string]any{"name": deps.MCPServerName, "version": deps.Version},
-			"capabilities":    map[string]any{"tools": map[string]any{}, "resources": map[string]any{}},
+			"capabilities":    mcp.CapabilitiesForProtocolVersion(version),
 			"instructions":    deps.ServerInstructions,
 		}
 		// Error impossible: map contains only primitive types and nested maps
@@ -76,7 +91,7 @@ func handleFastPath(req mcp.JSONRPCRequest, toolsList []mcp.MCPTool, framing int
 		return true
 
 	case "tools/list":
-		result := map[string]any{"tools": toolsList}
+		result := map[string]any{"tools": mcp.ToolsForProtocolVersion(toolsList, negotiatedProtocolVersion())}
 		// Error impossible: map contains only serializable tool definitions
 		resultJSON, _ := json.Marshal(result)
 		sendFastResponse(req.ID, resultJSON, framing)
@@ -103,6 +118,9 @@ func handleFastPath(req mcp.JSONRPCRequest, toolsList []mcp.MCPTool, framing int
 			sendFastError(req.ID, -32602, "Invalid params: "+err.Error(), framing)
 			return true
 		}
+		if deps.IsLiveResource != nil && deps.IsLiveResource(params.URI) {
+			return false // rendered by the daemon from capture state
+		}
 		canonicalURI, text, ok := deps.ResolveResourceContent(params.URI)
 		if !ok {
 			recordFastPathResourceRead(params.URI, false, -32002)
@@ -47,6 +47,7 @@ func captureBridgeIO(t *testing.T, input string, fn func()) string {
 
 	_, _ = io.WriteString(inW, input)
 	_ = inW.Close()
+	outC := drainPipe(outR)
 
 	fn()
 
@@ -55,12 +56,28 @@ func captureBridgeIO(t *testing.T, input string, fn func()) string {
 	_ = inR.Close()
 	_ = outW.Close()
 
-	out, err := io.ReadAll(outR)
-	if err != nil {
-		t.Fatalf("ReadAll(stdout) error = %v", err)
+	out := <-outC
+	if out.err != nil {
+		t.Fatalf("ReadAll(stdout) error = %v", out.err)
 	}
 	_ = outR.Close()
-	return string(out)
+	return string(out.data)
+}
+
+type pipeOutput struct {
+	data []byte
+	err  error
+}
+
+// drainPipe reads r until EOF in the background, so output larger than the
+// pipe buffer (tools/list is) does not block the writer.
+func drainPipe(r *os.File) <-chan pipeOutput {
+	ch := make(chan pipeOutput, 1)
+	go func() {
+		data, err := io.ReadAll(r)
+		ch <- pipeOutput{data: data, err: err}
+	}()
+	return ch
 }
 
 func captureBridgeIOWithStderr(t *testing.T, input string, fn func()) (string, string) {
@@ -88,6 +105,7 @@ func captureBridgeIOWithStderr(t *testing.T, input string, fn func()) (string, s
 
 	_, _ = io.WriteString(inW, input)
 	_ = inW.Close()
+	outC, errC := drainPipe(outR), drainPipe(errR)
 
 	fn()
 
@@ -98,18 +116,18 @@ func captureBridgeIOWithStderr(t *testing.T, input string, fn func()) (string, s
 	_ = outW.Close()
 	_ = errW.Close()
 
-	stdout, readErr := io.ReadAll(outR)
-	if readErr != nil {
-		t.Fatalf("ReadAll(stdout) error = %v", readErr)
+	stdout := <-outC
+	if stdout.err != nil {
+		t.Fatalf("ReadAll(stdout) error = %v", stdout.err)
 	}
 	_ = outR.Close()
-	stderr, readErr := io.ReadAll(errR)
-	if readErr != nil {
-		t.Fatalf("ReadAll(stderr) error = %v", readErr)
+	stderr := <-errC
+	if stderr.err != nil {
+		t.Fatalf("ReadAll(stderr) error = %v", stderr.err)
 	}
 	_ = errR.Close()
 
-	return string(stdout), string(stderr)
+	return string(stdout.data), string(stderr.data)
 }
 
 func parseJSONLines(t *testing.T, output string) []mcp.JSONRPCResponse {
@@ -380,6 +398,19 @@ func TestBridgeFastPathResourcesReadFailureTelemetry(t *testing.T) {
 	}
 }
 
+func TestBridgeFastPathResourcesReadForwardsLiveResources(t *testing.T) {
+	resetFastPathResourceReadCounters()
+	for _, uri := range []string{"kaboom://errors/latest", "kaboom://observe/logs"} {
+		req := mcp.JSONRPCRequest{JSONRPC: "2.0", ID: float64(1), Method: "resources/read", Params: json.RawMessage(`{"uri":"` + uri + `"}`)}
+		if handleFastPath(req, nil, 0) {
+			t.Fatalf("live resource %s handled by fast path, want forwarded to daemon", uri)
+		}
+	}
+	if success, failure := SnapshotFastPathResourceReadCounters(); success != 0 || failure != 0 {
+		t.Fatalf("fast-path resources/read counters = (%d,%d), want (0,0)", success, failure)
+	}
+}
+
 func TestBridgeFastPathResourcesReadTelemetryPersistsToStateLogs(t *testing.T) {
 	// Do not run in parallel; test redirects process stdio and env.
 	resetFastPathResourceReadCounters()
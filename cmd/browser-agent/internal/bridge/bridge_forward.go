@@ -18,9 +18,9 @@ import (
 	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/telemetry"
 )
 
-// bridgeDoHTTP delegates to internal/bridge for HTTP forwarding.
+// bridgeDoHTTP delegates to internal/bridge for HTTP forwarding, tagged with the negotiated protocol version.
 func bridgeDoHTTP(ctx context.Context, client *http.Client, endpoint string, line []byte) (*http.Response, error) {
-	return internbridge.DoHTTP(ctx, client, endpoint, line)
+	return internbridge.DoHTTP(ctx, client, endpoint, line, negotiatedProtocolVersion())
 }
 
 // bridgeForwardRequest forwards a JSON-RPC request to the HTTP server and writes the response.
@@ -109,7 +109,7 @@ func healDaemonReadyStateIfRunning(state *daemonState, isReady bool, isFailed bo
 // checkDaemonStatus returns an error string if the daemon is not ready, or "" if ready.
 func checkDaemonStatus(state *daemonState, req mcp.JSONRPCRequest, port int) string {
 	// Validate method requires daemon
-	if req.Method != "tools/call" && !strings.HasPrefix(req.Method, "tools/") && !strings.HasPrefix(req.Method, "resources/") {
+	if req.Method != "tools/call" && !strings.HasPrefix(req.Method, "tools/") && !strings.HasPrefix(req.Method, "resources/") && req.Method != "completion/complete" {
 		return "method_not_found"
 	}
 
@@ -62,6 +62,9 @@ func TestMain(m *testing.M) {
 			}
 			return "", "", false
 		},
+		IsLiveResource: func(uri string) bool {
+			return uri == "kaboom://errors/latest" || strings.HasPrefix(uri, "kaboom://observe/")
+		},
 		DaemonProcessArgv0:   func(exePath string) string { return exePath },
 		StopServerForUpgrade: func(port int) bool { return false },
 		FindProcessOnPort:    func(port int) ([]int, error) { return nil, nil },
@@ -78,6 +78,10 @@ type Deps struct {
 	// ResolveResourceContent resolves a resource URI to canonical URI, text content, and found flag.
 	ResolveResourceContent func(uri string) (canonicalURI string, text string, ok bool)
 
+	// IsLiveResource reports whether a resource URI is rendered from daemon capture state
+	// and must be forwarded instead of served by the fast path.
+	IsLiveResource func(uri string) bool
+
 	// -- Daemon lifecycle --
 
 	// DaemonProcessArgv0 returns the argv[0] name for spawned daemon processes.
@@ -11,6 +11,9 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/serverregistry"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/state"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/unixsock"
 	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/util"
 )
 
@@ -20,9 +23,13 @@ type RuntimeConfig struct {
 	MaxPostBodySize int64
 
 	// DaemonOps — callbacks into the main package for daemon lifecycle.
-	IsServerRunning     func(port int) bool
-	WaitForServer       func(port int, timeout time.Duration) bool
-	DaemonProcessArgv0  func(exePath string) string
+	IsServerRunning    func(port int) bool
+	WaitForServer      func(port int, timeout time.Duration) bool
+	DaemonProcessArgv0 func(exePath string) string
+	StopDaemon         func(port int)
+	DaemonPID          func(port int) int
+	ProcessAlive       func(pid int) bool
+	DefaultLogFile     func() string
 }
 
 // CLIToolNames lists valid tool names for CLI mode detection.
@@ -38,7 +45,8 @@ var CLIToolNames = map[string]bool{
 type CLIConfig struct {
 	Port    int
 	Format  string
-	Timeout int // milliseconds
+	Timeout int    // milliseconds
+	Socket  string // --listen unix:/path or KABOOM_LISTEN; empty means TCP on Port
 }
 
 // IsCLIMode returns true if the first argument is a known tool name.
@@ -72,7 +80,7 @@ func Run(args []string, rc RuntimeConfig) int {
 	}
 
 	// Ensure daemon is running and get base URL
-	baseURL, err := EnsureDaemon(cfg.Port, rc)
+	baseURL, err := EnsureDaemonFor(cfg, rc)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		return 1
@@ -105,6 +113,10 @@ func ResolveCLIConfig(args []string, rc RuntimeConfig) (CLIConfig, []string) {
 		Timeout: 15000,
 	}
 
+	if portFlag, _ := CLIParseFlag(args, "--port"); portFlag == "" && os.Getenv("KABOOM_PORT") == "" {
+		ApplyServerRegistry(&cfg, rc)
+	}
+
 	ApplyCLIEnvOverrides(&cfg)
 
 	remaining := ApplyCLIFlagOverrides(args, &cfg)
@@ -112,8 +124,41 @@ func ResolveCLIConfig(args []string, rc RuntimeConfig) (CLIConfig, []string) {
 	return cfg, remaining
 }
 
-// ApplyCLIEnvOverrides applies KABOOM_PORT and KABOOM_FORMAT environment variables.
+// ApplyServerRegistry points cfg at the live server registered in servers.json for the
+// current directory's project. Its state dir is adopted too, so PID files resolve and a
+// spawned daemon does not take over another project's server.
+func ApplyServerRegistry(cfg *CLIConfig, rc RuntimeConfig) {
+	if rc.IsServerRunning == nil {
+		return
+	}
+	path, err := serverregistry.Path()
+	if err != nil {
+		return
+	}
+	servers, err := serverregistry.Load(path)
+	if err != nil {
+		return
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		return
+	}
+	_, srv, ok := serverregistry.Lookup(servers, cwd)
+	if !ok || !rc.IsServerRunning(srv.Port) {
+		return
+	}
+	cfg.Port = srv.Port
+	if srv.StateDir != "" {
+		_ = os.Setenv(serverregistry.FileEnv, path)
+		_ = os.Setenv(state.StateDirEnv, srv.StateDir)
+	}
+}
+
+// ApplyCLIEnvOverrides applies KABOOM_PORT, KABOOM_LISTEN, and KABOOM_FORMAT environment variables.
 func ApplyCLIEnvOverrides(cfg *CLIConfig) {
+	if envListen := os.Getenv("KABOOM_LISTEN"); envListen != "" {
+		cfg.Socket = envListen
+	}
 	if envPort := os.Getenv("KABOOM_PORT"); envPort != "" {
 		if p, err := strconv.Atoi(envPort); err == nil {
 			cfg.Port = p
@@ -124,10 +169,16 @@ func ApplyCLIEnvOverrides(cfg *CLIConfig) {
 	}
 }
 
-// ApplyCLIFlagOverrides strips --port, --format, --timeout flags and applies their values.
+// ApplyCLIFlagOverrides strips --port, --listen, --format, --timeout flags and applies their values.
 func ApplyCLIFlagOverrides(args []string, cfg *CLIConfig) []string {
 	remaining := args
 
+	var listen string
+	listen, remaining = CLIParseFlag(remaining, "--listen")
+	if listen != "" {
+		cfg.Socket = listen
+	}
+
 	var portStr string
 	portStr, remaining = CLIParseFlag(remaining, "--port")
 	if portStr != "" {
@@ -153,6 +204,29 @@ func ApplyCLIFlagOverrides(args []string, cfg *CLIConfig) []string {
 	return remaining
 }
 
+// EnsureDaemonFor returns the base URL of the daemon cfg points at, spawning it if needed.
+// With cfg.Socket set, requests go over the Unix socket and no TCP port is used.
+func EnsureDaemonFor(cfg CLIConfig, rc RuntimeConfig) (string, error) {
+	if cfg.Socket == "" {
+		return EnsureDaemon(cfg.Port, rc)
+	}
+	path, err := unixsock.ParseListen(cfg.Socket)
+	if err != nil {
+		return "", fmt.Errorf("--listen %w", err)
+	}
+	daemonHTTPClient = unixsock.HTTPClient(path, 0)
+	if unixsock.Alive(path, time.Second) {
+		return unixsock.BaseURL, nil
+	}
+	if err := spawnDaemon(rc, "--daemon", "--port", strconv.Itoa(cfg.Port), "--listen", unixsock.Scheme+path); err != nil {
+		return "", err
+	}
+	if !unixsock.WaitAlive(path, 4*time.Second) {
+		return "", fmt.Errorf("daemon started but not responding on unix:%s after 4s", path)
+	}
+	return unixsock.BaseURL, nil
+}
+
 // EnsureDaemon checks if the server is running and spawns it if needed.
 // Returns the base URL (e.g., "http://127.0.0.1:7890").
 func EnsureDaemon(port int, rc RuntimeConfig) (string, error) {
@@ -162,13 +236,26 @@ func EnsureDaemon(port int, rc RuntimeConfig) (string, error) {
 		return baseURL, nil
 	}
 
-	// Spawn daemon
+	if err := spawnDaemon(rc, "--daemon", "--port", fmt.Sprintf("%d", port)); err != nil {
+		return "", err
+	}
+
+	// Wait for daemon to become ready
+	if !rc.WaitForServer(port, 4*time.Second) {
+		return "", fmt.Errorf("daemon started but not responding on port %d after 4s", port)
+	}
+
+	return baseURL, nil
+}
+
+// spawnDaemon starts a detached daemon from our own executable with args.
+func spawnDaemon(rc RuntimeConfig, args ...string) error {
 	exe, err := os.Executable()
 	if err != nil {
-		return "", fmt.Errorf("cannot find executable: %w", err)
+		return fmt.Errorf("cannot find executable: %w", err)
 	}
 
-	cmd := exec.Command(exe, "--daemon", "--port", fmt.Sprintf("%d", port)) // #nosec G204,G702 -- exe is our own binary path from os.Executable with fixed flags // nosemgrep: go.lang.security.audit.dangerous-exec-command.dangerous-exec-command, go_subproc_rule-subproc -- CLI opens browser with known URL
+	cmd := exec.Command(exe, args...) // #nosec G204,G702 -- exe is our own binary path from os.Executable with fixed flags // nosemgrep: go.lang.security.audit.dangerous-exec-command.dangerous-exec-command, go_subproc_rule-subproc -- CLI opens browser with known URL
 	cmd.Args[0] = rc.DaemonProcessArgv0(exe)
 	cmd.Stdout = nil
 	cmd.Stderr = nil
@@ -176,13 +263,7 @@ func EnsureDaemon(port int, rc RuntimeConfig) (string, error) {
 	util.SetDetachedProcess(cmd)
 
 	if err := cmd.Start(); err != nil {
-		return "", fmt.Errorf("failed to start daemon: %w", err)
-	}
-
-	// Wait for daemon to become ready
-	if !rc.WaitForServer(port, 4*time.Second) {
-		return "", fmt.Errorf("daemon started but not responding on port %d after 4s", port)
+		return fmt.Errorf("failed to start daemon: %w", err)
 	}
-
-	return baseURL, nil
+	return nil
 }
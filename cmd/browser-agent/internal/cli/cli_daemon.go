@@ -0,0 +1,383 @@
+// cli_daemon.go — Implements `kaboom status|stop|restart|logs`, supervision of the detached daemon.
+// Why: Managing the background daemon otherwise means lsof + kill; /health answers liveness on every OS
+// and the PID file names the process, so no platform process tooling is needed.
+// Docs: docs/features/feature/daemon-supervision/index.md
+
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"time"
+)
+
+const (
+	// daemonHealthTimeout bounds a single /health probe.
+	daemonHealthTimeout = 2 * time.Second
+	// daemonStopTimeout bounds how long restart waits for the old daemon to release the port.
+	daemonStopTimeout = 5 * time.Second
+	// defaultLogTailLines is how many lines `kaboom logs` prints before following.
+	defaultLogTailLines = 50
+	// logFollowInterval is the polling interval of `kaboom logs --follow`.
+	logFollowInterval = 250 * time.Millisecond
+	// logTailChunk is the block size read backwards from the end of the log.
+	logTailChunk = 64 * 1024
+
+	// ExitDaemonNotRunning is the status exit code when no daemon answers (LSB "program is not running").
+	ExitDaemonNotRunning = 3
+)
+
+// DaemonCommands lists the supervision subcommands.
+var DaemonCommands = map[string]bool{
+	"status":  true,
+	"stop":    true,
+	"restart": true,
+	"logs":    true,
+}
+
+// DaemonStatus is the state reported by `kaboom status`.
+type DaemonStatus struct {
+	State              string `json:"state"` // running, unresponsive, stopped
+	Port               int    `json:"port"`
+	PID                int    `json:"pid,omitempty"`
+	PIDFileStale       bool   `json:"pid_file_stale,omitempty"`
+	Version            string `json:"version,omitempty"`
+	UptimeSeconds      int64  `json:"uptime_seconds,omitempty"`
+	ExtensionConnected bool   `json:"extension_connected"`
+	ExtensionLastSeen  string `json:"extension_last_seen,omitempty"`
+	ExtensionTransport string `json:"extension_transport,omitempty"`
+	LogFile            string `json:"log_file,omitempty"`
+}
+
+// daemonHealth is the subset of /health read by the supervision commands.
+type daemonHealth struct {
+	Version       string `json:"version"`
+	PID           int    `json:"pid"`
+	UptimeSeconds int64  `json:"uptime_seconds"`
+	Logs          struct {
+		LogFile string `json:"log_file"`
+	} `json:"logs"`
+	Capture struct {
+		ExtensionConnected bool   `json:"extension_connected"`
+		ExtensionLastSeen  any    `json:"extension_last_seen"`
+		ExtensionTransport string `json:"extension_transport"`
+	} `json:"capture"`
+}
+
+// IsDaemonCommand returns true for `kaboom status|stop|restart|logs`.
+func IsDaemonCommand(args []string) bool {
+	return len(args) > 0 && DaemonCommands[args[0]]
+}
+
+// RunDaemonCommand runs one supervision subcommand. Returns exit code.
+func RunDaemonCommand(args []string, rc RuntimeConfig) int {
+	cfg, remaining := ResolveCLIConfig(args[1:], rc)
+	switch args[0] {
+	case "status":
+		return runDaemonStatus(cfg, rc)
+	case "stop":
+		return runDaemonStop(cfg, rc)
+	case "restart":
+		return runDaemonRestart(cfg, rc)
+	case "logs":
+		return runDaemonLogs(cfg, remaining, rc)
+	}
+	return 2
+}
+
+// fetchDaemonHealth probes /health on the local daemon.
+func fetchDaemonHealth(port int) (*daemonHealth, error) {
+	client := &http.Client{Timeout: daemonHealthTimeout}
+	resp, err := client.Get(fmt.Sprintf("http://127.0.0.1:%d/health", port)) // #nosec G704 -- localhost-only health probe
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("/health returned HTTP %d", resp.StatusCode)
+	}
+	var h daemonHealth
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&h); err != nil {
+		return nil, fmt.Errorf("decode /health: %w", err)
+	}
+	return &h, nil
+}
+
+// probeDaemonStatus combines /health with the PID file. /health is authoritative for liveness;
+// the PID file only tells a hung daemon apart from a stale file.
+func probeDaemonStatus(port int, rc RuntimeConfig) DaemonStatus {
+	status := DaemonStatus{State: "stopped", Port: port}
+	if h, err := fetchDaemonHealth(port); err == nil {
+		status.State = "running"
+		status.PID = h.PID
+		status.Version = h.Version
+		status.UptimeSeconds = h.UptimeSeconds
+		status.LogFile = h.Logs.LogFile
+		status.ExtensionConnected = h.Capture.ExtensionConnected
+		status.ExtensionTransport = h.Capture.ExtensionTransport
+		if lastSeen, ok := h.Capture.ExtensionLastSeen.(string); ok {
+			status.ExtensionLastSeen = lastSeen
+		}
+		if status.PID == 0 && rc.DaemonPID != nil {
+			status.PID = rc.DaemonPID(port)
+		}
+		return status
+	}
+	if rc.DaemonPID == nil {
+		return status
+	}
+	if pid := rc.DaemonPID(port); pid > 0 {
+		status.PID = pid
+		if rc.ProcessAlive != nil && rc.ProcessAlive(pid) {
+			status.State = "unresponsive"
+		} else {
+			status.PIDFileStale = true
+		}
+	}
+	return status
+}
+
+func runDaemonStatus(cfg CLIConfig, rc RuntimeConfig) int {
+	status := probeDaemonStatus(cfg.Port, rc)
+	if cfg.Format == "json" {
+		out, _ := json.MarshalIndent(status, "", "  ")
+		fmt.Println(string(out)) // lint:stdout-ok -- CLI mode output
+	} else {
+		printDaemonStatus(os.Stdout, status)
+	}
+	switch status.State {
+	case "running":
+		return 0
+	case "unresponsive":
+		return 1
+	default:
+		return ExitDaemonNotRunning
+	}
+}
+
+func printDaemonStatus(w io.Writer, s DaemonStatus) {
+	_, _ = fmt.Fprintf(w, "kaboom daemon: %s\n", s.State)
+	_, _ = fmt.Fprintf(w, "  port:       %d\n", s.Port)
+	if s.PID > 0 {
+		note := ""
+		if s.PIDFileStale {
+			note = " (stale PID file, process gone)"
+		}
+		_, _ = fmt.Fprintf(w, "  pid:        %d%s\n", s.PID, note)
+	}
+	if s.State == "unresponsive" {
+		_, _ = fmt.Fprintf(w, "  note:       process is alive but /health does not answer; try `kaboom restart`\n")
+	}
+	if s.State != "running" {
+		return
+	}
+	_, _ = fmt.Fprintf(w, "  version:    %s\n", s.Version)
+	_, _ = fmt.Fprintf(w, "  uptime:     %s\n", time.Duration(s.UptimeSeconds)*time.Second)
+	extension := "disconnected"
+	if s.ExtensionConnected {
+		extension = "connected"
+		if s.ExtensionTransport != "" {
+			extension += " (" + s.ExtensionTransport + ")"
+		}
+	}
+	if s.ExtensionLastSeen != "" {
+		extension += ", last seen " + s.ExtensionLastSeen
+	}
+	_, _ = fmt.Fprintf(w, "  extension:  %s\n", extension)
+	if s.LogFile != "" {
+		_, _ = fmt.Fprintf(w, "  log file:   %s\n", s.LogFile)
+	}
+}
+
+func runDaemonStop(cfg CLIConfig, rc RuntimeConfig) int {
+	status := probeDaemonStatus(cfg.Port, rc)
+	if status.State == "stopped" {
+		fmt.Printf("No kaboom daemon running on port %d\n", cfg.Port) // lint:stdout-ok -- CLI mode output
+		return 0
+	}
+	rc.StopDaemon(cfg.Port)
+	if !waitForDaemonExit(cfg.Port, rc, daemonStopTimeout) {
+		fmt.Fprintf(os.Stderr, "Error: daemon on port %d is still answering /health\n", cfg.Port)
+		return 1
+	}
+	return 0
+}
+
+func runDaemonRestart(cfg CLIConfig, rc RuntimeConfig) int {
+	if probeDaemonStatus(cfg.Port, rc).State != "stopped" {
+		rc.StopDaemon(cfg.Port)
+		if !waitForDaemonExit(cfg.Port, rc, daemonStopTimeout) {
+			fmt.Fprintf(os.Stderr, "Error: daemon on port %d did not stop; not starting a second one\n", cfg.Port)
+			return 1
+		}
+	} else {
+		fmt.Printf("No kaboom daemon running on port %d; starting one\n", cfg.Port) // lint:stdout-ok -- CLI mode output
+	}
+	if _, err := EnsureDaemon(cfg.Port, rc); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	status := probeDaemonStatus(cfg.Port, rc)
+	fmt.Printf("kaboom daemon restarted on port %d (PID %d, version %s)\n", cfg.Port, status.PID, status.Version) // lint:stdout-ok -- CLI mode output
+	return 0
+}
+
+// waitForDaemonExit polls until the port stops answering, up to timeout.
+func waitForDaemonExit(port int, rc RuntimeConfig, timeout time.Duration) bool {
+	deadline := time.Now().Add(timeout)
+	for {
+		if !rc.IsServerRunning(port) {
+			return true
+		}
+		if time.Now().After(deadline) {
+			return false
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+}
+
+func runDaemonLogs(cfg CLIConfig, args []string, rc RuntimeConfig) int {
+	follow := false
+	rest := args[:0]
+	for _, arg := range args {
+		if arg == "--follow" || arg == "-f" {
+			follow = true
+			continue
+		}
+		rest = append(rest, arg)
+	}
+	lines := defaultLogTailLines
+	linesStr, rest := CLIParseFlag(rest, "--lines")
+	if linesStr != "" {
+		n, err := strconv.Atoi(linesStr)
+		if err != nil || n < 0 {
+			fmt.Fprintf(os.Stderr, "Error: --lines must be a non-negative integer\n")
+			return 2
+		}
+		lines = n
+	}
+	if len(rest) > 0 {
+		fmt.Fprintf(os.Stderr, "Error: unexpected argument %q\nUsage: kaboom logs [--lines N] [--follow]\n", rest[0])
+		return 2
+	}
+
+	// Prefer the running daemon's own log path: it may have been started with --log-file or --state-dir.
+	path := ""
+	if h, err := fetchDaemonHealth(cfg.Port); err == nil {
+		path = h.Logs.LogFile
+	}
+	if path == "" && rc.DefaultLogFile != nil {
+		path = rc.DefaultLogFile()
+	}
+	if path == "" {
+		fmt.Fprintf(os.Stderr, "Error: cannot determine the daemon log file\n")
+		return 1
+	}
+
+	offset, err := tailLogFile(os.Stdout, path, lines)
+	if err != nil && !(follow && os.IsNotExist(err)) {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	if !follow {
+		return 0
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	fmt.Fprintf(os.Stderr, "[Kaboom] Following %s (Ctrl-C to stop)\n", path)
+	if err := followLogFile(ctx, os.Stdout, path, offset, logFollowInterval); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// tailLogFile writes the last n lines of path and returns the file size it read up to.
+func tailLogFile(w io.Writer, path string, n int) (int64, error) {
+	f, err := os.Open(path) // #nosec G304 -- log path comes from the daemon or the runtime state dir
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = f.Close() }()
+	info, err := f.Stat()
+	if err != nil {
+		return 0, err
+	}
+	size := info.Size()
+	if n == 0 || size == 0 {
+		return size, nil
+	}
+
+	start, err := lastLinesOffset(f, size, n)
+	if err != nil {
+		return 0, err
+	}
+	_, err = io.Copy(w, io.NewSectionReader(f, start, size-start))
+	return size, err
+}
+
+// lastLinesOffset walks back from size in chunks and returns where the last n lines begin.
+// A trailing newline ends the last line rather than starting an empty one.
+func lastLinesOffset(r io.ReaderAt, size int64, n int) (int64, error) {
+	buf := make([]byte, logTailChunk)
+	newlines := 0
+	for end := size; end > 0; {
+		chunk := min(int64(len(buf)), end)
+		start := end - chunk
+		if _, err := r.ReadAt(buf[:chunk], start); err != nil && err != io.EOF {
+			return 0, err
+		}
+		for i := chunk - 1; i >= 0; i-- {
+			if buf[i] != '\n' || start+i == size-1 {
+				continue
+			}
+			if newlines++; newlines == n {
+				return start + i + 1, nil
+			}
+		}
+		end = start
+	}
+	return 0, nil
+}
+
+// followLogFile copies bytes appended to path after offset until ctx is done.
+// A file that shrinks (rotated or truncated) is read again from the start.
+func followLogFile(ctx context.Context, w io.Writer, path string, offset int64, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+		info, err := os.Stat(path)
+		if err != nil {
+			continue // rotated away; the daemon recreates it on the next write
+		}
+		if info.Size() < offset {
+			fmt.Fprintf(os.Stderr, "[Kaboom] %s was truncated or rotated; reading from the start\n", path)
+			offset = 0
+		}
+		if info.Size() == offset {
+			continue
+		}
+		f, err := os.Open(path) // #nosec G304 -- log path comes from the daemon or the runtime state dir
+		if err != nil {
+			continue
+		}
+		copied, err := io.Copy(w, io.NewSectionReader(f, offset, info.Size()-offset))
+		_ = f.Close()
+		offset += copied
+		if err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,186 @@
+// cli_daemon_test.go — Tests for `kaboom status|logs` probing, log tailing, and log following.
+// Docs: docs/features/feature/daemon-supervision/index.md
+
+package cli
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestTailLogFile_LastLines(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "kaboom.jsonl")
+	if err := os.WriteFile(path, []byte("one\ntwo\nthree\nfour\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		n    int
+		want string
+	}{
+		{2, "three\nfour\n"},
+		{4, "one\ntwo\nthree\nfour\n"},
+		{10, "one\ntwo\nthree\nfour\n"},
+		{0, ""},
+	}
+	for _, tt := range tests {
+		var out bytes.Buffer
+		offset, err := tailLogFile(&out, path, tt.n)
+		if err != nil {
+			t.Fatalf("tail %d: %v", tt.n, err)
+		}
+		if out.String() != tt.want {
+			t.Errorf("tail %d = %q, want %q", tt.n, out.String(), tt.want)
+		}
+		if offset != 19 {
+			t.Errorf("tail %d offset = %d, want file size 19", tt.n, offset)
+		}
+	}
+}
+
+func TestLastLinesOffset_SpansChunks(t *testing.T) {
+	t.Parallel()
+	line := strings.Repeat("x", 1000) + "\n"
+	content := strings.Repeat(line, 200) // ~200KB, several tail chunks
+	start, err := lastLinesOffset(strings.NewReader(content), int64(len(content)), 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := int64(len(content) - 100*len(line)); start != want {
+		t.Fatalf("offset = %d, want %d", start, want)
+	}
+}
+
+// syncBuffer guards a bytes.Buffer shared with the follow goroutine.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestFollowLogFile_AppendsAndTruncation(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "kaboom.jsonl")
+	if err := os.WriteFile(path, []byte("old\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var out syncBuffer
+	done := make(chan error, 1)
+	go func() { done <- followLogFile(ctx, &out, path, 4, 10*time.Millisecond) }()
+
+	waitFor := func(want string) {
+		t.Helper()
+		deadline := time.Now().Add(2 * time.Second)
+		for !strings.Contains(out.String(), want) {
+			if time.Now().After(deadline) {
+				t.Fatalf("output %q never contained %q", out.String(), want)
+			}
+			time.Sleep(5 * time.Millisecond)
+		}
+	}
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = f.WriteString("appended\n")
+	_ = f.Close()
+	waitFor("appended\n")
+
+	// Rotation: the file is replaced by a shorter one.
+	if err := os.WriteFile(path, []byte("new\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	waitFor("new\n")
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatalf("follow: %v", err)
+	}
+	if strings.Contains(out.String(), "old") {
+		t.Errorf("output %q repeats lines before the start offset", out.String())
+	}
+}
+
+func TestProbeDaemonStatus(t *testing.T) {
+	t.Parallel()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"status":"ok","version":"0.8.2","pid":4242,"uptime_seconds":90,
+			"logs":{"log_file":"/tmp/kaboom.jsonl"},
+			"capture":{"extension_connected":true,"extension_last_seen":"2026-10-16T09:00:00Z","extension_transport":"ws"}}`))
+	}))
+	defer srv.Close()
+	port := srv.Listener.Addr().(*net.TCPAddr).Port
+
+	status := probeDaemonStatus(port, RuntimeConfig{})
+	if status.State != "running" || status.PID != 4242 || status.Version != "0.8.2" || status.UptimeSeconds != 90 {
+		t.Fatalf("status = %+v", status)
+	}
+	if !status.ExtensionConnected || status.ExtensionTransport != "ws" || status.LogFile != "/tmp/kaboom.jsonl" {
+		t.Fatalf("status = %+v", status)
+	}
+	var out bytes.Buffer
+	printDaemonStatus(&out, status)
+	if !strings.Contains(out.String(), "connected (ws)") || !strings.Contains(out.String(), "1m30s") {
+		t.Errorf("printed status:\n%s", out.String())
+	}
+}
+
+func TestProbeDaemonStatus_NoHealthUsesPIDFile(t *testing.T) {
+	t.Parallel()
+	// Grab a free port and close it so /health is refused.
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	port := ln.Addr().(*net.TCPAddr).Port
+	_ = ln.Close()
+
+	rc := RuntimeConfig{DaemonPID: func(int) int { return 99 }, ProcessAlive: func(int) bool { return false }}
+	if status := probeDaemonStatus(port, rc); status.State != "stopped" || !status.PIDFileStale || status.PID != 99 {
+		t.Fatalf("stale PID file: status = %+v", status)
+	}
+	rc.ProcessAlive = func(int) bool { return true }
+	if status := probeDaemonStatus(port, rc); status.State != "unresponsive" {
+		t.Fatalf("live PID without /health: status = %+v", status)
+	}
+	if status := probeDaemonStatus(port, RuntimeConfig{}); status.State != "stopped" || status.PID != 0 {
+		t.Fatalf("no PID file: status = %+v", status)
+	}
+}
+
+func TestIsDaemonCommand(t *testing.T) {
+	t.Parallel()
+	for _, cmd := range []string{"status", "stop", "restart", "logs"} {
+		if !IsDaemonCommand([]string{cmd}) {
+			t.Errorf("%s should be a daemon command", cmd)
+		}
+	}
+	if IsDaemonCommand([]string{"observe"}) || IsDaemonCommand(nil) {
+		t.Error("tool names and empty args are not daemon commands")
+	}
+}
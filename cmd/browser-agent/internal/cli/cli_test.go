@@ -9,10 +9,14 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
 	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/mcp"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/serverregistry"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/state"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/unixsock"
 )
 
 const testDefaultPort = 7890
@@ -123,6 +127,107 @@ func TestResolveCLIConfigFlagBeatsEnv(t *testing.T) {
 	}
 }
 
+func TestResolveCLIConfigListen(t *testing.T) {
+	t.Setenv("KABOOM_LISTEN", "unix:/tmp/env.sock")
+
+	cfg, _ := ResolveCLIConfig([]string{"observe", "errors"}, testRC())
+	if cfg.Socket != "unix:/tmp/env.sock" {
+		t.Errorf("expected socket from KABOOM_LISTEN, got %q", cfg.Socket)
+	}
+	cfg, remaining := ResolveCLIConfig([]string{"--listen", "unix:/tmp/flag.sock", "observe", "errors"}, testRC())
+	if cfg.Socket != "unix:/tmp/flag.sock" {
+		t.Errorf("expected socket from --listen (flag beats env), got %q", cfg.Socket)
+	}
+	if len(remaining) != 2 || remaining[0] != "observe" {
+		t.Errorf("expected --listen stripped, got %v", remaining)
+	}
+}
+
+func TestEnsureDaemonForSocket(t *testing.T) {
+	dir, err := os.MkdirTemp("", "kcli")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+	path := filepath.Join(dir, "k.sock")
+	ln, err := unixsock.Listen(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/health" {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		var req mcp.JSONRPCRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		resultJSON, _ := json.Marshal(mcp.MCPToolResult{Content: []mcp.MCPContentBlock{{Type: "text", Text: "over socket"}}})
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(mcp.JSONRPCResponse{JSONRPC: "2.0", ID: req.ID, Result: resultJSON})
+	})}
+	go func() { _ = srv.Serve(ln) }()
+	t.Cleanup(func() { _ = srv.Close() })
+	oldClient := daemonHTTPClient
+	t.Cleanup(func() { daemonHTTPClient = oldClient })
+
+	rc := testRC()
+	rc.IsServerRunning = func(int) bool {
+		t.Error("socket mode must not probe the TCP port")
+		return false
+	}
+	baseURL, err := EnsureDaemonFor(CLIConfig{Port: testDefaultPort, Socket: "unix:" + path}, rc)
+	if err != nil {
+		t.Fatalf("EnsureDaemonFor: %v", err)
+	}
+	result, err := CallTool(baseURL, "observe", map[string]any{"what": "errors"}, 5000, 10*1024*1024)
+	if err != nil {
+		t.Fatalf("CallTool over socket: %v", err)
+	}
+	if len(result.Content) == 0 || result.Content[0].Text != "over socket" {
+		t.Errorf("unexpected result: %+v", result)
+	}
+
+	if _, err := EnsureDaemonFor(CLIConfig{Socket: "tcp:127.0.0.1:1"}, rc); err == nil {
+		t.Error("expected an error for a non-unix --listen")
+	}
+}
+
+func TestResolveCLIConfigServerRegistry(t *testing.T) {
+	registry := filepath.Join(t.TempDir(), "servers.json")
+	projectState := t.TempDir()
+	t.Setenv(serverregistry.FileEnv, registry)
+	t.Setenv(state.StateDirEnv, t.TempDir())
+	t.Setenv("KABOOM_PORT", "")
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := serverregistry.Register(registry, cwd, serverregistry.Server{Port: 7900, PID: 1, StateDir: projectState}); err != nil {
+		t.Fatal(err)
+	}
+
+	rc := testRC()
+	rc.IsServerRunning = func(port int) bool { return port == 7900 }
+	cfg, _ := ResolveCLIConfig([]string{"observe", "errors"}, rc)
+	if cfg.Port != 7900 {
+		t.Errorf("expected the project's registered port 7900, got %d", cfg.Port)
+	}
+	if got := os.Getenv(state.StateDirEnv); got != projectState {
+		t.Errorf("expected state dir %s adopted from the registry, got %s", projectState, got)
+	}
+
+	cfg, _ = ResolveCLIConfig([]string{"--port", "9999", "observe", "errors"}, rc)
+	if cfg.Port != 9999 {
+		t.Errorf("expected --port to bypass the registry, got %d", cfg.Port)
+	}
+
+	rc.IsServerRunning = func(int) bool { return false }
+	cfg, _ = ResolveCLIConfig([]string{"observe", "errors"}, rc)
+	if cfg.Port != testDefaultPort {
+		t.Errorf("expected a dead registered server to fall back to %d, got %d", testDefaultPort, cfg.Port)
+	}
+}
+
 // --- NormalizeAction tests ---
 
 func TestNormalizeAction(t *testing.T) {
@@ -11,6 +11,7 @@ func ParseGenerateArgs(format string, args []string) (map[string]any, error) {
 		"--telemetry-mode":        {MCPKey: "telemetry_mode", Kind: FlagString},
 		"--error-message":         {MCPKey: "error_message", Kind: FlagString},
 		"--last-n":                {MCPKey: "last_n", Kind: FlagInt},
+		"--boundary":              {MCPKey: "boundary", Kind: FlagString},
 		"--base-url":              {MCPKey: "base_url", Kind: FlagString},
 		"--include-screenshots":   {MCPKey: "include_screenshots", Kind: FlagBool},
 		"--generate-fixtures":     {MCPKey: "generate_fixtures", Kind: FlagBool},
@@ -19,10 +20,17 @@ func ParseGenerateArgs(format string, args []string) (map[string]any, error) {
 		"--assert-network":        {MCPKey: "assert_network", Kind: FlagBool},
 		"--assert-no-errors":      {MCPKey: "assert_no_errors", Kind: FlagBool},
 		"--assert-response-shape": {MCPKey: "assert_response_shape", Kind: FlagBool},
+		"--locator-strategy":      {MCPKey: "locator_strategy", Kind: FlagString},
+		"--locales":               {MCPKey: "locales", Kind: FlagStringList},
 		"--scope":                 {MCPKey: "scope", Kind: FlagString},
 		"--include-passes":        {MCPKey: "include_passes", Kind: FlagBool},
 		"--save-to":               {MCPKey: "save_to", Kind: FlagString},
+		"--baseline":              {MCPKey: "baseline", Kind: FlagString},
+		"--suite-name":            {MCPKey: "suite_name", Kind: FlagString},
+		"--budget":                {MCPKey: "budget", Kind: FlagJSON},
+		"--request-id":            {MCPKey: "request_id", Kind: FlagString},
 		"--url":                   {MCPKey: "url", Kind: FlagString},
+		"--limit":                 {MCPKey: "limit", Kind: FlagInt},
 		"--method":                {MCPKey: "method", Kind: FlagString},
 		"--status-min":            {MCPKey: "status_min", Kind: FlagInt},
 		"--status-max":            {MCPKey: "status_max", Kind: FlagInt},
@@ -43,6 +51,9 @@ func ParseGenerateArgs(format string, args []string) (map[string]any, error) {
 		"--error-id":              {MCPKey: "error_id", Kind: FlagString},
 		"--include-mocks":         {MCPKey: "include_mocks", Kind: FlagBool},
 		"--output-format":         {MCPKey: "output_format", Kind: FlagString},
+		"--label":                 {MCPKey: "label", Kind: FlagString},
+		"--first-party-origins":   {MCPKey: "first_party_origins", Kind: FlagStringList},
+		"--first-party-suffixes": {MCPKey: "first_party_suffixes", Kind: FlagStringList},
 	})
 	if err != nil {
 		return nil, err
@@ -76,6 +87,10 @@ func ParseConfigureArgs(action string, args []string) (map[string]any, error) {
 		"--category":                {MCPKey: "category", Kind: FlagString},
 		"--reason":                  {MCPKey: "reason", Kind: FlagString},
 		"--classification":          {MCPKey: "classification", Kind: FlagString},
+		"--rule-action":             {MCPKey: "rule_action", Kind: FlagString},
+		"--tag":                     {MCPKey: "tag", Kind: FlagString},
+		"--position":                {MCPKey: "position", Kind: FlagInt},
+		"--list":                    {MCPKey: "list", Kind: FlagBool},
 		"--message-regex":           {MCPKey: "message_regex", Kind: FlagString},
 		"--source-regex":            {MCPKey: "source_regex", Kind: FlagString},
 		"--url-regex":               {MCPKey: "url_regex", Kind: FlagString},
@@ -103,6 +118,64 @@ func ParseConfigureArgs(action string, args []string) (map[string]any, error) {
 		"--streaming-action":        {MCPKey: "streaming_action", Kind: FlagString},
 		"--events":                  {MCPKey: "events", Kind: FlagStringList},
 		"--throttle-seconds":        {MCPKey: "throttle_seconds", Kind: FlagInt},
+		// Alert silence
+		"--duration":                {MCPKey: "duration", Kind: FlagString},
+		"--categories":              {MCPKey: "categories", Kind: FlagStringList},
+		"--silence-id":              {MCPKey: "silence_id", Kind: FlagString},
+		// Tool rate limits
+		"--calls-per-minute":        {MCPKey: "calls_per_minute", Kind: FlagInt},
+		"--hourly-quota":            {MCPKey: "hourly_quota", Kind: FlagInt},
+		"--client-id":               {MCPKey: "client_id", Kind: FlagString},
+		// Circuit breaker (--state is shared with finding lifecycle)
+		"--max-events-per-window":   {MCPKey: "max_events_per_window", Kind: FlagInt},
+		"--memory-limit-mb":         {MCPKey: "memory_limit_mb", Kind: FlagInt},
+		// WebSocket sampling (--url-pattern is shared with request mocks)
+		"--rate":                    {MCPKey: "rate", Kind: FlagJSON},
+		"--always-keep":             {MCPKey: "always_keep", Kind: FlagStringList},
+		// Network body limits
+		"--request-body-max":        {MCPKey: "request_body_max", Kind: FlagInt},
+		"--response-body-max":       {MCPKey: "response_body_max", Kind: FlagInt},
+		"--capture-body-max":        {MCPKey: "capture_body_max", Kind: FlagInt},
+		"--binary-capture-max":      {MCPKey: "binary_capture_max", Kind: FlagInt},
+		// Buffer retention
+		"--max-entries":             {MCPKey: "max_entries", Kind: FlagInt},
+		"--ttl":                     {MCPKey: "ttl", Kind: FlagString},
+		// WebSocket protobuf decoding
+		"--path":                    {MCPKey: "path", Kind: FlagString},
+		"--proto-message":           {MCPKey: "proto_message", Kind: FlagString},
+		// Screenshot redaction
+		"--selectors":               {MCPKey: "selectors", Kind: FlagStringList},
+		// API contract locks
+		"--endpoint":                {MCPKey: "endpoint", Kind: FlagString},
+		// Finding lifecycle
+		"--finding-id":              {MCPKey: "finding_id", Kind: FlagString},
+		"--state":                   {MCPKey: "state", Kind: FlagString},
+		// Alert webhooks
+		"--format":                  {MCPKey: "format", Kind: FlagString},
+		"--webhook-id":              {MCPKey: "webhook_id", Kind: FlagString},
+		// Invariants
+		"--expr":                    {MCPKey: "expr", Kind: FlagString},
+		"--scope":                   {MCPKey: "scope", Kind: FlagString},
+		"--invariant-id":            {MCPKey: "invariant_id", Kind: FlagString},
+		// Request mocks
+		"--url-pattern":             {MCPKey: "url_pattern", Kind: FlagString},
+		"--max-duration-ms":         {MCPKey: "max_duration_ms", Kind: FlagInt},
+		"--max-size-kb":             {MCPKey: "max_size_kb", Kind: FlagJSON},
+		"--budget-id":               {MCPKey: "budget_id", Kind: FlagString},
+		"--enabled":                 {MCPKey: "enabled", Kind: FlagJSON},
+		"--status":                  {MCPKey: "status", Kind: FlagInt},
+		"--body":                    {MCPKey: "body", Kind: FlagString},
+		"--headers":                 {MCPKey: "headers", Kind: FlagJSON},
+		"--delay-ms":                {MCPKey: "delay_ms", Kind: FlagInt},
+		"--mock-id":                 {MCPKey: "mock_id", Kind: FlagString},
+		// Region overrides
+		"--geolocation":             {MCPKey: "geolocation", Kind: FlagJSON},
+		"--timezone":                {MCPKey: "timezone", Kind: FlagString},
+		"--locale":                  {MCPKey: "locale", Kind: FlagString},
+		// Vault states
+		"--origin":                  {MCPKey: "origin", Kind: FlagString},
+		// Login recipes
+		"--vars":                    {MCPKey: "vars", Kind: FlagJSON},
 		// Action jitter
 		"--action-jitter-ms":        {MCPKey: "action_jitter_ms", Kind: FlagInt},
 		// Diff sessions / verification
@@ -84,6 +84,8 @@ func ParseInteractArgs(action string, args []string) (map[string]any, error) {
 		"--auto-dismiss":          {MCPKey: "auto_dismiss", Kind: FlagBool},
 		// Output enrichments
 		"--include-screenshot":    {MCPKey: "include_screenshot", Kind: FlagBool},
+		"--clip":                  {MCPKey: "clip", Kind: FlagBool},
+		"--highlight-selector":    {MCPKey: "highlight_selector", Kind: FlagString},
 		"--include-interactive":   {MCPKey: "include_interactive", Kind: FlagBool},
 		"--observe-mutations":     {MCPKey: "observe_mutations", Kind: FlagBool},
 		"--action-diff":           {MCPKey: "action_diff", Kind: FlagBool},
@@ -93,12 +95,20 @@ func ParseInteractArgs(action string, args []string) (map[string]any, error) {
 		// State management
 		"--snapshot-name":         {MCPKey: "snapshot_name", Kind: FlagString},
 		"--include-url":           {MCPKey: "include_url", Kind: FlagBool},
+		"--vault":                 {MCPKey: "vault", Kind: FlagBool},
+		"--ttl":                   {MCPKey: "ttl", Kind: FlagString},
+		"--origin":                {MCPKey: "origin", Kind: FlagString},
+		// Login recipes
+		"--recipe":                {MCPKey: "recipe", Kind: FlagString},
+		"--vars":                  {MCPKey: "vars", Kind: FlagJSON},
+		"--save-state":            {MCPKey: "save_state", Kind: FlagBool},
 		"--storage-type":          {MCPKey: "storage_type", Kind: FlagString},
 		"--key":                   {MCPKey: "key", Kind: FlagString},
 		"--domain":                {MCPKey: "domain", Kind: FlagString},
 		"--path":                  {MCPKey: "path", Kind: FlagString},
 		// Form filling
 		"--fields":                {MCPKey: "fields", Kind: FlagJSON},
+		"--values":                {MCPKey: "values", Kind: FlagJSON},
 		"--submit-selector":       {MCPKey: "submit_selector", Kind: FlagString},
 		"--submit-index":          {MCPKey: "submit_index", Kind: FlagInt},
 		// Recording
@@ -110,6 +120,14 @@ func ParseInteractArgs(action string, args []string) (map[string]any, error) {
 		"--api-endpoint":          {MCPKey: "api_endpoint", Kind: FlagString},
 		"--submit":                {MCPKey: "submit", Kind: FlagBool},
 		"--escalation-timeout-ms": {MCPKey: "escalation_timeout_ms", Kind: FlagInt},
+		// CDP passthrough
+		"--method":                {MCPKey: "method", Kind: FlagString},
+		"--params":                {MCPKey: "params", Kind: FlagJSON},
+		"--release":               {MCPKey: "release", Kind: FlagBool},
+		// Request replay
+		"--request-id":            {MCPKey: "request_id", Kind: FlagString},
+		"--overrides":             {MCPKey: "overrides", Kind: FlagJSON},
+		"--device":                {MCPKey: "device", Kind: FlagJSONOrString},
 		// Batch
 		"--steps":                 {MCPKey: "steps", Kind: FlagJSON},
 		"--step-timeout-ms":       {MCPKey: "step_timeout_ms", Kind: FlagInt},
@@ -12,6 +12,10 @@ func ParseObserveArgs(mode string, args []string) (map[string]any, error) {
 		"--telemetry-mode":         {MCPKey: "telemetry_mode", Kind: FlagString},
 		"--limit":                  {MCPKey: "limit", Kind: FlagInt},
 		"--summary":                {MCPKey: "summary", Kind: FlagBool},
+		"--cluster-trend":          {MCPKey: "cluster_trend", Kind: FlagBool},
+		"--compact":                {MCPKey: "compact", Kind: FlagBool},
+		"--wait-for-new":           {MCPKey: "wait_for_new", Kind: FlagBool},
+		"--timeout-ms":             {MCPKey: "timeout_ms", Kind: FlagInt},
 		"--scope":                  {MCPKey: "scope", Kind: FlagString},
 		// Pagination
 		"--after-cursor":           {MCPKey: "after_cursor", Kind: FlagString},
@@ -29,8 +33,18 @@ func ParseObserveArgs(mode string, args []string) (map[string]any, error) {
 		"--body-path":              {MCPKey: "body_path", Kind: FlagString},
 		"--connection-id":          {MCPKey: "connection_id", Kind: FlagString},
 		"--direction":              {MCPKey: "direction", Kind: FlagString},
+		"--event":                  {MCPKey: "event", Kind: FlagString},
+		"--event-type":             {MCPKey: "event_type", Kind: FlagString},
+		"--endpoint":               {MCPKey: "endpoint", Kind: FlagString},
+		"--slow-ms":                {MCPKey: "slow_ms", Kind: FlagInt},
+		"--errors-only":            {MCPKey: "errors_only", Kind: FlagBool},
+		"--mine":                   {MCPKey: "mine", Kind: FlagBool},
+		"--buffer":                 {MCPKey: "buffer", Kind: FlagString},
 		"--last-n":                 {MCPKey: "last_n", Kind: FlagInt},
 		"--include":                {MCPKey: "include", Kind: FlagStringList},
+		"--test-id":                {MCPKey: "test_id", Kind: FlagString},
+		"--boundary":               {MCPKey: "boundary", Kind: FlagString},
+		"--tab-id":                 {MCPKey: "tab_id", Kind: FlagInt},
 		"--correlation-id":         {MCPKey: "correlation_id", Kind: FlagString},
 		"--recording-id":           {MCPKey: "recording_id", Kind: FlagString},
 		"--window-seconds":         {MCPKey: "window_seconds", Kind: FlagInt},
@@ -40,12 +54,16 @@ func ParseObserveArgs(mode string, args []string) (map[string]any, error) {
 		"--include-internal":       {MCPKey: "include_internal", Kind: FlagBool},
 		"--include-extension-logs": {MCPKey: "include_extension_logs", Kind: FlagBool},
 		"--extension-limit":        {MCPKey: "extension_limit", Kind: FlagInt},
+		"--args-path":              {MCPKey: "args_path", Kind: FlagString},
+		"--args-value":             {MCPKey: "args_value", Kind: FlagString},
 		"--min-group-size":         {MCPKey: "min_group_size", Kind: FlagInt},
 		// Screenshot
 		"--format":                 {MCPKey: "format", Kind: FlagString},
 		"--quality":                {MCPKey: "quality", Kind: FlagInt},
 		"--full-page":              {MCPKey: "full_page", Kind: FlagBool},
 		"--selector":               {MCPKey: "selector", Kind: FlagString},
+		"--clip":                   {MCPKey: "clip", Kind: FlagBool},
+		"--highlight-selector":     {MCPKey: "highlight_selector", Kind: FlagString},
 		"--wait-for-stable":        {MCPKey: "wait_for_stable", Kind: FlagBool},
 		"--save-to":                {MCPKey: "save_to", Kind: FlagString},
 		// Storage / IndexedDB
@@ -53,6 +71,59 @@ func ParseObserveArgs(mode string, args []string) (map[string]any, error) {
 		"--key":                    {MCPKey: "key", Kind: FlagString},
 		"--database":               {MCPKey: "database", Kind: FlagString},
 		"--store":                  {MCPKey: "store", Kind: FlagString},
+		// Vitals trend and aggregates
+		"--mode":                   {MCPKey: "mode", Kind: FlagString},
+		"--days":                   {MCPKey: "days", Kind: FlagInt},
+		"--aggregate":              {MCPKey: "aggregate", Kind: FlagString},
+		"--window":                 {MCPKey: "window", Kind: FlagString},
+		// Verify fix
+		"--since":                  {MCPKey: "since", Kind: FlagString},
+		"--expect":                 {MCPKey: "expect", Kind: FlagJSON},
+		"--replay":                 {MCPKey: "replay", Kind: FlagString},
+		"--wait-ms":                {MCPKey: "wait_ms", Kind: FlagInt},
+		// Assert
+		"--conditions":             {MCPKey: "conditions", Kind: FlagJSON},
+		// Full network bodies
+		"--request-id":             {MCPKey: "request_id", Kind: FlagString},
+		"--full-body":              {MCPKey: "full_body", Kind: FlagBool},
+		"--include-binary":         {MCPKey: "include_binary", Kind: FlagBool},
+		// State at
+		"--t":                      {MCPKey: "t", Kind: FlagString},
+		// Auth state
+		"--expiring-within-seconds": {MCPKey: "expiring_within_seconds", Kind: FlagInt},
+		// Cookie audit
+		"--include-storage":        {MCPKey: "include_storage", Kind: FlagBool},
+		// Coverage
+		"--collect":                {MCPKey: "collect", Kind: FlagBool},
+		// Session compare
+		"--a":                      {MCPKey: "a", Kind: FlagString},
+		"--b":                      {MCPKey: "b", Kind: FlagString},
+		"--session":                {MCPKey: "session", Kind: FlagString},
+		"--query":                  {MCPKey: "query", Kind: FlagString},
+		// Third-party audit
+		"--first-party-origins":    {MCPKey: "first_party_origins", Kind: FlagStringList},
+		"--first-party-suffixes": {MCPKey: "first_party_suffixes", Kind: FlagStringList},
+		"--update-baseline":        {MCPKey: "update_baseline", Kind: FlagBool},
+		// Privacy audit
+		"--consent-cookie":         {MCPKey: "consent_cookie", Kind: FlagString},
+		"--consent-granted-value":  {MCPKey: "consent_granted_value", Kind: FlagString},
+		// Change feed
+		"--after-seq":              {MCPKey: "after_seq", Kind: FlagInt},
+		"--feed-id":                {MCPKey: "feed_id", Kind: FlagString},
+		"--types":                  {MCPKey: "types", Kind: FlagStringList},
+		"--since-commit":           {MCPKey: "since_commit", Kind: FlagString},
+		// Component audit
+		"--harness":                {MCPKey: "harness", Kind: FlagString},
+		"--story-url-template":     {MCPKey: "story_url_template", Kind: FlagString},
+		"--story-ids":              {MCPKey: "story_ids", Kind: FlagStringList},
+		"--filter":                 {MCPKey: "filter", Kind: FlagString},
+		"--tags":                   {MCPKey: "tags", Kind: FlagStringList},
+		"--checks":                 {MCPKey: "checks", Kind: FlagStringList},
+		"--settle-ms":              {MCPKey: "settle_ms", Kind: FlagInt},
+		// Remediation playbooks
+		"--finding-id":             {MCPKey: "finding_id", Kind: FlagString},
+		// Finding lifecycle
+		"--state":                  {MCPKey: "state", Kind: FlagString},
 		// Transients / Page inventory
 		"--classification":         {MCPKey: "classification", Kind: FlagString},
 		"--visible-only":           {MCPKey: "visible_only", Kind: FlagBool},
@@ -79,6 +150,8 @@ func ParseAnalyzeArgs(what string, args []string) (map[string]any, error) {
 		"--selector":            {MCPKey: "selector", Kind: FlagString},
 		"--frame":               {MCPKey: "frame", Kind: FlagIntOrString},
 		"--tab-id":              {MCPKey: "tab_id", Kind: FlagInt},
+		"--properties":          {MCPKey: "properties", Kind: FlagStringList},
+		"--markers":             {MCPKey: "markers", Kind: FlagStringList},
 		// Analysis control
 		"--operation":           {MCPKey: "operation", Kind: FlagString},
 		"--ignore-endpoints":    {MCPKey: "ignore_endpoints", Kind: FlagStringList},
@@ -91,7 +164,9 @@ func ParseAnalyzeArgs(what string, args []string) (map[string]any, error) {
 		"--max-workers":         {MCPKey: "max_workers", Kind: FlagInt},
 		"--checks":              {MCPKey: "checks", Kind: FlagStringList},
 		"--severity-min":        {MCPKey: "severity_min", Kind: FlagString},
+		"--category":            {MCPKey: "category", Kind: FlagString},
 		"--first-party-origins": {MCPKey: "first_party_origins", Kind: FlagStringList},
+		"--first-party-suffixes": {MCPKey: "first_party_suffixes", Kind: FlagStringList},
 		"--include-static":      {MCPKey: "include_static", Kind: FlagBool},
 		"--custom-lists":        {MCPKey: "custom_lists", Kind: FlagJSON},
 		"--correlation-id":      {MCPKey: "correlation_id", Kind: FlagString},
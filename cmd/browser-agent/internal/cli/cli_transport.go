@@ -14,21 +14,34 @@ import (
 	"time"
 
 	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/mcp"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/pkg/client"
 )
 
-// CallTool builds a JSON-RPC tools/call request, POSTs to /mcp, and parses the response.
+// daemonHTTPClient carries CLI requests to the daemon. EnsureDaemonFor swaps in a
+// Unix socket client when --listen unix: is set.
+var daemonHTTPClient = http.DefaultClient
+
+// CallTool calls a tool on the daemon at baseURL through pkg/client.
 func CallTool(baseURL, toolName string, mcpArgs map[string]any, timeoutMs int, maxBodySize int64) (*mcp.MCPToolResult, error) {
-	body, err := BuildToolCallBody(toolName, mcpArgs)
-	if err != nil {
-		return nil, err
-	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(timeoutMs)*time.Millisecond)
+	defer cancel()
 
-	respBody, err := PostToolCall(baseURL+"/mcp", body, timeoutMs, maxBodySize)
+	c := client.New(baseURL, client.WithHTTPClient(daemonHTTPClient), client.WithMaxResponseBytes(maxBodySize))
+	result, err := c.Call(ctx, toolName, mcpArgs)
 	if err != nil {
 		return nil, err
 	}
+	return toMCPToolResult(result), nil
+}
 
-	return ParseToolCallResponse(respBody)
+// toMCPToolResult converts a pkg/client result to the server's wire type used by CLI formatters.
+func toMCPToolResult(r *client.Result) *mcp.MCPToolResult {
+	out := &mcp.MCPToolResult{IsError: r.IsError, Metadata: r.Metadata}
+	out.Content = make([]mcp.MCPContentBlock, len(r.Content))
+	for i, block := range r.Content {
+		out.Content[i] = mcp.MCPContentBlock(block)
+	}
+	return out
 }
 
 // BuildToolCallBody creates the JSON-RPC request body for a tools/call.
@@ -63,8 +76,7 @@ func PostToolCall(endpoint string, body []byte, timeoutMs int, maxBodySize int64
 	}
 	httpReq.Header.Set("Content-Type", "application/json")
 
-	client := &http.Client{}
-	resp, err := client.Do(httpReq) // #nosec G704 -- endpoint comes from EnsureDaemon() and is localhost-only
+	resp, err := daemonHTTPClient.Do(httpReq) // #nosec G704 -- endpoint comes from EnsureDaemon() and is localhost-only
 	if err != nil {
 		return nil, fmt.Errorf("connect to server: %w. Verify daemon is running on the target port", err)
 	}
@@ -0,0 +1,203 @@
+// cli_watch.go — Implements `kaboom watch <dir>...`, a polling file watcher that reports edits to the daemon.
+// Why: Lets the timeline tie each save to the reload, errors, and vitals that followed it without an editor plugin.
+// Docs: docs/features/feature/watch-mode/index.md
+
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"time"
+)
+
+const (
+	// defaultWatchIntervalMs is the polling interval between tree scans.
+	defaultWatchIntervalMs = 500
+	// maxWatchedFiles bounds a scan so an accidental `watch /` cannot exhaust memory.
+	maxWatchedFiles = 50000
+	// maxWatchBatch matches the daemon's /file-changes batch limit.
+	maxWatchBatch = 500
+)
+
+// watchSkipDirs are directories whose churn is build output or tooling, not source edits.
+var watchSkipDirs = map[string]bool{
+	".git": true, "node_modules": true, "dist": true, "build": true, "out": true,
+	".next": true, ".nuxt": true, ".svelte-kit": true, ".turbo": true, ".cache": true,
+	".vite": true, "coverage": true, "vendor": true, "__pycache__": true,
+}
+
+// WatchChange is one changed file as posted to /file-changes.
+type WatchChange struct {
+	Path string `json:"path"`
+	Op   string `json:"op"`
+}
+
+type fileStamp struct {
+	modTime time.Time
+	size    int64
+}
+
+// IsWatchMode returns true for `kaboom watch ...`.
+func IsWatchMode(args []string) bool {
+	return len(args) > 0 && args[0] == "watch"
+}
+
+// RunWatch polls the given directories (default ".") and posts each batch of changes
+// to the daemon until interrupted. Returns exit code.
+func RunWatch(args []string, rc RuntimeConfig) int {
+	cfg, remaining := ResolveCLIConfig(args, rc)
+	intervalStr, remaining := CLIParseFlag(remaining, "--interval")
+	interval := defaultWatchIntervalMs
+	if intervalStr != "" {
+		n, err := strconv.Atoi(intervalStr)
+		if err != nil || n < 100 {
+			fmt.Fprintf(os.Stderr, "Error: --interval must be an integer >= 100 (ms)\n")
+			return 2
+		}
+		interval = n
+	}
+	roots := remaining
+	if len(roots) == 0 {
+		roots = []string{"."}
+	}
+	for i, root := range roots {
+		abs, err := filepath.Abs(root)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 2
+		}
+		if info, err := os.Stat(abs); err != nil || !info.IsDir() {
+			fmt.Fprintf(os.Stderr, "Error: %s is not a directory\n", root)
+			return 2
+		}
+		roots[i] = abs
+	}
+
+	baseURL, err := EnsureDaemonFor(cfg, rc)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	prev := make([]map[string]fileStamp, len(roots))
+	for i, root := range roots {
+		prev[i] = scanWatchTree(root)
+		fmt.Fprintf(os.Stderr, "[Kaboom] Watching %s (%d files)\n", root, len(prev[i]))
+	}
+	ticker := time.NewTicker(time.Duration(interval) * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return 0
+		case <-ticker.C:
+		}
+		for i, root := range roots {
+			next := scanWatchTree(root)
+			changes := diffWatchTrees(prev[i], next)
+			prev[i] = next
+			if len(changes) == 0 {
+				continue
+			}
+			if err := postFileChanges(ctx, baseURL, root, changes, cfg.Timeout); err != nil {
+				fmt.Fprintf(os.Stderr, "[Kaboom] Failed to report %d change(s): %v\n", len(changes), err)
+				continue
+			}
+			fmt.Fprintf(os.Stderr, "[Kaboom] Reported %d change(s) in %s\n", len(changes), root)
+		}
+	}
+}
+
+// scanWatchTree returns size and mtime for regular files under root, keyed by slash-separated
+// relative path. Hidden and build-output directories are skipped.
+func scanWatchTree(root string) map[string]fileStamp {
+	files := make(map[string]fileStamp)
+	_ = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if d.IsDir() {
+			if path != root && (watchSkipDirs[d.Name()] || d.Name()[0] == '.') {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		if len(files) >= maxWatchedFiles {
+			return filepath.SkipAll
+		}
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return nil
+		}
+		files[filepath.ToSlash(rel)] = fileStamp{modTime: info.ModTime(), size: info.Size()}
+		return nil
+	})
+	return files
+}
+
+// diffWatchTrees returns created, modified, and deleted files between two scans, sorted by path.
+func diffWatchTrees(prev, next map[string]fileStamp) []WatchChange {
+	var changes []WatchChange
+	for path, stamp := range next {
+		old, ok := prev[path]
+		switch {
+		case !ok:
+			changes = append(changes, WatchChange{Path: path, Op: "created"})
+		case !old.modTime.Equal(stamp.modTime) || old.size != stamp.size:
+			changes = append(changes, WatchChange{Path: path, Op: "modified"})
+		}
+	}
+	for path := range prev {
+		if _, ok := next[path]; !ok {
+			changes = append(changes, WatchChange{Path: path, Op: "deleted"})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Path < changes[j].Path })
+	return changes
+}
+
+// postFileChanges sends one batch to POST /file-changes, truncating oversized batches.
+func postFileChanges(ctx context.Context, baseURL, root string, changes []WatchChange, timeoutMs int) error {
+	if len(changes) > maxWatchBatch {
+		changes = changes[:maxWatchBatch]
+	}
+	body, err := json.Marshal(map[string]any{"source": "watch", "root": root, "changes": changes})
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(timeoutMs)*time.Millisecond)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, "POST", baseURL+"/file-changes", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := daemonHTTPClient.Do(req) // #nosec G704 -- baseURL comes from EnsureDaemon() and is localhost-only
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("daemon returned HTTP %d", resp.StatusCode)
+	}
+	return nil
+}
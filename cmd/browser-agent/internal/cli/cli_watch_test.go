@@ -0,0 +1,66 @@
+// cli_watch_test.go — Tests for the `kaboom watch` tree scan, skip rules, and change diffing.
+// Docs: docs/features/feature/watch-mode/index.md
+
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestWatchTree_DiffsCreatedModifiedDeleted(t *testing.T) {
+	t.Parallel()
+	root := t.TempDir()
+	write := func(rel, content string) {
+		t.Helper()
+		path := filepath.Join(root, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("src/app.ts", "a")
+	write("src/old.ts", "a")
+	write("node_modules/lib/index.js", "a")
+	write(".git/HEAD", "a")
+
+	before := scanWatchTree(root)
+	if len(before) != 2 {
+		t.Fatalf("scan = %v, want only src files", before)
+	}
+
+	write("src/app.ts", "ab")
+	write("src/new.ts", "a")
+	write("node_modules/lib/index.js", "ab")
+	if err := os.Remove(filepath.Join(root, "src/old.ts")); err != nil {
+		t.Fatal(err)
+	}
+	future := time.Now().Add(time.Minute)
+	_ = os.Chtimes(filepath.Join(root, "src/app.ts"), future, future)
+
+	changes := diffWatchTrees(before, scanWatchTree(root))
+	want := []WatchChange{
+		{Path: "src/app.ts", Op: "modified"},
+		{Path: "src/new.ts", Op: "created"},
+		{Path: "src/old.ts", Op: "deleted"},
+	}
+	if len(changes) != len(want) {
+		t.Fatalf("changes = %+v, want %+v", changes, want)
+	}
+	for i := range want {
+		if changes[i] != want[i] {
+			t.Errorf("change %d = %+v, want %+v", i, changes[i], want[i])
+		}
+	}
+}
+
+func TestIsWatchMode(t *testing.T) {
+	t.Parallel()
+	if !IsWatchMode([]string{"watch", "./src"}) || IsWatchMode([]string{"observe", "errors"}) || IsWatchMode(nil) {
+		t.Fatal("IsWatchMode misclassified arguments")
+	}
+}
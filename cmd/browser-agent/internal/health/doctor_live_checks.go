@@ -127,7 +127,7 @@ func RunDoctorChecks(cap *capture.Store) []DoctorCheck {
 		checks = append(checks, DoctorCheck{
 			Name: "circuit_breaker", Status: "fail",
 			Detail: "Circuit breaker OPEN: " + snap.CircuitReason,
-			Fix:    "Extension is sending too many errors. Check observe(errors) for root cause, then use configure(action:'clear',what:'circuit') to reset.",
+			Fix:    "Extension is sending too many events. Check observe(errors) for root cause; configure(what:'circuit', state:'closed') overrides the breaker and state:'auto' hands control back.",
 		})
 	}
 
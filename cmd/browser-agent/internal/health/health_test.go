@@ -5,9 +5,12 @@ package health
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"sync"
 	"testing"
 	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
 )
 
 // ---------------------------------------------------------------------------
@@ -341,6 +344,16 @@ func TestBuildMemoryInfo_NilCapture(t *testing.T) {
 	}
 }
 
+func TestBuildMemoryInfo_ReportsRawAndCompressedNetworkBytes(t *testing.T) {
+	cap := capture.NewCapture()
+	cap.AddNetworkBodies([]capture.NetworkBody{{URL: "https://a.test/api", Status: 200, ResponseBody: strings.Repeat(`{"ok":true},`, 1000)}})
+
+	b := BuildMemoryInfo(cap).BufferBreakdown
+	if b.NetworkCompressedEntries != 1 || b.NetworkBytes <= 0 || b.NetworkRawBytes <= b.NetworkBytes*3 {
+		t.Fatalf("breakdown = %+v, want compressed network bytes well below raw", b)
+	}
+}
+
 // ---------------------------------------------------------------------------
 // Response type construction
 // ---------------------------------------------------------------------------
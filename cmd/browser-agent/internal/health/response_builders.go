@@ -80,26 +80,39 @@ func BuildMemoryInfo(cap *capture.Store) MemoryInfo {
 	var memStats runtime.MemStats
 	runtime.ReadMemStats(&memStats)
 
+	var breakdown BufferMemoryBreakdown
+	if cap != nil {
+		storage := cap.GetNetworkBodyStorage()
+		breakdown = BufferMemoryBreakdown{
+			WebSocketBytes:           cap.GetWebSocketBufferMemory(),
+			NetworkBytes:             storage.StoredBytes,
+			NetworkRawBytes:          storage.RawBytes,
+			NetworkCompressedEntries: storage.CompressedEntries,
+			ActionsBytes:             cap.GetEnhancedActionsBufferMemory(),
+		}
+	}
+
 	return MemoryInfo{
-		CurrentMB: float64(memStats.Alloc) / (1024 * 1024),
-		AllocMB:   float64(memStats.Alloc) / (1024 * 1024),
-		SysMB:     float64(memStats.Sys) / (1024 * 1024),
-		BufferBreakdown: BufferMemoryBreakdown{
-			WebSocketBytes: 0,
-			NetworkBytes:   0,
-			ActionsBytes:   0,
-		},
+		CurrentMB:       float64(memStats.Alloc) / (1024 * 1024),
+		AllocMB:         float64(memStats.Alloc) / (1024 * 1024),
+		SysMB:           float64(memStats.Sys) / (1024 * 1024),
+		BufferBreakdown: breakdown,
 	}
 }
 
 // BuildBuffersInfo returns buffer utilization stats from capture and server.
 func BuildBuffersInfo(cap *capture.Store, server ServerDeps) BuffersInfo {
 	var networkEntries, wsEntries, actionEntries int
+	networkCapacity, wsCapacity, actionCapacity := capture.MaxNetworkBodies, capture.MaxWSEvents, capture.MaxEnhancedActions
 	if cap != nil {
 		h := cap.GetHealthSnapshot()
 		networkEntries = h.NetworkBodyCount
 		wsEntries = h.WebSocketCount
 		actionEntries = h.ActionCount
+		retention := cap.GetRetention()
+		networkCapacity = retention[capture.RetentionNetwork].MaxEntries
+		wsCapacity = retention[capture.RetentionWebSocket].MaxEntries
+		actionCapacity = retention[capture.RetentionActions].MaxEntries
 	}
 
 	consoleEntries, consoleCapacity, consoleDropped := getConsoleStats(server)
@@ -113,18 +126,18 @@ func BuildBuffersInfo(cap *capture.Store, server ServerDeps) BuffersInfo {
 		},
 		Network: BufferStats{
 			Entries:        networkEntries,
-			Capacity:       capture.MaxNetworkBodies,
-			UtilizationPct: CalcUtilization(networkEntries, capture.MaxNetworkBodies),
+			Capacity:       networkCapacity,
+			UtilizationPct: CalcUtilization(networkEntries, networkCapacity),
 		},
 		WebSocket: BufferStats{
 			Entries:        wsEntries,
-			Capacity:       capture.MaxWSEvents,
-			UtilizationPct: CalcUtilization(wsEntries, capture.MaxWSEvents),
+			Capacity:       wsCapacity,
+			UtilizationPct: CalcUtilization(wsEntries, wsCapacity),
 		},
 		Actions: BufferStats{
 			Entries:        actionEntries,
-			Capacity:       capture.MaxEnhancedActions,
-			UtilizationPct: CalcUtilization(actionEntries, capture.MaxEnhancedActions),
+			Capacity:       actionCapacity,
+			UtilizationPct: CalcUtilization(actionEntries, actionCapacity),
 		},
 	}
 }
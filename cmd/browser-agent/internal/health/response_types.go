@@ -15,6 +15,16 @@ type MCPHealthResponse struct {
 	Pilot            PilotInfo            `json:"pilot"`
 	CommandExecution CommandExecutionInfo `json:"command_execution"`
 	Upgrade          *UpgradeInfo         `json:"upgrade,omitempty"`
+	ReadOnly         *ReadOnlyInfo        `json:"read_only,omitempty"`
+}
+
+// ReadOnlyInfo describes read-only mode when the server replays an archived session bundle.
+type ReadOnlyInfo struct {
+	Enabled         bool   `json:"enabled"`
+	Reason          string `json:"reason"`
+	BundlePath      string `json:"bundle_path,omitempty"`
+	BundleCreatedAt string `json:"bundle_created_at,omitempty"`
+	BundleLabel     string `json:"bundle_label,omitempty"`
 }
 
 // UpgradeInfo contains binary upgrade detection state.
@@ -46,10 +56,13 @@ type MemoryInfo struct {
 }
 
 // BufferMemoryBreakdown shows memory usage per buffer type.
+// NetworkBytes counts bodies as stored (gzip-compressed where that helped); NetworkRawBytes as if uncompressed.
 type BufferMemoryBreakdown struct {
-	WebSocketBytes int64 `json:"websocket_bytes"`
-	NetworkBytes   int64 `json:"network_bytes"`
-	ActionsBytes   int64 `json:"actions_bytes"`
+	WebSocketBytes           int64 `json:"websocket_bytes"`
+	NetworkBytes             int64 `json:"network_bytes"`
+	NetworkRawBytes          int64 `json:"network_raw_bytes"`
+	NetworkCompressedEntries int   `json:"network_compressed_entries"`
+	ActionsBytes             int64 `json:"actions_bytes"`
 }
 
 // BuffersInfo contains buffer utilization statistics.
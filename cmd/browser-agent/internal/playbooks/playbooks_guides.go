@@ -16,7 +16,7 @@ Agentic Browser Devtools - rapid e2e web development. 5 tools for real-time brow
 | analyze | Trigger active analysis (synchronous by default) | what: dom, accessibility, performance, security_audit, third_party_audit, link_health, link_validation, page_summary, error_clusters, navigation_patterns, api_validation, annotations, annotation_detail, draw_history, draw_session, computed_styles, forms, form_state, form_validation, data_table, visual_baseline, visual_diff, visual_baselines, navigation, page_structure, audit, feature_gates |
 | generate | Create artifacts from captured data | what: test, reproduction, pr_summary, sarif, har, csp, sri, visual_test, annotation_report, annotation_issues, test_from_context, test_heal, test_classify |
 | configure | Session settings and utilities | what: health, store, load, noise_rule, clear, streaming, test_boundary_start, test_boundary_end, event_recording_start, event_recording_stop, playback, log_diff, telemetry, describe_capabilities, diff_sessions, audit_log, restart, save_sequence, get_sequence, list_sequences, delete_sequence, replay_sequence, doctor, security_mode, network_recording, action_jitter, report_issue, tutorial, examples |
-| interact | Browser automation (needs AI Web Pilot) | what: highlight, subtitle, save_state, load_state, list_states, delete_state, set_storage, delete_storage, clear_storage, set_cookie, delete_cookie, execute_js, navigate, refresh, back, forward, new_tab, switch_tab, close_tab, screenshot, click, type, select, check, get_text, get_value, get_attribute, query, set_attribute, focus, scroll_to, wait_for, key_press, paste, open_composer, submit_active_composer, confirm_top_dialog, dismiss_top_overlay, hover, auto_dismiss_overlays, wait_for_stable, list_interactive, get_readable, get_markdown, navigate_and_wait_for, navigate_and_document, fill_form_and_submit, fill_form, run_a11y_and_export_sarif, screen_recording_start, screen_recording_stop, upload, draw_mode_start, hardware_click, activate_tab, explore_page, batch, clipboard_read, clipboard_write |
+| interact | Browser automation (needs AI Web Pilot) | what: highlight, subtitle, save_state, load_state, list_states, delete_state, set_storage, delete_storage, clear_storage, set_cookie, delete_cookie, execute_js, navigate, refresh, back, forward, new_tab, switch_tab, close_tab, screenshot, click, type, select, check, get_text, get_value, get_attribute, query, set_attribute, focus, scroll_to, wait_for, key_press, paste, open_composer, submit_active_composer, confirm_top_dialog, dismiss_top_overlay, hover, auto_dismiss_overlays, wait_for_stable, list_interactive, get_readable, get_markdown, navigate_and_wait_for, navigate_and_document, fill_form_and_submit, fill_form, run_a11y_and_export_sarif, screen_recording_start, screen_recording_stop, upload, draw_mode_start, hardware_click, cdp, activate_tab, explore_page, batch, clipboard_read, clipboard_write |
 
 ## Key Patterns
 
@@ -6,6 +6,7 @@ package toolanalyze
 
 import (
 	"encoding/json"
+	"fmt"
 
 	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/analysis"
 	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/mcp"
@@ -13,15 +14,26 @@ import (
 )
 
 // HandleSecurityAudit handles analyze(what="security_audit").
+// category="csp_diff" diffs the served CSP instead of running the scanner checks.
 func HandleSecurityAudit(d Deps, req mcp.JSONRPCRequest, args json.RawMessage) mcp.JSONRPCResponse {
 	var params struct {
 		SeverityMin string   `json:"severity_min"`
 		Checks      []string `json:"checks"`
 		URLFilter   string   `json:"url"`
 		Summary     bool     `json:"summary"`
+		Category    string   `json:"category"`
 	}
 	lenientUnmarshal(args, &params)
 
+	switch params.Category {
+	case "":
+	case "csp_diff":
+		return handleCSPDiff(d, req)
+	default:
+		return fail(req, mcp.ErrInvalidParam, "Unknown security_audit category: "+params.Category,
+			"Use category 'csp_diff', or omit category for the full audit", mcp.WithParam("category"))
+	}
+
 	scanner := d.SecurityScanner()
 	if scanner == nil {
 		return fail(req, mcp.ErrNotInitialized, "Security scanner not initialized", "Internal error — do not retry")
@@ -51,6 +63,17 @@ func HandleSecurityAudit(d Deps, req mcp.JSONRPCRequest, args json.RawMessage) m
 	return succeed(req, "Security audit complete", result)
 }
 
+// handleCSPDiff handles analyze(what="security_audit", category="csp_diff").
+func handleCSPDiff(d Deps, req mcp.JSONRPCRequest) mcp.JSONRPCResponse {
+	_, _, tabURL := d.GetTrackingStatus()
+	result := security.DiffCSP(security.CSPDiffInput{
+		PageURL:   tabURL,
+		Bodies:    d.NetworkBodies(),
+		Waterfall: d.NetworkWaterfallEntries(),
+	})
+	return succeed(req, fmt.Sprintf("CSP diff: %s (%d missing, %d broad)", result.Status, result.Summary.Missing, result.Summary.Broad), result)
+}
+
 // HandleThirdPartyAudit handles analyze(what="third_party_audit").
 func HandleThirdPartyAudit(d Deps, req mcp.JSONRPCRequest, args json.RawMessage) mcp.JSONRPCResponse {
 	var params struct {
@@ -26,11 +26,14 @@ type NoiseRuleArgs struct {
 			StatusMax    int    `json:"status_max"`
 			Level        string `json:"level"`
 		} `json:"match_spec"`
+		Action string `json:"action"`
+		Tag    string `json:"tag"`
 	} `json:"rules"`
-	RuleID string `json:"rule_id"`
+	RuleID   string `json:"rule_id"`
+	Position *int   `json:"position"`
 }
 
-// HandleNoise handles configure(what="noise_rule") after arg rewriting.
+// HandleNoise handles configure(what="noise_rules") (alias "noise_rule") after arg rewriting.
 func HandleNoise(d Deps, req mcp.JSONRPCRequest, args json.RawMessage) mcp.JSONRPCResponse {
 	var arguments NoiseRuleArgs
 	lenientUnmarshal(args, &arguments)
@@ -55,13 +58,17 @@ func dispatchNoiseAction(d Deps, nc *noise.NoiseConfig, req mcp.JSONRPCRequest,
 	case "remove":
 		return noiseActionRemove(nc, req, args)
 	case "list":
-		return noiseActionList(nc), nil
+		return noiseActionList(d, nc), nil
 	case "reset":
 		return noiseActionReset(nc), nil
+	case "move":
+		return noiseActionMove(nc, req, args)
+	case "dry_run":
+		return noiseActionDryRun(d, nc), nil
 	case "auto_detect":
 		return noiseActionAutoDetect(d, nc), nil
 	default:
-		resp := fail(req, mcp.ErrUnknownMode, "Unknown noise action: "+args.Action, "Use a valid action: add, remove, list, reset, auto_detect", mcp.WithParam("noise_action"))
+		resp := fail(req, mcp.ErrUnknownMode, "Unknown noise action: "+args.Action, "Use a valid action: add, remove, list, move, dry_run, reset, auto_detect", mcp.WithParam("noise_action"))
 		return nil, &resp
 	}
 }
@@ -81,6 +88,8 @@ func noiseActionAdd(nc *noise.NoiseConfig, req mcp.JSONRPCRequest, args NoiseRul
 				StatusMax:    r.MatchSpec.StatusMax,
 				Level:        r.MatchSpec.Level,
 			},
+			Action: r.Action,
+			Tag:    r.Tag,
 		}
 	}
 	if err := nc.AddRules(rules); err != nil {
@@ -106,11 +115,28 @@ func noiseActionRemove(nc *noise.NoiseConfig, req mcp.JSONRPCRequest, args Noise
 	return map[string]any{"status": "ok", "removed": args.RuleID}, nil
 }
 
-func noiseActionList(nc *noise.NoiseConfig) any {
+func noiseActionMove(nc *noise.NoiseConfig, req mcp.JSONRPCRequest, args NoiseRuleArgs) (any, *mcp.JSONRPCResponse) {
+	if args.RuleID == "" {
+		resp := fail(req, mcp.ErrMissingParam, "Missing required parameter: rule_id", "Add the 'rule_id' parameter", mcp.WithParam("rule_id"))
+		return nil, &resp
+	}
+	if args.Position == nil {
+		resp := fail(req, mcp.ErrMissingParam, "Missing required parameter: position", "Add 'position' (0 = evaluated first)", mcp.WithParam("position"))
+		return nil, &resp
+	}
+	if err := nc.MoveRule(args.RuleID, *args.Position); err != nil {
+		resp := fail(req, mcp.ErrInvalidParam, err.Error(), "Use a user rule ID from list action")
+		return nil, &resp
+	}
+	return map[string]any{"status": "ok", "moved": args.RuleID, "rules": nc.ListRules()}, nil
+}
+
+func noiseActionList(d Deps, nc *noise.NoiseConfig) any {
 	rules := nc.ListRules()
 	stats := nc.GetStatistics()
 	return map[string]any{
-		"rules": rules,
+		"rules":   rules,
+		"matches": noiseDryRunCounts(d, nc),
 		"statistics": map[string]any{
 			"total_filtered": stats.TotalFiltered,
 			"per_rule":       stats.PerRule,
@@ -120,6 +146,32 @@ func noiseActionList(nc *noise.NoiseConfig) any {
 	}
 }
 
+func noiseActionDryRun(d Deps, nc *noise.NoiseConfig) any {
+	counts := nc.DryRun(d.ConsoleEntries(), d.NetworkBodies(), d.AllWebSocketEvents())
+	matching := make([]noise.RuleMatchCount, 0, len(counts))
+	for _, count := range counts {
+		if count.Matches > 0 {
+			matching = append(matching, count)
+		}
+	}
+	return map[string]any{
+		"matches":     matching,
+		"total_rules": len(counts),
+		"message":     "Counts are first-match claims over the current buffers; statistics are unchanged",
+	}
+}
+
+// noiseDryRunCounts maps rule ID to the number of buffered entries the rule currently claims.
+func noiseDryRunCounts(d Deps, nc *noise.NoiseConfig) map[string]int {
+	counts := map[string]int{}
+	for _, count := range nc.DryRun(d.ConsoleEntries(), d.NetworkBodies(), d.AllWebSocketEvents()) {
+		if count.Matches > 0 {
+			counts[count.RuleID] = count.Matches
+		}
+	}
+	return counts
+}
+
 func noiseActionReset(nc *noise.NoiseConfig) any {
 	nc.Reset()
 	return map[string]any{
@@ -0,0 +1,84 @@
+// silence.go — Implements configure(what="silence") alert silencing windows.
+// Why: Lets agents mute expected alert noise during maintenance without pausing telemetry capture.
+// Docs: docs/features/feature/push-alerts/index.md
+
+package toolconfigure
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/mcp"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/streaming"
+)
+
+// HandleSilence handles configure(what="silence").
+// operation=start (default when duration is set) opens a window, status lists active silences,
+// clear ends one silence (silence_id) or all of them.
+func HandleSilence(ab *streaming.AlertBuffer, req mcp.JSONRPCRequest, args json.RawMessage) mcp.JSONRPCResponse {
+	var params struct {
+		Operation  string   `json:"operation"`
+		Duration   string   `json:"duration"`
+		Categories []string `json:"categories"`
+		Reason     string   `json:"reason"`
+		SilenceID  string   `json:"silence_id"`
+	}
+	if len(args) > 0 {
+		if err := json.Unmarshal(args, &params); err != nil {
+			return fail(req, mcp.ErrInvalidJSON, "Invalid JSON arguments: "+err.Error(), "Fix JSON syntax and call again")
+		}
+	}
+	if params.Operation == "" {
+		params.Operation = "status"
+		if params.Duration != "" {
+			params.Operation = "start"
+		}
+	}
+
+	now := time.Now()
+	switch params.Operation {
+	case "start":
+		if params.Duration == "" {
+			return fail(req, mcp.ErrMissingParam, "Required parameter 'duration' is missing",
+				"Pass a duration such as '30m' or '2h'", mcp.WithParam("duration"))
+		}
+		d, err := time.ParseDuration(params.Duration)
+		if err != nil {
+			return fail(req, mcp.ErrInvalidParam, "Invalid duration: "+err.Error(),
+				"Use Go duration syntax such as '30m' or '1h30m'", mcp.WithParam("duration"))
+		}
+		silence, err := ab.AddSilence(params.Categories, d, params.Reason, now)
+		if err != nil {
+			return fail(req, mcp.ErrInvalidParam, err.Error(),
+				"Shorten the window or clear an existing silence first", mcp.WithParam("duration"))
+		}
+		return succeed(req, "Alert silence started", map[string]any{
+			"status":  "silenced",
+			"silence": silence,
+			"note":    "Capture continues; only alert emission is suppressed. The silence expires automatically.",
+		})
+
+	case "clear":
+		removed := ab.ClearSilences(params.SilenceID)
+		if params.SilenceID != "" && removed == 0 {
+			return fail(req, mcp.ErrInvalidParam, "No active silence with id "+params.SilenceID,
+				"Call configure(what='silence', operation='status') to list active silences", mcp.WithParam("silence_id"))
+		}
+		return succeed(req, "Alert silences cleared", map[string]any{
+			"status":  "cleared",
+			"removed": removed,
+			"active":  ab.ActiveSilences(now),
+		})
+
+	case "status":
+		active := ab.ActiveSilences(now)
+		return succeed(req, "Alert silences", map[string]any{
+			"active": active,
+			"count":  len(active),
+		})
+
+	default:
+		return fail(req, mcp.ErrInvalidParam, "Unknown silence operation: "+params.Operation,
+			"Use operation 'start', 'status', or 'clear'", mcp.WithParam("operation"))
+	}
+}
@@ -0,0 +1,47 @@
+// subscribe.go — Implements configure(what="subscribe") per-client push event filters.
+// Why: Different agents sharing one daemon care about different failures; each filters its own SSE stream.
+// Docs: docs/features/feature/push-alerts/index.md
+
+package toolconfigure
+
+import (
+	"encoding/json"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/mcp"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/notifyhub"
+)
+
+// HandleSubscribe handles configure(what="subscribe").
+// events replaces the calling client's filter (["all"] resets it); omitting events reports the current filter.
+// The filter applies to GET /mcp streams opened with the same X-Kaboom-Client ID.
+func HandleSubscribe(hub *notifyhub.Hub, req mcp.JSONRPCRequest, args json.RawMessage) mcp.JSONRPCResponse {
+	if hub == nil {
+		return fail(req, mcp.ErrNotInitialized, "Notification hub not initialized", "Restart the daemon and call again")
+	}
+	var params struct {
+		Events []string `json:"events"`
+	}
+	if len(args) > 0 {
+		if err := json.Unmarshal(args, &params); err != nil {
+			return fail(req, mcp.ErrInvalidJSON, "Invalid JSON arguments: "+err.Error(), "Fix JSON syntax and call again")
+		}
+	}
+
+	summary := "Push subscription"
+	if params.Events != nil {
+		if _, err := hub.Subscribe(req.ClientID, params.Events); err != nil {
+			return fail(req, mcp.ErrInvalidParam, err.Error(),
+				"Use console_error, network_error, ci_result, circuit_opened, circuit_closed, contract_violation, extension_reconnected, or all", mcp.WithParam("events"))
+		}
+		summary = "Push subscription updated"
+	}
+	status := hub.Status(req.ClientID)
+	result := map[string]any{
+		"subscription": status,
+		"stream":       "GET /mcp with Accept: text/event-stream",
+	}
+	if status.ClientSessions == 0 {
+		result["note"] = "No open stream for this client yet. Open GET /mcp with Accept: text/event-stream and the same X-Kaboom-Client header (or ?client_id=) to receive notifications."
+	}
+	return succeed(req, summary, result)
+}
@@ -20,6 +20,7 @@ func HandleExportHAR(d Deps, req mcp.JSONRPCRequest, args json.RawMessage) mcp.J
 		StatusMin int    `json:"status_min"`
 		StatusMax int    `json:"status_max"`
 		SaveTo    string `json:"save_to"`
+		Boundary  string `json:"boundary"`
 	}
 	if resp, stop := parseArgs(req, args, &params); stop {
 		return resp
@@ -28,6 +29,9 @@ func HandleExportHAR(d Deps, req mcp.JSONRPCRequest, args json.RawMessage) mcp.J
 	cap := d.GetCapture()
 	bodies := cap.GetNetworkBodies()
 	waterfall := cap.GetNetworkWaterfallEntries()
+	if params.Boundary != "" {
+		bodies, waterfall = harBoundaryEntries(bodies, waterfall, params.Boundary)
+	}
 	filter := capture.NetworkBodyFilter{
 		URLFilter: params.URL,
 		Method:    params.Method,
@@ -49,3 +53,20 @@ func HandleExportHAR(d Deps, req mcp.JSONRPCRequest, args json.RawMessage) mcp.J
 	summary := fmt.Sprintf("HAR export (%d entries)", len(harLog.Log.Entries))
 	return succeed(req, summary, harLog)
 }
+
+// harBoundaryEntries keeps only the bodies and waterfall entries tagged with boundary.
+func harBoundaryEntries(bodies []capture.NetworkBody, waterfall []capture.NetworkWaterfallEntry, boundary string) ([]capture.NetworkBody, []capture.NetworkWaterfallEntry) {
+	keptBodies := make([]capture.NetworkBody, 0, len(bodies))
+	for _, b := range bodies {
+		if capture.TaggedWith(b.TestIDs, boundary) {
+			keptBodies = append(keptBodies, b)
+		}
+	}
+	keptWaterfall := make([]capture.NetworkWaterfallEntry, 0, len(waterfall))
+	for _, w := range waterfall {
+		if capture.TaggedWith(w.TestIDs, boundary) {
+			keptWaterfall = append(keptWaterfall, w)
+		}
+	}
+	return keptBodies, keptWaterfall
+}
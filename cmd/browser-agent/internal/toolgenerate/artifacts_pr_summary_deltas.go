@@ -0,0 +1,284 @@
+// artifacts_pr_summary_deltas.go — Builds and renders the regression sections of generate(pr_summary).
+// Why: A PR comment should show what got worse or better in security, performance, errors, and accessibility, not just session activity.
+
+package toolgenerate
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/analysis"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/findings"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/session"
+)
+
+// prSectionItemLimit caps the items listed per section; the counts still cover every change.
+const prSectionItemLimit = 20
+
+// Delta statuses for PR summary items.
+const (
+	prStatusNew       = "new"
+	prStatusRegressed = "regressed"
+	prStatusFixed     = "fixed"
+)
+
+// PRDeltas is the tracked state a PR summary diffs, as gathered by a PRDeltaSource.
+type PRDeltas struct {
+	// Since starts the window: the baseline snapshot's capture time, or daemon start.
+	Since time.Time
+	// Baseline is the diff_sessions snapshot the window starts at; "" means daemon start.
+	Baseline string
+	Findings []findings.Finding
+	Clusters analysis.ClusterTrend
+	// Snapshot diffs the baseline snapshot against current state; nil without a baseline.
+	Snapshot *session.SessionDiffResult
+}
+
+// prSection is one area of the regression check.
+type prSection struct {
+	Area      string   `json:"area"`
+	Severity  string   `json:"severity"`
+	New       int      `json:"new"`
+	Regressed int      `json:"regressed"`
+	Fixed     int      `json:"fixed"`
+	Items     []prItem `json:"items"`
+}
+
+// prItem is one change within a section.
+type prItem struct {
+	Status   string `json:"status"`
+	Severity string `json:"severity,omitempty"`
+	ID       string `json:"id"`
+	Title    string `json:"title"`
+	Location string `json:"location,omitempty"`
+	Detail   string `json:"detail,omitempty"`
+}
+
+// buildPRSections classifies the deltas into the four PR summary areas, in display order.
+func buildPRSections(d PRDeltas) []prSection {
+	performance := findingItems(d.Findings, "performance", d.Since)
+	if d.Snapshot != nil {
+		performance = append(performance, snapshotPerfItems(d.Snapshot.Performance)...)
+	}
+	return []prSection{
+		newPRSection("Security", findingItems(d.Findings, "security", d.Since)),
+		newPRSection("Performance", performance),
+		newPRSection("Errors", clusterItems(d.Clusters, d.Since)),
+		newPRSection("Accessibility", findingItems(d.Findings, "a11y", d.Since)),
+	}
+}
+
+func newPRSection(area string, items []prItem) prSection {
+	s := prSection{Area: area, Severity: "none", Items: items}
+	worst := 0
+	for _, it := range items {
+		switch it.Status {
+		case prStatusNew:
+			s.New++
+		case prStatusRegressed:
+			s.Regressed++
+		case prStatusFixed:
+			s.Fixed++
+			continue
+		}
+		if rank := findings.SeverityRank(it.Severity); rank > worst || s.Severity == "none" {
+			worst = rank
+			s.Severity = severityName(rank)
+		}
+	}
+	if s.Items == nil {
+		s.Items = []prItem{}
+	}
+	return s
+}
+
+// findingItems returns the findings in category that appeared, regressed, or were fixed since.
+// A finding both first seen and fixed inside the window never reached the PR base, so it is skipped.
+func findingItems(list []findings.Finding, category string, since time.Time) []prItem {
+	var items []prItem
+	for _, f := range list {
+		if f.Category != category {
+			continue
+		}
+		var status string
+		switch {
+		case f.State == findings.StateRegressed && !f.ChangedAt.Before(since):
+			status = prStatusRegressed
+		case f.State == findings.StateFixed:
+			if f.FixedAt != nil && !f.FixedAt.Before(since) && f.FirstSeen.Before(since) {
+				status = prStatusFixed
+			}
+		case !f.FirstSeen.Before(since):
+			status = prStatusNew
+		}
+		if status == "" {
+			continue
+		}
+		title := f.Title
+		if title == "" {
+			title = f.FindingID
+		}
+		location := f.Location
+		if location == "" {
+			location = f.Scope
+		}
+		items = append(items, prItem{Status: status, Severity: f.Severity, ID: f.FindingID, Title: title, Location: location})
+	}
+	return items
+}
+
+// clusterItems returns error clusters first seen or regressed since.
+func clusterItems(trend analysis.ClusterTrend, since time.Time) []prItem {
+	var items []prItem
+	for _, c := range trend.Regressed {
+		if c.RegressedAt != nil && !c.RegressedAt.Before(since) {
+			items = append(items, clusterItem(c, prStatusRegressed))
+		}
+	}
+	for _, c := range trend.New {
+		if !c.FirstSeen.Before(since) && c.Status != analysis.ClusterStatusRegressed {
+			items = append(items, clusterItem(c, prStatusNew))
+		}
+	}
+	return items
+}
+
+func clusterItem(c analysis.ClusterHistoryRecord, status string) prItem {
+	return prItem{
+		Status:   status,
+		Severity: "error",
+		ID:       c.ID,
+		Title:    c.Message,
+		Detail:   fmt.Sprintf("%d occurrence(s) this session", c.SessionCount),
+	}
+}
+
+// snapshotPerfItems returns the page metrics that regressed against the baseline snapshot.
+func snapshotPerfItems(perf session.PerformanceDiff) []prItem {
+	metrics := []struct {
+		id, title string
+		change    *session.MetricChange
+	}{
+		{"load_time", "Load time", perf.LoadTime},
+		{"request_count", "Request count", perf.RequestCount},
+		{"transfer_size", "Transfer size", perf.TransferSize},
+	}
+	var items []prItem
+	for _, m := range metrics {
+		if m.change == nil || !m.change.Regression {
+			continue
+		}
+		items = append(items, prItem{
+			Status:   prStatusRegressed,
+			Severity: "medium",
+			ID:       "snapshot." + m.id,
+			Title:    m.title,
+			Detail:   fmt.Sprintf("%g → %g (%s)", m.change.Before, m.change.After, m.change.Change),
+		})
+	}
+	return items
+}
+
+// renderPRSections formats the sections as markdown: a status table, then one
+// collapsible block per area that has changes.
+func renderPRSections(sb *strings.Builder, d PRDeltas, sections []prSection) {
+	sb.WriteString("\n### Regression Check\n\n")
+	switch {
+	case d.Baseline != "":
+		fmt.Fprintf(sb, "Compared with snapshot `%s` (captured %s).\n\n", d.Baseline, d.Since.UTC().Format(time.RFC3339))
+	case !d.Since.IsZero():
+		fmt.Fprintf(sb, "Changes since the session started at %s.\n\n", d.Since.UTC().Format(time.RFC3339))
+	}
+	sb.WriteString("| Area | Status | New | Regressed | Fixed |\n")
+	sb.WriteString("| --- | --- | ---: | ---: | ---: |\n")
+	for _, s := range sections {
+		fmt.Fprintf(sb, "| %s | %s | %d | %d | %d |\n", s.Area, sectionBadge(s), s.New, s.Regressed, s.Fixed)
+	}
+	for _, s := range sections {
+		if len(s.Items) == 0 {
+			continue
+		}
+		fmt.Fprintf(sb, "\n<details>\n<summary><b>%s</b> %s: %d new, %d regressed, %d fixed</summary>\n\n", s.Area, sectionBadge(s), s.New, s.Regressed, s.Fixed)
+		for i, it := range s.Items {
+			if i == prSectionItemLimit {
+				fmt.Fprintf(sb, "- …and %d more\n", len(s.Items)-prSectionItemLimit)
+				break
+			}
+			sb.WriteString(renderPRItem(it))
+		}
+		sb.WriteString("\n</details>\n")
+	}
+}
+
+func renderPRItem(it prItem) string {
+	line := fmt.Sprintf("- %s **%s** %s (`%s`)", severityBadge(findings.SeverityRank(it.Severity)), it.Status, singleLine(it.Title), it.ID)
+	if it.Location != "" {
+		line += " at " + singleLine(it.Location)
+	}
+	if it.Detail != "" {
+		line += " — " + it.Detail
+	}
+	return line + "\n"
+}
+
+// sectionBadge is the worst new or regressed severity, or a pass badge.
+func sectionBadge(s prSection) string {
+	if s.New+s.Regressed == 0 {
+		return "✅ No regressions"
+	}
+	return severityBadge(findings.SeverityRank(s.Severity))
+}
+
+func severityBadge(rank int) string {
+	switch rank {
+	case 4:
+		return "🔴 Critical"
+	case 3:
+		return "🟠 High"
+	case 2:
+		return "🟡 Medium"
+	case 1:
+		return "🔵 Low"
+	}
+	return "⚪ Info"
+}
+
+func severityName(rank int) string {
+	switch rank {
+	case 4:
+		return "critical"
+	case 3:
+		return "high"
+	case 2:
+		return "medium"
+	case 1:
+		return "low"
+	}
+	return "info"
+}
+
+func singleLine(s string) string {
+	s = strings.Join(strings.Fields(s), " ")
+	if r := []rune(s); len(r) > 160 {
+		s = string(r[:157]) + "..."
+	}
+	return s
+}
+
+// sectionsChanged reports whether any section lists a change.
+func sectionsChanged(sections []prSection) bool {
+	for _, s := range sections {
+		if len(s.Items) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// attachPRSections adds the structured sections to a pr_summary response.
+func attachPRSections(response map[string]any, sections []prSection) {
+	if sections != nil {
+		response["regressions"] = sections
+	}
+}
@@ -12,8 +12,30 @@ import (
 	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/mcp"
 )
 
-// HandlePRSummary generates a PR markdown summary from captured session data.
+// HandlePRSummary generates a PR markdown summary from captured session data, plus
+// security, performance, error, and accessibility deltas when Deps is a PRDeltaSource.
 func HandlePRSummary(d Deps, req mcp.JSONRPCRequest, args json.RawMessage) mcp.JSONRPCResponse {
+	var params struct {
+		Baseline string `json:"baseline"`
+	}
+	lenientUnmarshal(args, &params)
+
+	var deltas *PRDeltas
+	if src, ok := d.(PRDeltaSource); ok {
+		got, err := src.PRDeltas(params.Baseline)
+		if err != nil {
+			return fail(req, mcp.ErrInvalidParam, "PR summary baseline unavailable: "+err.Error(),
+				`Capture one first with configure(what="diff_sessions", verif_session_action="capture", name=...), or omit baseline`, mcp.WithParam("baseline"))
+		}
+		deltas = &got
+	} else if params.Baseline != "" {
+		return fail(req, mcp.ErrNotInitialized, "Session snapshots are not available", "Omit baseline and call again")
+	}
+	var sections []prSection
+	if deltas != nil {
+		sections = buildPRSections(*deltas)
+	}
+
 	cap := d.GetCapture()
 	actions := cap.GetAllEnhancedActions()
 	completedCmds := cap.GetCompletedCommands()
@@ -53,7 +75,10 @@ func HandlePRSummary(d Deps, req mcp.JSONRPCRequest, args json.RawMessage) mcp.J
 	if totalActivity == 0 {
 		sb.WriteString("No activity captured during this session.\n\n")
 		sb.WriteString("Navigate to a page or interact with the browser to generate activity.\n")
-		return succeed(req, "PR summary generated", map[string]any{
+		if sectionsChanged(sections) {
+			renderPRSections(&sb, *deltas, sections)
+		}
+		response := map[string]any{
 			"summary": sb.String(),
 			"reason":  "no_activity_captured",
 			"hint":    "Navigate to a page or interact with the browser first, then call generate(pr_summary) again.",
@@ -61,7 +86,9 @@ func HandlePRSummary(d Deps, req mcp.JSONRPCRequest, args json.RawMessage) mcp.J
 				"actions": 0, "commands_completed": 0, "commands_failed": 0,
 				"console_errors": 0, "network_errors": 0, "network_captured": 0,
 			},
-		})
+		}
+		attachPRSections(response, sections)
+		return succeed(req, "PR summary generated", response)
 	}
 
 	if tabURL != "" {
@@ -86,8 +113,12 @@ func HandlePRSummary(d Deps, req mcp.JSONRPCRequest, args json.RawMessage) mcp.J
 	}
 	sb.WriteString(fmt.Sprintf("- **Network Requests Captured:** %d\n", len(networkBodies)))
 
+	if deltas != nil {
+		renderPRSections(&sb, *deltas, sections)
+	}
+
 	summary := sb.String()
-	return succeed(req, "PR summary generated", map[string]any{
+	response := map[string]any{
 		"summary": summary,
 		"stats": map[string]any{
 			"actions":            len(actions),
@@ -97,5 +128,7 @@ func HandlePRSummary(d Deps, req mcp.JSONRPCRequest, args json.RawMessage) mcp.J
 			"network_errors":     networkErrors,
 			"network_captured":   len(networkBodies),
 		},
-	})
+	}
+	attachPRSections(response, sections)
+	return succeed(req, "PR summary generated", response)
 }
@@ -1,10 +1,11 @@
-// artifacts_security_impl.go — Implements generate(csp) and generate(sri) artifact assembly.
+// artifacts_security_impl.go — Implements generate(csp), generate(sri), and generate(security_headers) artifact assembly.
 // Why: Groups security-focused artifact generation paths under one focused module.
 
 package toolgenerate
 
 import (
 	"encoding/json"
+	"fmt"
 
 	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/mcp"
 	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/security"
@@ -72,3 +73,28 @@ func HandleGenerateSRI(d Deps, req mcp.JSONRPCRequest, args json.RawMessage) mcp
 
 	return succeed(req, "SRI hashes generated", result)
 }
+
+// HandleGenerateSecurityHeaders recommends Permissions-Policy, framing, referrer, and
+// cross-origin isolation headers from observed page behavior.
+func HandleGenerateSecurityHeaders(d Deps, req mcp.JSONRPCRequest, _ json.RawMessage) mcp.JSONRPCResponse {
+	cap := d.GetCapture()
+	networkBodies := cap.GetNetworkBodies()
+	waterfall := cap.GetNetworkWaterfallEntries()
+	if len(networkBodies) == 0 && len(waterfall) == 0 {
+		return succeed(req, "Security headers unavailable", map[string]any{
+			"status": "unavailable",
+			"reason": "No network traffic captured yet. Header recommendations are derived from the scripts, frames, and origins the page loads.",
+			"hint":   "Navigate the tracked page and exercise its features (maps, camera, payments, popups), then call generate(security_headers) again.",
+		})
+	}
+
+	_, _, tabURL := cap.GetTrackingStatus()
+	result := security.GenerateSecurityHeaders(security.SecurityHeadersInput{
+		PageURL:   tabURL,
+		Bodies:    networkBodies,
+		Waterfall: waterfall,
+	})
+	summary := fmt.Sprintf("Security headers: %d recommended, %d missing, %d differ",
+		result.Summary.Total, result.Summary.Missing, result.Summary.Differs)
+	return succeed(req, summary, result)
+}
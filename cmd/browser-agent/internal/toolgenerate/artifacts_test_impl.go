@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/mcp"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/reproduction"
 	gen "github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/tools/generate"
 )
 
@@ -21,27 +22,70 @@ func HandleGenerateTest(d Deps, req mcp.JSONRPCRequest, args json.RawMessage) mc
 	if params.TestName == "" {
 		params.TestName = "generated test"
 	}
+	if param, msg := gen.ValidateLocaleParams(params); param != "" {
+		return fail(req, mcp.ErrInvalidParam, msg,
+			"Use locator_strategy auto or stable, and BCP 47 locales such as en-US or de-DE", mcp.WithParam(param))
+	}
 
-	allActions := d.GetCapture().GetAllEnhancedActions()
+	if env, _, ok := d.GetCapture().GetBrowserEnvironment(); ok {
+		params.Environment = &env
+	}
+	if device, ok := d.GetCapture().GetDeviceEmulation(); ok {
+		params.Device = &device
+	}
+	if region, ok := d.GetCapture().GetRegionOverride(); ok {
+		params.Region = &region
+	}
+
+	allActions := reproduction.FilterBoundary(d.GetCapture().GetAllEnhancedActions(), params.Boundary)
 	actions := gen.FilterLastN(allActions, params.LastN)
 	script := gen.GenerateTestScript(actions, params)
+	localeTexts := gen.LocaleTexts(actions, params)
+	strategy := params.LocatorStrategy
+	if strategy == "" {
+		strategy = gen.LocatorStrategyAuto
+	}
+
+	metadata := map[string]any{
+		"generated_at":           time.Now().Format(time.RFC3339),
+		"actions_available":      len(allActions),
+		"actions_included":       len(actions),
+		"assert_network":         params.AssertNetwork,
+		"assert_no_errors":       params.AssertNoErrors,
+		"locator_strategy":       strategy,
+		"locale_dependent_texts": len(localeTexts),
+	}
+	if recorded := gen.RecordedLocale(actions); recorded != "" {
+		metadata["recorded_locale"] = recorded
+	}
+	if len(params.Locales) > 0 {
+		metadata["locales"] = params.Locales
+	}
+	if params.Boundary != "" {
+		metadata["boundary"] = params.Boundary
+	}
+	if params.Environment != nil {
+		metadata["environment"] = params.Environment
+	}
+	if params.Device != nil {
+		metadata["device"] = params.Device
+	}
+	if params.Region != nil {
+		metadata["region_override"] = params.Region
+	}
 
 	result := map[string]any{
 		"script":       script,
 		"test_name":    params.TestName,
 		"action_count": len(actions),
-		"metadata": map[string]any{
-			"generated_at":      time.Now().Format(time.RFC3339),
-			"actions_available": len(allActions),
-			"actions_included":  len(actions),
-			"assert_network":    params.AssertNetwork,
-			"assert_no_errors":  params.AssertNoErrors,
-		},
+		"metadata":     metadata,
 	}
 
 	if len(actions) == 0 {
 		result["reason"] = "no_actions_captured"
 		result["hint"] = "Navigate and interact with the browser first, then call generate(test) again."
+	} else if len(localeTexts) > 0 && strategy == gen.LocatorStrategyAuto && len(params.Locales) == 0 {
+		result["hint"] = fmt.Sprintf("%d locators match UI text and only work in the recorded language. Use locator_strategy=\"stable\" to prefer ids, or locales=[...] to generate per-locale variants.", len(localeTexts))
 	}
 
 	summary := fmt.Sprintf("Playwright test '%s' (%d actions)", params.TestName, len(actions))
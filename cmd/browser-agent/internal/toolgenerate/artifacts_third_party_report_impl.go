@@ -0,0 +1,110 @@
+// artifacts_third_party_report_impl.go — Implements generate(third_party_report) markdown supply-chain reports.
+// Why: Turns the third-party script audit into a reviewable artifact for PRs and security reviews.
+
+package toolgenerate
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/analysis"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/mcp"
+)
+
+// HandleGenerateThirdPartyReport renders the third-party script supply-chain audit as markdown.
+// It compares against the stored baseline but never updates it.
+func HandleGenerateThirdPartyReport(d Deps, req mcp.JSONRPCRequest, args json.RawMessage) mcp.JSONRPCResponse {
+	var arguments struct {
+		FirstPartyOrigins  []string `json:"first_party_origins"`
+		FirstPartySuffixes []string `json:"first_party_suffixes"`
+	}
+	if len(args) > 0 {
+		if resp, stop := parseArgs(req, args, &arguments); stop {
+			return resp
+		}
+	}
+
+	cap := d.GetCapture()
+	networkBodies := cap.GetNetworkBodies()
+	waterfall := cap.GetNetworkWaterfallEntries()
+	if len(networkBodies) == 0 && len(waterfall) == 0 {
+		return succeed(req, "Third-party report unavailable", map[string]any{
+			"status": "unavailable",
+			"hint":   "Navigate the tracked page to load its scripts, then call generate(third_party_report) again.",
+		})
+	}
+
+	_, _, tabURL := cap.GetTrackingStatus()
+	var pageURLs []string
+	if tabURL != "" {
+		pageURLs = []string{tabURL}
+	}
+	params, _ := json.Marshal(analysis.SupplyChainParams{FirstPartyOrigins: arguments.FirstPartyOrigins, FirstPartySuffixes: arguments.FirstPartySuffixes})
+	report, err := analysis.HandleSupplyChainAudit(params, networkBodies, waterfall, pageURLs)
+	if err != nil {
+		return fail(req, mcp.ErrInvalidParam, "Third-party report failed: "+err.Error(), "Fix parameters and call again")
+	}
+
+	return succeed(req, "Third-party report generated", map[string]any{
+		"status":  "ok",
+		"report":  renderThirdPartyReport(report),
+		"summary": report.Summary,
+		"changes": report.Changes,
+	})
+}
+
+// renderThirdPartyReport formats the supply-chain report as markdown.
+func renderThirdPartyReport(r analysis.SupplyChainReport) string {
+	var sb strings.Builder
+	sb.WriteString("## Third-Party Script Supply-Chain Report\n\n")
+	if r.FirstPartyOrigin != "" {
+		sb.WriteString(fmt.Sprintf("- **First party:** %s\n", r.FirstPartyOrigin))
+	}
+	s := r.Summary
+	sb.WriteString(fmt.Sprintf("- **Scripts:** %d from %d origin(s), %d bytes\n", s.TotalScripts, s.Origins, s.TotalBytes))
+	sb.WriteString(fmt.Sprintf("- **Unpinned:** %d · **Unrecognized hosts:** %d · **Render-blocking:** %d · **Without SRI:** %d\n",
+		s.Unpinned, s.UnknownHosts, s.Blocking, s.WithoutSRI))
+	if r.Baseline != nil {
+		sb.WriteString(fmt.Sprintf("- **Baseline:** %s (%d scripts), %d change(s)\n", r.Baseline.CreatedAt, r.Baseline.Scripts, s.Changes))
+	} else {
+		sb.WriteString("- **Baseline:** none stored; run observe(what='third_party_audit', update_baseline=true) to enable change detection\n")
+	}
+
+	if len(r.Changes) > 0 {
+		sb.WriteString("\n### Changes Since Baseline\n\n")
+		for _, c := range r.Changes {
+			line := fmt.Sprintf("- `%s` %s", c.Kind, c.URL)
+			if c.Before != "" || c.After != "" {
+				line += fmt.Sprintf(" (%s → %s)", c.Before, c.After)
+			}
+			sb.WriteString(line + "\n")
+		}
+	}
+
+	if len(r.Scripts) > 0 {
+		sb.WriteString("\n### Scripts\n\n")
+		sb.WriteString("| Risk | Script | Host | Pinning | Load | SRI | Size |\n")
+		sb.WriteString("|------|--------|------|---------|------|-----|------|\n")
+		for _, sc := range r.Scripts {
+			pin := sc.PinKind
+			if sc.Version != "" {
+				pin += " " + sc.Version
+			}
+			sri := "no"
+			if sc.HasSRI {
+				sri = "yes"
+			}
+			sb.WriteString(fmt.Sprintf("| %s | %s | %s | %s | %s | %s | %d |\n",
+				sc.RiskLevel, sc.URL, sc.Classification, pin, sc.LoadPosition, sri, sc.SizeBytes))
+		}
+	}
+
+	if len(r.Recommendations) > 0 {
+		sb.WriteString("\n### Recommendations\n\n")
+		for _, rec := range r.Recommendations {
+			sb.WriteString("- " + rec + "\n")
+		}
+	}
+	return sb.String()
+}
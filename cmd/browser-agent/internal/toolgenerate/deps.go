@@ -29,3 +29,12 @@ type Deps interface {
 	// IsExtensionConnected reports whether the browser extension is connected.
 	IsExtensionConnected() bool
 }
+
+// PRDeltaSource is optionally implemented by Deps that track findings, error
+// clusters, and session snapshots. generate(pr_summary) adds its regression
+// sections only when the Deps implement it.
+type PRDeltaSource interface {
+	// PRDeltas gathers the tracked state since baseline, a diff_sessions snapshot
+	// name, or since the daemon started when baseline is "".
+	PRDeltas(baseline string) (PRDeltas, error)
+}
@@ -8,8 +8,11 @@ import (
 	"testing"
 	"time"
 
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/analysis"
 	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/annotation"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/findings"
 	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/mcp"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/session"
 )
 
 // ---------------------------------------------------------------------------
@@ -409,3 +412,82 @@ func TestGenerateValidParams_AllFormatsPresent(t *testing.T) {
 		}
 	}
 }
+
+// ---------------------------------------------------------------------------
+// PR summary deltas
+// ---------------------------------------------------------------------------
+
+func TestBuildPRSections_ClassifiesWindow(t *testing.T) {
+	since := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	before, after := since.Add(-time.Hour), since.Add(time.Hour)
+	fixedAt, regressedAt := after, after
+	deltas := PRDeltas{
+		Since: since,
+		Findings: []findings.Finding{
+			{FindingID: "security.missing_csp", Category: "security", Severity: "high", Title: "Missing CSP", State: findings.StateOpen, FirstSeen: after},
+			{FindingID: "security.weak_hsts", Category: "security", Severity: "medium", State: findings.StateFixed, FirstSeen: before, FixedAt: &fixedAt},
+			{FindingID: "security.old_issue", Category: "security", Severity: "critical", State: findings.StateOpen, FirstSeen: before},
+			{FindingID: "a11y.color-contrast", Category: "a11y", Severity: "serious", State: findings.StateRegressed, FirstSeen: before, ChangedAt: after},
+			{FindingID: "performance.lcp", Category: "performance", Severity: "poor", State: findings.StateFixed, FirstSeen: after, FixedAt: &fixedAt},
+		},
+		Clusters: analysis.ClusterTrend{
+			New:       []analysis.ClusterHistoryRecord{{ID: "c1", Message: "TypeError: x is undefined", FirstSeen: after, Status: analysis.ClusterStatusActive, SessionCount: 3}},
+			Regressed: []analysis.ClusterHistoryRecord{{ID: "c2", Message: "fetch failed", FirstSeen: before, Status: analysis.ClusterStatusRegressed, RegressedAt: &regressedAt}},
+		},
+		Snapshot: &session.SessionDiffResult{Performance: session.PerformanceDiff{
+			LoadTime:     &session.MetricChange{Before: 1000, After: 1600, Change: "+60%", Regression: true},
+			RequestCount: &session.MetricChange{Before: 40, After: 38, Change: "-5%"},
+		}},
+	}
+
+	sections := buildPRSections(deltas)
+	want := []struct {
+		area                   string
+		severity               string
+		newN, regressed, fixed int
+	}{
+		{"Security", "high", 1, 0, 1},
+		{"Performance", "medium", 0, 1, 0},
+		{"Errors", "high", 1, 1, 0},
+		{"Accessibility", "high", 0, 1, 0},
+	}
+	if len(sections) != len(want) {
+		t.Fatalf("sections = %d, want %d", len(sections), len(want))
+	}
+	for i, w := range want {
+		s := sections[i]
+		if s.Area != w.area || s.Severity != w.severity || s.New != w.newN || s.Regressed != w.regressed || s.Fixed != w.fixed {
+			t.Errorf("section %d = %+v, want %+v", i, s, w)
+		}
+	}
+}
+
+func TestRenderPRSections_BadgesAndDetails(t *testing.T) {
+	deltas := PRDeltas{
+		Baseline: "before",
+		Since:    time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC),
+		Findings: []findings.Finding{
+			{FindingID: "security.missing_csp", Category: "security", Severity: "critical", Title: "Missing\nCSP", Location: "https://app.test/", State: findings.StateOpen, FirstSeen: time.Date(2026, 10, 16, 13, 0, 0, 0, time.UTC)},
+		},
+	}
+	var sb strings.Builder
+	renderPRSections(&sb, deltas, buildPRSections(deltas))
+	out := sb.String()
+
+	for _, want := range []string{
+		"### Regression Check",
+		"Compared with snapshot `before` (captured 2026-10-16T12:00:00Z)",
+		"| Security | 🔴 Critical | 1 | 0 | 0 |",
+		"| Accessibility | ✅ No regressions | 0 | 0 | 0 |",
+		"<details>\n<summary><b>Security</b> 🔴 Critical: 1 new, 0 regressed, 0 fixed</summary>",
+		"- 🔴 Critical **new** Missing CSP (`security.missing_csp`) at https://app.test/",
+		"</details>",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("markdown missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Count(out, "<details>") != 1 {
+		t.Errorf("only sections with changes should get a details block:\n%s", out)
+	}
+}
@@ -15,19 +15,28 @@ import (
 // GenerateValidParams defines the allowed parameter names per generate format.
 // The "format" and "telemetry_mode" params are always allowed.
 var GenerateValidParams = map[string]map[string]bool{
-	"reproduction":      {"error_message": true, "last_n": true, "base_url": true, "include_screenshots": true, "generate_fixtures": true, "visual_assertions": true, "save_to": true, "output_format": true},
-	"test":              {"test_name": true, "last_n": true, "base_url": true, "assert_network": true, "assert_no_errors": true, "assert_response_shape": true, "save_to": true},
-	"pr_summary":        {"save_to": true},
-	"har":               {"url": true, "method": true, "status_min": true, "status_max": true, "save_to": true},
-	"csp":               {"mode": true, "include_report_uri": true, "exclude_origins": true, "save_to": true},
-	"sri":               {"resource_types": true, "origins": true, "save_to": true},
-	"sarif":             {"scope": true, "include_passes": true, "save_to": true},
-	"visual_test":       {"test_name": true, "annot_session": true, "save_to": true},
-	"annotation_report": {"annot_session": true, "save_to": true},
-	"annotation_issues": {"annot_session": true, "save_to": true},
-	"test_from_context": {"context": true, "error_id": true, "include_mocks": true, "output_format": true, "save_to": true},
-	"test_heal":         {"action": true, "test_file": true, "test_dir": true, "broken_selectors": true, "auto_apply": true, "save_to": true},
-	"test_classify":     {"action": true, "failure": true, "failures": true, "save_to": true},
+	"reproduction":       {"error_message": true, "last_n": true, "base_url": true, "include_screenshots": true, "generate_fixtures": true, "visual_assertions": true, "save_to": true, "output_format": true, "boundary": true},
+	"test":               {"test_name": true, "last_n": true, "base_url": true, "assert_network": true, "assert_no_errors": true, "assert_response_shape": true, "locator_strategy": true, "locales": true, "save_to": true, "boundary": true},
+	"pr_summary":         {"baseline": true, "save_to": true},
+	"har":                {"url": true, "method": true, "status_min": true, "status_max": true, "save_to": true, "boundary": true},
+	"csp":                {"mode": true, "include_report_uri": true, "exclude_origins": true, "save_to": true},
+	"sri":                {"resource_types": true, "origins": true, "save_to": true},
+	"security_headers":   {"save_to": true},
+	"third_party_report": {"first_party_origins": true, "save_to": true},
+	"sarif":              {"scope": true, "include_passes": true, "save_to": true},
+	"visual_test":        {"test_name": true, "annot_session": true, "save_to": true},
+	"annotation_report":  {"annot_session": true, "save_to": true},
+	"annotation_issues":  {"annot_session": true, "save_to": true},
+	"test_from_context":  {"context": true, "error_id": true, "include_mocks": true, "output_format": true, "save_to": true},
+	"test_heal":          {"action": true, "test_file": true, "test_dir": true, "broken_selectors": true, "auto_apply": true, "save_to": true},
+	"test_classify":      {"action": true, "failure": true, "failures": true, "save_to": true},
+	"session_bundle":     {"save_to": true, "label": true},
+	"debug_bundle":       {"save_to": true},
+	"junit":              {"suite_name": true, "budget": true, "save_to": true},
+	"gh_annotations":     {"save_to": true},
+	"curl":               {"request_id": true, "save_to": true},
+	"fetch":              {"request_id": true, "save_to": true},
+	"coverage_report":    {"url": true, "limit": true, "save_to": true},
 }
 
 // AlwaysAllowedGenerateParams are params valid for every generate format.
@@ -10,8 +10,10 @@ import (
 	"time"
 
 	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/loginrecipe"
 	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/mcp"
 	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/queries"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/statevault"
 )
 
 // GuardCheck mirrors the main package's guardCheck type.
@@ -33,6 +35,9 @@ type Deps struct {
 	// RequireCSPClear checks CSP restrictions for a given world.
 	RequireCSPClear func(req mcp.JSONRPCRequest, world string) (mcp.JSONRPCResponse, bool)
 
+	// CDPPassthroughAllows reports whether the operator policy lets a raw CDP method through.
+	CDPPassthroughAllows func(method string) (allowed bool, reason string)
+
 	// -- Command dispatch --
 
 	// EnqueuePendingQuery queues a command for the extension.
@@ -113,6 +118,12 @@ type Deps struct {
 	// GetCommandResult retrieves a command result by correlation ID.
 	GetCommandResult func(correlationID string) (*queries.CommandResult, bool)
 
+	// StateVault returns the encrypted auth state vault, or nil when --state-key is not set.
+	StateVault func() *statevault.Vault
+
+	// LoadLoginRecipe reads a login recipe stored with configure(what="define_login").
+	LoadLoginRecipe func(name string) (loginrecipe.Recipe, error)
+
 	// -- Shared concurrency --
 
 	// ReplayMu is the shared mutex for batch/replay serialization.
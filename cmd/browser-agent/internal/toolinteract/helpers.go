@@ -25,23 +25,24 @@ const JSONRPCVersion = mcp.JSONRPCVersion
 
 // Error code re-exports.
 const (
-	ErrInvalidJSON          = mcp.ErrInvalidJSON
-	ErrMissingParam         = mcp.ErrMissingParam
-	ErrInvalidParam         = mcp.ErrInvalidParam
-	ErrUnknownMode          = mcp.ErrUnknownMode
-	ErrPathNotAllowed       = mcp.ErrPathNotAllowed
-	ErrNotInitialized       = mcp.ErrNotInitialized
-	ErrNoData               = mcp.ErrNoData
-	ErrCodePilotDisabled    = mcp.ErrCodePilotDisabled
-	ErrOsAutomationDisabled = mcp.ErrOsAutomationDisabled
-	ErrRateLimited          = mcp.ErrRateLimited
-	ErrCursorExpired        = mcp.ErrCursorExpired
-	ErrExtTimeout           = mcp.ErrExtTimeout
-	ErrExtError             = mcp.ErrExtError
-	ErrQueueFull            = mcp.ErrQueueFull
-	ErrInternal             = mcp.ErrInternal
-	ErrMarshalFailed        = mcp.ErrMarshalFailed
-	ErrExportFailed         = mcp.ErrExportFailed
+	ErrInvalidJSON            = mcp.ErrInvalidJSON
+	ErrMissingParam           = mcp.ErrMissingParam
+	ErrInvalidParam           = mcp.ErrInvalidParam
+	ErrUnknownMode            = mcp.ErrUnknownMode
+	ErrPathNotAllowed         = mcp.ErrPathNotAllowed
+	ErrNotInitialized         = mcp.ErrNotInitialized
+	ErrNoData                 = mcp.ErrNoData
+	ErrCodePilotDisabled      = mcp.ErrCodePilotDisabled
+	ErrOsAutomationDisabled   = mcp.ErrOsAutomationDisabled
+	ErrCDPPassthroughDisabled = mcp.ErrCDPPassthroughDisabled
+	ErrRateLimited            = mcp.ErrRateLimited
+	ErrCursorExpired          = mcp.ErrCursorExpired
+	ErrExtTimeout             = mcp.ErrExtTimeout
+	ErrExtError               = mcp.ErrExtError
+	ErrQueueFull              = mcp.ErrQueueFull
+	ErrInternal               = mcp.ErrInternal
+	ErrMarshalFailed          = mcp.ErrMarshalFailed
+	ErrExportFailed           = mcp.ErrExportFailed
 )
 
 // succeed builds a success JSONRPCResponse with a JSON summary + data payload.
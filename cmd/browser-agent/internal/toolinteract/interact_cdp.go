@@ -0,0 +1,90 @@
+// Purpose: Handles interact(what="cdp"), sending one raw Chrome DevTools Protocol command to the tracked tab.
+// Why: Gives power users an escape hatch to CDP capabilities Kaboom has not wrapped, behind the operator policy.
+// Docs: docs/features/feature/cdp-passthrough/index.md
+
+package toolinteract
+
+import (
+	"encoding/json"
+	"regexp"
+)
+
+// cdpMethodPattern matches "Domain.method" CDP method names.
+var cdpMethodPattern = regexp.MustCompile(`^[A-Z][A-Za-z]*\.[a-z][A-Za-z]*$`)
+
+// HandleCDPPassthrough queues a cdp_action passthrough query. The command goes through the
+// same pilot, extension, and tab guards as hardware_click; the tool call is audit-logged and
+// the result redacted like any other tool response.
+//
+// The request asked for the passthrough only "when the CDP driver is active". That driver is
+// the extension's chrome.debugger attachment, which observe(what="capabilities") reports as
+// available exactly when the extension is connected and AI Web Pilot is on, so the
+// RequirePilot and RequireExtension guards are that gate; there is no separate driver state.
+//
+// The extension holds the debugger session between passthrough calls so state such as
+// Network.emulateNetworkConditions persists; release=true queues a release that ends it.
+func (h *InteractActionHandler) HandleCDPPassthrough(req JSONRPCRequest, args json.RawMessage) JSONRPCResponse {
+	var params struct {
+		Method  string          `json:"method"`
+		Params  json.RawMessage `json:"params"`
+		Release bool            `json:"release"`
+		TabID   int             `json:"tab_id"`
+	}
+	if resp, stop := parseArgs(req, args, &params); stop {
+		return resp
+	}
+	if params.Release {
+		return h.queueCDPRelease(req, args, params.TabID)
+	}
+	if resp, blocked := requireString(req, params.Method, "method", "Add the CDP method, e.g. method='Network.emulateNetworkConditions', or release=true to end the held session"); blocked {
+		return resp
+	}
+	if !cdpMethodPattern.MatchString(params.Method) {
+		return fail(req, ErrInvalidParam, "Invalid CDP method: "+params.Method, "Use Domain.method form, e.g. 'Network.emulateNetworkConditions'", withParam("method"))
+	}
+	cdpParams := map[string]any{}
+	if len(params.Params) > 0 && string(params.Params) != "null" {
+		if err := json.Unmarshal(params.Params, &cdpParams); err != nil {
+			return fail(req, ErrInvalidParam, "CDP params must be a JSON object", "Pass params as an object of the method's arguments", withParam("params"))
+		}
+	}
+	if allowed, reason := h.deps.CDPPassthroughAllows(params.Method); !allowed {
+		return fail(req, ErrCDPPassthroughDisabled, reason, "Use a wrapped interact action instead, or ask the user to allow this method when starting the daemon", withParam("method"), withAction("cdp"))
+	}
+
+	return h.newCommand("cdp_passthrough").
+		correlationPrefix("cdp").
+		reason("cdp").
+		queryType("cdp_action").
+		buildParams(map[string]any{
+			"action": "passthrough",
+			"method": params.Method,
+			"params": cdpParams,
+		}).
+		tabID(params.TabID).
+		guardsWithOpts(
+			[]func(*StructuredError){withAction("cdp")},
+			h.deps.RequirePilot, h.deps.RequireExtension, h.deps.RequireTabTracking,
+		).
+		recordAction("cdp", "", map[string]any{"method": params.Method}).
+		queuedMessage("CDP "+params.Method+" queued").
+		execute(req, args)
+}
+
+// queueCDPRelease queues a cdp_action release, detaching the debugger session held for
+// passthrough on the tab. It needs no method, so the operator's method policy does not apply.
+func (h *InteractActionHandler) queueCDPRelease(req JSONRPCRequest, args json.RawMessage, tabID int) JSONRPCResponse {
+	return h.newCommand("cdp_release").
+		correlationPrefix("cdp").
+		reason("cdp").
+		queryType("cdp_action").
+		buildParams(map[string]any{"action": "release"}).
+		tabID(tabID).
+		guardsWithOpts(
+			[]func(*StructuredError){withAction("cdp")},
+			h.deps.RequirePilot, h.deps.RequireExtension, h.deps.RequireTabTracking,
+		).
+		recordAction("cdp", "", map[string]any{"release": true}).
+		queuedMessage("CDP session release queued").
+		execute(req, args)
+}
@@ -71,6 +71,12 @@ func toFloat64(v any) (float64, bool) {
 }
 
 func (h *InteractActionHandler) HandleDOMPrimitive(req JSONRPCRequest, args json.RawMessage, action string) JSONRPCResponse {
+	return h.handleDOMPrimitive(req, args, action, false)
+}
+
+// handleDOMPrimitive runs a DOM primitive. With secretText set, the typed text is recorded
+// as "[redacted]" so it never reaches reproductions or generated tests.
+func (h *InteractActionHandler) handleDOMPrimitive(req JSONRPCRequest, args json.RawMessage, action string, secretText bool) JSONRPCResponse {
 	params, err := ParseDOMPrimitiveParams(args)
 	if err != nil {
 		return fail(req, ErrInvalidJSON, "Invalid JSON arguments: "+err.Error(), "Fix JSON syntax and call again")
@@ -113,7 +119,11 @@ func (h *InteractActionHandler) HandleDOMPrimitive(req JSONRPCRequest, args json
 			h.deps.RequirePilot, h.deps.RequireExtension, h.deps.RequireTabTracking,
 		).
 		postEnqueue(func() {
-			h.deps.RecordDOMPrimitiveAction(action, params.Selector, params.Text, params.Value)
+			text := params.Text
+			if secretText {
+				text = redactedValue
+			}
+			h.deps.RecordDOMPrimitiveAction(action, params.Selector, text, params.Value)
 		}).
 		queuedMessage(action + " queued").
 		execute(req, args)
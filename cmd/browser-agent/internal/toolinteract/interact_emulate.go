@@ -0,0 +1,159 @@
+// Purpose: Implements interact(action="emulate") to render the tracked tab as a phone, tablet, or custom viewport.
+// Why: Responsive bugs only reproduce at the device's viewport, DPR, user agent, and touch support.
+// Docs: docs/features/feature/device-emulation/index.md
+
+package toolinteract
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+)
+
+const (
+	iosUserAgent     = "Mozilla/5.0 (iPhone; CPU iPhone OS 16_0 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/16.0 Mobile/15E148 Safari/604.1"
+	ipadUserAgent    = "Mozilla/5.0 (iPad; CPU OS 16_0 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/16.0 Mobile/15E148 Safari/604.1"
+	androidUserAgent = "Mozilla/5.0 (Linux; Android 14; Pixel 7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/124.0.0.0 Mobile Safari/537.36"
+	galaxyUserAgent  = "Mozilla/5.0 (Linux; Android 13; SM-S911B) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/124.0.0.0 Mobile Safari/537.36"
+)
+
+// devicePresets mirror Playwright's device descriptors by name and viewport, so a
+// generated test can use devices['<name>'] and see the page the way the session did.
+var devicePresets = []capture.DeviceEmulation{
+	{Device: "iPhone 14", Width: 390, Height: 664, DevicePixelRatio: 3, UserAgent: iosUserAgent, Touch: true, Mobile: true},
+	{Device: "iPhone 14 Pro Max", Width: 430, Height: 740, DevicePixelRatio: 3, UserAgent: iosUserAgent, Touch: true, Mobile: true},
+	{Device: "iPhone SE", Width: 320, Height: 568, DevicePixelRatio: 2, UserAgent: iosUserAgent, Touch: true, Mobile: true},
+	{Device: "Pixel 7", Width: 412, Height: 839, DevicePixelRatio: 2.625, UserAgent: androidUserAgent, Touch: true, Mobile: true},
+	{Device: "Galaxy S23", Width: 360, Height: 780, DevicePixelRatio: 3, UserAgent: galaxyUserAgent, Touch: true, Mobile: true},
+	{Device: "iPad Mini", Width: 768, Height: 1024, DevicePixelRatio: 2, UserAgent: ipadUserAgent, Touch: true, Mobile: true},
+	{Device: "iPad Pro 11", Width: 834, Height: 1194, DevicePixelRatio: 2, UserAgent: ipadUserAgent, Touch: true, Mobile: true},
+	{Device: "Desktop Chrome", Width: 1280, Height: 720, DevicePixelRatio: 1},
+}
+
+// emulationOffValues clear emulation when passed as the device name.
+var emulationOffValues = map[string]bool{"off": true, "none": true, "reset": true}
+
+const (
+	maxEmulatedDimension = 10000
+	maxEmulatedDPR       = 10
+)
+
+// customDevice is the object form of the device param.
+type customDevice struct {
+	Width  int     `json:"width"`
+	Height int     `json:"height"`
+	DPR    float64 `json:"dpr"`
+	UA     string  `json:"ua"`
+	Touch  bool    `json:"touch"`
+	Mobile *bool   `json:"mobile"`
+}
+
+// HandleEmulate applies a device preset or custom metrics to the tracked tab, or clears
+// emulation with device="off". The device is recorded optimistically once the command is
+// queued so performance snapshots and generated tests taken afterwards carry it.
+func (h *InteractActionHandler) HandleEmulate(req JSONRPCRequest, args json.RawMessage) JSONRPCResponse {
+	var params struct {
+		Device json.RawMessage `json:"device"`
+		TabID  int             `json:"tab_id,omitempty"`
+	}
+	if resp, stop := parseArgs(req, args, &params); stop {
+		return resp
+	}
+
+	device, clear, errMsg := resolveEmulatedDevice(params.Device)
+	if errMsg != "" {
+		return fail(req, ErrInvalidParam, errMsg,
+			"Pass a device name ("+strings.Join(devicePresetNames(), ", ")+"), an object {width, height, dpr, ua, touch}, or 'off'",
+			withParam("device"))
+	}
+
+	queryParams := map[string]any{"action": "clear"}
+	label := "off"
+	var recorded *capture.DeviceEmulation
+	if !clear {
+		queryParams = map[string]any{
+			"action":              "apply",
+			"device":              device.Device,
+			"width":               device.Width,
+			"height":              device.Height,
+			"device_scale_factor": device.DevicePixelRatio,
+			"user_agent":          device.UserAgent,
+			"touch":               device.Touch,
+			"mobile":              device.Mobile,
+		}
+		label = device.Label()
+		recorded = &device
+	}
+
+	return h.newCommand("emulate").
+		correlationPrefix("emulate").
+		reason("emulate").
+		queryType("emulate").
+		buildParams(queryParams).
+		tabID(params.TabID).
+		guards(h.deps.RequirePilot, h.deps.RequireExtension, h.deps.RequireTabTracking).
+		postEnqueue(func() { h.deps.Capture().SetDeviceEmulation(recorded) }).
+		recordAction("emulate", "", map[string]any{"device": label}).
+		queuedMessage("Device emulation queued: " + label).
+		execute(req, args)
+}
+
+// resolveEmulatedDevice turns the device param into concrete metrics. clear is true for
+// "off"; errMsg is non-empty when the param is missing or invalid.
+func resolveEmulatedDevice(raw json.RawMessage) (device capture.DeviceEmulation, clear bool, errMsg string) {
+	trimmed := strings.TrimSpace(string(raw))
+	if trimmed == "" || trimmed == "null" {
+		return device, false, "Required parameter 'device' is missing"
+	}
+
+	var name string
+	if err := json.Unmarshal(raw, &name); err == nil {
+		name = strings.TrimSpace(name)
+		if emulationOffValues[strings.ToLower(name)] {
+			return device, true, ""
+		}
+		for _, preset := range devicePresets {
+			if strings.EqualFold(preset.Device, name) {
+				return preset, false, ""
+			}
+		}
+		return device, false, fmt.Sprintf("Unknown device %q", name)
+	}
+
+	var custom customDevice
+	if err := json.Unmarshal(raw, &custom); err != nil {
+		return device, false, "Invalid 'device': " + err.Error()
+	}
+	if custom.Width < 1 || custom.Width > maxEmulatedDimension || custom.Height < 1 || custom.Height > maxEmulatedDimension {
+		return device, false, fmt.Sprintf("Custom device width and height must be between 1 and %d", maxEmulatedDimension)
+	}
+	if custom.DPR < 0 || custom.DPR > maxEmulatedDPR {
+		return device, false, fmt.Sprintf("Custom device dpr must be between 0 and %d", maxEmulatedDPR)
+	}
+	if custom.DPR == 0 {
+		custom.DPR = 1
+	}
+	// Touch screens are phones and tablets unless the caller says otherwise.
+	mobile := custom.Touch
+	if custom.Mobile != nil {
+		mobile = *custom.Mobile
+	}
+	return capture.DeviceEmulation{
+		Width:            custom.Width,
+		Height:           custom.Height,
+		DevicePixelRatio: custom.DPR,
+		UserAgent:        custom.UA,
+		Touch:            custom.Touch,
+		Mobile:           mobile,
+	}, false, ""
+}
+
+func devicePresetNames() []string {
+	names := make([]string, len(devicePresets))
+	for i, preset := range devicePresets {
+		names[i] = preset.Device
+	}
+	return names
+}
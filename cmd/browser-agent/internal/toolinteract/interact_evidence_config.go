@@ -81,6 +81,7 @@ func isMutationAction(action string) bool {
 		"set_storage", "delete_storage", "clear_storage",
 		"set_cookie", "delete_cookie",
 		"fill_form", "fill_form_and_submit",
+		"replay_request", "emulate",
 		"upload":
 		return true
 	default:
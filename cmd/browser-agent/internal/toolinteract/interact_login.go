@@ -0,0 +1,160 @@
+// Purpose: Implements interact(what="login") to run a stored login recipe and save the resulting state.
+// Why: Agents otherwise re-derive the same login flow every session; a recipe logs in with one call.
+// Docs: docs/features/feature/login-recipes/index.md
+
+package toolinteract
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/loginrecipe"
+)
+
+// redactedValue replaces secrets in recorded actions; reproductions render it as "[user-provided]".
+const redactedValue = "[redacted]"
+
+// loginScrubTimeout bounds the wait for a secret type command to finish before its result is scrubbed.
+const loginScrubTimeout = 15 * time.Second
+
+// HandleLogin runs the steps of a login recipe in the tracked tab, stopping at the first
+// failure, then saves the logged-in state under the recipe's name: sealed in the vault
+// when a state key is configured, as a plain snapshot otherwise.
+func (h *InteractActionHandler) HandleLogin(req JSONRPCRequest, args json.RawMessage) JSONRPCResponse {
+	var params struct {
+		Recipe    string            `json:"recipe"`
+		Vars      map[string]string `json:"vars"`
+		SaveState *bool             `json:"save_state"`
+		TabID     int               `json:"tab_id,omitempty"`
+	}
+	if resp, stop := parseArgs(req, args, &params); stop {
+		return resp
+	}
+	if resp, blocked := requireString(req, params.Recipe, "recipe", "Add the recipe name from configure(what='define_login', operation='list')"); blocked {
+		return resp
+	}
+	if resp, blocked := checkGuards(req, h.deps.RequirePilot, h.deps.RequireExtension, h.deps.RequireTabTracking); blocked {
+		return resp
+	}
+	if resp, blocked := h.deps.RequireSessionStore(req); blocked {
+		return resp
+	}
+
+	recipe, err := h.deps.LoadLoginRecipe(params.Recipe)
+	if errors.Is(err, loginrecipe.ErrNotFound) {
+		return fail(req, ErrNoData, "Login recipe not found: "+params.Recipe,
+			"Define it with configure(what='define_login', name, steps), or list recipes with operation='list'", withParam("recipe"))
+	} else if err != nil {
+		return fail(req, ErrInvalidParam, err.Error(), "Define the recipe again with configure(what='define_login')", withParam("recipe"))
+	}
+	steps, origin, err := recipe.Expand(params.Vars, os.Getenv)
+	if err != nil {
+		return fail(req, ErrInvalidParam, "Cannot run login recipe "+recipe.Name+": "+err.Error(),
+			"Pass the missing vars, or set the text_env variables in the daemon's environment and restart it", withParam("vars"))
+	}
+
+	h.deps.RecordAIAction("login", origin, map[string]any{"recipe": recipe.Name, "steps": len(steps)})
+
+	trace := make([]WorkflowStep, 0, len(steps)+1)
+	workflowStart := time.Now()
+	for i, step := range steps {
+		stepStart := time.Now()
+		stepResp := h.runLoginStep(req, step, params.TabID)
+		trace = append(trace, WorkflowStep{
+			Action:   fmt.Sprintf("%s[%d]", step.What, i),
+			Status:   responseStatus(stepResp),
+			TimingMs: time.Since(stepStart).Milliseconds(),
+			Detail:   loginStepDetail(step),
+		})
+		if isErrorResponse(stepResp) {
+			return workflowResult(req, "login", trace, stepResp, workflowStart)
+		}
+	}
+
+	data := map[string]any{
+		"status": "logged_in",
+		"recipe": recipe.Name,
+		"origin": origin,
+		"trace":  trace,
+	}
+	if params.SaveState == nil || *params.SaveState {
+		saveArgs := map[string]any{"what": "save_state", "snapshot_name": recipe.Name}
+		vaulted := h.deps.StateVault() != nil
+		if vaulted {
+			saveArgs["vault"], saveArgs["ttl"] = true, recipe.TTL
+		}
+		stepStart := time.Now()
+		saveResp := h.deps.ToolInteract(req, buildQueryParams(saveArgs))
+		trace = append(trace, WorkflowStep{
+			Action:   "save_state",
+			Status:   responseStatus(saveResp),
+			TimingMs: time.Since(stepStart).Milliseconds(),
+			Detail:   recipe.Name,
+		})
+		if isErrorResponse(saveResp) {
+			return workflowResult(req, "login", trace, saveResp, workflowStart)
+		}
+		data["trace"] = trace
+		data["state"] = map[string]any{"snapshot_name": recipe.Name, "vault": vaulted}
+		if vaulted {
+			data["note"] = "Logged-in state sealed in the vault. Later runs can skip the login with interact(what='load_state', snapshot_name='" + recipe.Name + "', vault=true)."
+		} else {
+			data["note"] = "Saved as a plain snapshot, which drops cookie and token values. Start the daemon with KABOOM_STATE_KEY to keep the login itself in the encrypted vault."
+		}
+	}
+	data["total_ms"] = time.Since(workflowStart).Milliseconds()
+	return succeed(req, "Logged in with recipe "+recipe.Name, data)
+}
+
+// runLoginStep runs one resolved step through the regular interact handlers. Secret text is
+// recorded as redacted and scrubbed from the stored command result once the command finishes.
+func (h *InteractActionHandler) runLoginStep(req JSONRPCRequest, step loginrecipe.Resolved, tabID int) JSONRPCResponse {
+	args := map[string]any{"action": step.What, "tab_id": tabID}
+	for k, v := range step.Args {
+		args[k] = v
+	}
+	argsJSON := buildQueryParams(args)
+
+	switch {
+	case step.What == "navigate":
+		return h.HandleBrowserActionNavigateImpl(req, argsJSON)
+	case step.Secret:
+		resp := h.handleDOMPrimitive(req, argsJSON, step.What, true)
+		if correlationID := extractCorrelationIDFromToolResponse(resp); correlationID != "" {
+			h.scrubCommandValue(correlationID)
+		}
+		return resp
+	default:
+		return h.HandleDOMPrimitive(req, argsJSON, step.What)
+	}
+}
+
+// scrubCommandValue waits for a command to finish and replaces the value it echoed back.
+func (h *InteractActionHandler) scrubCommandValue(correlationID string) {
+	cmd, found := h.deps.Capture().WaitForCommand(correlationID, loginScrubTimeout)
+	if !found {
+		return
+	}
+	result := map[string]any{}
+	_ = json.Unmarshal(cmd.Result, &result)
+	result["value"] = redactedValue
+	h.deps.Capture().ReplaceCommandResult(correlationID, safeMarshal(result, `{"value":"[redacted]"}`))
+}
+
+// loginStepDetail describes a step for the trace without any typed text.
+func loginStepDetail(step loginrecipe.Resolved) string {
+	switch step.What {
+	case "navigate":
+		return fmt.Sprint(step.Args["url"])
+	case "wait_for":
+		if selector, ok := step.Args["selector"]; ok {
+			return fmt.Sprint(selector)
+		}
+		return "url contains " + fmt.Sprint(step.Args["url_contains"])
+	default:
+		return fmt.Sprint(step.Args["selector"])
+	}
+}
@@ -0,0 +1,161 @@
+// Purpose: Implements interact(action="replay_request") to re-issue a captured request from the tracked page.
+// Why: "Does it fail again?" needs the same cookies as the original call, which only the page context has.
+// Docs: docs/features/feature/request-replay/index.md
+
+package toolinteract
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+)
+
+// replayAuthHint is attached when the replay sends no Authorization header. Capture strips
+// Authorization and other credential headers, so token-authenticated APIs replay without them.
+const replayAuthHint = "Captured headers never include Authorization or other credentials; " +
+	"pass overrides.headers.Authorization for token-authenticated endpoints"
+
+// replayOverrides replaces parts of the captured request before it is re-issued.
+// Header values replace captured headers case-insensitively; an empty value drops the header.
+type replayOverrides struct {
+	Headers map[string]string `json:"headers"`
+	Body    *string           `json:"body"`
+}
+
+// HandleReplayRequest re-issues the network body entry with request_id from the page context
+// and returns the new response next to the original. The extension computes the diff.
+func (h *InteractActionHandler) HandleReplayRequest(req JSONRPCRequest, args json.RawMessage) JSONRPCResponse {
+	var params struct {
+		RequestID string          `json:"request_id"`
+		Overrides replayOverrides `json:"overrides"`
+		TabID     int             `json:"tab_id,omitempty"`
+	}
+	if resp, stop := parseArgs(req, args, &params); stop {
+		return resp
+	}
+	if resp, blocked := requireString(req, params.RequestID, "request_id", "Add request_id from observe(what='network_bodies')"); blocked {
+		return resp
+	}
+
+	entry, ok := findNetworkBody(h.deps.Capture(), params.RequestID)
+	if !ok {
+		return fail(req, ErrNoData, "No captured request with id "+params.RequestID,
+			"Call observe(what='network_bodies') for current request ids; old entries are evicted from the ring buffer",
+			withParam("request_id"))
+	}
+
+	requestBody, responseBody, complete := entry.RequestBody, entry.ResponseBody, !entry.RequestTruncated
+	if full, spilled := h.deps.Capture().GetFullBody(entry.RequestID); spilled {
+		requestBody, responseBody, complete = full.RequestBody, full.ResponseBody, full.Complete
+	}
+	if params.Overrides.Body != nil {
+		requestBody = *params.Overrides.Body
+	} else if !complete || strings.HasPrefix(requestBody, "[REDACTED") {
+		return fail(req, ErrInvalidParam, "The captured request body of "+params.RequestID+" is truncated or redacted",
+			"Pass the full body in overrides.body", withParam("overrides"))
+	}
+
+	headers := make(map[string]string, len(entry.RequestHeaders)+len(params.Overrides.Headers))
+	for name, value := range entry.RequestHeaders {
+		headers[name] = value
+	}
+	for name, value := range params.Overrides.Headers {
+		for existing := range headers {
+			if strings.EqualFold(existing, name) {
+				delete(headers, existing)
+			}
+		}
+		if value != "" {
+			headers[name] = value
+		}
+	}
+
+	// The extension does not always capture request headers; without one, fetch would send JSON as text/plain.
+	if requestBody != "" && json.Valid([]byte(requestBody)) && !hasHeaderFold(headers, "Content-Type") {
+		headers["Content-Type"] = "application/json"
+	}
+
+	overridden := make([]string, 0, len(params.Overrides.Headers)+1)
+	for name := range params.Overrides.Headers {
+		overridden = append(overridden, name)
+	}
+	if params.Overrides.Body != nil {
+		overridden = append(overridden, "body")
+	}
+
+	resp := h.newCommand("replay_request").
+		correlationPrefix("replay_request").
+		reason("replay_request").
+		queryType("replay_request").
+		buildParams(map[string]any{
+			"request_id": entry.RequestID,
+			"url":        entry.URL,
+			"method":     entry.Method,
+			"headers":    headers,
+			"body":       requestBody,
+			"max_body":   h.deps.Capture().GetBodyLimits().CaptureMax,
+			"original": map[string]any{
+				"status":       entry.Status,
+				"duration_ms":  entry.Duration,
+				"content_type": entry.ContentType,
+				"body":         responseBody,
+			},
+		}).
+		tabID(params.TabID).
+		guards(h.deps.RequirePilot, h.deps.RequireExtension, h.deps.RequireTabTracking).
+		recordAction("replay_request", entry.URL, map[string]any{"request_id": entry.RequestID, "overrides": overridden}).
+		queuedMessage("Request replay queued").
+		execute(req, args)
+	if isErrorResponse(resp) || hasHeaderFold(headers, "Authorization") {
+		return resp
+	}
+	return withReplayHint(resp, replayAuthHint)
+}
+
+// withReplayHint adds hint to the JSON payload of a replay response unless it already has one.
+func withReplayHint(resp JSONRPCResponse, hint string) JSONRPCResponse {
+	return mutateToolResult(resp, func(r *MCPToolResult) {
+		if len(r.Content) == 0 || r.Content[0].Type != "text" {
+			return
+		}
+		text := r.Content[0].Text
+		jsonStart := strings.Index(text, "{")
+		if jsonStart < 0 {
+			return
+		}
+		var data map[string]any
+		if err := json.Unmarshal([]byte(text[jsonStart:]), &data); err != nil {
+			return
+		}
+		if _, exists := data["hint"]; exists {
+			return
+		}
+		data["hint"] = hint
+		dataJSON, err := json.Marshal(data)
+		if err != nil {
+			return
+		}
+		r.Content[0].Text = text[:jsonStart] + string(dataJSON)
+	})
+}
+
+// findNetworkBody returns the newest captured network body with the given request ID.
+func findNetworkBody(store *capture.Store, requestID string) (capture.NetworkBody, bool) {
+	bodies := store.GetNetworkBodies()
+	for i := len(bodies) - 1; i >= 0; i-- {
+		if bodies[i].RequestID == requestID {
+			return bodies[i], true
+		}
+	}
+	return capture.NetworkBody{}, false
+}
+
+func hasHeaderFold(headers map[string]string, name string) bool {
+	for k := range headers {
+		if strings.EqualFold(k, name) {
+			return true
+		}
+	}
+	return false
+}
@@ -61,6 +61,8 @@ func (h *StateInteractHandler) HandleStateDelete(req JSONRPCRequest, args json.R
 	var params struct {
 		SnapshotName string `json:"snapshot_name"`
 		Name         string `json:"name"` // backward-compatible alias
+		Vault        bool   `json:"vault,omitempty"`
+		Origin       string `json:"origin,omitempty"`
 	}
 	if resp, stop := parseArgs(req, args, &params); stop {
 		return resp
@@ -74,6 +76,9 @@ func (h *StateInteractHandler) HandleStateDelete(req JSONRPCRequest, args json.R
 	if resp, blocked := h.deps.RequireSessionStore(req); blocked {
 		return resp
 	}
+	if params.Vault {
+		return h.handleVaultDelete(req, snapshotName, params.Origin)
+	}
 
 	if err := h.sessionStoreImpl.Delete(act.StateNamespace, snapshotName); err != nil {
 		return fail(req, ErrNoData, "State not found: "+snapshotName, "Use interact with action='list_states' to see available snapshots", h.deps.DiagnosticHint())
@@ -15,6 +15,8 @@ func (h *StateInteractHandler) HandleStateSave(req JSONRPCRequest, args json.Raw
 	var params struct {
 		SnapshotName string `json:"snapshot_name"`
 		Name         string `json:"name"` // backward-compatible alias
+		Vault        bool   `json:"vault,omitempty"`
+		TTL          string `json:"ttl,omitempty"`
 	}
 	if resp, stop := parseArgs(req, args, &params); stop {
 		return resp
@@ -28,6 +30,9 @@ func (h *StateInteractHandler) HandleStateSave(req JSONRPCRequest, args json.Raw
 	if resp, blocked := h.deps.RequireSessionStore(req); blocked {
 		return resp
 	}
+	if params.Vault {
+		return h.handleVaultSave(req, snapshotName, params.TTL)
+	}
 
 	_, tabID, tabURL := h.deps.Capture().GetTrackingStatus()
 	tabTitle := h.deps.Capture().GetTrackedTabTitle()
@@ -81,6 +86,8 @@ func (h *StateInteractHandler) HandleStateLoad(req JSONRPCRequest, args json.Raw
 		SnapshotName string `json:"snapshot_name"`
 		Name         string `json:"name"` // backward-compatible alias
 		IncludeURL   bool   `json:"include_url,omitempty"`
+		Vault        bool   `json:"vault,omitempty"`
+		Origin       string `json:"origin,omitempty"`
 	}
 	if resp, stop := parseArgs(req, args, &params); stop {
 		return resp
@@ -94,6 +101,9 @@ func (h *StateInteractHandler) HandleStateLoad(req JSONRPCRequest, args json.Raw
 	if resp, blocked := h.deps.RequireSessionStore(req); blocked {
 		return resp
 	}
+	if params.Vault {
+		return h.handleVaultLoad(req, snapshotName, params.Origin, params.IncludeURL)
+	}
 
 	data, err := h.sessionStoreImpl.Load(act.StateNamespace, snapshotName)
 	if err != nil {
@@ -0,0 +1,195 @@
+// Purpose: Implements save_state/load_state/delete_state with vault=true: full login state sealed in the encrypted vault.
+// Why: Plain snapshots drop cookies and tokens on purpose; reusing a login across runs needs them, encrypted at rest.
+// Docs: docs/features/feature/auth-state-vault/index.md
+
+package toolinteract
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/queries"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/statevault"
+)
+
+// authStateTimeout bounds the extension's cookie and storage read or write.
+const authStateTimeout = 10 * time.Second
+
+// authStateResult is the auth_state command result: the captured state, restore counts, or an error.
+type authStateResult struct {
+	URL    string `json:"url"`
+	Origin string `json:"origin"`
+	statevault.State
+	Restored map[string]any `json:"restored,omitempty"`
+	Error    string         `json:"error"`
+	Message  string         `json:"message"`
+}
+
+// requireVault returns the vault, or a response explaining how to configure it.
+func (h *StateInteractHandler) requireVault(req JSONRPCRequest) (*statevault.Vault, JSONRPCResponse, bool) {
+	if vault := h.deps.StateVault(); vault != nil {
+		return vault, JSONRPCResponse{}, false
+	}
+	return nil, fail(req, ErrNotInitialized, "The encrypted state vault is not configured",
+		"Ask the user to restart the daemon with KABOOM_STATE_KEY set (e.g. from `openssl rand -base64 32`), or save without vault=true",
+		withParam("vault")), true
+}
+
+// handleVaultSave captures the tracked tab's cookies (HttpOnly included) and web storage and seals them as name.
+func (h *StateInteractHandler) handleVaultSave(req JSONRPCRequest, name, ttlArg string) JSONRPCResponse {
+	vault, resp, blocked := h.requireVault(req)
+	if blocked {
+		return resp
+	}
+	if err := statevault.ValidateName(name); err != nil {
+		return fail(req, ErrInvalidParam, err.Error(), "Use a short name such as 'admin' or 'checkout-user'", withParam("snapshot_name"))
+	}
+	ttl, err := statevault.ParseTTL(ttlArg)
+	if err != nil {
+		return fail(req, ErrInvalidParam, err.Error(), "Pass ttl like '8h', or '168h' for a week", withParam("ttl"))
+	}
+	if resp, blocked := h.requireAuthStateGuards(req); blocked {
+		return resp
+	}
+
+	captured, resp, ok := h.runAuthState(req, map[string]any{"action": "capture"}, true)
+	if !ok {
+		return resp
+	}
+	entry, err := vault.Save(name, captured.Origin, captured.URL, captured.State, ttl)
+	if err != nil {
+		return fail(req, ErrInvalidParam, "Failed to save state: "+err.Error(), "Save from an http(s) page")
+	}
+
+	h.deps.RecordAIAction("save_state", captured.URL, map[string]any{"snapshot_name": name, "vault": true})
+	return succeed(req, "State saved to vault", map[string]any{
+		"status":        "saved",
+		"snapshot_name": name,
+		"vault":         true,
+		"state":         entry,
+		"note":          "Cookies and storage are encrypted on disk; values are never returned. Restore with load_state vault=true on the same origin.",
+	})
+}
+
+// handleVaultLoad opens name for origin (default: the tracked tab's origin) and restores it in the tracked tab.
+func (h *StateInteractHandler) handleVaultLoad(req JSONRPCRequest, name, origin string, includeURL bool) JSONRPCResponse {
+	vault, resp, blocked := h.requireVault(req)
+	if blocked {
+		return resp
+	}
+	if resp, blocked := h.requireAuthStateGuards(req); blocked {
+		return resp
+	}
+	if origin == "" {
+		_, _, origin = h.deps.Capture().GetTrackingStatus()
+	}
+
+	entry, state, err := vault.Open(name, origin)
+	switch {
+	case errors.Is(err, statevault.ErrNotFound):
+		return fail(req, ErrNoData, "No vault state "+name+" for "+origin,
+			"Use configure with what='list_states' to see saved states and their origins", withParam("snapshot_name"))
+	case errors.Is(err, statevault.ErrExpired):
+		return fail(req, ErrNoData, fmt.Sprintf("Vault state %s for %s expired at %s", name, entry.Origin, entry.ExpiresAt.Format(time.RFC3339)),
+			"Log in again and save a fresh state with save_state vault=true", withParam("snapshot_name"))
+	case errors.Is(err, statevault.ErrWrongKey):
+		return fail(req, ErrInvalidParam, "Vault state "+name+" cannot be decrypted with the configured key",
+			"The state was saved with a different KABOOM_STATE_KEY, or the file was modified; save it again")
+	case err != nil:
+		return fail(req, ErrInvalidParam, err.Error(), "Pass origin as an http(s) URL, or track a page on the state's origin", withParam("origin"))
+	}
+
+	restored, resp, ok := h.runAuthState(req, map[string]any{
+		"action":          "restore",
+		"origin":          entry.Origin,
+		"url":             entry.URL,
+		"include_url":     includeURL,
+		"cookies":         state.Cookies,
+		"local_storage":   state.LocalStorage,
+		"session_storage": state.SessionStorage,
+	}, false)
+	if !ok {
+		return resp
+	}
+
+	h.deps.RecordAIAction("load_state", "", map[string]any{"snapshot_name": name, "vault": true})
+	return succeed(req, "State restored from vault", map[string]any{
+		"status":        "loaded",
+		"snapshot_name": name,
+		"vault":         true,
+		"state":         entry,
+		"restored":      restored.Restored,
+	})
+}
+
+// handleVaultDelete removes name for origin (default: the tracked tab's origin).
+func (h *StateInteractHandler) handleVaultDelete(req JSONRPCRequest, name, origin string) JSONRPCResponse {
+	vault, resp, blocked := h.requireVault(req)
+	if blocked {
+		return resp
+	}
+	if origin == "" {
+		_, _, origin = h.deps.Capture().GetTrackingStatus()
+	}
+	if err := vault.Delete(name, origin); err != nil {
+		if errors.Is(err, statevault.ErrNotFound) {
+			return fail(req, ErrNoData, "No vault state "+name+" for "+origin,
+				"Use configure with what='list_states' to see saved states and their origins", withParam("snapshot_name"))
+		}
+		return fail(req, ErrInvalidParam, err.Error(), "Pass origin as an http(s) URL", withParam("origin"))
+	}
+
+	h.deps.RecordAIAction("delete_state", "", map[string]any{"snapshot_name": name, "vault": true})
+	return succeed(req, "State deleted from vault", map[string]any{
+		"status":        "deleted",
+		"snapshot_name": name,
+		"vault":         true,
+	})
+}
+
+func (h *StateInteractHandler) requireAuthStateGuards(req JSONRPCRequest) (JSONRPCResponse, bool) {
+	for _, guard := range []GuardCheck{h.deps.RequirePilot, h.deps.RequireExtension, h.deps.RequireTabTracking} {
+		if resp, blocked := guard(req); blocked {
+			return resp, true
+		}
+	}
+	return JSONRPCResponse{}, false
+}
+
+// runAuthState sends an auth_state command and waits for its result. When scrub is set the
+// stored command result is replaced once read, so captured secrets cannot be fetched later
+// through observe(command_result).
+func (h *StateInteractHandler) runAuthState(req JSONRPCRequest, params map[string]any, scrub bool) (authStateResult, JSONRPCResponse, bool) {
+	correlationID := newCorrelationID("auth_state")
+	query := queries.PendingQuery{
+		Type:          "auth_state",
+		Params:        buildQueryParams(params),
+		CorrelationID: correlationID,
+	}
+	if resp, blocked := h.deps.EnqueuePendingQuery(req, query, queries.AsyncCommandTimeout); blocked {
+		return authStateResult{}, resp, false
+	}
+
+	cmd, found := h.deps.Capture().WaitForCommand(correlationID, authStateTimeout)
+	if scrub {
+		defer h.deps.Capture().ReplaceCommandResult(correlationID, json.RawMessage(`{"sealed":true}`))
+	}
+	if !found || cmd.Status == "pending" {
+		return authStateResult{}, fail(req, ErrExtTimeout, "The extension did not answer the auth_state command in time",
+			"Check the extension is connected and retry", withRetryable(true)), false
+	}
+
+	var result authStateResult
+	_ = json.Unmarshal(cmd.Result, &result)
+	if cmd.Error != "" || result.Error != "" {
+		message := result.Message
+		if message == "" {
+			message = cmd.Error + result.Error
+		}
+		return authStateResult{}, fail(req, ErrExtError, "Auth state "+fmt.Sprint(params["action"])+" failed: "+message,
+			"Make sure the tracked tab is an http(s) page on the state's origin"), false
+	}
+	return result, JSONRPCResponse{}, true
+}
@@ -7,6 +7,7 @@ package toolinteract
 import (
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 )
@@ -66,18 +67,28 @@ func (h *InteractActionHandler) HandleFillFormAndSubmit(req JSONRPCRequest, args
 }
 
 // handleFillForm fills multiple form fields without submitting.
+// With values, every field is filled by one extension command; with fields,
+// each entry runs as its own type/select action.
 // Gates (requirePilot, requireExtension, requireTabTracking) are applied by the delegated handlers.
 func (h *InteractActionHandler) HandleFillForm(req JSONRPCRequest, args json.RawMessage) JSONRPCResponse {
 	var params struct {
-		Fields    []FormField `json:"fields"`
-		TabID     int         `json:"tab_id,omitempty"`
-		TimeoutMs int         `json:"timeout_ms,omitempty"`
+		Fields    []FormField    `json:"fields"`
+		Values    map[string]any `json:"values"`
+		Selector  string         `json:"selector,omitempty"`
+		TabID     int            `json:"tab_id,omitempty"`
+		TimeoutMs int            `json:"timeout_ms,omitempty"`
 	}
 	if resp, stop := parseArgs(req, args, &params); stop {
 		return resp
 	}
+	if len(params.Values) > 0 {
+		if len(params.Fields) > 0 {
+			return fail(req, ErrInvalidParam, "Use either 'values' or 'fields', not both", "Move every entry into 'values' keyed by field name", withParam("values"))
+		}
+		return h.fillFormValues(req, args, params.Selector, params.Values, params.TabID)
+	}
 	if len(params.Fields) == 0 {
-		return fail(req, ErrMissingParam, "Required parameter 'fields' is empty", "Provide at least one {selector, value} field entry", withParam("fields"))
+		return fail(req, ErrMissingParam, "Required parameter 'values' or 'fields' is empty", "Provide values={name: value} or at least one {selector, value} field entry", withParam("values"))
 	}
 	if params.TimeoutMs <= 0 {
 		params.TimeoutMs = 15_000
@@ -98,6 +109,28 @@ func (h *InteractActionHandler) HandleFillForm(req JSONRPCRequest, args json.Raw
 	return workflowResult(req, "fill_form", trace, lastResp, workflowStart)
 }
 
+// fillFormValues fills every keyed value in a single form_fill round-trip.
+// Keys resolve in the page by name, id, label, aria-label, then placeholder.
+// Only the keys are recorded; values may hold credentials.
+func (h *InteractActionHandler) fillFormValues(req JSONRPCRequest, args json.RawMessage, selector string, values map[string]any, tabID int) JSONRPCResponse {
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return h.newCommand("fill_form").
+		correlationPrefix("fill_form").
+		reason("fill_form").
+		queryType("form_fill").
+		buildParams(map[string]any{"selector": selector, "values": values}).
+		tabID(tabID).
+		guards(h.deps.RequirePilot, h.deps.RequireExtension, h.deps.RequireTabTracking).
+		recordAction("fill_form", "", map[string]any{"selector": selector, "fields": keys}).
+		queuedMessage("Form fill queued").
+		execute(req, args)
+}
+
 // fillWorkflowFields executes all field entry steps for fill_form* workflows.
 func (h *InteractActionHandler) fillWorkflowFields(req JSONRPCRequest, workflowName string, fields []FormField, tabID int, trace []WorkflowStep, workflowStart time.Time) ([]WorkflowStep, *JSONRPCResponse) {
 	for i, field := range fields {
@@ -0,0 +1,143 @@
+// compact.go — Tabular encoding for observe(compact=true) responses.
+// Why: Listings repeat the same keys on every entry; a header row plus value rows carries the same data in far fewer tokens.
+
+package toolobserve
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/mcp"
+)
+
+// CompactEncodingVersion identifies the table layout described in the "compact" response key.
+const CompactEncodingVersion = 1
+
+// compactMinRows is the smallest object array worth turning into a table.
+const compactMinRows = 2
+
+// CompactResponse rewrites the JSON payload of a successful observe response so every
+// array of objects becomes a table: element 0 is the header row of column names and
+// each following element is one entry's values in header order. Columns that are null
+// or missing in every entry are dropped. A "compact" key lists the rewritten paths.
+// Error responses and payloads without tables are returned unchanged.
+func CompactResponse(resp mcp.JSONRPCResponse) mcp.JSONRPCResponse {
+	return mcp.MutateToolResult(resp, func(r *mcp.MCPToolResult) {
+		if r.IsError || len(r.Content) == 0 {
+			return
+		}
+		text := r.Content[0].Text
+		payload, ok := mcp.ExtractJSONPayload(text)
+		if !ok || payload[0] != '{' {
+			return
+		}
+		dec := json.NewDecoder(bytes.NewReader(payload))
+		dec.UseNumber()
+		var data map[string]any
+		if dec.Decode(&data) != nil {
+			return
+		}
+		var tables []string
+		for _, key := range sortedKeys(data) {
+			data[key] = compactValue(data[key], key, &tables)
+		}
+		if len(tables) == 0 {
+			return
+		}
+		data["compact"] = map[string]any{
+			"version": CompactEncodingVersion,
+			"tables":  tables,
+			"format":  "Each listed path is an array whose first element is the column names; every later element is one entry's values in that order. Columns null in every entry are omitted.",
+		}
+		var buf bytes.Buffer
+		enc := json.NewEncoder(&buf)
+		enc.SetEscapeHTML(false)
+		if enc.Encode(data) != nil {
+			return
+		}
+		prefix := text[:strings.LastIndex(text, string(payload))]
+		r.Content[0].Text = prefix + strings.TrimSuffix(buf.String(), "\n")
+	})
+}
+
+// compactValue converts object arrays at any depth into tables, recording each path.
+// Paths use "." for object keys and "[]" for cells inside a table or plain array.
+func compactValue(v any, path string, tables *[]string) any {
+	switch val := v.(type) {
+	case map[string]any:
+		for _, key := range sortedKeys(val) {
+			val[key] = compactValue(val[key], path+"."+key, tables)
+		}
+		return val
+	case []any:
+		rows, ok := objectRows(val)
+		if !ok {
+			for i := range val {
+				val[i] = compactValue(val[i], path+"[]", tables)
+			}
+			return val
+		}
+		columns := tableColumns(rows)
+		table := make([]any, 0, len(rows)+1)
+		header := make([]any, len(columns))
+		for i, c := range columns {
+			header[i] = c
+		}
+		*tables = append(*tables, path)
+		table = append(table, header)
+		for _, row := range rows {
+			cells := make([]any, len(columns))
+			for i, c := range columns {
+				cells[i] = compactValue(row[c], path+"[]."+c, tables)
+			}
+			table = append(table, cells)
+		}
+		return table
+	}
+	return v
+}
+
+// objectRows returns the elements of an array made only of objects, when there are enough to tabulate.
+func objectRows(arr []any) ([]map[string]any, bool) {
+	if len(arr) < compactMinRows {
+		return nil, false
+	}
+	rows := make([]map[string]any, len(arr))
+	for i, el := range arr {
+		obj, ok := el.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		rows[i] = obj
+	}
+	return rows, true
+}
+
+// tableColumns returns the sorted union of keys that are non-null in at least one row.
+func tableColumns(rows []map[string]any) []string {
+	seen := make(map[string]bool)
+	for _, row := range rows {
+		for k, v := range row {
+			if v != nil {
+				seen[k] = true
+			}
+		}
+	}
+	columns := make([]string, 0, len(seen))
+	for k := range seen {
+		columns = append(columns, k)
+	}
+	sort.Strings(columns)
+	return columns
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,107 @@
+// compact_test.go — Tests for the observe(compact=true) tabular encoding.
+
+package toolobserve
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/mcp"
+)
+
+func compactPayload(t *testing.T, resp mcp.JSONRPCResponse) (string, map[string]any) {
+	t.Helper()
+	var result mcp.MCPToolResult
+	if err := json.Unmarshal(resp.Result, &result); err != nil {
+		t.Fatal(err)
+	}
+	text := result.Content[0].Text
+	payload, ok := mcp.ExtractJSONPayload(text)
+	if !ok {
+		t.Fatalf("no JSON payload in %q", text)
+	}
+	var data map[string]any
+	if err := json.Unmarshal(payload, &data); err != nil {
+		t.Fatal(err)
+	}
+	return text, data
+}
+
+func TestCompactResponse_EncodesObjectArraysAsTables(t *testing.T) {
+	t.Parallel()
+	req := mcp.JSONRPCRequest{JSONRPC: "2.0", ID: 1}
+	resp := mcp.Succeed(req, "Network waterfall", map[string]any{
+		"entries": []any{
+			map[string]any{"url": "https://a.test/x?a=1&b=2", "status": 200, "initiator": nil, "timings": []any{
+				map[string]any{"phase": "dns", "ms": 1}, map[string]any{"phase": "tcp", "ms": 2},
+			}},
+			map[string]any{"url": "https://a.test/y", "status": 404, "initiator": nil},
+		},
+		"single":   []any{map[string]any{"k": "v"}},
+		"strings":  []any{"a", "b"},
+		"count":    2,
+		"metadata": map[string]any{"nested": []any{map[string]any{"a": 1}, map[string]any{"b": 2}}},
+	})
+
+	text, data := compactPayload(t, CompactResponse(resp))
+	if !strings.HasPrefix(text, "Network waterfall\n") {
+		t.Fatalf("summary line should be kept: %q", text)
+	}
+	if strings.Contains(text, `\u0026`) {
+		t.Fatalf("compact output should not HTML-escape: %s", text)
+	}
+
+	entries := data["entries"].([]any)
+	if got := entries[0]; !reflect.DeepEqual(got, []any{"status", "timings", "url"}) {
+		t.Fatalf("header = %v, want all-null initiator dropped", got)
+	}
+	if got := entries[2]; !reflect.DeepEqual(got, []any{float64(404), nil, "https://a.test/y"}) {
+		t.Fatalf("row = %v", got)
+	}
+	timings := entries[1].([]any)[1].([]any)
+	if !reflect.DeepEqual(timings[0], []any{"ms", "phase"}) || !reflect.DeepEqual(timings[2], []any{float64(2), "tcp"}) {
+		t.Fatalf("nested table = %v", timings)
+	}
+	if _, isObj := data["single"].([]any)[0].(map[string]any); !isObj {
+		t.Fatal("single-entry arrays stay as objects")
+	}
+
+	meta := data["compact"].(map[string]any)
+	tables := fmt.Sprint(meta["tables"])
+	if meta["version"] != float64(CompactEncodingVersion) || tables != "[entries entries[].timings metadata.nested]" {
+		t.Fatalf("compact descriptor = %v", meta)
+	}
+}
+
+func TestCompactResponse_SavesTokensOnListings(t *testing.T) {
+	t.Parallel()
+	entries := make([]any, 50)
+	for i := range entries {
+		entries[i] = map[string]any{
+			"url": fmt.Sprintf("https://api.test/items/%d", i), "method": "GET", "status": 200,
+			"content_type": "application/json", "duration_ms": i, "transfer_size": 1200 + i, "initiator_type": "fetch",
+		}
+	}
+	resp := mcp.Succeed(mcp.JSONRPCRequest{JSONRPC: "2.0", ID: 1}, "Network", map[string]any{"entries": entries})
+	full, _ := compactPayload(t, resp)
+	compact, _ := compactPayload(t, CompactResponse(resp))
+	if ratio := float64(len(compact)) / float64(len(full)); ratio > 0.6 {
+		t.Fatalf("compact is %.0f%% of full size, want at most 60%%", ratio*100)
+	}
+}
+
+func TestCompactResponse_LeavesErrorsAndScalarsUnchanged(t *testing.T) {
+	t.Parallel()
+	req := mcp.JSONRPCRequest{JSONRPC: "2.0", ID: 1}
+	errResp := mcp.Fail(req, mcp.ErrInvalidParam, "bad", "fix it")
+	if got := CompactResponse(errResp); string(got.Result) != string(errResp.Result) {
+		t.Fatal("error responses must not be rewritten")
+	}
+	plain := mcp.Succeed(req, "Status", map[string]any{"count": 1, "ok": true})
+	if got := CompactResponse(plain); string(got.Result) != string(plain.Result) {
+		t.Fatal("payloads without tables must not be rewritten")
+	}
+}
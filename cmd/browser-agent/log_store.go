@@ -30,7 +30,13 @@ type LogStore struct {
 	errorTotalAdded int64            // monotonic counter of error-level entries ever added
 	telemetryMode   string           // telemetry summary verbosity: off|auto|full
 	onEntries       func([]LogEntry) // optional callback when entries are added (e.g., for clustering)
-	TTL             time.Duration    // TTL for read-time filtering (0 means unlimited)
+	testIDSource    func() []string  // optional: active test boundary IDs stamped on new entries as test_ids
+	ttl             time.Duration    // entries older than this are evicted (0 means unlimited)
+
+	// Ingest dedup (see log_store_dedup.go)
+	dedupEnabled     bool  // collapse consecutive identical entries (off by default)
+	dedupCollapsed   int64 // entries folded into a previous one
+	dedupTailPending bool  // newest entry was collapsed after its last file write
 
 	// Async logging
 	logChan       chan []LogEntry // buffered channel for async log writes
@@ -58,6 +64,14 @@ func NewLogStore(logFile string, maxEntries int, addWarning func(string)) *LogSt
 	}
 }
 
+// SetTestIDSource sets the function that reports the active test boundary IDs, which
+// are stamped on each ingested entry so observe(boundary=...) can scope logs to a test.
+func (ls *LogStore) SetTestIDSource(fn func() []string) {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+	ls.testIDSource = fn
+}
+
 // SetOnEntries sets the callback invoked when new log entries are added.
 // Thread-safe: acquires the write lock to avoid racing with addEntries.
 func (ls *LogStore) SetOnEntries(cb func([]LogEntry)) {
@@ -0,0 +1,130 @@
+// Purpose: Collapses consecutive identical log entries on ingest into one entry with count, first_ts, and last_ts.
+// Why: Hot loops flood the ring buffer with identical lines and evict the context around them.
+// Docs: docs/features/feature/log-dedup/index.md
+
+package main
+
+import (
+	"reflect"
+	"time"
+)
+
+// dedupIgnoredFields differ between occurrences of the same line and are not compared.
+var dedupIgnoredFields = map[string]bool{
+	"ts": true, "timestamp": true, "count": true, "first_ts": true, "last_ts": true,
+}
+
+// sameLogEntry reports whether a and b are the same line, ignoring timestamps and dedup counters.
+func sameLogEntry(a, b LogEntry) bool {
+	compared := 0
+	for k, av := range a {
+		if dedupIgnoredFields[k] {
+			continue
+		}
+		bv, ok := b[k]
+		if !ok || !reflect.DeepEqual(av, bv) {
+			return false
+		}
+		compared++
+	}
+	for k := range b {
+		if !dedupIgnoredFields[k] {
+			compared--
+		}
+	}
+	return compared == 0
+}
+
+// logEntryCount returns an entry's occurrence count: 1 unless it carries a dedup count.
+func logEntryCount(entry LogEntry) int {
+	switch n := entry["count"].(type) {
+	case int:
+		return n
+	case float64:
+		return int(n)
+	}
+	return 1
+}
+
+// collapseLogEntry returns a copy of tail with dup folded in. The stored map is replaced,
+// never mutated, because readers may hold it outside the lock.
+func collapseLogEntry(tail, dup LogEntry, now time.Time) LogEntry {
+	merged := make(LogEntry, len(tail)+3)
+	for k, v := range tail {
+		merged[k] = v
+	}
+	merged["count"] = logEntryCount(tail) + logEntryCount(dup)
+	if _, ok := merged["first_ts"]; !ok {
+		merged["first_ts"] = logEntryTimestamp(tail)
+	}
+	lastTS := logEntryTimestamp(dup)
+	if lastTS == "" {
+		lastTS = now.UTC().Format(time.RFC3339Nano)
+	}
+	merged["last_ts"] = lastTS
+	return merged
+}
+
+// logEntryTimestamp reads ts (extension entries) or timestamp.
+func logEntryTimestamp(entry LogEntry) string {
+	if ts, ok := entry["ts"].(string); ok && ts != "" {
+		return ts
+	}
+	ts, _ := entry["timestamp"].(string)
+	return ts
+}
+
+// tryCollapseLocked folds entry into the newest stored entry when dedup is on and they match.
+// Caller holds ls.mu.
+func (ls *LogStore) tryCollapseLocked(entry LogEntry, now time.Time) bool {
+	if !ls.dedupEnabled || len(ls.entries) == 0 {
+		return false
+	}
+	last := len(ls.entries) - 1
+	if !sameLogEntry(ls.entries[last], entry) {
+		return false
+	}
+	ls.entries[last] = collapseLogEntry(ls.entries[last], entry, now)
+	ls.logAddedAt[last] = now
+	ls.dedupCollapsed++
+	ls.dedupTailPending = true
+	return true
+}
+
+// takeDedupTailLocked returns the collapsed newest entry when its final count has not been
+// written to the log file yet. Caller holds ls.mu.
+func (ls *LogStore) takeDedupTailLocked() LogEntry {
+	if !ls.dedupTailPending || len(ls.entries) == 0 {
+		return nil
+	}
+	ls.dedupTailPending = false
+	return ls.entries[len(ls.entries)-1]
+}
+
+// takeDedupTail is takeDedupTailLocked under ls.mu; the file write happens after release.
+func (ls *LogStore) takeDedupTail() LogEntry {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+	return ls.takeDedupTailLocked()
+}
+
+// flushDedupTail queues the pending collapsed entry for the log file (used at shutdown).
+func (ls *LogStore) flushDedupTail() {
+	if tail := ls.takeDedupTail(); tail != nil {
+		_ = ls.appendToFile([]LogEntry{tail})
+	}
+}
+
+// setDedupEnabled turns ingest dedup on or off. Entries already collapsed keep their counts.
+func (ls *LogStore) setDedupEnabled(enabled bool) {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+	ls.dedupEnabled = enabled
+}
+
+// getDedupStatus returns whether dedup is on and how many entries it has collapsed.
+func (ls *LogStore) getDedupStatus() (enabled bool, collapsed int64) {
+	ls.mu.RLock()
+	defer ls.mu.RUnlock()
+	return ls.dedupEnabled, ls.dedupCollapsed
+}
@@ -0,0 +1,92 @@
+// Purpose: Tests ingest dedup of consecutive identical log entries, its JSONL persistence, and configure(what="dedup").
+// Docs: docs/features/feature/log-dedup/index.md
+
+package main
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestLogStoreDedup_CollapsesRunsAndPersistsCounts(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "dedup.jsonl")
+	srv, err := NewServer(logFile, 100)
+	if err != nil {
+		t.Fatalf("NewServer() error = %v", err)
+	}
+	srv.logs.setDedupEnabled(true)
+
+	tick := func(ts string) LogEntry {
+		return LogEntry{"level": "warn", "message": "poll tick", "args": []any{"poll tick", map[string]any{"n": 1.0}}, "ts": ts}
+	}
+	srv.logs.addEntries([]LogEntry{tick("2026-05-01T09:00:01Z"), tick("2026-05-01T09:00:02Z")})
+	srv.logs.addEntries([]LogEntry{
+		tick("2026-05-01T09:00:03Z"),
+		{"level": "info", "message": "done", "ts": "2026-05-01T09:00:04Z"},
+		tick("2026-05-01T09:00:05Z"),
+		tick("2026-05-01T09:00:06Z"),
+	})
+
+	entries := srv.logs.getEntries()
+	if len(entries) != 3 || srv.logs.logTotalAdded != 3 {
+		t.Fatalf("entries = %+v (total added %d), want 3 stored entries", entries, srv.logs.logTotalAdded)
+	}
+	first := entries[0]
+	if first["count"] != 3 || first["first_ts"] != "2026-05-01T09:00:01Z" || first["last_ts"] != "2026-05-01T09:00:03Z" {
+		t.Fatalf("first run = %+v, want count 3 from 09:00:01 to 09:00:03", first)
+	}
+	if _, ok := entries[1]["count"]; ok {
+		t.Fatalf("single entry should not carry a count: %+v", entries[1])
+	}
+	if entries[2]["count"] != 2 {
+		t.Fatalf("second run = %+v, want count 2", entries[2])
+	}
+	if enabled, collapsed := srv.logs.getDedupStatus(); !enabled || collapsed != 3 {
+		t.Fatalf("dedup status = %v/%d, want enabled with 3 collapsed", enabled, collapsed)
+	}
+
+	// Shutdown writes the open run's final count; a reload restores the collapsed entries.
+	srv.logs.shutdownAsyncLogger(2 * time.Second)
+	reloaded, err := NewServer(logFile, 100)
+	if err != nil {
+		t.Fatalf("NewServer(reload) error = %v", err)
+	}
+	defer reloaded.logs.shutdownAsyncLogger(time.Second)
+	loaded := reloaded.logs.getEntries()
+	if len(loaded) != 3 || loaded[0]["count"] != 3.0 || loaded[2]["count"] != 2.0 || loaded[2]["last_ts"] != "2026-05-01T09:00:06Z" {
+		t.Fatalf("reloaded entries = %+v, want the three collapsed entries", loaded)
+	}
+}
+
+func TestConfigureDedup_TogglesAndShowsCountsInObserveLogs(t *testing.T) {
+	t.Parallel()
+	h, server, _ := makeToolHandler(t)
+
+	if data := extractResultJSON(t, parseToolResult(t, callConfigureRaw(h, `{"what":"dedup"}`))); data["enabled"] != false {
+		t.Fatalf("dedup should be off by default, got %v", data)
+	}
+	data := extractResultJSON(t, parseToolResult(t, callConfigureRaw(h, `{"what":"dedup","enabled":true}`)))
+	if data["enabled"] != true {
+		t.Fatalf("enabled = %v, want true", data["enabled"])
+	}
+
+	server.logs.addEntries([]LogEntry{
+		{"level": "error", "message": "render loop", "ts": "2026-05-01T09:00:01Z"},
+		{"level": "error", "message": "render loop", "ts": "2026-05-01T09:00:02Z"},
+	})
+	logs := extractResultJSON(t, parseToolResult(t, callObserveRaw(h, "logs")))["logs"].([]any)
+	if len(logs) != 1 {
+		t.Fatalf("logs = %v, want one collapsed entry", logs)
+	}
+	entry := logs[0].(map[string]any)
+	if entry["count"] != float64(2) || entry["first_ts"] != "2026-05-01T09:00:01Z" || entry["last_ts"] != "2026-05-01T09:00:02Z" {
+		t.Fatalf("entry = %v, want count 2 with first_ts/last_ts", entry)
+	}
+
+	callConfigureRaw(h, `{"what":"dedup","enabled":false}`)
+	server.logs.addEntries([]LogEntry{{"level": "error", "message": "render loop", "ts": "2026-05-01T09:00:03Z"}})
+	if logs := extractResultJSON(t, parseToolResult(t, callObserveRaw(h, "logs")))["logs"].([]any); len(logs) != 2 {
+		t.Fatalf("logs = %v, want a separate entry once dedup is off", logs)
+	}
+}
@@ -0,0 +1,102 @@
+// Purpose: Applies the console buffer's runtime capacity and TTL, evicting the oldest entries.
+// Why: The console ring was sized by --max-entries alone and kept entries forever; retention is now tunable live.
+// Docs: docs/features/feature/ttl-retention/index.md
+
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+// retention returns the console buffer's capacity and TTL (0 = no age limit).
+func (ls *LogStore) retention() (int, time.Duration) {
+	ls.mu.RLock()
+	defer ls.mu.RUnlock()
+	return ls.maxEntries, ls.ttl
+}
+
+// consoleCapacity returns the console buffer's current capacity.
+func consoleCapacity(ls *LogStore) int {
+	maxEntries, _ := ls.retention()
+	return maxEntries
+}
+
+// setRetention changes the console buffer's capacity and TTL. Entries that no longer fit are
+// evicted immediately and the log file is rewritten, as on rotation.
+func (ls *LogStore) setRetention(maxEntries int, ttl time.Duration) {
+	ls.saveTrimmed(ls.setRetentionInMemory(maxEntries, ttl))
+}
+
+// setRetentionInMemory applies the limits under lock and returns a snapshot for I/O outside the lock.
+func (ls *LogStore) setRetentionInMemory(maxEntries int, ttl time.Duration) []LogEntry {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+	ls.maxEntries = maxEntries
+	ls.ttl = ttl
+	return ls.trimAndSnapshotLocked(time.Now())
+}
+
+// expireEntries drops entries older than the TTL and returns how many were dropped.
+// Ingest trims on every batch; the periodic sweep covers a console that has gone quiet.
+func (ls *LogStore) expireEntries(now time.Time) int {
+	dropped, snapshot := ls.expireEntriesInMemory(now)
+	ls.saveTrimmed(snapshot)
+	return dropped
+}
+
+// expireEntriesInMemory trims under lock and returns the drop count and a snapshot for I/O outside the lock.
+func (ls *LogStore) expireEntriesInMemory(now time.Time) (int, []LogEntry) {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+	before := len(ls.entries)
+	snapshot := ls.trimAndSnapshotLocked(now)
+	return before - len(ls.entries), snapshot
+}
+
+// trimLocked evicts entries past the TTL, then the oldest entries past maxEntries, and returns
+// how many were dropped (assumes mu is held). Entries restored from the log file carry no ingest
+// time, so the first TTL pass after a restart drops them.
+func (ls *LogStore) trimLocked(now time.Time) int {
+	drop := 0
+	if ls.ttl > 0 {
+		cutoff := now.Add(-ls.ttl)
+		for drop < len(ls.logAddedAt) && !ls.logAddedAt[drop].After(cutoff) {
+			drop++
+		}
+	}
+	drop = max(drop, len(ls.entries)-ls.maxEntries)
+	if drop <= 0 {
+		return 0
+	}
+	// Copy to new slices to allow GC of evicted entries.
+	kept := make([]LogEntry, len(ls.entries)-drop)
+	copy(kept, ls.entries[drop:])
+	ls.entries = kept
+	keptAt := make([]time.Time, len(ls.logAddedAt)-drop)
+	copy(keptAt, ls.logAddedAt[drop:])
+	ls.logAddedAt = keptAt
+	return drop
+}
+
+// trimAndSnapshotLocked trims and, when anything was dropped, returns the surviving entries
+// for a file rewrite outside the lock (assumes mu is held).
+func (ls *LogStore) trimAndSnapshotLocked(now time.Time) []LogEntry {
+	if ls.trimLocked(now) == 0 {
+		return nil
+	}
+	snapshot := make([]LogEntry, len(ls.entries))
+	copy(snapshot, ls.entries)
+	ls.dedupTailPending = false
+	return snapshot
+}
+
+// saveTrimmed rewrites the log file after a trim; nil means nothing was dropped.
+func (ls *LogStore) saveTrimmed(snapshot []LogEntry) {
+	if snapshot == nil {
+		return
+	}
+	if err := ls.saveEntriesCopy(snapshot); err != nil {
+		ls.addWarning(fmt.Sprintf("log_save_failed: %v", err))
+	}
+}
@@ -0,0 +1,80 @@
+// Purpose: Runs the loopback LSP diagnostics feed (port+2) and refreshes it from captured browser errors.
+// Why: Lets editors underline the workspace line that is throwing in the running browser.
+// Docs: docs/features/feature/code-navigation-modification/index.md
+
+package main
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/lspdiag"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/session"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/util"
+)
+
+// lspRefreshInterval is how often the diagnostics snapshot is rebuilt from the log buffer.
+const lspRefreshInterval = time.Second
+
+// startLSPDiagnostics binds the LSP diagnostics socket and refreshes it until ctx is done.
+//
+// Failure semantics:
+// - Bind failures are returned; callers treat them as non-fatal (the feed is optional).
+// - Refresh is skipped while no editor is connected.
+func startLSPDiagnostics(ctx context.Context, server *Server, mcpHandler *MCPHandler, cap *capture.Store, port int) error {
+	th, ok := mcpHandler.toolHandler.(*ToolHandler)
+	if !ok {
+		return nil
+	}
+	lsp := lspdiag.NewServer(version)
+	done, err := lsp.Listen(port)
+	if err != nil {
+		return err
+	}
+	server.setLSPPort(port)
+
+	util.SafeGo(func() {
+		ticker := time.NewTicker(lspRefreshInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				_ = lsp.Close()
+				return
+			case <-done:
+				server.setLSPPort(0)
+				return
+			case <-ticker.C:
+				if lsp.ClientCount() == 0 {
+					continue
+				}
+				entries, _ := th.GetLogEntries()
+				resolver := lspdiag.NewResolver(lspWorkspaceRoots(server, cap))
+				lsp.Update(lspdiag.Build(entries, resolver, th.IsConsoleNoise))
+			}
+		}
+	})
+	return nil
+}
+
+// lspWorkspaceRoots returns the directories errors may resolve into: the active codebase,
+// every registered client's CWD, and the daemon's own working directory.
+func lspWorkspaceRoots(server *Server, cap *capture.Store) []string {
+	var roots []string
+	if codebase := server.GetActiveCodebase(); codebase != "" {
+		roots = append(roots, codebase)
+	}
+	if reg := cap.GetClientRegistry(); reg != nil {
+		if clients, ok := reg.List().([]session.ClientInfo); ok {
+			for _, c := range clients {
+				roots = append(roots, c.CWD)
+			}
+		}
+	}
+	if cwd, err := os.Getwd(); err == nil {
+		roots = append(roots, cwd)
+	}
+	return roots
+}
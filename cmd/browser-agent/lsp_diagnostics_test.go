@@ -0,0 +1,106 @@
+// Purpose: Tests that the daemon's LSP diagnostics feed publishes captured errors resolved into the active codebase.
+// Docs: docs/features/feature/code-navigation-modification/index.md
+
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/lspdiag"
+)
+
+func writeLSPFrame(t *testing.T, w io.Writer, v any) {
+	t.Helper()
+	body, _ := json.Marshal(v)
+	if _, err := fmt.Fprintf(w, "Content-Length: %d\r\n\r\n%s", len(body), body); err != nil {
+		t.Fatalf("write frame: %v", err)
+	}
+}
+
+func readLSPFrame(t *testing.T, r *bufio.Reader) map[string]json.RawMessage {
+	t.Helper()
+	headers, err := textproto.NewReader(r).ReadMIMEHeader()
+	if err != nil {
+		t.Fatalf("read headers: %v", err)
+	}
+	n, _ := strconv.Atoi(headers.Get("Content-Length"))
+	body := make([]byte, n)
+	if _, err := io.ReadFull(r, body); err != nil {
+		t.Fatalf("read body: %v", err)
+	}
+	var msg map[string]json.RawMessage
+	if err := json.Unmarshal(body, &msg); err != nil {
+		t.Fatalf("decode frame: %v", err)
+	}
+	return msg
+}
+
+func TestLSPDiagnostics_PublishesErrorsInActiveCodebase(t *testing.T) {
+	t.Parallel()
+	server, err := NewServer(t.TempDir()+"/test.jsonl", 100)
+	if err != nil {
+		t.Fatalf("NewServer: %v", err)
+	}
+	t.Cleanup(func() { server.Close() })
+	cap := capture.NewCapture()
+	mcpHandler := NewToolHandler(server, cap)
+
+	workspace := t.TempDir()
+	appFile := filepath.Join(workspace, "src", "app.js")
+	if err := os.MkdirAll(filepath.Dir(appFile), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(appFile, []byte("const a = 1\nexplode()\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	server.SetActiveCodebase(workspace)
+	server.logs.addEntries([]LogEntry{{
+		"level": "error", "message": "ReferenceError: explode is not defined",
+		"source": "http://localhost:5173/src/app.js", "line": float64(2), "column": float64(1),
+	}})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	port := findFreePort(t)
+	if err := startLSPDiagnostics(ctx, server, mcpHandler, cap, port); err != nil {
+		t.Fatalf("startLSPDiagnostics: %v", err)
+	}
+	if server.getLSPPort() != port {
+		t.Fatalf("lsp port = %d, want %d", server.getLSPPort(), port)
+	}
+
+	nc, err := net.Dial("tcp", fmt.Sprintf("127.0.0.1:%d", port))
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	defer nc.Close()
+	_ = nc.SetDeadline(time.Now().Add(5 * time.Second))
+	r := bufio.NewReader(nc)
+	writeLSPFrame(t, nc, map[string]any{"jsonrpc": "2.0", "id": 1, "method": "initialize", "params": map[string]any{}})
+	readLSPFrame(t, r)
+	writeLSPFrame(t, nc, map[string]any{"jsonrpc": "2.0", "method": "initialized", "params": map[string]any{}})
+
+	msg := readLSPFrame(t, r)
+	var params lspdiag.PublishDiagnosticsParams
+	if err := json.Unmarshal(msg["params"], &params); err != nil {
+		t.Fatalf("decode publish: %v", err)
+	}
+	if params.URI != lspdiag.FileURI(appFile) || len(params.Diagnostics) != 1 {
+		t.Fatalf("publish = %+v", params)
+	}
+	if d := params.Diagnostics[0]; d.Range.Start.Line != 1 || d.Message != "ReferenceError: explode is not defined" {
+		t.Errorf("diagnostic = %+v", d)
+	}
+}
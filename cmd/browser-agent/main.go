@@ -5,10 +5,14 @@ package main
 
 import (
 	"fmt"
+	"net/http"
 	"os"
 	"time"
 
 	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/cmd/browser-agent/internal/cli"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/queries"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/remoteaccess"
 	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/telemetry"
 )
 
@@ -40,6 +44,41 @@ var (
 	osUploadAutomationFlag bool            // --enable-os-upload-automation (Stage 4 only)
 	uploadSecurityConfig   *UploadSecurity // validated upload security config
 
+	// Per-client tool call limits (set by --tool-rate-limit / --tool-quota, consumed by ToolHandler)
+	toolCallLimitsConfig = ToolCallLimits{CallsPerMinute: defaultToolCallsPerMinute}
+
+	// CDP passthrough policy (set by --enable-cdp-passthrough / --cdp-allow-method, consumed by ToolHandler)
+	cdpPassthroughConfig CDPPassthroughPolicy
+
+	// Per-buffer retention from --retention (consumed by ToolHandler as the startup defaults)
+	retentionFlagConfig map[string]capture.Retention
+
+	// Extension protocol record/replay paths (set by --record-protocol / --replay, consumed by runMCPMode)
+	protocolRecordPath string
+	protocolReplayPath string
+
+	// Fixture served by the simulated extension (set by --mock-browser, consumed by runMCPMode)
+	mockBrowserPath string
+
+	// Delivery retry policy for commands the extension has not picked up (set by --extension-retry, consumed by initCapture)
+	extensionRetryPolicy = queries.DefaultRetryPolicy()
+
+	// Auth state vault key (set by --state-key / KABOOM_STATE_KEY, consumed by ToolHandler)
+	stateVaultKey string
+
+	// Remote listener policy (set by --remote-listen / --remote-allow / --remote-host, consumed by startHTTPServer and corsMiddleware)
+	remoteAccessConfig *remoteaccess.Config
+	remoteTLSCertFile  string
+	remoteTLSKeyFile   string
+
+	// Unix socket for the daemon HTTP endpoint instead of TCP (set by --listen unix:/path, consumed by runMCPMode and connect mode)
+	listenSocketPath string
+
+	// Connect-mode target (set by --remote-url / --remote-fingerprint, consumed by runConnectMode)
+	connectRemoteURL  string
+	connectHTTPClient = http.DefaultClient
+	connectRequestKey string
+
 	startupWarnings []string
 )
 
@@ -53,6 +92,12 @@ func main() {
 	if len(os.Args) >= 2 && cli.IsCLIMode(os.Args[1:]) {
 		os.Exit(cli.Run(os.Args[1:], cliRuntimeConfig()))
 	}
+	if len(os.Args) >= 2 && cli.IsWatchMode(os.Args[1:]) {
+		os.Exit(cli.RunWatch(os.Args[2:], cliRuntimeConfig()))
+	}
+	if len(os.Args) >= 2 && cli.IsDaemonCommand(os.Args[1:]) {
+		os.Exit(cli.RunDaemonCommand(os.Args[1:], cliRuntimeConfig()))
+	}
 
 	cfg := parseAndValidateFlags()
 
@@ -81,14 +126,35 @@ Options:
   --log-file <path>      Path to log file (default: in runtime state dir)
   --state-dir <path>     Directory for runtime state (default: OS app state dir)
   --parallel             Opt-in parallel mode (isolated state dir, no takeover)
+  --auto-port            Pick a free port per project when 7890 is taken (default: $KABOOM_AUTO_PORT)
   --max-entries <number> Max log entries before rotation (default: 1000)
+  --retention <spec>     Buffer retention as buffer=[max_entries][:ttl], e.g. console=5000:30m (repeatable)
   --stop                 Stop the running server on the specified port
   --force                Force kill ALL running kaboom daemons (used during install)
   --api-key <key>        Require API key for HTTP requests (optional)
+  --state-key <key>      Key for the encrypted auth state vault (default: $KABOOM_STATE_KEY)
   --connect              Connect to existing server (multi-client mode)
+  --listen unix:<path>   Serve (or, with --connect, reach) the daemon on a Unix socket instead of TCP
+  --remote-listen <addr> Also serve HTTPS for remote peers, e.g. 0.0.0.0:7443 (needs --api-key, --remote-allow)
+  --remote-allow <cidr>  Peer IP or CIDR allowed on --remote-listen (repeatable)
+  --remote-host <name>   Host name remote peers use for this daemon (repeatable)
+  --tls-cert/--tls-key   Certificate for --remote-listen (default: generated self-signed)
+  --remote-url <url>     With --connect, forward to a remote daemon over https
+  --remote-fingerprint   SHA-256 fingerprint the --remote-url certificate must match
   --client-id <id>       Override client ID (default: derived from CWD)
+  --tool-rate-limit <n>  Max tool calls per minute per client, 0 = unlimited (default: 500)
+  --tool-quota <n>       Max tool calls per hour per client, 0 = unlimited (default: 0)
   --check                Verify setup (check port availability, print status)
   --doctor               Run full diagnostics (alias of --check)
+  --serve-bundle <path>  Serve MCP over stdio, read-only, from a session bundle
+  --ci --policy <file>   Headless CI gate: capture, evaluate the policy, exit 1 on violation
+  --ci-duration <d>      Stop the CI gate after a fixed duration (e.g. 90s)
+  --ci-test-id <id>      Stop the CI gate when this test boundary ends
+  --ci-report <path>     CI gate report (.xml = JUnit, else JSON; default kaboom-ci-report.json)
+  --record-protocol <f>  Append extension commands and results to a JSONL recording
+  --replay <file>        Answer extension commands from a recording (no browser needed)
+  --mock-browser <file>  Answer DOM, a11y, and interact commands from an HTML/JSON fixture
+  --extension-retry <s>  Re-offer commands the extension missed, e.g. attempts=5,backoff=1s,jitter=0.2 or off
   --fastpath-min-samples Minimum telemetry samples required for threshold check (default: 50)
   --fastpath-max-failure-ratio Maximum allowed fast-path failure ratio for --check (disabled by default)
   --persist              Deprecated no-op (kept for backwards compatibility)
@@ -107,6 +173,8 @@ Examples:
   kaboom --api-key s3cret             # Start with API key auth
   kaboom --connect --port 7890        # Connect to existing server
   kaboom --check                      # Verify setup before running
+  kaboom --serve-bundle incident.json # Investigate an archived session (read-only)
+  kaboom --ci --policy policy.json    # Gate a CI run on errors, budgets, domains, CSP
   kaboom --port 8080 --max-entries 500
 
 CLI Mode (direct tool access):
@@ -116,6 +184,13 @@ CLI Mode (direct tool access):
   kaboom generate har --save-to out.har
   kaboom configure health
   kaboom interact click --selector "#btn"
+  kaboom watch ./src                  # Report file edits for timeline edit verdicts
+
+Daemon Supervision:
+  kaboom status                       # Running/stopped, PID, version, uptime, extension (exit 3 if stopped)
+  kaboom stop                         # Stop the daemon (PID file, then /shutdown, then port lookup)
+  kaboom restart                      # Stop, then start a fresh daemon on the same port
+  kaboom logs --follow                # Tail the daemon log (--lines N, default 50)
 
   CLI flags: --port, --format (human|json|csv), --timeout (ms)
   Env vars: KABOOM_PORT, KABOOM_FORMAT, KABOOM_STATE_DIR
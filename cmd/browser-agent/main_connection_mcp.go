@@ -6,9 +6,12 @@ package main
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"runtime"
 
 	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/cmd/browser-agent/internal/terminal"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/lspdiag"
 	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/telemetry"
 	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/util"
 )
@@ -30,6 +33,12 @@ func runMCPMode(server *Server, port int, apiKey string, opts daemonLaunchOption
 	startVersionCheckLoop(ctx)
 	server.startScreenshotRateLimiterCleanup(ctx)
 	configureBinaryUpgradeMonitoring(ctx, server, port)
+	if err := startProtocolReplay(ctx, server, cap, protocolRecordPath, protocolReplayPath); err != nil {
+		return err
+	}
+	if err := startMockBrowser(ctx, server, cap, mockBrowserPath); err != nil {
+		return err
+	}
 
 	if err := enforceDaemonStartupPolicy(server, port, opts); err != nil {
 		return err
@@ -38,8 +47,10 @@ func runMCPMode(server *Server, port int, apiKey string, opts daemonLaunchOption
 	if err := cleanupStalePIDFile(server, port); err != nil {
 		return err
 	}
-	if err := preflightPortCheck(server, port); err != nil {
-		return err
+	if listenSocketPath == "" {
+		if err := preflightPortCheck(server, port); err != nil {
+			return err
+		}
 	}
 
 	srv, httpDone, err := startHTTPServer(server, port, apiKey, mux)
@@ -48,29 +59,17 @@ func runMCPMode(server *Server, port int, apiKey string, opts daemonLaunchOption
 	}
 	persistDaemonRuntimeState(server, port)
 
-	// Start dedicated terminal server on port+1.
-	// Non-fatal: if the terminal port is busy, log a warning and continue without terminal.
-	termPort := port + terminal.PortOffset
-	termMux, termRelays := setupTerminalMux(server, server.ptyManager, cap)
-	server.ptyRelays = termRelays
-	termSrv, termDone, termErr := startTerminalServer(termPort, termMux)
-	if termErr != nil {
-		stderrf("[Kaboom] WARNING: terminal server failed to start on port %d: %v\n", termPort, termErr)
-		stderrf("[Kaboom] Terminal features are unavailable. Free port %d or use a different base port.\n", termPort)
-		server.logLifecycle("terminal_server_bind_failed", termPort, map[string]any{
-			"error":     termErr.Error(),
-			"term_port": termPort,
-		})
+	// With --listen unix:, no TCP port is opened at all: the terminal server and LSP feed stay off.
+	var termSrv *http.Server
+	var termDone <-chan struct{}
+	termPort, lspPort := 0, 0
+	if listenSocketPath != "" {
+		stderrf("[Kaboom] Serving on unix:%s (no TCP port; terminal, LSP feed, and browser extension need TCP)\n", listenSocketPath)
+		server.logLifecycle("tcp_servers_skipped", port, map[string]any{"socket": listenSocketPath})
 	} else {
-		server.setTerminalPort(termPort)
-		server.logLifecycle("terminal_server_started", termPort, nil)
-		// Monitor terminal server — log if it dies, but do NOT bring down main daemon.
-		util.SafeGo(func() {
-			<-termDone
-			stderrf("[Kaboom] terminal server on port %d exited unexpectedly\n", termPort)
-			server.logLifecycle("terminal_server_died", termPort, nil)
-			server.setTerminalPort(0) // Mark as unavailable
-		})
+		termPort, lspPort = port+terminal.PortOffset, port+lspdiag.PortOffset
+		termSrv, termDone = startDaemonTerminalServer(server, cap, termPort)
+		startDaemonLSPDiagnostics(ctx, server, mcpHandler, cap, lspPort)
 	}
 
 	server.logLifecycle("startup", port, map[string]any{
@@ -79,6 +78,7 @@ func runMCPMode(server *Server, port int, apiKey string, opts daemonLaunchOption
 		"os":            runtime.GOOS,
 		"arch":          runtime.GOARCH,
 		"terminal_port": termPort,
+		"lsp_port":      lspPort,
 	})
 	server.logLifecycle("mcp_transport_ready", port, nil)
 
@@ -96,3 +96,40 @@ func runMCPMode(server *Server, port int, apiKey string, opts daemonLaunchOption
 	awaitShutdownSignal(server, srv, port, httpDone, termSrv, termDone, mcpHandler)
 	return nil
 }
+
+// startDaemonTerminalServer starts the dedicated terminal server on termPort.
+// Non-fatal: if the terminal port is busy, log a warning and continue without terminal.
+func startDaemonTerminalServer(server *Server, cap *capture.Store, termPort int) (*http.Server, <-chan struct{}) {
+	termMux, termRelays := setupTerminalMux(server, server.ptyManager, cap)
+	server.ptyRelays = termRelays
+	termSrv, termDone, termErr := startTerminalServer(termPort, termMux)
+	if termErr != nil {
+		stderrf("[Kaboom] WARNING: terminal server failed to start on port %d: %v\n", termPort, termErr)
+		stderrf("[Kaboom] Terminal features are unavailable. Free port %d or use a different base port.\n", termPort)
+		server.logLifecycle("terminal_server_bind_failed", termPort, map[string]any{
+			"error":     termErr.Error(),
+			"term_port": termPort,
+		})
+		return nil, nil
+	}
+	server.setTerminalPort(termPort)
+	server.logLifecycle("terminal_server_started", termPort, nil)
+	// Monitor terminal server — log if it dies, but do NOT bring down main daemon.
+	util.SafeGo(func() {
+		<-termDone
+		stderrf("[Kaboom] terminal server on port %d exited unexpectedly\n", termPort)
+		server.logLifecycle("terminal_server_died", termPort, nil)
+		server.setTerminalPort(0) // Mark as unavailable
+	})
+	return termSrv, termDone
+}
+
+// startDaemonLSPDiagnostics starts the LSP diagnostics feed on lspPort. Non-fatal, like the terminal server.
+func startDaemonLSPDiagnostics(ctx context.Context, server *Server, mcpHandler *MCPHandler, cap *capture.Store, lspPort int) {
+	if err := startLSPDiagnostics(ctx, server, mcpHandler, cap, lspPort); err != nil {
+		stderrf("[Kaboom] WARNING: LSP diagnostics feed failed to start on port %d: %v\n", lspPort, err)
+		server.logLifecycle("lsp_diagnostics_bind_failed", lspPort, map[string]any{"error": err.Error()})
+		return
+	}
+	server.logLifecycle("lsp_diagnostics_started", lspPort, nil)
+}
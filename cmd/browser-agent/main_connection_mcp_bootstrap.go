@@ -24,6 +24,7 @@ func initCapture(server *Server, port int) *capture.Store {
 	cap := capture.NewCapture()
 	cap.SetClientRegistry(newSessionClientRegistryAdapter(session.NewClientRegistry()))
 	cap.SetServerVersion(version)
+	_ = cap.SetRetryPolicy(extensionRetryPolicy) // validated by parseAndValidateFlags
 	cap.SetLifecycleCallback(func(event string, data map[string]any) {
 		entry := LogEntry{
 			"type":      "lifecycle",
@@ -159,18 +160,17 @@ func startHTTPServer(server *Server, port int, apiKey string, mux *http.ServeMux
 		ReadTimeout:  5 * time.Second,
 		WriteTimeout: 65 * time.Second, // Must accommodate blocking tool waits (screenshot 20s, interact 35s, annotations 55s)
 		IdleTimeout:  120 * time.Second,
-		Handler:      AuthMiddleware(apiKey)(mux),
+		Handler:      remotePeerGuard(AuthMiddleware(apiKey)(mux)),
 	}
 	util.SafeGo(func() {
 		defer close(httpDone)
-		addr := fmt.Sprintf("127.0.0.1:%d", port)
-		ln, err := net.Listen("tcp", addr)
+		ln, err := listenHTTP(port)
 		if err != nil {
 			httpReady <- err
 			return
 		}
 		httpReady <- nil
-		// #nosec G114 -- localhost-only MCP background server
+		// #nosec G114 -- localhost-only (or owner-only Unix socket) MCP background server
 		if err := srv.Serve(ln); err != nil && err != http.ErrServerClosed {
 			_ = appendExitDiagnostic("http_listener_error", map[string]any{
 				"port":  port,
@@ -182,10 +182,21 @@ func startHTTPServer(server *Server, port int, apiKey string, mux *http.ServeMux
 
 	if err := <-httpReady; err != nil {
 		server.logLifecycle("http_bind_failed", port, map[string]any{"error": err.Error()})
+		if listenSocketPath != "" {
+			return nil, nil, fmt.Errorf("cannot bind socket %s: %w", listenSocketPath, err)
+		}
 		return nil, nil, fmt.Errorf("cannot bind port %d: %w", port, err)
 	}
 
-	server.logLifecycle("http_bind_success", port, nil)
+	if listenSocketPath != "" {
+		server.logLifecycle("http_bind_success", port, map[string]any{"socket": listenSocketPath})
+	} else {
+		server.logLifecycle("http_bind_success", port, nil)
+	}
+	if err := startRemoteListener(server, srv, port); err != nil {
+		_ = srv.Close()
+		return nil, nil, err
+	}
 	return srv, httpDone, nil
 }
 
@@ -197,4 +208,5 @@ func persistDaemonRuntimeState(server *Server, port int) {
 	if err := persistCurrentDaemonLock(port); err != nil {
 		server.logLifecycle("daemon_lock_write_failed", port, map[string]any{"error": err.Error()})
 	}
+	registerDaemonServer(server, port)
 }
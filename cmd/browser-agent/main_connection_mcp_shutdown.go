@@ -12,6 +12,7 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/sessionbundle"
 	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/state"
 )
 
@@ -89,9 +90,15 @@ func awaitShutdownSignal(server *Server, srv *http.Server, port int, httpDone <-
 
 	server.logs.shutdownAsyncLogger(asyncLoggerDrainTimeout)
 	server.closeAnnotationStore()
-	// Close capture store to stop background cleanup goroutines (QueryDispatcher).
+	// Archive the session while the buffers are still intact, then close capture
+	// store to stop background cleanup goroutines (QueryDispatcher).
 	if mcpHandler != nil && mcpHandler.toolHandler != nil {
 		if th, ok := mcpHandler.toolHandler.(*ToolHandler); ok && th.capture != nil {
+			if session, archived, err := th.archiveSession(sessionbundle.ReasonShutdown); err != nil {
+				server.logLifecycle("session_archive_error", port, map[string]any{"error": err.Error()})
+			} else if archived {
+				server.logLifecycle("session_archived", port, map[string]any{"id": session.ID, "bytes": session.Bytes})
+			}
 			th.capture.Close()
 		}
 	}
@@ -117,6 +124,7 @@ func awaitShutdownSignal(server *Server, srv *http.Server, port int, httpDone <-
 
 	removePIDFile(port)
 	removeDaemonLockIfOwned(os.Getpid())
+	unregisterDaemonServer(port)
 }
 
 // mapSignalSource returns a human-readable description for a termination signal.
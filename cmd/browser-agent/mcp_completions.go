@@ -0,0 +1,99 @@
+// Purpose: Implements completion/complete for the arguments of Kaboom's resource templates.
+// Why: Lets 2025-03-26+ clients offer valid observe modes, schemas, playbooks, and demos instead of guessing URIs.
+// Docs: docs/mcp-integration/index.md
+
+package main
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/schema"
+)
+
+// maxCompletionValues is the spec's per-response cap on completion values.
+const maxCompletionValues = 100
+
+// handleCompletionComplete answers completion/complete for resource template arguments.
+//
+// Failure semantics:
+// - Malformed params yield JSON-RPC -32602.
+// - Prompt refs and unknown templates or arguments return an empty completion, not an error.
+func (h *MCPHandler) handleCompletionComplete(req JSONRPCRequest) JSONRPCResponse {
+	var params struct {
+		Ref struct {
+			Type string `json:"type"`
+			URI  string `json:"uri"`
+		} `json:"ref"`
+		Argument struct {
+			Name  string `json:"name"`
+			Value string `json:"value"`
+		} `json:"argument"`
+		Context struct {
+			Arguments map[string]string `json:"arguments"`
+		} `json:"context"`
+	}
+	if err := json.Unmarshal(req.Params, &params); err != nil {
+		return JSONRPCResponse{JSONRPC: JSONRPCVersion, ID: req.ID, Error: &JSONRPCError{Code: -32602, Message: "Invalid params: " + err.Error()}}
+	}
+
+	var candidates []string
+	if params.Ref.Type == "ref/resource" {
+		candidates = completionCandidates(params.Ref.URI, params.Argument.Name, params.Context.Arguments)
+	}
+	var values []string
+	for _, c := range candidates {
+		if strings.HasPrefix(c, params.Argument.Value) {
+			values = append(values, c)
+		}
+	}
+
+	result := MCPCompleteResult{}
+	result.Completion.Total = len(values)
+	if len(values) > maxCompletionValues {
+		values, result.Completion.HasMore = values[:maxCompletionValues], true
+	}
+	result.Completion.Values = append([]string{}, values...)
+	// Error impossible: MCPCompleteResult is a simple struct with no circular refs or unsupported types
+	resultJSON, _ := json.Marshal(result)
+	return succeedRaw(req, resultJSON)
+}
+
+// completionCandidates returns the sorted values one template argument can take.
+// context carries arguments the client already resolved (a playbook's capability narrows its levels).
+func completionCandidates(uriTemplate, argument string, context map[string]string) []string {
+	var out []string
+	switch {
+	case uriTemplate == liveResourcePrefix+"{what}" && argument == "what":
+		for mode := range liveResourceObserveModes {
+			out = append(out, mode)
+		}
+	case uriTemplate == schema.ObserveOutputSchemaURIPrefix+"{what}" && argument == "what":
+		return schema.ObserveOutputSchemaModes()
+	case uriTemplate == "kaboom://playbook/{capability}/{level}":
+		seen := map[string]bool{}
+		for key := range playbookMap {
+			capability, level, _ := strings.Cut(key, "/")
+			value := capability
+			if argument == "level" {
+				if want := context["capability"]; want != "" && want != capability {
+					continue
+				}
+				value = level
+			} else if argument != "capability" {
+				return nil
+			}
+			if !seen[value] {
+				seen[value] = true
+				out = append(out, value)
+			}
+		}
+	case uriTemplate == "kaboom://demo/{name}" && argument == "name":
+		for name := range demoScripts {
+			out = append(out, name)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
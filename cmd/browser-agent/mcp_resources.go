@@ -1,10 +1,12 @@
-// Purpose: Declares MCP resource URIs (capabilities, guide, quickstart) and URI templates (playbooks, demos) for client discovery.
+// Purpose: Declares MCP resource URIs (capabilities, guide, quickstart, live snapshots) and URI templates (playbooks, demos, observe modes, observe output schemas) for client discovery.
 // Why: Exposes token-efficient documentation resources that MCP clients can read on demand.
 
 package main
 
+import "github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/schema"
+
 func mcpResources() []MCPResource {
-	return []MCPResource{
+	return append([]MCPResource{
 		{
 			URI:         "kaboom://capabilities",
 			Name:        "Kaboom Capability Index",
@@ -23,7 +25,7 @@ func mcpResources() []MCPResource {
 			Description: "Short, canonical MCP call examples and workflows",
 			MimeType:    "text/markdown",
 		},
-	}
+	}, liveMCPResources()...)
 }
 
 func mcpResourceTemplates() []any {
@@ -40,5 +42,17 @@ func mcpResourceTemplates() []any {
 			"description": "Demo scripts for websockets, annotations, recording, and dependency vetting",
 			"mimeType":    "text/markdown",
 		},
+		map[string]any{
+			"uriTemplate": liveResourcePrefix + "{what}",
+			"name":        "Kaboom Live Buffer",
+			"description": "Current capture buffer as JSON for a buffer-backed observe mode (errors, logs, network_waterfall, network_bodies, websocket_events, actions, vitals, timeline, ...), with default parameters",
+			"mimeType":    liveResourceMimeType,
+		},
+		map[string]any{
+			"uriTemplate": schema.ObserveOutputSchemaURIPrefix + "{what}",
+			"name":        "Kaboom Observe Output Schema",
+			"description": "JSON Schema of the structuredContent returned by observe for one what mode",
+			"mimeType":    liveResourceMimeType,
+		},
 	}
 }
@@ -0,0 +1,135 @@
+// Purpose: Serves live capture-buffer snapshots (errors, HAR, timeline, buffer-backed observe modes) as MCP resources.
+// Why: Clients that prefer resources/read over tool calls can pull current state without learning the tool schema.
+// Docs: docs/mcp-integration/index.md
+
+package main
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/mcp"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/schema"
+)
+
+const (
+	liveResourcePrefix        = "kaboom://observe/"
+	liveResourceMimeType      = "application/json"
+	liveResourceErrorsLimit   = 50
+	liveResourceTimelineLimit = 200
+)
+
+// liveResource maps a fixed resource URI to the tool call that renders it.
+type liveResource struct {
+	resource MCPResource
+	tool     string
+	args     map[string]any
+}
+
+var liveResources = []liveResource{
+	{
+		resource: MCPResource{URI: "kaboom://errors/latest", Name: "Latest Browser Errors",
+			Description: "Most recent console errors from the tracked page (same as observe what=errors, limit 50)", MimeType: liveResourceMimeType},
+		tool: "observe", args: map[string]any{"what": "errors", "limit": liveResourceErrorsLimit},
+	},
+	{
+		resource: MCPResource{URI: "kaboom://har/current", Name: "Current Network HAR",
+			Description: "HAR 1.2 log of captured network traffic (same as generate what=har)", MimeType: liveResourceMimeType},
+		tool: "generate", args: map[string]any{"what": "har"},
+	},
+	{
+		resource: MCPResource{URI: "kaboom://timeline/session", Name: "Session Timeline",
+			Description: "Merged chronological timeline of actions, errors, network, and edits (same as observe what=timeline)", MimeType: liveResourceMimeType},
+		tool: "observe", args: map[string]any{"what": "timeline", "limit": liveResourceTimelineLimit},
+	},
+}
+
+// liveResourceObserveModes are the observe modes readable as kaboom://observe/{what}.
+// All are pure buffer reads; modes that round-trip to the extension are excluded so a
+// resource read never waits on the browser.
+var liveResourceObserveModes = map[string]bool{
+	"errors": true, "logs": true, "network_waterfall": true, "network_bodies": true,
+	"websocket_events": true, "websocket_status": true, "sse": true, "actions": true, "vitals": true,
+	"timeline": true, "error_bundles": true, "summarized_logs": true, "transients": true,
+	"changes": true, "history": true, "transport_security": true, "privacy_audit": true,
+	"contract_violations": true, "budget_violations": true, "findings": true, "memory": true,
+}
+
+// liveMCPResources lists the fixed live resources for resources/list.
+func liveMCPResources() []MCPResource {
+	out := make([]MCPResource, 0, len(liveResources))
+	for _, r := range liveResources {
+		out = append(out, r.resource)
+	}
+	return out
+}
+
+// isLiveResourceURI reports whether uri must be rendered by the daemon from capture state.
+// The bridge uses it to forward these reads instead of answering from static content.
+func isLiveResourceURI(uri string) bool {
+	if strings.HasPrefix(uri, liveResourcePrefix) || strings.HasPrefix(uri, schema.ObserveOutputSchemaURIPrefix) {
+		return true
+	}
+	for _, r := range liveResources {
+		if r.resource.URI == uri {
+			return true
+		}
+	}
+	return false
+}
+
+// readLiveResource renders uri through the matching tool handler and returns the tool's JSON payload.
+// Observe output schemas (kaboom://schema/observe/{what}) are served from internal/schema.
+// found is false for unknown URIs and observe modes outside liveResourceObserveModes; a tool
+// failure is returned as errMsg with found=true.
+func (h *ToolHandler) readLiveResource(uri string) (text string, errMsg string, found bool) {
+	tool, args := "", map[string]any(nil)
+	if mode, ok := strings.CutPrefix(uri, liveResourcePrefix); ok {
+		if !liveResourceObserveModes[mode] {
+			return "", "", false
+		}
+		tool, args = "observe", map[string]any{"what": mode}
+	}
+	if mode, ok := strings.CutPrefix(uri, schema.ObserveOutputSchemaURIPrefix); ok {
+		s, found := schema.ObserveOutputSchema(mode)
+		if !found {
+			return "", "", false
+		}
+		// Error impossible: schema maps hold only strings, bools, slices, and nested maps
+		data, _ := json.Marshal(s)
+		return string(data), "", true
+	}
+	for _, r := range liveResources {
+		if r.resource.URI == uri {
+			tool, args = r.tool, r.args
+		}
+	}
+	if tool == "" {
+		return "", "", false
+	}
+
+	argsJSON, _ := json.Marshal(args)
+	req := JSONRPCRequest{JSONRPC: JSONRPCVersion, ID: "resource:" + uri}
+	var resp JSONRPCResponse
+	switch tool {
+	case "generate":
+		resp = h.toolGenerate(req, argsJSON)
+	default:
+		resp = h.toolObserve(req, argsJSON)
+	}
+
+	var result MCPToolResult
+	if err := json.Unmarshal(resp.Result, &result); err != nil || len(result.Content) == 0 {
+		return "", "failed to render " + uri, true
+	}
+	text = strings.TrimSpace(result.Content[0].Text)
+	if result.IsError {
+		return "", text, true
+	}
+	// Tool text is "summary\n{json}"; resources carry the JSON alone.
+	payload, ok := mcp.ExtractJSONPayload(text)
+	if !ok {
+		return "", "no JSON payload rendering " + uri, true
+	}
+	return string(payload), "", true
+}
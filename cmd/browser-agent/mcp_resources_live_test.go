@@ -0,0 +1,77 @@
+// Purpose: Tests live capture-buffer MCP resources (list, templates, and resources/read rendering).
+// Docs: docs/mcp-integration/index.md
+
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+)
+
+func readResource(t *testing.T, h *MCPHandler, uri string) *JSONRPCResponse {
+	t.Helper()
+	return h.HandleRequest(JSONRPCRequest{JSONRPC: "2.0", ID: 1, Method: "resources/read", Params: json.RawMessage(`{"uri":"` + uri + `"}`)})
+}
+
+func TestLiveResources_Read(t *testing.T) {
+	t.Parallel()
+	server, err := NewServer(t.TempDir()+"/test.jsonl", 100)
+	if err != nil {
+		t.Fatalf("NewServer: %v", err)
+	}
+	t.Cleanup(func() { server.Close() })
+	cap := capture.NewCapture()
+	h := NewToolHandler(server, cap)
+
+	server.logs.addEntries([]LogEntry{{"level": "error", "message": "TypeError: cart is undefined", "ts": time.Now().UTC().Format(time.RFC3339Nano)}})
+	cap.AddNetworkBodies([]capture.NetworkBody{{Method: "GET", URL: "http://localhost:5173/api/cart", Status: 200, ContentType: "application/json"}})
+
+	for _, tc := range []struct {
+		uri  string
+		want string
+	}{
+		{"kaboom://errors/latest", "TypeError: cart is undefined"},
+		{"kaboom://har/current", `"log"`},
+		{"kaboom://timeline/session", "TypeError: cart is undefined"},
+		{"kaboom://observe/network_bodies", "/api/cart"},
+	} {
+		resp := readResource(t, h, tc.uri)
+		if resp == nil || resp.Error != nil {
+			t.Fatalf("resources/read %s = %+v, want success", tc.uri, resp)
+		}
+		data := mustDecodeJSON[MCPResourcesReadResult](t, resp.Result)
+		if len(data.Contents) != 1 || data.Contents[0].URI != tc.uri || data.Contents[0].MimeType != "application/json" {
+			t.Fatalf("resources/read %s contents = %+v", tc.uri, data.Contents)
+		}
+		text := data.Contents[0].Text
+		if !json.Valid([]byte(text)) || !strings.Contains(text, tc.want) {
+			t.Fatalf("resources/read %s text = %s, want JSON containing %q", tc.uri, text, tc.want)
+		}
+	}
+
+	for _, uri := range []string{"kaboom://observe/screenshot", "kaboom://observe/nope"} {
+		if resp := readResource(t, h, uri); resp == nil || resp.Error == nil || resp.Error.Code != -32002 {
+			t.Fatalf("resources/read %s = %+v, want -32002", uri, resp)
+		}
+	}
+}
+
+func TestLiveResources_Templates(t *testing.T) {
+	t.Parallel()
+	found := false
+	for _, raw := range mcpResourceTemplates() {
+		if tpl, ok := raw.(map[string]any); ok && tpl["uriTemplate"] == "kaboom://observe/{what}" {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatal("resources/templates/list missing kaboom://observe/{what}")
+	}
+	if !isLiveResourceURI("kaboom://har/current") || !isLiveResourceURI("kaboom://observe/logs") || isLiveResourceURI("kaboom://guide") {
+		t.Fatal("isLiveResourceURI misclassified a URI")
+	}
+}
@@ -0,0 +1,34 @@
+// Purpose: Wires --mock-browser into the daemon: a fixture-backed simulated extension answers DOM, a11y, and interact commands.
+// Why: Agent developers can run deterministic integration tests against the real tool surface without Chrome.
+// Docs: docs/features/feature/mock-browser/index.md
+
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/extreplay"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/mockbrowser"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/util"
+)
+
+// startMockBrowser loads the fixture and connects a simulated extension that answers
+// from it until ctx is done. A fixture that cannot be loaded is fatal.
+func startMockBrowser(ctx context.Context, server *Server, cap *capture.Store, fixturePath string) error {
+	if fixturePath == "" {
+		return nil
+	}
+	browser, err := mockbrowser.Load(fixturePath)
+	if err != nil {
+		return fmt.Errorf("cannot load --mock-browser %s: %w", fixturePath, err)
+	}
+	settings := browser.Settings()
+	driver := extreplay.NewDriver(cap, browser, settings, version)
+	driver.Logf = func(format string, args ...any) { stderrf("[Kaboom] "+format+"\n", args...) }
+	util.SafeGo(func() { driver.Run(ctx) })
+	stderrf("[Kaboom] Mock browser serving %s (%s)\n", fixturePath, settings.TrackedTabURL)
+	server.logLifecycle("mock_browser_started", 0, map[string]any{"path": fixturePath, "url": settings.TrackedTabURL})
+	return nil
+}
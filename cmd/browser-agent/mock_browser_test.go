@@ -0,0 +1,57 @@
+// Purpose: Tests that --mock-browser answers analyze and interact tool calls end to end from an HTML fixture, with no browser.
+// Docs: docs/features/feature/mock-browser/index.md
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMockBrowser_AnswersToolCallsFromFixture(t *testing.T) {
+	t.Parallel()
+	env := newToolTestEnv(t)
+	fixture := filepath.Join(t.TempDir(), "login.html")
+	page := `<html lang="en"><head><title>Login</title></head><body>
+		<form><label for="user">User</label><input id="user"><button>Sign in</button></form></body></html>`
+	if err := os.WriteFile(fixture, []byte(page), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := startMockBrowser(ctx, env.server, env.capture, fixture); err != nil {
+		t.Fatalf("startMockBrowser: %v", err)
+	}
+	for deadline := time.Now().Add(3 * time.Second); !env.capture.IsExtensionConnected(); time.Sleep(10 * time.Millisecond) {
+		if time.Now().After(deadline) {
+			t.Fatal("mock browser never connected")
+		}
+	}
+	req := JSONRPCRequest{JSONRPC: "2.0", ID: 1}
+	call := func(what string, resp JSONRPCResponse) string {
+		t.Helper()
+		result := parseToolResult(t, resp)
+		if result.IsError {
+			t.Fatalf("%s failed: %s", what, result.Content[0].Text)
+		}
+		return result.Content[0].Text
+	}
+
+	call("type", env.handler.toolInteract(req, json.RawMessage(`{"what":"type","selector":"#user","text":"ada"}`)))
+	if text := call("get_value", env.handler.toolInteract(req, json.RawMessage(`{"what":"get_value","selector":"label=User"}`))); !strings.Contains(text, `ada`) {
+		t.Fatalf("typed value not read back: %s", text)
+	}
+	if text := call("dom", env.handler.toolAnalyze(req, json.RawMessage(`{"what":"dom","selector":"button"}`))); !strings.Contains(text, "Sign in") {
+		t.Fatalf("dom query does not carry the fixture: %s", text)
+	}
+
+	if err := startMockBrowser(ctx, env.server, env.capture, filepath.Join(t.TempDir(), "missing.html")); err == nil {
+		t.Fatal("a missing --mock-browser fixture should be an error")
+	}
+}
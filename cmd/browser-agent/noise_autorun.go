@@ -180,6 +180,15 @@ func (h *ToolHandler) IsConsoleNoise(entry map[string]any) bool {
 	return h.noiseConfig.IsConsoleNoise(entry)
 }
 
+// ClassifyConsoleNoise applies the first matching noise rule to a log entry.
+// Satisfies observe.ConsoleNoiseClassifier. Returns the entry unchanged if noise config is nil.
+func (h *ToolHandler) ClassifyConsoleNoise(entry map[string]any) (map[string]any, bool) {
+	if h.noiseConfig == nil {
+		return entry, false
+	}
+	return h.noiseConfig.ClassifyConsole(entry)
+}
+
 // runNoiseAutoDetect collects current buffer data and runs noise auto-detection.
 // This is the same logic as noiseActionAutoDetect() but designed for background use.
 func (h *ToolHandler) runNoiseAutoDetect() {
@@ -0,0 +1,53 @@
+// Purpose: Wires --record-protocol and --replay into the daemon: records extension traffic, or simulates the extension from a recording.
+// Why: Tool handlers that wait on the extension can be developed and tested end to end without a browser.
+// Docs: docs/features/feature/protocol-replay/index.md
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/extreplay"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/util"
+)
+
+// startProtocolReplay installs the protocol recorder and starts the replay driver when
+// configured. Both stop when ctx is done. A recording that cannot be opened only warns;
+// a replay that cannot be loaded is fatal, since every extension command would time out.
+func startProtocolReplay(ctx context.Context, server *Server, cap *capture.Store, recordPath, replayPath string) error {
+	if replayPath != "" {
+		script, err := extreplay.Load(replayPath)
+		if err != nil {
+			return fmt.Errorf("cannot load --replay %s: %w", replayPath, err)
+		}
+		driver := extreplay.NewDriver(cap, script, script.Settings, script.ExtensionVersion)
+		driver.Logf = func(format string, args ...any) { stderrf("[Kaboom] "+format+"\n", args...) }
+		util.SafeGo(func() { driver.Run(ctx) })
+		stderrf("[Kaboom] Replaying %d extension exchange(s) from %s (%d unanswered skipped)\n", script.Len(), replayPath, script.Unanswered)
+		server.logLifecycle("protocol_replay_started", 0, map[string]any{
+			"path": replayPath, "exchanges": script.Len(), "unanswered": script.Unanswered,
+		})
+	}
+
+	if recordPath != "" {
+		rec, err := extreplay.NewRecorder(recordPath)
+		if err != nil {
+			stderrf("[Kaboom] WARNING: cannot record protocol to %s: %v\n", recordPath, err)
+			return nil
+		}
+		cap.SetProtocolRecorder(rec)
+		util.SafeGo(func() {
+			<-ctx.Done()
+			cap.SetProtocolRecorder(nil)
+			if err := rec.Close(); err != nil || rec.Err() != nil {
+				stderrf("[Kaboom] WARNING: protocol recording %s incomplete: %v\n", recordPath, errors.Join(err, rec.Err()))
+			}
+		})
+		stderrf("[Kaboom] Recording extension protocol to %s\n", recordPath)
+		server.logLifecycle("protocol_recording_started", 0, map[string]any{"path": recordPath})
+	}
+	return nil
+}
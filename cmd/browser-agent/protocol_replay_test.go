@@ -0,0 +1,53 @@
+// Purpose: Tests that --replay answers a tool's extension command end to end from a recording, with no browser.
+// Docs: docs/features/feature/protocol-replay/index.md
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestProtocolReplay_AnswersToolCallFromRecording(t *testing.T) {
+	t.Parallel()
+	env := newToolTestEnv(t)
+	recording := filepath.Join(t.TempDir(), "protocol.jsonl")
+	lines := strings.Join([]string{
+		`{"kind":"settings","settings":{"tracking_enabled":true,"tracked_tab_id":3,"tracked_tab_url":"http://localhost:5173/"},"extension_version":"0.7.12"}`,
+		`{"kind":"command","command":{"id":"q-9","type":"dom","params":{"selector":"#cart","what":"dom"},"correlation_id":"dom_rec"}}`,
+		`{"kind":"result","result":{"id":"q-9","correlation_id":"dom_rec","status":"complete","result":{"matches":[{"tag":"div","id":"cart"}]}}}`,
+	}, "\n") + "\n"
+	if err := os.WriteFile(recording, []byte(lines), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := startProtocolReplay(ctx, env.server, env.capture, "", recording); err != nil {
+		t.Fatalf("startProtocolReplay: %v", err)
+	}
+	// In the daemon the first heartbeat lands before the port is bound; wait for it here.
+	for deadline := time.Now().Add(3 * time.Second); !env.capture.IsExtensionConnected(); time.Sleep(10 * time.Millisecond) {
+		if time.Now().After(deadline) {
+			t.Fatal("simulated extension never connected")
+		}
+	}
+
+	resp := env.handler.toolAnalyze(JSONRPCRequest{JSONRPC: "2.0", ID: 1}, json.RawMessage(`{"what":"dom","selector":"#cart"}`))
+	result := parseToolResult(t, resp)
+	if result.IsError {
+		t.Fatalf("replayed dom query failed: %s", result.Content[0].Text)
+	}
+	if text := result.Content[0].Text; !strings.Contains(text, `"cart"`) || !strings.Contains(text, "complete") {
+		t.Fatalf("tool result does not carry the recorded answer: %s", text)
+	}
+
+	if err := startProtocolReplay(ctx, env.server, env.capture, "", filepath.Join(t.TempDir(), "missing.jsonl")); err == nil {
+		t.Fatal("a missing --replay file should be an error")
+	}
+}
@@ -1,17 +1,16 @@
-// Purpose: Negotiates MCP protocol version during initialize, supporting latest and one legacy version.
-// Why: Ensures backward compatibility when older MCP clients connect to a newer server.
+// Purpose: Negotiates the MCP protocol version during initialize and tracks it per client.
+// Why: Newer clients get newer result shapes while older MCP clients keep working against the same daemon.
 
 package main
 
-import "encoding/json"
+import (
+	"encoding/json"
 
-const (
-	mcpProtocolVersionLatest = "2025-06-18"
-	mcpProtocolVersionLegacy = "2024-11-05"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/mcp"
 )
 
 // negotiateProtocolVersion returns the protocol version selected for initialize.
-// Supports latest and one legacy version for backward compatibility.
+// See mcp.NegotiateProtocolVersion for the selection rules.
 func negotiateProtocolVersion(rawParams json.RawMessage) string {
 	var params struct {
 		ProtocolVersion string `json:"protocolVersion"` // SPEC:MCP
@@ -19,11 +18,32 @@ func negotiateProtocolVersion(rawParams json.RawMessage) string {
 	if len(rawParams) > 0 {
 		_ = json.Unmarshal(rawParams, &params)
 	}
+	return mcp.NegotiateProtocolVersion(params.ProtocolVersion)
+}
 
-	switch params.ProtocolVersion {
-	case mcpProtocolVersionLatest, mcpProtocolVersionLegacy:
-		return params.ProtocolVersion
-	default:
-		return mcpProtocolVersionLatest
+// rememberProtocolVersion records the version negotiated by a client's initialize.
+func (h *MCPHandler) rememberProtocolVersion(clientID, version string) {
+	h.protocolMu.Lock()
+	defer h.protocolMu.Unlock()
+	if h.protocolVersions == nil {
+		h.protocolVersions = make(map[string]string)
+	}
+	h.protocolVersions[clientID] = version
+}
+
+// protocolVersionFor resolves the protocol version that shapes the response to req.
+//
+// Precedence: the MCP-Protocol-Version header carried on the request (HTTP clients on
+// 2025-06-18+, and the stdio bridge), then the version the same client ID negotiated at
+// initialize, then mcp.LatestProtocolVersion for clients that never initialized here.
+func (h *MCPHandler) protocolVersionFor(req JSONRPCRequest) string {
+	if mcp.IsSupportedProtocolVersion(req.ProtocolVersion) {
+		return req.ProtocolVersion
+	}
+	h.protocolMu.Lock()
+	defer h.protocolMu.Unlock()
+	if v, ok := h.protocolVersions[req.ClientID]; ok {
+		return v
 	}
+	return mcp.LatestProtocolVersion
 }
@@ -0,0 +1,120 @@
+// Purpose: Tests multi-version protocol negotiation, version-gated capabilities, and the per-version result shims.
+// Docs: docs/mcp-integration/index.md
+
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/mcp"
+)
+
+func TestNegotiateProtocolVersion_PicksClosestSupported(t *testing.T) {
+	t.Parallel()
+	cases := map[string]string{
+		"2025-06-18": "2025-06-18",
+		"2025-03-26": "2025-03-26",
+		"2024-11-05": "2024-11-05",
+		"2025-05-01": "2025-03-26", // between revisions: newest we support that is not newer
+		"2025-11-25": "2025-06-18", // newer than we know
+		"2023-01-01": "2025-06-18", // older than all: offer latest
+		"":           "2025-06-18",
+	}
+	for requested, want := range cases {
+		params := json.RawMessage(`{"protocolVersion":"` + requested + `"}`)
+		if got := negotiateProtocolVersion(params); got != want {
+			t.Errorf("negotiate(%q) = %q, want %q", requested, got, want)
+		}
+	}
+}
+
+func TestProtocolVersion_GatesCapabilitiesAndResultShapes(t *testing.T) {
+	h, _, _ := newStructuredTestHandler(t)
+	initialize := func(clientID, version string) MCPInitializeResult {
+		resp := h.HandleRequest(JSONRPCRequest{JSONRPC: "2.0", ID: 1, Method: "initialize", ClientID: clientID,
+			Params: json.RawMessage(`{"protocolVersion":"` + version + `"}`)})
+		return mustDecodeJSON[MCPInitializeResult](t, resp.Result)
+	}
+	toolsList := func(clientID string) map[string]MCPTool {
+		resp := h.HandleRequest(JSONRPCRequest{JSONRPC: "2.0", ID: 2, Method: "tools/list", ClientID: clientID})
+		out := map[string]MCPTool{}
+		for _, tool := range mustDecodeJSON[MCPToolsListResult](t, resp.Result).Tools {
+			out[tool.Name] = tool
+		}
+		return out
+	}
+
+	if caps := initialize("old", "2024-11-05").Capabilities; caps.Completions != nil {
+		t.Errorf("2024-11-05 capabilities advertise completions: %+v", caps)
+	}
+	if caps := initialize("mid", "2025-03-26").Capabilities; caps.Completions == nil {
+		t.Errorf("2025-03-26 capabilities missing completions: %+v", caps)
+	}
+	initialize("new", "2025-06-18")
+
+	old, mid, latest := toolsList("old")["observe"], toolsList("mid")["observe"], toolsList("new")["observe"]
+	if old.Annotations != nil || old.OutputSchema != nil {
+		t.Errorf("2024-11-05 observe tool = annotations %v, outputSchema set %v; want neither", old.Annotations, old.OutputSchema != nil)
+	}
+	if mid.Annotations == nil || !mid.Annotations.ReadOnlyHint || mid.OutputSchema != nil {
+		t.Errorf("2025-03-26 observe tool should have annotations and no outputSchema: %+v", mid)
+	}
+	if latest.Annotations == nil || latest.OutputSchema == nil {
+		t.Errorf("2025-06-18 observe tool should have annotations and outputSchema")
+	}
+
+	call := func(clientID, header string) MCPToolResult {
+		resp := h.HandleRequest(JSONRPCRequest{JSONRPC: "2.0", ID: 3, Method: "tools/call", ClientID: clientID, ProtocolVersion: header,
+			Params: json.RawMessage(`{"name":"observe","arguments":{"what":"errors"}}`)})
+		return mustDecodeJSON[MCPToolResult](t, resp.Result)
+	}
+	if r := call("old", ""); len(r.StructuredContent) != 0 {
+		t.Errorf("2024-11-05 tools/call carries structuredContent")
+	}
+	if r := call("new", ""); len(r.StructuredContent) == 0 {
+		t.Errorf("2025-06-18 tools/call missing structuredContent")
+	}
+	// The MCP-Protocol-Version header wins over the version stored for the client ID.
+	if r := call("new", mcp.ProtocolVersion20250326); len(r.StructuredContent) != 0 {
+		t.Errorf("header 2025-03-26 tools/call carries structuredContent")
+	}
+}
+
+func TestHandleHTTP_RejectsUnsupportedProtocolVersionHeader(t *testing.T) {
+	h, _, _ := newStructuredTestHandler(t)
+	req := httptest.NewRequest(http.MethodPost, "/mcp", strings.NewReader(`{"jsonrpc":"2.0","id":1,"method":"ping"}`))
+	req.Header.Set("MCP-Protocol-Version", "1999-01-01")
+	rec := httptest.NewRecorder()
+	h.HandleHTTP(rec, req)
+	if rec.Code != http.StatusBadRequest || !strings.Contains(rec.Body.String(), "2025-06-18") {
+		t.Fatalf("status = %d body = %s, want 400 listing supported versions", rec.Code, rec.Body.String())
+	}
+}
+
+func TestCompletionComplete_ResourceTemplateArguments(t *testing.T) {
+	h, _, _ := newStructuredTestHandler(t)
+	complete := func(params string) MCPCompleteResult {
+		resp := h.HandleRequest(JSONRPCRequest{JSONRPC: "2.0", ID: 1, Method: "completion/complete", Params: json.RawMessage(params)})
+		if resp == nil || resp.Error != nil {
+			t.Fatalf("completion/complete %s = %+v", params, resp)
+		}
+		return mustDecodeJSON[MCPCompleteResult](t, resp.Result)
+	}
+
+	got := complete(`{"ref":{"type":"ref/resource","uri":"kaboom://observe/{what}"},"argument":{"name":"what","value":"net"}}`)
+	if strings.Join(got.Completion.Values, ",") != "network_bodies,network_waterfall" || got.Completion.Total != 2 {
+		t.Errorf("observe completion = %+v", got.Completion)
+	}
+	got = complete(`{"ref":{"type":"ref/resource","uri":"kaboom://playbook/{capability}/{level}"},"argument":{"name":"level","value":""},"context":{"arguments":{"capability":"performance"}}}`)
+	if len(got.Completion.Values) == 0 || got.Completion.Values[0] == "" {
+		t.Errorf("playbook level completion = %+v", got.Completion)
+	}
+	got = complete(`{"ref":{"type":"ref/prompt","name":"x"},"argument":{"name":"a","value":""}}`)
+	if got.Completion.Values == nil || len(got.Completion.Values) != 0 {
+		t.Errorf("prompt completion = %+v, want empty values array", got.Completion)
+	}
+}
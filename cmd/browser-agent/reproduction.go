@@ -30,7 +30,17 @@ func (h *ToolHandler) toolGetReproductionScript(req JSONRPCRequest, args json.Ra
 		return fail(req, ErrInvalidParam, err, "Use 'kaboom' or 'playwright'", withParam("output_format"))
 	}
 
-	allActions := h.capture.GetAllEnhancedActions()
+	if env, _, ok := h.capture.GetBrowserEnvironment(); ok {
+		params.Environment = &env
+	}
+	if device, ok := h.capture.GetDeviceEmulation(); ok {
+		params.Device = &device
+	}
+	if region, ok := h.capture.GetRegionOverride(); ok {
+		params.Region = &region
+	}
+
+	allActions := reproduction.FilterBoundary(h.capture.GetAllEnhancedActions(), params.Boundary)
 	actions := reproduction.FilterLastN(allActions, params.LastN)
 
 	script := reproduction.GenerateScript(actions, params)
@@ -0,0 +1,110 @@
+// Purpose: Implements --serve-bundle, which serves MCP over stdio in read-only mode against an imported session bundle.
+// Why: Lets an agent investigate a past incident with observe/analyze/generate without a browser or extension.
+// Docs: docs/features/feature/read-only-mode/index.md
+
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/sessionbundle"
+)
+
+// maxServeBundleLineBytes bounds a single JSON-RPC request line read from stdin.
+const maxServeBundleLineBytes = 10 << 20
+
+// runServeBundleMode loads the bundle and serves MCP over stdin/stdout until EOF.
+// Returns the process exit code.
+func runServeBundleMode(path string, maxEntries int, in io.Reader, out io.Writer) int {
+	bundle, err := sessionbundle.Load(path)
+	if err != nil {
+		stderrf("[Kaboom] Cannot load session bundle %s: %v\n", path, err)
+		return 1
+	}
+	handler, err := newBundleMCPHandler(bundle, path, maxEntries)
+	if err != nil {
+		stderrf("[Kaboom] Cannot serve session bundle %s: %v\n", path, err)
+		return 1
+	}
+	stderrf("[Kaboom] Serving session bundle %s (created %s) in read-only mode\n", path, bundle.CreatedAt.Format(time.RFC3339))
+	if err := serveStdioMCP(handler, in, out); err != nil {
+		stderrf("[Kaboom] stdio error: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// newBundleMCPHandler builds an in-memory server whose buffers hold the bundle contents.
+// No log file, settings, or extension connection is used.
+func newBundleMCPHandler(bundle sessionbundle.Bundle, path string, maxEntries int) (*MCPHandler, error) {
+	server, err := NewServer("", max(maxEntries, len(bundle.Logs)))
+	if err != nil {
+		return nil, err
+	}
+	if len(bundle.Logs) > 0 {
+		server.logs.addEntries(bundle.Logs)
+	}
+
+	// Restore before the tool handler registers capture callbacks so replayed
+	// telemetry is not persisted (vitals history, noise learning) as if it were live.
+	cap := capture.NewCapture()
+	cap.SetServerVersion(version)
+	bundle.Restore(cap)
+
+	mcpHandler := NewToolHandler(server, cap)
+	th := mcpHandler.toolHandler.(*ToolHandler)
+	th.readOnly = &readOnlyState{
+		Reason:          "serving archived session bundle",
+		BundlePath:      path,
+		BundleCreatedAt: bundle.CreatedAt.Format(time.RFC3339),
+		BundleLabel:     bundle.Label,
+	}
+	// There is no extension to wait for; tools that need one fail fast.
+	th.extensionReadinessTimeout = time.Millisecond
+
+	// Replay network telemetry through the transport monitor so its alerts reflect the bundle.
+	th.recordTransportActivity(capture.NetworkActivity{
+		PageURL:   bundle.PageURL,
+		Bodies:    bundle.NetworkBodies,
+		Waterfall: bundle.NetworkWaterfall,
+		WebSocket: bundle.WebSocketEvents,
+	})
+	return mcpHandler, nil
+}
+
+// serveStdioMCP reads newline-delimited JSON-RPC requests from in and writes responses to out.
+// Notifications produce no output.
+func serveStdioMCP(handler *MCPHandler, in io.Reader, out io.Writer) error {
+	scanner := bufio.NewScanner(in)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxServeBundleLineBytes)
+	enc := json.NewEncoder(out)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		var req JSONRPCRequest
+		if err := json.Unmarshal(line, &req); err != nil {
+			if encErr := enc.Encode(JSONRPCResponse{
+				JSONRPC: JSONRPCVersion,
+				Error:   &JSONRPCError{Code: -32700, Message: "Parse error: " + err.Error()},
+			}); encErr != nil {
+				return fmt.Errorf("write response: %w", encErr)
+			}
+			continue
+		}
+		resp := handler.HandleRequest(req)
+		if resp == nil {
+			continue
+		}
+		if err := enc.Encode(resp); err != nil {
+			return fmt.Errorf("write response: %w", err)
+		}
+	}
+	return scanner.Err()
+}
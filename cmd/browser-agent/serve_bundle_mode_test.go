@@ -0,0 +1,126 @@
+// Purpose: Tests session bundle export via generate and read-only serving via --serve-bundle.
+// Docs: docs/features/feature/read-only-mode/index.md
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/sessionbundle"
+)
+
+// exportTestBundle captures some telemetry and exports it through generate(session_bundle).
+func exportTestBundle(t *testing.T) string {
+	t.Helper()
+	h, server, cap := makeToolHandler(t)
+	server.logs.addEntries([]LogEntry{{"level": "error", "message": "checkout failed"}})
+	cap.AddNetworkBodies([]capture.NetworkBody{{Method: "POST", URL: "https://shop.test/api/checkout", Status: 502}})
+	cap.AddNetworkWaterfallEntries([]capture.NetworkWaterfallEntry{
+		{URL: "http://cdn.test/pay.js", InitiatorType: "script"},
+	}, "https://shop.test/")
+
+	path := filepath.Join(t.TempDir(), "incident.json.gz")
+	result := parseToolResult(t, callGenerateRaw(h, `{"what":"session_bundle","label":"checkout 502","save_to":"`+path+`"}`))
+	if result.IsError {
+		t.Fatalf("session_bundle export failed: %s", firstText(result))
+	}
+	data := extractResultJSON(t, result)
+	if data["saved_to"] != path {
+		t.Fatalf("saved_to = %v, want %s", data["saved_to"], path)
+	}
+	counts := data["counts"].(map[string]any)
+	if counts["network_bodies"] != float64(1) || counts["logs"] != float64(1) {
+		t.Fatalf("counts = %v, want one network body and one log", counts)
+	}
+	return path
+}
+
+func TestGenerateSessionBundle_RejectsTraversal(t *testing.T) {
+	t.Parallel()
+	h, _, _ := makeToolHandler(t)
+	result := parseToolResult(t, callGenerateRaw(h, `{"what":"session_bundle","save_to":"../escape.json"}`))
+	if !result.IsError {
+		t.Fatal("save_to with '..' should be rejected")
+	}
+}
+
+func TestServeBundle_ObserveWorksAndMutationsAreBlocked(t *testing.T) {
+	t.Parallel()
+	path := exportTestBundle(t)
+	bundle, err := sessionbundle.Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	mcpHandler, err := newBundleMCPHandler(bundle, path, 100)
+	if err != nil {
+		t.Fatalf("newBundleMCPHandler: %v", err)
+	}
+	h := mcpHandler.toolHandler.(*ToolHandler)
+
+	bodies := extractResultJSON(t, parseToolResult(t, callToolRaw(h, "observe", `{"what":"network_bodies"}`)))
+	if got := len(bodies["entries"].([]any)); got != 1 {
+		t.Fatalf("network_bodies entries = %d, want 1 from bundle", got)
+	}
+	transport := extractResultJSON(t, parseToolResult(t, callToolRaw(h, "observe", `{"what":"transport_security"}`)))
+	if transport["status"] != "violations" {
+		t.Fatalf("transport_security status = %v, want replayed violation", transport["status"])
+	}
+
+	for _, call := range []struct{ tool, args string }{
+		{"interact", `{"what":"click","selector":"#buy"}`},
+		{"configure", `{"what":"clear","buffer":"all"}`},
+		{"configure", `{"action":"store","store_action":"save","key":"k","data":{}}`},
+	} {
+		result := parseToolResult(t, callToolRaw(h, call.tool, call.args))
+		if !result.IsError || !strings.Contains(firstText(result), ErrReadOnlyMode) {
+			t.Errorf("%s %s: want %s error, got %s", call.tool, call.args, ErrReadOnlyMode, firstText(result))
+		}
+	}
+
+	health := extractResultJSON(t, parseToolResult(t, callToolRaw(h, "configure", `{"what":"health"}`)))
+	readOnly, _ := health["read_only"].(map[string]any)
+	if readOnly["enabled"] != true || readOnly["bundle_label"] != "checkout 502" {
+		t.Fatalf("health read_only = %v, want enabled with bundle label", health["read_only"])
+	}
+}
+
+func TestServeStdioMCP_HandlesRequestsAndParseErrors(t *testing.T) {
+	t.Parallel()
+	path := exportTestBundle(t)
+	in := strings.NewReader(strings.Join([]string{
+		`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{}}`,
+		`{"jsonrpc":"2.0","method":"notifications/initialized"}`,
+		`not json`,
+		`{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"observe","arguments":{"what":"errors"}}}`,
+	}, "\n"))
+	var out bytes.Buffer
+	if code := runServeBundleMode(path, 100, in, &out); code != 0 {
+		t.Fatalf("exit code = %d, want 0", code)
+	}
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("got %d response lines, want 3 (notification is silent):\n%s", len(lines), out.String())
+	}
+	var parseErr JSONRPCResponse
+	if err := json.Unmarshal([]byte(lines[1]), &parseErr); err != nil || parseErr.Error == nil || parseErr.Error.Code != -32700 {
+		t.Fatalf("second response = %s, want -32700 parse error", lines[1])
+	}
+	var call JSONRPCResponse
+	if err := json.Unmarshal([]byte(lines[2]), &call); err != nil || call.Error != nil {
+		t.Fatalf("tools/call response = %s, want success", lines[2])
+	}
+}
+
+func TestRunServeBundleMode_MissingBundle(t *testing.T) {
+	t.Parallel()
+	var out bytes.Buffer
+	if code := runServeBundleMode(filepath.Join(t.TempDir(), "missing.json"), 100, strings.NewReader(""), &out); code != 1 {
+		t.Fatalf("exit code = %d, want 1", code)
+	}
+}
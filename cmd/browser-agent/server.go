@@ -45,6 +45,9 @@ type Server struct {
 	// Terminal server port (0 = terminal server not running)
 	terminalPort int
 
+	// LSP diagnostics socket port (0 = feed not running)
+	lspPort int
+
 	// Active codebase path — set via MCP configure(what='store', key='active_codebase')
 	// or via the extension options page. Used as default CWD for terminal sessions.
 	activeCodebaseMu sync.RWMutex
@@ -53,6 +56,9 @@ type Server struct {
 	// Token savings tracker for output compression hooks.
 	tokenTracker *tracking.TokenTracker
 
+	// Rolling per-tool latency breakdowns, reported as /diagnostics tool_latency.
+	toolTimings *toolTimingStats
+
 	// Push drain authentication token. When non-empty, /push/drain requires
 	// Authorization: Bearer <token>. Set via --push-drain-token flag.
 	pushDrainToken string
@@ -71,6 +77,7 @@ func NewServer(logFile string, maxEntries int) (*Server, error) {
 		pushInbox:             push.NewPushInbox(50),
 		ptyManager:            pty.NewManager(),
 		tokenTracker:          tracking.NewTokenTracker(),
+		toolTimings:           newToolTimingStats(),
 		intentStore:           terminal.NewIntentStore(),
 		screenshotRateLimiter: make(map[string]time.Time),
 	}
@@ -183,6 +190,20 @@ func (s *Server) getTerminalPort() int {
 	return s.terminalPort
 }
 
+// setLSPPort stores the port the LSP diagnostics feed is listening on.
+func (s *Server) setLSPPort(port int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lspPort = port
+}
+
+// getLSPPort returns the LSP diagnostics port (0 if not running).
+func (s *Server) getLSPPort() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.lspPort
+}
+
 // GetActiveCodebase returns the active codebase path (thread-safe).
 func (s *Server) GetActiveCodebase() string {
 	s.activeCodebaseMu.RLock()
@@ -208,3 +229,8 @@ func (s *Server) Close() {
 func (s *Server) SetOnEntries(cb func([]LogEntry)) {
 	s.logs.SetOnEntries(cb)
 }
+
+// SetTestIDSource sets the source of active test boundary IDs stamped on new log entries.
+func (s *Server) SetTestIDSource(fn func() []string) {
+	s.logs.SetTestIDSource(fn)
+}
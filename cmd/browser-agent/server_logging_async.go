@@ -14,6 +14,7 @@ import (
 // addEntries adds new entries and rotates if needed.
 // #lizard forgives
 func (ls *LogStore) addEntries(newEntries []LogEntry) int {
+	ls.tagTestIDs(newEntries)
 	rotated, entriesToSave, appendOnly, cb := ls.addEntriesInMemory(newEntries)
 
 	// File I/O outside lock — snapshot protects consistency
@@ -40,12 +41,31 @@ func (ls *LogStore) addEntries(newEntries []LogEntry) int {
 	return len(newEntries)
 }
 
+// tagTestIDs stamps the active test boundary IDs on entries. The source is read before
+// ls.mu is taken so the capture lock is never acquired under the log lock.
+func (ls *LogStore) tagTestIDs(entries []LogEntry) {
+	ls.mu.RLock()
+	source := ls.testIDSource
+	ls.mu.RUnlock()
+	if source == nil {
+		return
+	}
+	ids := source()
+	if len(ids) == 0 {
+		return
+	}
+	for _, entry := range entries {
+		if entry != nil {
+			entry["test_ids"] = ids
+		}
+	}
+}
+
 // addEntriesInMemory mutates log state under lock and returns snapshots for I/O outside the lock.
 func (ls *LogStore) addEntriesInMemory(newEntries []LogEntry) (rotated bool, entriesToSave []LogEntry, appendOnly []LogEntry, cb func([]LogEntry)) {
 	ls.mu.Lock()
 	defer ls.mu.Unlock()
 
-	ls.logTotalAdded += int64(len(newEntries))
 	for _, entry := range newEntries {
 		level, ok := entry["level"].(string)
 		if ok && level == "error" {
@@ -53,30 +73,28 @@ func (ls *LogStore) addEntriesInMemory(newEntries []LogEntry) (rotated bool, ent
 		}
 	}
 
+	// Append entries, folding consecutive duplicates into the newest stored one.
+	// A run's final count is written to the file when the run ends.
 	now := time.Now()
-	for range newEntries {
+	stored := make([]LogEntry, 0, len(newEntries))
+	for _, entry := range newEntries {
+		if ls.tryCollapseLocked(entry, now) {
+			continue
+		}
+		if tail := ls.takeDedupTailLocked(); tail != nil {
+			stored = append(stored, tail)
+		}
+		ls.logTotalAdded++
 		ls.logAddedAt = append(ls.logAddedAt, now)
+		ls.entries = append(ls.entries, entry)
+		stored = append(stored, entry)
 	}
-	ls.entries = append(ls.entries, newEntries...)
 
-	// Rotate if needed — copy to new slice to allow GC of evicted entries
-	rotated = len(ls.entries) > ls.maxEntries
-	if rotated {
-		kept := make([]LogEntry, ls.maxEntries)
-		copy(kept, ls.entries[len(ls.entries)-ls.maxEntries:])
-		ls.entries = kept
-		keptAt := make([]time.Time, ls.maxEntries)
-		copy(keptAt, ls.logAddedAt[len(ls.logAddedAt)-ls.maxEntries:])
-		ls.logAddedAt = keptAt
-	}
-
-	// Snapshot data for file I/O outside the lock
-	if rotated {
-		entriesToSave = make([]LogEntry, len(ls.entries))
-		copy(entriesToSave, ls.entries)
-	} else {
-		appendOnly = make([]LogEntry, len(newEntries))
-		copy(appendOnly, newEntries)
+	// Rotate (and expire past the TTL) if needed, snapshotting data for file I/O outside the lock
+	entriesToSave = ls.trimAndSnapshotLocked(now)
+	rotated = entriesToSave != nil
+	if !rotated {
+		appendOnly = stored
 	}
 	cb = ls.onEntries
 	return rotated, entriesToSave, appendOnly, cb
@@ -192,11 +210,15 @@ func (ls *LogStore) clearEntriesInMemory() {
 	defer ls.mu.Unlock()
 	ls.entries = nil
 	ls.logAddedAt = nil
+	ls.dedupTailPending = false
 }
 
 // shutdownAsyncLogger gracefully shuts down the async logger, draining remaining logs.
 // Safe to call multiple times (e.g., from both Server.Close and awaitShutdownSignal).
 func (ls *LogStore) shutdownAsyncLogger(timeout time.Duration) {
+	// Persist the final count of a duplicate run still open at shutdown.
+	ls.flushDedupTail()
+
 	// Guard against double-close panic: only the first caller closes the channel.
 	if !ls.logChanClosed.CompareAndSwap(false, true) {
 		return
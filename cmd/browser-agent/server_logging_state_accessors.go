@@ -17,6 +17,13 @@ func (ls *LogStore) getEntryCount() int {
 	return len(ls.entries)
 }
 
+// getLogTotalAdded returns the total number of log entries ever added.
+func (ls *LogStore) getLogTotalAdded() int64 {
+	ls.mu.RLock()
+	defer ls.mu.RUnlock()
+	return ls.logTotalAdded
+}
+
 // getErrorTotalAdded returns the total number of error-level log entries ever added.
 func (ls *LogStore) getErrorTotalAdded() int64 {
 	ls.mu.RLock()
@@ -102,17 +102,19 @@ func isAllowedHost(host string) bool {
 //  1. Host header validation — rejects requests where Host is not a localhost variant.
 //  2. Origin validation — rejects requests from non-local, non-extension origins.
 //  3. CORS origin echo — returns the specific allowed origin, never wildcard "*".
+//
+// Requests on the --remote-listen TLS listener may also name a configured --remote-host.
 func corsMiddleware(next http.HandlerFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		// Layer 1: Validate Host header (DNS rebinding protection)
-		if !isAllowedHost(r.Host) {
+		if !isAllowedHost(r.Host) && !remoteHostAllowed(r) {
 			http.Error(w, "Invalid Host header", http.StatusForbidden)
 			return
 		}
 
 		// Layer 2: Validate Origin header — if present and invalid, reject with 403
 		origin := r.Header.Get("Origin")
-		if origin != "" && !isAllowedOrigin(origin) {
+		if origin != "" && !isAllowedOrigin(origin) && !remoteOriginAllowed(r, origin) {
 			http.Error(w, `{"error":"forbidden: invalid origin"}`, http.StatusForbidden)
 			return
 		}
@@ -144,10 +146,7 @@ func corsMiddleware(next http.HandlerFunc) http.HandlerFunc {
 // browser extension can call extension-facing endpoints.
 func extensionOnly(next http.HandlerFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		client := r.Header.Get("X-Kaboom-Client")
-		if client != "kaboom-extension" &&
-			client != "kaboom-extension-offscreen" &&
-			!strings.HasPrefix(client, "kaboom-extension/") {
+		if !isExtensionClientHeader(r.Header.Get("X-Kaboom-Client")) {
 			http.Error(w, `{"error":"forbidden: missing or invalid X-Kaboom-Client header"}`, http.StatusForbidden)
 			return
 		}
@@ -155,4 +154,11 @@ func extensionOnly(next http.HandlerFunc) http.HandlerFunc {
 	}
 }
 
+// isExtensionClientHeader reports whether an X-Kaboom-Client value identifies the extension.
+func isExtensionClientHeader(client string) bool {
+	return client == "kaboom-extension" ||
+		client == "kaboom-extension-offscreen" ||
+		strings.HasPrefix(client, "kaboom-extension/")
+}
+
 // Note: jsonResponse is defined in handler.go
@@ -32,6 +32,11 @@ func (ls *LogStore) loadEntries() error {
 		if err := json.Unmarshal([]byte(line), &entry); err != nil {
 			continue // Skip malformed lines
 		}
+		// A collapsed duplicate run is written after its first occurrence; keep the final count.
+		if n := len(ls.entries); n > 0 && entry["count"] != nil && sameLogEntry(ls.entries[n-1], entry) {
+			ls.entries[n-1] = entry
+			continue
+		}
 		ls.entries = append(ls.entries, entry)
 	}
 
@@ -34,15 +34,20 @@ func registerCaptureRoutes(mux *http.ServeMux, server *Server, cap *capture.Stor
 	// NOT MCP — Extension telemetry ingestion (extension → daemon data pipeline)
 	mux.HandleFunc("/websocket-events", corsMiddleware(extensionOnly(cap.HandleWebSocketEvents)))
 	mux.HandleFunc("/websocket-status", corsMiddleware(extensionOnly(cap.HandleWebSocketStatus)))
+	mux.HandleFunc("/sse-events", corsMiddleware(extensionOnly(cap.HandleSSEEvents)))
 	mux.HandleFunc("/network-bodies", corsMiddleware(extensionOnly(cap.HandleNetworkBodies)))
 	mux.HandleFunc("/network-waterfall", corsMiddleware(extensionOnly(cap.HandleNetworkWaterfall)))
 	mux.HandleFunc("/query-result", corsMiddleware(extensionOnly(cap.HandleQueryResult)))
 	mux.HandleFunc("/enhanced-actions", corsMiddleware(extensionOnly(cap.HandleEnhancedActions)))
 	mux.HandleFunc("/performance-snapshots", corsMiddleware(extensionOnly(cap.HandlePerformanceSnapshots)))
+	mux.HandleFunc("/coverage-snapshots", corsMiddleware(extensionOnly(handleCoverageSnapshots(cap))))
 
 	// NOT MCP — Unified sync endpoint (extension polls this instead of individual routes above)
 	mux.HandleFunc("/sync", corsMiddleware(extensionOnly(cap.HandleSync)))
 
+	// NOT MCP — Push transport for the same protocol; the extension falls back to /sync when it cannot connect
+	mux.HandleFunc("/extension-ws", corsMiddleware(handleExtensionWS(cap)))
+
 	// NOT MCP — Multi-client registry (extension bookkeeping, not AI-facing)
 	registerClientRegistryRoutes(mux, cap)
 
@@ -64,6 +69,15 @@ func registerCaptureRoutes(mux *http.ServeMux, server *Server, cap *capture.Stor
 	mux.HandleFunc("/snapshot", corsMiddleware(extensionOnly(handleSnapshot(server, cap))))
 	mux.HandleFunc("/clear", corsMiddleware(extensionOnly(handleClear(server, cap))))
 	mux.HandleFunc("/test-boundary", corsMiddleware(extensionOnly(handleTestBoundary(cap))))
+
+	// NOT MCP — Test-runner reporter ingestion (Vitest/Jest/Playwright run in Node, not the extension)
+	mux.HandleFunc("/test-events", corsMiddleware(handleTestEvents(cap)))
+
+	// NOT MCP — Dev-server rebuild/HMR ingestion (plugins run in the dev server, not the extension)
+	mux.HandleFunc("/build-events", corsMiddleware(handleBuildEvents(cap)))
+
+	// NOT MCP — Source edit notifications (kaboom watch and editor hooks run beside the dev server, not the extension)
+	mux.HandleFunc("/file-changes", corsMiddleware(handleFileChanges(cap)))
 }
 
 // registerUploadRoutes adds upload automation endpoints to the mux.
@@ -103,6 +117,10 @@ func registerCoreRoutes(mux *http.ServeMux, server *Server, cap *capture.Store)
 	mcp := NewToolHandler(server, cap)
 	mux.HandleFunc("/mcp", corsMiddleware(mcp.HandleHTTP))
 
+	// NOT MCP — Versioned Connect RPC mirror of the tool surface (kaboom.v1.ToolService).
+	// Dispatches through the same MCP handler, so auth and read-only policy are identical.
+	mux.HandleFunc("/kaboom.v1.ToolService/", corsMiddleware(handleToolServiceRPC(mcp)))
+
 	// NOT MCP — Dashboard status API (JSON feed for the HTML dashboard)
 	mux.HandleFunc("/api/status", corsMiddleware(handleStatusAPI(server, cap, mcp)))
 
@@ -151,7 +169,7 @@ func registerCoreRoutes(mux *http.ServeMux, server *Server, cap *capture.Store)
 
 	// NOT MCP — Log ingestion from extension (MCP reads logs via observe(what: "logs"))
 	mux.HandleFunc("/logs", corsMiddleware(extensionOnly(func(w http.ResponseWriter, r *http.Request) {
-		server.handleLogs(w, r)
+		server.handleLogs(w, r, cap)
 	})))
 
 	// NOT MCP — HTML pages for human navigation
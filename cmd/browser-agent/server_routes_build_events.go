@@ -0,0 +1,51 @@
+// Purpose: Implements the /build-events ingest endpoint for dev-server rebuild and HMR events.
+// Why: Lets a small Vite/Next.js/webpack plugin report rebuilds so the timeline can separate stale-bundle errors from regressions.
+// Docs: docs/features/feature/build-events/index.md
+
+package main
+
+import (
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/buildevents"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+)
+
+// maxBuildEventsBodySize bounds one POST /build-events batch (500 events with stacks).
+const maxBuildEventsBodySize = 4 << 20
+
+// handleBuildEvents returns an HTTP handler for POST /build-events.
+// Accepts one event, an array, or {"events": [...]}. Dev servers whose HMR socket is
+// captured by the extension are detected automatically and do not need to post.
+func handleBuildEvents(cap *capture.Store) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			jsonResponse(w, http.StatusMethodNotAllowed, map[string]string{"error": "Method not allowed"})
+			return
+		}
+
+		body, err := io.ReadAll(io.LimitReader(r.Body, maxBuildEventsBodySize+1))
+		if err != nil {
+			jsonResponse(w, http.StatusBadRequest, map[string]string{"error": "Failed to read body"})
+			return
+		}
+		if len(body) > maxBuildEventsBodySize {
+			jsonResponse(w, http.StatusRequestEntityTooLarge, map[string]string{"error": "Batch too large; send fewer events per request"})
+			return
+		}
+
+		events, err := buildevents.Parse(body, time.Now())
+		if err != nil {
+			jsonResponse(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+			return
+		}
+
+		store := cap.BuildEvents()
+		jsonResponse(w, http.StatusOK, map[string]any{
+			"accepted": store.Ingest(events),
+			"state":    store.State(),
+		})
+	}
+}
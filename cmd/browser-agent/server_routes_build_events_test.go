@@ -0,0 +1,91 @@
+// Purpose: Tests POST /build-events ingestion, HMR socket detection, and bundle labels in the timeline.
+// Docs: docs/features/feature/build-events/index.md
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+)
+
+func TestBuildEvents_LabelsStaleErrorsInTimeline(t *testing.T) {
+	t.Parallel()
+	h, server, cap := makeToolHandler(t)
+	now := time.Now()
+	ts := func(offset time.Duration) string { return now.Add(offset).UTC().Format(time.RFC3339Nano) }
+
+	// Error thrown by the old bundle, then a plugin-reported rebuild, then a fresh error.
+	server.logs.addEntries([]LogEntry{{"level": "error", "message": "TypeError: total is undefined", "ts": ts(-30 * time.Second)}})
+	rr := httptest.NewRecorder()
+	handleBuildEvents(cap).ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/build-events", bytes.NewBufferString(
+		`[{"type":"build_start","tool":"vite","timestamp":"`+ts(-20*time.Second)+`"},{"type":"build_end","tool":"vite","duration_ms":180,"timestamp":"`+ts(-19*time.Second)+`"}]`)))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("POST /build-events = %d %s", rr.Code, rr.Body.String())
+	}
+	server.logs.addEntries([]LogEntry{{"level": "error", "message": "RangeError: invalid quantity", "ts": ts(-10 * time.Second)}})
+
+	// A failed rebuild seen on the captured Vite HMR socket.
+	cap.AddWebSocketEvents([]capture.WebSocketEvent{{
+		Event: "message", Direction: "incoming", URL: "ws://localhost:5173/?token=t", Timestamp: ts(-5 * time.Second),
+		Data: `{"type":"error","err":{"message":"Transform failed","id":"/src/cart.ts"}}`,
+	}})
+	server.logs.addEntries([]LogEntry{{"level": "error", "message": "RangeError: invalid quantity", "ts": ts(-2 * time.Second)}})
+
+	result := parseToolResult(t, h.toolObserve(JSONRPCRequest{JSONRPC: "2.0", ID: 1}, json.RawMessage(`{"what":"timeline","include":["errors","builds"]}`)))
+	if result.IsError {
+		t.Fatalf("timeline failed: %s", firstText(result))
+	}
+	data := extractResultJSON(t, result)
+	bundles := map[string]string{}
+	builds := 0
+	for _, raw := range data["entries"].([]any) {
+		entry := raw.(map[string]any)
+		switch entry["type"] {
+		case "build":
+			builds++
+		case "error":
+			bundles[entry["timestamp"].(string)[:19]], _ = entry["bundle"].(string)
+		}
+	}
+	if builds != 3 {
+		t.Errorf("build entries = %d, want 3", builds)
+	}
+	local := func(offset time.Duration) string { return now.Add(offset).Format(time.RFC3339)[:19] }
+	want := map[string]string{
+		local(-30 * time.Second): "stale",
+		local(-10 * time.Second): "current",
+		local(-2 * time.Second):  "build_failed",
+	}
+	for at, label := range want {
+		if bundles[at] != label {
+			t.Errorf("error at %s bundle = %q, want %q (all: %v)", at, bundles[at], label, bundles)
+		}
+	}
+	state := data["builds"].(map[string]any)
+	if state["builds"] != float64(2) || state["last_status"] != "failed" || data["build_hint"] == nil {
+		t.Errorf("build context = %v, hint = %v", state, data["build_hint"])
+	}
+}
+
+func TestBuildEvents_RejectsInvalidPayloads(t *testing.T) {
+	t.Parallel()
+	handler := handleBuildEvents(capture.NewCapture())
+	for _, body := range []string{`not json`, `{"type":"lint"}`} {
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/build-events", bytes.NewBufferString(body)))
+		if rr.Code != http.StatusBadRequest {
+			t.Errorf("POST %s status = %d, want 400", body, rr.Code)
+		}
+	}
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/build-events", nil))
+	if rr.Code != http.StatusMethodNotAllowed {
+		t.Errorf("GET status = %d, want 405", rr.Code)
+	}
+}
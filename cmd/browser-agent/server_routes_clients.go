@@ -7,8 +7,11 @@ import (
 	"encoding/json"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/session"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/types"
 )
 
 func resolveClientRegistry(cap *capture.Store, w http.ResponseWriter) (capture.ClientRegistry, bool) {
@@ -49,13 +52,20 @@ func handleClientsList(w http.ResponseWriter, r *http.Request, cap *capture.Stor
 	case "POST":
 		r.Body = http.MaxBytesReader(w, r.Body, maxPostBodySize)
 		var body struct {
-			CWD string `json:"cwd"`
+			CWD           string               `json:"cwd"`
+			SourceControl *types.SourceControl `json:"source_control,omitempty"`
 		}
 		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
 			jsonResponse(w, http.StatusBadRequest, map[string]string{"error": "Invalid JSON"})
 			return
 		}
 		cs := reg.Register(body.CWD)
+		if sc := body.SourceControl; sc != nil && sc.Commit != "" {
+			if sc.CapturedAt.IsZero() {
+				sc.CapturedAt = time.Now().UTC()
+			}
+			reg.SetSourceControl(session.DeriveClientID(body.CWD), *sc)
+		}
 		jsonResponse(w, http.StatusOK, map[string]any{
 			"result": cs,
 		})
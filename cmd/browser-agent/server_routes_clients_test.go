@@ -14,6 +14,7 @@ import (
 	"testing"
 
 	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/types"
 )
 
 // mockClientRegistry implements capture.ClientRegistry for testing.
@@ -71,6 +72,26 @@ func (m *mockClientRegistry) Get(id string) any {
 	return nil
 }
 
+func (m *mockClientRegistry) SetSourceControl(id string, sc types.SourceControl) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	c, ok := m.clients[id]
+	if !ok {
+		return false
+	}
+	c["source_control"] = &sc
+	return true
+}
+
+func (m *mockClientRegistry) SourceControl(id string) *types.SourceControl {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if sc, ok := m.clients[id]["source_control"].(*types.SourceControl); ok {
+		return sc
+	}
+	return nil
+}
+
 func (m *mockClientRegistry) Unregister(id string) bool {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -0,0 +1,51 @@
+// Purpose: Implements the /coverage-snapshots ingest endpoint for JS/CSS coverage collected by the extension.
+// Why: Aggregating used byte ranges server-side lets coverage from many page loads answer "which bundles are mostly dead weight?".
+// Docs: docs/features/feature/code-coverage/index.md
+
+package main
+
+import (
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/coverage"
+)
+
+// maxCoverageBodySize bounds one POST /coverage-snapshots batch (used ranges for large bundles add up).
+const maxCoverageBodySize = 16 << 20
+
+// handleCoverageSnapshots returns an HTTP handler for POST /coverage-snapshots.
+// Accepts {"snapshots": [...]} or a single snapshot; reads go through observe(what="coverage").
+func handleCoverageSnapshots(cap *capture.Store) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			jsonResponse(w, http.StatusMethodNotAllowed, map[string]string{"error": "Method not allowed"})
+			return
+		}
+
+		body, err := io.ReadAll(io.LimitReader(r.Body, maxCoverageBodySize+1))
+		if err != nil {
+			jsonResponse(w, http.StatusBadRequest, map[string]string{"error": "Failed to read body"})
+			return
+		}
+		if len(body) > maxCoverageBodySize {
+			jsonResponse(w, http.StatusRequestEntityTooLarge, map[string]string{"error": "Snapshot too large; send fewer resources per request"})
+			return
+		}
+
+		snapshots, err := coverage.Parse(body, time.Now())
+		if err != nil {
+			jsonResponse(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+			return
+		}
+
+		store := cap.Coverage()
+		jsonResponse(w, http.StatusOK, map[string]any{
+			"status":  "ok",
+			"count":   store.Ingest(snapshots),
+			"summary": store.Summary(),
+		})
+	}
+}
@@ -0,0 +1,125 @@
+// Purpose: Tests POST /coverage-snapshots ingestion, observe(what="coverage"), and generate(what="coverage_report").
+// Docs: docs/features/feature/code-coverage/index.md
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+)
+
+func postCoverage(t *testing.T, cap *capture.Store, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	rr := httptest.NewRecorder()
+	handleCoverageSnapshots(cap).ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/coverage-snapshots", bytes.NewBufferString(body)))
+	return rr
+}
+
+func TestCoverage_AggregatesAcrossPagesAndReports(t *testing.T) {
+	t.Parallel()
+	h, _, cap := makeToolHandler(t)
+
+	for _, body := range []string{
+		`{"snapshots":[{"page_url":"https://shop.test/","reloaded":true,"resources":[
+			{"url":"https://shop.test/vendor.js","type":"js","total_bytes":409600,"ranges":[[0,51200]]},
+			{"url":"https://shop.test/site.css","type":"css","total_bytes":20480,"used_bytes":18432}]}]}`,
+		`{"page_url":"https://shop.test/cart","resources":[
+			{"url":"https://shop.test/vendor.js","type":"js","total_bytes":409600,"ranges":[[40960,102400]]}]}`,
+	} {
+		if rr := postCoverage(t, cap, body); rr.Code != http.StatusOK {
+			t.Fatalf("POST /coverage-snapshots = %d %s", rr.Code, rr.Body.String())
+		}
+	}
+
+	result := parseToolResult(t, h.toolObserve(JSONRPCRequest{JSONRPC: "2.0", ID: 1}, json.RawMessage(`{"what":"coverage"}`)))
+	if result.IsError {
+		t.Fatalf("observe coverage failed: %s", firstText(result))
+	}
+	data := extractResultJSON(t, result)
+	resources := data["resources"].([]any)
+	vendor := resources[0].(map[string]any)
+	if data["status"] != "ok" || len(resources) != 2 || vendor["url"] != "https://shop.test/vendor.js" || vendor["used_bytes"] != float64(102400) {
+		t.Fatalf("coverage = %v", data)
+	}
+	if summary := data["summary"].(map[string]any); summary["snapshots"] != float64(2) || summary["unused_bytes"] != float64(307200+2048) {
+		t.Errorf("summary = %v", summary)
+	}
+
+	cap.AddNetworkWaterfallEntries([]capture.NetworkWaterfallEntry{{URL: "https://shop.test/vendor.js", TransferSize: 120000, Duration: 310}}, "https://shop.test/")
+	report := parseToolResult(t, h.toolGenerate(JSONRPCRequest{JSONRPC: "2.0", ID: 2}, json.RawMessage(`{"what":"coverage_report"}`)))
+	if report.IsError {
+		t.Fatalf("coverage_report failed: %s", firstText(report))
+	}
+	rdata := extractResultJSON(t, report)
+	markdown, _ := rdata["report"].(string)
+	if rdata["significant"] != float64(1) || rdata["wasted_transfer_bytes"] != float64(90000) {
+		t.Errorf("report = %v", rdata)
+	}
+	if !strings.Contains(markdown, "| 1 | `vendor.js` | js | 400.0 KiB | 300.0 KiB | 75.0% | 87.9 KiB | high |") {
+		t.Errorf("markdown:\n%s", markdown)
+	}
+}
+
+func TestCoverage_CollectWaitsForPostedSnapshot(t *testing.T) {
+	t.Parallel()
+	env := newToolTestEnv(t)
+	env.capture.SetTrackingStatusForTest(1, "https://shop.test/")
+	env.capture.SimulateExtensionConnectForTest()
+
+	wait := startToolCall(t, func() JSONRPCResponse {
+		return env.handler.toolObserve(JSONRPCRequest{JSONRPC: "2.0", ID: 1}, json.RawMessage(`{"what":"coverage","collect":true}`))
+	})
+
+	q := awaitPendingQuery(t, env.capture, "coverage_snapshot")
+	if !strings.Contains(string(q.Params), `"reload":true`) {
+		t.Errorf("params = %s, want reload", q.Params)
+	}
+	// The extension posts the snapshot, then answers the query.
+	postCoverage(t, env.capture, `{"page_url":"https://shop.test/","resources":[{"url":"https://shop.test/app.js","total_bytes":1000,"used_bytes":400}]}`)
+	env.capture.SetQueryResult(q.ID, json.RawMessage(`{"status":"posted","page_url":"https://shop.test/","resources":1,"reloaded":true}`))
+
+	result := parseToolResult(t, wait())
+	if result.IsError {
+		t.Fatalf("collect should succeed, got: %s", firstText(result))
+	}
+	data := extractResultJSON(t, result)
+	if collected := data["collected"].(map[string]any); collected["resources"] != float64(1) || data["total"] != float64(1) {
+		t.Errorf("coverage = %v", data)
+	}
+	if !strings.Contains(firstText(result), "600 B of 1000 B unused (60.0%)") {
+		t.Errorf("summary line = %q", firstText(result))
+	}
+}
+
+func TestCoverage_EmptyAndInvalid(t *testing.T) {
+	t.Parallel()
+	h, _, cap := makeToolHandler(t)
+
+	data := extractResultJSON(t, parseToolResult(t, h.toolObserve(JSONRPCRequest{JSONRPC: "2.0", ID: 1}, json.RawMessage(`{"what":"coverage"}`))))
+	if data["status"] != "no_snapshots" || !strings.Contains(data["hint"].(string), "collect=true") {
+		t.Errorf("empty coverage = %v", data)
+	}
+	if report := parseToolResult(t, h.toolGenerate(JSONRPCRequest{JSONRPC: "2.0", ID: 2}, json.RawMessage(`{"what":"coverage_report"}`))); !report.IsError {
+		t.Error("coverage_report without snapshots should fail with no_data")
+	}
+	if collect := parseToolResult(t, h.toolObserve(JSONRPCRequest{JSONRPC: "2.0", ID: 3}, json.RawMessage(`{"what":"coverage","collect":true}`))); !collect.IsError || !strings.Contains(firstText(collect), "no_data") {
+		t.Errorf("collect without a tracked tab = %s", firstText(collect))
+	}
+
+	for _, body := range []string{`not json`, `{"snapshots":[` + strings.Repeat(`{},`, 20) + `{}]}`} {
+		if rr := postCoverage(t, cap, body); rr.Code != http.StatusBadRequest {
+			t.Errorf("POST %.20s status = %d, want 400", body, rr.Code)
+		}
+	}
+	rr := httptest.NewRecorder()
+	handleCoverageSnapshots(cap).ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/coverage-snapshots", nil))
+	if rr.Code != http.StatusMethodNotAllowed {
+		t.Errorf("GET status = %d, want 405", rr.Code)
+	}
+}
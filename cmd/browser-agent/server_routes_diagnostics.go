@@ -42,7 +42,21 @@ func (s *Server) handleDiagnostics(w http.ResponseWriter, r *http.Request, cap *
 		return
 	}
 
-	now := time.Now()
+	resp := s.diagnosticsSnapshot(cap, time.Now())
+	if cap != nil {
+		httpDebugLog := cap.GetHTTPDebugLog()
+		resp["http_debug_log"] = map[string]any{
+			"count":   len(httpDebugLog),
+			"entries": httpDebugLog,
+		}
+	}
+
+	jsonResponse(w, http.StatusOK, resp)
+}
+
+// diagnosticsSnapshot assembles the /diagnostics payload without the HTTP debug log.
+// generate(what="debug_bundle") writes the same snapshot to diagnostics.json.
+func (s *Server) diagnosticsSnapshot(cap *capture.Store, now time.Time) map[string]any {
 	launch := getCurrentLaunchMode()
 	resp := map[string]any{
 		"generated_at":   now.Format(time.RFC3339),
@@ -56,7 +70,7 @@ func (s *Server) handleDiagnostics(w http.ResponseWriter, r *http.Request, cap *
 		},
 		"logs": map[string]any{
 			"entries":     s.logs.getEntryCount(),
-			"max_entries": s.logs.maxEntries,
+			"max_entries": consoleCapacity(s.logs),
 			"log_file":    s.logs.logFile,
 		},
 		"launch_mode": map[string]any{
@@ -79,15 +93,11 @@ func (s *Server) handleDiagnostics(w http.ResponseWriter, r *http.Request, cap *
 	}
 	resp["last_events"] = lastEvents
 
-	if cap != nil {
-		httpDebugLog := cap.GetHTTPDebugLog()
-		resp["http_debug_log"] = map[string]any{
-			"count":   len(httpDebugLog),
-			"entries": httpDebugLog,
-		}
+	resp["tool_latency"] = map[string]any{
+		"window": toolTimingWindow,
+		"tools":  s.toolTimings.Snapshot(),
 	}
-
-	jsonResponse(w, http.StatusOK, resp)
+	return resp
 }
 
 // appendCaptureDiagnostics adds capture-related diagnostic fields to response map.
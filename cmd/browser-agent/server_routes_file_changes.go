@@ -0,0 +1,51 @@
+// Purpose: Implements the /file-changes ingest endpoint for source edits from `kaboom watch` and editor hooks.
+// Why: Lets the timeline tie each save to the reload, errors, and vitals that followed it.
+// Docs: docs/features/feature/watch-mode/index.md
+
+package main
+
+import (
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/filechanges"
+)
+
+// maxFileChangesBodySize bounds one POST /file-changes batch (500 changes with long paths).
+const maxFileChangesBodySize = 1 << 20
+
+// handleFileChanges returns an HTTP handler for POST /file-changes.
+// Accepts {"changes": [...]}, a bare array of changes, or {"paths": [...]}. The page
+// vitals current at arrival are kept as the edit's "before" for later comparison.
+func handleFileChanges(cap *capture.Store) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			jsonResponse(w, http.StatusMethodNotAllowed, map[string]string{"error": "Method not allowed"})
+			return
+		}
+
+		body, err := io.ReadAll(io.LimitReader(r.Body, maxFileChangesBodySize+1))
+		if err != nil {
+			jsonResponse(w, http.StatusBadRequest, map[string]string{"error": "Failed to read body"})
+			return
+		}
+		if len(body) > maxFileChangesBodySize {
+			jsonResponse(w, http.StatusRequestEntityTooLarge, map[string]string{"error": "Batch too large; send fewer changes per request"})
+			return
+		}
+
+		edit, err := filechanges.Parse(body, time.Now())
+		if err != nil {
+			jsonResponse(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+			return
+		}
+
+		edit = cap.FileChanges().Ingest(edit, filechanges.VitalsFrom(cap.GetPerformanceSnapshots()))
+		jsonResponse(w, http.StatusOK, map[string]any{
+			"edit_id": edit.ID,
+			"changes": len(edit.Changes),
+		})
+	}
+}
@@ -0,0 +1,82 @@
+// Purpose: Tests POST /file-changes ingestion and edit verdicts in the timeline.
+// Docs: docs/features/feature/watch-mode/index.md
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+)
+
+func TestFileChanges_TimelineJudgesLastEdit(t *testing.T) {
+	t.Parallel()
+	h, server, cap := makeToolHandler(t)
+	now := time.Now()
+	ts := func(offset time.Duration) string { return now.Add(offset).UTC().Format(time.RFC3339Nano) }
+
+	// The page was throwing before the edit.
+	server.logs.addEntries([]LogEntry{{"level": "error", "message": "TypeError: cart is undefined", "ts": ts(-40 * time.Second)}})
+	post := func(body string) map[string]any {
+		rr := httptest.NewRecorder()
+		handleFileChanges(cap).ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/file-changes", bytes.NewBufferString(body)))
+		if rr.Code != http.StatusOK {
+			t.Fatalf("POST /file-changes = %d %s", rr.Code, rr.Body.String())
+		}
+		var resp map[string]any
+		_ = json.Unmarshal(rr.Body.Bytes(), &resp)
+		return resp
+	}
+	if resp := post(`{"source":"watch","changes":[{"path":"src/cart.ts","op":"write"}],"timestamp":"` + ts(-30*time.Second) + `"}`); resp["edit_id"] != "edit_1" {
+		t.Fatalf("first edit response = %v", resp)
+	}
+
+	// Vite hot-swaps the module and the error stops; then a second edit introduces a new one.
+	rr := httptest.NewRecorder()
+	handleBuildEvents(cap).ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/build-events", bytes.NewBufferString(
+		`{"type":"hmr_update","tool":"vite","timestamp":"`+ts(-29*time.Second)+`"}`)))
+	post(`{"paths":["src/checkout.ts"],"timestamp":"` + ts(-10*time.Second) + `"}`)
+	cap.AddEnhancedActions([]capture.EnhancedAction{{Type: "navigation", Timestamp: now.Add(-9 * time.Second).UnixMilli(), ToURL: "http://localhost:5173/checkout"}})
+	server.logs.addEntries([]LogEntry{{"level": "error", "message": "ReferenceError: total is not defined", "ts": ts(-8 * time.Second)}})
+
+	result := parseToolResult(t, h.toolObserve(JSONRPCRequest{JSONRPC: "2.0", ID: 1}, json.RawMessage(`{"what":"timeline","include":["edits"]}`)))
+	if result.IsError {
+		t.Fatalf("timeline failed: %s", firstText(result))
+	}
+	data := extractResultJSON(t, result)
+	verdicts := map[string]string{}
+	for _, raw := range data["entries"].([]any) {
+		entry := raw.(map[string]any)
+		impact := entry["data"].(map[string]any)
+		verdicts[impact["edit_id"].(string)] = impact["verdict"].(string)
+	}
+	if verdicts["edit_1"] != "fixed" || verdicts["edit_2"] != "broke" {
+		t.Fatalf("verdicts = %v, want edit_1 fixed and edit_2 broke", verdicts)
+	}
+	last := data["last_edit"].(map[string]any)
+	if last["edit_id"] != "edit_2" || last["first_reload"] != "navigation http://localhost:5173/checkout" || data["edit_hint"] == nil {
+		t.Fatalf("last_edit = %v, hint = %v", last, data["edit_hint"])
+	}
+}
+
+func TestFileChanges_RejectsInvalidPayloads(t *testing.T) {
+	t.Parallel()
+	handler := handleFileChanges(capture.NewCapture())
+	for _, body := range []string{`not json`, `{"changes":[]}`} {
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/file-changes", bytes.NewBufferString(body)))
+		if rr.Code != http.StatusBadRequest {
+			t.Errorf("POST %s status = %d, want 400", body, rr.Code)
+		}
+	}
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/file-changes", nil))
+	if rr.Code != http.StatusMethodNotAllowed {
+		t.Errorf("GET status = %d, want 405", rr.Code)
+	}
+}
@@ -35,21 +35,27 @@ func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request, cap *captu
 	availVer := getAvailableVersion()
 
 	resp := map[string]any{
-		"status":       "ok",
-		"service-name": mcpServerName,
-		"name":         mcpServerName,
-		"version":      version,
+		"status":         "ok",
+		"service-name":   mcpServerName,
+		"name":           mcpServerName,
+		"version":        version,
+		"pid":            os.Getpid(),
+		"uptime_seconds": int64(time.Since(startTime).Seconds()),
 		"logs": map[string]any{
 			"entries":       s.logs.getEntryCount(),
-			"max_entries":   s.logs.maxEntries,
+			"max_entries":   consoleCapacity(s.logs),
 			"log_file":      s.logs.logFile,
 			"log_file_size": logFileSize,
 			"dropped_count": s.logs.getLogDropCount(),
 		},
+		"retention": effectiveRetention(s.logs, cap),
 	}
 	if termPort := s.getTerminalPort(); termPort > 0 {
 		resp["terminal_port"] = termPort
 	}
+	if lspPort := s.getLSPPort(); lspPort > 0 {
+		resp["lsp_port"] = lspPort
+	}
 
 	successReads, failedReads := bridge.SnapshotFastPathResourceReadCounters()
 	resp["bridge_fastpath"] = map[string]any{
@@ -74,14 +80,31 @@ func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request, cap *captu
 			"extension_connected": cap.IsExtensionConnected(),
 			"extension_last_seen": extStatus["last_seen"],
 			"extension_client_id": extStatus["client_id"],
+			"extension_transport": cap.ExtensionTransport(),
 			"security_mode":       securityMode,
 			"production_parity":   productionParity,
 			"insecure_rewrites":   rewrites,
 		}
+		resp["tabs"] = cap.GetTabBufferStats(logTabIDs(s.logs.getEntries()))
 	}
 	jsonResponse(w, http.StatusOK, resp)
 }
 
+// logTabIDs returns the tab each log entry was captured in, 0 when it has none.
+// Entries decoded from the extension's JSON carry tabId as float64.
+func logTabIDs(entries []LogEntry) []int {
+	ids := make([]int, len(entries))
+	for i, entry := range entries {
+		switch id := entry["tabId"].(type) {
+		case float64:
+			ids[i] = int(id)
+		case int:
+			ids[i] = id
+		}
+	}
+	return ids
+}
+
 // handleShutdown initiates a graceful server shutdown via SIGTERM.
 func (s *Server) handleShutdown(w http.ResponseWriter, r *http.Request) {
 	if r.Method != "POST" {
@@ -6,14 +6,16 @@ package main
 import (
 	"encoding/json"
 	"net/http"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
 )
 
 // handleLogs serves the /logs endpoint for ingesting and clearing log entries.
 // Reads go through GET /telemetry?type=logs.
-func (s *Server) handleLogs(w http.ResponseWriter, r *http.Request) {
+func (s *Server) handleLogs(w http.ResponseWriter, r *http.Request, cap *capture.Store) {
 	switch r.Method {
 	case "POST":
-		s.handleLogsPost(w, r)
+		s.handleLogsPost(w, r, cap)
 	case "DELETE":
 		s.logs.clearEntries()
 		jsonResponse(w, http.StatusOK, map[string]bool{"cleared": true})
@@ -23,7 +25,8 @@ func (s *Server) handleLogs(w http.ResponseWriter, r *http.Request) {
 }
 
 // handleLogsPost processes POST /logs requests to ingest new log entries.
-func (s *Server) handleLogsPost(w http.ResponseWriter, r *http.Request) {
+// While the capture circuit is open only console errors are kept.
+func (s *Server) handleLogsPost(w http.ResponseWriter, r *http.Request, cap *capture.Store) {
 	r.Body = http.MaxBytesReader(w, r.Body, maxPostBodySize)
 	var body struct {
 		Entries []LogEntry `json:"entries"`
@@ -39,10 +42,13 @@ func (s *Server) handleLogsPost(w http.ResponseWriter, r *http.Request) {
 	}
 
 	valid, rejected := validateLogEntries(body.Entries)
-	received := s.logs.addEntries(valid)
-	jsonResponse(w, http.StatusOK, map[string]int{
-		"received": received,
-		"rejected": rejected,
-		"entries":  s.logs.getEntryCount(),
-	})
+	resp := map[string]int{"rejected": rejected}
+	if cap != nil && cap.CheckCircuitOpen() {
+		kept := cap.KeepPriorityLogs(valid)
+		resp["dropped_under_pressure"] = len(valid) - len(kept)
+		valid = kept
+	}
+	resp["received"] = s.logs.addEntries(valid)
+	resp["entries"] = s.logs.getEntryCount()
+	jsonResponse(w, http.StatusOK, resp)
 }
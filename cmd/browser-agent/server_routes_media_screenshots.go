@@ -5,6 +5,7 @@
 package main
 
 import (
+	"encoding/base64"
 	"encoding/json"
 	"net/http"
 	"os"
@@ -65,6 +66,8 @@ func saveImageToScreenshotsDir(filename string, imageData []byte) (string, int,
 
 // handleScreenshot saves a screenshot JPEG to disk and returns the filename.
 // If query_id is provided, resolves the pending query directly (on-demand screenshot flow).
+// Redact regions are blacked out and highlight regions outlined before saving; if clip is
+// provided, the annotated image is then cropped to that element rectangle.
 func (s *Server) handleScreenshot(w http.ResponseWriter, r *http.Request, cap *capture.Store) {
 	if r.Method != "POST" {
 		jsonResponse(w, http.StatusMethodNotAllowed, map[string]string{"error": "Method not allowed"})
@@ -78,10 +81,14 @@ func (s *Server) handleScreenshot(w http.ResponseWriter, r *http.Request, cap *c
 
 	r.Body = http.MaxBytesReader(w, r.Body, maxPostBodySize)
 	var body struct {
-		DataURL       string `json:"data_url"`
-		URL           string `json:"url"`
-		CorrelationID string `json:"correlation_id"`
-		QueryID       string `json:"query_id"`
+		DataURL       string           `json:"data_url"`
+		URL           string           `json:"url"`
+		CorrelationID string           `json:"correlation_id"`
+		QueryID       string           `json:"query_id"`
+		Clip          *util.ImageClip  `json:"clip"`
+		Selector      string           `json:"selector"`
+		Redact        []util.ImageClip `json:"redact"`
+		Highlight     []util.ImageClip `json:"highlight"`
 	}
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
 		jsonResponse(w, http.StatusBadRequest, map[string]string{"error": "Invalid JSON"})
@@ -94,6 +101,28 @@ func (s *Server) handleScreenshot(w http.ResponseWriter, r *http.Request, cap *c
 		return
 	}
 
+	redacted, highlighted := 0, 0
+	if len(body.Redact) > 0 || len(body.Highlight) > 0 {
+		annotated, mimeType, nRedacted, nHighlighted, annotateErr := util.AnnotateImage(imageData, body.Redact, body.Highlight)
+		if annotateErr != nil {
+			// Never save an image whose redaction could not be applied.
+			jsonResponse(w, http.StatusBadRequest, map[string]string{"error": "Screenshot annotation failed: " + annotateErr.Error()})
+			return
+		}
+		imageData, redacted, highlighted = annotated, nRedacted, nHighlighted
+		body.DataURL = "data:" + mimeType + ";base64," + base64.StdEncoding.EncodeToString(annotated)
+	}
+
+	if body.Clip != nil {
+		cropped, mimeType, _, _, cropErr := util.CropImage(imageData, *body.Clip)
+		if cropErr != nil {
+			jsonResponse(w, http.StatusBadRequest, map[string]string{"error": "Element clip failed: " + cropErr.Error()})
+			return
+		}
+		imageData = cropped
+		body.DataURL = "data:" + mimeType + ";base64," + base64.StdEncoding.EncodeToString(cropped)
+	}
+
 	filename := util.BuildScreenshotFilename(body.URL, body.CorrelationID)
 	savePath, status, saveErr := saveImageToScreenshotsDir(filename, imageData)
 	if status != 0 {
@@ -109,12 +138,32 @@ func (s *Server) handleScreenshot(w http.ResponseWriter, r *http.Request, cap *c
 	if body.QueryID != "" && cap != nil {
 		// Include data_url in query result so observe(what="screenshot") can return inline image.
 		// The HTTP response intentionally omits it to keep the /screenshots response lean.
-		queryResult := map[string]string{
+		queryResult := map[string]any{
 			"filename":       filename,
 			"path":           savePath,
 			"correlation_id": body.CorrelationID,
 			"data_url":       body.DataURL,
 		}
+		if width, height, dimErr := util.ImageDimensions(imageData); dimErr == nil {
+			queryResult["width"] = width
+			queryResult["height"] = height
+		}
+		// An empty but present list still reports 0, so the agent can tell redaction was on.
+		if body.Redact != nil {
+			queryResult["redacted"] = redacted
+		}
+		if body.Highlight != nil {
+			queryResult["highlighted"] = highlighted
+		}
+		if body.Clip != nil {
+			queryResult["clip"] = map[string]any{
+				"selector": body.Selector,
+				"x":        body.Clip.X,
+				"y":        body.Clip.Y,
+				"width":    body.Clip.Width,
+				"height":   body.Clip.Height,
+			}
+		}
 		// Error impossible: map contains only primitive types from input
 		resultJSON, _ := json.Marshal(queryResult)
 		cap.SetQueryResult(body.QueryID, resultJSON)
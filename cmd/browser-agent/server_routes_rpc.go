@@ -0,0 +1,232 @@
+// Purpose: Serves kaboom.v1.ToolService over the Connect protocol (unary, JSON codec) alongside /mcp.
+// Why: Non-MCP consumers get a versioned, typed RPC surface that reuses the MCP dispatch path for auth and policy.
+// Docs: docs/features/feature/mcp-persistent-server/index.md
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/types"
+)
+
+// rpcRoutePrefix is the Connect route prefix: POST /kaboom.v1.ToolService/<Method>.
+const rpcRoutePrefix = "/" + types.RPCServiceName + "/"
+
+// rpcModeTools maps the per-tool RPC methods to the MCP tool they call.
+var rpcModeTools = map[string]string{
+	types.RPCMethodObserve:   "observe",
+	types.RPCMethodAnalyze:   "analyze",
+	types.RPCMethodGenerate:  "generate",
+	types.RPCMethodConfigure: "configure",
+	types.RPCMethodInteract:  "interact",
+}
+
+// handleToolServiceRPC serves Connect unary calls for kaboom.v1.ToolService.
+//
+// Every method is translated into an MCP JSON-RPC request and run through
+// MCPHandler.HandleRequest, so API-key auth (outer middleware), the read-only
+// tool gate, rate limiting, and redaction behave exactly as they do on /mcp.
+//
+// Failure semantics:
+// - Transport problems (method, content type, protocol version, body) return Connect errors without dispatch.
+// - JSON-RPC errors map to Connect error codes; tool-level failures are a 200 with is_error=true.
+func handleToolServiceRPC(mcp *MCPHandler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			jsonResponse(w, http.StatusMethodNotAllowed, types.RPCError{
+				Code:    types.RPCCodeUnimplemented,
+				Message: "only POST is supported for unary Connect calls",
+			})
+			return
+		}
+		if ct := r.Header.Get("Content-Type"); ct != "" && !strings.HasPrefix(ct, "application/json") {
+			w.Header().Set("Accept-Post", "application/json")
+			jsonResponse(w, http.StatusUnsupportedMediaType, types.RPCError{
+				Code:    types.RPCCodeUnimplemented,
+				Message: "unsupported content type " + ct + "; use the JSON codec (application/json)",
+			})
+			return
+		}
+		if v := r.Header.Get("Connect-Protocol-Version"); v != "" && v != "1" {
+			writeRPCError(w, types.RPCCodeInvalidArgument, "unsupported Connect-Protocol-Version "+v)
+			return
+		}
+
+		method := strings.TrimPrefix(r.URL.Path, rpcRoutePrefix)
+		r.Body = http.MaxBytesReader(w, r.Body, maxPostBodySize)
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			writeRPCError(w, types.RPCCodeInvalidArgument, "read error: "+err.Error())
+			return
+		}
+		if len(strings.TrimSpace(string(body))) == 0 {
+			body = []byte("{}")
+		}
+
+		clientID := r.Header.Get("X-Kaboom-Client")
+		switch method {
+		case types.RPCMethodListTools:
+			serveRPCListTools(w, mcp, clientID)
+		case types.RPCMethodCallTool:
+			var req types.RPCCallToolRequest
+			if err := json.Unmarshal(body, &req); err != nil {
+				writeRPCError(w, types.RPCCodeInvalidArgument, "invalid CallToolRequest: "+err.Error())
+				return
+			}
+			if req.Tool == "" {
+				writeRPCError(w, types.RPCCodeInvalidArgument, "tool is required")
+				return
+			}
+			serveRPCToolCall(w, mcp, clientID, req.Tool, req.Arguments)
+		default:
+			tool, ok := rpcModeTools[method]
+			if !ok {
+				writeRPCError(w, types.RPCCodeNotFound, "unknown method "+types.RPCServiceName+"/"+method)
+				return
+			}
+			var req types.RPCToolModeRequest
+			if err := json.Unmarshal(body, &req); err != nil {
+				writeRPCError(w, types.RPCCodeInvalidArgument, "invalid ToolModeRequest: "+err.Error())
+				return
+			}
+			args, err := mergeRPCWhat(req.What, req.Arguments)
+			if err != nil {
+				writeRPCError(w, types.RPCCodeInvalidArgument, err.Error())
+				return
+			}
+			serveRPCToolCall(w, mcp, clientID, tool, args)
+		}
+	}
+}
+
+// mergeRPCWhat sets "what" on the arguments object. An empty what leaves arguments untouched
+// so the tool reports its own missing-parameter error.
+func mergeRPCWhat(what string, raw json.RawMessage) (json.RawMessage, error) {
+	args := map[string]json.RawMessage{}
+	if len(raw) > 0 && string(raw) != "null" {
+		if err := json.Unmarshal(raw, &args); err != nil {
+			return nil, fmt.Errorf("arguments must be a JSON object: %w", err)
+		}
+	}
+	if what != "" {
+		// Error impossible: marshaling a string
+		args["what"], _ = json.Marshal(what)
+	}
+	return json.Marshal(args)
+}
+
+func serveRPCListTools(w http.ResponseWriter, mcp *MCPHandler, clientID string) {
+	resp := mcp.HandleRequest(JSONRPCRequest{JSONRPC: JSONRPCVersion, ID: "rpc", Method: "tools/list", ClientID: clientID})
+	if resp.Error != nil {
+		writeRPCJSONRPCError(w, resp.Error)
+		return
+	}
+	var result MCPToolsListResult
+	if err := json.Unmarshal(resp.Result, &result); err != nil {
+		writeRPCError(w, types.RPCCodeInternal, "decode tools/list: "+err.Error())
+		return
+	}
+	out := types.RPCListToolsResponse{Tools: make([]types.RPCToolDescriptor, 0, len(result.Tools))}
+	for _, tool := range result.Tools {
+		out.Tools = append(out.Tools, types.RPCToolDescriptor{
+			Name:        tool.Name,
+			Description: tool.Description,
+			InputSchema: tool.InputSchema,
+		})
+	}
+	jsonResponse(w, http.StatusOK, out)
+}
+
+func serveRPCToolCall(w http.ResponseWriter, mcp *MCPHandler, clientID, tool string, args json.RawMessage) {
+	params, err := json.Marshal(map[string]any{"name": tool, "arguments": args})
+	if err != nil {
+		writeRPCError(w, types.RPCCodeInvalidArgument, "encode arguments: "+err.Error())
+		return
+	}
+	resp := mcp.HandleRequest(JSONRPCRequest{
+		JSONRPC:  JSONRPCVersion,
+		ID:       "rpc",
+		Method:   "tools/call",
+		Params:   params,
+		ClientID: clientID,
+	})
+	if resp.Error != nil {
+		writeRPCJSONRPCError(w, resp.Error)
+		return
+	}
+	var result MCPToolResult
+	if err := json.Unmarshal(resp.Result, &result); err != nil {
+		writeRPCError(w, types.RPCCodeInternal, "decode tool result: "+err.Error())
+		return
+	}
+	out := types.RPCCallToolResponse{
+		Content:  make([]types.RPCContentBlock, 0, len(result.Content)),
+		IsError:  result.IsError,
+		Metadata: result.Metadata,
+	}
+	for _, block := range result.Content {
+		out.Content = append(out.Content, types.RPCContentBlock{
+			Type:     block.Type,
+			Text:     block.Text,
+			Data:     block.Data,
+			MimeType: block.MimeType,
+		})
+	}
+	jsonResponse(w, http.StatusOK, out)
+}
+
+// writeRPCJSONRPCError maps a JSON-RPC error from the MCP dispatch path to a Connect error.
+// Rate-limit and quota rejections are recognised by their typed RateLimitDecision data,
+// not the message text, and surface as resource_exhausted with a Retry-After header.
+func writeRPCJSONRPCError(w http.ResponseWriter, rpcErr *JSONRPCError) {
+	if decision, ok := rpcErr.Data.(RateLimitDecision); ok {
+		writeRPCRateLimitError(w, rpcErr.Message, decision)
+		return
+	}
+	code := types.RPCCodeInternal
+	switch rpcErr.Code {
+	case -32600, -32602, -32700:
+		code = types.RPCCodeInvalidArgument
+	case -32601:
+		code = types.RPCCodeNotFound
+	}
+	writeRPCError(w, code, rpcErr.Message)
+}
+
+// writeRPCRateLimitError writes a resource_exhausted error carrying the limiter decision
+// as a kaboom.v1.RateLimitInfo detail so RPC clients can back off without parsing text.
+func writeRPCRateLimitError(w http.ResponseWriter, message string, decision RateLimitDecision) {
+	debug := map[string]any{
+		"client_id":      decision.ClientID,
+		"scope":          decision.Scope,
+		"limit":          decision.Limit,
+		"remaining":      decision.Remaining,
+		"window":         decision.Window,
+		"retry_after_ms": decision.RetryAfterMs,
+	}
+	if !decision.ResetAt.IsZero() {
+		debug["reset_at"] = decision.ResetAt.UTC().Format(time.RFC3339)
+	}
+	if decision.RetryAfterMs > 0 {
+		// Retry-After is whole seconds; round up so clients never retry early.
+		w.Header().Set("Retry-After", strconv.FormatInt((decision.RetryAfterMs+999)/1000, 10))
+	}
+	jsonResponse(w, types.RPCCodeHTTPStatus(types.RPCCodeResourceExhausted), types.RPCError{
+		Code:    types.RPCCodeResourceExhausted,
+		Message: message,
+		Details: []types.RPCErrorDetail{{Type: types.RPCErrorDetailRateLimit, Debug: debug}},
+	})
+}
+
+// writeRPCError writes a Connect error body with the protocol's HTTP status for the code.
+func writeRPCError(w http.ResponseWriter, code, message string) {
+	jsonResponse(w, types.RPCCodeHTTPStatus(code), types.RPCError{Code: code, Message: message})
+}
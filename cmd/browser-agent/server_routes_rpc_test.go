@@ -0,0 +1,174 @@
+// Purpose: Tests the kaboom.v1.ToolService Connect routes: dispatch, error mapping, auth, and read-only policy.
+// Docs: docs/features/feature/mcp-persistent-server/index.md
+
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/types"
+)
+
+func newRPCTestMux(t *testing.T) (*http.ServeMux, *MCPHandler) {
+	t.Helper()
+	server, err := NewServer(t.TempDir()+"/test.jsonl", 100)
+	if err != nil {
+		t.Fatalf("NewServer: %v", err)
+	}
+	t.Cleanup(func() { server.Close() })
+	cap := capture.NewCapture()
+	return setupHTTPRoutes(server, cap)
+}
+
+func doRPC(t *testing.T, h http.Handler, method, body string, header map[string]string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, "/kaboom.v1.ToolService/"+method, strings.NewReader(body))
+	req.Host = "localhost:7890"
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range header {
+		req.Header.Set(k, v)
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestToolServiceRPC_ListToolsAndObserve(t *testing.T) {
+	t.Parallel()
+	mux, _ := newRPCTestMux(t)
+
+	rec := doRPC(t, mux, "ListTools", ``, nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("ListTools status = %d body = %s", rec.Code, rec.Body.String())
+	}
+	var list types.RPCListToolsResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &list); err != nil {
+		t.Fatalf("decode ListTools: %v", err)
+	}
+	names := map[string]bool{}
+	for _, tool := range list.Tools {
+		names[tool.Name] = true
+		if tool.InputSchema == nil {
+			t.Errorf("tool %s missing input_schema", tool.Name)
+		}
+	}
+	for _, want := range []string{"observe", "analyze", "generate", "configure", "interact"} {
+		if !names[want] {
+			t.Errorf("ListTools missing %s: %v", want, names)
+		}
+	}
+
+	rec = doRPC(t, mux, "Observe", `{"what":"errors","arguments":{"limit":5}}`, map[string]string{"Connect-Protocol-Version": "1"})
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Observe status = %d body = %s", rec.Code, rec.Body.String())
+	}
+	var resp types.RPCCallToolResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode Observe: %v", err)
+	}
+	if resp.IsError || len(resp.Content) == 0 || resp.Content[0].Type != "text" {
+		t.Fatalf("Observe response = %+v", resp)
+	}
+}
+
+func TestToolServiceRPC_ErrorMapping(t *testing.T) {
+	t.Parallel()
+	mux, _ := newRPCTestMux(t)
+
+	cases := []struct {
+		name, method, body string
+		status             int
+		code               string
+	}{
+		{"unknown method", "Teleport", `{}`, http.StatusNotFound, types.RPCCodeNotFound},
+		{"unknown tool", "CallTool", `{"tool":"teleport"}`, http.StatusNotFound, types.RPCCodeNotFound},
+		{"missing tool", "CallTool", `{}`, http.StatusBadRequest, types.RPCCodeInvalidArgument},
+		{"bad json", "Observe", `{bad`, http.StatusBadRequest, types.RPCCodeInvalidArgument},
+		{"non-object arguments", "Observe", `{"what":"errors","arguments":[1]}`, http.StatusBadRequest, types.RPCCodeInvalidArgument},
+	}
+	for _, tc := range cases {
+		rec := doRPC(t, mux, tc.method, tc.body, nil)
+		var rpcErr types.RPCError
+		_ = json.Unmarshal(rec.Body.Bytes(), &rpcErr)
+		if rec.Code != tc.status || rpcErr.Code != tc.code {
+			t.Errorf("%s: status=%d code=%q, want %d %q (body %s)", tc.name, rec.Code, rpcErr.Code, tc.status, tc.code, rec.Body.String())
+		}
+	}
+
+	rec := doRPC(t, mux, "ListTools", `{}`, map[string]string{"Content-Type": "application/proto"})
+	if rec.Code != http.StatusUnsupportedMediaType {
+		t.Errorf("proto codec status = %d, want 415", rec.Code)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/kaboom.v1.ToolService/ListTools", nil)
+	req.Host = "localhost:7890"
+	getRec := httptest.NewRecorder()
+	mux.ServeHTTP(getRec, req)
+	if getRec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("GET status = %d, want 405", getRec.Code)
+	}
+}
+
+func TestToolServiceRPC_SharesAuthAndReadOnlyPolicy(t *testing.T) {
+	t.Parallel()
+	mux, mcp := newRPCTestMux(t)
+	mcp.toolHandler.(*ToolHandler).readOnly = &readOnlyState{Reason: "serving archived bundle"}
+	handler := AuthMiddleware("secret")(mux)
+
+	if rec := doRPC(t, handler, "ListTools", `{}`, nil); rec.Code != http.StatusUnauthorized {
+		t.Fatalf("missing key status = %d, want 401", rec.Code)
+	}
+
+	rec := doRPC(t, handler, "Interact", `{"what":"click","arguments":{"selector":"#go"}}`, map[string]string{"X-Kaboom-Key": "secret"})
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Interact status = %d body = %s", rec.Code, rec.Body.String())
+	}
+	var resp types.RPCCallToolResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode Interact: %v", err)
+	}
+	if !resp.IsError || len(resp.Content) == 0 || !strings.Contains(resp.Content[0].Text, "read-only") {
+		t.Fatalf("read-only gate should reject interact over RPC: %+v", resp)
+	}
+}
+
+func TestToolServiceRPC_QuotaMapsToResourceExhausted(t *testing.T) {
+	t.Parallel()
+	mux, mcp := newRPCTestMux(t)
+	mcp.toolHandler.(*ToolHandler).toolCallLimiter.SetLimits("rpc-bot", ToolCallLimits{HourlyQuota: 1})
+	header := map[string]string{"X-Kaboom-Client": "rpc-bot"}
+
+	if rec := doRPC(t, mux, "Observe", `{"what":"errors"}`, header); rec.Code != http.StatusOK {
+		t.Fatalf("first call status = %d body = %s", rec.Code, rec.Body.String())
+	}
+
+	rec := doRPC(t, mux, "Observe", `{"what":"errors"}`, header)
+	if rec.Code != http.StatusTooManyRequests {
+		t.Fatalf("quota call status = %d, want 429 (body %s)", rec.Code, rec.Body.String())
+	}
+	if got := rec.Header().Get("Retry-After"); got == "" || got == "0" {
+		t.Errorf("Retry-After = %q, want positive seconds", got)
+	}
+	var rpcErr types.RPCError
+	if err := json.Unmarshal(rec.Body.Bytes(), &rpcErr); err != nil {
+		t.Fatalf("decode error: %v", err)
+	}
+	if rpcErr.Code != types.RPCCodeResourceExhausted {
+		t.Fatalf("code = %q, want %q", rpcErr.Code, types.RPCCodeResourceExhausted)
+	}
+	if len(rpcErr.Details) != 1 || rpcErr.Details[0].Type != types.RPCErrorDetailRateLimit {
+		t.Fatalf("details = %+v, want one %s", rpcErr.Details, types.RPCErrorDetailRateLimit)
+	}
+	debug := rpcErr.Details[0].Debug
+	if debug["scope"] != rateLimitScopeQuota || debug["client_id"] != "rpc-bot" {
+		t.Errorf("debug = %+v, want quota scope for rpc-bot", debug)
+	}
+	if ms, _ := debug["retry_after_ms"].(float64); ms <= 0 {
+		t.Errorf("retry_after_ms = %v, want > 0", debug["retry_after_ms"])
+	}
+}
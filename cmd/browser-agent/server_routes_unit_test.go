@@ -324,6 +324,31 @@ func TestLogsEndpointValidationAndMethods(t *testing.T) {
 	}
 }
 
+func TestLogsEndpointKeepsErrorsWhileCircuitOpen(t *testing.T) {
+	t.Parallel()
+
+	srv := newTestServerForHandlers(t)
+	cap := capture.NewCapture()
+	cap.SetCircuitMode("open")
+	mux, _ := setupHTTPRoutes(srv, cap)
+
+	req := localRequest(http.MethodPost, "/logs", bytes.NewBufferString(`{"entries":[{"level":"log","message":"noise"},{"level":"error","message":"boom"}]}`))
+	req.Header.Set("X-Kaboom-Client", "kaboom-extension")
+	rr := httptest.NewRecorder()
+	mux.ServeHTTP(rr, req)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("POST /logs status = %d, want %d", rr.Code, http.StatusOK)
+	}
+	body := decodeJSONMap(t, rr.Body.Bytes())
+	if body["received"].(float64) != 1 || body["dropped_under_pressure"].(float64) != 1 {
+		t.Fatalf("POST /logs counts unexpected: %v", body)
+	}
+	entries := srv.logs.getEntries()
+	if len(entries) != 1 || entries[0]["message"] != "boom" || entries[0][capture.PriorityCaptureTag] != true {
+		t.Fatalf("stored entries = %v, want the tagged error", entries)
+	}
+}
+
 func TestHandleScreenshotRoutes(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,107 @@
+// Purpose: Reads a client's git state at registration and stamps it onto generated artifacts.
+// Why: Reports need "produced against commit X" to reason about when a regression appeared.
+// Docs: docs/features/feature/source-control-context/index.md
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/types"
+)
+
+// sourceControlReadTimeout bounds the git commands run at client registration.
+const sourceControlReadTimeout = 2 * time.Second
+
+// readSourceControl returns the git branch, HEAD commit, and dirty state of dir.
+// Returns nil when dir is not inside a git work tree or git is unavailable.
+func readSourceControl(dir string) *types.SourceControl {
+	if dir == "" {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), sourceControlReadTimeout)
+	defer cancel()
+
+	git := func(args ...string) (string, bool) {
+		cmd := exec.CommandContext(ctx, "git", append([]string{"-C", dir}, args...)...) // #nosec G204 -- fixed git subcommands; dir is the client's own CWD
+		out, err := cmd.Output()
+		if err != nil {
+			return "", false
+		}
+		return strings.TrimSpace(string(out)), true
+	}
+
+	commit, ok := git("rev-parse", "HEAD")
+	if !ok || commit == "" {
+		return nil
+	}
+	sc := &types.SourceControl{Commit: commit, CapturedAt: time.Now().UTC()}
+	if branch, ok := git("rev-parse", "--abbrev-ref", "HEAD"); ok && branch != "HEAD" {
+		sc.Branch = branch
+	}
+	if status, ok := git("status", "--porcelain", "--untracked-files=no"); ok {
+		sc.Dirty = status != ""
+	}
+	return sc
+}
+
+// lookupSourceControl returns the git state the calling client reported at registration.
+func lookupSourceControl(cap *capture.Store, clientID string) *types.SourceControl {
+	if cap == nil || clientID == "" {
+		return nil
+	}
+	reg := cap.GetClientRegistry()
+	if reg == nil {
+		return nil
+	}
+	return reg.SourceControl(clientID)
+}
+
+// clientSourceControl implements configureSessionDeps for stamping session snapshots.
+func (h *ToolHandler) clientSourceControl(clientID string) *types.SourceControl {
+	return lookupSourceControl(h.capture, clientID)
+}
+
+// maybeStampSourceControl adds the client's git state to successful generate results,
+// both in metadata and in the JSON payload the agent reads.
+func (h *MCPHandler) maybeStampSourceControl(resp JSONRPCResponse, clientID, toolName string) JSONRPCResponse {
+	if toolName != "generate" || h.toolHandler == nil || resp.Result == nil {
+		return resp
+	}
+	sc := lookupSourceControl(h.toolHandler.GetCapture(), clientID)
+	if sc == nil {
+		return resp
+	}
+	return mutateToolResult(resp, func(r *MCPToolResult) {
+		if r.IsError {
+			return
+		}
+		if r.Metadata == nil {
+			r.Metadata = make(map[string]any)
+		}
+		r.Metadata["source_control"] = sc
+		if len(r.Content) == 0 {
+			return
+		}
+		text := r.Content[0].Text
+		jsonStart := strings.IndexByte(text, '{')
+		if jsonStart < 0 {
+			return
+		}
+		var data map[string]any
+		if err := json.Unmarshal([]byte(text[jsonStart:]), &data); err != nil {
+			return
+		}
+		data["source_control"] = sc
+		dataJSON, err := json.Marshal(data)
+		if err != nil {
+			return
+		}
+		r.Content[0].Text = text[:jsonStart] + string(dataJSON)
+	})
+}
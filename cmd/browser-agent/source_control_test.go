@@ -0,0 +1,100 @@
+// Purpose: Tests git-state capture at registration and stamping of snapshots and generate results.
+// Docs: docs/features/feature/source-control-context/index.md
+
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/session"
+)
+
+func TestReadSourceControl_GitWorkTree(t *testing.T) {
+	t.Parallel()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	if readSourceControl(t.TempDir()) != nil {
+		t.Error("non-repository should report no source control")
+	}
+
+	dir := t.TempDir()
+	run := func(args ...string) string {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-C", dir, "-c", "user.name=t", "-c", "user.email=t@example.com"}, args...)...)
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+		return strings.TrimSpace(string(out))
+	}
+	run("init", "-q", "-b", "feature/cart")
+	file := filepath.Join(dir, "app.js")
+	if err := os.WriteFile(file, []byte("v1"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	run("add", "app.js")
+	run("commit", "-q", "-m", "init")
+	head := run("rev-parse", "HEAD")
+
+	sc := readSourceControl(dir)
+	if sc == nil || sc.Commit != head || sc.Branch != "feature/cart" || sc.Dirty {
+		t.Fatalf("clean repo = %+v, want commit %s on feature/cart", sc, head)
+	}
+	if err := os.WriteFile(file, []byte("v2"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if sc := readSourceControl(dir); sc == nil || !sc.Dirty {
+		t.Errorf("modified repo = %+v, want dirty", sc)
+	}
+}
+
+func TestSourceControl_RegistrationStampsSnapshotAndGenerate(t *testing.T) {
+	t.Parallel()
+	cap := capture.NewCapture()
+	cap.SetClientRegistryForTest(newSessionClientRegistryAdapter(session.NewClientRegistry()))
+
+	cwd := "/work/shop"
+	rr := httptest.NewRecorder()
+	handleClientsList(rr, httptest.NewRequest(http.MethodPost, "/clients", strings.NewReader(
+		`{"cwd":"`+cwd+`","source_control":{"branch":"main","commit":"0123456789abcdef","dirty":true}}`)), cap)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("POST /clients = %d %s", rr.Code, rr.Body.String())
+	}
+	clientID := session.DeriveClientID(cwd)
+
+	req := httptest.NewRequest(http.MethodGet, "/snapshot", nil)
+	req.Header.Set("X-Kaboom-Client", clientID)
+	rr = httptest.NewRecorder()
+	handleSnapshot(newTestServerForHandlers(t), cap).ServeHTTP(rr, req)
+	var snap SnapshotResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &snap); err != nil {
+		t.Fatal(err)
+	}
+	if snap.SourceControl == nil || snap.SourceControl.Commit != "0123456789abcdef" || !snap.SourceControl.Dirty || snap.SourceControl.CapturedAt.IsZero() {
+		t.Errorf("snapshot source_control = %+v", snap.SourceControl)
+	}
+
+	h := &MCPHandler{toolHandler: &ToolHandler{capture: cap}}
+	base := succeed(JSONRPCRequest{JSONRPC: "2.0", ID: 1}, "Generated test", map[string]any{"script": "test()"})
+	stamped := parseToolResult(t, h.maybeStampSourceControl(base, clientID, "generate"))
+	if !strings.Contains(firstText(stamped), `"source_control":{"branch":"main","commit":"0123456789abcdef"`) || stamped.Metadata["source_control"] == nil {
+		t.Errorf("generate result not stamped: %s", firstText(stamped))
+	}
+	other := parseToolResult(t, h.maybeStampSourceControl(base, clientID, "observe"))
+	if strings.Contains(firstText(other), "source_control") {
+		t.Error("observe results should not be stamped")
+	}
+	anon := parseToolResult(t, h.maybeStampSourceControl(base, "", "generate"))
+	if strings.Contains(firstText(anon), "source_control") {
+		t.Error("results for unregistered clients should not be stamped")
+	}
+}
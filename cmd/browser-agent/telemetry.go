@@ -1,17 +1,23 @@
 // Purpose: Serves the HTTP /telemetry endpoint, dispatching to capture buffer getters by type query parameter.
 // Why: Provides a REST-accessible view of captured telemetry (logs, network, WebSocket, actions) for non-MCP consumers.
+// Docs: docs/features/feature/observe-long-poll/index.md
 
 package main
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
 )
 
 // handleTelemetry returns an http.HandlerFunc that serves GET /telemetry.
 // Dispatches to the appropriate buffer getter based on the type query param.
+// Successful reads carry an ETag and honor If-None-Match.
 func handleTelemetry(server *Server, cap *capture.Store) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != "GET" {
@@ -98,7 +104,7 @@ func handleTelemetry(server *Server, cap *capture.Store) http.HandlerFunc {
 
 		case "websocket_status":
 			status := cap.GetWebSocketStatus(capture.WebSocketStatusFilter{})
-			jsonResponse(w, http.StatusOK, map[string]any{
+			writeConditionalJSON(w, r, map[string]any{
 				"type":        telType,
 				"connections": status.Connections,
 				"closed":      status.Closed,
@@ -114,10 +120,44 @@ func handleTelemetry(server *Server, cap *capture.Store) http.HandlerFunc {
 			return
 		}
 
-		jsonResponse(w, http.StatusOK, map[string]any{
+		writeConditionalJSON(w, r, map[string]any{
 			"type":  telType,
 			"items": result,
 			"count": count,
 		})
 	}
 }
+
+// writeConditionalJSON writes body as a 200 JSON response tagged with a hash of its
+// bytes. A request whose If-None-Match names that tag gets 304 with no body, so
+// pollers skip re-downloading unchanged buffers.
+func writeConditionalJSON(w http.ResponseWriter, r *http.Request, body any) {
+	data, err := json.Marshal(body)
+	if err != nil {
+		jsonResponse(w, http.StatusInternalServerError, map[string]string{"error": "Failed to encode response"})
+		return
+	}
+	sum := sha256.Sum256(data)
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", "no-cache")
+	if etagMatches(r.Header.Get("If-None-Match"), etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(append(data, '\n'))
+}
+
+// etagMatches reports whether an If-None-Match header names etag. Weak tags compare
+// by value, per the weak comparison RFC 9110 requires for If-None-Match.
+func etagMatches(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
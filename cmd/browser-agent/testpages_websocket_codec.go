@@ -1,4 +1,4 @@
-// Purpose: Implements low-level WebSocket frame codec helpers for the test harness.
+// Purpose: Implements low-level WebSocket frame codec helpers for the test harness and /extension-ws.
 // Why: Separates RFC 6455 wire parsing/serialization from connection lifecycle and echo dispatch.
 
 package main
@@ -16,6 +16,11 @@ import (
 // Returns the FIN bit, opcode, unmasked payload, and any I/O error.
 // Payloads larger than maxWSPayload are rejected to prevent DoS.
 func wsReadFrame(r io.Reader) (fin bool, opcode byte, payload []byte, err error) {
+	return wsReadFrameLimit(r, maxWSPayload)
+}
+
+// wsReadFrameLimit is wsReadFrame with a caller-chosen payload cap.
+func wsReadFrameLimit(r io.Reader, limit uint64) (fin bool, opcode byte, payload []byte, err error) {
 	header := make([]byte, 2)
 	if _, err = io.ReadFull(r, header); err != nil {
 		return
@@ -40,8 +45,8 @@ func wsReadFrame(r io.Reader) (fin bool, opcode byte, payload []byte, err error)
 		length = binary.BigEndian.Uint64(ext)
 	}
 
-	if length > maxWSPayload {
-		err = fmt.Errorf("ws: frame payload %d bytes exceeds limit %d", length, uint64(maxWSPayload))
+	if length > limit {
+		err = fmt.Errorf("ws: frame payload %d bytes exceeds limit %d", length, limit)
 		return
 	}
 
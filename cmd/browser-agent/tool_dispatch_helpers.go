@@ -40,6 +40,10 @@ func (h *ToolHandler) dispatchTool(req JSONRPCRequest, args json.RawMessage, reg
 		return appendCanonicalWhatAliasWarning(resp, usedAliasParam, what, deprecatedIn, removeIn)
 	}
 
+	if resp, blocked := h.requireExtensionForMode(req, reg.Resolution.ToolName, what, args); blocked {
+		return appendCanonicalWhatAliasWarning(resp, usedAliasParam, what, deprecatedIn, removeIn)
+	}
+
 	if reg.PreDispatch != nil {
 		var preResp *JSONRPCResponse
 		args, preResp = reg.PreDispatch(h, req, args, what)
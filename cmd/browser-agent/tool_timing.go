@@ -0,0 +1,256 @@
+// Purpose: Measures per-call latency (queue wait, extension round-trip, analysis, serialization) and aggregates percentiles.
+// Why: "interact feels slow" is only actionable once the time is split between the daemon, the command queue, and the browser.
+// Docs: docs/features/feature/tool-timing/index.md
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/queries"
+)
+
+const (
+	// toolTimingWindow is the number of recent calls kept per tool:mode for percentiles.
+	toolTimingWindow = 256
+	// toolTimingMaxKeys caps distinct tool:mode keys; unknown modes beyond it share "<tool>:other".
+	toolTimingMaxKeys = 200
+)
+
+// toolTiming is one call's latency breakdown, attached as metadata.timing.
+// The four phases sum to TotalMs, up to rounding.
+type toolTiming struct {
+	TotalMs         float64 `json:"total_ms"`
+	QueueWaitMs     float64 `json:"queue_wait_ms"`
+	ExtensionMs     float64 `json:"extension_ms"`
+	AnalysisMs      float64 `json:"analysis_ms"`
+	SerializationMs float64 `json:"serialization_ms"`
+	CorrelationID   string  `json:"correlation_id,omitempty"`
+}
+
+// attachToolTiming records the call's breakdown for diagnostics and adds it as metadata.timing.
+// raw is the dispatch result before post-processing, read for the command's correlation_id.
+func (h *MCPHandler) attachToolTiming(resp, raw JSONRPCResponse, toolName string, args json.RawMessage, start, dispatched time.Time) JSONRPCResponse {
+	var cap *capture.Store
+	if h.toolHandler != nil {
+		cap = h.toolHandler.GetCapture()
+	}
+	timing := measureToolTiming(cap, raw, start, dispatched, time.Now())
+	if h.server != nil {
+		h.server.toolTimings.Record(toolName, usageKey(args), timing)
+	}
+	var result MCPToolResult
+	// Only mutate canonical MCP tool result payloads.
+	if resp.Result == nil || json.Unmarshal(resp.Result, &result) != nil || len(result.Content) == 0 {
+		return resp
+	}
+	if result.Metadata == nil {
+		result.Metadata = make(map[string]any)
+	}
+	result.Metadata["timing"] = timing
+	resultJSON, err := json.Marshal(result)
+	if err != nil {
+		return resp
+	}
+	resp.Result = json.RawMessage(resultJSON)
+	return resp
+}
+
+// measureToolTiming splits a call into phases. start..handled is the dispatch window; the
+// browser command named by the response's correlation_id contributes queue wait (queued ->
+// sent) and extension round-trip (sent -> terminal), clipped to that window so polling a
+// command queued by an earlier call is not double-counted. Post-processing is serialization.
+func measureToolTiming(cap *capture.Store, resp JSONRPCResponse, start, handled, done time.Time) toolTiming {
+	t := toolTiming{
+		TotalMs:         durationMs(done.Sub(start)),
+		SerializationMs: durationMs(done.Sub(handled)),
+	}
+	var queueWait, extension time.Duration
+	if cap != nil && bytes.Contains(resp.Result, []byte("correlation_id")) {
+		if corrID := extractCorrelationIDFromToolResponse(resp); corrID != "" {
+			if cmd, ok := cap.GetCommandResult(corrID); ok && cmd != nil {
+				t.CorrelationID = corrID
+				queueWait, extension = commandPhases(cmd.TraceEvents, start, handled)
+			}
+		}
+	}
+	t.QueueWaitMs = durationMs(queueWait)
+	t.ExtensionMs = durationMs(extension)
+	t.AnalysisMs = durationMs(handled.Sub(start) - queueWait - extension)
+	return t
+}
+
+// commandPhases returns the queued and in-extension time of a command inside [from, to].
+// A phase without an end stage is still running at to.
+func commandPhases(events []queries.CommandTraceEvent, from, to time.Time) (queueWait, extension time.Duration) {
+	var queued, sent, terminal time.Time
+	for _, evt := range events {
+		switch evt.Stage {
+		case "queued":
+			queued = evt.At
+		case "sent":
+			sent = evt.At
+		case "resolved", "errored", "timed_out":
+			if terminal.IsZero() {
+				terminal = evt.At
+			}
+		}
+	}
+	if queued.IsZero() {
+		return 0, 0
+	}
+	queueEnd := firstNonZeroTime(sent, terminal, to)
+	queueWait = timeOverlap(queued, queueEnd, from, to)
+	if !sent.IsZero() {
+		extension = timeOverlap(sent, firstNonZeroTime(terminal, to), from, to)
+	}
+	return queueWait, extension
+}
+
+func firstNonZeroTime(times ...time.Time) time.Time {
+	for _, t := range times {
+		if !t.IsZero() {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// timeOverlap returns the length of [a0, a1] ∩ [b0, b1].
+func timeOverlap(a0, a1, b0, b1 time.Time) time.Duration {
+	if a0.Before(b0) {
+		a0 = b0
+	}
+	if a1.After(b1) {
+		a1 = b1
+	}
+	if !a1.After(a0) {
+		return 0
+	}
+	return a1.Sub(a0)
+}
+
+// durationMs converts to milliseconds rounded to 0.1 ms; negative clock skew reads as 0.
+func durationMs(d time.Duration) float64 {
+	if d <= 0 {
+		return 0
+	}
+	return math.Round(float64(d)/float64(time.Millisecond)*10) / 10
+}
+
+// toolTimingStats keeps a rolling window of timings per tool:mode for the diagnostics endpoint.
+type toolTimingStats struct {
+	mu      sync.Mutex
+	samples map[string][]toolTiming
+}
+
+func newToolTimingStats() *toolTimingStats {
+	return &toolTimingStats{samples: make(map[string][]toolTiming)}
+}
+
+// Record adds a call under tool and mode ("" for tools without modes).
+func (s *toolTimingStats) Record(tool, mode string, t toolTiming) {
+	if s == nil {
+		return
+	}
+	key := tool
+	if mode != "" {
+		key = tool + ":" + mode
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.samples[key]; !ok && len(s.samples) >= toolTimingMaxKeys {
+		key = tool + ":other"
+	}
+	ring := append(s.samples[key], t)
+	if len(ring) > toolTimingWindow {
+		ring = ring[len(ring)-toolTimingWindow:]
+	}
+	s.samples[key] = ring
+}
+
+// copySamples copies the windows so percentiles are computed outside the lock.
+func (s *toolTimingStats) copySamples() map[string][]toolTiming {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	copied := make(map[string][]toolTiming, len(s.samples))
+	for key, ring := range s.samples {
+		copied[key] = append([]toolTiming(nil), ring...)
+	}
+	return copied
+}
+
+// latencyPercentiles summarizes one phase across the window.
+type latencyPercentiles struct {
+	P50 float64 `json:"p50"`
+	P95 float64 `json:"p95"`
+	P99 float64 `json:"p99"`
+	Max float64 `json:"max"`
+}
+
+// toolLatencySummary is the per tool:mode entry of diagnostics tool_latency.
+type toolLatencySummary struct {
+	Tool            string             `json:"tool"`
+	Count           int                `json:"count"`
+	TotalMs         latencyPercentiles `json:"total_ms"`
+	QueueWaitMs     latencyPercentiles `json:"queue_wait_ms"`
+	ExtensionMs     latencyPercentiles `json:"extension_ms"`
+	AnalysisMs      latencyPercentiles `json:"analysis_ms"`
+	SerializationMs latencyPercentiles `json:"serialization_ms"`
+}
+
+// Snapshot returns percentiles per tool:mode, slowest p95 first.
+func (s *toolTimingStats) Snapshot() []toolLatencySummary {
+	if s == nil {
+		return []toolLatencySummary{}
+	}
+	copied := s.copySamples()
+	out := make([]toolLatencySummary, 0, len(copied))
+	for key, ring := range copied {
+		phase := func(get func(toolTiming) float64) latencyPercentiles {
+			values := make([]float64, len(ring))
+			for i, t := range ring {
+				values[i] = get(t)
+			}
+			return percentilesOf(values)
+		}
+		out = append(out, toolLatencySummary{
+			Tool:            key,
+			Count:           len(ring),
+			TotalMs:         phase(func(t toolTiming) float64 { return t.TotalMs }),
+			QueueWaitMs:     phase(func(t toolTiming) float64 { return t.QueueWaitMs }),
+			ExtensionMs:     phase(func(t toolTiming) float64 { return t.ExtensionMs }),
+			AnalysisMs:      phase(func(t toolTiming) float64 { return t.AnalysisMs }),
+			SerializationMs: phase(func(t toolTiming) float64 { return t.SerializationMs }),
+		})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].TotalMs.P95 != out[j].TotalMs.P95 {
+			return out[i].TotalMs.P95 > out[j].TotalMs.P95
+		}
+		return out[i].Tool < out[j].Tool
+	})
+	return out
+}
+
+// percentilesOf uses nearest-rank percentiles so every value is one that was observed.
+func percentilesOf(values []float64) latencyPercentiles {
+	if len(values) == 0 {
+		return latencyPercentiles{}
+	}
+	sort.Float64s(values)
+	rank := func(p float64) float64 {
+		i := int(math.Ceil(p*float64(len(values)))) - 1
+		if i < 0 {
+			i = 0
+		}
+		return values[i]
+	}
+	return latencyPercentiles{P50: rank(0.50), P95: rank(0.95), P99: rank(0.99), Max: values[len(values)-1]}
+}
@@ -0,0 +1,164 @@
+// Purpose: Tests per-call timing breakdowns, their metadata attachment, and diagnostics percentiles.
+// Docs: docs/features/feature/tool-timing/index.md
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/queries"
+)
+
+func TestCommandPhases_ClipsToDispatchWindow(t *testing.T) {
+	t.Parallel()
+	base := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	at := func(ms int) time.Time { return base.Add(time.Duration(ms) * time.Millisecond) }
+	events := []queries.CommandTraceEvent{
+		{Stage: "queued", At: at(0)},
+		{Stage: "sent", At: at(100)},
+		{Stage: "started", At: at(120)},
+		{Stage: "resolved", At: at(400)},
+	}
+
+	tests := []struct {
+		name               string
+		from, to           time.Time
+		wantQueue, wantExt time.Duration
+	}{
+		{"whole lifecycle", at(0), at(450), 100 * time.Millisecond, 300 * time.Millisecond},
+		{"poll after queueing call", at(250), at(450), 0, 150 * time.Millisecond},
+		{"returned while in extension", at(0), at(200), 100 * time.Millisecond, 100 * time.Millisecond},
+		{"after completion", at(500), at(600), 0, 0},
+	}
+	for _, tt := range tests {
+		queue, ext := commandPhases(events, tt.from, tt.to)
+		if queue != tt.wantQueue || ext != tt.wantExt {
+			t.Errorf("%s: got queue=%v ext=%v, want queue=%v ext=%v", tt.name, queue, ext, tt.wantQueue, tt.wantExt)
+		}
+	}
+
+	// Never picked up: the whole window is queue wait.
+	queue, ext := commandPhases([]queries.CommandTraceEvent{{Stage: "queued", At: at(0)}, {Stage: "timed_out", At: at(300)}}, at(0), at(500))
+	if queue != 300*time.Millisecond || ext != 0 {
+		t.Errorf("timed out in queue: got queue=%v ext=%v, want 300ms/0", queue, ext)
+	}
+}
+
+func TestToolTimingStats_PercentilesAndKeyCap(t *testing.T) {
+	t.Parallel()
+	s := newToolTimingStats()
+	for i := 1; i <= 100; i++ {
+		s.Record("interact", "click", toolTiming{TotalMs: float64(i), ExtensionMs: float64(i) / 2})
+	}
+	s.Record("observe", "errors", toolTiming{TotalMs: 1})
+
+	snap := s.Snapshot()
+	if len(snap) != 2 || snap[0].Tool != "interact:click" {
+		t.Fatalf("snapshot should list interact:click first, got %+v", snap)
+	}
+	got := snap[0]
+	if got.Count != 100 || got.TotalMs.P50 != 50 || got.TotalMs.P95 != 95 || got.TotalMs.P99 != 99 || got.TotalMs.Max != 100 {
+		t.Errorf("total percentiles = %+v (count %d), want p50=50 p95=95 p99=99 max=100", got.TotalMs, got.Count)
+	}
+	if got.ExtensionMs.P50 != 25 {
+		t.Errorf("extension p50 = %v, want 25", got.ExtensionMs.P50)
+	}
+
+	for i := 0; i < toolTimingWindow+10; i++ {
+		s.Record("observe", "errors", toolTiming{TotalMs: 1})
+	}
+	for i := 0; i < toolTimingMaxKeys+5; i++ {
+		s.Record("analyze", fmt.Sprintf("mode_%d", i), toolTiming{TotalMs: 1})
+	}
+	counts := map[string]int{}
+	for _, entry := range s.Snapshot() {
+		counts[entry.Tool] = entry.Count
+	}
+	if counts["observe:errors"] != toolTimingWindow {
+		t.Errorf("window should cap samples at %d, got %d", toolTimingWindow, counts["observe:errors"])
+	}
+	if len(counts) != toolTimingMaxKeys+1 || counts["analyze:other"] == 0 {
+		t.Errorf("keys beyond the cap should fold into analyze:other, got %d keys", len(counts))
+	}
+}
+
+func TestMCPHandler_AttachesTimingAndReportsLatency(t *testing.T) {
+	t.Parallel()
+	srv, err := NewServer(filepath.Join(t.TempDir(), "timing.jsonl"), 100)
+	if err != nil {
+		t.Fatalf("NewServer() error = %v", err)
+	}
+	cap := capture.NewCapture()
+	const corrID = "click_timing_test"
+
+	h := NewMCPHandler(srv, "v-test")
+	h.SetToolHandler(&fakeToolHandlerForMCP{
+		cap:     cap,
+		limiter: testLimiter{allowed: true},
+		handleFn: func(req JSONRPCRequest, name string, _ json.RawMessage) (JSONRPCResponse, bool) {
+			_, _ = cap.CreatePendingQueryWithTimeout(queries.PendingQuery{
+				Type:          "browser_action",
+				Params:        json.RawMessage(`{"what":"click"}`),
+				CorrelationID: corrID,
+			}, 30*time.Second, "")
+			// Play the extension. The sleeps are the simulated queue wait and extension run
+			// time the timing phases must report, not polling.
+			time.Sleep(20 * time.Millisecond)
+			q := awaitPendingQuery(t, cap, "browser_action") // marks "sent"
+			cap.AcknowledgePendingQuery(q.ID)
+			time.Sleep(30 * time.Millisecond)
+			cap.CompleteCommand(corrID, json.RawMessage(`{"success":true}`), "")
+			return JSONRPCResponse{JSONRPC: "2.0", ID: req.ID, Result: mcpJSONResponse("Clicked", map[string]any{"correlation_id": corrID})}, true
+		},
+	})
+
+	resp := h.HandleRequest(JSONRPCRequest{
+		JSONRPC: "2.0",
+		ID:      1,
+		Method:  "tools/call",
+		Params:  json.RawMessage(`{"name":"interact","arguments":{"what":"click","selector":"button"}}`),
+	})
+	if resp == nil || resp.Error != nil {
+		t.Fatalf("tools/call response = %+v, want success", resp)
+	}
+	result := mustDecodeJSON[MCPToolResult](t, resp.Result)
+	raw, err := json.Marshal(result.Metadata["timing"])
+	if err != nil {
+		t.Fatalf("marshal timing: %v", err)
+	}
+	timing := mustDecodeJSON[toolTiming](t, raw)
+	if timing.CorrelationID != corrID {
+		t.Fatalf("timing.correlation_id = %q, want %q (timing %s)", timing.CorrelationID, corrID, raw)
+	}
+	if timing.QueueWaitMs < 15 || timing.ExtensionMs < 25 {
+		t.Errorf("queue/extension = %v/%v ms, want >= 15/25", timing.QueueWaitMs, timing.ExtensionMs)
+	}
+	if sum := timing.QueueWaitMs + timing.ExtensionMs + timing.AnalysisMs + timing.SerializationMs; sum < timing.TotalMs-1 || sum > timing.TotalMs+1 {
+		t.Errorf("phases sum to %v, total is %v", sum, timing.TotalMs)
+	}
+
+	rec := httptest.NewRecorder()
+	srv.handleDiagnostics(rec, httptest.NewRequest(http.MethodGet, "/diagnostics.json", nil), cap)
+	var diag struct {
+		ToolLatency struct {
+			Window int                  `json:"window"`
+			Tools  []toolLatencySummary `json:"tools"`
+		} `json:"tool_latency"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &diag); err != nil {
+		t.Fatalf("diagnostics decode: %v", err)
+	}
+	if diag.ToolLatency.Window != toolTimingWindow || len(diag.ToolLatency.Tools) != 1 {
+		t.Fatalf("tool_latency = %+v, want one entry", diag.ToolLatency)
+	}
+	if entry := diag.ToolLatency.Tools[0]; entry.Tool != "interact:click" || entry.Count != 1 || entry.ExtensionMs.P95 < 25 {
+		t.Errorf("tool_latency entry = %+v, want interact:click with extension time", entry)
+	}
+}
@@ -0,0 +1,44 @@
+// Purpose: Tests analyze(what="security_audit", category="csp_diff") end to end.
+// Docs: docs/features/feature/security-hardening/index.md
+
+package main
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+)
+
+func TestSecurityAudit_CSPDiff(t *testing.T) {
+	t.Parallel()
+	h, _, cap := makeToolHandler(t)
+	cap.SetTrackingStatusForTest(7, "https://shop.example.com/")
+	cap.AddNetworkBodies([]capture.NetworkBody{
+		{URL: "https://shop.example.com/", ContentType: "text/html", ResponseHeaders: map[string]string{
+			"Content-Security-Policy": "default-src 'self'; img-src *",
+		}},
+		{URL: "https://cdn.example.org/lib.js", ContentType: "application/javascript"},
+	})
+
+	result := parseToolResult(t, h.toolAnalyze(JSONRPCRequest{JSONRPC: "2.0", ID: 1}, json.RawMessage(`{"what":"security_audit","category":"csp_diff"}`)))
+	if result.IsError {
+		t.Fatalf("csp_diff failed: %s", firstText(result))
+	}
+	data := extractResultJSON(t, result)
+	if data["status"] != "would_break" {
+		t.Fatalf("status = %v, data = %v", data["status"], data)
+	}
+	missing := data["missing"].([]any)
+	if len(missing) != 1 || missing[0].(map[string]any)["source"] != "https://cdn.example.org" {
+		t.Fatalf("missing = %v", missing)
+	}
+	if broad := data["broad"].([]any); len(broad) != 1 || broad[0].(map[string]any)["kind"] != "wildcard" {
+		t.Fatalf("broad = %v", broad)
+	}
+
+	bad := parseToolResult(t, h.toolAnalyze(JSONRPCRequest{JSONRPC: "2.0", ID: 1}, json.RawMessage(`{"what":"security_audit","category":"nope"}`)))
+	if !bad.IsError {
+		t.Fatal("expected error for unknown category")
+	}
+}
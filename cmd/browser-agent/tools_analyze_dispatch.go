@@ -31,6 +31,8 @@ var analyzeHandlers = map[string]ModeHandler{
 	"draw_history":       method((*ToolHandler).toolListDrawHistory),
 	"draw_session":       method((*ToolHandler).toolGetDrawSession),
 	"computed_styles":    toolComputedStyles,
+	"styles":             method((*ToolHandler).toolAnalyzeStyles),
+	"i18n":               method((*ToolHandler).toolAnalyzeI18n),
 	"forms":              toolFormDiscovery,
 	"form_state":         toolFormState,
 	"form_validation":    toolFormValidation,
@@ -0,0 +1,57 @@
+// Purpose: Implements analyze(what="i18n"): scans the page's visible text and reports localization problems.
+// Why: Missing translations, hardcoded strings, and broken RTL layout are only visible on the rendered page.
+// Docs: docs/features/feature/i18n-audit/index.md
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/queries"
+	az "github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/tools/analyze"
+)
+
+// toolAnalyzeI18n queues a text probe and classifies the page's text into missing-lang,
+// untranslated-key, hardcoded-string, mixed-language, and RTL findings.
+// The classification needs the probe result, so this mode always waits.
+func (h *ToolHandler) toolAnalyzeI18n(req JSONRPCRequest, args json.RawMessage) JSONRPCResponse {
+	parsed, err := az.ParseI18nArgs(args)
+	if err != nil {
+		return fail(req, ErrInvalidParam, "Invalid i18n arguments: "+err.Error(), "Pass 'markers' as an array of attribute names", withParam("markers"))
+	}
+
+	correlationID := newCorrelationID("i18n")
+	query := queries.PendingQuery{
+		Type:          "i18n_scan",
+		Params:        buildQueryParams(map[string]any{"markers": parsed.Markers}),
+		TabID:         parsed.TabID,
+		CorrelationID: correlationID,
+	}
+	if resp, blocked := h.EnqueuePendingQuery(req, query, queries.AsyncCommandTimeout); blocked {
+		return resp
+	}
+
+	cmd, found, disconnected, _ := h.waitForCommandWithConnectivity(correlationID, asyncInitialWait)
+	if disconnected {
+		return h.finalizePendingDisconnect(req, correlationID)
+	}
+	if !found {
+		return fail(req, ErrNoData, "i18n probe was not tracked: "+correlationID, "Retry the call.", h.diagnosticHint())
+	}
+	if cmd.Status != "complete" || cmd.Error != "" {
+		return h.formatCommandResult(req, *cmd, correlationID)
+	}
+
+	var probe az.I18nProbe
+	if err := json.Unmarshal(cmd.Result, &probe); err != nil {
+		return fail(req, ErrExtError, "i18n probe returned malformed data: "+err.Error(), "Reload the extension and retry.")
+	}
+	if probe.Error != "" {
+		return fail(req, ErrExtError, "i18n probe failed: "+probe.Message, "Check that the page allows script injection and retry.")
+	}
+
+	report := az.AnalyzeI18n(probe, parsed.Markers)
+	summary := fmt.Sprintf("i18n audit of %s: %d findings", report.Page.URL, report.Total)
+	return succeed(req, summary, report)
+}
@@ -0,0 +1,60 @@
+// Purpose: Tests analyze(what="i18n") query dispatch and server-side classification of the text probe.
+// Docs: docs/features/feature/i18n-audit/index.md
+
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func runI18nProbe(t *testing.T, args string, probe map[string]any) (MCPToolResult, map[string]any) {
+	t.Helper()
+	env := newToolTestEnv(t)
+	env.capture.SetTrackingStatusForTest(1, "https://example.com")
+	env.capture.SimulateExtensionConnectForTest()
+
+	wait := startToolCall(t, func() JSONRPCResponse {
+		return env.handler.toolAnalyze(JSONRPCRequest{JSONRPC: "2.0", ID: 1}, json.RawMessage(args))
+	})
+	params := answerPendingQuery(t, env.capture, "i18n_scan", probe)
+	return parseToolResult(t, wait()), params
+}
+
+func TestAnalyzeI18n_ReportsFindings(t *testing.T) {
+	t.Parallel()
+	probe := map[string]any{
+		"url": "https://shop.test/", "html_lang": "", "marker_count": 2, "total_nodes": 3,
+		"text_nodes": []any{
+			map[string]any{"selector": "h1", "tag": "h1", "text": "Welcome", "direction": "ltr", "marked": true},
+			map[string]any{"selector": "p.promo", "tag": "p", "text": "home.title.missing", "direction": "ltr", "marked": true},
+			map[string]any{"selector": "button", "tag": "button", "text": "Buy now", "direction": "ltr"},
+		},
+	}
+	result, params := runI18nProbe(t, `{"what":"i18n","markers":["data-msg"]}`, probe)
+	if markers, _ := params["markers"].([]any); len(markers) != 1 || markers[0] != "data-msg" {
+		t.Fatalf("query params = %v, want markers forwarded", params)
+	}
+	if result.IsError {
+		t.Fatalf("i18n should succeed, got: %s", firstText(result))
+	}
+	data := extractResultJSON(t, result)
+	summary, _ := data["summary"].(map[string]any)
+	for _, category := range []string{"missing_lang", "untranslated_key", "hardcoded_string"} {
+		if summary[category] != float64(1) {
+			t.Errorf("summary[%s] = %v, want 1 (summary %v)", category, summary[category], summary)
+		}
+	}
+	if !strings.Contains(firstText(result), "3 findings") {
+		t.Errorf("summary line = %q", firstText(result))
+	}
+}
+
+func TestAnalyzeI18n_ProbeError(t *testing.T) {
+	t.Parallel()
+	result, _ := runI18nProbe(t, `{"what":"i18n"}`, map[string]any{"error": "i18n_scan_failed", "message": "Cannot access a chrome:// URL"})
+	if !result.IsError || !strings.Contains(firstText(result), "chrome://") {
+		t.Fatalf("expected probe failure, got %s", firstText(result))
+	}
+}
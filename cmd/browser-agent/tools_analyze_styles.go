@@ -0,0 +1,65 @@
+// Purpose: Implements analyze(what="styles"): probes an element's layout in the page and explains how it renders.
+// Why: query_dom shows tag and text but not why an element is hidden, clipped, or stacked under something else.
+// Docs: docs/features/feature/layout-inspection/index.md
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/queries"
+	az "github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/tools/analyze"
+)
+
+// toolAnalyzeStyles queues a layout probe and aggregates the raw element and
+// ancestor data into box model, stacking, clipping, and visibility findings.
+// The aggregation needs the probe result, so this mode always waits.
+func (h *ToolHandler) toolAnalyzeStyles(req JSONRPCRequest, args json.RawMessage) JSONRPCResponse {
+	parsed, err := az.ParseStylesArgs(args)
+	if err != nil {
+		return fail(req, ErrMissingParam, err.Error(), "Add the 'selector' parameter with a CSS selector", withParam("selector"))
+	}
+
+	correlationID := newCorrelationID("styles")
+	query := queries.PendingQuery{
+		Type:          "layout_inspect",
+		Params:        buildQueryParams(map[string]any{"selector": parsed.Selector, "properties": parsed.Properties}),
+		TabID:         parsed.TabID,
+		CorrelationID: correlationID,
+	}
+	if resp, blocked := h.EnqueuePendingQuery(req, query, queries.AsyncCommandTimeout); blocked {
+		return resp
+	}
+
+	cmd, found, disconnected, _ := h.waitForCommandWithConnectivity(correlationID, asyncInitialWait)
+	if disconnected {
+		return h.finalizePendingDisconnect(req, correlationID)
+	}
+	if !found {
+		return fail(req, ErrNoData, "Layout probe was not tracked: "+correlationID, "Retry the call.", h.diagnosticHint())
+	}
+	if cmd.Status != "complete" || cmd.Error != "" {
+		return h.formatCommandResult(req, *cmd, correlationID)
+	}
+
+	var probe az.LayoutProbe
+	if err := json.Unmarshal(cmd.Result, &probe); err != nil {
+		return fail(req, ErrExtError, "Layout probe returned malformed data: "+err.Error(), "Reload the extension and retry.")
+	}
+	if probe.Error != "" {
+		return fail(req, ErrExtError, "Layout probe failed: "+probe.Message, "Check the selector syntax and that the page allows script injection.", withParam("selector"))
+	}
+	if probe.Element == nil {
+		return fail(req, ErrNoData, "No element matches selector "+parsed.Selector,
+			"Check the selector with analyze(what='dom') and retry.", withParam("selector"))
+	}
+	probe.Selector = parsed.Selector
+
+	report := az.AnalyzeLayout(probe, parsed.Properties)
+	summary := fmt.Sprintf("Layout of %s: visible", report.Element)
+	if !report.Visibility.Visible {
+		summary = fmt.Sprintf("Layout of %s: not visible (%d reasons)", report.Element, len(report.Visibility.Reasons))
+	}
+	return succeed(req, summary, report)
+}
@@ -0,0 +1,89 @@
+// Purpose: Tests analyze(what="styles") query dispatch and server-side aggregation of the layout probe.
+// Docs: docs/features/feature/layout-inspection/index.md
+
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func runStylesProbe(t *testing.T, args string, probe map[string]any) (MCPToolResult, map[string]any) {
+	t.Helper()
+	env := newToolTestEnv(t)
+	env.capture.SetTrackingStatusForTest(1, "https://example.com")
+	env.capture.SimulateExtensionConnectForTest()
+
+	wait := startToolCall(t, func() JSONRPCResponse {
+		return env.handler.toolAnalyze(JSONRPCRequest{JSONRPC: "2.0", ID: 1}, json.RawMessage(args))
+	})
+	params := answerPendingQuery(t, env.capture, "layout_inspect", probe)
+	return parseToolResult(t, wait()), params
+}
+
+func TestAnalyzeStyles_RequiresSelector(t *testing.T) {
+	t.Parallel()
+	env := newToolTestEnv(t)
+	result := parseToolResult(t, env.handler.toolAnalyze(JSONRPCRequest{JSONRPC: "2.0", ID: 1}, json.RawMessage(`{"what":"styles"}`)))
+	if !result.IsError || !strings.Contains(firstText(result), "missing_param") {
+		t.Fatalf("expected missing_param, got %s", firstText(result))
+	}
+}
+
+func TestAnalyzeStyles_AggregatesProbe(t *testing.T) {
+	t.Parallel()
+	probe := map[string]any{
+		"match_count": 2,
+		"viewport":    map[string]any{"width": 800, "height": 600},
+		"element": map[string]any{
+			"tag": "button", "selector": "#save",
+			"rect":   map[string]any{"x": 10, "y": 120, "width": 80, "height": 30},
+			"styles": map[string]any{"display": "inline-block", "color": "rgb(0, 0, 0)", "position": "static"},
+		},
+		"ancestors": []any{
+			map[string]any{
+				"tag": "div", "selector": ".panel",
+				"rect":   map[string]any{"x": 0, "y": 0, "width": 300, "height": 100},
+				"styles": map[string]any{"display": "block", "overflow-x": "hidden", "overflow-y": "hidden", "transform": "matrix(1, 0, 0, 1, 0, 0)"},
+			},
+			map[string]any{"tag": "html", "selector": "html", "styles": map[string]any{"display": "block"}},
+		},
+	}
+	result, params := runStylesProbe(t, `{"what":"styles","selector":"#save","properties":["color"]}`, probe)
+	if params["selector"] != "#save" {
+		t.Fatalf("query params = %v, want selector forwarded", params)
+	}
+	if result.IsError {
+		t.Fatalf("styles failed: %s", firstText(result))
+	}
+
+	data := extractResultJSON(t, result)
+	if data["selector"] != "#save" || data["match_count"] != float64(2) {
+		t.Fatalf("result = %v", data)
+	}
+	if styles, _ := data["computed_styles"].(map[string]any); len(styles) != 1 || styles["color"] != "rgb(0, 0, 0)" {
+		t.Fatalf("computed_styles = %v, want only color", data["computed_styles"])
+	}
+	clipping, _ := data["clipping_ancestors"].([]any)
+	if len(clipping) != 1 || clipping[0].(map[string]any)["effect"] != "full" {
+		t.Fatalf("clipping_ancestors = %v, want .panel fully clipping", data["clipping_ancestors"])
+	}
+	vis, _ := data["visibility"].(map[string]any)
+	reasons, _ := vis["reasons"].([]any)
+	if vis["visible"] != false || len(reasons) != 1 || reasons[0].(map[string]any)["code"] != "clipped" {
+		t.Fatalf("visibility = %v, want clipped", vis)
+	}
+	stacking, _ := data["stacking_context"].(map[string]any)
+	if ctxs, _ := stacking["ancestor_contexts"].([]any); len(ctxs) != 2 {
+		t.Fatalf("ancestor_contexts = %v, want .panel and html", stacking)
+	}
+}
+
+func TestAnalyzeStyles_NoMatch(t *testing.T) {
+	t.Parallel()
+	result, _ := runStylesProbe(t, `{"what":"styles","selector":".ghost"}`, map[string]any{"match_count": 0, "element": nil})
+	if !result.IsError || !strings.Contains(firstText(result), "No element matches selector .ghost") {
+		t.Fatalf("expected no-match error, got %s", firstText(result))
+	}
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/state"
 	az "github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/tools/analyze"
 	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/tools/observe"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/types"
 )
 
 // ============================================
@@ -39,11 +40,12 @@ func (h *ToolHandler) toolVisualBaseline(req JSONRPCRequest, args json.RawMessag
 	now := time.Now()
 	_, _, trackedURL := h.capture.GetTrackingStatus()
 	metadata := az.BaselineMetadata{
-		Path:      screenshotPath,
-		URL:       trackedURL,
-		SavedAt:   now.Format(time.RFC3339),
-		Name:      parsed.Name,
-		Timestamp: now.UnixMilli(),
+		Path:          screenshotPath,
+		URL:           trackedURL,
+		SavedAt:       now.Format(time.RFC3339),
+		Name:          parsed.Name,
+		Timestamp:     now.UnixMilli(),
+		SourceControl: h.clientSourceControl(req.ClientID),
 	}
 	metadataJSON, _ := json.Marshal(metadata)
 
@@ -59,13 +61,17 @@ func (h *ToolHandler) toolVisualBaseline(req JSONRPCRequest, args json.RawMessag
 		}
 	}
 
-	return succeed(req, "Visual baseline saved", map[string]any{
+	response := map[string]any{
 		"status":   "saved",
 		"name":     parsed.Name,
 		"path":     screenshotPath,
 		"url":      trackedURL,
 		"saved_at": metadata.SavedAt,
-	})
+	}
+	if metadata.SourceControl != nil {
+		response["source_control"] = metadata.SourceControl
+	}
+	return succeed(req, "Visual baseline saved", response)
 }
 
 func (h *ToolHandler) toolVisualDiff(req JSONRPCRequest, args json.RawMessage) JSONRPCResponse {
@@ -146,6 +152,10 @@ func (h *ToolHandler) toolVisualDiff(req JSONRPCRequest, args json.RawMessage) J
 	if diffPath != "" {
 		response["diff_path"] = diffPath
 	}
+	// "baseline at commit abc123 vs current at def456" places the diff in the repo's history.
+	if sc := types.CompareSourceControl(baseline.SourceControl, h.clientSourceControl(req.ClientID)); sc != nil {
+		response["source_control"] = sc
+	}
 	if diffResult.DimensionDelta != nil {
 		response["dimension_delta"] = map[string]int{
 			"width":  diffResult.DimensionDelta[0],
@@ -86,6 +86,9 @@ func (h *ToolHandler) formatErrorCommandResult(req JSONRPCRequest, cmd queries.C
 }
 
 func (h *ToolHandler) formatExpiredCommandResult(req JSONRPCRequest, cmd queries.CommandResult, corrID string, responseData map[string]any) JSONRPCResponse {
+	if strings.HasPrefix(cmd.Error, queries.ExtensionUnreachableReason) {
+		return h.formatUnreachableCommandResult(req, cmd, corrID, responseData)
+	}
 	responseData["final"] = true
 	responseData["error"] = ErrExtTimeout
 	responseData["message"] = fmt.Sprintf("Command %s expired before the extension could execute it. Error: %s", corrID, cmd.Error)
@@ -97,6 +100,23 @@ func (h *ToolHandler) formatExpiredCommandResult(req JSONRPCRequest, cmd queries
 	return failJSON(req, summary, responseData)
 }
 
+// formatUnreachableCommandResult reports a command the extension never picked up, even after retries.
+// The extension never received it, so re-issuing the call cannot double-execute anything.
+func (h *ToolHandler) formatUnreachableCommandResult(req JSONRPCRequest, cmd queries.CommandResult, corrID string, responseData map[string]any) JSONRPCResponse {
+	responseData["final"] = true
+	responseData["error"] = ErrExtUnreachable
+	responseData["message"] = fmt.Sprintf("Command %s was never picked up by the extension: %s", corrID, cmd.Error)
+	responseData["retry"] = "The extension stopped polling the daemon. Check observe with what='pilot' to verify extension status, reload the extension if it shows disconnected, then retry the command. It never ran, so retrying is safe."
+	if record, ok := h.capture.ExtensionUnreachable(cmd.QueryID); ok {
+		responseData["diagnostics"] = record.Diagnostics()
+	}
+	responseData["hint"] = h.DiagnosticHintString()
+
+	h.finalizeResponseEnrichment(corrID, responseData, cmd)
+	summary := fmt.Sprintf("FAILED — Command %s: extension unreachable", corrID)
+	return failJSON(req, summary, responseData)
+}
+
 func (h *ToolHandler) formatTimeoutCommandResult(req JSONRPCRequest, cmd queries.CommandResult, corrID string, responseData map[string]any) JSONRPCResponse {
 	responseData["final"] = true
 	responseData["error"] = ErrExtTimeout
@@ -97,6 +97,9 @@ func (h *ToolHandler) MaybeWaitForCommand(req JSONRPCRequest, correlationID stri
 	// readiness gate before dispatching the command (P1-2 fix: no double wait).
 	// Here we only do an instant check to catch disconnections that occurred after
 	// requireExtension passed but before we reached this point.
+	if h.extensionDropped() {
+		return h.extensionDisconnectedError(req)
+	}
 	if !h.capture.IsExtensionConnected() {
 		return fail(req, ErrNoData, "Extension is not connected", "Ensure the Kaboom extension shows 'Connected' and a tab is tracked.", h.diagnosticHint())
 	}
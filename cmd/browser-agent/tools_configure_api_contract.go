@@ -0,0 +1,106 @@
+// Purpose: Implements configure(what="lock_api_contract") and feeds ingested responses into the locked-contract monitor.
+// Why: A frozen contract turns silent API drift (new fields, type changes, dropped keys) into alerts the moment a response deviates.
+// Docs: docs/features/feature/api-schema/index.md
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/analysis"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+)
+
+// recordNetworkActivity is the capture network callback: it hands each ingested batch to
+// the transport, API contract, invariant, and network budget monitors. Runs outside the Capture lock.
+func (h *ToolHandler) recordNetworkActivity(activity capture.NetworkActivity) {
+	h.recordTransportActivity(activity)
+	h.recordAPIContractActivity(activity)
+	h.recordInvariantNetwork(activity)
+	h.recordNetworkBudgets(activity)
+}
+
+// recordAPIContractActivity checks an ingested batch against locked contracts and queues alerts for new violations.
+func (h *ToolHandler) recordAPIContractActivity(activity capture.NetworkActivity) {
+	if h.apiContractMonitor == nil || h.alertBuffer == nil {
+		return
+	}
+	for _, alert := range h.apiContractMonitor.Observe(activity, time.Now()) {
+		h.alertBuffer.AddAlert(alert)
+	}
+}
+
+// toolConfigureLockAPIContract handles configure(what="lock_api_contract").
+// operation=lock (default) freezes the schema inferred from the endpoint's captured 2xx JSON
+// responses; status lists locked contracts; clear unlocks endpoint, or every contract without it.
+func (h *ToolHandler) toolConfigureLockAPIContract(req JSONRPCRequest, args json.RawMessage) JSONRPCResponse {
+	if h.apiContractMonitor == nil {
+		return fail(req, ErrNotInitialized, "API contract monitor not initialized", "Restart the daemon and call again")
+	}
+	var params struct {
+		Operation string `json:"operation"`
+		Endpoint  string `json:"endpoint"`
+	}
+	if resp, stop := parseArgs(req, args, &params); stop {
+		return resp
+	}
+	if params.Operation == "" {
+		params.Operation = "lock"
+	}
+
+	endpoint := ""
+	if params.Endpoint != "" {
+		var err error
+		if endpoint, err = analysis.ParseContractEndpoint(params.Endpoint); err != nil {
+			return fail(req, ErrInvalidParam, "Invalid endpoint: "+err.Error(),
+				"Use 'METHOD /path' (e.g. 'GET /api/users/{id}') or a request URL", withParam("endpoint"))
+		}
+	}
+
+	switch params.Operation {
+	case "lock":
+		if endpoint == "" {
+			return fail(req, ErrMissingParam, "Required parameter 'endpoint' is missing",
+				"Add endpoint as 'METHOD /path' or a request URL", withParam("endpoint"))
+		}
+		contract, err := analysis.InferAPIContract(endpoint, h.capture.GetNetworkBodies(), time.Now())
+		if err != nil {
+			return fail(req, ErrNoData, err.Error(),
+				"Exercise the endpoint in the browser so a successful JSON response is captured, then lock again", withParam("endpoint"))
+		}
+		if err := h.apiContractMonitor.Lock(contract); err != nil {
+			return fail(req, ErrExportFailed, "Contract locked for this session but not persisted: "+err.Error(),
+				"Check that the state directory is writable")
+		}
+		return succeed(req, fmt.Sprintf("Locked API contract: %d fields from %d responses", len(contract.Fields), contract.Samples), map[string]any{
+			"status":    "locked",
+			"operation": "lock",
+			"contract":  contract,
+			"hint":      `Deviations now raise regression alerts; review them with observe(what="contract_violations")`,
+		})
+	case "status":
+		contracts := h.apiContractMonitor.Contracts()
+		return succeed(req, fmt.Sprintf("%d locked API contract(s)", len(contracts)), map[string]any{
+			"status":    "ok",
+			"operation": "status",
+			"contracts": contracts,
+			"count":     len(contracts),
+		})
+	case "clear":
+		removed, err := h.apiContractMonitor.Unlock(endpoint)
+		if err != nil {
+			return fail(req, ErrExportFailed, "Contract removed for this session but the store was not updated: "+err.Error(),
+				"Check that the state directory is writable")
+		}
+		return succeed(req, fmt.Sprintf("Unlocked %d API contract(s)", removed), map[string]any{
+			"status":    "cleared",
+			"operation": "clear",
+			"removed":   removed,
+		})
+	default:
+		return fail(req, ErrInvalidParam, "operation must be 'lock', 'status', or 'clear'",
+			"Use a valid value for 'operation'", withParam("operation"), withHint("lock, status, or clear"))
+	}
+}
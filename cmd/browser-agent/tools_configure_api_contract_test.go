@@ -0,0 +1,67 @@
+// Purpose: Tests configure(what="lock_api_contract") locking, alerting on drift, and observe(what="contract_violations").
+// Docs: docs/features/feature/api-schema/index.md
+
+package main
+
+import (
+	"testing"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/state"
+)
+
+func TestLockAPIContract_DriftRaisesAlertAndObserveView(t *testing.T) {
+	t.Setenv(state.StateDirEnv, t.TempDir())
+	h, _, cap := makeToolHandler(t)
+	cap.AddNetworkBodies([]capture.NetworkBody{{
+		Method: "GET", URL: "https://api.test/users/7", Status: 200,
+		ContentType: "application/json", ResponseBody: `{"id":7,"email":"a@test"}`,
+	}})
+
+	lock := parseToolResult(t, callConfigureRaw(h, `{"what":"lock_api_contract","endpoint":"GET https://api.test/users/7"}`))
+	if lock.IsError {
+		t.Fatalf("lock_api_contract should succeed, got: %s", firstText(lock))
+	}
+	contract := extractResultJSON(t, lock)["contract"].(map[string]any)
+	if contract["endpoint"] != "GET /users/{id}" {
+		t.Fatalf("contract endpoint = %v, want GET /users/{id}", contract["endpoint"])
+	}
+	h.drainAlerts()
+
+	cap.AddNetworkBodies([]capture.NetworkBody{{
+		Method: "GET", URL: "https://api.test/users/8", Status: 200,
+		ContentType: "application/json", ResponseBody: `{"id":"8"}`,
+	}})
+	alerts := h.drainAlerts()
+	if len(alerts) != 2 || alerts[0].Source != "api_contract" {
+		t.Fatalf("alerts = %+v, want type_change and missing_required from api_contract", alerts)
+	}
+
+	data := extractResultJSON(t, parseToolResult(t, callObserveRaw(h, "contract_violations")))
+	if data["status"] != "violations" || data["total_violations"] != float64(2) {
+		t.Fatalf("status=%v total=%v, want violations/2", data["status"], data["total_violations"])
+	}
+	byKind := data["by_kind"].(map[string]any)
+	if byKind["type_change"] != float64(1) || byKind["missing_required"] != float64(1) {
+		t.Fatalf("by_kind = %v", byKind)
+	}
+
+	cleared := extractResultJSON(t, parseToolResult(t, callConfigureRaw(h, `{"what":"lock_api_contract","operation":"clear"}`)))
+	if cleared["removed"] != float64(1) {
+		t.Fatalf("clear removed = %v, want 1", cleared["removed"])
+	}
+	if data := extractResultJSON(t, parseToolResult(t, callObserveRaw(h, "contract_violations"))); data["status"] != "no_contracts" {
+		t.Fatalf("status after clear = %v, want no_contracts", data["status"])
+	}
+}
+
+func TestLockAPIContract_RequiresCapturedResponse(t *testing.T) {
+	t.Setenv(state.StateDirEnv, t.TempDir())
+	h, _, _ := makeToolHandler(t)
+	if result := parseToolResult(t, callConfigureRaw(h, `{"what":"lock_api_contract"}`)); !result.IsError {
+		t.Fatal("lock without endpoint should fail")
+	}
+	if result := parseToolResult(t, callConfigureRaw(h, `{"what":"lock_api_contract","endpoint":"GET /never/called"}`)); !result.IsError {
+		t.Fatal("lock without captured responses should fail")
+	}
+}
@@ -0,0 +1,125 @@
+// Purpose: Rolls the capture buffers into the session archive and implements configure(what="archive") for its retention.
+// Why: clear and shutdown used to discard a session for good; archived sessions stay listable and searchable.
+// Docs: docs/features/feature/session-archive/index.md
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/sessionbundle"
+)
+
+// archiveSession rolls the current buffers into the session archive. It returns false
+// when archiving is off or every buffer is empty.
+func (h *ToolHandler) archiveSession(reason string) (sessionbundle.ArchivedSession, bool, error) {
+	if h.sessionArchive == nil || h.capture == nil {
+		return sessionbundle.ArchivedSession{}, false, nil
+	}
+	var logs []LogEntry
+	if h.server != nil {
+		logs = h.server.logs.getEntries()
+	}
+	return h.sessionArchive.Roll(sessionbundle.FromCapture(h.capture, logs, "", time.Now()), reason)
+}
+
+// archivedSessionJSON renders one archived session for responses.
+func archivedSessionJSON(archive *sessionbundle.Archive, s sessionbundle.ArchivedSession) map[string]any {
+	return map[string]any{
+		"id":         s.ID,
+		"created_at": s.CreatedAt,
+		"reason":     s.Reason,
+		"page_url":   s.PageURL,
+		"counts":     s.Counts,
+		"bytes":      s.Bytes,
+		"path":       archive.Path(s),
+	}
+}
+
+// archiveSettingsJSON renders retention settings with the configure parameter names.
+func archiveSettingsJSON(s sessionbundle.ArchiveSettings) map[string]any {
+	return map[string]any{"enabled": s.Enabled, "max_entries": s.MaxSessions, "ttl": formatRetentionTTL(s.MaxAge)}
+}
+
+// toolConfigureArchive handles configure(what="archive").
+// operation=status (default) reports the settings and archived sessions; set (default when
+// enabled, max_entries, or ttl is passed) changes them; clear deletes every archived session.
+func (h *ToolHandler) toolConfigureArchive(req JSONRPCRequest, args json.RawMessage) JSONRPCResponse {
+	var params struct {
+		Operation  string  `json:"operation"`
+		Enabled    *bool   `json:"enabled"`
+		MaxEntries *int    `json:"max_entries"`
+		TTL        *string `json:"ttl"`
+	}
+	if resp, stop := parseArgs(req, args, &params); stop {
+		return resp
+	}
+	if params.Operation == "" {
+		params.Operation = "status"
+		if params.Enabled != nil || params.MaxEntries != nil || params.TTL != nil {
+			params.Operation = "set"
+		}
+	}
+	if h.sessionArchive == nil {
+		return fail(req, ErrNotInitialized, "Session archive is not available: the state directory could not be resolved",
+			"Set KABOOM_STATE_DIR to a writable directory and restart the daemon")
+	}
+
+	settings, err := h.sessionArchive.Settings()
+	if err != nil {
+		return fail(req, ErrInternal, "Cannot read the session archive: "+err.Error(),
+			"Check the archive directory "+h.sessionArchive.Dir())
+	}
+	summary := "Session archive"
+	data := map[string]any{}
+	switch params.Operation {
+	case "status":
+	case "set":
+		if params.Enabled != nil {
+			settings.Enabled = *params.Enabled
+		}
+		if params.MaxEntries != nil {
+			settings.MaxSessions = *params.MaxEntries
+		}
+		if params.TTL != nil {
+			ttl, err := parseRetentionTTL(*params.TTL)
+			if err != nil {
+				return fail(req, ErrInvalidParam, "Invalid ttl: "+err.Error(),
+					"Use Go duration syntax such as '72h', or '0' for no age limit", withParam("ttl"))
+			}
+			settings.MaxAge = ttl
+		}
+		pruned, err := h.sessionArchive.SetSettings(settings, time.Now())
+		if err != nil {
+			return fail(req, ErrInvalidParam, err.Error(),
+				fmt.Sprintf("Use max_entries between 1 and %d and a ttl of 0 or at least %s", sessionbundle.MaxArchiveSessions, sessionbundle.MinArchiveMaxAge))
+		}
+		data["pruned"] = pruned
+		summary = "Session archive updated"
+	case "clear":
+		removed, err := h.sessionArchive.Clear()
+		if err != nil {
+			return fail(req, ErrInternal, "Cannot clear the session archive: "+err.Error(),
+				"Check the archive directory "+h.sessionArchive.Dir())
+		}
+		data["removed"] = removed
+		summary = fmt.Sprintf("Deleted %d archived sessions", removed)
+	default:
+		return fail(req, ErrInvalidParam, "Unknown operation: "+params.Operation,
+			"Use operation status, set, or clear", withParam("operation"))
+	}
+
+	sessions, _ := h.sessionArchive.List()
+	var bytes int64
+	for _, s := range sessions {
+		bytes += s.Bytes
+	}
+	data["settings"] = archiveSettingsJSON(settings)
+	data["dir"] = h.sessionArchive.Dir()
+	data["sessions"] = len(sessions)
+	data["bytes"] = bytes
+	data["note"] = "configure(what=\"clear\") and daemon shutdown roll every buffer into a compressed session in dir. The archive keeps the newest max_entries sessions for ttl ('0' keeps them until evicted by count). List them with observe(what=\"sessions\") and search them with observe(what=\"search\", query=..., session=...)."
+	return succeed(req, summary, data)
+}
@@ -535,6 +535,31 @@ func TestConfigureAudit_AuditLog_AllOperations(t *testing.T) {
 	}
 }
 
+// TestConfigureAudit_AuditLog_RecordsMemoryEviction verifies memory-pressure passes land in the audit trail.
+func TestConfigureAudit_AuditLog_RecordsMemoryEviction(t *testing.T) {
+	env := newConfigureTestEnv(t)
+
+	// Spilled full bodies push total capture memory past the soft limit.
+	huge := strings.Repeat("x", 1<<20)
+	for i := 0; i < 14; i++ {
+		env.capture.AddNetworkBodies([]capture.NetworkBody{{URL: "https://a.test/huge", Status: 200, ResponseBody: huge}})
+	}
+
+	result, ok := env.callConfigure(t, `{"what":"audit_log","tool_name":"memory_eviction"}`)
+	if !ok || result.IsError {
+		t.Fatalf("audit_log report failed: %+v", result)
+	}
+	data := parseResponseJSON(t, result)
+	entries, _ := data["entries"].([]any)
+	if len(entries) == 0 {
+		t.Fatal("expected a memory_eviction audit entry")
+	}
+	entry := entries[0].(map[string]any)
+	if entry["client_id"] != memoryEvictionAuditClient || !strings.Contains(entry["parameters"].(string), `"level":"soft"`) {
+		t.Fatalf("entry = %v", entry)
+	}
+}
+
 // TestConfigureAudit_Health_Detailed tests health action returns expected fields
 func TestConfigureAudit_Health_Detailed(t *testing.T) {
 	handler := createConfigureTestHandler(t)
@@ -0,0 +1,73 @@
+// Purpose: Implements configure(what="body_limits") for network body inline limits and the extension capture ceilings.
+// Why: How much of each payload an agent needs varies by app; limits must change without a restart or rebuild.
+// Docs: docs/features/feature/full-body-fetch/index.md
+
+package main
+
+import (
+	"encoding/json"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+)
+
+// toolConfigureBodyLimits handles configure(what="body_limits").
+// operation=status (default) reports the limits; set (default when a limit is passed) changes
+// any of request_body_max, response_body_max, capture_body_max, and binary_capture_max; clear
+// restores the defaults.
+func (h *ToolHandler) toolConfigureBodyLimits(req JSONRPCRequest, args json.RawMessage) JSONRPCResponse {
+	var params struct {
+		Operation       string `json:"operation"`
+		RequestBodyMax  *int   `json:"request_body_max"`
+		ResponseBodyMax *int   `json:"response_body_max"`
+		CaptureBodyMax  *int   `json:"capture_body_max"`
+		BinaryMax       *int   `json:"binary_capture_max"`
+	}
+	if resp, stop := parseArgs(req, args, &params); stop {
+		return resp
+	}
+	if params.Operation == "" {
+		params.Operation = "status"
+		if params.RequestBodyMax != nil || params.ResponseBodyMax != nil || params.CaptureBodyMax != nil || params.BinaryMax != nil {
+			params.Operation = "set"
+		}
+	}
+
+	summary := "Network body limits"
+	switch params.Operation {
+	case "status":
+	case "set":
+		if params.RequestBodyMax == nil && params.ResponseBodyMax == nil && params.CaptureBodyMax == nil && params.BinaryMax == nil {
+			return fail(req, ErrMissingParam, "Pass request_body_max, response_body_max, capture_body_max, and/or binary_capture_max",
+				"Add at least one limit in bytes and call again", withParam("request_body_max"))
+		}
+		limits := h.capture.GetBodyLimits()
+		if v := params.RequestBodyMax; v != nil {
+			limits.RequestMax = *v
+		}
+		if v := params.ResponseBodyMax; v != nil {
+			limits.ResponseMax = *v
+		}
+		if v := params.CaptureBodyMax; v != nil {
+			limits.CaptureMax = *v
+		}
+		if v := params.BinaryMax; v != nil {
+			limits.BinaryMax = *v
+		}
+		if err := h.capture.SetBodyLimits(limits); err != nil {
+			return fail(req, ErrInvalidParam, err.Error(), "Use a byte count within the range the error names")
+		}
+		summary = "Network body limits updated"
+	case "clear":
+		_ = h.capture.SetBodyLimits(capture.DefaultBodyLimits())
+		summary = "Network body limits reset"
+	default:
+		return fail(req, ErrInvalidParam, "Unknown operation: "+params.Operation,
+			"Use operation status, set, or clear", withParam("operation"))
+	}
+
+	return succeed(req, summary, map[string]any{
+		"limits":   h.capture.GetBodyLimits(),
+		"defaults": capture.DefaultBodyLimits(),
+		"note":     "Bodies longer than request_body_max/response_body_max are truncated inline and carry full_body_ref; fetch the rest with observe(what=\"network_bodies\", request_id=<full_body_ref>, full_body=true). capture_body_max bounds what the extension sends and applies from its next sync. binary_capture_max > 0 makes the extension send the first bytes of binary fetch responses base64-encoded; entries gain binary_preview, and observe(what=\"network_bodies\", include_binary=true) returns the bytes.",
+	})
+}
@@ -0,0 +1,111 @@
+// Purpose: Tests configure(what="body_limits"), fetching a spilled body with observe(what="network_bodies", full_body=true), and binary capture.
+// Docs: docs/features/feature/full-body-fetch/index.md
+
+package main
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+)
+
+func TestConfigureBodyLimits_TruncateThenFetchFullBody(t *testing.T) {
+	t.Parallel()
+	h, _, cap := makeToolHandler(t)
+
+	set := parseToolResult(t, callConfigureRaw(h, `{"what":"body_limits","response_body_max":10}`))
+	if set.IsError {
+		t.Fatalf("set should succeed, got: %s", firstText(set))
+	}
+	limits := extractResultJSON(t, set)["limits"].(map[string]any)
+	if limits["response_body_max"] != float64(10) || limits["request_body_max"] != float64(8192) {
+		t.Fatalf("limits = %v", limits)
+	}
+
+	payload := `{"items":[` + strings.Repeat(`{"id":1},`, 20) + `{"id":2}]}`
+	cap.AddNetworkBodies([]capture.NetworkBody{{Method: "GET", URL: "https://api.test/items", Status: 200, ResponseBody: payload}})
+	entry := cap.GetNetworkBodies()[0]
+	if !entry.ResponseTruncated || len(entry.ResponseBody) != 10 || entry.FullBodyRef == "" {
+		t.Fatalf("entry = %+v, want a 10-byte truncated body with full_body_ref", entry)
+	}
+
+	req := JSONRPCRequest{JSONRPC: "2.0", ID: 1}
+	fetched := parseToolResult(t, h.toolObserve(req, json.RawMessage(`{"what":"network_bodies","request_id":"`+entry.FullBodyRef+`","full_body":true}`)))
+	if fetched.IsError {
+		t.Fatalf("full body fetch failed: %s", firstText(fetched))
+	}
+	full := extractResultJSON(t, fetched)["full_body"].(map[string]any)
+	if full["response_body"] != payload || full["complete"] != true {
+		t.Fatalf("full_body = %v", full)
+	}
+
+	missing := parseToolResult(t, h.toolObserve(req, json.RawMessage(`{"what":"network_bodies","request_id":"req-999","full_body":true}`)))
+	if !missing.IsError || !strings.Contains(firstText(missing), "no_data") {
+		t.Fatalf("unknown request_id should fail with no_data, got: %s", firstText(missing))
+	}
+
+	cleared := extractResultJSON(t, parseToolResult(t, callConfigureRaw(h, `{"what":"body_limits","operation":"clear"}`)))
+	if cleared["limits"].(map[string]any)["response_body_max"] != float64(16384) {
+		t.Fatalf("clear should restore defaults, got %v", cleared["limits"])
+	}
+}
+
+func TestConfigureBodyLimits_RejectsBadInput(t *testing.T) {
+	t.Parallel()
+	h, _, _ := makeToolHandler(t)
+	for _, args := range []string{
+		`{"what":"body_limits","request_body_max":-1}`,
+		`{"what":"body_limits","capture_body_max":2000000}`,
+		`{"what":"body_limits","operation":"set"}`,
+		`{"what":"body_limits","operation":"reset"}`,
+	} {
+		if result := parseToolResult(t, callConfigureRaw(h, args)); !result.IsError {
+			t.Fatalf("%s should fail, got: %s", args, firstText(result))
+		}
+	}
+	status := extractResultJSON(t, parseToolResult(t, callConfigureRaw(h, `{"what":"body_limits"}`)))
+	if status["limits"].(map[string]any)["capture_body_max"] != float64(65536) {
+		t.Fatalf("status = %v", status)
+	}
+}
+
+func TestConfigureBodyLimits_BinaryCaptureAndIncludeBinary(t *testing.T) {
+	t.Parallel()
+	h, _, cap := makeToolHandler(t)
+
+	set := parseToolResult(t, callConfigureRaw(h, `{"what":"body_limits","binary_capture_max":4096}`))
+	if set.IsError {
+		t.Fatalf("set should succeed, got: %s", firstText(set))
+	}
+	if got := extractResultJSON(t, set)["limits"].(map[string]any)["binary_capture_max"]; got != float64(4096) {
+		t.Fatalf("binary_capture_max = %v", got)
+	}
+
+	gif := base64.StdEncoding.EncodeToString([]byte("GIF89a\x02\x00\x03\x00"))
+	cap.AddNetworkBodies([]capture.NetworkBody{{Method: "GET", URL: "https://cdn.test/dot.gif", Status: 200,
+		ContentType: "image/gif", ResponseBody: gif, ResponseEncoding: "base64", ResponseSize: 10}})
+
+	req := JSONRPCRequest{JSONRPC: "2.0", ID: 1}
+	result := extractResultJSON(t, parseToolResult(t, h.toolObserve(req, json.RawMessage(`{"what":"network_bodies"}`))))
+	entry := result["entries"].([]any)[0].(map[string]any)
+	preview := entry["binary_preview"].(map[string]any)
+	if entry["response_body"] != nil || preview["kind"] != "image" || preview["width"] != float64(2) || preview["height"] != float64(3) {
+		t.Fatalf("default entry = %v, want preview only", entry)
+	}
+	if result["binary_hint"] == nil {
+		t.Fatal("omitted binary bodies should carry binary_hint")
+	}
+
+	result = extractResultJSON(t, parseToolResult(t, h.toolObserve(req, json.RawMessage(`{"what":"network_bodies","include_binary":true}`))))
+	entry = result["entries"].([]any)[0].(map[string]any)
+	if entry["response_body"] != gif || entry["response_encoding"] != "base64" {
+		t.Fatalf("include_binary entry = %v", entry)
+	}
+
+	if bad := parseToolResult(t, callConfigureRaw(h, `{"what":"body_limits","binary_capture_max":1000000}`)); !bad.IsError {
+		t.Fatalf("oversized binary_capture_max should fail, got: %s", firstText(bad))
+	}
+}
@@ -0,0 +1,110 @@
+// Purpose: Implements configure(what="circuit") for capture circuit breaker thresholds and the manual override, and turns breaker transitions into alerts.
+// Why: Fixed, opaque trip points either dropped telemetry on busy apps or let floods through; operators need to tune and override them live.
+// Docs: docs/features/feature/rate-limiting/index.md
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/circuit"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/types"
+)
+
+const bytesPerMB = 1024 * 1024
+
+// toolConfigureCircuit handles configure(what="circuit").
+// operation=status (default) reports state, thresholds, and cool-down progress; set (default
+// when a value is passed) changes max_events_per_window, memory_limit_mb, and/or state; clear
+// restores the default thresholds and automatic mode.
+func (h *ToolHandler) toolConfigureCircuit(req JSONRPCRequest, args json.RawMessage) JSONRPCResponse {
+	if h.capture == nil {
+		return fail(req, ErrNotInitialized, "Capture not initialized", "Restart the daemon and call again")
+	}
+	var params struct {
+		Operation          string `json:"operation"`
+		MaxEventsPerWindow *int   `json:"max_events_per_window"`
+		MemoryLimitMB      *int   `json:"memory_limit_mb"`
+		State              string `json:"state"`
+	}
+	if resp, stop := parseArgs(req, args, &params); stop {
+		return resp
+	}
+	if params.Operation == "" {
+		params.Operation = "status"
+		if params.MaxEventsPerWindow != nil || params.MemoryLimitMB != nil || params.State != "" {
+			params.Operation = "set"
+		}
+	}
+
+	summary := "Circuit breaker"
+	switch params.Operation {
+	case "status":
+	case "set":
+		if params.MaxEventsPerWindow == nil && params.MemoryLimitMB == nil && params.State == "" {
+			return fail(req, ErrMissingParam, "Pass max_events_per_window, memory_limit_mb, and/or state",
+				"Add a threshold or state (open, closed, auto) and call again", withParam("state"))
+		}
+		switch params.State {
+		case "", circuit.ModeAuto, circuit.ModeOpen, circuit.ModeClosed:
+		default:
+			return fail(req, ErrInvalidParam, "Invalid state: "+params.State,
+				"Use state open, closed, or auto", withParam("state"))
+		}
+		thresholds := h.capture.CircuitStatus().Thresholds
+		if v := params.MaxEventsPerWindow; v != nil {
+			if *v < 1 {
+				return fail(req, ErrInvalidParam, "max_events_per_window must be >= 1",
+					"Use a positive event count per 1-second window", withParam("max_events_per_window"))
+			}
+			thresholds.MaxEventsPerWindow = *v
+		}
+		if v := params.MemoryLimitMB; v != nil {
+			if *v < 0 {
+				return fail(req, ErrInvalidParam, "memory_limit_mb must be >= 0",
+					"Use 0 to disable the memory trip", withParam("memory_limit_mb"))
+			}
+			thresholds.MemoryLimitBytes = int64(*v) * bytesPerMB
+		}
+		h.capture.SetCircuitThresholds(thresholds)
+		if params.State != "" {
+			h.capture.SetCircuitMode(params.State)
+		}
+		summary = "Circuit breaker updated"
+	case "clear":
+		h.capture.SetCircuitThresholds(circuit.DefaultThresholds())
+		h.capture.SetCircuitMode(circuit.ModeAuto)
+		summary = "Circuit breaker reset"
+	default:
+		return fail(req, ErrInvalidParam, "Unknown operation: "+params.Operation,
+			"Use operation status, set, or clear", withParam("operation"))
+	}
+
+	return succeed(req, summary, map[string]any{
+		"circuit":  h.capture.CircuitStatus(),
+		"defaults": circuit.DefaultThresholds(),
+		"note":     "Opens after 5 windows in a row over max_events_per_window, or once capture memory passes memory_limit_mb. When the rate falls back it cools down for 10s, admitting a growing share of batches (admit_ratio), then closes. state=open rejects every batch, state=closed keeps it closed with only the per-window limit, state=auto restores automatic control. While open, priority capture still keeps console errors, failed requests (status >= 400), and WebSocket close/error events, tagged captured_under_pressure (priority_accepted/priority_dropped). Transitions raise alerts and circuit_opened/circuit_closed push events.",
+	})
+}
+
+// circuitTransitionAlert summarizes a circuit_opened or circuit_closed lifecycle event.
+func circuitTransitionAlert(opened bool, data map[string]any, now time.Time) types.Alert {
+	alert := types.Alert{
+		Severity:  "warning",
+		Category:  "threshold",
+		Timestamp: now.UTC().Format(time.RFC3339),
+		Source:    "circuit_breaker",
+	}
+	if opened {
+		alert.Title = fmt.Sprintf("Capture circuit breaker opened (%v)", data["reason"])
+		alert.Detail = "Extension batches are being rejected; console errors, failed requests, and WebSocket close/error events are still captured, tagged captured_under_pressure. Inspect with configure(what=\"circuit\"); state=closed overrides it."
+		return alert
+	}
+	alert.Severity = "info"
+	alert.Title = "Capture circuit breaker closed"
+	alert.Detail = fmt.Sprintf("Capture resumed; the circuit was open for %.0fs (%v) and kept %v priority entries.",
+		data["open_duration_secs"], data["previous_reason"], data["priority_accepted"])
+	return alert
+}
@@ -0,0 +1,102 @@
+// Purpose: Tests configure(what="circuit") tuning, the manual override, and transition alerts.
+// Docs: docs/features/feature/rate-limiting/index.md
+
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/types"
+)
+
+func TestConfigureCircuit_TuneOverrideAndReset(t *testing.T) {
+	t.Parallel()
+	h, _, cap := makeToolHandler(t)
+
+	status := extractResultJSON(t, parseToolResult(t, callConfigureRaw(h, `{"what":"circuit"}`)))["circuit"].(map[string]any)
+	if status["state"] != "closed" || status["mode"] != "auto" {
+		t.Fatalf("initial status = %v", status)
+	}
+
+	set := parseToolResult(t, callConfigureRaw(h, `{"what":"circuit","max_events_per_window":50,"memory_limit_mb":64}`))
+	if set.IsError {
+		t.Fatalf("set should succeed, got: %s", firstText(set))
+	}
+	thresholds := extractResultJSON(t, set)["circuit"].(map[string]any)["thresholds"].(map[string]any)
+	if thresholds["max_events_per_window"] != float64(50) || thresholds["memory_limit_bytes"] != float64(64*bytesPerMB) {
+		t.Fatalf("thresholds = %v", thresholds)
+	}
+	cap.RecordEvents(51)
+	if !cap.CheckRateLimit() {
+		t.Fatal("51 events should exceed a 50-event window")
+	}
+
+	opened := extractResultJSON(t, parseToolResult(t, callConfigureRaw(h, `{"what":"circuit","state":"open"}`)))["circuit"].(map[string]any)
+	if opened["state"] != "open" || opened["reason"] != "manual" {
+		t.Fatalf("state=open status = %v", opened)
+	}
+
+	reset := extractResultJSON(t, parseToolResult(t, callConfigureRaw(h, `{"what":"circuit","operation":"clear"}`)))["circuit"].(map[string]any)
+	if reset["state"] != "closed" || reset["mode"] != "auto" || reset["thresholds"].(map[string]any)["max_events_per_window"] != float64(1000) {
+		t.Fatalf("clear status = %v", reset)
+	}
+}
+
+func TestConfigureCircuit_RejectsBadInput(t *testing.T) {
+	t.Parallel()
+	h, _, _ := makeToolHandler(t)
+	for _, args := range []string{
+		`{"what":"circuit","state":"half_open"}`,
+		`{"what":"circuit","max_events_per_window":0}`,
+		`{"what":"circuit","memory_limit_mb":-1}`,
+		`{"what":"circuit","operation":"set"}`,
+		`{"what":"circuit","operation":"trip"}`,
+	} {
+		if result := parseToolResult(t, callConfigureRaw(h, args)); !result.IsError {
+			t.Errorf("%s should fail, got: %s", args, firstText(result))
+		}
+	}
+}
+
+func TestConfigureCircuit_TransitionsRaiseAlerts(t *testing.T) {
+	t.Parallel()
+	h, _, _ := makeToolHandler(t)
+
+	callConfigureRaw(h, `{"what":"circuit","state":"open"}`)
+	callConfigureRaw(h, `{"what":"circuit","state":"auto"}`)
+
+	var titles []string
+	deadline := time.Now().Add(2 * time.Second)
+	for len(titles) < 2 && time.Now().Before(deadline) {
+		for _, a := range h.alertBuffer.DrainAlerts() {
+			if a.Source == "circuit_breaker" {
+				titles = append(titles, a.Title)
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	joined := strings.Join(titles, " | ")
+	if !strings.Contains(joined, "opened (manual)") || !strings.Contains(joined, "closed") {
+		t.Fatalf("circuit alerts = %q, want an open and a close", joined)
+	}
+}
+
+func TestCircuitTransitionAlert(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	opened := circuitTransitionAlert(true, map[string]any{"reason": "rate_exceeded"}, now)
+	if opened.Severity != "warning" || opened.Title != "Capture circuit breaker opened (rate_exceeded)" {
+		t.Fatalf("opened alert = %+v", opened)
+	}
+	closed := circuitTransitionAlert(false, map[string]any{"previous_reason": "manual", "open_duration_secs": 12.4, "priority_accepted": 3}, now)
+	want := types.Alert{
+		Severity: "info", Category: "threshold", Source: "circuit_breaker", Timestamp: "2026-03-01T12:00:00Z",
+		Title:  "Capture circuit breaker closed",
+		Detail: "Capture resumed; the circuit was open for 12s (manual) and kept 3 priority entries.",
+	}
+	if closed != want {
+		t.Fatalf("closed alert = %+v, want %+v", closed, want)
+	}
+}
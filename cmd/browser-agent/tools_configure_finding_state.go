@@ -0,0 +1,48 @@
+// Purpose: Implements configure(what="finding_state") — moves a tracked finding to open, acknowledged, or fixed by hand.
+// Why: Some findings are accepted risks or can only be verified outside the browser; triage needs a way to say so.
+// Docs: docs/features/feature/finding-lifecycle/index.md
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/findings"
+)
+
+// toolConfigureFindingState handles configure(what="finding_state").
+func (h *ToolHandler) toolConfigureFindingState(req JSONRPCRequest, args json.RawMessage) JSONRPCResponse {
+	if h.findingTracker == nil {
+		return fail(req, ErrNotInitialized, "Finding tracker not initialized", "Restart the daemon and call again")
+	}
+	var params struct {
+		FindingID string `json:"finding_id"`
+		State     string `json:"state"`
+		Reason    string `json:"reason"`
+	}
+	if resp, stop := parseArgs(req, args, &params); stop {
+		return resp
+	}
+	if params.FindingID == "" {
+		return fail(req, ErrMissingParam, "Required parameter 'finding_id' is missing",
+			`Use an id from observe(what="findings"), e.g. security.missing_csp@3f9a1c2b`, withParam("finding_id"))
+	}
+	if params.State == "" {
+		return fail(req, ErrMissingParam, "Required parameter 'state' is missing",
+			"Use state open, acknowledged, or fixed", withParam("state"))
+	}
+
+	finding, err := h.findingTracker.SetState(params.FindingID, params.State, params.Reason, time.Now())
+	if errors.Is(err, findings.ErrUnknownFinding) {
+		return fail(req, ErrInvalidParam, err.Error(),
+			`List tracked findings with observe(what="findings") and pass an id`, withParam("finding_id"))
+	}
+	if err != nil {
+		return fail(req, ErrInvalidParam, err.Error(),
+			"regressed is set by audits; use open, acknowledged, or fixed", withParam("state"))
+	}
+	h.persistFindingTracker()
+	return succeed(req, "Finding "+finding.ID+" is "+finding.State, map[string]any{"finding": finding})
+}
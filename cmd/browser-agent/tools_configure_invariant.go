@@ -0,0 +1,170 @@
+// Purpose: Implements configure(what="invariant") and feeds ingested network and console batches into the invariant monitor.
+// Why: Standing assertions catch one-off violations between polls and keep the evidence for later triage.
+// Docs: docs/features/feature/invariants/index.md
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/invariants"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/types"
+)
+
+// defaultInvariantViolationLimit caps violations returned by operation=list.
+const defaultInvariantViolationLimit = 20
+
+// recordInvariantNetwork checks an ingested network batch and alerts on first-time violations.
+func (h *ToolHandler) recordInvariantNetwork(activity capture.NetworkActivity) {
+	if h.invariantMonitor == nil {
+		return
+	}
+	h.alertInvariantViolations(h.invariantMonitor.ObserveNetwork(activity, time.Now()))
+}
+
+// recordInvariantConsole checks ingested console entries and alerts on first-time violations.
+// Runs from the log onEntries callback.
+func (h *ToolHandler) recordInvariantConsole(entries []LogEntry) {
+	if h.invariantMonitor == nil {
+		return
+	}
+	h.alertInvariantViolations(h.invariantMonitor.ObserveConsole(entries, time.Now()))
+}
+
+func (h *ToolHandler) alertInvariantViolations(violations []invariants.Violation) {
+	if h.alertBuffer == nil {
+		return
+	}
+	for _, v := range violations {
+		h.alertBuffer.AddAlert(invariantViolationAlert(v))
+	}
+}
+
+// invariantViolationAlert summarizes the evidence in one line.
+func invariantViolationAlert(v invariants.Violation) types.Alert {
+	ev := v.Evidence
+	var what string
+	switch v.Kind {
+	case invariants.KindConsole:
+		what = fmt.Sprintf("console.%s %q", ev.Level, truncateAlertText(ev.Message, 160))
+	case invariants.KindWebSocket:
+		what = "WebSocket " + ev.Event + " " + ev.URL
+	default:
+		what = strings.TrimSpace(ev.Method + " " + ev.URL)
+		if ev.Status != 0 {
+			what += fmt.Sprintf(" -> %d", ev.Status)
+		}
+	}
+	if ev.PageURL != "" {
+		what += " on " + ev.PageURL
+	}
+	return types.Alert{
+		Severity:  "error",
+		Category:  "regression",
+		Title:     "Invariant violated: " + truncateAlertText(v.Expr, 120),
+		Detail:    fmt.Sprintf("%s (%s). Evidence via configure(what=\"invariant\", invariant_id=%q).", what, v.InvariantID, v.InvariantID),
+		Timestamp: v.FirstSeen.Format(time.RFC3339),
+		Source:    "invariant",
+	}
+}
+
+// toolConfigureInvariant handles configure(what="invariant").
+// operation=add (default when expr is set) registers an assertion; list (default) returns
+// invariants and recent violations, optionally for invariant_id; remove drops invariant_id;
+// clear drops every invariant.
+func (h *ToolHandler) toolConfigureInvariant(req JSONRPCRequest, args json.RawMessage) JSONRPCResponse {
+	if h.invariantMonitor == nil {
+		return fail(req, ErrNotInitialized, "Invariant monitor not initialized", "Restart the daemon and call again")
+	}
+	var params struct {
+		Operation   string `json:"operation"`
+		Expr        string `json:"expr"`
+		Scope       string `json:"scope"`
+		InvariantID string `json:"invariant_id"`
+		Limit       int    `json:"limit"`
+	}
+	if resp, stop := parseArgs(req, args, &params); stop {
+		return resp
+	}
+	if params.Operation == "" {
+		params.Operation = "list"
+		if params.Expr != "" {
+			params.Operation = "add"
+		}
+	}
+
+	m := h.invariantMonitor
+	switch params.Operation {
+	case "add":
+		if params.Expr == "" {
+			return fail(req, ErrMissingParam, "Required parameter 'expr' is missing",
+				"Add expr, e.g. 'no network request to prod-api.com'", withParam("expr"))
+		}
+		pageURL := ""
+		if h.capture != nil {
+			_, _, pageURL = h.capture.GetTrackingStatus()
+		}
+		inv, err := m.Add(params.Expr, params.Scope, pageURL, time.Now())
+		switch {
+		case errors.Is(err, invariants.ErrInvalidExpr):
+			return fail(req, ErrInvalidParam, err.Error(), "Rewrite expr in one of the supported forms", withParam("expr"))
+		case errors.Is(err, invariants.ErrNoPageForScope):
+			return fail(req, ErrNoData, err.Error(), `Track a tab first, or use scope "session"`, withParam("scope"))
+		case errors.Is(err, invariants.ErrUnknownScope):
+			return fail(req, ErrInvalidParam, err.Error(), `Use scope "session" or "page"`, withParam("scope"))
+		case err != nil:
+			return fail(req, ErrInvalidParam, err.Error(), "Remove an invariant with operation 'remove' first")
+		}
+		return succeed(req, "Invariant "+inv.ID+" added", map[string]any{
+			"status":    "added",
+			"invariant": inv,
+			"hint":      "Evaluated on every ingested batch from now on; violations raise regression alerts",
+		})
+
+	case "remove":
+		if params.InvariantID == "" {
+			return fail(req, ErrMissingParam, "Required parameter 'invariant_id' is missing",
+				"Call configure(what='invariant') to list ids", withParam("invariant_id"))
+		}
+		if err := m.Remove(params.InvariantID); err != nil {
+			return fail(req, ErrInvalidParam, err.Error()+": "+params.InvariantID,
+				"Call configure(what='invariant') to list ids", withParam("invariant_id"))
+		}
+		return succeed(req, "Invariant "+params.InvariantID+" removed", map[string]any{"status": "removed", "removed": params.InvariantID})
+
+	case "clear":
+		return succeed(req, "Invariants cleared", map[string]any{"status": "cleared", "removed": m.Clear()})
+
+	case "list":
+		limit := params.Limit
+		if limit <= 0 {
+			limit = defaultInvariantViolationLimit
+		}
+		list := m.List()
+		if params.InvariantID != "" {
+			found := false
+			for _, inv := range list {
+				found = found || inv.ID == params.InvariantID
+			}
+			if !found {
+				return fail(req, ErrInvalidParam, "Unknown invariant id: "+params.InvariantID,
+					"Call configure(what='invariant') without invariant_id to list ids", withParam("invariant_id"))
+			}
+		}
+		violations := m.Violations(params.InvariantID, limit)
+		return succeed(req, fmt.Sprintf("%d invariant(s), %d violation(s) shown", len(list), len(violations)), map[string]any{
+			"invariants": list,
+			"violations": violations,
+			"grammar":    invariants.Grammar,
+		})
+
+	default:
+		return fail(req, ErrInvalidParam, "Unknown invariant operation: "+params.Operation,
+			"Use operation 'add', 'list', 'remove', or 'clear'", withParam("operation"))
+	}
+}
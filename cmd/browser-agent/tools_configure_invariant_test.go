@@ -0,0 +1,83 @@
+// Purpose: Tests configure(what="invariant") add/list/remove/clear and violation alerts from ingested batches.
+// Docs: docs/features/feature/invariants/index.md
+
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+)
+
+func TestConfigureInvariant_CatchesViolationsOnIngest(t *testing.T) {
+	t.Parallel()
+	h, _, cap := makeToolHandler(t)
+	h.drainAlerts()
+
+	added := parseToolResult(t, callConfigureRaw(h, `{"what":"invariant","expr":"no network request to prod-api.com"}`))
+	if added.IsError {
+		t.Fatalf("invariant add should succeed, got: %s", firstText(added))
+	}
+	inv := extractResultJSON(t, added)["invariant"].(map[string]any)
+	if inv["scope"] != "session" || inv["id"] == "" {
+		t.Fatalf("invariant = %v", inv)
+	}
+	callConfigureRaw(h, `{"what":"invariant","expr":"no console error matching ^Uncaught"}`)
+
+	cap.AddNetworkBodies([]capture.NetworkBody{{Method: "POST", URL: "https://prod-api.com/orders", Status: 201}})
+	cap.AddNetworkBodies([]capture.NetworkBody{{Method: "POST", URL: "https://prod-api.com/orders", Status: 201}})
+	h.recordInvariantConsole([]LogEntry{
+		{"level": "error", "message": "Uncaught TypeError: x is not a function"},
+		{"level": "error", "message": "Handled: retrying"},
+	})
+
+	var alerts []string
+	for _, a := range h.drainAlerts() {
+		if a.Source == "invariant" {
+			alerts = append(alerts, a.Title+" | "+a.Detail)
+		}
+	}
+	if len(alerts) != 2 || !strings.Contains(alerts[0], "POST https://prod-api.com/orders -> 201") {
+		t.Fatalf("invariant alerts = %v, want one per distinct violation", alerts)
+	}
+
+	list := extractResultJSON(t, parseToolResult(t, callConfigureRaw(h, `{"what":"invariant","invariant_id":"`+inv["id"].(string)+`"}`)))
+	violations := list["violations"].([]any)
+	if len(violations) != 1 {
+		t.Fatalf("violations = %v, want 1 for the network invariant", violations)
+	}
+	v := violations[0].(map[string]any)
+	evidence := v["evidence"].(map[string]any)
+	if v["count"] != float64(2) || evidence["method"] != "POST" || evidence["status"] != float64(201) {
+		t.Fatalf("violation = %v, want POST 201 seen twice", v)
+	}
+
+	removed := parseToolResult(t, callConfigureRaw(h, `{"what":"invariant","operation":"remove","invariant_id":"`+inv["id"].(string)+`"}`))
+	if removed.IsError || len(h.invariantMonitor.List()) != 1 {
+		t.Fatalf("remove: %s", firstText(removed))
+	}
+	cleared := extractResultJSON(t, parseToolResult(t, callConfigureRaw(h, `{"what":"invariant","operation":"clear"}`)))
+	if cleared["removed"] != float64(1) {
+		t.Fatalf("clear removed = %v, want 1", cleared["removed"])
+	}
+}
+
+func TestConfigureInvariant_InvalidArgs(t *testing.T) {
+	t.Parallel()
+	h, _, _ := makeToolHandler(t)
+
+	for _, args := range []string{
+		`{"what":"invariant","operation":"add"}`,
+		`{"what":"invariant","expr":"requests to prod are bad"}`,
+		`{"what":"invariant","expr":"no console error","scope":"page"}`,
+		`{"what":"invariant","expr":"no console error","scope":"tab"}`,
+		`{"what":"invariant","operation":"remove","invariant_id":"inv-99"}`,
+		`{"what":"invariant","invariant_id":"inv-99"}`,
+		`{"what":"invariant","operation":"explode"}`,
+	} {
+		if result := parseToolResult(t, callConfigureRaw(h, args)); !result.IsError {
+			t.Errorf("%s should return isError:true", args)
+		}
+	}
+}
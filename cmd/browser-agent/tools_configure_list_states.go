@@ -0,0 +1,57 @@
+// Purpose: Implements configure(what="list_states") to list encrypted vault states per origin with expiry.
+// Why: An agent reusing a login needs to know which states exist, for which site, and whether they are still valid.
+// Docs: docs/features/feature/auth-state-vault/index.md
+
+package main
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/statevault"
+)
+
+// listedVaultState is a vault entry as listed: metadata only, plus whether it has expired.
+type listedVaultState struct {
+	statevault.Entry
+	Expired bool `json:"expired"`
+}
+
+// toolConfigureListStates lists vault state metadata. Listing reads no secrets, so it works
+// without --state-key; loading still needs the key the states were saved with.
+func (h *ToolHandler) toolConfigureListStates(req JSONRPCRequest, args json.RawMessage) JSONRPCResponse {
+	var params struct {
+		Origin string `json:"origin"`
+	}
+	if resp, stop := parseArgs(req, args, &params); stop {
+		return resp
+	}
+	if resp, blocked := h.requireSessionStore(req); blocked {
+		return resp
+	}
+
+	entries, err := statevault.List(h.sessionStoreImpl, params.Origin)
+	if err != nil {
+		return fail(req, ErrInvalidParam, err.Error(), "Pass origin as an http(s) URL such as https://app.example.com", withParam("origin"))
+	}
+	now := time.Now()
+	states := make([]listedVaultState, 0, len(entries))
+	expired := 0
+	for _, entry := range entries {
+		states = append(states, listedVaultState{Entry: entry, Expired: entry.Expired(now)})
+		if entry.Expired(now) {
+			expired++
+		}
+	}
+
+	data := map[string]any{
+		"vault_configured": h.stateVault != nil,
+		"states":           states,
+		"count":            len(states),
+		"expired":          expired,
+	}
+	if h.stateVault == nil {
+		data["note"] = "No state key is configured, so these states cannot be loaded or new ones saved. Restart the daemon with KABOOM_STATE_KEY set to the key they were saved with."
+	}
+	return succeed(req, "Vault states listed", data)
+}
@@ -0,0 +1,34 @@
+// Purpose: Implements configure(what="dedup") to toggle collapsing of consecutive identical console log entries.
+// Why: Hot loops evict useful context from the log buffer; agents turn dedup on when a page spams one line.
+// Docs: docs/features/feature/log-dedup/index.md
+
+package main
+
+import "encoding/json"
+
+// toolConfigureLogDedup handles configure(what="dedup"). With enabled it turns ingest dedup
+// on or off; without it, it reports the current state.
+func (h *ToolHandler) toolConfigureLogDedup(req JSONRPCRequest, args json.RawMessage) JSONRPCResponse {
+	var params struct {
+		Enabled *bool `json:"enabled"`
+	}
+	if resp, stop := parseArgs(req, args, &params); stop {
+		return resp
+	}
+
+	summary := "Log dedup status"
+	if params.Enabled != nil {
+		h.server.logs.setDedupEnabled(*params.Enabled)
+		summary = "Log dedup disabled"
+		if *params.Enabled {
+			summary = "Log dedup enabled"
+		}
+	}
+
+	enabled, collapsed := h.server.logs.getDedupStatus()
+	return succeed(req, summary, map[string]any{
+		"enabled":   enabled,
+		"collapsed": collapsed,
+		"note":      "Consecutive identical log entries (timestamps aside) are stored once with count, first_ts, and last_ts, in observe(what=\"logs\") and the saved JSONL. Only new entries are affected; cursors already past an entry do not see its count grow.",
+	})
+}
@@ -0,0 +1,86 @@
+// Purpose: Implements configure(what="define_login") to store, list, and remove login recipes.
+// Why: A login flow defined once can be replayed by interact(login) instead of being re-derived every session.
+// Docs: docs/features/feature/login-recipes/index.md
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/loginrecipe"
+)
+
+// toolConfigureDefineLogin handles configure(what="define_login"). operation=add (default when
+// steps are given) stores the recipe under name, replacing an existing one; list (default)
+// summarizes stored recipes; remove deletes name.
+func (h *ToolHandler) toolConfigureDefineLogin(req JSONRPCRequest, args json.RawMessage) JSONRPCResponse {
+	var params struct {
+		Operation   string             `json:"operation"`
+		Name        string             `json:"name"`
+		Description string             `json:"description"`
+		Steps       []loginrecipe.Step `json:"steps"`
+		Vars        map[string]string  `json:"vars"`
+		TTL         string             `json:"ttl"`
+	}
+	if resp, stop := parseArgs(req, args, &params); stop {
+		return resp
+	}
+	if params.Operation == "" {
+		params.Operation = "list"
+		if len(params.Steps) > 0 {
+			params.Operation = "add"
+		}
+	}
+	if resp, blocked := h.requireSessionStore(req); blocked {
+		return resp
+	}
+
+	switch params.Operation {
+	case "add":
+		recipe := loginrecipe.Recipe{
+			Name:        params.Name,
+			Description: params.Description,
+			Vars:        params.Vars,
+			TTL:         params.TTL,
+			Steps:       params.Steps,
+			SavedAt:     time.Now().UTC(),
+		}
+		if err := loginrecipe.Save(h.sessionStoreImpl, recipe); err != nil {
+			return fail(req, ErrInvalidParam, err.Error(),
+				"Start with a navigate step; use what navigate|type|click|wait_for; put passwords in text_env (a "+loginrecipe.EnvPrefix+"* daemon environment variable)")
+		}
+		return succeed(req, "Login recipe saved", map[string]any{
+			"status": "saved",
+			"recipe": recipe.Summary(),
+			"note":   fmt.Sprintf("Run it with interact(what='login', recipe='%s'). text_env variables are read from the daemon's environment at run time.", recipe.Name),
+		})
+	case "list":
+		recipes := loginrecipe.List(h.sessionStoreImpl)
+		summaries := make([]loginrecipe.Summary, 0, len(recipes))
+		for _, recipe := range recipes {
+			summaries = append(summaries, recipe.Summary())
+		}
+		return succeed(req, "Login recipes", map[string]any{
+			"recipes": summaries,
+			"count":   len(summaries),
+		})
+	case "remove":
+		if resp, blocked := requireString(req, params.Name, "name", "Add the 'name' of the recipe to remove"); blocked {
+			return resp
+		}
+		if err := loginrecipe.Delete(h.sessionStoreImpl, params.Name); err != nil {
+			if errors.Is(err, loginrecipe.ErrNotFound) {
+				return fail(req, ErrNoData, "Login recipe not found: "+params.Name,
+					"Use configure(what='define_login', operation='list') to see recipes", withParam("name"))
+			}
+			return fail(req, ErrInvalidParam, "Failed to remove login recipe: "+err.Error(), "Try again")
+		}
+		return succeed(req, "Login recipe removed", map[string]any{"status": "removed", "name": params.Name})
+	default:
+		return fail(req, ErrInvalidParam, "Unknown define_login operation: "+params.Operation,
+			"Use operation 'add', 'list', or 'remove'", withParam("operation"))
+	}
+}
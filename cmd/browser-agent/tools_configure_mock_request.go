@@ -0,0 +1,133 @@
+// Purpose: Implements configure(what="mock_request") to stub page fetch/XHR responses in the tracked tab.
+// Why: Frontend resilience is hard to debug against a healthy backend; mocks simulate failures, latency, and odd payloads.
+// Docs: docs/features/feature/request-mocking/index.md
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+)
+
+// toolConfigureMockRequest handles configure(what="mock_request").
+// operation=add (default when url_pattern is set) stores a mock; list (default) returns the
+// active mocks; remove drops mock_id; clear drops every mock.
+func (h *ToolHandler) toolConfigureMockRequest(req JSONRPCRequest, args json.RawMessage) JSONRPCResponse {
+	var params struct {
+		Operation  string            `json:"operation"`
+		URLPattern string            `json:"url_pattern"`
+		Method     string            `json:"method"`
+		Status     *int              `json:"status"`
+		Body       json.RawMessage   `json:"body"`
+		Headers    map[string]string `json:"headers"`
+		DelayMs    int               `json:"delay_ms"`
+		MockID     string            `json:"mock_id"`
+	}
+	if resp, stop := parseArgs(req, args, &params); stop {
+		return resp
+	}
+	if params.Operation == "" {
+		params.Operation = "list"
+		if params.URLPattern != "" {
+			params.Operation = "add"
+		}
+	}
+
+	switch params.Operation {
+	case "add":
+		if params.URLPattern == "" {
+			return fail(req, ErrMissingParam, "Required parameter 'url_pattern' is missing",
+				"Add url_pattern, e.g. '/api/users' or 'https://api.example.com/v1/*'", withParam("url_pattern"))
+		}
+		body, isJSON, err := mockRequestBody(params.Body)
+		if err != nil {
+			return fail(req, ErrInvalidParam, err.Error(), "Pass body as a string or a JSON value", withParam("body"))
+		}
+		headers := params.Headers
+		if isJSON && !hasHeader(headers, "Content-Type") {
+			headers = make(map[string]string, len(params.Headers)+1)
+			for k, v := range params.Headers {
+				headers[k] = v
+			}
+			headers["Content-Type"] = "application/json"
+		}
+		status := 200
+		if params.Status != nil {
+			status = *params.Status
+		}
+		mock, err := h.capture.AddRequestMock(capture.RequestMock{
+			URLPattern: params.URLPattern,
+			Method:     params.Method,
+			Status:     status,
+			Body:       body,
+			Headers:    headers,
+			DelayMs:    params.DelayMs,
+		})
+		if err != nil {
+			return fail(req, ErrInvalidParam, err.Error(), "Fix the mock parameters and call again")
+		}
+		return succeed(req, "Request mock "+mock.ID+" active", map[string]any{
+			"status": "added",
+			"mock":   mock,
+			"hint":   "The extension applies mocks on its next sync; requests it serves are still captured in observe(what=\"network_bodies\")",
+		})
+
+	case "remove":
+		if params.MockID == "" {
+			return fail(req, ErrMissingParam, "Required parameter 'mock_id' is missing",
+				"Call configure(what='mock_request') to list ids", withParam("mock_id"))
+		}
+		if !h.capture.RemoveRequestMock(params.MockID) {
+			return fail(req, ErrInvalidParam, "Unknown mock id: "+params.MockID,
+				"Call configure(what='mock_request') to list ids", withParam("mock_id"))
+		}
+		return succeed(req, "Request mock "+params.MockID+" removed", map[string]any{"status": "removed", "removed": params.MockID})
+
+	case "clear":
+		return succeed(req, "Request mocks cleared", map[string]any{"status": "cleared", "removed": h.capture.ClearRequestMocks()})
+
+	case "list":
+		mocks := h.capture.GetRequestMocks()
+		return succeed(req, fmt.Sprintf("%d request mock(s) active", len(mocks)), map[string]any{
+			"mocks": mocks,
+			"note":  "url_pattern matches as a substring of the request URL, or as a whole-URL glob when it contains *. status 0 simulates a network error.",
+		})
+
+	default:
+		return fail(req, ErrInvalidParam, "Unknown mock_request operation: "+params.Operation,
+			"Use operation 'add', 'list', 'remove', or 'clear'", withParam("operation"))
+	}
+}
+
+// mockRequestBody turns the body argument into the literal response text. A JSON string is
+// used as-is; any other JSON value is compacted and reported as JSON so it gets a content type.
+func mockRequestBody(raw json.RawMessage) (body string, isJSON bool, err error) {
+	trimmed := bytes.TrimSpace(raw)
+	if len(trimmed) == 0 || string(trimmed) == "null" {
+		return "", false, nil
+	}
+	if trimmed[0] == '"' {
+		if err := json.Unmarshal(trimmed, &body); err != nil {
+			return "", false, fmt.Errorf("invalid body string: %w", err)
+		}
+		return body, false, nil
+	}
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, trimmed); err != nil {
+		return "", false, fmt.Errorf("invalid body JSON: %w", err)
+	}
+	return buf.String(), true, nil
+}
+
+func hasHeader(headers map[string]string, name string) bool {
+	for k := range headers {
+		if strings.EqualFold(k, name) {
+			return true
+		}
+	}
+	return false
+}
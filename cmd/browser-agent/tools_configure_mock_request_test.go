@@ -0,0 +1,67 @@
+// Purpose: Tests configure(what="mock_request") add/list/remove/clear and the mocks delivered to the extension.
+// Docs: docs/features/feature/request-mocking/index.md
+
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestConfigureMockRequest_Lifecycle(t *testing.T) {
+	t.Parallel()
+	h, _, cap := makeToolHandler(t)
+
+	added := parseToolResult(t, callConfigureRaw(h, `{"what":"mock_request","url_pattern":"/api/users","method":"post","status":503,"body":{"error":"down"},"delay_ms":1500}`))
+	if added.IsError {
+		t.Fatalf("mock add should succeed, got: %s", firstText(added))
+	}
+	mock := extractResultJSON(t, added)["mock"].(map[string]any)
+	headers, _ := mock["headers"].(map[string]any)
+	if mock["id"] != "mock-1" || mock["method"] != "POST" || mock["status"] != float64(503) ||
+		mock["body"] != `{"error":"down"}` || mock["delay_ms"] != float64(1500) || headers["Content-Type"] != "application/json" {
+		t.Fatalf("mock = %v", mock)
+	}
+
+	plain := extractResultJSON(t, parseToolResult(t, callConfigureRaw(h, `{"what":"mock_request","url_pattern":"https://cdn.test/*.js","body":"oops","headers":{"content-type":"text/plain"}}`)))
+	second := plain["mock"].(map[string]any)
+	if second["status"] != float64(200) || second["body"] != "oops" || len(second["headers"].(map[string]any)) != 1 {
+		t.Fatalf("string body mock = %v, want status 200 and caller headers untouched", second)
+	}
+
+	list := extractResultJSON(t, parseToolResult(t, callConfigureRaw(h, `{"what":"mock_request"}`)))
+	if mocks := list["mocks"].([]any); len(mocks) != 2 {
+		t.Fatalf("mocks = %v, want 2", mocks)
+	}
+	if len(cap.GetRequestMocks()) != 2 {
+		t.Fatalf("capture holds %d mocks, want 2", len(cap.GetRequestMocks()))
+	}
+
+	removed := parseToolResult(t, callConfigureRaw(h, `{"what":"mock_request","operation":"remove","mock_id":"mock-1"}`))
+	if removed.IsError || len(cap.GetRequestMocks()) != 1 {
+		t.Fatalf("remove: %s", firstText(removed))
+	}
+	cleared := extractResultJSON(t, parseToolResult(t, callConfigureRaw(h, `{"what":"mock_request","operation":"clear"}`)))
+	if cleared["removed"] != float64(1) || len(cap.GetRequestMocks()) != 0 {
+		t.Fatalf("clear removed = %v, want 1", cleared["removed"])
+	}
+}
+
+func TestConfigureMockRequest_InvalidArgs(t *testing.T) {
+	t.Parallel()
+	h, _, _ := makeToolHandler(t)
+
+	for _, tc := range []struct{ args, want string }{
+		{`{"what":"mock_request","operation":"add"}`, "url_pattern"},
+		{`{"what":"mock_request","url_pattern":"/api","status":700}`, "status must be"},
+		{`{"what":"mock_request","url_pattern":"/api","delay_ms":120000}`, "delay_ms must be"},
+		{`{"what":"mock_request","operation":"remove"}`, "mock_id"},
+		{`{"what":"mock_request","operation":"remove","mock_id":"mock-9"}`, "Unknown mock id"},
+		{`{"what":"mock_request","operation":"explode"}`, "Unknown mock_request operation"},
+	} {
+		result := parseToolResult(t, callConfigureRaw(h, tc.args))
+		if !result.IsError || !strings.Contains(firstText(result), tc.want) {
+			t.Errorf("%s: expected error containing %q, got %s", tc.args, tc.want, firstText(result))
+		}
+	}
+}
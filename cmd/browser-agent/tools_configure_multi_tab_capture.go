@@ -0,0 +1,32 @@
+// Purpose: Implements configure(what="multi_tab_capture") to capture telemetry from every tab at once.
+// Why: Multi-tab flows (admin + storefront, popup auth) need all tabs recording, each entry attributed to its tab.
+// Docs: docs/features/feature/multi-tab-attribution/index.md
+
+package main
+
+import "encoding/json"
+
+// toolConfigureMultiTabCapture handles configure(what="multi_tab_capture"). With enabled it
+// turns capture from every tab on or off; without it, it reports the current state.
+func (h *ToolHandler) toolConfigureMultiTabCapture(req JSONRPCRequest, args json.RawMessage) JSONRPCResponse {
+	var params struct {
+		Enabled *bool `json:"enabled"`
+	}
+	if resp, stop := parseArgs(req, args, &params); stop {
+		return resp
+	}
+
+	summary := "Multi-tab capture status"
+	if params.Enabled != nil {
+		h.capture.SetMultiTabCapture(*params.Enabled)
+		summary = "Multi-tab capture disabled"
+		if *params.Enabled {
+			summary = "Multi-tab capture enabled"
+		}
+	}
+
+	return succeed(req, summary, map[string]any{
+		"enabled": h.capture.MultiTabCaptureEnabled(),
+		"note":    "Applies on the extension's next sync; open tabs start capturing from that point. Every entry carries tab_id (frame_id for iframes): scope observe with tab_id and see per-tab buffer counts in GET /health. The extension falls back to the tracked tab when the daemon disconnects.",
+	})
+}
@@ -0,0 +1,121 @@
+// Purpose: Implements configure(what="network_budget") and feeds ingested network batches into the budget monitor.
+// Why: Duration and size regressions surface as alerts on ingest instead of waiting for someone to read the waterfall.
+// Docs: docs/features/feature/network-budgets/index.md
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/netbudget"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/types"
+)
+
+// recordNetworkBudgets checks an ingested network batch and alerts on first-time budget violations.
+func (h *ToolHandler) recordNetworkBudgets(activity capture.NetworkActivity) {
+	if h.budgetMonitor == nil || h.alertBuffer == nil {
+		return
+	}
+	for _, v := range h.budgetMonitor.ObserveNetwork(activity, time.Now()) {
+		h.alertBuffer.AddAlert(budgetViolationAlert(v))
+	}
+}
+
+// budgetViolationAlert summarizes the over-budget response in one line.
+func budgetViolationAlert(v netbudget.Violation) types.Alert {
+	ev := v.Evidence
+	what := ev.URL
+	if ev.Method != "" {
+		what = ev.Method + " " + ev.URL
+	}
+	if ev.PageURL != "" {
+		what += " on " + ev.PageURL
+	}
+	return types.Alert{
+		Severity: "warning",
+		Category: "regression",
+		Title:    "Network budget exceeded: " + v.Metric + " " + truncateAlertText(v.Pattern, 100),
+		Detail: fmt.Sprintf("%s: %s %g %s, budget %g %s (%s). Review with observe(what=\"budget_violations\").",
+			what, v.Metric, v.Actual, v.Unit, v.Limit, v.Unit, v.BudgetID),
+		Timestamp: v.FirstSeen.Format(time.RFC3339),
+		Source:    "network_budget",
+	}
+}
+
+// toolConfigureNetworkBudget handles configure(what="network_budget").
+// operation=add (default when pattern is set) registers or updates a budget; list (default)
+// returns the budgets; remove drops budget_id; clear drops every budget.
+func (h *ToolHandler) toolConfigureNetworkBudget(req JSONRPCRequest, args json.RawMessage) JSONRPCResponse {
+	if h.budgetMonitor == nil {
+		return fail(req, ErrNotInitialized, "Network budget monitor not initialized", "Restart the daemon and call again")
+	}
+	var params struct {
+		Operation     string  `json:"operation"`
+		Pattern       string  `json:"pattern"`
+		MaxDurationMs int     `json:"max_duration_ms"`
+		MaxSizeKB     float64 `json:"max_size_kb"`
+		BudgetID      string  `json:"budget_id"`
+	}
+	if resp, stop := parseArgs(req, args, &params); stop {
+		return resp
+	}
+	if params.Operation == "" {
+		params.Operation = "list"
+		if params.Pattern != "" {
+			params.Operation = "add"
+		}
+	}
+
+	m := h.budgetMonitor
+	switch params.Operation {
+	case "add":
+		if params.Pattern == "" {
+			return fail(req, ErrMissingParam, "Required parameter 'pattern' is missing",
+				"Add pattern, e.g. '/api/search' or 'https://cdn.example.com/*.js'", withParam("pattern"))
+		}
+		budget, replaced, err := m.Add(params.Pattern, params.MaxDurationMs, params.MaxSizeKB, time.Now())
+		switch {
+		case errors.Is(err, netbudget.ErrInvalidPattern):
+			return fail(req, ErrInvalidParam, err.Error(), "Shorten pattern and call again", withParam("pattern"))
+		case errors.Is(err, netbudget.ErrNoLimit), errors.Is(err, netbudget.ErrNegativeLimit):
+			return fail(req, ErrInvalidParam, err.Error(), "Set a positive max_duration_ms and/or max_size_kb", withParam("max_duration_ms"))
+		case err != nil:
+			return fail(req, ErrInvalidParam, err.Error(), "Remove a budget with operation 'remove' first")
+		}
+		status, summary := "added", "Network budget "+budget.ID+" added"
+		if replaced {
+			status, summary = "updated", "Network budget "+budget.ID+" updated"
+		}
+		return succeed(req, summary, map[string]any{
+			"status": status,
+			"budget": budget,
+			"hint":   `Checked on every ingested request from now on; overruns raise regression alerts and show in observe(what="budget_violations")`,
+		})
+
+	case "remove":
+		if params.BudgetID == "" {
+			return fail(req, ErrMissingParam, "Required parameter 'budget_id' is missing",
+				"Call configure(what='network_budget') to list ids", withParam("budget_id"))
+		}
+		if err := m.Remove(params.BudgetID); err != nil {
+			return fail(req, ErrInvalidParam, err.Error()+": "+params.BudgetID,
+				"Call configure(what='network_budget') to list ids", withParam("budget_id"))
+		}
+		return succeed(req, "Network budget "+params.BudgetID+" removed", map[string]any{"status": "removed", "removed": params.BudgetID})
+
+	case "clear":
+		return succeed(req, "Network budgets cleared", map[string]any{"status": "cleared", "removed": m.Clear()})
+
+	case "list":
+		budgets := m.List()
+		return succeed(req, fmt.Sprintf("%d network budget(s)", len(budgets)), map[string]any{"budgets": budgets})
+
+	default:
+		return fail(req, ErrInvalidParam, "Unknown network_budget operation: "+params.Operation,
+			"Use operation 'add', 'list', 'remove', or 'clear'", withParam("operation"))
+	}
+}
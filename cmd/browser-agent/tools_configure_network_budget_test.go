@@ -0,0 +1,89 @@
+// Purpose: Tests configure(what="network_budget") add/list/remove/clear, ingest-time alerts, and observe(what="budget_violations").
+// Docs: docs/features/feature/network-budgets/index.md
+
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+)
+
+func TestConfigureNetworkBudget_AlertsOnIngest(t *testing.T) {
+	t.Parallel()
+	h, _, cap := makeToolHandler(t)
+	h.drainAlerts()
+
+	if data := extractResultJSON(t, parseToolResult(t, callObserveRaw(h, "budget_violations"))); data["status"] != "no_budgets" {
+		t.Fatalf("status before any budget = %v", data["status"])
+	}
+
+	added := parseToolResult(t, callConfigureRaw(h, `{"what":"network_budget","pattern":"/api/search","max_duration_ms":400}`))
+	if added.IsError {
+		t.Fatalf("network_budget add should succeed, got: %s", firstText(added))
+	}
+	budget := extractResultJSON(t, added)["budget"].(map[string]any)
+	if budget["id"] != "budget-1" || budget["max_duration_ms"] != float64(400) {
+		t.Fatalf("budget = %v", budget)
+	}
+	callConfigureRaw(h, `{"what":"network_budget","pattern":"https://cdn.shop.test/*.js","max_size_kb":200}`)
+
+	cap.AddNetworkBodies([]capture.NetworkBody{{Method: "GET", URL: "https://shop.test/api/search?q=shoes", Status: 200, Duration: 1250}})
+	cap.AddNetworkBodies([]capture.NetworkBody{{Method: "GET", URL: "https://shop.test/api/search?q=shoes", Status: 200, Duration: 900}})
+	cap.AddNetworkWaterfallEntries([]capture.NetworkWaterfallEntry{
+		{URL: "https://cdn.shop.test/vendor.js", InitiatorType: "script", Duration: 80, TransferSize: 512 * 1024},
+		{URL: "https://cdn.shop.test/app.js", InitiatorType: "script", Duration: 60, TransferSize: 90 * 1024},
+	}, "https://shop.test/")
+
+	var alerts []string
+	for _, a := range h.drainAlerts() {
+		if a.Source == "network_budget" {
+			alerts = append(alerts, a.Title+" | "+a.Detail)
+		}
+	}
+	if len(alerts) != 2 || !strings.Contains(alerts[0], "duration 1250 ms, budget 400 ms") || !strings.Contains(alerts[1], "size 512 kb") {
+		t.Fatalf("budget alerts = %v, want one per distinct overrun", alerts)
+	}
+
+	data := extractResultJSON(t, parseToolResult(t, callObserveRaw(h, "budget_violations")))
+	if data["status"] != "over_budget" || data["total_violations"] != float64(2) {
+		t.Fatalf("budget_violations = %v", data)
+	}
+	var slow map[string]any
+	for _, v := range data["violations"].([]any) {
+		if v.(map[string]any)["metric"] == "duration" {
+			slow = v.(map[string]any)
+		}
+	}
+	if slow == nil || slow["count"] != float64(2) || slow["worst"] != float64(1250) {
+		t.Fatalf("duration violation = %v, want seen twice with worst 1250", slow)
+	}
+
+	removed := parseToolResult(t, callConfigureRaw(h, `{"what":"network_budget","operation":"remove","budget_id":"budget-1"}`))
+	if removed.IsError || len(h.budgetMonitor.List()) != 1 {
+		t.Fatalf("remove: %s", firstText(removed))
+	}
+	cleared := extractResultJSON(t, parseToolResult(t, callConfigureRaw(h, `{"what":"network_budget","operation":"clear"}`)))
+	if cleared["removed"] != float64(1) {
+		t.Fatalf("clear removed = %v, want 1", cleared["removed"])
+	}
+}
+
+func TestConfigureNetworkBudget_InvalidArgs(t *testing.T) {
+	t.Parallel()
+	h, _, _ := makeToolHandler(t)
+
+	for _, args := range []string{
+		`{"what":"network_budget","operation":"add"}`,
+		`{"what":"network_budget","pattern":"/api"}`,
+		`{"what":"network_budget","pattern":"/api","max_duration_ms":-5}`,
+		`{"what":"network_budget","operation":"remove"}`,
+		`{"what":"network_budget","operation":"remove","budget_id":"budget-99"}`,
+		`{"what":"network_budget","operation":"explode"}`,
+	} {
+		if result := parseToolResult(t, callConfigureRaw(h, args)); !result.IsError {
+			t.Errorf("%s should return isError:true", args)
+		}
+	}
+}
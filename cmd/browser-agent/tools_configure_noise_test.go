@@ -10,6 +10,7 @@ import (
 	"testing"
 
 	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/cmd/browser-agent/internal/toolconfigure"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/noise"
 )
 
 // ============================================
@@ -289,6 +290,57 @@ func TestToolConfigureNoise_FullLifecycle(t *testing.T) {
 // Routed via analyze({what: "api_validation"})
 // ============================================
 
+func TestToolConfigureNoiseRules_ActionsOrderingAndMatchCounts(t *testing.T) {
+	t.Parallel()
+	h, server, _ := makeToolHandler(t)
+	h.noiseConfig = noise.NewNoiseConfig() // keep rules out of the shared project store
+
+	server.logs.addEntries([]LogEntry{
+		{"level": "warn", "message": "poll tick", "ts": "2026-05-01T09:00:01Z"},
+		{"level": "warn", "message": "poll tick", "ts": "2026-05-01T09:00:02Z"},
+		{"level": "error", "message": "checkout failed", "ts": "2026-05-01T09:00:03Z"},
+	})
+
+	add := extractResultJSON(t, parseToolResult(t, callConfigureRaw(h,
+		`{"what":"noise_rules","noise_action":"add","pattern":"poll","rule_action":"downgrade"}`)))
+	if add["rules_added"] != float64(1) {
+		t.Fatalf("add = %v, want one rule", add)
+	}
+	callConfigureRaw(h, `{"what":"noise_rules","noise_action":"add","pattern":"checkout","rule_action":"tag","tag":"known-flake"}`)
+
+	logs := extractResultJSON(t, parseToolResult(t, callObserveRaw(h, "logs")))["logs"].([]any)
+	if len(logs) != 3 {
+		t.Fatalf("logs = %v, want all three entries kept", logs)
+	}
+	first := logs[0].(map[string]any)
+	if first["level"] != "debug" || first["noise_original_level"] != "warn" || first["noise_rule"] != "user_1" {
+		t.Fatalf("downgraded entry = %v", first)
+	}
+	if tagged := logs[2].(map[string]any); tagged["noise_tag"] != "known-flake" {
+		t.Fatalf("tagged entry = %v", tagged)
+	}
+
+	list := extractResultJSON(t, parseToolResult(t, callConfigureRaw(h, `{"action":"noise_rules","list":true}`)))
+	matches, _ := list["matches"].(map[string]any)
+	if matches["user_1"] != float64(2) || matches["user_2"] != float64(1) {
+		t.Fatalf("matches = %v, want user_1=2, user_2=1", list["matches"])
+	}
+	if rules := list["rules"].([]any); rules[0].(map[string]any)["id"] != "user_1" {
+		t.Fatalf("user rules should be listed first in evaluation order, got %v", rules[0])
+	}
+
+	moved := parseToolResult(t, callConfigureRaw(h, `{"what":"noise_rules","noise_action":"move","rule_id":"user_2","position":0}`))
+	if moved.IsError {
+		t.Fatalf("move error: %s", firstText(moved))
+	}
+	if rules := h.noiseConfig.ListRules(); rules[0].ID != "user_2" {
+		t.Fatalf("first rule after move = %s, want user_2", rules[0].ID)
+	}
+	if missing := parseToolResult(t, callConfigureRaw(h, `{"what":"noise_rules","noise_action":"move","rule_id":"user_1"}`)); !missing.IsError {
+		t.Fatal("move without position should fail")
+	}
+}
+
 func TestToolValidateAPI_Analyze(t *testing.T) {
 	t.Parallel()
 	env := newConfigureTestEnv(t)
@@ -0,0 +1,91 @@
+// Purpose: Implements configure(what="override") to set geolocation, timezone, and locale for the tracked tab.
+// Why: Region-specific bugs need the page to believe it is somewhere else, in another language, without changing the machine.
+// Docs: docs/features/feature/region-override/index.md
+
+package main
+
+import (
+	"encoding/json"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+)
+
+// geolocationArg is the geolocation argument: {lat, lng, accuracy}.
+type geolocationArg struct {
+	Lat      *float64 `json:"lat"`
+	Lng      *float64 `json:"lng"`
+	Accuracy float64  `json:"accuracy"`
+}
+
+// toolConfigureOverride handles configure(what="override"). operation=set (default when
+// geolocation, timezone, or locale is given) merges the given fields into the active
+// overrides; an empty timezone or locale drops that field. status (default) reports them and
+// clear drops them all.
+func (h *ToolHandler) toolConfigureOverride(req JSONRPCRequest, args json.RawMessage) JSONRPCResponse {
+	var params struct {
+		Operation   string          `json:"operation"`
+		Geolocation *geolocationArg `json:"geolocation"`
+		Timezone    *string         `json:"timezone"`
+		Locale      *string         `json:"locale"`
+	}
+	if resp, stop := parseArgs(req, args, &params); stop {
+		return resp
+	}
+	if params.Operation == "" {
+		params.Operation = "status"
+		if params.Geolocation != nil || params.Timezone != nil || params.Locale != nil {
+			params.Operation = "set"
+		}
+	}
+
+	summary := "Region overrides status"
+	switch params.Operation {
+	case "set":
+		next, _ := h.capture.GetRegionOverride()
+		if g := params.Geolocation; g != nil {
+			if g.Lat == nil || g.Lng == nil {
+				return fail(req, ErrInvalidParam, "geolocation needs lat and lng",
+					"Pass geolocation={lat: 52.52, lng: 13.405}", withParam("geolocation"))
+			}
+			next.Geolocation = &capture.Geolocation{Latitude: *g.Lat, Longitude: *g.Lng, Accuracy: g.Accuracy}
+		}
+		if params.Timezone != nil {
+			next.Timezone = *params.Timezone
+		}
+		if params.Locale != nil {
+			next.Locale = *params.Locale
+		}
+		if err := h.capture.SetRegionOverride(next); err != nil {
+			return fail(req, ErrInvalidParam, err.Error(), "Fix the override and call again")
+		}
+		summary = "Region overrides set"
+	case "clear":
+		_ = h.capture.SetRegionOverride(capture.RegionOverride{})
+		summary = "Region overrides cleared"
+	case "status":
+	default:
+		return fail(req, ErrInvalidParam, "Unknown override operation: "+params.Operation,
+			"Use operation 'set', 'status', or 'clear'", withParam("operation"))
+	}
+
+	data := map[string]any{"active": false}
+	if active, ok := h.capture.GetRegionOverride(); ok {
+		data["active"] = true
+		data["override"] = active
+	}
+	data["note"] = "The extension applies overrides to the tracked tab on its next sync and keeps the debugger attached while they are active. " +
+		"Geolocation still needs the page's location permission. generate(test) and generate(reproduction) replay active overrides."
+	return succeed(req, summary, data)
+}
+
+// activeOverrides lists the browser overrides in effect, for configure(what="load") session context.
+func (h *ToolHandler) activeOverrides() map[string]any {
+	active := map[string]any{}
+	if region, ok := h.capture.GetRegionOverride(); ok {
+		active["region"] = region
+	}
+	if device, ok := h.capture.GetDeviceEmulation(); ok {
+		active["device"] = device
+	}
+	return active
+}
@@ -0,0 +1,105 @@
+// Purpose: Tests configure(what="override") set/merge/clear, session context, and overrides replayed in generated scripts.
+// Docs: docs/features/feature/region-override/index.md
+
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+)
+
+func TestConfigureOverride_SetMergeAndClear(t *testing.T) {
+	t.Parallel()
+	h, _, cap := makeToolHandler(t)
+
+	set := parseToolResult(t, callConfigureRaw(h, `{"what":"override","geolocation":{"lat":52.52,"lng":13.405},"timezone":"Europe/Berlin"}`))
+	if set.IsError {
+		t.Fatalf("override set failed: %s", firstText(set))
+	}
+	parseToolResult(t, callConfigureRaw(h, `{"what":"override","locale":"de-DE"}`))
+	region, ok := cap.GetRegionOverride()
+	if !ok || region.Geolocation == nil || region.Geolocation.Latitude != 52.52 || region.Timezone != "Europe/Berlin" || region.Locale != "de-DE" {
+		t.Fatalf("region after merge = %+v, want geolocation, timezone, and locale", region)
+	}
+
+	status := extractResultJSON(t, parseToolResult(t, callConfigureRaw(h, `{"what":"override"}`)))
+	override, _ := status["override"].(map[string]any)
+	if status["active"] != true || override["locale"] != "de-DE" {
+		t.Fatalf("status = %v", status)
+	}
+
+	load := extractResultJSON(t, parseToolResult(t, callConfigureRaw(h, `{"what":"load"}`)))
+	active, _ := load["active_overrides"].(map[string]any)
+	if loaded, _ := active["region"].(map[string]any); loaded["timezone"] != "Europe/Berlin" {
+		t.Fatalf("session context active_overrides = %v", load["active_overrides"])
+	}
+
+	parseToolResult(t, callConfigureRaw(h, `{"what":"override","timezone":""}`))
+	if region, _ := cap.GetRegionOverride(); region.Timezone != "" || region.Locale != "de-DE" {
+		t.Fatalf("empty timezone should drop only the timezone, got %+v", region)
+	}
+
+	cleared := extractResultJSON(t, parseToolResult(t, callConfigureRaw(h, `{"what":"override","operation":"clear"}`)))
+	if _, ok := cap.GetRegionOverride(); ok || cleared["active"] != false {
+		t.Fatalf("clear left overrides active: %v", cleared)
+	}
+}
+
+func TestConfigureOverride_InvalidArgs(t *testing.T) {
+	t.Parallel()
+	h, _, _ := makeToolHandler(t)
+
+	for _, tc := range []struct{ args, want string }{
+		{`{"what":"override","geolocation":{"lat":52.52}}`, "lat and lng"},
+		{`{"what":"override","geolocation":{"lat":95,"lng":0}}`, "latitude must be"},
+		{`{"what":"override","timezone":"Berlin time"}`, "invalid timezone"},
+		{`{"what":"override","locale":"german!"}`, "invalid locale"},
+		{`{"what":"override","operation":"explode"}`, "Unknown override operation"},
+	} {
+		result := parseToolResult(t, callConfigureRaw(h, tc.args))
+		if !result.IsError || !strings.Contains(firstText(result), tc.want) {
+			t.Errorf("%s: expected error containing %q, got %s", tc.args, tc.want, firstText(result))
+		}
+	}
+}
+
+func TestConfigureOverride_ReplayedInGeneratedScripts(t *testing.T) {
+	t.Parallel()
+	h, _, cap := makeToolHandler(t)
+	cap.ProcessSyncMessage(capture.SyncRequest{ExtSessionID: "s1", Settings: &capture.SyncSettings{
+		TrackingEnabled: true, TrackedTabID: 7, TrackedTabURL: "https://shop.example.com/",
+		Environment: &capture.BrowserEnvironment{Locale: "en-US", Timezone: "America/New_York", ExtensionVersion: "0.7.12"},
+	}}, "client", "test")
+	cap.AddEnhancedActions([]capture.EnhancedAction{{Type: "navigate", Timestamp: time.Now().UnixMilli(), ToURL: "https://shop.example.com/"}})
+	parseToolResult(t, callConfigureRaw(h, `{"what":"override","geolocation":{"lat":52.52,"lng":13.405},"timezone":"Europe/Berlin","locale":"de-DE"}`))
+
+	generate := func(args string) map[string]any {
+		t.Helper()
+		result := parseToolResult(t, h.toolGenerate(JSONRPCRequest{JSONRPC: "2.0", ID: 1}, json.RawMessage(args)))
+		if result.IsError {
+			t.Fatalf("generate %s failed: %s", args, firstText(result))
+		}
+		return extractResultJSON(t, result)
+	}
+	for _, args := range []string{`{"what":"test"}`, `{"what":"reproduction","output_format":"playwright"}`} {
+		script, _ := generate(args)["script"].(string)
+		for _, want := range []string{"locale: 'de-DE'", "timezoneId: 'Europe/Berlin'", "geolocation: { latitude: 52.52, longitude: 13.405 }", "permissions: ['geolocation']"} {
+			if !strings.Contains(script, want) {
+				t.Fatalf("generate %s missing %q:\n%s", args, want, script)
+			}
+		}
+		if strings.Contains(script, "America/New_York") {
+			t.Fatalf("overrides should replace the measured timezone:\n%s", script)
+		}
+	}
+	if script, _ := generate(`{"what":"reproduction"}`)["script"].(string); !strings.Contains(script, "# Overrides: geolocation 52.52,13.405 | Europe/Berlin | de-DE") {
+		t.Fatalf("kaboom reproduction should note the overrides:\n%s", script)
+	}
+	if meta, _ := generate(`{"what":"test"}`)["metadata"].(map[string]any); meta["region_override"] == nil {
+		t.Fatalf("test metadata should carry region_override: %v", meta)
+	}
+}
@@ -0,0 +1,86 @@
+// Purpose: Implements configure(what="rate_limit") for per-client tool call limits and quotas.
+// Why: Shared daemons need different budgets per agent; limits must change without a restart.
+// Docs: docs/features/feature/tool-rate-limits/index.md
+
+package main
+
+import (
+	"encoding/json"
+)
+
+// toolConfigureRateLimit handles configure(what="rate_limit").
+// operation=status (default) reports limits and usage; set (default when a limit is passed)
+// updates the defaults or, with client_id, one client's override; clear removes the override
+// for client_id or, without it, restores startup limits. client_id "self" means the caller.
+func (h *ToolHandler) toolConfigureRateLimit(req JSONRPCRequest, args json.RawMessage) JSONRPCResponse {
+	if h.toolCallLimiter == nil {
+		return fail(req, ErrNotInitialized, "Tool call limiter not initialized", "Restart the daemon and call again")
+	}
+	var params struct {
+		Operation      string `json:"operation"`
+		ClientID       string `json:"client_id"`
+		CallsPerMinute *int   `json:"calls_per_minute"`
+		HourlyQuota    *int   `json:"hourly_quota"`
+	}
+	if resp, stop := parseArgs(req, args, &params); stop {
+		return resp
+	}
+	if params.ClientID == "self" {
+		params.ClientID = req.ClientID
+		if params.ClientID == "" {
+			params.ClientID = anonymousLimiterClient
+		}
+	}
+	if params.Operation == "" {
+		params.Operation = "status"
+		if params.CallsPerMinute != nil || params.HourlyQuota != nil {
+			params.Operation = "set"
+		}
+	}
+
+	l := h.toolCallLimiter
+	summary := "Tool call limits"
+	switch params.Operation {
+	case "status":
+	case "set":
+		if params.CallsPerMinute == nil && params.HourlyQuota == nil {
+			return fail(req, ErrMissingParam, "Pass calls_per_minute and/or hourly_quota",
+				"Add calls_per_minute or hourly_quota (0 = unlimited) and call again", withParam("calls_per_minute"))
+		}
+		limits := l.Limits(params.ClientID)
+		if params.ClientID == "" {
+			limits = l.Status().Defaults
+		}
+		if v := params.CallsPerMinute; v != nil {
+			if *v < 0 {
+				return fail(req, ErrInvalidParam, "calls_per_minute must be >= 0", "Use 0 for unlimited", withParam("calls_per_minute"))
+			}
+			limits.CallsPerMinute = *v
+		}
+		if v := params.HourlyQuota; v != nil {
+			if *v < 0 {
+				return fail(req, ErrInvalidParam, "hourly_quota must be >= 0", "Use 0 for unlimited", withParam("hourly_quota"))
+			}
+			limits.HourlyQuota = *v
+		}
+		l.SetLimits(params.ClientID, limits)
+		summary = "Tool call limits updated"
+	case "clear":
+		l.ClearLimits(params.ClientID)
+		summary = "Tool call limits reset"
+	default:
+		return fail(req, ErrInvalidParam, "Unknown operation: "+params.Operation,
+			"Use operation status, set, or clear", withParam("operation"))
+	}
+
+	caller := req.ClientID
+	if caller == "" {
+		caller = anonymousLimiterClient
+	}
+	return succeed(req, summary, map[string]any{
+		"limits":      l.Status(),
+		"your_client": caller,
+		"your_limits": l.Limits(caller),
+		"note":        "Limits apply per X-Kaboom-Client; calls without the header share the \"default\" bucket. Rejections return JSON-RPC error data with remaining, reset_at, and retry_after_ms.",
+	})
+}
@@ -0,0 +1,50 @@
+// Purpose: Tests configure(what="rate_limit") status, per-client overrides, and validation.
+// Docs: docs/features/feature/tool-rate-limits/index.md
+
+package main
+
+import (
+	"testing"
+)
+
+func TestConfigureRateLimit_SetOverrideAndClear(t *testing.T) {
+	t.Parallel()
+	h, _, _ := makeToolHandler(t)
+
+	status := extractResultJSON(t, parseToolResult(t, callConfigureRaw(h, `{"what":"rate_limit"}`)))
+	defaults := status["limits"].(map[string]any)["defaults"].(map[string]any)
+	if defaults["calls_per_minute"] != float64(defaultToolCallsPerMinute) {
+		t.Fatalf("defaults = %v, want %d calls/min", defaults, defaultToolCallsPerMinute)
+	}
+
+	result := parseToolResult(t, callConfigureRaw(h, `{"what":"rate_limit","client_id":"ci-bot","hourly_quota":5}`))
+	if result.IsError {
+		t.Fatalf("set override should succeed, got: %s", firstText(result))
+	}
+	if got := h.toolCallLimiter.Limits("ci-bot"); got.HourlyQuota != 5 || got.CallsPerMinute != defaultToolCallsPerMinute {
+		t.Fatalf("ci-bot limits = %+v, want quota 5 on top of defaults", got)
+	}
+	if got := h.toolCallLimiter.Limits("other"); got.HourlyQuota != 0 {
+		t.Fatalf("other client limits = %+v, want defaults untouched", got)
+	}
+
+	callConfigureRaw(h, `{"what":"rate_limit","operation":"clear","client_id":"ci-bot"}`)
+	if got := h.toolCallLimiter.Limits("ci-bot"); got.HourlyQuota != 0 {
+		t.Fatalf("ci-bot limits after clear = %+v, want defaults", got)
+	}
+}
+
+func TestConfigureRateLimit_InvalidParams(t *testing.T) {
+	t.Parallel()
+	h, _, _ := makeToolHandler(t)
+
+	for _, args := range []string{
+		`{"what":"rate_limit","calls_per_minute":-1}`,
+		`{"what":"rate_limit","operation":"set"}`,
+		`{"what":"rate_limit","operation":"reset"}`,
+	} {
+		if result := parseToolResult(t, callConfigureRaw(h, args)); !result.IsError {
+			t.Errorf("%s should return isError:true", args)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+// Purpose: Implements configure(what="register_proto") for protobuf descriptor sets used to decode WebSocket frames.
+// Why: Binary socket frames are opaque hex without the app's schema; one registration makes every later read typed.
+// Docs: docs/features/feature/websocket-decoding/index.md
+
+package main
+
+import (
+	"encoding/json"
+	"path/filepath"
+)
+
+// toolConfigureRegisterProto handles configure(what="register_proto").
+// operation=register (default when path is passed) loads a FileDescriptorSet and binds
+// proto_message to binary frames on URLs containing url; list (default) reports the
+// registrations; clear removes them.
+func (h *ToolHandler) toolConfigureRegisterProto(req JSONRPCRequest, args json.RawMessage) JSONRPCResponse {
+	var params struct {
+		Operation    string `json:"operation"`
+		Path         string `json:"path"`
+		ProtoMessage string `json:"proto_message"`
+		URL          string `json:"url"`
+	}
+	if resp, stop := parseArgs(req, args, &params); stop {
+		return resp
+	}
+	if params.Operation == "" {
+		params.Operation = "list"
+		if params.Path != "" {
+			params.Operation = "register"
+		}
+	}
+
+	decoders := h.capture.WSDecoders()
+	summary := "Registered protobuf descriptors"
+	switch params.Operation {
+	case "list":
+	case "register":
+		if params.Path == "" {
+			return fail(req, ErrMissingParam, "Required parameter 'path' is missing",
+				"Pass the path of a descriptor set built with protoc --descriptor_set_out=<file> --include_imports", withParam("path"))
+		}
+		path, err := filepath.Abs(params.Path)
+		if err != nil {
+			return fail(req, ErrInvalidParam, err.Error(), "Pass an absolute path", withParam("path"))
+		}
+		if _, err := decoders.Register(path, params.ProtoMessage, params.URL); err != nil {
+			return fail(req, ErrInvalidParam, "Cannot register descriptor set: "+err.Error(),
+				"Check the path and proto_message, then call again", withParam("path"))
+		}
+		summary = "Protobuf descriptor registered"
+	case "clear":
+		decoders.Clear()
+		summary = "Protobuf descriptors cleared"
+	default:
+		return fail(req, ErrInvalidParam, "Unknown operation: "+params.Operation,
+			"Use operation register, list, or clear", withParam("operation"))
+	}
+
+	return succeed(req, summary, map[string]any{
+		"registrations": decoders.List(),
+		"note":          "observe(what=\"websocket_events\") decodes message payloads into a decoded field {codec, message_type, value}. JSON, socket.io, and SignalR frames decode without registration; binary frames decode with the newest registration whose url is a substring of the socket URL. The extension keeps the bytes of binary frames under 256 bytes only; larger frames stay a size placeholder.",
+	})
+}
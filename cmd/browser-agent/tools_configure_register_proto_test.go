@@ -0,0 +1,81 @@
+// Purpose: Tests configure(what="register_proto") and decoded payloads in observe(what="websocket_events").
+// Docs: docs/features/feature/websocket-decoding/index.md
+
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+)
+
+func TestObserveWebSocketEvents_DecodesPayloads(t *testing.T) {
+	t.Parallel()
+	h, _, cap := makeToolHandler(t)
+	cap.AddWebSocketEvents([]capture.WebSocketEvent{
+		{ID: "ws-1", Event: "open", URL: "wss://app.test/socket.io/?EIO=4"},
+		{ID: "ws-1", Event: "message", Direction: "incoming", URL: "wss://app.test/socket.io/?EIO=4", Data: `42["chat",{"text":"hi"}]`},
+		{ID: "ws-2", Event: "message", Direction: "incoming", URL: "wss://app.test/feed", Data: `{"type":"tick","price":3}`},
+	})
+
+	req := JSONRPCRequest{JSONRPC: "2.0", ID: 1}
+	result := extractResultJSON(t, parseToolResult(t, h.toolObserve(req, json.RawMessage(`{"what":"websocket_events"}`))))
+	entries := result["entries"].([]any)
+	if len(entries) != 3 {
+		t.Fatalf("entries = %d, want 3", len(entries))
+	}
+	codecs := map[string]string{}
+	for _, e := range entries {
+		entry := e.(map[string]any)
+		decoded, ok := entry["decoded"].(map[string]any)
+		if entry["event"] != "message" {
+			if ok {
+				t.Fatalf("lifecycle event decoded: %v", entry)
+			}
+			continue
+		}
+		codecs[decoded["message_type"].(string)] = decoded["codec"].(string)
+	}
+	if codecs["chat"] != "socket.io" || codecs["tick"] != "json" {
+		t.Fatalf("decoded message types = %v", codecs)
+	}
+
+	summary := extractResultJSON(t, parseToolResult(t, h.toolObserve(req, json.RawMessage(`{"what":"websocket_events","summary":true}`))))
+	if byType := summary["by_message_type"].(map[string]any); byType["chat"] != float64(1) || byType["tick"] != float64(1) {
+		t.Fatalf("by_message_type = %v", byType)
+	}
+}
+
+func TestConfigureRegisterProto_Operations(t *testing.T) {
+	t.Parallel()
+	h, _, _ := makeToolHandler(t)
+
+	listed := extractResultJSON(t, parseToolResult(t, callConfigureRaw(h, `{"what":"register_proto"}`)))
+	if regs := listed["registrations"].([]any); len(regs) != 0 {
+		t.Fatalf("registrations = %v, want none", regs)
+	}
+
+	junk := filepath.Join(t.TempDir(), "junk.pb")
+	if err := os.WriteFile(junk, []byte("not a descriptor"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	pathJSON, _ := json.Marshal(junk)
+	bad := parseToolResult(t, callConfigureRaw(h, `{"what":"register_proto","path":`+string(pathJSON)+`}`))
+	if !bad.IsError || !strings.Contains(firstText(bad), "FileDescriptorSet") {
+		t.Fatalf("junk file should fail naming FileDescriptorSet, got: %s", firstText(bad))
+	}
+
+	missing := parseToolResult(t, callConfigureRaw(h, `{"what":"register_proto","operation":"register"}`))
+	if !missing.IsError || !strings.Contains(firstText(missing), "path") {
+		t.Fatalf("register without path should fail, got: %s", firstText(missing))
+	}
+
+	cleared := parseToolResult(t, callConfigureRaw(h, `{"what":"register_proto","operation":"clear"}`))
+	if cleared.IsError {
+		t.Fatalf("clear failed: %s", firstText(cleared))
+	}
+}
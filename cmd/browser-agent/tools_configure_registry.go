@@ -36,13 +36,8 @@ var configureHandlers = map[string]ModeHandler{
 		return h.toolDoctor(req)
 	},
 	// Direct method delegates
-	"noise_rule": func(h *ToolHandler, req JSONRPCRequest, args json.RawMessage) JSONRPCResponse {
-		rewrittenArgs, err := cfg.RewriteNoiseRuleArgs(args)
-		if err != nil {
-			return fail(req, ErrInvalidJSON, "Invalid JSON arguments: "+err.Error(), "Fix JSON syntax and call again")
-		}
-		return toolconfigure.HandleNoise(h, req, rewrittenArgs)
-	},
+	"noise_rule":            configureNoiseRules,
+	"noise_rules":           configureNoiseRules,
 	"clear":                 method((*ToolHandler).toolConfigureClear),
 	"audit_log":             method((*ToolHandler).toolGetAuditLog),
 	"streaming":             method((*ToolHandler).toolConfigureStreaming),
@@ -69,6 +64,29 @@ var configureHandlers = map[string]ModeHandler{
 	"replay_sequence":       method((*ToolHandler).toolConfigureReplaySequence),
 	"security_mode":     cfgLocal(toolconfigure.HandleSecurityMode),
 	"network_recording": method((*ToolHandler).toolConfigureNetworkRecording),
+	"silence":           method((*ToolHandler).toolConfigureSilence),
+	"subscribe":         method((*ToolHandler).toolConfigureSubscribe),
+	"rate_limit":        method((*ToolHandler).toolConfigureRateLimit),
+	"circuit":           method((*ToolHandler).toolConfigureCircuit),
+	"ws_sampling":       method((*ToolHandler).toolConfigureWSSampling),
+	"body_limits":       method((*ToolHandler).toolConfigureBodyLimits),
+	"retention":         method((*ToolHandler).toolConfigureRetention),
+	"archive":           method((*ToolHandler).toolConfigureArchive),
+	"register_proto":    method((*ToolHandler).toolConfigureRegisterProto),
+	"screenshot_redaction": method((*ToolHandler).toolConfigureScreenshotRedaction),
+	"lock_api_contract": method((*ToolHandler).toolConfigureLockAPIContract),
+	"finding_state":     method((*ToolHandler).toolConfigureFindingState),
+	"add_webhook":       method((*ToolHandler).toolConfigureAddWebhook),
+	"list_webhooks":     method((*ToolHandler).toolConfigureListWebhooks),
+	"remove_webhook":    method((*ToolHandler).toolConfigureRemoveWebhook),
+	"invariant":         method((*ToolHandler).toolConfigureInvariant),
+	"mock_request":      method((*ToolHandler).toolConfigureMockRequest),
+	"network_budget":    method((*ToolHandler).toolConfigureNetworkBudget),
+	"dedup":             method((*ToolHandler).toolConfigureLogDedup),
+	"multi_tab_capture": method((*ToolHandler).toolConfigureMultiTabCapture),
+	"override":          method((*ToolHandler).toolConfigureOverride),
+	"list_states":       method((*ToolHandler).toolConfigureListStates),
+	"define_login":      method((*ToolHandler).toolConfigureDefineLogin),
 	"action_jitter": cfgLocal(toolconfigure.HandleActionJitter),
 	"report_issue":          method((*ToolHandler).toolConfigureReportIssue),
 	"setup_quality_gates":   method((*ToolHandler).toolConfigureSetupQualityGates),
@@ -81,5 +99,14 @@ func cfgLocal(fn func(toolconfigure.Deps, JSONRPCRequest, json.RawMessage) JSONR
 	}
 }
 
+// configureNoiseRules serves noise_rules and its original singular name noise_rule.
+func configureNoiseRules(h *ToolHandler, req JSONRPCRequest, args json.RawMessage) JSONRPCResponse {
+	rewrittenArgs, err := cfg.RewriteNoiseRuleArgs(args)
+	if err != nil {
+		return fail(req, ErrInvalidJSON, "Invalid JSON arguments: "+err.Error(), "Fix JSON syntax and call again")
+	}
+	return toolconfigure.HandleNoise(h, req, rewrittenArgs)
+}
+
 // getValidConfigureActions returns a sorted, comma-separated list of valid configure actions.
 func getValidConfigureActions() string { return sortedMapKeys(configureHandlers) }
@@ -0,0 +1,354 @@
+// Purpose: Implements configure(what="retention") for per-buffer capacity and TTL, persisted to project settings.
+// Why: Buffer sizes and ages were compile-time constants; a soak run and a quick repro need different retention.
+// Docs: docs/features/feature/ttl-retention/index.md
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/persistence"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/util"
+)
+
+const (
+	// retentionConsole is the console log buffer, owned by the server's LogStore.
+	retentionConsole = "console"
+
+	retentionSettingsNamespace = "settings"
+	retentionSettingsKey       = "retention"
+
+	// retentionSweepInterval is how often TTLs are enforced on buffers that receive no new entries.
+	retentionSweepInterval = 30 * time.Second
+)
+
+// retentionSetting is the persisted form of one buffer's retention.
+type retentionSetting struct {
+	MaxEntries int    `json:"max_entries"`
+	TTL        string `json:"ttl"`
+}
+
+// retentionBuffers returns every buffer whose retention is configurable, in reporting order.
+func retentionBuffers() []string {
+	return append([]string{retentionConsole}, capture.RetentionBuffers()...)
+}
+
+// parseRetentionTTL parses a TTL given as a Go duration; "" and "0" mean no age limit.
+func parseRetentionTTL(value string) (time.Duration, error) {
+	if value == "" || value == "0" {
+		return 0, nil
+	}
+	return time.ParseDuration(value)
+}
+
+// formatRetentionTTL renders a TTL for responses and settings.
+func formatRetentionTTL(ttl time.Duration) string {
+	if ttl <= 0 {
+		return "0"
+	}
+	return ttl.String()
+}
+
+// parseRetentionFlag parses one --retention value: buffer=[max_entries][:ttl],
+// e.g. "network=500", "console=5000:30m", or "actions=:1h".
+func parseRetentionFlag(value string, base map[string]capture.Retention) (string, capture.Retention, error) {
+	buffer, spec, ok := strings.Cut(value, "=")
+	buffer = strings.TrimSpace(buffer)
+	current, known := base[buffer]
+	if !ok || !known {
+		return "", capture.Retention{}, fmt.Errorf("want buffer=[max_entries][:ttl] with buffer one of %s", strings.Join(retentionBuffers(), ", "))
+	}
+	entries, ttl, _ := strings.Cut(spec, ":")
+	if entries = strings.TrimSpace(entries); entries != "" {
+		n, err := strconv.Atoi(entries)
+		if err != nil {
+			return "", capture.Retention{}, fmt.Errorf("invalid max_entries %q", entries)
+		}
+		current.MaxEntries = n
+	}
+	if ttl = strings.TrimSpace(ttl); ttl != "" {
+		d, err := parseRetentionTTL(ttl)
+		if err != nil {
+			return "", capture.Retention{}, fmt.Errorf("invalid ttl %q: %v", ttl, err)
+		}
+		current.TTL = d
+	}
+	if err := current.Validate(); err != nil {
+		return "", capture.Retention{}, err
+	}
+	return buffer, current, nil
+}
+
+// parseRetentionFlags folds repeated --retention values into per-buffer settings; later values
+// for the same buffer refine earlier ones.
+func parseRetentionFlags(values []string, consoleMax int) (map[string]capture.Retention, error) {
+	base := compiledRetentionDefaults(consoleMax)
+	out := make(map[string]capture.Retention)
+	for _, value := range values {
+		buffer, r, err := parseRetentionFlag(value, base)
+		if err != nil {
+			return nil, fmt.Errorf("%q: %w", value, err)
+		}
+		base[buffer] = r
+		out[buffer] = r
+	}
+	return out, nil
+}
+
+// compiledRetentionDefaults returns the built-in retention of every buffer; consoleMax comes from --max-entries.
+func compiledRetentionDefaults(consoleMax int) map[string]capture.Retention {
+	defaults := map[string]capture.Retention{retentionConsole: {MaxEntries: consoleMax}}
+	for _, buffer := range capture.RetentionBuffers() {
+		defaults[buffer], _ = capture.DefaultRetention(buffer)
+	}
+	return defaults
+}
+
+// initRetention records the startup retention (built-ins overlaid with --retention flags),
+// applies it, then applies persisted overrides, and starts the TTL sweep.
+func (h *ToolHandler) initRetention() {
+	consoleMax := defaultMaxEntries
+	if logs := h.consoleLogs(); logs != nil {
+		consoleMax, _ = logs.retention()
+	}
+	h.retentionDefaults = compiledRetentionDefaults(consoleMax)
+	for buffer, r := range retentionFlagConfig {
+		h.retentionDefaults[buffer] = r
+	}
+	for buffer, r := range retentionFlagConfig {
+		_ = h.applyRetention(buffer, r)
+	}
+	for buffer, r := range loadRetentionSettings(h.sessionStoreImpl) {
+		_ = h.applyRetention(buffer, r)
+	}
+
+	util.SafeGo(func() {
+		ticker := time.NewTicker(retentionSweepInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-h.shutdownCtx.Done():
+				return
+			case now := <-ticker.C:
+				h.expireRetention(now)
+			}
+		}
+	})
+}
+
+// loadRetentionSettings reads persisted overrides, skipping unknown buffers and invalid values.
+func loadRetentionSettings(store *persistence.SessionStore) map[string]capture.Retention {
+	out := make(map[string]capture.Retention)
+	if store == nil {
+		return out
+	}
+	data, err := store.Load(retentionSettingsNamespace, retentionSettingsKey)
+	if err != nil {
+		return out
+	}
+	var saved map[string]retentionSetting
+	if json.Unmarshal(data, &saved) != nil {
+		return out
+	}
+	for buffer, setting := range saved {
+		if !slices.Contains(retentionBuffers(), buffer) {
+			continue
+		}
+		ttl, err := parseRetentionTTL(setting.TTL)
+		r := capture.Retention{MaxEntries: setting.MaxEntries, TTL: ttl}
+		if err != nil || r.Validate() != nil {
+			continue
+		}
+		out[buffer] = r
+	}
+	return out
+}
+
+// saveRetentionSettings persists every buffer whose retention differs from its startup value.
+func (h *ToolHandler) saveRetentionSettings() error {
+	if h.sessionStoreImpl == nil {
+		return nil
+	}
+	overrides := make(map[string]retentionSetting)
+	for _, buffer := range retentionBuffers() {
+		if r := h.bufferRetention(buffer); r != h.retentionDefaults[buffer] {
+			overrides[buffer] = retentionSetting{MaxEntries: r.MaxEntries, TTL: formatRetentionTTL(r.TTL)}
+		}
+	}
+	if len(overrides) == 0 {
+		return h.sessionStoreImpl.Delete(retentionSettingsNamespace, retentionSettingsKey)
+	}
+	data, err := json.Marshal(overrides)
+	if err != nil {
+		return err
+	}
+	return h.sessionStoreImpl.Save(retentionSettingsNamespace, retentionSettingsKey, data)
+}
+
+// consoleLogs returns the server's log store, or nil when the handler runs without one.
+func (h *ToolHandler) consoleLogs() *LogStore {
+	if h.server == nil {
+		return nil
+	}
+	return h.server.logs
+}
+
+// bufferRetention returns the effective retention of one buffer.
+func (h *ToolHandler) bufferRetention(buffer string) capture.Retention {
+	if buffer == retentionConsole {
+		logs := h.consoleLogs()
+		if logs == nil {
+			return h.retentionDefaults[retentionConsole]
+		}
+		maxEntries, ttl := logs.retention()
+		return capture.Retention{MaxEntries: maxEntries, TTL: ttl}
+	}
+	if h.capture == nil {
+		return h.retentionDefaults[buffer]
+	}
+	return h.capture.GetRetention()[buffer]
+}
+
+// applyRetention validates and applies one buffer's retention.
+func (h *ToolHandler) applyRetention(buffer string, r capture.Retention) error {
+	if buffer != retentionConsole {
+		if h.capture == nil {
+			return fmt.Errorf("capture is not available")
+		}
+		return h.capture.SetRetention(buffer, r)
+	}
+	if err := r.Validate(); err != nil {
+		return err
+	}
+	if logs := h.consoleLogs(); logs != nil {
+		logs.setRetention(r.MaxEntries, r.TTL)
+	}
+	return nil
+}
+
+// expireRetention enforces TTLs on every buffer.
+func (h *ToolHandler) expireRetention(now time.Time) {
+	if logs := h.consoleLogs(); logs != nil {
+		logs.expireEntries(now)
+	}
+	if h.capture != nil {
+		h.capture.ExpireRetained(now)
+	}
+}
+
+// retentionJSON renders one buffer's retention for responses.
+func retentionJSON(r capture.Retention) map[string]any {
+	return map[string]any{"max_entries": r.MaxEntries, "ttl": formatRetentionTTL(r.TTL)}
+}
+
+// effectiveRetention renders the live retention of every buffer, for configure and /health.
+func effectiveRetention(logs *LogStore, cap *capture.Store) map[string]any {
+	report := make(map[string]any)
+	if logs != nil {
+		maxEntries, ttl := logs.retention()
+		report[retentionConsole] = retentionJSON(capture.Retention{MaxEntries: maxEntries, TTL: ttl})
+	}
+	if cap != nil {
+		for buffer, r := range cap.GetRetention() {
+			report[buffer] = retentionJSON(r)
+		}
+	}
+	return report
+}
+
+// defaultsReport renders the startup retention each buffer returns to on clear.
+func defaultsReport(defaults map[string]capture.Retention) map[string]any {
+	report := make(map[string]any, len(defaults))
+	for buffer, r := range defaults {
+		report[buffer] = retentionJSON(r)
+	}
+	return report
+}
+
+// toolConfigureRetention handles configure(what="retention").
+// operation=status (default) reports every buffer; set (default when a limit is passed) changes
+// max_entries and/or ttl of one buffer; clear restores one buffer, or all, to the startup values.
+func (h *ToolHandler) toolConfigureRetention(req JSONRPCRequest, args json.RawMessage) JSONRPCResponse {
+	var params struct {
+		Operation  string  `json:"operation"`
+		Buffer     string  `json:"buffer"`
+		MaxEntries *int    `json:"max_entries"`
+		TTL        *string `json:"ttl"`
+	}
+	if resp, stop := parseArgs(req, args, &params); stop {
+		return resp
+	}
+	if params.Operation == "" {
+		params.Operation = "status"
+		if params.MaxEntries != nil || params.TTL != nil {
+			params.Operation = "set"
+		}
+	}
+	if params.Buffer != "" && params.Buffer != "all" {
+		if _, ok := h.retentionDefaults[params.Buffer]; !ok {
+			return fail(req, ErrInvalidParam, "Unknown buffer: "+params.Buffer,
+				"Use buffer "+strings.Join(retentionBuffers(), ", "), withParam("buffer"))
+		}
+	}
+
+	summary := "Buffer retention"
+	switch params.Operation {
+	case "status":
+	case "set":
+		if params.Buffer == "" || params.Buffer == "all" {
+			return fail(req, ErrMissingParam, "Pass the buffer to change",
+				"Add buffer ("+strings.Join(retentionBuffers(), ", ")+") and call again", withParam("buffer"))
+		}
+		if params.MaxEntries == nil && params.TTL == nil {
+			return fail(req, ErrMissingParam, "Pass max_entries and/or ttl",
+				"Add max_entries (a count) or ttl (a duration such as '30m', or '0' for no age limit)", withParam("max_entries"))
+		}
+		r := h.bufferRetention(params.Buffer)
+		if params.MaxEntries != nil {
+			r.MaxEntries = *params.MaxEntries
+		}
+		if params.TTL != nil {
+			ttl, err := parseRetentionTTL(*params.TTL)
+			if err != nil {
+				return fail(req, ErrInvalidParam, "Invalid ttl: "+err.Error(),
+					"Use Go duration syntax such as '30m' or '2h', or '0' for no age limit", withParam("ttl"))
+			}
+			r.TTL = ttl
+		}
+		if err := h.applyRetention(params.Buffer, r); err != nil {
+			return fail(req, ErrInvalidParam, err.Error(),
+				fmt.Sprintf("Use max_entries between 1 and %d and a ttl of 0 or at least %s", capture.MaxRetentionEntries, capture.MinRetentionTTL))
+		}
+		summary = "Buffer retention updated"
+	case "clear":
+		buffers := retentionBuffers()
+		if params.Buffer != "" && params.Buffer != "all" {
+			buffers = []string{params.Buffer}
+		}
+		for _, buffer := range buffers {
+			_ = h.applyRetention(buffer, h.retentionDefaults[buffer])
+		}
+		summary = "Buffer retention reset"
+	default:
+		return fail(req, ErrInvalidParam, "Unknown operation: "+params.Operation,
+			"Use operation status, set, or clear", withParam("operation"))
+	}
+
+	data := map[string]any{
+		"retention": effectiveRetention(h.consoleLogs(), h.capture),
+		"defaults":  defaultsReport(h.retentionDefaults),
+		"note":      "Shrinking a buffer or shortening its ttl evicts the oldest entries immediately; a ttl of 0 keeps entries until they are evicted by count. Memory caps still apply. Changes persist for this project until cleared.",
+	}
+	if params.Operation != "status" {
+		if err := h.saveRetentionSettings(); err != nil {
+			data["persist_error"] = err.Error()
+		}
+	}
+	return succeed(req, summary, data)
+}
@@ -0,0 +1,115 @@
+// Purpose: Tests configure(what="retention"), its persisted settings, the --retention flag syntax, and /health reporting.
+// Docs: docs/features/feature/ttl-retention/index.md
+
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/persistence"
+)
+
+func TestConfigureRetention_SetPersistReportAndClear(t *testing.T) {
+	t.Parallel()
+	h, server, cap := makeToolHandler(t)
+	store, err := persistence.NewSessionStoreWithInterval(t.TempDir(), time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	h.sessionStoreImpl = store
+
+	for i := 0; i < 5; i++ {
+		server.logs.addEntries([]LogEntry{{"level": "info", "message": "line " + string(rune('a'+i))}})
+	}
+
+	set := parseToolResult(t, callConfigureRaw(h, `{"action":"retention","buffer":"console","max_entries":3}`))
+	if set.IsError {
+		t.Fatalf("set should succeed, got: %s", firstText(set))
+	}
+	console := extractResultJSON(t, set)["retention"].(map[string]any)["console"].(map[string]any)
+	if console["max_entries"] != float64(3) || console["ttl"] != "0" {
+		t.Fatalf("console retention = %v", console)
+	}
+	if got := server.logs.getEntryCount(); got != 3 {
+		t.Fatalf("console entries = %d, want 3 after shrinking", got)
+	}
+
+	if resp := parseToolResult(t, callConfigureRaw(h, `{"what":"retention","buffer":"network","ttl":"30m"}`)); resp.IsError {
+		t.Fatalf("network ttl should succeed, got: %s", firstText(resp))
+	}
+	if got := cap.GetRetention()[capture.RetentionNetwork]; got.TTL != 30*time.Minute || got.MaxEntries != capture.MaxNetworkBodies {
+		t.Fatalf("network retention = %+v", got)
+	}
+
+	saved := loadRetentionSettings(store)
+	if saved["console"].MaxEntries != 3 || saved["network"].TTL != 30*time.Minute || len(saved) != 2 {
+		t.Fatalf("persisted settings = %+v", saved)
+	}
+
+	rr := httptest.NewRecorder()
+	server.handleHealth(rr, httptest.NewRequest(http.MethodGet, "/health", nil), cap)
+	var health struct {
+		Logs      map[string]any            `json:"logs"`
+		Retention map[string]map[string]any `json:"retention"`
+	}
+	if err := json.Unmarshal(rr.Body.Bytes(), &health); err != nil {
+		t.Fatal(err)
+	}
+	if health.Logs["max_entries"] != float64(3) || health.Retention["network"]["ttl"] != "30m0s" || health.Retention["actions"]["max_entries"] != float64(capture.MaxEnhancedActions) {
+		t.Fatalf("/health = logs %v, retention %v", health.Logs, health.Retention)
+	}
+
+	cleared := extractResultJSON(t, parseToolResult(t, callConfigureRaw(h, `{"what":"retention","operation":"clear"}`)))
+	if cleared["retention"].(map[string]any)["console"].(map[string]any)["max_entries"] != float64(100) {
+		t.Fatalf("clear should restore the startup capacity, got %v", cleared["retention"])
+	}
+	if saved := loadRetentionSettings(store); len(saved) != 0 {
+		t.Fatalf("clear should drop persisted settings, got %+v", saved)
+	}
+}
+
+func TestConfigureRetention_RejectsBadInput(t *testing.T) {
+	t.Parallel()
+	h, _, _ := makeToolHandler(t)
+	h.sessionStoreImpl = nil
+	for _, args := range []string{
+		`{"what":"retention","buffer":"bogus","max_entries":10}`,
+		`{"what":"retention","max_entries":10}`,
+		`{"what":"retention","buffer":"websocket","ttl":"5s"}`,
+		`{"what":"retention","buffer":"websocket","ttl":"soon"}`,
+		`{"what":"retention","buffer":"actions","max_entries":0}`,
+		`{"what":"retention","operation":"resize"}`,
+	} {
+		if result := parseToolResult(t, callConfigureRaw(h, args)); !result.IsError {
+			t.Errorf("%s should fail, got: %s", args, firstText(result))
+		}
+	}
+}
+
+func TestParseRetentionFlags(t *testing.T) {
+	t.Parallel()
+	got, err := parseRetentionFlags([]string{"console=5000:30m", "actions=:1h", "actions=200", "performance=50"}, 1000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got["console"] != (capture.Retention{MaxEntries: 5000, TTL: 30 * time.Minute}) {
+		t.Fatalf("console = %+v", got["console"])
+	}
+	if got["actions"] != (capture.Retention{MaxEntries: 200, TTL: time.Hour}) {
+		t.Fatalf("actions = %+v, want later values to refine earlier ones", got["actions"])
+	}
+	if got["performance"].MaxEntries != 50 {
+		t.Fatalf("performance = %+v", got["performance"])
+	}
+
+	for _, bad := range []string{"network", "logs=10", "network=many", "network=10:5s", "websocket=0"} {
+		if _, err := parseRetentionFlags([]string{bad}, 1000); err == nil {
+			t.Errorf("%q should fail", bad)
+		}
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	cfg "github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/tools/configure"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/tools/observe"
 	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/util"
 )
 
@@ -46,6 +47,18 @@ func (h *ToolHandler) toolConfigureTestBoundaryStart(req JSONRPCRequest, args js
 		return *errResp
 	}
 
+	// Fingerprint storage so observe(storage, test_id) can report what the test changed.
+	if enabled, _, _ := h.capture.GetTrackingStatus(); enabled && h.capture.IsExtensionConnected() {
+		if err := observe.CaptureStorageBaseline(h.capture, result.TestID); err != nil {
+			result.StorageBaseline = "unavailable: " + err.Error()
+		} else {
+			result.StorageBaseline = "recorded"
+		}
+	}
+
+	// Tag everything captured from here on with the boundary ID.
+	h.capture.SetTestBoundaryStart(result.TestID)
+
 	// Track the active boundary.
 	h.activeBoundariesMu.Lock()
 	defer h.activeBoundariesMu.Unlock()
@@ -81,5 +94,9 @@ func (h *ToolHandler) toolConfigureTestBoundaryEnd(req JSONRPCRequest, args json
 			withParam("test_id"))
 	}
 
+	h.capture.SetTestBoundaryEnd(result.TestID)
+	logs, _ := h.GetLogEntries()
+	result.Summary = observe.BoundarySummary(h.capture, logs, result.TestID, time.Now())
+
 	return cfg.BuildTestBoundaryEndResponse(req.ID, result, wasActive)
 }
@@ -0,0 +1,55 @@
+// Purpose: Implements configure(what="screenshot_redaction") for CSS selectors blacked out on every screenshot.
+// Why: Screenshots bypass text redaction; regions holding PII must be painted out before the image is written.
+// Docs: docs/features/feature/screenshot-redaction/index.md
+
+package main
+
+import (
+	"encoding/json"
+)
+
+// toolConfigureScreenshotRedaction handles configure(what="screenshot_redaction").
+// operation=status (default) lists the selectors; set (default when selectors are passed)
+// replaces them; clear removes them all.
+func (h *ToolHandler) toolConfigureScreenshotRedaction(req JSONRPCRequest, args json.RawMessage) JSONRPCResponse {
+	var params struct {
+		Operation string   `json:"operation"`
+		Selectors []string `json:"selectors"`
+	}
+	if resp, stop := parseArgs(req, args, &params); stop {
+		return resp
+	}
+	if params.Operation == "" {
+		params.Operation = "status"
+		if params.Selectors != nil {
+			params.Operation = "set"
+		}
+	}
+
+	summary := "Screenshot redaction"
+	switch params.Operation {
+	case "status":
+	case "set":
+		if len(params.Selectors) == 0 {
+			return fail(req, ErrMissingParam, "Pass selectors: CSS selectors whose regions are blacked out",
+				"Add selectors, e.g. [\".ssn\", \"#card-number\"], or use operation=clear", withParam("selectors"))
+		}
+		if err := h.capture.SetScreenshotRedactSelectors(params.Selectors); err != nil {
+			return fail(req, ErrInvalidParam, err.Error(), "Use fewer or shorter selectors", withParam("selectors"))
+		}
+		summary = "Screenshot redaction updated"
+	case "clear":
+		_ = h.capture.SetScreenshotRedactSelectors(nil)
+		summary = "Screenshot redaction cleared"
+	default:
+		return fail(req, ErrInvalidParam, "Unknown operation: "+params.Operation,
+			"Use operation status, set, or clear", withParam("operation"))
+	}
+
+	selectors := h.capture.GetScreenshotRedactSelectors()
+	return succeed(req, summary, map[string]any{
+		"selectors": selectors,
+		"enabled":   len(selectors) > 0,
+		"note":      "Every element matching a selector is blacked out server-side before a screenshot is saved or returned. The extension picks up changes on its next sync; observe/interact screenshots use the new list immediately.",
+	})
+}
@@ -0,0 +1,118 @@
+// Purpose: Tests configure(what="screenshot_redaction") and the redaction and highlight painting on POST /screenshots.
+// Docs: docs/features/feature/screenshot-redaction/index.md
+
+package main
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"image"
+	"image/color"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestConfigureScreenshotRedaction_SetStatusClear(t *testing.T) {
+	t.Parallel()
+	h, _, cap := makeToolHandler(t)
+
+	set := parseToolResult(t, callConfigureRaw(h, `{"what":"screenshot_redaction","selectors":[".ssn"," #card ",".ssn"]}`))
+	if set.IsError {
+		t.Fatalf("set should succeed, got: %s", firstText(set))
+	}
+	if got := cap.GetScreenshotRedactSelectors(); len(got) != 2 || got[1] != "#card" {
+		t.Fatalf("selectors = %v", got)
+	}
+	status := extractResultJSON(t, parseToolResult(t, callConfigureRaw(h, `{"what":"screenshot_redaction"}`)))
+	if status["enabled"] != true || len(status["selectors"].([]any)) != 2 {
+		t.Fatalf("status = %v", status)
+	}
+
+	empty := parseToolResult(t, callConfigureRaw(h, `{"what":"screenshot_redaction","operation":"set","selectors":[]}`))
+	if !empty.IsError || !strings.Contains(firstText(empty), "missing_param") {
+		t.Fatalf("set without selectors should fail with missing_param, got: %s", firstText(empty))
+	}
+
+	cleared := extractResultJSON(t, parseToolResult(t, callConfigureRaw(h, `{"what":"screenshot_redaction","operation":"clear"}`)))
+	if cleared["enabled"] != false || len(cap.GetScreenshotRedactSelectors()) != 0 {
+		t.Fatalf("clear = %v", cleared)
+	}
+}
+
+func TestScreenshotRedaction_PaintsRegionsBeforeSaving(t *testing.T) {
+	t.Parallel()
+	env := newToolTestEnv(t)
+	env.capture.SetTrackingStatusForTest(1, "https://example.com")
+	env.capture.SimulateExtensionConnectForTest()
+	mux, _ := setupHTTPRoutes(env.server, env.capture)
+	if err := env.capture.SetScreenshotRedactSelectors([]string{".ssn"}); err != nil {
+		t.Fatal(err)
+	}
+
+	wait := startToolCall(t, func() JSONRPCResponse {
+		return env.handler.toolObserve(JSONRPCRequest{JSONRPC: "2.0", ID: 1},
+			json.RawMessage(`{"what":"screenshot","format":"png","highlight_selector":"#buy"}`))
+	})
+
+	q := awaitPendingQuery(t, env.capture, "screenshot")
+	queryID := q.ID
+	var params map[string]any
+	_ = json.Unmarshal(q.Params, &params)
+	if redact, _ := params["redact_selectors"].([]any); len(redact) != 1 || redact[0] != ".ssn" || params["highlight_selector"] != "#buy" {
+		t.Fatalf("query params = %v, want redact_selectors and highlight_selector forwarded", params)
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, 100, 50))
+	for y := 0; y < 50; y++ {
+		for x := 0; x < 100; x++ {
+			img.Set(x, y, color.RGBA{255, 255, 255, 255})
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+	body, _ := json.Marshal(map[string]any{
+		"data_url":  "data:image/png;base64," + base64.StdEncoding.EncodeToString(buf.Bytes()),
+		"query_id":  queryID,
+		"redact":    []map[string]any{{"x": 10, "y": 10, "width": 20, "height": 10, "viewport_width": 100}},
+		"highlight": []map[string]any{{"x": 60, "y": 20, "width": 20, "height": 10, "viewport_width": 100}},
+	})
+	httpReq := httptest.NewRequest(http.MethodPost, "http://localhost/screenshots", bytes.NewReader(body))
+	httpReq.Header.Set("X-Kaboom-Client", "kaboom-extension/redaction-test")
+	rr := httptest.NewRecorder()
+	mux.ServeHTTP(rr, httpReq)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("POST /screenshots = %d %s", rr.Code, rr.Body.String())
+	}
+
+	result := parseToolResult(t, wait())
+	if result.IsError {
+		t.Fatalf("screenshot failed: %s", firstText(result))
+	}
+	data := extractResultJSON(t, result)
+	if data["redacted"] != float64(1) || data["highlighted"] != float64(1) {
+		t.Fatalf("result = %v, want one redacted and one highlighted region", data)
+	}
+	if len(result.Content) < 2 || result.Content[1].Type != "image" {
+		t.Fatalf("expected an inline image block, got %+v", result.Content)
+	}
+	raw, err := base64.StdEncoding.DecodeString(result.Content[1].Data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	saved, err := png.Decode(bytes.NewReader(raw))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r, g, b, _ := saved.At(15, 15).RGBA(); r != 0 || g != 0 || b != 0 {
+		t.Fatalf("redacted pixel = %d,%d,%d, want black", r>>8, g>>8, b>>8)
+	}
+	if r, g, _, _ := saved.At(58, 25).RGBA(); r>>8 != 255 || g>>8 == 255 {
+		t.Fatal("highlight frame missing left of the highlighted element")
+	}
+}
@@ -7,12 +7,15 @@ package main
 import (
 	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/persistence"
 	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/session"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/types"
 )
 
 // configureSessionDeps defines the narrow interface that configureSessionHandler needs.
 type configureSessionDeps interface {
 	requireSessionStore(req JSONRPCRequest) (JSONRPCResponse, bool)
 	invalidateSummaryPref()
+	clientSourceControl(clientID string) *types.SourceControl
+	activeOverrides() map[string]any
 }
 
 type configureSessionHandler struct {
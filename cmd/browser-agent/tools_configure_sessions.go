@@ -24,7 +24,7 @@ func (h *configureSessionHandler) toolDiffSessions(req JSONRPCRequest, args json
 		return fail(req, ErrNotInitialized, "Session manager not initialized", "Internal error — do not retry")
 	}
 
-	result, err := h.sessionManager.HandleTool(args)
+	result, err := h.sessionManager.HandleToolWithSourceControl(args, h.deps.clientSourceControl(req.ClientID))
 	if err != nil {
 		return fail(req, ErrInvalidParam, err.Error(), "Fix request parameters and retry")
 	}
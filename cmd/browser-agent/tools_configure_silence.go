@@ -0,0 +1,16 @@
+// Purpose: Delegates alert silence handling to the toolconfigure sub-package.
+// Why: Keeps the handler wiring in main thin while logic lives in the sub-package.
+// Docs: docs/features/feature/push-alerts/index.md
+
+package main
+
+import (
+	"encoding/json"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/cmd/browser-agent/internal/toolconfigure"
+)
+
+// toolConfigureSilence delegates to the sub-package handler.
+func (h *ToolHandler) toolConfigureSilence(req JSONRPCRequest, args json.RawMessage) JSONRPCResponse {
+	return toolconfigure.HandleSilence(h.alertBuffer, req, args)
+}
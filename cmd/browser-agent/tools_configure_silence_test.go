@@ -0,0 +1,55 @@
+// Purpose: Tests for configure(what="silence") start/status/clear flows and doctor visibility.
+// Docs: docs/features/feature/push-alerts/index.md
+
+package main
+
+import (
+	"testing"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/types"
+)
+
+func TestConfigureSilence_StartListsInDoctorAndSuppressesAlerts(t *testing.T) {
+	t.Parallel()
+	h, _, _ := makeToolHandler(t)
+
+	result := parseToolResult(t, callConfigureRaw(h, `{"what":"silence","duration":"30m","categories":["anomaly"],"reason":"migration"}`))
+	if result.IsError {
+		t.Fatalf("silence start should succeed, got: %s", firstText(result))
+	}
+	data := extractResultJSON(t, result)
+	silence := data["silence"].(map[string]any)
+	if silence["reason"] != "migration" || silence["id"] == "" {
+		t.Fatalf("silence = %v", silence)
+	}
+
+	h.alertBuffer.AddAlert(types.Alert{Severity: "warning", Category: "anomaly", Title: "spike"})
+	if alerts := h.drainAlerts(); len(alerts) != 0 {
+		t.Fatalf("alerts = %+v, want none while silenced", alerts)
+	}
+
+	doctor := extractResultJSON(t, parseToolResult(t, callConfigureRaw(h, `{"what":"doctor"}`)))
+	if silences, ok := doctor["alert_silences"].([]any); !ok || len(silences) != 1 {
+		t.Fatalf("doctor alert_silences = %v, want 1 entry", doctor["alert_silences"])
+	}
+
+	cleared := extractResultJSON(t, parseToolResult(t, callConfigureRaw(h, `{"what":"silence","operation":"clear"}`)))
+	if cleared["removed"] != float64(1) {
+		t.Fatalf("removed = %v, want 1", cleared["removed"])
+	}
+}
+
+func TestConfigureSilence_InvalidDuration(t *testing.T) {
+	t.Parallel()
+	h, _, _ := makeToolHandler(t)
+
+	for _, args := range []string{
+		`{"what":"silence","duration":"soon"}`,
+		`{"what":"silence","operation":"start"}`,
+		`{"what":"silence","operation":"clear","silence_id":"silence_99"}`,
+	} {
+		if result := parseToolResult(t, callConfigureRaw(h, args)); !result.IsError {
+			t.Errorf("%s should return isError:true", args)
+		}
+	}
+}
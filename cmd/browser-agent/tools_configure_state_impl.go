@@ -5,8 +5,10 @@ package main
 
 import (
 	"encoding/json"
+	"time"
 
 	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/persistence"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/sessionbundle"
 )
 
 func (h *configureSessionHandler) toolConfigureStore(req JSONRPCRequest, args json.RawMessage) JSONRPCResponse {
@@ -102,6 +104,9 @@ func (h *configureSessionHandler) toolLoadSessionContext(req JSONRPCRequest, arg
 		if ctx.Performance != nil {
 			responseData["performance"] = ctx.Performance
 		}
+		if overrides := h.deps.activeOverrides(); len(overrides) > 0 {
+			responseData["active_overrides"] = overrides
+		}
 		return succeed(req, "Session context loaded", responseData)
 	}
 
@@ -124,12 +129,23 @@ func (h *ToolHandler) toolConfigureClear(req JSONRPCRequest, args json.RawMessag
 		buffer = "all"
 	}
 
+	responseData := map[string]any{"status": "ok", "buffer": buffer}
+	// Clearing everything ends the session: archive it first so it stays searchable.
+	if buffer == "all" {
+		if session, archived, err := h.archiveSession(sessionbundle.ReasonClear); err != nil {
+			responseData["archive_error"] = err.Error()
+		} else if archived {
+			responseData["archived"] = archivedSessionJSON(h.sessionArchive, session)
+		}
+	}
+
 	cleared, ok := h.clearConfiguredBuffer(buffer)
 	if !ok {
 		return fail(req, ErrInvalidParam, "Unknown buffer: "+buffer, "Use a valid buffer value", withParam("buffer"), withHint("all, network, websocket, actions, logs, inbox"))
 	}
+	h.bufferClears.record(buffer, time.Now())
 
-	responseData := map[string]any{"status": "ok", "buffer": buffer, "cleared": cleared}
+	responseData["cleared"] = cleared
 	return succeed(req, "Buffer cleared", responseData)
 }
 
@@ -141,8 +157,9 @@ func (h *ToolHandler) clearConfiguredBuffer(buffer string) (any, bool) {
 		h.capture.ClearAll()
 		h.server.logs.clearEntries()
 		cleared := map[string]any{
-			"buffers":                "all",
-			"extension_logs_cleared": h.capture.ClearExtensionLogs(),
+			"buffers":                 "all",
+			"extension_logs_cleared":  h.capture.ClearExtensionLogs(),
+			"error_clusters_resolved": h.resolveErrorClusters(),
 		}
 		if h.server.pushInbox != nil {
 			drained := h.server.pushInbox.DrainAll()
@@ -157,6 +174,9 @@ func (h *ToolHandler) clearConfiguredBuffer(buffer string) (any, bool) {
 				"waiters":        annotationCleared.Waiters,
 			}
 		}
+		if h.transportMonitor != nil {
+			h.transportMonitor.Reset()
+		}
 		return cleared, true
 	case "network":
 		counts := h.capture.ClearNetworkBuffers()
@@ -164,13 +184,15 @@ func (h *ToolHandler) clearConfiguredBuffer(buffer string) (any, bool) {
 	case "websocket":
 		counts := h.capture.ClearWebSocketBuffers()
 		return map[string]int{"events": counts.WebSocketEvents, "connections": counts.WebSocketStatus}, true
+	case "sse":
+		return map[string]int{"events": h.capture.ClearSSEBuffer()}, true
 	case "actions":
 		counts := h.capture.ClearActionBuffer()
 		return map[string]int{"actions": counts.Actions}, true
 	case "logs":
 		logCount := h.server.logs.getEntryCount()
 		h.server.logs.clearEntries()
-		return map[string]int{"logs": logCount}, true
+		return map[string]int{"logs": logCount, "error_clusters_resolved": h.resolveErrorClusters()}, true
 	case "inbox":
 		if h.server.pushInbox != nil {
 			drained := h.server.pushInbox.DrainAll()
@@ -0,0 +1,16 @@
+// Purpose: Delegates push subscription handling to the toolconfigure sub-package.
+// Why: Keeps the handler wiring in main thin while logic lives in the sub-package.
+// Docs: docs/features/feature/push-alerts/index.md
+
+package main
+
+import (
+	"encoding/json"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/cmd/browser-agent/internal/toolconfigure"
+)
+
+// toolConfigureSubscribe delegates to the sub-package handler.
+func (h *ToolHandler) toolConfigureSubscribe(req JSONRPCRequest, args json.RawMessage) JSONRPCResponse {
+	return toolconfigure.HandleSubscribe(h.notifyHub, req, args)
+}
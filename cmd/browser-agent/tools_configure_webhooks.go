@@ -0,0 +1,148 @@
+// Purpose: Implements configure(what="add_webhook"|"list_webhooks"|"remove_webhook") and builds the alert events posted to webhooks.
+// Why: Push notifications only reach attached agents; teams want circuit trips, new errors, and new findings in their own channels.
+// Docs: docs/features/feature/alert-webhooks/index.md
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/analysis"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/findings"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/webhooks"
+)
+
+// toolConfigureAddWebhook handles configure(what="add_webhook").
+func (h *ToolHandler) toolConfigureAddWebhook(req JSONRPCRequest, args json.RawMessage) JSONRPCResponse {
+	if h.webhooks == nil {
+		return fail(req, ErrNotInitialized, "Webhooks not initialized", "Restart the daemon and call again")
+	}
+	var params struct {
+		URL    string   `json:"url"`
+		Events []string `json:"events"`
+		Format string   `json:"format"`
+	}
+	if resp, stop := parseArgs(req, args, &params); stop {
+		return resp
+	}
+	if params.URL == "" {
+		return fail(req, ErrMissingParam, "Required parameter 'url' is missing",
+			"Add the endpoint, e.g. a Slack incoming-webhook URL", withParam("url"))
+	}
+
+	wh, err := h.webhooks.Add(params.URL, params.Events, params.Format)
+	switch {
+	case errors.Is(err, webhooks.ErrInvalidURL):
+		return fail(req, ErrInvalidParam, err.Error(), "Pass a full http(s) URL", withParam("url"))
+	case errors.Is(err, webhooks.ErrUnknownFormat):
+		return fail(req, ErrInvalidParam, err.Error(), "Use format json, slack, or discord, or omit it", withParam("format"))
+	case errors.Is(err, webhooks.ErrTooManyWebhooks):
+		return fail(req, ErrInvalidParam, err.Error(), `Remove one with configure(what="remove_webhook") first`)
+	case err != nil:
+		return fail(req, ErrInvalidParam, err.Error(), "Omit events to receive all of them", withParam("events"))
+	}
+	return succeed(req, "Webhook "+wh.ID+" added", map[string]any{"webhook": wh})
+}
+
+// toolConfigureListWebhooks handles configure(what="list_webhooks").
+func (h *ToolHandler) toolConfigureListWebhooks(req JSONRPCRequest, _ json.RawMessage) JSONRPCResponse {
+	if h.webhooks == nil {
+		return fail(req, ErrNotInitialized, "Webhooks not initialized", "Restart the daemon and call again")
+	}
+	list := h.webhooks.List()
+	return succeed(req, fmt.Sprintf("%d webhook(s)", len(list)), map[string]any{
+		"webhooks": list,
+		"events":   webhooks.AllEvents,
+	})
+}
+
+// toolConfigureRemoveWebhook handles configure(what="remove_webhook").
+func (h *ToolHandler) toolConfigureRemoveWebhook(req JSONRPCRequest, args json.RawMessage) JSONRPCResponse {
+	if h.webhooks == nil {
+		return fail(req, ErrNotInitialized, "Webhooks not initialized", "Restart the daemon and call again")
+	}
+	var params struct {
+		WebhookID string `json:"webhook_id"`
+	}
+	if resp, stop := parseArgs(req, args, &params); stop {
+		return resp
+	}
+	if params.WebhookID == "" {
+		return fail(req, ErrMissingParam, "Required parameter 'webhook_id' is missing",
+			`Use an id from configure(what="list_webhooks")`, withParam("webhook_id"))
+	}
+	if err := h.webhooks.Remove(params.WebhookID); err != nil {
+		return fail(req, ErrInvalidParam, err.Error()+": "+params.WebhookID,
+			`Use an id from configure(what="list_webhooks")`, withParam("webhook_id"))
+	}
+	return succeed(req, "Webhook "+params.WebhookID+" removed", map[string]any{"removed": params.WebhookID})
+}
+
+// circuitOpenedWebhookEvent mirrors the circuit_opened push notification.
+func circuitOpenedWebhookEvent(data map[string]any, now time.Time) webhooks.Event {
+	return webhooks.Event{
+		Event:     webhooks.EventCircuitOpened,
+		Severity:  "warning",
+		Title:     "Capture circuit breaker opened",
+		Detail:    "Telemetry is being dropped until the browser event rate recovers.",
+		Timestamp: now.UTC(),
+		Data:      data,
+	}
+}
+
+// errorClusterWebhookEvent reports a cluster seen for the first time, or one that reappeared after a clear.
+func errorClusterWebhookEvent(r analysis.ClusterHistoryRecord, regressed bool, now time.Time) webhooks.Event {
+	title, detail := "New error: ", fmt.Sprintf("First seen %s; %d occurrence(s) this session.", r.FirstSeen.UTC().Format(time.RFC3339), r.SessionCount)
+	if regressed {
+		title, detail = "Error regressed: ", fmt.Sprintf("Cleared earlier and seen again (regression #%d).", r.Regressions)
+	}
+	return webhooks.Event{
+		Event:     webhooks.EventErrorCluster,
+		Severity:  "error",
+		Title:     title + truncateAlertText(r.Message, 120),
+		Detail:    detail,
+		Timestamp: now.UTC(),
+		Data:      map[string]any{"cluster_id": r.ID, "status": r.Status, "regressions": r.Regressions},
+	}
+}
+
+// findingWebhookEvent maps a new or regressed security or performance finding to its webhook
+// event. Other categories (accessibility) are not delivered; ok is false for them.
+func findingWebhookEvent(f findings.Finding, regressed bool, now time.Time) (webhooks.Event, bool) {
+	var event, label string
+	switch f.Category {
+	case "security":
+		event, label = webhooks.EventSecurityFinding, "security finding"
+	case "performance":
+		event, label = webhooks.EventPerfRegression, "performance regression"
+	default:
+		return webhooks.Event{}, false
+	}
+	title := f.Title
+	if title == "" {
+		title = f.FindingID
+	}
+	state := "New " + label
+	if regressed {
+		state = "Regressed " + label
+	}
+	severity := "warning"
+	if findings.SeverityRank(f.Severity) >= 3 {
+		severity = "error"
+	}
+	detail := "Detected by " + f.Source
+	if f.Scope != "" {
+		detail += " on " + f.Scope
+	}
+	return webhooks.Event{
+		Event:     event,
+		Severity:  severity,
+		Title:     state + ": " + truncateAlertText(title, 120),
+		Detail:    detail + ".",
+		Timestamp: now.UTC(),
+		Data:      map[string]any{"id": f.ID, "finding_id": f.FindingID, "state": f.State, "severity": f.Severity},
+	}, true
+}
@@ -0,0 +1,112 @@
+// Purpose: Tests configure(what="add_webhook"|"list_webhooks"|"remove_webhook") and delivery of new error clusters and findings.
+// Docs: docs/features/feature/alert-webhooks/index.md
+
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/state"
+)
+
+// webhookSink is an endpoint that forwards each JSON payload it receives.
+func webhookSink(t *testing.T) (string, <-chan map[string]any) {
+	t.Helper()
+	got := make(chan map[string]any, 16)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var payload map[string]any
+		_ = json.Unmarshal(body, &payload)
+		got <- payload
+	}))
+	t.Cleanup(srv.Close)
+	return srv.URL + "/hook/secret-token", got
+}
+
+func waitWebhook(t *testing.T, got <-chan map[string]any) map[string]any {
+	t.Helper()
+	select {
+	case payload := <-got:
+		return payload
+	case <-time.After(5 * time.Second):
+		t.Fatal("webhook was not delivered")
+		return nil
+	}
+}
+
+func TestConfigureWebhooks_AddListRemove(t *testing.T) {
+	t.Parallel()
+	h, _, _ := makeToolHandler(t)
+	url, _ := webhookSink(t)
+
+	added := parseToolResult(t, callConfigureRaw(h, `{"what":"add_webhook","url":"`+url+`","events":["error_cluster"]}`))
+	if added.IsError {
+		t.Fatalf("add_webhook should succeed, got: %s", firstText(added))
+	}
+	wh := extractResultJSON(t, added)["webhook"].(map[string]any)
+	if wh["format"] != "json" || strings.Contains(wh["url"].(string), "secret-token") {
+		t.Fatalf("webhook = %v, want json format and origin-only url", wh)
+	}
+
+	list := extractResultJSON(t, parseToolResult(t, callConfigureRaw(h, `{"what":"list_webhooks"}`)))
+	if hooks, _ := list["webhooks"].([]any); len(hooks) != 1 {
+		t.Fatalf("webhooks = %v, want 1", list["webhooks"])
+	}
+
+	removed := parseToolResult(t, callConfigureRaw(h, `{"what":"remove_webhook","webhook_id":"`+wh["id"].(string)+`"}`))
+	if removed.IsError || len(h.webhooks.List()) != 0 {
+		t.Fatalf("remove_webhook: %s", firstText(removed))
+	}
+}
+
+func TestConfigureWebhooks_InvalidArgs(t *testing.T) {
+	t.Parallel()
+	h, _, _ := makeToolHandler(t)
+
+	for _, args := range []string{
+		`{"what":"add_webhook"}`,
+		`{"what":"add_webhook","url":"file:///etc/passwd"}`,
+		`{"what":"add_webhook","url":"https://example.com/hook","events":["page_load"]}`,
+		`{"what":"add_webhook","url":"https://example.com/hook","format":"xml"}`,
+		`{"what":"remove_webhook","webhook_id":"wh-99"}`,
+	} {
+		if result := parseToolResult(t, callConfigureRaw(h, args)); !result.IsError {
+			t.Errorf("%s should return isError:true", args)
+		}
+	}
+}
+
+func TestWebhooks_DeliverNewErrorClustersAndFindings(t *testing.T) {
+	t.Setenv(state.StateDirEnv, t.TempDir())
+	h, _, cap := makeToolHandler(t)
+	url, got := webhookSink(t)
+	if _, err := h.webhooks.Add(url, nil, "json"); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+
+	h.recordErrorClusters([]LogEntry{{"level": "error", "message": "TypeError: cart is undefined"}})
+	payload := waitWebhook(t, got)
+	if payload["event"] != "error_cluster" || !strings.HasPrefix(payload["title"].(string), "New error: TypeError") {
+		t.Fatalf("error cluster payload = %v", payload)
+	}
+	// A known cluster is not posted again.
+	h.recordErrorClusters([]LogEntry{{"level": "error", "message": "TypeError: cart is undefined"}})
+
+	addLCPSnapshot(cap, 5200)
+	callToolRaw(h, "observe", `{"what":"vitals"}`)
+	payload = waitWebhook(t, got)
+	if payload["event"] != "perf_regression" || !strings.HasPrefix(payload["title"].(string), "New performance regression") {
+		t.Fatalf("finding payload = %v", payload)
+	}
+	select {
+	case extra := <-got:
+		t.Fatalf("unexpected extra delivery: %v", extra)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
@@ -0,0 +1,64 @@
+// Purpose: Implements configure(what="ws_sampling") for per-connection WebSocket sampling policies.
+// Why: High-frequency sockets flood the buffer; agents choose the rate a stream keeps and which events always survive.
+// Docs: docs/features/feature/websocket-sampling/index.md
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+)
+
+// toolConfigureWSSampling handles configure(what="ws_sampling").
+// operation=list (default) returns the policies; set (default when rate is passed) adds or
+// replaces the policy for url_pattern; remove drops it; clear drops every policy.
+func (h *ToolHandler) toolConfigureWSSampling(req JSONRPCRequest, args json.RawMessage) JSONRPCResponse {
+	var params struct {
+		Operation  string   `json:"operation"`
+		URLPattern string   `json:"url_pattern"`
+		Rate       *float64 `json:"rate"`
+		AlwaysKeep []string `json:"always_keep"`
+	}
+	if resp, stop := parseArgs(req, args, &params); stop {
+		return resp
+	}
+	if params.Operation == "" {
+		params.Operation = "list"
+		if params.Rate != nil {
+			params.Operation = "set"
+		}
+	}
+
+	summary := "WebSocket sampling policies"
+	switch params.Operation {
+	case "list":
+	case "set":
+		if params.Rate == nil {
+			return fail(req, ErrMissingParam, "Required parameter 'rate' is missing",
+				"Add rate (messages kept per second per connection; 0 keeps only always_keep events)", withParam("rate"))
+		}
+		policy := capture.WSSamplingPolicy{URLPattern: params.URLPattern, Rate: *params.Rate, AlwaysKeep: params.AlwaysKeep}
+		if err := h.capture.SetWSSamplingPolicy(policy); err != nil {
+			return fail(req, ErrInvalidParam, err.Error(), "Fix the policy parameters and call again")
+		}
+		summary = fmt.Sprintf("WebSocket sampling policy set for %q", params.URLPattern)
+	case "remove":
+		if !h.capture.RemoveWSSamplingPolicy(params.URLPattern) {
+			return fail(req, ErrInvalidParam, fmt.Sprintf("No sampling policy for url_pattern %q", params.URLPattern),
+				`Use a url_pattern from configure(what="ws_sampling")`, withParam("url_pattern"))
+		}
+		summary = fmt.Sprintf("WebSocket sampling policy for %q removed", params.URLPattern)
+	case "clear":
+		summary = fmt.Sprintf("Cleared %d WebSocket sampling policies", h.capture.ClearWSSamplingPolicies())
+	default:
+		return fail(req, ErrInvalidParam, "Unknown operation: "+params.Operation,
+			"Use operation list, set, remove, or clear", withParam("operation"))
+	}
+
+	return succeed(req, summary, map[string]any{
+		"policies": h.capture.GetWSSamplingPolicies(),
+		"note":     "The first policy whose url_pattern the connection URL contains applies (empty matches every connection). Each connection keeps up to rate messages per second, bursting to one second's worth; events in always_keep (open, close, error, incoming, outgoing; default open, close, error) are never dropped. observe(what=\"websocket_status\") shows achieved_rate, kept, and dropped per connection; observe(what=\"websocket_events\") adds a dropped_estimate.",
+	})
+}
@@ -0,0 +1,88 @@
+// Purpose: Tests configure(what="ws_sampling") policies and the observe websocket drop estimate.
+// Docs: docs/features/feature/websocket-sampling/index.md
+
+package main
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+)
+
+func TestConfigureWSSampling_SetListRemoveClear(t *testing.T) {
+	t.Parallel()
+	h, _, _ := makeToolHandler(t)
+
+	set := parseToolResult(t, callConfigureRaw(h, `{"what":"ws_sampling","url_pattern":"ticks","rate":2,"always_keep":["error","close"]}`))
+	if set.IsError {
+		t.Fatalf("set should succeed, got: %s", firstText(set))
+	}
+	policies := extractResultJSON(t, set)["policies"].([]any)
+	if len(policies) != 1 {
+		t.Fatalf("policies = %v, want 1", policies)
+	}
+	p := policies[0].(map[string]any)
+	if p["url_pattern"] != "ticks" || p["rate"] != float64(2) || len(p["always_keep"].([]any)) != 2 {
+		t.Fatalf("policy = %v", p)
+	}
+
+	callConfigureRaw(h, `{"what":"ws_sampling","rate":10}`)
+	listed := extractResultJSON(t, parseToolResult(t, callConfigureRaw(h, `{"what":"ws_sampling"}`)))["policies"].([]any)
+	if len(listed) != 2 {
+		t.Fatalf("list = %v, want 2 policies", listed)
+	}
+
+	if removed := parseToolResult(t, callConfigureRaw(h, `{"what":"ws_sampling","operation":"remove","url_pattern":"ticks"}`)); removed.IsError {
+		t.Fatalf("remove should succeed, got: %s", firstText(removed))
+	}
+	cleared := extractResultJSON(t, parseToolResult(t, callConfigureRaw(h, `{"what":"ws_sampling","operation":"clear"}`)))
+	if len(cleared["policies"].([]any)) != 0 {
+		t.Fatalf("clear left policies: %v", cleared["policies"])
+	}
+}
+
+func TestConfigureWSSampling_RejectsBadInput(t *testing.T) {
+	t.Parallel()
+	h, _, _ := makeToolHandler(t)
+	for _, args := range []string{
+		`{"what":"ws_sampling","operation":"set"}`,
+		`{"what":"ws_sampling","rate":-1}`,
+		`{"what":"ws_sampling","rate":1,"always_keep":["ping"]}`,
+		`{"what":"ws_sampling","operation":"remove","url_pattern":"missing"}`,
+		`{"what":"ws_sampling","operation":"pause"}`,
+	} {
+		if result := parseToolResult(t, callConfigureRaw(h, args)); !result.IsError {
+			t.Errorf("%s should fail, got: %s", args, firstText(result))
+		}
+	}
+}
+
+func TestObserveWebSocketEvents_ReportsDroppedEstimate(t *testing.T) {
+	t.Parallel()
+	h, _, cap := makeToolHandler(t)
+
+	if result := parseToolResult(t, callObserveRaw(h, "websocket_events")); extractResultJSON(t, result)["sampling"] != nil {
+		t.Fatalf("sampling should be absent without policies: %s", firstText(result))
+	}
+
+	callConfigureRaw(h, `{"what":"ws_sampling","url_pattern":"ticks","rate":0}`)
+	now := time.Now().UTC()
+	events := []capture.WebSocketEvent{{Timestamp: now.Format(time.RFC3339Nano), Event: "open", ID: "ws-1", URL: "wss://example.com/ticks"}}
+	for i := range 5 {
+		events = append(events, capture.WebSocketEvent{
+			Timestamp: now.Format(time.RFC3339Nano), Event: "message", ID: "ws-1", Direction: "incoming", Data: fmt.Sprintf(`{"i":%d}`, i),
+		})
+	}
+	cap.AddWebSocketEvents(events)
+
+	data := extractResultJSON(t, parseToolResult(t, callObserveRaw(h, "websocket_events")))
+	sampling, ok := data["sampling"].(map[string]any)
+	if !ok {
+		t.Fatalf("sampling missing: %v", data)
+	}
+	if sampling["dropped_estimate"] != float64(5) || sampling["sampled_connections"] != float64(1) {
+		t.Fatalf("sampling = %v, want dropped_estimate 5 on 1 connection", sampling)
+	}
+}
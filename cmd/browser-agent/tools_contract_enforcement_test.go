@@ -275,6 +275,7 @@ func (s *scenario) callObserveWithArgs(t *testing.T, argsJSON string) (MCPToolRe
 // fulfilled returns a timeout error rather than hanging forever.
 func TestContractPendingQuery_Timeout(t *testing.T) {
 	s := newScenario(t)
+	_ = s.capture.SetRetryPolicy(queries.RetryPolicy{Attempts: 1}) // expire on the first missed window
 
 	// Create a pending query with a very short timeout
 	queryID, _ := s.capture.CreatePendingQueryWithTimeout(
@@ -410,11 +411,8 @@ func TestContractAsyncBridge_ConcurrentDelivery(t *testing.T) {
 		ch <- waitResult{data, err}
 	}()
 
-	// Give the goroutine time to start waiting
-	time.Sleep(50 * time.Millisecond)
-
-	// Deliver the result (simulates extension)
-	s.capture.SetQueryResult(queryID, json.RawMessage(`{"value": 2}`))
+	// Pick up and answer the query as the extension would
+	answerPendingQuery(t, s.capture, "execute", map[string]any{"value": 2})
 
 	// Wait for the goroutine to complete
 	select {
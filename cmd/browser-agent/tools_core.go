@@ -16,14 +16,22 @@ import (
 	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/analysis"
 	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/audit"
 	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/findings"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/invariants"
 	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/issuereport"
 	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/mcp"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/netbudget"
 	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/noise"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/notifyhub"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/performance"
 	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/persistence"
 	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/security"
 	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/session"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/sessionbundle"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/statevault"
 	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/streaming"
 	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/telemetry"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/webhooks"
 )
 
 // Note: Response helpers, error codes, and validation functions have been moved to:
@@ -55,13 +63,25 @@ type ToolHandler struct {
 	// Rate limiter for MCP tool calls (sliding window)
 	toolCallLimiter *ToolCallLimiter
 
+	// cdpPolicy gates interact(what="cdp") raw DevTools Protocol commands
+	cdpPolicy CDPPassthroughPolicy
+
 	// Alert system + context streaming (delegates to internal/streaming)
 	alertBuffer *streaming.AlertBuffer
 
+	// notifyHub pushes console errors, 5xx responses, CI results, and circuit
+	// openings to SSE sessions on GET /mcp, filtered by configure(action="subscribe").
+	notifyHub *notifyhub.Hub
+
+	// webhooks posts circuit openings, new error clusters, and new or regressed
+	// findings to endpoints added with configure(what="add_webhook").
+	webhooks *webhooks.Manager
+
 	// Concrete implementations (interface signatures differ from types package)
 	// These are used directly by tool handlers rather than through the interface fields above.
 	noiseConfig           *noise.NoiseConfig
 	sessionStoreImpl      *persistence.SessionStore
+	stateVault            *statevault.Vault // nil unless --state-key is set
 	securityScannerImpl   *security.Scanner
 	thirdPartyAuditorImpl *analysis.ThirdPartyAuditor
 	sessionManager        *session.Manager
@@ -79,6 +99,9 @@ type ToolHandler struct {
 	apiContractValidator *analysis.APIContractValidator
 	apiContractOffset    int
 
+	// Locked API contracts checked against every ingested response (configure lock_api_contract).
+	apiContractMonitor *analysis.APIContractMonitor
+
 	// Upload security config (folder-scoped permissions + denylist)
 	uploadSecurity *UploadSecurity
 
@@ -91,6 +114,12 @@ type ToolHandler struct {
 	// Dedicated interact action routing/jitter sub-handler.
 	interactActionHandler *toolinteract.InteractActionHandler
 
+	// Last configure(what="clear") per buffer, so observe(what="doctor") can tell a clear from no capture.
+	bufferClears bufferClearLog
+
+	// Archive that configure(what="clear") and shutdown roll the buffers into; nil disables archiving.
+	sessionArchive *sessionbundle.Archive
+
 	// Active test boundaries: test_id → start time.
 	// Used to detect out-of-order test_boundary_end calls.
 	activeBoundariesMu sync.Mutex
@@ -134,6 +163,45 @@ type ToolHandler struct {
 	// When nil, issuereport.ExecRunner{} is used. Set in tests to inject a fake.
 	issueCommandRunner issuereport.CommandRunner
 
+	// vitalsHistory aggregates per-route vitals samples into daily buckets
+	// persisted under the vitals_history session-store namespace.
+	vitalsHistory *performance.VitalsHistory
+
+	// memoryHistory keeps per-route memory samples across navigations for observe(what="memory").
+	memoryHistory *performance.MemoryHistory
+
+	// errorClusterHistory tracks error cluster fingerprints across sessions, persisted
+	// under the error_clusters session-store namespace; a log clear resolves them.
+	errorClusterHistory *analysis.ErrorClusterHistory
+
+	// findingTracker holds the lifecycle of every security, accessibility, and vitals
+	// finding, persisted under the findings session-store namespace.
+	findingTracker *findings.Tracker
+
+	// gitState re-reads each client's git state as tool calls arrive and keeps the
+	// commit timeline behind observe(what="changes", since_commit=...).
+	gitState *gitAwareness
+
+	// transportMonitor tracks mixed content, insecure WebSockets, and HTTPS downgrades
+	// across all ingested network telemetry and raises alerts on first sight.
+	transportMonitor *security.TransportMonitor
+
+	// invariantMonitor checks configure(what="invariant") assertions against every
+	// ingested network, WebSocket, and console batch.
+	invariantMonitor *invariants.Monitor
+
+	// budgetMonitor checks configure(what="network_budget") duration and size limits
+	// against every ingested network body and resource timing entry.
+	budgetMonitor *netbudget.Monitor
+
+	// retentionDefaults is each buffer's startup retention (built-ins overlaid with --retention),
+	// restored by configure(what="retention", operation="clear"). Set once at construction.
+	retentionDefaults map[string]capture.Retention
+
+	// readOnly is non-nil when the server replays an archived session bundle.
+	// Set once at startup; interact and mutating configure actions are rejected.
+	readOnly *readOnlyState
+
 	// usageCounter tracks tool:action call counts for periodic usage beacons.
 	// When nil, usage counting is disabled (backwards compatible).
 	usageTracker *telemetry.UsageTracker
@@ -148,6 +216,11 @@ func (h *ToolHandler) HandleToolCall(req JSONRPCRequest, name string, args json.
 
 	h.ensureToolModules()
 	h.ensureToolSchemas()
+	if resp, blocked := h.requireWritable(req, name, args); blocked {
+		return resp, true
+	}
+	// Pick up branch switches and new commits before the call stamps or diffs anything.
+	h.refreshSourceControl(req.ClientID)
 	resp, handled := h.dispatchViaModules(req, name, args)
 	if !handled {
 		return JSONRPCResponse{}, false
@@ -189,6 +262,11 @@ func (h *ToolHandler) HandleToolCall(req JSONRPCRequest, name string, args json.
 
 	h.recordAuditToolCall(req, name, args, resp, start)
 
+	// Finding lifecycle: audit results update open/fixed/regressed state.
+	if parsedOK && !resultIsError {
+		h.trackFindings(name, args, parsedResult)
+	}
+
 	// Usage tracker: per-call telemetry beaconed immediately + aggregated every 5 min.
 	// Separate from healthMetrics — different lifecycle and purpose.
 	if h.usageTracker != nil {
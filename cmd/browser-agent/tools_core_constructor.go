@@ -16,13 +16,23 @@ import (
 	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/analysis"
 	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/audit"
 	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/changefeed"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/invariants"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/lifecycle"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/netbudget"
 	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/noise"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/notifyhub"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/performance"
 	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/persistence"
 	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/redaction"
 	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/security"
 	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/session"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/sessionbundle"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/statevault"
 	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/streaming"
 	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/telemetry"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/types"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/webhooks"
 )
 
 // defaultColdStartTimeout is how long requireExtension waits for the extension
@@ -76,8 +86,10 @@ func NewToolHandler(server *Server, capture *capture.Store) *MCPHandler {
 
 	// Initialize health metrics.
 	handler.healthMetrics = health.NewMetrics()
-	handler.toolCallLimiter = NewToolCallLimiter(500, time.Minute)
+	handler.toolCallLimiter = NewToolCallLimiterWithLimits(toolCallLimitsConfig, time.Minute)
+	handler.cdpPolicy = cdpPassthroughConfig
 	handler.alertBuffer = streaming.NewAlertBuffer()
+	handler.notifyHub = notifyhub.New()
 
 	// Initialize session store (use current working directory as project path).
 	cwd, err := os.Getwd()
@@ -86,6 +98,13 @@ func NewToolHandler(server *Server, capture *capture.Store) *MCPHandler {
 			handler.sessionStoreImpl = store
 		}
 	}
+	if dir, err := sessionbundle.DefaultArchiveDir(); err == nil {
+		handler.sessionArchive = sessionbundle.NewArchive(dir)
+	}
+	if stateVaultKey != "" && handler.sessionStoreImpl != nil {
+		// The key was validated at flag parsing.
+		handler.stateVault, _ = statevault.New(stateVaultKey, handler.sessionStoreImpl)
+	}
 
 	// Initialize noise filtering with persistence support.
 	if handler.sessionStoreImpl != nil {
@@ -93,7 +112,72 @@ func NewToolHandler(server *Server, capture *capture.Store) *MCPHandler {
 	} else {
 		handler.noiseConfig = noise.NewNoiseConfig()
 	}
+
+	// Apply --retention flags and persisted per-buffer retention, and start the TTL sweep.
+	handler.initRetention()
+
 	handler.redactionEngine = redaction.NewRedactionEngine("")
+	handler.webhooks = webhooks.New(shutdownCtx, handler.redactionEngine.Redact)
+
+	// Restore persisted vitals history and record new snapshots, with their memory samples, as they arrive.
+	handler.vitalsHistory = loadVitalsHistory(handler.sessionStoreImpl, time.Now())
+	handler.memoryHistory = performance.NewMemoryHistory()
+	if handler.capture != nil {
+		handler.capture.SetPerformanceCallback(handler.recordPerformanceSnapshots)
+	}
+
+	// Restore error cluster history; console errors are recorded in the log callback below.
+	handler.errorClusterHistory = loadErrorClusterHistory(handler.sessionStoreImpl, time.Now())
+	handler.findingTracker = loadFindingTracker(handler.sessionStoreImpl)
+	handler.gitState = newGitAwareness(readSourceControl)
+
+	// Watch every ingested network batch for insecure transport, locked API contract
+	// drift, invariant violations, and network budget overruns, and alert immediately.
+	handler.transportMonitor = security.NewTransportMonitor()
+	contractsPath, _ := analysis.APIContractsPath() // "" keeps contracts in memory only
+	handler.apiContractMonitor = analysis.NewAPIContractMonitor(contractsPath)
+	handler.invariantMonitor = invariants.NewMonitor()
+	handler.budgetMonitor = netbudget.NewMonitor()
+	if handler.capture != nil {
+		handler.capture.SetNetworkCallback(handler.recordNetworkActivity)
+	}
+
+	// Sequence console and alert appends into the same change feed as network/ws/actions.
+	if handler.capture != nil {
+		feed := handler.capture.ChangeFeed()
+		handler.alertBuffer.SetOnAppend(func(a types.Alert) {
+			feed.Append(changefeed.KindAlert, changefeed.AlertPayload(a))
+		})
+		if server != nil {
+			server.SetOnEntries(func(entries []LogEntry) {
+				feed.Append(changefeed.KindConsole, changefeed.ConsolePayloads(entries)...)
+				handler.recordErrorClusters(entries)
+				handler.recordInvariantConsole(entries)
+			})
+			server.SetTestIDSource(handler.capture.GetActiveTestIDs)
+		}
+
+		// Push qualifying deltas, circuit transitions, and extension reconnects to subscribed SSE sessions;
+		// raise circuit transitions as alerts; record memory-pressure evictions in the audit trail.
+		feed.SetOnAppend(handler.notifyHub.PublishDeltas)
+		handler.capture.SubscribeLifecycle(func(event lifecycle.Event, data map[string]any) {
+			switch event {
+			case lifecycle.EventCircuitOpened:
+				handler.notifyHub.Publish(notifyhub.CircuitOpened(data, time.Now()))
+				handler.webhooks.Publish(circuitOpenedWebhookEvent(data, time.Now()))
+				handler.alertBuffer.AddAlert(circuitTransitionAlert(true, data, time.Now()))
+			case lifecycle.EventCircuitClosed:
+				handler.notifyHub.Publish(notifyhub.CircuitClosed(data, time.Now()))
+				handler.alertBuffer.AddAlert(circuitTransitionAlert(false, data, time.Now()))
+			case lifecycle.EventExtensionConnected:
+				if reconnect, _ := data["is_reconnect"].(bool); reconnect {
+					handler.notifyHub.Publish(notifyhub.ExtensionReconnected(data, time.Now()))
+				}
+			case lifecycle.EventMemoryEviction:
+				handler.recordMemoryEviction(data)
+			}
+		})
+	}
 
 	// Use server-scoped annotation store for draw mode.
 	handler.annotationStore = server.getAnnotationStore()
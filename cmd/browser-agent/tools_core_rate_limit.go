@@ -6,6 +6,7 @@ package main
 import (
 	"crypto/rand"
 	"encoding/binary"
+	"sort"
 	"sync"
 	"time"
 )
@@ -20,46 +21,240 @@ func randomInt63() int64 {
 	return int64(binary.BigEndian.Uint64(b[:]) & 0x7FFFFFFFFFFFFFFF)
 }
 
-// ToolCallLimiter implements a sliding window rate limiter for MCP tool calls.
+const (
+	// defaultToolCallsPerMinute is the per-client rate when neither flag nor env overrides it.
+	defaultToolCallsPerMinute = 500
+	// toolQuotaWindow is the window for ToolCallLimits.HourlyQuota.
+	toolQuotaWindow = time.Hour
+	// maxTrackedLimiterClients triggers pruning of clients idle for a full quota window.
+	maxTrackedLimiterClients = 256
+	// anonymousLimiterClient buckets calls made without X-Kaboom-Client (stdio bridge).
+	anonymousLimiterClient = "default"
+
+	rateLimitScopeRate  = "rate"
+	rateLimitScopeQuota = "quota"
+)
+
+// ToolCallLimits bounds tool calls for one client. Zero disables a limit.
+type ToolCallLimits struct {
+	CallsPerMinute int `json:"calls_per_minute"`
+	HourlyQuota    int `json:"hourly_quota"`
+}
+
+// RateLimitDecision describes the tightest limit applied to a call. It is returned
+// as JSON-RPC error data when a call is rejected so agents can back off precisely.
+type RateLimitDecision struct {
+	Allowed      bool      `json:"-"`
+	ErrorCode    string    `json:"error_code,omitempty"`
+	ClientID     string    `json:"client_id"`
+	Scope        string    `json:"scope,omitempty"` // "rate" or "quota"; empty when unlimited
+	Limit        int       `json:"limit"`
+	Remaining    int       `json:"remaining"`
+	Window       string    `json:"window,omitempty"`
+	ResetAt      time.Time `json:"reset_at,omitzero"`
+	RetryAfterMs int64     `json:"retry_after_ms,omitempty"`
+}
+
+// ToolCallLimiterStatus is the configure(what="rate_limit") view of the limiter.
+type ToolCallLimiterStatus struct {
+	Defaults  ToolCallLimits            `json:"defaults"`
+	Overrides map[string]ToolCallLimits `json:"overrides"`
+	Usage     []ToolCallClientUsage     `json:"usage"`
+}
+
+// ToolCallClientUsage reports one client's calls in the current windows.
+type ToolCallClientUsage struct {
+	ClientID     string `json:"client_id"`
+	LastMinute   int    `json:"last_minute"`
+	LastHour     int    `json:"last_hour"`
+	LimitedCalls int    `json:"limited_calls"`
+}
+
+// ToolCallLimiter implements per-client sliding-window rate limits and hourly quotas for MCP tool calls.
 // Thread-safe: uses its own mutex independent of other locks.
+//
+// Lock hierarchy: mu is a leaf lock.
 type ToolCallLimiter struct {
 	mu         sync.Mutex
-	timestamps []time.Time
-	maxCalls   int
-	window     time.Duration
+	startup    ToolCallLimits
+	defaults   ToolCallLimits
+	overrides  map[string]ToolCallLimits
+	clients    map[string]*clientCallWindow
+	rateWindow time.Duration
 }
 
-// NewToolCallLimiter creates a rate limiter allowing maxCalls within the given window.
+// clientCallWindow holds one client's call timestamps (oldest first) for the quota window.
+type clientCallWindow struct {
+	calls   []time.Time
+	limited int
+}
+
+// NewToolCallLimiter creates a limiter allowing maxCalls per client within the given window.
 func NewToolCallLimiter(maxCalls int, window time.Duration) *ToolCallLimiter {
+	return NewToolCallLimiterWithLimits(ToolCallLimits{CallsPerMinute: maxCalls}, window)
+}
+
+// NewToolCallLimiterWithLimits creates a limiter whose per-client defaults are limits;
+// CallsPerMinute applies to rateWindow (one minute in production).
+func NewToolCallLimiterWithLimits(limits ToolCallLimits, rateWindow time.Duration) *ToolCallLimiter {
 	return &ToolCallLimiter{
-		timestamps: make([]time.Time, 0, maxCalls),
-		maxCalls:   maxCalls,
-		window:     window,
+		startup:    limits,
+		defaults:   limits,
+		overrides:  make(map[string]ToolCallLimits),
+		clients:    make(map[string]*clientCallWindow),
+		rateWindow: rateWindow,
 	}
 }
 
-// Allow checks if a new call is permitted. If allowed, records it and returns true.
+// Allow checks a call from the anonymous client. If allowed, records it and returns true.
 func (l *ToolCallLimiter) Allow() bool {
+	return l.Check("").Allowed
+}
+
+// Check records a call for clientID when it is within limits and reports the tightest limit.
+func (l *ToolCallLimiter) Check(clientID string) RateLimitDecision {
+	if clientID == "" {
+		clientID = anonymousLimiterClient
+	}
 	l.mu.Lock()
 	defer l.mu.Unlock()
 
 	now := time.Now()
-	cutoff := now.Add(-l.window)
-
-	// Compact: remove expired timestamps.
-	valid := 0
-	for _, ts := range l.timestamps {
-		if ts.After(cutoff) {
-			l.timestamps[valid] = ts
-			valid++
+	limits := l.limitsLocked(clientID)
+	w := l.clients[clientID]
+	if w == nil {
+		l.pruneLocked(now)
+		w = &clientCallWindow{}
+		l.clients[clientID] = w
+	}
+	w.compact(now.Add(-max(toolQuotaWindow, l.rateWindow)))
+
+	decision := RateLimitDecision{Allowed: true, ClientID: clientID}
+	check := func(scope string, limit int, window time.Duration) {
+		if limit <= 0 || !decision.Allowed {
+			return
+		}
+		inWindow := w.since(now.Add(-window))
+		remaining := limit - len(inWindow)
+		if decision.Scope != "" && remaining >= decision.Remaining {
+			return
+		}
+		decision.Scope, decision.Limit, decision.Remaining = scope, limit, remaining
+		decision.Window = window.String()
+		if len(inWindow) > 0 {
+			decision.ResetAt = inWindow[0].Add(window)
+		} else {
+			decision.ResetAt = now.Add(window)
+		}
+		if remaining <= 0 {
+			decision.Allowed = false
 		}
 	}
-	l.timestamps = l.timestamps[:valid]
+	check(rateLimitScopeRate, limits.CallsPerMinute, l.rateWindow)
+	check(rateLimitScopeQuota, limits.HourlyQuota, toolQuotaWindow)
 
-	if len(l.timestamps) >= l.maxCalls {
-		return false
+	if !decision.Allowed {
+		w.limited++
+		decision.ErrorCode = "rate_limited"
+		decision.Remaining = 0
+		decision.RetryAfterMs = max(decision.ResetAt.Sub(now).Milliseconds(), 1)
+		return decision
 	}
+	w.calls = append(w.calls, now)
+	if decision.Scope != "" {
+		decision.Remaining--
+	}
+	return decision
+}
+
+// SetLimits replaces the defaults (clientID "") or one client's override.
+func (l *ToolCallLimiter) SetLimits(clientID string, limits ToolCallLimits) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if clientID == "" {
+		l.defaults = limits
+		return
+	}
+	l.overrides[clientID] = limits
+}
+
+// ClearLimits removes one client's override, or with clientID "" restores the startup
+// defaults and removes every override.
+func (l *ToolCallLimiter) ClearLimits(clientID string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if clientID != "" {
+		delete(l.overrides, clientID)
+		return
+	}
+	l.defaults = l.startup
+	l.overrides = make(map[string]ToolCallLimits)
+}
+
+// Limits returns the limits that apply to clientID.
+func (l *ToolCallLimiter) Limits(clientID string) ToolCallLimits {
+	if clientID == "" {
+		clientID = anonymousLimiterClient
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.limitsLocked(clientID)
+}
+
+// Status returns defaults, overrides, and per-client usage sorted by client ID.
+func (l *ToolCallLimiter) Status() ToolCallLimiterStatus {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := time.Now()
+	status := ToolCallLimiterStatus{
+		Defaults:  l.defaults,
+		Overrides: make(map[string]ToolCallLimits, len(l.overrides)),
+		Usage:     make([]ToolCallClientUsage, 0, len(l.clients)),
+	}
+	for id, limits := range l.overrides {
+		status.Overrides[id] = limits
+	}
+	for id, w := range l.clients {
+		status.Usage = append(status.Usage, ToolCallClientUsage{
+			ClientID:     id,
+			LastMinute:   len(w.since(now.Add(-l.rateWindow))),
+			LastHour:     len(w.since(now.Add(-toolQuotaWindow))),
+			LimitedCalls: w.limited,
+		})
+	}
+	sort.Slice(status.Usage, func(i, j int) bool { return status.Usage[i].ClientID < status.Usage[j].ClientID })
+	return status
+}
+
+func (l *ToolCallLimiter) limitsLocked(clientID string) ToolCallLimits {
+	if limits, ok := l.overrides[clientID]; ok {
+		return limits
+	}
+	return l.defaults
+}
+
+// pruneLocked drops clients with no calls in the quota window once too many are tracked.
+func (l *ToolCallLimiter) pruneLocked(now time.Time) {
+	if len(l.clients) < maxTrackedLimiterClients {
+		return
+	}
+	cutoff := now.Add(-toolQuotaWindow)
+	for id, w := range l.clients {
+		if len(w.calls) == 0 || !w.calls[len(w.calls)-1].After(cutoff) {
+			delete(l.clients, id)
+		}
+	}
+}
+
+// compact drops calls at or before cutoff.
+func (w *clientCallWindow) compact(cutoff time.Time) {
+	if expired := len(w.calls) - len(w.since(cutoff)); expired > 0 {
+		w.calls = append(w.calls[:0], w.calls[expired:]...)
+	}
+}
 
-	l.timestamps = append(l.timestamps, now)
-	return true
+// since returns the calls after cutoff (a view into w.calls).
+func (w *clientCallWindow) since(cutoff time.Time) []time.Time {
+	i := sort.Search(len(w.calls), func(i int) bool { return w.calls[i].After(cutoff) })
+	return w.calls[i:]
 }
@@ -27,7 +27,8 @@ func TestMaybeWaitForCommand_SyncByDefault(t *testing.T) {
 	// (Internal knowledge: IsExtensionConnected checks lastSyncSeen)
 	// We'll use the proper way to simulate connection: a Sync request.
 
-	// Create a result after a short delay
+	// Complete after a delay that simulates extension latency; this command is registered
+	// directly, not queued, so there is no pending query to await.
 	go func() {
 		time.Sleep(100 * time.Millisecond)
 		cap.CompleteCommand(correlationID, json.RawMessage(`{"success":true,"message":"instant result"}`), "")
@@ -299,6 +300,24 @@ func TestFormatCommandResult_FinalField(t *testing.T) {
 			t.Error("Expected isError=true for timeout status")
 		}
 	})
+
+	t.Run("never picked up returns extension_unreachable", func(t *testing.T) {
+		cmd := queries.CommandResult{
+			CorrelationID: "cmd-unreachable",
+			QueryID:       "q-unreachable",
+			Status:        "expired",
+			Error:         queries.ExtensionUnreachableReason + ": the extension never picked up dom_action command q-unreachable after 3 delivery attempts over 9s",
+			CreatedAt:     now,
+		}
+		resp := handler.formatCommandResult(req, cmd, cmd.CorrelationID)
+		data := parseMCPResponseData(t, resp.Result)
+		if data["error"] != ErrExtUnreachable {
+			t.Errorf("Expected error=%s, got %v", ErrExtUnreachable, data["error"])
+		}
+		if retry, _ := data["retry"].(string); !strings.Contains(retry, "safe") {
+			t.Errorf("Expected retry guidance to say retrying is safe, got %q", retry)
+		}
+	})
 }
 
 func TestFormatCommandResult_ElapsedMs(t *testing.T) {
@@ -42,7 +42,7 @@ func TestGetToolCallLimiter(t *testing.T) {
 		t.Fatal("GetToolCallLimiter should return non-nil limiter")
 	}
 	// Limiter should allow calls
-	if !limiter.Allow() {
+	if !limiter.Check("").Allowed {
 		t.Fatal("fresh limiter should allow first call")
 	}
 }
@@ -146,7 +146,7 @@ func TestCSP_BlockedActions_PageBlocked(t *testing.T) {
 		"execute_js", "click", "type", "select", "check", "scroll_to", "focus",
 		"get_text", "get_value", "get_attribute", "set_attribute",
 		"list_interactive", "get_readable", "get_markdown",
-		"fill_form", "fill_form_and_submit",
+		"fill_form", "fill_form_and_submit", "replay_request",
 	}
 	if len(actions) != len(expectedActions) {
 		t.Fatalf("expected %d blocked actions for page_blocked, got %d: %v", len(expectedActions), len(actions), actions)
@@ -11,23 +11,27 @@ import (
 
 // Error code aliases — all callers in package main use these unchanged.
 const (
-	ErrInvalidJSON          = mcp.ErrInvalidJSON
-	ErrMissingParam         = mcp.ErrMissingParam
-	ErrInvalidParam         = mcp.ErrInvalidParam
-	ErrUnknownMode          = mcp.ErrUnknownMode
-	ErrPathNotAllowed       = mcp.ErrPathNotAllowed
-	ErrNotInitialized       = mcp.ErrNotInitialized
-	ErrNoData               = mcp.ErrNoData
-	ErrCodePilotDisabled    = mcp.ErrCodePilotDisabled
-	ErrOsAutomationDisabled = mcp.ErrOsAutomationDisabled
-	ErrRateLimited          = mcp.ErrRateLimited
-	ErrCursorExpired        = mcp.ErrCursorExpired
-	ErrExtTimeout           = mcp.ErrExtTimeout
-	ErrExtError             = mcp.ErrExtError
-	ErrQueueFull            = mcp.ErrQueueFull
-	ErrInternal             = mcp.ErrInternal
-	ErrMarshalFailed        = mcp.ErrMarshalFailed
-	ErrExportFailed         = mcp.ErrExportFailed
+	ErrInvalidJSON            = mcp.ErrInvalidJSON
+	ErrMissingParam           = mcp.ErrMissingParam
+	ErrInvalidParam           = mcp.ErrInvalidParam
+	ErrUnknownMode            = mcp.ErrUnknownMode
+	ErrPathNotAllowed         = mcp.ErrPathNotAllowed
+	ErrNotInitialized         = mcp.ErrNotInitialized
+	ErrNoData                 = mcp.ErrNoData
+	ErrCodePilotDisabled      = mcp.ErrCodePilotDisabled
+	ErrOsAutomationDisabled   = mcp.ErrOsAutomationDisabled
+	ErrCDPPassthroughDisabled = mcp.ErrCDPPassthroughDisabled
+	ErrRateLimited            = mcp.ErrRateLimited
+	ErrCursorExpired          = mcp.ErrCursorExpired
+	ErrReadOnlyMode           = mcp.ErrReadOnlyMode
+	ErrExtTimeout             = mcp.ErrExtTimeout
+	ErrExtError               = mcp.ErrExtError
+	ErrExtDisconnected        = mcp.ErrExtDisconnected
+	ErrExtUnreachable         = mcp.ErrExtUnreachable
+	ErrQueueFull              = mcp.ErrQueueFull
+	ErrInternal               = mcp.ErrInternal
+	ErrMarshalFailed          = mcp.ErrMarshalFailed
+	ErrExportFailed           = mcp.ErrExportFailed
 )
 
 // StructuredError alias.
@@ -41,11 +45,17 @@ func withParam(p string) func(*StructuredError)    { return mcp.WithParam(p) }
 func withHint(h string) func(*StructuredError)     { return mcp.WithHint(h) }
 func withAction(a string) func(*StructuredError)   { return mcp.WithAction(a) }
 func withSelector(s string) func(*StructuredError) { return mcp.WithSelector(s) }
+func withLastSeen(ts string) func(*StructuredError) {
+	return mcp.WithLastSeen(ts)
+}
 func withRetryable(retryable bool) func(*StructuredError) {
 	return mcp.WithRetryable(retryable)
 }
 func withRetryAfterMs(ms int) func(*StructuredError) { return mcp.WithRetryAfterMs(ms) }
 func withFinal(final bool) func(*StructuredError)    { return mcp.WithFinal(final) }
+func withDiagnostics(d map[string]any) func(*StructuredError) {
+	return mcp.WithDiagnostics(d)
+}
 func withRecoveryToolCall(toolCall map[string]any) func(*StructuredError) {
 	return mcp.WithRecoveryToolCall(toolCall)
 }
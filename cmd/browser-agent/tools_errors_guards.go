@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
 )
@@ -61,9 +62,13 @@ func (h *ToolHandler) requirePilot(req JSONRPCRequest, extraOpts ...func(*Struct
 
 // requireExtension returns (resp, true) if the browser extension is not connected,
 // short-circuiting the caller with a structured error. On cold starts it waits up to
-// ExtensionReadinessTimeout (5s) for the extension to connect before giving up.
+// ExtensionReadinessTimeout (5s) for the extension to connect before giving up. An
+// extension that was connected and dropped fails immediately with extension_disconnected.
 // Usage: if resp, blocked := h.requireExtension(req); blocked { return resp }
 func (h *ToolHandler) requireExtension(req JSONRPCRequest, extraOpts ...func(*StructuredError)) (JSONRPCResponse, bool) {
+	if h.extensionDropped() {
+		return h.extensionDisconnectedError(req, extraOpts...), true
+	}
 	timeout := h.extensionReadinessTimeout
 	if timeout <= 0 {
 		timeout = capture.ExtensionReadinessTimeout
@@ -93,6 +98,31 @@ func (h *ToolHandler) requireExtension(req JSONRPCRequest, extraOpts ...func(*St
 	), true
 }
 
+// extensionDropped reports whether the extension synced earlier in this process and has
+// since gone quiet. A cold start (never synced) is not a drop: commands may still queue.
+func (h *ToolHandler) extensionDropped() bool {
+	return !h.capture.ExtensionLastSeen().IsZero() && !h.capture.IsExtensionConnected()
+}
+
+// extensionDisconnectedError is the extension_disconnected response: when the extension was
+// last seen, how to bring it back, and that captured telemetry is still readable meanwhile.
+func (h *ToolHandler) extensionDisconnectedError(req JSONRPCRequest, extraOpts ...func(*StructuredError)) JSONRPCResponse {
+	lastSeen := h.capture.ExtensionLastSeen()
+	opts := append([]func(*StructuredError){
+		h.diagnosticHint(),
+		withLastSeen(lastSeen.UTC().Format(time.RFC3339)),
+		withRecoveryToolCall(map[string]any{
+			"tool":      "observe",
+			"arguments": map[string]any{"what": "pilot"},
+		}),
+	}, extraOpts...)
+	return fail(req, ErrExtDisconnected,
+		fmt.Sprintf("Extension disconnected (last seen %s ago). Commands cannot be dispatched.", time.Since(lastSeen).Round(time.Second)),
+		"Check that the browser is open and the Kaboom extension is enabled, then retry. Captured logs, errors, and network data stay readable with observe meanwhile; configure(what='subscribe', events=['extension_reconnected']) pushes a notification when the extension returns.",
+		opts...,
+	)
+}
+
 // requireCSPClear returns (resp, true) if the page's CSP blocks script execution
 // for the given world. Only world="main" is blocked — "auto" and "isolated" bypass
 // page CSP because the extension's ISOLATED world is not subject to page CSP, and
@@ -0,0 +1,137 @@
+// Purpose: Fails extension-backed tool modes immediately while the extension is down, answering from cached state where one exists.
+// Why: Without this gate every screenshot, DOM, or audit call stalls until its pending query times out, one call at a time.
+// Docs: docs/features/feature/extension-disconnect-degradation/index.md
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/findings"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/mcp"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/performance"
+)
+
+// extensionQueryModes lists the modes, per tool, that block on an extension round-trip
+// without passing through requireExtension themselves. interact gates every action with
+// requireExtension already; observe and analyze modes not listed read captured buffers.
+var extensionQueryModes = map[string]map[string]bool{
+	"observe": {
+		"screenshot":      true,
+		"storage":         true,
+		"form_state":      true,
+		"indexeddb":       true,
+		"component_audit": true,
+		"seo":             true,
+		"page_inventory":  true,
+		"site_menus":      true,
+	},
+	"analyze": {
+		"dom":             true,
+		"accessibility":   true,
+		"computed_styles": true,
+		"styles":          true,
+		"i18n":            true,
+		"forms":           true,
+		"form_state":      true,
+		"form_validation": true,
+		"data_table":      true,
+		"navigation":      true,
+		"page_structure":  true,
+		"link_health":     true,
+		"page_summary":    true,
+		"feature_gates":   true,
+		"visual_baseline": true,
+		"visual_diff":     true,
+	},
+}
+
+// extensionCachedAnswer serves a mode from state captured while the extension was up.
+// ok=false means there is nothing cached worth returning.
+type extensionCachedAnswer func(h *ToolHandler, req JSONRPCRequest, args json.RawMessage) (resp JSONRPCResponse, ok bool)
+
+// extensionCachedAnswers maps "tool:what" to its fallback while the extension is disconnected.
+var extensionCachedAnswers = map[string]extensionCachedAnswer{
+	"analyze:accessibility": cachedA11yFindings,
+}
+
+// requireExtensionForMode gates extension-backed modes. An extension that dropped yields
+// the mode's cached answer, marked degraded, or an immediate extension_disconnected error.
+// Cold starts pass through so the query can wait for the first sync.
+func (h *ToolHandler) requireExtensionForMode(req JSONRPCRequest, tool, what string, args json.RawMessage) (JSONRPCResponse, bool) {
+	if h.capture == nil || !extensionQueryModes[tool][what] || !h.extensionDropped() {
+		return JSONRPCResponse{}, false
+	}
+	if answer, ok := extensionCachedAnswers[tool+":"+what]; ok {
+		if resp, ok := answer(h, req, args); ok {
+			return h.markDegraded(resp), true
+		}
+	}
+	return h.extensionDisconnectedError(req), true
+}
+
+// markDegraded records in metadata.degraded that the response came from cache.
+// trackFindings skips degraded responses: they are not a new audit run.
+func (h *ToolHandler) markDegraded(resp JSONRPCResponse) JSONRPCResponse {
+	degraded := map[string]any{
+		"reason":    ErrExtDisconnected,
+		"last_seen": h.capture.ExtensionLastSeen().UTC().Format(time.RFC3339),
+	}
+	return mcp.MutateToolResult(resp, func(r *mcp.MCPToolResult) {
+		if r.Metadata == nil {
+			r.Metadata = make(map[string]any)
+		}
+		r.Metadata["degraded"] = degraded
+	})
+}
+
+// cachedA11yFindings answers an accessibility audit with the open and regressed a11y
+// findings from earlier audits of the tracked page (all pages when none is tracked).
+func cachedA11yFindings(h *ToolHandler, req JSONRPCRequest, _ json.RawMessage) (JSONRPCResponse, bool) {
+	if h.findingTracker == nil {
+		return JSONRPCResponse{}, false
+	}
+	scope := ""
+	if _, _, tabURL := h.capture.GetTrackingStatus(); tabURL != "" {
+		scope = performance.VitalsRoute(tabURL)
+	}
+	var cached []map[string]any
+	var lastAudit time.Time
+	for _, f := range h.findingTracker.List("") {
+		if f.Category != "a11y" || (f.State != findings.StateOpen && f.State != findings.StateRegressed) {
+			continue
+		}
+		if scope != "" && f.Scope != scope {
+			continue
+		}
+		cached = append(cached, map[string]any{
+			"id":         f.ID,
+			"finding_id": f.FindingID,
+			"severity":   f.Severity,
+			"title":      f.Title,
+			"location":   f.Location,
+			"state":      f.State,
+			"last_seen":  f.LastSeen,
+		})
+		if f.LastSeen.After(lastAudit) {
+			lastAudit = f.LastSeen
+		}
+	}
+	if len(cached) == 0 {
+		return JSONRPCResponse{}, false
+	}
+	sort.SliceStable(cached, func(i, j int) bool {
+		return findings.SeverityRank(cached[i]["severity"].(string)) > findings.SeverityRank(cached[j]["severity"].(string))
+	})
+	return succeed(req, fmt.Sprintf("A11y audit unavailable (extension disconnected); %d open finding(s) from earlier audits", len(cached)), map[string]any{
+		"cached":          true,
+		"partial":         true,
+		"source":          "finding_lifecycle",
+		"last_audit_at":   lastAudit,
+		"cached_findings": cached,
+		"note":            "Findings from earlier audits, not a live audit. Fixes made since then are not reflected; rerun analyze(what='accessibility') once the extension reconnects.",
+	}), true
+}
@@ -0,0 +1,110 @@
+// Purpose: Tests fast extension_disconnected failures, cached fallbacks, and the reconnect push notification.
+// Docs: docs/features/feature/extension-disconnect-degradation/index.md
+
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/findings"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/notifyhub"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/performance"
+)
+
+func TestExtensionDropped_FailsFastWithLastSeen(t *testing.T) {
+	t.Parallel()
+	h, _, cap := makeToolHandler(t)
+	h.extensionReadinessTimeout = 5 * time.Second
+	cap.SetTrackingStatusForTest(1, "https://app.test/cart")
+	cap.SetPilotEnabled(true)
+	cap.SimulateExtensionConnectForTest()
+	cap.SimulateExtensionDisconnectForTest()
+
+	for _, call := range []struct {
+		name string
+		run  func() JSONRPCResponse
+	}{
+		{"observe screenshot", func() JSONRPCResponse { return callObserveRaw(h, "screenshot") }},
+		{"analyze dom", func() JSONRPCResponse { return callAnalyzeRaw(h, `{"what":"dom","selector":"body"}`) }},
+		{"interact click", func() JSONRPCResponse { return callInteractRaw(h, `{"what":"click","selector":"#buy"}`) }},
+	} {
+		start := time.Now()
+		resp := call.run()
+		if elapsed := time.Since(start); elapsed > time.Second {
+			t.Errorf("%s: took %v, want an immediate error", call.name, elapsed)
+		}
+		se := extractStructuredError(t, resp)
+		if se.ErrorCode != ErrExtDisconnected || se.LastSeen == "" || !se.Retryable {
+			t.Errorf("%s: error = %+v, want retryable extension_disconnected with last_seen", call.name, se)
+		}
+	}
+	if q := cap.GetLastPendingQuery(); q != nil {
+		t.Errorf("no command should be queued for a dropped extension, got %+v", q)
+	}
+}
+
+func TestExtensionDropped_A11yAnswersFromFindings(t *testing.T) {
+	t.Parallel()
+	h, _, cap := makeToolHandler(t)
+	const page = "https://app.test/cart"
+	cap.SetTrackingStatusForTest(1, page)
+	h.findingTracker.Apply(findings.Sweep{
+		Source: "accessibility",
+		Scope:  performance.VitalsRoute(page),
+		Observations: []findings.Observation{
+			{FindingID: "a11y.label", Severity: "moderate", Title: "Form elements must have labels"},
+			{FindingID: "a11y.color-contrast", Severity: "serious", Title: "Insufficient color contrast"},
+		},
+		Complete: true,
+	}, time.Now().Add(-time.Hour))
+	cap.SimulateExtensionConnectForTest()
+	cap.SimulateExtensionDisconnectForTest()
+
+	resp := callToolRaw(h, "analyze", `{"what":"accessibility"}`)
+	result := parseToolResult(t, resp)
+	if result.IsError {
+		t.Fatalf("expected cached answer, got error: %s", firstText(result))
+	}
+	if _, ok := result.Metadata["degraded"]; !ok {
+		t.Errorf("cached answer should carry metadata.degraded, got %+v", result.Metadata)
+	}
+	data := extractResultJSON(t, result)
+	cached, _ := data["cached_findings"].([]any)
+	if data["cached"] != true || len(cached) != 2 {
+		t.Fatalf("cached answer = %+v, want both findings", data)
+	}
+	if first, _ := cached[0].(map[string]any); first["finding_id"] != "a11y.color-contrast" {
+		t.Errorf("most severe finding should come first, got %+v", first)
+	}
+	for _, f := range h.findingTracker.List("") {
+		if f.Detections != 1 {
+			t.Errorf("%s detections = %d; a cached answer must not count as an audit run", f.FindingID, f.Detections)
+		}
+	}
+}
+
+func TestExtensionReconnect_PushesNotification(t *testing.T) {
+	t.Parallel()
+	h, _, cap := makeToolHandler(t)
+	session := h.notifyHub.Open("agent-1")
+	defer h.notifyHub.Close(session)
+
+	cap.SimulateSyncForTest("ext-1", "agent-1")
+	cap.SimulateExtensionDisconnectForTest()
+	cap.SimulateSyncForTest("ext-1", "agent-1")
+
+	select {
+	case n := <-session.Notifications():
+		if n.Event != notifyhub.EventExtensionReconnected || n.Data["is_reconnect"] != true {
+			t.Errorf("notification = %+v, want extension_reconnected", n)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("no notification pushed on reconnect")
+	}
+	select {
+	case n := <-session.Notifications():
+		t.Errorf("only the reconnect should push, got %+v", n)
+	default:
+	}
+}
@@ -0,0 +1,77 @@
+// Purpose: Tests observe(what="form_state") field reads and interact(what="fill_form", values) single round-trip fills.
+// Docs: docs/features/feature/form-state/index.md
+
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestObserveFormState_RedactsAndCountsInvalid(t *testing.T) {
+	t.Parallel()
+	env := newToolTestEnv(t)
+	env.capture.SetTrackingStatusForTest(7, "https://shop.example.com/checkout")
+
+	wait := startToolCall(t, func() JSONRPCResponse {
+		return env.handler.toolObserve(JSONRPCRequest{JSONRPC: "2.0", ID: 1}, json.RawMessage(`{"what":"form_state","selector":"form#checkout"}`))
+	})
+	params := answerPendingQuery(t, env.capture, "form_state", json.RawMessage(`{"count":1,"forms":[{"selector":"form#checkout","valid":false,"fields":[
+		{"name":"email","type":"email","value":"nope","valid":false,"validity":["type_mismatch"],"validation_message":"Enter an email."},
+		{"name":"pw","type":"password","value":"[REDACTED]","redacted":true,"valid":true},
+		{"name":"card_cvv","type":"text","value":"123","valid":true}]}]}`))
+	if params["selector"] != "form#checkout" {
+		t.Fatalf("query params = %v, want selector forwarded", params)
+	}
+	result := parseToolResult(t, wait())
+	if result.IsError {
+		t.Fatalf("form_state failed: %s", firstText(result))
+	}
+
+	data := extractResultJSON(t, result)
+	if data["count"] != float64(1) || data["invalid_fields"] != float64(1) || data["redacted_fields"] != float64(2) {
+		t.Fatalf("counts = %v/%v/%v, want 1/1/2", data["count"], data["invalid_fields"], data["redacted_fields"])
+	}
+	if strings.Contains(firstText(result), `"123"`) {
+		t.Fatal("card_cvv value leaked into the response")
+	}
+}
+
+func TestFillFormValues_QueuesSingleCommand(t *testing.T) {
+	t.Parallel()
+	env := newInteractTestEnv(t)
+	env.capture.SetPilotEnabled(true)
+
+	result, ok := env.callInteract(t, `{"what":"fill_form","selector":"form#checkout","values":{"email":"a@b.co","password":"hunter2","terms":true}}`)
+	if !ok || result.IsError {
+		t.Fatalf("fill_form failed: %s", firstText(result))
+	}
+
+	var fills []map[string]any
+	for _, q := range env.capture.GetPendingQueries() {
+		if q.Type == "dom_action" {
+			t.Fatal("values should not fan out into per-field dom_action commands")
+		}
+		if q.Type == "form_fill" {
+			var params map[string]any
+			_ = json.Unmarshal(q.Params, &params)
+			fills = append(fills, params)
+		}
+	}
+	if len(fills) != 1 {
+		t.Fatalf("form_fill queries = %d, want 1", len(fills))
+	}
+	values, _ := fills[0]["values"].(map[string]any)
+	if fills[0]["selector"] != "form#checkout" || len(values) != 3 || values["terms"] != true {
+		t.Fatalf("form_fill params = %v", fills[0])
+	}
+}
+
+func TestFillFormValues_RejectsValuesWithFields(t *testing.T) {
+	t.Parallel()
+	h := newTestToolHandler()
+	args := json.RawMessage(`{"values":{"email":"a@b.co"},"fields":[{"selector":"#email","value":"a@b.co"}]}`)
+	resp := h.interactAction().HandleFillForm(JSONRPCRequest{JSONRPC: "2.0", ID: json.RawMessage(`1`)}, args)
+	assertIsError(t, resp, "not both")
+}
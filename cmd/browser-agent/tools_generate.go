@@ -1,4 +1,4 @@
-// Purpose: Dispatches generate tool modes (reproduction, test, pr_summary, sarif, har, csp, sri, visual_test, annotation_report, annotation_issues, test_from_context, test_heal, test_classify) and assembles output artifacts.
+// Purpose: Dispatches generate tool modes (reproduction, test, pr_summary, sarif, har, csp, sri, security_headers, third_party_report, visual_test, annotation_report, annotation_issues, test_from_context, test_heal, test_classify, session_bundle, debug_bundle, junit, gh_annotations, curl, fetch, coverage_report) and assembles output artifacts.
 // Why: Acts as the top-level router for all artifact generation, delegating format-specific logic to sub-handlers.
 // Docs: docs/features/feature/test-generation/index.md
 package main
@@ -13,16 +13,25 @@ import (
 // generateHandlers maps generate format names to their handler functions.
 var generateHandlers = map[string]ModeHandler{
 	// Direct method delegates
-	"reproduction":      method((*ToolHandler).toolGetReproductionScript),
-	"test":              method((*ToolHandler).toolGenerateTest),
-	"pr_summary":        method((*ToolHandler).toolGeneratePRSummary),
-	"sarif":             method((*ToolHandler).toolExportSARIF),
-	"har":               method((*ToolHandler).toolExportHAR),
-	"csp":               method((*ToolHandler).toolGenerateCSP),
-	"sri":               method((*ToolHandler).toolGenerateSRI),
-	"visual_test":       method((*ToolHandler).toolGenerateVisualTest),
-	"annotation_report": method((*ToolHandler).toolGenerateAnnotationReport),
-	"annotation_issues": method((*ToolHandler).toolGenerateAnnotationIssues),
+	"reproduction":       method((*ToolHandler).toolGetReproductionScript),
+	"test":               method((*ToolHandler).toolGenerateTest),
+	"pr_summary":         method((*ToolHandler).toolGeneratePRSummary),
+	"sarif":              method((*ToolHandler).toolExportSARIF),
+	"har":                method((*ToolHandler).toolExportHAR),
+	"csp":                method((*ToolHandler).toolGenerateCSP),
+	"sri":                method((*ToolHandler).toolGenerateSRI),
+	"security_headers":   method((*ToolHandler).toolGenerateSecurityHeaders),
+	"third_party_report": method((*ToolHandler).toolGenerateThirdPartyReport),
+	"visual_test":        method((*ToolHandler).toolGenerateVisualTest),
+	"annotation_report":  method((*ToolHandler).toolGenerateAnnotationReport),
+	"annotation_issues":  method((*ToolHandler).toolGenerateAnnotationIssues),
+	"session_bundle":     method((*ToolHandler).toolGenerateSessionBundle),
+	"debug_bundle":       method((*ToolHandler).toolGenerateDebugBundle),
+	"junit":              method((*ToolHandler).toolGenerateJUnit),
+	"gh_annotations":     method((*ToolHandler).toolGenerateGHAnnotations),
+	"curl":               method((*ToolHandler).toolGenerateCurl),
+	"fetch":              method((*ToolHandler).toolGenerateFetch),
+	"coverage_report":    method((*ToolHandler).toolGenerateCoverageReport),
 	// Sub-handler delegates (require closures — testGen() accessor)
 	"test_from_context": func(h *ToolHandler, req JSONRPCRequest, args json.RawMessage) JSONRPCResponse {
 		return h.testGen().handleGenerateTestFromContext(req, args)
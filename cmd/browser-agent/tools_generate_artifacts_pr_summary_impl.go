@@ -4,6 +4,7 @@ package main
 
 import (
 	"encoding/json"
+	"errors"
 
 	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/cmd/browser-agent/internal/toolgenerate"
 )
@@ -11,3 +12,34 @@ import (
 func (h *ToolHandler) toolGeneratePRSummary(req JSONRPCRequest, args json.RawMessage) JSONRPCResponse {
 	return toolgenerate.HandlePRSummary(h.generateDeps(), req, args)
 }
+
+// PRDeltas implements toolgenerate.PRDeltaSource from the finding lifecycle, the error
+// cluster history, and, when baseline is set, a diff of that snapshot against current state.
+func (h *ToolHandler) PRDeltas(baseline string) (toolgenerate.PRDeltas, error) {
+	var deltas toolgenerate.PRDeltas
+	if h.errorClusterHistory != nil {
+		deltas.Clusters = h.errorClusterHistory.Trend()
+		deltas.Since = deltas.Clusters.SessionStart
+	}
+	if h.findingTracker != nil {
+		deltas.Findings = h.findingTracker.List("")
+	}
+	if baseline == "" {
+		return deltas, nil
+	}
+	if h.sessionManager == nil {
+		return deltas, errors.New("session manager not initialized")
+	}
+	diff, err := h.sessionManager.Compare(baseline, "current")
+	if err != nil {
+		return deltas, err
+	}
+	for _, snap := range h.sessionManager.List() {
+		if snap.Name == baseline {
+			deltas.Since = snap.CapturedAt
+		}
+	}
+	deltas.Baseline = baseline
+	deltas.Snapshot = diff
+	return deltas, nil
+}
@@ -1,4 +1,4 @@
-// Purpose: Thin adapter for generate(csp) and generate(sri) — delegates to toolgenerate sub-package.
+// Purpose: Thin adapter for generate(csp), generate(sri), generate(security_headers), and generate(third_party_report) — delegates to toolgenerate sub-package.
 
 package main
 
@@ -15,3 +15,11 @@ func (h *ToolHandler) toolGenerateCSP(req JSONRPCRequest, args json.RawMessage)
 func (h *ToolHandler) toolGenerateSRI(req JSONRPCRequest, args json.RawMessage) JSONRPCResponse {
 	return toolgenerate.HandleGenerateSRI(h.generateDeps(), req, args)
 }
+
+func (h *ToolHandler) toolGenerateSecurityHeaders(req JSONRPCRequest, args json.RawMessage) JSONRPCResponse {
+	return toolgenerate.HandleGenerateSecurityHeaders(h.generateDeps(), req, args)
+}
+
+func (h *ToolHandler) toolGenerateThirdPartyReport(req JSONRPCRequest, args json.RawMessage) JSONRPCResponse {
+	return toolgenerate.HandleGenerateThirdPartyReport(h.generateDeps(), req, args)
+}
@@ -302,6 +302,33 @@ func TestGenerateAudit_SRI_DataFlow(t *testing.T) {
 	}
 }
 
+// TestGenerateAudit_SecurityHeaders_DataFlow verifies security_headers derives headers from captured traffic
+func TestGenerateAudit_SecurityHeaders_DataFlow(t *testing.T) {
+	env := newGenerateTestEnv(t)
+	env.capture.AddNetworkBodies([]capture.NetworkBody{{
+		URL: "https://app.example.com/app.js", Status: 200, ContentType: "application/javascript",
+		ResponseBody: "navigator.geolocation.watchPosition(update); window.open(loginURL)",
+	}})
+	env.capture.AddNetworkWaterfallEntries([]capture.NetworkWaterfallEntry{
+		{URL: "https://maps.vendor.com/embed", InitiatorType: "iframe"},
+	}, "https://app.example.com/")
+
+	result, ok := env.callGenerate(t, `{"what":"security_headers"}`)
+	if !ok {
+		t.Fatal("security_headers should return result")
+	}
+	if result.IsError {
+		t.Fatalf("security_headers should NOT return isError\nGot: %+v", result)
+	}
+
+	text := result.Content[0].Text
+	for _, want := range []string{"Permissions-Policy", "geolocation=(self)", "same-origin-allow-popups", "credentialless", "rationale"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("security_headers response missing %q\nGot: %s", want, text)
+		}
+	}
+}
+
 // ============================================
 // Behavioral Tests: Error Handling
 // Invalid inputs should return structured errors
@@ -390,6 +417,7 @@ func TestGenerateAudit_EmptyState_ReturnsEmptyNotError(t *testing.T) {
 		{"har", `{"what":"har"}`},
 		{"csp", `{"what":"csp"}`},
 		{"sri", `{"what":"sri"}`},
+		{"security_headers", `{"what":"security_headers"}`},
 	}
 
 	for _, tc := range formats {
@@ -433,6 +461,7 @@ func TestGenerateAudit_AllFormats_NoPanic(t *testing.T) {
 		{"har", `{"what":"har"}`},
 		{"csp", `{"what":"csp"}`},
 		{"sri", `{"what":"sri"}`},
+		{"security_headers", `{"what":"security_headers"}`},
 		{"test_from_context", `{"what":"test_from_context","context":"error"}`},
 		{"test_heal", `{"what":"test_heal","action":"analyze"}`},
 		{"test_classify", `{"what":"test_classify"}`},
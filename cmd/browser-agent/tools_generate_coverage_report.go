@@ -0,0 +1,78 @@
+// Purpose: Implements generate(what="coverage_report"), a Markdown report of the biggest unused JS/CSS bundles.
+// Why: Ranking unused bytes by what they cost to download points at the one or two bundles worth splitting.
+// Docs: docs/features/feature/code-coverage/index.md
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/coverage"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/export"
+)
+
+// toolGenerateCoverageReport ranks aggregated coverage and weighs each bundle by its
+// transfer size from the network waterfall.
+func (h *ToolHandler) toolGenerateCoverageReport(req JSONRPCRequest, args json.RawMessage) JSONRPCResponse {
+	var params struct {
+		URL    string `json:"url"`
+		Limit  int    `json:"limit"`
+		SaveTo string `json:"save_to"`
+	}
+	if len(args) > 0 {
+		if resp, stop := parseArgs(req, args, &params); stop {
+			return resp
+		}
+	}
+
+	store := h.capture.Coverage()
+	aggs := store.Aggregates(params.URL)
+	if len(aggs) == 0 {
+		return fail(req, ErrNoData, "No coverage snapshots match",
+			"Call observe with what='coverage' and collect=true on the pages to report on, then generate again",
+			withRecoveryToolCall(map[string]any{"tool": "observe", "arguments": map[string]any{"what": "coverage", "collect": true}}))
+	}
+	summary := coverage.Summarize(aggs)
+	all := store.Summary()
+	summary.Snapshots, summary.LastSnapshot = all.Snapshots, all.LastSnapshot
+
+	report := coverage.BuildReport(aggs, summary, h.coverageTransfers(), params.Limit)
+	markdown := report.Markdown()
+	text := fmt.Sprintf("Coverage report: %d bundle(s) with 20 KiB+ unused, %s of %s unused overall",
+		report.Significant, coverage.FormatBytes(summary.UnusedBytes), coverage.FormatBytes(summary.TotalBytes))
+	response := map[string]any{
+		"summary":               report.Summary,
+		"significant":           report.Significant,
+		"wasted_transfer_bytes": report.WastedTransferBytes,
+		"bundles":               report.Bundles,
+	}
+	if params.SaveTo != "" {
+		result, err := export.WriteCoverageReportFile(markdown, params.SaveTo)
+		if err != nil {
+			return fail(req, ErrExportFailed, "Coverage report export failed: "+err.Error(), "Check the save_to path and try again", withParam("save_to"))
+		}
+		response["saved_to"] = result.SavedTo
+		response["file_size_bytes"] = result.FileSizeBytes
+		return succeed(req, text+"; saved to "+result.SavedTo, response)
+	}
+	response["report"] = markdown
+	return succeed(req, text, response)
+}
+
+// coverageTransfers indexes the latest waterfall entry per URL by its compressed transfer size.
+// Cache hits report a zero transfer size, so the encoded body size stands in; entries with neither are left out.
+func (h *ToolHandler) coverageTransfers() map[string]coverage.Transfer {
+	transfers := map[string]coverage.Transfer{}
+	for _, e := range h.capture.GetNetworkWaterfallEntries() {
+		size := e.TransferSize
+		if size <= 0 {
+			size = e.EncodedBodySize
+		}
+		if size <= 0 {
+			continue
+		}
+		transfers[e.URL] = coverage.Transfer{Bytes: int64(size), DurationMs: e.Duration}
+	}
+	return transfers
+}
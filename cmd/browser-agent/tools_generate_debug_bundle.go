@@ -0,0 +1,256 @@
+// Purpose: Implements generate(what="debug_bundle"), which zips diagnostics, the HTTP debug log, redacted buffers, settings, crash logs, and version info.
+// Why: Issues filed against Kaboom itself need the daemon's own state; one attachable artifact replaces several rounds of "please also send...".
+// Docs: docs/features/feature/debug-bundle/index.md
+
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/cmd/browser-agent/internal/health"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/sessionbundle"
+)
+
+// maxCrashLogTailBytes caps how much of each crash log goes into a debug bundle.
+const maxCrashLogTailBytes = 256 * 1024
+
+// debugBundleFile is one entry of the debug bundle zip.
+type debugBundleFile struct {
+	Name     string `json:"name"`
+	Source   string `json:"source,omitempty"`
+	Redacted bool   `json:"redacted"`
+	Bytes    int    `json:"bytes"`
+	data     []byte
+}
+
+// toolGenerateDebugBundle writes a zip for bug reports against Kaboom itself.
+// Without save_to the zip goes to <state root>/bundles/debug-<timestamp>.zip.
+func (h *ToolHandler) toolGenerateDebugBundle(req JSONRPCRequest, args json.RawMessage) JSONRPCResponse {
+	var params struct {
+		SaveTo string `json:"save_to"`
+	}
+	if resp, stop := parseArgs(req, args, &params); stop {
+		return resp
+	}
+	if strings.Contains(params.SaveTo, "..") {
+		return fail(req, ErrInvalidParam, "save_to must not contain '..'", "Use an absolute path or a path relative to the working directory", withParam("save_to"))
+	}
+
+	now := time.Now()
+	path := params.SaveTo
+	if path == "" {
+		dir, err := sessionbundle.DefaultDir()
+		if err != nil {
+			return fail(req, ErrExportFailed, "Cannot resolve bundle directory: "+err.Error(), "Pass save_to with an explicit file path")
+		}
+		path = filepath.Join(dir, "debug-"+now.UTC().Format("20060102-150405")+".zip")
+	}
+
+	files, err := h.debugBundleFiles(now, crashLogCandidates())
+	if err != nil {
+		return fail(req, ErrExportFailed, "Debug bundle export failed: "+err.Error(), "Retry; if it keeps failing, attach observe(what=\"doctor\") output instead")
+	}
+	size, err := writeDebugBundle(path, files, now)
+	if err != nil {
+		return fail(req, ErrExportFailed, "Debug bundle export failed: "+err.Error(), "Check the save_to path and try again")
+	}
+	return succeed(req, fmt.Sprintf("Debug bundle saved to %s", path), map[string]any{
+		"saved_to": path,
+		"bytes":    size,
+		"files":    files,
+		"hint":     "Attach the zip to the issue. Buffers, the HTTP debug log, diagnostics, and crash logs are redacted with the active redaction patterns; skim them before posting publicly.",
+	})
+}
+
+// debugBundleFiles collects the bundle entries. manifest.json comes first and lists the rest.
+func (h *ToolHandler) debugBundleFiles(now time.Time, crashLogs []string) ([]debugBundleFile, error) {
+	var files []debugBundleFile
+	add := func(name, source string, redacted bool, data []byte) {
+		files = append(files, debugBundleFile{Name: name, Source: source, Redacted: redacted, Bytes: len(data), data: data})
+	}
+	addJSON := func(name string, redacted bool, v any) error {
+		data, err := h.debugBundleJSON(v, redacted)
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		add(name, "", redacted, data)
+		return nil
+	}
+
+	diagnostics := map[string]any{"doctor": health.RunDoctorChecks(h.capture)}
+	if h.server != nil {
+		diagnostics["server"] = h.server.diagnosticsSnapshot(h.capture, now)
+	}
+	if h.healthMetrics != nil {
+		diagnostics["health"] = getHealthResponse(h.healthMetrics, h.capture, h.server, version)
+	}
+	if err := addJSON("diagnostics.json", true, diagnostics); err != nil {
+		return nil, err
+	}
+
+	entries, total := h.capture.GetHTTPDebugEntries()
+	if err := addJSON("http_debug_log.json", true, map[string]any{
+		"count":        len(entries),
+		"total_logged": total,
+		"entries":      entries,
+	}); err != nil {
+		return nil, err
+	}
+
+	var logs []LogEntry
+	if h.server != nil {
+		logs = h.server.logs.getEntries()
+	}
+	if err := addJSON("buffers.json", true, sessionbundle.FromCapture(h.capture, logs, "debug_bundle", now)); err != nil {
+		return nil, err
+	}
+
+	if err := addJSON("settings.json", false, h.debugBundleSettings()); err != nil {
+		return nil, err
+	}
+
+	for i, source := range crashLogs {
+		tail, err := readCrashLogTail(source, maxCrashLogTailBytes)
+		if err != nil || len(tail) == 0 {
+			continue
+		}
+		if h.redactionEngine != nil {
+			tail = []byte(h.redactionEngine.Redact(string(tail)))
+		}
+		add(fmt.Sprintf("crash_logs/%d-%s", i+1, filepath.Base(source)), source, h.redactionEngine != nil, tail)
+	}
+
+	manifest, err := json.MarshalIndent(map[string]any{
+		"generated_at":   now.UTC().Format(time.RFC3339),
+		"version":        version,
+		"uptime_seconds": int64(now.Sub(startTime).Seconds()),
+		"pid":            os.Getpid(),
+		"system": map[string]any{
+			"os":         runtime.GOOS,
+			"arch":       runtime.GOARCH,
+			"go_version": runtime.Version(),
+			"num_cpu":    runtime.NumCPU(),
+		},
+		"launch_mode": getCurrentLaunchMode().Mode,
+		"files":       files,
+	}, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("manifest.json: %w", err)
+	}
+	return append([]debugBundleFile{{Name: "manifest.json", Bytes: len(manifest), data: manifest}}, files...), nil
+}
+
+// debugBundleJSON marshals v, running every value through the redaction engine when redact is set.
+func (h *ToolHandler) debugBundleJSON(v any, redact bool) ([]byte, error) {
+	if !redact || h.redactionEngine == nil {
+		return json.MarshalIndent(v, "", "  ")
+	}
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var m map[string]any
+	if err := json.Unmarshal(raw, &m); err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(h.redactionEngine.RedactMapValues(m), "", "  ")
+}
+
+// debugBundleSettings reports the runtime settings that change what the daemon captures.
+func (h *ToolHandler) debugBundleSettings() map[string]any {
+	securityMode, productionParity, rewrites := h.capture.GetSecurityMode()
+	settings := map[string]any{
+		"retention":         effectiveRetention(h.consoleLogs(), h.capture),
+		"circuit":           h.capture.CircuitStatus(),
+		"body_limits":       h.capture.GetBodyLimits(),
+		"ws_sampling":       h.capture.GetWSSamplingPolicies(),
+		"multi_tab_capture": h.capture.MultiTabCaptureEnabled(),
+		"pilot_enabled":     h.capture.IsPilotActionAllowed(),
+		"security_mode": map[string]any{
+			"mode":              securityMode,
+			"production_parity": productionParity,
+			"rewrites":          rewrites,
+		},
+	}
+	if logs := h.consoleLogs(); logs != nil {
+		enabled, _ := logs.getDedupStatus()
+		settings["log_dedup"] = enabled
+	}
+	if h.toolCallLimiter != nil {
+		settings["rate_limits"] = h.toolCallLimiter.Status()
+	}
+	if h.sessionArchive != nil {
+		if archive, err := h.sessionArchive.Settings(); err == nil {
+			settings["session_archive"] = archiveSettingsJSON(archive)
+		}
+	}
+	return settings
+}
+
+// readCrashLogTail returns at most limit trailing bytes of a crash log, starting on a line
+// boundary when truncated. A missing file returns no data and no error.
+func readCrashLogTail(path string, limit int64) ([]byte, error) {
+	f, err := os.Open(path) // #nosec G304 -- crash path is derived from local runtime state paths only
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close() //nolint:errcheck // read-only file
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	truncated := info.Size() > limit
+	if truncated {
+		if _, err := f.Seek(info.Size()-limit, io.SeekStart); err != nil {
+			return nil, err
+		}
+	}
+	data, err := io.ReadAll(io.LimitReader(f, limit))
+	if err != nil {
+		return nil, err
+	}
+	if truncated {
+		if i := bytes.IndexByte(data, '\n'); i >= 0 {
+			data = data[i+1:]
+		}
+	}
+	return data, nil
+}
+
+// writeDebugBundle writes files into a zip at path and returns its size.
+func writeDebugBundle(path string, files []debugBundleFile, now time.Time) (int64, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return 0, fmt.Errorf("create bundle directory: %w", err)
+	}
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, file := range files {
+		w, err := zw.CreateHeader(&zip.FileHeader{Name: file.Name, Method: zip.Deflate, Modified: now})
+		if err != nil {
+			return 0, fmt.Errorf("add %s: %w", file.Name, err)
+		}
+		if _, err := w.Write(file.data); err != nil {
+			return 0, fmt.Errorf("add %s: %w", file.Name, err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return 0, fmt.Errorf("finish zip: %w", err)
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0o600); err != nil {
+		return 0, fmt.Errorf("write bundle: %w", err)
+	}
+	return int64(buf.Len()), nil
+}
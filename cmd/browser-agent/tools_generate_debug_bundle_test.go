@@ -0,0 +1,133 @@
+// Purpose: Tests generate(what="debug_bundle") zip contents, redaction, and crash log tails.
+// Docs: docs/features/feature/debug-bundle/index.md
+
+package main
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+)
+
+const debugBundleSecret = "Bearer sk4f9XzQ2mK7debugsecret"
+
+func readDebugBundle(t *testing.T, path string) map[string]string {
+	t.Helper()
+	zr, err := zip.OpenReader(path)
+	if err != nil {
+		t.Fatalf("open zip: %v", err)
+	}
+	defer zr.Close()
+	files := map[string]string{}
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatalf("open %s: %v", f.Name, err)
+		}
+		data, _ := io.ReadAll(rc)
+		rc.Close()
+		files[f.Name] = string(data)
+	}
+	return files
+}
+
+func TestGenerateDebugBundle_ZipsRedactedDiagnostics(t *testing.T) {
+	t.Parallel()
+	h, server, cap := makeToolHandler(t)
+
+	server.logs.addEntries([]LogEntry{{"level": "error", "message": "fetch failed with " + debugBundleSecret, "ts": time.Now().UTC().Format(time.RFC3339)}})
+	cap.AddNetworkBodies([]capture.NetworkBody{{Method: "GET", URL: "https://app.test/api/me", Status: 401,
+		RequestHeaders: map[string]string{"Authorization": debugBundleSecret}}})
+	cap.LogHTTPDebugEntry(capture.HTTPDebugEntry{Timestamp: time.Now(), Endpoint: "/sync", Method: "POST", ResponseStatus: 200,
+		RequestBody: `{"note":"` + debugBundleSecret + `"}`})
+
+	path := filepath.Join(t.TempDir(), "report", "debug.zip")
+	result := parseToolResult(t, callGenerateRaw(h, `{"what":"debug_bundle","save_to":"`+path+`"}`))
+	if result.IsError {
+		t.Fatalf("debug_bundle should succeed, got: %s", firstText(result))
+	}
+	if data := extractResultJSON(t, result); data["saved_to"] != path || data["bytes"].(float64) <= 0 {
+		t.Fatalf("response = %v", data)
+	}
+
+	files := readDebugBundle(t, path)
+	for _, name := range []string{"manifest.json", "diagnostics.json", "http_debug_log.json", "buffers.json", "settings.json"} {
+		if files[name] == "" {
+			t.Errorf("zip is missing %s; has %d files", name, len(files))
+		}
+	}
+	for name, content := range files {
+		if strings.Contains(content, "sk4f9XzQ2mK7debugsecret") {
+			t.Errorf("%s leaks the bearer token", name)
+		}
+	}
+	if !strings.Contains(files["buffers.json"], "[REDACTED:bearer-token]") || !strings.Contains(files["http_debug_log.json"], "/sync") {
+		t.Errorf("buffers or debug log missing expected content")
+	}
+
+	var manifest struct {
+		Version string         `json:"version"`
+		System  map[string]any `json:"system"`
+		Files   []struct {
+			Name string `json:"name"`
+		} `json:"files"`
+	}
+	if err := json.Unmarshal([]byte(files["manifest.json"]), &manifest); err != nil {
+		t.Fatalf("manifest: %v", err)
+	}
+	if manifest.Version != version || manifest.System["os"] == nil || len(manifest.Files) != len(files)-1 {
+		t.Errorf("manifest = %+v", manifest)
+	}
+	var settings map[string]any
+	if err := json.Unmarshal([]byte(files["settings.json"]), &settings); err != nil || settings["retention"] == nil || settings["session_archive"] == nil {
+		t.Errorf("settings = %v (%v)", settings, err)
+	}
+}
+
+func TestDebugBundleFiles_CrashLogTails(t *testing.T) {
+	t.Parallel()
+	h, _, _ := makeToolHandler(t)
+
+	dir := t.TempDir()
+	crash := filepath.Join(dir, "crash.log")
+	old := strings.Repeat(`{"event":"old"}`+"\n", maxCrashLogTailBytes/16+10)
+	if err := os.WriteFile(crash, []byte(old+`{"event":"panic","error":"`+debugBundleSecret+`"}`+"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	files, err := h.debugBundleFiles(time.Now(), []string{filepath.Join(dir, "missing.log"), crash})
+	if err != nil {
+		t.Fatalf("debugBundleFiles: %v", err)
+	}
+	var tail *debugBundleFile
+	for i := range files {
+		if strings.HasPrefix(files[i].Name, "crash_logs/") {
+			tail = &files[i]
+		}
+	}
+	if tail == nil || tail.Name != "crash_logs/2-crash.log" || tail.Source != crash {
+		t.Fatalf("crash log entry = %+v", tail)
+	}
+	content := string(tail.data)
+	if len(content) > maxCrashLogTailBytes || !strings.HasPrefix(content, `{"event":"old"}`) {
+		t.Errorf("tail is %d bytes starting %q, want a line-aligned tail", len(content), content[:min(len(content), 20)])
+	}
+	if !strings.Contains(content, "[REDACTED:bearer-token]") || strings.Contains(content, "debugsecret") {
+		t.Errorf("crash log tail not redacted: %q", content[len(content)-80:])
+	}
+}
+
+func TestGenerateDebugBundle_RejectsTraversal(t *testing.T) {
+	t.Parallel()
+	h, _, _ := makeToolHandler(t)
+	if result := parseToolResult(t, callGenerateRaw(h, `{"what":"debug_bundle","save_to":"../debug.zip"}`)); !result.IsError {
+		t.Fatalf("save_to with '..' should fail, got: %s", firstText(result))
+	}
+}
@@ -0,0 +1,188 @@
+// Purpose: Implements generate(what="gh_annotations"), emitting GitHub Actions workflow commands for session findings.
+// Why: A CI job that prints these lines gets console errors, contract violations, and a11y findings annotated on the PR diff.
+// Docs: docs/features/feature/gh-annotations-export/index.md
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/export"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/findings"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/lspdiag"
+)
+
+// toolGenerateGHAnnotations renders console errors, API contract violations, and open
+// accessibility findings as ::error/::warning/::notice lines.
+func (h *ToolHandler) toolGenerateGHAnnotations(req JSONRPCRequest, args json.RawMessage) JSONRPCResponse {
+	var params struct {
+		SaveTo string `json:"save_to"`
+	}
+	if len(args) > 0 {
+		if resp, stop := parseArgs(req, args, &params); stop {
+			return resp
+		}
+	}
+
+	console, unresolved := h.ghConsoleAnnotations(req.ClientID)
+	annotations := append(console, h.ghContractAnnotations()...)
+	annotations = append(annotations, h.ghA11yAnnotations()...)
+
+	counts := map[string]int{}
+	for _, a := range annotations {
+		counts[a.Level]++
+	}
+	summary := fmt.Sprintf("GitHub annotations: %d (%d errors, %d warnings, %d notices)",
+		len(annotations), counts[export.GHAnnotationError], counts[export.GHAnnotationWarning], counts[export.GHAnnotationNotice])
+	response := map[string]any{
+		"annotations":               len(annotations),
+		"errors":                    counts[export.GHAnnotationError],
+		"warnings":                  counts[export.GHAnnotationWarning],
+		"notices":                   counts[export.GHAnnotationNotice],
+		"unresolved_console_errors": unresolved,
+	}
+	if unresolved > 0 {
+		response["hint"] = "Console errors without a workspace file are annotated on the run summary only. Run from the repository checkout so stack frames resolve."
+	}
+	if params.SaveTo != "" {
+		result, err := export.WriteGHAnnotationsFile(annotations, params.SaveTo)
+		if err != nil {
+			return fail(req, ErrExportFailed, "GitHub annotations export failed: "+err.Error(), "Check the save_to path and try again", withParam("save_to"))
+		}
+		response["saved_to"] = result.SavedTo
+		response["file_size_bytes"] = result.FileSizeBytes
+		return succeed(req, summary+" saved to "+result.SavedTo+"; print it from a workflow step (cat "+result.SavedTo+")", response)
+	}
+	response["commands"] = export.FormatGHAnnotations(annotations)
+	return succeed(req, summary, response)
+}
+
+// ghConsoleAnnotations annotates each distinct console error at its first application frame.
+// Stacks arrive source-mapped from the extension, so frames name original sources. It also
+// returns how many distinct errors did not resolve to a workspace file.
+func (h *ToolHandler) ghConsoleAnnotations(clientID string) ([]export.GHAnnotation, int) {
+	entries, _ := h.GetLogEntries()
+	resolver := lspdiag.NewResolver(h.errorSourceRoots(clientID))
+
+	type key struct {
+		file         string
+		line, column int
+		message      string
+	}
+	counts := map[key]int{}
+	var order []key
+	for _, entry := range entries {
+		if level, _ := entry["level"].(string); level != "error" || h.IsConsoleNoise(entry) {
+			continue
+		}
+		k := key{message: ghMessageLine(entry["message"])}
+		if loc, ok := lspdiag.ResolveEntry(entry, resolver); ok {
+			k.file, k.line, k.column = workspaceRelative(resolver.Roots(), loc.Path), loc.Line, loc.Column
+		} else if source, _ := entry["source"].(string); source != "" {
+			k.message += " (" + source + ")"
+		}
+		if counts[k] == 0 {
+			order = append(order, k)
+		}
+		counts[k]++
+	}
+
+	annotations := make([]export.GHAnnotation, 0, len(order))
+	unresolved := 0
+	for _, k := range order {
+		message := k.message
+		if n := counts[k]; n > 1 {
+			message = fmt.Sprintf("%s (seen %d times in the browser)", message, n)
+		}
+		if k.file == "" {
+			unresolved++
+		}
+		annotations = append(annotations, export.GHAnnotation{
+			Level: export.GHAnnotationError, File: k.file, Line: k.line, Column: k.column,
+			Title: "Console error", Message: message,
+		})
+	}
+	return annotations, unresolved
+}
+
+func (h *ToolHandler) ghContractAnnotations() []export.GHAnnotation {
+	h.processAPIValidationBodies()
+	result := h.apiContractAnalyze(h.apiValidationFilter("", nil))
+	annotations := make([]export.GHAnnotation, 0, len(result.Violations))
+	for _, v := range result.Violations {
+		annotations = append(annotations, export.GHAnnotation{
+			Level:   ghAnnotationLevel(v.Severity),
+			Title:   "API contract: " + v.Type,
+			Message: v.Endpoint + ": " + v.Description,
+		})
+	}
+	return annotations
+}
+
+// ghA11yAnnotations annotates open and regressed accessibility findings from the finding lifecycle.
+func (h *ToolHandler) ghA11yAnnotations() []export.GHAnnotation {
+	if h.findingTracker == nil {
+		return nil
+	}
+	var annotations []export.GHAnnotation
+	for _, f := range h.findingTracker.List("") {
+		if f.Category != "a11y" || (f.State != findings.StateOpen && f.State != findings.StateRegressed) {
+			continue
+		}
+		message := f.Title
+		if message == "" {
+			message = f.FindingID
+		}
+		if f.Location != "" {
+			message += " at " + f.Location
+		}
+		if f.Scope != "" {
+			message += " on " + f.Scope
+		}
+		if f.State == findings.StateRegressed {
+			message += " (regressed)"
+		}
+		annotations = append(annotations, export.GHAnnotation{
+			Level:   ghAnnotationLevel(f.Severity),
+			Title:   "Accessibility: " + f.FindingID,
+			Message: message,
+		})
+	}
+	return annotations
+}
+
+// ghAnnotationLevel maps audit severities (security, axe impact, contract) onto annotation levels.
+func ghAnnotationLevel(severity string) string {
+	switch rank := findings.SeverityRank(severity); {
+	case rank >= 3:
+		return export.GHAnnotationError
+	case rank == 2:
+		return export.GHAnnotationWarning
+	}
+	return export.GHAnnotationNotice
+}
+
+// workspaceRelative returns path relative to the root containing it, with forward slashes,
+// which is how GitHub names files in a checkout.
+func workspaceRelative(roots []string, path string) string {
+	for _, root := range roots {
+		if rel, err := filepath.Rel(root, path); err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return filepath.ToSlash(rel)
+		}
+	}
+	return filepath.ToSlash(path)
+}
+
+func ghMessageLine(v any) string {
+	msg, _ := v.(string)
+	if i := strings.IndexByte(msg, '\n'); i >= 0 {
+		msg = msg[:i]
+	}
+	if msg = strings.TrimSpace(msg); msg == "" {
+		return "Uncaught error"
+	}
+	return msg
+}
@@ -0,0 +1,96 @@
+// Purpose: Tests generate(what="gh_annotations") console error resolution, a11y findings, and save_to.
+// Docs: docs/features/feature/gh-annotations-export/index.md
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/findings"
+)
+
+func TestGenerateGHAnnotations_ConsoleAndA11y(t *testing.T) {
+	t.Parallel()
+	h, server, _ := makeToolHandler(t)
+
+	workspace := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(workspace, "src"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(workspace, "src", "cart.ts"), []byte("export {}\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	server.SetActiveCodebase(workspace)
+
+	ts := time.Now().UTC().Format(time.RFC3339Nano)
+	resolved := LogEntry{"level": "error", "message": "TypeError: cart is undefined\n    at addItem", "ts": ts,
+		"stack": "TypeError: cart is undefined\n    at addItem (webpack://shop/./src/cart.ts:12:5)\n    at node_modules/react-dom/index.js:1:1"}
+	server.logs.addEntries([]LogEntry{
+		resolved,
+		resolved,
+		{"level": "error", "message": "Script error.", "source": "https://cdn.example.com/x.js", "ts": ts},
+		{"level": "warn", "message": "deprecated", "ts": ts},
+	})
+	h.findingTracker.Apply(findings.Sweep{Source: "accessibility", Scope: "http://localhost:5173/cart", Observations: []findings.Observation{
+		{FindingID: "a11y.color-contrast", Severity: "serious", Title: "Elements must meet minimum color contrast", Location: "button.checkout"},
+		{FindingID: "a11y.region", Severity: "moderate", Title: "Content should be in landmarks"},
+	}}, time.Now())
+
+	result := parseToolResult(t, callGenerateRaw(h, `{"what":"gh_annotations"}`))
+	if result.IsError {
+		t.Fatalf("gh_annotations should succeed, got: %s", firstText(result))
+	}
+	data := extractResultJSON(t, result)
+	if data["annotations"] != float64(4) || data["errors"] != float64(3) || data["warnings"] != float64(1) {
+		t.Errorf("counts = %v total, %v errors, %v warnings; want 4/3/1", data["annotations"], data["errors"], data["warnings"])
+	}
+	if data["unresolved_console_errors"] != float64(1) {
+		t.Errorf("unresolved_console_errors = %v, want 1", data["unresolved_console_errors"])
+	}
+
+	commands, _ := data["commands"].(string)
+	for _, want := range []string{
+		"::error file=src/cart.ts,line=12,col=5,title=Console error::TypeError: cart is undefined (seen 2 times in the browser)\n",
+		"::error title=Console error::Script error. (https://cdn.example.com/x.js)\n",
+		"::error title=Accessibility%3A a11y.color-contrast::Elements must meet minimum color contrast at button.checkout on http://localhost:5173/cart\n",
+		"::warning title=Accessibility%3A a11y.region::Content should be in landmarks on http://localhost:5173/cart\n",
+	} {
+		if !strings.Contains(commands, want) {
+			t.Errorf("commands missing %q:\n%s", want, commands)
+		}
+	}
+	if strings.Contains(commands, "deprecated") {
+		t.Error("warn-level logs are not annotated")
+	}
+}
+
+func TestGenerateGHAnnotations_SaveTo(t *testing.T) {
+	t.Parallel()
+	h, server, _ := makeToolHandler(t)
+	server.logs.addEntries([]LogEntry{{"level": "error", "message": "boom", "ts": time.Now().UTC().Format(time.RFC3339Nano)}})
+
+	path := filepath.Join(t.TempDir(), "annotations.txt")
+	data := extractResultJSON(t, parseToolResult(t, callGenerateRaw(h, `{"what":"gh_annotations","save_to":"`+path+`"}`)))
+	if data["saved_to"] != path {
+		t.Fatalf("saved_to = %v, want %s", data["saved_to"], path)
+	}
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read annotations: %v", err)
+	}
+	if string(raw) != "::error title=Console error::boom\n" {
+		t.Errorf("file = %q", raw)
+	}
+	if _, ok := data["commands"]; ok {
+		t.Error("commands should be omitted when save_to is set")
+	}
+
+	resp := parseToolResult(t, callGenerateRaw(h, `{"what":"gh_annotations","save_to":"../escape.txt"}`))
+	if !resp.IsError {
+		t.Error("unsafe save_to should fail")
+	}
+}
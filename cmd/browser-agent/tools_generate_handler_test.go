@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/findings"
 )
 
 // ============================================
@@ -319,6 +320,79 @@ func TestToolsGeneratePRSummary_WithActivity(t *testing.T) {
 	assertSnakeCaseFields(t, string(resp.Result))
 }
 
+func TestToolsGeneratePRSummary_RegressionSections(t *testing.T) {
+	t.Parallel()
+	h, _, cap := makeToolHandler(t)
+
+	cap.AddEnhancedActionsForTest([]capture.EnhancedAction{
+		{Type: "click", Timestamp: time.Now().UnixMilli(), URL: "https://example.com"},
+	})
+	h.findingTracker.Apply(findings.Sweep{Source: "security_audit", Observations: []findings.Observation{
+		{FindingID: "security.missing_csp", Severity: "high", Title: "Missing Content-Security-Policy"},
+	}}, time.Now())
+
+	resp := callGenerateRaw(h, `{"what":"pr_summary"}`)
+	result := parseToolResult(t, resp)
+	if result.IsError {
+		t.Fatalf("pr_summary should succeed, got: %s", result.Content[0].Text)
+	}
+	data := extractResultJSON(t, result)
+
+	summary, _ := data["summary"].(string)
+	for _, want := range []string{"### Regression Check", "| Security | 🟠 High | 1 | 0 | 0 |", "<details>", "Missing Content-Security-Policy"} {
+		if !strings.Contains(summary, want) {
+			t.Errorf("summary missing %q:\n%s", want, summary)
+		}
+	}
+	sections, _ := data["regressions"].([]any)
+	if len(sections) != 4 {
+		t.Fatalf("regressions = %v, want 4 sections", data["regressions"])
+	}
+	security, _ := sections[0].(map[string]any)
+	if security["area"] != "Security" || security["new"] != float64(1) || security["severity"] != "high" {
+		t.Errorf("security section = %v", security)
+	}
+
+	assertSnakeCaseFields(t, string(resp.Result))
+}
+
+func TestToolsGeneratePRSummary_Baseline(t *testing.T) {
+	t.Parallel()
+	h, _, cap := makeToolHandler(t)
+
+	cap.AddEnhancedActionsForTest([]capture.EnhancedAction{
+		{Type: "click", Timestamp: time.Now().UnixMilli(), URL: "https://example.com"},
+	})
+	if _, err := h.sessionManager.Capture("before", ""); err != nil {
+		t.Fatalf("capture snapshot: %v", err)
+	}
+
+	resp := callGenerateRaw(h, `{"what":"pr_summary","baseline":"before"}`)
+	result := parseToolResult(t, resp)
+	if result.IsError {
+		t.Fatalf("pr_summary with baseline should succeed, got: %s", result.Content[0].Text)
+	}
+	data := extractResultJSON(t, result)
+	summary, _ := data["summary"].(string)
+	if !strings.Contains(summary, "Compared with snapshot `before`") {
+		t.Errorf("summary should name the baseline snapshot:\n%s", summary)
+	}
+}
+
+func TestToolsGeneratePRSummary_UnknownBaseline(t *testing.T) {
+	t.Parallel()
+	h, _, _ := makeToolHandler(t)
+
+	resp := callGenerateRaw(h, `{"what":"pr_summary","baseline":"missing"}`)
+	result := parseToolResult(t, resp)
+	if !result.IsError {
+		t.Fatal("unknown baseline should return isError:true")
+	}
+	if !strings.Contains(result.Content[0].Text, "baseline") {
+		t.Errorf("error should name the baseline param, got: %s", result.Content[0].Text)
+	}
+}
+
 // ============================================
 // generate(format:"csp") — Response Fields
 // ============================================
@@ -0,0 +1,236 @@
+// Purpose: Implements generate(what="junit"), turning the session's implicit checks into a JUnit XML suite.
+// Why: A browser session run in CI should fail the pipeline natively on console errors, 5xx responses, contract drift, a11y violations, or blown budgets.
+// Docs: docs/features/feature/junit-export/index.md
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/export"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/tools/observe"
+)
+
+// junitMaxItems caps the offending items listed in one failure; the message keeps the full count.
+const junitMaxItems = 50
+
+// defaultJUnitBudget fails a page load only on Web Vitals "poor" ratings.
+func defaultJUnitBudget() observe.VitalsBudget {
+	lcp, fcp, inp, ttfb, cls := 4000.0, 3000.0, 500.0, 1800.0, 0.25
+	return observe.VitalsBudget{LCPMs: &lcp, FCPMs: &fcp, INPMs: &inp, TTFBMs: &ttfb, CLS: &cls}
+}
+
+// toolGenerateJUnit evaluates every session check and renders the results as JUnit XML.
+func (h *ToolHandler) toolGenerateJUnit(req JSONRPCRequest, args json.RawMessage) JSONRPCResponse {
+	var params struct {
+		SuiteName string                `json:"suite_name"`
+		Budget    *observe.VitalsBudget `json:"budget"`
+		SaveTo    string                `json:"save_to"`
+	}
+	if len(args) > 0 {
+		if resp, stop := parseArgs(req, args, &params); stop {
+			return resp
+		}
+	}
+	if params.SuiteName == "" {
+		params.SuiteName = "kaboom"
+	}
+	budget := defaultJUnitBudget()
+	if params.Budget != nil {
+		budget = *params.Budget
+	}
+
+	cases := []export.JUnitTestCase{
+		h.junitConsoleErrors(),
+		junitServerErrors(h.capture.GetNetworkBodies()),
+		h.junitAPIContract(),
+		h.junitAccessibility(),
+	}
+	cases = append(cases, junitVitalsBudgets(h.capture.GetPerformanceSnapshots(), budget)...)
+
+	_, _, tabURL := h.capture.GetTrackingStatus()
+	var properties []export.JUnitProperty
+	if tabURL != "" {
+		properties = append(properties, export.JUnitProperty{Name: "page_url", Value: tabURL})
+	}
+	properties = append(properties, export.JUnitProperty{Name: "kaboom_version", Value: version})
+	report := export.BuildJUnitReport(params.SuiteName, cases, properties, time.Now())
+	summary := fmt.Sprintf("JUnit report: %d tests, %d failed, %d skipped", report.Tests, report.Failures, report.Skipped)
+
+	response := map[string]any{
+		"tests":    report.Tests,
+		"failures": report.Failures,
+		"skipped":  report.Skipped,
+		"checks":   junitCheckSummaries(cases),
+	}
+	if params.SaveTo != "" {
+		result, err := export.WriteJUnitFile(report, params.SaveTo)
+		if err != nil {
+			return fail(req, ErrExportFailed, "JUnit export failed: "+err.Error(), "Check the save_to path and try again", withParam("save_to"))
+		}
+		response["saved_to"] = result.SavedTo
+		response["file_size_bytes"] = result.FileSizeBytes
+		return succeed(req, summary+" saved to "+result.SavedTo, response)
+	}
+	data, err := export.MarshalJUnit(report)
+	if err != nil {
+		return fail(req, ErrExportFailed, "JUnit export failed: "+err.Error(), "Report this bug.")
+	}
+	response["xml"] = string(data)
+	return succeed(req, summary, response)
+}
+
+// junitCase builds a test case that fails when items is non-empty.
+func junitCase(className, name, failureType string, items []string, message string) export.JUnitTestCase {
+	c := export.JUnitTestCase{Name: name, ClassName: className}
+	if len(items) == 0 {
+		return c
+	}
+	listed := items
+	if len(listed) > junitMaxItems {
+		listed = append(append([]string(nil), items[:junitMaxItems]...), fmt.Sprintf("...and %d more", len(items)-junitMaxItems))
+	}
+	c.Failure = &export.JUnitFailure{Message: message, Type: failureType, Text: strings.Join(listed, "\n")}
+	return c
+}
+
+func junitSkipped(className, name, reason string) export.JUnitTestCase {
+	return export.JUnitTestCase{Name: name, ClassName: className, Skipped: &export.JUnitSkipped{Message: reason}}
+}
+
+func (h *ToolHandler) junitConsoleErrors() export.JUnitTestCase {
+	items := h.consoleErrorMessages()
+	return junitCase("kaboom.console", "no_console_errors", "ConsoleError", items,
+		fmt.Sprintf("%d console error(s)", len(items)))
+}
+
+// consoleErrorMessages lists captured console errors that are not known noise, with their source.
+func (h *ToolHandler) consoleErrorMessages() []string {
+	entries, _ := h.GetLogEntries()
+	var items []string
+	for _, entry := range entries {
+		if level, _ := entry["level"].(string); level != "error" || h.IsConsoleNoise(entry) {
+			continue
+		}
+		msg, _ := entry["message"].(string)
+		if source, _ := entry["source"].(string); source != "" {
+			msg += " (" + source + ")"
+		}
+		items = append(items, msg)
+	}
+	return items
+}
+
+func junitServerErrors(bodies []capture.NetworkBody) export.JUnitTestCase {
+	var items []string
+	for _, b := range bodies {
+		if b.Status >= 500 {
+			items = append(items, fmt.Sprintf("%s %s -> %d", b.Method, b.URL, b.Status))
+		}
+	}
+	return junitCase("kaboom.network", "no_server_errors", "ServerError", items,
+		fmt.Sprintf("%d response(s) with status >= 500", len(items)))
+}
+
+func (h *ToolHandler) junitAPIContract() export.JUnitTestCase {
+	h.processAPIValidationBodies()
+	result := h.apiContractAnalyze(h.apiValidationFilter("", nil))
+	var items []string
+	for _, v := range result.Violations {
+		items = append(items, fmt.Sprintf("[%s] %s %s: %s", v.Severity, v.Endpoint, v.Type, v.Description))
+	}
+	return junitCase("kaboom.api", "no_api_contract_violations", "ContractViolation", items,
+		fmt.Sprintf("%d API contract violation(s)", len(items)))
+}
+
+// junitAccessibility runs a fresh accessibility audit, as generate(sarif) does, and fails on
+// serious or critical violations.
+func (h *ToolHandler) junitAccessibility() export.JUnitTestCase {
+	const className, name = "kaboom.accessibility", "no_serious_a11y_violations"
+	if !h.IsExtensionConnected() {
+		return junitSkipped(className, name, "Extension not connected; the accessibility audit needs a tracked tab")
+	}
+	raw, err := h.ExecuteA11yQuery("", nil, nil, false)
+	if err != nil {
+		return junitSkipped(className, name, "Accessibility audit failed: "+err.Error())
+	}
+	var audit struct {
+		Violations []struct {
+			ID     string `json:"id"`
+			Impact string `json:"impact"`
+			Help   string `json:"help"`
+			Nodes  []any  `json:"nodes"`
+		} `json:"violations"`
+	}
+	if err := json.Unmarshal(raw, &audit); err != nil {
+		return junitSkipped(className, name, "Accessibility audit returned unreadable results")
+	}
+	var items []string
+	for _, v := range audit.Violations {
+		if v.Impact != "serious" && v.Impact != "critical" {
+			continue
+		}
+		items = append(items, fmt.Sprintf("[%s] %s: %s (%d element(s))", v.Impact, v.ID, v.Help, len(v.Nodes)))
+	}
+	return junitCase(className, name, "AccessibilityViolation", items,
+		fmt.Sprintf("%d serious or critical accessibility violation(s)", len(items)))
+}
+
+// junitVitalsBudgets emits one case per page URL with a captured page load. A budget URL
+// filter limits the pages checked.
+func junitVitalsBudgets(snapshots []capture.PerformanceSnapshot, budget observe.VitalsBudget) []export.JUnitTestCase {
+	const className = "kaboom.performance"
+	byURL := make(map[string][]capture.PerformanceSnapshot)
+	var urls []string
+	for _, s := range snapshots {
+		if budget.URL != "" && !strings.Contains(s.URL, budget.URL) {
+			continue
+		}
+		if _, seen := byURL[s.URL]; !seen {
+			urls = append(urls, s.URL)
+		}
+		byURL[s.URL] = append(byURL[s.URL], s)
+	}
+	if len(urls) == 0 {
+		return []export.JUnitTestCase{junitSkipped(className, "vitals_within_budget", "No page load captured; load the page before exporting")}
+	}
+
+	cases := make([]export.JUnitTestCase, 0, len(urls))
+	for _, url := range urls {
+		pageBudget := budget
+		pageBudget.URL = ""
+		check := observe.CheckVitalsBudget(byURL[url], time.Time{}, pageBudget)
+		name := "vitals_within_budget " + url
+		switch check.Status {
+		case observe.FixCheckFail:
+			cases = append(cases, junitCase(className, name, "BudgetExceeded", []string{check.Detail}, check.Detail))
+		case observe.FixCheckPending:
+			cases = append(cases, junitSkipped(className, name, check.Detail))
+		default:
+			cases = append(cases, export.JUnitTestCase{Name: name, ClassName: className})
+		}
+	}
+	return cases
+}
+
+// junitCheckSummaries is the compact, JSON-friendly view of the cases for the tool response.
+func junitCheckSummaries(cases []export.JUnitTestCase) []map[string]any {
+	out := make([]map[string]any, 0, len(cases))
+	for _, c := range cases {
+		entry := map[string]any{"name": c.Name, "class_name": c.ClassName, "status": "passed"}
+		switch {
+		case c.Failure != nil:
+			entry["status"] = "failed"
+			entry["message"] = c.Failure.Message
+		case c.Skipped != nil:
+			entry["status"] = "skipped"
+			entry["message"] = c.Skipped.Message
+		}
+		out = append(out, entry)
+	}
+	return out
+}
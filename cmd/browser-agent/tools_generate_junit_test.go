@@ -0,0 +1,123 @@
+// Purpose: Tests generate(what="junit") check evaluation, XML output, and save_to.
+// Docs: docs/features/feature/junit-export/index.md
+
+package main
+
+import (
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/export"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/performance"
+)
+
+func junitStatuses(t *testing.T, data map[string]any) map[string]string {
+	t.Helper()
+	statuses := map[string]string{}
+	checks, _ := data["checks"].([]any)
+	for _, raw := range checks {
+		c := raw.(map[string]any)
+		statuses[c["name"].(string)] = c["status"].(string)
+	}
+	return statuses
+}
+
+func TestGenerateJUnit_FailsOnSessionProblems(t *testing.T) {
+	t.Parallel()
+	h, server, cap := makeToolHandler(t)
+	ts := time.Now().UTC().Format(time.RFC3339Nano)
+
+	server.logs.addEntries([]LogEntry{
+		{"level": "error", "message": "TypeError: cart is undefined", "ts": ts},
+		{"level": "warn", "message": "deprecated API", "ts": ts},
+	})
+	cap.AddNetworkBodies([]capture.NetworkBody{
+		{Timestamp: ts, Method: "POST", URL: "http://localhost:5173/api/cart", Status: 503},
+		{Timestamp: ts, Method: "GET", URL: "http://localhost:5173/api/user", Status: 404},
+	})
+	slow, fast := 5200.0, 900.0
+	cap.AddPerformanceSnapshots([]capture.PerformanceSnapshot{
+		{URL: "http://localhost:5173/cart", Timestamp: ts, Timing: performance.PerformanceTiming{LargestContentfulPaint: &slow}},
+		{URL: "http://localhost:5173/", Timestamp: ts, Timing: performance.PerformanceTiming{LargestContentfulPaint: &fast}},
+	})
+
+	result := parseToolResult(t, callGenerateRaw(h, `{"what":"junit"}`))
+	if result.IsError {
+		t.Fatalf("junit should succeed, got: %s", firstText(result))
+	}
+	data := extractResultJSON(t, result)
+
+	want := map[string]string{
+		"no_console_errors":                               "failed",
+		"no_server_errors":                                "failed",
+		"no_api_contract_violations":                      "passed",
+		"no_serious_a11y_violations":                      "skipped",
+		"vitals_within_budget http://localhost:5173/cart": "failed",
+		"vitals_within_budget http://localhost:5173/":     "passed",
+	}
+	got := junitStatuses(t, data)
+	for name, status := range want {
+		if got[name] != status {
+			t.Errorf("check %q = %q, want %q (all: %v)", name, got[name], status, got)
+		}
+	}
+	if data["tests"] != float64(6) || data["failures"] != float64(3) || data["skipped"] != float64(1) {
+		t.Errorf("counts = %v/%v/%v, want 6/3/1", data["tests"], data["failures"], data["skipped"])
+	}
+
+	xmlText, _ := data["xml"].(string)
+	var report export.JUnitReport
+	if err := xml.Unmarshal([]byte(xmlText), &report); err != nil {
+		t.Fatalf("xml does not parse: %v\n%s", err, xmlText)
+	}
+	if report.Failures != 3 || !strings.Contains(xmlText, "POST http://localhost:5173/api/cart -&gt; 503") {
+		t.Errorf("xml should list the 503 and count 3 failures:\n%s", xmlText)
+	}
+	if strings.Contains(xmlText, "/api/user") {
+		t.Error("a 404 is not a server error")
+	}
+	assertSnakeCaseFields(t, string(callGenerateRaw(h, `{"what":"junit"}`).Result))
+}
+
+func TestGenerateJUnit_CustomBudgetAndSaveTo(t *testing.T) {
+	t.Parallel()
+	h, _, cap := makeToolHandler(t)
+	lcp := 2000.0
+	cap.AddPerformanceSnapshots([]capture.PerformanceSnapshot{{URL: "http://localhost:5173/", Timestamp: time.Now().UTC().Format(time.RFC3339Nano),
+		Timing: performance.PerformanceTiming{LargestContentfulPaint: &lcp}}})
+
+	path := filepath.Join(t.TempDir(), "junit.xml")
+	result := parseToolResult(t, callGenerateRaw(h, `{"what":"junit","suite_name":"checkout","budget":{"lcp_ms":1500},"save_to":"`+path+`"}`))
+	if result.IsError {
+		t.Fatalf("junit save_to should succeed, got: %s", firstText(result))
+	}
+	data := extractResultJSON(t, result)
+	if data["saved_to"] != path {
+		t.Errorf("saved_to = %v, want %s", data["saved_to"], path)
+	}
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read report: %v", err)
+	}
+	if !strings.Contains(string(raw), `<testsuite name="checkout"`) || !strings.Contains(string(raw), "lcp_ms 2000 &gt; 1500") {
+		t.Errorf("report should use the suite name and the custom budget:\n%s", raw)
+	}
+}
+
+func TestGenerateJUnit_CleanSessionPasses(t *testing.T) {
+	t.Parallel()
+	h, _, _ := makeToolHandler(t)
+
+	data := extractResultJSON(t, parseToolResult(t, callGenerateRaw(h, `{"what":"junit"}`)))
+	if data["failures"] != float64(0) {
+		t.Errorf("an empty session should not fail, got checks %v", data["checks"])
+	}
+	if got := junitStatuses(t, data)["vitals_within_budget"]; got != "skipped" {
+		t.Errorf("vitals without a page load = %q, want skipped", got)
+	}
+}
@@ -0,0 +1,107 @@
+// Purpose: Implements generate(what="curl") and generate(what="fetch") for a captured network request.
+// Why: Developers reproduce API failures outside the browser from one paste, with credentials left as placeholders.
+// Docs: docs/features/feature/request-snippets/index.md
+
+package main
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/export"
+)
+
+func (h *ToolHandler) toolGenerateCurl(req JSONRPCRequest, args json.RawMessage) JSONRPCResponse {
+	return h.generateRequestSnippet(req, args, "curl")
+}
+
+func (h *ToolHandler) toolGenerateFetch(req JSONRPCRequest, args json.RawMessage) JSONRPCResponse {
+	return h.generateRequestSnippet(req, args, "fetch")
+}
+
+// generateRequestSnippet renders the network body with request_id as a cURL command or fetch() call.
+// The complete request body is used when it was spilled to the full-body store.
+func (h *ToolHandler) generateRequestSnippet(req JSONRPCRequest, args json.RawMessage, format string) JSONRPCResponse {
+	var params struct {
+		RequestID string `json:"request_id"`
+		SaveTo    string `json:"save_to"`
+	}
+	if resp, stop := parseArgs(req, args, &params); stop {
+		return resp
+	}
+	if params.RequestID == "" {
+		return fail(req, ErrMissingParam, "Required parameter 'request_id' is missing",
+			"Add request_id from observe(what='network_bodies')", withParam("request_id"))
+	}
+
+	var entry *capture.NetworkBody
+	bodies := h.capture.GetNetworkBodies()
+	for i := len(bodies) - 1; i >= 0; i-- {
+		if bodies[i].RequestID == params.RequestID {
+			entry = &bodies[i]
+			break
+		}
+	}
+	if entry == nil {
+		return fail(req, ErrNoData, "No captured request with id "+params.RequestID,
+			"Call observe(what='network_bodies') for current request ids; old entries are evicted from the ring buffer",
+			withParam("request_id"))
+	}
+
+	var warnings []string
+	body, complete := entry.RequestBody, !entry.RequestTruncated
+	if full, ok := h.capture.GetFullBody(entry.RequestID); ok {
+		body, complete = full.RequestBody, full.Complete
+	}
+	if !complete {
+		warnings = append(warnings, "The captured request body is truncated; paste the full body before running the snippet")
+	}
+	if strings.HasPrefix(body, "[REDACTED") {
+		warnings = append(warnings, "The request body of auth endpoints is not captured; replace the body placeholder")
+	}
+
+	headers := make(map[string]string, len(entry.RequestHeaders)+1)
+	for name, value := range entry.RequestHeaders {
+		headers[name] = value
+	}
+	if body != "" && json.Valid([]byte(body)) && !hasHeader(headers, "Content-Type") {
+		headers["Content-Type"] = "application/json"
+	}
+	if len(entry.RequestHeaders) == 0 {
+		warnings = append(warnings, "No request headers were captured; add any the endpoint needs, such as Authorization")
+	}
+
+	snippetReq := export.RequestSnippet{Method: entry.Method, URL: entry.URL, Headers: headers, Body: body}
+	snippet, redacted := export.FormatCurl(snippetReq)
+	label := "cURL command"
+	if format == "fetch" {
+		snippet, redacted = export.FormatFetch(snippetReq)
+		label = "fetch() snippet"
+	}
+
+	response := map[string]any{
+		"request_id": entry.RequestID,
+		"method":     entry.Method,
+		"url":        entry.URL,
+		"status":     entry.Status,
+	}
+	if len(redacted) > 0 {
+		response["redacted_headers"] = redacted
+	}
+	if len(warnings) > 0 {
+		response["warnings"] = warnings
+	}
+	summary := label + " for " + entry.Method + " " + entry.URL
+	if params.SaveTo != "" {
+		result, err := export.WriteRequestSnippetFile(snippet, params.SaveTo)
+		if err != nil {
+			return fail(req, ErrExportFailed, "Snippet export failed: "+err.Error(), "Check the save_to path and try again", withParam("save_to"))
+		}
+		response["saved_to"] = result.SavedTo
+		response["file_size_bytes"] = result.FileSizeBytes
+		return succeed(req, summary+" saved to "+result.SavedTo, response)
+	}
+	response["snippet"] = snippet
+	return succeed(req, summary, response)
+}
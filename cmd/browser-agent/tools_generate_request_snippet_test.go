@@ -0,0 +1,92 @@
+// Purpose: Tests generate(what="curl"/"fetch") request lookup, full-body use, redaction, and save_to.
+// Docs: docs/features/feature/request-snippets/index.md
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+)
+
+func TestGenerateRequestSnippet_CurlAndFetch(t *testing.T) {
+	t.Parallel()
+	h, _, cap := makeToolHandler(t)
+	cap.AddNetworkBodies([]capture.NetworkBody{{
+		Method:         "POST",
+		URL:            "https://shop.test/api/checkout",
+		Status:         502,
+		RequestBody:    `{"cart":42}`,
+		RequestHeaders: map[string]string{"Authorization": "Bearer secret-token", "X-Client": "web"},
+	}})
+	requestID := cap.GetNetworkBodies()[0].RequestID
+
+	curl := extractResultJSON(t, parseToolResult(t, callGenerateRaw(h, `{"what":"curl","request_id":"`+requestID+`"}`)))
+	snippet, _ := curl["snippet"].(string)
+	for _, want := range []string{
+		"curl 'https://shop.test/api/checkout'",
+		"-X POST",
+		"-H 'Authorization: [REDACTED:key-authorization]'",
+		"-H 'Content-Type: application/json'",
+		"-H 'X-Client: web'",
+		`--data-raw '{"cart":42}'`,
+	} {
+		if !strings.Contains(snippet, want) {
+			t.Errorf("curl snippet missing %q:\n%s", want, snippet)
+		}
+	}
+	if strings.Contains(snippet, "secret-token") {
+		t.Fatal("credentials must not reach the snippet")
+	}
+	if redacted, _ := curl["redacted_headers"].([]any); len(redacted) != 1 || redacted[0] != "Authorization" {
+		t.Errorf("redacted_headers = %v", curl["redacted_headers"])
+	}
+
+	fetch := extractResultJSON(t, parseToolResult(t, callGenerateRaw(h, `{"format":"fetch","request_id":"`+requestID+`"}`)))
+	if js, _ := fetch["snippet"].(string); !strings.Contains(js, `await fetch("https://shop.test/api/checkout"`) || !strings.Contains(js, "JSON.stringify(") {
+		t.Errorf("fetch snippet = %s", js)
+	}
+}
+
+func TestGenerateRequestSnippet_WarningsAndSaveTo(t *testing.T) {
+	t.Parallel()
+	h, _, cap := makeToolHandler(t)
+	cap.AddNetworkBodies([]capture.NetworkBody{{
+		Method:           "POST",
+		URL:              "https://shop.test/api/login",
+		Status:           401,
+		RequestBody:      "[REDACTED: auth endpoint]",
+		RequestTruncated: true,
+	}})
+	requestID := cap.GetNetworkBodies()[0].RequestID
+
+	path := filepath.Join(t.TempDir(), "login.sh")
+	data := extractResultJSON(t, parseToolResult(t, callGenerateRaw(h, `{"what":"curl","request_id":"`+requestID+`","save_to":"`+path+`"}`)))
+	if data["saved_to"] != path {
+		t.Fatalf("saved_to = %v, want %s", data["saved_to"], path)
+	}
+	if _, ok := data["snippet"]; ok {
+		t.Error("snippet should be omitted when save_to is set")
+	}
+	if warnings, _ := data["warnings"].([]any); len(warnings) != 3 {
+		t.Errorf("warnings = %v, want truncated body, redacted body, and missing headers", data["warnings"])
+	}
+	raw, err := os.ReadFile(path)
+	if err != nil || !strings.HasPrefix(string(raw), "curl 'https://shop.test/api/login'") {
+		t.Fatalf("file = %q, err = %v", raw, err)
+	}
+
+	for _, tc := range []struct{ args, want string }{
+		{`{"what":"curl"}`, "request_id"},
+		{`{"what":"fetch","request_id":"req-999"}`, "No captured request"},
+		{`{"what":"curl","request_id":"` + requestID + `","save_to":"../escape.sh"}`, "unsafe path"},
+	} {
+		result := parseToolResult(t, callGenerateRaw(h, tc.args))
+		if !result.IsError || !strings.Contains(firstText(result), tc.want) {
+			t.Errorf("%s: expected error containing %q, got %s", tc.args, tc.want, firstText(result))
+		}
+	}
+}
@@ -0,0 +1,59 @@
+// Purpose: Implements generate(what="session_bundle"), which archives every capture buffer into one JSON file.
+// Why: A bundle can be attached to an incident and later replayed with --serve-bundle without a browser.
+// Docs: docs/features/feature/read-only-mode/index.md
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/sessionbundle"
+)
+
+// toolGenerateSessionBundle writes the current capture state to a session bundle file.
+// Without save_to the bundle goes to <state root>/bundles/session-<timestamp>.json.
+func (h *ToolHandler) toolGenerateSessionBundle(req JSONRPCRequest, args json.RawMessage) JSONRPCResponse {
+	var params struct {
+		SaveTo string `json:"save_to"`
+		Label  string `json:"label"`
+	}
+	if resp, stop := parseArgs(req, args, &params); stop {
+		return resp
+	}
+	if strings.Contains(params.SaveTo, "..") {
+		return fail(req, ErrInvalidParam, "save_to must not contain '..'", "Use an absolute path or a path relative to the working directory", withParam("save_to"))
+	}
+
+	now := time.Now()
+	path := params.SaveTo
+	if path == "" {
+		dir, err := sessionbundle.DefaultDir()
+		if err != nil {
+			return fail(req, ErrExportFailed, "Cannot resolve bundle directory: "+err.Error(), "Pass save_to with an explicit file path")
+		}
+		path = filepath.Join(dir, "session-"+now.UTC().Format("20060102-150405")+".json")
+	}
+
+	var logs []LogEntry
+	if h.server != nil {
+		logs = h.server.logs.getEntries()
+	}
+	bundle := sessionbundle.FromCapture(h.capture, logs, params.Label, now)
+	savedTo, size, err := sessionbundle.Write(path, bundle)
+	if err != nil {
+		return fail(req, ErrExportFailed, "Session bundle export failed: "+err.Error(), "Check the save_to path and try again")
+	}
+	return succeed(req, fmt.Sprintf("Session bundle saved to %s", savedTo), map[string]any{
+		"saved_to":       savedTo,
+		"bytes":          size,
+		"format_version": bundle.FormatVersion,
+		"created_at":     bundle.CreatedAt,
+		"label":          bundle.Label,
+		"counts":         bundle.Counts(),
+		"serve_hint":     "kaboom --serve-bundle " + savedTo,
+	})
+}
@@ -11,7 +11,9 @@ import (
 
 	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/cmd/browser-agent/internal/toolinteract"
 	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/loginrecipe"
 	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/queries"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/statevault"
 	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/tools/observe"
 )
 
@@ -23,6 +25,9 @@ func buildInteractDeps(h *ToolHandler) *toolinteract.Deps {
 		RequireExtension:   h.requireExtension,
 		RequireTabTracking: h.requireTabTracking,
 		RequireCSPClear:    h.requireCSPClear,
+		CDPPassthroughAllows: func(method string) (bool, string) {
+			return h.cdpPolicy.Allows(method)
+		},
 
 		// Command dispatch
 		EnqueuePendingQuery: h.EnqueuePendingQuery,
@@ -84,6 +89,10 @@ func buildInteractDeps(h *ToolHandler) *toolinteract.Deps {
 		GetCommandResult: func(correlationID string) (*queries.CommandResult, bool) {
 			return h.capture.GetCommandResult(correlationID)
 		},
+		StateVault: func() *statevault.Vault { return h.stateVault },
+		LoadLoginRecipe: func(name string) (loginrecipe.Recipe, error) {
+			return loginrecipe.Load(h.sessionStoreImpl, name)
+		},
 
 		// Shared mutex for batch/replay serialization
 		ReplayMu: &replayMu,
@@ -0,0 +1,56 @@
+// Purpose: Holds the operator policy for interact(what="cdp"): whether raw Chrome DevTools Protocol calls are allowed, and which methods.
+// Why: The passthrough reaches capabilities Kaboom has not wrapped, so it stays off unless the person running the daemon opts in.
+// Docs: docs/features/feature/cdp-passthrough/index.md
+
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CDPPassthroughPolicy is set by --enable-cdp-passthrough and --cdp-allow-method.
+// Agents cannot change it at runtime.
+type CDPPassthroughPolicy struct {
+	Enabled bool `json:"enabled"`
+	// AllowMethods holds "Domain.method" or "Domain.*" patterns. Empty allows every
+	// method not in cdpDeniedMethods.
+	AllowMethods []string `json:"allow_methods,omitempty"`
+}
+
+// cdpDeniedMethods are refused even when allowed by pattern: they reach past the
+// tracked tab (other targets, the browser process, every origin's cookies and storage)
+// or tear down the page under test.
+var cdpDeniedMethods = []string{
+	"Target.*", "Browser.*", "Storage.*",
+	"Network.getAllCookies", "Network.clearBrowserCookies", "Network.clearBrowserCache",
+	"Page.close", "Page.crash",
+}
+
+// Allows reports whether method may be sent, with the reason when it may not.
+func (p CDPPassthroughPolicy) Allows(method string) (bool, string) {
+	if !p.Enabled {
+		return false, "CDP passthrough is disabled. The daemon must be started with --enable-cdp-passthrough (or KABOOM_CDP_PASSTHROUGH=1)."
+	}
+	if cdpMethodMatches(cdpDeniedMethods, method) {
+		return false, fmt.Sprintf("CDP method %s is never passed through: it acts outside the tracked tab (other targets, the browser, or browser-wide cookies and storage) or closes it.", method)
+	}
+	if len(p.AllowMethods) > 0 && !cdpMethodMatches(p.AllowMethods, method) {
+		return false, fmt.Sprintf("CDP method %s is not in the daemon's --cdp-allow-method list (%s).", method, strings.Join(p.AllowMethods, ", "))
+	}
+	return true, ""
+}
+
+// cdpMethodMatches matches exact methods and "Domain.*" wildcards.
+func cdpMethodMatches(patterns []string, method string) bool {
+	for _, pattern := range patterns {
+		if domain, ok := strings.CutSuffix(pattern, ".*"); ok {
+			if strings.HasPrefix(method, domain+".") {
+				return true
+			}
+		} else if pattern == method {
+			return true
+		}
+	}
+	return false
+}
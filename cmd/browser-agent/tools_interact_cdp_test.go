@@ -2,10 +2,11 @@
 // Why: Prevents silent regressions in hardware_click and CDP escalation paths.
 // Docs: docs/features/feature/interact-explore/index.md
 
-// tools_interact_cdp_test.go — Tests for hardware_click action and click CDP escalation.
+// tools_interact_cdp_test.go — Tests for hardware_click, click CDP escalation, and cdp passthrough.
 package main
 
 import (
+	"encoding/json"
 	"net/http/httptest"
 	"strings"
 	"testing"
@@ -193,3 +194,110 @@ func TestToolsInteractDispatch_HardwareClickInValidActions(t *testing.T) {
 		t.Error("hardware_click should appear in valid interact actions list")
 	}
 }
+
+// ============================================
+// cdp — Passthrough Policy
+// ============================================
+
+func TestToolsInteractCDP_DisabledByDefault(t *testing.T) {
+	t.Parallel()
+	h, _, cap := makeToolHandler(t)
+	cap.SetPilotEnabled(true)
+
+	resp := callInteractRaw(h, `{"what":"cdp","method":"Network.emulateNetworkConditions","params":{"offline":true}}`)
+	if code := extractErrorCode(t, resp); code != ErrCDPPassthroughDisabled {
+		t.Fatalf("error code = %q, want %q", code, ErrCDPPassthroughDisabled)
+	}
+	if pq := cap.GetLastPendingQuery(); pq != nil {
+		t.Errorf("disabled passthrough should not queue a command, got %+v", pq)
+	}
+}
+
+func TestToolsInteractCDP_PolicyRejectsMethods(t *testing.T) {
+	t.Parallel()
+	h, _, cap := makeToolHandler(t)
+	cap.SetPilotEnabled(true)
+	h.cdpPolicy = CDPPassthroughPolicy{Enabled: true, AllowMethods: []string{"Network.*", "Storage.*", "Target.createTarget"}}
+
+	for _, method := range []string{
+		"Emulation.setGeolocationOverride", "Target.createTarget", "Browser.close",
+		"Storage.getCookies", "Storage.clearDataForOrigin",
+		"Network.getAllCookies", "Network.clearBrowserCookies", "Network.clearBrowserCache",
+	} {
+		resp := callInteractRaw(h, `{"what":"cdp","method":"`+method+`"}`)
+		if code := extractErrorCode(t, resp); code != ErrCDPPassthroughDisabled {
+			t.Errorf("%s: error code = %q, want %q", method, code, ErrCDPPassthroughDisabled)
+		}
+	}
+	resp := callInteractRaw(h, `{"what":"cdp","method":"network.enable"}`)
+	if code := extractErrorCode(t, resp); code != ErrInvalidParam {
+		t.Errorf("malformed method: error code = %q, want %q", code, ErrInvalidParam)
+	}
+}
+
+func TestToolsInteractCDP_QueuesPassthrough(t *testing.T) {
+	t.Parallel()
+	h, _, cap := makeToolHandler(t)
+	h.cdpPolicy = CDPPassthroughPolicy{Enabled: true}
+	cap.SetPilotEnabled(true)
+	syncReq := httptest.NewRequest("POST", "/sync", strings.NewReader(`{"ext_session_id":"test"}`))
+	syncReq.Header.Set("X-Kaboom-Client", "test-client")
+	cap.HandleSync(httptest.NewRecorder(), syncReq)
+	cap.SetTrackingStatusForTest(42, "https://example.com")
+
+	resp := callInteractRaw(h, `{"what":"cdp","method":"Network.emulateNetworkConditions","params":{"offline":false,"latency":400,"downloadThroughput":50000,"uploadThroughput":20000}}`)
+	result := parseToolResult(t, resp)
+	if result.IsError {
+		t.Fatalf("cdp should queue when the policy allows it, got: %s", result.Content[0].Text)
+	}
+	pq := cap.GetLastPendingQuery()
+	if pq == nil || pq.Type != "cdp_action" {
+		t.Fatalf("pending query = %+v, want cdp_action", pq)
+	}
+	var params struct {
+		Action string         `json:"action"`
+		Method string         `json:"method"`
+		Params map[string]any `json:"params"`
+	}
+	if err := json.Unmarshal(pq.Params, &params); err != nil {
+		t.Fatalf("pending query params: %v", err)
+	}
+	if params.Action != "passthrough" || params.Method != "Network.emulateNetworkConditions" || params.Params["latency"] != float64(400) {
+		t.Errorf("pending query params = %+v", params)
+	}
+}
+
+func TestToolsInteractCDP_QueuesRelease(t *testing.T) {
+	t.Parallel()
+	h, _, cap := makeToolHandler(t)
+	h.cdpPolicy = CDPPassthroughPolicy{Enabled: true, AllowMethods: []string{"Network.*"}}
+	cap.SetPilotEnabled(true)
+	syncReq := httptest.NewRequest("POST", "/sync", strings.NewReader(`{"ext_session_id":"test"}`))
+	syncReq.Header.Set("X-Kaboom-Client", "test-client")
+	cap.HandleSync(httptest.NewRecorder(), syncReq)
+	cap.SetTrackingStatusForTest(42, "https://example.com")
+
+	resp := callInteractRaw(h, `{"what":"cdp","release":true}`)
+	result := parseToolResult(t, resp)
+	if result.IsError {
+		t.Fatalf("release should queue without a method, got: %s", result.Content[0].Text)
+	}
+	pq := cap.GetLastPendingQuery()
+	if pq == nil || pq.Type != "cdp_action" {
+		t.Fatalf("pending query = %+v, want cdp_action", pq)
+	}
+	var params struct {
+		Action string `json:"action"`
+	}
+	if err := json.Unmarshal(pq.Params, &params); err != nil {
+		t.Fatalf("pending query params: %v", err)
+	}
+	if params.Action != "release" {
+		t.Errorf("action = %q, want release", params.Action)
+	}
+
+	resp = callInteractRaw(h, `{"what":"cdp"}`)
+	if code := extractErrorCode(t, resp); code != ErrMissingParam {
+		t.Errorf("no method and no release: error code = %q, want %q", code, ErrMissingParam)
+	}
+}
@@ -179,6 +179,9 @@ func buildInteractHandlers() map[string]ModeHandler {
 		"hardware_click": func(th *ToolHandler, req JSONRPCRequest, args json.RawMessage) JSONRPCResponse {
 			return th.interactAction().HandleHardwareClick(req, args)
 		},
+		"cdp": func(th *ToolHandler, req JSONRPCRequest, args json.RawMessage) JSONRPCResponse {
+			return th.interactAction().HandleCDPPassthrough(req, args)
+		},
 		"activate_tab": func(th *ToolHandler, req JSONRPCRequest, args json.RawMessage) JSONRPCResponse {
 			return th.interactAction().HandleActivateTabImpl(req, args)
 		},
@@ -200,6 +203,15 @@ func buildInteractHandlers() map[string]ModeHandler {
 		"fill_form": func(th *ToolHandler, req JSONRPCRequest, args json.RawMessage) JSONRPCResponse {
 			return th.interactAction().HandleFillForm(req, args)
 		},
+		"login": func(th *ToolHandler, req JSONRPCRequest, args json.RawMessage) JSONRPCResponse {
+			return th.interactAction().HandleLogin(req, args)
+		},
+		"replay_request": func(th *ToolHandler, req JSONRPCRequest, args json.RawMessage) JSONRPCResponse {
+			return th.interactAction().HandleReplayRequest(req, args)
+		},
+		"emulate": func(th *ToolHandler, req JSONRPCRequest, args json.RawMessage) JSONRPCResponse {
+			return th.interactAction().HandleEmulate(req, args)
+		},
 		"run_a11y_and_export_sarif": func(th *ToolHandler, req JSONRPCRequest, args json.RawMessage) JSONRPCResponse {
 			return th.interactAction().HandleRunA11yAndExportSARIF(req, args)
 		},
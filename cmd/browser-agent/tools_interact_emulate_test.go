@@ -0,0 +1,121 @@
+// Purpose: Tests interact(what="emulate") device resolution, the queued command, and the device recorded on snapshots and generated tests.
+// Docs: docs/features/feature/device-emulation/index.md
+
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+)
+
+func emulateQueries(env *interactTestEnv) []map[string]any {
+	var out []map[string]any
+	for _, q := range env.capture.GetPendingQueries() {
+		if q.Type == "emulate" {
+			var params map[string]any
+			_ = json.Unmarshal(q.Params, &params)
+			out = append(out, params)
+		}
+	}
+	return out
+}
+
+func TestEmulate_PresetIsQueuedAndRecorded(t *testing.T) {
+	t.Parallel()
+	env := newInteractTestEnv(t)
+	env.capture.SetPilotEnabled(true)
+
+	result, ok := env.callInteract(t, `{"what":"emulate","device":"iphone 14"}`)
+	if !ok || result.IsError {
+		t.Fatalf("emulate failed: %s", firstText(result))
+	}
+	queries := emulateQueries(env)
+	if len(queries) != 1 {
+		t.Fatalf("emulate queries = %d, want 1", len(queries))
+	}
+	q := queries[0]
+	if q["action"] != "apply" || q["device"] != "iPhone 14" || q["width"] != float64(390) || q["device_scale_factor"] != float64(3) || q["touch"] != true {
+		t.Fatalf("emulate params = %v", q)
+	}
+
+	device, ok := env.capture.GetDeviceEmulation()
+	if !ok || device.Label() != "iPhone 14 (390x664 @3x)" {
+		t.Fatalf("recorded device = %+v, %v", device, ok)
+	}
+
+	env.capture.AddPerformanceSnapshots([]capture.PerformanceSnapshot{{URL: "https://example.com/", Timestamp: time.Now().Format(time.RFC3339)}})
+	if snap, _ := env.capture.GetPerformanceSnapshotByURL("https://example.com/"); snap.Emulation != "iPhone 14 (390x664 @3x)" {
+		t.Fatalf("snapshot emulation = %q", snap.Emulation)
+	}
+
+	env.capture.AddEnhancedActions([]capture.EnhancedAction{{Type: "navigate", Timestamp: time.Now().UnixMilli(), ToURL: "https://example.com/"}})
+	generated := parseToolResult(t, env.handler.toolGenerate(JSONRPCRequest{JSONRPC: "2.0", ID: 1}, json.RawMessage(`{"what":"test"}`)))
+	script, _ := extractResultJSON(t, generated)["script"].(string)
+	for _, want := range []string{"import { test, expect, devices } from '@playwright/test';", "...devices['iPhone 14'],"} {
+		if !strings.Contains(script, want) {
+			t.Fatalf("generated test missing %q:\n%s", want, script)
+		}
+	}
+
+	if result, ok := env.callInteract(t, `{"what":"emulate","device":"off"}`); !ok || result.IsError {
+		t.Fatalf("emulate off failed: %s", firstText(result))
+	}
+	if queries := emulateQueries(env); len(queries) != 2 || queries[1]["action"] != "clear" {
+		t.Fatalf("emulate off should queue a clear, got %v", queries)
+	}
+	if _, ok := env.capture.GetDeviceEmulation(); ok {
+		t.Fatal("device should be cleared after emulate off")
+	}
+}
+
+func TestEmulate_CustomDevice(t *testing.T) {
+	t.Parallel()
+	env := newInteractTestEnv(t)
+	env.capture.SetPilotEnabled(true)
+
+	result, ok := env.callInteract(t, `{"what":"emulate","device":{"width":800,"height":600,"dpr":1.5,"ua":"Kiosk/1.0","touch":true}}`)
+	if !ok || result.IsError {
+		t.Fatalf("emulate failed: %s", firstText(result))
+	}
+	q := emulateQueries(env)[0]
+	if q["width"] != float64(800) || q["device_scale_factor"] != 1.5 || q["user_agent"] != "Kiosk/1.0" || q["mobile"] != true {
+		t.Fatalf("emulate params = %v", q)
+	}
+
+	env.capture.AddEnhancedActions([]capture.EnhancedAction{{Type: "navigate", Timestamp: time.Now().UnixMilli(), ToURL: "https://example.com/"}})
+	generated := parseToolResult(t, env.handler.toolGenerate(JSONRPCRequest{JSONRPC: "2.0", ID: 1}, json.RawMessage(`{"what":"reproduction","output_format":"playwright"}`)))
+	script, _ := extractResultJSON(t, generated)["script"].(string)
+	for _, want := range []string{"viewport: { width: 800, height: 600 }", "deviceScaleFactor: 1.5", "userAgent: 'Kiosk/1.0'", "hasTouch: true", "isMobile: true"} {
+		if !strings.Contains(script, want) {
+			t.Fatalf("reproduction missing %q:\n%s", want, script)
+		}
+	}
+	if strings.Contains(script, "devices") {
+		t.Fatalf("custom devices should not import Playwright descriptors:\n%s", script)
+	}
+}
+
+func TestEmulate_InvalidDevice(t *testing.T) {
+	t.Parallel()
+	env := newInteractTestEnv(t)
+	env.capture.SetPilotEnabled(true)
+
+	for _, tc := range []struct{ args, want string }{
+		{`{"what":"emulate"}`, "device"},
+		{`{"what":"emulate","device":"Nokia 3310"}`, "Unknown device"},
+		{`{"what":"emulate","device":{"width":0,"height":600}}`, "width and height"},
+		{`{"what":"emulate","device":{"width":800,"height":600,"dpr":40}}`, "dpr"},
+	} {
+		result, ok := env.callInteract(t, tc.args)
+		if !ok || !result.IsError || !strings.Contains(firstText(result), tc.want) {
+			t.Errorf("%s: expected error containing %q, got %s", tc.args, tc.want, firstText(result))
+		}
+	}
+	if len(emulateQueries(env)) != 0 {
+		t.Fatal("invalid devices must not queue a command")
+	}
+}
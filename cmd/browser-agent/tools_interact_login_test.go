@@ -0,0 +1,199 @@
+// Purpose: Tests for configure(what="define_login") and interact(what="login").
+// Docs: docs/features/feature/login-recipes/index.md
+
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"sync"
+	"testing"
+)
+
+const stagingAdminRecipe = `{
+	"name": "staging-admin",
+	"vars": {"user": "admin@example.com"},
+	"steps": [
+		{"what": "navigate", "url": "https://app.test/login"},
+		{"what": "type", "selector": "#email", "text": "{{user}}"},
+		{"what": "type", "selector": "#password", "text_env": "KABOOM_LOGIN_TEST_PASS"},
+		{"what": "click", "selector": "button[type=submit]"},
+		{"what": "wait_for", "selector": "#dashboard"}
+	]
+}`
+
+// playLoginExtension completes every command the login run queues, echoing typed text back
+// the way the extension does, until the test ends. It returns the correlation IDs it answered by query type.
+// Like awaitPendingQuery, it wakes on PendingQueryAdded rather than polling the queue.
+func playLoginExtension(t *testing.T, env *interactHelpersTestEnv) func() map[string][]string {
+	t.Helper()
+	var mu sync.Mutex
+	answered := map[string][]string{}
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		seen := map[string]bool{}
+		for {
+			// Take the signal before reading the queue so an enqueue in between still wakes us.
+			added := env.capture.PendingQueryAdded()
+			for _, q := range env.capture.GetPendingQueries() {
+				if q.CorrelationID == "" || seen[q.CorrelationID] {
+					continue
+				}
+				seen[q.CorrelationID] = true
+				var params map[string]any
+				_ = json.Unmarshal(q.Params, &params)
+				result := map[string]any{"success": true, "action": params["action"]}
+				switch q.Type {
+				case "dom_action":
+					result["value"] = params["text"]
+				case "auth_state":
+					result = capturedAuthState
+				}
+				body, _ := json.Marshal(result)
+				env.capture.CompleteCommand(q.CorrelationID, body, "")
+				mu.Lock()
+				answered[q.Type] = append(answered[q.Type], q.CorrelationID)
+				mu.Unlock()
+			}
+			select {
+			case <-done:
+				return
+			case <-added:
+			}
+		}
+	}()
+	stop := func() map[string][]string {
+		close(done)
+		<-stopped
+		mu.Lock()
+		defer mu.Unlock()
+		return answered
+	}
+	t.Cleanup(func() {
+		select {
+		case <-stopped:
+		default:
+			stop()
+		}
+	})
+	return stop
+}
+
+func TestConfigureDefineLogin_AddListRemove(t *testing.T) {
+	t.Parallel()
+	env := newInteractHelpersTestEnv(t)
+	useTempSessionStore(t, env)
+	req := JSONRPCRequest{JSONRPC: "2.0", ID: json.RawMessage(`1`), ClientID: "test-client"}
+
+	data := extractResponseData(t, env.handler.toolConfigureDefineLogin(req, json.RawMessage(stagingAdminRecipe)))
+	recipe, _ := data["recipe"].(map[string]any)
+	if data["status"] != "saved" || recipe["origin"] != "https://app.test" || recipe["step_count"] != 5.0 {
+		t.Fatalf("add = %v", data)
+	}
+
+	data = extractResponseData(t, env.handler.toolConfigureDefineLogin(req, json.RawMessage(`{}`)))
+	recipes, _ := data["recipes"].([]any)
+	if data["count"] != 1.0 || len(recipes) != 1 {
+		t.Fatalf("list = %v", data)
+	}
+	listed := recipes[0].(map[string]any)
+	if secretEnv, _ := listed["secret_env"].([]any); len(secretEnv) != 1 || secretEnv[0] != "KABOOM_LOGIN_TEST_PASS" {
+		t.Fatalf("secret_env = %v", listed["secret_env"])
+	}
+
+	data = extractResponseData(t, env.handler.toolConfigureDefineLogin(req, json.RawMessage(`{"operation":"remove","name":"staging-admin"}`)))
+	if data["status"] != "removed" {
+		t.Fatalf("remove = %v", data)
+	}
+	resp := env.handler.toolConfigureDefineLogin(req, json.RawMessage(`{"operation":"remove","name":"staging-admin"}`))
+	if code := extractErrorCode(t, resp); code != ErrNoData {
+		t.Fatalf("remove missing: code = %q, want %q", code, ErrNoData)
+	}
+}
+
+func TestConfigureDefineLogin_RejectsInvalidRecipes(t *testing.T) {
+	t.Parallel()
+	env := newInteractHelpersTestEnv(t)
+	useTempSessionStore(t, env)
+	req := JSONRPCRequest{JSONRPC: "2.0", ID: json.RawMessage(`1`), ClientID: "test-client"}
+
+	for name, args := range map[string]string{
+		"env outside prefix": `{"name":"x","steps":[{"what":"navigate","url":"https://app.test/"},{"what":"type","selector":"#p","text_env":"HOME"}]}`,
+		"no navigate first":  `{"name":"x","steps":[{"what":"click","selector":"#go"}]}`,
+		"two origins":        `{"name":"x","steps":[{"what":"navigate","url":"https://app.test/"},{"what":"navigate","url":"https://evil.test/"}]}`,
+		"unknown operation":  `{"operation":"rename"}`,
+	} {
+		if code := extractErrorCode(t, env.handler.toolConfigureDefineLogin(req, json.RawMessage(args))); code != ErrInvalidParam {
+			t.Errorf("%s: code = %q, want %q", name, code, ErrInvalidParam)
+		}
+	}
+}
+
+// TestInteractLogin_RunsRecipeRedactsSecretAndSavesToVault is not parallel: it sets an environment variable.
+func TestInteractLogin_RunsRecipeRedactsSecretAndSavesToVault(t *testing.T) {
+	t.Setenv("KABOOM_LOGIN_TEST_PASS", "hunter2-secret")
+	env := newVaultTestEnv(t)
+	req := JSONRPCRequest{JSONRPC: "2.0", ID: json.RawMessage(`1`), ClientID: "test-client"}
+	extractResponseData(t, env.handler.toolConfigureDefineLogin(req, json.RawMessage(stagingAdminRecipe)))
+
+	stop := playLoginExtension(t, env)
+	resp := env.handler.interactAction().HandleLogin(req, json.RawMessage(`{"recipe":"staging-admin","vars":{"user":"qa@example.com"}}`))
+	answered := stop()
+	data := extractResponseData(t, resp)
+
+	if data["status"] != "logged_in" || data["origin"] != "https://app.test" {
+		t.Fatalf("login = %v", data)
+	}
+	if state, _ := data["state"].(map[string]any); state["vault"] != true {
+		t.Fatalf("state = %v, want vault save", data["state"])
+	}
+	if trace, _ := data["trace"].([]any); len(trace) != 6 {
+		t.Fatalf("trace has %d steps, want 5 steps and save_state", len(trace))
+	}
+	if strings.Contains(string(resp.Result), "hunter2-secret") {
+		t.Fatal("login response leaks the password")
+	}
+	if entries, _ := env.handler.stateVault.List("https://app.test"); len(entries) != 1 || entries[0].Name != "staging-admin" {
+		t.Fatalf("vault entries = %+v", entries)
+	}
+
+	var typed []string
+	for _, action := range env.capture.GetAllEnhancedActions() {
+		if action.Type == "input" {
+			typed = append(typed, action.Value)
+		}
+	}
+	if strings.Join(typed, ",") != "qa@example.com,[redacted]" {
+		t.Fatalf("recorded input values = %v, want the user and a redacted password", typed)
+	}
+	for _, correlationID := range answered["dom_action"] {
+		cmd, found := env.capture.GetCommandResult(correlationID)
+		if found && strings.Contains(string(cmd.Result), "hunter2-secret") {
+			t.Fatalf("command %s still holds the password: %s", correlationID, cmd.Result)
+		}
+	}
+}
+
+func TestInteractLogin_MissingSecretQueuesNothing(t *testing.T) {
+	env := newInteractHelpersTestEnv(t)
+	env.enablePilot(t)
+	useTempSessionStore(t, env)
+	t.Setenv("KABOOM_LOGIN_TEST_PASS", "")
+	req := JSONRPCRequest{JSONRPC: "2.0", ID: json.RawMessage(`1`), ClientID: "test-client"}
+	extractResponseData(t, env.handler.toolConfigureDefineLogin(req, json.RawMessage(stagingAdminRecipe)))
+
+	resp := env.handler.interactAction().HandleLogin(req, json.RawMessage(`{"recipe":"staging-admin"}`))
+	if code := extractErrorCode(t, resp); code != ErrInvalidParam {
+		t.Fatalf("code = %q, want %q", code, ErrInvalidParam)
+	}
+	if pending := env.capture.GetPendingQueries(); len(pending) != 0 {
+		t.Fatalf("queued %d commands before failing", len(pending))
+	}
+
+	resp = env.handler.interactAction().HandleLogin(req, json.RawMessage(`{"recipe":"nope"}`))
+	if code := extractErrorCode(t, resp); code != ErrNoData {
+		t.Fatalf("unknown recipe: code = %q, want %q", code, ErrNoData)
+	}
+}
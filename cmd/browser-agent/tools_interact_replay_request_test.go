@@ -0,0 +1,131 @@
+// Purpose: Tests interact(what="replay_request") request lookup, overrides, and the replay command it queues.
+// Docs: docs/features/feature/request-replay/index.md
+
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+)
+
+func TestReplayRequest_QueuesCapturedRequestWithOverrides(t *testing.T) {
+	t.Parallel()
+	env := newInteractTestEnv(t)
+	env.capture.SetPilotEnabled(true)
+	env.capture.AddNetworkBodies([]capture.NetworkBody{{
+		Method:         "POST",
+		URL:            "https://shop.test/api/checkout",
+		Status:         502,
+		Duration:       840,
+		ContentType:    "application/json",
+		RequestBody:    `{"cart":42}`,
+		ResponseBody:   `{"error":"upstream"}`,
+		RequestHeaders: map[string]string{"Content-Type": "application/json", "X-Trace": "abc"},
+	}})
+	requestID := env.capture.GetNetworkBodies()[0].RequestID
+
+	result, ok := env.callInteract(t, `{"what":"replay_request","request_id":"`+requestID+`","overrides":{"headers":{"x-trace":"","X-Debug":"1"}}}`)
+	if !ok || result.IsError {
+		t.Fatalf("replay_request failed: %s", firstText(result))
+	}
+
+	var replays []map[string]any
+	for _, q := range env.capture.GetPendingQueries() {
+		if q.Type == "replay_request" {
+			var params map[string]any
+			_ = json.Unmarshal(q.Params, &params)
+			replays = append(replays, params)
+		}
+	}
+	if len(replays) != 1 {
+		t.Fatalf("replay_request queries = %d, want 1", len(replays))
+	}
+	params := replays[0]
+	headers, _ := params["headers"].(map[string]any)
+	if params["url"] != "https://shop.test/api/checkout" || params["method"] != "POST" || params["body"] != `{"cart":42}` {
+		t.Fatalf("replay params = %v", params)
+	}
+	if len(headers) != 2 || headers["X-Debug"] != "1" || headers["Content-Type"] != "application/json" {
+		t.Fatalf("headers = %v, want X-Trace dropped and X-Debug added", headers)
+	}
+	original, _ := params["original"].(map[string]any)
+	if original["status"] != float64(502) || original["body"] != `{"error":"upstream"}` {
+		t.Fatalf("original = %v", original)
+	}
+}
+
+func TestReplayRequest_AuthorizationOverride(t *testing.T) {
+	t.Parallel()
+	env := newInteractTestEnv(t)
+	env.capture.SetPilotEnabled(true)
+	// Capture strips Authorization, so a bearer-token call is stored without it.
+	env.capture.AddNetworkBodies([]capture.NetworkBody{{
+		Method:         "GET",
+		URL:            "https://shop.test/api/orders",
+		Status:         500,
+		RequestHeaders: map[string]string{"Accept": "application/json"},
+	}})
+	requestID := env.capture.GetNetworkBodies()[0].RequestID
+
+	result, ok := env.callInteract(t, `{"what":"replay_request","request_id":"`+requestID+`"}`)
+	if !ok || result.IsError {
+		t.Fatalf("replay_request failed: %s", firstText(result))
+	}
+	if hint, _ := extractResultJSON(t, result)["hint"].(string); !strings.Contains(hint, "overrides.headers.Authorization") {
+		t.Errorf("hint = %q, want the Authorization override hint", hint)
+	}
+
+	result, ok = env.callInteract(t, `{"what":"replay_request","request_id":"`+requestID+`","overrides":{"headers":{"authorization":"Bearer t0k3n"}}}`)
+	if !ok || result.IsError {
+		t.Fatalf("replay_request with Authorization failed: %s", firstText(result))
+	}
+	if _, exists := extractResultJSON(t, result)["hint"]; exists {
+		t.Errorf("hint should be omitted when Authorization is sent: %s", firstText(result))
+	}
+	var sent []string
+	for _, q := range env.capture.GetPendingQueries() {
+		if q.Type != "replay_request" {
+			continue
+		}
+		var params struct {
+			Headers map[string]string `json:"headers"`
+		}
+		_ = json.Unmarshal(q.Params, &params)
+		sent = append(sent, params.Headers["authorization"])
+	}
+	if len(sent) != 2 || sent[0] != "" || sent[1] != "Bearer t0k3n" {
+		t.Fatalf("Authorization sent to the extension = %q, want only the override", sent)
+	}
+}
+
+func TestReplayRequest_InvalidArgs(t *testing.T) {
+	t.Parallel()
+	env := newInteractTestEnv(t)
+	env.capture.SetPilotEnabled(true)
+	env.capture.AddNetworkBodies([]capture.NetworkBody{{
+		Method:      "POST",
+		URL:         "https://shop.test/api/login",
+		Status:      401,
+		RequestBody: "[REDACTED: auth endpoint]",
+	}})
+	requestID := env.capture.GetNetworkBodies()[0].RequestID
+
+	for _, tc := range []struct{ args, want string }{
+		{`{"what":"replay_request"}`, "request_id"},
+		{`{"what":"replay_request","request_id":"req-999"}`, "No captured request"},
+		{`{"what":"replay_request","request_id":"` + requestID + `"}`, "overrides.body"},
+	} {
+		result, ok := env.callInteract(t, tc.args)
+		if !ok || !result.IsError || !strings.Contains(firstText(result), tc.want) {
+			t.Errorf("%s: expected error containing %q, got %s", tc.args, tc.want, firstText(result))
+		}
+	}
+
+	result, ok := env.callInteract(t, `{"what":"replay_request","request_id":"`+requestID+`","overrides":{"body":"{\"user\":\"qa\"}"}}`)
+	if !ok || result.IsError {
+		t.Fatalf("replay with body override failed: %s", firstText(result))
+	}
+}
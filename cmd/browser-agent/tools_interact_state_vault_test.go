@@ -0,0 +1,170 @@
+// Purpose: Tests for save_state/load_state vault=true and configure(what="list_states").
+// Docs: docs/features/feature/auth-state-vault/index.md
+
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/persistence"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/statevault"
+)
+
+// useTempSessionStore gives the handler a session store of its own, so parallel tests
+// do not see each other's vault entries or recipes.
+func useTempSessionStore(t *testing.T, env *interactHelpersTestEnv) *persistence.SessionStore {
+	t.Helper()
+	store, err := persistence.NewSessionStore(t.TempDir())
+	if err != nil {
+		t.Skipf("session store unavailable in this test environment: %v", err)
+	}
+	t.Cleanup(store.Shutdown)
+	env.handler.sessionStoreImpl = store
+	return store
+}
+
+func newVaultTestEnv(t *testing.T) *interactHelpersTestEnv {
+	t.Helper()
+	env := newInteractHelpersTestEnv(t)
+	env.enablePilot(t)
+	env.capture.SetTrackingStatusForTest(42, "https://app.test/dashboard")
+	store := useTempSessionStore(t, env)
+	vault, err := statevault.New("a-long-test-secret-phrase", store)
+	if err != nil {
+		t.Fatalf("statevault.New: %v", err)
+	}
+	env.handler.stateVault = vault
+	return env
+}
+
+var capturedAuthState = map[string]any{
+	"url":    "https://app.test/dashboard",
+	"origin": "https://app.test",
+	"cookies": []map[string]any{
+		{"name": "sid", "value": "s3cret-session", "domain": "app.test", "path": "/", "http_only": true, "host_only": true},
+	},
+	"local_storage":   map[string]any{"access_token": "eyJ.secret-token"},
+	"session_storage": map[string]any{},
+}
+
+func TestVaultState_SaveSealsAndLoadRestores(t *testing.T) {
+	t.Parallel()
+	env := newVaultTestEnv(t)
+	req := JSONRPCRequest{JSONRPC: "2.0", ID: json.RawMessage(`1`), ClientID: "test-client"}
+
+	wait := startToolCall(t, func() JSONRPCResponse {
+		return env.handler.stateInteract().HandleStateSave(req, json.RawMessage(`{"snapshot_name":"admin","vault":true,"ttl":"2h"}`))
+	})
+	if params := answerPendingQuery(t, env.capture, "auth_state", capturedAuthState); params["action"] != "capture" {
+		t.Fatalf("capture params = %v", params)
+	}
+	resp := wait()
+	data := extractResponseData(t, resp)
+	if data["status"] != "saved" || data["vault"] != true {
+		t.Fatalf("save response = %v", data)
+	}
+	text := string(resp.Result)
+	if strings.Contains(text, "s3cret-session") || strings.Contains(text, "eyJ.secret-token") {
+		t.Fatalf("save response leaks secrets: %s", text)
+	}
+	entry, _ := data["state"].(map[string]any)
+	if entry["origin"] != "https://app.test" || entry["cookies"] != 1.0 || entry["local_storage"] != 1.0 {
+		t.Fatalf("state entry = %v", entry)
+	}
+
+	raw, _ := env.handler.sessionStoreImpl.List(statevault.Namespace)
+	if len(raw) != 1 {
+		t.Fatalf("vault keys = %v, want one", raw)
+	}
+	sealed, _ := env.handler.sessionStoreImpl.Load(statevault.Namespace, raw[0])
+	if strings.Contains(string(sealed), "s3cret-session") {
+		t.Fatal("vault file contains plaintext cookie value")
+	}
+
+	wait = startToolCall(t, func() JSONRPCResponse {
+		return env.handler.stateInteract().HandleStateLoad(req, json.RawMessage(`{"snapshot_name":"admin","vault":true}`))
+	})
+	params := answerPendingQuery(t, env.capture, "auth_state", map[string]any{"restored": map[string]any{"cookies": 1, "local_storage": 1}})
+	data = extractResponseData(t, wait())
+	if data["status"] != "loaded" {
+		t.Fatalf("load response = %v", data)
+	}
+	if params["action"] != "restore" || params["origin"] != "https://app.test" {
+		t.Fatalf("restore params = %v", params)
+	}
+	cookies, _ := params["cookies"].([]any)
+	if len(cookies) != 1 || cookies[0].(map[string]any)["value"] != "s3cret-session" {
+		t.Fatalf("restore cookies = %v", params["cookies"])
+	}
+}
+
+func TestVaultState_SaveScrubsCommandResult(t *testing.T) {
+	t.Parallel()
+	env := newVaultTestEnv(t)
+	req := JSONRPCRequest{JSONRPC: "2.0", ID: json.RawMessage(`1`), ClientID: "test-client"}
+
+	wait := startToolCall(t, func() JSONRPCResponse {
+		return env.handler.stateInteract().HandleStateSave(req, json.RawMessage(`{"snapshot_name":"admin","vault":true}`))
+	})
+	correlationID := awaitPendingQuery(t, env.capture, "auth_state").CorrelationID
+	answerPendingQuery(t, env.capture, "auth_state", capturedAuthState)
+	extractResponseData(t, wait())
+
+	cmd, found := env.capture.GetCommandResult(correlationID)
+	if !found {
+		t.Fatalf("command %q not found", correlationID)
+	}
+	if strings.Contains(string(cmd.Result), "s3cret-session") {
+		t.Fatalf("command result still holds captured secrets: %s", cmd.Result)
+	}
+}
+
+func TestVaultState_NotConfigured(t *testing.T) {
+	t.Parallel()
+	env := newInteractHelpersTestEnv(t)
+	requireSessionStore(t, env)
+	env.enablePilot(t)
+
+	req := JSONRPCRequest{JSONRPC: "2.0", ID: json.RawMessage(`1`), ClientID: "test-client"}
+	resp := env.handler.stateInteract().HandleStateSave(req, json.RawMessage(`{"snapshot_name":"admin","vault":true}`))
+	if code := extractErrorCode(t, resp); code != ErrNotInitialized {
+		t.Fatalf("error code = %q, want %q", code, ErrNotInitialized)
+	}
+	if len(env.capture.GetPendingQueries()) != 0 {
+		t.Fatal("no auth_state command should be queued without a vault")
+	}
+}
+
+func TestConfigureListStates_ReportsExpiry(t *testing.T) {
+	t.Parallel()
+	env := newVaultTestEnv(t)
+	state := statevault.State{Cookies: []statevault.Cookie{{Name: "sid", Value: "v"}}}
+	if _, err := env.handler.stateVault.Save("admin", "https://app.test", "https://app.test/", state, time.Hour); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	if _, err := env.handler.stateVault.Save("old", "https://other.test", "https://other.test/", state, time.Millisecond); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	time.Sleep(5 * time.Millisecond)
+	req := JSONRPCRequest{JSONRPC: "2.0", ID: json.RawMessage(`1`), ClientID: "test-client"}
+
+	data := extractResponseData(t, env.handler.toolConfigureListStates(req, json.RawMessage(`{}`)))
+	if data["count"] != 2.0 || data["expired"] != 1.0 || data["vault_configured"] != true {
+		t.Fatalf("list_states = %v", data)
+	}
+
+	data = extractResponseData(t, env.handler.toolConfigureListStates(req, json.RawMessage(`{"origin":"https://APP.test/login"}`)))
+	states, _ := data["states"].([]any)
+	if len(states) != 1 || states[0].(map[string]any)["name"] != "admin" {
+		t.Fatalf("filtered states = %v", data["states"])
+	}
+
+	env.handler.stateVault = nil
+	data = extractResponseData(t, env.handler.toolConfigureListStates(req, json.RawMessage(`{}`)))
+	if data["count"] != 2.0 || data["note"] == nil {
+		t.Fatalf("list_states without key = %v", data)
+	}
+}
@@ -10,8 +10,19 @@ import (
 	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/cmd/browser-agent/internal/toolobserve"
 )
 
-// observeAliasParams references the shared default mode/action aliases.
-var observeAliasParams = defaultModeActionAliases
+// observeAliasParams defines the deprecated alias parameters for the observe tool.
+// "mode" only conflicts with 'what' when its value is itself an observe mode, because
+// some modes use it as a sub-mode selector (e.g. vitals mode="trend").
+var observeAliasParams = []modeAlias{
+	{JSONField: "mode", ConflictFn: isObserveMode, DeprecatedIn: "0.7.0", RemoveIn: "0.9.0"},
+	{JSONField: "action", DeprecatedIn: "0.7.0", RemoveIn: "0.9.0"},
+}
+
+// isObserveMode reports whether v names a registered observe mode.
+func isObserveMode(v string) bool {
+	_, ok := observeHandlers[v]
+	return ok
+}
 
 // observeRegistry is the tool registry for observe dispatch.
 var observeRegistry = toolRegistry{
@@ -39,8 +50,34 @@ var observeRegistry = toolRegistry{
 }
 
 // toolObserve dispatches observe requests based on the 'what' parameter.
+// wait_for_new=true blocks until matching data arrives before running the mode;
+// compact=true re-encodes the result's object arrays as header-row tables.
 func (h *ToolHandler) toolObserve(req JSONRPCRequest, args json.RawMessage) JSONRPCResponse {
 	reg := observeRegistry
 	reg.Resolution.ValidModes = getValidObserveModes()
-	return h.dispatchTool(req, args, reg)
+	var params struct {
+		observeWaitParams
+		Compact bool `json:"compact"`
+	}
+	lenientUnmarshal(args, &params)
+
+	// Unresolvable modes skip the wait so dispatchTool reports them as usual.
+	var wait map[string]any
+	if params.WaitForNew {
+		if what, _, errResp := resolveToolMode(req, args, reg.AliasDefs, reg.Resolution); errResp == nil && isObserveMode(what) {
+			var waitErr *JSONRPCResponse
+			if wait, waitErr = h.waitForObserveData(req, what, args, params.observeWaitParams); waitErr != nil {
+				return *waitErr
+			}
+		}
+	}
+
+	resp := h.dispatchTool(req, args, reg)
+	if wait != nil {
+		resp = attachObserveWait(resp, wait)
+	}
+	if params.Compact {
+		resp = toolobserve.CompactResponse(resp)
+	}
+	return resp
 }
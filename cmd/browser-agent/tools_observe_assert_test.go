@@ -0,0 +1,123 @@
+// Purpose: Tests observe(what="assert") across page, console, and network conditions.
+// Docs: docs/features/feature/assert/index.md
+
+package main
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+)
+
+func assertResults(t *testing.T, data map[string]any) []map[string]any {
+	t.Helper()
+	raw, _ := data["results"].([]any)
+	results := make([]map[string]any, len(raw))
+	for i, r := range raw {
+		results[i] = r.(map[string]any)
+	}
+	return results
+}
+
+func TestObserveAssert_JudgesAllConditionsAgainstOneSnapshot(t *testing.T) {
+	t.Parallel()
+	h, server, cap := makeToolHandler(t)
+	cap.SetTrackingStatusForTest(9, "https://shop.test/orders/42")
+	now := time.Now()
+	ts := func(offset time.Duration) string { return now.Add(offset).UTC().Format(time.RFC3339Nano) }
+
+	cap.AddEnhancedActions([]capture.EnhancedAction{{Type: "click", Timestamp: now.Add(-10 * time.Second).UnixMilli(), TabID: 9}})
+	server.logs.addEntries([]LogEntry{
+		{"level": "error", "message": "stale error before the click", "ts": ts(-60 * time.Second), "tabId": float64(9)},
+		{"level": "error", "message": "other tab", "ts": ts(-5 * time.Second), "tabId": float64(3)},
+	})
+	cap.AddNetworkBodies([]capture.NetworkBody{
+		{Timestamp: ts(-5 * time.Second), Method: "POST", URL: "https://shop.test/api/orders", Status: 422, TabID: 9},
+	})
+
+	wait := startToolCall(t, func() JSONRPCResponse {
+		return h.toolObserve(JSONRPCRequest{JSONRPC: "2.0", ID: 1}, json.RawMessage(`{"what":"assert","conditions":[
+		{"selector":".toast","text_contains":"saved"},
+		{"selector":".spinner","visible":false},
+		{"url_matches":"/orders/\\d+$"},
+		{"title_contains":"Invoice"},
+		{"no_console_errors_since":"last_action"},
+		{"no_network_errors_since":"last_action"}]}`))
+	})
+	params := answerPendingQuery(t, cap, "assert", map[string]any{
+		"url":   "https://shop.test/orders/42",
+		"title": "Order 42",
+		"elements": []map[string]any{
+			{"selector": ".toast", "count": 1, "visible_count": 1, "texts": []string{"Order saved"}, "text_match": "Order saved"},
+			{"selector": ".spinner", "count": 1, "visible_count": 0},
+		},
+	})
+	result := parseToolResult(t, wait())
+	if result.IsError {
+		t.Fatalf("assert failed: %s", firstText(result))
+	}
+	data := extractResultJSON(t, result)
+
+	if checks, _ := params["checks"].([]any); len(checks) != 2 {
+		t.Fatalf("page checks = %v, want the two selector conditions", checks)
+	}
+	if data["passed"] != false || data["passed_count"] != 4.0 || data["failed_count"] != 2.0 || data["title"] != "Order 42" {
+		t.Fatalf("assert = %v", data)
+	}
+	results := assertResults(t, data)
+	want := []bool{true, true, true, false, true, false}
+	for i, r := range results {
+		if r["passed"] != want[i] || r["index"] != float64(i) {
+			t.Errorf("results[%d] = %v, want passed=%v", i, r, want[i])
+		}
+	}
+	network, _ := results[5]["evidence"].(map[string]any)
+	if network["failed"] != 1.0 {
+		t.Fatalf("network evidence = %v", network)
+	}
+}
+
+func TestObserveAssert_BufferConditionsNeedNoPage(t *testing.T) {
+	t.Parallel()
+	h, server, cap := makeToolHandler(t)
+	server.logs.addEntries([]LogEntry{{"level": "error", "message": "TypeError: x is undefined", "ts": time.Now().UTC().Format(time.RFC3339Nano)}})
+
+	result := parseToolResult(t, h.toolObserve(JSONRPCRequest{JSONRPC: "2.0", ID: 1},
+		json.RawMessage(`{"what":"assert","conditions":[{"no_console_errors_since":"1m"}]}`)))
+	if result.IsError {
+		t.Fatalf("assert failed: %s", firstText(result))
+	}
+	data := extractResultJSON(t, result)
+	if data["passed"] != false || data["url"] != nil {
+		t.Fatalf("assert = %v", data)
+	}
+	if len(cap.GetPendingQueries()) != 0 {
+		t.Fatal("buffer-only conditions should not query the page")
+	}
+}
+
+func TestObserveAssert_RejectsInvalidConditions(t *testing.T) {
+	t.Parallel()
+	h, _, cap := makeToolHandler(t)
+	cap.SetTrackingStatusForTest(9, "https://shop.test/")
+
+	for _, conditions := range []string{
+		`[]`,
+		`[{}]`,
+		`[{"selector":"#a","url_matches":"x"}]`,
+		`[{"url_matches":"("}]`,
+		`[{"title_contains":"x","visible":true}]`,
+		`[{"no_console_errors_since":"yesterday"}]`,
+	} {
+		result := parseToolResult(t, h.toolObserve(JSONRPCRequest{JSONRPC: "2.0", ID: 1},
+			json.RawMessage(`{"what":"assert","conditions":`+conditions+`}`)))
+		if !result.IsError {
+			t.Errorf("conditions %s: expected an error, got %s", conditions, firstText(result))
+		}
+	}
+	if len(cap.GetPendingQueries()) != 0 {
+		t.Fatal("invalid conditions should not query the page")
+	}
+}
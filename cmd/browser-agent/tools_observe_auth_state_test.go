@@ -0,0 +1,63 @@
+// Purpose: Tests for observe(what="auth_state") JWT reporting through the tool dispatcher.
+// Docs: docs/features/feature/security-hardening/index.md
+
+package main
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+)
+
+func TestObserveAuthState_ReportsExpiredBearerToken(t *testing.T) {
+	t.Parallel()
+	h, _, cap := makeToolHandler(t)
+
+	payload, _ := json.Marshal(map[string]any{
+		"iss":   "https://auth.test",
+		"email": "dev@example.test",
+		"exp":   time.Now().Add(-time.Hour).Unix(),
+	})
+	token := "eyJhbGciOiJIUzI1NiJ9." + base64.RawURLEncoding.EncodeToString(payload) + ".sig"
+	cap.AddNetworkBodies([]capture.NetworkBody{{
+		Method:         "GET",
+		URL:            "https://api.test/me",
+		Status:         401,
+		RequestHeaders: map[string]string{"Authorization": "Bearer " + token},
+	}})
+
+	resp := callObserveRaw(h, "auth_state")
+	result := parseToolResult(t, resp)
+	if result.IsError {
+		t.Fatalf("auth_state should succeed, got: %s", firstText(result))
+	}
+	text := firstText(result)
+	if strings.Contains(text, token) || strings.Contains(text, "dev@example.test") {
+		t.Fatalf("auth_state leaked raw token or claim value: %s", text)
+	}
+	data := extractResultJSON(t, result)
+	if data["count"] != float64(1) {
+		t.Fatalf("count = %v, want 1", data["count"])
+	}
+	tok := data["tokens"].([]any)[0].(map[string]any)
+	if tok["status"] != "expired" || tok["issuer"] != "https://auth.test" {
+		t.Fatalf("token = %v, want expired token from https://auth.test", tok)
+	}
+}
+
+func TestObserveAuthState_EmptyIncludesHint(t *testing.T) {
+	t.Parallel()
+	h, _, _ := makeToolHandler(t)
+
+	result := parseToolResult(t, callObserveRaw(h, "auth_state"))
+	if result.IsError {
+		t.Fatalf("auth_state should succeed, got: %s", firstText(result))
+	}
+	if data := extractResultJSON(t, result); data["hint"] == nil {
+		t.Fatalf("empty auth_state should include a hint: %v", data)
+	}
+}
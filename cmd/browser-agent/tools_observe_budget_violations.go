@@ -0,0 +1,41 @@
+// Purpose: Implements observe(what="budget_violations") over the network budget monitor.
+// Why: Alerts announce the first overrun; this view shows every budget, offending URL, and worst value in one read.
+// Docs: docs/features/feature/network-budgets/index.md
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/netbudget"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/tools/observe"
+)
+
+// toolObserveBudgetViolations returns network budgets and the responses that exceeded them, most recent first.
+func (h *ToolHandler) toolObserveBudgetViolations(req JSONRPCRequest, args json.RawMessage) JSONRPCResponse {
+	var params struct {
+		URL   string `json:"url"`
+		Limit int    `json:"limit"`
+	}
+	lenientUnmarshal(args, &params)
+
+	monitor := h.budgetMonitor
+	if monitor == nil {
+		monitor = netbudget.NewMonitor()
+	}
+	summary := monitor.Summary(params.URL, params.Limit)
+	resp := map[string]any{
+		"status":           summary.Status,
+		"budgets":          summary.Budgets,
+		"total_violations": summary.TotalViolations,
+		"by_metric":        summary.ByMetric,
+		"violations":       summary.Violations,
+		"metadata":         observe.BuildResponseMetadata(h.capture, time.Now()),
+	}
+	if summary.Status == "no_budgets" {
+		resp["hint"] = `No network budgets. Add one with configure(what="network_budget", pattern="/api/", max_duration_ms=500)`
+	}
+	return succeed(req, fmt.Sprintf("Network budgets: %d violation(s)", summary.TotalViolations), resp)
+}
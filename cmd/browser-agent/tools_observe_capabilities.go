@@ -0,0 +1,204 @@
+// Purpose: Serves observe(what="capabilities"), a live manifest of which subsystems are active and their versions.
+// Why: Agents should pick a strategy from what is available (extension, pilot, CDP, storage, protocol, audits) instead of learning it from errors.
+// Docs: docs/features/feature/capabilities-manifest/index.md
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"runtime"
+	"sort"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/mcp"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/tools/observe"
+)
+
+// Where an audit gets its input: captured telemetry already on the server, or a query run in the page.
+const (
+	auditSourceCaptured = "captured"
+	auditSourcePage     = "page"
+)
+
+// capabilityAudit describes one audit listed in the capabilities manifest.
+type capabilityAudit struct {
+	tool   string
+	what   string
+	source string
+	// pageNote explains what is skipped without the extension for captured audits that also read the page.
+	pageNote string
+}
+
+// capabilityAudits lists the audits agents can run, in display order.
+var capabilityAudits = []capabilityAudit{
+	{tool: "analyze", what: "security_audit", source: auditSourceCaptured},
+	{tool: "analyze", what: "third_party_audit", source: auditSourceCaptured},
+	{tool: "observe", what: "privacy_audit", source: auditSourceCaptured},
+	{tool: "observe", what: "transport_security", source: auditSourceCaptured},
+	{tool: "observe", what: "cookie_audit", source: auditSourceCaptured, pageNote: "document.cookie and web storage are skipped without the extension"},
+	{tool: "observe", what: "vitals", source: auditSourceCaptured},
+	{tool: "analyze", what: "accessibility", source: auditSourcePage},
+	{tool: "analyze", what: "audit", source: auditSourcePage},
+	{tool: "observe", what: "component_audit", source: auditSourcePage},
+	{tool: "observe", what: "seo", source: auditSourcePage},
+	{tool: "observe", what: "coverage", source: auditSourcePage},
+}
+
+// toolObserveCapabilities reports which subsystems are active right now.
+func (h *ToolHandler) toolObserveCapabilities(req JSONRPCRequest, _ json.RawMessage) JSONRPCResponse {
+	extension := h.capabilityExtension()
+	connected, _ := extension["connected"].(bool)
+	pilot := h.capabilityPilot()
+	pilotEnabled, _ := pilot["enabled"].(bool)
+	cdp := capabilityCDP(connected, pilotEnabled, h.cdpPolicy)
+	storage := h.capabilityStorage()
+	audits := capabilityAuditList(connected)
+
+	subsystems := map[string]bool{
+		"extension": connected,
+		"pilot":     connected && pilotEnabled,
+		"cdp":       cdp["available"] == true,
+		"storage":   storage["persistent"] == true,
+		"read_only": h.readOnly != nil,
+	}
+	active := make([]string, 0, len(subsystems))
+	for name, on := range subsystems {
+		if on {
+			active = append(active, name)
+		}
+	}
+	sort.Strings(active)
+
+	return succeed(req, fmt.Sprintf("Capabilities: %d/%d subsystems active", len(active), len(subsystems)), map[string]any{
+		"server": map[string]any{
+			"version":   version,
+			"platform":  runtime.GOOS + "/" + runtime.GOARCH,
+			"read_only": h.readOnly != nil,
+		},
+		"protocol":   h.capabilityProtocol(req),
+		"extension":  extension,
+		"pilot":      pilot,
+		"cdp":        cdp,
+		"storage":    storage,
+		"audits":     audits,
+		"subsystems": subsystems,
+		"active":     active,
+		"hints":      capabilityHints(connected, pilotEnabled, storage["persistent"] == true, h.readOnly != nil),
+		"metadata":   observe.BuildResponseMetadata(h.capture, time.Now()),
+	})
+}
+
+// capabilityProtocol reports the MCP protocol version negotiated with the caller.
+func (h *ToolHandler) capabilityProtocol(req JSONRPCRequest) map[string]any {
+	negotiated := mcp.LatestProtocolVersion
+	if h.MCPHandler != nil {
+		negotiated = h.protocolVersionFor(req)
+	}
+	return map[string]any{
+		"negotiated": negotiated,
+		"latest":     mcp.LatestProtocolVersion,
+		"supported":  mcp.SupportedProtocolVersions,
+	}
+}
+
+// capabilityExtension reports the extension connection, its version, and the command transport.
+func (h *ToolHandler) capabilityExtension() map[string]any {
+	if h.capture == nil {
+		return map[string]any{"connected": false, "transport": capture.ExtensionTransportNone}
+	}
+	extVersion, _, mismatch := h.capture.GetVersionMismatch()
+	cspRestricted, cspLevel := h.capture.GetCSPStatus()
+	status := h.capture.GetExtensionStatus()
+	out := map[string]any{
+		"connected":        h.capture.IsExtensionConnected(),
+		"transport":        h.capture.ExtensionTransport(),
+		"version":          extVersion,
+		"version_mismatch": mismatch,
+		"last_seen":        status["last_seen"],
+		"csp_restricted":   cspRestricted,
+	}
+	if cspLevel != "" {
+		out["csp_level"] = cspLevel
+	}
+	return out
+}
+
+// capabilityPilot reports whether AI Web Pilot (interact) actions are allowed.
+func (h *ToolHandler) capabilityPilot() map[string]any {
+	info := buildPilotInfo(h.capture)
+	return map[string]any{
+		"enabled": info.Enabled,
+		"source":  info.Source,
+	}
+}
+
+// capabilityCDP reports the Chrome DevTools Protocol driver, which runs through the
+// extension's chrome.debugger attachment and is pilot-gated like other interact actions.
+// passthrough reports the operator policy for raw interact(what="cdp") commands.
+func capabilityCDP(extensionConnected, pilotEnabled bool, policy CDPPassthroughPolicy) map[string]any {
+	out := map[string]any{
+		"available":   extensionConnected && pilotEnabled,
+		"driver":      "extension_debugger",
+		"passthrough": policy,
+	}
+	switch {
+	case !extensionConnected:
+		out["reason"] = "extension not connected"
+	case !pilotEnabled:
+		out["reason"] = "AI Web Pilot is disabled"
+	}
+	return out
+}
+
+// capabilityStorage reports where session data (store/load, histories, findings) is kept.
+func (h *ToolHandler) capabilityStorage() map[string]any {
+	if h.sessionStoreImpl == nil {
+		return map[string]any{"backend": "memory", "persistent": false}
+	}
+	meta := h.sessionStoreImpl.GetMeta()
+	return map[string]any{
+		"backend":      "filesystem",
+		"persistent":   true,
+		"project_path": meta.ProjectPath,
+	}
+}
+
+// capabilityAuditList reports each audit with whether it can run now and whether
+// its findings feed the finding lifecycle.
+func capabilityAuditList(extensionConnected bool) []map[string]any {
+	out := make([]map[string]any, 0, len(capabilityAudits))
+	for _, a := range capabilityAudits {
+		_, tracked := findingAudits[a.tool+":"+a.what]
+		entry := map[string]any{
+			"name":      a.what,
+			"call":      fmt.Sprintf("%s(what=%q)", a.tool, a.what),
+			"source":    a.source,
+			"available": a.source == auditSourceCaptured || extensionConnected,
+			"tracked":   tracked,
+		}
+		if a.pageNote != "" && !extensionConnected {
+			entry["note"] = a.pageNote
+		}
+		out = append(out, entry)
+	}
+	return out
+}
+
+// capabilityHints suggests how to adapt when a subsystem is missing.
+func capabilityHints(extensionConnected, pilotEnabled, persistent, readOnly bool) []string {
+	var hints []string
+	if readOnly {
+		hints = append(hints, "Read-only session replay: observe and analyze read the archived bundle; interact and mutating configure calls are rejected.")
+	}
+	if !extensionConnected {
+		hints = append(hints, "Extension not connected: rely on captured data (observe errors/logs/network_waterfall) and captured-source audits; page audits, screenshots, and interact need the extension.")
+	} else if !pilotEnabled {
+		hints = append(hints, "AI Web Pilot is disabled: interact actions and CDP clicks will be rejected. Ask the user to enable it in the extension popup.")
+	}
+	if !persistent {
+		hints = append(hints, "No persistent storage: configure(what=\"store\") data, error cluster history, and finding state last only for this server process.")
+	}
+	return hints
+}
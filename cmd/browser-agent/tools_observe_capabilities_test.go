@@ -0,0 +1,94 @@
+// Purpose: Tests observe(what="capabilities") across disconnected, connected, and pilot-disabled states.
+// Docs: docs/features/feature/capabilities-manifest/index.md
+
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/mcp"
+)
+
+func observeCapabilities(t *testing.T, h *ToolHandler) map[string]any {
+	t.Helper()
+	req := JSONRPCRequest{JSONRPC: "2.0", ID: 1}
+	result := parseToolResult(t, h.toolObserve(req, json.RawMessage(`{"what":"capabilities"}`)))
+	if result.IsError {
+		t.Fatalf("capabilities should succeed, got: %s", firstText(result))
+	}
+	return extractResultJSON(t, result)
+}
+
+func capabilityAuditByName(t *testing.T, data map[string]any, name string) map[string]any {
+	t.Helper()
+	for _, a := range data["audits"].([]any) {
+		if audit := a.(map[string]any); audit["name"] == name {
+			return audit
+		}
+	}
+	t.Fatalf("audit %q missing from %v", name, data["audits"])
+	return nil
+}
+
+func TestObserveCapabilities_ExtensionDisconnected(t *testing.T) {
+	t.Parallel()
+	h, _, _ := makeToolHandler(t)
+	data := observeCapabilities(t, h)
+
+	if server := data["server"].(map[string]any); server["version"] != version {
+		t.Fatalf("server = %v", server)
+	}
+	if protocol := data["protocol"].(map[string]any); protocol["negotiated"] != mcp.LatestProtocolVersion {
+		t.Fatalf("protocol = %v", protocol)
+	}
+	ext := data["extension"].(map[string]any)
+	if ext["connected"] != false || ext["transport"] != "none" {
+		t.Fatalf("extension = %v", ext)
+	}
+	if cdp := data["cdp"].(map[string]any); cdp["available"] != false || cdp["reason"] != "extension not connected" {
+		t.Fatalf("cdp = %v", cdp)
+	}
+	if a := capabilityAuditByName(t, data, "accessibility"); a["available"] != false || a["tracked"] != true {
+		t.Fatalf("accessibility = %v", a)
+	}
+	if a := capabilityAuditByName(t, data, "security_audit"); a["available"] != true {
+		t.Fatalf("security_audit runs on captured data: %v", a)
+	}
+	if a := capabilityAuditByName(t, data, "cookie_audit"); a["note"] == nil {
+		t.Fatalf("cookie_audit should note skipped page storage: %v", a)
+	}
+	hints, _ := data["hints"].([]any)
+	if len(hints) == 0 || !strings.Contains(hints[0].(string), "Extension not connected") {
+		t.Fatalf("hints = %v", hints)
+	}
+}
+
+func TestObserveCapabilities_ConnectedWithPilot(t *testing.T) {
+	t.Parallel()
+	h, _, cap := makeToolHandler(t)
+	cap.SimulateExtensionConnectForTest()
+	cap.SetPilotEnabled(true)
+
+	data := observeCapabilities(t, h)
+	if ext := data["extension"].(map[string]any); ext["connected"] != true || ext["transport"] != "polling" {
+		t.Fatalf("extension = %v", ext)
+	}
+	if cdp := data["cdp"].(map[string]any); cdp["available"] != true || cdp["driver"] != "extension_debugger" {
+		t.Fatalf("cdp = %v", cdp)
+	}
+	if a := capabilityAuditByName(t, data, "accessibility"); a["available"] != true {
+		t.Fatalf("accessibility = %v", a)
+	}
+	subsystems := data["subsystems"].(map[string]any)
+	if subsystems["extension"] != true || subsystems["pilot"] != true || subsystems["cdp"] != true {
+		t.Fatalf("subsystems = %v", subsystems)
+	}
+
+	cap.SetPilotEnabled(false)
+	data = observeCapabilities(t, h)
+	if cdp := data["cdp"].(map[string]any); cdp["available"] != false || cdp["reason"] != "AI Web Pilot is disabled" {
+		t.Fatalf("cdp with pilot off = %v", cdp)
+	}
+}
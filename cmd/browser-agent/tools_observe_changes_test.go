@@ -0,0 +1,60 @@
+// Purpose: Tests for observe(what="changes") sequencing console, network, ws, action, and alert appends.
+// Docs: docs/features/feature/cursor-pagination/index.md
+
+package main
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/types"
+)
+
+func TestObserveChanges_SequencesEveryBufferAndResumes(t *testing.T) {
+	t.Parallel()
+	h, server, cap := makeToolHandler(t)
+
+	server.logs.addEntries([]LogEntry{{"level": "error", "message": "boom"}})
+	cap.AddNetworkBodies([]capture.NetworkBody{{Method: "POST", URL: "https://api.test/cart", Status: 500}})
+	cap.AddWebSocketEvents([]capture.WebSocketEvent{{Event: "open", ID: "ws-1", URL: "wss://api.test/live"}})
+	cap.AddEnhancedActions([]capture.EnhancedAction{{Type: "click", URL: "https://app.test/"}})
+	h.alertBuffer.AddAlert(types.Alert{Severity: "error", Category: "threshold", Title: "Error spike", Source: "test"})
+
+	req := JSONRPCRequest{JSONRPC: "2.0", ID: 1}
+	result := parseToolResult(t, h.toolObserve(req, json.RawMessage(`{"what":"changes","limit":3}`)))
+	if result.IsError {
+		t.Fatalf("changes should succeed, got: %s", firstText(result))
+	}
+	data := extractResultJSON(t, result)
+	changes := data["changes"].([]any)
+	wantTypes := []string{"console", "network", "ws"}
+	for i, c := range changes {
+		if got := c.(map[string]any)["type"]; got != wantTypes[i] {
+			t.Fatalf("change %d type = %v, want %s", i, got, wantTypes[i])
+		}
+	}
+	if data["has_more"] != true || data["next_seq"] != float64(3) || data["latest_seq"] != float64(5) {
+		t.Fatalf("page cursor = %v", data)
+	}
+
+	args, _ := json.Marshal(map[string]any{"what": "changes", "after_seq": 3, "feed_id": data["feed_id"]})
+	data = extractResultJSON(t, parseToolResult(t, h.toolObserve(req, args)))
+	changes = data["changes"].([]any)
+	if len(changes) != 2 || changes[0].(map[string]any)["type"] != "action" || changes[1].(map[string]any)["type"] != "alert" {
+		t.Fatalf("resumed changes = %v", changes)
+	}
+	if data["gap"] != nil || data["reset"] != nil {
+		t.Fatalf("contiguous resume should have no gap/reset: %v", data)
+	}
+}
+
+func TestObserveChanges_RejectsUnknownType(t *testing.T) {
+	t.Parallel()
+	h, _, _ := makeToolHandler(t)
+	req := JSONRPCRequest{JSONRPC: "2.0", ID: 1}
+	result := parseToolResult(t, h.toolObserve(req, json.RawMessage(`{"what":"changes","types":["dom"]}`)))
+	if !result.IsError {
+		t.Fatalf("unknown change type should fail, got: %s", firstText(result))
+	}
+}
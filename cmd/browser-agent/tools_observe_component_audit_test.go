@@ -0,0 +1,127 @@
+// Purpose: Tests observe(what="component_audit") driving a fake extension through story enumeration and per-story checks.
+// Docs: docs/features/feature/component-audit/index.md
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/state"
+)
+
+// fakeStorybookExtension answers pending queries the way the extension would for a
+// Storybook with two stories; the "card" story logs a console error when rendered.
+func fakeStorybookExtension(ctx context.Context, server *Server, cap *capture.Store, navigations *[]string) {
+	index := `{"v":4,"entries":{"button--primary":{"id":"button--primary","title":"Button","name":"Primary","type":"story"},"card--default":{"id":"card--default","title":"Card","name":"Default","type":"story"},"intro--docs":{"id":"intro--docs","type":"docs"}}}`
+	current := ""
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(5 * time.Millisecond):
+		}
+		for _, q := range cap.GetPendingQueries() {
+			var params map[string]any
+			_ = json.Unmarshal(q.Params, &params)
+			var result any
+			switch q.Type {
+			case "execute":
+				result = map[string]any{"success": true, "result": map[string]any{"path": "index.json", "body": index}}
+			case "browser_action":
+				current, _ = params["url"].(string)
+				*navigations = append(*navigations, current)
+				if strings.Contains(current, "card--default") {
+					server.logs.addEntries([]LogEntry{{"level": "error", "message": "TypeError: props.items is undefined\n    at Card"}})
+				}
+				result = map[string]any{"success": true}
+			case "a11y":
+				violations := []any{}
+				if strings.Contains(current, "button--primary") {
+					violations = append(violations, map[string]any{"id": "button-name", "impact": "critical", "nodes": []any{map[string]any{}}})
+				}
+				result = map[string]any{"violations": violations}
+			case "screenshot":
+				result = map[string]any{"data_url": "data:image/png;base64," + current}
+			default:
+				continue
+			}
+			raw, _ := json.Marshal(result)
+			cap.SetQueryResult(q.ID, raw)
+		}
+	}
+}
+
+func TestObserveComponentAudit_AuditsEachStory(t *testing.T) {
+	t.Setenv(state.StateDirEnv, t.TempDir())
+	h, server, cap := makeToolHandler(t)
+	cap.SetPilotEnabled(true)
+	cap.SetTrackingStatusForTest(7, "http://localhost:6006/?path=/docs/intro--docs")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var navigations []string
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		fakeStorybookExtension(ctx, server, cap, &navigations)
+	}()
+	t.Cleanup(func() { cancel(); <-done })
+
+	req := JSONRPCRequest{JSONRPC: "2.0", ID: 1}
+	args := json.RawMessage(`{"what":"component_audit","settle_ms":1,"update_baseline":true}`)
+	result := parseToolResult(t, h.toolObserve(req, args))
+	if result.IsError {
+		t.Fatalf("component_audit should succeed, got: %s", firstText(result))
+	}
+	data := extractResultJSON(t, result)
+	if data["harness"] != "storybook" || data["base_url"] != "http://localhost:6006/" || data["baseline_updated"] != true {
+		t.Fatalf("response header = %v", data)
+	}
+	stories := data["stories"].([]any)
+	if len(stories) != 2 {
+		t.Fatalf("expected 2 stories (docs entry skipped), got %d", len(stories))
+	}
+	button, card := stories[0].(map[string]any), stories[1].(map[string]any)
+	if button["story_id"] != "button--primary" || button["status"] != "fail" || button["a11y"].(map[string]any)["violations"] != float64(1) {
+		t.Errorf("button result = %v", button)
+	}
+	if card["status"] != "fail" || card["console_errors"].([]any)[0] != "TypeError: props.items is undefined" {
+		t.Errorf("card result = %v", card)
+	}
+	if v := card["visual"].(map[string]any); v["baseline"] != "new" || v["hash"] == "" {
+		t.Errorf("card visual = %v", v)
+	}
+	summary := data["summary"].(map[string]any)
+	if summary["stories"] != float64(2) || summary["failed"] != float64(2) || summary["a11y_violations"] != float64(1) {
+		t.Errorf("summary = %v", summary)
+	}
+
+	// Second run compares against the stored baseline and restores the tab each time.
+	args = json.RawMessage(`{"what":"component_audit","settle_ms":1,"story_ids":["button--primary"],"checks":["visual"]}`)
+	data = extractResultJSON(t, parseToolResult(t, h.toolObserve(req, args)))
+	story := data["stories"].([]any)[0].(map[string]any)
+	if story["status"] != "pass" || story["visual"].(map[string]any)["baseline"] != "unchanged" {
+		t.Errorf("baseline rerun = %v", story)
+	}
+	cancel()
+	<-done
+	if last := navigations[len(navigations)-1]; last != "http://localhost:6006/?path=/docs/intro--docs" {
+		t.Errorf("tab should be restored, last navigation = %s", last)
+	}
+}
+
+func TestObserveComponentAudit_RequiresPilot(t *testing.T) {
+	t.Parallel()
+	h, _, cap := makeToolHandler(t)
+	cap.SetTrackingStatusForTest(7, "http://localhost:6006/")
+
+	req := JSONRPCRequest{JSONRPC: "2.0", ID: 1}
+	result := parseToolResult(t, h.toolObserve(req, json.RawMessage(`{"what":"component_audit"}`)))
+	if !result.IsError || !strings.Contains(firstText(result), "pilot_disabled") {
+		t.Fatalf("expected pilot_disabled, got: %s", firstText(result))
+	}
+}
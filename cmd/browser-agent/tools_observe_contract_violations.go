@@ -0,0 +1,41 @@
+// Purpose: Implements observe(what="contract_violations") over the locked API contract monitor.
+// Why: Alerts announce the first deviation; this view shows every contract, violation, and repeat count in one read.
+// Docs: docs/features/feature/api-schema/index.md
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/analysis"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/tools/observe"
+)
+
+// toolObserveContractViolations returns locked contracts and the violations seen against them, most recent first.
+func (h *ToolHandler) toolObserveContractViolations(req JSONRPCRequest, args json.RawMessage) JSONRPCResponse {
+	var params struct {
+		URL   string `json:"url"`
+		Limit int    `json:"limit"`
+	}
+	lenientUnmarshal(args, &params)
+
+	monitor := h.apiContractMonitor
+	if monitor == nil {
+		monitor = analysis.NewAPIContractMonitor("")
+	}
+	summary := monitor.Summary(params.URL, params.Limit)
+	resp := map[string]any{
+		"status":           summary.Status,
+		"contracts":        summary.Contracts,
+		"total_violations": summary.TotalViolations,
+		"by_kind":          summary.ByKind,
+		"violations":       summary.Violations,
+		"metadata":         observe.BuildResponseMetadata(h.capture, time.Now()),
+	}
+	if summary.Status == "no_contracts" {
+		resp["hint"] = `No locked contracts. Lock one with configure(what="lock_api_contract", endpoint="GET /api/...")`
+	}
+	return succeed(req, fmt.Sprintf("API contracts: %d violation(s)", summary.TotalViolations), resp)
+}
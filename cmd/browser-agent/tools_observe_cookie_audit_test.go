@@ -0,0 +1,33 @@
+// Purpose: Tests for observe(what="cookie_audit") dispatch and graceful degradation without a tracked tab.
+// Docs: docs/features/feature/security-hardening/index.md
+
+package main
+
+import (
+	"testing"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+)
+
+func TestObserveCookieAudit_FlagsSetCookieWithoutStorage(t *testing.T) {
+	t.Parallel()
+	h, _, cap := makeToolHandler(t)
+	cap.AddNetworkBodies([]capture.NetworkBody{{
+		Method:          "POST",
+		URL:             "https://app.test/login",
+		Status:          200,
+		ResponseHeaders: map[string]string{"Set-Cookie": "sid=secret-sid-value; Path=/"},
+	}})
+
+	result := parseToolResult(t, callObserveRaw(h, "cookie_audit"))
+	if result.IsError {
+		t.Fatalf("cookie_audit should succeed, got: %s", firstText(result))
+	}
+	data := extractResultJSON(t, result)
+	if findings, ok := data["findings"].([]any); !ok || len(findings) == 0 {
+		t.Fatalf("findings = %v, want at least one", data["findings"])
+	}
+	if data["storage_included"] != false || data["storage_note"] == nil {
+		t.Fatalf("storage should be skipped with a note when no tab is tracked: %v", data)
+	}
+}
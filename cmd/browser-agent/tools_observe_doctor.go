@@ -0,0 +1,397 @@
+// Purpose: Implements observe(what="doctor"), a server-side decision tree that ranks likely causes of missing data.
+// Why: An empty buffer has many explanations (no extension, open circuit, TTL, a clear, noise rules); agents guessed.
+// Docs: docs/features/feature/observe-doctor/index.md
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/noise"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/session"
+)
+
+// doctorBuffers are the buffers observe(what="doctor") can explain, in reporting order.
+var doctorBuffers = []string{"logs", "network", "websocket", "sse", "actions"}
+
+// doctorNoiseBuffers are the buffers noise rules filter.
+var doctorNoiseBuffers = map[string]bool{"logs": true, "network": true, "websocket": true}
+
+// Likelihood weights order causes; ties keep decision-tree order.
+const (
+	doctorHigh   = 3
+	doctorMedium = 2
+	doctorLow    = 1
+)
+
+var doctorLikelihood = map[int]string{doctorHigh: "high", doctorMedium: "medium", doctorLow: "low"}
+
+// bufferClearLog records the last configure(what="clear") per buffer name ("all" included).
+type bufferClearLog struct {
+	mu   sync.Mutex
+	last map[string]time.Time
+}
+
+func (l *bufferClearLog) record(buffer string, at time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.last == nil {
+		l.last = make(map[string]time.Time)
+	}
+	l.last[buffer] = at
+}
+
+// lastClear returns the latest clear that emptied buffer, either directly or via "all".
+func (l *bufferClearLog) lastClear(buffer string) time.Time {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	at := l.last[buffer]
+	if all := l.last["all"]; all.After(at) {
+		at = all
+	}
+	return at
+}
+
+// doctorBufferFacts is what the doctor knows about one buffer.
+type doctorBufferFacts struct {
+	Name       string
+	Count      int
+	TotalAdded int64
+	TTL        time.Duration
+	ClearedAt  time.Time
+}
+
+// doctorNoiseRule is one noise rule and how many entries it has filtered.
+type doctorNoiseRule struct {
+	ID      string
+	Matches int
+}
+
+// doctorFacts is the state the decision tree runs over, gathered once per call.
+type doctorFacts struct {
+	Now                time.Time
+	ReadOnly           *readOnlyState
+	ExtensionConnected bool
+	ExtensionLastSeen  time.Time
+	ExtensionVersion   string
+	ServerVersion      string
+	VersionMismatch    bool
+	CircuitOpen        bool
+	CircuitReason      string
+	CircuitOpenedAt    time.Time
+	Tracking           bool
+	TrackedTabID       int
+	TrackedURL         string
+	MultiTab           bool
+	Buffers            []doctorBufferFacts
+	NoiseFiltered      int64
+	NoiseTopRules      []doctorNoiseRule
+	ClientID           string
+	ClientRegistered   bool
+}
+
+// doctorCause is one likely reason data is missing, with the calls that fix or confirm it.
+type doctorCause struct {
+	Rank       int      `json:"rank"`
+	ID         string   `json:"id"`
+	Likelihood string   `json:"likelihood"`
+	Buffer     string   `json:"buffer,omitempty"`
+	Summary    string   `json:"summary"`
+	Evidence   string   `json:"evidence,omitempty"`
+	Fix        string   `json:"fix"`
+	Commands   []string `json:"commands,omitempty"`
+	weight     int
+}
+
+// toolObserveDoctor explains why observe results are empty or thin.
+func (h *ToolHandler) toolObserveDoctor(req JSONRPCRequest, args json.RawMessage) JSONRPCResponse {
+	var params struct {
+		Buffer string `json:"buffer"`
+	}
+	lenientUnmarshal(args, &params)
+	buffers := doctorBuffers
+	if params.Buffer != "" && params.Buffer != "all" {
+		if !slices.Contains(doctorBuffers, params.Buffer) {
+			return fail(req, ErrInvalidParam, "Unknown buffer: "+params.Buffer,
+				"Use buffer "+strings.Join(doctorBuffers, ", ")+", or omit it", withParam("buffer"))
+		}
+		buffers = []string{params.Buffer}
+	}
+
+	facts := h.gatherDoctorFacts(req.ClientID, buffers, time.Now())
+	causes := diagnoseMissingData(facts)
+
+	bufferReport := make(map[string]any, len(facts.Buffers))
+	for _, b := range facts.Buffers {
+		entry := map[string]any{"count": b.Count, "total_added": b.TotalAdded, "ttl": formatRetentionTTL(b.TTL)}
+		if !b.ClearedAt.IsZero() {
+			entry["last_cleared"] = b.ClearedAt.UTC().Format(time.RFC3339)
+		}
+		bufferReport[b.Name] = entry
+	}
+
+	status := "causes_found"
+	summary := fmt.Sprintf("Doctor: %d likely cause(s)", len(causes))
+	response := map[string]any{
+		"causes":  causes,
+		"buffers": bufferReport,
+	}
+	if len(causes) == 0 {
+		status = "no_causes_found"
+		summary = "Doctor: capture looks healthy"
+		response["hint"] = "Capture looks healthy. If a call still returns nothing, loosen its filters (url, tab_id, boundary, min_level) " +
+			"or check the call itself with observe(what=\"server_debug\", errors_only=true, mine=true)."
+	}
+	response["status"] = status
+	return succeed(req, summary, response)
+}
+
+// gatherDoctorFacts snapshots everything the decision tree needs.
+func (h *ToolHandler) gatherDoctorFacts(clientID string, buffers []string, now time.Time) doctorFacts {
+	f := doctorFacts{Now: now, ReadOnly: h.readOnly, ClientID: clientID}
+	cap := h.capture
+	if cap != nil {
+		snap := cap.GetHealthSnapshot()
+		f.ExtensionConnected = cap.IsExtensionConnected()
+		f.ExtensionLastSeen = cap.ExtensionLastSeen()
+		f.ExtensionVersion, f.ServerVersion, f.VersionMismatch = cap.GetVersionMismatch()
+		f.CircuitOpen, f.CircuitReason, f.CircuitOpenedAt = snap.CircuitOpen, snap.CircuitReason, snap.CircuitOpenedTime
+		f.Tracking, f.TrackedTabID, f.TrackedURL = cap.GetTrackingStatus()
+		f.MultiTab = cap.MultiTabCaptureEnabled()
+		f.ClientRegistered = true
+		if reg := cap.GetClientRegistry(); reg != nil && clientID != "" {
+			cs, ok := reg.Get(clientID).(*session.ClientState)
+			f.ClientRegistered = ok && cs != nil
+		}
+	}
+	for _, name := range buffers {
+		b := doctorBufferFacts{Name: name, ClearedAt: h.bufferClears.lastClear(name)}
+		b.Count, b.TotalAdded, b.TTL = h.doctorBufferCounts(name)
+		f.Buffers = append(f.Buffers, b)
+	}
+	if h.noiseConfig != nil {
+		stats := h.noiseConfig.GetStatistics()
+		f.NoiseFiltered = stats.TotalFiltered
+		f.NoiseTopRules = topNoiseRules(stats, 3)
+	}
+	return f
+}
+
+// doctorBufferCounts returns a buffer's live count, total ever added, and TTL.
+func (h *ToolHandler) doctorBufferCounts(buffer string) (count int, total int64, ttl time.Duration) {
+	if buffer == "logs" {
+		if logs := h.consoleLogs(); logs != nil {
+			_, ttl = logs.retention()
+			return logs.getEntryCount(), logs.getLogTotalAdded(), ttl
+		}
+		return 0, 0, 0
+	}
+	if h.capture == nil {
+		return 0, 0, 0
+	}
+	snap := h.capture.GetSnapshot()
+	switch buffer {
+	case "network":
+		return snap.NetworkCount, snap.NetworkTotalAdded, h.bufferRetention(capture.RetentionNetwork).TTL
+	case "websocket":
+		return snap.WebSocketCount, snap.WebSocketTotalAdded, h.bufferRetention(capture.RetentionWebSocket).TTL
+	case "actions":
+		return snap.ActionCount, snap.ActionTotalAdded, h.bufferRetention(capture.RetentionActions).TTL
+	case "sse":
+		return len(h.capture.GetAllSSEEvents()), h.capture.GetSSETotalAdded(), 0
+	}
+	return 0, 0, 0
+}
+
+// topNoiseRules returns the rules that filtered the most entries, most first.
+func topNoiseRules(stats noise.NoiseStatistics, n int) []doctorNoiseRule {
+	rules := make([]doctorNoiseRule, 0, len(stats.PerRule))
+	for id, matches := range stats.PerRule {
+		if matches > 0 {
+			rules = append(rules, doctorNoiseRule{ID: id, Matches: matches})
+		}
+	}
+	sort.Slice(rules, func(i, j int) bool {
+		if rules[i].Matches != rules[j].Matches {
+			return rules[i].Matches > rules[j].Matches
+		}
+		return rules[i].ID < rules[j].ID
+	})
+	if len(rules) > n {
+		rules = rules[:n]
+	}
+	return rules
+}
+
+// diagnoseMissingData walks the decision tree and returns causes ranked most likely first.
+// Connection-level causes come first in tree order; per-buffer causes only fire for empty buffers.
+func diagnoseMissingData(f doctorFacts) []doctorCause {
+	var causes []doctorCause
+	add := func(c doctorCause) { causes = append(causes, c) }
+
+	if f.ReadOnly != nil {
+		add(doctorCause{
+			ID: "read_only_bundle", weight: doctorHigh,
+			Summary:  "Kaboom is serving a recorded session bundle, so nothing new is captured",
+			Evidence: "bundle " + f.ReadOnly.BundlePath,
+			Fix:      "Run kaboom without --serve-bundle to capture live data",
+		})
+	}
+
+	extensionDown := !f.ExtensionConnected && f.ReadOnly == nil
+	switch {
+	case extensionDown && f.ExtensionLastSeen.IsZero():
+		add(doctorCause{
+			ID: "extension_never_connected", weight: doctorHigh,
+			Summary:  "The browser extension has never synced with this daemon",
+			Fix:      "Open Chrome with the Kaboom extension installed and enabled; the popup should show Connected on this daemon's port",
+			Commands: []string{`configure(what="doctor")`},
+		})
+	case extensionDown:
+		add(doctorCause{
+			ID: "extension_disconnected", weight: doctorHigh,
+			Summary:  "The browser extension stopped polling, so nothing new reaches the buffers",
+			Evidence: fmt.Sprintf("last sync %s ago", f.Now.Sub(f.ExtensionLastSeen).Round(time.Second)),
+			Fix:      "Check that Chrome is running and the extension popup shows Connected; reload the extension if it does not",
+			Commands: []string{`configure(what="doctor")`},
+		})
+	}
+
+	if f.VersionMismatch {
+		add(doctorCause{
+			ID: "version_mismatch", weight: doctorMedium,
+			Summary:  "Extension and daemon versions differ, so some capture messages may be ignored",
+			Evidence: fmt.Sprintf("extension %s, daemon %s", f.ExtensionVersion, f.ServerVersion),
+			Fix:      "Update the extension or the daemon so both run the same major.minor version",
+			Commands: []string{`configure(what="health")`},
+		})
+	}
+
+	if f.CircuitOpen {
+		evidence := f.CircuitReason
+		if !f.CircuitOpenedAt.IsZero() {
+			evidence = fmt.Sprintf("%s (open for %s)", f.CircuitReason, f.Now.Sub(f.CircuitOpenedAt).Round(time.Second))
+		}
+		add(doctorCause{
+			ID: "circuit_open", weight: doctorHigh,
+			Summary:  "The ingest circuit breaker is open, so the daemon is rejecting extension batches",
+			Evidence: evidence,
+			Fix:      "Find what floods the daemon (often an error loop on the page), stop it, and let the circuit close; state=closed overrides it meanwhile",
+			Commands: []string{`configure(what="circuit")`, `observe(what="errors", summary=true)`, `configure(what="circuit", state="closed")`},
+		})
+	}
+
+	if f.ExtensionConnected && (!f.Tracking || f.TrackedTabID == 0) {
+		add(doctorCause{
+			ID: "no_tracked_tab", weight: doctorHigh,
+			Summary:  "No tab is tracked, so page events are not captured",
+			Fix:      "Focus the page to debug in Chrome or navigate to it; the extension tracks the active tab",
+			Commands: []string{`interact(what="navigate", url="...")`, `observe(what="tabs")`},
+		})
+	}
+
+	connectionBlocked := len(causes) > 0
+	for _, b := range f.Buffers {
+		if b.Count > 0 {
+			continue
+		}
+		switch {
+		case !b.ClearedAt.IsZero():
+			add(doctorCause{
+				ID: "buffer_cleared", Buffer: b.Name, weight: doctorHigh,
+				Summary:  fmt.Sprintf("The %s buffer was cleared and nothing has been captured since", b.Name),
+				Evidence: "cleared at " + b.ClearedAt.UTC().Format(time.RFC3339),
+				Fix:      "Reproduce the behavior again; configure(what=\"clear\") discards everything captured before it",
+				Commands: []string{`interact(what="refresh")`},
+			})
+		case b.TTL > 0 && b.TotalAdded > 0:
+			retention := b.Name
+			if retention == "logs" {
+				retention = retentionConsole
+			}
+			add(doctorCause{
+				ID: "ttl_expired", Buffer: b.Name, weight: doctorHigh,
+				Summary:  fmt.Sprintf("%d %s entries were captured but aged out of the buffer", b.TotalAdded, b.Name),
+				Evidence: "ttl " + formatRetentionTTL(b.TTL),
+				Fix:      "Lengthen or remove the TTL, then reproduce the behavior again",
+				Commands: []string{fmt.Sprintf(`configure(what="retention", buffer="%s", ttl="0")`, retention)},
+			})
+		case b.TotalAdded == 0 && !connectionBlocked:
+			add(neverCapturedCause(b.Name, f.MultiTab))
+		}
+	}
+
+	if f.NoiseFiltered > 0 && noiseRelevant(f.Buffers) {
+		ids := make([]string, len(f.NoiseTopRules))
+		for i, r := range f.NoiseTopRules {
+			ids[i] = fmt.Sprintf("%s (%d)", r.ID, r.Matches)
+		}
+		cause := doctorCause{
+			ID: "noise_filtered", weight: doctorMedium,
+			Summary:  fmt.Sprintf("Noise rules have filtered %d entries", f.NoiseFiltered),
+			Evidence: "top rules: " + strings.Join(ids, ", "),
+			Fix:      "List the rules and remove any that hide entries you need",
+			Commands: []string{`configure(what="noise_rule", noise_action="list")`},
+		}
+		if len(f.NoiseTopRules) > 0 {
+			cause.Commands = append(cause.Commands,
+				fmt.Sprintf(`configure(what="noise_rule", noise_action="remove", rule_id="%s")`, f.NoiseTopRules[0].ID))
+		}
+		add(cause)
+	}
+
+	if f.ClientID != "" && !f.ClientRegistered && f.ReadOnly == nil {
+		add(doctorCause{
+			ID: "client_not_registered", weight: doctorLow,
+			Summary:  "This MCP client is not registered with the daemon, so per-client state such as git context is missing",
+			Evidence: "client_id " + f.ClientID,
+			Fix:      "Restart the MCP client's kaboom bridge so it registers on connect",
+		})
+	}
+
+	sort.SliceStable(causes, func(i, j int) bool { return causes[i].weight > causes[j].weight })
+	for i := range causes {
+		causes[i].Rank = i + 1
+		causes[i].Likelihood = doctorLikelihood[causes[i].weight]
+	}
+	return causes
+}
+
+// neverCapturedCause explains a buffer that has never received an entry while capture is healthy.
+func neverCapturedCause(buffer string, multiTab bool) doctorCause {
+	cause := doctorCause{
+		ID: "never_captured", Buffer: buffer, weight: doctorMedium,
+		Summary:  fmt.Sprintf("The %s buffer has never received an entry", buffer),
+		Fix:      "Reload the page so capture hooks are in place before it runs, then reproduce the behavior",
+		Commands: []string{`interact(what="refresh")`},
+	}
+	switch buffer {
+	case "websocket", "sse":
+		cause.Fix = "Only connections opened after the page loads are captured; reload the page, then trigger the stream"
+	case "actions":
+		cause.Fix = "Actions are recorded from user input and interact calls on the tracked tab; perform one, then check again"
+		cause.Commands = nil
+	}
+	if !multiTab {
+		cause.Evidence = "only the tracked tab is captured"
+		cause.Commands = append(cause.Commands, `configure(what="multi_tab_capture", enabled=true)`)
+	}
+	return cause
+}
+
+// noiseRelevant reports whether any empty buffer in the report is one noise rules filter.
+func noiseRelevant(buffers []doctorBufferFacts) bool {
+	for _, b := range buffers {
+		if doctorNoiseBuffers[b.Name] && b.Count == 0 {
+			return true
+		}
+	}
+	return false
+}
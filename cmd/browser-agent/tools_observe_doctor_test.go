@@ -0,0 +1,112 @@
+// Purpose: Tests observe(what="doctor") cause ranking, buffer scoping, and clear tracking.
+// Docs: docs/features/feature/observe-doctor/index.md
+
+package main
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+	"time"
+)
+
+func doctorCauseIDs(causes []doctorCause) []string {
+	ids := make([]string, len(causes))
+	for i, c := range causes {
+		ids[i] = c.ID
+	}
+	return ids
+}
+
+func TestDiagnoseMissingData_RanksCauses(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2026, 1, 15, 10, 30, 0, 0, time.UTC)
+	healthy := doctorFacts{Now: now, ExtensionConnected: true, ExtensionLastSeen: now, Tracking: true, TrackedTabID: 7, ClientRegistered: true}
+
+	f := healthy
+	f.ClientID, f.ClientRegistered = "agent-a", false
+	f.NoiseFiltered = 40
+	f.NoiseTopRules = []doctorNoiseRule{{ID: "user_1", Matches: 30}, {ID: "builtin_favicon", Matches: 10}}
+	f.Buffers = []doctorBufferFacts{
+		{Name: "logs", Count: 12, TotalAdded: 12},
+		{Name: "network", TotalAdded: 80, TTL: 10 * time.Minute},
+		{Name: "websocket"},
+	}
+	causes := diagnoseMissingData(f)
+	want := []string{"ttl_expired", "never_captured", "noise_filtered", "client_not_registered"}
+	if got := doctorCauseIDs(causes); !slices.Equal(got, want) {
+		t.Fatalf("causes = %v, want %v", got, want)
+	}
+	if causes[0].Rank != 1 || causes[0].Likelihood != "high" || causes[0].Buffer != "network" {
+		t.Errorf("top cause = %+v", causes[0])
+	}
+	if cmd := causes[0].Commands[0]; cmd != `configure(what="retention", buffer="network", ttl="0")` {
+		t.Errorf("ttl command = %q", cmd)
+	}
+	if cmd := causes[2].Commands[1]; cmd != `configure(what="noise_rule", noise_action="remove", rule_id="user_1")` {
+		t.Errorf("noise command = %q", cmd)
+	}
+
+	f = healthy
+	f.Buffers = []doctorBufferFacts{{Name: "logs", Count: 3, TotalAdded: 3}}
+	if causes := diagnoseMissingData(f); len(causes) != 0 {
+		t.Fatalf("healthy capture: causes = %v", doctorCauseIDs(causes))
+	}
+}
+
+func TestDiagnoseMissingData_ConnectionCausesSuppressNeverCaptured(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2026, 1, 15, 10, 30, 0, 0, time.UTC)
+	f := doctorFacts{
+		Now:               now,
+		ExtensionLastSeen: now.Add(-2 * time.Minute),
+		CircuitOpen:       true,
+		CircuitReason:     "rate exceeded",
+		CircuitOpenedAt:   now.Add(-30 * time.Second),
+		VersionMismatch:   true,
+		Buffers:           []doctorBufferFacts{{Name: "logs"}},
+	}
+	causes := diagnoseMissingData(f)
+	want := []string{"extension_disconnected", "circuit_open", "version_mismatch"}
+	if got := doctorCauseIDs(causes); !slices.Equal(got, want) {
+		t.Fatalf("causes = %v, want %v", got, want)
+	}
+	if causes[0].Evidence != "last sync 2m0s ago" || causes[1].Evidence != "rate exceeded (open for 30s)" {
+		t.Errorf("evidence = %q, %q", causes[0].Evidence, causes[1].Evidence)
+	}
+}
+
+func TestObserveDoctor_ToolCall(t *testing.T) {
+	t.Parallel()
+	h, _, _ := makeToolHandler(t)
+	req := JSONRPCRequest{JSONRPC: "2.0", ID: 1}
+
+	result := extractResultJSON(t, parseToolResult(t, h.toolObserve(req, json.RawMessage(`{"what":"doctor"}`))))
+	causes := result["causes"].([]any)
+	if len(causes) == 0 || causes[0].(map[string]any)["id"] != "extension_never_connected" {
+		t.Fatalf("causes = %v, want extension_never_connected first", causes)
+	}
+	if buffers := result["buffers"].(map[string]any); len(buffers) != len(doctorBuffers) {
+		t.Fatalf("buffers = %v", buffers)
+	}
+
+	callConfigureRaw(h, `{"what":"clear","buffer":"logs"}`)
+	scoped := extractResultJSON(t, parseToolResult(t, h.toolObserve(req, json.RawMessage(`{"what":"doctor","buffer":"logs"}`))))
+	found := false
+	for _, c := range scoped["causes"].([]any) {
+		if c.(map[string]any)["id"] == "buffer_cleared" {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("causes = %v, want buffer_cleared after configure clear", scoped["causes"])
+	}
+	if logs := scoped["buffers"].(map[string]any)["logs"].(map[string]any); logs["last_cleared"] == nil {
+		t.Fatalf("logs buffer = %v, want last_cleared", logs)
+	}
+
+	bad := parseToolResult(t, h.toolObserve(req, json.RawMessage(`{"what":"doctor","buffer":"inbox"}`)))
+	if !bad.IsError {
+		t.Fatal("expected an error for a buffer the doctor cannot explain")
+	}
+}
@@ -0,0 +1,66 @@
+// Purpose: Tests observe environment and the recorded browser settings in generated tests and reproductions.
+// Docs: docs/features/feature/browser-environment/index.md
+
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+)
+
+func TestObserveEnvironment_ReportsSyncedBrowserSettings(t *testing.T) {
+	t.Parallel()
+	h, _, cap := makeToolHandler(t)
+
+	observeEnv := func() map[string]any {
+		t.Helper()
+		result := parseToolResult(t, h.toolObserve(JSONRPCRequest{JSONRPC: "2.0", ID: 1}, json.RawMessage(`{"what":"environment"}`)))
+		if result.IsError {
+			t.Fatalf("observe environment failed: %s", firstText(result))
+		}
+		return extractResultJSON(t, result)
+	}
+	if data := observeEnv(); data["available"] != false || data["hint"] == nil {
+		t.Fatalf("before any sync: available=%v hint=%v, want false with a hint", data["available"], data["hint"])
+	}
+
+	cap.ProcessSyncMessage(capture.SyncRequest{ExtSessionID: "s1", Settings: &capture.SyncSettings{
+		TrackingEnabled: true, TrackedTabID: 7, TrackedTabURL: "https://shop.example.com/",
+		Environment: &capture.BrowserEnvironment{
+			UserAgent: "Mozilla/5.0 (Macintosh) Chrome/131.0", Locale: "de-DE", Timezone: "Europe/Berlin",
+			ViewportWidth: 390, ViewportHeight: 844, DevicePixelRatio: 3, ExtensionVersion: "0.7.12",
+		},
+	}}, "client", "test")
+
+	data := observeEnv()
+	env, _ := data["environment"].(map[string]any)
+	if data["available"] != true || env["timezone"] != "Europe/Berlin" || env["viewport_width"] != float64(390) || data["tab_id"] != float64(7) {
+		t.Fatalf("environment = %v", data)
+	}
+
+	cap.AddEnhancedActions([]capture.EnhancedAction{{Type: "navigate", Timestamp: time.Now().UnixMilli(), ToURL: "https://shop.example.com/"}})
+	generate := func(args string) string {
+		t.Helper()
+		result := parseToolResult(t, h.toolGenerate(JSONRPCRequest{JSONRPC: "2.0", ID: 1}, json.RawMessage(args)))
+		if result.IsError {
+			t.Fatalf("generate %s failed: %s", args, firstText(result))
+		}
+		script, _ := extractResultJSON(t, result)["script"].(string)
+		return script
+	}
+	for _, args := range []string{`{"what":"test"}`, `{"what":"reproduction","output_format":"playwright"}`} {
+		script := generate(args)
+		for _, want := range []string{"test.use({", "viewport: { width: 390, height: 844 }", "deviceScaleFactor: 3", "locale: 'de-DE'", "timezoneId: 'Europe/Berlin'"} {
+			if !strings.Contains(script, want) {
+				t.Fatalf("generate %s missing %q:\n%s", args, want, script)
+			}
+		}
+	}
+	if script := generate(`{"what":"reproduction"}`); !strings.Contains(script, "# Environment: 390x844 @3x | de-DE | Europe/Berlin") {
+		t.Fatalf("kaboom reproduction should note the environment:\n%s", script)
+	}
+}
@@ -0,0 +1,145 @@
+// Purpose: Persists error cluster history, raises "error regressed" alerts, and serves observe(what="errors", cluster_trend=true).
+// Why: Agents need to tell errors introduced this session apart from known ones, and to notice when a cleared error comes back.
+// Docs: docs/features/feature/error-clustering/index.md
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/analysis"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/persistence"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/tools/observe"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/types"
+)
+
+// errorClusterNamespace and errorClusterKey locate the persisted cluster history in the session store.
+const (
+	errorClusterNamespace = "error_clusters"
+	errorClusterKey       = "history"
+)
+
+// loadErrorClusterHistory restores persisted cluster history. A nil store yields an in-memory history.
+func loadErrorClusterHistory(store *persistence.SessionStore, now time.Time) *analysis.ErrorClusterHistory {
+	history := analysis.NewErrorClusterHistory(now)
+	if store == nil {
+		return history
+	}
+	if data, err := store.Load(errorClusterNamespace, errorClusterKey); err == nil {
+		_ = history.Load(data)
+	}
+	return history
+}
+
+// recordErrorClusters folds ingested console errors into the cluster history, queues an
+// alert for each cluster that reappears after a clear, and posts new and regressed clusters
+// to webhooks. Runs from the log onEntries callback.
+func (h *ToolHandler) recordErrorClusters(entries []LogEntry) {
+	if h.errorClusterHistory == nil {
+		return
+	}
+	var observations []analysis.ClusterObservation
+	for _, entry := range entries {
+		if level, _ := entry["level"].(string); level != "error" {
+			continue
+		}
+		msg, _ := entry["message"].(string)
+		if msg == "" || h.IsConsoleNoise(entry) {
+			continue
+		}
+		observations = append(observations, analysis.ClusterObservation{ID: observe.ErrorClusterID(msg), Message: msg})
+	}
+	if len(observations) == 0 {
+		return
+	}
+	now := time.Now()
+	created, regressed := h.errorClusterHistory.RecordChanges(observations, now)
+	if h.alertBuffer != nil {
+		for _, r := range regressed {
+			h.alertBuffer.AddAlert(errorRegressedAlert(r, now))
+		}
+	}
+	for _, r := range created {
+		h.webhooks.Publish(errorClusterWebhookEvent(r, false, now))
+	}
+	for _, r := range regressed {
+		h.webhooks.Publish(errorClusterWebhookEvent(r, true, now))
+	}
+	h.persistErrorClusterHistory()
+}
+
+// resolveErrorClusters marks every tracked cluster resolved when console logs are cleared,
+// so a cluster that recurs afterwards is flagged as regressed.
+func (h *ToolHandler) resolveErrorClusters() int {
+	if h.errorClusterHistory == nil {
+		return 0
+	}
+	resolved := h.errorClusterHistory.Resolve(time.Now())
+	h.persistErrorClusterHistory()
+	return resolved
+}
+
+func (h *ToolHandler) persistErrorClusterHistory() {
+	if h.sessionStoreImpl == nil {
+		return
+	}
+	if data, err := h.errorClusterHistory.JSON(); err == nil {
+		h.sessionStoreImpl.MarkDirty(errorClusterNamespace, errorClusterKey, data)
+	}
+}
+
+func errorRegressedAlert(r analysis.ClusterHistoryRecord, now time.Time) types.Alert {
+	resolved := "an earlier clear"
+	if r.ResolvedAt != nil {
+		resolved = r.ResolvedAt.Format(time.RFC3339)
+	}
+	return types.Alert{
+		Severity:  "error",
+		Category:  "regression",
+		Title:     "Error regressed: " + truncateAlertText(r.Message, 120),
+		Detail:    fmt.Sprintf("Cluster %s was resolved at %s and has reappeared (regression #%d). Inspect with observe(what=\"errors\", cluster_trend=true).", r.ID, resolved, r.Regressions),
+		Timestamp: now.Format(time.RFC3339),
+		Source:    "error_cluster",
+	}
+}
+
+func truncateAlertText(s string, max int) string {
+	if len(s) <= max {
+		return s
+	}
+	return s[:max] + "..."
+}
+
+// toolObserveErrors routes observe(what="errors") between the raw error list and the cluster trend.
+func (h *ToolHandler) toolObserveErrors(req JSONRPCRequest, args json.RawMessage) JSONRPCResponse {
+	var params struct {
+		ClusterTrend bool `json:"cluster_trend"`
+	}
+	lenientUnmarshal(args, &params)
+	if !params.ClusterTrend {
+		return observe.GetBrowserErrors(h, req, args)
+	}
+
+	history := h.errorClusterHistory
+	if history == nil {
+		history = analysis.NewErrorClusterHistory(time.Now())
+	}
+	trend := history.Trend()
+	resp := map[string]any{
+		"cluster_trend": trend,
+		"summary": map[string]int{
+			"new":       len(trend.New),
+			"known":     len(trend.Known),
+			"regressed": len(trend.Regressed),
+			"resolved":  trend.Resolved,
+			"tracked":   trend.Tracked,
+		},
+		"metadata": observe.BuildResponseMetadata(h.capture, time.Now()),
+	}
+	if len(trend.Regressed) > 0 {
+		resp["hint"] = "Regressed clusters were resolved by a log clear and came back; check recent edits for a reverted fix"
+	}
+	return succeed(req, fmt.Sprintf("Error clusters: %d new, %d known, %d regressed", len(trend.New), len(trend.Known), len(trend.Regressed)), resp)
+}
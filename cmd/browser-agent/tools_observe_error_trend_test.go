@@ -0,0 +1,75 @@
+// Purpose: Tests observe(what="errors", cluster_trend=true) and the "error regressed" alert after a log clear.
+// Docs: docs/features/feature/error-clustering/index.md
+
+package main
+
+import (
+	"testing"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/state"
+)
+
+func observeClusterTrend(t *testing.T, h *ToolHandler) map[string]any {
+	t.Helper()
+	result := parseToolResult(t, callToolRaw(h, "observe", `{"what":"errors","cluster_trend":true}`))
+	if result.IsError {
+		t.Fatalf("cluster_trend should succeed, got: %s", firstText(result))
+	}
+	return extractResultJSON(t, result)
+}
+
+func TestObserveErrorsClusterTrend_RegressesAfterClear(t *testing.T) {
+	t.Setenv(state.StateDirEnv, t.TempDir())
+	h, server, _ := makeToolHandler(t)
+	h.drainAlerts()
+
+	errEntry := LogEntry{"level": "error", "message": "TypeError: Cannot read properties of undefined (reading 'id')", "source": "app.js"}
+	server.logs.addEntries([]LogEntry{errEntry, {"level": "info", "message": "ready"}})
+
+	summary := observeClusterTrend(t, h)["summary"].(map[string]any)
+	if summary["new"] != float64(1) || summary["known"] != float64(0) || summary["regressed"] != float64(0) {
+		t.Fatalf("summary after first error = %v, want 1 new", summary)
+	}
+
+	clear := parseToolResult(t, callConfigureRaw(h, `{"what":"clear","buffer":"logs"}`))
+	if clear.IsError {
+		t.Fatalf("clear logs failed: %s", firstText(clear))
+	}
+	if got := extractResultJSON(t, clear)["cleared"].(map[string]any)["error_clusters_resolved"]; got != float64(1) {
+		t.Fatalf("error_clusters_resolved = %v, want 1", got)
+	}
+
+	server.logs.addEntries([]LogEntry{errEntry})
+
+	var regressedAlert bool
+	for _, a := range h.drainAlerts() {
+		if a.Source == "error_cluster" && a.Category == "regression" {
+			regressedAlert = true
+		}
+	}
+	if !regressedAlert {
+		t.Fatal("expected an error_cluster regression alert after the error reappeared")
+	}
+
+	data := observeClusterTrend(t, h)
+	if s := data["summary"].(map[string]any); s["regressed"] != float64(1) {
+		t.Fatalf("summary after regression = %v, want 1 regressed", s)
+	}
+	if data["hint"] == nil {
+		t.Fatal("expected a hint when clusters regressed")
+	}
+}
+
+func TestObserveErrors_WithoutClusterTrendReturnsRawErrors(t *testing.T) {
+	t.Parallel()
+	h, server, _ := makeToolHandler(t)
+	server.logs.addEntries([]LogEntry{{"level": "error", "message": "boom"}})
+
+	data := extractResultJSON(t, parseToolResult(t, callObserveRaw(h, "errors")))
+	if _, ok := data["errors"]; !ok {
+		t.Fatalf("default errors response missing errors: %v", data)
+	}
+	if _, ok := data["cluster_trend"]; ok {
+		t.Fatal("cluster_trend should only be returned when requested")
+	}
+}
@@ -0,0 +1,285 @@
+// Purpose: Feeds audit results into the finding lifecycle, raises "finding regressed" alerts, and serves observe(what="findings").
+// Why: Security, accessibility, and vitals audits each report point-in-time findings; one persisted queue lets agents triage them together.
+// Docs: docs/features/feature/finding-lifecycle/index.md
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/findings"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/mcp"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/performance"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/persistence"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/tools/observe"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/types"
+)
+
+// findingsNamespace and findingsKey locate the persisted finding lifecycle in the session store.
+const (
+	findingsNamespace = "findings"
+	findingsKey       = "lifecycle"
+)
+
+// findingAudit describes a tool call whose findings feed the lifecycle.
+type findingAudit struct {
+	source string
+	// narrowedBy lists args that restrict the audit; a narrowed run records what it
+	// finds but never marks missing findings fixed.
+	narrowedBy []string
+	// complete reports whether the payload covers the whole audit. Nil means always.
+	complete func(payload map[string]any) bool
+	// scope returns the page the audit ran against. Nil uses the tracked tab URL.
+	scope func(payload map[string]any) string
+}
+
+// findingAudits maps "tool:what" to the audits tracked by the finding lifecycle.
+var findingAudits = map[string]findingAudit{
+	"analyze:security_audit": {
+		source:     "security_audit",
+		narrowedBy: []string{"checks", "severity_min", "url", "summary", "category"},
+	},
+	"observe:cookie_audit": {
+		source:     "cookie_audit",
+		narrowedBy: []string{"url"},
+		complete: func(p map[string]any) bool {
+			_, skipped := p["storage_note"]
+			return p["storage_included"] == true && !skipped
+		},
+	},
+	"analyze:accessibility": {
+		source:     "accessibility",
+		narrowedBy: []string{"selector", "scope", "tags", "summary"},
+		complete:   func(p map[string]any) bool { return p["partial"] != true },
+	},
+	"observe:vitals": {
+		source: "vitals",
+		complete: func(p map[string]any) bool {
+			metrics, _ := p["metrics"].(map[string]any)
+			return metrics["has_data"] == true
+		},
+		scope: func(p map[string]any) string {
+			metrics, _ := p["metrics"].(map[string]any)
+			u, _ := metrics["url"].(string)
+			return u
+		},
+	},
+}
+
+// loadFindingTracker restores the persisted finding lifecycle. A nil store yields an in-memory tracker.
+func loadFindingTracker(store *persistence.SessionStore) *findings.Tracker {
+	tracker := findings.NewTracker()
+	if store == nil {
+		return tracker
+	}
+	if data, err := store.Load(findingsNamespace, findingsKey); err == nil {
+		_ = tracker.Load(data)
+	}
+	return tracker
+}
+
+// trackFindings applies a successful audit result to the finding lifecycle, queues an
+// alert for each fixed finding the audit detected again, and posts new and regressed
+// security and performance findings to webhooks. Called from HandleToolCall.
+func (h *ToolHandler) trackFindings(name string, args json.RawMessage, result *MCPToolResult) {
+	if h.findingTracker == nil || result == nil || len(result.Content) == 0 {
+		return
+	}
+	if _, degraded := result.Metadata["degraded"]; degraded {
+		return
+	}
+	audit, ok := findingAudits[name+":"+extractWhatParam(args)]
+	if !ok {
+		return
+	}
+	raw, ok := mcp.ExtractJSONPayload(result.Content[0].Text)
+	if !ok {
+		return
+	}
+	var payload map[string]any
+	if json.Unmarshal(raw, &payload) != nil {
+		return
+	}
+
+	sweep := findings.Sweep{
+		Source:       audit.source,
+		Observations: collectFindingObservations(payload, nil),
+		Complete:     !auditNarrowed(args, audit.narrowedBy) && (audit.complete == nil || audit.complete(payload)),
+	}
+	scope := ""
+	if audit.scope != nil {
+		scope = audit.scope(payload)
+	} else if h.capture != nil {
+		_, _, scope = h.capture.GetTrackingStatus()
+	}
+	if scope != "" {
+		sweep.Scope = performance.VitalsRoute(scope)
+	}
+
+	now := time.Now()
+	opened, regressed := h.findingTracker.ApplyChanges(sweep, now)
+	if h.alertBuffer != nil {
+		for _, f := range regressed {
+			h.alertBuffer.AddAlert(findingRegressedAlert(f, now))
+		}
+	}
+	for _, f := range opened {
+		if e, ok := findingWebhookEvent(f, false, now); ok {
+			h.webhooks.Publish(e)
+		}
+	}
+	for _, f := range regressed {
+		if e, ok := findingWebhookEvent(f, true, now); ok {
+			h.webhooks.Publish(e)
+		}
+	}
+	h.persistFindingTracker()
+}
+
+// auditNarrowed reports whether any of the narrowing args is set to a non-zero value.
+func auditNarrowed(args json.RawMessage, narrowedBy []string) bool {
+	if len(narrowedBy) == 0 {
+		return false
+	}
+	var params map[string]any
+	if json.Unmarshal(args, &params) != nil {
+		return false
+	}
+	for _, key := range narrowedBy {
+		switch v := params[key].(type) {
+		case nil:
+		case string:
+			if v != "" {
+				return true
+			}
+		case bool:
+			if v {
+				return true
+			}
+		case []any:
+			if len(v) > 0 {
+				return true
+			}
+		default:
+			return true
+		}
+	}
+	return false
+}
+
+// collectFindingObservations walks an audit payload for objects carrying a finding_id.
+func collectFindingObservations(node any, out []findings.Observation) []findings.Observation {
+	switch v := node.(type) {
+	case map[string]any:
+		if id, ok := v["finding_id"].(string); ok && strings.Contains(id, ".") {
+			out = append(out, findings.Observation{
+				FindingID: id,
+				Location:  firstString(v, "location"),
+				Severity:  firstString(v, "severity", "impact", "rating"),
+				Title:     firstString(v, "title", "help", "description", "metric"),
+			})
+		}
+		for _, child := range v {
+			out = collectFindingObservations(child, out)
+		}
+	case []any:
+		for _, child := range v {
+			out = collectFindingObservations(child, out)
+		}
+	}
+	return out
+}
+
+func firstString(m map[string]any, keys ...string) string {
+	for _, k := range keys {
+		if s, ok := m[k].(string); ok && s != "" {
+			return s
+		}
+	}
+	return ""
+}
+
+func (h *ToolHandler) persistFindingTracker() {
+	if h.sessionStoreImpl == nil {
+		return
+	}
+	if data, err := h.findingTracker.JSON(); err == nil {
+		h.sessionStoreImpl.MarkDirty(findingsNamespace, findingsKey, data)
+	}
+}
+
+func findingRegressedAlert(f findings.Finding, now time.Time) types.Alert {
+	severity := "warning"
+	if findings.SeverityRank(f.Severity) >= 3 {
+		severity = "error"
+	}
+	title := f.Title
+	if title == "" {
+		title = f.FindingID
+	}
+	return types.Alert{
+		Severity:  severity,
+		Category:  "regression",
+		Title:     "Finding regressed: " + truncateAlertText(title, 120),
+		Detail:    fmt.Sprintf("%s was fixed and %s detected it again (regression #%d). Triage with observe(what=\"findings\", state=\"regressed\"); fix steps via observe(what=\"playbook\", finding_id=%q).", f.ID, f.Source, f.Regressions, f.FindingID),
+		Timestamp: now.Format(time.RFC3339),
+		Source:    "finding_lifecycle",
+	}
+}
+
+// toolObserveFindings returns tracked findings across every audit, optionally filtered by lifecycle state.
+func (h *ToolHandler) toolObserveFindings(req JSONRPCRequest, args json.RawMessage) JSONRPCResponse {
+	var params struct {
+		State string `json:"state"`
+		Limit int    `json:"limit"`
+	}
+	if resp, stop := parseArgs(req, args, &params); stop {
+		return resp
+	}
+	switch params.State {
+	case "", "all", findings.StateOpen, findings.StateAcknowledged, findings.StateFixed, findings.StateRegressed:
+	default:
+		return fail(req, ErrInvalidParam, "Unknown finding state: "+params.State,
+			"Use state open, acknowledged, fixed, regressed, or all", withParam("state"))
+	}
+
+	tracker := h.findingTracker
+	if tracker == nil {
+		tracker = findings.NewTracker()
+	}
+	list := tracker.List(params.State)
+	total := len(list)
+	if params.Limit > 0 && len(list) > params.Limit {
+		list = list[:params.Limit]
+	}
+	state := params.State
+	if state == "" {
+		state = "all"
+	}
+	counts := tracker.Counts()
+	resp := map[string]any{
+		"findings": list,
+		"count":    len(list),
+		"total":    total,
+		"state":    state,
+		"states":   counts,
+		"metadata": observe.BuildResponseMetadata(h.capture, time.Now()),
+	}
+	if totalTracked(counts) == 0 {
+		resp["hint"] = `No findings tracked yet. Run analyze(what="security_audit"), analyze(what="accessibility"), observe(what="cookie_audit"), or observe(what="vitals") first`
+	} else if total > 0 {
+		resp["hint"] = `Fix steps: observe(what="playbook", finding_id=<finding_id>). Accept or close by hand: configure(what="finding_state", finding_id=<id>, state="acknowledged"|"fixed")`
+	}
+	return succeed(req, fmt.Sprintf("Findings (%s): %d of %d tracked, %d open, %d regressed", state, total, totalTracked(counts), counts[findings.StateOpen], counts[findings.StateRegressed]), resp)
+}
+
+func totalTracked(counts map[string]int) int {
+	n := 0
+	for _, c := range counts {
+		n += c
+	}
+	return n
+}
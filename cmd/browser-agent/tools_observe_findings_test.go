@@ -0,0 +1,133 @@
+// Purpose: Tests the finding lifecycle end to end: vitals findings open, fix, regress, and hand triage via configure finding_state.
+// Docs: docs/features/feature/finding-lifecycle/index.md
+
+package main
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/findings"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/performance"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/state"
+)
+
+func addLCPSnapshot(cap *capture.Store, lcp float64) {
+	cap.AddPerformanceSnapshots([]capture.PerformanceSnapshot{{
+		URL:       "https://app.test/checkout?step=2",
+		Timestamp: time.Now().UTC().Format(time.RFC3339Nano),
+		Timing:    performance.PerformanceTiming{LargestContentfulPaint: &lcp},
+	}})
+}
+
+func observeFindings(t *testing.T, h *ToolHandler, state string) map[string]any {
+	t.Helper()
+	result := parseToolResult(t, callToolRaw(h, "observe", `{"what":"findings","state":"`+state+`"}`))
+	if result.IsError {
+		t.Fatalf("findings(state=%s) failed: %s", state, firstText(result))
+	}
+	return extractResultJSON(t, result)
+}
+
+func TestObserveFindings_VitalsLifecycle(t *testing.T) {
+	t.Setenv(state.StateDirEnv, t.TempDir())
+	h, _, cap := makeToolHandler(t)
+	h.findingTracker = findings.NewTracker()
+	h.drainAlerts()
+
+	addLCPSnapshot(cap, 5200)
+	callToolRaw(h, "observe", `{"what":"vitals"}`)
+
+	open := observeFindings(t, h, "open")
+	list := open["findings"].([]any)
+	if len(list) != 1 {
+		t.Fatalf("open findings = %v, want 1", list)
+	}
+	f := list[0].(map[string]any)
+	if f["finding_id"] != "performance.lcp" || f["scope"] != "app.test/checkout" || f["source"] != "vitals" {
+		t.Fatalf("finding = %v, want performance.lcp on app.test/checkout from vitals", f)
+	}
+	id := f["id"].(string)
+
+	addLCPSnapshot(cap, 1200)
+	callToolRaw(h, "observe", `{"what":"vitals"}`)
+	if fixed := observeFindings(t, h, "fixed")["findings"].([]any); len(fixed) != 1 {
+		t.Fatalf("fixed findings = %v, want the lcp finding", fixed)
+	}
+
+	addLCPSnapshot(cap, 4800)
+	callToolRaw(h, "observe", `{"what":"vitals"}`)
+	var alerted bool
+	for _, a := range h.drainAlerts() {
+		if a.Source == "finding_lifecycle" && a.Category == "regression" {
+			alerted = true
+		}
+	}
+	if !alerted {
+		t.Fatal("expected a finding_lifecycle regression alert")
+	}
+	regressed := observeFindings(t, h, "regressed")["findings"].([]any)
+	if len(regressed) != 1 || regressed[0].(map[string]any)["id"] != id {
+		t.Fatalf("regressed findings = %v, want %s", regressed, id)
+	}
+
+	resp := parseToolResult(t, callConfigureRaw(h, `{"what":"finding_state","finding_id":"`+id+`","state":"acknowledged","reason":"tracked in JIRA-12"}`))
+	if resp.IsError {
+		t.Fatalf("finding_state failed: %s", firstText(resp))
+	}
+	data := observeFindings(t, h, "acknowledged")
+	ack := data["findings"].([]any)
+	if len(ack) != 1 || ack[0].(map[string]any)["note"] != "tracked in JIRA-12" {
+		t.Fatalf("acknowledged findings = %v", ack)
+	}
+	if states := data["states"].(map[string]any); states["open"] != float64(0) || states["regressed"] != float64(0) {
+		t.Fatalf("states = %v, want nothing open or regressed", states)
+	}
+}
+
+func TestObserveFindings_InvalidState(t *testing.T) {
+	t.Parallel()
+	h, _, _ := makeToolHandler(t)
+	if result := parseToolResult(t, callToolRaw(h, "observe", `{"what":"findings","state":"closed"}`)); !result.IsError {
+		t.Fatal("unknown state should return isError:true")
+	}
+}
+
+func TestConfigureFindingState_Errors(t *testing.T) {
+	t.Parallel()
+	h, _, _ := makeToolHandler(t)
+	h.findingTracker = findings.NewTracker()
+	h.findingTracker.Apply(findings.Sweep{Source: "security_audit", Observations: []findings.Observation{{FindingID: "security.missing_csp"}}}, time.Now())
+	id := findings.StableID("security.missing_csp", "", "")
+
+	cases := map[string]string{
+		"missing finding_id": `{"what":"finding_state","state":"fixed"}`,
+		"missing state":      `{"what":"finding_state","finding_id":"` + id + `"}`,
+		"unknown id":         `{"what":"finding_state","finding_id":"security.missing_csp@00000000","state":"fixed"}`,
+		"regressed by hand":  `{"what":"finding_state","finding_id":"` + id + `","state":"regressed"}`,
+	}
+	for name, args := range cases {
+		if result := parseToolResult(t, callConfigureRaw(h, args)); !result.IsError {
+			t.Errorf("%s: want isError:true", name)
+		}
+	}
+}
+
+func TestAuditNarrowed(t *testing.T) {
+	t.Parallel()
+	keys := []string{"checks", "summary", "url"}
+	for args, want := range map[string]bool{
+		`{"what":"security_audit"}`:                      false,
+		`{"what":"security_audit","checks":[]}`:          false,
+		`{"what":"security_audit","summary":false}`:      false,
+		`{"what":"security_audit","checks":["headers"]}`: true,
+		`{"what":"security_audit","summary":true}`:       true,
+		`{"what":"security_audit","url":"api"}`:          true,
+	} {
+		if got := auditNarrowed(json.RawMessage(args), keys); got != want {
+			t.Errorf("auditNarrowed(%s) = %v, want %v", args, got, want)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+// Purpose: Tests observe flakiness over repeated configure test_boundary runs.
+// Docs: docs/features/feature/flakiness-report/index.md
+
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+)
+
+func TestObserveFlakiness_ComparesBoundaryRuns(t *testing.T) {
+	t.Parallel()
+	h, _, cap := makeToolHandler(t)
+
+	runCheckout := func(payStatus int) {
+		t.Helper()
+		if r := parseToolResult(t, callConfigureRaw(h, `{"what":"test_boundary_start","test_id":"checkout"}`)); r.IsError {
+			t.Fatalf("test_boundary_start failed: %s", firstText(r))
+		}
+		now := time.Now()
+		cap.AddEnhancedActions([]capture.EnhancedAction{{Type: "click", Timestamp: now.UnixMilli(), Selectors: map[string]any{"css": "#pay"}}})
+		cap.AddNetworkBodies([]capture.NetworkBody{{Timestamp: now.Add(5 * time.Millisecond).UTC().Format(time.RFC3339Nano), URL: "https://shop.example.com/api/pay", Method: "POST", Status: payStatus}})
+		time.Sleep(10 * time.Millisecond)
+		if r := parseToolResult(t, callConfigureRaw(h, `{"what":"test_boundary_end","test_id":"checkout"}`)); r.IsError {
+			t.Fatalf("test_boundary_end failed: %s", firstText(r))
+		}
+	}
+
+	observeFlakiness := func() map[string]any {
+		t.Helper()
+		result := parseToolResult(t, h.toolObserve(JSONRPCRequest{JSONRPC: "2.0", ID: 1}, json.RawMessage(`{"what":"flakiness","boundary":"checkout"}`)))
+		if result.IsError {
+			t.Fatalf("observe flakiness failed: %s", firstText(result))
+		}
+		return extractResultJSON(t, result)
+	}
+
+	runCheckout(200)
+	if hint, _ := observeFlakiness()["hint"].(string); !strings.Contains(hint, "at least 2 completed runs") {
+		t.Fatalf("one run should return the runs hint, got %q", hint)
+	}
+
+	runCheckout(200)
+	runCheckout(502)
+	data := observeFlakiness()
+	if data["runs"] != float64(3) || data["classification"] != "divergent" {
+		t.Fatalf("runs=%v classification=%v, want 3/divergent", data["runs"], data["classification"])
+	}
+	steps, _ := data["steps"].([]any)
+	if len(steps) != 2 {
+		t.Fatalf("steps = %v, want run start + click", steps)
+	}
+	pay, _ := steps[1].(map[string]any)
+	if pay["action"] != "click #pay" || pay["flake_probability"] != 0.33 {
+		t.Fatalf("pay step = %v, want click #pay with probability 0.33", pay)
+	}
+	if deviating, _ := pay["deviating_runs"].([]any); len(deviating) != 1 || deviating[0] != float64(3) {
+		t.Fatalf("deviating_runs = %v, want [3]", pay["deviating_runs"])
+	}
+}
+
+func TestObserveFlakiness_RequiresBoundary(t *testing.T) {
+	t.Parallel()
+	h, _, _ := makeToolHandler(t)
+
+	result := parseToolResult(t, h.toolObserve(JSONRPCRequest{JSONRPC: "2.0", ID: 1}, json.RawMessage(`{"what":"flakiness"}`)))
+	if !result.IsError || !strings.Contains(firstText(result), "boundary") {
+		t.Fatalf("missing boundary should fail, got: %s", firstText(result))
+	}
+}
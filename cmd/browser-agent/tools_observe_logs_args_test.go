@@ -0,0 +1,72 @@
+// Purpose: Tests observe(what="logs") structured format and args_path/args_value filters over console arguments.
+// Docs: docs/features/feature/structured-console-args/index.md
+
+package main
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestObserveLogs_StructuredFormatAndArgsPathFilter(t *testing.T) {
+	t.Parallel()
+	h, server, _ := makeToolHandler(t)
+	server.logs.addEntries([]LogEntry{
+		{"level": "info", "type": "console", "message": "checkout", "args": []any{"checkout", map[string]any{"requestId": "req-42", "attempt": float64(2)}}},
+		{"level": "info", "type": "console", "message": "checkout", "args": []any{"checkout", map[string]any{"requestId": "req-7", "attempt": float64(1)}}},
+		{"level": "warn", "type": "console", "message": "plain string log", "args": []any{"plain string log"}},
+	})
+
+	req := JSONRPCRequest{JSONRPC: "2.0", ID: 1}
+	resp := h.toolObserve(req, json.RawMessage(`{"what":"logs","format":"structured","args_path":"args[1].requestId","args_value":"req-42"}`))
+	result := parseToolResult(t, resp)
+	if result.IsError {
+		t.Fatalf("structured logs should succeed, got: %s", firstText(result))
+	}
+	logs := extractResultJSON(t, result)["logs"].([]any)
+	if len(logs) != 1 {
+		t.Fatalf("logs = %v, want only the req-42 entry", logs)
+	}
+	entry := logs[0].(map[string]any)
+	args, ok := entry["args"].([]any)
+	if !ok || len(args) != 2 {
+		t.Fatalf("args = %v, want the two logged arguments at top level", entry["args"])
+	}
+	if obj := args[1].(map[string]any); obj["requestId"] != "req-42" || obj["attempt"] != float64(2) {
+		t.Fatalf("args[1] = %v, want the logged object", obj)
+	}
+	if _, ok := entry["data"]; ok {
+		t.Fatalf("data = %v, want args lifted out of data", entry["data"])
+	}
+
+	// Non-string values compare as JSON; a path alone matches entries where it resolves.
+	for body, want := range map[string]int{
+		`{"what":"logs","args_path":"args[1].attempt","args_value":"1"}`: 1,
+		`{"what":"logs","args_path":"args[1].requestId"}`:                2,
+		`{"what":"logs"}`: 3,
+	} {
+		data := extractResultJSON(t, parseToolResult(t, h.toolObserve(req, json.RawMessage(body))))
+		if got := len(data["logs"].([]any)); got != want {
+			t.Fatalf("%s: %d logs, want %d", body, got, want)
+		}
+	}
+}
+
+func TestObserveLogs_ArgsFilterValidation(t *testing.T) {
+	t.Parallel()
+	h, _, _ := makeToolHandler(t)
+	req := JSONRPCRequest{JSONRPC: "2.0", ID: 1}
+
+	for _, body := range []string{
+		`{"what":"logs","args_path":"args[x]"}`,
+		`{"what":"logs","args_value":"req-42"}`,
+	} {
+		if result := parseToolResult(t, h.toolObserve(req, json.RawMessage(body))); !result.IsError {
+			t.Fatalf("%s should return isError:true", body)
+		}
+	}
+	data := extractResultJSON(t, parseToolResult(t, h.toolObserve(req, json.RawMessage(`{"what":"logs","format":"yaml"}`))))
+	if hint, _ := data["param_hint"].(string); hint == "" {
+		t.Fatalf("unknown format should produce a param_hint, got %v", data)
+	}
+}
@@ -0,0 +1,45 @@
+// Purpose: Tests filter expressions on observe(what="logs") and observe(what="errors").
+// Docs: docs/features/feature/log-query/index.md
+
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestObserveLogs_FilterExpression(t *testing.T) {
+	t.Parallel()
+	h, server, _ := makeToolHandler(t)
+	server.logs.addEntries([]LogEntry{
+		{"level": "warn", "message": "ResizeObserver loop limit exceeded", "url": "https://shop.test/checkout"},
+		{"level": "error", "message": "payment declined", "url": "https://shop.test/checkout"},
+		{"level": "error", "message": "profile failed", "url": "https://shop.test/profile"},
+		{"level": "info", "message": "checkout loaded", "url": "https://shop.test/checkout"},
+	})
+
+	observe := func(args string) map[string]any {
+		t.Helper()
+		result := parseToolResult(t, h.toolObserve(JSONRPCRequest{JSONRPC: "2.0", ID: 1}, json.RawMessage(args)))
+		if result.IsError {
+			t.Fatalf("observe %s failed: %s", args, firstText(result))
+		}
+		return extractResultJSON(t, result)
+	}
+
+	logs := observe(`{"what":"logs","scope":"all","filter":"level>=warn AND url~\"checkout\" AND NOT message~\"ResizeObserver\""}`)["logs"].([]any)
+	if len(logs) != 1 || logs[0].(map[string]any)["message"] != "payment declined" {
+		t.Fatalf("logs = %v, want only the payment error", logs)
+	}
+
+	errors := observe(`{"what":"errors","scope":"all","filter":"url~profile"}`)["errors"].([]any)
+	if len(errors) != 1 || errors[0].(map[string]any)["message"] != "profile failed" {
+		t.Fatalf("errors = %v, want only the profile error", errors)
+	}
+
+	bad := parseToolResult(t, h.toolObserve(JSONRPCRequest{JSONRPC: "2.0", ID: 1}, json.RawMessage(`{"what":"logs","filter":"level>=loud"}`)))
+	if !bad.IsError || !strings.Contains(firstText(bad), "unknown level") {
+		t.Fatalf("invalid filter should return an error naming the problem, got: %s", firstText(bad))
+	}
+}
@@ -0,0 +1,81 @@
+// Purpose: Records memory samples carried by performance snapshots and serves observe(what="memory").
+// Why: Leaks show as heap and DOM growth across repeated navigations, which a single snapshot per URL cannot reveal.
+// Docs: docs/features/feature/memory-telemetry/index.md
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/performance"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/tools/observe"
+)
+
+// defaultMemorySampleLimit caps samples returned per route when 'limit' is omitted.
+const defaultMemorySampleLimit = 10
+
+// recordPerformanceSnapshots is the capture performance callback: it feeds ingested snapshots
+// to the vitals and memory histories. Runs outside the Capture lock.
+func (h *ToolHandler) recordPerformanceSnapshots(snapshots []capture.PerformanceSnapshot) {
+	h.recordVitalsHistory(snapshots)
+	h.recordMemoryHistory(snapshots)
+}
+
+// recordMemoryHistory adds the memory sample of each snapshot to its route's history.
+func (h *ToolHandler) recordMemoryHistory(snapshots []capture.PerformanceSnapshot) {
+	if h.memoryHistory == nil {
+		return
+	}
+	now := time.Now()
+	for _, snap := range snapshots {
+		h.memoryHistory.Record(snap, now)
+	}
+}
+
+// toolObserveMemory returns per-route heap and DOM trends, suspected leaks first.
+func (h *ToolHandler) toolObserveMemory(req JSONRPCRequest, args json.RawMessage) JSONRPCResponse {
+	var params struct {
+		URL   string `json:"url"`
+		Limit int    `json:"limit"`
+	}
+	lenientUnmarshal(args, &params)
+	if params.Limit <= 0 {
+		params.Limit = defaultMemorySampleLimit
+	}
+
+	history := h.memoryHistory
+	if history == nil {
+		history = performance.NewMemoryHistory()
+	}
+	routes := history.Trends(params.URL, params.Limit)
+	leaks := 0
+	for _, r := range routes {
+		if r.LeakSuspected {
+			leaks++
+		}
+	}
+	status := "ok"
+	switch {
+	case len(routes) == 0:
+		status = "no_samples"
+	case leaks > 0:
+		status = "leak_suspected"
+	}
+	resp := map[string]any{
+		"status":           status,
+		"routes":           routes,
+		"leaks_suspected":  leaks,
+		"leak_navigations": performance.LeakNavigations,
+		"metadata":         observe.BuildResponseMetadata(h.capture, time.Now()),
+	}
+	switch status {
+	case "no_samples":
+		resp["hint"] = "No memory samples yet. One is taken with each page load's performance snapshot; reload the tracked page. Heap sizes need Chrome's performance.memory"
+	case "leak_suspected":
+		resp["hint"] = "Compare heap snapshots in DevTools across the growing navigations; listeners on detached nodes usually point at unremoved handlers"
+	}
+	return succeed(req, fmt.Sprintf("Memory: %d route(s), %d suspected leak(s)", len(routes), leaks), resp)
+}
@@ -0,0 +1,49 @@
+// Purpose: Tests observe(what="memory") over memory samples ingested with performance snapshots.
+// Docs: docs/features/feature/memory-telemetry/index.md
+
+package main
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/performance"
+)
+
+func TestObserveMemory_ReportsLeakAcrossNavigations(t *testing.T) {
+	t.Parallel()
+	h, _, cap := makeToolHandler(t)
+
+	if data := extractResultJSON(t, parseToolResult(t, callObserveRaw(h, "memory"))); data["status"] != "no_samples" {
+		t.Fatalf("status before any snapshot = %v", data["status"])
+	}
+
+	for i, heapMB := range []int64{20, 24, 29, 35} {
+		cap.AddPerformanceSnapshots([]capture.PerformanceSnapshot{{
+			URL:       "/dashboard",
+			Timestamp: fmt.Sprintf("2026-05-01T09:0%d:00Z", i),
+			Memory: &performance.MemorySample{
+				TimeOrigin: float64(1000 + i), UsedJSHeapSize: heapMB << 20, DOMNodes: 2000 + 250*i, DetachedListeners: 3 * i,
+			},
+		}})
+	}
+	cap.AddPerformanceSnapshots([]capture.PerformanceSnapshot{{URL: "/settings", Memory: &performance.MemorySample{TimeOrigin: 1, DOMNodes: 400}}})
+
+	data := extractResultJSON(t, parseToolResult(t, callObserveRaw(h, "memory")))
+	if data["status"] != "leak_suspected" || data["leaks_suspected"] != float64(1) {
+		t.Fatalf("memory = %v", data)
+	}
+	routes := data["routes"].([]any)
+	if len(routes) != 2 {
+		t.Fatalf("routes = %v, want dashboard and settings", routes)
+	}
+	dashboard := routes[0].(map[string]any)
+	if dashboard["route"] != "/dashboard" || dashboard["leak_suspected"] != true || dashboard["growing_navigations"] != float64(4) ||
+		dashboard["dom_nodes_last"] != float64(2750) || dashboard["detached_listeners"] != float64(9) {
+		t.Fatalf("dashboard trend = %v", dashboard)
+	}
+	if samples := dashboard["samples"].([]any); len(samples) != 4 {
+		t.Fatalf("samples = %v, want 4", samples)
+	}
+}
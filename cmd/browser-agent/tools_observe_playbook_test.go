@@ -0,0 +1,35 @@
+// Purpose: Tests observe(what="playbook") lookup, fallback, index, and unknown finding IDs.
+// Docs: docs/features/feature/remediation-playbooks/index.md
+
+package main
+
+import "testing"
+
+func TestObservePlaybook_LookupFallbackAndIndex(t *testing.T) {
+	t.Parallel()
+	h, _, _ := makeToolHandler(t)
+
+	data := extractResultJSON(t, parseToolResult(t, callToolRaw(h, "observe", `{"what":"playbook","finding_id":"security.missing_csp"}`)))
+	playbook := data["playbook"].(map[string]any)
+	if playbook["finding_id"] != "security.missing_csp" || playbook["generic"] != nil {
+		t.Fatalf("playbook = %v", playbook)
+	}
+	verify := playbook["verify"].(map[string]any)
+	if verify["tool"] != "analyze" || verify["arguments"].(map[string]any)["what"] != "security_audit" {
+		t.Fatalf("verify = %v, want an analyze security_audit call", verify)
+	}
+
+	data = extractResultJSON(t, parseToolResult(t, callToolRaw(h, "observe", `{"what":"playbook","finding_id":"a11y.region"}`)))
+	if data["playbook"].(map[string]any)["generic"] != true || data["hint"] == nil {
+		t.Fatalf("unknown a11y rule should return the generic fallback with a hint, got %v", data)
+	}
+
+	index := extractResultJSON(t, parseToolResult(t, callObserveRaw(h, "playbook")))
+	if count, _ := index["count"].(float64); count < 30 {
+		t.Fatalf("index count = %v, want the full catalog", index["count"])
+	}
+
+	if result := parseToolResult(t, callToolRaw(h, "observe", `{"what":"playbook","finding_id":"nope.nothing"}`)); !result.IsError {
+		t.Fatal("unknown category should fail")
+	}
+}
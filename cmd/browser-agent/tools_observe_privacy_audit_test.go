@@ -0,0 +1,44 @@
+// Purpose: Tests observe(what="privacy_audit") end to end through the tool dispatcher.
+// Docs: docs/features/feature/privacy-audit/index.md
+
+package main
+
+import (
+	"testing"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+)
+
+func TestObservePrivacyAudit(t *testing.T) {
+	t.Parallel()
+	h, _, cap := makeToolHandler(t)
+	cap.SetTrackingStatusForTest(3, "https://shop.example.com/")
+	cap.AddNetworkBodies([]capture.NetworkBody{
+		{Method: "GET", URL: "https://connect.facebook.net/en_US/fbevents.js", ContentType: "application/javascript", ResponseBody: "navigator.hardwareConcurrency"},
+		{Method: "GET", URL: "https://www.facebook.com/tr/?id=99&ev=Purchase&ud[em]=jo%40example.com", ContentType: "image/gif"},
+	})
+
+	result := parseToolResult(t, callToolRaw(h, "observe", `{"what":"privacy_audit"}`))
+	if result.IsError {
+		t.Fatalf("privacy_audit failed: %s", firstText(result))
+	}
+	data := extractResultJSON(t, result)
+	if data["first_party_origin"] != "https://shop.example.com" {
+		t.Fatalf("first_party_origin = %v", data["first_party_origin"])
+	}
+	vendors := data["vendors"].([]any)
+	if len(vendors) != 1 {
+		t.Fatalf("vendors = %v, want one Meta Pixel entry", vendors)
+	}
+	meta := vendors[0].(map[string]any)
+	sent := meta["data_sent"].(map[string]any)
+	if meta["vendor"] != "Meta Pixel" || meta["requests"] != float64(2) || meta["pixels"] != float64(1) || sent["pii_fields"].([]any)[0] != "email" {
+		t.Fatalf("meta vendor = %v", meta)
+	}
+	if apis := meta["fingerprinting_apis"].([]any); len(apis) != 1 || apis[0] != "hardware_concurrency" {
+		t.Fatalf("fingerprinting_apis = %v", apis)
+	}
+	if data["hint"] != nil {
+		t.Fatalf("unexpected hint with scanned scripts: %v", data["hint"])
+	}
+}
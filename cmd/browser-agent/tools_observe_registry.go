@@ -30,26 +30,50 @@ func obsLocal(fn func(toolobserve.Deps, JSONRPCRequest, json.RawMessage) JSONRPC
 // observeHandlers maps observe mode names to their handler functions.
 var observeHandlers = map[string]ModeHandler{
 	// Delegated to internal/tools/observe
-	"errors":            obs(observe.GetBrowserErrors),
-	"logs":              obs(observe.GetBrowserLogs),
-	"extension_logs":    obs(observe.GetExtensionLogs),
-	"network_waterfall": obs(observe.GetNetworkWaterfall),
-	"network_bodies":    obs(observe.GetNetworkBodies),
-	"websocket_events":  obs(observe.GetWSEvents),
-	"websocket_status":  obs(observe.GetWSStatus),
-	"actions":           obs(observe.GetEnhancedActions),
-	"vitals":            obs(observe.GetWebVitals),
-	"page":              obs(observe.GetPageInfo),
-	"tabs":              obs(observe.GetTabs),
-	"history":           obs(observe.AnalyzeHistory),
-	"pilot":             obs(observe.ObservePilot),
-	"timeline":          obs(observe.GetSessionTimeline),
-	"error_bundles":     obs(observe.GetErrorBundles),
-	"screenshot":        obs(observe.GetScreenshot),
-	"storage":           obs(observe.GetStorage),
-	"indexeddb":         obs(observe.GetIndexedDB),
-	"summarized_logs":   obs(observe.GetSummarizedLogs),
-	"transients":        obs(observe.GetTransients),
+	"errors":              method((*ToolHandler).toolObserveErrors),
+	"logs":                obs(observe.GetBrowserLogs),
+	"extension_logs":      obs(observe.GetExtensionLogs),
+	"network_waterfall":   obs(observe.GetNetworkWaterfall),
+	"network_bodies":      obs(observe.GetNetworkBodies),
+	"websocket_events":    obs(observe.GetWSEvents),
+	"websocket_status":    obs(observe.GetWSStatus),
+	"sse":                 obs(observe.GetSSEEvents),
+	"server_debug":        obs(observe.GetServerDebug),
+	"actions":             obs(observe.GetEnhancedActions),
+	"page":                obs(observe.GetPageInfo),
+	"environment":         obs(observe.GetEnvironment),
+	"auth_state":          obs(observe.GetAuthState),
+	"cookie_audit":        obs(observe.GetCookieAudit),
+	"transport_security":  method((*ToolHandler).toolObserveTransportSecurity),
+	"doctor":              method((*ToolHandler).toolObserveDoctor),
+	"sessions":            method((*ToolHandler).toolObserveSessions),
+	"search":              method((*ToolHandler).toolObserveSearch),
+	"session_compare":     obs(observe.SessionCompare),
+	"third_party_audit":   obs(observe.GetThirdPartyAudit),
+	"privacy_audit":       obs(observe.GetPrivacyAudit),
+	"contract_violations": method((*ToolHandler).toolObserveContractViolations),
+	"budget_violations":   method((*ToolHandler).toolObserveBudgetViolations),
+	"memory":              method((*ToolHandler).toolObserveMemory),
+	"tabs":                obs(observe.GetTabs),
+	"history":             obs(observe.AnalyzeHistory),
+	"pilot":               obs(observe.ObservePilot),
+	"timeline":            obs(observe.GetSessionTimeline),
+	"state_at":            obs(observe.GetStateAt),
+	"flakiness":           obs(observe.GetFlakiness),
+	"error_bundles":       obs(observe.GetErrorBundles),
+	"screenshot":          obs(observe.GetScreenshot),
+	"storage":             obs(observe.GetStorage),
+	"form_state":          obs(observe.GetFormState),
+	"indexeddb":           obs(observe.GetIndexedDB),
+	"summarized_logs":     obs(observe.GetSummarizedLogs),
+	"transients":          obs(observe.GetTransients),
+	"changes":             obs(observe.GetChanges),
+	"component_audit":     obs(observe.GetComponentAudit),
+	"seo":                 obs(observe.GetSEO),
+	"coverage":            obs(observe.GetCoverage),
+	"playbook":            obs(observe.GetPlaybook),
+	"findings":            method((*ToolHandler).toolObserveFindings),
+	"assert":              obs(observe.GetAssert),
 	// Annotations (canonical home; also available via analyze for backwards compat)
 	"annotations":       method((*ToolHandler).toolGetAnnotations),
 	"annotation_detail": method((*ToolHandler).toolGetAnnotationDetail),
@@ -68,6 +92,9 @@ var observeHandlers = map[string]ModeHandler{
 	"recording_actions": method((*ToolHandler).toolGetRecordingActions),
 	"playback_results":  method((*ToolHandler).toolGetPlaybackResults),
 	"log_diff_report":   method((*ToolHandler).toolGetLogDiffReport),
+	"vitals":            method((*ToolHandler).toolObserveVitals),
+	"verify_fix":        method((*ToolHandler).toolObserveVerifyFix),
+	"capabilities":      method((*ToolHandler).toolObserveCapabilities),
 }
 
 // observeValueAliases maps shorthand names to their canonical observe mode names with deprecation metadata.
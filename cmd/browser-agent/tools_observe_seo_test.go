@@ -0,0 +1,63 @@
+// Purpose: Tests observe(what="seo") query dispatch and merging the page probe with captured network traffic.
+// Docs: docs/features/feature/seo-audit/index.md
+
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+)
+
+func TestObserveSEO_MergesProbeAndNetwork(t *testing.T) {
+	t.Parallel()
+	env := newToolTestEnv(t)
+	env.capture.SetTrackingStatusForTest(1, "https://shop.test/")
+	env.capture.SimulateExtensionConnectForTest()
+	env.capture.AddNetworkBodies([]capture.NetworkBody{{Method: "GET", URL: "https://shop.test/old", Status: 404, ContentType: "text/html"}})
+
+	wait := startToolCall(t, func() JSONRPCResponse {
+		return env.handler.toolObserve(JSONRPCRequest{JSONRPC: "2.0", ID: 1}, json.RawMessage(`{"what":"seo"}`))
+	})
+	probe := map[string]any{
+		"url":          "https://shop.test/",
+		"titles":       []string{"Shop"},
+		"descriptions": []string{},
+		"canonicals":   []string{"https://shop.test/"},
+		"robots":       []any{map[string]any{"name": "robots", "content": "noindex"}},
+		"json_ld":      []any{map[string]any{"text": "{not json"}},
+		"links":        []any{map[string]any{"url": "https://shop.test/old", "text": "Archive"}},
+		"link_count":   1,
+	}
+	answerPendingQuery(t, env.capture, "seo_scan", probe)
+
+	result := parseToolResult(t, wait())
+	if result.IsError {
+		t.Fatalf("seo should succeed, got: %s", firstText(result))
+	}
+	data := extractResultJSON(t, result)
+	findings, _ := data["findings"].([]any)
+	checks := map[string]bool{}
+	for _, f := range findings {
+		checks[f.(map[string]any)["check"].(string)] = true
+	}
+	for _, want := range []string{"robots_noindex", "structured_data_parse_error", "broken_internal_link", "description_missing", "title_too_short"} {
+		if !checks[want] {
+			t.Errorf("missing %s in %v", want, checks)
+		}
+	}
+	if !strings.Contains(firstText(result), "(3 high)") {
+		t.Errorf("summary line = %q", firstText(result))
+	}
+}
+
+func TestObserveSEO_RequiresTrackedTab(t *testing.T) {
+	t.Parallel()
+	env := newToolTestEnv(t)
+	result := parseToolResult(t, env.handler.toolObserve(JSONRPCRequest{JSONRPC: "2.0", ID: 1}, json.RawMessage(`{"what":"seo"}`)))
+	if !result.IsError || !strings.Contains(firstText(result), "no_data") {
+		t.Fatalf("expected no_data without a tracked tab, got %s", firstText(result))
+	}
+}
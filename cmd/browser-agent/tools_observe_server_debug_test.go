@@ -0,0 +1,84 @@
+// Purpose: Tests observe(what="server_debug") filters, cursors, the mine scope, and error tagging of 200 responses.
+// Docs: docs/features/feature/server-debug-log/index.md
+
+package main
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+)
+
+func TestObserveServerDebug_FiltersAndCursor(t *testing.T) {
+	t.Parallel()
+	h, _, cap := makeToolHandler(t)
+	base := time.Date(2026, 1, 15, 10, 30, 0, 0, time.UTC)
+	for i, e := range []capture.HTTPDebugEntry{
+		{Endpoint: "/mcp", Method: "POST", ClientID: "agent-a", ResponseStatus: 200, DurationMs: 12},
+		{Endpoint: "/mcp", Method: "POST", ClientID: "agent-b", ResponseStatus: 400, DurationMs: 3, Error: "Parse error: unexpected EOF"},
+		{Endpoint: "/mcp", Method: "POST", ClientID: "agent-a", ResponseStatus: 200, DurationMs: 2400, Error: "tool error"},
+		{Endpoint: "/mcp", Method: "POST", ClientID: "agent-a", ResponseStatus: 200, DurationMs: 40},
+	} {
+		e.Timestamp = base.Add(time.Duration(i) * time.Second)
+		cap.LogHTTPDebugEntry(e)
+	}
+
+	req := JSONRPCRequest{JSONRPC: "2.0", ID: 1}
+	observe := func(args string) map[string]any {
+		t.Helper()
+		return extractResultJSON(t, parseToolResult(t, h.toolObserve(req, json.RawMessage(args))))
+	}
+
+	if n := len(observe(`{"what":"server_debug"}`)["entries"].([]any)); n != 4 {
+		t.Fatalf("entries = %d, want 4", n)
+	}
+	if n := len(observe(`{"what":"server_debug","status_min":400}`)["entries"].([]any)); n != 1 {
+		t.Fatalf("status_min entries = %d, want 1", n)
+	}
+	slow := observe(`{"what":"server_debug","slow_ms":1000}`)["entries"].([]any)
+	if len(slow) != 1 || slow[0].(map[string]any)["error"] != "tool error" {
+		t.Fatalf("slow_ms entries = %v", slow)
+	}
+	if n := len(observe(`{"what":"server_debug","errors_only":true}`)["entries"].([]any)); n != 2 {
+		t.Fatalf("errors_only entries = %d, want the 400 and the tool error", n)
+	}
+	if empty := observe(`{"what":"server_debug","endpoint":"/sync"}`); empty["hint"] == nil {
+		t.Fatalf("want a filtered-empty hint, got %v", empty)
+	}
+
+	mineReq := JSONRPCRequest{JSONRPC: "2.0", ID: 2, ClientID: "agent-b"}
+	mine := extractResultJSON(t, parseToolResult(t, h.toolObserve(mineReq, json.RawMessage(`{"what":"server_debug","mine":true}`))))
+	if entries := mine["entries"].([]any); len(entries) != 1 || entries[0].(map[string]any)["status"] != float64(400) {
+		t.Fatalf("mine entries = %v", entries)
+	}
+
+	page := observe(`{"what":"server_debug","limit":2}`)
+	cursor, _ := page["metadata"].(map[string]any)["cursor"].(string)
+	if cursor == "" {
+		t.Fatalf("metadata = %v, want a cursor", page["metadata"])
+	}
+	cap.LogHTTPDebugEntry(capture.HTTPDebugEntry{Timestamp: base.Add(time.Minute), Endpoint: "/mcp", Method: "POST", ResponseStatus: 200})
+	if n := len(observe(`{"what":"server_debug","since_cursor":"` + cursor + `"}`)["entries"].([]any)); n != 2 {
+		t.Fatalf("entries since cursor = %d, want the cursor entry and the new one", n)
+	}
+
+	summary := observe(`{"what":"server_debug","summary":true}`)
+	if summary["errors"] != float64(2) || summary["max_duration_ms"] != float64(2400) {
+		t.Fatalf("summary = %v", summary)
+	}
+}
+
+func TestResponseErrorMessage(t *testing.T) {
+	t.Parallel()
+	if got := responseErrorMessage(JSONRPCResponse{Error: &JSONRPCError{Code: -32601, Message: "Method not found"}}); got != "Method not found" {
+		t.Errorf("JSON-RPC error = %q", got)
+	}
+	if got := responseErrorMessage(JSONRPCResponse{Result: json.RawMessage(`{"content":[],"isError":true}`)}); got != "tool error" {
+		t.Errorf("tool error = %q", got)
+	}
+	if got := responseErrorMessage(JSONRPCResponse{Result: json.RawMessage(`{"content":[]}`)}); got != "" {
+		t.Errorf("success = %q", got)
+	}
+}
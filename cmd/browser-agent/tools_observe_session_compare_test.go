@@ -0,0 +1,52 @@
+// Purpose: Tests observe(what="session_compare") against an exported bundle and the live session.
+// Docs: docs/features/feature/read-only-mode/index.md
+
+package main
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+)
+
+func TestObserveSessionCompare_BundleVersusCurrent(t *testing.T) {
+	t.Parallel()
+	h, _, cap := makeToolHandler(t)
+	cap.AddNetworkBodies([]capture.NetworkBody{{Method: "GET", URL: "https://app.test/api/cart", Status: 200}})
+
+	path := filepath.Join(t.TempDir(), "baseline.json")
+	if result := parseToolResult(t, callGenerateRaw(h, `{"what":"session_bundle","label":"baseline","save_to":"`+path+`"}`)); result.IsError {
+		t.Fatalf("export failed: %s", firstText(result))
+	}
+	cap.AddNetworkBodies([]capture.NetworkBody{{Method: "GET", URL: "https://app.test/api/cart", Status: 503}})
+
+	result := parseToolResult(t, callToolRaw(h, "observe", `{"what":"session_compare","a":"`+path+`","b":"current"}`))
+	if result.IsError {
+		t.Fatalf("session_compare failed: %s", firstText(result))
+	}
+	data := extractResultJSON(t, result)
+	if data["total_divergences"] != float64(1) {
+		t.Fatalf("total_divergences = %v, want 1: %v", data["total_divergences"], data["divergences"])
+	}
+	top := data["divergences"].([]any)[0].(map[string]any)
+	if top["kind"] != "status_changed" || top["key"] != "GET /api/cart" {
+		t.Fatalf("top divergence = %v, want GET /api/cart status_changed", top)
+	}
+	if a := data["a"].(map[string]any); a["label"] != "baseline" {
+		t.Fatalf("a = %v, want label baseline", a)
+	}
+}
+
+func TestObserveSessionCompare_RequiresBothSides(t *testing.T) {
+	t.Parallel()
+	h, _, _ := makeToolHandler(t)
+	for _, args := range []string{
+		`{"what":"session_compare","a":"current"}`,
+		`{"what":"session_compare","a":"current","b":"no-such-label-or-file"}`,
+	} {
+		if result := parseToolResult(t, callToolRaw(h, "observe", args)); !result.IsError {
+			t.Errorf("%s: expected error", args)
+		}
+	}
+}
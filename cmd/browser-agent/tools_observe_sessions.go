@@ -0,0 +1,125 @@
+// Purpose: Implements observe(what="sessions") and observe(what="search") over the session archive.
+// Why: Agents ask "have we seen this error before?"; archived sessions answer it after a clear or restart.
+// Docs: docs/features/feature/session-archive/index.md
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/sessionbundle"
+)
+
+const (
+	defaultArchiveListLimit   = 50
+	defaultArchiveSearchLimit = 50
+	maxArchiveSearchLimit     = 500
+)
+
+// archiveUnavailable is returned when the state directory could not be resolved at startup.
+func archiveUnavailable(req JSONRPCRequest) JSONRPCResponse {
+	return fail(req, ErrNotInitialized, "Session archive is not available: the state directory could not be resolved",
+		"Set KABOOM_STATE_DIR to a writable directory and restart the daemon")
+}
+
+// toolObserveSessions lists archived sessions, newest first.
+func (h *ToolHandler) toolObserveSessions(req JSONRPCRequest, args json.RawMessage) JSONRPCResponse {
+	var params struct {
+		Limit int `json:"limit"`
+	}
+	if resp, stop := parseArgs(req, args, &params); stop {
+		return resp
+	}
+	if h.sessionArchive == nil {
+		return archiveUnavailable(req)
+	}
+	sessions, err := h.sessionArchive.List()
+	if err != nil {
+		return fail(req, ErrInternal, "Cannot read the session archive: "+err.Error(),
+			"Check the archive directory "+h.sessionArchive.Dir())
+	}
+	settings, _ := h.sessionArchive.Settings()
+
+	total := len(sessions)
+	limit := params.Limit
+	if limit <= 0 {
+		limit = defaultArchiveListLimit
+	}
+	out := make([]map[string]any, 0, min(total, limit))
+	for _, s := range sessions[:min(total, limit)] {
+		out = append(out, archivedSessionJSON(h.sessionArchive, s))
+	}
+	data := map[string]any{
+		"sessions": out,
+		"count":    len(out),
+		"total":    total,
+		"dir":      h.sessionArchive.Dir(),
+		"settings": archiveSettingsJSON(settings),
+	}
+	if total == 0 {
+		data["hint"] = "Nothing archived yet. configure(what=\"clear\") and daemon shutdown archive the current buffers; check configure(what=\"archive\") if archiving is disabled."
+	} else {
+		data["hint"] = "Search with observe(what=\"search\", query=..., session=<id>), compare with observe(what=\"session_compare\", a=<path>, b=\"current\"), or replay with kaboom --serve-bundle <path>."
+	}
+	return succeed(req, fmt.Sprintf("%d archived session(s)", total), data)
+}
+
+// toolObserveSearch searches one archived session, or all of them, for a text query.
+func (h *ToolHandler) toolObserveSearch(req JSONRPCRequest, args json.RawMessage) JSONRPCResponse {
+	var params struct {
+		Query   string `json:"query"`
+		Session string `json:"session"`
+		Buffer  string `json:"buffer"`
+		Limit   int    `json:"limit"`
+	}
+	if resp, stop := parseArgs(req, args, &params); stop {
+		return resp
+	}
+	params.Query = strings.TrimSpace(params.Query)
+	if params.Query == "" {
+		return fail(req, ErrMissingParam, "Required parameter 'query' is missing",
+			"Add query: text to find in archived logs, request URLs and bodies, WebSocket payloads, and actions", withParam("query"))
+	}
+	if params.Buffer != "" && params.Buffer != "all" && !slices.Contains(sessionbundle.SearchBuffers, params.Buffer) {
+		return fail(req, ErrInvalidParam, "Unknown buffer: "+params.Buffer,
+			"Use buffer all, "+strings.Join(sessionbundle.SearchBuffers, ", "), withParam("buffer"))
+	}
+	if h.sessionArchive == nil {
+		return archiveUnavailable(req)
+	}
+	limit := params.Limit
+	if limit <= 0 {
+		limit = defaultArchiveSearchLimit
+	}
+	limit = min(limit, maxArchiveSearchLimit)
+
+	result, err := h.sessionArchive.Search(params.Session, params.Query, params.Buffer, limit)
+	if err != nil {
+		return fail(req, ErrInvalidParam, "Cannot search the session archive: "+err.Error(),
+			`Use a session id from observe(what="sessions"), or omit session to search every archived session`, withParam("session"))
+	}
+	data := map[string]any{
+		"query":             params.Query,
+		"sessions_searched": result.Searched,
+		"total_matches":     result.Total,
+		"matches":           nonNilHits(result.Hits),
+		"truncated":         result.Total > len(result.Hits),
+	}
+	if params.Session != "" {
+		data["session"] = params.Session
+	}
+	if len(result.Skipped) > 0 {
+		data["skipped_sessions"] = result.Skipped
+	}
+	return succeed(req, fmt.Sprintf("%d match(es) in %d archived session(s)", result.Total, result.Searched), data)
+}
+
+func nonNilHits(hits []sessionbundle.SearchHit) []sessionbundle.SearchHit {
+	if hits == nil {
+		return []sessionbundle.SearchHit{}
+	}
+	return hits
+}
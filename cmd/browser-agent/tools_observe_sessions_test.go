@@ -0,0 +1,106 @@
+// Purpose: Tests archiving on configure(what="clear"), configure(what="archive"), and observe sessions/search.
+// Docs: docs/features/feature/session-archive/index.md
+
+package main
+
+import (
+	"testing"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+)
+
+func TestClearAll_ArchivesSessionForSearch(t *testing.T) {
+	t.Parallel()
+	h, _, cap := makeToolHandler(t)
+
+	// Empty buffers are not archived.
+	cleared := extractResultJSON(t, parseToolResult(t, callConfigureRaw(h, `{"what":"clear"}`)))
+	if cleared["archived"] != nil {
+		t.Fatalf("empty clear archived a session: %v", cleared["archived"])
+	}
+
+	cap.AddNetworkBodies([]capture.NetworkBody{{Method: "GET", URL: "https://app.test/api/orders", Status: 500, ResponseBody: `{"error":"OrderService unavailable"}`}})
+	cleared = extractResultJSON(t, parseToolResult(t, callConfigureRaw(h, `{"what":"clear","buffer":"all"}`)))
+	archived, ok := cleared["archived"].(map[string]any)
+	if !ok {
+		t.Fatalf("clear all did not archive: %v", cleared)
+	}
+	if got := len(cap.GetNetworkBodies()); got != 0 {
+		t.Fatalf("network bodies after clear = %d, want 0", got)
+	}
+	// Clearing a single buffer keeps the session going and archives nothing.
+	if one := extractResultJSON(t, parseToolResult(t, callConfigureRaw(h, `{"what":"clear","buffer":"network"}`))); one["archived"] != nil {
+		t.Fatalf("single-buffer clear archived: %v", one)
+	}
+
+	sessions := extractResultJSON(t, parseToolResult(t, callObserveRaw(h, "sessions")))
+	list := sessions["sessions"].([]any)
+	if len(list) != 1 || list[0].(map[string]any)["id"] != archived["id"] {
+		t.Fatalf("sessions = %v, want the archived session", list)
+	}
+
+	search := parseToolResult(t, callToolRaw(h, "observe", `{"what":"search","query":"orderservice","session":"`+archived["id"].(string)+`"}`))
+	if search.IsError {
+		t.Fatalf("search failed: %s", firstText(search))
+	}
+	data := extractResultJSON(t, search)
+	matches := data["matches"].([]any)
+	if data["total_matches"] != float64(1) || len(matches) != 1 {
+		t.Fatalf("search = %v, want 1 match", data)
+	}
+	if m := matches[0].(map[string]any); m["buffer"] != "network_bodies" || m["field"] != "response_body" {
+		t.Fatalf("match = %v", m)
+	}
+}
+
+func TestConfigureArchive_SetDisableAndClear(t *testing.T) {
+	t.Parallel()
+	h, _, cap := makeToolHandler(t)
+
+	status := extractResultJSON(t, parseToolResult(t, callConfigureRaw(h, `{"what":"archive"}`)))
+	settings := status["settings"].(map[string]any)
+	if settings["enabled"] != true || settings["max_entries"] != float64(20) || settings["ttl"] != "168h0m0s" {
+		t.Fatalf("default settings = %v", settings)
+	}
+
+	cap.AddNetworkBodies([]capture.NetworkBody{{Method: "GET", URL: "https://app.test/a", Status: 200}})
+	callConfigureRaw(h, `{"what":"clear"}`)
+	set := extractResultJSON(t, parseToolResult(t, callConfigureRaw(h, `{"what":"archive","max_entries":5,"ttl":"0","enabled":false}`)))
+	if got := set["settings"].(map[string]any); got["enabled"] != false || got["max_entries"] != float64(5) || got["ttl"] != "0" {
+		t.Fatalf("set settings = %v", got)
+	}
+
+	cap.AddNetworkBodies([]capture.NetworkBody{{Method: "GET", URL: "https://app.test/b", Status: 200}})
+	if cleared := extractResultJSON(t, parseToolResult(t, callConfigureRaw(h, `{"what":"clear"}`))); cleared["archived"] != nil {
+		t.Fatalf("archived while disabled: %v", cleared["archived"])
+	}
+
+	removed := extractResultJSON(t, parseToolResult(t, callConfigureRaw(h, `{"what":"archive","operation":"clear"}`)))
+	if removed["removed"] != float64(1) || removed["sessions"] != float64(0) {
+		t.Fatalf("archive clear = %v, want 1 removed", removed)
+	}
+}
+
+func TestArchiveModes_RejectBadInput(t *testing.T) {
+	t.Parallel()
+	h, _, _ := makeToolHandler(t)
+	for _, args := range []string{
+		`{"what":"archive","max_entries":0}`,
+		`{"what":"archive","ttl":"5m"}`,
+		`{"what":"archive","ttl":"soon"}`,
+		`{"what":"archive","operation":"pause"}`,
+	} {
+		if result := parseToolResult(t, callConfigureRaw(h, args)); !result.IsError {
+			t.Errorf("configure %s should fail, got: %s", args, firstText(result))
+		}
+	}
+	for _, args := range []string{
+		`{"what":"search"}`,
+		`{"what":"search","query":"x","buffer":"sse"}`,
+		`{"what":"search","query":"x","session":"session-missing"}`,
+	} {
+		if result := parseToolResult(t, callToolRaw(h, "observe", args)); !result.IsError {
+			t.Errorf("observe %s should fail, got: %s", args, firstText(result))
+		}
+	}
+}
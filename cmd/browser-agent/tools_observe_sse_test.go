@@ -0,0 +1,83 @@
+// Purpose: Tests observe(what="sse") pagination, filters, connection state, and configure clear buffer="sse".
+// Docs: docs/features/feature/sse-tracking/index.md
+
+package main
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+)
+
+func TestObserveSSE_EntriesAndConnections(t *testing.T) {
+	t.Parallel()
+	h, _, cap := makeToolHandler(t)
+	cap.AddSSEEvents([]capture.SSEEvent{
+		{Timestamp: "2026-01-15T10:30:00.000Z", ID: "es-1", Event: "open", URL: "https://app.test/feed"},
+		{Timestamp: "2026-01-15T10:30:01.000Z", ID: "es-1", Event: "message", URL: "https://app.test/feed", EventType: "message", Data: "hello"},
+		{Timestamp: "2026-01-15T10:30:02.000Z", ID: "es-1", Event: "message", URL: "https://app.test/feed", EventType: "price", LastEventID: "42", Data: `{"p":3}`},
+		{Timestamp: "2026-01-15T10:30:03.000Z", ID: "es-1", Event: "retry", URL: "https://app.test/feed"},
+		{Timestamp: "2026-01-15T10:30:04.000Z", ID: "es-2", Event: "open", URL: "https://app.test/notify"},
+		{Timestamp: "2026-01-15T10:30:05.000Z", ID: "es-2", Event: "close", URL: "https://app.test/notify"},
+	})
+
+	req := JSONRPCRequest{JSONRPC: "2.0", ID: 1}
+	result := extractResultJSON(t, parseToolResult(t, h.toolObserve(req, json.RawMessage(`{"what":"sse"}`))))
+	if entries := result["entries"].([]any); len(entries) != 6 {
+		t.Fatalf("entries = %d, want 6", len(entries))
+	}
+	conns := result["connections"].([]any)
+	if len(conns) != 2 {
+		t.Fatalf("connections = %v", conns)
+	}
+	feed := conns[0].(map[string]any)
+	if feed["state"] != "reconnecting" || feed["messages"] != float64(2) || feed["retries"] != float64(1) || feed["last_event_id"] != "42" {
+		t.Fatalf("feed connection = %v", feed)
+	}
+	if conns[1].(map[string]any)["state"] != "closed" {
+		t.Fatalf("notify connection = %v", conns[1])
+	}
+
+	named := extractResultJSON(t, parseToolResult(t, h.toolObserve(req, json.RawMessage(`{"what":"sse","event_type":"price"}`))))
+	entries := named["entries"].([]any)
+	if len(entries) != 1 || entries[0].(map[string]any)["last_event_id"] != "42" {
+		t.Fatalf("event_type filter entries = %v", entries)
+	}
+
+	page := extractResultJSON(t, parseToolResult(t, h.toolObserve(req, json.RawMessage(`{"what":"sse","limit":2}`))))
+	cursor, _ := page["metadata"].(map[string]any)["cursor"].(string)
+	if cursor == "" {
+		t.Fatalf("metadata = %v, want a cursor", page["metadata"])
+	}
+	cap.AddSSEEvents([]capture.SSEEvent{{Timestamp: "2026-01-15T10:30:06.000Z", ID: "es-1", Event: "open", URL: "https://app.test/feed"}})
+	next := extractResultJSON(t, parseToolResult(t, h.toolObserve(req, json.RawMessage(`{"what":"sse","since_cursor":"`+cursor+`"}`))))
+	if n := len(next["entries"].([]any)); n != 2 {
+		t.Fatalf("entries since cursor = %d, want the cursor entry and the new one", n)
+	}
+
+	summary := extractResultJSON(t, parseToolResult(t, h.toolObserve(req, json.RawMessage(`{"what":"sse","summary":true}`))))
+	if byEvent := summary["by_event"].(map[string]any); byEvent["message"] != float64(2) || byEvent["open"] != float64(3) {
+		t.Fatalf("by_event = %v", byEvent)
+	}
+}
+
+func TestObserveSSE_EmptyHintAndClear(t *testing.T) {
+	t.Parallel()
+	h, _, cap := makeToolHandler(t)
+	req := JSONRPCRequest{JSONRPC: "2.0", ID: 1}
+
+	empty := extractResultJSON(t, parseToolResult(t, h.toolObserve(req, json.RawMessage(`{"what":"sse"}`))))
+	if hint, _ := empty["hint"].(string); hint == "" {
+		t.Fatalf("want an empty-buffer hint, got %v", empty)
+	}
+
+	cap.AddSSEEvents([]capture.SSEEvent{{ID: "es-1", Event: "open"}})
+	cleared := extractResultJSON(t, parseToolResult(t, callConfigureRaw(h, `{"what":"clear","buffer":"sse"}`)))
+	if counts, _ := cleared["cleared"].(map[string]any); counts["events"] != float64(1) {
+		t.Fatalf("clear result = %v", cleared)
+	}
+	if len(cap.GetAllSSEEvents()) != 0 {
+		t.Fatal("sse buffer should be empty after clear")
+	}
+}
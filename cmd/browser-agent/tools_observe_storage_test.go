@@ -0,0 +1,179 @@
+// Purpose: Tests observe storage redaction, quota, and change tracking across test boundaries.
+// Docs: docs/features/feature/storage-observation/index.md
+
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+)
+
+// storagePageStub answers state_capture and IndexedDB listing queries with mutable page state.
+type storagePageStub struct {
+	mu      sync.Mutex
+	state   string
+	listing string
+}
+
+func (s *storagePageStub) set(state, listing string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.state, s.listing = state, listing
+}
+
+// serve answers queries until the test ends. Like awaitPendingQuery, it wakes on
+// PendingQueryAdded rather than polling the queue.
+func (s *storagePageStub) serve(t *testing.T, cap *capture.Capture) {
+	t.Helper()
+	done := make(chan struct{})
+	t.Cleanup(func() { close(done) })
+	go func() {
+		handled := map[string]bool{}
+		for {
+			// Take the signal before reading the queue so an enqueue in between still wakes us.
+			added := cap.PendingQueryAdded()
+			for _, q := range cap.GetPendingQueries() {
+				if handled[q.ID] {
+					continue
+				}
+				s.mu.Lock()
+				switch q.Type {
+				case "state_capture":
+					cap.SetQueryResult(q.ID, json.RawMessage(s.state))
+				case "execute":
+					cap.SetQueryResult(q.ID, json.RawMessage(`{"success":true,"result":`+s.listing+`}`))
+				}
+				s.mu.Unlock()
+				handled[q.ID] = true
+			}
+			select {
+			case <-done:
+				return
+			case <-added:
+			}
+		}
+	}()
+}
+
+func TestObserveStorage_RedactsValuesAndReportsQuota(t *testing.T) {
+	t.Parallel()
+	h, _, cap := makeToolHandler(t)
+	cap.SetTrackingStatusForTest(21, "https://app.example.com")
+
+	stub := &storagePageStub{}
+	stub.set(
+		`{"url":"https://app.example.com","localStorage":{"theme":"dark","auth_token":"eyJhbGci"},"sessionStorage":{},"cookies":"debug=true; session_id=s3cr3t"}`,
+		`{"supported":true,"databases":[{"name":"app","version":1,"object_stores":["todos"],"record_counts":{"todos":4},"total_records":4}],"quota":{"usage":2048,"quota":1048576,"percent_used":0.2,"persisted":false}}`,
+	)
+	stub.serve(t, cap)
+
+	resp := h.toolObserve(JSONRPCRequest{JSONRPC: "2.0", ID: 1}, json.RawMessage(`{"what":"storage","summary":false}`))
+	result := parseToolResult(t, resp)
+	if result.IsError {
+		t.Fatalf("storage should succeed, got: %s", firstText(result))
+	}
+	text := firstText(result)
+	if strings.Contains(text, "eyJhbGci") || strings.Contains(text, "s3cr3t") {
+		t.Fatalf("sensitive values leaked: %s", text)
+	}
+
+	data := extractResultJSON(t, result)
+	local, _ := data["local_storage"].(map[string]any)
+	if local["theme"] != "dark" {
+		t.Fatalf("local_storage.theme = %v, want dark", local["theme"])
+	}
+	if marker, _ := local["auth_token"].(string); !strings.HasPrefix(marker, "[REDACTED") {
+		t.Fatalf("local_storage.auth_token = %v, want redaction marker", local["auth_token"])
+	}
+	cookies, _ := data["cookies"].([]any)
+	if len(cookies) != 2 {
+		t.Fatalf("cookies = %v, want 2 parsed from document.cookie", data["cookies"])
+	}
+	quota, _ := data["quota"].(map[string]any)
+	if usage, _ := quota["usage"].(float64); usage != 2048 {
+		t.Fatalf("quota.usage = %v, want 2048", quota["usage"])
+	}
+	indexeddb, _ := data["indexeddb"].(map[string]any)
+	if _, leaked := indexeddb["quota"]; leaked {
+		t.Fatal("quota should be lifted out of the indexeddb listing")
+	}
+}
+
+func TestObserveStorage_TestBoundaryChanges(t *testing.T) {
+	t.Parallel()
+	h, _, cap := makeToolHandler(t)
+	cap.SetTrackingStatusForTest(22, "https://app.example.com")
+	cap.SimulateExtensionConnectForTest()
+
+	stub := &storagePageStub{}
+	stub.set(
+		`{"url":"https://app.example.com/cart","localStorage":{"cart":"[]","theme":"dark"},"sessionStorage":{},"cookies":[{"name":"sid","value":"a"}]}`,
+		`{"supported":true,"databases":[{"name":"shop","object_stores":["orders"],"record_counts":{"orders":2}}]}`,
+	)
+	stub.serve(t, cap)
+
+	start := parseToolResult(t, callConfigureRaw(h, `{"what":"test_boundary_start","test_id":"checkout"}`))
+	if start.IsError {
+		t.Fatalf("test_boundary_start failed: %s", firstText(start))
+	}
+	if got := extractResultJSON(t, start)["storage_baseline"]; got != "recorded" {
+		t.Fatalf("storage_baseline = %v, want recorded", got)
+	}
+
+	stub.set(
+		`{"url":"https://app.example.com/done","localStorage":{"cart":"[1]","order_id":"o-9"},"sessionStorage":{},"cookies":[{"name":"sid","value":"a"}]}`,
+		`{"supported":true,"databases":[{"name":"shop","object_stores":["orders"],"record_counts":{"orders":3}}]}`,
+	)
+
+	result := parseToolResult(t, h.toolObserve(JSONRPCRequest{JSONRPC: "2.0", ID: 2}, json.RawMessage(`{"what":"storage","test_id":"checkout"}`)))
+	if result.IsError {
+		t.Fatalf("storage should succeed, got: %s", firstText(result))
+	}
+	changes, ok := extractResultJSON(t, result)["changes"].(map[string]any)
+	if !ok {
+		t.Fatal("changes missing from storage response")
+	}
+	if changed, _ := changes["changed"].(bool); !changed {
+		t.Fatal("changes.changed = false, want true")
+	}
+	local, _ := changes["local_storage"].(map[string]any)
+	for field, want := range map[string]string{"added": "order_id", "removed": "theme", "modified": "cart"} {
+		keys, _ := local[field].([]any)
+		if len(keys) != 1 || keys[0] != want {
+			t.Errorf("local_storage.%s = %v, want [%s]", field, keys, want)
+		}
+	}
+	idb, _ := changes["indexeddb"].([]any)
+	if len(idb) != 1 {
+		t.Fatalf("indexeddb changes = %v, want 1", changes["indexeddb"])
+	}
+	entry, _ := idb[0].(map[string]any)
+	if entry["store"] != "shop/orders" || entry["before"] != float64(2) || entry["after"] != float64(3) {
+		t.Fatalf("indexeddb change = %v, want shop/orders 2->3", entry)
+	}
+}
+
+func TestObserveStorage_TestIDWithoutBaselineRecordsOne(t *testing.T) {
+	t.Parallel()
+	h, _, cap := makeToolHandler(t)
+	cap.SetTrackingStatusForTest(23, "https://app.example.com")
+
+	stub := &storagePageStub{}
+	stub.set(`{"url":"https://app.example.com","localStorage":{},"sessionStorage":{},"cookies":""}`, `{"supported":true,"databases":[]}`)
+	stub.serve(t, cap)
+
+	result := parseToolResult(t, h.toolObserve(JSONRPCRequest{JSONRPC: "2.0", ID: 1}, json.RawMessage(`{"what":"storage","test_id":"fresh"}`)))
+	if result.IsError {
+		t.Fatalf("storage should succeed, got: %s", firstText(result))
+	}
+	if recorded, _ := extractResultJSON(t, result)["baseline_recorded"].(bool); !recorded {
+		t.Fatal("baseline_recorded = false, want true")
+	}
+	if _, ok := cap.GetStorageBaseline("fresh"); !ok {
+		t.Fatal("baseline not stored for test_id")
+	}
+}
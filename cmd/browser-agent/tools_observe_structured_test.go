@@ -0,0 +1,153 @@
+// Purpose: Tests structuredContent on tools/call results and the published observe output schemas.
+// Docs: docs/mcp-integration/index.md
+
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/mcp"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/schema"
+)
+
+func newStructuredTestHandler(t *testing.T) (*MCPHandler, *Server, *capture.Store) {
+	t.Helper()
+	server, err := NewServer(t.TempDir()+"/test.jsonl", 100)
+	if err != nil {
+		t.Fatalf("NewServer: %v", err)
+	}
+	t.Cleanup(func() { server.Close() })
+	cap := capture.NewCapture()
+	return NewToolHandler(server, cap), server, cap
+}
+
+func callToolStructured(t *testing.T, h *MCPHandler, tool, args string) MCPToolResult {
+	t.Helper()
+	params := `{"name":"` + tool + `","arguments":` + args + `}`
+	resp := h.HandleRequest(JSONRPCRequest{JSONRPC: "2.0", ID: 1, Method: "tools/call", Params: json.RawMessage(params)})
+	if resp == nil || resp.Error != nil {
+		t.Fatalf("tools/call %s %s = %+v", tool, args, resp)
+	}
+	return mustDecodeJSON[MCPToolResult](t, resp.Result)
+}
+
+// jsonSchemaType maps a decoded JSON value to its JSON Schema type name.
+func jsonSchemaType(v any) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		return "number"
+	case string:
+		return "string"
+	case []any:
+		return "array"
+	default:
+		return "object"
+	}
+}
+
+// checkTopLevelConforms verifies required keys and declared property types of one result object.
+func checkTopLevelConforms(t *testing.T, mode string, s map[string]any, obj map[string]any) {
+	t.Helper()
+	if required, ok := s["required"].([]string); ok {
+		for _, key := range required {
+			if _, present := obj[key]; !present {
+				t.Errorf("observe %s: required key %q missing from structuredContent", mode, key)
+			}
+		}
+	}
+	props, _ := s["properties"].(map[string]any)
+	for key, value := range obj {
+		prop, ok := props[key].(map[string]any)
+		if !ok {
+			continue
+		}
+		got := jsonSchemaType(value)
+		switch want := prop["type"].(type) {
+		case string:
+			if got != want {
+				t.Errorf("observe %s: key %q has type %s, schema says %s", mode, key, got, want)
+			}
+		case []string:
+			if !strings.Contains(strings.Join(want, ","), got) {
+				t.Errorf("observe %s: key %q has type %s, schema says %v", mode, key, got, want)
+			}
+		}
+	}
+}
+
+func TestObserveStructuredContent_MatchesTextAndSchema(t *testing.T) {
+	t.Parallel()
+	h, server, cap := newStructuredTestHandler(t)
+	server.logs.addEntries([]LogEntry{{"level": "error", "message": "TypeError: cart is undefined", "ts": time.Now().UTC().Format(time.RFC3339Nano)}})
+	cap.AddNetworkBodies([]capture.NetworkBody{{Method: "GET", URL: "http://localhost:5173/api/cart", Status: 200, ContentType: "application/json"}})
+
+	for mode := range liveResourceObserveModes {
+		if mode == "network_waterfall" {
+			continue // refreshes from the extension when stale
+		}
+		result := callToolStructured(t, h, "observe", `{"what":"`+mode+`"}`)
+		if result.IsError {
+			t.Errorf("observe %s returned an error: %s", mode, firstText(result))
+			continue
+		}
+		if len(result.StructuredContent) == 0 {
+			t.Errorf("observe %s: structuredContent missing", mode)
+			continue
+		}
+		payload, ok := mcp.ExtractJSONPayload(result.Content[0].Text)
+		if !ok || string(payload) != string(result.StructuredContent) {
+			t.Errorf("observe %s: structuredContent differs from text payload", mode)
+		}
+		s, ok := schema.ObserveOutputSchema(mode)
+		if !ok {
+			t.Errorf("observe %s: no published output schema", mode)
+			continue
+		}
+		checkTopLevelConforms(t, mode, s, mustDecodeJSON[map[string]any](t, result.StructuredContent))
+	}
+}
+
+func TestObserveStructuredContent_IsRedacted(t *testing.T) {
+	t.Parallel()
+	h, server, _ := newStructuredTestHandler(t)
+	server.logs.addEntries([]LogEntry{{"level": "error", "message": "401 with Authorization: Bearer abc123secrettoken", "ts": time.Now().UTC().Format(time.RFC3339Nano)}})
+
+	result := callToolStructured(t, h, "observe", `{"what":"errors"}`)
+	if len(result.StructuredContent) == 0 {
+		t.Fatal("structuredContent missing")
+	}
+	if got := string(result.StructuredContent); strings.Contains(got, "abc123secrettoken") || !strings.Contains(got, "[REDACTED") {
+		t.Fatalf("structuredContent not redacted like the text: %s", got)
+	}
+}
+
+func TestObserveOutputSchemas_PublishedForEveryMode(t *testing.T) {
+	t.Parallel()
+	tool := schema.ObserveToolSchema()
+	if tool.OutputSchema["type"] != "object" {
+		t.Fatalf("observe outputSchema = %v, want type object", tool.OutputSchema)
+	}
+	h, _, _ := newStructuredTestHandler(t)
+	for mode := range observeHandlers {
+		resp := readResource(t, h, schema.ObserveOutputSchemaURIPrefix+mode)
+		if resp == nil || resp.Error != nil {
+			t.Errorf("resources/read schema for %s = %+v", mode, resp)
+			continue
+		}
+		data := mustDecodeJSON[MCPResourcesReadResult](t, resp.Result)
+		if len(data.Contents) != 1 || !strings.Contains(data.Contents[0].Text, `"observe what=`+mode+`"`) {
+			t.Errorf("schema resource for %s = %+v", mode, data.Contents)
+		}
+	}
+	if resp := readResource(t, h, schema.ObserveOutputSchemaURIPrefix+"nope"); resp == nil || resp.Error == nil || resp.Error.Code != -32002 {
+		t.Fatalf("unknown schema resource = %+v, want -32002", resp)
+	}
+}
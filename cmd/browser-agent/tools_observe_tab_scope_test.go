@@ -0,0 +1,86 @@
+// Purpose: Tests observe tab_id filters, per-tab buffer stats in /health, and configure(what="multi_tab_capture").
+// Docs: docs/features/feature/multi-tab-attribution/index.md
+
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+)
+
+func TestObserveTabID_ScopesEntriesToOneTab(t *testing.T) {
+	t.Parallel()
+	h, server, cap := makeToolHandler(t)
+	now := time.Now().Format(time.RFC3339)
+
+	// Log entries arrive as decoded JSON, so tabId is a float64.
+	server.logs.addEntries([]LogEntry{
+		{"level": "error", "message": "cart failed to load", "ts": now, "tabId": float64(4)},
+		{"level": "error", "message": "admin socket closed", "ts": now, "tabId": float64(9), "frameId": float64(2)},
+	})
+	cap.AddNetworkBodies([]capture.NetworkBody{
+		{URL: "https://shop.example.com/api/cart", Method: "GET", Status: 500, TabID: 4},
+		{URL: "https://admin.example.com/api/users", Method: "GET", Status: 200, TabID: 9, FrameID: 2},
+	})
+	cap.AddEnhancedActions([]capture.EnhancedAction{{Type: "click", Timestamp: time.Now().UnixMilli(), TabID: 9}})
+
+	observe := func(args string) string {
+		t.Helper()
+		result := parseToolResult(t, h.toolObserve(JSONRPCRequest{JSONRPC: "2.0", ID: 1}, json.RawMessage(args)))
+		if result.IsError {
+			t.Fatalf("observe %s failed: %s", args, firstText(result))
+		}
+		return firstText(result)
+	}
+
+	errs := observe(`{"what":"errors","tab_id":9}`)
+	if !strings.Contains(errs, "admin socket closed") || strings.Contains(errs, "cart failed to load") || !strings.Contains(errs, `"frame_id":2`) {
+		t.Fatalf("tab 9 errors should hold only the admin error with its frame:\n%s", errs)
+	}
+	bodies := observe(`{"what":"network_bodies","tab_id":4}`)
+	if !strings.Contains(bodies, "/api/cart") || strings.Contains(bodies, "/api/users") {
+		t.Fatalf("tab 4 bodies should hold only the cart request:\n%s", bodies)
+	}
+	if timeline := observe(`{"what":"timeline","tab_id":9}`); strings.Contains(timeline, "cart failed to load") || !strings.Contains(timeline, `"tab_id":9`) {
+		t.Fatalf("tab 9 timeline should hold only tab 9 entries:\n%s", timeline)
+	}
+
+	rr := httptest.NewRecorder()
+	server.handleHealth(rr, httptest.NewRequest(http.MethodGet, "/health", nil), cap)
+	var health struct {
+		Tabs []capture.TabBufferStats `json:"tabs"`
+	}
+	if err := json.Unmarshal(rr.Body.Bytes(), &health); err != nil {
+		t.Fatal(err)
+	}
+	want := []capture.TabBufferStats{
+		{TabID: 4, Logs: 1, NetworkBodies: 1},
+		{TabID: 9, Logs: 1, NetworkBodies: 1, Actions: 1},
+	}
+	if len(health.Tabs) != len(want) || health.Tabs[0] != want[0] || health.Tabs[1] != want[1] {
+		t.Fatalf("/health tabs = %+v, want %+v", health.Tabs, want)
+	}
+}
+
+func TestConfigureMultiTabCapture_TogglesCaptureForEveryTab(t *testing.T) {
+	t.Parallel()
+	h, _, cap := makeToolHandler(t)
+
+	if data := extractResultJSON(t, parseToolResult(t, callConfigureRaw(h, `{"what":"multi_tab_capture"}`))); data["enabled"] != false {
+		t.Fatalf("multi-tab capture should default off, got %v", data["enabled"])
+	}
+	data := extractResultJSON(t, parseToolResult(t, callConfigureRaw(h, `{"what":"multi_tab_capture","enabled":true}`)))
+	if data["enabled"] != true || !cap.MultiTabCaptureEnabled() {
+		t.Fatalf("enabled=%v capture=%v, want both on", data["enabled"], cap.MultiTabCaptureEnabled())
+	}
+	callConfigureRaw(h, `{"what":"multi_tab_capture","enabled":false}`)
+	if cap.MultiTabCaptureEnabled() {
+		t.Fatal("multi-tab capture should be off after enabled=false")
+	}
+}
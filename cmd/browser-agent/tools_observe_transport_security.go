@@ -0,0 +1,48 @@
+// Purpose: Feeds ingested network telemetry into the transport-security monitor and serves observe(what="transport_security").
+// Why: Mixed content and HTTPS downgrades should surface as alerts the moment they are captured, not only on demand.
+// Docs: docs/features/feature/security-hardening/index.md
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/security"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/tools/observe"
+)
+
+// recordTransportActivity checks an ingested batch and queues alerts for new violations.
+// Called from recordNetworkActivity, the capture network callback; runs outside the Capture lock.
+func (h *ToolHandler) recordTransportActivity(activity capture.NetworkActivity) {
+	if h.transportMonitor == nil || h.alertBuffer == nil {
+		return
+	}
+	for _, alert := range h.transportMonitor.Observe(activity, time.Now()) {
+		h.alertBuffer.AddAlert(alert)
+	}
+}
+
+// toolObserveTransportSecurity returns the violations seen since the server started.
+func (h *ToolHandler) toolObserveTransportSecurity(req JSONRPCRequest, args json.RawMessage) JSONRPCResponse {
+	var params struct {
+		URL string `json:"url"`
+	}
+	lenientUnmarshal(args, &params)
+
+	monitor := h.transportMonitor
+	if monitor == nil {
+		monitor = security.NewTransportMonitor()
+	}
+	summary := monitor.Summary(params.URL)
+	return succeed(req, fmt.Sprintf("Transport security: %d violation(s)", summary.TotalViolations), map[string]any{
+		"status":           summary.Status,
+		"total_violations": summary.TotalViolations,
+		"by_kind":          summary.ByKind,
+		"violations":       summary.Violations,
+		"monitoring_since": summary.MonitoringSince,
+		"metadata":         observe.BuildResponseMetadata(h.capture, time.Now()),
+	})
+}
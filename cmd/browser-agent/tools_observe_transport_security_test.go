@@ -0,0 +1,45 @@
+// Purpose: Tests that ingested network telemetry feeds the transport monitor, alerts, and observe(what="transport_security").
+// Docs: docs/features/feature/security-hardening/index.md
+
+package main
+
+import (
+	"testing"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+)
+
+func TestObserveTransportSecurity_MixedContentRaisesAlert(t *testing.T) {
+	t.Parallel()
+	h, _, cap := makeToolHandler(t)
+	cap.AddNetworkWaterfallEntries([]capture.NetworkWaterfallEntry{
+		{URL: "http://cdn.test/lib.js", InitiatorType: "script"},
+	}, "https://app.test/")
+
+	alerts := h.drainAlerts()
+	if len(alerts) != 1 || alerts[0].Category != "security" {
+		t.Fatalf("alerts = %+v, want one security alert", alerts)
+	}
+
+	result := parseToolResult(t, callObserveRaw(h, "transport_security"))
+	if result.IsError {
+		t.Fatalf("transport_security should succeed, got: %s", firstText(result))
+	}
+	data := extractResultJSON(t, result)
+	if data["status"] != "violations" || data["total_violations"] != float64(1) {
+		t.Fatalf("status=%v total=%v, want violations/1", data["status"], data["total_violations"])
+	}
+	v := data["violations"].([]any)[0].(map[string]any)
+	if v["kind"] != "mixed_content" || v["severity"] != "high" {
+		t.Fatalf("violation = %v, want high mixed_content", v)
+	}
+}
+
+func TestObserveTransportSecurity_SecureByDefault(t *testing.T) {
+	t.Parallel()
+	h, _, _ := makeToolHandler(t)
+	data := extractResultJSON(t, parseToolResult(t, callObserveRaw(h, "transport_security")))
+	if data["status"] != "secure" {
+		t.Fatalf("status = %v, want secure", data["status"])
+	}
+}
@@ -0,0 +1,100 @@
+// Purpose: Implements observe(what="verify_fix"): optionally replays a saved flow, then watches for a verdict on the fix.
+// Why: An agent that just edited code needs "fixed / not fixed / can't tell yet" with evidence, not four separate reads.
+// Docs: docs/features/feature/verify-fix/index.md
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/tools/observe"
+)
+
+const (
+	defaultVerifyFixWaitMs = 10000
+	maxVerifyFixWaitMs     = 30000
+	verifyFixPollInterval  = 250 * time.Millisecond
+)
+
+// toolObserveVerifyFix judges expect against everything captured after since. since accepts
+// an RFC3339 timestamp, a pagination cursor, or "last_edit" (the default when edits were posted).
+// With replay, the named saved sequence runs first and since defaults to its start. While the
+// verdict is inconclusive the handler keeps watching for up to wait_ms.
+func (h *ToolHandler) toolObserveVerifyFix(req JSONRPCRequest, args json.RawMessage) JSONRPCResponse {
+	var params struct {
+		Since  string                  `json:"since"`
+		Expect observe.VerifyFixExpect `json:"expect"`
+		Replay string                  `json:"replay"`
+		WaitMs *int                    `json:"wait_ms"`
+	}
+	if resp, stop := parseArgs(req, args, &params); stop {
+		return resp
+	}
+	waitMs := defaultVerifyFixWaitMs
+	if params.WaitMs != nil {
+		waitMs = *params.WaitMs
+	}
+	if waitMs < 0 || waitMs > maxVerifyFixWaitMs {
+		return fail(req, ErrInvalidParam, fmt.Sprintf("wait_ms must be between 0 and %d", maxVerifyFixWaitMs),
+			"Pass a smaller wait_ms, or 0 to judge immediately", withParam("wait_ms"))
+	}
+
+	var since time.Time
+	sinceSource := "since"
+	switch params.Since {
+	case "", "last_edit":
+		if edits := h.capture.FileChanges().Edits(1); len(edits) > 0 {
+			since, sinceSource = edits[0].Timestamp, "last_edit:"+edits[0].ID
+		} else if params.Since == "last_edit" || params.Replay == "" {
+			return fail(req, ErrMissingParam, "No since point: pass since, or report edits with `kaboom watch` first",
+				"Add since (RFC3339 timestamp or cursor from a previous observe call) and call again", withParam("since"))
+		}
+	default:
+		ts, err := observe.ParseVerifySince(params.Since)
+		if err != nil {
+			return fail(req, ErrInvalidParam, err.Error(), "Pass an RFC3339 timestamp, a cursor, or 'last_edit'", withParam("since"))
+		}
+		since = ts
+	}
+
+	var replay map[string]any
+	if params.Replay != "" {
+		replayStart := time.Now()
+		replayArgs, _ := json.Marshal(map[string]any{"name": params.Replay})
+		resp := h.toolConfigureReplaySequence(req, replayArgs)
+		if isErrorResponse(resp) {
+			return resp
+		}
+		replay = map[string]any{"name": params.Replay, "started_at": replayStart.UTC().Format(time.RFC3339Nano)}
+		if since.IsZero() {
+			since, sinceSource = replayStart, "replay_start"
+		}
+	}
+
+	deadline := time.Now().Add(time.Duration(waitMs) * time.Millisecond)
+	result := observe.VerifyFix(h, since, params.Expect)
+	for result.Verdict == observe.FixVerdictInconclusive && time.Now().Before(deadline) {
+		time.Sleep(verifyFixPollInterval)
+		result = observe.VerifyFix(h, since, params.Expect)
+	}
+
+	data := map[string]any{
+		"verdict":      result.Verdict,
+		"since":        result.Since,
+		"since_source": sinceSource,
+		"checks":       result.Checks,
+		"metadata":     observe.BuildResponseMetadata(h.capture, time.Now()),
+	}
+	if result.Activity != "" {
+		data["activity"] = result.Activity
+	}
+	if replay != nil {
+		data["replay"] = replay
+	}
+	if result.Verdict == observe.FixVerdictInconclusive {
+		data["hint"] = "Nothing has failed yet, but some checks are pending. Reload the page or exercise the flow (or pass replay=<saved sequence>) and call verify_fix again."
+	}
+	return succeed(req, "Fix verification: "+result.Verdict, data)
+}
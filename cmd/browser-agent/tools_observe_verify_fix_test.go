@@ -0,0 +1,148 @@
+// Purpose: Tests observe(what="verify_fix") verdicts across errors, requests, vitals, and since resolution.
+// Docs: docs/features/feature/verify-fix/index.md
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/performance"
+)
+
+func callVerifyFix(t *testing.T, h *ToolHandler, args string) (map[string]any, MCPToolResult) {
+	t.Helper()
+	result := parseToolResult(t, h.toolObserve(JSONRPCRequest{JSONRPC: "2.0", ID: 1}, json.RawMessage(args)))
+	if result.IsError {
+		return nil, result
+	}
+	return extractResultJSON(t, result), result
+}
+
+func checkStatuses(data map[string]any) map[string]string {
+	statuses := map[string]string{}
+	for _, raw := range data["checks"].([]any) {
+		c := raw.(map[string]any)
+		statuses[c["check"].(string)] = c["status"].(string)
+	}
+	return statuses
+}
+
+func TestVerifyFix_FixedAfterReload(t *testing.T) {
+	t.Parallel()
+	h, server, cap := makeToolHandler(t)
+	now := time.Now()
+	ts := func(offset time.Duration) string { return now.Add(offset).UTC().Format(time.RFC3339Nano) }
+	since := ts(-30 * time.Second)
+
+	server.logs.addEntries([]LogEntry{{"level": "error", "message": "TypeError: cart is undefined", "ts": ts(-60 * time.Second)}})
+	cap.AddEnhancedActions([]capture.EnhancedAction{{Type: "navigation", Timestamp: now.Add(-20 * time.Second).UnixMilli(), ToURL: "http://localhost:5173/cart"}})
+	cap.AddNetworkBodies([]capture.NetworkBody{
+		{Timestamp: ts(-50 * time.Second), Method: "POST", URL: "http://localhost:5173/api/cart", Status: 500},
+		{Timestamp: ts(-15 * time.Second), Method: "POST", URL: "http://localhost:5173/api/cart", Status: 201},
+	})
+	lcp := 1800.0
+	cap.AddPerformanceSnapshots([]capture.PerformanceSnapshot{{URL: "http://localhost:5173/cart", Timestamp: ts(-19 * time.Second),
+		Timing: performance.PerformanceTiming{LargestContentfulPaint: &lcp, Load: 900}}})
+
+	data, result := callVerifyFix(t, h, `{"what":"verify_fix","since":"`+since+`","wait_ms":0,"expect":{
+		"error_cluster_id_absent":"TypeError: cart is undefined",
+		"request_succeeds":"POST /api/cart",
+		"vitals_within_budget":{"url":"/cart","lcp_ms":2500,"load_ms":3000}}}`)
+	if data == nil {
+		t.Fatalf("verify_fix failed: %s", firstText(result))
+	}
+	if data["verdict"] != "fixed" {
+		t.Fatalf("verdict = %v, checks = %v", data["verdict"], data["checks"])
+	}
+	want := map[string]string{"error_cluster_id_absent": "pass", "request_succeeds": "pass", "vitals_within_budget": "pass"}
+	for check, status := range want {
+		if got := checkStatuses(data)[check]; got != status {
+			t.Errorf("%s = %q, want %q", check, got, status)
+		}
+	}
+}
+
+func TestVerifyFix_NotFixedAndClusterID(t *testing.T) {
+	t.Parallel()
+	h, server, cap := makeToolHandler(t)
+	now := time.Now()
+	ts := func(offset time.Duration) string { return now.Add(offset).UTC().Format(time.RFC3339Nano) }
+
+	server.logs.addEntries([]LogEntry{
+		{"level": "error", "message": "TypeError: item 42 is undefined", "ts": ts(-60 * time.Second)},
+		{"level": "error", "message": "TypeError: item 43 is undefined", "ts": ts(-5 * time.Second)},
+	})
+	lcp := 4200.0
+	cap.AddPerformanceSnapshots([]capture.PerformanceSnapshot{{URL: "http://localhost:5173/", Timestamp: ts(-6 * time.Second),
+		Timing: performance.PerformanceTiming{LargestContentfulPaint: &lcp}}})
+
+	// The cluster id is the fingerprint analyze(error_clusters) reports; pick the one that recurs after since.
+	clusters := parseToolResult(t, h.toolAnalyze(JSONRPCRequest{JSONRPC: "2.0", ID: 1}, json.RawMessage(`{"what":"error_clusters"}`)))
+	id := ""
+	for _, raw := range extractResultJSON(t, clusters)["clusters"].([]any) {
+		if cluster := raw.(map[string]any); strings.Contains(fmt.Sprint(cluster["message"]), "item 43") {
+			id, _ = cluster["id"].(string)
+		}
+	}
+	if id == "" {
+		t.Fatal("error_clusters did not report a cluster id")
+	}
+
+	data, result := callVerifyFix(t, h, `{"what":"verify_fix","since":"`+ts(-30*time.Second)+`","wait_ms":0,"expect":{
+		"error_cluster_id_absent":"`+id+`","vitals_within_budget":{"lcp_ms":2500}}}`)
+	if data == nil {
+		t.Fatalf("verify_fix failed: %s", firstText(result))
+	}
+	statuses := checkStatuses(data)
+	if data["verdict"] != "not_fixed" || statuses["error_cluster_id_absent"] != "fail" || statuses["vitals_within_budget"] != "fail" {
+		t.Fatalf("verdict = %v, statuses = %v", data["verdict"], statuses)
+	}
+}
+
+func TestVerifyFix_InconclusiveWithoutActivity(t *testing.T) {
+	t.Parallel()
+	h, _, _ := makeToolHandler(t)
+	data, result := callVerifyFix(t, h, `{"what":"verify_fix","since":"`+time.Now().Add(-time.Second).UTC().Format(time.RFC3339Nano)+`","wait_ms":0,"expect":{"request_succeeds":"/api/cart"}}`)
+	if data == nil {
+		t.Fatalf("verify_fix failed: %s", firstText(result))
+	}
+	if data["verdict"] != "inconclusive" || checkStatuses(data)["request_succeeds"] != "pending" || data["hint"] == nil {
+		t.Fatalf("data = %v", data)
+	}
+}
+
+func TestVerifyFix_SinceResolution(t *testing.T) {
+	t.Parallel()
+	h, _, cap := makeToolHandler(t)
+
+	if _, result := callVerifyFix(t, h, `{"what":"verify_fix","wait_ms":0}`); !result.IsError {
+		t.Fatal("expected missing since error without edits")
+	}
+	if _, result := callVerifyFix(t, h, `{"what":"verify_fix","since":"yesterday","wait_ms":0}`); !result.IsError {
+		t.Fatal("expected invalid since error")
+	}
+	if _, result := callVerifyFix(t, h, `{"what":"verify_fix","since":"last_edit","wait_ms":40000}`); !result.IsError {
+		t.Fatal("expected wait_ms range error")
+	}
+
+	rr := httptest.NewRecorder()
+	handleFileChanges(cap).ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/file-changes", bytes.NewBufferString(`{"paths":["src/cart.ts"]}`)))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("POST /file-changes = %d", rr.Code)
+	}
+	data, result := callVerifyFix(t, h, `{"what":"verify_fix","wait_ms":0}`)
+	if data == nil {
+		t.Fatalf("verify_fix failed: %s", firstText(result))
+	}
+	if data["since_source"] != "last_edit:edit_1" || checkStatuses(data)["no_errors"] != "pending" {
+		t.Fatalf("data = %v", data)
+	}
+}
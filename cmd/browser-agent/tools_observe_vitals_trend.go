@@ -0,0 +1,182 @@
+// Purpose: Persists per-route Web Vitals samples into daily buckets and serves observe(what="vitals", mode="trend")
+// and windowed percentiles (aggregate="p75", window="1h").
+// Why: The capture snapshot map only keeps the latest load per URL; agents need multi-day p75 series to spot regressions.
+// Docs: docs/features/feature/web-vitals/index.md
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/performance"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/persistence"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/tools/observe"
+)
+
+// vitalsHistoryNamespace is the session-store namespace holding one key per UTC day.
+const vitalsHistoryNamespace = "vitals_history"
+
+// defaultVitalsTrendDays is the trend window when 'days' is omitted.
+const defaultVitalsTrendDays = 14
+
+// Defaults for windowed aggregates when only one of 'aggregate' and 'window' is given.
+const (
+	defaultVitalsAggregate = "p75"
+	defaultVitalsWindow    = "1h"
+)
+
+// loadVitalsHistory restores retained daily buckets and deletes expired ones.
+// A nil store yields an empty in-memory history.
+func loadVitalsHistory(store *persistence.SessionStore, now time.Time) *performance.VitalsHistory {
+	history := performance.NewVitalsHistory()
+	if store == nil {
+		return history
+	}
+	dates, err := store.List(vitalsHistoryNamespace)
+	if err != nil {
+		return history
+	}
+	for _, date := range dates {
+		if performance.VitalsHistoryExpired(date, now) {
+			_ = store.Delete(vitalsHistoryNamespace, date)
+			continue
+		}
+		data, loadErr := store.Load(vitalsHistoryNamespace, date)
+		if loadErr != nil {
+			continue
+		}
+		_ = history.LoadDay(date, data)
+	}
+	return history
+}
+
+// recordVitalsHistory folds ingested snapshots into the history and marks touched days dirty.
+// Registered as the capture performance callback; runs outside the Capture lock.
+func (h *ToolHandler) recordVitalsHistory(snapshots []capture.PerformanceSnapshot) {
+	if h.vitalsHistory == nil {
+		return
+	}
+	now := time.Now()
+	touched := make(map[string]bool)
+	for _, snap := range snapshots {
+		if date := h.vitalsHistory.Record(snap, now); date != "" {
+			touched[date] = true
+		}
+	}
+	h.vitalsHistory.Prune(now)
+	if h.sessionStoreImpl == nil {
+		return
+	}
+	for date := range touched {
+		if data, err := h.vitalsHistory.DayJSON(date); err == nil {
+			h.sessionStoreImpl.MarkDirty(vitalsHistoryNamespace, date, data)
+		}
+	}
+}
+
+// toolObserveVitals routes observe(what="vitals") between the latest snapshot view, windowed
+// percentiles, and the daily trend.
+func (h *ToolHandler) toolObserveVitals(req JSONRPCRequest, args json.RawMessage) JSONRPCResponse {
+	var params struct {
+		Mode      string `json:"mode"`
+		Aggregate string `json:"aggregate"`
+		Window    string `json:"window"`
+	}
+	lenientUnmarshal(args, &params)
+
+	switch params.Mode {
+	case "", "vitals", "current":
+		if params.Aggregate != "" || params.Window != "" {
+			return h.toolObserveVitalsAggregate(req, args)
+		}
+		return observe.GetWebVitals(h, req, args)
+	case "trend":
+		return h.toolObserveVitalsTrend(req, args)
+	default:
+		return fail(req, ErrInvalidParam, "Unknown vitals mode: "+params.Mode,
+			"Use mode 'current' (default) or 'trend'", withParam("mode"))
+	}
+}
+
+// toolObserveVitalsTrend returns daily p75 series per route for the requested window.
+func (h *ToolHandler) toolObserveVitalsTrend(req JSONRPCRequest, args json.RawMessage) JSONRPCResponse {
+	var params struct {
+		Days int    `json:"days"`
+		URL  string `json:"url"`
+	}
+	if resp, stop := parseArgs(req, args, &params); stop {
+		return resp
+	}
+	if params.Days == 0 {
+		params.Days = defaultVitalsTrendDays
+	}
+	if params.Days < 1 || params.Days > performance.VitalsHistoryRetentionDays {
+		return fail(req, ErrInvalidParam,
+			fmt.Sprintf("days must be between 1 and %d", performance.VitalsHistoryRetentionDays),
+			"Pass a smaller 'days' window", withParam("days"))
+	}
+
+	history := h.vitalsHistory
+	if history == nil {
+		history = performance.NewVitalsHistory()
+	}
+	routes := history.Trend(params.URL, params.Days, time.Now())
+	return succeed(req, "Web vitals trend", map[string]any{
+		"mode":           "trend",
+		"days":           params.Days,
+		"retention_days": performance.VitalsHistoryRetentionDays,
+		"route_count":    len(routes),
+		"routes":         routes,
+		"metadata":       observe.BuildResponseMetadata(h.capture, time.Now()),
+	})
+}
+
+// toolObserveVitalsAggregate returns a percentile of each vital per route over a recent window.
+func (h *ToolHandler) toolObserveVitalsAggregate(req JSONRPCRequest, args json.RawMessage) JSONRPCResponse {
+	var params struct {
+		Aggregate string `json:"aggregate"`
+		Window    string `json:"window"`
+		URL       string `json:"url"`
+	}
+	if resp, stop := parseArgs(req, args, &params); stop {
+		return resp
+	}
+	if params.Aggregate == "" {
+		params.Aggregate = defaultVitalsAggregate
+	}
+	if params.Window == "" {
+		params.Window = defaultVitalsWindow
+	}
+	q, err := performance.ParseVitalsAggregate(params.Aggregate)
+	if err != nil {
+		return fail(req, ErrInvalidParam, err.Error(), "Use aggregate p75 for CrUX semantics", withParam("aggregate"))
+	}
+	window, err := performance.ParseVitalsWindow(params.Window)
+	if err != nil {
+		return fail(req, ErrInvalidParam, err.Error(),
+			"Use a window up to 24h, or mode='trend' for daily p75 over longer ranges", withParam("window"))
+	}
+
+	history := h.vitalsHistory
+	if history == nil {
+		history = performance.NewVitalsHistory()
+	}
+	now := time.Now()
+	routes := history.Aggregate(params.URL, q, window, now)
+	resp := map[string]any{
+		"mode":        "aggregate",
+		"aggregate":   strings.ToLower(params.Aggregate),
+		"window":      params.Window,
+		"route_count": len(routes),
+		"routes":      routes,
+		"metadata":    observe.BuildResponseMetadata(h.capture, now),
+	}
+	if len(routes) == 0 {
+		resp["hint"] = "No page loads captured in this window. Reload the tracked page, widen the window, or use mode='trend' for persisted daily history"
+	}
+	return succeed(req, fmt.Sprintf("Web vitals %s over %s", strings.ToLower(params.Aggregate), params.Window), resp)
+}
@@ -0,0 +1,137 @@
+// Purpose: Tests for observe(what="vitals", mode="trend") routing, validation, and capture-callback recording.
+// Docs: docs/features/feature/web-vitals/index.md
+
+package main
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/performance"
+)
+
+func TestObserveVitalsTrend_RecordsIngestedSnapshots(t *testing.T) {
+	t.Parallel()
+	h, _, cap := makeToolHandler(t)
+	h.vitalsHistory = performance.NewVitalsHistory()
+
+	lcp := 2400.0
+	cap.AddPerformanceSnapshots([]capture.PerformanceSnapshot{{
+		URL:       "https://app.test/dashboard?tab=1",
+		Timestamp: time.Now().UTC().Format(time.RFC3339Nano),
+		Timing:    performance.PerformanceTiming{LargestContentfulPaint: &lcp},
+	}})
+
+	req := JSONRPCRequest{JSONRPC: "2.0", ID: 1}
+	resp := h.toolObserve(req, json.RawMessage(`{"what":"vitals","mode":"trend","days":7}`))
+	result := parseToolResult(t, resp)
+	if result.IsError {
+		t.Fatalf("vitals trend should succeed, got: %s", firstText(result))
+	}
+	data := extractResultJSON(t, result)
+	if data["mode"] != "trend" || data["days"] != float64(7) {
+		t.Fatalf("mode/days = %v/%v, want trend/7", data["mode"], data["days"])
+	}
+	routes, ok := data["routes"].([]any)
+	if !ok || len(routes) != 1 {
+		t.Fatalf("routes = %v, want 1 entry", data["routes"])
+	}
+	route := routes[0].(map[string]any)
+	if route["route"] != "app.test/dashboard" {
+		t.Fatalf("route = %v, want app.test/dashboard", route["route"])
+	}
+	points := route["points"].([]any)
+	if p := points[0].(map[string]any); p["lcp_p75"] != float64(2400) {
+		t.Fatalf("lcp_p75 = %v, want 2400", p["lcp_p75"])
+	}
+}
+
+func TestObserveVitalsTrend_InvalidDays(t *testing.T) {
+	t.Parallel()
+	h, _, _ := makeToolHandler(t)
+
+	req := JSONRPCRequest{JSONRPC: "2.0", ID: 1}
+	resp := h.toolObserve(req, json.RawMessage(`{"what":"vitals","mode":"trend","days":90}`))
+	if result := parseToolResult(t, resp); !result.IsError {
+		t.Fatal("days beyond retention should return isError:true")
+	}
+}
+
+func TestObserveVitals_DefaultModeReturnsLatestSnapshot(t *testing.T) {
+	t.Parallel()
+	h, _, _ := makeToolHandler(t)
+
+	resp := callObserveRaw(h, "vitals")
+	result := parseToolResult(t, resp)
+	if result.IsError {
+		t.Fatalf("vitals should succeed, got: %s", firstText(result))
+	}
+	data := extractResultJSON(t, result)
+	if _, ok := data["metrics"]; !ok {
+		t.Fatalf("default vitals response missing metrics: %v", data)
+	}
+}
+
+func TestObserveVitals_UnknownSubModeRejected(t *testing.T) {
+	t.Parallel()
+	h, _, _ := makeToolHandler(t)
+
+	req := JSONRPCRequest{JSONRPC: "2.0", ID: 1}
+	resp := h.toolObserve(req, json.RawMessage(`{"what":"vitals","mode":"weekly"}`))
+	if result := parseToolResult(t, resp); !result.IsError {
+		t.Fatal("unknown vitals sub-mode should return isError:true")
+	}
+}
+
+func TestObserveVitalsAggregate_P75OverWindow(t *testing.T) {
+	t.Parallel()
+	h, _, cap := makeToolHandler(t)
+	h.vitalsHistory = performance.NewVitalsHistory()
+
+	now := time.Now().UTC()
+	for i, lcp := range []float64{1200, 1300, 1400, 6800} {
+		cap.AddPerformanceSnapshots([]capture.PerformanceSnapshot{{
+			URL:       "https://app.test/checkout",
+			Timestamp: now.Add(time.Duration(i-4) * time.Minute).Format(time.RFC3339Nano),
+			Timing:    performance.PerformanceTiming{LargestContentfulPaint: &lcp},
+		}})
+	}
+
+	req := JSONRPCRequest{JSONRPC: "2.0", ID: 1}
+	result := parseToolResult(t, h.toolObserve(req, json.RawMessage(`{"what":"vitals","aggregate":"p75","window":"1h"}`)))
+	if result.IsError {
+		t.Fatalf("vitals aggregate should succeed, got: %s", firstText(result))
+	}
+	data := extractResultJSON(t, result)
+	if data["mode"] != "aggregate" || data["aggregate"] != "p75" || data["window"] != "1h" {
+		t.Fatalf("mode/aggregate/window = %v/%v/%v", data["mode"], data["aggregate"], data["window"])
+	}
+	routes := data["routes"].([]any)
+	if len(routes) != 1 {
+		t.Fatalf("routes = %v, want checkout only", routes)
+	}
+	route := routes[0].(map[string]any)
+	values := route["values"].(map[string]any)
+	latest := route["latest"].(map[string]any)
+	if route["samples"] != float64(4) || values["lcp"] != float64(2750) || latest["lcp"] != float64(6800) {
+		t.Fatalf("checkout aggregate = %v, want p75 2750 with latest 6800", route)
+	}
+}
+
+func TestObserveVitalsAggregate_InvalidArgs(t *testing.T) {
+	t.Parallel()
+	h, _, _ := makeToolHandler(t)
+
+	req := JSONRPCRequest{JSONRPC: "2.0", ID: 1}
+	for _, args := range []string{
+		`{"what":"vitals","aggregate":"mean"}`,
+		`{"what":"vitals","window":"7d"}`,
+		`{"what":"vitals","aggregate":"p75","window":"72h"}`,
+	} {
+		if result := parseToolResult(t, h.toolObserve(req, json.RawMessage(args))); !result.IsError {
+			t.Errorf("%s should return isError:true", args)
+		}
+	}
+}
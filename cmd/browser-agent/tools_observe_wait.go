@@ -0,0 +1,107 @@
+// Purpose: Implements observe(wait_for_new=true), blocking until matching telemetry arrives before running the mode.
+// Why: "Wait for the error to happen again" should be one blocking call instead of a hot polling loop.
+// Docs: docs/features/feature/observe-long-poll/index.md
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/tools/observe"
+)
+
+// Long-poll bounds. The maximum leaves room for the mode itself inside the bridge's
+// 65s blocking-poll timeout and the HTTP server's 65s write timeout.
+const (
+	observeWaitDefault = 25 * time.Second
+	observeWaitMax     = 55 * time.Second
+)
+
+// observeWaitParams are the long-poll arguments accepted by every waitable observe mode.
+type observeWaitParams struct {
+	WaitForNew bool  `json:"wait_for_new"`
+	TimeoutMs  int   `json:"timeout_ms"`
+	AfterSeq   int64 `json:"after_seq"`
+}
+
+func observeWaitDuration(timeoutMs int) time.Duration {
+	if timeoutMs <= 0 {
+		return observeWaitDefault
+	}
+	wait := time.Duration(timeoutMs) * time.Millisecond
+	if wait > observeWaitMax {
+		return observeWaitMax
+	}
+	return wait
+}
+
+// waitForObserveData blocks until the change feed records a delta that mode would
+// return under the filters in args, or the timeout ends. The wait starts after the
+// caller's after_seq when given, otherwise after the latest sequence at call time.
+// Returns the wait descriptor for the response, or an error response for modes
+// that cannot wait.
+func (h *ToolHandler) waitForObserveData(req JSONRPCRequest, what string, args json.RawMessage, params observeWaitParams) (map[string]any, *JSONRPCResponse) {
+	match, ok := observe.WaitMatcher(what, args)
+	if !ok {
+		resp := fail(req, ErrInvalidParam, "wait_for_new is not supported for observe mode '"+what+"'",
+			"Drop wait_for_new, or use one of: "+strings.Join(observe.WaitableModes(), ", "), withParam("wait_for_new"))
+		return nil, &resp
+	}
+
+	feed := h.capture.ChangeFeed()
+	after := feed.LatestSeq()
+	if params.AfterSeq > 0 && params.AfterSeq < after {
+		after = params.AfterSeq
+	}
+	timeout := observeWaitDuration(params.TimeoutMs)
+	parent := h.shutdownCtx
+	if parent == nil {
+		parent = context.Background()
+	}
+	ctx, cancel := context.WithTimeout(parent, timeout)
+	defer cancel()
+
+	start := time.Now()
+	seq, found := feed.WaitFor(ctx, after, match)
+	wait := map[string]any{
+		"after_seq":  after,
+		"timeout_ms": timeout.Milliseconds(),
+		"waited_ms":  time.Since(start).Milliseconds(),
+		"timed_out":  !found,
+	}
+	if found {
+		wait["matched_seq"] = seq
+	}
+	return wait, nil
+}
+
+// attachObserveWait adds the wait descriptor to a successful observe response.
+func attachObserveWait(resp JSONRPCResponse, wait map[string]any) JSONRPCResponse {
+	return mutateToolResult(resp, func(r *MCPToolResult) {
+		if r.IsError || len(r.Content) == 0 {
+			return
+		}
+		if r.Metadata == nil {
+			r.Metadata = make(map[string]any)
+		}
+		r.Metadata["wait"] = wait
+		text := r.Content[0].Text
+		jsonStart := strings.IndexByte(text, '{')
+		if jsonStart < 0 {
+			return
+		}
+		var data map[string]any
+		if err := json.Unmarshal([]byte(text[jsonStart:]), &data); err != nil {
+			return
+		}
+		data["wait"] = wait
+		dataJSON, err := json.Marshal(data)
+		if err != nil {
+			return
+		}
+		r.Content[0].Text = text[:jsonStart] + string(dataJSON)
+	})
+}
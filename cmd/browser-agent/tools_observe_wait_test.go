@@ -0,0 +1,101 @@
+// Purpose: Tests observe(wait_for_new=true) long-polls and conditional GET /telemetry reads.
+// Docs: docs/features/feature/observe-long-poll/index.md
+
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+)
+
+func TestObserveWaitForNew_WakesOnMatchingData(t *testing.T) {
+	t.Parallel()
+	h, server, cap := makeToolHandler(t)
+	server.logs.addEntries([]LogEntry{{"level": "error", "message": "old boom"}})
+
+	go func() {
+		time.Sleep(30 * time.Millisecond)
+		server.logs.addEntries([]LogEntry{{"level": "warn", "message": "not an error"}})
+		cap.AddNetworkBodies([]capture.NetworkBody{{Method: "GET", URL: "https://app.test/", Status: 200}})
+		time.Sleep(30 * time.Millisecond)
+		server.logs.addEntries([]LogEntry{{"level": "error", "message": "new boom"}})
+	}()
+
+	req := JSONRPCRequest{JSONRPC: "2.0", ID: 1}
+	start := time.Now()
+	result := parseToolResult(t, h.toolObserve(req, json.RawMessage(`{"what":"errors","wait_for_new":true,"timeout_ms":5000}`)))
+	if result.IsError {
+		t.Fatalf("wait should succeed, got: %s", firstText(result))
+	}
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond || elapsed > 4*time.Second {
+		t.Fatalf("wait returned after %v; want it to block until the new error only", elapsed)
+	}
+	data := extractResultJSON(t, result)
+	wait := data["wait"].(map[string]any)
+	if wait["timed_out"] != false || wait["matched_seq"] != float64(4) || wait["after_seq"] != float64(1) {
+		t.Fatalf("wait = %v", wait)
+	}
+	if !strings.Contains(firstText(result), "new boom") {
+		t.Fatalf("result should include the new error: %s", firstText(result))
+	}
+}
+
+func TestObserveWaitForNew_TimesOutAndRejectsUnsupportedModes(t *testing.T) {
+	t.Parallel()
+	h, _, _ := makeToolHandler(t)
+	req := JSONRPCRequest{JSONRPC: "2.0", ID: 1}
+
+	result := parseToolResult(t, h.toolObserve(req, json.RawMessage(`{"what":"network_bodies","wait_for_new":true,"timeout_ms":20}`)))
+	if result.IsError {
+		t.Fatalf("timed-out wait should still return the mode result, got: %s", firstText(result))
+	}
+	if wait := extractResultJSON(t, result)["wait"].(map[string]any); wait["timed_out"] != true || wait["matched_seq"] != nil {
+		t.Fatalf("wait = %v", wait)
+	}
+
+	result = parseToolResult(t, h.toolObserve(req, json.RawMessage(`{"what":"vitals","wait_for_new":true}`)))
+	if !result.IsError || !strings.Contains(firstText(result), "network_bodies") {
+		t.Fatalf("unsupported mode should fail listing waitable modes, got: %s", firstText(result))
+	}
+
+	if got := observeWaitDuration(10 * 60 * 1000); got != observeWaitMax {
+		t.Fatalf("timeout should clamp to %v, got %v", observeWaitMax, got)
+	}
+}
+
+func TestTelemetry_ETagConditionalRequest(t *testing.T) {
+	t.Parallel()
+	server := newTestServerForHandlers(t)
+	cap := capture.NewCapture()
+	handler := handleTelemetry(server, cap)
+	get := func(etag string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/telemetry?type=network_bodies", nil)
+		if etag != "" {
+			req.Header.Set("If-None-Match", etag)
+		}
+		rr := httptest.NewRecorder()
+		handler(rr, req)
+		return rr
+	}
+
+	first := get("")
+	etag := first.Header().Get("ETag")
+	if first.Code != http.StatusOK || etag == "" {
+		t.Fatalf("first read = %d, etag %q", first.Code, etag)
+	}
+	if rr := get("W/" + etag); rr.Code != http.StatusNotModified || rr.Body.Len() != 0 {
+		t.Fatalf("unchanged buffer = %d with %d body bytes, want 304", rr.Code, rr.Body.Len())
+	}
+
+	cap.AddNetworkBodies([]capture.NetworkBody{{Method: "GET", URL: "https://app.test/", Status: 200}})
+	rr := get(etag)
+	if rr.Code != http.StatusOK || rr.Header().Get("ETag") == etag || !strings.Contains(rr.Body.String(), "app.test") {
+		t.Fatalf("changed buffer = %d etag %q body %s", rr.Code, rr.Header().Get("ETag"), rr.Body.String())
+	}
+}
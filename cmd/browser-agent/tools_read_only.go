@@ -0,0 +1,77 @@
+// Purpose: Read-only mode guard that blocks browser automation and state-mutating configure actions.
+// Why: A server replaying an archived session bundle must never drive a browser or rewrite the imported evidence.
+// Docs: docs/features/feature/read-only-mode/index.md
+
+package main
+
+import (
+	"encoding/json"
+)
+
+// readOnlyState is set once at startup and never toggled at runtime.
+type readOnlyState struct {
+	Reason          string
+	BundlePath      string
+	BundleCreatedAt string
+	BundleLabel     string
+}
+
+// readOnlyConfigureMutations lists configure actions that change session state, capture
+// buffers, or persisted data. Everything else on configure is allowed in read-only mode.
+var readOnlyConfigureMutations = map[string]bool{
+	"store":                 true,
+	"load":                  true,
+	"clear":                 true,
+	"noise_rule":            true,
+	"diff_sessions":         true,
+	"restart":               true,
+	"test_boundary_start":   true,
+	"test_boundary_end":     true,
+	"event_recording_start": true,
+	"event_recording_stop":  true,
+	"playback":              true,
+	"save_sequence":         true,
+	"delete_sequence":       true,
+	"replay_sequence":       true,
+	"security_mode":         true,
+	"network_recording":     true,
+	"action_jitter":         true,
+	"report_issue":          true,
+	"setup_quality_gates":   true,
+	"add_webhook":           true,
+	"remove_webhook":        true,
+}
+
+// requireWritable returns (resp, true) when read-only mode forbids the call.
+// interact is always blocked; configure is blocked for readOnlyConfigureMutations.
+func (h *ToolHandler) requireWritable(req JSONRPCRequest, name string, args json.RawMessage) (JSONRPCResponse, bool) {
+	if h.readOnly == nil {
+		return JSONRPCResponse{}, false
+	}
+	switch name {
+	case "interact":
+	case "configure":
+		var params struct {
+			What   string `json:"what"`
+			Action string `json:"action"`
+		}
+		lenientUnmarshal(args, &params)
+		what := params.What
+		if what == "" {
+			what = params.Action
+		}
+		if !readOnlyConfigureMutations[what] {
+			return JSONRPCResponse{}, false
+		}
+	default:
+		return JSONRPCResponse{}, false
+	}
+	return fail(req, ErrReadOnlyMode,
+		"Interactive and state-changing features are disabled in read-only mode ("+h.readOnly.Reason+").",
+		"Only observe, analyze, generate, and read-only configure actions are available. Restart the server without --serve-bundle to enable mutations.",
+		withRecoveryToolCall(map[string]any{
+			"tool":      "configure",
+			"arguments": map[string]any{"what": "health"},
+		}),
+	), true
+}
@@ -0,0 +1,111 @@
+// Purpose: Tests element-scoped screenshots: clip validation and the server-side crop through POST /screenshots.
+// Docs: docs/features/feature/element-screenshot/index.md
+
+package main
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"image"
+	"image/color"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestScreenshotClip_RejectsBadCombinations(t *testing.T) {
+	t.Parallel()
+	env := newToolTestEnv(t)
+	env.capture.SetTrackingStatusForTest(1, "https://example.com")
+	req := JSONRPCRequest{JSONRPC: "2.0", ID: 1}
+
+	for args, want := range map[string]string{
+		`{"what":"screenshot","clip":true}`:                                  "missing_param",
+		`{"what":"screenshot","selector":"#a","clip":true,"full_page":true}`: "invalid_param",
+	} {
+		result := parseToolResult(t, env.handler.toolObserve(req, json.RawMessage(args)))
+		if !result.IsError || !strings.Contains(firstText(result), want) {
+			t.Fatalf("%s: want %s, got %s", args, want, firstText(result))
+		}
+	}
+}
+
+func TestScreenshotClip_InteractCropsToElement(t *testing.T) {
+	t.Parallel()
+	env := newToolTestEnv(t)
+	env.capture.SetPilotEnabled(true)
+	env.capture.SetTrackingStatusForTest(1, "https://example.com")
+	env.capture.SimulateExtensionConnectForTest()
+	mux, _ := setupHTTPRoutes(env.server, env.capture)
+
+	wait := startToolCall(t, func() JSONRPCResponse {
+		return env.handler.toolInteract(JSONRPCRequest{JSONRPC: "2.0", ID: 1},
+			json.RawMessage(`{"what":"screenshot","selector":"#widget","clip":true}`))
+	})
+
+	q := awaitPendingQuery(t, env.capture, "screenshot")
+	queryID := q.ID
+	var params map[string]any
+	_ = json.Unmarshal(q.Params, &params)
+	if params["clip"] != true || params["selector"] != "#widget" {
+		t.Fatalf("query params = %v, want clip and selector forwarded", params)
+	}
+
+	// A 200x100 capture of a 100px-wide viewport at 2x; the element is CSS (10,5) 30x20.
+	img := image.NewRGBA(image.Rect(0, 0, 200, 100))
+	img.Set(20, 10, color.RGBA{255, 0, 0, 255})
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+	body, _ := json.Marshal(map[string]any{
+		"data_url": "data:image/png;base64," + base64.StdEncoding.EncodeToString(buf.Bytes()),
+		"url":      "https://example.com/",
+		"query_id": queryID,
+		"selector": "#widget",
+		"clip":     map[string]any{"x": 10, "y": 5, "width": 30, "height": 20, "viewport_width": 100, "device_pixel_ratio": 2},
+	})
+	httpReq := httptest.NewRequest(http.MethodPost, "http://localhost/screenshots", bytes.NewReader(body))
+	httpReq.Header.Set("X-Kaboom-Client", "kaboom-extension/clip-test")
+	rr := httptest.NewRecorder()
+	mux.ServeHTTP(rr, httpReq)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("POST /screenshots = %d %s", rr.Code, rr.Body.String())
+	}
+
+	result := parseToolResult(t, wait())
+	if result.IsError {
+		t.Fatalf("clip screenshot failed: %s", firstText(result))
+	}
+	data := extractResultJSON(t, result)
+	clip, _ := data["clip"].(map[string]any)
+	if data["width"] != float64(60) || data["height"] != float64(40) || clip["selector"] != "#widget" {
+		t.Fatalf("result = %v, want a 60x40 crop of #widget", data)
+	}
+	if len(result.Content) < 2 || result.Content[1].Type != "image" || result.Content[1].MimeType != "image/png" {
+		t.Fatalf("expected an inline PNG image block, got %+v", result.Content)
+	}
+}
+
+func TestScreenshotClip_OffscreenElementIsRejected(t *testing.T) {
+	t.Parallel()
+	srv := newTestServerForHandlers(t)
+	mux, _ := setupHTTPRoutes(srv, nil)
+
+	var buf bytes.Buffer
+	_ = png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 10, 10)))
+	body, _ := json.Marshal(map[string]any{
+		"data_url": "data:image/png;base64," + base64.StdEncoding.EncodeToString(buf.Bytes()),
+		"clip":     map[string]any{"x": 50, "y": 50, "width": 5, "height": 5},
+	})
+	httpReq := httptest.NewRequest(http.MethodPost, "http://localhost/screenshots", bytes.NewReader(body))
+	httpReq.Header.Set("X-Kaboom-Client", "kaboom-extension/clip-offscreen")
+	rr := httptest.NewRecorder()
+	mux.ServeHTTP(rr, httpReq)
+	if rr.Code != http.StatusBadRequest || !strings.Contains(rr.Body.String(), "outside the captured viewport") {
+		t.Fatalf("POST /screenshots = %d %s, want 400 for an off-screen clip", rr.Code, rr.Body.String())
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/audit"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/lifecycle"
 )
 
 func (h *ToolHandler) recordAuditToolCall(
@@ -48,6 +49,32 @@ func (h *ToolHandler) recordAuditToolCall(
 	h.auditTrail.Record(entry)
 }
 
+// memoryEvictionAuditClient is the audit client that system-initiated memory evictions are recorded under.
+const memoryEvictionAuditClient = "kaboom"
+
+// recordMemoryEviction records a memory-pressure eviction pass in the audit trail as a
+// memory_eviction entry, so configure(what="audit_log", tool_name="memory_eviction") shows what was shed.
+func (h *ToolHandler) recordMemoryEviction(data map[string]any) {
+	if h == nil || h.auditTrail == nil {
+		return
+	}
+	sessionID := h.auditSessionForClient(memoryEvictionAuditClient)
+	if sessionID == "" {
+		return
+	}
+	params, err := json.Marshal(data)
+	if err != nil {
+		return
+	}
+	h.auditTrail.Record(audit.Entry{
+		AuditSessionID: sessionID,
+		ClientID:       memoryEvictionAuditClient,
+		ToolName:       lifecycle.EventMemoryEviction.String(),
+		Parameters:     string(params),
+		Success:        true,
+	})
+}
+
 func (h *ToolHandler) auditSessionForClient(clientID string) string {
 	if h == nil || h.auditTrail == nil {
 		return ""
@@ -734,4 +734,46 @@ func TestToolCallLimiter_Allow(t *testing.T) {
 	}
 }
 
+func TestToolCallLimiter_PerClientIsolation(t *testing.T) {
+	limiter := NewToolCallLimiter(1, time.Minute)
+
+	if !limiter.Check("agent-a").Allowed {
+		t.Fatal("agent-a first call should be allowed")
+	}
+	if !limiter.Check("agent-b").Allowed {
+		t.Fatal("agent-b should have its own budget")
+	}
+	d := limiter.Check("agent-a")
+	if d.Allowed || d.Scope != rateLimitScopeRate || d.Remaining != 0 {
+		t.Fatalf("agent-a second call = %+v, want rate-limited", d)
+	}
+	if d.RetryAfterMs <= 0 || d.ResetAt.IsZero() {
+		t.Fatalf("decision should carry reset time, got %+v", d)
+	}
+	if usage := limiter.Status().Usage; len(usage) != 2 || usage[0].LimitedCalls != 1 {
+		t.Fatalf("usage = %+v, want agent-a with 1 limited call", usage)
+	}
+}
+
+func TestToolCallLimiter_QuotaAndOverrides(t *testing.T) {
+	limiter := NewToolCallLimiterWithLimits(ToolCallLimits{CallsPerMinute: 10, HourlyQuota: 2}, time.Minute)
+
+	if d := limiter.Check("ci"); !d.Allowed || d.Scope != rateLimitScopeQuota || d.Remaining != 1 {
+		t.Fatalf("first call = %+v, want quota scope with 1 remaining", d)
+	}
+	limiter.Check("ci")
+	if d := limiter.Check("ci"); d.Allowed || d.Scope != rateLimitScopeQuota {
+		t.Fatalf("third call = %+v, want quota exceeded", d)
+	}
+
+	limiter.SetLimits("ci", ToolCallLimits{})
+	if d := limiter.Check("ci"); !d.Allowed || d.Scope != "" {
+		t.Fatalf("unlimited override = %+v, want allowed without scope", d)
+	}
+	limiter.ClearLimits("")
+	if got := limiter.Limits("ci"); got.HourlyQuota != 2 {
+		t.Fatalf("limits after clear = %+v, want startup defaults", got)
+	}
+}
+
 // containsSubstring removed — replaced by strings.Contains at all call sites.
@@ -0,0 +1,69 @@
+// Purpose: Tests that configure test boundaries tag logs, actions, and traffic for boundary-scoped observe/generate.
+// Docs: docs/features/feature/test-boundaries/index.md
+
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+)
+
+func TestTestBoundary_ScopesObserveAndGenerate(t *testing.T) {
+	t.Parallel()
+	h, server, cap := makeToolHandler(t)
+	now := time.Now()
+
+	server.logs.addEntries([]LogEntry{{"level": "error", "message": "before the test", "ts": now.Format(time.RFC3339)}})
+	cap.AddEnhancedActions([]capture.EnhancedAction{{Type: "navigate", Timestamp: now.UnixMilli(), URL: "https://shop.example.com", ToURL: "https://shop.example.com/home"}})
+
+	start := parseToolResult(t, callConfigureRaw(h, `{"what":"test_boundary_start","test_id":"checkout"}`))
+	if start.IsError {
+		t.Fatalf("test_boundary_start failed: %s", firstText(start))
+	}
+
+	server.logs.addEntries([]LogEntry{{"level": "error", "message": "payment declined", "ts": now.Format(time.RFC3339)}})
+	cap.AddEnhancedActions([]capture.EnhancedAction{{Type: "navigate", Timestamp: now.UnixMilli() + 1, URL: "https://shop.example.com/home", ToURL: "https://shop.example.com/checkout"}})
+	cap.AddNetworkBodies([]capture.NetworkBody{{URL: "https://shop.example.com/api/pay", Method: "POST", Status: 402}})
+
+	end := parseToolResult(t, callConfigureRaw(h, `{"what":"test_boundary_end","test_id":"checkout"}`))
+	if end.IsError {
+		t.Fatalf("test_boundary_end failed: %s", firstText(end))
+	}
+	summary, ok := extractResultJSON(t, end)["summary"].(map[string]any)
+	if !ok {
+		t.Fatal("test_boundary_end should carry a boundary summary")
+	}
+	for key, want := range map[string]float64{"errors": 1, "actions": 1, "failed_requests": 1} {
+		if summary[key] != want {
+			t.Errorf("summary[%s] = %v, want %v", key, summary[key], want)
+		}
+	}
+
+	// Entries captured after the boundary ended stay out of it.
+	server.logs.addEntries([]LogEntry{{"level": "error", "message": "after the test", "ts": now.Format(time.RFC3339)}})
+
+	errs := parseToolResult(t, h.toolObserve(JSONRPCRequest{JSONRPC: "2.0", ID: 1}, json.RawMessage(`{"what":"errors","boundary":"checkout"}`)))
+	if errs.IsError {
+		t.Fatalf("observe errors failed: %s", firstText(errs))
+	}
+	text := firstText(errs)
+	if !strings.Contains(text, "payment declined") || strings.Contains(text, "before the test") || strings.Contains(text, "after the test") {
+		t.Fatalf("boundary-scoped errors should hold only the in-test error:\n%s", text)
+	}
+
+	repro := parseToolResult(t, h.toolGenerate(JSONRPCRequest{JSONRPC: "2.0", ID: 2}, json.RawMessage(`{"what":"reproduction","boundary":"checkout"}`)))
+	if repro.IsError {
+		t.Fatalf("generate reproduction failed: %s", firstText(repro))
+	}
+	data := extractResultJSON(t, repro)
+	if data["action_count"] != float64(1) {
+		t.Fatalf("action_count = %v, want 1 (only the in-test navigation)", data["action_count"])
+	}
+	if meta, _ := data["metadata"].(map[string]any); meta["boundary"] != "checkout" {
+		t.Fatalf("metadata.boundary = %v, want checkout", meta["boundary"])
+	}
+}
@@ -11,8 +11,13 @@ import (
 	"regexp"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/analysis"
 	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/findings"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/queries"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/sessionbundle"
 )
 
 // ============================================
@@ -33,6 +38,12 @@ func makeToolHandler(t *testing.T) (*ToolHandler, *Server, *capture.Store) {
 	cap.SetPilotEnabled(false) // keep legacy test default: explicitly disabled unless test opts in
 	mcpHandler := NewToolHandler(server, cap)
 	handler := mcpHandler.toolHandler.(*ToolHandler)
+	// Cluster and finding history persist under the working directory's project store;
+	// start each test from empty history so earlier runs cannot raise regression alerts.
+	handler.errorClusterHistory = analysis.NewErrorClusterHistory(time.Now())
+	handler.findingTracker = findings.NewTracker()
+	// clear and shutdown archive the session; keep archives out of the real state directory.
+	handler.sessionArchive = sessionbundle.NewArchive(t.TempDir())
 	return handler, server, cap
 }
 
@@ -59,6 +70,7 @@ func newToolTestEnv(t *testing.T) *toolTestEnv {
 	cap.SetPilotEnabled(false) // keep legacy test default: explicitly disabled unless test opts in
 	mcpHandler := NewToolHandler(server, cap)
 	handler := mcpHandler.toolHandler.(*ToolHandler)
+	handler.sessionArchive = sessionbundle.NewArchive(t.TempDir())
 	return &toolTestEnv{handler: handler, server: server, capture: cap}
 }
 
@@ -192,6 +204,69 @@ func callInteractRaw(h *ToolHandler, argsJSON string) JSONRPCResponse {
 	return h.toolInteract(req, normalizeInteractArgsForAsync(argsJSON))
 }
 
+// ============================================
+// Extension Query Round Trips
+// ============================================
+
+// extensionQueryWait bounds how long round-trip helpers wait for the handler.
+const extensionQueryWait = 5 * time.Second
+
+// startToolCall runs call on its own goroutine, for handlers that block until the
+// extension answers a query. The returned wait fails the test if call has not returned.
+func startToolCall(t *testing.T, call func() JSONRPCResponse) (wait func() JSONRPCResponse) {
+	t.Helper()
+	done := make(chan JSONRPCResponse, 1)
+	go func() { done <- call() }()
+	return func() JSONRPCResponse {
+		t.Helper()
+		select {
+		case resp := <-done:
+			return resp
+		case <-time.After(extensionQueryWait):
+			t.Fatal("tool call did not return")
+			return JSONRPCResponse{}
+		}
+	}
+}
+
+// awaitPendingQuery blocks until a query of queryType is queued and returns it.
+// It wakes on PendingQueryAdded rather than polling the queue.
+func awaitPendingQuery(t *testing.T, cap *capture.Store, queryType string) queries.PendingQueryResponse {
+	t.Helper()
+	deadline := time.After(extensionQueryWait)
+	for {
+		// Take the signal before reading the queue so an enqueue in between still wakes us.
+		added := cap.PendingQueryAdded()
+		for _, q := range cap.GetPendingQueries() {
+			if q.Type == queryType {
+				return q
+			}
+		}
+		select {
+		case <-added:
+		case <-deadline:
+			t.Fatalf("no %s query was queued", queryType)
+		}
+	}
+}
+
+// answerPendingQuery waits for a query of queryType, answers it with result as the
+// extension would, and returns the query's decoded params.
+func answerPendingQuery(t *testing.T, cap *capture.Store, queryType string, result any) map[string]any {
+	t.Helper()
+	q := awaitPendingQuery(t, cap, queryType)
+	var params map[string]any
+	if err := json.Unmarshal(q.Params, &params); err != nil {
+		t.Fatalf("%s params: %v", queryType, err)
+	}
+	raw, err := json.Marshal(result)
+	if err != nil {
+		t.Fatalf("%s result: %v", queryType, err)
+	}
+	cap.SetQueryResult(q.ID, raw)
+	return params
+}
+
 // ============================================
 // Async Normalization Helpers
 // ============================================
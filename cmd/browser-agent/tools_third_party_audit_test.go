@@ -0,0 +1,59 @@
+// Purpose: Tests observe(third_party_audit) baseline drift and generate(third_party_report) markdown output.
+// Docs: docs/features/feature/enterprise-audit/index.md
+
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/state"
+)
+
+func TestThirdPartyAudit_BaselineThenReportShowsDrift(t *testing.T) {
+	t.Setenv(state.StateDirEnv, t.TempDir())
+	h, _, cap := makeToolHandler(t)
+	cap.AddNetworkBodies([]capture.NetworkBody{{
+		Method: "GET", URL: "https://cdn.jsdelivr.net/npm/chart.js@4.4.0/dist/chart.umd.js", Status: 200,
+		ContentType: "application/javascript", ResponseBody: "chart-v1",
+	}})
+
+	result := parseToolResult(t, callToolRaw(h, "observe", `{"what":"third_party_audit","first_party_origins":["https://app.test"],"update_baseline":true}`))
+	if result.IsError {
+		t.Fatalf("third_party_audit should succeed, got: %s", firstText(result))
+	}
+	data := extractResultJSON(t, result)
+	baseline, ok := data["baseline"].(map[string]any)
+	if !ok || baseline["updated"] != true {
+		t.Fatalf("baseline should be stored: %v", data["baseline"])
+	}
+
+	cap.AddNetworkBodies([]capture.NetworkBody{{
+		Method: "GET", URL: "https://cdn.jsdelivr.net/npm/chart.js@4.4.1/dist/chart.umd.js", Status: 200,
+		ContentType: "application/javascript", ResponseBody: "chart-v2",
+	}})
+	report := parseToolResult(t, callGenerateRaw(h, `{"what":"third_party_report","first_party_origins":["https://app.test"]}`))
+	if report.IsError {
+		t.Fatalf("third_party_report should succeed, got: %s", firstText(report))
+	}
+	text := firstText(report)
+	for _, want := range []string{"Supply-Chain Report", "`added`", "chart.js@4.4.1", "semver 4.4.0"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("report missing %q:\n%s", want, text)
+		}
+	}
+}
+
+func TestThirdPartyReport_EmptyCaptureIsUnavailable(t *testing.T) {
+	t.Parallel()
+	h, _, _ := makeToolHandler(t)
+
+	result := parseToolResult(t, callGenerateRaw(h, `{"what":"third_party_report"}`))
+	if result.IsError {
+		t.Fatalf("empty capture should not be an error: %s", firstText(result))
+	}
+	if data := extractResultJSON(t, result); data["status"] != "unavailable" {
+		t.Fatalf("status = %v, want unavailable", data["status"])
+	}
+}
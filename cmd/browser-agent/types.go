@@ -30,3 +30,4 @@ type MCPResourceContent = mcp.MCPResourceContent
 type MCPResourcesReadResult = mcp.MCPResourcesReadResult
 type MCPToolsListResult = mcp.MCPToolsListResult
 type MCPResourceTemplatesListResult = mcp.MCPResourceTemplatesListResult
+type MCPCompleteResult = mcp.MCPCompleteResult
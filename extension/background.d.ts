@@ -10,7 +10,7 @@ export { RATE_LIMIT_CONFIG } from './background/communication.js';
 export { EXTENSION_SESSION_ID, getServerUrl, isDebugMode, getConnectionStatus, getCurrentLogLevel, isScreenshotOnError, getExtensionLogQueue } from './background/state.js';
 export { DebugCategory } from './background/index.js';
 export { debugLog, getDebugLog, clearDebugLog, exportDebugLog } from './background/index.js';
-export { sharedServerCircuitBreaker, logBatcher, wsBatcher, enhancedActionBatcher, networkBodyBatcher, perfBatcher } from './background/index.js';
+export { sharedServerCircuitBreaker, logBatcher, wsBatcher, sseBatcher, enhancedActionBatcher, networkBodyBatcher, perfBatcher } from './background/index.js';
 export { handleLogMessage, handleClearLogs, isConnectionCheckRunning, checkConnectionAndUpdate } from './background/index.js';
 export { applyCaptureOverrides } from './background/state.js';
 export { sendStatusPingWrapper } from './background/index.js';
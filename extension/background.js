@@ -40,7 +40,7 @@ export { debugLog, getDebugLog, clearDebugLog, exportDebugLog } from './backgrou
 // =============================================================================
 // === PUBLIC API: BATCHERS & CIRCUIT BREAKER
 // =============================================================================
-export { sharedServerCircuitBreaker, logBatcher, wsBatcher, enhancedActionBatcher, networkBodyBatcher, perfBatcher } from './background/index.js';
+export { sharedServerCircuitBreaker, logBatcher, wsBatcher, sseBatcher, enhancedActionBatcher, networkBodyBatcher, perfBatcher } from './background/index.js';
 // =============================================================================
 // === PUBLIC API: CORE HANDLERS
 // =============================================================================
@@ -1,9 +1,9 @@
 /**
- * Purpose: Creates concrete batcher instances for each telemetry data type (logs, WebSocket, actions, network bodies, performance).
+ * Purpose: Creates concrete batcher instances for each telemetry data type (logs, WebSocket, SSE, actions, network bodies, performance).
  * Why: Isolates batcher wiring from business logic in index.ts to keep module initialization explicit.
  * Docs: docs/features/feature/backend-log-streaming/index.md
  */
-import type { LogEntry, WebSocketEvent, NetworkBodyPayload, EnhancedAction, PerformanceSnapshot } from '../types/index.js';
+import type { LogEntry, WebSocketEvent, WireSSEEvent, NetworkBodyPayload, EnhancedAction, PerformanceSnapshot } from '../types/index.js';
 import { type CircuitBreaker, type BatcherWithCircuitBreaker, type Batcher } from './communication.js';
 /** Mutable connection status passed in from the state owner */
 export interface ConnectionStatusRef {
@@ -30,6 +30,8 @@ export interface BatcherInstances {
     logBatcher: Batcher<LogEntry>;
     wsBatcherWithCB: BatcherWithCircuitBreaker<WebSocketEvent>;
     wsBatcher: Batcher<WebSocketEvent>;
+    sseBatcherWithCB: BatcherWithCircuitBreaker<WireSSEEvent>;
+    sseBatcher: Batcher<WireSSEEvent>;
     enhancedActionBatcherWithCB: BatcherWithCircuitBreaker<EnhancedAction>;
     enhancedActionBatcher: Batcher<EnhancedAction>;
     networkBodyBatcherWithCB: BatcherWithCircuitBreaker<NetworkBodyPayload>;
@@ -1,9 +1,9 @@
 /**
- * Purpose: Creates concrete batcher instances for each telemetry data type (logs, WebSocket, actions, network bodies, performance).
+ * Purpose: Creates concrete batcher instances for each telemetry data type (logs, WebSocket, SSE, actions, network bodies, performance).
  * Why: Isolates batcher wiring from business logic in index.ts to keep module initialization explicit.
  * Docs: docs/features/feature/backend-log-streaming/index.md
  */
-import { updateBadge, createBatcherWithCircuitBreaker, sendLogsToServer, sendWSEventsToServer, sendEnhancedActionsToServer, sendNetworkBodiesToServer, sendPerformanceSnapshotsToServer } from './communication.js';
+import { updateBadge, createBatcherWithCircuitBreaker, sendLogsToServer, sendWSEventsToServer, sendSSEEventsToServer, sendEnhancedActionsToServer, sendNetworkBodiesToServer, sendPerformanceSnapshotsToServer } from './communication.js';
 import { checkContextAnnotations } from './state-manager.js';
 // =============================================================================
 // CONNECTION STATUS WRAPPER
@@ -42,6 +42,7 @@ export function createBatcherInstances(deps, sharedCircuitBreaker) {
         });
     }), { sharedCircuitBreaker });
     const wsBatcherWithCB = createBatcherWithCircuitBreaker(withConnectionStatus(deps, (events) => sendWSEventsToServer(deps.getServerUrl(), events, deps.debugLog)), { debounceMs: 200, maxBatchSize: 100, sharedCircuitBreaker });
+    const sseBatcherWithCB = createBatcherWithCircuitBreaker(withConnectionStatus(deps, (events) => sendSSEEventsToServer(deps.getServerUrl(), events, deps.debugLog)), { debounceMs: 200, maxBatchSize: 100, sharedCircuitBreaker });
     const enhancedActionBatcherWithCB = createBatcherWithCircuitBreaker(withConnectionStatus(deps, (actions) => sendEnhancedActionsToServer(deps.getServerUrl(), actions, deps.debugLog)), { debounceMs: 200, maxBatchSize: 50, sharedCircuitBreaker });
     const networkBodyBatcherWithCB = createBatcherWithCircuitBreaker(withConnectionStatus(deps, (bodies) => sendNetworkBodiesToServer(deps.getServerUrl(), bodies, deps.debugLog)), { debounceMs: 200, maxBatchSize: 50, sharedCircuitBreaker });
     const perfBatcherWithCB = createBatcherWithCircuitBreaker(withConnectionStatus(deps, (snapshots) => sendPerformanceSnapshotsToServer(deps.getServerUrl(), snapshots, deps.debugLog)), { debounceMs: 500, maxBatchSize: 10, sharedCircuitBreaker });
@@ -50,6 +51,8 @@ export function createBatcherInstances(deps, sharedCircuitBreaker) {
         logBatcher: logBatcherWithCB.batcher,
         wsBatcherWithCB,
         wsBatcher: wsBatcherWithCB.batcher,
+        sseBatcherWithCB,
+        sseBatcher: sseBatcherWithCB.batcher,
         enhancedActionBatcherWithCB,
         enhancedActionBatcher: enhancedActionBatcherWithCB.batcher,
         networkBodyBatcherWithCB,
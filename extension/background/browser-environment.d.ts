@@ -0,0 +1,25 @@
+/** Browser metadata sent as settings.environment on /sync. */
+export interface BrowserEnvironment {
+    user_agent: string;
+    locale: string;
+    timezone: string;
+    viewport_width?: number;
+    viewport_height?: number;
+    device_pixel_ratio?: number;
+    extension_version: string;
+}
+type PageEnvironment = Omit<BrowserEnvironment, 'extension_version'>;
+/**
+ * Self-contained: read the page's view of the browser environment.
+ * MUST remain self-contained — Chrome serializes the function source only (no closures).
+ */
+export declare function pageEnvironmentProbe(): PageEnvironment;
+/**
+ * Browser environment for the tracked tab, cached per tab and URL and refreshed every
+ * 30s so resizes are picked up without probing the page on every sync.
+ */
+export declare function getBrowserEnvironment(tabId: number | null | undefined, url: string | null | undefined, extensionVersion: string): Promise<BrowserEnvironment>;
+/** Forget the cached measurement (tests, tracked tab changes). */
+export declare function resetBrowserEnvironmentCache(): void;
+export {};
+//# sourceMappingURL=browser-environment.d.ts.map
@@ -4,10 +4,10 @@
  *      CDP Input.dispatch* commands produce true hardware events indistinguishable from real user input.
  * Docs: docs/features/feature/interact-explore/index.md
  */
-import { CDP_VERSION } from '../lib/constants.js';
 import { errorMessage } from '../lib/error-utils.js';
 import { KEY_CODES, charToKeyInfo } from './cdp-key-mappings.js';
 import { resolveElement, buildCDPResult } from './cdp-element-resolve.js';
+import { attachDebugger, detachDebugger, holdPassthroughDebugger, releasePassthroughDebugger } from './device-emulation.js';
 async function cdpSend(tabId, method, params) {
     await chrome.debugger.sendCommand({ tabId }, method, params);
 }
@@ -135,6 +135,20 @@ async function cdpKeyPress(tabId, params) {
         method: 'cdp'
     };
 }
+// Raw command from interact(what="cdp"). The daemon has already applied the operator
+// policy; the result is returned as-is and redacted server-side.
+async function cdpPassthrough(tabId, params) {
+    if (!params.method) {
+        throw new Error('passthrough requires method parameter');
+    }
+    const result = await chrome.debugger.sendCommand({ tabId }, params.method, params.params || {});
+    return {
+        success: true,
+        action: 'cdp',
+        method: params.method,
+        result: result ?? {}
+    };
+}
 function parseCDPParams(query) {
     try {
         const raw = typeof query.params === 'string' ? JSON.parse(query.params) : query.params;
@@ -285,7 +299,7 @@ export async function tryCDPEscalation(tabId, action, params) {
         if (!resolved)
             return null;
         // Step 2: Attach debugger
-        await chrome.debugger.attach({ tabId }, CDP_VERSION);
+        await attachDebugger(tabId);
         try {
             // Step 3: Execute CDP action
             if (action === 'click') {
@@ -322,12 +336,7 @@ export async function tryCDPEscalation(tabId, action, params) {
             return buildCDPResult(action, selector, resolved, Date.now() - startTime);
         }
         finally {
-            try {
-                await chrome.debugger.detach({ tabId });
-            }
-            catch {
-                /* already detached */
-            }
+            await detachDebugger(tabId);
         }
     }
     catch {
@@ -336,7 +345,7 @@ export async function tryCDPEscalation(tabId, action, params) {
     }
 }
 // =============================================================================
-// DIRECT CDP QUERIES (hardware_click via Go-side cdp_action)
+// DIRECT CDP QUERIES (hardware_click and cdp passthrough via Go-side cdp_action)
 // =============================================================================
 export async function executeCDPAction(query, tabId, syncClient, sendAsyncResult, actionToast) {
     const params = parseCDPParams(query);
@@ -349,10 +358,29 @@ export async function executeCDPAction(query, tabId, syncClient, sendAsyncResult
         sendAsyncResult(syncClient, query.id, query.correlation_id, 'error', null, 'missing_action');
         return;
     }
-    const toastLabel = action === 'key_press' ? 'Typing...' : `CDP ${action}`;
+    const toastLabel = action === 'key_press' ? 'Typing...' : action === 'passthrough' ? `CDP ${params.method}` : `CDP ${action}`;
     actionToast(tabId, toastLabel, undefined, 'trying', 10000);
+    if (action === 'release') {
+        try {
+            const released = await releasePassthroughDebugger(tabId);
+            actionToast(tabId, toastLabel, undefined, 'success');
+            sendAsyncResult(syncClient, query.id, query.correlation_id, 'complete', {
+                success: true,
+                action: 'cdp',
+                released
+            });
+        }
+        catch (err) {
+            const errorMsg = mapCDPError(err);
+            actionToast(tabId, toastLabel, errorMsg, 'error');
+            sendAsyncResult(syncClient, query.id, query.correlation_id, 'error', null, errorMsg);
+        }
+        return;
+    }
+    // Passthrough holds its session so state it sets survives until an explicit release.
+    const passthrough = action === 'passthrough';
     try {
-        await chrome.debugger.attach({ tabId }, CDP_VERSION);
+        await (passthrough ? holdPassthroughDebugger(tabId) : attachDebugger(tabId));
     }
     catch (err) {
         const errorMsg = mapCDPError(err);
@@ -372,6 +400,9 @@ export async function executeCDPAction(query, tabId, syncClient, sendAsyncResult
             case 'key_press':
                 result = await cdpKeyPress(tabId, params);
                 break;
+            case 'passthrough':
+                result = await cdpPassthrough(tabId, params);
+                break;
             default:
                 throw new Error(`Unknown CDP action: ${action}`);
         }
@@ -384,12 +415,8 @@ export async function executeCDPAction(query, tabId, syncClient, sendAsyncResult
         sendAsyncResult(syncClient, query.id, query.correlation_id, 'error', null, errorMsg);
     }
     finally {
-        try {
-            await chrome.debugger.detach({ tabId });
-        }
-        catch {
-            // Already detached or tab closed — safe to ignore
-        }
+        if (!passthrough)
+            await detachDebugger(tabId);
     }
 }
 //# sourceMappingURL=cdp-dispatch.js.map
\ No newline at end of file
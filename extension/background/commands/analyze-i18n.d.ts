@@ -0,0 +1,32 @@
+interface I18nTextNode {
+    selector: string;
+    tag: string;
+    text: string;
+    attr?: string;
+    lang: string;
+    direction: string;
+    dir_attr: string;
+    text_align: string;
+    marked: boolean;
+    no_translate: boolean;
+}
+interface I18nProbeResult {
+    url: string;
+    title: string;
+    html_lang: string;
+    html_dir: string;
+    body_direction: string;
+    marker_count: number;
+    text_nodes: I18nTextNode[];
+    total_nodes: number;
+    truncated: boolean;
+    error?: string;
+    message?: string;
+}
+/**
+ * Self-contained probe injected via chrome.scripting.executeScript.
+ * MUST remain self-contained — Chrome serializes the function source only (no closures).
+ */
+export declare function i18nScanProbe(markers: string[]): I18nProbeResult;
+export {};
+//# sourceMappingURL=analyze-i18n.d.ts.map
@@ -0,0 +1,122 @@
+// analyze-i18n.ts — Localization probe for analyze(what="i18n").
+// Collects visible text, translatable attributes, lang/dir context, and i18n marker
+// coverage; the server classifies them into hardcoded, untranslated, mixed-language, and RTL findings.
+import { registerCommand } from './registry.js';
+import { errorMessage } from '../../lib/error-utils.js';
+/**
+ * Self-contained probe injected via chrome.scripting.executeScript.
+ * MUST remain self-contained — Chrome serializes the function source only (no closures).
+ */
+export function i18nScanProbe(markers) {
+    const MAX_NODES = 800;
+    const MAX_TEXT = 200;
+    const SKIP_TAGS = new Set(['SCRIPT', 'STYLE', 'NOSCRIPT', 'TEMPLATE', 'CODE', 'PRE', 'KBD', 'SAMP', 'SVG', 'MATH']);
+    const ATTRS = ['placeholder', 'title', 'alt', 'aria-label'];
+    function describe(el) {
+        if (el.id)
+            return `#${el.id}`;
+        const tag = el.tagName.toLowerCase();
+        const classes = Array.from(el.classList)
+            .slice(0, 3)
+            .map((c) => `.${c}`)
+            .join('');
+        return tag + classes;
+    }
+    const validMarkers = markers.filter((m) => /^[A-Za-z][\w:.-]*$/.test(m));
+    const markerSelector = validMarkers.map((m) => `[${m.replace(/[:.]/g, '\\$&')}]`).join(',');
+    const markedCache = new Map();
+    function isMarked(el) {
+        if (!markerSelector)
+            return false;
+        let marked = markedCache.get(el);
+        if (marked === undefined) {
+            marked = el.closest(markerSelector) !== null;
+            markedCache.set(el, marked);
+        }
+        return marked;
+    }
+    const root = document.documentElement;
+    const result = {
+        url: location.href,
+        title: document.title,
+        html_lang: root.getAttribute('lang') || '',
+        html_dir: root.getAttribute('dir') || '',
+        body_direction: document.body ? window.getComputedStyle(document.body).direction : '',
+        marker_count: markerSelector ? document.querySelectorAll(markerSelector).length : 0,
+        text_nodes: [],
+        total_nodes: 0,
+        truncated: false
+    };
+    function push(el, text, attr) {
+        result.total_nodes++;
+        if (result.text_nodes.length >= MAX_NODES) {
+            result.truncated = true;
+            return;
+        }
+        const style = window.getComputedStyle(el);
+        const node = {
+            selector: describe(el),
+            tag: el.tagName.toLowerCase(),
+            text: text.length > MAX_TEXT ? text.slice(0, MAX_TEXT) : text,
+            lang: el.closest('[lang]')?.getAttribute('lang') || '',
+            direction: style.direction,
+            dir_attr: el.closest('[dir]')?.getAttribute('dir') || '',
+            text_align: style.textAlign,
+            marked: isMarked(el),
+            no_translate: el.closest('[translate="no"],.notranslate') !== null
+        };
+        if (attr)
+            node.attr = attr;
+        result.text_nodes.push(node);
+    }
+    if (!document.body)
+        return result;
+    const elements = document.body.querySelectorAll('*');
+    for (const el of Array.from(elements)) {
+        if (SKIP_TAGS.has(el.tagName.toUpperCase()) || el.closest('script,style,noscript,template,code,pre'))
+            continue;
+        if (el.getClientRects().length === 0)
+            continue;
+        let text = '';
+        for (const child of Array.from(el.childNodes)) {
+            if (child.nodeType === Node.TEXT_NODE)
+                text += child.textContent || '';
+        }
+        text = text.replace(/\s+/g, ' ').trim();
+        if (text)
+            push(el, text);
+        for (const attr of ATTRS) {
+            const value = (el.getAttribute(attr) || '').trim();
+            if (value)
+                push(el, value, attr);
+        }
+    }
+    return result;
+}
+registerCommand('i18n_scan', async (ctx) => {
+    const params = ctx.params;
+    try {
+        const results = await chrome.scripting.executeScript({
+            target: { tabId: ctx.tabId },
+            world: 'ISOLATED',
+            func: i18nScanProbe,
+            args: [Array.isArray(params.markers) ? params.markers : []]
+        });
+        const result = results?.[0]?.result;
+        if (!result) {
+            ctx.sendResult({
+                error: 'i18n_scan_failed',
+                message: 'i18n probe returned no result'
+            });
+            return;
+        }
+        ctx.sendResult(result);
+    }
+    catch (err) {
+        ctx.sendResult({
+            error: 'i18n_scan_failed',
+            message: errorMessage(err, 'i18n probe failed')
+        });
+    }
+});
+//# sourceMappingURL=analyze-i18n.js.map
@@ -0,0 +1,31 @@
+interface LayoutNode {
+    tag: string;
+    selector: string;
+    styles: Record<string, string>;
+    rect: {
+        x: number;
+        y: number;
+        width: number;
+        height: number;
+    };
+}
+interface LayoutProbeResult {
+    match_count: number;
+    viewport: {
+        width: number;
+        height: number;
+        scroll_x: number;
+        scroll_y: number;
+    };
+    element: LayoutNode | null;
+    ancestors: LayoutNode[];
+    error?: string;
+    message?: string;
+}
+/**
+ * Self-contained probe injected via chrome.scripting.executeScript.
+ * MUST remain self-contained — Chrome serializes the function source only (no closures).
+ */
+export declare function layoutInspectProbe(selector: string, properties: string[]): LayoutProbeResult;
+export {};
+//# sourceMappingURL=analyze-styles.d.ts.map
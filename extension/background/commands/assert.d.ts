@@ -0,0 +1,23 @@
+export interface AssertElementCheck {
+    selector: string;
+    text_contains?: string;
+}
+export interface AssertElementResult {
+    selector: string;
+    count: number;
+    visible_count: number;
+    texts?: string[];
+    text_match?: string;
+    error?: string;
+}
+export interface AssertPageSnapshot {
+    url: string;
+    title: string;
+    elements: AssertElementResult[];
+}
+/**
+ * Evaluate selector checks against the live DOM and read URL and title.
+ * MUST remain self-contained — Chrome serializes the function source only (no closures).
+ */
+export declare function assertPageProbe(checks: AssertElementCheck[]): AssertPageSnapshot;
+//# sourceMappingURL=assert.d.ts.map
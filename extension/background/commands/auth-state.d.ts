@@ -0,0 +1,33 @@
+/** Cookie as stored in the vault (snake_case, like the rest of the daemon protocol). */
+export interface VaultCookie {
+    name: string;
+    value: string;
+    domain: string;
+    path: string;
+    secure?: boolean;
+    http_only?: boolean;
+    host_only?: boolean;
+    same_site?: string;
+    expiration_date?: number;
+}
+export interface WebStorageSnapshot {
+    local_storage: Record<string, string>;
+    session_storage: Record<string, string>;
+}
+/**
+ * Read every localStorage and sessionStorage key, injected in the MAIN world.
+ * MUST remain self-contained — Chrome serializes the function source only (no closures).
+ */
+export declare function readWebStorageProbe(): WebStorageSnapshot;
+/**
+ * Write saved storage entries, injected in the MAIN world. Returns how many keys were written.
+ * MUST remain self-contained — Chrome serializes the function source only (no closures).
+ */
+export declare function writeWebStorageProbe(local: Record<string, string>, session: Record<string, string>): {
+    local_storage: number;
+    session_storage: number;
+};
+export declare function toVaultCookie(cookie: chrome.cookies.Cookie): VaultCookie;
+/** chrome.cookies.set details for a saved cookie, or null when it has already expired. */
+export declare function toCookieDetails(cookie: VaultCookie, nowSeconds: number): chrome.cookies.SetDetails | null;
+//# sourceMappingURL=auth-state.d.ts.map
@@ -0,0 +1,191 @@
+// auth-state.ts — Reads and writes a tab's full login state for save_state/load_state vault=true.
+// Cookies come from chrome.cookies so HttpOnly ones are included; web storage is read in the
+// page. The daemon encrypts what is captured, so nothing here is redacted.
+import { registerCommand } from './registry.js';
+import { requireAiWebPilot } from './helpers.js';
+import { errorMessage } from '../../lib/error-utils.js';
+// =============================================================================
+// PAGE PROBES
+// =============================================================================
+/**
+ * Read every localStorage and sessionStorage key, injected in the MAIN world.
+ * MUST remain self-contained — Chrome serializes the function source only (no closures).
+ */
+export function readWebStorageProbe() {
+    const read = (storage) => {
+        const out = {};
+        for (let i = 0; i < storage.length; i++) {
+            const key = storage.key(i);
+            if (key !== null)
+                out[key] = storage.getItem(key) ?? '';
+        }
+        return out;
+    };
+    return { local_storage: read(localStorage), session_storage: read(sessionStorage) };
+}
+/**
+ * Write saved storage entries, injected in the MAIN world. Returns how many keys were written.
+ * MUST remain self-contained — Chrome serializes the function source only (no closures).
+ */
+export function writeWebStorageProbe(local, session) {
+    const write = (storage, entries) => {
+        let written = 0;
+        for (const [key, value] of Object.entries(entries)) {
+            try {
+                storage.setItem(key, value);
+                written++;
+            }
+            catch {
+                // Quota exceeded — keep the rest
+            }
+        }
+        return written;
+    };
+    return { local_storage: write(localStorage, local || {}), session_storage: write(sessionStorage, session || {}) };
+}
+// =============================================================================
+// COOKIES
+// =============================================================================
+export function toVaultCookie(cookie) {
+    return {
+        name: cookie.name,
+        value: cookie.value,
+        domain: cookie.domain,
+        path: cookie.path,
+        secure: cookie.secure,
+        http_only: cookie.httpOnly,
+        host_only: cookie.hostOnly,
+        same_site: cookie.sameSite,
+        ...(cookie.expirationDate ? { expiration_date: cookie.expirationDate } : {})
+    };
+}
+/** chrome.cookies.set details for a saved cookie, or null when it has already expired. */
+export function toCookieDetails(cookie, nowSeconds) {
+    if (cookie.expiration_date && cookie.expiration_date <= nowSeconds)
+        return null;
+    const host = cookie.domain.replace(/^\./, '');
+    const path = cookie.path || '/';
+    return {
+        url: `${cookie.secure ? 'https' : 'http'}://${host}${path}`,
+        name: cookie.name,
+        value: cookie.value,
+        path,
+        // Host-only cookies must be set without a domain, or Chrome widens them to subdomains.
+        ...(cookie.host_only ? {} : { domain: cookie.domain }),
+        secure: cookie.secure === true,
+        httpOnly: cookie.http_only === true,
+        ...(cookie.same_site ? { sameSite: cookie.same_site } : {}),
+        ...(cookie.expiration_date ? { expirationDate: cookie.expiration_date } : {})
+    };
+}
+/** Cookies the page sends plus those set for other paths on its host, without duplicates. */
+async function readCookies(url) {
+    const byPage = await chrome.cookies.getAll({ url });
+    const byHost = await chrome.cookies.getAll({ domain: new URL(url).hostname });
+    const seen = new Set();
+    const cookies = [];
+    for (const cookie of [...byPage, ...byHost]) {
+        const id = `${cookie.name}|${cookie.domain}|${cookie.path}`;
+        if (seen.has(id))
+            continue;
+        seen.add(id);
+        cookies.push(toVaultCookie(cookie));
+    }
+    return cookies;
+}
+// =============================================================================
+// CAPTURE / RESTORE
+// =============================================================================
+async function pageOf(tabId) {
+    const tab = await chrome.tabs.get(tabId);
+    try {
+        const url = new URL(tab.url || '');
+        if (url.protocol === 'http:' || url.protocol === 'https:')
+            return { url: url.href, origin: url.origin };
+    }
+    catch {
+        // Not a URL — treated as unsupported
+    }
+    return null;
+}
+async function captureAuthState(tabId) {
+    const page = await pageOf(tabId);
+    if (!page)
+        return { error: 'unsupported_page', message: 'Auth state can only be saved from an http(s) page' };
+    const cookies = await readCookies(page.url);
+    const results = await chrome.scripting.executeScript({
+        target: { tabId },
+        world: 'MAIN',
+        func: readWebStorageProbe
+    });
+    const storage = results?.[0]?.result || { local_storage: {}, session_storage: {} };
+    return { url: page.url, origin: page.origin, cookies, ...storage };
+}
+async function restoreAuthState(tabId, params) {
+    const page = await pageOf(tabId);
+    if (!page || page.origin !== params.origin) {
+        return {
+            error: 'origin_mismatch',
+            message: `Navigate the tracked tab to ${params.origin} before loading this state (current: ${page?.origin || 'not an http(s) page'})`
+        };
+    }
+    const now = Date.now() / 1000;
+    let cookies = 0;
+    let skipped = 0;
+    for (const cookie of params.cookies || []) {
+        const details = toCookieDetails(cookie, now);
+        try {
+            if (details && (await chrome.cookies.set(details))) {
+                cookies++;
+                continue;
+            }
+        }
+        catch {
+            // Rejected by Chrome (e.g. a Secure cookie for http) — counted as skipped
+        }
+        skipped++;
+    }
+    const results = await chrome.scripting.executeScript({
+        target: { tabId },
+        world: 'MAIN',
+        func: writeWebStorageProbe,
+        args: [params.local_storage || {}, params.session_storage || {}]
+    });
+    const storage = results?.[0]?.result || {
+        local_storage: 0,
+        session_storage: 0
+    };
+    // Reload so the app boots with the restored login instead of its logged-out state.
+    const target = params.include_url && params.url ? params.url : page.url;
+    if (target === page.url)
+        await chrome.tabs.reload(tabId);
+    else
+        await chrome.tabs.update(tabId, { url: target });
+    return { restored: { cookies, cookies_skipped: skipped, ...storage, url: target } };
+}
+// =============================================================================
+// COMMAND
+// =============================================================================
+registerCommand('auth_state', async (ctx) => {
+    if (!requireAiWebPilot(ctx))
+        return;
+    const params = ctx.params;
+    try {
+        if (params.action === 'capture') {
+            ctx.sendResult(await captureAuthState(ctx.tabId));
+            return;
+        }
+        if (params.action === 'restore') {
+            ctx.sendResult(await restoreAuthState(ctx.tabId, params));
+            return;
+        }
+        ctx.sendResult({ error: 'unknown_action', message: `Unknown auth_state action: ${params.action}` });
+    }
+    catch (err) {
+        ctx.sendResult({
+            error: 'auth_state_failed',
+            message: errorMessage(err, 'Auth state command failed')
+        });
+    }
+});
+//# sourceMappingURL=auth-state.js.map
@@ -0,0 +1,2 @@
+export {};
+//# sourceMappingURL=emulate.d.ts.map
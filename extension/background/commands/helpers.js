@@ -202,7 +202,16 @@ const TARGETED_QUERY_TYPES = new Set([
     'page_summary',
     'page_structure',
     'navigation',
-    'feature_gates'
+    'feature_gates',
+    'layout_inspect',
+    'i18n_scan',
+    'seo_scan',
+    'coverage_snapshot',
+    'form_fill',
+    'replay_request',
+    'emulate',
+    'auth_state',
+    'assert'
 ]);
 export function requiresTargetTab(queryType) {
     return TARGETED_QUERY_TYPES.has(queryType);
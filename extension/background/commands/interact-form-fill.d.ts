@@ -0,0 +1,28 @@
+type FillValue = string | number | boolean | Array<string | number>;
+interface FormFillField {
+    key: string;
+    status: 'filled' | 'not_found' | 'error';
+    selector?: string;
+    matched_by?: string;
+    valid?: boolean;
+    validation_message?: string;
+    message?: string;
+}
+interface FormFillResult {
+    success: boolean;
+    form?: string;
+    filled: number;
+    not_found: string[];
+    errors: number;
+    fields: FormFillField[];
+    error?: string;
+    message?: string;
+}
+/**
+ * Self-contained filler injected via chrome.scripting.executeScript.
+ * MUST remain self-contained — Chrome serializes the function source only (no closures).
+ * Values are never echoed back, so secrets passed in do not reappear in the result.
+ */
+export declare function formFillProbe(formSelector: string, values: Record<string, FillValue>): FormFillResult;
+export {};
+//# sourceMappingURL=interact-form-fill.d.ts.map
@@ -0,0 +1,188 @@
+// interact-form-fill.ts — Single round-trip form filling for interact(what="fill_form", values={...}).
+// Resolves each key to a field by name, id, label, aria-label, or placeholder, sets it,
+// and reports per-field validity so the caller sees what still blocks submit.
+import { registerCommand } from './registry.js';
+import { requireAiWebPilot } from './helpers.js';
+import { errorMessage } from '../../lib/error-utils.js';
+/**
+ * Self-contained filler injected via chrome.scripting.executeScript.
+ * MUST remain self-contained — Chrome serializes the function source only (no closures).
+ * Values are never echoed back, so secrets passed in do not reappear in the result.
+ */
+export function formFillProbe(formSelector, values) {
+    const SKIP_TYPES = ['hidden', 'submit', 'button', 'reset', 'image'];
+    const TRUTHY = ['true', 'on', 'yes', '1', 'checked'];
+    const empty = { filled: 0, not_found: [], errors: 0, fields: [] };
+    let root = document;
+    if (formSelector) {
+        let found;
+        try {
+            found = document.querySelector(formSelector);
+        }
+        catch (err) {
+            return {
+                success: false,
+                ...empty,
+                error: 'invalid_selector',
+                message: err?.message || `Invalid selector: ${formSelector}`
+            };
+        }
+        if (!found) {
+            return { success: false, ...empty, error: 'form_not_found', message: `No element matches ${formSelector}` };
+        }
+        root = found;
+    }
+    const candidates = Array.from(root.querySelectorAll('input, select, textarea')).filter((el) => !SKIP_TYPES.includes((el.type || '').toLowerCase()));
+    const norm = (s) => (s || '').replace(/\s+/g, ' ').trim().toLowerCase();
+    function labelText(el) {
+        const labels = el.labels ? Array.from(el.labels) : [];
+        return norm(labels.map((l) => l.textContent || '').join(' ')).replace(/[\s*:]+$/, '');
+    }
+    function describe(el) {
+        if (el.id)
+            return `#${el.id}`;
+        const name = el.getAttribute('name');
+        if (name)
+            return `${el.tagName.toLowerCase()}[name="${name}"]`;
+        return el.tagName.toLowerCase();
+    }
+    function resolve(key) {
+        const k = norm(key);
+        const strategies = [
+            ['name', (el) => el.getAttribute('name') === key],
+            ['id', (el) => el.id === key],
+            ['label', (el) => labelText(el) === k],
+            ['aria_label', (el) => norm(el.getAttribute('aria-label')) === k],
+            ['placeholder', (el) => norm(el.getAttribute('placeholder')) === k]
+        ];
+        for (const [by, test] of strategies) {
+            const matches = candidates.filter(test);
+            if (matches.length > 0)
+                return { matches, by };
+        }
+        return { matches: [], by: '' };
+    }
+    function fire(el) {
+        el.dispatchEvent(new Event('input', { bubbles: true }));
+        el.dispatchEvent(new Event('change', { bubbles: true }));
+    }
+    // Returns an error message, or '' when the value was applied.
+    function apply(matches, value) {
+        const first = matches[0];
+        const type = (first.type || '').toLowerCase();
+        const wanted = (Array.isArray(value) ? value : [value]).map((v) => String(v));
+        if (type === 'file')
+            return 'File inputs cannot be filled; use interact(what="upload")';
+        if (type === 'radio') {
+            const radio = matches.find((r) => r.value === wanted[0] || labelText(r) === norm(wanted[0]));
+            if (!radio)
+                return `No radio option with value "${wanted[0]}"`;
+            radio.checked = true;
+            fire(radio);
+            return '';
+        }
+        if (type === 'checkbox') {
+            const boxes = matches;
+            if (boxes.length === 1 && !Array.isArray(value)) {
+                boxes[0].checked = typeof value === 'boolean' ? value : TRUTHY.includes(norm(String(value)));
+                fire(boxes[0]);
+                return '';
+            }
+            for (const box of boxes) {
+                box.checked = wanted.includes(box.value);
+                fire(box);
+            }
+            return '';
+        }
+        if (first.tagName === 'SELECT') {
+            const select = first;
+            const options = Array.from(select.options);
+            let hits = 0;
+            for (const opt of options) {
+                const selected = wanted.includes(opt.value) || wanted.some((w) => norm(w) === norm(opt.text));
+                if (selected)
+                    hits++;
+                if (select.multiple)
+                    opt.selected = selected;
+                else if (selected && hits === 1)
+                    select.value = opt.value;
+            }
+            if (hits === 0)
+                return `No option matches "${wanted.join(', ')}"`;
+            fire(select);
+            return '';
+        }
+        first.focus();
+        first.value = wanted.join(',');
+        fire(first);
+        return '';
+    }
+    const fields = [];
+    for (const [key, value] of Object.entries(values || {})) {
+        const { matches, by } = resolve(key);
+        if (matches.length === 0) {
+            fields.push({ key, status: 'not_found' });
+            continue;
+        }
+        const entry = { key, status: 'filled', selector: describe(matches[0]), matched_by: by };
+        try {
+            const problem = apply(matches, value);
+            if (problem) {
+                entry.status = 'error';
+                entry.message = problem;
+            }
+        }
+        catch (err) {
+            entry.status = 'error';
+            entry.message = err?.message || 'Failed to set value';
+        }
+        entry.valid = matches[0].validity.valid;
+        if (matches[0].validationMessage)
+            entry.validation_message = matches[0].validationMessage;
+        fields.push(entry);
+    }
+    const notFound = fields.filter((f) => f.status === 'not_found').map((f) => f.key);
+    const errors = fields.filter((f) => f.status === 'error').length;
+    const result = {
+        success: notFound.length === 0 && errors === 0,
+        form: formSelector || undefined,
+        filled: fields.filter((f) => f.status === 'filled').length,
+        not_found: notFound,
+        errors,
+        fields
+    };
+    if (!result.success) {
+        result.error = 'form_fill_incomplete';
+        result.message = `${notFound.length + errors} of ${fields.length} fields not filled; see fields[].status`;
+    }
+    return result;
+}
+registerCommand('form_fill', async (ctx) => {
+    if (!requireAiWebPilot(ctx))
+        return;
+    const params = ctx.params;
+    try {
+        const results = await chrome.scripting.executeScript({
+            target: { tabId: ctx.tabId },
+            world: 'ISOLATED',
+            func: formFillProbe,
+            args: [params.selector || '', params.values || {}]
+        });
+        const result = results?.[0]?.result;
+        if (!result) {
+            ctx.sendResult({
+                error: 'form_fill_failed',
+                message: 'Form fill returned no result'
+            });
+            return;
+        }
+        ctx.sendResult(result);
+    }
+    catch (err) {
+        ctx.sendResult({
+            error: 'form_fill_failed',
+            message: errorMessage(err, 'Form fill failed')
+        });
+    }
+});
+//# sourceMappingURL=interact-form-fill.js.map
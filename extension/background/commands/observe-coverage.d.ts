@@ -0,0 +1,46 @@
+interface CoverageRange {
+    startOffset: number;
+    endOffset: number;
+    count: number;
+}
+interface ScriptCoverage {
+    scriptId: string;
+    url: string;
+    functions: Array<{
+        ranges: CoverageRange[];
+    }>;
+}
+interface RuleUsage {
+    styleSheetId: string;
+    startOffset: number;
+    endOffset: number;
+    used: boolean;
+}
+interface StyleSheetHeader {
+    styleSheetId: string;
+    sourceURL: string;
+    length: number;
+}
+export interface CoverageResource {
+    url: string;
+    type: 'js' | 'css';
+    total_bytes: number;
+    used_bytes: number;
+    ranges?: Array<[number, number]>;
+}
+export interface CoverageSnapshot {
+    page_url: string;
+    tab_id?: number;
+    reloaded: boolean;
+    resources: CoverageResource[];
+}
+/**
+ * Converts Profiler.takePreciseCoverage block coverage into used ranges per script.
+ * Block ranges nest: a function range is refined by the blocks inside it. Painting outer
+ * ranges before inner ones (start ascending, longer first) lets the innermost count win.
+ */
+export declare function jsCoverageResources(scripts: ScriptCoverage[]): CoverageResource[];
+/** Converts CSS rule usage into used ranges per stylesheet; rules never matched stay unused. */
+export declare function cssCoverageResources(ruleUsage: RuleUsage[], sheets: StyleSheetHeader[]): CoverageResource[];
+export {};
+//# sourceMappingURL=observe-coverage.d.ts.map
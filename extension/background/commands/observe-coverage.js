@@ -0,0 +1,183 @@
+// observe-coverage.ts — CDP code coverage snapshot for observe(what="coverage", collect=true).
+// Reloads the tab under Profiler precise coverage and CSS rule usage tracking, converts the
+// result to used byte ranges per script and stylesheet, and POSTs it to /coverage-snapshots.
+import { registerCommand } from './registry.js';
+import { errorMessage } from '../../lib/error-utils.js';
+import { delay } from '../../lib/timeout-utils.js';
+import { scaleTimeout } from '../../lib/timeouts.js';
+import { attachDebugger, detachDebugger } from '../device-emulation.js';
+import { sendCoverageSnapshotsToServer } from '../server.js';
+import { getServerUrl } from '../state.js';
+// Mirrors coverage.MaxRanges on the server; beyond it only used_bytes is sent.
+const MAX_RANGES = 20000;
+// Lets lazy chunks and deferred styles requested right after load count.
+const SETTLE_MS = 1500;
+const LOAD_TIMEOUT_MS = 15000;
+// =============================================================================
+// CONVERSION
+// =============================================================================
+/** Collapses a per-byte used map into [start, end) ranges. */
+function usedRanges(used) {
+    const ranges = [];
+    let start = -1;
+    for (let i = 0; i <= used.length; i++) {
+        const on = i < used.length && used[i] === 1;
+        if (on && start < 0)
+            start = i;
+        if (!on && start >= 0) {
+            ranges.push([start, i]);
+            start = -1;
+        }
+    }
+    return ranges;
+}
+function toResource(url, type, used) {
+    const ranges = usedRanges(used);
+    const usedBytes = ranges.reduce((n, [s, e]) => n + e - s, 0);
+    const resource = { url, type, total_bytes: used.length, used_bytes: usedBytes };
+    if (ranges.length <= MAX_RANGES)
+        resource.ranges = ranges;
+    return resource;
+}
+/**
+ * Merges resources that share a URL (several inline scripts or <style> blocks on one page).
+ * Their offsets are relative to different sources, so only summed byte counts survive.
+ */
+function mergeByURL(resources) {
+    const byURL = new Map();
+    for (const r of resources) {
+        const prev = byURL.get(r.url);
+        if (!prev) {
+            byURL.set(r.url, r);
+            continue;
+        }
+        byURL.set(r.url, {
+            url: r.url,
+            type: r.type,
+            total_bytes: prev.total_bytes + r.total_bytes,
+            used_bytes: prev.used_bytes + r.used_bytes
+        });
+    }
+    return Array.from(byURL.values());
+}
+function coverableURL(url) {
+    return /^https?:\/\//.test(url) || url.startsWith('file://');
+}
+/**
+ * Converts Profiler.takePreciseCoverage block coverage into used ranges per script.
+ * Block ranges nest: a function range is refined by the blocks inside it. Painting outer
+ * ranges before inner ones (start ascending, longer first) lets the innermost count win.
+ */
+export function jsCoverageResources(scripts) {
+    const resources = [];
+    for (const script of scripts) {
+        if (!coverableURL(script.url))
+            continue;
+        const ranges = script.functions.flatMap((fn) => fn.ranges);
+        const total = ranges.reduce((max, r) => Math.max(max, r.endOffset), 0);
+        if (total <= 0)
+            continue;
+        ranges.sort((a, b) => a.startOffset - b.startOffset || b.endOffset - a.endOffset);
+        const used = new Uint8Array(total);
+        for (const r of ranges)
+            used.fill(r.count > 0 ? 1 : 0, r.startOffset, r.endOffset);
+        resources.push(toResource(script.url, 'js', used));
+    }
+    return mergeByURL(resources);
+}
+/** Converts CSS rule usage into used ranges per stylesheet; rules never matched stay unused. */
+export function cssCoverageResources(ruleUsage, sheets) {
+    const used = new Map();
+    for (const sheet of sheets) {
+        if (coverableURL(sheet.sourceURL) && sheet.length > 0)
+            used.set(sheet.styleSheetId, new Uint8Array(sheet.length));
+    }
+    for (const rule of ruleUsage) {
+        if (rule.used)
+            used.get(rule.styleSheetId)?.fill(1, rule.startOffset, rule.endOffset);
+    }
+    const resources = [];
+    for (const sheet of sheets) {
+        const map = used.get(sheet.styleSheetId);
+        if (map)
+            resources.push(toResource(sheet.sourceURL, 'css', map));
+    }
+    return mergeByURL(resources);
+}
+// =============================================================================
+// COLLECTION
+// =============================================================================
+async function collectCoverage(tabId, reload) {
+    const target = { tabId };
+    const send = (method, params = {}) => chrome.debugger.sendCommand(target, method, params);
+    const sheets = new Map();
+    let markLoaded = () => { };
+    const loaded = new Promise((resolve) => {
+        markLoaded = resolve;
+    });
+    const onEvent = (source, method, params) => {
+        if (source.tabId !== tabId)
+            return;
+        if (method === 'CSS.styleSheetAdded') {
+            const header = params.header;
+            sheets.set(header.styleSheetId, header);
+        }
+        else if (method === 'Page.loadEventFired') {
+            markLoaded();
+        }
+    };
+    await attachDebugger(tabId);
+    chrome.debugger.onEvent.addListener(onEvent);
+    try {
+        await send('Profiler.enable');
+        await send('Profiler.startPreciseCoverage', { callCount: false, detailed: true });
+        await send('DOM.enable');
+        await send('CSS.enable');
+        await send('CSS.startRuleUsageTracking');
+        if (reload) {
+            // Styles from the old document are gone after the reload.
+            sheets.clear();
+            await send('Page.enable');
+            await send('Page.reload', {});
+            await Promise.race([loaded, delay(scaleTimeout(LOAD_TIMEOUT_MS))]);
+        }
+        await delay(scaleTimeout(SETTLE_MS));
+        const js = (await send('Profiler.takePreciseCoverage'));
+        const css = (await send('CSS.stopRuleUsageTracking'));
+        await send('Profiler.stopPreciseCoverage');
+        const tab = await chrome.tabs.get(tabId);
+        return {
+            page_url: tab.url || '',
+            tab_id: tabId,
+            reloaded: reload,
+            resources: [
+                ...jsCoverageResources(js?.result || []),
+                ...cssCoverageResources(css?.ruleUsage || [], Array.from(sheets.values()))
+            ]
+        };
+    }
+    finally {
+        chrome.debugger.onEvent.removeListener(onEvent);
+        await detachDebugger(tabId);
+    }
+}
+registerCommand('coverage_snapshot', async (ctx) => {
+    const params = ctx.params;
+    try {
+        const snapshot = await collectCoverage(ctx.tabId, params.reload !== false);
+        await sendCoverageSnapshotsToServer(getServerUrl(), [snapshot]);
+        ctx.sendResult({
+            status: 'posted',
+            page_url: snapshot.page_url,
+            resources: snapshot.resources.length,
+            reloaded: snapshot.reloaded
+        });
+    }
+    catch (err) {
+        ctx.sendResult({
+            error: 'coverage_snapshot_failed',
+            message: errorMessage(err, 'Coverage snapshot failed')
+        });
+    }
+});
+//# sourceMappingURL=observe-coverage.js.map
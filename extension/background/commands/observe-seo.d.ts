@@ -0,0 +1,29 @@
+interface SeoProbeResult {
+    url: string;
+    titles: string[];
+    descriptions: string[];
+    canonicals: string[];
+    robots: Array<{
+        name: string;
+        content: string;
+    }>;
+    json_ld: Array<{
+        text: string;
+        truncated?: boolean;
+    }>;
+    links: Array<{
+        url: string;
+        text?: string;
+    }>;
+    link_count: number;
+    links_truncated: boolean;
+    error?: string;
+    message?: string;
+}
+/**
+ * Self-contained probe injected via chrome.scripting.executeScript.
+ * MUST remain self-contained — Chrome serializes the function source only (no closures).
+ */
+export declare function seoScanProbe(): SeoProbeResult;
+export {};
+//# sourceMappingURL=observe-seo.d.ts.map
@@ -0,0 +1,94 @@
+// observe-seo.ts — SEO probe for observe(what="seo").
+// Collects titles, meta descriptions, canonical links, robots directives, raw JSON-LD,
+// and same-origin links; the server checks them and matches links against captured traffic.
+import { registerCommand } from './registry.js';
+import { errorMessage } from '../../lib/error-utils.js';
+/**
+ * Self-contained probe injected via chrome.scripting.executeScript.
+ * MUST remain self-contained — Chrome serializes the function source only (no closures).
+ */
+export function seoScanProbe() {
+    const MAX_LINKS = 500;
+    const MAX_JSON_LD = 20;
+    const MAX_JSON_LD_CHARS = 100_000;
+    const ROBOTS_NAMES = ['robots', 'googlebot', 'bingbot'];
+    function clean(value) {
+        return (value || '').replace(/\s+/g, ' ').trim();
+    }
+    function metaNamed(names) {
+        return Array.from(document.querySelectorAll('meta[name]')).filter((m) => names.includes((m.getAttribute('name') || '').trim().toLowerCase()));
+    }
+    const titles = Array.from(document.querySelectorAll('title'))
+        .filter((t) => !t.closest('svg'))
+        .map((t) => clean(t.textContent));
+    const canonicals = Array.from(document.querySelectorAll('link[rel]'))
+        .filter((l) => (l.getAttribute('rel') || '').toLowerCase().split(/\s+/).includes('canonical'))
+        .map((l) => l.getAttribute('href') || '');
+    const jsonLd = Array.from(document.querySelectorAll('script[type]'))
+        .filter((s) => (s.getAttribute('type') || '').trim().toLowerCase() === 'application/ld+json')
+        .slice(0, MAX_JSON_LD)
+        .map((s) => {
+        const text = s.textContent || '';
+        return text.length > MAX_JSON_LD_CHARS ? { text: '', truncated: true } : { text };
+    });
+    const seen = new Set();
+    const links = [];
+    for (const a of Array.from(document.querySelectorAll('a[href]'))) {
+        let href;
+        try {
+            href = new URL(a.getAttribute('href') || '', location.href);
+        }
+        catch {
+            continue;
+        }
+        if (href.origin !== location.origin)
+            continue;
+        href.hash = '';
+        if (seen.has(href.href))
+            continue;
+        seen.add(href.href);
+        if (links.length < MAX_LINKS) {
+            const text = clean(a.textContent).slice(0, 80);
+            links.push(text ? { url: href.href, text } : { url: href.href });
+        }
+    }
+    return {
+        url: location.href,
+        titles,
+        descriptions: metaNamed(['description']).map((m) => m.getAttribute('content') || ''),
+        canonicals,
+        robots: metaNamed(ROBOTS_NAMES).map((m) => ({
+            name: (m.getAttribute('name') || '').trim().toLowerCase(),
+            content: m.getAttribute('content') || ''
+        })),
+        json_ld: jsonLd,
+        links,
+        link_count: seen.size,
+        links_truncated: seen.size > links.length
+    };
+}
+registerCommand('seo_scan', async (ctx) => {
+    try {
+        const results = await chrome.scripting.executeScript({
+            target: { tabId: ctx.tabId },
+            world: 'ISOLATED',
+            func: seoScanProbe
+        });
+        const result = results?.[0]?.result;
+        if (!result) {
+            ctx.sendResult({
+                error: 'seo_scan_failed',
+                message: 'SEO probe returned no result'
+            });
+            return;
+        }
+        ctx.sendResult(result);
+    }
+    catch (err) {
+        ctx.sendResult({
+            error: 'seo_scan_failed',
+            message: errorMessage(err, 'SEO probe failed')
+        });
+    }
+});
+//# sourceMappingURL=observe-seo.js.map
@@ -5,7 +5,7 @@
 // observe.ts — Command handlers for the observe MCP tool.
 // Handles: screenshot, waterfall, page_info, tabs.
 import { debugLog } from '../index.js';
-import { getServerUrl } from '../state.js';
+import { getServerUrl, getScreenshotRedactSelectors } from '../state.js';
 import { DebugCategory } from '../debug.js';
 import { recordScreenshot } from '../state-manager.js';
 import { domPrimitiveListInteractive } from '../dom-primitives-list-interactive.js';
@@ -14,7 +14,7 @@ import { CDP_VERSION } from '../../lib/constants.js';
 import { errorMessage } from '../../lib/error-utils.js';
 import { delay } from '../../lib/timeout-utils.js';
 import { postDaemonJSON } from '../../lib/daemon-http.js';
-import { captureVisibleTabSafe } from '../tab-state.js';
+import { captureVisibleTabSafe, measureScreenshotRegions } from '../tab-state.js';
 // =============================================================================
 // SCREENSHOT
 // =============================================================================
@@ -114,13 +114,42 @@ function computeFullPageCaptureDimensions(contentWidth, contentHeight, hintedHei
         height: Math.max(1, Math.min(Math.max(safeHeight, safeHint), MAX_CAPTURE_HEIGHT))
     };
 }
+/**
+ * Self-contained: scroll the selected element into view and measure its viewport-relative box.
+ * Returns an error code instead of throwing so the caller can surface it verbatim.
+ */
+function screenshotMeasureElement(selector) {
+    let el;
+    try {
+        el = document.querySelector(selector);
+    }
+    catch {
+        return { error: 'invalid_selector', message: `Invalid CSS selector: ${selector}` };
+    }
+    if (!el)
+        return { error: 'element_not_found', message: `No element matches selector: ${selector}` };
+    el.scrollIntoView({ block: 'center', inline: 'center' });
+    const rect = el.getBoundingClientRect();
+    if (rect.width <= 0 || rect.height <= 0) {
+        return { error: 'element_not_visible', message: `Element has no rendered size: ${selector}` };
+    }
+    return {
+        x: rect.left,
+        y: rect.top,
+        width: rect.width,
+        height: rect.height,
+        viewport_width: window.innerWidth,
+        device_pixel_ratio: window.devicePixelRatio || 1
+    };
+}
 /** Post screenshot data to server for saving and query resolution. */
-async function postScreenshot(dataUrl, pageUrl, queryId) {
+async function postScreenshot(dataUrl, pageUrl, queryId, extras = {}) {
     try {
         const response = await postDaemonJSON(`${getServerUrl()}/screenshots`, {
             data_url: dataUrl,
             url: pageUrl,
-            query_id: queryId
+            query_id: queryId,
+            ...extras
         });
         return response.ok;
     }
@@ -128,23 +157,85 @@ async function postScreenshot(dataUrl, pageUrl, queryId) {
         return false;
     }
 }
+/**
+ * Redaction selectors come from the query (so a just-configured list applies at once) and
+ * from the last sync; the highlight selector only from the query.
+ */
+function screenshotAnnotations(params) {
+    const fromQuery = Array.isArray(params.redact_selectors)
+        ? params.redact_selectors.filter((s) => typeof s === 'string' && s.length > 0)
+        : [];
+    return {
+        redactSelectors: [...new Set([...fromQuery, ...getScreenshotRedactSelectors()])],
+        highlightSelector: typeof params.highlight_selector === 'string' ? params.highlight_selector.trim() : ''
+    };
+}
+/**
+ * Measure annotation boxes right before a capture. Returns null after reporting the error when
+ * redaction is on but the page cannot be measured: an unredacted image must not be uploaded.
+ */
+async function measureAnnotations(ctx, annotations) {
+    const { redactSelectors, highlightSelector } = annotations;
+    if (redactSelectors.length === 0 && !highlightSelector)
+        return {};
+    let regions;
+    try {
+        regions = await measureScreenshotRegions(ctx.tabId, redactSelectors, highlightSelector);
+    }
+    catch (err) {
+        if (redactSelectors.length > 0) {
+            ctx.sendResult({
+                error: 'redaction_failed',
+                message: errorMessage(err, 'Could not measure redacted regions; screenshot not taken')
+            });
+            return null;
+        }
+        return {};
+    }
+    return {
+        ...(redactSelectors.length > 0 ? { redact: regions.redact } : {}),
+        ...(highlightSelector ? { highlight: regions.highlight } : {})
+    };
+}
 registerCommand('screenshot', async (ctx) => {
     const format = ctx.params.format === 'png' ? 'png' : 'jpeg';
     const quality = typeof ctx.params.quality === 'number' ? ctx.params.quality : 80;
     const fullPage = ctx.params.full_page === true;
+    const clipSelector = ctx.params.clip === true && typeof ctx.params.selector === 'string' ? ctx.params.selector.trim() : '';
+    const annotations = screenshotAnnotations(ctx.params);
     try {
         const tab = await chrome.tabs.get(ctx.tabId);
         if (fullPage) {
-            await captureFullPage(ctx, tab, format, quality);
+            await captureFullPage(ctx, tab, format, quality, annotations);
             return;
         }
+        // Element clip: measure in the page, capture the viewport, and let the server crop.
+        let clip = {};
+        if (clipSelector) {
+            const measured = await chrome.scripting.executeScript({
+                target: { tabId: ctx.tabId },
+                world: 'MAIN',
+                func: screenshotMeasureElement,
+                args: [clipSelector]
+            });
+            const rect = measured?.[0]?.result;
+            if (!rect || 'error' in rect) {
+                ctx.sendResult(rect ?? { error: 'element_not_found', message: `No element matches selector: ${clipSelector}` });
+                return;
+            }
+            clip = { selector: clipSelector, clip: rect };
+            await delay(100); // let scrollIntoView settle before capturing
+        }
+        const annotated = await measureAnnotations(ctx, annotations);
+        if (!annotated)
+            return;
         const dataUrl = await captureVisibleTabSafe(ctx.tabId, tab.windowId, {
             format: format,
             quality
         });
         recordScreenshot(ctx.tabId);
         // POST to /screenshots with query_id — server saves file and resolves query directly
-        const ok = await postScreenshot(dataUrl, tab.url, ctx.query.id);
+        const ok = await postScreenshot(dataUrl, tab.url, ctx.query.id, { ...clip, ...annotated });
         if (!ok) {
             ctx.sendResult({ error: 'screenshot_upload_failed', message: 'Server rejected screenshot' });
         }
@@ -158,7 +249,7 @@ registerCommand('screenshot', async (ctx) => {
     }
 });
 /** Full-page screenshot via CDP with scrollable container expansion (#363). */
-async function captureFullPage(ctx, tab, format, quality) {
+async function captureFullPage(ctx, tab, format, quality, annotations) {
     // Step 1: Expand scrollable containers in the page
     let hintedHeight = 0;
     try {
@@ -199,6 +290,10 @@ async function captureFullPage(ctx, tab, format, quality) {
             try {
                 // Brief pause for layout reflow after viewport resize
                 await delay(150);
+                // The viewport now spans the page, so viewport boxes line up with the full-page image.
+                const annotated = await measureAnnotations(ctx, annotations);
+                if (!annotated)
+                    return;
                 // Step 5: Capture full-page screenshot via CDP
                 const screenshotResult = (await chrome.debugger.sendCommand({ tabId: ctx.tabId }, 'Page.captureScreenshot', {
                     format,
@@ -210,7 +305,7 @@ async function captureFullPage(ctx, tab, format, quality) {
                 const mimeType = format === 'png' ? 'image/png' : 'image/jpeg';
                 const dataUrl = `data:${mimeType};base64,${screenshotResult.data}`;
                 recordScreenshot(ctx.tabId);
-                const ok = await postScreenshot(dataUrl, tab.url, ctx.query.id);
+                const ok = await postScreenshot(dataUrl, tab.url, ctx.query.id, annotated);
                 if (!ok) {
                     ctx.sendResult({ error: 'screenshot_upload_failed', message: 'Server rejected screenshot' });
                 }
@@ -242,12 +337,15 @@ async function captureFullPage(ctx, tab, format, quality) {
         debugLog(DebugCategory.CAPTURE, 'Full-page CDP failed, falling back to viewport capture', {
             error: errorMessage(err)
         });
+        const annotated = await measureAnnotations(ctx, annotations);
+        if (!annotated)
+            return;
         const dataUrl = await captureVisibleTabSafe(ctx.tabId, tab.windowId, {
             format: format,
             quality
         });
         recordScreenshot(ctx.tabId);
-        const ok = await postScreenshot(dataUrl, tab.url, ctx.query.id);
+        const ok = await postScreenshot(dataUrl, tab.url, ctx.query.id, annotated);
         if (!ok) {
             ctx.sendResult({ error: 'screenshot_upload_failed', message: 'Server rejected screenshot' });
         }
@@ -282,6 +380,7 @@ registerCommand('waterfall', async (ctx) => {
         ctx.sendResult({
             entries: result?.entries || [],
             page_url: tab.url || '',
+            tab_id: ctx.tabId,
             count: result?.entries?.length || 0
         });
         debugLog(DebugCategory.CAPTURE, 'Posted waterfall result', { queryId: ctx.query.id });
@@ -0,0 +1,40 @@
+interface ReplayOriginal {
+    status: number;
+    duration_ms?: number;
+    content_type?: string;
+    body?: string;
+}
+interface ReplayResponse {
+    status: number;
+    status_text: string;
+    url: string;
+    headers: Record<string, string>;
+    content_type: string;
+    body: string;
+    body_truncated: boolean;
+    duration_ms: number;
+}
+interface ReplayError {
+    error: string;
+    message: string;
+}
+export interface ReplayDiff {
+    status_changed: boolean;
+    body_changed: boolean;
+    duration_delta_ms?: number;
+    keys_added?: string[];
+    keys_removed?: string[];
+    keys_changed?: string[];
+}
+/**
+ * Self-contained replay injected via chrome.scripting.executeScript in the MAIN world.
+ * MUST remain self-contained — Chrome serializes the function source only (no closures).
+ */
+export declare function replayRequestProbe(url: string, method: string, headers: Record<string, string>, body: string, maxBody: number): Promise<ReplayResponse | ReplayError>;
+/**
+ * Compare the replay with the original capture. When both bodies are JSON objects,
+ * top-level keys are reported as added, removed, or changed.
+ */
+export declare function diffReplay(original: ReplayOriginal, replay: ReplayResponse): ReplayDiff;
+export {};
+//# sourceMappingURL=replay-request.d.ts.map
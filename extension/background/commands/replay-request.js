@@ -0,0 +1,124 @@
+// replay-request.ts — Re-issues a captured request for interact(what="replay_request").
+// The request runs from the tracked page so cookies and auth match the original, and
+// the page's fetch wrapper captures the replay into network_bodies like any other call.
+import { registerCommand } from './registry.js';
+import { requireAiWebPilot } from './helpers.js';
+import { errorMessage } from '../../lib/error-utils.js';
+// =============================================================================
+// PROBE
+// =============================================================================
+/**
+ * Self-contained replay injected via chrome.scripting.executeScript in the MAIN world.
+ * MUST remain self-contained — Chrome serializes the function source only (no closures).
+ */
+export async function replayRequestProbe(url, method, headers, body, maxBody) {
+    const verb = (method || 'GET').toUpperCase();
+    const start = performance.now();
+    try {
+        const response = await fetch(url, {
+            method: verb,
+            headers,
+            body: verb === 'GET' || verb === 'HEAD' || body === '' ? undefined : body,
+            credentials: 'include',
+            cache: 'no-store'
+        });
+        const text = await response.text();
+        const responseHeaders = {};
+        response.headers.forEach((value, name) => {
+            responseHeaders[name] = value;
+        });
+        const limit = maxBody > 0 ? maxBody : text.length;
+        return {
+            status: response.status,
+            status_text: response.statusText,
+            url: response.url || url,
+            headers: responseHeaders,
+            content_type: response.headers.get('content-type') || '',
+            body: text.slice(0, limit),
+            body_truncated: text.length > limit,
+            duration_ms: Math.round(performance.now() - start)
+        };
+    }
+    catch (err) {
+        return { error: 'replay_failed', message: err?.message || 'Replay request failed' };
+    }
+}
+// =============================================================================
+// DIFF
+// =============================================================================
+function parseJSONObject(text) {
+    if (!text)
+        return null;
+    try {
+        const value = JSON.parse(text);
+        return value && typeof value === 'object' && !Array.isArray(value) ? value : null;
+    }
+    catch {
+        return null;
+    }
+}
+/**
+ * Compare the replay with the original capture. When both bodies are JSON objects,
+ * top-level keys are reported as added, removed, or changed.
+ */
+export function diffReplay(original, replay) {
+    const diff = {
+        status_changed: original.status !== replay.status,
+        body_changed: (original.body || '') !== replay.body
+    };
+    if (original.duration_ms)
+        diff.duration_delta_ms = replay.duration_ms - original.duration_ms;
+    const before = parseJSONObject(original.body);
+    const after = parseJSONObject(replay.body);
+    if (before && after && diff.body_changed) {
+        diff.keys_added = Object.keys(after).filter((k) => !(k in before));
+        diff.keys_removed = Object.keys(before).filter((k) => !(k in after));
+        diff.keys_changed = Object.keys(after).filter((k) => k in before && JSON.stringify(before[k]) !== JSON.stringify(after[k]));
+    }
+    return diff;
+}
+// =============================================================================
+// COMMAND
+// =============================================================================
+registerCommand('replay_request', async (ctx) => {
+    if (!requireAiWebPilot(ctx))
+        return;
+    const params = ctx.params;
+    if (!params.url) {
+        ctx.sendResult({ error: 'missing_url', message: 'Replay needs the captured request URL' });
+        return;
+    }
+    try {
+        const results = await chrome.scripting.executeScript({
+            target: { tabId: ctx.tabId },
+            world: 'MAIN',
+            func: replayRequestProbe,
+            args: [params.url, params.method || 'GET', params.headers || {}, params.body || '', params.max_body || 0]
+        });
+        const replay = results?.[0]?.result;
+        if (!replay) {
+            ctx.sendResult({ error: 'replay_failed', message: 'Replay returned no result' });
+            return;
+        }
+        if ('error' in replay) {
+            ctx.sendResult(replay);
+            return;
+        }
+        const original = params.original || { status: 0 };
+        ctx.sendResult({
+            request_id: params.request_id,
+            method: (params.method || 'GET').toUpperCase(),
+            url: params.url,
+            original,
+            replay,
+            diff: diffReplay(original, replay)
+        });
+    }
+    catch (err) {
+        ctx.sendResult({
+            error: 'replay_failed',
+            message: errorMessage(err, 'Replay request failed')
+        });
+    }
+});
+//# sourceMappingURL=replay-request.js.map
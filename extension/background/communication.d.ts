@@ -9,7 +9,7 @@
  */
 export { createCircuitBreaker, type CircuitBreakerOptions, type CircuitBreaker } from './circuit-breaker.js';
 export { createBatcherWithCircuitBreaker, createLogBatcher, RATE_LIMIT_CONFIG, type Batcher, type BatcherWithCircuitBreaker, type BatcherConfig, type LogBatcherOptions } from './batchers.js';
-export { sendLogsToServer, sendWSEventsToServer, sendNetworkBodiesToServer, sendEnhancedActionsToServer, sendPerformanceSnapshotsToServer, checkServerHealth, updateBadge, sendStatusPing, type ServerHealthResponse } from './server.js';
+export { sendLogsToServer, sendWSEventsToServer, sendSSEEventsToServer, sendNetworkBodiesToServer, sendEnhancedActionsToServer, sendPerformanceSnapshotsToServer, checkServerHealth, updateBadge, sendStatusPing, type ServerHealthResponse } from './server.js';
 import type { LogEntry } from '../types/index.js';
 /**
  * Format a log entry with timestamp and truncation
@@ -20,13 +20,14 @@ export declare function formatLogEntry(entry: LogEntry): LogEntry;
  */
 export declare function shouldCaptureLog(logLevel: string, filterLevel: string, logType?: string): boolean;
 /**
- * Capture a screenshot of the visible tab area
+ * Capture a screenshot of the visible tab area. Elements matching redactSelectors are
+ * measured first and blacked out by the server; if they cannot be measured, nothing is uploaded.
  */
 export declare function captureScreenshot(tabId: number, serverUrl: string, relatedErrorId: string | null, errorType: string | null, canTakeScreenshotFn: (tabId: number) => {
     allowed: boolean;
     reason?: string;
     nextAllowedIn?: number | null;
-}, recordScreenshotFn: (tabId: number) => void, debugLogFn?: (category: string, message: string, data?: unknown) => void): Promise<{
+}, recordScreenshotFn: (tabId: number) => void, debugLogFn?: (category: string, message: string, data?: unknown) => void, redactSelectors?: string[]): Promise<{
     success: boolean;
     entry?: LogEntry;
     error?: string;
@@ -12,10 +12,42 @@ export { createCircuitBreaker } from './circuit-breaker.js';
 // Re-export batcher functions and types
 export { createBatcherWithCircuitBreaker, createLogBatcher, RATE_LIMIT_CONFIG } from './batchers.js';
 // Re-export server communication functions
-export { sendLogsToServer, sendWSEventsToServer, sendNetworkBodiesToServer, sendEnhancedActionsToServer, sendPerformanceSnapshotsToServer, checkServerHealth, updateBadge, sendStatusPing } from './server.js';
+export { sendLogsToServer, sendWSEventsToServer, sendSSEEventsToServer, sendNetworkBodiesToServer, sendEnhancedActionsToServer, sendPerformanceSnapshotsToServer, checkServerHealth, updateBadge, sendStatusPing } from './server.js';
 import { getRequestHeaders } from './server.js';
 import { errorMessage } from '../lib/error-utils.js';
-import { captureVisibleTabSafe } from './tab-state.js';
+import { captureVisibleTabSafe, measureScreenshotRegions } from './tab-state.js';
+/**
+ * Shrink an oversized object or array without flattening it, so path filters such as
+ * args[0].requestId still resolve. Top-level values are kept in order until the size
+ * budget is spent; later ones become '[truncated]' (arrays end with a single marker).
+ */
+function truncateStructured(arg, maxSize) {
+    let budget = maxSize;
+    const fits = (value) => {
+        const size = (JSON.stringify(value) ?? '').length;
+        if (size > budget)
+            return false;
+        budget -= size;
+        return true;
+    };
+    if (Array.isArray(arg)) {
+        const kept = [];
+        for (const item of arg) {
+            if (!fits(item)) {
+                kept.push('[truncated]');
+                break;
+            }
+            kept.push(item);
+        }
+        return kept;
+    }
+    const result = {};
+    for (const [key, value] of Object.entries(arg)) {
+        // eslint-disable-next-line security/detect-object-injection -- key from Object.entries of the logged value
+        result[key] = fits(value) ? value : '[truncated]';
+    }
+    return result;
+}
 /**
  * Truncate a single argument if too large
  */
@@ -28,6 +60,9 @@ function truncateArg(arg, maxSize = 10240) {
             if (typeof arg === 'string') {
                 return arg.slice(0, maxSize) + '... [truncated]';
             }
+            if (typeof arg === 'object') {
+                return truncateStructured(arg, maxSize);
+            }
             return serialized.slice(0, maxSize) + '...[truncated]';
         }
         return arg;
@@ -66,9 +101,10 @@ export function shouldCaptureLog(logLevel, filterLevel, logType) {
     return logIndex >= filterIndex;
 }
 /**
- * Capture a screenshot of the visible tab area
+ * Capture a screenshot of the visible tab area. Elements matching redactSelectors are
+ * measured first and blacked out by the server; if they cannot be measured, nothing is uploaded.
  */
-export async function captureScreenshot(tabId, serverUrl, relatedErrorId, errorType, canTakeScreenshotFn, recordScreenshotFn, debugLogFn) {
+export async function captureScreenshot(tabId, serverUrl, relatedErrorId, errorType, canTakeScreenshotFn, recordScreenshotFn, debugLogFn, redactSelectors = []) {
     const rateCheck = canTakeScreenshotFn(tabId);
     if (!rateCheck.allowed) {
         if (debugLogFn) {
@@ -85,6 +121,15 @@ export async function captureScreenshot(tabId, serverUrl, relatedErrorId, errorT
     }
     try {
         const tab = await chrome.tabs.get(tabId);
+        let redact;
+        if (redactSelectors.length > 0) {
+            try {
+                redact = (await measureScreenshotRegions(tabId, redactSelectors, '')).redact;
+            }
+            catch (err) {
+                throw new Error(`Screenshot redaction unavailable: ${errorMessage(err)}`);
+            }
+        }
         const dataUrl = await captureVisibleTabSafe(tabId, tab.windowId, {
             format: 'jpeg',
             quality: 80
@@ -96,7 +141,8 @@ export async function captureScreenshot(tabId, serverUrl, relatedErrorId, errorT
             body: JSON.stringify({
                 data_url: dataUrl,
                 url: tab.url,
-                correlation_id: relatedErrorId || ''
+                correlation_id: relatedErrorId || '',
+                ...(redact ? { redact } : {})
             })
         });
         if (!response.ok) {
@@ -0,0 +1,51 @@
+/**
+ * Purpose: Applies and clears Chrome DevTools device emulation and region overrides (geolocation, timezone, locale) on a tab.
+ * Why: Responsive and region-specific bugs need the page rendered as the device and place see it; CDP overrides only last while the debugger stays attached.
+ * Docs: docs/features/feature/device-emulation/index.md
+ */
+/** Resolved device sent by the daemon with the emulate command. */
+export interface DeviceEmulationSpec {
+    device?: string;
+    width: number;
+    height: number;
+    device_scale_factor: number;
+    user_agent?: string;
+    touch: boolean;
+    mobile: boolean;
+}
+/** Geolocation, timezone, and locale sent by the daemon as the region_override sync override. */
+export interface RegionOverride {
+    geolocation?: {
+        latitude: number;
+        longitude: number;
+        accuracy?: number;
+    };
+    timezone?: string;
+    locale?: string;
+}
+/** Attach the debugger for a one-shot CDP action, unless emulation or passthrough already holds it. */
+export declare function attachDebugger(tabId: number): Promise<void>;
+/** Detach after a one-shot CDP action, leaving a held attachment in place. */
+export declare function detachDebugger(tabId: number): Promise<void>;
+/**
+ * Attach for interact(what="cdp") and hold the session until releasePassthroughDebugger,
+ * so state set by passthrough commands (network conditions, overrides) survives later calls.
+ */
+export declare function holdPassthroughDebugger(tabId: number): Promise<void>;
+/**
+ * End the passthrough hold on tabId, returning whether one was held. Passthrough state ends
+ * with the session; when emulation still holds the tab it is re-attached and re-applied.
+ */
+export declare function releasePassthroughDebugger(tabId: number): Promise<boolean>;
+/**
+ * Apply spec to tabId and keep the debugger attached until clearDeviceEmulation.
+ * Region overrides on the tab stay in effect.
+ */
+export declare function applyDeviceEmulation(tabId: number, spec: DeviceEmulationSpec): Promise<Record<string, unknown>>;
+/** Clear device emulation on tabId. The debugger is released unless region overrides remain. */
+export declare function clearDeviceEmulation(tabId: number): Promise<Record<string, unknown>>;
+/** Apply region overrides to tabId, or clear them with null. Device emulation on the tab stays in effect. */
+export declare function applyRegionOverride(tabId: number, region: RegionOverride | null): Promise<void>;
+/** Forget held attachments (tests). */
+export declare function resetDeviceEmulationForTesting(): void;
+//# sourceMappingURL=device-emulation.d.ts.map
@@ -0,0 +1,190 @@
+/**
+ * Purpose: Applies and clears Chrome DevTools device emulation and region overrides (geolocation, timezone, locale) on a tab.
+ * Why: Responsive and region-specific bugs need the page rendered as the device and place see it; CDP overrides only last while the debugger stays attached.
+ * Docs: docs/features/feature/device-emulation/index.md
+ */
+// device-emulation.ts — Holds the debugger on emulated tabs and tabs driven by CDP passthrough.
+// One-shot CDP actions (hardware click, coverage) reuse a held attachment via
+// attachDebugger/detachDebugger instead of attaching and detaching themselves, which
+// would fail while the tab is held or drop the held session's state on detach.
+import { CDP_VERSION } from '../lib/constants.js';
+// =============================================================================
+// MODULE STATE
+// =============================================================================
+/** Emulation held on each tab; a tab is present only while its debugger attachment is held. */
+const emulatedTabs = new Map();
+/** Tabs whose attachment is held for interact(what="cdp") until releasePassthroughDebugger. */
+const passthroughTabs = new Set();
+let detachListenerInstalled = false;
+function installDetachListener() {
+    if (detachListenerInstalled || !chrome?.debugger?.onDetach)
+        return;
+    detachListenerInstalled = true;
+    // The user dismissing the debugging banner or closing the tab ends emulation and passthrough.
+    chrome.debugger.onDetach.addListener((source) => {
+        if (source.tabId === undefined)
+            return;
+        emulatedTabs.delete(source.tabId);
+        passthroughTabs.delete(source.tabId);
+    });
+}
+function isHeld(tabId) {
+    return emulatedTabs.has(tabId) || passthroughTabs.has(tabId);
+}
+// =============================================================================
+// SHARED ATTACHMENT
+// =============================================================================
+/** Attach the debugger for a one-shot CDP action, unless emulation or passthrough already holds it. */
+export async function attachDebugger(tabId) {
+    if (isHeld(tabId))
+        return;
+    await chrome.debugger.attach({ tabId }, CDP_VERSION);
+}
+/** Detach after a one-shot CDP action, leaving a held attachment in place. */
+export async function detachDebugger(tabId) {
+    if (isHeld(tabId))
+        return;
+    try {
+        await chrome.debugger.detach({ tabId });
+    }
+    catch {
+        // Already detached or tab closed — safe to ignore
+    }
+}
+/**
+ * Attach for interact(what="cdp") and hold the session until releasePassthroughDebugger,
+ * so state set by passthrough commands (network conditions, overrides) survives later calls.
+ */
+export async function holdPassthroughDebugger(tabId) {
+    installDetachListener();
+    if (passthroughTabs.has(tabId))
+        return;
+    await attachDebugger(tabId);
+    passthroughTabs.add(tabId);
+}
+/**
+ * End the passthrough hold on tabId, returning whether one was held. Passthrough state ends
+ * with the session; when emulation still holds the tab it is re-attached and re-applied.
+ */
+export async function releasePassthroughDebugger(tabId) {
+    if (!passthroughTabs.delete(tabId))
+        return false;
+    const current = emulatedTabs.get(tabId);
+    if (current) {
+        await emulate(tabId, current);
+    }
+    else {
+        await detachDebugger(tabId);
+    }
+    return true;
+}
+// =============================================================================
+// EMULATION
+// =============================================================================
+/**
+ * Replace everything emulated on tabId with next. The tab is detached first so no override
+ * from the previous state survives, then re-attached and held when next is not empty.
+ * A passthrough hold keeps the tab attached, though restarting the session drops its state.
+ */
+async function emulate(tabId, next) {
+    installDetachListener();
+    const wasEmulated = emulatedTabs.delete(tabId);
+    const passthroughHeld = passthroughTabs.has(tabId);
+    if (wasEmulated) {
+        try {
+            await chrome.debugger.detach({ tabId });
+        }
+        catch {
+            /* already detached */
+        }
+    }
+    const emulating = !!next.device || !!next.region;
+    if (!emulating && !passthroughHeld)
+        return;
+    // Detached above, or never attached: a passthrough-only hold is already attached.
+    if (wasEmulated || !passthroughHeld)
+        await chrome.debugger.attach({ tabId }, CDP_VERSION);
+    if (!emulating)
+        return;
+    emulatedTabs.set(tabId, next);
+    try {
+        await sendOverrides(tabId, next);
+    }
+    catch (err) {
+        emulatedTabs.delete(tabId);
+        if (!passthroughHeld) {
+            try {
+                await chrome.debugger.detach({ tabId });
+            }
+            catch {
+                /* already detached */
+            }
+        }
+        throw err;
+    }
+}
+async function sendOverrides(tabId, { device, region }) {
+    const send = (method, params) => chrome.debugger.sendCommand({ tabId }, method, params);
+    if (device) {
+        await send('Emulation.setDeviceMetricsOverride', {
+            width: device.width,
+            height: device.height,
+            deviceScaleFactor: device.device_scale_factor,
+            mobile: device.mobile
+        });
+        await send('Emulation.setTouchEmulationEnabled', {
+            enabled: device.touch,
+            maxTouchPoints: device.touch ? 5 : 0
+        });
+    }
+    if (region?.geolocation) {
+        await send('Emulation.setGeolocationOverride', {
+            latitude: region.geolocation.latitude,
+            longitude: region.geolocation.longitude,
+            accuracy: region.geolocation.accuracy || 100
+        });
+    }
+    if (region?.timezone) {
+        await send('Emulation.setTimezoneOverride', { timezoneId: region.timezone });
+    }
+    if (region?.locale) {
+        await send('Emulation.setLocaleOverride', { locale: region.locale });
+    }
+    // One call sets both: the device's user agent and the Accept-Language header for the locale.
+    if (device?.user_agent || region?.locale) {
+        await send('Emulation.setUserAgentOverride', {
+            userAgent: device?.user_agent || navigator.userAgent,
+            ...(region?.locale ? { acceptLanguage: region.locale } : {})
+        });
+    }
+}
+/**
+ * Apply spec to tabId and keep the debugger attached until clearDeviceEmulation.
+ * Region overrides on the tab stay in effect.
+ */
+export async function applyDeviceEmulation(tabId, spec) {
+    await emulate(tabId, { ...emulatedTabs.get(tabId), device: spec });
+    return { success: true, emulating: true, ...spec, device: spec.device || 'custom' };
+}
+/** Clear device emulation on tabId. The debugger is released unless region overrides remain. */
+export async function clearDeviceEmulation(tabId) {
+    const current = emulatedTabs.get(tabId);
+    const wasEmulating = !!current?.device;
+    if (current)
+        await emulate(tabId, { region: current.region });
+    return { success: true, emulating: false, was_emulating: wasEmulating };
+}
+/** Apply region overrides to tabId, or clear them with null. Device emulation on the tab stays in effect. */
+export async function applyRegionOverride(tabId, region) {
+    const current = emulatedTabs.get(tabId);
+    if (!region && !current?.region)
+        return;
+    await emulate(tabId, { device: current?.device, region: region || undefined });
+}
+/** Forget held attachments (tests). */
+export function resetDeviceEmulationForTesting() {
+    emulatedTabs.clear();
+    passthroughTabs.clear();
+    detachListenerInstalled = false;
+}
+//# sourceMappingURL=device-emulation.js.map
@@ -0,0 +1,15 @@
+/**
+ * Purpose: Remembers which command idempotency keys this browser session has already executed.
+ * Why: The daemon re-offers commands it has not seen acknowledged; after a service-worker restart the
+ * in-memory receipt dedup is gone, so a re-sent click would otherwise run twice.
+ * Docs: docs/features/feature/extension-retry/index.md
+ */
+/**
+ * Claim an idempotency key before executing its command.
+ * Returns false when the key was already claimed in this browser session — possibly by an
+ * earlier service-worker lifetime whose result never reached the daemon.
+ */
+export declare function claimIdempotencyKey(key: string): Promise<boolean>;
+/** Forget every claimed key in memory (test helper; session storage is left as-is). */
+export declare function resetIdempotencyKeysForTest(): void;
+//# sourceMappingURL=idempotency-keys.d.ts.map
@@ -0,0 +1,73 @@
+/**
+ * Purpose: Remembers which command idempotency keys this browser session has already executed.
+ * Why: The daemon re-offers commands it has not seen acknowledged; after a service-worker restart the
+ * in-memory receipt dedup is gone, so a re-sent click would otherwise run twice.
+ * Docs: docs/features/feature/extension-retry/index.md
+ */
+import { StorageKey } from '../lib/constants.js';
+import { getSession, setSession } from '../lib/storage-utils.js';
+// =============================================================================
+// CONSTANTS
+// =============================================================================
+/** Maximum remembered keys (oldest evicted first) */
+const MAX_IDEMPOTENCY_KEYS = 500;
+/** How long a key is remembered — well past the daemon's longest retry window */
+const IDEMPOTENCY_KEY_TTL_MS = 10 * 60 * 1000;
+// =============================================================================
+// STATE
+// =============================================================================
+/** Key -> claim time (ms). Loaded once per service-worker lifetime from session storage. */
+let claimedKeys = null;
+async function loadClaimedKeys() {
+    const keys = new Map();
+    try {
+        const raw = await getSession(StorageKey.IDEMPOTENCY_KEYS);
+        if (raw && typeof raw === 'object') {
+            for (const [key, claimedAt] of Object.entries(raw)) {
+                if (typeof claimedAt === 'number')
+                    keys.set(key, claimedAt);
+            }
+        }
+    }
+    catch {
+        /* session storage unavailable — dedup falls back to this worker lifetime */
+    }
+    return keys;
+}
+function pruneClaimedKeys(keys, now) {
+    for (const [key, claimedAt] of keys) {
+        if (now - claimedAt > IDEMPOTENCY_KEY_TTL_MS)
+            keys.delete(key);
+    }
+    while (keys.size > MAX_IDEMPOTENCY_KEYS) {
+        const oldest = keys.keys().next().value;
+        if (oldest === undefined)
+            break;
+        keys.delete(oldest);
+    }
+}
+// =============================================================================
+// PUBLIC API
+// =============================================================================
+/**
+ * Claim an idempotency key before executing its command.
+ * Returns false when the key was already claimed in this browser session — possibly by an
+ * earlier service-worker lifetime whose result never reached the daemon.
+ */
+export async function claimIdempotencyKey(key) {
+    if (!claimedKeys)
+        claimedKeys = loadClaimedKeys();
+    const keys = await claimedKeys;
+    const now = Date.now();
+    pruneClaimedKeys(keys, now);
+    if (keys.has(key))
+        return false;
+    keys.set(key, now);
+    setSession(StorageKey.IDEMPOTENCY_KEYS, Object.fromEntries(keys)).catch(() => { });
+    return true;
+}
+/** Forget every claimed key in memory (test helper; session storage is left as-is). */
+export function resetIdempotencyKeysForTest() {
+    claimedKeys = null;
+}
+//# sourceMappingURL=idempotency-keys.js.map
@@ -36,6 +36,7 @@ export declare function setDebugMode(enabled: boolean): void;
 export declare const sharedServerCircuitBreaker: import("./circuit-breaker.js").CircuitBreaker;
 export declare const logBatcher: import("./batchers.js").Batcher<LogEntry>;
 export declare const wsBatcher: import("./batchers.js").Batcher<import("../types/wire-websocket-event.js").WireWebSocketEvent>;
+export declare const sseBatcher: import("./batchers.js").Batcher<import("../types/wire-sse-event.js").WireSSEEvent>;
 export declare const enhancedActionBatcher: import("./batchers.js").Batcher<import("../types/wire-enhanced-action.js").WireEnhancedAction>;
 export declare const networkBodyBatcher: import("./batchers.js").Batcher<import("../types/wire-network.js").WireNetworkBody>;
 export declare const perfBatcher: import("./batchers.js").Batcher<import("../types/wire-performance-snapshot.js").WirePerformanceSnapshot>;
@@ -3,7 +3,7 @@
  * Why: Central export point that delegates to specialized modules while owning cross-cutting concerns.
  * Docs: docs/features/feature/backend-log-streaming/index.md
  */
-import { getServerUrl, getConnectionStatus, getExtensionLogQueue, pushExtensionLog, capExtensionLogs, getCurrentLogLevel, isScreenshotOnError, _setDebugModeRaw, setConnectionStatus, setConnectionCheckRunning, clearExtensionLogQueue, EXTENSION_SESSION_ID, isAiControlled, isAiWebPilotEnabled, isConnectionCheckRunning as isConnectionCheckRunningFlag, isDebugMode, applyCaptureOverrides } from './state.js';
+import { getServerUrl, getConnectionStatus, getExtensionLogQueue, pushExtensionLog, capExtensionLogs, getCurrentLogLevel, isScreenshotOnError, getScreenshotRedactSelectors, _setDebugModeRaw, setConnectionStatus, setConnectionCheckRunning, clearExtensionLogQueue, EXTENSION_SESSION_ID, isAiControlled, isAiWebPilotEnabled, isConnectionCheckRunning as isConnectionCheckRunningFlag, isDebugMode, applyCaptureOverrides } from './state.js';
 import { addDebugLogEntry, getDebugLog as getDebugLogEntries, clearDebugLog as clearDebugLogEntries, isSourceMapEnabled, resolveStackTrace, processErrorGroup, canTakeScreenshot, recordScreenshot } from './state-manager.js';
 import { createCircuitBreaker, RATE_LIMIT_CONFIG, shouldCaptureLog, formatLogEntry, captureScreenshot, updateBadge, checkServerHealth, sendStatusPing } from './communication.js';
 import { getTrackedTabInfo } from './event-listeners.js';
@@ -127,6 +127,8 @@ const logBatcherWithCB = _batchers.logBatcherWithCB;
 export const logBatcher = _batchers.logBatcher;
 const wsBatcherWithCB = _batchers.wsBatcherWithCB;
 export const wsBatcher = _batchers.wsBatcher;
+const sseBatcherWithCB = _batchers.sseBatcherWithCB;
+export const sseBatcher = _batchers.sseBatcher;
 const enhancedActionBatcherWithCB = _batchers.enhancedActionBatcherWithCB;
 export const enhancedActionBatcher = _batchers.enhancedActionBatcher;
 const networkBodyBatcherWithCB = _batchers.networkBodyBatcherWithCB;
@@ -172,6 +174,9 @@ export async function handleLogMessage(payload, sender, tabId) {
     if (resolvedTabId !== null && resolvedTabId !== undefined) {
         entry = { ...entry, tabId: resolvedTabId };
     }
+    if (sender?.frameId) {
+        entry = { ...entry, frameId: sender.frameId };
+    }
     // nosemgrep: missing-template-string-indicator
     debugLog(DebugCategory.CAPTURE, `Log received: type=${entry.type}, level=${entry.level}`, {
         url: entry.url,
@@ -203,7 +208,7 @@ async function maybeAutoScreenshot(errorEntry, sender) {
         return;
     const errorId = `err_${Date.now()}_${Math.random().toString(36).slice(2, 8)}`;
     errorEntry._errorId = errorId;
-    const result = await captureScreenshot(sender.tab.id, getServerUrl(), errorId, entryType || null, canTakeScreenshot, recordScreenshot, debugLog);
+    const result = await captureScreenshot(sender.tab.id, getServerUrl(), errorId, entryType || null, canTakeScreenshot, recordScreenshot, debugLog, getScreenshotRedactSelectors());
     if (result.success && result.entry) {
         logBatcher.add(result.entry);
     }
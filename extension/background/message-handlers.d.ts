@@ -6,7 +6,7 @@
  * @fileoverview Message Handlers - Handles all chrome.runtime.onMessage routing
  * with type-safe message discrimination.
  */
-import type { LogEntry, ChromeMessageSender, BrowserStateSnapshot, ConnectionStatus, ContextWarning, CircuitBreakerState, MemoryPressureState, WebSocketEvent, EnhancedAction, NetworkBodyPayload, PerformanceSnapshot } from '../types/index.js';
+import type { LogEntry, ChromeMessageSender, BrowserStateSnapshot, ConnectionStatus, ContextWarning, CircuitBreakerState, MemoryPressureState, WebSocketEvent, WireSSEEvent, EnhancedAction, NetworkBodyPayload, PerformanceSnapshot } from '../types/index.js';
 /** Message handler dependencies */
 export interface MessageHandlerDependencies {
     getServerUrl: () => string;
@@ -28,6 +28,7 @@ export interface MessageHandlerDependencies {
     setAiWebPilotEnabled: (enabled: boolean, callback?: () => void) => void;
     addToLogBatcher: (entry: LogEntry) => void;
     addToWsBatcher: (event: WebSocketEvent) => void;
+    addToSseBatcher: (event: WireSSEEvent) => void;
     addToEnhancedActionBatcher: (action: EnhancedAction) => void;
     addToNetworkBodyBatcher: (body: NetworkBodyPayload) => void;
     addToPerfBatcher: (snapshot: PerformanceSnapshot) => void;
@@ -90,6 +90,17 @@ function validateMessageType(message, expectedType, deps) {
     }
     return true;
 }
+/**
+ * Attribute a captured entry to the tab and frame that produced it, so entries from
+ * several tabs stay separable on the server. IDs already on the payload win.
+ */
+function withSenderOrigin(payload, sender, tabId) {
+    return {
+        ...payload,
+        tab_id: payload.tab_id ?? tabId ?? sender.tab?.id,
+        frame_id: payload.frame_id ?? sender.frameId
+    };
+}
 /**
  * Handle incoming message
  * Returns true if response will be sent asynchronously
@@ -104,18 +115,20 @@ function handleMessage(message, sender, sendResponse, deps) {
             sendResponse({ tabId: sender.tab?.id });
             return true;
         case 'ws_event':
-            deps.addToWsBatcher(message.payload);
+            deps.addToWsBatcher(withSenderOrigin(message.payload, sender, message.tabId));
+            return false;
+        case 'sse_event':
+            deps.addToSseBatcher(withSenderOrigin(message.payload, sender, message.tabId));
             return false;
         case 'enhanced_action':
-            deps.addToEnhancedActionBatcher(message.payload);
+            deps.addToEnhancedActionBatcher(withSenderOrigin(message.payload, sender, message.tabId));
             return false;
         case 'network_body':
             if (deps.isNetworkBodyCaptureDisabled()) {
                 deps.debugLog('capture', 'Network body dropped: capture disabled');
                 return true;
             }
-            // Attach tab_id from sender before batching (v5.3+)
-            deps.addToNetworkBodyBatcher({ ...message.payload, tab_id: message.payload.tab_id ?? message.tabId });
+            deps.addToNetworkBodyBatcher(withSenderOrigin(message.payload, sender, message.tabId));
             return false;
         case 'performance_snapshot':
             deps.addToPerfBatcher(message.payload);
@@ -9,9 +9,18 @@ import './commands/analyze.js';
 import './commands/analyze-navigation.js';
 import './commands/analyze-page-structure.js';
 import './commands/analyze-feature-gates.js';
+import './commands/analyze-styles.js';
+import './commands/analyze-i18n.js';
+import './commands/observe-seo.js';
+import './commands/observe-coverage.js';
 import './commands/interact.js';
 import './commands/interact-content.js';
 import './commands/interact-explore.js';
+import './commands/interact-form-fill.js';
+import './commands/replay-request.js';
+import './commands/emulate.js';
+import './commands/auth-state.js';
+import './commands/assert.js';
 // Re-export handlePilotCommand (used by index.ts re-export chain)
 export { handlePilotCommand } from './commands/interact.js';
 export async function handlePendingQuery(query, syncClient) {
@@ -6,7 +6,8 @@
  * @fileoverview Server Communication - HTTP functions for sending data to
  * the Kaboom server.
  */
-import type { LogEntry, WebSocketEvent, NetworkBodyPayload, EnhancedAction, PerformanceSnapshot, ConnectionStatus } from '../types/index.js';
+import type { LogEntry, WebSocketEvent, WireSSEEvent, NetworkBodyPayload, EnhancedAction, PerformanceSnapshot, ConnectionStatus } from '../types/index.js';
+import type { CoverageSnapshot } from './commands/observe-coverage.js';
 /**
  * Server health response
  */
@@ -47,6 +48,10 @@ export declare function sendLogsToServer(serverUrl: string, entries: LogEntry[],
  * Send WebSocket events to the server
  */
 export declare function sendWSEventsToServer(serverUrl: string, events: WebSocketEvent[], debugLogFn?: (category: string, message: string, data?: unknown) => void): Promise<void>;
+/**
+ * Send Server-Sent Events (EventSource) events to the server
+ */
+export declare function sendSSEEventsToServer(serverUrl: string, events: WireSSEEvent[], debugLogFn?: (category: string, message: string, data?: unknown) => void): Promise<void>;
 /**
  * Send network bodies to the server
  */
@@ -59,6 +64,10 @@ export declare function sendEnhancedActionsToServer(serverUrl: string, actions:
  * Send performance snapshots to server
  */
 export declare function sendPerformanceSnapshotsToServer(serverUrl: string, snapshots: PerformanceSnapshot[], debugLogFn?: (category: string, message: string, data?: unknown) => void): Promise<void>;
+/**
+ * Send JS/CSS coverage snapshots to server
+ */
+export declare function sendCoverageSnapshotsToServer(serverUrl: string, snapshots: CoverageSnapshot[], debugLogFn?: (category: string, message: string, data?: unknown) => void): Promise<void>;
 /**
  * Check server health
  */
@@ -64,6 +64,12 @@ export async function sendLogsToServer(serverUrl, entries, debugLogFn) {
 export async function sendWSEventsToServer(serverUrl, events, debugLogFn) {
     await sendTelemetryBatch(serverUrl, '/websocket-events', 'events', events, 'WS events', debugLogFn);
 }
+/**
+ * Send Server-Sent Events (EventSource) events to the server
+ */
+export async function sendSSEEventsToServer(serverUrl, events, debugLogFn) {
+    await sendTelemetryBatch(serverUrl, '/sse-events', 'events', events, 'SSE events', debugLogFn);
+}
 /**
  * Send network bodies to the server
  */
@@ -82,6 +88,12 @@ export async function sendEnhancedActionsToServer(serverUrl, actions, debugLogFn
 export async function sendPerformanceSnapshotsToServer(serverUrl, snapshots, debugLogFn) {
     await sendTelemetryBatch(serverUrl, '/performance-snapshots', 'snapshots', snapshots, 'performance snapshots', debugLogFn);
 }
+/**
+ * Send JS/CSS coverage snapshots to server
+ */
+export async function sendCoverageSnapshotsToServer(serverUrl, snapshots, debugLogFn) {
+    await sendTelemetryBatch(serverUrl, '/coverage-snapshots', 'snapshots', snapshots, 'coverage snapshots', debugLogFn);
+}
 /**
  * Check server health
  */
@@ -32,6 +32,8 @@ export declare function isDebugMode(): boolean;
 export declare function getConnectionStatus(): Readonly<MutableConnectionStatus>;
 export declare function getCurrentLogLevel(): string;
 export declare function isScreenshotOnError(): boolean;
+/** CSS selectors the server asked to black out on every screenshot (capture_overrides). */
+export declare function getScreenshotRedactSelectors(): string[];
 export declare function isAiControlled(): boolean;
 export declare function isConnectionCheckRunning(): boolean;
 export declare function isAiWebPilotCacheInitialized(): boolean;
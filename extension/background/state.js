@@ -51,6 +51,19 @@ export function getCurrentLogLevel() {
 export function isScreenshotOnError() {
     return state.screenshotOnError;
 }
+/** CSS selectors the server asked to black out on every screenshot (capture_overrides). */
+export function getScreenshotRedactSelectors() {
+    const raw = state.captureOverrides.screenshot_redact_selectors;
+    if (!raw)
+        return [];
+    try {
+        const parsed = JSON.parse(raw);
+        return Array.isArray(parsed) ? parsed.filter((s) => typeof s === 'string' && s.length > 0) : [];
+    }
+    catch {
+        return [];
+    }
+}
 function getCaptureOverrides() {
     return Object.freeze({ ...state.captureOverrides });
 }
@@ -2,6 +2,7 @@
  * Purpose: Unified sync client that replaces multiple polling loops with a single /sync endpoint, handling settings, commands, and extension logs.
  * Docs: docs/features/feature/backend-log-streaming/index.md
  */
+import type { BrowserEnvironment } from './browser-environment.js';
 /** Returns the server's install ID, or undefined if not yet received. */
 export declare function getServerInstallId(): string | undefined;
 /** Load persisted install ID from storage (call once on startup). */
@@ -21,6 +22,7 @@ export interface SyncSettings {
     capture_actions: boolean;
     csp_restricted: boolean;
     csp_level: string;
+    environment?: BrowserEnvironment;
 }
 /** Extension log entry */
 export interface SyncExtensionLog {
@@ -57,6 +59,10 @@ export interface SyncCommand {
     tab_id?: number;
     correlation_id?: string;
     trace_id?: string;
+    /** Stable across daemon re-deliveries; a key executes at most once per browser session */
+    idempotency_key?: string;
+    /** Delivery window the daemon is offering this command in (1 = first offer) */
+    attempt?: number;
 }
 /** Sync state */
 export interface SyncState {
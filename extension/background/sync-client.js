@@ -7,6 +7,7 @@ import { fetchWithTimeout } from '../lib/timeout-utils.js';
 import { buildDaemonJSONRequestInit } from '../lib/daemon-http.js';
 import { beacon } from '../lib/telemetry-beacon.js';
 import { drainUIFeatures, restoreUIFeatures } from './ui-usage-tracker.js';
+import { claimIdempotencyKey } from './idempotency-keys.js';
 // =============================================================================
 // SERVER INSTALL ID — single source of truth for all analytics
 // =============================================================================
@@ -340,6 +341,23 @@ export class SyncClient {
         return DEFAULT_COMMAND_TIMEOUT_MS;
     }
     async dispatchCommand(command) {
+        // Receipt dedup does not survive a service-worker restart; the idempotency key does.
+        // A key that already ran is reported, never re-executed, so a click cannot happen twice.
+        if (command.idempotency_key && !(await claimIdempotencyKey(command.idempotency_key))) {
+            const message = `Command ${command.id} (${command.type}) already ran under idempotency key ${command.idempotency_key}; not executing it again`;
+            this.log('Skipping duplicate delivery', { id: command.id, idempotency_key: command.idempotency_key });
+            this.queueCommandResult({
+                id: command.id,
+                correlation_id: command.correlation_id,
+                status: 'error',
+                result: { error: 'duplicate_delivery', message },
+                error: message
+            });
+            if (command.id) {
+                this.state.lastCommandAck = command.id;
+            }
+            return;
+        }
         this.markInProgress(command);
         const timeoutMs = this.commandTimeoutFor(command);
         let timeoutHandle = null;
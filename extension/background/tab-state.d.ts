@@ -82,6 +82,25 @@ export declare function captureVisibleTabSafe(tabId: number, windowId: number, o
     format: 'jpeg' | 'png';
     quality?: number;
 }): Promise<string>;
+/** CSS-pixel box of an element in the visible viewport, with the scale hints the daemon needs. */
+export interface ScreenshotRegion {
+    x: number;
+    y: number;
+    width: number;
+    height: number;
+    viewport_width: number;
+    device_pixel_ratio: number;
+}
+/** Boxes the daemon blacks out (redact) or outlines (highlight) before saving a screenshot. */
+export interface ScreenshotRegions {
+    redact: ScreenshotRegion[];
+    highlight: ScreenshotRegion[];
+}
+/**
+ * Measure redaction and highlight boxes in the tab's current viewport. Call right before
+ * capturing so the boxes match the pixels. Throws when the page cannot be scripted.
+ */
+export declare function measureScreenshotRegions(tabId: number, redactSelectors: string[], highlightSelector: string): Promise<ScreenshotRegions>;
 /**
  * Toggles visibility of all Kaboom UI overlays (hover launcher, draw mode)
  * in the target tab. Uses executeScript for speed — no message round-trip needed.
@@ -320,6 +320,64 @@ export async function captureVisibleTabSafe(tabId, windowId, options) {
         }
     }
 }
+const MAX_REDACT_REGIONS = 500;
+/**
+ * Self-contained: measure every element matching a redact selector and the first match of
+ * the highlight selector. Invalid selectors and unrendered elements are skipped.
+ */
+function screenshotMeasureRegions(redactSelectors, highlightSelector, maxRegions) {
+    const viewportWidth = window.innerWidth;
+    const dpr = window.devicePixelRatio || 1;
+    function box(el) {
+        const r = el.getBoundingClientRect();
+        if (r.width <= 0 || r.height <= 0)
+            return null;
+        return { x: r.left, y: r.top, width: r.width, height: r.height, viewport_width: viewportWidth, device_pixel_ratio: dpr };
+    }
+    const redact = [];
+    for (const selector of redactSelectors) {
+        let matches;
+        try {
+            matches = document.querySelectorAll(selector);
+        }
+        catch {
+            continue;
+        }
+        for (let i = 0; i < matches.length && redact.length < maxRegions; i++) {
+            const b = box(matches[i]);
+            if (b)
+                redact.push(b);
+        }
+    }
+    const highlight = [];
+    if (highlightSelector) {
+        try {
+            const el = document.querySelector(highlightSelector);
+            const b = el ? box(el) : null;
+            if (b)
+                highlight.push(b);
+        }
+        catch {
+            /* invalid highlight selector: capture without the outline */
+        }
+    }
+    return { redact, highlight };
+}
+/**
+ * Measure redaction and highlight boxes in the tab's current viewport. Call right before
+ * capturing so the boxes match the pixels. Throws when the page cannot be scripted.
+ */
+export async function measureScreenshotRegions(tabId, redactSelectors, highlightSelector) {
+    const results = await chrome.scripting.executeScript({
+        target: { tabId },
+        func: screenshotMeasureRegions,
+        args: [redactSelectors, highlightSelector, MAX_REDACT_REGIONS]
+    });
+    const regions = results?.[0]?.result;
+    if (!regions)
+        throw new Error('Screenshot region measurement returned no result');
+    return regions;
+}
 /**
  * Toggles visibility of all Kaboom UI overlays (hover launcher, draw mode)
  * in the target tab. Uses executeScript for speed — no message round-trip needed.
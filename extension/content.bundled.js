@@ -35,6 +35,9 @@
     PERFORMANCE_SNAPSHOT: "set_performance_snapshot_enabled",
     DEFERRAL: "set_deferral_enabled",
     NETWORK_BODY_CAPTURE: "set_network_body_capture_enabled",
+    NETWORK_BODY_CAPTURE_MAX: "set_network_body_capture_max",
+    NETWORK_BINARY_CAPTURE_MAX: "set_network_binary_capture_max",
+    REQUEST_MOCKS: "set_request_mocks",
     ACTION_TOASTS: "set_action_toasts_enabled",
     SUBTITLES: "set_subtitles_enabled",
     SERVER_URL: "set_server_url"
@@ -52,6 +55,9 @@
     SettingName.PERFORMANCE_SNAPSHOT,
     SettingName.DEFERRAL,
     SettingName.NETWORK_BODY_CAPTURE,
+    SettingName.NETWORK_BODY_CAPTURE_MAX,
+    SettingName.NETWORK_BINARY_CAPTURE_MAX,
+    SettingName.REQUEST_MOCKS,
     SettingName.SERVER_URL
   ]);
   var StorageKey = {
@@ -72,6 +78,10 @@
     PERFORMANCE_MARKS_ENABLED: "performanceMarksEnabled",
     ACTION_REPLAY_ENABLED: "actionReplayEnabled",
     NETWORK_BODY_CAPTURE_ENABLED: "networkBodyCaptureEnabled",
+    NETWORK_BODY_CAPTURE_MAX: "networkBodyCaptureMax",
+    NETWORK_BINARY_CAPTURE_MAX: "networkBinaryCaptureMax",
+    REQUEST_MOCKS: "requestMocks",
+    MULTI_TAB_CAPTURE: "multiTabCapture",
     ACTION_TOASTS_ENABLED: "actionToastsEnabled",
     SUBTITLES_ENABLED: "subtitlesEnabled",
     ACTION_RECORDING: "kaboom_action_recording",
@@ -227,9 +237,11 @@
   // extension/content/tab-tracking.js
   var isTrackedTab = false;
   var currentTabId = null;
+  var multiTabCapture = false;
   async function updateTrackingStatus() {
     try {
       const trackedTabId = await getLocal(StorageKey.TRACKED_TAB_ID);
+      multiTabCapture = await getLocal(StorageKey.MULTI_TAB_CAPTURE) === true;
       const response = await chrome.runtime.sendMessage({ type: "get_tab_id" });
       currentTabId = response?.tabId ?? null;
       isTrackedTab = currentTabId !== null && currentTabId !== void 0 && currentTabId === trackedTabId;
@@ -237,20 +249,20 @@
       isTrackedTab = false;
     }
   }
-  function getIsTrackedTab() {
-    return isTrackedTab;
+  function getIsCapturingTab() {
+    return isTrackedTab || multiTabCapture && currentTabId !== null;
   }
   function getCurrentTabId() {
     return currentTabId;
   }
   function initTabTracking(onChange) {
     const ready = updateTrackingStatus().then(() => {
-      onChange?.(isTrackedTab);
+      onChange?.(isTrackedTab, getIsCapturingTab());
     });
     onStorageChanged(async (changes) => {
-      if (changes[StorageKey.TRACKED_TAB_ID]) {
+      if (changes[StorageKey.TRACKED_TAB_ID] || changes[StorageKey.MULTI_TAB_CAPTURE]) {
         await updateTrackingStatus();
-        onChange?.(isTrackedTab);
+        onChange?.(isTrackedTab, getIsCapturingTab());
       }
     });
     return ready;
@@ -276,7 +288,10 @@
     { storageKey: "networkWaterfallEnabled", messageType: SettingName.NETWORK_WATERFALL },
     { storageKey: "performanceMarksEnabled", messageType: SettingName.PERFORMANCE_MARKS },
     { storageKey: "actionReplayEnabled", messageType: SettingName.ACTION_REPLAY },
-    { storageKey: "networkBodyCaptureEnabled", messageType: SettingName.NETWORK_BODY_CAPTURE }
+    { storageKey: "networkBodyCaptureEnabled", messageType: SettingName.NETWORK_BODY_CAPTURE },
+    { storageKey: "networkBodyCaptureMax", messageType: SettingName.NETWORK_BODY_CAPTURE_MAX, isLimit: true },
+    { storageKey: "networkBinaryCaptureMax", messageType: SettingName.NETWORK_BINARY_CAPTURE_MAX, isLimit: true },
+    { storageKey: "requestMocks", messageType: SettingName.REQUEST_MOCKS, isMocks: true }
   ];
   async function syncStoredSettings() {
     const storageKeys = SYNC_SETTINGS.map((s) => s.storageKey);
@@ -292,6 +307,10 @@
           mode: value,
           _nonce: pageNonce
         }, window.location.origin);
+      } else if (setting.isLimit) {
+        window.postMessage({ type: "kaboom_setting", setting: setting.messageType, limit: value, _nonce: pageNonce }, window.location.origin);
+      } else if (setting.isMocks) {
+        window.postMessage({ type: "kaboom_setting", setting: setting.messageType, mocks: value, _nonce: pageNonce }, window.location.origin);
       } else {
         window.postMessage({ type: "kaboom_setting", setting: setting.messageType, enabled: value, _nonce: pageNonce }, window.location.origin);
       }
@@ -572,6 +591,7 @@
   var MESSAGE_MAP = {
     kaboom_log: "log",
     kaboom_ws: "ws_event",
+    kaboom_sse: "sse_event",
     kaboom_network_body: "network_body",
     kaboom_enhanced_action: "enhanced_action",
     kaboom_performance_snapshot: "performance_snapshot"
@@ -611,7 +631,7 @@
           responseHandler(requestId, result);
         return;
       }
-      if (!getIsTrackedTab())
+      if (!getIsCapturingTab())
         return;
       if (messageType && messageType in MESSAGE_MAP && payload && typeof payload === "object") {
         const mappedType = MESSAGE_MAP[messageType];
@@ -1134,6 +1154,10 @@
       payload.mode = message.mode;
     } else if (message.type === SettingName.SERVER_URL) {
       payload.url = message.url;
+    } else if (message.type === SettingName.NETWORK_BODY_CAPTURE_MAX || message.type === SettingName.NETWORK_BINARY_CAPTURE_MAX) {
+      payload.limit = message.limit;
+    } else if (message.type === SettingName.REQUEST_MOCKS) {
+      payload.mocks = message.mocks;
     } else {
       payload.enabled = message.enabled;
     }
@@ -2835,8 +2859,8 @@
     if (cloaked)
       return;
     let scriptsInjected = false;
-    initTabTracking((tracked) => {
-      if (tracked && !scriptsInjected) {
+    initTabTracking((tracked, capturing) => {
+      if (capturing && !scriptsInjected) {
         initScriptInjection();
         scriptsInjected = true;
       }
@@ -38,8 +38,8 @@ isDomainCloaked().then((cloaked) => {
     // Track whether scripts have been injected
     let scriptsInjected = false;
     // Initialize tab tracking first, with callback for injection
-    initTabTracking((tracked) => {
-        if (tracked && !scriptsInjected) {
+    initTabTracking((tracked, capturing) => {
+        if (capturing && !scriptsInjected) {
             initScriptInjection();
             scriptsInjected = true;
         }
@@ -44,6 +44,8 @@ export declare function handleToggleMessage(message: ContentMessage & {
     enabled?: boolean;
     mode?: WebSocketCaptureMode;
     url?: string;
+    limit?: number;
+    mocks?: unknown[];
 }): void;
 type ExecuteJsResponse = {
     success: boolean;
@@ -123,6 +123,13 @@ export function handleToggleMessage(message) {
     else if (message.type === SettingName.SERVER_URL) {
         payload.url = message.url;
     }
+    else if (message.type === SettingName.NETWORK_BODY_CAPTURE_MAX ||
+        message.type === SettingName.NETWORK_BINARY_CAPTURE_MAX) {
+        payload.limit = message.limit;
+    }
+    else if (message.type === SettingName.REQUEST_MOCKS) {
+        payload.mocks = message.mocks;
+    }
     else {
         payload.enabled = message.enabled;
     }
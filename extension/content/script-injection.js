@@ -38,7 +38,10 @@ const SYNC_SETTINGS = [
     { storageKey: 'networkWaterfallEnabled', messageType: SettingName.NETWORK_WATERFALL },
     { storageKey: 'performanceMarksEnabled', messageType: SettingName.PERFORMANCE_MARKS },
     { storageKey: 'actionReplayEnabled', messageType: SettingName.ACTION_REPLAY },
-    { storageKey: 'networkBodyCaptureEnabled', messageType: SettingName.NETWORK_BODY_CAPTURE }
+    { storageKey: 'networkBodyCaptureEnabled', messageType: SettingName.NETWORK_BODY_CAPTURE },
+    { storageKey: 'networkBodyCaptureMax', messageType: SettingName.NETWORK_BODY_CAPTURE_MAX, isLimit: true },
+    { storageKey: 'networkBinaryCaptureMax', messageType: SettingName.NETWORK_BINARY_CAPTURE_MAX, isLimit: true },
+    { storageKey: 'requestMocks', messageType: SettingName.REQUEST_MOCKS, isMocks: true }
 ];
 /**
  * Sync stored settings to the inject script after it loads.
@@ -59,6 +62,12 @@ async function syncStoredSettings() {
                 _nonce: pageNonce
             }, window.location.origin);
         }
+        else if (setting.isLimit) {
+            window.postMessage({ type: 'kaboom_setting', setting: setting.messageType, limit: value, _nonce: pageNonce }, window.location.origin);
+        }
+        else if (setting.isMocks) {
+            window.postMessage({ type: 'kaboom_setting', setting: setting.messageType, mocks: value, _nonce: pageNonce }, window.location.origin);
+        }
         else {
             window.postMessage({ type: 'kaboom_setting', setting: setting.messageType, enabled: value, _nonce: pageNonce }, window.location.origin);
         }
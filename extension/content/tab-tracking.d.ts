@@ -6,6 +6,11 @@
  * Get the current tracking status
  */
 export declare function getIsTrackedTab(): boolean;
+/**
+ * Whether this tab forwards captured telemetry: the tracked tab always does, and every
+ * other tab does while multi-tab capture is on.
+ */
+export declare function getIsCapturingTab(): boolean;
 /**
  * Get the current tab ID
  */
@@ -15,5 +20,5 @@ export declare function getCurrentTabId(): number | null;
  * Returns a promise that resolves when initial tracking status is known.
  * The onChange callback fires after each status update (initial + storage changes).
  */
-export declare function initTabTracking(onChange?: (tracked: boolean) => void): Promise<void>;
+export declare function initTabTracking(onChange?: (tracked: boolean, capturing: boolean) => void): Promise<void>;
 //# sourceMappingURL=tab-tracking.d.ts.map
\ No newline at end of file
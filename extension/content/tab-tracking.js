@@ -12,6 +12,8 @@ import { getLocal, onStorageChanged } from '../lib/storage-utils.js';
 let isTrackedTab = false;
 // The tab ID of this content script's tab
 let currentTabId = null;
+// Whether the server asked every tab to capture, not only the tracked one (configure multi_tab_capture)
+let multiTabCapture = false;
 /**
  * Update tracking status by checking storage and current tab ID.
  * Called on script load, storage changes, and tab activation.
@@ -19,6 +21,7 @@ let currentTabId = null;
 async function updateTrackingStatus() {
     try {
         const trackedTabId = (await getLocal(StorageKey.TRACKED_TAB_ID));
+        multiTabCapture = (await getLocal(StorageKey.MULTI_TAB_CAPTURE)) === true;
         // Request tab ID from background script (content scripts can't access chrome.tabs)
         const response = (await chrome.runtime.sendMessage({ type: 'get_tab_id' }));
         currentTabId = response?.tabId ?? null;
@@ -35,6 +38,13 @@ async function updateTrackingStatus() {
 export function getIsTrackedTab() {
     return isTrackedTab;
 }
+/**
+ * Whether this tab forwards captured telemetry: the tracked tab always does, and every
+ * other tab does while multi-tab capture is on.
+ */
+export function getIsCapturingTab() {
+    return isTrackedTab || (multiTabCapture && currentTabId !== null);
+}
 /**
  * Get the current tab ID
  */
@@ -48,12 +58,12 @@ export function getCurrentTabId() {
  */
 export function initTabTracking(onChange) {
     const ready = updateTrackingStatus().then(() => {
-        onChange?.(isTrackedTab);
+        onChange?.(isTrackedTab, getIsCapturingTab());
     });
     onStorageChanged(async (changes) => {
-        if (changes[StorageKey.TRACKED_TAB_ID]) {
+        if (changes[StorageKey.TRACKED_TAB_ID] || changes[StorageKey.MULTI_TAB_CAPTURE]) {
             await updateTrackingStatus();
-            onChange?.(isTrackedTab);
+            onChange?.(isTrackedTab, getIsCapturingTab());
         }
     });
     return ready;
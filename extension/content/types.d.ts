@@ -5,7 +5,7 @@
  * @fileoverview Content Script Internal Types
  * Type definitions for internal content script use
  */
-import type { WebSocketCaptureMode, StateAction, BrowserStateSnapshot, PageMessageType, LogEntry, WebSocketEvent, NetworkBodyPayload, EnhancedAction, PerformanceSnapshot } from '../types/index.js';
+import type { WebSocketCaptureMode, StateAction, BrowserStateSnapshot, PageMessageType, LogEntry, WebSocketEvent, WireSSEEvent, NetworkBodyPayload, EnhancedAction, PerformanceSnapshot } from '../types/index.js';
 /**
  * Pending request statistics
  */
@@ -33,6 +33,8 @@ export interface SettingMessage {
     enabled?: boolean;
     mode?: WebSocketCaptureMode;
     url?: string;
+    limit?: number;
+    mocks?: unknown[];
 }
 /**
  * Highlight request message to page context
@@ -105,6 +107,11 @@ export interface WsEventMessageToBackground {
     payload: WebSocketEvent;
     tabId: number | null;
 }
+export interface SseEventMessageToBackground {
+    type: 'sse_event';
+    payload: WireSSEEvent;
+    tabId: number | null;
+}
 export interface NetworkBodyMessageToBackground {
     type: 'network_body';
     payload: NetworkBodyPayload;
@@ -120,5 +127,5 @@ export interface PerformanceSnapshotMessageToBackground {
     payload: PerformanceSnapshot;
     tabId: number | null;
 }
-export type BackgroundMessageFromContent = LogMessageToBackground | WsEventMessageToBackground | NetworkBodyMessageToBackground | EnhancedActionMessageToBackground | PerformanceSnapshotMessageToBackground;
+export type BackgroundMessageFromContent = LogMessageToBackground | WsEventMessageToBackground | SseEventMessageToBackground | NetworkBodyMessageToBackground | EnhancedActionMessageToBackground | PerformanceSnapshotMessageToBackground;
 //# sourceMappingURL=types.d.ts.map
\ No newline at end of file
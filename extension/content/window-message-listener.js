@@ -4,7 +4,7 @@
  */
 import { resolveHighlightRequest, resolveExecuteRequest, resolveA11yRequest, resolveDomRequest } from './request-tracking.js';
 import { MESSAGE_MAP, safeSendMessage } from './message-forwarding.js';
-import { getIsTrackedTab, getCurrentTabId } from './tab-tracking.js';
+import { getIsCapturingTab, getCurrentTabId } from './tab-tracking.js';
 import { getPageNonce } from './script-injection.js';
 const RESPONSE_HANDLERS = {
     kaboom_highlight_response: (id, result) => resolveHighlightRequest(id, result),
@@ -28,10 +28,11 @@ export function initWindowMessageListener() {
                 responseHandler(requestId, result);
             return;
         }
-        // Tab isolation filter: only forward captured data from the tracked tab.
-        // Response messages (highlight, execute JS, a11y) are NOT filtered because
-        // they are responses to explicit commands from the background script.
-        if (!getIsTrackedTab())
+        // Tab isolation filter: only forward captured data from the tracked tab, or from
+        // every tab while multi-tab capture is on. Response messages (highlight, execute JS,
+        // a11y) are NOT filtered because they are responses to explicit commands from the
+        // background script.
+        if (!getIsCapturingTab())
             return;
         if (messageType && messageType in MESSAGE_MAP && payload && typeof payload === 'object') {
             const mappedType = MESSAGE_MAP[messageType];
@@ -45,11 +45,13 @@ var MAX_PERFORMANCE_ENTRIES = 50;
 var PERFORMANCE_TIME_WINDOW_MS = 6e4;
 var WS_MAX_BODY_SIZE = 4096;
 var WS_PREVIEW_LIMIT = 200;
-var REQUEST_BODY_MAX = 8192;
-var RESPONSE_BODY_MAX = 16384;
+var BODY_CAPTURE_MAX_DEFAULT = 65536;
+var BODY_CAPTURE_MAX_LIMIT = 1048576;
+var BINARY_CAPTURE_MAX_LIMIT = 262144;
 var BODY_READ_TIMEOUT_MS = 5;
 var SENSITIVE_HEADER_PATTERNS = /^(authorization|cookie|set-cookie|x-api-key|x-auth-token|x-secret|x-password|.*token.*|.*secret.*|.*key.*|.*password.*)$/i;
 var BINARY_CONTENT_TYPES = /^(image|video|audio|font)\/|^application\/(wasm|octet-stream|zip|gzip|pdf)/;
+var BINARY_WIRE_CONTENT_TYPES = /^application\/(x-protobuf|protobuf|vnd\.google\.protobuf|grpc|x-msgpack|msgpack|cbor)/;
 var DOM_QUERY_MAX_ELEMENTS = 50;
 var DOM_QUERY_MAX_TEXT = 500;
 var DOM_QUERY_MAX_DEPTH = 5;
@@ -88,6 +90,7 @@ var ACTIONABLE_KEYS = /* @__PURE__ */ new Set([
 var MAX_LONG_TASKS = 50;
 var MAX_SLOWEST_REQUESTS = 3;
 var MAX_URL_LENGTH = 80;
+var MAX_INP_INTERACTIONS = 10;
 var SettingName = {
   NETWORK_WATERFALL: "set_network_waterfall_enabled",
   PERFORMANCE_MARKS: "set_performance_marks_enabled",
@@ -97,6 +100,9 @@ var SettingName = {
   PERFORMANCE_SNAPSHOT: "set_performance_snapshot_enabled",
   DEFERRAL: "set_deferral_enabled",
   NETWORK_BODY_CAPTURE: "set_network_body_capture_enabled",
+  NETWORK_BODY_CAPTURE_MAX: "set_network_body_capture_max",
+  NETWORK_BINARY_CAPTURE_MAX: "set_network_binary_capture_max",
+  REQUEST_MOCKS: "set_request_mocks",
   ACTION_TOASTS: "set_action_toasts_enabled",
   SUBTITLES: "set_subtitles_enabled",
   SERVER_URL: "set_server_url"
@@ -111,6 +117,9 @@ var INJECT_FORWARDED_SETTINGS = /* @__PURE__ */ new Set([
   SettingName.PERFORMANCE_SNAPSHOT,
   SettingName.DEFERRAL,
   SettingName.NETWORK_BODY_CAPTURE,
+  SettingName.NETWORK_BODY_CAPTURE_MAX,
+  SettingName.NETWORK_BINARY_CAPTURE_MAX,
+  SettingName.REQUEST_MOCKS,
   SettingName.SERVER_URL
 ]);
 
@@ -369,12 +378,21 @@ var ACTION_DATA_ENRICHERS = {
       a.value = o.value;
   }
 };
+function pageLocale() {
+  if (typeof document !== "undefined" && document.documentElement && document.documentElement.lang) {
+    return document.documentElement.lang;
+  }
+  return typeof navigator !== "undefined" && navigator.language ? navigator.language : "";
+}
 function recordEnhancedAction(type, element, opts = {}) {
   const action = {
     type,
     timestamp: Date.now(),
     url: typeof window !== "undefined" && window.location ? window.location.href : ""
   };
+  const locale = pageLocale();
+  if (locale)
+    action.locale = locale;
   if (element) {
     action.selectors = computeSelectors(element);
   }
@@ -692,12 +710,101 @@ function uninstallNavigationCapture() {
   }
 }
 
+// extension/lib/request-mocks.js
+var requestMocks = [];
+function setRequestMocks(mocks) {
+  requestMocks = Array.isArray(mocks) ? mocks.filter((m) => m && typeof m.url_pattern === "string" && m.url_pattern !== "") : [];
+}
+function absoluteUrl(url) {
+  try {
+    return new URL(url, typeof location !== "undefined" ? location.href : void 0).href;
+  } catch {
+    return url;
+  }
+}
+function globMatches(pattern, url) {
+  const source = pattern.split("*").map((part) => part.replace(/[.+?^${}()|[\]\\]/g, "\\$&")).join(".*");
+  return new RegExp(`^${source}$`).test(url);
+}
+function findRequestMock(url, method) {
+  if (requestMocks.length === 0 || !url)
+    return null;
+  const full = absoluteUrl(url);
+  const verb = (method || "GET").toUpperCase();
+  for (const mock of requestMocks) {
+    if (mock.method && mock.method.toUpperCase() !== verb)
+      continue;
+    const hit = mock.url_pattern.includes("*") ? globMatches(mock.url_pattern, full) : full.includes(mock.url_pattern) || url.includes(mock.url_pattern);
+    if (hit)
+      return mock;
+  }
+  return null;
+}
+function delay(ms) {
+  return ms && ms > 0 ? new Promise((resolve) => setTimeout(resolve, ms)) : Promise.resolve();
+}
+async function mockFetchResponse(mock) {
+  await delay(mock.delay_ms);
+  if (!mock.status)
+    throw new TypeError("Failed to fetch");
+  const nullBody = mock.status === 204 || mock.status === 205 || mock.status === 304;
+  return new Response(nullBody ? null : mock.body || "", {
+    status: mock.status,
+    headers: mock.headers || {}
+  });
+}
+function defineValue(xhr, prop, value) {
+  Object.defineProperty(xhr, prop, { configurable: true, get: () => value });
+}
+function fulfillXHRWithMock(xhr, mock) {
+  const headers = {};
+  for (const [name, value] of Object.entries(mock.headers || {}))
+    headers[name.toLowerCase()] = value;
+  const body = mock.body || "";
+  void delay(mock.delay_ms).then(() => {
+    defineValue(xhr, "readyState", 4);
+    if (!mock.status) {
+      defineValue(xhr, "status", 0);
+      xhr.dispatchEvent(new Event("readystatechange"));
+      xhr.dispatchEvent(new ProgressEvent("error"));
+      xhr.dispatchEvent(new ProgressEvent("loadend"));
+      return;
+    }
+    let response = body;
+    if (xhr.responseType === "json") {
+      try {
+        response = JSON.parse(body);
+      } catch {
+        response = null;
+      }
+    }
+    defineValue(xhr, "status", mock.status);
+    defineValue(xhr, "statusText", "");
+    defineValue(xhr, "responseText", body);
+    defineValue(xhr, "response", response);
+    defineValue(xhr, "responseURL", absoluteUrl(xhr.__kaboomUrl || ""));
+    Object.defineProperty(xhr, "getResponseHeader", {
+      configurable: true,
+      value: (name) => headers[String(name).toLowerCase()] ?? null
+    });
+    Object.defineProperty(xhr, "getAllResponseHeaders", {
+      configurable: true,
+      value: () => Object.entries(headers).map(([name, value]) => `${name}: ${value}\r\n`).join("")
+    });
+    xhr.dispatchEvent(new Event("readystatechange"));
+    xhr.dispatchEvent(new ProgressEvent("load"));
+    xhr.dispatchEvent(new ProgressEvent("loadend"));
+  });
+}
+
 // extension/lib/network.js
 var configuredServerUrl = "";
 var networkWaterfallEnabled = false;
 var pendingRequests = /* @__PURE__ */ new Map();
 var requestIdCounter = 0;
 var networkBodyCaptureEnabled = true;
+var networkBodyCaptureMax = BODY_CAPTURE_MAX_DEFAULT;
+var networkBinaryCaptureMax = 0;
 var SENSITIVE_URL_PATTERNS = /\/(auth|login|signin|signup|token|oauth|session|api[_-]?key|password|register)\b/i;
 function parseResourceTiming(timing) {
   return {
@@ -778,6 +885,12 @@ function setNetworkBodyCaptureEnabled(enabled) {
 function isNetworkBodyCaptureEnabled() {
   return networkBodyCaptureEnabled;
 }
+function setNetworkBodyCaptureMax(limit) {
+  networkBodyCaptureMax = Number.isInteger(limit) && limit > 0 && limit <= BODY_CAPTURE_MAX_LIMIT ? limit : BODY_CAPTURE_MAX_DEFAULT;
+}
+function setNetworkBinaryCaptureMax(limit) {
+  networkBinaryCaptureMax = Number.isInteger(limit) && limit > 0 && limit <= BINARY_CAPTURE_MAX_LIMIT ? limit : 0;
+}
 function setServerUrl(url) {
   configuredServerUrl = url || "";
 }
@@ -828,16 +941,16 @@ function sanitizeHeaders(headers) {
 function truncateRequestBody(body) {
   if (body === null || body === void 0)
     return { body: null, truncated: false };
-  if (body.length <= REQUEST_BODY_MAX)
+  if (body.length <= networkBodyCaptureMax)
     return { body, truncated: false };
-  return { body: body.slice(0, REQUEST_BODY_MAX), truncated: true };
+  return { body: body.slice(0, networkBodyCaptureMax), truncated: true };
 }
 function truncateResponseBody(body) {
   if (body === null || body === void 0)
     return { body: null, truncated: false };
-  if (body.length <= RESPONSE_BODY_MAX)
+  if (body.length <= networkBodyCaptureMax)
     return { body, truncated: false };
-  return { body: body.slice(0, RESPONSE_BODY_MAX), truncated: true };
+  return { body: body.slice(0, networkBodyCaptureMax), truncated: true };
 }
 async function readResponseBody(response) {
   const contentType = response.headers?.get?.("content-type") || "";
@@ -880,7 +993,23 @@ async function readCapturedBody(url, cloned, contentType) {
   }
   return readResponseBodyWithTimeout(cloned);
 }
-function postNetworkBody(win, url, method, response, contentType, requestBody, duration, truncResp, truncReq, responseTruncated) {
+function encodeBase64(bytes) {
+  let binary = "";
+  for (let i = 0; i < bytes.length; i += 32768) {
+    binary += String.fromCharCode(...bytes.subarray(i, i + 32768));
+  }
+  return btoa(binary);
+}
+async function readBinaryCapture(url, cloned, contentType) {
+  if (networkBinaryCaptureMax === 0 || !cloned || SENSITIVE_URL_PATTERNS.test(url))
+    return null;
+  if (!BINARY_CONTENT_TYPES.test(contentType) && !BINARY_WIRE_CONTENT_TYPES.test(contentType))
+    return null;
+  const buffer = await cloned.arrayBuffer();
+  const head = new Uint8Array(buffer, 0, Math.min(buffer.byteLength, networkBinaryCaptureMax));
+  return { body: encodeBase64(head), size: buffer.byteLength, truncated: buffer.byteLength > head.length };
+}
+function postNetworkBody(win, url, method, response, contentType, requestBody, duration, truncResp, truncReq, responseTruncated, binarySize) {
   const message = {
     type: "kaboom_network_body",
     payload: {
@@ -891,6 +1020,7 @@ function postNetworkBody(win, url, method, response, contentType, requestBody, d
       request_body: truncReq || (typeof requestBody === "string" ? requestBody : void 0),
       response_body: truncResp,
       ...responseTruncated ? { response_truncated: true } : {},
+      ...binarySize !== void 0 ? { response_encoding: "base64", response_size: binarySize } : {},
       duration
     }
   };
@@ -947,6 +1077,9 @@ function wrapXHRWithBodies() {
         }
       });
     }
+    const mock = findRequestMock(url, method);
+    if (mock)
+      return fulfillXHRWithMock(this, mock);
     return originalXHRSend.call(this, body);
   };
 }
@@ -1003,22 +1136,25 @@ function adoptEarlyBodies() {
 function wrapFetchWithBodies(fetchFn) {
   return async function(input, init) {
     const { url, method, requestBody } = extractFetchInfo(input, init);
+    const mock = findRequestMock(url, method);
+    const send = () => mock ? mockFetchResponse(mock) : fetchFn(input, init);
     if (!shouldCaptureUrl(url))
-      return fetchFn(input, init);
+      return send();
     const startTime = Date.now();
-    const response = await fetchFn(input, init);
+    const response = await send();
     const duration = Date.now() - startTime;
     const contentType = response.headers?.get?.("content-type") || "";
     const cloned = response.clone ? response.clone() : null;
     const win = typeof window !== "undefined" ? window : null;
     Promise.resolve().then(async () => {
       try {
-        const responseBody = await readCapturedBody(url, cloned, contentType);
-        const { body: truncResp, truncated: respTruncated } = truncateResponseBody(responseBody);
+        const binary = await readBinaryCapture(url, cloned, contentType);
+        const responseBody = binary ? binary.body : await readCapturedBody(url, cloned, contentType);
+        const { body: truncResp, truncated: respTruncated } = binary ? { body: binary.body, truncated: binary.truncated } : truncateResponseBody(responseBody);
         const rawReq = SENSITIVE_URL_PATTERNS.test(url) ? "[REDACTED: auth endpoint]" : typeof requestBody === "string" ? requestBody : null;
         const { body: truncReq } = truncateRequestBody(rawReq);
         if (win && networkBodyCaptureEnabled) {
-          postNetworkBody(win, url, method, response, contentType, requestBody, duration, truncResp || responseBody, truncReq, respTruncated);
+          postNetworkBody(win, url, method, response, contentType, requestBody, duration, truncResp || responseBody, truncReq, respTruncated, binary?.size);
         }
       } catch {
       }
@@ -1029,6 +1165,112 @@ function wrapFetchWithBodies(fetchFn) {
   };
 }
 
+// extension/lib/memory-sample.js
+var MAX_TRACKED_TARGETS = 5e3;
+var originalAddEventListener = null;
+var originalRemoveEventListener = null;
+var listenerCounts = /* @__PURE__ */ new WeakMap();
+var trackedTargets = [];
+var uaSpecificBytes = null;
+var uaMeasurePending = false;
+function isNode(target) {
+  return typeof Node !== "undefined" && target instanceof Node;
+}
+function adjustListenerCount(target, delta) {
+  if (!isNode(target))
+    return;
+  const count = listenerCounts.get(target);
+  if (count === void 0) {
+    if (delta < 0 || trackedTargets.length >= MAX_TRACKED_TARGETS)
+      return;
+    trackedTargets.push(new WeakRef(target));
+  }
+  listenerCounts.set(target, Math.max(0, (count || 0) + delta));
+}
+function installListenerTracking() {
+  if (originalAddEventListener || typeof EventTarget === "undefined" || typeof WeakRef === "undefined")
+    return;
+  originalAddEventListener = EventTarget.prototype.addEventListener;
+  originalRemoveEventListener = EventTarget.prototype.removeEventListener;
+  const add = originalAddEventListener;
+  const remove = originalRemoveEventListener;
+  EventTarget.prototype.addEventListener = function(...args) {
+    if (args[1])
+      adjustListenerCount(this, 1);
+    return add.apply(this, args);
+  };
+  EventTarget.prototype.removeEventListener = function(...args) {
+    if (args[1])
+      adjustListenerCount(this, -1);
+    return remove.apply(this, args);
+  };
+}
+function uninstallListenerTracking() {
+  if (originalAddEventListener)
+    EventTarget.prototype.addEventListener = originalAddEventListener;
+  if (originalRemoveEventListener)
+    EventTarget.prototype.removeEventListener = originalRemoveEventListener;
+  originalAddEventListener = null;
+  originalRemoveEventListener = null;
+  listenerCounts = /* @__PURE__ */ new WeakMap();
+  trackedTargets = [];
+}
+function countDOMNodes() {
+  if (typeof document === "undefined" || typeof document.getElementsByTagName !== "function")
+    return 0;
+  return document.getElementsByTagName("*").length;
+}
+function countListeners() {
+  let total = 0;
+  let detached = 0;
+  const live = [];
+  for (const ref of trackedTargets) {
+    const node = ref.deref();
+    if (!node)
+      continue;
+    live.push(ref);
+    const count = listenerCounts.get(node) || 0;
+    total += count;
+    if (!node.isConnected)
+      detached += count;
+  }
+  trackedTargets = live;
+  return { event_listeners: total, detached_listeners: detached };
+}
+function captureMemorySample() {
+  const sample = {
+    time_origin: performance.timeOrigin || 0,
+    dom_nodes: countDOMNodes(),
+    ...countListeners()
+  };
+  const memory = performance.memory;
+  if (memory) {
+    sample.used_js_heap_size = memory.usedJSHeapSize;
+    sample.total_js_heap_size = memory.totalJSHeapSize;
+    sample.js_heap_size_limit = memory.jsHeapSizeLimit;
+  }
+  if (uaSpecificBytes !== null)
+    sample.ua_specific_bytes = uaSpecificBytes;
+  return sample;
+}
+async function measureUASpecificMemory() {
+  const measure = performance.measureUserAgentSpecificMemory;
+  if (uaMeasurePending || typeof measure !== "function")
+    return null;
+  if (typeof crossOriginIsolated === "undefined" || !crossOriginIsolated)
+    return null;
+  uaMeasurePending = true;
+  try {
+    const result = await measure.call(performance);
+    uaSpecificBytes = result.bytes;
+    return uaSpecificBytes;
+  } catch {
+    return null;
+  } finally {
+    uaMeasurePending = false;
+  }
+}
+
 // extension/lib/perf-snapshot.js
 var perfSnapshotEnabled = true;
 var longTaskEntries = [];
@@ -1041,6 +1283,7 @@ var fcpValue = null;
 var lcpValue = null;
 var clsValue = 0;
 var inpValue = null;
+var interactions = /* @__PURE__ */ new Map();
 function mapInitiatorType(type) {
   switch (type) {
     case "script":
@@ -1106,6 +1349,7 @@ function capturePerformanceSnapshot() {
   };
   const network = aggregateResourceTiming();
   const longTasks = getLongTaskMetrics();
+  const slowInteractions = getINPInteractions();
   const marks = performance.getEntriesByType("mark") || [];
   const measures = performance.getEntriesByType("measure") || [];
   const userTiming = marks.length > 0 || measures.length > 0 ? {
@@ -1119,7 +1363,9 @@ function capturePerformanceSnapshot() {
     network,
     long_tasks: longTasks,
     cumulative_layout_shift: getCLS(),
-    user_timing: userTiming
+    user_timing: userTiming,
+    memory: captureMemorySample(),
+    interactions: slowInteractions.length > 0 ? slowInteractions : void 0
   };
 }
 function installPerfObservers() {
@@ -1128,6 +1374,7 @@ function installPerfObservers() {
   lcpValue = null;
   clsValue = 0;
   inpValue = null;
+  interactions = /* @__PURE__ */ new Map();
   longTaskObserver = new PerformanceObserver((list) => {
     const entries = list.getEntries();
     for (const entry of entries) {
@@ -1171,6 +1418,7 @@ function installPerfObservers() {
         if (inpValue === null || inpEntry.duration > inpValue) {
           inpValue = inpEntry.duration;
         }
+        recordInteraction(inpEntry);
       }
     }
   });
@@ -1198,6 +1446,7 @@ function uninstallPerfObservers() {
     inpObserver = null;
   }
   longTaskEntries = [];
+  interactions = /* @__PURE__ */ new Map();
 }
 function getLongTaskMetrics() {
   let totalBlockingTime = 0;
@@ -1227,6 +1476,39 @@ function getCLS() {
 function getINP() {
   return inpValue;
 }
+function recordInteraction(entry) {
+  const id = entry.interactionId;
+  if (!id)
+    return;
+  const existing = interactions.get(id);
+  if (existing && existing.duration >= entry.duration)
+    return;
+  const processingStart = entry.processingStart ?? entry.startTime;
+  const processingEnd = entry.processingEnd ?? processingStart;
+  const target = entry.target && entry.target.tagName ? entry.target : null;
+  interactions.set(id, {
+    interaction_id: id,
+    name: entry.name,
+    target: getElementSelector(target),
+    start_time: Math.round(entry.startTime),
+    duration: Math.round(entry.duration),
+    input_delay: Math.round(Math.max(0, processingStart - entry.startTime)),
+    processing_time: Math.round(Math.max(0, processingEnd - processingStart)),
+    presentation_delay: Math.round(Math.max(0, entry.startTime + entry.duration - processingEnd))
+  });
+  if (interactions.size > MAX_INP_INTERACTIONS) {
+    let fastest = null;
+    for (const interaction of interactions.values()) {
+      if (!fastest || interaction.duration < fastest.duration)
+        fastest = interaction;
+    }
+    if (fastest)
+      interactions.delete(fastest.interaction_id);
+  }
+}
+function getINPInteractions() {
+  return [...interactions.values()].sort((a, b) => b.duration - a.duration);
+}
 function sendPerformanceSnapshot() {
   if (!perfSnapshotEnabled)
     return;
@@ -1234,6 +1516,13 @@ function sendPerformanceSnapshot() {
   if (!snapshot)
     return;
   window.postMessage({ type: "kaboom_performance_snapshot", payload: snapshot }, window.location.origin);
+  void measureUASpecificMemory().then((bytes) => {
+    if (bytes === null || !perfSnapshotEnabled)
+      return;
+    const updated = capturePerformanceSnapshot();
+    if (updated)
+      window.postMessage({ type: "kaboom_performance_snapshot", payload: updated }, window.location.origin);
+  });
 }
 var snapshotResendTimer = null;
 function scheduleSnapshotResend() {
@@ -1457,6 +1746,18 @@ function isPerformanceMarksEnabled() {
 }
 
 // extension/lib/bridge.js
+var MAX_MESSAGE_JSON_LENGTH = 1e3;
+function messageFromArg(arg) {
+  if (arg === null || arg === void 0)
+    return "";
+  if (typeof arg !== "object")
+    return String(arg);
+  try {
+    return JSON.stringify(arg).slice(0, MAX_MESSAGE_JSON_LENGTH);
+  } catch {
+    return String(arg);
+  }
+}
 function postLog(payload) {
   const context = getContextAnnotations();
   const actions = payload.level === "error" ? getActionBuffer() : null;
@@ -1472,7 +1773,7 @@ function postLog(payload) {
       // Enriched fields (these are the source of truth)
       ts: (/* @__PURE__ */ new Date()).toISOString(),
       url: window.location.href,
-      message: payload.message || payload.error || (payload.args?.[0] !== null && payload.args?.[0] !== void 0 ? String(payload.args[0]) : ""),
+      message: payload.message || payload.error || messageFromArg(payload.args?.[0]),
       source: payload.filename ? `${payload.filename}:${payload.lineno || 0}` : "",
       // Core fields from payload
       level,
@@ -2338,6 +2639,109 @@ function resetForTesting() {
   }
 }
 
+// extension/lib/sse.js
+var originalEventSource = null;
+var sseCaptureEnabled = true;
+var LIFECYCLE_EVENTS = /* @__PURE__ */ new Set(["open", "error"]);
+var READY_STATE_CONNECTING = 0;
+var READY_STATE_CLOSED = 2;
+function postSSEEvent(payload) {
+  window.postMessage({ type: "kaboom_sse", payload }, window.location.origin);
+}
+function postLifecycleEvent2(event, connectionId, source) {
+  postSSEEvent({
+    event,
+    id: connectionId,
+    url: source.url,
+    ts: (/* @__PURE__ */ new Date()).toISOString(),
+    ...source.withCredentials && { with_credentials: true }
+  });
+}
+function postMessageEvent2(connectionId, source, message) {
+  const raw = typeof message.data === "string" ? message.data : String(message.data);
+  const { data, truncated } = truncateWsMessage(raw);
+  postSSEEvent({
+    event: "message",
+    id: connectionId,
+    url: source.url,
+    ts: (/* @__PURE__ */ new Date()).toISOString(),
+    event_type: message.type,
+    ...message.lastEventId && { last_event_id: message.lastEventId },
+    data,
+    size: getSize(raw),
+    ...truncated && { truncated: true }
+  });
+}
+function attachCapture(source, connectionId) {
+  const originalAddEventListener = source.addEventListener;
+  const captured = /* @__PURE__ */ new Set();
+  const captureType = (type) => {
+    if (captured.has(type) || LIFECYCLE_EVENTS.has(type))
+      return;
+    captured.add(type);
+    originalAddEventListener.call(source, type, (event) => {
+      if (!sseCaptureEnabled)
+        return;
+      postMessageEvent2(connectionId, source, event);
+    });
+  };
+  source.addEventListener = function(type, listener, options) {
+    captureType(type);
+    originalAddEventListener.call(source, type, listener, options);
+  };
+  captureType("message");
+  originalAddEventListener.call(source, "open", () => {
+    if (!sseCaptureEnabled)
+      return;
+    postLifecycleEvent2("open", connectionId, source);
+  });
+  originalAddEventListener.call(source, "error", () => {
+    if (!sseCaptureEnabled)
+      return;
+    const reconnecting = source.readyState === READY_STATE_CONNECTING;
+    postLifecycleEvent2(reconnecting ? "retry" : "error", connectionId, source);
+  });
+  const originalClose = source.close.bind(source);
+  source.close = function() {
+    const wasClosed = source.readyState === READY_STATE_CLOSED;
+    originalClose();
+    if (sseCaptureEnabled && !wasClosed)
+      postLifecycleEvent2("close", connectionId, source);
+  };
+}
+function installSSECapture() {
+  if (typeof window === "undefined")
+    return;
+  if (!window.EventSource)
+    return;
+  if (originalEventSource)
+    return;
+  sseCaptureEnabled = true;
+  originalEventSource = window.EventSource;
+  const OriginalES = originalEventSource;
+  function KaboomEventSource(url, init) {
+    const source = new OriginalES(url, init);
+    attachCapture(source, crypto.randomUUID());
+    return source;
+  }
+  KaboomEventSource.prototype = OriginalES.prototype;
+  Object.defineProperty(KaboomEventSource, "CONNECTING", { value: OriginalES.CONNECTING, writable: false });
+  Object.defineProperty(KaboomEventSource, "OPEN", { value: OriginalES.OPEN, writable: false });
+  Object.defineProperty(KaboomEventSource, "CLOSED", { value: OriginalES.CLOSED, writable: false });
+  window.EventSource = KaboomEventSource;
+}
+function setSSECaptureEnabled(enabled) {
+  sseCaptureEnabled = enabled;
+}
+function uninstallSSECapture() {
+  if (typeof window === "undefined")
+    return;
+  if (originalEventSource) {
+    window.EventSource = originalEventSource;
+    originalEventSource = null;
+  }
+}
+
 // extension/lib/dom-queries.js
 async function executeDOMQuery(params) {
   const { selector, include_styles, properties, include_children, max_depth } = params;
@@ -3028,8 +3432,10 @@ function install() {
   installActionCapture();
   installNavigationCapture();
   installWebSocketCapture();
+  installSSECapture();
   installPerformanceCapture();
   installTransientCapture();
+  installListenerTracking();
 }
 function uninstall() {
   uninstallConsoleCapture();
@@ -3039,8 +3445,10 @@ function uninstall() {
   uninstallActionCapture();
   uninstallNavigationCapture();
   uninstallWebSocketCapture();
+  uninstallSSECapture();
   uninstallPerformanceCapture();
   uninstallTransientCapture();
+  uninstallListenerTracking();
 }
 function shouldDeferIntercepts() {
   if (typeof document === "undefined")
@@ -3440,6 +3848,29 @@ function findLabel(el) {
     return placeholder.trim();
   return "";
 }
+var REDACTED_FIELD_VALUE = "[REDACTED]";
+var SENSITIVE_AUTOCOMPLETE = ["current-password", "new-password", "one-time-code", "cc-number", "cc-csc", "cc-exp"];
+function isSensitiveField(el) {
+  if ((el.getAttribute("type") || "").toLowerCase() === "password")
+    return true;
+  const autocomplete = (el.getAttribute("autocomplete") || "").toLowerCase();
+  return SENSITIVE_AUTOCOMPLETE.some((token) => autocomplete.includes(token));
+}
+var VALIDITY_FLAGS = [
+  ["valueMissing", "value_missing"],
+  ["typeMismatch", "type_mismatch"],
+  ["patternMismatch", "pattern_mismatch"],
+  ["tooLong", "too_long"],
+  ["tooShort", "too_short"],
+  ["rangeUnderflow", "range_underflow"],
+  ["rangeOverflow", "range_overflow"],
+  ["stepMismatch", "step_mismatch"],
+  ["badInput", "bad_input"],
+  ["customError", "custom_error"]
+];
+function validityFailures(validity) {
+  return VALIDITY_FLAGS.filter(([flag]) => validity[flag]).map(([, name]) => name);
+}
 function buildFieldSelector(el) {
   if (el.id)
     return `#${el.id}`;
@@ -3497,16 +3928,19 @@ function discoverForms(params) {
       const fieldType = field.getAttribute("type") || field.tagName.toLowerCase();
       if (fieldType === "hidden")
         continue;
+      const sensitive = isSensitiveField(field);
       const fieldInfo = {
         name: field.name || "",
         type: fieldType,
         required: field.required,
-        value: field.value || "",
+        value: sensitive && field.value ? REDACTED_FIELD_VALUE : field.value || "",
         label: findLabel(field),
         selector: buildFieldSelector(field),
         tag: field.tagName.toLowerCase(),
         validation_constraints: getValidationConstraints(field)
       };
+      if (sensitive && field.value)
+        fieldInfo.redacted = true;
       if (field.tagName === "SELECT") {
         const select = field;
         fieldInfo.options = Array.from(select.options).map((opt) => ({
@@ -3521,6 +3955,17 @@ function discoverForms(params) {
           fieldInfo.validation_message = field.validationMessage;
         }
       }
+      if (params.mode === "state") {
+        if (fieldType === "checkbox" || fieldType === "radio") {
+          fieldInfo.checked = field.checked;
+        }
+        fieldInfo.valid = field.validity.valid;
+        const failures = validityFailures(field.validity);
+        if (failures.length > 0)
+          fieldInfo.validity = failures;
+        if (field.validationMessage)
+          fieldInfo.validation_message = field.validationMessage;
+      }
       fields.push(fieldInfo);
     }
     let submitButton = null;
@@ -3549,6 +3994,9 @@ function discoverForms(params) {
         }));
       }
     }
+    if (params.mode === "state") {
+      formInfo.valid = fields.every((f) => f.valid !== false);
+    }
     results.push(formInfo);
   }
   return results;
@@ -3833,6 +4281,12 @@ function isValidSettingPayload(data) {
     return typeof data.mode === "string";
   if (data.setting === SettingName.SERVER_URL)
     return typeof data.url === "string";
+  if (data.setting === SettingName.NETWORK_BODY_CAPTURE_MAX)
+    return typeof data.limit === "number";
+  if (data.setting === SettingName.NETWORK_BINARY_CAPTURE_MAX)
+    return typeof data.limit === "number";
+  if (data.setting === SettingName.REQUEST_MOCKS)
+    return Array.isArray(data.mocks);
   if (typeof data.enabled !== "boolean") {
     console.warn("[KaBOOM!] Invalid enabled value type");
     return false;
@@ -3851,15 +4305,22 @@ var SETTING_HANDLERS = {
   [SettingName.ACTION_REPLAY]: (data) => setActionCaptureEnabled(data.enabled),
   [SettingName.WEBSOCKET_CAPTURE]: (data) => {
     setWebSocketCaptureEnabled(data.enabled);
-    if (data.enabled)
+    setSSECaptureEnabled(data.enabled);
+    if (data.enabled) {
       installWebSocketCapture();
-    else
+      installSSECapture();
+    } else {
       uninstallWebSocketCapture();
+      uninstallSSECapture();
+    }
   },
   [SettingName.WEBSOCKET_CAPTURE_MODE]: (data) => setWebSocketCaptureMode(data.mode || "medium"),
   [SettingName.PERFORMANCE_SNAPSHOT]: (data) => setPerformanceSnapshotEnabled(data.enabled),
   [SettingName.DEFERRAL]: (data) => setDeferralEnabled(data.enabled),
   [SettingName.NETWORK_BODY_CAPTURE]: (data) => setNetworkBodyCaptureEnabled(data.enabled),
+  [SettingName.NETWORK_BODY_CAPTURE_MAX]: (data) => setNetworkBodyCaptureMax(data.limit),
+  [SettingName.NETWORK_BINARY_CAPTURE_MAX]: (data) => setNetworkBinaryCaptureMax(data.limit),
+  [SettingName.REQUEST_MOCKS]: (data) => setRequestMocks(data.mocks),
   [SettingName.SERVER_URL]: (data) => setServerUrl(data.url)
 };
 function handleSetting(data) {
@@ -4026,7 +4487,7 @@ function handleFormStateMessage(data) {
     const params = data.params || {};
     const forms = discoverForms({
       selector: params.selector,
-      mode: "discover"
+      mode: "state"
     });
     postResponse({
       type: "kaboom_form_state_response",
@@ -4457,6 +4918,7 @@ export {
   getEnhancedActionBuffer,
   getFCP,
   getINP,
+  getINPInteractions,
   getImplicitRole,
   getLCP,
   getLongTaskMetrics,
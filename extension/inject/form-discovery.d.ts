@@ -1,10 +1,10 @@
 /**
- * Purpose: Scans page forms to extract field metadata (name, type, required, validation) and optionally runs client-side validation.
+ * Purpose: Scans page forms to extract field metadata (name, type, required, validation), live validity state, and optionally runs client-side validation.
  * Docs: docs/features/feature/form-filling/index.md
  */
 interface FormDiscoveryParams {
     selector?: string;
-    mode?: 'discover' | 'validate';
+    mode?: 'discover' | 'validate' | 'state';
 }
 interface FormFieldInfo {
     name: string;
@@ -21,6 +21,10 @@ interface FormFieldInfo {
         selected: boolean;
     }>;
     validation_message?: string;
+    checked?: boolean;
+    valid?: boolean;
+    validity?: string[];
+    redacted?: boolean;
 }
 interface FormInfo {
     action: string;
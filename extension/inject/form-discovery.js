@@ -1,5 +1,5 @@
 /**
- * Purpose: Scans page forms to extract field metadata (name, type, required, validation) and optionally runs client-side validation.
+ * Purpose: Scans page forms to extract field metadata (name, type, required, validation), live validity state, and optionally runs client-side validation.
  * Docs: docs/features/feature/form-filling/index.md
  */
 /**
@@ -28,6 +28,38 @@ function findLabel(el) {
         return placeholder.trim();
     return '';
 }
+const REDACTED_FIELD_VALUE = '[REDACTED]';
+// Autocomplete tokens whose values must never leave the page.
+const SENSITIVE_AUTOCOMPLETE = ['current-password', 'new-password', 'one-time-code', 'cc-number', 'cc-csc', 'cc-exp'];
+/**
+ * Report whether a field holds a secret by type or autocomplete hint.
+ * Name-based rules are applied by the server's redaction engine.
+ */
+function isSensitiveField(el) {
+    if ((el.getAttribute('type') || '').toLowerCase() === 'password')
+        return true;
+    const autocomplete = (el.getAttribute('autocomplete') || '').toLowerCase();
+    return SENSITIVE_AUTOCOMPLETE.some((token) => autocomplete.includes(token));
+}
+const VALIDITY_FLAGS = [
+    ['valueMissing', 'value_missing'],
+    ['typeMismatch', 'type_mismatch'],
+    ['patternMismatch', 'pattern_mismatch'],
+    ['tooLong', 'too_long'],
+    ['tooShort', 'too_short'],
+    ['rangeUnderflow', 'range_underflow'],
+    ['rangeOverflow', 'range_overflow'],
+    ['stepMismatch', 'step_mismatch'],
+    ['badInput', 'bad_input'],
+    ['customError', 'custom_error']
+];
+/**
+ * List the failing ValidityState flags. Reads the live state without
+ * calling checkValidity(), so no invalid events fire on the page.
+ */
+function validityFailures(validity) {
+    return VALIDITY_FLAGS.filter(([flag]) => validity[flag]).map(([, name]) => name);
+}
 /**
  * Build a minimal CSS selector for a form element.
  */
@@ -102,16 +134,19 @@ export function discoverForms(params) {
             // Skip hidden inputs
             if (fieldType === 'hidden')
                 continue;
+            const sensitive = isSensitiveField(field);
             const fieldInfo = {
                 name: field.name || '',
                 type: fieldType,
                 required: field.required,
-                value: field.value || '',
+                value: sensitive && field.value ? REDACTED_FIELD_VALUE : field.value || '',
                 label: findLabel(field),
                 selector: buildFieldSelector(field),
                 tag: field.tagName.toLowerCase(),
                 validation_constraints: getValidationConstraints(field)
             };
+            if (sensitive && field.value)
+                fieldInfo.redacted = true;
             // Add options for select elements
             if (field.tagName === 'SELECT') {
                 const select = field;
@@ -128,6 +163,18 @@ export function discoverForms(params) {
                     fieldInfo.validation_message = field.validationMessage;
                 }
             }
+            // Add live validity in state mode
+            if (params.mode === 'state') {
+                if (fieldType === 'checkbox' || fieldType === 'radio') {
+                    fieldInfo.checked = field.checked;
+                }
+                fieldInfo.valid = field.validity.valid;
+                const failures = validityFailures(field.validity);
+                if (failures.length > 0)
+                    fieldInfo.validity = failures;
+                if (field.validationMessage)
+                    fieldInfo.validation_message = field.validationMessage;
+            }
             fields.push(fieldInfo);
         }
         // Find submit button
@@ -161,6 +208,9 @@ export function discoverForms(params) {
                 }));
             }
         }
+        if (params.mode === 'state') {
+            formInfo.valid = fields.every((f) => f.valid !== false);
+        }
         results.push(formInfo);
     }
     return results;
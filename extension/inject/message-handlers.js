@@ -144,7 +144,7 @@ function handleFormStateMessage(data) {
         const params = (data.params || {});
         const forms = discoverForms({
             selector: params.selector,
-            mode: 'discover'
+            mode: 'state'
         });
         postResponse({
             type: 'kaboom_form_state_response',
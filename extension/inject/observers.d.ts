@@ -1,5 +1,5 @@
 /**
- * Purpose: Registers and manages runtime observers for DOM mutations, network requests, performance entries, and WebSocket events in the page context.
+ * Purpose: Registers and manages runtime observers for DOM mutations, network requests, performance entries, and WebSocket and SSE events in the page context.
  * Docs: docs/features/feature/observe/index.md
  */
 /**
@@ -1,19 +1,21 @@
 /**
- * Purpose: Registers and manages runtime observers for DOM mutations, network requests, performance entries, and WebSocket events in the page context.
+ * Purpose: Registers and manages runtime observers for DOM mutations, network requests, performance entries, and WebSocket and SSE events in the page context.
  * Docs: docs/features/feature/observe/index.md
  */
 /**
  * @fileoverview Observers - Observer registration and management for DOM, network,
- * performance, and WebSocket events.
+ * performance, WebSocket, and Server-Sent Events.
  */
 import { installPerformanceCapture, uninstallPerformanceCapture } from '../lib/performance.js';
 import { installPerfObservers } from '../lib/perf-snapshot.js';
 import { installWebSocketCapture, uninstallWebSocketCapture } from '../lib/websocket.js';
+import { installSSECapture, uninstallSSECapture } from '../lib/sse.js';
 import { wrapFetchWithBodies, wrapXHRWithBodies, unwrapXHR, adoptEarlyBodies, sanitizeHeaders } from '../lib/network.js';
 import { installConsoleCapture, uninstallConsoleCapture } from '../lib/console.js';
 import { installExceptionCapture, uninstallExceptionCapture } from '../lib/exceptions.js';
 import { installActionCapture, uninstallActionCapture, installNavigationCapture, uninstallNavigationCapture } from '../lib/actions.js';
 import { installTransientCapture, uninstallTransientCapture } from '../lib/transient-capture.js';
+import { installListenerTracking, uninstallListenerTracking } from '../lib/memory-sample.js';
 import { postLog } from '../lib/bridge.js';
 import { MAX_RESPONSE_LENGTH, MEMORY_SOFT_LIMIT_MB, MEMORY_HARD_LIMIT_MB } from '../lib/constants.js';
 import { errorMessage } from '../lib/error-utils.js';
@@ -137,8 +139,10 @@ export function install() {
     installActionCapture();
     installNavigationCapture();
     installWebSocketCapture();
+    installSSECapture();
     installPerformanceCapture();
     installTransientCapture();
+    installListenerTracking();
 }
 /**
  * Uninstall all capture hooks
@@ -151,8 +155,10 @@ export function uninstall() {
     uninstallActionCapture();
     uninstallNavigationCapture();
     uninstallWebSocketCapture();
+    uninstallSSECapture();
     uninstallPerformanceCapture();
     uninstallTransientCapture();
+    uninstallListenerTracking();
 }
 /**
  * Check if heavy intercepts should be deferred until page load
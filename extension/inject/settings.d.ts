@@ -16,6 +16,8 @@ export interface SettingMessageData {
     enabled?: boolean;
     mode?: string;
     url?: string;
+    limit?: number;
+    mocks?: unknown[];
 }
 /**
  * State command message from content script
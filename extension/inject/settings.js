@@ -2,10 +2,12 @@
  * Purpose: Applies runtime setting changes (network capture, performance marks, WebSocket mode, action replay) and handles state save/load commands in the inject context.
  * Docs: docs/features/feature/state-time-travel/index.md
  */
-import { setNetworkWaterfallEnabled, setNetworkBodyCaptureEnabled, setServerUrl } from '../lib/network.js';
+import { setNetworkWaterfallEnabled, setNetworkBodyCaptureEnabled, setNetworkBodyCaptureMax, setNetworkBinaryCaptureMax, setServerUrl } from '../lib/network.js';
+import { setRequestMocks } from '../lib/request-mocks.js';
 import { setPerformanceMarksEnabled, installPerformanceCapture, uninstallPerformanceCapture } from '../lib/performance.js';
 import { setActionCaptureEnabled } from '../lib/actions.js';
 import { setWebSocketCaptureEnabled, setWebSocketCaptureMode, installWebSocketCapture, uninstallWebSocketCapture } from '../lib/websocket.js';
+import { setSSECaptureEnabled, installSSECapture, uninstallSSECapture } from '../lib/sse.js';
 import { setPerformanceSnapshotEnabled } from '../lib/perf-snapshot.js';
 import { setDeferralEnabled } from './observers.js';
 import { INJECT_FORWARDED_SETTINGS, SettingName } from '../lib/constants.js';
@@ -24,6 +26,12 @@ export function isValidSettingPayload(data) {
         return typeof data.mode === 'string';
     if (data.setting === SettingName.SERVER_URL)
         return typeof data.url === 'string';
+    if (data.setting === SettingName.NETWORK_BODY_CAPTURE_MAX)
+        return typeof data.limit === 'number';
+    if (data.setting === SettingName.NETWORK_BINARY_CAPTURE_MAX)
+        return typeof data.limit === 'number';
+    if (data.setting === SettingName.REQUEST_MOCKS)
+        return Array.isArray(data.mocks);
     // Boolean settings
     if (typeof data.enabled !== 'boolean') {
         console.warn('[KaBOOM!] Invalid enabled value type');
@@ -42,16 +50,25 @@ const SETTING_HANDLERS = {
     },
     [SettingName.ACTION_REPLAY]: (data) => setActionCaptureEnabled(data.enabled),
     [SettingName.WEBSOCKET_CAPTURE]: (data) => {
+        // Streaming capture covers both WebSocket and EventSource connections.
         setWebSocketCaptureEnabled(data.enabled);
-        if (data.enabled)
+        setSSECaptureEnabled(data.enabled);
+        if (data.enabled) {
             installWebSocketCapture();
-        else
+            installSSECapture();
+        }
+        else {
             uninstallWebSocketCapture();
+            uninstallSSECapture();
+        }
     },
     [SettingName.WEBSOCKET_CAPTURE_MODE]: (data) => setWebSocketCaptureMode((data.mode || 'medium')),
     [SettingName.PERFORMANCE_SNAPSHOT]: (data) => setPerformanceSnapshotEnabled(data.enabled),
     [SettingName.DEFERRAL]: (data) => setDeferralEnabled(data.enabled),
     [SettingName.NETWORK_BODY_CAPTURE]: (data) => setNetworkBodyCaptureEnabled(data.enabled),
+    [SettingName.NETWORK_BODY_CAPTURE_MAX]: (data) => setNetworkBodyCaptureMax(data.limit),
+    [SettingName.NETWORK_BINARY_CAPTURE_MAX]: (data) => setNetworkBinaryCaptureMax(data.limit),
+    [SettingName.REQUEST_MOCKS]: (data) => setRequestMocks(data.mocks),
     [SettingName.SERVER_URL]: (data) => setServerUrl(data.url)
 };
 export function handleSetting(data) {
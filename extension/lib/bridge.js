@@ -8,6 +8,24 @@
  */
 import { getContextAnnotations } from './context.js';
 import { getActionBuffer } from './actions.js';
+// Objects in the message are JSON previews; the full structure stays in args
+const MAX_MESSAGE_JSON_LENGTH = 1000;
+/**
+ * Message text for a first console argument: objects and arrays read as JSON
+ * instead of "[object Object]".
+ */
+function messageFromArg(arg) {
+    if (arg === null || arg === undefined)
+        return '';
+    if (typeof arg !== 'object')
+        return String(arg);
+    try {
+        return JSON.stringify(arg).slice(0, MAX_MESSAGE_JSON_LENGTH);
+    }
+    catch {
+        return String(arg);
+    }
+}
 /**
  * Post a log message to the content script
  */
@@ -30,9 +48,7 @@ export function postLog(payload) {
             // Enriched fields (these are the source of truth)
             ts: new Date().toISOString(),
             url: window.location.href,
-            message: payload.message ||
-                payload.error ||
-                (payload.args?.[0] !== null && payload.args?.[0] !== undefined ? String(payload.args[0]) : ''),
+            message: payload.message || payload.error || messageFromArg(payload.args?.[0]),
             source: payload.filename ? `${payload.filename}:${payload.lineno || 0}` : '',
             // Core fields from payload
             level,
@@ -19,11 +19,13 @@ export declare const MAX_PERFORMANCE_ENTRIES = 50;
 export declare const PERFORMANCE_TIME_WINDOW_MS = 60000;
 export declare const WS_MAX_BODY_SIZE = 4096;
 export declare const WS_PREVIEW_LIMIT = 200;
-export declare const REQUEST_BODY_MAX = 8192;
-export declare const RESPONSE_BODY_MAX = 16384;
+export declare const BODY_CAPTURE_MAX_DEFAULT = 65536;
+export declare const BODY_CAPTURE_MAX_LIMIT = 1048576;
+export declare const BINARY_CAPTURE_MAX_LIMIT = 262144;
 export declare const BODY_READ_TIMEOUT_MS = 5;
 export declare const SENSITIVE_HEADER_PATTERNS: RegExp;
 export declare const BINARY_CONTENT_TYPES: RegExp;
+export declare const BINARY_WIRE_CONTENT_TYPES: RegExp;
 export declare const DOM_QUERY_MAX_ELEMENTS = 50;
 export declare const DOM_QUERY_MAX_TEXT = 500;
 export declare const DOM_QUERY_MAX_DEPTH = 5;
@@ -52,6 +54,7 @@ export declare const ACTIONABLE_KEYS: ReadonlySet<string>;
 export declare const MAX_LONG_TASKS = 50;
 export declare const MAX_SLOWEST_REQUESTS = 3;
 export declare const MAX_URL_LENGTH = 80;
+export declare const MAX_INP_INTERACTIONS = 10;
 /**
  * Setting names used across background, content, and inject contexts.
  * Single source of truth — all layers import from here.
@@ -69,6 +72,9 @@ export declare const SettingName: {
     readonly PERFORMANCE_SNAPSHOT: "set_performance_snapshot_enabled";
     readonly DEFERRAL: "set_deferral_enabled";
     readonly NETWORK_BODY_CAPTURE: "set_network_body_capture_enabled";
+    readonly NETWORK_BODY_CAPTURE_MAX: "set_network_body_capture_max";
+    readonly NETWORK_BINARY_CAPTURE_MAX: "set_network_binary_capture_max";
+    readonly REQUEST_MOCKS: "set_request_mocks";
     readonly ACTION_TOASTS: "set_action_toasts_enabled";
     readonly SUBTITLES: "set_subtitles_enabled";
     readonly SERVER_URL: "set_server_url";
@@ -106,6 +112,10 @@ export declare const StorageKey: {
     readonly PERFORMANCE_MARKS_ENABLED: "performanceMarksEnabled";
     readonly ACTION_REPLAY_ENABLED: "actionReplayEnabled";
     readonly NETWORK_BODY_CAPTURE_ENABLED: "networkBodyCaptureEnabled";
+    readonly NETWORK_BODY_CAPTURE_MAX: "networkBodyCaptureMax";
+    readonly NETWORK_BINARY_CAPTURE_MAX: "networkBinaryCaptureMax";
+    readonly REQUEST_MOCKS: "requestMocks";
+    readonly MULTI_TAB_CAPTURE: "multiTabCapture";
     readonly ACTION_TOASTS_ENABLED: "actionToastsEnabled";
     readonly SUBTITLES_ENABLED: "subtitlesEnabled";
     readonly ACTION_RECORDING: "kaboom_action_recording";
@@ -125,5 +135,6 @@ export declare const StorageKey: {
     readonly TERMINAL_WORKSPACE_MAIN_TAB_ID: "kaboom_terminal_workspace_main_tab_id";
     readonly CLOAKED_DOMAINS: "kaboom_cloaked_domains";
     readonly ERROR_GROUPS: "kaboom_error_groups";
+    readonly IDEMPOTENCY_KEYS: "kaboom_idempotency_keys";
 };
 //# sourceMappingURL=constants.d.ts.map
\ No newline at end of file
@@ -0,0 +1,43 @@
+/**
+ * Purpose: Samples JS heap size, DOM node count, and listeners on detached nodes for performance snapshots.
+ * Why: The server spots leaks from heap and DOM growth across repeated navigations of the same route.
+ * Docs: docs/features/feature/memory-telemetry/index.md
+ */
+interface MemorySampleData {
+    time_origin: number;
+    used_js_heap_size?: number;
+    total_js_heap_size?: number;
+    js_heap_size_limit?: number;
+    ua_specific_bytes?: number;
+    dom_nodes: number;
+    event_listeners: number;
+    detached_listeners: number;
+}
+/**
+ * Hook EventTarget add/removeEventListener to count listeners per DOM node.
+ */
+export declare function installListenerTracking(): void;
+/**
+ * Restore the original EventTarget methods and drop tracked targets.
+ */
+export declare function uninstallListenerTracking(): void;
+/**
+ * Count listeners on live tracked nodes, and on those no longer connected to the document.
+ * Collected targets are pruned as a side effect.
+ */
+export declare function countListeners(): {
+    event_listeners: number;
+    detached_listeners: number;
+};
+/**
+ * Capture a memory sample for the current document.
+ */
+export declare function captureMemorySample(): MemorySampleData;
+/**
+ * Start performance.measureUserAgentSpecificMemory() when the page is cross-origin isolated.
+ * Resolves with the measured bytes once the browser reports them (it may defer up to ~20s),
+ * or null when the API is unavailable, a measurement is already running, or it fails.
+ */
+export declare function measureUASpecificMemory(): Promise<number | null>;
+export {};
+//# sourceMappingURL=memory-sample.d.ts.map
@@ -104,6 +104,28 @@ export declare function setNetworkBodyCaptureEnabled(enabled: boolean): void;
  * @returns Whether body capture is enabled
  */
 export declare function isNetworkBodyCaptureEnabled(): boolean;
+/**
+ * Set the per-body capture ceiling pushed by the server's body_capture_max override.
+ * Out-of-range values restore the default.
+ * @param limit - Maximum characters kept per request or response body
+ */
+export declare function setNetworkBodyCaptureMax(limit: number): void;
+/**
+ * Get the per-body capture ceiling
+ * @returns Maximum characters kept per body
+ */
+export declare function getNetworkBodyCaptureMax(): number;
+/**
+ * Set the binary capture cap pushed by the server's binary_capture_max override.
+ * Out-of-range values turn binary capture off.
+ * @param limit - Bytes of each binary response to capture, 0 for none
+ */
+export declare function setNetworkBinaryCaptureMax(limit: number): void;
+/**
+ * Get the binary capture cap
+ * @returns Bytes captured per binary response, 0 when off
+ */
+export declare function getNetworkBinaryCaptureMax(): number;
 /**
  * Set the configured server URL for capture filtering.
  * Called when the server URL is loaded from settings.
@@ -123,13 +145,13 @@ export declare function shouldCaptureUrl(url: string): boolean;
  */
 export declare function sanitizeHeaders(headers: HeadersInit | Headers | Record<string, string> | null): Record<string, string>;
 /**
- * Truncate request body at 8KB limit
+ * Truncate request body at the capture ceiling (64KB by default)
  * @param body - The request body
  * @returns Truncation result
  */
 export declare function truncateRequestBody(body: string | null | undefined): TruncationResult;
 /**
- * Truncate response body at 16KB limit
+ * Truncate response body at the capture ceiling (64KB by default)
  * @param body - The response body
  * @returns Truncation result
  */
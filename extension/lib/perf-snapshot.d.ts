@@ -2,6 +2,7 @@
  * Purpose: Observes web vitals (FCP, LCP, CLS, INP), long tasks, and resource timing to build comprehensive performance snapshots.
  * Docs: docs/features/feature/performance-audit/index.md
  */
+import { captureMemorySample } from './memory-sample.js';
 interface ResourceByType {
     count: number;
     size: number;
@@ -37,6 +38,22 @@ interface UserTimingEntry {
     start_time: number;
     duration?: number;
 }
+interface InteractionTiming {
+    interaction_id: number;
+    name: string;
+    target: string;
+    start_time: number;
+    duration: number;
+    input_delay: number;
+    processing_time: number;
+    presentation_delay: number;
+}
+type EventTimingEntry = PerformanceEntry & {
+    interactionId?: number;
+    processingStart?: number;
+    processingEnd?: number;
+    target?: Node | null;
+};
 interface PerformanceSnapshotData {
     url: string;
     timestamp: string;
@@ -48,6 +65,8 @@ interface PerformanceSnapshotData {
         marks: UserTimingEntry[];
         measures: UserTimingEntry[];
     };
+    memory?: ReturnType<typeof captureMemorySample>;
+    interactions?: InteractionTiming[];
 }
 /**
  * Map resource initiator types to standard categories
@@ -89,6 +108,16 @@ export declare function getCLS(): number;
  * Get Interaction to Next Paint value
  */
 export declare function getINP(): number | null;
+/**
+ * Keep the slowest entry per interaction, and only the MAX_INP_INTERACTIONS slowest interactions.
+ * The breakdown follows the INP phases: input delay until handlers start, handler processing,
+ * and presentation delay until the next frame is painted.
+ */
+export declare function recordInteraction(entry: EventTimingEntry): void;
+/**
+ * Get the slowest recorded interactions, slowest first
+ */
+export declare function getINPInteractions(): InteractionTiming[];
 /**
  * Send performance snapshot via postMessage to content script
  */
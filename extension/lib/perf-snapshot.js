@@ -7,7 +7,9 @@
  * Observes web vitals (FCP, LCP, CLS, INP), long tasks, and resource timing
  * to build comprehensive performance snapshots.
  */
-import { MAX_LONG_TASKS, MAX_SLOWEST_REQUESTS, MAX_URL_LENGTH } from './constants.js';
+import { MAX_INP_INTERACTIONS, MAX_LONG_TASKS, MAX_SLOWEST_REQUESTS, MAX_URL_LENGTH } from './constants.js';
+import { captureMemorySample, measureUASpecificMemory } from './memory-sample.js';
+import { getElementSelector } from './serialize.js';
 // Performance snapshot state
 let perfSnapshotEnabled = true;
 let longTaskEntries = [];
@@ -20,6 +22,7 @@ let fcpValue = null;
 let lcpValue = null;
 let clsValue = 0;
 let inpValue = null;
+let interactions = new Map();
 /**
  * Map resource initiator types to standard categories
  */
@@ -99,6 +102,7 @@ export function capturePerformanceSnapshot() {
     };
     const network = aggregateResourceTiming();
     const longTasks = getLongTaskMetrics();
+    const slowInteractions = getINPInteractions();
     // Capture user timing marks and measures
     const marks = performance.getEntriesByType('mark') || [];
     const measures = performance.getEntriesByType('measure') || [];
@@ -115,7 +119,9 @@ export function capturePerformanceSnapshot() {
         network,
         long_tasks: longTasks,
         cumulative_layout_shift: getCLS(),
-        user_timing: userTiming
+        user_timing: userTiming,
+        memory: captureMemorySample(),
+        interactions: slowInteractions.length > 0 ? slowInteractions : undefined
     };
 }
 /**
@@ -127,6 +133,7 @@ export function installPerfObservers() {
     lcpValue = null;
     clsValue = 0;
     inpValue = null;
+    interactions = new Map();
     // Long task observer
     // #lizard forgives
     longTaskObserver = new PerformanceObserver((list) => {
@@ -178,6 +185,7 @@ export function installPerfObservers() {
                 if (inpValue === null || inpEntry.duration > inpValue) {
                     inpValue = inpEntry.duration;
                 }
+                recordInteraction(inpEntry);
             }
         }
     });
@@ -208,6 +216,7 @@ export function uninstallPerfObservers() {
         inpObserver = null;
     }
     longTaskEntries = [];
+    interactions = new Map();
 }
 /**
  * Get accumulated long task metrics
@@ -252,6 +261,47 @@ export function getCLS() {
 export function getINP() {
     return inpValue;
 }
+/**
+ * Keep the slowest entry per interaction, and only the MAX_INP_INTERACTIONS slowest interactions.
+ * The breakdown follows the INP phases: input delay until handlers start, handler processing,
+ * and presentation delay until the next frame is painted.
+ */
+export function recordInteraction(entry) {
+    const id = entry.interactionId;
+    if (!id)
+        return;
+    const existing = interactions.get(id);
+    if (existing && existing.duration >= entry.duration)
+        return;
+    const processingStart = entry.processingStart ?? entry.startTime;
+    const processingEnd = entry.processingEnd ?? processingStart;
+    const target = entry.target && entry.target.tagName ? entry.target : null;
+    interactions.set(id, {
+        interaction_id: id,
+        name: entry.name,
+        target: getElementSelector(target),
+        start_time: Math.round(entry.startTime),
+        duration: Math.round(entry.duration),
+        input_delay: Math.round(Math.max(0, processingStart - entry.startTime)),
+        processing_time: Math.round(Math.max(0, processingEnd - processingStart)),
+        presentation_delay: Math.round(Math.max(0, entry.startTime + entry.duration - processingEnd))
+    });
+    if (interactions.size > MAX_INP_INTERACTIONS) {
+        let fastest = null;
+        for (const interaction of interactions.values()) {
+            if (!fastest || interaction.duration < fastest.duration)
+                fastest = interaction;
+        }
+        if (fastest)
+            interactions.delete(fastest.interaction_id);
+    }
+}
+/**
+ * Get the slowest recorded interactions, slowest first
+ */
+export function getINPInteractions() {
+    return [...interactions.values()].sort((a, b) => b.duration - a.duration);
+}
 /**
  * Send performance snapshot via postMessage to content script
  */
@@ -262,6 +312,14 @@ export function sendPerformanceSnapshot() {
     if (!snapshot)
         return;
     window.postMessage({ type: 'kaboom_performance_snapshot', payload: snapshot }, window.location.origin);
+    // UA-specific memory resolves late; re-send so the server replaces this load's sample.
+    void measureUASpecificMemory().then((bytes) => {
+        if (bytes === null || !perfSnapshotEnabled)
+            return;
+        const updated = capturePerformanceSnapshot();
+        if (updated)
+            window.postMessage({ type: 'kaboom_performance_snapshot', payload: updated }, window.location.origin);
+    });
 }
 // Debounce timer for snapshot re-sends triggered by user timing changes
 let snapshotResendTimer = null;
@@ -31,6 +31,7 @@ interface EnhancedActionRecord {
     classification?: string;
     duration_ms?: number;
     role?: string;
+    locale?: string;
 }
 interface ScriptOptions {
     errorMessage?: string;
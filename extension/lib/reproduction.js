@@ -221,6 +221,16 @@ const ACTION_DATA_ENRICHERS = {
             a.value = o.value;
     }
 };
+/**
+ * Page UI language: <html lang>, falling back to the browser language.
+ * Text selectors only match in this locale, so test generation records it.
+ */
+function pageLocale() {
+    if (typeof document !== 'undefined' && document.documentElement && document.documentElement.lang) {
+        return document.documentElement.lang;
+    }
+    return typeof navigator !== 'undefined' && navigator.language ? navigator.language : '';
+}
 /**
  * Record an enhanced action with multi-strategy selectors
  */
@@ -230,6 +240,9 @@ export function recordEnhancedAction(type, element, opts = {}) {
         timestamp: Date.now(),
         url: typeof window !== 'undefined' && window.location ? window.location.href : ''
     };
+    const locale = pageLocale();
+    if (locale)
+        action.locale = locale;
     if (element) {
         action.selectors = computeSelectors(element);
     }
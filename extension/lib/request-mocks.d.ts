@@ -0,0 +1,39 @@
+/**
+ * Purpose: Serves server-configured request mocks to page fetch/XHR calls in place of the network.
+ * Docs: docs/features/feature/request-mocking/index.md
+ */
+/**
+ * @fileoverview Request mocking.
+ * Holds the mocks pushed by configure(what="mock_request") and fulfills matching
+ * fetch and XMLHttpRequest calls with the stubbed status, headers, body, and delay.
+ * Status 0 simulates a network error.
+ */
+export interface RequestMock {
+    id: string;
+    url_pattern: string;
+    method?: string;
+    status: number;
+    body?: string;
+    headers?: Record<string, string>;
+    delay_ms?: number;
+}
+/**
+ * Replace the active mocks. Entries without a url_pattern are dropped.
+ */
+export declare function setRequestMocks(mocks: unknown): void;
+export declare function getRequestMocks(): RequestMock[];
+/**
+ * Find the first mock for a request. A pattern containing * must match the whole
+ * absolute URL; any other pattern matches as a substring. Method-less mocks match any method.
+ */
+export declare function findRequestMock(url: string, method: string): RequestMock | null;
+/**
+ * Build the fetch Response for a mock after its delay. Status 0 rejects like a failed network request.
+ */
+export declare function mockFetchResponse(mock: RequestMock): Promise<Response>;
+/**
+ * Complete an opened XMLHttpRequest with a mock instead of sending it. Response getters are
+ * shadowed on the instance and the usual readystatechange/load (or error)/loadend events fire.
+ */
+export declare function fulfillXHRWithMock(xhr: XMLHttpRequest, mock: RequestMock): void;
+//# sourceMappingURL=request-mocks.d.ts.map
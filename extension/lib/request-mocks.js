@@ -0,0 +1,128 @@
+/**
+ * Purpose: Serves server-configured request mocks to page fetch/XHR calls in place of the network.
+ * Docs: docs/features/feature/request-mocking/index.md
+ */
+// =============================================================================
+// STATE
+// =============================================================================
+let requestMocks = [];
+/**
+ * Replace the active mocks. Entries without a url_pattern are dropped.
+ */
+export function setRequestMocks(mocks) {
+    requestMocks = Array.isArray(mocks)
+        ? mocks.filter((m) => m && typeof m.url_pattern === 'string' && m.url_pattern !== '')
+        : [];
+}
+export function getRequestMocks() {
+    return requestMocks;
+}
+// =============================================================================
+// MATCHING
+// =============================================================================
+function absoluteUrl(url) {
+    try {
+        return new URL(url, typeof location !== 'undefined' ? location.href : undefined).href;
+    }
+    catch {
+        return url;
+    }
+}
+function globMatches(pattern, url) {
+    const source = pattern
+        .split('*')
+        .map((part) => part.replace(/[.+?^${}()|[\]\\]/g, '\\$&'))
+        .join('.*');
+    return new RegExp(`^${source}$`).test(url);
+}
+/**
+ * Find the first mock for a request. A pattern containing * must match the whole
+ * absolute URL; any other pattern matches as a substring. Method-less mocks match any method.
+ */
+export function findRequestMock(url, method) {
+    if (requestMocks.length === 0 || !url)
+        return null;
+    const full = absoluteUrl(url);
+    const verb = (method || 'GET').toUpperCase();
+    for (const mock of requestMocks) {
+        if (mock.method && mock.method.toUpperCase() !== verb)
+            continue;
+        const hit = mock.url_pattern.includes('*')
+            ? globMatches(mock.url_pattern, full)
+            : full.includes(mock.url_pattern) || url.includes(mock.url_pattern);
+        if (hit)
+            return mock;
+    }
+    return null;
+}
+// =============================================================================
+// FULFILLMENT
+// =============================================================================
+function delay(ms) {
+    return ms && ms > 0 ? new Promise((resolve) => setTimeout(resolve, ms)) : Promise.resolve();
+}
+/**
+ * Build the fetch Response for a mock after its delay. Status 0 rejects like a failed network request.
+ */
+export async function mockFetchResponse(mock) {
+    await delay(mock.delay_ms);
+    if (!mock.status)
+        throw new TypeError('Failed to fetch');
+    // Null-body statuses reject a body in the Response constructor.
+    const nullBody = mock.status === 204 || mock.status === 205 || mock.status === 304;
+    return new Response(nullBody ? null : mock.body || '', {
+        status: mock.status,
+        headers: mock.headers || {}
+    });
+}
+function defineValue(xhr, prop, value) {
+    Object.defineProperty(xhr, prop, { configurable: true, get: () => value });
+}
+/**
+ * Complete an opened XMLHttpRequest with a mock instead of sending it. Response getters are
+ * shadowed on the instance and the usual readystatechange/load (or error)/loadend events fire.
+ */
+export function fulfillXHRWithMock(xhr, mock) {
+    const headers = {};
+    for (const [name, value] of Object.entries(mock.headers || {}))
+        headers[name.toLowerCase()] = value;
+    const body = mock.body || '';
+    void delay(mock.delay_ms).then(() => {
+        defineValue(xhr, 'readyState', 4);
+        if (!mock.status) {
+            defineValue(xhr, 'status', 0);
+            xhr.dispatchEvent(new Event('readystatechange'));
+            xhr.dispatchEvent(new ProgressEvent('error'));
+            xhr.dispatchEvent(new ProgressEvent('loadend'));
+            return;
+        }
+        let response = body;
+        if (xhr.responseType === 'json') {
+            try {
+                response = JSON.parse(body);
+            }
+            catch {
+                response = null;
+            }
+        }
+        defineValue(xhr, 'status', mock.status);
+        defineValue(xhr, 'statusText', '');
+        defineValue(xhr, 'responseText', body);
+        defineValue(xhr, 'response', response);
+        defineValue(xhr, 'responseURL', absoluteUrl(xhr.__kaboomUrl || ''));
+        Object.defineProperty(xhr, 'getResponseHeader', {
+            configurable: true,
+            value: (name) => headers[String(name).toLowerCase()] ?? null
+        });
+        Object.defineProperty(xhr, 'getAllResponseHeaders', {
+            configurable: true,
+            value: () => Object.entries(headers)
+                .map(([name, value]) => `${name}: ${value}\r\n`)
+                .join('')
+        });
+        xhr.dispatchEvent(new Event('readystatechange'));
+        xhr.dispatchEvent(new ProgressEvent('load'));
+        xhr.dispatchEvent(new ProgressEvent('loadend'));
+    });
+}
+//# sourceMappingURL=request-mocks.js.map
@@ -0,0 +1,21 @@
+/**
+ * Purpose: Wraps the EventSource constructor to capture Server-Sent Events lifecycle (open, retry, error, close) and messages, including named event types.
+ * Docs: docs/features/feature/sse-tracking/index.md
+ */
+/**
+ * Install SSE capture by wrapping the EventSource constructor.
+ */
+export declare function installSSECapture(): void;
+/**
+ * Set SSE capture enabled state
+ */
+export declare function setSSECaptureEnabled(enabled: boolean): void;
+/**
+ * Uninstall SSE capture, restoring the original constructor
+ */
+export declare function uninstallSSECapture(): void;
+/**
+ * Reset all module state for testing purposes
+ */
+export declare function resetForTesting(): void;
+//# sourceMappingURL=sse.d.ts.map
@@ -0,0 +1,141 @@
+/**
+ * Purpose: Wraps the EventSource constructor to capture Server-Sent Events lifecycle (open, retry, error, close) and messages, including named event types.
+ * Docs: docs/features/feature/sse-tracking/index.md
+ */
+import { getSize, truncateWsMessage } from './websocket-tracking.js';
+// =============================================================================
+// MODULE STATE
+// =============================================================================
+let originalEventSource = null;
+let sseCaptureEnabled = true;
+/** Events that are lifecycle notifications, not named message types */
+const LIFECYCLE_EVENTS = new Set(['open', 'error']);
+/** EventSource readyState values */
+const READY_STATE_CONNECTING = 0;
+const READY_STATE_CLOSED = 2;
+// =============================================================================
+// CAPTURE EVENT HELPERS
+// =============================================================================
+function postSSEEvent(payload) {
+    window.postMessage({ type: 'kaboom_sse', payload }, window.location.origin);
+}
+/** Post a lifecycle event (open/retry/error/close) */
+function postLifecycleEvent(event, connectionId, source) {
+    postSSEEvent({
+        event,
+        id: connectionId,
+        url: source.url,
+        ts: new Date().toISOString(),
+        ...(source.withCredentials && { with_credentials: true })
+    });
+}
+/** Post a message event, keeping the event name and Last-Event-ID */
+function postMessageEvent(connectionId, source, message) {
+    const raw = typeof message.data === 'string' ? message.data : String(message.data);
+    const { data, truncated } = truncateWsMessage(raw);
+    postSSEEvent({
+        event: 'message',
+        id: connectionId,
+        url: source.url,
+        ts: new Date().toISOString(),
+        event_type: message.type,
+        ...(message.lastEventId && { last_event_id: message.lastEventId }),
+        data,
+        size: getSize(raw),
+        ...(truncated && { truncated: true })
+    });
+}
+/** Attach lifecycle and message capture to an EventSource instance */
+function attachCapture(source, connectionId) {
+    const originalAddEventListener = source.addEventListener;
+    const captured = new Set();
+    const captureType = (type) => {
+        if (captured.has(type) || LIFECYCLE_EVENTS.has(type))
+            return;
+        captured.add(type);
+        originalAddEventListener.call(source, type, (event) => {
+            if (!sseCaptureEnabled)
+                return;
+            postMessageEvent(connectionId, source, event);
+        });
+    };
+    source.addEventListener = function (type, listener, options) {
+        captureType(type);
+        originalAddEventListener.call(source, type, listener, options);
+    };
+    captureType('message');
+    originalAddEventListener.call(source, 'open', () => {
+        if (!sseCaptureEnabled)
+            return;
+        postLifecycleEvent('open', connectionId, source);
+    });
+    originalAddEventListener.call(source, 'error', () => {
+        if (!sseCaptureEnabled)
+            return;
+        const reconnecting = source.readyState === READY_STATE_CONNECTING;
+        postLifecycleEvent(reconnecting ? 'retry' : 'error', connectionId, source);
+    });
+    const originalClose = source.close.bind(source);
+    source.close = function () {
+        const wasClosed = source.readyState === READY_STATE_CLOSED;
+        originalClose();
+        if (sseCaptureEnabled && !wasClosed)
+            postLifecycleEvent('close', connectionId, source);
+    };
+}
+// =============================================================================
+// INSTALLATION
+// =============================================================================
+/**
+ * Install SSE capture by wrapping the EventSource constructor.
+ */
+export function installSSECapture() {
+    if (typeof window === 'undefined')
+        return;
+    if (!window.EventSource)
+        return; // No EventSource support
+    if (originalEventSource)
+        return; // Already installed
+    sseCaptureEnabled = true;
+    originalEventSource = window.EventSource;
+    const OriginalES = originalEventSource;
+    function KaboomEventSource(url, init) {
+        const source = new OriginalES(url, init);
+        attachCapture(source, crypto.randomUUID());
+        return source;
+    }
+    KaboomEventSource.prototype = OriginalES.prototype;
+    Object.defineProperty(KaboomEventSource, 'CONNECTING', { value: OriginalES.CONNECTING, writable: false });
+    Object.defineProperty(KaboomEventSource, 'OPEN', { value: OriginalES.OPEN, writable: false });
+    Object.defineProperty(KaboomEventSource, 'CLOSED', { value: OriginalES.CLOSED, writable: false });
+    window.EventSource = KaboomEventSource;
+}
+// =============================================================================
+// CONFIGURATION
+// =============================================================================
+/**
+ * Set SSE capture enabled state
+ */
+export function setSSECaptureEnabled(enabled) {
+    sseCaptureEnabled = enabled;
+}
+/**
+ * Uninstall SSE capture, restoring the original constructor
+ */
+export function uninstallSSECapture() {
+    if (typeof window === 'undefined')
+        return;
+    if (originalEventSource) {
+        window.EventSource = originalEventSource;
+        originalEventSource = null;
+    }
+}
+/**
+ * Reset all module state for testing purposes
+ */
+export function resetForTesting() {
+    uninstallSSECapture();
+    sseCaptureEnabled = false;
+    originalEventSource = null;
+}
+//# sourceMappingURL=sse.js.map
@@ -9,6 +9,7 @@
  */
 import type { LogEntry } from './telemetry.js';
 import type { WebSocketEvent, WebSocketCaptureMode } from './websocket.js';
+import type { WireSSEEvent } from './wire-sse-event.js';
 import type { NetworkBodyPayload } from './network.js';
 import type { EnhancedAction } from './actions.js';
 import type { PerformanceSnapshot } from './performance.js';
@@ -33,6 +34,14 @@ export interface WsEventMessage {
     readonly payload: WebSocketEvent;
     readonly tabId?: number;
 }
+/**
+ * Server-Sent Events (EventSource) event message from content script
+ */
+export interface SseEventMessage {
+    readonly type: 'sse_event';
+    readonly payload: WireSSEEvent;
+    readonly tabId?: number;
+}
 /**
  * Enhanced action message from content script
  */
@@ -167,7 +176,7 @@ export interface VersionMismatchMessage {
 /**
  * Union of all background-bound messages
  */
-export type BackgroundMessage = GetTabIdMessage | WsEventMessage | EnhancedActionMessage | NetworkBodyMessage | PerformanceSnapshotMessage | LogMessage | GetStatusMessage | ClearLogsMessage | SetLogLevelMessage | SetBooleanSettingMessage | SetWebSocketCaptureModeMessage | GetAiWebPilotEnabledMessage | GetTrackingStateMessage | GetDiagnosticStateMessage | CaptureScreenshotMessage | GetDebugLogMessage | ClearDebugLogMessage | SetServerUrlMessage | DrawModeCaptureScreenshotMessage | DrawModeCompletedMessage | PushChatMessage | ScreenRecordingStartMessage | ScreenRecordingStopMessage | RecordingGestureGrantedMessage | RecordingGestureDeniedMessage | OpenPopupForRecordingMessage | OpenTerminalPanelMessage | QaScanRequestedMessage;
+export type BackgroundMessage = GetTabIdMessage | WsEventMessage | SseEventMessage | EnhancedActionMessage | NetworkBodyMessage | PerformanceSnapshotMessage | LogMessage | GetStatusMessage | ClearLogsMessage | SetLogLevelMessage | SetBooleanSettingMessage | SetWebSocketCaptureModeMessage | GetAiWebPilotEnabledMessage | GetTrackingStateMessage | GetDiagnosticStateMessage | CaptureScreenshotMessage | GetDebugLogMessage | ClearDebugLogMessage | SetServerUrlMessage | DrawModeCaptureScreenshotMessage | DrawModeCompletedMessage | PushChatMessage | ScreenRecordingStartMessage | ScreenRecordingStopMessage | RecordingGestureGrantedMessage | RecordingGestureDeniedMessage | OpenPopupForRecordingMessage | OpenTerminalPanelMessage | QaScanRequestedMessage;
 /**
  * Draw mode: content script requests screenshot capture
  */
@@ -453,7 +462,7 @@ export type ContentMessage = ContentPingMessage | HighlightMessage | ExecuteJsMe
 /**
  * Page to content script messages (postMessage types)
  */
-export type PageMessageType = 'kaboom_log' | 'kaboom_ws' | 'kaboom_network_body' | 'kaboom_enhanced_action' | 'kaboom_performance_snapshot' | 'kaboom_inject_bridge_pong' | 'kaboom_highlight_response' | 'kaboom_execute_js_result' | 'kaboom_a11y_query_response' | 'kaboom_dom_query_response' | 'kaboom_state_response' | 'kaboom_waterfall_response' | 'kaboom_link_health_response' | 'kaboom_form_state_response' | 'kaboom_data_table_response';
+export type PageMessageType = 'kaboom_log' | 'kaboom_ws' | 'kaboom_sse' | 'kaboom_network_body' | 'kaboom_enhanced_action' | 'kaboom_performance_snapshot' | 'kaboom_inject_bridge_pong' | 'kaboom_highlight_response' | 'kaboom_execute_js_result' | 'kaboom_a11y_query_response' | 'kaboom_dom_query_response' | 'kaboom_state_response' | 'kaboom_waterfall_response' | 'kaboom_link_health_response' | 'kaboom_form_state_response' | 'kaboom_data_table_response';
 /**
  * Content to page messages (postMessage types)
  */
@@ -22,8 +22,10 @@ export interface WireEnhancedAction {
     readonly selected_text?: string;
     readonly scroll_y?: number;
     readonly tab_id?: number;
+    readonly frame_id?: number;
     readonly classification?: string;
     readonly duration_ms?: number;
     readonly role?: string;
+    readonly locale?: string;
 }
 //# sourceMappingURL=wire-enhanced-action.d.ts.map
\ No newline at end of file
@@ -17,7 +17,10 @@ export interface WireNetworkBody {
     readonly duration?: number;
     readonly request_truncated?: boolean;
     readonly response_truncated?: boolean;
+    readonly response_encoding?: string;
+    readonly response_size?: number;
     readonly tab_id?: number;
+    readonly frame_id?: number;
 }
 /**
  * WireNetworkWaterfallEntry is the JSON shape for a single PerformanceResourceTiming entry.
@@ -41,5 +44,6 @@ export interface WireNetworkWaterfallEntry {
 export interface WireNetworkWaterfallPayload {
     readonly entries: readonly WireNetworkWaterfallEntry[];
     readonly page_url: string;
+    readonly tab_id?: number;
 }
 //# sourceMappingURL=wire-network.d.ts.map
\ No newline at end of file
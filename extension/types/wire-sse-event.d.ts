@@ -0,0 +1,24 @@
+/**
+ * @fileoverview Wire type for Server-Sent Events — matches internal/types/wire_sse_event.go
+ *
+ * Canonical TypeScript definition for the SSEEvent HTTP payload.
+ * Changes here MUST be mirrored in the Go counterpart. Run `make check-wire-drift`.
+ */
+/**
+ * WireSSEEvent is the JSON shape sent over HTTP for captured EventSource events.
+ */
+export interface WireSSEEvent {
+    readonly ts?: string;
+    readonly event: 'open' | 'message' | 'error' | 'retry' | 'close';
+    readonly id: string;
+    readonly url?: string;
+    readonly event_type?: string;
+    readonly last_event_id?: string;
+    readonly data?: string;
+    readonly size?: number;
+    readonly truncated?: boolean;
+    readonly with_credentials?: boolean;
+    readonly tab_id?: number;
+    readonly frame_id?: number;
+}
+//# sourceMappingURL=wire-sse-event.d.ts.map
@@ -0,0 +1,5 @@
+// THIS FILE IS GENERATED — do not edit by hand.
+// Source: internal/types/wire_sse_event.go
+// Generator: scripts/generate-wire-types.js
+export {};
+//# sourceMappingURL=wire-sse-event.js.map
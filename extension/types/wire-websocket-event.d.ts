@@ -18,5 +18,7 @@ export interface WireWebSocketEvent {
     readonly size?: number;
     readonly code?: number;
     readonly reason?: string;
+    readonly tab_id?: number;
+    readonly frame_id?: number;
 }
 //# sourceMappingURL=wire-websocket-event.d.ts.map
\ No newline at end of file
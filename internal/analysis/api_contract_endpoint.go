@@ -16,6 +16,11 @@ var (
 	contractHexPattern     = regexp.MustCompile(`^[0-9a-fA-F]{16,}$`)
 )
 
+// NormalizeEndpoint exposes normalizeEndpoint for callers that compare endpoints across sessions.
+func NormalizeEndpoint(method, rawURL string) string {
+	return normalizeEndpoint(method, rawURL)
+}
+
 // normalizeEndpoint converts a METHOD + URL into a normalized endpoint key.
 // Dynamic segments (numeric IDs, UUIDs, hex hashes) are replaced with {id}.
 // Query parameters are stripped.
@@ -0,0 +1,512 @@
+// Purpose: Freezes an endpoint's inferred response schema as a locked contract and checks every later response against it.
+// Why: Learned shapes drift with the API; a locked contract turns new fields, type changes, and dropped keys into immediate alerts.
+// Docs: docs/features/feature/api-schema/index.md
+
+package analysis
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/state"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/types"
+)
+
+// Locked contract violation kinds.
+const (
+	ContractNewField        = "new_field"
+	ContractTypeChange      = "type_change"
+	ContractNullField       = "null_field"
+	ContractMissingRequired = "missing_required"
+)
+
+// maxLockedContractViolations bounds distinct violations retained by the monitor.
+const maxLockedContractViolations = 200
+
+// rootContractPath names the response root in violations (e.g. an object turned into an array).
+const rootContractPath = "$"
+
+// LockedAPIContract is the frozen response schema for one endpoint.
+// Fields maps flattened paths ("user.id", "items[].price") to their observed types;
+// a field seen with several types lists them joined by "|" (e.g. "null|string").
+type LockedAPIContract struct {
+	Endpoint string            `json:"endpoint"` // "METHOD /path", normalized like the validator's keys
+	LockedAt string            `json:"locked_at"`
+	Samples  int               `json:"samples"`
+	RootType string            `json:"root_type"`
+	Fields   map[string]string `json:"fields"`
+	Required []string          `json:"required"` // present in every sample where the parent was present
+}
+
+// LockedContractViolation is one distinct deviation from a locked contract, keyed by endpoint, kind, and field.
+type LockedContractViolation struct {
+	Endpoint     string    `json:"endpoint"`
+	Kind         string    `json:"kind"`
+	Severity     string    `json:"severity"`
+	Field        string    `json:"field"`
+	ExpectedType string    `json:"expected_type,omitempty"`
+	ActualType   string    `json:"actual_type,omitempty"`
+	URL          string    `json:"url"`
+	Status       int       `json:"status"`
+	Count        int       `json:"count"`
+	FirstSeen    time.Time `json:"first_seen"`
+	LastSeen     time.Time `json:"last_seen"`
+}
+
+// LockedContractSummary is the monitor state returned by observe(what="contract_violations").
+type LockedContractSummary struct {
+	Status          string                    `json:"status"` // "no_contracts", "clean", or "violations"
+	Contracts       []LockedAPIContract       `json:"contracts"`
+	TotalViolations int                       `json:"total_violations"`
+	ByKind          map[string]int            `json:"by_kind"`
+	Violations      []LockedContractViolation `json:"violations"`
+}
+
+// APIContractMonitor holds locked contracts and the violations seen against them.
+// Contracts persist to disk; violations live for the session. Each distinct violation
+// raises one alert when first seen; repeats only bump its count.
+type APIContractMonitor struct {
+	mu         sync.Mutex
+	path       string // empty disables persistence
+	contracts  map[string]LockedAPIContract
+	violations map[string]*LockedContractViolation
+	order      []string // insertion order for eviction
+}
+
+// APIContractsPath returns the file locked contracts persist to.
+func APIContractsPath() (string, error) {
+	return state.InRoot("api_contracts", "contracts.json")
+}
+
+// NewAPIContractMonitor creates a monitor backed by path and loads any contracts stored there.
+// An unreadable file is ignored so a corrupt store never blocks startup; the next lock rewrites it.
+func NewAPIContractMonitor(path string) *APIContractMonitor {
+	m := &APIContractMonitor{
+		path:       path,
+		contracts:  make(map[string]LockedAPIContract),
+		violations: make(map[string]*LockedContractViolation),
+	}
+	if path == "" {
+		return m
+	}
+	data, err := os.ReadFile(path) // #nosec G304 -- path is derived from the state dir
+	if err != nil {
+		return m
+	}
+	var stored []LockedAPIContract
+	if json.Unmarshal(data, &stored) != nil {
+		return m
+	}
+	for _, c := range stored {
+		m.contracts[c.Endpoint] = c
+	}
+	return m
+}
+
+// ParseContractEndpoint normalizes "METHOD /path", "METHOD https://host/path", or a bare
+// path/URL (GET assumed) into the endpoint key the validator and monitor use.
+func ParseContractEndpoint(raw string) (string, error) {
+	raw = strings.TrimSpace(raw)
+	method, target := "GET", raw
+	if before, after, ok := strings.Cut(raw, " "); ok {
+		method, target = strings.ToUpper(before), strings.TrimSpace(after)
+	}
+	if target == "" {
+		return "", errors.New("endpoint is empty")
+	}
+	return normalizeEndpoint(method, target), nil
+}
+
+// InferAPIContract builds a contract for endpoint from the captured 2xx JSON responses to it.
+// Returns an error when no usable sample exists.
+func InferAPIContract(endpoint string, bodies []capture.NetworkBody, now time.Time) (LockedAPIContract, error) {
+	shaper := &APIContractValidator{}
+	fieldTypes := map[string]map[string]bool{}
+	present := map[string]int{} // samples where the path appeared
+	nonNull := map[string]int{} // samples where the path held a non-null value
+	rootTypes := map[string]bool{}
+	samples := 0
+	for _, body := range bodies {
+		parsed, ok := parseContractBody(body)
+		if !ok || normalizeEndpoint(body.Method, body.URL) != endpoint {
+			continue
+		}
+		samples++
+		shape := shaper.extractShape(parsed, 0)
+		rootTypes[describeType(shape)] = true
+		fields := map[string]string{}
+		flattenContractShape(shape, "", fields)
+		for path, fieldType := range fields {
+			if fieldTypes[path] == nil {
+				fieldTypes[path] = map[string]bool{}
+			}
+			fieldTypes[path][fieldType] = true
+			present[path]++
+			if fieldType != "null" {
+				nonNull[path]++
+			}
+		}
+	}
+	if samples == 0 {
+		return LockedAPIContract{}, fmt.Errorf("no 2xx JSON responses captured for %s", endpoint)
+	}
+
+	contract := LockedAPIContract{
+		Endpoint: endpoint,
+		LockedAt: now.UTC().Format(time.RFC3339),
+		Samples:  samples,
+		RootType: joinContractTypes(rootTypes),
+		Fields:   make(map[string]string, len(fieldTypes)),
+		Required: []string{},
+	}
+	for path, seen := range fieldTypes {
+		contract.Fields[path] = joinContractTypes(seen)
+		parentCount := samples
+		if parent := contractParent(path); parent != "" {
+			parentCount = nonNull[parent]
+		}
+		// Array elements ("items[]") are never required: an empty list is a valid response.
+		if present[path] == parentCount && !strings.HasSuffix(path, "[]") {
+			contract.Required = append(contract.Required, path)
+		}
+	}
+	sort.Strings(contract.Required)
+	return contract, nil
+}
+
+// Lock stores contract, replacing any existing contract for its endpoint, and persists the set.
+// Violations recorded against a previous contract for the endpoint are dropped.
+func (m *APIContractMonitor) Lock(contract LockedAPIContract) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.contracts[contract.Endpoint] = contract
+	m.dropViolationsLocked(contract.Endpoint)
+	return m.saveLocked()
+}
+
+// Unlock removes the contract for endpoint, or every contract when endpoint is empty.
+// Returns the number of contracts removed.
+func (m *APIContractMonitor) Unlock(endpoint string) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	removed := 0
+	for key := range m.contracts {
+		if endpoint == "" || key == endpoint {
+			delete(m.contracts, key)
+			m.dropViolationsLocked(key)
+			removed++
+		}
+	}
+	if removed == 0 {
+		return 0, nil
+	}
+	return removed, m.saveLocked()
+}
+
+// Contracts returns the locked contracts sorted by endpoint.
+func (m *APIContractMonitor) Contracts() []LockedAPIContract {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.sortedContractsLocked()
+}
+
+// Observe checks one ingested batch against the locked contracts and returns alerts for violations not seen before.
+func (m *APIContractMonitor) Observe(activity capture.NetworkActivity, now time.Time) []types.Alert {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if len(m.contracts) == 0 {
+		return nil
+	}
+
+	var alerts []types.Alert
+	for _, body := range activity.Bodies {
+		contract, ok := m.contracts[normalizeEndpoint(body.Method, body.URL)]
+		if !ok {
+			continue
+		}
+		parsed, ok := parseContractBody(body)
+		if !ok {
+			continue
+		}
+		for _, v := range checkLockedContract(contract, parsed) {
+			key := v.Endpoint + "|" + v.Kind + "|" + v.Field
+			v.URL, v.Status, v.LastSeen = body.URL, body.Status, now
+			if existing, ok := m.violations[key]; ok {
+				existing.Count++
+				existing.URL, existing.Status, existing.LastSeen = v.URL, v.Status, now
+				existing.ActualType = v.ActualType
+				continue
+			}
+			v.Count = 1
+			v.FirstSeen = now
+			m.violations[key] = &v
+			m.order = append(m.order, key)
+			m.evictLocked()
+			alerts = append(alerts, lockedContractAlert(v, now))
+		}
+	}
+	return alerts
+}
+
+// Summary returns the contracts and retained violations, most recent first, optionally
+// filtered by endpoint substring. limit <= 0 returns every violation.
+func (m *APIContractMonitor) Summary(endpointFilter string, limit int) LockedContractSummary {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	summary := LockedContractSummary{
+		Status:     "clean",
+		Contracts:  []LockedAPIContract{},
+		ByKind:     map[string]int{},
+		Violations: []LockedContractViolation{},
+	}
+	for _, c := range m.sortedContractsLocked() {
+		if strings.Contains(c.Endpoint, endpointFilter) {
+			summary.Contracts = append(summary.Contracts, c)
+		}
+	}
+	for _, key := range m.order {
+		v := m.violations[key]
+		if !strings.Contains(v.Endpoint, endpointFilter) {
+			continue
+		}
+		summary.Violations = append(summary.Violations, *v)
+		summary.ByKind[v.Kind]++
+	}
+	sort.SliceStable(summary.Violations, func(i, j int) bool {
+		return summary.Violations[i].LastSeen.After(summary.Violations[j].LastSeen)
+	})
+	summary.TotalViolations = len(summary.Violations)
+	if limit > 0 && len(summary.Violations) > limit {
+		summary.Violations = summary.Violations[:limit]
+	}
+	switch {
+	case summary.TotalViolations > 0:
+		summary.Status = "violations"
+	case len(m.contracts) == 0:
+		summary.Status = "no_contracts"
+	}
+	return summary
+}
+
+// checkLockedContract compares one parsed response against contract.
+// Only the topmost deviation is reported: a new object's children, the keys under a field
+// whose type changed, and items of an empty array are not reported again.
+func checkLockedContract(contract LockedAPIContract, parsed any) []LockedContractViolation {
+	shape := (&APIContractValidator{}).extractShape(parsed, 0)
+	if rootType := describeType(shape); !contractTypeAllows(contract.RootType, rootType) {
+		return []LockedContractViolation{newLockedViolation(contract.Endpoint, rootContractPath, contract.RootType, rootType)}
+	}
+	actual := map[string]string{}
+	flattenContractShape(shape, "", actual)
+
+	// parentMatches reports whether path's parent is present with its contracted type,
+	// so a deviation there is new rather than a side effect of a change higher up.
+	parentMatches := func(path string) bool {
+		parent := contractParent(path)
+		if parent == "" {
+			return true
+		}
+		actualType, ok := actual[parent]
+		return ok && actualType != "null" && contractTypeAllows(contract.Fields[parent], actualType)
+	}
+
+	var found []LockedContractViolation
+	for _, path := range contract.Required {
+		if _, ok := actual[path]; !ok && parentMatches(path) {
+			found = append(found, LockedContractViolation{
+				Endpoint: contract.Endpoint, Kind: ContractMissingRequired, Severity: violationSeverity(ContractMissingRequired),
+				Field: path, ExpectedType: contract.Fields[path],
+			})
+		}
+	}
+	for path, actualType := range actual {
+		if !parentMatches(path) {
+			continue
+		}
+		expected, known := contract.Fields[path]
+		switch {
+		case !known && !strings.HasSuffix(path, "[]"):
+			found = append(found, LockedContractViolation{
+				Endpoint: contract.Endpoint, Kind: ContractNewField, Severity: violationSeverity(ContractNewField),
+				Field: path, ActualType: actualType,
+			})
+		case known && !contractTypeAllows(expected, actualType):
+			found = append(found, newLockedViolation(contract.Endpoint, path, expected, actualType))
+		}
+	}
+	sort.Slice(found, func(i, j int) bool {
+		if found[i].Field != found[j].Field {
+			return found[i].Field < found[j].Field
+		}
+		return found[i].Kind < found[j].Kind
+	})
+	return found
+}
+
+// newLockedViolation classifies a type mismatch: null where a value was contracted is null_field, anything else type_change.
+func newLockedViolation(endpoint, field, expected, actual string) LockedContractViolation {
+	kind := ContractTypeChange
+	if actual == "null" {
+		kind = ContractNullField
+	}
+	return LockedContractViolation{
+		Endpoint: endpoint, Kind: kind, Severity: violationSeverity(kind),
+		Field: field, ExpectedType: expected, ActualType: actual,
+	}
+}
+
+// contractTypeAllows reports whether actual is one of the contracted types.
+// A field only ever seen as null has no known type and accepts anything.
+func contractTypeAllows(expected, actual string) bool {
+	if expected == "null" || expected == actual {
+		return true
+	}
+	for _, t := range strings.Split(expected, "|") {
+		if t == actual {
+			return true
+		}
+	}
+	return false
+}
+
+// flattenContractShape records the type of every path in an extracted shape.
+// Object keys join with "." and array elements append "[]"; the root itself is not recorded.
+func flattenContractShape(shape any, prefix string, out map[string]string) {
+	switch s := shape.(type) {
+	case map[string]any:
+		if elem, ok := s["$array"]; ok {
+			if prefix != "" {
+				out[prefix] = "array"
+			}
+			flattenContractShape(elem, prefix+"[]", out)
+			return
+		}
+		if prefix != "" {
+			out[prefix] = "object"
+		}
+		for key, value := range s {
+			path := key
+			if prefix != "" {
+				path = prefix + "." + key
+			}
+			flattenContractShape(value, path, out)
+		}
+	case string:
+		if prefix != "" {
+			out[prefix] = s
+		}
+	}
+}
+
+// contractParent returns the path one level up ("items[].id" -> "items[]", "items[]" -> "items"), or "" for the root.
+func contractParent(path string) string {
+	if trimmed, ok := strings.CutSuffix(path, "[]"); ok {
+		return trimmed
+	}
+	if i := strings.LastIndex(path, "."); i >= 0 {
+		return path[:i]
+	}
+	return ""
+}
+
+func joinContractTypes(seen map[string]bool) string {
+	out := make([]string, 0, len(seen))
+	for t := range seen {
+		out = append(out, t)
+	}
+	sort.Strings(out)
+	return strings.Join(out, "|")
+}
+
+// parseContractBody decodes a complete 2xx JSON response body.
+func parseContractBody(body capture.NetworkBody) (any, bool) {
+	if body.Status < 200 || body.Status >= 300 || body.ResponseBody == "" || body.ResponseTruncated {
+		return nil, false
+	}
+	if body.ContentType != "" && !strings.Contains(body.ContentType, "json") {
+		return nil, false
+	}
+	var parsed any
+	if err := json.Unmarshal([]byte(body.ResponseBody), &parsed); err != nil {
+		return nil, false
+	}
+	return parsed, true
+}
+
+func lockedContractAlert(v LockedContractViolation, now time.Time) types.Alert {
+	severity := "warning"
+	if v.Severity == "high" {
+		severity = "error"
+	}
+	var detail string
+	switch v.Kind {
+	case ContractNewField:
+		detail = fmt.Sprintf("%s returned field %q (%s), which is not in the locked contract.", v.URL, v.Field, v.ActualType)
+	case ContractMissingRequired:
+		detail = fmt.Sprintf("%s omitted required field %q (%s).", v.URL, v.Field, v.ExpectedType)
+	default:
+		detail = fmt.Sprintf("%s returned %q as %s; the locked contract expects %s.", v.URL, v.Field, v.ActualType, v.ExpectedType)
+	}
+	return types.Alert{
+		Severity:  severity,
+		Category:  "regression",
+		Title:     fmt.Sprintf("API contract violation (%s): %s", v.Kind, v.Endpoint),
+		Detail:    detail + ` Inspect with observe(what="contract_violations").`,
+		Timestamp: now.Format(time.RFC3339),
+		Source:    "api_contract",
+	}
+}
+
+// dropViolationsLocked forgets violations for endpoint. Must be called with m.mu held.
+func (m *APIContractMonitor) dropViolationsLocked(endpoint string) {
+	kept := m.order[:0]
+	for _, key := range m.order {
+		if m.violations[key].Endpoint == endpoint {
+			delete(m.violations, key)
+			continue
+		}
+		kept = append(kept, key)
+	}
+	m.order = kept
+}
+
+// evictLocked drops the oldest violations beyond the retention cap. Must be called with m.mu held.
+func (m *APIContractMonitor) evictLocked() {
+	for len(m.order) > maxLockedContractViolations {
+		delete(m.violations, m.order[0])
+		m.order = m.order[1:]
+	}
+}
+
+func (m *APIContractMonitor) sortedContractsLocked() []LockedAPIContract {
+	out := make([]LockedAPIContract, 0, len(m.contracts))
+	for _, c := range m.contracts {
+		out = append(out, c)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Endpoint < out[j].Endpoint })
+	return out
+}
+
+// saveLocked writes the contracts to m.path, creating the parent directory. Must be called with m.mu held.
+func (m *APIContractMonitor) saveLocked() error {
+	if m.path == "" {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(m.path), 0o755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(m.sortedContractsLocked(), "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(m.path, data, 0o600)
+}
@@ -0,0 +1,164 @@
+// Purpose: Tests locked API contracts: inference from captured responses, violation detection, and persistence.
+// Docs: docs/features/feature/api-schema/index.md
+
+package analysis
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+)
+
+func contractBody(url, body string) capture.NetworkBody {
+	return capture.NetworkBody{Method: "GET", URL: url, Status: 200, ContentType: "application/json", ResponseBody: body}
+}
+
+func TestInferAPIContract_FieldsTypesAndRequired(t *testing.T) {
+	t.Parallel()
+	bodies := []capture.NetworkBody{
+		contractBody("https://api.test/users/1", `{"id":1,"name":"a","nickname":null,"tags":[{"k":"x"}],"meta":{"v":1}}`),
+		contractBody("https://api.test/users/2", `{"id":2,"name":"b","nickname":"bee","tags":[],"meta":{"v":2}}`),
+		contractBody("https://api.test/users/3", `{"id":3,"name":"c","tags":[{"k":"y"}],"meta":{"v":3}}`),
+		contractBody("https://api.test/orders", `{"total":5}`), // other endpoint
+	}
+	contract, err := InferAPIContract("GET /users/{id}", bodies, time.Now())
+	if err != nil {
+		t.Fatalf("InferAPIContract: %v", err)
+	}
+	if contract.Samples != 3 || contract.RootType != "object" {
+		t.Fatalf("samples/root = %d/%s, want 3/object", contract.Samples, contract.RootType)
+	}
+	if got := contract.Fields["nickname"]; got != "null|string" {
+		t.Errorf("nickname type = %q, want null|string", got)
+	}
+	if got := contract.Fields["tags[].k"]; got != "string" {
+		t.Errorf("tags[].k type = %q, want string", got)
+	}
+	required := strings.Join(contract.Required, ",")
+	if required != "id,meta,meta.v,name,tags,tags[].k" {
+		t.Errorf("required = %s", required)
+	}
+
+	if _, err := InferAPIContract("GET /missing", bodies, time.Now()); err == nil {
+		t.Error("InferAPIContract with no samples should fail")
+	}
+}
+
+func TestAPIContractMonitor_ReportsEachViolationKindOnce(t *testing.T) {
+	t.Parallel()
+	contract, err := InferAPIContract("GET /users/{id}", []capture.NetworkBody{
+		contractBody("https://api.test/users/1", `{"id":1,"name":"a","address":{"city":"x"},"items":[{"sku":"s"}]}`),
+	}, time.Now())
+	if err != nil {
+		t.Fatalf("InferAPIContract: %v", err)
+	}
+	m := NewAPIContractMonitor("")
+	if err := m.Lock(contract); err != nil {
+		t.Fatalf("Lock: %v", err)
+	}
+
+	now := time.Now()
+	// Conforming response, and an empty list whose items are not required.
+	if alerts := m.Observe(capture.NetworkActivity{Bodies: []capture.NetworkBody{
+		contractBody("https://api.test/users/2", `{"id":2,"name":"b","address":{"city":"y"},"items":[]}`),
+	}}, now); len(alerts) != 0 {
+		t.Fatalf("conforming response raised alerts: %+v", alerts)
+	}
+
+	drifted := contractBody("https://api.test/users/3", `{"id":"3","address":null,"items":[{"sku":"s","qty":1}],"extra":{"a":1}}`)
+	alerts := m.Observe(capture.NetworkActivity{Bodies: []capture.NetworkBody{drifted}}, now)
+	summary := m.Summary("", 0)
+	got := map[string]string{}
+	for _, v := range summary.Violations {
+		got[v.Field] = v.Kind
+	}
+	want := map[string]string{
+		"id":          ContractTypeChange,
+		"name":        ContractMissingRequired,
+		"address":     ContractNullField,
+		"items[].qty": ContractNewField,
+		"extra":       ContractNewField, // extra.a is not reported separately
+	}
+	if len(got) != len(want) {
+		t.Fatalf("violations = %v, want %v", got, want)
+	}
+	for field, kind := range want {
+		if got[field] != kind {
+			t.Errorf("violation for %s = %q, want %q", field, got[field], kind)
+		}
+	}
+	if len(alerts) != len(want) {
+		t.Fatalf("len(alerts) = %d, want %d", len(alerts), len(want))
+	}
+	for _, a := range alerts {
+		if a.Category != "regression" || a.Source != "api_contract" {
+			t.Errorf("unexpected alert shape: %+v", a)
+		}
+	}
+
+	// Repeats bump counts without new alerts.
+	if again := m.Observe(capture.NetworkActivity{Bodies: []capture.NetworkBody{drifted}}, now); len(again) != 0 {
+		t.Fatalf("repeat raised %d alerts", len(again))
+	}
+	if summary := m.Summary("", 1); summary.TotalViolations != len(want) || len(summary.Violations) != 1 || summary.Violations[0].Count != 2 {
+		t.Fatalf("limited summary = %+v", summary)
+	}
+}
+
+func TestAPIContractMonitor_RootTypeChangeIsSingleViolation(t *testing.T) {
+	t.Parallel()
+	contract, _ := InferAPIContract("GET /list", []capture.NetworkBody{
+		contractBody("https://api.test/list", `[{"id":1}]`),
+	}, time.Now())
+	m := NewAPIContractMonitor("")
+	_ = m.Lock(contract)
+	m.Observe(capture.NetworkActivity{Bodies: []capture.NetworkBody{
+		contractBody("https://api.test/list", `{"items":[{"id":1}]}`),
+	}}, time.Now())
+	summary := m.Summary("", 0)
+	if summary.TotalViolations != 1 || summary.Violations[0].Field != "$" || summary.Violations[0].ActualType != "object" {
+		t.Fatalf("summary = %+v, want one root type_change", summary)
+	}
+}
+
+func TestAPIContractMonitor_PersistsContracts(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "api_contracts", "contracts.json")
+	contract, _ := InferAPIContract("POST /login", []capture.NetworkBody{{
+		Method: "POST", URL: "https://api.test/login", Status: 200, ResponseBody: `{"token":"t"}`,
+	}}, time.Now())
+	if err := NewAPIContractMonitor(path).Lock(contract); err != nil {
+		t.Fatalf("Lock: %v", err)
+	}
+
+	reloaded := NewAPIContractMonitor(path)
+	if got := reloaded.Contracts(); len(got) != 1 || got[0].Endpoint != "POST /login" {
+		t.Fatalf("reloaded contracts = %+v", got)
+	}
+	if removed, err := reloaded.Unlock("POST /login"); err != nil || removed != 1 {
+		t.Fatalf("Unlock = %d, %v", removed, err)
+	}
+	if got := NewAPIContractMonitor(path).Summary("", 0); got.Status != "no_contracts" {
+		t.Fatalf("status after unlock = %q, want no_contracts", got.Status)
+	}
+}
+
+func TestParseContractEndpoint(t *testing.T) {
+	t.Parallel()
+	cases := map[string]string{
+		"/users/42":                        "GET /users/{id}",
+		"post https://api.test/orders?x=1": "POST /orders",
+		"DELETE /items/abc":                "DELETE /items/abc",
+	}
+	for raw, want := range cases {
+		if got, err := ParseContractEndpoint(raw); err != nil || got != want {
+			t.Errorf("ParseContractEndpoint(%q) = %q, %v; want %q", raw, got, err, want)
+		}
+	}
+	if _, err := ParseContractEndpoint("  "); err == nil {
+		t.Error("empty endpoint should fail")
+	}
+}
@@ -214,7 +214,7 @@ func violationSeverity(violationType string) string {
 	switch violationType {
 	case "error_spike":
 		return "critical"
-	case "shape_change":
+	case "shape_change", "missing_required":
 		return "high"
 	case "type_change":
 		return "high"
@@ -93,6 +93,12 @@ func parseStack(stack string) []StackFrame {
 	return frames
 }
 
+// ParseStack parses a V8-style stack trace ("at fn (file:line:col)") into frames.
+// Lines that are not frames are returned with Function "<unknown>" and zero Line.
+func ParseStack(stack string) []StackFrame {
+	return parseStack(stack)
+}
+
 func appFrames(frames []StackFrame) []StackFrame {
 	result := make([]StackFrame, 0)
 	for _, f := range frames {
@@ -0,0 +1,233 @@
+// Purpose: Tracks error cluster fingerprints across sessions and flags clusters that regress after being cleared.
+// Why: Session-scoped clustering forgets everything on restart; history tells new breakage apart from known noise and catches fixes that came undone.
+// Docs: docs/features/feature/error-clustering/index.md
+
+package analysis
+
+import (
+	"encoding/json"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Error cluster history states.
+const (
+	ClusterStatusActive    = "active"
+	ClusterStatusResolved  = "resolved"
+	ClusterStatusRegressed = "regressed"
+)
+
+// maxClusterHistory bounds persisted clusters; the least recently seen are evicted first.
+const maxClusterHistory = 500
+
+// ClusterObservation is one error occurrence keyed by its cluster fingerprint.
+type ClusterObservation struct {
+	ID      string
+	Message string
+}
+
+// ClusterHistoryRecord is the persisted lifetime of one error cluster.
+type ClusterHistoryRecord struct {
+	ID          string     `json:"id"`
+	Message     string     `json:"message"`
+	FirstSeen   time.Time  `json:"first_seen"`
+	LastSeen    time.Time  `json:"last_seen"`
+	TotalCount  int        `json:"total_count"`
+	Sessions    int        `json:"sessions"`
+	Status      string     `json:"status"`
+	ResolvedAt  *time.Time `json:"resolved_at,omitempty"`
+	RegressedAt *time.Time `json:"regressed_at,omitempty"`
+	Regressions int        `json:"regressions,omitempty"`
+	// SessionCount is the number of occurrences since this daemon started (not persisted).
+	SessionCount int `json:"session_count"`
+}
+
+// ClusterTrend splits the clusters seen this session into new and known, plus regressions.
+type ClusterTrend struct {
+	SessionStart time.Time              `json:"session_start"`
+	New          []ClusterHistoryRecord `json:"new"`
+	Known        []ClusterHistoryRecord `json:"known"`
+	Regressed    []ClusterHistoryRecord `json:"regressed"`
+	Resolved     int                    `json:"resolved"`
+	Tracked      int                    `json:"tracked"`
+}
+
+// ErrorClusterHistory is a thread-safe, persistable record of every error cluster seen.
+type ErrorClusterHistory struct {
+	mu           sync.Mutex
+	records      map[string]*ClusterHistoryRecord
+	session      map[string]int // occurrences per cluster this session
+	sessionStart time.Time
+}
+
+// NewErrorClusterHistory returns an empty history whose session starts at now.
+func NewErrorClusterHistory(now time.Time) *ErrorClusterHistory {
+	return &ErrorClusterHistory{
+		records:      make(map[string]*ClusterHistoryRecord),
+		session:      make(map[string]int),
+		sessionStart: now,
+	}
+}
+
+// Load replaces the persisted records from JSON produced by JSON.
+func (h *ErrorClusterHistory) Load(data []byte) error {
+	var records []*ClusterHistoryRecord
+	if err := json.Unmarshal(data, &records); err != nil {
+		return err
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.records = make(map[string]*ClusterHistoryRecord, len(records))
+	for _, r := range records {
+		if r == nil || r.ID == "" {
+			continue
+		}
+		r.SessionCount = 0
+		h.records[r.ID] = r
+	}
+	return nil
+}
+
+// JSON serializes the records for persistence.
+func (h *ErrorClusterHistory) JSON() ([]byte, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	records := make([]ClusterHistoryRecord, 0, len(h.records))
+	for _, r := range h.records {
+		records = append(records, *r)
+	}
+	sortClusterRecords(records)
+	return json.Marshal(records)
+}
+
+// Record folds observations into the history. It returns the clusters that regressed,
+// i.e. reappeared after Resolve, so the caller can alert once per regression.
+func (h *ErrorClusterHistory) Record(observations []ClusterObservation, now time.Time) []ClusterHistoryRecord {
+	_, regressed := h.RecordChanges(observations, now)
+	return regressed
+}
+
+// RecordChanges is Record that also returns the clusters seen for the first time ever.
+func (h *ErrorClusterHistory) RecordChanges(observations []ClusterObservation, now time.Time) (created, regressed []ClusterHistoryRecord) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	var createdIDs []string
+	for _, o := range observations {
+		if o.ID == "" {
+			continue
+		}
+		r, ok := h.records[o.ID]
+		if !ok {
+			r = &ClusterHistoryRecord{ID: o.ID, Message: o.Message, FirstSeen: now, Status: ClusterStatusActive}
+			h.records[o.ID] = r
+			createdIDs = append(createdIDs, o.ID)
+		}
+		if r.Status == ClusterStatusResolved {
+			at := now
+			r.Status = ClusterStatusRegressed
+			r.RegressedAt = &at
+			r.Regressions++
+			regressed = append(regressed, *r)
+		}
+		if h.session[o.ID] == 0 {
+			r.Sessions++
+		}
+		h.session[o.ID]++
+		r.TotalCount++
+		r.LastSeen = now
+		r.Message = o.Message
+	}
+	for _, id := range createdIDs {
+		created = append(created, *h.records[id])
+	}
+	h.evictLocked()
+	for i := range regressed {
+		regressed[i].SessionCount = h.session[regressed[i].ID]
+	}
+	for i := range created {
+		created[i].SessionCount = h.session[created[i].ID]
+	}
+	return created, regressed
+}
+
+// Resolve marks every active or regressed cluster resolved, as of a buffer clear.
+// Returns how many clusters changed state.
+func (h *ErrorClusterHistory) Resolve(now time.Time) int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	resolved := 0
+	for _, r := range h.records {
+		if r.Status == ClusterStatusResolved {
+			continue
+		}
+		at := now
+		r.Status = ClusterStatusResolved
+		r.ResolvedAt = &at
+		resolved++
+	}
+	return resolved
+}
+
+// Trend reports this session's clusters: new (first seen this session), known (seen
+// in an earlier session too), and regressed (reappeared after a clear).
+func (h *ErrorClusterHistory) Trend() ClusterTrend {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	trend := ClusterTrend{
+		SessionStart: h.sessionStart,
+		New:          []ClusterHistoryRecord{},
+		Known:        []ClusterHistoryRecord{},
+		Regressed:    []ClusterHistoryRecord{},
+		Tracked:      len(h.records),
+	}
+	for id, r := range h.records {
+		rec := *r
+		rec.SessionCount = h.session[id]
+		switch {
+		case rec.Status == ClusterStatusResolved:
+			trend.Resolved++
+			continue
+		case rec.Status == ClusterStatusRegressed:
+			trend.Regressed = append(trend.Regressed, rec)
+		}
+		if rec.SessionCount == 0 {
+			continue
+		}
+		if rec.FirstSeen.Before(h.sessionStart) {
+			trend.Known = append(trend.Known, rec)
+		} else {
+			trend.New = append(trend.New, rec)
+		}
+	}
+	sortClusterRecords(trend.New)
+	sortClusterRecords(trend.Known)
+	sortClusterRecords(trend.Regressed)
+	return trend
+}
+
+// evictLocked drops the least recently seen clusters beyond maxClusterHistory. Caller holds mu.
+func (h *ErrorClusterHistory) evictLocked() {
+	if len(h.records) <= maxClusterHistory {
+		return
+	}
+	records := make([]*ClusterHistoryRecord, 0, len(h.records))
+	for _, r := range h.records {
+		records = append(records, r)
+	}
+	sort.Slice(records, func(i, j int) bool { return records[i].LastSeen.Before(records[j].LastSeen) })
+	for _, r := range records[:len(records)-maxClusterHistory] {
+		delete(h.records, r.ID)
+		delete(h.session, r.ID)
+	}
+}
+
+// sortClusterRecords orders records most recently seen first, then by ID.
+func sortClusterRecords(records []ClusterHistoryRecord) {
+	sort.Slice(records, func(i, j int) bool {
+		if !records[i].LastSeen.Equal(records[j].LastSeen) {
+			return records[i].LastSeen.After(records[j].LastSeen)
+		}
+		return records[i].ID < records[j].ID
+	})
+}
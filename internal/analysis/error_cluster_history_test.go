@@ -0,0 +1,125 @@
+// Purpose: Tests error cluster history: new vs known split, clear-then-regress, persistence round trip, and eviction.
+// Docs: docs/features/feature/error-clustering/index.md
+
+package analysis
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestErrorClusterHistory_NewVersusKnown(t *testing.T) {
+	t.Parallel()
+	start := time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)
+
+	previous := NewErrorClusterHistory(start.Add(-24 * time.Hour))
+	previous.Record([]ClusterObservation{{ID: "old", Message: "TypeError: x is undefined"}}, start.Add(-time.Hour))
+	data, err := previous.JSON()
+	if err != nil {
+		t.Fatalf("JSON: %v", err)
+	}
+
+	h := NewErrorClusterHistory(start)
+	if err := h.Load(data); err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	h.Record([]ClusterObservation{
+		{ID: "old", Message: "TypeError: x is undefined"},
+		{ID: "fresh", Message: "ReferenceError: y"},
+		{ID: "fresh", Message: "ReferenceError: y"},
+	}, start.Add(time.Minute))
+
+	trend := h.Trend()
+	if len(trend.New) != 1 || trend.New[0].ID != "fresh" || trend.New[0].SessionCount != 2 {
+		t.Fatalf("new = %+v, want fresh with session_count 2", trend.New)
+	}
+	if len(trend.Known) != 1 || trend.Known[0].ID != "old" {
+		t.Fatalf("known = %+v, want old", trend.Known)
+	}
+	known := trend.Known[0]
+	if known.TotalCount != 2 || known.Sessions != 2 || !known.FirstSeen.Equal(start.Add(-time.Hour)) {
+		t.Fatalf("known record = %+v, want total 2 across 2 sessions with original first_seen", known)
+	}
+	if trend.Tracked != 2 || len(trend.Regressed) != 0 {
+		t.Fatalf("tracked/regressed = %d/%d, want 2/0", trend.Tracked, len(trend.Regressed))
+	}
+}
+
+func TestErrorClusterHistory_ResolveThenRegress(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)
+	h := NewErrorClusterHistory(now)
+
+	if got := h.Record([]ClusterObservation{{ID: "a", Message: "boom"}, {ID: "b", Message: "bang"}}, now); len(got) != 0 {
+		t.Fatalf("first sighting reported regressions: %+v", got)
+	}
+	if resolved := h.Resolve(now.Add(time.Minute)); resolved != 2 {
+		t.Fatalf("Resolve = %d, want 2", resolved)
+	}
+	if resolved := h.Resolve(now.Add(2 * time.Minute)); resolved != 0 {
+		t.Fatalf("second Resolve = %d, want 0", resolved)
+	}
+
+	regressed := h.Record([]ClusterObservation{{ID: "a", Message: "boom"}, {ID: "a", Message: "boom"}}, now.Add(3*time.Minute))
+	if len(regressed) != 1 || regressed[0].ID != "a" || regressed[0].Regressions != 1 {
+		t.Fatalf("regressed = %+v, want a once", regressed)
+	}
+	if regressed[0].ResolvedAt == nil || regressed[0].RegressedAt == nil {
+		t.Fatalf("regressed record missing resolved_at/regressed_at: %+v", regressed[0])
+	}
+
+	trend := h.Trend()
+	if len(trend.Regressed) != 1 || trend.Regressed[0].Status != ClusterStatusRegressed {
+		t.Fatalf("trend regressed = %+v", trend.Regressed)
+	}
+	if trend.Resolved != 1 {
+		t.Fatalf("resolved = %d, want 1 (b stays resolved)", trend.Resolved)
+	}
+}
+
+func TestErrorClusterHistory_RecordChangesReportsCreated(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)
+	h := NewErrorClusterHistory(now)
+
+	created, regressed := h.RecordChanges([]ClusterObservation{{ID: "a", Message: "boom"}, {ID: "a", Message: "boom"}}, now)
+	if len(created) != 1 || created[0].ID != "a" || created[0].SessionCount != 2 || len(regressed) != 0 {
+		t.Fatalf("created = %+v, regressed = %+v; want a once with session_count 2", created, regressed)
+	}
+	if created, _ = h.RecordChanges([]ClusterObservation{{ID: "a", Message: "boom"}}, now.Add(time.Minute)); len(created) != 0 {
+		t.Fatalf("known cluster reported as created: %+v", created)
+	}
+}
+
+func TestErrorClusterHistory_LoadRejectsGarbage(t *testing.T) {
+	t.Parallel()
+	h := NewErrorClusterHistory(time.Now())
+	if err := h.Load([]byte("not json")); err == nil {
+		t.Fatal("Load should fail on invalid JSON")
+	}
+	if err := h.Load([]byte(`[{"id":""},null,{"id":"ok","message":"m","status":"resolved"}]`)); err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if trend := h.Trend(); trend.Tracked != 1 || trend.Resolved != 1 {
+		t.Fatalf("trend = %+v, want one resolved record", trend)
+	}
+}
+
+func TestErrorClusterHistory_EvictsLeastRecentlySeen(t *testing.T) {
+	t.Parallel()
+	base := time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)
+	h := NewErrorClusterHistory(base)
+	for i := 0; i < maxClusterHistory+5; i++ {
+		h.Record([]ClusterObservation{{ID: fmt.Sprintf("c%03d", i), Message: "m"}}, base.Add(time.Duration(i)*time.Second))
+	}
+	trend := h.Trend()
+	if trend.Tracked != maxClusterHistory {
+		t.Fatalf("tracked = %d, want %d", trend.Tracked, maxClusterHistory)
+	}
+	for _, r := range trend.New {
+		if r.ID < "c005" {
+			t.Fatalf("oldest cluster %s survived eviction", r.ID)
+		}
+	}
+}
@@ -0,0 +1,566 @@
+// Purpose: Builds a privacy inventory of analytics beacons, tracking pixels, and fingerprinting-adjacent API usage.
+// Why: Privacy reviews need one vendor-grouped view of who receives what data, built from passively captured traffic.
+// Docs: docs/features/feature/privacy-audit/index.md
+
+package analysis
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/util"
+)
+
+// Privacy vendor categories.
+const (
+	PrivacyCategoryAnalytics     = "analytics"
+	PrivacyCategoryAdvertising   = "advertising"
+	PrivacyCategorySessionReplay = "session_replay"
+	PrivacyCategoryTagManager    = "tag_manager"
+	PrivacyCategoryMonitoring    = "monitoring"
+	PrivacyCategoryUnknown       = "unknown"
+)
+
+// pixelMaxBytes is the largest image response still treated as a tracking pixel (a 1x1 GIF is 43 bytes).
+const pixelMaxBytes = 256
+
+// privacySampleURLs caps the example URLs kept per vendor.
+const privacySampleURLs = 3
+
+// PrivacyAuditParams defines input for observe(privacy_audit).
+type PrivacyAuditParams struct {
+	FirstPartyOrigins  []string `json:"first_party_origins"`
+	FirstPartySuffixes []string `json:"first_party_suffixes"`
+	// ConsentCookie names the CMP cookie to track; empty auto-detects common CMPs.
+	ConsentCookie string `json:"consent_cookie"`
+	// ConsentGrantedValue is a substring of ConsentCookie's value that means consent was granted.
+	ConsentGrantedValue string `json:"consent_granted_value"`
+}
+
+// PrivacyAuditReport is the vendor-grouped privacy inventory for a session.
+type PrivacyAuditReport struct {
+	FirstPartyOrigin string                 `json:"first_party_origin"`
+	Vendors          []PrivacyVendor        `json:"vendors"`
+	Fingerprinting   []FingerprintingScript `json:"fingerprinting"`
+	Summary          PrivacyAuditSummary    `json:"summary"`
+	Recommendations  []string               `json:"recommendations"`
+	// Consent is nil when no CMP cookie is configured or detected and no consent control was clicked.
+	Consent *PrivacyConsent `json:"consent,omitempty"`
+}
+
+// PrivacyVendor groups every tracking request sent to one vendor.
+type PrivacyVendor struct {
+	Vendor     string          `json:"vendor"`
+	Category   string          `json:"category"`
+	FirstParty bool            `json:"first_party"`
+	Hosts      []string        `json:"hosts"`
+	Requests   int             `json:"requests"`
+	Beacons    int             `json:"beacons"`
+	Pixels     int             `json:"pixels"`
+	Methods    []string        `json:"methods"`
+	DataSent   PrivacyDataSent `json:"data_sent"`
+	// FingerprintingAPIs lists fingerprinting-adjacent APIs referenced by this vendor's scripts.
+	FingerprintingAPIs []string `json:"fingerprinting_apis,omitempty"`
+	SampleURLs         []string `json:"sample_urls"`
+}
+
+// PrivacyDataSent summarizes what a vendor received: parameter names, not values.
+type PrivacyDataSent struct {
+	QueryKeys []string `json:"query_keys"`
+	BodyKeys  []string `json:"body_keys"`
+	// Identifiers are keys that carry user, device, session, or click IDs.
+	Identifiers []string `json:"identifiers"`
+	// PIIFields are PII kinds (email, phone, ssn) detected in URLs or request bodies.
+	PIIFields    []string `json:"pii_fields"`
+	RequestBytes int      `json:"request_bytes"`
+}
+
+// FingerprintingScript is one captured script that references fingerprinting-adjacent APIs.
+type FingerprintingScript struct {
+	URL        string   `json:"url"`
+	Vendor     string   `json:"vendor"`
+	FirstParty bool     `json:"first_party"`
+	APIs       []string `json:"apis"`
+}
+
+// PrivacyAuditSummary provides aggregate counts.
+type PrivacyAuditSummary struct {
+	Vendors               int            `json:"vendors"`
+	ByCategory            map[string]int `json:"by_category"`
+	Beacons               int            `json:"beacons"`
+	Pixels                int            `json:"pixels"`
+	VendorsReceivingPII   int            `json:"vendors_receiving_pii"`
+	VendorsReceivingIDs   int            `json:"vendors_receiving_identifiers"`
+	FingerprintingScripts int            `json:"fingerprinting_scripts"`
+	// ScriptsScanned is how many captured script bodies were searched for fingerprinting APIs.
+	ScriptsScanned int `json:"scripts_scanned"`
+	// ConsentViolations counts tracking requests and tracker cookie writes made before consent.
+	ConsentViolations int `json:"consent_violations"`
+}
+
+// privacyVendorRule maps a host suffix (and optional path prefix) to a tracking vendor.
+type privacyVendorRule struct {
+	host       string
+	pathPrefix string
+	vendor     string
+	category   string
+}
+
+// privacyVendors is the catalog of common tracking vendors, matched on host suffix.
+var privacyVendors = []privacyVendorRule{
+	{host: "google-analytics.com", vendor: "Google Analytics", category: PrivacyCategoryAnalytics},
+	{host: "analytics.google.com", vendor: "Google Analytics", category: PrivacyCategoryAnalytics},
+	{host: "googletagmanager.com", vendor: "Google Tag Manager", category: PrivacyCategoryTagManager},
+	{host: "doubleclick.net", vendor: "Google Ads", category: PrivacyCategoryAdvertising},
+	{host: "googleadservices.com", vendor: "Google Ads", category: PrivacyCategoryAdvertising},
+	{host: "googlesyndication.com", vendor: "Google Ads", category: PrivacyCategoryAdvertising},
+	{host: "connect.facebook.net", vendor: "Meta Pixel", category: PrivacyCategoryAdvertising},
+	{host: "facebook.com", pathPrefix: "/tr", vendor: "Meta Pixel", category: PrivacyCategoryAdvertising},
+	{host: "segment.io", vendor: "Segment", category: PrivacyCategoryAnalytics},
+	{host: "segment.com", vendor: "Segment", category: PrivacyCategoryAnalytics},
+	{host: "mixpanel.com", vendor: "Mixpanel", category: PrivacyCategoryAnalytics},
+	{host: "amplitude.com", vendor: "Amplitude", category: PrivacyCategoryAnalytics},
+	{host: "heapanalytics.com", vendor: "Heap", category: PrivacyCategoryAnalytics},
+	{host: "posthog.com", vendor: "PostHog", category: PrivacyCategoryAnalytics},
+	{host: "plausible.io", vendor: "Plausible", category: PrivacyCategoryAnalytics},
+	{host: "scorecardresearch.com", vendor: "Comscore", category: PrivacyCategoryAnalytics},
+	{host: "hotjar.com", vendor: "Hotjar", category: PrivacyCategorySessionReplay},
+	{host: "hotjar.io", vendor: "Hotjar", category: PrivacyCategorySessionReplay},
+	{host: "clarity.ms", vendor: "Microsoft Clarity", category: PrivacyCategorySessionReplay},
+	{host: "fullstory.com", vendor: "FullStory", category: PrivacyCategorySessionReplay},
+	{host: "logrocket.io", vendor: "LogRocket", category: PrivacyCategorySessionReplay},
+	{host: "lr-ingest.io", vendor: "LogRocket", category: PrivacyCategorySessionReplay},
+	{host: "bat.bing.com", vendor: "Microsoft Advertising", category: PrivacyCategoryAdvertising},
+	{host: "px.ads.linkedin.com", vendor: "LinkedIn Insight", category: PrivacyCategoryAdvertising},
+	{host: "snap.licdn.com", vendor: "LinkedIn Insight", category: PrivacyCategoryAdvertising},
+	{host: "analytics.tiktok.com", vendor: "TikTok Pixel", category: PrivacyCategoryAdvertising},
+	{host: "ads-twitter.com", vendor: "X Ads", category: PrivacyCategoryAdvertising},
+	{host: "analytics.twitter.com", vendor: "X Ads", category: PrivacyCategoryAdvertising},
+	{host: "criteo.com", vendor: "Criteo", category: PrivacyCategoryAdvertising},
+	{host: "criteo.net", vendor: "Criteo", category: PrivacyCategoryAdvertising},
+	{host: "adnxs.com", vendor: "Xandr", category: PrivacyCategoryAdvertising},
+	{host: "quantserve.com", vendor: "Quantcast", category: PrivacyCategoryAdvertising},
+	{host: "taboola.com", vendor: "Taboola", category: PrivacyCategoryAdvertising},
+	{host: "outbrain.com", vendor: "Outbrain", category: PrivacyCategoryAdvertising},
+	{host: "sentry.io", vendor: "Sentry", category: PrivacyCategoryMonitoring},
+	{host: "browser-intake-datadoghq.com", vendor: "Datadog RUM", category: PrivacyCategoryMonitoring},
+	{host: "browser-intake-datadoghq.eu", vendor: "Datadog RUM", category: PrivacyCategoryMonitoring},
+	{host: "nr-data.net", vendor: "New Relic", category: PrivacyCategoryMonitoring},
+}
+
+// identifierKeys are parameter names that carry user, device, session, or click identifiers.
+var identifierKeys = map[string]bool{
+	"cid": true, "uid": true, "user_id": true, "userid": true, "anonymousid": true, "anonymous_id": true,
+	"distinct_id": true, "device_id": true, "deviceid": true, "sid": true, "session_id": true, "sessionid": true,
+	"client_id": true, "clientid": true, "_ga": true, "_gid": true, "_fbp": true, "_fbc": true,
+	"gclid": true, "fbclid": true, "msclkid": true, "ttclid": true, "li_fat_id": true, "ud": true,
+}
+
+// fingerprintingAPIs maps a reported API name to the source tokens that reference it.
+// Matches are references in captured script source, not proof of a runtime call.
+var fingerprintingAPIs = []struct {
+	api    string
+	tokens []string
+}{
+	{"canvas_read", []string{"toDataURL(", "getImageData("}},
+	{"webgl_renderer", []string{"WEBGL_debug_renderer_info", "UNMASKED_RENDERER_WEBGL"}},
+	{"audio_fingerprint", []string{"OfflineAudioContext"}},
+	{"battery", []string{"getBattery("}},
+	{"device_memory", []string{"deviceMemory"}},
+	{"hardware_concurrency", []string{"hardwareConcurrency"}},
+	{"media_devices", []string{"enumerateDevices("}},
+	{"high_entropy_client_hints", []string{"getHighEntropyValues("}},
+	{"font_probe", []string{"document.fonts.check("}},
+}
+
+// privacyVendorAcc accumulates one vendor's requests before sorting into PrivacyVendor.
+type privacyVendorAcc struct {
+	vendor                           PrivacyVendor
+	hosts, methods, query, body, ids map[string]bool
+	pii, apis                        map[string]bool
+}
+
+// privacyRequest is one observed request, merged from network bodies and resource timing.
+type privacyRequest struct {
+	url           string
+	method        string
+	contentType   string
+	initiatorType string
+	requestBody   string
+	size          int
+	sizeKnown     bool
+	at            time.Time
+	atApprox      bool // server-side resource-timing receipt time, not the request time
+}
+
+// BuildPrivacyAudit inventories tracking vendors, beacons, pixels, and fingerprinting-adjacent API
+// references in the captured session.
+//
+// Classification:
+// - Requests to catalogued vendors are always included.
+// - navigator.sendBeacon requests (initiator "beacon") are included for any host.
+// - Small image responses with a query string to third-party hosts are tracking pixels.
+//
+// actions feed consent-state reconstruction (clicks on CMP accept/reject controls).
+func BuildPrivacyAudit(bodies []capture.NetworkBody, waterfall []capture.NetworkWaterfallEntry, actions []capture.EnhancedAction, pageURLs []string, params PrivacyAuditParams) PrivacyAuditReport {
+	seeds := pageURLs
+	if len(params.FirstPartyOrigins) > 0 {
+		seeds = params.FirstPartyOrigins
+	}
+	firstParty := util.NewFirstPartyRules(seeds, params.FirstPartySuffixes)
+
+	vendors := map[string]*privacyVendorAcc{}
+	var tracked []consentRequest
+	var beacons, pixels int
+	for _, r := range collectPrivacyRequests(bodies, waterfall) {
+		parsed, err := url.Parse(r.url)
+		if err != nil || parsed.Hostname() == "" || !strings.HasPrefix(parsed.Scheme, "http") {
+			continue
+		}
+		host := strings.ToLower(parsed.Hostname())
+		isFirstParty := firstParty.IsFirstPartyHost(host)
+		name, category, known := matchPrivacyVendor(host, parsed.Path)
+		isBeacon := r.initiatorType == "beacon"
+		isPixel := !isFirstParty && parsed.RawQuery != "" && isPixelRequest(r)
+		if !known && !isBeacon && !isPixel {
+			continue
+		}
+		if !known {
+			name, category = util.RegistrableDomain(host), PrivacyCategoryUnknown
+		}
+
+		acc := vendors[name]
+		if acc == nil {
+			acc = newPrivacyVendorAcc(name, category, isFirstParty)
+			vendors[name] = acc
+		}
+		acc.add(r, parsed, host, isBeacon, isPixel)
+		if !isFirstParty {
+			tracked = append(tracked, consentRequest{req: r, vendor: name, category: category})
+		}
+		if isBeacon {
+			beacons++
+		}
+		if isPixel {
+			pixels++
+		}
+	}
+
+	fingerprinting, scanned := scanFingerprinting(bodies, firstParty)
+	for _, fp := range fingerprinting {
+		if acc := vendors[fp.Vendor]; acc != nil {
+			for _, api := range fp.APIs {
+				acc.apis[api] = true
+			}
+		}
+	}
+
+	report := PrivacyAuditReport{
+		Vendors:        make([]PrivacyVendor, 0, len(vendors)),
+		Fingerprinting: fingerprinting,
+	}
+	if len(seeds) > 0 {
+		report.FirstPartyOrigin = util.ExtractOrigin(seeds[0])
+	}
+	for _, acc := range vendors {
+		report.Vendors = append(report.Vendors, acc.finish())
+	}
+	sort.Slice(report.Vendors, func(i, j int) bool {
+		if report.Vendors[i].Requests != report.Vendors[j].Requests {
+			return report.Vendors[i].Requests > report.Vendors[j].Requests
+		}
+		return report.Vendors[i].Vendor < report.Vendors[j].Vendor
+	})
+	report.Consent = buildPrivacyConsent(bodies, actions, tracked, firstParty, params)
+	report.Summary = buildPrivacySummary(report, beacons, pixels, scanned)
+	report.Recommendations = buildPrivacyRecommendations(report)
+	return report
+}
+
+// collectPrivacyRequests merges network bodies with resource-timing entries by URL. Bodies carry
+// method and payload; timing carries initiator type and transfer size.
+func collectPrivacyRequests(bodies []capture.NetworkBody, waterfall []capture.NetworkWaterfallEntry) []privacyRequest {
+	byURL := map[string]int{}
+	var out []privacyRequest
+	for _, b := range bodies {
+		at, _ := parseConsentTime(b.Timestamp)
+		out = append(out, privacyRequest{
+			url: b.URL, method: strings.ToUpper(b.Method), contentType: b.ContentType,
+			requestBody: b.RequestBody, size: len(b.ResponseBody), sizeKnown: !b.ResponseTruncated && b.BinaryFormat == "",
+			at: at,
+		})
+		byURL[b.URL] = len(out) - 1
+	}
+	for _, w := range waterfall {
+		if i, ok := byURL[w.URL]; ok {
+			out[i].initiatorType = w.InitiatorType
+			if w.DecodedBodySize > 0 || w.TransferSize > 0 {
+				out[i].size, out[i].sizeKnown = w.DecodedBodySize, true
+			}
+			if out[i].at.IsZero() {
+				out[i].at, out[i].atApprox = w.Timestamp, true
+			}
+			continue
+		}
+		method := "GET"
+		if w.InitiatorType == "beacon" {
+			method = "POST"
+		}
+		out = append(out, privacyRequest{
+			url: w.URL, method: method, initiatorType: w.InitiatorType,
+			size: w.DecodedBodySize, sizeKnown: w.DecodedBodySize > 0 || w.TransferSize > 0,
+			at: w.Timestamp, atApprox: true,
+		})
+		byURL[w.URL] = len(out) - 1
+	}
+	return out
+}
+
+// isPixelRequest reports whether a request looks like a tracking pixel: a tiny (or empty) image.
+func isPixelRequest(r privacyRequest) bool {
+	isImage := r.initiatorType == "img" || r.initiatorType == "image" || strings.HasPrefix(strings.ToLower(r.contentType), "image/")
+	if !isImage {
+		return false
+	}
+	return !r.sizeKnown || r.size <= pixelMaxBytes
+}
+
+// matchPrivacyVendor returns the catalogued vendor for host/path.
+func matchPrivacyVendor(host, path string) (string, string, bool) {
+	for _, rule := range privacyVendors {
+		if host != rule.host && !strings.HasSuffix(host, "."+rule.host) {
+			continue
+		}
+		if rule.pathPrefix != "" && !strings.HasPrefix(path, rule.pathPrefix) {
+			continue
+		}
+		return rule.vendor, rule.category, true
+	}
+	return "", "", false
+}
+
+func newPrivacyVendorAcc(name, category string, firstParty bool) *privacyVendorAcc {
+	return &privacyVendorAcc{
+		vendor: PrivacyVendor{Vendor: name, Category: category, FirstParty: firstParty},
+		hosts:  map[string]bool{}, methods: map[string]bool{}, query: map[string]bool{},
+		body: map[string]bool{}, ids: map[string]bool{}, pii: map[string]bool{}, apis: map[string]bool{},
+	}
+}
+
+// add records one request's counts and the parameter names it sent.
+func (a *privacyVendorAcc) add(r privacyRequest, parsed *url.URL, host string, isBeacon, isPixel bool) {
+	a.vendor.Requests++
+	if isBeacon {
+		a.vendor.Beacons++
+	}
+	if isPixel {
+		a.vendor.Pixels++
+	}
+	a.hosts[host] = true
+	if r.method != "" {
+		a.methods[r.method] = true
+	}
+	if len(a.vendor.SampleURLs) < privacySampleURLs {
+		a.vendor.SampleURLs = append(a.vendor.SampleURLs, r.url)
+	}
+
+	for key := range parsed.Query() {
+		a.query[key] = true
+		a.noteIdentifier(key)
+	}
+	for _, key := range requestBodyKeys(r.requestBody, r.contentType) {
+		a.body[key] = true
+		a.noteIdentifier(key)
+	}
+	a.vendor.DataSent.RequestBytes += len(r.requestBody)
+	if unescaped, err := url.QueryUnescape(parsed.RawQuery); err == nil {
+		for _, field := range detectPIIFields(unescaped) {
+			a.pii[field] = true
+		}
+	}
+	for _, field := range detectPIIFields(r.requestBody) {
+		a.pii[field] = true
+	}
+}
+
+func (a *privacyVendorAcc) noteIdentifier(key string) {
+	leaf := key
+	if i := strings.LastIndexByte(leaf, '.'); i >= 0 {
+		leaf = leaf[i+1:]
+	}
+	if identifierKeys[strings.ToLower(leaf)] {
+		a.ids[key] = true
+	}
+}
+
+func (a *privacyVendorAcc) finish() PrivacyVendor {
+	v := a.vendor
+	v.Hosts = sortedSetKeys(a.hosts)
+	v.Methods = sortedSetKeys(a.methods)
+	v.DataSent.QueryKeys = sortedSetKeys(a.query)
+	v.DataSent.BodyKeys = sortedSetKeys(a.body)
+	v.DataSent.Identifiers = sortedSetKeys(a.ids)
+	v.DataSent.PIIFields = sortedSetKeys(a.pii)
+	if len(a.apis) > 0 {
+		v.FingerprintingAPIs = sortedSetKeys(a.apis)
+	}
+	return v
+}
+
+// requestBodyKeys extracts parameter names from a JSON or form-encoded request body.
+// JSON objects contribute top-level keys and one level of nested keys as "parent.child";
+// a JSON array of objects (batched events) contributes the keys of its elements.
+func requestBodyKeys(body, contentType string) []string {
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return nil
+	}
+	if strings.HasPrefix(body, "{") || strings.HasPrefix(body, "[") {
+		var decoded any
+		if err := json.Unmarshal([]byte(body), &decoded); err != nil {
+			return nil
+		}
+		keys := map[string]bool{}
+		collectJSONKeys(decoded, "", 0, keys)
+		return sortedSetKeys(keys)
+	}
+	if strings.Contains(strings.ToLower(contentType), "json") {
+		return nil
+	}
+	values, err := url.ParseQuery(body)
+	if err != nil {
+		return nil
+	}
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func collectJSONKeys(v any, prefix string, depth int, keys map[string]bool) {
+	switch t := v.(type) {
+	case map[string]any:
+		for key, child := range t {
+			name := key
+			if prefix != "" {
+				name = prefix + "." + key
+			}
+			keys[name] = true
+			if depth == 0 {
+				collectJSONKeys(child, name, depth+1, keys)
+			}
+		}
+	case []any:
+		for _, elem := range t {
+			collectJSONKeys(elem, prefix, depth, keys)
+		}
+	}
+}
+
+// scanFingerprinting searches captured script bodies for fingerprinting-adjacent API references.
+func scanFingerprinting(bodies []capture.NetworkBody, firstParty util.FirstPartyRules) ([]FingerprintingScript, int) {
+	out := []FingerprintingScript{}
+	scanned := 0
+	seen := map[string]bool{}
+	for _, b := range bodies {
+		if b.ResponseBody == "" || seen[b.URL] || !strings.Contains(strings.ToLower(b.ContentType), "javascript") {
+			continue
+		}
+		seen[b.URL] = true
+		scanned++
+		var apis []string
+		for _, fp := range fingerprintingAPIs {
+			for _, token := range fp.tokens {
+				if strings.Contains(b.ResponseBody, token) {
+					apis = append(apis, fp.api)
+					break
+				}
+			}
+		}
+		if len(apis) == 0 {
+			continue
+		}
+		parsed, err := url.Parse(b.URL)
+		if err != nil {
+			continue
+		}
+		host := strings.ToLower(parsed.Hostname())
+		vendor, _, known := matchPrivacyVendor(host, parsed.Path)
+		if !known {
+			vendor = util.RegistrableDomain(host)
+		}
+		out = append(out, FingerprintingScript{URL: b.URL, Vendor: vendor, FirstParty: firstParty.IsFirstPartyHost(host), APIs: apis})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if len(out[i].APIs) != len(out[j].APIs) {
+			return len(out[i].APIs) > len(out[j].APIs)
+		}
+		return out[i].URL < out[j].URL
+	})
+	return out, scanned
+}
+
+func buildPrivacySummary(report PrivacyAuditReport, beacons, pixels, scanned int) PrivacyAuditSummary {
+	s := PrivacyAuditSummary{
+		Vendors: len(report.Vendors), ByCategory: map[string]int{}, Beacons: beacons, Pixels: pixels,
+		FingerprintingScripts: len(report.Fingerprinting), ScriptsScanned: scanned,
+	}
+	if report.Consent != nil {
+		s.ConsentViolations = report.Consent.ViolationCount
+	}
+	for _, v := range report.Vendors {
+		s.ByCategory[v.Category]++
+		if len(v.DataSent.PIIFields) > 0 {
+			s.VendorsReceivingPII++
+		}
+		if len(v.DataSent.Identifiers) > 0 {
+			s.VendorsReceivingIDs++
+		}
+	}
+	return s
+}
+
+func buildPrivacyRecommendations(report PrivacyAuditReport) []string {
+	recs := []string{}
+	for _, v := range report.Vendors {
+		if len(v.DataSent.PIIFields) > 0 && !v.FirstParty {
+			recs = append(recs, v.Vendor+" receives "+strings.Join(v.DataSent.PIIFields, ", ")+" — hash or drop PII before it leaves the page, and confirm the vendor is covered by your DPA.")
+		}
+	}
+	if n := report.Summary.ByCategory[PrivacyCategorySessionReplay]; n > 0 {
+		recs = append(recs, "Session replay is active — verify input masking covers passwords, payment fields, and free-text PII.")
+	}
+	if c := report.Consent; c != nil && c.ViolationCount > 0 {
+		recs = append(recs, fmt.Sprintf("%d tracking request(s) or tracker cookie write(s) happened before consent was granted — gate these tags on the %s consent signal (GDPR/ePrivacy, CPRA).", c.ViolationCount, c.CMP))
+	}
+	if n := report.Summary.ByCategory[PrivacyCategoryAdvertising]; n > 0 && report.Consent == nil {
+		recs = append(recs, "Advertising pixels fire in this session — confirm they are gated on consent where required (GDPR/ePrivacy, CPRA).")
+	}
+	thirdPartyFP := 0
+	for _, fp := range report.Fingerprinting {
+		if !fp.FirstParty {
+			thirdPartyFP++
+		}
+	}
+	if thirdPartyFP > 0 {
+		recs = append(recs, "Third-party scripts reference fingerprinting-adjacent APIs — review whether they build device fingerprints and disclose it in the privacy notice.")
+	}
+	return recs
+}
+
+// sortedSetKeys returns the keys of a string set in sorted order (never nil, for stable JSON).
+func sortedSetKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,87 @@
+// Purpose: Tests the privacy inventory: vendor grouping, beacons, pixels, data-sent keys, and fingerprinting scans.
+// Docs: docs/features/feature/privacy-audit/index.md
+
+package analysis
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+)
+
+func TestBuildPrivacyAudit_GroupsVendorsAndDataSent(t *testing.T) {
+	t.Parallel()
+	bodies := []capture.NetworkBody{
+		{Method: "POST", URL: "https://www.google-analytics.com/g/collect?v=2&tid=G-123&cid=555.777&en=page_view", ContentType: "text/plain"},
+		{Method: "POST", URL: "https://api.segment.io/v1/t", ContentType: "application/json",
+			RequestBody: `{"event":"Checkout","userId":"u_42","properties":{"email":"jo@example.com","total":42}}`},
+		{Method: "GET", URL: "https://cdn.myapp.com/app.js", ContentType: "application/javascript",
+			ResponseBody: `function fp(){var c=document.createElement("canvas");return c.toDataURL()+navigator.deviceMemory}`},
+		{Method: "GET", URL: "https://fp.tracker-x.io/collect.js", ContentType: "text/javascript",
+			ResponseBody: `navigator.getBattery().then(b=>b.level);gl.getExtension("WEBGL_debug_renderer_info")`},
+	}
+	waterfall := []capture.NetworkWaterfallEntry{
+		{URL: "https://www.google-analytics.com/g/collect?v=2&tid=G-123&cid=555.777&en=page_view", InitiatorType: "beacon"},
+		{URL: "https://px.adnet.example/p.gif?uid=abc&ref=home", InitiatorType: "img", TransferSize: 343, DecodedBodySize: 43},
+		{URL: "https://images.myapp.com/hero.png?w=800", InitiatorType: "img", DecodedBodySize: 40},
+		{URL: "https://cdn.example.org/photo.jpg?w=800", InitiatorType: "img", DecodedBodySize: 90000},
+		{URL: "https://www.myapp.com/api/metrics", InitiatorType: "beacon"},
+	}
+
+	report := BuildPrivacyAudit(bodies, waterfall, nil, []string{"https://www.myapp.com/checkout"}, PrivacyAuditParams{})
+
+	vendors := map[string]PrivacyVendor{}
+	for _, v := range report.Vendors {
+		vendors[v.Vendor] = v
+	}
+	if len(vendors) != 4 {
+		t.Fatalf("vendors = %+v, want Google Analytics, Segment, example (pixel), myapp.com (first-party beacon)", report.Vendors)
+	}
+	ga := vendors["Google Analytics"]
+	if ga.Category != PrivacyCategoryAnalytics || ga.Beacons != 1 || ga.Requests != 1 || !slices.Contains(ga.DataSent.Identifiers, "cid") || !slices.Contains(ga.DataSent.QueryKeys, "tid") {
+		t.Fatalf("google analytics = %+v", ga)
+	}
+	seg := vendors["Segment"]
+	if !slices.Contains(seg.DataSent.BodyKeys, "properties.email") || !slices.Contains(seg.DataSent.Identifiers, "userId") || !slices.Equal(seg.DataSent.PIIFields, []string{"email"}) {
+		t.Fatalf("segment data sent = %+v", seg.DataSent)
+	}
+	if px := vendors["adnet.example"]; px.Pixels != 1 || px.Category != PrivacyCategoryUnknown || !slices.Contains(px.DataSent.Identifiers, "uid") {
+		t.Fatalf("pixel vendor = %+v", px)
+	}
+	if own := vendors["myapp.com"]; !own.FirstParty || own.Beacons != 1 {
+		t.Fatalf("first-party beacon = %+v", own)
+	}
+
+	if len(report.Fingerprinting) != 2 || report.Summary.ScriptsScanned != 2 {
+		t.Fatalf("fingerprinting = %+v, scanned = %d", report.Fingerprinting, report.Summary.ScriptsScanned)
+	}
+	for _, fp := range report.Fingerprinting {
+		switch fp.URL {
+		case "https://cdn.myapp.com/app.js":
+			if !fp.FirstParty || !slices.Equal(fp.APIs, []string{"canvas_read", "device_memory"}) {
+				t.Errorf("first-party fingerprinting = %+v", fp)
+			}
+		case "https://fp.tracker-x.io/collect.js":
+			if fp.FirstParty || fp.Vendor != "tracker-x.io" || !slices.Equal(fp.APIs, []string{"webgl_renderer", "battery"}) {
+				t.Errorf("third-party fingerprinting = %+v", fp)
+			}
+		}
+	}
+	if report.Summary.Beacons != 2 || report.Summary.Pixels != 1 || report.Summary.VendorsReceivingPII != 1 || len(report.Recommendations) == 0 {
+		t.Fatalf("summary = %+v, recommendations = %v", report.Summary, report.Recommendations)
+	}
+}
+
+func TestRequestBodyKeys(t *testing.T) {
+	t.Parallel()
+	if got := requestBodyKeys(`[{"event":"a","context":{"ip":"1.2.3.4"}},{"event":"b"}]`, "application/json"); !slices.Equal(got, []string{"context", "context.ip", "event"}) {
+		t.Errorf("batched JSON keys = %v", got)
+	}
+	if got := requestBodyKeys("ev=PageView&id=123&ud%5Bem%5D=hash", "application/x-www-form-urlencoded"); !slices.Equal(got, []string{"ev", "id", "ud[em]"}) {
+		t.Errorf("form keys = %v", got)
+	}
+	if got := requestBodyKeys("not json", "application/json"); got != nil {
+		t.Errorf("malformed JSON keys = %v, want nil", got)
+	}
+}
@@ -0,0 +1,481 @@
+// Purpose: Reconstructs the page's consent state over time and flags tracking requests and tracker cookie writes made before consent.
+// Why: "Did anything fire before the user accepted?" is the concrete GDPR/CCPA question privacy reviews need answered with evidence.
+// Docs: docs/features/feature/privacy-audit/index.md
+
+package analysis
+
+import (
+	"encoding/base64"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/util"
+)
+
+// Consent states. not_required means the CMP reported consent is not needed (e.g. out-of-region).
+const (
+	ConsentStateGranted     = "granted"
+	ConsentStateDenied      = "denied"
+	ConsentStatePending     = "pending"
+	ConsentStateNotRequired = "not_required"
+	ConsentStateUnknown     = "unknown"
+)
+
+// Consent violation kinds.
+const (
+	ConsentViolationRequest      = "request"
+	ConsentViolationStorageWrite = "storage_write"
+)
+
+// maxConsentViolations caps the violations listed; the summary still counts all of them.
+const maxConsentViolations = 50
+
+// PrivacyConsent is the consent-state timeline and everything that ran before consent.
+type PrivacyConsent struct {
+	CMP    string `json:"cmp"`
+	Cookie string `json:"cookie,omitempty"`
+	// State is the last observed consent state.
+	State string `json:"state"`
+	// GrantedAt is the first observation of granted consent; empty if never granted.
+	GrantedAt      string              `json:"granted_at,omitempty"`
+	Timeline       []ConsentTransition `json:"timeline"`
+	Violations     []ConsentViolation  `json:"violations"`
+	ViolationCount int                 `json:"violation_count"`
+	Note           string              `json:"note,omitempty"`
+}
+
+// ConsentTransition is one observed change of consent state.
+type ConsentTransition struct {
+	At     string `json:"at"`
+	State  string `json:"state"`
+	Source string `json:"source"` // request_cookie, set_cookie, user_action
+	// Evidence is the URL whose headers carried the cookie, or the clicked control.
+	Evidence string `json:"evidence"`
+}
+
+// ConsentViolation is a tracking request or tracker cookie write made while consent was not granted.
+type ConsentViolation struct {
+	Kind         string `json:"kind"`
+	At           string `json:"at"`
+	ConsentState string `json:"consent_state"`
+	Vendor       string `json:"vendor"`
+	Category     string `json:"category,omitempty"`
+	URL          string `json:"url"`
+	Cookie       string `json:"cookie,omitempty"`
+	Evidence     string `json:"evidence"`
+	// TimingApproximate marks timestamps taken from server-side resource-timing receipt.
+	TimingApproximate bool `json:"timing_approximate,omitempty"`
+}
+
+// consentCMP is a known consent-management platform cookie and its value parser.
+type consentCMP struct {
+	name   string
+	cookie string
+	state  func(value string) string
+}
+
+var consentCMPs = []consentCMP{
+	{name: "OneTrust", cookie: "OptanonConsent", state: oneTrustConsentState},
+	{name: "Cookiebot", cookie: "CookieConsent", state: cookiebotConsentState},
+	{name: "IAB TCF v2", cookie: "euconsent-v2", state: tcfConsentState},
+	{name: "IAB CCPA", cookie: "usprivacy", state: usPrivacyConsentState},
+	{name: "CookieYes", cookie: "cookieyes-consent", state: cookieYesConsentState},
+	{name: "Complianz", cookie: "cmplz_marketing", state: allowDenyConsentState},
+	{name: "Complianz", cookie: "cmplz_statistics", state: allowDenyConsentState},
+}
+
+// consentButtons maps CMP control IDs to the decision a click records.
+var consentButtons = map[string]string{
+	"onetrust-accept-btn-handler":                           ConsentStateGranted,
+	"accept-recommended-btn-handler":                        ConsentStateGranted,
+	"onetrust-reject-all-handler":                           ConsentStateDenied,
+	"CybotCookiebotDialogBodyLevelButtonLevelOptinAllowAll": ConsentStateGranted,
+	"CybotCookiebotDialogBodyButtonAccept":                  ConsentStateGranted,
+	"CybotCookiebotDialogBodyButtonDecline":                 ConsentStateDenied,
+	"didomi-notice-agree-button":                            ConsentStateGranted,
+	"didomi-notice-disagree-button":                         ConsentStateDenied,
+	"cky-btn-accept":                                        ConsentStateGranted,
+	"cky-btn-reject":                                        ConsentStateDenied,
+}
+
+// consentButtonTexts are visible labels of consent controls, matched case-insensitively.
+var consentButtonTexts = []struct {
+	text  string
+	state string
+}{
+	{"accept all", ConsentStateGranted}, {"accept cookies", ConsentStateGranted}, {"allow all", ConsentStateGranted},
+	{"allow cookies", ConsentStateGranted}, {"agree and close", ConsentStateGranted}, {"i accept", ConsentStateGranted},
+	{"reject all", ConsentStateDenied}, {"decline all", ConsentStateDenied}, {"only necessary", ConsentStateDenied},
+	{"necessary only", ConsentStateDenied}, {"reject cookies", ConsentStateDenied},
+}
+
+// trackerCookiePrefixes are cookie names written by analytics and advertising tags.
+var trackerCookiePrefixes = []string{
+	"_ga", "_gid", "_gat", "_gcl_", "_fbp", "_fbc", "_hjSession", "_hjid", "_hjFirstSeen", "_clck", "_clsk",
+	"ajs_anonymous_id", "ajs_user_id", "mp_", "amplitude_id", "AMP_", "_uetsid", "_uetvid", "li_fat_id",
+	"_ttp", "__hstc", "hubspotutk", "_pin_unauth", "_rdt_uuid",
+}
+
+// consentRequest is a tracking request the consent check evaluates.
+type consentRequest struct {
+	req      privacyRequest
+	vendor   string
+	category string
+}
+
+// consentNeedsCategories are vendor categories that require prior consent under GDPR/ePrivacy.
+// Monitoring and tag managers are commonly loaded pre-consent and are left to reviewer judgement.
+var consentNeedsCategories = map[string]bool{
+	PrivacyCategoryAnalytics: true, PrivacyCategoryAdvertising: true,
+	PrivacyCategorySessionReplay: true, PrivacyCategoryUnknown: true,
+}
+
+// consentObservation is one point-in-time reading of the consent state.
+type consentObservation struct {
+	at       time.Time
+	state    string
+	source   string
+	evidence string
+}
+
+// consentCookieWrite is the first sighting of a tracker cookie.
+type consentCookieWrite struct {
+	at       time.Time
+	cookie   string
+	url      string
+	evidence string
+}
+
+// buildPrivacyConsent reconstructs consent state from captured Cookie/Set-Cookie headers and clicks on
+// consent controls, then flags tracking requests and tracker cookie writes made while consent was not
+// granted. Returns nil when no CMP is configured or detected and no consent control was clicked.
+//
+// The state before the first observation is taken from that observation when it is a cookie
+// reading (consent persisted from an earlier visit); otherwise it is pending.
+func buildPrivacyConsent(bodies []capture.NetworkBody, actions []capture.EnhancedAction, tracked []consentRequest, firstParty util.FirstPartyRules, params PrivacyAuditParams) *PrivacyConsent {
+	cmp := resolveConsentCMP(bodies, params)
+	var obs []consentObservation
+	var writes []consentCookieWrite
+	seenTracker := map[string]bool{}
+	for _, b := range bodies {
+		at, ok := parseConsentTime(b.Timestamp)
+		if !ok {
+			continue
+		}
+		for name, value := range b.RequestHeaders {
+			if !strings.EqualFold(name, "cookie") {
+				continue
+			}
+			cookies := parseCookieHeader(value)
+			if cmp.cookie != "" {
+				if v, found := cookies[cmp.cookie]; found {
+					obs = append(obs, consentObservation{at, cmp.state(v), "request_cookie", b.URL})
+				} else if firstParty.IsFirstPartyURL(b.URL) {
+					obs = append(obs, consentObservation{at, ConsentStatePending, "request_cookie", b.URL})
+				}
+			}
+			for cookie := range cookies {
+				if isTrackerCookie(cookie) && !seenTracker[cookie] {
+					seenTracker[cookie] = true
+					writes = append(writes, consentCookieWrite{at, cookie, b.URL, "sent in Cookie header"})
+				}
+			}
+		}
+		for name, value := range b.ResponseHeaders {
+			if !strings.EqualFold(name, "set-cookie") {
+				continue
+			}
+			for _, line := range strings.Split(value, "\n") {
+				cookie, v, ok := strings.Cut(strings.TrimSpace(strings.SplitN(line, ";", 2)[0]), "=")
+				if !ok {
+					continue
+				}
+				if cmp.cookie != "" && cookie == cmp.cookie {
+					obs = append(obs, consentObservation{at, cmp.state(v), "set_cookie", b.URL})
+				}
+				if isTrackerCookie(cookie) && !seenTracker[cookie] {
+					seenTracker[cookie] = true
+					writes = append(writes, consentCookieWrite{at, cookie, b.URL, "set by Set-Cookie response header"})
+				}
+			}
+		}
+	}
+	for _, a := range actions {
+		if state, control := consentClickDecision(a); state != "" {
+			obs = append(obs, consentObservation{time.UnixMilli(a.Timestamp), state, "user_action", control})
+		}
+	}
+	if cmp.cookie == "" && len(obs) == 0 {
+		return nil
+	}
+
+	consent := &PrivacyConsent{CMP: cmp.name, Cookie: cmp.cookie, Timeline: []ConsentTransition{}, Violations: []ConsentViolation{}}
+	if len(obs) == 0 {
+		consent.State = ConsentStateUnknown
+		consent.Note = "No consent signal captured: the consent cookie never appeared in captured Cookie/Set-Cookie headers and no consent control was clicked. Reload the page with the banner visible, make a choice, then re-run."
+		return consent
+	}
+	sort.SliceStable(obs, func(i, j int) bool { return obs[i].at.Before(obs[j].at) })
+	for _, o := range obs {
+		if n := len(consent.Timeline); n > 0 && consent.Timeline[n-1].State == o.state {
+			continue
+		}
+		consent.Timeline = append(consent.Timeline, ConsentTransition{At: formatConsentTime(o.at), State: o.state, Source: o.source, Evidence: o.evidence})
+		if o.state == ConsentStateGranted && consent.GrantedAt == "" {
+			consent.GrantedAt = formatConsentTime(o.at)
+		}
+	}
+	consent.State = consent.Timeline[len(consent.Timeline)-1].State
+	initial := ConsentStatePending
+	if obs[0].source != "user_action" {
+		initial = obs[0].state
+	}
+	stateAt := func(t time.Time) string {
+		state := initial
+		for _, o := range obs {
+			if o.at.After(t) {
+				break
+			}
+			state = o.state
+		}
+		return state
+	}
+	allowed := func(state string) bool { return state == ConsentStateGranted || state == ConsentStateNotRequired }
+
+	for _, t := range tracked {
+		if t.req.at.IsZero() || !consentNeedsCategories[t.category] {
+			continue
+		}
+		if state := stateAt(t.req.at); !allowed(state) {
+			consent.addViolation(ConsentViolation{
+				Kind: ConsentViolationRequest, At: formatConsentTime(t.req.at), ConsentState: state,
+				Vendor: t.vendor, Category: t.category, URL: t.req.url, Evidence: t.req.method + " " + t.req.url,
+				TimingApproximate: t.req.atApprox,
+			})
+		}
+	}
+	for _, w := range writes {
+		if state := stateAt(w.at); !allowed(state) {
+			vendor := "unknown"
+			if parsed, err := url.Parse(w.url); err == nil {
+				vendor = util.RegistrableDomain(parsed.Hostname())
+			}
+			consent.addViolation(ConsentViolation{
+				Kind: ConsentViolationStorageWrite, At: formatConsentTime(w.at), ConsentState: state,
+				Vendor: vendor, URL: w.url, Cookie: w.cookie, Evidence: "tracker cookie " + w.cookie + " " + w.evidence,
+			})
+		}
+	}
+	sort.SliceStable(consent.Violations, func(i, j int) bool { return consent.Violations[i].At < consent.Violations[j].At })
+	if len(consent.Violations) > maxConsentViolations {
+		consent.Violations = consent.Violations[:maxConsentViolations]
+	}
+	return consent
+}
+
+func (c *PrivacyConsent) addViolation(v ConsentViolation) {
+	c.ViolationCount++
+	c.Violations = append(c.Violations, v)
+}
+
+// resolveConsentCMP picks the configured consent cookie, or the first known CMP cookie seen in traffic.
+func resolveConsentCMP(bodies []capture.NetworkBody, params PrivacyAuditParams) consentCMP {
+	if params.ConsentCookie != "" {
+		cmp := consentCMP{name: "custom", cookie: params.ConsentCookie, state: customConsentState(params.ConsentGrantedValue)}
+		for _, known := range consentCMPs {
+			if known.cookie == params.ConsentCookie && params.ConsentGrantedValue == "" {
+				cmp = known
+			}
+		}
+		return cmp
+	}
+	for _, b := range bodies {
+		for name, value := range b.RequestHeaders {
+			if strings.EqualFold(name, "cookie") {
+				cookies := parseCookieHeader(value)
+				for _, known := range consentCMPs {
+					if _, ok := cookies[known.cookie]; ok {
+						return known
+					}
+				}
+			}
+		}
+		for name, value := range b.ResponseHeaders {
+			if strings.EqualFold(name, "set-cookie") {
+				for _, known := range consentCMPs {
+					if strings.Contains(value, known.cookie+"=") {
+						return known
+					}
+				}
+			}
+		}
+	}
+	return consentCMP{name: "unknown"}
+}
+
+// consentClickDecision reports the decision recorded by a click on a consent control.
+func consentClickDecision(a capture.EnhancedAction) (state, control string) {
+	if a.Type != "click" {
+		return "", ""
+	}
+	for _, raw := range a.Selectors {
+		s, ok := raw.(string)
+		if !ok {
+			continue
+		}
+		for id, decision := range consentButtons {
+			if strings.Contains(s, id) {
+				return decision, s
+			}
+		}
+		lower := strings.ToLower(s)
+		for _, b := range consentButtonTexts {
+			if strings.Contains(lower, b.text) {
+				return b.state, s
+			}
+		}
+	}
+	return "", ""
+}
+
+// parseCookieHeader splits a Cookie request header into name/value pairs.
+func parseCookieHeader(header string) map[string]string {
+	out := map[string]string{}
+	for _, pair := range strings.Split(header, ";") {
+		if name, value, ok := strings.Cut(strings.TrimSpace(pair), "="); ok && name != "" {
+			out[name] = value
+		}
+	}
+	return out
+}
+
+func isTrackerCookie(name string) bool {
+	for _, prefix := range trackerCookiePrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func parseConsentTime(ts string) (time.Time, bool) {
+	if ts == "" {
+		return time.Time{}, false
+	}
+	t, err := time.Parse(time.RFC3339Nano, ts)
+	return t, err == nil
+}
+
+func formatConsentTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+// oneTrustConsentState reads OptanonConsent groups: any non-essential group (not C0001) at 1 is granted.
+func oneTrustConsentState(value string) string {
+	values, err := url.ParseQuery(value)
+	if err != nil || values.Get("groups") == "" {
+		return ConsentStatePending
+	}
+	for _, group := range strings.Split(values.Get("groups"), ",") {
+		id, flag, _ := strings.Cut(group, ":")
+		if id != "C0001" && flag == "1" {
+			return ConsentStateGranted
+		}
+	}
+	return ConsentStateDenied
+}
+
+// cookiebotConsentState reads CookieConsent: statistics or marketing true is granted; -1 means not required.
+func cookiebotConsentState(value string) string {
+	if unescaped, err := url.QueryUnescape(value); err == nil {
+		value = unescaped
+	}
+	switch {
+	case value == "-1":
+		return ConsentStateNotRequired
+	case strings.Contains(value, "statistics:true") || strings.Contains(value, "marketing:true"):
+		return ConsentStateGranted
+	case strings.Contains(value, "necessary:true"):
+		return ConsentStateDenied
+	}
+	return ConsentStatePending
+}
+
+// tcfPurposesOffset is the bit offset of PurposesConsent in a TCF v2 core string.
+const tcfPurposesOffset = 152
+
+// tcfConsentState decodes a TCF v2 TC string: purpose 1 (store/access information on a device) is required.
+func tcfConsentState(value string) string {
+	core := strings.TrimRight(strings.SplitN(value, ".", 2)[0], "=")
+	data, err := base64.RawURLEncoding.DecodeString(core)
+	if err != nil || len(data)*8 <= tcfPurposesOffset || data[0]>>2 != 2 {
+		return ConsentStatePending
+	}
+	if data[tcfPurposesOffset/8]&(0x80>>(tcfPurposesOffset%8)) != 0 {
+		return ConsentStateGranted
+	}
+	return ConsentStateDenied
+}
+
+// usPrivacyConsentState reads a US Privacy string ("1YNN"): the third character is the sale opt-out.
+func usPrivacyConsentState(value string) string {
+	if len(value) != 4 {
+		return ConsentStatePending
+	}
+	switch value[2] {
+	case 'Y', 'y':
+		return ConsentStateDenied
+	case 'N', 'n':
+		return ConsentStateGranted
+	}
+	return ConsentStateNotRequired
+}
+
+// cookieYesConsentState reads cookieyes-consent: analytics or advertisement "yes" is granted.
+func cookieYesConsentState(value string) string {
+	if unescaped, err := url.QueryUnescape(value); err == nil {
+		value = unescaped
+	}
+	switch {
+	case strings.Contains(value, "analytics:yes") || strings.Contains(value, "advertisement:yes"):
+		return ConsentStateGranted
+	case strings.Contains(value, "action:yes"):
+		return ConsentStateDenied
+	}
+	return ConsentStatePending
+}
+
+func allowDenyConsentState(value string) string {
+	switch strings.ToLower(value) {
+	case "allow":
+		return ConsentStateGranted
+	case "deny":
+		return ConsentStateDenied
+	}
+	return ConsentStatePending
+}
+
+// customConsentState builds a parser for a configured cookie. With grantedValue set, the value must
+// contain it; otherwise any value other than an explicit refusal counts as granted.
+func customConsentState(grantedValue string) func(string) string {
+	return func(value string) string {
+		if unescaped, err := url.QueryUnescape(value); err == nil {
+			value = unescaped
+		}
+		lower := strings.ToLower(strings.TrimSpace(value))
+		if grantedValue != "" {
+			if strings.Contains(lower, strings.ToLower(grantedValue)) {
+				return ConsentStateGranted
+			}
+			return ConsentStateDenied
+		}
+		switch lower {
+		case "", "0", "false", "no", "deny", "denied", "reject", "rejected", "declined":
+			return ConsentStateDenied
+		}
+		return ConsentStateGranted
+	}
+}
@@ -0,0 +1,104 @@
+// Purpose: Tests consent-state reconstruction and pre-consent violation detection for privacy_audit.
+// Docs: docs/features/feature/privacy-audit/index.md
+
+package analysis
+
+import (
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+)
+
+func TestPrivacyConsent_FlagsTrackingBeforeAccept(t *testing.T) {
+	t.Parallel()
+	t0 := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) string { return t0.Add(d).Format(time.RFC3339Nano) }
+	bodies := []capture.NetworkBody{
+		{Timestamp: at(500 * time.Millisecond), Method: "GET", URL: "https://www.myapp.com/api/me",
+			RequestHeaders: map[string]string{"Cookie": "session=abc; _ga=GA1.2.3.4"}},
+		{Timestamp: at(time.Second), Method: "POST", URL: "https://www.google-analytics.com/g/collect?cid=1"},
+		{Timestamp: at(3 * time.Second), Method: "GET", URL: "https://www.myapp.com/api/cart",
+			RequestHeaders: map[string]string{"Cookie": "session=abc; OptanonConsent=isGpcEnabled=0&groups=C0001%3A1%2CC0002%3A1"}},
+		{Timestamp: at(4 * time.Second), Method: "POST", URL: "https://www.google-analytics.com/g/collect?cid=2"},
+	}
+	actions := []capture.EnhancedAction{
+		{Type: "click", Timestamp: t0.Add(2 * time.Second).UnixMilli(), Selectors: map[string]any{"id": "onetrust-accept-btn-handler"}},
+	}
+
+	report := BuildPrivacyAudit(bodies, nil, actions, []string{"https://www.myapp.com/"}, PrivacyAuditParams{})
+	c := report.Consent
+	if c == nil || c.CMP != "OneTrust" || c.State != ConsentStateGranted || c.GrantedAt != at(2*time.Second) {
+		t.Fatalf("consent = %+v", c)
+	}
+	if len(c.Timeline) != 2 || c.Timeline[0].State != ConsentStatePending || c.Timeline[1].Source != "user_action" {
+		t.Fatalf("timeline = %+v", c.Timeline)
+	}
+	if c.ViolationCount != 2 || report.Summary.ConsentViolations != 2 {
+		t.Fatalf("violations = %+v", c.Violations)
+	}
+	write, req := c.Violations[0], c.Violations[1]
+	if write.Kind != ConsentViolationStorageWrite || write.Cookie != "_ga" || write.ConsentState != ConsentStatePending {
+		t.Errorf("storage write violation = %+v", write)
+	}
+	if req.Kind != ConsentViolationRequest || req.Vendor != "Google Analytics" || req.URL != bodies[1].URL {
+		t.Errorf("request violation = %+v", req)
+	}
+}
+
+func TestPrivacyConsent_PersistedConsentAndUnobservedCookie(t *testing.T) {
+	t.Parallel()
+	t0 := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	bodies := []capture.NetworkBody{
+		{Timestamp: t0.Format(time.RFC3339Nano), Method: "POST", URL: "https://api.segment.io/v1/t"},
+		{Timestamp: t0.Add(time.Second).Format(time.RFC3339Nano), Method: "GET", URL: "https://www.myapp.com/",
+			RequestHeaders: map[string]string{"cookie": "consent=analytics%2Cads"}},
+	}
+	report := BuildPrivacyAudit(bodies, nil, nil, []string{"https://www.myapp.com/"}, PrivacyAuditParams{ConsentCookie: "consent", ConsentGrantedValue: "analytics"})
+	if c := report.Consent; c == nil || c.CMP != "custom" || c.State != ConsentStateGranted || c.ViolationCount != 0 {
+		t.Fatalf("persisted consent = %+v, want granted with no violations", c)
+	}
+
+	report = BuildPrivacyAudit(bodies[:1], nil, nil, nil, PrivacyAuditParams{ConsentCookie: "consent"})
+	if c := report.Consent; c == nil || c.State != ConsentStateUnknown || c.Note == "" || c.ViolationCount != 0 {
+		t.Fatalf("unobserved consent = %+v, want unknown with a note", c)
+	}
+	if report := BuildPrivacyAudit(bodies[:1], nil, nil, nil, PrivacyAuditParams{}); report.Consent != nil {
+		t.Fatalf("consent = %+v, want nil without a CMP", report.Consent)
+	}
+}
+
+func TestConsentCookieParsers(t *testing.T) {
+	t.Parallel()
+	tc := make([]byte, 20)
+	tc[0] = 2 << 2 // version 2
+	tc[tcfPurposesOffset/8] = 0x80 >> (tcfPurposesOffset % 8)
+	granted := base64.RawURLEncoding.EncodeToString(tc)
+	tc[tcfPurposesOffset/8] = 0
+	denied := base64.RawURLEncoding.EncodeToString(tc)
+
+	cases := []struct {
+		name  string
+		got   string
+		state string
+	}{
+		{"tcf purpose 1", tcfConsentState(granted + ".YAAAAAAAAAA"), ConsentStateGranted},
+		{"tcf no purpose 1", tcfConsentState(denied), ConsentStateDenied},
+		{"tcf garbage", tcfConsentState("!!"), ConsentStatePending},
+		{"usprivacy opt-out", usPrivacyConsentState("1YYN"), ConsentStateDenied},
+		{"usprivacy no opt-out", usPrivacyConsentState("1YNN"), ConsentStateGranted},
+		{"usprivacy n/a", usPrivacyConsentState("1---"), ConsentStateNotRequired},
+		{"cookiebot marketing", cookiebotConsentState("{stamp:%27x%27%2Cnecessary:true%2Cstatistics:false%2Cmarketing:true}"), ConsentStateGranted},
+		{"cookiebot necessary only", cookiebotConsentState("{necessary:true,statistics:false,marketing:false}"), ConsentStateDenied},
+		{"cookiebot out of region", cookiebotConsentState("-1"), ConsentStateNotRequired},
+		{"onetrust necessary only", oneTrustConsentState("groups=C0001%3A1%2CC0002%3A0"), ConsentStateDenied},
+		{"cookieyes rejected", cookieYesConsentState("consent:no,action:yes,analytics:no"), ConsentStateDenied},
+		{"custom refusal", customConsentState("")("false"), ConsentStateDenied},
+	}
+	for _, c := range cases {
+		if c.got != c.state {
+			t.Errorf("%s = %s, want %s", c.name, c.got, c.state)
+		}
+	}
+}
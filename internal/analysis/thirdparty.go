@@ -17,10 +17,12 @@ type ThirdPartyAuditor struct{}
 
 // ThirdPartyParams defines input for the audit tool.
 type ThirdPartyParams struct {
-	FirstPartyOrigins []string     `json:"first_party_origins"`
-	IncludeStatic     *bool        `json:"include_static"`
-	CustomLists       *CustomLists `json:"custom_lists"`
-	CustomListsFile   string       `json:"custom_lists_file"`
+	FirstPartyOrigins []string `json:"first_party_origins"`
+	// FirstPartySuffixes are extra domains treated as first-party along with all their subdomains.
+	FirstPartySuffixes []string     `json:"first_party_suffixes"`
+	IncludeStatic      *bool        `json:"include_static"`
+	CustomLists        *CustomLists `json:"custom_lists"`
+	CustomListsFile    string       `json:"custom_lists_file"`
 }
 
 // CustomLists defines enterprise custom domain lists.
@@ -104,9 +106,9 @@ type originData struct {
 // Audit analyzes network bodies to identify and classify third-party origins.
 func (a *ThirdPartyAuditor) Audit(bodies []capture.NetworkBody, pageURLs []string, params ThirdPartyParams) ThirdPartyResult {
 	customLists := resolveCustomLists(params)
-	firstPartyOrigins := buildFirstPartySet(params, pageURLs, customLists)
+	firstParty := buildFirstPartyRules(params, pageURLs, customLists)
 
-	originMap := groupByThirdPartyOrigin(bodies, firstPartyOrigins)
+	originMap := groupByThirdPartyOrigin(bodies, firstParty)
 	entries := buildAllEntries(originMap, customLists)
 	entries = filterAndSort(entries, params.IncludeStatic)
 
@@ -6,7 +6,6 @@ package analysis
 
 import (
 	"sort"
-	"strings"
 
 	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
 	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/util"
@@ -23,46 +22,27 @@ func resolveCustomLists(params ThirdPartyParams) *CustomLists {
 	return nil
 }
 
-// buildFirstPartySet determines first-party origins from params and page URLs.
-func buildFirstPartySet(params ThirdPartyParams, pageURLs []string, customLists *CustomLists) map[string]bool {
-	firstParty := make(map[string]bool)
+// buildFirstPartyRules determines first-party hosts from params and page URLs.
+// Explicit origins replace the page URLs as seeds; configured suffixes and custom
+// internal domains match their domain and every subdomain.
+func buildFirstPartyRules(params ThirdPartyParams, pageURLs []string, customLists *CustomLists) util.FirstPartyRules {
+	seeds := pageURLs
 	if len(params.FirstPartyOrigins) > 0 {
-		for _, origin := range params.FirstPartyOrigins {
-			firstParty[origin] = true
-		}
-	} else {
-		for _, pageURL := range pageURLs {
-			if origin := util.ExtractOrigin(pageURL); origin != "" {
-				firstParty[origin] = true
-			}
-		}
-	}
-	addInternalDomains(firstParty, customLists)
-	return firstParty
-}
-
-// addInternalDomains treats custom internal domains as first-party.
-func addInternalDomains(firstParty map[string]bool, customLists *CustomLists) {
-	if customLists == nil {
-		return
+		seeds = params.FirstPartyOrigins
 	}
-	for _, internal := range customLists.Internal {
-		origin := internal
-		if !strings.Contains(origin, "://") {
-			origin = "https://" + origin
-		}
-		if parsed := util.ExtractOrigin(origin); parsed != "" {
-			firstParty[parsed] = true
-		}
+	suffixes := params.FirstPartySuffixes
+	if customLists != nil {
+		suffixes = append(append([]string(nil), suffixes...), customLists.Internal...)
 	}
+	return util.NewFirstPartyRules(seeds, suffixes)
 }
 
 // groupByThirdPartyOrigin groups bodies by third-party origin (excluding first-party).
-func groupByThirdPartyOrigin(bodies []capture.NetworkBody, firstParty map[string]bool) map[string]*originData {
+func groupByThirdPartyOrigin(bodies []capture.NetworkBody, firstParty util.FirstPartyRules) map[string]*originData {
 	originMap := make(map[string]*originData)
 	for _, body := range bodies {
 		origin := util.ExtractOrigin(body.URL)
-		if origin == "" || firstParty[origin] {
+		if origin == "" || firstParty.IsFirstPartyURL(body.URL) {
 			continue
 		}
 		if _, ok := originMap[origin]; !ok {
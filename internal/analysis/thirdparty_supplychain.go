@@ -0,0 +1,364 @@
+// Purpose: Builds a supply-chain report of third-party scripts: pinning, host class, size, load position, and drift.
+// Why: Unpinned or silently changed third-party scripts are the main browser supply-chain attack surface.
+// Docs: docs/features/feature/enterprise-audit/index.md
+
+package analysis
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/util"
+)
+
+// SupplyChainParams defines input for observe(third_party_audit) and generate(third_party_report).
+type SupplyChainParams struct {
+	FirstPartyOrigins  []string     `json:"first_party_origins"`
+	FirstPartySuffixes []string     `json:"first_party_suffixes"`
+	CustomLists        *CustomLists `json:"custom_lists"`
+	CustomListsFile    string       `json:"custom_lists_file"`
+	// UpdateBaseline stores the current scripts as the snapshot later audits compare against.
+	UpdateBaseline bool `json:"update_baseline"`
+}
+
+// SupplyChainReport is the supply-chain view of third-party scripts.
+type SupplyChainReport struct {
+	FirstPartyOrigin string               `json:"first_party_origin"`
+	Scripts          []SupplyChainScript  `json:"scripts"`
+	Changes          []SupplyChainChange  `json:"changes"`
+	Baseline         *SupplyChainBaseline `json:"baseline,omitempty"`
+	Summary          SupplyChainSummary   `json:"summary"`
+	Recommendations  []string             `json:"recommendations"`
+}
+
+// SupplyChainScript describes one third-party script URL.
+type SupplyChainScript struct {
+	URL            string `json:"url"`
+	Origin         string `json:"origin"`
+	Classification string `json:"classification"` // known_cdn, unknown, suspicious, enterprise_allowed, enterprise_blocked
+	Pinned         bool   `json:"pinned"`
+	// PinKind is semver, content_hash, query_version, floating, or none.
+	PinKind   string `json:"pin_kind"`
+	Version   string `json:"version,omitempty"`
+	SizeBytes int    `json:"size_bytes"`
+	// LoadPosition is blocking, async, defer, module, dynamic (injected at runtime), or unknown (no HTML captured).
+	LoadPosition string   `json:"load_position"`
+	HasSRI       bool     `json:"has_sri"`
+	ContentHash  string   `json:"content_hash,omitempty"`
+	RiskLevel    string   `json:"risk_level"`
+	RiskReasons  []string `json:"risk_reasons,omitempty"`
+}
+
+// SupplyChainChange is one difference from the stored baseline.
+type SupplyChainChange struct {
+	Kind   string `json:"kind"` // added, removed, content_changed, version_changed
+	URL    string `json:"url"`
+	Before string `json:"before,omitempty"`
+	After  string `json:"after,omitempty"`
+}
+
+// SupplyChainBaseline describes the snapshot the report was compared against.
+type SupplyChainBaseline struct {
+	Path      string `json:"path,omitempty"`
+	CreatedAt string `json:"created_at,omitempty"`
+	Scripts   int    `json:"scripts"`
+	Updated   bool   `json:"updated"`
+}
+
+// SupplyChainSummary provides aggregate counts.
+type SupplyChainSummary struct {
+	TotalScripts   int `json:"total_scripts"`
+	Origins        int `json:"origins"`
+	Unpinned       int `json:"unpinned"`
+	UnknownHosts   int `json:"unknown_hosts"`
+	Blocking       int `json:"blocking"`
+	WithoutSRI     int `json:"without_sri"`
+	TotalBytes     int `json:"total_bytes"`
+	HighRisk       int `json:"high_risk"`
+	Changes        int `json:"changes"`
+	ContentChanged int `json:"content_changed"`
+}
+
+var (
+	semverAtPattern      = regexp.MustCompile(`@v?(\d+\.\d+\.\d+[0-9A-Za-z.+-]*)`)
+	floatingAtPattern    = regexp.MustCompile(`@(latest|next|canary|v?\d+(\.\d+)?)(/|$)`)
+	semverPathPattern    = regexp.MustCompile(`/v?(\d+\.\d+\.\d+[0-9A-Za-z.+-]*)/`)
+	contentHashPattern   = regexp.MustCompile(`[.-]([a-f0-9]{8,})\.m?js$`)
+	scriptTagPattern     = regexp.MustCompile(`(?is)<script\b([^>]*)>`)
+	srcAttrPattern       = regexp.MustCompile(`(?i)\bsrc\s*=\s*(?:"([^"]*)"|'([^']*)'|([^\s>]+))`)
+	typeModulePattern    = regexp.MustCompile(`(?i)\btype\s*=\s*["']?module\b`)
+	asyncAttrPattern     = regexp.MustCompile(`(?i)(^|\s)async(\s|=|$)`)
+	deferAttrPattern     = regexp.MustCompile(`(?i)(^|\s)defer(\s|=|$)`)
+	integrityAttrPattern = regexp.MustCompile(`(?i)\bintegrity\s*=`)
+	queryVersionKeys     = []string{"v", "ver", "version"}
+)
+
+// scriptTag is how a captured HTML document loads a script.
+type scriptTag struct {
+	position string
+	hasSRI   bool
+}
+
+// BuildSupplyChainReport classifies every third-party script seen in network bodies or resource
+// timing and diffs it against baseline (nil when no snapshot exists yet).
+func BuildSupplyChainReport(bodies []capture.NetworkBody, waterfall []capture.NetworkWaterfallEntry, pageURLs []string, params SupplyChainParams, baseline *SupplyChainSnapshot) SupplyChainReport {
+	customLists := resolveCustomLists(ThirdPartyParams{CustomLists: params.CustomLists, CustomListsFile: params.CustomListsFile})
+	firstParty := buildFirstPartyRules(ThirdPartyParams{FirstPartyOrigins: params.FirstPartyOrigins, FirstPartySuffixes: params.FirstPartySuffixes}, pageURLs, customLists)
+
+	tags, sawHTML := collectScriptTags(bodies, firstParty)
+	scripts := map[string]*SupplyChainScript{}
+	thirdPartyScript := func(rawURL string) *SupplyChainScript {
+		origin := util.ExtractOrigin(rawURL)
+		if origin == "" || firstParty.IsFirstPartyURL(rawURL) || !strings.HasPrefix(origin, "http") {
+			return nil
+		}
+		if s, ok := scripts[rawURL]; ok {
+			return s
+		}
+		s := &SupplyChainScript{URL: rawURL, Origin: origin}
+		scripts[rawURL] = s
+		return s
+	}
+
+	for _, body := range bodies {
+		if contentTypeToResourceType(body.ContentType) != "script" {
+			continue
+		}
+		s := thirdPartyScript(body.URL)
+		if s == nil {
+			continue
+		}
+		if body.ResponseBody != "" && !body.ResponseTruncated {
+			sum := sha256.Sum256([]byte(body.ResponseBody))
+			s.ContentHash = "sha256-" + hex.EncodeToString(sum[:])
+		}
+		if len(body.ResponseBody) > s.SizeBytes {
+			s.SizeBytes = len(body.ResponseBody)
+		}
+	}
+	for _, entry := range waterfall {
+		if !strings.EqualFold(entry.InitiatorType, "script") {
+			continue
+		}
+		s := thirdPartyScript(entry.URL)
+		if s == nil {
+			continue
+		}
+		if size := max(entry.DecodedBodySize, entry.TransferSize); size > s.SizeBytes {
+			s.SizeBytes = size
+		}
+	}
+
+	report := SupplyChainReport{
+		Scripts: make([]SupplyChainScript, 0, len(scripts)),
+		Changes: []SupplyChainChange{},
+	}
+	if len(pageURLs) > 0 {
+		report.FirstPartyOrigin = util.ExtractOrigin(pageURLs[0])
+	}
+	for _, s := range scripts {
+		s.Classification = classifyReputation(extractHostname(s.Origin), customLists).Classification
+		s.PinKind, s.Version = detectPinning(s.URL)
+		s.Pinned = s.PinKind == "semver" || s.PinKind == "content_hash" || s.PinKind == "query_version"
+		switch tag, ok := tags[s.URL]; {
+		case ok:
+			s.LoadPosition = tag.position
+			s.HasSRI = tag.hasSRI
+		case sawHTML:
+			s.LoadPosition = "dynamic"
+		default:
+			s.LoadPosition = "unknown"
+		}
+		report.Scripts = append(report.Scripts, *s)
+	}
+
+	if baseline != nil {
+		report.Changes = append(report.Changes, diffSupplyChain(baseline, report.Scripts)...)
+		report.Baseline = &SupplyChainBaseline{CreatedAt: baseline.CreatedAt, Scripts: len(baseline.Scripts)}
+	}
+	changedContent := map[string]bool{}
+	for _, c := range report.Changes {
+		if c.Kind == "content_changed" {
+			changedContent[c.URL] = true
+		}
+	}
+	for i := range report.Scripts {
+		assessSupplyChainRisk(&report.Scripts[i], changedContent[report.Scripts[i].URL])
+	}
+	sort.Slice(report.Scripts, func(i, j int) bool {
+		a, b := report.Scripts[i], report.Scripts[j]
+		if riskOrder(a.RiskLevel) != riskOrder(b.RiskLevel) {
+			return riskOrder(a.RiskLevel) < riskOrder(b.RiskLevel)
+		}
+		return a.URL < b.URL
+	})
+
+	report.Summary = buildSupplyChainSummary(report.Scripts, report.Changes)
+	report.Recommendations = buildSupplyChainRecommendations(report.Summary)
+	return report
+}
+
+// detectPinning reports how a script URL pins its content and the version it names.
+func detectPinning(rawURL string) (kind, version string) {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return "none", ""
+	}
+	path := parsed.Path
+	if m := semverAtPattern.FindStringSubmatch(path); m != nil {
+		return "semver", m[1]
+	}
+	if m := floatingAtPattern.FindStringSubmatch(path); m != nil {
+		return "floating", m[1]
+	}
+	if m := semverPathPattern.FindStringSubmatch(path); m != nil {
+		return "semver", m[1]
+	}
+	if m := contentHashPattern.FindStringSubmatch(path); m != nil {
+		return "content_hash", m[1]
+	}
+	query := parsed.Query()
+	for _, key := range queryVersionKeys {
+		if v := query.Get(key); v != "" {
+			return "query_version", v
+		}
+	}
+	return "none", ""
+}
+
+// collectScriptTags maps absolute script URLs to how first-party HTML documents load them.
+func collectScriptTags(bodies []capture.NetworkBody, firstParty util.FirstPartyRules) (map[string]scriptTag, bool) {
+	tags := map[string]scriptTag{}
+	sawHTML := false
+	for _, body := range bodies {
+		if !strings.Contains(strings.ToLower(body.ContentType), "text/html") || body.ResponseBody == "" {
+			continue
+		}
+		if !firstParty.Empty() && !firstParty.IsFirstPartyURL(body.URL) {
+			continue
+		}
+		sawHTML = true
+		base, err := url.Parse(body.URL)
+		if err != nil {
+			continue
+		}
+		for _, m := range scriptTagPattern.FindAllStringSubmatch(body.ResponseBody, -1) {
+			attrs := m[1]
+			src := srcAttrPattern.FindStringSubmatch(attrs)
+			if src == nil {
+				continue
+			}
+			ref, err := url.Parse(strings.TrimSpace(src[1] + src[2] + src[3]))
+			if err != nil {
+				continue
+			}
+			tag := scriptTag{position: "blocking", hasSRI: integrityAttrPattern.MatchString(attrs)}
+			switch {
+			case typeModulePattern.MatchString(attrs):
+				tag.position = "module"
+				if asyncAttrPattern.MatchString(attrs) {
+					tag.position = "async"
+				}
+			case asyncAttrPattern.MatchString(attrs):
+				tag.position = "async"
+			case deferAttrPattern.MatchString(attrs):
+				tag.position = "defer"
+			}
+			tags[base.ResolveReference(ref).String()] = tag
+		}
+	}
+	return tags, sawHTML
+}
+
+// assessSupplyChainRisk sets the risk level and reasons for one script.
+func assessSupplyChainRisk(s *SupplyChainScript, contentChanged bool) {
+	var high, medium, low []string
+	switch s.Classification {
+	case "enterprise_blocked":
+		high = append(high, "host is on the enterprise blocked list")
+	case "suspicious":
+		high = append(high, "host matches suspicious-domain heuristics")
+	}
+	if contentChanged {
+		high = append(high, "content changed since baseline without a URL change")
+	}
+	if !s.Pinned {
+		if s.Classification == "unknown" {
+			high = append(high, "unpinned script from an unrecognized host")
+		} else {
+			medium = append(medium, "version is not pinned; the host can ship new code at any time")
+		}
+	}
+	if s.LoadPosition == "blocking" {
+		medium = append(medium, "render-blocking third-party script")
+	}
+	if !s.HasSRI && s.LoadPosition != "dynamic" {
+		low = append(low, "no Subresource Integrity attribute")
+	}
+
+	s.RiskReasons = append(append(high, medium...), low...)
+	switch {
+	case len(high) > 0:
+		s.RiskLevel = "high"
+	case len(medium) > 0:
+		s.RiskLevel = "medium"
+	default:
+		s.RiskLevel = "low"
+	}
+}
+
+func buildSupplyChainSummary(scripts []SupplyChainScript, changes []SupplyChainChange) SupplyChainSummary {
+	summary := SupplyChainSummary{TotalScripts: len(scripts), Changes: len(changes)}
+	origins := map[string]bool{}
+	for _, s := range scripts {
+		origins[s.Origin] = true
+		summary.TotalBytes += s.SizeBytes
+		if !s.Pinned {
+			summary.Unpinned++
+		}
+		if s.Classification == "unknown" || s.Classification == "suspicious" {
+			summary.UnknownHosts++
+		}
+		if s.LoadPosition == "blocking" {
+			summary.Blocking++
+		}
+		if !s.HasSRI {
+			summary.WithoutSRI++
+		}
+		if s.RiskLevel == "high" {
+			summary.HighRisk++
+		}
+	}
+	for _, c := range changes {
+		if c.Kind == "content_changed" {
+			summary.ContentChanged++
+		}
+	}
+	summary.Origins = len(origins)
+	return summary
+}
+
+func buildSupplyChainRecommendations(s SupplyChainSummary) []string {
+	var recs []string
+	if s.ContentChanged > 0 {
+		recs = append(recs, "Review scripts whose content changed under the same URL; pin them to an exact version or self-host them.")
+	}
+	if s.Unpinned > 0 {
+		recs = append(recs, "Pin third-party scripts to exact versions (e.g. pkg@1.2.3) so upstream releases cannot change shipped code.")
+	}
+	if s.WithoutSRI > 0 {
+		recs = append(recs, "Add integrity attributes to statically loaded third-party scripts; use generate(what=\"sri\") for hashes.")
+	}
+	if s.Blocking > 0 {
+		recs = append(recs, "Load render-blocking third-party scripts with async or defer.")
+	}
+	if s.UnknownHosts > 0 {
+		recs = append(recs, "Confirm ownership of scripts served from unrecognized hosts, or add them to custom_lists.allowed.")
+	}
+	return recs
+}
@@ -0,0 +1,188 @@
+// Purpose: Persists third-party script snapshots and diffs the current scripts against them.
+// Why: Change detection needs a baseline that survives daemon restarts, keyed by the first-party origin.
+// Docs: docs/features/feature/enterprise-audit/index.md
+
+package analysis
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/state"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/util"
+)
+
+// SupplyChainSnapshot is the stored baseline of third-party scripts for one first-party origin.
+type SupplyChainSnapshot struct {
+	FirstPartyOrigin string                        `json:"first_party_origin"`
+	CreatedAt        string                        `json:"created_at"`
+	Scripts          map[string]SnapshotScriptInfo `json:"scripts"`
+}
+
+// SnapshotScriptInfo is what the baseline remembers about one script URL.
+type SnapshotScriptInfo struct {
+	Version     string `json:"version,omitempty"`
+	ContentHash string `json:"content_hash,omitempty"`
+	SizeBytes   int    `json:"size_bytes"`
+}
+
+var unsafeBaselineChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// SupplyChainBaselinePath returns the snapshot file for a first-party origin.
+func SupplyChainBaselinePath(origin string) (string, error) {
+	name := strings.Trim(unsafeBaselineChars.ReplaceAllString(origin, "_"), "_")
+	if name == "" {
+		name = "default"
+	}
+	return state.InRoot("third_party", name+".json")
+}
+
+// LoadSupplyChainBaseline reads a snapshot. A missing file returns (nil, nil).
+func LoadSupplyChainBaseline(path string) (*SupplyChainSnapshot, error) {
+	data, err := os.ReadFile(path) // #nosec G304 -- path is derived from the state dir
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var snap SupplyChainSnapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return nil, fmt.Errorf("parse baseline %s: %w", path, err)
+	}
+	return &snap, nil
+}
+
+// SaveSupplyChainBaseline writes a snapshot, creating the parent directory.
+func SaveSupplyChainBaseline(path string, snap SupplyChainSnapshot) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(snap, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o600)
+}
+
+// SnapshotFromReport captures the report's scripts as a new baseline.
+func SnapshotFromReport(report SupplyChainReport, now time.Time) SupplyChainSnapshot {
+	snap := SupplyChainSnapshot{
+		FirstPartyOrigin: report.FirstPartyOrigin,
+		CreatedAt:        now.UTC().Format(time.RFC3339),
+		Scripts:          make(map[string]SnapshotScriptInfo, len(report.Scripts)),
+	}
+	for _, s := range report.Scripts {
+		snap.Scripts[s.URL] = SnapshotScriptInfo{Version: s.Version, ContentHash: s.ContentHash, SizeBytes: s.SizeBytes}
+	}
+	return snap
+}
+
+// diffSupplyChain compares current scripts with the baseline. A URL that differs only in its
+// version is reported as version_changed rather than an added/removed pair.
+func diffSupplyChain(baseline *SupplyChainSnapshot, scripts []SupplyChainScript) []SupplyChainChange {
+	var changes []SupplyChainChange
+	current := make(map[string]bool, len(scripts))
+	for _, s := range scripts {
+		current[s.URL] = true
+	}
+
+	// Baseline scripts missing from the current set, indexed by their versionless URL.
+	gone := map[string]string{}
+	for u, info := range baseline.Scripts {
+		if !current[u] {
+			gone[versionlessKey(u, info.Version)] = u
+		}
+	}
+
+	for _, s := range scripts {
+		before, ok := baseline.Scripts[s.URL]
+		if ok {
+			if before.ContentHash != "" && s.ContentHash != "" && before.ContentHash != s.ContentHash {
+				changes = append(changes, SupplyChainChange{Kind: "content_changed", URL: s.URL, Before: before.ContentHash, After: s.ContentHash})
+			}
+			continue
+		}
+		key := versionlessKey(s.URL, s.Version)
+		if prev, ok := gone[key]; ok && s.Version != "" {
+			delete(gone, key)
+			changes = append(changes, SupplyChainChange{Kind: "version_changed", URL: s.URL, Before: baseline.Scripts[prev].Version, After: s.Version})
+			continue
+		}
+		changes = append(changes, SupplyChainChange{Kind: "added", URL: s.URL})
+	}
+	for _, u := range gone {
+		changes = append(changes, SupplyChainChange{Kind: "removed", URL: u})
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		if changes[i].Kind != changes[j].Kind {
+			return changes[i].Kind < changes[j].Kind
+		}
+		return changes[i].URL < changes[j].URL
+	})
+	return changes
+}
+
+func versionlessKey(rawURL, version string) string {
+	if version == "" {
+		return rawURL
+	}
+	return strings.Replace(rawURL, version, "*", 1)
+}
+
+// HandleSupplyChainAudit parses params, builds the report against the stored baseline for the
+// first-party origin, and replaces that baseline when update_baseline is set.
+//
+// Failure semantics:
+// - Invalid JSON params, an unparseable baseline file, or a failed baseline write return an error.
+// - A missing baseline is not an error; the report simply has no changes.
+func HandleSupplyChainAudit(params json.RawMessage, bodies []capture.NetworkBody, waterfall []capture.NetworkWaterfallEntry, pageURLs []string) (SupplyChainReport, error) {
+	var p SupplyChainParams
+	if len(params) > 0 {
+		if err := json.Unmarshal(params, &p); err != nil {
+			return SupplyChainReport{}, fmt.Errorf("invalid params: %w", err)
+		}
+	}
+
+	origin := ""
+	if len(p.FirstPartyOrigins) > 0 {
+		origin = p.FirstPartyOrigins[0]
+	} else if len(pageURLs) > 0 {
+		origin = pageURLs[0]
+	}
+	path, err := SupplyChainBaselinePath(util.ExtractOrigin(origin))
+	if err != nil {
+		return SupplyChainReport{}, fmt.Errorf("resolve baseline path: %w", err)
+	}
+	baseline, err := LoadSupplyChainBaseline(path)
+	if err != nil {
+		return SupplyChainReport{}, err
+	}
+
+	report := BuildSupplyChainReport(bodies, waterfall, pageURLs, p, baseline)
+	if report.Baseline != nil {
+		report.Baseline.Path = path
+	}
+	if p.UpdateBaseline {
+		snap := SnapshotFromReport(report, time.Now())
+		if err := SaveSupplyChainBaseline(path, snap); err != nil {
+			return SupplyChainReport{}, fmt.Errorf("save baseline: %w", err)
+		}
+		if report.Baseline == nil {
+			report.Baseline = &SupplyChainBaseline{Path: path}
+		}
+		report.Baseline.Updated = true
+		report.Baseline.CreatedAt = snap.CreatedAt
+		report.Baseline.Scripts = len(snap.Scripts)
+	}
+	return report, nil
+}
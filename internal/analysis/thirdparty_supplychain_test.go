@@ -0,0 +1,169 @@
+// Purpose: Tests for the third-party script supply-chain report and baseline drift detection.
+// Docs: docs/features/feature/enterprise-audit/index.md
+
+package analysis
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/state"
+)
+
+func findScript(t *testing.T, report SupplyChainReport, url string) SupplyChainScript {
+	t.Helper()
+	for _, s := range report.Scripts {
+		if s.URL == url {
+			return s
+		}
+	}
+	t.Fatalf("script %s not in report: %+v", url, report.Scripts)
+	return SupplyChainScript{}
+}
+
+func TestDetectPinning(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		url, kind, version string
+	}{
+		{"https://cdn.jsdelivr.net/npm/lodash@4.17.21/lodash.min.js", "semver", "4.17.21"},
+		{"https://unpkg.com/react@18/umd/react.production.min.js", "floating", "18"},
+		{"https://unpkg.com/htmx.org@latest", "floating", "latest"},
+		{"https://cdnjs.cloudflare.com/ajax/libs/jquery/3.7.1/jquery.min.js", "semver", "3.7.1"},
+		{"https://static.vendor.io/app.3f9a1c2b7d.js", "content_hash", "3f9a1c2b7d"},
+		{"https://widgets.vendor.io/embed.js?v=20240101", "query_version", "20240101"},
+		{"https://tracker.vendor.io/t.js", "none", ""},
+	}
+	for _, tc := range cases {
+		kind, version := detectPinning(tc.url)
+		if kind != tc.kind || version != tc.version {
+			t.Errorf("detectPinning(%s) = %s/%s, want %s/%s", tc.url, kind, version, tc.kind, tc.version)
+		}
+	}
+}
+
+func TestBuildSupplyChainReport_ClassifiesLoadPositionAndRisk(t *testing.T) {
+	t.Parallel()
+	html := `<html><head>
+<script src="https://cdn.jsdelivr.net/npm/lodash@4.17.21/lodash.min.js" integrity="sha384-abc" crossorigin="anonymous"></script>
+<script async src='https://tracker.unknownhost.com/t.js'></script>
+<script src=/local.js></script>
+<script type="module" src="https://esm.sh/preact@10"></script>
+</head></html>`
+	bodies := []NetworkBody{
+		{URL: "https://myapp.com/", ContentType: "text/html", ResponseBody: html},
+		{URL: "https://cdn.jsdelivr.net/npm/lodash@4.17.21/lodash.min.js", ContentType: "application/javascript", ResponseBody: "var _={}"},
+		{URL: "https://tracker.unknownhost.com/t.js", ContentType: "text/javascript", ResponseBody: "track()"},
+		{URL: "https://myapp.com/local.js", ContentType: "application/javascript", ResponseBody: "x"},
+		{URL: "https://esm.sh/preact@10", ContentType: "application/javascript", ResponseBody: "export{}"},
+	}
+	waterfall := []capture.NetworkWaterfallEntry{
+		{URL: "https://chat.widget.io/loader.js", InitiatorType: "script", DecodedBodySize: 4096},
+	}
+
+	report := BuildSupplyChainReport(bodies, waterfall, []string{"https://myapp.com/"}, SupplyChainParams{}, nil)
+
+	if report.Summary.TotalScripts != 4 {
+		t.Fatalf("total scripts = %d, want 4 (first-party excluded): %+v", report.Summary.TotalScripts, report.Scripts)
+	}
+	lodash := findScript(t, report, "https://cdn.jsdelivr.net/npm/lodash@4.17.21/lodash.min.js")
+	if !lodash.Pinned || lodash.LoadPosition != "blocking" || !lodash.HasSRI || lodash.Classification != "known_cdn" {
+		t.Errorf("lodash = %+v", lodash)
+	}
+	if lodash.RiskLevel != "medium" {
+		t.Errorf("pinned but render-blocking lodash should be medium, got %s %v", lodash.RiskLevel, lodash.RiskReasons)
+	}
+	tracker := findScript(t, report, "https://tracker.unknownhost.com/t.js")
+	if tracker.LoadPosition != "async" || tracker.RiskLevel != "high" || tracker.Pinned {
+		t.Errorf("tracker = %+v", tracker)
+	}
+	preact := findScript(t, report, "https://esm.sh/preact@10")
+	if preact.LoadPosition != "module" || preact.PinKind != "floating" {
+		t.Errorf("preact = %+v", preact)
+	}
+	chat := findScript(t, report, "https://chat.widget.io/loader.js")
+	if chat.LoadPosition != "dynamic" || chat.SizeBytes != 4096 {
+		t.Errorf("chat = %+v", chat)
+	}
+	if report.Scripts[0].RiskLevel != "high" {
+		t.Errorf("scripts should be sorted by risk, first = %+v", report.Scripts[0])
+	}
+	if len(report.Changes) != 0 || report.Baseline != nil {
+		t.Errorf("no baseline should mean no changes: %+v", report.Changes)
+	}
+}
+
+func TestBuildSupplyChainReport_DetectsDriftFromBaseline(t *testing.T) {
+	t.Parallel()
+	page := []string{"https://myapp.com/"}
+	before := []NetworkBody{
+		{URL: "https://cdn.jsdelivr.net/npm/lodash@4.17.20/lodash.min.js", ContentType: "application/javascript", ResponseBody: "v20"},
+		{URL: "https://widgets.vendor.io/embed.js?v=1", ContentType: "application/javascript", ResponseBody: "original"},
+		{URL: "https://old.vendor.io/legacy.js", ContentType: "application/javascript", ResponseBody: "legacy"},
+	}
+	baseline := SnapshotFromReport(BuildSupplyChainReport(before, nil, page, SupplyChainParams{}, nil), time.Now())
+
+	after := []NetworkBody{
+		{URL: "https://cdn.jsdelivr.net/npm/lodash@4.17.21/lodash.min.js", ContentType: "application/javascript", ResponseBody: "v21"},
+		{URL: "https://widgets.vendor.io/embed.js?v=1", ContentType: "application/javascript", ResponseBody: "tampered"},
+		{URL: "https://new.vendor.io/pixel.js", ContentType: "application/javascript", ResponseBody: "pixel"},
+	}
+	report := BuildSupplyChainReport(after, nil, page, SupplyChainParams{}, &baseline)
+
+	kinds := map[string]string{}
+	for _, c := range report.Changes {
+		kinds[c.URL] = c.Kind
+	}
+	want := map[string]string{
+		"https://cdn.jsdelivr.net/npm/lodash@4.17.21/lodash.min.js": "version_changed",
+		"https://widgets.vendor.io/embed.js?v=1":                    "content_changed",
+		"https://new.vendor.io/pixel.js":                            "added",
+		"https://old.vendor.io/legacy.js":                           "removed",
+	}
+	for url, kind := range want {
+		if kinds[url] != kind {
+			t.Errorf("change for %s = %q, want %q (all: %+v)", url, kinds[url], kind, report.Changes)
+		}
+	}
+	if report.Summary.ContentChanged != 1 {
+		t.Errorf("content_changed = %d", report.Summary.ContentChanged)
+	}
+	if embed := findScript(t, report, "https://widgets.vendor.io/embed.js?v=1"); embed.RiskLevel != "high" {
+		t.Errorf("tampered pinned script should be high risk: %+v", embed)
+	}
+}
+
+func TestHandleSupplyChainAudit_PersistsBaseline(t *testing.T) {
+	t.Setenv(state.StateDirEnv, t.TempDir())
+	bodies := []NetworkBody{
+		{URL: "https://cdn.example.com/a.js", ContentType: "application/javascript", ResponseBody: "one"},
+	}
+	page := []string{"https://myapp.com/home"}
+
+	first, err := HandleSupplyChainAudit(json.RawMessage(`{"update_baseline":true}`), bodies, nil, page)
+	if err != nil {
+		t.Fatalf("first audit: %v", err)
+	}
+	if first.Baseline == nil || !first.Baseline.Updated || first.Baseline.Scripts != 1 {
+		t.Fatalf("baseline = %+v", first.Baseline)
+	}
+	if filepath.Base(first.Baseline.Path) != "https_myapp.com.json" {
+		t.Errorf("baseline path = %s", first.Baseline.Path)
+	}
+
+	bodies[0].ResponseBody = "two"
+	second, err := HandleSupplyChainAudit(nil, bodies, nil, page)
+	if err != nil {
+		t.Fatalf("second audit: %v", err)
+	}
+	if len(second.Changes) != 1 || second.Changes[0].Kind != "content_changed" || second.Baseline.Updated {
+		t.Errorf("second audit changes = %+v baseline = %+v", second.Changes, second.Baseline)
+	}
+
+	if _, err := HandleSupplyChainAudit(json.RawMessage(`{bad`), bodies, nil, page); err == nil {
+		t.Error("invalid params should error")
+	}
+}
@@ -33,6 +33,22 @@ func TestThirdPartyBasicClassification(t *testing.T) {
 	}
 }
 
+func TestThirdPartySubdomainsAndSuffixesAreFirstParty(t *testing.T) {
+	t.Parallel()
+	auditor := NewThirdPartyAuditor()
+	bodies := []NetworkBody{
+		{URL: "https://api.myapp.com/v1/cart", ContentType: "application/json", Method: "POST", RequestBody: `{"id":1}`},
+		{URL: "https://cdn.myapp.com/app.js", ContentType: "application/javascript", Method: "GET"},
+		{URL: "https://assets.myapp-static.net/logo.svg", ContentType: "image/svg+xml", Method: "GET"},
+		{URL: "https://www.googletagmanager.com/gtm.js", ContentType: "application/javascript", Method: "GET"},
+	}
+	result := auditor.Audit(bodies, []string{"https://www.myapp.com/"}, ThirdPartyParams{FirstPartySuffixes: []string{"myapp-static.net"}})
+
+	if len(result.ThirdParties) != 1 || result.ThirdParties[0].Origin != "https://www.googletagmanager.com" {
+		t.Fatalf("third parties = %+v, want only googletagmanager", result.ThirdParties)
+	}
+}
+
 func TestThirdPartyRiskLevels(t *testing.T) {
 	t.Parallel()
 	auditor := NewThirdPartyAuditor()
@@ -58,12 +58,17 @@ func WaitForServer(port int, timeout time.Duration) bool {
 }
 
 // DoHTTP sends a raw JSON-RPC payload to the daemon and returns the HTTP response.
+// protocolVersion, when set, is sent as MCP-Protocol-Version so the daemon shapes results
+// for the version the stdio client negotiated.
 // The caller must provide a context that outlives the response body read.
-func DoHTTP(ctx context.Context, client *http.Client, endpoint string, line []byte) (*http.Response, error) {
+func DoHTTP(ctx context.Context, client *http.Client, endpoint string, line []byte, protocolVersion string) (*http.Response, error) {
 	httpReq, err := http.NewRequestWithContext(ctx, "POST", endpoint, bytes.NewReader(line)) // #nosec G704 -- endpoint is localhost-only
 	if err != nil {
 		return nil, err
 	}
 	httpReq.Header.Set("Content-Type", "application/json")
+	if protocolVersion != "" {
+		httpReq.Header.Set("MCP-Protocol-Version", protocolVersion)
+	}
 	return client.Do(httpReq)
 }
@@ -18,7 +18,8 @@ const (
 // ToolCallTimeout returns the per-request timeout based on the MCP method and tool name.
 // Fast tools (observe, generate, most configure actions, resources/read) get 10s;
 // slow tools (analyze, interact, long-running configure actions) get 35s.
-// Annotation observe (observe command_result for ann_*) gets 65s for blocking poll.
+// Annotation observe (observe command_result for ann_*) and observe long-polls
+// (wait_for_new=true) get 65s for blocking poll.
 //
 // method is the JSON-RPC method (e.g. "tools/call", "resources/read").
 // params is the raw JSON of the request params.
@@ -61,8 +62,12 @@ func ToolCallTimeout(method string, params json.RawMessage) time.Duration {
 		var args struct {
 			What          string `json:"what"`
 			CorrelationID string `json:"correlation_id"`
+			WaitForNew    bool   `json:"wait_for_new"`
 		}
 		if json.Unmarshal(p.Arguments, &args) == nil {
+			if args.WaitForNew {
+				return BlockingPoll
+			}
 			if args.What == "command_result" &&
 				len(args.CorrelationID) > 4 && args.CorrelationID[:4] == "ann_" {
 				return BlockingPoll
@@ -32,6 +32,7 @@ func TestToolCallTimeout(t *testing.T) {
 		{"observe screenshot gets slow timeout", "tools/call", `{"name":"observe","arguments":{"what":"screenshot"}}`, SlowTimeout},
 		{"observe command_result non-annotation gets fast", "tools/call", `{"name":"observe","arguments":{"what":"command_result","correlation_id":"cmd_123"}}`, FastTimeout},
 		{"observe command_result annotation gets blocking poll", "tools/call", `{"name":"observe","arguments":{"what":"command_result","correlation_id":"ann_detail_abc"}}`, BlockingPoll},
+		{"observe long-poll gets blocking poll", "tools/call", `{"name":"observe","arguments":{"what":"errors","wait_for_new":true}}`, BlockingPoll},
 		{"malformed params gets fast timeout", "tools/call", `{bad json}`, FastTimeout},
 		{"unknown tool gets fast timeout", "tools/call", `{"name":"unknown_tool","arguments":{}}`, FastTimeout},
 	}
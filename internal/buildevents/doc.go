@@ -0,0 +1,24 @@
+// Purpose: Package buildevents — ingests dev-server build/HMR events and classifies errors against rebuilds.
+// Why: An error thrown by a bundle that has since been rebuilt is not a regression; agents need to tell the two apart.
+// Docs: docs/features/feature/build-events/index.md
+
+/*
+Package buildevents records rebuild lifecycle events from dev servers (Vite,
+Next.js, webpack-dev-server) and answers "which bundle was the page running
+when this error happened?".
+
+Events arrive two ways: a dev-server plugin POSTs them to /build-events (Parse),
+or they are recognized in the dev server's own HMR WebSocket traffic that the
+extension already captures (FromWebSocket).
+
+Key types:
+  - Event: one normalized build event (build_start, build_end, hmr_update, full_reload).
+  - Store: bounded event history with its own lock (a leaf lock).
+  - Classifier: a snapshot that labels a timestamp as current, stale, or building.
+
+Key functions:
+  - Parse: decodes a single event, an array, or {"events": [...]}.
+  - FromWebSocket: derives build events from captured HMR WebSocket messages.
+  - (*Store).Classifier: snapshot used to label many timestamps at once.
+*/
+package buildevents
@@ -0,0 +1,205 @@
+// Purpose: Decodes and normalizes build events posted by dev-server plugins.
+// Why: Vite, Next.js, and webpack plugins report rebuilds with different names; one shape keeps classification simple.
+// Docs: docs/features/feature/build-events/index.md
+
+package buildevents
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Event types after normalization.
+const (
+	TypeBuildStart = "build_start"
+	TypeBuildEnd   = "build_end"
+	TypeHMRUpdate  = "hmr_update"  // modules hot-swapped in place; implies a finished rebuild
+	TypeFullReload = "full_reload" // dev server forced a page reload; implies a finished rebuild
+)
+
+// Build outcomes.
+const (
+	StatusSuccess = "success"
+	StatusFailed  = "failed"
+)
+
+// Payload limits.
+const (
+	MaxBatch         = 500
+	maxModules       = 50
+	maxMessageLength = 1000
+	maxStackLength   = 4000
+)
+
+// Failure is the compile or bundling error that failed a build.
+type Failure struct {
+	Message string `json:"message"`
+	Stack   string `json:"stack,omitempty"`
+	File    string `json:"file,omitempty"`
+}
+
+// Event is one normalized build event.
+type Event struct {
+	Type       string    `json:"type"`
+	Tool       string    `json:"tool,omitempty"`   // vite, next, webpack, ...
+	Source     string    `json:"source,omitempty"` // "plugin" (POST /build-events) or "websocket" (captured HMR traffic)
+	Status     string    `json:"status,omitempty"`
+	Hash       string    `json:"hash,omitempty"`
+	Modules    []string  `json:"modules,omitempty"`
+	DurationMs int64     `json:"duration_ms,omitempty"`
+	Error      *Failure  `json:"error,omitempty"`
+	Timestamp  time.Time `json:"timestamp"`
+}
+
+// Completes reports whether the event marks a finished rebuild (successful or not).
+func (e Event) Completes() bool {
+	return e.Type == TypeBuildEnd || e.Type == TypeHMRUpdate || e.Type == TypeFullReload
+}
+
+// wireEvent accepts timestamps as RFC3339 strings or Unix milliseconds.
+type wireEvent struct {
+	Event
+	Timestamp json.RawMessage `json:"timestamp"`
+}
+
+// Parse decodes a single event object, an array of events, or {"events": [...]}.
+// Events without a timestamp are stamped with now. Each event is validated and normalized.
+func Parse(raw []byte, now time.Time) ([]Event, error) {
+	raw = bytes.TrimSpace(raw)
+	var wire []wireEvent
+	switch {
+	case len(raw) == 0:
+		return nil, errors.New("empty body")
+	case raw[0] == '[':
+		if err := json.Unmarshal(raw, &wire); err != nil {
+			return nil, fmt.Errorf("invalid JSON: %w", err)
+		}
+	default:
+		var envelope struct {
+			Events []wireEvent `json:"events"`
+		}
+		if err := json.Unmarshal(raw, &envelope); err != nil {
+			return nil, fmt.Errorf("invalid JSON: %w", err)
+		}
+		if envelope.Events != nil {
+			wire = envelope.Events
+		} else {
+			var single wireEvent
+			if err := json.Unmarshal(raw, &single); err != nil {
+				return nil, fmt.Errorf("invalid JSON: %w", err)
+			}
+			wire = []wireEvent{single}
+		}
+	}
+	if len(wire) > MaxBatch {
+		return nil, fmt.Errorf("batch of %d events exceeds limit of %d", len(wire), MaxBatch)
+	}
+
+	events := make([]Event, 0, len(wire))
+	for i, w := range wire {
+		ev := w.Event
+		ts, err := parseTimestamp(w.Timestamp, now)
+		if err != nil {
+			return nil, fmt.Errorf("event %d: %w", i, err)
+		}
+		ev.Timestamp = ts
+		ev.Source = "plugin"
+		if err := normalize(&ev); err != nil {
+			return nil, fmt.Errorf("event %d: %w", i, err)
+		}
+		events = append(events, ev)
+	}
+	return events, nil
+}
+
+func parseTimestamp(raw json.RawMessage, now time.Time) (time.Time, error) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return now, nil
+	}
+	var ms int64
+	if err := json.Unmarshal(raw, &ms); err == nil {
+		return time.UnixMilli(ms), nil
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err != nil {
+		return time.Time{}, errors.New("timestamp must be RFC3339 or Unix milliseconds")
+	}
+	ts, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("timestamp: %w", err)
+	}
+	return ts, nil
+}
+
+// normalize canonicalizes type and status names and bounds payload sizes.
+func normalize(ev *Event) error {
+	ev.Type = normalizeType(ev.Type)
+	switch ev.Type {
+	case TypeBuildStart:
+		ev.Status = ""
+	case TypeBuildEnd, TypeHMRUpdate, TypeFullReload:
+		ev.Status = normalizeStatus(ev.Status)
+		if ev.Status == "" {
+			ev.Status = StatusSuccess
+			if ev.Error != nil {
+				ev.Status = StatusFailed
+			}
+		}
+	default:
+		return fmt.Errorf("unknown type %q (use build_start, build_end, hmr_update, full_reload)", ev.Type)
+	}
+	ev.Tool = strings.ToLower(strings.TrimSpace(ev.Tool))
+	if len(ev.Modules) > maxModules {
+		ev.Modules = ev.Modules[:maxModules]
+	}
+	if ev.Error != nil {
+		ev.Error.Message = truncate(ev.Error.Message, maxMessageLength)
+		ev.Error.Stack = truncate(ev.Error.Stack, maxStackLength)
+	}
+	return nil
+}
+
+// normalizeType maps common plugin phase names onto the canonical types.
+func normalizeType(t string) string {
+	t = strings.ToLower(strings.TrimSpace(t))
+	switch t {
+	case "building", "rebuild_start", "compile_start", "invalid":
+		return TypeBuildStart
+	case "built", "rebuild_end", "compile_end", "compiled", "done":
+		return TypeBuildEnd
+	case "hmr", "update", "hot_update":
+		return TypeHMRUpdate
+	case "reload", "full-reload", "page_reload":
+		return TypeFullReload
+	}
+	for _, suffix := range []string{"_finish", "_done", "_complete"} {
+		if strings.HasSuffix(t, suffix) {
+			return strings.TrimSuffix(t, suffix) + "_end"
+		}
+	}
+	return t
+}
+
+func normalizeStatus(s string) string {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "":
+		return ""
+	case "ok", "success", "succeeded", "passed", "built":
+		return StatusSuccess
+	case "fail", "failed", "failure", "error", "errors":
+		return StatusFailed
+	default:
+		return strings.ToLower(strings.TrimSpace(s))
+	}
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n] + "..."
+}
@@ -0,0 +1,165 @@
+// Purpose: Recognizes rebuilds in captured Vite, Next.js, and webpack-dev-server HMR WebSocket traffic.
+// Why: The extension already records the dev server's HMR socket, so rebuilds show up without installing a plugin.
+// Docs: docs/features/feature/build-events/index.md
+
+package buildevents
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/types"
+)
+
+// hmrMessage is the union of the HMR message shapes we recognize.
+type hmrMessage struct {
+	Type    string `json:"type"`   // vite, webpack-dev-server
+	Action  string `json:"action"` // next.js
+	Hash    string `json:"hash"`
+	Path    string `json:"path"`
+	Updates []struct {
+		Path string `json:"path"`
+	} `json:"updates"`
+	Err *struct {
+		Message string `json:"message"`
+		Stack   string `json:"stack"`
+		ID      string `json:"id"`
+	} `json:"err"`
+	Errors json.RawMessage `json:"errors"`
+	Data   json.RawMessage `json:"data"`
+}
+
+// FromWebSocket derives build events from incoming HMR messages among captured WebSocket events.
+// Messages that do not match a known dev-server protocol are ignored.
+func FromWebSocket(events []types.WebSocketEvent, now time.Time) []Event {
+	var out []Event
+	for _, ws := range events {
+		if ws.Event != "message" || ws.Direction != "incoming" || !strings.HasPrefix(strings.TrimSpace(ws.Data), "{") {
+			continue
+		}
+		var msg hmrMessage
+		if json.Unmarshal([]byte(ws.Data), &msg) != nil {
+			continue
+		}
+		ev, ok := classifyHMR(ws.URL, msg)
+		if !ok {
+			continue
+		}
+		ev.Source = "websocket"
+		ev.Timestamp = now
+		if ts, err := time.Parse(time.RFC3339Nano, ws.Timestamp); err == nil {
+			ev.Timestamp = ts
+		}
+		if err := normalize(&ev); err == nil {
+			out = append(out, ev)
+		}
+	}
+	return out
+}
+
+func classifyHMR(url string, msg hmrMessage) (Event, bool) {
+	switch {
+	case strings.Contains(url, "/_next/webpack-hmr") || strings.Contains(url, "/_next/turbopack-hmr"):
+		return classifyNext(msg)
+	case isWebpackDevServerURL(url):
+		if ev, ok := classifyWebpack(msg); ok {
+			return ev, true
+		}
+	}
+	return classifyVite(msg)
+}
+
+// classifyNext handles Next.js dev server actions. "sync" is sent on every connect and is ignored.
+func classifyNext(msg hmrMessage) (Event, bool) {
+	switch msg.Action {
+	case "building":
+		return Event{Type: TypeBuildStart, Tool: "next"}, true
+	case "built":
+		ev := Event{Type: TypeBuildEnd, Tool: "next", Hash: msg.Hash}
+		if message := firstErrorMessage(msg.Errors); message != "" {
+			ev.Status = StatusFailed
+			ev.Error = &Failure{Message: message}
+		}
+		return ev, true
+	case "serverComponentChanges":
+		return Event{Type: TypeHMRUpdate, Tool: "next"}, true
+	case "reloadPage":
+		return Event{Type: TypeFullReload, Tool: "next"}, true
+	}
+	return Event{}, false
+}
+
+func isWebpackDevServerURL(url string) bool {
+	path := url
+	if i := strings.IndexAny(path, "?#"); i >= 0 {
+		path = path[:i]
+	}
+	return strings.HasSuffix(path, "/ws") || strings.Contains(path, "/sockjs-node")
+}
+
+// classifyWebpack handles webpack-dev-server messages: invalid → ok/still-ok/errors.
+func classifyWebpack(msg hmrMessage) (Event, bool) {
+	switch msg.Type {
+	case "invalid":
+		return Event{Type: TypeBuildStart, Tool: "webpack"}, true
+	case "ok", "still-ok":
+		return Event{Type: TypeBuildEnd, Tool: "webpack", Status: StatusSuccess}, true
+	case "errors":
+		message := firstErrorMessage(msg.Data)
+		if message == "" {
+			message = "build failed"
+		}
+		return Event{Type: TypeBuildEnd, Tool: "webpack", Status: StatusFailed, Error: &Failure{Message: message}}, true
+	case "static-changed":
+		return Event{Type: TypeFullReload, Tool: "webpack"}, true
+	}
+	return Event{}, false
+}
+
+// classifyVite handles Vite HMR payloads. Shapes are checked strictly because
+// "update" and "error" are common message types in application sockets.
+func classifyVite(msg hmrMessage) (Event, bool) {
+	switch msg.Type {
+	case "update":
+		if len(msg.Updates) == 0 {
+			return Event{}, false
+		}
+		ev := Event{Type: TypeHMRUpdate, Tool: "vite"}
+		for _, u := range msg.Updates {
+			ev.Modules = append(ev.Modules, u.Path)
+		}
+		return ev, true
+	case "full-reload":
+		ev := Event{Type: TypeFullReload, Tool: "vite"}
+		if msg.Path != "" && msg.Path != "*" {
+			ev.Modules = []string{msg.Path}
+		}
+		return ev, true
+	case "error":
+		if msg.Err == nil || msg.Err.Message == "" {
+			return Event{}, false
+		}
+		return Event{Type: TypeBuildEnd, Tool: "vite", Status: StatusFailed, Error: &Failure{Message: msg.Err.Message, Stack: msg.Err.Stack, File: msg.Err.ID}}, true
+	}
+	return Event{}, false
+}
+
+// firstErrorMessage returns the first entry of an error list given as strings or {message} objects.
+func firstErrorMessage(raw json.RawMessage) string {
+	var list []json.RawMessage
+	if json.Unmarshal(raw, &list) != nil || len(list) == 0 {
+		return ""
+	}
+	var s string
+	if json.Unmarshal(list[0], &s) == nil {
+		return s
+	}
+	var obj struct {
+		Message string `json:"message"`
+	}
+	if json.Unmarshal(list[0], &obj) == nil && obj.Message != "" {
+		return obj.Message
+	}
+	return "build failed"
+}
@@ -0,0 +1,52 @@
+// Purpose: Tests rebuild detection in captured Vite, Next.js, and webpack-dev-server HMR traffic.
+// Docs: docs/features/feature/build-events/index.md
+
+package buildevents
+
+import (
+	"testing"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/types"
+)
+
+func TestFromWebSocket_DevServerProtocols(t *testing.T) {
+	t.Parallel()
+	now := time.Now()
+	in := func(url, data string) types.WebSocketEvent {
+		return types.WebSocketEvent{Event: "message", Direction: "incoming", URL: url, Data: data, Timestamp: "2026-10-16T12:00:00Z"}
+	}
+	events := FromWebSocket([]types.WebSocketEvent{
+		in("ws://localhost:5173/?token=abc", `{"type":"connected"}`),
+		in("ws://localhost:5173/?token=abc", `{"type":"update","updates":[{"type":"js-update","path":"/src/App.tsx"}]}`),
+		in("ws://localhost:5173/?token=abc", `{"type":"error","err":{"message":"Transform failed","id":"/src/App.tsx"}}`),
+		in("ws://localhost:3000/_next/webpack-hmr", `{"action":"sync","hash":"a"}`),
+		in("ws://localhost:3000/_next/webpack-hmr", `{"action":"building"}`),
+		in("ws://localhost:3000/_next/webpack-hmr", `{"action":"built","hash":"b","errors":[]}`),
+		in("ws://localhost:8080/ws", `{"type":"errors","data":[{"message":"Module not found"}]}`),
+		// Application sockets reusing generic message types are not builds.
+		in("wss://api.example.com/live", `{"type":"update","data":{"price":3}}`),
+		in("wss://api.example.com/live", `{"type":"ok"}`),
+		{Event: "message", Direction: "outgoing", URL: "ws://localhost:5173/", Data: `{"type":"full-reload"}`},
+	}, now)
+
+	want := []struct{ typ, tool, status string }{
+		{TypeHMRUpdate, "vite", StatusSuccess},
+		{TypeBuildEnd, "vite", StatusFailed},
+		{TypeBuildStart, "next", ""},
+		{TypeBuildEnd, "next", StatusSuccess},
+		{TypeBuildEnd, "webpack", StatusFailed},
+	}
+	if len(events) != len(want) {
+		t.Fatalf("events = %+v, want %d", events, len(want))
+	}
+	for i, w := range want {
+		ev := events[i]
+		if ev.Type != w.typ || ev.Tool != w.tool || ev.Status != w.status || ev.Source != "websocket" {
+			t.Errorf("event %d = %+v, want %+v", i, ev, w)
+		}
+	}
+	if events[0].Modules[0] != "/src/App.tsx" || events[1].Error.File != "/src/App.tsx" || events[4].Error.Message != "Module not found" {
+		t.Errorf("details lost: %+v", events)
+	}
+}
@@ -0,0 +1,141 @@
+// Purpose: Stores build events and labels timestamps by which bundle the page was running.
+// Why: "TypeError at 12:03:04" means little until you know the bundle was replaced at 12:03:06.
+// Docs: docs/features/feature/build-events/index.md
+
+package buildevents
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// MaxEvents bounds the retained build event history.
+const MaxEvents = 1000
+
+// Bundle labels for a timestamp.
+const (
+	BundleCurrent     = "current"      // no rebuild since; the error reflects the code being served now
+	BundleStale       = "stale"        // a successful rebuild replaced the bundle afterwards
+	BundleBuilding    = "building"     // a rebuild was in progress; modules may have been half-swapped
+	BundleBuildFailed = "build_failed" // the latest rebuild failed, so the page ran an outdated bundle
+)
+
+// State summarizes the build history.
+type State struct {
+	Builds     int    `json:"builds"`
+	Failed     int    `json:"failed"`
+	Building   bool   `json:"building"`
+	LastStatus string `json:"last_status,omitempty"`
+	LastTool   string `json:"last_tool,omitempty"`
+	LastAt     string `json:"last_rebuild_at,omitempty"`
+}
+
+// Store keeps bounded build event history.
+//
+// Lock hierarchy: Store.mu is a leaf lock; Store never calls out while holding it.
+type Store struct {
+	mu     sync.Mutex
+	events []Event
+}
+
+// NewStore creates an empty store.
+func NewStore() *Store {
+	return &Store{}
+}
+
+// Ingest records events, keeping history ordered by timestamp. Returns the number accepted.
+func (s *Store) Ingest(events []Event) int {
+	if len(events) == 0 {
+		return 0
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.events = append(s.events, events...)
+	sort.SliceStable(s.events, func(i, j int) bool { return s.events[i].Timestamp.Before(s.events[j].Timestamp) })
+	if over := len(s.events) - MaxEvents; over > 0 {
+		s.events = append([]Event(nil), s.events[over:]...)
+	}
+	return len(events)
+}
+
+// Events returns up to limit of the most recent events, oldest first (limit <= 0 returns all).
+func (s *Store) Events(limit int) []Event {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	start := 0
+	if limit > 0 && len(s.events) > limit {
+		start = len(s.events) - limit
+	}
+	return append([]Event(nil), s.events[start:]...)
+}
+
+// State summarizes retained builds and the most recent outcome.
+func (s *Store) State() State {
+	return s.Classifier().State()
+}
+
+// Classifier labels many timestamps against one snapshot of the build history.
+type Classifier struct {
+	events []Event // ordered by timestamp
+}
+
+// Classifier snapshots the current history for repeated lookups.
+func (s *Store) Classifier() Classifier {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return Classifier{events: append([]Event(nil), s.events...)}
+}
+
+// Empty reports whether there is no build history to classify against.
+func (c Classifier) Empty() bool {
+	return len(c.events) == 0
+}
+
+// Bundle labels t. Returns "" when there is no build history.
+func (c Classifier) Bundle(t time.Time) string {
+	if c.Empty() {
+		return ""
+	}
+	// The first event at or after t decides whether the bundle was replaced later.
+	i := sort.Search(len(c.events), func(i int) bool { return !c.events[i].Timestamp.Before(t) })
+	for _, ev := range c.events[i:] {
+		if ev.Completes() && ev.Status == StatusSuccess {
+			return BundleStale
+		}
+	}
+	// Otherwise the state just before t decides.
+	for j := i - 1; j >= 0; j-- {
+		ev := c.events[j]
+		switch {
+		case ev.Type == TypeBuildStart:
+			return BundleBuilding
+		case ev.Completes() && ev.Status == StatusFailed:
+			return BundleBuildFailed
+		case ev.Completes():
+			return BundleCurrent
+		}
+	}
+	return BundleCurrent
+}
+
+// State summarizes the snapshot.
+func (c Classifier) State() State {
+	var st State
+	for _, ev := range c.events {
+		switch {
+		case ev.Type == TypeBuildStart:
+			st.Building = true
+		case ev.Completes():
+			st.Building = false
+			st.Builds++
+			if ev.Status == StatusFailed {
+				st.Failed++
+			}
+			st.LastStatus = ev.Status
+			st.LastTool = ev.Tool
+			st.LastAt = ev.Timestamp.UTC().Format(time.RFC3339Nano)
+		}
+	}
+	return st
+}
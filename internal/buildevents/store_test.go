@@ -0,0 +1,71 @@
+// Purpose: Tests plugin payload parsing and bundle classification against the rebuild history.
+// Docs: docs/features/feature/build-events/index.md
+
+package buildevents
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParse_ShapesAndNormalization(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+
+	events, err := Parse([]byte(`{"events":[
+		{"type":"building","tool":"Vite"},
+		{"type":"built","error":{"message":"Unexpected token"},"timestamp":1791115200000},
+		{"type":"update","modules":["/src/App.tsx"],"timestamp":"2026-10-16T12:00:05Z"}]}`), now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if events[0].Type != TypeBuildStart || events[0].Tool != "vite" || !events[0].Timestamp.Equal(now) || events[0].Source != "plugin" {
+		t.Errorf("start = %+v", events[0])
+	}
+	if events[1].Type != TypeBuildEnd || events[1].Status != StatusFailed || events[1].Timestamp.UnixMilli() != 1791115200000 {
+		t.Errorf("failed end = %+v", events[1])
+	}
+	if events[2].Type != TypeHMRUpdate || events[2].Status != StatusSuccess || !events[2].Completes() {
+		t.Errorf("hmr = %+v", events[2])
+	}
+
+	for _, bad := range []string{``, `{"type":"lint"}`, `{"type":"build_end","timestamp":"soon"}`} {
+		if _, err := Parse([]byte(bad), now); err == nil {
+			t.Errorf("Parse(%q) should fail", bad)
+		}
+	}
+}
+
+func TestClassifier_BundleLabels(t *testing.T) {
+	t.Parallel()
+	base := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	at := func(sec int) time.Time { return base.Add(time.Duration(sec) * time.Second) }
+
+	s := NewStore()
+	if s.Classifier().Bundle(at(0)) != "" {
+		t.Fatal("no history should classify as empty")
+	}
+	// Ingested out of order on purpose: history is kept sorted by timestamp.
+	s.Ingest([]Event{
+		{Type: TypeBuildEnd, Status: StatusSuccess, Timestamp: at(12)},
+		{Type: TypeBuildStart, Timestamp: at(10)},
+	})
+	s.Ingest([]Event{
+		{Type: TypeBuildEnd, Status: StatusSuccess, Tool: "vite", Timestamp: at(0)},
+		{Type: TypeBuildEnd, Status: StatusFailed, Tool: "vite", Timestamp: at(20)},
+	})
+
+	cls := s.Classifier()
+	for sec, want := range map[int]string{5: BundleStale, 11: BundleStale, 15: BundleCurrent, 25: BundleBuildFailed} {
+		if got := cls.Bundle(at(sec)); got != want {
+			t.Errorf("Bundle(+%ds) = %q, want %q", sec, got, want)
+		}
+	}
+	s.Ingest([]Event{{Type: TypeBuildStart, Timestamp: at(30)}})
+	if got := s.Classifier().Bundle(at(31)); got != BundleBuilding {
+		t.Errorf("Bundle during rebuild = %q", got)
+	}
+	if st := s.State(); st.Builds != 3 || st.Failed != 1 || !st.Building || st.LastStatus != StatusFailed {
+		t.Errorf("state = %+v", st)
+	}
+}
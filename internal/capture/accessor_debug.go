@@ -11,3 +11,8 @@ func (c *Capture) LogHTTPDebugEntry(entry HTTPDebugEntry) {
 func (c *Capture) GetHTTPDebugLog() []HTTPDebugEntry {
 	return c.debug.GetHTTPDebugLog()
 }
+
+// GetHTTPDebugEntries returns the HTTP debug log oldest first and the total entries ever logged.
+func (c *Capture) GetHTTPDebugEntries() ([]HTTPDebugEntry, int64) {
+	return c.debug.GetHTTPDebugEntries()
+}
@@ -45,3 +45,19 @@ func (c *Capture) GetAllEnhancedActions() []EnhancedAction {
 	defer c.mu.RUnlock()
 	return c.buffers.enhancedActionsCopy()
 }
+
+// GetEnhancedActionsWithTimestamps returns copies of the enhanced actions and their
+// ingestion timestamps, index-aligned and read under one lock.
+func (c *Capture) GetEnhancedActionsWithTimestamps() ([]EnhancedAction, []time.Time) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.buffers.enhancedActionsCopy(), c.buffers.actionTimestamps()
+}
+
+// GetNetworkBodiesWithTimestamps returns copies of the network bodies and their ingestion
+// timestamps, index-aligned and read under one lock so eviction cannot skew them.
+func (c *Capture) GetNetworkBodiesWithTimestamps() ([]NetworkBody, []time.Time) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.buffers.networkBodiesCopy(), c.buffers.networkTimestamps()
+}
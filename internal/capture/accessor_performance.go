@@ -2,12 +2,32 @@
 // Why: Isolates performance snapshot access from other capture store accessors.
 package capture
 
+import "time"
+
 // AddPerformanceSnapshots stores performance snapshots from the extension.
-// Snapshots are keyed by URL with LRU eviction (max 100 entries).
+// Snapshots are keyed by URL with oldest-first eviction (retention limit, default 100 entries).
+// While a device is emulated, each snapshot records it so numbers from different devices aren't compared blindly.
+// The performance callback, if set, is invoked after the lock is released.
 func (c *Capture) AddPerformanceSnapshots(snapshots []PerformanceSnapshot) {
-	c.mu.Lock()
-	defer c.mu.Unlock()
-	c.perf.appendSnapshots(snapshots)
+	cb := func() func([]PerformanceSnapshot) {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		if c.deviceEmulation != nil {
+			label := c.deviceEmulation.Label()
+			stamped := make([]PerformanceSnapshot, len(snapshots))
+			copy(stamped, snapshots)
+			for i := range stamped {
+				stamped[i].Emulation = label
+			}
+			snapshots = stamped
+		}
+		c.perf.appendSnapshots(snapshots, time.Now())
+		return c.perfCallback
+	}()
+	c.relieveMemoryPressure()
+	if cb != nil && len(snapshots) > 0 {
+		cb(snapshots)
+	}
 }
 
 // GetPerformanceSnapshots returns all stored performance snapshots (thread-safe)
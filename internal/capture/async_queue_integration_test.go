@@ -235,6 +235,7 @@ func TestAsyncQueueMultiClientIntegration(t *testing.T) {
 func TestAsyncQueueExpirationIntegration(t *testing.T) {
 	t.Parallel()
 	capture := NewCapture()
+	_ = capture.SetRetryPolicy(queries.RetryPolicy{Attempts: 1}) // expire on the first missed window
 
 	// Create command with short timeout (will expire)
 	correlationID := "expiration_integration_test"
@@ -252,7 +253,8 @@ func TestAsyncQueueExpirationIntegration(t *testing.T) {
 		t.Fatal("Command should be pending initially")
 	}
 
-	// Wait for expiration with polling (faster and less flaky than fixed long sleeps)
+	// Poll for expiration: nothing sweeps the deadline in the background, so each
+	// GetCommandResult is what moves the command to expired.
 	deadline := time.Now().Add(800 * time.Millisecond)
 	for time.Now().Before(deadline) {
 		cmd, found = capture.GetCommandResult(correlationID)
@@ -0,0 +1,233 @@
+// Purpose: Builds content-type-aware previews of captured binary responses: image dimensions, protobuf field sketches, WASM section lists.
+// Why: Binary bodies used to be dropped for a size placeholder; a preview tells an agent what came back without handing it raw bytes.
+// Docs: docs/features/feature/binary-format-detection/index.md
+
+package capture
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"strings"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/types"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/util"
+)
+
+// BinaryPreview is an alias to canonical definition in internal/types/network.go
+type BinaryPreview = types.BinaryPreview
+
+const (
+	binaryEncodingBase64 = "base64"
+	previewHexHeadBytes  = 32 // bytes shown in hex_head
+	previewMaxFields     = 32 // top-level protobuf fields sketched
+	previewMaxSections   = 32 // WASM sections listed
+)
+
+// DecodeBinaryBody returns the bytes of a base64-encoded captured body. Inline bodies cut at the
+// response limit may end mid-quantum, so only the complete four-character groups are decoded.
+func DecodeBinaryBody(body string) []byte {
+	body = body[:len(body)-len(body)%4]
+	data, err := base64.StdEncoding.DecodeString(body)
+	if err != nil {
+		return nil
+	}
+	return data
+}
+
+// attachBinaryPreview summarizes a base64 binary response and runs format detection on its bytes,
+// which the text-based detector cannot see through the encoding.
+func attachBinaryPreview(body *NetworkBody) {
+	if body.ResponseEncoding != binaryEncodingBase64 || body.ResponseBody == "" {
+		return
+	}
+	data := DecodeBinaryBody(body.ResponseBody)
+	if len(data) == 0 {
+		return
+	}
+	if body.BinaryFormat == "" {
+		if format := util.DetectBinaryFormat(data); format != nil {
+			body.BinaryFormat = format.Name
+			body.FormatConfidence = format.Confidence
+		}
+	}
+	body.BinaryPreview = buildBinaryPreview(data, body.ContentType, body.BinaryFormat)
+}
+
+// buildBinaryPreview picks a preview by content type first, then by magic bytes.
+func buildBinaryPreview(data []byte, contentType, detected string) *BinaryPreview {
+	preview := &BinaryPreview{Kind: "binary", Format: detected, CapturedBytes: len(data)}
+	preview.HexHead = hex.EncodeToString(data[:min(len(data), previewHexHeadBytes)])
+	ct := strings.ToLower(contentType)
+	switch {
+	case strings.HasPrefix(ct, "image/") || imageFormat(data) != "":
+		if format := imageFormat(data); format != "" {
+			preview.Kind = "image"
+			preview.Format = format
+			preview.Width, preview.Height = imageDimensions(format, data)
+		}
+	case strings.Contains(ct, "wasm") || isWasm(data):
+		if isWasm(data) {
+			preview.Kind = "wasm"
+			preview.Format = "wasm"
+			preview.Sections = wasmSections(data)
+		}
+	case strings.Contains(ct, "protobuf") || strings.Contains(ct, "grpc") || detected == "protobuf":
+		if fields := protobufFields(data); len(fields) > 0 {
+			preview.Kind = "protobuf"
+			preview.Format = "protobuf"
+			preview.Fields = fields
+		}
+	}
+	return preview
+}
+
+// imageFormat identifies the image formats whose dimensions sit in a fixed header.
+func imageFormat(data []byte) string {
+	switch {
+	case len(data) >= 8 && string(data[:8]) == "\x89PNG\r\n\x1a\n":
+		return "png"
+	case len(data) >= 6 && (string(data[:6]) == "GIF87a" || string(data[:6]) == "GIF89a"):
+		return "gif"
+	case len(data) >= 3 && data[0] == 0xFF && data[1] == 0xD8 && data[2] == 0xFF:
+		return "jpeg"
+	case len(data) >= 12 && string(data[:4]) == "RIFF" && string(data[8:12]) == "WEBP":
+		return "webp"
+	case len(data) >= 2 && string(data[:2]) == "BM":
+		return "bmp"
+	}
+	return ""
+}
+
+// imageDimensions reads width and height from the image header; 0, 0 when the capture is too short.
+func imageDimensions(format string, data []byte) (int, int) {
+	switch format {
+	case "png":
+		if len(data) >= 24 && string(data[12:16]) == "IHDR" {
+			return int(binary.BigEndian.Uint32(data[16:20])), int(binary.BigEndian.Uint32(data[20:24]))
+		}
+	case "gif":
+		if len(data) >= 10 {
+			return int(binary.LittleEndian.Uint16(data[6:8])), int(binary.LittleEndian.Uint16(data[8:10]))
+		}
+	case "bmp":
+		if len(data) >= 26 {
+			h := int(int32(binary.LittleEndian.Uint32(data[22:26])))
+			return int(int32(binary.LittleEndian.Uint32(data[18:22]))), max(h, -h)
+		}
+	case "webp":
+		return webpDimensions(data)
+	case "jpeg":
+		return jpegDimensions(data)
+	}
+	return 0, 0
+}
+
+// webpDimensions handles the lossy (VP8), lossless (VP8L), and extended (VP8X) chunk layouts.
+func webpDimensions(data []byte) (int, int) {
+	if len(data) < 30 {
+		return 0, 0
+	}
+	switch string(data[12:16]) {
+	case "VP8 ":
+		return int(binary.LittleEndian.Uint16(data[26:28]) & 0x3FFF), int(binary.LittleEndian.Uint16(data[28:30]) & 0x3FFF)
+	case "VP8L":
+		bits := binary.LittleEndian.Uint32(data[21:25])
+		return int(bits&0x3FFF) + 1, int(bits>>14&0x3FFF) + 1
+	case "VP8X":
+		w := int(data[24]) | int(data[25])<<8 | int(data[26])<<16
+		h := int(data[27]) | int(data[28])<<8 | int(data[29])<<16
+		return w + 1, h + 1
+	}
+	return 0, 0
+}
+
+// jpegDimensions walks the marker segments to the first start-of-frame.
+func jpegDimensions(data []byte) (int, int) {
+	i := 2
+	for i+9 < len(data) {
+		if data[i] != 0xFF {
+			return 0, 0
+		}
+		marker := data[i+1]
+		size := int(binary.BigEndian.Uint16(data[i+2 : i+4]))
+		isSOF := marker >= 0xC0 && marker <= 0xCF && marker != 0xC4 && marker != 0xC8 && marker != 0xCC
+		if isSOF {
+			return int(binary.BigEndian.Uint16(data[i+7 : i+9])), int(binary.BigEndian.Uint16(data[i+5 : i+7]))
+		}
+		i += 2 + size
+	}
+	return 0, 0
+}
+
+func isWasm(data []byte) bool {
+	return len(data) >= 8 && string(data[:4]) == "\x00asm"
+}
+
+var wasmSectionNames = []string{
+	"custom", "type", "import", "function", "table", "memory", "global",
+	"export", "start", "element", "code", "data", "datacount", "tag",
+}
+
+// wasmSections lists the sections present in the captured head of the module. A section whose
+// size runs past the capture is still listed; parsing stops there.
+func wasmSections(data []byte) []types.WasmSection {
+	var sections []types.WasmSection
+	i := 8
+	for i < len(data) && len(sections) < previewMaxSections {
+		id := int(data[i])
+		size, n := binary.Uvarint(data[i+1:])
+		if n <= 0 {
+			break
+		}
+		name := "unknown"
+		if id < len(wasmSectionNames) {
+			name = wasmSectionNames[id]
+		}
+		if size > uint64(len(data)) {
+			return append(sections, types.WasmSection{ID: id, Name: name, Size: int(min(size, 1<<32))})
+		}
+		sections = append(sections, types.WasmSection{ID: id, Name: name, Size: int(size)})
+		i += 1 + n + int(size)
+	}
+	return sections
+}
+
+var protobufWireTypes = map[uint64]string{0: "varint", 1: "fixed64", 2: "bytes", 5: "fixed32"}
+
+// protobufFields sketches the top-level fields of a protobuf message without a schema. It stops
+// at the first tag that does not parse, which is also where a truncated capture ends.
+func protobufFields(data []byte) []types.ProtobufField {
+	var fields []types.ProtobufField
+	i := 0
+	for i < len(data) && len(fields) < previewMaxFields {
+		tag, n := binary.Uvarint(data[i:])
+		wire, ok := protobufWireTypes[tag&7]
+		if n <= 0 || !ok || tag>>3 == 0 {
+			break
+		}
+		i += n
+		field := types.ProtobufField{Number: int(tag >> 3), WireType: wire}
+		switch tag & 7 {
+		case 0:
+			_, m := binary.Uvarint(data[i:])
+			if m <= 0 {
+				return fields
+			}
+			i += m
+		case 1:
+			i += 8
+		case 5:
+			i += 4
+		case 2:
+			length, m := binary.Uvarint(data[i:])
+			if m <= 0 || length > uint64(len(data)) {
+				return fields
+			}
+			field.Length = int(length)
+			i += m + int(length)
+		}
+		fields = append(fields, field)
+	}
+	return fields
+}
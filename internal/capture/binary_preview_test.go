@@ -0,0 +1,93 @@
+// Purpose: Tests binary response previews: image dimensions, protobuf field sketches, WASM section lists, and ingest wiring.
+// Docs: docs/features/feature/binary-format-detection/index.md
+
+package capture
+
+import (
+	"bytes"
+	"encoding/base64"
+	"testing"
+)
+
+func pngHeader(width, height byte) []byte {
+	return []byte{
+		0x89, 'P', 'N', 'G', '\r', '\n', 0x1a, '\n',
+		0, 0, 0, 13, 'I', 'H', 'D', 'R',
+		0, 0, 0, width, 0, 0, 0, height,
+		8, 6, 0, 0, 0,
+	}
+}
+
+func TestBuildBinaryPreview_ImageDimensions(t *testing.T) {
+	t.Parallel()
+	jpeg := []byte{0xFF, 0xD8, 0xFF, 0xE0, 0, 4, 0, 0, 0xFF, 0xC0, 0, 17, 8, 0, 0x20, 0, 0x40, 3}
+	gif := []byte("GIF89a\x10\x00\x08\x00")
+	for _, tc := range []struct {
+		name   string
+		data   []byte
+		format string
+		w, h   int
+	}{
+		{"png", pngHeader(64, 32), "png", 64, 32},
+		{"jpeg", jpeg, "jpeg", 64, 32},
+		{"gif", gif, "gif", 16, 8},
+	} {
+		p := buildBinaryPreview(tc.data, "image/"+tc.format, "")
+		if p.Kind != "image" || p.Format != tc.format || p.Width != tc.w || p.Height != tc.h {
+			t.Errorf("%s preview = %+v, want %dx%d", tc.name, p, tc.w, tc.h)
+		}
+	}
+}
+
+func TestBuildBinaryPreview_WasmSectionsAndProtobufFields(t *testing.T) {
+	t.Parallel()
+	wasm := []byte{0, 'a', 's', 'm', 1, 0, 0, 0, 1, 2, 0xAA, 0xBB, 3, 1, 0, 10, 200, 1}
+	p := buildBinaryPreview(wasm, "application/wasm", "")
+	if p.Kind != "wasm" || len(p.Sections) != 3 {
+		t.Fatalf("wasm preview = %+v", p)
+	}
+	if p.Sections[0].Name != "type" || p.Sections[1].Name != "function" || p.Sections[2].Name != "code" || p.Sections[2].Size != 200 {
+		t.Fatalf("sections = %+v, want type, function, then code cut off at 200 bytes", p.Sections)
+	}
+
+	// field 1 varint 150, field 2 bytes "hi", field 3 fixed32
+	proto := []byte{0x08, 0x96, 0x01, 0x12, 0x02, 'h', 'i', 0x1D, 1, 2, 3, 4}
+	p = buildBinaryPreview(proto, "application/x-protobuf", "")
+	if p.Kind != "protobuf" || len(p.Fields) != 3 {
+		t.Fatalf("protobuf preview = %+v", p)
+	}
+	if p.Fields[1].Number != 2 || p.Fields[1].WireType != "bytes" || p.Fields[1].Length != 2 || p.Fields[2].WireType != "fixed32" {
+		t.Fatalf("fields = %+v", p.Fields)
+	}
+	if p.HexHead != "08960112026869"+"1d01020304" {
+		t.Fatalf("hex_head = %q", p.HexHead)
+	}
+}
+
+func TestAddNetworkBodies_AttachesBinaryPreview(t *testing.T) {
+	t.Parallel()
+	c := NewCapture()
+	data := pngHeader(10, 20)
+	encoded := base64.StdEncoding.EncodeToString(data)
+	c.AddNetworkBodies([]NetworkBody{
+		{URL: "https://a.test/logo.png", Status: 200, ContentType: "image/png", ResponseBody: encoded, ResponseEncoding: "base64", ResponseSize: 4096, ResponseTruncated: true},
+		{URL: "https://a.test/api", Status: 200, ContentType: "application/json", ResponseBody: `{"ok":true}`},
+	})
+
+	bodies := c.GetNetworkBodies()
+	p := bodies[0].BinaryPreview
+	if p == nil || p.Kind != "image" || p.Width != 10 || p.Height != 20 || p.CapturedBytes != len(data) {
+		t.Fatalf("preview = %+v", p)
+	}
+	if bodies[0].ResponseBody != encoded || bodies[0].ResponseSize != 4096 {
+		t.Fatal("the captured base64 body and full size must be kept")
+	}
+	if bodies[1].BinaryPreview != nil {
+		t.Fatal("text bodies get no preview")
+	}
+
+	// A body cut mid-quantum by the inline limit still decodes its complete groups.
+	if got := DecodeBinaryBody(encoded[:len(encoded)-3]); len(got) == 0 || !bytes.HasPrefix(data, got) {
+		t.Fatalf("decoded %x from a cut body", got)
+	}
+}
@@ -0,0 +1,148 @@
+// Purpose: Holds network request/response bodies gzip-compressed in the ring and inflates them on read.
+// Why: Large JSON responses dominated the 8MB network-body budget; compressed they take a fraction of it.
+// Docs: docs/features/feature/memory-enforcement/index.md
+
+package capture
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+// minCompressBodyBytes is the smallest body worth compressing; gzip framing eats the gain below it.
+const minCompressBodyBytes = 512
+
+// gzipWriters reuses compressors across ingests; BestSpeed keeps the work under Capture.mu short.
+var gzipWriters = sync.Pool{New: func() any {
+	w, _ := gzip.NewWriterLevel(nil, gzip.BestSpeed)
+	return w
+}}
+
+// packedBody is one request or response body as the ring holds it: gzip bytes when that
+// saved memory, otherwise the raw string.
+type packedBody struct {
+	raw  string
+	gz   []byte
+	size int // uncompressed length
+}
+
+// packBody compresses s when it is large enough and compression actually shrinks it.
+func packBody(s string) packedBody {
+	if len(s) < minCompressBodyBytes {
+		return packedBody{raw: s, size: len(s)}
+	}
+	gz, ok := gzipString(s)
+	if !ok || len(gz) >= len(s) {
+		return packedBody{raw: s, size: len(s)}
+	}
+	return packedBody{gz: gz, size: len(s)}
+}
+
+// stored returns the bytes the body occupies in memory.
+func (p packedBody) stored() int64 {
+	if p.gz != nil {
+		return int64(len(p.gz))
+	}
+	return int64(len(p.raw))
+}
+
+// unpack returns the original body, inflating it if it was compressed.
+func (p packedBody) unpack() string {
+	if p.gz == nil {
+		return p.raw
+	}
+	r, err := gzip.NewReader(bytes.NewReader(p.gz))
+	if err != nil {
+		return ""
+	}
+	var out strings.Builder
+	out.Grow(p.size)
+	if _, err := io.Copy(&out, r); err != nil {
+		return ""
+	}
+	return out.String()
+}
+
+func gzipString(s string) ([]byte, bool) {
+	w := gzipWriters.Get().(*gzip.Writer)
+	defer gzipWriters.Put(w)
+	var buf bytes.Buffer
+	w.Reset(&buf)
+	if _, err := io.WriteString(w, s); err != nil {
+		return nil, false
+	}
+	if err := w.Close(); err != nil {
+		return nil, false
+	}
+	// Clone so the entry does not pin the buffer's spare capacity.
+	return bytes.Clone(buf.Bytes()), true
+}
+
+// newNetworkBodyEntry moves the bodies of b into packed storage.
+func newNetworkBodyEntry(b NetworkBody, now time.Time) networkBodyEntry {
+	entry := networkBodyEntry{Body: b, AddedAt: now, request: packBody(b.RequestBody), response: packBody(b.ResponseBody)}
+	entry.Body.RequestBody = ""
+	entry.Body.ResponseBody = ""
+	return entry
+}
+
+// body returns the entry with its bodies restored. Entries built without packing keep their
+// bodies inline in Body, so the packed fields are only applied when set.
+func (e *networkBodyEntry) body() NetworkBody {
+	b := e.Body
+	if e.request.size > 0 {
+		b.RequestBody = e.request.unpack()
+	}
+	if e.response.size > 0 {
+		b.ResponseBody = e.response.unpack()
+	}
+	return b
+}
+
+// payloadStored returns the memory held by the entry's bodies.
+func (e *networkBodyEntry) payloadStored() int64 {
+	return e.request.stored() + e.response.stored() + int64(len(e.Body.RequestBody)+len(e.Body.ResponseBody))
+}
+
+// payloadRaw returns the uncompressed length of the entry's bodies.
+func (e *networkBodyEntry) payloadRaw() int64 {
+	return int64(e.request.size+e.response.size) + int64(len(e.Body.RequestBody)+len(e.Body.ResponseBody))
+}
+
+// clearPayload drops the entry's bodies.
+func (e *networkBodyEntry) clearPayload() {
+	e.request = packedBody{}
+	e.response = packedBody{}
+	e.Body.RequestBody = ""
+	e.Body.ResponseBody = ""
+}
+
+// NetworkBodyStorage reports how the network body buffer holds its bodies. Byte counts are the
+// same estimates the memory cap uses: RawBytes as if uncompressed, StoredBytes as actually held.
+type NetworkBodyStorage struct {
+	Entries           int   `json:"entries"`
+	CompressedEntries int   `json:"compressed_entries"`
+	RawBytes          int64 `json:"raw_bytes"`
+	StoredBytes       int64 `json:"stored_bytes"`
+}
+
+// GetNetworkBodyStorage returns raw and compressed sizes of the network body buffer.
+func (c *Capture) GetNetworkBodyStorage() NetworkBodyStorage {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	stats := NetworkBodyStorage{
+		Entries:     len(c.buffers.networkBodies),
+		RawBytes:    c.buffers.networkBodyRawTotal,
+		StoredBytes: c.buffers.networkBodyMemoryTotal,
+	}
+	for i := range c.buffers.networkBodies {
+		if c.buffers.networkBodies[i].request.gz != nil || c.buffers.networkBodies[i].response.gz != nil {
+			stats.CompressedEntries++
+		}
+	}
+	return stats
+}
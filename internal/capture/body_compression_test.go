@@ -0,0 +1,58 @@
+// Purpose: Tests gzip body storage in the network ring: lossless reads, size reporting, and the skip rules.
+// Docs: docs/features/feature/memory-enforcement/index.md
+
+package capture
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestBodyCompression_RoundTripsAndReportsSizes(t *testing.T) {
+	var sb strings.Builder
+	sb.WriteString("[")
+	for i := 0; i < 150; i++ {
+		fmt.Fprintf(&sb, `{"id":%d,"name":"user-%d","email":"user%d@example.test","active":true},`, i, i, i)
+	}
+	response := strings.TrimSuffix(sb.String(), ",") + "]"
+	if len(response) > maxResponseBodySize {
+		t.Fatalf("fixture is %d bytes; keep it inline", len(response))
+	}
+
+	c := NewCapture()
+	c.AddNetworkBodies([]NetworkBody{
+		{URL: "https://a.test/users", Status: 200, RequestBody: `{"page":1}`, ResponseBody: response},
+		{URL: "https://a.test/random", Status: 200, ResponseBody: incompressible(4000)},
+	})
+
+	bodies := c.GetNetworkBodies()
+	if bodies[0].ResponseBody != response || bodies[0].RequestBody != `{"page":1}` {
+		t.Fatal("compressed body must read back byte-for-byte")
+	}
+	if len(bodies[1].ResponseBody) != 4000 {
+		t.Fatalf("incompressible body length = %d", len(bodies[1].ResponseBody))
+	}
+
+	stats := c.GetNetworkBodyStorage()
+	if stats.Entries != 2 || stats.CompressedEntries != 1 {
+		t.Fatalf("stats = %+v, want only the JSON body compressed", stats)
+	}
+	if stats.StoredBytes != c.GetNetworkBodiesBufferMemory() || stats.StoredBytes >= stats.RawBytes {
+		t.Fatalf("stats = %+v, want stored bytes below raw", stats)
+	}
+	jsonRaw := int64(len(response) + len(`{"page":1}`))
+	if saved := stats.RawBytes - stats.StoredBytes; saved < jsonRaw*2/3 {
+		t.Fatalf("saved %d of %d JSON bytes, want at least 3x compression", saved, jsonRaw)
+	}
+}
+
+func TestBodyCompression_SkipsSmallBodies(t *testing.T) {
+	p := packBody(strings.Repeat("a", minCompressBodyBytes-1))
+	if p.gz != nil || p.stored() != int64(minCompressBodyBytes-1) {
+		t.Fatalf("small body packed = %+v", p)
+	}
+	if p := packBody(strings.Repeat("a", minCompressBodyBytes)); p.gz == nil || p.unpack() != strings.Repeat("a", minCompressBodyBytes) {
+		t.Fatal("repetitive body at the threshold should compress and round-trip")
+	}
+}
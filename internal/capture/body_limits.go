@@ -0,0 +1,165 @@
+// Purpose: Applies configurable inline limits to network bodies and keeps the overflow in a bounded full-body store.
+// Why: Fixed 8KB/16KB truncation discarded payloads for good; agents now see a short body inline and fetch the rest by request ID.
+// Docs: docs/features/feature/full-body-fetch/index.md
+
+package capture
+
+import (
+	"fmt"
+	"strconv"
+	"unicode/utf8"
+)
+
+// BodyLimits bounds captured request and response bodies.
+type BodyLimits struct {
+	RequestMax  int `json:"request_body_max"`   // bytes of a request body kept inline
+	ResponseMax int `json:"response_body_max"`  // bytes of a response body kept inline
+	CaptureMax  int `json:"capture_body_max"`   // characters per body the extension sends; past the inline limits the rest goes to the full-body store
+	BinaryMax   int `json:"binary_capture_max"` // bytes of a binary response the extension sends base64-encoded; 0 keeps only a size placeholder
+}
+
+// DefaultBodyLimits returns the limits a daemon starts with.
+func DefaultBodyLimits() BodyLimits {
+	return BodyLimits{RequestMax: maxRequestBodySize, ResponseMax: maxResponseBodySize, CaptureMax: defaultBodyCaptureMax}
+}
+
+// Validate reports the first out-of-range limit.
+func (l BodyLimits) Validate() error {
+	switch {
+	case l.RequestMax < 1 || l.RequestMax > maxBodyCaptureMax:
+		return fmt.Errorf("request_body_max must be between 1 and %d", maxBodyCaptureMax)
+	case l.ResponseMax < 1 || l.ResponseMax > maxBodyCaptureMax:
+		return fmt.Errorf("response_body_max must be between 1 and %d", maxBodyCaptureMax)
+	case l.CaptureMax < 1 || l.CaptureMax > maxBodyCaptureMax:
+		return fmt.Errorf("capture_body_max must be between 1 and %d", maxBodyCaptureMax)
+	case l.BinaryMax < 0 || l.BinaryMax > maxBinaryCaptureMax:
+		return fmt.Errorf("binary_capture_max must be between 0 and %d", maxBinaryCaptureMax)
+	}
+	return nil
+}
+
+// FullBody is the complete payload of one request, kept past the inline limits.
+type FullBody struct {
+	RequestID    string `json:"request_id"`
+	Method       string `json:"method"`
+	URL          string `json:"url"`
+	Status       int    `json:"status"`
+	RequestBody  string `json:"request_body,omitempty"`
+	ResponseBody string `json:"response_body,omitempty"`
+	// Complete is false when the extension already cut a body at capture_body_max.
+	Complete bool `json:"complete"`
+}
+
+// fullBodyStore holds spilled bodies by request ID, evicting the oldest past fullBodyMemoryLimit.
+// Protected by Capture.mu. seq survives clears so request IDs are never reused.
+type fullBodyStore struct {
+	seq     int64
+	entries map[string]FullBody
+	order   []string
+	memory  int64
+}
+
+func (s *fullBodyStore) nextRequestID() string {
+	s.seq++
+	return "req-" + strconv.FormatInt(s.seq, 10)
+}
+
+func (s *fullBodyStore) put(body FullBody) {
+	if s.entries == nil {
+		s.entries = make(map[string]FullBody)
+	}
+	s.entries[body.RequestID] = body
+	s.order = append(s.order, body.RequestID)
+	s.memory += fullBodyMemory(body)
+	for s.memory > fullBodyMemoryLimit && len(s.order) > 1 {
+		oldest := s.order[0]
+		s.order = s.order[1:]
+		s.memory -= fullBodyMemory(s.entries[oldest])
+		delete(s.entries, oldest)
+	}
+}
+
+func (s *fullBodyStore) clear() {
+	s.entries = nil
+	s.order = nil
+	s.memory = 0
+}
+
+func fullBodyMemory(b FullBody) int64 {
+	return int64(len(b.RequestBody) + len(b.ResponseBody) + len(b.URL))
+}
+
+// SetBodyLimits replaces the body limits. The capture ceiling reaches the extension on its next sync.
+func (c *Capture) SetBodyLimits(limits BodyLimits) error {
+	if err := limits.Validate(); err != nil {
+		return err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.bodyLimits = limits
+	return nil
+}
+
+// GetBodyLimits returns the current body limits.
+func (c *Capture) GetBodyLimits() BodyLimits {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.bodyLimits
+}
+
+// GetFullBody returns the complete body spilled for requestID, if it is still retained.
+func (c *Capture) GetFullBody(requestID string) (FullBody, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	body, ok := c.fullBodies.entries[requestID]
+	return body, ok
+}
+
+// assignRequestIDAndSpill gives body a request ID and, when a body exceeds its inline limit,
+// keeps the complete payload in the full-body store and truncates the inline copy.
+// Caller must hold c.mu.
+func (c *Capture) assignRequestIDAndSpill(body *NetworkBody) {
+	body.RequestID = c.fullBodies.nextRequestID()
+	limits := c.bodyLimits
+	if len(body.RequestBody) <= limits.RequestMax && len(body.ResponseBody) <= limits.ResponseMax {
+		return
+	}
+	c.fullBodies.put(FullBody{
+		RequestID:    body.RequestID,
+		Method:       body.Method,
+		URL:          body.URL,
+		Status:       body.Status,
+		RequestBody:  body.RequestBody,
+		ResponseBody: body.ResponseBody,
+		Complete:     !body.RequestTruncated && !body.ResponseTruncated,
+	})
+	if len(body.RequestBody) > limits.RequestMax {
+		body.RequestBody = cutBody(body.RequestBody, limits.RequestMax)
+		body.RequestTruncated = true
+	}
+	if len(body.ResponseBody) > limits.ResponseMax {
+		body.ResponseBody = cutBody(body.ResponseBody, limits.ResponseMax)
+		body.ResponseTruncated = true
+	}
+	body.FullBodyRef = body.RequestID
+}
+
+// cutBody truncates s to at most n bytes without splitting a UTF-8 sequence.
+func cutBody(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
+
+// networkBodyPostLimit bounds POST /network-bodies. A full batch at the capture ceiling must
+// fit; three bytes per character covers UTF-8 expansion of the extension's UTF-16 lengths,
+// and two bytes per captured byte covers base64 binary responses.
+func (c *Capture) networkBodyPostLimit() int64 {
+	limits := c.GetBodyLimits()
+	perBody := max(3*int64(limits.CaptureMax), 2*int64(limits.BinaryMax))
+	return maxExtensionPostBody + int64(networkBodyBatchMax)*2*perBody
+}
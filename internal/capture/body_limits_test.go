@@ -0,0 +1,102 @@
+// Purpose: Tests inline body limits, spilling to the full-body store, eviction, and the capture_body_max sync override.
+// Docs: docs/features/feature/full-body-fetch/index.md
+
+package capture
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAddNetworkBodies_SpillsPastInlineLimits(t *testing.T) {
+	c := NewCapture()
+	if err := c.SetBodyLimits(BodyLimits{RequestMax: 4, ResponseMax: 6, CaptureMax: defaultBodyCaptureMax}); err != nil {
+		t.Fatal(err)
+	}
+	c.AddNetworkBodies([]NetworkBody{
+		{Method: "POST", URL: "https://a.test/big", Status: 200, RequestBody: "abcdefgh", ResponseBody: "héllo wörld"},
+		{Method: "GET", URL: "https://a.test/small", Status: 200, ResponseBody: "ok"},
+	})
+
+	bodies := c.GetNetworkBodies()
+	big, small := bodies[0], bodies[1]
+	if big.RequestID == "" || big.FullBodyRef != big.RequestID || !big.RequestTruncated || !big.ResponseTruncated {
+		t.Fatalf("big entry = %+v, want truncated with a full_body_ref", big)
+	}
+	if big.RequestBody != "abcd" || big.ResponseBody != "héllo" {
+		t.Fatalf("inline bodies = %q / %q, want cut on rune boundaries", big.RequestBody, big.ResponseBody)
+	}
+	if small.RequestID == "" || small.RequestID == big.RequestID || small.FullBodyRef != "" {
+		t.Fatalf("small entry = %+v, want its own ID and no ref", small)
+	}
+
+	full, ok := c.GetFullBody(big.FullBodyRef)
+	if !ok || full.RequestBody != "abcdefgh" || full.ResponseBody != "héllo wörld" || !full.Complete {
+		t.Fatalf("full body = %+v, %v", full, ok)
+	}
+	if _, ok := c.GetFullBody(small.RequestID); ok {
+		t.Fatal("a body within its limits should not be spilled")
+	}
+
+	c.ClearNetworkBuffers()
+	if _, ok := c.GetFullBody(big.FullBodyRef); ok {
+		t.Fatal("clearing network buffers should drop full bodies")
+	}
+	c.AddNetworkBodies([]NetworkBody{{URL: "https://a.test/after"}})
+	if id := c.GetNetworkBodies()[0].RequestID; id == big.RequestID || id == small.RequestID {
+		t.Fatalf("request ID %q reused after clear", id)
+	}
+}
+
+func TestFullBodyStore_EvictsOldestPastMemoryLimit(t *testing.T) {
+	var s fullBodyStore
+	chunk := strings.Repeat("x", fullBodyMemoryLimit/2)
+	for i := 0; i < 3; i++ {
+		s.put(FullBody{RequestID: s.nextRequestID(), ResponseBody: chunk})
+	}
+	if _, ok := s.entries["req-1"]; ok || len(s.order) != 2 || s.memory > fullBodyMemoryLimit {
+		t.Fatalf("store kept %v (%d bytes), want the oldest evicted", s.order, s.memory)
+	}
+}
+
+func TestBodyLimits_ValidateAndSyncOverride(t *testing.T) {
+	c := NewCapture()
+	for _, bad := range []BodyLimits{
+		{RequestMax: 0, ResponseMax: 1, CaptureMax: 1},
+		{RequestMax: 1, ResponseMax: maxBodyCaptureMax + 1, CaptureMax: 1},
+		{RequestMax: 1, ResponseMax: 1, CaptureMax: -1},
+		{RequestMax: 1, ResponseMax: 1, CaptureMax: 1, BinaryMax: maxBinaryCaptureMax + 1},
+	} {
+		if err := c.SetBodyLimits(bad); err == nil {
+			t.Fatalf("%+v should be rejected", bad)
+		}
+	}
+	if c.GetBodyLimits() != DefaultBodyLimits() {
+		t.Fatal("rejected limits must not be applied")
+	}
+	if _, ok := c.buildCaptureOverrides()["body_capture_max"]; ok {
+		t.Fatal("default capture ceiling should not be sent as an override")
+	}
+
+	limits := DefaultBodyLimits()
+	limits.CaptureMax = 200000
+	if err := c.SetBodyLimits(limits); err != nil {
+		t.Fatal(err)
+	}
+	if got := c.buildCaptureOverrides()["body_capture_max"]; got != "200000" {
+		t.Fatalf("body_capture_max override = %q", got)
+	}
+	if c.networkBodyPostLimit() <= maxExtensionPostBody {
+		t.Fatal("POST limit should grow with the capture ceiling")
+	}
+	if _, ok := c.buildCaptureOverrides()["binary_capture_max"]; ok {
+		t.Fatal("binary capture is off by default and should not be sent")
+	}
+	limits.BinaryMax = 4096
+	if err := c.SetBodyLimits(limits); err != nil {
+		t.Fatal(err)
+	}
+	if got := c.buildCaptureOverrides()["binary_capture_max"]; got != "4096" {
+		t.Fatalf("binary_capture_max override = %q", got)
+	}
+}
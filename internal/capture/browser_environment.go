@@ -0,0 +1,30 @@
+// Purpose: Holds the browser metadata (UA, viewport, DPR, locale, timezone, extension version) the extension reports on /sync.
+// Why: Bugs that only show on one viewport, locale, or timezone need that context in reports, generated tests, and reproductions.
+// Docs: docs/features/feature/browser-environment/index.md
+
+package capture
+
+import "time"
+
+// BrowserEnvironment is the tracked page's view of the browser. Viewport fields are zero
+// when the page could not be scripted and the extension reported its own view instead.
+type BrowserEnvironment struct {
+	UserAgent        string  `json:"user_agent"`
+	Locale           string  `json:"locale"`
+	Timezone         string  `json:"timezone"`
+	ViewportWidth    int     `json:"viewport_width,omitempty"`
+	ViewportHeight   int     `json:"viewport_height,omitempty"`
+	DevicePixelRatio float64 `json:"device_pixel_ratio,omitempty"`
+	ExtensionVersion string  `json:"extension_version"`
+}
+
+// GetBrowserEnvironment returns the last reported environment and when it arrived.
+// ok is false until the extension has reported one.
+func (c *Capture) GetBrowserEnvironment() (env BrowserEnvironment, updated time.Time, ok bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.extensionState.environmentUpdated.IsZero() {
+		return BrowserEnvironment{}, time.Time{}, false
+	}
+	return c.extensionState.environment, c.extensionState.environmentUpdated, true
+}
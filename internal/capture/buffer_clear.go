@@ -30,6 +30,7 @@ func (c *Capture) ClearNetworkBuffers() BufferClearCounts {
 
 	// Clear network bodies buffer and reset memory tracking
 	c.buffers.clearNetworkBuffers()
+	c.fullBodies.clear()
 
 	return counts
 }
@@ -92,10 +93,15 @@ func (c *Capture) ClearAll() {
 	defer c.mu.Unlock()
 
 	c.buffers.clearAllEventBuffers()
+	c.fullBodies.clear()
 	c.networkWaterfall.clear()
 	c.wsConnections.clear()
 	c.extensionState.activeTestIDs = make(map[string]bool)
+	c.extensionState.boundaries = nil
 
 	// Reset performance data
 	c.perf.clear()
+
+	// Coverage aggregates describe the page state being cleared (leaf lock, safe under c.mu)
+	c.coverage.Clear()
 }
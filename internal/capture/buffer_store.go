@@ -13,10 +13,13 @@ type wsEventEntry struct {
 	AddedAt time.Time
 }
 
-// networkBodyEntry bundles a NetworkBody with its ingestion timestamp.
+// networkBodyEntry bundles a NetworkBody with its ingestion timestamp. Entries made by
+// newNetworkBodyEntry hold the bodies in request/response (see body_compression.go).
 type networkBodyEntry struct {
-	Body    NetworkBody
-	AddedAt time.Time
+	Body     NetworkBody
+	AddedAt  time.Time
+	request  packedBody
+	response packedBody
 }
 
 // enhancedActionEntry bundles an EnhancedAction with its ingestion timestamp.
@@ -37,11 +40,22 @@ type BufferStore struct {
 	networkBodies          []networkBodyEntry
 	networkTotalAdded      int64
 	networkErrorTotalAdded int64
-	networkBodyMemoryTotal int64
+	networkBodyMemoryTotal int64 // as stored, bodies compressed
+	networkBodyRawTotal    int64 // as if every body were uncompressed
 
 	// Enhanced action buffer state.
 	enhancedActions  []enhancedActionEntry
 	actionTotalAdded int64
+
+	// Server-Sent Events buffer state (see sse.go).
+	sseEvents      []sseEventEntry
+	sseTotalAdded  int64
+	sseMemoryTotal int64
+
+	// Retention limits per buffer (see retention.go).
+	wsLimit      Retention
+	networkLimit Retention
+	actionLimit  Retention
 }
 
 func newBufferStore() BufferStore {
@@ -49,6 +63,10 @@ func newBufferStore() BufferStore {
 		wsEvents:        make([]wsEventEntry, 0, MaxWSEvents),
 		networkBodies:   make([]networkBodyEntry, 0, MaxNetworkBodies),
 		enhancedActions: make([]enhancedActionEntry, 0, MaxEnhancedActions),
+		sseEvents:       make([]sseEventEntry, 0, MaxSSEEvents),
+		wsLimit:         Retention{MaxEntries: MaxWSEvents},
+		networkLimit:    Retention{MaxEntries: MaxNetworkBodies},
+		actionLimit:     Retention{MaxEntries: MaxEnhancedActions},
 	}
 }
 
@@ -91,7 +109,7 @@ func (s *BufferStore) networkBodiesCopy() []NetworkBody {
 	}
 	out := make([]NetworkBody, len(s.networkBodies))
 	for i := range s.networkBodies {
-		out[i] = s.networkBodies[i].Body
+		out[i] = s.networkBodies[i].body()
 	}
 	return out
 }
@@ -123,6 +141,7 @@ func (s *BufferStore) clearNetworkBuffers() {
 	s.networkTotalAdded = 0
 	s.networkErrorTotalAdded = 0
 	s.networkBodyMemoryTotal = 0
+	s.networkBodyRawTotal = 0
 }
 
 func (s *BufferStore) clearWebSocketBuffers() {
@@ -140,6 +159,7 @@ func (s *BufferStore) clearAllEventBuffers() {
 	s.clearNetworkBuffers()
 	s.clearWebSocketBuffers()
 	s.clearActionBuffers()
+	s.clearSSEBuffers()
 }
 
 func (s *BufferStore) appendEnhancedActions(actions []EnhancedAction, now time.Time) bool {
@@ -154,12 +174,8 @@ func (s *BufferStore) appendEnhancedActions(actions []EnhancedAction, now time.T
 			hasNavigation = true
 		}
 	}
-	if len(s.enhancedActions) > MaxEnhancedActions {
-		keep := len(s.enhancedActions) - MaxEnhancedActions
-		newEntries := make([]enhancedActionEntry, MaxEnhancedActions)
-		copy(newEntries, s.enhancedActions[keep:])
-		s.enhancedActions = newEntries
-	}
+	s.evictActionsByCount()
+	s.expireActions(now)
 	return hasNavigation
 }
 
@@ -171,24 +187,22 @@ func (s *BufferStore) appendNetworkBodies(bodies []NetworkBody, testIDs []string
 		}
 		bodies[i].TestIDs = testIDs
 		detectAndSetBinaryFormat(&bodies[i])
-		s.networkBodies = append(s.networkBodies, networkBodyEntry{
-			Body:    bodies[i],
-			AddedAt: now,
-		})
-		s.networkBodyMemoryTotal += nbEntryMemory(&bodies[i])
+		detectAuthHeader(&bodies[i])
+		entry := newNetworkBodyEntry(bodies[i], now)
+		s.networkBodyMemoryTotal += nbEntryMemory(&entry)
+		s.networkBodyRawTotal += nbEntryRawMemory(&entry)
+		s.networkBodies = append(s.networkBodies, entry)
 	}
 	s.evictNetworkByCount()
 	s.evictNetworkForMemory()
+	s.expireNetwork(now)
 }
 
-func (s *BufferStore) appendWebSocketEvents(events []WebSocketEvent, testIDs []string, now time.Time, onEvent func(WebSocketEvent)) {
+func (s *BufferStore) appendWebSocketEvents(events []WebSocketEvent, testIDs []string, now time.Time) {
 	s.wsTotalAdded += int64(len(events))
 	for i := range events {
 		events[i].TestIDs = testIDs
 		detectWSBinaryFormat(&events[i])
-		if onEvent != nil {
-			onEvent(events[i])
-		}
 		s.wsEvents = append(s.wsEvents, wsEventEntry{
 			Event:   events[i],
 			AddedAt: now,
@@ -197,31 +211,38 @@ func (s *BufferStore) appendWebSocketEvents(events []WebSocketEvent, testIDs []s
 	}
 	s.evictWebSocketByCount()
 	s.evictWebSocketForMemory()
+	s.expireWebSocket(now)
 }
 
 func (s *BufferStore) evictNetworkByCount() {
-	if len(s.networkBodies) <= MaxNetworkBodies {
+	if len(s.networkBodies) <= s.networkLimit.MaxEntries {
 		return
 	}
-	keep := len(s.networkBodies) - MaxNetworkBodies
-	for j := 0; j < keep; j++ {
-		s.networkBodyMemoryTotal -= nbEntryMemory(&s.networkBodies[j].Body)
-	}
-	newEntries := make([]networkBodyEntry, MaxNetworkBodies)
-	copy(newEntries, s.networkBodies[keep:])
-	s.networkBodies = newEntries
+	s.dropNetworkHead(len(s.networkBodies) - s.networkLimit.MaxEntries)
 }
 
+// evictNetworkForMemory enforces the buffer's memory cap. The largest success bodies are shed
+// first, then error bodies; whole entries are dropped oldest-first only if that is not enough.
 func (s *BufferStore) evictNetworkForMemory() {
 	excess := s.networkBodyMemoryTotal - nbBufferMemoryLimit
 	if excess <= 0 {
 		return
 	}
+	for _, errors := range []bool{false, true} {
+		if excess > 0 {
+			_, freed := s.shedNetworkPayloads(excess, errors)
+			excess -= freed
+		}
+	}
+	if excess <= 0 {
+		return
+	}
 	drop := 0
 	for drop < len(s.networkBodies) && excess > 0 {
-		entryMem := nbEntryMemory(&s.networkBodies[drop].Body)
+		entryMem := nbEntryMemory(&s.networkBodies[drop])
 		excess -= entryMem
 		s.networkBodyMemoryTotal -= entryMem
+		s.networkBodyRawTotal -= nbEntryRawMemory(&s.networkBodies[drop])
 		drop++
 	}
 	surviving := make([]networkBodyEntry, len(s.networkBodies)-drop)
@@ -230,23 +251,31 @@ func (s *BufferStore) evictNetworkForMemory() {
 }
 
 func (s *BufferStore) evictWebSocketByCount() {
-	if len(s.wsEvents) <= MaxWSEvents {
+	if len(s.wsEvents) <= s.wsLimit.MaxEntries {
 		return
 	}
-	drop := len(s.wsEvents) - MaxWSEvents
-	for j := 0; j < drop; j++ {
-		s.wsMemoryTotal -= wsEventMemory(&s.wsEvents[j].Event)
+	s.dropWebSocketHead(len(s.wsEvents) - s.wsLimit.MaxEntries)
+}
+
+func (s *BufferStore) evictActionsByCount() {
+	if len(s.enhancedActions) <= s.actionLimit.MaxEntries {
+		return
 	}
-	newEntries := make([]wsEventEntry, MaxWSEvents)
-	copy(newEntries, s.wsEvents[drop:])
-	s.wsEvents = newEntries
+	s.dropActionHead(len(s.enhancedActions) - s.actionLimit.MaxEntries)
 }
 
+// evictWebSocketForMemory enforces the buffer's memory cap, shedding the largest payloads
+// before dropping whole events oldest-first.
 func (s *BufferStore) evictWebSocketForMemory() {
 	excess := s.wsMemoryTotal - wsBufferMemoryLimit
 	if excess <= 0 {
 		return
 	}
+	_, freed := s.shedWebSocketPayloads(excess)
+	excess -= freed
+	if excess <= 0 {
+		return
+	}
 	drop := 0
 	for drop < len(s.wsEvents) && excess > 0 {
 		entryMem := wsEventMemory(&s.wsEvents[drop].Event)
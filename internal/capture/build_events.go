@@ -0,0 +1,12 @@
+// Purpose: Exposes the Capture-owned dev-server build event store.
+// Why: POST /build-events and captured HMR sockets write here; observe(what="timeline") reads it to label errors by bundle.
+// Docs: docs/features/feature/build-events/index.md
+
+package capture
+
+import "github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/buildevents"
+
+// BuildEvents returns the build event store. Store has its own lock.
+func (c *Capture) BuildEvents() *buildevents.Store {
+	return c.buildEvents
+}
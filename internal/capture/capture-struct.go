@@ -8,8 +8,14 @@ import (
 	"sync"
 	"time"
 
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/buildevents"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/changefeed"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/coverage"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/filechanges"
 	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/performance"
 	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/redaction"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/testevents"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/wsdecode"
 )
 
 // Capture manages all buffered browser state: WebSocket events, network bodies,
@@ -94,6 +100,26 @@ type Capture struct {
 	perf    PerformanceStore // Performance snapshots and baselines. Protected by parent mu (no separate lock).
 	session SessionTracker   // Session-level performance aggregation. Protected by parent mu (no separate lock).
 
+	bodyLimits BodyLimits    // Inline and capture limits for network bodies. Protected by parent mu (no separate lock).
+	fullBodies fullBodyStore // Complete bodies spilled past the inline limits, by request ID. Protected by parent mu (no separate lock).
+
+	memoryLimits memoryPressureLimits // Total-memory thresholds for priority eviction (see memory_pressure.go). Protected by parent mu (no separate lock).
+
+	wsSampling []WSSamplingPolicy // Per-connection WebSocket sampling policies, first match wins. Protected by parent mu (no separate lock).
+	priorityWS priorityDedup      // WebSocket events already kept from rejected batches. Protected by parent mu (no separate lock).
+
+	screenshotRedactSelectors []string // CSS selectors blacked out on every screenshot. Protected by parent mu (no separate lock).
+
+	requestMocks   []RequestMock // Stubbed responses the extension serves for matching requests. Protected by parent mu (no separate lock).
+	requestMockSeq int           // Last assigned request mock id. Protected by parent mu (no separate lock).
+
+	multiTabCapture bool // Capture from every tab, not just the tracked one. Protected by parent mu (no separate lock).
+
+	deviceEmulation *DeviceEmulation // Device applied by interact(emulate); nil when not emulating. Protected by parent mu (no separate lock).
+	regionOverride  *RegionOverride  // Geolocation/timezone/locale set by configure(override); nil when none. Protected by parent mu (no separate lock).
+
+	storageBaselines map[string]StorageSnapshot // Storage fingerprints by test ID for observe(storage) change tracking. Protected by parent mu (no separate lock).
+
 	// ============================================
 	// Multi-Client Support
 	// ============================================
@@ -104,9 +130,48 @@ type Capture struct {
 	// Lifecycle Event Callbacks
 	// ============================================
 
-	lifecycle          *LifecycleObserver // Typed event bus for lifecycle events (circuit breaker, extension state, buffer overflow). Has own lock — independent of Capture.mu. Delegates to internal/lifecycle.
-	navigationCallback func()             // Optional callback fired after a navigation action is ingested (called outside lock)
-	featuresCallback   func(map[string]bool) // Optional callback fired when extension reports feature usage (called outside lock)
+	lifecycle          *LifecycleObserver          // Typed event bus for lifecycle events (circuit breaker, extension state, buffer overflow). Has own lock — independent of Capture.mu. Delegates to internal/lifecycle.
+	navigationCallback func()                      // Optional callback fired after a navigation action is ingested (called outside lock)
+	featuresCallback   func(map[string]bool)       // Optional callback fired when extension reports feature usage (called outside lock)
+	perfCallback       func([]PerformanceSnapshot) // Optional callback fired after performance snapshots are ingested (called outside lock)
+	networkCallback    func(NetworkActivity)       // Optional callback fired after network bodies, waterfall entries, or WebSocket events are ingested (called outside lock)
+	protocolRecorder   ProtocolRecorder            // Optional --record-protocol sink for extension command traffic (called outside lock)
+
+	// ============================================
+	// Change Feed (Own Lock)
+	// ============================================
+
+	changes *changefeed.Feed // Global sequence over console/network/ws/action/alert appends. Has own sync.Mutex (leaf lock) — independent of Capture.mu.
+
+	// ============================================
+	// Test Runner Events (Own Lock)
+	// ============================================
+
+	testEvents *testevents.Store // Test-runner lifecycle events and per-test time segments from POST /test-events. Has own sync.Mutex (leaf lock) — independent of Capture.mu.
+
+	// ============================================
+	// Dev-Server Build Events (Own Lock)
+	// ============================================
+
+	buildEvents *buildevents.Store // Rebuild/HMR events from POST /build-events and captured HMR sockets. Has own sync.Mutex (leaf lock) — independent of Capture.mu.
+
+	// ============================================
+	// Source Edits (Own Lock)
+	// ============================================
+
+	fileChanges *filechanges.Store // Edits from POST /file-changes (kaboom watch, editor hooks). Has own sync.Mutex (leaf lock) — independent of Capture.mu.
+
+	// ============================================
+	// Code Coverage (Own Lock)
+	// ============================================
+
+	coverage *coverage.Store // Per-URL JS/CSS used/unused bytes from POST /coverage-snapshots. Has own sync.Mutex (leaf lock) — independent of Capture.mu.
+
+	// ============================================
+	// WebSocket Decoders (Own Lock)
+	// ============================================
+
+	wsDecoders *wsdecode.Registry // Protobuf descriptor sets from configure(what="register_proto"). Has own sync.Mutex (leaf lock) — independent of Capture.mu.
 
 	// ============================================
 	// Version Information
@@ -141,13 +206,7 @@ func NewCapture() *Capture {
 			pilotSource:             PilotSourceAssumedStartup,
 			securityMode:            SecurityModeNormal,
 		},
-		perf: PerformanceStore{
-			snapshots:       make(map[string]performance.Snapshot),
-			snapshotOrder:   make([]string, 0),
-			baselines:       make(map[string]performance.Baseline),
-			baselineOrder:   make([]string, 0),
-			beforeSnapshots: make(map[string]performance.Snapshot),
-		},
+		perf: newPerformanceStore(),
 		session: SessionTracker{
 			FirstSnapshots: make(map[string]performance.Snapshot),
 		},
@@ -156,14 +215,23 @@ func NewCapture() *Capture {
 			cacheOrder: make([]string, 0),
 			inflight:   make(map[string]*a11yInflightEntry),
 		},
+		bodyLimits:       DefaultBodyLimits(),
+		memoryLimits:     defaultMemoryPressureLimits(),
 		debug:            NewDebugLogger(),
 		recordingManager: NewRecordingManager(),
 
 		logRedactor: redaction.NewRedactionEngine(""),
 		lifecycle:   NewLifecycleObserver(),
+		changes:     changefeed.New(changefeed.DefaultCapacity),
+		testEvents:  testevents.NewStore(),
+		buildEvents: buildevents.NewStore(),
+		fileChanges: filechanges.NewStore(),
+		coverage:    coverage.NewStore(),
+		wsDecoders:  wsdecode.NewRegistry(),
 	}
 	c.queryDispatcher = NewQueryDispatcher()
 	c.circuit = NewCircuitBreaker(c.lifecycle.EmitFunc())
+	c.circuit.SetMemorySource(c.GetCaptureMemory)
 
 	// Note: clientRegistry is initialized by capture.New() in capture package
 	// to avoid circular import (those packages import capture for NetworkBody, WebSocketEvent, etc.)
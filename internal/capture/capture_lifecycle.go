@@ -31,6 +31,34 @@ func (c *Capture) SetFeaturesCallback(cb func(map[string]bool)) {
 	c.featuresCallback = cb
 }
 
+// SetPerformanceCallback sets a callback for ingested performance snapshots.
+// Called from AddPerformanceSnapshots after the Capture lock is released.
+// Used to persist per-route vitals history beyond the in-memory snapshot map.
+func (c *Capture) SetPerformanceCallback(cb func([]PerformanceSnapshot)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.perfCallback = cb
+}
+
+// NetworkActivity is one ingested batch of network telemetry passed to the network callback.
+// Exactly one of Bodies, Waterfall, or WebSocket is populated. PageURL is the waterfall
+// payload's page URL, or the tracked tab URL for bodies and WebSocket events.
+type NetworkActivity struct {
+	PageURL   string
+	Bodies    []NetworkBody
+	Waterfall []NetworkWaterfallEntry
+	WebSocket []WebSocketEvent
+}
+
+// SetNetworkCallback sets a callback for ingested network telemetry.
+// Called from AddNetworkBodies, AddNetworkWaterfallEntries, and AddWebSocketEvents
+// after the Capture lock is released. Used for continuous transport-security monitoring.
+func (c *Capture) SetNetworkCallback(cb func(NetworkActivity)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.networkCallback = cb
+}
+
 // SubscribeLifecycle registers a typed lifecycle event listener and returns a
 // subscription ID for later removal via UnsubscribeLifecycle.
 // Thread-safe; the observer has its own lock independent of Capture.mu.
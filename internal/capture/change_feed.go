@@ -0,0 +1,13 @@
+// Purpose: Exposes the Capture-owned change feed that sequences every buffer append.
+// Why: Network, WebSocket, and action appends happen here; console and alert producers share the same feed.
+// Docs: docs/features/feature/cursor-pagination/index.md
+
+package capture
+
+import "github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/changefeed"
+
+// ChangeFeed returns the global change feed. Console and alert producers outside
+// this package append to it directly; Feed has its own lock.
+func (c *Capture) ChangeFeed() *changefeed.Feed {
+	return c.changes
+}
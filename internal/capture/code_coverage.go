@@ -0,0 +1,12 @@
+// Purpose: Exposes the Capture-owned JS/CSS code coverage store.
+// Why: POST /coverage-snapshots writes here; observe(what="coverage") and generate(what="coverage_report") read it.
+// Docs: docs/features/feature/code-coverage/index.md
+
+package capture
+
+import "github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/coverage"
+
+// Coverage returns the code coverage store. Store has its own lock.
+func (c *Capture) Coverage() *coverage.Store {
+	return c.coverage
+}
@@ -16,6 +16,7 @@ const (
 	MaxNetworkBodies   = 100
 	MaxExtensionLogs   = 500
 	MaxEnhancedActions = 1000
+	MaxSSEEvents       = 500
 
 	// RateLimitThreshold is re-exported from internal/circuit for backward compatibility.
 	RateLimitThreshold = circuit.RateLimitThreshold
@@ -28,14 +29,20 @@ const (
 	MinNetworkWaterfallCapacity     = 100
 	MaxNetworkWaterfallCapacity     = 10000
 
-	defaultWSLimit       = 50
-	defaultBodyLimit     = 20
-	maxExtensionPostBody = 5 << 20         // 5MB - max size for incoming extension POST bodies
-	maxRequestBodySize   = 8192            // 8KB - truncation limit for captured request bodies
-	maxResponseBodySize  = 16384           // 16KB
-	wsBufferMemoryLimit  = 4 * 1024 * 1024 // 4MB
-	nbBufferMemoryLimit  = 8 * 1024 * 1024 // 8MB
-	rateWindow           = 5 * time.Second // rolling window for msg/s calculation
+	defaultWSLimit        = 50
+	defaultBodyLimit      = 20
+	maxExtensionPostBody  = 5 << 20          // 5MB - max size for incoming extension POST bodies
+	maxRequestBodySize    = 8192             // 8KB - default inline limit for captured request bodies
+	maxResponseBodySize   = 16384            // 16KB - default inline limit for captured response bodies
+	defaultBodyCaptureMax = 65536            // 64KB - default per-body capture ceiling sent to the extension
+	maxBodyCaptureMax     = 1 << 20          // 1MB - highest capture ceiling configure(body_limits) accepts
+	maxBinaryCaptureMax   = 256 * 1024       // 256KB - highest binary capture cap configure(body_limits) accepts
+	fullBodyMemoryLimit   = 32 * 1024 * 1024 // 32MB - full bodies kept past the inline limits
+	networkBodyBatchMax   = 50               // mirrors the extension's network body batch size
+	wsBufferMemoryLimit   = 4 * 1024 * 1024  // 4MB
+	sseBufferMemoryLimit  = 2 * 1024 * 1024  // 2MB
+	nbBufferMemoryLimit   = 8 * 1024 * 1024  // 8MB
+	rateWindow            = 5 * time.Second  // rolling window for msg/s calculation
 
 )
 
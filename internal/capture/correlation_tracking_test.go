@@ -64,6 +64,7 @@ func TestCorrelationIDTracking(t *testing.T) {
 func TestCorrelationIDExpiration(t *testing.T) {
 	t.Parallel()
 	capture := NewCapture()
+	_ = capture.SetRetryPolicy(queries.RetryPolicy{Attempts: 1}) // expire on the first missed window
 
 	correlationID := "test_expired_67890"
 	query := queries.PendingQuery{
@@ -110,6 +111,7 @@ func TestCorrelationIDExpiration(t *testing.T) {
 func TestCorrelationIDListCommands(t *testing.T) {
 	t.Parallel()
 	capture := NewCapture()
+	_ = capture.SetRetryPolicy(queries.RetryPolicy{Attempts: 1}) // expire on the first missed window
 
 	// Create 3 pending commands
 	for i := 0; i < 3; i++ {
@@ -171,19 +171,30 @@ func TestCoverageBoost_NetworkBodiesBranches(t *testing.T) {
 		t.Fatalf("last network body TestIDs = %+v, want [tid]", last.TestIDs)
 	}
 
+	// Inline limits cap each entry, so memory eviction needs several entries at the highest limits.
 	c2 := newCoverageCapture(t)
-	huge := strings.Repeat("x", nbBufferMemoryLimit)
-	c2.AddNetworkBodies([]NetworkBody{{
-		Method:       "POST",
-		URL:          "https://example.test/huge",
-		RequestBody:  huge,
-		ResponseBody: huge,
-	}})
-	if got := c2.GetNetworkBodyCount(); got != 0 {
-		t.Fatalf("GetNetworkBodyCount() after memory eviction = %d, want 0", got)
-	}
-	if got := c2.GetNetworkBodiesBufferMemory(); got != 0 {
-		t.Fatalf("GetNetworkBodiesBufferMemory() after eviction = %d, want 0", got)
+	if err := c2.SetBodyLimits(BodyLimits{RequestMax: maxBodyCaptureMax, ResponseMax: maxBodyCaptureMax, CaptureMax: maxBodyCaptureMax}); err != nil {
+		t.Fatal(err)
+	}
+	huge := incompressible(maxBodyCaptureMax)
+	const added = 5
+	for i := 0; i < added; i++ {
+		c2.AddNetworkBodies([]NetworkBody{{
+			Method:       "POST",
+			URL:          "https://example.test/huge",
+			RequestBody:  huge,
+			ResponseBody: huge,
+		}})
+	}
+	// Memory eviction sheds the largest payloads in place instead of dropping whole entries.
+	if got := c2.GetNetworkBodyCount(); got != added {
+		t.Fatalf("GetNetworkBodyCount() after memory eviction = %d, want %d", got, added)
+	}
+	if first := c2.GetNetworkBodies()[0]; !first.BodyEvicted || first.ResponseBody != "" {
+		t.Fatalf("oldest body after memory eviction = evicted %v, %d bytes; want its payload shed", first.BodyEvicted, len(first.ResponseBody))
+	}
+	if got := c2.GetNetworkBodiesBufferMemory(); got > nbBufferMemoryLimit {
+		t.Fatalf("GetNetworkBodiesBufferMemory() after eviction = %d, want <= %d", got, nbBufferMemoryLimit)
 	}
 }
 
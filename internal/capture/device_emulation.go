@@ -0,0 +1,53 @@
+// Purpose: Holds the device applied by interact(emulate) so performance snapshots and generated tests can record it.
+// Why: Viewport, DPR, and user agent change what a page renders and how fast it loads; results taken under emulation must say so.
+// Docs: docs/features/feature/device-emulation/index.md
+
+package capture
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// DeviceEmulation is a resolved device: a named preset or custom metrics. Device is the
+// preset name and is empty for custom metrics.
+type DeviceEmulation struct {
+	Device           string  `json:"device,omitempty"`
+	Width            int     `json:"width"`
+	Height           int     `json:"height"`
+	DevicePixelRatio float64 `json:"device_scale_factor"`
+	UserAgent        string  `json:"user_agent,omitempty"`
+	Touch            bool    `json:"touch"`
+	Mobile           bool    `json:"mobile"`
+}
+
+// Label names the device for reports, e.g. "iPhone 14 (390x844 @3x)" or "custom (800x600 @1x)".
+func (d DeviceEmulation) Label() string {
+	name := d.Device
+	if name == "" {
+		name = "custom"
+	}
+	return fmt.Sprintf("%s (%dx%d @%sx)", name, d.Width, d.Height, strconv.FormatFloat(d.DevicePixelRatio, 'f', -1, 64))
+}
+
+// SetDeviceEmulation records the device now emulated on the tracked tab; nil clears it.
+func (c *Capture) SetDeviceEmulation(device *DeviceEmulation) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if device == nil {
+		c.deviceEmulation = nil
+		return
+	}
+	d := *device
+	c.deviceEmulation = &d
+}
+
+// GetDeviceEmulation returns the emulated device. ok is false when none is active.
+func (c *Capture) GetDeviceEmulation() (DeviceEmulation, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.deviceEmulation == nil {
+		return DeviceEmulation{}, false
+	}
+	return *c.deviceEmulation, true
+}
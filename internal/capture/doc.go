@@ -17,8 +17,13 @@
 // The Capture type maintains ring buffers with configurable capacity, memory-based
 // eviction, and TTL filtering. All methods are thread-safe using a single mutex.
 //
-// Memory management enforces soft/hard/critical limits to prevent unbounded growth:
-//   - Soft (50MB): Evict 25% of oldest entries
-//   - Hard (100MB): Evict 50% of oldest entries
-//   - Critical (150MB): Clear all buffers, enter minimal mode
+// Memory management enforces soft/hard/critical limits on total capture memory,
+// shedding by priority (lowest first) and largest payload first (memory_pressure.go):
+//   - Soft (12MB): performance snapshots, then success response bodies
+//   - Hard (20MB): also WebSocket message payloads
+//   - Critical (28MB): also the oldest actions, then error response bodies
+//
+// Network bodies are held gzip-compressed and inflated on read (body_compression.go).
+// Bodies and payloads are cleared in place (body_evicted/data_evicted) so cursors hold.
+// Each pass emits a memory_eviction lifecycle event.
 package capture
@@ -7,6 +7,7 @@ package capture
 import (
 	"time"
 
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/changefeed"
 	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/util"
 )
 
@@ -38,12 +39,14 @@ func (c *Capture) AddEnhancedActions(actions []EnhancedAction) {
 		}
 
 		hasNavigation := c.buffers.appendEnhancedActions(actions, now)
+		c.changes.Append(changefeed.KindAction, changefeed.ActionPayloads(actions)...)
 
 		if hasNavigation {
 			return c.navigationCallback
 		}
 		return nil
 	}()
+	c.relieveMemoryPressure()
 
 	// Fire navigation callback outside lock to prevent deadlocks
 	if navCb != nil {
@@ -43,6 +43,7 @@ type ExtensionState struct {
 	// Disconnect detection (P0-1 hardening)
 	lastSyncSeen     time.Time // When last /sync request was received. Zero = never synced.
 	lastSyncClientID string    // Client ID from most recent /sync request.
+	wsSessions       int       // Open /extension-ws sockets. >0 means commands are pushed, not long-polled.
 
 	// AI Web Pilot
 	pilotEnabled     bool      // Last known pilot toggle from sync/settings cache.
@@ -68,12 +69,17 @@ type ExtensionState struct {
 	cspRestricted bool   // true if page CSP blocks execute_js (new Function).
 	cspLevel      string // "none", "script_exec", or "page_blocked".
 
+	// Browser metadata from /sync settings, for observe(environment) and generated tests.
+	environment        BrowserEnvironment // Last reported environment. Zero until the first report.
+	environmentUpdated time.Time          // When environment was last reported. Zero = never.
+
 	// Last-resort altered-environment debug mode.
 	securityMode     string   // "normal" (default) or "insecure_proxy".
 	insecureRewrites []string // Rewrite set active in insecure mode (for transparent reporting).
 
 	// Test boundaries
 	activeTestIDs map[string]bool // Active test boundary IDs. Used to tag events during ingestion.
+	boundaries    []TestBoundary  // Started boundaries, oldest first, capped at maxTestBoundaries.
 }
 
 // ExtensionSnapshot contains a point-in-time view of extension state for health reporting.
@@ -40,6 +40,14 @@ func (c *Capture) IsExtensionConnected() bool {
 	return !c.extensionState.lastSyncSeen.IsZero() && time.Since(c.extensionState.lastSyncSeen) < extensionDisconnectThreshold
 }
 
+// ExtensionLastSeen returns when the extension last synced. Zero means it has never
+// synced in this process, i.e. a cold start rather than a disconnect.
+func (c *Capture) ExtensionLastSeen() time.Time {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.extensionState.lastSyncSeen
+}
+
 // GetExtensionStatus returns a detached connection snapshot.
 // Fields: connected (bool), last_seen (RFC3339 string), client_id (string).
 //
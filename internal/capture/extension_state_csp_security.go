@@ -23,7 +23,7 @@ func CSPBlockedActions(level string) (actions []string, reason string) {
 				"execute_js", "click", "type", "select", "check", "scroll_to", "focus",
 				"get_text", "get_value", "get_attribute", "set_attribute",
 				"list_interactive", "get_readable", "get_markdown",
-				"fill_form", "fill_form_and_submit",
+				"fill_form", "fill_form_and_submit", "replay_request",
 			},
 			"Page blocks all script injection. Only navigate, screenshot, and network observation available."
 	default:
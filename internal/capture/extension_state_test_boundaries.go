@@ -2,10 +2,30 @@
 // Why: Separates test-boundary lifecycle from other extension state to keep CI concerns isolated.
 package capture
 
+import "time"
+
+// maxTestBoundaries bounds the boundary windows remembered for observe(boundary=...).
+const maxTestBoundaries = 100
+
+// TestBoundary is the time window of one test boundary. EndedAt is zero while it is active.
+type TestBoundary struct {
+	ID        string
+	StartedAt time.Time
+	EndedAt   time.Time
+}
+
+// Active reports whether the boundary has not ended yet.
+func (b TestBoundary) Active() bool { return b.EndedAt.IsZero() }
+
 // GetActiveTestIDs returns the list of currently active test IDs.
 func (c *Capture) GetActiveTestIDs() []string {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
+	return c.activeTestIDsLocked()
+}
+
+// activeTestIDsLocked returns the active test IDs used to tag ingested entries. Caller holds c.mu.
+func (c *Capture) activeTestIDsLocked() []string {
 	result := make([]string, 0, len(c.extensionState.activeTestIDs))
 	for testID := range c.extensionState.activeTestIDs {
 		result = append(result, testID)
@@ -13,22 +33,90 @@ func (c *Capture) GetActiveTestIDs() []string {
 	return result
 }
 
+// TaggedWith reports whether an entry's test IDs include id.
+func TaggedWith(testIDs []string, id string) bool {
+	for _, t := range testIDs {
+		if t == id {
+			return true
+		}
+	}
+	return false
+}
+
+// GetTestBoundary returns the most recent window recorded for a test ID.
+func (c *Capture) GetTestBoundary(id string) (TestBoundary, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	for i := len(c.extensionState.boundaries) - 1; i >= 0; i-- {
+		if c.extensionState.boundaries[i].ID == id {
+			return c.extensionState.boundaries[i], true
+		}
+	}
+	return TestBoundary{}, false
+}
+
+// GetTestBoundaries returns every remembered window for a test ID, oldest first.
+// Repeated runs of the same test each add a window.
+func (c *Capture) GetTestBoundaries(id string) []TestBoundary {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	var out []TestBoundary
+	for _, b := range c.extensionState.boundaries {
+		if b.ID == id {
+			out = append(out, b)
+		}
+	}
+	return out
+}
+
 // SetTestBoundaryStart marks a test boundary as active for future event tagging.
 //
 // Invariants:
 // - activeTestIDs behaves as a set (idempotent insert).
+// - Starting an already-active boundary keeps its original window.
 func (c *Capture) SetTestBoundaryStart(id string) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
+	if c.extensionState.activeTestIDs[id] {
+		return
+	}
 	c.extensionState.activeTestIDs[id] = true
+	c.extensionState.boundaries = append(c.extensionState.boundaries, TestBoundary{ID: id, StartedAt: time.Now()})
+	if over := len(c.extensionState.boundaries) - maxTestBoundaries; over > 0 {
+		c.extensionState.boundaries = append([]TestBoundary(nil), c.extensionState.boundaries[over:]...)
+	}
 }
 
-// SetTestBoundaryEnd clears a test boundary marker.
+// SetTestBoundaryEnd clears a test boundary marker and emits test_boundary_ended.
 //
 // Failure semantics:
-// - Deleting unknown IDs is a no-op.
+// - Deleting unknown IDs is a no-op and emits nothing.
 func (c *Capture) SetTestBoundaryEnd(id string) {
+	// The lifecycle event is emitted after c.mu is released.
+	if c.endTestBoundary(id) {
+		c.emitLifecycleEvent("test_boundary_ended", map[string]any{"test_id": id})
+	}
+}
+
+// endTestBoundary stamps the end of an active boundary and reports whether id was active.
+func (c *Capture) endTestBoundary(id string) bool {
 	c.mu.Lock()
 	defer c.mu.Unlock()
+	if !c.extensionState.activeTestIDs[id] {
+		return false
+	}
 	delete(c.extensionState.activeTestIDs, id)
+	for i := len(c.extensionState.boundaries) - 1; i >= 0; i-- {
+		if c.extensionState.boundaries[i].ID == id {
+			c.extensionState.boundaries[i].EndedAt = time.Now()
+			break
+		}
+	}
+	return true
+}
+
+// EndTestRun emits test_run_ended after a test runner reports run_end.
+// Boundaries the run left open are closed separately via SetTestBoundaryEnd.
+func (c *Capture) EndTestRun(runID string) {
+	c.emitLifecycleEvent("test_run_ended", map[string]any{"run_id": runID})
 }
@@ -0,0 +1,87 @@
+// Purpose: Tests test boundary windows and boundary tagging of buffered entries.
+// Docs: docs/features/feature/test-boundaries/index.md
+
+package capture
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestTestBoundary_WindowLifecycle(t *testing.T) {
+	t.Parallel()
+	c := NewCapture()
+
+	if _, ok := c.GetTestBoundary("checkout"); ok {
+		t.Fatal("unknown boundary should not be found")
+	}
+
+	c.SetTestBoundaryStart("checkout")
+	b, ok := c.GetTestBoundary("checkout")
+	if !ok || !b.Active() || b.StartedAt.IsZero() {
+		t.Fatalf("started boundary = %+v, ok=%v; want active with StartedAt", b, ok)
+	}
+
+	// Restarting an active boundary keeps the original window.
+	c.SetTestBoundaryStart("checkout")
+	again, _ := c.GetTestBoundary("checkout")
+	if !again.StartedAt.Equal(b.StartedAt) {
+		t.Fatalf("restart moved StartedAt: %v -> %v", b.StartedAt, again.StartedAt)
+	}
+
+	c.SetTestBoundaryEnd("checkout")
+	ended, _ := c.GetTestBoundary("checkout")
+	if ended.Active() || ended.EndedAt.Before(ended.StartedAt) {
+		t.Fatalf("ended boundary = %+v, want EndedAt >= StartedAt", ended)
+	}
+
+	// A second run of the same ID is reported as the newest window.
+	c.SetTestBoundaryStart("checkout")
+	rerun, _ := c.GetTestBoundary("checkout")
+	if !rerun.Active() {
+		t.Fatalf("rerun boundary = %+v, want active", rerun)
+	}
+}
+
+func TestTestBoundary_WindowsCapped(t *testing.T) {
+	t.Parallel()
+	c := NewCapture()
+
+	for i := 0; i < maxTestBoundaries+10; i++ {
+		id := fmt.Sprintf("t%d", i)
+		c.SetTestBoundaryStart(id)
+		c.SetTestBoundaryEnd(id)
+	}
+	if _, ok := c.GetTestBoundary("t0"); ok {
+		t.Fatal("oldest boundary should be evicted past the cap")
+	}
+	if _, ok := c.GetTestBoundary(fmt.Sprintf("t%d", maxTestBoundaries+9)); !ok {
+		t.Fatal("newest boundary should be kept")
+	}
+
+	c.ClearAll()
+	if _, ok := c.GetTestBoundary(fmt.Sprintf("t%d", maxTestBoundaries+9)); ok {
+		t.Fatal("ClearAll should forget boundary windows")
+	}
+}
+
+func TestTestBoundary_TagsWaterfallEntries(t *testing.T) {
+	t.Parallel()
+	c := NewCapture()
+
+	c.AddNetworkWaterfallEntries([]NetworkWaterfallEntry{{URL: "https://example.com/before.js"}}, "https://example.com")
+	c.SetTestBoundaryStart("checkout")
+	c.AddNetworkWaterfallEntries([]NetworkWaterfallEntry{{URL: "https://example.com/during.js"}}, "https://example.com")
+	c.SetTestBoundaryEnd("checkout")
+
+	entries := c.GetNetworkWaterfallEntries()
+	if len(entries) != 2 {
+		t.Fatalf("got %d waterfall entries, want 2", len(entries))
+	}
+	for _, e := range entries {
+		tagged := TaggedWith(e.TestIDs, "checkout")
+		if want := e.URL == "https://example.com/during.js"; tagged != want {
+			t.Errorf("%s tagged=%v, want %v (test_ids=%v)", e.URL, tagged, want, e.TestIDs)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+// Purpose: Tracks which transport the extension uses for command delivery and builds push replies for /extension-ws.
+// Why: Pushing commands over a socket removes the poll interval from every interact/analyze round trip; /health reports which path is live.
+// Docs: docs/features/feature/extension-websocket/index.md
+
+package capture
+
+import "time"
+
+// Extension command transports reported by ExtensionTransport.
+const (
+	ExtensionTransportWebSocket = "websocket"
+	ExtensionTransportPolling   = "polling"
+	ExtensionTransportNone      = "none"
+)
+
+// ExtensionWSHeartbeatMs is the heartbeat cadence requested from socket clients.
+// It stays well under extensionDisconnectThreshold so connection state never flaps.
+const ExtensionWSHeartbeatMs = 2000
+
+// ExtensionWebSocketOpened records a new /extension-ws session.
+func (c *Capture) ExtensionWebSocketOpened() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.extensionState.wsSessions++
+}
+
+// ExtensionWebSocketClosed records the end of an /extension-ws session.
+func (c *Capture) ExtensionWebSocketClosed() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.extensionState.wsSessions > 0 {
+		c.extensionState.wsSessions--
+	}
+}
+
+// ExtensionTransport reports how commands currently reach the extension:
+// "websocket" while a socket is open, "polling" while /sync heartbeats are fresh, otherwise "none".
+func (c *Capture) ExtensionTransport() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	switch {
+	case c.extensionState.wsSessions > 0:
+		return ExtensionTransportWebSocket
+	case !c.extensionState.lastSyncSeen.IsZero() && time.Since(c.extensionState.lastSyncSeen) < extensionDisconnectThreshold:
+		return ExtensionTransportPolling
+	default:
+		return ExtensionTransportNone
+	}
+}
+
+// ProcessSyncMessage applies one heartbeat received over /extension-ws and returns its reply.
+// Unlike POST /sync it never long-polls: queued commands are pushed separately (see SyncPush).
+func (c *Capture) ProcessSyncMessage(req SyncRequest, clientID, userAgent string) SyncResponse {
+	return c.processSync(req, clientID, userAgent, syncTransport{
+		endpoint:   "extension-ws",
+		method:     "WS",
+		idlePollMs: ExtensionWSHeartbeatMs,
+	})
+}
+
+// PendingSyncCommands returns every queued command in /sync wire form.
+func (c *Capture) PendingSyncCommands() []SyncCommand {
+	return buildSyncCommands(c.GetPendingQueries())
+}
+
+// SyncPush wraps commands in an unsolicited SyncResponse so socket clients handle
+// pushes and heartbeat replies with the same code path.
+func (c *Capture) SyncPush(commands []SyncCommand) SyncResponse {
+	if recorder := c.getProtocolRecorder(); recorder != nil && len(commands) > 0 {
+		recorder.RecordCommands(commands)
+	}
+	return SyncResponse{
+		Ack:              true,
+		Commands:         commands,
+		NextPollMs:       ExtensionWSHeartbeatMs,
+		ServerTime:       time.Now().Format(time.RFC3339),
+		ServerVersion:    c.GetServerVersion(),
+		CaptureOverrides: c.buildCaptureOverrides(),
+	}
+}
@@ -0,0 +1,12 @@
+// Purpose: Exposes the Capture-owned source edit store.
+// Why: POST /file-changes writes here; observe(what="timeline") reads it to judge each edit by what the page did next.
+// Docs: docs/features/feature/watch-mode/index.md
+
+package capture
+
+import "github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/filechanges"
+
+// FileChanges returns the source edit store. Store has its own lock.
+func (c *Capture) FileChanges() *filechanges.Store {
+	return c.fileChanges
+}
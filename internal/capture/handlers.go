@@ -15,12 +15,13 @@ import (
 )
 
 // HandleNetworkBodies handles POST /network-bodies from the extension.
-// Reads go through GET /telemetry?type=network_bodies.
+// Reads go through GET /telemetry?type=network_bodies. While the circuit is open
+// only failed requests (status >= 400) are kept.
 func (c *Capture) HandleNetworkBodies(w http.ResponseWriter, r *http.Request) {
 	if !util.RequireMethod(w, r, "POST") {
 		return
 	}
-	r.Body = http.MaxBytesReader(w, r.Body, maxExtensionPostBody)
+	r.Body = http.MaxBytesReader(w, r.Body, c.networkBodyPostLimit())
 	var payload struct {
 		Bodies []NetworkBody `json:"bodies"`
 	}
@@ -29,11 +30,15 @@ func (c *Capture) HandleNetworkBodies(w http.ResponseWriter, r *http.Request) {
 		util.JSONResponse(w, http.StatusBadRequest, map[string]string{"error": "Invalid JSON"})
 		return
 	}
+	resp := map[string]any{"status": "ok"}
+	if c.CheckCircuitOpen() {
+		kept := c.KeepPriorityNetworkBodies(payload.Bodies)
+		resp["dropped_under_pressure"] = len(payload.Bodies) - len(kept)
+		payload.Bodies = kept
+	}
 	c.AddNetworkBodies(payload.Bodies)
-	util.JSONResponse(w, http.StatusOK, map[string]any{
-		"status": "ok",
-		"count":  len(payload.Bodies),
-	})
+	resp["count"] = len(payload.Bodies)
+	util.JSONResponse(w, http.StatusOK, resp)
 }
 
 // HandleNetworkWaterfall handles POST /network-waterfall from the extension.
@@ -49,6 +54,7 @@ func (c *Capture) HandleNetworkWaterfall(w http.ResponseWriter, r *http.Request)
 		util.JSONResponse(w, http.StatusBadRequest, map[string]string{"error": "Invalid JSON"})
 		return
 	}
+	AttributeWaterfallTab(payload.Entries, payload.TabID)
 	c.AddNetworkWaterfallEntries(payload.Entries, payload.PageURL)
 	util.JSONResponse(w, http.StatusOK, map[string]any{
 		"status": "ok",
@@ -80,6 +86,12 @@ func (c *Capture) HandleQueryResult(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if recorder := c.getProtocolRecorder(); recorder != nil {
+		recorder.RecordResults([]SyncCommandResult{{
+			ID: body.ID, CorrelationID: body.CorrelationID, Status: body.Status, Result: body.Result, Error: body.Error,
+		}})
+	}
+
 	// Handle query_id for synchronous query results
 	if body.ID != "" {
 		if body.CorrelationID != "" {
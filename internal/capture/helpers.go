@@ -38,6 +38,11 @@ func (c *Capture) readIngestBody(w http.ResponseWriter, r *http.Request) ([]byte
 		c.WriteRateLimitResponse(w)
 		return nil, false
 	}
+	return c.readBody(w, r)
+}
+
+// readBody reads an extension POST body up to the ingest cap, writing 413 when it is larger.
+func (c *Capture) readBody(w http.ResponseWriter, r *http.Request) ([]byte, bool) {
 	r.Body = http.MaxBytesReader(w, r.Body, maxExtensionPostBody)
 	body, err := io.ReadAll(r.Body)
 	if err != nil {
@@ -47,11 +52,11 @@ func (c *Capture) readIngestBody(w http.ResponseWriter, r *http.Request) ([]byte
 	return body, true
 }
 
-// recordAndRecheck records a batch of events for rate limiting and rechecks.
+// recordAndRecheck records a batch of events for rate limiting and rechecks the window.
 // Returns true if the request may proceed; on rate limit it writes the 429 response.
 func (c *Capture) recordAndRecheck(w http.ResponseWriter, count int) bool {
 	c.RecordEvents(count)
-	if c.CheckRateLimit() {
+	if c.circuit.OverWindowLimit() {
 		c.WriteRateLimitResponse(w)
 		return false
 	}
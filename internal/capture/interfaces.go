@@ -4,7 +4,11 @@
 
 package capture
 
-import "encoding/json"
+import (
+	"encoding/json"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/types"
+)
 
 // SchemaStore defines the interface for API schema detection and tracking.
 // Implemented by *analysis.SchemaStore. Methods called by HTTP handlers and observers.
@@ -42,4 +46,8 @@ type ClientRegistry interface {
 	Get(id string) any
 	// Unregister removes a client by ID and reports whether the client existed.
 	Unregister(id string) bool
+	// SetSourceControl records the git state a registered client reported for its CWD.
+	SetSourceControl(id string, sc types.SourceControl) bool
+	// SourceControl returns a client's reported git state, or nil if unknown.
+	SourceControl(id string) *types.SourceControl
 }
@@ -5,6 +5,8 @@
 package capture
 
 import (
+	"time"
+
 	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/performance"
 )
 
@@ -24,6 +26,8 @@ type a11yInflightEntry struct{}
 type PerformanceStore struct {
 	snapshots       map[string]performance.Snapshot
 	snapshotOrder   []string
+	snapshotAddedAt map[string]time.Time // when each URL's snapshot was last stored, for the retention TTL
+	limit           Retention
 	baselines       map[string]performance.Baseline
 	baselineOrder   []string
 	beforeSnapshots map[string]performance.Snapshot // keyed by correlation_id, for perf_diff
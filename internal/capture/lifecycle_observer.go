@@ -24,6 +24,8 @@ const (
 	EventRateLimitTriggered     = lifecycle.EventRateLimitTriggered
 	EventCommandStateDesync     = lifecycle.EventCommandStateDesync
 	EventSyncSnapshot           = lifecycle.EventSyncSnapshot
+	EventTestBoundaryEnded      = lifecycle.EventTestBoundaryEnded
+	EventTestRunEnded           = lifecycle.EventTestRunEnded
 )
 
 // NewLifecycleObserver re-exports lifecycle.NewObserver for backward compatibility.
@@ -20,9 +20,23 @@ func wsEventMemory(e *WebSocketEvent) int64 {
 	return int64(len(e.Data)) + wsEventOverhead
 }
 
-// nbEntryMemory returns the memory estimate for a single network body entry.
-func nbEntryMemory(b *NetworkBody) int64 {
-	return int64(len(b.RequestBody)+len(b.ResponseBody)) + networkBodyOverhead
+// nbEntryMemory returns the memory estimate for a single network body entry, counting
+// its bodies as stored (compressed where that helped).
+func nbEntryMemory(e *networkBodyEntry) int64 {
+	return e.payloadStored() + nbHeaderMemory(&e.Body) + networkBodyOverhead
+}
+
+// nbEntryRawMemory returns the estimate for the same entry with its bodies uncompressed.
+func nbEntryRawMemory(e *networkBodyEntry) int64 {
+	return e.payloadRaw() + nbHeaderMemory(&e.Body) + networkBodyOverhead
+}
+
+func nbHeaderMemory(b *NetworkBody) int64 {
+	size := 0
+	for name, value := range b.RequestHeaders {
+		size += len(name) + len(value)
+	}
+	return int64(size)
 }
 
 // ============================================
@@ -52,7 +66,14 @@ func (c *Capture) GetWebSocketBufferMemory() int64 {
 	return c.buffers.calcWSMemory()
 }
 
-// GetNetworkBodiesBufferMemory returns approximate memory usage of network bodies buffer
+// GetEnhancedActionsBufferMemory returns approximate memory usage of the enhanced actions buffer
+func (c *Capture) GetEnhancedActionsBufferMemory() int64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return int64(len(c.buffers.enhancedActions)) * actionMemoryFixed
+}
+
+// GetNetworkBodiesBufferMemory returns approximate memory usage of network bodies buffer, bodies as stored (compressed)
 func (c *Capture) GetNetworkBodiesBufferMemory() int64 {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
@@ -0,0 +1,285 @@
+// Purpose: Sheds capture memory under pressure in priority order, largest payloads first.
+// Why: Oldest-first eviction let one burst of giant success bodies push out the error responses and actions agents debug with.
+// Docs: docs/features/feature/memory-enforcement/index.md
+
+package capture
+
+import "sort"
+
+// Memory pressure levels over total capture memory.
+const (
+	MemoryPressureSoft     = "soft"
+	MemoryPressureHard     = "hard"
+	MemoryPressureCritical = "critical"
+)
+
+const (
+	defaultMemorySoftLimit     = 12 * 1024 * 1024 // 12MB
+	defaultMemoryHardLimit     = 20 * 1024 * 1024 // 20MB
+	defaultMemoryCriticalLimit = 28 * 1024 * 1024 // 28MB
+	perfSnapshotMemoryFixed    = 2000             // bytes per performance snapshot (fixed estimate)
+)
+
+// memoryPressureLimits are the total-memory thresholds for each pressure level.
+// A pass frees memory until the total is back under three quarters of the soft limit.
+type memoryPressureLimits struct {
+	soft     int64
+	hard     int64
+	critical int64
+}
+
+func defaultMemoryPressureLimits() memoryPressureLimits {
+	return memoryPressureLimits{
+		soft:     defaultMemorySoftLimit,
+		hard:     defaultMemoryHardLimit,
+		critical: defaultMemoryCriticalLimit,
+	}
+}
+
+// BufferEviction reports what one pressure pass shed from a single buffer.
+type BufferEviction struct {
+	Buffer  string `json:"buffer"`
+	Entries int    `json:"entries"`
+	Bytes   int64  `json:"bytes"`
+}
+
+// MemoryEviction reports one pressure pass. It is the payload of the memory_eviction lifecycle event.
+type MemoryEviction struct {
+	Level        string           `json:"level"`
+	MemoryBefore int64            `json:"memory_before"`
+	MemoryAfter  int64            `json:"memory_after"`
+	Evicted      []BufferEviction `json:"evicted"`
+}
+
+// lifecycleData renders the eviction as lifecycle event data.
+func (e MemoryEviction) lifecycleData() map[string]any {
+	evicted := make([]map[string]any, 0, len(e.Evicted))
+	for _, b := range e.Evicted {
+		evicted = append(evicted, map[string]any{"buffer": b.Buffer, "entries": b.Entries, "bytes": b.Bytes})
+	}
+	return map[string]any{
+		"level":         e.Level,
+		"memory_before": e.MemoryBefore,
+		"memory_after":  e.MemoryAfter,
+		"evicted":       evicted,
+	}
+}
+
+// pressureTier is one rung of the eviction ladder. Tiers run lowest priority first and a
+// tier only runs once pressure reaches its level, so higher-value data goes last.
+type pressureTier struct {
+	buffer string
+	level  int
+	shed   func(c *Capture, need int64) (int, int64)
+}
+
+// pressureTiers orders capture data by how much an agent loses when it goes:
+// perf snapshots, then success bodies, then WebSocket payloads, then actions, then error bodies.
+// Console errors live in the server's log store and are never shed here.
+var pressureTiers = []pressureTier{
+	{buffer: "performance", level: 1, shed: func(c *Capture, need int64) (int, int64) {
+		return c.perf.shedOldestSnapshots(need)
+	}},
+	{buffer: "network_bodies", level: 1, shed: func(c *Capture, need int64) (int, int64) {
+		return c.shedNetworkMemory(need, false)
+	}},
+	{buffer: "websocket", level: 2, shed: func(c *Capture, need int64) (int, int64) {
+		return c.buffers.shedWebSocketPayloads(need)
+	}},
+	{buffer: "actions", level: 3, shed: func(c *Capture, need int64) (int, int64) {
+		return c.buffers.shedOldestActions(need)
+	}},
+	{buffer: "network_errors", level: 3, shed: func(c *Capture, need int64) (int, int64) {
+		return c.shedNetworkMemory(need, true)
+	}},
+}
+
+var pressureLevelNames = []string{"", MemoryPressureSoft, MemoryPressureHard, MemoryPressureCritical}
+
+// GetCaptureMemory returns the estimated memory held by all capture buffers.
+func (c *Capture) GetCaptureMemory() int64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.captureMemoryLocked()
+}
+
+// captureMemoryLocked sums every buffer's estimate (caller must hold lock).
+func (c *Capture) captureMemoryLocked() int64 {
+	return c.buffers.calcWSMemory() +
+		c.buffers.sseMemoryTotal +
+		c.buffers.calcNBMemory() +
+		int64(len(c.buffers.enhancedActions))*actionMemoryFixed +
+		int64(len(c.perf.snapshots))*perfSnapshotMemoryFixed +
+		c.fullBodies.memory
+}
+
+// pressureLevelLocked returns 0 (none) through 3 (critical) for the given total.
+func (c *Capture) pressureLevelLocked(total int64) int {
+	switch {
+	case total > c.memoryLimits.critical:
+		return 3
+	case total > c.memoryLimits.hard:
+		return 2
+	case total > c.memoryLimits.soft:
+		return 1
+	}
+	return 0
+}
+
+// relieveMemoryPressure runs a pressure pass after ingest and emits a memory_eviction
+// lifecycle event when anything was shed. Must be called without c.mu held.
+func (c *Capture) relieveMemoryPressure() {
+	if eviction, ok := c.evictForMemoryPressure(); ok {
+		c.emitLifecycleEvent("memory_eviction", eviction.lifecycleData())
+	}
+}
+
+// evictForMemoryPressure walks the tiers allowed at the current level until memory is back
+// under target. Shedding clears payloads in place where it can, so sequences and cursors hold.
+func (c *Capture) evictForMemoryPressure() (MemoryEviction, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	before := c.captureMemoryLocked()
+	level := c.pressureLevelLocked(before)
+	if level == 0 {
+		return MemoryEviction{}, false
+	}
+
+	need := before - c.memoryLimits.soft*3/4
+	eviction := MemoryEviction{Level: pressureLevelNames[level], MemoryBefore: before}
+	for _, tier := range pressureTiers {
+		if need <= 0 {
+			break
+		}
+		if tier.level > level {
+			continue
+		}
+		if entries, freed := tier.shed(c, need); entries > 0 {
+			eviction.Evicted = append(eviction.Evicted, BufferEviction{Buffer: tier.buffer, Entries: entries, Bytes: freed})
+			need -= freed
+		}
+	}
+	eviction.MemoryAfter = c.captureMemoryLocked()
+	return eviction, len(eviction.Evicted) > 0
+}
+
+// shedNetworkMemory frees spilled full bodies, then inline payloads, of error or non-error
+// responses, largest first (caller must hold lock).
+func (c *Capture) shedNetworkMemory(need int64, errors bool) (int, int64) {
+	entries, freed := c.fullBodies.shedLargest(need, errors)
+	if freed < need {
+		n, f := c.buffers.shedNetworkPayloads(need-freed, errors)
+		entries += n
+		freed += f
+	}
+	return entries, freed
+}
+
+// largestFirst returns the indexes of the included candidates ordered by size, largest first.
+// Ties keep buffer order, so the oldest of equal-sized entries goes first.
+func largestFirst(n int, size func(int) int64, include func(int) bool) []int {
+	idx := make([]int, 0, n)
+	for i := 0; i < n; i++ {
+		if include(i) && size(i) > 0 {
+			idx = append(idx, i)
+		}
+	}
+	sort.SliceStable(idx, func(a, b int) bool { return size(idx[a]) > size(idx[b]) })
+	return idx
+}
+
+// shedNetworkPayloads clears the largest inline bodies of error (status >= 400) or non-error
+// entries until need bytes are freed. Entries stay in place with body_evicted set.
+func (s *BufferStore) shedNetworkPayloads(need int64, errors bool) (int, int64) {
+	payload := func(i int) int64 { return s.networkBodies[i].payloadStored() }
+	isError := func(i int) bool { return (s.networkBodies[i].Body.Status >= 400) == errors }
+	shed, freed := 0, int64(0)
+	for _, i := range largestFirst(len(s.networkBodies), payload, isError) {
+		if freed >= need {
+			break
+		}
+		size := payload(i)
+		entry := &s.networkBodies[i]
+		s.networkBodyRawTotal -= entry.payloadRaw()
+		entry.clearPayload()
+		entry.Body.BodyEvicted = true
+		s.networkBodyMemoryTotal -= size
+		freed += size
+		shed++
+	}
+	return shed, freed
+}
+
+// shedWebSocketPayloads clears the largest message payloads until need bytes are freed.
+// Events stay in place with data_evicted set.
+func (s *BufferStore) shedWebSocketPayloads(need int64) (int, int64) {
+	payload := func(i int) int64 { return int64(len(s.wsEvents[i].Event.Data)) }
+	all := func(int) bool { return true }
+	shed, freed := 0, int64(0)
+	for _, i := range largestFirst(len(s.wsEvents), payload, all) {
+		if freed >= need {
+			break
+		}
+		size := payload(i)
+		s.wsEvents[i].Event.Data = ""
+		s.wsEvents[i].Event.DataEvicted = true
+		s.wsMemoryTotal -= size
+		freed += size
+		shed++
+	}
+	return shed, freed
+}
+
+// shedOldestActions drops the oldest actions until need bytes are freed. Actions are
+// fixed-size, so there is no largest entry to prefer.
+func (s *BufferStore) shedOldestActions(need int64) (int, int64) {
+	n := int((need + actionMemoryFixed - 1) / actionMemoryFixed)
+	n = min(n, len(s.enhancedActions))
+	s.dropActionHead(n)
+	return n, int64(n) * actionMemoryFixed
+}
+
+// shedOldestSnapshots drops the oldest performance snapshots until need bytes are freed.
+func (s *PerformanceStore) shedOldestSnapshots(need int64) (int, int64) {
+	shed := 0
+	freed := int64(0)
+	for freed < need && len(s.snapshotOrder) > 0 {
+		oldestKey := s.snapshotOrder[0]
+		s.snapshotOrder = s.snapshotOrder[1:]
+		delete(s.snapshots, oldestKey)
+		delete(s.snapshotAddedAt, oldestKey)
+		freed += perfSnapshotMemoryFixed
+		shed++
+	}
+	return shed, freed
+}
+
+// shedLargest drops the largest spilled bodies of error or non-error responses until need
+// bytes are freed. Their inline entries keep full_body_ref; fetching it reports the eviction.
+func (s *fullBodyStore) shedLargest(need int64, errors bool) (int, int64) {
+	size := func(i int) int64 { return fullBodyMemory(s.entries[s.order[i]]) }
+	isError := func(i int) bool { return (s.entries[s.order[i]].Status >= 400) == errors }
+	drop := make(map[string]bool)
+	freed := int64(0)
+	for _, i := range largestFirst(len(s.order), size, isError) {
+		if freed >= need {
+			break
+		}
+		freed += size(i)
+		drop[s.order[i]] = true
+	}
+	if len(drop) == 0 {
+		return 0, 0
+	}
+	kept := s.order[:0]
+	for _, id := range s.order {
+		if drop[id] {
+			delete(s.entries, id)
+			continue
+		}
+		kept = append(kept, id)
+	}
+	s.order = kept
+	s.memory -= freed
+	return len(drop), freed
+}
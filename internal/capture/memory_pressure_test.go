@@ -0,0 +1,119 @@
+// Purpose: Tests priority-weighted memory-pressure eviction and its memory_eviction lifecycle event.
+// Docs: docs/features/feature/memory-enforcement/index.md
+
+package capture
+
+import (
+	"math/rand"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// incompressible returns n random bytes, so gzip storage cannot shrink the body under test.
+func incompressible(n int) string {
+	b := make([]byte, n)
+	rand.New(rand.NewSource(int64(n))).Read(b)
+	return string(b)
+}
+
+func TestMemoryPressure_SoftShedsLargestSuccessBodyFirst(t *testing.T) {
+	c := NewCapture()
+	c.memoryLimits = memoryPressureLimits{soft: 10000, hard: 20000, critical: 40000}
+	var mu sync.Mutex
+	var events []map[string]any
+	c.SubscribeLifecycle(func(event LifecycleEvent, data map[string]any) {
+		if event.String() == "memory_eviction" {
+			mu.Lock()
+			defer mu.Unlock()
+			events = append(events, data)
+		}
+	})
+
+	c.AddNetworkBodies([]NetworkBody{{URL: "https://a.test/fail", Status: 500, ResponseBody: incompressible(5000)}})
+	c.AddNetworkBodies([]NetworkBody{
+		{URL: "https://a.test/small", Status: 200, ResponseBody: incompressible(1000)},
+		{URL: "https://a.test/big", Status: 200, ResponseBody: incompressible(5000)},
+	})
+
+	bodies := c.GetNetworkBodies()
+	if len(bodies) != 3 {
+		t.Fatalf("len = %d, want all 3 entries kept in place", len(bodies))
+	}
+	if bodies[0].BodyEvicted || len(bodies[0].ResponseBody) != 5000 {
+		t.Fatalf("error body must survive soft pressure: %+v", bodies[0].BodyEvicted)
+	}
+	if bodies[1].BodyEvicted || !bodies[2].BodyEvicted || bodies[2].ResponseBody != "" {
+		t.Fatalf("want only the largest success body shed, got small=%v big=%v", bodies[1].BodyEvicted, bodies[2].BodyEvicted)
+	}
+	if got := c.GetCaptureMemory(); got > 7500 {
+		t.Fatalf("memory after pass = %d, want <= 7500", got)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(events) != 1 || events[0]["level"] != MemoryPressureSoft {
+		t.Fatalf("events = %v, want one soft eviction", events)
+	}
+	evicted := events[0]["evicted"].([]map[string]any)
+	if len(evicted) != 1 || evicted[0]["buffer"] != "network_bodies" || evicted[0]["entries"] != 1 || evicted[0]["bytes"] != int64(5000) {
+		t.Fatalf("evicted = %v", evicted)
+	}
+}
+
+func TestMemoryPressure_LevelsGateHigherPriorityBuffers(t *testing.T) {
+	c := NewCapture()
+	c.AddNetworkBodies([]NetworkBody{{URL: "https://a.test/fail", Status: 502, ResponseBody: incompressible(4000)}})
+	c.AddWebSocketEvents([]WebSocketEvent{{ID: "ws-1", Event: "message", Data: strings.Repeat("w", 4000)}})
+	c.AddEnhancedActions([]EnhancedAction{{Type: "click"}, {Type: "input"}})
+
+	// Hard pressure reaches WebSocket payloads but not actions or error bodies.
+	c.mu.Lock()
+	c.memoryLimits = memoryPressureLimits{soft: 4000, hard: 6000, critical: 100000}
+	c.mu.Unlock()
+	eviction, ok := c.evictForMemoryPressure()
+	if !ok || eviction.Level != MemoryPressureHard || len(eviction.Evicted) != 1 || eviction.Evicted[0].Buffer != "websocket" {
+		t.Fatalf("hard eviction = %+v", eviction)
+	}
+	if ws := c.GetAllWebSocketEvents(); len(ws) != 1 || !ws[0].DataEvicted {
+		t.Fatalf("websocket events = %+v, want payload shed in place", ws)
+	}
+	if c.GetEnhancedActionCount() != 2 || c.GetNetworkBodies()[0].BodyEvicted {
+		t.Fatal("actions and error bodies must survive hard pressure")
+	}
+
+	// Critical pressure drops actions before it touches error bodies.
+	c.mu.Lock()
+	c.memoryLimits.critical = 5000
+	c.mu.Unlock()
+	eviction, ok = c.evictForMemoryPressure()
+	if !ok || eviction.Level != MemoryPressureCritical {
+		t.Fatalf("critical eviction = %+v", eviction)
+	}
+	if len(eviction.Evicted) != 2 || eviction.Evicted[0].Buffer != "actions" || eviction.Evicted[1].Buffer != "network_errors" {
+		t.Fatalf("critical order = %+v, want actions then network_errors", eviction.Evicted)
+	}
+	if c.GetEnhancedActionCount() != 0 || !c.GetNetworkBodies()[0].BodyEvicted {
+		t.Fatal("critical pressure should shed actions and the error body")
+	}
+	if c.GetActionTotalAdded() != 2 || c.GetNetworkTotalAdded() != 1 {
+		t.Fatal("eviction must not rewind totals")
+	}
+}
+
+func TestMemoryPressure_ShedsSpilledFullBodiesBeforeInline(t *testing.T) {
+	c := NewCapture()
+	c.memoryLimits = memoryPressureLimits{soft: 40000, hard: 80000, critical: 160000}
+	c.AddNetworkBodies([]NetworkBody{{URL: "https://a.test/huge", Status: 200, ResponseBody: strings.Repeat("h", 60000)}})
+
+	body := c.GetNetworkBodies()[0]
+	if body.FullBodyRef == "" {
+		t.Fatal("expected the body to spill past the inline limit")
+	}
+	if _, ok := c.GetFullBody(body.FullBodyRef); ok {
+		t.Fatal("spilled full body should be shed under pressure")
+	}
+	if body.BodyEvicted {
+		t.Fatal("inline body should survive once the full body freed enough")
+	}
+}
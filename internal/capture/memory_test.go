@@ -64,7 +64,7 @@ func recalcMemoryTotals(c *Capture) {
 	}
 	c.buffers.networkBodyMemoryTotal = 0
 	for i := range c.buffers.networkBodies {
-		c.buffers.networkBodyMemoryTotal += nbEntryMemory(&c.buffers.networkBodies[i].Body)
+		c.buffers.networkBodyMemoryTotal += nbEntryMemory(&c.buffers.networkBodies[i])
 	}
 }
 
@@ -77,15 +77,6 @@ func extractWSEvents(entries []wsEventEntry) []WebSocketEvent {
 	return out
 }
 
-// extractNetworkBodies extracts NetworkBody values from entry wrappers (test helper).
-func extractNetworkBodies(entries []networkBodyEntry) []NetworkBody {
-	out := make([]NetworkBody, len(entries))
-	for i := range entries {
-		out[i] = entries[i].Body
-	}
-	return out
-}
-
 // bruteForceWSMemory recalculates WS memory by iterating all events (reference implementation).
 func bruteForceWSMemory(events []WebSocketEvent) int64 {
 	var total int64
@@ -95,11 +86,13 @@ func bruteForceWSMemory(events []WebSocketEvent) int64 {
 	return total
 }
 
-// bruteForceNBMemory recalculates NB memory by iterating all bodies (reference implementation).
-func bruteForceNBMemory(bodies []NetworkBody) int64 {
+// bruteForceNBMemory recalculates NB memory by iterating all entries (reference implementation).
+// Bodies count as stored: compressed bytes when packed, inline bytes otherwise.
+func bruteForceNBMemory(entries []networkBodyEntry) int64 {
 	var total int64
-	for i := range bodies {
-		total += int64(len(bodies[i].RequestBody)+len(bodies[i].ResponseBody)) + networkBodyOverhead
+	for i := range entries {
+		e := &entries[i]
+		total += e.request.stored() + e.response.stored() + int64(len(e.Body.RequestBody)+len(e.Body.ResponseBody)) + networkBodyOverhead
 	}
 	return total
 }
@@ -196,7 +189,7 @@ func TestMemory_RunningTotal_NBAccurateAfterAdd(t *testing.T) {
 
 	c.mu.RLock()
 	runningTotal := c.buffers.networkBodyMemoryTotal
-	expected := bruteForceNBMemory(extractNetworkBodies(c.buffers.networkBodies))
+	expected := bruteForceNBMemory(c.buffers.networkBodies)
 	c.mu.RUnlock()
 
 	if runningTotal != expected {
@@ -242,7 +235,7 @@ func TestMemory_RunningTotal_NBAccurateAfterRotation(t *testing.T) {
 
 	c.mu.RLock()
 	runningTotal := c.buffers.networkBodyMemoryTotal
-	expected := bruteForceNBMemory(extractNetworkBodies(c.buffers.networkBodies))
+	expected := bruteForceNBMemory(c.buffers.networkBodies)
 	count := len(c.buffers.networkBodies)
 	c.mu.RUnlock()
 
@@ -289,7 +282,7 @@ func TestMemory_RunningTotal_NBAccurateAfterPerBufferEviction(t *testing.T) {
 
 	c.mu.RLock()
 	runningTotal := c.buffers.networkBodyMemoryTotal
-	expected := bruteForceNBMemory(extractNetworkBodies(c.buffers.networkBodies))
+	expected := bruteForceNBMemory(c.buffers.networkBodies)
 	c.mu.RUnlock()
 
 	if runningTotal != expected {
@@ -381,7 +374,7 @@ func TestMemory_RunningTotal_MultipleAddEvictCycles(t *testing.T) {
 	wsRunning := c.buffers.wsMemoryTotal
 	wsExpected := bruteForceWSMemory(extractWSEvents(c.buffers.wsEvents))
 	nbRunning := c.buffers.networkBodyMemoryTotal
-	nbExpected := bruteForceNBMemory(extractNetworkBodies(c.buffers.networkBodies))
+	nbExpected := bruteForceNBMemory(c.buffers.networkBodies)
 	c.mu.RUnlock()
 
 	if wsRunning != wsExpected {
@@ -5,8 +5,10 @@
 package capture
 
 import (
+	"strings"
 	"time"
 
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/changefeed"
 	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/util"
 )
 
@@ -33,26 +35,53 @@ func detectAndSetBinaryFormat(body *NetworkBody) {
 	}
 }
 
+// detectAuthHeader marks bodies whose forwarded request headers carry credentials.
+func detectAuthHeader(body *NetworkBody) {
+	if body.HasAuthHeader {
+		return
+	}
+	for name := range body.RequestHeaders {
+		if strings.EqualFold(name, "authorization") {
+			body.HasAuthHeader = true
+			return
+		}
+	}
+}
+
 // AddNetworkBodies ingests a batch into the network evidence ring buffer.
 //
 // Invariants:
 // - Each networkBodyEntry stores the body and its ingestion timestamp together.
 // - Totals are monotonic (`networkTotalAdded`, `networkErrorTotalAdded`) and never decremented.
 // - Active test IDs are snapshotted once per batch for consistent event tagging.
+// - Every body gets a request ID; a body past its inline limit is truncated and its full copy kept under that ID.
 //
 // Failure semantics:
 // - Batch ingestion never partially fails; over-capacity data is deterministically evicted.
+// - The network callback, if set, is invoked after the lock is released.
 func (c *Capture) AddNetworkBodies(bodies []NetworkBody) {
-	c.mu.Lock()
-	defer c.mu.Unlock()
+	cb, pageURL := func() (func(NetworkActivity), string) {
+		c.mu.Lock()
+		defer c.mu.Unlock()
 
-	now := time.Now()
-	activeTestIDs := make([]string, 0)
-	for testID := range c.extensionState.activeTestIDs {
-		activeTestIDs = append(activeTestIDs, testID)
-	}
+		now := time.Now()
+		activeTestIDs := make([]string, 0)
+		for testID := range c.extensionState.activeTestIDs {
+			activeTestIDs = append(activeTestIDs, testID)
+		}
+		for i := range bodies {
+			attachBinaryPreview(&bodies[i])
+			c.assignRequestIDAndSpill(&bodies[i])
+		}
 
-	c.buffers.appendNetworkBodies(bodies, activeTestIDs, now)
+		c.buffers.appendNetworkBodies(bodies, activeTestIDs, now)
+		c.changes.Append(changefeed.KindNetwork, changefeed.NetworkPayloads(bodies)...)
+		return c.networkCallback, c.extensionState.trackedTabURL
+	}()
+	c.relieveMemoryPressure()
+	if cb != nil && len(bodies) > 0 {
+		cb(NetworkActivity{PageURL: pageURL, Bodies: bodies})
+	}
 }
 
 // GetNetworkBodyCount returns the current number of network bodies in the buffer.
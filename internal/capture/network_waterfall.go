@@ -10,11 +10,27 @@ import (
 
 // AddNetworkWaterfallEntries adds network waterfall entries to the buffer.
 // Each entry is tagged with the page URL and current timestamp.
+// The network callback, if set, is invoked after the lock is released.
 func (c *Capture) AddNetworkWaterfallEntries(entries []NetworkWaterfallEntry, pageURL string) {
-	c.mu.Lock()
-	defer c.mu.Unlock()
+	cb := func() func(NetworkActivity) {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		c.networkWaterfall.appendEntries(entries, pageURL, c.activeTestIDsLocked(), time.Now())
+		return c.networkCallback
+	}()
+	if cb != nil && len(entries) > 0 {
+		cb(NetworkActivity{PageURL: pageURL, Waterfall: entries})
+	}
+}
 
-	c.networkWaterfall.appendEntries(entries, pageURL, time.Now())
+// AttributeWaterfallTab stamps entries read from one tab with its ID, keeping any tab an
+// entry already carries. A zero tabID leaves the entries unattributed.
+func AttributeWaterfallTab(entries []NetworkWaterfallEntry, tabID int) {
+	for i := range entries {
+		if entries[i].TabID == 0 {
+			entries[i].TabID = tabID
+		}
+	}
 }
 
 // GetNetworkWaterfallCount returns the current number of waterfall entries.
@@ -7,10 +7,13 @@ package capture
 import "time"
 
 // appendEntries appends entries, annotates each one with page URL/timestamp, and enforces capacity.
-func (b *NetworkWaterfallBuffer) appendEntries(entries []NetworkWaterfallEntry, pageURL string, now time.Time) {
+func (b *NetworkWaterfallBuffer) appendEntries(entries []NetworkWaterfallEntry, pageURL string, testIDs []string, now time.Time) {
 	for i := range entries {
 		entries[i].PageURL = pageURL
 		entries[i].Timestamp = now
+		if len(testIDs) > 0 {
+			entries[i].TestIDs = testIDs
+		}
 		b.entries = append(b.entries, entries[i])
 	}
 
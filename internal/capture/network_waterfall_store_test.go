@@ -16,7 +16,7 @@ func TestNetworkWaterfallBuffer_AppendEntriesTagsAndEvicts(t *testing.T) {
 		{URL: "https://a.test/a"},
 		{URL: "https://a.test/b"},
 		{URL: "https://a.test/c"},
-	}, "https://app.local/dashboard", now)
+	}, "https://app.local/dashboard", nil, now)
 
 	if got := buf.count(); got != 2 {
 		t.Fatalf("count = %d, want 2", got)
@@ -40,7 +40,7 @@ func TestNetworkWaterfallBuffer_SnapshotDetached(t *testing.T) {
 		entries:  make([]NetworkWaterfallEntry, 0, 2),
 		capacity: 2,
 	}
-	buf.appendEntries([]NetworkWaterfallEntry{{URL: "https://a.test/a"}}, "https://app.local", now)
+	buf.appendEntries([]NetworkWaterfallEntry{{URL: "https://a.test/a"}}, "https://app.local", nil, now)
 
 	snap := buf.snapshot()
 	if len(snap) != 1 {
@@ -62,7 +62,7 @@ func TestNetworkWaterfallBuffer_Clear(t *testing.T) {
 	buf.appendEntries([]NetworkWaterfallEntry{
 		{URL: "https://a.test/a"},
 		{URL: "https://a.test/b"},
-	}, "https://app.local", now)
+	}, "https://app.local", nil, now)
 
 	removed := buf.clear()
 	if removed != 2 {
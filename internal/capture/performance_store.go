@@ -4,13 +4,28 @@
 
 package capture
 
+import "time"
+
 const (
 	maxPerformanceSnapshots = 100
 	maxBeforeSnapshots      = 50
 )
 
+// newPerformanceStore returns an empty store with the default retention limit.
+func newPerformanceStore() PerformanceStore {
+	return PerformanceStore{
+		snapshots:       make(map[string]PerformanceSnapshot),
+		snapshotOrder:   make([]string, 0),
+		snapshotAddedAt: make(map[string]time.Time),
+		limit:           Retention{MaxEntries: maxPerformanceSnapshots},
+		baselines:       make(map[string]PerformanceBaseline),
+		baselineOrder:   make([]string, 0),
+		beforeSnapshots: make(map[string]PerformanceSnapshot),
+	}
+}
+
 // appendSnapshots stores snapshots by URL with oldest-entry eviction.
-func (s *PerformanceStore) appendSnapshots(snapshots []PerformanceSnapshot) {
+func (s *PerformanceStore) appendSnapshots(snapshots []PerformanceSnapshot, now time.Time) {
 	for _, snapshot := range snapshots {
 		key := snapshot.URL
 		if key == "" {
@@ -21,13 +36,42 @@ func (s *PerformanceStore) appendSnapshots(snapshots []PerformanceSnapshot) {
 			s.snapshotOrder = append(s.snapshotOrder, key)
 		}
 		s.snapshots[key] = snapshot
+		s.snapshotAddedAt[key] = now
+		s.evictSnapshotsByCount()
+	}
+	s.expireSnapshots(now)
+}
 
-		for len(s.snapshots) > maxPerformanceSnapshots && len(s.snapshotOrder) > 0 {
-			oldestKey := s.snapshotOrder[0]
-			s.snapshotOrder = s.snapshotOrder[1:]
-			delete(s.snapshots, oldestKey)
+// evictSnapshotsByCount drops the oldest URLs until the store fits its retention limit.
+func (s *PerformanceStore) evictSnapshotsByCount() {
+	for len(s.snapshots) > s.limit.MaxEntries && len(s.snapshotOrder) > 0 {
+		oldestKey := s.snapshotOrder[0]
+		s.snapshotOrder = s.snapshotOrder[1:]
+		delete(s.snapshots, oldestKey)
+		delete(s.snapshotAddedAt, oldestKey)
+	}
+}
+
+// expireSnapshots drops URLs whose latest snapshot is older than the retention TTL.
+// A URL re-measured later keeps its place in snapshotOrder, so expired keys need not be a prefix.
+func (s *PerformanceStore) expireSnapshots(now time.Time) int {
+	if s.limit.TTL <= 0 {
+		return 0
+	}
+	cutoff := now.Add(-s.limit.TTL)
+	kept := s.snapshotOrder[:0]
+	dropped := 0
+	for _, key := range s.snapshotOrder {
+		if s.snapshotAddedAt[key].After(cutoff) {
+			kept = append(kept, key)
+			continue
 		}
+		delete(s.snapshots, key)
+		delete(s.snapshotAddedAt, key)
+		dropped++
 	}
+	s.snapshotOrder = kept
+	return dropped
 }
 
 // snapshotsList returns a detached list copy.
@@ -75,6 +119,7 @@ func (s *PerformanceStore) takeBeforeSnapshot(correlationID string) (Performance
 func (s *PerformanceStore) clear() {
 	s.snapshots = make(map[string]PerformanceSnapshot)
 	s.snapshotOrder = make([]string, 0)
+	s.snapshotAddedAt = make(map[string]time.Time)
 	s.baselines = make(map[string]PerformanceBaseline)
 	s.baselineOrder = make([]string, 0)
 	s.beforeSnapshots = make(map[string]PerformanceSnapshot)
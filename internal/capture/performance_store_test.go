@@ -1,21 +1,18 @@
 package capture
 
-import "testing"
+import (
+	"testing"
+	"time"
+)
 
 func TestPerformanceStore_AppendSnapshotsEvictsOldest(t *testing.T) {
-	store := PerformanceStore{
-		snapshots:       make(map[string]PerformanceSnapshot),
-		snapshotOrder:   make([]string, 0),
-		baselines:       make(map[string]PerformanceBaseline),
-		baselineOrder:   make([]string, 0),
-		beforeSnapshots: make(map[string]PerformanceSnapshot),
-	}
+	store := newPerformanceStore()
 
 	input := make([]PerformanceSnapshot, 0, 101)
 	for i := 0; i < 101; i++ {
 		input = append(input, PerformanceSnapshot{URL: "https://app.local/page-" + itoa(i)})
 	}
-	store.appendSnapshots(input)
+	store.appendSnapshots(input, time.Now())
 
 	if got := len(store.snapshots); got != 100 {
 		t.Fatalf("snapshot count = %d, want 100", got)
@@ -29,14 +26,8 @@ func TestPerformanceStore_AppendSnapshotsEvictsOldest(t *testing.T) {
 }
 
 func TestPerformanceStore_SnapshotsListDetached(t *testing.T) {
-	store := PerformanceStore{
-		snapshots:       make(map[string]PerformanceSnapshot),
-		snapshotOrder:   make([]string, 0),
-		baselines:       make(map[string]PerformanceBaseline),
-		baselineOrder:   make([]string, 0),
-		beforeSnapshots: make(map[string]PerformanceSnapshot),
-	}
-	store.appendSnapshots([]PerformanceSnapshot{{URL: "https://app.local"}})
+	store := newPerformanceStore()
+	store.appendSnapshots([]PerformanceSnapshot{{URL: "https://app.local"}}, time.Now())
 
 	list := store.snapshotsList()
 	if len(list) != 1 {
@@ -49,13 +40,7 @@ func TestPerformanceStore_SnapshotsListDetached(t *testing.T) {
 }
 
 func TestPerformanceStore_BeforeSnapshotStoreAndTake(t *testing.T) {
-	store := PerformanceStore{
-		snapshots:       make(map[string]PerformanceSnapshot),
-		snapshotOrder:   make([]string, 0),
-		baselines:       make(map[string]PerformanceBaseline),
-		baselineOrder:   make([]string, 0),
-		beforeSnapshots: make(map[string]PerformanceSnapshot),
-	}
+	store := newPerformanceStore()
 
 	store.storeBeforeSnapshot("corr-1", PerformanceSnapshot{URL: "https://app.local/before"})
 	got, ok := store.takeBeforeSnapshot("corr-1")
@@ -71,14 +56,8 @@ func TestPerformanceStore_BeforeSnapshotStoreAndTake(t *testing.T) {
 }
 
 func TestPerformanceStore_Clear(t *testing.T) {
-	store := PerformanceStore{
-		snapshots:       make(map[string]PerformanceSnapshot),
-		snapshotOrder:   make([]string, 0),
-		baselines:       make(map[string]PerformanceBaseline),
-		baselineOrder:   make([]string, 0),
-		beforeSnapshots: make(map[string]PerformanceSnapshot),
-	}
-	store.appendSnapshots([]PerformanceSnapshot{{URL: "https://app.local"}})
+	store := newPerformanceStore()
+	store.appendSnapshots([]PerformanceSnapshot{{URL: "https://app.local"}}, time.Now())
 	store.storeBeforeSnapshot("corr-1", PerformanceSnapshot{URL: "https://app.local/before"})
 
 	store.clear()
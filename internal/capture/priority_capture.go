@@ -0,0 +1,134 @@
+// Purpose: Keeps console errors, failed requests, and WebSocket close/error events from batches the circuit breaker rejects.
+// Why: An open circuit rejected everything, including the error that caused the storm.
+// Docs: docs/features/feature/rate-limiting/index.md
+
+package capture
+
+import (
+	"fmt"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/types"
+)
+
+// PriorityCaptureTag marks log entries kept by priority capture; structs use captured_under_pressure too.
+const PriorityCaptureTag = "captured_under_pressure"
+
+// maxPriorityKeys bounds the WebSocket events remembered for retry de-duplication.
+const maxPriorityKeys = 512
+
+// priorityDedup remembers WebSocket events kept from 429'd batches. The extension
+// re-queues a rejected batch, so each retry carries the same events again.
+type priorityDedup struct {
+	seen  map[string]struct{}
+	order []string
+}
+
+// add records key and reports whether it was new, evicting the oldest key at capacity.
+func (d *priorityDedup) add(key string) bool {
+	if _, ok := d.seen[key]; ok {
+		return false
+	}
+	if d.seen == nil {
+		d.seen = make(map[string]struct{})
+	}
+	if len(d.order) >= maxPriorityKeys {
+		delete(d.seen, d.order[0])
+		d.order = d.order[1:]
+	}
+	d.seen[key] = struct{}{}
+	d.order = append(d.order, key)
+	return true
+}
+
+// isPriorityWebSocketEvent reports whether a WebSocket event survives an open circuit.
+func isPriorityWebSocketEvent(e WebSocketEvent) bool {
+	return e.Event == "close" || e.Event == "error"
+}
+
+// priorityKey identifies a WebSocket event across retries of the same batch.
+func priorityKey(e WebSocketEvent) string {
+	return fmt.Sprintf("%s|%s|%s|%d", e.ID, e.Event, e.Timestamp, e.CloseCode)
+}
+
+// isPriorityLogEntry reports whether a console entry survives an open circuit.
+func isPriorityLogEntry(e types.LogEntry) bool {
+	level, _ := e["level"].(string)
+	return level == "error"
+}
+
+// keepPriorityWebSocketEvents stores the close and error events of a rejected batch,
+// tagged captured_under_pressure, skipping ones a previous attempt already stored.
+func (c *Capture) keepPriorityWebSocketEvents(events []WebSocketEvent) {
+	kept, dropped := c.selectPriorityWebSocketEvents(events)
+	c.circuit.RecordPriority(len(kept), dropped)
+	if len(kept) > 0 {
+		c.AddWebSocketEvents(kept)
+	}
+}
+
+// selectPriorityWebSocketEvents marks the not-yet-stored close and error events of
+// events as seen and returns them tagged, plus the count of non-priority events.
+func (c *Capture) selectPriorityWebSocketEvents(events []WebSocketEvent) (kept []WebSocketEvent, dropped int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	kept = make([]WebSocketEvent, 0, len(events))
+	for _, e := range events {
+		if !isPriorityWebSocketEvent(e) {
+			dropped++
+			continue
+		}
+		if !c.priorityWS.add(priorityKey(e)) {
+			continue
+		}
+		e.UnderPressure = true
+		kept = append(kept, e)
+	}
+	return kept, dropped
+}
+
+// withoutKeptPriority drops events priority capture already stored, for when a
+// re-queued batch is finally admitted.
+func (c *Capture) withoutKeptPriority(events []WebSocketEvent) []WebSocketEvent {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if len(c.priorityWS.seen) == 0 {
+		return events
+	}
+	out := make([]WebSocketEvent, 0, len(events))
+	for _, e := range events {
+		if _, kept := c.priorityWS.seen[priorityKey(e)]; !kept {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+// KeepPriorityNetworkBodies returns the failed requests (status >= 400), tagged
+// captured_under_pressure, and records the rest as dropped. Ingest calls it while
+// CheckCircuitOpen reports the circuit open.
+func (c *Capture) KeepPriorityNetworkBodies(bodies []NetworkBody) []NetworkBody {
+	kept := make([]NetworkBody, 0, len(bodies))
+	for _, b := range bodies {
+		if b.Status >= 400 {
+			b.UnderPressure = true
+			kept = append(kept, b)
+		}
+	}
+	c.circuit.RecordPriority(len(kept), len(bodies)-len(kept))
+	return kept
+}
+
+// KeepPriorityLogs returns the console errors, tagged captured_under_pressure, and
+// records the rest as dropped. Log ingest calls it while CheckCircuitOpen reports the
+// circuit open.
+func (c *Capture) KeepPriorityLogs(entries []types.LogEntry) []types.LogEntry {
+	kept := make([]types.LogEntry, 0, len(entries))
+	for _, e := range entries {
+		if isPriorityLogEntry(e) {
+			e[PriorityCaptureTag] = true
+			kept = append(kept, e)
+		}
+	}
+	c.circuit.RecordPriority(len(kept), len(entries)-len(kept))
+	return kept
+}
@@ -0,0 +1,124 @@
+// Purpose: Tests priority capture of errors, failed requests, and WebSocket close/error events while the circuit is open.
+// Docs: docs/features/feature/rate-limiting/index.md
+
+package capture
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/types"
+)
+
+func TestHandleWebSocketEvents_CircuitOpenKeepsCloseAndError(t *testing.T) {
+	t.Parallel()
+	c := setupTestCapture(t)
+	c.circuit.ForceOpen("rate_exceeded")
+
+	body := `{"events":[
+		{"event":"open","id":"ws-1","url":"wss://example.com/ws","ts":"2026-01-01T00:00:00Z"},
+		{"event":"message","id":"ws-1","direction":"incoming","data":"tick","ts":"2026-01-01T00:00:01Z"},
+		{"event":"error","id":"ws-1","ts":"2026-01-01T00:00:02Z"},
+		{"event":"close","id":"ws-1","code":1011,"reason":"overload","ts":"2026-01-01T00:00:03Z"}
+	]}`
+	post := func() int {
+		rec := httptest.NewRecorder()
+		c.HandleWebSocketEvents(rec, httptest.NewRequest("POST", "/websocket-events", bytes.NewBufferString(body)))
+		return rec.Code
+	}
+
+	if code := post(); code != http.StatusTooManyRequests {
+		t.Fatalf("status = %d, want 429 so the extension keeps backing off", code)
+	}
+	events := c.GetWebSocketEvents(WebSocketEventFilter{})
+	if len(events) != 2 {
+		t.Fatalf("stored %d events, want close and error: %+v", len(events), events)
+	}
+	for _, e := range events {
+		if !e.UnderPressure || (e.Event != "close" && e.Event != "error") {
+			t.Errorf("stored %+v, want tagged close/error only", e)
+		}
+	}
+
+	// The extension re-queues a 429'd batch: neither the retry nor the admitted
+	// batch after the circuit closes may store the priority events twice.
+	post()
+	c.SetCircuitMode("closed")
+	if code := post(); code != http.StatusOK {
+		t.Fatalf("status after close = %d, want 200", code)
+	}
+	counts := map[string]int{}
+	for _, e := range c.GetWebSocketEvents(WebSocketEventFilter{}) {
+		counts[e.Event]++
+	}
+	if counts["close"] != 1 || counts["error"] != 1 || counts["open"] != 1 || counts["message"] != 1 {
+		t.Fatalf("event counts = %v, want one of each", counts)
+	}
+
+	st := c.CircuitStatus()
+	if st.PriorityAccepted != 2 || st.PriorityDropped != 4 {
+		t.Errorf("priority accepted/dropped = %d/%d, want 2/4", st.PriorityAccepted, st.PriorityDropped)
+	}
+}
+
+func TestHandleNetworkBodies_CircuitOpenKeepsFailedRequests(t *testing.T) {
+	t.Parallel()
+	c := setupTestCapture(t)
+	c.circuit.ForceOpen("rate_exceeded")
+
+	body := `{"bodies":[
+		{"method":"GET","url":"https://example.com/ok","status":200},
+		{"method":"POST","url":"https://example.com/api","status":500},
+		{"method":"GET","url":"https://example.com/missing","status":404}
+	]}`
+	rec := httptest.NewRecorder()
+	c.HandleNetworkBodies(rec, httptest.NewRequest("POST", "/network-bodies", bytes.NewBufferString(body)))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", rec.Code)
+	}
+	var resp map[string]any
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if resp["count"] != float64(2) || resp["dropped_under_pressure"] != float64(1) {
+		t.Fatalf("response = %v, want count 2 and 1 dropped", resp)
+	}
+	for _, b := range c.GetNetworkBodies() {
+		if b.Status < 400 || !b.UnderPressure {
+			t.Errorf("stored %+v, want tagged failed requests only", b)
+		}
+	}
+}
+
+func TestHandleNetworkBodies_CircuitClosedKeepsEverything(t *testing.T) {
+	t.Parallel()
+	c := setupTestCapture(t)
+	body := `{"bodies":[{"method":"GET","url":"https://example.com/ok","status":200}]}`
+	rec := httptest.NewRecorder()
+	c.HandleNetworkBodies(rec, httptest.NewRequest("POST", "/network-bodies", bytes.NewBufferString(body)))
+	if got := c.GetNetworkBodies(); len(got) != 1 || got[0].UnderPressure {
+		t.Fatalf("stored %+v, want the untagged body", got)
+	}
+	if bytes.Contains(rec.Body.Bytes(), []byte("dropped_under_pressure")) {
+		t.Errorf("closed circuit response reports drops: %s", rec.Body.String())
+	}
+}
+
+func TestKeepPriorityLogs(t *testing.T) {
+	t.Parallel()
+	c := setupTestCapture(t)
+	kept := c.KeepPriorityLogs([]types.LogEntry{
+		{"level": "log", "message": "noise"},
+		{"level": "error", "message": "boom"},
+		{"level": "warn", "message": "careful"},
+	})
+	if len(kept) != 1 || kept[0]["message"] != "boom" || kept[0][PriorityCaptureTag] != true {
+		t.Fatalf("kept = %v, want the tagged error", kept)
+	}
+	if st := c.CircuitStatus(); st.PriorityAccepted != 1 || st.PriorityDropped != 2 {
+		t.Errorf("priority accepted/dropped = %d/%d, want 1/2", st.PriorityAccepted, st.PriorityDropped)
+	}
+}
@@ -0,0 +1,27 @@
+// Purpose: Lets a recorder observe extension command traffic: settings, commands delivered, and results received.
+// Why: --record-protocol captures real extension exchanges so --replay can answer the same commands without a browser.
+// Docs: docs/features/feature/protocol-replay/index.md
+
+package capture
+
+// ProtocolRecorder receives extension protocol traffic. Methods are called outside
+// Capture.mu, possibly concurrently, and may see the same command more than once
+// while it awaits acknowledgment.
+type ProtocolRecorder interface {
+	RecordSettings(settings SyncSettings, extensionVersion string)
+	RecordCommands(commands []SyncCommand)
+	RecordResults(results []SyncCommandResult)
+}
+
+// SetProtocolRecorder installs (or, with nil, removes) the protocol recorder.
+func (c *Capture) SetProtocolRecorder(r ProtocolRecorder) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.protocolRecorder = r
+}
+
+func (c *Capture) getProtocolRecorder() ProtocolRecorder {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.protocolRecorder
+}
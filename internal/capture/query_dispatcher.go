@@ -6,6 +6,7 @@ package capture
 
 import (
 	"encoding/json"
+	"errors"
 	"time"
 
 	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/queries"
@@ -48,6 +49,11 @@ func (c *Capture) WaitForPendingQueries(timeout time.Duration) {
 	c.queryDispatcher.WaitForPendingQueries(timeout)
 }
 
+// PendingQueryAdded delegates to QueryDispatcher.
+func (c *Capture) PendingQueryAdded() <-chan struct{} {
+	return c.queryDispatcher.PendingQueryAdded()
+}
+
 // AcknowledgePendingQuery delegates to QueryDispatcher.
 func (c *Capture) AcknowledgePendingQuery(queryID string) {
 	c.queryDispatcher.AcknowledgePendingQuery(queryID)
@@ -102,14 +108,44 @@ func (c *Capture) GetQueryResultForClient(id string, clientID string) (json.RawM
 	return c.queryDispatcher.GetQueryResultForClient(id, clientID)
 }
 
-// WaitForResult delegates to QueryDispatcher.
+// WaitForResult delegates to QueryDispatcher, adding last-poll diagnostics to extension_unreachable errors.
 func (c *Capture) WaitForResult(id string, timeout time.Duration) (json.RawMessage, error) {
-	return c.queryDispatcher.WaitForResult(id, timeout)
+	return c.WaitForResultWithClient(id, timeout, "")
 }
 
-// WaitForResultWithClient delegates to QueryDispatcher.
+// WaitForResultWithClient delegates to QueryDispatcher, adding last-poll diagnostics to extension_unreachable errors.
 func (c *Capture) WaitForResultWithClient(id string, timeout time.Duration, clientID string) (json.RawMessage, error) {
-	return c.queryDispatcher.WaitForResultWithClient(id, timeout, clientID)
+	result, err := c.queryDispatcher.WaitForResultWithClient(id, timeout, clientID)
+	var unreachable *queries.ExtensionUnreachableError
+	if errors.As(err, &unreachable) {
+		c.annotateUnreachable(unreachable)
+	}
+	return result, err
+}
+
+// ExtensionUnreachable returns the give-up record of a query the extension never picked up,
+// with last-poll diagnostics.
+func (c *Capture) ExtensionUnreachable(queryID string) (*queries.ExtensionUnreachableError, bool) {
+	record, ok := c.queryDispatcher.ExtensionUnreachable(queryID)
+	if ok {
+		c.annotateUnreachable(record)
+	}
+	return record, ok
+}
+
+func (c *Capture) annotateUnreachable(record *queries.ExtensionUnreachableError) {
+	record.LastPollAt = c.ExtensionLastSeen()
+	record.Transport = c.ExtensionTransport()
+}
+
+// SetRetryPolicy delegates to QueryDispatcher.
+func (c *Capture) SetRetryPolicy(policy queries.RetryPolicy) error {
+	return c.queryDispatcher.SetRetryPolicy(policy)
+}
+
+// GetRetryPolicy delegates to QueryDispatcher.
+func (c *Capture) GetRetryPolicy() queries.RetryPolicy {
+	return c.queryDispatcher.GetRetryPolicy()
 }
 
 // SetQueryTimeout delegates to QueryDispatcher.
@@ -142,6 +178,11 @@ func (c *Capture) ExpireCommand(correlationID string) {
 	c.queryDispatcher.ExpireCommand(correlationID)
 }
 
+// ReplaceCommandResult delegates to QueryDispatcher.
+func (c *Capture) ReplaceCommandResult(correlationID string, result json.RawMessage) {
+	c.queryDispatcher.ReplaceCommandResult(correlationID, result)
+}
+
 // WaitForCommand delegates to QueryDispatcher.
 func (c *Capture) WaitForCommand(correlationID string, timeout time.Duration) (*queries.CommandResult, bool) {
 	return c.queryDispatcher.WaitForCommand(correlationID, timeout)
@@ -21,6 +21,26 @@ func (c *Capture) CheckRateLimit() bool {
 	return c.circuit.CheckRateLimit()
 }
 
+// CheckCircuitOpen delegates to CircuitBreaker.CheckOpen.
+func (c *Capture) CheckCircuitOpen() bool {
+	return c.circuit.CheckOpen()
+}
+
+// CircuitStatus delegates to CircuitBreaker.
+func (c *Capture) CircuitStatus() CircuitStatus {
+	return c.circuit.Status()
+}
+
+// SetCircuitThresholds delegates to CircuitBreaker.
+func (c *Capture) SetCircuitThresholds(t CircuitThresholds) {
+	c.circuit.SetThresholds(t)
+}
+
+// SetCircuitMode delegates to CircuitBreaker (circuit.ModeAuto, ModeOpen, or ModeClosed).
+func (c *Capture) SetCircuitMode(mode string) {
+	c.circuit.SetMode(mode)
+}
+
 // GetHealthStatus delegates to CircuitBreaker.
 func (c *Capture) GetHealthStatus() HealthResponse {
 	return c.circuit.GetHealthStatus()
@@ -0,0 +1,117 @@
+// Purpose: Holds the geolocation, timezone, and locale overrides the extension applies to the tracked tab.
+// Why: Region-specific bugs (pricing, date formatting, geo-gated content) only reproduce from the right place and language.
+// Docs: docs/features/feature/region-override/index.md
+
+package capture
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var (
+	regionLocalePattern   = regexp.MustCompile(`^[A-Za-z]{2,3}(-[A-Za-z0-9]{2,8})*$`)
+	regionTimezonePattern = regexp.MustCompile(`^(UTC|[A-Za-z]+(/[A-Za-z0-9_+\-]+)+)$`)
+)
+
+// Geolocation is a fixed position reported to navigator.geolocation. Accuracy is in meters.
+type Geolocation struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+	Accuracy  float64 `json:"accuracy,omitempty"`
+}
+
+// RegionOverride is the set of active overrides; empty fields are not overridden.
+type RegionOverride struct {
+	Geolocation *Geolocation `json:"geolocation,omitempty"`
+	Timezone    string       `json:"timezone,omitempty"`
+	Locale      string       `json:"locale,omitempty"`
+}
+
+// IsZero reports whether no override is set.
+func (o RegionOverride) IsZero() bool {
+	return o.Geolocation == nil && o.Timezone == "" && o.Locale == ""
+}
+
+// Validate checks coordinate ranges, the IANA timezone name, and the BCP 47 locale tag.
+func (o RegionOverride) Validate() error {
+	if g := o.Geolocation; g != nil {
+		switch {
+		case g.Latitude < -90 || g.Latitude > 90:
+			return fmt.Errorf("latitude must be between -90 and 90, got %g", g.Latitude)
+		case g.Longitude < -180 || g.Longitude > 180:
+			return fmt.Errorf("longitude must be between -180 and 180, got %g", g.Longitude)
+		case g.Accuracy < 0:
+			return fmt.Errorf("accuracy must not be negative, got %g", g.Accuracy)
+		}
+	}
+	if o.Timezone != "" && !regionTimezonePattern.MatchString(o.Timezone) {
+		return fmt.Errorf("invalid timezone %q, want an IANA name such as Europe/Berlin", o.Timezone)
+	}
+	if o.Locale != "" && !regionLocalePattern.MatchString(o.Locale) {
+		return fmt.Errorf("invalid locale %q, want a BCP 47 tag such as de-DE", o.Locale)
+	}
+	return nil
+}
+
+// Describe summarizes the overrides in one line, e.g. "geolocation 52.52,13.405 | Europe/Berlin | de-DE".
+func (o RegionOverride) Describe() string {
+	var parts []string
+	if g := o.Geolocation; g != nil {
+		parts = append(parts, fmt.Sprintf("geolocation %g,%g", g.Latitude, g.Longitude))
+	}
+	for _, s := range []string{o.Timezone, o.Locale} {
+		if s != "" {
+			parts = append(parts, s)
+		}
+	}
+	return strings.Join(parts, " | ")
+}
+
+// SetRegionOverride validates and stores the overrides; a zero value clears them. The
+// extension applies the change on its next sync.
+func (c *Capture) SetRegionOverride(o RegionOverride) error {
+	if err := o.Validate(); err != nil {
+		return err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if o.IsZero() {
+		c.regionOverride = nil
+		return nil
+	}
+	if o.Geolocation != nil {
+		g := *o.Geolocation
+		o.Geolocation = &g
+	}
+	c.regionOverride = &o
+	return nil
+}
+
+// GetRegionOverride returns the active overrides. ok is false when none are set.
+func (c *Capture) GetRegionOverride() (RegionOverride, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.regionOverride == nil {
+		return RegionOverride{}, false
+	}
+	o := *c.regionOverride
+	if o.Geolocation != nil {
+		g := *o.Geolocation
+		o.Geolocation = &g
+	}
+	return o, true
+}
+
+// regionOverrideOverride serializes the active overrides for the sync overrides map.
+func (c *Capture) regionOverrideOverride() string {
+	o, ok := c.GetRegionOverride()
+	if !ok {
+		return ""
+	}
+	// Error impossible: RegionOverride holds only strings and floats.
+	data, _ := json.Marshal(o)
+	return string(data)
+}
@@ -0,0 +1,36 @@
+// Purpose: Tests region override validation and delivery through the sync overrides map.
+// Docs: docs/features/feature/region-override/index.md
+
+package capture
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestRegionOverride_SyncsAsOverride(t *testing.T) {
+	t.Parallel()
+	c := NewCapture()
+	if _, ok := c.buildCaptureOverrides()["region_override"]; ok {
+		t.Fatal("region_override should be absent by default")
+	}
+
+	if err := c.SetRegionOverride(RegionOverride{Geolocation: &Geolocation{Latitude: 35.68, Longitude: 139.69}, Locale: "ja-JP"}); err != nil {
+		t.Fatalf("SetRegionOverride: %v", err)
+	}
+	var synced RegionOverride
+	if err := json.Unmarshal([]byte(c.buildCaptureOverrides()["region_override"]), &synced); err != nil {
+		t.Fatalf("region_override is not JSON: %v", err)
+	}
+	if synced.Geolocation == nil || synced.Geolocation.Longitude != 139.69 || synced.Locale != "ja-JP" || synced.Timezone != "" {
+		t.Fatalf("synced override = %+v", synced)
+	}
+
+	if err := c.SetRegionOverride(RegionOverride{Timezone: "Mars/Olympus Mons"}); err == nil {
+		t.Fatal("timezone with a space should be rejected")
+	}
+	_ = c.SetRegionOverride(RegionOverride{})
+	if _, ok := c.buildCaptureOverrides()["region_override"]; ok {
+		t.Fatal("clearing should drop region_override")
+	}
+}
@@ -0,0 +1,109 @@
+// Purpose: Holds request mocks the extension applies to matching page fetch/XHR calls.
+// Why: Agents simulate API failures, slow responses, and edge-case payloads without touching the backend.
+// Docs: docs/features/feature/request-mocking/index.md
+
+package capture
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	maxRequestMocks          = 50
+	maxRequestMockBodyBytes  = 1 << 20
+	maxRequestMockDelayMs    = 60000
+	maxRequestMockPatternLen = 2048
+)
+
+// RequestMock is one stubbed response. Status 0 simulates a network error.
+type RequestMock struct {
+	ID         string            `json:"id"`
+	URLPattern string            `json:"url_pattern"`
+	Method     string            `json:"method,omitempty"`
+	Status     int               `json:"status"`
+	Body       string            `json:"body,omitempty"`
+	Headers    map[string]string `json:"headers,omitempty"`
+	DelayMs    int               `json:"delay_ms,omitempty"`
+	CreatedAt  time.Time         `json:"created_at"`
+}
+
+// AddRequestMock validates and stores a mock. A mock with the same url_pattern and method
+// is replaced and keeps its id. The mock reaches the extension on its next sync.
+func (c *Capture) AddRequestMock(m RequestMock) (RequestMock, error) {
+	m.URLPattern = strings.TrimSpace(m.URLPattern)
+	m.Method = strings.ToUpper(strings.TrimSpace(m.Method))
+	switch {
+	case m.URLPattern == "":
+		return RequestMock{}, fmt.Errorf("url_pattern is required")
+	case len(m.URLPattern) > maxRequestMockPatternLen:
+		return RequestMock{}, fmt.Errorf("url_pattern longer than %d bytes", maxRequestMockPatternLen)
+	case m.Status != 0 && (m.Status < 100 || m.Status > 599):
+		return RequestMock{}, fmt.Errorf("status must be 100-599, or 0 for a network error, got %d", m.Status)
+	case len(m.Body) > maxRequestMockBodyBytes:
+		return RequestMock{}, fmt.Errorf("body larger than %d bytes", maxRequestMockBodyBytes)
+	case m.DelayMs < 0 || m.DelayMs > maxRequestMockDelayMs:
+		return RequestMock{}, fmt.Errorf("delay_ms must be 0-%d, got %d", maxRequestMockDelayMs, m.DelayMs)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i, existing := range c.requestMocks {
+		if existing.URLPattern == m.URLPattern && existing.Method == m.Method {
+			m.ID = existing.ID
+			m.CreatedAt = existing.CreatedAt
+			c.requestMocks[i] = m
+			return m, nil
+		}
+	}
+	if len(c.requestMocks) >= maxRequestMocks {
+		return RequestMock{}, fmt.Errorf("at most %d request mocks are allowed; remove one first", maxRequestMocks)
+	}
+	c.requestMockSeq++
+	m.ID = fmt.Sprintf("mock-%d", c.requestMockSeq)
+	m.CreatedAt = time.Now()
+	c.requestMocks = append(c.requestMocks, m)
+	return m, nil
+}
+
+// RemoveRequestMock drops the mock with the given id and reports whether it existed.
+func (c *Capture) RemoveRequestMock(id string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i, m := range c.requestMocks {
+		if m.ID == id {
+			c.requestMocks = append(c.requestMocks[:i], c.requestMocks[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// ClearRequestMocks drops every mock and returns how many were removed.
+func (c *Capture) ClearRequestMocks() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	n := len(c.requestMocks)
+	c.requestMocks = nil
+	return n
+}
+
+// GetRequestMocks returns a copy of the active mocks in insertion order.
+func (c *Capture) GetRequestMocks() []RequestMock {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return append([]RequestMock(nil), c.requestMocks...)
+}
+
+// requestMocksOverride encodes the mocks for capture_overrides, or "" when there are none.
+func (c *Capture) requestMocksOverride() string {
+	mocks := c.GetRequestMocks()
+	if len(mocks) == 0 {
+		return ""
+	}
+	// Error impossible: RequestMock holds only strings, ints, maps of strings, and a time.
+	data, _ := json.Marshal(mocks)
+	return string(data)
+}
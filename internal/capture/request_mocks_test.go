@@ -0,0 +1,77 @@
+// Purpose: Tests request mock validation, replacement, removal, and its capture_overrides encoding.
+// Docs: docs/features/feature/request-mocking/index.md
+
+package capture
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestRequestMocks_AddReplaceAndSyncOverride(t *testing.T) {
+	c := NewCapture()
+	if _, ok := c.buildCaptureOverrides()["request_mocks"]; ok {
+		t.Fatal("no override expected before mocks are added")
+	}
+
+	first, err := c.AddRequestMock(RequestMock{URLPattern: " /api/users ", Method: "get", Status: 500, Body: "boom"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first.ID != "mock-1" || first.URLPattern != "/api/users" || first.Method != "GET" {
+		t.Fatalf("mock = %+v, want trimmed pattern and upper-case method", first)
+	}
+	replaced, err := c.AddRequestMock(RequestMock{URLPattern: "/api/users", Method: "GET", Status: 200, DelayMs: 3000})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if replaced.ID != first.ID || len(c.GetRequestMocks()) != 1 {
+		t.Fatalf("same pattern and method should replace in place, got %+v", c.GetRequestMocks())
+	}
+	if _, err := c.AddRequestMock(RequestMock{URLPattern: "/api/users", Status: 0}); err != nil {
+		t.Fatal(err)
+	}
+
+	var synced []RequestMock
+	if err := json.Unmarshal([]byte(c.buildCaptureOverrides()["request_mocks"]), &synced); err != nil {
+		t.Fatal(err)
+	}
+	if len(synced) != 2 || synced[0].DelayMs != 3000 || synced[1].Status != 0 || synced[1].ID != "mock-2" {
+		t.Fatalf("synced mocks = %+v", synced)
+	}
+
+	if !c.RemoveRequestMock("mock-1") || c.RemoveRequestMock("mock-1") {
+		t.Fatal("remove should succeed once")
+	}
+	if n := c.ClearRequestMocks(); n != 1 {
+		t.Fatalf("clear removed %d, want 1", n)
+	}
+	if _, ok := c.buildCaptureOverrides()["request_mocks"]; ok {
+		t.Fatal("clearing the mocks should drop the override")
+	}
+}
+
+func TestRequestMocks_Validation(t *testing.T) {
+	c := NewCapture()
+	for _, m := range []RequestMock{
+		{URLPattern: "  "},
+		{URLPattern: "/api", Status: 99},
+		{URLPattern: "/api", Status: 600},
+		{URLPattern: "/api", Status: 200, DelayMs: -1},
+		{URLPattern: "/api", Status: 200, DelayMs: maxRequestMockDelayMs + 1},
+		{URLPattern: "/api", Status: 200, Body: strings.Repeat("x", maxRequestMockBodyBytes+1)},
+	} {
+		if _, err := c.AddRequestMock(m); err == nil {
+			t.Errorf("AddRequestMock(%q, status %d, delay %d) should fail", m.URLPattern, m.Status, m.DelayMs)
+		}
+	}
+	for i := 0; i < maxRequestMocks; i++ {
+		if _, err := c.AddRequestMock(RequestMock{URLPattern: "/api/" + strings.Repeat("a", i+1), Status: 200}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := c.AddRequestMock(RequestMock{URLPattern: "/one-too-many", Status: 200}); err == nil {
+		t.Fatal("expected an error past the mock limit")
+	}
+}
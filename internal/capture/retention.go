@@ -0,0 +1,190 @@
+// Purpose: Makes capture buffer capacities and TTLs configurable per buffer at runtime.
+// Why: One compiled-in ring size cannot fit both a quick debugging session and a long soak run.
+// Docs: docs/features/feature/ttl-retention/index.md
+
+package capture
+
+import (
+	"fmt"
+	"time"
+)
+
+// Capture buffers whose retention is configurable.
+const (
+	RetentionNetwork     = "network"
+	RetentionWebSocket   = "websocket"
+	RetentionActions     = "actions"
+	RetentionPerformance = "performance"
+)
+
+const (
+	// MaxRetentionEntries is the largest max_entries a buffer accepts. Memory caps still apply.
+	MaxRetentionEntries = 100000
+	// MinRetentionTTL is the shortest non-zero TTL accepted; shorter values are almost always a unit mistake.
+	MinRetentionTTL = time.Minute
+)
+
+// Retention bounds one buffer: at most MaxEntries entries, none older than TTL (0 = no age limit).
+type Retention struct {
+	MaxEntries int
+	TTL        time.Duration
+}
+
+// Validate reports the first out-of-range limit.
+func (r Retention) Validate() error {
+	switch {
+	case r.MaxEntries < 1 || r.MaxEntries > MaxRetentionEntries:
+		return fmt.Errorf("max_entries must be between 1 and %d", MaxRetentionEntries)
+	case r.TTL < 0:
+		return fmt.Errorf("ttl must not be negative")
+	case r.TTL > 0 && r.TTL < MinRetentionTTL:
+		return fmt.Errorf("ttl must be 0 (unlimited) or at least %s", MinRetentionTTL)
+	}
+	return nil
+}
+
+// RetentionBuffers returns the configurable capture buffers in reporting order.
+func RetentionBuffers() []string {
+	return []string{RetentionNetwork, RetentionWebSocket, RetentionActions, RetentionPerformance}
+}
+
+// DefaultRetention returns the limits a capture buffer starts with.
+func DefaultRetention(buffer string) (Retention, bool) {
+	switch buffer {
+	case RetentionNetwork:
+		return Retention{MaxEntries: MaxNetworkBodies}, true
+	case RetentionWebSocket:
+		return Retention{MaxEntries: MaxWSEvents}, true
+	case RetentionActions:
+		return Retention{MaxEntries: MaxEnhancedActions}, true
+	case RetentionPerformance:
+		return Retention{MaxEntries: maxPerformanceSnapshots}, true
+	}
+	return Retention{}, false
+}
+
+// GetRetention returns the effective retention of every capture buffer.
+func (c *Capture) GetRetention() map[string]Retention {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return map[string]Retention{
+		RetentionNetwork:     c.buffers.networkLimit,
+		RetentionWebSocket:   c.buffers.wsLimit,
+		RetentionActions:     c.buffers.actionLimit,
+		RetentionPerformance: c.perf.limit,
+	}
+}
+
+// SetRetention replaces one buffer's retention. Shrinking a buffer or shortening its TTL
+// evicts the oldest entries immediately; totals are untouched, as with ring eviction.
+func (c *Capture) SetRetention(buffer string, r Retention) error {
+	if _, ok := DefaultRetention(buffer); !ok {
+		return fmt.Errorf("unknown buffer %q", buffer)
+	}
+	if err := r.Validate(); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := time.Now()
+	switch buffer {
+	case RetentionNetwork:
+		c.buffers.networkLimit = r
+		c.buffers.evictNetworkByCount()
+		c.buffers.expireNetwork(now)
+	case RetentionWebSocket:
+		c.buffers.wsLimit = r
+		c.buffers.evictWebSocketByCount()
+		c.buffers.expireWebSocket(now)
+	case RetentionActions:
+		c.buffers.actionLimit = r
+		c.buffers.evictActionsByCount()
+		c.buffers.expireActions(now)
+	case RetentionPerformance:
+		c.perf.limit = r
+		c.perf.evictSnapshotsByCount()
+		c.perf.expireSnapshots(now)
+	}
+	return nil
+}
+
+// ExpireRetained drops entries older than their buffer's TTL and returns how many were dropped.
+// Appends expire their own buffer; call this periodically so idle buffers age out too.
+func (c *Capture) ExpireRetained(now time.Time) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.buffers.expireNetwork(now) +
+		c.buffers.expireWebSocket(now) +
+		c.buffers.expireActions(now) +
+		c.perf.expireSnapshots(now)
+}
+
+// expiredPrefix counts the leading entries added at or before now-ttl.
+// Buffers append in ingestion order, so expired entries always form a prefix.
+func expiredPrefix(n int, addedAt func(int) time.Time, ttl time.Duration, now time.Time) int {
+	if ttl <= 0 {
+		return 0
+	}
+	cutoff := now.Add(-ttl)
+	i := 0
+	for i < n && !addedAt(i).After(cutoff) {
+		i++
+	}
+	return i
+}
+
+func (s *BufferStore) expireNetwork(now time.Time) int {
+	drop := expiredPrefix(len(s.networkBodies), func(i int) time.Time { return s.networkBodies[i].AddedAt }, s.networkLimit.TTL, now)
+	s.dropNetworkHead(drop)
+	return drop
+}
+
+func (s *BufferStore) expireWebSocket(now time.Time) int {
+	drop := expiredPrefix(len(s.wsEvents), func(i int) time.Time { return s.wsEvents[i].AddedAt }, s.wsLimit.TTL, now)
+	s.dropWebSocketHead(drop)
+	return drop
+}
+
+func (s *BufferStore) expireActions(now time.Time) int {
+	drop := expiredPrefix(len(s.enhancedActions), func(i int) time.Time { return s.enhancedActions[i].AddedAt }, s.actionLimit.TTL, now)
+	s.dropActionHead(drop)
+	return drop
+}
+
+// dropNetworkHead removes the n oldest bodies, copying the rest so evicted entries can be collected.
+func (s *BufferStore) dropNetworkHead(n int) {
+	if n <= 0 {
+		return
+	}
+	for j := 0; j < n; j++ {
+		s.networkBodyMemoryTotal -= nbEntryMemory(&s.networkBodies[j])
+		s.networkBodyRawTotal -= nbEntryRawMemory(&s.networkBodies[j])
+	}
+	surviving := make([]networkBodyEntry, len(s.networkBodies)-n)
+	copy(surviving, s.networkBodies[n:])
+	s.networkBodies = surviving
+}
+
+// dropWebSocketHead removes the n oldest events, copying the rest so evicted entries can be collected.
+func (s *BufferStore) dropWebSocketHead(n int) {
+	if n <= 0 {
+		return
+	}
+	for j := 0; j < n; j++ {
+		s.wsMemoryTotal -= wsEventMemory(&s.wsEvents[j].Event)
+	}
+	surviving := make([]wsEventEntry, len(s.wsEvents)-n)
+	copy(surviving, s.wsEvents[n:])
+	s.wsEvents = surviving
+}
+
+// dropActionHead removes the n oldest actions, copying the rest so evicted entries can be collected.
+func (s *BufferStore) dropActionHead(n int) {
+	if n <= 0 {
+		return
+	}
+	surviving := make([]enhancedActionEntry, len(s.enhancedActions)-n)
+	copy(surviving, s.enhancedActions[n:])
+	s.enhancedActions = surviving
+}
@@ -0,0 +1,83 @@
+// Purpose: Tests per-buffer retention limits: validation, shrink-on-set eviction, and TTL expiry.
+// Docs: docs/features/feature/ttl-retention/index.md
+
+package capture
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSetRetention_ShrinkEvictsOldestAndKeepsTotals(t *testing.T) {
+	c := NewCapture()
+	for i := 0; i < 10; i++ {
+		c.AddEnhancedActions([]EnhancedAction{{Type: "click", URL: "https://a.test/" + itoa(i)}})
+	}
+	if err := c.SetRetention(RetentionActions, Retention{MaxEntries: 4}); err != nil {
+		t.Fatal(err)
+	}
+
+	actions := c.GetAllEnhancedActions()
+	if len(actions) != 4 || actions[0].URL != "https://a.test/6" {
+		t.Fatalf("actions = %+v, want the newest 4", actions)
+	}
+	if got := c.GetActionTotalAdded(); got != 10 {
+		t.Fatalf("action total = %d, want 10 (eviction must not rewind cursors)", got)
+	}
+
+	c.AddEnhancedActions([]EnhancedAction{{Type: "click", URL: "https://a.test/10"}})
+	if got := len(c.GetAllEnhancedActions()); got != 4 {
+		t.Fatalf("after append len = %d, want capacity 4", got)
+	}
+	if got := c.GetRetention()[RetentionActions]; got.MaxEntries != 4 {
+		t.Fatalf("GetRetention = %+v", got)
+	}
+}
+
+func TestSetRetention_RejectsInvalidLimits(t *testing.T) {
+	c := NewCapture()
+	cases := map[string]struct {
+		buffer string
+		r      Retention
+	}{
+		"unknown buffer": {"console", Retention{MaxEntries: 10}},
+		"zero entries":   {RetentionNetwork, Retention{}},
+		"too many":       {RetentionNetwork, Retention{MaxEntries: MaxRetentionEntries + 1}},
+		"ttl too short":  {RetentionWebSocket, Retention{MaxEntries: 10, TTL: 5 * time.Second}},
+		"negative ttl":   {RetentionWebSocket, Retention{MaxEntries: 10, TTL: -time.Minute}},
+	}
+	for name, tc := range cases {
+		if err := c.SetRetention(tc.buffer, tc.r); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+	if got := c.GetRetention()[RetentionNetwork]; got.MaxEntries != MaxNetworkBodies {
+		t.Fatalf("rejected set changed retention: %+v", got)
+	}
+}
+
+func TestExpireRetained_DropsEntriesPastTTL(t *testing.T) {
+	c := NewCapture()
+	c.AddNetworkBodies([]NetworkBody{{URL: "https://a.test/old", Status: 200}})
+	c.AddWebSocketEvents([]WebSocketEvent{{ID: "ws-1", Event: "message", URL: "wss://a.test"}})
+	c.AddPerformanceSnapshots([]PerformanceSnapshot{{URL: "https://a.test/"}})
+	for _, buffer := range []string{RetentionNetwork, RetentionWebSocket, RetentionPerformance} {
+		def, _ := DefaultRetention(buffer)
+		if err := c.SetRetention(buffer, Retention{MaxEntries: def.MaxEntries, TTL: time.Hour}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if dropped := c.ExpireRetained(time.Now()); dropped != 0 {
+		t.Fatalf("dropped %d fresh entries", dropped)
+	}
+	if dropped := c.ExpireRetained(time.Now().Add(2 * time.Hour)); dropped != 3 {
+		t.Fatalf("dropped = %d, want 3", dropped)
+	}
+	if len(c.GetNetworkBodies()) != 0 || len(c.GetAllWebSocketEvents()) != 0 || len(c.GetPerformanceSnapshots()) != 0 {
+		t.Fatal("expected all buffers empty after expiry")
+	}
+	if c.GetNetworkTotalAdded() != 1 {
+		t.Fatalf("network total = %d, want 1", c.GetNetworkTotalAdded())
+	}
+}
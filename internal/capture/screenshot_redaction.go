@@ -0,0 +1,60 @@
+// Purpose: Holds the CSS selectors whose regions are blacked out on every screenshot before it is saved.
+// Why: Screenshots can show PII that text redaction never sees; the extension measures these selectors and the daemon paints them out.
+// Docs: docs/features/feature/screenshot-redaction/index.md
+
+package capture
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+const (
+	maxScreenshotRedactSelectors     = 50
+	maxScreenshotRedactSelectorBytes = 512
+)
+
+// SetScreenshotRedactSelectors replaces the screenshot redaction selectors. Blank entries
+// and duplicates are dropped; an empty list turns screenshot redaction off. The list reaches
+// the extension on its next sync.
+func (c *Capture) SetScreenshotRedactSelectors(selectors []string) error {
+	cleaned := make([]string, 0, len(selectors))
+	seen := make(map[string]bool, len(selectors))
+	for _, sel := range selectors {
+		sel = strings.TrimSpace(sel)
+		if sel == "" || seen[sel] {
+			continue
+		}
+		if len(sel) > maxScreenshotRedactSelectorBytes {
+			return fmt.Errorf("selector longer than %d bytes: %.40s...", maxScreenshotRedactSelectorBytes, sel)
+		}
+		seen[sel] = true
+		cleaned = append(cleaned, sel)
+	}
+	if len(cleaned) > maxScreenshotRedactSelectors {
+		return fmt.Errorf("at most %d selectors are allowed, got %d", maxScreenshotRedactSelectors, len(cleaned))
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.screenshotRedactSelectors = cleaned
+	return nil
+}
+
+// GetScreenshotRedactSelectors returns a copy of the screenshot redaction selectors.
+func (c *Capture) GetScreenshotRedactSelectors() []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return append([]string(nil), c.screenshotRedactSelectors...)
+}
+
+// screenshotRedactOverride encodes the selectors for capture_overrides, or "" when there are none.
+func (c *Capture) screenshotRedactOverride() string {
+	selectors := c.GetScreenshotRedactSelectors()
+	if len(selectors) == 0 {
+		return ""
+	}
+	// Error impossible: a string slice always marshals.
+	data, _ := json.Marshal(selectors)
+	return string(data)
+}
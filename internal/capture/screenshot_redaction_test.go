@@ -0,0 +1,36 @@
+// Purpose: Tests screenshot redaction selector storage and its capture_overrides encoding.
+// Docs: docs/features/feature/screenshot-redaction/index.md
+
+package capture
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestScreenshotRedactSelectors_CleanAndSyncOverride(t *testing.T) {
+	c := NewCapture()
+	if _, ok := c.buildCaptureOverrides()["screenshot_redact_selectors"]; ok {
+		t.Fatal("no override expected before selectors are set")
+	}
+
+	if err := c.SetScreenshotRedactSelectors([]string{" .ssn ", "", "#card", ".ssn"}); err != nil {
+		t.Fatal(err)
+	}
+	if got := c.GetScreenshotRedactSelectors(); len(got) != 2 || got[0] != ".ssn" || got[1] != "#card" {
+		t.Fatalf("selectors = %v, want trimmed and deduplicated", got)
+	}
+	if got := c.buildCaptureOverrides()["screenshot_redact_selectors"]; got != `[".ssn","#card"]` {
+		t.Fatalf("override = %q", got)
+	}
+
+	if err := c.SetScreenshotRedactSelectors([]string{strings.Repeat("a", maxScreenshotRedactSelectorBytes+1)}); err == nil {
+		t.Fatal("expected an error for an oversized selector")
+	}
+	if err := c.SetScreenshotRedactSelectors(nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := c.buildCaptureOverrides()["screenshot_redact_selectors"]; ok {
+		t.Fatal("clearing the selectors should drop the override")
+	}
+}
@@ -0,0 +1,97 @@
+// Purpose: Implements Server-Sent Events (EventSource) ingestion and buffering alongside the WebSocket buffers.
+// Why: SSE streams carry the same live-update traffic as WebSockets; agents need their lifecycle and messages too.
+// Docs: docs/features/feature/sse-tracking/index.md
+
+package capture
+
+import (
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/types"
+)
+
+// SSEEvent is an alias to canonical definition in internal/types/network.go
+type SSEEvent = types.SSEEvent
+
+const sseEventOverhead = 200 // bytes overhead per SSE event
+
+// sseEventEntry bundles an SSEEvent with its ingestion timestamp.
+type sseEventEntry struct {
+	Event   SSEEvent
+	AddedAt time.Time
+}
+
+func sseEventMemory(e *SSEEvent) int64 {
+	return int64(len(e.Data)) + sseEventOverhead
+}
+
+// AddSSEEvents ingests EventSource telemetry from the extension.
+//
+// Failure semantics:
+// - Over-capacity batches are accepted then oldest entries are evicted by count and memory.
+func (c *Capture) AddSSEEvents(events []SSEEvent) {
+	func() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+
+		activeTestIDs := make([]string, 0)
+		for testID := range c.extensionState.activeTestIDs {
+			activeTestIDs = append(activeTestIDs, testID)
+		}
+		c.buffers.appendSSEEvents(events, activeTestIDs, time.Now())
+	}()
+	c.relieveMemoryPressure()
+}
+
+// GetAllSSEEvents returns a copy of all buffered SSE events, oldest first (thread-safe).
+func (c *Capture) GetAllSSEEvents() []SSEEvent {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	out := make([]SSEEvent, len(c.buffers.sseEvents))
+	for i := range c.buffers.sseEvents {
+		out[i] = c.buffers.sseEvents[i].Event
+	}
+	return out
+}
+
+// GetSSETotalAdded returns the monotonic count of SSE events ever added, for cursor sequences.
+func (c *Capture) GetSSETotalAdded() int64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.buffers.sseTotalAdded
+}
+
+// ClearSSEBuffer resets the SSE buffer and returns how many events it held.
+func (c *Capture) ClearSSEBuffer() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	n := len(c.buffers.sseEvents)
+	c.buffers.clearSSEBuffers()
+	return n
+}
+
+func (s *BufferStore) appendSSEEvents(events []SSEEvent, testIDs []string, now time.Time) {
+	s.sseTotalAdded += int64(len(events))
+	for i := range events {
+		events[i].TestIDs = testIDs
+		s.sseEvents = append(s.sseEvents, sseEventEntry{Event: events[i], AddedAt: now})
+		s.sseMemoryTotal += sseEventMemory(&events[i])
+	}
+	// Drop oldest-first until both the count and the memory cap hold.
+	drop := 0
+	for drop < len(s.sseEvents) && (len(s.sseEvents)-drop > MaxSSEEvents || s.sseMemoryTotal > sseBufferMemoryLimit) {
+		s.sseMemoryTotal -= sseEventMemory(&s.sseEvents[drop].Event)
+		drop++
+	}
+	if drop > 0 {
+		surviving := make([]sseEventEntry, len(s.sseEvents)-drop)
+		copy(surviving, s.sseEvents[drop:])
+		s.sseEvents = surviving
+	}
+}
+
+func (s *BufferStore) clearSSEBuffers() {
+	s.sseEvents = make([]sseEventEntry, 0)
+	s.sseTotalAdded = 0
+	s.sseMemoryTotal = 0
+}
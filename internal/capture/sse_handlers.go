@@ -0,0 +1,36 @@
+// Purpose: Handles HTTP POST ingestion of Server-Sent Events (EventSource) activity from the browser extension.
+// Why: Separates SSE ingestion HTTP handler from storage and query logic, as for WebSocket events.
+// Docs: docs/features/feature/sse-tracking/index.md
+
+package capture
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/util"
+)
+
+// HandleSSEEvents handles POST /sse-events from the extension.
+// Reads go through observe(what="sse").
+func (c *Capture) HandleSSEEvents(w http.ResponseWriter, r *http.Request) {
+	if !util.RequireMethod(w, r, "POST") {
+		return
+	}
+	body, ok := c.readIngestBody(w, r)
+	if !ok {
+		return
+	}
+	var payload struct {
+		Events []SSEEvent `json:"events"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		util.JSONResponse(w, http.StatusBadRequest, map[string]string{"error": "Invalid JSON"})
+		return
+	}
+	if !c.recordAndRecheck(w, len(payload.Events)) {
+		return
+	}
+	c.AddSSEEvents(payload.Events)
+	w.WriteHeader(http.StatusOK)
+}
@@ -0,0 +1,94 @@
+// Purpose: Tests Server-Sent Events capture: ingestion, ring-buffer eviction, and clearing.
+// Docs: docs/features/feature/sse-tracking/index.md
+
+package capture
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestSSE_PostEndpointStoresEvents(t *testing.T) {
+	t.Parallel()
+	c := setupTestCapture(t)
+
+	body := `{"events":[` +
+		`{"ts":"2026-01-15T10:30:00.000Z","event":"open","id":"es-1","url":"https://app.test/stream"},` +
+		`{"ts":"2026-01-15T10:30:01.000Z","event":"message","id":"es-1","url":"https://app.test/stream","event_type":"update","last_event_id":"7","data":"{\"n\":1}","size":7}]}`
+	req := httptest.NewRequest("POST", "/sse-events", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	c.HandleSSEEvents(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", rec.Code)
+	}
+	events := c.GetAllSSEEvents()
+	if len(events) != 2 || events[1].EventType != "update" || events[1].LastEventID != "7" {
+		t.Fatalf("stored events = %+v", events)
+	}
+
+	rec = httptest.NewRecorder()
+	c.HandleSSEEvents(rec, httptest.NewRequest("POST", "/sse-events", bytes.NewBufferString("not json")))
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("invalid JSON status = %d, want 400", rec.Code)
+	}
+}
+
+func TestSSE_BufferEvictsOldestAndKeepsTotals(t *testing.T) {
+	t.Parallel()
+	c := setupTestCapture(t)
+
+	events := make([]SSEEvent, MaxSSEEvents+50)
+	for i := range events {
+		events[i] = SSEEvent{ID: "es-1", Event: "message", Data: fmt.Sprintf("m%d", i)}
+	}
+	c.AddSSEEvents(events)
+
+	got := c.GetAllSSEEvents()
+	if len(got) != MaxSSEEvents {
+		t.Fatalf("len = %d, want %d", len(got), MaxSSEEvents)
+	}
+	if got[0].Data != "m50" {
+		t.Fatalf("oldest kept = %q, want m50", got[0].Data)
+	}
+	if c.GetSSETotalAdded() != int64(MaxSSEEvents+50) {
+		t.Fatalf("total added = %d", c.GetSSETotalAdded())
+	}
+}
+
+func TestSSE_BufferHonorsMemoryLimit(t *testing.T) {
+	t.Parallel()
+	c := setupTestCapture(t)
+
+	big := strings.Repeat("x", 512*1024)
+	for i := 0; i < 6; i++ {
+		c.AddSSEEvents([]SSEEvent{{ID: "es-1", Event: "message", Data: big}})
+	}
+	if n := len(c.GetAllSSEEvents()); n >= 6 || n == 0 {
+		t.Fatalf("len = %d, want oldest payloads dropped under the memory cap", n)
+	}
+}
+
+func TestSSE_ClearResetsBuffer(t *testing.T) {
+	t.Parallel()
+	c := setupTestCapture(t)
+	c.AddSSEEvents([]SSEEvent{{ID: "es-1", Event: "open"}, {ID: "es-1", Event: "close"}})
+
+	if n := c.ClearSSEBuffer(); n != 2 {
+		t.Fatalf("cleared = %d, want 2", n)
+	}
+	if len(c.GetAllSSEEvents()) != 0 || c.GetSSETotalAdded() != 0 {
+		t.Fatal("buffer and totals should reset")
+	}
+
+	c.AddSSEEvents([]SSEEvent{{ID: "es-2", Event: "open"}})
+	c.ClearAll()
+	if len(c.GetAllSSEEvents()) != 0 {
+		t.Fatal("ClearAll should clear SSE events")
+	}
+}
@@ -0,0 +1,49 @@
+// Purpose: Keeps per-test storage fingerprints so observe(storage) can report what a test changed.
+// Why: Verifying that a mutation persisted needs a before-state; the page only ever shows the after-state.
+// Docs: docs/features/feature/storage-observation/index.md
+
+package capture
+
+import "time"
+
+// maxStorageBaselines bounds the fingerprints kept; the oldest is dropped first.
+const maxStorageBaselines = 32
+
+// StorageSnapshot fingerprints a page's storage at one moment. Values are kept as short
+// hashes, never as the stored data, so a baseline can sit in memory without holding secrets.
+type StorageSnapshot struct {
+	URL            string
+	TakenAt        time.Time
+	LocalStorage   map[string]string // key -> value hash
+	SessionStorage map[string]string // key -> value hash
+	Cookies        map[string]string // name -> value hash
+	IndexedDB      map[string]int    // "database/store" -> record count (-1 when uncounted)
+}
+
+// SetStorageBaseline records snap as the before-state for testID, replacing any earlier one.
+func (c *Capture) SetStorageBaseline(testID string, snap StorageSnapshot) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.storageBaselines == nil {
+		c.storageBaselines = make(map[string]StorageSnapshot)
+	}
+	if _, exists := c.storageBaselines[testID]; !exists && len(c.storageBaselines) >= maxStorageBaselines {
+		oldestID := ""
+		var oldest time.Time
+		for id, s := range c.storageBaselines {
+			if oldestID == "" || s.TakenAt.Before(oldest) {
+				oldestID, oldest = id, s.TakenAt
+			}
+		}
+		delete(c.storageBaselines, oldestID)
+	}
+	c.storageBaselines[testID] = snap
+}
+
+// GetStorageBaseline returns the before-state recorded for testID.
+func (c *Capture) GetStorageBaseline(testID string) (StorageSnapshot, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	snap, ok := c.storageBaselines[testID]
+	return snap, ok
+}
@@ -0,0 +1,39 @@
+// Purpose: Tests storage baseline storage and oldest-first eviction.
+// Docs: docs/features/feature/storage-observation/index.md
+
+package capture
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestStorageBaseline_SetAndReplace(t *testing.T) {
+	t.Parallel()
+	c := NewCapture()
+	if _, ok := c.GetStorageBaseline("t1"); ok {
+		t.Fatal("unexpected baseline before set")
+	}
+	c.SetStorageBaseline("t1", StorageSnapshot{URL: "https://a.example"})
+	c.SetStorageBaseline("t1", StorageSnapshot{URL: "https://b.example"})
+	snap, ok := c.GetStorageBaseline("t1")
+	if !ok || snap.URL != "https://b.example" {
+		t.Fatalf("baseline = %+v, %v; want replaced URL", snap, ok)
+	}
+}
+
+func TestStorageBaseline_EvictsOldest(t *testing.T) {
+	t.Parallel()
+	c := NewCapture()
+	base := time.Now()
+	for i := 0; i <= maxStorageBaselines; i++ {
+		c.SetStorageBaseline(fmt.Sprintf("t%d", i), StorageSnapshot{TakenAt: base.Add(time.Duration(i) * time.Second)})
+	}
+	if _, ok := c.GetStorageBaseline("t0"); ok {
+		t.Fatal("oldest baseline should be evicted")
+	}
+	if _, ok := c.GetStorageBaseline(fmt.Sprintf("t%d", maxStorageBaselines)); !ok {
+		t.Fatal("newest baseline missing")
+	}
+}
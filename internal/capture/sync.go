@@ -90,6 +90,9 @@ type SyncSettings struct {
 	CaptureActions   bool   `json:"capture_actions"`
 	CspRestricted    bool   `json:"csp_restricted"`
 	CspLevel         string `json:"csp_level"`
+
+	// Browser metadata measured in the tracked page; nil from extensions that predate it.
+	Environment *BrowserEnvironment `json:"environment,omitempty"`
 }
 
 // SyncCommandResult is a command result from the extension.
@@ -142,13 +145,16 @@ type SyncResponse struct {
 }
 
 // SyncCommand is a command from server to extension.
+// IdempotencyKey is stable across delivery retries (Attempt > 1); the extension executes each key once.
 type SyncCommand struct {
-	ID            string          `json:"id"`
-	Type          string          `json:"type"`
-	Params        json.RawMessage `json:"params"`
-	TabID         int             `json:"tab_id,omitempty"`
-	CorrelationID string          `json:"correlation_id,omitempty"`
-	TraceID       string          `json:"trace_id,omitempty"`
+	ID             string          `json:"id"`
+	Type           string          `json:"type"`
+	Params         json.RawMessage `json:"params"`
+	TabID          int             `json:"tab_id,omitempty"`
+	CorrelationID  string          `json:"correlation_id,omitempty"`
+	TraceID        string          `json:"trace_id,omitempty"`
+	IdempotencyKey string          `json:"idempotency_key,omitempty"`
+	Attempt        int             `json:"attempt,omitempty"`
 }
 
 // =============================================================================
@@ -203,13 +209,31 @@ func (c *Capture) HandleSync(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	resp := c.processSync(req, r.Header.Get("X-Kaboom-Client"), r.Header.Get("User-Agent"), syncTransport{
+		endpoint:   "sync",
+		method:     "POST",
+		longPoll:   syncLongPollTimeout(),
+		idlePollMs: 1000,
+	})
+	util.JSONResponse(w, http.StatusOK, resp)
+}
+
+// syncTransport describes how a sync heartbeat arrived and how its reply is delivered.
+type syncTransport struct {
+	endpoint   string        // Polling-log endpoint name.
+	method     string        // Polling-log method name.
+	longPoll   time.Duration // How long to hold the reply waiting for commands. 0 = reply immediately.
+	idlePollMs int           // next_poll_ms when no commands are queued.
+}
+
+// processSync applies one heartbeat and builds its reply. Shared by POST /sync and /extension-ws.
+func (c *Capture) processSync(req SyncRequest, clientID, userAgent string, transport syncTransport) SyncResponse {
 	now := time.Now()
-	clientID := r.Header.Get("X-Kaboom-Client")
 
 	state := c.updateSyncConnectionState(req, clientID, now)
 
 	if !state.wasConnected || state.isReconnect {
-		telemetry.BeaconEvent("extension_connect", map[string]string{"browser": extractBrowserName(r.Header.Get("User-Agent"))})
+		telemetry.BeaconEvent("extension_connect", map[string]string{"browser": extractBrowserName(userAgent)})
 		util.SafeGo(func() {
 			c.emitLifecycleEvent("extension_connected", map[string]any{
 				"ext_session_id":     state.extSessionID,
@@ -230,6 +254,16 @@ func (c *Capture) HandleSync(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	recorder := c.getProtocolRecorder()
+	if recorder != nil {
+		if req.Settings != nil {
+			recorder.RecordSettings(*req.Settings, req.ExtensionVersion)
+		}
+		if len(req.CommandResults) > 0 {
+			recorder.RecordResults(req.CommandResults)
+		}
+	}
+
 	c.processSyncCommandResults(req.CommandResults, clientID)
 	if req.LastCommandAck != "" {
 		c.AcknowledgePendingQuery(req.LastCommandAck)
@@ -252,17 +286,20 @@ func (c *Capture) HandleSync(w http.ResponseWriter, r *http.Request) {
 	c.reconcileInProgressCommandState(req.InProgress)
 
 	pendingQueries := c.GetPendingQueries()
-	if len(pendingQueries) == 0 {
-		c.WaitForPendingQueries(syncLongPollTimeout())
+	if len(pendingQueries) == 0 && transport.longPoll > 0 {
+		c.WaitForPendingQueries(transport.longPoll)
 		pendingQueries = c.GetPendingQueries()
 	}
 
-	c.updateSyncLogs(req, now, state.pilotEnabled, len(pendingQueries))
+	c.updateSyncLogs(req, now, state.pilotEnabled, len(pendingQueries), transport)
 
 	commands := buildSyncCommands(pendingQueries)
+	if recorder != nil && len(commands) > 0 {
+		recorder.RecordCommands(commands)
+	}
 
-	nextPollMs := 1000
-	if len(commands) > 0 {
+	nextPollMs := transport.idlePollMs
+	if len(commands) > 0 && transport.longPoll > 0 {
 		nextPollMs = 200
 	}
 	if shouldEmitSyncSnapshot(req, state, len(commands)) {
@@ -270,6 +307,7 @@ func (c *Capture) HandleSync(w http.ResponseWriter, r *http.Request) {
 			c.emitLifecycleEvent("sync_snapshot", map[string]any{
 				"ext_session_id":       state.extSessionID,
 				"client_id":            clientID,
+				"transport":            transport.endpoint,
 				"pilot_enabled":        state.pilotEnabled,
 				"in_progress_count":    state.inProgressCount,
 				"pending_commands_out": len(commands),
@@ -280,7 +318,7 @@ func (c *Capture) HandleSync(w http.ResponseWriter, r *http.Request) {
 		})
 	}
 
-	resp := SyncResponse{
+	return SyncResponse{
 		Ack:              true,
 		Commands:         commands,
 		NextPollMs:       nextPollMs,
@@ -289,6 +327,4 @@ func (c *Capture) HandleSync(w http.ResponseWriter, r *http.Request) {
 		InstallID:        telemetry.GetInstallID(),
 		CaptureOverrides: c.buildCaptureOverrides(),
 	}
-
-	util.JSONResponse(w, http.StatusOK, resp)
 }
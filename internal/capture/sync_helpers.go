@@ -5,6 +5,7 @@
 package capture
 
 import (
+	"strconv"
 	"strings"
 
 	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/queries"
@@ -15,12 +16,14 @@ func buildSyncCommands(pending []queries.PendingQueryResponse) []SyncCommand {
 	commands := make([]SyncCommand, len(pending))
 	for i, q := range pending {
 		commands[i] = SyncCommand{
-			ID:            q.ID,
-			Type:          q.Type,
-			Params:        q.Params,
-			TabID:         q.TabID,
-			CorrelationID: q.CorrelationID,
-			TraceID:       q.TraceID,
+			ID:             q.ID,
+			Type:           q.Type,
+			Params:         q.Params,
+			TabID:          q.TabID,
+			CorrelationID:  q.CorrelationID,
+			TraceID:        q.TraceID,
+			IdempotencyKey: q.IdempotencyKey,
+			Attempt:        q.Attempt,
 		}
 	}
 	return commands
@@ -41,15 +44,33 @@ func shouldEmitSyncSnapshot(req SyncRequest, state syncConnectionState, commands
 }
 
 func (c *Capture) buildCaptureOverrides() map[string]string {
+	overrides := map[string]string{}
+	limits := c.GetBodyLimits()
+	if limits.CaptureMax != defaultBodyCaptureMax {
+		overrides["body_capture_max"] = strconv.Itoa(limits.CaptureMax)
+	}
+	if limits.BinaryMax > 0 {
+		overrides["binary_capture_max"] = strconv.Itoa(limits.BinaryMax)
+	}
+	if selectors := c.screenshotRedactOverride(); selectors != "" {
+		overrides["screenshot_redact_selectors"] = selectors
+	}
+	if mocks := c.requestMocksOverride(); mocks != "" {
+		overrides["request_mocks"] = mocks
+	}
+	if c.MultiTabCaptureEnabled() {
+		overrides["multi_tab_capture"] = "true"
+	}
+	if region := c.regionOverrideOverride(); region != "" {
+		overrides["region_override"] = region
+	}
 	mode, productionParity, rewrites := c.GetSecurityMode()
 	if mode == SecurityModeNormal {
-		return map[string]string{}
+		return overrides
 	}
 
-	overrides := map[string]string{
-		"security_mode":     mode,
-		"production_parity": "false",
-	}
+	overrides["security_mode"] = mode
+	overrides["production_parity"] = "false"
 	if productionParity {
 		overrides["production_parity"] = "true"
 	}
@@ -39,14 +39,14 @@ func (c *Capture) processSyncCommandResults(results []SyncCommandResult, clientI
 //
 // Failure semantics:
 // - Invalid/missing timestamps are normalized to server receive time.
-func (c *Capture) updateSyncLogs(req SyncRequest, now time.Time, pilotEnabled bool, queryCount int) {
+func (c *Capture) updateSyncLogs(req SyncRequest, now time.Time, pilotEnabled bool, queryCount int, transport syncTransport) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
 	c.logPollingActivity(PollingLogEntry{
 		Timestamp:    now,
-		Endpoint:     "sync",
-		Method:       "POST",
+		Endpoint:     transport.endpoint,
+		Method:       transport.method,
 		ExtSessionID: req.ExtSessionID,
 		PilotEnabled: &pilotEnabled,
 		QueryCount:   queryCount,
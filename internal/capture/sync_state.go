@@ -70,6 +70,10 @@ func (c *Capture) updateSyncConnectionState(req SyncRequest, clientID string, no
 		c.extensionState.trackedTabActive = req.Settings.TrackedTabActive
 		c.extensionState.cspRestricted = req.Settings.CspRestricted
 		c.extensionState.cspLevel = req.Settings.CspLevel
+		if req.Settings.Environment != nil {
+			c.extensionState.environment = *req.Settings.Environment
+			c.extensionState.environmentUpdated = now
+		}
 	}
 	if req.InProgress != nil {
 		c.extensionState.inProgress = normalizeInProgressList(req.InProgress)
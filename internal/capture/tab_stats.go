@@ -0,0 +1,77 @@
+// Purpose: Counts buffered telemetry per browser tab for /health and holds the multi-tab capture switch.
+// Why: With several tabs capturing at once, "which tab is filling the buffers" must be answerable at a glance.
+// Docs: docs/features/feature/multi-tab-attribution/index.md
+
+package capture
+
+import "sort"
+
+// TabBufferStats counts the buffered entries one tab contributed. Tab 0 collects
+// entries with no tab attribution (daemon-side entries, older extensions).
+type TabBufferStats struct {
+	TabID            int `json:"tab_id"`
+	Logs             int `json:"logs"`
+	NetworkBodies    int `json:"network_bodies"`
+	NetworkResources int `json:"network_resources"`
+	WebSocketEvents  int `json:"websocket_events"`
+	SSEEvents        int `json:"sse_events"`
+	Actions          int `json:"actions"`
+}
+
+// GetTabBufferStats returns per-tab entry counts across the capture buffers, ordered by
+// tab ID. Logs live in the server's log store, so the caller passes the tab of each
+// buffered log entry in logTabIDs.
+func (c *Capture) GetTabBufferStats(logTabIDs []int) []TabBufferStats {
+	byTab := map[int]*TabBufferStats{}
+	tab := func(id int) *TabBufferStats {
+		s, ok := byTab[id]
+		if !ok {
+			s = &TabBufferStats{TabID: id}
+			byTab[id] = s
+		}
+		return s
+	}
+	for _, id := range logTabIDs {
+		tab(id).Logs++
+	}
+
+	c.mu.RLock()
+	for i := range c.buffers.networkBodies {
+		tab(c.buffers.networkBodies[i].Body.TabID).NetworkBodies++
+	}
+	for i := range c.networkWaterfall.entries {
+		tab(c.networkWaterfall.entries[i].TabID).NetworkResources++
+	}
+	for i := range c.buffers.wsEvents {
+		tab(c.buffers.wsEvents[i].Event.TabID).WebSocketEvents++
+	}
+	for i := range c.buffers.sseEvents {
+		tab(c.buffers.sseEvents[i].Event.TabID).SSEEvents++
+	}
+	for i := range c.buffers.enhancedActions {
+		tab(c.buffers.enhancedActions[i].Action.TabID).Actions++
+	}
+	c.mu.RUnlock()
+
+	stats := make([]TabBufferStats, 0, len(byTab))
+	for _, s := range byTab {
+		stats = append(stats, *s)
+	}
+	sort.Slice(stats, func(i, j int) bool { return stats[i].TabID < stats[j].TabID })
+	return stats
+}
+
+// SetMultiTabCapture turns capture from every tab on or off. The setting reaches the
+// extension on its next sync; off leaves only the tracked tab capturing.
+func (c *Capture) SetMultiTabCapture(enabled bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.multiTabCapture = enabled
+}
+
+// MultiTabCaptureEnabled reports whether every tab captures telemetry.
+func (c *Capture) MultiTabCaptureEnabled() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.multiTabCapture
+}
@@ -0,0 +1,59 @@
+// Purpose: Tests per-tab buffer counts reported in /health and the multi-tab capture override.
+// Docs: docs/features/feature/multi-tab-attribution/index.md
+
+package capture
+
+import "testing"
+
+func TestGetTabBufferStats_CountsEachTab(t *testing.T) {
+	t.Parallel()
+	c := NewCapture()
+
+	c.AddNetworkBodies([]NetworkBody{
+		{URL: "https://a.test/api", Method: "GET", Status: 200, TabID: 4},
+		{URL: "https://a.test/api/cart", Method: "GET", Status: 200, TabID: 4},
+		{URL: "https://b.test/api", Method: "GET", Status: 500, TabID: 9, FrameID: 2},
+	})
+	c.AddEnhancedActions([]EnhancedAction{{Type: "click", Timestamp: 1, TabID: 9}})
+	c.AddWebSocketEvents([]WebSocketEvent{{Event: "open", ID: "ws-1", TabID: 4}})
+	c.AddSSEEvents([]SSEEvent{{Event: "open", ID: "sse-1"}})
+	entries := []NetworkWaterfallEntry{{URL: "https://b.test/app.js"}}
+	AttributeWaterfallTab(entries, 9)
+	c.AddNetworkWaterfallEntries(entries, "https://b.test/")
+
+	stats := c.GetTabBufferStats([]int{4, 0, 4})
+	if len(stats) != 3 {
+		t.Fatalf("stats = %+v, want tabs 0, 4, 9", stats)
+	}
+	want := []TabBufferStats{
+		{TabID: 0, Logs: 1, SSEEvents: 1},
+		{TabID: 4, Logs: 2, NetworkBodies: 2, WebSocketEvents: 1},
+		{TabID: 9, NetworkBodies: 1, NetworkResources: 1, Actions: 1},
+	}
+	for i := range want {
+		if stats[i] != want[i] {
+			t.Errorf("stats[%d] = %+v, want %+v", i, stats[i], want[i])
+		}
+	}
+}
+
+func TestAttributeWaterfallTab_KeepsExistingTab(t *testing.T) {
+	t.Parallel()
+	entries := []NetworkWaterfallEntry{{URL: "https://a.test/x.js", TabID: 3}, {URL: "https://a.test/y.js"}}
+	AttributeWaterfallTab(entries, 8)
+	if entries[0].TabID != 3 || entries[1].TabID != 8 {
+		t.Fatalf("tabs = %d, %d; want 3, 8", entries[0].TabID, entries[1].TabID)
+	}
+}
+
+func TestMultiTabCapture_SyncsAsOverride(t *testing.T) {
+	t.Parallel()
+	c := NewCapture()
+	if _, ok := c.buildCaptureOverrides()["multi_tab_capture"]; ok {
+		t.Fatal("multi_tab_capture override should be absent by default")
+	}
+	c.SetMultiTabCapture(true)
+	if got := c.buildCaptureOverrides()["multi_tab_capture"]; got != "true" {
+		t.Fatalf("multi_tab_capture override = %q, want true", got)
+	}
+}
@@ -0,0 +1,12 @@
+// Purpose: Exposes the Capture-owned test-runner event store that segments telemetry by test.
+// Why: POST /test-events writes here and observe(what="timeline") reads segments to attribute captured events.
+// Docs: docs/features/feature/test-runner-events/index.md
+
+package capture
+
+import "github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/testevents"
+
+// TestEvents returns the test-runner event store. Store has its own lock.
+func (c *Capture) TestEvents() *testevents.Store {
+	return c.testEvents
+}
@@ -41,6 +41,8 @@ type (
 	CircuitBreaker    = circuit.CircuitBreaker    // Rate limiting + circuit breaker state machine
 	HealthResponse    = circuit.HealthResponse    // GET /health response
 	RateLimitResponse = circuit.RateLimitResponse // 429 response body
+	CircuitThresholds = circuit.Thresholds        // Tunable circuit trip points
+	CircuitStatus     = circuit.Status            // configure(what="circuit") state
 
 	// Recording subsystem types — moved to internal/recording package.
 	RecordingManager = recording.Manager          // Recording lifecycle, playback, and log-diff engine
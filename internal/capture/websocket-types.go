@@ -59,6 +59,7 @@ type connectionState struct {
 	outgoing   directionStats
 	sampling   bool
 	lastSample *SamplingInfo
+	sampler    *wsSampler // server-side ws_sampling state; nil when no policy matches
 }
 
 type directionStats struct {
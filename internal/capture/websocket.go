@@ -8,6 +8,8 @@ import (
 	"strings"
 	"time"
 
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/buildevents"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/changefeed"
 	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/util"
 )
 
@@ -39,18 +41,30 @@ func detectWSBinaryFormat(event *WebSocketEvent) {
 // Failure semantics:
 // - Over-capacity batches are accepted then oldest entries are evicted.
 // - Unknown event kinds are retained in wsEvents even if they do not change connection state.
+// - The network callback, if set, is invoked after the lock is released.
+// - Dev-server HMR messages are also recorded as build events (buildEvents has its own lock).
 func (c *Capture) AddWebSocketEvents(events []WebSocketEvent) {
-	c.mu.Lock()
-	defer c.mu.Unlock()
+	cb, pageURL := func() (func(NetworkActivity), string) {
+		c.mu.Lock()
+		defer c.mu.Unlock()
 
-	now := time.Now()
+		now := time.Now()
 
-	activeTestIDs := make([]string, 0)
-	for testID := range c.extensionState.activeTestIDs {
-		activeTestIDs = append(activeTestIDs, testID)
-	}
+		activeTestIDs := make([]string, 0)
+		for testID := range c.extensionState.activeTestIDs {
+			activeTestIDs = append(activeTestIDs, testID)
+		}
 
-	c.buffers.appendWebSocketEvents(events, activeTestIDs, now, c.wsConnections.trackEvent)
+		events = c.wsConnections.trackAndSample(events, c.wsSampling, now)
+		c.buffers.appendWebSocketEvents(events, activeTestIDs, now)
+		c.changes.Append(changefeed.KindWebSocket, changefeed.WebSocketPayloads(events)...)
+		return c.networkCallback, c.extensionState.trackedTabURL
+	}()
+	c.relieveMemoryPressure()
+	c.buildEvents.Ingest(buildevents.FromWebSocket(events, time.Now()))
+	if cb != nil && len(events) > 0 {
+		cb(NetworkActivity{PageURL: pageURL, WebSocket: events})
+	}
 }
 
 // GetWebSocketEventCount returns the current number of buffered events
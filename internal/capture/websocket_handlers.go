@@ -10,12 +10,14 @@ import (
 )
 
 // HandleWebSocketEvents handles POST /websocket-events from the extension.
-// Reads go through GET /telemetry?type=websocket_events.
+// Reads go through GET /telemetry?type=websocket_events. A rejected batch still
+// keeps its close and error events before the 429.
 func (c *Capture) HandleWebSocketEvents(w http.ResponseWriter, r *http.Request) {
 	if !util.RequireMethod(w, r, "POST") {
 		return
 	}
-	body, ok := c.readIngestBody(w, r)
+	rejected := c.CheckRateLimit()
+	body, ok := c.readBody(w, r)
 	if !ok {
 		return
 	}
@@ -26,10 +28,16 @@ func (c *Capture) HandleWebSocketEvents(w http.ResponseWriter, r *http.Request)
 		util.JSONResponse(w, http.StatusBadRequest, map[string]string{"error": "Invalid JSON"})
 		return
 	}
-	if !c.recordAndRecheck(w, len(payload.Events)) {
+	if !rejected {
+		c.RecordEvents(len(payload.Events))
+		rejected = c.circuit.OverWindowLimit()
+	}
+	if rejected {
+		c.keepPriorityWebSocketEvents(payload.Events)
+		c.WriteRateLimitResponse(w)
 		return
 	}
-	c.AddWebSocketEvents(payload.Events)
+	c.AddWebSocketEvents(c.withoutKeptPriority(payload.Events))
 	w.WriteHeader(http.StatusOK)
 }
 
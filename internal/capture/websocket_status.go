@@ -92,7 +92,7 @@ func buildWSConnection(conn *connectionState) WebSocketConnection {
 				Bytes:     conn.outgoing.bytes,
 			},
 		},
-		Sampling: WebSocketSamplingStatus{Active: conn.sampling},
+		Sampling: conn.samplingStatus(),
 	}
 	if openedTime := util.ParseTimestamp(conn.openedAt); !openedTime.IsZero() {
 		wc.Duration = formatDuration(time.Since(openedTime))
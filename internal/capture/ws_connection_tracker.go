@@ -60,6 +60,9 @@ func (t *WSConnectionTracker) trackConnClose(event WebSocketEvent) {
 	}
 	closed.TotalMessages.Incoming = conn.incoming.total
 	closed.TotalMessages.Outgoing = conn.outgoing.total
+	if conn.sampler != nil {
+		closed.SampledDropped = conn.sampler.dropped
+	}
 
 	t.closedConns = append(t.closedConns, closed)
 	if len(t.closedConns) > maxClosedConns {
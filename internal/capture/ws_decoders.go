@@ -0,0 +1,12 @@
+// Purpose: Exposes the Capture-owned WebSocket payload decoder registry.
+// Why: configure(what="register_proto") writes descriptor sets here; observe(what="websocket_events") decodes with them.
+// Docs: docs/features/feature/websocket-decoding/index.md
+
+package capture
+
+import "github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/wsdecode"
+
+// WSDecoders returns the WebSocket decoder registry. Registry has its own lock.
+func (c *Capture) WSDecoders() *wsdecode.Registry {
+	return c.wsDecoders
+}
@@ -0,0 +1,230 @@
+// Purpose: Applies per-connection WebSocket sampling policies at ingest and tracks achieved rates and drops.
+// Why: Chatty sockets were sampled implicitly; agents need to choose what a high-frequency stream keeps and see what was dropped.
+// Docs: docs/features/feature/websocket-sampling/index.md
+
+package capture
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/util"
+)
+
+// WSSamplingPolicy keeps at most Rate messages per second on each connection whose URL
+// contains URLPattern; an empty pattern matches every connection. Events whose type
+// (open, close, error) or message direction (incoming, outgoing) is in AlwaysKeep bypass it.
+type WSSamplingPolicy struct {
+	URLPattern string   `json:"url_pattern"`
+	Rate       float64  `json:"rate"`
+	AlwaysKeep []string `json:"always_keep"`
+}
+
+// WSAlwaysKeepKinds lists the values accepted in WSSamplingPolicy.AlwaysKeep.
+var WSAlwaysKeepKinds = []string{"open", "close", "error", "incoming", "outgoing"}
+
+// DefaultWSAlwaysKeep applies when a policy is set without always_keep: lifecycle events are never dropped.
+var DefaultWSAlwaysKeep = []string{"open", "close", "error"}
+
+// maxWSSamplingPolicies bounds the policy list; the first matching pattern wins.
+const maxWSSamplingPolicies = 20
+
+// keeps reports whether the event bypasses the rate limit.
+func (p WSSamplingPolicy) keeps(event WebSocketEvent) bool {
+	for _, kind := range p.AlwaysKeep {
+		if kind == event.Event || (event.Event == "message" && kind == event.Direction) {
+			return true
+		}
+	}
+	return false
+}
+
+// burst is the token bucket capacity: one second of traffic, at least one message.
+// A zero rate keeps nothing beyond AlwaysKeep.
+func (p WSSamplingPolicy) burst() float64 {
+	if p.Rate <= 0 {
+		return 0
+	}
+	return max(p.Rate, 1)
+}
+
+// matchWSSamplingPolicy returns the first policy whose pattern the URL contains.
+func matchWSSamplingPolicy(policies []WSSamplingPolicy, url string) (WSSamplingPolicy, bool) {
+	for _, p := range policies {
+		if strings.Contains(url, p.URLPattern) {
+			return p, true
+		}
+	}
+	return WSSamplingPolicy{}, false
+}
+
+// wsSampler is one connection's token bucket and counters under its current policy.
+type wsSampler struct {
+	pattern   string
+	tokens    float64
+	last      time.Time
+	seen      int
+	kept      int
+	dropped   int
+	keptTimes []time.Time // kept-event timestamps within rateWindow, for the achieved rate
+}
+
+// admit decides one event at time t. A policy change restarts the bucket and counters.
+func (s *wsSampler) admit(p WSSamplingPolicy, event WebSocketEvent, t time.Time) bool {
+	if s.last.IsZero() || s.pattern != p.URLPattern {
+		*s = wsSampler{pattern: p.URLPattern, tokens: p.burst(), last: t}
+	}
+	s.seen++
+	keep := p.keeps(event)
+	if !keep {
+		if elapsed := t.Sub(s.last); elapsed > 0 {
+			s.tokens = min(p.burst(), s.tokens+elapsed.Seconds()*p.Rate)
+			s.last = t
+		}
+		if s.tokens >= 1 {
+			s.tokens--
+			keep = true
+		}
+	}
+	if keep {
+		s.kept++
+		s.keptTimes = appendAndPrune(s.keptTimes, t)
+	} else {
+		s.dropped++
+	}
+	return keep
+}
+
+// trackAndSample applies every event to connection state and returns the events the
+// sampling policies keep. Events on connections without an open record are kept.
+// Caller must hold the parent lock.
+func (t *WSConnectionTracker) trackAndSample(events []WebSocketEvent, policies []WSSamplingPolicy, now time.Time) []WebSocketEvent {
+	if len(policies) == 0 {
+		for i := range events {
+			t.trackEvent(events[i])
+		}
+		return events
+	}
+	kept := make([]WebSocketEvent, 0, len(events))
+	for i := range events {
+		// open creates the connection record the decision needs; close removes it,
+		// so it is decided before tracking moves the counters into closed history.
+		if events[i].Event == "open" {
+			t.trackEvent(events[i])
+		}
+		keep := t.sampleEvent(events[i], policies, now)
+		if events[i].Event != "open" {
+			t.trackEvent(events[i])
+		}
+		if keep {
+			kept = append(kept, events[i])
+		}
+	}
+	return kept
+}
+
+// sampleEvent decides one event against the policy matching its connection's URL.
+func (t *WSConnectionTracker) sampleEvent(event WebSocketEvent, policies []WSSamplingPolicy, now time.Time) bool {
+	conn := t.connections[event.ID]
+	if conn == nil {
+		return true
+	}
+	policy, ok := matchWSSamplingPolicy(policies, conn.url)
+	if !ok {
+		conn.sampler = nil
+		return true
+	}
+	if conn.sampler == nil {
+		conn.sampler = &wsSampler{}
+	}
+	at := util.ParseTimestamp(event.Timestamp)
+	if at.IsZero() {
+		at = now
+	}
+	return conn.sampler.admit(policy, event, at)
+}
+
+// samplingStatus reports extension-side sampling and the server policy in effect.
+func (conn *connectionState) samplingStatus() WebSocketSamplingStatus {
+	st := WebSocketSamplingStatus{Active: conn.sampling}
+	if conn.sampling {
+		st.Reason = "extension adaptive sampling"
+		if conn.lastSample != nil {
+			st.Rate = conn.lastSample.Rate
+		}
+	}
+	if s := conn.sampler; s != nil {
+		st.Active = true
+		st.Reason = "ws_sampling policy"
+		if conn.sampling {
+			st.Reason = "ws_sampling policy and extension adaptive sampling"
+		}
+		pattern := s.pattern
+		st.Policy = &pattern
+		st.AchievedRate = calcRate(s.keptTimes)
+		st.Seen, st.Kept, st.Dropped = s.seen, s.kept, s.dropped
+	}
+	return st
+}
+
+// SetWSSamplingPolicy adds a policy, replacing one with the same url_pattern in place.
+// Nil AlwaysKeep becomes DefaultWSAlwaysKeep.
+func (c *Capture) SetWSSamplingPolicy(p WSSamplingPolicy) error {
+	if p.Rate < 0 {
+		return fmt.Errorf("rate must be >= 0 messages per second")
+	}
+	if p.AlwaysKeep == nil {
+		p.AlwaysKeep = slices.Clone(DefaultWSAlwaysKeep)
+	}
+	for _, kind := range p.AlwaysKeep {
+		if !slices.Contains(WSAlwaysKeepKinds, kind) {
+			return fmt.Errorf("unknown always_keep kind %q (valid: %s)", kind, strings.Join(WSAlwaysKeepKinds, ", "))
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i := range c.wsSampling {
+		if c.wsSampling[i].URLPattern == p.URLPattern {
+			c.wsSampling[i] = p
+			return nil
+		}
+	}
+	if len(c.wsSampling) >= maxWSSamplingPolicies {
+		return fmt.Errorf("at most %d sampling policies; remove one first", maxWSSamplingPolicies)
+	}
+	c.wsSampling = append(c.wsSampling, p)
+	return nil
+}
+
+// RemoveWSSamplingPolicy drops the policy with the given url_pattern.
+func (c *Capture) RemoveWSSamplingPolicy(urlPattern string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	before := len(c.wsSampling)
+	c.wsSampling = slices.DeleteFunc(c.wsSampling, func(p WSSamplingPolicy) bool { return p.URLPattern == urlPattern })
+	return len(c.wsSampling) < before
+}
+
+// ClearWSSamplingPolicies drops every policy and returns how many were removed.
+func (c *Capture) ClearWSSamplingPolicies() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	n := len(c.wsSampling)
+	c.wsSampling = nil
+	return n
+}
+
+// GetWSSamplingPolicies returns a copy of the policies in match order.
+func (c *Capture) GetWSSamplingPolicies() []WSSamplingPolicy {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	out := make([]WSSamplingPolicy, len(c.wsSampling))
+	for i, p := range c.wsSampling {
+		p.AlwaysKeep = slices.Clone(p.AlwaysKeep)
+		out[i] = p
+	}
+	return out
+}
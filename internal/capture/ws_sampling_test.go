@@ -0,0 +1,132 @@
+// Purpose: Tests for per-connection WebSocket sampling policies.
+// Docs: docs/features/feature/websocket-sampling/index.md
+
+package capture
+
+import (
+	"testing"
+	"time"
+)
+
+func wsEventAt(base time.Time, offset time.Duration, id, event, direction string) WebSocketEvent {
+	return WebSocketEvent{
+		Timestamp: base.Add(offset).UTC().Format(time.RFC3339Nano),
+		Type:      "websocket",
+		Event:     event,
+		ID:        id,
+		Direction: direction,
+		URL:       "wss://feed.example.com/ticks",
+	}
+}
+
+func TestWSSamplingPolicyKeepsRateAndLifecycle(t *testing.T) {
+	t.Parallel()
+	c := setupTestCapture(t)
+	if err := c.SetWSSamplingPolicy(WSSamplingPolicy{URLPattern: "feed.example.com", Rate: 1}); err != nil {
+		t.Fatalf("SetWSSamplingPolicy: %v", err)
+	}
+
+	base := time.Now().Add(-3 * time.Second)
+	events := []WebSocketEvent{wsEventAt(base, 0, "ws-1", "open", "")}
+	// 8 messages 250ms apart: the bucket holds one token and refills one per second.
+	for i := range 8 {
+		events = append(events, wsEventAt(base, time.Duration(i)*250*time.Millisecond, "ws-1", "message", "incoming"))
+	}
+	events = append(events, wsEventAt(base, 1800*time.Millisecond, "ws-1", "error", ""))
+	c.AddWebSocketEvents(events)
+
+	status := c.GetWebSocketStatus(WebSocketStatusFilter{})
+	if len(status.Connections) != 1 {
+		t.Fatalf("connections = %d, want 1", len(status.Connections))
+	}
+	s := status.Connections[0].Sampling
+	if !s.Active || s.Policy == nil || *s.Policy != "feed.example.com" {
+		t.Fatalf("sampling = %+v, want active feed.example.com policy", s)
+	}
+	if s.Seen != 10 || s.Kept != 4 || s.Dropped != 6 {
+		t.Errorf("seen/kept/dropped = %d/%d/%d, want 10/4/6", s.Seen, s.Kept, s.Dropped)
+	}
+	if s.AchievedRate <= 0 {
+		t.Errorf("achieved_rate = %v, want > 0", s.AchievedRate)
+	}
+	if got := c.GetWebSocketEventCount(); got != 4 {
+		t.Errorf("buffered events = %d, want 4 (open, 2 messages, error)", got)
+	}
+	// Tracking still sees every message even when the buffer keeps few.
+	if total := status.Connections[0].MessageRate.Incoming.Total; total != 8 {
+		t.Errorf("incoming total = %d, want 8", total)
+	}
+
+	c.AddWebSocketEvents([]WebSocketEvent{wsEventAt(base, 2*time.Second, "ws-1", "close", "")})
+	status = c.GetWebSocketStatus(WebSocketStatusFilter{})
+	if len(status.Closed) != 1 || status.Closed[0].SampledDropped != 6 {
+		t.Fatalf("closed = %+v, want sampled_dropped 6", status.Closed)
+	}
+}
+
+func TestWSSamplingPolicyUnmatchedConnectionsKeepEverything(t *testing.T) {
+	t.Parallel()
+	c := setupTestCapture(t)
+	if err := c.SetWSSamplingPolicy(WSSamplingPolicy{URLPattern: "other.example.com", Rate: 0}); err != nil {
+		t.Fatalf("SetWSSamplingPolicy: %v", err)
+	}
+	base := time.Now()
+	c.AddWebSocketEvents([]WebSocketEvent{
+		wsEventAt(base, 0, "ws-1", "open", ""),
+		wsEventAt(base, 0, "ws-1", "message", "incoming"),
+		wsEventAt(base, 0, "ws-1", "message", "outgoing"),
+	})
+	if got := c.GetWebSocketEventCount(); got != 3 {
+		t.Errorf("buffered events = %d, want 3", got)
+	}
+	if s := c.GetWebSocketStatus(WebSocketStatusFilter{}).Connections[0].Sampling; s.Policy != nil {
+		t.Errorf("sampling policy = %q, want none", *s.Policy)
+	}
+}
+
+func TestWSSamplingPolicyAlwaysKeepDirection(t *testing.T) {
+	t.Parallel()
+	c := setupTestCapture(t)
+	if err := c.SetWSSamplingPolicy(WSSamplingPolicy{Rate: 0, AlwaysKeep: []string{"open", "outgoing"}}); err != nil {
+		t.Fatalf("SetWSSamplingPolicy: %v", err)
+	}
+	base := time.Now()
+	c.AddWebSocketEvents([]WebSocketEvent{
+		wsEventAt(base, 0, "ws-1", "open", ""),
+		wsEventAt(base, 0, "ws-1", "message", "incoming"),
+		wsEventAt(base, 0, "ws-1", "message", "outgoing"),
+	})
+	got := c.GetWebSocketEvents(WebSocketEventFilter{})
+	if len(got) != 2 {
+		t.Fatalf("buffered events = %d, want 2 (open, outgoing)", len(got))
+	}
+	for _, e := range got {
+		if e.Direction == "incoming" {
+			t.Errorf("incoming message kept with rate 0: %+v", e)
+		}
+	}
+}
+
+func TestSetWSSamplingPolicyValidation(t *testing.T) {
+	t.Parallel()
+	c := setupTestCapture(t)
+	if err := c.SetWSSamplingPolicy(WSSamplingPolicy{Rate: -1}); err == nil {
+		t.Error("negative rate accepted")
+	}
+	if err := c.SetWSSamplingPolicy(WSSamplingPolicy{Rate: 1, AlwaysKeep: []string{"ping"}}); err == nil {
+		t.Error("unknown always_keep kind accepted")
+	}
+	if err := c.SetWSSamplingPolicy(WSSamplingPolicy{URLPattern: "a", Rate: 1}); err != nil {
+		t.Fatalf("SetWSSamplingPolicy: %v", err)
+	}
+	if err := c.SetWSSamplingPolicy(WSSamplingPolicy{URLPattern: "a", Rate: 5}); err != nil {
+		t.Fatalf("replace: %v", err)
+	}
+	policies := c.GetWSSamplingPolicies()
+	if len(policies) != 1 || policies[0].Rate != 5 || len(policies[0].AlwaysKeep) != len(DefaultWSAlwaysKeep) {
+		t.Fatalf("policies = %+v, want one replaced policy with default always_keep", policies)
+	}
+	if !c.RemoveWSSamplingPolicy("a") || c.RemoveWSSamplingPolicy("a") {
+		t.Error("RemoveWSSamplingPolicy should succeed once")
+	}
+}
@@ -0,0 +1,21 @@
+// Purpose: Package changefeed — unified, sequence-numbered delta log across capture buffers.
+// Why: Lets polling agents resume after a reconnect without re-reading every buffer or silently missing evicted data.
+// Docs: docs/features/feature/cursor-pagination/index.md
+
+/*
+Package changefeed assigns every buffer append (console, network, WebSocket, action,
+alert) a monotonic global sequence number and retains a bounded window of compact
+typed deltas.
+
+Key types:
+  - Feed: bounded ring of deltas with its own lock (a leaf lock — it never calls out).
+  - Delta: one sequenced change with a kind and a compact payload.
+  - Page: the result of Since, including an explicit Gap when the caller's cursor
+    fell behind the retained window.
+
+Key functions:
+  - New: creates a Feed with the given capacity.
+  - Append: records deltas of one kind and returns the last assigned sequence.
+  - Since: returns deltas strictly after a sequence, in order.
+*/
+package changefeed
@@ -0,0 +1,237 @@
+// Purpose: Bounded, sequence-numbered delta log with resumable reads and explicit gap reporting.
+// Why: Per-buffer cursors drift independently; one global sequence gives agents a single resume point.
+// Docs: docs/features/feature/cursor-pagination/index.md
+
+package changefeed
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Kind identifies the buffer a delta came from.
+type Kind string
+
+const (
+	KindConsole   Kind = "console"
+	KindNetwork   Kind = "network"
+	KindWebSocket Kind = "ws"
+	KindAction    Kind = "action"
+	KindAlert     Kind = "alert"
+)
+
+// Kinds lists every delta kind in documentation order.
+var Kinds = []Kind{KindConsole, KindNetwork, KindWebSocket, KindAction, KindAlert}
+
+// DefaultCapacity is the number of deltas retained before the oldest are evicted.
+const DefaultCapacity = 5000
+
+// Delta is one sequenced change.
+type Delta struct {
+	Seq       int64          `json:"seq"`
+	Kind      Kind           `json:"type"`
+	Timestamp string         `json:"ts"`
+	Data      map[string]any `json:"data"`
+}
+
+// Gap reports deltas that were evicted before the caller read them.
+// FromSeq..ToSeq is inclusive.
+type Gap struct {
+	FromSeq int64 `json:"from_seq"`
+	ToSeq   int64 `json:"to_seq"`
+	Missed  int64 `json:"missed"`
+}
+
+// Page is the result of a Since read.
+//
+// Invariants:
+//   - Deltas are in ascending Seq order.
+//   - NextSeq is the after_seq to pass on the next call; it never goes backwards for a stable FeedID.
+//   - Reset is true when the cursor is ahead of this feed (daemon restart or foreign feed_id);
+//     the page then starts from the oldest retained delta.
+type Page struct {
+	FeedID    string  `json:"feed_id"`
+	Deltas    []Delta `json:"deltas"`
+	NextSeq   int64   `json:"next_seq"`
+	LatestSeq int64   `json:"latest_seq"`
+	OldestSeq int64   `json:"oldest_seq"`
+	HasMore   bool    `json:"has_more"`
+	Gap       *Gap    `json:"gap,omitempty"`
+	Reset     bool    `json:"reset,omitempty"`
+}
+
+// Feed is a bounded ring of deltas sharing one monotonic sequence.
+//
+// Lock hierarchy: Feed.mu is a leaf lock. Append may be called while holding
+// Capture.mu or AlertBuffer.Mu; Feed never calls out while holding mu. The
+// onAppend observer runs after mu is released but still under the caller's
+// locks, so it must not block or call back into capture.
+type Feed struct {
+	id       string
+	capacity int
+
+	mu       sync.Mutex
+	deltas   []Delta
+	lastSeq  int64
+	now      func() time.Time
+	onAppend func([]Delta)
+	// appended is closed and replaced on every Append to wake WaitFor callers.
+	appended chan struct{}
+}
+
+// New creates a Feed retaining up to capacity deltas (DefaultCapacity if <= 0).
+func New(capacity int) *Feed {
+	if capacity <= 0 {
+		capacity = DefaultCapacity
+	}
+	return &Feed{
+		id:       strconv.FormatInt(time.Now().UnixNano(), 36),
+		capacity: capacity,
+		deltas:   make([]Delta, 0, 64),
+		now:      time.Now,
+		appended: make(chan struct{}),
+	}
+}
+
+// ID returns the feed identity. It changes on every daemon start so clients can
+// tell a restart apart from an idle feed.
+func (f *Feed) ID() string {
+	return f.id
+}
+
+// Append records one delta per payload and returns the last assigned sequence.
+// Nil receivers and empty batches are no-ops.
+func (f *Feed) Append(kind Kind, payloads ...map[string]any) int64 {
+	if f == nil || len(payloads) == 0 {
+		return 0
+	}
+	last, added, observer := func() (int64, []Delta, func([]Delta)) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		ts := f.now().UTC().Format(time.RFC3339Nano)
+		first := len(f.deltas)
+		for _, p := range payloads {
+			f.lastSeq++
+			f.deltas = append(f.deltas, Delta{Seq: f.lastSeq, Kind: kind, Timestamp: ts, Data: p})
+		}
+		var added []Delta
+		if f.onAppend != nil {
+			added = make([]Delta, len(payloads))
+			copy(added, f.deltas[first:])
+		}
+		if over := len(f.deltas) - f.capacity; over > 0 {
+			// Copy so evicted payloads can be collected.
+			kept := make([]Delta, f.capacity)
+			copy(kept, f.deltas[over:])
+			f.deltas = kept
+		}
+		close(f.appended)
+		f.appended = make(chan struct{})
+		return f.lastSeq, added, f.onAppend
+	}()
+
+	if observer != nil {
+		observer(added)
+	}
+	return last
+}
+
+// SetOnAppend registers an observer that receives each appended batch after
+// the feed lock is released. Pass nil to remove it.
+func (f *Feed) SetOnAppend(fn func([]Delta)) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.onAppend = fn
+}
+
+// WaitFor blocks until a delta with Seq > afterSeq satisfies match (any delta when
+// match is nil) and returns its sequence. Returns false when ctx ends first.
+// Deltas evicted between wake-ups are not inspected. match runs under the feed lock
+// and must not call back into the feed.
+func (f *Feed) WaitFor(ctx context.Context, afterSeq int64, match func(Delta) bool) (int64, bool) {
+	for {
+		seq, found, appended := f.scanAfter(afterSeq, match)
+		if found {
+			return seq, true
+		}
+		afterSeq = seq
+		select {
+		case <-appended:
+		case <-ctx.Done():
+			return 0, false
+		}
+	}
+}
+
+// scanAfter looks for a matching delta after afterSeq. When none matches it returns
+// the latest sequence scanned and the channel closed by the next Append.
+func (f *Feed) scanAfter(afterSeq int64, match func(Delta) bool) (int64, bool, <-chan struct{}) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	start := len(f.deltas) - int(f.lastSeq-afterSeq)
+	if start < 0 {
+		start = 0
+	}
+	if start > len(f.deltas) {
+		start = len(f.deltas)
+	}
+	for _, d := range f.deltas[start:] {
+		if match == nil || match(d) {
+			return d.Seq, true, nil
+		}
+	}
+	return f.lastSeq, false, f.appended
+}
+
+// LatestSeq returns the most recently assigned sequence (0 if nothing was appended).
+func (f *Feed) LatestSeq() int64 {
+	if f == nil {
+		return 0
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.lastSeq
+}
+
+// Since returns up to limit deltas with Seq > afterSeq, optionally restricted to kinds.
+//
+// Failure semantics:
+// - A cursor older than the retained window yields a Gap and resumes at the oldest delta.
+// - A cursor ahead of LatestSeq, or a feedID from another daemon, yields Reset.
+// - Filtered-out deltas still advance NextSeq so they are not rescanned.
+func (f *Feed) Since(feedID string, afterSeq int64, limit int, kinds map[Kind]bool) Page {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	page := Page{FeedID: f.id, LatestSeq: f.lastSeq, Deltas: []Delta{}}
+	if len(f.deltas) > 0 {
+		page.OldestSeq = f.deltas[0].Seq
+	}
+	if afterSeq < 0 || afterSeq > f.lastSeq || (feedID != "" && feedID != f.id) {
+		page.Reset = afterSeq != 0 || feedID != ""
+		afterSeq = 0
+	}
+	if !page.Reset && page.OldestSeq > afterSeq+1 {
+		page.Gap = &Gap{FromSeq: afterSeq + 1, ToSeq: page.OldestSeq - 1, Missed: page.OldestSeq - 1 - afterSeq}
+	}
+
+	page.NextSeq = afterSeq
+	start := len(f.deltas) - int(f.lastSeq-afterSeq)
+	if start < 0 {
+		start = 0
+	}
+	for _, d := range f.deltas[start:] {
+		if limit > 0 && len(page.Deltas) >= limit {
+			page.HasMore = true
+			return page
+		}
+		page.NextSeq = d.Seq
+		if len(kinds) > 0 && !kinds[d.Kind] {
+			continue
+		}
+		page.Deltas = append(page.Deltas, d)
+	}
+	return page
+}
@@ -0,0 +1,144 @@
+// Purpose: Tests sequence assignment, resumable reads, kind filtering, eviction gaps, restart resets, and blocking waits.
+// Docs: docs/features/feature/cursor-pagination/index.md
+
+package changefeed
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/types"
+)
+
+func TestFeed_SinceResumesInOrderAndFilters(t *testing.T) {
+	t.Parallel()
+	f := New(10)
+	f.Append(KindConsole, ConsolePayloads([]types.LogEntry{{"level": "error", "message": "boom"}})...)
+	f.Append(KindNetwork, NetworkPayloads([]types.NetworkBody{{Method: "GET", URL: "/a", Status: 500}, {Method: "GET", URL: "/b", Status: 200}})...)
+	if last := f.Append(KindAlert, AlertPayload(types.Alert{Severity: "error", Title: "t"})); last != 4 {
+		t.Fatalf("last seq = %d, want 4", last)
+	}
+
+	page := f.Since("", 0, 2, nil)
+	if len(page.Deltas) != 2 || page.Deltas[0].Seq != 1 || page.Deltas[1].Kind != KindNetwork || !page.HasMore || page.NextSeq != 2 {
+		t.Fatalf("first page = %+v", page)
+	}
+	if page.Gap != nil || page.Reset {
+		t.Errorf("fresh feed should have no gap/reset: %+v", page)
+	}
+	if page.Deltas[0].Data["message"] != "boom" {
+		t.Errorf("console payload = %v", page.Deltas[0].Data)
+	}
+
+	page = f.Since(page.FeedID, page.NextSeq, 0, nil)
+	if len(page.Deltas) != 2 || page.Deltas[0].Seq != 3 || page.HasMore || page.NextSeq != 4 {
+		t.Fatalf("second page = %+v", page)
+	}
+
+	page = f.Since("", 0, 0, map[Kind]bool{KindAlert: true})
+	if len(page.Deltas) != 1 || page.Deltas[0].Kind != KindAlert || page.NextSeq != 4 {
+		t.Fatalf("filtered page = %+v", page)
+	}
+	if page = f.Since("", 4, 0, nil); len(page.Deltas) != 0 || page.NextSeq != 4 {
+		t.Fatalf("caught-up page = %+v", page)
+	}
+}
+
+func TestFeed_EvictionReportsGap(t *testing.T) {
+	t.Parallel()
+	f := New(3)
+	for i := 0; i < 7; i++ {
+		f.Append(KindAction, map[string]any{"i": i})
+	}
+	page := f.Since("", 2, 0, nil)
+	if page.OldestSeq != 5 || page.Gap == nil || *page.Gap != (Gap{FromSeq: 3, ToSeq: 4, Missed: 2}) {
+		t.Fatalf("gap = %+v (oldest %d)", page.Gap, page.OldestSeq)
+	}
+	if len(page.Deltas) != 3 || page.Deltas[0].Seq != 5 {
+		t.Fatalf("deltas after gap = %+v", page.Deltas)
+	}
+	if page = f.Since("", 4, 0, nil); page.Gap != nil {
+		t.Errorf("cursor at oldest-1 is contiguous: %+v", page.Gap)
+	}
+}
+
+func TestFeed_ResetOnForeignCursor(t *testing.T) {
+	t.Parallel()
+	f := New(10)
+	f.Append(KindWebSocket, WebSocketPayloads([]types.WebSocketEvent{{Event: "open", ID: "c1"}})...)
+
+	for name, page := range map[string]Page{
+		"ahead":        f.Since("", 99, 0, nil),
+		"foreign feed": f.Since("other", 1, 0, nil),
+	} {
+		if !page.Reset || len(page.Deltas) != 1 || page.Deltas[0].Seq != 1 {
+			t.Errorf("%s: page = %+v", name, page)
+		}
+	}
+	if page := f.Since(f.ID(), 0, 0, nil); page.Reset {
+		t.Errorf("matching feed id should not reset: %+v", page)
+	}
+}
+
+func TestFeed_NilAppendIsNoop(t *testing.T) {
+	t.Parallel()
+	var f *Feed
+	if f.Append(KindConsole, map[string]any{}) != 0 || f.LatestSeq() != 0 {
+		t.Fatal("nil feed should be a no-op")
+	}
+}
+
+func TestFeed_OnAppendReceivesSequencedBatch(t *testing.T) {
+	t.Parallel()
+	f := New(2)
+	var got []Delta
+	f.SetOnAppend(func(batch []Delta) { got = append(got, batch...) })
+	f.Append(KindNetwork, NetworkPayloads([]types.NetworkBody{{URL: "/a"}, {URL: "/b"}, {URL: "/c"}})...)
+	if len(got) != 3 || got[0].Seq != 1 || got[2].Seq != 3 || got[2].Data["url"] != "/c" {
+		t.Fatalf("observer batch = %+v", got)
+	}
+	f.SetOnAppend(nil)
+	f.Append(KindConsole, map[string]any{})
+	if len(got) != 3 {
+		t.Errorf("removed observer still called: %+v", got)
+	}
+}
+
+func TestFeed_WaitForBlocksUntilMatchingDelta(t *testing.T) {
+	t.Parallel()
+	f := New(10)
+	f.Append(KindNetwork, NetworkPayloads([]types.NetworkBody{{Method: "GET", URL: "/old", Status: 500}})...)
+	after := f.LatestSeq()
+	isConsole := func(d Delta) bool { return d.Kind == KindConsole }
+
+	done := make(chan int64, 1)
+	go func() {
+		seq, _ := f.WaitFor(context.Background(), after, isConsole)
+		done <- seq
+	}()
+	f.Append(KindNetwork, NetworkPayloads([]types.NetworkBody{{Method: "GET", URL: "/new", Status: 200}})...)
+	select {
+	case seq := <-done:
+		t.Fatalf("non-matching delta woke the waiter at seq %d", seq)
+	case <-time.After(20 * time.Millisecond):
+	}
+	f.Append(KindConsole, ConsolePayloads([]types.LogEntry{{"level": "error", "message": "boom"}})...)
+	select {
+	case seq := <-done:
+		if seq != 3 {
+			t.Fatalf("matched seq = %d, want 3", seq)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("matching delta did not wake the waiter")
+	}
+
+	if seq, ok := f.WaitFor(context.Background(), 0, isConsole); !ok || seq != 3 {
+		t.Fatalf("already-present delta = %d, %v", seq, ok)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, ok := f.WaitFor(ctx, f.LatestSeq(), nil); ok {
+		t.Fatal("WaitFor should return false when the context ends")
+	}
+}
@@ -0,0 +1,110 @@
+// Purpose: Builds compact delta payloads from captured console, network, WebSocket, action, and alert records.
+// Why: Deltas summarize what changed; full bodies stay behind the per-buffer observe modes.
+// Docs: docs/features/feature/cursor-pagination/index.md
+
+package changefeed
+
+import "github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/types"
+
+// maxMessageLen caps console messages carried in a delta.
+const maxMessageLen = 300
+
+// ConsolePayloads summarizes log entries.
+func ConsolePayloads(entries []types.LogEntry) []map[string]any {
+	out := make([]map[string]any, 0, len(entries))
+	for _, e := range entries {
+		p := map[string]any{}
+		for _, key := range []string{"level", "source", "url"} {
+			if v, ok := e[key].(string); ok && v != "" {
+				p[key] = v
+			}
+		}
+		if msg, ok := e["message"].(string); ok {
+			if len(msg) > maxMessageLen {
+				msg = msg[:maxMessageLen] + "..."
+			}
+			p["message"] = msg
+		}
+		if tabID, ok := e["tabId"]; ok {
+			p["tab_id"] = tabID
+		}
+		out = append(out, p)
+	}
+	return out
+}
+
+// NetworkPayloads summarizes network bodies (bodies and headers are omitted).
+func NetworkPayloads(bodies []types.NetworkBody) []map[string]any {
+	out := make([]map[string]any, 0, len(bodies))
+	for _, b := range bodies {
+		p := map[string]any{"method": b.Method, "url": b.URL, "status": b.Status}
+		if b.Duration > 0 {
+			p["duration_ms"] = b.Duration
+		}
+		if b.ContentType != "" {
+			p["content_type"] = b.ContentType
+		}
+		if b.TabID != 0 {
+			p["tab_id"] = b.TabID
+		}
+		out = append(out, p)
+	}
+	return out
+}
+
+// WebSocketPayloads summarizes WebSocket events (frame data is omitted).
+func WebSocketPayloads(events []types.WebSocketEvent) []map[string]any {
+	out := make([]map[string]any, 0, len(events))
+	for _, e := range events {
+		p := map[string]any{"event": e.Event, "id": e.ID}
+		if e.URL != "" {
+			p["url"] = e.URL
+		}
+		if e.Direction != "" {
+			p["direction"] = e.Direction
+		}
+		if e.Size > 0 {
+			p["size"] = e.Size
+		}
+		if e.TabID != 0 {
+			p["tab_id"] = e.TabID
+		}
+		out = append(out, p)
+	}
+	return out
+}
+
+// ActionPayloads summarizes enhanced actions (typed values are omitted).
+func ActionPayloads(actions []types.EnhancedAction) []map[string]any {
+	out := make([]map[string]any, 0, len(actions))
+	for _, a := range actions {
+		p := map[string]any{"action": a.Type}
+		if a.URL != "" {
+			p["url"] = a.URL
+		}
+		if a.ToURL != "" {
+			p["to_url"] = a.ToURL
+		}
+		if css, ok := a.Selectors["css"].(string); ok && css != "" {
+			p["selector"] = css
+		}
+		if a.Source != "" {
+			p["source"] = a.Source
+		}
+		if a.TabID != 0 {
+			p["tab_id"] = a.TabID
+		}
+		out = append(out, p)
+	}
+	return out
+}
+
+// AlertPayload summarizes one alert.
+func AlertPayload(a types.Alert) map[string]any {
+	return map[string]any{
+		"severity": a.Severity,
+		"category": a.Category,
+		"title":    a.Title,
+		"source":   a.Source,
+	}
+}
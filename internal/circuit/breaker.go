@@ -6,6 +6,7 @@ package circuit
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"sync"
 	"time"
@@ -15,16 +16,31 @@ import (
 
 // Constants for circuit breaker behavior.
 const (
-	// RateLimitThreshold is the maximum events/second before rate limiting kicks in.
+	// RateLimitThreshold is the default maximum events/second before rate limiting kicks in.
 	RateLimitThreshold = 1000
 
 	// CircuitOpenStreakCount is consecutive seconds over threshold to open circuit.
 	CircuitOpenStreakCount = 5
 
-	// CircuitCloseSeconds is seconds below threshold to close circuit.
+	// CircuitCloseSeconds is seconds below threshold to close circuit. Admission ramps
+	// from 0 to 100% across this cool-down before the circuit closes.
 	CircuitCloseSeconds = 10
 )
 
+// Override modes accepted by SetMode.
+const (
+	ModeAuto   = "auto"   // thresholds drive the state machine
+	ModeOpen   = "open"   // held open: every batch is rejected
+	ModeClosed = "closed" // held closed: only the per-window limit applies
+)
+
+// Reasons reported while the circuit is open.
+const (
+	ReasonRateExceeded   = "rate_exceeded"
+	ReasonMemoryExceeded = "memory_exceeded"
+	ReasonManual         = "manual"
+)
+
 // HealthResponse is returned by GET /health endpoint.
 type HealthResponse struct {
 	CircuitOpen bool   `json:"circuit_open"`
@@ -43,9 +59,39 @@ type RateLimitResponse struct {
 	Threshold    int    `json:"threshold"`
 }
 
+// Thresholds are the tunable trip points. MemoryLimitBytes 0 disables the memory trip.
+type Thresholds struct {
+	MaxEventsPerWindow int   `json:"max_events_per_window"`
+	MemoryLimitBytes   int64 `json:"memory_limit_bytes"`
+}
+
+// DefaultThresholds returns the startup trip points.
+func DefaultThresholds() Thresholds {
+	return Thresholds{MaxEventsPerWindow: RateLimitThreshold}
+}
+
+// Status is the full breaker state reported by configure(what="circuit").
+type Status struct {
+	State            string     `json:"state"` // open or closed
+	Mode             string     `json:"mode"`
+	Reason           string     `json:"reason,omitempty"`
+	OpenedAt         string     `json:"opened_at,omitempty"`
+	CurrentRate      int        `json:"current_rate"`
+	MemoryBytes      int64      `json:"memory_bytes,omitempty"`
+	Thresholds       Thresholds `json:"thresholds"`
+	CoolingDown      bool       `json:"cooling_down"`
+	AdmitRatio       float64    `json:"admit_ratio"`
+	CoolDownAdmitted int        `json:"cool_down_admitted"`
+	CoolDownRejected int        `json:"cool_down_rejected"`
+	PriorityAccepted int        `json:"priority_accepted"` // errors, failed requests, and WS close/error kept from rejected batches
+	PriorityDropped  int        `json:"priority_dropped"`  // other entries in those batches
+}
+
 // CircuitBreaker implements a rate limiter with circuit breaker pattern.
 // Uses a 1-second sliding window for event counting and a streak-based
-// state machine for circuit open/close transitions.
+// state machine for circuit open/close transitions. While an automatically
+// opened circuit cools down, a growing share of batches is admitted instead
+// of rejecting everything until the close.
 type CircuitBreaker struct {
 	mu                   sync.RWMutex
 	windowEventCount     int
@@ -56,6 +102,19 @@ type CircuitBreaker struct {
 	circuitOpenedAt      time.Time
 	circuitReason        string
 
+	thresholds Thresholds
+	mode       string
+	memoryFn   func() int64 // capture memory source; sampled without mu held
+	lastMemory int64
+
+	// Cool-down sampling counters for the current open period.
+	coolDownSeen     int
+	coolDownAdmitted int
+
+	// Priority capture counters since the circuit last opened.
+	priorityAccepted int
+	priorityDropped  int
+
 	// Injected: emits lifecycle events (circuit_opened, circuit_closed)
 	emitEvent func(event string, data map[string]any)
 }
@@ -66,10 +125,20 @@ func NewCircuitBreaker(emitEvent func(string, map[string]any)) *CircuitBreaker {
 	return &CircuitBreaker{
 		rateWindowStart:      now,
 		lastBelowThresholdAt: now,
+		thresholds:           DefaultThresholds(),
+		mode:                 ModeAuto,
 		emitEvent:            emitEvent,
 	}
 }
 
+// SetMemorySource sets the function reporting capture memory for the memory trip.
+// The function is called without the breaker lock held.
+func (cb *CircuitBreaker) SetMemorySource(fn func() int64) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	cb.memoryFn = fn
+}
+
 // IsOpen returns whether the circuit breaker is currently open (rejecting all requests).
 func (cb *CircuitBreaker) IsOpen() bool {
 	cb.mu.RLock()
@@ -94,82 +163,205 @@ func (cb *CircuitBreaker) SetWindowState(start time.Time, count int) {
 	cb.windowEventCount = count
 }
 
-// RecordEvents records N events received in the current 1-second window.
-// Called by ingest handlers with batch sizes.
-func (cb *CircuitBreaker) RecordEvents(count int) {
+// SetThresholds replaces the trip points. Callers validate MaxEventsPerWindow > 0.
+func (cb *CircuitBreaker) SetThresholds(t Thresholds) {
 	cb.mu.Lock()
 	defer cb.mu.Unlock()
+	cb.thresholds = t
+}
 
-	now := time.Now()
+// SetMode applies a manual override. open holds the circuit open (reason manual);
+// closed closes it and keeps it closed; auto hands control back to the thresholds,
+// closing a circuit that was only open because of the manual hold.
+func (cb *CircuitBreaker) SetMode(mode string) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	prev := cb.mode
+	cb.mode = mode
+	switch mode {
+	case ModeOpen:
+		if !cb.circuitOpen {
+			cb.openLocked(ReasonManual, map[string]any{"reason": ReasonManual, "mode": ModeOpen})
+		}
+	case ModeClosed:
+		if cb.circuitOpen {
+			cb.closeLocked()
+		}
+	case ModeAuto:
+		if prev == ModeOpen && cb.circuitOpen && cb.circuitReason == ReasonManual {
+			cb.closeLocked()
+		}
+	}
+}
+
+// sampleMemory reads capture memory when the memory trip is enabled, else returns -1.
+func (cb *CircuitBreaker) sampleMemory() int64 {
+	cb.mu.RLock()
+	fn, limit := cb.memoryFn, cb.thresholds.MemoryLimitBytes
+	cb.mu.RUnlock()
+	if fn == nil || limit <= 0 {
+		return -1
+	}
+	return fn()
+}
+
+// observeLocked stores a memory sample and rolls the rate window if it expired.
+// Caller must hold lock.
+func (cb *CircuitBreaker) observeLocked(now time.Time, memory int64) {
+	if memory >= 0 {
+		cb.lastMemory = memory
+	}
 	if now.Sub(cb.rateWindowStart) > time.Second {
 		cb.tickRateWindow()
 		cb.windowEventCount = 0
 		cb.rateWindowStart = now
 	}
+}
+
+// RecordEvents records N events received in the current 1-second window.
+// Called by ingest handlers with batch sizes.
+func (cb *CircuitBreaker) RecordEvents(count int) {
+	memory := cb.sampleMemory()
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	cb.observeLocked(time.Now(), memory)
 	cb.windowEventCount += count
 }
 
 // CheckRateLimit returns true if the request should be rejected (429).
-// Checks: 1) circuit open, 2) window rate.
+// Checks: 1) circuit open, admitting a sample while cooling down, 2) window rate.
 func (cb *CircuitBreaker) CheckRateLimit() bool {
-	cb.mu.RLock()
-	defer cb.mu.RUnlock()
+	return cb.check(true)
+}
+
+// CheckOpen returns true if the circuit is open and does not admit this batch as a
+// cool-down sample. Ingest paths that do not count toward the event window use it to
+// switch to priority capture.
+func (cb *CircuitBreaker) CheckOpen() bool {
+	return cb.check(false)
+}
+
+func (cb *CircuitBreaker) check(includeWindow bool) bool {
+	memory := cb.sampleMemory()
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
 
+	now := time.Now()
+	cb.observeLocked(now, memory)
 	if cb.circuitOpen {
-		return true
+		return !cb.admitSampleLocked(now)
 	}
+	return includeWindow && cb.windowEventCount > cb.thresholds.MaxEventsPerWindow
+}
 
-	now := time.Now()
-	if now.Sub(cb.rateWindowStart) > time.Second {
+// RecordPriority counts entries a rejected batch kept (errors, failed requests,
+// WebSocket close/error) and dropped under priority capture.
+func (cb *CircuitBreaker) RecordPriority(accepted, dropped int) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	cb.priorityAccepted += accepted
+	cb.priorityDropped += dropped
+}
+
+// OverWindowLimit reports whether the current window exceeds the per-window limit.
+// Ingest handlers call it after RecordEvents; unlike CheckRateLimit it takes no
+// cool-down sample, so an admitted batch is not judged twice.
+func (cb *CircuitBreaker) OverWindowLimit() bool {
+	cb.mu.RLock()
+	defer cb.mu.RUnlock()
+	if time.Since(cb.rateWindowStart) > time.Second {
 		return false // window expired, rate is effectively 0
 	}
-	return cb.windowEventCount > RateLimitThreshold
+	return cb.windowEventCount > cb.thresholds.MaxEventsPerWindow
+}
+
+// admitRatioLocked returns the share of batches admitted now: 1 when closed, 0 when
+// held open or still over a threshold, and a linear ramp across the cool-down otherwise.
+// Caller must hold lock.
+func (cb *CircuitBreaker) admitRatioLocked(now time.Time) float64 {
+	if !cb.circuitOpen {
+		return 1
+	}
+	if cb.mode == ModeOpen || cb.rateLimitStreak > 0 || cb.lastBelowThresholdAt.IsZero() {
+		return 0
+	}
+	start := cb.lastBelowThresholdAt
+	if cb.circuitOpenedAt.After(start) {
+		start = cb.circuitOpenedAt
+	}
+	ratio := float64(now.Sub(start)) / float64(time.Duration(CircuitCloseSeconds)*time.Second)
+	return min(max(ratio, 0), 1)
+}
+
+// admitSampleLocked decides one batch during an open period, keeping the admitted
+// share at or under the current ratio. Caller must hold lock.
+func (cb *CircuitBreaker) admitSampleLocked(now time.Time) bool {
+	ratio := cb.admitRatioLocked(now)
+	cb.coolDownSeen++
+	if ratio > 0 && float64(cb.coolDownAdmitted+1) <= ratio*float64(cb.coolDownSeen) {
+		cb.coolDownAdmitted++
+		return true
+	}
+	return false
+}
+
+// overMemoryLocked reports whether the last memory sample exceeds the memory limit.
+// Caller must hold lock.
+func (cb *CircuitBreaker) overMemoryLocked() bool {
+	return cb.thresholds.MemoryLimitBytes > 0 && cb.lastMemory > cb.thresholds.MemoryLimitBytes
 }
 
 // tickRateWindow is called when a 1-second window expires.
 // Updates streak counter and evaluates circuit state. Caller must hold lock.
 func (cb *CircuitBreaker) tickRateWindow() {
-	if cb.windowEventCount > RateLimitThreshold {
+	if cb.windowEventCount > cb.thresholds.MaxEventsPerWindow {
 		cb.rateLimitStreak++
 		cb.lastBelowThresholdAt = time.Time{}
 	} else {
 		cb.rateLimitStreak = 0
-		if cb.lastBelowThresholdAt.IsZero() {
-			cb.lastBelowThresholdAt = time.Now()
+		if cb.overMemoryLocked() {
+			cb.lastBelowThresholdAt = time.Time{}
+		} else if cb.lastBelowThresholdAt.IsZero() {
+			// Below since the window ended; with no traffic in between that may be long ago.
+			cb.lastBelowThresholdAt = cb.rateWindowStart.Add(time.Second)
+			if now := time.Now(); cb.lastBelowThresholdAt.After(now) {
+				cb.lastBelowThresholdAt = now
+			}
 		}
 	}
 	cb.evaluateCircuit()
 }
 
 // evaluateCircuit implements the circuit breaker FSM.
-// CLOSED->OPEN: streak>=5. OPEN->CLOSED: streak=0 AND below for 10s.
-// Caller must hold lock.
+// CLOSED->OPEN: streak>=5, or memory over limit. OPEN->CLOSED: streak=0, memory under
+// limit, AND below for 10s. Manual modes hold the state. Caller must hold lock.
 func (cb *CircuitBreaker) evaluateCircuit() {
+	if cb.mode != ModeAuto {
+		return
+	}
 	if !cb.circuitOpen {
 		// Rate-based opening
 		if cb.rateLimitStreak >= CircuitOpenStreakCount {
-			cb.circuitOpen = true
-			cb.circuitOpenedAt = time.Now()
-			cb.circuitReason = "rate_exceeded"
-			// Capture values before goroutine to avoid data race on struct fields
-			streak := cb.rateLimitStreak
-			rate := cb.windowEventCount
-			emitFn := cb.emitEvent
-			util.SafeGo(func() {
-				emitFn("circuit_opened", map[string]any{
-					"reason":    "rate_exceeded",
-					"streak":    streak,
-					"rate":      rate,
-					"threshold": RateLimitThreshold,
-				})
+			cb.openLocked(ReasonRateExceeded, map[string]any{
+				"reason":    ReasonRateExceeded,
+				"streak":    cb.rateLimitStreak,
+				"rate":      cb.windowEventCount,
+				"threshold": cb.thresholds.MaxEventsPerWindow,
 			})
 			return
 		}
+		if cb.overMemoryLocked() {
+			cb.openLocked(ReasonMemoryExceeded, map[string]any{
+				"reason":       ReasonMemoryExceeded,
+				"memory_bytes": cb.lastMemory,
+				"limit_bytes":  cb.thresholds.MemoryLimitBytes,
+			})
+		}
 		return
 	}
 
 	// Check if circuit should close
-	if cb.rateLimitStreak > 0 {
+	if cb.rateLimitStreak > 0 || cb.overMemoryLocked() {
 		return
 	}
 	if cb.lastBelowThresholdAt.IsZero() {
@@ -180,21 +372,44 @@ func (cb *CircuitBreaker) evaluateCircuit() {
 	}
 
 	// All conditions met -- close
+	cb.closeLocked()
+}
+
+// openLocked opens the circuit and emits circuit_opened with data. Caller must hold lock.
+func (cb *CircuitBreaker) openLocked(reason string, data map[string]any) {
+	cb.circuitOpen = true
+	cb.circuitOpenedAt = time.Now()
+	cb.circuitReason = reason
+	cb.coolDownSeen, cb.coolDownAdmitted = 0, 0
+	cb.priorityAccepted, cb.priorityDropped = 0, 0
+	emitFn := cb.emitEvent
+	util.SafeGo(func() { // lint:safe-closure: captures only the emitFn and data locals
+		emitFn("circuit_opened", data)
+	})
+}
+
+// closeLocked closes the circuit and emits circuit_closed. Caller must hold lock.
+func (cb *CircuitBreaker) closeLocked() {
 	openDuration := time.Since(cb.circuitOpenedAt)
 	prevReason := cb.circuitReason
 	cb.circuitOpen = false
 	cb.circuitReason = ""
 	cb.rateLimitStreak = 0
 	// Capture values before goroutine to avoid data race on struct fields
-	rate := cb.windowEventCount
+	data := map[string]any{
+		"previous_reason":    prevReason,
+		"open_duration_secs": openDuration.Seconds(),
+		"rate":               cb.windowEventCount,
+		"mode":               cb.mode,
+		"cool_down_admitted": cb.coolDownAdmitted,
+		"cool_down_rejected": cb.coolDownSeen - cb.coolDownAdmitted,
+		"priority_accepted":  cb.priorityAccepted,
+		"priority_dropped":   cb.priorityDropped,
+	}
+	cb.coolDownSeen, cb.coolDownAdmitted = 0, 0
 	emitFn := cb.emitEvent
-
-	util.SafeGo(func() {
-		emitFn("circuit_closed", map[string]any{
-			"previous_reason":    prevReason,
-			"open_duration_secs": openDuration.Seconds(),
-			"rate":               rate,
-		})
+	util.SafeGo(func() { // lint:safe-closure: captures only the emitFn and data locals
+		emitFn("circuit_closed", data)
 	})
 }
 
@@ -214,6 +429,35 @@ func (cb *CircuitBreaker) GetHealthStatus() HealthResponse {
 	return resp
 }
 
+// Status returns thresholds, override mode, and cool-down progress.
+func (cb *CircuitBreaker) Status() Status {
+	cb.mu.RLock()
+	defer cb.mu.RUnlock()
+
+	ratio := cb.admitRatioLocked(time.Now())
+	st := Status{
+		State:            "closed",
+		Mode:             cb.mode,
+		Reason:           cb.circuitReason,
+		CurrentRate:      cb.windowEventCount,
+		Thresholds:       cb.thresholds,
+		CoolingDown:      cb.circuitOpen && ratio > 0,
+		AdmitRatio:       ratio,
+		CoolDownAdmitted: cb.coolDownAdmitted,
+		CoolDownRejected: cb.coolDownSeen - cb.coolDownAdmitted,
+		PriorityAccepted: cb.priorityAccepted,
+		PriorityDropped:  cb.priorityDropped,
+	}
+	if cb.thresholds.MemoryLimitBytes > 0 {
+		st.MemoryBytes = cb.lastMemory
+	}
+	if cb.circuitOpen {
+		st.State = "open"
+		st.OpenedAt = cb.circuitOpenedAt.Format(time.RFC3339)
+	}
+	return st
+}
+
 // GetState returns circuit breaker state fields for external snapshot consumers.
 // Used by Capture.GetHealthSnapshot() to avoid reentrant locking.
 func (cb *CircuitBreaker) GetState() (open bool, reason string, openedAt time.Time, eventCount int) {
@@ -227,15 +471,16 @@ func (cb *CircuitBreaker) WriteRateLimitResponse(w http.ResponseWriter) {
 	cb.mu.RLock()
 	currentRate := cb.windowEventCount
 	isOpen := cb.circuitOpen
+	threshold := cb.thresholds.MaxEventsPerWindow
 	cb.mu.RUnlock()
 
 	resp := RateLimitResponse{
 		Error:        "rate_limited",
-		Message:      "Server receiving >1000 events/sec. Retry after backoff.",
+		Message:      fmt.Sprintf("Server receiving >%d events/sec. Retry after backoff.", threshold),
 		RetryAfterMs: 1000,
 		CircuitOpen:  isOpen,
 		CurrentRate:  currentRate,
-		Threshold:    RateLimitThreshold,
+		Threshold:    threshold,
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -5,6 +5,9 @@
 package circuit
 
 import (
+	"encoding/json"
+	"net/http/httptest"
+	"slices"
 	"sync"
 	"testing"
 	"time"
@@ -190,3 +193,164 @@ func TestCircuitBreaker_RecordEventsWindowReset(t *testing.T) {
 		t.Errorf("windowEventCount = %d, want 10 after reset", count)
 	}
 }
+
+func TestCircuitBreaker_CoolDownAdmitsGrowingShare(t *testing.T) {
+	t.Parallel()
+	cb := newTestCircuitBreaker()
+	cb.ForceOpen(ReasonRateExceeded)
+
+	// Just opened: below threshold, but the ramp has not started.
+	if !cb.CheckRateLimit() {
+		t.Fatal("a freshly opened circuit should reject")
+	}
+
+	// Halfway through the cool-down roughly half of the batches get through.
+	cb.mu.Lock()
+	cb.circuitOpenedAt = time.Now().Add(-time.Duration(CircuitCloseSeconds) * time.Second / 2)
+	cb.lastBelowThresholdAt = cb.circuitOpenedAt
+	cb.coolDownSeen, cb.coolDownAdmitted = 0, 0
+	cb.mu.Unlock()
+	admitted := 0
+	for i := 0; i < 100; i++ {
+		if !cb.CheckRateLimit() {
+			admitted++
+		}
+	}
+	if admitted < 40 || admitted > 60 {
+		t.Fatalf("admitted %d of 100 halfway through cool-down, want about 50", admitted)
+	}
+	st := cb.Status()
+	if !st.CoolingDown || st.CoolDownAdmitted != admitted || st.CoolDownRejected != 100-admitted {
+		t.Fatalf("status = %+v", st)
+	}
+}
+
+func TestCircuitBreaker_ThresholdsAreTunable(t *testing.T) {
+	t.Parallel()
+	cb := newTestCircuitBreaker()
+	cb.SetThresholds(Thresholds{MaxEventsPerWindow: 10})
+	cb.RecordEvents(11)
+	if !cb.CheckRateLimit() || !cb.OverWindowLimit() {
+		t.Fatal("11 events should exceed a 10-event window")
+	}
+
+	rec := httptest.NewRecorder()
+	cb.WriteRateLimitResponse(rec)
+	var body RateLimitResponse
+	if err := json.NewDecoder(rec.Body).Decode(&body); err != nil || body.Threshold != 10 {
+		t.Fatalf("429 body = %+v (err %v), want threshold 10", body, err)
+	}
+}
+
+func TestCircuitBreaker_MemoryLimitOpensAndHoldsCircuit(t *testing.T) {
+	t.Parallel()
+	var events []string
+	var mu sync.Mutex
+	cb := NewCircuitBreaker(func(event string, _ map[string]any) {
+		mu.Lock()
+		events = append(events, event)
+		mu.Unlock()
+	})
+	memory := int64(2 << 20)
+	cb.SetMemorySource(func() int64 { return memory })
+	cb.SetThresholds(Thresholds{MaxEventsPerWindow: RateLimitThreshold, MemoryLimitBytes: 1 << 20})
+
+	cb.SetWindowState(time.Now().Add(-2*time.Second), 1)
+	if !cb.CheckRateLimit() {
+		t.Fatal("memory over limit should open the circuit")
+	}
+	if _, reason, _, _ := cb.GetState(); reason != ReasonMemoryExceeded {
+		t.Fatalf("reason = %q, want %q", reason, ReasonMemoryExceeded)
+	}
+
+	// Rate is fine and the cool-down has long passed, but memory is still high.
+	cb.mu.Lock()
+	cb.lastBelowThresholdAt = time.Now().Add(-time.Minute)
+	cb.evaluateCircuit()
+	cb.mu.Unlock()
+	if !cb.IsOpen() {
+		t.Fatal("circuit should stay open while memory is over the limit")
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	mu.Lock()
+	defer mu.Unlock()
+	if len(events) != 1 || events[0] != "circuit_opened" {
+		t.Fatalf("events = %v, want [circuit_opened]", events)
+	}
+}
+
+func TestCircuitBreaker_ManualModes(t *testing.T) {
+	t.Parallel()
+	var events []string
+	var mu sync.Mutex
+	cb := NewCircuitBreaker(func(event string, _ map[string]any) {
+		mu.Lock()
+		events = append(events, event)
+		mu.Unlock()
+	})
+
+	cb.SetMode(ModeOpen)
+	if !cb.CheckRateLimit() {
+		t.Fatal("state=open should reject")
+	}
+	if st := cb.Status(); st.State != "open" || st.Reason != ReasonManual || st.AdmitRatio != 0 {
+		t.Fatalf("status = %+v", st)
+	}
+
+	cb.SetMode(ModeAuto)
+	if cb.IsOpen() {
+		t.Fatal("auto should close a circuit that was only held open manually")
+	}
+
+	// Held closed, a sustained flood never opens the circuit.
+	cb.SetMode(ModeClosed)
+	cb.mu.Lock()
+	for i := 0; i < CircuitOpenStreakCount+1; i++ {
+		cb.windowEventCount = RateLimitThreshold + 1
+		cb.tickRateWindow()
+	}
+	cb.mu.Unlock()
+	if cb.IsOpen() {
+		t.Fatal("state=closed should keep the circuit closed")
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	mu.Lock()
+	defer mu.Unlock()
+	// Emission runs on goroutines, so delivery order is not guaranteed.
+	slices.Sort(events)
+	if !slices.Equal(events, []string{"circuit_closed", "circuit_opened"}) {
+		t.Fatalf("events = %v, want one circuit_opened and one circuit_closed", events)
+	}
+}
+
+func TestCircuitBreaker_CheckOpenIgnoresWindowAndCountsPriority(t *testing.T) {
+	t.Parallel()
+	cb := newTestCircuitBreaker()
+	cb.SetWindowState(time.Now(), RateLimitThreshold+1)
+	if !cb.CheckRateLimit() {
+		t.Fatal("CheckRateLimit should reject an over-limit window")
+	}
+	if cb.CheckOpen() {
+		t.Fatal("CheckOpen should ignore the window while the circuit is closed")
+	}
+
+	cb.RecordPriority(3, 7)
+	cb.ForceOpen(ReasonRateExceeded)
+	if !cb.CheckOpen() {
+		t.Fatal("CheckOpen should report a freshly opened circuit")
+	}
+	cb.RecordPriority(2, 5)
+	if st := cb.Status(); st.PriorityAccepted != 5 || st.PriorityDropped != 12 {
+		t.Fatalf("priority accepted/dropped = %d/%d, want 5/12", st.PriorityAccepted, st.PriorityDropped)
+	}
+
+	cb.SetMode(ModeOpen) // already open: keeps the counters
+	cb.SetMode(ModeAuto)
+	cb.SetMode(ModeClosed)
+	cb.SetMode(ModeOpen) // reopening resets them
+	if st := cb.Status(); st.PriorityAccepted != 0 || st.PriorityDropped != 0 {
+		t.Fatalf("priority counters after reopen = %d/%d, want 0/0", st.PriorityAccepted, st.PriorityDropped)
+	}
+}
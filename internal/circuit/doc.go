@@ -5,15 +5,21 @@
 /*
 Package circuit implements a circuit breaker for the capture ingest path.
 
-When event rates exceed 1000/second for 5 consecutive seconds, the circuit opens
-and rejects new events until rates fall below threshold for 10 seconds.
+When event rates exceed 1000/second (tunable) for 5 consecutive seconds, or capture
+memory passes an optional limit, the circuit opens and rejects new events. Once rates
+fall below threshold it cools down for 10 seconds, admitting a growing share of batches,
+then closes. Manual overrides hold the circuit open or closed. While it is open, ingest
+keeps priority entries (errors, failed requests, WebSocket close/error) and counts them.
 
 Key types:
   - Breaker: state machine tracking event rates with open/closed/half-open transitions.
   - HealthResponse: JSON-serializable health status returned by the /health endpoint.
+  - Thresholds / Status: tunable trip points and the state reported by configure(what="circuit").
 
 Key functions:
   - NewBreaker: creates a breaker with default thresholds.
   - Allow: checks whether an incoming event should be accepted or rejected.
+  - SetThresholds / SetMode: retune trip points and apply the open/closed/auto override.
+  - CheckOpen / RecordPriority: switch ingest to priority capture and count what it kept.
 */
 package circuit
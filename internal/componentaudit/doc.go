@@ -0,0 +1,22 @@
+// Purpose: Package componentaudit — component-harness story discovery and per-story audit results.
+// Why: Story-level results let agents fix one component at a time instead of auditing whole pages.
+// Docs: docs/features/feature/component-audit/index.md
+
+/*
+Package componentaudit recognizes component harnesses (Storybook 6/7+, Ladle, or a
+custom URL template), enumerates their stories, and shapes per-story audit results.
+
+Key types:
+  - Story: one enumerable story with its harness-specific ID.
+  - StoryResult: a11y, visual, and console-error findings for one story.
+  - Baseline: stored screenshot hashes keyed by story ID for visual change detection.
+
+Key functions:
+  - ParseIndex: decodes index.json / stories.json / meta.json into stories.
+  - StoryURL: builds the isolated render URL for a story.
+  - SummarizeAxe: reduces an axe-core result to violation counts and rule IDs.
+  - Summarize: rolls story results into totals.
+
+Browser I/O lives in internal/tools/observe; this package has no extension dependency.
+*/
+package componentaudit
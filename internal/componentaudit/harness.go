@@ -0,0 +1,201 @@
+// Purpose: Recognizes component harness indexes and builds isolated story render URLs.
+// Why: Storybook 6, Storybook 7+, and Ladle publish different index shapes and iframe URLs.
+// Docs: docs/features/feature/component-audit/index.md
+
+package componentaudit
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// Harness names.
+const (
+	HarnessAuto      = "auto"
+	HarnessStorybook = "storybook"
+	HarnessLadle     = "ladle"
+	HarnessCustom    = "custom"
+)
+
+// StoryIDPlaceholder is substituted with the URL-escaped story ID in custom templates.
+const StoryIDPlaceholder = "{id}"
+
+// Story is one enumerable component story.
+type Story struct {
+	ID         string   `json:"id"`
+	Title      string   `json:"title,omitempty"`
+	Name       string   `json:"name,omitempty"`
+	ImportPath string   `json:"import_path,omitempty"`
+	Tags       []string `json:"tags,omitempty"`
+}
+
+// IndexPaths returns the index documents to try for a harness, in order.
+// Custom harnesses have no index; callers must pass story IDs explicitly.
+func IndexPaths(harness string) []string {
+	switch harness {
+	case HarnessStorybook:
+		return []string{"index.json", "stories.json"}
+	case HarnessLadle:
+		return []string{"meta.json"}
+	case HarnessCustom:
+		return nil
+	default:
+		return []string{"index.json", "stories.json", "meta.json"}
+	}
+}
+
+// ParseIndex decodes a harness index document and reports which harness produced it.
+//
+// Supported shapes:
+//   - Storybook 7+ index.json: {"v":4,"entries":{id:{id,title,name,importPath,type,tags}}} (docs entries skipped)
+//   - Storybook 6 stories.json: {"v":3,"stories":{id:{id,title|kind,name,importPath}}}
+//   - Ladle meta.json: {"about":{...},"stories":{id:{name,levels,filePath}}}
+func ParseIndex(raw []byte) (string, []Story, error) {
+	var doc struct {
+		Entries map[string]indexEntry `json:"entries"`
+		Stories map[string]indexEntry `json:"stories"`
+		About   json.RawMessage       `json:"about"`
+	}
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return "", nil, fmt.Errorf("parse story index: %w", err)
+	}
+
+	var harness string
+	var stories []Story
+	switch {
+	case doc.Entries != nil:
+		harness = HarnessStorybook
+		for id, e := range doc.Entries {
+			if e.Type != "" && e.Type != "story" {
+				continue
+			}
+			stories = append(stories, e.story(id))
+		}
+	case doc.Stories != nil && (doc.About != nil || hasLevels(doc.Stories)):
+		harness = HarnessLadle
+		for id, e := range doc.Stories {
+			s := e.story(id)
+			if s.Title == "" && len(e.Levels) > 0 {
+				s.Title = strings.Join(e.Levels, "/")
+			}
+			if s.ImportPath == "" {
+				s.ImportPath = e.FilePath
+			}
+			stories = append(stories, s)
+		}
+	case doc.Stories != nil:
+		harness = HarnessStorybook
+		for id, e := range doc.Stories {
+			stories = append(stories, e.story(id))
+		}
+	default:
+		return "", nil, errors.New("not a recognized story index (expected entries or stories)")
+	}
+	sort.Slice(stories, func(i, j int) bool { return stories[i].ID < stories[j].ID })
+	return harness, stories, nil
+}
+
+type indexEntry struct {
+	ID         string   `json:"id"`
+	Title      string   `json:"title"`
+	Kind       string   `json:"kind"`
+	Name       string   `json:"name"`
+	ImportPath string   `json:"importPath"`
+	Type       string   `json:"type"`
+	Tags       []string `json:"tags"`
+	Levels     []string `json:"levels"`
+	FilePath   string   `json:"filePath"`
+}
+
+func (e indexEntry) story(key string) Story {
+	id := e.ID
+	if id == "" {
+		id = key
+	}
+	title := e.Title
+	if title == "" {
+		title = e.Kind
+	}
+	return Story{ID: id, Title: title, Name: e.Name, ImportPath: e.ImportPath, Tags: e.Tags}
+}
+
+func hasLevels(entries map[string]indexEntry) bool {
+	for _, e := range entries {
+		if len(e.Levels) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// BaseURL reduces a harness page URL (manager UI, iframe, or root) to the directory
+// that serves the index and iframe documents, with a trailing slash.
+func BaseURL(pageURL string) (string, error) {
+	u, err := url.Parse(strings.TrimSpace(pageURL))
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "", fmt.Errorf("harness URL must be an absolute http(s) URL: %q", pageURL)
+	}
+	u.RawQuery = ""
+	u.Fragment = ""
+	if i := strings.LastIndex(u.Path, "/"); i >= 0 && strings.HasSuffix(u.Path[i+1:], ".html") {
+		u.Path = u.Path[:i+1]
+	}
+	if !strings.HasSuffix(u.Path, "/") {
+		u.Path += "/"
+	}
+	return u.String(), nil
+}
+
+// StoryURL returns the isolated render URL for one story. A non-empty template
+// (containing StoryIDPlaceholder) takes precedence over the harness default.
+func StoryURL(harness, baseURL, storyID, template string) (string, error) {
+	if template != "" {
+		if !strings.Contains(template, StoryIDPlaceholder) {
+			return "", fmt.Errorf("story_url_template must contain %s", StoryIDPlaceholder)
+		}
+		return strings.ReplaceAll(template, StoryIDPlaceholder, url.QueryEscape(storyID)), nil
+	}
+	switch harness {
+	case HarnessLadle:
+		return baseURL + "?story=" + url.QueryEscape(storyID) + "&mode=preview", nil
+	case HarnessStorybook:
+		return baseURL + "iframe.html?id=" + url.QueryEscape(storyID) + "&viewMode=story", nil
+	default:
+		return "", fmt.Errorf("harness %q needs story_url_template", harness)
+	}
+}
+
+// Select filters stories by case-insensitive substring over ID/title/name and by tag,
+// then truncates to limit. It reports whether stories were dropped by the limit.
+func Select(stories []Story, filter string, tags []string, limit int) ([]Story, bool) {
+	filter = strings.ToLower(strings.TrimSpace(filter))
+	out := make([]Story, 0, len(stories))
+	for _, s := range stories {
+		if filter != "" && !strings.Contains(strings.ToLower(s.ID+" "+s.Title+" "+s.Name), filter) {
+			continue
+		}
+		if len(tags) > 0 && !hasAnyTag(s.Tags, tags) {
+			continue
+		}
+		out = append(out, s)
+	}
+	if limit > 0 && len(out) > limit {
+		return out[:limit], true
+	}
+	return out, false
+}
+
+func hasAnyTag(have, want []string) bool {
+	for _, w := range want {
+		for _, h := range have {
+			if strings.EqualFold(h, w) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,95 @@
+// Purpose: Tests harness index parsing, base/story URL construction, and story selection.
+// Docs: docs/features/feature/component-audit/index.md
+
+package componentaudit
+
+import "testing"
+
+func TestParseIndex_Shapes(t *testing.T) {
+	t.Parallel()
+	cases := map[string]struct {
+		raw     string
+		harness string
+		ids     []string
+		title   string
+	}{
+		"storybook 7 index.json skips docs": {
+			raw:     `{"v":4,"entries":{"button--primary":{"id":"button--primary","title":"Button","name":"Primary","importPath":"./src/Button.stories.tsx","type":"story","tags":["autodocs"]},"button--docs":{"id":"button--docs","title":"Button","name":"Docs","type":"docs"}}}`,
+			harness: HarnessStorybook,
+			ids:     []string{"button--primary"},
+			title:   "Button",
+		},
+		"storybook 6 stories.json uses kind": {
+			raw:     `{"v":3,"stories":{"card--default":{"id":"card--default","kind":"Card","name":"Default"}}}`,
+			harness: HarnessStorybook,
+			ids:     []string{"card--default"},
+			title:   "Card",
+		},
+		"ladle meta.json": {
+			raw:     `{"about":{"homepage":"https://ladle.dev"},"stories":{"forms--input":{"name":"Input","levels":["Forms"],"filePath":"src/input.stories.tsx"}}}`,
+			harness: HarnessLadle,
+			ids:     []string{"forms--input"},
+			title:   "Forms",
+		},
+	}
+	for name, tc := range cases {
+		harness, stories, err := ParseIndex([]byte(tc.raw))
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if harness != tc.harness || len(stories) != len(tc.ids) || stories[0].ID != tc.ids[0] || stories[0].Title != tc.title {
+			t.Errorf("%s: harness=%s stories=%+v", name, harness, stories)
+		}
+	}
+	if _, _, err := ParseIndex([]byte(`{"hello":1}`)); err == nil {
+		t.Error("unrecognized index should fail")
+	}
+}
+
+func TestStoryURLAndBaseURL(t *testing.T) {
+	t.Parallel()
+	for in, want := range map[string]string{
+		"http://localhost:6006/?path=/story/button--primary":    "http://localhost:6006/",
+		"http://localhost:6006/iframe.html?id=x&viewMode=story": "http://localhost:6006/",
+		"https://design.test/storybook":                         "https://design.test/storybook/",
+	} {
+		if got, err := BaseURL(in); err != nil || got != want {
+			t.Errorf("BaseURL(%q) = %q, %v; want %q", in, got, err, want)
+		}
+	}
+	if _, err := BaseURL("chrome://newtab"); err == nil {
+		t.Error("non-http URL should fail")
+	}
+
+	base := "http://localhost:6006/"
+	if got, _ := StoryURL(HarnessStorybook, base, "button--primary", ""); got != "http://localhost:6006/iframe.html?id=button--primary&viewMode=story" {
+		t.Errorf("storybook URL = %s", got)
+	}
+	if got, _ := StoryURL(HarnessLadle, base, "forms--input", ""); got != "http://localhost:6006/?story=forms--input&mode=preview" {
+		t.Errorf("ladle URL = %s", got)
+	}
+	if got, _ := StoryURL(HarnessCustom, base, "a b", "http://h.test/render/{id}"); got != "http://h.test/render/a+b" {
+		t.Errorf("template URL = %s", got)
+	}
+	if _, err := StoryURL(HarnessCustom, base, "x", "http://h.test/render"); err == nil {
+		t.Error("template without {id} should fail")
+	}
+}
+
+func TestSelect_FilterTagsLimit(t *testing.T) {
+	t.Parallel()
+	stories := []Story{
+		{ID: "button--primary", Title: "Button", Tags: []string{"a11y"}},
+		{ID: "button--secondary", Title: "Button"},
+		{ID: "card--default", Title: "Card", Tags: []string{"A11Y"}},
+	}
+	if got, _ := Select(stories, "BUTTON", nil, 0); len(got) != 2 {
+		t.Errorf("filter = %+v", got)
+	}
+	if got, _ := Select(stories, "", []string{"a11y"}, 0); len(got) != 2 || got[1].ID != "card--default" {
+		t.Errorf("tags = %+v", got)
+	}
+	if got, truncated := Select(stories, "", nil, 1); len(got) != 1 || !truncated {
+		t.Errorf("limit = %+v truncated=%v", got, truncated)
+	}
+}
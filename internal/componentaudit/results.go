@@ -0,0 +1,228 @@
+// Purpose: Shapes per-story audit results, axe summaries, and stored visual baselines.
+// Why: Results keyed by story ID must be comparable across runs, so visual hashes persist per harness origin.
+// Docs: docs/features/feature/component-audit/index.md
+
+package componentaudit
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/state"
+)
+
+// Check names accepted by the audit.
+const (
+	CheckA11y    = "a11y"
+	CheckVisual  = "visual"
+	CheckConsole = "console"
+)
+
+// AllChecks lists every check in run order.
+var AllChecks = []string{CheckA11y, CheckVisual, CheckConsole}
+
+// Story statuses.
+const (
+	StatusPass  = "pass"
+	StatusFail  = "fail"
+	StatusError = "error"
+)
+
+// Visual baseline states.
+const (
+	VisualNew       = "new"
+	VisualUnchanged = "unchanged"
+	VisualChanged   = "changed"
+)
+
+// StoryResult is the outcome of auditing one story.
+type StoryResult struct {
+	StoryID       string         `json:"story_id"`
+	Title         string         `json:"title,omitempty"`
+	Name          string         `json:"name,omitempty"`
+	URL           string         `json:"url"`
+	Status        string         `json:"status"`
+	A11y          *A11ySummary   `json:"a11y,omitempty"`
+	Visual        *VisualSummary `json:"visual,omitempty"`
+	ConsoleErrors []string       `json:"console_errors,omitempty"`
+	Errors        []string       `json:"errors,omitempty"`
+	DurationMs    int64          `json:"duration_ms"`
+}
+
+// A11ySummary reduces an axe-core result for one story.
+type A11ySummary struct {
+	Violations int            `json:"violations"`
+	ByImpact   map[string]int `json:"by_impact,omitempty"`
+	Rules      []string       `json:"rules,omitempty"`
+}
+
+// VisualSummary reports the story screenshot against the stored baseline.
+type VisualSummary struct {
+	Hash     string `json:"hash"`
+	Baseline string `json:"baseline"`
+}
+
+// SummarizeAxe reduces an axe-core result ({"violations":[{id,impact,nodes}]}) to counts.
+// Violations count affected nodes, so one rule failing on three buttons counts as three.
+func SummarizeAxe(raw json.RawMessage) (*A11ySummary, error) {
+	var result struct {
+		Violations []struct {
+			ID     string            `json:"id"`
+			Impact string            `json:"impact"`
+			Nodes  []json.RawMessage `json:"nodes"`
+		} `json:"violations"`
+		Error string `json:"error"`
+	}
+	if err := json.Unmarshal(raw, &result); err != nil {
+		return nil, fmt.Errorf("parse a11y result: %w", err)
+	}
+	if result.Error != "" {
+		return nil, errors.New(result.Error)
+	}
+	summary := &A11ySummary{ByImpact: map[string]int{}}
+	for _, v := range result.Violations {
+		n := len(v.Nodes)
+		if n == 0 {
+			n = 1
+		}
+		impact := v.Impact
+		if impact == "" {
+			impact = "unknown"
+		}
+		summary.Violations += n
+		summary.ByImpact[impact] += n
+		summary.Rules = append(summary.Rules, v.ID)
+	}
+	sort.Strings(summary.Rules)
+	return summary, nil
+}
+
+// HashScreenshot returns a stable content hash for a screenshot data URL or payload.
+func HashScreenshot(dataURL string) string {
+	if i := strings.Index(dataURL, ","); i >= 0 && strings.HasPrefix(dataURL, "data:") {
+		dataURL = dataURL[i+1:]
+	}
+	sum := sha256.Sum256([]byte(dataURL))
+	return hex.EncodeToString(sum[:])
+}
+
+// Finalize derives the story status from its findings.
+func (r *StoryResult) Finalize() {
+	switch {
+	case len(r.Errors) > 0:
+		r.Status = StatusError
+	case len(r.ConsoleErrors) > 0,
+		r.A11y != nil && r.A11y.Violations > 0,
+		r.Visual != nil && r.Visual.Baseline == VisualChanged:
+		r.Status = StatusFail
+	default:
+		r.Status = StatusPass
+	}
+}
+
+// Summary rolls story results into totals.
+type Summary struct {
+	Stories        int `json:"stories"`
+	Passed         int `json:"passed"`
+	Failed         int `json:"failed"`
+	Errored        int `json:"errored"`
+	A11yViolations int `json:"a11y_violations"`
+	ConsoleErrors  int `json:"console_errors"`
+	VisualChanged  int `json:"visual_changed"`
+}
+
+// Summarize totals a set of finalized results.
+func Summarize(results []StoryResult) Summary {
+	s := Summary{Stories: len(results)}
+	for _, r := range results {
+		switch r.Status {
+		case StatusPass:
+			s.Passed++
+		case StatusFail:
+			s.Failed++
+		case StatusError:
+			s.Errored++
+		}
+		if r.A11y != nil {
+			s.A11yViolations += r.A11y.Violations
+		}
+		s.ConsoleErrors += len(r.ConsoleErrors)
+		if r.Visual != nil && r.Visual.Baseline == VisualChanged {
+			s.VisualChanged++
+		}
+	}
+	return s
+}
+
+// Baseline stores screenshot hashes per story for one harness origin.
+type Baseline struct {
+	Origin    string            `json:"origin"`
+	UpdatedAt string            `json:"updated_at"`
+	Hashes    map[string]string `json:"hashes"`
+}
+
+// Compare classifies a story hash against the baseline.
+func (b *Baseline) Compare(storyID, hash string) string {
+	if b == nil {
+		return VisualNew
+	}
+	prev, ok := b.Hashes[storyID]
+	switch {
+	case !ok:
+		return VisualNew
+	case prev == hash:
+		return VisualUnchanged
+	default:
+		return VisualChanged
+	}
+}
+
+var unsafeBaselineChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// BaselinePath returns the baseline file for a harness origin.
+func BaselinePath(origin string) (string, error) {
+	name := strings.Trim(unsafeBaselineChars.ReplaceAllString(origin, "_"), "_")
+	if name == "" {
+		name = "default"
+	}
+	return state.InRoot("component_audit", name+".json")
+}
+
+// LoadBaseline reads a baseline. A missing file returns (nil, nil).
+func LoadBaseline(path string) (*Baseline, error) {
+	data, err := os.ReadFile(path) // #nosec G304 -- path is derived from the state dir
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var b Baseline
+	if err := json.Unmarshal(data, &b); err != nil {
+		return nil, fmt.Errorf("parse baseline %s: %w", path, err)
+	}
+	if b.Hashes == nil {
+		b.Hashes = map[string]string{}
+	}
+	return &b, nil
+}
+
+// SaveBaseline writes a baseline, creating the parent directory.
+func SaveBaseline(path string, b Baseline) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o600)
+}
@@ -0,0 +1,79 @@
+// Purpose: Tests axe summarization, story status derivation, totals, and visual baseline round-trips.
+// Docs: docs/features/feature/component-audit/index.md
+
+package componentaudit
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+)
+
+func TestSummarizeAxe_CountsNodesByImpact(t *testing.T) {
+	t.Parallel()
+	raw := json.RawMessage(`{"violations":[{"id":"color-contrast","impact":"serious","nodes":[{},{}]},{"id":"button-name","impact":"critical","nodes":[{}]}],"passes":[]}`)
+	got, err := SummarizeAxe(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Violations != 3 || got.ByImpact["serious"] != 2 || got.ByImpact["critical"] != 1 {
+		t.Errorf("summary = %+v", got)
+	}
+	if len(got.Rules) != 2 || got.Rules[0] != "button-name" {
+		t.Errorf("rules = %v", got.Rules)
+	}
+	if _, err := SummarizeAxe(json.RawMessage(`{"error":"axe failed to load"}`)); err == nil {
+		t.Error("error payload should fail")
+	}
+}
+
+func TestFinalizeAndSummarize(t *testing.T) {
+	t.Parallel()
+	results := []StoryResult{
+		{StoryID: "ok", A11y: &A11ySummary{}, Visual: &VisualSummary{Baseline: VisualUnchanged}},
+		{StoryID: "a11y", A11y: &A11ySummary{Violations: 2}},
+		{StoryID: "drift", Visual: &VisualSummary{Baseline: VisualChanged}},
+		{StoryID: "console", ConsoleErrors: []string{"TypeError: x"}},
+		{StoryID: "broken", Errors: []string{"navigate: timeout"}},
+	}
+	for i := range results {
+		results[i].Finalize()
+	}
+	want := []string{StatusPass, StatusFail, StatusFail, StatusFail, StatusError}
+	for i, r := range results {
+		if r.Status != want[i] {
+			t.Errorf("%s status = %s, want %s", r.StoryID, r.Status, want[i])
+		}
+	}
+	s := Summarize(results)
+	if s != (Summary{Stories: 5, Passed: 1, Failed: 3, Errored: 1, A11yViolations: 2, ConsoleErrors: 1, VisualChanged: 1}) {
+		t.Errorf("summary = %+v", s)
+	}
+}
+
+func TestBaseline_RoundTripAndCompare(t *testing.T) {
+	t.Parallel()
+	var missing *Baseline
+	if missing.Compare("x", "h") != VisualNew {
+		t.Error("nil baseline should report new")
+	}
+
+	path := filepath.Join(t.TempDir(), "component_audit", "localhost_6006.json")
+	if b, err := LoadBaseline(path); err != nil || b != nil {
+		t.Fatalf("missing baseline = %+v, %v", b, err)
+	}
+	hash := HashScreenshot("data:image/png;base64,AAAA")
+	if hash != HashScreenshot("AAAA") {
+		t.Error("hash should ignore the data URL prefix")
+	}
+	if err := SaveBaseline(path, Baseline{Origin: "http://localhost:6006", Hashes: map[string]string{"button--primary": hash}}); err != nil {
+		t.Fatal(err)
+	}
+	b, err := LoadBaseline(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.Compare("button--primary", hash) != VisualUnchanged || b.Compare("button--primary", "other") != VisualChanged || b.Compare("card", hash) != VisualNew {
+		t.Errorf("compare results wrong for %+v", b)
+	}
+}
@@ -0,0 +1,155 @@
+// Purpose: Extracts colour pairs from axe color-contrast failure summaries and renders CSS custom property overrides.
+// Why: The extension forwards only axe's failureSummary text, so the colours have to be read back out of it.
+// Docs: docs/features/feature/contrast-fixes/index.md
+
+package contrast
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Axe rule IDs that report contrast failures: AA and the AAA "enhanced" variant.
+const (
+	RuleAA  = "color-contrast"
+	RuleAAA = "color-contrast-enhanced"
+)
+
+var (
+	axeColors     = regexp.MustCompile(`foreground color: (#[0-9a-fA-F]{3,8}|rgba?\([^)]*\)), background color: (#[0-9a-fA-F]{3,8}|rgba?\([^)]*\))`)
+	axeExpected   = regexp.MustCompile(`[Ee]xpected contrast ratio of ([0-9.]+):1`)
+	axeFontSize   = regexp.MustCompile(`font size: ([0-9.]+)pt`)
+	axeFontWeight = regexp.MustCompile(`font weight: (\w+)`)
+)
+
+// Fix describes one failing colour pair and the nearest compliant colours for AA and AAA.
+type Fix struct {
+	Foreground string     `json:"foreground"`
+	Background string     `json:"background"`
+	Ratio      float64    `json:"ratio"`
+	LargeText  bool       `json:"large_text"`
+	AA         Suggestion `json:"aa"`
+	AAA        Suggestion `json:"aaa"`
+}
+
+// FixFromAxeSummary reads the colours and text size out of an axe color-contrast
+// failureSummary, such as "Element has insufficient color contrast of 2.84 (foreground
+// color: #999999, background color: #ffffff, font size: 12.0pt (16px), font weight:
+// normal). Expected contrast ratio of 4.5:1". ok is false when no colour pair is present.
+func FixFromAxeSummary(summary string) (Fix, bool) {
+	m := axeColors.FindStringSubmatch(summary)
+	if m == nil {
+		return Fix{}, false
+	}
+	fg, err := ParseColor(m[1])
+	if err != nil {
+		return Fix{}, false
+	}
+	bg, err := ParseColor(m[2])
+	if err != nil {
+		return Fix{}, false
+	}
+	return NewFix(fg, bg, largeText(summary)), true
+}
+
+// NewFix computes AA and AAA suggestions for a colour pair.
+func NewFix(fg, bg Color, large bool) Fix {
+	aa, aaa := Required(large)
+	return Fix{
+		Foreground: fg.Hex(),
+		Background: bg.Hex(),
+		Ratio:      Round2(Ratio(fg, bg)),
+		LargeText:  large,
+		AA:         Suggest(fg, bg, "AA", aa),
+		AAA:        Suggest(fg, bg, "AAA", aaa),
+	}
+}
+
+// largeText applies the WCAG definition (18pt, or 14pt bold). Without a font size it
+// falls back to axe's expected ratio, where 3:1 only applies to large text.
+func largeText(summary string) bool {
+	if m := axeFontSize.FindStringSubmatch(summary); m != nil {
+		pt, _ := strconv.ParseFloat(m[1], 64)
+		weight := ""
+		if w := axeFontWeight.FindStringSubmatch(summary); w != nil {
+			weight = w[1]
+		}
+		bold := weight == "bold" || weight == "bolder"
+		if n, err := strconv.Atoi(weight); err == nil {
+			bold = n >= 700
+		}
+		return pt >= 18 || (bold && pt >= 14)
+	}
+	if m := axeExpected.FindStringSubmatch(summary); m != nil {
+		expected, _ := strconv.ParseFloat(m[1], 64)
+		return expected == RatioAALarge
+	}
+	return false
+}
+
+// Target is a failing selector, the level its rule requires ("AA" or "AAA"), and its fix.
+type Target struct {
+	Selector string
+	Level    string
+	Fix      Fix
+}
+
+// CSSOverrides renders one custom property per failing colour pair and level, and a rule
+// applying it to the affected selectors. The foreground option is preferred because
+// changing text colour rarely affects anything else; the background option is used
+// when no text colour of the same hue is compliant.
+func CSSOverrides(targets []Target) string {
+	type group struct {
+		level     string
+		fix       Fix
+		selectors []string
+	}
+	var order []string
+	groups := map[string]*group{}
+	for _, t := range targets {
+		key := t.Level + t.Fix.Foreground + t.Fix.Background
+		g, ok := groups[key]
+		if !ok {
+			g = &group{level: t.Level, fix: t.Fix}
+			groups[key] = g
+			order = append(order, key)
+		}
+		if t.Selector != "" && !slices.Contains(g.selectors, t.Selector) {
+			g.selectors = append(g.selectors, t.Selector)
+		}
+	}
+
+	var b strings.Builder
+	for i, key := range order {
+		g := groups[key]
+		s := g.fix.AA
+		if g.level == "AAA" {
+			s = g.fix.AAA
+		}
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "/* %s on %s is %.2f:1; WCAG %s needs %g:1 */\n", g.fix.Foreground, g.fix.Background, g.fix.Ratio, g.level, s.RequiredRatio)
+
+		property, option := "color", s.Foreground
+		if option == nil {
+			property, option = "background-color", s.Background
+		}
+		if option == nil {
+			b.WriteString("/* No colour of either hue reaches this ratio; change both colours. */\n")
+			continue
+		}
+		name := fmt.Sprintf("--kaboom-contrast-%s-on-%s", strings.TrimPrefix(g.fix.Foreground, "#"), strings.TrimPrefix(g.fix.Background, "#"))
+		if g.level == "AAA" {
+			name += "-aaa"
+		}
+		fmt.Fprintf(&b, ":root {\n  %s: %s; /* %.2f:1 */\n}\n", name, option.Color, option.Ratio)
+		if len(g.selectors) > 0 {
+			fmt.Fprintf(&b, "%s {\n  %s: var(%s);\n}\n", strings.Join(g.selectors, ",\n"), property, name)
+		}
+	}
+	return b.String()
+}
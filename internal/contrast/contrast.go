@@ -0,0 +1,232 @@
+// Purpose: Computes WCAG contrast ratios and suggests the nearest compliant colours that keep the original hue.
+// Why: A color-contrast violation says what is wrong but not what to change; agents guessed hex values and re-ran the audit.
+// Docs: docs/features/feature/contrast-fixes/index.md
+
+// Package contrast parses the colours axe reports for color-contrast violations and
+// proposes replacements that meet WCAG AA or AAA.
+//
+// Suggestions keep the hue and saturation of the colour being changed and move only its
+// HSL lightness, choosing the smallest change that reaches the required ratio. Both a
+// foreground and a background option are offered; either may be impossible, for example
+// when a mid-grey background cannot reach 7:1 against any text colour of its hue.
+package contrast
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// WCAG 2.x minimum ratios.
+const (
+	RatioAA       = 4.5
+	RatioAALarge  = 3.0
+	RatioAAA      = 7.0
+	RatioAAALarge = 4.5
+)
+
+// lightnessStep is the HSL lightness increment searched for a compliant colour.
+const lightnessStep = 0.002
+
+// Color is an opaque sRGB colour.
+type Color struct {
+	R, G, B uint8
+}
+
+// Hex returns the colour as #rrggbb.
+func (c Color) Hex() string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
+
+var rgbFunc = regexp.MustCompile(`^rgba?\(\s*(\d{1,3})[\s,]+(\d{1,3})[\s,]+(\d{1,3})`)
+
+// ParseColor accepts #rgb, #rrggbb, #rrggbbaa, and rgb()/rgba(). Alpha is ignored:
+// axe reports colours already blended against their backdrop.
+func ParseColor(s string) (Color, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if m := rgbFunc.FindStringSubmatch(s); m != nil {
+		var c [3]uint8
+		for i := range c {
+			n, _ := strconv.Atoi(m[i+1])
+			if n > 255 {
+				return Color{}, fmt.Errorf("invalid colour %q", s)
+			}
+			c[i] = uint8(n)
+		}
+		return Color{c[0], c[1], c[2]}, nil
+	}
+	hex := strings.TrimPrefix(s, "#")
+	switch len(hex) {
+	case 3:
+		hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+	case 6:
+	case 8:
+		hex = hex[:6]
+	default:
+		return Color{}, fmt.Errorf("invalid colour %q", s)
+	}
+	v, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return Color{}, fmt.Errorf("invalid colour %q", s)
+	}
+	return Color{uint8(v >> 16), uint8(v >> 8), uint8(v)}, nil
+}
+
+// Luminance returns the WCAG relative luminance of c.
+func Luminance(c Color) float64 {
+	channel := func(v uint8) float64 {
+		s := float64(v) / 255
+		if s <= 0.04045 {
+			return s / 12.92
+		}
+		return math.Pow((s+0.055)/1.055, 2.4)
+	}
+	return 0.2126*channel(c.R) + 0.7152*channel(c.G) + 0.0722*channel(c.B)
+}
+
+// Ratio returns the WCAG contrast ratio between two colours, from 1 to 21.
+func Ratio(a, b Color) float64 {
+	la, lb := Luminance(a), Luminance(b)
+	if la < lb {
+		la, lb = lb, la
+	}
+	return (la + 0.05) / (lb + 0.05)
+}
+
+// Round2 rounds a ratio to two decimals for display, as axe does.
+func Round2(r float64) float64 {
+	return math.Round(r*100) / 100
+}
+
+// Required returns the AA and AAA ratios for normal or large text.
+func Required(largeText bool) (aa, aaa float64) {
+	if largeText {
+		return RatioAALarge, RatioAAALarge
+	}
+	return RatioAA, RatioAAA
+}
+
+// Option is one compliant replacement colour.
+type Option struct {
+	Color string  `json:"color"`
+	Ratio float64 `json:"ratio"`
+}
+
+// Suggestion is the nearest compliant foreground and background for one target ratio.
+// A nil option means no colour of the original hue reaches the target.
+type Suggestion struct {
+	Level         string  `json:"level"`
+	RequiredRatio float64 `json:"required_ratio"`
+	Foreground    *Option `json:"foreground,omitempty"`
+	Background    *Option `json:"background,omitempty"`
+}
+
+// Suggest returns the nearest foreground (against bg) and background (against fg)
+// reaching target, each keeping the hue of the colour it replaces.
+func Suggest(fg, bg Color, level string, target float64) Suggestion {
+	s := Suggestion{Level: level, RequiredRatio: target}
+	if c, ok := nearest(fg, bg, target); ok {
+		s.Foreground = &Option{Color: c.Hex(), Ratio: Round2(Ratio(c, bg))}
+	}
+	if c, ok := nearest(bg, fg, target); ok {
+		s.Background = &Option{Color: c.Hex(), Ratio: Round2(Ratio(fg, c))}
+	}
+	return s
+}
+
+// nearest moves c's HSL lightness, darker and lighter, until it contrasts with against
+// by at least target, and returns whichever direction needed the smaller change.
+func nearest(c, against Color, target float64) (Color, bool) {
+	if Ratio(c, against) >= target {
+		return c, true
+	}
+	h, s, l := toHSL(c)
+	var best Color
+	bestDelta := math.Inf(1)
+	for _, dir := range []float64{-1, 1} {
+		for delta := lightnessStep; ; delta += lightnessStep {
+			nl := l + dir*delta
+			if nl < 0 || nl > 1 {
+				// The extreme may still pass even when no step landed on it exactly.
+				nl = math.Max(0, math.Min(1, nl))
+				if cand := fromHSL(h, s, nl); Ratio(cand, against) >= target && delta < bestDelta {
+					best, bestDelta = cand, delta
+				}
+				break
+			}
+			if cand := fromHSL(h, s, nl); Ratio(cand, against) >= target {
+				if delta < bestDelta {
+					best, bestDelta = cand, delta
+				}
+				break
+			}
+		}
+	}
+	return best, !math.IsInf(bestDelta, 1)
+}
+
+func toHSL(c Color) (h, s, l float64) {
+	r, g, b := float64(c.R)/255, float64(c.G)/255, float64(c.B)/255
+	maxC, minC := math.Max(r, math.Max(g, b)), math.Min(r, math.Min(g, b))
+	l = (maxC + minC) / 2
+	d := maxC - minC
+	if d == 0 {
+		return 0, 0, l
+	}
+	if l > 0.5 {
+		s = d / (2 - maxC - minC)
+	} else {
+		s = d / (maxC + minC)
+	}
+	switch maxC {
+	case r:
+		h = (g - b) / d
+		if g < b {
+			h += 6
+		}
+	case g:
+		h = (b-r)/d + 2
+	default:
+		h = (r-g)/d + 4
+	}
+	return h / 6, s, l
+}
+
+func fromHSL(h, s, l float64) Color {
+	if s == 0 {
+		v := to8(l)
+		return Color{v, v, v}
+	}
+	var q float64
+	if l < 0.5 {
+		q = l * (1 + s)
+	} else {
+		q = l + s - l*s
+	}
+	p := 2*l - q
+	return Color{to8(hueToRGB(p, q, h+1.0/3)), to8(hueToRGB(p, q, h)), to8(hueToRGB(p, q, h-1.0/3))}
+}
+
+func hueToRGB(p, q, t float64) float64 {
+	if t < 0 {
+		t++
+	}
+	if t > 1 {
+		t--
+	}
+	switch {
+	case t < 1.0/6:
+		return p + (q-p)*6*t
+	case t < 0.5:
+		return q
+	case t < 2.0/3:
+		return p + (q-p)*(2.0/3-t)*6
+	}
+	return p
+}
+
+func to8(v float64) uint8 {
+	return uint8(math.Round(math.Max(0, math.Min(1, v)) * 255))
+}
@@ -0,0 +1,119 @@
+// Purpose: Tests contrast ratios, hue-preserving suggestions, axe summary parsing, and CSS overrides.
+// Docs: docs/features/feature/contrast-fixes/index.md
+
+package contrast
+
+import (
+	"strings"
+	"testing"
+)
+
+func mustColor(t *testing.T, s string) Color {
+	t.Helper()
+	c, err := ParseColor(s)
+	if err != nil {
+		t.Fatalf("ParseColor(%q): %v", s, err)
+	}
+	return c
+}
+
+func TestParseColor(t *testing.T) {
+	t.Parallel()
+	for in, want := range map[string]string{
+		"#fff":                 "#ffffff",
+		"#1A2b3C":              "#1a2b3c",
+		"#11223380":            "#112233",
+		"rgb(255, 0, 10)":      "#ff000a",
+		"rgba(0 128 255 / .5)": "#0080ff",
+	} {
+		if got := mustColor(t, in).Hex(); got != want {
+			t.Errorf("ParseColor(%q) = %s, want %s", in, got, want)
+		}
+	}
+	for _, bad := range []string{"", "#12", "#ggg", "rgb(300,0,0)", "red"} {
+		if _, err := ParseColor(bad); err == nil {
+			t.Errorf("ParseColor(%q) accepted", bad)
+		}
+	}
+}
+
+func TestRatio_KnownPairs(t *testing.T) {
+	t.Parallel()
+	if r := Round2(Ratio(mustColor(t, "#000"), mustColor(t, "#fff"))); r != 21 {
+		t.Errorf("black on white = %v, want 21", r)
+	}
+	if r := Round2(Ratio(mustColor(t, "#999999"), mustColor(t, "#ffffff"))); r != 2.85 {
+		t.Errorf("#999 on white = %v, want 2.85", r)
+	}
+}
+
+func TestSuggest_NearestCompliantKeepsHue(t *testing.T) {
+	t.Parallel()
+	grey := Suggest(mustColor(t, "#999999"), mustColor(t, "#ffffff"), "AA", RatioAA)
+	if grey.Foreground == nil || grey.Foreground.Color != "#767676" || grey.Foreground.Ratio < RatioAA {
+		t.Fatalf("grey foreground = %+v, want #767676", grey.Foreground)
+	}
+
+	blue := mustColor(t, "#6fa8dc")
+	s := Suggest(blue, mustColor(t, "#ffffff"), "AAA", RatioAAA)
+	if s.Foreground == nil {
+		t.Fatal("no AAA foreground for a light blue on white")
+	}
+	got := mustColor(t, s.Foreground.Color)
+	if Ratio(got, mustColor(t, "#ffffff")) < RatioAAA {
+		t.Errorf("suggestion %s is %.2f:1, below 7:1", s.Foreground.Color, s.Foreground.Ratio)
+	}
+	h0, _, _ := toHSL(blue)
+	h1, _, _ := toHSL(got)
+	if d := h0 - h1; d > 0.02 || d < -0.02 {
+		t.Errorf("hue moved from %.3f to %.3f", h0, h1)
+	}
+
+	// Mid greys cannot reach 7:1 at all: even black on #808080 is only 5.3:1.
+	mid := Suggest(mustColor(t, "#777777"), mustColor(t, "#808080"), "AAA", RatioAAA)
+	if mid.Foreground != nil || mid.Background != nil {
+		t.Errorf("mid grey pair = %+v, want no option at 7:1", mid)
+	}
+}
+
+func TestFixFromAxeSummary(t *testing.T) {
+	t.Parallel()
+	summary := "Fix any of the following:\n  Element has insufficient color contrast of 2.84 (foreground color: #999999, background color: #ffffff, font size: 12.0pt (16px), font weight: normal). Expected contrast ratio of 4.5:1"
+	fix, ok := FixFromAxeSummary(summary)
+	if !ok {
+		t.Fatal("summary not parsed")
+	}
+	if fix.Foreground != "#999999" || fix.Background != "#ffffff" || fix.LargeText || fix.AA.RequiredRatio != 4.5 || fix.AAA.RequiredRatio != 7 {
+		t.Fatalf("fix = %+v", fix)
+	}
+
+	large, _ := FixFromAxeSummary("foreground color: #aaaaaa, background color: #ffffff, font size: 14.0pt (18.6667px), font weight: bold). Expected contrast ratio of 3:1")
+	if !large.LargeText || large.AA.RequiredRatio != 3 || large.AAA.RequiredRatio != 4.5 {
+		t.Errorf("bold 14pt fix = %+v, want large text", large)
+	}
+	if _, ok := FixFromAxeSummary("Element's background color could not be determined"); ok {
+		t.Error("summary without colours parsed")
+	}
+}
+
+func TestCSSOverrides_GroupsSelectorsPerPair(t *testing.T) {
+	t.Parallel()
+	fix := NewFix(mustColor(t, "#999999"), mustColor(t, "#ffffff"), false)
+	css := CSSOverrides([]Target{
+		{Selector: ".muted", Level: "AA", Fix: fix},
+		{Selector: "button.checkout", Level: "AA", Fix: fix},
+		{Selector: ".muted", Level: "AA", Fix: fix},
+		{Selector: ".legal", Level: "AAA", Fix: fix},
+	})
+	for _, want := range []string{
+		"/* #999999 on #ffffff is 2.85:1; WCAG AA needs 4.5:1 */",
+		"--kaboom-contrast-999999-on-ffffff: #767676; /* 4.54:1 */",
+		".muted,\nbutton.checkout {\n  color: var(--kaboom-contrast-999999-on-ffffff);\n}",
+		"--kaboom-contrast-999999-on-ffffff-aaa:",
+		".legal {\n  color: var(--kaboom-contrast-999999-on-ffffff-aaa);\n}",
+	} {
+		if !strings.Contains(css, want) {
+			t.Errorf("css missing %q:\n%s", want, css)
+		}
+	}
+}
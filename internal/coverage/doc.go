@@ -0,0 +1,26 @@
+// Purpose: Package coverage — aggregates JS/CSS code coverage snapshots posted by the extension.
+// Why: Unused bytes in shipped bundles slow every page load; agents need the biggest offenders ranked, not raw CDP dumps.
+// Docs: docs/features/feature/code-coverage/index.md
+
+/*
+Package coverage records per-resource JavaScript and CSS coverage snapshots and
+aggregates used/unused bytes per resource URL across page loads.
+
+The extension collects coverage over CDP (Profiler precise coverage and CSS rule
+usage tracking) and POSTs it to /coverage-snapshots. Each resource carries its
+total size and the byte ranges that executed or matched; the Store unions those
+ranges across snapshots, so code used on any visited page counts as used.
+
+Key types:
+  - Snapshot: one page's coverage, as posted by the extension.
+  - Resource: one script or stylesheet within a snapshot.
+  - Store: bounded per-URL aggregates with its own lock (a leaf lock).
+  - Aggregate: used/unused bytes for one resource URL across snapshots.
+  - Report: the biggest unused bundles, weighted by what they cost to download.
+
+Key functions:
+  - Parse: decodes {"snapshots": [...]} or a single snapshot object.
+  - (*Store).Ingest / Aggregates / Summary: record and read aggregates.
+  - BuildReport / (Report).Markdown: rank unused bundles for generate(what="coverage_report").
+*/
+package coverage
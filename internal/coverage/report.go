@@ -0,0 +1,173 @@
+// Purpose: Ranks aggregated coverage into a report of the biggest unused bundles.
+// Why: Unused code still has to be downloaded, parsed, and (for CSS) matched before first render; the largest bundles are where splitting pays off.
+// Docs: docs/features/feature/code-coverage/index.md
+
+package coverage
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// DefaultReportLimit is how many bundles a report lists when no limit is given.
+const DefaultReportLimit = 10
+
+// Severity thresholds. Lighthouse flags unused JavaScript and CSS above 20 KiB.
+const (
+	significantUnusedBytes = 20 * 1024
+	heavyUnusedBytes       = 100 * 1024
+	heavyUnusedPercent     = 50
+)
+
+// Severities.
+const (
+	SeverityHigh   = "high"
+	SeverityMedium = "medium"
+	SeverityLow    = "low"
+)
+
+// Transfer is what downloading a resource cost, taken from the network waterfall.
+type Transfer struct {
+	Bytes      int64   // compressed bytes over the wire
+	DurationMs float64 // request start to response end
+}
+
+// Bundle is one resource in the report.
+type Bundle struct {
+	Aggregate
+	Severity            string  `json:"severity"`
+	TransferBytes       int64   `json:"transfer_bytes,omitempty"`
+	WastedTransferBytes int64   `json:"wasted_transfer_bytes,omitempty"` // transfer bytes scaled by the unused share
+	LoadMs              float64 `json:"load_ms,omitempty"`
+	Fix                 string  `json:"fix"`
+}
+
+// Report lists the biggest unused bundles.
+type Report struct {
+	Summary             Summary  `json:"summary"`
+	Significant         int      `json:"significant"` // bundles with at least 20 KiB unused
+	WastedTransferBytes int64    `json:"wasted_transfer_bytes"`
+	Bundles             []Bundle `json:"bundles"`
+}
+
+// BuildReport ranks aggregates (already sorted by unused bytes) and keeps the top limit
+// that have unused bytes. transfers is keyed by resource URL; missing entries are fine.
+func BuildReport(aggs []Aggregate, summary Summary, transfers map[string]Transfer, limit int) Report {
+	if limit <= 0 {
+		limit = DefaultReportLimit
+	}
+	report := Report{Summary: summary, Bundles: []Bundle{}}
+	for _, a := range aggs {
+		if a.UnusedBytes <= 0 {
+			continue
+		}
+		b := Bundle{Aggregate: a, Severity: severity(a), Fix: fixFor(a)}
+		if t, ok := transfers[a.URL]; ok && t.Bytes > 0 {
+			b.TransferBytes = t.Bytes
+			b.WastedTransferBytes = t.Bytes * a.UnusedBytes / a.TotalBytes
+			b.LoadMs = t.DurationMs
+		}
+		if a.UnusedBytes >= significantUnusedBytes {
+			report.Significant++
+		}
+		report.WastedTransferBytes += b.WastedTransferBytes
+		if len(report.Bundles) < limit {
+			report.Bundles = append(report.Bundles, b)
+		}
+	}
+	return report
+}
+
+func severity(a Aggregate) string {
+	switch {
+	case a.UnusedBytes >= heavyUnusedBytes && a.UnusedPercent >= heavyUnusedPercent:
+		return SeverityHigh
+	case a.UnusedBytes >= significantUnusedBytes:
+		return SeverityMedium
+	default:
+		return SeverityLow
+	}
+}
+
+func fixFor(a Aggregate) string {
+	inline := false
+	for _, p := range a.Pages {
+		inline = inline || p == a.URL
+	}
+	switch {
+	case inline && a.Type == TypeCSS:
+		return "Inline <style> blocks on this page carry unused rules; keep only critical above-the-fold CSS inline."
+	case inline:
+		return "Inline scripts on this page carry code that never runs; move it to a lazily loaded module."
+	case a.Type == TypeCSS:
+		return "Remove unused rules (PurgeCSS or your framework's content scanning) or split route-specific styles out of the shared stylesheet."
+	default:
+		return "Split this bundle with dynamic import() per route or feature so pages download only what they run; check a bundle analyzer for dead dependencies."
+	}
+}
+
+// Markdown renders the report for generate(what="coverage_report").
+func (r Report) Markdown() string {
+	var b strings.Builder
+	s := r.Summary
+	b.WriteString("# Code coverage report\n\n")
+	fmt.Fprintf(&b, "%d snapshot(s), %d resource(s): %s of %s unused (%.1f%%).\n\n",
+		s.Snapshots, s.Resources, FormatBytes(s.UnusedBytes), FormatBytes(s.TotalBytes), s.UnusedPercent)
+	fmt.Fprintf(&b, "- JavaScript: %d file(s), %s of %s unused\n", s.JS.Files, FormatBytes(s.JS.UnusedBytes), FormatBytes(s.JS.TotalBytes))
+	fmt.Fprintf(&b, "- CSS: %d file(s), %s of %s unused\n", s.CSS.Files, FormatBytes(s.CSS.UnusedBytes), FormatBytes(s.CSS.TotalBytes))
+	if r.WastedTransferBytes > 0 {
+		fmt.Fprintf(&b, "- Estimated wasted download: %s\n", FormatBytes(r.WastedTransferBytes))
+	}
+
+	if len(r.Bundles) == 0 {
+		b.WriteString("\nNo unused bytes found.\n")
+		return b.String()
+	}
+	b.WriteString("\n## Biggest unused bundles\n\n")
+	b.WriteString("| # | Resource | Type | Size | Unused | Unused % | Wasted download | Severity |\n")
+	b.WriteString("|---|----------|------|------|--------|----------|-----------------|----------|\n")
+	for i, bundle := range r.Bundles {
+		wasted := "-"
+		if bundle.WastedTransferBytes > 0 {
+			wasted = FormatBytes(bundle.WastedTransferBytes)
+		}
+		fmt.Fprintf(&b, "| %d | `%s` | %s | %s | %s | %.1f%% | %s | %s |\n",
+			i+1, shortName(bundle.URL), bundle.Type, FormatBytes(bundle.TotalBytes), FormatBytes(bundle.UnusedBytes),
+			bundle.UnusedPercent, wasted, bundle.Severity)
+	}
+
+	b.WriteString("\n## What to do\n\n")
+	for _, bundle := range r.Bundles {
+		if bundle.Severity == SeverityLow {
+			continue
+		}
+		fmt.Fprintf(&b, "- **%s** (%s): %s\n", shortName(bundle.URL), bundle.URL, bundle.Fix)
+	}
+	if r.Significant == 0 {
+		b.WriteString("- Nothing over 20 KiB unused; coverage is healthy.\n")
+	}
+	return b.String()
+}
+
+// shortName is the last path segment of a URL, or the URL itself when it has none.
+func shortName(u string) string {
+	trimmed, _, _ := strings.Cut(u, "?")
+	trimmed = strings.TrimSuffix(trimmed, "/")
+	if name := path.Base(trimmed); name != "" && name != "." && !strings.HasSuffix(name, ":") {
+		return strings.ReplaceAll(name, "|", "%7C")
+	}
+	return u
+}
+
+// FormatBytes renders a byte count in B, KiB, or MiB.
+func FormatBytes(n int64) string {
+	switch {
+	case n >= 1<<20:
+		return fmt.Sprintf("%.1f MiB", float64(n)/(1<<20))
+	case n >= 1<<10:
+		return fmt.Sprintf("%.1f KiB", float64(n)/(1<<10))
+	default:
+		return fmt.Sprintf("%d B", n)
+	}
+}
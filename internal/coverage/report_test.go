@@ -0,0 +1,58 @@
+// report_test.go — Tests for ranking unused bundles and rendering the coverage report.
+package coverage
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBuildReport_RanksAndWeighsTransfer(t *testing.T) {
+	t.Parallel()
+	aggs := []Aggregate{
+		{URL: "https://shop.test/vendor.js", Type: TypeJS, TotalBytes: 400 * 1024, UnusedBytes: 300 * 1024, UnusedPercent: 75},
+		{URL: "https://shop.test/site.css", Type: TypeCSS, TotalBytes: 60 * 1024, UnusedBytes: 40 * 1024, UnusedPercent: 66.7},
+		{URL: "https://shop.test/", Type: TypeJS, TotalBytes: 4096, UnusedBytes: 1024, UnusedPercent: 25, Pages: []string{"https://shop.test/"}},
+		{URL: "https://shop.test/used.js", Type: TypeJS, TotalBytes: 100, UsedBytes: 100},
+	}
+	transfers := map[string]Transfer{"https://shop.test/vendor.js": {Bytes: 100 * 1024, DurationMs: 420}}
+	report := BuildReport(aggs, Summarize(aggs), transfers, 0)
+
+	if len(report.Bundles) != 3 || report.Significant != 2 {
+		t.Fatalf("bundles = %d significant = %d, want fully used file skipped", len(report.Bundles), report.Significant)
+	}
+	vendor := report.Bundles[0]
+	if vendor.Severity != SeverityHigh || vendor.TransferBytes != 100*1024 || vendor.WastedTransferBytes != 75*1024 || vendor.LoadMs != 420 {
+		t.Errorf("vendor = %+v", vendor)
+	}
+	if report.Bundles[1].Severity != SeverityMedium || !strings.Contains(report.Bundles[1].Fix, "unused rules") {
+		t.Errorf("css = %+v", report.Bundles[1])
+	}
+	if inline := report.Bundles[2]; inline.Severity != SeverityLow || !strings.Contains(inline.Fix, "Inline scripts") {
+		t.Errorf("inline = %+v", inline)
+	}
+	if report.WastedTransferBytes != 75*1024 {
+		t.Errorf("wasted = %d", report.WastedTransferBytes)
+	}
+	if limited := BuildReport(aggs, Summarize(aggs), nil, 1); len(limited.Bundles) != 1 || limited.Significant != 2 {
+		t.Errorf("limit should trim the list but not the counts: %+v", limited)
+	}
+}
+
+func TestReportMarkdown(t *testing.T) {
+	t.Parallel()
+	aggs := []Aggregate{{URL: "https://shop.test/static/vendor.js?v=3", Type: TypeJS, TotalBytes: 2 << 20, UnusedBytes: 1 << 20, UnusedPercent: 50}}
+	md := BuildReport(aggs, Summarize(aggs), nil, 5).Markdown()
+	for _, want := range []string{
+		"# Code coverage report",
+		"1.0 MiB of 2.0 MiB unused (50.0%)",
+		"| 1 | `vendor.js` | js | 2.0 MiB | 1.0 MiB | 50.0% | - | high |",
+		"- **vendor.js** (https://shop.test/static/vendor.js?v=3): Split this bundle",
+	} {
+		if !strings.Contains(md, want) {
+			t.Errorf("markdown missing %q:\n%s", want, md)
+		}
+	}
+	if empty := BuildReport(nil, Summary{}, nil, 5).Markdown(); !strings.Contains(empty, "No unused bytes found") {
+		t.Errorf("empty report = %s", empty)
+	}
+}
@@ -0,0 +1,156 @@
+// Purpose: Decodes and normalizes coverage snapshots posted by the extension.
+// Why: CDP reports overlapping, unordered ranges; one sorted, clamped shape keeps aggregation simple.
+// Docs: docs/features/feature/code-coverage/index.md
+
+package coverage
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Resource types.
+const (
+	TypeJS  = "js"
+	TypeCSS = "css"
+)
+
+// Payload limits.
+const (
+	MaxBatch     = 20    // snapshots per POST
+	MaxResources = 500   // resources per snapshot, and aggregates retained by the Store
+	MaxRanges    = 20000 // used ranges per resource; beyond this only used_bytes is kept
+	maxURLLength = 2048
+)
+
+// Range is a half-open [start, end) byte range, encoded as a two-element array.
+type Range [2]int64
+
+// Len returns the range length in bytes.
+func (r Range) Len() int64 {
+	return r[1] - r[0]
+}
+
+// Resource is the coverage of one script or stylesheet in a snapshot.
+type Resource struct {
+	URL        string  `json:"url"`
+	Type       string  `json:"type"`
+	TotalBytes int64   `json:"total_bytes"`
+	UsedBytes  int64   `json:"used_bytes"`
+	Ranges     []Range `json:"ranges,omitempty"` // used ranges; when present they decide UsedBytes
+}
+
+// Snapshot is one page's coverage as collected by the extension.
+type Snapshot struct {
+	PageURL   string     `json:"page_url"`
+	TabID     int        `json:"tab_id,omitempty"`
+	Reloaded  bool       `json:"reloaded,omitempty"` // coverage started before the page loaded, so load-time code counts
+	Resources []Resource `json:"resources"`
+	Timestamp time.Time  `json:"timestamp"` // server-side receive time
+}
+
+// Parse decodes {"snapshots": [...]} or a single snapshot object, stamping each with now.
+// Resources without a URL or size are dropped; ranges are clamped, sorted, and merged.
+func Parse(raw []byte, now time.Time) ([]Snapshot, error) {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 {
+		return nil, errors.New("empty body")
+	}
+	var envelope struct {
+		Snapshots []Snapshot `json:"snapshots"`
+	}
+	if err := json.Unmarshal(raw, &envelope); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+	snapshots := envelope.Snapshots
+	if snapshots == nil {
+		var single Snapshot
+		if err := json.Unmarshal(raw, &single); err != nil {
+			return nil, fmt.Errorf("invalid JSON: %w", err)
+		}
+		snapshots = []Snapshot{single}
+	}
+	if len(snapshots) > MaxBatch {
+		return nil, fmt.Errorf("batch of %d snapshots exceeds limit of %d", len(snapshots), MaxBatch)
+	}
+	for i := range snapshots {
+		if len(snapshots[i].Resources) > MaxResources {
+			return nil, fmt.Errorf("snapshot %d: %d resources exceeds limit of %d", i, len(snapshots[i].Resources), MaxResources)
+		}
+		snapshots[i].Timestamp = now
+		snapshots[i].Resources = normalizeResources(snapshots[i].Resources)
+	}
+	return snapshots, nil
+}
+
+// normalizeResources drops unusable entries and canonicalizes the rest in place.
+func normalizeResources(resources []Resource) []Resource {
+	kept := resources[:0]
+	for _, r := range resources {
+		r.URL = strings.TrimSpace(r.URL)
+		if r.URL == "" || len(r.URL) > maxURLLength || r.TotalBytes <= 0 {
+			continue
+		}
+		r.Type = normalizeType(r.Type, r.URL)
+		if len(r.Ranges) > MaxRanges {
+			r.Ranges = nil
+		}
+		if r.Ranges != nil {
+			r.Ranges = mergeRanges(r.Ranges, r.TotalBytes)
+			r.UsedBytes = rangesLen(r.Ranges)
+		}
+		r.UsedBytes = min(max(r.UsedBytes, 0), r.TotalBytes)
+		kept = append(kept, r)
+	}
+	return kept
+}
+
+// normalizeType maps the reported type onto js/css, falling back to the URL extension.
+func normalizeType(t, url string) string {
+	switch strings.ToLower(strings.TrimSpace(t)) {
+	case "css", "stylesheet", "style":
+		return TypeCSS
+	case "js", "javascript", "script":
+		return TypeJS
+	}
+	path, _, _ := strings.Cut(url, "?")
+	if strings.HasSuffix(strings.ToLower(path), ".css") {
+		return TypeCSS
+	}
+	return TypeJS
+}
+
+// mergeRanges clamps ranges to [0, total), drops empty ones, and returns their sorted union.
+func mergeRanges(ranges []Range, total int64) []Range {
+	clamped := make([]Range, 0, len(ranges))
+	for _, r := range ranges {
+		start, end := max(r[0], 0), min(r[1], total)
+		if end > start {
+			clamped = append(clamped, Range{start, end})
+		}
+	}
+	sort.Slice(clamped, func(i, j int) bool { return clamped[i][0] < clamped[j][0] })
+	merged := clamped[:0]
+	for _, r := range clamped {
+		if n := len(merged); n > 0 && r[0] <= merged[n-1][1] {
+			merged[n-1][1] = max(merged[n-1][1], r[1])
+			continue
+		}
+		merged = append(merged, r)
+	}
+	return merged
+}
+
+// rangesLen sums the lengths of disjoint ranges.
+func rangesLen(ranges []Range) int64 {
+	var n int64
+	for _, r := range ranges {
+		n += r.Len()
+	}
+	return n
+}
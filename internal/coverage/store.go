@@ -0,0 +1,241 @@
+// Purpose: Aggregates coverage snapshots into used/unused bytes per resource URL.
+// Why: One page load under-reports usage; unioning ranges across loads shows what is never used anywhere.
+// Docs: docs/features/feature/code-coverage/index.md
+
+package coverage
+
+import (
+	"math"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxPagesPerResource bounds the page URLs remembered for each resource.
+const maxPagesPerResource = 5
+
+// Aggregate is the coverage of one resource URL across every snapshot that loaded it.
+type Aggregate struct {
+	URL           string   `json:"url"`
+	Type          string   `json:"type"`
+	TotalBytes    int64    `json:"total_bytes"`
+	UsedBytes     int64    `json:"used_bytes"`
+	UnusedBytes   int64    `json:"unused_bytes"`
+	UnusedPercent float64  `json:"unused_percent"`
+	Snapshots     int      `json:"snapshots"`
+	Pages         []string `json:"pages,omitempty"`
+	LastSeen      string   `json:"last_seen"`
+}
+
+// TypeTotals sums one resource type.
+type TypeTotals struct {
+	Files       int   `json:"files"`
+	TotalBytes  int64 `json:"total_bytes"`
+	UsedBytes   int64 `json:"used_bytes"`
+	UnusedBytes int64 `json:"unused_bytes"`
+}
+
+// Summary totals the retained aggregates.
+type Summary struct {
+	Snapshots     int        `json:"snapshots"`
+	Resources     int        `json:"resources"`
+	TotalBytes    int64      `json:"total_bytes"`
+	UnusedBytes   int64      `json:"unused_bytes"`
+	UnusedPercent float64    `json:"unused_percent"`
+	JS            TypeTotals `json:"js"`
+	CSS           TypeTotals `json:"css"`
+	LastSnapshot  string     `json:"last_snapshot_at,omitempty"`
+}
+
+type resourceKey struct {
+	url, typ string
+}
+
+// entry accumulates one resource. usedFloor holds used_bytes from range-less reports,
+// which cannot be unioned, so the larger of it and the range union wins.
+type entry struct {
+	total     int64
+	ranges    []Range
+	usedFloor int64
+	snapshots int
+	pages     []string
+	lastSeen  time.Time
+}
+
+func (e *entry) used() int64 {
+	return min(max(rangesLen(e.ranges), e.usedFloor), e.total)
+}
+
+// Store keeps bounded per-resource coverage aggregates.
+//
+// Lock hierarchy: Store.mu is a leaf lock; Store never calls out while holding it.
+type Store struct {
+	mu        sync.Mutex
+	entries   map[resourceKey]*entry
+	order     []resourceKey // least recently seen first
+	snapshots int
+	lastAt    time.Time
+}
+
+// NewStore creates an empty store.
+func NewStore() *Store {
+	return &Store{entries: make(map[resourceKey]*entry)}
+}
+
+// Ingest merges snapshots into the aggregates. Returns the number of resources recorded.
+func (s *Store) Ingest(snapshots []Snapshot) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	recorded := 0
+	for _, snap := range snapshots {
+		if len(snap.Resources) == 0 {
+			continue
+		}
+		s.snapshots++
+		if snap.Timestamp.After(s.lastAt) {
+			s.lastAt = snap.Timestamp
+		}
+		for _, r := range snap.Resources {
+			s.merge(r, snap.PageURL, snap.Timestamp)
+			recorded++
+		}
+	}
+	s.evict()
+	return recorded
+}
+
+// merge folds one resource report into its aggregate. A changed total size means a new
+// build of the bundle, so earlier ranges no longer line up and are discarded.
+func (s *Store) merge(r Resource, pageURL string, at time.Time) {
+	key := resourceKey{url: r.URL, typ: r.Type}
+	e, ok := s.entries[key]
+	if !ok || e.total != r.TotalBytes {
+		e = &entry{total: r.TotalBytes}
+		s.entries[key] = e
+	}
+	if r.Ranges != nil {
+		e.ranges = mergeRanges(append(e.ranges, r.Ranges...), e.total)
+	} else {
+		e.usedFloor = max(e.usedFloor, r.UsedBytes)
+	}
+	e.snapshots++
+	e.lastSeen = at
+	if pageURL != "" && len(e.pages) < maxPagesPerResource && !slices.Contains(e.pages, pageURL) {
+		e.pages = append(e.pages, pageURL)
+	}
+	s.touch(key)
+}
+
+// touch moves key to the most recently seen end of the eviction order.
+func (s *Store) touch(key resourceKey) {
+	if i := slices.Index(s.order, key); i >= 0 {
+		s.order = slices.Delete(s.order, i, i+1)
+	}
+	s.order = append(s.order, key)
+}
+
+// evict drops the least recently seen resources beyond MaxResources.
+func (s *Store) evict() {
+	for len(s.order) > MaxResources {
+		delete(s.entries, s.order[0])
+		s.order = s.order[1:]
+	}
+}
+
+// Aggregates returns resources whose URL or page URL contains filter (all when empty),
+// largest unused byte count first.
+func (s *Store) Aggregates(filter string) []Aggregate {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.aggregatesLocked(filter)
+}
+
+// aggregatesLocked builds Aggregates output. Caller holds s.mu.
+func (s *Store) aggregatesLocked(filter string) []Aggregate {
+	out := make([]Aggregate, 0, len(s.entries))
+	for key, e := range s.entries {
+		if filter != "" && !strings.Contains(key.url, filter) && !anyContains(e.pages, filter) {
+			continue
+		}
+		used := e.used()
+		out = append(out, Aggregate{
+			URL:           key.url,
+			Type:          key.typ,
+			TotalBytes:    e.total,
+			UsedBytes:     used,
+			UnusedBytes:   e.total - used,
+			UnusedPercent: percent(e.total-used, e.total),
+			Snapshots:     e.snapshots,
+			Pages:         append([]string(nil), e.pages...),
+			LastSeen:      e.lastSeen.UTC().Format(time.RFC3339),
+		})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].UnusedBytes != out[j].UnusedBytes {
+			return out[i].UnusedBytes > out[j].UnusedBytes
+		}
+		return out[i].URL < out[j].URL
+	})
+	return out
+}
+
+// Summary totals the retained aggregates.
+func (s *Store) Summary() Summary {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sum := Summarize(s.aggregatesLocked(""))
+	sum.Snapshots = s.snapshots
+	if !s.lastAt.IsZero() {
+		sum.LastSnapshot = s.lastAt.UTC().Format(time.RFC3339)
+	}
+	return sum
+}
+
+// Clear drops all aggregates.
+func (s *Store) Clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries = make(map[resourceKey]*entry)
+	s.order = nil
+	s.snapshots = 0
+	s.lastAt = time.Time{}
+}
+
+// Summarize totals a list of aggregates by type.
+func Summarize(aggs []Aggregate) Summary {
+	var sum Summary
+	for _, a := range aggs {
+		totals := &sum.JS
+		if a.Type == TypeCSS {
+			totals = &sum.CSS
+		}
+		totals.Files++
+		totals.TotalBytes += a.TotalBytes
+		totals.UsedBytes += a.UsedBytes
+		totals.UnusedBytes += a.UnusedBytes
+		sum.TotalBytes += a.TotalBytes
+		sum.UnusedBytes += a.UnusedBytes
+	}
+	sum.Resources = len(aggs)
+	sum.UnusedPercent = percent(sum.UnusedBytes, sum.TotalBytes)
+	return sum
+}
+
+// percent returns part/whole as a percentage rounded to one decimal.
+func percent(part, whole int64) float64 {
+	if whole <= 0 {
+		return 0
+	}
+	return math.Round(float64(part)*1000/float64(whole)) / 10
+}
+
+func anyContains(list []string, sub string) bool {
+	for _, v := range list {
+		if strings.Contains(v, sub) {
+			return true
+		}
+	}
+	return false
+}
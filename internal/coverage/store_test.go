@@ -0,0 +1,122 @@
+// store_test.go — Tests for coverage snapshot parsing and per-URL aggregation.
+package coverage
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestParse_NormalizesResources(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	snaps, err := Parse([]byte(`{"snapshots":[{"page_url":"https://shop.test/","resources":[
+		{"url":"https://shop.test/app.js","total_bytes":100,"used_bytes":99,"ranges":[[50,70],[0,10],[5,20],[90,150],[30,30]]},
+		{"url":"https://shop.test/site.css?v=2","total_bytes":40,"used_bytes":55},
+		{"url":"","total_bytes":10},
+		{"url":"https://shop.test/empty.js","total_bytes":0}
+	]}]}`), now)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if len(snaps) != 1 || !snaps[0].Timestamp.Equal(now) {
+		t.Fatalf("snapshots = %+v", snaps)
+	}
+	res := snaps[0].Resources
+	if len(res) != 2 {
+		t.Fatalf("resources = %+v, want the two usable entries", res)
+	}
+	js := res[0]
+	if js.Type != TypeJS || js.UsedBytes != 50 || fmt.Sprint(js.Ranges) != "[[0 20] [50 70] [90 100]]" {
+		t.Errorf("js = %+v, want merged, clamped ranges deciding used bytes", js)
+	}
+	if css := res[1]; css.Type != TypeCSS || css.UsedBytes != 40 {
+		t.Errorf("css = %+v, want type from extension and used clamped to total", css)
+	}
+}
+
+func TestParse_SingleSnapshotAndLimits(t *testing.T) {
+	t.Parallel()
+	snaps, err := Parse([]byte(`{"page_url":"https://a.test/","resources":[{"url":"https://a.test/a.js","type":"script","total_bytes":5,"used_bytes":1}]}`), time.Now())
+	if err != nil || len(snaps) != 1 || snaps[0].Resources[0].Type != TypeJS {
+		t.Fatalf("single snapshot = %+v, %v", snaps, err)
+	}
+	if _, err := Parse([]byte(`{not json`), time.Now()); err == nil {
+		t.Error("invalid JSON should fail")
+	}
+	if _, err := Parse(nil, time.Now()); err == nil {
+		t.Error("empty body should fail")
+	}
+}
+
+func TestStore_UnionsRangesAcrossPages(t *testing.T) {
+	t.Parallel()
+	s := NewStore()
+	at := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	s.Ingest([]Snapshot{
+		{PageURL: "https://shop.test/", Timestamp: at, Resources: []Resource{
+			{URL: "https://shop.test/app.js", Type: TypeJS, TotalBytes: 1000, Ranges: []Range{{0, 200}}},
+			{URL: "https://shop.test/site.css", Type: TypeCSS, TotalBytes: 400, UsedBytes: 100},
+		}},
+		{PageURL: "https://shop.test/cart", Timestamp: at.Add(time.Minute), Resources: []Resource{
+			{URL: "https://shop.test/app.js", Type: TypeJS, TotalBytes: 1000, Ranges: []Range{{100, 300}}},
+			{URL: "https://shop.test/site.css", Type: TypeCSS, TotalBytes: 400, UsedBytes: 50},
+		}},
+	})
+
+	aggs := s.Aggregates("")
+	if len(aggs) != 2 {
+		t.Fatalf("aggregates = %+v", aggs)
+	}
+	app := aggs[0]
+	if app.URL != "https://shop.test/app.js" || app.UsedBytes != 300 || app.UnusedBytes != 700 || app.UnusedPercent != 70 {
+		t.Errorf("app = %+v, want ranges unioned to 300 used", app)
+	}
+	if app.Snapshots != 2 || len(app.Pages) != 2 {
+		t.Errorf("app snapshots/pages = %d %v", app.Snapshots, app.Pages)
+	}
+	if css := aggs[1]; css.UsedBytes != 100 {
+		t.Errorf("css = %+v, want the larger range-less used count", css)
+	}
+	if got := s.Aggregates("/cart"); len(got) != 2 {
+		t.Errorf("page filter should match resources loaded by the page, got %d", len(got))
+	}
+
+	sum := s.Summary()
+	if sum.Snapshots != 2 || sum.Resources != 2 || sum.UnusedBytes != 1000 || sum.JS.UnusedBytes != 700 || sum.CSS.Files != 1 {
+		t.Errorf("summary = %+v", sum)
+	}
+	if sum.LastSnapshot != "2026-10-17T12:01:00Z" {
+		t.Errorf("last snapshot = %q", sum.LastSnapshot)
+	}
+}
+
+func TestStore_RebuildResetsRanges(t *testing.T) {
+	t.Parallel()
+	s := NewStore()
+	s.Ingest([]Snapshot{{Resources: []Resource{{URL: "https://a.test/app.js", Type: TypeJS, TotalBytes: 1000, Ranges: []Range{{0, 900}}}}}})
+	s.Ingest([]Snapshot{{Resources: []Resource{{URL: "https://a.test/app.js", Type: TypeJS, TotalBytes: 1200, Ranges: []Range{{0, 100}}}}}})
+	agg := s.Aggregates("")[0]
+	if agg.TotalBytes != 1200 || agg.UsedBytes != 100 || agg.Snapshots != 1 {
+		t.Errorf("after rebuild = %+v, want only the new build's coverage", agg)
+	}
+}
+
+func TestStore_EvictsLeastRecentlySeen(t *testing.T) {
+	t.Parallel()
+	s := NewStore()
+	for i := 0; i <= MaxResources; i++ {
+		s.Ingest([]Snapshot{{Resources: []Resource{{URL: fmt.Sprintf("https://a.test/%d.js", i), Type: TypeJS, TotalBytes: 10}}}})
+	}
+	aggs := s.Aggregates("")
+	if len(aggs) != MaxResources {
+		t.Fatalf("retained %d, want %d", len(aggs), MaxResources)
+	}
+	if got := s.Aggregates("https://a.test/0.js"); len(got) != 0 {
+		t.Errorf("oldest resource should be evicted, got %+v", got)
+	}
+	s.Clear()
+	if sum := s.Summary(); sum.Resources != 0 || sum.Snapshots != 0 {
+		t.Errorf("after clear = %+v", sum)
+	}
+}
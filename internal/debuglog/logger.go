@@ -19,6 +19,7 @@ type Logger struct {
 	pollingLogIndex   int
 	httpDebugLog      []types.HTTPDebugEntry
 	httpDebugLogIndex int
+	httpDebugTotal    int64
 }
 
 // NewLogger creates a Logger with pre-allocated circular buffers.
@@ -43,6 +44,7 @@ func (dl *Logger) LogHTTPDebugEntry(entry types.HTTPDebugEntry) {
 	defer dl.mu.Unlock()
 	dl.httpDebugLog[dl.httpDebugLogIndex] = entry
 	dl.httpDebugLogIndex = (dl.httpDebugLogIndex + 1) % LogSize
+	dl.httpDebugTotal++
 }
 
 // GetPollingLog returns a copy of the polling activity log.
@@ -62,3 +64,20 @@ func (dl *Logger) GetHTTPDebugLog() []types.HTTPDebugEntry {
 	copy(result, dl.httpDebugLog)
 	return result
 }
+
+// GetHTTPDebugEntries returns the logged HTTP entries oldest first, without unused slots,
+// plus the total ever logged so callers can assign stable cursor sequences.
+func (dl *Logger) GetHTTPDebugEntries() ([]types.HTTPDebugEntry, int64) {
+	dl.mu.Lock()
+	defer dl.mu.Unlock()
+	n := LogSize
+	if dl.httpDebugTotal < LogSize {
+		n = int(dl.httpDebugTotal)
+	}
+	result := make([]types.HTTPDebugEntry, 0, n)
+	start := (dl.httpDebugLogIndex - n + LogSize) % LogSize
+	for i := 0; i < n; i++ {
+		result = append(result, dl.httpDebugLog[(start+i)%LogSize])
+	}
+	return result, dl.httpDebugTotal
+}
@@ -166,3 +166,29 @@ func TestLogger_PollingLogCircularWrapping(t *testing.T) {
 		}
 	}
 }
+
+func TestLogger_GetHTTPDebugEntriesChronological(t *testing.T) {
+	t.Parallel()
+	dl := NewLogger()
+
+	for i := 0; i < 3; i++ {
+		dl.LogHTTPDebugEntry(types.HTTPDebugEntry{ResponseStatus: i})
+	}
+	entries, total := dl.GetHTTPDebugEntries()
+	if len(entries) != 3 || total != 3 || entries[0].ResponseStatus != 0 || entries[2].ResponseStatus != 2 {
+		t.Fatalf("before wrap: entries=%v total=%d", entries, total)
+	}
+
+	for i := 3; i < 60; i++ {
+		dl.LogHTTPDebugEntry(types.HTTPDebugEntry{ResponseStatus: i})
+	}
+	entries, total = dl.GetHTTPDebugEntries()
+	if len(entries) != LogSize || total != 60 {
+		t.Fatalf("after wrap: len=%d total=%d", len(entries), total)
+	}
+	for i, e := range entries {
+		if e.ResponseStatus != 10+i {
+			t.Fatalf("entries[%d].ResponseStatus = %d, want %d (oldest first)", i, e.ResponseStatus, 10+i)
+		}
+	}
+}
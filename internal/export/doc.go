@@ -1,4 +1,4 @@
-// Purpose: Package export — HAR 1.2 and SARIF 2.1.0 serializers for captured browser data.
+// Purpose: Package export — HAR 1.2, SARIF 2.1.0, JUnit XML, GitHub Actions annotation, and request snippet serializers for captured browser data.
 // Why: Provides stable export formats consumed by browser DevTools, GitHub Code Scanning, and CI pipelines.
 // Docs: docs/features/feature/har-export/index.md
 
@@ -8,6 +8,9 @@ Package export converts captured browser telemetry into standard interchange for
 Key functions:
   - ExportHAR: converts NetworkBody entries into HAR 1.2 JSON for import into DevTools or Charles Proxy.
   - ExportSARIF: converts axe-core accessibility violations into SARIF 2.1.0 for GitHub Code Scanning.
+  - BuildJUnitReport / MarshalJUnit: render pass/fail session checks as JUnit XML for CI pipelines.
+  - FormatGHAnnotations: render findings as GitHub Actions workflow commands that annotate a PR diff.
+  - FormatCurl / FormatFetch: render one captured request as a runnable snippet with sensitive headers redacted.
   - SaveToFile: writes export output to a file path with atomic write semantics.
 */
 package export
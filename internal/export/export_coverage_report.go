@@ -0,0 +1,25 @@
+// Purpose: Writes the Markdown code coverage report produced by generate(what="coverage_report").
+// Why: A saved report can be attached to a PR or perf ticket next to the bundle analyzer output.
+// Docs: docs/features/feature/code-coverage/index.md
+
+package export
+
+import "fmt"
+
+// CoverageReportExportResult describes a written coverage report.
+type CoverageReportExportResult struct {
+	SavedTo       string `json:"saved_to"`
+	FileSizeBytes int64  `json:"file_size_bytes"`
+}
+
+// WriteCoverageReportFile writes a rendered report to path. Paths follow the HAR export rules.
+func WriteCoverageReportFile(markdown, path string) (CoverageReportExportResult, error) {
+	if !isPathSafe(path) {
+		return CoverageReportExportResult{}, fmt.Errorf("unsafe path: %s", path)
+	}
+	data := []byte(markdown)
+	if err := writeHARData(path, data); err != nil {
+		return CoverageReportExportResult{}, err
+	}
+	return CoverageReportExportResult{SavedTo: path, FileSizeBytes: int64(len(data))}, nil
+}
@@ -0,0 +1,97 @@
+// Purpose: Renders findings as GitHub Actions workflow commands (::error file=...,line=...::message).
+// Why: A CI step that prints these lines annotates the PR diff directly, with no upload action or token.
+// Docs: docs/features/feature/gh-annotations-export/index.md
+
+package export
+
+import (
+	"fmt"
+	"strings"
+)
+
+// GitHub Actions annotation levels.
+// SPEC:GitHubActions — workflow command names for annotations.
+const (
+	GHAnnotationError   = "error"
+	GHAnnotationWarning = "warning"
+	GHAnnotationNotice  = "notice"
+)
+
+// GHAnnotation is one workflow command annotation. Without File it shows in the run summary only.
+type GHAnnotation struct {
+	Level   string `json:"level"`
+	File    string `json:"file,omitempty"` // repository-relative, forward slashes
+	Line    int    `json:"line,omitempty"`
+	Column  int    `json:"col,omitempty"`
+	Title   string `json:"title,omitempty"`
+	Message string `json:"message"`
+}
+
+// GHAnnotationsExportResult describes an annotation file written to disk.
+type GHAnnotationsExportResult struct {
+	SavedTo       string `json:"saved_to"`
+	FileSizeBytes int64  `json:"file_size_bytes"`
+}
+
+// String renders the annotation as a single workflow command line.
+func (a GHAnnotation) String() string {
+	var props []string
+	if a.File != "" {
+		props = append(props, "file="+escapeGHProperty(a.File))
+		if a.Line > 0 {
+			props = append(props, fmt.Sprintf("line=%d", a.Line))
+			if a.Column > 0 {
+				props = append(props, fmt.Sprintf("col=%d", a.Column))
+			}
+		}
+	}
+	if a.Title != "" {
+		props = append(props, "title="+escapeGHProperty(a.Title))
+	}
+	level := a.Level
+	if level == "" {
+		level = GHAnnotationError
+	}
+	cmd := "::" + level
+	if len(props) > 0 {
+		cmd += " " + strings.Join(props, ",")
+	}
+	return cmd + "::" + escapeGHData(a.Message)
+}
+
+// FormatGHAnnotations renders one workflow command per line.
+func FormatGHAnnotations(annotations []GHAnnotation) string {
+	var sb strings.Builder
+	for _, a := range annotations {
+		sb.WriteString(a.String())
+		sb.WriteByte('\n')
+	}
+	return sb.String()
+}
+
+// WriteGHAnnotationsFile writes the rendered commands to path so a CI step can cat them.
+// Paths follow the HAR export rules.
+func WriteGHAnnotationsFile(annotations []GHAnnotation, path string) (GHAnnotationsExportResult, error) {
+	if !isPathSafe(path) {
+		return GHAnnotationsExportResult{}, fmt.Errorf("unsafe path: %s", path)
+	}
+	data := []byte(FormatGHAnnotations(annotations))
+	if err := writeHARData(path, data); err != nil {
+		return GHAnnotationsExportResult{}, err
+	}
+	return GHAnnotationsExportResult{SavedTo: path, FileSizeBytes: int64(len(data))}, nil
+}
+
+// escapeGHData escapes a command's message; a raw newline would end the command.
+func escapeGHData(s string) string {
+	s = strings.ReplaceAll(s, "%", "%25")
+	s = strings.ReplaceAll(s, "\r", "%0D")
+	return strings.ReplaceAll(s, "\n", "%0A")
+}
+
+// escapeGHProperty additionally escapes the property separators.
+func escapeGHProperty(s string) string {
+	s = escapeGHData(s)
+	s = strings.ReplaceAll(s, ":", "%3A")
+	return strings.ReplaceAll(s, ",", "%2C")
+}
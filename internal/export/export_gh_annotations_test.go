@@ -0,0 +1,68 @@
+// Purpose: Unit tests for GitHub Actions workflow command rendering, escaping, and file output.
+// Docs: docs/features/feature/gh-annotations-export/index.md
+
+package export
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestGHAnnotation_String(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		in   GHAnnotation
+		want string
+	}{
+		{
+			name: "file position and title",
+			in:   GHAnnotation{Level: GHAnnotationError, File: "src/cart.ts", Line: 42, Column: 7, Title: "Console error", Message: "TypeError: x is undefined"},
+			want: "::error file=src/cart.ts,line=42,col=7,title=Console error::TypeError: x is undefined",
+		},
+		{
+			name: "escapes separators and newlines",
+			in:   GHAnnotation{Level: GHAnnotationWarning, File: "src/a,b.ts", Title: "API contract: type_change", Message: "100% broken\nsecond line"},
+			want: "::warning file=src/a%2Cb.ts,title=API contract%3A type_change::100%25 broken%0Asecond line",
+		},
+		{
+			name: "summary only",
+			in:   GHAnnotation{Level: GHAnnotationNotice, Line: 3, Message: "minor"},
+			want: "::notice::minor",
+		},
+		{
+			name: "default level",
+			in:   GHAnnotation{Message: "boom"},
+			want: "::error::boom",
+		},
+	}
+	for _, tt := range tests {
+		if got := tt.in.String(); got != tt.want {
+			t.Errorf("%s:\n got %q\nwant %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestWriteGHAnnotationsFile(t *testing.T) {
+	t.Parallel()
+
+	annotations := []GHAnnotation{{Message: "one"}, {Level: GHAnnotationWarning, Message: "two"}}
+	path := filepath.Join(t.TempDir(), "annotations.txt")
+	result, err := WriteGHAnnotationsFile(annotations, path)
+	if err != nil {
+		t.Fatalf("WriteGHAnnotationsFile: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read written file: %v", err)
+	}
+	if string(data) != "::error::one\n::warning::two\n" || result.FileSizeBytes != int64(len(data)) {
+		t.Errorf("file = %q, result = %+v", data, result)
+	}
+
+	if _, err := WriteGHAnnotationsFile(annotations, "../escape.txt"); err == nil {
+		t.Error("path traversal should be rejected")
+	}
+}
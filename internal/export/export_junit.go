@@ -0,0 +1,127 @@
+// Purpose: Serializes pass/fail/skip session checks as a JUnit XML report for CI pipelines.
+// Why: CI systems read JUnit natively, so a browser session can fail a pipeline without a custom parser.
+// Docs: docs/features/feature/junit-export/index.md
+
+package export
+
+import (
+	"encoding/xml"
+	"fmt"
+	"time"
+)
+
+// JUnitReport is the <testsuites> root element.
+// SPEC:JUnit — element and attribute names follow the JUnit XML report format.
+type JUnitReport struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Errors   int              `xml:"errors,attr"`
+	Skipped  int              `xml:"skipped,attr"`
+	Time     string           `xml:"time,attr"`
+	Suites   []JUnitTestSuite `xml:"testsuite"`
+}
+
+// JUnitTestSuite is one <testsuite>.
+type JUnitTestSuite struct {
+	Name       string          `xml:"name,attr"`
+	Tests      int             `xml:"tests,attr"`
+	Failures   int             `xml:"failures,attr"`
+	Errors     int             `xml:"errors,attr"`
+	Skipped    int             `xml:"skipped,attr"`
+	Time       string          `xml:"time,attr"`
+	Timestamp  string          `xml:"timestamp,attr"`
+	Properties []JUnitProperty `xml:"properties>property,omitempty"`
+	TestCases  []JUnitTestCase `xml:"testcase"`
+}
+
+// JUnitProperty is one <property> of a suite.
+type JUnitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+// JUnitTestCase is one <testcase>. A case with neither Failure nor Skipped passed.
+type JUnitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *JUnitFailure `xml:"failure,omitempty"`
+	Skipped   *JUnitSkipped `xml:"skipped,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+// JUnitFailure explains a failed case; Text carries one offending item per line.
+type JUnitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// JUnitSkipped explains why a case could not run.
+type JUnitSkipped struct {
+	Message string `xml:"message,attr"`
+}
+
+// JUnitExportResult describes a JUnit report written to disk.
+type JUnitExportResult struct {
+	SavedTo       string `json:"saved_to"`
+	FileSizeBytes int64  `json:"file_size_bytes"`
+}
+
+// BuildJUnitReport wraps cases in a single suite named name and fills in every count.
+func BuildJUnitReport(name string, cases []JUnitTestCase, properties []JUnitProperty, now time.Time) JUnitReport {
+	suite := JUnitTestSuite{
+		Name:       name,
+		Tests:      len(cases),
+		Time:       "0",
+		Timestamp:  now.UTC().Format("2006-01-02T15:04:05"),
+		Properties: properties,
+		TestCases:  cases,
+	}
+	for i := range suite.TestCases {
+		c := &suite.TestCases[i]
+		if c.Time == "" {
+			c.Time = "0"
+		}
+		switch {
+		case c.Failure != nil:
+			suite.Failures++
+		case c.Skipped != nil:
+			suite.Skipped++
+		}
+	}
+	return JUnitReport{
+		Name:     name,
+		Tests:    suite.Tests,
+		Failures: suite.Failures,
+		Skipped:  suite.Skipped,
+		Time:     suite.Time,
+		Suites:   []JUnitTestSuite{suite},
+	}
+}
+
+// MarshalJUnit renders the report as indented XML with the standard header.
+func MarshalJUnit(report JUnitReport) ([]byte, error) {
+	body, err := xml.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal JUnit: %w", err)
+	}
+	return append([]byte(xml.Header), append(body, '\n')...), nil
+}
+
+// WriteJUnitFile writes the report to path. Paths follow the HAR export rules.
+func WriteJUnitFile(report JUnitReport, path string) (JUnitExportResult, error) {
+	if !isPathSafe(path) {
+		return JUnitExportResult{}, fmt.Errorf("unsafe path: %s", path)
+	}
+	data, err := MarshalJUnit(report)
+	if err != nil {
+		return JUnitExportResult{}, err
+	}
+	if err := writeHARData(path, data); err != nil {
+		return JUnitExportResult{}, err
+	}
+	return JUnitExportResult{SavedTo: path, FileSizeBytes: int64(len(data))}, nil
+}
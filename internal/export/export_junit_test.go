@@ -0,0 +1,77 @@
+// Purpose: Unit tests for JUnit XML report building, marshaling, and file output.
+// Docs: docs/features/feature/junit-export/index.md
+
+package export
+
+import (
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBuildJUnitReport_CountsAndMarshal(t *testing.T) {
+	t.Parallel()
+
+	cases := []JUnitTestCase{
+		{Name: "no_console_errors", ClassName: "kaboom.console"},
+		{Name: "no_server_errors", ClassName: "kaboom.network", Failure: &JUnitFailure{Message: "1 response(s)", Type: "ServerError", Text: "GET /api -> 503 & <retry>"}},
+		{Name: "no_serious_a11y_violations", ClassName: "kaboom.accessibility", Skipped: &JUnitSkipped{Message: "Extension not connected"}},
+	}
+	now := time.Date(2026, 10, 16, 9, 30, 0, 0, time.UTC)
+	report := BuildJUnitReport("kaboom", cases, []JUnitProperty{{Name: "page_url", Value: "https://app.test/"}}, now)
+	if report.Tests != 3 || report.Failures != 1 || report.Skipped != 1 {
+		t.Fatalf("counts = tests %d failures %d skipped %d, want 3/1/1", report.Tests, report.Failures, report.Skipped)
+	}
+
+	data, err := MarshalJUnit(report)
+	if err != nil {
+		t.Fatalf("MarshalJUnit: %v", err)
+	}
+	out := string(data)
+	for _, want := range []string{
+		`<?xml version="1.0" encoding="UTF-8"?>`,
+		`<testsuites name="kaboom" tests="3" failures="1" errors="0" skipped="1" time="0">`,
+		`<testsuite name="kaboom" tests="3" failures="1" errors="0" skipped="1" time="0" timestamp="2026-10-16T09:30:00">`,
+		`<property name="page_url" value="https://app.test/"></property>`,
+		`<testcase name="no_console_errors" classname="kaboom.console" time="0"></testcase>`,
+		`GET /api -&gt; 503 &amp; &lt;retry&gt;`,
+		`<skipped message="Extension not connected"></skipped>`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("XML missing %q:\n%s", want, out)
+		}
+	}
+
+	var parsed JUnitReport
+	if err := xml.Unmarshal(data, &parsed); err != nil {
+		t.Fatalf("XML does not round-trip: %v", err)
+	}
+	if got := parsed.Suites[0].TestCases[1].Failure; got == nil || got.Text != "GET /api -> 503 & <retry>" {
+		t.Errorf("failure text after round-trip = %+v", got)
+	}
+}
+
+func TestWriteJUnitFile(t *testing.T) {
+	t.Parallel()
+
+	report := BuildJUnitReport("kaboom", []JUnitTestCase{{Name: "no_console_errors", ClassName: "kaboom.console"}}, nil, time.Now())
+	path := filepath.Join(t.TempDir(), "kaboom-junit.xml")
+	result, err := WriteJUnitFile(report, path)
+	if err != nil {
+		t.Fatalf("WriteJUnitFile: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read written file: %v", err)
+	}
+	if result.SavedTo != path || result.FileSizeBytes != int64(len(data)) {
+		t.Errorf("result = %+v, file has %d bytes", result, len(data))
+	}
+
+	if _, err := WriteJUnitFile(report, "../escape.xml"); err == nil {
+		t.Error("path traversal should be rejected")
+	}
+}
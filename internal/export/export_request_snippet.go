@@ -0,0 +1,145 @@
+// Purpose: Renders a captured request as a runnable cURL command or fetch() snippet.
+// Why: Reproducing an API failure outside the browser should take one paste, without leaking the session's credentials.
+// Docs: docs/features/feature/request-snippets/index.md
+
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/redaction"
+)
+
+// RequestSnippet is the request a snippet reproduces.
+type RequestSnippet struct {
+	Method  string
+	URL     string
+	Headers map[string]string
+	Body    string
+}
+
+// snippetSkippedHeaders are computed by the client and would be wrong once the body changes.
+var snippetSkippedHeaders = map[string]bool{
+	"content-length": true,
+	"host":           true,
+}
+
+// RedactSnippetHeaders replaces the value of every header the redaction key rules treat as
+// sensitive (Authorization, Cookie, X-API-Key, ...) with its redaction marker. It returns the
+// headers in sorted order and the names that were replaced.
+func RedactSnippetHeaders(headers map[string]string) (pairs [][2]string, redacted []string) {
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		if !snippetSkippedHeaders[strings.ToLower(name)] {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	pairs = make([][2]string, 0, len(names))
+	for _, name := range names {
+		value := headers[name]
+		if marker, ok := redaction.KeyRedactionMarker(name); ok {
+			value = marker
+			redacted = append(redacted, name)
+		}
+		pairs = append(pairs, [2]string{name, value})
+	}
+	return pairs, redacted
+}
+
+// FormatCurl renders s as a multi-line cURL command. Values are single-quoted for POSIX shells.
+func FormatCurl(s RequestSnippet) (snippet string, redacted []string) {
+	method := strings.ToUpper(s.Method)
+	pairs, redacted := RedactSnippetHeaders(s.Headers)
+
+	lines := []string{"curl " + shellQuote(s.URL)}
+	if method != "" && method != "GET" {
+		lines = append(lines, "-X "+method)
+	}
+	for _, h := range pairs {
+		lines = append(lines, "-H "+shellQuote(h[0]+": "+h[1]))
+	}
+	if s.Body != "" {
+		lines = append(lines, "--data-raw "+shellQuote(s.Body))
+	}
+	return strings.Join(lines, " \\\n  ") + "\n", redacted
+}
+
+// FormatFetch renders s as an awaited fetch() call. A JSON body is written as a
+// JSON.stringify() of the parsed value so it stays readable and editable.
+func FormatFetch(s RequestSnippet) (snippet string, redacted []string) {
+	method := strings.ToUpper(s.Method)
+	if method == "" {
+		method = "GET"
+	}
+	pairs, redacted := RedactSnippetHeaders(s.Headers)
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "const response = await fetch(%s, {\n", jsString(s.URL))
+	fmt.Fprintf(&sb, "  method: %s", jsString(method))
+	if len(pairs) > 0 {
+		sb.WriteString(",\n  headers: {\n")
+		for i, h := range pairs {
+			fmt.Fprintf(&sb, "    %s: %s", jsString(h[0]), jsString(h[1]))
+			if i < len(pairs)-1 {
+				sb.WriteByte(',')
+			}
+			sb.WriteByte('\n')
+		}
+		sb.WriteString("  }")
+	}
+	if s.Body != "" {
+		sb.WriteString(",\n  body: " + jsBody(s.Body))
+	}
+	sb.WriteString("\n});\nconsole.log(response.status, await response.text());\n")
+	return sb.String(), redacted
+}
+
+// RequestSnippetExportResult describes a snippet written to disk.
+type RequestSnippetExportResult struct {
+	SavedTo       string `json:"saved_to"`
+	FileSizeBytes int64  `json:"file_size_bytes"`
+}
+
+// WriteRequestSnippetFile writes a rendered snippet to path. Paths follow the HAR export rules.
+func WriteRequestSnippetFile(snippet, path string) (RequestSnippetExportResult, error) {
+	if !isPathSafe(path) {
+		return RequestSnippetExportResult{}, fmt.Errorf("unsafe path: %s", path)
+	}
+	data := []byte(snippet)
+	if err := writeHARData(path, data); err != nil {
+		return RequestSnippetExportResult{}, err
+	}
+	return RequestSnippetExportResult{SavedTo: path, FileSizeBytes: int64(len(data))}, nil
+}
+
+// shellQuote wraps s in single quotes, closing and escaping any embedded quote.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// jsString returns s as a JavaScript string literal. JSON string syntax is valid JavaScript;
+// HTML escaping is off so & and < stay readable.
+func jsString(s string) string {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	// Error impossible: encoding a string cannot fail.
+	_ = enc.Encode(s)
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+func jsBody(body string) string {
+	trimmed := strings.TrimSpace(body)
+	if (strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "[")) && json.Valid([]byte(trimmed)) {
+		var out bytes.Buffer
+		if err := json.Indent(&out, []byte(trimmed), "  ", "  "); err == nil {
+			return "JSON.stringify(" + out.String() + ")"
+		}
+	}
+	return jsString(body)
+}
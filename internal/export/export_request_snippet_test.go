@@ -0,0 +1,74 @@
+// Purpose: Unit tests for cURL and fetch() snippet rendering, quoting, and header redaction.
+// Docs: docs/features/feature/request-snippets/index.md
+
+package export
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFormatCurl(t *testing.T) {
+	t.Parallel()
+
+	snippet, redacted := FormatCurl(RequestSnippet{
+		Method:  "post",
+		URL:     "https://api.test/orders?q=it's",
+		Headers: map[string]string{"Content-Type": "application/json", "Authorization": "Bearer abc", "Content-Length": "12"},
+		Body:    `{"note":"it's"}`,
+	})
+
+	want := "curl 'https://api.test/orders?q=it'\\''s' \\\n" +
+		"  -X POST \\\n" +
+		"  -H 'Authorization: [REDACTED:key-authorization]' \\\n" +
+		"  -H 'Content-Type: application/json' \\\n" +
+		"  --data-raw '{\"note\":\"it'\\''s\"}'\n"
+	if snippet != want {
+		t.Errorf("snippet =\n%s\nwant\n%s", snippet, want)
+	}
+	if !reflect.DeepEqual(redacted, []string{"Authorization"}) {
+		t.Errorf("redacted = %v", redacted)
+	}
+
+	get, _ := FormatCurl(RequestSnippet{Method: "GET", URL: "https://api.test/items"})
+	if get != "curl 'https://api.test/items'\n" {
+		t.Errorf("GET snippet = %q", get)
+	}
+}
+
+func TestFormatFetch(t *testing.T) {
+	t.Parallel()
+
+	snippet, redacted := FormatFetch(RequestSnippet{
+		Method:  "PUT",
+		URL:     "https://api.test/items/1",
+		Headers: map[string]string{"Cookie": "sid=1", "X-Client": "web"},
+		Body:    `{"tags":["a"]}`,
+	})
+
+	want := `const response = await fetch("https://api.test/items/1", {
+  method: "PUT",
+  headers: {
+    "Cookie": "[REDACTED:key-cookie]",
+    "X-Client": "web"
+  },
+  body: JSON.stringify({
+    "tags": [
+      "a"
+    ]
+  })
+});
+console.log(response.status, await response.text());
+`
+	if snippet != want {
+		t.Errorf("snippet =\n%s\nwant\n%s", snippet, want)
+	}
+	if !reflect.DeepEqual(redacted, []string{"Cookie"}) {
+		t.Errorf("redacted = %v", redacted)
+	}
+
+	text, _ := FormatFetch(RequestSnippet{Method: "POST", URL: "/form", Body: "a=1&b=\"2\""})
+	if want := "const response = await fetch(\"/form\", {\n  method: \"POST\",\n  body: \"a=1&b=\\\"2\\\"\"\n});\nconsole.log(response.status, await response.text());\n"; text != want {
+		t.Errorf("text body snippet = %q", text)
+	}
+}
@@ -0,0 +1,28 @@
+// Purpose: Package extreplay — records extension command traffic to JSONL and replays it as a simulated extension.
+// Why: Tool handlers that wait on the extension can only be exercised end to end with a browser; a recording removes that need.
+// Docs: docs/features/feature/protocol-replay/index.md
+
+/*
+Package extreplay records the daemon side of the extension protocol and plays it
+back. With --record-protocol, every command the daemon delivers to the extension
+(over /sync or /extension-ws), every result the extension posts back (in /sync or
+to /query-result), and every change in extension settings is appended to a JSONL
+file. With --replay, a Driver stands in for the extension: it heartbeats with the
+recorded settings and answers each queued command with the recorded result of a
+matching command.
+
+Matching, in order:
+  - an unused exchange with the same type and the same params (compared as canonical JSON);
+  - an unused exchange with the same type;
+  - the last exchange served for the same type and params, so repeated tool calls keep working.
+
+A command with no match gets an error result naming its type, so the tool call
+fails fast instead of timing out.
+
+Key types:
+  - Recorder: a capture.ProtocolRecorder that appends Entry lines to a file.
+  - Script: a loaded recording; Match pairs a live command with a recorded exchange.
+  - Driver: the simulated extension loop; it answers through a Responder, which
+    *Script implements and the mock browser (internal/mockbrowser) also implements.
+*/
+package extreplay
@@ -0,0 +1,109 @@
+// Purpose: Simulates the extension side of the sync protocol, answering queued commands through a Responder.
+// Why: Lets tool handlers that wait on the extension run end to end headlessly, in tests and local development.
+// Docs: docs/features/feature/protocol-replay/index.md
+
+package extreplay
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+)
+
+const (
+	// ClientID is the client header value real extensions send.
+	ClientID = "kaboom-extension"
+	// userAgent identifies simulated heartbeats in connection logs.
+	userAgent = "kaboom-simulated-extension"
+	// idleWait is the longest gap between heartbeats; well under the disconnect threshold.
+	idleWait = time.Second
+	// minHeartbeatGap stops a command that is never dequeued from spinning the loop.
+	minHeartbeatGap = 50 * time.Millisecond
+)
+
+// Extension is the daemon surface the driver talks to; *capture.Store satisfies it.
+type Extension interface {
+	ProcessSyncMessage(req capture.SyncRequest, clientID, userAgent string) capture.SyncResponse
+	WaitForPendingQueries(timeout time.Duration)
+}
+
+// Responder answers commands delivered to a simulated extension. *Script replays a
+// recording; the mock browser answers from a fixture page.
+type Responder interface {
+	// Respond returns the result for cmd. An error becomes a status "error" result.
+	Respond(cmd capture.SyncCommand) (capture.SyncCommandResult, error)
+}
+
+// Driver heartbeats like a connected extension reporting settings, and answers each
+// delivered command through its Responder on the next heartbeat.
+type Driver struct {
+	ext              Extension
+	responder        Responder
+	settings         *capture.SyncSettings
+	extensionVersion string
+	sessionID        string
+
+	// Logf, when set, reports commands the responder cannot answer.
+	Logf func(format string, args ...any)
+}
+
+// NewDriver returns a driver answering ext's commands through responder.
+func NewDriver(ext Extension, responder Responder, settings *capture.SyncSettings, extensionVersion string) *Driver {
+	return &Driver{
+		ext:              ext,
+		responder:        responder,
+		settings:         settings,
+		extensionVersion: extensionVersion,
+		sessionID:        fmt.Sprintf("simulated-%d", time.Now().UnixNano()),
+	}
+}
+
+// Run heartbeats until ctx is done.
+func (d *Driver) Run(ctx context.Context) {
+	var results []capture.SyncCommandResult
+	var lastAck string
+	for ctx.Err() == nil {
+		started := time.Now()
+		// InProgress stays nil: answers arrive on the next heartbeat, so nothing is ever
+		// running extension-side for the daemon to reconcile.
+		resp := d.ext.ProcessSyncMessage(capture.SyncRequest{
+			ExtSessionID:     d.sessionID,
+			ExtensionVersion: d.extensionVersion,
+			Settings:         d.settings,
+			LastCommandAck:   lastAck,
+			CommandResults:   results,
+		}, ClientID, userAgent)
+
+		results, lastAck = nil, ""
+		for _, cmd := range resp.Commands {
+			results = append(results, d.answer(cmd))
+			lastAck = cmd.ID
+		}
+		if len(results) > 0 {
+			continue
+		}
+		d.ext.WaitForPendingQueries(idleWait)
+		if gap := minHeartbeatGap - time.Since(started); gap > 0 {
+			select {
+			case <-ctx.Done():
+			case <-time.After(gap):
+			}
+		}
+	}
+}
+
+// answer returns the responder's result for cmd, addressed to cmd, or an error result.
+func (d *Driver) answer(cmd capture.SyncCommand) capture.SyncCommandResult {
+	result, err := d.responder.Respond(cmd)
+	if err != nil {
+		if d.Logf != nil {
+			d.Logf("%v (command %s)", err, cmd.ID)
+		}
+		// Same shape the extension sends when a command throws.
+		result = capture.SyncCommandResult{Status: "error", Error: err.Error()}
+	}
+	result.ID, result.CorrelationID = cmd.ID, cmd.CorrelationID
+	return result
+}
@@ -0,0 +1,146 @@
+// Purpose: Tests recording extension traffic through capture, matching recorded exchanges, and replaying them headlessly.
+// Docs: docs/features/feature/protocol-replay/index.md
+
+package extreplay
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/queries"
+)
+
+func TestRecordThenReplay(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "protocol.jsonl")
+	rec, err := NewRecorder(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Record: a real extension picks up a dom query and answers it on its next heartbeat.
+	live := capture.NewCapture()
+	defer live.Close()
+	live.SetProtocolRecorder(rec)
+	settings := &capture.SyncSettings{TrackingEnabled: true, TrackedTabID: 7, TrackedTabURL: "http://localhost:5173/"}
+	queryID, err := live.CreatePendingQuery(queries.PendingQuery{Type: "dom", Params: json.RawMessage(`{"selector":"#cart","action":"query"}`)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp := live.ProcessSyncMessage(capture.SyncRequest{ExtSessionID: "s1", ExtensionVersion: "0.7.12", Settings: settings}, ClientID, "test")
+	if len(resp.Commands) != 1 || resp.Commands[0].ID != queryID {
+		t.Fatalf("commands = %+v, want %s", resp.Commands, queryID)
+	}
+	live.ProcessSyncMessage(capture.SyncRequest{
+		ExtSessionID: "s1", ExtensionVersion: "0.7.12", Settings: settings, LastCommandAck: queryID,
+		CommandResults: []capture.SyncCommandResult{{ID: queryID, Status: "complete", Result: json.RawMessage(`{"matches":1}`)}},
+	}, ClientID, "test")
+	if err := rec.Close(); err != nil || rec.Err() != nil {
+		t.Fatalf("close = %v, write err = %v", err, rec.Err())
+	}
+	raw, _ := os.ReadFile(path)
+	if lines := strings.Count(string(raw), "\n"); lines != 3 {
+		t.Fatalf("recording has %d lines, want settings+command+result:\n%s", lines, raw)
+	}
+
+	script, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if script.Len() != 1 || script.Settings == nil || script.Settings.TrackedTabID != 7 || script.ExtensionVersion != "0.7.12" {
+		t.Fatalf("script = %d exchanges, settings %+v, version %q", script.Len(), script.Settings, script.ExtensionVersion)
+	}
+
+	// Replay: the driver answers the same query on a fresh daemon with no browser.
+	replay := capture.NewCapture()
+	defer replay.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	driver := NewDriver(replay, script, script.Settings, script.ExtensionVersion)
+	go driver.Run(ctx)
+
+	id, _ := replay.CreatePendingQuery(queries.PendingQuery{Type: "dom", Params: json.RawMessage(`{"action":"query","selector":"#cart"}`)})
+	result, err := replay.WaitForResult(id, 3*time.Second)
+	if err != nil || string(result) != `{"matches":1}` {
+		t.Fatalf("replayed result = %s (err %v)", result, err)
+	}
+	if enabled, tabID, _ := replay.GetTrackingStatus(); !enabled || tabID != 7 {
+		t.Fatalf("tracking = %v/%d, want the recorded tab", enabled, tabID)
+	}
+
+	// A command the recording never saw fails fast instead of timing out.
+	if _, err := replay.CreatePendingQuery(queries.PendingQuery{Type: "screenshot", Params: json.RawMessage(`{}`), CorrelationID: "corr-shot"}); err != nil {
+		t.Fatal(err)
+	}
+	deadline := time.Now().Add(3 * time.Second)
+	for {
+		cmd, ok := replay.GetCommandResult("corr-shot")
+		if ok && cmd.Status == "error" {
+			if !strings.Contains(cmd.Error, "no screenshot command") {
+				t.Fatalf("error = %q", cmd.Error)
+			}
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("unmatched command not failed: %+v", cmd)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+}
+
+func TestScriptMatchOrder(t *testing.T) {
+	t.Parallel()
+	recording := strings.Join([]string{
+		`{"kind":"command","command":{"id":"q-1","type":"dom","params":{"selector":"#a"}}}`,
+		`{"kind":"command","command":{"id":"q-2","type":"dom","params":{"selector":"#b"}}}`,
+		`{"kind":"command","command":{"id":"q-3","type":"execute","params":{},"correlation_id":"c-3"}}`,
+		`{"kind":"command","command":{"id":"q-4","type":"dom","params":{"selector":"#never"}}}`,
+		`{"kind":"result","result":{"id":"q-2","status":"complete","result":{"el":"b"}}}`,
+		`{"kind":"result","result":{"id":"q-1","status":"complete","result":{"el":"a"}}}`,
+		`{"kind":"result","result":{"correlation_id":"c-3","status":"complete","result":{"value":3}}}`,
+		``,
+	}, "\n")
+	script, err := Parse(strings.NewReader(recording))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if script.Len() != 3 || script.Unanswered != 1 {
+		t.Fatalf("exchanges = %d, unanswered = %d; want 3 and 1", script.Len(), script.Unanswered)
+	}
+
+	match := func(typ, params string) string {
+		t.Helper()
+		res, ok := script.Match(capture.SyncCommand{ID: "live", Type: typ, Params: json.RawMessage(params), CorrelationID: "live-corr"})
+		if !ok {
+			return ""
+		}
+		if res.ID != "live" || res.CorrelationID != "live-corr" {
+			t.Fatalf("result not rewritten to the live command: %+v", res)
+		}
+		return string(res.Result)
+	}
+	if got := match("dom", `{"selector":"#b"}`); got != `{"el":"b"}` {
+		t.Errorf("exact params: got %s", got)
+	}
+	if got := match("dom", `{"selector":"#z"}`); got != `{"el":"a"}` {
+		t.Errorf("same type, unused: got %s", got)
+	}
+	if got := match("dom", `{"selector":"#b"}`); got != `{"el":"b"}` {
+		t.Errorf("repeat reuses last served: got %s", got)
+	}
+	if got := match("execute", `{}`); got != `{"value":3}` {
+		t.Errorf("correlated result: got %s", got)
+	}
+	if got := match("dom", `{"selector":"#y"}`); got != "" {
+		t.Errorf("exhausted type with new params should not match, got %s", got)
+	}
+	if _, err := Parse(strings.NewReader(`{"kind":"bogus"}`)); err == nil {
+		t.Error("unknown entry kind should be rejected")
+	}
+}
@@ -0,0 +1,149 @@
+// Purpose: Appends extension protocol traffic (settings, commands, results) to a JSONL recording.
+// Why: A recording of real extension exchanges is the input --replay needs to stand in for the browser.
+// Docs: docs/features/feature/protocol-replay/index.md
+
+package extreplay
+
+import (
+	"encoding/json"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+)
+
+// Entry kinds.
+const (
+	KindSettings = "settings"
+	KindCommand  = "command"
+	KindResult   = "result"
+)
+
+// maxSeenKeys bounds the dedupe set; the oldest keys are forgotten first.
+const maxSeenKeys = 4096
+
+// Entry is one line of a recording. Exactly one of Settings, Command, or Result is set, per Kind.
+type Entry struct {
+	Kind             string                     `json:"kind"`
+	At               time.Time                  `json:"at"`
+	Settings         *capture.SyncSettings      `json:"settings,omitempty"`
+	ExtensionVersion string                     `json:"extension_version,omitempty"`
+	Command          *capture.SyncCommand       `json:"command,omitempty"`
+	Result           *capture.SyncCommandResult `json:"result,omitempty"`
+}
+
+// Recorder is a capture.ProtocolRecorder that appends Entry lines to a file. Commands
+// redelivered while awaiting acknowledgment, results posted twice, and unchanged
+// settings are written once.
+type Recorder struct {
+	mu        sync.Mutex
+	f         *os.File
+	enc       *json.Encoder
+	seen      map[string]bool
+	seenOrder []string
+	settings  *capture.SyncSettings
+	version   string
+	err       error
+}
+
+var _ capture.ProtocolRecorder = (*Recorder)(nil)
+
+// NewRecorder opens path for appending, creating it if needed. The file holds raw
+// page data returned by the extension, so it is created owner-only.
+func NewRecorder(path string) (*Recorder, error) {
+	// #nosec G304 -- operator-supplied --record-protocol path
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	return &Recorder{f: f, enc: json.NewEncoder(f), seen: make(map[string]bool)}, nil
+}
+
+// RecordSettings writes settings when they differ from the last recorded settings.
+func (r *Recorder) RecordSettings(settings capture.SyncSettings, extensionVersion string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.settings != nil && settingsEqual(*r.settings, settings) && r.version == extensionVersion {
+		return
+	}
+	r.settings, r.version = &settings, extensionVersion
+	r.writeLocked(Entry{Kind: KindSettings, Settings: &settings, ExtensionVersion: extensionVersion})
+}
+
+// RecordCommands writes each command the first time its ID is delivered.
+func (r *Recorder) RecordCommands(commands []capture.SyncCommand) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i := range commands {
+		cmd := commands[i]
+		if r.markSeenLocked("command|" + cmd.ID) {
+			r.writeLocked(Entry{Kind: KindCommand, Command: &cmd})
+		}
+	}
+}
+
+// RecordResults writes each result once per ID, correlation ID, and status.
+func (r *Recorder) RecordResults(results []capture.SyncCommandResult) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i := range results {
+		res := results[i]
+		if r.markSeenLocked("result|" + res.ID + "|" + res.CorrelationID + "|" + res.Status) {
+			r.writeLocked(Entry{Kind: KindResult, Result: &res})
+		}
+	}
+}
+
+// Err returns the first write error. Recording stops after it.
+func (r *Recorder) Err() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.err
+}
+
+// Close closes the file; later traffic is not recorded.
+func (r *Recorder) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.f == nil {
+		return nil
+	}
+	err := r.f.Close()
+	r.f = nil
+	return err
+}
+
+func (r *Recorder) writeLocked(e Entry) {
+	if r.err != nil || r.f == nil {
+		return
+	}
+	e.At = time.Now().UTC()
+	r.err = r.enc.Encode(e)
+}
+
+// markSeenLocked reports whether key is new, remembering it.
+func (r *Recorder) markSeenLocked(key string) bool {
+	if r.seen[key] {
+		return false
+	}
+	r.seen[key] = true
+	r.seenOrder = append(r.seenOrder, key)
+	for len(r.seenOrder) > maxSeenKeys {
+		delete(r.seen, r.seenOrder[0])
+		r.seenOrder = r.seenOrder[1:]
+	}
+	return true
+}
+
+func settingsEqual(a, b capture.SyncSettings) bool {
+	aActive, bActive := a.TrackedTabActive, b.TrackedTabActive
+	a.TrackedTabActive, b.TrackedTabActive = nil, nil
+	if a != b {
+		return false
+	}
+	if aActive == nil || bActive == nil {
+		return aActive == bActive
+	}
+	return *aActive == *bActive
+}
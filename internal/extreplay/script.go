@@ -0,0 +1,197 @@
+// Purpose: Loads a protocol recording and matches live commands to recorded exchanges.
+// Why: Replay must answer commands whose IDs differ from the recording, so exchanges are matched by type and params.
+// Docs: docs/features/feature/protocol-replay/index.md
+
+package extreplay
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+)
+
+// maxEntryBytes bounds one recording line; screenshot results are the largest.
+const maxEntryBytes = 32 << 20
+
+// Exchange is a recorded command and the result the extension returned for it.
+type Exchange struct {
+	Command capture.SyncCommand
+	Result  capture.SyncCommandResult
+
+	params string // canonical params
+}
+
+// Script is a loaded recording. Match is safe for concurrent use.
+type Script struct {
+	// Settings are the last recorded extension settings, nil if none were recorded.
+	Settings *capture.SyncSettings
+	// ExtensionVersion is the version reported with Settings.
+	ExtensionVersion string
+	// Unanswered counts recorded commands that never got a result; they are not replayed.
+	Unanswered int
+
+	mu        sync.Mutex
+	exchanges []Exchange
+	used      []bool
+	lastByKey map[string]int
+}
+
+// Load reads a recording file.
+func Load(path string) (*Script, error) {
+	// #nosec G304 -- operator-supplied --replay path
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close() //nolint:errcheck // read-only file
+	return Parse(f)
+}
+
+// Parse reads a recording. Each command is paired with the result carrying its ID,
+// or its correlation ID for async commands.
+func Parse(r io.Reader) (*Script, error) {
+	s := &Script{lastByKey: make(map[string]int)}
+	var pending []capture.SyncCommand
+	answered := make(map[string]capture.SyncCommandResult)
+
+	reader := bufio.NewReaderSize(r, 64<<10)
+	for lineNo := 1; ; lineNo++ {
+		line, err := readLine(reader)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNo, err)
+		}
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+		var e Entry
+		if err := json.Unmarshal(line, &e); err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNo, err)
+		}
+		switch {
+		case e.Kind == KindSettings && e.Settings != nil:
+			s.Settings, s.ExtensionVersion = e.Settings, e.ExtensionVersion
+		case e.Kind == KindCommand && e.Command != nil:
+			pending = append(pending, *e.Command)
+		case e.Kind == KindResult && e.Result != nil:
+			// Correlated commands may report a non-terminal status before the final one;
+			// the last result wins.
+			if e.Result.ID != "" {
+				answered["id|"+e.Result.ID] = *e.Result
+			}
+			if e.Result.CorrelationID != "" {
+				answered["corr|"+e.Result.CorrelationID] = *e.Result
+			}
+		default:
+			return nil, fmt.Errorf("line %d: unknown entry kind %q", lineNo, e.Kind)
+		}
+	}
+
+	for _, cmd := range pending {
+		result, ok := answered["id|"+cmd.ID]
+		if !ok && cmd.CorrelationID != "" {
+			result, ok = answered["corr|"+cmd.CorrelationID]
+		}
+		if !ok {
+			s.Unanswered++
+			continue
+		}
+		s.exchanges = append(s.exchanges, Exchange{Command: cmd, Result: result, params: canonicalParams(cmd.Params)})
+	}
+	s.used = make([]bool, len(s.exchanges))
+	return s, nil
+}
+
+// Len returns the number of replayable exchanges.
+func (s *Script) Len() int {
+	return len(s.exchanges)
+}
+
+// Match returns the recorded result for cmd, rewritten to cmd's ID and correlation ID.
+// See the package doc for the matching order.
+func (s *Script) Match(cmd capture.SyncCommand) (capture.SyncCommandResult, bool) {
+	params := canonicalParams(cmd.Params)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	idx := -1
+	for i, ex := range s.exchanges {
+		if !s.used[i] && ex.Command.Type == cmd.Type && ex.params == params {
+			idx = i
+			break
+		}
+	}
+	if idx < 0 {
+		for i, ex := range s.exchanges {
+			if !s.used[i] && ex.Command.Type == cmd.Type {
+				idx = i
+				break
+			}
+		}
+	}
+	key := cmd.Type + "|" + params
+	if idx < 0 {
+		last, ok := s.lastByKey[key]
+		if !ok {
+			return capture.SyncCommandResult{}, false
+		}
+		idx = last
+	}
+	s.used[idx] = true
+	s.lastByKey[key] = idx
+
+	result := s.exchanges[idx].Result
+	result.ID, result.CorrelationID = cmd.ID, cmd.CorrelationID
+	return result, true
+}
+
+// Respond implements Responder: the matched result, or an error naming the unmatched type.
+func (s *Script) Respond(cmd capture.SyncCommand) (capture.SyncCommandResult, error) {
+	if result, ok := s.Match(cmd); ok {
+		return result, nil
+	}
+	return capture.SyncCommandResult{}, fmt.Errorf("replay: the recording has no %s command", cmd.Type)
+}
+
+// canonicalParams re-encodes params so key order and whitespace do not affect matching.
+func canonicalParams(raw json.RawMessage) string {
+	var v any
+	if len(raw) == 0 || json.Unmarshal(raw, &v) != nil {
+		return string(bytes.TrimSpace(raw))
+	}
+	out, err := json.Marshal(v)
+	if err != nil {
+		return string(raw)
+	}
+	return string(out)
+}
+
+// readLine returns the next line without its newline, failing on lines over maxEntryBytes.
+func readLine(r *bufio.Reader) ([]byte, error) {
+	var line []byte
+	for {
+		chunk, isPrefix, err := r.ReadLine()
+		if err != nil {
+			if errors.Is(err, io.EOF) && len(line) > 0 {
+				return line, nil
+			}
+			return nil, err
+		}
+		line = append(line, chunk...)
+		if len(line) > maxEntryBytes {
+			return nil, fmt.Errorf("entry exceeds %d bytes", maxEntryBytes)
+		}
+		if !isPrefix {
+			return line, nil
+		}
+	}
+}
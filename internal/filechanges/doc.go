@@ -0,0 +1,20 @@
+// Purpose: Package filechanges — records source edits and correlates them with what the page did next.
+// Why: After saving a file an agent needs to know whether the page reloaded and whether the edit fixed or broke it.
+// Docs: docs/features/feature/watch-mode/index.md
+
+/*
+Package filechanges records file-change notifications (from `kaboom watch` or an
+editor hook POSTing to /file-changes) as edits, and correlates each edit with the
+reloads, console errors, and page-load vitals that followed it.
+
+Key types:
+  - Edit: one batch of changed files, plus the page vitals in effect when it arrived.
+  - Store: bounded edit history with its own lock (a leaf lock).
+  - Observations: the browser-side signals an edit is judged against.
+  - Impact: what followed one edit, with a verdict (broke, fixed, no_change, ...).
+
+Key functions:
+  - Parse: decodes {"changes": [...]}, an array of changes, or {"paths": [...]}.
+  - Correlate: computes an Impact for each edit from Observations.
+*/
+package filechanges
@@ -0,0 +1,179 @@
+// Purpose: Decodes and normalizes file-change notifications posted by watchers and editor hooks.
+// Why: fs watchers report create/write/rename/remove differently; one shape keeps correlation simple.
+// Docs: docs/features/feature/watch-mode/index.md
+
+package filechanges
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/performance"
+)
+
+// Change operations after normalization.
+const (
+	OpCreated  = "created"
+	OpModified = "modified"
+	OpDeleted  = "deleted"
+)
+
+// Payload limits.
+const (
+	MaxBatch      = 500
+	maxPathLength = 1024
+)
+
+// Change is one changed file.
+type Change struct {
+	Path string `json:"path"`
+	Op   string `json:"op"`
+}
+
+// Edit is one batch of file changes, usually one save or one watcher tick.
+type Edit struct {
+	ID        string    `json:"id"`
+	Timestamp time.Time `json:"timestamp"`
+	Source    string    `json:"source,omitempty"` // "watch" (kaboom watch) or the poster's own label
+	Root      string    `json:"root,omitempty"`   // watched directory; paths are relative to it
+	Changes   []Change  `json:"changes"`
+
+	baseline []Vitals // page vitals in effect when the edit arrived
+}
+
+// Files returns the changed paths in order.
+func (e Edit) Files() []string {
+	files := make([]string, len(e.Changes))
+	for i, c := range e.Changes {
+		files[i] = c.Path
+	}
+	return files
+}
+
+// Vitals is the subset of a page-load performance snapshot compared across edits.
+type Vitals struct {
+	URL       string    `json:"url"`
+	Timestamp time.Time `json:"timestamp"`
+	LCP       *float64  `json:"lcp,omitempty"`
+	FCP       *float64  `json:"fcp,omitempty"`
+	INP       *float64  `json:"inp,omitempty"`
+	CLS       *float64  `json:"cls,omitempty"`
+	Load      float64   `json:"load,omitempty"`
+}
+
+// VitalsFrom extracts Vitals from captured performance snapshots, skipping ones
+// without a parseable timestamp.
+func VitalsFrom(snapshots []performance.PerformanceSnapshot) []Vitals {
+	out := make([]Vitals, 0, len(snapshots))
+	for _, s := range snapshots {
+		ts, err := time.Parse(time.RFC3339Nano, s.Timestamp)
+		if err != nil {
+			continue
+		}
+		out = append(out, Vitals{
+			URL:       s.URL,
+			Timestamp: ts,
+			LCP:       s.Timing.LargestContentfulPaint,
+			FCP:       s.Timing.FirstContentfulPaint,
+			INP:       s.Timing.InteractionToNextPaint,
+			CLS:       s.CLS,
+			Load:      s.Timing.Load,
+		})
+	}
+	return out
+}
+
+// wireEdit accepts timestamps as RFC3339 strings or Unix milliseconds, and bare path lists.
+type wireEdit struct {
+	Source    string          `json:"source"`
+	Root      string          `json:"root"`
+	Changes   []Change        `json:"changes"`
+	Paths     []string        `json:"paths"`
+	Timestamp json.RawMessage `json:"timestamp"`
+}
+
+// Parse decodes {"changes": [...]} (with optional source, root, timestamp), a bare
+// array of changes, or {"paths": [...]}. A batch without a timestamp is stamped with now.
+func Parse(raw []byte, now time.Time) (Edit, error) {
+	raw = bytes.TrimSpace(raw)
+	var wire wireEdit
+	switch {
+	case len(raw) == 0:
+		return Edit{}, errors.New("empty body")
+	case raw[0] == '[':
+		if err := json.Unmarshal(raw, &wire.Changes); err != nil {
+			return Edit{}, fmt.Errorf("invalid JSON: %w", err)
+		}
+	default:
+		if err := json.Unmarshal(raw, &wire); err != nil {
+			return Edit{}, fmt.Errorf("invalid JSON: %w", err)
+		}
+	}
+	for _, p := range wire.Paths {
+		wire.Changes = append(wire.Changes, Change{Path: p})
+	}
+	if len(wire.Changes) == 0 {
+		return Edit{}, errors.New("no changes (send changes or paths)")
+	}
+	if len(wire.Changes) > MaxBatch {
+		return Edit{}, fmt.Errorf("batch of %d changes exceeds limit of %d", len(wire.Changes), MaxBatch)
+	}
+
+	ts, err := parseTimestamp(wire.Timestamp, now)
+	if err != nil {
+		return Edit{}, err
+	}
+	edit := Edit{
+		Timestamp: ts,
+		Source:    strings.TrimSpace(wire.Source),
+		Root:      strings.TrimSpace(wire.Root),
+		Changes:   make([]Change, 0, len(wire.Changes)),
+	}
+	for i, c := range wire.Changes {
+		c.Path = strings.TrimSpace(c.Path)
+		if c.Path == "" {
+			return Edit{}, fmt.Errorf("change %d: path is required", i)
+		}
+		if len(c.Path) > maxPathLength {
+			return Edit{}, fmt.Errorf("change %d: path exceeds %d bytes", i, maxPathLength)
+		}
+		c.Op = normalizeOp(c.Op)
+		edit.Changes = append(edit.Changes, c)
+	}
+	return edit, nil
+}
+
+func parseTimestamp(raw json.RawMessage, now time.Time) (time.Time, error) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return now, nil
+	}
+	var ms int64
+	if err := json.Unmarshal(raw, &ms); err == nil {
+		return time.UnixMilli(ms), nil
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err != nil {
+		return time.Time{}, errors.New("timestamp must be RFC3339 or Unix milliseconds")
+	}
+	ts, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("timestamp: %w", err)
+	}
+	return ts, nil
+}
+
+// normalizeOp maps fsnotify/chokidar/watchman operation names onto the canonical ops.
+func normalizeOp(op string) string {
+	switch strings.ToLower(strings.TrimSpace(op)) {
+	case "create", "created", "add", "added", "new":
+		return OpCreated
+	case "remove", "removed", "delete", "deleted", "unlink", "rename", "renamed":
+		return OpDeleted
+	default:
+		return OpModified
+	}
+}
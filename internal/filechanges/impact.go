@@ -0,0 +1,210 @@
+// Purpose: Correlates each edit with the reloads, console errors, and vitals changes that followed it.
+// Why: "Did my last edit fix it?" is answered by comparing the page before and after the reload the edit caused.
+// Docs: docs/features/feature/watch-mode/index.md
+
+package filechanges
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Verdicts for an edit.
+const (
+	VerdictAwaitingReload  = "awaiting_reload"  // no reload, rebuild, or error seen since the edit
+	VerdictBroke           = "broke"            // errors appeared that were not present before the edit
+	VerdictFixed           = "fixed"            // errors present before the edit did not recur after the reload
+	VerdictVitalsRegressed = "vitals_regressed" // no error change, but a page-load metric got markedly worse
+	VerdictNoChange        = "no_change"        // the page reloaded and nothing measurable changed
+)
+
+const (
+	// baselineLookback bounds how far before the first edit errors count as "before".
+	baselineLookback = 5 * time.Minute
+	// vitalsRegressionPct is the worsening that turns an otherwise clean edit into vitals_regressed.
+	vitalsRegressionPct = 20.0
+	// maxImpactMessages bounds new/resolved error lists per edit.
+	maxImpactMessages = 10
+	maxMessageLength  = 200
+)
+
+// Signal is one timestamped browser-side observation (an error message or a reload cause).
+type Signal struct {
+	Timestamp time.Time
+	Summary   string
+}
+
+// Observations are the browser-side signals edits are judged against.
+type Observations struct {
+	Errors  []Signal // console/page errors
+	Reloads []Signal // navigations, page loads, and finished dev-server rebuilds
+	Vitals  []Vitals // latest page load per URL
+}
+
+// VitalsChange compares one metric of one page before and after an edit.
+type VitalsChange struct {
+	URL      string  `json:"url"`
+	Metric   string  `json:"metric"`
+	Before   float64 `json:"before"`
+	After    float64 `json:"after"`
+	DeltaPct float64 `json:"delta_pct"`
+}
+
+// Impact is what followed one edit, up to the next edit or now.
+type Impact struct {
+	EditID         string         `json:"edit_id"`
+	Timestamp      time.Time      `json:"timestamp"`
+	Files          []string       `json:"files"`
+	Verdict        string         `json:"verdict"`
+	Reloads        int            `json:"reloads"`
+	FirstReload    string         `json:"first_reload,omitempty"` // what reloaded the page first, e.g. "hmr_update [vite]"
+	ReloadAfterMs  int64          `json:"reload_after_ms,omitempty"`
+	ErrorsAfter    int            `json:"errors_after"`
+	NewErrors      []string       `json:"new_errors,omitempty"`
+	ResolvedErrors []string       `json:"resolved_errors,omitempty"`
+	Vitals         []VitalsChange `json:"vitals,omitempty"`
+	Superseded     bool           `json:"superseded,omitempty"` // a later edit closed this edit's window
+}
+
+// Correlate computes an Impact for each edit (edits ordered oldest first). Each edit's
+// window runs until the next edit; "before" errors are those since the previous edit.
+func Correlate(edits []Edit, obs Observations, now time.Time) []Impact {
+	impacts := make([]Impact, 0, len(edits))
+	for i, edit := range edits {
+		start := edit.Timestamp.Add(-baselineLookback)
+		if i > 0 {
+			start = edits[i-1].Timestamp
+		}
+		end := now
+		if i+1 < len(edits) {
+			end = edits[i+1].Timestamp
+		}
+		impact := correlateOne(edit, obs, start, end)
+		impact.Superseded = i+1 < len(edits)
+		impacts = append(impacts, impact)
+	}
+	return impacts
+}
+
+func correlateOne(edit Edit, obs Observations, start, end time.Time) Impact {
+	impact := Impact{EditID: edit.ID, Timestamp: edit.Timestamp, Files: edit.Files()}
+	inWindow := func(t time.Time) bool { return !t.Before(edit.Timestamp) && t.Before(end) }
+
+	var reloadAt time.Time
+	for _, r := range obs.Reloads {
+		if !inWindow(r.Timestamp) {
+			continue
+		}
+		impact.Reloads++
+		if reloadAt.IsZero() || r.Timestamp.Before(reloadAt) {
+			reloadAt = r.Timestamp
+			impact.FirstReload = r.Summary
+		}
+	}
+	if !reloadAt.IsZero() {
+		impact.ReloadAfterMs = reloadAt.Sub(edit.Timestamp).Milliseconds()
+	}
+
+	before := make(map[string]bool)
+	after := make(map[string]bool)
+	afterReload := make(map[string]bool)
+	for _, e := range obs.Errors {
+		msg := errorKey(e.Summary)
+		switch {
+		case !e.Timestamp.Before(start) && e.Timestamp.Before(edit.Timestamp):
+			before[msg] = true
+		case inWindow(e.Timestamp):
+			impact.ErrorsAfter++
+			after[msg] = true
+			if !reloadAt.IsZero() && !e.Timestamp.Before(reloadAt) {
+				afterReload[msg] = true
+			}
+		}
+	}
+	for msg := range after {
+		if !before[msg] {
+			impact.NewErrors = append(impact.NewErrors, msg)
+		}
+	}
+	// Resolution needs a reload: until then the old bundle is still running.
+	if !reloadAt.IsZero() {
+		for msg := range before {
+			if !afterReload[msg] {
+				impact.ResolvedErrors = append(impact.ResolvedErrors, msg)
+			}
+		}
+	}
+	impact.NewErrors = firstSorted(impact.NewErrors, maxImpactMessages)
+	impact.ResolvedErrors = firstSorted(impact.ResolvedErrors, maxImpactMessages)
+
+	impact.Vitals = compareVitals(edit.baseline, obs.Vitals, inWindow)
+	impact.Verdict = verdict(impact)
+	return impact
+}
+
+func verdict(impact Impact) string {
+	switch {
+	case len(impact.NewErrors) > 0:
+		return VerdictBroke
+	case len(impact.ResolvedErrors) > 0:
+		return VerdictFixed
+	case impact.Reloads == 0 && impact.ErrorsAfter == 0:
+		return VerdictAwaitingReload
+	}
+	for _, v := range impact.Vitals {
+		if v.DeltaPct >= vitalsRegressionPct {
+			return VerdictVitalsRegressed
+		}
+	}
+	return VerdictNoChange
+}
+
+// compareVitals pairs page loads inside the edit window with the baseline load of the same URL.
+func compareVitals(baseline, current []Vitals, inWindow func(time.Time) bool) []VitalsChange {
+	byURL := make(map[string]Vitals, len(baseline))
+	for _, v := range baseline {
+		byURL[v.URL] = v
+	}
+	var changes []VitalsChange
+	for _, cur := range current {
+		prev, ok := byURL[cur.URL]
+		if !ok || !inWindow(cur.Timestamp) || !cur.Timestamp.After(prev.Timestamp) {
+			continue
+		}
+		add := func(metric string, before, after *float64) {
+			if before == nil || after == nil || *before <= 0 {
+				return
+			}
+			delta := (*after - *before) / *before * 100
+			changes = append(changes, VitalsChange{
+				URL: cur.URL, Metric: metric, Before: *before, After: *after,
+				DeltaPct: math.Round(delta*10) / 10,
+			})
+		}
+		add("lcp", prev.LCP, cur.LCP)
+		add("fcp", prev.FCP, cur.FCP)
+		add("inp", prev.INP, cur.INP)
+		add("cls", prev.CLS, cur.CLS)
+		add("load", &prev.Load, &cur.Load)
+	}
+	return changes
+}
+
+func errorKey(msg string) string {
+	msg = strings.TrimSpace(msg)
+	if len(msg) > maxMessageLength {
+		msg = msg[:maxMessageLength] + "..."
+	}
+	return msg
+}
+
+// firstSorted sorts map-derived messages for stable output and keeps the first n.
+func firstSorted(msgs []string, n int) []string {
+	sort.Strings(msgs)
+	if len(msgs) > n {
+		msgs = msgs[:n]
+	}
+	return msgs
+}
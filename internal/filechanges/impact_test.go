@@ -0,0 +1,91 @@
+// Purpose: Tests payload parsing, edit storage, and edit-to-effect correlation verdicts.
+// Docs: docs/features/feature/watch-mode/index.md
+
+package filechanges
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParse_ShapesAndNormalization(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+
+	edit, err := Parse([]byte(`{"source":"watch","root":"/app","changes":[{"path":"src/a.ts","op":"write"},{"path":"src/b.ts","op":"unlink"}]}`), now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !edit.Timestamp.Equal(now) || edit.Source != "watch" || edit.Changes[0].Op != OpModified || edit.Changes[1].Op != OpDeleted {
+		t.Errorf("edit = %+v", edit)
+	}
+	edit, err = Parse([]byte(`{"paths":["src/c.ts"],"timestamp":1791115200000}`), now)
+	if err != nil || len(edit.Changes) != 1 || edit.Timestamp.UnixMilli() != 1791115200000 {
+		t.Errorf("paths edit = %+v, err = %v", edit, err)
+	}
+
+	for _, bad := range []string{``, `{}`, `[{"path":" "}]`, `{"paths":["a"],"timestamp":"soon"}`} {
+		if _, err := Parse([]byte(bad), now); err == nil {
+			t.Errorf("Parse(%q) should fail", bad)
+		}
+	}
+}
+
+func TestStore_AssignsIDsAndBoundsHistory(t *testing.T) {
+	t.Parallel()
+	s := NewStore()
+	base := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	for i := 0; i < MaxEdits+5; i++ {
+		s.Ingest(Edit{Timestamp: base.Add(time.Duration(i) * time.Second), Changes: []Change{{Path: "a.ts"}}}, nil)
+	}
+	edits := s.Edits(0)
+	if len(edits) != MaxEdits || edits[len(edits)-1].ID != "edit_205" {
+		t.Fatalf("kept %d edits, last %q", len(edits), edits[len(edits)-1].ID)
+	}
+	if got := s.Edits(2); len(got) != 2 || got[0].ID != "edit_204" {
+		t.Fatalf("Edits(2) = %+v", got)
+	}
+}
+
+func TestCorrelate_Verdicts(t *testing.T) {
+	t.Parallel()
+	t0 := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	at := func(sec int) time.Time { return t0.Add(time.Duration(sec) * time.Second) }
+	lcp := func(v float64) *float64 { return &v }
+
+	s := NewStore()
+	fix := s.Ingest(Edit{Timestamp: at(10), Changes: []Change{{Path: "src/a.ts"}}},
+		[]Vitals{{URL: "http://localhost:3000/", Timestamp: at(0), LCP: lcp(1000)}})
+	s.Ingest(Edit{Timestamp: at(30), Changes: []Change{{Path: "src/b.ts"}}}, nil)
+	s.Ingest(Edit{Timestamp: at(50), Changes: []Change{{Path: "src/c.ts"}}}, nil)
+
+	obs := Observations{
+		Errors: []Signal{
+			{Timestamp: at(5), Summary: "TypeError: x is undefined"},
+			{Timestamp: at(35), Summary: "ReferenceError: y is not defined"},
+		},
+		Reloads: []Signal{
+			{Timestamp: at(11), Summary: "hmr_update [vite]"},
+			{Timestamp: at(31), Summary: "full_reload [vite]"},
+		},
+		Vitals: []Vitals{{URL: "http://localhost:3000/", Timestamp: at(12), LCP: lcp(1500)}},
+	}
+	impacts := Correlate(s.Edits(0), obs, at(60))
+	if len(impacts) != 3 {
+		t.Fatalf("impacts = %d, want 3", len(impacts))
+	}
+
+	first := impacts[0]
+	if first.EditID != fix.ID || first.Verdict != VerdictFixed || first.ReloadAfterMs != 1000 || !first.Superseded {
+		t.Errorf("first = %+v, want fixed after 1s reload", first)
+	}
+	if len(first.ResolvedErrors) != 1 || len(first.Vitals) != 1 || first.Vitals[0].DeltaPct != 50 {
+		t.Errorf("first resolved/vitals = %+v / %+v", first.ResolvedErrors, first.Vitals)
+	}
+	if second := impacts[1]; second.Verdict != VerdictBroke || len(second.NewErrors) != 1 {
+		t.Errorf("second = %+v, want broke with 1 new error", second)
+	}
+	if last := impacts[2]; last.Verdict != VerdictAwaitingReload || last.Superseded {
+		t.Errorf("last = %+v, want awaiting_reload", last)
+	}
+}
@@ -0,0 +1,62 @@
+// Purpose: Stores recent edits with the page vitals that were current when each arrived.
+// Why: Only the latest load per URL is kept elsewhere, so the "before" vitals must be captured at edit time.
+// Docs: docs/features/feature/watch-mode/index.md
+
+package filechanges
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// MaxEdits bounds the retained edit history.
+const MaxEdits = 200
+
+// Store keeps bounded edit history.
+//
+// Lock hierarchy: Store.mu is a leaf lock; Store never calls out while holding it.
+type Store struct {
+	mu    sync.Mutex
+	edits []Edit
+	seq   int
+}
+
+// NewStore creates an empty store.
+func NewStore() *Store {
+	return &Store{}
+}
+
+// Ingest assigns an ID to edit, records baseline as the vitals it will be compared
+// against, and keeps history ordered by timestamp. Returns the stored edit.
+func (s *Store) Ingest(edit Edit, baseline []Vitals) Edit {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.seq++
+	edit.ID = fmt.Sprintf("edit_%d", s.seq)
+	edit.baseline = append([]Vitals(nil), baseline...)
+	s.edits = append(s.edits, edit)
+	sort.SliceStable(s.edits, func(i, j int) bool { return s.edits[i].Timestamp.Before(s.edits[j].Timestamp) })
+	if over := len(s.edits) - MaxEdits; over > 0 {
+		s.edits = append([]Edit(nil), s.edits[over:]...)
+	}
+	return edit
+}
+
+// Edits returns up to limit of the most recent edits, oldest first (limit <= 0 returns all).
+func (s *Store) Edits(limit int) []Edit {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	start := 0
+	if limit > 0 && len(s.edits) > limit {
+		start = len(s.edits) - limit
+	}
+	return append([]Edit(nil), s.edits[start:]...)
+}
+
+// Len returns the number of retained edits.
+func (s *Store) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.edits)
+}
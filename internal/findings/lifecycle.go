@@ -0,0 +1,290 @@
+// Purpose: Tracks security, accessibility, and performance findings through open/acknowledged/fixed/regressed states.
+// Why: Audits are point-in-time; a persisted lifecycle turns repeated audit runs into one triage queue and catches fixes that came undone.
+// Docs: docs/features/feature/finding-lifecycle/index.md
+
+package findings
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Finding lifecycle states.
+const (
+	StateOpen         = "open"
+	StateAcknowledged = "acknowledged"
+	StateFixed        = "fixed"
+	StateRegressed    = "regressed"
+)
+
+// States lists every lifecycle state in triage order.
+var States = []string{StateOpen, StateRegressed, StateAcknowledged, StateFixed}
+
+// SetState errors.
+var (
+	ErrUnknownFinding = errors.New("unknown finding")
+	ErrInvalidState   = errors.New("invalid finding state")
+)
+
+// maxFindings bounds persisted findings; the least recently seen are evicted first.
+const maxFindings = 1000
+
+// Observation is one finding reported by an audit run.
+type Observation struct {
+	// FindingID is the remediation playbook ID, e.g. "security.missing_csp".
+	FindingID string
+	Location  string
+	Severity  string
+	Title     string
+}
+
+// Sweep is one audit run: everything it detected on a page.
+type Sweep struct {
+	Source       string
+	Scope        string
+	Observations []Observation
+	// Complete is true when the run covered the whole audit, so a tracked finding
+	// from the same source and scope that was not re-detected is considered fixed.
+	Complete bool
+}
+
+// Finding is the persisted lifecycle of one finding on one page.
+type Finding struct {
+	ID          string     `json:"id"`
+	FindingID   string     `json:"finding_id"`
+	Category    string     `json:"category"`
+	Source      string     `json:"source"`
+	Scope       string     `json:"scope,omitempty"`
+	Location    string     `json:"location,omitempty"`
+	Severity    string     `json:"severity,omitempty"`
+	Title       string     `json:"title,omitempty"`
+	State       string     `json:"state"`
+	FirstSeen   time.Time  `json:"first_seen"`
+	LastSeen    time.Time  `json:"last_seen"`
+	Detections  int        `json:"detections"`
+	ChangedAt   time.Time  `json:"state_changed_at"`
+	FixedAt     *time.Time `json:"fixed_at,omitempty"`
+	Regressions int        `json:"regressions,omitempty"`
+	Note        string     `json:"note,omitempty"`
+}
+
+// StableID derives a finding's ID from its playbook ID, page scope, and location.
+// The playbook ID stays readable as the prefix, e.g. "security.missing_csp@3f9a1c2b".
+func StableID(findingID, scope, location string) string {
+	sum := sha256.Sum256([]byte(findingID + "\x00" + scope + "\x00" + location))
+	return findingID + "@" + hex.EncodeToString(sum[:4])
+}
+
+// Category returns the category prefix of a playbook ID ("security", "a11y", "performance").
+func Category(findingID string) string {
+	category, _, _ := strings.Cut(findingID, ".")
+	return category
+}
+
+// Tracker is a thread-safe, persistable set of finding lifecycles.
+type Tracker struct {
+	mu       sync.Mutex
+	findings map[string]*Finding
+}
+
+// NewTracker returns an empty tracker.
+func NewTracker() *Tracker {
+	return &Tracker{findings: make(map[string]*Finding)}
+}
+
+// Load replaces the tracked findings from JSON produced by JSON.
+func (t *Tracker) Load(data []byte) error {
+	var records []*Finding
+	if err := json.Unmarshal(data, &records); err != nil {
+		return err
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.findings = make(map[string]*Finding, len(records))
+	for _, r := range records {
+		if r == nil || r.ID == "" {
+			continue
+		}
+		t.findings[r.ID] = r
+	}
+	return nil
+}
+
+// JSON serializes the tracked findings for persistence.
+func (t *Tracker) JSON() ([]byte, error) {
+	return json.Marshal(t.List(""))
+}
+
+// Apply folds an audit run into the lifecycle. Re-detecting a fixed finding marks it
+// regressed; the regressed findings are returned so the caller can alert once each.
+func (t *Tracker) Apply(s Sweep, now time.Time) []Finding {
+	_, regressed := t.ApplyChanges(s, now)
+	return regressed
+}
+
+// ApplyChanges is Apply that also returns the findings detected for the first time.
+func (t *Tracker) ApplyChanges(s Sweep, now time.Time) (opened, regressed []Finding) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	seen := make(map[string]bool, len(s.Observations))
+	var openedIDs, regressedIDs []string
+	for _, o := range s.Observations {
+		if o.FindingID == "" {
+			continue
+		}
+		id := StableID(o.FindingID, s.Scope, o.Location)
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		f, ok := t.findings[id]
+		if !ok {
+			f = &Finding{
+				ID: id, FindingID: o.FindingID, Category: Category(o.FindingID), Source: s.Source,
+				Scope: s.Scope, Location: o.Location, State: StateOpen, FirstSeen: now, ChangedAt: now,
+			}
+			t.findings[id] = f
+			openedIDs = append(openedIDs, id)
+		}
+		if f.State == StateFixed {
+			f.State = StateRegressed
+			f.ChangedAt = now
+			f.Regressions++
+			regressedIDs = append(regressedIDs, id)
+		}
+		f.LastSeen = now
+		f.Detections++
+		if o.Severity != "" {
+			f.Severity = o.Severity
+		}
+		if o.Title != "" {
+			f.Title = o.Title
+		}
+	}
+	if s.Complete {
+		for id, f := range t.findings {
+			if seen[id] || f.State == StateFixed || f.Source != s.Source || f.Scope != s.Scope {
+				continue
+			}
+			at := now
+			f.State = StateFixed
+			f.FixedAt = &at
+			f.ChangedAt = now
+		}
+	}
+	for _, id := range openedIDs {
+		opened = append(opened, *t.findings[id])
+	}
+	for _, id := range regressedIDs {
+		regressed = append(regressed, *t.findings[id])
+	}
+	t.evictLocked()
+	return opened, regressed
+}
+
+// SetState moves a finding to open, acknowledged, or fixed by hand, e.g. to accept a
+// known issue or to close one the audit cannot re-check.
+func (t *Tracker) SetState(id, state, note string, now time.Time) (Finding, error) {
+	switch state {
+	case StateOpen, StateAcknowledged, StateFixed:
+	default:
+		return Finding{}, fmt.Errorf("%w %q: use open, acknowledged, or fixed", ErrInvalidState, state)
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	f, ok := t.findings[id]
+	if !ok {
+		return Finding{}, fmt.Errorf("%w %q", ErrUnknownFinding, id)
+	}
+	if f.State != state {
+		f.State = state
+		f.ChangedAt = now
+		if state == StateFixed {
+			at := now
+			f.FixedAt = &at
+		}
+	}
+	if note != "" {
+		f.Note = note
+	}
+	return *f, nil
+}
+
+// List returns findings in the given state ("" or "all" for every state),
+// most severe first, then most recently seen.
+func (t *Tracker) List(state string) []Finding {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	out := make([]Finding, 0, len(t.findings))
+	for _, f := range t.findings {
+		if state == "" || state == "all" || f.State == state {
+			out = append(out, *f)
+		}
+	}
+	sortFindings(out)
+	return out
+}
+
+// Counts returns the number of tracked findings in each state.
+func (t *Tracker) Counts() map[string]int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	counts := make(map[string]int, len(States))
+	for _, s := range States {
+		counts[s] = 0
+	}
+	for _, f := range t.findings {
+		counts[f.State]++
+	}
+	return counts
+}
+
+// evictLocked drops the least recently seen findings beyond maxFindings. Caller holds mu.
+func (t *Tracker) evictLocked() {
+	if len(t.findings) <= maxFindings {
+		return
+	}
+	records := make([]*Finding, 0, len(t.findings))
+	for _, f := range t.findings {
+		records = append(records, f)
+	}
+	sort.Slice(records, func(i, j int) bool { return records[i].LastSeen.Before(records[j].LastSeen) })
+	for _, f := range records[:len(records)-maxFindings] {
+		delete(t.findings, f.ID)
+	}
+}
+
+// SeverityRank orders severities across audit vocabularies (security, axe impact, vitals rating).
+func SeverityRank(severity string) int {
+	switch strings.ToLower(severity) {
+	case "critical":
+		return 4
+	case "high", "serious", "poor", "error":
+		return 3
+	case "medium", "moderate", "warning", "needs_improvement":
+		return 2
+	case "low", "minor", "info":
+		return 1
+	}
+	return 0
+}
+
+func sortFindings(findings []Finding) {
+	sort.Slice(findings, func(i, j int) bool {
+		a, b := findings[i], findings[j]
+		if ra, rb := SeverityRank(a.Severity), SeverityRank(b.Severity); ra != rb {
+			return ra > rb
+		}
+		if !a.LastSeen.Equal(b.LastSeen) {
+			return a.LastSeen.After(b.LastSeen)
+		}
+		return a.ID < b.ID
+	})
+}
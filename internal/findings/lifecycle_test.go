@@ -0,0 +1,150 @@
+// Purpose: Tests finding lifecycle transitions, stable IDs, hand triage, persistence, and ordering.
+// Docs: docs/features/feature/finding-lifecycle/index.md
+
+package findings
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func sweep(source, scope string, complete bool, ids ...string) Sweep {
+	s := Sweep{Source: source, Scope: scope, Complete: complete}
+	for _, id := range ids {
+		s.Observations = append(s.Observations, Observation{FindingID: id, Severity: "high"})
+	}
+	return s
+}
+
+func TestStableID_ReadableAndDeterministic(t *testing.T) {
+	t.Parallel()
+	a := StableID("security.missing_csp", "app.test/", "")
+	if a != StableID("security.missing_csp", "app.test/", "") {
+		t.Fatal("StableID is not deterministic")
+	}
+	if a == StableID("security.missing_csp", "app.test/other", "") || a == StableID("security.missing_csp", "app.test/", "x") {
+		t.Fatal("StableID should differ by scope and location")
+	}
+	if got := a[:len("security.missing_csp@")]; got != "security.missing_csp@" || len(a) != len("security.missing_csp@")+8 {
+		t.Fatalf("StableID = %q, want security.missing_csp@<8 hex>", a)
+	}
+}
+
+func TestTracker_OpenFixRegress(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	tr := NewTracker()
+
+	if got := tr.Apply(sweep("security_audit", "app.test/", true, "security.missing_csp", "security.missing_hsts"), now); len(got) != 0 {
+		t.Fatalf("first run reported regressions: %+v", got)
+	}
+	if c := tr.Counts(); c[StateOpen] != 2 {
+		t.Fatalf("counts = %v, want 2 open", c)
+	}
+
+	// A narrowed run never fixes what it did not look at.
+	tr.Apply(sweep("security_audit", "app.test/", false), now.Add(time.Minute))
+	if c := tr.Counts(); c[StateOpen] != 2 {
+		t.Fatalf("narrowed run changed state: %v", c)
+	}
+	// Other sources and pages are untouched by a complete run.
+	tr.Apply(sweep("accessibility", "app.test/", true), now.Add(time.Minute))
+	tr.Apply(sweep("security_audit", "app.test/other", true), now.Add(time.Minute))
+	if c := tr.Counts(); c[StateOpen] != 2 {
+		t.Fatalf("unrelated runs changed state: %v", c)
+	}
+
+	tr.Apply(sweep("security_audit", "app.test/", true, "security.missing_hsts"), now.Add(2*time.Minute))
+	fixed := tr.List(StateFixed)
+	if len(fixed) != 1 || fixed[0].FindingID != "security.missing_csp" || fixed[0].FixedAt == nil {
+		t.Fatalf("fixed = %+v, want missing_csp with fixed_at", fixed)
+	}
+
+	regressed := tr.Apply(sweep("security_audit", "app.test/", true, "security.missing_csp", "security.missing_hsts"), now.Add(3*time.Minute))
+	if len(regressed) != 1 || regressed[0].FindingID != "security.missing_csp" || regressed[0].Regressions != 1 || regressed[0].State != StateRegressed {
+		t.Fatalf("regressed = %+v", regressed)
+	}
+	// Seeing it again while regressed does not alert twice.
+	if again := tr.Apply(sweep("security_audit", "app.test/", true, "security.missing_csp", "security.missing_hsts"), now.Add(4*time.Minute)); len(again) != 0 {
+		t.Fatalf("second sighting re-reported regression: %+v", again)
+	}
+	if f := tr.List(StateRegressed)[0]; f.Detections != 3 || !f.FirstSeen.Equal(now) {
+		t.Fatalf("regressed record = %+v, want 3 detections since %v", f, now)
+	}
+}
+
+func TestTracker_ApplyChangesReportsOpened(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	tr := NewTracker()
+
+	opened, regressed := tr.ApplyChanges(sweep("vitals", "app.test/", true, "performance.lcp_poor", "performance.lcp_poor"), now)
+	if len(opened) != 1 || opened[0].Category != "performance" || len(regressed) != 0 {
+		t.Fatalf("first run opened = %+v, regressed = %+v; want one performance finding", opened, regressed)
+	}
+	if opened, _ = tr.ApplyChanges(sweep("vitals", "app.test/", true, "performance.lcp_poor"), now.Add(time.Minute)); len(opened) != 0 {
+		t.Fatalf("re-detection reported as opened: %+v", opened)
+	}
+}
+
+func TestTracker_SetState(t *testing.T) {
+	t.Parallel()
+	now := time.Now()
+	tr := NewTracker()
+	tr.Apply(sweep("vitals", "app.test/", true, "performance.lcp"), now)
+	id := StableID("performance.lcp", "app.test/", "")
+
+	f, err := tr.SetState(id, StateAcknowledged, "known slow hero image", now)
+	if err != nil || f.State != StateAcknowledged || f.Note != "known slow hero image" {
+		t.Fatalf("SetState = %+v, %v", f, err)
+	}
+	// An acknowledged finding that stays detected keeps its state.
+	tr.Apply(sweep("vitals", "app.test/", true, "performance.lcp"), now.Add(time.Second))
+	if got := tr.List(StateAcknowledged); len(got) != 1 {
+		t.Fatalf("acknowledged = %+v, want it kept", got)
+	}
+
+	if _, err := tr.SetState(id, StateRegressed, "", now); !errors.Is(err, ErrInvalidState) {
+		t.Fatalf("SetState(regressed) err = %v, want ErrInvalidState", err)
+	}
+	if _, err := tr.SetState("performance.lcp@deadbeef", StateFixed, "", now); !errors.Is(err, ErrUnknownFinding) {
+		t.Fatalf("SetState(unknown) err = %v, want ErrUnknownFinding", err)
+	}
+}
+
+func TestTracker_JSONRoundTripAndOrdering(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	tr := NewTracker()
+	tr.Apply(Sweep{Source: "accessibility", Observations: []Observation{
+		{FindingID: "a11y.image-alt", Severity: "minor"},
+		{FindingID: "a11y.color-contrast", Severity: "serious"},
+	}}, now)
+	tr.Apply(Sweep{Source: "security_audit", Observations: []Observation{{FindingID: "security.credential_in_url", Severity: "critical"}}}, now)
+
+	data, err := tr.JSON()
+	if err != nil {
+		t.Fatalf("JSON: %v", err)
+	}
+	restored := NewTracker()
+	if err := restored.Load(data); err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	list := restored.List("all")
+	if len(list) != 3 {
+		t.Fatalf("restored %d findings, want 3", len(list))
+	}
+	want := []string{"security.credential_in_url", "a11y.color-contrast", "a11y.image-alt"}
+	for i, f := range list {
+		if f.FindingID != want[i] {
+			t.Errorf("list[%d] = %s, want %s (severity order)", i, f.FindingID, want[i])
+		}
+	}
+	if list[1].Category != "a11y" {
+		t.Errorf("category = %q, want a11y", list[1].Category)
+	}
+	if err := restored.Load([]byte("{")); err == nil {
+		t.Error("Load should reject invalid JSON")
+	}
+}
@@ -0,0 +1,27 @@
+// Purpose: Package invariants — standing assertions evaluated against every ingested telemetry batch.
+// Why: Polling observe calls miss one-off events between polls; an invariant checks every batch as it arrives.
+// Docs: docs/features/feature/invariants/index.md
+
+/*
+Package invariants parses short "no ..." expressions and checks them against each
+network, WebSocket, and console batch the daemon ingests. A match is recorded as a
+Violation with its evidence, deduplicated by what matched, so a request repeated a
+thousand times is one violation with a count of 1000.
+
+Expressions:
+  - no [network] request to <host|url-part>
+  - no [network] request matching <regex>
+  - no [network] status <op> <code> [to <host|url-part>]   (op: >=, >, <=, <, =)
+  - no console <level> [matching <regex>]                  (level: error, warn, info, log, debug)
+  - no websocket [connection] [to <host|url-part>]
+
+Scopes:
+  - session: every page, until removed or the daemon stops.
+  - page: only activity from the origin of the page tracked when the invariant was added.
+
+Key types:
+  - Monitor: the registry; ObserveNetwork and ObserveConsole return first-time violations.
+  - Rule: a parsed expression.
+  - Violation: one distinct match with evidence, count, and first/last seen.
+*/
+package invariants
@@ -0,0 +1,332 @@
+// Purpose: Holds registered invariants and checks each ingested network, WebSocket, and console batch against them.
+// Why: Violations must be caught as telemetry arrives, with evidence, even when they happen between observe calls.
+// Docs: docs/features/feature/invariants/index.md
+
+package invariants
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/util"
+)
+
+// Scopes.
+const (
+	ScopeSession = "session"
+	ScopePage    = "page"
+)
+
+const (
+	// MaxInvariants caps registrations per daemon.
+	MaxInvariants = 50
+	// maxViolations bounds retained violations; the oldest are evicted first.
+	maxViolations = 500
+	// maxEvidenceText truncates console messages and stacks kept as evidence.
+	maxEvidenceText = 1000
+)
+
+// Registration errors.
+var (
+	ErrTooManyInvariants = fmt.Errorf("invariant limit reached (%d)", MaxInvariants)
+	ErrUnknownInvariant  = errors.New("unknown invariant id")
+	ErrUnknownScope      = errors.New("unknown invariant scope (valid: session, page)")
+	ErrNoPageForScope    = errors.New(`scope "page" needs a tracked page`)
+)
+
+// Invariant is one registered expression.
+type Invariant struct {
+	ID    string `json:"id"`
+	Expr  string `json:"expr"`
+	Scope string `json:"scope"`
+	// Origin is the tracked page's origin for scope "page".
+	Origin          string     `json:"origin,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+	Violations      int        `json:"violations"`
+	LastViolationAt *time.Time `json:"last_violation_at,omitempty"`
+
+	rule Rule
+}
+
+// Evidence is what matched: the request, WebSocket event, or console entry.
+type Evidence struct {
+	URL           string `json:"url,omitempty"`
+	Method        string `json:"method,omitempty"`
+	Status        int    `json:"status,omitempty"`
+	InitiatorType string `json:"initiator_type,omitempty"`
+	Event         string `json:"event,omitempty"`
+	Level         string `json:"level,omitempty"`
+	Message       string `json:"message,omitempty"`
+	Source        string `json:"source,omitempty"`
+	Stack         string `json:"stack,omitempty"`
+	PageURL       string `json:"page_url,omitempty"`
+	TabID         int    `json:"tab_id,omitempty"`
+	Timestamp     string `json:"ts,omitempty"`
+}
+
+// Violation is one distinct match of an invariant. Repeats of the same request URL or
+// console message increment Count; Evidence is from the first occurrence.
+type Violation struct {
+	InvariantID string    `json:"invariant_id"`
+	Expr        string    `json:"expr"`
+	Kind        string    `json:"kind"`
+	Evidence    Evidence  `json:"evidence"`
+	Count       int       `json:"count"`
+	FirstSeen   time.Time `json:"first_seen"`
+	LastSeen    time.Time `json:"last_seen"`
+}
+
+// Monitor is a thread-safe invariant registry. Observe methods run on ingest callbacks
+// outside the Capture lock; Monitor.mu is a leaf lock.
+type Monitor struct {
+	mu         sync.Mutex
+	invariants map[string]*Invariant
+	order      []string
+	nextID     int
+	violations map[string]*Violation
+	vorder     []string
+}
+
+// NewMonitor returns an empty monitor.
+func NewMonitor() *Monitor {
+	return &Monitor{invariants: make(map[string]*Invariant), violations: make(map[string]*Violation)}
+}
+
+// Add parses and registers expr. pageURL is the tracked page, required for scope "page".
+func (m *Monitor) Add(expr, scope, pageURL string, now time.Time) (Invariant, error) {
+	rule, err := Parse(expr)
+	if err != nil {
+		return Invariant{}, err
+	}
+	inv := &Invariant{Expr: strings.TrimSpace(expr), Scope: scope, CreatedAt: now, rule: rule}
+	switch scope {
+	case "", ScopeSession:
+		inv.Scope = ScopeSession
+	case ScopePage:
+		if inv.Origin = util.ExtractOrigin(pageURL); inv.Origin == "" {
+			return Invariant{}, ErrNoPageForScope
+		}
+	default:
+		return Invariant{}, ErrUnknownScope
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if len(m.invariants) >= MaxInvariants {
+		return Invariant{}, ErrTooManyInvariants
+	}
+	m.nextID++
+	inv.ID = "inv-" + strconv.Itoa(m.nextID)
+	m.invariants[inv.ID] = inv
+	m.order = append(m.order, inv.ID)
+	return *inv, nil
+}
+
+// Remove unregisters an invariant and drops its violations.
+func (m *Monitor) Remove(id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.invariants[id]; !ok {
+		return ErrUnknownInvariant
+	}
+	delete(m.invariants, id)
+	m.order = removeID(m.order, id)
+	for key, v := range m.violations {
+		if v.InvariantID == id {
+			delete(m.violations, key)
+			m.vorder = removeID(m.vorder, key)
+		}
+	}
+	return nil
+}
+
+// Clear unregisters every invariant and returns how many were removed.
+func (m *Monitor) Clear() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	n := len(m.invariants)
+	m.invariants = make(map[string]*Invariant)
+	m.order = nil
+	m.violations = make(map[string]*Violation)
+	m.vorder = nil
+	return n
+}
+
+// List returns invariants in creation order.
+func (m *Monitor) List() []Invariant {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	out := make([]Invariant, 0, len(m.order))
+	for _, id := range m.order {
+		out = append(out, *m.invariants[id])
+	}
+	return out
+}
+
+// Violations returns retained violations, most recently seen (then first seen) first, optionally for one
+// invariant. limit <= 0 returns all.
+func (m *Monitor) Violations(invariantID string, limit int) []Violation {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	out := make([]Violation, 0, len(m.violations))
+	for _, v := range m.violations {
+		if invariantID == "" || v.InvariantID == invariantID {
+			out = append(out, *v)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if !out[i].LastSeen.Equal(out[j].LastSeen) {
+			return out[i].LastSeen.After(out[j].LastSeen)
+		}
+		return out[i].FirstSeen.After(out[j].FirstSeen)
+	})
+	if limit > 0 && len(out) > limit {
+		out = out[:limit]
+	}
+	return out
+}
+
+// ObserveNetwork checks one ingested network batch and returns violations seen for the first time.
+func (m *Monitor) ObserveNetwork(activity capture.NetworkActivity, now time.Time) []Violation {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if len(m.invariants) == 0 {
+		return nil
+	}
+	var fresh []Violation
+	for _, id := range m.order {
+		inv := m.invariants[id]
+		r := inv.rule
+		switch r.Kind {
+		case KindRequest, KindStatus:
+			for _, body := range activity.Bodies {
+				if !inv.inScope(activity.PageURL) || !r.matchURL(body.URL) {
+					continue
+				}
+				if r.Kind == KindStatus && !r.matchStatus(body.Status) {
+					continue
+				}
+				fresh = m.recordLocked(inv, body.Method+" "+body.URL, Evidence{
+					URL: body.URL, Method: body.Method, Status: body.Status,
+					PageURL: activity.PageURL, TabID: body.TabID, Timestamp: body.Timestamp,
+				}, now, fresh)
+			}
+			if r.Kind == KindStatus {
+				continue // resource timing entries carry no status
+			}
+			for _, entry := range activity.Waterfall {
+				pageURL := entry.PageURL
+				if pageURL == "" {
+					pageURL = activity.PageURL
+				}
+				if !inv.inScope(pageURL) || !r.matchURL(entry.URL) {
+					continue
+				}
+				// Keyed like a GET body so a fetch reported by both sources is one violation.
+				fresh = m.recordLocked(inv, "GET "+entry.URL, Evidence{
+					URL: entry.URL, InitiatorType: entry.InitiatorType, PageURL: pageURL,
+				}, now, fresh)
+			}
+		case KindWebSocket:
+			for _, event := range activity.WebSocket {
+				if !inv.inScope(activity.PageURL) || !r.matchURL(event.URL) {
+					continue
+				}
+				fresh = m.recordLocked(inv, event.URL, Evidence{
+					URL: event.URL, Event: event.Event, PageURL: activity.PageURL,
+					TabID: event.TabID, Timestamp: event.Timestamp,
+				}, now, fresh)
+			}
+		}
+	}
+	return fresh
+}
+
+// ObserveConsole checks ingested console entries and returns violations seen for the first time.
+func (m *Monitor) ObserveConsole(entries []map[string]any, now time.Time) []Violation {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if len(m.invariants) == 0 {
+		return nil
+	}
+	var fresh []Violation
+	for _, id := range m.order {
+		inv := m.invariants[id]
+		r := inv.rule
+		if r.Kind != KindConsole {
+			continue
+		}
+		for _, entry := range entries {
+			level, _ := entry["level"].(string)
+			if level == "warning" {
+				level = "warn"
+			}
+			message, _ := entry["message"].(string)
+			pageURL, _ := entry["url"].(string)
+			if level != r.Level || !inv.inScope(pageURL) || (r.Pattern != nil && !r.Pattern.MatchString(message)) {
+				continue
+			}
+			source, _ := entry["source"].(string)
+			stack, _ := entry["stack"].(string)
+			ts, _ := entry["ts"].(string)
+			tabID, _ := entry["tabId"].(float64)
+			fresh = m.recordLocked(inv, message, Evidence{
+				Level: level, Message: truncate(message), Source: source, Stack: truncate(stack),
+				PageURL: pageURL, TabID: int(tabID), Timestamp: ts,
+			}, now, fresh)
+		}
+	}
+	return fresh
+}
+
+// recordLocked counts a match keyed by invariant and subject, appending it to fresh when new.
+func (m *Monitor) recordLocked(inv *Invariant, subject string, ev Evidence, now time.Time, fresh []Violation) []Violation {
+	key := inv.ID + "|" + subject
+	at := now
+	inv.LastViolationAt = &at
+	if existing, ok := m.violations[key]; ok {
+		existing.Count++
+		existing.LastSeen = now
+		return fresh
+	}
+	v := &Violation{InvariantID: inv.ID, Expr: inv.Expr, Kind: inv.rule.Kind, Evidence: ev, Count: 1, FirstSeen: now, LastSeen: now}
+	inv.Violations++
+	m.violations[key] = v
+	m.vorder = append(m.vorder, key)
+	m.evictLocked()
+	return append(fresh, *v)
+}
+
+func (m *Monitor) evictLocked() {
+	for len(m.vorder) > maxViolations {
+		delete(m.violations, m.vorder[0])
+		m.vorder = m.vorder[1:]
+	}
+}
+
+// inScope reports whether activity from pageURL counts for the invariant.
+func (inv *Invariant) inScope(pageURL string) bool {
+	return inv.Scope != ScopePage || util.ExtractOrigin(pageURL) == inv.Origin
+}
+
+func removeID(ids []string, id string) []string {
+	for i, other := range ids {
+		if other == id {
+			return append(ids[:i], ids[i+1:]...)
+		}
+	}
+	return ids
+}
+
+func truncate(s string) string {
+	if len(s) <= maxEvidenceText {
+		return s
+	}
+	return s[:maxEvidenceText] + "..."
+}
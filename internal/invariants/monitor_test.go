@@ -0,0 +1,145 @@
+// Purpose: Tests invariant expression parsing, network/WebSocket/console matching, dedup, scope, and removal.
+// Docs: docs/features/feature/invariants/index.md
+
+package invariants
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+)
+
+func TestParse_Grammar(t *testing.T) {
+	t.Parallel()
+	valid := map[string]string{
+		"no network request to prod-api.com":            KindRequest,
+		"No requests matching /\\/admin\\//":            KindRequest,
+		"no network status >= 500":                      KindStatus,
+		"no status = 401 to api.example.com":            KindStatus,
+		"no console errors":                             KindConsole,
+		"no console warning matching deprecated API":    KindConsole,
+		"no websocket connection to legacy.example.com": KindWebSocket,
+	}
+	for expr, kind := range valid {
+		rule, err := Parse(expr)
+		if err != nil || rule.Kind != kind {
+			t.Errorf("Parse(%q) = %+v, %v; want kind %s", expr, rule, err, kind)
+		}
+	}
+	for _, expr := range []string{
+		"network request to prod-api.com",
+		"no network request",
+		"no request to a b",
+		"no status ~ 500",
+		"no status >= abc",
+		"no console trace",
+		"no console error matching (",
+		"no cookies",
+	} {
+		if _, err := Parse(expr); !errors.Is(err, ErrInvalidExpr) {
+			t.Errorf("Parse(%q) err = %v, want ErrInvalidExpr", expr, err)
+		}
+	}
+}
+
+func TestParse_PatternWhitespace(t *testing.T) {
+	t.Parallel()
+	for expr, want := range map[string]string{
+		"no request\tmatching /api/":               "api",
+		"no request  matching   /api/":             "api",
+		"no console error\tMATCHING\t^Uncaught  x": "^Uncaught  x",
+		"no console\nerror matching \"a b\"":       "a b",
+	} {
+		rule, err := Parse(expr)
+		if err != nil || rule.Pattern == nil || rule.Pattern.String() != want {
+			t.Errorf("Parse(%q) = %+v, %v; want pattern %q", expr, rule, err, want)
+		}
+	}
+}
+
+func TestMonitor_NetworkViolationsDedupWithEvidence(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC)
+	m := NewMonitor()
+	inv, err := m.Add("no network request to prod-api.com", "", "", now)
+	if err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	m.Add("no network status >= 500", ScopeSession, "", now)
+
+	batch := capture.NetworkActivity{
+		PageURL: "https://staging.app.test/cart",
+		Bodies: []capture.NetworkBody{
+			{Method: "POST", URL: "https://eu.prod-api.com/orders", Status: 503, TabID: 7},
+			{Method: "GET", URL: "https://staging-api.app.test/cart", Status: 200},
+		},
+	}
+	fresh := m.ObserveNetwork(batch, now)
+	if len(fresh) != 2 {
+		t.Fatalf("fresh = %+v, want the prod request and the 503", fresh)
+	}
+	v := fresh[0]
+	if v.InvariantID != inv.ID || v.Kind != KindRequest || v.Evidence.Method != "POST" || v.Evidence.Status != 503 ||
+		v.Evidence.PageURL != "https://staging.app.test/cart" || v.Evidence.TabID != 7 {
+		t.Fatalf("violation = %+v", v)
+	}
+
+	// The same request again, and as a resource timing entry, is not new.
+	again := m.ObserveNetwork(capture.NetworkActivity{
+		Bodies:    batch.Bodies[:1],
+		Waterfall: []capture.NetworkWaterfallEntry{{URL: "https://prod-api.com/config.js", InitiatorType: "script"}},
+	}, now.Add(time.Second))
+	if len(again) != 1 || again[0].Evidence.URL != "https://prod-api.com/config.js" {
+		t.Fatalf("second batch fresh = %+v, want only the script", again)
+	}
+	got := m.Violations(inv.ID, 0)
+	if len(got) != 2 || got[1].Count != 2 || !got[1].LastSeen.Equal(now.Add(time.Second)) {
+		t.Fatalf("violations = %+v, want the POST counted twice", got)
+	}
+	if list := m.List(); list[0].Violations != 2 || list[0].LastViolationAt == nil {
+		t.Fatalf("invariant = %+v", list[0])
+	}
+}
+
+func TestMonitor_ConsoleWebSocketAndPageScope(t *testing.T) {
+	t.Parallel()
+	now := time.Now()
+	m := NewMonitor()
+	if _, err := m.Add("no console error", ScopePage, "", now); !errors.Is(err, ErrNoPageForScope) {
+		t.Fatalf("page scope without a page: err = %v", err)
+	}
+	if _, err := m.Add("no console error", "tab", "", now); !errors.Is(err, ErrUnknownScope) {
+		t.Fatalf("unknown scope: err = %v", err)
+	}
+	m.Add("no console error matching ^Uncaught", ScopePage, "https://app.test/home", now)
+	ws, _ := m.Add("no websocket to legacy.app.test", "", "", now)
+
+	fresh := m.ObserveConsole([]map[string]any{
+		{"level": "error", "message": "Uncaught TypeError", "url": "https://other.test/", "tabId": float64(3)},
+		{"level": "error", "message": "Handled failure", "url": "https://app.test/cart"},
+		{"level": "error", "message": "Uncaught RangeError", "url": "https://app.test/cart", "stack": "at x.js:1"},
+	}, now)
+	if len(fresh) != 1 || fresh[0].Evidence.Message != "Uncaught RangeError" || fresh[0].Evidence.Stack != "at x.js:1" {
+		t.Fatalf("console fresh = %+v, want only the in-scope uncaught error", fresh)
+	}
+
+	fresh = m.ObserveNetwork(capture.NetworkActivity{WebSocket: []capture.WebSocketEvent{
+		{Event: "open", URL: "wss://legacy.app.test/socket"},
+		{Event: "open", URL: "wss://live.app.test/socket"},
+	}}, now)
+	if len(fresh) != 1 || fresh[0].InvariantID != ws.ID || fresh[0].Evidence.Event != "open" {
+		t.Fatalf("websocket fresh = %+v", fresh)
+	}
+
+	if err := m.Remove(ws.ID); err != nil || len(m.Violations(ws.ID, 0)) != 0 {
+		t.Fatalf("Remove: %v, violations left %d", err, len(m.Violations(ws.ID, 0)))
+	}
+	if err := m.Remove(ws.ID); !errors.Is(err, ErrUnknownInvariant) {
+		t.Fatalf("second Remove: %v", err)
+	}
+	if n := m.Clear(); n != 1 || len(m.List()) != 0 {
+		t.Fatalf("Clear = %d", n)
+	}
+}
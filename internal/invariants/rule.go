@@ -0,0 +1,206 @@
+// Purpose: Parses invariant expressions ("no network request to prod-api.com") into Rules and matches telemetry against them.
+// Why: A small fixed grammar keeps expressions readable to agents and cheap to evaluate on every ingest batch.
+// Docs: docs/features/feature/invariants/index.md
+
+package invariants
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Rule kinds.
+const (
+	KindRequest   = "network_request"
+	KindStatus    = "network_status"
+	KindConsole   = "console"
+	KindWebSocket = "websocket"
+)
+
+// Grammar lists the supported expression forms, for error hints.
+const Grammar = "no [network] request to <host>; no [network] request matching <regex>; " +
+	"no [network] status >= <code> [to <host>]; no console <error|warn|info|log|debug> [matching <regex>]; " +
+	"no websocket [to <host>]"
+
+// ErrInvalidExpr wraps every parse error.
+var ErrInvalidExpr = errors.New("invalid invariant expression")
+
+// Rule is a parsed invariant expression.
+type Rule struct {
+	Kind string
+	// Target restricts matches to a host (and its subdomains) or, when it contains
+	// "/", to URLs containing it. Empty matches every URL.
+	Target  string
+	Pattern *regexp.Regexp
+	Level   string
+	Op      string
+	Status  int
+}
+
+// Parse turns an expression into a Rule. Keywords are case-insensitive; hosts and
+// regular expressions keep their case.
+func Parse(expr string) (Rule, error) {
+	fields := strings.Fields(expr)
+	if len(fields) < 2 || !strings.EqualFold(fields[0], "no") {
+		return Rule{}, invalid(`expressions start with "no"`)
+	}
+	i := 1
+	if strings.EqualFold(fields[i], "network") {
+		i++
+	}
+	if i >= len(fields) {
+		return Rule{}, invalid("missing subject after \"no network\"")
+	}
+	subject := strings.ToLower(fields[i])
+	rest := fields[i+1:]
+
+	switch subject {
+	case "request", "requests":
+		if len(rest) >= 2 && strings.EqualFold(rest[0], "matching") {
+			re, err := compilePattern(expr)
+			return Rule{Kind: KindRequest, Pattern: re}, err
+		}
+		target, err := parseTarget(rest)
+		if err != nil || target == "" {
+			return Rule{}, invalid(`use "request to <host>" or "request matching <regex>"`)
+		}
+		return Rule{Kind: KindRequest, Target: target}, nil
+
+	case "status":
+		if len(rest) < 2 {
+			return Rule{}, invalid(`use "status >= 500"`)
+		}
+		op := rest[0]
+		switch op {
+		case ">=", ">", "<=", "<", "=", "==":
+		default:
+			return Rule{}, invalid("unknown status operator " + strconv.Quote(op))
+		}
+		code, err := strconv.Atoi(rest[1])
+		if err != nil || code < 0 || code > 999 {
+			return Rule{}, invalid("status must be an HTTP status code")
+		}
+		target, err := parseTarget(rest[2:])
+		if err != nil {
+			return Rule{}, err
+		}
+		return Rule{Kind: KindStatus, Op: op, Status: code, Target: target}, nil
+
+	case "console":
+		if len(rest) == 0 {
+			return Rule{}, invalid(`use "console error"`)
+		}
+		level := strings.TrimSuffix(strings.ToLower(rest[0]), "s")
+		if level == "warning" {
+			level = "warn"
+		}
+		switch level {
+		case "error", "warn", "info", "log", "debug":
+		default:
+			return Rule{}, invalid("unknown console level " + strconv.Quote(rest[0]))
+		}
+		rule := Rule{Kind: KindConsole, Level: level}
+		if len(rest) > 1 {
+			if !strings.EqualFold(rest[1], "matching") || len(rest) < 3 {
+				return Rule{}, invalid(`use "console error matching <regex>"`)
+			}
+			re, err := compilePattern(expr)
+			if err != nil {
+				return Rule{}, err
+			}
+			rule.Pattern = re
+		}
+		return rule, nil
+
+	case "websocket", "websockets":
+		if len(rest) > 0 && strings.HasPrefix(strings.ToLower(rest[0]), "connection") {
+			rest = rest[1:]
+		}
+		target, err := parseTarget(rest)
+		if err != nil {
+			return Rule{}, err
+		}
+		return Rule{Kind: KindWebSocket, Target: target}, nil
+	}
+	return Rule{}, invalid("unknown subject " + strconv.Quote(fields[i]))
+}
+
+func invalid(reason string) error {
+	return fmt.Errorf("%w: %s (supported: %s)", ErrInvalidExpr, reason, Grammar)
+}
+
+// parseTarget reads an optional "to <host|url-part>" suffix.
+func parseTarget(rest []string) (string, error) {
+	if len(rest) == 0 {
+		return "", nil
+	}
+	if len(rest) != 2 || !strings.EqualFold(rest[0], "to") {
+		return "", invalid("unexpected " + strconv.Quote(strings.Join(rest, " ")))
+	}
+	return strings.ToLower(strings.Trim(rest[1], `"'`)), nil
+}
+
+// matchingKeyword finds the "matching" field however Parse's strings.Fields split it.
+// No field before it can contain "matching", so the first hit is the keyword.
+var matchingKeyword = regexp.MustCompile(`(?i)\smatching\s`)
+
+// compilePattern compiles everything after the "matching" keyword, minus surrounding quotes or slashes.
+func compilePattern(expr string) (*regexp.Regexp, error) {
+	loc := matchingKeyword.FindStringIndex(expr)
+	if loc == nil {
+		return nil, invalid(`use "matching <regex>"`)
+	}
+	raw := strings.TrimSpace(expr[loc[1]:])
+	if len(raw) >= 2 && (raw[0] == '/' || raw[0] == '"' || raw[0] == '\'') && raw[len(raw)-1] == raw[0] {
+		raw = raw[1 : len(raw)-1]
+	}
+	re, err := regexp.Compile(raw)
+	if err != nil {
+		return nil, invalid("bad regex: " + err.Error())
+	}
+	return re, nil
+}
+
+// matchURL reports whether rawURL satisfies the rule's Target and Pattern.
+func (r Rule) matchURL(rawURL string) bool {
+	if r.Pattern != nil && !r.Pattern.MatchString(rawURL) {
+		return false
+	}
+	if r.Target == "" {
+		return true
+	}
+	if strings.Contains(r.Target, "/") {
+		return strings.Contains(strings.ToLower(rawURL), r.Target)
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+	host := strings.ToLower(u.Hostname())
+	if strings.Contains(r.Target, ":") {
+		host = strings.ToLower(u.Host)
+	}
+	return host == r.Target || strings.HasSuffix(host, "."+r.Target)
+}
+
+// matchStatus compares an HTTP status against the rule's operator.
+func (r Rule) matchStatus(status int) bool {
+	if status == 0 {
+		return false
+	}
+	switch r.Op {
+	case ">=":
+		return status >= r.Status
+	case ">":
+		return status > r.Status
+	case "<=":
+		return status <= r.Status
+	case "<":
+		return status < r.Status
+	}
+	return status == r.Status
+}
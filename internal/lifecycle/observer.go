@@ -23,6 +23,9 @@ const (
 	EventRateLimitTriggered           // Rate limit threshold hit
 	EventCommandStateDesync           // Command state mismatch with extension
 	EventSyncSnapshot                 // Periodic sync state snapshot
+	EventTestBoundaryEnded            // A test boundary was closed (data: test_id)
+	EventTestRunEnded                 // A test runner reported run_end (data: run_id)
+	EventMemoryEviction               // Memory pressure shed capture data (data: level, evicted)
 )
 
 // eventNames maps typed events to their wire-format string names.
@@ -36,6 +39,9 @@ var eventNames = map[Event]string{
 	EventRateLimitTriggered:     "rate_limit_triggered",
 	EventCommandStateDesync:     "command_state_desync",
 	EventSyncSnapshot:           "sync_snapshot",
+	EventTestBoundaryEnded:      "test_boundary_ended",
+	EventTestRunEnded:           "test_run_ended",
+	EventMemoryEviction:         "memory_eviction",
 }
 
 // stringToEvent maps wire-format string names to typed events (reverse of eventNames).
@@ -0,0 +1,26 @@
+// Purpose: Package loginrecipe — named, parameterized login flows that interact(login) replays.
+// Why: Agents re-derive the same login steps every session; a stored recipe logs in with one call.
+// Docs: docs/features/feature/login-recipes/index.md
+
+/*
+Package loginrecipe validates, stores, and expands login recipes: a short list of
+navigate, type, click, and wait_for steps defined once with configure(what="define_login").
+
+Recipes hold no secrets. A type step either carries text, which may use {{var}}
+placeholders filled from the recipe's default vars or the vars passed to interact(login),
+or names an environment variable in text_env that is read from the daemon's environment
+when the recipe runs. Only variables starting with EnvPrefix can be read, so a recipe
+cannot type arbitrary daemon secrets into a page. Every navigate step must stay on the
+origin of the first one.
+
+Key types:
+  - Recipe: the stored flow, its default vars, and the ttl of the state saved after login.
+  - Step: one navigate, type, click, or wait_for step.
+  - Resolved: a step expanded into interact arguments, flagged when its text is a secret.
+
+Key functions:
+  - Validate: checks a recipe before it is stored.
+  - (Recipe).Expand: fills placeholders and environment variables for one run.
+  - Save / Load / List / Delete: recipes in the session store.
+*/
+package loginrecipe
@@ -0,0 +1,314 @@
+// Purpose: Validates, expands, and persists login recipes.
+// Why: Keeps the recipe format, placeholder rules, and secret handling out of the MCP handlers.
+// Docs: docs/features/feature/login-recipes/index.md
+
+package loginrecipe
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/statevault"
+)
+
+// Namespace is the session store namespace holding recipes.
+const Namespace = "login_recipes"
+
+// MaxSteps caps the length of a recipe.
+const MaxSteps = 20
+
+// EnvPrefix is the prefix every text_env variable must have.
+const EnvPrefix = "KABOOM_LOGIN_"
+
+// ErrNotFound is returned when no recipe has the name.
+var ErrNotFound = errors.New("login recipe not found")
+
+var (
+	placeholderPattern = regexp.MustCompile(`\{\{\s*([A-Za-z_][A-Za-z0-9_]*)\s*\}\}`)
+	varNamePattern     = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+	envNamePattern     = regexp.MustCompile(`^` + EnvPrefix + `[A-Z0-9_]+$`)
+)
+
+// Store is the subset of persistence.SessionStore recipes are kept in.
+type Store = statevault.Store
+
+// Step is one step of a login flow.
+type Step struct {
+	What        string `json:"what"`
+	URL         string `json:"url,omitempty"`          // navigate
+	Selector    string `json:"selector,omitempty"`     // type, click, wait_for
+	Text        string `json:"text,omitempty"`         // type; may use {{var}} placeholders
+	TextEnv     string `json:"text_env,omitempty"`     // type; daemon environment variable holding the text
+	URLContains string `json:"url_contains,omitempty"` // wait_for
+	TimeoutMs   int    `json:"timeout_ms,omitempty"`   // wait_for
+}
+
+// Recipe is a named login flow.
+type Recipe struct {
+	Name        string            `json:"name"`
+	Description string            `json:"description,omitempty"`
+	Vars        map[string]string `json:"vars,omitempty"` // defaults for {{var}} placeholders
+	TTL         string            `json:"ttl,omitempty"`  // ttl of the state saved after login
+	Steps       []Step            `json:"steps"`
+	SavedAt     time.Time         `json:"saved_at"`
+}
+
+// Summary describes a recipe for listing.
+type Summary struct {
+	Name        string    `json:"name"`
+	Description string    `json:"description,omitempty"`
+	Origin      string    `json:"origin,omitempty"`
+	StepCount   int       `json:"step_count"`
+	Vars        []string  `json:"vars"`
+	SecretEnv   []string  `json:"secret_env"`
+	SavedAt     time.Time `json:"saved_at"`
+}
+
+// Resolved is a step expanded for one run.
+type Resolved struct {
+	What   string
+	Args   map[string]any // interact arguments
+	Secret bool           // text came from text_env and must not be recorded or echoed
+}
+
+// Validate checks recipe before it is stored.
+func Validate(recipe Recipe) error {
+	if err := statevault.ValidateName(recipe.Name); err != nil {
+		return fmt.Errorf("invalid recipe name %q: use letters, digits, '.', '_', or '-' (max 64)", recipe.Name)
+	}
+	if len(recipe.Steps) == 0 || len(recipe.Steps) > MaxSteps {
+		return fmt.Errorf("steps must have 1 to %d entries", MaxSteps)
+	}
+	if recipe.Steps[0].What != "navigate" {
+		return errors.New("step[0] must be a navigate step: the recipe's origin comes from its url")
+	}
+	if _, err := statevault.ParseTTL(recipe.TTL); err != nil {
+		return err
+	}
+	for name := range recipe.Vars {
+		if !varNamePattern.MatchString(name) {
+			return fmt.Errorf("invalid var name %q: use letters, digits, and '_'", name)
+		}
+	}
+	for i, step := range recipe.Steps {
+		if err := validateStep(step); err != nil {
+			return fmt.Errorf("step[%d]: %w", i, err)
+		}
+	}
+	return checkOrigins(recipe.Steps, func(url string) bool { return !placeholderPattern.MatchString(url) })
+}
+
+func validateStep(step Step) error {
+	switch step.What {
+	case "navigate":
+		if step.URL == "" {
+			return errors.New("navigate needs url")
+		}
+	case "type":
+		if step.Selector == "" {
+			return errors.New("type needs selector")
+		}
+		if (step.Text == "") == (step.TextEnv == "") {
+			return errors.New("type needs exactly one of text or text_env")
+		}
+		if step.TextEnv != "" && !envNamePattern.MatchString(step.TextEnv) {
+			return fmt.Errorf("text_env %q must be an upper-case variable name starting with %s", step.TextEnv, EnvPrefix)
+		}
+	case "click":
+		if step.Selector == "" {
+			return errors.New("click needs selector")
+		}
+	case "wait_for":
+		if step.Selector == "" && step.URLContains == "" {
+			return errors.New("wait_for needs selector or url_contains")
+		}
+	default:
+		return fmt.Errorf("what must be navigate, type, click, or wait_for, got %q", step.What)
+	}
+	return nil
+}
+
+// checkOrigins verifies that every navigate URL selected by include shares the first one's origin.
+func checkOrigins(steps []Step, include func(url string) bool) error {
+	first := ""
+	for i, step := range steps {
+		if step.What != "navigate" || !include(step.URL) {
+			continue
+		}
+		origin, err := statevault.NormalizeOrigin(step.URL)
+		if err != nil {
+			return fmt.Errorf("step[%d]: %w", i, err)
+		}
+		if first == "" {
+			first = origin
+		} else if origin != first {
+			return fmt.Errorf("step[%d]: navigate leaves %s for %s; a recipe stays on one origin", i, first, origin)
+		}
+	}
+	return nil
+}
+
+// Origin returns the origin of the first navigate step, or "" while its URL has placeholders.
+func (r Recipe) Origin() string {
+	if len(r.Steps) == 0 || placeholderPattern.MatchString(r.Steps[0].URL) {
+		return ""
+	}
+	origin, _ := statevault.NormalizeOrigin(r.Steps[0].URL)
+	return origin
+}
+
+// Summary returns the listing form of r.
+func (r Recipe) Summary() Summary {
+	vars := map[string]bool{}
+	secretEnv := []string{}
+	for _, step := range r.Steps {
+		for _, field := range []string{step.URL, step.Selector, step.Text, step.URLContains} {
+			for _, match := range placeholderPattern.FindAllStringSubmatch(field, -1) {
+				vars[match[1]] = true
+			}
+		}
+		if step.TextEnv != "" {
+			secretEnv = append(secretEnv, step.TextEnv)
+		}
+	}
+	names := make([]string, 0, len(vars))
+	for name := range vars {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return Summary{
+		Name:        r.Name,
+		Description: r.Description,
+		Origin:      r.Origin(),
+		StepCount:   len(r.Steps),
+		Vars:        names,
+		SecretEnv:   secretEnv,
+		SavedAt:     r.SavedAt,
+	}
+}
+
+// Expand fills placeholders from vars, falling back to the recipe's defaults, and text_env
+// from getenv. It returns the steps as interact arguments and the recipe's origin.
+func (r Recipe) Expand(vars map[string]string, getenv func(string) string) ([]Resolved, string, error) {
+	var missing []string
+	fill := func(s string) string {
+		return placeholderPattern.ReplaceAllStringFunc(s, func(m string) string {
+			name := placeholderPattern.FindStringSubmatch(m)[1]
+			if v, ok := vars[name]; ok {
+				return v
+			}
+			if v, ok := r.Vars[name]; ok {
+				return v
+			}
+			if !slices.Contains(missing, name) {
+				missing = append(missing, name)
+			}
+			return m
+		})
+	}
+
+	expanded := make([]Step, len(r.Steps))
+	for i, step := range r.Steps {
+		step.URL, step.Selector, step.Text, step.URLContains = fill(step.URL), fill(step.Selector), fill(step.Text), fill(step.URLContains)
+		expanded[i] = step
+	}
+	if len(missing) > 0 {
+		return nil, "", fmt.Errorf("missing vars: %s", strings.Join(missing, ", "))
+	}
+	if err := checkOrigins(expanded, func(string) bool { return true }); err != nil {
+		return nil, "", err
+	}
+	origin, err := statevault.NormalizeOrigin(expanded[0].URL)
+	if err != nil {
+		return nil, "", err
+	}
+
+	resolved := make([]Resolved, 0, len(expanded))
+	for i, step := range expanded {
+		args := map[string]any{"what": step.What}
+		secret := false
+		switch step.What {
+		case "navigate":
+			args["url"] = step.URL
+		case "type":
+			text := step.Text
+			if step.TextEnv != "" {
+				text = getenv(step.TextEnv)
+				if text == "" {
+					return nil, "", fmt.Errorf("step[%d]: environment variable %s is not set in the daemon", i, step.TextEnv)
+				}
+				secret = true
+			}
+			args["selector"], args["text"], args["clear"] = step.Selector, text, true
+		case "click":
+			args["selector"] = step.Selector
+		case "wait_for":
+			if step.Selector != "" {
+				args["selector"] = step.Selector
+			}
+			if step.URLContains != "" {
+				args["url_contains"] = step.URLContains
+			}
+			if step.TimeoutMs > 0 {
+				args["timeout_ms"] = step.TimeoutMs
+			}
+		}
+		resolved = append(resolved, Resolved{What: step.What, Args: args, Secret: secret})
+	}
+	return resolved, origin, nil
+}
+
+// Save validates and stores recipe, replacing any recipe of the same name.
+func Save(store Store, recipe Recipe) error {
+	if err := Validate(recipe); err != nil {
+		return err
+	}
+	data, err := json.Marshal(recipe)
+	if err != nil {
+		return err
+	}
+	return store.Save(Namespace, recipe.Name, data)
+}
+
+// Load reads the recipe called name.
+func Load(store Store, name string) (Recipe, error) {
+	if statevault.ValidateName(name) != nil {
+		return Recipe{}, ErrNotFound
+	}
+	data, err := store.Load(Namespace, name)
+	if err != nil || data == nil {
+		return Recipe{}, ErrNotFound
+	}
+	var recipe Recipe
+	if err := json.Unmarshal(data, &recipe); err != nil {
+		return Recipe{}, fmt.Errorf("recipe %s is unreadable: %w", name, err)
+	}
+	return recipe, nil
+}
+
+// List returns every stored recipe, sorted by name. Unreadable entries are skipped.
+func List(store Store) []Recipe {
+	keys, _ := store.List(Namespace)
+	sort.Strings(keys)
+	recipes := make([]Recipe, 0, len(keys))
+	for _, key := range keys {
+		if recipe, err := Load(store, key); err == nil {
+			recipes = append(recipes, recipe)
+		}
+	}
+	return recipes
+}
+
+// Delete removes the recipe called name.
+func Delete(store Store, name string) error {
+	if _, err := Load(store, name); err != nil {
+		return err
+	}
+	return store.Delete(Namespace, name)
+}
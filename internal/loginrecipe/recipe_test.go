@@ -0,0 +1,151 @@
+// Purpose: Tests recipe validation, placeholder and environment expansion, and storage for login recipes.
+// Docs: docs/features/feature/login-recipes/index.md
+
+package loginrecipe
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+type memStore map[string][]byte
+
+func (m memStore) Save(ns, key string, data []byte) error {
+	m[ns+"/"+key] = append([]byte(nil), data...)
+	return nil
+}
+
+func (m memStore) Load(ns, key string) ([]byte, error) {
+	data, ok := m[ns+"/"+key]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return data, nil
+}
+
+func (m memStore) List(ns string) ([]string, error) {
+	var keys []string
+	for k := range m {
+		if key, ok := strings.CutPrefix(k, ns+"/"); ok {
+			keys = append(keys, key)
+		}
+	}
+	return keys, nil
+}
+
+func (m memStore) Delete(ns, key string) error {
+	delete(m, ns+"/"+key)
+	return nil
+}
+
+func stagingAdmin() Recipe {
+	return Recipe{
+		Name: "staging-admin",
+		Vars: map[string]string{"user": "admin@example.com"},
+		Steps: []Step{
+			{What: "navigate", URL: "https://staging.example.com/login"},
+			{What: "type", Selector: "#email", Text: "{{user}}"},
+			{What: "type", Selector: "#password", TextEnv: "KABOOM_LOGIN_STAGING_PASS"},
+			{What: "click", Selector: "button[type=submit]"},
+			{What: "wait_for", Selector: "#dashboard", TimeoutMs: 15000},
+		},
+	}
+}
+
+func TestExpand_FillsVarsAndSecrets(t *testing.T) {
+	env := map[string]string{"KABOOM_LOGIN_STAGING_PASS": "hunter2"}
+	steps, origin, err := stagingAdmin().Expand(map[string]string{"user": "qa@example.com"}, func(k string) string { return env[k] })
+	if err != nil {
+		t.Fatalf("Expand: %v", err)
+	}
+	if origin != "https://staging.example.com" {
+		t.Fatalf("origin = %q", origin)
+	}
+	if steps[1].Args["text"] != "qa@example.com" || steps[1].Secret {
+		t.Fatalf("user step = %+v, want the vars override and not secret", steps[1])
+	}
+	if steps[2].Args["text"] != "hunter2" || !steps[2].Secret || steps[2].Args["clear"] != true {
+		t.Fatalf("password step = %+v, want the env value marked secret", steps[2])
+	}
+	if steps[4].Args["timeout_ms"] != 15000 {
+		t.Fatalf("wait_for args = %v", steps[4].Args)
+	}
+
+	steps, _, err = stagingAdmin().Expand(nil, func(k string) string { return env[k] })
+	if err != nil || steps[1].Args["text"] != "admin@example.com" {
+		t.Fatalf("default var: steps[1] = %+v, err = %v", steps[1], err)
+	}
+}
+
+func TestExpand_Errors(t *testing.T) {
+	if _, _, err := stagingAdmin().Expand(nil, func(string) string { return "" }); err == nil || !strings.Contains(err.Error(), "KABOOM_LOGIN_STAGING_PASS") {
+		t.Fatalf("unset env: err = %v", err)
+	}
+
+	recipe := stagingAdmin()
+	recipe.Vars = nil
+	recipe.Steps[0].URL = "https://{{host}}/login"
+	if _, _, err := recipe.Expand(nil, func(string) string { return "x" }); err == nil || err.Error() != "missing vars: host, user" {
+		t.Fatalf("missing vars: err = %v", err)
+	}
+
+	recipe = stagingAdmin()
+	recipe.Steps = append(recipe.Steps, Step{What: "navigate", URL: "https://{{host}}/"})
+	if _, _, err := recipe.Expand(map[string]string{"host": "evil.test"}, func(string) string { return "x" }); err == nil || !strings.Contains(err.Error(), "one origin") {
+		t.Fatalf("origin change: err = %v", err)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	if err := Validate(stagingAdmin()); err != nil {
+		t.Fatalf("valid recipe: %v", err)
+	}
+	cases := map[string]func(*Recipe){
+		"bad name":          func(r *Recipe) { r.Name = "../x" },
+		"no steps":          func(r *Recipe) { r.Steps = nil },
+		"starts with click": func(r *Recipe) { r.Steps = r.Steps[3:] },
+		"unknown step":      func(r *Recipe) { r.Steps[3].What = "execute_js" },
+		"text and env":      func(r *Recipe) { r.Steps[2].Text = "x" },
+		"env prefix":        func(r *Recipe) { r.Steps[2].TextEnv = "AWS_SECRET_ACCESS_KEY" },
+		"wait without goal": func(r *Recipe) { r.Steps[4].Selector = "" },
+		"second origin":     func(r *Recipe) { r.Steps = append(r.Steps, Step{What: "navigate", URL: "https://other.test/"}) },
+		"bad ttl":           func(r *Recipe) { r.TTL = "10s" },
+		"bad var name":      func(r *Recipe) { r.Vars = map[string]string{"a-b": "x"} },
+	}
+	for name, mutate := range cases {
+		recipe := stagingAdmin()
+		mutate(&recipe)
+		if err := Validate(recipe); err == nil {
+			t.Errorf("%s: Validate accepted the recipe", name)
+		}
+	}
+}
+
+func TestStore_SaveLoadListDelete(t *testing.T) {
+	store := memStore{}
+	if err := Save(store, stagingAdmin()); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	recipe, err := Load(store, "staging-admin")
+	if err != nil || len(recipe.Steps) != 5 {
+		t.Fatalf("Load = %+v, %v", recipe, err)
+	}
+	summary := recipe.Summary()
+	if summary.Origin != "https://staging.example.com" || summary.StepCount != 5 ||
+		strings.Join(summary.Vars, ",") != "user" || strings.Join(summary.SecretEnv, ",") != "KABOOM_LOGIN_STAGING_PASS" {
+		t.Fatalf("Summary = %+v", summary)
+	}
+	if got := List(store); len(got) != 1 || got[0].Name != "staging-admin" {
+		t.Fatalf("List = %+v", got)
+	}
+	if err := Delete(store, "staging-admin"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := Load(store, "staging-admin"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Load after delete: %v", err)
+	}
+	if err := Delete(store, "staging-admin"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Delete missing: %v", err)
+	}
+}
@@ -0,0 +1,181 @@
+// Purpose: Converts captured browser error log entries into LSP diagnostics keyed by workspace file URI.
+// Why: Picks the first application frame that lands in the workspace so the editor underlines the throwing line.
+// Docs: docs/features/feature/code-navigation-modification/index.md
+
+package lspdiag
+
+import (
+	"bufio"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/analysis"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/types"
+)
+
+// DiagnosticSource is the "source" label editors show next to each diagnostic.
+const DiagnosticSource = "kaboom"
+
+// maxDiagnosticsPerFile bounds how many distinct runtime errors are published for one file.
+const maxDiagnosticsPerFile = 100
+
+// Location is a resolved 1-based workspace position.
+type Location struct {
+	Path   string
+	Line   int
+	Column int
+}
+
+// ResolveEntry finds the workspace location an error entry points at: the first non-framework
+// stack frame that resolves into a root, falling back to the entry's own source/line/column.
+func ResolveEntry(entry types.LogEntry, resolver *Resolver) (Location, bool) {
+	stack, _ := entry["stack"].(string)
+	for _, frame := range analysis.ParseStack(stack) {
+		if frame.Line <= 0 || frame.IsFramework {
+			continue
+		}
+		if path := resolver.Resolve(frame.File); path != "" {
+			return Location{Path: path, Line: frame.Line, Column: frame.Column}, true
+		}
+	}
+	source, _ := entry["source"].(string)
+	line := intField(entry["line"])
+	if source != "" && line > 0 {
+		if path := resolver.Resolve(source); path != "" {
+			return Location{Path: path, Line: line, Column: intField(entry["column"])}, true
+		}
+	}
+	return Location{}, false
+}
+
+// Build converts error-level entries into diagnostics keyed by file URI. Repeated errors at
+// the same position collapse into one diagnostic with an occurrence count. isNoise, when
+// non-nil, drops entries the noise filter suppresses.
+func Build(entries []types.LogEntry, resolver *Resolver, isNoise func(types.LogEntry) bool) map[string][]Diagnostic {
+	type key struct {
+		path         string
+		line, column int
+		message      string
+	}
+	counts := map[key]int{}
+	var order []key
+	for _, entry := range entries {
+		if level, _ := entry["level"].(string); level != "error" {
+			continue
+		}
+		if isNoise != nil && isNoise(entry) {
+			continue
+		}
+		loc, ok := ResolveEntry(entry, resolver)
+		if !ok {
+			continue
+		}
+		k := key{path: loc.Path, line: loc.Line, column: loc.Column, message: entryMessage(entry)}
+		if counts[k] == 0 {
+			order = append(order, k)
+		}
+		counts[k]++
+	}
+
+	lines := lineLengths{}
+	byURI := map[string][]Diagnostic{}
+	for _, k := range order {
+		uri := FileURI(k.path)
+		if len(byURI[uri]) >= maxDiagnosticsPerFile {
+			continue
+		}
+		message := k.message
+		if n := counts[k]; n > 1 {
+			message = fmt.Sprintf("%s (seen %d times in the browser)", message, n)
+		}
+		start := Position{Line: k.line - 1, Character: max(k.column-1, 0)}
+		end := Position{Line: start.Line, Character: start.Character + 1}
+		if length, ok := lines.get(k.path, k.line); ok && length > start.Character {
+			end.Character = length
+		}
+		byURI[uri] = append(byURI[uri], Diagnostic{
+			Range:    Range{Start: start, End: end},
+			Severity: SeverityError,
+			Code:     "runtime-error",
+			Source:   DiagnosticSource,
+			Message:  message,
+		})
+	}
+	for _, diags := range byURI {
+		sort.SliceStable(diags, func(i, j int) bool {
+			if diags[i].Range.Start.Line != diags[j].Range.Start.Line {
+				return diags[i].Range.Start.Line < diags[j].Range.Start.Line
+			}
+			return diags[i].Range.Start.Character < diags[j].Range.Start.Character
+		})
+	}
+	return byURI
+}
+
+// FileURI converts an absolute path into a file:// URI.
+func FileURI(path string) string {
+	slashed := filepath.ToSlash(path)
+	if !strings.HasPrefix(slashed, "/") {
+		slashed = "/" + slashed // Windows drive paths: C:/x → /C:/x
+	}
+	return (&url.URL{Scheme: "file", Path: slashed}).String()
+}
+
+func entryMessage(entry types.LogEntry) string {
+	msg, _ := entry["message"].(string)
+	if i := strings.IndexByte(msg, '\n'); i >= 0 {
+		msg = msg[:i]
+	}
+	msg = strings.TrimSpace(msg)
+	if msg == "" {
+		msg = "Uncaught error"
+	}
+	return msg
+}
+
+func intField(v any) int {
+	switch n := v.(type) {
+	case float64:
+		return int(n)
+	case int:
+		return n
+	case int64:
+		return int(n)
+	}
+	return 0
+}
+
+// lineLengths lazily reads files to find the length of a 1-based line, so the diagnostic
+// range can span from the throwing column to the end of the line.
+type lineLengths map[string][]int
+
+func (l lineLengths) get(path string, line int) (int, bool) {
+	lengths, ok := l[path]
+	if !ok {
+		lengths = readLineLengths(path)
+		l[path] = lengths
+	}
+	if line < 1 || line > len(lengths) {
+		return 0, false
+	}
+	return lengths[line-1], true
+}
+
+func readLineLengths(path string) []int {
+	f, err := os.Open(path) // #nosec G304 -- path was resolved inside a workspace root
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+	var lengths []int
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+	for scanner.Scan() {
+		lengths = append(lengths, len([]rune(scanner.Text())))
+	}
+	return lengths
+}
@@ -0,0 +1,95 @@
+// Purpose: Tests workspace resolution of frame sources and conversion of error entries into LSP diagnostics.
+// Docs: docs/features/feature/code-navigation-modification/index.md
+
+package lspdiag
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/types"
+)
+
+func writeWorkspaceFile(t *testing.T, root, rel, content string) string {
+	t.Helper()
+	path := filepath.Join(root, filepath.FromSlash(rel))
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestResolver_Resolve(t *testing.T) {
+	t.Parallel()
+	root := t.TempDir()
+	app := writeWorkspaceFile(t, root, "src/components/Cart.tsx", "x\n")
+	writeWorkspaceFile(t, root, "main.js", "x\n")
+
+	r := NewResolver([]string{root, "", root, string(filepath.Separator)})
+	if len(r.Roots()) != 1 {
+		t.Fatalf("roots should be deduplicated: %v", r.Roots())
+	}
+	cases := map[string]string{
+		"webpack://my-app/./src/components/Cart.tsx":         app,
+		"webpack:///src/components/Cart.tsx":                 app,
+		"http://localhost:5173/src/components/Cart.tsx?t=12": app,
+		"https://app.test/static/src/components/Cart.tsx":    app,
+		"./src/components/Cart.tsx":                          app,
+		"/@fs" + filepath.ToSlash(app):                       app,
+		"file://" + filepath.ToSlash(app):                    app,
+		"http://localhost:3000/assets/main.js":               "",
+		"webpack://my-app/./node_modules/react/index.js":     "",
+		"src/missing.ts":                                     "",
+		"<anonymous>":                                        "",
+		"/@fs/etc/passwd":                                    "",
+	}
+	for source, want := range cases {
+		if got := r.Resolve(source); got != want {
+			t.Errorf("Resolve(%q) = %q, want %q", source, got, want)
+		}
+	}
+}
+
+func TestBuild_PrefersFirstWorkspaceFrameAndCollapsesRepeats(t *testing.T) {
+	t.Parallel()
+	root := t.TempDir()
+	cart := writeWorkspaceFile(t, root, "src/cart.ts", "line one\n  total += item.price\n")
+	stack := "TypeError: Cannot read properties of undefined (reading 'price')\n" +
+		"    at Object.render (http://localhost:3000/node_modules/react/cjs/react.js:10:2)\n" +
+		"    at sumCart (webpack://shop/./src/cart.ts:2:11) [resolved from http://localhost:3000/bundle.js:1:900]\n" +
+		"    at onClick (webpack://shop/./src/other.ts:9:1)"
+	errEntry := types.LogEntry{"level": "error", "message": "TypeError: Cannot read properties of undefined (reading 'price')\nmore", "stack": stack}
+	entries := []types.LogEntry{
+		errEntry,
+		errEntry,
+		{"level": "warn", "message": "ignored", "source": "http://localhost:3000/src/cart.ts", "line": float64(1)},
+		{"level": "error", "message": "ReferenceError: x is not defined", "source": "http://localhost:3000/src/cart.ts", "line": float64(1), "column": float64(3)},
+		{"level": "error", "message": "noise", "source": "http://localhost:3000/src/cart.ts", "line": float64(1)},
+		{"level": "error", "message": "elsewhere", "source": "https://cdn.test/lib.js", "line": float64(4)},
+	}
+	isNoise := func(e types.LogEntry) bool { return e["message"] == "noise" }
+
+	got := Build(entries, NewResolver([]string{root}), isNoise)
+	if len(got) != 1 {
+		t.Fatalf("expected diagnostics for one file, got %v", got)
+	}
+	diags := got[FileURI(cart)]
+	if len(diags) != 2 {
+		t.Fatalf("diagnostics = %+v", diags)
+	}
+	first, second := diags[0], diags[1]
+	if first.Message != "ReferenceError: x is not defined" || first.Range.Start != (Position{Line: 0, Character: 2}) || first.Range.End.Character != 8 {
+		t.Errorf("first = %+v", first)
+	}
+	wantMsg := "TypeError: Cannot read properties of undefined (reading 'price') (seen 2 times in the browser)"
+	if second.Message != wantMsg || second.Range.Start != (Position{Line: 1, Character: 10}) || second.Range.End.Character != 21 {
+		t.Errorf("second = %+v", second)
+	}
+	if second.Severity != SeverityError || second.Source != DiagnosticSource {
+		t.Errorf("severity/source = %d/%s", second.Severity, second.Source)
+	}
+}
@@ -0,0 +1,19 @@
+// Purpose: Live-runtime diagnostics provider that speaks the Language Server Protocol.
+// Why: Editors already know how to underline LSP diagnostics; mapping browser errors onto that contract needs no plugin.
+// Docs: docs/features/feature/code-navigation-modification/index.md
+
+/*
+Package lspdiag turns captured browser errors into LSP textDocument/publishDiagnostics
+notifications for files in the local workspace.
+
+Errors are resolved to workspace files from their (source-mapped) stack frames and the
+error's source URL, relative to the workspace roots the daemon knows about (registered
+client CWDs and the active codebase). The daemon serves the feed on a loopback TCP socket
+using standard LSP framing, so any editor with a generic LSP client can connect.
+
+Key functions:
+  - Build: converts error log entries into diagnostics keyed by file URI.
+  - Resolver.Resolve: maps a bundler/dev-server source path onto a workspace file.
+  - Server: accepts LSP connections and pushes diagnostics as they change.
+*/
+package lspdiag
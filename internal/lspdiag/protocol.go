@@ -0,0 +1,104 @@
+// Purpose: LSP wire types and base-protocol (Content-Length) message framing.
+// Why: The feed only needs a handful of LSP structures; declaring them here keeps the package dependency-free.
+// Docs: docs/features/feature/code-navigation-modification/index.md
+
+package lspdiag
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/textproto"
+	"strconv"
+	"strings"
+)
+
+// maxMessageSize bounds a single inbound LSP message. Clients only send small control messages.
+const maxMessageSize = 1 << 20
+
+// LSP DiagnosticSeverity values.
+const (
+	SeverityError   = 1
+	SeverityWarning = 2
+)
+
+// Position is a zero-based line/character offset (LSP Position).
+type Position struct {
+	Line      int `json:"line"`
+	Character int `json:"character"`
+}
+
+// Range is a start/end pair of positions (LSP Range).
+type Range struct {
+	Start Position `json:"start"`
+	End   Position `json:"end"`
+}
+
+// Diagnostic is an LSP Diagnostic.
+type Diagnostic struct {
+	Range    Range  `json:"range"`
+	Severity int    `json:"severity"`
+	Code     string `json:"code,omitempty"`
+	Source   string `json:"source"`
+	Message  string `json:"message"`
+}
+
+// PublishDiagnosticsParams is the payload of textDocument/publishDiagnostics.
+type PublishDiagnosticsParams struct {
+	URI         string       `json:"uri"`
+	Diagnostics []Diagnostic `json:"diagnostics"`
+}
+
+// message is a JSON-RPC 2.0 request, response, or notification.
+type message struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method,omitempty"`
+	Params  json.RawMessage `json:"params,omitempty"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *responseError  `json:"error,omitempty"`
+}
+
+type responseError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// readMessage reads one Content-Length framed message.
+func readMessage(r *bufio.Reader) (message, error) {
+	headers, err := textproto.NewReader(r).ReadMIMEHeader()
+	if err != nil {
+		return message{}, err
+	}
+	length, err := strconv.Atoi(strings.TrimSpace(headers.Get("Content-Length")))
+	if err != nil || length < 0 {
+		return message{}, fmt.Errorf("invalid Content-Length %q", headers.Get("Content-Length"))
+	}
+	if length > maxMessageSize {
+		return message{}, fmt.Errorf("message of %d bytes exceeds %d byte limit", length, maxMessageSize)
+	}
+	body := make([]byte, length)
+	if _, err := io.ReadFull(r, body); err != nil {
+		return message{}, err
+	}
+	var msg message
+	if err := json.Unmarshal(body, &msg); err != nil {
+		return message{}, fmt.Errorf("parse message: %w", err)
+	}
+	return msg, nil
+}
+
+// writeMessage writes one Content-Length framed message.
+func writeMessage(w io.Writer, msg message) error {
+	msg.JSONRPC = "2.0"
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(w, "Content-Length: %d\r\n\r\n", len(body)); err != nil {
+		return err
+	}
+	_, err = w.Write(body)
+	return err
+}
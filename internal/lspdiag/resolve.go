@@ -0,0 +1,146 @@
+// Purpose: Maps bundler and dev-server source paths from stack frames onto files in the local workspace.
+// Why: Source-mapped frames name files as webpack://, /@fs/, or served URLs; editors need absolute paths.
+// Docs: docs/features/feature/code-navigation-modification/index.md
+
+package lspdiag
+
+import (
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Resolver maps frame sources to workspace files under a fixed set of roots.
+// Results are memoized, so a Resolver should live for one build pass.
+type Resolver struct {
+	roots []string
+	cache map[string]string
+}
+
+// NewResolver returns a Resolver for the given workspace roots. Empty and duplicate roots are
+// dropped, as is a filesystem root, which would let any absolute path resolve.
+func NewResolver(roots []string) *Resolver {
+	seen := map[string]bool{}
+	var clean []string
+	for _, root := range roots {
+		if root == "" {
+			continue
+		}
+		abs, err := filepath.Abs(root)
+		if err != nil || seen[abs] || filepath.Dir(abs) == abs {
+			continue
+		}
+		seen[abs] = true
+		clean = append(clean, abs)
+	}
+	return &Resolver{roots: clean, cache: map[string]string{}}
+}
+
+// Roots returns the normalized workspace roots.
+func (r *Resolver) Roots() []string {
+	return r.roots
+}
+
+// Resolve returns the absolute workspace path for a frame source, or "" when the source
+// is not a file inside any root. Dependencies under node_modules are never resolved.
+func (r *Resolver) Resolve(source string) string {
+	if path, ok := r.cache[source]; ok {
+		return path
+	}
+	path := r.resolve(source)
+	r.cache[source] = path
+	return path
+}
+
+func (r *Resolver) resolve(source string) string {
+	if len(r.roots) == 0 {
+		return ""
+	}
+	candidate := sourcePath(source)
+	if candidate == "" || strings.Contains(candidate, "node_modules/") {
+		return ""
+	}
+
+	// Absolute paths (file://, vite /@fs/) are accepted only when they sit inside a root.
+	if filepath.IsAbs(candidate) && isRegularFile(candidate) {
+		for _, root := range r.roots {
+			if isWithin(root, candidate) {
+				return candidate
+			}
+		}
+	}
+
+	// Relative or served paths: try the path under each root, then drop leading segments
+	// (e.g. a "static/" mount prefix or a webpack namespace) until something matches. A bare
+	// file name is only tried when it was the whole path, so a served /assets/main.js never
+	// matches an unrelated main.js at the workspace root.
+	segments := strings.Split(strings.TrimLeft(filepath.ToSlash(candidate), "/"), "/")
+	for start := 0; start < len(segments); start++ {
+		if start > 0 && len(segments)-start < 2 {
+			break
+		}
+		rel := filepath.FromSlash(strings.Join(segments[start:], "/"))
+		for _, root := range r.roots {
+			full := filepath.Join(root, rel)
+			if isWithin(root, full) && isRegularFile(full) {
+				return full
+			}
+		}
+	}
+	return ""
+}
+
+// sourcePath strips scheme, host, query, and bundler prefixes from a frame source.
+func sourcePath(source string) string {
+	source = strings.TrimSpace(source)
+	if source == "" || strings.HasPrefix(source, "<") {
+		return ""
+	}
+	switch {
+	case strings.HasPrefix(source, "webpack://"):
+		// webpack://<namespace>/./src/app.ts or webpack:///src/app.ts
+		rest := strings.TrimPrefix(source, "webpack://")
+		if i := strings.Index(rest, "/"); i >= 0 {
+			rest = rest[i:]
+		}
+		source = rest
+	case strings.HasPrefix(source, "file://"):
+		if u, err := url.Parse(source); err == nil {
+			return filepath.FromSlash(u.Path)
+		}
+	case strings.HasPrefix(source, "http://"), strings.HasPrefix(source, "https://"):
+		u, err := url.Parse(source)
+		if err != nil {
+			return ""
+		}
+		source = u.Path
+	}
+
+	if i := strings.IndexAny(source, "?#"); i >= 0 {
+		source = source[:i]
+	}
+	if rest, ok := strings.CutPrefix(source, "/@fs/"); ok {
+		// Vite serves files outside the project root as /@fs/<absolute path>.
+		return filepath.FromSlash("/" + rest)
+	}
+	source = strings.TrimPrefix(source, "/")
+	for strings.HasPrefix(source, "./") {
+		source = source[2:]
+	}
+	source = strings.TrimPrefix(source, "~/")
+	if source == "" {
+		return ""
+	}
+	return filepath.FromSlash(source)
+}
+
+func isWithin(root, path string) bool {
+	rel, err := filepath.Rel(root, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+func isRegularFile(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.Mode().IsRegular()
+}
@@ -0,0 +1,281 @@
+// Purpose: Loopback LSP server that pushes runtime-error diagnostics to connected editors.
+// Why: A push-only language server lets any generic LSP client show browser errors inline without a custom plugin.
+// Docs: docs/features/feature/code-navigation-modification/index.md
+
+package lspdiag
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"reflect"
+	"sync"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/util"
+)
+
+// PortOffset is the offset from the main daemon port for the LSP diagnostics socket.
+// The terminal server uses offset 1.
+const PortOffset = 2
+
+// Server accepts LSP connections and publishes the current diagnostics snapshot.
+//
+// Invariants:
+// - Only clients that finished the initialize/initialized handshake receive notifications.
+// - Files that drop out of the snapshot are published once with an empty list so editors clear them.
+type Server struct {
+	version string
+
+	mu       sync.Mutex
+	current  map[string][]Diagnostic
+	conns    map[*conn]struct{}
+	listener net.Listener
+	closed   bool
+}
+
+// NewServer returns a Server that reports the given version in serverInfo.
+func NewServer(version string) *Server {
+	return &Server{
+		version: version,
+		current: map[string][]Diagnostic{},
+		conns:   map[*conn]struct{}{},
+	}
+}
+
+// Listen binds 127.0.0.1:port and serves in the background. The returned channel
+// closes when the listener stops.
+func (s *Server) Listen(port int) (<-chan struct{}, error) {
+	ln, err := net.Listen("tcp", fmt.Sprintf("127.0.0.1:%d", port))
+	if err != nil {
+		return nil, err
+	}
+	done := make(chan struct{})
+	util.SafeGo(func() {
+		defer close(done)
+		_ = s.Serve(ln)
+	})
+	return done, nil
+}
+
+// Serve accepts connections on ln until Close is called or the listener fails.
+func (s *Server) Serve(ln net.Listener) error {
+	if !s.setListener(ln) {
+		return ln.Close()
+	}
+
+	for {
+		nc, err := ln.Accept()
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			return err
+		}
+		c := &conn{server: s, rw: nc}
+		s.add(c)
+		util.SafeGo(func() { c.serve() })
+	}
+}
+
+// ServeConn runs the LSP protocol on an already-established stream (stdio, pipes, tests).
+// It blocks until the client exits or the stream closes.
+func (s *Server) ServeConn(rw io.ReadWriteCloser) {
+	c := &conn{server: s, rw: rw}
+	s.add(c)
+	c.serve()
+}
+
+// Update replaces the diagnostics snapshot and pushes changed files to ready clients.
+func (s *Server) Update(next map[string][]Diagnostic) {
+	changed, conns := s.replaceSnapshot(next)
+	for _, c := range conns {
+		for _, p := range changed {
+			c.notify("textDocument/publishDiagnostics", p)
+		}
+	}
+}
+
+// replaceSnapshot stores next and returns the changed files and the clients to notify.
+// Notifications are written after the lock is released so a slow client cannot stall Update.
+func (s *Server) replaceSnapshot(next map[string][]Diagnostic) ([]PublishDiagnosticsParams, []*conn) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var changed []PublishDiagnosticsParams
+	for uri, diags := range next {
+		if !reflect.DeepEqual(s.current[uri], diags) {
+			changed = append(changed, PublishDiagnosticsParams{URI: uri, Diagnostics: diags})
+		}
+	}
+	for uri := range s.current {
+		if _, ok := next[uri]; !ok {
+			changed = append(changed, PublishDiagnosticsParams{URI: uri, Diagnostics: []Diagnostic{}})
+		}
+	}
+	s.current = next
+	return changed, s.readyConnsLocked()
+}
+
+// ClientCount returns the number of connected clients.
+func (s *Server) ClientCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.conns)
+}
+
+// Close stops accepting connections and disconnects every client.
+func (s *Server) Close() error {
+	ln, conns := s.markClosed()
+	var err error
+	if ln != nil {
+		err = ln.Close()
+	}
+	for _, c := range conns {
+		_ = c.rw.Close()
+	}
+	return err
+}
+
+// markClosed flags the server closed and returns the listener and clients to shut down.
+func (s *Server) markClosed() (net.Listener, []*conn) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
+	conns := make([]*conn, 0, len(s.conns))
+	for c := range s.conns {
+		conns = append(conns, c)
+	}
+	return s.listener, conns
+}
+
+// setListener records ln for Close, or reports false when the server is already closed.
+func (s *Server) setListener(ln net.Listener) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return false
+	}
+	s.listener = ln
+	return true
+}
+
+func (s *Server) add(c *conn) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.conns[c] = struct{}{}
+}
+
+func (s *Server) readyConnsLocked() []*conn {
+	var ready []*conn
+	for c := range s.conns {
+		if c.isReady() {
+			ready = append(ready, c)
+		}
+	}
+	return ready
+}
+
+func (s *Server) snapshot() []PublishDiagnosticsParams {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make([]PublishDiagnosticsParams, 0, len(s.current))
+	for uri, diags := range s.current {
+		out = append(out, PublishDiagnosticsParams{URI: uri, Diagnostics: diags})
+	}
+	return out
+}
+
+func (s *Server) remove(c *conn) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.conns, c)
+}
+
+// conn is one LSP client connection.
+type conn struct {
+	server *Server
+	rw     io.ReadWriteCloser
+
+	writeMu sync.Mutex
+	ready   bool
+}
+
+func (c *conn) setReady() {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	c.ready = true
+}
+
+func (c *conn) isReady() bool {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	return c.ready
+}
+
+func (c *conn) serve() {
+	defer c.server.remove(c)
+	defer c.rw.Close()
+
+	r := bufio.NewReader(c.rw)
+	for {
+		msg, err := readMessage(r)
+		if err != nil {
+			return
+		}
+		if !c.handle(msg) {
+			return
+		}
+	}
+}
+
+// handle processes one inbound message and reports whether the connection stays open.
+func (c *conn) handle(msg message) bool {
+	isRequest := len(msg.ID) > 0
+	switch msg.Method {
+	case "initialize":
+		c.respond(msg.ID, map[string]any{
+			"capabilities": map[string]any{
+				// Diagnostics are pushed; the server does not track open documents.
+				"textDocumentSync": 0,
+			},
+			"serverInfo": map[string]any{"name": DiagnosticSource, "version": c.server.version},
+		})
+	case "initialized":
+		c.setReady()
+		for _, p := range c.server.snapshot() {
+			c.notify("textDocument/publishDiagnostics", p)
+		}
+	case "shutdown":
+		c.respond(msg.ID, nil)
+	case "exit":
+		return false
+	default:
+		if isRequest {
+			c.write(message{ID: msg.ID, Error: &responseError{Code: -32601, Message: "Method not found: " + msg.Method}})
+		}
+		// Unknown notifications (didOpen, didChange, $/...) are ignored.
+	}
+	return true
+}
+
+func (c *conn) respond(id json.RawMessage, result any) {
+	// Error impossible: results are plain maps and nil
+	data, _ := json.Marshal(result)
+	c.write(message{ID: id, Result: data})
+}
+
+func (c *conn) notify(method string, params any) {
+	// Error impossible: params are plain structs
+	data, _ := json.Marshal(params)
+	c.write(message{Method: method, Params: data})
+}
+
+func (c *conn) write(msg message) {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	if err := writeMessage(c.rw, msg); err != nil {
+		_ = c.rw.Close()
+	}
+}
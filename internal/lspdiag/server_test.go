@@ -0,0 +1,131 @@
+// Purpose: Tests the LSP handshake, diagnostics push, clearing of resolved files, and shutdown/exit.
+// Docs: docs/features/feature/code-navigation-modification/index.md
+
+package lspdiag
+
+import (
+	"bufio"
+	"encoding/json"
+	"net"
+	"testing"
+	"time"
+)
+
+type testClient struct {
+	t    *testing.T
+	conn net.Conn
+	r    *bufio.Reader
+}
+
+func (c *testClient) send(msg message) {
+	c.t.Helper()
+	if err := writeMessage(c.conn, msg); err != nil {
+		c.t.Fatalf("write: %v", err)
+	}
+}
+
+func (c *testClient) recv() message {
+	c.t.Helper()
+	_ = c.conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	msg, err := readMessage(c.r)
+	if err != nil {
+		c.t.Fatalf("read: %v", err)
+	}
+	return msg
+}
+
+func (c *testClient) recvPublish() PublishDiagnosticsParams {
+	c.t.Helper()
+	msg := c.recv()
+	if msg.Method != "textDocument/publishDiagnostics" {
+		c.t.Fatalf("expected publishDiagnostics, got %+v", msg)
+	}
+	var p PublishDiagnosticsParams
+	if err := json.Unmarshal(msg.Params, &p); err != nil {
+		c.t.Fatalf("decode params: %v", err)
+	}
+	return p
+}
+
+func TestServer_HandshakePublishAndClear(t *testing.T) {
+	t.Parallel()
+	srv := NewServer("1.2.3")
+	diag := Diagnostic{Range: Range{Start: Position{Line: 4}, End: Position{Line: 4, Character: 9}}, Severity: SeverityError, Source: DiagnosticSource, Message: "boom"}
+	srv.Update(map[string][]Diagnostic{"file:///w/a.ts": {diag}})
+
+	serverSide, clientSide := net.Pipe()
+	done := make(chan struct{})
+	go func() {
+		srv.ServeConn(serverSide)
+		close(done)
+	}()
+	c := &testClient{t: t, conn: clientSide, r: bufio.NewReader(clientSide)}
+
+	c.send(message{ID: json.RawMessage(`1`), Method: "initialize", Params: json.RawMessage(`{"processId":null}`)})
+	init := c.recv()
+	var result struct {
+		ServerInfo struct{ Name, Version string } `json:"serverInfo"`
+	}
+	if err := json.Unmarshal(init.Result, &result); err != nil || string(init.ID) != "1" || result.ServerInfo.Version != "1.2.3" {
+		t.Fatalf("initialize result = %s (%v)", init.Result, err)
+	}
+
+	c.send(message{Method: "initialized", Params: json.RawMessage(`{}`)})
+	if p := c.recvPublish(); p.URI != "file:///w/a.ts" || len(p.Diagnostics) != 1 || p.Diagnostics[0].Message != "boom" {
+		t.Fatalf("initial publish = %+v", p)
+	}
+
+	go srv.Update(map[string][]Diagnostic{"file:///w/b.ts": {diag}})
+	seen := map[string]int{}
+	for i := 0; i < 2; i++ {
+		p := c.recvPublish()
+		seen[p.URI] = len(p.Diagnostics)
+	}
+	if n, ok := seen["file:///w/a.ts"]; !ok || n != 0 {
+		t.Errorf("a.ts should be cleared with an empty list: %v", seen)
+	}
+	if seen["file:///w/b.ts"] != 1 {
+		t.Errorf("b.ts should be published: %v", seen)
+	}
+
+	c.send(message{ID: json.RawMessage(`2`), Method: "textDocument/hover"})
+	if resp := c.recv(); resp.Error == nil || resp.Error.Code != -32601 {
+		t.Errorf("unknown request should be method-not-found: %+v", resp)
+	}
+
+	c.send(message{ID: json.RawMessage(`3`), Method: "shutdown"})
+	if resp := c.recv(); string(resp.ID) != "3" || string(resp.Result) != "null" {
+		t.Errorf("shutdown response = %+v", resp)
+	}
+	c.send(message{Method: "exit"})
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("server did not close the connection after exit")
+	}
+	if srv.ClientCount() != 0 {
+		t.Errorf("client count = %d after exit", srv.ClientCount())
+	}
+}
+
+func TestServer_ListenAcceptsTCPClients(t *testing.T) {
+	t.Parallel()
+	srv := NewServer("dev")
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go func() { _ = srv.Serve(ln) }()
+	t.Cleanup(func() { _ = srv.Close() })
+
+	nc, err := net.Dial("tcp", ln.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer nc.Close()
+	c := &testClient{t: t, conn: nc, r: bufio.NewReader(nc)}
+	c.send(message{ID: json.RawMessage(`"a"`), Method: "initialize"})
+	if resp := c.recv(); string(resp.ID) != `"a"` || resp.Result == nil {
+		t.Fatalf("initialize over TCP = %+v", resp)
+	}
+}
@@ -0,0 +1,75 @@
+// Purpose: Resolves a browser error to a local source file and reads the lines around the throwing line.
+// Why: observe(errors) can hand the agent an absolute path plus context instead of a served URL it must map by hand.
+// Docs: docs/features/feature/code-navigation-modification/index.md
+
+package lspdiag
+
+import (
+	"bufio"
+	"os"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/types"
+)
+
+// SnippetContext is the number of lines read on each side of the error line.
+const SnippetContext = 5
+
+// maxSnippetLineLen truncates long (e.g. minified) lines in a snippet.
+const maxSnippetLineLen = 240
+
+// SourceLink is an error location resolved to a workspace file, with surrounding source.
+type SourceLink struct {
+	Path    string        `json:"path"`
+	Line    int           `json:"line"`
+	Column  int           `json:"column,omitempty"`
+	Snippet []SnippetLine `json:"snippet,omitempty"`
+}
+
+// SnippetLine is one 1-based source line. Error marks the line the error points at.
+type SnippetLine struct {
+	Line  int    `json:"line"`
+	Text  string `json:"text"`
+	Error bool   `json:"error,omitempty"`
+}
+
+// Link resolves an error entry to a workspace file (see ResolveEntry) and attaches
+// SnippetContext lines on each side of the error line. The path is verified to exist
+// by the resolver; a line past the end of the file yields a link without a snippet.
+func Link(entry types.LogEntry, resolver *Resolver) (SourceLink, bool) {
+	loc, ok := ResolveEntry(entry, resolver)
+	if !ok {
+		return SourceLink{}, false
+	}
+	return SourceLink{
+		Path:    loc.Path,
+		Line:    loc.Line,
+		Column:  loc.Column,
+		Snippet: readSnippet(loc.Path, loc.Line, SnippetContext),
+	}, true
+}
+
+func readSnippet(path string, line, context int) []SnippetLine {
+	f, err := os.Open(path) // #nosec G304 -- path was resolved inside a workspace root
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+	first, last := max(line-context, 1), line+context
+	var out []SnippetLine
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+	for n := 1; n <= last && scanner.Scan(); n++ {
+		if n < first {
+			continue
+		}
+		text := []rune(scanner.Text())
+		if len(text) > maxSnippetLineLen {
+			text = append(text[:maxSnippetLineLen], '…')
+		}
+		out = append(out, SnippetLine{Line: n, Text: string(text), Error: n == line})
+	}
+	if len(out) == 0 || out[len(out)-1].Line < line {
+		return nil
+	}
+	return out
+}
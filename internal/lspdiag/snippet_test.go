@@ -0,0 +1,62 @@
+// Purpose: Tests resolving error entries to workspace files with surrounding source snippets.
+// Docs: docs/features/feature/code-navigation-modification/index.md
+
+package lspdiag
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/types"
+)
+
+func TestLink_SnippetAroundErrorLine(t *testing.T) {
+	t.Parallel()
+	root := t.TempDir()
+	var src strings.Builder
+	for i := 1; i <= 60; i++ {
+		fmt.Fprintf(&src, "line %d\n", i)
+	}
+	path := writeWorkspaceFile(t, root, "src/components/Login.tsx", src.String())
+
+	entry := types.LogEntry{
+		"level":   "error",
+		"message": "TypeError: x is undefined",
+		"stack":   "TypeError: x is undefined\n    at submit (http://localhost:5173/src/components/Login.tsx:42:7)",
+	}
+	link, ok := Link(entry, NewResolver([]string{root}))
+	if !ok {
+		t.Fatal("entry should resolve into the workspace")
+	}
+	if link.Path != path || link.Line != 42 || link.Column != 7 {
+		t.Fatalf("link = %+v", link)
+	}
+	if len(link.Snippet) != 2*SnippetContext+1 || link.Snippet[0].Line != 37 || link.Snippet[10].Line != 47 {
+		t.Fatalf("snippet should span lines 37-47: %+v", link.Snippet)
+	}
+	if mid := link.Snippet[SnippetContext]; !mid.Error || mid.Text != "line 42" {
+		t.Fatalf("error line = %+v", mid)
+	}
+}
+
+func TestLink_ClampsAtFileEdges(t *testing.T) {
+	t.Parallel()
+	root := t.TempDir()
+	writeWorkspaceFile(t, root, "src/app.js", "a\nb\nc\n")
+	resolver := NewResolver([]string{root})
+
+	link, ok := Link(types.LogEntry{"source": "http://localhost:3000/src/app.js", "line": float64(2)}, resolver)
+	if !ok || len(link.Snippet) != 3 || !link.Snippet[1].Error {
+		t.Fatalf("short file should yield every line: %+v", link)
+	}
+
+	link, ok = Link(types.LogEntry{"source": "http://localhost:3000/src/app.js", "line": float64(90)}, resolver)
+	if !ok || link.Snippet != nil {
+		t.Fatalf("line past EOF should resolve without a snippet: %+v", link)
+	}
+
+	if _, ok := Link(types.LogEntry{"source": "http://localhost:3000/src/missing.js", "line": float64(1)}, resolver); ok {
+		t.Fatal("missing file should not resolve")
+	}
+}
@@ -5,7 +5,10 @@ package mcp
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/queries"
 )
 
 // Error codes are self-describing snake_case strings.
@@ -19,17 +22,21 @@ const (
 	ErrPathNotAllowed = "path_not_allowed"
 
 	// State errors — LLM must change state before retrying
-	ErrNotInitialized       = "not_initialized"
-	ErrNoData               = "no_data"
-	ErrCodePilotDisabled    = "pilot_disabled"
-	ErrOsAutomationDisabled = "os_automation_disabled"
-	ErrRateLimited          = "rate_limited"
-	ErrCursorExpired        = "cursor_expired"
+	ErrNotInitialized         = "not_initialized"
+	ErrNoData                 = "no_data"
+	ErrCodePilotDisabled      = "pilot_disabled"
+	ErrOsAutomationDisabled   = "os_automation_disabled"
+	ErrCDPPassthroughDisabled = "cdp_passthrough_disabled"
+	ErrRateLimited            = "rate_limited"
+	ErrCursorExpired          = "cursor_expired"
+	ErrReadOnlyMode           = "read_only_mode_enabled"
 
 	// Communication errors — retry with backoff
-	ErrExtTimeout = "extension_timeout"
-	ErrExtError   = "extension_error"
-	ErrQueueFull  = "queue_full"
+	ErrExtTimeout      = "extension_timeout"
+	ErrExtError        = "extension_error"
+	ErrExtDisconnected = "extension_disconnected"
+	ErrExtUnreachable  = "extension_unreachable"
+	ErrQueueFull       = "queue_full"
 
 	// Internal errors — do not retry
 	ErrInternal      = "internal_error"
@@ -52,6 +59,10 @@ type StructuredError struct {
 	Hint         string `json:"hint,omitempty"`
 	Action       string `json:"action,omitempty"`
 	Selector     string `json:"selector,omitempty"`
+	// LastSeen is when the extension last synced (RFC3339), on extension_disconnected errors.
+	LastSeen string `json:"last_seen,omitempty"`
+	// Diagnostics carries delivery evidence (attempts, last poll, transport) on extension_unreachable errors.
+	Diagnostics map[string]any `json:"diagnostics,omitempty"`
 
 	// RecoveryToolCall is a copy-pasteable MCP tool call the LLM can use to recover.
 	// Keys: "tool" (string), "arguments" (map[string]any).
@@ -109,6 +120,16 @@ func WithSelector(s string) func(*StructuredError) {
 	return func(se *StructuredError) { se.Selector = s }
 }
 
+// WithLastSeen sets when the extension was last seen (RFC3339).
+func WithLastSeen(ts string) func(*StructuredError) {
+	return func(se *StructuredError) { se.LastSeen = ts }
+}
+
+// WithDiagnostics attaches structured delivery evidence.
+func WithDiagnostics(d map[string]any) func(*StructuredError) {
+	return func(se *StructuredError) { se.Diagnostics = d }
+}
+
 // WithRetryable marks whether the error is retryable by the LLM.
 func WithRetryable(retryable bool) func(*StructuredError) {
 	return func(se *StructuredError) { se.Retryable = retryable }
@@ -130,6 +151,16 @@ func WithRecoveryToolCall(toolCall map[string]any) func(*StructuredError) {
 	return func(se *StructuredError) { se.RecoveryToolCall = toolCall }
 }
 
+// ExtensionWaitFailure classifies a failed wait for an extension result: extension_unreachable with
+// delivery diagnostics when the extension never picked the command up, extension_timeout otherwise.
+func ExtensionWaitFailure(err error) (string, []func(*StructuredError)) {
+	var unreachable *queries.ExtensionUnreachableError
+	if errors.As(err, &unreachable) {
+		return ErrExtUnreachable, []func(*StructuredError){WithDiagnostics(unreachable.Diagnostics())}
+	}
+	return ErrExtTimeout, nil
+}
+
 // retryDefaultsForCode returns option functions that set retryable and retry_after_ms
 // based on the error code. Retryable errors are transient conditions the LLM can
 // retry after a brief delay; non-retryable errors require the LLM to change its input.
@@ -139,6 +170,8 @@ func retryDefaultsForCode(code string) []func(*StructuredError) {
 		return []func(*StructuredError){WithRetryable(true), WithRetryAfterMs(1000)}
 	case ErrExtError:
 		return []func(*StructuredError){WithRetryable(true), WithRetryAfterMs(2000)}
+	case ErrExtDisconnected, ErrExtUnreachable:
+		return []func(*StructuredError){WithRetryable(true), WithRetryAfterMs(3000)}
 	case ErrRateLimited:
 		return []func(*StructuredError){WithRetryable(true), WithRetryAfterMs(1000)}
 	case ErrCursorExpired:
@@ -16,6 +16,7 @@ type JSONRPCRequest struct {
 	Method          string          `json:"method"`
 	Params          json.RawMessage `json:"params,omitempty"`
 	ClientID        string          `json:"-"` // per-request client ID for multi-client isolation (not serialized)
+	ProtocolVersion string          `json:"-"` // MCP-Protocol-Version header, when the transport carries one (not serialized)
 	idPresent       bool            `json:"-"`
 	idExplicitNull  bool            `json:"-"`
 	idInvalidFormat bool            `json:"-"`
@@ -43,6 +44,7 @@ func (r *JSONRPCRequest) UnmarshalJSON(data []byte) error {
 	r.Method = raw.Method
 	r.Params = raw.Params
 	r.ClientID = ""
+	r.ProtocolVersion = ""
 	r.ID = nil
 	_, r.idPresent = object["id"]
 	r.idExplicitNull = false
@@ -95,6 +97,7 @@ type JSONRPCResponse struct {
 type JSONRPCError struct {
 	Code    int    `json:"code"`
 	Message string `json:"message"`
+	Data    any    `json:"data,omitempty"` // Optional structured detail (e.g. rate-limit state)
 }
 
 // MCPTool represents a tool in the MCP protocol.
@@ -102,5 +105,18 @@ type MCPTool struct {
 	Name        string         `json:"name"`
 	Description string         `json:"description"`
 	InputSchema map[string]any `json:"inputSchema"` // SPEC:MCP — camelCase required by MCP protocol
+	// OutputSchema describes structuredContent on successful results (SPEC:MCP 2025-06-18).
+	OutputSchema map[string]any `json:"outputSchema,omitempty"`
+	// Annotations are behavior hints for clients (SPEC:MCP 2025-03-26).
+	Annotations *MCPToolAnnotations `json:"annotations,omitempty"`
 	// Note: _meta removed - not in MCP spec, caused schema validation errors in Cursor
 }
+
+// MCPToolAnnotations are tool behavior hints. All four hints are always emitted because
+// the spec defaults for omitted hints (destructive, open-world) are the pessimistic ones.
+type MCPToolAnnotations struct {
+	ReadOnlyHint    bool `json:"readOnlyHint"`    // SPEC:MCP
+	DestructiveHint bool `json:"destructiveHint"` // SPEC:MCP
+	IdempotentHint  bool `json:"idempotentHint"`  // SPEC:MCP
+	OpenWorldHint   bool `json:"openWorldHint"`   // SPEC:MCP
+}
@@ -0,0 +1,117 @@
+// Purpose: Declares the supported MCP protocol versions, the features each enables, and the shims that shape results per version.
+// Why: Newer clients get newer result shapes (tool annotations, structuredContent) while 2024-11-05 clients keep the shapes they validate against.
+// Docs: docs/mcp-integration/index.md
+
+package mcp
+
+import "encoding/json"
+
+// Supported MCP protocol versions (SPEC:MCP revision dates).
+const (
+	ProtocolVersion20250618 = "2025-06-18"
+	ProtocolVersion20250326 = "2025-03-26"
+	ProtocolVersion20241105 = "2024-11-05"
+
+	// LatestProtocolVersion is offered when the client asks for a version older than any we support.
+	LatestProtocolVersion = ProtocolVersion20250618
+)
+
+// SupportedProtocolVersions lists every negotiable version, newest first.
+var SupportedProtocolVersions = []string{ProtocolVersion20250618, ProtocolVersion20250326, ProtocolVersion20241105}
+
+// ProtocolFeatures are the version-gated parts of the protocol this server implements.
+type ProtocolFeatures struct {
+	// ToolAnnotations adds behavior hints (readOnlyHint, destructiveHint, ...) to tools/list (2025-03-26).
+	ToolAnnotations bool
+	// Completions advertises completion/complete for resource template arguments (2025-03-26).
+	Completions bool
+	// StructuredContent adds outputSchema to tools/list and structuredContent to tool results (2025-06-18).
+	StructuredContent bool
+}
+
+// IsSupportedProtocolVersion reports whether version is one the server can speak.
+func IsSupportedProtocolVersion(version string) bool {
+	for _, v := range SupportedProtocolVersions {
+		if v == version {
+			return true
+		}
+	}
+	return false
+}
+
+// NegotiateProtocolVersion picks the version to answer initialize with.
+//
+// An exact match is accepted as-is. Otherwise the newest supported version that is not newer
+// than the request is chosen, so a client on an unreleased or intermediate revision still
+// gets the closest shape it understands. Requests older than every supported version (or
+// malformed) get LatestProtocolVersion, and the client decides whether to disconnect.
+// Revision identifiers are ISO dates, so string order is chronological order.
+func NegotiateProtocolVersion(requested string) string {
+	for _, v := range SupportedProtocolVersions {
+		if requested >= v {
+			return v
+		}
+	}
+	return LatestProtocolVersion
+}
+
+// FeaturesForProtocolVersion returns the features enabled for a negotiated version.
+// Unknown or empty versions get the latest feature set, matching pre-negotiation behavior.
+func FeaturesForProtocolVersion(version string) ProtocolFeatures {
+	if !IsSupportedProtocolVersion(version) {
+		version = LatestProtocolVersion
+	}
+	return ProtocolFeatures{
+		ToolAnnotations:   version >= ProtocolVersion20250326,
+		Completions:       version >= ProtocolVersion20250326,
+		StructuredContent: version >= ProtocolVersion20250618,
+	}
+}
+
+// CapabilitiesForProtocolVersion returns the initialize capabilities advertised for version.
+func CapabilitiesForProtocolVersion(version string) MCPCapabilities {
+	caps := MCPCapabilities{Tools: MCPToolsCapability{}, Resources: MCPResourcesCapability{}}
+	if FeaturesForProtocolVersion(version).Completions {
+		caps.Completions = &MCPCompletionsCapability{}
+	}
+	return caps
+}
+
+// ToolsForProtocolVersion strips tool fields the client's version does not define.
+// The input slice is not modified.
+func ToolsForProtocolVersion(tools []MCPTool, version string) []MCPTool {
+	features := FeaturesForProtocolVersion(version)
+	if features.ToolAnnotations && features.StructuredContent {
+		return tools
+	}
+	out := make([]MCPTool, len(tools))
+	for i, tool := range tools {
+		if !features.ToolAnnotations {
+			tool.Annotations = nil
+		}
+		if !features.StructuredContent {
+			tool.OutputSchema = nil
+		}
+		out[i] = tool
+	}
+	return out
+}
+
+// ToolResultForProtocolVersion drops structuredContent from a tools/call result for clients
+// older than 2025-06-18. Responses without a tool result are returned unchanged.
+func ToolResultForProtocolVersion(resp JSONRPCResponse, version string) JSONRPCResponse {
+	if FeaturesForProtocolVersion(version).StructuredContent || resp.Result == nil {
+		return resp
+	}
+	var result MCPToolResult
+	if json.Unmarshal(resp.Result, &result) != nil || len(result.StructuredContent) == 0 {
+		return resp
+	}
+	result.StructuredContent = nil
+	resultJSON, err := json.Marshal(result)
+	if err != nil {
+		return resp
+	}
+	resp.Result = json.RawMessage(resultJSON)
+	return resp
+}
@@ -39,11 +39,22 @@ func ClampResponseSize(result json.RawMessage) json.RawMessage {
 
 	// Effective limit for text content only
 	effectiveLimit := MaxResponseBytes + imageBytes
-	originalSize := len(result)
-	if originalSize <= effectiveLimit {
+	if len(result) <= effectiveLimit {
 		return result
 	}
 
+	// structuredContent duplicates the text payload; drop it before truncating text.
+	if len(toolResult.StructuredContent) > 0 {
+		toolResult.StructuredContent = nil
+		if stripped, err := json.Marshal(toolResult); err == nil {
+			result = json.RawMessage(stripped)
+			if len(result) <= effectiveLimit {
+				return result
+			}
+		}
+	}
+	originalSize := len(result)
+
 	text := toolResult.Content[0].Text
 	if text == "" {
 		return result
@@ -95,3 +95,47 @@ func AppendWarningsToToolResult(result *MCPToolResult, warnings []string) bool {
 	})
 	return true
 }
+
+// ExtractJSONPayload returns the JSON document in a "summary\n{json}" text block,
+// skipping leading summary and warning lines. ok is false when no line starts a
+// valid JSON object or array that runs to the end of the text.
+func ExtractJSONPayload(text string) (payload json.RawMessage, ok bool) {
+	text = strings.TrimSpace(text)
+	for text != "" {
+		// Warning lines like "[WARNING] ..." also start with a bracket, so validate each candidate.
+		if (text[0] == '{' || text[0] == '[') && json.Valid([]byte(text)) {
+			return json.RawMessage(text), true
+		}
+		_, rest, found := strings.Cut(text, "\n")
+		if !found {
+			break
+		}
+		text = strings.TrimSpace(rest)
+	}
+	return nil, false
+}
+
+// AttachStructuredContent sets structuredContent from the JSON object in the first
+// text block so clients can read results without re-parsing text. Derive it after
+// redaction and warning injection so both representations carry the same data.
+// Responses whose payload is not a JSON object are returned unchanged.
+func AttachStructuredContent(resp JSONRPCResponse) JSONRPCResponse {
+	var result MCPToolResult
+	if resp.Result == nil || json.Unmarshal(resp.Result, &result) != nil {
+		return resp
+	}
+	if len(result.Content) == 0 || result.Content[0].Type != "text" {
+		return resp
+	}
+	payload, ok := ExtractJSONPayload(result.Content[0].Text)
+	if !ok || payload[0] != '{' {
+		return resp
+	}
+	result.StructuredContent = payload
+	resultJSON, err := json.Marshal(result)
+	if err != nil {
+		return resp
+	}
+	resp.Result = json.RawMessage(resultJSON)
+	return resp
+}
@@ -463,3 +463,44 @@ func TestImageContentBlock_SerializesCorrectly(t *testing.T) {
 		t.Errorf("image block JSON should not contain 'text' field, got: %s", s)
 	}
 }
+
+func TestAttachStructuredContent(t *testing.T) {
+	t.Parallel()
+	resp := PrependWarningToResponse(Succeed(JSONRPCRequest{JSONRPC: "2.0", ID: 1}, "Browser errors", map[string]any{"count": 2}), "[WARNING] stale extension\n\n")
+	resp = AttachStructuredContent(resp)
+	var result MCPToolResult
+	if err := json.Unmarshal(resp.Result, &result); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if string(result.StructuredContent) != `{"count":2}` {
+		t.Fatalf("structuredContent = %s, want {\"count\":2}", result.StructuredContent)
+	}
+
+	for _, text := range []string{"plain text only", "Rows\n[1,2,3]", "Broken\n{\"count\":"} {
+		resp := JSONRPCResponse{JSONRPC: "2.0", ID: 1, Result: buildRawToolResult(text)}
+		var got MCPToolResult
+		_ = json.Unmarshal(AttachStructuredContent(resp).Result, &got)
+		if got.StructuredContent != nil {
+			t.Errorf("text %q: structuredContent = %s, want none", text, got.StructuredContent)
+		}
+	}
+}
+
+func TestClampResponseSize_DropsStructuredContentFirst(t *testing.T) {
+	t.Parallel()
+	payload := `{"data":"` + strings.Repeat("x", MaxResponseBytes*2/3) + `"}`
+	raw, _ := json.Marshal(MCPToolResult{
+		Content:           []MCPContentBlock{{Type: "text", Text: "Summary\n" + payload}},
+		StructuredContent: json.RawMessage(payload),
+	})
+	var result MCPToolResult
+	if err := json.Unmarshal(ClampResponseSize(raw), &result); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if result.StructuredContent != nil {
+		t.Fatal("expected structuredContent to be dropped when over the limit")
+	}
+	if strings.Contains(result.Content[0].Text, "[truncated") {
+		t.Fatal("text should survive intact once structuredContent is dropped")
+	}
+}
@@ -3,7 +3,11 @@
 
 package mcp
 
-import "github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/types"
+import (
+	"encoding/json"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/types"
+)
 
 // JSONRPCVersion is the JSON-RPC protocol version string. Use this constant
 // instead of the magic string "2.0" when constructing JSON-RPC responses.
@@ -24,6 +28,8 @@ type MCPToolResult struct {
 	Content  []MCPContentBlock `json:"content"`
 	IsError  bool              `json:"isError"` // SPEC:MCP
 	Metadata map[string]any    `json:"metadata,omitempty"`
+	// StructuredContent mirrors the JSON payload of the first text block (SPEC:MCP 2025-06-18).
+	StructuredContent json.RawMessage `json:"structuredContent,omitempty"`
 }
 
 // MCPInitializeResult represents the result of an MCP initialize request.
@@ -44,6 +50,8 @@ type MCPServerInfo struct {
 type MCPCapabilities struct {
 	Tools     MCPToolsCapability     `json:"tools"`
 	Resources MCPResourcesCapability `json:"resources"`
+	// Completions is advertised from 2025-03-26; nil omits it for older clients.
+	Completions *MCPCompletionsCapability `json:"completions,omitempty"`
 }
 
 // MCPToolsCapability declares tool support.
@@ -52,6 +60,9 @@ type MCPToolsCapability struct{}
 // MCPResourcesCapability declares resource support.
 type MCPResourcesCapability struct{}
 
+// MCPCompletionsCapability declares completion/complete support (SPEC:MCP 2025-03-26).
+type MCPCompletionsCapability struct{}
+
 // MCPResource describes an available resource.
 type MCPResource struct {
 	URI         string `json:"uri"`
@@ -82,6 +93,18 @@ type MCPToolsListResult struct {
 	Tools []MCPTool `json:"tools"`
 }
 
+// MCPCompletion is the completion/complete result body (SPEC:MCP 2025-03-26).
+type MCPCompletion struct {
+	Values  []string `json:"values"`
+	Total   int      `json:"total"`
+	HasMore bool     `json:"hasMore"` // SPEC:MCP
+}
+
+// MCPCompleteResult represents the result of a completion/complete request.
+type MCPCompleteResult struct {
+	Completion MCPCompletion `json:"completion"`
+}
+
 // MCPResourceTemplatesListResult represents the result of a resources/templates/list request.
 type MCPResourceTemplatesListResult struct {
 	ResourceTemplates []any `json:"resourceTemplates"` // SPEC:MCP
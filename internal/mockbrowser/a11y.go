@@ -0,0 +1,418 @@
+// Purpose: Roles, accessible names, and a static subset of axe-core rules for a11y audits of the fixture.
+// Why: analyze(what="accessibility") must return the extension's axe result shape without running axe-core.
+// Docs: docs/features/feature/mock-browser/index.md
+
+package mockbrowser
+
+import (
+	"strings"
+)
+
+// Caps mirror the extension's formatAxeResults.
+const (
+	maxA11yNodes = 10
+	maxNodeHTML  = 200
+)
+
+// a11yRule is one statically evaluated axe-core rule.
+type a11yRule struct {
+	id          string
+	impact      string
+	description string
+	summary     string // failureSummary for each failing node
+	tags        []string
+	// applies selects the elements the rule checks; fails reports a violation.
+	applies func(d *Document, el *Node) bool
+	fails   func(d *Document, el *Node) bool
+}
+
+var a11yRules = []a11yRule{
+	{
+		id:          "document-title",
+		impact:      "serious",
+		description: "Ensure each HTML document contains a non-empty <title> element",
+		summary:     "Fix any of the following:\n  Document does not have a non-empty <title> element",
+		tags:        []string{"cat.text-alternatives", "wcag2a", "wcag242"},
+		applies:     func(_ *Document, el *Node) bool { return el.Tag == "html" },
+		fails:       func(d *Document, _ *Node) bool { return strings.TrimSpace(d.Title) == "" },
+	},
+	{
+		id:          "html-has-lang",
+		impact:      "serious",
+		description: "Ensure every HTML document has a lang attribute",
+		summary:     "Fix any of the following:\n  The <html> element does not have a lang attribute",
+		tags:        []string{"cat.language", "wcag2a", "wcag311"},
+		applies:     func(_ *Document, el *Node) bool { return el.Tag == "html" },
+		fails:       func(_ *Document, el *Node) bool { return strings.TrimSpace(el.AttrValue("lang")) == "" },
+	},
+	{
+		id:          "image-alt",
+		impact:      "critical",
+		description: "Ensure <img> elements have alternative text or a role of none or presentation",
+		summary:     "Fix any of the following:\n  Element does not have an alt attribute\n  aria-label attribute does not exist or is empty\n  Element has no title attribute or the title attribute is empty",
+		tags:        []string{"cat.text-alternatives", "wcag2a", "wcag111"},
+		applies: func(_ *Document, el *Node) bool {
+			role := el.AttrValue("role")
+			return el.Tag == "img" && role != "none" && role != "presentation" && el.Visible()
+		},
+		fails: func(d *Document, el *Node) bool {
+			_, hasAlt := el.Attr("alt")
+			return !hasAlt && AccessibleName(d, el) == ""
+		},
+	},
+	{
+		id:          "button-name",
+		impact:      "critical",
+		description: "Ensure buttons have discernible text",
+		summary:     "Fix any of the following:\n  Element does not have inner text that is visible to screen readers\n  aria-label attribute does not exist or is empty\n  Element has no title attribute or the title attribute is empty",
+		tags:        []string{"cat.name-role-value", "wcag2a", "wcag412"},
+		applies: func(_ *Document, el *Node) bool {
+			return (el.Tag == "button" || el.AttrValue("role") == "button") && el.Visible()
+		},
+		fails: func(d *Document, el *Node) bool { return AccessibleName(d, el) == "" },
+	},
+	{
+		id:          "link-name",
+		impact:      "serious",
+		description: "Ensure links have discernible text",
+		summary:     "Fix all of the following:\n  Element is in tab order and does not have accessible text",
+		tags:        []string{"cat.name-role-value", "wcag2a", "wcag244", "wcag412"},
+		applies: func(_ *Document, el *Node) bool {
+			_, href := el.Attr("href")
+			return el.Tag == "a" && href && el.Visible()
+		},
+		fails: func(d *Document, el *Node) bool { return AccessibleName(d, el) == "" },
+	},
+	{
+		id:          "label",
+		impact:      "critical",
+		description: "Ensure every form element has a label",
+		summary:     "Fix any of the following:\n  Form element does not have an implicit (wrapped) <label>\n  Form element does not have an explicit <label>\n  aria-label attribute does not exist or is empty",
+		tags:        []string{"cat.forms", "wcag2a", "wcag412"},
+		applies: func(_ *Document, el *Node) bool {
+			if !el.Visible() {
+				return false
+			}
+			switch el.Tag {
+			case "select", "textarea":
+				return true
+			case "input":
+				switch strings.ToLower(el.AttrValue("type")) {
+				case "button", "submit", "reset", "image", "hidden":
+					return false
+				}
+				return true
+			}
+			return false
+		},
+		fails: func(d *Document, el *Node) bool { return AccessibleName(d, el) == "" },
+	},
+}
+
+// A11yViolation is one failed rule, in the extension's formatted shape.
+type A11yViolation struct {
+	ID          string     `json:"id"`
+	Impact      string     `json:"impact,omitempty"`
+	Description string     `json:"description"`
+	HelpURL     string     `json:"helpUrl"`
+	WCAG        []string   `json:"wcag,omitempty"`
+	Nodes       []A11yNode `json:"nodes"`
+	NodeCount   int        `json:"nodeCount,omitempty"`
+}
+
+// A11yNode is one failing element.
+type A11yNode struct {
+	Selector       string `json:"selector"`
+	HTML           string `json:"html"`
+	FailureSummary string `json:"failureSummary,omitempty"`
+}
+
+// A11yResult is an audit result: violations plus per-rule outcome counts.
+type A11yResult struct {
+	Violations []A11yViolation `json:"violations"`
+	Summary    struct {
+		Violations   int `json:"violations"`
+		Passes       int `json:"passes"`
+		Incomplete   int `json:"incomplete"`
+		Inapplicable int `json:"inapplicable"`
+	} `json:"summary"`
+}
+
+// Audit runs the rules over the elements inside scope (the whole document when
+// scope is nil). A non-empty tags list restricts the run to rules carrying any of
+// the tags or whose ID is listed, like axe's runOnly.
+func Audit(d *Document, scope *Node, tags []string) A11yResult {
+	var res A11yResult
+	res.Violations = []A11yViolation{}
+	if scope == nil {
+		scope = d.Root
+	}
+	candidates := scope.Elements()
+	if scope.IsElement() {
+		candidates = append([]*Node{scope}, candidates...)
+	}
+	for _, rule := range a11yRules {
+		if len(tags) > 0 && !ruleSelected(rule, tags) {
+			continue
+		}
+		applied := 0
+		var failing []*Node
+		for _, el := range candidates {
+			if !rule.applies(d, el) {
+				continue
+			}
+			applied++
+			if rule.fails(d, el) {
+				failing = append(failing, el)
+			}
+		}
+		switch {
+		case applied == 0:
+			res.Summary.Inapplicable++
+		case len(failing) == 0:
+			res.Summary.Passes++
+		default:
+			res.Summary.Violations++
+			res.Violations = append(res.Violations, buildViolation(d, rule, failing))
+		}
+	}
+	return res
+}
+
+func ruleSelected(rule a11yRule, tags []string) bool {
+	for _, t := range tags {
+		if t == rule.id || containsString(rule.tags, t) {
+			return true
+		}
+	}
+	return false
+}
+
+func buildViolation(d *Document, rule a11yRule, failing []*Node) A11yViolation {
+	v := A11yViolation{
+		ID:          rule.id,
+		Impact:      rule.impact,
+		Description: rule.description,
+		HelpURL:     "https://dequeuniversity.com/rules/axe/4.10/" + rule.id,
+		Nodes:       []A11yNode{},
+	}
+	for _, t := range rule.tags {
+		if strings.HasPrefix(t, "wcag") {
+			v.WCAG = append(v.WCAG, t)
+		}
+	}
+	for i, el := range failing {
+		if i == maxA11yNodes {
+			v.NodeCount = len(failing)
+			break
+		}
+		v.Nodes = append(v.Nodes, A11yNode{
+			Selector:       uniqueSelector(d, el),
+			HTML:           truncateRunes(el.OuterHTML(), maxNodeHTML),
+			FailureSummary: rule.summary,
+		})
+	}
+	return v
+}
+
+// Role returns the explicit role attribute, or the element's implicit ARIA role.
+func Role(el *Node) string {
+	if role := strings.TrimSpace(el.AttrValue("role")); role != "" {
+		return strings.Fields(role)[0]
+	}
+	switch el.Tag {
+	case "a", "area":
+		if _, ok := el.Attr("href"); ok {
+			return "link"
+		}
+	case "button":
+		return "button"
+	case "input":
+		switch strings.ToLower(el.AttrValue("type")) {
+		case "button", "submit", "reset", "image":
+			return "button"
+		case "checkbox":
+			return "checkbox"
+		case "radio":
+			return "radio"
+		case "range":
+			return "slider"
+		case "number":
+			return "spinbutton"
+		case "search":
+			return "searchbox"
+		case "", "text", "email", "tel", "url", "password":
+			return "textbox"
+		}
+	case "textarea":
+		return "textbox"
+	case "select":
+		if _, multiple := el.Attr("multiple"); multiple {
+			return "listbox"
+		}
+		return "combobox"
+	case "option":
+		return "option"
+	case "img":
+		if alt, ok := el.Attr("alt"); ok && alt == "" {
+			return "presentation"
+		}
+		return "img"
+	case "h1", "h2", "h3", "h4", "h5", "h6":
+		return "heading"
+	case "nav":
+		return "navigation"
+	case "main":
+		return "main"
+	case "ul", "ol":
+		return "list"
+	case "li":
+		return "listitem"
+	case "form":
+		return "form"
+	case "table":
+		return "table"
+	case "dialog":
+		return "dialog"
+	}
+	return ""
+}
+
+// AccessibleName approximates the accessible name computation: aria-labelledby,
+// aria-label, labels for form controls, alt text, element content for roles named
+// from content, then title and placeholder.
+func AccessibleName(d *Document, el *Node) string {
+	if ids := strings.Fields(el.AttrValue("aria-labelledby")); len(ids) > 0 {
+		var parts []string
+		for _, id := range ids {
+			if ref := d.ByID(id); ref != nil {
+				if text := textAlternative(ref); text != "" {
+					parts = append(parts, text)
+				}
+			}
+		}
+		if name := strings.Join(parts, " "); name != "" {
+			return name
+		}
+	}
+	if label := strings.TrimSpace(el.AttrValue("aria-label")); label != "" {
+		return label
+	}
+	if name := labelName(d, el); name != "" {
+		return name
+	}
+	switch el.Tag {
+	case "img", "area":
+		if alt := strings.TrimSpace(el.AttrValue("alt")); alt != "" {
+			return alt
+		}
+	case "input":
+		switch strings.ToLower(el.AttrValue("type")) {
+		case "button", "submit", "reset":
+			if v := strings.TrimSpace(el.AttrValue("value")); v != "" {
+				return v
+			}
+		case "image":
+			if alt := strings.TrimSpace(el.AttrValue("alt")); alt != "" {
+				return alt
+			}
+		}
+	}
+	switch Role(el) {
+	case "button", "link", "heading", "option", "listitem", "checkbox", "radio", "tab", "menuitem":
+		if text := textAlternative(el); text != "" {
+			return text
+		}
+	}
+	if title := strings.TrimSpace(el.AttrValue("title")); title != "" {
+		return title
+	}
+	return strings.TrimSpace(el.AttrValue("placeholder"))
+}
+
+// labelName returns the text of <label for=id> or an enclosing <label> for a
+// labelable element.
+func labelName(d *Document, el *Node) string {
+	switch el.Tag {
+	case "input", "select", "textarea", "button", "meter", "output", "progress":
+	default:
+		return ""
+	}
+	var parts []string
+	if id := el.AttrValue("id"); id != "" {
+		for _, label := range d.Root.Elements() {
+			if label.Tag == "label" && label.AttrValue("for") == id {
+				if text := labelText(label, el); text != "" {
+					parts = append(parts, text)
+				}
+			}
+		}
+	}
+	if wrap := el.Parent.Closest("label"); wrap != nil {
+		if _, hasFor := wrap.Attr("for"); !hasFor {
+			if text := labelText(wrap, el); text != "" {
+				parts = append(parts, text)
+			}
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// labelText is a label's visible text, excluding the control it labels.
+func labelText(label, control *Node) string {
+	var parts []string
+	var walk func(*Node)
+	walk = func(p *Node) {
+		for _, c := range p.Children {
+			switch {
+			case c == control:
+			case c.IsElement():
+				if !nonRenderedTags[c.Tag] && ownVisible(c) {
+					walk(c)
+				}
+			default:
+				parts = append(parts, strings.Fields(c.Text)...)
+			}
+		}
+	}
+	walk(label)
+	return strings.Join(parts, " ")
+}
+
+// textAlternative is an element's name from content: visible text, with image alt
+// text and nested aria-labels standing in for their elements.
+func textAlternative(el *Node) string {
+	var parts []string
+	var walk func(*Node)
+	walk = func(p *Node) {
+		for _, c := range p.Children {
+			switch {
+			case !c.IsElement():
+				parts = append(parts, strings.Fields(c.Text)...)
+			case nonRenderedTags[c.Tag] || !ownVisible(c) || c.AttrValue("aria-hidden") == "true":
+			case c.AttrValue("aria-label") != "":
+				parts = append(parts, strings.TrimSpace(c.AttrValue("aria-label")))
+			case c.Tag == "img":
+				if alt := strings.TrimSpace(c.AttrValue("alt")); alt != "" {
+					parts = append(parts, alt)
+				}
+			default:
+				walk(c)
+			}
+		}
+	}
+	walk(el)
+	return strings.Join(parts, " ")
+}
+
+// truncateRunes cuts s to at most n runes.
+func truncateRunes(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	return string(r[:n])
+}
@@ -0,0 +1,687 @@
+// Purpose: Answers dom_action commands (interact) against the fixture: element resolution, reads, and mutations.
+// Why: Mirrors the extension's dom-primitives result shapes so agents see the same success and error contracts.
+// Docs: docs/features/feature/mock-browser/index.md
+
+package mockbrowser
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// actionParams are the dom_action params the mock understands.
+type actionParams struct {
+	Action        string `json:"action"`
+	Selector      string `json:"selector"`
+	Text          string `json:"text"`
+	Value         string `json:"value"`
+	Name          string `json:"name"`
+	Clear         bool   `json:"clear"`
+	Checked       *bool  `json:"checked"`
+	ElementID     string `json:"element_id"`
+	ScopeSelector string `json:"scope_selector"`
+	Nth           *int   `json:"nth"`
+	TimeoutMs     int    `json:"timeout_ms"`
+	TextContains  string `json:"text_contains"`
+	Role          string `json:"role"`
+	VisibleOnly   bool   `json:"visible_only"`
+}
+
+// ambiguitySensitive actions fail on several viable matches instead of taking the first.
+var ambiguitySensitive = map[string]bool{
+	"click": true, "type": true, "select": true, "check": true, "set_attribute": true,
+	"paste": true, "key_press": true, "focus": true, "scroll_to": true, "hover": true,
+}
+
+// defaultWaitTimeoutMs is the extension's wait_for default.
+const defaultWaitTimeoutMs = 5000
+
+// resolution is the element an action targets and how it was found.
+type resolution struct {
+	el         *Node
+	matchCount int
+	strategy   string
+}
+
+type actionRunner struct {
+	doc *Document
+	p   actionParams
+}
+
+func (r *actionRunner) fail(code, message string) map[string]any {
+	return map[string]any{"success": false, "action": r.p.Action, "selector": r.p.Selector, "error": code, "message": message}
+}
+
+func (r *actionRunner) ok(extra map[string]any) map[string]any {
+	out := map[string]any{"success": true, "action": r.p.Action, "selector": r.p.Selector}
+	for k, v := range extra {
+		out[k] = v
+	}
+	return out
+}
+
+// mutated is the extension's mutatingSuccess: ok plus the matched element summary.
+func (r *actionRunner) mutated(res resolution, extra map[string]any) map[string]any {
+	out := r.ok(extra)
+	out["matched"] = r.matched(res.el)
+	out["match_count"] = res.matchCount
+	out["match_strategy"] = res.strategy
+	return out
+}
+
+func (r *actionRunner) matched(el *Node) map[string]any {
+	m := map[string]any{"tag": el.Tag, "selector": r.p.Selector, "element_id": el.ElementID()}
+	if role := el.AttrValue("role"); role != "" {
+		m["role"] = role
+	}
+	if label := el.AttrValue("aria-label"); label != "" {
+		m["aria_label"] = label
+	}
+	if preview := textPreview(el, 80); preview != "" {
+		m["text_preview"] = preview
+	}
+	if classes := strings.Fields(el.AttrValue("class")); len(classes) > 0 {
+		m["classes"] = classes[:min(len(classes), 5)]
+	}
+	return m
+}
+
+// runAction executes one dom_action against doc.
+func runAction(doc *Document, p actionParams) map[string]any {
+	r := &actionRunner{doc: doc, p: p}
+	switch p.Action {
+	case "list_interactive":
+		return r.listInteractive()
+	case "wait_for_stable":
+		return map[string]any{"success": true, "action": p.Action, "selector": "", "stable": true, "timed_out": false, "waited_ms": 0}
+	case "wait_for_text":
+		if p.Text == "" {
+			return map[string]any{"success": false, "action": p.Action, "selector": "", "error": "empty_text", "message": "text parameter is required for wait_for_text"}
+		}
+		if strings.Contains(bodyText(doc), p.Text) {
+			return map[string]any{"success": true, "action": p.Action, "selector": "", "matched_text": p.Text}
+		}
+		return map[string]any{"success": false, "action": p.Action, "selector": "", "error": "text_not_found"}
+	case "wait_for_absent":
+		if p.Selector == "" {
+			return map[string]any{"success": false, "action": p.Action, "selector": "", "error": "missing_selector", "message": "selector is required for wait_for_absent"}
+		}
+		matches, err := resolveElements(doc, p.Selector, doc.Root)
+		if err != nil {
+			return r.fail("invalid_selector", err.Error())
+		}
+		if len(matches) == 0 {
+			return r.ok(map[string]any{"absent": true})
+		}
+		return map[string]any{"success": false, "action": p.Action, "selector": p.Selector, "error": "element_still_present"}
+	}
+
+	res, failure := r.resolve()
+	if failure != nil {
+		if p.Action == "wait_for" && failure["error"] == "element_not_found" {
+			timeout := p.TimeoutMs
+			if timeout <= 0 {
+				timeout = defaultWaitTimeoutMs
+			}
+			return r.fail("timeout", fmt.Sprintf("Element not found within %dms: %s", timeout, p.Selector))
+		}
+		return failure
+	}
+	el := res.el
+
+	switch p.Action {
+	case "click":
+		// Clicking a checkbox or radio flips it, as the real click would; nothing navigates.
+		if kind := inputType(el); el.Tag == "input" && (kind == "checkbox" || kind == "radio") {
+			setChecked(doc, el, kind == "radio" || !hasAttr(el, "checked"))
+		}
+		return r.mutated(res, nil)
+	case "type":
+		text := strings.ReplaceAll(p.Text, `\n`, "\n")
+		if !isTypeable(el) {
+			return r.fail("not_typeable", "Element is not an input, textarea, or contenteditable: "+strings.ToUpper(el.Tag))
+		}
+		value := text
+		if !p.Clear {
+			value = controlValue(el) + text
+		}
+		setControlValue(el, value)
+		return r.mutated(res, map[string]any{"value": value, "insertion_strategy": "native_setter"})
+	case "select":
+		if el.Tag != "select" {
+			return r.fail("not_select", "Element is not a <select>: "+strings.ToUpper(el.Tag))
+		}
+		selectOption(el, p.Value)
+		return r.mutated(res, map[string]any{"value": controlValue(el)})
+	case "check":
+		kind := inputType(el)
+		if el.Tag != "input" || (kind != "checkbox" && kind != "radio") {
+			return r.fail("not_checkable", fmt.Sprintf("Element is not a checkbox or radio: %s type=%s", strings.ToUpper(el.Tag), orNA(el.AttrValue("type"))))
+		}
+		desired := true
+		if p.Checked != nil {
+			desired = *p.Checked
+		}
+		// Like the extension's click-to-toggle, a radio cannot be unchecked directly.
+		if hasAttr(el, "checked") != desired && (kind == "checkbox" || desired) {
+			setChecked(doc, el, desired)
+		}
+		return r.mutated(res, map[string]any{"value": hasAttr(el, "checked")})
+	case "get_text":
+		return r.ok(map[string]any{"value": el.InnerText()})
+	case "get_value":
+		if !hasValueProperty(el) {
+			return r.fail("no_value_property", "Element has no value property: "+strings.ToUpper(el.Tag))
+		}
+		return r.ok(map[string]any{"value": controlValue(el)})
+	case "get_attribute":
+		v, found := el.Attr(strings.ToLower(p.Name))
+		if !found {
+			return r.ok(map[string]any{"value": nil, "reason": "attribute_not_found", "message": fmt.Sprintf("Attribute %q not found", p.Name)})
+		}
+		return r.ok(map[string]any{"value": v})
+	case "set_attribute":
+		name := strings.ToLower(p.Name)
+		el.SetAttr(name, p.Value)
+		return r.mutated(res, map[string]any{"value": el.AttrValue(name)})
+	case "focus", "hover", "scroll_to":
+		return r.mutated(res, nil)
+	case "wait_for":
+		return r.ok(map[string]any{"value": el.Tag})
+	}
+	return r.fail("unsupported_action", "The mock browser does not support action: "+p.Action)
+}
+
+// resolve finds the target element the way the extension does: element_id first,
+// then nth, then ambiguity checks for mutating actions, else the first visible match.
+func (r *actionRunner) resolve() (resolution, map[string]any) {
+	scope := r.doc.Root
+	if r.p.ScopeSelector != "" {
+		scopes, err := resolveElements(r.doc, r.p.ScopeSelector, r.doc.Root)
+		if err != nil {
+			return resolution{}, r.fail("invalid_selector", err.Error())
+		}
+		if len(scopes) == 0 {
+			return resolution{}, r.fail("scope_not_found", "No element matches scope_selector: "+r.p.ScopeSelector)
+		}
+		scope = scopes[0]
+	}
+
+	if r.p.ElementID != "" {
+		el := r.doc.ByElementID(r.p.ElementID)
+		if el == nil {
+			return resolution{}, r.fail("stale_element_id", "Element handle is stale or unknown: "+r.p.ElementID+". Call list_interactive again.")
+		}
+		if !scope.Contains(el) {
+			return resolution{}, r.fail("element_id_scope_mismatch", "Element handle does not belong to scope: "+r.p.ScopeSelector)
+		}
+		return resolution{el: el, matchCount: 1, strategy: "element_id"}, nil
+	}
+
+	if r.p.Selector == "" {
+		return resolution{}, r.fail("missing_selector", "selector or element_id is required for "+r.p.Action)
+	}
+	matches, err := resolveElements(r.doc, r.p.Selector, scope)
+	if err != nil {
+		return resolution{}, r.fail("invalid_selector", err.Error())
+	}
+	if len(matches) == 0 {
+		return resolution{}, r.fail("element_not_found", "No element matches selector: "+r.p.Selector)
+	}
+	viable := preferVisible(matches)
+
+	strategy := "selector"
+	switch {
+	case strings.Contains(r.p.Selector, ":nth-match("):
+		strategy = "nth_match_selector"
+	case r.p.ScopeSelector != "":
+		strategy = "scoped_selector"
+	}
+
+	if r.p.Nth != nil {
+		nth := *r.p.Nth
+		idx := nth
+		if nth < 0 {
+			idx = len(viable) + nth
+		}
+		if idx < 0 || idx >= len(viable) {
+			return resolution{}, r.fail("nth_out_of_range", fmt.Sprintf(
+				"nth=%d is out of range — selector matched %d element(s). Use nth 0..%d or -1..-%d.",
+				nth, len(viable), len(viable)-1, len(viable)))
+		}
+		return resolution{el: viable[idx], matchCount: len(viable), strategy: "nth_param"}, nil
+	}
+
+	if ambiguitySensitive[r.p.Action] && len(viable) > 1 {
+		out := r.fail("ambiguous_target", fmt.Sprintf(
+			"Selector matches multiple viable elements: %s. Add nth, scope/scope_rect, or use list_interactive element_id/index.", r.p.Selector))
+		out["match_count"] = len(viable)
+		out["match_strategy"] = "ambiguous_ranked"
+		candidates := make([]map[string]any, 0, len(viable))
+		for _, el := range viable {
+			c := r.matched(el)
+			c["selector"] = uniqueSelector(r.doc, el)
+			c["visible"] = el.Visible()
+			delete(c, "classes")
+			candidates = append(candidates, c)
+		}
+		out["candidates"] = candidates
+		out["suggested_element_id"] = viable[0].ElementID()
+		return resolution{}, out
+	}
+	return resolution{el: viable[0], matchCount: 1, strategy: strategy}, nil
+}
+
+// resolveElements resolves a CSS or semantic selector (text=, role=, placeholder=,
+// label=, aria-label=), with an optional :nth-match(n) suffix, inside scope.
+func resolveElements(doc *Document, sel string, scope *Node) ([]*Node, error) {
+	if base, n, ok := parseNthMatch(sel); ok {
+		matches, err := resolveElements(doc, base, scope)
+		if err != nil || n > len(matches) {
+			return nil, err
+		}
+		return matches[n-1 : n], nil
+	}
+	switch {
+	case strings.HasPrefix(sel, "text="):
+		return byText(scope, strings.TrimPrefix(sel, "text=")), nil
+	case strings.HasPrefix(sel, "role="):
+		return byAttr(scope, "role", strings.TrimPrefix(sel, "role=")), nil
+	case strings.HasPrefix(sel, "placeholder="):
+		return byAttr(scope, "placeholder", strings.TrimPrefix(sel, "placeholder=")), nil
+	case strings.HasPrefix(sel, "aria-label="):
+		return byAriaLabel(scope, strings.TrimPrefix(sel, "aria-label=")), nil
+	case strings.HasPrefix(sel, "label="):
+		return byLabel(doc, scope, strings.TrimPrefix(sel, "label=")), nil
+	}
+	parsed, err := ParseSelector(sel)
+	if err != nil {
+		return nil, err
+	}
+	return parsed.Query(scope), nil
+}
+
+func parseNthMatch(sel string) (string, int, bool) {
+	const marker = ":nth-match("
+	i := strings.LastIndex(sel, marker)
+	if i <= 0 || !strings.HasSuffix(sel, ")") {
+		return "", 0, false
+	}
+	n, err := strconv.Atoi(sel[i+len(marker) : len(sel)-1])
+	if err != nil || n < 1 {
+		return "", 0, false
+	}
+	return sel[:i], n, true
+}
+
+// interactiveAncestorTags are the targets a text= match resolves to.
+var interactiveAncestorTags = map[string]bool{"a": true, "button": true, "label": true, "summary": true}
+
+// byText returns, for each visible text node containing text, its nearest interactive
+// ancestor, else its first interactive child, else its parent element.
+func byText(scope *Node, text string) []*Node {
+	var out []*Node
+	seen := map[*Node]bool{}
+	var walk func(*Node)
+	walk = func(p *Node) {
+		for _, c := range p.Children {
+			if c.IsElement() {
+				walk(c)
+				continue
+			}
+			if !p.IsElement() || !strings.Contains(strings.TrimSpace(c.Text), text) {
+				continue
+			}
+			target := interactiveAncestor(p)
+			if target == nil {
+				target = firstInteractiveChild(p)
+			}
+			if target == nil {
+				target = p
+			}
+			if target.Visible() && !seen[target] {
+				seen[target] = true
+				out = append(out, target)
+			}
+		}
+	}
+	walk(scope)
+	return out
+}
+
+func interactiveAncestor(el *Node) *Node {
+	for p := el; p.IsElement(); p = p.Parent {
+		role := p.AttrValue("role")
+		if interactiveAncestorTags[p.Tag] || role == "button" || role == "link" {
+			return p
+		}
+	}
+	return nil
+}
+
+func firstInteractiveChild(el *Node) *Node {
+	for _, c := range el.Elements() {
+		role := c.AttrValue("role")
+		switch {
+		case c.Tag == "a" && hasAttr(c, "href"), c.Tag == "button", c.Tag == "select", c.Tag == "textarea",
+			c.Tag == "input" && inputType(c) != "hidden", role == "button", role == "link":
+			if c.Visible() {
+				return c
+			}
+		}
+	}
+	return nil
+}
+
+func byAttr(scope *Node, name, value string) []*Node {
+	var out []*Node
+	for _, el := range scope.Elements() {
+		if v, ok := el.Attr(name); ok && v == value {
+			out = append(out, el)
+		}
+	}
+	return out
+}
+
+// byAriaLabel matches aria-label exactly, falling back to a prefix match.
+func byAriaLabel(scope *Node, label string) []*Node {
+	if exact := byAttr(scope, "aria-label", label); len(exact) > 0 {
+		return exact
+	}
+	var out []*Node
+	for _, el := range scope.Elements() {
+		if v, ok := el.Attr("aria-label"); ok && strings.HasPrefix(v, label) {
+			out = append(out, el)
+		}
+	}
+	return out
+}
+
+// byLabel returns the controls of <label> elements whose text contains label.
+func byLabel(doc *Document, scope *Node, label string) []*Node {
+	var out []*Node
+	for _, l := range scope.Elements() {
+		if l.Tag != "label" || !strings.Contains(strings.TrimSpace(l.InnerText()), label) {
+			continue
+		}
+		target := l
+		if id := l.AttrValue("for"); id != "" {
+			if control := doc.ByID(id); control != nil {
+				target = control
+			}
+		} else {
+			for _, c := range l.Elements() {
+				if c.Tag == "input" || c.Tag == "select" || c.Tag == "textarea" {
+					target = c
+					break
+				}
+			}
+		}
+		out = append(out, target)
+	}
+	return out
+}
+
+// interactiveSelector is the extension's list_interactive candidate set.
+var interactiveSelector = mustParseSelector(`a[href], button, input, select, textarea, [role="button"], [role="link"], [role="tab"], [role="menuitem"], [contenteditable="true"], [onclick], [tabindex]`)
+
+func mustParseSelector(src string) *Selector {
+	sel, err := ParseSelector(src)
+	if err != nil {
+		panic(err)
+	}
+	return sel
+}
+
+func (r *actionRunner) listInteractive() map[string]any {
+	var visible, hidden []*Node
+	for _, el := range interactiveSelector.Query(r.doc.Root) {
+		if el.Tag == "input" && inputType(el) == "hidden" {
+			continue
+		}
+		if r.p.VisibleOnly && !el.Visible() {
+			continue
+		}
+		if r.p.Role != "" && Role(el) != r.p.Role {
+			continue
+		}
+		if r.p.TextContains != "" {
+			haystack := strings.ToLower(AccessibleName(r.doc, el) + " " + el.InnerText())
+			if !strings.Contains(haystack, strings.ToLower(r.p.TextContains)) {
+				continue
+			}
+		}
+		if el.Visible() {
+			visible = append(visible, el)
+		} else {
+			hidden = append(hidden, el)
+		}
+	}
+	elements := make([]map[string]any, 0, len(visible)+len(hidden))
+	for i, el := range append(visible, hidden...) {
+		entry := map[string]any{
+			"index":        i,
+			"tag":          el.Tag,
+			"element_type": elementType(el),
+			"selector":     uniqueSelector(r.doc, el),
+			"element_id":   el.ElementID(),
+			"label":        truncateRunes(AccessibleName(r.doc, el), 80),
+			"visible":      el.Visible(),
+		}
+		if el.Tag == "input" {
+			entry["type"] = inputType(el)
+		}
+		if role := Role(el); role != "" {
+			entry["role"] = role
+		}
+		if ph := el.AttrValue("placeholder"); ph != "" {
+			entry["placeholder"] = ph
+		}
+		elements = append(elements, entry)
+	}
+	out := map[string]any{"success": true, "elements": elements, "candidate_count": len(elements)}
+	filters := map[string]any{}
+	if r.p.TextContains != "" {
+		filters["text_contains"] = r.p.TextContains
+	}
+	if r.p.Role != "" {
+		filters["role"] = r.p.Role
+	}
+	if r.p.VisibleOnly {
+		filters["visible_only"] = true
+	}
+	if len(filters) > 0 {
+		out["filters_applied"] = filters
+	}
+	return out
+}
+
+func elementType(el *Node) string {
+	switch el.Tag {
+	case "a":
+		return "link"
+	case "button", "select", "textarea":
+		return el.Tag
+	case "input":
+		switch inputType(el) {
+		case "button", "submit", "reset", "image":
+			return "button"
+		case "checkbox", "radio":
+			return inputType(el)
+		}
+		return "input"
+	}
+	if role := el.AttrValue("role"); role != "" {
+		return role
+	}
+	return "other"
+}
+
+func preferVisible(nodes []*Node) []*Node {
+	var visible []*Node
+	for _, el := range nodes {
+		if el.Visible() {
+			visible = append(visible, el)
+		}
+	}
+	if len(visible) > 0 {
+		return visible
+	}
+	return nodes
+}
+
+func bodyText(doc *Document) string {
+	for _, el := range doc.Root.Elements() {
+		if el.Tag == "body" {
+			return el.InnerText()
+		}
+	}
+	return doc.Root.InnerText()
+}
+
+func textPreview(el *Node, n int) string {
+	return truncateRunes(strings.TrimSpace(el.TextContent()), n)
+}
+
+func hasAttr(el *Node, name string) bool {
+	_, ok := el.Attr(name)
+	return ok
+}
+
+func inputType(el *Node) string {
+	if el.Tag != "input" {
+		return ""
+	}
+	kind := strings.ToLower(strings.TrimSpace(el.AttrValue("type")))
+	if kind == "" {
+		return "text"
+	}
+	return kind
+}
+
+func orNA(s string) string {
+	if s == "" {
+		return "N/A"
+	}
+	return s
+}
+
+// isTypeable matches the extension: text-like inputs, textareas, and contenteditable.
+func isTypeable(el *Node) bool {
+	switch el.Tag {
+	case "textarea":
+		return true
+	case "input":
+		switch inputType(el) {
+		case "checkbox", "radio", "button", "submit", "reset", "image", "file", "hidden", "range", "color":
+			return false
+		}
+		return true
+	}
+	v, ok := el.Attr("contenteditable")
+	return ok && (v == "" || strings.EqualFold(v, "true"))
+}
+
+func hasValueProperty(el *Node) bool {
+	switch el.Tag {
+	case "input", "textarea", "select", "option", "button", "output", "data", "meter", "progress", "li":
+		return true
+	}
+	return false
+}
+
+// controlValue is the element's current value property.
+func controlValue(el *Node) string {
+	switch el.Tag {
+	case "textarea":
+		return el.TextContent()
+	case "select":
+		var first *Node
+		for _, opt := range el.Elements() {
+			if opt.Tag != "option" {
+				continue
+			}
+			if first == nil {
+				first = opt
+			}
+			if hasAttr(opt, "selected") {
+				return controlValue(opt)
+			}
+		}
+		if first == nil || hasAttr(el, "multiple") {
+			return ""
+		}
+		return controlValue(first)
+	case "option":
+		if v, ok := el.Attr("value"); ok {
+			return v
+		}
+		return strings.Join(strings.Fields(el.TextContent()), " ")
+	case "input":
+		if v, ok := el.Attr("value"); ok {
+			return v
+		}
+		if kind := inputType(el); kind == "checkbox" || kind == "radio" {
+			return "on"
+		}
+		return ""
+	}
+	if v, ok := el.Attr("value"); ok {
+		return v
+	}
+	if _, ok := el.Attr("contenteditable"); ok {
+		return el.InnerText()
+	}
+	return ""
+}
+
+// setControlValue stores a typed value where later queries read it: the value
+// attribute for inputs, and the text content for textareas and contenteditable.
+func setControlValue(el *Node, value string) {
+	if el.Tag == "input" {
+		el.SetAttr("value", value)
+		return
+	}
+	el.Children = nil
+	if value != "" {
+		el.AppendChild(&Node{Text: value})
+	}
+}
+
+// selectOption marks the option whose value is v as selected. An unknown value
+// leaves nothing selected, as assigning select.value does.
+func selectOption(sel *Node, v string) {
+	for _, opt := range sel.Elements() {
+		if opt.Tag != "option" {
+			continue
+		}
+		if controlValue(opt) == v {
+			opt.SetAttr("selected", "")
+		} else {
+			opt.RemoveAttr("selected")
+		}
+	}
+}
+
+// setChecked sets a checkbox or radio; checking a radio unchecks the rest of its group.
+func setChecked(doc *Document, el *Node, checked bool) {
+	if !checked {
+		el.RemoveAttr("checked")
+		return
+	}
+	el.SetAttr("checked", "")
+	name := el.AttrValue("name")
+	if inputType(el) != "radio" || name == "" {
+		return
+	}
+	groupRoot := doc.Root
+	if form := el.Closest("form"); form != nil {
+		groupRoot = form
+	}
+	for _, other := range groupRoot.Elements() {
+		if other != el && other.Tag == "input" && inputType(other) == "radio" && other.AttrValue("name") == name {
+			other.RemoveAttr("checked")
+		}
+	}
+}
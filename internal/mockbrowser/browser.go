@@ -0,0 +1,200 @@
+// Purpose: Browser answers dom, a11y, and dom_action extension commands from a fixture document.
+// Why: Implements extreplay.Responder so the simulated-extension driver can serve a fixture instead of a recording.
+// Docs: docs/features/feature/mock-browser/index.md
+
+package mockbrowser
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+)
+
+// Caps mirror the extension's DOM query constants.
+const (
+	maxQueryElements = 50
+	maxQueryText     = 500
+	maxQueryDepth    = 5
+	defaultDepth     = 3
+)
+
+// TabID is the tab the mock reports as tracked.
+const TabID = 1
+
+// Browser serves one fixture page. It is safe for concurrent use; commands run one
+// at a time so interactions apply in order.
+type Browser struct {
+	mu  sync.Mutex
+	doc *Document
+}
+
+// New returns a Browser serving doc.
+func New(doc *Document) *Browser {
+	return &Browser{doc: doc}
+}
+
+// Load returns a Browser serving the fixture at path (see LoadFixture).
+func Load(path string) (*Browser, error) {
+	doc, err := LoadFixture(path)
+	if err != nil {
+		return nil, err
+	}
+	return New(doc), nil
+}
+
+// Settings are the extension settings the mock reports: AI Pilot on and the fixture
+// page tracked, so interact commands are not gated.
+func (b *Browser) Settings() *capture.SyncSettings {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return &capture.SyncSettings{
+		PilotEnabled:    true,
+		TrackingEnabled: true,
+		TrackedTabID:    TabID,
+		TrackedTabURL:   b.doc.URL,
+		TrackedTabTitle: b.doc.Title,
+	}
+}
+
+// Respond implements extreplay.Responder.
+func (b *Browser) Respond(cmd capture.SyncCommand) (capture.SyncCommandResult, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var result any
+	switch cmd.Type {
+	case "dom":
+		result = b.query(cmd.Params)
+	case "a11y":
+		result = b.audit(cmd.Params)
+	case "dom_action":
+		var p actionParams
+		if err := unmarshalParams(cmd.Params, &p); err != nil {
+			return capture.SyncCommandResult{}, err
+		}
+		result = runAction(b.doc, p)
+	default:
+		return capture.SyncCommandResult{}, fmt.Errorf("mock browser: %s commands are not supported", cmd.Type)
+	}
+	raw, err := json.Marshal(result)
+	if err != nil {
+		return capture.SyncCommandResult{}, err
+	}
+	return capture.SyncCommandResult{ID: cmd.ID, CorrelationID: cmd.CorrelationID, Status: "complete", Result: raw}, nil
+}
+
+func unmarshalParams(raw json.RawMessage, v any) error {
+	if len(raw) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(raw, v); err != nil {
+		return fmt.Errorf("mock browser: invalid params: %w", err)
+	}
+	return nil
+}
+
+// domQueryParams are the analyze(what="dom") params.
+type domQueryParams struct {
+	Selector        string `json:"selector"`
+	IncludeChildren bool   `json:"include_children"`
+	MaxDepth        int    `json:"max_depth"`
+}
+
+// domEntry is one serialized element, as the extension's serializeDOMElement emits it.
+type domEntry struct {
+	Tag        string            `json:"tag"`
+	Text       string            `json:"text"`
+	Visible    bool              `json:"visible"`
+	Attributes map[string]string `json:"attributes,omitempty"`
+	Children   []domEntry        `json:"children,omitempty"`
+}
+
+func (b *Browser) query(raw json.RawMessage) any {
+	var p domQueryParams
+	if err := unmarshalParams(raw, &p); err != nil {
+		return queryError(err)
+	}
+	if p.Selector == "" {
+		p.Selector = "*"
+	}
+	sel, err := ParseSelector(p.Selector)
+	if err != nil {
+		return queryError(err)
+	}
+	depth := p.MaxDepth
+	if depth <= 0 {
+		depth = defaultDepth
+	}
+	depth = min(depth, maxQueryDepth)
+
+	elements := sel.Query(b.doc.Root)
+	matches := make([]domEntry, 0, min(len(elements), maxQueryElements))
+	for _, el := range elements[:min(len(elements), maxQueryElements)] {
+		matches = append(matches, serialize(el, p.IncludeChildren, depth, 0))
+	}
+	return map[string]any{
+		"url":           b.doc.URL,
+		"title":         b.doc.Title,
+		"matchCount":    len(elements),
+		"returnedCount": len(matches),
+		"matches":       matches,
+	}
+}
+
+func queryError(err error) map[string]any {
+	return map[string]any{"error": "dom_query_failed", "message": "Failed to execute DOM query: " + err.Error()}
+}
+
+func serialize(el *Node, includeChildren bool, maxDepth, depth int) domEntry {
+	entry := domEntry{
+		Tag:     el.Tag,
+		Text:    truncateRunes(el.TextContent(), maxQueryText),
+		Visible: el.Visible(),
+	}
+	if len(el.Attrs) > 0 {
+		entry.Attributes = make(map[string]string, len(el.Attrs))
+		for _, a := range el.Attrs {
+			entry.Attributes[a.Name] = a.Value
+		}
+	}
+	if includeChildren && depth < maxDepth {
+		children := el.ElementChildren()
+		for _, c := range children[:min(len(children), maxQueryElements)] {
+			entry.Children = append(entry.Children, serialize(c, true, maxDepth, depth+1))
+		}
+	}
+	return entry
+}
+
+// a11yParams are the accessibility audit params.
+type a11yParams struct {
+	Scope string   `json:"scope"`
+	Tags  []string `json:"tags"`
+}
+
+func (b *Browser) audit(raw json.RawMessage) any {
+	var p a11yParams
+	if err := unmarshalParams(raw, &p); err != nil {
+		return auditError(err)
+	}
+	var scope *Node
+	if strings.TrimSpace(p.Scope) != "" {
+		sel, err := ParseSelector(p.Scope)
+		if err != nil {
+			return auditError(err)
+		}
+		matches := sel.Query(b.doc.Root)
+		if len(matches) == 0 {
+			return auditError(fmt.Errorf("no elements match scope %q", p.Scope))
+		}
+		scope = matches[0]
+	}
+	return Audit(b.doc, scope, p.Tags)
+}
+
+func auditError(err error) map[string]any {
+	return map[string]any{"error": "a11y_audit_failed", "message": "Failed to execute accessibility audit: " + err.Error()}
+}
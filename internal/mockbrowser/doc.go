@@ -0,0 +1,24 @@
+// Purpose: Package mockbrowser — answers extension commands from a fixture page so Kaboom runs with no browser.
+// Why: Agent developers need deterministic integration tests against the real tool surface without Chrome.
+// Docs: docs/features/feature/mock-browser/index.md
+
+/*
+Package mockbrowser stands in for the browser extension. A Browser holds a fixture
+document, parsed from an HTML file or a JSON DOM snapshot, and answers the commands
+tools queue for the extension:
+
+  - dom: analyze(what="dom") queries, in the extension's result shape;
+  - a11y: accessibility audits, with a small set of axe-core rules evaluated statically;
+  - dom_action: interact actions (click, type, select, check, get_text, get_value,
+    get_attribute, set_attribute, list_interactive, wait_for, and no-op focus, hover,
+    and scroll_to). Interactions mutate the fixture, so a typed value is visible to
+    later queries.
+
+Other command types fail with an error naming the type. A Browser is an
+extreplay.Responder, so extreplay.Driver connects it to the daemon as a simulated
+extension.
+
+There is no layout, script execution, or network: visibility comes from hidden
+attributes and inline display/visibility styles, and clicks do not navigate or submit.
+*/
+package mockbrowser
@@ -0,0 +1,404 @@
+// Purpose: Fixture DOM tree: nodes, attributes, text extraction, visibility, and fixture loading.
+// Why: Every mock-browser answer is computed from this tree, and interactions mutate it in place.
+// Docs: docs/features/feature/mock-browser/index.md
+
+package mockbrowser
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// maxFixtureBytes bounds a fixture file.
+const maxFixtureBytes = 16 << 20
+
+// Attr is one element attribute. Names are lower-case.
+type Attr struct {
+	Name  string
+	Value string
+}
+
+// Node is an element, a text node (Tag == "" with Text set), or the document root
+// (Tag == "" with no parent).
+type Node struct {
+	Tag      string
+	Attrs    []Attr
+	Text     string
+	Children []*Node
+	Parent   *Node
+
+	order int // document order, used for element IDs
+}
+
+// Document is a fixture page.
+type Document struct {
+	URL   string
+	Title string
+	Root  *Node
+}
+
+// IsElement reports whether n is an element.
+func (n *Node) IsElement() bool {
+	return n != nil && n.Tag != ""
+}
+
+// Attr returns the named attribute.
+func (n *Node) Attr(name string) (string, bool) {
+	for _, a := range n.Attrs {
+		if a.Name == name {
+			return a.Value, true
+		}
+	}
+	return "", false
+}
+
+// AttrValue returns the named attribute, or "" when absent.
+func (n *Node) AttrValue(name string) string {
+	v, _ := n.Attr(name)
+	return v
+}
+
+// SetAttr sets or adds an attribute.
+func (n *Node) SetAttr(name, value string) {
+	for i := range n.Attrs {
+		if n.Attrs[i].Name == name {
+			n.Attrs[i].Value = value
+			return
+		}
+	}
+	n.Attrs = append(n.Attrs, Attr{Name: name, Value: value})
+}
+
+// RemoveAttr removes an attribute if present.
+func (n *Node) RemoveAttr(name string) {
+	for i := range n.Attrs {
+		if n.Attrs[i].Name == name {
+			n.Attrs = append(n.Attrs[:i], n.Attrs[i+1:]...)
+			return
+		}
+	}
+}
+
+// AppendChild adds c as the last child of n.
+func (n *Node) AppendChild(c *Node) {
+	c.Parent = n
+	n.Children = append(n.Children, c)
+}
+
+// ElementChildren returns the element children of n.
+func (n *Node) ElementChildren() []*Node {
+	var out []*Node
+	for _, c := range n.Children {
+		if c.IsElement() {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// Elements returns the element descendants of n in document order.
+func (n *Node) Elements() []*Node {
+	var out []*Node
+	var walk func(*Node)
+	walk = func(p *Node) {
+		for _, c := range p.Children {
+			if c.IsElement() {
+				out = append(out, c)
+				walk(c)
+			}
+		}
+	}
+	walk(n)
+	return out
+}
+
+// Contains reports whether o is n or a descendant of n.
+func (n *Node) Contains(o *Node) bool {
+	for p := o; p != nil; p = p.Parent {
+		if p == n {
+			return true
+		}
+	}
+	return false
+}
+
+// Closest returns the nearest inclusive ancestor with the given tag.
+func (n *Node) Closest(tag string) *Node {
+	for p := n; p.IsElement(); p = p.Parent {
+		if p.Tag == tag {
+			return p
+		}
+	}
+	return nil
+}
+
+// TextContent concatenates descendant text, like DOM textContent.
+func (n *Node) TextContent() string {
+	if !n.IsElement() && n.Parent != nil {
+		return n.Text
+	}
+	var b strings.Builder
+	var walk func(*Node)
+	walk = func(p *Node) {
+		for _, c := range p.Children {
+			if c.IsElement() {
+				walk(c)
+			} else {
+				b.WriteString(c.Text)
+			}
+		}
+	}
+	walk(n)
+	return b.String()
+}
+
+// InnerText approximates innerText: visible text with whitespace collapsed.
+func (n *Node) InnerText() string {
+	var parts []string
+	var walk func(*Node)
+	walk = func(p *Node) {
+		for _, c := range p.Children {
+			switch {
+			case c.IsElement():
+				if !nonRenderedTags[c.Tag] && ownVisible(c) {
+					walk(c)
+				}
+			case strings.TrimSpace(c.Text) != "":
+				parts = append(parts, strings.Fields(c.Text)...)
+			}
+		}
+	}
+	if !n.IsElement() && n.Parent != nil {
+		return strings.Join(strings.Fields(n.Text), " ")
+	}
+	walk(n)
+	return strings.Join(parts, " ")
+}
+
+// nonRenderedTags never contribute text or layout.
+var nonRenderedTags = map[string]bool{
+	"head": true, "script": true, "style": true, "template": true, "title": true,
+	"meta": true, "link": true, "noscript": true,
+}
+
+// Visible reports whether n would render: no hidden ancestor, no display:none or
+// visibility:hidden inline style, and not a hidden input or head content.
+func (n *Node) Visible() bool {
+	for p := n; p.IsElement(); p = p.Parent {
+		if nonRenderedTags[p.Tag] || !ownVisible(p) {
+			return false
+		}
+	}
+	return true
+}
+
+func ownVisible(n *Node) bool {
+	if _, hidden := n.Attr("hidden"); hidden {
+		return false
+	}
+	if n.Tag == "input" && strings.EqualFold(n.AttrValue("type"), "hidden") {
+		return false
+	}
+	style := strings.ToLower(strings.ReplaceAll(n.AttrValue("style"), " ", ""))
+	return !strings.Contains(style, "display:none") && !strings.Contains(style, "visibility:hidden")
+}
+
+// OpenTag renders n's start tag, e.g. <input type="text" id="q">.
+func (n *Node) OpenTag() string {
+	var b strings.Builder
+	b.WriteString("<" + n.Tag)
+	for _, a := range n.Attrs {
+		b.WriteString(" " + a.Name)
+		if a.Value != "" {
+			b.WriteString(`="` + strings.ReplaceAll(a.Value, `"`, "&quot;") + `"`)
+		}
+	}
+	b.WriteString(">")
+	return b.String()
+}
+
+// OuterHTML serializes n and its subtree.
+func (n *Node) OuterHTML() string {
+	var b strings.Builder
+	n.writeHTML(&b)
+	return b.String()
+}
+
+func (n *Node) writeHTML(b *strings.Builder) {
+	if !n.IsElement() {
+		if n.Parent != nil && (n.Parent.Tag == "script" || n.Parent.Tag == "style") {
+			b.WriteString(n.Text)
+		} else {
+			b.WriteString(html.EscapeString(n.Text))
+		}
+		return
+	}
+	b.WriteString(n.OpenTag())
+	if voidTags[n.Tag] {
+		return
+	}
+	for _, c := range n.Children {
+		c.writeHTML(b)
+	}
+	b.WriteString("</" + n.Tag + ">")
+}
+
+// ElementID is the stable ID list_interactive reports and dom_action accepts.
+func (n *Node) ElementID() string {
+	return fmt.Sprintf("mock-%d", n.order)
+}
+
+// number assigns document order to every element.
+func (d *Document) number() {
+	for i, el := range d.Root.Elements() {
+		el.order = i + 1
+	}
+}
+
+// ByElementID finds the element with the given ElementID.
+func (d *Document) ByElementID(id string) *Node {
+	for _, el := range d.Root.Elements() {
+		if el.ElementID() == id {
+			return el
+		}
+	}
+	return nil
+}
+
+// ByID returns the first element with the given id attribute.
+func (d *Document) ByID(id string) *Node {
+	for _, el := range d.Root.Elements() {
+		if v, ok := el.Attr("id"); ok && v == id {
+			return el
+		}
+	}
+	return nil
+}
+
+// NewDocument wraps a parsed tree. The title defaults to the <title> element's text.
+func NewDocument(root *Node, url, title string) *Document {
+	d := &Document{URL: url, Title: title, Root: root}
+	if d.Title == "" {
+		for _, el := range root.Elements() {
+			if el.Tag == "title" {
+				d.Title = strings.TrimSpace(el.TextContent())
+				break
+			}
+		}
+	}
+	d.number()
+	return d
+}
+
+// LoadFixture reads an HTML file, or a JSON DOM snapshot when the name ends in .json.
+// The page URL defaults to the fixture's file:// URL.
+func LoadFixture(path string) (*Document, error) {
+	// #nosec G304 -- operator-supplied --mock-browser fixture path
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxFixtureBytes {
+		return nil, fmt.Errorf("fixture exceeds %d bytes", maxFixtureBytes)
+	}
+	url := "file://" + filepath.ToSlash(path)
+	if abs, err := filepath.Abs(path); err == nil {
+		url = "file://" + filepath.ToSlash(abs)
+	}
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		return ParseSnapshot(data, url)
+	}
+	return NewDocument(ParseHTML(string(data)), url, ""), nil
+}
+
+// SnapshotNode is one element of a JSON DOM snapshot, in the shape analyze(what="dom")
+// returns with include_children: tag, attributes, children, and text for leaf elements.
+type SnapshotNode struct {
+	Tag        string            `json:"tag"`
+	Attributes map[string]string `json:"attributes,omitempty"`
+	Text       string            `json:"text,omitempty"`
+	Children   []SnapshotNode    `json:"children,omitempty"`
+}
+
+// Snapshot is a JSON DOM fixture. Root is one element (usually html); Matches, the
+// field a saved analyze(what="dom") result carries, is accepted instead.
+type Snapshot struct {
+	URL     string         `json:"url,omitempty"`
+	Title   string         `json:"title,omitempty"`
+	Root    *SnapshotNode  `json:"root,omitempty"`
+	Matches []SnapshotNode `json:"matches,omitempty"`
+}
+
+// ParseSnapshot builds a document from a JSON DOM snapshot. defaultURL is used when
+// the snapshot has none.
+func ParseSnapshot(data []byte, defaultURL string) (*Document, error) {
+	var snap Snapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return nil, fmt.Errorf("invalid DOM snapshot: %w", err)
+	}
+	nodes := snap.Matches
+	if snap.Root != nil {
+		nodes = []SnapshotNode{*snap.Root}
+	}
+	if len(nodes) == 0 {
+		return nil, errors.New("DOM snapshot has no root or matches")
+	}
+	root := &Node{}
+	for _, sn := range nodes {
+		el, err := buildSnapshotNode(sn, 0)
+		if err != nil {
+			return nil, err
+		}
+		root.AppendChild(el)
+	}
+	url := snap.URL
+	if url == "" {
+		url = defaultURL
+	}
+	return NewDocument(root, url, snap.Title), nil
+}
+
+// maxSnapshotDepth bounds snapshot nesting.
+const maxSnapshotDepth = 256
+
+func buildSnapshotNode(sn SnapshotNode, depth int) (*Node, error) {
+	if depth > maxSnapshotDepth {
+		return nil, fmt.Errorf("DOM snapshot nests deeper than %d", maxSnapshotDepth)
+	}
+	tag := strings.ToLower(strings.TrimSpace(sn.Tag))
+	if tag == "" {
+		return nil, errors.New("DOM snapshot node has no tag")
+	}
+	el := &Node{Tag: tag}
+	for name, value := range sn.Attributes {
+		el.Attrs = append(el.Attrs, Attr{Name: strings.ToLower(name), Value: value})
+	}
+	sortAttrs(el.Attrs)
+	// text is textContent in a saved query result; only leaves use it, so parents
+	// do not repeat their children's text.
+	if len(sn.Children) == 0 && sn.Text != "" {
+		el.AppendChild(&Node{Text: sn.Text})
+	}
+	for _, child := range sn.Children {
+		c, err := buildSnapshotNode(child, depth+1)
+		if err != nil {
+			return nil, err
+		}
+		el.AppendChild(c)
+	}
+	return el, nil
+}
+
+// sortAttrs orders map-sourced attributes so output is deterministic.
+func sortAttrs(attrs []Attr) {
+	for i := 1; i < len(attrs); i++ {
+		for j := i; j > 0 && attrs[j].Name < attrs[j-1].Name; j-- {
+			attrs[j], attrs[j-1] = attrs[j-1], attrs[j]
+		}
+	}
+}
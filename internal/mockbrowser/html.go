@@ -0,0 +1,277 @@
+// Purpose: Lenient HTML parser for mock-browser fixtures.
+// Why: The module has no third-party dependencies, and fixtures only need a faithful element tree, not full HTML5 error recovery.
+// Docs: docs/features/feature/mock-browser/index.md
+
+package mockbrowser
+
+import (
+	"html"
+	"strings"
+)
+
+// voidTags never have children or end tags.
+var voidTags = map[string]bool{
+	"area": true, "base": true, "br": true, "col": true, "embed": true, "hr": true, "img": true,
+	"input": true, "link": true, "meta": true, "source": true, "track": true, "wbr": true,
+}
+
+// rawTextTags hold unparsed text up to their end tag.
+var rawTextTags = map[string]bool{"script": true, "style": true, "textarea": true, "title": true}
+
+// closesParagraph lists block elements whose start tag ends an open <p>.
+var closesParagraph = map[string]bool{
+	"address": true, "article": true, "aside": true, "blockquote": true, "div": true, "dl": true,
+	"fieldset": true, "footer": true, "form": true, "h1": true, "h2": true, "h3": true, "h4": true,
+	"h5": true, "h6": true, "header": true, "hr": true, "main": true, "nav": true, "ol": true,
+	"p": true, "pre": true, "section": true, "table": true, "ul": true,
+}
+
+// impliedEnd maps a start tag to the open elements it implicitly closes, and the
+// elements that bound that search.
+var impliedEnd = map[string]struct{ closes, scope []string }{
+	"li":       {[]string{"li"}, []string{"ul", "ol"}},
+	"option":   {[]string{"option"}, []string{"select", "datalist", "optgroup"}},
+	"optgroup": {[]string{"optgroup", "option"}, []string{"select"}},
+	"dt":       {[]string{"dt", "dd"}, []string{"dl"}},
+	"dd":       {[]string{"dt", "dd"}, []string{"dl"}},
+	"tr":       {[]string{"tr", "td", "th"}, []string{"table", "thead", "tbody", "tfoot"}},
+	"td":       {[]string{"td", "th"}, []string{"tr", "table"}},
+	"th":       {[]string{"td", "th"}, []string{"tr", "table"}},
+}
+
+// ParseHTML parses src into a tree under an unnamed root node. It never fails:
+// stray end tags are dropped and unclosed elements end at end of input.
+func ParseHTML(src string) *Node {
+	root := &Node{}
+	p := &htmlParser{src: src, stack: []*Node{root}}
+	p.run()
+	return root
+}
+
+type htmlParser struct {
+	src   string
+	pos   int
+	stack []*Node
+}
+
+func (p *htmlParser) current() *Node {
+	return p.stack[len(p.stack)-1]
+}
+
+func (p *htmlParser) run() {
+	for p.pos < len(p.src) {
+		lt := strings.IndexByte(p.src[p.pos:], '<')
+		if lt < 0 {
+			p.text(p.src[p.pos:])
+			return
+		}
+		if lt > 0 {
+			p.text(p.src[p.pos : p.pos+lt])
+			p.pos += lt
+		}
+		rest := p.src[p.pos:]
+		switch {
+		case strings.HasPrefix(rest, "<!--"):
+			end := strings.Index(rest[4:], "-->")
+			if end < 0 {
+				return
+			}
+			p.pos += 4 + end + 3
+		case strings.HasPrefix(rest, "<!"), strings.HasPrefix(rest, "<?"):
+			p.skipPast('>')
+		case strings.HasPrefix(rest, "</"):
+			p.endTag()
+		case len(rest) > 1 && isASCIILetter(rest[1]):
+			p.startTag()
+		default:
+			p.text("<")
+			p.pos++
+		}
+	}
+}
+
+func (p *htmlParser) skipPast(c byte) {
+	i := strings.IndexByte(p.src[p.pos:], c)
+	if i < 0 {
+		p.pos = len(p.src)
+		return
+	}
+	p.pos += i + 1
+}
+
+func (p *htmlParser) text(s string) {
+	if s == "" {
+		return
+	}
+	cur := p.current()
+	s = html.UnescapeString(s)
+	if n := len(cur.Children); n > 0 && !cur.Children[n-1].IsElement() {
+		cur.Children[n-1].Text += s
+		return
+	}
+	cur.AppendChild(&Node{Text: s})
+}
+
+func (p *htmlParser) endTag() {
+	start := p.pos + 2
+	p.skipPast('>')
+	name := strings.ToLower(strings.TrimSpace(strings.TrimSuffix(p.src[start:p.pos], ">")))
+	if i := strings.IndexAny(name, " \t\n\r/"); i >= 0 {
+		name = name[:i]
+	}
+	for i := len(p.stack) - 1; i > 0; i-- {
+		if p.stack[i].Tag == name {
+			p.stack = p.stack[:i]
+			return
+		}
+	}
+}
+
+func (p *htmlParser) startTag() {
+	i := p.pos + 1
+	for i < len(p.src) && !isSpace(p.src[i]) && p.src[i] != '>' && p.src[i] != '/' {
+		i++
+	}
+	el := &Node{Tag: strings.ToLower(p.src[p.pos+1 : i])}
+	selfClosing := false
+	for {
+		for i < len(p.src) && isSpace(p.src[i]) {
+			i++
+		}
+		if i >= len(p.src) {
+			break
+		}
+		if p.src[i] == '>' {
+			i++
+			break
+		}
+		if p.src[i] == '/' {
+			selfClosing = true
+			i++
+			continue
+		}
+		selfClosing = false
+		nameStart := i
+		for i < len(p.src) && !isSpace(p.src[i]) && p.src[i] != '>' && p.src[i] != '=' && p.src[i] != '/' {
+			i++
+		}
+		name := strings.ToLower(p.src[nameStart:i])
+		for i < len(p.src) && isSpace(p.src[i]) {
+			i++
+		}
+		value := ""
+		if i < len(p.src) && p.src[i] == '=' {
+			i++
+			for i < len(p.src) && isSpace(p.src[i]) {
+				i++
+			}
+			if i < len(p.src) && (p.src[i] == '"' || p.src[i] == '\'') {
+				q := p.src[i]
+				end := strings.IndexByte(p.src[i+1:], q)
+				if end < 0 {
+					end = len(p.src) - i - 1
+				}
+				value = p.src[i+1 : i+1+end]
+				i = min(i+2+end, len(p.src))
+			} else {
+				valStart := i
+				for i < len(p.src) && !isSpace(p.src[i]) && p.src[i] != '>' {
+					i++
+				}
+				value = p.src[valStart:i]
+			}
+		}
+		if name != "" {
+			if _, dup := el.Attr(name); !dup {
+				el.Attrs = append(el.Attrs, Attr{Name: name, Value: html.UnescapeString(value)})
+			}
+		}
+	}
+	p.pos = i
+
+	p.closeImplied(el.Tag)
+	p.current().AppendChild(el)
+	if voidTags[el.Tag] || selfClosing {
+		return
+	}
+	if rawTextTags[el.Tag] {
+		end := rawTextEnd(p.src[p.pos:], el.Tag)
+		if end < 0 {
+			end = len(p.src) - p.pos
+		}
+		if body := p.src[p.pos : p.pos+end]; body != "" {
+			if el.Tag != "script" && el.Tag != "style" {
+				body = html.UnescapeString(body)
+			}
+			if el.Tag == "textarea" {
+				body = strings.TrimPrefix(body, "\n")
+			}
+			el.AppendChild(&Node{Text: body})
+		}
+		p.pos += end
+		if p.pos < len(p.src) {
+			p.skipPast('>')
+		}
+		return
+	}
+	p.stack = append(p.stack, el)
+}
+
+// rawTextEnd returns the offset of the first "</tag" in src, matching the tag name
+// case-insensitively, or -1. It compares the original bytes: lowercasing the whole
+// body first can change byte lengths and shift the offset.
+func rawTextEnd(src, tag string) int {
+	for i := 0; ; {
+		j := strings.Index(src[i:], "</")
+		if j < 0 {
+			return -1
+		}
+		i += j
+		if rest := src[i+2:]; len(rest) >= len(tag) && strings.EqualFold(rest[:len(tag)], tag) {
+			return i
+		}
+		i += 2
+	}
+}
+
+// closeImplied pops elements the new start tag implicitly ends.
+func (p *htmlParser) closeImplied(tag string) {
+	if closesParagraph[tag] {
+		p.popTo([]string{"p"}, []string{"div", "section", "article", "main", "td", "th", "li", "button", "body"})
+	}
+	if rule, ok := impliedEnd[tag]; ok {
+		p.popTo(rule.closes, rule.scope)
+	}
+}
+
+// popTo pops through the nearest open element named in closes, unless an element
+// named in scope is reached first.
+func (p *htmlParser) popTo(closes, scope []string) {
+	for i := len(p.stack) - 1; i > 0; i-- {
+		tag := p.stack[i].Tag
+		if containsString(scope, tag) {
+			return
+		}
+		if containsString(closes, tag) {
+			p.stack = p.stack[:i]
+			return
+		}
+	}
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f'
+}
+
+func isASCIILetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
@@ -0,0 +1,284 @@
+// Purpose: Tests fixture parsing, selector matching, and the dom, a11y, and dom_action answers of the mock browser.
+// Docs: docs/features/feature/mock-browser/index.md
+
+package mockbrowser
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+)
+
+const fixtureHTML = `<!DOCTYPE html>
+<html>
+<head><title>Checkout &amp; Pay</title><style>p { color: red }</style></head>
+<body>
+  <!-- cart -->
+  <h1 id="heading">Your cart</h1>
+  <ul id="items"><li class="item sale">Apples<li class="item">Pears</ul>
+  <form id="pay">
+    <label for="email">Email</label>
+    <input id="email" type="email" placeholder="you@example.com">
+    <input type="text" name="coupon">
+    <label><input type="checkbox" name="terms"> I agree</label>
+    <select id="ship" aria-label="Shipping"><option value="std">Standard<option value="exp">Express</select>
+    <textarea id="note" title="Delivery note"></textarea>
+    <input type="hidden" name="csrf" value="t0k3n">
+    <button type="submit">Pay now</button>
+    <button class="icon"><img src="x.png"></button>
+  </form>
+  <div style="display: none"><button>Hidden</button></div>
+  <img src="logo.png" alt="Shop">
+</body>
+</html>`
+
+func newFixture(t *testing.T) *Browser {
+	t.Helper()
+	return New(NewDocument(ParseHTML(fixtureHTML), "http://shop.test/checkout", ""))
+}
+
+// respond runs one command and decodes the result object.
+func respond(t *testing.T, b *Browser, typ string, params string) map[string]any {
+	t.Helper()
+	res, err := b.Respond(capture.SyncCommand{ID: "q-1", Type: typ, Params: json.RawMessage(params), CorrelationID: "c-1"})
+	if err != nil {
+		t.Fatalf("%s %s: %v", typ, params, err)
+	}
+	if res.ID != "q-1" || res.CorrelationID != "c-1" || res.Status != "complete" {
+		t.Fatalf("result envelope = %+v", res)
+	}
+	var out map[string]any
+	if err := json.Unmarshal(res.Result, &out); err != nil {
+		t.Fatal(err)
+	}
+	return out
+}
+
+func TestParseHTMLAndSelectors(t *testing.T) {
+	t.Parallel()
+	doc := NewDocument(ParseHTML(fixtureHTML), "", "")
+	if doc.Title != "Checkout & Pay" {
+		t.Errorf("title = %q", doc.Title)
+	}
+	cases := map[string]int{
+		"li":                         2, // implied </li>
+		"ul > li.item":               2,
+		"li.item.sale":               1,
+		"li:first-child + li":        1,
+		"#pay input[type=email]":     1,
+		"input[name^=cou]":           1,
+		"form > *:not(label)":        7,
+		"option:checked":             0,
+		"select option:nth-child(2)": 1,
+		"h1 ~ form button":           2,
+		"[placeholder$='.com']":      1,
+		"img, h1":                    3,
+	}
+	for sel, want := range cases {
+		parsed, err := ParseSelector(sel)
+		if err != nil {
+			t.Errorf("%s: %v", sel, err)
+			continue
+		}
+		if got := len(parsed.Query(doc.Root)); got != want {
+			t.Errorf("%s matched %d, want %d", sel, got, want)
+		}
+	}
+	if _, err := ParseSelector("a:hover"); err == nil {
+		t.Error("unsupported pseudo-class should be rejected")
+	}
+
+	email := doc.ByID("email")
+	if sel := uniqueSelector(doc, email); sel != "#email" {
+		t.Errorf("unique selector = %q", sel)
+	}
+	pears := mustParseSelector("li:last-child").Query(doc.Root)[0]
+	if sel := uniqueSelector(doc, pears); sel != "#items > li:nth-child(2)" {
+		t.Errorf("unique selector = %q", sel)
+	}
+	if got := AccessibleName(doc, email); got != "Email" {
+		t.Errorf("email name = %q", got)
+	}
+	terms := mustParseSelector("[name=terms]").Query(doc.Root)[0]
+	if got := AccessibleName(doc, terms); got != "I agree" {
+		t.Errorf("wrapped label name = %q", got)
+	}
+}
+
+func TestParseHTMLRawTextNonASCII(t *testing.T) {
+	t.Parallel()
+	// "Ⱥ" lowercases to a longer encoding, so offsets into a lowercased copy drift.
+	title := strings.Repeat("Ⱥ", 20)
+	script := `var s = "ȺȺ İstanbul";`
+	style := `p::after { content: "Ⱥ" }`
+	doc := NewDocument(ParseHTML("<html><head><title>"+title+"</TITLE><script>"+script+"</Script><style>"+style+"</style></head><body><p>after</p></body></html>"), "", "")
+	if doc.Title != title {
+		t.Errorf("title = %q, want %q", doc.Title, title)
+	}
+	for tag, want := range map[string]string{"script": script, "style": style} {
+		el := mustParseSelector(tag).Query(doc.Root)[0]
+		if len(el.Children) != 1 || el.Children[0].Text != want {
+			t.Errorf("%s body = %+v, want %q", tag, el.Children, want)
+		}
+	}
+	if got := len(mustParseSelector("body > p").Query(doc.Root)); got != 1 {
+		t.Errorf("content after raw text matched %d paragraphs, want 1", got)
+	}
+}
+
+func TestDOMQuery(t *testing.T) {
+	t.Parallel()
+	b := newFixture(t)
+	out := respond(t, b, "dom", `{"selector":"ul","include_children":true}`)
+	if out["url"] != "http://shop.test/checkout" || out["title"] != "Checkout & Pay" || out["matchCount"] != 1.0 {
+		t.Fatalf("query = %v", out)
+	}
+	ul := out["matches"].([]any)[0].(map[string]any)
+	if ul["text"] != "ApplesPears" || ul["visible"] != true || ul["attributes"].(map[string]any)["id"] != "items" {
+		t.Errorf("ul = %v", ul)
+	}
+	if children := ul["children"].([]any); len(children) != 2 {
+		t.Errorf("children = %v", children)
+	}
+	hidden := respond(t, b, "dom", `{"selector":"div button"}`)["matches"].([]any)[0].(map[string]any)
+	if hidden["visible"] != false {
+		t.Errorf("display:none ancestor should hide the button: %v", hidden)
+	}
+	if out := respond(t, b, "dom", `{"selector":"a:hover"}`); out["error"] != "dom_query_failed" {
+		t.Errorf("bad selector = %v", out)
+	}
+}
+
+func TestA11yAudit(t *testing.T) {
+	t.Parallel()
+	b := newFixture(t)
+	out := respond(t, b, "a11y", `{}`)
+	got := map[string]int{}
+	for _, v := range out["violations"].([]any) {
+		v := v.(map[string]any)
+		got[v["id"].(string)] = len(v["nodes"].([]any))
+	}
+	// The coupon field has no label, the icon button and its image have no text, and
+	// <html> has no lang; the hidden button is not audited.
+	want := map[string]int{"label": 1, "button-name": 1, "image-alt": 1, "html-has-lang": 1}
+	if len(got) != len(want) {
+		t.Fatalf("violations = %v, want %v", got, want)
+	}
+	for id, n := range want {
+		if got[id] != n {
+			t.Errorf("%s nodes = %d, want %d", id, got[id], n)
+		}
+	}
+	summary := out["summary"].(map[string]any)
+	if summary["violations"] != 4.0 || summary["passes"] != 1.0 || summary["inapplicable"] != 1.0 {
+		t.Errorf("summary = %v", summary)
+	}
+
+	scoped := respond(t, b, "a11y", `{"scope":"#pay","tags":["cat.forms"]}`)
+	if vs := scoped["violations"].([]any); len(vs) != 1 || vs[0].(map[string]any)["id"] != "label" {
+		t.Errorf("scoped audit = %v", vs)
+	}
+}
+
+func TestDOMActions(t *testing.T) {
+	t.Parallel()
+	b := newFixture(t)
+	act := func(params string) map[string]any {
+		t.Helper()
+		return respond(t, b, "dom_action", params)
+	}
+
+	if out := act(`{"action":"type","selector":"#email","text":"a@b.co"}`); out["success"] != true || out["value"] != "a@b.co" {
+		t.Fatalf("type = %v", out)
+	}
+	act(`{"action":"type","selector":"label=Email","text":"m"}`)
+	if out := act(`{"action":"get_value","selector":"#email"}`); out["value"] != "a@b.com" {
+		t.Errorf("typed value = %v", out)
+	}
+	act(`{"action":"type","selector":"#note","text":"leave at door","clear":true}`)
+	if out := respond(t, b, "dom", `{"selector":"#note"}`); out["matches"].([]any)[0].(map[string]any)["text"] != "leave at door" {
+		t.Errorf("textarea not updated: %v", out)
+	}
+
+	act(`{"action":"select","selector":"#ship","value":"exp"}`)
+	if out := act(`{"action":"get_value","selector":"#ship"}`); out["value"] != "exp" {
+		t.Errorf("select = %v", out)
+	}
+	if out := act(`{"action":"check","selector":"[name=terms]"}`); out["value"] != true {
+		t.Errorf("check = %v", out)
+	}
+	if out := act(`{"action":"click","selector":"text=I agree"}`); out["success"] != true {
+		t.Errorf("click label = %v", out)
+	}
+
+	if out := act(`{"action":"get_text","selector":"h1"}`); out["value"] != "Your cart" {
+		t.Errorf("get_text = %v", out)
+	}
+	if out := act(`{"action":"get_attribute","selector":"#email","name":"data-x"}`); out["value"] != nil || out["reason"] != "attribute_not_found" {
+		t.Errorf("missing attribute = %v", out)
+	}
+
+	// Two visible buttons: mutating actions need disambiguation, nth picks one.
+	if out := act(`{"action":"click","selector":"button"}`); out["error"] != "ambiguous_target" || len(out["candidates"].([]any)) != 2 {
+		t.Errorf("ambiguous click = %v", out)
+	}
+	if out := act(`{"action":"click","selector":"button","nth":-1}`); out["success"] != true || out["match_strategy"] != "nth_param" {
+		t.Errorf("nth click = %v", out)
+	}
+	if out := act(`{"action":"click","selector":"#missing"}`); out["error"] != "element_not_found" {
+		t.Errorf("missing = %v", out)
+	}
+	if out := act(`{"action":"type","selector":"h1","text":"x"}`); out["error"] != "not_typeable" {
+		t.Errorf("not typeable = %v", out)
+	}
+	if out := act(`{"action":"wait_for","selector":".spinner","timeout_ms":100}`); out["error"] != "timeout" {
+		t.Errorf("wait_for = %v", out)
+	}
+
+	list := act(`{"action":"list_interactive","visible_only":true}`)
+	elements := list["elements"].([]any)
+	if list["candidate_count"] != float64(len(elements)) || len(elements) != 7 {
+		t.Fatalf("list_interactive = %v", list)
+	}
+	var payID string
+	for _, e := range elements {
+		e := e.(map[string]any)
+		if e["label"] == "Pay now" {
+			payID = e["element_id"].(string)
+		}
+	}
+	if out := act(`{"action":"click","element_id":"` + payID + `"}`); out["success"] != true || out["match_strategy"] != "element_id" {
+		t.Errorf("click by element_id = %v", out)
+	}
+
+	if _, err := b.Respond(capture.SyncCommand{ID: "q-2", Type: "screenshot"}); err == nil || !strings.Contains(err.Error(), "screenshot") {
+		t.Errorf("unsupported command err = %v", err)
+	}
+}
+
+func TestLoadJSONSnapshot(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "page.json")
+	snapshot := `{"title":"Saved","matches":[{"tag":"html","attributes":{"lang":"en"},"children":[
+		{"tag":"body","children":[{"tag":"button","attributes":{"id":"go"},"text":"Go"}]}]}]}`
+	if err := os.WriteFile(path, []byte(snapshot), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	b, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := b.Settings(); !strings.HasPrefix(s.TrackedTabURL, "file://") || s.TrackedTabTitle != "Saved" || !s.PilotEnabled {
+		t.Errorf("settings = %+v", s)
+	}
+	if out := respond(t, b, "dom_action", `{"action":"get_text","selector":"#go"}`); out["value"] != "Go" {
+		t.Errorf("get_text = %v", out)
+	}
+	if _, err := ParseSnapshot([]byte(`{"root":{"tag":""}}`), ""); err == nil {
+		t.Error("snapshot node without a tag should be rejected")
+	}
+}
@@ -0,0 +1,555 @@
+// Purpose: CSS selector parsing and matching over the fixture tree.
+// Why: dom queries, audits, and interactions all target elements by the same CSS selectors agents send to the real extension.
+// Docs: docs/features/feature/mock-browser/index.md
+
+package mockbrowser
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Selector is a parsed selector list (comma-separated complex selectors).
+type Selector struct {
+	src  string
+	list []complexSelector
+}
+
+// complexSelector is compounds joined by combinators; combs[i] joins parts[i] and parts[i+1].
+type complexSelector struct {
+	parts []compound
+	combs []byte
+}
+
+type compound struct {
+	tag     string // "" or "*" matches any element
+	ids     []string
+	classes []string
+	attrs   []attrSelector
+	pseudos []pseudoSelector
+}
+
+type attrSelector struct {
+	name, op, value string
+}
+
+type pseudoSelector struct {
+	name string
+	a, b int       // nth-child(an+b)
+	not  *Selector // :not(...)
+}
+
+// String returns the source text.
+func (s *Selector) String() string {
+	return s.src
+}
+
+// ParseSelector parses a CSS selector list. Supported: type, universal, #id, .class,
+// attribute ([a], =, ^=, $=, *=, ~=, |=), the descendant, child, and sibling combinators,
+// and the pseudo-classes first-child, last-child, only-child, nth-child, checked,
+// disabled, enabled, and not().
+func ParseSelector(src string) (*Selector, error) {
+	p := &selectorParser{src: src}
+	sel, err := p.parseList()
+	if err != nil {
+		return nil, fmt.Errorf("invalid selector %q: %w", src, err)
+	}
+	if p.pos < len(p.src) {
+		return nil, fmt.Errorf("invalid selector %q: unexpected %q", src, p.src[p.pos:])
+	}
+	return sel, nil
+}
+
+type selectorParser struct {
+	src string
+	pos int
+}
+
+func (p *selectorParser) skipSpace() bool {
+	start := p.pos
+	for p.pos < len(p.src) && isSpace(p.src[p.pos]) {
+		p.pos++
+	}
+	return p.pos > start
+}
+
+func (p *selectorParser) peek() byte {
+	if p.pos < len(p.src) {
+		return p.src[p.pos]
+	}
+	return 0
+}
+
+func (p *selectorParser) parseList() (*Selector, error) {
+	start := p.pos
+	sel := &Selector{}
+	for {
+		p.skipSpace()
+		cs, err := p.parseComplex()
+		if err != nil {
+			return nil, err
+		}
+		sel.list = append(sel.list, cs)
+		p.skipSpace()
+		if p.peek() != ',' {
+			break
+		}
+		p.pos++
+	}
+	sel.src = strings.TrimSpace(p.src[start:p.pos])
+	return sel, nil
+}
+
+func (p *selectorParser) parseComplex() (complexSelector, error) {
+	var cs complexSelector
+	for {
+		c, err := p.parseCompound()
+		if err != nil {
+			return cs, err
+		}
+		cs.parts = append(cs.parts, c)
+		spaced := p.skipSpace()
+		switch ch := p.peek(); ch {
+		case '>', '+', '~':
+			p.pos++
+			p.skipSpace()
+			cs.combs = append(cs.combs, ch)
+		case 0, ',', ')':
+			return cs, nil
+		default:
+			if !spaced {
+				return cs, fmt.Errorf("unexpected %q", string(ch))
+			}
+			cs.combs = append(cs.combs, ' ')
+		}
+	}
+}
+
+func (p *selectorParser) parseCompound() (compound, error) {
+	var c compound
+	start := p.pos
+	if p.peek() == '*' {
+		p.pos++
+		c.tag = "*"
+	} else if name := p.ident(); name != "" {
+		c.tag = strings.ToLower(name)
+	}
+	for {
+		switch p.peek() {
+		case '#':
+			p.pos++
+			id := p.ident()
+			if id == "" {
+				return c, fmt.Errorf("empty id")
+			}
+			c.ids = append(c.ids, id)
+		case '.':
+			p.pos++
+			class := p.ident()
+			if class == "" {
+				return c, fmt.Errorf("empty class")
+			}
+			c.classes = append(c.classes, class)
+		case '[':
+			a, err := p.parseAttr()
+			if err != nil {
+				return c, err
+			}
+			c.attrs = append(c.attrs, a)
+		case ':':
+			ps, err := p.parsePseudo()
+			if err != nil {
+				return c, err
+			}
+			c.pseudos = append(c.pseudos, ps)
+		default:
+			if p.pos == start {
+				if p.pos >= len(p.src) {
+					return c, fmt.Errorf("empty selector")
+				}
+				return c, fmt.Errorf("unexpected %q", string(p.src[p.pos]))
+			}
+			return c, nil
+		}
+	}
+}
+
+// ident reads a CSS identifier, honouring backslash escapes.
+func (p *selectorParser) ident() string {
+	var b strings.Builder
+	for p.pos < len(p.src) {
+		ch := p.src[p.pos]
+		switch {
+		case ch == '\\' && p.pos+1 < len(p.src):
+			b.WriteByte(p.src[p.pos+1])
+			p.pos += 2
+		case ch == '-' || ch == '_' || ch >= 0x80 || isASCIILetter(ch) || (ch >= '0' && ch <= '9'):
+			b.WriteByte(ch)
+			p.pos++
+		default:
+			return b.String()
+		}
+	}
+	return b.String()
+}
+
+func (p *selectorParser) parseAttr() (attrSelector, error) {
+	p.pos++ // [
+	p.skipSpace()
+	a := attrSelector{name: strings.ToLower(p.ident())}
+	if a.name == "" {
+		return a, fmt.Errorf("empty attribute name")
+	}
+	p.skipSpace()
+	if p.peek() == ']' {
+		p.pos++
+		return a, nil
+	}
+	switch {
+	case p.peek() == '=':
+		a.op = "="
+		p.pos++
+	case p.pos+1 < len(p.src) && p.src[p.pos+1] == '=' && strings.IndexByte("^$*~|", p.peek()) >= 0:
+		a.op = p.src[p.pos : p.pos+2]
+		p.pos += 2
+	default:
+		return a, fmt.Errorf("bad attribute operator")
+	}
+	p.skipSpace()
+	if q := p.peek(); q == '"' || q == '\'' {
+		end := strings.IndexByte(p.src[p.pos+1:], q)
+		if end < 0 {
+			return a, fmt.Errorf("unterminated attribute value")
+		}
+		a.value = p.src[p.pos+1 : p.pos+1+end]
+		p.pos += end + 2
+	} else {
+		a.value = p.ident()
+	}
+	p.skipSpace()
+	if p.peek() != ']' {
+		return a, fmt.Errorf("unterminated attribute selector")
+	}
+	p.pos++
+	return a, nil
+}
+
+func (p *selectorParser) parsePseudo() (pseudoSelector, error) {
+	p.pos++ // :
+	ps := pseudoSelector{name: strings.ToLower(p.ident())}
+	switch ps.name {
+	case "first-child", "last-child", "only-child", "checked", "disabled", "enabled":
+		return ps, nil
+	case "nth-child", "not":
+	default:
+		return ps, fmt.Errorf("unsupported pseudo-class :%s", ps.name)
+	}
+	if p.peek() != '(' {
+		return ps, fmt.Errorf(":%s needs an argument", ps.name)
+	}
+	p.pos++
+	if ps.name == "not" {
+		inner, err := p.parseList()
+		if err != nil {
+			return ps, err
+		}
+		ps.not = inner
+	} else {
+		end := strings.IndexByte(p.src[p.pos:], ')')
+		if end < 0 {
+			return ps, fmt.Errorf("unterminated :nth-child")
+		}
+		a, b, err := parseNth(p.src[p.pos : p.pos+end])
+		if err != nil {
+			return ps, err
+		}
+		ps.a, ps.b = a, b
+		p.pos += end
+	}
+	p.skipSpace()
+	if p.peek() != ')' {
+		return ps, fmt.Errorf("unterminated :%s", ps.name)
+	}
+	p.pos++
+	return ps, nil
+}
+
+// parseNth parses an+b, odd, even, or an integer.
+func parseNth(s string) (int, int, error) {
+	s = strings.ToLower(strings.ReplaceAll(s, " ", ""))
+	switch s {
+	case "odd":
+		return 2, 1, nil
+	case "even":
+		return 2, 0, nil
+	}
+	n := strings.IndexByte(s, 'n')
+	if n < 0 {
+		b, err := strconv.Atoi(s)
+		return 0, b, err
+	}
+	a := 1
+	switch coef := s[:n]; coef {
+	case "", "+":
+	case "-":
+		a = -1
+	default:
+		v, err := strconv.Atoi(coef)
+		if err != nil {
+			return 0, 0, fmt.Errorf("bad :nth-child(%s)", s)
+		}
+		a = v
+	}
+	b := 0
+	if rest := s[n+1:]; rest != "" {
+		v, err := strconv.Atoi(rest)
+		if err != nil {
+			return 0, 0, fmt.Errorf("bad :nth-child(%s)", s)
+		}
+		b = v
+	}
+	return a, b, nil
+}
+
+// Matches reports whether el matches any selector in the list.
+func (s *Selector) Matches(el *Node) bool {
+	for _, cs := range s.list {
+		if cs.matchAt(len(cs.parts)-1, el) {
+			return true
+		}
+	}
+	return false
+}
+
+// Query returns the element descendants of scope that match, in document order.
+func (s *Selector) Query(scope *Node) []*Node {
+	var out []*Node
+	for _, el := range scope.Elements() {
+		if s.Matches(el) {
+			out = append(out, el)
+		}
+	}
+	return out
+}
+
+// matchAt matches parts[i] against el, then the combinators to its left.
+func (cs complexSelector) matchAt(i int, el *Node) bool {
+	if !cs.parts[i].matches(el) {
+		return false
+	}
+	if i == 0 {
+		return true
+	}
+	switch cs.combs[i-1] {
+	case '>':
+		return el.Parent.IsElement() && cs.matchAt(i-1, el.Parent)
+	case '+':
+		prev := previousElement(el)
+		return prev != nil && cs.matchAt(i-1, prev)
+	case '~':
+		for prev := previousElement(el); prev != nil; prev = previousElement(prev) {
+			if cs.matchAt(i-1, prev) {
+				return true
+			}
+		}
+		return false
+	default:
+		for anc := el.Parent; anc.IsElement(); anc = anc.Parent {
+			if cs.matchAt(i-1, anc) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+func (c compound) matches(el *Node) bool {
+	if !el.IsElement() || (c.tag != "" && c.tag != "*" && c.tag != el.Tag) {
+		return false
+	}
+	for _, id := range c.ids {
+		if el.AttrValue("id") != id {
+			return false
+		}
+	}
+	if len(c.classes) > 0 {
+		have := strings.Fields(el.AttrValue("class"))
+		for _, class := range c.classes {
+			if !containsString(have, class) {
+				return false
+			}
+		}
+	}
+	for _, a := range c.attrs {
+		if !a.matches(el) {
+			return false
+		}
+	}
+	for _, ps := range c.pseudos {
+		if !ps.matches(el) {
+			return false
+		}
+	}
+	return true
+}
+
+func (a attrSelector) matches(el *Node) bool {
+	v, ok := el.Attr(a.name)
+	if !ok {
+		return false
+	}
+	switch a.op {
+	case "":
+		return true
+	case "=":
+		return v == a.value
+	case "^=":
+		return a.value != "" && strings.HasPrefix(v, a.value)
+	case "$=":
+		return a.value != "" && strings.HasSuffix(v, a.value)
+	case "*=":
+		return a.value != "" && strings.Contains(v, a.value)
+	case "~=":
+		return containsString(strings.Fields(v), a.value)
+	case "|=":
+		return v == a.value || strings.HasPrefix(v, a.value+"-")
+	}
+	return false
+}
+
+func (ps pseudoSelector) matches(el *Node) bool {
+	switch ps.name {
+	case "first-child":
+		return previousElement(el) == nil
+	case "last-child":
+		return nextElement(el) == nil
+	case "only-child":
+		return previousElement(el) == nil && nextElement(el) == nil
+	case "nth-child":
+		pos := 1
+		for prev := previousElement(el); prev != nil; prev = previousElement(prev) {
+			pos++
+		}
+		if ps.a == 0 {
+			return pos == ps.b
+		}
+		n := pos - ps.b
+		return n%ps.a == 0 && n/ps.a >= 0
+	case "checked":
+		if el.Tag == "option" {
+			_, ok := el.Attr("selected")
+			return ok
+		}
+		_, ok := el.Attr("checked")
+		return ok && el.Tag == "input"
+	case "disabled":
+		return isDisabled(el)
+	case "enabled":
+		return isFormControl(el) && !isDisabled(el)
+	case "not":
+		return !ps.not.Matches(el)
+	}
+	return false
+}
+
+func previousElement(el *Node) *Node {
+	if el.Parent == nil {
+		return nil
+	}
+	var prev *Node
+	for _, c := range el.Parent.Children {
+		if c == el {
+			return prev
+		}
+		if c.IsElement() {
+			prev = c
+		}
+	}
+	return nil
+}
+
+func nextElement(el *Node) *Node {
+	if el.Parent == nil {
+		return nil
+	}
+	seen := false
+	for _, c := range el.Parent.Children {
+		if seen && c.IsElement() {
+			return c
+		}
+		if c == el {
+			seen = true
+		}
+	}
+	return nil
+}
+
+func isFormControl(el *Node) bool {
+	switch el.Tag {
+	case "input", "button", "select", "textarea", "option", "optgroup", "fieldset":
+		return true
+	}
+	return false
+}
+
+// isDisabled reports the disabled attribute on el or an enclosing fieldset.
+func isDisabled(el *Node) bool {
+	if !isFormControl(el) {
+		return false
+	}
+	for p := el; p.IsElement(); p = p.Parent {
+		if _, ok := p.Attr("disabled"); ok && (p == el || p.Tag == "fieldset") {
+			return true
+		}
+	}
+	return false
+}
+
+// uniqueSelector returns a selector that matches only el in doc: #id when the id is
+// unique, otherwise a tag:nth-child path from the nearest uniquely identified ancestor.
+func uniqueSelector(doc *Document, el *Node) string {
+	var path []string
+	for p := el; p.IsElement(); p = p.Parent {
+		if id := p.AttrValue("id"); id != "" && isPlainIdent(id) && doc.ByID(id) == p && countID(doc, id) == 1 {
+			path = append(path, "#"+id)
+			break
+		}
+		pos := 1
+		for prev := previousElement(p); prev != nil; prev = previousElement(prev) {
+			pos++
+		}
+		if p.Parent.IsElement() || pos > 1 || nextElement(p) != nil {
+			path = append(path, fmt.Sprintf("%s:nth-child(%d)", p.Tag, pos))
+		} else {
+			path = append(path, p.Tag)
+		}
+	}
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return strings.Join(path, " > ")
+}
+
+func countID(doc *Document, id string) int {
+	n := 0
+	for _, el := range doc.Root.Elements() {
+		if el.AttrValue("id") == id {
+			n++
+		}
+	}
+	return n
+}
+
+func isPlainIdent(s string) bool {
+	if s == "" || (s[0] >= '0' && s[0] <= '9') {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if !(c == '-' || c == '_' || isASCIILetter(c) || (c >= '0' && c <= '9')) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,27 @@
+// Purpose: Package netbudget — per-URL-pattern response duration and size budgets checked on ingest.
+// Why: The waterfall is passive; a budget turns "this endpoint got slow or heavy" into an alert the moment it happens.
+// Docs: docs/features/feature/network-budgets/index.md
+
+/*
+Package netbudget checks every ingested network body and resource timing entry against
+registered budgets. A budget pairs a URL pattern with a maximum duration, a maximum
+response size, or both. An over-budget response is recorded as a Violation keyed by
+budget, metric, and URL, so a slow endpoint polled a hundred times is one violation
+with a count of 100 and the worst value seen.
+
+Patterns:
+  - A URL substring, e.g. "/api/search".
+  - A whole-URL glob when the pattern contains *, e.g. "https://cdn.example.com/*.js".
+
+Measurements:
+  - Duration: the body's duration in ms, or the resource timing entry's duration.
+  - Size: the resource timing transfer size (encoded body size for cache hits), or the
+    captured response body length. A truncated body is a lower bound, so it can only
+    prove a violation.
+
+Key types:
+  - Monitor: the registry; ObserveNetwork returns first-time violations.
+  - Budget: one registered pattern with its limits and violation totals.
+  - Violation: one over-budget URL and metric with evidence, count, and worst value.
+*/
+package netbudget
@@ -0,0 +1,333 @@
+// Purpose: Holds registered network budgets and checks each ingested body and resource timing batch against them.
+// Why: A response that blows its duration or size budget must alert as it arrives, not when someone reads the waterfall.
+// Docs: docs/features/feature/network-budgets/index.md
+
+package netbudget
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+)
+
+// Metrics.
+const (
+	MetricDuration = "duration"
+	MetricSize     = "size"
+)
+
+const (
+	// MaxBudgets caps registrations per daemon.
+	MaxBudgets = 50
+	// maxPatternLen bounds a budget's URL pattern.
+	maxPatternLen = 500
+	// maxViolations bounds retained violations; the oldest are evicted first.
+	maxViolations = 500
+)
+
+// Registration errors.
+var (
+	ErrTooManyBudgets = fmt.Errorf("network budget limit reached (%d)", MaxBudgets)
+	ErrUnknownBudget  = errors.New("unknown budget id")
+	ErrInvalidPattern = fmt.Errorf("pattern must be 1-%d characters", maxPatternLen)
+	ErrNoLimit        = errors.New("a budget needs max_duration_ms, max_size_kb, or both")
+	ErrNegativeLimit  = errors.New("max_duration_ms and max_size_kb must not be negative")
+)
+
+// Budget is one registered URL pattern with its limits. A zero limit is not checked.
+type Budget struct {
+	ID            string    `json:"id"`
+	Pattern       string    `json:"pattern"`
+	MaxDurationMs int       `json:"max_duration_ms,omitempty"`
+	MaxSizeKB     float64   `json:"max_size_kb,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+	// Violations counts distinct over-budget URLs; Exceedances counts every over-budget response.
+	Violations      int        `json:"violations"`
+	Exceedances     int        `json:"exceedances"`
+	LastViolationAt *time.Time `json:"last_violation_at,omitempty"`
+
+	glob *regexp.Regexp
+}
+
+// Evidence is the response that broke the budget first.
+type Evidence struct {
+	URL           string `json:"url"`
+	Method        string `json:"method,omitempty"`
+	Status        int    `json:"status,omitempty"`
+	InitiatorType string `json:"initiator_type,omitempty"`
+	PageURL       string `json:"page_url,omitempty"`
+	TabID         int    `json:"tab_id,omitempty"`
+	Timestamp     string `json:"ts,omitempty"`
+	// Source is "network_bodies" or "network_waterfall".
+	Source string `json:"source"`
+}
+
+// Violation is one URL over one budget metric. Limit, Actual, and Worst are in Unit:
+// ms for duration, KB for size. Actual is from the first occurrence.
+type Violation struct {
+	BudgetID  string    `json:"budget_id"`
+	Pattern   string    `json:"pattern"`
+	Metric    string    `json:"metric"`
+	Unit      string    `json:"unit"`
+	Limit     float64   `json:"limit"`
+	Actual    float64   `json:"actual"`
+	Worst     float64   `json:"worst"`
+	Evidence  Evidence  `json:"evidence"`
+	Count     int       `json:"count"`
+	FirstSeen time.Time `json:"first_seen"`
+	LastSeen  time.Time `json:"last_seen"`
+}
+
+// Summary is the monitor state returned by observe(what="budget_violations").
+type Summary struct {
+	// Status is "no_budgets", "ok", or "over_budget".
+	Status          string         `json:"status"`
+	Budgets         []Budget       `json:"budgets"`
+	TotalViolations int            `json:"total_violations"`
+	ByMetric        map[string]int `json:"by_metric"`
+	Violations      []Violation    `json:"violations"`
+}
+
+// Monitor is a thread-safe budget registry. ObserveNetwork runs on the ingest callback
+// outside the Capture lock; Monitor.mu is a leaf lock.
+type Monitor struct {
+	mu         sync.Mutex
+	budgets    map[string]*Budget
+	order      []string
+	nextID     int
+	violations map[string]*Violation
+	vorder     []string
+}
+
+// NewMonitor returns an empty monitor.
+func NewMonitor() *Monitor {
+	return &Monitor{budgets: make(map[string]*Budget), violations: make(map[string]*Violation)}
+}
+
+// Add registers a budget for pattern. A budget with the same pattern is updated in place,
+// keeping its ID and dropping its violations; replaced reports that case.
+func (m *Monitor) Add(pattern string, maxDurationMs int, maxSizeKB float64, now time.Time) (b Budget, replaced bool, err error) {
+	pattern = strings.TrimSpace(pattern)
+	switch {
+	case pattern == "" || len(pattern) > maxPatternLen:
+		return Budget{}, false, ErrInvalidPattern
+	case maxDurationMs < 0 || maxSizeKB < 0:
+		return Budget{}, false, ErrNegativeLimit
+	case maxDurationMs == 0 && maxSizeKB == 0:
+		return Budget{}, false, ErrNoLimit
+	}
+	var glob *regexp.Regexp
+	if strings.Contains(pattern, "*") {
+		glob = regexp.MustCompile("^" + strings.ReplaceAll(regexp.QuoteMeta(pattern), `\*`, ".*") + "$")
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, id := range m.order {
+		if existing := m.budgets[id]; existing.Pattern == pattern {
+			existing.MaxDurationMs, existing.MaxSizeKB = maxDurationMs, maxSizeKB
+			existing.Violations, existing.Exceedances, existing.LastViolationAt = 0, 0, nil
+			m.dropViolationsLocked(id)
+			return *existing, true, nil
+		}
+	}
+	if len(m.budgets) >= MaxBudgets {
+		return Budget{}, false, ErrTooManyBudgets
+	}
+	m.nextID++
+	budget := &Budget{
+		ID: "budget-" + strconv.Itoa(m.nextID), Pattern: pattern,
+		MaxDurationMs: maxDurationMs, MaxSizeKB: maxSizeKB, CreatedAt: now, glob: glob,
+	}
+	m.budgets[budget.ID] = budget
+	m.order = append(m.order, budget.ID)
+	return *budget, false, nil
+}
+
+// Remove unregisters a budget and drops its violations.
+func (m *Monitor) Remove(id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.budgets[id]; !ok {
+		return ErrUnknownBudget
+	}
+	delete(m.budgets, id)
+	m.order = removeID(m.order, id)
+	m.dropViolationsLocked(id)
+	return nil
+}
+
+// Clear unregisters every budget and returns how many were removed.
+func (m *Monitor) Clear() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	n := len(m.budgets)
+	m.budgets = make(map[string]*Budget)
+	m.order = nil
+	m.violations = make(map[string]*Violation)
+	m.vorder = nil
+	return n
+}
+
+// List returns budgets in creation order.
+func (m *Monitor) List() []Budget {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.listLocked()
+}
+
+// Summary returns every budget and retained violations, most recently seen first.
+// urlFilter keeps violations whose URL contains it; limit <= 0 returns all.
+func (m *Monitor) Summary(urlFilter string, limit int) Summary {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	s := Summary{Budgets: m.listLocked(), ByMetric: map[string]int{}, Violations: []Violation{}}
+	for _, v := range m.violations {
+		if urlFilter != "" && !strings.Contains(v.Evidence.URL, urlFilter) {
+			continue
+		}
+		s.Violations = append(s.Violations, *v)
+		s.ByMetric[v.Metric]++
+	}
+	s.TotalViolations = len(s.Violations)
+	sort.Slice(s.Violations, func(i, j int) bool {
+		if !s.Violations[i].LastSeen.Equal(s.Violations[j].LastSeen) {
+			return s.Violations[i].LastSeen.After(s.Violations[j].LastSeen)
+		}
+		return s.Violations[i].FirstSeen.After(s.Violations[j].FirstSeen)
+	})
+	if limit > 0 && len(s.Violations) > limit {
+		s.Violations = s.Violations[:limit]
+	}
+	switch {
+	case len(s.Budgets) == 0:
+		s.Status = "no_budgets"
+	case s.TotalViolations == 0:
+		s.Status = "ok"
+	default:
+		s.Status = "over_budget"
+	}
+	return s
+}
+
+// ObserveNetwork checks one ingested network batch and returns violations seen for the first time.
+func (m *Monitor) ObserveNetwork(activity capture.NetworkActivity, now time.Time) []Violation {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if len(m.budgets) == 0 {
+		return nil
+	}
+	var fresh []Violation
+	for _, id := range m.order {
+		b := m.budgets[id]
+		for _, body := range activity.Bodies {
+			if !b.matches(body.URL) {
+				continue
+			}
+			ev := Evidence{
+				URL: body.URL, Method: body.Method, Status: body.Status, PageURL: activity.PageURL,
+				TabID: body.TabID, Timestamp: body.Timestamp, Source: "network_bodies",
+			}
+			fresh = m.checkLocked(b, ev, float64(body.Duration), len(body.ResponseBody), now, fresh)
+		}
+		for _, entry := range activity.Waterfall {
+			if !b.matches(entry.URL) {
+				continue
+			}
+			pageURL := entry.PageURL
+			if pageURL == "" {
+				pageURL = activity.PageURL
+			}
+			size := entry.TransferSize
+			if size == 0 {
+				size = entry.EncodedBodySize // cache hit: nothing transferred, but the payload is as heavy
+			}
+			ev := Evidence{URL: entry.URL, InitiatorType: entry.InitiatorType, PageURL: pageURL, Source: "network_waterfall"}
+			fresh = m.checkLocked(b, ev, entry.Duration, size, now, fresh)
+		}
+	}
+	return fresh
+}
+
+// checkLocked compares one response's duration (ms) and size (bytes) against b's limits.
+func (m *Monitor) checkLocked(b *Budget, ev Evidence, durationMs float64, sizeBytes int, now time.Time, fresh []Violation) []Violation {
+	if b.MaxDurationMs > 0 && durationMs > float64(b.MaxDurationMs) {
+		fresh = m.recordLocked(b, MetricDuration, "ms", float64(b.MaxDurationMs), math.Round(durationMs), ev, now, fresh)
+	}
+	if sizeKB := float64(sizeBytes) / 1024; b.MaxSizeKB > 0 && sizeKB > b.MaxSizeKB {
+		fresh = m.recordLocked(b, MetricSize, "kb", b.MaxSizeKB, math.Round(sizeKB*10)/10, ev, now, fresh)
+	}
+	return fresh
+}
+
+// recordLocked counts an over-budget response keyed by budget, metric, and URL, appending it to fresh when new.
+func (m *Monitor) recordLocked(b *Budget, metric, unit string, limit, actual float64, ev Evidence, now time.Time, fresh []Violation) []Violation {
+	key := b.ID + "|" + metric + "|" + ev.URL
+	at := now
+	b.LastViolationAt = &at
+	b.Exceedances++
+	if existing, ok := m.violations[key]; ok {
+		existing.Count++
+		existing.LastSeen = now
+		existing.Worst = math.Max(existing.Worst, actual)
+		return fresh
+	}
+	v := &Violation{
+		BudgetID: b.ID, Pattern: b.Pattern, Metric: metric, Unit: unit, Limit: limit,
+		Actual: actual, Worst: actual, Evidence: ev, Count: 1, FirstSeen: now, LastSeen: now,
+	}
+	b.Violations++
+	m.violations[key] = v
+	m.vorder = append(m.vorder, key)
+	m.evictLocked()
+	return append(fresh, *v)
+}
+
+func (m *Monitor) evictLocked() {
+	for len(m.vorder) > maxViolations {
+		delete(m.violations, m.vorder[0])
+		m.vorder = m.vorder[1:]
+	}
+}
+
+func (m *Monitor) dropViolationsLocked(id string) {
+	for key, v := range m.violations {
+		if v.BudgetID == id {
+			delete(m.violations, key)
+			m.vorder = removeID(m.vorder, key)
+		}
+	}
+}
+
+func (m *Monitor) listLocked() []Budget {
+	out := make([]Budget, 0, len(m.order))
+	for _, id := range m.order {
+		out = append(out, *m.budgets[id])
+	}
+	return out
+}
+
+// matches reports whether url falls under the budget: a substring match, or a whole-URL glob.
+func (b *Budget) matches(url string) bool {
+	if b.glob != nil {
+		return b.glob.MatchString(url)
+	}
+	return strings.Contains(url, b.Pattern)
+}
+
+func removeID(ids []string, id string) []string {
+	for i, other := range ids {
+		if other == id {
+			return append(ids[:i], ids[i+1:]...)
+		}
+	}
+	return ids
+}
@@ -0,0 +1,132 @@
+// Purpose: Tests budget registration, pattern matching, duration/size checks, dedup, and the summary.
+// Docs: docs/features/feature/network-budgets/index.md
+
+package netbudget
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+)
+
+func TestMonitor_AddValidatesAndReplacesSamePattern(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC)
+	m := NewMonitor()
+	for _, tc := range []struct {
+		pattern  string
+		duration int
+		size     float64
+		want     error
+	}{
+		{"", 100, 0, ErrInvalidPattern},
+		{strings.Repeat("a", maxPatternLen+1), 100, 0, ErrInvalidPattern},
+		{"/api", 0, 0, ErrNoLimit},
+		{"/api", -1, 10, ErrNegativeLimit},
+	} {
+		if _, _, err := m.Add(tc.pattern, tc.duration, tc.size, now); !errors.Is(err, tc.want) {
+			t.Errorf("Add(%.20q, %d, %v) err = %v, want %v", tc.pattern, tc.duration, tc.size, err, tc.want)
+		}
+	}
+
+	first, replaced, err := m.Add("/api/search", 500, 0, now)
+	if err != nil || replaced || first.ID != "budget-1" {
+		t.Fatalf("Add = %+v, %v, %v", first, replaced, err)
+	}
+	m.ObserveNetwork(capture.NetworkActivity{Bodies: []capture.NetworkBody{{URL: "https://app.test/api/search?q=a", Duration: 900}}}, now)
+
+	updated, replaced, err := m.Add(" /api/search ", 1000, 50, now)
+	if err != nil || !replaced || updated.ID != first.ID || updated.MaxDurationMs != 1000 || updated.Violations != 0 {
+		t.Fatalf("re-Add = %+v, %v, %v; want budget-1 updated with violations reset", updated, replaced, err)
+	}
+	if s := m.Summary("", 0); len(s.Budgets) != 1 || s.TotalViolations != 0 {
+		t.Fatalf("summary after update = %+v", s)
+	}
+}
+
+func TestMonitor_ObserveNetworkDurationAndSize(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC)
+	m := NewMonitor()
+	api, _, _ := m.Add("/api/", 300, 0, now)
+	js, _, _ := m.Add("https://cdn.app.test/*.js", 0, 100, now)
+
+	fresh := m.ObserveNetwork(capture.NetworkActivity{
+		PageURL: "https://app.test/cart",
+		Bodies: []capture.NetworkBody{
+			{Method: "GET", URL: "https://app.test/api/cart", Status: 200, Duration: 820, TabID: 3},
+			{Method: "GET", URL: "https://app.test/api/user", Status: 200, Duration: 120},
+		},
+		Waterfall: []capture.NetworkWaterfallEntry{
+			{URL: "https://cdn.app.test/vendor.js", InitiatorType: "script", Duration: 40, TransferSize: 250 * 1024},
+			{URL: "https://cdn.app.test/app.js", InitiatorType: "script", Duration: 40, EncodedBodySize: 150 * 1024},
+			{URL: "https://cdn.app.test/small.js", InitiatorType: "script", Duration: 40, TransferSize: 20 * 1024},
+			{URL: "https://cdn.app.test/vendor.js.map", InitiatorType: "other", TransferSize: 900 * 1024},
+		},
+	}, now)
+	if len(fresh) != 3 {
+		t.Fatalf("fresh = %+v, want the slow cart call and two heavy scripts", fresh)
+	}
+	slow := fresh[0]
+	if slow.BudgetID != api.ID || slow.Metric != MetricDuration || slow.Unit != "ms" || slow.Limit != 300 || slow.Actual != 820 ||
+		slow.Evidence.Source != "network_bodies" || slow.Evidence.TabID != 3 || slow.Evidence.PageURL != "https://app.test/cart" {
+		t.Fatalf("duration violation = %+v", slow)
+	}
+	heavy := fresh[1]
+	if heavy.BudgetID != js.ID || heavy.Metric != MetricSize || heavy.Unit != "kb" || heavy.Actual != 250 || heavy.Evidence.InitiatorType != "script" {
+		t.Fatalf("size violation = %+v", heavy)
+	}
+	if fresh[2].Evidence.URL != "https://cdn.app.test/app.js" || fresh[2].Actual != 150 {
+		t.Fatalf("cache-hit size violation = %+v, want encoded body size used", fresh[2])
+	}
+
+	// A slower repeat is counted, not re-announced, and raises the worst value.
+	again := m.ObserveNetwork(capture.NetworkActivity{
+		Bodies: []capture.NetworkBody{{Method: "GET", URL: "https://app.test/api/cart", Duration: 1500}},
+	}, now.Add(time.Second))
+	if len(again) != 0 {
+		t.Fatalf("repeat fresh = %+v, want none", again)
+	}
+	s := m.Summary("", 0)
+	if s.Status != "over_budget" || s.TotalViolations != 3 || s.ByMetric[MetricSize] != 2 || s.ByMetric[MetricDuration] != 1 {
+		t.Fatalf("summary = %+v", s)
+	}
+	top := s.Violations[0]
+	if top.Evidence.URL != "https://app.test/api/cart" || top.Count != 2 || top.Actual != 820 || top.Worst != 1500 {
+		t.Fatalf("most recent violation = %+v", top)
+	}
+	if s.Budgets[0].Violations != 1 || s.Budgets[0].Exceedances != 2 || s.Budgets[0].LastViolationAt == nil {
+		t.Fatalf("budget totals = %+v", s.Budgets[0])
+	}
+	if filtered := m.Summary("cdn.app.test", 1); filtered.TotalViolations != 2 || len(filtered.Violations) != 1 {
+		t.Fatalf("filtered summary = %+v", filtered)
+	}
+}
+
+func TestMonitor_RemoveAndClear(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC)
+	m := NewMonitor()
+	if s := m.Summary("", 0); s.Status != "no_budgets" {
+		t.Fatalf("empty status = %q", s.Status)
+	}
+	a, _, _ := m.Add("/a", 10, 0, now)
+	m.Add("/b", 10, 0, now)
+	m.ObserveNetwork(capture.NetworkActivity{Bodies: []capture.NetworkBody{{URL: "/a", Duration: 50}, {URL: "/b", Duration: 50}}}, now)
+
+	if err := m.Remove(a.ID); err != nil {
+		t.Fatalf("Remove: %v", err)
+	}
+	if err := m.Remove(a.ID); !errors.Is(err, ErrUnknownBudget) {
+		t.Fatalf("second Remove err = %v", err)
+	}
+	if s := m.Summary("", 0); len(s.Budgets) != 1 || s.TotalViolations != 1 || s.Violations[0].Pattern != "/b" {
+		t.Fatalf("summary after remove = %+v", s)
+	}
+	if n := m.Clear(); n != 1 || len(m.List()) != 0 {
+		t.Fatalf("Clear = %d, list = %+v", n, m.List())
+	}
+}
@@ -47,6 +47,8 @@ type NoiseRule struct {
 	AutoDetected   bool           `json:"auto_detected,omitempty"`
 	CreatedAt      time.Time      `json:"created_at"`
 	Reason         string         `json:"reason,omitempty"`
+	Action         string         `json:"action,omitempty"` // "drop" (default), "downgrade", "tag"; see noise_engine.go
+	Tag            string         `json:"tag,omitempty"`    // label for action "tag"; defaults to Classification
 }
 
 // compiledRule holds a rule with pre-compiled regex patterns.
@@ -137,11 +139,13 @@ func NewNoiseConfigWithStore(store *persistence.SessionStore) *NoiseConfig {
 	return nc
 }
 
-// recompile compiles all regex patterns in rules. Invalid regexes result in nil (never match).
+// recompile compiles all regex patterns in rules, in evaluation order (see orderedRulesLocked).
+// Invalid regexes result in nil (never match).
 func (nc *NoiseConfig) recompile() {
-	compiled := make([]compiledRule, len(nc.rules))
-	for i := range nc.rules {
-		rule := &nc.rules[i]
+	ordered := nc.orderedRulesLocked()
+	compiled := make([]compiledRule, len(ordered))
+	for i := range ordered {
+		rule := &ordered[i]
 		current := compiledRule{rule: *rule}
 		if rule.MatchSpec.MessageRegex != "" {
 			if re, err := regexp.Compile(rule.MatchSpec.MessageRegex); err == nil {
@@ -0,0 +1,261 @@
+// Purpose: Adds rule actions (drop/downgrade/tag), evaluation ordering, and dry-run match counts to the noise engine.
+// Why: Lets a project keep noisy-but-useful console entries visible at lower priority instead of only hiding them.
+// Docs: docs/features/feature/noise-filtering/index.md
+
+package noise
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Rule actions. An empty Action means ActionDrop so rules persisted before actions existed keep working.
+const (
+	ActionDrop      = "drop"
+	ActionDowngrade = "downgrade"
+	ActionTag       = "tag"
+)
+
+// downgradedLevel is the level a "downgrade" rule assigns to matching console entries.
+const downgradedLevel = "debug"
+
+// RuleMatchCount is the dry-run result for one rule: how many buffered entries it would claim.
+type RuleMatchCount struct {
+	RuleID   string `json:"rule_id"`
+	Category string `json:"category"`
+	Action   string `json:"action"`
+	Matches  int    `json:"matches"`
+}
+
+// ruleAction returns the effective action of a rule.
+func ruleAction(rule NoiseRule) string {
+	if rule.Action == "" {
+		return ActionDrop
+	}
+	return rule.Action
+}
+
+// isBuiltinRule reports whether the rule ships with the server (and is evaluated last).
+func isBuiltinRule(rule NoiseRule) bool {
+	return strings.HasPrefix(rule.ID, "builtin_")
+}
+
+// validateRuleAction checks that a rule's action is known and valid for its category.
+// Only console entries carry a level or free-form fields, so downgrade and tag are console-only.
+func validateRuleAction(rule NoiseRule) error {
+	switch ruleAction(rule) {
+	case ActionDrop:
+		return nil
+	case ActionDowngrade, ActionTag:
+		if rule.Category != "console" {
+			return fmt.Errorf("action %q is only supported for console rules (got category %q)", rule.Action, rule.Category)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown action %q (valid: drop, downgrade, tag)", rule.Action)
+	}
+}
+
+// validateRuleActions validates the action of every rule before any are added.
+func validateRuleActions(rules []NoiseRule) error {
+	for i := range rules {
+		if err := validateRuleAction(rules[i]); err != nil {
+			return fmt.Errorf("invalid rule %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// orderedRulesLocked returns a copy of the rules in evaluation order: user and auto-detected
+// rules in their stored order, then built-ins. First match wins, so a project rule can
+// override a built-in (assumes mu is held).
+func (nc *NoiseConfig) orderedRulesLocked() []NoiseRule {
+	ordered := make([]NoiseRule, 0, len(nc.rules))
+	for _, rule := range nc.rules {
+		if !isBuiltinRule(rule) {
+			ordered = append(ordered, rule)
+		}
+	}
+	for _, rule := range nc.rules {
+		if isBuiltinRule(rule) {
+			ordered = append(ordered, rule)
+		}
+	}
+	return ordered
+}
+
+// MoveRule moves a user rule to a zero-based position among the user rules, changing
+// which rule wins when several match. Out-of-range positions are clamped.
+func (nc *NoiseConfig) MoveRule(id string, position int) error {
+	nc.mu.Lock()
+	defer nc.mu.Unlock()
+
+	if strings.HasPrefix(id, "builtin_") {
+		return fmt.Errorf("cannot move built-in rule: %s", id)
+	}
+
+	user := make([]NoiseRule, 0, len(nc.rules))
+	builtins := make([]NoiseRule, 0, len(nc.rules))
+	from := -1
+	for _, rule := range nc.rules {
+		if isBuiltinRule(rule) {
+			builtins = append(builtins, rule)
+			continue
+		}
+		if rule.ID == id {
+			from = len(user)
+		}
+		user = append(user, rule)
+	}
+	if from < 0 {
+		return fmt.Errorf("rule not found: %s", id)
+	}
+
+	moved := user[from]
+	user = append(user[:from], user[from+1:]...)
+	position = max(0, min(position, len(user)))
+	user = append(user[:position], append([]NoiseRule{moved}, user[position:]...)...)
+
+	nc.rules = append(builtins, user...)
+	nc.recompile()
+	nc.persistRulesLocked()
+	return nil
+}
+
+// firstConsoleMatchLocked returns the first compiled console rule matching the entry (assumes mu is held).
+func (nc *NoiseConfig) firstConsoleMatchLocked(entry LogEntry) *compiledRule {
+	message, _ := entry["message"].(string)
+	source, _ := entry["source"].(string)
+	level, _ := entry["level"].(string)
+
+	for i := range nc.compiled {
+		compiled := &nc.compiled[i]
+		if compiled.rule.Category != "console" {
+			continue
+		}
+		if compiled.rule.MatchSpec.Level != "" && compiled.rule.MatchSpec.Level != level {
+			continue
+		}
+		if matchesConsoleRule(compiled, message, source) {
+			return compiled
+		}
+	}
+	return nil
+}
+
+// firstNetworkMatchLocked returns the first compiled network rule matching the body (assumes mu is held).
+// Security invariant: 401/403 responses never match.
+func (nc *NoiseConfig) firstNetworkMatchLocked(body NetworkBody) *compiledRule {
+	if body.Status == 401 || body.Status == 403 {
+		return nil
+	}
+	for i := range nc.compiled {
+		compiled := &nc.compiled[i]
+		if compiled.rule.Category != "network" {
+			continue
+		}
+		if matchesNetworkFilters(compiled, body) && matchesNetworkRule(compiled, body.URL) {
+			return compiled
+		}
+	}
+	return nil
+}
+
+// firstWebSocketMatchLocked returns the first compiled websocket rule matching the event (assumes mu is held).
+func (nc *NoiseConfig) firstWebSocketMatchLocked(event WebSocketEvent) *compiledRule {
+	for i := range nc.compiled {
+		compiled := &nc.compiled[i]
+		if compiled.rule.Category != "websocket" {
+			continue
+		}
+		if compiled.urlRegex != nil && compiled.urlRegex.MatchString(event.URL) {
+			return compiled
+		}
+	}
+	return nil
+}
+
+// ClassifyConsole applies the first matching console rule to an entry.
+// It returns drop=true when the rule's action is drop. For downgrade and tag it returns an
+// annotated copy (the input map is never mutated); otherwise the entry is returned unchanged.
+func (nc *NoiseConfig) ClassifyConsole(entry LogEntry) (LogEntry, bool) {
+	nc.mu.RLock()
+	defer nc.mu.RUnlock()
+
+	compiled := nc.firstConsoleMatchLocked(entry)
+	if compiled == nil {
+		nc.recordSignal()
+		return entry, false
+	}
+
+	rule := compiled.rule
+	switch ruleAction(rule) {
+	case ActionDowngrade:
+		nc.recordAnnotation(rule.ID)
+		annotated := copyLogEntry(entry)
+		if level, ok := entry["level"]; ok {
+			annotated["noise_original_level"] = level
+		}
+		annotated["level"] = downgradedLevel
+		annotated["noise_rule"] = rule.ID
+		return annotated, false
+	case ActionTag:
+		nc.recordAnnotation(rule.ID)
+		annotated := copyLogEntry(entry)
+		tag := rule.Tag
+		if tag == "" {
+			tag = rule.Classification
+		}
+		annotated["noise_tag"] = tag
+		annotated["noise_rule"] = rule.ID
+		return annotated, false
+	default:
+		nc.recordMatch(rule.ID)
+		return entry, true
+	}
+}
+
+// copyLogEntry returns a shallow copy of a log entry.
+func copyLogEntry(entry LogEntry) LogEntry {
+	out := make(LogEntry, len(entry)+3)
+	for key, value := range entry {
+		out[key] = value
+	}
+	return out
+}
+
+// DryRun reports, for every rule in evaluation order, how many of the given entries it
+// currently claims (first match wins, as in live filtering). Statistics are not touched.
+func (nc *NoiseConfig) DryRun(consoleEntries []LogEntry, networkBodies []NetworkBody, wsEvents []WebSocketEvent) []RuleMatchCount {
+	nc.mu.RLock()
+	defer nc.mu.RUnlock()
+
+	counts := make(map[string]int, len(nc.compiled))
+	for _, entry := range consoleEntries {
+		if compiled := nc.firstConsoleMatchLocked(entry); compiled != nil {
+			counts[compiled.rule.ID]++
+		}
+	}
+	for i := range networkBodies {
+		if compiled := nc.firstNetworkMatchLocked(networkBodies[i]); compiled != nil {
+			counts[compiled.rule.ID]++
+		}
+	}
+	for i := range wsEvents {
+		if compiled := nc.firstWebSocketMatchLocked(wsEvents[i]); compiled != nil {
+			counts[compiled.rule.ID]++
+		}
+	}
+
+	result := make([]RuleMatchCount, len(nc.compiled))
+	for i := range nc.compiled {
+		rule := nc.compiled[i].rule
+		result[i] = RuleMatchCount{
+			RuleID:   rule.ID,
+			Category: rule.Category,
+			Action:   ruleAction(rule),
+			Matches:  counts[rule.ID],
+		}
+	}
+	return result
+}
@@ -0,0 +1,123 @@
+// Purpose: Tests noise rule actions, evaluation order, reordering, and dry-run counts.
+// Docs: docs/features/feature/noise-filtering/index.md
+
+package noise
+
+import (
+	"testing"
+)
+
+func TestClassifyConsole_AppliesFirstMatchingRuleAction(t *testing.T) {
+	t.Parallel()
+	nc := NewNoiseConfig()
+	if err := nc.AddRules([]NoiseRule{
+		{Category: "console", Classification: "repetitive", Action: ActionDowngrade, MatchSpec: NoiseMatchSpec{MessageRegex: "poll tick"}},
+		{Category: "console", Classification: "framework", Action: ActionTag, Tag: "hmr", MatchSpec: NoiseMatchSpec{MessageRegex: `\[HMR\]`}},
+		{Category: "console", Classification: "cosmetic", MatchSpec: NoiseMatchSpec{MessageRegex: "poll"}},
+	}); err != nil {
+		t.Fatalf("AddRules() error = %v", err)
+	}
+
+	entry := LogEntry{"level": "warn", "message": "poll tick 12"}
+	downgraded, drop := nc.ClassifyConsole(entry)
+	if drop || downgraded["level"] != "debug" || downgraded["noise_original_level"] != "warn" || downgraded["noise_rule"] != "user_1" {
+		t.Fatalf("downgrade = %v (drop %v), want debug copy from user_1", downgraded, drop)
+	}
+	if entry["level"] != "warn" {
+		t.Fatalf("input entry was mutated: %v", entry)
+	}
+	if nc.IsConsoleNoise(entry) {
+		t.Fatal("an entry claimed by a downgrade rule must not be dropped by a later drop rule")
+	}
+
+	tagged, drop := nc.ClassifyConsole(LogEntry{"level": "log", "message": "[HMR] connected"})
+	if drop || tagged["noise_tag"] != "hmr" || tagged["level"] != "log" {
+		t.Fatalf("tag = %v (drop %v), want noise_tag hmr", tagged, drop)
+	}
+	if !nc.IsConsoleNoise(LogEntry{"level": "log", "message": "poll done"}) {
+		t.Fatal("drop rule should still drop entries the earlier rules miss")
+	}
+
+	// Downgrade/tag matches are counted per rule but not as filtered.
+	stats := nc.GetStatistics()
+	if stats.PerRule["user_1"] != 2 || stats.TotalFiltered != 1 {
+		t.Fatalf("stats = %+v, want user_1=2 and one filtered", stats)
+	}
+}
+
+func TestAddRules_RejectsInvalidActions(t *testing.T) {
+	t.Parallel()
+	nc := NewNoiseConfig()
+	for _, rule := range []NoiseRule{
+		{Category: "console", Action: "hide", MatchSpec: NoiseMatchSpec{MessageRegex: "x"}},
+		{Category: "network", Action: ActionDowngrade, MatchSpec: NoiseMatchSpec{URLRegex: "x"}},
+	} {
+		if err := nc.AddRules([]NoiseRule{rule}); err == nil {
+			t.Fatalf("AddRules(%+v) succeeded, want error", rule)
+		}
+	}
+}
+
+func TestMoveRule_ReordersAndPersists(t *testing.T) {
+	t.Parallel()
+	store := newNoiseTestSessionStore(t)
+	nc := NewNoiseConfigWithStore(store)
+	if err := nc.AddRules([]NoiseRule{
+		{Category: "console", Action: ActionTag, Tag: "broad", MatchSpec: NoiseMatchSpec{MessageRegex: "socket"}},
+		{Category: "console", Action: ActionTag, Tag: "narrow", MatchSpec: NoiseMatchSpec{MessageRegex: "socket closed"}},
+	}); err != nil {
+		t.Fatalf("AddRules() error = %v", err)
+	}
+
+	entry := LogEntry{"level": "info", "message": "socket closed"}
+	if tagged, _ := nc.ClassifyConsole(entry); tagged["noise_tag"] != "broad" {
+		t.Fatalf("before move tag = %v, want broad", tagged["noise_tag"])
+	}
+	if err := nc.MoveRule("user_2", 0); err != nil {
+		t.Fatalf("MoveRule() error = %v", err)
+	}
+	if tagged, _ := nc.ClassifyConsole(entry); tagged["noise_tag"] != "narrow" {
+		t.Fatalf("after move tag = %v, want narrow", tagged["noise_tag"])
+	}
+	if err := nc.MoveRule("builtin_hotjar", 0); err == nil {
+		t.Fatal("moving a built-in rule should fail")
+	}
+	if rules := nc.ListRules(); rules[0].ID != "user_2" || rules[1].ID != "user_1" {
+		t.Fatalf("ListRules order = %s, %s; want user_2, user_1", rules[0].ID, rules[1].ID)
+	}
+
+	reloaded := NewNoiseConfigWithStore(store)
+	if rules := reloaded.ListRules(); rules[0].ID != "user_2" || rules[0].Tag != "narrow" {
+		t.Fatalf("reloaded first rule = %+v, want user_2 tagged narrow", rules[0])
+	}
+}
+
+func TestDryRun_CountsFirstMatchesWithoutTouchingStats(t *testing.T) {
+	t.Parallel()
+	nc := NewNoiseConfig()
+	if err := nc.AddRules([]NoiseRule{
+		{Category: "console", MatchSpec: NoiseMatchSpec{MessageRegex: "noisy"}},
+		{Category: "network", MatchSpec: NoiseMatchSpec{URLRegex: "/metrics"}},
+	}); err != nil {
+		t.Fatalf("AddRules() error = %v", err)
+	}
+
+	counts := nc.DryRun(
+		[]LogEntry{{"level": "log", "message": "noisy 1"}, {"level": "log", "message": "noisy 2"}, {"level": "log", "message": "signal"}},
+		[]NetworkBody{{URL: "https://app.test/metrics", Status: 200}, {URL: "https://app.test/metrics", Status: 401}},
+		nil,
+	)
+	byID := map[string]int{}
+	for _, count := range counts {
+		byID[count.RuleID] = count.Matches
+	}
+	if byID["user_1"] != 2 || byID["user_2"] != 1 {
+		t.Fatalf("dry-run counts = %v, want user_1=2, user_2=1 (401 never matches)", byID)
+	}
+	if counts[0].RuleID != "user_1" || counts[0].Action != ActionDrop {
+		t.Fatalf("first count = %+v, want user_1 with default action drop", counts[0])
+	}
+	if stats := nc.GetStatistics(); stats.TotalFiltered != 0 || len(stats.PerRule) != 0 {
+		t.Fatalf("dry run changed stats: %+v", stats)
+	}
+}
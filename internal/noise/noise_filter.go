@@ -6,31 +6,11 @@ package noise
 
 import "github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
 
-// IsConsoleNoise checks if a console log entry matches any noise rule.
+// IsConsoleNoise checks if a console log entry should be dropped: the first matching
+// console rule has action drop. Entries claimed by downgrade/tag rules are not noise.
 func (nc *NoiseConfig) IsConsoleNoise(entry LogEntry) bool {
-	nc.mu.RLock()
-	defer nc.mu.RUnlock()
-
-	message, _ := entry["message"].(string)
-	source, _ := entry["source"].(string)
-	level, _ := entry["level"].(string)
-
-	for i := range nc.compiled {
-		compiled := &nc.compiled[i]
-		if compiled.rule.Category != "console" {
-			continue
-		}
-		if compiled.rule.MatchSpec.Level != "" && compiled.rule.MatchSpec.Level != level {
-			continue
-		}
-		if matchesConsoleRule(compiled, message, source) {
-			nc.recordMatch(compiled.rule.ID)
-			return true
-		}
-	}
-
-	nc.recordSignal()
-	return false
+	_, drop := nc.ClassifyConsole(entry)
+	return drop
 }
 
 // matchesConsoleRule returns true if message or source matches the compiled rule (OR logic).
@@ -41,28 +21,15 @@ func matchesConsoleRule(compiled *compiledRule, message, source string) bool {
 	return compiled.sourceRegex != nil && compiled.sourceRegex.MatchString(source)
 }
 
-// IsNetworkNoise checks if a network body matches any noise rule.
+// IsNetworkNoise checks if a network body matches a drop rule.
 // Security invariant: 401/403 responses are NEVER noise.
 func (nc *NoiseConfig) IsNetworkNoise(body capture.NetworkBody) bool {
-	if body.Status == 401 || body.Status == 403 {
-		return false
-	}
-
 	nc.mu.RLock()
 	defer nc.mu.RUnlock()
 
-	for i := range nc.compiled {
-		compiled := &nc.compiled[i]
-		if compiled.rule.Category != "network" {
-			continue
-		}
-		if !matchesNetworkFilters(compiled, body) {
-			continue
-		}
-		if matchesNetworkRule(compiled, body.URL) {
-			nc.recordMatch(compiled.rule.ID)
-			return true
-		}
+	if compiled := nc.firstNetworkMatchLocked(body); compiled != nil && ruleAction(compiled.rule) == ActionDrop {
+		nc.recordMatch(compiled.rule.ID)
+		return true
 	}
 
 	nc.recordSignal()
@@ -92,20 +59,14 @@ func matchesNetworkRule(compiled *compiledRule, rawURL string) bool {
 	return compiled.rule.MatchSpec.Method != "" || compiled.rule.MatchSpec.StatusMin > 0
 }
 
-// IsWebSocketNoise checks if a WebSocket event matches any noise rule.
+// IsWebSocketNoise checks if a WebSocket event matches a drop rule.
 func (nc *NoiseConfig) IsWebSocketNoise(event capture.WebSocketEvent) bool {
 	nc.mu.RLock()
 	defer nc.mu.RUnlock()
 
-	for i := range nc.compiled {
-		compiled := &nc.compiled[i]
-		if compiled.rule.Category != "websocket" {
-			continue
-		}
-		if compiled.urlRegex != nil && compiled.urlRegex.MatchString(event.URL) {
-			nc.recordMatch(compiled.rule.ID)
-			return true
-		}
+	if compiled := nc.firstWebSocketMatchLocked(event); compiled != nil && ruleAction(compiled.rule) == ActionDrop {
+		nc.recordMatch(compiled.rule.ID)
+		return true
 	}
 
 	nc.recordSignal()
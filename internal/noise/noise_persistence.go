@@ -69,6 +69,10 @@ func (nc *NoiseConfig) validatePersistedRules(rules []NoiseRule) []NoiseRule {
 			fmt.Fprintf(os.Stderr, "noise: skipping rule %s: invalid regex\n", rule.ID)
 			continue
 		}
+		if err := validateRuleAction(rule); err != nil {
+			fmt.Fprintf(os.Stderr, "noise: skipping rule %s: %v\n", rule.ID, err)
+			continue
+		}
 		valid = append(valid, rule)
 	}
 	return valid
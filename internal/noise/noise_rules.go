@@ -11,14 +11,13 @@ import (
 	"time"
 )
 
-// ListRules returns a copy of all current rules.
+// ListRules returns a copy of all current rules in evaluation order: user and auto-detected
+// rules first, then built-ins.
 func (nc *NoiseConfig) ListRules() []NoiseRule {
 	nc.mu.RLock()
 	defer nc.mu.RUnlock()
 
-	result := make([]NoiseRule, len(nc.rules))
-	copy(result, nc.rules)
-	return result
+	return nc.orderedRulesLocked()
 }
 
 // validateRegexPattern checks if a regex pattern is safe to compile.
@@ -83,6 +82,9 @@ func (nc *NoiseConfig) AddRules(rules []NoiseRule) error {
 	if err := validateAllRulePatterns(rules); err != nil {
 		return err
 	}
+	if err := validateRuleActions(rules); err != nil {
+		return err
+	}
 
 	for i := range rules {
 		if len(nc.rules) >= maxNoiseRules {
@@ -16,6 +16,14 @@ func (nc *NoiseConfig) recordMatch(ruleID string) {
 	nc.stats.LastNoiseAt = time.Now()
 }
 
+// recordAnnotation counts a downgrade/tag match for a rule without counting it as filtered.
+func (nc *NoiseConfig) recordAnnotation(ruleID string) {
+	nc.statsMu.Lock()
+	defer nc.statsMu.Unlock()
+
+	nc.stats.PerRule[ruleID]++
+}
+
 // recordSignal updates the last signal timestamp (thread-safe via statsMu).
 func (nc *NoiseConfig) recordSignal() {
 	nc.statsMu.Lock()
@@ -0,0 +1,29 @@
+// Purpose: Package notifyhub — fans MCP notifications out to connected SSE sessions with per-client event filters.
+// Why: Agents on the HTTP transport otherwise have to poll observe to learn that something broke.
+// Docs: docs/features/feature/push-alerts/index.md
+
+/*
+Package notifyhub turns a small set of high-signal capture events into MCP
+notifications/message frames and delivers them to every open server-sent-events
+session whose client subscribed to that event.
+
+Events:
+  - console_error: a console entry at level "error".
+  - network_error: a captured request that completed with status >= 500.
+  - ci_result: a CI webhook result recorded as a "ci" alert.
+  - circuit_opened: the capture circuit breaker opened.
+  - circuit_closed: the capture circuit breaker closed again.
+  - contract_violation: a response deviated from a locked API contract.
+  - extension_reconnected: the browser extension synced again after a disconnect.
+
+Key types:
+  - Hub: session registry plus per-client subscriptions; its lock is a leaf lock.
+  - Session: one open stream with a bounded outbox; slow readers drop, never block.
+  - Notification: one event, rendered to a JSON-RPC frame with Frame.
+
+Key functions:
+  - Classify: maps a changefeed delta to a notification, if it qualifies.
+  - (*Hub).Subscribe: sets the event filter for a client ID.
+  - (*Hub).PublishDeltas / (*Hub).Publish: delivers to matching sessions.
+*/
+package notifyhub
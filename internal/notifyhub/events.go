@@ -0,0 +1,200 @@
+// Purpose: Defines push event names and classifies change-feed deltas and lifecycle events into notifications.
+// Why: Only failures worth interrupting an agent for are pushed; everything else stays behind observe(what="changes").
+// Docs: docs/features/feature/push-alerts/index.md
+
+package notifyhub
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/changefeed"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/streaming"
+)
+
+// Push event names accepted by configure(action="subscribe").
+const (
+	EventConsoleError         = "console_error"
+	EventNetworkError         = "network_error"
+	EventCIResult             = "ci_result"
+	EventCircuitOpened        = "circuit_opened"
+	EventCircuitClosed        = "circuit_closed"
+	EventContractViolation    = "contract_violation"
+	EventExtensionReconnected = "extension_reconnected"
+
+	// EventAll subscribes to every event.
+	EventAll = "all"
+)
+
+// AllEvents lists every push event in documentation order.
+var AllEvents = []string{EventConsoleError, EventNetworkError, EventCIResult, EventCircuitOpened, EventCircuitClosed, EventContractViolation, EventExtensionReconnected}
+
+// serverErrorStatus is the lowest HTTP status pushed as network_error.
+const serverErrorStatus = 500
+
+// contractAlertSource is the alert source of locked API contract violations.
+const contractAlertSource = "api_contract"
+
+// Notification is one pushed event.
+type Notification struct {
+	Event     string
+	Level     string
+	Seq       int64 // change-feed sequence; 0 for events outside the feed
+	Timestamp string
+	Data      map[string]any
+}
+
+// Frame renders the notification as a JSON-RPC notifications/message payload.
+func (n Notification) Frame() ([]byte, error) {
+	data := make(map[string]any, len(n.Data)+3)
+	for k, v := range n.Data {
+		data[k] = v
+	}
+	data["event"] = n.Event
+	data["ts"] = n.Timestamp
+	if n.Seq > 0 {
+		data["seq"] = n.Seq
+	}
+	return json.Marshal(streaming.MCPNotification{
+		JSONRPC: "2.0",
+		Method:  "notifications/message",
+		Params: streaming.NotificationParams{
+			Level:  n.Level,
+			Logger: streaming.NotificationLoggerName,
+			Data:   data,
+		},
+	})
+}
+
+// Classify maps a change-feed delta to a push notification.
+// Returns false for deltas that are not pushed (info logs, 2xx-4xx requests, alerts other than CI and API contract).
+func Classify(d changefeed.Delta) (Notification, bool) {
+	n := Notification{Seq: d.Seq, Timestamp: d.Timestamp, Data: d.Data}
+	switch d.Kind {
+	case changefeed.KindConsole:
+		if level, _ := d.Data["level"].(string); level != "error" {
+			return Notification{}, false
+		}
+		n.Event, n.Level = EventConsoleError, "error"
+	case changefeed.KindNetwork:
+		if statusOf(d.Data["status"]) < serverErrorStatus {
+			return Notification{}, false
+		}
+		n.Event, n.Level = EventNetworkError, "error"
+	case changefeed.KindAlert:
+		severity, _ := d.Data["severity"].(string)
+		if source, _ := d.Data["source"].(string); source == contractAlertSource {
+			n.Event, n.Level = EventContractViolation, "warning"
+			if severity == "error" {
+				n.Level = "error"
+			}
+			break
+		}
+		if category, _ := d.Data["category"].(string); category != "ci" {
+			return Notification{}, false
+		}
+		n.Event, n.Level = EventCIResult, "info"
+		if severity == "error" {
+			n.Level = "error"
+		}
+	default:
+		return Notification{}, false
+	}
+	return n, true
+}
+
+// CircuitOpened builds the notification for a circuit_opened lifecycle event.
+func CircuitOpened(data map[string]any, now time.Time) Notification {
+	payload := make(map[string]any, len(data)+1)
+	for k, v := range data {
+		payload[k] = v
+	}
+	payload["message"] = "Capture circuit breaker opened; telemetry is being dropped until the event rate recovers"
+	return Notification{
+		Event:     EventCircuitOpened,
+		Level:     "warning",
+		Timestamp: now.UTC().Format(time.RFC3339Nano),
+		Data:      payload,
+	}
+}
+
+// CircuitClosed builds the notification for a circuit_closed lifecycle event, so agents
+// that saw circuit_opened know capture resumed.
+func CircuitClosed(data map[string]any, now time.Time) Notification {
+	payload := make(map[string]any, len(data)+1)
+	for k, v := range data {
+		payload[k] = v
+	}
+	payload["message"] = "Capture circuit breaker closed; telemetry is being captured again"
+	return Notification{
+		Event:     EventCircuitClosed,
+		Level:     "info",
+		Timestamp: now.UTC().Format(time.RFC3339Nano),
+		Data:      payload,
+	}
+}
+
+// ExtensionReconnected builds the notification for an extension that synced again after a
+// disconnect, so agents that got extension_disconnected errors know to retry.
+func ExtensionReconnected(data map[string]any, now time.Time) Notification {
+	payload := make(map[string]any, len(data)+1)
+	for k, v := range data {
+		payload[k] = v
+	}
+	payload["message"] = "Browser extension reconnected; extension-backed tools are available again"
+	return Notification{
+		Event:     EventExtensionReconnected,
+		Level:     "info",
+		Timestamp: now.UTC().Format(time.RFC3339Nano),
+		Data:      payload,
+	}
+}
+
+// NormalizeEvents validates an event list. Empty input or "all" means every event (nil).
+func NormalizeEvents(events []string) ([]string, error) {
+	seen := map[string]bool{}
+	for _, raw := range events {
+		ev := strings.ToLower(strings.TrimSpace(raw))
+		if ev == EventAll {
+			return nil, nil
+		}
+		if !isEvent(ev) {
+			return nil, fmt.Errorf("unknown event %q (valid: %s, all)", raw, strings.Join(AllEvents, ", "))
+		}
+		seen[ev] = true
+	}
+	if len(seen) == 0 {
+		return nil, nil
+	}
+	out := make([]string, 0, len(seen))
+	for _, ev := range AllEvents {
+		if seen[ev] {
+			out = append(out, ev)
+		}
+	}
+	return out, nil
+}
+
+func isEvent(ev string) bool {
+	for _, known := range AllEvents {
+		if ev == known {
+			return true
+		}
+	}
+	return false
+}
+
+// statusOf reads an HTTP status from a delta payload (int in-process, float64 after JSON).
+func statusOf(v any) int {
+	switch s := v.(type) {
+	case int:
+		return s
+	case int64:
+		return int(s)
+	case float64:
+		return int(s)
+	}
+	return 0
+}
@@ -0,0 +1,177 @@
+// Purpose: Registers SSE sessions, stores per-client subscriptions, and delivers notifications without blocking producers.
+// Why: Publish runs on capture ingest paths (under Capture.mu), so delivery must be bounded and lock-light.
+// Docs: docs/features/feature/push-alerts/index.md
+
+package notifyhub
+
+import (
+	"strconv"
+	"sync"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/changefeed"
+)
+
+// SessionBuffer is the number of undelivered notifications a session holds before dropping.
+const SessionBuffer = 64
+
+// Session is one open notification stream.
+type Session struct {
+	ID       string
+	ClientID string
+
+	ch chan Notification
+}
+
+// Notifications returns the session outbox. It is closed when the session is closed.
+func (s *Session) Notifications() <-chan Notification {
+	return s.ch
+}
+
+// Status summarizes the hub for one client.
+type Status struct {
+	ClientID       string   `json:"client_id"`
+	Events         []string `json:"events"`
+	ClientSessions int      `json:"client_sessions"`
+	TotalSessions  int      `json:"total_sessions"`
+	Delivered      int64    `json:"delivered"`
+	Dropped        int64    `json:"dropped"`
+}
+
+// Hub fans notifications out to open sessions.
+//
+// Lock hierarchy: Hub.mu is a leaf lock. Publish may be called while holding
+// Capture.mu; sends are non-blocking so a stalled reader never holds it up.
+type Hub struct {
+	mu        sync.Mutex
+	sessions  map[string]*Session
+	filters   map[string][]string // client ID -> subscribed events; absent means all
+	nextID    int64
+	delivered int64
+	dropped   int64
+}
+
+// New creates an empty hub.
+func New() *Hub {
+	return &Hub{
+		sessions: make(map[string]*Session),
+		filters:  make(map[string][]string),
+	}
+}
+
+// Open registers a session for clientID ("" is a valid anonymous client).
+func (h *Hub) Open(clientID string) *Session {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.nextID++
+	s := &Session{
+		ID:       "sse-" + strconv.FormatInt(h.nextID, 10),
+		ClientID: clientID,
+		ch:       make(chan Notification, SessionBuffer),
+	}
+	h.sessions[s.ID] = s
+	return s
+}
+
+// Close unregisters a session and closes its outbox. Closing twice is a no-op.
+func (h *Hub) Close(s *Session) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if _, ok := h.sessions[s.ID]; !ok {
+		return
+	}
+	delete(h.sessions, s.ID)
+	close(s.ch)
+}
+
+// Subscribe sets the events pushed to clientID's sessions and returns the normalized list.
+// Empty events or "all" subscribes to everything.
+func (h *Hub) Subscribe(clientID string, events []string) ([]string, error) {
+	normalized, err := NormalizeEvents(events)
+	if err != nil {
+		return nil, err
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if normalized == nil {
+		delete(h.filters, clientID)
+		return AllEvents, nil
+	}
+	h.filters[clientID] = normalized
+	return normalized, nil
+}
+
+// Status reports subscriptions and delivery counters for clientID.
+func (h *Hub) Status(clientID string) Status {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	st := Status{
+		ClientID:      clientID,
+		Events:        AllEvents,
+		TotalSessions: len(h.sessions),
+		Delivered:     h.delivered,
+		Dropped:       h.dropped,
+	}
+	if events, ok := h.filters[clientID]; ok {
+		st.Events = events
+	}
+	for _, s := range h.sessions {
+		if s.ClientID == clientID {
+			st.ClientSessions++
+		}
+	}
+	return st
+}
+
+// Publish delivers n to every session subscribed to n.Event and returns how many accepted it.
+func (h *Hub) Publish(n Notification) int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.publishLocked(n)
+}
+
+// PublishDeltas classifies a change-feed batch and delivers the qualifying notifications.
+// Suitable for changefeed.Feed.SetOnAppend.
+func (h *Hub) PublishDeltas(batch []changefeed.Delta) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if len(h.sessions) == 0 {
+		return
+	}
+	for _, d := range batch {
+		if n, ok := Classify(d); ok {
+			h.publishLocked(n)
+		}
+	}
+}
+
+// publishLocked sends without blocking. Caller must hold h.mu.
+func (h *Hub) publishLocked(n Notification) int {
+	accepted := 0
+	for _, s := range h.sessions {
+		if !h.wantsLocked(s.ClientID, n.Event) {
+			continue
+		}
+		select {
+		case s.ch <- n:
+			accepted++
+			h.delivered++
+		default:
+			h.dropped++
+		}
+	}
+	return accepted
+}
+
+// wantsLocked reports whether clientID is subscribed to event. Caller must hold h.mu.
+func (h *Hub) wantsLocked(clientID, event string) bool {
+	events, ok := h.filters[clientID]
+	if !ok {
+		return true
+	}
+	for _, ev := range events {
+		if ev == event {
+			return true
+		}
+	}
+	return false
+}
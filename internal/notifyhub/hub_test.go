@@ -0,0 +1,111 @@
+// Purpose: Tests delta classification, per-client subscriptions, non-blocking fan-out, and frame rendering.
+// Docs: docs/features/feature/push-alerts/index.md
+
+package notifyhub
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/changefeed"
+)
+
+func TestClassify_OnlyPushesFailures(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		delta changefeed.Delta
+		event string
+	}{
+		{changefeed.Delta{Kind: changefeed.KindConsole, Data: map[string]any{"level": "error", "message": "boom"}}, EventConsoleError},
+		{changefeed.Delta{Kind: changefeed.KindConsole, Data: map[string]any{"level": "warn"}}, ""},
+		{changefeed.Delta{Kind: changefeed.KindNetwork, Data: map[string]any{"status": 503}}, EventNetworkError},
+		{changefeed.Delta{Kind: changefeed.KindNetwork, Data: map[string]any{"status": 404}}, ""},
+		{changefeed.Delta{Kind: changefeed.KindAlert, Data: map[string]any{"category": "ci", "severity": "error"}}, EventCIResult},
+		{changefeed.Delta{Kind: changefeed.KindAlert, Data: map[string]any{"category": "regression"}}, ""},
+		{changefeed.Delta{Kind: changefeed.KindAlert, Data: map[string]any{"category": "regression", "source": "api_contract"}}, EventContractViolation},
+		{changefeed.Delta{Kind: changefeed.KindAction, Data: map[string]any{}}, ""},
+	}
+	for i, tc := range cases {
+		n, ok := Classify(tc.delta)
+		if ok != (tc.event != "") || n.Event != tc.event {
+			t.Errorf("case %d: got (%q, %v), want %q", i, n.Event, ok, tc.event)
+		}
+	}
+}
+
+func TestHub_SubscriptionsFilterPerClient(t *testing.T) {
+	t.Parallel()
+	h := New()
+	ci := h.Open("ci-agent")
+	all := h.Open("")
+	if events, err := h.Subscribe("ci-agent", []string{"CI_RESULT", "circuit_opened"}); err != nil || len(events) != 2 || events[0] != EventCIResult {
+		t.Fatalf("subscribe = %v, %v", events, err)
+	}
+	if _, err := h.Subscribe("ci-agent", []string{"dom_changed"}); err == nil {
+		t.Fatal("unknown event should be rejected")
+	}
+
+	h.PublishDeltas([]changefeed.Delta{
+		{Seq: 1, Kind: changefeed.KindConsole, Data: map[string]any{"level": "error"}},
+		{Seq: 2, Kind: changefeed.KindAlert, Data: map[string]any{"category": "ci", "severity": "info"}},
+	})
+	if got := len(ci.Notifications()); got != 1 {
+		t.Errorf("ci-agent should only get ci_result, got %d", got)
+	}
+	if got := len(all.Notifications()); got != 2 {
+		t.Errorf("unfiltered client should get both, got %d", got)
+	}
+
+	st := h.Status("ci-agent")
+	if st.ClientSessions != 1 || st.TotalSessions != 2 || st.Delivered != 3 {
+		t.Errorf("status = %+v", st)
+	}
+	if events, _ := h.Subscribe("ci-agent", []string{"all"}); len(events) != len(AllEvents) {
+		t.Errorf("all should reset the filter, got %v", events)
+	}
+}
+
+func TestHub_SlowSessionDropsInsteadOfBlocking(t *testing.T) {
+	t.Parallel()
+	h := New()
+	s := h.Open("")
+	n := CircuitOpened(map[string]any{"reason": "rate_exceeded"}, time.Now())
+	for i := 0; i < SessionBuffer+5; i++ {
+		h.Publish(n)
+	}
+	if st := h.Status(""); st.Delivered != SessionBuffer || st.Dropped != 5 {
+		t.Errorf("status = %+v", st)
+	}
+	h.Close(s)
+	h.Close(s)
+	if _, open := <-s.Notifications(); !open {
+		t.Fatal("buffered notifications should still drain after close")
+	}
+	if h.Publish(n) != 0 {
+		t.Error("closed session should not receive")
+	}
+}
+
+func TestNotificationFrame(t *testing.T) {
+	t.Parallel()
+	n, _ := Classify(changefeed.Delta{Seq: 7, Kind: changefeed.KindNetwork, Timestamp: "t", Data: map[string]any{"status": 500, "url": "/api"}})
+	raw, err := n.Frame()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var frame struct {
+		Method string `json:"method"`
+		Params struct {
+			Level string         `json:"level"`
+			Data  map[string]any `json:"data"`
+		} `json:"params"`
+	}
+	if err := json.Unmarshal(raw, &frame); err != nil {
+		t.Fatal(err)
+	}
+	if frame.Method != "notifications/message" || frame.Params.Level != "error" ||
+		frame.Params.Data["event"] != EventNetworkError || frame.Params.Data["seq"] != float64(7) || frame.Params.Data["url"] != "/api" {
+		t.Errorf("frame = %s", raw)
+	}
+}
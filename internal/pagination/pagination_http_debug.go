@@ -0,0 +1,62 @@
+// Purpose: Adapts generic cursor pagination to the daemon's HTTP debug log.
+// Why: Keeps debug-entry serialization isolated from generic pagination rules, as for SSE events.
+// Docs: docs/features/feature/server-debug-log/index.md
+
+package pagination
+
+import (
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+)
+
+// HTTPDebugEntryWithSequence pairs an HTTP debug entry with its sequence number and timestamp for pagination.
+type HTTPDebugEntryWithSequence struct {
+	Entry     capture.HTTPDebugEntry
+	Sequence  int64
+	Timestamp string
+}
+
+// GetSequence implements Sequenced.
+func (e HTTPDebugEntryWithSequence) GetSequence() int64 { return e.Sequence }
+
+// GetTimestamp implements Sequenced.
+func (e HTTPDebugEntryWithSequence) GetTimestamp() string { return e.Timestamp }
+
+// EnrichHTTPDebugEntries adds sequence numbers to HTTP debug entries for pagination.
+// Must be called with the UNFILTERED, oldest-first entry list to get correct sequence numbers.
+func EnrichHTTPDebugEntries(entries []capture.HTTPDebugEntry, totalAdded int64) []HTTPDebugEntryWithSequence {
+	enriched := make([]HTTPDebugEntryWithSequence, len(entries))
+	baseSeq := totalAdded - int64(len(entries)) + 1
+
+	for i, entry := range entries {
+		enriched[i] = HTTPDebugEntryWithSequence{
+			Entry:     entry,
+			Sequence:  baseSeq + int64(i),
+			Timestamp: entry.Timestamp.UTC().Format(time.RFC3339Nano),
+		}
+	}
+
+	return enriched
+}
+
+// SerializeHTTPDebugEntryWithSequence converts an HTTPDebugEntryWithSequence to a JSON-serializable map.
+// Headers are omitted; they are already redacted and rarely explain a failed call.
+func SerializeHTTPDebugEntryWithSequence(enriched HTTPDebugEntryWithSequence) map[string]any {
+	result := map[string]any{
+		"endpoint":    enriched.Entry.Endpoint,
+		"method":      enriched.Entry.Method,
+		"status":      enriched.Entry.ResponseStatus,
+		"duration_ms": enriched.Entry.DurationMs,
+		"timestamp":   enriched.Timestamp,
+		"sequence":    enriched.Sequence,
+	}
+
+	addNonEmpty(result, "client_id", enriched.Entry.ClientID)
+	addNonEmpty(result, "ext_session_id", enriched.Entry.ExtSessionID)
+	addNonEmpty(result, "request_body", enriched.Entry.RequestBody)
+	addNonEmpty(result, "response_body", enriched.Entry.ResponseBody)
+	addNonEmpty(result, "error", enriched.Entry.Error)
+
+	return result
+}
@@ -0,0 +1,70 @@
+// Purpose: Adapts generic cursor pagination to Server-Sent Events streams.
+// Why: Keeps SSE-specific serialization isolated from generic pagination rules, as for websocket events.
+// Docs: docs/features/feature/pagination/index.md
+
+package pagination
+
+import "github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+
+// SSEEntryWithSequence pairs an SSE event with its sequence number and timestamp for pagination.
+type SSEEntryWithSequence struct {
+	Entry     capture.SSEEvent
+	Sequence  int64
+	Timestamp string
+}
+
+// GetSequence implements Sequenced.
+func (e SSEEntryWithSequence) GetSequence() int64 { return e.Sequence }
+
+// GetTimestamp implements Sequenced.
+func (e SSEEntryWithSequence) GetTimestamp() string { return e.Timestamp }
+
+// EnrichSSEEntries adds sequence numbers to SSE events for pagination.
+// Must be called with the UNFILTERED entry list to get correct sequence numbers.
+func EnrichSSEEntries(events []capture.SSEEvent, sseTotalAdded int64) []SSEEntryWithSequence {
+	enriched := make([]SSEEntryWithSequence, len(events))
+	baseSeq := sseTotalAdded - int64(len(events)) + 1
+
+	for i, event := range events {
+		enriched[i] = SSEEntryWithSequence{
+			Entry:     event,
+			Sequence:  baseSeq + int64(i),
+			Timestamp: event.Timestamp,
+		}
+	}
+
+	return enriched
+}
+
+// SerializeSSEEntryWithSequence converts an SSEEntryWithSequence to a JSON-serializable map.
+func SerializeSSEEntryWithSequence(enriched SSEEntryWithSequence) map[string]any {
+	result := map[string]any{
+		"event":     enriched.Entry.Event,
+		"id":        enriched.Entry.ID,
+		"timestamp": enriched.Timestamp,
+		"sequence":  enriched.Sequence,
+	}
+
+	addNonEmpty(result, "url", enriched.Entry.URL)
+	addNonEmpty(result, "event_type", enriched.Entry.EventType)
+	addNonEmpty(result, "last_event_id", enriched.Entry.LastEventID)
+	addNonEmpty(result, "data", enriched.Entry.Data)
+
+	if enriched.Entry.Size > 0 {
+		result["size"] = enriched.Entry.Size
+	}
+	if enriched.Entry.Truncated {
+		result["truncated"] = true
+	}
+	if enriched.Entry.WithCredentials {
+		result["with_credentials"] = true
+	}
+	if enriched.Entry.TabID > 0 {
+		result["tab_id"] = enriched.Entry.TabID
+	}
+	if enriched.Entry.FrameID > 0 {
+		result["frame_id"] = enriched.Entry.FrameID
+	}
+
+	return result
+}
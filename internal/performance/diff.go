@@ -11,6 +11,8 @@ package performance
 import (
 	"fmt"
 	"math"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/remediation"
 )
 
 // ============================================
@@ -45,6 +47,8 @@ type MetricDiff struct {
 	Unit     string  `json:"unit,omitempty"` // "ms", "KB", "count" — empty for unitless (CLS)
 	Improved bool    `json:"improved"`
 	Rating   string  `json:"rating,omitempty"` // "good", "needs_improvement", "poor" per Web Vitals thresholds
+	// FindingID names the remediation playbook when Rating is needs_improvement or poor.
+	FindingID string `json:"finding_id,omitempty"`
 }
 
 // PerfDiff holds the complete diff result with all metrics and resources.
@@ -106,14 +110,16 @@ func buildMetricDiff(name string, beforeVal, afterVal float64) (MetricDiff, bool
 		delta = round1(afterVal - beforeVal)
 		pctStr = formatPct((afterVal - beforeVal) / beforeVal * 100)
 	}
+	rating := rateMetric(name, round1(afterVal))
 	return MetricDiff{
-		Before:   round1(beforeVal),
-		After:    round1(afterVal),
-		Delta:    delta,
-		Pct:      pctStr,
-		Unit:     unitForMetric(name),
-		Improved: delta < 0,
-		Rating:   rateMetric(name, round1(afterVal)),
+		Before:    round1(beforeVal),
+		After:     round1(afterVal),
+		Delta:     delta,
+		Pct:       pctStr,
+		Unit:      unitForMetric(name),
+		Improved:  delta < 0,
+		Rating:    rating,
+		FindingID: vitalFindingID(name, rating),
 	}, true
 }
 
@@ -232,6 +238,39 @@ var webVitalsThresholds = map[string]metricThreshold{
 	"cls":  {good: 0.1, ni: 0.25},
 }
 
+// VitalFinding is a Web Vital rated needs_improvement or poor, with its remediation playbook ID.
+type VitalFinding struct {
+	FindingID string  `json:"finding_id"`
+	Metric    string  `json:"metric"`
+	Value     float64 `json:"value"`
+	Rating    string  `json:"rating"`
+}
+
+// RateVitals returns a finding for each metric in values (keyed lcp, fcp, ttfb, cls)
+// that misses the "good" threshold, in lcp, fcp, ttfb, cls order.
+func RateVitals(values map[string]float64) []VitalFinding {
+	var findings []VitalFinding
+	for _, name := range []string{"lcp", "fcp", "ttfb", "cls"} {
+		value, ok := values[name]
+		if !ok {
+			continue
+		}
+		rating := rateMetric(name, value)
+		if id := vitalFindingID(name, rating); id != "" {
+			findings = append(findings, VitalFinding{FindingID: id, Metric: name, Value: value, Rating: rating})
+		}
+	}
+	return findings
+}
+
+// vitalFindingID returns the playbook ID for a metric that is not rated good, or "".
+func vitalFindingID(name, rating string) string {
+	if rating != "needs_improvement" && rating != "poor" {
+		return ""
+	}
+	return remediation.PerformanceFindingID(name)
+}
+
 // rateMetric returns a Web Vitals rating for the given metric's current value.
 // Returns "" for metrics without standard thresholds.
 func rateMetric(name string, value float64) string {
@@ -501,6 +501,26 @@ func TestPerfDiff_LCP_Rating_Poor(t *testing.T) {
 	if lcp.Rating != "poor" {
 		t.Errorf("LCP 5000ms rating = %q, want 'poor' (>4000ms)", lcp.Rating)
 	}
+	if lcp.FindingID != "performance.lcp" {
+		t.Errorf("poor LCP finding_id = %q, want performance.lcp", lcp.FindingID)
+	}
+	if load := diff.Metrics["load"]; load.FindingID != "" {
+		t.Errorf("unrated metric should have no finding_id, got %q", load.FindingID)
+	}
+}
+
+func TestRateVitals_FlagsOnlyMetricsBelowGood(t *testing.T) {
+	t.Parallel()
+	findings := RateVitals(map[string]float64{"lcp": 1200, "fcp": 2000, "ttfb": 2500, "cls": 0.05})
+	if len(findings) != 2 {
+		t.Fatalf("RateVitals = %+v, want fcp and ttfb", findings)
+	}
+	if findings[0].FindingID != "performance.fcp" || findings[0].Rating != "needs_improvement" {
+		t.Errorf("findings[0] = %+v", findings[0])
+	}
+	if findings[1].FindingID != "performance.ttfb" || findings[1].Rating != "poor" {
+		t.Errorf("findings[1] = %+v", findings[1])
+	}
 }
 
 func TestPerfDiff_CLS_Rating_NeedsImprovement(t *testing.T) {
@@ -0,0 +1,215 @@
+// Purpose: Keeps per-route memory samples across navigations and flags routes whose heap grows on every load.
+// Why: The snapshot map holds only the latest load per URL; a leak only shows as growth across repeated navigations.
+// Docs: docs/features/feature/memory-telemetry/index.md
+
+package performance
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// LeakNavigations is how many consecutive navigations of a route must each grow the heap
+	// before a leak is suspected.
+	LeakNavigations = 4
+	// leakMinGrowthPct and leakMinGrowthBytes filter out allocator noise: the growth across
+	// the run must exceed both.
+	leakMinGrowthPct   = 10
+	leakMinGrowthBytes = 1 << 20
+	// maxMemoryRoutes bounds route cardinality; the least recently sampled route is evicted.
+	maxMemoryRoutes = 100
+	// maxMemorySamplesPerRoute bounds navigations kept per route.
+	maxMemorySamplesPerRoute = 20
+)
+
+// MemoryPoint is one navigation's memory sample.
+type MemoryPoint struct {
+	Timestamp string `json:"ts"`
+	MemorySample
+}
+
+// MemoryRouteTrend summarizes the samples kept for one route.
+type MemoryRouteTrend struct {
+	Route       string `json:"route"`
+	Navigations int    `json:"navigations"`
+	// HeapMetric is the field the trend and leak check use: used_js_heap_size, or
+	// ua_specific_bytes when only that is present on every sample. Empty when neither is.
+	HeapMetric     string  `json:"heap_metric,omitempty"`
+	HeapFirstBytes int64   `json:"heap_first_bytes,omitempty"`
+	HeapLastBytes  int64   `json:"heap_last_bytes,omitempty"`
+	HeapDeltaBytes int64   `json:"heap_delta_bytes,omitempty"`
+	HeapDeltaPct   float64 `json:"heap_delta_pct,omitempty"`
+	// GrowingNavigations is the length of the trailing run of samples whose heap exceeds the one before.
+	GrowingNavigations int    `json:"growing_navigations"`
+	DOMNodesFirst      int    `json:"dom_nodes_first"`
+	DOMNodesLast       int    `json:"dom_nodes_last"`
+	DetachedListeners  int    `json:"detached_listeners"`
+	LeakSuspected      bool   `json:"leak_suspected"`
+	LeakReason         string `json:"leak_reason,omitempty"`
+	// Samples holds the most recent navigations, oldest first.
+	Samples []MemoryPoint `json:"samples"`
+	last    time.Time
+}
+
+// MemoryHistory is a thread-safe store of memory samples keyed by route.
+type MemoryHistory struct {
+	mu     sync.Mutex
+	routes map[string][]MemoryPoint
+	seen   map[string]time.Time
+}
+
+// NewMemoryHistory creates an empty history.
+func NewMemoryHistory() *MemoryHistory {
+	return &MemoryHistory{routes: make(map[string][]MemoryPoint), seen: make(map[string]time.Time)}
+}
+
+// Record adds the snapshot's memory sample to its route. A sample from the same document
+// load (same time origin) as the route's latest replaces it, so snapshot re-sends within
+// one page view count as one navigation. Returns false when the snapshot carries no sample.
+func (h *MemoryHistory) Record(snapshot PerformanceSnapshot, now time.Time) bool {
+	if snapshot.Memory == nil || snapshot.URL == "" {
+		return false
+	}
+	route := VitalsRoute(snapshot.URL)
+	ts := snapshot.Timestamp
+	if ts == "" {
+		ts = now.UTC().Format(time.RFC3339)
+	}
+	point := MemoryPoint{Timestamp: ts, MemorySample: *snapshot.Memory}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	points := h.routes[route]
+	if n := len(points); n > 0 && point.TimeOrigin != 0 && points[n-1].TimeOrigin == point.TimeOrigin {
+		points[n-1] = point
+	} else {
+		if len(points) >= maxMemorySamplesPerRoute {
+			points = points[len(points)-maxMemorySamplesPerRoute+1:]
+		}
+		points = append(points, point)
+	}
+	h.routes[route] = points
+	h.seen[route] = now
+	h.evictLocked()
+	return true
+}
+
+func (h *MemoryHistory) evictLocked() {
+	for len(h.routes) > maxMemoryRoutes {
+		oldest, oldestAt := "", time.Time{}
+		for route, at := range h.seen {
+			if oldest == "" || at.Before(oldestAt) {
+				oldest, oldestAt = route, at
+			}
+		}
+		delete(h.routes, oldest)
+		delete(h.seen, oldest)
+	}
+}
+
+// Trends summarizes every route whose key contains routeFilter, suspected leaks first,
+// then most recently sampled. sampleLimit caps samples per route (<= 0 keeps all).
+func (h *MemoryHistory) Trends(routeFilter string, sampleLimit int) []MemoryRouteTrend {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	out := make([]MemoryRouteTrend, 0, len(h.routes))
+	for route, points := range h.routes {
+		if routeFilter != "" && !strings.Contains(route, routeFilter) {
+			continue
+		}
+		trend := memoryTrend(route, points)
+		trend.last = h.seen[route]
+		if sampleLimit > 0 && len(trend.Samples) > sampleLimit {
+			trend.Samples = trend.Samples[len(trend.Samples)-sampleLimit:]
+		}
+		out = append(out, trend)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].LeakSuspected != out[j].LeakSuspected {
+			return out[i].LeakSuspected
+		}
+		if !out[i].last.Equal(out[j].last) {
+			return out[i].last.After(out[j].last)
+		}
+		return out[i].Route < out[j].Route
+	})
+	return out
+}
+
+// memoryTrend computes the heap trend and leak heuristic for one route's samples.
+func memoryTrend(route string, points []MemoryPoint) MemoryRouteTrend {
+	samples := make([]MemoryPoint, len(points))
+	copy(samples, points)
+	first, last := samples[0], samples[len(samples)-1]
+	t := MemoryRouteTrend{
+		Route:             route,
+		Navigations:       len(samples),
+		DOMNodesFirst:     first.DOMNodes,
+		DOMNodesLast:      last.DOMNodes,
+		DetachedListeners: last.DetachedListeners,
+		Samples:           samples,
+	}
+
+	heap, metric := heapSeries(samples)
+	if heap == nil {
+		return t
+	}
+	t.HeapMetric = metric
+	t.HeapFirstBytes, t.HeapLastBytes = heap[0], heap[len(heap)-1]
+	t.HeapDeltaBytes = t.HeapLastBytes - t.HeapFirstBytes
+	if t.HeapFirstBytes > 0 {
+		t.HeapDeltaPct = round1(float64(t.HeapDeltaBytes) * 100 / float64(t.HeapFirstBytes))
+	}
+
+	run := 1
+	for i := len(heap) - 1; i > 0 && heap[i] > heap[i-1]; i-- {
+		run++
+	}
+	if run == 1 {
+		run = 0 // a single navigation is not growth
+	}
+	t.GrowingNavigations = run
+	if run < LeakNavigations {
+		return t
+	}
+	start, end := heap[len(heap)-run], heap[len(heap)-1]
+	growth := end - start
+	if growth < leakMinGrowthBytes || float64(growth)*100 < float64(start)*leakMinGrowthPct {
+		return t
+	}
+	t.LeakSuspected = true
+	t.LeakReason = fmt.Sprintf("Heap grew on each of the last %d navigations: %.1f MB to %.1f MB (+%.0f%%)",
+		run, float64(start)/(1<<20), float64(end)/(1<<20), float64(growth)*100/float64(start))
+	if nodes := last.DOMNodes - samples[len(samples)-run].DOMNodes; nodes > 0 {
+		t.LeakReason += fmt.Sprintf("; DOM nodes +%d", nodes)
+	}
+	if last.DetachedListeners > 0 {
+		t.LeakReason += fmt.Sprintf("; %d listener(s) on detached nodes", last.DetachedListeners)
+	}
+	return t
+}
+
+// heapSeries picks the heap reading present on every sample, preferring performance.memory.
+func heapSeries(samples []MemoryPoint) ([]int64, string) {
+	used := make([]int64, 0, len(samples))
+	ua := make([]int64, 0, len(samples))
+	for _, s := range samples {
+		if s.UsedJSHeapSize > 0 {
+			used = append(used, s.UsedJSHeapSize)
+		}
+		if s.UASpecificBytes != nil {
+			ua = append(ua, *s.UASpecificBytes)
+		}
+	}
+	switch len(samples) {
+	case len(used):
+		return used, "used_js_heap_size"
+	case len(ua):
+		return ua, "ua_specific_bytes"
+	}
+	return nil, ""
+}
@@ -0,0 +1,78 @@
+// Purpose: Tests memory sample recording per route, same-load replacement, trends, and the leak heuristic.
+// Docs: docs/features/feature/memory-telemetry/index.md
+
+package performance
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func memorySnapshot(url string, origin float64, heapMB float64, nodes, detached int) PerformanceSnapshot {
+	return PerformanceSnapshot{URL: url, Memory: &MemorySample{
+		TimeOrigin: origin, UsedJSHeapSize: int64(heapMB * (1 << 20)), DOMNodes: nodes, DetachedListeners: detached,
+	}}
+}
+
+func TestMemoryHistory_LeakSuspectedAfterMonotonicGrowth(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC)
+	h := NewMemoryHistory()
+	if h.Record(PerformanceSnapshot{URL: "/cart"}, now) {
+		t.Fatal("a snapshot without memory should not be recorded")
+	}
+
+	// /cart grows on every load; /home fluctuates.
+	for i, heap := range []float64{10, 12, 14, 16, 19} {
+		at := now.Add(time.Duration(i) * time.Minute)
+		h.Record(memorySnapshot("/cart", float64(1000+i), heap, 1200+100*i, i), at)
+		h.Record(memorySnapshot("/home", float64(2000+i), []float64{8, 9, 8, 9, 8}[i], 900, 0), at)
+	}
+	// A re-send from the last /cart load replaces its sample instead of adding a navigation.
+	h.Record(memorySnapshot("/cart", 1004, 20, 1600, 4), now.Add(5*time.Minute))
+
+	trends := h.Trends("", 0)
+	if len(trends) != 2 {
+		t.Fatalf("trends = %+v, want two routes", trends)
+	}
+	cart := trends[0]
+	if cart.Route != "/cart" || !cart.LeakSuspected || cart.Navigations != 5 || cart.GrowingNavigations != 5 {
+		t.Fatalf("cart trend = %+v, want a suspected leak over 5 navigations", cart)
+	}
+	if cart.HeapMetric != "used_js_heap_size" || cart.HeapDeltaPct != 100 || cart.DOMNodesLast != 1600 || cart.DetachedListeners != 4 {
+		t.Fatalf("cart trend = %+v", cart)
+	}
+	if !strings.Contains(cart.LeakReason, "10.0 MB to 20.0 MB") || !strings.Contains(cart.LeakReason, "4 listener(s) on detached nodes") {
+		t.Fatalf("leak reason = %q", cart.LeakReason)
+	}
+	if home := trends[1]; home.LeakSuspected || home.GrowingNavigations != 0 {
+		t.Fatalf("home trend = %+v, want no leak", home)
+	}
+
+	if limited := h.Trends("cart", 2); len(limited) != 1 || len(limited[0].Samples) != 2 || limited[0].Samples[1].UsedJSHeapSize != 20<<20 {
+		t.Fatalf("filtered trends = %+v", limited)
+	}
+}
+
+func TestMemoryHistory_SmallGrowthAndMissingHeapAreNotLeaks(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC)
+	h := NewMemoryHistory()
+	for i := 0; i < 6; i++ {
+		h.Record(memorySnapshot("/search", float64(i+1), 50+0.1*float64(i), 500, 0), now)
+		// No performance.memory (non-Chrome): DOM nodes only.
+		h.Record(PerformanceSnapshot{URL: "/about", Memory: &MemorySample{TimeOrigin: float64(i + 1), DOMNodes: 300 + i}}, now)
+	}
+	for _, trend := range h.Trends("", 0) {
+		if trend.LeakSuspected {
+			t.Fatalf("%s: leak suspected on noise: %+v", trend.Route, trend)
+		}
+		if trend.Route == "/about" && (trend.HeapMetric != "" || trend.DOMNodesLast != 305) {
+			t.Fatalf("about trend = %+v, want DOM nodes only", trend)
+		}
+		if trend.Route == "/search" && trend.GrowingNavigations != 6 {
+			t.Fatalf("search trend = %+v, want growth counted but under the threshold", trend)
+		}
+	}
+}
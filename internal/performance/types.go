@@ -16,14 +16,48 @@ package performance
 // PerformanceSnapshot represents a captured performance snapshot from a page load.
 // Wire fields: see WirePerformanceSnapshot in wire_performance.go
 type PerformanceSnapshot struct {
-	URL        string            `json:"url"`
-	Timestamp  string            `json:"timestamp"`
-	Timing     PerformanceTiming `json:"timing"`
-	Network    NetworkSummary    `json:"network"`
-	LongTasks  LongTaskMetrics   `json:"long_tasks"`
-	CLS        *float64          `json:"cumulative_layout_shift,omitempty"` // snake_case (from browser LayoutShift)
-	Resources  []ResourceEntry   `json:"resources,omitempty"`
-	UserTiming *UserTimingData   `json:"user_timing,omitempty"`
+	URL          string              `json:"url"`
+	Timestamp    string              `json:"timestamp"`
+	Timing       PerformanceTiming   `json:"timing"`
+	Network      NetworkSummary      `json:"network"`
+	LongTasks    LongTaskMetrics     `json:"long_tasks"`
+	CLS          *float64            `json:"cumulative_layout_shift,omitempty"` // snake_case (from browser LayoutShift)
+	Resources    []ResourceEntry     `json:"resources,omitempty"`
+	UserTiming   *UserTimingData     `json:"user_timing,omitempty"`
+	Memory       *MemorySample       `json:"memory,omitempty"`
+	Interactions []InteractionTiming `json:"interactions,omitempty"`
+	Emulation    string              `json:"emulation,omitempty"` // emulated device label, set by the daemon while interact(emulate) is active
+}
+
+// InteractionTiming is the slowest event timing entry of one user interaction, split into
+// the phases INP is made of. Snapshots carry the worst interactions, slowest first.
+type InteractionTiming struct {
+	InteractionID int64  `json:"interaction_id"`
+	Name          string `json:"name"`   // event type, e.g. click, keydown
+	Target        string `json:"target"` // element selector; empty when the node was removed
+	// All times are ms. Duration = InputDelay + ProcessingTime + PresentationDelay.
+	StartTime         float64 `json:"start_time"`
+	Duration          float64 `json:"duration"`
+	InputDelay        float64 `json:"input_delay"`
+	ProcessingTime    float64 `json:"processing_time"`
+	PresentationDelay float64 `json:"presentation_delay"`
+}
+
+// MemorySample holds heap and DOM size readings taken with the snapshot.
+// Heap sizes are bytes from Chrome's performance.memory; UASpecificBytes is from
+// performance.measureUserAgentSpecificMemory(), available only on cross-origin isolated pages.
+type MemorySample struct {
+	// TimeOrigin is performance.timeOrigin and identifies the document load.
+	TimeOrigin      float64 `json:"time_origin"`
+	UsedJSHeapSize  int64   `json:"used_js_heap_size,omitempty"`
+	TotalJSHeapSize int64   `json:"total_js_heap_size,omitempty"`
+	JSHeapSizeLimit int64   `json:"js_heap_size_limit,omitempty"`
+	UASpecificBytes *int64  `json:"ua_specific_bytes,omitempty"`
+	DOMNodes        int     `json:"dom_nodes"`
+	// EventListeners counts listeners registered on nodes since the page loaded and not removed;
+	// DetachedListeners is the share of them on nodes no longer in the document.
+	EventListeners    int `json:"event_listeners"`
+	DetachedListeners int `json:"detached_listeners"`
 }
 
 // UserTimingData holds captured performance.mark() and performance.measure() entries.
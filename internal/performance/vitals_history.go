@@ -0,0 +1,257 @@
+// Purpose: Aggregates per-route Web Vitals samples into daily buckets and computes p75 trend series.
+// Why: The in-memory snapshot map only holds the latest load per URL; multi-day trends need bounded daily history.
+// Docs: docs/features/feature/web-vitals/index.md
+
+package performance
+
+import (
+	"encoding/json"
+	"math"
+	"net/url"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	// VitalsHistoryRetentionDays is how many daily buckets are kept before pruning.
+	VitalsHistoryRetentionDays = 30
+	// vitalsDateLayout is the bucket key format (UTC calendar day).
+	vitalsDateLayout = "2006-01-02"
+	// maxVitalsRoutesPerDay bounds route cardinality inside one daily bucket.
+	maxVitalsRoutesPerDay = 100
+	// maxVitalsSamplesPerMetric bounds samples kept per metric per route per day.
+	maxVitalsSamplesPerMetric = 50
+)
+
+// VitalsDay holds raw samples for one route on one day.
+type VitalsDay struct {
+	Samples int       `json:"samples"`
+	LCP     []float64 `json:"lcp,omitempty"`
+	FCP     []float64 `json:"fcp,omitempty"`
+	CLS     []float64 `json:"cls,omitempty"`
+	INP     []float64 `json:"inp,omitempty"`
+	TTFB    []float64 `json:"ttfb,omitempty"`
+}
+
+// VitalsTrendPoint is the p75 summary of one route on one day.
+type VitalsTrendPoint struct {
+	Date    string   `json:"date"`
+	Samples int      `json:"samples"`
+	LCPP75  *float64 `json:"lcp_p75,omitempty"`
+	FCPP75  *float64 `json:"fcp_p75,omitempty"`
+	CLSP75  *float64 `json:"cls_p75,omitempty"`
+	INPP75  *float64 `json:"inp_p75,omitempty"`
+	TTFBP75 *float64 `json:"ttfb_p75,omitempty"`
+}
+
+// VitalsRouteTrend is the daily p75 series for one route.
+// LCPChangePct compares the last day with LCP data against the first.
+type VitalsRouteTrend struct {
+	Route        string             `json:"route"`
+	Points       []VitalsTrendPoint `json:"points"`
+	LCPChangePct *float64           `json:"lcp_change_pct,omitempty"`
+}
+
+// VitalsHistory is a thread-safe store of daily vitals buckets keyed by date, then route,
+// plus the last day of timestamped samples per route for windowed aggregates.
+// It performs no I/O; callers persist buckets via DayJSON/LoadDay. Recent samples are not persisted.
+type VitalsHistory struct {
+	mu         sync.Mutex
+	days       map[string]map[string]*VitalsDay
+	recent     map[string][]vitalsSample
+	recentSeen map[string]time.Time
+}
+
+// NewVitalsHistory creates an empty history.
+func NewVitalsHistory() *VitalsHistory {
+	return &VitalsHistory{
+		days:       make(map[string]map[string]*VitalsDay),
+		recent:     make(map[string][]vitalsSample),
+		recentSeen: make(map[string]time.Time),
+	}
+}
+
+// VitalsRoute normalizes a page URL into a route key (host + path, no query or fragment).
+func VitalsRoute(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return rawURL
+	}
+	path := u.Path
+	if path == "" {
+		path = "/"
+	}
+	return u.Host + path
+}
+
+// VitalsDate returns the bucket key for a timestamp.
+func VitalsDate(t time.Time) string {
+	return t.UTC().Format(vitalsDateLayout)
+}
+
+// Record adds one snapshot to the bucket for its timestamp (falling back to now).
+// Returns the bucket date, or "" when the snapshot has no URL or the day's route cap is reached.
+func (h *VitalsHistory) Record(snapshot PerformanceSnapshot, now time.Time) string {
+	if snapshot.URL == "" {
+		return ""
+	}
+	at := now
+	if ts, err := time.Parse(time.RFC3339Nano, snapshot.Timestamp); err == nil {
+		at = ts
+	}
+	date := VitalsDate(at)
+	route := VitalsRoute(snapshot.URL)
+	values := snapshotVitals(snapshot)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.recordRecentLocked(route, at, now, values)
+
+	bucket, ok := h.days[date]
+	if !ok {
+		bucket = make(map[string]*VitalsDay)
+		h.days[date] = bucket
+	}
+	day, ok := bucket[route]
+	if !ok {
+		if len(bucket) >= maxVitalsRoutesPerDay {
+			return ""
+		}
+		day = &VitalsDay{}
+		bucket[route] = day
+	}
+
+	day.Samples++
+	day.LCP = appendVitalsSample(day.LCP, values.LCP)
+	day.FCP = appendVitalsSample(day.FCP, values.FCP)
+	day.CLS = appendVitalsSample(day.CLS, values.CLS)
+	day.INP = appendVitalsSample(day.INP, values.INP)
+	day.TTFB = appendVitalsSample(day.TTFB, values.TTFB)
+	return date
+}
+
+// appendVitalsSample appends v (when present), dropping the oldest sample once at capacity.
+func appendVitalsSample(samples []float64, v *float64) []float64 {
+	if v == nil {
+		return samples
+	}
+	if len(samples) >= maxVitalsSamplesPerMetric {
+		samples = samples[len(samples)-maxVitalsSamplesPerMetric+1:]
+	}
+	return append(samples, *v)
+}
+
+// DayJSON serializes one daily bucket for persistence.
+func (h *VitalsHistory) DayJSON(date string) ([]byte, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return json.Marshal(h.days[date])
+}
+
+// LoadDay replaces one daily bucket from persisted JSON.
+func (h *VitalsHistory) LoadDay(date string, data []byte) error {
+	if _, err := time.Parse(vitalsDateLayout, date); err != nil {
+		return err
+	}
+	var bucket map[string]*VitalsDay
+	if err := json.Unmarshal(data, &bucket); err != nil {
+		return err
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if bucket == nil {
+		bucket = make(map[string]*VitalsDay)
+	}
+	h.days[date] = bucket
+	return nil
+}
+
+// Prune drops buckets older than the retention window and returns the dropped dates.
+func (h *VitalsHistory) Prune(now time.Time) []string {
+	cutoff := VitalsDate(now.AddDate(0, 0, -VitalsHistoryRetentionDays))
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	var dropped []string
+	for date := range h.days {
+		if date < cutoff {
+			delete(h.days, date)
+			dropped = append(dropped, date)
+		}
+	}
+	sort.Strings(dropped)
+	return dropped
+}
+
+// VitalsHistoryExpired reports whether a bucket date falls outside the retention window.
+func VitalsHistoryExpired(date string, now time.Time) bool {
+	return date < VitalsDate(now.AddDate(0, 0, -VitalsHistoryRetentionDays))
+}
+
+// Trend returns daily p75 series for the last `days` days ending at now.
+// When route is non-empty only that route (normalized via VitalsRoute) is returned.
+// Routes are sorted by name; days without samples are omitted from a route's points.
+func (h *VitalsHistory) Trend(route string, days int, now time.Time) []VitalsRouteTrend {
+	if days <= 0 {
+		days = 1
+	}
+	if days > VitalsHistoryRetentionDays {
+		days = VitalsHistoryRetentionDays
+	}
+	if route != "" {
+		route = VitalsRoute(route)
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	byRoute := make(map[string][]VitalsTrendPoint)
+	for i := days - 1; i >= 0; i-- {
+		date := VitalsDate(now.AddDate(0, 0, -i))
+		for r, day := range h.days[date] {
+			if route != "" && r != route {
+				continue
+			}
+			byRoute[r] = append(byRoute[r], VitalsTrendPoint{
+				Date:    date,
+				Samples: day.Samples,
+				LCPP75:  p75(day.LCP),
+				FCPP75:  p75(day.FCP),
+				CLSP75:  p75(day.CLS),
+				INPP75:  p75(day.INP),
+				TTFBP75: p75(day.TTFB),
+			})
+		}
+	}
+
+	out := make([]VitalsRouteTrend, 0, len(byRoute))
+	for r, points := range byRoute {
+		out = append(out, VitalsRouteTrend{Route: r, Points: points, LCPChangePct: lcpChangePct(points)})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Route < out[j].Route })
+	return out
+}
+
+// lcpChangePct compares the last LCP p75 against the first one in the series.
+func lcpChangePct(points []VitalsTrendPoint) *float64 {
+	var first, last *float64
+	for _, p := range points {
+		if p.LCPP75 == nil {
+			continue
+		}
+		if first == nil {
+			first = p.LCPP75
+		}
+		last = p.LCPP75
+	}
+	if first == nil || last == first || *first == 0 {
+		return nil
+	}
+	pct := math.Round((*last-*first) / *first * 1000) / 10
+	return &pct
+}
+
+// p75 returns the 75th percentile (linear interpolation) or nil for no samples.
+func p75(samples []float64) *float64 {
+	return percentile(samples, 0.75)
+}
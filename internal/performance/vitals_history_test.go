@@ -0,0 +1,125 @@
+// Purpose: Tests for daily vitals history aggregation, persistence round-trips, and p75 trend series.
+// Docs: docs/features/feature/web-vitals/index.md
+
+package performance
+
+import (
+	"testing"
+	"time"
+)
+
+func vitalsSnap(url string, at time.Time, lcp float64) PerformanceSnapshot {
+	return PerformanceSnapshot{
+		URL:       url,
+		Timestamp: at.UTC().Format(time.RFC3339Nano),
+		Timing: PerformanceTiming{
+			LargestContentfulPaint: &lcp,
+			TimeToFirstByte:        120,
+		},
+	}
+}
+
+func TestVitalsRoute_StripsQueryAndFragment(t *testing.T) {
+	t.Parallel()
+	cases := map[string]string{
+		"https://app.test/checkout?step=2#top": "app.test/checkout",
+		"https://app.test":                     "app.test/",
+		"not a url":                            "not a url",
+	}
+	for in, want := range cases {
+		if got := VitalsRoute(in); got != want {
+			t.Errorf("VitalsRoute(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestVitalsHistory_TrendDailyP75(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	h := NewVitalsHistory()
+
+	for _, lcp := range []float64{1000, 2000, 3000, 4000, 5000} {
+		h.Record(vitalsSnap("https://app.test/home?a=1", now.AddDate(0, 0, -2), lcp), now)
+	}
+	h.Record(vitalsSnap("https://app.test/home", now, 2000), now)
+	h.Record(vitalsSnap("https://app.test/other", now, 900), now)
+
+	trends := h.Trend("https://app.test/home", 7, now)
+	if len(trends) != 1 {
+		t.Fatalf("len(trends) = %d, want 1", len(trends))
+	}
+	points := trends[0].Points
+	if len(points) != 2 {
+		t.Fatalf("len(points) = %d, want 2", len(points))
+	}
+	if points[0].Date != "2026-03-08" || points[0].Samples != 5 {
+		t.Fatalf("first point = %+v, want 2026-03-08 with 5 samples", points[0])
+	}
+	if points[0].LCPP75 == nil || *points[0].LCPP75 != 4000 {
+		t.Fatalf("first LCP p75 = %v, want 4000", points[0].LCPP75)
+	}
+	if points[1].TTFBP75 == nil || *points[1].TTFBP75 != 120 {
+		t.Fatalf("second TTFB p75 = %v, want 120", points[1].TTFBP75)
+	}
+	if trends[0].LCPChangePct == nil || *trends[0].LCPChangePct != -50 {
+		t.Fatalf("LCPChangePct = %v, want -50", trends[0].LCPChangePct)
+	}
+
+	if all := h.Trend("", 7, now); len(all) != 2 {
+		t.Fatalf("unfiltered trend routes = %d, want 2", len(all))
+	}
+	if short := h.Trend("", 1, now); len(short) != 2 || len(short[0].Points) != 1 {
+		t.Fatalf("1-day window should only include today's points, got %+v", short)
+	}
+}
+
+func TestVitalsHistory_SampleCapDropsOldest(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	h := NewVitalsHistory()
+	for i := 0; i < maxVitalsSamplesPerMetric+10; i++ {
+		h.Record(vitalsSnap("https://app.test/", now, float64(i)), now)
+	}
+	day := h.days[VitalsDate(now)]["app.test/"]
+	if len(day.LCP) != maxVitalsSamplesPerMetric {
+		t.Fatalf("len(LCP) = %d, want %d", len(day.LCP), maxVitalsSamplesPerMetric)
+	}
+	if day.LCP[0] != 10 {
+		t.Fatalf("oldest retained sample = %v, want 10", day.LCP[0])
+	}
+	if day.Samples != maxVitalsSamplesPerMetric+10 {
+		t.Fatalf("Samples = %d, want %d", day.Samples, maxVitalsSamplesPerMetric+10)
+	}
+}
+
+func TestVitalsHistory_DayJSONRoundTripAndPrune(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	src := NewVitalsHistory()
+	old := now.AddDate(0, 0, -(VitalsHistoryRetentionDays + 1))
+	oldDate := src.Record(vitalsSnap("https://app.test/", old, 1500), now)
+	today := src.Record(vitalsSnap("https://app.test/", now, 2500), now)
+
+	data, err := src.DayJSON(today)
+	if err != nil {
+		t.Fatalf("DayJSON: %v", err)
+	}
+	dst := NewVitalsHistory()
+	if err := dst.LoadDay(today, data); err != nil {
+		t.Fatalf("LoadDay: %v", err)
+	}
+	if got := dst.Trend("https://app.test/", 1, now); len(got) != 1 || *got[0].Points[0].LCPP75 != 2500 {
+		t.Fatalf("round-tripped trend = %+v", got)
+	}
+	if err := dst.LoadDay("not-a-date", data); err == nil {
+		t.Fatal("LoadDay should reject non-date keys")
+	}
+
+	if !VitalsHistoryExpired(oldDate, now) {
+		t.Fatalf("date %s should be expired", oldDate)
+	}
+	dropped := src.Prune(now)
+	if len(dropped) != 1 || dropped[0] != oldDate {
+		t.Fatalf("Prune dropped %v, want [%s]", dropped, oldDate)
+	}
+}
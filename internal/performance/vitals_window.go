@@ -0,0 +1,205 @@
+// Purpose: Keeps timestamped per-route Web Vitals samples for the last day and aggregates them by percentile over a window.
+// Why: Daily buckets drop sample times; telling a one-off slow load from a regression needs p75 over the last hour, as CrUX reports it.
+// Docs: docs/features/feature/web-vitals/index.md
+
+package performance
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// VitalsMaxWindow is the longest aggregation window; older samples are dropped.
+	// Longer ranges are served by the daily buckets (Trend).
+	VitalsMaxWindow = 24 * time.Hour
+	// maxRecentVitalsSamples bounds timestamped samples kept per route.
+	maxRecentVitalsSamples = 200
+	// maxRecentVitalsRoutes bounds route cardinality; the least recently sampled route is evicted.
+	maxRecentVitalsRoutes = 100
+)
+
+// vitalsPercentiles maps the accepted aggregate names to their quantile.
+var vitalsPercentiles = map[string]float64{"p50": 0.50, "p75": 0.75, "p90": 0.90, "p95": 0.95, "p99": 0.99}
+
+// VitalsValues holds one value per Web Vital; nil when no sample carried the metric.
+type VitalsValues struct {
+	LCP  *float64 `json:"lcp,omitempty"`
+	FCP  *float64 `json:"fcp,omitempty"`
+	CLS  *float64 `json:"cls,omitempty"`
+	INP  *float64 `json:"inp,omitempty"`
+	TTFB *float64 `json:"ttfb,omitempty"`
+}
+
+// vitalsSample is one page load's vitals with its capture time.
+type vitalsSample struct {
+	at     time.Time
+	values VitalsValues
+}
+
+// VitalsRouteAggregate is the percentile of each vital over the samples of one route
+// inside the window, next to the latest sample for comparison.
+type VitalsRouteAggregate struct {
+	Route   string       `json:"route"`
+	Samples int          `json:"samples"`
+	Values  VitalsValues `json:"values"`
+	Latest  VitalsValues `json:"latest"`
+	FirstAt string       `json:"first_at"`
+	LastAt  string       `json:"last_at"`
+}
+
+// ParseVitalsAggregate validates an aggregate name (p50, p75, p90, p95, p99) and returns its quantile.
+func ParseVitalsAggregate(name string) (float64, error) {
+	q, ok := vitalsPercentiles[strings.ToLower(name)]
+	if !ok {
+		return 0, fmt.Errorf("unknown aggregate %q: use p50, p75, p90, p95, or p99", name)
+	}
+	return q, nil
+}
+
+// ParseVitalsWindow parses a Go duration ("90m", "1h") bounded by VitalsMaxWindow.
+func ParseVitalsWindow(s string) (time.Duration, error) {
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid window %q: use a duration such as 30m, 1h, or 24h", s)
+	}
+	if d <= 0 || d > VitalsMaxWindow {
+		return 0, fmt.Errorf("window %s out of range: must be positive and at most %s", s, VitalsMaxWindow)
+	}
+	return d, nil
+}
+
+// snapshotVitals extracts the vitals a snapshot carries.
+func snapshotVitals(snapshot PerformanceSnapshot) VitalsValues {
+	v := VitalsValues{
+		LCP: snapshot.Timing.LargestContentfulPaint,
+		FCP: snapshot.Timing.FirstContentfulPaint,
+		CLS: snapshot.CLS,
+		INP: snapshot.Timing.InteractionToNextPaint,
+	}
+	if snapshot.Timing.TimeToFirstByte > 0 {
+		ttfb := snapshot.Timing.TimeToFirstByte
+		v.TTFB = &ttfb
+	}
+	return v
+}
+
+// recordRecentLocked appends a timestamped sample for route and drops samples older than
+// VitalsMaxWindow. Caller holds h.mu.
+func (h *VitalsHistory) recordRecentLocked(route string, at, now time.Time, values VitalsValues) {
+	samples := pruneVitalsSamples(h.recent[route], now.Add(-VitalsMaxWindow))
+	if len(samples) >= maxRecentVitalsSamples {
+		samples = samples[len(samples)-maxRecentVitalsSamples+1:]
+	}
+	h.recent[route] = append(samples, vitalsSample{at: at, values: values})
+	h.recentSeen[route] = now
+
+	for len(h.recent) > maxRecentVitalsRoutes {
+		oldest, oldestAt := "", time.Time{}
+		for r, seen := range h.recentSeen {
+			if oldest == "" || seen.Before(oldestAt) {
+				oldest, oldestAt = r, seen
+			}
+		}
+		delete(h.recent, oldest)
+		delete(h.recentSeen, oldest)
+	}
+}
+
+// pruneVitalsSamples drops samples captured before cutoff, keeping arrival order.
+func pruneVitalsSamples(samples []vitalsSample, cutoff time.Time) []vitalsSample {
+	kept := samples[:0]
+	for _, s := range samples {
+		if !s.at.Before(cutoff) {
+			kept = append(kept, s)
+		}
+	}
+	return kept
+}
+
+// Aggregate returns the q-quantile of each vital per route over samples captured within
+// window of now. When route is non-empty only that route (normalized via VitalsRoute) is
+// returned. Routes without samples in the window are omitted; the rest are sorted by name.
+func (h *VitalsHistory) Aggregate(route string, q float64, window time.Duration, now time.Time) []VitalsRouteAggregate {
+	if route != "" {
+		route = VitalsRoute(route)
+	}
+	cutoff := now.Add(-window)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	out := make([]VitalsRouteAggregate, 0)
+	for r, samples := range h.recent {
+		if route != "" && r != route {
+			continue
+		}
+		var inWindow []vitalsSample
+		for _, s := range samples {
+			if !s.at.Before(cutoff) && !s.at.After(now) {
+				inWindow = append(inWindow, s)
+			}
+		}
+		if len(inWindow) == 0 {
+			continue
+		}
+		sort.SliceStable(inWindow, func(i, j int) bool { return inWindow[i].at.Before(inWindow[j].at) })
+		out = append(out, aggregateVitals(r, inWindow, q))
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Route < out[j].Route })
+	return out
+}
+
+// aggregateVitals computes the quantile per metric for time-ordered samples.
+func aggregateVitals(route string, samples []vitalsSample, q float64) VitalsRouteAggregate {
+	var lcp, fcp, cls, inp, ttfb []float64
+	collect := func(dst []float64, v *float64) []float64 {
+		if v == nil {
+			return dst
+		}
+		return append(dst, *v)
+	}
+	for _, s := range samples {
+		lcp = collect(lcp, s.values.LCP)
+		fcp = collect(fcp, s.values.FCP)
+		cls = collect(cls, s.values.CLS)
+		inp = collect(inp, s.values.INP)
+		ttfb = collect(ttfb, s.values.TTFB)
+	}
+	last := samples[len(samples)-1]
+	return VitalsRouteAggregate{
+		Route:   route,
+		Samples: len(samples),
+		Values: VitalsValues{
+			LCP:  percentile(lcp, q),
+			FCP:  percentile(fcp, q),
+			CLS:  percentile(cls, q),
+			INP:  percentile(inp, q),
+			TTFB: percentile(ttfb, q),
+		},
+		Latest:  last.values,
+		FirstAt: samples[0].at.UTC().Format(time.RFC3339),
+		LastAt:  last.at.UTC().Format(time.RFC3339),
+	}
+}
+
+// percentile returns the q-quantile (linear interpolation) or nil for no samples.
+func percentile(samples []float64, q float64) *float64 {
+	if len(samples) == 0 {
+		return nil
+	}
+	sorted := append([]float64(nil), samples...)
+	sort.Float64s(sorted)
+	index := q * float64(len(sorted)-1)
+	lower := int(math.Floor(index))
+	upper := int(math.Ceil(index))
+	v := sorted[lower]
+	if lower != upper {
+		fraction := index - float64(lower)
+		v = sorted[lower]*(1-fraction) + sorted[upper]*fraction
+	}
+	return &v
+}
@@ -0,0 +1,86 @@
+// Purpose: Tests windowed percentile aggregation over timestamped per-route vitals samples.
+// Docs: docs/features/feature/web-vitals/index.md
+
+package performance
+
+import (
+	"testing"
+	"time"
+)
+
+func TestVitalsHistory_AggregateOverWindow(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	h := NewVitalsHistory()
+
+	// Two hours ago the route was slow; in the last hour one outlier among fast loads.
+	for _, lcp := range []float64{6000, 6500, 7000} {
+		h.Record(vitalsSnap("https://app.test/home", now.Add(-2*time.Hour), lcp), now)
+	}
+	for i, lcp := range []float64{1200, 1400, 1300, 1500, 5200} {
+		h.Record(vitalsSnap("https://app.test/home?ref=x", now.Add(time.Duration(i-50)*time.Minute), lcp), now)
+	}
+	h.Record(vitalsSnap("https://app.test/other", now.Add(-10*time.Minute), 900), now)
+
+	aggs := h.Aggregate("https://app.test/home", 0.75, time.Hour, now)
+	if len(aggs) != 1 {
+		t.Fatalf("len(aggs) = %d, want 1", len(aggs))
+	}
+	home := aggs[0]
+	if home.Route != "app.test/home" || home.Samples != 5 {
+		t.Fatalf("home aggregate = %+v, want 5 samples in the last hour", home)
+	}
+	if home.Values.LCP == nil || *home.Values.LCP != 1500 {
+		t.Fatalf("LCP p75 = %v, want 1500", home.Values.LCP)
+	}
+	if home.Latest.LCP == nil || *home.Latest.LCP != 5200 {
+		t.Fatalf("latest LCP = %v, want the 5200 outlier", home.Latest.LCP)
+	}
+	if home.Values.TTFB == nil || *home.Values.TTFB != 120 || home.Values.INP != nil {
+		t.Fatalf("values = %+v, want TTFB 120 and no INP", home.Values)
+	}
+	if home.FirstAt != "2026-03-10T11:10:00Z" || home.LastAt != "2026-03-10T11:14:00Z" {
+		t.Fatalf("first/last = %s/%s", home.FirstAt, home.LastAt)
+	}
+
+	if wide := h.Aggregate("https://app.test/home", 0.5, 3*time.Hour, now); len(wide) != 1 || wide[0].Samples != 8 || *wide[0].Values.LCP != 3350 {
+		t.Fatalf("3h p50 = %+v, want 8 samples with median 3350", wide)
+	}
+	if all := h.Aggregate("", 0.75, time.Hour, now); len(all) != 2 || all[1].Route != "app.test/other" {
+		t.Fatalf("unfiltered aggregate = %+v, want home and other", all)
+	}
+}
+
+func TestVitalsHistory_RecentSamplesOutsideMaxWindowDropped(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	h := NewVitalsHistory()
+	h.Record(vitalsSnap("https://app.test/home", now.Add(-30*time.Hour), 9000), now)
+	h.Record(vitalsSnap("https://app.test/home", now, 1000), now)
+
+	if got := len(h.recent["app.test/home"]); got != 1 {
+		t.Fatalf("recent samples = %d, want samples older than %s dropped", got, VitalsMaxWindow)
+	}
+	// Daily buckets still keep the old sample for trends.
+	if trend := h.Trend("https://app.test/home", 3, now); len(trend) != 1 || len(trend[0].Points) != 2 {
+		t.Fatalf("trend = %+v, want both days", trend)
+	}
+}
+
+func TestParseVitalsAggregateAndWindow(t *testing.T) {
+	t.Parallel()
+	if q, err := ParseVitalsAggregate("P95"); err != nil || q != 0.95 {
+		t.Fatalf("ParseVitalsAggregate(P95) = %v, %v", q, err)
+	}
+	if _, err := ParseVitalsAggregate("avg"); err == nil {
+		t.Fatal("avg should be rejected")
+	}
+	if d, err := ParseVitalsWindow("90m"); err != nil || d != 90*time.Minute {
+		t.Fatalf("ParseVitalsWindow(90m) = %v, %v", d, err)
+	}
+	for _, bad := range []string{"1d", "48h", "-1h", "0s"} {
+		if _, err := ParseVitalsWindow(bad); err == nil {
+			t.Errorf("window %q should be rejected", bad)
+		}
+	}
+}
@@ -61,6 +61,30 @@ type WireUserTimingData struct {
 	Measures []WireUserTimingEntry `json:"measures"`
 }
 
+// WireMemorySample holds heap and DOM size readings taken with the snapshot.
+type WireMemorySample struct {
+	TimeOrigin        float64 `json:"time_origin"`
+	UsedJSHeapSize    int64   `json:"used_js_heap_size,omitempty"`
+	TotalJSHeapSize   int64   `json:"total_js_heap_size,omitempty"`
+	JSHeapSizeLimit   int64   `json:"js_heap_size_limit,omitempty"`
+	UASpecificBytes   *int64  `json:"ua_specific_bytes,omitempty"`
+	DOMNodes          int     `json:"dom_nodes"`
+	EventListeners    int     `json:"event_listeners"`
+	DetachedListeners int     `json:"detached_listeners"`
+}
+
+// WireInteractionTiming is the slowest event timing entry of one user interaction.
+type WireInteractionTiming struct {
+	InteractionID     int64   `json:"interaction_id"`
+	Name              string  `json:"name"`
+	Target            string  `json:"target"`
+	StartTime         float64 `json:"start_time"`
+	Duration          float64 `json:"duration"`
+	InputDelay        float64 `json:"input_delay"`
+	ProcessingTime    float64 `json:"processing_time"`
+	PresentationDelay float64 `json:"presentation_delay"`
+}
+
 // WirePerformanceSnapshot is the canonical wire format for performance data.
 type WirePerformanceSnapshot struct {
 	URL        string              `json:"url"`
@@ -70,4 +94,6 @@ type WirePerformanceSnapshot struct {
 	LongTasks  WireLongTaskMetrics `json:"long_tasks"`
 	CLS        *float64            `json:"cumulative_layout_shift,omitempty"`
 	UserTiming *WireUserTimingData `json:"user_timing,omitempty"`
+	Memory     *WireMemorySample   `json:"memory,omitempty"`
+	Interactions []WireInteractionTiming `json:"interactions,omitempty"`
 }
@@ -108,6 +108,26 @@ func TestNewQueryDispatcher_CompleteCommand_WithError(t *testing.T) {
 	}
 }
 
+func TestNewQueryDispatcher_ReplaceCommandResult(t *testing.T) {
+	t.Parallel()
+
+	qd := NewQueryDispatcher()
+	defer qd.Close()
+
+	qd.RegisterCommand("corr-secret", "q-secret", 30*time.Second)
+	qd.ReplaceCommandResult("corr-secret", json.RawMessage(`{"scrubbed":true}`))
+	if cmd, _ := qd.GetCommandResult("corr-secret"); cmd.Status != "pending" || len(cmd.Result) != 0 {
+		t.Fatalf("pending command should be left alone, got %+v", cmd)
+	}
+
+	qd.CompleteCommand("corr-secret", json.RawMessage(`{"cookies":[{"value":"s3cret"}]}`), "")
+	qd.ReplaceCommandResult("corr-secret", json.RawMessage(`{"scrubbed":true}`))
+	cmd, _ := qd.GetCommandResult("corr-secret")
+	if cmd.Status != "complete" || string(cmd.Result) != `{"scrubbed":true}` {
+		t.Fatalf("Result = %s, want the replacement", string(cmd.Result))
+	}
+}
+
 func TestNewQueryDispatcher_ApplyCommandResult_ErrorStatus(t *testing.T) {
 	t.Parallel()
 
@@ -346,9 +366,9 @@ func TestNewQueryDispatcher_ExpireCommandResultAfterDispatchTimeout(t *testing.T
 	}
 
 	qd.AcknowledgePendingQuery(queryID)
-	time.Sleep(50 * time.Millisecond)
 
-	cmd, found := qd.GetCommandResult("corr-dispatched-timeout")
+	// Nothing answers, so the wait outlasts the 30ms dispatch deadline and its final read expires the command.
+	cmd, found := qd.WaitForCommand("corr-dispatched-timeout", 50*time.Millisecond)
 	if !found {
 		t.Fatal("expected expired command result to be present")
 	}
@@ -388,19 +408,27 @@ func TestNewQueryDispatcher_WaitForCommand_Async(t *testing.T) {
 	qd := NewQueryDispatcher()
 	defer qd.Close()
 
-	qd.RegisterCommand("corr-async", "q-async", 30*time.Second)
+	qd.CreatePendingQuery(PendingQuery{Type: "dom_action", Params: json.RawMessage(`{}`), CorrelationID: "corr-async"})
 
+	type waitResult struct {
+		cmd   *CommandResult
+		found bool
+	}
+	done := make(chan waitResult, 1)
 	go func() {
-		time.Sleep(20 * time.Millisecond)
-		qd.CompleteCommand("corr-async", json.RawMessage(`{"async":true}`), "")
+		cmd, found := qd.WaitForCommand("corr-async", 2*time.Second)
+		done <- waitResult{cmd, found}
 	}()
 
-	cmd, found := qd.WaitForCommand("corr-async", 2*time.Second)
-	if !found {
+	q := awaitQuery(t, qd, "dom_action")
+	qd.SetQueryResult(q.ID, json.RawMessage(`{"async":true}`))
+
+	got := <-done
+	if !got.found {
 		t.Fatal("WaitForCommand returned false")
 	}
-	if cmd.Status != "complete" {
-		t.Errorf("Status = %q, want complete", cmd.Status)
+	if got.cmd.Status != "complete" {
+		t.Errorf("Status = %q, want complete", got.cmd.Status)
 	}
 }
 
@@ -5,6 +5,7 @@ package queries
 
 import (
 	"encoding/json"
+	"strconv"
 	"sync"
 	"time"
 )
@@ -13,12 +14,15 @@ import (
 //
 // Invariants:
 // - Query.ID is unique within this process and remains stable until acknowledged/expired.
-// - Expires is an absolute deadline; once passed, queue cleanup treats the entry as non-deliverable.
+// - Expires is an absolute deadline; once passed, cleanup re-arms a never-sent entry (RetryPolicy) or drops it.
 // - ClientID is empty for single-client mode, non-empty for multi-client isolation.
+// - SentAt is zero until a poll or push first hands the entry to the extension.
 type PendingQueryEntry struct {
 	Query    PendingQueryResponse
 	Expires  time.Time
 	ClientID string // owning client for multi-client isolation
+	QueuedAt time.Time
+	SentAt   time.Time
 }
 
 // QueryResultEntry stores a one-time consumable extension result.
@@ -35,7 +39,8 @@ type QueryResultEntry struct {
 
 // QueryDispatcher manages pending query queues, result storage, and async command tracking.
 // Owns two locks:
-//   - mu (sync.Mutex): protects pendingQueries, queryResults, queryCond, queryIDCounter, queryTimeout
+//   - mu (sync.Mutex): protects pendingQueries, queryResults, queryCond, queryIDCounter, queryTimeout,
+//     retryPolicy, unreachable
 //   - resultsMu (sync.RWMutex): protects completedResults, failedCommands
 //
 // Lock ordering: mu released BEFORE resultsMu acquired (never reverse).
@@ -43,6 +48,7 @@ type QueryResultEntry struct {
 // Invariants:
 // - pendingQueries is FIFO and bounded by MaxPendingQueries.
 // - commandNotify is always non-nil; writers close-and-rotate it under resultsMu to signal waiters.
+// - queryAdded is always non-nil; enqueue closes-and-rotates it under mu so every waiter wakes.
 // - failedCommands is an append-only ring (max 100) for terminal failure history.
 //
 // Failure semantics:
@@ -56,6 +62,10 @@ type QueryDispatcher struct {
 	queryCond      *sync.Cond
 	queryIDCounter int
 	queryTimeout   time.Duration
+	queryAdded     chan struct{} // closed when a pending query is added, then recreated (broadcast)
+	retryPolicy    RetryPolicy
+	unreachable    map[string]*ExtensionUnreachableError // by query ID; pruned after QueryResultTTL
+	instanceID     string                                // scopes default idempotency keys to this process
 
 	resultsMu        sync.RWMutex
 	completedResults map[string]*CommandResult
@@ -80,6 +90,10 @@ func NewQueryDispatcher() *QueryDispatcher {
 		failedCommands:   make([]*CommandResult, 0, 100),
 		commandNotify:    make(chan struct{}),
 		queryNotify:      make(chan struct{}, 1),
+		queryAdded:       make(chan struct{}),
+		retryPolicy:      DefaultRetryPolicy(),
+		unreachable:      make(map[string]*ExtensionUnreachableError),
+		instanceID:       strconv.FormatInt(time.Now().UnixNano(), 36),
 	}
 	qd.queryCond = sync.NewCond(&qd.mu)
 	qd.stopCleanup = qd.startResultCleanup()
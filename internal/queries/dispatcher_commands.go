@@ -172,6 +172,25 @@ func (qd *QueryDispatcher) ExpireCommand(correlationID string) {
 	qd.ApplyCommandResult(correlationID, "expired", nil, "Command expired before extension could execute it")
 }
 
+// ReplaceCommandResult swaps the stored result of a finished command, so a result that
+// carried secrets (auth state captured for the encrypted vault) is not readable afterwards
+// through command status lookups.
+//
+// Failure semantics:
+// - No-op for unknown or still-pending commands.
+func (qd *QueryDispatcher) ReplaceCommandResult(correlationID string, result json.RawMessage) {
+	qd.resultsMu.Lock()
+	defer qd.resultsMu.Unlock()
+	if cmd, exists := qd.completedResults[correlationID]; exists && cmd.Status != "pending" {
+		cmd.Result = result
+	}
+	for _, cmd := range qd.failedCommands {
+		if cmd.CorrelationID == correlationID {
+			cmd.Result = result
+		}
+	}
+}
+
 // expireCommandWithReason is ExpireCommand with an explicit diagnostic reason.
 //
 // Failure semantics:
@@ -77,28 +77,20 @@ func (qd *QueryDispatcher) GetCommandResult(correlationID string) (*CommandResul
 //
 // Invariants:
 // - Must run lock-free at entry; acquires mu/resultsMu internally in ordered phases.
+// - Expired queue entries are swept first, so commands still under retry get their deadline extended.
 //
 // Failure semantics:
 // - Safe under races with extension completion: ApplyCommandResult's pending guard prevents double-terminal transitions.
 func (qd *QueryDispatcher) cleanExpiredCommands() {
 	now := time.Now()
-	expiredSet := make(map[string]struct{})
-	expiredCorrelationIDs := func() []string {
+	sweep := func() deliverySweep {
 		qd.mu.Lock()
 		defer qd.mu.Unlock()
-
-		var ids []string
-		for _, pending := range qd.pendingQueries {
-			if !pending.Expires.After(now) && pending.Query.CorrelationID != "" {
-				if _, seen := expiredSet[pending.Query.CorrelationID]; !seen {
-					expiredSet[pending.Query.CorrelationID] = struct{}{}
-					ids = append(ids, pending.Query.CorrelationID)
-				}
-			}
-		}
-		return ids
+		return qd.sweepExpiredQueriesLocked(now)
 	}()
+	qd.finishDeliverySweep(sweep)
 
+	var expiredCorrelationIDs []string
 	qd.resultsMu.RLock()
 	for correlationID, cmd := range qd.completedResults {
 		if cmd.Status != "pending" {
@@ -109,10 +101,7 @@ func (qd *QueryDispatcher) cleanExpiredCommands() {
 			expiresAt = cmd.CreatedAt.Add(AsyncCommandTimeout)
 		}
 		if !expiresAt.After(now) {
-			if _, seen := expiredSet[correlationID]; !seen {
-				expiredSet[correlationID] = struct{}{}
-				expiredCorrelationIDs = append(expiredCorrelationIDs, correlationID)
-			}
+			expiredCorrelationIDs = append(expiredCorrelationIDs, correlationID)
 		}
 	}
 	qd.resultsMu.RUnlock()
@@ -56,21 +56,31 @@ func (qd *QueryDispatcher) CreatePendingQueryWithTimeout(query PendingQuery, tim
 
 		qd.queryIDCounter++
 		id := fmt.Sprintf("q-%d", qd.queryIDCounter)
+		idempotencyKey := query.IdempotencyKey
+		if idempotencyKey == "" {
+			idempotencyKey = qd.instanceID + "-" + id
+		}
 
+		now := time.Now()
 		entry := PendingQueryEntry{
 			Query: PendingQueryResponse{
-				ID:            id,
-				Type:          query.Type,
-				Params:        query.Params,
-				TabID:         query.TabID,
-				CorrelationID: query.CorrelationID,
-				TraceID:       deriveTraceID(query.TraceID, query.CorrelationID, id),
+				ID:             id,
+				Type:           query.Type,
+				Params:         query.Params,
+				TabID:          query.TabID,
+				CorrelationID:  query.CorrelationID,
+				TraceID:        deriveTraceID(query.TraceID, query.CorrelationID, id),
+				IdempotencyKey: idempotencyKey,
+				Attempt:        1,
 			},
-			Expires:  time.Now().Add(timeout),
+			Expires:  now.Add(timeout),
 			ClientID: clientID,
+			QueuedAt: now,
 		}
 
 		qd.pendingQueries = append(qd.pendingQueries, entry)
+		close(qd.queryAdded)
+		qd.queryAdded = make(chan struct{})
 		return pendingQueryPlan{
 			id:            id,
 			correlationID: query.CorrelationID,
@@ -100,6 +110,15 @@ func (qd *QueryDispatcher) CreatePendingQueryWithTimeout(query PendingQuery, tim
 	return plan.id, nil
 }
 
+// PendingQueryAdded returns a channel that is closed the next time a query is enqueued.
+// Unlike WaitForPendingQueries, every caller holding the channel is woken, so push
+// transports can wait alongside /sync long-polls. Call again after each wake-up.
+func (qd *QueryDispatcher) PendingQueryAdded() <-chan struct{} {
+	qd.mu.Lock()
+	defer qd.mu.Unlock()
+	return qd.queryAdded
+}
+
 // WaitForPendingQueries blocks until queue is non-empty or timeout elapses.
 func (qd *QueryDispatcher) WaitForPendingQueries(timeout time.Duration) {
 	if func() bool {
@@ -5,19 +5,6 @@ package queries
 
 import "time"
 
-// cleanExpiredQueries removes past-deadline queue entries in-place.
-func (qd *QueryDispatcher) cleanExpiredQueries() {
-	now := time.Now()
-	remaining := qd.pendingQueries[:0]
-
-	for _, pending := range qd.pendingQueries {
-		if pending.Expires.After(now) {
-			remaining = append(remaining, pending)
-		}
-	}
-	qd.pendingQueries = remaining
-}
-
 // ============================================
 // Query Retrieval (Extension Polling)
 // ============================================
@@ -25,20 +12,27 @@ func (qd *QueryDispatcher) cleanExpiredQueries() {
 type pendingQuerySnapshot struct {
 	result             []PendingQueryResponse
 	sentCorrelationIDs []string
+	sweep              deliverySweep
 }
 
+// snapshotPendingQueries sweeps expired entries, then returns and marks as sent everything deliverable.
 func (qd *QueryDispatcher) snapshotPendingQueries(clientID string) pendingQuerySnapshot {
 	qd.mu.Lock()
 	defer qd.mu.Unlock()
 
-	qd.cleanExpiredQueries()
+	now := time.Now()
+	sweep := qd.sweepExpiredQueriesLocked(now)
 
 	result := make([]PendingQueryResponse, 0, len(qd.pendingQueries))
 	sentCorrelationIDs := make([]string, 0, len(qd.pendingQueries))
-	for _, pending := range qd.pendingQueries {
+	for i := range qd.pendingQueries {
+		pending := &qd.pendingQueries[i]
 		if clientID != "" && pending.ClientID != clientID {
 			continue
 		}
+		if pending.SentAt.IsZero() {
+			pending.SentAt = now
+		}
 		result = append(result, pending.Query)
 		if pending.Query.CorrelationID != "" {
 			sentCorrelationIDs = append(sentCorrelationIDs, pending.Query.CorrelationID)
@@ -47,12 +41,14 @@ func (qd *QueryDispatcher) snapshotPendingQueries(clientID string) pendingQueryS
 	return pendingQuerySnapshot{
 		result:             result,
 		sentCorrelationIDs: sentCorrelationIDs,
+		sweep:              sweep,
 	}
 }
 
 // GetPendingQueries snapshots all currently deliverable queued commands.
 func (qd *QueryDispatcher) GetPendingQueries() []PendingQueryResponse {
 	snapshot := qd.snapshotPendingQueries("")
+	qd.finishDeliverySweep(snapshot.sweep)
 
 	for _, correlationID := range snapshot.sentCorrelationIDs {
 		qd.recordTraceEvent(correlationID, traceStageSent, "sync", "pending", "", time.Now())
@@ -63,6 +59,7 @@ func (qd *QueryDispatcher) GetPendingQueries() []PendingQueryResponse {
 // GetPendingQueriesForClient snapshots queued commands scoped to one client.
 func (qd *QueryDispatcher) GetPendingQueriesForClient(clientID string) []PendingQueryResponse {
 	snapshot := qd.snapshotPendingQueries(clientID)
+	qd.finishDeliverySweep(snapshot.sweep)
 
 	for _, correlationID := range snapshot.sentCorrelationIDs {
 		qd.recordTraceEvent(correlationID, traceStageSent, "sync", "pending", "", time.Now())
@@ -70,6 +67,16 @@ func (qd *QueryDispatcher) GetPendingQueriesForClient(clientID string) []Pending
 	return snapshot.result
 }
 
+// pendingQueryLocked finds a queued entry by query ID. Caller holds mu.
+func (qd *QueryDispatcher) pendingQueryLocked(id string) (PendingQueryEntry, bool) {
+	for _, pending := range qd.pendingQueries {
+		if pending.Query.ID == id {
+			return pending, true
+		}
+	}
+	return PendingQueryEntry{}, false
+}
+
 // AcknowledgePendingQuery advances queue head through queryID (inclusive).
 func (qd *QueryDispatcher) AcknowledgePendingQuery(queryID string) {
 	if queryID == "" {
@@ -52,6 +52,11 @@ func (qd *QueryDispatcher) cleanExpiredResults() {
 				delete(qd.queryResults, id)
 			}
 		}
+		for id, record := range qd.unreachable {
+			if now.Sub(record.GaveUpAt) > QueryResultTTL {
+				delete(qd.unreachable, id)
+			}
+		}
 
 		var orphaned []string
 		gracePeriod := AsyncCommandTimeout + 10*time.Second
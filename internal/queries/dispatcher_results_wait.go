@@ -23,7 +23,8 @@ func (qd *QueryDispatcher) WaitForResult(id string, timeout time.Duration) (json
 // 1. Check if result already exists
 // 2. If not, wait on condition variable
 // 3. Recheck periodically (10ms intervals)
-// 4. Return result or timeout error
+// 4. At the deadline, sweep the queue: a never-sent query under retry extends the deadline
+// 5. Return result, *ExtensionUnreachableError, or timeout error
 //
 // Invariants:
 // - done channel is always closed before Unlock (defer LIFO) to stop ticker goroutine.
@@ -31,6 +32,7 @@ func (qd *QueryDispatcher) WaitForResult(id string, timeout time.Duration) (json
 //
 // Failure semantics:
 // - Timeout returns deterministic error; caller decides retry/abort policy.
+// - A query the extension never picked up in any delivery window returns *ExtensionUnreachableError.
 // - Missing result after wakeups is expected (spurious or unrelated broadcasts).
 func (qd *QueryDispatcher) WaitForResultWithClient(id string, timeout time.Duration, clientID string) (json.RawMessage, error) {
 	deadline := time.Now().Add(timeout)
@@ -66,8 +68,21 @@ func (qd *QueryDispatcher) WaitForResultWithClient(id string, timeout time.Durat
 		}
 
 		// Check timeout
-		if time.Now().After(deadline) {
-			return nil, fmt.Errorf("timeout waiting for result %s", id)
+		if now := time.Now(); now.After(deadline) {
+			if sweep := qd.sweepExpiredQueriesLocked(now); !sweep.empty() {
+				qd.mu.Unlock()
+				qd.finishDeliverySweep(sweep)
+				qd.mu.Lock() // lint:manual-unlock — relock after finishing the sweep unlocked; the deferred Unlock still releases it
+				continue
+			}
+			if pending, ok := qd.pendingQueryLocked(id); ok && pending.SentAt.IsZero() && pending.Query.Attempt > 1 {
+				deadline = pending.Expires
+			} else if record, ok := qd.unreachable[id]; ok {
+				out := *record
+				return nil, &out
+			} else {
+				return nil, fmt.Errorf("timeout waiting for result %s", id)
+			}
 		}
 
 		qd.queryCond.Wait()
@@ -188,6 +188,35 @@ func TestNewQueryDispatcher_CreatePendingQueryWithTimeout(t *testing.T) {
 	}
 }
 
+func TestNewQueryDispatcher_PendingQueryAdded_WakesAllWaiters(t *testing.T) {
+	t.Parallel()
+
+	qd := NewQueryDispatcher()
+	defer qd.Close()
+
+	first, second := qd.PendingQueryAdded(), qd.PendingQueryAdded()
+	select {
+	case <-first:
+		t.Fatal("channel closed before any query was added")
+	default:
+	}
+
+	qd.CreatePendingQuery(PendingQuery{Type: "dom", Params: json.RawMessage(`{}`)})
+	for i, ch := range []<-chan struct{}{first, second} {
+		select {
+		case <-ch:
+		case <-time.After(time.Second):
+			t.Fatalf("waiter %d not woken", i)
+		}
+	}
+
+	select {
+	case <-qd.PendingQueryAdded():
+		t.Fatal("rotated channel should stay open until the next query")
+	default:
+	}
+}
+
 func TestNewQueryDispatcher_CreatePendingQuery_WithCorrelationID(t *testing.T) {
 	t.Parallel()
 
@@ -456,17 +485,46 @@ func TestNewQueryDispatcher_WaitForResult_Async(t *testing.T) {
 
 	id, _ := qd.CreatePendingQuery(PendingQuery{Type: "dom", Params: json.RawMessage(`{}`)})
 
+	type waitResult struct {
+		data json.RawMessage
+		err  error
+	}
+	done := make(chan waitResult, 1)
 	go func() {
-		time.Sleep(20 * time.Millisecond)
-		qd.SetQueryResult(id, json.RawMessage(`{"async":true}`))
+		data, err := qd.WaitForResult(id, 2*time.Second)
+		done <- waitResult{data, err}
 	}()
 
-	result, err := qd.WaitForResult(id, 2*time.Second)
-	if err != nil {
-		t.Fatalf("WaitForResult error = %v", err)
+	q := awaitQuery(t, qd, "dom")
+	qd.SetQueryResult(q.ID, json.RawMessage(`{"async":true}`))
+
+	got := <-done
+	if got.err != nil {
+		t.Fatalf("WaitForResult error = %v", got.err)
 	}
-	if string(result) != `{"async":true}` {
-		t.Errorf("result = %s, want {\"async\":true}", string(result))
+	if string(got.data) != `{"async":true}` {
+		t.Errorf("result = %s, want {\"async\":true}", string(got.data))
+	}
+}
+
+// awaitQuery waits for a query of queryType to be queued and returns it as the
+// extension's poll would see it.
+func awaitQuery(t *testing.T, qd *QueryDispatcher, queryType string) PendingQueryResponse {
+	t.Helper()
+	deadline := time.After(2 * time.Second)
+	for {
+		// Take the signal before reading the queue so an enqueue in between still wakes us.
+		added := qd.PendingQueryAdded()
+		for _, q := range qd.GetPendingQueries() {
+			if q.Type == queryType {
+				return q
+			}
+		}
+		select {
+		case <-added:
+		case <-deadline:
+			t.Fatalf("no %s query was queued", queryType)
+		}
 	}
 }
 
@@ -11,6 +11,7 @@ import (
 
 const (
 	traceStageQueued   = "queued"
+	traceStageRetried  = "retried"
 	traceStageSent     = "sent"
 	traceStageStarted  = "started"
 	traceStageResolved = "resolved"
@@ -0,0 +1,285 @@
+// Purpose: Retry policy for commands the extension has not picked up, and the extension_unreachable error raised when it gives up.
+// Why: A single missed poll used to fail interact/analyze calls with a generic timeout; re-offering the command covers MV3 worker restarts and dropped long-polls.
+// Docs: docs/features/feature/extension-retry/index.md
+
+package queries
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Retry policy bounds accepted by RetryPolicy.Validate.
+const (
+	MaxRetryAttempts = 10
+	MaxRetryBackoff  = time.Minute
+)
+
+// RetryPolicy governs how long a command nobody has polled stays on offer to the extension.
+//
+// Invariants:
+// - Attempts counts delivery windows including the first; 1 disables retries.
+// - Retry n (1-based) adds a window of Backoff * 2^(n-1), capped at MaxBackoff, then jittered by ±Jitter.
+// - Only never-delivered commands are retried; a command the extension has received keeps its own timeout.
+type RetryPolicy struct {
+	Attempts   int           `json:"attempts"`
+	Backoff    time.Duration `json:"backoff"`
+	MaxBackoff time.Duration `json:"max_backoff"`
+	Jitter     float64       `json:"jitter"`
+}
+
+// DefaultRetryPolicy covers one or two missed polls (the extension polls every 1-2s).
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{Attempts: 3, Backoff: time.Second, MaxBackoff: 4 * time.Second, Jitter: 0.2}
+}
+
+// Validate reports the first out-of-range field.
+func (p RetryPolicy) Validate() error {
+	switch {
+	case p.Attempts < 1 || p.Attempts > MaxRetryAttempts:
+		return fmt.Errorf("attempts must be between 1 and %d", MaxRetryAttempts)
+	case p.Backoff < 0 || p.Backoff > MaxRetryBackoff:
+		return fmt.Errorf("backoff must be between 0 and %s", MaxRetryBackoff)
+	case p.MaxBackoff < 0 || p.MaxBackoff > MaxRetryBackoff:
+		return fmt.Errorf("max_backoff must be between 0 and %s", MaxRetryBackoff)
+	case p.Jitter < 0 || p.Jitter > 1:
+		return fmt.Errorf("jitter must be between 0 and 1")
+	}
+	return nil
+}
+
+// Delay returns the extra delivery window granted by retry n (1-based).
+func (p RetryPolicy) Delay(retry int) time.Duration {
+	d := p.Backoff
+	for i := 1; i < retry && (p.MaxBackoff <= 0 || d < p.MaxBackoff); i++ {
+		d *= 2
+	}
+	if p.MaxBackoff > 0 && d > p.MaxBackoff {
+		d = p.MaxBackoff
+	}
+	if p.Jitter > 0 && d > 0 {
+		d += time.Duration((rand.Float64()*2 - 1) * p.Jitter * float64(d))
+	}
+	return d
+}
+
+// ParseRetryPolicy overlays a comma-separated key=value spec onto base, e.g.
+// "attempts=5,backoff=500ms,max_backoff=8s,jitter=0.1". "off" disables retries.
+func ParseRetryPolicy(spec string, base RetryPolicy) (RetryPolicy, error) {
+	spec = strings.TrimSpace(spec)
+	if spec == "off" {
+		base.Attempts = 1
+		return base, nil
+	}
+	for _, field := range strings.Split(spec, ",") {
+		if field = strings.TrimSpace(field); field == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(field, "=")
+		if !ok {
+			return base, fmt.Errorf("%q: want key=value", field)
+		}
+		var err error
+		switch strings.TrimSpace(key) {
+		case "attempts":
+			base.Attempts, err = strconv.Atoi(strings.TrimSpace(value))
+		case "backoff":
+			base.Backoff, err = time.ParseDuration(strings.TrimSpace(value))
+		case "max_backoff":
+			base.MaxBackoff, err = time.ParseDuration(strings.TrimSpace(value))
+		case "jitter":
+			base.Jitter, err = strconv.ParseFloat(strings.TrimSpace(value), 64)
+		default:
+			return base, fmt.Errorf("unknown key %q (use attempts, backoff, max_backoff, jitter)", key)
+		}
+		if err != nil {
+			return base, fmt.Errorf("%q: %v", field, err)
+		}
+	}
+	return base, base.Validate()
+}
+
+// SetRetryPolicy replaces the policy applied to commands that expire undelivered.
+func (qd *QueryDispatcher) SetRetryPolicy(policy RetryPolicy) error {
+	if err := policy.Validate(); err != nil {
+		return err
+	}
+	qd.mu.Lock()
+	defer qd.mu.Unlock()
+	qd.retryPolicy = policy
+	return nil
+}
+
+// GetRetryPolicy returns the active retry policy.
+func (qd *QueryDispatcher) GetRetryPolicy() RetryPolicy {
+	qd.mu.Lock()
+	defer qd.mu.Unlock()
+	return qd.retryPolicy
+}
+
+// ============================================
+// Unreachable Error
+// ============================================
+
+// ExtensionUnreachableReason prefixes the command error of async commands that were never picked up.
+const ExtensionUnreachableReason = "extension_unreachable"
+
+// ExtensionUnreachableError reports a command the extension never picked up in any delivery window.
+// The dispatcher cannot see extension polls; Capture fills LastPollAt and Transport.
+type ExtensionUnreachableError struct {
+	QueryID       string
+	CorrelationID string
+	Type          string
+	Attempts      int
+	QueuedAt      time.Time
+	GaveUpAt      time.Time
+	LastPollAt    time.Time // zero when the extension never polled this daemon
+	Transport     string
+}
+
+func (e *ExtensionUnreachableError) Error() string {
+	return fmt.Sprintf("%s: the extension never picked up %s command %s after %d delivery attempts over %s",
+		ExtensionUnreachableReason, e.Type, e.QueryID, e.Attempts, e.GaveUpAt.Sub(e.QueuedAt).Round(time.Millisecond))
+}
+
+// Diagnostics renders the error for structured tool responses.
+func (e *ExtensionUnreachableError) Diagnostics() map[string]any {
+	diag := map[string]any{
+		"query_id":  e.QueryID,
+		"type":      e.Type,
+		"attempts":  e.Attempts,
+		"waited_ms": e.GaveUpAt.Sub(e.QueuedAt).Milliseconds(),
+		"last_poll": "never",
+	}
+	if e.CorrelationID != "" {
+		diag["correlation_id"] = e.CorrelationID
+	}
+	if e.Transport != "" {
+		diag["transport"] = e.Transport
+	}
+	if !e.LastPollAt.IsZero() {
+		diag["last_poll"] = e.LastPollAt.Format(time.RFC3339)
+		diag["since_last_poll_ms"] = e.GaveUpAt.Sub(e.LastPollAt).Milliseconds()
+	}
+	return diag
+}
+
+// ExtensionUnreachable returns the give-up record of a query, if it was never picked up.
+func (qd *QueryDispatcher) ExtensionUnreachable(queryID string) (*ExtensionUnreachableError, bool) {
+	qd.mu.Lock()
+	defer qd.mu.Unlock()
+	record, ok := qd.unreachable[queryID]
+	if !ok {
+		return nil, false
+	}
+	out := *record
+	return &out, true
+}
+
+// ============================================
+// Delivery Sweep
+// ============================================
+
+type deliveryRetry struct {
+	correlationID string
+	attempt       int
+	expires       time.Time
+}
+
+// deliverySweep carries the resultsMu half of a sweep out from under mu.
+type deliverySweep struct {
+	retried   []deliveryRetry
+	exhausted []PendingQueryEntry
+}
+
+func (s deliverySweep) empty() bool {
+	return len(s.retried) == 0 && len(s.exhausted) == 0
+}
+
+// sweepExpiredQueriesLocked re-arms expired, never-delivered entries while the retry policy allows
+// and removes every other expired entry.
+//
+// Invariants:
+// - Caller holds mu and must pass the result to finishDeliverySweep after releasing it.
+// - Re-armed entries keep their ID and idempotency key; only Attempt and Expires change.
+// - A re-arm wakes /sync long-polls and push transports so the command is offered again.
+func (qd *QueryDispatcher) sweepExpiredQueriesLocked(now time.Time) deliverySweep {
+	var sweep deliverySweep
+	remaining := qd.pendingQueries[:0]
+	for _, pending := range qd.pendingQueries {
+		if pending.Expires.After(now) {
+			remaining = append(remaining, pending)
+			continue
+		}
+		if pending.SentAt.IsZero() && pending.Query.Attempt < qd.retryPolicy.Attempts {
+			pending.Query.Attempt++
+			pending.Expires = now.Add(qd.retryPolicy.Delay(pending.Query.Attempt - 1))
+			remaining = append(remaining, pending)
+			sweep.retried = append(sweep.retried, deliveryRetry{
+				correlationID: pending.Query.CorrelationID,
+				attempt:       pending.Query.Attempt,
+				expires:       pending.Expires,
+			})
+			continue
+		}
+		if pending.SentAt.IsZero() {
+			qd.unreachable[pending.Query.ID] = &ExtensionUnreachableError{
+				QueryID:       pending.Query.ID,
+				CorrelationID: pending.Query.CorrelationID,
+				Type:          pending.Query.Type,
+				Attempts:      pending.Query.Attempt,
+				QueuedAt:      pending.QueuedAt,
+				GaveUpAt:      now,
+			}
+		}
+		sweep.exhausted = append(sweep.exhausted, pending)
+	}
+	qd.pendingQueries = remaining
+
+	if len(sweep.retried) > 0 {
+		close(qd.queryAdded)
+		qd.queryAdded = make(chan struct{})
+		select {
+		case qd.queryNotify <- struct{}{}:
+		default:
+		}
+	}
+	return sweep
+}
+
+// finishDeliverySweep extends command deadlines for re-armed entries and expires exhausted ones.
+//
+// Invariants:
+// - Must run without mu held (acquires resultsMu).
+func (qd *QueryDispatcher) finishDeliverySweep(sweep deliverySweep) {
+	if len(sweep.retried) > 0 {
+		func() {
+			qd.resultsMu.Lock()
+			defer qd.resultsMu.Unlock()
+			now := time.Now()
+			for _, retry := range sweep.retried {
+				cmd, ok := qd.completedResults[retry.correlationID]
+				if !ok || cmd.Status != "pending" {
+					continue
+				}
+				if cmd.ExpiresAt.Before(retry.expires) {
+					cmd.ExpiresAt = retry.expires
+				}
+				qd.appendTraceEventLocked(cmd, traceStageRetried, "retry", "pending",
+					fmt.Sprintf("not picked up; delivery attempt %d", retry.attempt), now)
+			}
+		}()
+	}
+	for _, pending := range sweep.exhausted {
+		reason := "Command expired waiting for extension result"
+		if pending.SentAt.IsZero() {
+			if record, ok := qd.ExtensionUnreachable(pending.Query.ID); ok {
+				reason = record.Error()
+			}
+		}
+		qd.expireCommandWithReason(pending.Query.CorrelationID, reason)
+	}
+}
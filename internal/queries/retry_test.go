@@ -0,0 +1,186 @@
+// Purpose: Tests for delivery retries of undelivered commands and the extension_unreachable error.
+// Docs: docs/features/feature/extension-retry/index.md
+
+// retry_test.go — Tests for RetryPolicy backoff/parsing, re-offering missed commands, and give-up diagnostics.
+package queries
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRetryPolicy_DelayBacksOffUpToCap(t *testing.T) {
+	t.Parallel()
+
+	p := RetryPolicy{Attempts: 5, Backoff: time.Second, MaxBackoff: 4 * time.Second}
+	for retry, want := range map[int]time.Duration{1: time.Second, 2: 2 * time.Second, 3: 4 * time.Second, 4: 4 * time.Second} {
+		if got := p.Delay(retry); got != want {
+			t.Errorf("Delay(%d) = %v, want %v", retry, got, want)
+		}
+	}
+
+	p.Jitter = 0.5
+	for i := 0; i < 50; i++ {
+		if got := p.Delay(2); got < time.Second || got > 3*time.Second {
+			t.Fatalf("jittered Delay(2) = %v, want within ±50%% of 2s", got)
+		}
+	}
+}
+
+func TestParseRetryPolicy(t *testing.T) {
+	t.Parallel()
+
+	got, err := ParseRetryPolicy("attempts=5, backoff=500ms,jitter=0", DefaultRetryPolicy())
+	if err != nil {
+		t.Fatalf("ParseRetryPolicy: %v", err)
+	}
+	want := RetryPolicy{Attempts: 5, Backoff: 500 * time.Millisecond, MaxBackoff: 4 * time.Second, Jitter: 0}
+	if got != want {
+		t.Fatalf("policy = %+v, want %+v", got, want)
+	}
+	if off, _ := ParseRetryPolicy("off", DefaultRetryPolicy()); off.Attempts != 1 {
+		t.Fatalf("off = %+v, want a single attempt", off)
+	}
+	for _, spec := range []string{"attempts=0", "attempts=x", "jitter=2", "backoff=-1s", "retries=3", "attempts"} {
+		if _, err := ParseRetryPolicy(spec, DefaultRetryPolicy()); err == nil {
+			t.Errorf("ParseRetryPolicy(%q) accepted an invalid spec", spec)
+		}
+	}
+}
+
+func TestWaitForResult_ReoffersMissedQueryToLatePoller(t *testing.T) {
+	t.Parallel()
+
+	qd := NewQueryDispatcher()
+	defer qd.Close()
+	_ = qd.SetRetryPolicy(RetryPolicy{Attempts: 3, Backoff: 300 * time.Millisecond})
+
+	id, err := qd.CreatePendingQueryWithTimeout(PendingQuery{Type: "dom_action", Params: json.RawMessage(`{}`)}, 40*time.Millisecond, "")
+	if err != nil {
+		t.Fatalf("CreatePendingQueryWithTimeout: %v", err)
+	}
+
+	// Taken after the first enqueue, so the next wake is the re-offer once the first window is missed.
+	reoffered := qd.PendingQueryAdded()
+	delivered := make(chan PendingQueryResponse, 1)
+	go func() {
+		select {
+		case <-reoffered:
+		case <-time.After(2 * time.Second):
+		}
+		for _, q := range qd.GetPendingQueries() {
+			if q.ID == id {
+				delivered <- q
+				qd.SetQueryResult(q.ID, json.RawMessage(`{"ok":true}`))
+				return
+			}
+		}
+		close(delivered)
+	}()
+
+	result, err := qd.WaitForResult(id, 40*time.Millisecond)
+	if err != nil {
+		t.Fatalf("WaitForResult: %v", err)
+	}
+	if string(result) != `{"ok":true}` {
+		t.Fatalf("result = %s", result)
+	}
+	q, ok := <-delivered
+	if !ok {
+		t.Fatal("late poll did not see the re-offered query")
+	}
+	if q.Attempt != 2 || q.IdempotencyKey == "" {
+		t.Fatalf("re-offered query = %+v, want attempt 2 with an idempotency key", q)
+	}
+}
+
+func TestWaitForResult_ReportsUnreachableAfterEveryAttempt(t *testing.T) {
+	t.Parallel()
+
+	qd := NewQueryDispatcher()
+	defer qd.Close()
+	_ = qd.SetRetryPolicy(RetryPolicy{Attempts: 3, Backoff: 20 * time.Millisecond, MaxBackoff: 30 * time.Millisecond})
+
+	id, _ := qd.CreatePendingQueryWithTimeout(PendingQuery{Type: "screenshot", Params: json.RawMessage(`{}`)}, 30*time.Millisecond, "")
+	start := time.Now()
+	_, err := qd.WaitForResult(id, 30*time.Millisecond)
+
+	var unreachable *ExtensionUnreachableError
+	if !errors.As(err, &unreachable) {
+		t.Fatalf("err = %v, want *ExtensionUnreachableError", err)
+	}
+	if unreachable.QueryID != id || unreachable.Type != "screenshot" || unreachable.Attempts != 3 {
+		t.Fatalf("unreachable = %+v", unreachable)
+	}
+	if elapsed := time.Since(start); elapsed < 60*time.Millisecond {
+		t.Fatalf("gave up after %v, before the retry windows elapsed", elapsed)
+	}
+	if diag := unreachable.Diagnostics(); diag["last_poll"] != "never" || diag["attempts"] != 3 {
+		t.Fatalf("diagnostics = %v", diag)
+	}
+	if len(qd.GetPendingQueries()) != 0 {
+		t.Fatal("exhausted query should leave the queue")
+	}
+}
+
+func TestWaitForResult_DeliveredQueryIsNotRetried(t *testing.T) {
+	t.Parallel()
+
+	qd := NewQueryDispatcher()
+	defer qd.Close()
+	_ = qd.SetRetryPolicy(RetryPolicy{Attempts: 3, Backoff: time.Second})
+
+	id, _ := qd.CreatePendingQueryWithTimeout(PendingQuery{Type: "dom_action", Params: json.RawMessage(`{}`)}, 30*time.Millisecond, "")
+	if len(qd.GetPendingQueries()) != 1 {
+		t.Fatal("query should be delivered on the first poll")
+	}
+
+	start := time.Now()
+	_, err := qd.WaitForResult(id, 30*time.Millisecond)
+	var unreachable *ExtensionUnreachableError
+	if err == nil || errors.As(err, &unreachable) {
+		t.Fatalf("err = %v, want a plain timeout for a delivered query", err)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Fatalf("delivered query waited %v; retries must not extend it", elapsed)
+	}
+}
+
+func TestGetCommandResult_UnreachableCommandExpiresWithReason(t *testing.T) {
+	t.Parallel()
+
+	qd := NewQueryDispatcher()
+	defer qd.Close()
+	_ = qd.SetRetryPolicy(RetryPolicy{Attempts: 2, Backoff: 20 * time.Millisecond})
+
+	queryID, _ := qd.CreatePendingQueryWithTimeout(PendingQuery{
+		Type:          "dom_action",
+		Params:        json.RawMessage(`{"action":"click"}`),
+		CorrelationID: "corr-unreachable",
+	}, 30*time.Millisecond, "")
+
+	var cmd *CommandResult
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		cmd, _ = qd.GetCommandResult("corr-unreachable")
+		if cmd != nil && cmd.Status != "pending" {
+			break
+		}
+		time.Sleep(5 * time.Millisecond) // expiry is swept lazily on read; polling drives it
+	}
+	if cmd == nil || cmd.Status != "expired" {
+		t.Fatalf("command = %+v, want expired", cmd)
+	}
+	if !strings.HasPrefix(cmd.Error, ExtensionUnreachableReason) {
+		t.Fatalf("error = %q, want the extension_unreachable reason", cmd.Error)
+	}
+	if !strings.Contains(cmd.TraceTimeline, traceStageRetried) {
+		t.Fatalf("trace = %q, want a retried stage", cmd.TraceTimeline)
+	}
+	if record, ok := qd.ExtensionUnreachable(queryID); !ok || record.CorrelationID != "corr-unreachable" || record.Attempts != 2 {
+		t.Fatalf("unreachable record = %+v, %v", record, ok)
+	}
+}
@@ -23,6 +23,9 @@ type PendingQuery struct {
 	TabID         int             `json:"tab_id,omitempty"`         // Target tab ID (0 = active tab)
 	CorrelationID string          `json:"correlation_id,omitempty"` // LLM-facing tracking ID for async commands
 	TraceID       string          `json:"trace_id,omitempty"`       // End-to-end trace ID for async command lifecycle
+	// IdempotencyKey lets the extension refuse a second execution of the same command
+	// (default: unique per enqueue, scoped to this daemon process).
+	IdempotencyKey string `json:"idempotency_key,omitempty"`
 }
 
 // PendingQueryResponse is the transport envelope delivered to the extension.
@@ -30,24 +33,27 @@ type PendingQuery struct {
 // Invariants:
 // - ID is daemon-generated and unique for this process lifetime.
 // - TraceID should remain stable across queue, extension, and observe surfaces.
+// - IdempotencyKey is stable across retries; Attempt counts delivery windows (1 = first offer).
 type PendingQueryResponse struct {
-	ID            string          `json:"id"`
-	Type          string          `json:"type"`
-	Params        json.RawMessage `json:"params"`
-	TabID         int             `json:"tab_id,omitempty"`         // Target tab ID (0 = active tab)
-	CorrelationID string          `json:"correlation_id,omitempty"` // LLM-facing tracking ID for async commands
-	TraceID       string          `json:"trace_id,omitempty"`       // End-to-end trace ID for async command lifecycle
+	ID             string          `json:"id"`
+	Type           string          `json:"type"`
+	Params         json.RawMessage `json:"params"`
+	TabID          int             `json:"tab_id,omitempty"`          // Target tab ID (0 = active tab)
+	CorrelationID  string          `json:"correlation_id,omitempty"`  // LLM-facing tracking ID for async commands
+	TraceID        string          `json:"trace_id,omitempty"`        // End-to-end trace ID for async command lifecycle
+	IdempotencyKey string          `json:"idempotency_key,omitempty"` // Extension-side dedup key, stable across retries
+	Attempt        int             `json:"attempt,omitempty"`         // Delivery window, incremented by each retry
 }
 
 // CommandTraceEvent records one lifecycle transition for async command diagnostics.
 //
 // Invariants:
-// - Stage values are canonical (queued, sent, started, resolved, timed_out, errored).
+// - Stage values are canonical (queued, retried, sent, started, resolved, timed_out, errored).
 // - At timestamps are append-ordered per command, producing a stable TraceTimeline.
 type CommandTraceEvent struct {
-	Stage   string    `json:"stage"` // queued, sent, started, resolved, timed_out, errored
+	Stage   string    `json:"stage"` // queued, retried, sent, started, resolved, timed_out, errored
 	At      time.Time `json:"at"`
-	Source  string    `json:"source,omitempty"` // queue, sync, extension, timeout
+	Source  string    `json:"source,omitempty"` // queue, retry, sync, extension, timeout
 	Status  string    `json:"status,omitempty"`
 	Message string    `json:"message,omitempty"`
 }
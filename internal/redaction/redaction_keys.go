@@ -72,3 +72,14 @@ func isSensitiveKeyName(key string) bool {
 	}
 	return false
 }
+
+// KeyRedactionMarker returns the placeholder that replaces a value stored under
+// a sensitive key name, and whether key is sensitive at all. Callers that carry
+// field names beside values (form fields, header lists) use it to apply the
+// same key rule RedactMapValues applies to map keys.
+func KeyRedactionMarker(key string) (string, bool) {
+	if !isSensitiveKeyName(key) {
+		return "", false
+	}
+	return "[REDACTED:key-" + normalizeSensitiveKeyName(key) + "]", true
+}
@@ -17,7 +17,7 @@ func (e *RedactionEngine) RedactMapValues(data map[string]any) map[string]any {
 
 func (e *RedactionEngine) redactValue(key string, value any) any {
 	// Check sensitive key name first
-	if isSensitiveKeyName(key) {
+	if marker, ok := KeyRedactionMarker(key); ok {
 		switch v := value.(type) {
 		case map[string]any:
 			// Keep container shape stable for structural keys (e.g., session_storage),
@@ -30,7 +30,7 @@ func (e *RedactionEngine) redactValue(key string, value any) any {
 			}
 			return out
 		default:
-			return "[REDACTED:key-" + normalizeSensitiveKeyName(key) + "]"
+			return marker
 		}
 	}
 
@@ -116,6 +116,16 @@ func TestRedactMapValues_RedactsSensitiveKeyNamePatterns(t *testing.T) {
 	}
 }
 
+func TestKeyRedactionMarker(t *testing.T) {
+	t.Parallel()
+	if marker, ok := KeyRedactionMarker("Card-CVV"); !ok || marker != "[REDACTED:key-cardcvv]" {
+		t.Errorf("KeyRedactionMarker(Card-CVV) = %q, %v", marker, ok)
+	}
+	if marker, ok := KeyRedactionMarker("email"); ok || marker != "" {
+		t.Errorf("KeyRedactionMarker(email) = %q, %v, want not sensitive", marker, ok)
+	}
+}
+
 func TestRedactMapValues_RedactsPatternMatches(t *testing.T) {
 	t.Parallel()
 	engine := NewRedactionEngine("")
@@ -0,0 +1,116 @@
+// Purpose: Remediation playbooks for axe-core accessibility violations, keyed "a11y.<rule id>".
+// Why: Axe names the rule that failed; the playbook adds the fix pattern and the re-audit call that proves it.
+// Docs: docs/features/feature/remediation-playbooks/index.md
+
+package remediation
+
+// A11yFindingID returns the finding ID for an axe rule id (e.g. "color-contrast" -> "a11y.color-contrast").
+func A11yFindingID(rule string) string {
+	if rule == "" {
+		return CategoryAccessibility + "." + genericSuffix
+	}
+	return CategoryAccessibility + "." + rule
+}
+
+func reaudit(rule string) Verification {
+	return analyzeCall("No violation with id "+rule+" for the affected selectors",
+		map[string]any{"what": "accessibility", "force_refresh": true})
+}
+
+func axeReference(rule string) []string {
+	return []string{"https://dequeuniversity.com/rules/axe/4.10/" + rule}
+}
+
+func a11yPlaybook(rule, title, why string, steps []string, snippets ...Snippet) Playbook {
+	return Playbook{
+		FindingID: A11yFindingID(rule), Category: CategoryAccessibility,
+		Title: title, Why: why, Steps: steps, Snippets: snippets,
+		Verify: reaudit(rule), References: axeReference(rule),
+	}
+}
+
+func accessibilityPlaybooks() []Playbook {
+	return []Playbook{
+		a11yPlaybook("color-contrast", "Raise text contrast to WCAG AA",
+			"Low-contrast text is unreadable for people with low vision and in bright light.",
+			[]string{
+				"Take each node's selector and its contrast_fix: the nearest AA and AAA text and background colours that keep the original hue.",
+				"Apply the foreground suggestion, or the background one when no text colour qualifies; contrast_fixes.css has them as custom property overrides. The ratio must be at least 4.5:1 (3:1 for text 24px+ or 18.66px+ bold).",
+				"Prefer changing the design token so every use of the colour is fixed at once.",
+			},
+			Snippet{Language: "css", Description: "Adjust the token", Code: ":root {\n  --text-muted: #595959; /* was #999999, 2.8:1 on white */\n}"}),
+		a11yPlaybook("image-alt", "Give images a text alternative",
+			"Screen readers announce images without alt text as a file name or skip them entirely.",
+			[]string{
+				"For informative images, describe what the image conveys in alt.",
+				"For decorative images, use alt=\"\" so assistive tech skips them.",
+				"For images inside links or buttons, describe the action, not the picture.",
+			},
+			Snippet{Language: "html", Description: "Informative and decorative images", Code: "<img src=\"chart.png\" alt=\"Revenue grew 20% in Q3\">\n<img src=\"divider.svg\" alt=\"\">"}),
+		a11yPlaybook("label", "Associate every form control with a label",
+			"Unlabelled inputs are announced only as \"edit text\", so users cannot tell what to enter.",
+			[]string{
+				"Add a <label for> pointing at the input's id, or wrap the input in the label.",
+				"If a visible label is impossible, use aria-label or aria-labelledby.",
+				"Do not rely on placeholder text as the label.",
+			},
+			Snippet{Language: "html", Description: "Explicit label", Code: "<label for=\"email\">Email</label>\n<input id=\"email\" type=\"email\" autocomplete=\"email\">"}),
+		a11yPlaybook("button-name", "Give buttons an accessible name",
+			"Icon-only buttons without a name are announced as just \"button\".",
+			[]string{
+				"Add visible text to the button, or aria-label for icon-only buttons.",
+				"Mark the icon itself aria-hidden=\"true\" so it is not read twice.",
+			},
+			Snippet{Language: "html", Description: "Icon button", Code: "<button type=\"button\" aria-label=\"Close dialog\">\n  <svg aria-hidden=\"true\">...</svg>\n</button>"}),
+		a11yPlaybook("link-name", "Give links discernible text",
+			"Links without text are announced as their URL or as just \"link\".",
+			[]string{
+				"Add link text describing the destination.",
+				"For image links, put the description in the image's alt attribute.",
+				"For icon links, add aria-label and hide the icon from assistive tech.",
+			},
+			Snippet{Language: "html", Description: "Image link", Code: "<a href=\"/\"><img src=\"logo.svg\" alt=\"Acme home\"></a>"}),
+		a11yPlaybook("html-has-lang", "Declare the page language",
+			"Without a lang attribute screen readers may pronounce the content with the wrong voice.",
+			[]string{
+				"Add a lang attribute to the <html> element in the root template.",
+				"Mark inline passages in other languages with their own lang attribute.",
+			},
+			Snippet{Language: "html", Description: "Root element", Code: "<html lang=\"en\">"}),
+		a11yPlaybook("document-title", "Give every page a descriptive title",
+			"The title is the first thing announced and identifies the tab; an empty one leaves users disoriented.",
+			[]string{
+				"Set a <title> in the document head.",
+				"In single-page apps, update document.title on every route change.",
+			},
+			Snippet{Language: "javascript", Description: "SPA route change", Code: "router.afterEach((to) => {\n  document.title = `${to.meta.title} | Acme`;\n});"}),
+		a11yPlaybook("heading-order", "Keep heading levels sequential",
+			"Skipped heading levels break the outline screen reader users navigate by.",
+			[]string{
+				"Use heading levels for structure only; never pick a level for its font size.",
+				"Make each heading at most one level deeper than the one before it.",
+				"Restyle with CSS classes if the visual size must differ from the level.",
+			},
+			Snippet{Language: "html", Description: "Style without skipping levels", Code: "<h2>Plans</h2>\n<h3 class=\"text-sm\">Starter</h3> <!-- was <h5> -->"}),
+		a11yPlaybook("aria-hidden-focus", "Remove focusable content from aria-hidden regions",
+			"Keyboard users can tab into content that screen readers claim does not exist.",
+			[]string{
+				"If the region should be hidden from everyone, hide it with the hidden attribute or display:none.",
+				"If it is only visually inert (e.g. behind a modal), add the inert attribute instead of aria-hidden.",
+				"Otherwise remove aria-hidden from the region.",
+			},
+			Snippet{Language: "html", Description: "Background behind a modal", Code: "<main inert>...</main>\n<div role=\"dialog\" aria-modal=\"true\">...</div>"}),
+		{
+			FindingID: CategoryAccessibility + "." + genericSuffix, Category: CategoryAccessibility,
+			Title: "Fix an accessibility violation",
+			Why:   "No dedicated playbook exists for this rule; the violation's helpUrl explains the requirement.",
+			Steps: []string{
+				"Open the violation's helpUrl for the rule's requirement.",
+				"Fix each node listed in the violation, starting with the highest impact.",
+				"Re-run the audit with force_refresh.",
+			},
+			Verify: analyzeCall("The rule no longer appears in violations",
+				map[string]any{"what": "accessibility", "force_refresh": true}),
+		},
+	}
+}
@@ -0,0 +1,94 @@
+// Purpose: Remediation playbooks for Web Vitals rated needs_improvement or poor.
+// Why: A slow metric says what is wrong, not where to look; the playbook names the usual causes and the budget check.
+// Docs: docs/features/feature/remediation-playbooks/index.md
+
+package remediation
+
+// PerformanceFindingID returns the finding ID for a Web Vitals metric name (lcp, fcp, ttfb, cls).
+func PerformanceFindingID(metric string) string {
+	return CategoryPerformance + "." + metric
+}
+
+func withinBudget(metric string, budget any) Verification {
+	return observeCall(metric+" within budget after the next page load", map[string]any{
+		"what":   "verify_fix",
+		"expect": map[string]any{"vitals_within_budget": map[string]any{metric: budget}},
+	})
+}
+
+func performancePlaybooks() []Playbook {
+	return []Playbook{
+		{
+			FindingID: PerformanceFindingID("lcp"), Category: CategoryPerformance,
+			Title: "Bring Largest Contentful Paint under 2.5s",
+			Why:   "LCP measures when the main content appears; past 2.5s users perceive the page as slow.",
+			Steps: []string{
+				"Identify the LCP element (usually the hero image or headline) with analyze(what=\"performance\").",
+				"If it is an image: preload it, serve a modern format at the rendered size, and never lazy-load it.",
+				"Remove render-blocking CSS and scripts ahead of it; defer non-critical JavaScript.",
+				"If TTFB is also slow, fix that first: LCP cannot beat the server.",
+			},
+			Snippets: []Snippet{
+				{Language: "html", Description: "Prioritize the hero image", Code: "<link rel=\"preload\" as=\"image\" href=\"/hero.avif\" fetchpriority=\"high\">\n<img src=\"/hero.avif\" width=\"1200\" height=\"600\" fetchpriority=\"high\" alt=\"...\">"},
+			},
+			Verify:     withinBudget("lcp_ms", 2500),
+			References: []string{"https://web.dev/articles/optimize-lcp"},
+		},
+		{
+			FindingID: PerformanceFindingID("fcp"), Category: CategoryPerformance,
+			Title: "Bring First Contentful Paint under 1.8s",
+			Why:   "FCP is the first visual feedback; a blank screen past 1.8s makes the page feel broken.",
+			Steps: []string{
+				"Inline critical CSS and load the rest asynchronously.",
+				"Add defer or async to scripts in <head>.",
+				"Use font-display: swap so text renders before web fonts arrive.",
+			},
+			Snippets: []Snippet{
+				{Language: "html", Description: "Non-blocking script and font", Code: "<script src=\"/app.js\" defer></script>\n<style>@font-face { font-family: Brand; src: url(/brand.woff2); font-display: swap; }</style>"},
+			},
+			Verify:     withinBudget("fcp_ms", 1800),
+			References: []string{"https://web.dev/articles/fcp"},
+		},
+		{
+			FindingID: PerformanceFindingID("ttfb"), Category: CategoryPerformance,
+			Title: "Bring Time to First Byte under 800ms",
+			Why:   "Every other metric waits on the first byte; slow TTFB is a server, cache, or redirect problem.",
+			Steps: []string{
+				"Check for redirect chains on the document request in observe(what=\"network_waterfall\").",
+				"Cache the HTML at the edge or cache expensive data lookups on the server.",
+				"Profile the server handler for slow queries or sequential upstream calls.",
+			},
+			Snippets: []Snippet{
+				{Language: "http", Description: "Let a CDN cache the page briefly", Code: "Cache-Control: public, s-maxage=60, stale-while-revalidate=300"},
+			},
+			Verify:     withinBudget("ttfb_ms", 800),
+			References: []string{"https://web.dev/articles/optimize-ttfb"},
+		},
+		{
+			FindingID: PerformanceFindingID("cls"), Category: CategoryPerformance,
+			Title: "Bring Cumulative Layout Shift under 0.1",
+			Why:   "Layout shifts move content under the user's cursor and cause mis-clicks.",
+			Steps: []string{
+				"Give images, videos, and iframes explicit width and height (or aspect-ratio).",
+				"Reserve space for ads, embeds, and late-loading banners.",
+				"Avoid inserting content above existing content except in response to user input.",
+			},
+			Snippets: []Snippet{
+				{Language: "css", Description: "Reserve space for media", Code: "img, video { max-width: 100%; height: auto; } /* keep width/height attributes in the HTML */\n.ad-slot { min-height: 250px; }"},
+			},
+			Verify:     withinBudget("cls", 0.1),
+			References: []string{"https://web.dev/articles/optimize-cls"},
+		},
+		{
+			FindingID: CategoryPerformance + "." + genericSuffix, Category: CategoryPerformance,
+			Title: "Investigate a slow metric",
+			Why:   "No dedicated playbook exists for this metric; start from the waterfall and the performance report.",
+			Steps: []string{
+				"Run analyze(what=\"performance\") to find the largest contributors.",
+				"Fix the biggest blocking resource or slowest request first.",
+				"Reload and compare with the previous snapshot.",
+			},
+			Verify: observeCall("Metric improves on the next page load", map[string]any{"what": "vitals"}),
+		},
+	}
+}
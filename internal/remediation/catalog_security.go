@@ -0,0 +1,281 @@
+// Purpose: Remediation playbooks for security findings from security_audit, cookie_audit, and transport checks.
+// Why: Each scanner check stamps one of these IDs, so every security finding resolves to concrete fix steps.
+// Docs: docs/features/feature/remediation-playbooks/index.md
+
+package remediation
+
+// Security finding IDs stamped on security.SecurityFinding.FindingID.
+const (
+	SecurityCredentialInURL          = "security.credential_in_url"
+	SecurityCredentialInBody         = "security.credential_in_body"
+	SecurityCredentialInConsole      = "security.credential_in_console"
+	SecurityPIIExposure              = "security.pii_exposure"
+	SecurityCookieMissingHttpOnly    = "security.cookie_missing_httponly"
+	SecurityCookieMissingSecure      = "security.cookie_missing_secure"
+	SecurityCookieMissingSameSite    = "security.cookie_missing_samesite"
+	SecurityCookieOversized          = "security.cookie_oversized"
+	SecuritySecretInWebStorage       = "security.secret_in_web_storage"
+	SecurityMissingHSTS              = "security.missing_hsts"
+	SecurityMissingCSP               = "security.missing_csp"
+	SecurityMissingContentTypeOpts   = "security.missing_x_content_type_options"
+	SecurityMissingFrameOptions      = "security.missing_x_frame_options"
+	SecurityMissingReferrerPolicy    = "security.missing_referrer_policy"
+	SecurityMissingPermissionsPolicy = "security.missing_permissions_policy"
+	SecurityInsecureTransport        = "security.insecure_transport"
+	SecurityMixedContent             = "security.mixed_content"
+	SecurityUnauthenticatedData      = "security.unauthenticated_sensitive_data"
+	SecuritySuspiciousOrigin         = "security.suspicious_origin"
+)
+
+// MissingHeaderFindingID maps a required security header name to its finding ID.
+func MissingHeaderFindingID(header string) string {
+	switch header {
+	case "Strict-Transport-Security":
+		return SecurityMissingHSTS
+	case "Content-Security-Policy":
+		return SecurityMissingCSP
+	case "X-Content-Type-Options":
+		return SecurityMissingContentTypeOpts
+	case "X-Frame-Options":
+		return SecurityMissingFrameOptions
+	case "Referrer-Policy":
+		return SecurityMissingReferrerPolicy
+	case "Permissions-Policy":
+		return SecurityMissingPermissionsPolicy
+	default:
+		return CategorySecurity + "." + genericSuffix
+	}
+}
+
+func securityAudit(check, expect string) Verification {
+	return analyzeCall(expect, map[string]any{"what": "security_audit", "checks": []string{check}})
+}
+
+func headerPlaybook(id, header, value, why string) Playbook {
+	return Playbook{
+		FindingID: id, Category: CategorySecurity,
+		Title: "Send the " + header + " response header",
+		Why:   why,
+		Steps: []string{
+			"Find where HTML responses get their headers: framework middleware, reverse proxy, or CDN config.",
+			"Add " + header + ": " + value + " to every HTML response, not only the entry page.",
+			"Reload the page so a fresh response is captured.",
+		},
+		Snippets: []Snippet{
+			{Language: "javascript", Description: "Express middleware", Code: "app.use((req, res, next) => {\n  res.setHeader('" + header + "', '" + value + "');\n  next();\n});"},
+			{Language: "nginx", Description: "nginx server block", Code: "add_header " + header + " \"" + value + "\" always;"},
+		},
+		Verify:     securityAudit("headers", "No finding with finding_id "+id),
+		References: []string{"https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/" + header},
+	}
+}
+
+func securityPlaybooks() []Playbook {
+	return []Playbook{
+		{
+			FindingID: SecurityCredentialInURL, Category: CategorySecurity,
+			Title: "Move secrets out of request URLs",
+			Why:   "URLs are written to browser history, server access logs, proxies, and Referer headers, so a secret in a query string leaks widely.",
+			Steps: []string{
+				"Find the code that builds the flagged URL (the finding location shows the request).",
+				"Send the secret in an Authorization header or the request body instead of the query string.",
+				"Rotate the exposed credential; it is already in logs.",
+				"Replay the flow that made the request.",
+			},
+			Snippets: []Snippet{
+				{Language: "javascript", Description: "Header instead of query parameter", Code: "// before: fetch(`/api/data?api_key=${key}`)\nfetch('/api/data', { headers: { Authorization: `Bearer ${key}` } });"},
+			},
+			Verify:     securityAudit("credentials", "No finding with finding_id "+SecurityCredentialInURL),
+			References: []string{"https://owasp.org/www-community/vulnerabilities/Information_exposure_through_query_strings_in_url"},
+		},
+		{
+			FindingID: SecurityCredentialInBody, Category: CategorySecurity,
+			Title: "Stop returning secrets in request or response bodies",
+			Why:   "Anything in a body the browser sees is readable by every script on the page and by anyone inspecting traffic.",
+			Steps: []string{
+				"Identify the endpoint and field that carries the secret (location and evidence on the finding).",
+				"Remove the field from the serializer, or proxy the secret-using call through your server.",
+				"For client-side SDKs, switch to publishable or short-lived scoped tokens.",
+				"Rotate the exposed credential.",
+			},
+			Snippets: []Snippet{
+				{Language: "javascript", Description: "Strip secrets before responding", Code: "const { secretKey, ...safe } = account;\nres.json(safe);"},
+			},
+			Verify: securityAudit("credentials", "No finding with finding_id "+SecurityCredentialInBody),
+		},
+		{
+			FindingID: SecurityCredentialInConsole, Category: CategorySecurity,
+			Title: "Remove credentials from console output",
+			Why:   "Console output is collected by error trackers and extensions, so logged tokens leave the browser.",
+			Steps: []string{
+				"Search the source for console calls that print tokens, headers, or auth responses.",
+				"Delete the log line or redact the value before logging.",
+				"Reload and repeat the flow that logged the token.",
+			},
+			Snippets: []Snippet{
+				{Language: "javascript", Description: "Redact before logging", Code: "console.debug('auth ok', { token: token ? '[redacted]' : null });"},
+			},
+			Verify: securityAudit("credentials", "No finding with finding_id "+SecurityCredentialInConsole),
+		},
+		{
+			FindingID: SecurityPIIExposure, Category: CategorySecurity,
+			Title: "Minimize personal data sent over the network",
+			Why:   "PII in requests, especially to third parties, creates privacy and compliance exposure.",
+			Steps: []string{
+				"Check whether the receiving endpoint actually needs the flagged field.",
+				"Drop the field, or replace it with a token or hash the server can resolve.",
+				"For third-party calls, strip PII in the integration layer before the request is built.",
+			},
+			Snippets: []Snippet{
+				{Language: "javascript", Description: "Send only what the analytics call needs", Code: "analytics.track('signup', { plan: user.plan }); // not user.email"},
+			},
+			Verify: securityAudit("pii", "No finding with finding_id "+SecurityPIIExposure+" for the endpoint"),
+		},
+		{
+			FindingID: SecurityCookieMissingHttpOnly, Category: CategorySecurity,
+			Title: "Mark session cookies HttpOnly",
+			Why:   "A session cookie readable from JavaScript can be stolen by any XSS bug.",
+			Steps: []string{
+				"Find where the server sets the cookie named in the finding.",
+				"Add the HttpOnly attribute; if client code reads the cookie, move that logic server-side.",
+				"Log in again so a fresh Set-Cookie is captured.",
+			},
+			Snippets: []Snippet{
+				{Language: "javascript", Description: "Express", Code: "res.cookie('session', id, { httpOnly: true, secure: true, sameSite: 'lax' });"},
+				{Language: "http", Description: "Raw header", Code: "Set-Cookie: session=...; HttpOnly; Secure; SameSite=Lax"},
+			},
+			Verify: observeCall("No finding with finding_id "+SecurityCookieMissingHttpOnly, map[string]any{"what": "cookie_audit"}),
+		},
+		{
+			FindingID: SecurityCookieMissingSecure, Category: CategorySecurity,
+			Title: "Mark cookies Secure on HTTPS sites",
+			Why:   "Without Secure the browser will send the cookie over plain HTTP, where it can be intercepted.",
+			Steps: []string{
+				"Add the Secure attribute wherever the cookie is set.",
+				"Make sure local development still works (localhost is treated as secure by modern browsers).",
+				"Reload so a fresh Set-Cookie is captured.",
+			},
+			Snippets: []Snippet{
+				{Language: "http", Description: "Raw header", Code: "Set-Cookie: prefs=...; Secure; SameSite=Lax"},
+			},
+			Verify: observeCall("No finding with finding_id "+SecurityCookieMissingSecure, map[string]any{"what": "cookie_audit"}),
+		},
+		{
+			FindingID: SecurityCookieMissingSameSite, Category: CategorySecurity,
+			Title: "Set SameSite on session cookies",
+			Why:   "Cookies without SameSite ride along on cross-site requests, enabling CSRF.",
+			Steps: []string{
+				"Add SameSite=Lax (or Strict if no cross-site navigation needs the session).",
+				"Use SameSite=None; Secure only for cookies that must work in third-party iframes.",
+				"Log in again so a fresh Set-Cookie is captured.",
+			},
+			Snippets: []Snippet{
+				{Language: "http", Description: "Raw header", Code: "Set-Cookie: session=...; HttpOnly; Secure; SameSite=Lax"},
+			},
+			Verify: observeCall("No finding with finding_id "+SecurityCookieMissingSameSite, map[string]any{"what": "cookie_audit"}),
+		},
+		{
+			FindingID: SecurityCookieOversized, Category: CategorySecurity,
+			Title: "Shrink oversized cookies",
+			Why:   "Browsers silently drop oversized cookies, and every byte is re-sent on each request.",
+			Steps: []string{
+				"Move the cookie's payload into server-side session storage.",
+				"Keep only an opaque identifier in the cookie.",
+			},
+			Verify: observeCall("No finding with finding_id "+SecurityCookieOversized, map[string]any{"what": "cookie_audit"}),
+		},
+		{
+			FindingID: SecuritySecretInWebStorage, Category: CategorySecurity,
+			Title: "Keep credentials out of localStorage and sessionStorage",
+			Why:   "Web storage is readable by every script on the origin, so one XSS bug exposes the credential.",
+			Steps: []string{
+				"Find the code that writes the flagged key.",
+				"Store the session in an HttpOnly, Secure, SameSite cookie set by the server, or keep short-lived tokens in memory only.",
+				"Remove the existing key on next load so old values do not linger.",
+			},
+			Snippets: []Snippet{
+				{Language: "javascript", Description: "Clean up the legacy key", Code: "localStorage.removeItem('auth_token');"},
+			},
+			Verify: observeCall("No finding with finding_id "+SecuritySecretInWebStorage, map[string]any{"what": "cookie_audit"}),
+		},
+		headerPlaybook(SecurityMissingHSTS, "Strict-Transport-Security", "max-age=31536000; includeSubDomains",
+			"Without HSTS the first request can be downgraded to HTTP by a network attacker."),
+		headerPlaybook(SecurityMissingCSP, "Content-Security-Policy", "default-src 'self'; object-src 'none'; base-uri 'self'",
+			"A Content Security Policy limits what injected script can do and is the main defence-in-depth against XSS."),
+		headerPlaybook(SecurityMissingContentTypeOpts, "X-Content-Type-Options", "nosniff",
+			"Without nosniff the browser may execute a response as script even when its Content-Type says otherwise."),
+		headerPlaybook(SecurityMissingFrameOptions, "X-Frame-Options", "DENY",
+			"Pages that can be framed by other origins are open to clickjacking."),
+		headerPlaybook(SecurityMissingReferrerPolicy, "Referrer-Policy", "strict-origin-when-cross-origin",
+			"Without a Referrer-Policy full URLs, including paths and query strings, can leak to third parties."),
+		headerPlaybook(SecurityMissingPermissionsPolicy, "Permissions-Policy", "camera=(), microphone=(), geolocation=()",
+			"A Permissions-Policy stops embedded or injected code from using powerful browser features you never need."),
+		{
+			FindingID: SecurityInsecureTransport, Category: CategorySecurity,
+			Title: "Serve API calls over HTTPS",
+			Why:   "Plain HTTP traffic can be read and modified by anyone on the network path.",
+			Steps: []string{
+				"Change the flagged base URL to https:// (check environment config and hard-coded constants).",
+				"Enable TLS on the server and redirect HTTP to HTTPS.",
+				"Replay the flow that made the request.",
+			},
+			Snippets: []Snippet{
+				{Language: "javascript", Description: "Environment-driven base URL", Code: "const API_BASE = process.env.API_BASE ?? 'https://api.example.com';"},
+			},
+			Verify: observeCall("status is not insecure and the endpoint is absent from findings", map[string]any{"what": "transport_security"}),
+		},
+		{
+			FindingID: SecurityMixedContent, Category: CategorySecurity,
+			Title: "Load every subresource over HTTPS",
+			Why:   "An HTTP script or stylesheet on an HTTPS page can be swapped by a network attacker, compromising the whole page.",
+			Steps: []string{
+				"Change the flagged resource URL to https:// or a protocol-relative origin you control.",
+				"Add Content-Security-Policy: upgrade-insecure-requests as a backstop.",
+				"Reload the page.",
+			},
+			Snippets: []Snippet{
+				{Language: "html", Description: "Upgrade the reference", Code: "<script src=\"https://cdn.example.com/lib.js\"></script>"},
+			},
+			Verify: observeCall("No mixed-content entries for the page", map[string]any{"what": "transport_security"}),
+		},
+		{
+			FindingID: SecurityUnauthenticatedData, Category: CategorySecurity,
+			Title: "Require authentication on endpoints that return personal data",
+			Why:   "An endpoint that returns PII without credentials can be scraped by anyone who finds the URL.",
+			Steps: []string{
+				"Confirm whether the endpoint is meant to be public.",
+				"If not, add your auth middleware to the route and return 401 without credentials.",
+				"If it is public, remove the personal fields from the response.",
+			},
+			Snippets: []Snippet{
+				{Language: "javascript", Description: "Express route guard", Code: "router.get('/api/users/:id', requireAuth, getUser);"},
+			},
+			Verify: securityAudit("auth", "No finding with finding_id "+SecurityUnauthenticatedData),
+		},
+		{
+			FindingID: SecuritySuspiciousOrigin, Category: CategorySecurity,
+			Title: "Verify resources loaded from suspicious origins",
+			Why:   "Resources from look-alike domains, raw IPs, odd ports, or high-abuse TLDs are a common supply-chain attack vector.",
+			Steps: []string{
+				"Check the exact origin against the vendor's documented domain.",
+				"Fix typos in CDN references or package config, or self-host the dependency.",
+				"Add Subresource Integrity to third-party scripts you keep.",
+			},
+			Snippets: []Snippet{
+				{Language: "html", Description: "Subresource Integrity", Code: "<script src=\"https://cdn.example.com/lib.js\" integrity=\"sha384-...\" crossorigin=\"anonymous\"></script>"},
+			},
+			Verify: observeCall("The origin no longer appears", map[string]any{"what": "third_party_audit"}),
+		},
+		{
+			FindingID: CategorySecurity + "." + genericSuffix, Category: CategorySecurity,
+			Title: "Address a security finding",
+			Why:   "No dedicated playbook exists for this finding; follow its remediation text and re-run the audit.",
+			Steps: []string{
+				"Read the finding's title, evidence, and remediation fields.",
+				"Apply the fix at the source named in location.",
+				"Re-run the audit to confirm the finding is gone.",
+			},
+			Verify: analyzeCall("The finding no longer appears", map[string]any{"what": "security_audit"}),
+		},
+	}
+}
@@ -0,0 +1,139 @@
+// Purpose: Defines machine-readable remediation playbooks and resolves them by finding ID.
+// Why: Lets an agent go from a security, accessibility, or performance finding to a fix and a verification call without hand-written prompts.
+// Docs: docs/features/feature/remediation-playbooks/index.md
+
+// Package remediation holds the playbook catalog behind observe(what="playbook").
+//
+// Finding producers (security scans, cookie audits, axe runs, vitals diffs) stamp each
+// finding with a stable ID such as "security.missing_csp", "a11y.color-contrast", or
+// "performance.lcp". Lookup resolves that ID to a playbook with ordered steps, code
+// snippets, and the tool call that proves the fix landed.
+package remediation
+
+import (
+	"sort"
+	"strings"
+)
+
+// Finding categories; each ID is prefixed with its category and a dot.
+const (
+	CategorySecurity      = "security"
+	CategoryAccessibility = "a11y"
+	CategoryPerformance   = "performance"
+)
+
+// genericSuffix names the per-category fallback playbook returned for unknown IDs.
+const genericSuffix = "generic"
+
+// Snippet is an illustrative code change for one stack.
+type Snippet struct {
+	Language    string `json:"language"`
+	Description string `json:"description"`
+	Code        string `json:"code"`
+}
+
+// Verification is the tool call that confirms a fix, and what a passing result looks like.
+type Verification struct {
+	Tool      string         `json:"tool"`
+	Arguments map[string]any `json:"arguments"`
+	Expect    string         `json:"expect"`
+}
+
+// Playbook is a remediation recipe for one class of finding.
+type Playbook struct {
+	FindingID  string       `json:"finding_id"`
+	Category   string       `json:"category"`
+	Title      string       `json:"title"`
+	Why        string       `json:"why"`
+	Steps      []string     `json:"steps"`
+	Snippets   []Snippet    `json:"snippets,omitempty"`
+	Verify     Verification `json:"verify"`
+	References []string     `json:"references,omitempty"`
+	// Generic is true when the requested ID had no dedicated playbook and the
+	// category fallback was returned instead.
+	Generic bool `json:"generic,omitempty"`
+}
+
+// IndexEntry is the compact listing returned when no finding ID is requested.
+type IndexEntry struct {
+	FindingID string `json:"finding_id"`
+	Category  string `json:"category"`
+	Title     string `json:"title"`
+}
+
+var catalog = buildCatalog(securityPlaybooks(), accessibilityPlaybooks(), performancePlaybooks())
+
+func buildCatalog(groups ...[]Playbook) map[string]Playbook {
+	out := make(map[string]Playbook)
+	for _, group := range groups {
+		for _, p := range group {
+			out[p.FindingID] = p
+		}
+	}
+	return out
+}
+
+// Lookup returns the playbook for findingID. IDs are matched case-insensitively; an
+// unknown ID with a known category prefix falls back to that category's generic playbook
+// (with Generic set). ok is false only when the category itself is unknown.
+func Lookup(findingID string) (Playbook, bool) {
+	id := strings.ToLower(strings.TrimSpace(findingID))
+	if p, ok := catalog[id]; ok && !strings.HasSuffix(id, "."+genericSuffix) {
+		return clone(p), true
+	}
+	category, _, _ := strings.Cut(id, ".")
+	p, ok := catalog[category+"."+genericSuffix]
+	if !ok {
+		return Playbook{}, false
+	}
+	p = clone(p)
+	p.FindingID = id
+	p.Generic = true
+	return p, true
+}
+
+// Has reports whether findingID has a dedicated (non-generic) playbook.
+func Has(findingID string) bool {
+	p, ok := Lookup(findingID)
+	return ok && !p.Generic
+}
+
+// Index lists every dedicated playbook, sorted by category then ID.
+func Index() []IndexEntry {
+	entries := make([]IndexEntry, 0, len(catalog))
+	for id, p := range catalog {
+		if strings.HasSuffix(id, "."+genericSuffix) {
+			continue
+		}
+		entries = append(entries, IndexEntry{FindingID: id, Category: p.Category, Title: p.Title})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Category != entries[j].Category {
+			return entries[i].Category < entries[j].Category
+		}
+		return entries[i].FindingID < entries[j].FindingID
+	})
+	return entries
+}
+
+// clone copies slices and the argument map so callers can't mutate the shared catalog.
+func clone(p Playbook) Playbook {
+	p.Steps = append([]string(nil), p.Steps...)
+	p.Snippets = append([]Snippet(nil), p.Snippets...)
+	p.References = append([]string(nil), p.References...)
+	args := make(map[string]any, len(p.Verify.Arguments))
+	for k, v := range p.Verify.Arguments {
+		args[k] = v
+	}
+	p.Verify.Arguments = args
+	return p
+}
+
+// observeCall and analyzeCall build Verification values for the catalog.
+func observeCall(expect string, args map[string]any) Verification {
+	return Verification{Tool: "observe", Arguments: args, Expect: expect}
+}
+
+func analyzeCall(expect string, args map[string]any) Verification {
+	return Verification{Tool: "analyze", Arguments: args, Expect: expect}
+}
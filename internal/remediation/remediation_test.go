@@ -0,0 +1,93 @@
+// Purpose: Tests remediation playbook lookup, category fallback, and catalog completeness.
+// Docs: docs/features/feature/remediation-playbooks/index.md
+
+package remediation
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLookup_ExactGenericAndUnknown(t *testing.T) {
+	t.Parallel()
+	p, ok := Lookup(" Security.Missing_CSP ")
+	if !ok || p.Generic || p.FindingID != SecurityMissingCSP {
+		t.Fatalf("Lookup(missing_csp) = %+v, %v", p, ok)
+	}
+
+	p, ok = Lookup("a11y.region")
+	if !ok || !p.Generic || p.FindingID != "a11y.region" || p.Category != CategoryAccessibility {
+		t.Fatalf("Lookup(a11y.region) = %+v, %v; want a11y generic fallback", p, ok)
+	}
+	if Has("a11y.region") || !Has(A11yFindingID("color-contrast")) {
+		t.Error("Has should be true only for dedicated playbooks")
+	}
+
+	if _, ok := Lookup("bogus.thing"); ok {
+		t.Error("Lookup with unknown category should fail")
+	}
+	if _, ok := Lookup("security.generic"); !ok {
+		t.Error("Lookup(security.generic) should resolve to the fallback")
+	}
+}
+
+func TestLookup_ReturnsIndependentCopies(t *testing.T) {
+	t.Parallel()
+	p, _ := Lookup(SecurityCookieMissingHttpOnly)
+	p.Steps[0] = "mutated"
+	p.Verify.Arguments["what"] = "mutated"
+	again, _ := Lookup(SecurityCookieMissingHttpOnly)
+	if again.Steps[0] == "mutated" || again.Verify.Arguments["what"] == "mutated" {
+		t.Fatal("Lookup leaked a reference into the shared catalog")
+	}
+}
+
+func TestCatalog_EveryPlaybookIsActionable(t *testing.T) {
+	t.Parallel()
+	for id, p := range catalog {
+		category, _, _ := strings.Cut(id, ".")
+		if p.FindingID != id || p.Category != category {
+			t.Errorf("%s: finding_id/category = %s/%s", id, p.FindingID, p.Category)
+		}
+		if p.Title == "" || p.Why == "" || len(p.Steps) == 0 {
+			t.Errorf("%s: title, why, and steps are required", id)
+		}
+		if (p.Verify.Tool != "observe" && p.Verify.Tool != "analyze") || p.Verify.Arguments["what"] == nil || p.Verify.Expect == "" {
+			t.Errorf("%s: verify = %+v, want an observe/analyze call with what and expect", id, p.Verify)
+		}
+	}
+	for _, category := range []string{CategorySecurity, CategoryAccessibility, CategoryPerformance} {
+		if _, ok := catalog[category+"."+genericSuffix]; !ok {
+			t.Errorf("missing generic playbook for %s", category)
+		}
+	}
+	for _, header := range []string{"Strict-Transport-Security", "Content-Security-Policy", "X-Content-Type-Options", "X-Frame-Options", "Referrer-Policy", "Permissions-Policy"} {
+		if !Has(MissingHeaderFindingID(header)) {
+			t.Errorf("no playbook for missing %s", header)
+		}
+	}
+	for _, metric := range []string{"lcp", "fcp", "ttfb", "cls"} {
+		if !Has(PerformanceFindingID(metric)) {
+			t.Errorf("no playbook for %s", metric)
+		}
+	}
+}
+
+func TestIndex_SortedWithoutGenerics(t *testing.T) {
+	t.Parallel()
+	index := Index()
+	if len(index) != len(catalog)-3 {
+		t.Fatalf("len(Index) = %d, want %d", len(index), len(catalog)-3)
+	}
+	for i, e := range index {
+		if strings.HasSuffix(e.FindingID, ".generic") {
+			t.Errorf("index lists generic playbook %s", e.FindingID)
+		}
+		if i > 0 {
+			prev := index[i-1]
+			if prev.Category > e.Category || (prev.Category == e.Category && prev.FindingID > e.FindingID) {
+				t.Errorf("index not sorted at %d: %s before %s", i, prev.FindingID, e.FindingID)
+			}
+		}
+	}
+}
@@ -0,0 +1,17 @@
+// Purpose: Package remoteaccess — opt-in TLS listener policy for reaching the daemon from another machine.
+// Why: The agent may run on a remote dev box while the browser stays on a laptop; the localhost-only checks must relax only when configured.
+// Docs: docs/features/feature/remote-mode/index.md
+
+/*
+Package remoteaccess holds the policy and key material for remote mode: a second,
+TLS-only listener that accepts requests from allowlisted peers carrying the API key.
+
+Key types:
+  - Config: listen address, peer allowlist, and accepted Host names.
+
+Key functions:
+  - ParsePeers: IP and CIDR allowlist entries to prefixes.
+  - LoadOrCreateCertificate: a configured key pair, or a generated self-signed one kept on disk.
+  - Fingerprint / PinnedClientConfig: the server certificate's SHA-256 pin and a client TLS config that enforces it.
+*/
+package remoteaccess
@@ -0,0 +1,247 @@
+// Purpose: Parses the remote peer allowlist, checks Host names, and loads or generates the remote listener's TLS certificate.
+// Why: Remote mode must never serve plaintext or unauthenticated peers, and a self-signed cert keeps it zero-setup.
+// Docs: docs/features/feature/remote-mode/index.md
+
+package remoteaccess
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	// CertFileName and KeyFileName are the generated key pair's names inside the state dir.
+	CertFileName = "remote-cert.pem"
+	KeyFileName  = "remote-key.pem"
+
+	// certValidity is the lifetime of a generated self-signed certificate.
+	certValidity = 365 * 24 * time.Hour
+	// fingerprintPrefix marks the hash algorithm in a printed or pinned fingerprint.
+	fingerprintPrefix = "sha256:"
+)
+
+// Config is the remote listener policy. A zero Config means remote mode is off.
+type Config struct {
+	ListenAddr string
+	Peers      []netip.Prefix
+	Hosts      []string
+}
+
+// Enabled reports whether a remote listener is configured.
+func (c *Config) Enabled() bool {
+	return c != nil && c.ListenAddr != ""
+}
+
+// ParsePeers converts allowlist entries (single IPs or CIDR ranges) to prefixes.
+func ParsePeers(entries []string) ([]netip.Prefix, error) {
+	peers := make([]netip.Prefix, 0, len(entries))
+	for _, raw := range entries {
+		for _, entry := range strings.Split(raw, ",") {
+			entry = strings.TrimSpace(entry)
+			if entry == "" {
+				continue
+			}
+			if strings.Contains(entry, "/") {
+				prefix, err := netip.ParsePrefix(entry)
+				if err != nil {
+					return nil, fmt.Errorf("peer %q: %w", entry, err)
+				}
+				peers = append(peers, prefix.Masked())
+				continue
+			}
+			addr, err := netip.ParseAddr(entry)
+			if err != nil {
+				return nil, fmt.Errorf("peer %q: not an IP or CIDR", entry)
+			}
+			addr = addr.Unmap()
+			peers = append(peers, netip.PrefixFrom(addr, addr.BitLen()))
+		}
+	}
+	return peers, nil
+}
+
+// PeerAllowed reports whether a request's RemoteAddr falls inside the allowlist.
+func (c *Config) PeerAllowed(remoteAddr string) bool {
+	host := remoteAddr
+	if h, _, err := net.SplitHostPort(remoteAddr); err == nil {
+		host = h
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap().WithZone("")
+	for _, prefix := range c.Peers {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// HostAllowed reports whether a Host header names the daemon as configured for remote peers.
+// The port is ignored; names compare case-insensitively.
+func (c *Config) HostAllowed(host string) bool {
+	hostname := host
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		hostname = h
+	}
+	hostname = strings.TrimSuffix(strings.TrimPrefix(hostname, "["), "]")
+	for _, allowed := range c.Hosts {
+		if strings.EqualFold(hostname, allowed) {
+			return true
+		}
+	}
+	return false
+}
+
+// LoadOrCreateCertificate loads certFile/keyFile when both are set. Otherwise it loads the
+// generated pair in dir, creating a self-signed one (valid for hosts and localhost) if none exists
+// or the stored one has expired. created reports whether a new pair was written.
+func LoadOrCreateCertificate(certFile, keyFile, dir string, hosts []string) (cert tls.Certificate, created bool, err error) {
+	if certFile != "" || keyFile != "" {
+		if certFile == "" || keyFile == "" {
+			return tls.Certificate{}, false, errors.New("both a certificate and a key file are required")
+		}
+		cert, err = tls.LoadX509KeyPair(certFile, keyFile)
+		return cert, false, err
+	}
+	certPath, keyPath := filepath.Join(dir, CertFileName), filepath.Join(dir, KeyFileName)
+	if cert, err = tls.LoadX509KeyPair(certPath, keyPath); err == nil && !expired(cert) {
+		return cert, false, nil
+	}
+	certPEM, keyPEM, err := generateSelfSigned(hosts)
+	if err != nil {
+		return tls.Certificate{}, false, err
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return tls.Certificate{}, false, err
+	}
+	if err := os.WriteFile(keyPath, keyPEM, 0o600); err != nil {
+		return tls.Certificate{}, false, err
+	}
+	if err := os.WriteFile(certPath, certPEM, 0o600); err != nil {
+		return tls.Certificate{}, false, err
+	}
+	cert, err = tls.X509KeyPair(certPEM, keyPEM)
+	return cert, err == nil, err
+}
+
+// expired reports whether the leaf certificate is past (or within a day of) its NotAfter.
+func expired(cert tls.Certificate) bool {
+	if len(cert.Certificate) == 0 {
+		return true
+	}
+	leaf, err := x509.ParseCertificate(cert.Certificate[0])
+	if err != nil {
+		return true
+	}
+	return time.Now().Add(24 * time.Hour).After(leaf.NotAfter)
+}
+
+// generateSelfSigned creates a P-256 certificate and key in PEM form, with hosts as SANs.
+func generateSelfSigned(hosts []string) (certPEM, keyPEM []byte, err error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, nil, err
+	}
+	now := time.Now()
+	tmpl := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: "kaboom remote", Organization: []string{"Kaboom"}},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(certValidity),
+		KeyUsage:              x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		DNSNames:              []string{"localhost"},
+		IPAddresses:           []net.IP{net.IPv4(127, 0, 0, 1), net.IPv6loopback},
+	}
+	for _, h := range hosts {
+		if ip := net.ParseIP(h); ip != nil {
+			tmpl.IPAddresses = append(tmpl.IPAddresses, ip)
+		} else if h != "" && !strings.EqualFold(h, "localhost") {
+			tmpl.DNSNames = append(tmpl.DNSNames, h)
+		}
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		return nil, nil, err
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	certPEM = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM = pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	return certPEM, keyPEM, nil
+}
+
+// Fingerprint returns "sha256:<hex>" of the leaf certificate's DER bytes.
+func Fingerprint(cert tls.Certificate) string {
+	if len(cert.Certificate) == 0 {
+		return ""
+	}
+	return fingerprintDER(cert.Certificate[0])
+}
+
+func fingerprintDER(der []byte) string {
+	sum := sha256.Sum256(der)
+	return fingerprintPrefix + hex.EncodeToString(sum[:])
+}
+
+// normalizeFingerprint accepts "sha256:<hex>" or bare hex, with or without colons, in any case.
+func normalizeFingerprint(fp string) (string, error) {
+	fp = strings.ToLower(strings.TrimSpace(fp))
+	fp = strings.TrimPrefix(fp, fingerprintPrefix)
+	fp = strings.ReplaceAll(fp, ":", "")
+	if b, err := hex.DecodeString(fp); err != nil || len(b) != sha256.Size {
+		return "", fmt.Errorf("fingerprint must be %d hex bytes of SHA-256", sha256.Size)
+	}
+	return fingerprintPrefix + fp, nil
+}
+
+// PinnedClientConfig returns a client TLS config that accepts exactly the server certificate
+// with the given fingerprint. Chain and name verification are replaced by the pin, which is
+// what lets a self-signed server certificate authenticate the server.
+func PinnedClientConfig(fingerprint string) (*tls.Config, error) {
+	want, err := normalizeFingerprint(fingerprint)
+	if err != nil {
+		return nil, err
+	}
+	return &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: true, // #nosec G402 -- replaced by the fingerprint pin in VerifyPeerCertificate
+		VerifyPeerCertificate: func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+			if len(rawCerts) == 0 {
+				return errors.New("server presented no certificate")
+			}
+			got := fingerprintDER(rawCerts[0])
+			if subtle.ConstantTimeCompare([]byte(got), []byte(want)) != 1 {
+				return fmt.Errorf("server certificate fingerprint %s does not match pinned %s", got, want)
+			}
+			return nil
+		},
+	}, nil
+}
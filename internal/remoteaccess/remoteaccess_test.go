@@ -0,0 +1,139 @@
+// Purpose: Tests for the remote peer allowlist, Host matching, certificate generation, and fingerprint pinning.
+// Docs: docs/features/feature/remote-mode/index.md
+
+package remoteaccess
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestParsePeersAndPeerAllowed(t *testing.T) {
+	t.Parallel()
+	peers, err := ParsePeers([]string{"10.0.0.0/8", "192.168.1.20, fd00::/8"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := &Config{ListenAddr: ":7443", Peers: peers}
+
+	tests := []struct {
+		addr string
+		want bool
+	}{
+		{"10.2.3.4:51000", true},
+		{"192.168.1.20:40000", true},
+		{"192.168.1.21:40000", false},
+		{"[::ffff:10.1.1.1]:5000", true},
+		{"[fd12::1]:5000", true},
+		{"[2001:db8::1]:5000", false},
+		{"203.0.113.9:443", false},
+		{"not-an-ip", false},
+	}
+	for _, tt := range tests {
+		if got := cfg.PeerAllowed(tt.addr); got != tt.want {
+			t.Errorf("PeerAllowed(%q) = %v, want %v", tt.addr, got, tt.want)
+		}
+	}
+
+	if _, err := ParsePeers([]string{"laptop.local"}); err == nil {
+		t.Error("expected an error for a hostname entry")
+	}
+	if _, err := ParsePeers([]string{"10.0.0.0/99"}); err == nil {
+		t.Error("expected an error for an invalid CIDR")
+	}
+}
+
+func TestHostAllowed(t *testing.T) {
+	t.Parallel()
+	cfg := &Config{Hosts: []string{"devbox.internal", "100.64.0.7", "fd00::7"}}
+	for host, want := range map[string]bool{
+		"devbox.internal:7443": true,
+		"DEVBOX.internal":      true,
+		"100.64.0.7:7443":      true,
+		"[fd00::7]:7443":       true,
+		"attacker.example":     false,
+		"":                     false,
+	} {
+		if got := cfg.HostAllowed(host); got != want {
+			t.Errorf("HostAllowed(%q) = %v, want %v", host, got, want)
+		}
+	}
+}
+
+func TestEnabled(t *testing.T) {
+	t.Parallel()
+	var nilCfg *Config
+	if nilCfg.Enabled() || (&Config{}).Enabled() {
+		t.Error("an empty config must be disabled")
+	}
+	if !(&Config{ListenAddr: "0.0.0.0:7443"}).Enabled() {
+		t.Error("a config with a listen address must be enabled")
+	}
+}
+
+func TestLoadOrCreateCertificateReusesGeneratedPair(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	first, created, err := LoadOrCreateCertificate("", "", dir, []string{"devbox.internal", "100.64.0.7"})
+	if err != nil || !created {
+		t.Fatalf("first load: created=%v err=%v", created, err)
+	}
+	second, created, err := LoadOrCreateCertificate("", "", dir, nil)
+	if err != nil || created {
+		t.Fatalf("second load: created=%v err=%v", created, err)
+	}
+	if Fingerprint(first) != Fingerprint(second) {
+		t.Error("a stored certificate must be reused, not regenerated")
+	}
+	if !strings.HasPrefix(Fingerprint(first), "sha256:") {
+		t.Errorf("fingerprint = %q, want sha256: prefix", Fingerprint(first))
+	}
+
+	if _, _, err := LoadOrCreateCertificate("cert.pem", "", dir, nil); err == nil {
+		t.Error("expected an error when only the certificate file is given")
+	}
+}
+
+func TestPinnedClientConfig(t *testing.T) {
+	t.Parallel()
+	cert, _, err := LoadOrCreateCertificate("", "", t.TempDir(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	srv.TLS = &tls.Config{Certificates: []tls.Certificate{cert}, MinVersion: tls.VersionTLS12}
+	srv.StartTLS()
+	defer srv.Close()
+
+	get := func(fp string) error {
+		cfg, err := PinnedClientConfig(fp)
+		if err != nil {
+			return err
+		}
+		client := &http.Client{Transport: &http.Transport{TLSClientConfig: cfg}}
+		resp, err := client.Get(srv.URL)
+		if err != nil {
+			return err
+		}
+		return resp.Body.Close()
+	}
+
+	pinned := Fingerprint(cert)
+	if err := get(pinned); err != nil {
+		t.Fatalf("pinned fingerprint rejected: %v", err)
+	}
+	if err := get(strings.ToUpper(strings.TrimPrefix(pinned, "sha256:"))); err != nil {
+		t.Fatalf("bare uppercase fingerprint rejected: %v", err)
+	}
+	if err := get("sha256:" + strings.Repeat("00", 32)); err == nil {
+		t.Error("a mismatched fingerprint must fail the handshake")
+	}
+	if _, err := PinnedClientConfig("sha256:abc"); err == nil {
+		t.Error("expected an error for a short fingerprint")
+	}
+}
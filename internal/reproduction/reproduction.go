@@ -19,6 +19,18 @@ type Params struct {
 	BaseURL            string `json:"base_url"`
 	IncludeScreenshots bool   `json:"include_screenshots"`
 	ErrorMessage       string `json:"error_message"`
+	Boundary           string `json:"boundary"`
+
+	// StableLocators prefers element ids over role, label, and text locators.
+	StableLocators bool `json:"-"`
+	// LocatorText, when set, maps a locator's UI text to the JS expression used in its place.
+	LocatorText func(text string) string `json:"-"`
+	// Environment, when set, is replayed in a Playwright test.use block and noted in the Kaboom header.
+	Environment *capture.BrowserEnvironment `json:"-"`
+	// Device, when set, is the device emulated during capture; it replaces the measured viewport in test.use.
+	Device *capture.DeviceEmulation `json:"-"`
+	// Region, when set, holds the configure(override) geolocation, timezone, and locale replayed in test.use.
+	Region *capture.RegionOverride `json:"-"`
 }
 
 // Result is the response payload.
@@ -37,6 +49,11 @@ type Meta struct {
 	SelectorsUsed    []string `json:"selectors_used"`
 	ActionsAvailable int      `json:"actions_available"`
 	ActionsIncluded  int      `json:"actions_included"`
+	Boundary         string   `json:"boundary,omitempty"`
+
+	Environment *capture.BrowserEnvironment `json:"environment,omitempty"`
+	Device      *capture.DeviceEmulation    `json:"device,omitempty"`
+	Region      *capture.RegionOverride     `json:"region_override,omitempty"`
 }
 
 const maxReproOutputBytes = 200 * 1024 // 200KB cap
@@ -61,6 +78,20 @@ func ValidateOutputFormat(format string) string {
 	return ""
 }
 
+// FilterBoundary returns the actions tagged with boundary, or all if boundary is empty.
+func FilterBoundary(actions []capture.EnhancedAction, boundary string) []capture.EnhancedAction {
+	if boundary == "" {
+		return actions
+	}
+	out := make([]capture.EnhancedAction, 0, len(actions))
+	for _, a := range actions {
+		if capture.TaggedWith(a.TestIDs, boundary) {
+			out = append(out, a)
+		}
+	}
+	return out
+}
+
 // FilterLastN returns the last N actions, or all if lastN <= 0.
 func FilterLastN(actions []capture.EnhancedAction, lastN int) []capture.EnhancedAction {
 	if lastN > 0 && lastN < len(actions) {
@@ -97,6 +128,10 @@ func BuildResult(script string, params Params, actions, allActions []capture.Enh
 			SelectorsUsed:    collectSelectorTypes(actions),
 			ActionsAvailable: len(allActions),
 			ActionsIncluded:  len(actions),
+			Boundary:         params.Boundary,
+			Environment:      params.Environment,
+			Device:           params.Device,
+			Region:           params.Region,
 		},
 	}
 }
@@ -34,8 +34,33 @@ func writeKaboomHeader(b *strings.Builder, actions []capture.EnhancedAction, opt
 		desc = ChopString(opts.ErrorMessage, 80)
 	}
 	fmt.Fprintf(b, "# Reproduction: %s\n", desc)
-	fmt.Fprintf(b, "# Captured: %s | %d actions | %s\n\n",
+	fmt.Fprintf(b, "# Captured: %s | %d actions | %s\n",
 		time.Now().Format(time.RFC3339), len(actions), startURL)
+	if env := opts.Environment; env != nil {
+		fmt.Fprintf(b, "# Environment: %s\n", describeEnvironment(*env))
+	}
+	if opts.Device != nil {
+		fmt.Fprintf(b, "# Device: %s\n", opts.Device.Label())
+	}
+	if opts.Region != nil {
+		fmt.Fprintf(b, "# Overrides: %s\n", opts.Region.Describe())
+	}
+	b.WriteString("\n")
+}
+
+// describeEnvironment summarizes the recorded browser in one line: viewport, locale,
+// timezone, and user agent, skipping what was not reported.
+func describeEnvironment(env capture.BrowserEnvironment) string {
+	var parts []string
+	if env.ViewportWidth > 0 {
+		parts = append(parts, fmt.Sprintf("%dx%d @%gx", env.ViewportWidth, env.ViewportHeight, env.DevicePixelRatio))
+	}
+	for _, s := range []string{env.Locale, env.Timezone, env.UserAgent} {
+		if s != "" {
+			parts = append(parts, s)
+		}
+	}
+	return strings.Join(parts, " | ")
 }
 
 func writeKaboomSteps(b *strings.Builder, actions []capture.EnhancedAction, opts Params) {
@@ -29,7 +29,10 @@ func GeneratePlaywrightScript(actions []capture.EnhancedAction, opts Params) str
 }
 
 func writePlaywrightHeader(b *strings.Builder, opts Params) {
-	b.WriteString("import { test, expect } from '@playwright/test';\n\n")
+	b.WriteString(PlaywrightImport(opts.Device) + "\n\n")
+	if use := PlaywrightUseBlock(opts.Environment, opts.Device, opts.Region, true); use != "" {
+		b.WriteString(use + "\n")
+	}
 	testName := "reproduction: captured user actions"
 	if opts.ErrorMessage != "" {
 		testName = "reproduction: " + ChopString(opts.ErrorMessage, 80)
@@ -56,23 +59,123 @@ func writePlaywrightFooter(b *strings.Builder, opts Params) {
 	b.WriteString("});\n")
 }
 
+// PlaywrightImport is the @playwright/test import line; devices is imported when the
+// session emulated a named preset.
+func PlaywrightImport(device *capture.DeviceEmulation) string {
+	if device != nil && device.Device != "" {
+		return "import { test, expect, devices } from '@playwright/test';"
+	}
+	return "import { test, expect } from '@playwright/test';"
+}
+
+// PlaywrightUseBlock renders the recorded browser environment as a top-level test.use
+// block, or "" when nothing was recorded. An emulated device replaces the measured viewport
+// and region overrides replace the measured locale and timezone. withLocale=false leaves
+// the locale to a per-locale test.use further down.
+func PlaywrightUseBlock(env *capture.BrowserEnvironment, device *capture.DeviceEmulation, region *capture.RegionOverride, withLocale bool) string {
+	if env == nil && device == nil && region == nil {
+		return ""
+	}
+	var opts []string
+	if device != nil {
+		opts = playwrightDeviceOptions(*device)
+	} else {
+		if env.UserAgent != "" {
+			opts = append(opts, fmt.Sprintf("userAgent: '%s'", EscapeJS(env.UserAgent)))
+		}
+		if env.ViewportWidth > 0 && env.ViewportHeight > 0 {
+			opts = append(opts, fmt.Sprintf("viewport: { width: %d, height: %d }", env.ViewportWidth, env.ViewportHeight))
+		}
+		if env.DevicePixelRatio > 0 {
+			opts = append(opts, fmt.Sprintf("deviceScaleFactor: %g", env.DevicePixelRatio))
+		}
+	}
+	if env == nil {
+		env = &capture.BrowserEnvironment{}
+	}
+	if region == nil {
+		region = &capture.RegionOverride{}
+	}
+	locale, timezone := firstNonEmpty(region.Locale, env.Locale), firstNonEmpty(region.Timezone, env.Timezone)
+	if withLocale && locale != "" {
+		opts = append(opts, fmt.Sprintf("locale: '%s'", EscapeJS(locale)))
+	}
+	if timezone != "" {
+		opts = append(opts, fmt.Sprintf("timezoneId: '%s'", EscapeJS(timezone)))
+	}
+	if g := region.Geolocation; g != nil {
+		geo := fmt.Sprintf("latitude: %g, longitude: %g", g.Latitude, g.Longitude)
+		if g.Accuracy > 0 {
+			geo += fmt.Sprintf(", accuracy: %g", g.Accuracy)
+		}
+		opts = append(opts, "geolocation: { "+geo+" }", "permissions: ['geolocation']")
+	}
+	if len(opts) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString("// Browser environment recorded during capture")
+	if env.ExtensionVersion != "" {
+		fmt.Fprintf(&b, " (Kaboom extension %s)", env.ExtensionVersion)
+	}
+	b.WriteString(".\n")
+	if device != nil {
+		fmt.Fprintf(&b, "// Device emulated with interact(emulate): %s.\n", device.Label())
+	}
+	if !region.IsZero() {
+		fmt.Fprintf(&b, "// Overrides set with configure(override): %s.\n", region.Describe())
+	}
+	b.WriteString("test.use({\n")
+	for _, o := range opts {
+		b.WriteString("  " + o + ",\n")
+	}
+	b.WriteString("});\n")
+	return b.String()
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// playwrightDeviceOptions replays an emulated device: the Playwright descriptor for a
+// preset, explicit metrics for a custom device. Either replaces the measured viewport.
+func playwrightDeviceOptions(device capture.DeviceEmulation) []string {
+	if device.Device != "" {
+		return []string{fmt.Sprintf("...devices['%s']", EscapeJS(device.Device))}
+	}
+	opts := []string{
+		fmt.Sprintf("viewport: { width: %d, height: %d }", device.Width, device.Height),
+		fmt.Sprintf("deviceScaleFactor: %g", device.DevicePixelRatio),
+	}
+	if device.UserAgent != "" {
+		opts = append(opts, fmt.Sprintf("userAgent: '%s'", EscapeJS(device.UserAgent)))
+	}
+	return append(opts, fmt.Sprintf("hasTouch: %t", device.Touch), fmt.Sprintf("isMobile: %t", device.Mobile))
+}
+
 // PlaywrightStep converts a single action to a Playwright code line.
 func PlaywrightStep(action capture.EnhancedAction, opts Params) string {
 	switch action.Type {
 	case "navigate":
 		return pwNavigateStep(action, opts)
 	case "click":
-		return pwLocatorAction(action, "click", "click")
+		return pwLocatorAction(action, opts, "click", "click")
 	case "input":
-		return pwInputStep(action)
+		return pwInputStep(action, opts)
 	case "select":
-		return pwSelectStep(action)
+		return pwSelectStep(action, opts)
 	case "keypress":
 		return fmt.Sprintf("await page.keyboard.press('%s');", EscapeJS(action.Key))
 	case "scroll":
 		return fmt.Sprintf("// Scroll to y=%d", action.ScrollY)
 	case "scroll_element":
-		return pwLocatorAction(action, "scrollIntoViewIfNeeded", "scroll element into view")
+		return pwLocatorAction(action, opts, "scrollIntoViewIfNeeded", "scroll element into view")
 	case "refresh":
 		return "await page.reload();"
 	case "back":
@@ -82,7 +185,7 @@ func PlaywrightStep(action capture.EnhancedAction, opts Params) string {
 	case "new_tab":
 		return pwNewTabStep(action, opts)
 	case "focus":
-		return pwLocatorAction(action, "focus", "focus")
+		return pwLocatorAction(action, opts, "focus", "focus")
 	default:
 		return ""
 	}
@@ -110,16 +213,25 @@ func pwNewTabStep(action capture.EnhancedAction, opts Params) string {
 	return fmt.Sprintf("// Open new tab: %s", EscapeJS(targetURL))
 }
 
-func pwLocatorAction(action capture.EnhancedAction, actionName, fallbackLabel string) string {
-	loc := PlaywrightLocator(action.Selectors)
+// StepLocator returns the locator a step uses for the action's element under opts.
+func StepLocator(action capture.EnhancedAction, opts Params) string {
+	loc := ChooseLocator(action.Selectors, opts.StableLocators)
+	if opts.LocatorText != nil && loc.Text != "" {
+		return loc.Expr(opts.LocatorText(loc.Text))
+	}
+	return loc.Expr("")
+}
+
+func pwLocatorAction(action capture.EnhancedAction, opts Params, actionName, fallbackLabel string) string {
+	loc := StepLocator(action, opts)
 	if loc == "" {
 		return fmt.Sprintf("// %s - no selector available", fallbackLabel)
 	}
 	return fmt.Sprintf("await page.%s.%s();", loc, actionName)
 }
 
-func pwInputStep(action capture.EnhancedAction) string {
-	loc := PlaywrightLocator(action.Selectors)
+func pwInputStep(action capture.EnhancedAction, opts Params) string {
+	loc := StepLocator(action, opts)
 	if loc == "" {
 		return "// input - no selector available"
 	}
@@ -130,8 +242,8 @@ func pwInputStep(action capture.EnhancedAction) string {
 	return fmt.Sprintf("await page.%s.fill('%s');", loc, EscapeJS(value))
 }
 
-func pwSelectStep(action capture.EnhancedAction) string {
-	loc := PlaywrightLocator(action.Selectors)
+func pwSelectStep(action capture.EnhancedAction, opts Params) string {
+	loc := StepLocator(action, opts)
 	if loc == "" {
 		return "// select - no selector available"
 	}
@@ -70,52 +70,95 @@ func describeWithoutRole(testID, text, ariaLabel, id, cssPath string) string {
 	return "(unknown element)"
 }
 
-type pwLocatorCandidate struct {
-	value  string
-	format func(string) string
+// Locator kinds. Role, label, and text locators match UI text, so they only
+// resolve in the locale the page was recorded in.
+const (
+	LocatorTestID = "testId"
+	LocatorRole   = "role"
+	LocatorLabel  = "label"
+	LocatorText   = "text"
+	LocatorID     = "id"
+	LocatorCSS    = "css"
+)
+
+// Locator is the Playwright locator chosen for one element.
+type Locator struct {
+	Kind string
+	// Value is the test id, element id, or CSS path for locale-independent kinds,
+	// and the ARIA role for role locators.
+	Value string
+	// Text is the UI text the locator matches (role name, aria-label, or visible
+	// text). Empty when the locator does not depend on the page language.
+	Text string
+}
+
+// Expr renders the locator as a Playwright call. A non-empty textExpr is a JS
+// expression used in place of the literal Text, e.g. a per-locale string lookup.
+func (l Locator) Expr(textExpr string) string {
+	text := textExpr
+	if text == "" && l.Text != "" {
+		text = "'" + EscapeJS(l.Text) + "'"
+	}
+	switch l.Kind {
+	case LocatorTestID:
+		return fmt.Sprintf("getByTestId('%s')", EscapeJS(l.Value))
+	case LocatorRole:
+		if text == "" {
+			return fmt.Sprintf("getByRole('%s')", EscapeJS(l.Value))
+		}
+		return fmt.Sprintf("getByRole('%s', { name: %s })", EscapeJS(l.Value), text)
+	case LocatorLabel:
+		return fmt.Sprintf("getByLabel(%s)", text)
+	case LocatorText:
+		return fmt.Sprintf("getByText(%s)", text)
+	case LocatorID:
+		return fmt.Sprintf("locator('#%s')", EscapeJS(l.Value))
+	case LocatorCSS:
+		return fmt.Sprintf("locator('%s')", EscapeJS(l.Value))
+	}
+	return ""
 }
 
 // PlaywrightLocator returns the best Playwright locator string for a selector map.
 // Priority: testId > role > ariaLabel > text > id > cssPath
 func PlaywrightLocator(selectors map[string]any) string {
+	return ChooseLocator(selectors, false).Expr("")
+}
+
+// ChooseLocator picks the locator for a selector map. With stable set, element ids
+// rank above role, label, and text so the locator survives a UI language change.
+// Priority: testId > role > ariaLabel > text > id > cssPath
+// Stable:   testId > id > role > ariaLabel > text > cssPath
+func ChooseLocator(selectors map[string]any, stable bool) Locator {
 	if selectors == nil {
-		return ""
+		return Locator{}
 	}
-
-	// Role has special handling for optional name parameter.
+	testID := selectorStr(selectors, "testId")
+	id := selectorStr(selectors, "id")
 	role, roleName := selectorRole(selectors)
-	if loc := pwRoleLocator(role, roleName); loc != "" {
-		// Role is priority 2; check testId first.
-		testID := selectorStr(selectors, "testId")
-		if testID != "" {
-			return fmt.Sprintf("getByTestId('%s')", EscapeJS(testID))
-		}
-		return loc
-	}
 
-	candidates := []pwLocatorCandidate{
-		{selectorStr(selectors, "testId"), func(v string) string { return fmt.Sprintf("getByTestId('%s')", EscapeJS(v)) }},
-		{selectorStr(selectors, "ariaLabel"), func(v string) string { return fmt.Sprintf("getByLabel('%s')", EscapeJS(v)) }},
-		{selectorStr(selectors, "text"), func(v string) string { return fmt.Sprintf("getByText('%s')", EscapeJS(v)) }},
-		{selectorStr(selectors, "id"), func(v string) string { return fmt.Sprintf("locator('#%s')", EscapeJS(v)) }},
-		{selectorStr(selectors, "cssPath"), func(v string) string { return fmt.Sprintf("locator('%s')", EscapeJS(v)) }},
+	candidates := []Locator{{Kind: LocatorTestID, Value: testID}}
+	if stable {
+		candidates = append(candidates, Locator{Kind: LocatorID, Value: id})
 	}
-	for _, c := range candidates {
-		if c.value != "" {
-			return c.format(c.value)
-		}
+	if role != "" {
+		candidates = append(candidates, Locator{Kind: LocatorRole, Value: role, Text: roleName})
 	}
-	return ""
-}
-
-func pwRoleLocator(role, roleName string) string {
-	if role == "" {
-		return ""
+	candidates = append(candidates,
+		Locator{Kind: LocatorLabel, Text: selectorStr(selectors, "ariaLabel")},
+		Locator{Kind: LocatorText, Text: selectorStr(selectors, "text")},
+	)
+	if !stable {
+		candidates = append(candidates, Locator{Kind: LocatorID, Value: id})
 	}
-	if roleName != "" {
-		return fmt.Sprintf("getByRole('%s', { name: '%s' })", EscapeJS(role), EscapeJS(roleName))
+	candidates = append(candidates, Locator{Kind: LocatorCSS, Value: selectorStr(selectors, "cssPath")})
+
+	for _, c := range candidates {
+		if c.Value != "" || c.Text != "" {
+			return c
+		}
 	}
-	return fmt.Sprintf("getByRole('%s')", EscapeJS(role))
+	return Locator{}
 }
 
 // selectorStr extracts a string value from the selectors map.
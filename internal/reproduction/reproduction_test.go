@@ -381,6 +381,24 @@ func TestReproduction_Playwright_LocatorPriority(t *testing.T) {
 	}
 }
 
+func TestChooseLocator_Stable(t *testing.T) {
+	t.Parallel()
+
+	sels := map[string]any{"role": map[string]any{"role": "button", "name": "Senden"}, "text": "Senden", "id": "send"}
+	if loc := ChooseLocator(sels, false); loc.Kind != LocatorRole || loc.Text != "Senden" {
+		t.Errorf("ChooseLocator(auto) = %+v, want role with text", loc)
+	}
+	loc := ChooseLocator(sels, true)
+	if loc.Kind != LocatorID || loc.Text != "" || loc.Expr("") != "locator('#send')" {
+		t.Errorf("ChooseLocator(stable) = %+v, want locale-independent id", loc)
+	}
+
+	role := ChooseLocator(map[string]any{"role": map[string]any{"role": "button", "name": "Senden"}}, true)
+	if got := role.Expr("t.send"); got != "getByRole('button', { name: t.send })" {
+		t.Errorf("Expr(text expression) = %q", got)
+	}
+}
+
 func TestReproduction_Playwright_URLRewriting(t *testing.T) {
 	t.Parallel()
 	actions := []capture.EnhancedAction{
@@ -10,13 +10,14 @@ func analyzeToolSchema() mcp.MCPTool {
 	return mcp.MCPTool{
 		Name:        "analyze",
 		Description: "Trigger active analysis. Creates async queries the extension executes.\n\nSynchronous Mode (Default): Tools block until the extension returns a result (up to 15s). Set background:true to return immediately with a correlation_id, then poll with observe(what='command_result', correlation_id=...).\n\nDraw Mode: Use annotations to get all annotations from the last draw mode session. Use annotation_detail with correlation_id to get full computed styles and DOM detail for a specific annotation.\n\nUse summary:true on supported modes for compact token-efficient responses.",
+		Annotations: &mcp.MCPToolAnnotations{ReadOnlyHint: false, DestructiveHint: false, IdempotentHint: false, OpenWorldHint: true},
 		InputSchema: map[string]any{
 			"type": "object",
 			"properties": map[string]any{
 				"what": map[string]any{
 					"type":        "string",
 					"description": "Analysis mode to run against the page",
-					"enum":        []string{"dom", "performance", "accessibility", "error_clusters", "navigation_patterns", "security_audit", "third_party_audit", "link_health", "link_validation", "page_summary", "annotations", "annotation_detail", "api_validation", "draw_history", "draw_session", "computed_styles", "forms", "form_state", "form_validation", "data_table", "visual_baseline", "visual_diff", "visual_baselines", "navigation", "page_structure", "audit", "feature_gates", "page_issues"},
+					"enum":        []string{"dom", "performance", "accessibility", "error_clusters", "navigation_patterns", "security_audit", "third_party_audit", "link_health", "link_validation", "page_summary", "annotations", "annotation_detail", "api_validation", "draw_history", "draw_session", "computed_styles", "styles", "i18n", "forms", "form_state", "form_validation", "data_table", "visual_baseline", "visual_diff", "visual_baselines", "navigation", "page_structure", "audit", "feature_gates", "page_issues"},
 				},
 				"telemetry_mode": map[string]any{
 					"type":        "string",
@@ -25,7 +26,17 @@ func analyzeToolSchema() mcp.MCPTool {
 				},
 				"selector": map[string]any{
 					"type":        "string",
-					"description": "CSS selector (dom, accessibility, computed_styles, forms, form_state, data_table)",
+					"description": "CSS selector (dom, accessibility, computed_styles, styles, forms, form_state, data_table)",
+				},
+				"properties": map[string]any{
+					"type":        "array",
+					"description": "CSS properties to return instead of the default set (computed_styles, styles)",
+					"items":       map[string]any{"type": "string"},
+				},
+				"markers": map[string]any{
+					"type":        "array",
+					"description": "Attributes that mark translated text, replacing the defaults data-i18n, data-i18n-key, data-translate, data-l10n-id, data-t, i18n, v-t (i18n)",
+					"items":       map[string]any{"type": "string"},
 				},
 				"frame": map[string]any{
 					"description": "Target iframe: CSS selector, 0-based index, or \"all\" (dom, accessibility)",
@@ -100,11 +111,21 @@ func analyzeToolSchema() mcp.MCPTool {
 					"description": "Min severity (security_audit)",
 					"enum":        []string{"critical", "high", "medium", "low", "info"},
 				},
+				"category": map[string]any{
+					"type":        "string",
+					"description": "Focused audit (security_audit): csp_diff diffs the served CSP against the policy observed traffic needs",
+					"enum":        []string{"csp_diff"},
+				},
 				"first_party_origins": map[string]any{
 					"type":        "array",
 					"description": "First-party origins (third_party_audit)",
 					"items":       map[string]any{"type": "string"},
 				},
+				"first_party_suffixes": map[string]any{
+					"type":        "array",
+					"description": "Domains treated as first-party with all subdomains, e.g. myapp-cdn.net; page hosts are already grouped by eTLD+1 (security_audit, third_party_audit)",
+					"items":       map[string]any{"type": "string"},
+				},
 				"include_static": map[string]any{
 					"type":        "boolean",
 					"description": "Include static-only origins (third_party_audit)",
@@ -9,7 +9,8 @@ import "github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/mcp"
 func configureToolSchema() mcp.MCPTool {
 	return mcp.MCPTool{
 		Name:        "configure",
-		Description: "Session settings and utilities.\n\nSession: store, load, clear, telemetry, security_mode.\nDiagnostics: health, doctor, restart, audit_log, describe_capabilities, report_issue.\nRecording: event_recording_start/stop, playback, log_diff, network_recording.\nSequences: save/get/list/delete/replay_sequence.\nNoise & streaming: noise_rule, streaming, action_jitter.\nTesting: test_boundary_start/end.\nQuality: setup_quality_gates.\nHelp: tutorial, examples, diff_sessions.\n\nDiscovery: describe_capabilities — list available modes and per-mode parameters for any tool. Filter with tool and mode params, e.g. configure(what:'describe_capabilities', tool:'observe', mode:'errors') returns only the params relevant to that mode.",
+		Description: "Session settings and utilities.\n\nSession: store, load, clear, telemetry, security_mode, retention.\nDiagnostics: health, doctor, restart, audit_log, describe_capabilities, report_issue, rate_limit.\nAPI contracts: lock_api_contract.\nFindings: finding_state.\nRecording: event_recording_start/stop, playback, log_diff, network_recording.\nSequences: save/get/list/delete/replay_sequence.\nNoise & streaming: noise_rules, streaming, subscribe, silence, action_jitter.\nTesting: test_boundary_start/end.\nQuality: setup_quality_gates.\nHelp: tutorial, examples, diff_sessions.\n\nDiscovery: describe_capabilities — list available modes and per-mode parameters for any tool. Filter with tool and mode params, e.g. configure(what:'describe_capabilities', tool:'observe', mode:'errors') returns only the params relevant to that mode.",
+		Annotations: &mcp.MCPToolAnnotations{ReadOnlyHint: false, DestructiveHint: true, IdempotentHint: false, OpenWorldHint: false},
 		InputSchema: map[string]any{
 			"type":       "object",
 			"properties": configureToolProperties(),
@@ -7,7 +7,7 @@ func configureCoreProperties() map[string]any {
 		"what": map[string]any{
 			"type":        "string",
 			"description": "Setting or utility to configure",
-			"enum":        []string{"store", "load", "noise_rule", "clear", "health", "tutorial", "examples", "streaming", "test_boundary_start", "test_boundary_end", "event_recording_start", "event_recording_stop", "playback", "log_diff", "telemetry", "describe_capabilities", "diff_sessions", "audit_log", "restart", "save_sequence", "get_sequence", "list_sequences", "delete_sequence", "replay_sequence", "doctor", "security_mode", "network_recording", "action_jitter", "report_issue", "setup_quality_gates"},
+			"enum":        []string{"store", "load", "noise_rule", "clear", "health", "tutorial", "examples", "streaming", "test_boundary_start", "test_boundary_end", "event_recording_start", "event_recording_stop", "playback", "log_diff", "telemetry", "describe_capabilities", "diff_sessions", "audit_log", "restart", "save_sequence", "get_sequence", "list_sequences", "delete_sequence", "replay_sequence", "doctor", "security_mode", "network_recording", "action_jitter", "report_issue", "setup_quality_gates", "silence", "subscribe", "rate_limit", "body_limits", "screenshot_redaction", "lock_api_contract", "finding_state", "add_webhook", "list_webhooks", "remove_webhook", "invariant", "mock_request", "network_budget", "dedup", "multi_tab_capture", "override", "list_states", "define_login", "noise_rules", "retention", "register_proto", "circuit", "ws_sampling", "archive"},
 		},
 		"action": map[string]any{
 			"type":        "string",
@@ -56,7 +56,7 @@ func configureCoreProperties() map[string]any {
 		"noise_action": map[string]any{
 			"type":        "string",
 			"description": "Noise operation (default: list)",
-			"enum":        []string{"add", "remove", "list", "reset", "auto_detect"},
+			"enum":        []string{"add", "remove", "list", "move", "dry_run", "reset", "auto_detect"},
 			"default":     "list",
 		},
 		"rules": map[string]any{
@@ -82,7 +82,7 @@ func configureCoreProperties() map[string]any {
 		},
 		"method": map[string]any{
 			"type":        "string",
-			"description": "HTTP method filter (noise_action=add, network_recording)",
+			"description": "HTTP method filter (noise_action=add, network_recording), or the method a mock applies to; omit for any (mock_request)",
 		},
 		"domain": map[string]any{
 			"type":        "string",
@@ -102,11 +102,28 @@ func configureCoreProperties() map[string]any {
 		},
 		"rule_id": map[string]any{
 			"type":        "string",
-			"description": "Rule ID to remove",
+			"description": "Rule ID to remove or move",
+		},
+		"rule_action": map[string]any{
+			"type":        "string",
+			"description": "What a matching rule does (default: drop). downgrade sets console entries to debug; tag labels them. Single-rule flattening helper for noise_action=add",
+			"enum":        []string{"drop", "downgrade", "tag"},
+		},
+		"tag": map[string]any{
+			"type":        "string",
+			"description": "Label for rule_action=tag (default: the rule's classification)",
+		},
+		"position": map[string]any{
+			"type":        "integer",
+			"description": "Zero-based evaluation position among user rules for noise_action=move (0 = evaluated first)",
+		},
+		"list": map[string]any{
+			"type":        "boolean",
+			"description": "List rules with how many buffered entries each currently matches (noise_rules; same as noise_action=list)",
 		},
 		"pattern": map[string]any{
 			"type":        "string",
-			"description": "Regex pattern (single-rule flattening helper for noise_action=add)",
+			"description": "Regex pattern (single-rule flattening helper for noise_action=add), or the URL substring or whole-URL glob with * a budget applies to (network_budget)",
 		},
 		"category": map[string]any{
 			"type":        "string",
@@ -116,7 +133,7 @@ func configureCoreProperties() map[string]any {
 		},
 		"reason": map[string]any{
 			"type":        "string",
-			"description": "Why this is noise",
+			"description": "Why this is noise (noise_rule), why alerts are silenced (silence), or a note on a finding state change (finding_state)",
 		},
 	}
 }
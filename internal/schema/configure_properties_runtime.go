@@ -6,13 +6,17 @@ func configureRuntimeProperties() map[string]any {
 	return map[string]any{
 		"buffer": map[string]any{
 			"type":        "string",
-			"description": "Buffer to clear (clear). Use 'all' to reset everything",
-			"enum":        []string{"network", "websocket", "actions", "logs", "inbox", "all"},
+			"description": "Buffer to clear (clear; 'all' resets everything), or to tune (retention: console, network, websocket, actions, performance)",
+			"enum":        []string{"network", "websocket", "sse", "actions", "logs", "inbox", "all", "console", "performance"},
 		},
 		"tab_id": map[string]any{
 			"type":        "number",
 			"description": "Target tab ID",
 		},
+		"enabled": map[string]any{
+			"type":        "boolean",
+			"description": "Turn a toggle on or off; omit to read the state. dedup: collapse consecutive identical log entries. multi_tab_capture: capture from every tab, not just the tracked one. archive: roll buffers into the session archive on clear and shutdown",
+		},
 		"verif_session_action": map[string]any{
 			"type":        "string",
 			"description": "Session verification operation (diff_sessions)",
@@ -20,7 +24,7 @@ func configureRuntimeProperties() map[string]any {
 		},
 		"name": map[string]any{
 			"type":        "string",
-			"description": "Name for recording, snapshot, or sequence (event_recording_start, diff_sessions, save/get/delete/replay_sequence)",
+			"description": "Name for recording, snapshot, sequence, or login recipe (event_recording_start, diff_sessions, save/get/delete/replay_sequence, define_login)",
 		},
 		"compare_a": map[string]any{
 			"type":        "string",
@@ -32,7 +36,7 @@ func configureRuntimeProperties() map[string]any {
 		},
 		"url": map[string]any{
 			"type":        "string",
-			"description": "URL filter for snapshot capture (diff_sessions)",
+			"description": "URL filter for snapshot capture (diff_sessions), the endpoint to POST alerts to (add_webhook), or the WebSocket URL substring a descriptor applies to (register_proto)",
 		},
 		"recording_id": map[string]any{
 			"type":        "string",
@@ -48,8 +52,21 @@ func configureRuntimeProperties() map[string]any {
 		},
 		"operation": map[string]any{
 			"type":        "string",
-			"description": "Sub-operation: audit_log (analyze/report/clear), network_recording (start/stop/status), report_issue (list_templates/preview/submit)",
-			"enum":        []string{"analyze", "report", "clear", "start", "stop", "status", "list_templates", "preview", "submit"},
+			"description": "Sub-operation: audit_log (analyze/report/clear), network_recording (start/stop/status), report_issue (list_templates/preview/submit), silence (start/status/clear), rate_limit (status/set/clear), body_limits (status/set/clear), retention (status/set/clear), archive (status/set/clear), register_proto (register/list/clear), circuit (status/set/clear), ws_sampling (list/set/remove/clear), screenshot_redaction (status/set/clear), lock_api_contract (lock/status/clear), invariant (add/list/remove/clear), mock_request (add/list/remove/clear), network_budget (add/list/remove/clear), override (set/status/clear), define_login (add/list/remove)",
+			"enum":        []string{"analyze", "report", "clear", "start", "stop", "status", "list_templates", "preview", "submit", "set", "lock", "add", "list", "remove", "register"},
+		},
+		"duration": map[string]any{
+			"type":        "string",
+			"description": "Silence window as a Go duration, e.g. '30m' or '2h', max 24h (silence)",
+		},
+		"categories": map[string]any{
+			"type":        "array",
+			"items":       map[string]any{"type": "string"},
+			"description": "Alert categories to silence, e.g. regression, anomaly, ci, noise, threshold. Omit to silence all (silence)",
+		},
+		"silence_id": map[string]any{
+			"type":        "string",
+			"description": "Silence to end with operation=clear; omit to clear all (silence)",
 		},
 		"template": map[string]any{
 			"type":        "string",
@@ -88,9 +105,9 @@ func configureRuntimeProperties() map[string]any {
 			"type": "array",
 			"items": map[string]any{
 				"type": "string",
-				"enum": []string{"errors", "network_errors", "performance", "user_frustration", "security", "regression", "anomaly", "ci", "all"},
+				"enum": []string{"errors", "network_errors", "performance", "user_frustration", "security", "regression", "anomaly", "ci", "console_error", "network_error", "ci_result", "circuit_opened", "circuit_closed", "contract_violation", "extension_reconnected", "error_cluster", "security_finding", "perf_regression", "all"},
 			},
-			"description": "Event categories to stream",
+			"description": "Event categories to stream (streaming), SSE push events for this client (subscribe: console_error, network_error, ci_result, circuit_opened, circuit_closed, contract_violation, extension_reconnected, all), or alerts to deliver (add_webhook: circuit_opened, error_cluster, security_finding, perf_regression, all)",
 		},
 		"throttle_seconds": map[string]any{
 			"type":        "integer",
@@ -98,6 +115,188 @@ func configureRuntimeProperties() map[string]any {
 			"maximum":     60,
 			"description": "Min seconds between notifications",
 		},
+		"calls_per_minute": map[string]any{
+			"type":        "integer",
+			"minimum":     0,
+			"description": "Tool calls allowed per rolling minute; 0 = unlimited (rate_limit)",
+		},
+		"hourly_quota": map[string]any{
+			"type":        "integer",
+			"minimum":     0,
+			"description": "Tool calls allowed per rolling hour; 0 = unlimited (rate_limit)",
+		},
+		"request_body_max": map[string]any{
+			"type":        "integer",
+			"minimum":     1,
+			"description": "Bytes of each request body kept inline; longer bodies are truncated with a full_body_ref (body_limits, default 8192)",
+		},
+		"response_body_max": map[string]any{
+			"type":        "integer",
+			"minimum":     1,
+			"description": "Bytes of each response body kept inline; longer bodies are truncated with a full_body_ref (body_limits, default 16384)",
+		},
+		"capture_body_max": map[string]any{
+			"type":        "integer",
+			"minimum":     1,
+			"description": "Characters per body the extension captures and sends, up to 1048576; the part past the inline limits is kept for full_body fetch (body_limits, default 65536)",
+		},
+		"binary_capture_max": map[string]any{
+			"type":        "integer",
+			"minimum":     0,
+			"maximum":     262144,
+			"description": "Bytes of each binary fetch response the extension captures base64-encoded, for binary_preview and include_binary; 0 keeps only a size placeholder (body_limits, default 0)",
+		},
+		"path": map[string]any{
+			"type":        "string",
+			"description": "FileDescriptorSet file built with protoc --descriptor_set_out=<file> --include_imports, up to 4 MB (register_proto)",
+		},
+		"proto_message": map[string]any{
+			"type":        "string",
+			"description": "Message type binary WebSocket frames decode as, full or short name; omit when the set defines one message (register_proto)",
+		},
+		"max_entries": map[string]any{
+			"type":        "integer",
+			"minimum":     1,
+			"maximum":     100000,
+			"description": "Entries the buffer keeps before evicting the oldest (retention); archived sessions kept, max 500 (archive)",
+		},
+		"ttl": map[string]any{
+			"type":        "string",
+			"description": "Maximum entry age as a Go duration, e.g. '30m' or '2h', min 1m; '0' keeps entries until evicted by count (retention). For archive: how long archived sessions are kept, min 1h. For define_login: how long the state saved after login stays loadable, 1m to 720h, default 24h",
+		},
+		"url_pattern": map[string]any{
+			"type":        "string",
+			"description": "Request URL substring, or a whole-URL glob when it contains * (mock_request). WebSocket URL substring a sampling policy applies to; empty matches every connection (ws_sampling)",
+		},
+		"rate": map[string]any{
+			"type":        "number",
+			"minimum":     0,
+			"description": "Messages kept per second on each matching connection; 0 keeps only always_keep events (ws_sampling)",
+		},
+		"always_keep": map[string]any{
+			"type":        "array",
+			"items":       map[string]any{"type": "string", "enum": []string{"open", "close", "error", "incoming", "outgoing"}},
+			"description": "Event types or message directions never dropped by the policy; default open, close, error (ws_sampling)",
+		},
+		"status": map[string]any{
+			"type":        "integer",
+			"minimum":     0,
+			"maximum":     599,
+			"description": "Mocked response status, default 200; 0 simulates a network error (mock_request)",
+		},
+		"body": map[string]any{
+			"type":        "string",
+			"description": "Mocked response body; a JSON value is also accepted and defaults Content-Type to application/json (mock_request)",
+		},
+		"headers": map[string]any{
+			"type":                 "object",
+			"additionalProperties": map[string]any{"type": "string"},
+			"description":          "Mocked response headers (mock_request)",
+		},
+		"delay_ms": map[string]any{
+			"type":        "integer",
+			"minimum":     0,
+			"maximum":     60000,
+			"description": "Delay before the mocked response is delivered (mock_request)",
+		},
+		"geolocation": map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"lat":      map[string]any{"type": "number", "minimum": -90, "maximum": 90},
+				"lng":      map[string]any{"type": "number", "minimum": -180, "maximum": 180},
+				"accuracy": map[string]any{"type": "number", "minimum": 0},
+			},
+			"description": "Position reported to navigator.geolocation: {lat, lng, accuracy in meters} (override)",
+		},
+		"timezone": map[string]any{
+			"type":        "string",
+			"description": "IANA timezone for the page, e.g. Europe/Berlin; empty string drops it (override)",
+		},
+		"locale": map[string]any{
+			"type":        "string",
+			"description": "BCP 47 locale for Intl and Accept-Language, e.g. de-DE; empty string drops it (override)",
+		},
+		"origin": map[string]any{
+			"type":        "string",
+			"description": "Only list vault states for this origin, e.g. https://app.example.com (list_states)",
+		},
+		"vars": map[string]any{
+			"type":                 "object",
+			"description":          "Default values for {{var}} placeholders in login recipe steps (define_login)",
+			"additionalProperties": map[string]any{"type": "string"},
+		},
+		"mock_id": map[string]any{
+			"type":        "string",
+			"description": "Mock to remove (mock_request operation=remove)",
+		},
+		"max_duration_ms": map[string]any{
+			"type":        "integer",
+			"minimum":     0,
+			"description": "Slowest allowed response for the pattern in ms; 0 skips the check (network_budget)",
+		},
+		"max_size_kb": map[string]any{
+			"type":        "number",
+			"minimum":     0,
+			"description": "Largest allowed response for the pattern in KB; 0 skips the check (network_budget)",
+		},
+		"budget_id": map[string]any{
+			"type":        "string",
+			"description": "Budget to remove (network_budget operation=remove)",
+		},
+		"selectors": map[string]any{
+			"type":        "array",
+			"items":       map[string]any{"type": "string"},
+			"description": "CSS selectors whose elements are blacked out on every screenshot before it is saved; replaces the current list (screenshot_redaction)",
+		},
+		"endpoint": map[string]any{
+			"type":        "string",
+			"description": "Endpoint as 'METHOD /path' or a request URL; GET when no method is given, IDs normalize to {id}. Omit with operation=clear to unlock all (lock_api_contract)",
+		},
+		"finding_id": map[string]any{
+			"type":        "string",
+			"description": "Tracked finding id from observe(what='findings'), e.g. security.missing_csp@3f9a1c2b (finding_state)",
+		},
+		"state": map[string]any{
+			"type":        "string",
+			"description": "New lifecycle state; regressed is set only by audits (finding_state: open, acknowledged, fixed). Override for the capture circuit breaker (circuit: open, closed, auto)",
+			"enum":        []string{"open", "acknowledged", "fixed", "closed", "auto"},
+		},
+		"max_events_per_window": map[string]any{
+			"type":        "integer",
+			"minimum":     1,
+			"description": "Ingested events per 1-second window before batches get 429s; 5 windows in a row open the circuit (circuit)",
+		},
+		"memory_limit_mb": map[string]any{
+			"type":        "integer",
+			"minimum":     0,
+			"description": "Capture memory in MB that opens the circuit; 0 = no memory trip (circuit)",
+		},
+		"format": map[string]any{
+			"type":        "string",
+			"description": "Webhook payload format; inferred from Slack and Discord URLs, otherwise json (add_webhook)",
+			"enum":        []string{"json", "slack", "discord"},
+		},
+		"webhook_id": map[string]any{
+			"type":        "string",
+			"description": "Webhook id from add_webhook or list_webhooks (remove_webhook)",
+		},
+		"expr": map[string]any{
+			"type":        "string",
+			"description": "Standing assertion checked on every ingested batch, e.g. 'no network request to prod-api.com', 'no network status >= 500', 'no console error matching ^Uncaught' (invariant)",
+		},
+		"scope": map[string]any{
+			"type":        "string",
+			"description": "Where the invariant applies: session (default, every page) or page (the tracked page's origin) (invariant)",
+			"enum":        []string{"session", "page"},
+		},
+		"invariant_id": map[string]any{
+			"type":        "string",
+			"description": "Invariant id from invariant add/list (invariant remove, or list to filter violations)",
+		},
+		"client_id": map[string]any{
+			"type":        "string",
+			"description": "Client to override (X-Kaboom-Client value, or 'self'); omit to change defaults (rate_limit)",
+		},
 		"severity_min": map[string]any{
 			"type":        "string",
 			"description": "Min event severity for streaming notifications (streaming)",
@@ -121,7 +320,7 @@ func configureRuntimeProperties() map[string]any {
 		},
 		"steps": map[string]any{
 			"type":        "array",
-			"description": "Ordered list of interact action objects (save_sequence, replay_sequence override)",
+			"description": "Ordered list of interact action objects (save_sequence, replay_sequence override). For define_login: {what: navigate|type|click|wait_for, url, selector, text, text_env, url_contains, timeout_ms}; text may use {{var}} placeholders and text_env names a KABOOM_LOGIN_* daemon environment variable holding a secret",
 			"items":       map[string]any{"type": "object"},
 		},
 		"tags": map[string]any{
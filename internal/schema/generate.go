@@ -24,14 +24,15 @@ func generateToolSchema() mcp.MCPTool {
 	failureSchema := testFailureSchema()
 	return mcp.MCPTool{
 		Name:        "generate",
-		Description: "Generate artifacts from captured data: reproduction (bug script), csp (Content Security Policy), sarif (static analysis results). Test generation: test_from_context, test_heal, test_classify. Annotation formats: visual_test, annotation_report, annotation_issues.\n\nPrerequisites: Most modes require captured data first — use observe to verify data exists before generating. reproduction needs captured actions (observe what='actions'). har needs network bodies (observe what='network_bodies'). Use save_to param to write output directly to a file path.",
+		Description: "Generate artifacts from captured data: reproduction (bug script), csp (Content Security Policy), security_headers (Permissions-Policy, framing, referrer, COOP/COEP with per-header rationale), third_party_report (third-party script supply-chain report), sarif (static analysis results). Test generation: test_from_context, test_heal, test_classify. Annotation formats: visual_test, annotation_report, annotation_issues. session_bundle archives all captured data for replay with --serve-bundle. debug_bundle zips diagnostics, the HTTP debug log, redacted buffers, settings, crash logs, and version/OS info into one file to attach to a Kaboom bug report. junit turns session checks (console errors, 5xx responses, API contract violations, serious a11y violations, vitals budgets) into JUnit XML for CI. gh_annotations emits GitHub Actions workflow commands (::error file=...,line=...::msg) for console errors, API contract violations, and a11y findings so a CI job annotates the PR diff. curl and fetch turn a captured request (request_id from observe what='network_bodies') into a runnable cURL command or fetch() snippet with sensitive headers replaced by placeholders. coverage_report renders Markdown ranking the JS/CSS bundles with the most unused bytes (from observe what='coverage' snapshots), weighted by download size, with a fix for each.\n\nPrerequisites: Most modes require captured data first — use observe to verify data exists before generating. reproduction needs captured actions (observe what='actions'). har needs network bodies (observe what='network_bodies'). Use save_to param to write output directly to a file path.",
+		Annotations: &mcp.MCPToolAnnotations{ReadOnlyHint: false, DestructiveHint: false, IdempotentHint: false, OpenWorldHint: false},
 		InputSchema: map[string]any{
 			"type": "object",
 			"properties": map[string]any{
 				"what": map[string]any{
 					"type":        "string",
 					"description": "Artifact type to generate from captured data",
-					"enum":        []string{"reproduction", "test", "pr_summary", "har", "csp", "sri", "sarif", "visual_test", "annotation_report", "annotation_issues", "test_from_context", "test_heal", "test_classify"},
+					"enum":        []string{"reproduction", "test", "pr_summary", "har", "csp", "sri", "security_headers", "third_party_report", "sarif", "visual_test", "annotation_report", "annotation_issues", "test_from_context", "test_heal", "test_classify", "session_bundle", "debug_bundle", "junit", "gh_annotations", "curl", "fetch", "coverage_report"},
 				},
 				"format": map[string]any{
 					"type":        "string",
@@ -50,6 +51,10 @@ func generateToolSchema() mcp.MCPTool {
 					"type":        "number",
 					"description": "Use last N actions (reproduction)",
 				},
+				"boundary": map[string]any{
+					"type":        "string",
+					"description": "Only actions or traffic tagged with this configure test_boundary_start test_id (reproduction, test, har)",
+				},
 				"base_url": map[string]any{
 					"type":        "string",
 					"description": "Replace origin in URLs",
@@ -82,6 +87,16 @@ func generateToolSchema() mcp.MCPTool {
 					"type":        "boolean",
 					"description": "Assert response shape (test)",
 				},
+				"locator_strategy": map[string]any{
+					"type":        "string",
+					"description": "auto (default) or stable: prefer test ids and element ids over text so locators survive UI language changes (test)",
+					"enum":        []string{"auto", "stable"},
+				},
+				"locales": map[string]any{
+					"type":        "array",
+					"description": "BCP 47 locales, e.g. [\"en-US\",\"de-DE\"]: run the test once per locale with text locators read from a per-locale STRINGS table (test)",
+					"items":       map[string]any{"type": "string"},
+				},
 				"scope": map[string]any{
 					"type":        "string",
 					"description": "CSS selector scope (sarif)",
@@ -94,9 +109,17 @@ func generateToolSchema() mcp.MCPTool {
 					"type":        "string",
 					"description": "File path to save output",
 				},
+				"baseline": map[string]any{
+					"type":        "string",
+					"description": "diff_sessions snapshot to compare against; deltas start at its capture time (pr_summary, default: session start)",
+				},
 				"url": map[string]any{
 					"type":        "string",
-					"description": "URL filter (har)",
+					"description": "URL filter (har, coverage_report)",
+				},
+				"limit": map[string]any{
+					"type":        "number",
+					"description": "Bundles to list (coverage_report, default 10)",
 				},
 				"method": map[string]any{
 					"type":        "string",
@@ -126,7 +149,7 @@ func generateToolSchema() mcp.MCPTool {
 				},
 				"resource_types": map[string]any{
 					"type":        "array",
-					"description": "Resource types: script, stylesheet (sri)",
+					"description": "Resource types: scripts, styles, modules (sri)",
 					"items":       map[string]any{"type": "string"},
 				},
 				"origins": map[string]any{
@@ -134,6 +157,32 @@ func generateToolSchema() mcp.MCPTool {
 					"description": "Filter origins (sri)",
 					"items":       map[string]any{"type": "string"},
 				},
+				"first_party_origins": map[string]any{
+					"type":        "array",
+					"description": "First-party origins; defaults to the tracked page origin (third_party_report)",
+					"items":       map[string]any{"type": "string"},
+				},
+				"first_party_suffixes": map[string]any{
+					"type":        "array",
+					"description": "Domains treated as first-party with all subdomains; page hosts are already grouped by eTLD+1 (third_party_report)",
+					"items":       map[string]any{"type": "string"},
+				},
+				"label": map[string]any{
+					"type":        "string",
+					"description": "Human-readable bundle label (session_bundle)",
+				},
+				"suite_name": map[string]any{
+					"type":        "string",
+					"description": "JUnit testsuite name (junit, default: kaboom)",
+				},
+				"budget": map[string]any{
+					"type":        "object",
+					"description": "Vitals budget per page load: url filter plus any of lcp_ms, fcp_ms, inp_ms, ttfb_ms, load_ms, cls (junit, default: Web Vitals poor thresholds)",
+				},
+				"request_id": map[string]any{
+					"type":        "string",
+					"description": "Captured request id from observe(what='network_bodies') (curl, fetch)",
+				},
 				"annot_session": map[string]any{
 					"type":        "string",
 					"description": "Named annotation session (applies to visual_test, annotation_report, annotation_issues)",
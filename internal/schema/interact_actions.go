@@ -19,14 +19,14 @@ type InteractActionSpec struct {
 var interactActionSpecs = []InteractActionSpec{
 	{Name: "highlight", Hint: "Visually highlight an element with a colored overlay", Optional: []string{"selector", "element_id", "index", "nth", "scope_selector", "frame", "duration_ms"}},
 	{Name: "subtitle", Hint: "Display a status subtitle in the extension UI", Optional: []string{"text"}},
-	{Name: "save_state", Hint: "Snapshot cookies/storage/URL for later restore", Required: []string{"snapshot_name"}, Optional: []string{"storage_type", "include_url"}},
-	{Name: "state_save", Hint: "Snapshot cookies/storage/URL (alias for save_state)", Required: []string{"snapshot_name"}, Optional: []string{"storage_type", "include_url"}, IsAlias: true},
-	{Name: "load_state", Hint: "Restore a previously saved state snapshot", Required: []string{"snapshot_name"}, Optional: []string{"storage_type"}},
-	{Name: "state_load", Hint: "Restore a saved state snapshot (alias for load_state)", Required: []string{"snapshot_name"}, Optional: []string{"storage_type"}, IsAlias: true},
+	{Name: "save_state", Hint: "Snapshot cookies/storage/URL for later restore; vault=true seals the full login (HttpOnly cookies, tokens) encrypted", Required: []string{"snapshot_name"}, Optional: []string{"storage_type", "include_url", "vault", "ttl"}},
+	{Name: "state_save", Hint: "Snapshot cookies/storage/URL (alias for save_state)", Required: []string{"snapshot_name"}, Optional: []string{"storage_type", "include_url", "vault", "ttl"}, IsAlias: true},
+	{Name: "load_state", Hint: "Restore a previously saved state snapshot; vault=true restores an encrypted login on the same origin", Required: []string{"snapshot_name"}, Optional: []string{"storage_type", "include_url", "vault", "origin"}},
+	{Name: "state_load", Hint: "Restore a saved state snapshot (alias for load_state)", Required: []string{"snapshot_name"}, Optional: []string{"storage_type", "include_url", "vault", "origin"}, IsAlias: true},
 	{Name: "list_states", Hint: "List all saved state snapshots"},
 	{Name: "state_list", Hint: "List saved state snapshots (alias for list_states)", IsAlias: true},
-	{Name: "delete_state", Hint: "Delete a saved state snapshot", Required: []string{"snapshot_name"}},
-	{Name: "state_delete", Hint: "Delete a state snapshot (alias for delete_state)", Required: []string{"snapshot_name"}, IsAlias: true},
+	{Name: "delete_state", Hint: "Delete a saved state snapshot", Required: []string{"snapshot_name"}, Optional: []string{"vault", "origin"}},
+	{Name: "state_delete", Hint: "Delete a state snapshot (alias for delete_state)", Required: []string{"snapshot_name"}, Optional: []string{"vault", "origin"}, IsAlias: true},
 	{Name: "set_storage", Hint: "Set a localStorage or sessionStorage key", Required: []string{"key"}, Optional: []string{"storage_type", "value"}},
 	{Name: "delete_storage", Hint: "Delete a storage key", Required: []string{"key"}, Optional: []string{"storage_type"}},
 	{Name: "clear_storage", Hint: "Clear all keys from a storage type", Optional: []string{"storage_type"}},
@@ -40,7 +40,7 @@ var interactActionSpecs = []InteractActionSpec{
 	{Name: "new_tab", Hint: "Open a new browser tab", Optional: []string{"url"}},
 	{Name: "switch_tab", Hint: "Switch to a different browser tab", Optional: []string{"tab_id", "tab_index", "set_tracked"}},
 	{Name: "close_tab", Hint: "Close a browser tab", Optional: []string{"tab_id"}},
-	{Name: "screenshot", Hint: "Capture page screenshot (alias for observe/screenshot)"},
+	{Name: "screenshot", Hint: "Capture page screenshot (alias for observe/screenshot); selector + clip=true captures one element; highlight_selector outlines one", Optional: []string{"selector", "clip", "highlight_selector"}},
 	{Name: "click", Hint: "Click an element by selector, element_id, or coordinates", Optional: []string{"selector", "element_id", "index", "nth", "scope_selector", "frame", "reason", "correlation_id", "timeout_ms", "x", "y", "analyze", "wait_for_stable", "stability_ms"}},
 	{Name: "type", Hint: "Type text into an input or textarea", Required: []string{"text"}, Optional: []string{"selector", "element_id", "index", "nth", "scope_selector", "frame", "clear"}},
 	{Name: "select", Hint: "Choose an option in a <select> dropdown", Required: []string{"value"}, Optional: []string{"selector", "element_id", "index", "nth", "scope_selector", "frame"}},
@@ -68,7 +68,10 @@ var interactActionSpecs = []InteractActionSpec{
 	{Name: "navigate_and_wait_for", Hint: "Navigate to a URL and wait for a selector to appear", Required: []string{"url", "wait_for"}, Optional: []string{"include_content"}},
 	{Name: "navigate_and_document", Hint: "Click to navigate, optionally wait for URL change/stability, then return page context", Optional: []string{"selector", "element_id", "index", "index_generation", "nth", "scope_selector", "scope_rect", "frame", "tab_id", "reason", "timeout_ms", "wait_for_url_change", "wait_for_stable", "stability_ms", "include_screenshot", "include_interactive"}},
 	{Name: "fill_form_and_submit", Hint: "Fill form fields and click the submit button", Optional: []string{"fields", "submit_selector", "submit_index", "scope_selector", "frame"}},
-	{Name: "fill_form", Hint: "Fill multiple form fields at once", Optional: []string{"fields", "scope_selector", "frame"}},
+	{Name: "fill_form", Hint: "Fill multiple form fields at once: values={name: value} in one round-trip, or fields=[{selector, value}]", Optional: []string{"values", "selector", "fields", "scope_selector", "frame"}},
+	{Name: "login", Hint: "Run a login recipe stored with configure(what='define_login') in the tracked tab, then save the logged-in state under the recipe name (vault when KABOOM_STATE_KEY is set). Secrets come from text_env variables and are never recorded", Required: []string{"recipe"}, Optional: []string{"vars", "save_state", "tab_id"}},
+	{Name: "replay_request", Hint: "Re-issue a captured request from the page (same cookies; pass Authorization in overrides.headers) and diff the new response against the original", Required: []string{"request_id"}, Optional: []string{"overrides", "tab_id"}},
+	{Name: "emulate", Hint: "Emulate a device on the tracked tab (viewport, DPR, user agent, touch); recorded on performance snapshots and generated tests. device='off' clears it", Required: []string{"device"}, Optional: []string{"tab_id"}},
 	{Name: "run_a11y_and_export_sarif", Hint: "Run accessibility audit and export results as SARIF", Optional: []string{"save_to", "scope_selector", "frame"}},
 	{Name: "screen_recording_start", Hint: "Start recording browser session with video capture", Optional: []string{"name", "audio", "fps"}},
 	{Name: "record_start", Hint: "Start recording browser session (alias for screen_recording_start)", Optional: []string{"name", "audio", "fps"}, IsAlias: true},
@@ -77,6 +80,7 @@ var interactActionSpecs = []InteractActionSpec{
 	{Name: "upload", Hint: "Upload a file to a file input or API endpoint", Optional: []string{"file_path", "api_endpoint", "submit", "escalation_timeout_ms"}},
 	{Name: "draw_mode_start", Hint: "Activate annotation overlay for drawing rectangles and adding feedback", Optional: []string{"annot_session", "timeout_ms"}},
 	{Name: "hardware_click", Hint: "CDP-level click at x/y coordinates for isTrusted events", Optional: []string{"x", "y"}},
+	{Name: "cdp", Hint: "Send a raw Chrome DevTools Protocol command to the tracked tab (escape hatch; operator must enable it). The session is held between calls; release=true ends it", Optional: []string{"method", "params", "release", "tab_id"}},
 	{Name: "activate_tab", Hint: "Bring the tracked tab to the foreground"},
 	{Name: "explore_page", Hint: "Composite page exploration: screenshot, interactive elements, readable text, navigation links, and metadata in one call", Optional: []string{"url", "visible_only", "limit"}},
 	{Name: "batch", Hint: "Execute a sequence of interact actions in one call", Optional: []string{"steps", "step_timeout_ms", "continue_on_error", "stop_after_step"}},
@@ -16,6 +16,55 @@ func interactCoreActionProperties() map[string]any {
 			"type":        "boolean",
 			"description": "Restore URL with state",
 		},
+		"vault": map[string]any{
+			"type":        "boolean",
+			"description": "Use the encrypted auth state vault: full cookies (HttpOnly included) and storage, sealed with the daemon's KABOOM_STATE_KEY (save_state, load_state, delete_state)",
+		},
+		"ttl": map[string]any{
+			"type":        "string",
+			"description": "How long a vault state stays loadable, Go duration between 1m and 720h, default 24h (save_state vault=true)",
+		},
+		"origin": map[string]any{
+			"type":        "string",
+			"description": "Origin of the vault state, e.g. https://app.example.com; defaults to the tracked tab's origin (load_state, delete_state vault=true)",
+		},
+		"recipe": map[string]any{
+			"type":        "string",
+			"description": "Login recipe name from configure(what='define_login') (login)",
+		},
+		"vars": map[string]any{
+			"type":                 "object",
+			"description":          "Values for the recipe's {{var}} placeholders, overriding its defaults (login)",
+			"additionalProperties": map[string]any{"type": "string"},
+		},
+		"save_state": map[string]any{
+			"type":        "boolean",
+			"description": "Save the logged-in state under the recipe name after the steps succeed (login, default true)",
+		},
+		"method": map[string]any{
+			"type":        "string",
+			"description": "CDP method in Domain.method form (cdp), e.g. Network.emulateNetworkConditions",
+		},
+		"params": map[string]any{
+			"type":        "object",
+			"description": "CDP method arguments (cdp)",
+		},
+		"release": map[string]any{
+			"type":        "boolean",
+			"description": "Release the debugger session held for cdp, ending state such as network conditions (cdp)",
+		},
+		"request_id": map[string]any{
+			"type":        "string",
+			"description": "Captured request id from observe(what='network_bodies') (replay_request)",
+		},
+		"overrides": map[string]any{
+			"type":        "object",
+			"description": "Changes applied before replay: {headers: {name: value}, body: string}. An empty header value drops that header. Authorization is never captured; set it here for token-authenticated endpoints (replay_request)",
+		},
+		"device": map[string]any{
+			"type":        []string{"string", "object"},
+			"description": "Device name (iPhone 14, iPhone 14 Pro Max, iPhone SE, Pixel 7, Galaxy S23, iPad Mini, iPad Pro 11, Desktop Chrome), custom {width, height, dpr, ua, touch}, or 'off' (emulate)",
+		},
 		"script": map[string]any{
 			"type":        "string",
 			"description": "JS code (execute_js)",
@@ -17,6 +17,10 @@ func interactFormAndWaitProperties() map[string]any {
 				"required": []string{"value"},
 			},
 		},
+		"values": map[string]any{
+			"type":        "object",
+			"description": "Field values keyed by name, id, label, aria-label, or placeholder, e.g. {\"email\": \"a@b.co\", \"terms\": true}. Filled in one round-trip; use selector to scope to one form (fill_form)",
+		},
 		"submit_selector": map[string]any{
 			"type":        "string",
 			"description": "Submit button selector (fill_form_and_submit)",
@@ -8,6 +8,14 @@ func interactOutputAndBatchProperties() map[string]any {
 			"type":        "boolean",
 			"description": "Capture a screenshot after the action completes and return it inline as an image content block",
 		},
+		"clip": map[string]any{
+			"type":        "boolean",
+			"description": "Crop the screenshot to the element matched by a CSS selector and return its width and height (screenshot)",
+		},
+		"highlight_selector": map[string]any{
+			"type":        "string",
+			"description": "Draw an outline around the element matched by this CSS selector (screenshot)",
+		},
 		"include_interactive": map[string]any{
 			"type":        "boolean",
 			"description": "Run list_interactive after the action and include results in the response",
@@ -8,15 +8,17 @@ import "github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/mcp"
 // ObserveToolSchema returns the MCP tool definition for the observe tool.
 func ObserveToolSchema() mcp.MCPTool {
 	return mcp.MCPTool{
-		Name:        "observe",
-		Description: "Read captured browser state from extension buffers.\n\nnetwork_bodies captures fetch() only; use network_waterfall for all requests. extension_logs = internal debug logs (use logs for console). error_bundles = pre-assembled debug context per error. Use body_path to extract JSON subtrees from network_bodies.\n\nPagination: pass after_cursor/before_cursor/since_cursor from response metadata. restart_on_eviction=true if cursor expired.",
+		Name:         "observe",
+		Description:  "Read captured browser state from extension buffers.\n\nnetwork_bodies captures fetch() only; use network_waterfall for all requests. extension_logs = internal debug logs (use logs for console). error_bundles = pre-assembled debug context per error. Use body_path to extract JSON subtrees from network_bodies.\n\nPagination: pass after_cursor/before_cursor/since_cursor from response metadata. restart_on_eviction=true if cursor expired.",
+		Annotations:  &mcp.MCPToolAnnotations{ReadOnlyHint: true, DestructiveHint: false, IdempotentHint: true, OpenWorldHint: false},
+		OutputSchema: observeOutputSchema(),
 		InputSchema: map[string]any{
 			"type": "object",
 			"properties": map[string]any{
 				"what": map[string]any{
 					"type":        "string",
 					"description": "Data mode to read from extension buffers",
-					"enum":        []string{"errors", "logs", "extension_logs", "network_waterfall", "network_bodies", "websocket_events", "websocket_status", "actions", "vitals", "page", "tabs", "history", "pilot", "timeline", "error_bundles", "screenshot", "storage", "indexeddb", "command_result", "pending_commands", "failed_commands", "saved_videos", "recordings", "recording_actions", "playback_results", "log_diff_report", "summarized_logs", "page_inventory", "transients", "inbox", "site_menus"},
+					"enum":        []string{"errors", "logs", "extension_logs", "network_waterfall", "network_bodies", "websocket_events", "websocket_status", "sse", "server_debug", "actions", "vitals", "page", "environment", "tabs", "history", "pilot", "timeline", "error_bundles", "screenshot", "storage", "form_state", "indexeddb", "command_result", "pending_commands", "failed_commands", "saved_videos", "recordings", "recording_actions", "playback_results", "log_diff_report", "summarized_logs", "page_inventory", "transients", "inbox", "site_menus", "auth_state", "cookie_audit", "transport_security", "session_compare", "third_party_audit", "privacy_audit", "contract_violations", "budget_violations", "memory", "changes", "component_audit", "seo", "coverage", "verify_fix", "assert", "state_at", "flakiness", "playbook", "findings", "capabilities", "doctor", "sessions", "search"},
 				},
 				"telemetry_mode": map[string]any{
 					"type":        "string",
@@ -64,9 +66,17 @@ func ObserveToolSchema() mcp.MCPTool {
 					"type":        "number",
 					"description": "Max extension logs when include_extension_logs=true (logs)",
 				},
+				"args_path": map[string]any{
+					"type":        "string",
+					"description": "Only entries where this path into the log entry resolves, e.g. args[0].requestId (logs)",
+				},
+				"args_value": map[string]any{
+					"type":        "string",
+					"description": "With args_path: only entries whose value there equals this; non-strings compare as JSON, e.g. 42 or true (logs)",
+				},
 				"url": map[string]any{
 					"type":        "string",
-					"description": "Filter by URL substring (errors, logs, network_waterfall, network_bodies, websocket_events, actions, transients, error_bundles)",
+					"description": "Filter by URL substring (errors, logs, network_waterfall, network_bodies, websocket_events, sse, actions, transients, error_bundles, auth_state, cookie_audit, transport_security); endpoint substring for contract_violations; URL substring for budget_violations; route substring for memory; route URL for vitals mode=trend and aggregate; harness URL for component_audit",
 				},
 				"database": map[string]any{
 					"type":        "string",
@@ -87,11 +97,27 @@ func ObserveToolSchema() mcp.MCPTool {
 				},
 				"method": map[string]any{
 					"type":        "string",
-					"description": "HTTP method filter (network_bodies)",
+					"description": "HTTP method filter (network_bodies, server_debug)",
 				},
 				"status_min": map[string]any{
 					"type":        "number",
-					"description": "Min HTTP status code (network_bodies)",
+					"description": "Min HTTP status code (network_bodies, server_debug)",
+				},
+				"endpoint": map[string]any{
+					"type":        "string",
+					"description": "Daemon endpoint path substring, e.g. /mcp (server_debug)",
+				},
+				"slow_ms": map[string]any{
+					"type":        "number",
+					"description": "Only requests that took at least this many milliseconds (server_debug)",
+				},
+				"errors_only": map[string]any{
+					"type":        "boolean",
+					"description": "Only failed requests: HTTP status >= 400 or a JSON-RPC/tool error (server_debug)",
+				},
+				"mine": map[string]any{
+					"type":        "boolean",
+					"description": "Only requests from the calling MCP client (server_debug)",
 				},
 				"status_max": map[string]any{
 					"type":        "number",
@@ -101,24 +127,62 @@ func ObserveToolSchema() mcp.MCPTool {
 					"type":        "string",
 					"description": "Extract JSON value from response_body using path, e.g. data.items[0].id (network_bodies)",
 				},
+				"request_id": map[string]any{
+					"type":        "string",
+					"description": "Only the body with this request_id or full_body_ref (network_bodies)",
+				},
+				"full_body": map[string]any{
+					"type":        "boolean",
+					"description": "Return the complete request/response body kept for request_id past the inline limit (network_bodies)",
+				},
+				"include_binary": map[string]any{
+					"type":        "boolean",
+					"description": "Return the base64 bytes of captured binary responses; by default only binary_preview is shown (network_bodies)",
+				},
+				"buffer": map[string]any{
+					"type":        "string",
+					"description": "Buffer to explain (doctor) or search (search: logs covers console and extension logs; sse is not archived); omit for all",
+					"enum":        []string{"all", "logs", "network", "websocket", "sse", "actions"},
+				},
 				"connection_id": map[string]any{
 					"type":        "string",
-					"description": "WebSocket connection ID filter (websocket_events, websocket_status)",
+					"description": "WebSocket or EventSource connection ID filter (websocket_events, websocket_status, sse)",
 				},
 				"direction": map[string]any{
 					"type":        "string",
 					"description": "WebSocket message direction filter (websocket_events)",
 					"enum":        []string{"incoming", "outgoing"},
 				},
+				"event": map[string]any{
+					"type":        "string",
+					"description": "Server-Sent Events lifecycle filter (sse)",
+					"enum":        []string{"open", "message", "retry", "error", "close"},
+				},
+				"event_type": map[string]any{
+					"type":        "string",
+					"description": "Named SSE event type filter, e.g. \"update\" for event: update lines (sse)",
+				},
 				"last_n": map[string]any{
 					"type":        "number",
 					"description": "Return last N items only (actions)",
 				},
 				"include": map[string]any{
 					"type":        "array",
-					"description": "Categories to include (timeline)",
+					"description": "Categories to include: actions, errors, network, websocket, tests, builds, edits (timeline)",
 					"items":       map[string]any{"type": "string"},
 				},
+				"test_id": map[string]any{
+					"type":        "string",
+					"description": "Only entries captured while this test ran, as reported to POST /test-events (timeline); storage changes since this test's test_boundary_start baseline (storage)",
+				},
+				"boundary": map[string]any{
+					"type":        "string",
+					"description": "Only entries tagged with this configure test_boundary_start test_id, plus a per-boundary summary (errors, logs, network_waterfall, network_bodies, websocket_events, sse, actions, timeline); the boundary whose repeated runs to compare (flakiness)",
+				},
+				"tab_id": map[string]any{
+					"type":        "integer",
+					"description": "Only entries captured in this browser tab; replaces the tracked-tab scope (errors, logs, network_waterfall, network_bodies, websocket_events, sse, actions, timeline). GET /health lists per-tab counts",
+				},
 				"correlation_id": map[string]any{
 					"type":        "string",
 					"description": "Async command correlation ID (command_result)",
@@ -146,8 +210,8 @@ func ObserveToolSchema() mcp.MCPTool {
 				},
 				"format": map[string]any{
 					"type":        "string",
-					"description": "Screenshot format (screenshot)",
-					"enum":        []string{"png", "jpeg"},
+					"description": "Screenshot format (screenshot); text (default) or structured to return console arguments as JSON values in args (logs)",
+					"enum":        []string{"png", "jpeg", "text", "structured"},
 				},
 				"quality": map[string]any{
 					"type":        "number",
@@ -159,7 +223,15 @@ func ObserveToolSchema() mcp.MCPTool {
 				},
 				"selector": map[string]any{
 					"type":        "string",
-					"description": "Capture specific element by CSS selector (screenshot)",
+					"description": "Capture specific element by CSS selector (screenshot; requires clip=true), or the forms to read (form_state; default every form)",
+				},
+				"clip": map[string]any{
+					"type":        "boolean",
+					"description": "Crop the screenshot to the selector's bounding box; returns the cropped image with its width and height (screenshot)",
+				},
+				"highlight_selector": map[string]any{
+					"type":        "string",
+					"description": "Draw an outline around the element matched by this CSS selector, e.g. the one under discussion (screenshot)",
 				},
 				"wait_for_stable": map[string]any{
 					"type":        "boolean",
@@ -178,9 +250,197 @@ func ObserveToolSchema() mcp.MCPTool {
 					"description": "Transient element classification filter (transients)",
 					"enum":        []string{"alert", "toast", "snackbar", "notification", "tooltip", "banner", "flash"},
 				},
+				"cluster_trend": map[string]any{
+					"type":        "boolean",
+					"description": "Return error clusters split into new this session, known from earlier sessions, and regressed after a clear, with persisted first/last seen and counts (errors)",
+				},
 				"summary": map[string]any{
 					"type":        "boolean",
-					"description": "Return compact summary instead of full entries (errors, logs, network_waterfall, network_bodies, websocket_events, websocket_status, actions, error_bundles, timeline, history, transients, storage)",
+					"description": "Return compact summary instead of full entries (errors, logs, network_waterfall, network_bodies, websocket_events, websocket_status, sse, server_debug, actions, error_bundles, timeline, history, transients, storage)",
+				},
+				"compact": map[string]any{
+					"type":        "boolean",
+					"description": "Tabular encoding for any mode: each array of objects becomes [header row of column names, ...value rows], all-null columns dropped. Result key compact.tables lists the rewritten paths. Same data, ~50-70% fewer tokens on large listings",
+				},
+				"wait_for_new": map[string]any{
+					"type":        "boolean",
+					"description": "Long-poll: block until new data matching this call's filters arrives (or timeout_ms passes), then return the mode's result with a wait object {timed_out, matched_seq, waited_ms}. Use instead of repeated polling (errors, logs, error_bundles, network_bodies, websocket_events, actions, changes)",
+				},
+				"timeout_ms": map[string]any{
+					"type":        "number",
+					"description": "Maximum wait for wait_for_new in milliseconds (default 25000, max 55000)",
+				},
+				"mode": map[string]any{
+					"type":        "string",
+					"description": "Sub-mode (vitals): current (default, latest snapshot) or trend (daily p75 series from persisted history)",
+					"enum":        []string{"current", "trend"},
+				},
+				"days": map[string]any{
+					"type":        "number",
+					"description": "Trend window in days (vitals mode=trend, default 14, max 30)",
+				},
+				"aggregate": map[string]any{
+					"type":        "string",
+					"description": "Percentile of each vital per route over 'window' (vitals; p75 matches CrUX). Setting aggregate or window switches vitals to windowed percentiles",
+					"enum":        []string{"p50", "p75", "p90", "p95", "p99"},
+				},
+				"window": map[string]any{
+					"type":        "string",
+					"description": "Aggregation window as a duration, e.g. 30m, 1h (vitals with aggregate; default 1h, max 24h)",
+				},
+				"since": map[string]any{
+					"type":        "string",
+					"description": "Judge only what was captured after this point: RFC3339 timestamp, cursor, or 'last_edit' (verify_fix; default last_edit, or the replay start)",
+				},
+				"t": map[string]any{
+					"type":        "string",
+					"description": "Moment to reconstruct: RFC3339 timestamp or cursor (state_at, required)",
+				},
+				"expect": map[string]any{
+					"type":        "object",
+					"description": "What must hold after the fix (verify_fix; default: no errors)",
+					"properties": map[string]any{
+						"error_cluster_id_absent": map[string]any{"type": "string", "description": "Error cluster id (analyze error_clusters) or message substring that must not recur"},
+						"request_succeeds":        map[string]any{"type": "string", "description": "URL substring, optionally 'METHOD path', whose latest request must return 2xx/3xx"},
+						"vitals_within_budget": map[string]any{
+							"type":        "object",
+							"description": "Budget for the next page load: url filter plus any of lcp_ms, fcp_ms, inp_ms, ttfb_ms, load_ms, cls",
+						},
+					},
+				},
+				"conditions": map[string]any{
+					"type":        "array",
+					"description": "Conditions judged together against one page snapshot (assert, required). Each sets one of: selector (with exists, visible, count, or text_contains; default exists=true), url_matches (regex), title_contains, no_console_errors_since, no_network_errors_since (since: 'last_action', a duration like 30s, RFC3339 timestamp, or cursor)",
+					"items": map[string]any{
+						"type": "object",
+						"properties": map[string]any{
+							"selector":                map[string]any{"type": "string"},
+							"exists":                  map[string]any{"type": "boolean"},
+							"visible":                 map[string]any{"type": "boolean"},
+							"count":                   map[string]any{"type": "integer"},
+							"text_contains":           map[string]any{"type": "string"},
+							"url_matches":             map[string]any{"type": "string"},
+							"title_contains":          map[string]any{"type": "string"},
+							"no_console_errors_since": map[string]any{"type": "string"},
+							"no_network_errors_since": map[string]any{"type": "string"},
+						},
+					},
+				},
+				"replay": map[string]any{
+					"type":        "string",
+					"description": "Saved sequence to replay before judging (verify_fix)",
+				},
+				"wait_ms": map[string]any{
+					"type":        "number",
+					"description": "How long to keep watching while the verdict is inconclusive (verify_fix, default 10000, max 30000)",
+				},
+				"collect": map[string]any{
+					"type":        "boolean",
+					"description": "Reload the tracked tab under CDP JS/CSS coverage and record a fresh snapshot before reporting (coverage, default false)",
+				},
+				"include_storage": map[string]any{
+					"type":        "boolean",
+					"description": "Also audit the tracked tab's document.cookie, localStorage, and sessionStorage (cookie_audit, default true)",
+				},
+				"a": map[string]any{
+					"type":        "string",
+					"description": "First session: bundle path, bundle label, or 'current' (session_compare)",
+				},
+				"b": map[string]any{
+					"type":        "string",
+					"description": "Second session: bundle path, bundle label, or 'current' (session_compare)",
+				},
+				"session": map[string]any{
+					"type":        "string",
+					"description": "Archived session id from observe(what='sessions'); omit to search every archived session (search)",
+				},
+				"query": map[string]any{
+					"type":        "string",
+					"description": "Case-insensitive text to find in archived log messages, request URLs and bodies, WebSocket payloads, and action values (search)",
+				},
+				"first_party_origins": map[string]any{
+					"type":        "array",
+					"items":       map[string]any{"type": "string"},
+					"description": "First-party origins; defaults to the tracked page origin (third_party_audit, privacy_audit)",
+				},
+				"first_party_suffixes": map[string]any{
+					"type":        "array",
+					"description": "Domains treated as first-party with all subdomains; page hosts are already grouped by eTLD+1 (third_party_audit, privacy_audit)",
+					"items":       map[string]any{"type": "string"},
+				},
+				"consent_cookie": map[string]any{
+					"type":        "string",
+					"description": "Cookie that holds consent state; auto-detected for OneTrust, Cookiebot, TCF, CookieYes, and Complianz when omitted (privacy_audit)",
+				},
+				"consent_granted_value": map[string]any{
+					"type":        "string",
+					"description": "Substring of consent_cookie that means tracking consent was granted; any other non-refusal value counts as granted when omitted (privacy_audit)",
+				},
+				"update_baseline": map[string]any{
+					"type":        "boolean",
+					"description": "Store the current results as the baseline for change detection (third_party_audit scripts, component_audit screenshots)",
+				},
+				"expiring_within_seconds": map[string]any{
+					"type":        "number",
+					"description": "Flag tokens expiring within this many seconds as expiring_soon (auth_state, default 300)",
+				},
+				"after_seq": map[string]any{
+					"type":        "number",
+					"description": "Return changes with a sequence greater than this; pass next_seq from the previous response (changes)",
+				},
+				"feed_id": map[string]any{
+					"type":        "string",
+					"description": "feed_id from the previous response; a mismatch means the daemon restarted and the feed resets (changes)",
+				},
+				"since_commit": map[string]any{
+					"type":        "string",
+					"description": "Return changes recorded since this git commit (4-40 hex chars) was checked out in your working directory; cannot be combined with after_seq (changes)",
+				},
+				"types": map[string]any{
+					"type":        "array",
+					"items":       map[string]any{"type": "string", "enum": []string{"console", "network", "ws", "action", "alert"}},
+					"description": "Only return these change types (changes)",
+				},
+				"harness": map[string]any{
+					"type":        "string",
+					"description": "Component harness (component_audit, default auto-detect)",
+					"enum":        []string{"auto", "storybook", "ladle", "custom"},
+				},
+				"story_url_template": map[string]any{
+					"type":        "string",
+					"description": "Story render URL with {id} placeholder, for custom harnesses (component_audit)",
+				},
+				"story_ids": map[string]any{
+					"type":        "array",
+					"items":       map[string]any{"type": "string"},
+					"description": "Audit only these story IDs instead of enumerating the index (component_audit)",
+				},
+				"filter": map[string]any{
+					"type":        "string",
+					"description": "Substring match over story ID, title, and name (component_audit), or a filter expression such as level>=warn AND url~\"checkout\" AND NOT message~\"ResizeObserver\" (logs, errors, extension_logs)",
+				},
+				"tags": map[string]any{
+					"type":        "array",
+					"items":       map[string]any{"type": "string"},
+					"description": "Only stories carrying any of these tags (component_audit)",
+				},
+				"checks": map[string]any{
+					"type":        "array",
+					"items":       map[string]any{"type": "string", "enum": []string{"a11y", "visual", "console"}},
+					"description": "Per-story checks to run (component_audit, default all)",
+				},
+				"settle_ms": map[string]any{
+					"type":        "number",
+					"description": "Wait after each story loads before auditing (component_audit, default 750, max 10000)",
+				},
+				"finding_id": map[string]any{
+					"type":        "string",
+					"description": "finding_id from a security, accessibility, or vitals finding, e.g. security.missing_csp or a11y.color-contrast (playbook; omit to list all playbooks)",
+				},
+				"state": map[string]any{
+					"type":        "string",
+					"description": "Lifecycle state to list (findings, default all). open is the triage queue",
+					"enum":        []string{"open", "acknowledged", "fixed", "regressed", "all"},
 				},
 				"visible_only": map[string]any{
 					"type":        "boolean",
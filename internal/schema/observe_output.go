@@ -0,0 +1,265 @@
+// Purpose: Declares the observe tool's structuredContent output schema and the per-mode (what=...) result schemas.
+// Why: Lets clients validate and render observe results from structuredContent without parsing text.
+// Docs: docs/mcp-integration/index.md
+
+package schema
+
+import "sort"
+
+// ObserveOutputSchemaURIPrefix is the resource URI prefix serving per-mode output schemas.
+const ObserveOutputSchemaURIPrefix = "kaboom://schema/observe/"
+
+// observeOutputSchema is the tools/list outputSchema for observe. Every mode returns an
+// object, so the envelope is intentionally open; the per-mode schemas carry the detail.
+func observeOutputSchema() map[string]any {
+	return map[string]any{
+		"type":        "object",
+		"description": "Mode-specific result object, identical to the JSON in the text content. Per-mode schemas: resources/read " + ObserveOutputSchemaURIPrefix + "{what}.",
+	}
+}
+
+var (
+	outStr    = map[string]any{"type": "string"}
+	outNum    = map[string]any{"type": "number"}
+	outBool   = map[string]any{"type": "boolean"}
+	outObj    = map[string]any{"type": "object"}
+	outArr    = map[string]any{"type": "array"}
+	outArrNil = map[string]any{"type": []string{"array", "null"}}
+)
+
+// outputMode builds an open object schema. props maps property names to schemas; required
+// lists keys present on every successful default-parameter response.
+func outputMode(description string, props map[string]any, required ...string) map[string]any {
+	s := map[string]any{
+		"type":                 "object",
+		"description":          description,
+		"properties":           props,
+		"additionalProperties": true,
+	}
+	if len(required) > 0 {
+		s["required"] = required
+	}
+	return s
+}
+
+// entryList is the common {entries, count, metadata, hint} buffer-read shape.
+func entryList(description string) map[string]any {
+	return outputMode(description, map[string]any{
+		"entries": outArr, "count": outNum, "metadata": outObj, "hint": outStr,
+	}, "entries", "count", "metadata")
+}
+
+// observeOutputSchemas maps each observe mode to the schema of its result object.
+// Extension-backed modes list their stable keys without requiring them, since the
+// extension can add or omit fields across versions.
+var observeOutputSchemas = map[string]map[string]any{
+	"errors": outputMode("Console errors, newest first; errors resolved to a file under the caller's CWD carry source_file {path, line, column, snippet}", map[string]any{
+		"errors": outArr, "count": outNum, "scope": outStr, "metadata": outObj, "hint": outStr, "param_hint": outStr,
+		"cluster_trend": outObj, "summary": outObj,
+	}, "errors", "count", "metadata"),
+	"logs": outputMode("Console log entries", map[string]any{
+		"logs": outArr, "count": outNum, "metadata": outObj, "hint": outStr,
+	}, "logs", "count", "metadata"),
+	"extension_logs": outputMode("Extension-internal debug logs", map[string]any{
+		"logs": outArr, "count": outNum, "metadata": outObj,
+	}, "logs", "count", "metadata"),
+	"network_waterfall": entryList("Resource timing entries for every request"),
+	"network_bodies": outputMode("Captured fetch request/response bodies; with full_body=true, full_body holds the complete payload for request_id", map[string]any{
+		"entries": outArr, "count": outNum, "metadata": outObj, "hint": outStr, "full_body": outObj, "binary_hint": outStr,
+	}, "entries", "count", "metadata"),
+	"websocket_events": outputMode("WebSocket frames and lifecycle events; sampling reports a dropped_estimate when ws_sampling policies or extension sampling are active", map[string]any{
+		"entries": outArr, "count": outNum, "metadata": outObj, "hint": outStr, "param_hint": outStr, "sampling": outObj,
+	}, "entries", "count", "metadata"),
+	"sse": outputMode("Server-Sent Events lifecycle events and messages, oldest first, with per-stream connection state", map[string]any{
+		"entries": outArr, "count": outNum, "connections": outArr, "metadata": outObj, "hint": outStr, "param_hint": outStr,
+	}, "entries", "count", "connections", "metadata"),
+	"server_debug": outputMode("Daemon HTTP debug log of recent /mcp requests and responses, oldest first", map[string]any{
+		"entries": outArr, "count": outNum, "metadata": outObj, "hint": outStr,
+	}, "entries", "count", "metadata"),
+	"websocket_status": outputMode("Open and recently closed WebSocket connections; each connection's sampling shows the ws_sampling policy, achieved_rate, kept, and dropped", map[string]any{
+		"connections": outArr, "closed": outArr, "active_count": outNum, "closed_count": outNum, "metadata": outObj, "hint": outStr,
+	}, "connections", "closed", "metadata"),
+	"actions":    entryList("Recorded user actions"),
+	"transients": entryList("Transient UI elements (toasts, alerts, snackbars)"),
+	"timeline":   entryList("Merged chronological timeline of actions, errors, network, and edits"),
+	"history":    entryList("Navigation history"),
+	"vitals": outputMode("Core Web Vitals for the tracked page", map[string]any{
+		"metrics": outObj, "metadata": outObj,
+	}, "metrics", "metadata"),
+	"page": outputMode("Tracked page URL, title, and readiness", map[string]any{
+		"url": outStr, "title": outStr, "tracked": outBool, "tab_status": outStr, "page_ready_for_commands": outBool,
+		"csp_restricted": outBool, "csp_level": outStr, "metadata": outObj,
+	}, "tracked", "metadata"),
+	"tabs": outputMode("Open browser tabs", map[string]any{
+		"tabs": outArr, "tracking_active": outBool, "metadata": outObj,
+	}, "tabs", "metadata"),
+	"pilot": outputMode("AI Web Pilot state", map[string]any{
+		"enabled": outBool, "configured_enabled": outBool, "authoritative": outBool, "source": outStr, "state": outStr,
+		"extension_connected": outBool, "extension_last_seen": outStr, "in_progress": outArr, "in_progress_count": outNum, "metadata": outObj,
+	}, "enabled", "state"),
+	"error_bundles": outputMode("Pre-assembled debug context per error", map[string]any{
+		"bundles": outArr, "count": outNum, "metadata": outObj, "hint": outStr,
+	}, "bundles", "count", "metadata"),
+	"screenshot": outputMode("Screenshot file metadata; the image itself is an image content block", map[string]any{
+		"filename": outStr, "path": outStr, "width": outNum, "height": outNum, "clip": outObj, "redacted": outNum, "highlighted": outNum, "save_to": outStr, "save_to_error": outStr,
+	}),
+	"form_state": outputMode("Forms with per-field value, validity flags, and validation message", map[string]any{
+		"forms": outArr, "count": outNum, "invalid_fields": outNum, "redacted_fields": outNum, "metadata": outObj, "hint": outStr,
+	}, "forms", "count"),
+	"storage": outputMode("localStorage, sessionStorage, cookies (sensitive values redacted), IndexedDB record counts, quota, and changes since a test baseline", map[string]any{
+		"url": outStr, "local_storage": outObj, "session_storage": outObj, "cookies": map[string]any{"type": []string{"array", "object"}},
+		"indexeddb": outObj, "indexeddb_error": outStr, "quota": outObj, "changes": outObj, "baseline_recorded": outBool, "metadata": outObj,
+	}),
+	"indexeddb": outputMode("Entries from one IndexedDB object store", map[string]any{
+		"database": outStr, "store": outStr, "entries": outArr, "count": outNum, "limit": outNum, "object_stores": outArr, "metadata": outObj,
+	}, "entries", "count", "metadata"),
+	"command_result": outputMode("Status and result of an async command by correlation_id", map[string]any{
+		"correlation_id": outStr, "status": outStr, "result": outObj, "error": outStr,
+	}),
+	"pending_commands": outputMode("Async commands by lifecycle state", map[string]any{
+		"pending": outArr, "completed": outArr, "failed": outArr, "extension_in_progress": outArr, "extension_in_progress_count": outNum,
+	}, "pending", "completed", "failed"),
+	"failed_commands": outputMode("Recently failed async commands", map[string]any{
+		"commands": outArr, "count": outNum,
+	}, "commands", "count"),
+	"saved_videos": outputMode("Saved screen recordings on disk", map[string]any{
+		"recordings": outArr, "total": outNum, "storage_used_bytes": outNum,
+	}, "recordings", "total"),
+	"recordings": outputMode("Saved action recordings", map[string]any{
+		"recordings": outArr, "count": outNum, "limit": outNum,
+	}, "recordings", "count"),
+	"recording_actions": outputMode("Actions in one recording", map[string]any{
+		"recording_id": outStr, "name": outStr, "created_at": outStr, "start_url": outStr, "duration_ms": outNum, "action_count": outNum, "actions": outArr,
+	}, "recording_id", "actions"),
+	"playback_results": outputMode("Per-action results of a recording replay", map[string]any{
+		"recording_id": outStr, "status": outStr, "actions_executed": outNum, "actions_failed": outNum, "actions_total": outNum,
+		"duration_ms": outNum, "results": outArr, "selector_failures": outObj,
+	}, "recording_id", "results"),
+	"log_diff_report": outputMode("Log comparison between an original and a replayed recording", map[string]any{
+		"status": outStr, "report": outStr, "summary": outStr, "stats": outObj,
+	}, "status", "summary"),
+	"summarized_logs": outputMode("Log messages grouped by fingerprint with anomalies", map[string]any{
+		"groups": outArr, "anomalies": outArr, "summary": outObj, "metadata": outObj,
+	}, "groups", "summary", "metadata"),
+	"page_inventory": outputMode("Async command status; result holds page info plus the interactive element inventory", map[string]any{
+		"correlation_id": outStr, "status": outStr, "result": outObj, "error": outStr,
+	}),
+	"inbox": outputMode("Pending push events (annotations, screenshots, chat)", map[string]any{
+		"events": outArr, "count": outNum,
+	}, "events", "count"),
+	"site_menus": outputMode("Navigation menus grouped by landmark", map[string]any{
+		"main": outArr, "sidebar": outArr, "footer": outArr, "other": outArr, "ungrouped": outArr,
+	}),
+	"auth_state": outputMode("Auth tokens found in storage and cookies with expiry", map[string]any{
+		"tokens": outArr, "count": outNum, "expiring_within_seconds": outNum, "summary": outObj, "metadata": outObj, "hint": outStr,
+	}, "tokens", "count", "summary", "metadata"),
+	"cookie_audit": outputMode("Cookie and storage security findings", map[string]any{
+		"findings": outArr, "summary": outObj, "cookies_checked": outNum, "storage_included": outBool,
+		"storage_keys_checked": outNum, "storage_note": outStr, "metadata": outObj, "hint": outStr,
+	}, "findings", "summary", "metadata"),
+	"transport_security": outputMode("Mixed-content and transport security violations", map[string]any{
+		"status": outStr, "violations": outArr, "total_violations": outNum, "by_kind": outObj, "monitoring_since": outStr, "metadata": outObj,
+	}, "status", "violations", "total_violations", "metadata"),
+	"session_compare": outputMode("Divergences between two captured sessions", map[string]any{
+		"a": outObj, "b": outObj, "total_divergences": outNum, "by_category": outObj, "divergences": outArr, "metadata": outObj,
+	}, "a", "b", "total_divergences", "divergences"),
+	"third_party_audit": outputMode("Third-party script inventory and changes", map[string]any{
+		"first_party_origin": outStr, "scripts": outArr, "changes": outArr, "summary": outObj, "recommendations": outArrNil, "metadata": outObj, "hint": outStr,
+	}, "scripts", "summary", "metadata"),
+	"privacy_audit": outputMode("Vendor-grouped beacons, pixels, data sent, and fingerprinting API usage", map[string]any{
+		"first_party_origin": outStr, "vendors": outArr, "fingerprinting": outArr, "consent": outObj, "summary": outObj, "recommendations": outArr, "metadata": outObj, "hint": outStr,
+	}, "vendors", "fingerprinting", "summary", "metadata"),
+	"contract_violations": outputMode("Locked API contracts and the responses that deviated from them", map[string]any{
+		"status": outStr, "contracts": outArr, "violations": outArr, "total_violations": outNum, "by_kind": outObj, "metadata": outObj, "hint": outStr,
+	}, "status", "contracts", "violations", "total_violations", "metadata"),
+	"budget_violations": outputMode("Network budgets and the responses that exceeded their duration or size limits", map[string]any{
+		"status": outStr, "budgets": outArr, "violations": outArr, "total_violations": outNum, "by_metric": outObj, "metadata": outObj, "hint": outStr,
+	}, "status", "budgets", "violations", "total_violations", "metadata"),
+	"memory": outputMode("Per-route heap and DOM trends across navigations, with suspected leaks", map[string]any{
+		"status": outStr, "routes": outArr, "leaks_suspected": outNum, "leak_navigations": outNum, "metadata": outObj, "hint": outStr,
+	}, "status", "routes", "leaks_suspected", "metadata"),
+	"changes": outputMode("Change feed since a sequence cursor", map[string]any{
+		"changes": outArr, "count": outNum, "feed_id": outStr, "has_more": outBool, "latest_seq": outNum, "next_seq": outNum, "oldest_seq": outNum,
+	}, "changes", "count", "next_seq"),
+	"component_audit": outputMode("Per-story component checks from a Storybook harness", map[string]any{
+		"harness": outStr, "base_url": outStr, "stories": outArr, "summary": outObj, "checks": outArr, "truncated": outBool, "hint": outStr,
+	}, "stories", "summary"),
+	"seo": outputMode("SEO findings (title, meta description, canonical, robots, JSON-LD, broken internal links) sorted by severity, each with a fix", map[string]any{
+		"page": outObj, "summary": outObj, "count": outNum, "findings": outArr, "metadata": outObj, "hint": outStr,
+	}, "page", "summary", "count", "findings", "metadata"),
+	"coverage": outputMode("Per-URL JS/CSS used and unused bytes aggregated across coverage snapshots, biggest unused first", map[string]any{
+		"status": outStr, "summary": outObj, "count": outNum, "total": outNum, "resources": outArr, "collected": outObj, "metadata": outObj, "hint": outStr,
+	}, "status", "summary", "count", "total", "resources", "metadata"),
+	"annotations": outputMode("Draw-mode annotations from the current or named session", map[string]any{
+		"annotations": outArr, "count": outNum, "status": outStr, "correlation_id": outStr, "filter_applied": outStr, "message": outStr,
+	}),
+	"annotation_detail": outputMode("Full DOM detail for one annotation", map[string]any{
+		"correlation_id": outStr, "selector": outStr, "tag": outStr, "text_content": outStr, "classes": outArr, "id": outStr,
+	}),
+	"draw_history": outputMode("Saved draw-mode sessions", map[string]any{
+		"sessions": outArr, "count": outNum, "storage_dir": outStr,
+	}, "sessions", "count"),
+	"draw_session": outputMode("One saved draw-mode session", map[string]any{
+		"annotations": outArr,
+	}),
+	"playbook": outputMode("Remediation playbook for a finding, or the playbook index", map[string]any{
+		"playbook": outObj, "playbooks": outArr, "count": outNum, "hint": outStr,
+	}),
+	"findings": outputMode("Tracked security, accessibility, and performance findings with lifecycle state", map[string]any{
+		"findings": outArr, "count": outNum, "total": outNum, "state": outStr, "states": outObj, "metadata": outObj, "hint": outStr,
+	}, "findings", "count", "states"),
+	"doctor": outputMode("Ranked likely causes of missing data, each with evidence, a fix, and the calls to run, plus per-buffer counts", map[string]any{
+		"status": outStr, "causes": outArr, "buffers": outObj, "hint": outStr,
+	}, "status", "causes", "buffers"),
+	"sessions": outputMode("Archived sessions newest first, each with id, reason, counts, size, and bundle path, plus archive settings", map[string]any{
+		"sessions": outArr, "count": outNum, "total": outNum, "dir": outStr, "settings": outObj, "hint": outStr,
+	}, "sessions", "count", "total"),
+	"search": outputMode("Text matches across archived sessions with buffer, timestamp, summary, and snippet", map[string]any{
+		"query": outStr, "session": outStr, "sessions_searched": outNum, "total_matches": outNum, "matches": outArr, "truncated": outBool, "skipped_sessions": outArr,
+	}, "query", "sessions_searched", "total_matches", "matches"),
+	"capabilities": outputMode("Which subsystems are active (extension, pilot, CDP, storage, protocol, audits) with version info", map[string]any{
+		"server": outObj, "protocol": outObj, "extension": outObj, "pilot": outObj, "cdp": outObj, "storage": outObj,
+		"audits": outArr, "subsystems": outObj, "active": outArr, "hints": outArr, "metadata": outObj,
+	}, "server", "protocol", "extension", "subsystems", "audits"),
+	"verify_fix": outputMode("Fixed/not_fixed verdict with per-check evidence", map[string]any{
+		"verdict": outStr, "since": outStr, "activity": outStr, "checks": outArr,
+	}, "verdict", "since", "checks"),
+	"assert": outputMode("Pass/fail per condition with evidence, judged against one page snapshot", map[string]any{
+		"passed": outBool, "passed_count": outNum, "failed_count": outNum, "results": outArr, "url": outStr, "title": outStr,
+		"evaluated_at": outStr, "metadata": outObj,
+	}, "passed", "passed_count", "failed_count", "results"),
+	"flakiness": outputMode("Repeated runs of one test boundary compared step by step: stable, timing_only, network_order, or divergent, with a flake probability per step", map[string]any{
+		"boundary": outStr, "runs": outNum, "classification": outStr, "flake_score": outNum, "run_summaries": outArr, "steps": outArr,
+		"active_run_excluded": outBool, "metadata": outObj, "hint": outStr,
+	}, "boundary", "runs", "classification", "flake_score", "run_summaries", "steps", "metadata"),
+	"environment": outputMode("Browser metadata from the tracked page: user agent, viewport, device pixel ratio, locale, timezone, and extension version", map[string]any{
+		"available": outBool, "environment": outObj, "updated_at": outStr, "tab_id": outNum, "tab_url": outStr, "metadata": outObj, "hint": outStr,
+	}, "available", "metadata"),
+	"state_at": outputMode("Best-known page state at t: URL, recent actions, in-flight requests, open WebSockets, last errors", map[string]any{
+		"t": outStr, "url": outStr, "url_source": outStr, "recent_actions": outArr, "in_flight_requests": outArr,
+		"open_websockets": outArr, "last_errors": outArr, "coverage": outObj, "metadata": outObj,
+	}, "t", "recent_actions", "in_flight_requests", "open_websockets", "last_errors", "coverage"),
+}
+
+// ObserveOutputSchema returns the JSON Schema of the result object for observe what=mode.
+func ObserveOutputSchema(mode string) (map[string]any, bool) {
+	s, ok := observeOutputSchemas[mode]
+	if !ok {
+		return nil, false
+	}
+	out := map[string]any{"$schema": "https://json-schema.org/draft/2020-12/schema", "title": "observe what=" + mode}
+	for k, v := range s {
+		out[k] = v
+	}
+	return out, true
+}
+
+// ObserveOutputSchemaModes returns the observe modes with a published output schema, sorted.
+func ObserveOutputSchemaModes() []string {
+	modes := make([]string, 0, len(observeOutputSchemas))
+	for m := range observeOutputSchemas {
+		modes = append(modes, m)
+	}
+	sort.Strings(modes)
+	return modes
+}
@@ -0,0 +1,219 @@
+// Purpose: Audits cookies (Set-Cookie and document.cookie) and web storage for insecure attributes and stored secrets.
+// Why: Cookie flags and localStorage token storage are the most common client-side auth weaknesses agents miss.
+// Docs: docs/features/feature/security-hardening/index.md
+
+package security
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/remediation"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/util"
+)
+
+// MaxCookieBytes is the per-cookie size browsers reliably accept (RFC 6265 minimum guarantee).
+const MaxCookieBytes = 4096
+
+// sensitiveStorageKeyPattern flags storage keys that conventionally hold credentials.
+var sensitiveStorageKeyPattern = regexp.MustCompile(`(?i)(token|secret|passw(or)?d|api[_-]?key|jwt|auth|session|credential|private[_-]?key)`)
+
+// StorageSnapshot is the extension-reported client-side state for one page.
+// Values are only inspected, never returned.
+type StorageSnapshot struct {
+	URL            string
+	DocumentCookie string
+	LocalStorage   map[string]string
+	SessionStorage map[string]string
+}
+
+// CookieAuditResult is the output of AuditCookies.
+type CookieAuditResult struct {
+	Findings        []SecurityFinding `json:"findings"`
+	Summary         ScanSummary       `json:"summary"`
+	CookiesChecked  int               `json:"cookies_checked"`
+	StorageKeys     int               `json:"storage_keys_checked"`
+	StorageIncluded bool              `json:"storage_included"`
+}
+
+// AuditCookies inspects Set-Cookie response headers from captured traffic and, when provided,
+// the page's document.cookie and web storage. Findings never include cookie or storage values.
+func AuditCookies(bodies []capture.NetworkBody, storage *StorageSnapshot) CookieAuditResult {
+	var findings []SecurityFinding
+	checked := 0
+	seen := make(map[string]bool)
+
+	for _, body := range bodies {
+		isHTTPS := strings.HasPrefix(body.URL, "https://")
+		for name, value := range body.ResponseHeaders {
+			if !strings.EqualFold(name, "set-cookie") || value == "" {
+				continue
+			}
+			for _, line := range strings.Split(value, "\n") {
+				line = strings.TrimSpace(line)
+				if line == "" {
+					continue
+				}
+				cookie := parseSingleCookie(line)
+				// Report each cookie once per origin to keep repeated responses from flooding findings.
+				key := util.ExtractOrigin(body.URL) + "|" + cookie.Name
+				if seen[key] {
+					continue
+				}
+				seen[key] = true
+				checked++
+				findings = append(findings, checkSingleCookie(cookie, body.URL, isHTTPS)...)
+				nameValue, _, _ := strings.Cut(line, ";")
+				if f := oversizedCookieFinding(cookie.Name, len(strings.TrimSpace(nameValue)), body.URL, "Set-Cookie"); f != nil {
+					findings = append(findings, *f)
+				}
+			}
+		}
+	}
+
+	result := CookieAuditResult{CookiesChecked: checked}
+	if storage != nil {
+		result.StorageIncluded = true
+		docFindings, docChecked := auditDocumentCookie(storage)
+		findings = append(findings, docFindings...)
+		result.CookiesChecked += docChecked
+		for _, area := range []struct {
+			name   string
+			values map[string]string
+		}{{"localStorage", storage.LocalStorage}, {"sessionStorage", storage.SessionStorage}} {
+			result.StorageKeys += len(area.values)
+			findings = append(findings, auditStorageArea(area.name, area.values, storage.URL)...)
+		}
+	}
+
+	if findings == nil {
+		findings = []SecurityFinding{}
+	}
+	result.Findings = findings
+	result.Summary = buildSummary(findings, bodies)
+	return result
+}
+
+// auditDocumentCookie flags JS-readable credential cookies and oversized cookies.
+func auditDocumentCookie(storage *StorageSnapshot) ([]SecurityFinding, int) {
+	var findings []SecurityFinding
+	checked := 0
+	for _, pair := range strings.Split(storage.DocumentCookie, ";") {
+		pair = strings.TrimSpace(pair)
+		name, _, ok := strings.Cut(pair, "=")
+		if !ok || name == "" {
+			continue
+		}
+		checked++
+		if sessionCookiePattern.MatchString(name) {
+			findings = append(findings, SecurityFinding{
+				Check: "cookies", FindingID: remediation.SecurityCookieMissingHttpOnly, Severity: "warning",
+				Title:       fmt.Sprintf("Session cookie '%s' readable from JavaScript", name),
+				Description: fmt.Sprintf("The cookie '%s' appears in document.cookie, so it is not HttpOnly and any injected script can read it.", name),
+				Location:    storage.URL,
+				Evidence:    fmt.Sprintf("document.cookie contains %s (value withheld)", name),
+				Remediation: "Set the cookie server-side with the HttpOnly flag.",
+			})
+		}
+		if f := oversizedCookieFinding(name, len(pair), storage.URL, "document.cookie"); f != nil {
+			findings = append(findings, *f)
+		}
+	}
+	return findings, checked
+}
+
+func oversizedCookieFinding(name string, size int, location, source string) *SecurityFinding {
+	if size <= MaxCookieBytes {
+		return nil
+	}
+	return &SecurityFinding{
+		Check: "cookies", FindingID: remediation.SecurityCookieOversized, Severity: "low",
+		Title:       fmt.Sprintf("Cookie '%s' is oversized (%d bytes)", name, size),
+		Description: fmt.Sprintf("The cookie '%s' exceeds %d bytes; browsers may silently drop it and it inflates every request.", name, MaxCookieBytes),
+		Location:    location,
+		Evidence:    fmt.Sprintf("%s: %s is %d bytes", source, name, size),
+		Remediation: "Store large state server-side and keep only an identifier in the cookie.",
+	}
+}
+
+// auditStorageArea flags credential-looking keys and secret-shaped values in web storage.
+func auditStorageArea(area string, values map[string]string, location string) []SecurityFinding {
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var findings []SecurityFinding
+	for _, key := range keys {
+		kind := storageSecretKind(values[key])
+		if kind == "" && !sensitiveStorageKeyPattern.MatchString(key) {
+			continue
+		}
+		severity := "medium"
+		evidence := fmt.Sprintf("%s key %q has a credential-like name (value withheld)", area, key)
+		if kind != "" {
+			severity = "high"
+			evidence = fmt.Sprintf("%s key %q holds a value matching %s (value withheld)", area, key, kind)
+		}
+		findings = append(findings, SecurityFinding{
+			Check:       "storage",
+			FindingID:   remediation.SecuritySecretInWebStorage,
+			Severity:    severity,
+			Title:       fmt.Sprintf("Secret stored in %s key '%s'", area, key),
+			Description: fmt.Sprintf("%s is readable by any script running on the origin, so an XSS bug exposes this value.", area),
+			Location:    location,
+			Evidence:    evidence,
+			Remediation: "Keep credentials in HttpOnly, Secure, SameSite cookies or in memory instead of web storage.",
+		})
+	}
+	return findings
+}
+
+// storageSecretKind names the credential pattern a value matches, or "" when none does.
+func storageSecretKind(value string) string {
+	switch {
+	case value == "":
+		return ""
+	case jwtPattern.MatchString(value):
+		return "JWT"
+	case awsKeyPattern.MatchString(value):
+		return "AWS access key"
+	case githubTokenPattern.MatchString(value):
+		return "GitHub token"
+	case stripeKeyPattern.MatchString(value):
+		return "Stripe secret key"
+	case privateKeyPattern.MatchString(value):
+		return "private key"
+	case bearerPattern.MatchString(value):
+		return "bearer token"
+	}
+	return ""
+}
+
+// LogAuditFindings records an audit run in the session security audit trail.
+func LogAuditFindings(action, location string, findings []SecurityFinding) {
+	bySeverity := make(map[string]int)
+	for _, f := range findings {
+		bySeverity[f.Severity]++
+	}
+	parts := make([]string, 0, len(bySeverity))
+	for _, sev := range []string{"critical", "high", "medium", "warning", "low", "info"} {
+		if n := bySeverity[sev]; n > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", n, sev))
+		}
+	}
+	reason := fmt.Sprintf("%d finding(s)", len(findings))
+	if len(parts) > 0 {
+		reason += ": " + strings.Join(parts, ", ")
+	}
+	logSecurityEvent(SecurityAuditEvent{
+		Action: action,
+		Origin: util.ExtractOrigin(location),
+		Reason: reason,
+		Source: "observe",
+	})
+}
@@ -0,0 +1,98 @@
+// Purpose: Tests for cookie attribute, oversized-cookie, and web-storage secret auditing.
+// Docs: docs/features/feature/security-hardening/index.md
+
+package security
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+)
+
+func findingTitles(findings []SecurityFinding) string {
+	titles := make([]string, 0, len(findings))
+	for _, f := range findings {
+		titles = append(titles, f.Title)
+	}
+	return strings.Join(titles, "\n")
+}
+
+func TestAuditCookies_SetCookieAttributesAndSize(t *testing.T) {
+	t.Parallel()
+	big := "prefs=" + strings.Repeat("x", MaxCookieBytes)
+	bodies := []capture.NetworkBody{
+		{URL: "https://app.test/login", ResponseHeaders: map[string]string{
+			"set-cookie": "session_id=abc\n" + big + "; Secure; HttpOnly; SameSite=Lax",
+		}},
+		// Same cookie from the same origin is reported once.
+		{URL: "https://app.test/refresh", ResponseHeaders: map[string]string{"Set-Cookie": "session_id=def"}},
+	}
+
+	result := AuditCookies(bodies, nil)
+	titles := findingTitles(result.Findings)
+	for _, want := range []string{
+		"Session cookie 'session_id' missing HttpOnly flag",
+		"Cookie 'session_id' missing Secure flag on HTTPS",
+		"Cookie 'session_id' missing SameSite attribute",
+		"Cookie 'prefs' is oversized",
+	} {
+		if !strings.Contains(titles, want) {
+			t.Errorf("missing finding %q in:\n%s", want, titles)
+		}
+	}
+	if result.CookiesChecked != 2 {
+		t.Fatalf("CookiesChecked = %d, want 2 (deduplicated per origin)", result.CookiesChecked)
+	}
+	if result.StorageIncluded {
+		t.Fatal("StorageIncluded should be false without a snapshot")
+	}
+}
+
+func TestAuditCookies_StorageSecretsNeverLeakValues(t *testing.T) {
+	t.Parallel()
+	jwt := "eyJhbGciOiJIUzI1NiJ9.eyJzdWIiOiJ1c2VyIn0.sig"
+	result := AuditCookies(nil, &StorageSnapshot{
+		URL:            "https://app.test/",
+		DocumentCookie: "theme=dark; auth_token=plain-secret-value",
+		LocalStorage:   map[string]string{"cachedUser": jwt, "theme": "dark", "refreshToken": "opaque-refresh"},
+		SessionStorage: map[string]string{"step": "2"},
+	})
+
+	if result.StorageKeys != 4 || result.CookiesChecked != 2 {
+		t.Fatalf("StorageKeys=%d CookiesChecked=%d, want 4 and 2", result.StorageKeys, result.CookiesChecked)
+	}
+	bySeverity := map[string]string{}
+	for _, f := range result.Findings {
+		bySeverity[f.Title] = f.Severity
+	}
+	if bySeverity["Secret stored in localStorage key 'cachedUser'"] != "high" {
+		t.Errorf("JWT value in localStorage should be high severity: %v", bySeverity)
+	}
+	if bySeverity["Secret stored in localStorage key 'refreshToken'"] != "medium" {
+		t.Errorf("credential-named key should be medium severity: %v", bySeverity)
+	}
+	if _, ok := bySeverity["Session cookie 'auth_token' readable from JavaScript"]; !ok {
+		t.Errorf("JS-readable auth cookie not flagged: %v", bySeverity)
+	}
+	out, _ := json.Marshal(result)
+	for _, secret := range []string{jwt, "plain-secret-value", "opaque-refresh"} {
+		if strings.Contains(string(out), secret) {
+			t.Fatalf("audit output leaks %q", secret)
+		}
+	}
+}
+
+func TestLogAuditFindings_AppendsSecurityAuditEvent(t *testing.T) {
+	before := len(GetSecurityAuditEvents())
+	LogAuditFindings("cookie_audit", "https://app.test/path", []SecurityFinding{{Severity: "high"}, {Severity: "warning"}})
+	events := GetSecurityAuditEvents()
+	if len(events) != before+1 {
+		t.Fatalf("len(events) = %d, want %d", len(events), before+1)
+	}
+	last := events[len(events)-1]
+	if last.Action != "cookie_audit" || last.Origin != "https://app.test" || last.Reason != "2 finding(s): 1 high, 1 warning" {
+		t.Fatalf("event = %+v", last)
+	}
+}
@@ -0,0 +1,597 @@
+// Purpose: Diffs the Content-Security-Policy a page actually serves against the policy its observed traffic needs.
+// Why: Turns "generate a CSP" into actionable edits: sources the current policy will block, and sources it allows too broadly.
+// Docs: docs/features/feature/security-hardening/index.md
+
+package security
+
+import (
+	"net/url"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/util"
+)
+
+// CSP diff statuses.
+const (
+	CSPDiffNoPolicy   = "no_policy"   // no served policy was found on the captured document
+	CSPDiffWouldBreak = "would_break" // observed resources are blocked (or reported) by the served policy
+	CSPDiffTighten    = "can_tighten" // nothing is blocked, but some directives are broader than the traffic needs
+	CSPDiffOK         = "ok"
+)
+
+// maxCSPDiffExamples bounds the example URLs listed per missing source.
+const maxCSPDiffExamples = 3
+
+// CSPDiffInput is the captured state the diff reads.
+type CSPDiffInput struct {
+	PageURL   string
+	Bodies    []capture.NetworkBody
+	Waterfall []capture.NetworkWaterfallEntry
+}
+
+// ServedCSP is the policy found on the captured document response.
+type ServedCSP struct {
+	Policy string `json:"policy"`
+	// Source is "header", "report_only_header", or "meta".
+	Source     string              `json:"source"`
+	Enforced   bool                `json:"enforced"`
+	URL        string              `json:"url"`
+	Directives map[string][]string `json:"directives"`
+}
+
+// CSPMissingSource is an observed origin the served policy does not allow.
+type CSPMissingSource struct {
+	Directive string `json:"directive"`
+	Source    string `json:"source"`
+	// GoverningDirective is the served directive that decides the load (default-src when Directive is absent).
+	GoverningDirective string   `json:"governing_directive"`
+	Examples           []string `json:"examples"`
+}
+
+// CSPBroadSource is a served source that allows more than the observed traffic needs.
+type CSPBroadSource struct {
+	Directive string `json:"directive"`
+	Source    string `json:"source"`
+	// Kind is "wildcard", "scheme", "wildcard_host", "unsafe_inline", "unsafe_eval", or "unused".
+	Kind      string   `json:"kind"`
+	Reason    string   `json:"reason"`
+	Suggested []string `json:"suggested,omitempty"`
+}
+
+// CSPDiffSummary counts findings.
+type CSPDiffSummary struct {
+	Missing int `json:"missing"`
+	Broad   int `json:"broad"`
+}
+
+// CSPDiffResult is the response for analyze(what="security_audit", category="csp_diff").
+type CSPDiffResult struct {
+	Status    string              `json:"status"`
+	PageURL   string              `json:"page_url"`
+	Served    *ServedCSP          `json:"served,omitempty"`
+	Generated string              `json:"generated_policy"`
+	Observed  map[string][]string `json:"observed"`
+	Missing   []CSPMissingSource  `json:"missing"`
+	Broad     []CSPBroadSource    `json:"broad"`
+	// SuggestedPolicy is the served policy with missing sources added and wildcards narrowed to observed origins.
+	SuggestedPolicy string         `json:"suggested_policy,omitempty"`
+	Summary         CSPDiffSummary `json:"summary"`
+	Warnings        []string       `json:"warnings,omitempty"`
+}
+
+var (
+	cspMetaTagPattern     = regexp.MustCompile(`(?is)<meta\b[^>]*http-equiv\s*=\s*["']?content-security-policy["']?[^>]*>`)
+	cspMetaContentPattern = regexp.MustCompile(`(?is)\bcontent\s*=\s*(?:"([^"]*)"|'([^']*)')`)
+)
+
+// cspFetchDirectives fall back to default-src when absent from a policy.
+var cspFetchDirectives = map[string]bool{
+	"script-src": true, "style-src": true, "img-src": true, "font-src": true, "connect-src": true,
+	"media-src": true, "object-src": true, "frame-src": true, "worker-src": true, "manifest-src": true,
+}
+
+// DiffCSP compares the CSP served on the page's document response with the sources observed
+// in captured traffic.
+//
+// Invariants:
+// - A source is "missing" only when an observed request would be blocked by the served policy,
+// so adding every missing source never widens the policy beyond observed traffic.
+// - "unused" findings are advisory: pages that were not visited may still need those sources.
+func DiffCSP(in CSPDiffInput) CSPDiffResult {
+	pageOrigin := util.ExtractOrigin(in.PageURL)
+	observed, examples := observeCSPSources(in)
+	result := CSPDiffResult{
+		Status:    CSPDiffOK,
+		PageURL:   in.PageURL,
+		Generated: generatedCSPPolicy(observed, pageOrigin),
+		Observed:  observed,
+		Missing:   []CSPMissingSource{},
+		Broad:     []CSPBroadSource{},
+	}
+	if in.PageURL == "" {
+		result.Warnings = append(result.Warnings, "No tracked page URL; the most recent HTML response is treated as the page, and 'self' cannot be resolved.")
+	}
+
+	served, warnings := findServedCSP(in)
+	result.Warnings = append(result.Warnings, warnings...)
+	if served == nil {
+		result.Status = CSPDiffNoPolicy
+		result.SuggestedPolicy = result.Generated
+		result.Warnings = append(result.Warnings, "No Content-Security-Policy header or meta tag was found on the captured document; deploy generated_policy as Content-Security-Policy-Report-Only first.")
+		return result
+	}
+	result.Served = served
+	if len(observed) == 0 {
+		result.Warnings = append(result.Warnings, "No subresource loads were captured; browse the page before diffing.")
+	}
+
+	for _, directive := range sortedDirectiveNames(observed) {
+		governing, sources, restricted := governingDirective(served.Directives, directive)
+		if !restricted {
+			continue
+		}
+		for _, origin := range observed[directive] {
+			if cspAllows(sources, origin, pageOrigin) {
+				continue
+			}
+			result.Missing = append(result.Missing, CSPMissingSource{
+				Directive:          directive,
+				Source:             origin,
+				GoverningDirective: governing,
+				Examples:           examples[directive+"|"+origin],
+			})
+		}
+	}
+	result.Broad = broadCSPSources(served.Directives, observed, pageOrigin)
+	result.SuggestedPolicy = suggestCSPPolicy(served.Directives, result.Missing, result.Broad)
+	result.Summary = CSPDiffSummary{Missing: len(result.Missing), Broad: len(result.Broad)}
+
+	switch {
+	case len(result.Missing) > 0:
+		result.Status = CSPDiffWouldBreak
+		if !served.Enforced {
+			result.Warnings = append(result.Warnings, "The served policy is report-only: missing sources produce violation reports now and will break once it is enforced.")
+		}
+	case len(result.Broad) > 0:
+		result.Status = CSPDiffTighten
+	}
+	return result
+}
+
+// observeCSPSources maps captured subresource loads to the directive governing each,
+// returning sorted origins per directive and example URLs keyed by "directive|origin".
+func observeCSPSources(in CSPDiffInput) (map[string][]string, map[string][]string) {
+	sets := map[string]map[string]bool{}
+	examples := map[string][]string{}
+	note := func(directive, rawURL string) {
+		origin := util.ExtractOrigin(rawURL)
+		if directive == "" || origin == "" {
+			return
+		}
+		if sets[directive] == nil {
+			sets[directive] = map[string]bool{}
+		}
+		sets[directive][origin] = true
+		key := directive + "|" + origin
+		if len(examples[key]) < maxCSPDiffExamples && !slices.Contains(examples[key], rawURL) {
+			examples[key] = append(examples[key], rawURL)
+		}
+	}
+	for _, body := range in.Bodies {
+		if isHTMLResponse(body) {
+			continue // documents and frames are governed by navigation, not fetch directives
+		}
+		note(cspDirectiveForContentType(body.ContentType), body.URL)
+	}
+	for _, entry := range in.Waterfall {
+		note(cspDirectiveForInitiator(entry.InitiatorType, entry.URL), entry.URL)
+	}
+
+	observed := make(map[string][]string, len(sets))
+	for directive, origins := range sets {
+		observed[directive] = sortedKeys(origins)
+	}
+	return observed, examples
+}
+
+func cspDirectiveForContentType(contentType string) string {
+	ct := strings.ToLower(contentType)
+	switch {
+	case strings.Contains(ct, "javascript"):
+		return "script-src"
+	case strings.Contains(ct, "css"):
+		return "style-src"
+	case strings.Contains(ct, "font"):
+		return "font-src"
+	case strings.Contains(ct, "image"):
+		return "img-src"
+	case strings.Contains(ct, "video"), strings.Contains(ct, "audio"):
+		return "media-src"
+	default:
+		return "connect-src"
+	}
+}
+
+func cspDirectiveForInitiator(initiator, rawURL string) string {
+	switch strings.ToLower(initiator) {
+	case "script":
+		return "script-src"
+	case "img", "image", "input":
+		return "img-src"
+	case "iframe", "frame", "subdocument":
+		return "frame-src"
+	case "video", "audio", "track":
+		return "media-src"
+	case "fetch", "xmlhttprequest", "beacon", "eventsource", "websocket":
+		return "connect-src"
+	case "link", "css":
+		path := strings.ToLower(rawURL)
+		if u, err := url.Parse(rawURL); err == nil {
+			path = strings.ToLower(u.Path)
+		}
+		switch {
+		case hasAnySuffix(path, ".woff", ".woff2", ".ttf", ".otf", ".eot"):
+			return "font-src"
+		case hasAnySuffix(path, ".png", ".jpg", ".jpeg", ".gif", ".svg", ".webp", ".avif", ".ico"):
+			return "img-src"
+		case hasAnySuffix(path, ".js", ".mjs"):
+			return "script-src"
+		}
+		return "style-src"
+	}
+	return ""
+}
+
+// findServedCSP locates the page's document response and reads its policy: the enforced header
+// first, then the report-only header, then a <meta http-equiv> tag in the captured HTML.
+func findServedCSP(in CSPDiffInput) (*ServedCSP, []string) {
+	var document *capture.NetworkBody
+	for i := len(in.Bodies) - 1; i >= 0; i-- {
+		body := &in.Bodies[i]
+		if !isHTMLResponse(*body) {
+			continue
+		}
+		if in.PageURL == "" || sameDocument(body.URL, in.PageURL) {
+			document = body
+			break
+		}
+	}
+	if document == nil {
+		return nil, []string{"The page's HTML document response was not captured; reload the tracked page so its headers can be read."}
+	}
+
+	served := &ServedCSP{URL: document.URL}
+	if v := headerValue(document.ResponseHeaders, "Content-Security-Policy"); v != "" {
+		served.Policy, served.Source, served.Enforced = v, "header", true
+	} else if v := headerValue(document.ResponseHeaders, "Content-Security-Policy-Report-Only"); v != "" {
+		served.Policy, served.Source = v, "report_only_header"
+	} else if m := cspMetaTagPattern.FindString(document.ResponseBody); m != "" {
+		if c := cspMetaContentPattern.FindStringSubmatch(m); c != nil {
+			served.Policy, served.Source, served.Enforced = c[1]+c[2], "meta", true
+		}
+	}
+	if served.Policy == "" {
+		return nil, nil
+	}
+
+	var warnings []string
+	if policies := strings.Split(served.Policy, ","); len(policies) > 1 {
+		warnings = append(warnings, "The document serves several policies; only the first is diffed, but browsers enforce all of them.")
+		served.Policy = strings.TrimSpace(policies[0])
+	}
+	served.Directives = ParseCSP(served.Policy)
+	return served, warnings
+}
+
+// ParseCSP splits a policy into directive → sources. Directive names are lowercased;
+// for repeated directives the first wins, as in browsers.
+func ParseCSP(policy string) map[string][]string {
+	directives := map[string][]string{}
+	for _, part := range strings.Split(policy, ";") {
+		fields := strings.Fields(part)
+		if len(fields) == 0 {
+			continue
+		}
+		name := strings.ToLower(fields[0])
+		if _, seen := directives[name]; seen {
+			continue
+		}
+		directives[name] = fields[1:]
+	}
+	return directives
+}
+
+// governingDirective returns the served directive that decides loads for directive.
+// restricted is false when neither the directive nor its default-src fallback is present.
+func governingDirective(served map[string][]string, directive string) (string, []string, bool) {
+	if sources, ok := served[directive]; ok {
+		return directive, sources, true
+	}
+	if cspFetchDirectives[directive] {
+		if sources, ok := served["default-src"]; ok {
+			return "default-src", sources, true
+		}
+	}
+	if directive == "frame-src" {
+		if sources, ok := served["child-src"]; ok {
+			return "child-src", sources, true
+		}
+	}
+	return "", nil, false
+}
+
+// cspAllows reports whether any source in the list matches origin.
+func cspAllows(sources []string, origin, pageOrigin string) bool {
+	target, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+	for _, src := range sources {
+		if cspSourceMatches(src, target, pageOrigin) {
+			return true
+		}
+	}
+	return false
+}
+
+func cspSourceMatches(src string, target *url.URL, pageOrigin string) bool {
+	lower := strings.ToLower(src)
+	scheme := target.Scheme
+	switch {
+	case lower == "*":
+		return scheme == "http" || scheme == "https" || scheme == "ws" || scheme == "wss"
+	case lower == "'self'":
+		if pageOrigin == "" {
+			return false
+		}
+		page, err := url.Parse(pageOrigin)
+		if err != nil {
+			return false
+		}
+		// CSP3: 'self' also matches WebSocket connections to the page's host.
+		return target.Host == page.Host && (scheme == page.Scheme || schemeUpgrades(page.Scheme, scheme) ||
+			(page.Scheme == "https" && scheme == "wss") || (page.Scheme == "http" && (scheme == "ws" || scheme == "wss")))
+	case strings.HasPrefix(lower, "'"):
+		return false // nonces, hashes, and keywords do not allow origins
+	case strings.HasSuffix(lower, ":") && !strings.Contains(lower, "/"):
+		want := strings.TrimSuffix(lower, ":")
+		return scheme == want || schemeUpgrades(want, scheme)
+	}
+
+	srcScheme, rest := "", lower
+	if i := strings.Index(lower, "://"); i >= 0 {
+		srcScheme, rest = lower[:i], lower[i+3:]
+	}
+	if srcScheme != "" && srcScheme != scheme && !schemeUpgrades(srcScheme, scheme) {
+		return false
+	}
+	if srcScheme == "" && scheme != "https" && scheme != "wss" && scheme != "http" && scheme != "ws" {
+		return false
+	}
+	if i := strings.IndexByte(rest, '/'); i >= 0 {
+		rest = rest[:i] // paths are ignored: the diff compares origins
+	}
+	host, port := rest, ""
+	if i := strings.LastIndexByte(rest, ':'); i >= 0 {
+		host, port = rest[:i], rest[i+1:]
+	}
+	targetHost := strings.ToLower(target.Hostname())
+	if strings.HasPrefix(host, "*.") {
+		if !strings.HasSuffix(targetHost, host[1:]) {
+			return false
+		}
+	} else if host != targetHost {
+		return false
+	}
+	switch port {
+	case "*":
+		return true
+	case "":
+		return target.Port() == "" || target.Port() == defaultPort(scheme)
+	default:
+		return port == target.Port() || (target.Port() == "" && port == defaultPort(scheme))
+	}
+}
+
+// schemeUpgrades reports whether a source scheme also allows the secure upgrade of itself.
+func schemeUpgrades(from, to string) bool {
+	return (from == "http" && to == "https") || (from == "ws" && to == "wss")
+}
+
+func defaultPort(scheme string) string {
+	switch scheme {
+	case "http", "ws":
+		return "80"
+	case "https", "wss":
+		return "443"
+	}
+	return ""
+}
+
+// broadCSPSources flags served sources that allow more than observed traffic needs.
+func broadCSPSources(served, observed map[string][]string, pageOrigin string) []CSPBroadSource {
+	findings := []CSPBroadSource{}
+	for _, directive := range sortedDirectiveNames(served) {
+		sources := served[directive]
+		needs := originsGovernedBy(served, observed, directive)
+		hasNonceOrHash := false
+		for _, src := range sources {
+			lower := strings.ToLower(src)
+			if strings.HasPrefix(lower, "'nonce-") || strings.HasPrefix(lower, "'sha") {
+				hasNonceOrHash = true
+			}
+		}
+		scriptLike := directive == "script-src" || directive == "default-src"
+		for _, src := range sources {
+			lower := strings.ToLower(src)
+			var f *CSPBroadSource
+			switch {
+			case lower == "*":
+				f = &CSPBroadSource{Kind: "wildcard", Reason: "Allows loads from any origin"}
+			case lower == "https:" || lower == "http:" || lower == "wss:" || lower == "ws:":
+				f = &CSPBroadSource{Kind: "scheme", Reason: "Allows every host over " + strings.TrimSuffix(lower, ":")}
+			case (lower == "data:" || lower == "blob:") && scriptLike:
+				f = &CSPBroadSource{Kind: "scheme", Reason: lower + " sources let injected markup run script"}
+			case lower == "'unsafe-inline'" && scriptLike && !hasNonceOrHash:
+				findings = append(findings, CSPBroadSource{Directive: directive, Source: src, Kind: "unsafe_inline",
+					Reason: "Inline script is allowed, which defeats XSS protection; use nonces or hashes"})
+				continue
+			case lower == "'unsafe-eval'" && scriptLike:
+				findings = append(findings, CSPBroadSource{Directive: directive, Source: src, Kind: "unsafe_eval",
+					Reason: "eval() and new Function() are allowed; remove unless a dependency requires it"})
+				continue
+			case strings.Contains(lower, "*."):
+				matched := matchingOrigins([]string{src}, needs, pageOrigin)
+				f = &CSPBroadSource{Kind: "wildcard_host", Reason: "Wildcard host allows every subdomain"}
+				if len(matched) == 0 {
+					f.Kind, f.Reason = "unused", "No captured request used this source"
+				}
+				findings = append(findings, CSPBroadSource{Directive: directive, Source: src, Kind: f.Kind, Reason: f.Reason, Suggested: matched})
+				continue
+			case !strings.HasPrefix(lower, "'") && !strings.HasSuffix(lower, ":"):
+				if len(needs) > 0 && len(matchingOrigins([]string{src}, needs, pageOrigin)) == 0 {
+					findings = append(findings, CSPBroadSource{Directive: directive, Source: src, Kind: "unused",
+						Reason: "No captured request used this source; remove it if no unvisited page needs it"})
+				}
+				continue
+			}
+			if f == nil {
+				continue
+			}
+			f.Directive, f.Source = directive, src
+			f.Suggested = minimalSources(needs, pageOrigin)
+			findings = append(findings, *f)
+		}
+	}
+	return findings
+}
+
+// originsGovernedBy returns the observed origins whose loads the served directive decides.
+func originsGovernedBy(served, observed map[string][]string, directive string) []string {
+	set := map[string]bool{}
+	for observedDirective, origins := range observed {
+		if governing, _, ok := governingDirective(served, observedDirective); ok && governing == directive {
+			for _, o := range origins {
+				set[o] = true
+			}
+		}
+	}
+	return sortedKeys(set)
+}
+
+func matchingOrigins(sources, origins []string, pageOrigin string) []string {
+	var out []string
+	for _, o := range origins {
+		if cspAllows(sources, o, pageOrigin) {
+			out = append(out, o)
+		}
+	}
+	return out
+}
+
+// minimalSources lists the sources a directive needs: 'self' for the page origin plus each other origin.
+func minimalSources(origins []string, pageOrigin string) []string {
+	out := []string{"'self'"}
+	for _, o := range origins {
+		if o != pageOrigin {
+			out = append(out, o)
+		}
+	}
+	return out
+}
+
+// suggestCSPPolicy applies missing sources and narrows wildcard, scheme, and wildcard-host
+// sources to observed origins. Keywords such as 'unsafe-inline' need code changes and are kept.
+func suggestCSPPolicy(served map[string][]string, missing []CSPMissingSource, broad []CSPBroadSource) string {
+	directives := make(map[string][]string, len(served))
+	for name, sources := range served {
+		directives[name] = append([]string(nil), sources...)
+	}
+	for _, f := range broad {
+		switch f.Kind {
+		case "wildcard", "scheme", "wildcard_host":
+			directives[f.Directive] = replaceSource(directives[f.Directive], f.Source, f.Suggested)
+		}
+	}
+	for _, m := range missing {
+		if _, ok := directives[m.Directive]; !ok {
+			// Copy the fallback so adding a source here does not widen every other fetch directive.
+			directives[m.Directive] = append([]string(nil), directives[m.GoverningDirective]...)
+		}
+		if !slices.Contains(directives[m.Directive], m.Source) {
+			directives[m.Directive] = append(directives[m.Directive], m.Source)
+		}
+	}
+
+	parts := make([]string, 0, len(directives))
+	for _, name := range sortedDirectiveNames(directives) {
+		sources := directives[name]
+		if len(sources) == 0 {
+			parts = append(parts, name)
+			continue
+		}
+		parts = append(parts, name+" "+strings.Join(sources, " "))
+	}
+	return strings.Join(parts, "; ")
+}
+
+func replaceSource(sources []string, old string, with []string) []string {
+	out := make([]string, 0, len(sources)+len(with))
+	for _, s := range sources {
+		if s != old {
+			out = append(out, s)
+		}
+	}
+	for _, w := range with {
+		if !slices.Contains(out, w) {
+			out = append(out, w)
+		}
+	}
+	return out
+}
+
+// generatedCSPPolicy builds the policy observed traffic needs, default-src 'self' first.
+func generatedCSPPolicy(observed map[string][]string, pageOrigin string) string {
+	parts := []string{"default-src 'self'"}
+	for _, directive := range sortedDirectiveNames(observed) {
+		parts = append(parts, directive+" "+strings.Join(minimalSources(observed[directive], pageOrigin), " "))
+	}
+	return strings.Join(parts, "; ")
+}
+
+// sortedDirectiveNames orders default-src first, then alphabetically.
+func sortedDirectiveNames(directives map[string][]string) []string {
+	names := make([]string, 0, len(directives))
+	for name := range directives {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if (names[i] == "default-src") != (names[j] == "default-src") {
+			return names[i] == "default-src"
+		}
+		return names[i] < names[j]
+	})
+	return names
+}
+
+func headerValue(headers map[string]string, name string) string {
+	for k, v := range headers {
+		if strings.EqualFold(k, name) {
+			return v
+		}
+	}
+	return ""
+}
+
+func hasAnySuffix(s string, suffixes ...string) bool {
+	for _, suffix := range suffixes {
+		if strings.HasSuffix(s, suffix) {
+			return true
+		}
+	}
+	return false
+}
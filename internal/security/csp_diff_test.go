@@ -0,0 +1,142 @@
+// Purpose: Tests diffing the served CSP against the sources observed in captured traffic.
+// Docs: docs/features/feature/security-hardening/index.md
+
+package security
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+)
+
+func TestDiffCSP_MissingAndBroadSources(t *testing.T) {
+	t.Parallel()
+	result := DiffCSP(CSPDiffInput{
+		PageURL: "https://app.example.com/checkout",
+		Bodies: []capture.NetworkBody{
+			{URL: "https://app.example.com/checkout", ContentType: "text/html", ResponseHeaders: map[string]string{
+				"content-security-policy": "default-src 'self'; script-src 'self' https://js.stripe.com 'unsafe-inline'; img-src *; connect-src 'self' https://*.example.net https://old.example.org",
+			}},
+			{URL: "https://js.stripe.com/v3/", ContentType: "application/javascript"},
+			{URL: "https://cdn.segment.com/analytics.js", ContentType: "text/javascript"},
+			{URL: "https://api.example.net/v1/cart", ContentType: "application/json"},
+			{URL: "https://fonts.gstatic.com/s/inter.woff2", ContentType: "font/woff2"},
+		},
+		Waterfall: []capture.NetworkWaterfallEntry{
+			{URL: "https://images.example.com/hero.png", InitiatorType: "img"},
+		},
+	})
+
+	if result.Status != CSPDiffWouldBreak || result.Served == nil || !result.Served.Enforced || result.Served.Source != "header" {
+		t.Fatalf("status = %s, served = %+v", result.Status, result.Served)
+	}
+	missing := map[string]string{}
+	for _, m := range result.Missing {
+		missing[m.Directive+" "+m.Source] = m.GoverningDirective
+	}
+	if len(missing) != 2 || missing["script-src https://cdn.segment.com"] != "script-src" || missing["font-src https://fonts.gstatic.com"] != "default-src" {
+		t.Fatalf("missing = %v", missing)
+	}
+
+	broad := map[string]string{}
+	for _, b := range result.Broad {
+		broad[b.Directive+" "+b.Source] = b.Kind
+	}
+	want := map[string]string{
+		"img-src *":                           "wildcard",
+		"script-src 'unsafe-inline'":          "unsafe_inline",
+		"connect-src https://*.example.net":   "wildcard_host",
+		"connect-src https://old.example.org": "unused",
+	}
+	for k, kind := range want {
+		if broad[k] != kind {
+			t.Errorf("broad[%s] = %q, want %q (all: %v)", k, broad[k], kind, broad)
+		}
+	}
+
+	for _, part := range []string{
+		"font-src 'self' https://fonts.gstatic.com",
+		"img-src 'self' https://images.example.com",
+		"https://cdn.segment.com",
+		"https://api.example.net",
+		"'unsafe-inline'",
+	} {
+		if !strings.Contains(result.SuggestedPolicy, part) {
+			t.Errorf("suggested policy missing %q: %s", part, result.SuggestedPolicy)
+		}
+	}
+	if strings.Contains(result.SuggestedPolicy, "*") {
+		t.Errorf("suggested policy still has wildcards: %s", result.SuggestedPolicy)
+	}
+}
+
+func TestDiffCSP_MetaAndReportOnly(t *testing.T) {
+	t.Parallel()
+	meta := DiffCSP(CSPDiffInput{
+		PageURL: "https://app.example.com/",
+		Bodies: []capture.NetworkBody{
+			{URL: "https://app.example.com/", ContentType: "text/html; charset=utf-8",
+				ResponseBody: `<head><meta http-equiv="Content-Security-Policy" content="default-src 'self' https:"></head>`},
+			{URL: "https://app.example.com/main.js", ContentType: "application/javascript"},
+		},
+	})
+	if meta.Served == nil || meta.Served.Source != "meta" || meta.Status != CSPDiffTighten || meta.Broad[0].Kind != "scheme" {
+		t.Fatalf("meta result = %+v", meta)
+	}
+
+	reportOnly := DiffCSP(CSPDiffInput{
+		PageURL: "https://app.example.com/",
+		Bodies: []capture.NetworkBody{
+			{URL: "https://app.example.com/", ContentType: "text/html", ResponseHeaders: map[string]string{
+				"Content-Security-Policy-Report-Only": "default-src 'self'",
+			}},
+			{URL: "wss://app.example.com/socket", ContentType: "application/json"},
+			{URL: "https://widget.example.io/w.js", ContentType: "application/javascript"},
+		},
+	})
+	if reportOnly.Served.Enforced || reportOnly.Status != CSPDiffWouldBreak || len(reportOnly.Missing) != 1 || len(reportOnly.Warnings) == 0 {
+		t.Fatalf("report-only result = %+v", reportOnly)
+	}
+}
+
+func TestDiffCSP_NoPolicy(t *testing.T) {
+	t.Parallel()
+	result := DiffCSP(CSPDiffInput{
+		PageURL: "https://app.example.com/",
+		Bodies: []capture.NetworkBody{
+			{URL: "https://app.example.com/", ContentType: "text/html"},
+			{URL: "https://cdn.example.com/app.css", ContentType: "text/css"},
+		},
+	})
+	if result.Status != CSPDiffNoPolicy || result.SuggestedPolicy != "default-src 'self'; style-src 'self' https://cdn.example.com" {
+		t.Fatalf("result = %+v", result)
+	}
+}
+
+func TestCSPSourceMatching(t *testing.T) {
+	t.Parallel()
+	page := "https://app.example.com"
+	cases := []struct {
+		sources []string
+		origin  string
+		want    bool
+	}{
+		{[]string{"'self'"}, "https://app.example.com", true},
+		{[]string{"'self'"}, "https://cdn.example.com", false},
+		{[]string{"https://*.example.com"}, "https://cdn.example.com", true},
+		{[]string{"https://*.example.com"}, "https://example.com", false},
+		{[]string{"cdn.example.com"}, "https://cdn.example.com", true},
+		{[]string{"http://cdn.example.com"}, "https://cdn.example.com", true},
+		{[]string{"https://cdn.example.com:8443"}, "https://cdn.example.com", false},
+		{[]string{"https://cdn.example.com:*"}, "https://cdn.example.com:8443", true},
+		{[]string{"https:"}, "https://any.example.org", true},
+		{[]string{"*"}, "wss://rt.example.com", true},
+		{[]string{"'none'"}, "https://app.example.com", false},
+	}
+	for _, c := range cases {
+		if got := cspAllows(c.sources, c.origin, page); got != c.want {
+			t.Errorf("cspAllows(%v, %s) = %v, want %v", c.sources, c.origin, got, c.want)
+		}
+	}
+}
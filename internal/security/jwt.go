@@ -0,0 +1,354 @@
+// Purpose: Detects and decodes (without verifying) JWTs seen in captured auth headers, cookies, and bodies.
+// Why: Agents debugging auth failures need issuer/audience/expiry context without ever seeing raw token values.
+// Docs: docs/features/feature/security-hardening/index.md
+
+package security
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+)
+
+// Token status values reported by ScanAuthTokens.
+const (
+	TokenStatusValid        = "valid"
+	TokenStatusExpiringSoon = "expiring_soon"
+	TokenStatusExpired      = "expired"
+	TokenStatusNotYetValid  = "not_yet_valid"
+	TokenStatusNoExpiry     = "no_expiry"
+)
+
+// DefaultExpiringWithin is the window in which a valid token is flagged as expiring soon.
+const DefaultExpiringWithin = 5 * time.Minute
+
+// maxTokensPerSource bounds JWT matches taken from a single header or body.
+const maxTokensPerSource = 5
+
+// plainClaims are reported verbatim; every other claim value is redacted.
+var plainClaims = map[string]bool{"iss": true, "aud": true, "exp": true, "iat": true, "nbf": true}
+
+// DecodedJWT is the unverified header and payload of a JWT.
+type DecodedJWT struct {
+	Header map[string]any
+	Claims map[string]any
+}
+
+// AuthToken describes one distinct JWT observed in captured traffic. Raw values are never included.
+type AuthToken struct {
+	TokenID          string         `json:"token_id"`
+	Source           string         `json:"source"`
+	Name             string         `json:"name,omitempty"`
+	Method           string         `json:"method,omitempty"`
+	URL              string         `json:"url"`
+	LastSeen         string         `json:"last_seen,omitempty"`
+	Occurrences      int            `json:"occurrences"`
+	Algorithm        string         `json:"alg,omitempty"`
+	KeyID            string         `json:"kid,omitempty"`
+	Issuer           string         `json:"issuer,omitempty"`
+	Audience         []string       `json:"audience,omitempty"`
+	IssuedAt         string         `json:"issued_at,omitempty"`
+	NotBefore        string         `json:"not_before,omitempty"`
+	ExpiresAt        string         `json:"expires_at,omitempty"`
+	ExpiresInSeconds *int64         `json:"expires_in_seconds,omitempty"`
+	Status           string         `json:"status"`
+	Claims           map[string]any `json:"claims"`
+}
+
+// AuthStateSummary counts tokens by status.
+type AuthStateSummary struct {
+	Total        int `json:"total"`
+	Valid        int `json:"valid"`
+	ExpiringSoon int `json:"expiring_soon"`
+	Expired      int `json:"expired"`
+	NotYetValid  int `json:"not_yet_valid"`
+	NoExpiry     int `json:"no_expiry"`
+}
+
+// DecodeJWT splits a compact JWT and decodes its header and payload.
+// The signature is ignored: this is for inspection, never for trust decisions.
+func DecodeJWT(raw string) (*DecodedJWT, error) {
+	parts := strings.Split(strings.TrimSpace(raw), ".")
+	if len(parts) != 3 {
+		return nil, errors.New("jwt: expected 3 dot-separated segments")
+	}
+	header, err := decodeJWTSegment(parts[0])
+	if err != nil {
+		return nil, errors.New("jwt: invalid header: " + err.Error())
+	}
+	claims, err := decodeJWTSegment(parts[1])
+	if err != nil {
+		return nil, errors.New("jwt: invalid payload: " + err.Error())
+	}
+	return &DecodedJWT{Header: header, Claims: claims}, nil
+}
+
+func decodeJWTSegment(seg string) (map[string]any, error) {
+	data, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(seg, "="))
+	if err != nil {
+		return nil, err
+	}
+	var out map[string]any
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ScanAuthTokens finds JWTs in captured network bodies and reports their decoded state.
+// Sources: Authorization and Cookie request headers, Set-Cookie response headers,
+// and JWT-shaped strings in request/response bodies. Tokens are deduplicated by hash;
+// the most recent occurrence wins. Results are ordered expired first, then by expiry.
+func ScanAuthTokens(bodies []capture.NetworkBody, now time.Time, expiringWithin time.Duration) ([]AuthToken, AuthStateSummary) {
+	if expiringWithin <= 0 {
+		expiringWithin = DefaultExpiringWithin
+	}
+	byID := make(map[string]*AuthToken)
+	for _, body := range bodies {
+		for _, c := range tokenCandidates(body) {
+			recordToken(byID, body, c, now, expiringWithin)
+		}
+	}
+
+	tokens := make([]AuthToken, 0, len(byID))
+	var summary AuthStateSummary
+	for _, t := range byID {
+		tokens = append(tokens, *t)
+		summary.add(t.Status)
+	}
+	sort.Slice(tokens, func(i, j int) bool {
+		ri, rj := statusRank(tokens[i].Status), statusRank(tokens[j].Status)
+		if ri != rj {
+			return ri < rj
+		}
+		if tokens[i].ExpiresAt != tokens[j].ExpiresAt {
+			return tokens[i].ExpiresAt < tokens[j].ExpiresAt
+		}
+		return tokens[i].TokenID < tokens[j].TokenID
+	})
+	return tokens, summary
+}
+
+// tokenCandidate is a raw JWT string and where it was found.
+type tokenCandidate struct {
+	raw    string
+	source string
+	name   string
+}
+
+// #lizard forgives
+func tokenCandidates(body capture.NetworkBody) []tokenCandidate {
+	var out []tokenCandidate
+	for name, value := range body.RequestHeaders {
+		switch strings.ToLower(name) {
+		case "authorization":
+			for _, raw := range findJWTs(value) {
+				out = append(out, tokenCandidate{raw: raw, source: "authorization_header"})
+			}
+		case "cookie":
+			for _, pair := range strings.Split(value, ";") {
+				cookieName, cookieValue, ok := strings.Cut(strings.TrimSpace(pair), "=")
+				if !ok {
+					continue
+				}
+				for _, raw := range findJWTs(cookieValue) {
+					out = append(out, tokenCandidate{raw: raw, source: "cookie", name: cookieName})
+				}
+			}
+		}
+	}
+	for name, value := range body.ResponseHeaders {
+		if !strings.EqualFold(name, "set-cookie") {
+			continue
+		}
+		for _, line := range strings.Split(value, "\n") {
+			nameValue, _, _ := strings.Cut(strings.TrimSpace(line), ";")
+			cookieName, cookieValue, ok := strings.Cut(nameValue, "=")
+			if !ok {
+				continue
+			}
+			for _, raw := range findJWTs(cookieValue) {
+				out = append(out, tokenCandidate{raw: raw, source: "set_cookie", name: cookieName})
+			}
+		}
+	}
+	for _, raw := range findJWTs(body.RequestBody) {
+		out = append(out, tokenCandidate{raw: raw, source: "request_body"})
+	}
+	for _, raw := range findJWTs(body.ResponseBody) {
+		out = append(out, tokenCandidate{raw: raw, source: "response_body"})
+	}
+	return out
+}
+
+func findJWTs(s string) []string {
+	if !strings.Contains(s, "eyJ") {
+		return nil
+	}
+	// jwtPattern (credential scan patterns) can over-match; DecodeJWT rejects non-JSON segments.
+	return jwtPattern.FindAllString(s, maxTokensPerSource)
+}
+
+func recordToken(byID map[string]*AuthToken, body capture.NetworkBody, c tokenCandidate, now time.Time, expiringWithin time.Duration) {
+	sum := sha256.Sum256([]byte(c.raw))
+	id := "jwt_" + hex.EncodeToString(sum[:6])
+	if existing, ok := byID[id]; ok {
+		existing.Occurrences++
+		if body.Timestamp >= existing.LastSeen {
+			existing.Source, existing.Name = c.source, c.name
+			existing.Method, existing.URL, existing.LastSeen = body.Method, body.URL, body.Timestamp
+		}
+		return
+	}
+	decoded, err := DecodeJWT(c.raw)
+	if err != nil {
+		return
+	}
+	token := &AuthToken{
+		TokenID:     id,
+		Source:      c.source,
+		Name:        c.name,
+		Method:      body.Method,
+		URL:         body.URL,
+		LastSeen:    body.Timestamp,
+		Occurrences: 1,
+		Algorithm:   stringClaim(decoded.Header, "alg"),
+		KeyID:       stringClaim(decoded.Header, "kid"),
+		Issuer:      stringClaim(decoded.Claims, "iss"),
+		Audience:    audienceClaim(decoded.Claims["aud"]),
+		Claims:      redactClaims(decoded.Claims),
+	}
+	iat, hasIat := timeClaim(decoded.Claims, "iat")
+	nbf, hasNbf := timeClaim(decoded.Claims, "nbf")
+	exp, hasExp := timeClaim(decoded.Claims, "exp")
+	if hasIat {
+		token.IssuedAt = iat.Format(time.RFC3339)
+	}
+	if hasNbf {
+		token.NotBefore = nbf.Format(time.RFC3339)
+	}
+	if hasExp {
+		token.ExpiresAt = exp.Format(time.RFC3339)
+		remaining := int64(exp.Sub(now).Seconds())
+		token.ExpiresInSeconds = &remaining
+	}
+	switch {
+	case hasNbf && now.Before(nbf):
+		token.Status = TokenStatusNotYetValid
+	case !hasExp:
+		token.Status = TokenStatusNoExpiry
+	case !now.Before(exp):
+		token.Status = TokenStatusExpired
+	case exp.Sub(now) <= expiringWithin:
+		token.Status = TokenStatusExpiringSoon
+	default:
+		token.Status = TokenStatusValid
+	}
+	byID[id] = token
+}
+
+func stringClaim(m map[string]any, key string) string {
+	s, _ := m[key].(string)
+	return s
+}
+
+func timeClaim(m map[string]any, key string) (time.Time, bool) {
+	v, ok := m[key].(float64)
+	if !ok {
+		return time.Time{}, false
+	}
+	return time.Unix(int64(v), 0).UTC(), true
+}
+
+func audienceClaim(v any) []string {
+	switch aud := v.(type) {
+	case string:
+		return []string{aud}
+	case []any:
+		out := make([]string, 0, len(aud))
+		for _, a := range aud {
+			if s, ok := a.(string); ok {
+				out = append(out, s)
+			}
+		}
+		return out
+	}
+	return nil
+}
+
+// redactClaims keeps claim names and nesting but replaces non-registered values
+// with "[redacted:<type>]" placeholders.
+func redactClaims(claims map[string]any) map[string]any {
+	out := make(map[string]any, len(claims))
+	for k, v := range claims {
+		if plainClaims[k] {
+			out[k] = v
+			continue
+		}
+		out[k] = redactClaimValue(v)
+	}
+	return out
+}
+
+func redactClaimValue(v any) any {
+	switch val := v.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(val))
+		for k, inner := range val {
+			out[k] = redactClaimValue(inner)
+		}
+		return out
+	case []any:
+		out := make([]any, len(val))
+		for i, inner := range val {
+			out[i] = redactClaimValue(inner)
+		}
+		return out
+	case string:
+		return "[redacted:string]"
+	case float64:
+		return "[redacted:number]"
+	case bool:
+		return "[redacted:bool]"
+	case nil:
+		return nil
+	}
+	return "[redacted]"
+}
+
+func statusRank(status string) int {
+	switch status {
+	case TokenStatusExpired:
+		return 0
+	case TokenStatusExpiringSoon:
+		return 1
+	case TokenStatusNotYetValid:
+		return 2
+	case TokenStatusNoExpiry:
+		return 3
+	}
+	return 4
+}
+
+func (s *AuthStateSummary) add(status string) {
+	s.Total++
+	switch status {
+	case TokenStatusValid:
+		s.Valid++
+	case TokenStatusExpiringSoon:
+		s.ExpiringSoon++
+	case TokenStatusExpired:
+		s.Expired++
+	case TokenStatusNotYetValid:
+		s.NotYetValid++
+	case TokenStatusNoExpiry:
+		s.NoExpiry++
+	}
+}
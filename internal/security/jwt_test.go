@@ -0,0 +1,117 @@
+// Purpose: Tests for JWT decoding, auth token discovery across headers/cookies/bodies, and claim redaction.
+// Docs: docs/features/feature/security-hardening/index.md
+
+package security
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+)
+
+func makeTestJWT(t *testing.T, claims map[string]any) string {
+	t.Helper()
+	enc := func(v any) string {
+		data, err := json.Marshal(v)
+		if err != nil {
+			t.Fatalf("marshal: %v", err)
+		}
+		return base64.RawURLEncoding.EncodeToString(data)
+	}
+	return enc(map[string]any{"alg": "RS256", "kid": "key-1", "typ": "JWT"}) + "." + enc(claims) + ".c2lnbmF0dXJl"
+}
+
+func TestDecodeJWT(t *testing.T) {
+	t.Parallel()
+	tok := makeTestJWT(t, map[string]any{"iss": "https://auth.test", "sub": "user-42"})
+	decoded, err := DecodeJWT(tok)
+	if err != nil {
+		t.Fatalf("DecodeJWT: %v", err)
+	}
+	if decoded.Header["alg"] != "RS256" || decoded.Claims["sub"] != "user-42" {
+		t.Fatalf("decoded = %+v", decoded)
+	}
+	for _, bad := range []string{"", "a.b", "eyJhbGciOi.eyJub3Q.sig", "eyJ!!.eyJ!!.x"} {
+		if _, err := DecodeJWT(bad); err == nil {
+			t.Errorf("DecodeJWT(%q) should fail", bad)
+		}
+	}
+}
+
+func TestScanAuthTokens_StatusAndSources(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	expired := makeTestJWT(t, map[string]any{"iss": "https://auth.test", "aud": "api", "exp": float64(now.Add(-time.Hour).Unix())})
+	soon := makeTestJWT(t, map[string]any{"iss": "https://auth.test", "aud": []any{"api", "web"}, "exp": float64(now.Add(time.Minute).Unix())})
+	valid := makeTestJWT(t, map[string]any{"exp": float64(now.Add(time.Hour).Unix()), "nbf": float64(now.Add(-time.Hour).Unix())})
+	noExp := makeTestJWT(t, map[string]any{"sub": "user-42"})
+
+	bodies := []capture.NetworkBody{
+		{Method: "GET", URL: "https://api.test/me", Timestamp: "2026-05-01T11:59:00Z",
+			RequestHeaders: map[string]string{"Authorization": "Bearer " + expired}},
+		{Method: "GET", URL: "https://api.test/feed", Timestamp: "2026-05-01T11:59:30Z",
+			RequestHeaders: map[string]string{"cookie": "theme=dark; session=" + soon}},
+		{Method: "POST", URL: "https://api.test/login", Timestamp: "2026-05-01T11:59:40Z",
+			ResponseHeaders: map[string]string{"Set-Cookie": "refresh=" + noExp + "; HttpOnly; Secure"},
+			ResponseBody:    `{"access_token":"` + valid + `"}`},
+		{Method: "GET", URL: "https://api.test/me", Timestamp: "2026-05-01T12:00:00Z",
+			RequestHeaders: map[string]string{"Authorization": "Bearer " + expired}},
+	}
+
+	tokens, summary := ScanAuthTokens(bodies, now, 5*time.Minute)
+	if summary.Total != 4 || summary.Expired != 1 || summary.ExpiringSoon != 1 || summary.Valid != 1 || summary.NoExpiry != 1 {
+		t.Fatalf("summary = %+v", summary)
+	}
+	first := tokens[0]
+	if first.Status != TokenStatusExpired || first.Source != "authorization_header" || first.Occurrences != 2 {
+		t.Fatalf("first token = %+v, want expired authorization_header seen twice", first)
+	}
+	if first.Issuer != "https://auth.test" || first.Algorithm != "RS256" || first.KeyID != "key-1" {
+		t.Fatalf("first token header/issuer = %+v", first)
+	}
+	if first.ExpiresInSeconds == nil || *first.ExpiresInSeconds != -3600 {
+		t.Fatalf("expires_in_seconds = %v, want -3600", first.ExpiresInSeconds)
+	}
+	if tokens[1].Source != "cookie" || tokens[1].Name != "session" || len(tokens[1].Audience) != 2 {
+		t.Fatalf("second token = %+v, want session cookie with 2 audiences", tokens[1])
+	}
+	if tokens[2].Source != "set_cookie" || tokens[2].Name != "refresh" {
+		t.Fatalf("third token = %+v, want refresh set_cookie", tokens[2])
+	}
+}
+
+func TestScanAuthTokens_RedactsClaimValues(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	tok := makeTestJWT(t, map[string]any{
+		"iss":   "https://auth.test",
+		"sub":   "user-42",
+		"roles": []any{"admin"},
+		"ctx":   map[string]any{"tenant": "acme", "mfa": true},
+	})
+	tokens, _ := ScanAuthTokens([]capture.NetworkBody{{
+		URL:            "https://api.test/",
+		RequestHeaders: map[string]string{"Authorization": "Bearer " + tok},
+	}}, now, 0)
+	if len(tokens) != 1 {
+		t.Fatalf("len(tokens) = %d, want 1", len(tokens))
+	}
+	out, _ := json.Marshal(tokens[0])
+	for _, secret := range []string{tok, "user-42", "admin", "acme"} {
+		if strings.Contains(string(out), secret) {
+			t.Fatalf("output leaks %q: %s", secret, out)
+		}
+	}
+	claims := tokens[0].Claims
+	if claims["iss"] != "https://auth.test" || claims["sub"] != "[redacted:string]" {
+		t.Fatalf("claims = %v", claims)
+	}
+	ctx := claims["ctx"].(map[string]any)
+	if ctx["mfa"] != "[redacted:bool]" || ctx["tenant"] != "[redacted:string]" {
+		t.Fatalf("nested claims = %v", ctx)
+	}
+}
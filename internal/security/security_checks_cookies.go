@@ -10,6 +10,7 @@ import (
 	"strings"
 
 	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/remediation"
 )
 
 // ============================================
@@ -25,7 +26,7 @@ func checkSingleCookie(cookie cookieAttrs, bodyURL string, isHTTPS bool) []Secur
 
 	if isSensitive && !cookie.HttpOnly {
 		findings = append(findings, SecurityFinding{
-			Check: "cookies", Severity: "warning",
+			Check: "cookies", FindingID: remediation.SecurityCookieMissingHttpOnly, Severity: "warning",
 			Title:       fmt.Sprintf("Session cookie '%s' missing HttpOnly flag", cookie.Name),
 			Description: fmt.Sprintf("The cookie '%s' appears to be a session cookie but lacks the HttpOnly flag, making it accessible to JavaScript (XSS risk).", cookie.Name),
 			Location:    bodyURL,
@@ -35,7 +36,7 @@ func checkSingleCookie(cookie cookieAttrs, bodyURL string, isHTTPS bool) []Secur
 	}
 	if isHTTPS && !cookie.Secure {
 		findings = append(findings, SecurityFinding{
-			Check: "cookies", Severity: "warning",
+			Check: "cookies", FindingID: remediation.SecurityCookieMissingSecure, Severity: "warning",
 			Title:       fmt.Sprintf("Cookie '%s' missing Secure flag on HTTPS", cookie.Name),
 			Description: fmt.Sprintf("The cookie '%s' is set on an HTTPS page but lacks the Secure flag, meaning it could be sent over HTTP.", cookie.Name),
 			Location:    bodyURL,
@@ -45,7 +46,7 @@ func checkSingleCookie(cookie cookieAttrs, bodyURL string, isHTTPS bool) []Secur
 	}
 	if isSensitive && cookie.SameSite == "" {
 		findings = append(findings, SecurityFinding{
-			Check: "cookies", Severity: "warning",
+			Check: "cookies", FindingID: remediation.SecurityCookieMissingSameSite, Severity: "warning",
 			Title:       fmt.Sprintf("Cookie '%s' missing SameSite attribute", cookie.Name),
 			Description: fmt.Sprintf("The cookie '%s' lacks a SameSite attribute, which may allow cross-site request forgery.", cookie.Name),
 			Location:    bodyURL,
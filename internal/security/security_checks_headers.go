@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/remediation"
 	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/util"
 )
 
@@ -46,6 +47,7 @@ func checkHeadersForOrigin(body capture.NetworkBody, origin string) []SecurityFi
 		if body.ResponseHeaders == nil || body.ResponseHeaders[header.Name] == "" {
 			findings = append(findings, SecurityFinding{
 				Check:       "headers",
+				FindingID:   remediation.MissingHeaderFindingID(header.Name),
 				Severity:    header.Severity,
 				Title:       fmt.Sprintf("Missing %s header", header.Name),
 				Description: fmt.Sprintf("The response from %s does not include the %s header.", origin, header.Name),
@@ -10,6 +10,7 @@ import (
 	"strings"
 
 	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/remediation"
 )
 
 // ============================================
@@ -23,12 +24,12 @@ var (
 	ccPattern    = regexp.MustCompile(`\b\d{4}[\s-]?\d{4}[\s-]?\d{4}[\s-]?\d{4}\b`)
 )
 
-func (s *SecurityScanner) checkPII(bodies []capture.NetworkBody, pageURLs []string) []SecurityFinding {
+func (s *SecurityScanner) checkPII(bodies []capture.NetworkBody, pageURLs, firstPartySuffixes []string) []SecurityFinding {
 	var findings []SecurityFinding
 
 	for _, body := range bodies {
 		if body.RequestBody != "" {
-			isThirdParty := isThirdPartyURL(body.URL, pageURLs)
+			isThirdParty := isThirdPartyURL(body.URL, pageURLs, firstPartySuffixes)
 			findings = append(findings, s.scanForPII(body.RequestBody, body.URL, "request body", isThirdParty)...)
 		}
 
@@ -53,7 +54,7 @@ func scanForSSN(content, sourceURL, location string, isThirdParty bool) *Securit
 		desc = fmt.Sprintf("A Social Security Number pattern is being sent to a third-party endpoint in %s.", location)
 	}
 	return &SecurityFinding{
-		Check: "pii", Severity: severity,
+		Check: "pii", FindingID: remediation.SecurityPIIExposure, Severity: severity,
 		Title: "SSN pattern detected in " + location, Description: desc,
 		Location: sourceURL, Evidence: redactSecret(match),
 		Remediation: "Never transmit SSNs in plain text. Use tokenization or encryption.",
@@ -71,7 +72,7 @@ func scanForCreditCard(content, sourceURL, location string) *SecurityFinding {
 		return nil
 	}
 	return &SecurityFinding{
-		Check: "pii", Severity: "critical",
+		Check: "pii", FindingID: remediation.SecurityPIIExposure, Severity: "critical",
 		Title:       "Credit card number detected in " + location,
 		Description: fmt.Sprintf("A credit card number pattern was detected in %s.", location),
 		Location:    sourceURL, Evidence: redactSecret(match),
@@ -93,7 +94,7 @@ func scanForEmailPII(content, sourceURL, location string, isThirdParty bool) *Se
 		return nil
 	}
 	return &SecurityFinding{
-		Check: "pii", Severity: thirdPartySeverity(isThirdParty),
+		Check: "pii", FindingID: remediation.SecurityPIIExposure, Severity: thirdPartySeverity(isThirdParty),
 		Title:       "Email address in " + location,
 		Description: fmt.Sprintf("An email address was detected in %s.", location),
 		Location:    sourceURL, Evidence: redactSecret(emailPattern.FindString(content)),
@@ -112,7 +113,7 @@ func scanForPhonePII(content, sourceURL, location string, isThirdParty bool) *Se
 		return nil
 	}
 	return &SecurityFinding{
-		Check: "pii", Severity: thirdPartySeverity(isThirdParty),
+		Check: "pii", FindingID: remediation.SecurityPIIExposure, Severity: thirdPartySeverity(isThirdParty),
 		Title:       "Phone number in " + location,
 		Description: fmt.Sprintf("A phone number pattern was detected in %s.", location),
 		Location:    sourceURL, Evidence: redactSecret(match),
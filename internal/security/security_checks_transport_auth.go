@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/remediation"
 )
 
 // ============================================
@@ -36,6 +37,7 @@ func (s *SecurityScanner) checkTransport(bodies []capture.NetworkBody, pageURLs
 
 		findings = append(findings, SecurityFinding{
 			Check:       "transport",
+			FindingID:   remediation.SecurityInsecureTransport,
 			Severity:    "warning",
 			Title:       "API call over unencrypted HTTP",
 			Description: fmt.Sprintf("%s %s uses unencrypted HTTP. Data in transit can be intercepted.", body.Method, body.URL),
@@ -51,6 +53,7 @@ func (s *SecurityScanner) checkTransport(bodies []capture.NetworkBody, pageURLs
 			}
 			findings = append(findings, SecurityFinding{
 				Check:       "transport",
+				FindingID:   remediation.SecurityMixedContent,
 				Severity:    severity,
 				Title:       "Mixed content: HTTPS page loading HTTP resource",
 				Description: fmt.Sprintf("An HTTPS page is loading a resource from %s over unencrypted HTTP.", body.URL),
@@ -83,6 +86,7 @@ func (s *SecurityScanner) checkAuthPatterns(bodies []capture.NetworkBody) []Secu
 		if len(piiFields) > 0 {
 			findings = append(findings, SecurityFinding{
 				Check:       "auth",
+				FindingID:   remediation.SecurityUnauthenticatedData,
 				Severity:    "warning",
 				Title:       "Endpoint returns sensitive data without authentication",
 				Description: fmt.Sprintf("GET %s returned PII fields (%s) but no Authorization header was present.", body.URL, strings.Join(piiFields, ", ")),
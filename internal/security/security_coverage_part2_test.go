@@ -32,7 +32,7 @@ func TestCheckPII_RequestBodyToThirdParty(t *testing.T) {
 	}
 	pageURLs := []string{"https://myapp.com"}
 
-	findings := s.checkPII(bodies, pageURLs)
+	findings := s.checkPII(bodies, pageURLs, nil)
 	if len(findings) < 2 {
 		t.Errorf("expected at least 2 PII findings (email + SSN), got %d", len(findings))
 	}
@@ -49,7 +49,7 @@ func TestCheckPII_ResponseBody(t *testing.T) {
 		},
 	}
 
-	findings := s.checkPII(bodies, []string{"https://myapp.com"})
+	findings := s.checkPII(bodies, []string{"https://myapp.com"}, nil)
 	if len(findings) == 0 {
 		t.Fatal("expected PII finding for email in response body")
 	}
@@ -202,7 +202,7 @@ func TestExtractOrigin_NoHost(t *testing.T) {
 
 func TestIsThirdPartyURL_EmptyPageURLs(t *testing.T) {
 	t.Parallel()
-	got := isThirdPartyURL("https://example.com/api", nil)
+	got := isThirdPartyURL("https://example.com/api", nil, nil)
 	if got {
 		t.Error("isThirdPartyURL with empty pageURLs should return false")
 	}
@@ -210,7 +210,7 @@ func TestIsThirdPartyURL_EmptyPageURLs(t *testing.T) {
 
 func TestIsThirdPartyURL_InvalidRequestURL(t *testing.T) {
 	t.Parallel()
-	got := isThirdPartyURL("://invalid", []string{"https://example.com"})
+	got := isThirdPartyURL("://invalid", []string{"https://example.com"}, nil)
 	if got {
 		t.Error("isThirdPartyURL with invalid request URL should return false")
 	}
@@ -218,7 +218,7 @@ func TestIsThirdPartyURL_InvalidRequestURL(t *testing.T) {
 
 func TestIsThirdPartyURL_SubdomainMatch(t *testing.T) {
 	t.Parallel()
-	got := isThirdPartyURL("https://api.example.com/data", []string{"https://example.com"})
+	got := isThirdPartyURL("https://api.example.com/data", []string{"https://example.com"}, nil)
 	if got {
 		t.Error("api.example.com should be first-party relative to example.com")
 	}
@@ -226,7 +226,7 @@ func TestIsThirdPartyURL_SubdomainMatch(t *testing.T) {
 
 func TestIsThirdPartyURL_ReverseSubdomain(t *testing.T) {
 	t.Parallel()
-	got := isThirdPartyURL("https://example.com/data", []string{"https://api.example.com"})
+	got := isThirdPartyURL("https://example.com/data", []string{"https://api.example.com"}, nil)
 	if got {
 		t.Error("example.com should be first-party relative to api.example.com")
 	}
@@ -234,15 +234,23 @@ func TestIsThirdPartyURL_ReverseSubdomain(t *testing.T) {
 
 func TestIsThirdPartyURL_ThirdParty(t *testing.T) {
 	t.Parallel()
-	got := isThirdPartyURL("https://analytics.google.com/collect", []string{"https://example.com"})
+	got := isThirdPartyURL("https://analytics.google.com/collect", []string{"https://example.com"}, nil)
 	if !got {
 		t.Error("analytics.google.com should be third-party relative to example.com")
 	}
 }
 
+func TestIsThirdPartyURL_SharedHostingSuffix(t *testing.T) {
+	t.Parallel()
+	got := isThirdPartyURL("https://evil.s3.amazonaws.com/x.js", []string{"https://a.s3.amazonaws.com/"}, nil)
+	if !got {
+		t.Error("another S3 bucket should be third-party relative to a.s3.amazonaws.com")
+	}
+}
+
 func TestIsThirdPartyURL_InvalidPageURL(t *testing.T) {
 	t.Parallel()
-	got := isThirdPartyURL("https://example.com/api", []string{"://invalid"})
+	got := isThirdPartyURL("https://example.com/api", []string{"://invalid"}, nil)
 	if !got {
 		t.Error("should be third-party when page URL is invalid")
 	}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 
 	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/remediation"
 )
 
 func (s *SecurityScanner) checkCredentials(bodies []capture.NetworkBody, entries []LogEntry) []SecurityFinding {
@@ -36,6 +37,7 @@ func (s *SecurityScanner) scanURLForAPIKeys(url string) []SecurityFinding {
 		}
 		findings = append(findings, SecurityFinding{
 			Check:       "credentials",
+			FindingID:   remediation.SecurityCredentialInURL,
 			Severity:    "critical",
 			Title:       fmt.Sprintf("API key exposed in URL query parameter '%s'", m[1]),
 			Description: fmt.Sprintf("GET request includes secret in query parameter '%s'. Query parameters are logged in server access logs, browser history, and may be cached by proxies.", m[1]),
@@ -60,6 +62,7 @@ func (s *SecurityScanner) scanURLForGenericSecrets(url string) []SecurityFinding
 		}
 		findings = append(findings, SecurityFinding{
 			Check:       "credentials",
+			FindingID:   remediation.SecurityCredentialInURL,
 			Severity:    "critical",
 			Title:       "Secret value exposed in URL query parameter",
 			Description: "Request URL contains a secret-named parameter with a long value.",
@@ -78,7 +81,7 @@ func (s *SecurityScanner) scanURLForCredentials(body capture.NetworkBody) []Secu
 
 	if jwtPattern.MatchString(body.URL) {
 		findings = append(findings, SecurityFinding{
-			Check: "credentials", Severity: "critical",
+			Check: "credentials", FindingID: remediation.SecurityCredentialInURL, Severity: "critical",
 			Title:       "JWT token exposed in URL",
 			Description: "A JWT token was found in the request URL. URLs are logged in browser history, server logs, and may leak via Referer headers.",
 			Location:    body.URL, Evidence: redactSecret(jwtPattern.FindString(body.URL)),
@@ -87,7 +90,7 @@ func (s *SecurityScanner) scanURLForCredentials(body capture.NetworkBody) []Secu
 	}
 	if awsKeyPattern.MatchString(body.URL) {
 		findings = append(findings, SecurityFinding{
-			Check: "credentials", Severity: "critical",
+			Check: "credentials", FindingID: remediation.SecurityCredentialInURL, Severity: "critical",
 			Title:       "AWS access key exposed in URL",
 			Description: "An AWS access key ID was found in the request URL.",
 			Location:    body.URL, Evidence: redactSecret(awsKeyPattern.FindString(body.URL)),
@@ -121,6 +124,7 @@ func (s *SecurityScanner) scanBodyForCredentials(bodyContent, sourceURL, locatio
 		}
 		findings = append(findings, SecurityFinding{
 			Check:       "credentials",
+			FindingID:   remediation.SecurityCredentialInBody,
 			Severity:    check.severity,
 			Title:       fmt.Sprintf(check.titleFmt, location),
 			Description: fmt.Sprintf(check.descFmt, location),
@@ -137,6 +141,7 @@ func (s *SecurityScanner) scanBodyForCredentials(bodyContent, sourceURL, locatio
 		}
 		findings = append(findings, SecurityFinding{
 			Check:       "credentials",
+			FindingID:   remediation.SecurityCredentialInBody,
 			Severity:    "warning",
 			Title:       fmt.Sprintf("API key '%s' in %s", m[1], location),
 			Description: fmt.Sprintf("An API key field '%s' was found in the %s.", m[1], location),
@@ -169,6 +174,7 @@ func (s *SecurityScanner) scanConsoleForCredentials(entry LogEntry) []SecurityFi
 		match := bearerPattern.FindString(msg)
 		findings = append(findings, SecurityFinding{
 			Check:       "credentials",
+			FindingID:   remediation.SecurityCredentialInConsole,
 			Severity:    "critical",
 			Title:       "Bearer token logged to console",
 			Description: "A Bearer token was found in console output. Console logs may be captured by browser extensions or error tracking services.",
@@ -185,6 +191,7 @@ func (s *SecurityScanner) scanConsoleForCredentials(entry LogEntry) []SecurityFi
 		if !bearerPattern.MatchString(msg) {
 			findings = append(findings, SecurityFinding{
 				Check:       "credentials",
+				FindingID:   remediation.SecurityCredentialInConsole,
 				Severity:    "critical",
 				Title:       "JWT token logged to console",
 				Description: "A JWT token was found in console output.",
@@ -200,6 +207,7 @@ func (s *SecurityScanner) scanConsoleForCredentials(entry LogEntry) []SecurityFi
 		match := awsKeyPattern.FindString(msg)
 		findings = append(findings, SecurityFinding{
 			Check:       "credentials",
+			FindingID:   remediation.SecurityCredentialInConsole,
 			Severity:    "critical",
 			Title:       "AWS access key logged to console",
 			Description: "An AWS access key was found in console output.",
@@ -0,0 +1,419 @@
+// Purpose: Recommends a security response header set from observed page behavior.
+// Why: Permissions-Policy, framing, referrer, and cross-origin isolation headers need evidence of what the page actually uses.
+// Docs: docs/features/feature/security-hardening/index.md
+
+package security
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/util"
+)
+
+// SecurityHeadersInput is the captured state the recommendation engine reads.
+type SecurityHeadersInput struct {
+	PageURL   string
+	Bodies    []capture.NetworkBody
+	Waterfall []capture.NetworkWaterfallEntry
+}
+
+// SecurityHeadersResult is the response for generate(what="security_headers").
+type SecurityHeadersResult struct {
+	PageURL string                 `json:"page_url"`
+	Headers []HeaderRecommendation `json:"headers"`
+	// HeaderBlock is every recommended header as "Name: value" lines, ready to paste into server config.
+	HeaderBlock string                  `json:"header_block"`
+	Observed    SecurityHeadersEvidence `json:"observed"`
+	Summary     SecurityHeadersSummary  `json:"summary"`
+	Warnings    []string                `json:"warnings,omitempty"`
+}
+
+// HeaderRecommendation is one proposed response header with the reason for its value.
+type HeaderRecommendation struct {
+	Name      string `json:"name"`
+	Value     string `json:"value"`
+	Rationale string `json:"rationale"`
+	// Current is the value seen on the captured document response, if any.
+	Current string `json:"current,omitempty"`
+	// Status is "missing", "differs", or "matches" relative to Current.
+	Status string `json:"status"`
+}
+
+// SecurityHeadersEvidence lists the observed behavior that drove the recommendations.
+type SecurityHeadersEvidence struct {
+	// FeaturesUsed maps a Permissions-Policy feature to the captured resources that call its API.
+	FeaturesUsed        map[string][]string `json:"features_used"`
+	OpensPopups         bool                `json:"opens_popups"`
+	PopupSources        []string            `json:"popup_sources,omitempty"`
+	CrossOriginOrigins  []string            `json:"cross_origin_origins"`
+	CrossOriginFrames   []string            `json:"cross_origin_frames"`
+	SensitiveQueryPages []string            `json:"sensitive_query_pages,omitempty"`
+	DocumentCaptured    bool                `json:"document_captured"`
+	ScriptsScanned      int                 `json:"scripts_scanned"`
+}
+
+// SecurityHeadersSummary counts recommendations by status.
+type SecurityHeadersSummary struct {
+	Total   int `json:"total"`
+	Missing int `json:"missing"`
+	Differs int `json:"differs"`
+	Matches int `json:"matches"`
+}
+
+// permissionFeature maps a Permissions-Policy feature to the JS APIs that require it.
+type permissionFeature struct {
+	name    string
+	pattern *regexp.Regexp
+}
+
+// permissionFeatures is ordered as the features appear in the generated policy.
+var permissionFeatures = []permissionFeature{
+	{"accelerometer", regexp.MustCompile(`new\s+Accelerometer\s*\(|DeviceMotionEvent`)},
+	{"autoplay", regexp.MustCompile(`\bautoplay\b`)},
+	{"bluetooth", regexp.MustCompile(`navigator\.bluetooth\b`)},
+	{"camera", regexp.MustCompile(`getUserMedia\s*\(\s*\{[^}]*video`)},
+	{"clipboard-read", regexp.MustCompile(`navigator\.clipboard\.read(Text)?\s*\(`)},
+	{"clipboard-write", regexp.MustCompile(`navigator\.clipboard\.write(Text)?\s*\(`)},
+	{"display-capture", regexp.MustCompile(`getDisplayMedia\s*\(`)},
+	{"fullscreen", regexp.MustCompile(`requestFullscreen\s*\(`)},
+	{"geolocation", regexp.MustCompile(`navigator\.geolocation\b`)},
+	{"gyroscope", regexp.MustCompile(`new\s+Gyroscope\s*\(|DeviceOrientationEvent`)},
+	{"hid", regexp.MustCompile(`navigator\.hid\b`)},
+	{"magnetometer", regexp.MustCompile(`new\s+Magnetometer\s*\(`)},
+	{"microphone", regexp.MustCompile(`getUserMedia\s*\(\s*\{[^}]*audio`)},
+	{"midi", regexp.MustCompile(`requestMIDIAccess\s*\(`)},
+	{"payment", regexp.MustCompile(`new\s+PaymentRequest\s*\(`)},
+	{"publickey-credentials-get", regexp.MustCompile(`navigator\.credentials\.get\s*\(`)},
+	{"screen-wake-lock", regexp.MustCompile(`navigator\.wakeLock\b`)},
+	{"serial", regexp.MustCompile(`navigator\.serial\b`)},
+	{"usb", regexp.MustCompile(`navigator\.usb\b`)},
+	{"web-share", regexp.MustCompile(`navigator\.share\s*\(`)},
+	{"xr-spatial-tracking", regexp.MustCompile(`navigator\.xr\b`)},
+}
+
+var windowOpenPattern = regexp.MustCompile(`window\.open\s*\(`)
+
+// sensitiveQueryParams are query keys whose values should not leak through Referer.
+var sensitiveQueryParams = map[string]bool{
+	"token": true, "access_token": true, "id_token": true, "code": true, "session": true,
+	"sessionid": true, "key": true, "api_key": true, "apikey": true, "password": true,
+	"email": true, "reset": true, "auth": true, "sig": true, "signature": true,
+}
+
+// GenerateSecurityHeaders proposes Permissions-Policy, framing, referrer, COOP/COEP/CORP,
+// nosniff, and HSTS values for the page, each with a rationale grounded in captured traffic.
+//
+// Invariants:
+// - Features are only allowed ("self") when a captured first-party or third-party script calls their API;
+// everything else in permissionFeatures is disabled.
+// - COEP is relaxed to "credentialless" when cross-origin subresources were observed, since
+// require-corp would block any of them that do not send CORP/CORS headers.
+func GenerateSecurityHeaders(in SecurityHeadersInput) SecurityHeadersResult {
+	pageOrigin := util.ExtractOrigin(in.PageURL)
+	evidence, document := collectHeaderEvidence(in, pageOrigin)
+
+	var warnings []string
+	if in.PageURL == "" {
+		warnings = append(warnings, "No tracked page URL; first-party origin could not be determined, so every origin is treated as cross-origin.")
+	}
+	if !evidence.DocumentCaptured {
+		warnings = append(warnings, "The page's HTML document response was not captured; current header values are unknown and every header is reported as missing.")
+	}
+	if evidence.ScriptsScanned == 0 {
+		warnings = append(warnings, "No script bodies were captured; Permissions-Policy assumes no powerful features are used. Exercise the page's features and regenerate.")
+	}
+
+	headers := []HeaderRecommendation{
+		recommendPermissionsPolicy(evidence),
+		recommendFrameOptions(evidence),
+		recommendFrameAncestors(),
+		recommendReferrerPolicy(evidence),
+		recommendCOOP(evidence),
+		recommendCOEP(evidence),
+		{
+			Name:      "Cross-Origin-Resource-Policy",
+			Value:     "same-origin",
+			Rationale: "Prevents other origins from embedding this page's responses via no-cors requests. Use same-site instead if sibling subdomains load these resources.",
+		},
+		{
+			Name:      "X-Content-Type-Options",
+			Value:     "nosniff",
+			Rationale: "Stops MIME sniffing so responses are only executed or rendered as their declared Content-Type.",
+		},
+	}
+	if strings.HasPrefix(in.PageURL, "https://") && !isLocalhostURL(in.PageURL) {
+		headers = append(headers, HeaderRecommendation{
+			Name:      "Strict-Transport-Security",
+			Value:     "max-age=31536000; includeSubDomains",
+			Rationale: "The page is served over HTTPS; HSTS prevents protocol downgrade on later visits. Add preload only after every subdomain serves HTTPS.",
+		})
+	}
+
+	var summary SecurityHeadersSummary
+	var block strings.Builder
+	for i := range headers {
+		h := &headers[i]
+		h.Current = lookupHeader(document, h.Name)
+		h.Status = headerStatus(h.Current, h.Value)
+		switch h.Status {
+		case "missing":
+			summary.Missing++
+		case "differs":
+			summary.Differs++
+		default:
+			summary.Matches++
+		}
+		fmt.Fprintf(&block, "%s: %s\n", h.Name, h.Value)
+	}
+	summary.Total = len(headers)
+
+	return SecurityHeadersResult{
+		PageURL:     in.PageURL,
+		Headers:     headers,
+		HeaderBlock: block.String(),
+		Observed:    evidence,
+		Summary:     summary,
+		Warnings:    warnings,
+	}
+}
+
+// collectHeaderEvidence scans captured bodies and resource timing for feature usage,
+// popups, cross-origin subresources and frames. It also returns the captured document response.
+func collectHeaderEvidence(in SecurityHeadersInput, pageOrigin string) (SecurityHeadersEvidence, *capture.NetworkBody) {
+	features := map[string]map[string]bool{}
+	popups := map[string]bool{}
+	crossOrigins := map[string]bool{}
+	crossFrames := map[string]bool{}
+	sensitivePages := map[string]bool{}
+	var document *capture.NetworkBody
+	scanned := 0
+
+	noteCrossOrigin := func(rawURL string) {
+		origin := util.ExtractOrigin(rawURL)
+		if origin != "" && origin != pageOrigin && strings.HasPrefix(origin, "http") {
+			crossOrigins[origin] = true
+		}
+	}
+
+	for i := range in.Bodies {
+		body := &in.Bodies[i]
+		noteCrossOrigin(body.URL)
+		if isHTMLResponse(*body) {
+			if document == nil && sameDocument(body.URL, in.PageURL) {
+				document = body
+			}
+			if util.ExtractOrigin(body.URL) == pageOrigin && hasSensitiveQuery(body.URL) {
+				sensitivePages[stripFragment(body.URL)] = true
+			}
+		}
+		if !isJavaScriptContent(body.ContentType) && !isHTMLResponse(*body) {
+			continue
+		}
+		if body.ResponseBody == "" {
+			continue
+		}
+		scanned++
+		for _, f := range permissionFeatures {
+			if f.pattern.MatchString(body.ResponseBody) {
+				if features[f.name] == nil {
+					features[f.name] = map[string]bool{}
+				}
+				features[f.name][body.URL] = true
+			}
+		}
+		if windowOpenPattern.MatchString(body.ResponseBody) {
+			popups[body.URL] = true
+		}
+	}
+
+	for _, entry := range in.Waterfall {
+		noteCrossOrigin(entry.URL)
+		switch strings.ToLower(entry.InitiatorType) {
+		case "iframe", "frame", "subdocument":
+			if origin := util.ExtractOrigin(entry.URL); origin != "" && origin != pageOrigin {
+				crossFrames[origin] = true
+			}
+		}
+	}
+	if pageOrigin != "" && hasSensitiveQuery(in.PageURL) {
+		sensitivePages[stripFragment(in.PageURL)] = true
+	}
+
+	used := make(map[string][]string, len(features))
+	for name, sources := range features {
+		used[name] = sortedKeys(sources)
+	}
+	return SecurityHeadersEvidence{
+		FeaturesUsed:        used,
+		OpensPopups:         len(popups) > 0,
+		PopupSources:        sortedKeys(popups),
+		CrossOriginOrigins:  sortedKeys(crossOrigins),
+		CrossOriginFrames:   sortedKeys(crossFrames),
+		SensitiveQueryPages: sortedKeys(sensitivePages),
+		DocumentCaptured:    document != nil,
+		ScriptsScanned:      scanned,
+	}, document
+}
+
+func recommendPermissionsPolicy(ev SecurityHeadersEvidence) HeaderRecommendation {
+	parts := make([]string, 0, len(permissionFeatures))
+	var allowed []string
+	for _, f := range permissionFeatures {
+		if len(ev.FeaturesUsed[f.name]) > 0 {
+			parts = append(parts, f.name+"=(self)")
+			allowed = append(allowed, f.name)
+			continue
+		}
+		parts = append(parts, f.name+"=()")
+	}
+	rationale := "No captured script calls a permission-gated API, so every listed feature is disabled for this page and all embedded frames."
+	if len(allowed) > 0 {
+		rationale = fmt.Sprintf("Captured scripts use %s, so those stay allowed for the page's own origin (see observed.features_used); all other features are disabled. Add a frame origin to a feature's allowlist only if an embedded iframe needs it.",
+			strings.Join(allowed, ", "))
+	}
+	return HeaderRecommendation{Name: "Permissions-Policy", Value: strings.Join(parts, ", "), Rationale: rationale}
+}
+
+func recommendFrameOptions(ev SecurityHeadersEvidence) HeaderRecommendation {
+	rationale := "Nothing observed requires this page to be framed; DENY blocks clickjacking in browsers that predate frame-ancestors."
+	if len(ev.CrossOriginFrames) > 0 {
+		rationale += " The page embedding cross-origin iframes is unaffected; this header only controls who may frame this page."
+	}
+	return HeaderRecommendation{Name: "X-Frame-Options", Value: "DENY", Rationale: rationale}
+}
+
+func recommendFrameAncestors() HeaderRecommendation {
+	return HeaderRecommendation{
+		Name:      "Content-Security-Policy",
+		Value:     "frame-ancestors 'none'",
+		Rationale: "frame-ancestors supersedes X-Frame-Options in modern browsers. Merge this directive into the policy from generate(what=\"csp\"); list embedding origins instead of 'none' if the page is meant to be framed.",
+	}
+}
+
+func recommendReferrerPolicy(ev SecurityHeadersEvidence) HeaderRecommendation {
+	if len(ev.SensitiveQueryPages) > 0 {
+		return HeaderRecommendation{
+			Name:  "Referrer-Policy",
+			Value: "no-referrer",
+			Rationale: fmt.Sprintf("%d first-party page URL(s) carry sensitive query parameters (tokens, codes, emails); no-referrer keeps them out of the Referer header sent to %d cross-origin host(s).",
+				len(ev.SensitiveQueryPages), len(ev.CrossOriginOrigins)),
+		}
+	}
+	rationale := "Sends only the origin to other sites and nothing on HTTPS-to-HTTP downgrades, while keeping full referrers for same-origin analytics."
+	if len(ev.CrossOriginOrigins) > 0 {
+		rationale += fmt.Sprintf(" %d cross-origin host(s) receive requests from this page.", len(ev.CrossOriginOrigins))
+	}
+	return HeaderRecommendation{Name: "Referrer-Policy", Value: "strict-origin-when-cross-origin", Rationale: rationale}
+}
+
+func recommendCOOP(ev SecurityHeadersEvidence) HeaderRecommendation {
+	if ev.OpensPopups {
+		return HeaderRecommendation{
+			Name:      "Cross-Origin-Opener-Policy",
+			Value:     "same-origin-allow-popups",
+			Rationale: "Captured scripts call window.open (see observed.popup_sources); same-origin-allow-popups keeps popup flows such as OAuth or payments working while isolating this window from cross-origin openers.",
+		}
+	}
+	return HeaderRecommendation{
+		Name:      "Cross-Origin-Opener-Policy",
+		Value:     "same-origin",
+		Rationale: "No popups were observed, so the page can be fully isolated from cross-origin windows, which blocks cross-window attacks and is required for cross-origin isolation.",
+	}
+}
+
+func recommendCOEP(ev SecurityHeadersEvidence) HeaderRecommendation {
+	if len(ev.CrossOriginOrigins) == 0 {
+		return HeaderRecommendation{
+			Name:      "Cross-Origin-Embedder-Policy",
+			Value:     "require-corp",
+			Rationale: "All observed subresources are same-origin, so require-corp can be enabled without breaking loads; together with COOP same-origin it enables cross-origin isolation.",
+		}
+	}
+	rationale := fmt.Sprintf("%d cross-origin host(s) serve subresources; require-corp would block any that do not send Cross-Origin-Resource-Policy or CORS headers. credentialless loads them without cookies instead.",
+		len(ev.CrossOriginOrigins))
+	if len(ev.CrossOriginFrames) > 0 {
+		rationale += fmt.Sprintf(" %d cross-origin iframe origin(s) must also send COEP or be loaded with the iframe credentialless attribute.", len(ev.CrossOriginFrames))
+	}
+	return HeaderRecommendation{Name: "Cross-Origin-Embedder-Policy", Value: "credentialless", Rationale: rationale}
+}
+
+// lookupHeader returns the document's value for name, case-insensitively.
+// For Content-Security-Policy it reports only whether frame-ancestors is already present.
+func lookupHeader(document *capture.NetworkBody, name string) string {
+	if document == nil {
+		return ""
+	}
+	for k, v := range document.ResponseHeaders {
+		if !strings.EqualFold(k, name) {
+			continue
+		}
+		if name == "Content-Security-Policy" {
+			return extractDirective(v, "frame-ancestors")
+		}
+		return v
+	}
+	return ""
+}
+
+func extractDirective(policy, directive string) string {
+	for _, part := range strings.Split(policy, ";") {
+		part = strings.TrimSpace(part)
+		if strings.HasPrefix(strings.ToLower(part), directive) {
+			return part
+		}
+	}
+	return ""
+}
+
+func headerStatus(current, recommended string) string {
+	switch {
+	case current == "":
+		return "missing"
+	case normalizeHeaderValue(current) == normalizeHeaderValue(recommended):
+		return "matches"
+	default:
+		return "differs"
+	}
+}
+
+func normalizeHeaderValue(v string) string {
+	return strings.Join(strings.Fields(strings.ToLower(v)), " ")
+}
+
+func sameDocument(a, b string) bool {
+	return a != "" && stripFragment(a) == stripFragment(b)
+}
+
+func stripFragment(rawURL string) string {
+	if i := strings.IndexByte(rawURL, '#'); i >= 0 {
+		return rawURL[:i]
+	}
+	return rawURL
+}
+
+func hasSensitiveQuery(rawURL string) bool {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+	for key := range parsed.Query() {
+		if sensitiveQueryParams[strings.ToLower(key)] {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedKeys(set map[string]bool) []string {
+	out := make([]string, 0, len(set))
+	for k := range set {
+		out = append(out, k)
+	}
+	sort.Strings(out)
+	return out
+}
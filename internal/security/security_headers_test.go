@@ -0,0 +1,138 @@
+// Purpose: Tests the security header recommendation engine.
+// Docs: docs/features/feature/security-hardening/index.md
+
+package security
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+)
+
+func findHeader(t *testing.T, result SecurityHeadersResult, name string) HeaderRecommendation {
+	t.Helper()
+	for _, h := range result.Headers {
+		if h.Name == name {
+			return h
+		}
+	}
+	t.Fatalf("header %s not recommended; got %+v", name, result.Headers)
+	return HeaderRecommendation{}
+}
+
+func TestGenerateSecurityHeaders_AllowsOnlyObservedFeatures(t *testing.T) {
+	t.Parallel()
+	result := GenerateSecurityHeaders(SecurityHeadersInput{
+		PageURL: "https://app.example.com/map",
+		Bodies: []capture.NetworkBody{
+			{URL: "https://app.example.com/map", ContentType: "text/html", ResponseBody: "<html></html>"},
+			{URL: "https://app.example.com/app.js", ContentType: "application/javascript",
+				ResponseBody: "navigator.geolocation.getCurrentPosition(cb); navigator.mediaDevices.getUserMedia({ video: true })"},
+		},
+	})
+
+	pp := findHeader(t, result, "Permissions-Policy")
+	for _, want := range []string{"geolocation=(self)", "camera=(self)", "microphone=()", "payment=()"} {
+		if !strings.Contains(pp.Value, want) {
+			t.Errorf("Permissions-Policy missing %q: %s", want, pp.Value)
+		}
+	}
+	if srcs := result.Observed.FeaturesUsed["geolocation"]; len(srcs) != 1 || srcs[0] != "https://app.example.com/app.js" {
+		t.Errorf("geolocation sources = %v", srcs)
+	}
+	if !strings.Contains(pp.Rationale, "geolocation") {
+		t.Errorf("rationale should name allowed features: %s", pp.Rationale)
+	}
+	if findHeader(t, result, "Strict-Transport-Security").Value == "" {
+		t.Error("HSTS should be recommended for https pages")
+	}
+}
+
+func TestGenerateSecurityHeaders_PopupsAndCrossOriginRelaxIsolation(t *testing.T) {
+	t.Parallel()
+	result := GenerateSecurityHeaders(SecurityHeadersInput{
+		PageURL: "https://shop.example.com/",
+		Bodies: []capture.NetworkBody{
+			{URL: "https://shop.example.com/checkout.js", ContentType: "text/javascript",
+				ResponseBody: `document.querySelector("#pay").onclick = () => window.open(authURL)`},
+			{URL: "https://cdn.other.net/lib.js", ContentType: "text/javascript", ResponseBody: "x"},
+		},
+		Waterfall: []capture.NetworkWaterfallEntry{
+			{URL: "https://widgets.partner.io/embed", InitiatorType: "iframe"},
+		},
+	})
+
+	if got := findHeader(t, result, "Cross-Origin-Opener-Policy").Value; got != "same-origin-allow-popups" {
+		t.Errorf("COOP = %q", got)
+	}
+	coep := findHeader(t, result, "Cross-Origin-Embedder-Policy")
+	if coep.Value != "credentialless" || !strings.Contains(coep.Rationale, "iframe") {
+		t.Errorf("COEP = %+v", coep)
+	}
+	if len(result.Observed.CrossOriginOrigins) != 2 || len(result.Observed.CrossOriginFrames) != 1 {
+		t.Errorf("observed = %+v", result.Observed)
+	}
+}
+
+func TestGenerateSecurityHeaders_IsolatedPageAndSensitiveReferrer(t *testing.T) {
+	t.Parallel()
+	result := GenerateSecurityHeaders(SecurityHeadersInput{
+		PageURL: "http://localhost:3000/reset?token=abc",
+		Bodies: []capture.NetworkBody{
+			{URL: "http://localhost:3000/main.js", ContentType: "application/javascript", ResponseBody: "console.log(1)"},
+		},
+	})
+
+	if got := findHeader(t, result, "Cross-Origin-Opener-Policy").Value; got != "same-origin" {
+		t.Errorf("COOP = %q", got)
+	}
+	if got := findHeader(t, result, "Cross-Origin-Embedder-Policy").Value; got != "require-corp" {
+		t.Errorf("COEP = %q", got)
+	}
+	if got := findHeader(t, result, "Referrer-Policy").Value; got != "no-referrer" {
+		t.Errorf("Referrer-Policy = %q", got)
+	}
+	for _, h := range result.Headers {
+		if h.Name == "Strict-Transport-Security" {
+			t.Error("HSTS should not be recommended for http/localhost pages")
+		}
+	}
+}
+
+func TestGenerateSecurityHeaders_ComparesWithDocumentHeaders(t *testing.T) {
+	t.Parallel()
+	result := GenerateSecurityHeaders(SecurityHeadersInput{
+		PageURL: "https://app.example.com/#home",
+		Bodies: []capture.NetworkBody{{
+			URL: "https://app.example.com/", ContentType: "text/html; charset=utf-8",
+			ResponseHeaders: map[string]string{
+				"x-content-type-options":  "nosniff",
+				"X-Frame-Options":         "SAMEORIGIN",
+				"Content-Security-Policy": "default-src 'self'; frame-ancestors 'none'",
+			},
+		}},
+	})
+
+	if h := findHeader(t, result, "X-Content-Type-Options"); h.Status != "matches" {
+		t.Errorf("nosniff = %+v", h)
+	}
+	if h := findHeader(t, result, "X-Frame-Options"); h.Status != "differs" || h.Current != "SAMEORIGIN" {
+		t.Errorf("XFO = %+v", h)
+	}
+	if h := findHeader(t, result, "Content-Security-Policy"); h.Status != "matches" {
+		t.Errorf("frame-ancestors = %+v", h)
+	}
+	if h := findHeader(t, result, "Permissions-Policy"); h.Status != "missing" {
+		t.Errorf("Permissions-Policy = %+v", h)
+	}
+	if !result.Observed.DocumentCaptured {
+		t.Error("document should be matched ignoring the fragment")
+	}
+	if result.Summary.Total != len(result.Headers) || result.Summary.Matches != 2 {
+		t.Errorf("summary = %+v", result.Summary)
+	}
+	if !strings.Contains(result.HeaderBlock, "X-Frame-Options: DENY\n") {
+		t.Errorf("header block = %q", result.HeaderBlock)
+	}
+}
@@ -2,7 +2,10 @@
 // Why: Separates network-level security analysis from credential scanning and header checks.
 package security
 
-import "github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+import (
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/remediation"
+)
 
 func (s *SecurityScanner) checkNetworkSecurity(entries []capture.NetworkWaterfallEntry, pageURLs []string) []SecurityFinding {
 	var findings []SecurityFinding
@@ -16,6 +19,7 @@ func (s *SecurityScanner) checkNetworkSecurity(entries []capture.NetworkWaterfal
 		for _, flag := range flags {
 			findings = append(findings, SecurityFinding{
 				Check:       "network",
+				FindingID:   networkFlagFindingID(flag.Type),
 				Severity:    flag.Severity,
 				Title:       flag.Message,
 				Description: networkFlagDescription(flag.Type),
@@ -28,6 +32,14 @@ func (s *SecurityScanner) checkNetworkSecurity(entries []capture.NetworkWaterfal
 	return findings
 }
 
+// networkFlagFindingID maps a network flag type to its remediation playbook ID.
+func networkFlagFindingID(flagType string) string {
+	if flagType == "mixed_content" {
+		return remediation.SecurityMixedContent
+	}
+	return remediation.SecuritySuspiciousOrigin
+}
+
 func networkFlagDescription(flagType string) string {
 	switch flagType {
 	case "suspicious_tld":
@@ -16,7 +16,7 @@ func (s *SecurityScanner) runSecurityChecks(checkSet map[string]bool, bodies []c
 	}
 	checks := []checkEntry{
 		{"credentials", func() []SecurityFinding { return s.checkCredentials(bodies, input.ConsoleEntries) }},
-		{"pii", func() []SecurityFinding { return s.checkPII(bodies, input.PageURLs) }},
+		{"pii", func() []SecurityFinding { return s.checkPII(bodies, input.PageURLs, input.FirstPartySuffixes) }},
 		{"headers", func() []SecurityFinding { return s.checkSecurityHeaders(bodies) }},
 		{"cookies", func() []SecurityFinding { return s.checkCookies(bodies) }},
 		{"transport", func() []SecurityFinding { return s.checkTransport(bodies, input.PageURLs) }},
@@ -61,9 +61,10 @@ func (s *SecurityScanner) Scan(input SecurityScanInput) SecurityScanResult {
 
 func (s *SecurityScanner) HandleSecurityAudit(params json.RawMessage, bodies []capture.NetworkBody, entries []LogEntry, pageURLs []string, waterfallEntries []capture.NetworkWaterfallEntry) (any, error) {
 	var toolParams struct {
-		Checks      []string `json:"checks"`
-		URLFilter   string   `json:"url"`
-		SeverityMin string   `json:"severity_min"`
+		Checks             []string `json:"checks"`
+		URLFilter          string   `json:"url"`
+		SeverityMin        string   `json:"severity_min"`
+		FirstPartySuffixes []string `json:"first_party_suffixes"`
 	}
 	if len(params) > 0 {
 		_ = json.Unmarshal(params, &toolParams)
@@ -77,6 +78,8 @@ func (s *SecurityScanner) HandleSecurityAudit(params json.RawMessage, bodies []c
 		URLFilter:        toolParams.URLFilter,
 		Checks:           toolParams.Checks,
 		SeverityMin:      toolParams.SeverityMin,
+
+		FirstPartySuffixes: toolParams.FirstPartySuffixes,
 	}
 
 	result := s.Scan(input)
@@ -20,6 +20,9 @@ type SecurityFinding struct {
 	Location    string `json:"location"`
 	Evidence    string `json:"evidence"`
 	Remediation string `json:"remediation"`
+	// FindingID names the remediation playbook for this class of finding
+	// (observe what="playbook"), e.g. "security.missing_csp".
+	FindingID string `json:"finding_id,omitempty"`
 }
 
 type LogEntry = types.LogEntry
@@ -32,6 +35,9 @@ type SecurityScanInput struct {
 	URLFilter        string
 	Checks           []string
 	SeverityMin      string
+	// FirstPartySuffixes are domains treated as first-party (with all subdomains) in addition
+	// to the page hosts and their registrable domains.
+	FirstPartySuffixes []string
 }
 
 type SecurityScanResult struct {
@@ -292,12 +292,18 @@ func TestSecurityHelpers_isTestKeyAndThirdParty(t *testing.T) {
 	}
 
 	pageURLs := []string{"https://app.example.com/dashboard"}
-	if isThirdPartyURL("https://api.app.example.com/users", pageURLs) {
+	if isThirdPartyURL("https://api.app.example.com/users", pageURLs, nil) {
 		t.Fatal("subdomain should not be treated as third-party")
 	}
-	if !isThirdPartyURL("https://evil-example.net/collect", pageURLs) {
+	if !isThirdPartyURL("https://evil-example.net/collect", pageURLs, nil) {
 		t.Fatal("different domain should be treated as third-party")
 	}
+	if isThirdPartyURL("https://cdn.example.com/app.js", pageURLs, nil) {
+		t.Fatal("sibling under the same registrable domain should not be treated as third-party")
+	}
+	if isThirdPartyURL("https://img.example-cdn.net/a.png", pageURLs, []string{"example-cdn.net"}) {
+		t.Fatal("host under a first-party suffix should not be treated as third-party")
+	}
 }
 
 func TestHandleSecurityAudit_CredentialsOnlyPath(t *testing.T) {
@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/util"
 )
 
 func isLocalhostURL(rawURL string) bool {
@@ -28,26 +29,17 @@ func isJavaScriptContent(contentType string) bool {
 	return strings.Contains(ct, "javascript")
 }
 
-func isThirdPartyURL(requestURL string, pageURLs []string) bool {
-	if len(pageURLs) == 0 {
+// isThirdPartyURL reports whether requestURL belongs to a different party than the pages.
+// Subdomains and siblings under the same registrable domain (api.myapp.com, cdn.myapp.com)
+// and hosts matching a first-party suffix are first-party. Without any page URL or suffix
+// there is nothing to compare against, so nothing is third-party.
+func isThirdPartyURL(requestURL string, pageURLs, firstPartySuffixes []string) bool {
+	if len(pageURLs) == 0 && len(firstPartySuffixes) == 0 {
 		return false
 	}
 	reqParsed, err := url.Parse(requestURL)
-	if err != nil {
+	if err != nil || reqParsed.Hostname() == "" {
 		return false
 	}
-	reqHost := reqParsed.Hostname()
-
-	for _, pageURL := range pageURLs {
-		pageParsed, err := url.Parse(pageURL)
-		if err != nil {
-			continue
-		}
-		pageHost := pageParsed.Hostname()
-		// Same domain check (including subdomains)
-		if reqHost == pageHost || strings.HasSuffix(reqHost, "."+pageHost) || strings.HasSuffix(pageHost, "."+reqHost) {
-			return false
-		}
-	}
-	return true
+	return !util.NewFirstPartyRules(pageURLs, firstPartySuffixes).IsFirstPartyHost(reqParsed.Hostname())
 }
@@ -1,4 +1,4 @@
-// Purpose: Generates Subresource Integrity hashes and related metadata from observed script/style resources.
+// Purpose: Generates Subresource Integrity hashes and related metadata from observed script/style/module resources.
 // Why: Enables integrity pinning workflows that reduce third-party tampering and supply-chain risk.
 // Docs: docs/features/feature/security-hardening/index.md
 //
@@ -14,12 +14,14 @@ import (
 //
 // Failure semantics:
 // - Invalid page URLs simply do not contribute first-party origins.
-func newSRIFilterConfig(pageURLs []string, params SRIParams) sriFilterConfig {
+func newSRIFilterConfig(bodies []capture.NetworkBody, pageURLs []string, params SRIParams) sriFilterConfig {
 	cfg := sriFilterConfig{
 		firstPartyOrigins: make(map[string]bool),
 		originFilter:      make(map[string]bool),
+		dynamicImports:    collectDynamicImports(bodies),
 		includeScripts:    true,
 		includeStyles:     true,
+		includeModules:    true,
 	}
 	for _, pageURL := range pageURLs {
 		if origin := util.ExtractOrigin(pageURL); origin != "" {
@@ -32,12 +34,16 @@ func newSRIFilterConfig(pageURLs []string, params SRIParams) sriFilterConfig {
 	if len(params.ResourceTypes) > 0 {
 		cfg.includeScripts = false
 		cfg.includeStyles = false
+		cfg.includeModules = false
 		for _, rt := range params.ResourceTypes {
 			switch rt {
 			case "scripts":
 				cfg.includeScripts = true
+				cfg.includeModules = true
 			case "styles":
 				cfg.includeStyles = true
+			case "modules":
+				cfg.includeModules = true
 			}
 		}
 	}
@@ -46,7 +52,8 @@ func newSRIFilterConfig(pageURLs []string, params SRIParams) sriFilterConfig {
 
 // shouldIncludeResourceType returns whether resource type passes requested filters.
 func (cfg sriFilterConfig) shouldIncludeResourceType(resType string) bool {
-	return (resType == "script" && cfg.includeScripts) || (resType == "style" && cfg.includeStyles)
+	return (resType == "script" && cfg.includeScripts) || (resType == "style" && cfg.includeStyles) ||
+		(resType == "module" && cfg.includeModules)
 }
 
 // hasVaryUserAgent checks if response headers contain Vary: User-Agent.
@@ -85,6 +92,9 @@ func (g *SRIGenerator) evaluateBody(body capture.NetworkBody, cfg sriFilterConfi
 	}
 
 	resType := sriResourceType(body.ContentType)
+	if resType == "script" && cfg.dynamicImports[body.URL] {
+		resType = "module"
+	}
 	if resType == "" {
 		return sriBodyOutcome{thirdParty: true, skip: true}
 	}
@@ -129,7 +139,7 @@ func buildSRIWarnings(truncated, placeholder, varyUA []string) []string {
 	return warnings
 }
 
-// Generate analyzes captured bodies and emits hashable third-party script/style resources.
+// Generate analyzes captured bodies and emits hashable third-party script/style/module resources.
 //
 // Invariants:
 // - Summary counters include filtered/skipped third-party resources for auditability.
@@ -137,11 +147,11 @@ func buildSRIWarnings(truncated, placeholder, varyUA []string) []string {
 // Failure semantics:
 // - Non-hashable resources are excluded with warnings instead of aborting the run.
 func (g *SRIGenerator) Generate(bodies []capture.NetworkBody, pageURLs []string, params SRIParams) SRIResult {
-	cfg := newSRIFilterConfig(pageURLs, params)
-	result := SRIResult{Resources: []SRIResource{}, Warnings: []string{}}
+	cfg := newSRIFilterConfig(bodies, pageURLs, params)
+	result := SRIResult{Resources: []SRIResource{}, Manifest: map[string]string{}, Warnings: []string{}}
 	seenURLs := make(map[string]bool)
 
-	var totalThirdParty, scriptsWithoutSRI, stylesWithoutSRI int
+	var totalThirdParty, scriptsWithoutSRI, stylesWithoutSRI, modulesWithoutSRI int
 	var truncated, placeholder, varyUA []string
 
 	for _, body := range bodies {
@@ -154,6 +164,8 @@ func (g *SRIGenerator) Generate(bodies []capture.NetworkBody, pageURLs []string,
 			scriptsWithoutSRI++
 		case "style":
 			stylesWithoutSRI++
+		case "module":
+			modulesWithoutSRI++
 		}
 		if out.placeholder {
 			placeholder = append(placeholder, body.URL)
@@ -170,13 +182,20 @@ func (g *SRIGenerator) Generate(bodies []capture.NetworkBody, pageURLs []string,
 			varyUA = append(varyUA, body.URL)
 		}
 		result.Resources = append(result.Resources, out.resource)
+		result.Manifest[out.resource.URL] = out.resource.Hash
 	}
 
+	tags := make([]string, 0, len(result.Resources))
+	for _, res := range result.Resources {
+		tags = append(tags, res.TagTemplate)
+	}
+	result.HTMLPatch = strings.Join(tags, "\n")
 	result.Warnings = buildSRIWarnings(truncated, placeholder, varyUA)
 	result.Summary = SRISummary{
 		TotalThirdPartyResources: totalThirdParty,
 		ScriptsWithoutSRI:        scriptsWithoutSRI,
 		StylesWithoutSRI:         stylesWithoutSRI,
+		ModulesWithoutSRI:        modulesWithoutSRI,
 		HashesGenerated:          len(result.Resources),
 	}
 	return result
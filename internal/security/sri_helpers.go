@@ -6,9 +6,16 @@ import (
 	"crypto/sha512"
 	"encoding/base64"
 	"fmt"
+	"net/url"
+	"regexp"
 	"strings"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
 )
 
+// dynamicImportPattern matches import("specifier") calls with a string-literal specifier.
+var dynamicImportPattern = regexp.MustCompile("\\bimport\\s*\\(\\s*[\"'`]([^\"'`\\s]+)[\"'`]\\s*\\)")
+
 // computeSHA384 computes the SHA-384 hash of content and returns it in SRI format.
 func computeSHA384(content string) string {
 	hasher := sha512.New384()
@@ -41,7 +48,44 @@ func sriResourceType(contentType string) string {
 	return ""
 }
 
+// collectDynamicImports returns absolute URLs referenced by import() in captured script bodies.
+// Relative specifiers resolve against the importing script URL; bare specifiers
+// (resolved by bundlers or import maps) are ignored.
+func collectDynamicImports(bodies []capture.NetworkBody) map[string]bool {
+	imports := make(map[string]bool)
+	for _, body := range bodies {
+		if sriResourceType(body.ContentType) != "script" || !strings.Contains(body.ResponseBody, "import") {
+			continue
+		}
+		base, err := url.Parse(body.URL)
+		if err != nil {
+			continue
+		}
+		for _, m := range dynamicImportPattern.FindAllStringSubmatch(body.ResponseBody, -1) {
+			spec := m[1]
+			if !isURLSpecifier(spec) {
+				continue
+			}
+			if target, err := base.Parse(spec); err == nil {
+				imports[target.String()] = true
+			}
+		}
+	}
+	return imports
+}
+
+// isURLSpecifier reports whether a module specifier is a URL or path rather than a bare package name.
+func isURLSpecifier(spec string) bool {
+	for _, prefix := range []string{"./", "../", "/", "https://", "http://"} {
+		if strings.HasPrefix(spec, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
 // generateTagTemplate creates an HTML tag with SRI attributes.
+// import() cannot carry an integrity attribute, so modules are pinned with modulepreload.
 func generateTagTemplate(resourceURL, hash, resType string) string {
 	if resType == "script" {
 		return fmt.Sprintf(`<script src="%s" integrity="%s" crossorigin="anonymous"></script>`, resourceURL, hash)
@@ -49,5 +93,8 @@ func generateTagTemplate(resourceURL, hash, resType string) string {
 	if resType == "style" {
 		return fmt.Sprintf(`<link rel="stylesheet" href="%s" integrity="%s" crossorigin="anonymous">`, resourceURL, hash)
 	}
+	if resType == "module" {
+		return fmt.Sprintf(`<link rel="modulepreload" href="%s" integrity="%s" crossorigin="anonymous">`, resourceURL, hash)
+	}
 	return ""
 }
@@ -515,3 +515,73 @@ func TestSRIGeneratorSubdomainFirstParty(t *testing.T) {
 		t.Errorf("expected at least 1 resource, got %d", len(result.Resources))
 	}
 }
+
+func TestSRIGeneratorDynamicImportModules(t *testing.T) {
+	t.Parallel()
+	gen := NewSRIGenerator()
+	bodies := []NetworkBody{
+		{URL: "https://cdn.example.com/lib/main.js", ContentType: "text/javascript",
+			ResponseBody: `const m = await import("./chunk-a.js"); import('https://cdn.example.com/b.mjs'); import("lodash");`},
+		{URL: "https://cdn.example.com/lib/chunk-a.js", ContentType: "text/javascript", ResponseBody: "export const a = 1;"},
+		{URL: "https://cdn.example.com/b.mjs", ContentType: "application/javascript", ResponseBody: "export const b = 2;"},
+		{URL: "https://cdn.example.com/theme.css", ContentType: "text/css", ResponseBody: "body{}"},
+	}
+
+	result := gen.Generate(bodies, []string{"https://myapp.com/"}, SRIParams{})
+
+	byURL := map[string]SRIResource{}
+	for _, r := range result.Resources {
+		byURL[r.URL] = r
+	}
+	if byURL["https://cdn.example.com/lib/main.js"].Type != "script" {
+		t.Errorf("importing script should stay type=script, got %+v", byURL["https://cdn.example.com/lib/main.js"])
+	}
+	for _, u := range []string{"https://cdn.example.com/lib/chunk-a.js", "https://cdn.example.com/b.mjs"} {
+		res := byURL[u]
+		if res.Type != "module" || !strings.Contains(res.TagTemplate, `rel="modulepreload"`) {
+			t.Errorf("%s: expected modulepreload module, got %+v", u, res)
+		}
+	}
+	if result.Summary.ModulesWithoutSRI != 2 || result.Summary.ScriptsWithoutSRI != 1 || result.Summary.StylesWithoutSRI != 1 {
+		t.Errorf("summary = %+v, want 1 script, 1 style, 2 modules", result.Summary)
+	}
+
+	// "modules" selects only dynamically imported modules; "scripts" includes them.
+	if r := gen.Generate(bodies, []string{"https://myapp.com/"}, SRIParams{ResourceTypes: []string{"modules"}}); len(r.Resources) != 2 {
+		t.Errorf("modules filter: got %d resources, want 2", len(r.Resources))
+	}
+	if r := gen.Generate(bodies, []string{"https://myapp.com/"}, SRIParams{ResourceTypes: []string{"scripts"}}); len(r.Resources) != 3 {
+		t.Errorf("scripts filter: got %d resources, want 3", len(r.Resources))
+	}
+}
+
+func TestSRIGeneratorHTMLPatchAndManifest(t *testing.T) {
+	t.Parallel()
+	gen := NewSRIGenerator()
+	bodies := []NetworkBody{
+		{URL: "https://cdn.example.com/app.js", ContentType: "application/javascript", ResponseBody: "js"},
+		{URL: "https://cdn.example.com/style.css", ContentType: "text/css", ResponseBody: "css"},
+	}
+
+	result := gen.Generate(bodies, []string{"https://myapp.com/"}, SRIParams{})
+
+	if len(result.Manifest) != 2 {
+		t.Fatalf("manifest = %v, want 2 entries", result.Manifest)
+	}
+	for _, r := range result.Resources {
+		if result.Manifest[r.URL] != r.Hash {
+			t.Errorf("manifest[%s] = %q, want %q", r.URL, result.Manifest[r.URL], r.Hash)
+		}
+		if !strings.Contains(result.HTMLPatch, r.TagTemplate) {
+			t.Errorf("html_patch missing tag for %s", r.URL)
+		}
+	}
+	if lines := strings.Split(result.HTMLPatch, "\n"); len(lines) != 2 {
+		t.Errorf("html_patch has %d lines, want 2", len(lines))
+	}
+
+	empty := gen.Generate(nil, nil, SRIParams{})
+	if empty.Manifest == nil || empty.HTMLPatch != "" {
+		t.Errorf("empty result should have empty manifest and patch, got %+v", empty)
+	}
+}
@@ -8,9 +8,10 @@ type SRIGenerator struct{}
 // SRIParams defines filter/output options for generate_sri tool.
 //
 // Invariants:
-// - ResourceTypes accepts scripts/styles; unknown values are ignored.
+// - ResourceTypes accepts scripts/styles/modules; unknown values are ignored.
+// - "scripts" also selects modules, since dynamically imported modules are scripts.
 type SRIParams struct {
-	ResourceTypes []string `json:"resource_types"` // "scripts", "styles" - default: both
+	ResourceTypes []string `json:"resource_types"` // "scripts", "styles", "modules" - default: all
 	Origins       []string `json:"origins"`        // Filter to specific origins
 	OutputFormat  string   `json:"output_format"`  // "html", "json", "webpack", "vite" - default: html
 }
@@ -18,14 +19,18 @@ type SRIParams struct {
 // SRIResult is the full response from the generate_sri tool.
 type SRIResult struct {
 	Resources []SRIResource `json:"resources"`
-	Summary   SRISummary    `json:"summary"`
-	Warnings  []string      `json:"warnings,omitempty"`
+	// HTMLPatch is every tag template joined by newlines, ready to paste into <head>.
+	HTMLPatch string `json:"html_patch"`
+	// Manifest maps resource URL to its integrity attribute value.
+	Manifest map[string]string `json:"manifest"`
+	Summary  SRISummary        `json:"summary"`
+	Warnings []string          `json:"warnings,omitempty"`
 }
 
 // SRIResource represents a single resource with its computed SRI hash.
 type SRIResource struct {
 	URL           string `json:"url"`
-	Type          string `json:"type"` // "script" or "style"
+	Type          string `json:"type"` // "script", "style", or "module"
 	Hash          string `json:"hash"` // "sha384-{base64hash}"
 	Crossorigin   string `json:"crossorigin"`
 	TagTemplate   string `json:"tag_template"` // Ready-to-use HTML tag
@@ -38,6 +43,7 @@ type SRISummary struct {
 	TotalThirdPartyResources int `json:"total_third_party_resources"`
 	ScriptsWithoutSRI        int `json:"scripts_without_sri"`
 	StylesWithoutSRI         int `json:"styles_without_sri"`
+	ModulesWithoutSRI        int `json:"modules_without_sri"`
 	AlreadyProtected         int `json:"already_protected"`
 	HashesGenerated          int `json:"hashes_generated"`
 }
@@ -49,14 +55,16 @@ type SRISummary struct {
 type sriFilterConfig struct {
 	firstPartyOrigins map[string]bool
 	originFilter      map[string]bool
+	dynamicImports    map[string]bool // absolute URLs loaded via import() in captured scripts
 	includeScripts    bool
 	includeStyles     bool
+	includeModules    bool
 }
 
 // sriBodyOutcome describes the result of evaluating a single network body for SRI.
 type sriBodyOutcome struct {
 	thirdParty  bool
-	resType     string // "script", "style", or "" if not applicable
+	resType     string // "script", "style", "module", or "" if not applicable
 	skip        bool   // true when filtered out or duplicate
 	truncated   bool
 	placeholder bool // true when body is a capture placeholder, not real content
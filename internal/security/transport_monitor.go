@@ -0,0 +1,247 @@
+// Purpose: Continuously watches ingested network telemetry for mixed content, insecure WebSockets, and HTTPS downgrades.
+// Why: Point-in-time scans miss transport regressions that appear mid-session; violations should alert as they happen.
+// Docs: docs/features/feature/security-hardening/index.md
+
+package security
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/types"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/util"
+)
+
+// Transport violation kinds.
+const (
+	TransportMixedContent      = "mixed_content"
+	TransportInsecureWebSocket = "insecure_websocket"
+	TransportDowngradeRedirect = "downgrade_redirect"
+)
+
+// maxTransportViolations bounds distinct violations retained by the monitor.
+const maxTransportViolations = 200
+
+// TransportViolation is one distinct insecure-transport occurrence, keyed by kind, page origin, and resource.
+type TransportViolation struct {
+	Kind           string    `json:"kind"`
+	Severity       string    `json:"severity"`
+	URL            string    `json:"url"`
+	PageURL        string    `json:"page_url,omitempty"`
+	RedirectTarget string    `json:"redirect_target,omitempty"`
+	InitiatorType  string    `json:"initiator_type,omitempty"`
+	Count          int       `json:"count"`
+	FirstSeen      time.Time `json:"first_seen"`
+	LastSeen       time.Time `json:"last_seen"`
+}
+
+// TransportSecuritySummary is the monitor state returned by observe(what="transport_security").
+type TransportSecuritySummary struct {
+	Status          string               `json:"status"` // "secure" or "violations"
+	TotalViolations int                  `json:"total_violations"`
+	ByKind          map[string]int       `json:"by_kind"`
+	Violations      []TransportViolation `json:"violations"`
+	MonitoringSince time.Time            `json:"monitoring_since"`
+}
+
+// TransportMonitor accumulates transport violations across the session.
+// Each distinct violation raises one alert when first seen; repeats only bump its count.
+type TransportMonitor struct {
+	mu         sync.Mutex
+	violations map[string]*TransportViolation
+	order      []string // insertion order for eviction
+	startedAt  time.Time
+}
+
+// NewTransportMonitor creates an empty monitor.
+func NewTransportMonitor() *TransportMonitor {
+	return &TransportMonitor{
+		violations: make(map[string]*TransportViolation),
+		startedAt:  time.Now(),
+	}
+}
+
+// Observe checks one ingested batch and returns alerts for violations not seen before.
+func (m *TransportMonitor) Observe(activity capture.NetworkActivity, now time.Time) []types.Alert {
+	var found []TransportViolation
+	for _, entry := range activity.Waterfall {
+		pageURL := entry.PageURL
+		if pageURL == "" {
+			pageURL = activity.PageURL
+		}
+		if flag := checkMixedContent(entry, pageURL); flag != nil && !isLoopbackURL(entry.URL) {
+			found = append(found, TransportViolation{
+				Kind: TransportMixedContent, Severity: flag.Severity,
+				URL: entry.URL, PageURL: pageURL, InitiatorType: entry.InitiatorType,
+			})
+		}
+	}
+	for _, body := range activity.Bodies {
+		if isSecurePage(activity.PageURL) && schemeOf(body.URL) == "http" && !isLoopbackURL(body.URL) {
+			// fetch/XHR is active mixed content: it can rewrite page state.
+			found = append(found, TransportViolation{
+				Kind: TransportMixedContent, Severity: "high",
+				URL: body.URL, PageURL: activity.PageURL, InitiatorType: "fetch",
+			})
+		}
+		if target := downgradeTarget(body); target != "" {
+			found = append(found, TransportViolation{
+				Kind: TransportDowngradeRedirect, Severity: "high",
+				URL: body.URL, PageURL: activity.PageURL, RedirectTarget: target,
+			})
+		}
+	}
+	for _, event := range activity.WebSocket {
+		if isSecurePage(activity.PageURL) && schemeOf(event.URL) == "ws" && !isLoopbackURL(event.URL) {
+			found = append(found, TransportViolation{
+				Kind: TransportInsecureWebSocket, Severity: "high",
+				URL: event.URL, PageURL: activity.PageURL,
+			})
+		}
+	}
+	if len(found) == 0 {
+		return nil
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var alerts []types.Alert
+	for _, v := range found {
+		key := v.Kind + "|" + util.ExtractOrigin(v.PageURL) + "|" + v.URL
+		if existing, ok := m.violations[key]; ok {
+			existing.Count++
+			existing.LastSeen = now
+			continue
+		}
+		v.Count = 1
+		v.FirstSeen = now
+		v.LastSeen = now
+		m.violations[key] = &v
+		m.order = append(m.order, key)
+		m.evictLocked()
+		alerts = append(alerts, transportAlert(v, now))
+	}
+	return alerts
+}
+
+// Summary returns retained violations, most recent first, optionally filtered by URL substring
+// (matched against the resource and page URLs).
+func (m *TransportMonitor) Summary(urlFilter string) TransportSecuritySummary {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	summary := TransportSecuritySummary{
+		Status:          "secure",
+		ByKind:          map[string]int{},
+		Violations:      []TransportViolation{},
+		MonitoringSince: m.startedAt.UTC(),
+	}
+	for _, key := range m.order {
+		v := m.violations[key]
+		if urlFilter != "" && !strings.Contains(v.URL, urlFilter) && !strings.Contains(v.PageURL, urlFilter) {
+			continue
+		}
+		summary.Violations = append(summary.Violations, *v)
+		summary.ByKind[v.Kind]++
+	}
+	sort.SliceStable(summary.Violations, func(i, j int) bool {
+		return summary.Violations[i].LastSeen.After(summary.Violations[j].LastSeen)
+	})
+	summary.TotalViolations = len(summary.Violations)
+	if summary.TotalViolations > 0 {
+		summary.Status = "violations"
+	}
+	return summary
+}
+
+// Reset clears all retained violations.
+func (m *TransportMonitor) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.violations = make(map[string]*TransportViolation)
+	m.order = nil
+	m.startedAt = time.Now()
+}
+
+// evictLocked drops the oldest violations beyond the retention cap. Must be called with m.mu held.
+func (m *TransportMonitor) evictLocked() {
+	for len(m.order) > maxTransportViolations {
+		delete(m.violations, m.order[0])
+		m.order = m.order[1:]
+	}
+}
+
+// downgradeTarget returns the http:// Location of an https redirect response, or "".
+func downgradeTarget(body capture.NetworkBody) string {
+	if body.Status < 300 || body.Status > 399 || schemeOf(body.URL) != "https" {
+		return ""
+	}
+	for name, value := range body.ResponseHeaders {
+		if !strings.EqualFold(name, "location") {
+			continue
+		}
+		base, err := url.Parse(body.URL)
+		if err != nil {
+			return ""
+		}
+		target, err := base.Parse(strings.TrimSpace(value))
+		if err != nil || target.Scheme != "http" || isLocalHost(target.Hostname()) {
+			return ""
+		}
+		return target.String()
+	}
+	return ""
+}
+
+func transportAlert(v TransportViolation, now time.Time) types.Alert {
+	severity := "warning"
+	if v.Severity == "high" {
+		severity = "error"
+	}
+	var title, detail string
+	switch v.Kind {
+	case TransportInsecureWebSocket:
+		title = "Insecure WebSocket on HTTPS page"
+		detail = fmt.Sprintf("%s opened an unencrypted ws:// connection to %s; use wss://.", v.PageURL, v.URL)
+	case TransportDowngradeRedirect:
+		title = "HTTPS request redirected to HTTP"
+		detail = fmt.Sprintf("%s redirected to %s, dropping TLS; redirect to an https:// URL and enable HSTS.", v.URL, v.RedirectTarget)
+	default:
+		title = "Mixed content: HTTP resource on HTTPS page"
+		detail = fmt.Sprintf("%s loaded %s over plain HTTP (%s); serve it over HTTPS.", v.PageURL, v.URL, v.InitiatorType)
+	}
+	return types.Alert{
+		Severity:  severity,
+		Category:  "security",
+		Title:     title,
+		Detail:    detail,
+		Timestamp: now.Format(time.RFC3339),
+		Source:    "transport_monitor",
+	}
+}
+
+func isSecurePage(pageURL string) bool {
+	return schemeOf(pageURL) == "https"
+}
+
+func schemeOf(raw string) string {
+	parsed, err := url.Parse(raw)
+	if err != nil {
+		return ""
+	}
+	return strings.ToLower(parsed.Scheme)
+}
+
+// isLoopbackURL reports whether the URL targets localhost, which browsers treat as potentially trustworthy.
+func isLoopbackURL(raw string) bool {
+	parsed, err := url.Parse(raw)
+	if err != nil {
+		return false
+	}
+	return isLocalHost(parsed.Hostname())
+}
@@ -0,0 +1,89 @@
+// Purpose: Tests for continuous mixed-content, insecure WebSocket, and downgrade-redirect monitoring.
+// Docs: docs/features/feature/security-hardening/index.md
+
+package security
+
+import (
+	"testing"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+)
+
+func TestTransportMonitor_DetectsEachViolationKind(t *testing.T) {
+	t.Parallel()
+	m := NewTransportMonitor()
+	now := time.Now()
+
+	alerts := m.Observe(capture.NetworkActivity{
+		PageURL: "https://app.test/",
+		Waterfall: []capture.NetworkWaterfallEntry{
+			{URL: "http://cdn.test/app.js", InitiatorType: "script"},
+			{URL: "http://localhost:3000/dev.js", InitiatorType: "script"}, // loopback is exempt
+			{URL: "https://cdn.test/ok.css", InitiatorType: "stylesheet"},
+		},
+	}, now)
+	alerts = append(alerts, m.Observe(capture.NetworkActivity{
+		PageURL: "https://app.test/",
+		Bodies: []capture.NetworkBody{{
+			URL: "https://app.test/old", Status: 301,
+			ResponseHeaders: map[string]string{"Location": "http://app.test/new"},
+		}},
+	}, now)...)
+	alerts = append(alerts, m.Observe(capture.NetworkActivity{
+		PageURL:   "https://app.test/",
+		WebSocket: []capture.WebSocketEvent{{Event: "open", URL: "ws://live.test/feed"}},
+	}, now)...)
+
+	if len(alerts) != 3 {
+		t.Fatalf("len(alerts) = %d, want 3: %+v", len(alerts), alerts)
+	}
+	for _, a := range alerts {
+		if a.Category != "security" || a.Source != "transport_monitor" || a.Severity != "error" {
+			t.Errorf("unexpected alert shape: %+v", a)
+		}
+	}
+	summary := m.Summary("")
+	if summary.Status != "violations" || summary.TotalViolations != 3 {
+		t.Fatalf("summary = %+v, want 3 violations", summary)
+	}
+	for _, kind := range []string{TransportMixedContent, TransportDowngradeRedirect, TransportInsecureWebSocket} {
+		if summary.ByKind[kind] != 1 {
+			t.Errorf("ByKind[%s] = %d, want 1", kind, summary.ByKind[kind])
+		}
+	}
+}
+
+func TestTransportMonitor_RepeatsCountWithoutRealerting(t *testing.T) {
+	t.Parallel()
+	m := NewTransportMonitor()
+	activity := capture.NetworkActivity{
+		PageURL: "https://app.test/",
+		Bodies:  []capture.NetworkBody{{URL: "http://api.test/data", Status: 200}},
+	}
+	if got := m.Observe(activity, time.Now()); len(got) != 1 {
+		t.Fatalf("first observation alerts = %d, want 1", len(got))
+	}
+	if got := m.Observe(activity, time.Now()); len(got) != 0 {
+		t.Fatalf("repeat observation alerts = %d, want 0", len(got))
+	}
+	if v := m.Summary("api.test").Violations; len(v) != 1 || v[0].Count != 2 {
+		t.Fatalf("violations = %+v, want one with count 2", v)
+	}
+	if s := m.Summary("other.test"); s.Status != "secure" || s.TotalViolations != 0 {
+		t.Fatalf("filtered summary = %+v, want secure", s)
+	}
+}
+
+func TestTransportMonitor_IgnoresInsecurePages(t *testing.T) {
+	t.Parallel()
+	m := NewTransportMonitor()
+	alerts := m.Observe(capture.NetworkActivity{
+		PageURL:   "http://app.test/",
+		Bodies:    []capture.NetworkBody{{URL: "http://api.test/data"}},
+		WebSocket: []capture.WebSocketEvent{{URL: "ws://live.test/feed"}},
+	}, time.Now())
+	if len(alerts) != 0 {
+		t.Fatalf("http page should not raise transport alerts: %+v", alerts)
+	}
+}
@@ -0,0 +1,17 @@
+// Purpose: Package serverregistry — the servers.json discovery file mapping project directories to daemon ports.
+// Why: Two projects can each run a daemon; clients started in a project must find its port instead of assuming 7890.
+// Docs: docs/features/feature/port-discovery/index.md
+
+/*
+Package serverregistry keeps servers.json in the runtime state root: one entry per
+project directory with the port, PID, and state dir of the daemon serving it.
+
+Key types:
+  - Server: one daemon as recorded by its own startup.
+
+Key functions:
+  - Register / Unregister: a daemon adds itself at startup and removes itself on shutdown.
+  - Lookup: the entry for a working directory, matching the nearest enclosing project.
+  - PickPort: the first port slot (port, port+1, port+2) that is free to bind.
+*/
+package serverregistry
@@ -0,0 +1,184 @@
+// Purpose: Reads and rewrites servers.json, resolves a working directory to its project's daemon, and picks free port slots.
+// Why: Lets connect mode, the bridge, and the CLI resolve the right daemon by CWD when several projects run their own.
+// Docs: docs/features/feature/port-discovery/index.md
+
+package serverregistry
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/state"
+)
+
+const (
+	// FileEnv overrides the registry path. A daemon whose state dir is project-scoped inherits it
+	// from the process that chose the port, so every daemon registers in the same file.
+	FileEnv = "KABOOM_SERVERS_FILE"
+
+	// PortStride separates candidate ports: each daemon also binds port+1 (terminal) and port+2 (LSP).
+	PortStride = 10
+	// MaxPortCandidates bounds how far PickPort searches above the base port.
+	MaxPortCandidates = 20
+)
+
+// Server is one daemon as recorded in servers.json, keyed by its project directory.
+type Server struct {
+	Port      int    `json:"port"`
+	PID       int    `json:"pid"`
+	StateDir  string `json:"state_dir,omitempty"`
+	Version   string `json:"version,omitempty"`
+	StartedAt string `json:"started_at,omitempty"`
+}
+
+// Path returns the registry file: $KABOOM_SERVERS_FILE, else servers.json in the state root.
+func Path() (string, error) {
+	if override := strings.TrimSpace(os.Getenv(FileEnv)); override != "" {
+		return filepath.Clean(override), nil
+	}
+	return state.InRoot("servers.json")
+}
+
+// Load reads the registry. A missing file is an empty registry.
+func Load(path string) (map[string]Server, error) {
+	data, err := os.ReadFile(path) // #nosec G304 -- registry path from the runtime state dir or FileEnv
+	if err != nil {
+		if os.IsNotExist(err) {
+			return map[string]Server{}, nil
+		}
+		return nil, err
+	}
+	servers := map[string]Server{}
+	if err := json.Unmarshal(data, &servers); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	return servers, nil
+}
+
+// save writes the registry atomically so a reader never sees a partial file.
+func save(path string, servers map[string]Server) error {
+	data, err := json.MarshalIndent(servers, "", "  ")
+	if err != nil {
+		return err
+	}
+	// #nosec G301 -- runtime state directory: owner rwx, group rx for diagnostics
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return err
+	}
+	tmp := path + ".tmp-" + strconv.Itoa(os.Getpid())
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+	return nil
+}
+
+// Register records srv for project. Entries for the same project or the same port are replaced:
+// a port can only be served by one daemon, so an older claim on it is stale.
+func Register(path, project string, srv Server) error {
+	project, err := normalizeProject(project)
+	if err != nil {
+		return err
+	}
+	servers, err := Load(path)
+	if err != nil {
+		servers = map[string]Server{} // a corrupt registry is rebuilt rather than blocking startup
+	}
+	for key, existing := range servers {
+		if existing.Port == srv.Port {
+			delete(servers, key)
+		}
+	}
+	servers[project] = srv
+	return save(path, servers)
+}
+
+// Unregister removes the entry for port if pid still owns it.
+func Unregister(path string, port, pid int) error {
+	servers, err := Load(path)
+	if err != nil {
+		return err
+	}
+	changed := false
+	for key, existing := range servers {
+		if existing.Port == port && existing.PID == pid {
+			delete(servers, key)
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+	return save(path, servers)
+}
+
+// Lookup returns the entry whose project is cwd or its nearest enclosing directory.
+func Lookup(servers map[string]Server, cwd string) (string, Server, bool) {
+	cwd, err := normalizeProject(cwd)
+	if err != nil {
+		return "", Server{}, false
+	}
+	best := ""
+	for project := range servers {
+		if !withinProject(cwd, project) || len(project) <= len(best) {
+			continue
+		}
+		best = project
+	}
+	if best == "" {
+		return "", Server{}, false
+	}
+	return best, servers[best], true
+}
+
+// PickPort returns the first of base, base+PortStride, ... whose port, port+1, and port+2 are all free.
+func PickPort(base int) (int, error) {
+	for i := 0; i < MaxPortCandidates; i++ {
+		port := base + i*PortStride
+		if port+2 > 65535 {
+			break
+		}
+		if portsFree(port, port+1, port+2) {
+			return port, nil
+		}
+	}
+	return 0, fmt.Errorf("no free port slot in %d-%d (step %d)", base, base+(MaxPortCandidates-1)*PortStride, PortStride)
+}
+
+func portsFree(ports ...int) bool {
+	for _, port := range ports {
+		ln, err := net.Listen("tcp", "127.0.0.1:"+strconv.Itoa(port))
+		if err != nil {
+			return false
+		}
+		_ = ln.Close()
+	}
+	return true
+}
+
+func normalizeProject(dir string) (string, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	if resolved, err := filepath.EvalSymlinks(abs); err == nil {
+		abs = resolved
+	}
+	return filepath.Clean(abs), nil
+}
+
+func withinProject(cwd, project string) bool {
+	rel, err := filepath.Rel(project, cwd)
+	if err != nil {
+		return false
+	}
+	return rel == "." || (rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)))
+}
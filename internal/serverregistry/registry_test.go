@@ -0,0 +1,103 @@
+// Purpose: Tests for servers.json registration, CWD lookup, and port slot selection.
+// Docs: docs/features/feature/port-discovery/index.md
+
+package serverregistry
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRegisterLookupUnregister(t *testing.T) {
+	t.Parallel()
+	root := t.TempDir()
+	path := filepath.Join(root, "servers.json")
+	web := filepath.Join(root, "web")
+	admin := filepath.Join(root, "web", "admin")
+	for _, dir := range []string{web, admin, filepath.Join(admin, "src")} {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := Register(path, web, Server{Port: 7890, PID: 10}); err != nil {
+		t.Fatal(err)
+	}
+	if err := Register(path, admin, Server{Port: 7900, PID: 11}); err != nil {
+		t.Fatal(err)
+	}
+	servers, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		cwd  string
+		port int
+		ok   bool
+	}{
+		{web, 7890, true},
+		{admin, 7900, true},
+		{filepath.Join(admin, "src"), 7900, true}, // nearest enclosing project wins
+		{root, 0, false},
+	}
+	for _, tt := range tests {
+		_, srv, ok := Lookup(servers, tt.cwd)
+		if ok != tt.ok || srv.Port != tt.port {
+			t.Errorf("Lookup(%s) = %d, %v; want %d, %v", tt.cwd, srv.Port, ok, tt.port, tt.ok)
+		}
+	}
+
+	// A new daemon on a port replaces the stale claim of another project.
+	if err := Register(path, admin, Server{Port: 7890, PID: 12}); err != nil {
+		t.Fatal(err)
+	}
+	servers, _ = Load(path)
+	if len(servers) != 1 {
+		t.Fatalf("servers = %+v, want only the new claim on 7890", servers)
+	}
+
+	// Unregister only removes the entry the PID still owns.
+	if err := Unregister(path, 7890, 10); err != nil {
+		t.Fatal(err)
+	}
+	if servers, _ = Load(path); len(servers) != 1 {
+		t.Fatalf("foreign PID removed the entry: %+v", servers)
+	}
+	if err := Unregister(path, 7890, 12); err != nil {
+		t.Fatal(err)
+	}
+	if servers, _ = Load(path); len(servers) != 0 {
+		t.Fatalf("servers = %+v, want empty", servers)
+	}
+}
+
+func TestLoad_MissingFileIsEmpty(t *testing.T) {
+	t.Parallel()
+	servers, err := Load(filepath.Join(t.TempDir(), "servers.json"))
+	if err != nil || len(servers) != 0 {
+		t.Fatalf("Load = %v, %v", servers, err)
+	}
+}
+
+func TestPickPort_SkipsBusySlot(t *testing.T) {
+	t.Parallel()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = ln.Close() }()
+	busy := ln.Addr().(*net.TCPAddr).Port
+
+	// The slot starting two below the busy port includes it as its LSP port.
+	base := busy - 2
+	port, err := PickPort(base)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if port == base || (port-base)%PortStride != 0 {
+		t.Fatalf("PickPort(%d) = %d, want a later slot (busy %d)", base, port, busy)
+	}
+}
@@ -10,6 +10,8 @@ package session
 import (
 	"sync"
 	"time"
+
+	gastypes "github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/types"
 )
 
 // ============================================
@@ -116,6 +118,31 @@ func (r *ClientRegistry) GetOrDefault(id string) *ClientState {
 	}
 }
 
+// SetSourceControl records the git state reported by a registered client.
+// Returns false when the client is not registered.
+func (r *ClientRegistry) SetSourceControl(id string, sc gastypes.SourceControl) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	cs, exists := r.clients[id]
+	if !exists {
+		return false
+	}
+	cs.SetSourceControl(sc)
+	return true
+}
+
+// SourceControl returns the git state reported by a client, or nil when the client is
+// unknown or reported none. Unlike Get, it does not count as client activity.
+func (r *ClientRegistry) SourceControl(id string) *gastypes.SourceControl {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	cs, exists := r.clients[id]
+	if !exists {
+		return nil
+	}
+	return cs.GetSourceControl()
+}
+
 // Unregister removes a client by ID.
 // Returns true when a client was removed, false when the ID was not registered.
 func (r *ClientRegistry) Unregister(id string) bool {
@@ -145,6 +172,10 @@ func (r *ClientRegistry) List() []ClientInfo {
 			LastSeenAt: cs.LastSeenAt.Format(time.RFC3339),
 			IdleFor:    time.Since(cs.LastSeenAt).Round(time.Second).String(),
 		}
+		if cs.SourceControl != nil {
+			sc := *cs.SourceControl
+			info.SourceControl = &sc
+		}
 		cs.mu.RUnlock()
 		result = append(result, info)
 	}
@@ -243,4 +274,6 @@ type ClientInfo struct {
 	CreatedAt  string `json:"created_at"`
 	LastSeenAt string `json:"last_seen_at"`
 	IdleFor    string `json:"idle_for"`
+
+	SourceControl *gastypes.SourceControl `json:"source_control,omitempty"`
 }
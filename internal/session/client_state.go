@@ -9,6 +9,8 @@ import (
 	"encoding/hex"
 	"sync"
 	"time"
+
+	gastypes "github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/types"
 )
 
 // ============================================
@@ -46,6 +48,9 @@ type ClientState struct {
 	// Per-client checkpoint namespace prefix (clientId + ":")
 	// Checkpoints are stored as "clientId:checkpointName" in the global store
 	CheckpointPrefix string
+
+	// Git state of CWD reported at registration (nil when not a repository)
+	SourceControl *gastypes.SourceControl
 }
 
 // NewClientState creates a new client state for the given CWD.
@@ -117,6 +122,24 @@ func (cs *ClientState) GetActionCursor() BufferCursor {
 	return cs.EnhancedActionCursor
 }
 
+// SetSourceControl records the git state reported for this client's CWD.
+func (cs *ClientState) SetSourceControl(sc gastypes.SourceControl) {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	cs.SourceControl = &sc
+}
+
+// GetSourceControl returns a copy of the reported git state, or nil if none was reported.
+func (cs *ClientState) GetSourceControl() *gastypes.SourceControl {
+	cs.mu.RLock()
+	defer cs.mu.RUnlock()
+	if cs.SourceControl == nil {
+		return nil
+	}
+	sc := *cs.SourceControl
+	return &sc
+}
+
 // ============================================
 // Helper Functions
 // ============================================
@@ -0,0 +1,105 @@
+// Purpose: Remembers when each client working directory was first seen at each git commit.
+// Why: observe(what="changes", since_commit=...) needs a change-feed position for "after this commit was checked out".
+// Docs: docs/features/feature/source-control-context/index.md
+
+package session
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	gastypes "github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/types"
+)
+
+// maxCommitMarks bounds the timeline; the oldest marks are dropped first.
+const maxCommitMarks = 256
+
+// minCommitPrefix is the shortest commit prefix Resolve accepts.
+const minCommitPrefix = 4
+
+// CommitMark records the first time a working directory was seen at a commit.
+type CommitMark struct {
+	CWD       string    `json:"-"`
+	Commit    string    `json:"commit"`
+	Branch    string    `json:"branch,omitempty"`
+	FirstSeen time.Time `json:"first_seen"`
+	// Seq is the change-feed sequence at FirstSeen; later deltas happened on this commit or after.
+	Seq int64 `json:"seq"`
+}
+
+// CommitTimeline is an append-only, bounded list of commit transitions per working directory.
+type CommitTimeline struct {
+	mu    sync.Mutex
+	marks []CommitMark
+}
+
+// NewCommitTimeline creates an empty timeline.
+func NewCommitTimeline() *CommitTimeline {
+	return &CommitTimeline{}
+}
+
+// Observe records sc for cwd when it differs from the last commit seen there.
+// Returns true when a new mark was added.
+func (t *CommitTimeline) Observe(cwd string, sc gastypes.SourceControl, seq int64, now time.Time) bool {
+	if sc.Commit == "" {
+		return false
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for i := len(t.marks) - 1; i >= 0; i-- {
+		if t.marks[i].CWD == cwd {
+			if t.marks[i].Commit == sc.Commit {
+				return false
+			}
+			break
+		}
+	}
+	t.marks = append(t.marks, CommitMark{CWD: cwd, Commit: sc.Commit, Branch: sc.Branch, FirstSeen: now.UTC(), Seq: seq})
+	if over := len(t.marks) - maxCommitMarks; over > 0 {
+		t.marks = append([]CommitMark(nil), t.marks[over:]...)
+	}
+	return true
+}
+
+// Marks returns the marks for cwd (every directory when cwd is ""), oldest first.
+func (t *CommitTimeline) Marks(cwd string) []CommitMark {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	out := make([]CommitMark, 0, len(t.marks))
+	for _, m := range t.marks {
+		if cwd == "" || m.CWD == cwd {
+			out = append(out, m)
+		}
+	}
+	return out
+}
+
+// Resolve finds the earliest mark whose commit starts with prefix, within cwd when set.
+//
+// Failure semantics:
+// - found is false when no tracked commit matches.
+// - A prefix shorter than minCommitPrefix, or one matching two different commits, is an error.
+func (t *CommitTimeline) Resolve(cwd, prefix string) (mark CommitMark, found bool, err error) {
+	prefix = strings.ToLower(strings.TrimSpace(prefix))
+	if len(prefix) < minCommitPrefix {
+		return CommitMark{}, false, fmt.Errorf("commit %q is too short; use at least %d characters", prefix, minCommitPrefix)
+	}
+	for _, m := range t.Marks(cwd) {
+		if !strings.HasPrefix(m.Commit, prefix) {
+			continue
+		}
+		if found && m.Commit != mark.Commit {
+			return CommitMark{}, false, fmt.Errorf("commit prefix %q is ambiguous: %s and %s", prefix, shortCommit(mark.Commit), shortCommit(m.Commit))
+		}
+		if !found {
+			mark, found = m, true
+		}
+	}
+	return mark, found, nil
+}
+
+func shortCommit(commit string) string {
+	return gastypes.SourceControl{Commit: commit}.ShortCommit()
+}
@@ -0,0 +1,69 @@
+// Purpose: Tests commit transitions, prefix resolution, and bounding of the commit timeline.
+// Docs: docs/features/feature/source-control-context/index.md
+
+package session
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	gastypes "github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/types"
+)
+
+func TestCommitTimeline_RecordsTransitionsPerDirectory(t *testing.T) {
+	t.Parallel()
+	tl := NewCommitTimeline()
+	now := time.Now()
+	main := gastypes.SourceControl{Branch: "main", Commit: "abc1230000000000000000000000000000000000"}
+	feat := gastypes.SourceControl{Branch: "feat", Commit: "def4560000000000000000000000000000000000"}
+
+	if !tl.Observe("/app", main, 0, now) {
+		t.Fatal("first commit should add a mark")
+	}
+	if tl.Observe("/app", main, 7, now) {
+		t.Fatal("same commit should not add a mark")
+	}
+	if !tl.Observe("/other", main, 3, now) {
+		t.Fatal("another directory tracks its own marks")
+	}
+	if !tl.Observe("/app", feat, 9, now) {
+		t.Fatal("new commit should add a mark")
+	}
+	if tl.Observe("/app", gastypes.SourceControl{}, 10, now) {
+		t.Fatal("missing commit should be ignored")
+	}
+
+	if marks := tl.Marks("/app"); len(marks) != 2 || marks[1].Branch != "feat" || marks[1].Seq != 9 {
+		t.Fatalf("marks = %+v", marks)
+	}
+	mark, found, err := tl.Resolve("/app", "DEF456")
+	if err != nil || !found || mark.Seq != 9 {
+		t.Fatalf("Resolve = %+v, %v, %v", mark, found, err)
+	}
+	if _, found, _ := tl.Resolve("/app", "0123"); found {
+		t.Fatal("unknown commit should not resolve")
+	}
+	if _, _, err := tl.Resolve("/app", "ab"); err == nil {
+		t.Fatal("short prefix should fail")
+	}
+}
+
+func TestCommitTimeline_AmbiguousPrefixAndBound(t *testing.T) {
+	t.Parallel()
+	tl := NewCommitTimeline()
+	now := time.Now()
+	tl.Observe("/app", gastypes.SourceControl{Commit: "aaaa1111"}, 1, now)
+	tl.Observe("/app", gastypes.SourceControl{Commit: "aaaa2222"}, 2, now)
+	if _, _, err := tl.Resolve("/app", "aaaa"); err == nil || !strings.Contains(err.Error(), "ambiguous") {
+		t.Fatalf("err = %v, want ambiguous", err)
+	}
+
+	for i := 0; i < maxCommitMarks+10; i++ {
+		tl.Observe("/app", gastypes.SourceControl{Commit: fmt.Sprintf("bbbb%08d", i)}, int64(i), now)
+	}
+	if got := len(tl.Marks("")); got != maxCommitMarks {
+		t.Fatalf("marks = %d, want %d", got, maxCommitMarks)
+	}
+}
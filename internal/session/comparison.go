@@ -7,10 +7,18 @@ package session
 
 import (
 	"fmt"
+
+	gastypes "github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/types"
 )
 
 // Compare diffs two snapshots. Use "current" as b to compare against live state.
 func (sm *SessionManager) Compare(a, b string) (*SessionDiffResult, error) {
+	return sm.CompareWithSourceControl(a, b, nil)
+}
+
+// CompareWithSourceControl compares two snapshots; current is the caller's git state,
+// used when b is the live "current" state.
+func (sm *SessionManager) CompareWithSourceControl(a, b string, current *gastypes.SourceControl) (*SessionDiffResult, error) {
 	sm.mu.RLock()
 	snapA, existsA := sm.snaps[a]
 	sm.mu.RUnlock()
@@ -23,6 +31,7 @@ func (sm *SessionManager) Compare(a, b string) (*SessionDiffResult, error) {
 	if b == reservedSnapshotName {
 		// Compare against current live state
 		snapB = sm.captureCurrentState("current", snapA.URLFilter)
+		snapB.SourceControl = current
 	} else {
 		sm.mu.RLock()
 		found, exists := sm.snaps[b]
@@ -49,6 +58,7 @@ func (sm *SessionManager) Compare(a, b string) (*SessionDiffResult, error) {
 
 	// Compute summary and verdict
 	result.Summary = sm.computeSummary(result)
+	result.SourceControl = diffSourceControl(snapA.SourceControl, snapB.SourceControl, result.Summary)
 
 	return result, nil
 }
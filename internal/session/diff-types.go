@@ -5,6 +5,8 @@
 // SessionDiffResult, ErrorDiff, SessionNetworkDiff, PerformanceDiff, etc.
 package session
 
+import gastypes "github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/types"
+
 // SessionDiffResult is the full comparison result between two snapshots.
 type SessionDiffResult struct {
 	A           string             `json:"a"`
@@ -13,6 +15,17 @@ type SessionDiffResult struct {
 	Network     SessionNetworkDiff `json:"network"`
 	Performance PerformanceDiff    `json:"performance"`
 	Summary     DiffSummary        `json:"summary"`
+
+	SourceControl *SourceControlDiff `json:"source_control,omitempty"`
+}
+
+// SourceControlDiff relates both snapshots to the commits they were captured against.
+type SourceControlDiff struct {
+	A             *gastypes.SourceControl `json:"a,omitempty"`
+	B             *gastypes.SourceControl `json:"b,omitempty"`
+	CommitChanged bool                    `json:"commit_changed"`
+	Commits       string                  `json:"commits,omitempty"`
+	Note          string                  `json:"note,omitempty"`
 }
 
 // ErrorDiff holds the error comparison between two snapshots.
@@ -0,0 +1,358 @@
+// Purpose: Compares repeated runs of one test boundary step by step, classifies how they differ, and scores flake probability per step.
+// Why: Re-running a flow in a verification loop only helps if a run that diverged can be told apart from one that was merely slower or reordered its requests.
+// Docs: docs/features/feature/flakiness-report/index.md
+
+package session
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+)
+
+// Difference classes, from least to most severe.
+const (
+	FlakeStable       = "stable"
+	FlakeTimingOnly   = "timing_only"
+	FlakeNetworkOrder = "network_order"
+	FlakeDivergent    = "divergent"
+)
+
+const (
+	// A step's duration counts as a timing difference when it is this far from the
+	// median across runs, both in absolute terms and relative to the median.
+	flakeTimingToleranceMs    = 250
+	flakeTimingToleranceRatio = 0.5
+
+	maxFlakeDifferences = 5 // listed per run and per step
+	flakeRunStartLabel  = "(run start)"
+)
+
+var flakeRank = map[string]int{FlakeStable: 0, FlakeTimingOnly: 1, FlakeNetworkOrder: 2, FlakeDivergent: 3}
+
+// flakeWeights is how much one deviating run adds to a step's flake probability:
+// a divergence counts fully, reordered requests half, and timing noise a quarter.
+var flakeWeights = map[string]float64{FlakeTimingOnly: 0.25, FlakeNetworkOrder: 0.5, FlakeDivergent: 1}
+
+// FlakeRun is what one completed run of a test boundary captured.
+type FlakeRun struct {
+	StartedAt time.Time
+	EndedAt   time.Time
+	Actions   []FlakeAction
+	Requests  []FlakeRequest
+	Errors    []FlakeError
+}
+
+// FlakeAction is a user action; each action starts a new step.
+type FlakeAction struct {
+	At    time.Time
+	Label string // e.g. "click #pay"
+}
+
+// FlakeRequest is a completed network request.
+type FlakeRequest struct {
+	At     time.Time
+	Method string
+	URL    string
+	Status int
+}
+
+// FlakeError is a console error.
+type FlakeError struct {
+	At      time.Time
+	Message string
+}
+
+// FlakinessReport is the result of comparing runs of one boundary.
+type FlakinessReport struct {
+	Runs           int               `json:"runs"`
+	Classification string            `json:"classification"`
+	FlakeScore     float64           `json:"flake_score"`
+	RunSummaries   []FlakeRunSummary `json:"run_summaries"`
+	Steps          []FlakeStepReport `json:"steps"`
+}
+
+// FlakeRunSummary describes how one run differed from the others.
+type FlakeRunSummary struct {
+	Run            int      `json:"run"`
+	StartedAt      string   `json:"started_at"`
+	DurationMs     int64    `json:"duration_ms"`
+	Actions        int      `json:"actions"`
+	Requests       int      `json:"requests"`
+	Errors         int      `json:"errors"`
+	Classification string   `json:"classification"`
+	Differences    []string `json:"differences,omitempty"`
+}
+
+// FlakeStepReport scores one step across all runs.
+type FlakeStepReport struct {
+	Step             int      `json:"step"`
+	Action           string   `json:"action"`
+	FlakeProbability float64  `json:"flake_probability"`
+	Classification   string   `json:"classification"`
+	DeviatingRuns    []int    `json:"deviating_runs,omitempty"`
+	MedianMs         int64    `json:"median_ms"`
+	SpreadMs         int64    `json:"spread_ms"`
+	Differences      []string `json:"differences,omitempty"`
+}
+
+// flakeStep is one run's activity from an action (or the run start) until the next action.
+type flakeStep struct {
+	action     string
+	requests   []string // "METHOD /path -> status", in arrival order
+	errors     []string // normalized, sorted
+	durationMs int64
+}
+
+// signature identifies what the step did, ignoring request order and timing.
+func (s flakeStep) signature() string {
+	reqs := append([]string(nil), s.requests...)
+	sort.Strings(reqs)
+	return s.action + "\x00" + strings.Join(reqs, "\x01") + "\x00" + strings.Join(s.errors, "\x01")
+}
+
+// AnalyzeFlakiness compares runs step by step against the most common behavior at each
+// step. Steps are aligned by action index: step 0 is everything before the first action.
+func AnalyzeFlakiness(runs []FlakeRun) FlakinessReport {
+	report := FlakinessReport{
+		Runs:           len(runs),
+		Classification: FlakeStable,
+		RunSummaries:   make([]FlakeRunSummary, len(runs)),
+		Steps:          []FlakeStepReport{},
+	}
+	steps := make([][]flakeStep, len(runs))
+	maxSteps := 0
+	for i, run := range runs {
+		steps[i] = splitFlakeRun(run)
+		maxSteps = max(maxSteps, len(steps[i]))
+		report.RunSummaries[i] = FlakeRunSummary{
+			Run:            i + 1,
+			StartedAt:      run.StartedAt.UTC().Format(time.RFC3339Nano),
+			DurationMs:     run.EndedAt.Sub(run.StartedAt).Milliseconds(),
+			Actions:        len(run.Actions),
+			Requests:       len(run.Requests),
+			Errors:         len(run.Errors),
+			Classification: FlakeStable,
+		}
+	}
+	if len(runs) < 2 {
+		return report
+	}
+
+	for i := 0; i < maxSteps; i++ {
+		step := analyzeFlakeStep(steps, i, report.RunSummaries)
+		report.Steps = append(report.Steps, step)
+		report.FlakeScore = math.Max(report.FlakeScore, step.FlakeProbability)
+	}
+	for _, rs := range report.RunSummaries {
+		report.Classification = worseFlakeClass(report.Classification, rs.Classification)
+	}
+	return report
+}
+
+// analyzeFlakeStep scores step i and records each run's difference on its summary.
+func analyzeFlakeStep(steps [][]flakeStep, i int, summaries []FlakeRunSummary) FlakeStepReport {
+	modal := modalFlakeStep(steps, i)
+	var durations []int64
+	for _, run := range steps {
+		if i < len(run) {
+			durations = append(durations, run[i].durationMs)
+		}
+	}
+	median, spread := medianSpread(durations)
+	report := FlakeStepReport{
+		Step:           i,
+		Action:         modal.action,
+		Classification: FlakeStable,
+		MedianMs:       median,
+		SpreadMs:       spread,
+	}
+
+	weight := 0.0
+	for r, run := range steps {
+		class, diff := classifyFlakeStep(run, i, modal, median)
+		if class == FlakeStable {
+			continue
+		}
+		weight += flakeWeights[class]
+		report.DeviatingRuns = append(report.DeviatingRuns, r+1)
+		report.Classification = worseFlakeClass(report.Classification, class)
+		if len(report.Differences) < maxFlakeDifferences {
+			report.Differences = append(report.Differences, fmt.Sprintf("run %d: %s", r+1, diff))
+		}
+		summaries[r].Classification = worseFlakeClass(summaries[r].Classification, class)
+		if len(summaries[r].Differences) < maxFlakeDifferences {
+			summaries[r].Differences = append(summaries[r].Differences, fmt.Sprintf("step %d (%s): %s", i, modal.action, diff))
+		}
+	}
+	report.FlakeProbability = math.Round(weight/float64(len(steps))*100) / 100
+	return report
+}
+
+// classifyFlakeStep compares one run's step i against the modal step.
+func classifyFlakeStep(run []flakeStep, i int, modal flakeStep, medianMs int64) (string, string) {
+	if i >= len(run) {
+		return FlakeDivergent, fmt.Sprintf("step missing; the run ended after %d steps", len(run))
+	}
+	s := run[i]
+	if s.signature() != modal.signature() {
+		return FlakeDivergent, describeFlakeDivergence(s, modal)
+	}
+	if strings.Join(s.requests, "\x01") != strings.Join(modal.requests, "\x01") {
+		return FlakeNetworkOrder, "requests completed in a different order: " + strings.Join(s.requests, ", ")
+	}
+	delta := s.durationMs - medianMs
+	if delta < 0 {
+		delta = -delta
+	}
+	if delta > flakeTimingToleranceMs && float64(delta) > flakeTimingToleranceRatio*float64(medianMs) {
+		return FlakeTimingOnly, fmt.Sprintf("took %dms vs median %dms", s.durationMs, medianMs)
+	}
+	return FlakeStable, ""
+}
+
+// describeFlakeDivergence lists what a step did differently from the modal step.
+func describeFlakeDivergence(s, modal flakeStep) string {
+	var parts []string
+	if s.action != modal.action {
+		parts = append(parts, fmt.Sprintf("action %q instead of %q", s.action, modal.action))
+	}
+	extra, missing := multisetDiff(s.requests, modal.requests)
+	for _, r := range extra {
+		parts = append(parts, "unexpected "+r)
+	}
+	for _, r := range missing {
+		parts = append(parts, "missing "+r)
+	}
+	newErrs, goneErrs := multisetDiff(s.errors, modal.errors)
+	for _, e := range newErrs {
+		parts = append(parts, "error: "+e)
+	}
+	for _, e := range goneErrs {
+		parts = append(parts, "no error: "+e)
+	}
+	return strings.Join(parts, "; ")
+}
+
+// modalFlakeStep returns the most common step i across runs; ties go to the earliest run.
+func modalFlakeStep(steps [][]flakeStep, i int) flakeStep {
+	counts := map[string]int{}
+	var best flakeStep
+	bestCount := 0
+	for _, run := range steps {
+		if i >= len(run) {
+			continue
+		}
+		sig := run[i].signature()
+		counts[sig]++
+		if counts[sig] > bestCount {
+			best, bestCount = run[i], counts[sig]
+		}
+	}
+	// Keep the first run with the winning signature so its request order is the reference.
+	for _, run := range steps {
+		if i < len(run) && run[i].signature() == best.signature() {
+			return run[i]
+		}
+	}
+	return best
+}
+
+// splitFlakeRun cuts a run into steps at each action and assigns requests and errors
+// to the step they fall in.
+func splitFlakeRun(run FlakeRun) []flakeStep {
+	actions := append([]FlakeAction(nil), run.Actions...)
+	sort.SliceStable(actions, func(i, j int) bool { return actions[i].At.Before(actions[j].At) })
+
+	starts := []time.Time{run.StartedAt}
+	steps := []flakeStep{{action: flakeRunStartLabel}}
+	for _, a := range actions {
+		// Browser clocks can trail the server's run start by a few milliseconds.
+		starts = append(starts, maxTime(a.At, run.StartedAt))
+		steps = append(steps, flakeStep{action: a.Label})
+	}
+	for i := range steps {
+		end := run.EndedAt
+		if i+1 < len(starts) {
+			end = starts[i+1]
+		}
+		steps[i].durationMs = max(end.Sub(starts[i]).Milliseconds(), 0)
+	}
+
+	stepAt := func(t time.Time) int {
+		i := sort.Search(len(starts), func(i int) bool { return starts[i].After(t) }) - 1
+		return max(i, 0)
+	}
+	requests := append([]FlakeRequest(nil), run.Requests...)
+	sort.SliceStable(requests, func(i, j int) bool { return requests[i].At.Before(requests[j].At) })
+	for _, r := range requests {
+		i := stepAt(r.At)
+		steps[i].requests = append(steps[i].requests, flakeRequestKey(r))
+	}
+	for _, e := range run.Errors {
+		i := stepAt(e.At)
+		steps[i].errors = append(steps[i].errors, normalizeVerifyErrorMessage(e.Message))
+	}
+	for i := range steps {
+		sort.Strings(steps[i].errors)
+	}
+	return steps
+}
+
+// flakeRequestKey identifies a request by method, path with IDs normalized, and status.
+func flakeRequestKey(r FlakeRequest) string {
+	return fmt.Sprintf("%s %s -> %d", r.Method, normalizeVerifyErrorMessage(capture.ExtractURLPath(r.URL)), r.Status)
+}
+
+// multisetDiff returns items in a but not b, and items in b but not a, counting duplicates.
+func multisetDiff(a, b []string) (onlyA, onlyB []string) {
+	counts := map[string]int{}
+	for _, s := range b {
+		counts[s]++
+	}
+	for _, s := range a {
+		if counts[s] > 0 {
+			counts[s]--
+			continue
+		}
+		onlyA = append(onlyA, s)
+	}
+	for _, s := range b {
+		if counts[s] > 0 {
+			counts[s]--
+			onlyB = append(onlyB, s)
+		}
+	}
+	return onlyA, onlyB
+}
+
+func medianSpread(values []int64) (median, spread int64) {
+	if len(values) == 0 {
+		return 0, 0
+	}
+	sorted := append([]int64(nil), values...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	median = sorted[len(sorted)/2]
+	if len(sorted)%2 == 0 {
+		median = (sorted[len(sorted)/2-1] + sorted[len(sorted)/2]) / 2
+	}
+	return median, sorted[len(sorted)-1] - sorted[0]
+}
+
+func maxTime(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
+
+func worseFlakeClass(a, b string) string {
+	if flakeRank[b] > flakeRank[a] {
+		return b
+	}
+	return a
+}
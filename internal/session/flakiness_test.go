@@ -0,0 +1,138 @@
+// Purpose: Tests step-by-step flakiness classification across repeated boundary runs.
+// Docs: docs/features/feature/flakiness-report/index.md
+
+package session
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+var flakeBase = time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+
+// checkoutRun builds one run of a two-step checkout: load the cart, then pay.
+// payStatus is the /api/pay status; reorder swaps the two cart requests; payDelay
+// stretches the time between the click and the end of the run.
+func checkoutRun(offset time.Duration, payStatus int, reorder bool, payDelay time.Duration) FlakeRun {
+	start := flakeBase.Add(offset)
+	at := func(ms int) time.Time { return start.Add(time.Duration(ms) * time.Millisecond) }
+	cart := []FlakeRequest{
+		{At: at(150), Method: "GET", URL: "https://shop.test/api/cart/42", Status: 200},
+		{At: at(180), Method: "GET", URL: "https://shop.test/api/prices", Status: 200},
+	}
+	if reorder {
+		cart[0].At, cart[1].At = cart[1].At, cart[0].At
+	}
+	run := FlakeRun{
+		StartedAt: start,
+		EndedAt:   at(1000).Add(payDelay),
+		Actions: []FlakeAction{
+			{At: at(100), Label: "navigate /cart"},
+			{At: at(500), Label: "click #pay"},
+		},
+		Requests: append(cart, FlakeRequest{At: at(600), Method: "POST", URL: "https://shop.test/api/pay", Status: payStatus}),
+	}
+	if payStatus >= 400 {
+		run.Errors = []FlakeError{{At: at(650), Message: "Payment failed for order 42"}}
+	}
+	return run
+}
+
+func TestAnalyzeFlakiness_StableRuns(t *testing.T) {
+	t.Parallel()
+	report := AnalyzeFlakiness([]FlakeRun{
+		checkoutRun(0, 200, false, 0),
+		checkoutRun(time.Minute, 200, false, 0),
+		checkoutRun(2*time.Minute, 200, false, 0),
+	})
+	if report.Classification != FlakeStable || report.FlakeScore != 0 {
+		t.Fatalf("classification=%s score=%v, want stable/0", report.Classification, report.FlakeScore)
+	}
+	if len(report.Steps) != 3 || report.Steps[2].Action != "click #pay" {
+		t.Fatalf("steps = %+v, want run start + 2 actions", report.Steps)
+	}
+}
+
+func TestAnalyzeFlakiness_TimingOnly(t *testing.T) {
+	t.Parallel()
+	report := AnalyzeFlakiness([]FlakeRun{
+		checkoutRun(0, 200, false, 0),
+		checkoutRun(time.Minute, 200, false, 0),
+		checkoutRun(2*time.Minute, 200, false, 3*time.Second),
+	})
+	if report.Classification != FlakeTimingOnly {
+		t.Fatalf("classification = %s, want timing_only", report.Classification)
+	}
+	pay := report.Steps[2]
+	if pay.Classification != FlakeTimingOnly || pay.FlakeProbability != 0.08 || len(pay.DeviatingRuns) != 1 || pay.DeviatingRuns[0] != 3 {
+		t.Fatalf("pay step = %+v, want timing_only, probability 0.08 for run 3", pay)
+	}
+	if pay.SpreadMs != 3000 {
+		t.Fatalf("spread_ms = %d, want 3000", pay.SpreadMs)
+	}
+}
+
+func TestAnalyzeFlakiness_NetworkOrder(t *testing.T) {
+	t.Parallel()
+	report := AnalyzeFlakiness([]FlakeRun{
+		checkoutRun(0, 200, false, 0),
+		checkoutRun(time.Minute, 200, true, 0),
+		checkoutRun(2*time.Minute, 200, false, 0),
+	})
+	if report.Classification != FlakeNetworkOrder {
+		t.Fatalf("classification = %s, want network_order", report.Classification)
+	}
+	if cart := report.Steps[1]; cart.Classification != FlakeNetworkOrder || cart.FlakeProbability != 0.17 {
+		t.Fatalf("cart step = %+v, want network_order with probability 0.17", cart)
+	}
+	if report.RunSummaries[1].Classification != FlakeNetworkOrder || report.RunSummaries[0].Classification != FlakeStable {
+		t.Fatalf("run summaries = %+v", report.RunSummaries)
+	}
+}
+
+func TestAnalyzeFlakiness_GenuineDivergence(t *testing.T) {
+	t.Parallel()
+	report := AnalyzeFlakiness([]FlakeRun{
+		checkoutRun(0, 200, false, 0),
+		checkoutRun(time.Minute, 502, false, 0),
+		checkoutRun(2*time.Minute, 200, false, 0),
+		checkoutRun(3*time.Minute, 200, false, 0),
+	})
+	if report.Classification != FlakeDivergent || report.FlakeScore != 0.25 {
+		t.Fatalf("classification=%s score=%v, want divergent/0.25", report.Classification, report.FlakeScore)
+	}
+	pay := report.Steps[2]
+	if pay.Classification != FlakeDivergent || len(pay.DeviatingRuns) != 1 || pay.DeviatingRuns[0] != 2 {
+		t.Fatalf("pay step = %+v, want divergent in run 2", pay)
+	}
+	diff := strings.Join(pay.Differences, "\n")
+	for _, want := range []string{"unexpected POST /api/pay -> 502", "missing POST /api/pay -> 200", "error: Payment failed for order [id]"} {
+		if !strings.Contains(diff, want) {
+			t.Errorf("differences %q missing %q", diff, want)
+		}
+	}
+	if report.Steps[1].Classification != FlakeStable {
+		t.Fatalf("cart step should be stable, got %+v", report.Steps[1])
+	}
+}
+
+func TestAnalyzeFlakiness_MissingStep(t *testing.T) {
+	t.Parallel()
+	short := checkoutRun(time.Minute, 200, false, 0)
+	short.Actions = short.Actions[:1]
+	short.Requests = short.Requests[:2]
+	report := AnalyzeFlakiness([]FlakeRun{checkoutRun(0, 200, false, 0), short})
+	pay := report.Steps[2]
+	if pay.Classification != FlakeDivergent || !strings.Contains(pay.Differences[0], "step missing") {
+		t.Fatalf("pay step = %+v, want divergent with step missing", pay)
+	}
+}
+
+func TestAnalyzeFlakiness_SingleRun(t *testing.T) {
+	t.Parallel()
+	report := AnalyzeFlakiness([]FlakeRun{checkoutRun(0, 200, false, 0)})
+	if report.Runs != 1 || len(report.Steps) != 0 || report.Classification != FlakeStable {
+		t.Fatalf("single run report = %+v, want no step comparison", report)
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/performance"
+	gastypes "github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/types"
 )
 
 // SessionManager manages named session snapshots.
@@ -38,11 +39,18 @@ func NewSessionManager(maxSnapshots int, reader CaptureStateReader) *SessionMana
 
 // Capture stores the current state as a named snapshot.
 func (sm *SessionManager) Capture(name, urlFilter string) (*NamedSnapshot, error) {
+	return sm.CaptureWithSourceControl(name, urlFilter, nil)
+}
+
+// CaptureWithSourceControl stores the current state as a named snapshot stamped with
+// the calling client's git state (nil when the client reported none).
+func (sm *SessionManager) CaptureWithSourceControl(name, urlFilter string, sc *gastypes.SourceControl) (*NamedSnapshot, error) {
 	if err := sm.validateName(name); err != nil {
 		return nil, err
 	}
 
 	snapshot := sm.captureCurrentState(name, urlFilter)
+	snapshot.SourceControl = sc
 
 	sm.mu.Lock()
 	defer sm.mu.Unlock()
@@ -131,6 +139,7 @@ func (sm *SessionManager) List() []SnapshotListEntry {
 			CapturedAt: snap.CapturedAt,
 			PageURL:    snap.PageURL,
 			ErrorCount: len(snap.ConsoleErrors),
+			Commit:     snapshotCommit(snap),
 		})
 	}
 	return entries
@@ -0,0 +1,49 @@
+// Purpose: Relates snapshot diffs to the git commits each snapshot was captured against.
+// Why: "This regression appeared after commit X" needs both commits next to the diff verdict.
+// Docs: docs/features/feature/source-control-context/index.md
+
+package session
+
+import (
+	"fmt"
+
+	gastypes "github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/types"
+)
+
+// snapshotCommit returns the short commit a snapshot was captured against, or "".
+func snapshotCommit(snap *NamedSnapshot) string {
+	if snap.SourceControl == nil {
+		return ""
+	}
+	return snap.SourceControl.ShortCommit()
+}
+
+// diffSourceControl returns nil when neither snapshot carries git state.
+func diffSourceControl(a, b *gastypes.SourceControl, summary DiffSummary) *SourceControlDiff {
+	if a == nil && b == nil {
+		return nil
+	}
+	d := &SourceControlDiff{A: a, B: b, Commits: gastypes.DescribeCommits(a, b)}
+	if a == nil || b == nil {
+		d.Note = "Only one snapshot has git state; commits cannot be compared."
+		return d
+	}
+	d.CommitChanged = a.Commit != b.Commit
+	switch {
+	case summary.NewErrors > 0 && d.CommitChanged:
+		d.Note = fmt.Sprintf("%d new error(s) appeared after moving from commit %s to %s.", summary.NewErrors, a.ShortCommit(), describeCommit(b))
+	case summary.NewErrors > 0 && b.Dirty:
+		d.Note = fmt.Sprintf("%d new error(s) appeared on commit %s with uncommitted changes.", summary.NewErrors, b.ShortCommit())
+	case d.CommitChanged:
+		d.Note = fmt.Sprintf("Snapshots span commits %s and %s.", a.ShortCommit(), describeCommit(b))
+	}
+	return d
+}
+
+func describeCommit(sc *gastypes.SourceControl) string {
+	desc := sc.ShortCommit()
+	if sc.Dirty {
+		desc += " (dirty)"
+	}
+	return desc
+}
@@ -0,0 +1,73 @@
+// Purpose: Tests git-state stamping of session snapshots and commit notes on compare.
+// Docs: docs/features/feature/source-control-context/index.md
+
+package session
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	gastypes "github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/types"
+)
+
+func TestSessionManager_SourceControlStampedAndCompared(t *testing.T) {
+	t.Parallel()
+	mock := &mockCaptureState{pageURL: "http://localhost:3000"}
+	sm := NewSessionManager(10, mock)
+
+	before := &gastypes.SourceControl{Branch: "main", Commit: "1111111111111111aaaa", CapturedAt: time.Now()}
+	if _, err := sm.HandleToolWithSourceControl(json.RawMessage(`{"action":"capture","name":"before"}`), before); err != nil {
+		t.Fatal(err)
+	}
+	if got := sm.List()[0].Commit; got != "111111111111" {
+		t.Errorf("list commit = %q", got)
+	}
+
+	mock.consoleErrors = []SnapshotError{{Type: "error", Message: "TypeError: x is undefined", Count: 1}}
+	after := &gastypes.SourceControl{Branch: "main", Commit: "2222222222222222bbbb", Dirty: true, CapturedAt: time.Now()}
+	diff, err := sm.CompareWithSourceControl("before", "current", after)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sc := diff.SourceControl
+	if sc == nil || !sc.CommitChanged || sc.A.Commit != before.Commit || sc.B.Commit != after.Commit {
+		t.Fatalf("source_control = %+v", sc)
+	}
+	if !strings.Contains(sc.Note, "from commit 111111111111 to 222222222222 (dirty)") {
+		t.Errorf("note = %q", sc.Note)
+	}
+	if sc.Commits != "baseline at commit 111111111111 (main) vs current at 222222222222 (main, dirty)" {
+		t.Errorf("commits = %q", sc.Commits)
+	}
+
+	// Snapshots without git state keep the diff free of source_control.
+	if _, err := sm.Capture("plain", ""); err != nil {
+		t.Fatal(err)
+	}
+	if diff, _ := sm.Compare("plain", "current"); diff.SourceControl != nil {
+		t.Errorf("unexpected source_control: %+v", diff.SourceControl)
+	}
+}
+
+func TestClientRegistry_SourceControl(t *testing.T) {
+	t.Parallel()
+	r := NewClientRegistry()
+	cs := r.Register("/work/app")
+	if r.SourceControl(cs.ID) != nil {
+		t.Fatal("new client should have no source control")
+	}
+	if r.SetSourceControl("missing", gastypes.SourceControl{Commit: "abc"}) {
+		t.Error("SetSourceControl on unknown client should return false")
+	}
+	if !r.SetSourceControl(cs.ID, gastypes.SourceControl{Branch: "feat", Commit: "abc"}) {
+		t.Fatal("SetSourceControl failed")
+	}
+	if sc := r.SourceControl(cs.ID); sc == nil || sc.Branch != "feat" {
+		t.Errorf("SourceControl = %+v", sc)
+	}
+	if info := r.List()[0]; info.SourceControl == nil || info.SourceControl.Commit != "abc" {
+		t.Errorf("List source_control = %+v", info.SourceControl)
+	}
+}
@@ -8,6 +8,8 @@ package session
 import (
 	"encoding/json"
 	"fmt"
+
+	gastypes "github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/types"
 )
 
 // diffSessionsParams defines the MCP tool input schema.
@@ -20,33 +22,37 @@ type diffSessionsParams struct {
 }
 
 // handleCapture handles the "capture" action for diff_sessions.
-func (sm *SessionManager) handleCapture(p diffSessionsParams) (any, error) {
+func (sm *SessionManager) handleCapture(p diffSessionsParams, sc *gastypes.SourceControl) (any, error) {
 	if p.Name == "" {
 		return nil, fmt.Errorf("'name' is required for capture action")
 	}
-	snap, err := sm.Capture(p.Name, p.URLFilter)
+	snap, err := sm.CaptureWithSourceControl(p.Name, p.URLFilter, sc)
 	if err != nil {
 		return nil, err
 	}
+	summary := map[string]any{
+		"captured_at":      snap.CapturedAt,
+		"console_errors":   len(snap.ConsoleErrors),
+		"console_warnings": len(snap.ConsoleWarnings),
+		"network_requests": len(snap.NetworkRequests),
+		"page_url":         snap.PageURL,
+	}
+	if snap.SourceControl != nil {
+		summary["source_control"] = snap.SourceControl
+	}
 	return map[string]any{
-		"action": "captured",
-		"name":   snap.Name,
-		"snapshot": map[string]any{
-			"captured_at":      snap.CapturedAt,
-			"console_errors":   len(snap.ConsoleErrors),
-			"console_warnings": len(snap.ConsoleWarnings),
-			"network_requests": len(snap.NetworkRequests),
-			"page_url":         snap.PageURL,
-		},
+		"action":   "captured",
+		"name":     snap.Name,
+		"snapshot": summary,
 	}, nil
 }
 
 // handleCompare handles the "compare" action for diff_sessions.
-func (sm *SessionManager) handleCompare(p diffSessionsParams) (any, error) {
+func (sm *SessionManager) handleCompare(p diffSessionsParams, sc *gastypes.SourceControl) (any, error) {
 	if p.CompareA == "" || p.CompareB == "" {
 		return nil, fmt.Errorf("'compare_a' and 'compare_b' are required for compare action")
 	}
-	diff, err := sm.Compare(p.CompareA, p.CompareB)
+	diff, err := sm.CompareWithSourceControl(p.CompareA, p.CompareB, sc)
 	if err != nil {
 		return nil, err
 	}
@@ -69,6 +75,12 @@ func (sm *SessionManager) handleDelete(p diffSessionsParams) (any, error) {
 
 // HandleTool dispatches the diff_sessions MCP tool call.
 func (sm *SessionManager) HandleTool(params json.RawMessage) (any, error) {
+	return sm.HandleToolWithSourceControl(params, nil)
+}
+
+// HandleToolWithSourceControl dispatches a diff_sessions call on behalf of a client whose
+// git state (nil if unknown) stamps captured snapshots and the live side of compares.
+func (sm *SessionManager) HandleToolWithSourceControl(params json.RawMessage, sc *gastypes.SourceControl) (any, error) {
 	var p diffSessionsParams
 	if err := json.Unmarshal(params, &p); err != nil {
 		return nil, fmt.Errorf("invalid params: %w", err)
@@ -76,9 +88,9 @@ func (sm *SessionManager) HandleTool(params json.RawMessage) (any, error) {
 
 	switch p.Action {
 	case "capture":
-		return sm.handleCapture(p)
+		return sm.handleCapture(p, sc)
 	case "compare":
-		return sm.handleCompare(p)
+		return sm.handleCompare(p, sc)
 	case "list":
 		return map[string]any{"action": "listed", "snapshots": sm.List()}, nil
 	case "delete":
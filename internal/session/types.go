@@ -36,4 +36,5 @@ type SnapshotListEntry struct {
 	CapturedAt time.Time `json:"captured_at"`
 	PageURL    string    `json:"page_url"`
 	ErrorCount int       `json:"error_count"`
+	Commit     string    `json:"commit,omitempty"`
 }
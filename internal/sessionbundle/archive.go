@@ -0,0 +1,285 @@
+// Purpose: Rolls capture buffers into a directory of timestamped, gzip-compressed bundles with count and age retention.
+// Why: Clearing buffers or stopping the daemon used to discard the session; the archive keeps it searchable.
+// Docs: docs/features/feature/session-archive/index.md
+
+package sessionbundle
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/state"
+)
+
+// Reasons recorded on archived sessions.
+const (
+	ReasonClear    = "clear"
+	ReasonShutdown = "shutdown"
+)
+
+// Archive retention defaults and bounds.
+const (
+	DefaultArchiveMaxSessions = 20
+	DefaultArchiveMaxAge      = 7 * 24 * time.Hour
+	MaxArchiveSessions        = 500
+	MinArchiveMaxAge          = time.Hour
+)
+
+// archiveIndexFile lists the archived sessions and the retention settings.
+const archiveIndexFile = "index.json"
+
+// ArchiveSettings controls whether sessions are archived and how long they are kept.
+// MaxAge 0 keeps sessions until MaxSessions evicts them.
+type ArchiveSettings struct {
+	Enabled     bool
+	MaxSessions int
+	MaxAge      time.Duration
+}
+
+// DefaultArchiveSettings archives every session and keeps the last 20 for a week.
+func DefaultArchiveSettings() ArchiveSettings {
+	return ArchiveSettings{Enabled: true, MaxSessions: DefaultArchiveMaxSessions, MaxAge: DefaultArchiveMaxAge}
+}
+
+// Validate reports settings outside the supported bounds.
+func (s ArchiveSettings) Validate() error {
+	if s.MaxSessions < 1 || s.MaxSessions > MaxArchiveSessions {
+		return fmt.Errorf("max_sessions must be between 1 and %d", MaxArchiveSessions)
+	}
+	if s.MaxAge != 0 && s.MaxAge < MinArchiveMaxAge {
+		return fmt.Errorf("max_age must be 0 or at least %s", MinArchiveMaxAge)
+	}
+	return nil
+}
+
+// ArchivedSession describes one archived bundle.
+type ArchivedSession struct {
+	ID        string         `json:"id"`
+	File      string         `json:"file"`
+	CreatedAt time.Time      `json:"created_at"`
+	Reason    string         `json:"reason"`
+	PageURL   string         `json:"page_url,omitempty"`
+	Counts    map[string]int `json:"counts"`
+	Bytes     int64          `json:"bytes"`
+}
+
+// archiveIndex is the on-disk form of index.json.
+type archiveIndex struct {
+	Enabled     bool              `json:"enabled"`
+	MaxSessions int               `json:"max_sessions"`
+	MaxAge      string            `json:"max_age"`
+	Sessions    []ArchivedSession `json:"sessions"` // oldest first
+}
+
+// Archive is a directory of archived session bundles. The index is re-read on every
+// call so a restarted daemon sees the sessions archived by the previous one.
+type Archive struct {
+	mu  sync.Mutex
+	dir string
+}
+
+// NewArchive returns an archive rooted at dir. The directory is created on first write.
+func NewArchive(dir string) *Archive {
+	return &Archive{dir: dir}
+}
+
+// DefaultArchiveDir returns the directory sessions are archived to.
+func DefaultArchiveDir() (string, error) {
+	return state.InRoot("archive")
+}
+
+// Dir returns the archive directory.
+func (a *Archive) Dir() string {
+	return a.dir
+}
+
+// Settings returns the persisted retention settings, or the defaults.
+func (a *Archive) Settings() (ArchiveSettings, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	idx, err := a.readIndex()
+	if err != nil {
+		return DefaultArchiveSettings(), err
+	}
+	return idx.settings(), nil
+}
+
+// SetSettings validates and persists the settings, then prunes sessions they no longer keep.
+// Returns the number of sessions removed.
+func (a *Archive) SetSettings(s ArchiveSettings, now time.Time) (int, error) {
+	if err := s.Validate(); err != nil {
+		return 0, err
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	idx, err := a.readIndex()
+	if err != nil {
+		return 0, err
+	}
+	idx.Enabled, idx.MaxSessions, idx.MaxAge = s.Enabled, s.MaxSessions, s.MaxAge.String()
+	pruned := a.prune(&idx, now)
+	return pruned, a.writeIndex(idx)
+}
+
+// Roll writes b as a new archived session and applies retention. It returns false
+// without writing when archiving is disabled or every buffer is empty.
+func (a *Archive) Roll(b Bundle, reason string) (ArchivedSession, bool, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	idx, err := a.readIndex()
+	if err != nil {
+		return ArchivedSession{}, false, err
+	}
+	if !idx.Enabled || b.empty() {
+		return ArchivedSession{}, false, nil
+	}
+
+	id := "session-" + b.CreatedAt.UTC().Format("20060102-150405") + "-" + reason
+	for n := 2; idx.find(id) >= 0; n++ {
+		id = fmt.Sprintf("session-%s-%s-%d", b.CreatedAt.UTC().Format("20060102-150405"), reason, n)
+	}
+	file := id + ".json.gz"
+	_, size, err := Write(filepath.Join(a.dir, file), b)
+	if err != nil {
+		return ArchivedSession{}, false, err
+	}
+	session := ArchivedSession{
+		ID:        id,
+		File:      file,
+		CreatedAt: b.CreatedAt,
+		Reason:    reason,
+		PageURL:   b.PageURL,
+		Counts:    b.Counts(),
+		Bytes:     size,
+	}
+	idx.Sessions = append(idx.Sessions, session)
+	a.prune(&idx, b.CreatedAt)
+	return session, true, a.writeIndex(idx)
+}
+
+// List returns the archived sessions, newest first.
+func (a *Archive) List() ([]ArchivedSession, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	idx, err := a.readIndex()
+	if err != nil {
+		return nil, err
+	}
+	sessions := slices.Clone(idx.Sessions)
+	slices.Reverse(sessions)
+	return sessions, nil
+}
+
+// Path returns the bundle file of an archived session, usable with Load and --serve-bundle.
+func (a *Archive) Path(s ArchivedSession) string {
+	return filepath.Join(a.dir, s.File)
+}
+
+// Clear deletes every archived session and keeps the settings. Returns how many were removed.
+func (a *Archive) Clear() (int, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	idx, err := a.readIndex()
+	if err != nil {
+		return 0, err
+	}
+	n := len(idx.Sessions)
+	for _, s := range idx.Sessions {
+		a.remove(s)
+	}
+	idx.Sessions = nil
+	return n, a.writeIndex(idx)
+}
+
+// prune drops sessions older than MaxAge, then the oldest beyond MaxSessions.
+// Caller must hold a.mu.
+func (a *Archive) prune(idx *archiveIndex, now time.Time) int {
+	settings := idx.settings()
+	var kept []ArchivedSession
+	for _, s := range idx.Sessions {
+		if settings.MaxAge > 0 && now.Sub(s.CreatedAt) > settings.MaxAge {
+			a.remove(s)
+			continue
+		}
+		kept = append(kept, s)
+	}
+	for len(kept) > settings.MaxSessions {
+		a.remove(kept[0])
+		kept = kept[1:]
+	}
+	pruned := len(idx.Sessions) - len(kept)
+	idx.Sessions = kept
+	return pruned
+}
+
+// remove deletes a session's bundle file; a file already gone is not an error.
+func (a *Archive) remove(s ArchivedSession) {
+	_ = os.Remove(a.Path(s))
+}
+
+// readIndex loads index.json, or the defaults when the archive is new.
+// Caller must hold a.mu.
+func (a *Archive) readIndex() (archiveIndex, error) {
+	settings := DefaultArchiveSettings()
+	idx := archiveIndex{Enabled: settings.Enabled, MaxSessions: settings.MaxSessions, MaxAge: settings.MaxAge.String()}
+	data, err := os.ReadFile(filepath.Join(a.dir, archiveIndexFile)) // #nosec G304 -- path is under the daemon state directory
+	if errors.Is(err, os.ErrNotExist) {
+		return idx, nil
+	}
+	if err != nil {
+		return idx, fmt.Errorf("read archive index: %w", err)
+	}
+	if err := json.Unmarshal(data, &idx); err != nil {
+		return idx, fmt.Errorf("decode archive index: %w", err)
+	}
+	return idx, nil
+}
+
+// writeIndex persists index.json. Caller must hold a.mu.
+func (a *Archive) writeIndex(idx archiveIndex) error {
+	if err := os.MkdirAll(a.dir, 0o750); err != nil {
+		return fmt.Errorf("create archive directory: %w", err)
+	}
+	data, err := json.MarshalIndent(idx, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal archive index: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(a.dir, archiveIndexFile), data, 0o600); err != nil {
+		return fmt.Errorf("write archive index: %w", err)
+	}
+	return nil
+}
+
+// settings converts the persisted fields, falling back to defaults for invalid values.
+func (idx archiveIndex) settings() ArchiveSettings {
+	s := ArchiveSettings{Enabled: idx.Enabled, MaxSessions: idx.MaxSessions}
+	if d, err := time.ParseDuration(idx.MaxAge); err == nil {
+		s.MaxAge = d
+	}
+	if s.Validate() != nil {
+		defaults := DefaultArchiveSettings()
+		s.MaxSessions, s.MaxAge = defaults.MaxSessions, defaults.MaxAge
+	}
+	return s
+}
+
+// find returns the index of the session with id, or -1.
+func (idx archiveIndex) find(id string) int {
+	return slices.IndexFunc(idx.Sessions, func(s ArchivedSession) bool { return s.ID == id })
+}
+
+// empty reports whether every buffer in the bundle is empty.
+func (b Bundle) empty() bool {
+	for _, n := range b.Counts() {
+		if n > 0 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,154 @@
+// Purpose: Tests the session archive's rolling, retention, persisted settings, and search.
+// Docs: docs/features/feature/session-archive/index.md
+
+package sessionbundle
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/types"
+)
+
+func archivedBundle(at time.Time, message string) Bundle {
+	return Bundle{
+		FormatVersion: FormatVersion,
+		CreatedAt:     at,
+		Logs:          []types.LogEntry{{"level": "error", "message": message, "ts": at.Format(time.RFC3339)}},
+	}
+}
+
+func TestArchive_RollSkipsEmptyAndDisabled(t *testing.T) {
+	t.Parallel()
+	a := NewArchive(t.TempDir())
+	if _, ok, err := a.Roll(FromCapture(capture.NewCapture(), nil, "", time.Now()), ReasonClear); ok || err != nil {
+		t.Fatalf("empty bundle archived: ok=%v err=%v", ok, err)
+	}
+
+	settings := DefaultArchiveSettings()
+	settings.Enabled = false
+	if _, err := a.SetSettings(settings, time.Now()); err != nil {
+		t.Fatalf("SetSettings: %v", err)
+	}
+	if _, ok, _ := a.Roll(archivedBundle(time.Now(), "boom"), ReasonClear); ok {
+		t.Fatal("archived while disabled")
+	}
+	// Settings survive a new Archive over the same directory, as after a daemon restart.
+	if got, _ := NewArchive(a.Dir()).Settings(); got.Enabled {
+		t.Fatalf("settings after reopen = %+v, want disabled", got)
+	}
+}
+
+func TestArchive_RollAppliesCountAndAgeRetention(t *testing.T) {
+	t.Parallel()
+	a := NewArchive(t.TempDir())
+	if _, err := a.SetSettings(ArchiveSettings{Enabled: true, MaxSessions: 2, MaxAge: 24 * time.Hour}, time.Now()); err != nil {
+		t.Fatalf("SetSettings: %v", err)
+	}
+
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	stale, _, err := a.Roll(archivedBundle(now.Add(-48*time.Hour), "stale"), ReasonShutdown)
+	if err != nil {
+		t.Fatalf("Roll: %v", err)
+	}
+	first, _, _ := a.Roll(archivedBundle(now, "first"), ReasonClear)
+	second, _, _ := a.Roll(archivedBundle(now, "second"), ReasonClear)
+	if first.ID == second.ID || !strings.HasSuffix(second.ID, "-2") {
+		t.Fatalf("ids %q and %q, want a -2 suffix on the collision", first.ID, second.ID)
+	}
+	if _, err := os.Stat(a.Path(stale)); !os.IsNotExist(err) {
+		t.Errorf("stale bundle still on disk: %v", err)
+	}
+
+	third, _, _ := a.Roll(archivedBundle(now.Add(time.Minute), "third"), ReasonClear)
+	sessions, err := a.List()
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(sessions) != 2 || sessions[0].ID != third.ID || sessions[1].ID != second.ID {
+		t.Fatalf("sessions = %+v, want third then second", sessions)
+	}
+	if sessions[0].Counts["logs"] != 1 || sessions[0].Bytes == 0 || sessions[0].Reason != ReasonClear {
+		t.Errorf("session metadata = %+v", sessions[0])
+	}
+
+	if n, err := a.Clear(); n != 2 || err != nil {
+		t.Fatalf("Clear = %d, %v; want 2", n, err)
+	}
+	if sessions, _ := a.List(); len(sessions) != 0 {
+		t.Fatalf("sessions after Clear = %d", len(sessions))
+	}
+}
+
+func TestArchiveSettings_Validate(t *testing.T) {
+	t.Parallel()
+	for _, s := range []ArchiveSettings{
+		{MaxSessions: 0},
+		{MaxSessions: MaxArchiveSessions + 1},
+		{MaxSessions: 5, MaxAge: time.Minute},
+	} {
+		if s.Validate() == nil {
+			t.Errorf("%+v accepted", s)
+		}
+	}
+	if err := (ArchiveSettings{MaxSessions: 5}).Validate(); err != nil {
+		t.Errorf("no age limit rejected: %v", err)
+	}
+}
+
+func TestArchive_Search(t *testing.T) {
+	t.Parallel()
+	a := NewArchive(t.TempDir())
+	now := time.Now().UTC()
+	older := archivedBundle(now.Add(-time.Hour), "Checkout FAILED: card declined")
+	older.NetworkBodies = []capture.NetworkBody{{Method: "POST", URL: "https://shop.test/api/checkout", Status: 502, ResponseBody: `{"error":"upstream checkout timeout"}`}}
+	newer := archivedBundle(now, "render ok")
+	newer.WebSocketEvents = []capture.WebSocketEvent{{Event: "close", ID: "ws-1", URL: "wss://shop.test/live", CloseReason: "checkout session ended"}}
+	oldSession, _, _ := a.Roll(older, ReasonShutdown)
+	a.Roll(newer, ReasonClear)
+
+	all, err := a.Search("", "CHECKOUT", "", 10)
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if all.Searched != 2 || all.Total != 3 || len(all.Hits) != 3 {
+		t.Fatalf("search = %+v, want 3 hits over 2 sessions", all)
+	}
+	if hit := all.Hits[0]; hit.Buffer != "websocket_events" || hit.Field != "reason" {
+		t.Errorf("newest session should match first, got %+v", hit)
+	}
+
+	one, err := a.Search(oldSession.ID, "checkout", "network", 1)
+	if err != nil {
+		t.Fatalf("Search(session): %v", err)
+	}
+	if one.Total != 1 || one.Hits[0].Session != oldSession.ID || one.Hits[0].Summary != "POST https://shop.test/api/checkout -> 502" {
+		t.Fatalf("session search = %+v", one)
+	}
+
+	limited, _ := a.Search("", "checkout", "", 1)
+	if limited.Total != 3 || len(limited.Hits) != 1 {
+		t.Errorf("limit: total=%d hits=%d, want 3/1", limited.Total, len(limited.Hits))
+	}
+	if _, err := a.Search("session-missing", "checkout", "", 10); err == nil {
+		t.Error("unknown session accepted")
+	}
+}
+
+func TestSnippet_TrimsAroundMatch(t *testing.T) {
+	t.Parallel()
+	text := strings.Repeat("a", 100) + "NEEDLE" + strings.Repeat("é", 100)
+	hit, ok := matchFields("needle", [][2]string{{"message", text}})
+	if !ok {
+		t.Fatal("no match")
+	}
+	if !strings.HasPrefix(hit.Snippet, "...") || !strings.HasSuffix(hit.Snippet, "...") || !strings.Contains(hit.Snippet, "NEEDLE") {
+		t.Fatalf("snippet = %q", hit.Snippet)
+	}
+	if !strings.HasSuffix(strings.TrimSuffix(hit.Snippet, "..."), "é") {
+		t.Errorf("snippet cut inside a rune: %q", hit.Snippet)
+	}
+}
@@ -0,0 +1,222 @@
+// Purpose: Defines the session bundle document and its capture/restore/file I/O operations.
+// Why: One self-describing JSON artifact is easier to attach to an incident than a set of exports.
+// Docs: docs/features/feature/read-only-mode/index.md
+
+package sessionbundle
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/state"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/types"
+)
+
+// FormatVersion is the bundle schema version written by this build.
+const FormatVersion = 1
+
+// ManifestFile is the file name looked up when a bundle path is a directory.
+const ManifestFile = "bundle.json"
+
+// maxBundleBytes caps the decompressed size accepted by Load.
+const maxBundleBytes = 512 << 20
+
+// Bundle is a point-in-time copy of every capture buffer.
+type Bundle struct {
+	FormatVersion    int                             `json:"format_version"`
+	CreatedAt        time.Time                       `json:"created_at"`
+	ServerVersion    string                          `json:"server_version,omitempty"`
+	Label            string                          `json:"label,omitempty"`
+	PageURL          string                          `json:"page_url,omitempty"`
+	Logs             []types.LogEntry                `json:"logs"`
+	ExtensionLogs    []capture.ExtensionLog          `json:"extension_logs"`
+	NetworkBodies    []capture.NetworkBody           `json:"network_bodies"`
+	NetworkWaterfall []capture.NetworkWaterfallEntry `json:"network_waterfall"`
+	WebSocketEvents  []capture.WebSocketEvent        `json:"websocket_events"`
+	Actions          []capture.EnhancedAction        `json:"actions"`
+	Performance      []capture.PerformanceSnapshot   `json:"performance"`
+}
+
+// FromCapture snapshots the capture store and server log entries into a bundle.
+func FromCapture(cap *capture.Capture, logs []types.LogEntry, label string, now time.Time) Bundle {
+	_, _, pageURL := cap.GetTrackingStatus()
+	return Bundle{
+		FormatVersion:    FormatVersion,
+		CreatedAt:        now.UTC(),
+		ServerVersion:    cap.GetServerVersion(),
+		Label:            label,
+		PageURL:          pageURL,
+		Logs:             nonNil(logs),
+		ExtensionLogs:    nonNil(cap.GetExtensionLogs()),
+		NetworkBodies:    nonNil(cap.GetNetworkBodies()),
+		NetworkWaterfall: nonNil(cap.GetNetworkWaterfallEntries()),
+		WebSocketEvents:  nonNil(cap.GetAllWebSocketEvents()),
+		Actions:          nonNil(cap.GetAllEnhancedActions()),
+		Performance:      nonNil(cap.GetPerformanceSnapshots()),
+	}
+}
+
+// Counts returns the number of entries per buffer.
+func (b Bundle) Counts() map[string]int {
+	return map[string]int{
+		"logs":              len(b.Logs),
+		"extension_logs":    len(b.ExtensionLogs),
+		"network_bodies":    len(b.NetworkBodies),
+		"network_waterfall": len(b.NetworkWaterfall),
+		"websocket_events":  len(b.WebSocketEvents),
+		"actions":           len(b.Actions),
+		"performance":       len(b.Performance),
+	}
+}
+
+// Restore ingests the bundle into cap. Server log entries are not part of the capture
+// store; callers add b.Logs to their own log buffer.
+func (b Bundle) Restore(cap *capture.Capture) {
+	if len(b.ExtensionLogs) > 0 {
+		cap.AddExtensionLogs(b.ExtensionLogs)
+	}
+	if len(b.NetworkBodies) > 0 {
+		cap.AddNetworkBodies(b.NetworkBodies)
+	}
+	// Waterfall entries are ingested per page so each keeps its original page URL.
+	var pages []string
+	byPage := make(map[string][]capture.NetworkWaterfallEntry)
+	for _, entry := range b.NetworkWaterfall {
+		if _, ok := byPage[entry.PageURL]; !ok {
+			pages = append(pages, entry.PageURL)
+		}
+		byPage[entry.PageURL] = append(byPage[entry.PageURL], entry)
+	}
+	for _, page := range pages {
+		cap.AddNetworkWaterfallEntries(byPage[page], page)
+	}
+	if len(b.WebSocketEvents) > 0 {
+		cap.AddWebSocketEvents(b.WebSocketEvents)
+	}
+	if len(b.Actions) > 0 {
+		cap.AddEnhancedActions(b.Actions)
+	}
+	if len(b.Performance) > 0 {
+		cap.AddPerformanceSnapshots(b.Performance)
+	}
+}
+
+// Write serializes the bundle to path, gzip-compressed when path ends in ".gz".
+// When path is an existing directory the bundle is written to path/bundle.json.
+// Returns the resolved path and the number of bytes written.
+func Write(path string, b Bundle) (string, int64, error) {
+	if info, err := os.Stat(path); err == nil && info.IsDir() {
+		path = filepath.Join(path, ManifestFile)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return "", 0, fmt.Errorf("create bundle directory: %w", err)
+	}
+	data, err := json.Marshal(b)
+	if err != nil {
+		return "", 0, fmt.Errorf("marshal bundle: %w", err)
+	}
+	if strings.HasSuffix(path, ".gz") {
+		var buf bytes.Buffer
+		zw := gzip.NewWriter(&buf)
+		if _, err := zw.Write(data); err != nil {
+			return "", 0, fmt.Errorf("compress bundle: %w", err)
+		}
+		if err := zw.Close(); err != nil {
+			return "", 0, fmt.Errorf("compress bundle: %w", err)
+		}
+		data = buf.Bytes()
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return "", 0, fmt.Errorf("write bundle: %w", err)
+	}
+	return path, int64(len(data)), nil
+}
+
+// Load reads a bundle from a file or from a directory containing bundle.json.
+// Files ending in ".gz" are decompressed.
+func Load(path string) (Bundle, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return Bundle{}, fmt.Errorf("open bundle: %w", err)
+	}
+	if info.IsDir() {
+		path = filepath.Join(path, ManifestFile)
+	}
+	f, err := os.Open(path) // #nosec G304 -- path is supplied by the local operator
+	if err != nil {
+		return Bundle{}, fmt.Errorf("open bundle: %w", err)
+	}
+	defer f.Close() //nolint:errcheck // read-only file
+
+	var r io.Reader = f
+	if strings.HasSuffix(path, ".gz") {
+		zr, err := gzip.NewReader(f)
+		if err != nil {
+			return Bundle{}, fmt.Errorf("decompress bundle: %w", err)
+		}
+		defer zr.Close() //nolint:errcheck // read-only stream
+		r = zr
+	}
+
+	var b Bundle
+	if err := json.NewDecoder(io.LimitReader(r, maxBundleBytes)).Decode(&b); err != nil {
+		return Bundle{}, fmt.Errorf("decode bundle: %w", err)
+	}
+	if b.FormatVersion == 0 {
+		return Bundle{}, errors.New("not a session bundle: missing format_version")
+	}
+	if b.FormatVersion > FormatVersion {
+		return Bundle{}, fmt.Errorf("bundle format_version %d is newer than supported version %d; upgrade Kaboom", b.FormatVersion, FormatVersion)
+	}
+	return b, nil
+}
+
+// DefaultDir returns the directory where bundles are written when no path is given.
+func DefaultDir() (string, error) {
+	return state.InRoot("bundles")
+}
+
+// FindByLabel returns the newest bundle in dir whose label matches, and its path.
+// Unreadable files are skipped.
+func FindByLabel(dir, label string) (Bundle, string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return Bundle{}, "", fmt.Errorf("list bundles: %w", err)
+	}
+	var found Bundle
+	var foundPath string
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !(strings.HasSuffix(name, ".json") || strings.HasSuffix(name, ".json.gz")) {
+			continue
+		}
+		path := filepath.Join(dir, name)
+		b, err := Load(path)
+		if err != nil || b.Label != label {
+			continue
+		}
+		if foundPath == "" || b.CreatedAt.After(found.CreatedAt) {
+			found, foundPath = b, path
+		}
+	}
+	if foundPath == "" {
+		return Bundle{}, "", fmt.Errorf("no bundle labeled %q in %s", label, dir)
+	}
+	return found, foundPath, nil
+}
+
+func nonNil[T any](s []T) []T {
+	if s == nil {
+		return []T{}
+	}
+	return s
+}
@@ -0,0 +1,118 @@
+// Purpose: Tests session bundle capture, file round trips, and format validation.
+// Docs: docs/features/feature/read-only-mode/index.md
+
+package sessionbundle
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/types"
+)
+
+func populatedCapture() *capture.Capture {
+	cap := capture.NewCapture()
+	cap.AddNetworkBodies([]capture.NetworkBody{{Method: "GET", URL: "https://app.test/api/users", Status: 500}})
+	cap.AddNetworkWaterfallEntries([]capture.NetworkWaterfallEntry{{URL: "https://app.test/app.js", InitiatorType: "script"}}, "https://app.test/")
+	cap.AddEnhancedActions([]capture.EnhancedAction{{Type: "click", Timestamp: 1, URL: "https://app.test/"}})
+	return cap
+}
+
+func TestBundle_RoundTripRestoresCapture(t *testing.T) {
+	t.Parallel()
+	logs := []types.LogEntry{{"level": "error", "message": "boom"}}
+	b := FromCapture(populatedCapture(), logs, "checkout outage", time.Now())
+
+	for _, name := range []string{"bundle.json", "bundle.json.gz"} {
+		path := filepath.Join(t.TempDir(), name)
+		saved, size, err := Write(path, b)
+		if err != nil || saved != path || size == 0 {
+			t.Fatalf("Write(%s) = %q, %d, %v", name, saved, size, err)
+		}
+		loaded, err := Load(path)
+		if err != nil {
+			t.Fatalf("Load(%s): %v", name, err)
+		}
+		if loaded.Label != "checkout outage" || len(loaded.Logs) != 1 {
+			t.Fatalf("Load(%s) label=%q logs=%d", name, loaded.Label, len(loaded.Logs))
+		}
+
+		restored := capture.NewCapture()
+		loaded.Restore(restored)
+		if got := len(restored.GetNetworkBodies()); got != 1 {
+			t.Fatalf("restored network bodies = %d, want 1", got)
+		}
+		wf := restored.GetNetworkWaterfallEntries()
+		if len(wf) != 1 || wf[0].PageURL != "https://app.test/" {
+			t.Fatalf("restored waterfall = %+v, want one entry on https://app.test/", wf)
+		}
+		if got := len(restored.GetAllEnhancedActions()); got != 1 {
+			t.Fatalf("restored actions = %d, want 1", got)
+		}
+	}
+}
+
+func TestWrite_DirectoryUsesManifestName(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	saved, _, err := Write(dir, FromCapture(capture.NewCapture(), nil, "", time.Now()))
+	if err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if saved != filepath.Join(dir, ManifestFile) {
+		t.Fatalf("saved = %q, want %s in dir", saved, ManifestFile)
+	}
+	if _, err := Load(dir); err != nil {
+		t.Fatalf("Load(dir): %v", err)
+	}
+}
+
+func TestLoad_RejectsUnknownFormats(t *testing.T) {
+	t.Parallel()
+	cases := map[string]string{
+		"missing_version": `{"logs":[]}`,
+		"future_version":  `{"format_version":99}`,
+		"not_json":        `not json`,
+	}
+	for name, body := range cases {
+		path := filepath.Join(t.TempDir(), name+".json")
+		if err := os.WriteFile(path, []byte(body), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := Load(path); err == nil {
+			t.Errorf("%s: Load succeeded, want error", name)
+		}
+	}
+	if _, err := Load(filepath.Join(t.TempDir(), "absent.json")); err == nil || !strings.Contains(err.Error(), "open bundle") {
+		t.Errorf("missing file error = %v, want open bundle error", err)
+	}
+}
+
+func TestFindByLabel_ReturnsNewestMatch(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	older := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	for name, b := range map[string]Bundle{
+		"old.json":     {FormatVersion: FormatVersion, CreatedAt: older, Label: "ci"},
+		"new.json.gz":  {FormatVersion: FormatVersion, CreatedAt: older.Add(time.Hour), Label: "ci"},
+		"local.json":   {FormatVersion: FormatVersion, CreatedAt: older, Label: "local"},
+		"notes.txt":    {FormatVersion: FormatVersion, Label: "ci"},
+		"garbage.json": {},
+	} {
+		if _, _, err := Write(filepath.Join(dir, name), b); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	_, path, err := FindByLabel(dir, "ci")
+	if err != nil || filepath.Base(path) != "new.json.gz" {
+		t.Fatalf("FindByLabel(ci) = %q, %v; want new.json.gz", path, err)
+	}
+	if _, _, err := FindByLabel(dir, "staging"); err == nil {
+		t.Fatal("FindByLabel(staging) should fail")
+	}
+}
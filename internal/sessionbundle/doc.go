@@ -0,0 +1,17 @@
+// Purpose: Portable session bundle format for archiving and replaying captured telemetry.
+// Why: Lets an incident captured on one machine be re-served later without a browser or extension.
+// Docs: docs/features/feature/read-only-mode/index.md
+
+/*
+Package sessionbundle serializes the capture buffers (console/server logs, extension logs,
+network bodies and waterfall, WebSocket events, actions, performance snapshots) into a single
+JSON document and restores it into a fresh capture store.
+
+Key functions:
+  - FromCapture: snapshots a live capture store and server logs into a Bundle.
+  - Write / Load: persist a Bundle as JSON (gzip when the path ends in .gz).
+  - Restore: ingests a Bundle into an empty capture store.
+  - Archive: rolls bundles into a retention-managed directory on clear and shutdown.
+  - Bundle.Search / Archive.Search: case-insensitive text search over one or every archived session.
+*/
+package sessionbundle
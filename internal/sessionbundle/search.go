@@ -0,0 +1,216 @@
+// Purpose: Case-insensitive text search over the logs, network bodies, WebSocket events, and actions in a bundle.
+// Why: An archived session is only useful if an agent can find the request or error it remembers.
+// Docs: docs/features/feature/session-archive/index.md
+
+package sessionbundle
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/types"
+)
+
+// SearchBuffers lists the buffer filters accepted by Search; "all" or "" searches every one.
+// logs covers console logs and extension logs.
+var SearchBuffers = []string{"logs", "network", "websocket", "actions"}
+
+// snippetRadius is how many bytes of context surround a match in SearchHit.Snippet.
+const snippetRadius = 60
+
+// maxSummaryLen caps SearchHit.Summary.
+const maxSummaryLen = 200
+
+// SearchHit is one matching entry.
+type SearchHit struct {
+	Session   string `json:"session,omitempty"`
+	Buffer    string `json:"buffer"`
+	Timestamp string `json:"timestamp,omitempty"`
+	Summary   string `json:"summary"`
+	Field     string `json:"field"`
+	Snippet   string `json:"snippet"`
+}
+
+// logSearchFields are the console log entry fields searched, in match order.
+var logSearchFields = []string{"message", "args", "stack", "url", "source", "filename"}
+
+// Search returns the entries whose text fields contain query, ignoring case, in buffer
+// order. buffer restricts the search to one of SearchBuffers.
+func (b Bundle) Search(query, buffer string) []SearchHit {
+	q := strings.ToLower(query)
+	if q == "" {
+		return nil
+	}
+	wants := func(name string) bool { return buffer == "" || buffer == "all" || buffer == name }
+	var hits []SearchHit
+
+	if wants("logs") {
+		for _, entry := range b.Logs {
+			if hit, ok := matchFields(q, logFields(entry)); ok {
+				level, _ := entry["level"].(string)
+				message, _ := entry["message"].(string)
+				ts, _ := entry["ts"].(string)
+				hit.Buffer, hit.Timestamp, hit.Summary = "logs", ts, level+": "+message
+				hits = append(hits, hit)
+			}
+		}
+		for _, entry := range b.ExtensionLogs {
+			fields := [][2]string{{"message", entry.Message}, {"category", entry.Category}, {"source", entry.Source}, {"data", string(entry.Data)}}
+			if hit, ok := matchFields(q, fields); ok {
+				hit.Buffer, hit.Timestamp = "extension_logs", entry.Timestamp.UTC().Format(time.RFC3339Nano)
+				hit.Summary = fmt.Sprintf("[%s] %s", entry.Source, entry.Message)
+				hits = append(hits, hit)
+			}
+		}
+	}
+	if wants("network") {
+		for _, body := range b.NetworkBodies {
+			fields := [][2]string{{"url", body.URL}, {"request_body", body.RequestBody}, {"response_body", body.ResponseBody}}
+			if hit, ok := matchFields(q, fields); ok {
+				hit.Buffer, hit.Timestamp = "network_bodies", body.Timestamp
+				hit.Summary = fmt.Sprintf("%s %s -> %d", body.Method, body.URL, body.Status)
+				hits = append(hits, hit)
+			}
+		}
+	}
+	if wants("websocket") {
+		for _, event := range b.WebSocketEvents {
+			fields := [][2]string{{"url", event.URL}, {"data", event.Data}, {"reason", event.CloseReason}}
+			if hit, ok := matchFields(q, fields); ok {
+				hit.Buffer, hit.Timestamp = "websocket_events", event.Timestamp
+				hit.Summary = strings.TrimSpace(fmt.Sprintf("%s %s %s", event.Event, event.Direction, event.URL))
+				hits = append(hits, hit)
+			}
+		}
+	}
+	if wants("actions") {
+		for _, action := range b.Actions {
+			fields := [][2]string{{"url", action.URL}, {"value", action.Value}, {"selected_text", action.SelectedText}, {"to_url", action.ToURL}}
+			if hit, ok := matchFields(q, fields); ok {
+				hit.Buffer = "actions"
+				if action.Timestamp > 0 {
+					hit.Timestamp = time.UnixMilli(action.Timestamp).UTC().Format(time.RFC3339Nano)
+				}
+				hit.Summary = action.Type + " " + action.URL
+				hits = append(hits, hit)
+			}
+		}
+	}
+	for i := range hits {
+		hits[i].Summary = truncate(hits[i].Summary, maxSummaryLen)
+	}
+	return hits
+}
+
+// logFields renders the searchable console log fields as text.
+func logFields(entry types.LogEntry) [][2]string {
+	fields := make([][2]string, 0, len(logSearchFields))
+	for _, name := range logSearchFields {
+		switch v := entry[name].(type) {
+		case nil:
+		case string:
+			fields = append(fields, [2]string{name, v})
+		default:
+			if data, err := json.Marshal(v); err == nil {
+				fields = append(fields, [2]string{name, string(data)})
+			}
+		}
+	}
+	return fields
+}
+
+// matchFields returns a hit for the first field containing the lower-cased query.
+func matchFields(q string, fields [][2]string) (SearchHit, bool) {
+	for _, field := range fields {
+		text := field[1]
+		lower := strings.ToLower(text)
+		at := strings.Index(lower, q)
+		if at < 0 {
+			continue
+		}
+		// Lower-casing can change byte lengths outside ASCII; cut the snippet from
+		// the lower-cased text when the offsets no longer line up.
+		if len(lower) != len(text) {
+			text = lower
+		}
+		return SearchHit{Field: field[0], Snippet: snippet(text, at, len(q))}, true
+	}
+	return SearchHit{}, false
+}
+
+// snippet returns the match with up to snippetRadius bytes of context on each side,
+// cut on rune boundaries and marked with "..." where text was dropped.
+func snippet(text string, at, n int) string {
+	start, end := max(at-snippetRadius, 0), min(at+n+snippetRadius, len(text))
+	for start > 0 && !utf8.RuneStart(text[start]) {
+		start--
+	}
+	for end < len(text) && !utf8.RuneStart(text[end]) {
+		end++
+	}
+	out := text[start:end]
+	if start > 0 {
+		out = "..." + out
+	}
+	if end < len(text) {
+		out += "..."
+	}
+	return out
+}
+
+// truncate shortens s to at most n bytes on a rune boundary.
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n] + "..."
+}
+
+// ArchiveSearch is the result of searching one or every archived session.
+type ArchiveSearch struct {
+	Hits     []SearchHit
+	Total    int      // matches before limit
+	Searched int      // sessions searched
+	Skipped  []string // sessions whose bundle could not be read
+}
+
+// Search runs Bundle.Search over the session with id, or over every archived session
+// newest first when id is empty, keeping at most limit hits.
+func (a *Archive) Search(id, query, buffer string, limit int) (ArchiveSearch, error) {
+	sessions, err := a.List()
+	if err != nil {
+		return ArchiveSearch{}, err
+	}
+	if id != "" {
+		i := slices.IndexFunc(sessions, func(s ArchivedSession) bool { return s.ID == id })
+		if i < 0 {
+			return ArchiveSearch{}, fmt.Errorf("no archived session %q", id)
+		}
+		sessions = sessions[i : i+1]
+	}
+
+	var result ArchiveSearch
+	for _, session := range sessions {
+		b, err := Load(a.Path(session))
+		if err != nil {
+			result.Skipped = append(result.Skipped, session.ID)
+			continue
+		}
+		result.Searched++
+		for _, hit := range b.Search(query, buffer) {
+			result.Total++
+			if len(result.Hits) < limit {
+				hit.Session = session.ID
+				result.Hits = append(result.Hits, hit)
+			}
+		}
+	}
+	return result, nil
+}
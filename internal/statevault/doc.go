@@ -0,0 +1,25 @@
+// Purpose: Package statevault — encrypted, per-origin storage for authenticated browser state.
+// Why: Reusing a login across agent runs means persisting cookies and tokens, which must not sit on disk in plaintext.
+// Docs: docs/features/feature/auth-state-vault/index.md
+
+/*
+Package statevault seals captured login state (cookies, including HttpOnly ones, plus
+localStorage and sessionStorage) with AES-256-GCM before it reaches the session store.
+
+States are named per origin, so "admin" on staging and "admin" on production are
+separate entries. Each entry keeps its metadata (origin, URL, saved and expiry times,
+item counts) in the clear so states can be listed without the key; the metadata is
+bound to the ciphertext as additional data, so editing an expiry on disk makes the
+entry undecryptable rather than longer-lived.
+
+Key types:
+  - Vault: seals and opens states with a key from --state-key or KABOOM_STATE_KEY.
+  - State: the secret part — cookies and web storage.
+  - Entry: the plaintext metadata of one saved state.
+
+Key functions:
+  - New: derives the AES key from the configured secret.
+  - (*Vault).Save / Open / List / Delete: named states per origin.
+  - NormalizeOrigin: reduces a page URL to the scheme://host[:port] states are keyed by.
+*/
+package statevault
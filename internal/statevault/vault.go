@@ -0,0 +1,311 @@
+// Purpose: Seals, opens, lists, and deletes named auth states per origin with AES-256-GCM.
+// Why: Keeps key handling and the on-disk format in one place, away from the MCP handlers.
+// Docs: docs/features/feature/auth-state-vault/index.md
+
+package statevault
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Namespace is the session store namespace holding sealed states.
+const Namespace = "auth_states"
+
+// MinSecretLength is the shortest --state-key accepted when it is not a base64 32-byte key.
+const MinSecretLength = 16
+
+const (
+	// DefaultTTL is how long a state stays loadable when no ttl is given.
+	DefaultTTL = 24 * time.Hour
+	// MaxTTL caps the ttl of a state.
+	MaxTTL = 30 * 24 * time.Hour
+)
+
+const formatVersion = 1
+
+var (
+	// ErrNotFound is returned when no state has the name for the origin.
+	ErrNotFound = errors.New("state not found")
+	// ErrExpired is returned by Open for a state past its expiry.
+	ErrExpired = errors.New("state expired")
+	// ErrWrongKey is returned when a state cannot be decrypted: a different key, or tampering.
+	ErrWrongKey = errors.New("state cannot be decrypted with the configured key")
+)
+
+var stateNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]{0,63}$`)
+
+// Store is the subset of persistence.SessionStore the vault writes through.
+type Store interface {
+	Save(namespace, key string, data []byte) error
+	Load(namespace, key string) ([]byte, error)
+	List(namespace string) ([]string, error)
+	Delete(namespace, key string) error
+}
+
+// Cookie is one browser cookie as read with chrome.cookies, HttpOnly ones included.
+type Cookie struct {
+	Name           string  `json:"name"`
+	Value          string  `json:"value"`
+	Domain         string  `json:"domain"`
+	Path           string  `json:"path"`
+	Secure         bool    `json:"secure,omitempty"`
+	HTTPOnly       bool    `json:"http_only,omitempty"`
+	HostOnly       bool    `json:"host_only,omitempty"`
+	SameSite       string  `json:"same_site,omitempty"`
+	ExpirationDate float64 `json:"expiration_date,omitempty"` // seconds since the epoch; 0 = session cookie
+}
+
+// State is the secret part of a saved login.
+type State struct {
+	Cookies        []Cookie          `json:"cookies"`
+	LocalStorage   map[string]string `json:"local_storage"`
+	SessionStorage map[string]string `json:"session_storage"`
+}
+
+// Entry is the plaintext metadata of a saved state.
+type Entry struct {
+	Name           string    `json:"name"`
+	Origin         string    `json:"origin"`
+	URL            string    `json:"url"`
+	SavedAt        time.Time `json:"saved_at"`
+	ExpiresAt      time.Time `json:"expires_at"`
+	Cookies        int       `json:"cookies"`
+	LocalStorage   int       `json:"local_storage"`
+	SessionStorage int       `json:"session_storage"`
+}
+
+// Expired reports whether the entry is past its expiry at now.
+func (e Entry) Expired(now time.Time) bool {
+	return !now.Before(e.ExpiresAt)
+}
+
+// sealed is the on-disk form: metadata in the clear, state encrypted with the metadata as additional data.
+type sealed struct {
+	Version int `json:"version"`
+	Entry
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// Vault seals states with one key. It is safe for concurrent use.
+type Vault struct {
+	aead  cipher.AEAD
+	store Store
+	now   func() time.Time
+}
+
+// New derives the AES-256 key from secret. A base64-encoded 32-byte key (openssl rand -base64 32)
+// is used as is; any other secret of at least MinSecretLength characters is hashed with SHA-256.
+func New(secret string, store Store) (*Vault, error) {
+	secret = strings.TrimSpace(secret)
+	key, err := base64.StdEncoding.DecodeString(secret)
+	if err != nil || len(key) != 32 {
+		if len(secret) < MinSecretLength {
+			return nil, fmt.Errorf("state key must be a base64 32-byte key or at least %d characters", MinSecretLength)
+		}
+		sum := sha256.Sum256([]byte(secret))
+		key = sum[:]
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &Vault{aead: aead, store: store, now: time.Now}, nil
+}
+
+// NormalizeOrigin reduces an http(s) URL or origin to scheme://host[:port].
+func NormalizeOrigin(raw string) (string, error) {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "", fmt.Errorf("invalid origin %q: need an http(s) URL such as https://app.example.com", raw)
+	}
+	return strings.ToLower(u.Scheme + "://" + u.Host), nil
+}
+
+// ValidateName checks a state name: letters, digits, '.', '_', and '-', up to 64 characters.
+func ValidateName(name string) error {
+	if !stateNamePattern.MatchString(name) || strings.Contains(name, "..") {
+		return fmt.Errorf("invalid state name %q: use letters, digits, '.', '_', or '-' (max 64)", name)
+	}
+	return nil
+}
+
+// ParseTTL reads a state ttl as a Go duration between 1m and MaxTTL; empty means DefaultTTL.
+func ParseTTL(raw string) (time.Duration, error) {
+	if raw == "" {
+		return DefaultTTL, nil
+	}
+	ttl, err := time.ParseDuration(raw)
+	if err != nil || ttl < time.Minute || ttl > MaxTTL {
+		return 0, fmt.Errorf("ttl must be a duration between 1m and %s, e.g. '12h'", MaxTTL)
+	}
+	return ttl, nil
+}
+
+// storeKey names the file for a state; the origin is hashed because URLs contain path separators.
+func storeKey(name, origin string) string {
+	sum := sha256.Sum256([]byte(origin))
+	return name + "@" + hex.EncodeToString(sum[:8])
+}
+
+// Save seals state as name for origin, replacing any state of the same name, and returns its metadata.
+func (v *Vault) Save(name, origin, pageURL string, state State, ttl time.Duration) (Entry, error) {
+	if err := ValidateName(name); err != nil {
+		return Entry{}, err
+	}
+	origin, err := NormalizeOrigin(origin)
+	if err != nil {
+		return Entry{}, err
+	}
+	if ttl <= 0 {
+		return Entry{}, errors.New("ttl must be positive")
+	}
+	now := v.now().UTC().Truncate(time.Second)
+	entry := Entry{
+		Name:           name,
+		Origin:         origin,
+		URL:            pageURL,
+		SavedAt:        now,
+		ExpiresAt:      now.Add(ttl),
+		Cookies:        len(state.Cookies),
+		LocalStorage:   len(state.LocalStorage),
+		SessionStorage: len(state.SessionStorage),
+	}
+
+	plaintext, err := json.Marshal(state)
+	if err != nil {
+		return Entry{}, err
+	}
+	aad, err := json.Marshal(entry)
+	if err != nil {
+		return Entry{}, err
+	}
+	nonce := make([]byte, v.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return Entry{}, err
+	}
+	data, err := json.Marshal(sealed{
+		Version:    formatVersion,
+		Entry:      entry,
+		Nonce:      nonce,
+		Ciphertext: v.aead.Seal(nil, nonce, plaintext, aad),
+	})
+	if err != nil {
+		return Entry{}, err
+	}
+	if err := v.store.Save(Namespace, storeKey(name, origin), data); err != nil {
+		return Entry{}, err
+	}
+	return entry, nil
+}
+
+// Open decrypts the state saved as name for origin. An expired state returns its metadata with ErrExpired.
+func (v *Vault) Open(name, origin string) (Entry, State, error) {
+	s, err := v.load(name, origin)
+	if err != nil {
+		return Entry{}, State{}, err
+	}
+	if s.Entry.Expired(v.now()) {
+		return s.Entry, State{}, ErrExpired
+	}
+	aad, err := json.Marshal(s.Entry)
+	if err != nil {
+		return Entry{}, State{}, err
+	}
+	plaintext, err := v.aead.Open(nil, s.Nonce, s.Ciphertext, aad)
+	if err != nil {
+		return s.Entry, State{}, ErrWrongKey
+	}
+	var state State
+	if err := json.Unmarshal(plaintext, &state); err != nil {
+		return s.Entry, State{}, ErrWrongKey
+	}
+	return s.Entry, state, nil
+}
+
+// List returns the metadata of every saved state, or only those for origin when it is set,
+// sorted by origin and name. Nothing is decrypted.
+func (v *Vault) List(origin string) ([]Entry, error) {
+	return List(v.store, origin)
+}
+
+// List reads state metadata from store without a key, for listing when no vault is configured.
+func List(store Store, origin string) ([]Entry, error) {
+	if origin != "" {
+		normalized, err := NormalizeOrigin(origin)
+		if err != nil {
+			return nil, err
+		}
+		origin = normalized
+	}
+	keys, err := store.List(Namespace)
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]Entry, 0, len(keys))
+	for _, key := range keys {
+		data, err := store.Load(Namespace, key)
+		if err != nil {
+			continue
+		}
+		var s sealed
+		if json.Unmarshal(data, &s) != nil || s.Name == "" {
+			continue
+		}
+		if origin == "" || s.Origin == origin {
+			entries = append(entries, s.Entry)
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Origin != entries[j].Origin {
+			return entries[i].Origin < entries[j].Origin
+		}
+		return entries[i].Name < entries[j].Name
+	})
+	return entries, nil
+}
+
+// Delete removes the state saved as name for origin.
+func (v *Vault) Delete(name, origin string) error {
+	if _, err := v.load(name, origin); err != nil {
+		return err
+	}
+	origin, _ = NormalizeOrigin(origin)
+	return v.store.Delete(Namespace, storeKey(name, origin))
+}
+
+func (v *Vault) load(name, origin string) (sealed, error) {
+	if err := ValidateName(name); err != nil {
+		return sealed{}, err
+	}
+	origin, err := NormalizeOrigin(origin)
+	if err != nil {
+		return sealed{}, err
+	}
+	data, err := v.store.Load(Namespace, storeKey(name, origin))
+	if err != nil {
+		return sealed{}, ErrNotFound
+	}
+	var s sealed
+	if err := json.Unmarshal(data, &s); err != nil || s.Version != formatVersion {
+		return sealed{}, ErrWrongKey
+	}
+	return s, nil
+}
@@ -0,0 +1,149 @@
+// Purpose: Tests sealing, opening, listing, expiry, wrong keys, and tampering for the auth state vault.
+// Docs: docs/features/feature/auth-state-vault/index.md
+
+package statevault
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+type memStore map[string][]byte
+
+func (m memStore) Save(ns, key string, data []byte) error {
+	m[ns+"/"+key] = append([]byte(nil), data...)
+	return nil
+}
+
+func (m memStore) Load(ns, key string) ([]byte, error) {
+	data, ok := m[ns+"/"+key]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return data, nil
+}
+
+func (m memStore) List(ns string) ([]string, error) {
+	var keys []string
+	for k := range m {
+		if key, ok := strings.CutPrefix(k, ns+"/"); ok {
+			keys = append(keys, key)
+		}
+	}
+	return keys, nil
+}
+
+func (m memStore) Delete(ns, key string) error {
+	delete(m, ns+"/"+key)
+	return nil
+}
+
+var loginState = State{
+	Cookies:      []Cookie{{Name: "sid", Value: "s3cret-session", Domain: "app.example.com", Path: "/", Secure: true, HTTPOnly: true, HostOnly: true}},
+	LocalStorage: map[string]string{"access_token": "eyJhbGciOi.secret"},
+}
+
+func TestVault_SaveOpenListDelete(t *testing.T) {
+	t.Parallel()
+	store := memStore{}
+	v, err := New("correct horse battery staple", store)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	entry, err := v.Save("admin", "https://App.example.com/dashboard?x=1", "https://app.example.com/dashboard", loginState, 12*time.Hour)
+	if err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	if entry.Origin != "https://app.example.com" || entry.Cookies != 1 || entry.LocalStorage != 1 || entry.ExpiresAt.Sub(entry.SavedAt) != 12*time.Hour {
+		t.Fatalf("entry = %+v", entry)
+	}
+	for _, data := range store {
+		if strings.Contains(string(data), "s3cret-session") || strings.Contains(string(data), "eyJhbGciOi") {
+			t.Fatalf("secrets written in plaintext: %s", data)
+		}
+	}
+
+	_, opened, err := v.Open("admin", "https://app.example.com")
+	if err != nil || opened.Cookies[0].Value != "s3cret-session" || !opened.Cookies[0].HTTPOnly || opened.LocalStorage["access_token"] != "eyJhbGciOi.secret" {
+		t.Fatalf("Open = %+v, %v", opened, err)
+	}
+
+	if _, err := v.Save("admin", "https://staging.example.com", "https://staging.example.com/", State{}, time.Hour); err != nil {
+		t.Fatalf("Save staging: %v", err)
+	}
+	all, _ := v.List("")
+	if len(all) != 2 || all[0].Origin != "https://app.example.com" || all[1].Origin != "https://staging.example.com" {
+		t.Fatalf("List = %+v", all)
+	}
+	if scoped, _ := v.List("https://staging.example.com/login"); len(scoped) != 1 || scoped[0].Name != "admin" {
+		t.Fatalf("List(staging) = %+v", scoped)
+	}
+
+	if err := v.Delete("admin", "https://app.example.com"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, _, err := v.Open("admin", "https://app.example.com"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Open after delete = %v, want ErrNotFound", err)
+	}
+}
+
+func TestVault_ExpiryWrongKeyAndTampering(t *testing.T) {
+	t.Parallel()
+	store := memStore{}
+	v, _ := New("correct horse battery staple", store)
+	now := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	v.now = func() time.Time { return now }
+	if _, err := v.Save("admin", "https://app.example.com", "", loginState, time.Hour); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	other, _ := New("a different secret entirely", store)
+	other.now = v.now
+	if _, _, err := other.Open("admin", "https://app.example.com"); !errors.Is(err, ErrWrongKey) {
+		t.Fatalf("Open with another key = %v, want ErrWrongKey", err)
+	}
+
+	// Pushing the expiry out on disk breaks the authentication tag instead of extending the login.
+	for key, data := range store {
+		var raw map[string]any
+		_ = json.Unmarshal(data, &raw)
+		raw["expires_at"] = now.Add(24 * time.Hour).Format(time.RFC3339)
+		tampered, _ := json.Marshal(raw)
+		store[key] = tampered
+		v.now = func() time.Time { return now.Add(2 * time.Hour) }
+		if _, _, err := v.Open("admin", "https://app.example.com"); !errors.Is(err, ErrWrongKey) {
+			t.Fatalf("Open tampered = %v, want ErrWrongKey", err)
+		}
+		store[key] = data
+	}
+
+	v.now = func() time.Time { return now.Add(time.Hour) }
+	if entry, _, err := v.Open("admin", "https://app.example.com"); !errors.Is(err, ErrExpired) || entry.Name != "admin" {
+		t.Fatalf("Open expired = %+v, %v, want ErrExpired", entry, err)
+	}
+}
+
+func TestVault_Validation(t *testing.T) {
+	t.Parallel()
+	if _, err := New("short", memStore{}); err == nil {
+		t.Fatal("short secrets should be rejected")
+	}
+	if _, err := New("MDEyMzQ1Njc4OWFiY2RlZjAxMjM0NTY3ODlhYmNkZWY=", memStore{}); err != nil {
+		t.Fatalf("base64 32-byte key: %v", err)
+	}
+	v, _ := New("correct horse battery staple", memStore{})
+	for _, tc := range []struct{ name, origin string }{
+		{"../escape", "https://app.example.com"},
+		{"has space", "https://app.example.com"},
+		{"admin", "chrome://settings"},
+		{"admin", "app.example.com"},
+	} {
+		if _, err := v.Save(tc.name, tc.origin, "", State{}, time.Hour); err == nil {
+			t.Errorf("Save(%q, %q) should fail", tc.name, tc.origin)
+		}
+	}
+}
@@ -80,12 +80,10 @@ func (ab *AlertBuffer) maybeCreateAnomalyAlert(t time.Time, recentCount int, rol
 		Timestamp: t.Format(time.RFC3339),
 		Source:    "anomaly_detector",
 	}
-	if len(ab.Alerts) >= AlertBufferCap {
-		newAlerts := make([]types.Alert, len(ab.Alerts)-1)
-		copy(newAlerts, ab.Alerts[1:])
-		ab.Alerts = newAlerts
+	if ab.silencedLocked(alert, t) {
+		return nil
 	}
-	ab.Alerts = append(ab.Alerts, alert)
+	ab.appendLocked(alert)
 	return &alert
 }
 
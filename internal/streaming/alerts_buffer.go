@@ -4,20 +4,23 @@
 
 package streaming
 
-import "github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/types"
+import (
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/types"
+)
 
 // AddAlert appends an alert to the buffer, evicting the oldest if at capacity.
 // Also emits the alert as an MCP notification if streaming is enabled.
+// Alerts covered by an active silence are dropped.
 func (ab *AlertBuffer) AddAlert(a types.Alert) {
 	stream := func() *StreamState {
 		ab.Mu.Lock()
 		defer ab.Mu.Unlock()
-		if len(ab.Alerts) >= AlertBufferCap {
-			newAlerts := make([]types.Alert, len(ab.Alerts)-1)
-			copy(newAlerts, ab.Alerts[1:])
-			ab.Alerts = newAlerts
+		if ab.silencedLocked(a, time.Now()) {
+			return nil
 		}
-		ab.Alerts = append(ab.Alerts, a)
+		ab.appendLocked(a)
 		return ab.Stream
 	}()
 
@@ -26,6 +29,29 @@ func (ab *AlertBuffer) AddAlert(a types.Alert) {
 	}
 }
 
+// SetOnAppend registers a hook invoked for every alert that enters the buffer
+// (after silence filtering). The hook runs with Mu held and must not call back
+// into the AlertBuffer; it is intended for leaf-locked sinks such as the change feed.
+func (ab *AlertBuffer) SetOnAppend(fn func(types.Alert)) {
+	ab.Mu.Lock()
+	defer ab.Mu.Unlock()
+	ab.onAppend = fn
+}
+
+// appendLocked appends an alert, evicting the oldest if at capacity.
+// Must be called with ab.Mu held.
+func (ab *AlertBuffer) appendLocked(a types.Alert) {
+	if len(ab.Alerts) >= AlertBufferCap {
+		newAlerts := make([]types.Alert, len(ab.Alerts)-1)
+		copy(newAlerts, ab.Alerts[1:])
+		ab.Alerts = newAlerts
+	}
+	ab.Alerts = append(ab.Alerts, a)
+	if ab.onAppend != nil {
+		ab.onAppend(a)
+	}
+}
+
 // DrainAlerts returns all pending alerts (deduplicated, correlated, sorted)
 // and clears the buffer. Returns nil if no alerts pending.
 func (ab *AlertBuffer) DrainAlerts() []types.Alert {
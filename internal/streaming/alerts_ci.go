@@ -13,7 +13,8 @@ import (
 )
 
 // ProcessCIResult stores the CI result and generates an alert if new.
-// Returns the new alert (for streaming), or nil if this was an idempotent update.
+// Returns the new alert (for streaming), or nil if this was an idempotent update
+// or the alert is covered by an active silence (the CI result is still stored).
 func (ab *AlertBuffer) ProcessCIResult(ciResult types.CIResult) *types.Alert {
 	ab.Mu.Lock()
 	defer ab.Mu.Unlock()
@@ -30,12 +31,10 @@ func (ab *AlertBuffer) ProcessCIResult(ciResult types.CIResult) *types.Alert {
 	ab.CIResults = append(ab.CIResults, ciResult)
 
 	alert := BuildCIAlert(ciResult)
-	if len(ab.Alerts) >= AlertBufferCap {
-		newAlerts := make([]types.Alert, len(ab.Alerts)-1)
-		copy(newAlerts, ab.Alerts[1:])
-		ab.Alerts = newAlerts
+	if ab.silencedLocked(alert, time.Now()) {
+		return nil
 	}
-	ab.Alerts = append(ab.Alerts, alert)
+	ab.appendLocked(alert)
 	return &alert
 }
 
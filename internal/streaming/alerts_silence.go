@@ -0,0 +1,124 @@
+// Purpose: Time-boxed alert silences (maintenance windows) that suppress alert emission without pausing capture.
+// Why: Known-noisy operations (dependency upgrades, migrations) should not flood agents with alerts they already expect.
+// Docs: docs/features/feature/push-alerts/index.md
+
+package streaming
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/types"
+)
+
+// MaxAlertSilences bounds concurrently active silences.
+const MaxAlertSilences = 20
+
+// MaxSilenceDuration caps a single silence window.
+const MaxSilenceDuration = 24 * time.Hour
+
+// AlertSilence suppresses alerts in the listed categories (all categories when empty) until ExpiresAt.
+type AlertSilence struct {
+	ID         string    `json:"id"`
+	Categories []string  `json:"categories,omitempty"`
+	Reason     string    `json:"reason,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+	Suppressed int       `json:"suppressed"`
+}
+
+// matches reports whether the silence covers the alert category.
+func (s *AlertSilence) matches(category string) bool {
+	if len(s.Categories) == 0 {
+		return true
+	}
+	for _, c := range s.Categories {
+		if c == category || c == "all" {
+			return true
+		}
+	}
+	return false
+}
+
+// AddSilence starts a silence window. Returns an error when the window is out of range
+// or the active-silence cap is reached.
+func (ab *AlertBuffer) AddSilence(categories []string, d time.Duration, reason string, now time.Time) (AlertSilence, error) {
+	if d <= 0 || d > MaxSilenceDuration {
+		return AlertSilence{}, fmt.Errorf("duration must be between 1s and %s", MaxSilenceDuration)
+	}
+	ab.Mu.Lock()
+	defer ab.Mu.Unlock()
+
+	ab.pruneSilencesLocked(now)
+	if len(ab.Silences) >= MaxAlertSilences {
+		return AlertSilence{}, fmt.Errorf("too many active silences (max %d)", MaxAlertSilences)
+	}
+	ab.silenceSeq++
+	s := AlertSilence{
+		ID:         fmt.Sprintf("silence_%d", ab.silenceSeq),
+		Categories: append([]string(nil), categories...),
+		Reason:     reason,
+		CreatedAt:  now.UTC(),
+		ExpiresAt:  now.Add(d).UTC(),
+	}
+	ab.Silences = append(ab.Silences, s)
+	return s, nil
+}
+
+// ClearSilences ends the silence with the given ID, or all silences when id is empty.
+// Returns the number of silences removed.
+func (ab *AlertBuffer) ClearSilences(id string) int {
+	ab.Mu.Lock()
+	defer ab.Mu.Unlock()
+	if id == "" {
+		n := len(ab.Silences)
+		ab.Silences = nil
+		return n
+	}
+	kept := ab.Silences[:0]
+	for _, s := range ab.Silences {
+		if s.ID != id {
+			kept = append(kept, s)
+		}
+	}
+	removed := len(ab.Silences) - len(kept)
+	ab.Silences = kept
+	return removed
+}
+
+// ActiveSilences returns a copy of unexpired silences, dropping expired ones.
+func (ab *AlertBuffer) ActiveSilences(now time.Time) []AlertSilence {
+	ab.Mu.Lock()
+	defer ab.Mu.Unlock()
+	ab.pruneSilencesLocked(now)
+	out := make([]AlertSilence, len(ab.Silences))
+	copy(out, ab.Silences)
+	return out
+}
+
+// silencedLocked reports whether an active silence covers the alert and counts the suppression.
+// Must be called with ab.Mu held.
+func (ab *AlertBuffer) silencedLocked(alert types.Alert, now time.Time) bool {
+	if len(ab.Silences) == 0 {
+		return false
+	}
+	ab.pruneSilencesLocked(now)
+	for i := range ab.Silences {
+		if ab.Silences[i].matches(alert.Category) {
+			ab.Silences[i].Suppressed++
+			return true
+		}
+	}
+	return false
+}
+
+// pruneSilencesLocked drops expired silences. Must be called with ab.Mu held.
+func (ab *AlertBuffer) pruneSilencesLocked(now time.Time) {
+	kept := ab.Silences[:0]
+	for _, s := range ab.Silences {
+		if now.Before(s.ExpiresAt) {
+			kept = append(kept, s)
+		}
+	}
+	ab.Silences = kept
+}
@@ -0,0 +1,70 @@
+// Purpose: Tests for alert silence windows: category matching, suppression counts, CI storage, and auto-expiry.
+// Docs: docs/features/feature/push-alerts/index.md
+
+package streaming
+
+import (
+	"testing"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/types"
+)
+
+func TestAlertSilence_SuppressesMatchingCategories(t *testing.T) {
+	t.Parallel()
+	ab := NewAlertBuffer()
+	silence, err := ab.AddSilence([]string{"regression"}, 30*time.Minute, "dependency upgrade", time.Now())
+	if err != nil {
+		t.Fatalf("AddSilence: %v", err)
+	}
+
+	ab.AddAlert(types.Alert{Severity: "error", Category: "regression", Title: "LCP regressed"})
+	ab.AddAlert(types.Alert{Severity: "warning", Category: "noise", Title: "Noisy console"})
+
+	alerts := ab.DrainAlerts()
+	if len(alerts) != 1 || alerts[0].Category != "noise" {
+		t.Fatalf("drained alerts = %+v, want only the noise alert", alerts)
+	}
+	active := ab.ActiveSilences(time.Now())
+	if len(active) != 1 || active[0].ID != silence.ID || active[0].Suppressed != 1 {
+		t.Fatalf("active silences = %+v, want %s with 1 suppressed", active, silence.ID)
+	}
+}
+
+func TestAlertSilence_CIResultStoredButAlertSuppressed(t *testing.T) {
+	t.Parallel()
+	ab := NewAlertBuffer()
+	if _, err := ab.AddSilence(nil, time.Hour, "", time.Now()); err != nil {
+		t.Fatalf("AddSilence: %v", err)
+	}
+	if alert := ab.ProcessCIResult(types.CIResult{Status: "failure", Commit: "abc123"}); alert != nil {
+		t.Fatalf("ProcessCIResult returned %+v, want nil while silenced", alert)
+	}
+	if len(ab.CIResults) != 1 {
+		t.Fatalf("len(CIResults) = %d, want 1 (capture must continue)", len(ab.CIResults))
+	}
+	if len(ab.Alerts) != 0 {
+		t.Fatalf("len(Alerts) = %d, want 0", len(ab.Alerts))
+	}
+}
+
+func TestAlertSilence_ExpiryAndClear(t *testing.T) {
+	t.Parallel()
+	ab := NewAlertBuffer()
+	now := time.Now()
+	first, _ := ab.AddSilence(nil, time.Minute, "", now)
+	second, _ := ab.AddSilence([]string{"ci"}, time.Hour, "", now)
+
+	if got := ab.ActiveSilences(now.Add(2 * time.Minute)); len(got) != 1 || got[0].ID != second.ID {
+		t.Fatalf("after expiry active = %+v, want only %s", got, second.ID)
+	}
+	if n := ab.ClearSilences(first.ID); n != 0 {
+		t.Fatalf("ClearSilences(expired) = %d, want 0", n)
+	}
+	if n := ab.ClearSilences(""); n != 1 {
+		t.Fatalf("ClearSilences(all) = %d, want 1", n)
+	}
+	if _, err := ab.AddSilence(nil, 48*time.Hour, "", now); err == nil {
+		t.Fatal("AddSilence should reject windows beyond MaxSilenceDuration")
+	}
+}
@@ -89,6 +89,10 @@ type AlertBuffer struct {
 	CIResults  []types.CIResult
 	ErrorTimes []time.Time
 	Stream     *StreamState
+	Silences   []AlertSilence
+
+	silenceSeq int
+	onAppend   func(types.Alert) // optional; invoked with Mu held, so it must not re-enter AlertBuffer
 }
 
 // NewAlertBuffer creates an AlertBuffer with a default StreamState.
@@ -0,0 +1,25 @@
+// Purpose: Package testevents — ingests test-runner lifecycle events and derives per-test time segments.
+// Why: Browser telemetry is only actionable in CI when it can be attributed to the exact test that produced it.
+// Docs: docs/features/feature/test-runner-events/index.md
+
+/*
+Package testevents accepts lifecycle events from Vitest/Jest/Playwright-style
+reporters (run, suite, and test start/end) and keeps a bounded history of the
+raw events plus one Segment per test execution.
+
+A Segment is the [start, end] window during which a test was running. Callers
+attribute captured telemetry to a test by locating the segment that contains
+its timestamp (Locator.At), which is how observe(what="timeline") interleaves and
+segments browser events by test.
+
+Key types:
+  - Event: one normalized reporter event.
+  - Segment: one test execution window with its outcome.
+  - Store: bounded event and segment history with its own lock (a leaf lock).
+
+Key functions:
+  - Parse: decodes a single event, an array, or {"events": [...]}.
+  - (*Store).Ingest: records events and reports which tests started or ended.
+  - (*Store).Locator: a snapshot that finds the innermost test running at a given time.
+*/
+package testevents
@@ -0,0 +1,215 @@
+// Purpose: Decodes and normalizes reporter payloads into typed lifecycle events.
+// Why: Vitest, Jest, and Playwright reporters name phases and outcomes differently; one shape keeps segmentation simple.
+// Docs: docs/features/feature/test-runner-events/index.md
+
+package testevents
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Event types after normalization.
+const (
+	TypeRunStart   = "run_start"
+	TypeRunEnd     = "run_end"
+	TypeSuiteStart = "suite_start"
+	TypeSuiteEnd   = "suite_end"
+	TypeTestStart  = "test_start"
+	TypeTestEnd    = "test_end"
+)
+
+// Test outcomes.
+const (
+	StatusRunning     = "running"
+	StatusPassed      = "passed"
+	StatusFailed      = "failed"
+	StatusSkipped     = "skipped"
+	StatusInterrupted = "interrupted"
+)
+
+// Payload limits.
+const (
+	MaxBatch         = 500
+	maxMessageLength = 1000
+	maxStackLength   = 4000
+)
+
+// Failure is the assertion or error that failed a test.
+type Failure struct {
+	Message string `json:"message"`
+	Stack   string `json:"stack,omitempty"`
+}
+
+// Event is one normalized reporter event.
+type Event struct {
+	Type       string    `json:"type"`
+	Runner     string    `json:"runner,omitempty"`
+	RunID      string    `json:"run_id,omitempty"`
+	TestID     string    `json:"test_id,omitempty"`
+	Name       string    `json:"name,omitempty"`
+	Suite      string    `json:"suite,omitempty"`
+	File       string    `json:"file,omitempty"`
+	Status     string    `json:"status,omitempty"`
+	DurationMs int64     `json:"duration_ms,omitempty"`
+	Error      *Failure  `json:"error,omitempty"`
+	Timestamp  time.Time `json:"timestamp"`
+}
+
+// wireEvent accepts timestamps as RFC3339 strings or Unix milliseconds.
+type wireEvent struct {
+	Event
+	Timestamp json.RawMessage `json:"timestamp"`
+}
+
+// Parse decodes a single event object, an array of events, or {"events": [...]}.
+// Events without a timestamp are stamped with now. Each event is validated and normalized.
+func Parse(raw []byte, now time.Time) ([]Event, error) {
+	raw = bytes.TrimSpace(raw)
+	var wire []wireEvent
+	switch {
+	case len(raw) == 0:
+		return nil, errors.New("empty body")
+	case raw[0] == '[':
+		if err := json.Unmarshal(raw, &wire); err != nil {
+			return nil, fmt.Errorf("invalid JSON: %w", err)
+		}
+	default:
+		var envelope struct {
+			Events []wireEvent `json:"events"`
+		}
+		if err := json.Unmarshal(raw, &envelope); err != nil {
+			return nil, fmt.Errorf("invalid JSON: %w", err)
+		}
+		if envelope.Events != nil {
+			wire = envelope.Events
+		} else {
+			var single wireEvent
+			if err := json.Unmarshal(raw, &single); err != nil {
+				return nil, fmt.Errorf("invalid JSON: %w", err)
+			}
+			wire = []wireEvent{single}
+		}
+	}
+	if len(wire) > MaxBatch {
+		return nil, fmt.Errorf("batch of %d events exceeds limit of %d", len(wire), MaxBatch)
+	}
+
+	events := make([]Event, 0, len(wire))
+	for i, w := range wire {
+		ev := w.Event
+		ts, err := parseTimestamp(w.Timestamp, now)
+		if err != nil {
+			return nil, fmt.Errorf("event %d: %w", i, err)
+		}
+		ev.Timestamp = ts
+		if err := normalize(&ev); err != nil {
+			return nil, fmt.Errorf("event %d: %w", i, err)
+		}
+		events = append(events, ev)
+	}
+	return events, nil
+}
+
+func parseTimestamp(raw json.RawMessage, now time.Time) (time.Time, error) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return now, nil
+	}
+	var ms int64
+	if err := json.Unmarshal(raw, &ms); err == nil {
+		return time.UnixMilli(ms), nil
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err != nil {
+		return time.Time{}, errors.New("timestamp must be RFC3339 or Unix milliseconds")
+	}
+	ts, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("timestamp: %w", err)
+	}
+	return ts, nil
+}
+
+// normalize canonicalizes type and status names and derives TestID.
+func normalize(ev *Event) error {
+	ev.Type = normalizeType(ev.Type)
+	switch ev.Type {
+	case TypeRunStart, TypeRunEnd:
+	case TypeSuiteStart, TypeSuiteEnd:
+		if ev.Suite == "" {
+			ev.Suite = ev.Name
+		}
+		if ev.Suite == "" {
+			return errors.New("suite events require suite or name")
+		}
+	case TypeTestStart, TypeTestEnd:
+		if ev.TestID == "" {
+			ev.TestID = joinNonEmpty(" > ", ev.File, ev.Suite, ev.Name)
+		}
+		if ev.TestID == "" {
+			return errors.New("test events require test_id or name")
+		}
+	default:
+		return fmt.Errorf("unknown type %q (use run_start, run_end, suite_start, suite_end, test_start, test_end)", ev.Type)
+	}
+
+	ev.Status = normalizeStatus(ev.Status)
+	if ev.Type == TypeTestEnd && ev.Status == "" {
+		ev.Status = StatusPassed
+		if ev.Error != nil {
+			ev.Status = StatusFailed
+		}
+	}
+	if ev.Error != nil {
+		ev.Error.Message = truncate(ev.Error.Message, maxMessageLength)
+		ev.Error.Stack = truncate(ev.Error.Stack, maxStackLength)
+	}
+	return nil
+}
+
+// normalizeType maps "finish"/"done" phase names onto "end".
+func normalizeType(t string) string {
+	t = strings.ToLower(strings.TrimSpace(t))
+	for _, suffix := range []string{"_finish", "_done", "_complete"} {
+		if strings.HasSuffix(t, suffix) {
+			return strings.TrimSuffix(t, suffix) + "_end"
+		}
+	}
+	return t
+}
+
+func normalizeStatus(s string) string {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "":
+		return ""
+	case "pass", "passed", "ok", "success":
+		return StatusPassed
+	case "fail", "failed", "failure", "error", "timedout":
+		return StatusFailed
+	case "skip", "skipped", "pending", "todo", "disabled":
+		return StatusSkipped
+	default:
+		return strings.ToLower(strings.TrimSpace(s))
+	}
+}
+
+func joinNonEmpty(sep string, parts ...string) string {
+	kept := parts[:0:0]
+	for _, p := range parts {
+		if p != "" {
+			kept = append(kept, p)
+		}
+	}
+	return strings.Join(kept, sep)
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n] + "..."
+}
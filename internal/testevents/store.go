@@ -0,0 +1,259 @@
+// Purpose: Stores reporter events and derives one time segment per test execution.
+// Why: Segments turn "what was captured at 12:03:04" into "what test-cart-adds-item produced".
+// Docs: docs/features/feature/test-runner-events/index.md
+
+package testevents
+
+import (
+	"sync"
+	"time"
+)
+
+// Retention limits.
+const (
+	MaxEvents   = 2000
+	MaxSegments = 1000
+)
+
+// Segment is the window during which one test ran.
+type Segment struct {
+	TestID     string   `json:"test_id"`
+	Name       string   `json:"name,omitempty"`
+	Suite      string   `json:"suite,omitempty"`
+	File       string   `json:"file,omitempty"`
+	Runner     string   `json:"runner,omitempty"`
+	RunID      string   `json:"run_id,omitempty"`
+	Status     string   `json:"status"`
+	Start      string   `json:"start"`
+	End        string   `json:"end,omitempty"`
+	DurationMs int64    `json:"duration_ms,omitempty"`
+	Error      *Failure `json:"error,omitempty"`
+
+	start time.Time
+	end   time.Time // zero while running
+}
+
+// Contains reports whether t falls inside the segment. Running segments extend to now.
+func (s Segment) Contains(t, now time.Time) bool {
+	end := s.end
+	if end.IsZero() {
+		end = now
+	}
+	return !t.Before(s.start) && !t.After(end)
+}
+
+// IngestResult reports what a batch changed.
+type IngestResult struct {
+	Accepted int      `json:"accepted"`
+	Started  []string `json:"started,omitempty"`
+	Ended    []string `json:"ended,omitempty"`
+	// RunsEnded lists the run IDs of run_end events in the batch ("" for an unnamed run).
+	RunsEnded []string `json:"runs_ended,omitempty"`
+}
+
+// Summary counts segments by outcome.
+type Summary struct {
+	Tests   int `json:"tests"`
+	Running int `json:"running"`
+	Passed  int `json:"passed"`
+	Failed  int `json:"failed"`
+	Skipped int `json:"skipped"`
+	Other   int `json:"other"`
+}
+
+// Store keeps bounded event and segment history.
+//
+// Lock hierarchy: Store.mu is a leaf lock; Store never calls out while holding it.
+type Store struct {
+	mu       sync.Mutex
+	events   []Event
+	segments []*Segment
+	open     map[string]*Segment // test ID -> running segment
+}
+
+// NewStore creates an empty store.
+func NewStore() *Store {
+	return &Store{open: make(map[string]*Segment)}
+}
+
+// Ingest records events in order and opens or closes test segments.
+// A test_start for a test that is already running closes the earlier run as interrupted.
+// A test_end without a matching start creates a segment back-dated by duration_ms.
+func (s *Store) Ingest(events []Event) IngestResult {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var res IngestResult
+	for _, ev := range events {
+		s.events = append(s.events, ev)
+		res.Accepted++
+		switch ev.Type {
+		case TypeTestStart:
+			if prev, ok := s.open[ev.TestID]; ok {
+				closeSegment(prev, ev.Timestamp, StatusInterrupted, 0, nil)
+			}
+			seg := newSegment(ev, ev.Timestamp)
+			s.appendSegmentLocked(seg)
+			s.open[ev.TestID] = seg
+			res.Started = append(res.Started, ev.TestID)
+		case TypeTestEnd:
+			seg, ok := s.open[ev.TestID]
+			if !ok {
+				seg = newSegment(ev, ev.Timestamp.Add(-time.Duration(ev.DurationMs)*time.Millisecond))
+				s.appendSegmentLocked(seg)
+			}
+			delete(s.open, ev.TestID)
+			closeSegment(seg, ev.Timestamp, ev.Status, ev.DurationMs, ev.Error)
+			res.Ended = append(res.Ended, ev.TestID)
+		case TypeRunEnd:
+			res.RunsEnded = append(res.RunsEnded, ev.RunID)
+			// A finished run cannot have tests still executing.
+			for id, seg := range s.open {
+				if ev.RunID == "" || seg.RunID == ev.RunID {
+					closeSegment(seg, ev.Timestamp, StatusInterrupted, 0, nil)
+					delete(s.open, id)
+					res.Ended = append(res.Ended, id)
+				}
+			}
+		}
+	}
+	if over := len(s.events) - MaxEvents; over > 0 {
+		s.events = append([]Event(nil), s.events[over:]...)
+	}
+	return res
+}
+
+func newSegment(ev Event, start time.Time) *Segment {
+	return &Segment{
+		TestID: ev.TestID,
+		Name:   ev.Name,
+		Suite:  ev.Suite,
+		File:   ev.File,
+		Runner: ev.Runner,
+		RunID:  ev.RunID,
+		Status: StatusRunning,
+		Start:  start.UTC().Format(time.RFC3339Nano),
+		start:  start,
+	}
+}
+
+func closeSegment(seg *Segment, end time.Time, status string, durationMs int64, failure *Failure) {
+	seg.end = end
+	seg.End = end.UTC().Format(time.RFC3339Nano)
+	seg.Status = status
+	if durationMs <= 0 {
+		durationMs = end.Sub(seg.start).Milliseconds()
+	}
+	seg.DurationMs = durationMs
+	seg.Error = failure
+}
+
+// appendSegmentLocked adds a segment, evicting the oldest past MaxSegments.
+func (s *Store) appendSegmentLocked(seg *Segment) {
+	s.segments = append(s.segments, seg)
+	if over := len(s.segments) - MaxSegments; over > 0 {
+		for _, evicted := range s.segments[:over] {
+			if s.open[evicted.TestID] == evicted {
+				delete(s.open, evicted.TestID)
+			}
+		}
+		s.segments = append([]*Segment(nil), s.segments[over:]...)
+	}
+}
+
+// Events returns up to limit of the most recent events, oldest first (limit <= 0 returns all).
+func (s *Store) Events(limit int) []Event {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	start := 0
+	if limit > 0 && len(s.events) > limit {
+		start = len(s.events) - limit
+	}
+	return append([]Event(nil), s.events[start:]...)
+}
+
+// Segments returns a copy of all retained segments in ingestion order.
+func (s *Store) Segments() []Segment {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make([]Segment, len(s.segments))
+	for i, seg := range s.segments {
+		out[i] = *seg
+	}
+	return out
+}
+
+// Segment returns the most recent segment for testID.
+func (s *Store) Segment(testID string) (Segment, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := len(s.segments) - 1; i >= 0; i-- {
+		if s.segments[i].TestID == testID {
+			return *s.segments[i], true
+		}
+	}
+	return Segment{}, false
+}
+
+// Locator attributes many timestamps against one snapshot of segments.
+type Locator struct {
+	segments []*Segment
+	now      time.Time
+}
+
+// Locator snapshots the current segments for repeated lookups.
+func (s *Store) Locator(now time.Time) Locator {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	snapshot := make([]*Segment, len(s.segments))
+	for i, seg := range s.segments {
+		copied := *seg
+		snapshot[i] = &copied
+	}
+	return Locator{segments: snapshot, now: now}
+}
+
+// Empty reports whether there are no segments to attribute against.
+func (l Locator) Empty() bool {
+	return len(l.segments) == 0
+}
+
+// At returns the innermost (latest-started) test running at t.
+func (l Locator) At(t time.Time) (Segment, bool) {
+	return locate(l.segments, t, l.now)
+}
+
+func locate(segments []*Segment, t, now time.Time) (Segment, bool) {
+	var best *Segment
+	for _, seg := range segments {
+		if seg.Contains(t, now) && (best == nil || seg.start.After(best.start)) {
+			best = seg
+		}
+	}
+	if best == nil {
+		return Segment{}, false
+	}
+	return *best, true
+}
+
+// Summarize counts retained segments by outcome.
+func (s *Store) Summarize() Summary {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sum := Summary{Tests: len(s.segments)}
+	for _, seg := range s.segments {
+		switch seg.Status {
+		case StatusRunning:
+			sum.Running++
+		case StatusPassed:
+			sum.Passed++
+		case StatusFailed:
+			sum.Failed++
+		case StatusSkipped:
+			sum.Skipped++
+		default:
+			sum.Other++
+		}
+	}
+	return sum
+}
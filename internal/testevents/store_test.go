@@ -0,0 +1,96 @@
+// Purpose: Tests reporter payload parsing, segment derivation, and timestamp attribution.
+// Docs: docs/features/feature/test-runner-events/index.md
+
+package testevents
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParse_ShapesAndNormalization(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+
+	single, err := Parse([]byte(`{"type":"test_finish","file":"cart.test.ts","suite":"cart","name":"adds item","status":"fail","error":{"message":"expected 2"},"timestamp":1791115200000}`), now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ev := single[0]
+	if ev.Type != TypeTestEnd || ev.TestID != "cart.test.ts > cart > adds item" || ev.Status != StatusFailed || ev.Timestamp.UnixMilli() != 1791115200000 {
+		t.Errorf("single = %+v", ev)
+	}
+
+	batch, err := Parse([]byte(`{"events":[{"type":"suite_start","name":"cart"},{"type":"test_end","test_id":"t1","error":{"message":"x"},"timestamp":"2026-10-16T11:59:00Z"}]}`), now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(batch) != 2 || batch[0].Suite != "cart" || !batch[0].Timestamp.Equal(now) || batch[1].Status != StatusFailed {
+		t.Errorf("batch = %+v", batch)
+	}
+
+	if arr, err := Parse([]byte(`[{"type":"run_start","runner":"vitest"}]`), now); err != nil || len(arr) != 1 {
+		t.Errorf("array = %+v, %v", arr, err)
+	}
+	for _, bad := range []string{``, `{"type":"test_start"}`, `{"type":"hook_start","name":"x"}`, `{"type":"run_start","timestamp":"yesterday"}`} {
+		if _, err := Parse([]byte(bad), now); err == nil {
+			t.Errorf("Parse(%q) should fail", bad)
+		}
+	}
+}
+
+func TestStore_SegmentsAndAttribution(t *testing.T) {
+	t.Parallel()
+	base := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	at := func(sec int) time.Time { return base.Add(time.Duration(sec) * time.Second) }
+
+	s := NewStore()
+	res := s.Ingest([]Event{
+		{Type: TypeTestStart, TestID: "outer", Timestamp: at(0)},
+		{Type: TypeTestStart, TestID: "inner", Timestamp: at(2)},
+		{Type: TypeTestEnd, TestID: "inner", Status: StatusPassed, Timestamp: at(4)},
+		{Type: TypeTestEnd, TestID: "outer", Status: StatusFailed, Error: &Failure{Message: "boom"}, Timestamp: at(6)},
+		{Type: TypeTestEnd, TestID: "orphan", Status: StatusPassed, DurationMs: 1000, Timestamp: at(10)},
+		{Type: TypeTestStart, TestID: "hung", RunID: "r1", Timestamp: at(12)},
+		{Type: TypeRunEnd, RunID: "r1", Timestamp: at(15)},
+	})
+	if res.Accepted != 7 || len(res.Started) != 3 || len(res.Ended) != 4 {
+		t.Fatalf("ingest result = %+v", res)
+	}
+
+	loc := s.Locator(at(20))
+	for sec, want := range map[int]string{1: "outer", 3: "inner", 5: "outer", 9: "orphan", 13: "hung", 8: "", 16: ""} {
+		seg, ok := loc.At(at(sec))
+		if got := seg.TestID; ok != (want != "") || got != want {
+			t.Errorf("At(+%ds) = %q, want %q", sec, got, want)
+		}
+	}
+
+	if seg, _ := s.Segment("outer"); seg.Status != StatusFailed || seg.DurationMs != 6000 || seg.Error.Message != "boom" {
+		t.Errorf("outer = %+v", seg)
+	}
+	if seg, _ := s.Segment("hung"); seg.Status != StatusInterrupted {
+		t.Errorf("run_end should interrupt running tests, got %+v", seg)
+	}
+	if sum := s.Summarize(); sum != (Summary{Tests: 4, Passed: 2, Failed: 1, Other: 1}) {
+		t.Errorf("summary = %+v", sum)
+	}
+}
+
+func TestStore_RetentionBounds(t *testing.T) {
+	t.Parallel()
+	s := NewStore()
+	now := time.Now()
+	for i := 0; i < MaxEvents+10; i++ {
+		s.Ingest([]Event{{Type: TypeTestStart, TestID: "t", Timestamp: now}})
+	}
+	if got := len(s.Segments()); got != MaxSegments {
+		t.Errorf("segments = %d, want %d", got, MaxSegments)
+	}
+	if got := len(s.Events(0)); got != MaxEvents {
+		t.Errorf("events = %d, want %d", got, MaxEvents)
+	}
+	if got := len(s.Events(5)); got != 5 {
+		t.Errorf("limited events = %d", got)
+	}
+}
@@ -0,0 +1,452 @@
+// Purpose: Aggregates the i18n probe's text nodes into missing-lang, untranslated-key, hardcoded-string, mixed-language, and RTL findings.
+// Why: Localization bugs only show on the rendered page; the probe collects raw text and the server classifies it in one place.
+// Docs: docs/features/feature/i18n-audit/index.md
+
+package analyze
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// DefaultI18nMarkers are the attributes that mark text as coming from an i18n framework:
+// i18next/jQuery (data-i18n), Angular/ngx (i18n), Vue (v-t), Fluent (data-l10n-id), and common variants.
+var DefaultI18nMarkers = []string{"data-i18n", "data-i18n-key", "data-translate", "data-l10n-id", "data-t", "i18n", "v-t"}
+
+// Finding categories reported by analyze(what="i18n").
+const (
+	I18nMissingLang     = "missing_lang"
+	I18nInvalidLang     = "invalid_lang"
+	I18nUntranslatedKey = "untranslated_key"
+	I18nHardcodedString = "hardcoded_string"
+	I18nMixedLanguage   = "mixed_language"
+	I18nRTLLayout       = "rtl_layout"
+)
+
+// maxI18nFindingsPerCategory caps listed findings; the summary still counts every one.
+const maxI18nFindingsPerCategory = 25
+
+// I18nArgs holds parsed arguments for analyze(what="i18n").
+type I18nArgs struct {
+	Markers []string `json:"markers,omitempty"`
+	TabID   int      `json:"tab_id,omitempty"`
+}
+
+// ParseI18nArgs parses i18n audit arguments, defaulting the marker attributes.
+func ParseI18nArgs(args json.RawMessage) (*I18nArgs, error) {
+	params, err := parseAnalyzeArgs[I18nArgs](args)
+	if err != nil {
+		return nil, err
+	}
+	markers := params.Markers[:0]
+	for _, m := range params.Markers {
+		if m = strings.TrimSpace(m); m != "" {
+			markers = append(markers, m)
+		}
+	}
+	params.Markers = markers
+	if len(params.Markers) == 0 {
+		params.Markers = DefaultI18nMarkers
+	}
+	return params, nil
+}
+
+// I18nTextNode is one element's visible text, or one translatable attribute, from the probe.
+type I18nTextNode struct {
+	Selector string `json:"selector"`
+	Tag      string `json:"tag"`
+	Text     string `json:"text"`
+	// Attr names the attribute the text came from (placeholder, title, alt, aria-label); empty for text content.
+	Attr string `json:"attr,omitempty"`
+	// Lang is the closest lang attribute, the element's effective language.
+	Lang string `json:"lang"`
+	// Direction is the computed CSS direction; DirAttr the closest dir attribute.
+	Direction string `json:"direction"`
+	DirAttr   string `json:"dir_attr"`
+	TextAlign string `json:"text_align"`
+	// Marked is true when the element or an ancestor carries a marker attribute.
+	Marked bool `json:"marked"`
+	// NoTranslate is true under translate="no" or class="notranslate".
+	NoTranslate bool `json:"no_translate"`
+}
+
+// I18nProbe is the raw page data collected by the extension.
+type I18nProbe struct {
+	URL           string         `json:"url"`
+	Title         string         `json:"title"`
+	HTMLLang      string         `json:"html_lang"`
+	HTMLDir       string         `json:"html_dir"`
+	BodyDirection string         `json:"body_direction"`
+	MarkerCount   int            `json:"marker_count"`
+	TextNodes     []I18nTextNode `json:"text_nodes"`
+	TotalNodes    int            `json:"total_nodes"`
+	Truncated     bool           `json:"truncated"`
+	Error         string         `json:"error,omitempty"`
+	Message       string         `json:"message,omitempty"`
+}
+
+// I18nFinding is one localization problem with a fix hint.
+type I18nFinding struct {
+	Category string `json:"category"`
+	Severity string `json:"severity"`
+	Selector string `json:"selector,omitempty"`
+	Text     string `json:"text,omitempty"`
+	Detail   string `json:"detail"`
+	Fix      string `json:"fix"`
+}
+
+// I18nPage describes the audited page.
+type I18nPage struct {
+	URL         string   `json:"url"`
+	Title       string   `json:"title,omitempty"`
+	Lang        string   `json:"lang"`
+	Dir         string   `json:"dir"`
+	Script      string   `json:"expected_script,omitempty"`
+	Markers     []string `json:"markers"`
+	MarkerCount int      `json:"marker_elements"`
+	TextNodes   int      `json:"text_nodes"`
+	Truncated   bool     `json:"truncated,omitempty"`
+}
+
+// I18nReport is the per-page result of analyze(what="i18n").
+type I18nReport struct {
+	Page     I18nPage       `json:"page"`
+	Summary  map[string]int `json:"summary"`
+	Total    int            `json:"total_findings"`
+	Findings []I18nFinding  `json:"findings"`
+	Notes    []string       `json:"notes,omitempty"`
+}
+
+var (
+	validLangTag = regexp.MustCompile(`^[A-Za-z]{2,3}(-[A-Za-z0-9]{1,8})*$`)
+	// dottedKey matches message keys such as home.title.missing or checkout.cta_label.
+	dottedKey = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_-]*(\.[A-Za-z0-9_-]+)+$`)
+	// constantKey matches SCREAMING_CASE keys such as HOME_TITLE.
+	constantKey = regexp.MustCompile(`^[A-Z][A-Z0-9]*(_[A-Z0-9]+)+$`)
+	// leftoverPlaceholder matches interpolation that was never filled in.
+	leftoverPlaceholder = regexp.MustCompile(`\{\{\s*[\w.$-]+\s*\}\}|\$\{[\w.]+\}|%\{\w+\}|\{[a-z][A-Za-z0-9_]*\}`)
+	missingTranslation  = regexp.MustCompile(`(?i)\[?missing\s+["']?[\w.-]+["']?\s+translation|translation missing|MISSING_TRANSLATION|__MISSING__`)
+)
+
+// nonKeySuffixes are last segments that make a dotted token a domain or file name, not a key.
+var nonKeySuffixes = map[string]bool{
+	"com": true, "org": true, "net": true, "io": true, "dev": true, "app": true, "co": true, "uk": true, "de": true,
+	"js": true, "ts": true, "css": true, "html": true, "json": true, "png": true, "jpg": true, "svg": true, "pdf": true,
+}
+
+// rtlLanguages are primary subtags written right to left.
+var rtlLanguages = map[string]bool{"ar": true, "he": true, "iw": true, "fa": true, "ur": true, "yi": true, "ps": true, "dv": true, "ckb": true, "sd": true, "ug": true}
+
+// Scripts detected in text. Japanese accepts Han and kana.
+const (
+	scriptLatin      = "Latin"
+	scriptCyrillic   = "Cyrillic"
+	scriptGreek      = "Greek"
+	scriptArabic     = "Arabic"
+	scriptHebrew     = "Hebrew"
+	scriptHan        = "Han"
+	scriptJapanese   = "Japanese"
+	scriptHangul     = "Hangul"
+	scriptDevanagari = "Devanagari"
+	scriptThai       = "Thai"
+)
+
+var scriptTables = []struct {
+	name  string
+	table *unicode.RangeTable
+}{
+	{scriptLatin, unicode.Latin}, {scriptCyrillic, unicode.Cyrillic}, {scriptGreek, unicode.Greek},
+	{scriptArabic, unicode.Arabic}, {scriptHebrew, unicode.Hebrew}, {scriptHan, unicode.Han},
+	{scriptJapanese, unicode.Hiragana}, {scriptJapanese, unicode.Katakana}, {scriptHangul, unicode.Hangul},
+	{scriptDevanagari, unicode.Devanagari}, {scriptThai, unicode.Thai},
+}
+
+var langScripts = map[string]string{
+	"ar": scriptArabic, "fa": scriptArabic, "ur": scriptArabic, "ps": scriptArabic, "ckb": scriptArabic, "sd": scriptArabic, "ug": scriptArabic,
+	"he": scriptHebrew, "iw": scriptHebrew, "yi": scriptHebrew,
+	"ru": scriptCyrillic, "uk": scriptCyrillic, "bg": scriptCyrillic, "be": scriptCyrillic, "mk": scriptCyrillic, "kk": scriptCyrillic, "mn": scriptCyrillic,
+	"el": scriptGreek, "zh": scriptHan, "ja": scriptJapanese, "ko": scriptHangul,
+	"hi": scriptDevanagari, "mr": scriptDevanagari, "ne": scriptDevanagari, "th": scriptThai,
+	"en": scriptLatin, "fr": scriptLatin, "de": scriptLatin, "es": scriptLatin, "it": scriptLatin, "pt": scriptLatin,
+	"nl": scriptLatin, "sv": scriptLatin, "da": scriptLatin, "nb": scriptLatin, "no": scriptLatin, "fi": scriptLatin,
+	"pl": scriptLatin, "cs": scriptLatin, "sk": scriptLatin, "hu": scriptLatin, "ro": scriptLatin, "tr": scriptLatin,
+	"id": scriptLatin, "ms": scriptLatin, "vi": scriptLatin, "hr": scriptLatin, "sl": scriptLatin, "ca": scriptLatin,
+}
+
+var scriptSubtags = map[string]string{
+	"latn": scriptLatin, "cyrl": scriptCyrillic, "grek": scriptGreek, "arab": scriptArabic, "hebr": scriptHebrew,
+	"hans": scriptHan, "hant": scriptHan, "jpan": scriptJapanese, "kore": scriptHangul, "deva": scriptDevanagari, "thai": scriptThai,
+}
+
+// stopwords tell Latin-script languages apart; a text needs two hits to count.
+var stopwords = map[string][]string{
+	"en": {"the", "and", "you", "your", "with", "for", "this", "that", "are", "is", "of", "to"},
+	"fr": {"le", "la", "les", "et", "vous", "votre", "avec", "pour", "est", "des", "une", "du"},
+	"de": {"der", "die", "das", "und", "sie", "ihr", "mit", "für", "ist", "nicht", "ein", "eine"},
+	"es": {"el", "los", "las", "y", "usted", "su", "con", "para", "es", "una", "del", "por"},
+	"it": {"il", "lo", "gli", "e", "con", "per", "è", "una", "del", "della", "che", "non"},
+	"pt": {"o", "os", "as", "e", "você", "seu", "com", "para", "é", "uma", "do", "da"},
+	"nl": {"de", "het", "en", "je", "uw", "met", "voor", "is", "een", "van", "niet", "zijn"},
+}
+
+// AnalyzeI18n classifies the probe's page and text nodes into localization findings.
+func AnalyzeI18n(probe I18nProbe, markers []string) I18nReport {
+	lang := strings.TrimSpace(probe.HTMLLang)
+	dir := probe.HTMLDir
+	if dir == "" {
+		dir = probe.BodyDirection
+	}
+	report := I18nReport{
+		Page: I18nPage{
+			URL: probe.URL, Title: probe.Title, Lang: lang, Dir: dir, Script: expectedScript(lang),
+			Markers: markers, MarkerCount: probe.MarkerCount, TextNodes: probe.TotalNodes, Truncated: probe.Truncated,
+		},
+		Summary: map[string]int{},
+	}
+	add := func(f I18nFinding) {
+		report.Summary[f.Category]++
+		report.Total++
+		if report.Summary[f.Category] <= maxI18nFindingsPerCategory {
+			report.Findings = append(report.Findings, f)
+		}
+	}
+
+	switch {
+	case lang == "":
+		add(I18nFinding{Category: I18nMissingLang, Severity: "high", Selector: "html",
+			Detail: "The <html> element has no lang attribute, so screen readers, hyphenation, and translation tools guess the language",
+			Fix:    `Set <html lang="en"> (or the page's language) from the active locale`})
+	case !validLangTag.MatchString(lang):
+		add(I18nFinding{Category: I18nInvalidLang, Severity: "medium", Selector: "html", Text: lang,
+			Detail: fmt.Sprintf("lang=%q is not a BCP 47 language tag", lang),
+			Fix:    fmt.Sprintf("Use a tag such as %q", strings.ReplaceAll(lang, "_", "-"))})
+	}
+	if primary := primarySubtag(lang); rtlLanguages[primary] && dir != "rtl" {
+		add(I18nFinding{Category: I18nRTLLayout, Severity: "high", Selector: "html", Text: lang,
+			Detail: fmt.Sprintf("The page language %q is written right to left but the document renders left to right", lang),
+			Fix:    fmt.Sprintf(`Set <html lang="%s" dir="rtl">`, lang)})
+	}
+
+	hardcoded := probe.MarkerCount > 0
+	if !hardcoded {
+		report.Notes = append(report.Notes, fmt.Sprintf("No elements carry an i18n marker (%s), so hardcoded-string detection was skipped. Pass markers with the attribute your framework renders.", strings.Join(markers, ", ")))
+	}
+	rtlSeen := map[string]bool{}
+	for _, n := range probe.TextNodes {
+		text := strings.TrimSpace(n.Text)
+		if text == "" {
+			continue
+		}
+		where := n.Selector
+		if n.Attr != "" {
+			where += "[" + n.Attr + "]"
+		}
+		if key, ok := untranslatedKey(text); ok {
+			add(I18nFinding{Category: I18nUntranslatedKey, Severity: "high", Selector: where, Text: clip(text),
+				Detail: "Rendered text looks like " + key,
+				Fix:    "Add the key to the active locale's messages, or fix the lookup that fell back to the key"})
+			continue
+		}
+		if hardcoded && !n.Marked && !n.NoTranslate && translatable(text) {
+			add(I18nFinding{Category: I18nHardcodedString, Severity: "medium", Selector: where, Text: clip(text),
+				Detail: "Text is not wrapped by the i18n framework",
+				Fix:    "Move the string into the message catalog and render it through the framework's t() or marker attribute; mark brand names with translate=\"no\""})
+		}
+		effective := n.Lang
+		if effective == "" {
+			effective = lang
+		}
+		if detail, ok := mixedLanguage(text, effective); ok {
+			add(I18nFinding{Category: I18nMixedLanguage, Severity: "medium", Selector: where, Text: clip(text),
+				Detail: detail,
+				Fix:    "Translate the string for this locale, or mark the element with its own lang attribute if the language switch is intended"})
+		}
+		if n.Attr == "" && !rtlSeen[n.Selector] {
+			if detail, fix, ok := rtlIssue(text, n); ok {
+				rtlSeen[n.Selector] = true
+				add(I18nFinding{Category: I18nRTLLayout, Severity: "medium", Selector: where, Text: clip(text), Detail: detail, Fix: fix})
+			}
+		}
+	}
+	if probe.Truncated {
+		report.Notes = append(report.Notes, fmt.Sprintf("Only the first %d of %d text nodes were checked.", len(probe.TextNodes), probe.TotalNodes))
+	}
+	if report.Findings == nil {
+		report.Findings = []I18nFinding{}
+	}
+	return report
+}
+
+// untranslatedKey reports text that is a raw message key, a missing-translation marker,
+// or contains interpolation that was never filled.
+func untranslatedKey(text string) (string, bool) {
+	if missingTranslation.MatchString(text) {
+		return "a missing-translation marker", true
+	}
+	if m := leftoverPlaceholder.FindString(text); m != "" {
+		return "an unfilled placeholder " + m, true
+	}
+	if strings.ContainsAny(text, " \t\n") {
+		return "", false
+	}
+	if constantKey.MatchString(text) {
+		return "a message key", true
+	}
+	if dottedKey.MatchString(text) {
+		segments := strings.Split(text, ".")
+		last := strings.ToLower(segments[len(segments)-1])
+		if nonKeySuffixes[last] {
+			return "", false
+		}
+		for _, s := range segments {
+			// Version numbers and decimals (v1.2.3) have segments starting with a digit.
+			if !unicode.IsLetter(rune(s[0])) {
+				return "", false
+			}
+		}
+		if len(segments) > 2 || (len(segments[0]) >= 3 && len(last) >= 3 && strings.ToLower(text) == text) {
+			return "a message key", true
+		}
+	}
+	return "", false
+}
+
+// translatable reports whether text contains words worth translating (not numbers, symbols, or one letter).
+func translatable(text string) bool {
+	letters := 0
+	for _, r := range text {
+		if unicode.IsLetter(r) {
+			letters++
+		}
+	}
+	return letters >= 2
+}
+
+// mixedLanguage reports text whose script, or for Latin-script languages whose common
+// words, do not match the effective language.
+func mixedLanguage(text, lang string) (string, bool) {
+	want := expectedScript(lang)
+	if want == "" {
+		return "", false
+	}
+	got, words := dominantScript(text)
+	if got == "" {
+		return "", false
+	}
+	if got != want && !(want == scriptJapanese && got == scriptHan) {
+		// Short Latin runs (brand names, product codes) are normal on non-Latin pages.
+		if got == scriptLatin && words < 3 {
+			return "", false
+		}
+		return fmt.Sprintf("%s text on a page whose language %q is written in %s", got, lang, want), true
+	}
+	if want != scriptLatin || words < 4 {
+		return "", false
+	}
+	primary := primarySubtag(lang)
+	if _, known := stopwords[primary]; !known {
+		return "", false
+	}
+	guess, score := guessLatinLanguage(text)
+	if guess != "" && guess != primary && score >= 2 && stopwordHits(text, primary) == 0 {
+		return fmt.Sprintf("Text reads as %q on a page whose language is %q", guess, lang), true
+	}
+	return "", false
+}
+
+// rtlIssue reports right-to-left text laid out left to right, and physical alignment in RTL context.
+func rtlIssue(text string, n I18nTextNode) (detail, fix string, ok bool) {
+	script, _ := dominantScript(text)
+	rtlText := script == scriptArabic || script == scriptHebrew
+	if rtlText && n.Direction == "ltr" && n.DirAttr == "" {
+		return script + " text is laid out left to right", `Add dir="rtl" (or dir="auto" for user content) to the element, or wrap the text in <bdi>`, true
+	}
+	if n.Direction == "rtl" && n.TextAlign == "left" {
+		return "text-align: left in a right-to-left context pins the text to the wrong edge", "Use text-align: start (and logical properties such as margin-inline-start) so the layout mirrors", true
+	}
+	return "", "", false
+}
+
+// dominantScript returns the script of at least 60% of the letters, and the word count.
+func dominantScript(text string) (string, int) {
+	counts := map[string]int{}
+	letters := 0
+	for _, r := range text {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		letters++
+		for _, s := range scriptTables {
+			if unicode.Is(s.table, r) {
+				counts[s.name]++
+				break
+			}
+		}
+	}
+	words := len(strings.Fields(text))
+	if letters < 3 {
+		return "", words
+	}
+	for name, c := range counts {
+		if c*10 >= letters*6 {
+			return name, words
+		}
+	}
+	return "", words
+}
+
+// guessLatinLanguage returns the language with the most stopword hits, and the hit count.
+func guessLatinLanguage(text string) (string, int) {
+	best, bestScore := "", 0
+	langs := make([]string, 0, len(stopwords))
+	for l := range stopwords {
+		langs = append(langs, l)
+	}
+	slices.Sort(langs)
+	for _, l := range langs {
+		if s := stopwordHits(text, l); s > bestScore {
+			best, bestScore = l, s
+		}
+	}
+	return best, bestScore
+}
+
+func stopwordHits(text, lang string) int {
+	hits := 0
+	for _, w := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool { return !unicode.IsLetter(r) }) {
+		if slices.Contains(stopwords[lang], w) {
+			hits++
+		}
+	}
+	return hits
+}
+
+// expectedScript maps a language tag to the script its text should use, honouring a script subtag.
+func expectedScript(lang string) string {
+	parts := strings.Split(strings.ToLower(strings.ReplaceAll(lang, "_", "-")), "-")
+	for _, p := range parts[1:] {
+		if s, ok := scriptSubtags[p]; ok {
+			return s
+		}
+	}
+	return langScripts[parts[0]]
+}
+
+func primarySubtag(lang string) string {
+	lang = strings.ToLower(strings.ReplaceAll(lang, "_", "-"))
+	primary, _, _ := strings.Cut(lang, "-")
+	return primary
+}
+
+// clip shortens finding text to keep reports compact.
+func clip(text string) string {
+	const maxRunes = 120
+	if r := []rune(text); len(r) > maxRunes {
+		return string(r[:maxRunes]) + "..."
+	}
+	return text
+}
@@ -0,0 +1,119 @@
+// Purpose: Tests i18n probe classification into lang, untranslated-key, hardcoded, mixed-language, and RTL findings.
+// Docs: docs/features/feature/i18n-audit/index.md
+
+package analyze
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func i18nFindings(report I18nReport, category string) []I18nFinding {
+	var out []I18nFinding
+	for _, f := range report.Findings {
+		if f.Category == category {
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+func TestParseI18nArgs_DefaultsMarkers(t *testing.T) {
+	t.Parallel()
+	parsed, err := ParseI18nArgs(json.RawMessage(`{"markers":[" ", "data-msg"]}`))
+	if err != nil || len(parsed.Markers) != 1 || parsed.Markers[0] != "data-msg" {
+		t.Fatalf("ParseI18nArgs = %+v, %v", parsed, err)
+	}
+	parsed, _ = ParseI18nArgs(nil)
+	if len(parsed.Markers) != len(DefaultI18nMarkers) {
+		t.Fatalf("default markers = %v", parsed.Markers)
+	}
+}
+
+func TestUntranslatedKey(t *testing.T) {
+	t.Parallel()
+	for text, want := range map[string]bool{
+		"home.title.missing":               true,
+		"checkout.cta_label":               true,
+		"CART_EMPTY_MESSAGE":               true,
+		"Hello {{ user.name }}":            true,
+		"Total: ${amount}":                 true,
+		"[missing \"en.nav\" translation]": true,
+		"example.com":                      false,
+		"Node.js":                          false,
+		"app.bundle.js":                    false,
+		"v1.2.3":                           false,
+		"Save changes":                     false,
+		"e.g.":                             false,
+	} {
+		if _, got := untranslatedKey(text); got != want {
+			t.Errorf("untranslatedKey(%q) = %v, want %v", text, got, want)
+		}
+	}
+}
+
+func TestAnalyzeI18n_ClassifiesPage(t *testing.T) {
+	t.Parallel()
+	probe := I18nProbe{
+		URL: "https://shop.test/de/", HTMLLang: "de", BodyDirection: "ltr", MarkerCount: 3,
+		TextNodes: []I18nTextNode{
+			{Selector: "h1", Text: "Willkommen im Laden", Lang: "de", Direction: "ltr", Marked: true},
+			{Selector: "p.promo", Text: "home.banner.missing", Lang: "de", Direction: "ltr", Marked: true},
+			{Selector: "button", Text: "Add to the cart and check out", Lang: "de", Direction: "ltr"},
+			{Selector: "input", Attr: "placeholder", Text: "Suchen", Lang: "de", Direction: "ltr", Marked: true},
+			{Selector: "span.brand", Text: "Kaboom", Lang: "de", Direction: "ltr", NoTranslate: true},
+			{Selector: "blockquote", Text: "The quick brown fox and the lazy dog", Lang: "en", Direction: "ltr", Marked: true},
+			{Selector: "p.review", Text: "منتج رائع جدا", Lang: "de", Direction: "ltr", Marked: true},
+			{Selector: "span.price", Text: "19,99 €", Lang: "de", Direction: "ltr"},
+		},
+		TotalNodes: 8,
+	}
+	report := AnalyzeI18n(probe, DefaultI18nMarkers)
+
+	if got := i18nFindings(report, I18nUntranslatedKey); len(got) != 1 || got[0].Selector != "p.promo" {
+		t.Errorf("untranslated = %+v", got)
+	}
+	if got := i18nFindings(report, I18nHardcodedString); len(got) != 1 || got[0].Selector != "button" {
+		t.Errorf("hardcoded = %+v, want only the unmarked button (brand is translate=no, price has no words)", got)
+	}
+	mixed := i18nFindings(report, I18nMixedLanguage)
+	if len(mixed) != 2 || mixed[0].Selector != "button" || mixed[1].Selector != "p.review" {
+		t.Errorf("mixed = %+v, want English button and Arabic review; the lang=en quote is intended", mixed)
+	}
+	if got := i18nFindings(report, I18nRTLLayout); len(got) != 1 || got[0].Selector != "p.review" {
+		t.Errorf("rtl = %+v", got)
+	}
+	if len(i18nFindings(report, I18nMissingLang)) != 0 || report.Page.Script != "Latin" {
+		t.Errorf("page = %+v", report.Page)
+	}
+	if report.Total != len(report.Findings) || report.Summary[I18nMixedLanguage] != 2 {
+		t.Errorf("summary = %v total = %d", report.Summary, report.Total)
+	}
+}
+
+func TestAnalyzeI18n_LangAndRTLPage(t *testing.T) {
+	t.Parallel()
+	missing := AnalyzeI18n(I18nProbe{TextNodes: []I18nTextNode{{Selector: "p", Text: "Plain text here"}}}, DefaultI18nMarkers)
+	if got := i18nFindings(missing, I18nMissingLang); len(got) != 1 {
+		t.Errorf("missing lang = %+v", missing.Findings)
+	}
+	if len(missing.Notes) == 0 || len(i18nFindings(missing, I18nHardcodedString)) != 0 {
+		t.Errorf("without markers hardcoded detection should be skipped with a note: %+v", missing)
+	}
+
+	if got := i18nFindings(AnalyzeI18n(I18nProbe{HTMLLang: "en_US"}, nil), I18nInvalidLang); len(got) != 1 || got[0].Fix != `Use a tag such as "en-US"` {
+		t.Errorf("invalid lang = %+v", got)
+	}
+
+	rtl := AnalyzeI18n(I18nProbe{
+		HTMLLang: "ar", BodyDirection: "ltr",
+		TextNodes: []I18nTextNode{
+			{Selector: "p", Text: "مرحبا بكم في المتجر", Direction: "rtl", DirAttr: "rtl", TextAlign: "left"},
+			{Selector: "p", Text: "تسوق الآن", Direction: "rtl", DirAttr: "rtl", TextAlign: "left"},
+		},
+	}, nil)
+	got := i18nFindings(rtl, I18nRTLLayout)
+	if len(got) != 2 || got[0].Selector != "html" || got[0].Severity != "high" || got[1].Selector != "p" {
+		t.Errorf("rtl = %+v, want the page dir and one deduplicated text-align finding", got)
+	}
+}
@@ -0,0 +1,466 @@
+// Purpose: Aggregates raw layout probe data into computed styles, box model, stacking, clipping, and visibility findings.
+// Why: Explains why an element looks wrong (hidden, clipped, stacked under something) instead of only returning raw CSS.
+// Docs: docs/features/feature/layout-inspection/index.md
+
+package analyze
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// DefaultStyleProperties is the computed-style subset reported when no properties filter is given.
+var DefaultStyleProperties = []string{
+	"display", "position", "z-index", "visibility", "opacity",
+	"width", "height", "box-sizing", "overflow-x", "overflow-y",
+	"transform", "color", "background-color", "font-size", "line-height", "pointer-events",
+}
+
+// StylesArgs holds parsed arguments for analyze(what="styles").
+type StylesArgs struct {
+	Selector   string   `json:"selector"`
+	Properties []string `json:"properties,omitempty"`
+	TabID      int      `json:"tab_id,omitempty"`
+}
+
+// ParseStylesArgs validates and parses layout inspection arguments.
+func ParseStylesArgs(args json.RawMessage) (*StylesArgs, error) {
+	params, err := parseAnalyzeArgs[StylesArgs](args)
+	if err != nil {
+		return nil, err
+	}
+	params.Selector = strings.TrimSpace(params.Selector)
+	if params.Selector == "" {
+		return nil, errors.New("required parameter 'selector' is missing")
+	}
+	return params, nil
+}
+
+// LayoutRect is a viewport-relative rectangle in CSS pixels.
+type LayoutRect struct {
+	X      float64 `json:"x"`
+	Y      float64 `json:"y"`
+	Width  float64 `json:"width"`
+	Height float64 `json:"height"`
+}
+
+func (r LayoutRect) right() float64  { return r.X + r.Width }
+func (r LayoutRect) bottom() float64 { return r.Y + r.Height }
+
+// LayoutNode is one element reported by the extension probe: the target or one of its ancestors.
+type LayoutNode struct {
+	Tag      string            `json:"tag"`
+	Selector string            `json:"selector"`
+	Styles   map[string]string `json:"styles"`
+	Rect     LayoutRect        `json:"rect"`
+}
+
+func (n LayoutNode) style(prop string) string { return strings.TrimSpace(n.Styles[prop]) }
+
+// LayoutViewport describes the visible viewport at probe time.
+type LayoutViewport struct {
+	Width   float64 `json:"width"`
+	Height  float64 `json:"height"`
+	ScrollX float64 `json:"scroll_x"`
+	ScrollY float64 `json:"scroll_y"`
+}
+
+// LayoutProbe is the raw payload the extension collects for a styles query.
+// Ancestors are ordered nearest first and end at the document element.
+type LayoutProbe struct {
+	Selector   string         `json:"selector"`
+	MatchCount int            `json:"match_count"`
+	Viewport   LayoutViewport `json:"viewport"`
+	Element    *LayoutNode    `json:"element"`
+	Ancestors  []LayoutNode   `json:"ancestors"`
+	Error      string         `json:"error,omitempty"`
+	Message    string         `json:"message,omitempty"`
+}
+
+// BoxEdges holds per-side widths in CSS pixels.
+type BoxEdges struct {
+	Top    float64 `json:"top"`
+	Right  float64 `json:"right"`
+	Bottom float64 `json:"bottom"`
+	Left   float64 `json:"left"`
+}
+
+// BoxModel describes the element's content, padding, border, and margin boxes.
+type BoxModel struct {
+	Content LayoutRect `json:"content"`
+	Padding LayoutRect `json:"padding"`
+	Border  LayoutRect `json:"border"`
+	Margin  LayoutRect `json:"margin"`
+	Edges   struct {
+		Margin  BoxEdges `json:"margin"`
+		Border  BoxEdges `json:"border"`
+		Padding BoxEdges `json:"padding"`
+	} `json:"edges"`
+}
+
+// StackingAncestor is an ancestor that establishes a stacking context.
+type StackingAncestor struct {
+	Selector string   `json:"selector"`
+	ZIndex   string   `json:"z_index"`
+	Reasons  []string `json:"reasons"`
+}
+
+// StackingInfo explains which stacking context the element paints in.
+type StackingInfo struct {
+	CreatesContext bool               `json:"creates_context"`
+	Reasons        []string           `json:"reasons,omitempty"`
+	ZIndex         string             `json:"z_index"`
+	Ancestors      []StackingAncestor `json:"ancestor_contexts"`
+}
+
+// ClippingAncestor is an ancestor whose overflow or clip-path can cut off the element.
+type ClippingAncestor struct {
+	Selector  string     `json:"selector"`
+	OverflowX string     `json:"overflow_x"`
+	OverflowY string     `json:"overflow_y"`
+	ClipPath  string     `json:"clip_path,omitempty"`
+	Rect      LayoutRect `json:"rect"`
+	// Effect is "none", "partial", or "full" depending on how much of the element falls outside.
+	Effect string `json:"effect"`
+}
+
+// VisibilityReason is one cause that keeps the element from being seen.
+type VisibilityReason struct {
+	Code     string `json:"code"`
+	Selector string `json:"selector"`
+	Detail   string `json:"detail"`
+}
+
+// VisibilityReport summarizes whether the element is visible and why not.
+type VisibilityReport struct {
+	Visible bool               `json:"visible"`
+	Reasons []VisibilityReason `json:"reasons"`
+}
+
+// StylesReport is the aggregated response for analyze(what="styles").
+type StylesReport struct {
+	Selector          string             `json:"selector"`
+	MatchCount        int                `json:"match_count"`
+	Element           string             `json:"element"`
+	Tag               string             `json:"tag"`
+	ComputedStyles    map[string]string  `json:"computed_styles"`
+	BoxModel          BoxModel           `json:"box_model"`
+	StackingContext   StackingInfo       `json:"stacking_context"`
+	ClippingAncestors []ClippingAncestor `json:"clipping_ancestors"`
+	Visibility        VisibilityReport   `json:"visibility"`
+}
+
+// AnalyzeLayout builds a StylesReport from a probe. The probe must carry an element.
+func AnalyzeLayout(probe LayoutProbe, properties []string) StylesReport {
+	el := *probe.Element
+	clipping := clippingAncestors(el, probe.Ancestors)
+	return StylesReport{
+		Selector:          probe.Selector,
+		MatchCount:        probe.MatchCount,
+		Element:           el.Selector,
+		Tag:               el.Tag,
+		ComputedStyles:    pickStyles(el.Styles, properties),
+		BoxModel:          boxModel(el),
+		StackingContext:   stackingInfo(el, probe.Ancestors),
+		ClippingAncestors: clipping,
+		Visibility:        visibility(el, probe.Ancestors, probe.Viewport, clipping),
+	}
+}
+
+func pickStyles(styles map[string]string, properties []string) map[string]string {
+	if len(properties) == 0 {
+		properties = DefaultStyleProperties
+	}
+	out := make(map[string]string, len(properties))
+	for _, prop := range properties {
+		if v, ok := styles[prop]; ok {
+			out[prop] = v
+		}
+	}
+	return out
+}
+
+// cssNumber parses a computed number or px length such as "12px"; anything else counts as zero.
+func cssNumber(v string) float64 {
+	n, err := strconv.ParseFloat(strings.TrimSuffix(strings.TrimSpace(v), "px"), 64)
+	if err != nil {
+		return 0
+	}
+	return n
+}
+
+func edges(n LayoutNode, prefix, suffix string) BoxEdges {
+	return BoxEdges{
+		Top:    cssNumber(n.Styles[prefix+"-top"+suffix]),
+		Right:  cssNumber(n.Styles[prefix+"-right"+suffix]),
+		Bottom: cssNumber(n.Styles[prefix+"-bottom"+suffix]),
+		Left:   cssNumber(n.Styles[prefix+"-left"+suffix]),
+	}
+}
+
+func inset(r LayoutRect, e BoxEdges) LayoutRect {
+	return LayoutRect{
+		X:      r.X + e.Left,
+		Y:      r.Y + e.Top,
+		Width:  max(0, r.Width-e.Left-e.Right),
+		Height: max(0, r.Height-e.Top-e.Bottom),
+	}
+}
+
+// boxModel derives the four CSS boxes from the border-box rect and computed edge widths.
+func boxModel(el LayoutNode) BoxModel {
+	var box BoxModel
+	box.Edges.Margin = edges(el, "margin", "")
+	box.Edges.Border = edges(el, "border", "-width")
+	box.Edges.Padding = edges(el, "padding", "")
+	box.Border = el.Rect
+	box.Padding = inset(box.Border, box.Edges.Border)
+	box.Content = inset(box.Padding, box.Edges.Padding)
+	m := box.Edges.Margin
+	box.Margin = LayoutRect{
+		X:      el.Rect.X - m.Left,
+		Y:      el.Rect.Y - m.Top,
+		Width:  el.Rect.Width + m.Left + m.Right,
+		Height: el.Rect.Height + m.Top + m.Bottom,
+	}
+	return box
+}
+
+// willChangeContextProps are will-change values that force a stacking context.
+var willChangeContextProps = []string{"transform", "opacity", "filter", "perspective", "clip-path", "mask", "isolation", "mix-blend-mode", "z-index", "position"}
+
+// stackingReasons lists why a node establishes a stacking context. parentDisplay is
+// the parent's computed display, needed for z-index on flex and grid items.
+func stackingReasons(n LayoutNode, parentDisplay string) []string {
+	if n.Tag == "html" {
+		return []string{"root element"}
+	}
+	var reasons []string
+	position := n.style("position")
+	zIndex := n.style("z-index")
+	switch {
+	case position == "fixed" || position == "sticky":
+		reasons = append(reasons, "position: "+position)
+	case zIndex != "" && zIndex != "auto" && position != "" && position != "static":
+		reasons = append(reasons, fmt.Sprintf("z-index: %s on position: %s", zIndex, position))
+	case zIndex != "" && zIndex != "auto" && isFlexOrGrid(parentDisplay):
+		reasons = append(reasons, fmt.Sprintf("z-index: %s on a %s item", zIndex, parentDisplay))
+	}
+	if op := n.style("opacity"); op != "" && cssNumber(op) < 1 {
+		reasons = append(reasons, "opacity: "+op)
+	}
+	for _, prop := range []string{"transform", "filter", "backdrop-filter", "perspective", "clip-path", "mask-image"} {
+		if v := n.style(prop); v != "" && v != "none" {
+			reasons = append(reasons, prop+": "+v)
+		}
+	}
+	if v := n.style("mix-blend-mode"); v != "" && v != "normal" {
+		reasons = append(reasons, "mix-blend-mode: "+v)
+	}
+	if n.style("isolation") == "isolate" {
+		reasons = append(reasons, "isolation: isolate")
+	}
+	if v := n.style("will-change"); v != "" && v != "auto" {
+		for _, prop := range willChangeContextProps {
+			if strings.Contains(v, prop) {
+				reasons = append(reasons, "will-change: "+v)
+				break
+			}
+		}
+	}
+	if v := n.style("contain"); containsAny(v, "layout", "paint", "strict", "content") {
+		reasons = append(reasons, "contain: "+v)
+	}
+	if v := n.style("container-type"); v == "size" || v == "inline-size" {
+		reasons = append(reasons, "container-type: "+v)
+	}
+	return reasons
+}
+
+func isFlexOrGrid(display string) bool {
+	return containsAny(display, "flex", "grid")
+}
+
+func containsAny(s string, words ...string) bool {
+	for _, w := range words {
+		if strings.Contains(s, w) {
+			return true
+		}
+	}
+	return false
+}
+
+func parentDisplay(ancestors []LayoutNode, i int) string {
+	if i < len(ancestors) {
+		return ancestors[i].style("display")
+	}
+	return ""
+}
+
+func stackingInfo(el LayoutNode, ancestors []LayoutNode) StackingInfo {
+	reasons := stackingReasons(el, parentDisplay(ancestors, 0))
+	info := StackingInfo{
+		CreatesContext: len(reasons) > 0,
+		Reasons:        reasons,
+		ZIndex:         el.style("z-index"),
+		Ancestors:      []StackingAncestor{},
+	}
+	for i, a := range ancestors {
+		if r := stackingReasons(a, parentDisplay(ancestors, i+1)); len(r) > 0 {
+			info.Ancestors = append(info.Ancestors, StackingAncestor{Selector: a.Selector, ZIndex: a.style("z-index"), Reasons: r})
+		}
+	}
+	return info
+}
+
+func clips(overflow string) bool {
+	return overflow != "" && overflow != "visible"
+}
+
+// clippingAncestors finds ancestors that clip overflow and measures their effect.
+// Fixed-position elements escape overflow clipping unless an ancestor is a containing
+// block for them (transform, filter, or perspective).
+func clippingAncestors(el LayoutNode, ancestors []LayoutNode) []ClippingAncestor {
+	out := []ClippingAncestor{}
+	fixed := el.style("position") == "fixed"
+	for _, a := range ancestors {
+		if a.Tag == "html" || a.Tag == "body" {
+			continue
+		}
+		ox, oy := a.style("overflow-x"), a.style("overflow-y")
+		clipPath := a.style("clip-path")
+		if clipPath == "none" {
+			clipPath = ""
+		}
+		if !clips(ox) && !clips(oy) && clipPath == "" {
+			continue
+		}
+		if fixed && !containsFixed(a) {
+			continue
+		}
+		out = append(out, ClippingAncestor{
+			Selector:  a.Selector,
+			OverflowX: ox,
+			OverflowY: oy,
+			ClipPath:  clipPath,
+			Rect:      a.Rect,
+			Effect:    clipEffect(el.Rect, a.Rect, clips(ox) || clipPath != "", clips(oy) || clipPath != ""),
+		})
+	}
+	return out
+}
+
+func containsFixed(a LayoutNode) bool {
+	for _, prop := range []string{"transform", "filter", "perspective"} {
+		if v := a.style(prop); v != "" && v != "none" {
+			return true
+		}
+	}
+	return false
+}
+
+// clipEffect compares the element against the clip rect on the clipped axes.
+func clipEffect(el, clip LayoutRect, clipX, clipY bool) string {
+	outside := func(lo, hi, clo, chi float64) (full, partial bool) {
+		return hi <= clo || lo >= chi, lo < clo || hi > chi
+	}
+	var full, partial bool
+	if clipX {
+		f, p := outside(el.X, el.right(), clip.X, clip.right())
+		full, partial = full || f, partial || p
+	}
+	if clipY {
+		f, p := outside(el.Y, el.bottom(), clip.Y, clip.bottom())
+		full, partial = full || f, partial || p
+	}
+	switch {
+	case full && el.Width > 0 && el.Height > 0:
+		return "full"
+	case partial:
+		return "partial"
+	default:
+		return "none"
+	}
+}
+
+// visibility collects every reason the element cannot be seen, outermost cause first
+// for inherited properties.
+func visibility(el LayoutNode, ancestors []LayoutNode, vp LayoutViewport, clipping []ClippingAncestor) VisibilityReport {
+	reasons := []VisibilityReason{}
+	chain := append([]LayoutNode{el}, ancestors...)
+
+	displayNone := false
+	for _, n := range chain {
+		if n.style("display") == "none" {
+			displayNone = true
+			reasons = append(reasons, VisibilityReason{Code: "display_none", Selector: n.Selector, Detail: "display: none removes it and all descendants from layout"})
+		}
+	}
+
+	if v := el.style("visibility"); v == "hidden" || v == "collapse" {
+		source := el.Selector
+		for _, a := range ancestors {
+			if av := a.style("visibility"); av != "hidden" && av != "collapse" {
+				break
+			}
+			source = a.Selector
+		}
+		detail := "visibility: " + v
+		if source != el.Selector {
+			detail += " inherited from " + source
+		}
+		reasons = append(reasons, VisibilityReason{Code: "visibility_hidden", Selector: source, Detail: detail})
+	}
+
+	for _, n := range chain {
+		if op := n.style("opacity"); op != "" && cssNumber(op) == 0 {
+			reasons = append(reasons, VisibilityReason{Code: "opacity_zero", Selector: n.Selector, Detail: "opacity: 0"})
+		}
+	}
+
+	if displayNone {
+		return VisibilityReport{Visible: false, Reasons: reasons}
+	}
+
+	r := el.Rect
+	if r.Width == 0 || r.Height == 0 {
+		reasons = append(reasons, VisibilityReason{Code: "zero_size", Selector: el.Selector, Detail: fmt.Sprintf("rendered size is %gx%g", r.Width, r.Height)})
+	} else if detail := offScreenDetail(r, vp); detail != "" {
+		reasons = append(reasons, VisibilityReason{Code: "off_screen", Selector: el.Selector, Detail: detail})
+	}
+
+	for _, c := range clipping {
+		if c.Effect == "full" {
+			reasons = append(reasons, VisibilityReason{Code: "clipped", Selector: c.Selector, Detail: "element lies entirely outside this ancestor's clip area"})
+		}
+	}
+	return VisibilityReport{Visible: len(reasons) == 0, Reasons: reasons}
+}
+
+// offScreenDetail reports where the element sits when it is entirely outside the viewport.
+func offScreenDetail(r LayoutRect, vp LayoutViewport) string {
+	if vp.Width <= 0 || vp.Height <= 0 {
+		return ""
+	}
+	var where string
+	switch {
+	case r.bottom() <= 0:
+		where = "above the viewport"
+	case r.Y >= vp.Height:
+		where = "below the viewport"
+	case r.right() <= 0:
+		where = "left of the viewport"
+	case r.X >= vp.Width:
+		where = "right of the viewport"
+	default:
+		return ""
+	}
+	// Negative document coordinates cannot be scrolled to.
+	if r.right()+vp.ScrollX <= 0 || r.bottom()+vp.ScrollY <= 0 {
+		return where + "; positioned outside the document, so scrolling cannot reveal it"
+	}
+	return where + "; scroll it into view"
+}
@@ -0,0 +1,156 @@
+// Purpose: Tests layout probe aggregation: box model, stacking contexts, clipping ancestors, and visibility reasons.
+// Docs: docs/features/feature/layout-inspection/index.md
+
+package analyze
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func node(tag, selector string, rect LayoutRect, styles map[string]string) LayoutNode {
+	return LayoutNode{Tag: tag, Selector: selector, Rect: rect, Styles: styles}
+}
+
+func probeFor(el LayoutNode, ancestors ...LayoutNode) LayoutProbe {
+	root := node("html", "html", LayoutRect{Width: 800, Height: 600}, map[string]string{"display": "block"})
+	return LayoutProbe{
+		Selector:   el.Selector,
+		MatchCount: 1,
+		Viewport:   LayoutViewport{Width: 800, Height: 600},
+		Element:    &el,
+		Ancestors:  append(ancestors, root),
+	}
+}
+
+func reasonCodes(v VisibilityReport) map[string]string {
+	out := map[string]string{}
+	for _, r := range v.Reasons {
+		out[r.Code] = r.Selector
+	}
+	return out
+}
+
+func TestParseStylesArgs_RequiresSelector(t *testing.T) {
+	t.Parallel()
+	if _, err := ParseStylesArgs(json.RawMessage(`{"selector":"  "}`)); err == nil {
+		t.Fatal("expected error for blank selector")
+	}
+	parsed, err := ParseStylesArgs(json.RawMessage(`{"selector":"#a","properties":["color"]}`))
+	if err != nil || parsed.Selector != "#a" || len(parsed.Properties) != 1 {
+		t.Fatalf("ParseStylesArgs = %+v, %v", parsed, err)
+	}
+}
+
+func TestAnalyzeLayout_BoxModelAndStyleFilter(t *testing.T) {
+	t.Parallel()
+	el := node("div", "#card", LayoutRect{X: 100, Y: 50, Width: 200, Height: 100}, map[string]string{
+		"display": "block", "color": "rgb(0, 0, 0)",
+		"margin-top": "10px", "margin-right": "10px", "margin-bottom": "10px", "margin-left": "10px",
+		"border-top-width": "2px", "border-right-width": "2px", "border-bottom-width": "2px", "border-left-width": "2px",
+		"padding-top": "8px", "padding-right": "8px", "padding-bottom": "8px", "padding-left": "8px",
+	})
+	report := AnalyzeLayout(probeFor(el), []string{"color", "missing"})
+
+	if len(report.ComputedStyles) != 1 || report.ComputedStyles["color"] != "rgb(0, 0, 0)" {
+		t.Fatalf("computed_styles = %v, want only color", report.ComputedStyles)
+	}
+	box := report.BoxModel
+	if box.Content != (LayoutRect{X: 110, Y: 60, Width: 180, Height: 80}) {
+		t.Errorf("content box = %+v", box.Content)
+	}
+	if box.Margin != (LayoutRect{X: 90, Y: 40, Width: 220, Height: 120}) {
+		t.Errorf("margin box = %+v", box.Margin)
+	}
+	if !report.Visibility.Visible || len(report.ClippingAncestors) != 0 {
+		t.Errorf("expected a visible, unclipped element: %+v", report)
+	}
+}
+
+func TestAnalyzeLayout_StackingContexts(t *testing.T) {
+	t.Parallel()
+	el := node("div", ".item", LayoutRect{Width: 10, Height: 10}, map[string]string{"display": "block", "position": "static", "z-index": "5"})
+	flexParent := node("div", ".row", LayoutRect{Width: 100, Height: 10}, map[string]string{"display": "flex", "opacity": "0.9"})
+	modal := node("div", "#modal", LayoutRect{Width: 800, Height: 600}, map[string]string{"display": "block", "position": "relative", "z-index": "10"})
+
+	info := AnalyzeLayout(probeFor(el, flexParent, modal), nil).StackingContext
+	if !info.CreatesContext || info.Reasons[0] != "z-index: 5 on a flex item" {
+		t.Fatalf("element stacking = %+v, want z-index on a flex item", info)
+	}
+	want := []string{".row", "#modal", "html"}
+	if len(info.Ancestors) != len(want) {
+		t.Fatalf("ancestor contexts = %+v, want %v", info.Ancestors, want)
+	}
+	for i, sel := range want {
+		if info.Ancestors[i].Selector != sel {
+			t.Errorf("ancestor context %d = %s, want %s", i, info.Ancestors[i].Selector, sel)
+		}
+	}
+	if info.Ancestors[1].Reasons[0] != "z-index: 10 on position: relative" {
+		t.Errorf("modal reasons = %v", info.Ancestors[1].Reasons)
+	}
+}
+
+func TestAnalyzeLayout_ClippingAncestors(t *testing.T) {
+	t.Parallel()
+	scroller := node("div", ".scroller", LayoutRect{X: 0, Y: 0, Width: 100, Height: 100}, map[string]string{"display": "block", "overflow-x": "visible", "overflow-y": "hidden"})
+	wide := node("div", ".wide", LayoutRect{X: 0, Y: 0, Width: 400, Height: 400}, map[string]string{"display": "block", "overflow-x": "hidden", "overflow-y": "hidden"})
+
+	partial := node("p", ".p", LayoutRect{X: 0, Y: 80, Width: 50, Height: 40}, map[string]string{"display": "block"})
+	report := AnalyzeLayout(probeFor(partial, scroller, wide), nil)
+	if len(report.ClippingAncestors) != 2 || report.ClippingAncestors[0].Effect != "partial" || report.ClippingAncestors[1].Effect != "none" {
+		t.Fatalf("clipping = %+v, want partial then none", report.ClippingAncestors)
+	}
+	if !report.Visibility.Visible {
+		t.Errorf("a partially clipped element is still visible: %+v", report.Visibility)
+	}
+
+	// Overflow only clips on the y axis, so an element to the right is not clipped by .scroller.
+	right := node("p", ".r", LayoutRect{X: 150, Y: 10, Width: 20, Height: 20}, map[string]string{"display": "block"})
+	if got := AnalyzeLayout(probeFor(right, scroller), nil).ClippingAncestors[0].Effect; got != "none" {
+		t.Errorf("x-overflow effect = %s, want none", got)
+	}
+
+	below := node("p", ".b", LayoutRect{X: 0, Y: 150, Width: 20, Height: 20}, map[string]string{"display": "block"})
+	report = AnalyzeLayout(probeFor(below, scroller), nil)
+	if reasonCodes(report.Visibility)["clipped"] != ".scroller" || report.Visibility.Visible {
+		t.Fatalf("visibility = %+v, want clipped by .scroller", report.Visibility)
+	}
+
+	// Fixed elements escape overflow unless the ancestor is a containing block.
+	fixed := node("div", ".toast", LayoutRect{X: 0, Y: 150, Width: 20, Height: 20}, map[string]string{"display": "block", "position": "fixed"})
+	if got := AnalyzeLayout(probeFor(fixed, scroller), nil).ClippingAncestors; len(got) != 0 {
+		t.Errorf("fixed element should escape overflow clipping, got %+v", got)
+	}
+}
+
+func TestAnalyzeLayout_VisibilityReasons(t *testing.T) {
+	t.Parallel()
+	hiddenParent := node("section", "#panel", LayoutRect{}, map[string]string{"display": "none", "visibility": "hidden"})
+	el := node("button", "#save", LayoutRect{}, map[string]string{"display": "inline-block", "visibility": "hidden", "opacity": "1"})
+	report := AnalyzeLayout(probeFor(el, hiddenParent), nil)
+	codes := reasonCodes(report.Visibility)
+	if report.Visibility.Visible || codes["display_none"] != "#panel" || codes["visibility_hidden"] != "#panel" {
+		t.Fatalf("visibility = %+v, want display_none and inherited visibility from #panel", report.Visibility)
+	}
+	if _, ok := codes["zero_size"]; ok {
+		t.Error("zero_size is implied by display:none and should not be reported")
+	}
+
+	tiny := node("span", ".dot", LayoutRect{X: 5, Y: 5, Width: 0, Height: 12}, map[string]string{"display": "inline", "opacity": "0"})
+	codes = reasonCodes(AnalyzeLayout(probeFor(tiny), nil).Visibility)
+	if codes["zero_size"] != ".dot" || codes["opacity_zero"] != ".dot" {
+		t.Fatalf("reasons = %v, want zero_size and opacity_zero", codes)
+	}
+
+	for rect, want := range map[LayoutRect]string{
+		{X: 10, Y: 900, Width: 50, Height: 50}:   "below the viewport; scroll it into view",
+		{X: -9999, Y: 10, Width: 50, Height: 50}: "left of the viewport; positioned outside the document, so scrolling cannot reveal it",
+	} {
+		off := node("div", ".off", rect, map[string]string{"display": "block"})
+		reasons := AnalyzeLayout(probeFor(off), nil).Visibility.Reasons
+		if len(reasons) != 1 || reasons[0].Code != "off_screen" || reasons[0].Detail != want {
+			t.Errorf("rect %+v reasons = %+v, want off_screen %q", rect, reasons, want)
+		}
+	}
+}
@@ -6,6 +6,8 @@ package analyze
 import (
 	"encoding/json"
 	"errors"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/types"
 )
 
 // VisualBaselineArgs holds parsed arguments for saving a visual baseline.
@@ -58,4 +60,6 @@ type BaselineMetadata struct {
 	Height    int    `json:"height"`
 	Name      string `json:"name"`
 	Timestamp int64  `json:"timestamp"`
+	// SourceControl is the saving client's git state; nil for baselines saved outside a repo.
+	SourceControl *types.SourceControl `json:"source_control,omitempty"`
 }
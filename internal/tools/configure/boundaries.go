@@ -46,6 +46,9 @@ func missingTestIDResponse(reqID any) *mcp.JSONRPCResponse {
 type TestBoundaryStartResult struct {
 	TestID string
 	Label  string
+	// StorageBaseline reports the storage snapshot taken for change tracking:
+	// "recorded", "unavailable: <reason>", or empty when no tab is tracked.
+	StorageBaseline string
 }
 
 // ParseTestBoundaryStart validates test_boundary_start arguments and returns
@@ -74,17 +77,23 @@ func ParseTestBoundaryStart(reqID any, args json.RawMessage) (*TestBoundaryStart
 
 // BuildTestBoundaryStartResponse builds the MCP response for a validated test_boundary_start.
 func BuildTestBoundaryStartResponse(reqID any, r *TestBoundaryStartResult) mcp.JSONRPCResponse {
-	return mcp.JSONRPCResponse{JSONRPC: "2.0", ID: reqID, Result: mcp.JSONResponse("Test boundary started", map[string]any{
+	data := map[string]any{
 		"status":  "ok",
 		"test_id": r.TestID,
 		"label":   r.Label,
 		"message": "Test boundary started",
-	})}
+	}
+	if r.StorageBaseline != "" {
+		data["storage_baseline"] = r.StorageBaseline
+	}
+	return mcp.JSONRPCResponse{JSONRPC: "2.0", ID: reqID, Result: mcp.JSONResponse("Test boundary started", data)}
 }
 
 // TestBoundaryEndResult holds the validated parameters for a test_boundary_end request.
 type TestBoundaryEndResult struct {
 	TestID string
+	// Summary counts what was captured inside the boundary; nil when not computed.
+	Summary map[string]any
 }
 
 // ParseTestBoundaryEnd validates test_boundary_end arguments and returns
@@ -106,10 +115,14 @@ func ParseTestBoundaryEnd(reqID any, args json.RawMessage) (*TestBoundaryEndResu
 
 // BuildTestBoundaryEndResponse builds the MCP response for a validated test_boundary_end.
 func BuildTestBoundaryEndResponse(reqID any, r *TestBoundaryEndResult, wasActive bool) mcp.JSONRPCResponse {
-	return mcp.JSONRPCResponse{JSONRPC: "2.0", ID: reqID, Result: mcp.JSONResponse("Test boundary ended", map[string]any{
+	data := map[string]any{
 		"status":     "ok",
 		"test_id":    r.TestID,
 		"was_active": wasActive,
 		"message":    "Test boundary ended",
-	})}
+	}
+	if r.Summary != nil {
+		data["summary"] = r.Summary
+	}
+	return mcp.JSONRPCResponse{JSONRPC: "2.0", ID: reqID, Result: mcp.JSONResponse("Test boundary ended", data)}
 }
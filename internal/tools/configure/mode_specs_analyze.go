@@ -11,7 +11,7 @@ var analyzeModeSpecs = map[string]modeParamSpec{
 		Hint: "Page load performance metrics and bottleneck analysis",
 	},
 	"accessibility": {
-		Hint:     "WCAG/axe accessibility audit with violation details. summary=true returns counts + top issues",
+		Hint:     "WCAG/axe accessibility audit with violation details; color-contrast nodes carry the nearest AA/AAA colours and contrast_fixes holds CSS custom property overrides. summary=true returns counts + top issues",
 		Optional: []string{"selector", "scope", "tags", "force_refresh", "frame", "summary"},
 	},
 	"error_clusters": {
@@ -21,12 +21,12 @@ var analyzeModeSpecs = map[string]modeParamSpec{
 		Hint: "Analyze navigation history patterns and detect repeated loops or dead ends",
 	},
 	"security_audit": {
-		Hint:     "Check for credential leaks, CSP, cookie, and header risks. summary=true returns counts + top issues",
-		Optional: []string{"checks", "severity_min", "summary"},
+		Hint:     "Check for credential leaks, CSP, cookie, and header risks. summary=true returns counts + top issues. category=csp_diff diffs the served CSP against observed traffic: missing sources that will break and broad sources to tighten",
+		Optional: []string{"checks", "severity_min", "summary", "category", "first_party_suffixes"},
 	},
 	"third_party_audit": {
 		Hint:     "Audit third-party script origins and data exposure. summary=true returns counts + top origins",
-		Optional: []string{"first_party_origins", "include_static", "custom_lists", "summary"},
+		Optional: []string{"first_party_origins", "first_party_suffixes", "include_static", "custom_lists", "summary"},
 	},
 	"link_health": {
 		Hint:     "Check all page links for broken URLs (404s, timeouts)",
@@ -63,6 +63,15 @@ var analyzeModeSpecs = map[string]modeParamSpec{
 		Hint:     "CSS computed styles for an element",
 		Optional: []string{"selector", "frame"},
 	},
+	"styles": {
+		Hint:     "Why an element renders as it does: computed styles, box model, stacking context, clipping ancestors, visibility reasons",
+		Required: []string{"selector"},
+		Optional: []string{"properties", "tab_id"},
+	},
+	"i18n": {
+		Hint:     "Per-page localization audit: missing lang, untranslated keys like home.title.missing, hardcoded strings outside i18n markers, mixed-language text, RTL layout issues",
+		Optional: []string{"markers", "tab_id"},
+	},
 	"forms": {
 		Hint:     "Form structure: field names, types, and attributes",
 		Optional: []string{"selector", "frame"},
@@ -11,14 +11,22 @@ var configureModeSpecs = map[string]modeParamSpec{
 		Hint: "Load stored session data by namespace",
 	},
 	"noise_rule": {
-		Hint: "Suppress recurring console noise with pattern rules",
+		Hint: "Suppress recurring console noise with pattern rules (alias of noise_rules)",
 		Optional: []string{
 			"noise_action", "rules", "rule_id", "pattern", "category", "classification",
 			"message_regex", "source_regex", "url_regex", "method", "status_min", "status_max", "level", "reason",
+			"rule_action", "tag", "position", "list",
+		},
+	},
+	"noise_rules": {
+		Hint: "Ordered noise rules: drop, downgrade, or tag matching console/network/websocket entries; list shows per-rule match counts",
+		Optional: []string{
+			"noise_action", "list", "rules", "rule_id", "position", "pattern", "category", "classification",
+			"rule_action", "tag", "message_regex", "source_regex", "url_regex", "method", "status_min", "status_max", "level", "reason",
 		},
 	},
 	"clear": {
-		Hint:     "Reset capture buffers (network, logs, actions, all)",
+		Hint:     "Reset capture buffers (network, logs, actions, all). Clearing all first archives the session for observe(what=\"sessions\") and observe(what=\"search\")",
 		Optional: []string{"buffer"},
 	},
 	"health": {
@@ -110,6 +118,99 @@ var configureModeSpecs = map[string]modeParamSpec{
 		Hint:     "Passive network traffic recording with start/stop capture",
 		Optional: []string{"operation", "domain", "method"},
 	},
+	"silence": {
+		Hint:     "Maintenance window: suppress alert emission (not capture) for a duration, auto-expiring. operation: start (default with duration)|status|clear",
+		Optional: []string{"operation", "duration", "categories", "reason", "silence_id"},
+	},
+	"subscribe": {
+		Hint:     "Filter SSE push notifications (GET /mcp, Accept: text/event-stream) for this client. events: console_error|network_error|ci_result|circuit_opened|circuit_closed|contract_violation|extension_reconnected|all; omit to show the current filter",
+		Optional: []string{"events"},
+	},
+	"rate_limit": {
+		Hint:     "Per-client tool call limits. operation: status (default)|set (default with a limit)|clear; omit client_id to change defaults, 0 = unlimited",
+		Optional: []string{"operation", "calls_per_minute", "hourly_quota", "client_id"},
+	},
+	"circuit": {
+		Hint:     "Capture ingest circuit breaker. Tune max_events_per_window and memory_limit_mb, or override state: open (reject all)|closed (stay closed)|auto. While cooling down it admits a growing share of batches. While open, console errors, failed requests (status >= 400), and WebSocket close/error events are still kept, tagged captured_under_pressure (priority_accepted/priority_dropped). operation: status (default)|set (default with a value)|clear (defaults, auto)",
+		Optional: []string{"operation", "max_events_per_window", "memory_limit_mb", "state"},
+	},
+	"ws_sampling": {
+		Hint:     "Per-connection WebSocket sampling: connections whose URL contains url_pattern (empty = all; first match wins) keep up to rate messages/s; always_keep event types or directions (default open, close, error) are never dropped. observe websocket_status shows achieved_rate and drops; websocket_events adds dropped_estimate. operation: list (default)|set (default with rate)|remove|clear",
+		Optional: []string{"operation", "url_pattern", "rate", "always_keep"},
+	},
+	"body_limits": {
+		Hint:     "Network body truncation. Bodies past request_body_max/response_body_max are cut inline and carry full_body_ref; observe(what=\"network_bodies\", request_id=<ref>, full_body=true) returns the whole payload up to capture_body_max, the ceiling the extension captures. binary_capture_max > 0 captures the head of binary responses for binary_preview. operation: status (default)|set (default with a limit)|clear",
+		Optional: []string{"operation", "request_body_max", "response_body_max", "capture_body_max", "binary_capture_max"},
+	},
+	"retention": {
+		Hint:     "Per-buffer capacity and age limit for console, network, websocket, actions, and performance. operation: status (default)|set (default with a limit)|clear (one buffer, or all). ttl is a duration such as '30m', '0' = no age limit. Shrinking evicts the oldest entries immediately; settings persist for the project until cleared",
+		Optional: []string{"operation", "buffer", "max_entries", "ttl"},
+	},
+	"archive": {
+		Hint:     "Session archive: configure(what=\"clear\") of every buffer and daemon shutdown roll the buffers into a compressed bundle. max_entries is how many sessions are kept (default 20), ttl how long ('0' = until evicted by count, default 168h), enabled turns archiving off. List with observe(what=\"sessions\"), search with observe(what=\"search\"). operation: status (default)|set (default with a value)|clear (delete archived sessions)",
+		Optional: []string{"operation", "enabled", "max_entries", "ttl"},
+	},
+	"register_proto": {
+		Hint:     "Register a protobuf descriptor set (protoc --descriptor_set_out --include_imports) so observe(what=\"websocket_events\") decodes binary frames on URLs containing url as proto_message. JSON, socket.io, and SignalR frames decode without registration. operation: list (default)|register (default with path)|clear",
+		Optional: []string{"operation", "path", "proto_message", "url"},
+	},
+	"screenshot_redaction": {
+		Hint:     "Black out PII on screenshots: every element matching selectors is painted over server-side before the image is saved or returned. operation: status (default)|set (default with selectors)|clear",
+		Optional: []string{"operation", "selectors"},
+	},
+	"lock_api_contract": {
+		Hint:     "Freeze the response schema inferred from an endpoint's captured 2xx JSON responses; later new fields, type changes, nulls, and missing required keys raise regression alerts and show in observe(what=\"contract_violations\"). operation: lock (default)|status|clear",
+		Optional: []string{"operation", "endpoint"},
+	},
+	"finding_state": {
+		Hint:     "Move a tracked finding (id from observe(what=\"findings\")) to open, acknowledged (accepted; leaves the open queue), or fixed by hand. reason is kept as a note. A fixed finding that audits detect again becomes regressed",
+		Required: []string{"finding_id", "state"},
+		Optional: []string{"reason"},
+	},
+	"add_webhook": {
+		Hint:     "POST alerts to a Slack, Discord, or JSON endpoint with retry and backoff. events: circuit_opened|error_cluster|security_finding|perf_regression|all (default all); format: json|slack|discord, inferred from the URL when omitted",
+		Required: []string{"url"},
+		Optional: []string{"events", "format"},
+	},
+	"list_webhooks": {
+		Hint: "List registered webhooks with delivered, failed, retried, and dropped counts. URLs are shown as origin only",
+	},
+	"remove_webhook": {
+		Hint:     "Unregister a webhook; undelivered alerts for it are discarded",
+		Required: []string{"webhook_id"},
+	},
+	"invariant": {
+		Hint:     "Standing assertions checked on every ingested network, WebSocket, and console batch; violations are kept with evidence and raise regression alerts. operation: add (default with expr)|list (default)|remove|clear. expr: no [network] request to <host>|no [network] request matching <regex>|no [network] status >= <code> [to <host>]|no console <level> [matching <regex>]|no websocket [to <host>]; scope: session (default)|page",
+		Optional: []string{"operation", "expr", "scope", "invariant_id", "limit"},
+	},
+	"mock_request": {
+		Hint:     "Stub page fetch/XHR responses in the tracked tab to simulate API failures, latency, and edge-case payloads. operation: add (default with url_pattern)|list (default)|remove|clear. url_pattern is a URL substring, or a whole-URL glob with *; status defaults to 200, 0 = network error; body may be a string or JSON (JSON defaults Content-Type to application/json); delay_ms up to 60000. A mock with the same url_pattern and method replaces the old one",
+		Optional: []string{"operation", "url_pattern", "method", "status", "body", "headers", "delay_ms", "mock_id"},
+	},
+	"network_budget": {
+		Hint:     "Per-URL-pattern response budgets checked on every ingested request and resource timing entry; overruns raise regression alerts and show in observe(what=\"budget_violations\"). operation: add (default with pattern)|list (default)|remove|clear. pattern is a URL substring, or a whole-URL glob with *; set max_duration_ms, max_size_kb, or both. Adding an existing pattern updates its limits",
+		Optional: []string{"operation", "pattern", "max_duration_ms", "max_size_kb", "budget_id"},
+	},
+	"dedup": {
+		Hint:     "Collapse consecutive identical console log entries on ingest into one entry with count, first_ts, and last_ts, in observe(what=\"logs\") and the saved JSONL. Off by default; omit enabled to read the state and the number of collapsed entries",
+		Optional: []string{"enabled"},
+	},
+	"multi_tab_capture": {
+		Hint:     "Capture telemetry from every open tab at once instead of only the tracked tab. Entries stay tagged with tab_id (and frame_id for iframes), so scope observe with tab_id; GET /health lists per-tab buffer counts. Off by default; applies on the next extension sync; omit enabled to read the state",
+		Optional: []string{"enabled"},
+	},
+	"override": {
+		Hint:     "Make the tracked tab report another place and language: geolocation={lat, lng}, timezone (IANA, e.g. Europe/Berlin), locale (BCP 47, e.g. de-DE). operation: set (default with any field)|status (default)|clear. set merges into the active overrides; an empty timezone or locale drops it. Applies on the next extension sync; active overrides appear in configure(what=\"load\") and are replayed by generate(test) and generate(reproduction)",
+		Optional: []string{"operation", "geolocation", "timezone", "locale"},
+	},
+	"list_states": {
+		Hint:     "List encrypted vault states saved with interact(save_state, vault=true): name, origin, saved_at, expires_at, expired, and cookie/storage counts. Values are never returned. origin filters to one site",
+		Optional: []string{"origin"},
+	},
+	"define_login": {
+		Hint:     "Store a login recipe for interact(what='login'): steps of navigate|type|click|wait_for starting with navigate and staying on one origin. Put passwords in text_env (a KABOOM_LOGIN_* variable read from the daemon's environment at run time); use {{var}} placeholders with default vars. operation: add (default with steps)|list (default)|remove",
+		Optional: []string{"operation", "name", "description", "steps", "vars", "ttl"},
+	},
 	"action_jitter": {
 		Hint:     "Randomized micro-delays before interact actions for human-like timing",
 		Optional: []string{"action_jitter_ms"},
@@ -5,28 +5,36 @@ package configure
 var generateModeSpecs = map[string]modeParamSpec{
 	"reproduction": {
 		Hint:     "Generate Playwright reproduction script from captured actions/errors",
-		Optional: []string{"error_message", "last_n", "base_url", "include_screenshots", "generate_fixtures", "visual_assertions", "output_format", "save_to"},
+		Optional: []string{"error_message", "last_n", "base_url", "include_screenshots", "generate_fixtures", "visual_assertions", "output_format", "boundary", "save_to"},
 	},
 	"test": {
 		Hint:     "Generate Playwright test from recorded browser actions (requires prior action capture)",
-		Optional: []string{"test_name", "last_n", "base_url", "assert_network", "assert_no_errors", "assert_response_shape", "save_to"},
+		Optional: []string{"test_name", "last_n", "base_url", "assert_network", "assert_no_errors", "assert_response_shape", "locator_strategy", "locales", "boundary", "save_to"},
 	},
 	"pr_summary": {
-		Hint:     "Generate PR summary from captured session activity",
-		Optional: []string{"save_to"},
+		Hint:     "Generate PR comment markdown: session activity plus security, performance, error cluster, and accessibility deltas with severity badges",
+		Optional: []string{"baseline", "save_to"},
+	},
+	"third_party_report": {
+		Hint:     "Markdown supply-chain report of third-party scripts: pinning, host class, load position, SRI, and changes since the stored baseline",
+		Optional: []string{"first_party_origins", "first_party_suffixes", "save_to"},
 	},
 	"har": {
 		Hint:     "Export captured network traffic as HAR file",
-		Optional: []string{"url", "method", "status_min", "status_max", "save_to"},
+		Optional: []string{"url", "method", "status_min", "status_max", "boundary", "save_to"},
 	},
 	"csp": {
 		Hint:     "Generate Content-Security-Policy header from observed resources",
 		Optional: []string{"mode", "include_report_uri", "exclude_origins", "save_to"},
 	},
 	"sri": {
-		Hint:     "Generate Subresource Integrity hashes for scripts/styles",
+		Hint:     "Generate Subresource Integrity hashes, HTML patch, and manifest for scripts/styles/dynamic-import modules",
 		Optional: []string{"resource_types", "origins", "save_to"},
 	},
+	"security_headers": {
+		Hint:     "Recommend Permissions-Policy, X-Frame-Options/frame-ancestors, Referrer-Policy, and COOP/COEP from observed page behavior",
+		Optional: []string{"save_to"},
+	},
 	"sarif": {
 		Hint:     "Export errors and violations as SARIF for IDE/CI integration",
 		Optional: []string{"scope", "include_passes", "save_to"},
@@ -56,4 +64,34 @@ var generateModeSpecs = map[string]modeParamSpec{
 		Hint:     "Classify test failures by root cause. action: failure (single) | batch (multiple)",
 		Optional: []string{"action", "failure", "failures", "save_to"},
 	},
+	"session_bundle": {
+		Hint:     "Archive all captured buffers into a session bundle for kaboom --serve-bundle",
+		Optional: []string{"save_to", "label"},
+	},
+	"debug_bundle": {
+		Hint:     "Zip of diagnostics, the HTTP debug log, redacted buffers, settings, crash logs, and version/OS info to attach to a Kaboom bug report",
+		Optional: []string{"save_to"},
+	},
+	"junit": {
+		Hint:     "JUnit XML of session checks for CI: no console errors, no 5xx responses, no API contract violations, no serious/critical a11y violations, vitals within budget",
+		Optional: []string{"suite_name", "budget", "save_to"},
+	},
+	"gh_annotations": {
+		Hint:     "GitHub Actions workflow commands (::error file=...,line=...::msg) for console errors at their source-mapped file, API contract violations, and open a11y findings; print the saved file from a CI step",
+		Optional: []string{"save_to"},
+	},
+	"coverage_report": {
+		Hint:     "Markdown report of the JS/CSS bundles with the most unused bytes across observe coverage snapshots, with estimated wasted download from the network waterfall, severity, and a fix per bundle. url filters by resource or page URL; limit caps bundles (default 10)",
+		Optional: []string{"url", "limit", "save_to"},
+	},
+	"curl": {
+		Hint:     "Runnable cURL command for a captured request; sensitive headers become redaction placeholders",
+		Required: []string{"request_id"},
+		Optional: []string{"save_to"},
+	},
+	"fetch": {
+		Hint:     "Runnable fetch() snippet for a captured request; sensitive headers become redaction placeholders",
+		Required: []string{"request_id"},
+		Optional: []string{"save_to"},
+	},
 }
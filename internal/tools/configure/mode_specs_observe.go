@@ -4,41 +4,92 @@ package configure
 
 var observeModeSpecs = map[string]modeParamSpec{
 	"errors": {
-		Hint:     "Raw JavaScript console errors. summary=true returns counts by source + top messages",
-		Optional: []string{"scope", "limit", "summary"},
+		Hint:     "Raw JavaScript console errors. summary=true returns counts by source + top messages. cluster_trend=true returns clusters new this session vs known, plus regressions after a log clear",
+		Optional: []string{"scope", "limit", "summary", "cluster_trend", "filter", "boundary", "tab_id", "wait_for_new", "timeout_ms"},
 	},
 	"logs": {
-		Hint:     "Console log messages with level/source filtering. summary=true returns counts by level/source",
-		Optional: []string{"min_level", "source", "include_internal", "include_extension_logs", "extension_limit", "limit", "scope", "summary"},
+		Hint:     "Console log messages with level/source filtering. summary=true returns counts by level/source. format=structured returns console arguments as JSON in args; args_path=args[0].requestId (plus args_value) filters on them. filter takes an expression like level>=warn AND url~\"checkout\" AND NOT message~\"ResizeObserver\"",
+		Optional: []string{"min_level", "source", "include_internal", "include_extension_logs", "extension_limit", "limit", "scope", "summary", "format", "args_path", "args_value", "filter", "boundary", "tab_id", "wait_for_new", "timeout_ms"},
 	},
 	"extension_logs": {
 		Hint:     "Kaboom extension internal debug logs",
-		Optional: []string{"limit"},
+		Optional: []string{"limit", "filter"},
 	},
 	"network_waterfall": {
 		Hint:     "HTTP request/response timeline with status and timing. summary=true returns compact {url,ms,type} entries",
-		Optional: []string{"url", "method", "status_min", "status_max", "limit", "summary", "after_cursor", "before_cursor", "since_cursor", "restart_on_eviction"},
+		Optional: []string{"url", "method", "status_min", "status_max", "limit", "summary", "after_cursor", "before_cursor", "since_cursor", "restart_on_eviction", "boundary", "tab_id"},
 	},
 	"network_bodies": {
-		Hint:     "HTTP response bodies with JSON path extraction. summary=true returns status groups + top URLs",
-		Optional: []string{"url", "body_path", "method", "status_min", "status_max", "limit", "after_cursor", "before_cursor", "since_cursor", "restart_on_eviction", "summary"},
+		Hint:     "HTTP response bodies with JSON path extraction. summary=true returns status groups + top URLs. Bodies cut at the inline limit carry full_body_ref; request_id=<ref> with full_body=true returns the whole payload. Captured binary responses show binary_preview; include_binary=true adds their base64 bytes",
+		Optional: []string{"url", "body_path", "request_id", "full_body", "include_binary", "method", "status_min", "status_max", "limit", "after_cursor", "before_cursor", "since_cursor", "restart_on_eviction", "summary", "boundary", "tab_id", "wait_for_new", "timeout_ms"},
 	},
 	"websocket_events": {
 		Hint:     "WebSocket message frames (incoming/outgoing). summary=true returns direction/event counts",
-		Optional: []string{"connection_id", "direction", "limit", "after_cursor", "before_cursor", "since_cursor", "restart_on_eviction", "summary"},
+		Optional: []string{"connection_id", "direction", "limit", "after_cursor", "before_cursor", "since_cursor", "restart_on_eviction", "summary", "boundary", "tab_id", "wait_for_new", "timeout_ms"},
 	},
 	"websocket_status": {
 		Hint:     "Active WebSocket connection states",
 		Optional: []string{"url", "connection_id", "summary"},
 	},
+	"sse": {
+		Hint:     "Server-Sent Events (EventSource) open/message/retry/error/close with cursors. connections reports each stream's state, message count, retries, and last_event_id. summary=true returns event and event_type counts",
+		Optional: []string{"url", "connection_id", "event", "event_type", "limit", "after_cursor", "before_cursor", "since_cursor", "restart_on_eviction", "summary", "boundary", "tab_id"},
+	},
+	"server_debug": {
+		Hint:     "Daemon HTTP debug log of recent /mcp requests and responses (bodies truncated, redacted) with cursors. errors_only catches JSON-RPC and tool errors returned with status 200; mine=true keeps only the calling client's requests. summary=true returns counts by endpoint and status plus durations",
+		Optional: []string{"endpoint", "method", "status_min", "slow_ms", "errors_only", "mine", "limit", "after_cursor", "before_cursor", "since_cursor", "restart_on_eviction", "summary"},
+	},
 	"actions": {
 		Hint:     "User interaction log (clicks, inputs, navigation). summary=true returns counts by type + time range",
-		Optional: []string{"limit", "after_cursor", "before_cursor", "since_cursor", "last_n", "restart_on_eviction", "summary"},
+		Optional: []string{"limit", "after_cursor", "before_cursor", "since_cursor", "last_n", "restart_on_eviction", "summary", "boundary", "tab_id", "wait_for_new", "timeout_ms"},
 	},
 	"vitals": {
-		Hint:     "Core Web Vitals (LCP, CLS, INP, FCP, TTFB)",
+		Hint:     "Core Web Vitals (LCP, CLS, INP, FCP, TTFB); metrics below the good threshold are listed in findings with a playbook finding_id. inp_interactions lists the slowest interactions with target selector and input_delay/processing_time/presentation_delay; emulation names the device set by interact(emulate). mode=trend returns daily p75 series per route from persisted history. aggregate=p75 with window=1h returns each route's percentile over recent loads next to the latest load, to tell a one-off slow load from a regression",
+		Optional: []string{"limit", "mode", "days", "url", "aggregate", "window"},
+	},
+	"verify_fix": {
+		Hint:     "Did the fix work? Judges expect={error_cluster_id_absent, request_succeeds, vitals_within_budget} against what was captured after since (default last_edit). replay=<saved sequence> re-runs the flow first; otherwise watches up to wait_ms for the next reload. verdict=fixed|not_fixed|inconclusive with per-check evidence",
+		Optional: []string{"since", "expect", "replay", "wait_ms"},
+	},
+	"assert": {
+		Hint:     "Deterministic check of several conditions at once: selector (exists, visible, count, text_contains), url_matches, title_contains, no_console_errors_since, no_network_errors_since. DOM, URL, and title are read in one pass over the page. Returns passed plus pass/fail and evidence per condition; a failed condition is a result, not an error",
+		Required: []string{"conditions"},
+	},
+	"state_at": {
+		Hint:     "Post-mortem: what was happening at t? Reconstructs the URL, the last actions, requests in flight, open WebSocket connections, and the last errors at that moment from the buffers, with coverage caveats when t predates retained data",
+		Required: []string{"t"},
+	},
+	"flakiness": {
+		Hint:     "Compares repeated runs of one test boundary (each wrapped in configure test_boundary_start/end with the same test_id) step by step. Each run and step is classified stable, timing_only, network_order (same requests, different completion order), or divergent (different actions, statuses, or errors); flake_probability per step weights divergence 1, network_order 0.5, timing_only 0.25",
+		Required: []string{"boundary"},
+	},
+	"playbook": {
+		Hint:     "Remediation playbook for a finding_id (from security_audit, cookie_audit, accessibility, or vitals findings): why it matters, ordered fix steps, code snippets, and the verify call that proves the fix. Omit finding_id to list every playbook",
+		Optional: []string{"finding_id"},
+	},
+	"findings": {
+		Hint:     "One triage queue across security_audit, cookie_audit, accessibility, and vitals. Every finding has a stable id and a persisted state: open, acknowledged, fixed (a full re-run no longer detects it), or regressed (detected again after fixed; raises an alert). state=open for what needs work; configure(what=\"finding_state\") acknowledges or closes by hand",
+		Optional: []string{"state", "limit"},
+	},
+	"doctor": {
+		Hint:     "Why is data missing? Runs a server-side decision tree (extension polling, circuit breaker, tracked tab, clears, TTL expiry, noise rules, client registration) and returns likely causes ranked high to low, each with evidence, a fix, and the exact calls to run. buffer narrows it to one buffer",
+		Optional: []string{"buffer"},
+	},
+	"sessions": {
+		Hint:     "Sessions archived when configure(what=\"clear\") cleared every buffer or the daemon shut down, newest first, with reason, per-buffer counts, size, and the bundle path (usable as session_compare a/b and with kaboom --serve-bundle). Retention is set with configure(what=\"archive\")",
 		Optional: []string{"limit"},
 	},
+	"search": {
+		Hint:     "Case-insensitive text search over archived sessions: console and extension log messages, request URLs and bodies, WebSocket payloads and close reasons, action URLs and values. session narrows to one archived session id; buffer narrows to logs|network|websocket|actions",
+		Required: []string{"query"},
+		Optional: []string{"session", "buffer", "limit"},
+	},
+	"capabilities": {
+		Hint: "Call first to plan: which subsystems are active right now (extension connection and transport, AI Web Pilot, CDP driver, storage backend, negotiated MCP protocol) with server and extension versions, which audits can run, and hints for working around what is missing",
+	},
+	"environment": {
+		Hint: "Browser the session runs in: user agent, viewport, device pixel ratio, locale, timezone, and extension version, measured in the tracked page and refreshed on sync. generate(test) and generate(reproduction, output_format=playwright) replay it in a test.use block",
+	},
 	"page": {
 		Hint: "Current page URL, title, and tracked tab info (metadata only; for content use analyze/page_summary or interact/explore_page)",
 	},
@@ -53,20 +104,24 @@ var observeModeSpecs = map[string]modeParamSpec{
 		Hint: "AI Web Pilot connection status and availability",
 	},
 	"timeline": {
-		Hint:     "Merged chronological view of actions, errors, network, and WebSocket events. summary=true returns counts by type",
-		Optional: []string{"include", "limit", "summary"},
+		Hint:     "Merged chronological view of actions, errors, network, WebSocket, test-runner, dev-server rebuild, and source edit events; entries carry the test running when captured, errors carry bundle=current|stale|building|build_failed, and edits carry verdict=broke|fixed|no_change|vitals_regressed|awaiting_reload with last_edit summarized. test_id scopes to one test; boundary scopes to entries tagged by a configure test_boundary. summary=true returns counts by type",
+		Optional: []string{"include", "limit", "summary", "test_id", "boundary", "tab_id"},
 	},
 	"error_bundles": {
 		Hint:     "Pre-assembled debug context per error (error + network + actions + logs in time window). summary=true returns bundle counts + unique messages",
-		Optional: []string{"window_seconds", "limit", "scope", "summary"},
+		Optional: []string{"window_seconds", "limit", "scope", "summary", "wait_for_new", "timeout_ms"},
 	},
 	"screenshot": {
 		Hint:     "Capture page screenshot (full page or element)",
-		Optional: []string{"format", "quality", "full_page", "selector", "wait_for_stable", "save_to"},
+		Optional: []string{"format", "quality", "full_page", "selector", "clip", "highlight_selector", "wait_for_stable", "save_to"},
 	},
 	"storage": {
-		Hint:     "localStorage, sessionStorage, and cookies (with full metadata including httpOnly)",
-		Optional: []string{"storage_type", "key", "summary"},
+		Hint:     "localStorage, sessionStorage, and cookies (with full metadata including httpOnly; values under secret-like keys redacted), IndexedDB databases with per-store record counts, and quota usage. test_id reports keys added/removed/modified and record-count changes since that test's test_boundary_start baseline",
+		Optional: []string{"storage_type", "key", "summary", "test_id"},
+	},
+	"form_state": {
+		Hint:     "Every field's name, type, current value, validity flags, and validation message for forms matching selector (e.g. form#checkout). Password, payment, and secret-named values are redacted",
+		Optional: []string{"selector"},
 	},
 	"indexeddb": {
 		Hint:     "IndexedDB database/store contents",
@@ -122,4 +177,57 @@ var observeModeSpecs = map[string]modeParamSpec{
 		Hint:     "Discover page menus using 3-layer heuristic: semantic landmarks, axis alignment, border proximity. Returns {main, sidebar, footer, other, ungrouped}",
 		Optional: []string{"summary"},
 	},
+	"auth_state": {
+		Hint:     "JWTs seen in Authorization/Cookie/Set-Cookie headers and bodies: decoded (unverified) issuer, audience, expiry, and status. Claim values are redacted; structure is preserved",
+		Optional: []string{"url", "expiring_within_seconds"},
+	},
+	"cookie_audit": {
+		Hint:     "Audit Set-Cookie headers plus document.cookie/localStorage/sessionStorage: missing Secure/HttpOnly/SameSite, oversized cookies, secrets in web storage. Values are never returned; findings go to the security audit trail",
+		Optional: []string{"url", "include_storage"},
+	},
+	"transport_security": {
+		Hint:     "Session-long transport monitor: http:// subresources on https pages, ws:// sockets on https pages, and https->http redirects. Each new violation also raises an immediate security alert",
+		Optional: []string{"url"},
+	},
+	"session_compare": {
+		Hint:     "Ranked divergences between two sessions (errors, network, vitals, actions). a/b: bundle path, bundle label, or 'current'",
+		Required: []string{"a", "b"},
+		Optional: []string{"limit"},
+	},
+	"third_party_audit": {
+		Hint:     "Third-party script supply chain: origin, known CDN vs unknown host, version pinning, size, load position (blocking/async/defer/module/dynamic), SRI, and changes since the stored baseline",
+		Optional: []string{"first_party_origins", "first_party_suffixes", "update_baseline"},
+	},
+	"privacy_audit": {
+		Hint:     "Privacy inventory grouped by vendor: analytics beacons, tracking pixels, parameter names and identifiers sent, PII detected, and fingerprinting-adjacent APIs (canvas reads, battery, deviceMemory, ...) referenced by captured scripts, plus a consent timeline with tracking that fired before consent was granted",
+		Optional: []string{"first_party_origins", "first_party_suffixes", "consent_cookie", "consent_granted_value"},
+	},
+	"contract_violations": {
+		Hint:     "Locked API contracts (configure lock_api_contract) and every response that deviated: new_field, type_change, null_field, missing_required, with repeat counts. url filters by endpoint substring",
+		Optional: []string{"url", "limit"},
+	},
+	"memory": {
+		Hint:     "Heap trend per route across navigations: used JS heap (Chrome performance.memory, or measureUserAgentSpecificMemory on cross-origin isolated pages), DOM node counts, and listeners left on detached nodes. A leak is suspected when the heap grows on 4+ consecutive navigations of the same route by 10% and 1 MB. url filters by route; limit caps samples per route (default 10)",
+		Optional: []string{"url", "limit"},
+	},
+	"budget_violations": {
+		Hint:     "Network budgets (configure network_budget) and every URL that exceeded one: metric (duration|size), limit, first and worst value, repeat count, and evidence. url filters by URL substring",
+		Optional: []string{"url", "limit"},
+	},
+	"changes": {
+		Hint:     "Resumable change feed: console, network, ws, action, and alert deltas in global sequence order. Pass next_seq back as after_seq; a gap object reports changes evicted before you read them. since_commit starts from when a git commit was checked out",
+		Optional: []string{"after_seq", "feed_id", "limit", "types", "since_commit", "wait_for_new", "timeout_ms"},
+	},
+	"component_audit": {
+		Hint:     "Storybook/Ladle component audit: enumerates stories from the harness index, renders each in the tracked tab, and reports a11y violations, screenshot drift vs the stored baseline, and console errors keyed by story ID. Navigates the tab (requires AI Web Pilot) and restores it afterwards",
+		Optional: []string{"url", "harness", "story_url_template", "story_ids", "filter", "tags", "checks", "limit", "settle_ms", "update_baseline"},
+	},
+	"seo": {
+		Hint:     "SEO check of the tracked page: missing/duplicate title and meta description, canonical URL problems, robots noindex/nofollow (meta and X-Robots-Tag), JSON-LD parse errors, and same-site links that failed in captured traffic. Findings carry severity and a fix",
+		Optional: []string{"tab_id"},
+	},
+	"coverage": {
+		Hint:     "JS/CSS code coverage per resource URL: total, used, and unused bytes, unioned across every page load the extension snapshotted, biggest unused first. collect=true reloads the tracked tab under CDP coverage and records a snapshot first. url filters by resource or page URL; limit caps resources (default 20). generate coverage_report ranks them by download cost",
+		Optional: []string{"url", "limit", "collect", "tab_id"},
+	},
 }
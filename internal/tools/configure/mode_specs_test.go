@@ -170,8 +170,8 @@ func TestBuildCapabilitiesSummary_ObserveHints(t *testing.T) {
 	observeRaw := summary["observe"].(map[string]any)
 	modes := observeRaw["modes"].(map[string]string)
 
-	if modes["errors"] != "Raw JavaScript console errors. summary=true returns counts by source + top messages" {
-		t.Errorf("errors hint = %q, want 'Raw JavaScript console errors. summary=true returns counts by source + top messages'", modes["errors"])
+	if want := "Raw JavaScript console errors. summary=true returns counts by source + top messages. cluster_trend=true returns clusters new this session vs known, plus regressions after a log clear"; modes["errors"] != want {
+		t.Errorf("errors hint = %q, want %q", modes["errors"], want)
 	}
 	if modes["screenshot"] != "Capture page screenshot (full page or element)" {
 		t.Errorf("screenshot hint = %q", modes["screenshot"])
@@ -111,6 +111,12 @@ func buildFlatNoiseRule(rawMap map[string]any) (map[string]any, bool) {
 	if classification := stringOrEmpty(rawMap["classification"]); classification != "" {
 		rule["classification"] = classification
 	}
+	if ruleAction := stringOrEmpty(rawMap["rule_action"]); ruleAction != "" {
+		rule["action"] = ruleAction
+	}
+	if tag := stringOrEmpty(rawMap["tag"]); tag != "" {
+		rule["tag"] = tag
+	}
 	return rule, true
 }
 
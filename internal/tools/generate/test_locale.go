@@ -0,0 +1,153 @@
+// Purpose: Locale handling for generated Playwright tests: locator strategy, recorded locale, and per-locale string tables.
+// Why: Text and accessible-name locators only match the UI language they were recorded in; i18n suites need them swappable per locale.
+// Docs: docs/features/feature/test-generation/index.md
+
+package generate
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/reproduction"
+)
+
+// Locator strategies for generate({format: "test"}).
+const (
+	// LocatorStrategyAuto picks the most readable locator (test id, then role, label, text).
+	LocatorStrategyAuto = "auto"
+	// LocatorStrategyStable ranks element ids above role, label, and text so
+	// fewer locators depend on the UI language.
+	LocatorStrategyStable = "stable"
+)
+
+// maxLocales bounds the per-locale variants in one generated file.
+const maxLocales = 20
+
+var localePattern = regexp.MustCompile(`^[A-Za-z]{2,3}(-[A-Za-z0-9]{2,8})*$`)
+
+// ValidateLocaleParams checks locator_strategy and locales. It returns the name of
+// the offending parameter and a message, or empty strings when both are valid.
+func ValidateLocaleParams(params TestGenParams) (param, msg string) {
+	switch params.LocatorStrategy {
+	case "", LocatorStrategyAuto, LocatorStrategyStable:
+	default:
+		return "locator_strategy", "Invalid locator_strategy: " + params.LocatorStrategy
+	}
+	if len(params.Locales) > maxLocales {
+		return "locales", fmt.Sprintf("Too many locales: %d (max %d)", len(params.Locales), maxLocales)
+	}
+	seen := make(map[string]bool, len(params.Locales))
+	for _, l := range params.Locales {
+		if !localePattern.MatchString(l) {
+			return "locales", "Invalid locale: " + l
+		}
+		if seen[strings.ToLower(l)] {
+			return "locales", "Duplicate locale: " + l
+		}
+		seen[strings.ToLower(l)] = true
+	}
+	return "", ""
+}
+
+// RecordedLocale returns the UI locale of the most recent action that recorded a
+// well-formed one. The value comes from page markup, so malformed tags are ignored.
+func RecordedLocale(actions []capture.EnhancedAction) string {
+	for i := len(actions) - 1; i >= 0; i-- {
+		if localePattern.MatchString(actions[i].Locale) {
+			return actions[i].Locale
+		}
+	}
+	return ""
+}
+
+// LocaleTexts returns the distinct UI strings the generated locators match, in
+// first-use order. These are the locators that break when the UI language changes.
+func LocaleTexts(actions []capture.EnhancedAction, params TestGenParams) []string {
+	stable := params.LocatorStrategy == LocatorStrategyStable
+	seen := make(map[string]bool)
+	var texts []string
+	for _, a := range actions {
+		if !usesLocator(a.Type) {
+			continue
+		}
+		text := reproduction.ChooseLocator(a.Selectors, stable).Text
+		if text == "" || seen[text] {
+			continue
+		}
+		seen[text] = true
+		texts = append(texts, text)
+	}
+	return texts
+}
+
+// usesLocator reports whether PlaywrightStep targets an element for this action type.
+func usesLocator(actionType string) bool {
+	switch actionType {
+	case "click", "input", "select", "focus", "scroll_element":
+		return true
+	}
+	return false
+}
+
+// localeStrings assigns a key to each locale-dependent string for the STRINGS table.
+type localeStrings struct {
+	texts []string
+	keys  map[string]string
+}
+
+func newLocaleStrings(texts []string) localeStrings {
+	keys := make(map[string]string, len(texts))
+	for i, t := range texts {
+		keys[t] = fmt.Sprintf("text%d", i+1)
+	}
+	return localeStrings{texts: texts, keys: keys}
+}
+
+// expr returns the per-locale lookup for a recorded string.
+func (s localeStrings) expr(text string) string {
+	if key, ok := s.keys[text]; ok {
+		return "t." + key
+	}
+	return "'" + reproduction.EscapeJS(text) + "'"
+}
+
+// sameLocale matches locales case-insensitively; a bare language ("de") matches any of its regions ("de-DE").
+func sameLocale(a, b string) bool {
+	if a == "" || b == "" {
+		return false
+	}
+	langA, _, _ := strings.Cut(a, "-")
+	langB, _, _ := strings.Cut(b, "-")
+	if !strings.EqualFold(langA, langB) {
+		return false
+	}
+	return strings.EqualFold(a, b) || a == langA || b == langB
+}
+
+// writeLocaleTable writes the STRINGS table. Locales other than the recorded one
+// start with the recorded text and a TODO to translate it.
+func writeLocaleTable(b *strings.Builder, strs localeStrings, locales []string, recorded string) {
+	if recorded != "" {
+		fmt.Fprintf(b, "// Recorded in locale %s. Text locators read from STRINGS; translate each locale's entries.\n", recorded)
+	} else {
+		b.WriteString("// Recorded locale unknown. Text locators read from STRINGS; translate each locale's entries.\n")
+	}
+	b.WriteString("const STRINGS = {\n")
+	for _, locale := range locales {
+		fmt.Fprintf(b, "  '%s': {\n", reproduction.EscapeJS(locale))
+		todo := ""
+		if !sameLocale(locale, recorded) {
+			todo = " // TODO: translate"
+			if recorded != "" {
+				todo += " from " + recorded
+			}
+		}
+		for _, text := range strs.texts {
+			fmt.Fprintf(b, "    %s: '%s',%s\n", strs.keys[text], reproduction.EscapeJS(text), todo)
+		}
+		b.WriteString("  },\n")
+	}
+	b.WriteString("};\n\n")
+}
@@ -0,0 +1,146 @@
+// Purpose: Tests for locale-aware test generation: locator strategy, recorded locale, and per-locale variants.
+// Docs: docs/features/feature/test-generation/index.md
+
+package generate
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+)
+
+func localeActions() []capture.EnhancedAction {
+	return []capture.EnhancedAction{
+		{Type: "navigate", Timestamp: 1000, ToURL: "https://example.com/login", Locale: "de-DE"},
+		{Type: "input", Timestamp: 2000, Value: "max", Locale: "de-DE", Selectors: map[string]any{
+			"id": "user", "ariaLabel": "Benutzername", "cssPath": "form > input",
+		}},
+		{Type: "click", Timestamp: 3000, Locale: "de-DE", Selectors: map[string]any{
+			"role": map[string]any{"role": "button", "name": "Anmelden"}, "text": "Anmelden",
+		}},
+		{Type: "click", Timestamp: 4000, Locale: "de-DE", Selectors: map[string]any{
+			"testId": "help-link", "text": "Hilfe",
+		}},
+	}
+}
+
+func TestGenerateTestScript_RecordsLocale(t *testing.T) {
+	t.Parallel()
+
+	script := GenerateTestScript(localeActions(), TestGenParams{TestName: "login"})
+	if !strings.Contains(script, "// Recorded in locale de-DE;") {
+		t.Errorf("script should note the recorded locale:\n%s", script)
+	}
+	if !strings.Contains(script, "getByLabel('Benutzername').fill('max')") {
+		t.Errorf("auto strategy should keep the label locator:\n%s", script)
+	}
+	if !strings.Contains(script, "getByTestId('help-link')") {
+		t.Errorf("test id should win over text:\n%s", script)
+	}
+}
+
+func TestGenerateTestScript_StableLocators(t *testing.T) {
+	t.Parallel()
+
+	params := TestGenParams{TestName: "login", LocatorStrategy: LocatorStrategyStable}
+	script := GenerateTestScript(localeActions(), params)
+	if !strings.Contains(script, "locator('#user').fill('max')") {
+		t.Errorf("stable strategy should prefer the element id:\n%s", script)
+	}
+	if got := LocaleTexts(localeActions(), params); len(got) != 1 || got[0] != "Anmelden" {
+		t.Errorf("LocaleTexts(stable) = %v, want [Anmelden]", got)
+	}
+	if got := LocaleTexts(localeActions(), TestGenParams{}); len(got) != 2 {
+		t.Errorf("LocaleTexts(auto) = %v, want label and role name", got)
+	}
+}
+
+func TestGenerateTestScript_LocaleVariants(t *testing.T) {
+	t.Parallel()
+
+	params := TestGenParams{TestName: "login", Locales: []string{"de-DE", "fr-FR"}}
+	script := GenerateTestScript(localeActions(), params)
+
+	for _, want := range []string{
+		"// Recorded in locale de-DE.",
+		"  'de-DE': {\n    text1: 'Benutzername',\n    text2: 'Anmelden',\n  },",
+		"    text2: 'Anmelden', // TODO: translate from de-DE",
+		"for (const [locale, t] of Object.entries(STRINGS)) {",
+		"  test.describe('login [' + locale + ']', () => {",
+		"    test.use({ locale });",
+		"      await expect(page.getByLabel(t.text1)).toBeVisible();",
+		"      await page.getByRole('button', { name: t.text2 }).click();",
+		"      await page.getByTestId('help-link').click();",
+	} {
+		if !strings.Contains(script, want) {
+			t.Errorf("script missing %q:\n%s", want, script)
+		}
+	}
+	if strings.Contains(script, "expect(page.getByTestId") {
+		t.Errorf("locale-independent locators need no visibility assertion:\n%s", script)
+	}
+}
+
+func TestRecordedLocale_IgnoresMalformed(t *testing.T) {
+	t.Parallel()
+
+	actions := []capture.EnhancedAction{{Locale: "en-US"}, {Locale: "x\n// injected"}, {}}
+	if got := RecordedLocale(actions); got != "en-US" {
+		t.Errorf("RecordedLocale = %q, want en-US", got)
+	}
+}
+
+func TestValidateLocaleParams(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		params TestGenParams
+		param  string
+	}{
+		{TestGenParams{}, ""},
+		{TestGenParams{LocatorStrategy: "stable", Locales: []string{"en", "pt-BR", "zh-Hant-TW"}}, ""},
+		{TestGenParams{LocatorStrategy: "xpath"}, "locator_strategy"},
+		{TestGenParams{Locales: []string{"en US"}}, "locales"},
+		{TestGenParams{Locales: []string{"en-US", "EN-us"}}, "locales"},
+	}
+	for _, tt := range tests {
+		if param, _ := ValidateLocaleParams(tt.params); param != tt.param {
+			t.Errorf("ValidateLocaleParams(%+v) param = %q, want %q", tt.params, param, tt.param)
+		}
+	}
+}
+
+func TestSameLocale(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"de-DE", "de-de", true},
+		{"de", "de-AT", true},
+		{"de-DE", "de-AT", false},
+		{"fr-FR", "de-DE", false},
+		{"en", "", false},
+	}
+	for _, tt := range tests {
+		if got := sameLocale(tt.a, tt.b); got != tt.want {
+			t.Errorf("sameLocale(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestGenerateTestScript_EnvironmentLeavesLocaleToVariants(t *testing.T) {
+	t.Parallel()
+
+	env := &capture.BrowserEnvironment{Locale: "de-DE", Timezone: "Europe/Berlin", ViewportWidth: 1280, ViewportHeight: 720, DevicePixelRatio: 1}
+	script := GenerateTestScript(localeActions(), TestGenParams{TestName: "login", Locales: []string{"de-DE", "en-US"}, Environment: env})
+	top := script[:strings.Index(script, "for (const")]
+	if !strings.Contains(top, "timezoneId: 'Europe/Berlin'") || strings.Contains(top, "locale:") {
+		t.Errorf("top-level test.use should carry the environment but not the locale:\n%s", top)
+	}
+	if !strings.Contains(script, "test.use({ locale });") {
+		t.Errorf("per-locale variants should still set their own locale:\n%s", script)
+	}
+}
@@ -20,16 +20,27 @@ type TestGenParams struct {
 	AssertNetwork       bool   `json:"assert_network"`
 	AssertNoErrors      bool   `json:"assert_no_errors"`
 	AssertResponseShape bool   `json:"assert_response_shape"`
+	// LocatorStrategy is "auto" (default) or "stable"; see LocatorStrategyStable.
+	LocatorStrategy string `json:"locator_strategy"`
+	// Locales, when set, generates one test.describe per locale with text locators read from a STRINGS table.
+	Locales []string `json:"locales"`
+	// Boundary, when set, limits the test to actions tagged with that test boundary ID.
+	Boundary string `json:"boundary"`
+	// Environment, when set, is replayed in a top-level test.use block.
+	Environment *capture.BrowserEnvironment `json:"-"`
+	// Device, when set, is the device emulated during capture; it replaces the measured viewport in test.use.
+	Device *capture.DeviceEmulation `json:"-"`
+	// Region, when set, holds the configure(override) geolocation, timezone, and locale replayed in test.use.
+	Region *capture.RegionOverride `json:"-"`
 }
 
 // GenerateTestScript builds a complete Playwright test file from captured actions.
 func GenerateTestScript(actions []capture.EnhancedAction, params TestGenParams) string {
 	var b strings.Builder
 
-	b.WriteString("import { test, expect } from '@playwright/test';\n\n")
-	fmt.Fprintf(&b, "test.describe('%s', () => {\n", reproduction.EscapeJS(params.TestName))
-
 	if len(actions) == 0 {
+		b.WriteString("import { test, expect } from '@playwright/test';\n\n")
+		fmt.Fprintf(&b, "test.describe('%s', () => {\n", reproduction.EscapeJS(params.TestName))
 		b.WriteString("  // reason: no_actions_captured\n")
 		b.WriteString("  // hint: Navigate and interact with the browser first, then call generate(test) again.\n")
 		b.WriteString("  test('should load page', async ({ page }) => {\n")
@@ -37,27 +48,78 @@ func GenerateTestScript(actions []capture.EnhancedAction, params TestGenParams)
 		b.WriteString("    await page.goto('/');\n")
 		b.WriteString("    await expect(page).toHaveTitle(/.+/);\n")
 		b.WriteString("  });\n")
-	} else {
-		writeTestSteps(&b, actions, params)
+		b.WriteString("});\n")
+		return b.String()
 	}
 
+	b.WriteString(reproduction.PlaywrightImport(params.Device) + "\n\n")
+	if use := reproduction.PlaywrightUseBlock(params.Environment, params.Device, params.Region, len(params.Locales) == 0); use != "" {
+		b.WriteString(use + "\n")
+	}
+
+	recorded := RecordedLocale(actions)
+	if len(params.Locales) > 0 {
+		writeLocaleSuite(&b, actions, params, recorded)
+		return b.String()
+	}
+
+	if recorded != "" {
+		fmt.Fprintf(&b, "// Recorded in locale %s; role, label, and text locators match that UI language.\n", recorded)
+	}
+	fmt.Fprintf(&b, "test.describe('%s', () => {\n", reproduction.EscapeJS(params.TestName))
+	writeTestSteps(&b, actions, params, nil)
 	b.WriteString("});\n")
 	return b.String()
 }
 
+// writeLocaleSuite wraps the test blocks in a loop over the STRINGS table so the
+// same steps run once per locale, each with its own translated text locators.
+func writeLocaleSuite(b *strings.Builder, actions []capture.EnhancedAction, params TestGenParams, recorded string) {
+	strs := newLocaleStrings(LocaleTexts(actions, params))
+	writeLocaleTable(b, strs, params.Locales, recorded)
+
+	b.WriteString("for (const [locale, t] of Object.entries(STRINGS)) {\n")
+	fmt.Fprintf(b, "  test.describe('%s [' + locale + ']', () => {\n", reproduction.EscapeJS(params.TestName))
+	b.WriteString("    test.use({ locale });\n\n")
+
+	var body strings.Builder
+	writeTestSteps(&body, actions, params, &strs)
+	for _, line := range strings.SplitAfter(body.String(), "\n") {
+		if strings.TrimSpace(line) != "" {
+			b.WriteString("  ")
+		}
+		b.WriteString(line)
+	}
+
+	b.WriteString("  });\n")
+	b.WriteString("}\n")
+}
+
 // writeTestSteps groups actions into logical test blocks and writes them.
-func writeTestSteps(b *strings.Builder, actions []capture.EnhancedAction, params TestGenParams) {
+// With strs set, text locators read from the per-locale table and each one is
+// asserted visible before use, so a missing translation fails at the right step.
+func writeTestSteps(b *strings.Builder, actions []capture.EnhancedAction, params TestGenParams, strs *localeStrings) {
 	groups := GroupActionsByNavigation(actions)
 
+	opts := reproduction.Params{
+		BaseURL:        params.BaseURL,
+		StableLocators: params.LocatorStrategy == LocatorStrategyStable,
+	}
+	if strs != nil {
+		opts.LocatorText = strs.expr
+	}
+
 	for i, group := range groups {
 		testLabel := testLabelForGroup(group, i)
 		fmt.Fprintf(b, "  test('%s', async ({ page }) => {\n", reproduction.EscapeJS(testLabel))
 
-		opts := reproduction.Params{BaseURL: params.BaseURL}
 		var prevTs int64
 		for _, action := range group {
 			reproduction.WritePauseComment(b, prevTs, action.Timestamp, "    // [%ds pause]\n")
 			prevTs = action.Timestamp
+			if strs != nil && usesLocator(action.Type) && reproduction.ChooseLocator(action.Selectors, opts.StableLocators).Text != "" {
+				fmt.Fprintf(b, "    await expect(page.%s).toBeVisible();\n", reproduction.StepLocator(action, opts))
+			}
 			line := reproduction.PlaywrightStep(action, opts)
 			if line != "" {
 				b.WriteString("    " + line + "\n")
@@ -5,27 +5,43 @@ package observe
 
 import (
 	"encoding/json"
+	"sort"
 	"time"
 
 	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/buffers"
 	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
 	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/mcp"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/performance"
 	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/queries"
 )
 
 const wsStatusSummarySampleLimit = 10
 
+// maxVitalsInteractions caps the slowest interactions reported alongside INP.
+const maxVitalsInteractions = 5
+
 // GetNetworkWaterfall returns network waterfall entries from the performance API.
 func GetNetworkWaterfall(deps Deps, req mcp.JSONRPCRequest, args json.RawMessage) mcp.JSONRPCResponse {
 	var params struct {
 		Limit     int    `json:"limit"`
 		URLFilter string `json:"url"`
 		Summary   bool   `json:"summary"`
+		Boundary  string `json:"boundary"`
+		TabID     int    `json:"tab_id"`
 	}
 	mcp.LenientUnmarshal(args, &params)
 	params.Limit = clampLimit(params.Limit, 100)
 
 	allEntries := refreshWaterfallIfStale(deps)
+	if params.Boundary != "" || params.TabID != 0 {
+		scoped := make([]capture.NetworkWaterfallEntry, 0, len(allEntries))
+		for _, entry := range allEntries {
+			if inBoundary(entry.TestIDs, params.Boundary) && inTab(entry.TabID, params.TabID) {
+				scoped = append(scoped, entry)
+			}
+		}
+		allEntries = scoped
+	}
 
 	var newestTS time.Time
 	if len(allEntries) > 0 {
@@ -34,11 +50,13 @@ func GetNetworkWaterfall(deps Deps, req mcp.JSONRPCRequest, args json.RawMessage
 
 	if params.Summary {
 		entries := filterWaterfallSummaryEntries(allEntries, params.URLFilter, params.Limit)
-		return mcp.Succeed(req, "Network waterfall", map[string]any{
+		summary := map[string]any{
 			"entries":  entries,
 			"count":    len(entries),
 			"metadata": BuildResponseMetadata(deps.GetCapture(), newestTS),
-		})
+		}
+		addBoundaryContext(summary, deps, params.Boundary)
+		return mcp.Succeed(req, "Network waterfall", summary)
 	}
 
 	entries := filterWaterfallEntries(allEntries, params.URLFilter, params.Limit)
@@ -50,6 +68,7 @@ func GetNetworkWaterfall(deps Deps, req mcp.JSONRPCRequest, args json.RawMessage
 	if len(entries) == 0 {
 		response["hint"] = networkWaterfallEmptyHint(params.URLFilter)
 	}
+	addBoundaryContext(response, deps, params.Boundary)
 	return mcp.Succeed(req, "Network waterfall", response)
 }
 
@@ -59,6 +78,10 @@ func refreshWaterfallIfStale(deps Deps) []capture.NetworkWaterfallEntry {
 	if len(allEntries) > 0 && time.Since(allEntries[len(allEntries)-1].Timestamp) < 1*time.Second {
 		return allEntries
 	}
+	// An extension that dropped cannot answer; serve the buffer instead of waiting out the query.
+	if !cap.ExtensionLastSeen().IsZero() && !cap.IsExtensionConnected() {
+		return allEntries
+	}
 
 	queryID, qerr := cap.CreatePendingQueryWithTimeout(
 		queries.PendingQuery{
@@ -80,8 +103,10 @@ func refreshWaterfallIfStale(deps Deps) []capture.NetworkWaterfallEntry {
 	var waterfallResult struct {
 		Entries []capture.NetworkWaterfallEntry `json:"entries"`
 		PageURL string                          `json:"page_url"`
+		TabID   int                             `json:"tab_id"`
 	}
 	if err := json.Unmarshal(result, &waterfallResult); err == nil && len(waterfallResult.Entries) > 0 {
+		capture.AttributeWaterfallTab(waterfallResult.Entries, waterfallResult.TabID)
 		cap.AddNetworkWaterfallEntries(waterfallResult.Entries, waterfallResult.PageURL)
 		return cap.GetNetworkWaterfallEntries()
 	}
@@ -101,7 +126,7 @@ func filterWaterfallEntries(allEntries []capture.NetworkWaterfallEntry, urlFilte
 }
 
 func waterfallEntryToMap(entry capture.NetworkWaterfallEntry) map[string]any {
-	return map[string]any{
+	m := map[string]any{
 		"url":               entry.URL,
 		"initiator_type":    entry.InitiatorType,
 		"duration_ms":       entry.Duration,
@@ -112,6 +137,10 @@ func waterfallEntryToMap(entry capture.NetworkWaterfallEntry) map[string]any {
 		"timestamp":         entry.Timestamp,
 		"page_url":          entry.PageURL,
 	}
+	if entry.TabID != 0 {
+		m["tab_id"] = entry.TabID
+	}
+	return m
 }
 
 func waterfallSummaryEntry(entry capture.NetworkWaterfallEntry) map[string]any {
@@ -256,9 +285,42 @@ func buildVitalsMap(snapshots []capture.PerformanceSnapshot) map[string]any {
 	if latest.CLS != nil {
 		vitals["cls"] = *latest.CLS
 	}
+	if latest.Timing.InteractionToNextPaint != nil {
+		vitals["inp"] = *latest.Timing.InteractionToNextPaint
+	}
+	if worst := slowestInteractions(latest.Interactions, maxVitalsInteractions); len(worst) > 0 {
+		vitals["inp_interactions"] = worst
+	}
+	if latest.Emulation != "" {
+		vitals["emulation"] = latest.Emulation
+	}
+	rated := map[string]float64{"ttfb": latest.Timing.TimeToFirstByte}
+	for _, name := range []string{"lcp", "fcp", "cls"} {
+		if v, ok := vitals[name].(float64); ok {
+			rated[name] = v
+		}
+	}
+	if findings := performance.RateVitals(rated); len(findings) > 0 {
+		vitals["findings"] = findings
+	}
 	return vitals
 }
 
+// slowestInteractions returns up to limit interactions, slowest first, without relying on
+// the order the extension sent them in.
+func slowestInteractions(interactions []performance.InteractionTiming, limit int) []performance.InteractionTiming {
+	if len(interactions) == 0 {
+		return nil
+	}
+	sorted := make([]performance.InteractionTiming, len(interactions))
+	copy(sorted, interactions)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Duration > sorted[j].Duration })
+	if len(sorted) > limit {
+		sorted = sorted[:limit]
+	}
+	return sorted
+}
+
 // GetTabs returns information about tracked browser tabs.
 func GetTabs(deps Deps, req mcp.JSONRPCRequest, _ json.RawMessage) mcp.JSONRPCResponse {
 	cap := deps.GetCapture()
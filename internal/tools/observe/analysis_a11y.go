@@ -8,7 +8,9 @@ import (
 	"sort"
 
 	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/a11ysummary"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/contrast"
 	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/mcp"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/remediation"
 )
 
 // RunA11yAudit executes an accessibility audit via the extension.
@@ -52,6 +54,8 @@ func RunA11yAudit(deps Deps, req mcp.JSONRPCRequest, args json.RawMessage) mcp.J
 		return mcp.JSONRPCResponse{JSONRPC: "2.0", ID: req.ID, Result: mcp.StructuredErrorResponse(mcp.ErrInvalidJSON, "Failed to parse a11y result: "+err.Error(), "Check extension logs for errors")}
 	}
 
+	stampA11yFindingIDs(auditResult)
+	attachContrastFixes(auditResult)
 	if params.Summary {
 		return mcp.Succeed(req, "A11y audit", buildA11ySummary(auditResult))
 	}
@@ -66,6 +70,58 @@ func ensureA11ySummary(auditResult map[string]any) {
 	a11ysummary.EnsureAuditSummary(auditResult)
 }
 
+// stampA11yFindingIDs adds a finding_id to each violation so it can be resolved
+// with observe(what="playbook").
+func stampA11yFindingIDs(auditResult map[string]any) {
+	violations, _ := auditResult["violations"].([]any)
+	for _, v := range violations {
+		if vMap, ok := v.(map[string]any); ok {
+			rule, _ := vMap["id"].(string)
+			vMap["finding_id"] = remediation.A11yFindingID(rule)
+		}
+	}
+}
+
+// attachContrastFixes adds a contrast_fix with the nearest AA and AAA colours to each
+// color-contrast node whose failureSummary names its colours, and a contrast_fixes
+// stylesheet of custom property overrides covering every fixed node.
+func attachContrastFixes(auditResult map[string]any) {
+	violations, _ := auditResult["violations"].([]any)
+	var targets []contrast.Target
+	for _, v := range violations {
+		vMap, _ := v.(map[string]any)
+		rule, _ := vMap["id"].(string)
+		level := "AA"
+		switch rule {
+		case contrast.RuleAA:
+		case contrast.RuleAAA:
+			level = "AAA"
+		default:
+			continue
+		}
+		nodes, _ := vMap["nodes"].([]any)
+		for _, n := range nodes {
+			node, _ := n.(map[string]any)
+			summary, _ := node["failureSummary"].(string)
+			fix, ok := contrast.FixFromAxeSummary(summary)
+			if !ok {
+				continue
+			}
+			node["contrast_fix"] = fix
+			selector, _ := node["selector"].(string)
+			targets = append(targets, contrast.Target{Selector: selector, Level: level, Fix: fix})
+		}
+	}
+	if len(targets) == 0 {
+		return
+	}
+	auditResult["contrast_fixes"] = map[string]any{
+		"nodes": len(targets),
+		"css":   contrast.CSSOverrides(targets),
+		"hint":  "Paste css into a stylesheet loaded after the page's own, or copy each suggested colour into the design token it came from. Re-run analyze(what=\"accessibility\", force_refresh=true) to confirm.",
+	}
+}
+
 // buildA11ySummary creates a compact representation of an a11y audit result.
 func buildA11ySummary(auditResult map[string]any) map[string]any {
 	passes, _ := auditResult["passes"].([]any)
@@ -98,9 +154,10 @@ func buildA11ySummary(auditResult map[string]any) map[string]any {
 	topIssues := make([]map[string]any, topN)
 	for i := 0; i < topN; i++ {
 		topIssues[i] = map[string]any{
-			"rule":     issues[i].rule,
-			"count":    issues[i].count,
-			"severity": issues[i].severity,
+			"rule":       issues[i].rule,
+			"count":      issues[i].count,
+			"severity":   issues[i].severity,
+			"finding_id": remediation.A11yFindingID(issues[i].rule),
 		}
 	}
 
@@ -97,6 +97,7 @@ func clustersToResponse(clusters map[string]*errorCluster) []map[string]any {
 			"last_seen":   c.lastSeen,
 			"urls":        urlList,
 			"stack_trace": c.stackTrace,
+			"id":          fingerprintMessage(c.message),
 		})
 	}
 	return result
@@ -29,6 +29,8 @@ func GetScreenshot(deps Deps, req mcp.JSONRPCRequest, args json.RawMessage) mcp.
 		Quality       int    `json:"quality,omitempty"`
 		FullPage      bool   `json:"full_page,omitempty"`
 		Selector      string `json:"selector,omitempty"`
+		Clip          bool   `json:"clip,omitempty"`
+		Highlight     string `json:"highlight_selector,omitempty"`
 		WaitForStable bool   `json:"wait_for_stable,omitempty"`
 		SaveTo        string `json:"save_to,omitempty"`
 	}
@@ -48,6 +50,19 @@ func GetScreenshot(deps Deps, req mcp.JSONRPCRequest, args json.RawMessage) mcp.
 		)}
 	}
 
+	if params.Clip && params.Selector == "" {
+		return mcp.JSONRPCResponse{JSONRPC: "2.0", ID: req.ID, Result: mcp.StructuredErrorResponse(
+			mcp.ErrMissingParam, "clip requires selector",
+			"Pass the CSS selector of the element to capture", mcp.WithParam("selector"),
+		)}
+	}
+	if params.Clip && params.FullPage {
+		return mcp.JSONRPCResponse{JSONRPC: "2.0", ID: req.ID, Result: mcp.StructuredErrorResponse(
+			mcp.ErrInvalidParam, "clip and full_page cannot be combined",
+			"Use clip=true with selector for one element, or full_page=true for the whole page", mcp.WithParam("clip"),
+		)}
+	}
+
 	screenshotParams := map[string]any{}
 	if params.Format != "" {
 		screenshotParams["format"] = params.Format
@@ -61,6 +76,17 @@ func GetScreenshot(deps Deps, req mcp.JSONRPCRequest, args json.RawMessage) mcp.
 	if params.Selector != "" {
 		screenshotParams["selector"] = params.Selector
 	}
+	if params.Clip {
+		screenshotParams["clip"] = true
+	}
+	if params.Highlight != "" {
+		screenshotParams["highlight_selector"] = params.Highlight
+	}
+	// Send the redaction list with the query so a just-configured list applies
+	// before the extension's next sync delivers it.
+	if redact := cap.GetScreenshotRedactSelectors(); len(redact) > 0 {
+		screenshotParams["redact_selectors"] = redact
+	}
 	if params.WaitForStable {
 		screenshotParams["wait_for_stable"] = true
 	}
@@ -83,7 +109,8 @@ func GetScreenshot(deps Deps, req mcp.JSONRPCRequest, args json.RawMessage) mcp.
 
 	result, err := cap.WaitForResult(queryID, 20*time.Second)
 	if err != nil {
-		return mcp.JSONRPCResponse{JSONRPC: "2.0", ID: req.ID, Result: mcp.StructuredErrorResponse(mcp.ErrExtTimeout, "Screenshot capture timeout: "+err.Error(), "Ensure the extension is connected and the page has loaded. Try refreshing the page, then retry.", mcp.WithHint(deps.DiagnosticHintString()))}
+		code, opts := mcp.ExtensionWaitFailure(err)
+		return mcp.JSONRPCResponse{JSONRPC: "2.0", ID: req.ID, Result: mcp.StructuredErrorResponse(code, "Screenshot capture timeout: "+err.Error(), "Ensure the extension is connected and the page has loaded. Try refreshing the page, then retry.", append(opts, mcp.WithHint(deps.DiagnosticHintString()))...)}
 	}
 
 	var screenshotResult map[string]any
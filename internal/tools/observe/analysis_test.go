@@ -14,6 +14,7 @@ import (
 
 	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
 	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/mcp"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/performance"
 )
 
 // ============================================
@@ -221,6 +222,42 @@ func TestBuildTimelineSummary_Empty(t *testing.T) {
 	}
 }
 
+// ============================================
+// Web Vitals Tests
+// ============================================
+
+func TestBuildVitalsMap_INPInteractionsSlowestFirst(t *testing.T) {
+	t.Parallel()
+	inp := 480.0
+	interactions := []performance.InteractionTiming{
+		{InteractionID: 1, Name: "keydown", Target: "input#search", Duration: 120},
+		{InteractionID: 2, Name: "click", Target: "button#checkout", Duration: 480, InputDelay: 12, ProcessingTime: 410, PresentationDelay: 58},
+	}
+	for i := 3; i <= 8; i++ {
+		interactions = append(interactions, performance.InteractionTiming{InteractionID: int64(i), Name: "pointerdown", Duration: 40})
+	}
+
+	vitals := buildVitalsMap([]capture.PerformanceSnapshot{{
+		URL:          "/cart",
+		Timing:       performance.Timing{InteractionToNextPaint: &inp},
+		Interactions: interactions,
+	}})
+
+	if vitals["inp"] != inp {
+		t.Errorf("inp = %v, want %v", vitals["inp"], inp)
+	}
+	worst, ok := vitals["inp_interactions"].([]performance.InteractionTiming)
+	if !ok || len(worst) != maxVitalsInteractions {
+		t.Fatalf("inp_interactions = %#v, want %d entries", vitals["inp_interactions"], maxVitalsInteractions)
+	}
+	if worst[0].Target != "button#checkout" || worst[0].ProcessingTime != 410 || worst[1].Name != "keydown" {
+		t.Errorf("inp_interactions not slowest first: %+v", worst[:2])
+	}
+	if interactions[0].InteractionID != 1 {
+		t.Error("buildVitalsMap must not reorder the snapshot's interactions")
+	}
+}
+
 // ============================================
 // History Limit Tests
 // ============================================
@@ -567,3 +604,48 @@ func TestRunA11yAudit_ResultWithErrorFieldReturnsGracefully(t *testing.T) {
 		t.Errorf("response should preserve error field, got: %s", text)
 	}
 }
+
+func TestRunA11yAudit_AttachesContrastFixes(t *testing.T) {
+	t.Parallel()
+	cap := capture.NewCapture()
+	cap.SetTrackingStatusForTest(1, "https://example.com")
+
+	resultJSON, _ := json.Marshal(map[string]any{
+		"violations": []any{
+			map[string]any{"id": "color-contrast", "impact": "serious", "nodes": []any{
+				map[string]any{"selector": ".muted", "failureSummary": "Fix any of the following:\n  Element has insufficient color contrast of 2.85 (foreground color: #999999, background color: #ffffff, font size: 12.0pt (16px), font weight: normal). Expected contrast ratio of 4.5:1"},
+				map[string]any{"selector": ".overlay", "failureSummary": "Fix any of the following:\n  Element's background color could not be determined due to a background image"},
+			}},
+			map[string]any{"id": "image-alt", "impact": "critical", "nodes": []any{map[string]any{"selector": "img"}}},
+		},
+	})
+	deps := &mockA11yDeps{cap: cap, a11yResult: resultJSON}
+
+	req := mcp.JSONRPCRequest{JSONRPC: "2.0", ID: json.RawMessage(`1`)}
+	var result mcp.MCPToolResult
+	if err := json.Unmarshal(RunA11yAudit(deps, req, json.RawMessage(`{}`)).Result, &result); err != nil {
+		t.Fatalf("failed to parse result: %v", err)
+	}
+	text := result.Content[0].Text
+	var data map[string]any
+	if err := json.Unmarshal([]byte(text[strings.Index(text, "{"):]), &data); err != nil {
+		t.Fatalf("failed to parse audit JSON: %v", err)
+	}
+
+	nodes := data["violations"].([]any)[0].(map[string]any)["nodes"].([]any)
+	fix, ok := nodes[0].(map[string]any)["contrast_fix"].(map[string]any)
+	if !ok {
+		t.Fatalf("first node has no contrast_fix: %v", nodes[0])
+	}
+	if aa := fix["aa"].(map[string]any); aa["foreground"].(map[string]any)["color"] != "#767676" {
+		t.Errorf("aa suggestion = %v, want #767676", aa)
+	}
+	if _, ok := nodes[1].(map[string]any)["contrast_fix"]; ok {
+		t.Error("node without colours got a contrast_fix")
+	}
+	fixes := data["contrast_fixes"].(map[string]any)
+	css, _ := fixes["css"].(string)
+	if fixes["nodes"] != float64(1) || !strings.Contains(css, ".muted {\n  color: var(--kaboom-contrast-999999-on-ffffff);") {
+		t.Errorf("contrast_fixes = %v", fixes)
+	}
+}
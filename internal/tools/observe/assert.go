@@ -0,0 +1,528 @@
+// Purpose: Handles observe(what:"assert"): evaluates DOM, URL, title, console, and network conditions in one call.
+// Why: Agents verifying a step need a deterministic pass/fail with evidence instead of re-reading raw buffers.
+// Docs: docs/features/feature/assert/index.md
+
+package observe
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/mcp"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/queries"
+)
+
+// MaxAssertConditions caps the conditions in one assert call.
+const MaxAssertConditions = 20
+
+// maxAssertSamples bounds the errors or failed requests listed as evidence.
+const maxAssertSamples = 3
+
+// assertQueryTimeout bounds the page snapshot round trip.
+const assertQueryTimeout = 10 * time.Second
+
+// Assert condition kinds.
+const (
+	AssertSelector        = "selector"
+	AssertURLMatches      = "url_matches"
+	AssertTitleContains   = "title_contains"
+	AssertNoConsoleErrors = "no_console_errors_since"
+	AssertNoNetworkErrors = "no_network_errors_since"
+)
+
+// AssertCondition is one condition of observe(what="assert"). Exactly one kind is set:
+// selector (checked with exists, visible, count, or text_contains; exists=true by default),
+// url_matches, title_contains, no_console_errors_since, or no_network_errors_since.
+type AssertCondition struct {
+	Selector             string `json:"selector,omitempty"`
+	Exists               *bool  `json:"exists,omitempty"`
+	Visible              *bool  `json:"visible,omitempty"`
+	Count                *int   `json:"count,omitempty"`
+	TextContains         string `json:"text_contains,omitempty"`
+	URLMatches           string `json:"url_matches,omitempty"`    // regular expression
+	TitleContains        string `json:"title_contains,omitempty"` // case-sensitive substring
+	NoConsoleErrorsSince string `json:"no_console_errors_since,omitempty"`
+	NoNetworkErrorsSince string `json:"no_network_errors_since,omitempty"` // status >= 400
+}
+
+// Kind returns the condition's kind, or an error when it sets none or several.
+func (c AssertCondition) Kind() (string, error) {
+	var kinds []string
+	if c.Selector != "" {
+		kinds = append(kinds, AssertSelector)
+	}
+	if c.URLMatches != "" {
+		kinds = append(kinds, AssertURLMatches)
+	}
+	if c.TitleContains != "" {
+		kinds = append(kinds, AssertTitleContains)
+	}
+	if c.NoConsoleErrorsSince != "" {
+		kinds = append(kinds, AssertNoConsoleErrors)
+	}
+	if c.NoNetworkErrorsSince != "" {
+		kinds = append(kinds, AssertNoNetworkErrors)
+	}
+	switch {
+	case len(kinds) == 0:
+		return "", errors.New("set one of selector, url_matches, title_contains, no_console_errors_since, or no_network_errors_since")
+	case len(kinds) > 1:
+		return "", fmt.Errorf("set only one of %s; use separate conditions", strings.Join(kinds, ", "))
+	}
+	if kinds[0] != AssertSelector && (c.Exists != nil || c.Visible != nil || c.Count != nil || c.TextContains != "") {
+		return "", errors.New("exists, visible, count, and text_contains need a selector")
+	}
+	return kinds[0], nil
+}
+
+// needsPage reports whether the condition is evaluated against the page snapshot.
+func (c AssertCondition) needsPage() bool {
+	return c.Selector != "" || c.URLMatches != "" || c.TitleContains != ""
+}
+
+// AssertElement is the page's view of one selector condition.
+type AssertElement struct {
+	Selector     string   `json:"selector"`
+	Count        int      `json:"count"`
+	VisibleCount int      `json:"visible_count"`
+	Texts        []string `json:"texts,omitempty"`      // first matches' text, trimmed
+	TextMatch    string   `json:"text_match,omitempty"` // text of the first match containing text_contains
+	Error        string   `json:"error,omitempty"`      // invalid selector
+}
+
+// AssertPage is the snapshot the extension takes in one pass over the tracked page.
+type AssertPage struct {
+	URL      string          `json:"url"`
+	Title    string          `json:"title"`
+	Elements []AssertElement `json:"elements"`
+	Error    string          `json:"error,omitempty"`
+	Message  string          `json:"message,omitempty"`
+}
+
+// AssertCheck is the outcome of one condition.
+type AssertCheck struct {
+	Index    int    `json:"index"`
+	Check    string `json:"check"`
+	Target   string `json:"target"`
+	Passed   bool   `json:"passed"`
+	Detail   string `json:"detail"`
+	Evidence any    `json:"evidence,omitempty"`
+}
+
+// assertPlan is a validated assert call: conditions with their compiled patterns and
+// resolved since points.
+type assertPlan struct {
+	conditions []AssertCondition
+	kinds      []string
+	patterns   map[int]*regexp.Regexp
+	since      map[int]time.Time
+}
+
+// planAssert validates conditions and resolves their patterns and since points.
+func planAssert(cap *capture.Store, conditions []AssertCondition, now time.Time) (assertPlan, error) {
+	if len(conditions) == 0 || len(conditions) > MaxAssertConditions {
+		return assertPlan{}, fmt.Errorf("conditions must have 1 to %d entries", MaxAssertConditions)
+	}
+	plan := assertPlan{conditions: conditions, patterns: map[int]*regexp.Regexp{}, since: map[int]time.Time{}}
+	for i, c := range conditions {
+		kind, err := c.Kind()
+		if err != nil {
+			return assertPlan{}, fmt.Errorf("conditions[%d]: %w", i, err)
+		}
+		plan.kinds = append(plan.kinds, kind)
+		switch kind {
+		case AssertSelector:
+			if c.Count != nil && *c.Count < 0 {
+				return assertPlan{}, fmt.Errorf("conditions[%d]: count must be >= 0", i)
+			}
+		case AssertURLMatches:
+			re, err := regexp.Compile(c.URLMatches)
+			if err != nil {
+				return assertPlan{}, fmt.Errorf("conditions[%d]: invalid url_matches pattern: %w", i, err)
+			}
+			plan.patterns[i] = re
+		case AssertNoConsoleErrors, AssertNoNetworkErrors:
+			raw := c.NoConsoleErrorsSince + c.NoNetworkErrorsSince
+			since, err := resolveAssertSince(cap, raw, now)
+			if err != nil {
+				return assertPlan{}, fmt.Errorf("conditions[%d]: %w", i, err)
+			}
+			plan.since[i] = since
+		}
+	}
+	return plan, nil
+}
+
+// resolveAssertSince accepts "last_action" (the newest recorded action, or the start of
+// capture when there is none), a duration back from now ("30s"), an RFC3339 timestamp, or a cursor.
+func resolveAssertSince(cap *capture.Store, raw string, now time.Time) (time.Time, error) {
+	if raw == "last_action" {
+		var latest time.Time
+		for _, a := range cap.GetAllEnhancedActions() {
+			if ts := time.UnixMilli(a.Timestamp); ts.After(latest) {
+				latest = ts
+			}
+		}
+		return latest, nil
+	}
+	if d, err := time.ParseDuration(raw); err == nil {
+		if d <= 0 {
+			return time.Time{}, fmt.Errorf("since duration must be positive, got %q", raw)
+		}
+		return now.Add(-d), nil
+	}
+	ts, err := ParseVerifySince(raw)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("since must be 'last_action', a duration like 30s, an RFC3339 timestamp, or a cursor, got %q", raw)
+	}
+	return ts, nil
+}
+
+// pageChecks lists the selector conditions in the order the extension answers them.
+func (p assertPlan) pageChecks() []map[string]any {
+	checks := []map[string]any{}
+	for _, c := range p.conditions {
+		if c.Selector == "" {
+			continue
+		}
+		check := map[string]any{"selector": c.Selector}
+		if c.TextContains != "" {
+			check["text_contains"] = c.TextContains
+		}
+		checks = append(checks, check)
+	}
+	return checks
+}
+
+func (p assertPlan) needsPage() bool {
+	for _, c := range p.conditions {
+		if c.needsPage() {
+			return true
+		}
+	}
+	return false
+}
+
+// evaluate judges every condition against page (nil when no condition needs it) and
+// the capture buffers as they stand.
+func (p assertPlan) evaluate(deps Deps, page *AssertPage) []AssertCheck {
+	checks := make([]AssertCheck, 0, len(p.conditions))
+	element := 0
+	for i, c := range p.conditions {
+		var check AssertCheck
+		switch p.kinds[i] {
+		case AssertSelector:
+			var el AssertElement
+			if page != nil && element < len(page.Elements) {
+				el = page.Elements[element]
+			}
+			element++
+			check = checkSelector(c, el)
+		case AssertURLMatches:
+			check = checkURLMatches(c.URLMatches, p.patterns[i], page.URL)
+		case AssertTitleContains:
+			check = checkTitleContains(c.TitleContains, page.Title)
+		case AssertNoConsoleErrors:
+			check = checkNoConsoleErrors(deps, c.NoConsoleErrorsSince, p.since[i])
+		case AssertNoNetworkErrors:
+			check = checkNoNetworkErrors(deps.GetCapture(), c.NoNetworkErrorsSince, p.since[i])
+		}
+		check.Index = i
+		checks = append(checks, check)
+	}
+	return checks
+}
+
+func checkSelector(c AssertCondition, el AssertElement) AssertCheck {
+	check := AssertCheck{Check: AssertSelector, Target: c.Selector}
+	evidence := map[string]any{"count": el.Count, "visible_count": el.VisibleCount}
+	if len(el.Texts) > 0 {
+		evidence["texts"] = el.Texts
+	}
+	check.Evidence = evidence
+	if el.Error != "" {
+		check.Detail = "Selector failed in the page: " + el.Error
+		return check
+	}
+
+	var failures, passes []string
+	judge := func(ok bool, pass, fail string) {
+		if ok {
+			passes = append(passes, pass)
+		} else {
+			failures = append(failures, fail)
+		}
+	}
+	exists := c.Exists == nil && c.Visible == nil && c.Count == nil && c.TextContains == ""
+	if c.Exists != nil {
+		exists = *c.Exists
+		if !exists {
+			judge(el.Count == 0, "no element matches", fmt.Sprintf("expected no match, found %d", el.Count))
+		}
+	}
+	if exists {
+		judge(el.Count > 0, fmt.Sprintf("%d element(s) match", el.Count), "no element matches")
+	}
+	if c.Count != nil {
+		judge(el.Count == *c.Count, fmt.Sprintf("count is %d", el.Count), fmt.Sprintf("expected count %d, found %d", *c.Count, el.Count))
+	}
+	if c.Visible != nil {
+		if *c.Visible {
+			judge(el.VisibleCount > 0, fmt.Sprintf("%d visible", el.VisibleCount), fmt.Sprintf("none of %d match(es) is visible", el.Count))
+		} else {
+			judge(el.VisibleCount == 0, "none visible", fmt.Sprintf("expected hidden, %d visible", el.VisibleCount))
+		}
+	}
+	if c.TextContains != "" {
+		if el.TextMatch != "" {
+			evidence["text_match"] = el.TextMatch
+		}
+		judge(el.TextMatch != "", fmt.Sprintf("text contains %q", c.TextContains), fmt.Sprintf("no match contains text %q", c.TextContains))
+	}
+
+	check.Passed = len(failures) == 0
+	if check.Passed {
+		check.Detail = strings.Join(passes, "; ")
+	} else {
+		check.Detail = strings.Join(failures, "; ")
+	}
+	return check
+}
+
+func checkURLMatches(pattern string, re *regexp.Regexp, url string) AssertCheck {
+	check := AssertCheck{Check: AssertURLMatches, Target: pattern, Evidence: map[string]any{"url": url}}
+	check.Passed = re.MatchString(url)
+	if check.Passed {
+		check.Detail = "URL matches"
+	} else {
+		check.Detail = "URL " + url + " does not match"
+	}
+	return check
+}
+
+func checkTitleContains(want, title string) AssertCheck {
+	check := AssertCheck{Check: AssertTitleContains, Target: want, Evidence: map[string]any{"title": title}}
+	check.Passed = strings.Contains(title, want)
+	if check.Passed {
+		check.Detail = "Title contains the text"
+	} else {
+		check.Detail = fmt.Sprintf("Title %q does not contain the text", title)
+	}
+	return check
+}
+
+// checkNoConsoleErrors passes when the tracked tab logged no error since since. Noise-rule
+// matches are left out, as in observe errors.
+func checkNoConsoleErrors(deps Deps, raw string, since time.Time) AssertCheck {
+	check := AssertCheck{Check: AssertNoConsoleErrors, Target: raw}
+	_, trackedTabID, _ := deps.GetCapture().GetTrackingStatus()
+	entries, added := deps.GetLogEntries()
+	count, suppressed := 0, 0
+	samples := []map[string]any{}
+	for i, entry := range entries {
+		if level, _ := entry["level"].(string); level != "error" {
+			continue
+		}
+		ts, err := time.Parse(time.RFC3339Nano, logEntryTimestamp(entry))
+		if err != nil && i < len(added) {
+			ts = added[i]
+		}
+		if ts.Before(since) {
+			continue
+		}
+		if tabID, ok := entry["tabId"].(float64); ok && trackedTabID != 0 && int(tabID) != trackedTabID {
+			continue
+		}
+		if classified, drop := classifyConsoleNoise(deps, entry); drop || classified["level"] != "error" {
+			suppressed++
+			continue
+		}
+		count++
+		if len(samples) < maxAssertSamples {
+			msg, _ := entry["message"].(string)
+			samples = append(samples, map[string]any{"message": truncateDetail(msg), "timestamp": ts.UTC().Format(time.RFC3339Nano)})
+		}
+	}
+	evidence := map[string]any{"since": since.UTC().Format(time.RFC3339Nano), "errors": count}
+	if suppressed > 0 {
+		evidence["noise_suppressed"] = suppressed
+	}
+	if count > 0 {
+		evidence["samples"] = samples
+	}
+	check.Evidence = evidence
+	check.Passed = count == 0
+	if check.Passed {
+		check.Detail = "No console errors since " + evidence["since"].(string)
+	} else {
+		check.Detail = fmt.Sprintf("%d console error(s) since %s; first: %s", count, evidence["since"], samples[0]["message"])
+	}
+	return check
+}
+
+// checkNoNetworkErrors passes when the tracked tab got no response with status >= 400 since since.
+func checkNoNetworkErrors(cap *capture.Store, raw string, since time.Time) AssertCheck {
+	check := AssertCheck{Check: AssertNoNetworkErrors, Target: raw}
+	_, trackedTabID, _ := cap.GetTrackingStatus()
+	bodies, added := cap.GetNetworkBodiesWithTimestamps()
+	failed, requests := 0, 0
+	samples := []map[string]any{}
+	for i, b := range bodies {
+		ts, err := time.Parse(time.RFC3339Nano, b.Timestamp)
+		if err != nil && i < len(added) {
+			ts = added[i]
+		}
+		if ts.Before(since) || (trackedTabID != 0 && b.TabID != 0 && b.TabID != trackedTabID) {
+			continue
+		}
+		requests++
+		if b.Status < 400 {
+			continue
+		}
+		failed++
+		if len(samples) < maxAssertSamples {
+			samples = append(samples, map[string]any{"method": b.Method, "url": b.URL, "status": b.Status})
+		}
+	}
+	evidence := map[string]any{"since": since.UTC().Format(time.RFC3339Nano), "requests": requests, "failed": failed}
+	if failed > 0 {
+		evidence["samples"] = samples
+	}
+	check.Evidence = evidence
+	check.Passed = failed == 0
+	if check.Passed {
+		check.Detail = fmt.Sprintf("%d request(s) since %s, none failed", requests, evidence["since"])
+	} else {
+		check.Detail = fmt.Sprintf("%d of %d request(s) failed since %s; first: %s %s -> %d",
+			failed, requests, evidence["since"], samples[0]["method"], samples[0]["url"], samples[0]["status"])
+	}
+	return check
+}
+
+// GetAssert evaluates conditions together: DOM, URL, and title conditions against one
+// snapshot of the tracked page, console and network conditions against the buffers as they
+// stand when that snapshot returns. A failed condition is a result, not a tool error.
+func GetAssert(deps Deps, req mcp.JSONRPCRequest, args json.RawMessage) mcp.JSONRPCResponse {
+	var params struct {
+		Conditions []AssertCondition `json:"conditions"`
+	}
+	if len(args) > 0 {
+		if err := json.Unmarshal(args, &params); err != nil {
+			return mcp.JSONRPCResponse{JSONRPC: "2.0", ID: req.ID, Result: mcp.StructuredErrorResponse(
+				mcp.ErrInvalidJSON, "Invalid JSON arguments: "+err.Error(), "Fix JSON syntax and call again",
+			)}
+		}
+	}
+	cap := deps.GetCapture()
+	plan, err := planAssert(cap, params.Conditions, time.Now())
+	if err != nil {
+		return mcp.JSONRPCResponse{JSONRPC: "2.0", ID: req.ID, Result: mcp.StructuredErrorResponse(
+			mcp.ErrInvalidParam, "Invalid assert conditions: "+err.Error(),
+			`Pass conditions like [{"selector":"#toast","text_contains":"Saved"},{"url_matches":"/orders/\\d+"},{"no_console_errors_since":"last_action"}]`,
+			mcp.WithParam("conditions"),
+		)}
+	}
+
+	var page *AssertPage
+	if plan.needsPage() {
+		snapshot, errResp := fetchAssertPage(deps, req, plan.pageChecks())
+		if errResp != nil {
+			return *errResp
+		}
+		page = snapshot
+	}
+	now := time.Now()
+	checks := plan.evaluate(deps, page)
+
+	passedCount := 0
+	for _, c := range checks {
+		if c.Passed {
+			passedCount++
+		}
+	}
+	passed := passedCount == len(checks)
+	response := map[string]any{
+		"passed":       passed,
+		"passed_count": passedCount,
+		"failed_count": len(checks) - passedCount,
+		"results":      checks,
+		"evaluated_at": now.UTC().Format(time.RFC3339Nano),
+		"metadata":     BuildResponseMetadata(cap, now),
+	}
+	if page != nil {
+		response["url"] = page.URL
+		response["title"] = page.Title
+	}
+	verdict := "passed"
+	if !passed {
+		verdict = "failed"
+	}
+	return mcp.Succeed(req, fmt.Sprintf("Assert %s: %d/%d conditions passed", verdict, passedCount, len(checks)), response)
+}
+
+// fetchAssertPage asks the extension for URL, title, and every selector check in one pass.
+func fetchAssertPage(deps Deps, req mcp.JSONRPCRequest, checks []map[string]any) (*AssertPage, *mcp.JSONRPCResponse) {
+	cap := deps.GetCapture()
+	fail := func(result json.RawMessage) (*AssertPage, *mcp.JSONRPCResponse) {
+		return nil, &mcp.JSONRPCResponse{JSONRPC: "2.0", ID: req.ID, Result: result}
+	}
+	if enabled, _, _ := cap.GetTrackingStatus(); !enabled {
+		return fail(mcp.StructuredErrorResponse(
+			mcp.ErrNoData,
+			"No tab is being tracked. Open the Kaboom extension popup and click 'Track This Tab'.",
+			"Track a tab first, or assert only no_console_errors_since and no_network_errors_since.",
+			mcp.WithHint(deps.DiagnosticHintString()),
+		))
+	}
+
+	queryParams, _ := json.Marshal(map[string]any{"checks": checks})
+	queryID, qerr := cap.CreatePendingQueryWithTimeout(
+		queries.PendingQuery{Type: "assert", Params: queryParams},
+		assertQueryTimeout,
+		req.ClientID,
+	)
+	if qerr != nil {
+		return fail(mcp.StructuredErrorResponse(
+			mcp.ErrQueueFull,
+			"Command queue full: "+qerr.Error(),
+			"Wait for in-flight commands to complete, then retry.",
+			mcp.WithRecoveryToolCall(map[string]any{"tool": "observe", "arguments": map[string]any{"what": "pending_commands"}}),
+		))
+	}
+	result, err := cap.WaitForResult(queryID, assertQueryTimeout)
+	if err != nil {
+		code, opts := mcp.ExtensionWaitFailure(err)
+		return fail(mcp.StructuredErrorResponse(
+			code,
+			"Assert timeout: "+err.Error(),
+			"Ensure the extension is connected and the page has loaded.",
+			append(opts, mcp.WithHint(deps.DiagnosticHintString()))...,
+		))
+	}
+
+	var page AssertPage
+	if err := json.Unmarshal(result, &page); err != nil {
+		return fail(mcp.StructuredErrorResponse(
+			mcp.ErrInvalidJSON,
+			"Failed to parse assert result: "+err.Error(),
+			"Check extension logs for errors",
+		))
+	}
+	if page.Error != "" {
+		detail := page.Message
+		if detail == "" {
+			detail = page.Error
+		}
+		return fail(mcp.StructuredErrorResponse(
+			mcp.ErrExtError,
+			"Assert failed in the page: "+detail,
+			"The tracked page may be a browser page (chrome://) that cannot be scripted. Navigate to a regular page and retry.",
+		))
+	}
+	return &page, nil
+}
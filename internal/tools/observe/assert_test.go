@@ -0,0 +1,42 @@
+// assert_test.go — Tests for selector condition judgement in observe(what="assert").
+package observe
+
+import "testing"
+
+func TestCheckSelector(t *testing.T) {
+	t.Parallel()
+	yes, no := true, false
+	two, zero := 2, 0
+	toast := AssertElement{Selector: ".toast", Count: 2, VisibleCount: 1, Texts: []string{"Draft", "Saved"}, TextMatch: "Saved"}
+	none := AssertElement{Selector: ".toast"}
+
+	tests := []struct {
+		name string
+		cond AssertCondition
+		el   AssertElement
+		want bool
+	}{
+		{"exists by default", AssertCondition{Selector: ".toast"}, toast, true},
+		{"exists by default, no match", AssertCondition{Selector: ".toast"}, none, false},
+		{"exists false", AssertCondition{Selector: ".toast", Exists: &no}, none, true},
+		{"exists false, match", AssertCondition{Selector: ".toast", Exists: &no}, toast, false},
+		{"count", AssertCondition{Selector: ".toast", Count: &two}, toast, true},
+		{"count zero", AssertCondition{Selector: ".toast", Count: &zero}, toast, false},
+		{"visible", AssertCondition{Selector: ".toast", Visible: &yes}, toast, true},
+		{"hidden", AssertCondition{Selector: ".toast", Visible: &no}, toast, false},
+		{"hidden, no match", AssertCondition{Selector: ".toast", Visible: &no}, none, true},
+		{"text", AssertCondition{Selector: ".toast", TextContains: "Saved"}, toast, true},
+		{"text missing", AssertCondition{Selector: ".toast", TextContains: "Saved"}, AssertElement{Count: 1, VisibleCount: 1}, false},
+		{"visible and text", AssertCondition{Selector: ".toast", Visible: &yes, TextContains: "Saved"}, toast, true},
+		{"invalid selector", AssertCondition{Selector: "[x"}, AssertElement{Error: "not a valid selector"}, false},
+	}
+	for _, tt := range tests {
+		check := checkSelector(tt.cond, tt.el)
+		if check.Passed != tt.want {
+			t.Errorf("%s: passed = %v (%s), want %v", tt.name, check.Passed, check.Detail, tt.want)
+		}
+		if check.Detail == "" {
+			t.Errorf("%s: empty detail", tt.name)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+// Purpose: Implements observe(what="auth_state") — decoded JWT claims and expiry status from captured traffic.
+// Why: Lets agents diagnose 401s and silent session loss without exposing raw token values.
+// Docs: docs/features/feature/security-hardening/index.md
+
+package observe
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/mcp"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/security"
+)
+
+// GetAuthState scans captured network bodies for JWTs and reports issuer, audience, and expiry per token.
+func GetAuthState(deps Deps, req mcp.JSONRPCRequest, args json.RawMessage) mcp.JSONRPCResponse {
+	var params struct {
+		URL                   string `json:"url"`
+		ExpiringWithinSeconds int    `json:"expiring_within_seconds"`
+	}
+	mcp.LenientUnmarshal(args, &params)
+	if params.ExpiringWithinSeconds < 0 {
+		return mcp.Fail(req, mcp.ErrInvalidParam, "expiring_within_seconds must be >= 0",
+			"Omit it to use the default 300-second window", mcp.WithParam("expiring_within_seconds"))
+	}
+	within := security.DefaultExpiringWithin
+	if params.ExpiringWithinSeconds > 0 {
+		within = time.Duration(params.ExpiringWithinSeconds) * time.Second
+	}
+
+	cap := deps.GetCapture()
+	bodies := cap.GetNetworkBodies()
+	if params.URL != "" {
+		matched := make([]capture.NetworkBody, 0, len(bodies))
+		for _, b := range bodies {
+			if ContainsIgnoreCase(b.URL, params.URL) {
+				matched = append(matched, b)
+			}
+		}
+		bodies = matched
+	}
+
+	now := time.Now()
+	tokens, summary := security.ScanAuthTokens(bodies, now, within)
+	result := map[string]any{
+		"tokens":                  tokens,
+		"count":                   len(tokens),
+		"summary":                 summary,
+		"expiring_within_seconds": int(within.Seconds()),
+		"metadata":                BuildResponseMetadata(cap, now),
+	}
+	if len(tokens) == 0 {
+		result["hint"] = "No JWTs found in captured Authorization/Cookie headers, Set-Cookie headers, or bodies. Trigger an authenticated request, then retry."
+	}
+	return mcp.Succeed(req, authStateSummaryLine(summary), result)
+}
+
+func authStateSummaryLine(s security.AuthStateSummary) string {
+	if s.Total == 0 {
+		return "Auth state: no tokens observed"
+	}
+	return fmt.Sprintf("Auth state: %d token(s), %d expired, %d expiring soon", s.Total, s.Expired, s.ExpiringSoon)
+}
@@ -0,0 +1,145 @@
+// Purpose: Scopes observe results to one test boundary and summarizes what a boundary captured.
+// Why: "Everything from test checkout_flow" needs every buffer filtered by the same boundary ID.
+// Docs: docs/features/feature/test-boundaries/index.md
+
+package observe
+
+import (
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/mcp"
+)
+
+// maxBoundaryFailedSamples bounds the failed requests listed in a boundary summary.
+const maxBoundaryFailedSamples = 5
+
+// inBoundary reports whether an entry tagged with testIDs belongs to boundary.
+// An empty boundary matches every entry.
+func inBoundary(testIDs []string, boundary string) bool {
+	return boundary == "" || capture.TaggedWith(testIDs, boundary)
+}
+
+// logTestIDs returns the test_ids stamped on a log entry. Entries read back from the
+// log file carry them as []any rather than []string.
+func logTestIDs(entry mcp.LogEntry) []string {
+	switch ids := entry["test_ids"].(type) {
+	case []string:
+		return ids
+	case []any:
+		out := make([]string, 0, len(ids))
+		for _, id := range ids {
+			if s, ok := id.(string); ok {
+				out = append(out, s)
+			}
+		}
+		return out
+	}
+	return nil
+}
+
+// logInBoundary reports whether a log entry's test_ids include boundary.
+func logInBoundary(entry mcp.LogEntry, boundary string) bool {
+	return boundary == "" || inBoundary(logTestIDs(entry), boundary)
+}
+
+// BoundarySummary reports a boundary's window and what was captured inside it: errors,
+// failed requests, actions, and event counts. Counts only cover entries still buffered.
+func BoundarySummary(cap *capture.Store, logs []mcp.LogEntry, id string, now time.Time) map[string]any {
+	summary := map[string]any{"test_id": id}
+	if b, ok := cap.GetTestBoundary(id); ok {
+		end := b.EndedAt
+		if b.Active() {
+			end = now
+		} else {
+			summary["ended_at"] = b.EndedAt.UTC().Format(time.RFC3339Nano)
+		}
+		summary["started_at"] = b.StartedAt.UTC().Format(time.RFC3339Nano)
+		summary["active"] = b.Active()
+		summary["duration_ms"] = end.Sub(b.StartedAt).Milliseconds()
+	}
+
+	errorCount, logCount := 0, 0
+	for _, entry := range logs {
+		if !logInBoundary(entry, id) {
+			continue
+		}
+		logCount++
+		if level, _ := entry["level"].(string); level == "error" {
+			errorCount++
+		}
+	}
+
+	failed := 0
+	samples := []map[string]any{}
+	bodies := cap.GetNetworkBodies()
+	requests := 0
+	for _, b := range bodies {
+		if !inBoundary(b.TestIDs, id) {
+			continue
+		}
+		requests++
+		if b.Status < 400 {
+			continue
+		}
+		failed++
+		if len(samples) < maxBoundaryFailedSamples {
+			samples = append(samples, map[string]any{"method": b.Method, "url": b.URL, "status": b.Status})
+		}
+	}
+
+	actions := 0
+	for _, a := range cap.GetAllEnhancedActions() {
+		if inBoundary(a.TestIDs, id) {
+			actions++
+		}
+	}
+	resources := 0
+	for _, w := range cap.GetNetworkWaterfallEntries() {
+		if inBoundary(w.TestIDs, id) {
+			resources++
+		}
+	}
+	wsEvents := 0
+	for _, e := range cap.GetAllWebSocketEvents() {
+		if inBoundary(e.TestIDs, id) {
+			wsEvents++
+		}
+	}
+	sseEvents := 0
+	for _, e := range cap.GetAllSSEEvents() {
+		if inBoundary(e.TestIDs, id) {
+			sseEvents++
+		}
+	}
+
+	summary["errors"] = errorCount
+	summary["logs"] = logCount
+	summary["failed_requests"] = failed
+	summary["network_bodies"] = requests
+	summary["network_resources"] = resources
+	summary["actions"] = actions
+	summary["websocket_events"] = wsEvents
+	summary["sse_events"] = sseEvents
+	if len(samples) > 0 {
+		summary["failed_request_samples"] = samples
+	}
+	return summary
+}
+
+// addBoundaryContext attaches the boundary summary to a boundary-scoped response, with a
+// hint when no boundary by that ID was ever started.
+func addBoundaryContext(response map[string]any, deps Deps, boundary string) {
+	if boundary == "" {
+		return
+	}
+	logs, _ := deps.GetLogEntries()
+	response["boundary"] = BoundarySummary(deps.GetCapture(), logs, boundary, time.Now())
+	if _, ok := deps.GetCapture().GetTestBoundary(boundary); !ok {
+		response["boundary_hint"] = boundaryUnknownHint(boundary)
+	}
+}
+
+func boundaryUnknownHint(boundary string) string {
+	return "No test boundary '" + boundary + "' was recorded. Entries are tagged only while a boundary is active: call configure(what=\"test_boundary_start\", test_id=\"" + boundary + "\") before the test and test_boundary_end after it."
+}
@@ -0,0 +1,142 @@
+// Purpose: Tests boundary-scoped observe filtering and per-boundary summaries.
+// Docs: docs/features/feature/test-boundaries/index.md
+
+package observe
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/mcp"
+)
+
+// mockBoundaryDeps serves a capture store plus fixed log entries.
+type mockBoundaryDeps struct {
+	mockTransientDeps
+	logs []mcp.LogEntry
+}
+
+func (m *mockBoundaryDeps) GetLogEntries() ([]mcp.LogEntry, []time.Time) {
+	return m.logs, make([]time.Time, len(m.logs))
+}
+
+func newBoundaryDeps() *mockBoundaryDeps {
+	c := capture.NewCapture()
+	c.AddNetworkBodies([]capture.NetworkBody{{URL: "https://example.com/api/before", Method: "GET", Status: 500}})
+	c.SetTestBoundaryStart("checkout")
+	c.AddNetworkBodies([]capture.NetworkBody{
+		{URL: "https://example.com/api/cart", Method: "GET", Status: 200},
+		{URL: "https://example.com/api/pay", Method: "POST", Status: 502},
+	})
+	c.AddEnhancedActions([]capture.EnhancedAction{{Type: "click", Timestamp: time.Now().UnixMilli(), URL: "https://example.com/cart"}})
+	c.SetTestBoundaryEnd("checkout")
+
+	return &mockBoundaryDeps{
+		mockTransientDeps: mockTransientDeps{cap: c},
+		logs: []mcp.LogEntry{
+			{"level": "error", "message": "outside", "ts": time.Now().Format(time.RFC3339)},
+			{"level": "error", "message": "payment failed", "ts": time.Now().Format(time.RFC3339), "test_ids": []any{"checkout"}},
+			{"level": "info", "message": "cart loaded", "ts": time.Now().Format(time.RFC3339), "test_ids": []string{"checkout"}},
+		},
+	}
+}
+
+func decodeBoundaryResult(t *testing.T, resp mcp.JSONRPCResponse) map[string]any {
+	t.Helper()
+	var result mcp.MCPToolResult
+	if err := json.Unmarshal(resp.Result, &result); err != nil {
+		t.Fatalf("unmarshal result: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("unexpected error: %s", result.Content[0].Text)
+	}
+	text := result.Content[0].Text
+	var data map[string]any
+	if err := json.Unmarshal([]byte(text[strings.Index(text, "{"):]), &data); err != nil {
+		t.Fatalf("unmarshal data: %v\n%s", err, text)
+	}
+	return data
+}
+
+func TestLogInBoundary(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		entry    mcp.LogEntry
+		boundary string
+		want     bool
+	}{
+		{mcp.LogEntry{}, "", true},
+		{mcp.LogEntry{}, "checkout", false},
+		{mcp.LogEntry{"test_ids": []string{"checkout"}}, "checkout", true},
+		{mcp.LogEntry{"test_ids": []any{"login", "checkout"}}, "checkout", true},
+		{mcp.LogEntry{"test_ids": []any{"login"}}, "checkout", false},
+	}
+	for _, tc := range cases {
+		if got := logInBoundary(tc.entry, tc.boundary); got != tc.want {
+			t.Errorf("logInBoundary(%v, %q) = %v, want %v", tc.entry, tc.boundary, got, tc.want)
+		}
+	}
+}
+
+func TestBoundarySummary_CountsOnlyTaggedEntries(t *testing.T) {
+	t.Parallel()
+	deps := newBoundaryDeps()
+
+	summary := BoundarySummary(deps.cap, deps.logs, "checkout", time.Now())
+	want := map[string]int{"errors": 1, "logs": 2, "network_bodies": 2, "failed_requests": 1, "actions": 1}
+	for key, n := range want {
+		if summary[key] != n {
+			t.Errorf("summary[%s] = %v, want %d", key, summary[key], n)
+		}
+	}
+	if summary["active"] != false {
+		t.Errorf("active = %v, want false after end", summary["active"])
+	}
+	samples, _ := summary["failed_request_samples"].([]map[string]any)
+	if len(samples) != 1 || samples[0]["url"] != "https://example.com/api/pay" {
+		t.Errorf("failed_request_samples = %v, want the /api/pay 502", samples)
+	}
+}
+
+func TestGetNetworkBodies_BoundaryFilter(t *testing.T) {
+	t.Parallel()
+	deps := newBoundaryDeps()
+
+	data := decodeBoundaryResult(t, GetNetworkBodies(deps, mcp.JSONRPCRequest{ID: 1}, json.RawMessage(`{"boundary":"checkout"}`)))
+	if data["count"] != float64(2) {
+		t.Fatalf("count = %v, want 2 (only bodies inside the boundary)", data["count"])
+	}
+	if _, ok := data["boundary"].(map[string]any); !ok {
+		t.Fatalf("boundary summary missing: %v", data)
+	}
+	if _, ok := data["boundary_hint"]; ok {
+		t.Fatalf("known boundary should not carry boundary_hint")
+	}
+}
+
+func TestGetBrowserErrors_BoundaryFilter(t *testing.T) {
+	t.Parallel()
+	deps := newBoundaryDeps()
+
+	data := decodeBoundaryResult(t, GetBrowserErrors(deps, mcp.JSONRPCRequest{ID: 1}, json.RawMessage(`{"boundary":"checkout"}`)))
+	errs, _ := data["errors"].([]any)
+	if len(errs) != 1 {
+		t.Fatalf("errors = %v, want only the tagged error", errs)
+	}
+}
+
+func TestBoundaryFilter_UnknownBoundaryHint(t *testing.T) {
+	t.Parallel()
+	deps := newBoundaryDeps()
+
+	data := decodeBoundaryResult(t, GetEnhancedActions(deps, mcp.JSONRPCRequest{ID: 1}, json.RawMessage(`{"boundary":"nope"}`)))
+	if data["count"] != float64(0) {
+		t.Fatalf("count = %v, want 0", data["count"])
+	}
+	if hint, _ := data["boundary_hint"].(string); !strings.Contains(hint, "test_boundary_start") {
+		t.Fatalf("boundary_hint = %q, want a test_boundary_start pointer", hint)
+	}
+}
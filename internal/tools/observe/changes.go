@@ -0,0 +1,134 @@
+// Purpose: Implements observe(what="changes") — resumable, globally sequenced deltas across capture buffers.
+// Why: One after_seq cursor replaces per-buffer polling and makes evicted ranges explicit instead of silent.
+// Docs: docs/features/feature/cursor-pagination/index.md
+
+package observe
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/changefeed"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/mcp"
+)
+
+const (
+	defaultChangesLimit = 100
+	maxChangesLimit     = 1000
+)
+
+// CommitResolver is an optional Deps extension that maps a git commit in the calling
+// client's working directory to the change-feed sequence at which it was checked out.
+type CommitResolver interface {
+	ResolveSinceCommit(clientID, commit string) (CommitPosition, error)
+}
+
+// CommitPosition is where a commit starts in the change feed.
+type CommitPosition struct {
+	Commit    string `json:"commit"`
+	Branch    string `json:"branch,omitempty"`
+	FirstSeen string `json:"first_seen,omitempty"`
+	Seq       int64  `json:"seq"`
+	Note      string `json:"note,omitempty"`
+}
+
+// GetChanges returns deltas appended after after_seq, in sequence order.
+func GetChanges(deps Deps, req mcp.JSONRPCRequest, args json.RawMessage) mcp.JSONRPCResponse {
+	var params struct {
+		AfterSeq    int64    `json:"after_seq"`
+		FeedID      string   `json:"feed_id"`
+		Limit       int      `json:"limit"`
+		Types       []string `json:"types"`
+		SinceCommit string   `json:"since_commit"`
+	}
+	mcp.LenientUnmarshal(args, &params)
+	if params.AfterSeq < 0 {
+		return mcp.Fail(req, mcp.ErrInvalidParam, "after_seq must be >= 0",
+			"Omit after_seq (or pass 0) to read from the oldest retained change", mcp.WithParam("after_seq"))
+	}
+	var since *CommitPosition
+	if params.SinceCommit != "" {
+		if params.AfterSeq > 0 {
+			return mcp.Fail(req, mcp.ErrInvalidParam, "since_commit and after_seq cannot be combined",
+				"Use since_commit for the first read, then page with after_seq=next_seq", mcp.WithParam("since_commit"))
+		}
+		resolver, ok := deps.(CommitResolver)
+		if !ok {
+			return mcp.Fail(req, mcp.ErrNotInitialized, "Commit tracking is not available",
+				"Use after_seq instead", mcp.WithParam("since_commit"))
+		}
+		pos, err := resolver.ResolveSinceCommit(req.ClientID, params.SinceCommit)
+		if err != nil {
+			return mcp.Fail(req, mcp.ErrInvalidParam, err.Error(),
+				"Pass a commit (at least 4 hex characters) that was checked out in your working directory while the daemon was running, or an ancestor of one", mcp.WithParam("since_commit"))
+		}
+		since = &pos
+		params.AfterSeq = pos.Seq
+		params.FeedID = ""
+	}
+	kinds := map[changefeed.Kind]bool{}
+	for _, t := range params.Types {
+		kind := changefeed.Kind(strings.ToLower(strings.TrimSpace(t)))
+		if !isChangeKind(kind) {
+			return mcp.Fail(req, mcp.ErrInvalidParam, "Unknown change type: "+t,
+				"Use one of: "+changeKindList(), mcp.WithParam("types"))
+		}
+		kinds[kind] = true
+	}
+	limit := params.Limit
+	if limit <= 0 {
+		limit = defaultChangesLimit
+	}
+	if limit > maxChangesLimit {
+		limit = maxChangesLimit
+	}
+
+	page := deps.GetCapture().ChangeFeed().Since(params.FeedID, params.AfterSeq, limit, kinds)
+	response := map[string]any{
+		"feed_id":    page.FeedID,
+		"changes":    page.Deltas,
+		"count":      len(page.Deltas),
+		"next_seq":   page.NextSeq,
+		"latest_seq": page.LatestSeq,
+		"oldest_seq": page.OldestSeq,
+		"has_more":   page.HasMore,
+	}
+	if page.Gap != nil {
+		response["gap"] = page.Gap
+		response["gap_hint"] = fmt.Sprintf("%d change(s) were evicted before this read. Re-read the affected buffers (logs, network_bodies, websocket_events, actions) if completeness matters.", page.Gap.Missed)
+	}
+	if since != nil {
+		response["since_commit"] = since
+	}
+	if page.Reset {
+		response["reset"] = true
+		response["reset_hint"] = "after_seq/feed_id belong to a different daemon run. Sequences restarted; discard the old cursor and continue from next_seq."
+	}
+
+	summary := fmt.Sprintf("%d change(s) after seq %d; next_seq=%d", len(page.Deltas), params.AfterSeq, page.NextSeq)
+	if since != nil {
+		summary = fmt.Sprintf("%d change(s) since commit %s; next_seq=%d", len(page.Deltas), since.Commit, page.NextSeq)
+	}
+	if page.Gap != nil {
+		summary += fmt.Sprintf(" (gap: %d missed)", page.Gap.Missed)
+	}
+	return mcp.Succeed(req, summary, response)
+}
+
+func isChangeKind(kind changefeed.Kind) bool {
+	for _, k := range changefeed.Kinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+func changeKindList() string {
+	names := make([]string, len(changefeed.Kinds))
+	for i, k := range changefeed.Kinds {
+		names[i] = string(k)
+	}
+	return strings.Join(names, ", ")
+}
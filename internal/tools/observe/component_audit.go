@@ -0,0 +1,396 @@
+// Purpose: Implements observe(what="component_audit") — per-story a11y, visual, and console audits for component harnesses.
+// Why: Storybook-style harnesses isolate components; auditing each story pinpoints the component that regressed.
+// Docs: docs/features/feature/component-audit/index.md
+
+package observe
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/componentaudit"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/mcp"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/queries"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/util"
+)
+
+const (
+	defaultComponentAuditLimit = 20
+	maxComponentAuditLimit     = 100
+	defaultStorySettleMs       = 750
+	maxStorySettleMs           = 10_000
+	componentNavigateTimeout   = 15 * time.Second
+	componentScreenshotTimeout = 20 * time.Second
+	componentIndexTimeout      = 10 * time.Second
+	maxStoryConsoleErrors      = 10
+)
+
+type componentAuditParams struct {
+	URL              string   `json:"url"`
+	Harness          string   `json:"harness"`
+	StoryURLTemplate string   `json:"story_url_template"`
+	StoryIDs         []string `json:"story_ids"`
+	Filter           string   `json:"filter"`
+	Tags             []string `json:"tags"`
+	Checks           []string `json:"checks"`
+	Limit            int      `json:"limit"`
+	SettleMs         int      `json:"settle_ms"`
+	UpdateBaseline   bool     `json:"update_baseline"`
+}
+
+// GetComponentAudit enumerates harness stories, renders each one in the tracked tab,
+// and reports a11y, visual, and console-error results keyed by story ID.
+//
+// Failure semantics:
+//   - Enumeration failures fail the call; per-story failures are reported on that story (status "error").
+//   - The tracked tab is navigated back to its original URL afterwards (best effort).
+func GetComponentAudit(deps Deps, req mcp.JSONRPCRequest, args json.RawMessage) mcp.JSONRPCResponse {
+	var params componentAuditParams
+	mcp.LenientUnmarshal(args, &params)
+	checks, resp, ok := componentAuditChecks(req, params.Checks)
+	if !ok {
+		return resp
+	}
+	harness := strings.ToLower(strings.TrimSpace(params.Harness))
+	switch harness {
+	case "":
+		harness = componentaudit.HarnessAuto
+	case componentaudit.HarnessAuto, componentaudit.HarnessStorybook, componentaudit.HarnessLadle, componentaudit.HarnessCustom:
+	default:
+		return mcp.Fail(req, mcp.ErrInvalidParam, "Unknown harness: "+params.Harness,
+			"Use auto, storybook, ladle, or custom", mcp.WithParam("harness"))
+	}
+	if harness == componentaudit.HarnessCustom && (params.StoryURLTemplate == "" || len(params.StoryIDs) == 0) {
+		return mcp.Fail(req, mcp.ErrMissingParam, "harness=custom requires story_url_template and story_ids",
+			"Pass story_url_template (with {id}) and the story_ids to render", mcp.WithParam("story_url_template"))
+	}
+
+	cap := deps.GetCapture()
+	enabled, _, tabURL := cap.GetTrackingStatus()
+	if !enabled {
+		return mcp.Fail(req, mcp.ErrNoData, "No tab is being tracked. component_audit renders each story in the tracked tab.",
+			"Track a tab (ideally the running Storybook) and retry", mcp.WithHint(deps.DiagnosticHintString()))
+	}
+	if !cap.IsPilotActionAllowed() {
+		return mcp.Fail(req, mcp.ErrCodePilotDisabled, "component_audit navigates the tracked tab and requires AI Web Pilot",
+			"Enable AI Web Pilot in the extension popup, then retry", mcp.WithHint(deps.DiagnosticHintString()))
+	}
+	target := params.URL
+	if target == "" {
+		target = tabURL
+	}
+	base, err := componentaudit.BaseURL(target)
+	if err != nil {
+		return mcp.Fail(req, mcp.ErrInvalidParam, err.Error(),
+			"Pass url pointing at the harness, e.g. http://localhost:6006", mcp.WithParam("url"))
+	}
+
+	stories, harness, err := enumerateStories(cap, harness, base, tabURL, params.StoryIDs, params.StoryURLTemplate)
+	if err != nil {
+		return mcp.Fail(req, mcp.ErrNoData, "Could not enumerate stories: "+err.Error(),
+			"Point url at a running Storybook/Ladle, or pass story_ids with story_url_template", mcp.WithParam("url"))
+	}
+	selected, truncated := componentaudit.Select(stories, params.Filter, params.Tags, clampComponentLimit(params.Limit))
+	if len(selected) == 0 {
+		return mcp.Fail(req, mcp.ErrNoData, fmt.Sprintf("No stories matched (%d enumerated)", len(stories)),
+			"Loosen filter/tags or check story_ids", mcp.WithParam("filter"))
+	}
+
+	var baseline *componentaudit.Baseline
+	baselinePath := ""
+	if checks[componentaudit.CheckVisual] {
+		if baselinePath, err = componentaudit.BaselinePath(util.ExtractOrigin(base)); err == nil {
+			baseline, err = componentaudit.LoadBaseline(baselinePath)
+		}
+		if err != nil {
+			return mcp.Fail(req, mcp.ErrInternal, "Visual baseline unavailable: "+err.Error(),
+				"Retry without the visual check, or fix the state directory permissions")
+		}
+	}
+
+	settle := clampSettle(params.SettleMs)
+	results := make([]componentaudit.StoryResult, 0, len(selected))
+	for _, story := range selected {
+		results = append(results, auditStory(deps, cap, harness, base, params.StoryURLTemplate, story, checks, baseline, settle))
+	}
+	if tabURL != "" {
+		_ = navigateTrackedTab(cap, tabURL)
+	}
+
+	response := map[string]any{
+		"harness":  harness,
+		"base_url": base,
+		"stories":  results,
+		"summary":  componentaudit.Summarize(results),
+		"checks":   checkList(checks),
+	}
+	if truncated {
+		response["truncated"] = true
+		response["hint"] = fmt.Sprintf("Audited the first %d of the matching stories. Raise limit or narrow filter to cover the rest.", len(selected))
+	}
+	if checks[componentaudit.CheckVisual] {
+		if params.UpdateBaseline {
+			if err := saveComponentBaseline(baselinePath, base, baseline, results); err != nil {
+				response["baseline_error"] = err.Error()
+			} else {
+				response["baseline_updated"] = true
+			}
+		} else if baseline == nil {
+			response["baseline_hint"] = "No visual baseline yet; every story reports visual=new. Re-run with update_baseline=true to store one."
+		}
+	}
+
+	s := componentaudit.Summarize(results)
+	summary := fmt.Sprintf("Component audit: %d stories, %d passed, %d failed, %d errored", s.Stories, s.Passed, s.Failed, s.Errored)
+	return mcp.Succeed(req, summary, response)
+}
+
+func componentAuditChecks(req mcp.JSONRPCRequest, requested []string) (map[string]bool, mcp.JSONRPCResponse, bool) {
+	checks := map[string]bool{}
+	if len(requested) == 0 {
+		requested = componentaudit.AllChecks
+	}
+	for _, c := range requested {
+		name := strings.ToLower(strings.TrimSpace(c))
+		valid := false
+		for _, known := range componentaudit.AllChecks {
+			if name == known {
+				valid = true
+			}
+		}
+		if !valid {
+			return nil, mcp.Fail(req, mcp.ErrInvalidParam, "Unknown check: "+c,
+				"Use any of: "+strings.Join(componentaudit.AllChecks, ", "), mcp.WithParam("checks")), false
+		}
+		checks[name] = true
+	}
+	return checks, mcp.JSONRPCResponse{}, true
+}
+
+func checkList(checks map[string]bool) []string {
+	out := make([]string, 0, len(checks))
+	for _, c := range componentaudit.AllChecks {
+		if checks[c] {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+func clampComponentLimit(limit int) int {
+	if limit <= 0 {
+		return defaultComponentAuditLimit
+	}
+	if limit > maxComponentAuditLimit {
+		return maxComponentAuditLimit
+	}
+	return limit
+}
+
+func clampSettle(ms int) time.Duration {
+	if ms <= 0 {
+		ms = defaultStorySettleMs
+	}
+	if ms > maxStorySettleMs {
+		ms = maxStorySettleMs
+	}
+	return time.Duration(ms) * time.Millisecond
+}
+
+// enumerateStories returns explicit story IDs as-is, otherwise fetches the harness index
+// from inside the page (same origin, so no daemon-side fetch of local services).
+func enumerateStories(cap *capture.Store, harness, base, tabURL string, ids []string, template string) ([]componentaudit.Story, string, error) {
+	if len(ids) > 0 {
+		if harness == componentaudit.HarnessAuto {
+			harness = componentaudit.HarnessStorybook
+			if template != "" {
+				harness = componentaudit.HarnessCustom
+			}
+		}
+		stories := make([]componentaudit.Story, 0, len(ids))
+		for _, id := range ids {
+			if id = strings.TrimSpace(id); id != "" {
+				stories = append(stories, componentaudit.Story{ID: id})
+			}
+		}
+		return stories, harness, nil
+	}
+
+	if util.ExtractOrigin(tabURL) != util.ExtractOrigin(base) {
+		if err := navigateTrackedTab(cap, base); err != nil {
+			return nil, harness, fmt.Errorf("open harness: %w", err)
+		}
+	}
+	data, err := executeObserveScript(cap, buildStoryIndexScript(base, componentaudit.IndexPaths(harness)), "observe_component_index", componentIndexTimeout)
+	if err != nil {
+		return nil, harness, err
+	}
+	if msg, ok := data["error"].(string); ok && msg != "" {
+		return nil, harness, errors.New(msg)
+	}
+	body, _ := data["body"].(string)
+	detected, stories, err := componentaudit.ParseIndex([]byte(body))
+	if err != nil {
+		return nil, harness, err
+	}
+	if harness == componentaudit.HarnessAuto {
+		harness = detected
+	}
+	return stories, harness, nil
+}
+
+func buildStoryIndexScript(base string, paths []string) string {
+	baseJSON, _ := json.Marshal(base)
+	pathsJSON, _ := json.Marshal(paths)
+	return fmt.Sprintf(`(() => (async () => {
+  const base = %s;
+  const paths = %s;
+  for (const p of paths) {
+    try {
+      const res = await fetch(new URL(p, base), { credentials: "same-origin" });
+      if (res.ok) return { path: p, body: await res.text() };
+    } catch (e) {}
+  }
+  return { error: "no story index at " + paths.map((p) => new URL(p, base).href).join(", ") };
+})())()`, baseJSON, pathsJSON)
+}
+
+// auditStory renders one story and runs the requested checks against it.
+func auditStory(deps Deps, cap *capture.Store, harness, base, template string, story componentaudit.Story, checks map[string]bool, baseline *componentaudit.Baseline, settle time.Duration) componentaudit.StoryResult {
+	start := time.Now()
+	result := componentaudit.StoryResult{StoryID: story.ID, Title: story.Title, Name: story.Name}
+	defer func() { result.DurationMs = time.Since(start).Milliseconds() }()
+
+	storyURL, err := componentaudit.StoryURL(harness, base, story.ID, template)
+	if err != nil {
+		result.Errors = append(result.Errors, err.Error())
+		result.Finalize()
+		return result
+	}
+	result.URL = storyURL
+
+	logsBefore := deps.GetLogTotalAdded()
+	if err := navigateTrackedTab(cap, storyURL); err != nil {
+		result.Errors = append(result.Errors, "navigate: "+err.Error())
+		result.Finalize()
+		return result
+	}
+	time.Sleep(settle)
+
+	if checks[componentaudit.CheckA11y] {
+		raw, err := deps.ExecuteA11yQuery("", nil, nil, true)
+		if err == nil {
+			result.A11y, err = componentaudit.SummarizeAxe(raw)
+		}
+		if err != nil {
+			result.Errors = append(result.Errors, "a11y: "+err.Error())
+		}
+	}
+	if checks[componentaudit.CheckVisual] {
+		if dataURL, err := captureStoryScreenshot(cap); err != nil {
+			result.Errors = append(result.Errors, "visual: "+err.Error())
+		} else {
+			hash := componentaudit.HashScreenshot(dataURL)
+			result.Visual = &componentaudit.VisualSummary{Hash: hash, Baseline: baseline.Compare(story.ID, hash)}
+		}
+	}
+	if checks[componentaudit.CheckConsole] {
+		result.ConsoleErrors = consoleErrorsSince(deps, logsBefore)
+	}
+	result.Finalize()
+	return result
+}
+
+// consoleErrorsSince returns non-noise error messages logged after the given total.
+func consoleErrorsSince(deps Deps, before int64) []string {
+	added := deps.GetLogTotalAdded() - before
+	if added <= 0 {
+		return nil
+	}
+	entries, _ := deps.GetLogEntries()
+	if int64(len(entries)) > added {
+		entries = entries[len(entries)-int(added):]
+	}
+	var out []string
+	for _, e := range entries {
+		if level, _ := e["level"].(string); level != "error" || deps.IsConsoleNoise(e) {
+			continue
+		}
+		msg, _ := e["message"].(string)
+		if i := strings.IndexByte(msg, '\n'); i >= 0 {
+			msg = msg[:i]
+		}
+		out = append(out, msg)
+		if len(out) == maxStoryConsoleErrors {
+			break
+		}
+	}
+	return out
+}
+
+// navigateTrackedTab navigates the tracked tab and waits for the extension to confirm.
+func navigateTrackedTab(cap *capture.Store, target string) error {
+	params, _ := json.Marshal(map[string]any{"action": "navigate", "url": target, "reason": "component_audit"})
+	data, err := runComponentQuery(cap, "browser_action", params, componentNavigateTimeout)
+	if err != nil {
+		return err
+	}
+	if ok, has := data["success"].(bool); has && !ok {
+		return errors.New(executeResultErrorMessage(data))
+	}
+	return nil
+}
+
+func captureStoryScreenshot(cap *capture.Store) (string, error) {
+	data, err := runComponentQuery(cap, "screenshot", json.RawMessage(`{"format":"png","wait_for_stable":true}`), componentScreenshotTimeout)
+	if err != nil {
+		return "", err
+	}
+	dataURL, _ := data["data_url"].(string)
+	if dataURL == "" {
+		return "", errors.New("extension returned no screenshot data")
+	}
+	return dataURL, nil
+}
+
+func runComponentQuery(cap *capture.Store, queryType string, params json.RawMessage, timeout time.Duration) (map[string]any, error) {
+	queryID, err := cap.CreatePendingQueryWithTimeout(queries.PendingQuery{Type: queryType, Params: params}, timeout, "")
+	if err != nil {
+		return nil, err
+	}
+	raw, err := cap.WaitForResult(queryID, timeout)
+	if err != nil {
+		return nil, err
+	}
+	var data map[string]any
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return nil, fmt.Errorf("parse %s result: %w", queryType, err)
+	}
+	if msg, ok := data["error"].(string); ok && msg != "" {
+		return nil, errors.New(msg)
+	}
+	return data, nil
+}
+
+func saveComponentBaseline(path, base string, prev *componentaudit.Baseline, results []componentaudit.StoryResult) error {
+	next := componentaudit.Baseline{
+		Origin:    util.ExtractOrigin(base),
+		UpdatedAt: time.Now().UTC().Format(time.RFC3339),
+		Hashes:    map[string]string{},
+	}
+	if prev != nil {
+		for id, hash := range prev.Hashes {
+			next.Hashes[id] = hash
+		}
+	}
+	for _, r := range results {
+		if r.Visual != nil {
+			next.Hashes[r.StoryID] = r.Visual.Hash
+		}
+	}
+	return componentaudit.SaveBaseline(path, next)
+}
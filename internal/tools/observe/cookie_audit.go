@@ -0,0 +1,158 @@
+// Purpose: Implements observe(what="cookie_audit") — cookie attribute and web-storage secret audit.
+// Why: Combines captured Set-Cookie headers with live document.cookie/localStorage state in one security view.
+// Docs: docs/features/feature/security-hardening/index.md
+
+package observe
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/mcp"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/queries"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/security"
+)
+
+// cookieAuditStorageTimeout bounds the best-effort storage round-trip to the extension.
+const cookieAuditStorageTimeout = 5 * time.Second
+
+// GetCookieAudit audits captured Set-Cookie headers and, when a tab is tracked, the page's
+// document.cookie and web storage. Findings are also recorded in the security audit trail.
+func GetCookieAudit(deps Deps, req mcp.JSONRPCRequest, args json.RawMessage) mcp.JSONRPCResponse {
+	var params struct {
+		URL            string `json:"url"`
+		IncludeStorage *bool  `json:"include_storage"`
+	}
+	mcp.LenientUnmarshal(args, &params)
+
+	cap := deps.GetCapture()
+	bodies := cap.GetNetworkBodies()
+	if params.URL != "" {
+		matched := make([]capture.NetworkBody, 0, len(bodies))
+		for _, b := range bodies {
+			if ContainsIgnoreCase(b.URL, params.URL) {
+				matched = append(matched, b)
+			}
+		}
+		bodies = matched
+	}
+
+	var storage *security.StorageSnapshot
+	var storageNote string
+	if params.IncludeStorage == nil || *params.IncludeStorage {
+		snap, err := requestStorageSnapshot(cap)
+		if err != nil {
+			storageNote = "Storage not audited: " + err.Error()
+		} else {
+			storage = snap
+		}
+	}
+
+	result := security.AuditCookies(bodies, storage)
+	location := params.URL
+	if storage != nil && storage.URL != "" {
+		location = storage.URL
+	}
+	security.LogAuditFindings("cookie_audit", location, result.Findings)
+
+	response := map[string]any{
+		"findings":             result.Findings,
+		"summary":              result.Summary,
+		"cookies_checked":      result.CookiesChecked,
+		"storage_keys_checked": result.StorageKeys,
+		"storage_included":     result.StorageIncluded,
+		"metadata":             BuildResponseMetadata(cap, time.Now()),
+	}
+	if storageNote != "" {
+		response["storage_note"] = storageNote
+	}
+	if result.CookiesChecked == 0 && !result.StorageIncluded {
+		response["hint"] = "No Set-Cookie headers captured and no tracked tab to read storage from. Track a tab and load an authenticated page, then retry."
+	}
+	summary := fmt.Sprintf("Cookie audit: %d finding(s)", len(result.Findings))
+	return mcp.Succeed(req, summary, response)
+}
+
+// requestStorageSnapshot asks the extension for the tracked tab's cookies and web storage.
+func requestStorageSnapshot(cap *capture.Capture) (*security.StorageSnapshot, error) {
+	if enabled, _, _ := cap.GetTrackingStatus(); !enabled {
+		return nil, errors.New("no tab is being tracked")
+	}
+	if !cap.IsExtensionConnected() {
+		return nil, errors.New("extension is not connected")
+	}
+	queryID, err := cap.CreatePendingQueryWithTimeout(
+		queries.PendingQuery{
+			Type:   "state_capture",
+			Params: json.RawMessage(`{"action":"capture"}`),
+		},
+		cookieAuditStorageTimeout,
+		"",
+	)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := cap.WaitForResult(queryID, cookieAuditStorageTimeout)
+	if err != nil {
+		return nil, err
+	}
+	var state struct {
+		URL            string         `json:"url"`
+		LocalStorage   map[string]any `json:"localStorage"`
+		SessionStorage map[string]any `json:"sessionStorage"`
+		Cookies        any            `json:"cookies"`
+		Error          string         `json:"error"`
+	}
+	if err := json.Unmarshal(raw, &state); err != nil {
+		return nil, err
+	}
+	if state.Error != "" {
+		return nil, errors.New(state.Error)
+	}
+	return &security.StorageSnapshot{
+		URL:            state.URL,
+		DocumentCookie: documentCookieString(state.Cookies),
+		LocalStorage:   stringifyStorage(state.LocalStorage),
+		SessionStorage: stringifyStorage(state.SessionStorage),
+	}, nil
+}
+
+// documentCookieString accepts the extension's document.cookie string or a [{name,value}] array.
+func documentCookieString(v any) string {
+	switch cookies := v.(type) {
+	case string:
+		return cookies
+	case []any:
+		out := ""
+		for _, c := range cookies {
+			m, ok := c.(map[string]any)
+			if !ok {
+				continue
+			}
+			name, _ := m["name"].(string)
+			value, _ := m["value"].(string)
+			if out != "" {
+				out += "; "
+			}
+			out += name + "=" + value
+		}
+		return out
+	}
+	return ""
+}
+
+func stringifyStorage(m map[string]any) map[string]string {
+	out := make(map[string]string, len(m))
+	for k, v := range m {
+		if s, ok := v.(string); ok {
+			out[k] = s
+			continue
+		}
+		b, _ := json.Marshal(v)
+		out[k] = string(b)
+	}
+	return out
+}
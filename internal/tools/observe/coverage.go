@@ -0,0 +1,170 @@
+// Purpose: Handles observe(what:"coverage") mode: reports per-URL JS/CSS used and unused bytes aggregated from extension coverage snapshots.
+// Why: Bundles that ship mostly unused code slow every load; agents need the worst offenders ranked before deciding what to split.
+// Docs: docs/features/feature/code-coverage/index.md
+
+package observe
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/coverage"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/mcp"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/queries"
+)
+
+const (
+	// coverageSnapshotTimeout covers a reload, the load event, and the settle delay.
+	coverageSnapshotTimeout = 30 * time.Second
+	defaultCoverageLimit    = 20
+)
+
+// coverageCollectResult is the extension's reply to a coverage_snapshot query. The
+// snapshot itself is POSTed to /coverage-snapshots before the reply is sent.
+type coverageCollectResult struct {
+	Status    string `json:"status"`
+	PageURL   string `json:"page_url"`
+	Resources int    `json:"resources"`
+	Reloaded  bool   `json:"reloaded"`
+	Error     string `json:"error,omitempty"`
+	Message   string `json:"message,omitempty"`
+}
+
+// GetCoverage returns aggregated coverage, biggest unused resources first. With
+// collect=true it first has the extension reload the tracked tab under CDP coverage
+// and post a fresh snapshot.
+func GetCoverage(deps Deps, req mcp.JSONRPCRequest, args json.RawMessage) mcp.JSONRPCResponse {
+	var params struct {
+		URL     string `json:"url"`
+		Limit   int    `json:"limit"`
+		Collect bool   `json:"collect"`
+		TabID   int    `json:"tab_id"`
+	}
+	if len(args) > 0 {
+		if err := json.Unmarshal(args, &params); err != nil {
+			return mcp.JSONRPCResponse{JSONRPC: "2.0", ID: req.ID, Result: mcp.StructuredErrorResponse(
+				mcp.ErrInvalidJSON, "Invalid JSON arguments: "+err.Error(), "Fix JSON syntax and call again",
+			)}
+		}
+	}
+	if params.Limit <= 0 {
+		params.Limit = defaultCoverageLimit
+	}
+
+	cap := deps.GetCapture()
+	var collected *coverageCollectResult
+	if params.Collect {
+		result, errResp := collectCoverage(deps, req, params.TabID)
+		if errResp != nil {
+			return *errResp
+		}
+		collected = result
+	}
+
+	store := cap.Coverage()
+	aggs := store.Aggregates(params.URL)
+	summary := coverage.Summarize(aggs)
+	all := store.Summary()
+	summary.Snapshots, summary.LastSnapshot = all.Snapshots, all.LastSnapshot
+	status := "ok"
+	if len(aggs) == 0 {
+		status = "no_snapshots"
+	}
+	total := len(aggs)
+	if len(aggs) > params.Limit {
+		aggs = aggs[:params.Limit]
+	}
+
+	response := map[string]any{
+		"status":    status,
+		"summary":   summary,
+		"count":     len(aggs),
+		"total":     total,
+		"resources": aggs,
+		"metadata":  BuildResponseMetadata(cap, time.Now()),
+	}
+	if collected != nil {
+		response["collected"] = collected
+	}
+	switch {
+	case status == "no_snapshots" && params.URL != "" && summary.Snapshots > 0:
+		response["hint"] = "No covered resource or page URL contains '" + params.URL + "'. Call without url to see everything collected."
+	case status == "no_snapshots":
+		response["hint"] = "No coverage collected yet. Call observe with what='coverage' and collect=true to reload the tracked tab under coverage."
+	default:
+		response["hint"] = "generate with what='coverage_report' ranks these by download cost with fixes. Visit more pages with collect=true so code they use stops counting as unused."
+	}
+
+	text := fmt.Sprintf("Coverage: %s of %s unused (%.1f%%) across %d resource(s)",
+		coverage.FormatBytes(summary.UnusedBytes), coverage.FormatBytes(summary.TotalBytes), summary.UnusedPercent, summary.Resources)
+	return mcp.Succeed(req, text, response)
+}
+
+// collectCoverage asks the extension for a fresh snapshot of the tracked tab and waits
+// until it has been posted. Returns an error response when collection is impossible.
+func collectCoverage(deps Deps, req mcp.JSONRPCRequest, tabID int) (*coverageCollectResult, *mcp.JSONRPCResponse) {
+	cap := deps.GetCapture()
+	fail := func(result json.RawMessage) *mcp.JSONRPCResponse {
+		return &mcp.JSONRPCResponse{JSONRPC: "2.0", ID: req.ID, Result: result}
+	}
+	enabled, _, _ := cap.GetTrackingStatus()
+	if !enabled && tabID == 0 {
+		return nil, fail(mcp.StructuredErrorResponse(
+			mcp.ErrNoData,
+			"No tab is being tracked. Open the Kaboom extension popup and click 'Track This Tab'.",
+			"Track a tab first, then call observe with what='coverage' and collect=true.",
+			mcp.WithHint(deps.DiagnosticHintString()),
+		))
+	}
+
+	queryID, qerr := cap.CreatePendingQueryWithTimeout(
+		queries.PendingQuery{
+			Type:   "coverage_snapshot",
+			Params: json.RawMessage(`{"reload":true}`),
+			TabID:  tabID,
+		},
+		coverageSnapshotTimeout,
+		"",
+	)
+	if qerr != nil {
+		return nil, fail(mcp.StructuredErrorResponse(
+			mcp.ErrQueueFull,
+			"Command queue full: "+qerr.Error(),
+			"Wait for in-flight commands to complete, then retry.",
+			mcp.WithRecoveryToolCall(map[string]any{"tool": "observe", "arguments": map[string]any{"what": "pending_commands"}}),
+		))
+	}
+
+	raw, err := cap.WaitForResult(queryID, coverageSnapshotTimeout)
+	if err != nil {
+		code, opts := mcp.ExtensionWaitFailure(err)
+		return nil, fail(mcp.StructuredErrorResponse(
+			code,
+			"Coverage snapshot timeout: "+err.Error(),
+			"Ensure the extension is connected and the page finishes loading.",
+			append(opts, mcp.WithHint(deps.DiagnosticHintString()))...,
+		))
+	}
+
+	var result coverageCollectResult
+	if err := json.Unmarshal(raw, &result); err != nil {
+		return nil, fail(mcp.StructuredErrorResponse(
+			mcp.ErrInvalidJSON,
+			"Failed to parse coverage snapshot result: "+err.Error(),
+			"Check extension logs for errors",
+		))
+	}
+	if result.Error != "" {
+		detail := result.Message
+		if detail == "" {
+			detail = result.Error
+		}
+		return nil, fail(mcp.StructuredErrorResponse(
+			mcp.ErrExtError,
+			"Coverage snapshot failed: "+detail,
+			"Check that the tab is a regular web page and that no other extension holds the debugger on it.",
+		))
+	}
+	return &result, nil
+}
@@ -166,3 +166,27 @@ func networkWaterfallEmptyHint(urlFilter string) string {
 		"and live request interception. Navigate to a page or trigger requests, then re-check. " +
 		"Check observe({what: \"pilot\"}) for extension status."
 }
+
+// sseEmptyHint returns a context-aware hint when sse returns no entries.
+func sseEmptyHint(unfilteredCount int, urlFilter string) string {
+	if unfilteredCount > 0 && urlFilter != "" {
+		return fmt.Sprintf(
+			"No SSE events matched the url filter %q, but %d events exist unfiltered. "+
+				"Try broadening the filter or calling observe({what: \"sse\"}) without a url param.",
+			urlFilter, unfilteredCount,
+		)
+	}
+	return "No SSE events captured. EventSource interception must be active before streams open; " +
+		"refresh the page while tracking is active to capture Server-Sent Events."
+}
+
+func serverDebugEmptyHint(unfilteredCount int) string {
+	if unfilteredCount > 0 {
+		return fmt.Sprintf(
+			"No server debug entries matched the filters, but %d entries exist unfiltered. "+
+				"Try observe({what: \"server_debug\"}) without filters.",
+			unfilteredCount,
+		)
+	}
+	return "No server debug entries yet. Only /mcp requests are logged, and only the most recent ones are kept."
+}
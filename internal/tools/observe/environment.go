@@ -0,0 +1,47 @@
+// Purpose: Implements observe(what="environment"), the browser metadata the extension reports on each sync.
+// Why: Viewport-, locale-, and timezone-dependent bugs only reproduce with the same browser settings.
+// Docs: docs/features/feature/browser-environment/index.md
+
+package observe
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/mcp"
+)
+
+// GetEnvironment handles observe(what="environment").
+func GetEnvironment(deps Deps, req mcp.JSONRPCRequest, _ json.RawMessage) mcp.JSONRPCResponse {
+	cap := deps.GetCapture()
+	now := time.Now()
+	env, updated, ok := cap.GetBrowserEnvironment()
+	if !ok {
+		return mcp.Succeed(req, "Browser environment not reported yet", map[string]any{
+			"available": false,
+			"hint":      "The extension reports the environment on each sync. Connect the extension and track a tab, then call again.",
+			"metadata":  BuildResponseMetadata(cap, now),
+		})
+	}
+
+	response := map[string]any{
+		"available":   true,
+		"environment": env,
+		"updated_at":  updated.UTC().Format(time.RFC3339),
+		"metadata":    BuildResponseMetadata(cap, now),
+	}
+	if _, tabID, tabURL := cap.GetTrackingStatus(); tabID > 0 {
+		response["tab_id"] = tabID
+		response["tab_url"] = tabURL
+	}
+	if env.ViewportWidth == 0 {
+		response["hint"] = "Viewport and device pixel ratio are missing because the tracked page could not be scripted (no tracked tab, or a browser page such as chrome://)."
+	}
+
+	summary := fmt.Sprintf("Browser environment: %s, %s", env.Locale, env.Timezone)
+	if env.ViewportWidth > 0 {
+		summary = fmt.Sprintf("Browser environment: %dx%d @%gx, %s, %s", env.ViewportWidth, env.ViewportHeight, env.DevicePixelRatio, env.Locale, env.Timezone)
+	}
+	return mcp.Succeed(req, summary, response)
+}
@@ -87,13 +87,18 @@ func extractJSONPath(root any, path string) (any, bool, error) {
 	if err != nil {
 		return nil, false, err
 	}
+	value, ok := walkJSONPath(root, tokens)
+	return value, ok, nil
+}
 
+// walkJSONPath resolves parsed path tokens against decoded JSON; false when any segment is missing.
+func walkJSONPath(root any, tokens []jsonPathToken) (any, bool) {
 	current := root
 	for _, token := range tokens {
 		if token.isIndex {
 			items, ok := current.([]any)
 			if !ok || token.index < 0 || token.index >= len(items) {
-				return nil, false, nil
+				return nil, false
 			}
 			current = items[token.index]
 			continue
@@ -101,16 +106,16 @@ func extractJSONPath(root any, path string) (any, bool, error) {
 
 		object, ok := current.(map[string]any)
 		if !ok {
-			return nil, false, nil
+			return nil, false
 		}
 		next, ok := object[token.key]
 		if !ok {
-			return nil, false, nil
+			return nil, false
 		}
 		current = next
 	}
 
-	return current, true, nil
+	return current, true
 }
 
 func parseJSONPath(path string) ([]jsonPathToken, error) {
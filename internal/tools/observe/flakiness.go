@@ -0,0 +1,136 @@
+// Purpose: Implements observe(what="flakiness"), comparing repeated runs of one test boundary.
+// Why: The verification loop re-runs flows to catch flakes; this is the report that says which step flaked and how.
+// Docs: docs/features/feature/flakiness-report/index.md
+
+package observe
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/mcp"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/session"
+)
+
+// GetFlakiness handles observe(what="flakiness", boundary=...).
+func GetFlakiness(deps Deps, req mcp.JSONRPCRequest, args json.RawMessage) mcp.JSONRPCResponse {
+	var params struct {
+		Boundary string `json:"boundary"`
+	}
+	mcp.LenientUnmarshal(args, &params)
+	if params.Boundary == "" {
+		return mcp.Fail(req, mcp.ErrMissingParam, "Required parameter 'boundary' is missing",
+			"Pass the test_id used with configure(what=\"test_boundary_start\") for each run of the test", mcp.WithParam("boundary"))
+	}
+
+	windows := deps.GetCapture().GetTestBoundaries(params.Boundary)
+	runs, activeExcluded := collectFlakeRuns(deps, params.Boundary, windows)
+	report := session.AnalyzeFlakiness(runs)
+
+	response := map[string]any{
+		"boundary":       params.Boundary,
+		"runs":           report.Runs,
+		"classification": report.Classification,
+		"flake_score":    report.FlakeScore,
+		"run_summaries":  report.RunSummaries,
+		"steps":          report.Steps,
+		"metadata":       BuildResponseMetadata(deps.GetCapture(), time.Now()),
+	}
+	if activeExcluded {
+		response["active_run_excluded"] = true
+	}
+	if report.Runs < 2 {
+		response["hint"] = flakinessRunsHint(params.Boundary, report.Runs)
+	}
+	summary := fmt.Sprintf("Flakiness for %s: %s across %d runs (score %.2f)", params.Boundary, report.Classification, report.Runs, report.FlakeScore)
+	return mcp.Succeed(req, summary, response)
+}
+
+// collectFlakeRuns splits the entries tagged with boundary into one run per completed window.
+// An entry belongs to the latest window that started at or before its ingestion, since tags
+// are applied on arrival; browser timestamps only order entries within a run. The active
+// window still claims its own entries but is left out of the comparison.
+func collectFlakeRuns(deps Deps, boundary string, windows []capture.TestBoundary) ([]session.FlakeRun, bool) {
+	if len(windows) == 0 {
+		return nil, false
+	}
+	runs := make([]session.FlakeRun, len(windows))
+	for i, w := range windows {
+		runs[i] = session.FlakeRun{StartedAt: w.StartedAt, EndedAt: w.EndedAt}
+	}
+	runAt := func(t time.Time) int {
+		idx := 0
+		for i, w := range windows {
+			if !w.StartedAt.After(t) {
+				idx = i
+			}
+		}
+		return idx
+	}
+
+	cap := deps.GetCapture()
+	actions, actionTimes := cap.GetEnhancedActionsWithTimestamps()
+	for i, a := range actions {
+		if !inBoundary(a.TestIDs, boundary) || i >= len(actionTimes) {
+			continue
+		}
+		r := runAt(actionTimes[i])
+		runs[r].Actions = append(runs[r].Actions, session.FlakeAction{At: time.UnixMilli(a.Timestamp), Label: flakeActionLabel(a)})
+	}
+
+	bodies, ingested := cap.GetNetworkBodiesWithTimestamps()
+	for i, b := range bodies {
+		if !inBoundary(b.TestIDs, boundary) || i >= len(ingested) {
+			continue
+		}
+		at := ingested[i]
+		if ts, err := time.Parse(time.RFC3339Nano, b.Timestamp); err == nil {
+			at = ts
+		}
+		r := runAt(ingested[i])
+		runs[r].Requests = append(runs[r].Requests, session.FlakeRequest{At: at, Method: b.Method, URL: b.URL, Status: b.Status})
+	}
+
+	logs, logTimes := deps.GetLogEntries()
+	for i, entry := range logs {
+		if level, _ := entry["level"].(string); level != "error" || !logInBoundary(entry, boundary) || i >= len(logTimes) {
+			continue
+		}
+		at := logTimes[i]
+		if ts, err := time.Parse(time.RFC3339Nano, logEntryTimestamp(entry)); err == nil {
+			at = ts
+		}
+		msg, _ := entry["message"].(string)
+		r := runAt(logTimes[i])
+		runs[r].Errors = append(runs[r].Errors, session.FlakeError{At: at, Message: msg})
+	}
+
+	completed := runs[:0]
+	activeExcluded := false
+	for i, run := range runs {
+		if windows[i].Active() {
+			activeExcluded = true
+			continue
+		}
+		completed = append(completed, run)
+	}
+	return completed, activeExcluded
+}
+
+// flakeActionLabel names an action by type and target, leaving out typed values that
+// legitimately change between runs.
+func flakeActionLabel(a capture.EnhancedAction) string {
+	if a.ToURL != "" {
+		return a.Type + " " + capture.ExtractURLPath(a.ToURL)
+	}
+	if css, ok := a.Selectors["css"].(string); ok && css != "" {
+		return a.Type + " " + css
+	}
+	return a.Type
+}
+
+func flakinessRunsHint(boundary string, runs int) string {
+	return fmt.Sprintf("Flakiness needs at least 2 completed runs of boundary '%s'; found %d. Wrap each run of the test in configure(what=\"test_boundary_start\", test_id=\"%s\") and test_boundary_end, then call again.", boundary, runs, boundary)
+}
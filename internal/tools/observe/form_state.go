@@ -0,0 +1,156 @@
+// Purpose: Handles observe(what:"form_state") mode: reads every field's value, validity, and validation message from page forms.
+// Why: Agents filling a form need to see what the page already holds and why submit is blocked, without N query_dom calls.
+// Docs: docs/features/feature/form-state/index.md
+
+package observe
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/mcp"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/queries"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/redaction"
+)
+
+// redactFormFields applies key-name redaction to field values in place. The page
+// already masks password and payment fields by type and autocomplete; this adds
+// the server's name rules (ssn, token, secret, ...) on the field name and label.
+// Returns how many fields are redacted and how many are invalid.
+func redactFormFields(forms []any) (redacted, invalid int) {
+	for _, f := range forms {
+		form, ok := f.(map[string]any)
+		if !ok {
+			continue
+		}
+		fields, _ := form["fields"].([]any)
+		for _, raw := range fields {
+			field, ok := raw.(map[string]any)
+			if !ok {
+				continue
+			}
+			if valid, ok := field["valid"].(bool); ok && !valid {
+				invalid++
+			}
+			if already, _ := field["redacted"].(bool); already {
+				redacted++
+				continue
+			}
+			value, _ := field["value"].(string)
+			if value == "" {
+				continue
+			}
+			for _, key := range []string{"name", "label"} {
+				name, _ := field[key].(string)
+				if marker, ok := redaction.KeyRedactionMarker(name); ok {
+					field["value"] = marker
+					field["redacted"] = true
+					redacted++
+					break
+				}
+			}
+		}
+	}
+	return redacted, invalid
+}
+
+// GetFormState returns field names, types, current values, and validity for the
+// forms matching selector (default: every form) in the tracked tab.
+func GetFormState(deps Deps, req mcp.JSONRPCRequest, args json.RawMessage) mcp.JSONRPCResponse {
+	var params struct {
+		Selector string `json:"selector"`
+	}
+	if len(args) > 0 {
+		if err := json.Unmarshal(args, &params); err != nil {
+			return mcp.JSONRPCResponse{JSONRPC: "2.0", ID: req.ID, Result: mcp.StructuredErrorResponse(
+				mcp.ErrInvalidJSON, "Invalid JSON arguments: "+err.Error(), "Fix JSON syntax and call again",
+			)}
+		}
+	}
+
+	cap := deps.GetCapture()
+	enabled, _, _ := cap.GetTrackingStatus()
+	if !enabled {
+		return mcp.JSONRPCResponse{JSONRPC: "2.0", ID: req.ID, Result: mcp.StructuredErrorResponse(
+			mcp.ErrNoData,
+			"No tab is being tracked. Open the Kaboom extension popup and click 'Track This Tab'.",
+			"Track a tab first, then call observe with what='form_state'.",
+			mcp.WithHint(deps.DiagnosticHintString()),
+		)}
+	}
+
+	queryParams, _ := json.Marshal(map[string]any{"selector": params.Selector})
+	queryID, qerr := cap.CreatePendingQueryWithTimeout(
+		queries.PendingQuery{
+			Type:   "form_state",
+			Params: queryParams,
+		},
+		10*time.Second,
+		"",
+	)
+	if qerr != nil {
+		return mcp.JSONRPCResponse{JSONRPC: "2.0", ID: req.ID, Result: mcp.StructuredErrorResponse(
+			mcp.ErrQueueFull,
+			"Command queue full: "+qerr.Error(),
+			"Wait for in-flight commands to complete, then retry.",
+			mcp.WithRecoveryToolCall(map[string]any{"tool": "observe", "arguments": map[string]any{"what": "pending_commands"}}),
+		)}
+	}
+
+	result, err := cap.WaitForResult(queryID, 10*time.Second)
+	if err != nil {
+		code, opts := mcp.ExtensionWaitFailure(err)
+		return mcp.JSONRPCResponse{JSONRPC: "2.0", ID: req.ID, Result: mcp.StructuredErrorResponse(
+			code,
+			"Form state timeout: "+err.Error(),
+			"Ensure the extension is connected and the page has loaded.",
+			append(opts, mcp.WithHint(deps.DiagnosticHintString()))...,
+		)}
+	}
+
+	var state struct {
+		Forms   []any  `json:"forms"`
+		Error   string `json:"error"`
+		Message string `json:"message"`
+	}
+	if err := json.Unmarshal(result, &state); err != nil {
+		return mcp.JSONRPCResponse{JSONRPC: "2.0", ID: req.ID, Result: mcp.StructuredErrorResponse(
+			mcp.ErrInvalidJSON,
+			"Failed to parse form state result: "+err.Error(),
+			"Check extension logs for errors",
+		)}
+	}
+	if state.Error != "" {
+		detail := state.Message
+		if detail == "" {
+			detail = state.Error
+		}
+		return mcp.JSONRPCResponse{JSONRPC: "2.0", ID: req.ID, Result: mcp.StructuredErrorResponse(
+			mcp.ErrExtError,
+			"Form state failed: "+detail,
+			"Check the selector syntax and that the tab is accessible.",
+			mcp.WithParam("selector"),
+		)}
+	}
+	if state.Forms == nil {
+		state.Forms = []any{}
+	}
+
+	redacted, invalid := redactFormFields(state.Forms)
+	response := map[string]any{
+		"forms":           state.Forms,
+		"count":           len(state.Forms),
+		"invalid_fields":  invalid,
+		"redacted_fields": redacted,
+		"metadata":        BuildResponseMetadata(cap, time.Now()),
+	}
+	if len(state.Forms) == 0 {
+		hint := "No <form> elements on the page."
+		if params.Selector != "" {
+			hint = "No form matches selector " + params.Selector + "."
+		}
+		response["hint"] = hint + " Check analyze(what='forms') for the forms the page has."
+	}
+	return mcp.Succeed(req, fmt.Sprintf("Form state: %d forms, %d invalid fields", len(state.Forms), invalid), response)
+}
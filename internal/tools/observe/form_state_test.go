@@ -0,0 +1,30 @@
+// form_state_test.go — Tests for form_state field redaction and validity counts.
+package observe
+
+import "testing"
+
+func TestRedactFormFields(t *testing.T) {
+	t.Parallel()
+	forms := []any{
+		map[string]any{"fields": []any{
+			map[string]any{"name": "email", "value": "a@b.co", "valid": true},
+			map[string]any{"name": "pw", "type": "password", "value": "[REDACTED]", "redacted": true, "valid": true},
+			map[string]any{"name": "user_ssn", "value": "123", "valid": false},
+			map[string]any{"name": "f3", "label": "API Token", "value": "abc", "valid": true},
+			map[string]any{"name": "coupon_secret", "value": "", "valid": false},
+		}},
+		"not a form",
+	}
+
+	redacted, invalid := redactFormFields(forms)
+	if redacted != 3 || invalid != 2 {
+		t.Fatalf("redacted, invalid = %d, %d, want 3, 2", redacted, invalid)
+	}
+	fields := forms[0].(map[string]any)["fields"].([]any)
+	want := []string{"a@b.co", "[REDACTED]", "[REDACTED:key-userssn]", "[REDACTED:key-apitoken]", ""}
+	for i, w := range want {
+		if got := fields[i].(map[string]any)["value"]; got != w {
+			t.Errorf("field %d value = %v, want %q", i, got, w)
+		}
+	}
+}
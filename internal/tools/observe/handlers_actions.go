@@ -15,11 +15,13 @@ import (
 // Supports optional "type" filter to return only actions of a specific type.
 func GetEnhancedActions(deps Deps, req mcp.JSONRPCRequest, args json.RawMessage) mcp.JSONRPCResponse {
 	var params struct {
-		Limit   int    `json:"limit"`
-		LastN   int    `json:"last_n"`
-		URL     string `json:"url"`
-		Type    string `json:"type"`
-		Summary bool   `json:"summary"`
+		Limit    int    `json:"limit"`
+		LastN    int    `json:"last_n"`
+		URL      string `json:"url"`
+		Type     string `json:"type"`
+		Summary  bool   `json:"summary"`
+		Boundary string `json:"boundary"`
+		TabID    int    `json:"tab_id"`
 	}
 	mcp.LenientUnmarshal(args, &params)
 	params.Limit = clampLimit(params.Limit, 100)
@@ -29,6 +31,9 @@ func GetEnhancedActions(deps Deps, req mcp.JSONRPCRequest, args json.RawMessage)
 		if params.Type != "" && a.Type != params.Type {
 			return false
 		}
+		if !inBoundary(a.TestIDs, params.Boundary) || !inTab(a.TabID, params.TabID) {
+			return false
+		}
 		if params.URL != "" && !ContainsIgnoreCase(a.URL, params.URL) {
 			return false
 		}
@@ -46,7 +51,9 @@ func GetEnhancedActions(deps Deps, req mcp.JSONRPCRequest, args json.RawMessage)
 
 	responseMeta := BuildResponseMetadata(deps.GetCapture(), newestTS)
 	if params.Summary {
-		return mcp.Succeed(req, "Enhanced actions", buildActionsSummary(filtered, responseMeta))
+		summary := buildActionsSummary(filtered, responseMeta)
+		addBoundaryContext(summary, deps, params.Boundary)
+		return mcp.Succeed(req, "Enhanced actions", summary)
 	}
 
 	response := map[string]any{
@@ -57,6 +64,7 @@ func GetEnhancedActions(deps Deps, req mcp.JSONRPCRequest, args json.RawMessage)
 	if len(filtered) == 0 {
 		response["hint"] = actionsEmptyHint()
 	}
+	addBoundaryContext(response, deps, params.Boundary)
 	return mcp.Succeed(req, "Enhanced actions", response)
 }
 
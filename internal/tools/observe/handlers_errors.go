@@ -7,19 +7,37 @@ import (
 	"time"
 
 	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/buffers"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/lspdiag"
 	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/mcp"
 )
 
+// ErrorSourceLinker is an optional Deps extension that maps error entries onto the
+// calling client's source tree. The result is index-aligned with entries; nil
+// elements are errors that did not resolve to a local file.
+type ErrorSourceLinker interface {
+	LinkErrorSources(clientID string, entries []mcp.LogEntry) []*lspdiag.SourceLink
+}
+
 // GetBrowserErrors returns error-level log entries from the capture buffer.
 func GetBrowserErrors(deps Deps, req mcp.JSONRPCRequest, args json.RawMessage) mcp.JSONRPCResponse {
 	var params struct {
-		Limit   int    `json:"limit"`
-		URL     string `json:"url"`
-		Scope   string `json:"scope"`
-		Summary bool   `json:"summary"`
+		Limit    int    `json:"limit"`
+		URL      string `json:"url"`
+		Scope    string `json:"scope"`
+		Summary  bool   `json:"summary"`
+		Filter   string `json:"filter"`
+		Boundary string `json:"boundary"`
+		TabID    int    `json:"tab_id"`
 	}
 	mcp.LenientUnmarshal(args, &params)
 	params.Limit = clampLimit(params.Limit, 100)
+	if params.Scope == "" && params.Boundary != "" {
+		params.Scope = "all" // a test can span pages
+	}
+	if params.Scope == "" && params.TabID != 0 {
+		params.Scope = "all" // tab_id names the tab instead of the tracked one
+	}
 	if params.Scope == "" {
 		params.Scope = "current_page"
 	}
@@ -33,6 +51,10 @@ func GetBrowserErrors(deps Deps, req mcp.JSONRPCRequest, args json.RawMessage) m
 	if params.URL == "" && params.Scope == "current_page" && trackedTabURL != "" {
 		params.URL = trackedTabURL
 	}
+	query, err := parseLogQuery(params.Filter)
+	if err != nil {
+		return logQueryErrorResponse(req, err)
+	}
 	entries, _ := deps.GetLogEntries()
 
 	noiseSuppressed := 0
@@ -41,10 +63,14 @@ func GetBrowserErrors(deps Deps, req mcp.JSONRPCRequest, args json.RawMessage) m
 		if level != "error" {
 			return false
 		}
-		if deps.IsConsoleNoise(entry) {
+		// A downgrade rule moves the entry out of the error level, so it leaves this view too.
+		if classified, drop := classifyConsoleNoise(deps, entry); drop || classified["level"] != "error" {
 			noiseSuppressed++
 			return false
 		}
+		if !logInBoundary(entry, params.Boundary) || !logInTab(entry, params.TabID) {
+			return false
+		}
 		if params.Scope == "current_page" && trackedTabID != 0 {
 			entryTabID, _ := entry["tabId"].(float64)
 			if int(entryTabID) != trackedTabID {
@@ -57,7 +83,7 @@ func GetBrowserErrors(deps Deps, req mcp.JSONRPCRequest, args json.RawMessage) m
 				return false
 			}
 		}
-		return true
+		return query.match(entry)
 	}, params.Limit)
 
 	errors := make([]map[string]any, len(matched))
@@ -72,6 +98,19 @@ func GetBrowserErrors(deps Deps, req mcp.JSONRPCRequest, args json.RawMessage) m
 			"timestamp": entry["ts"],
 			"tab_id":    entry["tabId"],
 		}
+		if frameID, ok := entry["frameId"]; ok {
+			errors[i]["frame_id"] = frameID
+		}
+		if pressured, _ := entry[capture.PriorityCaptureTag].(bool); pressured {
+			errors[i][capture.PriorityCaptureTag] = true
+		}
+	}
+	if linker, ok := deps.(ErrorSourceLinker); ok && !params.Summary {
+		for i, link := range linker.LinkErrorSources(req.ClientID, matched) {
+			if link != nil && i < len(errors) {
+				errors[i]["source_file"] = link
+			}
+		}
 	}
 
 	var newestTS time.Time
@@ -89,6 +128,7 @@ func GetBrowserErrors(deps Deps, req mcp.JSONRPCRequest, args json.RawMessage) m
 		if paramHint != "" {
 			summaryResp["param_hint"] = paramHint
 		}
+		addBoundaryContext(summaryResp, deps, params.Boundary)
 		return mcp.Succeed(req, "Browser errors", summaryResp)
 	}
 
@@ -104,5 +144,6 @@ func GetBrowserErrors(deps Deps, req mcp.JSONRPCRequest, args json.RawMessage) m
 	if len(errors) == 0 {
 		response["hint"] = errorsEmptyHint(params.Scope)
 	}
+	addBoundaryContext(response, deps, params.Boundary)
 	return mcp.Succeed(req, "Browser errors", response)
 }
@@ -11,7 +11,7 @@ import (
 	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/mcp"
 )
 
-func buildExtensionLogEntries(allLogs []capture.ExtensionLog, limit int, level string, minLevel string) []map[string]any {
+func buildExtensionLogEntries(allLogs []capture.ExtensionLog, limit int, level string, minLevel string, query *logQuery) []map[string]any {
 	matched := buffers.ReverseFilterLimit(allLogs, func(entry capture.ExtensionLog) bool {
 		if level != "" && entry.Level != level {
 			return false
@@ -19,6 +19,9 @@ func buildExtensionLogEntries(allLogs []capture.ExtensionLog, limit int, level s
 		if minLevel != "" && LogLevelRank(entry.Level) < LogLevelRank(minLevel) {
 			return false
 		}
+		if query != nil && !query.match(extensionLogQueryFields(entry)) {
+			return false
+		}
 		return true
 	}, limit)
 
@@ -36,17 +39,36 @@ func buildExtensionLogEntries(allLogs []capture.ExtensionLog, limit int, level s
 	return logs
 }
 
+// extensionLogQueryFields exposes an extension log to filter expressions under the same
+// field names as console entries.
+func extensionLogQueryFields(entry capture.ExtensionLog) map[string]any {
+	return map[string]any{
+		"level":    entry.Level,
+		"message":  entry.Message,
+		"source":   entry.Source,
+		"category": entry.Category,
+		"data":     entry.Data,
+		"ts":       entry.Timestamp.UTC().Format(time.RFC3339Nano),
+	}
+}
+
 // GetExtensionLogs returns internal extension debug logs.
 func GetExtensionLogs(deps Deps, req mcp.JSONRPCRequest, args json.RawMessage) mcp.JSONRPCResponse {
 	var params struct {
-		Limit int    `json:"limit"`
-		Level string `json:"level"`
+		Limit  int    `json:"limit"`
+		Level  string `json:"level"`
+		Filter string `json:"filter"`
 	}
 	mcp.LenientUnmarshal(args, &params)
 	params.Limit = clampLimit(params.Limit, 100)
 
+	query, err := parseLogQuery(params.Filter)
+	if err != nil {
+		return logQueryErrorResponse(req, err)
+	}
+
 	allLogs := deps.GetCapture().GetExtensionLogs()
-	logs := buildExtensionLogEntries(allLogs, params.Limit, params.Level, "")
+	logs := buildExtensionLogEntries(allLogs, params.Limit, params.Level, "", query)
 
 	var newestTS time.Time
 	if len(allLogs) > 0 {
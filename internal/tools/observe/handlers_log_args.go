@@ -0,0 +1,78 @@
+// Purpose: Path filters over structured console arguments and the structured logs output format.
+// Why: Apps log objects like {requestId, userId}; agents correlate on those fields, not on the flattened message.
+// Docs: docs/features/feature/structured-console-args/index.md
+
+package observe
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// logArgsFilter matches log entries whose value at a path (e.g. args[0].requestId) exists,
+// and optionally equals value.
+type logArgsFilter struct {
+	tokens   []jsonPathToken
+	value    string
+	hasValue bool
+}
+
+// newLogArgsFilter parses args_path and args_value. Returns nil when no path is given.
+func newLogArgsFilter(path string, value *string) (*logArgsFilter, error) {
+	if strings.TrimSpace(path) == "" {
+		if value != nil {
+			return nil, fmt.Errorf("args_value requires args_path")
+		}
+		return nil, nil
+	}
+	tokens, err := parseJSONPath(path)
+	if err != nil {
+		return nil, fmt.Errorf("invalid args_path %q: %w", path, err)
+	}
+	f := &logArgsFilter{tokens: tokens}
+	if value != nil {
+		f.value, f.hasValue = *value, true
+	}
+	return f, nil
+}
+
+// match resolves the path against the raw entry, so paths start at a top-level field such as args.
+func (f *logArgsFilter) match(entry map[string]any) bool {
+	v, ok := walkJSONPath(entry, f.tokens)
+	if !ok {
+		return false
+	}
+	return !f.hasValue || logArgValueString(v) == f.value
+}
+
+// logArgValueString renders a resolved value for comparison: strings as-is, everything else as JSON
+// (42, true, null, {"a":1}).
+func logArgValueString(v any) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(raw)
+}
+
+// structuredBrowserLogEntry is normalizeBrowserLogEntry with console arguments lifted out of
+// data into a top-level args array, kept as the JSON values the page logged.
+func structuredBrowserLogEntry(entry map[string]any) map[string]any {
+	normalized := normalizeBrowserLogEntry(entry)
+	args, ok := entry["args"]
+	if !ok {
+		return normalized
+	}
+	normalized["args"] = args
+	if data, ok := normalized["data"].(map[string]any); ok {
+		delete(data, "args")
+		if len(data) == 0 {
+			delete(normalized, "data")
+		}
+	}
+	return normalized
+}
@@ -52,17 +52,32 @@ func normalizeBrowserLogEntry(entry map[string]any) map[string]any {
 	if event, ok := entry["event"]; ok {
 		normalized["event"] = event
 	}
+	if frameID, ok := entry["frameId"]; ok {
+		normalized["frame_id"] = frameID
+	}
 	if pid, ok := entry["pid"]; ok {
 		normalized["pid"] = pid
 	}
 	if port, ok := entry["port"]; ok {
 		normalized["port"] = port
 	}
+	// Set on entries that ingest dedup collapsed from consecutive identical lines.
+	for _, k := range []string{"count", "first_ts", "last_ts"} {
+		if v, ok := entry[k]; ok {
+			normalized[k] = v
+		}
+	}
+	// Set on entries a downgrade or tag noise rule claimed.
+	for _, k := range []string{"noise_rule", "noise_tag", "noise_original_level"} {
+		if v, ok := entry[k]; ok {
+			normalized[k] = v
+		}
+	}
 
 	extras := make(map[string]any)
 	for k, v := range entry {
 		switch k {
-		case "type", "level", "message", "source", "url", "line", "column", "ts", "timestamp", "tabId", "event", "pid", "port":
+		case "type", "level", "message", "source", "url", "line", "column", "ts", "timestamp", "tabId", "frameId", "event", "pid", "port", "count", "first_ts", "last_ts", "noise_rule", "noise_tag", "noise_original_level":
 			// handled above
 		default:
 			extras[k] = v
@@ -11,24 +11,45 @@ import (
 	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/util"
 )
 
+// ConsoleNoiseClassifier is an optional Deps extension for noise rules whose action is not
+// drop: it returns the entry to show (a downgraded or tagged copy when a rule applies) and
+// whether the entry should be dropped.
+type ConsoleNoiseClassifier interface {
+	ClassifyConsoleNoise(entry mcp.LogEntry) (mcp.LogEntry, bool)
+}
+
+// classifyConsoleNoise applies ConsoleNoiseClassifier when deps implements it, else IsConsoleNoise.
+func classifyConsoleNoise(deps Deps, entry mcp.LogEntry) (mcp.LogEntry, bool) {
+	if classifier, ok := deps.(ConsoleNoiseClassifier); ok {
+		return classifier.ClassifyConsoleNoise(entry)
+	}
+	return entry, deps.IsConsoleNoise(entry)
+}
+
 // GetBrowserLogs returns console log entries with cursor-based pagination.
 // #lizard forgives
 func GetBrowserLogs(deps Deps, req mcp.JSONRPCRequest, args json.RawMessage) mcp.JSONRPCResponse {
 	var params struct {
-		Limit             int    `json:"limit"`
-		Level             string `json:"level"`
-		MinLevel          string `json:"min_level"`
-		Source            string `json:"source"`
-		URL               string `json:"url"`
-		Scope             string `json:"scope"`
-		AfterCursor       string `json:"after_cursor"`
-		BeforeCursor      string `json:"before_cursor"`
-		SinceCursor       string `json:"since_cursor"`
-		RestartOnEviction bool   `json:"restart_on_eviction"`
-		IncludeInternal   bool   `json:"include_internal"`
-		IncludeExtension  bool   `json:"include_extension_logs"`
-		ExtensionLimit    int    `json:"extension_limit"`
-		Summary           bool   `json:"summary"`
+		Limit             int     `json:"limit"`
+		Level             string  `json:"level"`
+		MinLevel          string  `json:"min_level"`
+		Source            string  `json:"source"`
+		URL               string  `json:"url"`
+		Scope             string  `json:"scope"`
+		AfterCursor       string  `json:"after_cursor"`
+		BeforeCursor      string  `json:"before_cursor"`
+		SinceCursor       string  `json:"since_cursor"`
+		RestartOnEviction bool    `json:"restart_on_eviction"`
+		IncludeInternal   bool    `json:"include_internal"`
+		IncludeExtension  bool    `json:"include_extension_logs"`
+		ExtensionLimit    int     `json:"extension_limit"`
+		Summary           bool    `json:"summary"`
+		Format            string  `json:"format"`
+		ArgsPath          string  `json:"args_path"`
+		ArgsValue         *string `json:"args_value"`
+		Filter            string  `json:"filter"`
+		Boundary          string  `json:"boundary"`
+		TabID             int     `json:"tab_id"`
 	}
 	mcp.LenientUnmarshal(args, &params)
 
@@ -44,6 +65,12 @@ func GetBrowserLogs(deps Deps, req mcp.JSONRPCRequest, args json.RawMessage) mcp
 		params.MinLevel = ""
 	}
 
+	if params.Scope == "" && params.Boundary != "" {
+		params.Scope = "all" // a test can span pages
+	}
+	if params.Scope == "" && params.TabID != 0 {
+		params.Scope = "all" // tab_id names the tab instead of the tracked one
+	}
 	if params.Scope == "" {
 		params.Scope = "current_page"
 	}
@@ -57,6 +84,30 @@ func GetBrowserLogs(deps Deps, req mcp.JSONRPCRequest, args json.RawMessage) mcp
 		params.Scope = "current_page"
 	}
 
+	if params.Format == "" {
+		params.Format = "text"
+	}
+	if params.Format != "text" && params.Format != "structured" {
+		hint := "Unknown format " + params.Format + " ignored (using default=text). Valid values: text, structured."
+		if paramHint != "" {
+			paramHint += " " + hint
+		} else {
+			paramHint = hint
+		}
+		params.Format = "text"
+	}
+
+	argsFilter, err := newLogArgsFilter(params.ArgsPath, params.ArgsValue)
+	if err != nil {
+		return mcp.JSONRPCResponse{JSONRPC: "2.0", ID: req.ID, Result: mcp.StructuredErrorResponse(
+			mcp.ErrInvalidParam, err.Error(), "Use a path like args[0].requestId", mcp.WithParam("args_path"))}
+	}
+
+	query, err := parseLogQuery(params.Filter)
+	if err != nil {
+		return logQueryErrorResponse(req, err)
+	}
+
 	_, trackedTabID, trackedTabURL := deps.GetCapture().GetTrackingStatus()
 	params.Limit = clampLimit(params.Limit, 100)
 
@@ -84,11 +135,16 @@ func GetBrowserLogs(deps Deps, req mcp.JSONRPCRequest, args json.RawMessage) mcp
 		if !params.IncludeInternal && isInternalLogType(entryType) {
 			continue
 		}
+		if !logInBoundary(e.Entry, params.Boundary) || !logInTab(e.Entry, params.TabID) {
+			continue
+		}
 
-		if deps.IsConsoleNoise(e.Entry) {
+		classified, drop := classifyConsoleNoise(deps, e.Entry)
+		if drop {
 			noiseSuppressed++
 			continue
 		}
+		e.Entry = classified
 
 		if params.Scope == "current_page" && trackedTabID != 0 {
 			if !(params.IncludeInternal && isInternalLogType(entryType)) {
@@ -125,6 +181,14 @@ func GetBrowserLogs(deps Deps, req mcp.JSONRPCRequest, args json.RawMessage) mcp
 			}
 		}
 
+		if argsFilter != nil && !argsFilter.match(e.Entry) {
+			continue
+		}
+
+		if query != nil && !query.match(e.Entry) {
+			continue
+		}
+
 		filtered = append(filtered, e)
 	}
 
@@ -139,9 +203,13 @@ func GetBrowserLogs(deps Deps, req mcp.JSONRPCRequest, args json.RawMessage) mcp
 			mcp.ErrInvalidParam, err.Error(), "Check cursor format or use restart_on_eviction:true")}
 	}
 
+	normalize := normalizeBrowserLogEntry
+	if params.Format == "structured" {
+		normalize = structuredBrowserLogEntry
+	}
 	logs := make([]map[string]any, len(paginated))
 	for i, e := range paginated {
-		logs[i] = normalizeBrowserLogEntry(e.Entry)
+		logs[i] = normalize(e.Entry)
 	}
 
 	var newestTS time.Time
@@ -166,6 +234,7 @@ func GetBrowserLogs(deps Deps, req mcp.JSONRPCRequest, args json.RawMessage) mcp
 		if paramHint != "" {
 			summaryResp["param_hint"] = paramHint
 		}
+		addBoundaryContext(summaryResp, deps, params.Boundary)
 		return mcp.Succeed(req, "Browser logs", summaryResp)
 	}
 
@@ -180,6 +249,7 @@ func GetBrowserLogs(deps Deps, req mcp.JSONRPCRequest, args json.RawMessage) mcp
 	if len(logs) == 0 {
 		response["hint"] = logsEmptyHint(params.Scope, params.MinLevel)
 	}
+	addBoundaryContext(response, deps, params.Boundary)
 
 	if params.IncludeExtension {
 		limit := params.ExtensionLimit
@@ -187,7 +257,7 @@ func GetBrowserLogs(deps Deps, req mcp.JSONRPCRequest, args json.RawMessage) mcp
 			limit = params.Limit
 		}
 		limit = clampLimit(limit, 100)
-		extLogs := buildExtensionLogEntries(deps.GetCapture().GetExtensionLogs(), limit, params.Level, params.MinLevel)
+		extLogs := buildExtensionLogEntries(deps.GetCapture().GetExtensionLogs(), limit, params.Level, params.MinLevel, query)
 		response["extension_logs"] = extLogs
 		response["extension_logs_count"] = len(extLogs)
 	}
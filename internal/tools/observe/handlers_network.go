@@ -4,27 +4,37 @@ package observe
 
 import (
 	"encoding/json"
+	"strings"
 	"time"
 
 	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/buffers"
 	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
 	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/mcp"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/wsdecode"
 )
 
 // GetNetworkBodies returns captured HTTP response bodies with optional filtering.
 // #lizard forgives
 func GetNetworkBodies(deps Deps, req mcp.JSONRPCRequest, args json.RawMessage) mcp.JSONRPCResponse {
 	var params struct {
-		Limit     int    `json:"limit"`
-		URL       string `json:"url"`
-		Method    string `json:"method"`
-		StatusMin int    `json:"status_min"`
-		StatusMax int    `json:"status_max"`
-		BodyPath  string `json:"body_path"`
-		Summary   bool   `json:"summary"`
+		Limit         int    `json:"limit"`
+		URL           string `json:"url"`
+		Method        string `json:"method"`
+		StatusMin     int    `json:"status_min"`
+		StatusMax     int    `json:"status_max"`
+		BodyPath      string `json:"body_path"`
+		Summary       bool   `json:"summary"`
+		RequestID     string `json:"request_id"`
+		FullBody      bool   `json:"full_body"`
+		IncludeBinary bool   `json:"include_binary"`
+		Boundary      string `json:"boundary"`
+		TabID         int    `json:"tab_id"`
 	}
 	mcp.LenientUnmarshal(args, &params)
 	params.Limit = clampLimit(params.Limit, 100)
+	if params.FullBody {
+		return getFullNetworkBody(deps, req, params.RequestID)
+	}
 
 	allBodies := deps.GetCapture().GetNetworkBodies()
 	var bodyFilterErr error
@@ -35,6 +45,12 @@ func GetNetworkBodies(deps Deps, req mcp.JSONRPCRequest, args json.RawMessage) m
 		if params.URL != "" && !ContainsIgnoreCase(b.URL, params.URL) {
 			return false
 		}
+		if params.RequestID != "" && b.RequestID != params.RequestID {
+			return false
+		}
+		if !inBoundary(b.TestIDs, params.Boundary) || !inTab(b.TabID, params.TabID) {
+			return false
+		}
 		if params.Method != "" && !ContainsIgnoreCase(b.Method, params.Method) {
 			return false
 		}
@@ -68,6 +84,10 @@ func GetNetworkBodies(deps Deps, req mcp.JSONRPCRequest, args json.RawMessage) m
 			filtered[i] = filteredBody
 		}
 	}
+	omittedBinary := 0
+	if !params.IncludeBinary {
+		omittedBinary = omitBinaryBodies(filtered)
+	}
 	var newestTS time.Time
 	if len(allBodies) > 0 {
 		newestTS, _ = time.Parse(time.RFC3339, allBodies[len(allBodies)-1].Timestamp)
@@ -87,6 +107,7 @@ func GetNetworkBodies(deps Deps, req mcp.JSONRPCRequest, args json.RawMessage) m
 		if len(filtered) == 0 {
 			summary["hint"] = networkBodiesEmptyHint(waterfallCount, len(allBodies), hintFilters)
 		}
+		addBoundaryContext(summary, deps, params.Boundary)
 		return mcp.Succeed(req, "Network bodies", summary)
 	}
 
@@ -99,10 +120,68 @@ func GetNetworkBodies(deps Deps, req mcp.JSONRPCRequest, args json.RawMessage) m
 	if len(filtered) == 0 {
 		response["hint"] = networkBodiesEmptyHint(waterfallCount, len(allBodies), hintFilters)
 	}
+	if omittedBinary > 0 {
+		response["binary_hint"] = "Base64 bytes of binary responses are omitted; binary_preview summarizes them. Pass include_binary=true to return response_body."
+	}
+	addBoundaryContext(response, deps, params.Boundary)
 
 	return mcp.Succeed(req, "Network bodies", response)
 }
 
+// omitBinaryBodies drops captured base64 binary responses from the entries, keeping their
+// binary_preview, and returns how many were dropped.
+func omitBinaryBodies(bodies []capture.NetworkBody) int {
+	omitted := 0
+	for i := range bodies {
+		if bodies[i].ResponseEncoding == "base64" && bodies[i].ResponseBody != "" {
+			bodies[i].ResponseBody = ""
+			omitted++
+		}
+	}
+	return omitted
+}
+
+// getFullNetworkBody returns the complete payload kept for requestID past the inline limits,
+// alongside its inline entry when that is still buffered.
+func getFullNetworkBody(deps Deps, req mcp.JSONRPCRequest, requestID string) mcp.JSONRPCResponse {
+	if requestID == "" {
+		return mcp.Fail(req, mcp.ErrMissingParam, "full_body requires request_id",
+			"Pass the full_body_ref of a truncated entry from observe(what=\"network_bodies\") as request_id", mcp.WithParam("request_id"))
+	}
+	cap := deps.GetCapture()
+	var entries []capture.NetworkBody
+	for _, b := range cap.GetNetworkBodies() {
+		if b.RequestID == requestID {
+			entries = append(entries, b)
+		}
+	}
+	full, ok := cap.GetFullBody(requestID)
+	if !ok && len(entries) > 0 && entries[0].FullBodyRef == "" && !entries[0].BodyEvicted {
+		// Never spilled: the inline entry already holds everything that was captured.
+		b := entries[0]
+		full = capture.FullBody{RequestID: b.RequestID, Method: b.Method, URL: b.URL, Status: b.Status,
+			RequestBody: b.RequestBody, ResponseBody: b.ResponseBody, Complete: !b.RequestTruncated && !b.ResponseTruncated}
+		ok = true
+	}
+	if !ok {
+		return mcp.Fail(req, mcp.ErrNoData, "No full body retained for request_id "+requestID,
+			"The full body was evicted or the ID is unknown; reproduce the request, then fetch its new full_body_ref", mcp.WithParam("request_id"))
+	}
+	if entries == nil {
+		entries = []capture.NetworkBody{}
+	}
+	response := map[string]any{
+		"entries":   entries,
+		"count":     len(entries),
+		"full_body": full,
+		"metadata":  BuildResponseMetadata(cap, time.Now()),
+	}
+	if !full.Complete {
+		response["hint"] = "The extension cut this body at capture_body_max; raise it with configure(what=\"body_limits\", capture_body_max=...) and reproduce the request"
+	}
+	return mcp.Succeed(req, "Full network body", response)
+}
+
 // GetWSEvents returns captured WebSocket events with optional filtering.
 func GetWSEvents(deps Deps, req mcp.JSONRPCRequest, args json.RawMessage) mcp.JSONRPCResponse {
 	var params struct {
@@ -111,6 +190,8 @@ func GetWSEvents(deps Deps, req mcp.JSONRPCRequest, args json.RawMessage) mcp.JS
 		ConnectionID string `json:"connection_id"`
 		Direction    string `json:"direction"`
 		Summary      bool   `json:"summary"`
+		Boundary     string `json:"boundary"`
+		TabID        int    `json:"tab_id"`
 	}
 	mcp.LenientUnmarshal(args, &params)
 
@@ -133,22 +214,28 @@ func GetWSEvents(deps Deps, req mcp.JSONRPCRequest, args json.RawMessage) mcp.JS
 		if params.Direction != "" && evt.Direction != params.Direction {
 			return false
 		}
-		return true
+		return inBoundary(evt.TestIDs, params.Boundary) && inTab(evt.TabID, params.TabID)
 	}, params.Limit)
+	decodeWSMessages(deps.GetCapture().WSDecoders(), filtered)
 	var newestTS time.Time
 	if len(allEvents) > 0 {
 		newestTS, _ = time.Parse(time.RFC3339, allEvents[len(allEvents)-1].Timestamp)
 	}
 
 	responseMeta := BuildResponseMetadata(deps.GetCapture(), newestTS)
+	sampling := wsSamplingEstimate(deps.GetCapture(), params.URL, params.ConnectionID)
 	if params.Summary {
 		summary := buildWSEventsSummary(filtered, responseMeta)
+		if sampling != nil {
+			summary["sampling"] = sampling
+		}
 		if paramHint != "" {
 			summary["param_hint"] = paramHint
 		}
 		if len(filtered) == 0 {
 			summary["hint"] = wsEventsEmptyHint(len(allEvents), params.URL)
 		}
+		addBoundaryContext(summary, deps, params.Boundary)
 		return mcp.Succeed(req, "WebSocket events", summary)
 	}
 
@@ -157,6 +244,9 @@ func GetWSEvents(deps Deps, req mcp.JSONRPCRequest, args json.RawMessage) mcp.JS
 		"count":    len(filtered),
 		"metadata": responseMeta,
 	}
+	if sampling != nil {
+		response["sampling"] = sampling
+	}
 	if paramHint != "" {
 		response["param_hint"] = paramHint
 	}
@@ -164,6 +254,56 @@ func GetWSEvents(deps Deps, req mcp.JSONRPCRequest, args json.RawMessage) mcp.JS
 	if len(filtered) == 0 {
 		response["hint"] = wsEventsEmptyHint(len(allEvents), params.URL)
 	}
+	addBoundaryContext(response, deps, params.Boundary)
 
 	return mcp.Succeed(req, "WebSocket events", response)
 }
+
+// wsSamplingEstimate totals what ws_sampling policies dropped on the connections the filters
+// select, open and closed. Returns nil when nothing is sampled. Drops by the extension's own
+// adaptive sampling happen before ingest and are not counted, so the total is a lower bound.
+func wsSamplingEstimate(c *capture.Capture, url, connectionID string) map[string]any {
+	status := c.GetWebSocketStatus(capture.WebSocketStatusFilter{ConnectionID: connectionID})
+	selected := func(connURL string) bool { return url == "" || ContainsIgnoreCase(connURL, url) }
+
+	dropped, sampled := 0, 0
+	extension := false
+	for _, conn := range status.Connections {
+		if !selected(conn.URL) {
+			continue
+		}
+		if conn.Sampling.Policy != nil {
+			sampled++
+			dropped += conn.Sampling.Dropped
+		}
+		if strings.Contains(conn.Sampling.Reason, "extension") {
+			extension = true
+		}
+	}
+	for _, closed := range status.Closed {
+		if selected(closed.URL) {
+			dropped += closed.SampledDropped
+		}
+	}
+	policies := len(c.GetWSSamplingPolicies())
+	if dropped == 0 && sampled == 0 && !extension {
+		return nil
+	}
+	return map[string]any{
+		"dropped_estimate":    dropped,
+		"sampled_connections": sampled,
+		"policies":            policies,
+		"extension_sampling":  extension,
+		"note":                "dropped_estimate counts events dropped by configure(what=\"ws_sampling\") policies; messages the extension skipped before sending are not included",
+	}
+}
+
+// decodeWSMessages attaches the decoded form of each message payload. Decoding happens at read
+// time so descriptor sets registered after the traffic still apply.
+func decodeWSMessages(decoders *wsdecode.Registry, events []capture.WebSocketEvent) {
+	for i := range events {
+		if events[i].Event == "message" && !events[i].DataEvicted {
+			events[i].Decoded = decoders.Decode(events[i].URL, events[i].Data)
+		}
+	}
+}
@@ -0,0 +1,137 @@
+// Purpose: Observe handler for the daemon's HTTP debug log: recent /mcp request/response pairs with cursors.
+// Why: Agents diagnosing a failed tool call need their own request trail without curling /diagnostics.
+// Docs: docs/features/feature/server-debug-log/index.md
+
+package observe
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/mcp"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/pagination"
+)
+
+// GetServerDebug returns entries from the daemon's HTTP debug ring (oldest first, cursor-paginated).
+// The current call is logged after it responds, so it never appears in its own result.
+func GetServerDebug(deps Deps, req mcp.JSONRPCRequest, args json.RawMessage) mcp.JSONRPCResponse {
+	var params struct {
+		Limit             int    `json:"limit"`
+		Endpoint          string `json:"endpoint"`
+		Method            string `json:"method"`
+		StatusMin         int    `json:"status_min"`
+		SlowMs            int64  `json:"slow_ms"`
+		ErrorsOnly        bool   `json:"errors_only"`
+		Mine              bool   `json:"mine"`
+		AfterCursor       string `json:"after_cursor"`
+		BeforeCursor      string `json:"before_cursor"`
+		SinceCursor       string `json:"since_cursor"`
+		RestartOnEviction bool   `json:"restart_on_eviction"`
+		Summary           bool   `json:"summary"`
+	}
+	mcp.LenientUnmarshal(args, &params)
+	params.Limit = clampLimit(params.Limit, 20)
+
+	cap := deps.GetCapture()
+	all, totalAdded := cap.GetHTTPDebugEntries()
+	matches := func(e capture.HTTPDebugEntry) bool {
+		if params.Endpoint != "" && !ContainsIgnoreCase(e.Endpoint, params.Endpoint) {
+			return false
+		}
+		if params.Method != "" && !ContainsIgnoreCase(e.Method, params.Method) {
+			return false
+		}
+		if params.StatusMin > 0 && e.ResponseStatus < params.StatusMin {
+			return false
+		}
+		if params.SlowMs > 0 && e.DurationMs < params.SlowMs {
+			return false
+		}
+		if params.ErrorsOnly && !serverDebugFailed(e) {
+			return false
+		}
+		return !params.Mine || e.ClientID == req.ClientID
+	}
+
+	enriched := pagination.EnrichHTTPDebugEntries(all, totalAdded)
+	filtered := make([]pagination.HTTPDebugEntryWithSequence, 0, len(enriched))
+	for _, e := range enriched {
+		if matches(e.Entry) {
+			filtered = append(filtered, e)
+		}
+	}
+
+	paginated, pMeta, err := pagination.ApplyCursorPagination(filtered, pagination.CursorParams{
+		AfterCursor:       params.AfterCursor,
+		BeforeCursor:      params.BeforeCursor,
+		SinceCursor:       params.SinceCursor,
+		Limit:             params.Limit,
+		RestartOnEviction: params.RestartOnEviction,
+	})
+	if err != nil {
+		return mcp.Fail(req, mcp.ErrInvalidParam, err.Error(), "Check cursor format or use restart_on_eviction:true")
+	}
+
+	var newestTS time.Time
+	if len(paginated) > 0 {
+		newestTS = paginated[len(paginated)-1].Entry.Timestamp
+	}
+	isFirstPage := params.AfterCursor == "" && params.BeforeCursor == "" && params.SinceCursor == ""
+	meta := BuildPaginatedMetadataWithSummary(cap, newestTS, pMeta, isFirstPage, nil)
+
+	if params.Summary {
+		return mcp.Succeed(req, "Server debug log", buildServerDebugSummary(filtered, meta))
+	}
+
+	entries := make([]map[string]any, len(paginated))
+	for i, e := range paginated {
+		entries[i] = pagination.SerializeHTTPDebugEntryWithSequence(e)
+	}
+	response := map[string]any{
+		"entries":  entries,
+		"count":    len(entries),
+		"metadata": meta,
+	}
+	if len(entries) == 0 {
+		response["hint"] = serverDebugEmptyHint(len(all))
+	}
+	return mcp.Succeed(req, "Server debug log", response)
+}
+
+// serverDebugFailed reports whether an entry is an HTTP failure or carries an error,
+// which includes JSON-RPC and tool errors returned with status 200.
+func serverDebugFailed(e capture.HTTPDebugEntry) bool {
+	return e.ResponseStatus >= http.StatusBadRequest || e.Error != ""
+}
+
+// buildServerDebugSummary returns {total, by_endpoint, by_status, errors, max_duration_ms, avg_duration_ms, metadata}.
+func buildServerDebugSummary(entries []pagination.HTTPDebugEntryWithSequence, meta map[string]any) map[string]any {
+	byEndpoint := make(map[string]int)
+	byStatus := make(map[int]int)
+	errors := 0
+	var maxMs, sumMs int64
+	for _, e := range entries {
+		byEndpoint[e.Entry.Endpoint]++
+		byStatus[e.Entry.ResponseStatus]++
+		if serverDebugFailed(e.Entry) {
+			errors++
+		}
+		sumMs += e.Entry.DurationMs
+		maxMs = max(maxMs, e.Entry.DurationMs)
+	}
+	var avgMs int64
+	if len(entries) > 0 {
+		avgMs = sumMs / int64(len(entries))
+	}
+	return map[string]any{
+		"total":           len(entries),
+		"by_endpoint":     byEndpoint,
+		"by_status":       byStatus,
+		"errors":          errors,
+		"max_duration_ms": maxMs,
+		"avg_duration_ms": avgMs,
+		"metadata":        meta,
+	}
+}
@@ -0,0 +1,195 @@
+// Purpose: Observe handler for Server-Sent Events (EventSource) streams: events with cursors, plus per-connection state.
+// Why: SSE-driven UIs fail on dropped streams and silent reconnect loops that only the connection lifecycle shows.
+// Docs: docs/features/feature/sse-tracking/index.md
+
+package observe
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/mcp"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/pagination"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/util"
+)
+
+var sseLifecycleEvents = map[string]bool{"open": true, "message": true, "retry": true, "error": true, "close": true}
+
+// GetSSEEvents returns captured EventSource events (oldest first, cursor-paginated) and the
+// state of each connection they belong to.
+func GetSSEEvents(deps Deps, req mcp.JSONRPCRequest, args json.RawMessage) mcp.JSONRPCResponse {
+	var params struct {
+		Limit             int    `json:"limit"`
+		URL               string `json:"url"`
+		ConnectionID      string `json:"connection_id"`
+		Event             string `json:"event"`
+		EventType         string `json:"event_type"`
+		AfterCursor       string `json:"after_cursor"`
+		BeforeCursor      string `json:"before_cursor"`
+		SinceCursor       string `json:"since_cursor"`
+		RestartOnEviction bool   `json:"restart_on_eviction"`
+		Summary           bool   `json:"summary"`
+		Boundary          string `json:"boundary"`
+		TabID             int    `json:"tab_id"`
+	}
+	mcp.LenientUnmarshal(args, &params)
+
+	var paramHint string
+	if params.Event != "" && !sseLifecycleEvents[params.Event] {
+		paramHint = "Unknown event " + params.Event + " ignored (using default=all). Valid values: open, message, retry, error, close."
+		params.Event = ""
+	}
+	params.Limit = clampLimit(params.Limit, 100)
+
+	cap := deps.GetCapture()
+	allEvents := cap.GetAllSSEEvents()
+	inScope := func(evt capture.SSEEvent) bool {
+		if params.URL != "" && !ContainsIgnoreCase(evt.URL, params.URL) {
+			return false
+		}
+		if !inBoundary(evt.TestIDs, params.Boundary) || !inTab(evt.TabID, params.TabID) {
+			return false
+		}
+		return params.ConnectionID == "" || evt.ID == params.ConnectionID
+	}
+
+	enriched := pagination.EnrichSSEEntries(allEvents, cap.GetSSETotalAdded())
+	filtered := make([]pagination.SSEEntryWithSequence, 0, len(enriched))
+	scoped := make([]capture.SSEEvent, 0, len(allEvents))
+	for _, e := range enriched {
+		if !inScope(e.Entry) {
+			continue
+		}
+		scoped = append(scoped, e.Entry)
+		if params.Event != "" && e.Entry.Event != params.Event {
+			continue
+		}
+		if params.EventType != "" && e.Entry.EventType != params.EventType {
+			continue
+		}
+		filtered = append(filtered, e)
+	}
+
+	paginated, pMeta, err := pagination.ApplyCursorPagination(filtered, pagination.CursorParams{
+		AfterCursor:       params.AfterCursor,
+		BeforeCursor:      params.BeforeCursor,
+		SinceCursor:       params.SinceCursor,
+		Limit:             params.Limit,
+		RestartOnEviction: params.RestartOnEviction,
+	})
+	if err != nil {
+		return mcp.Fail(req, mcp.ErrInvalidParam, err.Error(), "Check cursor format or use restart_on_eviction:true")
+	}
+
+	var newestTS time.Time
+	if len(paginated) > 0 {
+		newestTS = util.ParseTimestamp(paginated[len(paginated)-1].Timestamp)
+	}
+	isFirstPage := params.AfterCursor == "" && params.BeforeCursor == "" && params.SinceCursor == ""
+	meta := BuildPaginatedMetadataWithSummary(cap, newestTS, pMeta, isFirstPage, nil)
+	connections := buildSSEConnections(scoped)
+
+	if params.Summary {
+		summary := buildSSESummary(filtered, connections, meta)
+		if paramHint != "" {
+			summary["param_hint"] = paramHint
+		}
+		addBoundaryContext(summary, deps, params.Boundary)
+		return mcp.Succeed(req, "SSE events", summary)
+	}
+
+	entries := make([]map[string]any, len(paginated))
+	for i, e := range paginated {
+		entries[i] = pagination.SerializeSSEEntryWithSequence(e)
+	}
+	response := map[string]any{
+		"entries":     entries,
+		"count":       len(entries),
+		"connections": connections,
+		"metadata":    meta,
+	}
+	if paramHint != "" {
+		response["param_hint"] = paramHint
+	}
+	if len(entries) == 0 {
+		response["hint"] = sseEmptyHint(len(allEvents), params.URL)
+	}
+	addBoundaryContext(response, deps, params.Boundary)
+	return mcp.Succeed(req, "SSE events", response)
+}
+
+// sseConnection is the state of one EventSource as reconstructed from its events.
+type sseConnection struct {
+	ID          string         `json:"id"`
+	URL         string         `json:"url,omitempty"`
+	State       string         `json:"state"` // connecting, open, reconnecting, closed
+	OpenedAt    string         `json:"opened_at,omitempty"`
+	LastEventAt string         `json:"last_event_at,omitempty"`
+	Messages    int            `json:"messages"`
+	Retries     int            `json:"retries"`
+	LastEventID string         `json:"last_event_id,omitempty"`
+	EventTypes  map[string]int `json:"event_types,omitempty"`
+}
+
+// buildSSEConnections folds events into per-connection state, in order of first appearance.
+// A retry means the browser lost the stream and is reconnecting; error and close are terminal.
+func buildSSEConnections(events []capture.SSEEvent) []sseConnection {
+	byID := map[string]*sseConnection{}
+	var order []string
+	for _, evt := range events {
+		conn := byID[evt.ID]
+		if conn == nil {
+			conn = &sseConnection{ID: evt.ID, URL: evt.URL, State: "connecting"}
+			byID[evt.ID] = conn
+			order = append(order, evt.ID)
+		}
+		conn.LastEventAt = evt.Timestamp
+		switch evt.Event {
+		case "open":
+			conn.State = "open"
+			if conn.OpenedAt == "" {
+				conn.OpenedAt = evt.Timestamp
+			}
+		case "message":
+			conn.State = "open"
+			conn.Messages++
+			if conn.EventTypes == nil {
+				conn.EventTypes = map[string]int{}
+			}
+			conn.EventTypes[evt.EventType]++
+			if evt.LastEventID != "" {
+				conn.LastEventID = evt.LastEventID
+			}
+		case "retry":
+			conn.State = "reconnecting"
+			conn.Retries++
+		case "error", "close":
+			conn.State = "closed"
+		}
+	}
+	out := make([]sseConnection, len(order))
+	for i, id := range order {
+		out[i] = *byID[id]
+	}
+	return out
+}
+
+// buildSSESummary returns {total, by_event, by_event_type, connections, metadata}.
+func buildSSESummary(entries []pagination.SSEEntryWithSequence, connections []sseConnection, meta map[string]any) map[string]any {
+	byEvent := make(map[string]int)
+	byEventType := make(map[string]int)
+	for _, e := range entries {
+		byEvent[e.Entry.Event]++
+		if e.Entry.EventType != "" {
+			byEventType[e.Entry.EventType]++
+		}
+	}
+	return map[string]any{
+		"total":         len(entries),
+		"by_event":      byEvent,
+		"by_event_type": byEventType,
+		"connections":   connections,
+		"metadata":      meta,
+	}
+}
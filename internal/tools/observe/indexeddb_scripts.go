@@ -112,13 +112,33 @@ func buildIndexedDBEntriesScript(database, store string, limit int) string {
 })())()`, jsStringLiteral(database), jsStringLiteral(store), limit)
 }
 
+// indexedDBListingScript lists databases with per-store record counts and, when the
+// Storage API is available, the origin's quota estimate.
 const indexedDBListingScript = `(() => (async () => {
+  let quota;
+  try {
+    if (typeof navigator !== "undefined" && navigator.storage && typeof navigator.storage.estimate === "function") {
+      const estimate = await navigator.storage.estimate();
+      const usage = typeof estimate.usage === "number" ? estimate.usage : 0;
+      const limit = typeof estimate.quota === "number" ? estimate.quota : 0;
+      quota = {
+        usage,
+        quota: limit,
+        percent_used: limit > 0 ? Math.round((usage / limit) * 10000) / 100 : 0,
+        persisted: typeof navigator.storage.persisted === "function" ? await navigator.storage.persisted() : false
+      };
+      if (estimate.usageDetails) quota.usage_details = estimate.usageDetails;
+    }
+  } catch {
+    quota = undefined;
+  }
+
   try {
     if (typeof indexedDB === "undefined") {
-      return { supported: false, databases: [] };
+      return { supported: false, databases: [], quota };
     }
     if (typeof indexedDB.databases !== "function") {
-      return { supported: false, databases: [], error: "indexeddb_databases_unavailable" };
+      return { supported: false, databases: [], error: "indexeddb_databases_unavailable", quota };
     }
 
     const infos = await indexedDB.databases();
@@ -128,6 +148,8 @@ const indexedDBListingScript = `(() => (async () => {
       const name = String(info.name);
       const version = typeof info.version === "number" ? info.version : null;
       let objectStores = [];
+      const recordCounts = {};
+      let totalRecords = 0;
       try {
         const req = indexedDB.open(name);
         const db = await new Promise((resolve, reject) => {
@@ -136,6 +158,18 @@ const indexedDBListingScript = `(() => (async () => {
           req.onblocked = () => reject(new Error("indexeddb_open_blocked"));
         });
         objectStores = Array.from(db.objectStoreNames || []);
+        if (objectStores.length > 0) {
+          const tx = db.transaction(objectStores, "readonly");
+          const counts = await Promise.all(objectStores.map((store) => new Promise((resolve) => {
+            const countReq = tx.objectStore(store).count();
+            countReq.onsuccess = () => resolve(countReq.result || 0);
+            countReq.onerror = () => resolve(-1);
+          })));
+          objectStores.forEach((store, i) => {
+            recordCounts[store] = counts[i];
+            if (counts[i] > 0) totalRecords += counts[i];
+          });
+        }
         db.close();
       } catch {
         objectStores = [];
@@ -144,13 +178,15 @@ const indexedDBListingScript = `(() => (async () => {
       databases.push({
         name,
         version,
-        object_stores: objectStores
+        object_stores: objectStores,
+        record_counts: recordCounts,
+        total_records: totalRecords
       });
     }
 
-    return { supported: true, databases };
+    return { supported: true, databases, quota };
   } catch (e) {
-    return { supported: false, databases: [], error: String((e && e.message) || e) };
+    return { supported: false, databases: [], error: String((e && e.message) || e), quota };
   }
 })())()`
 
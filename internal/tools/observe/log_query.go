@@ -0,0 +1,410 @@
+// Purpose: Parses and evaluates log filter expressions such as level>=warn AND url~"checkout" AND NOT message~"ResizeObserver".
+// Why: Agents otherwise pull hundreds of entries and filter them client-side; one compiled expression does it server-side.
+// Docs: docs/features/feature/log-query/index.md
+
+package observe
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/mcp"
+)
+
+const (
+	maxLogQueryLength = 1000
+	maxLogQueryDepth  = 32
+)
+
+// logQuery is a compiled filter expression. A nil *logQuery matches everything.
+type logQuery struct {
+	root logQueryNode
+}
+
+type logQueryNode interface {
+	eval(entry map[string]any) bool
+}
+
+type logQueryAnd struct{ left, right logQueryNode }
+type logQueryOr struct{ left, right logQueryNode }
+type logQueryNot struct{ inner logQueryNode }
+
+func (n logQueryAnd) eval(entry map[string]any) bool {
+	return n.left.eval(entry) && n.right.eval(entry)
+}
+
+func (n logQueryOr) eval(entry map[string]any) bool {
+	return n.left.eval(entry) || n.right.eval(entry)
+}
+
+func (n logQueryNot) eval(entry map[string]any) bool {
+	return !n.inner.eval(entry)
+}
+
+// logQueryCompare is one field/operator/value term.
+type logQueryCompare struct {
+	field string
+	path  []jsonPathToken // set when field is a path such as args[0].requestId
+	op    string
+	value string
+	re    *regexp.Regexp // for ~ and !~
+	rank  int            // level rank for ordering operators on level
+}
+
+// match reports whether the expression accepts the entry.
+func (q *logQuery) match(entry map[string]any) bool {
+	return q == nil || q.root.eval(entry)
+}
+
+// parseLogQuery compiles a filter expression. Returns nil for an empty expression.
+//
+// Grammar (keywords are case-insensitive; NOT binds tighter than AND, AND tighter than OR):
+//
+//	expr    := and { OR and }
+//	and     := unary { AND unary }
+//	unary   := NOT unary | "(" expr ")" | field op value
+//	op      := = | != | ~ | !~ | > | >= | < | <=
+//
+// ~ is a case-insensitive regex match. Values may be bare words or quoted with " or '.
+func parseLogQuery(expr string) (*logQuery, error) {
+	if strings.TrimSpace(expr) == "" {
+		return nil, nil
+	}
+	if len(expr) > maxLogQueryLength {
+		return nil, fmt.Errorf("filter exceeds %d characters", maxLogQueryLength)
+	}
+	tokens, err := lexLogQuery(expr)
+	if err != nil {
+		return nil, err
+	}
+	p := &logQueryParser{tokens: tokens}
+	root, err := p.parseOr(0)
+	if err != nil {
+		return nil, err
+	}
+	if tok, ok := p.peek(); ok {
+		return nil, fmt.Errorf("unexpected %q at position %d", tok.text, tok.pos)
+	}
+	return &logQuery{root: root}, nil
+}
+
+// logQueryErrorResponse reports a filter that failed to parse.
+func logQueryErrorResponse(req mcp.JSONRPCRequest, err error) mcp.JSONRPCResponse {
+	return mcp.JSONRPCResponse{JSONRPC: "2.0", ID: req.ID, Result: mcp.StructuredErrorResponse(
+		mcp.ErrInvalidParam, "Invalid filter: "+err.Error(),
+		`Use field op value terms joined by AND/OR/NOT, e.g. level>=warn AND url~"checkout" AND NOT message~"ResizeObserver"`,
+		mcp.WithParam("filter"))}
+}
+
+// ============================================
+// Lexer
+// ============================================
+
+type logQueryTokenKind int
+
+const (
+	tokWord logQueryTokenKind = iota
+	tokString
+	tokOp
+	tokLParen
+	tokRParen
+)
+
+type logQueryToken struct {
+	kind logQueryTokenKind
+	text string
+	pos  int
+}
+
+func lexLogQuery(expr string) ([]logQueryToken, error) {
+	var tokens []logQueryToken
+	for i := 0; i < len(expr); {
+		c := expr[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '(':
+			tokens = append(tokens, logQueryToken{kind: tokLParen, text: "(", pos: i})
+			i++
+		case c == ')':
+			tokens = append(tokens, logQueryToken{kind: tokRParen, text: ")", pos: i})
+			i++
+		case c == '"' || c == '\'':
+			text, next, err := lexQuotedString(expr, i)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, logQueryToken{kind: tokString, text: text, pos: i})
+			i = next
+		case strings.IndexByte("=!~<>", c) >= 0:
+			op := lexLogQueryOperator(expr[i:])
+			if op == "" {
+				return nil, fmt.Errorf("unexpected '!' at position %d (use != or !~, or NOT)", i)
+			}
+			text := op
+			if op == "==" {
+				text = "="
+			}
+			tokens = append(tokens, logQueryToken{kind: tokOp, text: text, pos: i})
+			i += len(op)
+		default:
+			start := i
+			for i < len(expr) && !isLogQueryDelimiter(expr[i]) {
+				i++
+			}
+			tokens = append(tokens, logQueryToken{kind: tokWord, text: expr[start:i], pos: start})
+		}
+	}
+	return tokens, nil
+}
+
+// lexLogQueryOperator returns the operator at the start of s, preferring two-character operators.
+func lexLogQueryOperator(s string) string {
+	for _, op := range []string{"==", "!=", "!~", ">=", "<=", "=", "~", ">", "<"} {
+		if strings.HasPrefix(s, op) {
+			return op
+		}
+	}
+	return ""
+}
+
+func isLogQueryDelimiter(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '(' || c == ')' || c == '"' || c == '\'' || strings.IndexByte("=!~<>", c) >= 0
+}
+
+// lexQuotedString reads a quoted string starting at expr[start]; backslash escapes the next character.
+func lexQuotedString(expr string, start int) (string, int, error) {
+	quote := expr[start]
+	var b strings.Builder
+	for i := start + 1; i < len(expr); i++ {
+		switch expr[i] {
+		case '\\':
+			if i+1 < len(expr) {
+				i++
+				b.WriteByte(expr[i])
+			}
+		case quote:
+			return b.String(), i + 1, nil
+		default:
+			b.WriteByte(expr[i])
+		}
+	}
+	return "", 0, fmt.Errorf("unterminated string starting at position %d", start)
+}
+
+// ============================================
+// Parser
+// ============================================
+
+type logQueryParser struct {
+	tokens []logQueryToken
+	pos    int
+}
+
+func (p *logQueryParser) peek() (logQueryToken, bool) {
+	if p.pos >= len(p.tokens) {
+		return logQueryToken{}, false
+	}
+	return p.tokens[p.pos], true
+}
+
+func (p *logQueryParser) peekKeyword(keyword string) bool {
+	tok, ok := p.peek()
+	return ok && tok.kind == tokWord && strings.EqualFold(tok.text, keyword)
+}
+
+func (p *logQueryParser) parseOr(depth int) (logQueryNode, error) {
+	left, err := p.parseAnd(depth)
+	if err != nil {
+		return nil, err
+	}
+	for p.peekKeyword("OR") {
+		p.pos++
+		right, err := p.parseAnd(depth)
+		if err != nil {
+			return nil, err
+		}
+		left = logQueryOr{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *logQueryParser) parseAnd(depth int) (logQueryNode, error) {
+	left, err := p.parseUnary(depth)
+	if err != nil {
+		return nil, err
+	}
+	for p.peekKeyword("AND") {
+		p.pos++
+		right, err := p.parseUnary(depth)
+		if err != nil {
+			return nil, err
+		}
+		left = logQueryAnd{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *logQueryParser) parseUnary(depth int) (logQueryNode, error) {
+	if depth > maxLogQueryDepth {
+		return nil, fmt.Errorf("filter nests deeper than %d levels", maxLogQueryDepth)
+	}
+	tok, ok := p.peek()
+	if !ok {
+		return nil, fmt.Errorf("unexpected end of filter")
+	}
+	switch {
+	case tok.kind == tokWord && strings.EqualFold(tok.text, "NOT"):
+		p.pos++
+		inner, err := p.parseUnary(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		return logQueryNot{inner: inner}, nil
+	case tok.kind == tokLParen:
+		p.pos++
+		inner, err := p.parseOr(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		if closing, ok := p.peek(); !ok || closing.kind != tokRParen {
+			return nil, fmt.Errorf("missing ')' for '(' at position %d", tok.pos)
+		}
+		p.pos++
+		return inner, nil
+	case tok.kind == tokWord:
+		return p.parseComparison()
+	default:
+		return nil, fmt.Errorf("unexpected %q at position %d", tok.text, tok.pos)
+	}
+}
+
+func (p *logQueryParser) parseComparison() (logQueryNode, error) {
+	fieldTok := p.tokens[p.pos]
+	p.pos++
+	opTok, ok := p.peek()
+	if !ok || opTok.kind != tokOp {
+		return nil, fmt.Errorf("expected an operator after %q at position %d (=, !=, ~, !~, >, >=, <, <=)", fieldTok.text, fieldTok.pos)
+	}
+	p.pos++
+	valueTok, ok := p.peek()
+	if !ok || (valueTok.kind != tokWord && valueTok.kind != tokString) {
+		return nil, fmt.Errorf("expected a value after %s%s at position %d", fieldTok.text, opTok.text, opTok.pos)
+	}
+	p.pos++
+	return newLogQueryCompare(fieldTok.text, opTok.text, valueTok.text)
+}
+
+func newLogQueryCompare(field, op, value string) (logQueryNode, error) {
+	cmp := logQueryCompare{field: field, op: op, value: value}
+	if strings.ContainsAny(field, ".[") {
+		path, err := parseJSONPath(field)
+		if err != nil {
+			return nil, fmt.Errorf("invalid field path %q: %w", field, err)
+		}
+		cmp.path = path
+	}
+	switch op {
+	case "~", "!~":
+		re, err := regexp.Compile("(?i)" + value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid regex %q for %s: %w", value, field, err)
+		}
+		cmp.re = re
+	case ">", ">=", "<", "<=":
+		if field == "level" {
+			cmp.rank = LogLevelRank(strings.ToLower(value))
+			if cmp.rank < 0 {
+				return nil, fmt.Errorf("unknown level %q (valid: debug, log, info, warn, error)", value)
+			}
+		}
+	}
+	return cmp, nil
+}
+
+// ============================================
+// Evaluation
+// ============================================
+
+// logQueryFieldAliases maps documented field names onto raw log entry keys.
+var logQueryFieldAliases = map[string]string{
+	"tab_id":   "tabId",
+	"frame_id": "frameId",
+}
+
+// resolve returns the field's value in a raw log entry.
+func (c logQueryCompare) resolve(entry map[string]any) (any, bool) {
+	if c.path != nil {
+		return walkJSONPath(entry, c.path)
+	}
+	switch c.field {
+	case "ts", "timestamp":
+		ts := logEntryTimestamp(entry)
+		return ts, ts != ""
+	}
+	key := c.field
+	if alias, ok := logQueryFieldAliases[key]; ok {
+		key = alias
+	}
+	v, ok := entry[key]
+	return v, ok && v != nil
+}
+
+func (c logQueryCompare) eval(entry map[string]any) bool {
+	raw, ok := c.resolve(entry)
+	if !ok {
+		// A missing field matches only negative operators.
+		return c.op == "!=" || c.op == "!~"
+	}
+	actual := logArgValueString(raw)
+	switch c.op {
+	case "=":
+		return actual == c.value
+	case "!=":
+		return actual != c.value
+	case "~":
+		return c.re.MatchString(actual)
+	case "!~":
+		return !c.re.MatchString(actual)
+	}
+	return compareLogQueryOrdered(c, actual)
+}
+
+// compareLogQueryOrdered applies >, >=, <, <=: by severity for level, numerically when both
+// sides are numbers, else lexically (which orders ISO timestamps correctly).
+func compareLogQueryOrdered(c logQueryCompare, actual string) bool {
+	var diff int
+	switch {
+	case c.field == "level":
+		rank := LogLevelRank(strings.ToLower(actual))
+		if rank < 0 {
+			return false
+		}
+		diff = rank - c.rank
+	default:
+		a, errA := strconv.ParseFloat(actual, 64)
+		b, errB := strconv.ParseFloat(c.value, 64)
+		if errA == nil && errB == nil {
+			switch {
+			case a < b:
+				diff = -1
+			case a > b:
+				diff = 1
+			}
+		} else {
+			diff = strings.Compare(actual, c.value)
+		}
+	}
+	switch c.op {
+	case ">":
+		return diff > 0
+	case ">=":
+		return diff >= 0
+	case "<":
+		return diff < 0
+	default:
+		return diff <= 0
+	}
+}
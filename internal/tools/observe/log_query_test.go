@@ -0,0 +1,73 @@
+// Purpose: Tests parsing and evaluation of observe log filter expressions.
+// Docs: docs/features/feature/log-query/index.md
+
+package observe
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseLogQuery_EvaluatesExpressions(t *testing.T) {
+	t.Parallel()
+	entry := map[string]any{
+		"level":   "warn",
+		"message": "ResizeObserver loop limit exceeded",
+		"url":     "https://shop.test/checkout",
+		"tabId":   7.0,
+		"ts":      "2026-05-01T09:00:02Z",
+		"args":    []any{"fetch failed", map[string]any{"requestId": "r-42", "status": 502.0}},
+	}
+
+	cases := []struct {
+		expr string
+		want bool
+	}{
+		{`level>=warn AND url~"checkout"`, true},
+		{`level>=warn AND url~"checkout" AND NOT message~"ResizeObserver"`, false},
+		{`level>error`, false},
+		{`level<=warn and level>log`, true},
+		{`message~'resizeobserver'`, true},
+		{`level=error OR (url~checkout AND tab_id=7)`, true},
+		{`NOT (level=warn OR level=error)`, false},
+		{`args[1].requestId=r-42 AND args[1].status>=500`, true},
+		{`args[1].status<500`, false},
+		{`ts>="2026-05-01T09:00:00Z" AND ts<"2026-05-01T09:00:03Z"`, true},
+		{`source!=extension AND missing!~x`, true},
+		{`source=""`, false},
+		{`message=="ResizeObserver loop limit exceeded"`, true},
+	}
+	for _, tc := range cases {
+		q, err := parseLogQuery(tc.expr)
+		if err != nil {
+			t.Fatalf("parseLogQuery(%q) error = %v", tc.expr, err)
+		}
+		if got := q.match(entry); got != tc.want {
+			t.Errorf("parseLogQuery(%q).match = %v, want %v", tc.expr, got, tc.want)
+		}
+	}
+
+	if q, err := parseLogQuery("  "); err != nil || q != nil || !q.match(entry) {
+		t.Fatalf("empty filter = %v, %v; want nil query that matches everything", q, err)
+	}
+}
+
+func TestParseLogQuery_RejectsMalformedExpressions(t *testing.T) {
+	t.Parallel()
+	cases := map[string]string{
+		`level>=warn AND`:       "unexpected end",
+		`level>=loud`:           "unknown level",
+		`(level=warn`:           "missing ')'",
+		`message~"unterminated`: "unterminated string",
+		`message~"(["`:          "invalid regex",
+		`level warn`:            "expected an operator",
+		`level=warn url~x`:      "unexpected",
+		`!level=warn`:           "use != or !~, or NOT",
+		strings.Repeat("(", 40) + "level=warn" + strings.Repeat(")", 40): "nests deeper",
+	}
+	for expr, want := range cases {
+		if _, err := parseLogQuery(expr); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("parseLogQuery(%q) error = %v, want containing %q", expr, err, want)
+		}
+	}
+}
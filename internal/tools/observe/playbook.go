@@ -0,0 +1,42 @@
+// Purpose: Implements observe(what="playbook") — remediation playbook lookup by finding ID.
+// Why: Findings carry a finding_id; this resolves it to fix steps, snippets, and the verification call.
+// Docs: docs/features/feature/remediation-playbooks/index.md
+
+package observe
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/mcp"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/remediation"
+)
+
+// GetPlaybook returns the remediation playbook for finding_id, or the playbook index when it is omitted.
+func GetPlaybook(_ Deps, req mcp.JSONRPCRequest, args json.RawMessage) mcp.JSONRPCResponse {
+	var params struct {
+		FindingID string `json:"finding_id"`
+	}
+	mcp.LenientUnmarshal(args, &params)
+
+	if params.FindingID == "" {
+		index := remediation.Index()
+		return mcp.Succeed(req, fmt.Sprintf("%d remediation playbooks", len(index)), map[string]any{
+			"playbooks": index,
+			"count":     len(index),
+			"hint":      `Findings from analyze security_audit, observe cookie_audit, analyze accessibility, and observe vitals carry a finding_id; pass it as observe(what="playbook", finding_id=...)`,
+		})
+	}
+
+	playbook, ok := remediation.Lookup(params.FindingID)
+	if !ok {
+		return mcp.Fail(req, mcp.ErrInvalidParam, "Unknown finding_id: "+params.FindingID,
+			`Use an ID from a finding's finding_id field, or call observe(what="playbook") without finding_id to list them`,
+			mcp.WithParam("finding_id"))
+	}
+	resp := map[string]any{"playbook": playbook}
+	if playbook.Generic {
+		resp["hint"] = "No dedicated playbook for this finding; returned the " + playbook.Category + " fallback"
+	}
+	return mcp.Succeed(req, "Remediation playbook: "+playbook.Title, resp)
+}
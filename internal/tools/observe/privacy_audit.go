@@ -0,0 +1,49 @@
+// Purpose: Implements observe(what="privacy_audit") — vendor-grouped inventory of beacons, pixels, and fingerprinting APIs, with consent correlation.
+// Why: Gives privacy reviews one view of which vendors received which data, built from passively captured traffic.
+// Docs: docs/features/feature/privacy-audit/index.md
+
+package observe
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/analysis"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/mcp"
+)
+
+// GetPrivacyAudit builds the privacy inventory for the tracked page's captured session.
+func GetPrivacyAudit(deps Deps, req mcp.JSONRPCRequest, args json.RawMessage) mcp.JSONRPCResponse {
+	var params analysis.PrivacyAuditParams
+	mcp.LenientUnmarshal(args, &params)
+
+	cap := deps.GetCapture()
+	_, _, tabURL := cap.GetTrackingStatus()
+	var pageURLs []string
+	if tabURL != "" {
+		pageURLs = []string{tabURL}
+	}
+
+	report := analysis.BuildPrivacyAudit(cap.GetNetworkBodies(), cap.GetNetworkWaterfallEntries(), cap.GetAllEnhancedActions(), pageURLs, params)
+	response := map[string]any{
+		"first_party_origin": report.FirstPartyOrigin,
+		"vendors":            report.Vendors,
+		"fingerprinting":     report.Fingerprinting,
+		"summary":            report.Summary,
+		"recommendations":    report.Recommendations,
+		"metadata":           BuildResponseMetadata(cap, time.Now()),
+	}
+	if report.Consent != nil {
+		response["consent"] = report.Consent
+	}
+	if report.Summary.ScriptsScanned == 0 {
+		response["hint"] = "No script bodies captured, so fingerprinting API usage was not scanned. Reload the tracked page with network body capture on, then call observe(what='privacy_audit') again."
+	}
+	summary := fmt.Sprintf("Privacy audit: %d vendor(s), %d beacon(s), %d pixel(s), %d fingerprinting script(s)",
+		report.Summary.Vendors, report.Summary.Beacons, report.Summary.Pixels, report.Summary.FingerprintingScripts)
+	if report.Consent != nil {
+		summary += fmt.Sprintf(", consent %s with %d pre-consent violation(s)", report.Consent.State, report.Consent.ViolationCount)
+	}
+	return mcp.Succeed(req, summary, response)
+}
@@ -0,0 +1,137 @@
+// Purpose: Handles observe(what:"seo") mode: reads the page's head metadata, structured data, and internal links, and reports SEO problems.
+// Why: Search problems (noindex left on, duplicate titles, broken JSON-LD) are invisible in the rendered page and easy to ship.
+// Docs: docs/features/feature/seo-audit/index.md
+
+package observe
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/mcp"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/queries"
+)
+
+const seoScanTimeout = 10 * time.Second
+
+// seoRobotsMeta is one robots meta tag (robots, googlebot, bingbot).
+type seoRobotsMeta struct {
+	Name    string `json:"name"`
+	Content string `json:"content"`
+}
+
+// seoJSONLD is the raw text of one application/ld+json script.
+type seoJSONLD struct {
+	Text      string `json:"text"`
+	Truncated bool   `json:"truncated,omitempty"`
+}
+
+// seoLink is one same-origin anchor, resolved and without its fragment.
+type seoLink struct {
+	URL  string `json:"url"`
+	Text string `json:"text,omitempty"`
+}
+
+// seoProbe is the raw head and link data collected by the extension's seo_scan query.
+type seoProbe struct {
+	URL            string          `json:"url"`
+	Titles         []string        `json:"titles"`
+	Descriptions   []string        `json:"descriptions"`
+	Canonicals     []string        `json:"canonicals"`
+	Robots         []seoRobotsMeta `json:"robots"`
+	JSONLD         []seoJSONLD     `json:"json_ld"`
+	Links          []seoLink       `json:"links"`
+	LinkCount      int             `json:"link_count"`
+	LinksTruncated bool            `json:"links_truncated"`
+	Error          string          `json:"error"`
+	Message        string          `json:"message"`
+}
+
+// GetSEO checks the tracked page's title, meta description, canonical URL, robots
+// directives, and JSON-LD, and reports same-origin links that failed in captured traffic.
+func GetSEO(deps Deps, req mcp.JSONRPCRequest, args json.RawMessage) mcp.JSONRPCResponse {
+	var params struct {
+		TabID int `json:"tab_id"`
+	}
+	if len(args) > 0 {
+		if err := json.Unmarshal(args, &params); err != nil {
+			return mcp.JSONRPCResponse{JSONRPC: "2.0", ID: req.ID, Result: mcp.StructuredErrorResponse(
+				mcp.ErrInvalidJSON, "Invalid JSON arguments: "+err.Error(), "Fix JSON syntax and call again",
+			)}
+		}
+	}
+
+	cap := deps.GetCapture()
+	enabled, _, _ := cap.GetTrackingStatus()
+	if !enabled && params.TabID == 0 {
+		return mcp.JSONRPCResponse{JSONRPC: "2.0", ID: req.ID, Result: mcp.StructuredErrorResponse(
+			mcp.ErrNoData,
+			"No tab is being tracked. Open the Kaboom extension popup and click 'Track This Tab'.",
+			"Track a tab first, then call observe with what='seo'.",
+			mcp.WithHint(deps.DiagnosticHintString()),
+		)}
+	}
+
+	queryID, qerr := cap.CreatePendingQueryWithTimeout(
+		queries.PendingQuery{
+			Type:   "seo_scan",
+			Params: json.RawMessage(`{}`),
+			TabID:  params.TabID,
+		},
+		seoScanTimeout,
+		"",
+	)
+	if qerr != nil {
+		return mcp.JSONRPCResponse{JSONRPC: "2.0", ID: req.ID, Result: mcp.StructuredErrorResponse(
+			mcp.ErrQueueFull,
+			"Command queue full: "+qerr.Error(),
+			"Wait for in-flight commands to complete, then retry.",
+			mcp.WithRecoveryToolCall(map[string]any{"tool": "observe", "arguments": map[string]any{"what": "pending_commands"}}),
+		)}
+	}
+
+	result, err := cap.WaitForResult(queryID, seoScanTimeout)
+	if err != nil {
+		code, opts := mcp.ExtensionWaitFailure(err)
+		return mcp.JSONRPCResponse{JSONRPC: "2.0", ID: req.ID, Result: mcp.StructuredErrorResponse(
+			code,
+			"SEO scan timeout: "+err.Error(),
+			"Ensure the extension is connected and the page has loaded.",
+			append(opts, mcp.WithHint(deps.DiagnosticHintString()))...,
+		)}
+	}
+
+	var probe seoProbe
+	if err := json.Unmarshal(result, &probe); err != nil {
+		return mcp.JSONRPCResponse{JSONRPC: "2.0", ID: req.ID, Result: mcp.StructuredErrorResponse(
+			mcp.ErrInvalidJSON,
+			"Failed to parse SEO scan result: "+err.Error(),
+			"Check extension logs for errors",
+		)}
+	}
+	if probe.Error != "" {
+		detail := probe.Message
+		if detail == "" {
+			detail = probe.Error
+		}
+		return mcp.JSONRPCResponse{JSONRPC: "2.0", ID: req.ID, Result: mcp.StructuredErrorResponse(
+			mcp.ErrExtError,
+			"SEO scan failed: "+detail,
+			"Check that the tab is a regular web page that allows script injection.",
+		)}
+	}
+
+	report := buildSEOReport(probe, cap.GetNetworkBodies())
+	response := map[string]any{
+		"page":     report.Page,
+		"summary":  report.Summary,
+		"count":    len(report.Findings),
+		"findings": report.Findings,
+		"metadata": BuildResponseMetadata(cap, time.Now()),
+	}
+	if probe.LinksTruncated {
+		response["hint"] = fmt.Sprintf("Only the first %d of %d internal links were matched against network traffic.", len(probe.Links), probe.LinkCount)
+	}
+	return mcp.Succeed(req, fmt.Sprintf("SEO: %d findings (%d high) on %s", len(report.Findings), report.Summary[seoHigh], probe.URL), response)
+}
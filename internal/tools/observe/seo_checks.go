@@ -0,0 +1,399 @@
+// Purpose: Classifies the seo_scan probe and captured network traffic into SEO findings with severity and fix hints.
+// Docs: docs/features/feature/seo-audit/index.md
+
+package observe
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+)
+
+// SEO finding severities, most severe first.
+const (
+	seoHigh   = "high"
+	seoMedium = "medium"
+	seoLow    = "low"
+	seoInfo   = "info"
+)
+
+var seoSeverityRank = map[string]int{seoHigh: 0, seoMedium: 1, seoLow: 2, seoInfo: 3}
+
+// Lengths search engines display without truncation.
+const (
+	seoTitleMin       = 10
+	seoTitleMax       = 60
+	seoDescriptionMin = 50
+	seoDescriptionMax = 160
+)
+
+// seoFinding is one SEO problem with a fix hint.
+type seoFinding struct {
+	Check    string `json:"check"`
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+	Fix      string `json:"fix"`
+	Value    string `json:"value,omitempty"`
+	URL      string `json:"url,omitempty"`
+	Status   int    `json:"status,omitempty"`
+}
+
+// seoPage summarizes what search engines see for the page.
+type seoPage struct {
+	URL            string   `json:"url"`
+	Title          string   `json:"title"`
+	Description    string   `json:"description"`
+	Canonical      string   `json:"canonical,omitempty"`
+	Robots         []string `json:"robots,omitempty"`
+	StructuredData int      `json:"structured_data_blocks"`
+	InternalLinks  int      `json:"internal_links"`
+}
+
+type seoReport struct {
+	Page     seoPage
+	Summary  map[string]int
+	Findings []seoFinding
+}
+
+// buildSEOReport runs every check against the probe and the captured network bodies.
+func buildSEOReport(probe seoProbe, bodies []capture.NetworkBody) seoReport {
+	page, _ := url.Parse(probe.URL)
+	report := seoReport{
+		Page:    seoPage{URL: probe.URL, StructuredData: len(probe.JSONLD), InternalLinks: probe.LinkCount},
+		Summary: map[string]int{seoHigh: 0, seoMedium: 0, seoLow: 0, seoInfo: 0},
+	}
+	if len(probe.Titles) > 0 {
+		report.Page.Title = probe.Titles[0]
+	}
+	if len(probe.Descriptions) > 0 {
+		report.Page.Description = probe.Descriptions[0]
+	}
+	if len(probe.Canonicals) > 0 {
+		report.Page.Canonical = resolveSEOURL(page, probe.Canonicals[0])
+	}
+
+	var findings []seoFinding
+	findings = append(findings, checkSEOText("title", "<title>", probe.Titles, seoTitleMin, seoTitleMax, seoHigh)...)
+	findings = append(findings, checkSEOText("description", `<meta name="description">`, probe.Descriptions, seoDescriptionMin, seoDescriptionMax, seoMedium)...)
+	if report.Page.Title != "" && report.Page.Title == report.Page.Description {
+		findings = append(findings, seoFinding{Check: "description_same_as_title", Severity: seoLow, Value: report.Page.Description,
+			Message: "The meta description repeats the title",
+			Fix:     "Write a description that summarizes the page content in one or two sentences"})
+	}
+	findings = append(findings, checkSEOCanonical(page, probe.Canonicals, bodies)...)
+	robots, robotsFindings := checkSEORobots(probe.Robots, pageHeader(page, bodies, "x-robots-tag"))
+	report.Page.Robots = robots
+	findings = append(findings, robotsFindings...)
+	findings = append(findings, checkSEOStructuredData(probe.JSONLD)...)
+	findings = append(findings, checkSEOBrokenLinks(page, probe.Links, bodies)...)
+
+	slices.SortStableFunc(findings, func(a, b seoFinding) int { return seoSeverityRank[a.Severity] - seoSeverityRank[b.Severity] })
+	for _, f := range findings {
+		report.Summary[f.Severity]++
+	}
+	if findings == nil {
+		findings = []seoFinding{}
+	}
+	report.Findings = findings
+	return report
+}
+
+// checkSEOText checks that exactly one non-empty value exists and that its length is in range.
+func checkSEOText(check, element string, values []string, minLen, maxLen int, missingSeverity string) []seoFinding {
+	var nonEmpty []string
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			nonEmpty = append(nonEmpty, v)
+		}
+	}
+	switch {
+	case len(nonEmpty) == 0:
+		return []seoFinding{{Check: check + "_missing", Severity: missingSeverity,
+			Message: "The page has no " + element + " or it is empty",
+			Fix:     fmt.Sprintf("Add one %s of %d-%d characters that describes this page", element, minLen, maxLen)}}
+	case len(values) > 1:
+		return []seoFinding{{Check: check + "_duplicate", Severity: seoMedium, Value: strings.Join(values, " | "),
+			Message: fmt.Sprintf("The page has %d %s elements; search engines pick one unpredictably", len(values), element),
+			Fix:     "Keep a single " + element + "; check that the layout and the page component do not both render one"}}
+	}
+	n := utf8.RuneCountInString(nonEmpty[0])
+	switch {
+	case n < minLen:
+		return []seoFinding{{Check: check + "_too_short", Severity: seoLow, Value: nonEmpty[0],
+			Message: fmt.Sprintf("The %s is %d characters", element, n),
+			Fix:     fmt.Sprintf("Use %d-%d characters so it describes the page", minLen, maxLen)}}
+	case n > maxLen:
+		return []seoFinding{{Check: check + "_too_long", Severity: seoLow, Value: nonEmpty[0],
+			Message: fmt.Sprintf("The %s is %d characters and will be truncated in results", element, n),
+			Fix:     fmt.Sprintf("Shorten it to at most %d characters, keeping the key terms first", maxLen)}}
+	}
+	return nil
+}
+
+func checkSEOCanonical(page *url.URL, canonicals []string, bodies []capture.NetworkBody) []seoFinding {
+	switch len(canonicals) {
+	case 0:
+		return []seoFinding{{Check: "canonical_missing", Severity: seoLow,
+			Message: "The page has no canonical URL, so duplicates (query strings, trailing slashes) compete with it",
+			Fix:     `Add <link rel="canonical" href="..."> with the preferred absolute URL`}}
+	case 1:
+	default:
+		return []seoFinding{{Check: "canonical_multiple", Severity: seoHigh, Value: strings.Join(canonicals, " | "),
+			Message: fmt.Sprintf("The page declares %d canonical URLs; search engines ignore all of them", len(canonicals)),
+			Fix:     "Render exactly one canonical link"}}
+	}
+
+	raw := strings.TrimSpace(canonicals[0])
+	target, err := url.Parse(raw)
+	if raw == "" || err != nil {
+		return []seoFinding{{Check: "canonical_invalid", Severity: seoHigh, Value: raw,
+			Message: "The canonical href is empty or not a URL",
+			Fix:     "Set the canonical href to the preferred absolute URL"}}
+	}
+	var findings []seoFinding
+	if !target.IsAbs() {
+		findings = append(findings, seoFinding{Check: "canonical_relative", Severity: seoLow, Value: raw,
+			Message: "The canonical URL is relative",
+			Fix:     "Use an absolute URL including scheme and host"})
+	}
+	if page == nil {
+		return findings
+	}
+	target = page.ResolveReference(target)
+	switch {
+	case page.Scheme == "https" && target.Scheme == "http":
+		findings = append(findings, seoFinding{Check: "canonical_insecure", Severity: seoMedium, URL: target.String(),
+			Message: "An https page declares an http canonical",
+			Fix:     "Point the canonical at the https URL"})
+	case !strings.EqualFold(target.Host, page.Host):
+		findings = append(findings, seoFinding{Check: "canonical_cross_domain", Severity: seoMedium, URL: target.String(),
+			Message: "The canonical points to another host, so this page will not be indexed",
+			Fix:     "Keep it only if " + target.Host + " is the intended primary copy; otherwise point it at this host"})
+	case seoComparable(target) != seoComparable(page):
+		findings = append(findings, seoFinding{Check: "canonical_points_elsewhere", Severity: seoInfo, URL: target.String(),
+			Message: "The canonical names a different URL; this page is treated as a duplicate of it",
+			Fix:     "Expected for filtered or paginated variants; otherwise make the canonical self-referencing"})
+	}
+	if status := seoObservedStatus(target, bodies); status >= 300 {
+		severity, check, fix := seoHigh, "canonical_broken", "Point the canonical at a URL that returns 200"
+		if status < 400 {
+			severity, check, fix = seoMedium, "canonical_redirects", "Point the canonical at the final URL instead of one that redirects"
+		}
+		findings = append(findings, seoFinding{Check: check, Severity: severity, URL: target.String(), Status: status,
+			Message: fmt.Sprintf("The canonical URL returned HTTP %d in captured traffic", status), Fix: fix})
+	}
+	return findings
+}
+
+// checkSEORobots reports indexing directives from robots meta tags and the X-Robots-Tag header.
+func checkSEORobots(metas []seoRobotsMeta, header string) ([]string, []seoFinding) {
+	type source struct{ name, content string }
+	var sources []source
+	for _, m := range metas {
+		sources = append(sources, source{`<meta name="` + strings.ToLower(m.Name) + `">`, m.Content})
+	}
+	if header != "" {
+		sources = append(sources, source{"X-Robots-Tag header", header})
+	}
+
+	var directives []string
+	var findings []seoFinding
+	for _, s := range sources {
+		for _, d := range strings.Split(strings.ToLower(s.content), ",") {
+			d = strings.TrimSpace(d)
+			// Header values may be scoped to a crawler: "googlebot: noindex".
+			if agent, rest, ok := strings.Cut(d, ":"); ok && strings.HasSuffix(strings.TrimSpace(agent), "bot") {
+				d = strings.TrimSpace(rest)
+			}
+			if d == "" || slices.Contains(directives, d) {
+				continue
+			}
+			directives = append(directives, d)
+			switch {
+			case d == "noindex" || d == "none":
+				findings = append(findings, seoFinding{Check: "robots_noindex", Severity: seoHigh, Value: d,
+					Message: s.name + " tells search engines not to index this page",
+					Fix:     "Remove " + d + " if this page should appear in search results (it is often left over from staging)"})
+			case d == "nofollow":
+				findings = append(findings, seoFinding{Check: "robots_nofollow", Severity: seoMedium, Value: d,
+					Message: s.name + " tells search engines not to follow links on this page",
+					Fix:     "Remove nofollow unless the page's links should pass no ranking signal"})
+			case d == "nosnippet" || d == "max-snippet:0":
+				findings = append(findings, seoFinding{Check: "robots_nosnippet", Severity: seoLow, Value: d,
+					Message: s.name + " hides the text snippet in search results",
+					Fix:     "Remove it unless the snippet must be suppressed"})
+			}
+		}
+	}
+	return directives, findings
+}
+
+// checkSEOStructuredData parses each JSON-LD block and checks for @context and @type.
+func checkSEOStructuredData(blocks []seoJSONLD) []seoFinding {
+	var findings []seoFinding
+	for i, block := range blocks {
+		label := fmt.Sprintf("JSON-LD block %d", i+1)
+		if block.Truncated {
+			findings = append(findings, seoFinding{Check: "structured_data_unchecked", Severity: seoInfo,
+				Message: label + " is too large to check", Fix: "Validate it with a structured-data testing tool"})
+			continue
+		}
+		var doc any
+		if err := json.Unmarshal([]byte(block.Text), &doc); err != nil {
+			findings = append(findings, seoFinding{Check: "structured_data_parse_error", Severity: seoHigh, Value: seoSnippet(block.Text),
+				Message: label + " is not valid JSON: " + seoJSONError(block.Text, err),
+				Fix:     "Fix the JSON; search engines ignore the whole block. Common causes are trailing commas, unescaped quotes, and template placeholders"})
+			continue
+		}
+		items := []any{doc}
+		if list, ok := doc.([]any); ok {
+			items = list
+		}
+		for _, item := range items {
+			obj, ok := item.(map[string]any)
+			if !ok {
+				continue
+			}
+			if _, ok := obj["@context"]; !ok {
+				findings = append(findings, seoFinding{Check: "structured_data_missing_context", Severity: seoMedium,
+					Message: label + ` has no "@context"`, Fix: `Add "@context": "https://schema.org"`})
+			}
+			_, hasType := obj["@type"]
+			_, hasGraph := obj["@graph"]
+			if !hasType && !hasGraph {
+				findings = append(findings, seoFinding{Check: "structured_data_missing_type", Severity: seoMedium,
+					Message: label + ` has no "@type"`, Fix: `Add the schema.org type, for example "@type": "Product"`})
+			}
+		}
+	}
+	return findings
+}
+
+// checkSEOBrokenLinks reports same-host pages that failed in captured traffic. Failures of
+// requests the page links to are high severity; other failed same-host HTML is medium.
+func checkSEOBrokenLinks(page *url.URL, links []seoLink, bodies []capture.NetworkBody) []seoFinding {
+	if page == nil {
+		return nil
+	}
+	linked := map[string]string{}
+	for _, l := range links {
+		if u, err := url.Parse(l.URL); err == nil {
+			linked[seoComparable(u)] = l.Text
+		}
+	}
+	type failure struct {
+		url    string
+		status int
+	}
+	var order []string
+	failed := map[string]failure{}
+	for _, b := range bodies {
+		if b.Status < 400 || (b.Method != "" && !strings.EqualFold(b.Method, "GET")) {
+			continue
+		}
+		u, err := url.Parse(b.URL)
+		if err != nil || !strings.EqualFold(u.Host, page.Host) {
+			continue
+		}
+		key := seoComparable(u)
+		if _, isLink := linked[key]; !isLink && !strings.Contains(strings.ToLower(b.ContentType), "html") {
+			continue
+		}
+		if _, seen := failed[key]; !seen {
+			order = append(order, key)
+		}
+		failed[key] = failure{url: b.URL, status: b.Status}
+	}
+
+	var findings []seoFinding
+	for _, key := range order {
+		f := failed[key]
+		if text, isLink := linked[key]; isLink {
+			message := fmt.Sprintf("A link on this page returned HTTP %d", f.status)
+			if text != "" {
+				message = fmt.Sprintf("The link %q returned HTTP %d", text, f.status)
+			}
+			findings = append(findings, seoFinding{Check: "broken_internal_link", Severity: seoHigh, URL: f.url, Status: f.status,
+				Message: message, Fix: "Update the href to a live page, or redirect the old URL with a 301"})
+			continue
+		}
+		findings = append(findings, seoFinding{Check: "broken_internal_page", Severity: seoMedium, URL: f.url, Status: f.status,
+			Message: fmt.Sprintf("A same-site page returned HTTP %d in captured traffic", f.status),
+			Fix:     "Find what links to it and fix the link, or redirect the URL with a 301"})
+	}
+	return findings
+}
+
+// pageHeader returns a response header from the captured document request for page.
+func pageHeader(page *url.URL, bodies []capture.NetworkBody, name string) string {
+	if page == nil {
+		return ""
+	}
+	key := seoComparable(page)
+	for i := len(bodies) - 1; i >= 0; i-- {
+		u, err := url.Parse(bodies[i].URL)
+		if err != nil || seoComparable(u) != key {
+			continue
+		}
+		for k, v := range bodies[i].ResponseHeaders {
+			if strings.EqualFold(k, name) {
+				return v
+			}
+		}
+	}
+	return ""
+}
+
+// seoObservedStatus returns the latest captured status for target, or 0.
+func seoObservedStatus(target *url.URL, bodies []capture.NetworkBody) int {
+	key := seoComparable(target)
+	for i := len(bodies) - 1; i >= 0; i-- {
+		if u, err := url.Parse(bodies[i].URL); err == nil && seoComparable(u) == key {
+			return bodies[i].Status
+		}
+	}
+	return 0
+}
+
+// seoComparable normalizes a URL for comparison: no fragment, lowercase host, no trailing slash.
+func seoComparable(u *url.URL) string {
+	c := *u
+	c.Fragment = ""
+	c.Host = strings.ToLower(c.Host)
+	c.Path = strings.TrimSuffix(c.Path, "/")
+	return c.String()
+}
+
+func resolveSEOURL(page *url.URL, raw string) string {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil || page == nil {
+		return raw
+	}
+	return page.ResolveReference(u).String()
+}
+
+// seoJSONError adds the line and column to JSON syntax errors.
+func seoJSONError(text string, err error) string {
+	var syntax *json.SyntaxError
+	if !errors.As(err, &syntax) {
+		return err.Error()
+	}
+	before := text[:min(int(syntax.Offset), len(text))]
+	line := strings.Count(before, "\n") + 1
+	col := utf8.RuneCountInString(before[strings.LastIndex(before, "\n")+1:])
+	return fmt.Sprintf("%s at line %d, column %d", syntax.Error(), line, col)
+}
+
+func seoSnippet(text string) string {
+	text = strings.Join(strings.Fields(text), " ")
+	if r := []rune(text); len(r) > 120 {
+		return string(r[:120]) + "..."
+	}
+	return text
+}
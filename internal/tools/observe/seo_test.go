@@ -0,0 +1,119 @@
+// seo_test.go — Tests for observe(what="seo") checks over the page probe and captured traffic.
+package observe
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+)
+
+func seoChecks(report seoReport) map[string]seoFinding {
+	byCheck := map[string]seoFinding{}
+	for _, f := range report.Findings {
+		byCheck[f.Check] = f
+	}
+	return byCheck
+}
+
+func TestBuildSEOReport_HealthyPage(t *testing.T) {
+	t.Parallel()
+	report := buildSEOReport(seoProbe{
+		URL:          "https://shop.test/shoes/",
+		Titles:       []string{"Running shoes for every distance | Shop"},
+		Descriptions: []string{"Road, trail, and track running shoes with free returns and a 60-day comfort guarantee."},
+		Canonicals:   []string{"https://shop.test/shoes"},
+		Robots:       []seoRobotsMeta{{Name: "robots", Content: "index, follow"}},
+		JSONLD:       []seoJSONLD{{Text: `{"@context":"https://schema.org","@type":"ItemList"}`}},
+		LinkCount:    12,
+	}, nil)
+	if len(report.Findings) != 0 {
+		t.Fatalf("findings = %+v, want none", report.Findings)
+	}
+	if report.Page.Canonical != "https://shop.test/shoes" || report.Page.StructuredData != 1 || len(report.Page.Robots) != 2 {
+		t.Errorf("page = %+v", report.Page)
+	}
+}
+
+func TestBuildSEOReport_FlagsProblems(t *testing.T) {
+	t.Parallel()
+	bodies := []capture.NetworkBody{
+		{Method: "GET", URL: "https://shop.test/sale", Status: 200, ResponseHeaders: map[string]string{"X-Robots-Tag": "googlebot: nofollow"}},
+		{Method: "GET", URL: "https://shop.test/old-deals", Status: 404, ContentType: "text/html"},
+		{Method: "GET", URL: "https://shop.test/gone", Status: 410, ContentType: "text/html"},
+		{Method: "GET", URL: "https://shop.test/api/cart", Status: 500, ContentType: "application/json"},
+		{Method: "GET", URL: "https://cdn.other.test/missing.html", Status: 404, ContentType: "text/html"},
+	}
+	report := buildSEOReport(seoProbe{
+		URL:          "https://shop.test/sale",
+		Titles:       []string{"Sale", "Shop"},
+		Descriptions: nil,
+		Canonicals:   []string{"http://shop.test/sale"},
+		Robots:       []seoRobotsMeta{{Name: "robots", Content: "noindex"}},
+		JSONLD:       []seoJSONLD{{Text: "{\n  \"@context\": \"https://schema.org\",\n  \"@type\": \"Offer\",\n}"}, {Text: `{"name":"x"}`}},
+		Links:        []seoLink{{URL: "https://shop.test/old-deals", Text: "Old deals"}},
+		LinkCount:    1,
+	}, bodies)
+
+	checks := seoChecks(report)
+	for check, severity := range map[string]string{
+		"title_duplicate":                 seoMedium,
+		"description_missing":             seoMedium,
+		"canonical_insecure":              seoMedium,
+		"robots_noindex":                  seoHigh,
+		"robots_nofollow":                 seoMedium,
+		"structured_data_parse_error":     seoHigh,
+		"structured_data_missing_context": seoMedium,
+		"structured_data_missing_type":    seoMedium,
+		"broken_internal_link":            seoHigh,
+		"broken_internal_page":            seoMedium,
+	} {
+		if f, ok := checks[check]; !ok || f.Severity != severity {
+			t.Errorf("%s = %+v, want severity %s", check, f, severity)
+		}
+	}
+	if f := checks["structured_data_parse_error"]; !strings.Contains(f.Message, "line 4") {
+		t.Errorf("parse error should carry a position: %q", f.Message)
+	}
+	if f := checks["broken_internal_link"]; f.URL != "https://shop.test/old-deals" || f.Status != 404 || !strings.Contains(f.Message, "Old deals") {
+		t.Errorf("broken link = %+v", f)
+	}
+	if f := checks["broken_internal_page"]; f.URL != "https://shop.test/gone" {
+		t.Errorf("broken page = %+v, want the unlinked 410 only (not the API call or other host)", f)
+	}
+	if report.Findings[0].Severity != seoHigh || report.Summary[seoHigh] != 3 {
+		t.Errorf("findings not sorted by severity or summary wrong: %v", report.Summary)
+	}
+}
+
+func TestCheckSEOCanonical(t *testing.T) {
+	t.Parallel()
+	page, _ := url.Parse("https://shop.test/shoes?color=red")
+	cases := map[string]struct {
+		canonicals []string
+		bodies     []capture.NetworkBody
+		want       string
+	}{
+		"missing":     {nil, nil, "canonical_missing"},
+		"multiple":    {[]string{"/a", "/b"}, nil, "canonical_multiple"},
+		"relative":    {[]string{"/shoes?color=red"}, nil, "canonical_relative"},
+		"cross host":  {[]string{"https://partner.test/shoes"}, nil, "canonical_cross_domain"},
+		"elsewhere":   {[]string{"https://shop.test/shoes"}, nil, "canonical_points_elsewhere"},
+		"broken":      {[]string{"https://shop.test/shoes?color=red"}, []capture.NetworkBody{{URL: "https://shop.test/shoes?color=red", Status: 404}}, "canonical_broken"},
+		"redirecting": {[]string{"https://shop.test/shoes?color=red"}, []capture.NetworkBody{{URL: "https://shop.test/shoes?color=red", Status: 301}}, "canonical_redirects"},
+	}
+	for name, tc := range cases {
+		findings := checkSEOCanonical(page, tc.canonicals, tc.bodies)
+		found := false
+		for _, f := range findings {
+			found = found || f.Check == tc.want
+		}
+		if !found {
+			t.Errorf("%s: findings = %+v, want %s", name, findings, tc.want)
+		}
+	}
+	if got := checkSEOCanonical(page, []string{"https://shop.test/shoes/?color=red#top"}, nil); len(got) != 0 {
+		t.Errorf("self-referencing canonical = %+v, want none", got)
+	}
+}
@@ -0,0 +1,98 @@
+// Purpose: Implements observe(what="session_compare") over session bundles referenced by path, label, or "current".
+// Why: Side-by-side comparison of two captured sessions surfaces what differs between environments.
+// Docs: docs/features/feature/read-only-mode/index.md
+
+package observe
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/mcp"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/sessionbundle"
+)
+
+// sessionCompareCurrent selects the live capture state instead of a stored bundle.
+const sessionCompareCurrent = "current"
+
+// defaultSessionCompareLimit caps divergences returned when limit is not set.
+const defaultSessionCompareLimit = 50
+
+// SessionCompare diffs two sessions and returns a ranked list of divergences.
+func SessionCompare(deps Deps, req mcp.JSONRPCRequest, args json.RawMessage) mcp.JSONRPCResponse {
+	var params struct {
+		A     string `json:"a"`
+		B     string `json:"b"`
+		Limit int    `json:"limit"`
+	}
+	mcp.LenientUnmarshal(args, &params)
+	if params.A == "" || params.B == "" {
+		return mcp.Fail(req, mcp.ErrMissingParam, "Both 'a' and 'b' are required",
+			"Pass a bundle path, a bundle label, or 'current' for each side. Create bundles with generate(what='session_bundle').",
+			mcp.WithParam("a"))
+	}
+
+	a, aRef, err := resolveSessionRef(deps, params.A)
+	if err != nil {
+		return mcp.Fail(req, mcp.ErrInvalidParam, "Cannot resolve session a: "+err.Error(),
+			"Use a bundle file path, a label from generate(what='session_bundle', label=...), or 'current'", mcp.WithParam("a"))
+	}
+	b, bRef, err := resolveSessionRef(deps, params.B)
+	if err != nil {
+		return mcp.Fail(req, mcp.ErrInvalidParam, "Cannot resolve session b: "+err.Error(),
+			"Use a bundle file path, a label from generate(what='session_bundle', label=...), or 'current'", mcp.WithParam("b"))
+	}
+
+	divergences := CompareSessions(a, b)
+	total := len(divergences)
+	limit := params.Limit
+	if limit <= 0 {
+		limit = defaultSessionCompareLimit
+	}
+	if len(divergences) > limit {
+		divergences = divergences[:limit]
+	}
+	byCategory := map[string]int{}
+	for _, d := range divergences {
+		byCategory[d.Category]++
+	}
+
+	return mcp.Succeed(req, fmt.Sprintf("Session compare: %d divergence(s)", total), map[string]any{
+		"a":                 sessionRefSummary(a, aRef),
+		"b":                 sessionRefSummary(b, bRef),
+		"total_divergences": total,
+		"by_category":       byCategory,
+		"divergences":       divergences,
+		"metadata":          BuildResponseMetadata(deps.GetCapture(), time.Now()),
+	})
+}
+
+// resolveSessionRef loads a session from "current", an existing path, or a bundle label
+// in the default bundle directory. Returns the bundle and the resolved source.
+func resolveSessionRef(deps Deps, ref string) (sessionbundle.Bundle, string, error) {
+	if ref == sessionCompareCurrent {
+		logs, _ := deps.GetLogEntries()
+		return sessionbundle.FromCapture(deps.GetCapture(), logs, sessionCompareCurrent, time.Now()), sessionCompareCurrent, nil
+	}
+	if _, err := os.Stat(ref); err == nil {
+		b, err := sessionbundle.Load(ref)
+		return b, ref, err
+	}
+	dir, err := sessionbundle.DefaultDir()
+	if err != nil {
+		return sessionbundle.Bundle{}, "", err
+	}
+	return sessionbundle.FindByLabel(dir, ref)
+}
+
+func sessionRefSummary(b sessionbundle.Bundle, source string) map[string]any {
+	return map[string]any{
+		"source":     source,
+		"label":      b.Label,
+		"created_at": b.CreatedAt,
+		"page_url":   b.PageURL,
+		"counts":     b.Counts(),
+	}
+}
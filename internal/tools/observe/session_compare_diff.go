@@ -0,0 +1,331 @@
+// Purpose: Computes ranked divergences between two session bundles across errors, network, vitals, and actions.
+// Why: "Works on my machine, fails in CI" is answered fastest by the few differences that matter, ranked first.
+// Docs: docs/features/feature/read-only-mode/index.md
+
+package observe
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/analysis"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/sessionbundle"
+)
+
+// Divergence categories.
+const (
+	divergenceErrors  = "errors"
+	divergenceNetwork = "network"
+	divergenceVitals  = "vitals"
+	divergenceActions = "actions"
+)
+
+// vitalsRegressionRatio is the relative change below which a vitals difference is treated as noise.
+const vitalsRegressionRatio = 0.2
+
+// SessionDivergence is one difference between session A and session B.
+// Score orders divergences; higher means more likely to explain a behavior difference.
+type SessionDivergence struct {
+	Category string  `json:"category"`
+	Kind     string  `json:"kind"`
+	Key      string  `json:"key"`
+	Summary  string  `json:"summary"`
+	A        any     `json:"a,omitempty"`
+	B        any     `json:"b,omitempty"`
+	Score    float64 `json:"score"`
+}
+
+// CompareSessions returns divergences between a and b, highest score first.
+func CompareSessions(a, b sessionbundle.Bundle) []SessionDivergence {
+	var out []SessionDivergence
+	out = append(out, compareErrorClusters(a, b)...)
+	out = append(out, compareNetwork(a.NetworkBodies, b.NetworkBodies)...)
+	out = append(out, compareVitals(a, b)...)
+	out = append(out, compareActions(a.Actions, b.Actions)...)
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].Score != out[j].Score {
+			return out[i].Score > out[j].Score
+		}
+		return out[i].Key < out[j].Key
+	})
+	return out
+}
+
+type sessionErrorCluster struct {
+	Message string `json:"message"`
+	Count   int    `json:"count"`
+}
+
+// sessionErrorClusters groups error-level page logs by message fingerprint.
+func sessionErrorClusters(b sessionbundle.Bundle) map[string]*sessionErrorCluster {
+	clusters := make(map[string]*sessionErrorCluster)
+	add := func(msg string) {
+		key := fingerprintMessage(msg)
+		if key == "" {
+			return
+		}
+		if c, ok := clusters[key]; ok {
+			c.Count++
+			return
+		}
+		clusters[key] = &sessionErrorCluster{Message: msg, Count: 1}
+	}
+	for _, entry := range b.Logs {
+		if level, _ := entry["level"].(string); level == "error" {
+			msg, _ := entry["message"].(string)
+			add(msg)
+		}
+	}
+	return clusters
+}
+
+func compareErrorClusters(a, b sessionbundle.Bundle) []SessionDivergence {
+	ca, cb := sessionErrorClusters(a), sessionErrorClusters(b)
+	var out []SessionDivergence
+	for key, ea := range ca {
+		eb, ok := cb[key]
+		switch {
+		case !ok:
+			out = append(out, SessionDivergence{
+				Category: divergenceErrors, Kind: "only_in_a", Key: key,
+				Summary: fmt.Sprintf("Error only in A (%dx): %s", ea.Count, truncateForSummary(ea.Message)),
+				A:       ea, Score: 50 + math.Min(float64(ea.Count), 40),
+			})
+		case ea.Count >= 2*eb.Count || eb.Count >= 2*ea.Count:
+			out = append(out, SessionDivergence{
+				Category: divergenceErrors, Kind: "count_changed", Key: key,
+				Summary: fmt.Sprintf("Error count %d → %d: %s", ea.Count, eb.Count, truncateForSummary(ea.Message)),
+				A:       ea, B: eb, Score: 20 + math.Min(math.Abs(float64(eb.Count-ea.Count)), 20),
+			})
+		}
+	}
+	for key, eb := range cb {
+		if _, ok := ca[key]; ok {
+			continue
+		}
+		out = append(out, SessionDivergence{
+			Category: divergenceErrors, Kind: "only_in_b", Key: key,
+			Summary: fmt.Sprintf("Error only in B (%dx): %s", eb.Count, truncateForSummary(eb.Message)),
+			B:       eb, Score: 50 + math.Min(float64(eb.Count), 40),
+		})
+	}
+	return out
+}
+
+type sessionEndpointStats struct {
+	Count          int         `json:"count"`
+	Statuses       map[int]int `json:"statuses"`
+	Failures       int         `json:"failures"`
+	MedianDuration int         `json:"median_duration_ms"`
+	durations      []int
+}
+
+// sessionEndpoints groups network bodies by host-independent endpoint (METHOD + normalized path),
+// so sessions recorded against different hosts (localhost vs CI) still line up.
+func sessionEndpoints(bodies []capture.NetworkBody) map[string]*sessionEndpointStats {
+	stats := make(map[string]*sessionEndpointStats)
+	for _, body := range bodies {
+		key := analysis.NormalizeEndpoint(strings.ToUpper(body.Method), body.URL)
+		s, ok := stats[key]
+		if !ok {
+			s = &sessionEndpointStats{Statuses: map[int]int{}}
+			stats[key] = s
+		}
+		s.Count++
+		s.Statuses[body.Status]++
+		if body.Status >= 400 || body.Status == 0 {
+			s.Failures++
+		}
+		if body.Duration > 0 {
+			s.durations = append(s.durations, body.Duration)
+		}
+	}
+	for _, s := range stats {
+		if len(s.durations) > 0 {
+			sort.Ints(s.durations)
+			s.MedianDuration = s.durations[len(s.durations)/2]
+		}
+	}
+	return stats
+}
+
+func compareNetwork(aBodies, bBodies []capture.NetworkBody) []SessionDivergence {
+	ea, eb := sessionEndpoints(aBodies), sessionEndpoints(bBodies)
+	var out []SessionDivergence
+	for key, sa := range ea {
+		sb, ok := eb[key]
+		if !ok {
+			out = append(out, SessionDivergence{
+				Category: divergenceNetwork, Kind: "only_in_a", Key: key,
+				Summary: fmt.Sprintf("%s called %dx only in A", key, sa.Count),
+				A:       sa, Score: 15 + float64(min(sa.Failures, 10)),
+			})
+			continue
+		}
+		aFails, bFails := sa.Failures > 0, sb.Failures > 0
+		if aFails != bFails {
+			out = append(out, SessionDivergence{
+				Category: divergenceNetwork, Kind: "status_changed", Key: key,
+				Summary: fmt.Sprintf("%s failures %d/%d in A vs %d/%d in B", key, sa.Failures, sa.Count, sb.Failures, sb.Count),
+				A:       sa, B: sb, Score: 60,
+			})
+		} else if !sameStatusClasses(sa.Statuses, sb.Statuses) {
+			out = append(out, SessionDivergence{
+				Category: divergenceNetwork, Kind: "status_changed", Key: key,
+				Summary: fmt.Sprintf("%s status codes differ", key),
+				A:       sa, B: sb, Score: 30,
+			})
+		}
+		if slower, faster := max(sa.MedianDuration, sb.MedianDuration), min(sa.MedianDuration, sb.MedianDuration); faster > 0 && slower >= 2*faster && slower-faster >= 200 {
+			out = append(out, SessionDivergence{
+				Category: divergenceNetwork, Kind: "latency_changed", Key: key,
+				Summary: fmt.Sprintf("%s median latency %dms in A vs %dms in B", key, sa.MedianDuration, sb.MedianDuration),
+				A:       sa.MedianDuration, B: sb.MedianDuration, Score: 25,
+			})
+		}
+	}
+	for key, sb := range eb {
+		if _, ok := ea[key]; ok {
+			continue
+		}
+		out = append(out, SessionDivergence{
+			Category: divergenceNetwork, Kind: "only_in_b", Key: key,
+			Summary: fmt.Sprintf("%s called %dx only in B", key, sb.Count),
+			B:       sb, Score: 15 + float64(min(sb.Failures, 10)),
+		})
+	}
+	return out
+}
+
+func sameStatusClasses(a, b map[int]int) bool {
+	classes := func(m map[int]int) map[int]bool {
+		out := make(map[int]bool, len(m))
+		for status := range m {
+			out[status/100] = true
+		}
+		return out
+	}
+	ca, cb := classes(a), classes(b)
+	if len(ca) != len(cb) {
+		return false
+	}
+	for c := range ca {
+		if !cb[c] {
+			return false
+		}
+	}
+	return true
+}
+
+// sessionVitals averages each timing metric across the bundle's performance snapshots.
+// Lower is better for every metric returned.
+func sessionVitals(b sessionbundle.Bundle) map[string]float64 {
+	sums := map[string]float64{}
+	counts := map[string]int{}
+	add := func(name string, v float64) {
+		if v > 0 {
+			sums[name] += v
+			counts[name]++
+		}
+	}
+	for _, snap := range b.Performance {
+		t := snap.Timing
+		add("ttfb", t.TimeToFirstByte)
+		add("dom_content_loaded", t.DomContentLoaded)
+		add("load", t.Load)
+		if t.FirstContentfulPaint != nil {
+			add("fcp", *t.FirstContentfulPaint)
+		}
+		if t.LargestContentfulPaint != nil {
+			add("lcp", *t.LargestContentfulPaint)
+		}
+		if t.InteractionToNextPaint != nil {
+			add("inp", *t.InteractionToNextPaint)
+		}
+		if snap.CLS != nil {
+			sums["cls"] += *snap.CLS
+			counts["cls"]++
+		}
+	}
+	avg := make(map[string]float64, len(sums))
+	for name, sum := range sums {
+		avg[name] = sum / float64(counts[name])
+	}
+	return avg
+}
+
+func compareVitals(a, b sessionbundle.Bundle) []SessionDivergence {
+	va, vb := sessionVitals(a), sessionVitals(b)
+	var out []SessionDivergence
+	for name, x := range va {
+		y, ok := vb[name]
+		if !ok || x == 0 {
+			continue
+		}
+		change := (y - x) / x
+		if math.Abs(change) < vitalsRegressionRatio {
+			continue
+		}
+		kind := "regressed_in_b"
+		if change < 0 {
+			kind = "regressed_in_a"
+		}
+		out = append(out, SessionDivergence{
+			Category: divergenceVitals, Kind: kind, Key: name,
+			Summary: fmt.Sprintf("%s %.2f in A vs %.2f in B (%+.0f%%)", name, x, y, change*100),
+			A:       x, B: y, Score: 15 + math.Min(math.Abs(change)*20, 25),
+		})
+	}
+	return out
+}
+
+// actionToken reduces an action to a host-independent step such as "click #submit".
+func actionToken(a capture.EnhancedAction) string {
+	target := ""
+	for _, key := range []string{"testId", "css", "text"} {
+		if v, ok := a.Selectors[key].(string); ok && v != "" {
+			target = v
+			break
+		}
+	}
+	if a.Type == "navigate" {
+		target = analysis.NormalizeEndpoint("", a.ToURL)
+	}
+	return strings.TrimSpace(a.Type + " " + target)
+}
+
+func compareActions(aActions, bActions []capture.EnhancedAction) []SessionDivergence {
+	if len(aActions) == 0 || len(bActions) == 0 {
+		return nil
+	}
+	n := min(len(aActions), len(bActions))
+	for i := 0; i < n; i++ {
+		ta, tb := actionToken(aActions[i]), actionToken(bActions[i])
+		if ta != tb {
+			return []SessionDivergence{{
+				Category: divergenceActions, Kind: "sequence_diverged", Key: fmt.Sprintf("step_%d", i+1),
+				Summary: fmt.Sprintf("Action sequences diverge at step %d: %q vs %q", i+1, ta, tb),
+				A:       ta, B: tb, Score: 35,
+			}}
+		}
+	}
+	if len(aActions) != len(bActions) {
+		return []SessionDivergence{{
+			Category: divergenceActions, Kind: "length_changed", Key: fmt.Sprintf("step_%d", n+1),
+			Summary: fmt.Sprintf("Action sequences match for %d steps; A has %d, B has %d", n, len(aActions), len(bActions)),
+			A:       len(aActions), B: len(bActions), Score: 20,
+		}}
+	}
+	return nil
+}
+
+func truncateForSummary(msg string) string {
+	const limit = 120
+	if len(msg) <= limit {
+		return msg
+	}
+	return msg[:limit] + "…"
+}
@@ -0,0 +1,108 @@
+// Purpose: Tests ranked divergence detection between two session bundles.
+// Docs: docs/features/feature/read-only-mode/index.md
+
+package observe
+
+import (
+	"testing"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/performance"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/sessionbundle"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/types"
+)
+
+func findDivergence(ds []SessionDivergence, category, kind string) *SessionDivergence {
+	for i := range ds {
+		if ds[i].Category == category && ds[i].Kind == kind {
+			return &ds[i]
+		}
+	}
+	return nil
+}
+
+func TestCompareSessions_RanksFailuresAndNewErrorsFirst(t *testing.T) {
+	t.Parallel()
+	local := sessionbundle.Bundle{
+		Logs: []types.LogEntry{{"level": "warn", "message": "deprecated API"}},
+		NetworkBodies: []capture.NetworkBody{
+			{Method: "GET", URL: "http://localhost:3000/api/users/1", Status: 200, Duration: 40},
+			{Method: "GET", URL: "http://localhost:3000/api/flags", Status: 200, Duration: 10},
+		},
+	}
+	ci := sessionbundle.Bundle{
+		Logs: []types.LogEntry{
+			{"level": "error", "message": "TypeError: cannot read properties of undefined (reading 'id')"},
+		},
+		NetworkBodies: []capture.NetworkBody{
+			// Different host and ID still line up with the local endpoint.
+			{Method: "GET", URL: "https://ci.internal/api/users/42", Status: 500, Duration: 45},
+		},
+	}
+
+	ds := CompareSessions(local, ci)
+	if len(ds) < 3 {
+		t.Fatalf("divergences = %+v, want at least 3", ds)
+	}
+	// A newly failing endpoint usually explains the new error, so it ranks first.
+	if ds[0].Category != divergenceNetwork || ds[0].Kind != "status_changed" || ds[0].Key != "GET /api/users/{id}" {
+		t.Fatalf("top divergence = %+v, want GET /api/users/{id} status_changed", ds[0])
+	}
+	if ds[1].Category != divergenceErrors || ds[1].Kind != "only_in_b" {
+		t.Fatalf("second divergence = %+v, want error only in B", ds[1])
+	}
+	if missing := findDivergence(ds, divergenceNetwork, "only_in_a"); missing == nil || missing.Key != "GET /api/flags" {
+		t.Fatalf("only_in_a = %+v, want GET /api/flags", missing)
+	}
+	for i := 1; i < len(ds); i++ {
+		if ds[i].Score > ds[i-1].Score {
+			t.Fatalf("divergences not sorted by score: %+v", ds)
+		}
+	}
+}
+
+func TestCompareSessions_IdenticalSessionsHaveNoDivergences(t *testing.T) {
+	t.Parallel()
+	b := sessionbundle.Bundle{
+		Logs:          []types.LogEntry{{"level": "error", "message": "boom 1"}},
+		NetworkBodies: []capture.NetworkBody{{Method: "GET", URL: "https://a.test/x", Status: 200}},
+		Actions:       []capture.EnhancedAction{{Type: "click", Selectors: map[string]any{"css": "#go"}}},
+	}
+	if ds := CompareSessions(b, b); len(ds) != 0 {
+		t.Fatalf("divergences = %+v, want none", ds)
+	}
+}
+
+func TestCompareSessions_VitalsAndActions(t *testing.T) {
+	t.Parallel()
+	lcp := func(v float64) []capture.PerformanceSnapshot {
+		return []capture.PerformanceSnapshot{{Timing: performance.PerformanceTiming{LargestContentfulPaint: &v, Load: 1000}}}
+	}
+	a := sessionbundle.Bundle{
+		Performance: lcp(1200),
+		Actions: []capture.EnhancedAction{
+			{Type: "click", Selectors: map[string]any{"css": "#login"}},
+			{Type: "input", Selectors: map[string]any{"testId": "email"}},
+		},
+	}
+	b := sessionbundle.Bundle{
+		Performance: lcp(3000),
+		Actions: []capture.EnhancedAction{
+			{Type: "click", Selectors: map[string]any{"css": "#login"}},
+			{Type: "click", Selectors: map[string]any{"css": "#sso"}},
+		},
+	}
+
+	ds := CompareSessions(a, b)
+	vit := findDivergence(ds, divergenceVitals, "regressed_in_b")
+	if vit == nil || vit.Key != "lcp" {
+		t.Fatalf("vitals divergence = %+v, want lcp regressed_in_b", vit)
+	}
+	if findDivergence(ds, divergenceVitals, "regressed_in_a") != nil {
+		t.Fatal("unchanged load time should not be reported")
+	}
+	act := findDivergence(ds, divergenceActions, "sequence_diverged")
+	if act == nil || act.Key != "step_2" || act.B != "click #sso" {
+		t.Fatalf("action divergence = %+v, want step_2 click #sso", act)
+	}
+}
@@ -0,0 +1,348 @@
+// Purpose: Reconstructs the best-known page state at a past moment from the capture buffers.
+// Why: Post-mortem questions ("what was happening right before the crash?") need one precise answer, not a manual merge of four buffers.
+// Docs: docs/features/feature/state-at/index.md
+
+package observe
+
+import (
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/mcp"
+)
+
+const (
+	stateAtActionLimit = 10
+	stateAtErrorLimit  = 5
+)
+
+// StateAt is the page state reconstructed for one moment.
+type StateAt struct {
+	T              string             `json:"t"`
+	URL            string             `json:"url,omitempty"`
+	URLSource      string             `json:"url_source,omitempty"` // navigation, action, next_navigation, or tracked_tab
+	RecentActions  []StateAtAction    `json:"recent_actions"`
+	InFlight       []StateAtRequest   `json:"in_flight_requests"`
+	OpenWebSockets []StateAtWebSocket `json:"open_websockets"`
+	LastErrors     []StateAtError     `json:"last_errors"`
+	Coverage       StateAtCoverage    `json:"coverage"`
+}
+
+// StateAtAction is a user or agent action at or before t, newest first.
+type StateAtAction struct {
+	Timestamp string `json:"timestamp"`
+	AgoMs     int64  `json:"ago_ms"`
+	Type      string `json:"type"`
+	Selector  string `json:"selector,omitempty"`
+	Value     string `json:"value,omitempty"`
+	URL       string `json:"url,omitempty"`
+	Source    string `json:"source,omitempty"`
+}
+
+// StateAtRequest is a request that had started but not finished at t.
+type StateAtRequest struct {
+	Method      string `json:"method"`
+	URL         string `json:"url"`
+	Status      int    `json:"status"`
+	StartedAt   string `json:"started_at"`
+	CompletedAt string `json:"completed_at"`
+	ElapsedMs   int64  `json:"elapsed_ms"`            // time in flight at t
+	Approximate bool   `json:"approximate,omitempty"` // completion time is the server ingestion time
+}
+
+// StateAtWebSocket is a connection open at t.
+type StateAtWebSocket struct {
+	ID            string `json:"id"`
+	URL           string `json:"url,omitempty"`
+	OpenedAt      string `json:"opened_at,omitempty"` // empty when the open event was evicted
+	LastMessageAt string `json:"last_message_at,omitempty"`
+	Messages      int    `json:"messages"`
+}
+
+// StateAtError is an error logged at or before t, newest first.
+type StateAtError struct {
+	Timestamp string `json:"timestamp"`
+	AgoMs     int64  `json:"ago_ms"`
+	Message   string `json:"message"`
+	Source    string `json:"source,omitempty"`
+}
+
+// StateAtCoverage tells how far back the buffers reach and what the answer may be missing.
+type StateAtCoverage struct {
+	Oldest  string   `json:"oldest,omitempty"`
+	Newest  string   `json:"newest,omitempty"`
+	Caveats []string `json:"caveats,omitempty"`
+}
+
+// GetStateAt handles observe(what="state_at", t=...).
+func GetStateAt(deps Deps, req mcp.JSONRPCRequest, args json.RawMessage) mcp.JSONRPCResponse {
+	var params struct {
+		T string `json:"t"`
+	}
+	mcp.LenientUnmarshal(args, &params)
+	if params.T == "" {
+		return mcp.Fail(req, mcp.ErrMissingParam, "Required parameter 't' is missing",
+			"Pass t as an RFC3339 timestamp, e.g. the timestamp of an error from observe(what=\"errors\")", mcp.WithParam("t"))
+	}
+	t, err := ParseVerifySince(params.T)
+	if err != nil {
+		return mcp.Fail(req, mcp.ErrInvalidParam, "t must be an RFC3339 timestamp or a timestamp cursor, got "+params.T,
+			"Use a timestamp such as 2024-01-15T10:30:05Z", mcp.WithParam("t"))
+	}
+	state := ReconstructStateAt(deps, t, time.Now())
+	response := map[string]any{
+		"t":                  state.T,
+		"recent_actions":     state.RecentActions,
+		"in_flight_requests": state.InFlight,
+		"open_websockets":    state.OpenWebSockets,
+		"last_errors":        state.LastErrors,
+		"coverage":           state.Coverage,
+		"metadata":           BuildResponseMetadata(deps.GetCapture(), time.Now()),
+	}
+	if state.URL != "" {
+		response["url"] = state.URL
+		response["url_source"] = state.URLSource
+	}
+	return mcp.Succeed(req, "State at "+state.T, response)
+}
+
+// ReconstructStateAt builds the page state at t from everything the buffers still hold.
+func ReconstructStateAt(deps Deps, t, now time.Time) StateAt {
+	cap := deps.GetCapture()
+	state := StateAt{
+		T:              t.UTC().Format(time.RFC3339Nano),
+		RecentActions:  []StateAtAction{},
+		InFlight:       []StateAtRequest{},
+		OpenWebSockets: []StateAtWebSocket{},
+		LastErrors:     []StateAtError{},
+	}
+	var span stateAtSpan
+
+	actions := cap.GetAllEnhancedActions()
+	for _, a := range actions {
+		span.add(time.UnixMilli(a.Timestamp))
+	}
+	state.URL, state.URLSource = stateAtURL(cap, actions, t)
+	state.RecentActions = stateAtActions(actions, t)
+
+	bodies, ingested := cap.GetNetworkBodiesWithTimestamps()
+	state.InFlight = stateAtRequests(bodies, ingested, t, &span)
+
+	state.OpenWebSockets = stateAtWebSockets(cap.GetAllWebSocketEvents(), t, &span)
+	state.LastErrors = stateAtErrors(deps, t, &span)
+
+	state.Coverage = span.coverage(t, now)
+	return state
+}
+
+// stateAtURL prefers the last navigation at or before t, then the page URL of the last action,
+// then the origin of the first navigation after t, and finally the tracked tab.
+func stateAtURL(cap *capture.Store, actions []capture.EnhancedAction, t time.Time) (string, string) {
+	cutoff := t.UnixMilli()
+	var navURL, actionURL, nextFrom string
+	var navAt, actionAt int64
+	nextAt := int64(-1)
+	for _, a := range actions {
+		isNav := (a.Type == "navigate" || a.Type == "navigation") && a.ToURL != ""
+		switch {
+		case a.Timestamp <= cutoff && isNav && a.Timestamp >= navAt:
+			navURL, navAt = a.ToURL, a.Timestamp
+		case a.Timestamp <= cutoff && !isNav && a.URL != "" && a.Timestamp >= actionAt:
+			actionURL, actionAt = a.URL, a.Timestamp
+		case a.Timestamp > cutoff && isNav && a.FromURL != "" && (nextAt < 0 || a.Timestamp < nextAt):
+			nextFrom, nextAt = a.FromURL, a.Timestamp
+		}
+	}
+	switch {
+	case navURL != "" && navAt >= actionAt:
+		return navURL, "navigation"
+	case actionURL != "":
+		return actionURL, "action"
+	case navURL != "":
+		return navURL, "navigation"
+	case nextFrom != "":
+		return nextFrom, "next_navigation"
+	}
+	if nextAt < 0 {
+		if _, _, tabURL := cap.GetTrackingStatus(); tabURL != "" {
+			return tabURL, "tracked_tab"
+		}
+	}
+	return "", ""
+}
+
+func stateAtActions(actions []capture.EnhancedAction, t time.Time) []StateAtAction {
+	cutoff := t.UnixMilli()
+	out := []StateAtAction{}
+	for _, a := range actions {
+		if a.Timestamp > cutoff {
+			continue
+		}
+		entry := StateAtAction{
+			Timestamp: time.UnixMilli(a.Timestamp).UTC().Format(time.RFC3339Nano),
+			AgoMs:     cutoff - a.Timestamp,
+			Type:      a.Type,
+			Value:     a.Value,
+			URL:       a.URL,
+			Source:    a.Source,
+		}
+		if css, ok := a.Selectors["css"].(string); ok {
+			entry.Selector = css
+		}
+		if a.ToURL != "" {
+			entry.URL = a.ToURL
+		}
+		out = append(out, entry)
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].AgoMs < out[j].AgoMs })
+	if len(out) > stateAtActionLimit {
+		out = out[:stateAtActionLimit]
+	}
+	return out
+}
+
+// stateAtRequests lists requests whose [start, completion) window contains t. Bodies are
+// captured on completion, so start is completion minus duration; a body without the
+// extension's ts falls back to its ingestion time and is marked approximate.
+func stateAtRequests(bodies []capture.NetworkBody, ingested []time.Time, t time.Time, span *stateAtSpan) []StateAtRequest {
+	out := []StateAtRequest{}
+	for i, b := range bodies {
+		end, approximate := time.Time{}, false
+		if ts, err := time.Parse(time.RFC3339Nano, b.Timestamp); err == nil {
+			end = ts
+		} else if i < len(ingested) {
+			end, approximate = ingested[i], true
+		}
+		if end.IsZero() {
+			continue
+		}
+		start := end.Add(-time.Duration(b.Duration) * time.Millisecond)
+		span.add(start)
+		span.add(end)
+		if start.After(t) || !end.After(t) {
+			continue
+		}
+		out = append(out, StateAtRequest{
+			Method:      b.Method,
+			URL:         b.URL,
+			Status:      b.Status,
+			StartedAt:   start.UTC().Format(time.RFC3339Nano),
+			CompletedAt: end.UTC().Format(time.RFC3339Nano),
+			ElapsedMs:   t.Sub(start).Milliseconds(),
+			Approximate: approximate,
+		})
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].StartedAt < out[j].StartedAt })
+	return out
+}
+
+// stateAtWebSockets replays connection lifecycle events up to t. A connection whose open
+// event was evicted still counts as open when it carried traffic and had not closed.
+func stateAtWebSockets(events []capture.WebSocketEvent, t time.Time, span *stateAtSpan) []StateAtWebSocket {
+	conns := map[string]*StateAtWebSocket{}
+	var order []string
+	for _, ev := range events {
+		ts, err := time.Parse(time.RFC3339Nano, ev.Timestamp)
+		if err != nil {
+			continue
+		}
+		span.add(ts)
+		if ts.After(t) || ev.ID == "" {
+			continue
+		}
+		conn, ok := conns[ev.ID]
+		if !ok {
+			conn = &StateAtWebSocket{ID: ev.ID}
+			conns[ev.ID] = conn
+			order = append(order, ev.ID)
+		}
+		if ev.URL != "" {
+			conn.URL = ev.URL
+		}
+		switch ev.Event {
+		case "open":
+			*conn = StateAtWebSocket{ID: ev.ID, URL: conn.URL, OpenedAt: ts.UTC().Format(time.RFC3339Nano)}
+		case "close":
+			delete(conns, ev.ID)
+		case "message":
+			conn.Messages++
+			conn.LastMessageAt = ts.UTC().Format(time.RFC3339Nano)
+		}
+	}
+	out := []StateAtWebSocket{}
+	for _, id := range order {
+		if conn, ok := conns[id]; ok {
+			out = append(out, *conn)
+			delete(conns, id)
+		}
+	}
+	return out
+}
+
+func stateAtErrors(deps Deps, t time.Time, span *stateAtSpan) []StateAtError {
+	logEntries, ingested := deps.GetLogEntries()
+	out := []StateAtError{}
+	for i, entry := range logEntries {
+		ts, err := time.Parse(time.RFC3339Nano, logEntryTimestamp(entry))
+		if err != nil {
+			if i >= len(ingested) {
+				continue
+			}
+			ts = ingested[i]
+		}
+		span.add(ts)
+		if level, _ := entry["level"].(string); level != "error" || ts.After(t) {
+			continue
+		}
+		msg, _ := entry["message"].(string)
+		source, _ := entry["source"].(string)
+		out = append(out, StateAtError{
+			Timestamp: ts.UTC().Format(time.RFC3339Nano),
+			AgoMs:     t.Sub(ts).Milliseconds(),
+			Message:   truncateDetail(msg),
+			Source:    source,
+		})
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].AgoMs < out[j].AgoMs })
+	if len(out) > stateAtErrorLimit {
+		out = out[:stateAtErrorLimit]
+	}
+	return out
+}
+
+// stateAtSpan tracks the oldest and newest event seen across buffers.
+type stateAtSpan struct {
+	oldest, newest time.Time
+}
+
+func (s *stateAtSpan) add(ts time.Time) {
+	if ts.IsZero() {
+		return
+	}
+	if s.oldest.IsZero() || ts.Before(s.oldest) {
+		s.oldest = ts
+	}
+	if ts.After(s.newest) {
+		s.newest = ts
+	}
+}
+
+func (s *stateAtSpan) coverage(t, now time.Time) StateAtCoverage {
+	var c StateAtCoverage
+	if s.oldest.IsZero() {
+		c.Caveats = append(c.Caveats, "No events are buffered; nothing can be reconstructed")
+		return c
+	}
+	c.Oldest = s.oldest.UTC().Format(time.RFC3339Nano)
+	c.Newest = s.newest.UTC().Format(time.RFC3339Nano)
+	if t.Before(s.oldest) {
+		c.Caveats = append(c.Caveats, "t is older than every buffered event; earlier data was evicted or never captured, so this state is likely incomplete")
+	}
+	if t.After(now) {
+		c.Caveats = append(c.Caveats, "t is in the future; the state shown is the current one")
+	}
+	c.Caveats = append(c.Caveats, "Requests are captured on completion, so one still pending when the buffers were read is not listed as in flight")
+	return c
+}
@@ -0,0 +1,96 @@
+// Purpose: Tests observe(what="state_at") reconstruction of URL, actions, in-flight requests, WebSockets, and errors at a moment.
+// Docs: docs/features/feature/state-at/index.md
+
+package observe
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/mcp"
+)
+
+type stateAtDeps struct {
+	mockTransientDeps
+	logs []mcp.LogEntry
+}
+
+func (m *stateAtDeps) GetLogEntries() ([]mcp.LogEntry, []time.Time) {
+	return m.logs, make([]time.Time, len(m.logs))
+}
+
+func TestReconstructStateAt(t *testing.T) {
+	base := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+	at := func(sec float64) time.Time { return base.Add(time.Duration(sec * float64(time.Second))) }
+	iso := func(sec float64) string { return at(sec).Format(time.RFC3339Nano) }
+
+	c := capture.NewCapture()
+	c.AddEnhancedActionsForTest([]capture.EnhancedAction{
+		{Type: "navigate", Timestamp: at(0).UnixMilli(), FromURL: "https://shop.test/", ToURL: "https://shop.test/cart"},
+		{Type: "click", Timestamp: at(3).UnixMilli(), URL: "https://shop.test/cart", Selectors: map[string]any{"css": "#checkout"}},
+		{Type: "navigate", Timestamp: at(9).UnixMilli(), FromURL: "https://shop.test/cart", ToURL: "https://shop.test/done"},
+	})
+	c.AddNetworkBodies([]capture.NetworkBody{
+		{Timestamp: iso(6), Method: "POST", URL: "https://shop.test/api/order", Status: 500, Duration: 2500}, // 3.5s-6s
+		{Timestamp: iso(4), Method: "GET", URL: "https://shop.test/api/cart", Status: 200, Duration: 500},    // done before t
+	})
+	c.AddWebSocketEventsForTest([]capture.WebSocketEvent{
+		{Timestamp: iso(1), Event: "open", ID: "ws-1", URL: "wss://shop.test/live"},
+		{Timestamp: iso(2), Event: "message", ID: "ws-1", Direction: "incoming"},
+		{Timestamp: iso(1), Event: "open", ID: "ws-2", URL: "wss://shop.test/chat"},
+		{Timestamp: iso(4), Event: "close", ID: "ws-2"},
+		{Timestamp: iso(2), Event: "message", ID: "ws-3", URL: "wss://shop.test/old"}, // open evicted
+	})
+	deps := &stateAtDeps{mockTransientDeps: mockTransientDeps{cap: c}, logs: []mcp.LogEntry{
+		{"level": "error", "message": "TypeError: cart is undefined", "ts": iso(2)},
+		{"level": "warn", "message": "slow render", "ts": iso(4)},
+		{"level": "error", "message": "Order failed", "ts": iso(7)},
+	}}
+
+	state := ReconstructStateAt(deps, at(5), at(60))
+	if state.URL != "https://shop.test/cart" || state.URLSource != "action" {
+		t.Fatalf("url = %q (%s), want the cart page", state.URL, state.URLSource)
+	}
+	if len(state.RecentActions) != 2 || state.RecentActions[0].Type != "click" || state.RecentActions[0].AgoMs != 2000 || state.RecentActions[0].Selector != "#checkout" {
+		t.Fatalf("recent actions = %+v", state.RecentActions)
+	}
+	if len(state.InFlight) != 1 || state.InFlight[0].URL != "https://shop.test/api/order" || state.InFlight[0].ElapsedMs != 1500 {
+		t.Fatalf("in flight = %+v", state.InFlight)
+	}
+	if len(state.OpenWebSockets) != 2 || state.OpenWebSockets[0].ID != "ws-1" || state.OpenWebSockets[0].Messages != 1 ||
+		state.OpenWebSockets[1].ID != "ws-3" || state.OpenWebSockets[1].OpenedAt != "" {
+		t.Fatalf("open websockets = %+v", state.OpenWebSockets)
+	}
+	if len(state.LastErrors) != 1 || state.LastErrors[0].Message != "TypeError: cart is undefined" || state.LastErrors[0].AgoMs != 3000 {
+		t.Fatalf("last errors = %+v", state.LastErrors)
+	}
+
+	before := ReconstructStateAt(deps, base.Add(-time.Minute), at(60))
+	if before.URL != "https://shop.test/" || before.URLSource != "next_navigation" {
+		t.Fatalf("url before first event = %q (%s)", before.URL, before.URLSource)
+	}
+	if len(before.Coverage.Caveats) == 0 || !strings.Contains(before.Coverage.Caveats[0], "older than every buffered event") {
+		t.Fatalf("missing eviction caveat: %+v", before.Coverage)
+	}
+}
+
+func TestGetStateAt_RequiresValidT(t *testing.T) {
+	deps := &stateAtDeps{mockTransientDeps: mockTransientDeps{cap: capture.NewCapture()}}
+	req := mcp.JSONRPCRequest{JSONRPC: "2.0", ID: 1}
+	for _, args := range []string{`{}`, `{"t":"yesterday"}`} {
+		var result mcp.MCPToolResult
+		if err := json.Unmarshal(GetStateAt(deps, req, json.RawMessage(args)).Result, &result); err != nil {
+			t.Fatal(err)
+		}
+		if !result.IsError {
+			t.Fatalf("%s should fail", args)
+		}
+	}
+	data := extractMCPJSON(t, GetStateAt(deps, req, json.RawMessage(`{"t":"2024-01-15T10:30:05Z"}`)))
+	if data["t"] != "2024-01-15T10:30:05Z" {
+		t.Fatalf("t = %v", data["t"])
+	}
+}
@@ -1,14 +1,20 @@
 // Purpose: Handles observe(what:"storage") mode: reads localStorage/sessionStorage and returns filtered entries.
 // Docs: docs/features/feature/observe/index.md
+// Docs: docs/features/feature/storage-observation/index.md
 
 package observe
 
 import (
 	"encoding/json"
+	"sort"
+	"strings"
 	"time"
 
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+
 	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/mcp"
 	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/queries"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/redaction"
 )
 
 // summarizeStorageMap returns a summary of a key-value storage map.
@@ -25,15 +31,32 @@ func summarizeStorageMap(data map[string]any) map[string]any {
 			totalBytes += len(b)
 		}
 	}
+	sort.Strings(keys)
+	return storageSummary(len(data), totalBytes, keys)
+}
+
+// storageSummary builds the key_count/total_bytes/sample_keys summary shared by all
+// stores, listing sensitive key names so an agent knows values exist without seeing them.
+func storageSummary(count, totalBytes int, keys []string) map[string]any {
 	sampleKeys := keys
 	if len(sampleKeys) > 5 {
 		sampleKeys = sampleKeys[:5]
 	}
-	return map[string]any{
-		"key_count":   len(data),
+	summary := map[string]any{
+		"key_count":   count,
 		"total_bytes": totalBytes,
 		"sample_keys": sampleKeys,
 	}
+	var sensitive []string
+	for _, k := range keys {
+		if _, ok := redaction.KeyRedactionMarker(k); ok {
+			sensitive = append(sensitive, k)
+		}
+	}
+	if len(sensitive) > 0 {
+		summary["sensitive_keys"] = sensitive
+	}
+	return summary
 }
 
 // summarizeCookies returns a summary of a cookie array.
@@ -49,15 +72,66 @@ func summarizeCookies(cookies []any) map[string]any {
 			totalBytes += len(b)
 		}
 	}
-	sampleKeys := names
-	if len(sampleKeys) > 5 {
-		sampleKeys = sampleKeys[:5]
+	sort.Strings(names)
+	return storageSummary(len(cookies), totalBytes, names)
+}
+
+// normalizeCookies returns the captured cookies as a list of maps. The extension sends
+// chrome.cookies metadata when it can and falls back to the raw document.cookie string.
+func normalizeCookies(raw any) ([]any, bool) {
+	switch v := raw.(type) {
+	case []any:
+		return v, true
+	case string:
+		cookies := []any{}
+		for _, part := range strings.Split(v, ";") {
+			name, value, _ := strings.Cut(strings.TrimSpace(part), "=")
+			if name == "" {
+				continue
+			}
+			cookies = append(cookies, map[string]any{"name": name, "value": value})
+		}
+		return cookies, true
 	}
-	return map[string]any{
-		"key_count":   len(cookies),
-		"total_bytes": totalBytes,
-		"sample_keys": sampleKeys,
+	return nil, false
+}
+
+// redactStorageMap copies data with values under sensitive key names replaced by a marker.
+func redactStorageMap(data map[string]any) map[string]any {
+	out := make(map[string]any, len(data))
+	for k, v := range data {
+		if marker, ok := redaction.KeyRedactionMarker(k); ok {
+			out[k] = marker
+			continue
+		}
+		out[k] = v
 	}
+	return out
+}
+
+// redactCookies copies cookies with values of sensitive-named cookies replaced by a marker.
+func redactCookies(cookies []any) []any {
+	out := make([]any, 0, len(cookies))
+	for _, c := range cookies {
+		m, ok := c.(map[string]any)
+		if !ok {
+			out = append(out, c)
+			continue
+		}
+		name, _ := m["name"].(string)
+		marker, sensitive := redaction.KeyRedactionMarker(name)
+		if !sensitive {
+			out = append(out, m)
+			continue
+		}
+		copied := make(map[string]any, len(m))
+		for k, v := range m {
+			copied[k] = v
+		}
+		copied["value"] = marker
+		out = append(out, copied)
+	}
+	return out
 }
 
 // storageParams holds parsed parameters for storage queries.
@@ -65,6 +139,7 @@ type storageParams struct {
 	Summary     bool
 	StorageType string // "local", "session", "cookies", or "" for all
 	Key         string // specific key/cookie name filter
+	TestID      string // test boundary whose baseline changes are reported
 }
 
 func parseStorageParams(args json.RawMessage) storageParams {
@@ -76,6 +151,7 @@ func parseStorageParams(args json.RawMessage) storageParams {
 		Summary     *bool  `json:"summary"`
 		StorageType string `json:"storage_type"`
 		Key         string `json:"key"`
+		TestID      string `json:"test_id"`
 	}
 	if json.Unmarshal(args, &raw) == nil {
 		if raw.Summary != nil {
@@ -83,6 +159,7 @@ func parseStorageParams(args json.RawMessage) storageParams {
 		}
 		p.StorageType = raw.StorageType
 		p.Key = raw.Key
+		p.TestID = raw.TestID
 	}
 	return p
 }
@@ -114,20 +191,19 @@ func filterCookies(cookies []any, name string) []any {
 	return filtered
 }
 
-// GetStorage returns localStorage, sessionStorage, and cookies from the tracked tab.
-func GetStorage(deps Deps, req mcp.JSONRPCRequest, args json.RawMessage) mcp.JSONRPCResponse {
-	params := parseStorageParams(args)
-	cap := deps.GetCapture()
-	enabled, _, _ := cap.GetTrackingStatus()
-	if !enabled {
-		return mcp.JSONRPCResponse{JSONRPC: "2.0", ID: req.ID, Result: mcp.StructuredErrorResponse(
-			mcp.ErrNoData,
-			"No tab is being tracked. Open the Kaboom extension popup and click 'Track This Tab'.",
-			"Track a tab first, then call observe with what='storage'.",
-			mcp.WithHint(deps.DiagnosticHintString()),
-		)}
-	}
+// storageFetchError carries the structured error code for a failed storage capture.
+type storageFetchError struct {
+	code    string
+	message string
+	retry   string
+	opts    []func(*mcp.StructuredError)
+}
 
+func (e *storageFetchError) Error() string { return e.message }
+
+// fetchStorageState asks the extension for the tracked tab's localStorage,
+// sessionStorage, and cookies.
+func fetchStorageState(cap *capture.Store) (map[string]any, error) {
 	queryID, qerr := cap.CreatePendingQueryWithTimeout(
 		queries.PendingQuery{
 			Type:   "state_capture",
@@ -137,42 +213,56 @@ func GetStorage(deps Deps, req mcp.JSONRPCRequest, args json.RawMessage) mcp.JSO
 		"",
 	)
 	if qerr != nil {
-		return mcp.JSONRPCResponse{JSONRPC: "2.0", ID: req.ID, Result: mcp.StructuredErrorResponse(
-			mcp.ErrQueueFull,
-			"Command queue full: "+qerr.Error(),
-			"Wait for in-flight commands to complete, then retry.",
-			mcp.WithRecoveryToolCall(map[string]any{"tool": "observe", "arguments": map[string]any{"what": "pending_commands"}}),
-		)}
+		return nil, &storageFetchError{mcp.ErrQueueFull, "Command queue full: " + qerr.Error(), "Wait for in-flight commands to complete, then retry.", nil}
 	}
 
 	result, err := cap.WaitForResult(queryID, 10*time.Second)
 	if err != nil {
-		return mcp.JSONRPCResponse{JSONRPC: "2.0", ID: req.ID, Result: mcp.StructuredErrorResponse(
-			mcp.ErrExtTimeout,
-			"Storage capture timeout: "+err.Error(),
-			"Ensure the extension is connected and the page has loaded.",
-			mcp.WithHint(deps.DiagnosticHintString()),
-		)}
+		code, opts := mcp.ExtensionWaitFailure(err)
+		return nil, &storageFetchError{code, "Storage capture timeout: " + err.Error(), "Ensure the extension is connected and the page has loaded.", opts}
 	}
 
 	var stateResult map[string]any
 	if err := json.Unmarshal(result, &stateResult); err != nil {
-		return mcp.JSONRPCResponse{JSONRPC: "2.0", ID: req.ID, Result: mcp.StructuredErrorResponse(
-			mcp.ErrInvalidJSON,
-			"Failed to parse storage result: "+err.Error(),
-			"Check extension logs for errors",
-		)}
+		return nil, &storageFetchError{mcp.ErrInvalidJSON, "Failed to parse storage result: " + err.Error(), "Check extension logs for errors", nil}
 	}
 
 	if errMsg, ok := stateResult["error"].(string); ok {
+		return nil, &storageFetchError{mcp.ErrExtError, "Storage capture failed: " + errMsg, "Check that the tab is accessible.", nil}
+	}
+	return stateResult, nil
+}
+
+// GetStorage returns localStorage, sessionStorage, and cookies from the tracked tab.
+// Values stored under sensitive key names are redacted. With test_id it also reports
+// what changed since the baseline recorded at test_boundary_start.
+func GetStorage(deps Deps, req mcp.JSONRPCRequest, args json.RawMessage) mcp.JSONRPCResponse {
+	params := parseStorageParams(args)
+	cap := deps.GetCapture()
+	enabled, _, _ := cap.GetTrackingStatus()
+	if !enabled {
 		return mcp.JSONRPCResponse{JSONRPC: "2.0", ID: req.ID, Result: mcp.StructuredErrorResponse(
-			mcp.ErrExtError,
-			"Storage capture failed: "+errMsg,
-			"Check that the tab is accessible.",
+			mcp.ErrNoData,
+			"No tab is being tracked. Open the Kaboom extension popup and click 'Track This Tab'.",
+			"Track a tab first, then call observe with what='storage'.",
 			mcp.WithHint(deps.DiagnosticHintString()),
 		)}
 	}
 
+	stateResult, err := fetchStorageState(cap)
+	if err != nil {
+		fetchErr, _ := err.(*storageFetchError)
+		opts := append(fetchErr.opts, mcp.WithHint(deps.DiagnosticHintString()))
+		if fetchErr.code == mcp.ErrQueueFull {
+			opts = []func(*mcp.StructuredError){mcp.WithRecoveryToolCall(map[string]any{"tool": "observe", "arguments": map[string]any{"what": "pending_commands"}})}
+		} else if fetchErr.code == mcp.ErrInvalidJSON {
+			opts = nil
+		}
+		return mcp.JSONRPCResponse{JSONRPC: "2.0", ID: req.ID, Result: mcp.StructuredErrorResponse(
+			fetchErr.code, fetchErr.message, fetchErr.retry, opts...,
+		)}
+	}
+
 	response := map[string]any{
 		"url":      stateResult["url"],
 		"metadata": BuildResponseMetadata(cap, time.Now()),
@@ -181,6 +271,7 @@ func GetStorage(deps Deps, req mcp.JSONRPCRequest, args json.RawMessage) mcp.JSO
 	includeLocal := params.StorageType == "" || params.StorageType == "local"
 	includeSession := params.StorageType == "" || params.StorageType == "session"
 	includeCookies := params.StorageType == "" || params.StorageType == "cookies"
+	cookies, hasCookies := normalizeCookies(stateResult["cookies"])
 
 	if params.Summary {
 		if includeLocal {
@@ -193,39 +284,57 @@ func GetStorage(deps Deps, req mcp.JSONRPCRequest, args json.RawMessage) mcp.JSO
 				response["session_storage"] = summarizeStorageMap(filterStorageMap(v, params.Key))
 			}
 		}
-		if includeCookies {
-			if v, ok := stateResult["cookies"].([]any); ok {
-				response["cookies"] = summarizeCookies(filterCookies(v, params.Key))
-			}
+		if includeCookies && hasCookies {
+			response["cookies"] = summarizeCookies(filterCookies(cookies, params.Key))
 		}
 	} else {
 		if includeLocal {
 			if v, ok := stateResult["localStorage"].(map[string]any); ok {
-				response["local_storage"] = filterStorageMap(v, params.Key)
+				response["local_storage"] = redactStorageMap(filterStorageMap(v, params.Key))
 			}
 		}
 		if includeSession {
 			if v, ok := stateResult["sessionStorage"].(map[string]any); ok {
-				response["session_storage"] = filterStorageMap(v, params.Key)
+				response["session_storage"] = redactStorageMap(filterStorageMap(v, params.Key))
 			}
 		}
-		if includeCookies {
-			if v, ok := stateResult["cookies"].([]any); ok {
-				response["cookies"] = filterCookies(v, params.Key)
-			}
+		if includeCookies && hasCookies {
+			response["cookies"] = redactCookies(filterCookies(cookies, params.Key))
 		}
 	}
 
-	// IndexedDB listing is best-effort (skip if storage_type filter excludes it)
-	if params.StorageType == "" {
-		if indexeddb, err := getIndexedDBListing(cap); err != nil {
-			response["indexeddb"] = map[string]any{
-				"supported": false,
-				"databases": []any{},
+	// IndexedDB listing is best-effort (skip if storage_type filter excludes it,
+	// unless change tracking needs the record counts).
+	var indexeddb map[string]any
+	if params.StorageType == "" || params.TestID != "" {
+		listing, err := getIndexedDBListing(cap)
+		if err == nil {
+			indexeddb = listing
+		}
+		if params.StorageType == "" {
+			if err != nil {
+				response["indexeddb"] = map[string]any{
+					"supported": false,
+					"databases": []any{},
+				}
+				response["indexeddb_error"] = err.Error()
+			} else {
+				if quota, ok := listing["quota"]; ok {
+					response["quota"] = quota
+					delete(listing, "quota")
+				}
+				response["indexeddb"] = listing
 			}
-			response["indexeddb_error"] = err.Error()
+		}
+	}
+
+	if params.TestID != "" {
+		current := snapshotFromState(stateResult, indexeddb, time.Now())
+		if baseline, ok := cap.GetStorageBaseline(params.TestID); ok {
+			response["changes"] = diffStorageSnapshots(baseline, current)
 		} else {
-			response["indexeddb"] = indexeddb
+			cap.SetStorageBaseline(params.TestID, current)
+			response["baseline_recorded"] = true
 		}
 	}
 
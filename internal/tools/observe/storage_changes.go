@@ -0,0 +1,174 @@
+// Purpose: Fingerprints page storage and diffs it against a test's baseline for observe(storage, test_id).
+// Why: Lets an agent confirm a write actually persisted (or a logout actually cleared state) without reading values.
+// Docs: docs/features/feature/storage-observation/index.md
+
+package observe
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+)
+
+// hashStorageValue returns a short, stable fingerprint of a stored value.
+func hashStorageValue(v any) string {
+	var raw []byte
+	if s, ok := v.(string); ok {
+		raw = []byte(s)
+	} else {
+		raw, _ = json.Marshal(v)
+	}
+	sum := sha256.Sum256(raw)
+	return hex.EncodeToString(sum[:8])
+}
+
+func hashStorageMap(data map[string]any) map[string]string {
+	out := make(map[string]string, len(data))
+	for k, v := range data {
+		out[k] = hashStorageValue(v)
+	}
+	return out
+}
+
+// snapshotFromState fingerprints a state_capture result and an IndexedDB listing.
+// indexeddb may be nil when the listing failed.
+func snapshotFromState(state, indexeddb map[string]any, now time.Time) capture.StorageSnapshot {
+	snap := capture.StorageSnapshot{
+		TakenAt:        now,
+		LocalStorage:   map[string]string{},
+		SessionStorage: map[string]string{},
+		Cookies:        map[string]string{},
+		IndexedDB:      map[string]int{},
+	}
+	snap.URL, _ = state["url"].(string)
+	if v, ok := state["localStorage"].(map[string]any); ok {
+		snap.LocalStorage = hashStorageMap(v)
+	}
+	if v, ok := state["sessionStorage"].(map[string]any); ok {
+		snap.SessionStorage = hashStorageMap(v)
+	}
+	if cookies, ok := normalizeCookies(state["cookies"]); ok {
+		for _, c := range cookies {
+			m, ok := c.(map[string]any)
+			if !ok {
+				continue
+			}
+			if name, ok := m["name"].(string); ok && name != "" {
+				snap.Cookies[name] = hashStorageValue(m["value"])
+			}
+		}
+	}
+	dbs, _ := indexeddb["databases"].([]any)
+	for _, raw := range dbs {
+		db, ok := raw.(map[string]any)
+		if !ok {
+			continue
+		}
+		name, _ := db["name"].(string)
+		counts, _ := db["record_counts"].(map[string]any)
+		for store, n := range counts {
+			count, _ := n.(float64)
+			snap.IndexedDB[name+"/"+store] = int(count)
+		}
+	}
+	return snap
+}
+
+// diffHashedStore reports keys added, removed, and modified between two fingerprint maps.
+func diffHashedStore(before, after map[string]string) map[string]any {
+	added, removed, modified := []string{}, []string{}, []string{}
+	for k, h := range after {
+		prev, ok := before[k]
+		switch {
+		case !ok:
+			added = append(added, k)
+		case prev != h:
+			modified = append(modified, k)
+		}
+	}
+	for k := range before {
+		if _, ok := after[k]; !ok {
+			removed = append(removed, k)
+		}
+	}
+	sort.Strings(added)
+	sort.Strings(removed)
+	sort.Strings(modified)
+	return map[string]any{"added": added, "removed": removed, "modified": modified}
+}
+
+// diffIndexedDBCounts reports object stores whose record count changed, appeared, or vanished.
+func diffIndexedDBCounts(before, after map[string]int) []map[string]any {
+	stores := make(map[string]bool, len(before)+len(after))
+	for k := range before {
+		stores[k] = true
+	}
+	for k := range after {
+		stores[k] = true
+	}
+	names := make([]string, 0, len(stores))
+	for k := range stores {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+
+	changes := []map[string]any{}
+	for _, store := range names {
+		prev, hadPrev := before[store]
+		next, hasNext := after[store]
+		if hadPrev && hasNext && prev == next {
+			continue
+		}
+		change := map[string]any{"store": store}
+		if hadPrev {
+			change["before"] = prev
+		}
+		if hasNext {
+			change["after"] = next
+		}
+		changes = append(changes, change)
+	}
+	return changes
+}
+
+// diffStorageSnapshots reports everything that changed between a baseline and the current snapshot.
+func diffStorageSnapshots(before, after capture.StorageSnapshot) map[string]any {
+	local := diffHashedStore(before.LocalStorage, after.LocalStorage)
+	session := diffHashedStore(before.SessionStorage, after.SessionStorage)
+	cookies := diffHashedStore(before.Cookies, after.Cookies)
+	indexeddb := diffIndexedDBCounts(before.IndexedDB, after.IndexedDB)
+
+	changed := len(indexeddb) > 0
+	for _, d := range []map[string]any{local, session, cookies} {
+		for _, keys := range d {
+			if len(keys.([]string)) > 0 {
+				changed = true
+			}
+		}
+	}
+	return map[string]any{
+		"changed":           changed,
+		"baseline_taken_at": before.TakenAt.UTC().Format(time.RFC3339),
+		"baseline_url":      before.URL,
+		"local_storage":     local,
+		"session_storage":   session,
+		"cookies":           cookies,
+		"indexeddb":         indexeddb,
+	}
+}
+
+// CaptureStorageBaseline fingerprints the tracked tab's storage and records it as the
+// before-state for testID. IndexedDB counts are best-effort; a failed listing leaves them empty.
+func CaptureStorageBaseline(cap *capture.Store, testID string) error {
+	state, err := fetchStorageState(cap)
+	if err != nil {
+		return err
+	}
+	indexeddb, _ := getIndexedDBListing(cap)
+	cap.SetStorageBaseline(testID, snapshotFromState(state, indexeddb, time.Now()))
+	return nil
+}
@@ -0,0 +1,109 @@
+// Purpose: Tests storage fingerprinting, baseline diffs, cookie normalization, and value redaction.
+// Docs: docs/features/feature/storage-observation/index.md
+
+package observe
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSnapshotFromState_HashesValuesAndCountsStores(t *testing.T) {
+	t.Parallel()
+	state := map[string]any{
+		"url":            "https://app.example.com",
+		"localStorage":   map[string]any{"theme": "dark"},
+		"sessionStorage": map[string]any{"step": "2"},
+		"cookies":        "sid=abc; lang=en",
+	}
+	listing := map[string]any{"databases": []any{
+		map[string]any{"name": "shop", "record_counts": map[string]any{"orders": float64(3), "items": float64(0)}},
+	}}
+
+	snap := snapshotFromState(state, listing, time.Now())
+	if snap.LocalStorage["theme"] == "" || snap.LocalStorage["theme"] == "dark" {
+		t.Fatalf("theme fingerprint = %q, want a hash", snap.LocalStorage["theme"])
+	}
+	if len(snap.Cookies) != 2 {
+		t.Fatalf("cookies = %v, want sid and lang", snap.Cookies)
+	}
+	if snap.IndexedDB["shop/orders"] != 3 || snap.IndexedDB["shop/items"] != 0 {
+		t.Fatalf("indexeddb counts = %v", snap.IndexedDB)
+	}
+}
+
+func TestDiffStorageSnapshots(t *testing.T) {
+	t.Parallel()
+	now := time.Now()
+	before := snapshotFromState(map[string]any{
+		"localStorage": map[string]any{"cart": "[]", "theme": "dark"},
+		"cookies":      []any{map[string]any{"name": "sid", "value": "a"}},
+	}, map[string]any{"databases": []any{map[string]any{"name": "shop", "record_counts": map[string]any{"orders": float64(1)}}}}, now)
+	after := snapshotFromState(map[string]any{
+		"localStorage": map[string]any{"cart": "[1]", "order": "o-1"},
+		"cookies":      []any{map[string]any{"name": "sid", "value": "a"}},
+	}, map[string]any{"databases": []any{map[string]any{"name": "shop", "record_counts": map[string]any{"orders": float64(2), "audit": float64(1)}}}}, now)
+
+	diff := diffStorageSnapshots(before, after)
+	if diff["changed"] != true {
+		t.Fatal("changed = false, want true")
+	}
+	local := diff["local_storage"].(map[string]any)
+	if got := local["added"].([]string); len(got) != 1 || got[0] != "order" {
+		t.Errorf("added = %v, want [order]", got)
+	}
+	if got := local["removed"].([]string); len(got) != 1 || got[0] != "theme" {
+		t.Errorf("removed = %v, want [theme]", got)
+	}
+	if got := local["modified"].([]string); len(got) != 1 || got[0] != "cart" {
+		t.Errorf("modified = %v, want [cart]", got)
+	}
+	if got := diff["cookies"].(map[string]any)["modified"].([]string); len(got) != 0 {
+		t.Errorf("cookies.modified = %v, want none", got)
+	}
+	idb := diff["indexeddb"].([]map[string]any)
+	if len(idb) != 2 || idb[0]["store"] != "shop/audit" || idb[1]["after"] != 2 {
+		t.Fatalf("indexeddb = %v, want shop/audit added and shop/orders 1->2", idb)
+	}
+	if _, had := idb[0]["before"]; had {
+		t.Error("new store should have no before count")
+	}
+}
+
+func TestDiffStorageSnapshots_Unchanged(t *testing.T) {
+	t.Parallel()
+	snap := snapshotFromState(map[string]any{"localStorage": map[string]any{"a": "1"}}, nil, time.Now())
+	if diff := diffStorageSnapshots(snap, snap); diff["changed"] != false {
+		t.Fatalf("changed = %v, want false", diff["changed"])
+	}
+}
+
+func TestRedactStorageMapAndCookies(t *testing.T) {
+	t.Parallel()
+	local := redactStorageMap(map[string]any{"theme": "dark", "access_token": "xyz"})
+	if local["theme"] != "dark" {
+		t.Errorf("theme = %v, want dark", local["theme"])
+	}
+	if marker, _ := local["access_token"].(string); !strings.HasPrefix(marker, "[REDACTED") {
+		t.Errorf("access_token = %v, want redaction marker", local["access_token"])
+	}
+
+	original := map[string]any{"name": "session_token", "value": "secret", "httpOnly": true}
+	cookies := redactCookies([]any{original})
+	if cookies[0].(map[string]any)["value"] == "secret" {
+		t.Error("sensitive cookie value not redacted")
+	}
+	if original["value"] != "secret" {
+		t.Error("redactCookies mutated the captured cookie")
+	}
+}
+
+func TestSummarizeStorageMap_ListsSensitiveKeys(t *testing.T) {
+	t.Parallel()
+	result := summarizeStorageMap(map[string]any{"theme": "dark", "password": "p"})
+	sensitive, _ := result["sensitive_keys"].([]string)
+	if len(sensitive) != 1 || sensitive[0] != "password" {
+		t.Fatalf("sensitive_keys = %v, want [password]", sensitive)
+	}
+}
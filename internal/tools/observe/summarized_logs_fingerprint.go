@@ -26,6 +26,11 @@ func fingerprintMessage(msg string) string {
 	return slugify(s)
 }
 
+// ErrorClusterID returns the cluster id analyze(what="error_clusters") reports for msg.
+func ErrorClusterID(msg string) string {
+	return fingerprintMessage(msg)
+}
+
 // slugify converts a normalized message into a URL-safe slug.
 func slugify(s string) string {
 	s = strings.Map(func(r rune) rune {
@@ -66,10 +66,11 @@ func statusGroup(status int) string {
 	}
 }
 
-// buildWSEventsSummary returns {total, by_direction, by_event_type, connection_count, metadata}.
+// buildWSEventsSummary returns {total, by_direction, by_event_type, by_message_type, connection_count, metadata}.
 func buildWSEventsSummary(events []capture.WebSocketEvent, meta ResponseMetadata) map[string]any {
 	byDirection := make(map[string]int)
 	byEvent := make(map[string]int)
+	byMessageType := make(map[string]int)
 	connIDs := make(map[string]bool)
 
 	for _, e := range events {
@@ -82,15 +83,22 @@ func buildWSEventsSummary(events []capture.WebSocketEvent, meta ResponseMetadata
 		if e.ID != "" {
 			connIDs[e.ID] = true
 		}
+		if e.Decoded != nil && e.Decoded.MessageType != "" {
+			byMessageType[e.Decoded.MessageType]++
+		}
 	}
 
-	return map[string]any{
+	result := map[string]any{
 		"total":            len(events),
 		"by_direction":     byDirection,
 		"by_event_type":    byEvent,
 		"connection_count": len(connIDs),
 		"metadata":         meta,
 	}
+	if len(byMessageType) > 0 {
+		result["by_message_type"] = byMessageType
+	}
+	return result
 }
 
 // buildActionsSummary returns {total, by_type, time_range, metadata}.
@@ -0,0 +1,30 @@
+// Purpose: Scopes observe results to one browser tab.
+// Why: Several tabs feed the same buffers; tab_id keeps a multi-tab debugging session untangled.
+// Docs: docs/features/feature/multi-tab-attribution/index.md
+
+package observe
+
+import "github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/mcp"
+
+// inTab reports whether an entry attributed to entryTab belongs to tab.
+// A zero tab matches every entry.
+func inTab(entryTab, tab int) bool {
+	return tab == 0 || entryTab == tab
+}
+
+// logTabID returns the tab a log entry was captured in. Entries decoded from the
+// extension's JSON carry it as float64; daemon-built entries may hold an int.
+func logTabID(entry mcp.LogEntry) int {
+	switch id := entry["tabId"].(type) {
+	case float64:
+		return int(id)
+	case int:
+		return id
+	}
+	return 0
+}
+
+// logInTab reports whether a log entry was captured in tab.
+func logInTab(entry mcp.LogEntry, tab int) bool {
+	return inTab(logTabID(entry), tab)
+}
@@ -0,0 +1,47 @@
+// Purpose: Implements observe(what="third_party_audit") — supply-chain view of third-party scripts.
+// Why: Reports pinning, host class, size, load position, and drift from a stored baseline from passively captured traffic.
+// Docs: docs/features/feature/enterprise-audit/index.md
+
+package observe
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/analysis"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/mcp"
+)
+
+// GetThirdPartyAudit builds the third-party script supply-chain report for the tracked page.
+// With update_baseline=true the current scripts become the snapshot later calls diff against.
+func GetThirdPartyAudit(deps Deps, req mcp.JSONRPCRequest, args json.RawMessage) mcp.JSONRPCResponse {
+	cap := deps.GetCapture()
+	_, _, tabURL := cap.GetTrackingStatus()
+	var pageURLs []string
+	if tabURL != "" {
+		pageURLs = []string{tabURL}
+	}
+
+	report, err := analysis.HandleSupplyChainAudit(args, cap.GetNetworkBodies(), cap.GetNetworkWaterfallEntries(), pageURLs)
+	if err != nil {
+		return mcp.Fail(req, mcp.ErrInvalidParam, "Third-party audit failed: "+err.Error(), "Fix parameters and call again")
+	}
+
+	response := map[string]any{
+		"first_party_origin": report.FirstPartyOrigin,
+		"scripts":            report.Scripts,
+		"changes":            report.Changes,
+		"summary":            report.Summary,
+		"recommendations":    report.Recommendations,
+		"metadata":           BuildResponseMetadata(cap, time.Now()),
+	}
+	if report.Baseline != nil {
+		response["baseline"] = report.Baseline
+	} else {
+		response["hint"] = "No baseline stored for this origin. Call observe(what='third_party_audit', update_baseline=true) to snapshot the current scripts for change detection."
+	}
+	summary := fmt.Sprintf("Third-party audit: %d script(s) from %d origin(s), %d unpinned, %d change(s)",
+		report.Summary.TotalScripts, report.Summary.Origins, report.Summary.Unpinned, report.Summary.Changes)
+	return mcp.Succeed(req, summary, response)
+}
@@ -15,10 +15,14 @@ import (
 )
 
 type timelineEntry struct {
-	Timestamp string `json:"timestamp"`
-	Type      string `json:"type"`
-	Summary   string `json:"summary"`
-	Data      any    `json:"data,omitempty"`
+	Timestamp string   `json:"timestamp"`
+	Type      string   `json:"type"`
+	Summary   string   `json:"summary"`
+	Test      string   `json:"test,omitempty"`   // test running when the entry was captured (from POST /test-events)
+	Bundle    string   `json:"bundle,omitempty"` // errors only: current, stale, building, or build_failed relative to dev-server rebuilds
+	TabID     int      `json:"tab_id,omitempty"` // browser tab the source entry came from
+	Data      any      `json:"data,omitempty"`
+	testIDs   []string // test boundaries the source entry was tagged with, for boundary=
 }
 
 type timelineIncludes struct {
@@ -26,11 +30,14 @@ type timelineIncludes struct {
 	errors  bool
 	network bool
 	ws      bool
+	tests   bool
+	builds  bool
+	edits   bool
 }
 
 func parseTimelineIncludes(include []string) timelineIncludes {
 	if len(include) == 0 {
-		return timelineIncludes{actions: true, errors: true, network: true, ws: true}
+		return timelineIncludes{actions: true, errors: true, network: true, ws: true, tests: true, builds: true, edits: true}
 	}
 	var inc timelineIncludes
 	for _, v := range include {
@@ -43,6 +50,12 @@ func parseTimelineIncludes(include []string) timelineIncludes {
 			inc.network = true
 		case "websocket":
 			inc.ws = true
+		case "tests":
+			inc.tests = true
+		case "builds":
+			inc.builds = true
+		case "edits":
+			inc.edits = true
 		}
 	}
 	return inc
@@ -51,9 +64,12 @@ func parseTimelineIncludes(include []string) timelineIncludes {
 // GetSessionTimeline returns a merged, time-sorted timeline of all captured events.
 func GetSessionTimeline(deps Deps, req mcp.JSONRPCRequest, args json.RawMessage) mcp.JSONRPCResponse {
 	var params struct {
-		Limit   int      `json:"limit"`
-		Include []string `json:"include"`
-		Summary bool     `json:"summary"`
+		Limit    int      `json:"limit"`
+		Include  []string `json:"include"`
+		Summary  bool     `json:"summary"`
+		TestID   string   `json:"test_id"`
+		Boundary string   `json:"boundary"`
+		TabID    int      `json:"tab_id"`
 	}
 	mcp.LenientUnmarshal(args, &params)
 	if params.Limit <= 0 {
@@ -65,6 +81,26 @@ func GetSessionTimeline(deps Deps, req mcp.JSONRPCRequest, args json.RawMessage)
 
 	inc := parseTimelineIncludes(params.Include)
 	entries := collectTimelineEntries(deps, inc)
+	impacts := correlateTimelineEdits(deps, time.Now())
+	if inc.edits {
+		entries = append(entries, collectTimelineEdits(impacts)...)
+	}
+	tests := deps.GetCapture().TestEvents()
+	attributeTimelineTests(entries, tests.Locator(time.Now()))
+	builds := deps.GetCapture().BuildEvents().Classifier()
+	labelTimelineBundles(entries, builds)
+	if params.TestID != "" {
+		entries = filterTimelineTest(entries, params.TestID)
+	}
+	if params.Boundary != "" || params.TabID != 0 {
+		kept := entries[:0]
+		for _, e := range entries {
+			if inBoundary(e.testIDs, params.Boundary) && inTab(e.TabID, params.TabID) {
+				kept = append(kept, e)
+			}
+		}
+		entries = kept
+	}
 
 	sort.Slice(entries, func(i, j int) bool {
 		return entries[i].Timestamp > entries[j].Timestamp
@@ -72,7 +108,11 @@ func GetSessionTimeline(deps Deps, req mcp.JSONRPCRequest, args json.RawMessage)
 
 	if params.Summary {
 		summary := buildTimelineSummary(entries)
+		addTimelineTestContext(summary, tests, params.TestID)
+		addTimelineBuildContext(summary, entries, builds)
+		addTimelineEditContext(summary, impacts)
 		summary["metadata"] = BuildResponseMetadata(deps.GetCapture(), time.Now())
+		addBoundaryContext(summary, deps, params.Boundary)
 		return mcp.Succeed(req, "Timeline", summary)
 	}
 
@@ -85,9 +125,13 @@ func GetSessionTimeline(deps Deps, req mcp.JSONRPCRequest, args json.RawMessage)
 		"count":    len(entries),
 		"metadata": BuildResponseMetadata(deps.GetCapture(), time.Now()),
 	}
+	addTimelineTestContext(response, tests, params.TestID)
+	addTimelineBuildContext(response, entries, builds)
+	addTimelineEditContext(response, impacts)
 	if len(entries) == 0 {
 		response["hint"] = timelineEmptyHint()
 	}
+	addBoundaryContext(response, deps, params.Boundary)
 	return mcp.Succeed(req, "Timeline", response)
 }
 
@@ -106,6 +150,12 @@ func collectTimelineEntries(deps Deps, inc timelineIncludes) []timelineEntry {
 	if inc.ws {
 		entries = append(entries, collectTimelineWebSocket(cap.GetAllWebSocketEvents())...)
 	}
+	if inc.tests {
+		entries = append(entries, collectTimelineTests(cap.TestEvents().Events(0))...)
+	}
+	if inc.builds {
+		entries = append(entries, collectTimelineBuilds(cap.BuildEvents().Events(0))...)
+	}
 	return entries
 }
 
@@ -122,6 +172,8 @@ func collectTimelineActions(cap *capture.Store) []timelineEntry {
 			Timestamp: ts,
 			Type:      "action",
 			Summary:   a.Type + " on " + selector,
+			TabID:     a.TabID,
+			testIDs:   a.TestIDs,
 		})
 	}
 	return entries
@@ -144,6 +196,8 @@ func collectTimelineErrors(deps Deps) []timelineEntry {
 			Timestamp: ts,
 			Type:      "error",
 			Summary:   msg,
+			TabID:     logTabID(entry),
+			testIDs:   logTestIDs(entry),
 		})
 	}
 	return entries
@@ -162,6 +216,8 @@ func collectTimelineNetwork(networkEntries []capture.NetworkWaterfallEntry) []ti
 			Timestamp: ts,
 			Type:      "network",
 			Summary:   n.InitiatorType + " " + n.URL,
+			TabID:     n.TabID,
+			testIDs:   n.TestIDs,
 		})
 	}
 	return entries
@@ -178,6 +234,8 @@ func collectTimelineWebSocket(wsEvents []capture.WebSocketEvent) []timelineEntry
 			Timestamp: ws.Timestamp,
 			Type:      "websocket",
 			Summary:   summary,
+			TabID:     ws.TabID,
+			testIDs:   ws.TestIDs,
 		})
 	}
 	return entries
@@ -0,0 +1,95 @@
+// Purpose: Interleaves dev-server rebuilds into the timeline and labels errors by the bundle they ran against.
+// Why: Errors thrown by a bundle that has since been rebuilt are not regressions; agents need to see that at a glance.
+// Docs: docs/features/feature/build-events/index.md
+
+package observe
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/buildevents"
+)
+
+const timelineTypeBuild = "build"
+
+func collectTimelineBuilds(events []buildevents.Event) []timelineEntry {
+	entries := make([]timelineEntry, 0, len(events))
+	for _, ev := range events {
+		entries = append(entries, timelineEntry{
+			Timestamp: ev.Timestamp.Local().Format(time.RFC3339Nano),
+			Type:      timelineTypeBuild,
+			Summary:   buildEventSummary(ev),
+			Data:      ev,
+		})
+	}
+	return entries
+}
+
+func buildEventSummary(ev buildevents.Event) string {
+	var summary string
+	switch {
+	case ev.Type == buildevents.TypeBuildStart:
+		summary = "rebuild started"
+	case ev.Status == buildevents.StatusFailed:
+		summary = "rebuild failed"
+	case ev.Type == buildevents.TypeHMRUpdate:
+		summary = "rebuild finished (hot update)"
+	case ev.Type == buildevents.TypeFullReload:
+		summary = "rebuild finished (full reload)"
+	default:
+		summary = "rebuild finished"
+	}
+	if ev.Tool != "" {
+		summary += " [" + ev.Tool + "]"
+	}
+	if len(ev.Modules) > 0 {
+		summary += ": " + strings.Join(ev.Modules[:min(len(ev.Modules), 3)], ", ")
+		if len(ev.Modules) > 3 {
+			summary += fmt.Sprintf(" +%d more", len(ev.Modules)-3)
+		}
+	}
+	if ev.Error != nil && ev.Error.Message != "" {
+		msg := ev.Error.Message
+		if len(msg) > 80 {
+			msg = msg[:80] + "..."
+		}
+		summary += " — " + msg
+	}
+	return summary
+}
+
+// labelTimelineBundles marks each error with the bundle it ran against (see buildevents.Bundle* labels).
+func labelTimelineBundles(entries []timelineEntry, cls buildevents.Classifier) {
+	if cls.Empty() {
+		return
+	}
+	for i := range entries {
+		if entries[i].Type != "error" {
+			continue
+		}
+		ts, err := time.Parse(time.RFC3339Nano, entries[i].Timestamp)
+		if err != nil {
+			continue
+		}
+		entries[i].Bundle = cls.Bundle(ts)
+	}
+}
+
+// addTimelineBuildContext adds rebuild totals and a hint when errors predate the latest rebuild.
+func addTimelineBuildContext(response map[string]any, entries []timelineEntry, cls buildevents.Classifier) {
+	if cls.Empty() {
+		return
+	}
+	response["builds"] = cls.State()
+	stale := 0
+	for _, e := range entries {
+		if e.Bundle == buildevents.BundleStale || e.Bundle == buildevents.BundleBuildFailed {
+			stale++
+		}
+	}
+	if stale > 0 {
+		response["build_hint"] = fmt.Sprintf("%d error(s) ran against an outdated bundle (bundle=stale or build_failed). Reproduce them on the current build before treating them as regressions.", stale)
+	}
+}
@@ -0,0 +1,95 @@
+// Purpose: Interleaves source edits into the timeline, each judged by the reloads, errors, and vitals that followed it.
+// Why: Lets an agent verify "did my last edit fix or break the page" in one call instead of diffing timelines by hand.
+// Docs: docs/features/feature/watch-mode/index.md
+
+package observe
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/buildevents"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/filechanges"
+)
+
+const timelineTypeEdit = "edit"
+
+// correlateTimelineEdits judges every retained edit against captured errors, reloads, and vitals.
+// Returns nil when no edits were posted.
+func correlateTimelineEdits(deps Deps, now time.Time) []filechanges.Impact {
+	cap := deps.GetCapture()
+	edits := cap.FileChanges().Edits(0)
+	if len(edits) == 0 {
+		return nil
+	}
+
+	obs := filechanges.Observations{Errors: errorSignals(deps)}
+	obs.Reloads = reloadSignals(cap)
+	obs.Vitals = filechanges.VitalsFrom(cap.GetPerformanceSnapshots())
+	return filechanges.Correlate(edits, obs, now)
+}
+
+// reloadSignals lists moments the page picked up new code: successful dev-server rebuilds,
+// navigations, and page loads (one performance snapshot per load).
+func reloadSignals(cap *capture.Store) []filechanges.Signal {
+	var signals []filechanges.Signal
+	for _, ev := range cap.BuildEvents().Events(0) {
+		if !ev.Completes() || ev.Status != buildevents.StatusSuccess {
+			continue
+		}
+		summary := ev.Type
+		if ev.Tool != "" {
+			summary += " [" + ev.Tool + "]"
+		}
+		signals = append(signals, filechanges.Signal{Timestamp: ev.Timestamp, Summary: summary})
+	}
+	for _, a := range cap.GetAllEnhancedActions() {
+		if a.Type != "navigation" && a.Type != "navigate" {
+			continue
+		}
+		signals = append(signals, filechanges.Signal{Timestamp: time.UnixMilli(a.Timestamp), Summary: "navigation " + a.ToURL})
+	}
+	for _, v := range filechanges.VitalsFrom(cap.GetPerformanceSnapshots()) {
+		signals = append(signals, filechanges.Signal{Timestamp: v.Timestamp, Summary: "page load " + v.URL})
+	}
+	return signals
+}
+
+func collectTimelineEdits(impacts []filechanges.Impact) []timelineEntry {
+	entries := make([]timelineEntry, 0, len(impacts))
+	for _, impact := range impacts {
+		entries = append(entries, timelineEntry{
+			Timestamp: impact.Timestamp.Local().Format(time.RFC3339Nano),
+			Type:      timelineTypeEdit,
+			Summary:   editSummary(impact),
+			Data:      impact,
+		})
+	}
+	return entries
+}
+
+func editSummary(impact filechanges.Impact) string {
+	files := impact.Files
+	summary := fmt.Sprintf("edited %d file(s): %s", len(files), strings.Join(files[:min(len(files), 3)], ", "))
+	if len(files) > 3 {
+		summary += fmt.Sprintf(" +%d more", len(files)-3)
+	}
+	return summary + " → " + impact.Verdict
+}
+
+// addTimelineEditContext adds the latest edit's impact and a hint when it broke the page.
+func addTimelineEditContext(response map[string]any, impacts []filechanges.Impact) {
+	if len(impacts) == 0 {
+		return
+	}
+	last := impacts[len(impacts)-1]
+	response["last_edit"] = last
+	switch last.Verdict {
+	case filechanges.VerdictBroke:
+		response["edit_hint"] = fmt.Sprintf("The last edit (%s) was followed by %d new error(s). Inspect new_errors before editing further.", last.EditID, len(last.NewErrors))
+	case filechanges.VerdictAwaitingReload:
+		response["edit_hint"] = "The page has not reloaded since the last edit. Reload it (interact what=refresh) or wait for the dev server before judging the change."
+	}
+}
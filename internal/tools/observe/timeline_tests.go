@@ -0,0 +1,97 @@
+// Purpose: Interleaves test-runner events into the timeline and attributes captured entries to the running test.
+// Why: Lets agents ask "what did the browser do during this failing test" instead of correlating timestamps by hand.
+// Docs: docs/features/feature/test-runner-events/index.md
+
+package observe
+
+import (
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/testevents"
+)
+
+const timelineTypeTest = "test"
+
+func collectTimelineTests(events []testevents.Event) []timelineEntry {
+	entries := make([]timelineEntry, 0, len(events))
+	for _, ev := range events {
+		summary := ev.Type
+		if ev.Status != "" && ev.Type == testevents.TypeTestEnd {
+			summary += " " + ev.Status
+		}
+		if label := testEventLabel(ev); label != "" {
+			summary += ": " + label
+		}
+		if ev.Error != nil && ev.Error.Message != "" {
+			msg := ev.Error.Message
+			if len(msg) > 80 {
+				msg = msg[:80] + "..."
+			}
+			summary += " — " + msg
+		}
+		entries = append(entries, timelineEntry{
+			Timestamp: ev.Timestamp.Local().Format(time.RFC3339Nano),
+			Type:      timelineTypeTest,
+			Summary:   summary,
+			Test:      ev.TestID,
+			Data:      ev,
+		})
+	}
+	return entries
+}
+
+func testEventLabel(ev testevents.Event) string {
+	switch {
+	case ev.TestID != "":
+		return ev.TestID
+	case ev.Suite != "":
+		return ev.Suite
+	default:
+		return ev.Runner
+	}
+}
+
+// attributeTimelineTests tags captured entries with the innermost test running at their timestamp.
+func attributeTimelineTests(entries []timelineEntry, loc testevents.Locator) {
+	if loc.Empty() {
+		return
+	}
+	for i := range entries {
+		if entries[i].Type == timelineTypeTest {
+			continue
+		}
+		ts, err := time.Parse(time.RFC3339Nano, entries[i].Timestamp)
+		if err != nil {
+			continue
+		}
+		if seg, ok := loc.At(ts); ok {
+			entries[i].Test = seg.TestID
+		}
+	}
+}
+
+// filterTimelineTest keeps entries captured during testID plus that test's own events.
+func filterTimelineTest(entries []timelineEntry, testID string) []timelineEntry {
+	kept := entries[:0]
+	for _, e := range entries {
+		if e.Test == testID {
+			kept = append(kept, e)
+		}
+	}
+	return kept
+}
+
+// addTimelineTestContext adds the selected test's segment, or outcome totals when tests were reported.
+func addTimelineTestContext(response map[string]any, store *testevents.Store, testID string) {
+	if testID != "" {
+		if seg, ok := store.Segment(testID); ok {
+			response["test"] = seg
+		} else {
+			response["test_hint"] = "No test events for test_id " + testID + ". Reporters POST lifecycle events to /test-events; omit test_id to see all reported tests."
+		}
+		return
+	}
+	if sum := store.Summarize(); sum.Tests > 0 {
+		response["tests"] = sum
+	}
+}
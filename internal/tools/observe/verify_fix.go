@@ -0,0 +1,282 @@
+// Purpose: Evaluates "did my fix work?" expectations against everything captured since a point in time.
+// Why: Collapses the agent's reload → read errors → read network → read vitals loop into one structured verdict.
+// Docs: docs/features/feature/verify-fix/index.md
+
+package observe
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/capture"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/filechanges"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/pagination"
+)
+
+// Fix verdicts.
+const (
+	FixVerdictFixed        = "fixed"        // every expectation passed
+	FixVerdictNotFixed     = "not_fixed"    // at least one expectation failed
+	FixVerdictInconclusive = "inconclusive" // nothing failed, but the page has not yet shown enough to judge
+)
+
+// Check statuses.
+const (
+	FixCheckPass    = "pass"
+	FixCheckFail    = "fail"
+	FixCheckPending = "pending"
+)
+
+// VerifyFixExpect lists what must hold after the fix. With no fields set,
+// verification expects no errors at all.
+type VerifyFixExpect struct {
+	ErrorClusterIDAbsent string        `json:"error_cluster_id_absent,omitempty"` // fingerprint id (analyze error_clusters / summarized_logs) or message substring
+	RequestSucceeds      string        `json:"request_succeeds,omitempty"`        // URL substring, optionally prefixed by a method: "POST /api/cart"
+	VitalsWithinBudget   *VitalsBudget `json:"vitals_within_budget,omitempty"`
+}
+
+// VitalsBudget bounds page-load metrics; unset fields are not checked.
+type VitalsBudget struct {
+	URL    string   `json:"url,omitempty"` // only page loads whose URL contains this
+	LCPMs  *float64 `json:"lcp_ms,omitempty"`
+	FCPMs  *float64 `json:"fcp_ms,omitempty"`
+	INPMs  *float64 `json:"inp_ms,omitempty"`
+	TTFBMs *float64 `json:"ttfb_ms,omitempty"`
+	LoadMs *float64 `json:"load_ms,omitempty"`
+	CLS    *float64 `json:"cls,omitempty"`
+}
+
+// FixCheck is the outcome of one expectation.
+type FixCheck struct {
+	Check    string `json:"check"`
+	Target   string `json:"target,omitempty"`
+	Status   string `json:"status"`
+	Detail   string `json:"detail"`
+	Evidence any    `json:"evidence,omitempty"`
+}
+
+// FixVerification is the verify_fix result.
+type FixVerification struct {
+	Verdict  string     `json:"verdict"`
+	Since    string     `json:"since"`
+	Activity string     `json:"activity,omitempty"` // first sign the page ran again after since
+	Checks   []FixCheck `json:"checks"`
+}
+
+// ParseVerifySince accepts an RFC3339 timestamp or a pagination cursor ("timestamp:sequence").
+func ParseVerifySince(since string) (time.Time, error) {
+	if ts, err := time.Parse(time.RFC3339Nano, since); err == nil {
+		return ts, nil
+	}
+	cursor, err := pagination.ParseCursor(since)
+	if err != nil || cursor.Timestamp == "" {
+		return time.Time{}, fmt.Errorf("since must be an RFC3339 timestamp or a timestamp cursor, got %q", since)
+	}
+	return time.Parse(time.RFC3339Nano, cursor.Timestamp)
+}
+
+// VerifyFix evaluates expect against captured errors, network bodies, reloads, and vitals after since.
+func VerifyFix(deps Deps, since time.Time, expect VerifyFixExpect) FixVerification {
+	cap := deps.GetCapture()
+	result := FixVerification{Since: since.UTC().Format(time.RFC3339Nano)}
+
+	var activityAt time.Time
+	for _, s := range reloadSignals(cap) {
+		if !s.Timestamp.Before(since) && (activityAt.IsZero() || s.Timestamp.Before(activityAt)) {
+			activityAt, result.Activity = s.Timestamp, s.Summary
+		}
+	}
+	for _, a := range cap.GetAllEnhancedActions() {
+		if ts := time.UnixMilli(a.Timestamp); !ts.Before(since) && (activityAt.IsZero() || ts.Before(activityAt)) {
+			activityAt, result.Activity = ts, a.Type+" action"
+		}
+	}
+	exercised := !activityAt.IsZero()
+
+	errs := errorSignals(deps)
+	if expect.ErrorClusterIDAbsent != "" {
+		result.Checks = append(result.Checks, checkErrorAbsent(errs, since, expect.ErrorClusterIDAbsent, exercised))
+	}
+	if expect.RequestSucceeds != "" {
+		result.Checks = append(result.Checks, checkRequestSucceeds(cap.GetNetworkBodies(), since, expect.RequestSucceeds))
+	}
+	if expect.VitalsWithinBudget != nil {
+		result.Checks = append(result.Checks, CheckVitalsBudget(cap.GetPerformanceSnapshots(), since, *expect.VitalsWithinBudget))
+	}
+	if len(result.Checks) == 0 {
+		result.Checks = append(result.Checks, checkErrorAbsent(errs, since, "", exercised))
+	}
+
+	result.Verdict = FixVerdictFixed
+	for _, c := range result.Checks {
+		switch c.Status {
+		case FixCheckFail:
+			result.Verdict = FixVerdictNotFixed
+		case FixCheckPending:
+			if result.Verdict == FixVerdictFixed {
+				result.Verdict = FixVerdictInconclusive
+			}
+		}
+	}
+	return result
+}
+
+func errorSignals(deps Deps) []filechanges.Signal {
+	logEntries, _ := deps.GetLogEntries()
+	var errs []filechanges.Signal
+	for _, entry := range logEntries {
+		if level, _ := entry["level"].(string); level != "error" {
+			continue
+		}
+		ts, err := time.Parse(time.RFC3339Nano, logEntryTimestamp(entry))
+		if err != nil {
+			continue
+		}
+		msg, _ := entry["message"].(string)
+		errs = append(errs, filechanges.Signal{Timestamp: ts, Summary: msg})
+	}
+	return errs
+}
+
+// checkErrorAbsent passes when no matching error occurred after since and the page has run
+// since then. An empty id matches every error.
+func checkErrorAbsent(errs []filechanges.Signal, since time.Time, id string, exercised bool) FixCheck {
+	check := FixCheck{Check: "error_cluster_id_absent", Target: id}
+	if id == "" {
+		check.Check = "no_errors"
+	}
+	before, after := 0, 0
+	var lastAfter filechanges.Signal
+	for _, e := range errs {
+		if id != "" && fingerprintMessage(e.Summary) != id && !strings.Contains(e.Summary, id) {
+			continue
+		}
+		if e.Timestamp.Before(since) {
+			before++
+			continue
+		}
+		after++
+		if e.Timestamp.After(lastAfter.Timestamp) {
+			lastAfter = e
+		}
+	}
+	evidence := map[string]any{"occurrences_before": before, "occurrences_after": after}
+	check.Evidence = evidence
+	switch {
+	case after > 0:
+		check.Status = FixCheckFail
+		check.Detail = fmt.Sprintf("%d matching error(s) after since; latest: %s", after, truncateDetail(lastAfter.Summary))
+		evidence["last_seen"] = lastAfter.Timestamp.UTC().Format(time.RFC3339Nano)
+	case !exercised:
+		check.Status = FixCheckPending
+		check.Detail = "No reload, navigation, or interaction since; the old code may still be running"
+	default:
+		check.Status = FixCheckPass
+		check.Detail = "No matching errors since the page ran again"
+		if id != "" && before == 0 {
+			check.Detail += " (note: no earlier occurrences matched this id either)"
+		}
+	}
+	return check
+}
+
+// checkRequestSucceeds judges the most recent matching request after since.
+func checkRequestSucceeds(bodies []capture.NetworkBody, since time.Time, target string) FixCheck {
+	check := FixCheck{Check: "request_succeeds", Target: target}
+	method, pattern := "", target
+	if fields := strings.Fields(target); len(fields) == 2 && fields[0] == strings.ToUpper(fields[0]) {
+		method, pattern = fields[0], fields[1]
+	}
+	var latest *capture.NetworkBody
+	var latestAt time.Time
+	matched := 0
+	for i := range bodies {
+		b := &bodies[i]
+		if !strings.Contains(b.URL, pattern) || (method != "" && !strings.EqualFold(b.Method, method)) {
+			continue
+		}
+		ts, err := time.Parse(time.RFC3339Nano, b.Timestamp)
+		if err != nil || ts.Before(since) {
+			continue
+		}
+		matched++
+		if latest == nil || !ts.Before(latestAt) {
+			latest, latestAt = b, ts
+		}
+	}
+	if latest == nil {
+		check.Status = FixCheckPending
+		check.Detail = "No matching request captured since; trigger the flow that sends it"
+		return check
+	}
+	check.Evidence = map[string]any{"matched": matched, "method": latest.Method, "url": latest.URL, "status": latest.Status, "at": latestAt.UTC().Format(time.RFC3339Nano)}
+	if latest.Status >= 200 && latest.Status < 400 {
+		check.Status = FixCheckPass
+		check.Detail = fmt.Sprintf("Latest %s %s returned %d", latest.Method, latest.URL, latest.Status)
+		return check
+	}
+	check.Status = FixCheckFail
+	check.Detail = fmt.Sprintf("Latest %s %s returned %d", latest.Method, latest.URL, latest.Status)
+	return check
+}
+
+// CheckVitalsBudget judges the most recent page load after since against the budget.
+func CheckVitalsBudget(snapshots []capture.PerformanceSnapshot, since time.Time, budget VitalsBudget) FixCheck {
+	check := FixCheck{Check: "vitals_within_budget", Target: budget.URL}
+	var latest *capture.PerformanceSnapshot
+	var latestAt time.Time
+	for i := range snapshots {
+		s := &snapshots[i]
+		if budget.URL != "" && !strings.Contains(s.URL, budget.URL) {
+			continue
+		}
+		ts, err := time.Parse(time.RFC3339Nano, s.Timestamp)
+		if err != nil || ts.Before(since) {
+			continue
+		}
+		if latest == nil || ts.After(latestAt) {
+			latest, latestAt = s, ts
+		}
+	}
+	if latest == nil {
+		check.Status = FixCheckPending
+		check.Detail = "No page load captured since; reload the page to measure vitals"
+		return check
+	}
+
+	measured := map[string]any{"url": latest.URL}
+	var over []string
+	compare := func(name string, limit, value *float64) {
+		if limit == nil || value == nil {
+			return
+		}
+		measured[name] = *value
+		if *value > *limit {
+			over = append(over, fmt.Sprintf("%s %.4g > %.4g", name, *value, *limit))
+		}
+	}
+	t := latest.Timing
+	compare("lcp_ms", budget.LCPMs, t.LargestContentfulPaint)
+	compare("fcp_ms", budget.FCPMs, t.FirstContentfulPaint)
+	compare("inp_ms", budget.INPMs, t.InteractionToNextPaint)
+	compare("ttfb_ms", budget.TTFBMs, &t.TimeToFirstByte)
+	compare("load_ms", budget.LoadMs, &t.Load)
+	compare("cls", budget.CLS, latest.CLS)
+	check.Evidence = measured
+	if len(over) > 0 {
+		check.Status = FixCheckFail
+		check.Detail = "Over budget: " + strings.Join(over, ", ")
+		return check
+	}
+	check.Status = FixCheckPass
+	check.Detail = "All measured metrics within budget"
+	return check
+}
+
+func truncateDetail(s string) string {
+	if len(s) > 120 {
+		return s[:120] + "..."
+	}
+	return s
+}
@@ -0,0 +1,138 @@
+// Purpose: Maps observe modes and their filters onto change-feed deltas for observe(wait_for_new=true).
+// Why: A long-poll should wake only for data the caller's filters would return, not for any buffer append.
+// Docs: docs/features/feature/observe-long-poll/index.md
+
+package observe
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/changefeed"
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/mcp"
+)
+
+// waitMatchers builds the delta predicate for each mode that supports wait_for_new.
+var waitMatchers = map[string]func(args json.RawMessage) func(changefeed.Delta) bool{
+	"errors":           waitErrors,
+	"error_bundles":    waitErrors,
+	"logs":             waitLogs,
+	"network_bodies":   waitNetworkBodies,
+	"websocket_events": waitWebSocketEvents,
+	"actions":          waitKind(changefeed.KindAction),
+	"changes":          waitChanges,
+}
+
+// WaitableModes lists the modes that accept wait_for_new, sorted.
+func WaitableModes() []string {
+	modes := make([]string, 0, len(waitMatchers))
+	for mode := range waitMatchers {
+		modes = append(modes, mode)
+	}
+	sort.Strings(modes)
+	return modes
+}
+
+// WaitMatcher returns the predicate that decides whether a delta is new data for mode
+// under the filters in args. ok is false when mode does not support wait_for_new.
+func WaitMatcher(mode string, args json.RawMessage) (match func(changefeed.Delta) bool, ok bool) {
+	build, ok := waitMatchers[mode]
+	if !ok {
+		return nil, false
+	}
+	return build(args), true
+}
+
+func waitKind(kind changefeed.Kind) func(json.RawMessage) func(changefeed.Delta) bool {
+	return func(json.RawMessage) func(changefeed.Delta) bool {
+		return func(d changefeed.Delta) bool { return d.Kind == kind }
+	}
+}
+
+func waitErrors(json.RawMessage) func(changefeed.Delta) bool {
+	return func(d changefeed.Delta) bool {
+		return d.Kind == changefeed.KindConsole && d.Data["level"] == "error"
+	}
+}
+
+func waitLogs(args json.RawMessage) func(changefeed.Delta) bool {
+	var params struct {
+		Level    string `json:"level"`
+		MinLevel string `json:"min_level"`
+		Source   string `json:"source"`
+	}
+	mcp.LenientUnmarshal(args, &params)
+	if params.MinLevel == "" {
+		params.MinLevel = params.Level
+	}
+	minRank := LogLevelRank(params.MinLevel)
+	return func(d changefeed.Delta) bool {
+		if d.Kind != changefeed.KindConsole {
+			return false
+		}
+		level, _ := d.Data["level"].(string)
+		if minRank >= 0 && LogLevelRank(level) < minRank {
+			return false
+		}
+		return params.Source == "" || d.Data["source"] == params.Source
+	}
+}
+
+func waitNetworkBodies(args json.RawMessage) func(changefeed.Delta) bool {
+	var params struct {
+		URL       string `json:"url"`
+		Method    string `json:"method"`
+		StatusMin int    `json:"status_min"`
+		StatusMax int    `json:"status_max"`
+	}
+	mcp.LenientUnmarshal(args, &params)
+	return func(d changefeed.Delta) bool {
+		if d.Kind != changefeed.KindNetwork {
+			return false
+		}
+		if url, _ := d.Data["url"].(string); params.URL != "" && !strings.Contains(url, params.URL) {
+			return false
+		}
+		if method, _ := d.Data["method"].(string); params.Method != "" && !strings.EqualFold(method, params.Method) {
+			return false
+		}
+		status, _ := d.Data["status"].(int)
+		if params.StatusMin > 0 && status < params.StatusMin {
+			return false
+		}
+		return params.StatusMax <= 0 || status <= params.StatusMax
+	}
+}
+
+func waitWebSocketEvents(args json.RawMessage) func(changefeed.Delta) bool {
+	var params struct {
+		ConnectionID string `json:"connection_id"`
+		Direction    string `json:"direction"`
+	}
+	mcp.LenientUnmarshal(args, &params)
+	return func(d changefeed.Delta) bool {
+		if d.Kind != changefeed.KindWebSocket {
+			return false
+		}
+		if params.ConnectionID != "" && d.Data["id"] != params.ConnectionID {
+			return false
+		}
+		return params.Direction == "" || d.Data["direction"] == params.Direction
+	}
+}
+
+func waitChanges(args json.RawMessage) func(changefeed.Delta) bool {
+	var params struct {
+		Types []string `json:"types"`
+	}
+	mcp.LenientUnmarshal(args, &params)
+	if len(params.Types) == 0 {
+		return func(changefeed.Delta) bool { return true }
+	}
+	kinds := make(map[changefeed.Kind]bool, len(params.Types))
+	for _, t := range params.Types {
+		kinds[changefeed.Kind(t)] = true
+	}
+	return func(d changefeed.Delta) bool { return kinds[d.Kind] }
+}
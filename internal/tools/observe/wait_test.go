@@ -0,0 +1,53 @@
+// Purpose: Tests the change-feed predicates behind observe(wait_for_new=true).
+// Docs: docs/features/feature/observe-long-poll/index.md
+
+package observe
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/changefeed"
+)
+
+func TestWaitMatcher_AppliesModeFilters(t *testing.T) {
+	t.Parallel()
+	errDelta := changefeed.Delta{Kind: changefeed.KindConsole, Data: map[string]any{"level": "error", "source": "console"}}
+	warnDelta := changefeed.Delta{Kind: changefeed.KindConsole, Data: map[string]any{"level": "warn", "source": "network"}}
+	apiDelta := changefeed.Delta{Kind: changefeed.KindNetwork, Data: map[string]any{"method": "POST", "url": "https://api.test/cart", "status": 500}}
+	wsDelta := changefeed.Delta{Kind: changefeed.KindWebSocket, Data: map[string]any{"id": "ws-1", "direction": "incoming"}}
+
+	cases := []struct {
+		mode  string
+		args  string
+		delta changefeed.Delta
+		want  bool
+	}{
+		{"errors", `{}`, errDelta, true},
+		{"errors", `{}`, warnDelta, false},
+		{"logs", `{"min_level":"warn"}`, warnDelta, true},
+		{"logs", `{"level":"error"}`, warnDelta, false},
+		{"logs", `{"source":"console"}`, warnDelta, false},
+		{"network_bodies", `{"url":"/cart","method":"post","status_min":500}`, apiDelta, true},
+		{"network_bodies", `{"status_max":499}`, apiDelta, false},
+		{"network_bodies", `{"url":"/users"}`, apiDelta, false},
+		{"websocket_events", `{"connection_id":"ws-1","direction":"incoming"}`, wsDelta, true},
+		{"websocket_events", `{"direction":"outgoing"}`, wsDelta, false},
+		{"actions", `{}`, errDelta, false},
+		{"changes", `{}`, wsDelta, true},
+		{"changes", `{"types":["network"]}`, wsDelta, false},
+	}
+	for _, tc := range cases {
+		match, ok := WaitMatcher(tc.mode, json.RawMessage(tc.args))
+		if !ok {
+			t.Fatalf("%s should support wait_for_new", tc.mode)
+		}
+		if got := match(tc.delta); got != tc.want {
+			t.Errorf("%s %s on %v = %v, want %v", tc.mode, tc.args, tc.delta.Data, got, tc.want)
+		}
+	}
+
+	if _, ok := WaitMatcher("screenshot", nil); ok {
+		t.Error("screenshot should not support wait_for_new")
+	}
+}
@@ -27,7 +27,38 @@ type WebSocketEvent struct {
 	BinaryFormat     string        `json:"binary_format,omitempty"`   // server-only enrichment
 	FormatConfidence float64       `json:"format_confidence,omitempty"` // server-only enrichment
 	TabID            int           `json:"tab_id,omitempty"`          // Chrome tab ID that produced this event
+	FrameID          int           `json:"frame_id,omitempty"`        // Chrome frame ID within the tab (0 = top frame)
 	TestIDs          []string      `json:"test_ids,omitempty"`        // Test IDs this event belongs to
+	DataEvicted      bool          `json:"data_evicted,omitempty"`    // server-only enrichment: payload shed under memory pressure
+	Decoded          *WSDecoded    `json:"decoded,omitempty"`         // server-only enrichment: payload decoded at read time
+	UnderPressure    bool          `json:"captured_under_pressure,omitempty"` // server-only enrichment: kept from a batch the circuit breaker rejected
+}
+
+// WSDecoded is a WebSocket payload decoded by a codec (json, socket.io, signalr, protobuf).
+type WSDecoded struct {
+	Codec       string `json:"codec"`
+	MessageType string `json:"message_type,omitempty"` // event name, invocation target, packet kind, or protobuf message
+	Value       any    `json:"value,omitempty"`
+}
+
+// SSEEvent is a captured Server-Sent Events (EventSource) lifecycle event or message.
+// Event is open, message, error (the connection gave up), retry (the browser is reconnecting),
+// or close (the page called close()).
+// Wire fields: see WireSSEEvent in wire_sse_event.go
+type SSEEvent struct {
+	Timestamp       string   `json:"ts,omitempty"`
+	Event           string   `json:"event"`
+	ID              string   `json:"id"`
+	URL             string   `json:"url,omitempty"`
+	EventType       string   `json:"event_type,omitempty"`    // SSE "event:" field; "message" when the stream omits it
+	LastEventID     string   `json:"last_event_id,omitempty"` // SSE "id:" field, sent back as Last-Event-ID on reconnect
+	Data            string   `json:"data,omitempty"`
+	Size            int      `json:"size,omitempty"`
+	Truncated       bool     `json:"truncated,omitempty"`
+	WithCredentials bool     `json:"with_credentials,omitempty"`
+	TabID           int      `json:"tab_id,omitempty"`   // Chrome tab ID that produced this event
+	FrameID         int      `json:"frame_id,omitempty"` // Chrome frame ID within the tab (0 = top frame)
+	TestIDs         []string `json:"test_ids,omitempty"` // Test IDs this event belongs to
 }
 
 // SamplingInfo describes the sampling state when a message was captured
@@ -84,6 +115,7 @@ type WebSocketClosedConnection struct {
 		Incoming int `json:"incoming"`
 		Outgoing int `json:"outgoing"`
 	} `json:"total_messages"`
+	SampledDropped int `json:"sampled_dropped,omitempty"` // events dropped by a ws_sampling policy
 }
 
 // WebSocketMessageRate contains rate info for a direction
@@ -120,11 +152,17 @@ type WebSocketSchema struct {
 	Variants     []string `json:"variants,omitempty"`
 }
 
-// WebSocketSamplingStatus describes sampling state
+// WebSocketSamplingStatus describes sampling state. Rate is the extension-reported rate;
+// Policy through Dropped describe the server-side ws_sampling policy in effect.
 type WebSocketSamplingStatus struct {
-	Active bool   `json:"active"`
-	Rate   string `json:"rate,omitempty"`
-	Reason string `json:"reason,omitempty"`
+	Active       bool    `json:"active"`
+	Rate         string  `json:"rate,omitempty"`
+	Reason       string  `json:"reason,omitempty"`
+	Policy       *string `json:"policy,omitempty"`        // url_pattern of the matching policy ("" matches all)
+	AchievedRate float64 `json:"achieved_rate,omitempty"` // kept messages per second over the rate window
+	Seen         int     `json:"seen,omitempty"`
+	Kept         int     `json:"kept,omitempty"`
+	Dropped      int     `json:"dropped,omitempty"`
 }
 
 // ============================================
@@ -144,12 +182,47 @@ type NetworkBody struct {
 	Duration           int               `json:"duration,omitempty"`
 	RequestTruncated   bool              `json:"request_truncated,omitempty"`
 	ResponseTruncated  bool              `json:"response_truncated,omitempty"`
+	ResponseEncoding   string            `json:"response_encoding,omitempty"` // "base64" when response_body holds captured binary bytes
+	ResponseSize       int               `json:"response_size,omitempty"`     // full binary response length in bytes; the capture may hold only its head
+	RequestHeaders     map[string]string `json:"request_headers,omitempty"`
 	ResponseHeaders    map[string]string `json:"response_headers,omitempty"` // server-only enrichment
 	HasAuthHeader      bool              `json:"has_auth_header,omitempty"` // server-only enrichment
 	BinaryFormat       string            `json:"binary_format,omitempty"`   // server-only enrichment
 	FormatConfidence   float64           `json:"format_confidence,omitempty"` // server-only enrichment
 	TabID              int               `json:"tab_id,omitempty"` // Chrome tab ID that produced this request
+	FrameID            int               `json:"frame_id,omitempty"` // Chrome frame ID within the tab (0 = top frame)
 	TestIDs            []string          `json:"test_ids,omitempty"` // Test IDs this entry belongs to
+	RequestID          string            `json:"request_id,omitempty"`    // server-only enrichment
+	FullBodyRef        string            `json:"full_body_ref,omitempty"` // server-only enrichment: set when the complete body is kept past the inline limit
+	BodyEvicted        bool              `json:"body_evicted,omitempty"`  // server-only enrichment: bodies shed under memory pressure
+	BinaryPreview      *BinaryPreview    `json:"binary_preview,omitempty"` // server-only enrichment: summary of a captured binary response
+	UnderPressure      bool              `json:"captured_under_pressure,omitempty"` // server-only enrichment: kept while the circuit breaker was open
+}
+
+// BinaryPreview summarizes a captured binary response so agents can reason about it without the bytes.
+type BinaryPreview struct {
+	Kind          string          `json:"kind"`             // image, protobuf, wasm, or binary
+	Format        string          `json:"format,omitempty"` // image format or detected binary format
+	Width         int             `json:"width,omitempty"`
+	Height        int             `json:"height,omitempty"`
+	Fields        []ProtobufField `json:"fields,omitempty"`   // top-level protobuf fields, in wire order
+	Sections      []WasmSection   `json:"sections,omitempty"` // WASM module sections, in file order
+	CapturedBytes int             `json:"captured_bytes"`
+	HexHead       string          `json:"hex_head"` // first bytes as hex
+}
+
+// ProtobufField is one top-level field of a protobuf message, decoded without a schema.
+type ProtobufField struct {
+	Number   int    `json:"number"`
+	WireType string `json:"wire_type"`        // varint, fixed64, bytes, or fixed32
+	Length   int    `json:"length,omitempty"` // payload length of a bytes field
+}
+
+// WasmSection is one section of a WebAssembly module.
+type WasmSection struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+	Size int    `json:"size"`
 }
 
 // NetworkBodyFilter defines filtering criteria for network bodies
@@ -178,12 +251,15 @@ type NetworkWaterfallEntry struct {
 	EncodedBodySize int       `json:"encoded_body_size"`
 	PageURL         string    `json:"page_url,omitempty"`
 	Timestamp       time.Time `json:"timestamp,omitempty"` // Server-side timestamp
+	TabID           int       `json:"tab_id,omitempty"`    // Chrome tab the waterfall was read from
+	TestIDs         []string  `json:"test_ids,omitempty"`  // Test IDs this entry belongs to
 }
 
 // NetworkWaterfallPayload is POSTed by the extension.
 type NetworkWaterfallPayload struct {
 	Entries []NetworkWaterfallEntry `json:"entries"`
 	PageURL string                  `json:"page_url"`
+	TabID   int                     `json:"tab_id,omitempty"` // tab the entries were read from
 }
 
 // ============================================
@@ -208,11 +284,13 @@ type EnhancedAction struct {
 	SelectedText   string         `json:"selected_text,omitempty"`
 	ScrollY        int            `json:"scroll_y,omitempty"`
 	TabID          int            `json:"tab_id,omitempty"`    // Chrome tab ID that produced this action
+	FrameID        int            `json:"frame_id,omitempty"`  // Chrome frame ID within the tab (0 = top frame)
 	TestIDs        []string       `json:"test_ids,omitempty"` // Test IDs this action belongs to
 	Source         string         `json:"source,omitempty"`         // "human" for user actions, "ai" for AI-driven actions via interact tool
 	Classification string         `json:"classification,omitempty"` // Transient classification: toast, alert, snackbar, notification, tooltip, banner, flash
 	DurationMs     int            `json:"duration_ms,omitempty"`    // Transient visibility duration (ms). MVP: always 0 (removal tracking not yet implemented)
 	Role           string         `json:"role,omitempty"`           // ARIA role of the transient element (e.g., "alert", "status")
+	Locale         string         `json:"locale,omitempty"`         // Page UI language when recorded (<html lang>, else browser language)
 }
 
 // EnhancedActionFilter defines filtering criteria for enhanced actions
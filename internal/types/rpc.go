@@ -0,0 +1,124 @@
+// Purpose: Go mirror of the kaboom.v1.ToolService protobuf contract served over the Connect protocol.
+// Why: Keeps the RPC wire shapes in the zero-dependency types layer so daemon, SDK, and tests share one definition.
+// Docs: docs/features/feature/mcp-persistent-server/index.md
+
+package types
+
+import "encoding/json"
+
+// RPCServiceName is the fully-qualified protobuf service name. Connect routes are
+// POST /<RPCServiceName>/<Method>.
+const RPCServiceName = "kaboom.v1.ToolService"
+
+// RPC method names, in proto declaration order.
+const (
+	RPCMethodListTools = "ListTools"
+	RPCMethodCallTool  = "CallTool"
+	RPCMethodObserve   = "Observe"
+	RPCMethodAnalyze   = "Analyze"
+	RPCMethodGenerate  = "Generate"
+	RPCMethodConfigure = "Configure"
+	RPCMethodInteract  = "Interact"
+)
+
+// RPCMethods lists every method declared on kaboom.v1.ToolService.
+var RPCMethods = []string{
+	RPCMethodListTools,
+	RPCMethodCallTool,
+	RPCMethodObserve,
+	RPCMethodAnalyze,
+	RPCMethodGenerate,
+	RPCMethodConfigure,
+	RPCMethodInteract,
+}
+
+// RPCListToolsRequest mirrors kaboom.v1.ListToolsRequest.
+type RPCListToolsRequest struct{}
+
+// RPCListToolsResponse mirrors kaboom.v1.ListToolsResponse.
+type RPCListToolsResponse struct {
+	Tools []RPCToolDescriptor `json:"tools"`
+}
+
+// RPCToolDescriptor mirrors kaboom.v1.ToolDescriptor.
+type RPCToolDescriptor struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	// any: google.protobuf.Struct carries an arbitrary JSON Schema object
+	InputSchema map[string]any `json:"input_schema,omitempty"`
+}
+
+// RPCCallToolRequest mirrors kaboom.v1.CallToolRequest.
+type RPCCallToolRequest struct {
+	Tool      string          `json:"tool"`
+	Arguments json.RawMessage `json:"arguments,omitempty"`
+}
+
+// RPCToolModeRequest mirrors kaboom.v1.ToolModeRequest. What is merged into
+// Arguments before dispatch and wins over any "what" key already present.
+type RPCToolModeRequest struct {
+	What      string          `json:"what"`
+	Arguments json.RawMessage `json:"arguments,omitempty"`
+}
+
+// RPCContentBlock mirrors kaboom.v1.ContentBlock.
+type RPCContentBlock struct {
+	Type     string `json:"type"`
+	Text     string `json:"text,omitempty"`
+	Data     string `json:"data,omitempty"`
+	MimeType string `json:"mime_type,omitempty"`
+}
+
+// RPCCallToolResponse mirrors kaboom.v1.CallToolResponse.
+type RPCCallToolResponse struct {
+	Content []RPCContentBlock `json:"content"`
+	IsError bool              `json:"is_error,omitempty"`
+	// any: google.protobuf.Struct carries tool-specific metadata
+	Metadata map[string]any `json:"metadata,omitempty"`
+}
+
+// RPCError mirrors kaboom.v1.Error, the Connect error body.
+type RPCError struct {
+	Code    string           `json:"code"`
+	Message string           `json:"message"`
+	Details []RPCErrorDetail `json:"details,omitempty"`
+}
+
+// RPCErrorDetail mirrors kaboom.v1.ErrorDetail. Type names the detail message;
+// Debug carries its fields as JSON, matching Connect's debug detail encoding.
+type RPCErrorDetail struct {
+	Type string `json:"type"`
+	// any: google.protobuf.Struct carries the detail's fields
+	Debug map[string]any `json:"debug,omitempty"`
+}
+
+// RPCErrorDetailRateLimit is the detail type attached to resource_exhausted errors.
+const RPCErrorDetailRateLimit = "kaboom.v1.RateLimitInfo"
+
+// Connect error codes used by the daemon.
+const (
+	RPCCodeInvalidArgument   = "invalid_argument"
+	RPCCodeNotFound          = "not_found"
+	RPCCodePermissionDenied  = "permission_denied"
+	RPCCodeResourceExhausted = "resource_exhausted"
+	RPCCodeUnimplemented     = "unimplemented"
+	RPCCodeInternal          = "internal"
+)
+
+// RPCCodeHTTPStatus returns the HTTP status the Connect protocol assigns to a code.
+func RPCCodeHTTPStatus(code string) int {
+	switch code {
+	case RPCCodeInvalidArgument:
+		return 400
+	case RPCCodeNotFound:
+		return 404
+	case RPCCodePermissionDenied:
+		return 403
+	case RPCCodeResourceExhausted:
+		return 429
+	case RPCCodeUnimplemented:
+		return 501
+	default:
+		return 500
+	}
+}
@@ -0,0 +1,109 @@
+// rpc_test.go — Drift check between proto/kaboom/v1/tools.proto and the Go RPC mirror types.
+package types
+
+import (
+	"os"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+)
+
+var (
+	protoRPCPattern     = regexp.MustCompile(`rpc (\w+)\(`)
+	protoMessagePattern = regexp.MustCompile(`(?s)message (\w+) \{(.*?)\}`)
+	protoJSONNamePat    = regexp.MustCompile(`json_name = "(\w+)"`)
+)
+
+// rpcMessageTypes maps each proto message to its Go mirror.
+var rpcMessageTypes = map[string]reflect.Type{
+	"ListToolsRequest":  reflect.TypeOf(RPCListToolsRequest{}),
+	"ListToolsResponse": reflect.TypeOf(RPCListToolsResponse{}),
+	"ToolDescriptor":    reflect.TypeOf(RPCToolDescriptor{}),
+	"CallToolRequest":   reflect.TypeOf(RPCCallToolRequest{}),
+	"ToolModeRequest":   reflect.TypeOf(RPCToolModeRequest{}),
+	"ContentBlock":      reflect.TypeOf(RPCContentBlock{}),
+	"CallToolResponse":  reflect.TypeOf(RPCCallToolResponse{}),
+	"Error":             reflect.TypeOf(RPCError{}),
+	"ErrorDetail":       reflect.TypeOf(RPCErrorDetail{}),
+}
+
+func readToolsProto(t *testing.T) string {
+	t.Helper()
+	data, err := os.ReadFile("proto/kaboom/v1/tools.proto")
+	if err != nil {
+		t.Fatalf("read proto: %v", err)
+	}
+	return string(data)
+}
+
+func TestRPCProto_MethodsMatchGo(t *testing.T) {
+	t.Parallel()
+	src := readToolsProto(t)
+
+	var protoMethods []string
+	for _, m := range protoRPCPattern.FindAllStringSubmatch(src, -1) {
+		protoMethods = append(protoMethods, m[1])
+	}
+	if !reflect.DeepEqual(protoMethods, RPCMethods) {
+		t.Fatalf("proto rpcs = %v, Go RPCMethods = %v", protoMethods, RPCMethods)
+	}
+	if !strings.Contains(src, "package kaboom.v1;") || !strings.Contains(src, "service ToolService {") {
+		t.Fatalf("proto package/service does not match RPCServiceName %q", RPCServiceName)
+	}
+}
+
+func TestRPCProto_MessageFieldsMatchGoJSONTags(t *testing.T) {
+	t.Parallel()
+	src := readToolsProto(t)
+
+	seen := map[string]bool{}
+	for _, m := range protoMessagePattern.FindAllStringSubmatch(src, -1) {
+		name, body := m[1], m[2]
+		goType, ok := rpcMessageTypes[name]
+		if !ok {
+			t.Errorf("proto message %s has no Go mirror in rpcMessageTypes", name)
+			continue
+		}
+		seen[name] = true
+
+		var protoFields []string
+		for _, f := range protoJSONNamePat.FindAllStringSubmatch(body, -1) {
+			protoFields = append(protoFields, f[1])
+		}
+		var goFields []string
+		for i := 0; i < goType.NumField(); i++ {
+			tag := strings.Split(goType.Field(i).Tag.Get("json"), ",")[0]
+			goFields = append(goFields, tag)
+		}
+		sort.Strings(protoFields)
+		sort.Strings(goFields)
+		if strings.Join(protoFields, ",") != strings.Join(goFields, ",") {
+			t.Errorf("%s: proto fields %v != Go json tags %v", name, protoFields, goFields)
+		}
+	}
+	for name := range rpcMessageTypes {
+		if !seen[name] {
+			t.Errorf("Go mirror for %s has no proto message", name)
+		}
+	}
+}
+
+func TestRPCCodeHTTPStatus(t *testing.T) {
+	t.Parallel()
+	cases := map[string]int{
+		RPCCodeInvalidArgument:   400,
+		RPCCodeNotFound:          404,
+		RPCCodePermissionDenied:  403,
+		RPCCodeResourceExhausted: 429,
+		RPCCodeUnimplemented:     501,
+		RPCCodeInternal:          500,
+		"unknown":                500,
+	}
+	for code, want := range cases {
+		if got := RPCCodeHTTPStatus(code); got != want {
+			t.Errorf("RPCCodeHTTPStatus(%s) = %d, want %d", code, got, want)
+		}
+	}
+}
@@ -45,4 +45,5 @@ type NamedSnapshot struct {
 	NetworkRequests      []SnapshotNetworkRequest `json:"network_requests"`
 	WebSocketConnections []SnapshotWSConnection   `json:"websocket_connections"`
 	Performance          *performance.Snapshot    `json:"performance,omitempty"`
+	SourceControl        *SourceControl           `json:"source_control,omitempty"` // Client's git state when captured
 }
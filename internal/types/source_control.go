@@ -0,0 +1,78 @@
+// Purpose: Defines the git state a client reports for its working directory at registration.
+// Why: Lets snapshots and generated artifacts name the commit they were produced against.
+// Docs: docs/features/feature/source-control-context/index.md
+
+package types
+
+import "time"
+
+// SourceControl is the git branch, commit, and dirty state of a client's working
+// directory, captured once when the client registers with the daemon.
+type SourceControl struct {
+	Branch     string    `json:"branch,omitempty"`
+	Commit     string    `json:"commit"`
+	Dirty      bool      `json:"dirty"`
+	CapturedAt time.Time `json:"captured_at"`
+}
+
+// ShortCommit returns the first 12 characters of the commit hash.
+func (sc SourceControl) ShortCommit() string {
+	if len(sc.Commit) > 12 {
+		return sc.Commit[:12]
+	}
+	return sc.Commit
+}
+
+// SourceControlComparison relates the git state a baseline was captured against to
+// the current state, for diff output.
+type SourceControlComparison struct {
+	Baseline      *SourceControl `json:"baseline,omitempty"`
+	Current       *SourceControl `json:"current,omitempty"`
+	CommitChanged bool           `json:"commit_changed"`
+	Commits       string         `json:"commits"`
+}
+
+// CompareSourceControl returns nil when neither side carries git state.
+func CompareSourceControl(baseline, current *SourceControl) *SourceControlComparison {
+	if baseline == nil && current == nil {
+		return nil
+	}
+	return &SourceControlComparison{
+		Baseline:      baseline,
+		Current:       current,
+		CommitChanged: baseline != nil && current != nil && baseline.Commit != current.Commit,
+		Commits:       DescribeCommits(baseline, current),
+	}
+}
+
+// DescribeCommits renders "baseline at commit abc123 vs current at def456" for diff
+// output. Either side may be nil; a dirty work tree is flagged.
+func DescribeCommits(baseline, current *SourceControl) string {
+	switch {
+	case baseline == nil && current == nil:
+		return ""
+	case baseline == nil:
+		return "baseline commit unknown vs current at " + current.describe()
+	case current == nil:
+		return "baseline at commit " + baseline.describe() + " vs current commit unknown"
+	case baseline.Commit == current.Commit && baseline.Dirty == current.Dirty:
+		return "baseline and current both at commit " + current.describe()
+	default:
+		return "baseline at commit " + baseline.describe() + " vs current at " + current.describe()
+	}
+}
+
+func (sc SourceControl) describe() string {
+	desc := sc.ShortCommit()
+	if sc.Branch != "" {
+		desc += " (" + sc.Branch
+		if sc.Dirty {
+			desc += ", dirty"
+		}
+		return desc + ")"
+	}
+	if sc.Dirty {
+		desc += " (dirty)"
+	}
+	return desc
+}
@@ -20,7 +20,9 @@ type WireEnhancedAction struct {
 	SelectedText  string         `json:"selected_text,omitempty"`
 	ScrollY        int            `json:"scroll_y,omitempty"`
 	TabID          int            `json:"tab_id,omitempty"`
+	FrameID        int            `json:"frame_id,omitempty"`
 	Classification string         `json:"classification,omitempty"`
 	DurationMs     int            `json:"duration_ms,omitempty"`
 	Role           string         `json:"role,omitempty"`
+	Locale         string         `json:"locale,omitempty"`
 }
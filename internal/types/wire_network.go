@@ -6,16 +6,20 @@ package types
 
 // WireNetworkBody is the canonical wire format for captured network request/response bodies.
 type WireNetworkBody struct {
-	Method            string `json:"method"`
-	URL               string `json:"url"`
-	Status            int    `json:"status"`
-	RequestBody       string `json:"request_body,omitempty"`
-	ResponseBody      string `json:"response_body,omitempty"`
-	ContentType       string `json:"content_type,omitempty"`
-	Duration          int    `json:"duration,omitempty"`
-	RequestTruncated  bool   `json:"request_truncated,omitempty"`
-	ResponseTruncated bool   `json:"response_truncated,omitempty"`
-	TabID             int    `json:"tab_id,omitempty"`
+	Method            string            `json:"method"`
+	URL               string            `json:"url"`
+	Status            int               `json:"status"`
+	RequestBody       string            `json:"request_body,omitempty"`
+	ResponseBody      string            `json:"response_body,omitempty"`
+	ContentType       string            `json:"content_type,omitempty"`
+	Duration          int               `json:"duration,omitempty"`
+	RequestTruncated  bool              `json:"request_truncated,omitempty"`
+	ResponseTruncated bool              `json:"response_truncated,omitempty"`
+	ResponseEncoding  string            `json:"response_encoding,omitempty"`
+	ResponseSize      int               `json:"response_size,omitempty"`
+	RequestHeaders    map[string]string `json:"request_headers,omitempty"`
+	TabID             int               `json:"tab_id,omitempty"`
+	FrameID           int               `json:"frame_id,omitempty"`
 }
 
 // WireNetworkWaterfallEntry is the canonical wire format for a PerformanceResourceTiming entry.
@@ -37,4 +41,5 @@ type WireNetworkWaterfallEntry struct {
 type WireNetworkWaterfallPayload struct {
 	Entries []WireNetworkWaterfallEntry `json:"entries"`
 	PageURL string                      `json:"page_url"`
+	TabID   int                         `json:"tab_id,omitempty"`
 }
@@ -0,0 +1,21 @@
+// Purpose: Defines canonical wire schema for Server-Sent Events (EventSource) telemetry payload transport.
+// Why: Prevents SSE event shape drift across extension producers and daemon consumers.
+// Docs: docs/features/feature/sse-tracking/index.md
+
+package types
+
+// WireSSEEvent is the canonical wire format for captured EventSource events.
+type WireSSEEvent struct {
+	Timestamp       string `json:"ts,omitempty"`
+	Event           string `json:"event"`
+	ID              string `json:"id"`
+	URL             string `json:"url,omitempty"`
+	EventType       string `json:"event_type,omitempty"`
+	LastEventID     string `json:"last_event_id,omitempty"`
+	Data            string `json:"data,omitempty"`
+	Size            int    `json:"size,omitempty"`
+	Truncated       bool   `json:"truncated,omitempty"`
+	WithCredentials bool   `json:"with_credentials,omitempty"`
+	TabID           int    `json:"tab_id,omitempty"`
+	FrameID         int    `json:"frame_id,omitempty"`
+}
@@ -16,4 +16,6 @@ type WireWebSocketEvent struct {
 	Size        int    `json:"size,omitempty"`
 	CloseCode   int    `json:"code,omitempty"`
 	CloseReason string `json:"reason,omitempty"`
+	TabID       int    `json:"tab_id,omitempty"`
+	FrameID     int    `json:"frame_id,omitempty"`
 }
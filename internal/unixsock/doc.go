@@ -0,0 +1,14 @@
+// Purpose: Package unixsock — the Unix domain socket transport for the daemon's HTTP endpoint.
+// Why: Security-sensitive setups want no TCP port at all; filesystem permissions then gate access.
+// Docs: docs/features/feature/unix-socket-transport/index.md
+
+/*
+Package unixsock parses --listen unix:/path specs, binds the daemon's socket, and builds
+HTTP clients that dial it.
+
+Key functions:
+  - ParseListen: "unix:/path" to an absolute socket path.
+  - Listen: binds the socket owner-only, replacing a stale socket but never a live one.
+  - HTTPClient / Alive: clients and health probes that dial the socket instead of TCP.
+*/
+package unixsock
@@ -0,0 +1,108 @@
+// Purpose: Tests for unix: listen specs, owner-only socket binding, stale socket cleanup, and socket HTTP clients.
+// Docs: docs/features/feature/unix-socket-transport/index.md
+
+package unixsock
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestParseListen(t *testing.T) {
+	t.Parallel()
+	if path, err := ParseListen(""); err != nil || path != "" {
+		t.Fatalf("empty spec: path=%q err=%v", path, err)
+	}
+	if path, err := ParseListen("unix:/tmp/kaboom.sock"); err != nil || path != filepath.Clean("/tmp/kaboom.sock") {
+		t.Fatalf("absolute spec: path=%q err=%v", path, err)
+	}
+	if path, err := ParseListen("unix:run/kaboom.sock"); err != nil || !filepath.IsAbs(path) {
+		t.Fatalf("relative spec must resolve to an absolute path: path=%q err=%v", path, err)
+	}
+	for _, bad := range []string{"tcp:127.0.0.1:7890", "/tmp/kaboom.sock", "unix:"} {
+		if _, err := ParseListen(bad); err == nil {
+			t.Errorf("ParseListen(%q) = nil error, want error", bad)
+		}
+	}
+}
+
+// socketPath returns a short socket path; sun_path is limited to ~104 bytes on macOS.
+func socketPath(t *testing.T) string {
+	t.Helper()
+	dir, err := os.MkdirTemp("", "ks")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+	return filepath.Join(dir, "sub", "k.sock")
+}
+
+func TestListenServeAndReplaceStale(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("socket permissions are POSIX-only")
+	}
+	t.Parallel()
+	path := socketPath(t)
+
+	ln, err := Listen(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != 0o600 {
+		t.Errorf("socket mode = %o, want 600", perm)
+	}
+
+	srv := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Host != "localhost" {
+			t.Errorf("Host = %q, want localhost", r.Host)
+		}
+		w.WriteHeader(http.StatusOK)
+	})}
+	go func() { _ = srv.Serve(ln) }()
+	if !WaitAlive(path, 2*time.Second) {
+		t.Fatal("daemon on socket not reachable")
+	}
+	if _, err := Listen(path); err == nil {
+		t.Fatal("a live socket must not be replaced")
+	}
+	_ = srv.Close()
+
+	// Leave a stale socket file behind, as a crashed daemon would.
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		stale, err := Listen(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ul, ok := stale.(interface{ SetUnlinkOnClose(bool) }); ok {
+			ul.SetUnlinkOnClose(false)
+		}
+		_ = stale.Close()
+	}
+	if Alive(path, 200*time.Millisecond) {
+		t.Fatal("closed socket must not answer")
+	}
+	again, err := Listen(path)
+	if err != nil {
+		t.Fatalf("stale socket was not replaced: %v", err)
+	}
+	_ = again.Close()
+}
+
+func TestListenRefusesNonSocket(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "k.sock")
+	if err := os.WriteFile(path, []byte("not a socket"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Listen(path); err == nil {
+		t.Fatal("a regular file must not be removed or replaced")
+	}
+}
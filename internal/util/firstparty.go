@@ -0,0 +1,101 @@
+// Purpose: Classifies hosts as first- or third-party using page hosts, eTLD+1 grouping, and configured suffix rules.
+// Why: api.myapp.com and cdn.myapp.com are the same party as www.myapp.com; every auditor must agree on that.
+// Docs: docs/features/feature/enterprise-audit/index.md
+
+package util
+
+import (
+	"net"
+	"net/url"
+	"strings"
+)
+
+// RegistrableDomain returns the eTLD+1 of host under the Public Suffix List, e.g.
+// "api.shop.co.uk" -> "shop.co.uk" and "a.bucket.s3.amazonaws.com" -> "bucket.s3.amazonaws.com".
+// IP addresses, single-label hosts (localhost), and bare public suffixes are returned unchanged.
+func RegistrableDomain(host string) string {
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	if host == "" || net.ParseIP(host) != nil || !strings.Contains(host, ".") {
+		return host
+	}
+	labels := strings.Split(host, ".")
+	suffixStart := publicSuffixStart(labels)
+	if suffixStart == 0 {
+		return host
+	}
+	return strings.Join(labels[suffixStart-1:], ".")
+}
+
+// FirstPartyRules decides whether a URL belongs to the same party as the page under test.
+//
+// A host is first-party when it equals a seed host, shares a seed host's registrable
+// domain (eTLD+1), or matches a configured suffix rule. Ports are ignored, so a dev
+// server on localhost:3000 calling an API on localhost:8080 stays first-party.
+type FirstPartyRules struct {
+	hosts    map[string]bool
+	domains  map[string]bool
+	suffixes []string
+}
+
+// NewFirstPartyRules builds rules from seed URLs (page URLs or explicit first-party origins)
+// and suffix rules. Suffixes match the domain itself and every subdomain; "myapp.com",
+// ".myapp.com", and "*.myapp.com" are equivalent. Scheme-qualified suffixes are accepted.
+func NewFirstPartyRules(seedURLs []string, suffixes []string) FirstPartyRules {
+	rules := FirstPartyRules{hosts: map[string]bool{}, domains: map[string]bool{}}
+	for _, seed := range seedURLs {
+		host := hostOf(seed)
+		if host == "" {
+			continue
+		}
+		rules.hosts[host] = true
+		rules.domains[RegistrableDomain(host)] = true
+	}
+	for _, s := range suffixes {
+		s = strings.ToLower(strings.TrimSpace(s))
+		if strings.Contains(s, "://") {
+			s = hostOf(s)
+		}
+		s = strings.TrimSuffix(strings.TrimLeft(strings.TrimPrefix(s, "*"), "."), ".")
+		if s != "" {
+			rules.suffixes = append(rules.suffixes, s)
+		}
+	}
+	return rules
+}
+
+// Empty reports whether the rules have no seeds or suffixes, i.e. nothing can be classified.
+func (r FirstPartyRules) Empty() bool {
+	return len(r.hosts) == 0 && len(r.suffixes) == 0
+}
+
+// IsFirstPartyURL reports whether rawURL's host is first-party. Unparseable URLs are not.
+func (r FirstPartyRules) IsFirstPartyURL(rawURL string) bool {
+	return r.IsFirstPartyHost(hostOf(rawURL))
+}
+
+// IsFirstPartyHost reports whether host (without port) is first-party.
+func (r FirstPartyRules) IsFirstPartyHost(host string) bool {
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	if host == "" {
+		return false
+	}
+	if r.hosts[host] || r.domains[RegistrableDomain(host)] {
+		return true
+	}
+	for _, s := range r.suffixes {
+		if host == s || strings.HasSuffix(host, "."+s) {
+			return true
+		}
+	}
+	return false
+}
+
+// hostOf returns the lowercased hostname of rawURL, unwrapping blob: URLs.
+func hostOf(rawURL string) string {
+	rawURL = strings.TrimPrefix(rawURL, "blob:")
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	return strings.ToLower(parsed.Hostname())
+}
@@ -0,0 +1,81 @@
+// Purpose: Tests eTLD+1 grouping and first-party suffix rules.
+// Docs: docs/features/feature/enterprise-audit/index.md
+
+package util
+
+import "testing"
+
+func TestRegistrableDomain(t *testing.T) {
+	t.Parallel()
+	cases := map[string]string{
+		"api.myapp.com":                     "myapp.com",
+		"myapp.com":                         "myapp.com",
+		"CDN.MyApp.com.":                    "myapp.com",
+		"api.shop.co.uk":                    "shop.co.uk",
+		"co.uk":                             "co.uk",
+		"alice.github.io":                   "alice.github.io",
+		"preview.app.vercel.app":            "app.vercel.app",
+		"bucket.s3.amazonaws.com":           "bucket.s3.amazonaws.com",
+		"img.bucket.s3.amazonaws.com":       "bucket.s3.amazonaws.com",
+		"s3.amazonaws.com":                  "s3.amazonaws.com",
+		"ec2-1-2-3-4.compute.amazonaws.com": "ec2-1-2-3-4.compute.amazonaws.com",
+		"www.alice.pythonanywhere.com":      "alice.pythonanywhere.com",
+		"sklep.shop.com.pl":                 "shop.com.pl",
+		"a.b.example.ck":                    "b.example.ck",
+		"www.ck":                            "www.ck",
+		"cdn.www.ck":                        "www.ck",
+		"xn--aroport-bya.ci":                "xn--aroport-bya.ci",
+		"x.example.unlistedtld":             "example.unlistedtld",
+		"localhost":                         "localhost",
+		"127.0.0.1":                         "127.0.0.1",
+		"::1":                               "::1",
+	}
+	for host, want := range cases {
+		if got := RegistrableDomain(host); got != want {
+			t.Errorf("RegistrableDomain(%q) = %q, want %q", host, got, want)
+		}
+	}
+}
+
+func TestFirstPartyRules(t *testing.T) {
+	t.Parallel()
+	rules := NewFirstPartyRules([]string{"https://www.myapp.com/checkout", "http://localhost:3000/"}, []string{"*.myapp-cdn.net", "https://static.partner.io"})
+	cases := map[string]bool{
+		"https://api.myapp.com/v1/cart":        true,
+		"https://cdn.myapp.com/app.js":         true,
+		"http://localhost:8080/api":            true,
+		"https://img.myapp-cdn.net/hero.png":   true,
+		"https://myapp-cdn.net/x":              true,
+		"https://static.partner.io/lib.js":     true,
+		"https://other.partner.io/lib.js":      false,
+		"https://notmyapp.com/":                false,
+		"https://myapp.com.evil.io/":           false,
+		"https://www.google-analytics.com/g":   false,
+		"blob:https://www.myapp.com/3f1c-uuid": true,
+		"data:text/plain,hi":                   false,
+	}
+	for rawURL, want := range cases {
+		if got := rules.IsFirstPartyURL(rawURL); got != want {
+			t.Errorf("IsFirstPartyURL(%q) = %v, want %v", rawURL, got, want)
+		}
+	}
+
+	shared := NewFirstPartyRules([]string{"https://alice.github.io/"}, nil)
+	if shared.IsFirstPartyURL("https://bob.github.io/") {
+		t.Error("different github.io sites must not be grouped as first-party")
+	}
+	bucket := NewFirstPartyRules([]string{"https://a.s3.amazonaws.com/index.html"}, nil)
+	if bucket.IsFirstPartyURL("https://evil.s3.amazonaws.com/x.js") {
+		t.Error("different S3 buckets must not be grouped as first-party")
+	}
+	if !bucket.IsFirstPartyURL("https://assets.a.s3.amazonaws.com/x.js") {
+		t.Error("subdomains of the page's own bucket should stay first-party")
+	}
+	tenant := NewFirstPartyRules([]string{"https://alice.pythonanywhere.com/"}, nil)
+	if tenant.IsFirstPartyURL("https://bob.pythonanywhere.com/") {
+		t.Error("private-suffix tenants must not be grouped as first-party")
+	}
+	if !NewFirstPartyRules(nil, nil).Empty() || shared.Empty() {
+		t.Error("Empty() misreports seeded rules")
+	}
+}
@@ -0,0 +1,67 @@
+// media_annotate.go — Blacks out redacted regions and outlines highlighted elements on screenshots.
+package util
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+)
+
+// highlightColor outlines highlighted elements; chosen to stand out on both light and dark UIs.
+var highlightColor = color.RGBA{R: 255, G: 59, B: 48, A: 255}
+
+// highlightStroke is the outline width in CSS pixels.
+const highlightStroke = 3
+
+// AnnotateImage paints each redact region solid black and draws an outline around each
+// highlight region, then re-encodes in the input format. Regions use the same CSS-pixel
+// coordinates as CropImage. Redaction is applied first so an outline never reveals a
+// redacted pixel. Regions entirely outside the image are skipped and not counted.
+func AnnotateImage(data []byte, redact, highlight []ImageClip) (out []byte, mimeType string, redacted, highlighted int, err error) {
+	img, format, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", 0, 0, fmt.Errorf("decode screenshot: %w", err)
+	}
+	bounds := img.Bounds()
+	canvas := image.NewRGBA(bounds)
+	draw.Draw(canvas, bounds, img, bounds.Min, draw.Src)
+
+	for _, region := range redact {
+		rect := region.pixelRect(bounds)
+		if rect.Empty() {
+			continue
+		}
+		draw.Draw(canvas, rect, image.Black, image.Point{}, draw.Src)
+		redacted++
+	}
+
+	stroke := image.NewUniform(highlightColor)
+	for _, region := range highlight {
+		rect := region.pixelRect(bounds)
+		if rect.Empty() {
+			continue
+		}
+		width := int(math.Max(1, math.Round(highlightStroke*region.scale(bounds.Dx()))))
+		// The frame sits just outside the element; at an image edge it moves inside instead.
+		outer := rect.Inset(-width).Intersect(bounds)
+		inner := outer.Inset(width)
+		for _, edge := range []image.Rectangle{
+			image.Rect(outer.Min.X, outer.Min.Y, outer.Max.X, inner.Min.Y),
+			image.Rect(outer.Min.X, inner.Max.Y, outer.Max.X, outer.Max.Y),
+			image.Rect(outer.Min.X, inner.Min.Y, inner.Min.X, inner.Max.Y),
+			image.Rect(inner.Max.X, inner.Min.Y, outer.Max.X, inner.Max.Y),
+		} {
+			draw.Draw(canvas, edge, stroke, image.Point{}, draw.Src)
+		}
+		highlighted++
+	}
+
+	out, mimeType, err = encodeImageAs(canvas, format)
+	if err != nil {
+		return nil, "", 0, 0, fmt.Errorf("encode annotated screenshot: %w", err)
+	}
+	return out, mimeType, redacted, highlighted, nil
+}
@@ -0,0 +1,54 @@
+// media_annotate_test.go — Tests for screenshot redaction and highlight annotation.
+
+package util
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"testing"
+)
+
+func TestAnnotateImage_RedactsAndHighlights(t *testing.T) {
+	t.Parallel()
+	// CSS box (50,20) 20x10 at 2x covers the red device-pixel box (100,40) 40x20.
+	box := ImageClip{X: 50, Y: 20, Width: 20, Height: 10, ViewportWidth: 100}
+	out, mimeType, redacted, highlighted, err := AnnotateImage(testScreenshot(t, "png"),
+		[]ImageClip{box},
+		[]ImageClip{{X: 10, Y: 10, Width: 10, Height: 10, ViewportWidth: 100}, {X: 500, Y: 500, Width: 5, Height: 5}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mimeType != "image/png" || redacted != 1 || highlighted != 1 {
+		t.Fatalf("mime=%s redacted=%d highlighted=%d, want image/png 1 1 (off-image box skipped)", mimeType, redacted, highlighted)
+	}
+	img, err := png.Decode(bytes.NewReader(out))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range []image.Point{{100, 40}, {139, 59}} {
+		if r, g, b, _ := img.At(p.X, p.Y).RGBA(); r != 0 || g != 0 || b != 0 {
+			t.Fatalf("pixel %v = %d,%d,%d, want black", p, r>>8, g>>8, b>>8)
+		}
+	}
+	// The 6px (3 CSS px at 2x) frame sits outside the highlighted box at (20,20)-(40,40).
+	if r, g, b, _ := img.At(17, 30).RGBA(); r>>8 != 255 || g>>8 != 59 || b>>8 != 48 {
+		t.Fatalf("frame pixel = %d,%d,%d, want highlight color", r>>8, g>>8, b>>8)
+	}
+	if r, g, b, _ := img.At(30, 30).RGBA(); r>>8 != 255 || g>>8 != 255 || b>>8 != 255 {
+		t.Fatalf("pixel inside highlight = %d,%d,%d, want untouched white", r>>8, g>>8, b>>8)
+	}
+}
+
+func TestAnnotateImage_HighlightAtEdgeStaysVisible(t *testing.T) {
+	t.Parallel()
+	out, _, _, highlighted, err := AnnotateImage(testScreenshot(t, "png"), nil,
+		[]ImageClip{{X: 0, Y: 0, Width: 200, Height: 100}})
+	if err != nil || highlighted != 1 {
+		t.Fatalf("highlighted=%d err=%v", highlighted, err)
+	}
+	img, _ := png.Decode(bytes.NewReader(out))
+	if r, g, _, _ := img.At(0, 50).RGBA(); r>>8 != 255 || g>>8 != 59 {
+		t.Fatal("full-image highlight should draw its frame inside the image edge")
+	}
+}
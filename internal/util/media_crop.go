@@ -0,0 +1,97 @@
+// media_crop.go — Crops captured screenshots to an element's bounding box.
+package util
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/draw"
+	"image/jpeg"
+	"image/png"
+	"math"
+)
+
+// ImageClip is an element's viewport rectangle in CSS pixels, as measured by the extension.
+type ImageClip struct {
+	X                float64 `json:"x"`
+	Y                float64 `json:"y"`
+	Width            float64 `json:"width"`
+	Height           float64 `json:"height"`
+	ViewportWidth    float64 `json:"viewport_width,omitempty"`
+	DevicePixelRatio float64 `json:"device_pixel_ratio,omitempty"`
+}
+
+// ImageDimensions reads the pixel size of an encoded PNG or JPEG without decoding it.
+func ImageDimensions(data []byte) (width, height int, err error) {
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return 0, 0, err
+	}
+	return cfg.Width, cfg.Height, nil
+}
+
+// CropImage crops an encoded PNG or JPEG to clip and re-encodes it in the same format.
+// CSS pixels are scaled by the captured width over ViewportWidth, falling back to
+// DevicePixelRatio. The rectangle is clamped to the image; an empty result is an error.
+func CropImage(data []byte, clip ImageClip) (out []byte, mimeType string, width, height int, err error) {
+	img, format, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", 0, 0, fmt.Errorf("decode screenshot: %w", err)
+	}
+	bounds := img.Bounds()
+	rect := clip.pixelRect(bounds)
+	if rect.Empty() {
+		return nil, "", 0, 0, errors.New("element is outside the captured viewport")
+	}
+
+	var cropped image.Image
+	if sub, ok := img.(interface {
+		SubImage(image.Rectangle) image.Image
+	}); ok {
+		cropped = sub.SubImage(rect)
+	} else {
+		rgba := image.NewRGBA(image.Rect(0, 0, rect.Dx(), rect.Dy()))
+		draw.Draw(rgba, rgba.Bounds(), img, rect.Min, draw.Src)
+		cropped = rgba
+	}
+
+	out, mimeType, err = encodeImageAs(cropped, format)
+	if err != nil {
+		return nil, "", 0, 0, fmt.Errorf("encode cropped screenshot: %w", err)
+	}
+	return out, mimeType, rect.Dx(), rect.Dy(), nil
+}
+
+// scale returns image pixels per CSS pixel for a capture imageWidth pixels wide.
+func (c ImageClip) scale(imageWidth int) float64 {
+	switch {
+	case c.ViewportWidth > 0:
+		return float64(imageWidth) / c.ViewportWidth
+	case c.DevicePixelRatio > 0:
+		return c.DevicePixelRatio
+	}
+	return 1
+}
+
+// pixelRect converts the clip to image pixels, rounded outward and clamped to bounds.
+func (c ImageClip) pixelRect(bounds image.Rectangle) image.Rectangle {
+	scale := c.scale(bounds.Dx())
+	return image.Rect(
+		bounds.Min.X+int(math.Floor(c.X*scale)),
+		bounds.Min.Y+int(math.Floor(c.Y*scale)),
+		bounds.Min.X+int(math.Ceil((c.X+c.Width)*scale)),
+		bounds.Min.Y+int(math.Ceil((c.Y+c.Height)*scale)),
+	).Intersect(bounds)
+}
+
+// encodeImageAs encodes img as PNG when format is "png" and as JPEG otherwise.
+func encodeImageAs(img image.Image, format string) ([]byte, string, error) {
+	var buf bytes.Buffer
+	if format == "png" {
+		err := png.Encode(&buf, img)
+		return buf.Bytes(), "image/png", err
+	}
+	err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: 90})
+	return buf.Bytes(), "image/jpeg", err
+}
@@ -0,0 +1,75 @@
+// media_crop_test.go — Tests for cropping screenshots to an element rectangle.
+
+package util
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+)
+
+// testScreenshot is a 200x100 image, red in the 40x20 device-pixel box at (100,40).
+func testScreenshot(t *testing.T, format string) []byte {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, 200, 100))
+	for y := 0; y < 100; y++ {
+		for x := 0; x < 200; x++ {
+			c := color.RGBA{255, 255, 255, 255}
+			if x >= 100 && x < 140 && y >= 40 && y < 60 {
+				c = color.RGBA{255, 0, 0, 255}
+			}
+			img.Set(x, y, c)
+		}
+	}
+	var buf bytes.Buffer
+	var err error
+	if format == "png" {
+		err = png.Encode(&buf, img)
+	} else {
+		err = jpeg.Encode(&buf, img, &jpeg.Options{Quality: 100})
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestCropImage_ScalesCSSPixelsToCapture(t *testing.T) {
+	t.Parallel()
+	// Viewport is 100 CSS px wide, captured at 2x: the red box is at CSS (50,20) 20x10.
+	out, mime, w, h, err := CropImage(testScreenshot(t, "png"), ImageClip{X: 50, Y: 20, Width: 20, Height: 10, ViewportWidth: 100})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mime != "image/png" || w != 40 || h != 20 {
+		t.Fatalf("crop = %s %dx%d, want image/png 40x20", mime, w, h)
+	}
+	img, err := png.Decode(bytes.NewReader(out))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r, g, _, _ := img.At(img.Bounds().Min.X, img.Bounds().Min.Y).RGBA(); r>>8 != 255 || g>>8 != 0 {
+		t.Fatal("cropped corner should be inside the red box")
+	}
+	if gw, gh, _ := ImageDimensions(out); gw != 40 || gh != 20 {
+		t.Fatalf("ImageDimensions = %dx%d", gw, gh)
+	}
+}
+
+func TestCropImage_ClampsAndRejectsOffscreen(t *testing.T) {
+	t.Parallel()
+	data := testScreenshot(t, "jpeg")
+	_, mime, w, h, err := CropImage(data, ImageClip{X: 180, Y: 90, Width: 50, Height: 50, DevicePixelRatio: 1})
+	if err != nil || mime != "image/jpeg" || w != 20 || h != 10 {
+		t.Fatalf("clamped crop = %s %dx%d, %v; want image/jpeg 20x10", mime, w, h, err)
+	}
+	if _, _, _, _, err := CropImage(data, ImageClip{X: 300, Y: 0, Width: 10, Height: 10}); err == nil {
+		t.Fatal("a rectangle outside the image should fail")
+	}
+	if _, _, _, _, err := CropImage([]byte("not an image"), ImageClip{Width: 1, Height: 1}); err == nil {
+		t.Fatal("undecodable data should fail")
+	}
+}
@@ -0,0 +1,64 @@
+// Purpose: Looks up public suffixes in the embedded Public Suffix List for eTLD+1 grouping.
+// Why: A hand-picked suffix subset groups unrelated tenants (bucket.s3.amazonaws.com, user.pythonanywhere.com) as one party.
+// Docs: docs/features/feature/enterprise-audit/index.md
+
+package util
+
+import (
+	_ "embed"
+	"strings"
+	"sync"
+)
+
+// publicSuffixData is the full Public Suffix List, ICANN and private sections, one rule per
+// line with internationalized labels in punycode. Regenerate it with
+// scripts/generate-public-suffixes.py.
+//
+//go:embed public_suffixes.dat
+var publicSuffixData string
+
+// publicSuffixRules is the parsed list. wildcard holds the parent of each "*.x" rule and
+// exception the name of each "!x" rule.
+type publicSuffixRules struct {
+	exact     map[string]bool
+	wildcard  map[string]bool
+	exception map[string]bool
+}
+
+// loadPublicSuffixes parses publicSuffixData on first use.
+var loadPublicSuffixes = sync.OnceValue(func() publicSuffixRules {
+	rules := publicSuffixRules{exact: map[string]bool{}, wildcard: map[string]bool{}, exception: map[string]bool{}}
+	for _, line := range strings.Split(publicSuffixData, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "" || strings.HasPrefix(line, "//"):
+		case strings.HasPrefix(line, "!"):
+			rules.exception[line[1:]] = true
+		case strings.HasPrefix(line, "*."):
+			rules.wildcard[line[2:]] = true
+		default:
+			rules.exact[line] = true
+		}
+	}
+	return rules
+})
+
+// publicSuffixStart returns the index of the first label of the public suffix of the host
+// split into labels, following the PSL algorithm: an exception rule wins, otherwise the
+// matching rule with the most labels, otherwise the implicit "*" rule (the last label).
+func publicSuffixStart(labels []string) int {
+	rules := loadPublicSuffixes()
+	for i := range labels {
+		name := strings.Join(labels[i:], ".")
+		if rules.exception[name] {
+			return i + 1
+		}
+		if rules.exact[name] {
+			return i
+		}
+		if i+1 < len(labels) && rules.wildcard[strings.Join(labels[i+1:], ".")] {
+			return i
+		}
+	}
+	return len(labels) - 1
+}
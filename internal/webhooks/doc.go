@@ -0,0 +1,26 @@
+// Purpose: Package webhooks — delivers high-signal alerts to outbound HTTP endpoints (Slack, Discord, generic JSON) with retry and backoff.
+// Why: Push notifications only reach connected agents; a team channel or incident bot needs alerts while nobody is attached.
+// Docs: docs/features/feature/alert-webhooks/index.md
+
+/*
+Package webhooks fans a small set of alert events out to registered HTTP
+endpoints. Each webhook has its own bounded queue and delivery goroutine, so a
+slow or dead endpoint never delays the others or the capture paths publishing.
+
+Events:
+  - circuit_opened: the capture circuit breaker opened.
+  - error_cluster: a console error cluster seen for the first time, or one that came back after a clear.
+  - security_finding: a security audit finding that is new or regressed.
+  - perf_regression: a performance finding (e.g. a Web Vital crossing into poor) that is new or regressed.
+
+Formats:
+  - json: the Event as JSON.
+  - slack: an incoming-webhook message ({"text": ...}).
+  - discord: a webhook message ({"content": ...}).
+
+Key types:
+  - Manager: webhook registry; Publish enqueues without blocking.
+  - Event: one alert, rendered per webhook format by Render.
+  - Webhook: a registration plus delivery counters, with the URL reduced to its origin.
+*/
+package webhooks
@@ -0,0 +1,52 @@
+// Purpose: Renders a webhook Event as the request body for each payload format.
+// Why: Slack and Discord reject arbitrary JSON; generic endpoints want the structured event.
+// Docs: docs/features/feature/alert-webhooks/index.md
+
+package webhooks
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// discordContentLimit is Discord's maximum message length.
+const discordContentLimit = 2000
+
+// Render returns the POST body for e in the given format.
+func Render(format string, e Event) ([]byte, error) {
+	switch format {
+	case FormatSlack:
+		return json.Marshal(map[string]string{"text": messageText(e, "*")})
+	case FormatDiscord:
+		text := messageText(e, "**")
+		if runes := []rune(text); len(runes) > discordContentLimit {
+			text = string(runes[:discordContentLimit-1]) + "…"
+		}
+		return json.Marshal(map[string]string{"content": text})
+	case FormatJSON:
+		return json.Marshal(struct {
+			Source string `json:"source"`
+			Event
+		}{Source: "kaboom", Event: e})
+	}
+	return nil, fmt.Errorf("unknown webhook format %q", format)
+}
+
+// messageText is the chat-message form: a severity marker, the bold title, then the detail.
+func messageText(e Event, bold string) string {
+	text := severityMarker(e.Severity) + " " + bold + e.Title + bold + " (" + e.Event + ")"
+	if e.Detail != "" {
+		text += "\n" + e.Detail
+	}
+	return text
+}
+
+func severityMarker(severity string) string {
+	switch severity {
+	case "error":
+		return "🔴"
+	case "warning":
+		return "🟠"
+	}
+	return "🔵"
+}
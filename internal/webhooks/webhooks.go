@@ -0,0 +1,366 @@
+// Purpose: Registers outbound webhooks and delivers published alert events to each with retry and exponential backoff.
+// Why: Publish runs on capture and audit paths, so delivery must be asynchronous, bounded, and isolated per endpoint.
+// Docs: docs/features/feature/alert-webhooks/index.md
+
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Webhook event names accepted by configure(what="add_webhook").
+const (
+	EventCircuitOpened   = "circuit_opened"
+	EventErrorCluster    = "error_cluster"
+	EventSecurityFinding = "security_finding"
+	EventPerfRegression  = "perf_regression"
+
+	// EventAll subscribes a webhook to every event.
+	EventAll = "all"
+)
+
+// AllEvents lists every webhook event in documentation order.
+var AllEvents = []string{EventCircuitOpened, EventErrorCluster, EventSecurityFinding, EventPerfRegression}
+
+// Payload formats.
+const (
+	FormatJSON    = "json"
+	FormatSlack   = "slack"
+	FormatDiscord = "discord"
+)
+
+// Formats lists the accepted payload formats.
+var Formats = []string{FormatJSON, FormatSlack, FormatDiscord}
+
+const (
+	// MaxWebhooks caps registrations per daemon.
+	MaxWebhooks = 10
+	// QueueSize is the number of undelivered events a webhook holds before dropping.
+	QueueSize = 64
+	// MaxAttempts is the number of delivery attempts per event, including the first.
+	MaxAttempts = 4
+	// RetryBase is the delay before the first retry; it doubles on each later retry.
+	RetryBase = time.Second
+	// deliveryTimeout bounds one POST.
+	deliveryTimeout = 5 * time.Second
+)
+
+// Registration errors.
+var (
+	ErrInvalidURL      = errors.New("webhook url must be an absolute http or https URL")
+	ErrUnknownFormat   = fmt.Errorf("unknown webhook format (valid: %s)", strings.Join(Formats, ", "))
+	ErrTooManyWebhooks = fmt.Errorf("webhook limit reached (%d)", MaxWebhooks)
+	ErrUnknownWebhook  = errors.New("unknown webhook id")
+)
+
+// Event is one alert delivered to webhooks.
+type Event struct {
+	Event     string         `json:"event"`
+	Severity  string         `json:"severity"` // error, warning, or info
+	Title     string         `json:"title"`
+	Detail    string         `json:"detail,omitempty"`
+	Timestamp time.Time      `json:"ts"`
+	Data      map[string]any `json:"data,omitempty"`
+}
+
+// Webhook is the public view of a registration. URL is reduced to scheme and host
+// because incoming-webhook URLs carry their secret in the path.
+type Webhook struct {
+	ID             string     `json:"id"`
+	URL            string     `json:"url"`
+	Events         []string   `json:"events"`
+	Format         string     `json:"format"`
+	CreatedAt      time.Time  `json:"created_at"`
+	Delivered      int64      `json:"delivered"`
+	Failed         int64      `json:"failed"`
+	Retries        int64      `json:"retries"`
+	Dropped        int64      `json:"dropped"`
+	LastStatus     int        `json:"last_status,omitempty"`
+	LastError      string     `json:"last_error,omitempty"`
+	LastDeliveryAt *time.Time `json:"last_delivery_at,omitempty"`
+}
+
+// hook is one registration with its queue. Counters in view are guarded by Manager.mu.
+type hook struct {
+	view   Webhook
+	url    string
+	events map[string]bool // nil means every event
+	queue  chan Event
+	cancel context.CancelFunc
+}
+
+// Manager fans events out to registered webhooks.
+//
+// Lock hierarchy: Manager.mu is a leaf lock. Publish may be called from capture
+// callbacks; sends are non-blocking so a stalled endpoint never holds it up.
+type Manager struct {
+	mu        sync.Mutex
+	ctx       context.Context
+	hooks     map[string]*hook
+	order     []string
+	nextID    int64
+	client    *http.Client
+	redact    func(string) string
+	retryBase time.Duration
+}
+
+// New creates a manager whose delivery goroutines stop when ctx is cancelled.
+// redact, when non-nil, scrubs event titles and details before they leave the process.
+func New(ctx context.Context, redact func(string) string) *Manager {
+	return &Manager{
+		ctx:       ctx,
+		hooks:     make(map[string]*hook),
+		client:    &http.Client{Timeout: deliveryTimeout},
+		redact:    redact,
+		retryBase: RetryBase,
+	}
+}
+
+// Add registers a webhook. Empty events or "all" subscribes to every event; an empty
+// format is inferred from the URL (Slack and Discord hosts) and defaults to json.
+func (m *Manager) Add(rawURL string, events []string, format string) (Webhook, error) {
+	u, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return Webhook{}, ErrInvalidURL
+	}
+	normalized, err := NormalizeEvents(events)
+	if err != nil {
+		return Webhook{}, err
+	}
+	if format = strings.ToLower(strings.TrimSpace(format)); format == "" {
+		format = InferFormat(u)
+	} else if !isFormat(format) {
+		return Webhook{}, ErrUnknownFormat
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if len(m.hooks) >= MaxWebhooks {
+		return Webhook{}, ErrTooManyWebhooks
+	}
+	m.nextID++
+	ctx, cancel := context.WithCancel(m.ctx)
+	h := &hook{
+		view: Webhook{
+			ID:        "wh-" + strconv.FormatInt(m.nextID, 10),
+			URL:       u.Scheme + "://" + u.Host,
+			Events:    AllEvents,
+			Format:    format,
+			CreatedAt: time.Now().UTC(),
+		},
+		url:    u.String(),
+		queue:  make(chan Event, QueueSize),
+		cancel: cancel,
+	}
+	if normalized != nil {
+		h.view.Events = normalized
+		h.events = make(map[string]bool, len(normalized))
+		for _, ev := range normalized {
+			h.events[ev] = true
+		}
+	}
+	m.hooks[h.view.ID] = h
+	m.order = append(m.order, h.view.ID)
+	go m.run(ctx, h)
+	return h.view, nil
+}
+
+// Remove unregisters a webhook and stops its delivery goroutine; queued events are discarded.
+func (m *Manager) Remove(id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	h, ok := m.hooks[id]
+	if !ok {
+		return ErrUnknownWebhook
+	}
+	h.cancel()
+	delete(m.hooks, id)
+	for i, other := range m.order {
+		if other == id {
+			m.order = append(m.order[:i], m.order[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+// List returns registrations in creation order with their delivery counters.
+func (m *Manager) List() []Webhook {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	out := make([]Webhook, 0, len(m.order))
+	for _, id := range m.order {
+		out = append(out, m.hooks[id].view)
+	}
+	return out
+}
+
+// Publish queues e for every webhook subscribed to e.Event and returns how many accepted it.
+func (m *Manager) Publish(e Event) int {
+	if m == nil {
+		return 0
+	}
+	if e.Timestamp.IsZero() {
+		e.Timestamp = time.Now().UTC()
+	}
+	if m.redact != nil {
+		e.Title = m.redact(e.Title)
+		e.Detail = m.redact(e.Detail)
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	accepted := 0
+	for _, id := range m.order {
+		h := m.hooks[id]
+		if h.events != nil && !h.events[e.Event] {
+			continue
+		}
+		select {
+		case h.queue <- e:
+			accepted++
+		default:
+			h.view.Dropped++
+		}
+	}
+	return accepted
+}
+
+// run delivers one webhook's events in order until ctx is cancelled.
+func (m *Manager) run(ctx context.Context, h *hook) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case e := <-h.queue:
+			m.deliver(ctx, h, e)
+		}
+	}
+}
+
+// deliver POSTs e, retrying transport errors, 429, and 5xx up to MaxAttempts with
+// exponential backoff. Other statuses are final.
+func (m *Manager) deliver(ctx context.Context, h *hook, e Event) {
+	body, err := Render(h.view.Format, e)
+	if err != nil {
+		m.recordResult(h, 0, err, 0)
+		return
+	}
+	delay := m.retryBase
+	for attempt := 1; ; attempt++ {
+		status, err := m.post(ctx, h.url, body)
+		if err == nil && status >= 200 && status < 300 {
+			m.recordResult(h, status, nil, attempt-1)
+			return
+		}
+		if err == nil {
+			err = fmt.Errorf("endpoint returned HTTP %d", status)
+		}
+		retryable := status == 0 || status == http.StatusTooManyRequests || status >= 500
+		if !retryable || attempt >= MaxAttempts {
+			m.recordResult(h, status, err, attempt-1)
+			return
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+}
+
+// post sends one attempt. status is 0 when the request did not complete.
+func (m *Manager) post(ctx context.Context, target string, body []byte) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "kaboom-webhooks")
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	_ = resp.Body.Close() //nolint:errcheck // only the status matters
+	return resp.StatusCode, nil
+}
+
+func (m *Manager) recordResult(h *hook, status int, err error, retries int) {
+	now := time.Now().UTC()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	h.view.Retries += int64(retries)
+	h.view.LastStatus = status
+	h.view.LastDeliveryAt = &now
+	if err != nil {
+		h.view.Failed++
+		h.view.LastError = err.Error()
+		return
+	}
+	h.view.Delivered++
+	h.view.LastError = ""
+}
+
+// NormalizeEvents validates an event list. Empty input or "all" means every event (nil).
+func NormalizeEvents(events []string) ([]string, error) {
+	seen := map[string]bool{}
+	for _, raw := range events {
+		ev := strings.ToLower(strings.TrimSpace(raw))
+		if ev == EventAll {
+			return nil, nil
+		}
+		if !isEvent(ev) {
+			return nil, fmt.Errorf("unknown webhook event %q (valid: %s, all)", raw, strings.Join(AllEvents, ", "))
+		}
+		seen[ev] = true
+	}
+	if len(seen) == 0 {
+		return nil, nil
+	}
+	out := make([]string, 0, len(seen))
+	for _, ev := range AllEvents {
+		if seen[ev] {
+			out = append(out, ev)
+		}
+	}
+	return out, nil
+}
+
+// InferFormat picks slack or discord from well-known webhook hosts, json otherwise.
+func InferFormat(u *url.URL) string {
+	host := strings.ToLower(u.Hostname())
+	switch {
+	case host == "hooks.slack.com":
+		return FormatSlack
+	case (host == "discord.com" || host == "discordapp.com") && strings.HasPrefix(u.Path, "/api/webhooks/"):
+		return FormatDiscord
+	}
+	return FormatJSON
+}
+
+func isEvent(ev string) bool {
+	for _, known := range AllEvents {
+		if ev == known {
+			return true
+		}
+	}
+	return false
+}
+
+func isFormat(format string) bool {
+	for _, known := range Formats {
+		if format == known {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,179 @@
+// Purpose: Tests webhook registration validation, event filtering, payload formats, and retry with backoff.
+// Docs: docs/features/feature/alert-webhooks/index.md
+
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// recorder is an endpoint that answers with statuses in order (then 200) and records bodies.
+type recorder struct {
+	mu       sync.Mutex
+	statuses []int
+	bodies   []string
+	got      chan string
+}
+
+func newRecorder(statuses ...int) (*recorder, *httptest.Server) {
+	r := &recorder{statuses: statuses, got: make(chan string, 16)}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, _ := io.ReadAll(req.Body)
+		r.mu.Lock()
+		status := http.StatusOK
+		if len(r.statuses) > 0 {
+			status, r.statuses = r.statuses[0], r.statuses[1:]
+		}
+		r.bodies = append(r.bodies, string(body))
+		r.mu.Unlock()
+		w.WriteHeader(status)
+		if status < 300 {
+			r.got <- string(body)
+		}
+	}))
+	return r, srv
+}
+
+func testManager(t *testing.T) *Manager {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	m := New(ctx, func(s string) string { return strings.ReplaceAll(s, "hunter2", "[REDACTED]") })
+	m.retryBase = time.Millisecond
+	return m
+}
+
+func waitBody(t *testing.T, r *recorder) string {
+	t.Helper()
+	select {
+	case body := <-r.got:
+		return body
+	case <-time.After(5 * time.Second):
+		t.Fatal("webhook was not delivered")
+		return ""
+	}
+}
+
+func TestAdd_ValidatesInput(t *testing.T) {
+	t.Parallel()
+	m := testManager(t)
+	if _, err := m.Add("ftp://example.com/hook", nil, ""); err != ErrInvalidURL {
+		t.Errorf("ftp url: err = %v, want ErrInvalidURL", err)
+	}
+	if _, err := m.Add("https://example.com/hook", []string{"page_load"}, ""); err == nil {
+		t.Error("unknown event accepted")
+	}
+	if _, err := m.Add("https://example.com/hook", nil, "xml"); err != ErrUnknownFormat {
+		t.Errorf("xml format: err = %v, want ErrUnknownFormat", err)
+	}
+
+	wh, err := m.Add("https://hooks.slack.com/services/T0/B0/secret", []string{"all"}, "")
+	if err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	if wh.Format != FormatSlack || wh.URL != "https://hooks.slack.com" || len(wh.Events) != len(AllEvents) {
+		t.Errorf("registration = %+v, want slack format, origin-only URL, all events", wh)
+	}
+	wh, _ = m.Add("https://discord.com/api/webhooks/1/secret", nil, "")
+	if wh.Format != FormatDiscord {
+		t.Errorf("discord url inferred %q", wh.Format)
+	}
+	if err := m.Remove("wh-99"); err != ErrUnknownWebhook {
+		t.Errorf("Remove unknown: err = %v", err)
+	}
+}
+
+func TestPublish_FiltersEventsAndRedacts(t *testing.T) {
+	t.Parallel()
+	m := testManager(t)
+	rec, srv := newRecorder()
+	defer srv.Close()
+	if _, err := m.Add(srv.URL, []string{EventSecurityFinding}, FormatJSON); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+
+	if n := m.Publish(Event{Event: EventErrorCluster, Title: "ignored"}); n != 0 {
+		t.Errorf("unsubscribed event accepted by %d webhooks", n)
+	}
+	m.Publish(Event{Event: EventSecurityFinding, Severity: "error", Title: "Token hunter2 in URL"})
+
+	var got map[string]any
+	if err := json.Unmarshal([]byte(waitBody(t, rec)), &got); err != nil {
+		t.Fatalf("body is not JSON: %v", err)
+	}
+	if got["event"] != EventSecurityFinding || got["source"] != "kaboom" || got["title"] != "Token [REDACTED] in URL" {
+		t.Errorf("payload = %v", got)
+	}
+}
+
+func TestDeliver_RetriesServerErrors(t *testing.T) {
+	t.Parallel()
+	m := testManager(t)
+	rec, srv := newRecorder(http.StatusServiceUnavailable, http.StatusTooManyRequests)
+	defer srv.Close()
+	wh, _ := m.Add(srv.URL, nil, FormatSlack)
+
+	m.Publish(Event{Event: EventCircuitOpened, Severity: "warning", Title: "Capture circuit opened"})
+	var slack map[string]string
+	if err := json.Unmarshal([]byte(waitBody(t, rec)), &slack); err != nil || !strings.Contains(slack["text"], "*Capture circuit opened*") {
+		t.Fatalf("slack body = %v (err %v)", slack, err)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		list := m.List()
+		if len(list) == 1 && list[0].Delivered == 1 {
+			if list[0].Retries != 2 || list[0].Failed != 0 || list[0].ID != wh.ID {
+				t.Errorf("counters = %+v, want 1 delivered after 2 retries", list[0])
+			}
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("delivery not recorded: %+v", list)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestDeliver_GivesUpOnClientErrors(t *testing.T) {
+	t.Parallel()
+	m := testManager(t)
+	rec, srv := newRecorder(http.StatusNotFound)
+	defer srv.Close()
+	m.Add(srv.URL, nil, FormatJSON)
+
+	m.Publish(Event{Event: EventPerfRegression, Title: "LCP poor"})
+	deadline := time.Now().Add(5 * time.Second)
+	for m.List()[0].Failed == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("failure not recorded")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	if len(rec.bodies) != 1 || m.List()[0].LastStatus != http.StatusNotFound {
+		t.Errorf("attempts = %d, last status = %d; want one attempt, 404", len(rec.bodies), m.List()[0].LastStatus)
+	}
+}
+
+func TestRender_DiscordTruncates(t *testing.T) {
+	t.Parallel()
+	body, err := Render(FormatDiscord, Event{Event: EventErrorCluster, Severity: "error", Title: "x", Detail: strings.Repeat("a", 3000)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var msg map[string]string
+	_ = json.Unmarshal(body, &msg)
+	if n := len([]rune(msg["content"])); n != discordContentLimit || !strings.HasPrefix(msg["content"], "🔴 **x**") {
+		t.Errorf("discord content length %d, prefix %q", n, msg["content"][:12])
+	}
+}
@@ -0,0 +1,76 @@
+// Purpose: Picks a codec for a WebSocket payload and decodes plain JSON messages.
+// Why: Most socket traffic is JSON with a type discriminator; naming it saves the agent a read of every frame.
+// Docs: docs/features/feature/websocket-decoding/index.md
+
+package wsdecode
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/types"
+)
+
+// Codec names reported in WSDecoded.Codec.
+const (
+	CodecJSON     = "json"
+	CodecSocketIO = "socket.io"
+	CodecSignalR  = "signalr"
+	CodecProtobuf = "protobuf"
+)
+
+// maxDecodeBytes bounds the payloads decoded per call; larger frames are left raw.
+const maxDecodeBytes = 256 * 1024
+
+// jsonTypeKeys are the discriminator keys checked, in order, for a JSON message type.
+var jsonTypeKeys = []string{"type", "event", "op", "action", "method", "kind", "t", "channel"}
+
+// Decode returns the decoded form of a message payload sent on url, or nil when no codec applies.
+func (r *Registry) Decode(url, data string) *types.WSDecoded {
+	if data == "" || len(data) > maxDecodeBytes {
+		return nil
+	}
+	if raw, ok := binaryFrame(data); ok {
+		return r.decodeProtobuf(url, raw)
+	}
+	if decoded := decodeSocketIO(url, data); decoded != nil {
+		return decoded
+	}
+	if decoded := decodeSignalR(data); decoded != nil {
+		return decoded
+	}
+	return decodeJSON(data)
+}
+
+// decodeJSON parses a JSON object or array and names it by its discriminator key.
+func decodeJSON(data string) *types.WSDecoded {
+	trimmed := bytes.TrimSpace([]byte(data))
+	if len(trimmed) == 0 || (trimmed[0] != '{' && trimmed[0] != '[') {
+		return nil
+	}
+	var value any
+	if err := json.Unmarshal(trimmed, &value); err != nil {
+		return nil
+	}
+	return &types.WSDecoded{Codec: CodecJSON, MessageType: jsonMessageType(value), Value: value}
+}
+
+// jsonMessageType returns the first string or number discriminator of an object.
+func jsonMessageType(value any) string {
+	obj, ok := value.(map[string]any)
+	if !ok {
+		return ""
+	}
+	for _, key := range jsonTypeKeys {
+		switch v := obj[key].(type) {
+		case string:
+			if v != "" {
+				return v
+			}
+		case float64:
+			return strconv.FormatFloat(v, 'f', -1, 64)
+		}
+	}
+	return ""
+}
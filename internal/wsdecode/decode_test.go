@@ -0,0 +1,183 @@
+// Purpose: Tests WebSocket payload decoding across the JSON, socket.io, SignalR, and protobuf codecs.
+// Docs: docs/features/feature/websocket-decoding/index.md
+
+package wsdecode
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDecode_JSONMessageType(t *testing.T) {
+	r := NewRegistry()
+	got := r.Decode("wss://a.test/live", `{"op":"subscribe","channel":"prices"}`)
+	if got == nil || got.Codec != CodecJSON || got.MessageType != "subscribe" {
+		t.Fatalf("decoded = %+v, want json/subscribe", got)
+	}
+	if got := r.Decode("wss://a.test/live", `{"type":3}`); got == nil || got.MessageType != "3" {
+		t.Fatalf("numeric discriminator = %+v", got)
+	}
+	if got := r.Decode("wss://a.test/live", "hello"); got != nil {
+		t.Fatalf("plain text decoded as %+v", got)
+	}
+}
+
+func TestDecode_SocketIO(t *testing.T) {
+	r := NewRegistry()
+	got := r.Decode("wss://a.test/socket.io/?EIO=4&transport=websocket", `42/chat,7["message",{"text":"hi"}]`)
+	if got == nil || got.Codec != CodecSocketIO || got.MessageType != "message" {
+		t.Fatalf("event = %+v", got)
+	}
+	value := got.Value.(map[string]any)
+	if value["namespace"] != "/chat" || !reflect.DeepEqual(value["args"], []any{map[string]any{"text": "hi"}}) {
+		t.Fatalf("value = %#v", value)
+	}
+
+	if got := r.Decode("wss://a.test/socket.io/?EIO=4", "2"); got == nil || got.MessageType != "ping" {
+		t.Fatalf("ping = %+v", got)
+	}
+	if got := r.Decode("wss://a.test/other", "2"); got != nil {
+		t.Fatalf("bare digit off a socket.io URL decoded as %+v", got)
+	}
+	// Event packets are recognized without the /socket.io/ path.
+	if got := r.Decode("wss://a.test/ws", `42["join","room-1"]`); got == nil || got.MessageType != "join" {
+		t.Fatalf("event off socket.io URL = %+v", got)
+	}
+	if got := r.Decode("wss://a.test/ws", `42`); got != nil {
+		t.Fatalf("a bare number should not decode as socket.io: %+v", got)
+	}
+}
+
+func TestDecode_SignalR(t *testing.T) {
+	r := NewRegistry()
+	got := r.Decode("wss://a.test/hub", `{"type":1,"target":"ReceiveMessage","arguments":["ann","hi"]}`+"\x1e")
+	if got == nil || got.Codec != CodecSignalR || got.MessageType != "ReceiveMessage" {
+		t.Fatalf("invocation = %+v", got)
+	}
+	got = r.Decode("wss://a.test/hub", `{"type":6}`+"\x1e"+`{"type":3,"invocationId":"1"}`+"\x1e")
+	if got == nil || got.MessageType != "ping" || len(got.Value.([]any)) != 2 {
+		t.Fatalf("batched records = %+v", got)
+	}
+	if got := r.Decode("wss://a.test/hub", `{"protocol":"json","version":1}`+"\x1e"); got == nil || got.MessageType != "handshake" {
+		t.Fatalf("handshake = %+v", got)
+	}
+}
+
+// Minimal protobuf encoding helpers for building descriptor sets and frames.
+
+func pbVarint(b []byte, num int, v uint64) []byte {
+	b = binary.AppendUvarint(b, uint64(num)<<3|wireVarint)
+	return binary.AppendUvarint(b, v)
+}
+
+func pbBytes(b []byte, num int, v []byte) []byte {
+	b = binary.AppendUvarint(b, uint64(num)<<3|wireBytes)
+	b = binary.AppendUvarint(b, uint64(len(v)))
+	return append(b, v...)
+}
+
+func pbField(name string, number, label, typ int, typeName string) []byte {
+	f := pbBytes(nil, 1, []byte(name))
+	f = pbVarint(f, 3, uint64(number))
+	f = pbVarint(f, 4, uint64(label))
+	f = pbVarint(f, 5, uint64(typ))
+	if typeName != "" {
+		f = pbBytes(f, 6, []byte(typeName))
+	}
+	return f
+}
+
+// writeChatDescriptor writes a set for package chat:
+//
+//	message Envelope { int64 id = 1; string text = 2; Kind kind = 3; repeated int32 tags = 4; Author author = 5; }
+//	message Author { string name = 1; }
+//	enum Kind { UNKNOWN = 0; TEXT = 1; }
+func writeChatDescriptor(t *testing.T) string {
+	t.Helper()
+	envelope := pbBytes(nil, 1, []byte("Envelope"))
+	envelope = pbBytes(envelope, 2, pbField("id", 1, 1, typeInt64, ""))
+	envelope = pbBytes(envelope, 2, pbField("text", 2, 1, typeString, ""))
+	envelope = pbBytes(envelope, 2, pbField("kind", 3, 1, typeEnum, ".chat.Kind"))
+	envelope = pbBytes(envelope, 2, pbField("tags", 4, labelRepeated, typeInt32, ""))
+	envelope = pbBytes(envelope, 2, pbField("author", 5, 1, typeMessage, ".chat.Author"))
+	author := pbBytes(nil, 1, []byte("Author"))
+	author = pbBytes(author, 2, pbField("name", 1, 1, typeString, ""))
+	kind := pbBytes(nil, 1, []byte("Kind"))
+	kind = pbBytes(kind, 2, pbVarint(pbBytes(nil, 1, []byte("UNKNOWN")), 2, 0))
+	kind = pbBytes(kind, 2, pbVarint(pbBytes(nil, 1, []byte("TEXT")), 2, 1))
+
+	file := pbBytes(nil, 1, []byte("chat.proto"))
+	file = pbBytes(file, 2, []byte("chat"))
+	file = pbBytes(file, 4, envelope)
+	file = pbBytes(file, 4, author)
+	file = pbBytes(file, 5, kind)
+
+	path := filepath.Join(t.TempDir(), "chat.pb")
+	if err := os.WriteFile(path, pbBytes(nil, 1, file), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestRegistry_DecodesRegisteredProtobufFrames(t *testing.T) {
+	r := NewRegistry()
+	path := writeChatDescriptor(t)
+	if _, err := r.Register(path, "", ""); err == nil || !strings.Contains(err.Error(), "chat.Envelope") {
+		t.Fatalf("ambiguous set should list candidates, got %v", err)
+	}
+	reg, err := r.Register(path, "Envelope", "/feed")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reg.Message != "chat.Envelope" || reg.MessageTypes != 2 {
+		t.Fatalf("registration = %+v", reg)
+	}
+
+	frame := pbVarint(nil, 1, 42)
+	frame = pbBytes(frame, 2, []byte("hi"))
+	frame = pbVarint(frame, 3, 1)
+	frame = pbBytes(frame, 4, []byte{1, 2, 3}) // packed
+	frame = pbBytes(frame, 5, pbBytes(nil, 1, []byte("ann")))
+	frame = pbVarint(frame, 9, 7) // not in the descriptor
+	payload := fmt.Sprintf("[Binary: %dB] %s", len(frame), hex.EncodeToString(frame))
+
+	got := r.Decode("wss://a.test/feed", payload)
+	if got == nil || got.Codec != CodecProtobuf || got.MessageType != "chat.Envelope" {
+		t.Fatalf("decoded = %+v", got)
+	}
+	want := map[string]any{
+		"id": int64(42), "text": "hi", "kind": "TEXT",
+		"tags":   []any{int32(1), int32(2), int32(3)},
+		"author": map[string]any{"name": "ann"},
+		"#9":     []any{uint64(7)},
+	}
+	if !reflect.DeepEqual(got.Value, want) {
+		t.Fatalf("value = %#v\nwant    %#v", got.Value, want)
+	}
+
+	if got := r.Decode("wss://a.test/other", payload); got != nil {
+		t.Fatalf("URL filter ignored: %+v", got)
+	}
+	if got := r.Decode("wss://a.test/feed", "[Binary: 300B, magic:0a0b0c0d]"); got != nil {
+		t.Fatalf("large-frame placeholder decoded as %+v", got)
+	}
+	if n := r.Clear(); n != 1 || len(r.List()) != 0 {
+		t.Fatalf("clear = %d, list = %v", n, r.List())
+	}
+}
+
+func TestRegistry_RejectsNonDescriptorFiles(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "junk.pb")
+	if err := os.WriteFile(path, []byte("not a descriptor"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewRegistry().Register(path, "", ""); err == nil {
+		t.Fatal("expected an error for a non-descriptor file")
+	}
+}
@@ -0,0 +1,28 @@
+// Purpose: Package wsdecode — decodes captured WebSocket payloads (JSON, socket.io, SignalR, registered protobuf).
+// Why: Raw frames like 42["chat",{...}] or a hex dump hide the message type an agent is looking for.
+// Docs: docs/features/feature/websocket-decoding/index.md
+
+/*
+Package wsdecode turns captured WebSocket message payloads into typed values.
+
+Decoding runs when events are read, not when they are captured, so a protobuf
+descriptor registered after the traffic still applies to frames already in the
+buffer.
+
+Codecs, tried in order:
+  - protobuf: binary frames (the extension's "[Binary: NB] <hex>" form) on a URL
+    with a registered descriptor set.
+  - socket.io: Engine.IO/socket.io packets such as 42["event",...].
+  - signalr: JSON hub protocol records terminated by 0x1E.
+  - json: any JSON object or array; the message type comes from a discriminator
+    key such as "type", "event", or "op".
+
+Key types:
+  - Registry: registered protobuf descriptor sets with their own lock (a leaf lock).
+  - Registration: one descriptor set bound to a message and an optional URL filter.
+
+Key functions:
+  - (*Registry).Decode: decodes one payload, or returns nil when no codec applies.
+  - (*Registry).Register: loads a FileDescriptorSet file produced by protoc.
+*/
+package wsdecode
@@ -0,0 +1,307 @@
+// Purpose: Parses protoc FileDescriptorSet files into the message and enum tables the decoder walks.
+// Why: Pulling in a protobuf runtime for one read-only schema walk would break the stdlib-only build.
+// Docs: docs/features/feature/websocket-decoding/index.md
+
+package wsdecode
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Wire types.
+const (
+	wireVarint  = 0
+	wireFixed64 = 1
+	wireBytes   = 2
+	wireFixed32 = 5
+)
+
+// FieldDescriptorProto.Type values used by the decoder.
+const (
+	typeDouble   = 1
+	typeFloat    = 2
+	typeInt64    = 3
+	typeUint64   = 4
+	typeInt32    = 5
+	typeFixed64  = 6
+	typeFixed32  = 7
+	typeBool     = 8
+	typeString   = 9
+	typeMessage  = 11
+	typeBytes    = 12
+	typeUint32   = 13
+	typeEnum     = 14
+	typeSfixed32 = 15
+	typeSfixed64 = 16
+	typeSint32   = 17
+	typeSint64   = 18
+)
+
+const labelRepeated = 3
+
+var errTruncated = errors.New("truncated protobuf data")
+
+type fieldDescriptor struct {
+	name     string
+	number   int
+	repeated bool
+	typ      int
+	typeName string // fully qualified, without the leading dot
+}
+
+type messageDescriptor struct {
+	fullName string
+	fields   map[int]*fieldDescriptor
+}
+
+type descriptorSet struct {
+	messages map[string]*messageDescriptor
+	enums    map[string]map[int64]string
+}
+
+// protoField is one field read off the wire. varint holds the value of varint and fixed
+// fields; bytes holds length-delimited payloads.
+type protoField struct {
+	number int
+	wire   int
+	varint uint64
+	bytes  []byte
+}
+
+// walkFields calls fn for each field in data and fails on malformed or truncated input.
+func walkFields(data []byte, fn func(protoField) error) error {
+	for len(data) > 0 {
+		tag, n := binary.Uvarint(data)
+		if n <= 0 || tag>>3 == 0 {
+			return errTruncated
+		}
+		data = data[n:]
+		f := protoField{number: int(tag >> 3), wire: int(tag & 7)}
+		switch f.wire {
+		case wireVarint:
+			f.varint, n = binary.Uvarint(data)
+			if n <= 0 {
+				return errTruncated
+			}
+			data = data[n:]
+		case wireFixed64:
+			if len(data) < 8 {
+				return errTruncated
+			}
+			f.varint, data = binary.LittleEndian.Uint64(data), data[8:]
+		case wireFixed32:
+			if len(data) < 4 {
+				return errTruncated
+			}
+			f.varint, data = uint64(binary.LittleEndian.Uint32(data)), data[4:]
+		case wireBytes:
+			length, n := binary.Uvarint(data)
+			if n <= 0 || length > uint64(len(data)-n) {
+				return errTruncated
+			}
+			f.bytes, data = data[n:n+int(length)], data[n+int(length):]
+		default:
+			return fmt.Errorf("unsupported wire type %d", f.wire)
+		}
+		if err := fn(f); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseDescriptorSet reads a google.protobuf.FileDescriptorSet.
+func parseDescriptorSet(data []byte) (*descriptorSet, error) {
+	set := &descriptorSet{messages: map[string]*messageDescriptor{}, enums: map[string]map[int64]string{}}
+	err := walkFields(data, func(f protoField) error {
+		if f.number != 1 || f.wire != wireBytes {
+			return nil
+		}
+		return set.parseFile(f.bytes)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("not a FileDescriptorSet (build one with protoc --descriptor_set_out --include_imports): %w", err)
+	}
+	if len(set.messages) == 0 {
+		return nil, errors.New("descriptor set defines no message types")
+	}
+	return set, nil
+}
+
+// parseFile reads a FileDescriptorProto: package (2), message_type (4), enum_type (5).
+func (s *descriptorSet) parseFile(data []byte) error {
+	var pkg string
+	var messages, enums [][]byte
+	err := walkFields(data, func(f protoField) error {
+		if f.wire != wireBytes {
+			return nil
+		}
+		switch f.number {
+		case 2:
+			pkg = string(f.bytes)
+		case 4:
+			messages = append(messages, f.bytes)
+		case 5:
+			enums = append(enums, f.bytes)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	for _, m := range messages {
+		if err := s.parseMessage(pkg, m); err != nil {
+			return err
+		}
+	}
+	for _, e := range enums {
+		if err := s.parseEnum(pkg, e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseMessage reads a DescriptorProto: name (1), field (2), nested_type (3), enum_type (4).
+func (s *descriptorSet) parseMessage(scope string, data []byte) error {
+	msg := &messageDescriptor{fields: map[int]*fieldDescriptor{}}
+	var nested, enums [][]byte
+	err := walkFields(data, func(f protoField) error {
+		if f.wire != wireBytes {
+			return nil
+		}
+		switch f.number {
+		case 1:
+			msg.fullName = qualify(scope, string(f.bytes))
+		case 2:
+			field, err := parseField(f.bytes)
+			if err != nil {
+				return err
+			}
+			msg.fields[field.number] = field
+		case 3:
+			nested = append(nested, f.bytes)
+		case 4:
+			enums = append(enums, f.bytes)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	s.messages[msg.fullName] = msg
+	for _, m := range nested {
+		if err := s.parseMessage(msg.fullName, m); err != nil {
+			return err
+		}
+	}
+	for _, e := range enums {
+		if err := s.parseEnum(msg.fullName, e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseField reads a FieldDescriptorProto: name (1), number (3), label (4), type (5), type_name (6).
+func parseField(data []byte) (*fieldDescriptor, error) {
+	field := &fieldDescriptor{}
+	err := walkFields(data, func(f protoField) error {
+		switch f.number {
+		case 1:
+			field.name = string(f.bytes)
+		case 3:
+			field.number = int(f.varint)
+		case 4:
+			field.repeated = f.varint == labelRepeated
+		case 5:
+			field.typ = int(f.varint)
+		case 6:
+			field.typeName = strings.TrimPrefix(string(f.bytes), ".")
+		}
+		return nil
+	})
+	return field, err
+}
+
+// parseEnum reads an EnumDescriptorProto: name (1), value (2) with name (1) and number (2).
+func (s *descriptorSet) parseEnum(scope string, data []byte) error {
+	var name string
+	values := map[int64]string{}
+	err := walkFields(data, func(f protoField) error {
+		switch {
+		case f.number == 1 && f.wire == wireBytes:
+			name = string(f.bytes)
+		case f.number == 2 && f.wire == wireBytes:
+			var valueName string
+			var number int64
+			if err := walkFields(f.bytes, func(v protoField) error {
+				switch v.number {
+				case 1:
+					valueName = string(v.bytes)
+				case 2:
+					number = int64(int32(v.varint))
+				}
+				return nil
+			}); err != nil {
+				return err
+			}
+			values[number] = valueName
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	s.enums[qualify(scope, name)] = values
+	return nil
+}
+
+// resolveMessage finds a message by full name or by a name suffix that matches exactly one
+// message. An empty name selects the set's only message type.
+func (s *descriptorSet) resolveMessage(name string) (*messageDescriptor, error) {
+	name = strings.TrimPrefix(name, ".")
+	if name == "" {
+		if len(s.messages) == 1 {
+			for _, m := range s.messages {
+				return m, nil
+			}
+		}
+		return nil, fmt.Errorf("descriptor set defines %d message types; pass message (one of: %s)", len(s.messages), abbreviate(s.messageNames()))
+	}
+	if m, ok := s.messages[name]; ok {
+		return m, nil
+	}
+	var found []*messageDescriptor
+	for fullName, m := range s.messages {
+		if strings.HasSuffix(fullName, "."+name) {
+			found = append(found, m)
+		}
+	}
+	switch len(found) {
+	case 1:
+		return found[0], nil
+	case 0:
+		return nil, fmt.Errorf("message %q not found (one of: %s)", name, abbreviate(s.messageNames()))
+	default:
+		return nil, fmt.Errorf("message %q is ambiguous; pass the full name", name)
+	}
+}
+
+func qualify(scope, name string) string {
+	if scope == "" {
+		return name
+	}
+	return scope + "." + name
+}
+
+// abbreviate joins names for an error message, listing at most ten.
+func abbreviate(names []string) string {
+	if len(names) > 10 {
+		return strings.Join(names[:10], ", ") + fmt.Sprintf(", and %d more", len(names)-10)
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,177 @@
+// Purpose: Holds the protobuf descriptor sets registered for WebSocket decoding.
+// Why: Protobuf frames are opaque without a schema; agents register the app's descriptor set once per session.
+// Docs: docs/features/feature/websocket-decoding/index.md
+
+package wsdecode
+
+import (
+	"encoding/hex"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/types"
+)
+
+const (
+	// MaxDescriptorBytes caps the descriptor set file read by Register.
+	MaxDescriptorBytes = 4 * 1024 * 1024
+	// maxRegistrations caps the registry; the oldest registration is dropped past it.
+	maxRegistrations = 16
+)
+
+// Registration describes one registered descriptor set.
+type Registration struct {
+	Path         string    `json:"path"`
+	Message      string    `json:"message"`
+	URL          string    `json:"url,omitempty"`
+	MessageTypes int       `json:"message_types"`
+	RegisteredAt time.Time `json:"registered_at"`
+}
+
+type registration struct {
+	info Registration
+	set  *descriptorSet
+	root *messageDescriptor
+}
+
+// Registry holds registered protobuf descriptor sets. It has its own lock and calls nothing
+// while holding it, so callers may use it under any other lock.
+type Registry struct {
+	mu      sync.Mutex
+	entries []*registration
+}
+
+// NewRegistry returns an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// Register loads the FileDescriptorSet at path (protoc --descriptor_set_out --include_imports)
+// and decodes binary frames on URLs containing url as message. An empty message selects the
+// set's only message type. Registering the same path and url again replaces the earlier entry.
+func (r *Registry) Register(path, message, url string) (Registration, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return Registration{}, err
+	}
+	if info.Size() > MaxDescriptorBytes {
+		return Registration{}, fmt.Errorf("descriptor set is %d bytes, over the %d byte limit", info.Size(), MaxDescriptorBytes)
+	}
+	data, err := os.ReadFile(path) // #nosec G304 -- path is supplied by the local agent operator
+	if err != nil {
+		return Registration{}, err
+	}
+	set, err := parseDescriptorSet(data)
+	if err != nil {
+		return Registration{}, err
+	}
+	root, err := set.resolveMessage(message)
+	if err != nil {
+		return Registration{}, err
+	}
+
+	entry := &registration{
+		info: Registration{
+			Path:         path,
+			Message:      root.fullName,
+			URL:          url,
+			MessageTypes: len(set.messages),
+			RegisteredAt: time.Now(),
+		},
+		set:  set,
+		root: root,
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	kept := r.entries[:0]
+	for _, e := range r.entries {
+		if e.info.Path != path || e.info.URL != url {
+			kept = append(kept, e)
+		}
+	}
+	r.entries = append(kept, entry)
+	if len(r.entries) > maxRegistrations {
+		r.entries = r.entries[len(r.entries)-maxRegistrations:]
+	}
+	return entry.info, nil
+}
+
+// List returns the registrations, oldest first.
+func (r *Registry) List() []Registration {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	out := make([]Registration, len(r.entries))
+	for i, e := range r.entries {
+		out[i] = e.info
+	}
+	return out
+}
+
+// Clear removes every registration and returns how many there were.
+func (r *Registry) Clear() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	n := len(r.entries)
+	r.entries = nil
+	return n
+}
+
+// match returns the newest registration whose URL filter matches url.
+func (r *Registry) match(url string) *registration {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i := len(r.entries) - 1; i >= 0; i-- {
+		if strings.Contains(url, r.entries[i].info.URL) {
+			return r.entries[i]
+		}
+	}
+	return nil
+}
+
+// binaryFrame extracts the bytes of a small binary frame captured as "[Binary: NB] <hex>".
+// Frames of 256 bytes or more are captured as a size and magic bytes only; ok is still true
+// for them so no text codec tries the placeholder, but raw is nil.
+func binaryFrame(data string) (raw []byte, ok bool) {
+	if !strings.HasPrefix(data, "[Binary: ") {
+		return nil, false
+	}
+	_, hexPart, found := strings.Cut(data, "] ")
+	if !found {
+		return nil, true
+	}
+	raw, err := hex.DecodeString(hexPart)
+	if err != nil {
+		return nil, true
+	}
+	return raw, true
+}
+
+// decodeProtobuf decodes a binary frame with the registration matching url.
+func (r *Registry) decodeProtobuf(url string, raw []byte) *types.WSDecoded {
+	if len(raw) == 0 {
+		return nil
+	}
+	entry := r.match(url)
+	if entry == nil {
+		return nil
+	}
+	value, err := entry.set.decodeMessage(entry.root, raw, 0)
+	if err != nil {
+		return nil
+	}
+	return &types.WSDecoded{Codec: CodecProtobuf, MessageType: entry.root.fullName, Value: value}
+}
+
+// messageNames returns the sorted full names of the set's message types, for error messages.
+func (s *descriptorSet) messageNames() []string {
+	names := make([]string, 0, len(s.messages))
+	for name := range s.messages {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,62 @@
+// Purpose: Splits SignalR JSON hub protocol frames into typed records.
+// Why: SignalR packs several 0x1E-terminated records into one frame, which plain JSON parsing rejects.
+// Docs: docs/features/feature/websocket-decoding/index.md
+
+package wsdecode
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/types"
+)
+
+// signalRRecordSeparator terminates every record in the JSON hub protocol.
+const signalRRecordSeparator = "\x1e"
+
+var signalRMessageTypes = map[int]string{
+	1: "invocation", 2: "stream_item", 3: "completion", 4: "stream_invocation",
+	5: "cancel_invocation", 6: "ping", 7: "close", 8: "ack", 9: "sequence",
+}
+
+// decodeSignalR decodes a frame of one or more hub protocol records. The message type is the
+// invocation target when there is one, so {"type":1,"target":"ReceiveMessage"} reads as
+// ReceiveMessage. A frame with several records reports the first record's type and an array value.
+func decodeSignalR(data string) *types.WSDecoded {
+	if !strings.HasSuffix(data, signalRRecordSeparator) {
+		return nil
+	}
+	var records []any
+	messageType := ""
+	for _, part := range strings.Split(strings.TrimSuffix(data, signalRRecordSeparator), signalRRecordSeparator) {
+		var record map[string]any
+		if err := json.Unmarshal([]byte(part), &record); err != nil {
+			return nil
+		}
+		if messageType == "" {
+			messageType = signalRRecordType(record)
+		}
+		records = append(records, record)
+	}
+	decoded := &types.WSDecoded{Codec: CodecSignalR, MessageType: messageType, Value: records}
+	if len(records) == 1 {
+		decoded.Value = records[0]
+	}
+	return decoded
+}
+
+// signalRRecordType names a record by target, then by numeric type. A record without a type is
+// the handshake request or response.
+func signalRRecordType(record map[string]any) string {
+	if target, ok := record["target"].(string); ok && target != "" {
+		return target
+	}
+	n, ok := record["type"].(float64)
+	if !ok {
+		return "handshake"
+	}
+	if name, ok := signalRMessageTypes[int(n)]; ok {
+		return name
+	}
+	return "unknown"
+}
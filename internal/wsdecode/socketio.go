@@ -0,0 +1,94 @@
+// Purpose: Unwraps Engine.IO and socket.io packets into event names and arguments.
+// Why: socket.io frames prefix JSON with packet digits, namespaces, and ack IDs that hide the event name.
+// Docs: docs/features/feature/websocket-decoding/index.md
+
+package wsdecode
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/brennhill/Kaboom-Browser-AI-Devtools-MCP/internal/types"
+)
+
+var engineIOPackets = map[byte]string{'0': "open", '1': "close", '2': "ping", '3': "pong", '5': "upgrade", '6': "noop"}
+
+var socketIOPackets = map[byte]string{
+	'0': "connect", '1': "disconnect", '2': "event", '3': "ack",
+	'4': "connect_error", '5': "binary_event", '6': "binary_ack",
+}
+
+// decodeSocketIO decodes an Engine.IO packet. Bare control packets ("2", "3probe") are only
+// treated as socket.io on a /socket.io/ URL; event and ack packets carrying a JSON array are
+// distinctive enough to recognize anywhere.
+func decodeSocketIO(url, data string) *types.WSDecoded {
+	onSocketIO := strings.Contains(url, "/socket.io/")
+	if data[0] != '4' {
+		if !onSocketIO {
+			return nil
+		}
+		kind, ok := engineIOPackets[data[0]]
+		if !ok {
+			return nil
+		}
+		decoded := &types.WSDecoded{Codec: CodecSocketIO, MessageType: kind}
+		if rest := data[1:]; rest != "" {
+			decoded.Value = jsonOrString(rest)
+		}
+		return decoded
+	}
+	if len(data) < 2 {
+		return nil
+	}
+	kind, ok := socketIOPackets[data[1]]
+	if !ok {
+		return nil
+	}
+	rest := data[2:]
+	namespace := ""
+	if strings.HasPrefix(rest, "/") {
+		i := strings.IndexByte(rest, ',')
+		if i < 0 {
+			namespace, rest = rest, ""
+		} else {
+			namespace, rest = rest[:i], rest[i+1:]
+		}
+	}
+	rest = strings.TrimLeft(rest, "0123456789") // ack ID
+	isEvent := kind == "event" || kind == "ack" || kind == "binary_event" || kind == "binary_ack"
+	if !onSocketIO && (!isEvent || !strings.HasPrefix(rest, "[")) {
+		return nil
+	}
+
+	decoded := &types.WSDecoded{Codec: CodecSocketIO, MessageType: kind}
+	value := map[string]any{}
+	if namespace != "" {
+		value["namespace"] = namespace
+	}
+	var args []any
+	if isEvent && json.Unmarshal([]byte(rest), &args) == nil {
+		isAck := kind == "ack" || kind == "binary_ack"
+		if len(args) > 0 && !isAck {
+			if name, ok := args[0].(string); ok {
+				decoded.MessageType = name
+				args = args[1:]
+			}
+		}
+		value["args"] = args
+	} else if rest != "" {
+		value["data"] = jsonOrString(rest)
+	}
+	if len(value) > 0 {
+		decoded.Value = value
+	}
+	return decoded
+}
+
+// jsonOrString parses s as JSON, falling back to the raw string.
+func jsonOrString(s string) any {
+	var v any
+	if json.Unmarshal([]byte(s), &v) == nil {
+		return v
+	}
+	return s
+}
@@ -0,0 +1,198 @@
+// Purpose: Implements the JSON-RPC tools/call transport to the Kaboom HTTP MCP endpoint.
+// Why: Gives Go programs the CLI's transport without shelling out to the kaboom binary.
+// Docs: docs/features/feature/enhanced-cli-config/index.md
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+)
+
+// DefaultBaseURL is the default local server address.
+const DefaultBaseURL = "http://127.0.0.1:7890"
+
+// DefaultMaxResponseBytes caps response bodies read from the server.
+const DefaultMaxResponseBytes int64 = 10 << 20
+
+// Args are tool arguments. Keys are the MCP parameter names (snake_case).
+type Args map[string]any
+
+// Client calls tools on one Kaboom server. It is safe for concurrent use.
+type Client struct {
+	baseURL          string
+	httpClient       *http.Client
+	apiKey           string
+	clientID         string
+	maxResponseBytes int64
+	nextID           atomic.Int64
+}
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithHTTPClient sets the HTTP client used for requests. Timeouts are normally
+// controlled per call through the context instead.
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *Client) { c.httpClient = hc }
+}
+
+// WithAPIKey sends the key in X-Kaboom-Key for servers started with --api-key.
+func WithAPIKey(key string) Option {
+	return func(c *Client) { c.apiKey = key }
+}
+
+// WithClientID sends X-Kaboom-Client so the server can attribute calls in multi-client mode.
+func WithClientID(id string) Option {
+	return func(c *Client) { c.clientID = id }
+}
+
+// WithMaxResponseBytes caps response bodies read from the server.
+func WithMaxResponseBytes(n int64) Option {
+	return func(c *Client) { c.maxResponseBytes = n }
+}
+
+// New creates a client for the server at baseURL (e.g. "http://127.0.0.1:7890").
+// An empty baseURL uses DefaultBaseURL.
+func New(baseURL string, opts ...Option) *Client {
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+	c := &Client{
+		baseURL:          strings.TrimRight(baseURL, "/"),
+		httpClient:       &http.Client{},
+		maxResponseBytes: DefaultMaxResponseBytes,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// BaseURL returns the server address the client talks to.
+func (c *Client) BaseURL() string {
+	return c.baseURL
+}
+
+type rpcRequest struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      any             `json:"id"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+type rpcResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      any             `json:"id"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *RPCError       `json:"error,omitempty"`
+}
+
+// RPCError is a JSON-RPC protocol error (unknown method, malformed request).
+// Tool failures are reported as *ToolError instead.
+type RPCError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *RPCError) Error() string {
+	return fmt.Sprintf("server error (%d): %s", e.Code, e.Message)
+}
+
+// Call invokes a tool and returns its raw result. Tool-level failures are not
+// converted into errors: check Result.IsError or call Result.Err. Transport and
+// JSON-RPC protocol failures are returned as errors.
+func (c *Client) Call(ctx context.Context, tool string, args Args) (*Result, error) {
+	if args == nil {
+		args = Args{}
+	}
+	params, err := json.Marshal(map[string]any{"name": tool, "arguments": args})
+	if err != nil {
+		return nil, fmt.Errorf("marshal params: %w. Check argument types", err)
+	}
+	raw, err := c.rpc(ctx, "tools/call", params)
+	if err != nil {
+		return nil, err
+	}
+	var result Result
+	if err := json.Unmarshal(raw, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse tool result: %w", err)
+	}
+	return &result, nil
+}
+
+// ListTools returns the server's tool definitions from tools/list.
+func (c *Client) ListTools(ctx context.Context) ([]ToolInfo, error) {
+	raw, err := c.rpc(ctx, "tools/list", nil)
+	if err != nil {
+		return nil, err
+	}
+	var list struct {
+		Tools []ToolInfo `json:"tools"`
+	}
+	if err := json.Unmarshal(raw, &list); err != nil {
+		return nil, fmt.Errorf("failed to parse tools/list: %w", err)
+	}
+	return list.Tools, nil
+}
+
+// ToolInfo describes one tool from tools/list.
+type ToolInfo struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	InputSchema map[string]any `json:"inputSchema"`
+}
+
+// rpc sends one JSON-RPC request to /mcp and returns the result payload.
+func (c *Client) rpc(ctx context.Context, method string, params json.RawMessage) (json.RawMessage, error) {
+	body, err := json.Marshal(rpcRequest{
+		JSONRPC: "2.0",
+		ID:      fmt.Sprintf("go-client-%d", c.nextID.Add(1)),
+		Method:  method,
+		Params:  params,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("marshal request: %w. Check argument types", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/mcp", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("create HTTP request: %w. Verify endpoint URL", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	if c.apiKey != "" {
+		httpReq.Header.Set("X-Kaboom-Key", c.apiKey)
+	}
+	if c.clientID != "" {
+		httpReq.Header.Set("X-Kaboom-Client", c.clientID)
+	}
+
+	resp, err := c.httpClient.Do(httpReq) // #nosec G107 -- base URL is chosen by the caller
+	if err != nil {
+		return nil, fmt.Errorf("connect to server: %w. Verify daemon is running on the target port", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, c.maxResponseBytes))
+	if err != nil {
+		return nil, fmt.Errorf("read response: %w. Server may have disconnected", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(respBody))
+	}
+
+	var rpcResp rpcResponse
+	if err := json.Unmarshal(respBody, &rpcResp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	if rpcResp.Error != nil {
+		return nil, rpcResp.Error
+	}
+	return rpcResp.Result, nil
+}
@@ -0,0 +1,269 @@
+// Purpose: Tests the Go client transport, result decoding, tool errors, and cursor streaming.
+// Docs: docs/features/feature/enhanced-cli-config/index.md
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+type recordedCall struct {
+	method  string
+	tool    string
+	args    map[string]any
+	headers http.Header
+}
+
+// fakeServer answers /mcp with respond(call) and records every request.
+type fakeServer struct {
+	mu      sync.Mutex
+	calls   []recordedCall
+	respond func(call recordedCall) (result any, rpcErr *RPCError)
+}
+
+func (f *fakeServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/mcp" || r.Method != http.MethodPost {
+		http.NotFound(w, r)
+		return
+	}
+	var req struct {
+		ID     any    `json:"id"`
+		Method string `json:"method"`
+		Params struct {
+			Name      string         `json:"name"`
+			Arguments map[string]any `json:"arguments"`
+		} `json:"params"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	call := recordedCall{method: req.Method, tool: req.Params.Name, args: req.Params.Arguments, headers: r.Header.Clone()}
+	f.mu.Lock()
+	f.calls = append(f.calls, call)
+	f.mu.Unlock()
+
+	result, rpcErr := f.respond(call)
+	resp := map[string]any{"jsonrpc": "2.0", "id": req.ID}
+	if rpcErr != nil {
+		resp["error"] = rpcErr
+	} else {
+		resp["result"] = result
+	}
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+func (f *fakeServer) recorded() []recordedCall {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]recordedCall(nil), f.calls...)
+}
+
+func textResult(text string, isError bool) map[string]any {
+	return map[string]any{
+		"content": []map[string]any{{"type": "text", "text": text}},
+		"isError": isError,
+	}
+}
+
+func newFake(t *testing.T, respond func(recordedCall) (any, *RPCError)) (*fakeServer, *httptest.Server) {
+	t.Helper()
+	fake := &fakeServer{respond: respond}
+	srv := httptest.NewServer(fake)
+	t.Cleanup(srv.Close)
+	return fake, srv
+}
+
+func TestCall_SendsToolArgsAndHeaders(t *testing.T) {
+	t.Parallel()
+	fake, srv := newFake(t, func(recordedCall) (any, *RPCError) {
+		return textResult("3 errors\n{\"count\":3}", false), nil
+	})
+
+	c := New(srv.URL+"/", WithAPIKey("secret"), WithClientID("ci-bot"))
+	res, err := c.ObserveErrors(context.Background(), Args{"limit": 5})
+	if err != nil {
+		t.Fatalf("ObserveErrors: %v", err)
+	}
+	if res.Summary() != "3 errors" {
+		t.Fatalf("Summary = %q", res.Summary())
+	}
+	var payload struct {
+		Count int `json:"count"`
+	}
+	if err := res.Decode(&payload); err != nil || payload.Count != 3 {
+		t.Fatalf("Decode = %+v, %v", payload, err)
+	}
+
+	calls := fake.recorded()
+	if len(calls) != 1 {
+		t.Fatalf("calls = %d, want 1", len(calls))
+	}
+	got := calls[0]
+	if got.method != "tools/call" || got.tool != ToolObserve {
+		t.Fatalf("method/tool = %s/%s", got.method, got.tool)
+	}
+	if got.args["what"] != "errors" || got.args["limit"] != float64(5) {
+		t.Fatalf("args = %v", got.args)
+	}
+	if got.headers.Get("X-Kaboom-Key") != "secret" || got.headers.Get("X-Kaboom-Client") != "ci-bot" {
+		t.Fatalf("headers = %v", got.headers)
+	}
+}
+
+func TestTypedHelpers_FillModeArguments(t *testing.T) {
+	t.Parallel()
+	fake, srv := newFake(t, func(recordedCall) (any, *RPCError) {
+		return textResult("{}", false), nil
+	})
+	c := New(srv.URL)
+	ctx := context.Background()
+
+	if _, err := c.InteractClick(ctx, "#submit", nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.InteractType(ctx, "#email", "a@b.c", Args{"clear": true}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.GenerateTest(ctx, Args{"test_name": "login"}); err != nil {
+		t.Fatal(err)
+	}
+
+	calls := fake.recorded()
+	if calls[0].tool != ToolInteract || calls[0].args["what"] != "click" || calls[0].args["selector"] != "#submit" {
+		t.Fatalf("click call = %+v", calls[0])
+	}
+	if calls[1].args["text"] != "a@b.c" || calls[1].args["selector"] != "#email" || calls[1].args["clear"] != true {
+		t.Fatalf("type call = %+v", calls[1])
+	}
+	if calls[2].tool != ToolGenerate || calls[2].args["what"] != "test" || calls[2].args["test_name"] != "login" {
+		t.Fatalf("generate call = %+v", calls[2])
+	}
+}
+
+func TestCall_ToolErrorIsStructured(t *testing.T) {
+	t.Parallel()
+	_, srv := newFake(t, func(recordedCall) (any, *RPCError) {
+		return textResult("Error: extension_timeout — Retry\n{\"error_code\":\"extension_timeout\",\"message\":\"timed out\",\"retryable\":true,\"retry_after_ms\":2000}", true), nil
+	})
+	c := New(srv.URL)
+
+	raw, err := c.Call(context.Background(), ToolObserve, Args{"what": "page"})
+	if err != nil {
+		t.Fatalf("Call should not return tool errors as errors: %v", err)
+	}
+	if !raw.IsError {
+		t.Fatal("expected IsError")
+	}
+
+	res, err := c.ObservePage(context.Background())
+	var toolErr *ToolError
+	if !errors.As(err, &toolErr) {
+		t.Fatalf("err = %v, want *ToolError", err)
+	}
+	if toolErr.Code != "extension_timeout" || !toolErr.Retryable || toolErr.RetryAfterMs != 2000 {
+		t.Fatalf("toolErr = %+v", toolErr)
+	}
+	if res == nil {
+		t.Fatal("result should be returned alongside the tool error")
+	}
+}
+
+func TestCall_RPCAndHTTPErrors(t *testing.T) {
+	t.Parallel()
+	_, srv := newFake(t, func(recordedCall) (any, *RPCError) {
+		return nil, &RPCError{Code: -32601, Message: "Method not found"}
+	})
+	_, err := New(srv.URL).Call(context.Background(), "nope", nil)
+	var rpcErr *RPCError
+	if !errors.As(err, &rpcErr) || rpcErr.Code != -32601 {
+		t.Fatalf("err = %v, want RPCError -32601", err)
+	}
+
+	_, err = New(srv.URL+"/missing").Call(context.Background(), ToolObserve, nil)
+	if err == nil || !strings.Contains(err.Error(), "HTTP 404") {
+		t.Fatalf("err = %v, want HTTP 404", err)
+	}
+}
+
+func TestCall_HonorsContextCancellation(t *testing.T) {
+	t.Parallel()
+	release := make(chan struct{})
+	_, srv := newFake(t, func(recordedCall) (any, *RPCError) {
+		<-release
+		return textResult("{}", false), nil
+	})
+	defer close(release)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := New(srv.URL).Call(ctx, ToolObserve, nil); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("err = %v, want deadline exceeded", err)
+	}
+}
+
+func TestWatch_DeliversOnlyNewBatches(t *testing.T) {
+	t.Parallel()
+	var (
+		mu   sync.Mutex
+		poll int
+	)
+	cursors := []string{"100:1", "100:1", "102:3"}
+	fake, srv := newFake(t, func(call recordedCall) (any, *RPCError) {
+		mu.Lock()
+		defer mu.Unlock()
+		cursor := cursors[len(cursors)-1]
+		if poll < len(cursors) {
+			cursor = cursors[poll]
+		}
+		poll++
+		return textResult("logs\n{\"logs\":[],\"metadata\":{\"cursor\":\""+cursor+"\"}}", false), nil
+	})
+
+	var batches []string
+	err := New(srv.URL).Watch(context.Background(), "logs", Args{"min_level": "warn"},
+		WatchOptions{Interval: 5 * time.Millisecond},
+		func(r *Result) error {
+			batches = append(batches, resultCursor(r))
+			return ErrStopWatch
+		})
+	if err != nil {
+		t.Fatalf("Watch: %v", err)
+	}
+	if len(batches) != 1 || batches[0] != "102:3" {
+		t.Fatalf("batches = %v, want [102:3]", batches)
+	}
+
+	calls := fake.recorded()
+	if _, ok := calls[0].args["before_cursor"]; ok {
+		t.Fatalf("first poll should not send before_cursor: %v", calls[0].args)
+	}
+	last := calls[len(calls)-1].args
+	if last["before_cursor"] != "100:1" || last["restart_on_eviction"] != true || last["min_level"] != "warn" {
+		t.Fatalf("poll args = %v", last)
+	}
+}
+
+func TestWatch_StopsOnContextCancel(t *testing.T) {
+	t.Parallel()
+	_, srv := newFake(t, func(recordedCall) (any, *RPCError) {
+		return textResult("{\"logs\":[],\"metadata\":{}}", false), nil
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+	defer cancel()
+	err := New(srv.URL).Watch(ctx, "logs", nil, WatchOptions{Interval: 5 * time.Millisecond}, func(*Result) error {
+		t.Fatal("callback should not run without new entries")
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Watch: %v", err)
+	}
+}
@@ -0,0 +1,29 @@
+// Purpose: Package documentation for the embeddable Go client of the Kaboom MCP server.
+// Docs: docs/features/feature/enhanced-cli-config/index.md
+
+// Package client calls a running Kaboom server over its HTTP MCP endpoint.
+//
+// It is the same transport the kaboom CLI uses, exposed with context support,
+// typed helpers for common tool modes, and cursor-based streaming:
+//
+//	c := client.New("http://127.0.0.1:7890")
+//	res, err := c.ObserveErrors(ctx, client.Args{"limit": 20})
+//	if err != nil {
+//		var toolErr *client.ToolError
+//		if errors.As(err, &toolErr) && toolErr.Retryable {
+//			// back off and retry
+//		}
+//		return err
+//	}
+//	var payload struct {
+//		Errors []map[string]any `json:"errors"`
+//	}
+//	err = res.Decode(&payload)
+//
+// Every tool mode is reachable through the per-tool methods (Observe, Analyze,
+// Generate, Configure, Interact) or the raw Call; the typed helpers are thin
+// wrappers that fill in the mode name.
+//
+// The package depends only on the standard library so it can be vendored or
+// copied into other modules.
+package client
@@ -0,0 +1,122 @@
+// Purpose: Defines tool results and structured tool errors returned by the client.
+// Why: Tool responses are a summary line plus JSON; callers need typed decoding and error inspection.
+// Docs: docs/features/feature/enhanced-cli-config/index.md
+
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ContentBlock is one MCP content block in a tool result.
+type ContentBlock struct {
+	Type     string `json:"type"`
+	Text     string `json:"text,omitempty"`
+	Data     string `json:"data,omitempty"`
+	MimeType string `json:"mimeType,omitempty"`
+}
+
+// Result is a tool call result.
+type Result struct {
+	Content  []ContentBlock `json:"content"`
+	IsError  bool           `json:"isError"`
+	Metadata map[string]any `json:"metadata,omitempty"`
+}
+
+// Text returns the concatenated text content blocks.
+func (r *Result) Text() string {
+	var parts []string
+	for _, block := range r.Content {
+		if block.Type == "text" {
+			parts = append(parts, block.Text)
+		}
+	}
+	return strings.Join(parts, "\n")
+}
+
+// Summary returns the human-readable first line of the first text block.
+func (r *Result) Summary() string {
+	for _, block := range r.Content {
+		if block.Type != "text" {
+			continue
+		}
+		line, _, _ := strings.Cut(block.Text, "\n")
+		return line
+	}
+	return ""
+}
+
+// JSON returns the JSON payload of the first text block that carries one.
+// Tool responses are either pure JSON or "summary\n{json}".
+func (r *Result) JSON() (json.RawMessage, error) {
+	for _, block := range r.Content {
+		if block.Type != "text" {
+			continue
+		}
+		if payload, ok := extractJSON(block.Text); ok {
+			return payload, nil
+		}
+	}
+	return nil, errors.New("result has no JSON payload")
+}
+
+// Decode unmarshals the result's JSON payload into v.
+func (r *Result) Decode(v any) error {
+	payload, err := r.JSON()
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(payload, v)
+}
+
+// Err returns a *ToolError when the result is a tool failure, or nil.
+func (r *Result) Err() error {
+	if !r.IsError {
+		return nil
+	}
+	toolErr := &ToolError{Message: r.Text()}
+	if payload, err := r.JSON(); err == nil {
+		_ = json.Unmarshal(payload, toolErr)
+	}
+	if toolErr.Code == "" {
+		toolErr.Code = "tool_error"
+	}
+	return toolErr
+}
+
+// ToolError is a structured tool failure (isError=true). Fields mirror the server's
+// structured error payload; Retryable and RetryAfterMs say whether and when to retry.
+type ToolError struct {
+	Code             string         `json:"error_code"`
+	Message          string         `json:"message"`
+	RecoveryPlaybook string         `json:"recovery_playbook"`
+	Retryable        bool           `json:"retryable"`
+	RetryAfterMs     int            `json:"retry_after_ms,omitempty"`
+	Param            string         `json:"param,omitempty"`
+	Hint             string         `json:"hint,omitempty"`
+	RecoveryToolCall map[string]any `json:"recovery_tool_call,omitempty"`
+}
+
+func (e *ToolError) Error() string {
+	if e.RecoveryPlaybook != "" {
+		return fmt.Sprintf("%s: %s (%s)", e.Code, e.Message, e.RecoveryPlaybook)
+	}
+	return fmt.Sprintf("%s: %s", e.Code, e.Message)
+}
+
+func extractJSON(text string) (json.RawMessage, bool) {
+	trimmed := strings.TrimSpace(text)
+	if json.Valid([]byte(trimmed)) && (strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "[")) {
+		return json.RawMessage(trimmed), true
+	}
+	if _, rest, ok := strings.Cut(text, "\n"); ok {
+		rest = strings.TrimSpace(rest)
+		if json.Valid([]byte(rest)) {
+			return json.RawMessage(rest), true
+		}
+	}
+	return nil, false
+}
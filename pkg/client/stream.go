@@ -0,0 +1,106 @@
+// Purpose: Streams new observe entries by polling with pagination cursors.
+// Why: Lets automation react to new logs, errors, or actions as they are captured without re-reading buffers.
+// Docs: docs/features/feature/enhanced-cli-config/index.md
+
+package client
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// DefaultWatchInterval is the poll interval used when WatchOptions.Interval is zero.
+const DefaultWatchInterval = time.Second
+
+// watchBatchLimit is the page size per poll; the server's maximum.
+const watchBatchLimit = 1000
+
+// WatchOptions configures Watch.
+type WatchOptions struct {
+	// Interval between polls. Defaults to DefaultWatchInterval.
+	Interval time.Duration
+	// IncludeBacklog delivers entries already buffered when Watch starts.
+	// By default only entries captured after the first poll are delivered.
+	IncludeBacklog bool
+}
+
+// ErrStopWatch can be returned by a Watch callback to stop watching without an error.
+var ErrStopWatch = errors.New("stop watch")
+
+// Watch polls observe(what=...) and calls fn with each batch of new entries, in capture
+// order, until ctx is done or fn returns an error. Works with cursor-paginated modes
+// (logs, errors, actions, websocket_events, network_bodies, ...).
+//
+// Returns nil when ctx is cancelled or fn returns ErrStopWatch.
+func (c *Client) Watch(ctx context.Context, what string, args Args, opts WatchOptions, fn func(*Result) error) error {
+	interval := opts.Interval
+	if interval <= 0 {
+		interval = DefaultWatchInterval
+	}
+
+	// First poll: establish the cursor at the newest buffered entry.
+	result, err := c.Observe(ctx, what, args)
+	if err != nil {
+		return ignoreCancel(ctx, err)
+	}
+	cursor := resultCursor(result)
+	if opts.IncludeBacklog && cursor != "" {
+		if err := fn(result); err != nil {
+			return stopOrErr(err)
+		}
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		pollArgs := withArg(args, "before_cursor", cursor) // before_cursor = strictly newer entries
+		pollArgs["restart_on_eviction"] = true
+		pollArgs["limit"] = watchBatchLimit
+		result, err := c.Observe(ctx, what, pollArgs)
+		if err != nil {
+			return ignoreCancel(ctx, err)
+		}
+		next := resultCursor(result)
+		if next == "" || next == cursor {
+			continue
+		}
+		cursor = next
+		if err := fn(result); err != nil {
+			return stopOrErr(err)
+		}
+	}
+}
+
+// resultCursor returns metadata.cursor from a result payload, or "" when no entries were returned.
+func resultCursor(r *Result) string {
+	var payload struct {
+		Metadata struct {
+			Cursor string `json:"cursor"`
+		} `json:"metadata"`
+	}
+	if err := r.Decode(&payload); err != nil {
+		return ""
+	}
+	return payload.Metadata.Cursor
+}
+
+func stopOrErr(err error) error {
+	if errors.Is(err, ErrStopWatch) {
+		return nil
+	}
+	return err
+}
+
+func ignoreCancel(ctx context.Context, err error) error {
+	if ctx.Err() != nil {
+		return nil
+	}
+	return err
+}
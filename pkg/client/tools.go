@@ -0,0 +1,198 @@
+// Purpose: Per-tool methods and typed helpers for common observe/analyze/generate/configure/interact modes.
+// Why: Callers get compile-checked entry points instead of hand-building {"what": ...} argument maps.
+// Docs: docs/features/feature/enhanced-cli-config/index.md
+
+package client
+
+import "context"
+
+// Tool names exposed by the server.
+const (
+	ToolObserve   = "observe"
+	ToolAnalyze   = "analyze"
+	ToolGenerate  = "generate"
+	ToolConfigure = "configure"
+	ToolInteract  = "interact"
+)
+
+// Observe calls observe(what=...). Tool failures are returned as *ToolError.
+func (c *Client) Observe(ctx context.Context, what string, args Args) (*Result, error) {
+	return c.callMode(ctx, ToolObserve, what, args)
+}
+
+// Analyze calls analyze(what=...). Tool failures are returned as *ToolError.
+func (c *Client) Analyze(ctx context.Context, what string, args Args) (*Result, error) {
+	return c.callMode(ctx, ToolAnalyze, what, args)
+}
+
+// Generate calls generate(what=...). Tool failures are returned as *ToolError.
+func (c *Client) Generate(ctx context.Context, what string, args Args) (*Result, error) {
+	return c.callMode(ctx, ToolGenerate, what, args)
+}
+
+// Configure calls configure(what=...). Tool failures are returned as *ToolError.
+func (c *Client) Configure(ctx context.Context, what string, args Args) (*Result, error) {
+	return c.callMode(ctx, ToolConfigure, what, args)
+}
+
+// Interact calls interact(what=...). Tool failures are returned as *ToolError.
+func (c *Client) Interact(ctx context.Context, what string, args Args) (*Result, error) {
+	return c.callMode(ctx, ToolInteract, what, args)
+}
+
+// callMode merges what into a copy of args, calls the tool, and converts tool failures to errors.
+// The result is returned alongside a *ToolError so callers can still inspect it.
+func (c *Client) callMode(ctx context.Context, tool, what string, args Args) (*Result, error) {
+	merged := make(Args, len(args)+1)
+	for k, v := range args {
+		merged[k] = v
+	}
+	merged["what"] = what
+	result, err := c.Call(ctx, tool, merged)
+	if err != nil {
+		return nil, err
+	}
+	return result, result.Err()
+}
+
+// ObserveErrors returns captured console errors.
+func (c *Client) ObserveErrors(ctx context.Context, args Args) (*Result, error) {
+	return c.Observe(ctx, "errors", args)
+}
+
+// ObserveLogs returns captured console logs (args: min_level, limit, cursors).
+func (c *Client) ObserveLogs(ctx context.Context, args Args) (*Result, error) {
+	return c.Observe(ctx, "logs", args)
+}
+
+// ObserveNetworkBodies returns captured fetch/XHR request and response bodies.
+func (c *Client) ObserveNetworkBodies(ctx context.Context, args Args) (*Result, error) {
+	return c.Observe(ctx, "network_bodies", args)
+}
+
+// ObserveNetworkWaterfall returns resource timing for all requests.
+func (c *Client) ObserveNetworkWaterfall(ctx context.Context, args Args) (*Result, error) {
+	return c.Observe(ctx, "network_waterfall", args)
+}
+
+// ObserveActions returns recorded user and agent actions.
+func (c *Client) ObserveActions(ctx context.Context, args Args) (*Result, error) {
+	return c.Observe(ctx, "actions", args)
+}
+
+// ObserveVitals returns Web Vitals and performance snapshots.
+func (c *Client) ObserveVitals(ctx context.Context, args Args) (*Result, error) {
+	return c.Observe(ctx, "vitals", args)
+}
+
+// ObservePage returns the tracked page's URL, title, and state.
+func (c *Client) ObservePage(ctx context.Context) (*Result, error) {
+	return c.Observe(ctx, "page", nil)
+}
+
+// AnalyzeDOM queries the DOM of the tracked page (args: selector).
+func (c *Client) AnalyzeDOM(ctx context.Context, selector string, args Args) (*Result, error) {
+	return c.Analyze(ctx, "dom", withArg(args, "selector", selector))
+}
+
+// AnalyzeAccessibility runs an accessibility audit on the tracked page.
+func (c *Client) AnalyzeAccessibility(ctx context.Context, args Args) (*Result, error) {
+	return c.Analyze(ctx, "accessibility", args)
+}
+
+// AnalyzeErrorClusters groups captured errors by root cause.
+func (c *Client) AnalyzeErrorClusters(ctx context.Context) (*Result, error) {
+	return c.Analyze(ctx, "error_clusters", nil)
+}
+
+// GenerateTest generates a Playwright test from recorded actions (args: test_name, base_url, save_to).
+func (c *Client) GenerateTest(ctx context.Context, args Args) (*Result, error) {
+	return c.Generate(ctx, "test", args)
+}
+
+// GenerateReproduction generates a reproduction script from captured actions and errors.
+func (c *Client) GenerateReproduction(ctx context.Context, args Args) (*Result, error) {
+	return c.Generate(ctx, "reproduction", args)
+}
+
+// GenerateHAR exports captured network traffic as HAR (args: url, method, save_to).
+func (c *Client) GenerateHAR(ctx context.Context, args Args) (*Result, error) {
+	return c.Generate(ctx, "har", args)
+}
+
+// GenerateSessionBundle archives all captured buffers into a session bundle (args: save_to, label).
+func (c *Client) GenerateSessionBundle(ctx context.Context, args Args) (*Result, error) {
+	return c.Generate(ctx, "session_bundle", args)
+}
+
+// GenerateDebugBundle zips daemon diagnostics, redacted buffers, settings, and crash logs for a bug report (args: save_to).
+func (c *Client) GenerateDebugBundle(ctx context.Context, args Args) (*Result, error) {
+	return c.Generate(ctx, "debug_bundle", args)
+}
+
+// GenerateJUnit exports the session's checks as JUnit XML (args: suite_name, budget, save_to).
+func (c *Client) GenerateJUnit(ctx context.Context, args Args) (*Result, error) {
+	return c.Generate(ctx, "junit", args)
+}
+
+// GenerateGHAnnotations emits GitHub Actions annotation commands for session findings (args: save_to).
+func (c *Client) GenerateGHAnnotations(ctx context.Context, args Args) (*Result, error) {
+	return c.Generate(ctx, "gh_annotations", args)
+}
+
+// GenerateCurl renders a captured request as a cURL command (args: request_id, save_to).
+func (c *Client) GenerateCurl(ctx context.Context, args Args) (*Result, error) {
+	return c.Generate(ctx, "curl", args)
+}
+
+// GenerateFetch renders a captured request as a fetch() snippet (args: request_id, save_to).
+func (c *Client) GenerateFetch(ctx context.Context, args Args) (*Result, error) {
+	return c.Generate(ctx, "fetch", args)
+}
+
+// GenerateCoverageReport renders the biggest unused JS/CSS bundles as Markdown (args: url, limit, save_to).
+func (c *Client) GenerateCoverageReport(ctx context.Context, args Args) (*Result, error) {
+	return c.Generate(ctx, "coverage_report", args)
+}
+
+// ConfigureHealth returns server and extension health.
+func (c *Client) ConfigureHealth(ctx context.Context) (*Result, error) {
+	return c.Configure(ctx, "health", nil)
+}
+
+// ConfigureClear clears a capture buffer ("all", "logs", "network", ...).
+func (c *Client) ConfigureClear(ctx context.Context, buffer string) (*Result, error) {
+	return c.Configure(ctx, "clear", Args{"buffer": buffer})
+}
+
+// InteractNavigate navigates the tracked tab to url.
+func (c *Client) InteractNavigate(ctx context.Context, url string, args Args) (*Result, error) {
+	return c.Interact(ctx, "navigate", withArg(args, "url", url))
+}
+
+// InteractClick clicks the element matching selector.
+func (c *Client) InteractClick(ctx context.Context, selector string, args Args) (*Result, error) {
+	return c.Interact(ctx, "click", withArg(args, "selector", selector))
+}
+
+// InteractType types text into the element matching selector.
+func (c *Client) InteractType(ctx context.Context, selector, text string, args Args) (*Result, error) {
+	return c.Interact(ctx, "type", withArg(withArg(args, "selector", selector), "text", text))
+}
+
+// InteractScreenshot captures a screenshot of the tracked tab.
+func (c *Client) InteractScreenshot(ctx context.Context, args Args) (*Result, error) {
+	return c.Interact(ctx, "screenshot", args)
+}
+
+// withArg returns a copy of args with key set; empty values are omitted.
+func withArg(args Args, key, value string) Args {
+	out := make(Args, len(args)+1)
+	for k, v := range args {
+		out[k] = v
+	}
+	if value != "" {
+		out[key] = value
+	}
+	return out
+}
@@ -40,6 +40,11 @@ const WIRE_PAIRS = [
     ts: 'src/types/wire-websocket-event.ts',
     types: [{ go: 'WireWebSocketEvent', ts: 'WireWebSocketEvent' }]
   },
+  {
+    go: 'internal/types/wire_sse_event.go',
+    ts: 'src/types/wire-sse-event.ts',
+    types: [{ go: 'WireSSEEvent', ts: 'WireSSEEvent' }]
+  },
   {
     go: 'internal/performance/wire_performance.go',
     ts: 'src/types/wire-performance-snapshot.ts',
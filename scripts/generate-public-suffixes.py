@@ -0,0 +1,73 @@
+#!/usr/bin/env python3
+# Purpose: Regenerates internal/util/public_suffixes.dat from the Public Suffix List.
+# Why: eTLD+1 grouping must follow the full PSL (ICANN and private sections), and the daemon ships without network access to fetch it.
+# Docs: docs/features/feature/enterprise-audit/index.md
+
+"""
+Regenerate the embedded Public Suffix List used by util.RegistrableDomain.
+
+Usage:
+    python3 scripts/generate-public-suffixes.py                 # download from publicsuffix.org
+    python3 scripts/generate-public-suffixes.py path/to/list.dat  # use a local copy
+
+Rules are written one per line, lowercased, with internationalized labels converted to
+punycode so they match the ASCII hostnames that url.Parse returns. Wildcard ("*.ck") and
+exception ("!www.ck") rules are kept as-is.
+"""
+
+import hashlib
+import sys
+import urllib.request
+from pathlib import Path
+
+PSL_URL = "https://publicsuffix.org/list/public_suffix_list.dat"
+OUTPUT_FILE = Path(__file__).resolve().parent.parent / "internal/util/public_suffixes.dat"
+
+
+def ascii_label(label):
+    """Return label in the ASCII form browsers put in hostnames."""
+    if label == "*" or label.isascii():
+        return label
+    return "xn--" + label.encode("punycode").decode("ascii")
+
+
+def ascii_rule(rule):
+    """Convert one PSL rule, keeping a leading '!' exception marker."""
+    prefix = ""
+    if rule.startswith("!"):
+        prefix, rule = "!", rule[1:]
+    return prefix + ".".join(ascii_label(label) for label in rule.lower().split("."))
+
+
+def parse(text):
+    """Yield rules from PSL text: the first whitespace-delimited token of each non-comment line."""
+    for line in text.splitlines():
+        line = line.strip()
+        if not line or line.startswith("//"):
+            continue
+        yield ascii_rule(line.split()[0])
+
+
+def main():
+    if len(sys.argv) > 1:
+        source = sys.argv[1]
+        text = Path(source).read_text(encoding="utf-8")
+    else:
+        source = PSL_URL
+        with urllib.request.urlopen(PSL_URL, timeout=30) as resp:
+            text = resp.read().decode("utf-8")
+
+    rules = list(dict.fromkeys(parse(text)))
+    digest = hashlib.sha256(text.encode("utf-8")).hexdigest()
+    header = [
+        "// Generated by scripts/generate-public-suffixes.py. DO NOT EDIT.",
+        f"// Source: {PSL_URL} (sha256 {digest})",
+        "// License: Mozilla Public License 2.0, https://mozilla.org/MPL/2.0/",
+        f"// Rules: {len(rules)}",
+    ]
+    OUTPUT_FILE.write_text("\n".join(header + rules) + "\n", encoding="utf-8")
+    print(f"wrote {len(rules)} rules from {source} to {OUTPUT_FILE}")
+
+
+if __name__ == "__main__":
+    main()
@@ -26,6 +26,7 @@ const WIRE_PAIRS = [
   { go: 'internal/types/wire_enhanced_action.go', ts: 'src/types/wire-enhanced-action.ts' },
   { go: 'internal/types/wire_network.go', ts: 'src/types/wire-network.ts' },
   { go: 'internal/types/wire_websocket_event.go', ts: 'src/types/wire-websocket-event.ts' },
+  { go: 'internal/types/wire_sse_event.go', ts: 'src/types/wire-sse-event.ts' },
   { go: 'internal/performance/wire_performance.go', ts: 'src/types/wire-performance-snapshot.ts' }
 ]
 
@@ -35,7 +36,8 @@ const WIRE_PAIRS = [
  */
 const TYPE_OVERRIDES = {
   // direction is a string in Go but a union type in TS
-  'WireWebSocketEvent.direction': "'incoming' | 'outgoing'"
+  'WireWebSocketEvent.direction': "'incoming' | 'outgoing'",
+  'WireSSEEvent.event': "'open' | 'message' | 'error' | 'retry' | 'close'"
 }
 
 /**
@@ -65,6 +67,10 @@ const FILE_DESCRIPTIONS = {
     overview: 'Wire type for WebSocket events',
     description: 'Canonical TypeScript definition for the WebSocketEvent HTTP payload.'
   },
+  'wire_sse_event.go': {
+    overview: 'Wire type for Server-Sent Events',
+    description: 'Canonical TypeScript definition for the SSEEvent HTTP payload.'
+  },
   'wire_performance.go': {
     overview: 'Wire types for performance snapshots',
     description: 'Canonical TypeScript definitions for the PerformanceSnapshot HTTP payload.'
@@ -83,6 +89,7 @@ const STRUCT_COMMENT_OVERRIDES = {
     'WireNetworkWaterfallEntry is the JSON shape for a single PerformanceResourceTiming entry.',
   WireNetworkWaterfallPayload: 'WireNetworkWaterfallPayload is the top-level shape POSTed to /network-waterfall.',
   WireWebSocketEvent: 'WireWebSocketEvent is the JSON shape sent over HTTP for captured WebSocket events.',
+  WireSSEEvent: 'WireSSEEvent is the JSON shape sent over HTTP for captured EventSource events.',
   WirePerformanceTiming: 'WirePerformanceTiming holds navigation timing metrics.',
   WireTypeSummary: 'WireTypeSummary holds per-type resource metrics.',
   WireSlowRequest: 'WireSlowRequest represents one of the slowest network requests.',
@@ -104,9 +111,16 @@ const SERVER_ONLY_COMMENTS = {
   ],
   WireNetworkBody: [
     '// server-only: ts — server-side timestamp',
-    '// server-only: response_headers, has_auth_header, binary_format, format_confidence, test_ids'
+    '// server-only: response_headers, has_auth_header, binary_format, format_confidence, test_ids',
+    '// server-only: request_id, full_body_ref — full_body_ref is set when a body spills past the inline limit',
+    '// server-only: body_evicted — set when memory pressure shed the bodies',
+    '// server-only: binary_preview — summary of a captured binary response'
+  ],
+  WireWebSocketEvent: [
+    '// server-only: sampled, binary_format, format_confidence, test_ids',
+    '// server-only: data_evicted — set when memory pressure shed the payload'
   ],
-  WireWebSocketEvent: ['// server-only: sampled, binary_format, format_confidence, tab_id, test_ids'],
+  WireSSEEvent: ['// server-only: test_ids'],
   WirePerformanceSnapshot: ['// server-only: resources — added by Go daemon for causal diffing']
 }
 
@@ -117,11 +117,19 @@ for p in sorted(spec.get('paths', {}).keys()):
 
 ROUTE_SYNC_OK=true
 
-# Check Go routes exist in OpenAPI (skip / and /clients/ which is a prefix pattern)
+# Check Go routes exist in OpenAPI (skip / and the /clients/ prefix pattern).
+# A prefix route ("/svc/") is documented by a single-parameter path ("/svc/{method}").
 for route in $GO_ROUTES; do
   if [ "$route" = "/" ] || [ "$route" = "/clients/" ] || [ "$route" = "/api/status" ] || [ "$route" = "/diagnostics.json" ] || [ "$route" = "/logs.html" ] || [ "$route" = "/setup" ] || [ "$route" = "/docs" ]; then
     continue
   fi
+  case "$route" in
+    */)
+      if echo "$OPENAPI_PATHS" | grep -qE "^${route//./\\.}\{[^/}]+\}$"; then
+        continue
+      fi
+      ;;
+  esac
   if ! echo "$OPENAPI_PATHS" | grep -qx "$route"; then
     fail "Route $route registered in Go but missing from openapi.json"
     ROUTE_SYNC_OK=false
@@ -157,11 +165,13 @@ bold "5. Checking extensionOnly middleware on ingest endpoints..."
 # These endpoints MUST have extensionOnly (extension-facing, data ingest)
 MUST_HAVE_EXTENSION_ONLY=(
   "/websocket-events"
+  "/sse-events"
   "/network-bodies"
   "/network-waterfall"
   "/query-result"
   "/enhanced-actions"
   "/performance-snapshots"
+  "/coverage-snapshots"
   "/sync"
   "/screenshots"
   "/logs"
@@ -203,7 +213,7 @@ EMPTY_ERRORS=$(grep -rn -A1 'w\.WriteHeader(http\.Status' cmd/browser-agent/ int
   | grep -v '_test.go' \
   | grep -B1 'return$' \
   | grep 'WriteHeader' \
-  | grep -vE 'StatusOK|StatusNoContent|StatusMethodNotAllowed' \
+  | grep -vE 'StatusOK|StatusNoContent|StatusNotModified|StatusMethodNotAllowed' \
   || true)
 
 if [ -n "$EMPTY_ERRORS" ]; then
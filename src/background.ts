@@ -72,6 +72,7 @@ export {
   sharedServerCircuitBreaker,
   logBatcher,
   wsBatcher,
+  sseBatcher,
   enhancedActionBatcher,
   networkBodyBatcher,
   perfBatcher
@@ -1,16 +1,17 @@
 /**
- * Purpose: Creates concrete batcher instances for each telemetry data type (logs, WebSocket, actions, network bodies, performance).
+ * Purpose: Creates concrete batcher instances for each telemetry data type (logs, WebSocket, SSE, actions, network bodies, performance).
  * Why: Isolates batcher wiring from business logic in index.ts to keep module initialization explicit.
  * Docs: docs/features/feature/backend-log-streaming/index.md
  */
 
 // batcher-instances.ts — Concrete batcher instances for each data type.
-// Creates log, WebSocket, enhanced-action, network-body, and performance batchers,
+// Creates log, WebSocket, SSE, enhanced-action, network-body, and performance batchers,
 // each wired to the shared circuit breaker and connection-status tracking.
 
 import type {
   LogEntry,
   WebSocketEvent,
+  WireSSEEvent,
   NetworkBodyPayload,
   EnhancedAction,
   PerformanceSnapshot
@@ -21,6 +22,7 @@ import {
   createBatcherWithCircuitBreaker,
   sendLogsToServer,
   sendWSEventsToServer,
+  sendSSEEventsToServer,
   sendEnhancedActionsToServer,
   sendNetworkBodiesToServer,
   sendPerformanceSnapshotsToServer,
@@ -90,6 +92,8 @@ export interface BatcherInstances {
   logBatcher: Batcher<LogEntry>
   wsBatcherWithCB: BatcherWithCircuitBreaker<WebSocketEvent>
   wsBatcher: Batcher<WebSocketEvent>
+  sseBatcherWithCB: BatcherWithCircuitBreaker<WireSSEEvent>
+  sseBatcher: Batcher<WireSSEEvent>
   enhancedActionBatcherWithCB: BatcherWithCircuitBreaker<EnhancedAction>
   enhancedActionBatcher: Batcher<EnhancedAction>
   networkBodyBatcherWithCB: BatcherWithCircuitBreaker<NetworkBodyPayload>
@@ -127,6 +131,11 @@ export function createBatcherInstances(deps: BatcherDeps, sharedCircuitBreaker:
     { debounceMs: 200, maxBatchSize: 100, sharedCircuitBreaker }
   )
 
+  const sseBatcherWithCB = createBatcherWithCircuitBreaker<WireSSEEvent>(
+    withConnectionStatus(deps, (events) => sendSSEEventsToServer(deps.getServerUrl(), events, deps.debugLog)),
+    { debounceMs: 200, maxBatchSize: 100, sharedCircuitBreaker }
+  )
+
   const enhancedActionBatcherWithCB = createBatcherWithCircuitBreaker<EnhancedAction>(
     withConnectionStatus(deps, (actions) => sendEnhancedActionsToServer(deps.getServerUrl(), actions, deps.debugLog)),
     { debounceMs: 200, maxBatchSize: 50, sharedCircuitBreaker }
@@ -149,6 +158,8 @@ export function createBatcherInstances(deps: BatcherDeps, sharedCircuitBreaker:
     logBatcher: logBatcherWithCB.batcher,
     wsBatcherWithCB,
     wsBatcher: wsBatcherWithCB.batcher,
+    sseBatcherWithCB,
+    sseBatcher: sseBatcherWithCB.batcher,
     enhancedActionBatcherWithCB,
     enhancedActionBatcher: enhancedActionBatcherWithCB.batcher,
     networkBodyBatcherWithCB,
@@ -0,0 +1,97 @@
+// browser-environment.ts — Browser metadata (UA, viewport, DPR, locale, timezone) reported with each sync.
+// Measured in the tracked page so emulation and zoom show up as the page sees them; generated
+// tests replay it through Playwright's test.use block.
+
+// =============================================================================
+// TYPES
+// =============================================================================
+
+/** Browser metadata sent as settings.environment on /sync. */
+export interface BrowserEnvironment {
+  user_agent: string
+  locale: string
+  timezone: string
+  viewport_width?: number
+  viewport_height?: number
+  device_pixel_ratio?: number
+  extension_version: string
+}
+
+type PageEnvironment = Omit<BrowserEnvironment, 'extension_version'>
+
+// =============================================================================
+// MODULE STATE
+// =============================================================================
+
+/** Re-measure at most this often while the tracked tab and URL stay the same. */
+const ENVIRONMENT_REFRESH_MS = 30_000
+
+let cached: { tabId: number; url: string; at: number; env: BrowserEnvironment } | null = null
+
+// =============================================================================
+// PROBE
+// =============================================================================
+
+/**
+ * Self-contained: read the page's view of the browser environment.
+ * MUST remain self-contained — Chrome serializes the function source only (no closures).
+ */
+export function pageEnvironmentProbe(): PageEnvironment {
+  return {
+    user_agent: navigator.userAgent,
+    locale: navigator.language,
+    timezone: Intl.DateTimeFormat().resolvedOptions().timeZone,
+    viewport_width: window.innerWidth,
+    viewport_height: window.innerHeight,
+    device_pixel_ratio: window.devicePixelRatio || 1
+  }
+}
+
+/** The service worker's view, used when no page can be scripted (no tab, chrome:// pages). */
+function workerEnvironment(): PageEnvironment {
+  return {
+    user_agent: typeof navigator !== 'undefined' ? navigator.userAgent : '',
+    locale: typeof navigator !== 'undefined' ? navigator.language : '',
+    timezone: Intl.DateTimeFormat().resolvedOptions().timeZone
+  }
+}
+
+// =============================================================================
+// PUBLIC API
+// =============================================================================
+
+/**
+ * Browser environment for the tracked tab, cached per tab and URL and refreshed every
+ * 30s so resizes are picked up without probing the page on every sync.
+ */
+export async function getBrowserEnvironment(
+  tabId: number | null | undefined,
+  url: string | null | undefined,
+  extensionVersion: string
+): Promise<BrowserEnvironment> {
+  const now = Date.now()
+  const tab = tabId || 0
+  const pageUrl = url || ''
+  if (cached && cached.tabId === tab && cached.url === pageUrl && now - cached.at < ENVIRONMENT_REFRESH_MS) {
+    return cached.env
+  }
+
+  let page: PageEnvironment | undefined
+  if (tab && typeof chrome !== 'undefined' && chrome.scripting?.executeScript) {
+    try {
+      const results = await chrome.scripting.executeScript({ target: { tabId: tab }, func: pageEnvironmentProbe })
+      page = results?.[0]?.result as PageEnvironment | undefined
+    } catch {
+      /* restricted page or closed tab: fall back to the worker's view */
+    }
+  }
+
+  const env: BrowserEnvironment = { ...(page || workerEnvironment()), extension_version: extensionVersion }
+  cached = { tabId: tab, url: pageUrl, at: now, env }
+  return env
+}
+
+/** Forget the cached measurement (tests, tracked tab changes). */
+export function resetBrowserEnvironmentCache(): void {
+  cached = null
+}
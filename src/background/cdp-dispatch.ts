@@ -12,10 +12,15 @@ import type { PendingQuery } from '../types/queries.js'
 import type { SyncClient } from './sync-client.js'
 import type { DOMActionParams, DOMResult } from './dom-types.js'
 import type { SendAsyncResultFn, ActionToastFn } from './commands/helpers.js'
-import { CDP_VERSION } from '../lib/constants.js'
 import { errorMessage } from '../lib/error-utils.js'
 import { KEY_CODES, charToKeyInfo } from './cdp-key-mappings.js'
 import { resolveElement, buildCDPResult } from './cdp-element-resolve.js'
+import {
+  attachDebugger,
+  detachDebugger,
+  holdPassthroughDebugger,
+  releasePassthroughDebugger
+} from './device-emulation.js'
 
 interface CDPActionParams {
   action: string
@@ -25,6 +30,8 @@ interface CDPActionParams {
   text?: string
   key?: string
   modifiers?: number
+  method?: string
+  params?: Record<string, unknown>
 }
 
 async function cdpSend(tabId: number, method: string, params: Record<string, unknown>): Promise<void> {
@@ -179,6 +186,21 @@ async function cdpKeyPress(tabId: number, params: CDPActionParams): Promise<Reco
   }
 }
 
+// Raw command from interact(what="cdp"). The daemon has already applied the operator
+// policy; the result is returned as-is and redacted server-side.
+async function cdpPassthrough(tabId: number, params: CDPActionParams): Promise<Record<string, unknown>> {
+  if (!params.method) {
+    throw new Error('passthrough requires method parameter')
+  }
+  const result = await chrome.debugger.sendCommand({ tabId }, params.method, params.params || {})
+  return {
+    success: true,
+    action: 'cdp',
+    method: params.method,
+    result: result ?? {}
+  }
+}
+
 function parseCDPParams(query: PendingQuery): CDPActionParams | null {
   try {
     const raw = typeof query.params === 'string' ? JSON.parse(query.params) : query.params
@@ -340,7 +362,7 @@ export async function tryCDPEscalation(
     if (!resolved) return null
 
     // Step 2: Attach debugger
-    await chrome.debugger.attach({ tabId }, CDP_VERSION)
+    await attachDebugger(tabId)
 
     try {
       // Step 3: Execute CDP action
@@ -373,11 +395,7 @@ export async function tryCDPEscalation(
       // Step 4: Build DOMResult with matched evidence
       return buildCDPResult(action, selector, resolved, Date.now() - startTime)
     } finally {
-      try {
-        await chrome.debugger.detach({ tabId })
-      } catch {
-        /* already detached */
-      }
+      await detachDebugger(tabId)
     }
   } catch {
     // CDP unavailable or failed — fall back to DOM primitives silently
@@ -386,7 +404,7 @@ export async function tryCDPEscalation(
 }
 
 // =============================================================================
-// DIRECT CDP QUERIES (hardware_click via Go-side cdp_action)
+// DIRECT CDP QUERIES (hardware_click and cdp passthrough via Go-side cdp_action)
 // =============================================================================
 
 export async function executeCDPAction(
@@ -408,11 +426,31 @@ export async function executeCDPAction(
     return
   }
 
-  const toastLabel = action === 'key_press' ? 'Typing...' : `CDP ${action}`
+  const toastLabel =
+    action === 'key_press' ? 'Typing...' : action === 'passthrough' ? `CDP ${params.method}` : `CDP ${action}`
   actionToast(tabId, toastLabel, undefined, 'trying', 10000)
 
+  if (action === 'release') {
+    try {
+      const released = await releasePassthroughDebugger(tabId)
+      actionToast(tabId, toastLabel, undefined, 'success')
+      sendAsyncResult(syncClient, query.id, query.correlation_id!, 'complete', {
+        success: true,
+        action: 'cdp',
+        released
+      })
+    } catch (err) {
+      const errorMsg = mapCDPError(err)
+      actionToast(tabId, toastLabel, errorMsg, 'error')
+      sendAsyncResult(syncClient, query.id, query.correlation_id!, 'error', null, errorMsg)
+    }
+    return
+  }
+
+  // Passthrough holds its session so state it sets survives until an explicit release.
+  const passthrough = action === 'passthrough'
   try {
-    await chrome.debugger.attach({ tabId }, CDP_VERSION)
+    await (passthrough ? holdPassthroughDebugger(tabId) : attachDebugger(tabId))
   } catch (err) {
     const errorMsg = mapCDPError(err)
     actionToast(tabId, toastLabel, errorMsg, 'error')
@@ -433,6 +471,9 @@ export async function executeCDPAction(
       case 'key_press':
         result = await cdpKeyPress(tabId, params)
         break
+      case 'passthrough':
+        result = await cdpPassthrough(tabId, params)
+        break
       default:
         throw new Error(`Unknown CDP action: ${action}`)
     }
@@ -444,10 +485,6 @@ export async function executeCDPAction(
     actionToast(tabId, toastLabel, errorMsg, 'error')
     sendAsyncResult(syncClient, query.id, query.correlation_id!, 'error', null, errorMsg)
   } finally {
-    try {
-      await chrome.debugger.detach({ tabId })
-    } catch {
-      // Already detached or tab closed — safe to ignore
-    }
+    if (!passthrough) await detachDebugger(tabId)
   }
 }
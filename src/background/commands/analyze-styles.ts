@@ -0,0 +1,167 @@
+// analyze-styles.ts — Layout inspection probe for analyze(what="styles").
+// Collects raw computed styles and rects for the element and its ancestors;
+// the server turns them into box model, stacking, clipping, and visibility findings.
+
+import { registerCommand } from './registry.js'
+import { errorMessage } from '../../lib/error-utils.js'
+
+// =============================================================================
+// LAYOUT INSPECTION PROBE
+// =============================================================================
+
+interface LayoutNode {
+  tag: string
+  selector: string
+  styles: Record<string, string>
+  rect: { x: number; y: number; width: number; height: number }
+}
+
+interface LayoutProbeResult {
+  match_count: number
+  viewport: { width: number; height: number; scroll_x: number; scroll_y: number }
+  element: LayoutNode | null
+  ancestors: LayoutNode[]
+  error?: string
+  message?: string
+}
+
+/**
+ * Self-contained probe injected via chrome.scripting.executeScript.
+ * MUST remain self-contained — Chrome serializes the function source only (no closures).
+ */
+export function layoutInspectProbe(selector: string, properties: string[]): LayoutProbeResult {
+  const MAX_ANCESTORS = 64
+  // Properties the server needs to explain stacking, clipping, and visibility.
+  const LAYOUT_PROPERTIES = [
+    'display',
+    'visibility',
+    'opacity',
+    'position',
+    'z-index',
+    'overflow-x',
+    'overflow-y',
+    'transform',
+    'filter',
+    'backdrop-filter',
+    'perspective',
+    'clip-path',
+    'mask-image',
+    'mix-blend-mode',
+    'isolation',
+    'will-change',
+    'contain',
+    'container-type'
+  ]
+  const ELEMENT_PROPERTIES = [
+    'width',
+    'height',
+    'box-sizing',
+    'color',
+    'background-color',
+    'font-size',
+    'line-height',
+    'pointer-events',
+    'margin-top',
+    'margin-right',
+    'margin-bottom',
+    'margin-left',
+    'border-top-width',
+    'border-right-width',
+    'border-bottom-width',
+    'border-left-width',
+    'padding-top',
+    'padding-right',
+    'padding-bottom',
+    'padding-left'
+  ]
+
+  function describe(el: Element): string {
+    if (el.id) return `#${el.id}`
+    const tag = el.tagName.toLowerCase()
+    const classes = Array.from(el.classList)
+      .slice(0, 3)
+      .map((c) => `.${c}`)
+      .join('')
+    return tag + classes
+  }
+
+  function snapshot(el: Element, props: string[]): LayoutNode {
+    const style = window.getComputedStyle(el)
+    const styles: Record<string, string> = {}
+    for (const prop of props) {
+      styles[prop] = style.getPropertyValue(prop)
+    }
+    const rect = el.getBoundingClientRect()
+    return {
+      tag: el.tagName.toLowerCase(),
+      selector: describe(el),
+      styles,
+      rect: { x: rect.x, y: rect.y, width: rect.width, height: rect.height }
+    }
+  }
+
+  const viewport = {
+    width: window.innerWidth,
+    height: window.innerHeight,
+    scroll_x: window.scrollX,
+    scroll_y: window.scrollY
+  }
+
+  let matches: NodeListOf<Element>
+  try {
+    matches = document.querySelectorAll(selector)
+  } catch (err) {
+    return {
+      match_count: 0,
+      viewport,
+      element: null,
+      ancestors: [],
+      error: 'invalid_selector',
+      message: (err as Error)?.message || `Invalid selector: ${selector}`
+    }
+  }
+
+  const target = matches[0]
+  if (!target) return { match_count: 0, viewport, element: null, ancestors: [] }
+
+  const elementProps = Array.from(new Set([...LAYOUT_PROPERTIES, ...ELEMENT_PROPERTIES, ...(properties || [])]))
+  const ancestors: LayoutNode[] = []
+  for (let p = target.parentElement; p && ancestors.length < MAX_ANCESTORS; p = p.parentElement) {
+    ancestors.push(snapshot(p, LAYOUT_PROPERTIES))
+  }
+
+  return {
+    match_count: matches.length,
+    viewport,
+    element: snapshot(target, elementProps),
+    ancestors
+  }
+}
+
+registerCommand('layout_inspect', async (ctx) => {
+  const params = ctx.params as { selector?: string; properties?: string[] }
+  try {
+    const results = await chrome.scripting.executeScript({
+      target: { tabId: ctx.tabId },
+      world: 'ISOLATED',
+      func: layoutInspectProbe,
+      args: [params.selector || '', Array.isArray(params.properties) ? params.properties : []]
+    })
+
+    const result = results?.[0]?.result
+    if (!result) {
+      ctx.sendResult({
+        error: 'layout_inspect_failed',
+        message: 'Layout probe returned no result'
+      })
+      return
+    }
+
+    ctx.sendResult(result)
+  } catch (err) {
+    ctx.sendResult({
+      error: 'layout_inspect_failed',
+      message: errorMessage(err, 'Layout probe failed')
+    })
+  }
+})
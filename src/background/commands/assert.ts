@@ -0,0 +1,117 @@
+// assert.ts — Page snapshot for observe(what="assert").
+// URL, title, and every selector check are read in a single injected pass so the daemon
+// judges all DOM conditions against the same moment.
+
+import { registerCommand } from './registry.js'
+import { errorMessage } from '../../lib/error-utils.js'
+
+// =============================================================================
+// TYPES
+// =============================================================================
+
+export interface AssertElementCheck {
+  selector: string
+  text_contains?: string
+}
+
+export interface AssertElementResult {
+  selector: string
+  count: number
+  visible_count: number
+  texts?: string[]
+  text_match?: string
+  error?: string
+}
+
+export interface AssertPageSnapshot {
+  url: string
+  title: string
+  elements: AssertElementResult[]
+}
+
+// =============================================================================
+// PROBE
+// =============================================================================
+
+/**
+ * Evaluate selector checks against the live DOM and read URL and title.
+ * MUST remain self-contained — Chrome serializes the function source only (no closures).
+ */
+export function assertPageProbe(checks: AssertElementCheck[]): AssertPageSnapshot {
+  const MAX_TEXTS = 3
+  const MAX_TEXT_LENGTH = 200
+  const textOf = (el: Element): string => {
+    const raw = (el as HTMLElement).innerText ?? el.textContent ?? ''
+    return raw.replace(/\s+/g, ' ').trim()
+  }
+  const clip = (text: string): string => (text.length > MAX_TEXT_LENGTH ? text.slice(0, MAX_TEXT_LENGTH) + '…' : text)
+  const isVisible = (el: Element): boolean => {
+    const checkVisibility = (el as Element & { checkVisibility?: (opts?: object) => boolean }).checkVisibility
+    if (typeof checkVisibility === 'function') {
+      return checkVisibility.call(el, { checkOpacity: true, checkVisibilityCSS: true })
+    }
+    const style = getComputedStyle(el)
+    if (style.display === 'none' || style.visibility === 'hidden' || style.opacity === '0') return false
+    return el.getClientRects().length > 0
+  }
+
+  const elements: AssertElementResult[] = []
+  for (const check of checks || []) {
+    let matches: Element[]
+    try {
+      matches = Array.from(document.querySelectorAll(check.selector))
+    } catch (err) {
+      elements.push({
+        selector: check.selector,
+        count: 0,
+        visible_count: 0,
+        error: err instanceof Error ? err.message : String(err)
+      })
+      continue
+    }
+    const result: AssertElementResult = {
+      selector: check.selector,
+      count: matches.length,
+      visible_count: matches.filter(isVisible).length
+    }
+    const texts = matches.slice(0, MAX_TEXTS).map((el) => clip(textOf(el)))
+    if (texts.length > 0) result.texts = texts
+    if (check.text_contains) {
+      for (const el of matches) {
+        const text = textOf(el)
+        if (text.includes(check.text_contains)) {
+          result.text_match = clip(text)
+          break
+        }
+      }
+    }
+    elements.push(result)
+  }
+  return { url: location.href, title: document.title, elements }
+}
+
+// =============================================================================
+// COMMAND
+// =============================================================================
+
+registerCommand('assert', async (ctx) => {
+  const params = ctx.params as { checks?: AssertElementCheck[] }
+  try {
+    const results = await chrome.scripting.executeScript({
+      target: { tabId: ctx.tabId },
+      func: assertPageProbe,
+      args: [params.checks || []]
+    })
+    const snapshot = results?.[0]?.result as AssertPageSnapshot | undefined
+    if (!snapshot) {
+      ctx.sendResult({ error: 'assert_failed', message: 'The page returned no snapshot' })
+      return
+    }
+    ctx.sendResult(snapshot)
+  } catch (err) {
+    ctx.sendResult({
+      error: 'assert_failed',
+      message: errorMessage(err, 'Assert snapshot failed')
+    })
+  }
+})
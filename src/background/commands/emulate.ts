@@ -0,0 +1,39 @@
+// emulate.ts — Applies or clears device emulation for interact(what="emulate").
+// The daemon resolves presets to concrete metrics, so the extension only applies
+// what it is sent and reports back what is now in effect.
+
+import { registerCommand } from './registry.js'
+import { requireAiWebPilot } from './helpers.js'
+import { errorMessage } from '../../lib/error-utils.js'
+import { applyDeviceEmulation, clearDeviceEmulation, type DeviceEmulationSpec } from '../device-emulation.js'
+
+registerCommand('emulate', async (ctx) => {
+  if (!requireAiWebPilot(ctx)) return
+  const params = ctx.params as Partial<DeviceEmulationSpec> & { action?: string }
+  try {
+    if (params.action === 'clear') {
+      ctx.sendResult(await clearDeviceEmulation(ctx.tabId))
+      return
+    }
+    if (!params.width || !params.height) {
+      ctx.sendResult({ error: 'missing_viewport', message: 'Emulation needs a width and height' })
+      return
+    }
+    ctx.sendResult(
+      await applyDeviceEmulation(ctx.tabId, {
+        device: params.device,
+        width: params.width,
+        height: params.height,
+        device_scale_factor: params.device_scale_factor || 1,
+        user_agent: params.user_agent,
+        touch: params.touch === true,
+        mobile: params.mobile === true
+      })
+    )
+  } catch (err) {
+    ctx.sendResult({
+      error: 'emulation_failed',
+      message: errorMessage(err, 'Device emulation failed')
+    })
+  }
+})
@@ -291,7 +291,16 @@ const TARGETED_QUERY_TYPES = new Set<string>([
   'page_summary',
   'page_structure',
   'navigation',
-  'feature_gates'
+  'feature_gates',
+  'layout_inspect',
+  'i18n_scan',
+  'seo_scan',
+  'coverage_snapshot',
+  'form_fill',
+  'replay_request',
+  'emulate',
+  'auth_state',
+  'assert'
 ])
 
 export function requiresTargetTab(queryType: string): boolean {
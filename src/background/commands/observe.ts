@@ -7,7 +7,7 @@
 // Handles: screenshot, waterfall, page_info, tabs.
 
 import { debugLog } from '../index.js'
-import { getServerUrl } from '../state.js'
+import { getServerUrl, getScreenshotRedactSelectors } from '../state.js'
 import { DebugCategory } from '../debug.js'
 import { recordScreenshot } from '../state-manager.js'
 import { domPrimitiveListInteractive } from '../dom-primitives-list-interactive.js'
@@ -16,7 +16,8 @@ import { CDP_VERSION } from '../../lib/constants.js'
 import { errorMessage } from '../../lib/error-utils.js'
 import { delay } from '../../lib/timeout-utils.js'
 import { postDaemonJSON } from '../../lib/daemon-http.js'
-import { captureVisibleTabSafe } from '../tab-state.js'
+import { captureVisibleTabSafe, measureScreenshotRegions } from '../tab-state.js'
+import type { ScreenshotRegion, ScreenshotRegions } from '../tab-state.js'
 
 // =============================================================================
 // SCREENSHOT
@@ -136,13 +137,60 @@ function computeFullPageCaptureDimensions(
   }
 }
 
+/**
+ * Self-contained: scroll the selected element into view and measure its viewport-relative box.
+ * Returns an error code instead of throwing so the caller can surface it verbatim.
+ */
+function screenshotMeasureElement(selector: string): ScreenshotRegion | { error: string; message: string } {
+  let el: Element | null
+  try {
+    el = document.querySelector(selector)
+  } catch {
+    return { error: 'invalid_selector', message: `Invalid CSS selector: ${selector}` }
+  }
+  if (!el) return { error: 'element_not_found', message: `No element matches selector: ${selector}` }
+  el.scrollIntoView({ block: 'center', inline: 'center' })
+  const rect = el.getBoundingClientRect()
+  if (rect.width <= 0 || rect.height <= 0) {
+    return { error: 'element_not_visible', message: `Element has no rendered size: ${selector}` }
+  }
+  return {
+    x: rect.left,
+    y: rect.top,
+    width: rect.width,
+    height: rect.height,
+    viewport_width: window.innerWidth,
+    device_pixel_ratio: window.devicePixelRatio || 1
+  }
+}
+
+/** Optional element clip and annotation boxes sent with a screenshot upload. */
+interface ScreenshotUploadExtras {
+  selector?: string
+  clip?: ScreenshotRegion
+  redact?: ScreenshotRegion[]
+  highlight?: ScreenshotRegion[]
+}
+
+/** Redaction and highlight selectors for one capture. */
+interface ScreenshotAnnotations {
+  redactSelectors: string[]
+  highlightSelector: string
+}
+
 /** Post screenshot data to server for saving and query resolution. */
-async function postScreenshot(dataUrl: string, pageUrl: string | undefined, queryId: string): Promise<boolean> {
+async function postScreenshot(
+  dataUrl: string,
+  pageUrl: string | undefined,
+  queryId: string,
+  extras: ScreenshotUploadExtras = {}
+): Promise<boolean> {
   try {
     const response = await postDaemonJSON(`${getServerUrl()}/screenshots`, {
       data_url: dataUrl,
       url: pageUrl,
-      query_id: queryId
+      query_id: queryId,
+      ...extras
     })
     return response.ok
   } catch {
@@ -150,19 +198,86 @@ async function postScreenshot(dataUrl: string, pageUrl: string | undefined, quer
   }
 }
 
+/**
+ * Redaction selectors come from the query (so a just-configured list applies at once) and
+ * from the last sync; the highlight selector only from the query.
+ */
+function screenshotAnnotations(params: Record<string, unknown>): ScreenshotAnnotations {
+  const fromQuery = Array.isArray(params.redact_selectors)
+    ? params.redact_selectors.filter((s): s is string => typeof s === 'string' && s.length > 0)
+    : []
+  return {
+    redactSelectors: [...new Set([...fromQuery, ...getScreenshotRedactSelectors()])],
+    highlightSelector: typeof params.highlight_selector === 'string' ? params.highlight_selector.trim() : ''
+  }
+}
+
+/**
+ * Measure annotation boxes right before a capture. Returns null after reporting the error when
+ * redaction is on but the page cannot be measured: an unredacted image must not be uploaded.
+ */
+async function measureAnnotations(
+  ctx: { tabId: number; sendResult: (r: unknown) => void },
+  annotations: ScreenshotAnnotations
+): Promise<ScreenshotUploadExtras | null> {
+  const { redactSelectors, highlightSelector } = annotations
+  if (redactSelectors.length === 0 && !highlightSelector) return {}
+  let regions: ScreenshotRegions
+  try {
+    regions = await measureScreenshotRegions(ctx.tabId, redactSelectors, highlightSelector)
+  } catch (err) {
+    if (redactSelectors.length > 0) {
+      ctx.sendResult({
+        error: 'redaction_failed',
+        message: errorMessage(err, 'Could not measure redacted regions; screenshot not taken')
+      })
+      return null
+    }
+    return {}
+  }
+  return {
+    ...(redactSelectors.length > 0 ? { redact: regions.redact } : {}),
+    ...(highlightSelector ? { highlight: regions.highlight } : {})
+  }
+}
+
 registerCommand('screenshot', async (ctx) => {
   const format = ctx.params.format === 'png' ? 'png' : 'jpeg'
   const quality = typeof ctx.params.quality === 'number' ? ctx.params.quality : 80
   const fullPage = ctx.params.full_page === true
+  const clipSelector =
+    ctx.params.clip === true && typeof ctx.params.selector === 'string' ? ctx.params.selector.trim() : ''
+  const annotations = screenshotAnnotations(ctx.params)
 
   try {
     const tab = await chrome.tabs.get(ctx.tabId)
 
     if (fullPage) {
-      await captureFullPage(ctx, tab, format, quality)
+      await captureFullPage(ctx, tab, format, quality, annotations)
       return
     }
 
+    // Element clip: measure in the page, capture the viewport, and let the server crop.
+    let clip: ScreenshotUploadExtras = {}
+    if (clipSelector) {
+      const measured = await chrome.scripting.executeScript({
+        target: { tabId: ctx.tabId },
+        world: 'MAIN',
+        func: screenshotMeasureElement,
+        args: [clipSelector]
+      })
+      const rect = measured?.[0]?.result as ScreenshotRegion | { error: string; message: string } | undefined
+      if (!rect || 'error' in rect) {
+        ctx.sendResult(rect ?? { error: 'element_not_found', message: `No element matches selector: ${clipSelector}` })
+        return
+      }
+      clip = { selector: clipSelector, clip: rect }
+      await delay(100) // let scrollIntoView settle before capturing
+    }
+
+    const annotated = await measureAnnotations(ctx, annotations)
+    if (!annotated) return
+
     const dataUrl = await captureVisibleTabSafe(ctx.tabId, tab.windowId, {
       format: format as 'jpeg' | 'png',
       quality
@@ -170,7 +285,7 @@ registerCommand('screenshot', async (ctx) => {
     recordScreenshot(ctx.tabId)
 
     // POST to /screenshots with query_id — server saves file and resolves query directly
-    const ok = await postScreenshot(dataUrl, tab.url, ctx.query.id)
+    const ok = await postScreenshot(dataUrl, tab.url, ctx.query.id, { ...clip, ...annotated })
     if (!ok) {
       ctx.sendResult({ error: 'screenshot_upload_failed', message: 'Server rejected screenshot' })
     }
@@ -188,7 +303,8 @@ async function captureFullPage(
   ctx: { tabId: number; query: { id: string }; sendResult: (r: unknown) => void },
   tab: chrome.tabs.Tab,
   format: 'png' | 'jpeg',
-  quality: number
+  quality: number,
+  annotations: ScreenshotAnnotations
 ): Promise<void> {
   // Step 1: Expand scrollable containers in the page
   let hintedHeight = 0
@@ -242,6 +358,10 @@ async function captureFullPage(
         // Brief pause for layout reflow after viewport resize
         await delay(150)
 
+        // The viewport now spans the page, so viewport boxes line up with the full-page image.
+        const annotated = await measureAnnotations(ctx, annotations)
+        if (!annotated) return
+
         // Step 5: Capture full-page screenshot via CDP
         const screenshotResult = (await chrome.debugger.sendCommand({ tabId: ctx.tabId }, 'Page.captureScreenshot', {
           format,
@@ -255,7 +375,7 @@ async function captureFullPage(
         const dataUrl = `data:${mimeType};base64,${screenshotResult.data}`
         recordScreenshot(ctx.tabId)
 
-        const ok = await postScreenshot(dataUrl, tab.url, ctx.query.id)
+        const ok = await postScreenshot(dataUrl, tab.url, ctx.query.id, annotated)
         if (!ok) {
           ctx.sendResult({ error: 'screenshot_upload_failed', message: 'Server rejected screenshot' })
         }
@@ -282,12 +402,14 @@ async function captureFullPage(
     debugLog(DebugCategory.CAPTURE, 'Full-page CDP failed, falling back to viewport capture', {
       error: errorMessage(err)
     })
+    const annotated = await measureAnnotations(ctx, annotations)
+    if (!annotated) return
     const dataUrl = await captureVisibleTabSafe(ctx.tabId, tab.windowId, {
       format: format as 'jpeg' | 'png',
       quality
     })
     recordScreenshot(ctx.tabId)
-    const ok = await postScreenshot(dataUrl, tab.url, ctx.query.id)
+    const ok = await postScreenshot(dataUrl, tab.url, ctx.query.id, annotated)
     if (!ok) {
       ctx.sendResult({ error: 'screenshot_upload_failed', message: 'Server rejected screenshot' })
     }
@@ -324,6 +446,7 @@ registerCommand('waterfall', async (ctx) => {
     ctx.sendResult({
       entries: result?.entries || [],
       page_url: tab.url || '',
+      tab_id: ctx.tabId,
       count: result?.entries?.length || 0
     })
     debugLog(DebugCategory.CAPTURE, 'Posted waterfall result', { queryId: ctx.query.id })
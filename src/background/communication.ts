@@ -27,6 +27,7 @@ export {
 export {
   sendLogsToServer,
   sendWSEventsToServer,
+  sendSSEEventsToServer,
   sendNetworkBodiesToServer,
   sendEnhancedActionsToServer,
   sendPerformanceSnapshotsToServer,
@@ -40,7 +41,41 @@ export {
 import type { LogEntry } from '../types/index.js'
 import { getRequestHeaders } from './server.js'
 import { errorMessage } from '../lib/error-utils.js'
-import { captureVisibleTabSafe } from './tab-state.js'
+import { captureVisibleTabSafe, measureScreenshotRegions } from './tab-state.js'
+
+/**
+ * Shrink an oversized object or array without flattening it, so path filters such as
+ * args[0].requestId still resolve. Top-level values are kept in order until the size
+ * budget is spent; later ones become '[truncated]' (arrays end with a single marker).
+ */
+function truncateStructured(arg: object, maxSize: number): unknown {
+  let budget = maxSize
+  const fits = (value: unknown): boolean => {
+    const size = (JSON.stringify(value) ?? '').length
+    if (size > budget) return false
+    budget -= size
+    return true
+  }
+
+  if (Array.isArray(arg)) {
+    const kept: unknown[] = []
+    for (const item of arg) {
+      if (!fits(item)) {
+        kept.push('[truncated]')
+        break
+      }
+      kept.push(item)
+    }
+    return kept
+  }
+
+  const result: Record<string, unknown> = {}
+  for (const [key, value] of Object.entries(arg)) {
+    // eslint-disable-next-line security/detect-object-injection -- key from Object.entries of the logged value
+    result[key] = fits(value) ? value : '[truncated]'
+  }
+  return result
+}
 
 /**
  * Truncate a single argument if too large
@@ -54,6 +89,9 @@ function truncateArg(arg: unknown, maxSize = 10240): unknown {
       if (typeof arg === 'string') {
         return arg.slice(0, maxSize) + '... [truncated]'
       }
+      if (typeof arg === 'object') {
+        return truncateStructured(arg, maxSize)
+      }
       return serialized.slice(0, maxSize) + '...[truncated]'
     }
     return arg
@@ -98,7 +136,8 @@ export function shouldCaptureLog(logLevel: string, filterLevel: string, logType?
 }
 
 /**
- * Capture a screenshot of the visible tab area
+ * Capture a screenshot of the visible tab area. Elements matching redactSelectors are
+ * measured first and blacked out by the server; if they cannot be measured, nothing is uploaded.
  */
 export async function captureScreenshot(
   tabId: number,
@@ -107,7 +146,8 @@ export async function captureScreenshot(
   errorType: string | null,
   canTakeScreenshotFn: (tabId: number) => { allowed: boolean; reason?: string; nextAllowedIn?: number | null },
   recordScreenshotFn: (tabId: number) => void,
-  debugLogFn?: (category: string, message: string, data?: unknown) => void
+  debugLogFn?: (category: string, message: string, data?: unknown) => void,
+  redactSelectors: string[] = []
 ): Promise<{
   success: boolean
   entry?: LogEntry
@@ -131,6 +171,14 @@ export async function captureScreenshot(
 
   try {
     const tab = await chrome.tabs.get(tabId)
+    let redact: unknown[] | undefined
+    if (redactSelectors.length > 0) {
+      try {
+        redact = (await measureScreenshotRegions(tabId, redactSelectors, '')).redact
+      } catch (err) {
+        throw new Error(`Screenshot redaction unavailable: ${errorMessage(err)}`)
+      }
+    }
     const dataUrl = await captureVisibleTabSafe(tabId, tab.windowId, {
       format: 'jpeg',
       quality: 80
@@ -144,7 +192,8 @@ export async function captureScreenshot(
       body: JSON.stringify({
         data_url: dataUrl,
         url: tab.url,
-        correlation_id: relatedErrorId || ''
+        correlation_id: relatedErrorId || '',
+        ...(redact ? { redact } : {})
       })
     })
 
@@ -21,6 +21,7 @@ import {
   capExtensionLogs,
   getCurrentLogLevel,
   isScreenshotOnError,
+  getScreenshotRedactSelectors,
   _setDebugModeRaw,
   setConnectionStatus,
   setConnectionCheckRunning,
@@ -205,6 +206,8 @@ const logBatcherWithCB = _batchers.logBatcherWithCB
 export const logBatcher = _batchers.logBatcher
 const wsBatcherWithCB = _batchers.wsBatcherWithCB
 export const wsBatcher = _batchers.wsBatcher
+const sseBatcherWithCB = _batchers.sseBatcherWithCB
+export const sseBatcher = _batchers.sseBatcher
 const enhancedActionBatcherWithCB = _batchers.enhancedActionBatcherWithCB
 export const enhancedActionBatcher = _batchers.enhancedActionBatcher
 const networkBodyBatcherWithCB = _batchers.networkBodyBatcherWithCB
@@ -255,6 +258,9 @@ export async function handleLogMessage(payload: LogEntry, sender: ChromeMessageS
   if (resolvedTabId !== null && resolvedTabId !== undefined) {
     entry = { ...entry, tabId: resolvedTabId } as LogEntry
   }
+  if (sender?.frameId) {
+    entry = { ...entry, frameId: sender.frameId } as LogEntry
+  }
 
   // nosemgrep: missing-template-string-indicator
   debugLog(DebugCategory.CAPTURE, `Log received: type=${(entry as { type?: string }).type}, level=${entry.level}`, {
@@ -297,7 +303,8 @@ async function maybeAutoScreenshot(errorEntry: LogEntry, sender: ChromeMessageSe
     entryType || null,
     canTakeScreenshot,
     recordScreenshot,
-    debugLog
+    debugLog,
+    getScreenshotRedactSelectors()
   )
 
   if (result.success && result.entry) {
@@ -19,6 +19,7 @@ import {
   sharedServerCircuitBreaker,
   logBatcher,
   wsBatcher,
+  sseBatcher,
   enhancedActionBatcher,
   networkBodyBatcher,
   perfBatcher,
@@ -35,6 +36,7 @@ import {
   getConnectionStatus,
   isDebugMode,
   isScreenshotOnError,
+  getScreenshotRedactSelectors,
   getCurrentLogLevel,
   isAiWebPilotEnabled,
   isAiWebPilotCacheInitialized,
@@ -244,6 +246,7 @@ async function initializeExtensionAsync(): Promise<void> {
 
       addToLogBatcher: (entry) => logBatcher.add(entry),
       addToWsBatcher: (event) => wsBatcher.add(event),
+      addToSseBatcher: (event) => sseBatcher.add(event),
       addToEnhancedActionBatcher: (action) => enhancedActionBatcher.add(action),
       addToNetworkBodyBatcher: (body) => networkBodyBatcher.add(body),
       addToPerfBatcher: (snapshot) => perfBatcher.add(snapshot),
@@ -251,7 +254,16 @@ async function initializeExtensionAsync(): Promise<void> {
       handleLogMessage,
       handleClearLogs,
       captureScreenshot: (tabId, relatedErrorId) =>
-        captureScreenshot(tabId, getServerUrl(), relatedErrorId, null, canTakeScreenshot, recordScreenshot, debugLog),
+        captureScreenshot(
+          tabId,
+          getServerUrl(),
+          relatedErrorId,
+          null,
+          canTakeScreenshot,
+          recordScreenshot,
+          debugLog,
+          getScreenshotRedactSelectors()
+        ),
       checkConnectionAndUpdate,
       clearSourceMapCache,
 
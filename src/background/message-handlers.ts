@@ -19,6 +19,7 @@ import type {
   CircuitBreakerState,
   MemoryPressureState,
   WebSocketEvent,
+  WireSSEEvent,
   EnhancedAction,
   NetworkBodyPayload,
   PerformanceSnapshot
@@ -62,6 +63,7 @@ export interface MessageHandlerDependencies {
   // Batchers
   addToLogBatcher: (entry: LogEntry) => void
   addToWsBatcher: (event: WebSocketEvent) => void
+  addToSseBatcher: (event: WireSSEEvent) => void
   addToEnhancedActionBatcher: (action: EnhancedAction) => void
   addToNetworkBodyBatcher: (body: NetworkBodyPayload) => void
   addToPerfBatcher: (snapshot: PerformanceSnapshot) => void
@@ -185,6 +187,22 @@ function validateMessageType(message: unknown, expectedType: string, deps: Messa
   return true
 }
 
+/**
+ * Attribute a captured entry to the tab and frame that produced it, so entries from
+ * several tabs stay separable on the server. IDs already on the payload win.
+ */
+function withSenderOrigin<T extends { readonly tab_id?: number; readonly frame_id?: number }>(
+  payload: T,
+  sender: ChromeMessageSender,
+  tabId?: number
+): T {
+  return {
+    ...payload,
+    tab_id: payload.tab_id ?? tabId ?? sender.tab?.id,
+    frame_id: payload.frame_id ?? sender.frameId
+  }
+}
+
 /**
  * Handle incoming message
  * Returns true if response will be sent asynchronously
@@ -206,11 +224,15 @@ function handleMessage(
       return true
 
     case 'ws_event':
-      deps.addToWsBatcher(message.payload)
+      deps.addToWsBatcher(withSenderOrigin(message.payload, sender, message.tabId))
+      return false
+
+    case 'sse_event':
+      deps.addToSseBatcher(withSenderOrigin(message.payload, sender, message.tabId))
       return false
 
     case 'enhanced_action':
-      deps.addToEnhancedActionBatcher(message.payload)
+      deps.addToEnhancedActionBatcher(withSenderOrigin(message.payload, sender, message.tabId))
       return false
 
     case 'network_body':
@@ -218,8 +240,7 @@ function handleMessage(
         deps.debugLog('capture', 'Network body dropped: capture disabled')
         return true
       }
-      // Attach tab_id from sender before batching (v5.3+)
-      deps.addToNetworkBodyBatcher({ ...message.payload, tab_id: message.payload.tab_id ?? message.tabId })
+      deps.addToNetworkBodyBatcher(withSenderOrigin(message.payload, sender, message.tabId))
       return false
 
     case 'performance_snapshot':
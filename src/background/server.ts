@@ -11,11 +11,13 @@
 import type {
   LogEntry,
   WebSocketEvent,
+  WireSSEEvent,
   NetworkBodyPayload,
   EnhancedAction,
   PerformanceSnapshot,
   ConnectionStatus
 } from '../types/index.js'
+import type { CoverageSnapshot } from './commands/observe-coverage.js'
 import { KABOOM_LOG_PREFIX } from '../lib/brand.js'
 import { getExtensionVersion } from './version-check.js'
 import { errorMessage } from '../lib/error-utils.js'
@@ -125,6 +127,17 @@ export async function sendWSEventsToServer(
   await sendTelemetryBatch(serverUrl, '/websocket-events', 'events', events, 'WS events', debugLogFn)
 }
 
+/**
+ * Send Server-Sent Events (EventSource) events to the server
+ */
+export async function sendSSEEventsToServer(
+  serverUrl: string,
+  events: WireSSEEvent[],
+  debugLogFn?: (category: string, message: string, data?: unknown) => void
+): Promise<void> {
+  await sendTelemetryBatch(serverUrl, '/sse-events', 'events', events, 'SSE events', debugLogFn)
+}
+
 /**
  * Send network bodies to the server
  */
@@ -158,6 +171,17 @@ export async function sendPerformanceSnapshotsToServer(
   await sendTelemetryBatch(serverUrl, '/performance-snapshots', 'snapshots', snapshots, 'performance snapshots', debugLogFn)
 }
 
+/**
+ * Send JS/CSS coverage snapshots to server
+ */
+export async function sendCoverageSnapshotsToServer(
+  serverUrl: string,
+  snapshots: CoverageSnapshot[],
+  debugLogFn?: (category: string, message: string, data?: unknown) => void
+): Promise<void> {
+  await sendTelemetryBatch(serverUrl, '/coverage-snapshots', 'snapshots', snapshots, 'coverage snapshots', debugLogFn)
+}
+
 /**
  * Check server health
  */
@@ -102,6 +102,18 @@ export function isScreenshotOnError(): boolean {
   return state.screenshotOnError
 }
 
+/** CSS selectors the server asked to black out on every screenshot (capture_overrides). */
+export function getScreenshotRedactSelectors(): string[] {
+  const raw = state.captureOverrides.screenshot_redact_selectors
+  if (!raw) return []
+  try {
+    const parsed: unknown = JSON.parse(raw)
+    return Array.isArray(parsed) ? parsed.filter((s): s is string => typeof s === 'string' && s.length > 0) : []
+  } catch {
+    return []
+  }
+}
+
 function getCaptureOverrides(): Readonly<Record<string, string>> {
   return Object.freeze({ ...state.captureOverrides })
 }
@@ -14,6 +14,8 @@ import { fetchWithTimeout } from '../lib/timeout-utils.js'
 import { buildDaemonJSONRequestInit } from '../lib/daemon-http.js'
 import { beacon } from '../lib/telemetry-beacon.js'
 import { drainUIFeatures, restoreUIFeatures } from './ui-usage-tracker.js'
+import { claimIdempotencyKey } from './idempotency-keys.js'
+import type { BrowserEnvironment } from './browser-environment.js'
 
 // =============================================================================
 // SERVER INSTALL ID — single source of truth for all analytics
@@ -65,6 +67,7 @@ export interface SyncSettings {
   capture_actions: boolean
   csp_restricted: boolean
   csp_level: string
+  environment?: BrowserEnvironment
 }
 
 /** Extension log entry */
@@ -117,6 +120,10 @@ export interface SyncCommand {
   tab_id?: number
   correlation_id?: string
   trace_id?: string
+  /** Stable across daemon re-deliveries; a key executes at most once per browser session */
+  idempotency_key?: string
+  /** Delivery window the daemon is offering this command in (1 = first offer) */
+  attempt?: number
 }
 
 /** Response from /sync */
@@ -504,6 +511,24 @@ export class SyncClient {
   }
 
   private async dispatchCommand(command: SyncCommand): Promise<void> {
+    // Receipt dedup does not survive a service-worker restart; the idempotency key does.
+    // A key that already ran is reported, never re-executed, so a click cannot happen twice.
+    if (command.idempotency_key && !(await claimIdempotencyKey(command.idempotency_key))) {
+      const message = `Command ${command.id} (${command.type}) already ran under idempotency key ${command.idempotency_key}; not executing it again`
+      this.log('Skipping duplicate delivery', { id: command.id, idempotency_key: command.idempotency_key })
+      this.queueCommandResult({
+        id: command.id,
+        correlation_id: command.correlation_id,
+        status: 'error',
+        result: { error: 'duplicate_delivery', message },
+        error: message
+      })
+      if (command.id) {
+        this.state.lastCommandAck = command.id
+      }
+      return
+    }
+
     this.markInProgress(command)
     const timeoutMs = this.commandTimeoutFor(command)
     let timeoutHandle: ReturnType<typeof setTimeout> | null = null
@@ -10,12 +10,15 @@
 import type { PendingQuery } from '../types/index.js'
 import { createSyncClient, type SyncClient, type SyncCommand, type SyncSettings } from './sync-client.js'
 import { getLastCSPStatus } from './browser-actions.js'
+import { getBrowserEnvironment } from './browser-environment.js'
+import { applyRegionOverride, type RegionOverride } from './device-emulation.js'
 import { DebugCategory } from './debug.js'
 import { updateBadge } from './communication.js'
 import { isQueryProcessing, addProcessingQuery, removeProcessingQuery } from './state-manager.js'
-import { getTrackedTabInfo } from './event-listeners.js'
+import { getTrackedTabInfo, forwardToAllContentScripts, saveSetting } from './event-listeners.js'
 import { handlePendingQuery as handlePendingQueryImpl } from './pending-queries.js'
 import { errorMessage } from '../lib/error-utils.js'
+import { BODY_CAPTURE_MAX_DEFAULT, SettingName, StorageKey } from '../lib/constants.js'
 
 // =============================================================================
 // TYPES
@@ -67,6 +70,24 @@ export interface SyncManagerDeps {
 /** Sync client instance (initialized lazily) */
 let syncClient: SyncClient | null = null
 
+/** Body capture ceiling last pushed to content scripts (null until the first sync) */
+let appliedBodyCaptureMax: number | null = null
+/** Binary capture cap last pushed to content scripts (null until the first sync) */
+let appliedBinaryCaptureMax: number | null = null
+
+/** Raw request_mocks override last pushed to content scripts (null until the first sync) */
+let appliedRequestMocks: string | null = null
+
+/** Multi-tab capture flag last persisted for content scripts (null until the first sync) */
+let appliedMultiTabCapture: boolean | null = null
+
+/** Raw region_override last applied to the tracked tab (null until the first sync) */
+let appliedRegionOverride: string | null = null
+/** Tab holding the region overrides (0 when none) */
+let regionOverrideTabId = 0
+/** Serializes region override updates so overlapping syncs don't interleave attach and detach */
+let regionOverrideQueue: Promise<void> = Promise.resolve()
+
 // =============================================================================
 // HELPERS
 // =============================================================================
@@ -81,6 +102,99 @@ function getExtensionVersion(): string {
   return ''
 }
 
+/**
+ * Push the server's body_capture_max override (or the default when absent) to
+ * every page, persisting it so newly injected pages start with the same ceiling.
+ */
+function syncBodyCaptureMax(overrides: Record<string, string>, debugLog: DebugLogFn): void {
+  const parsed = Number(overrides.body_capture_max)
+  const limit = Number.isInteger(parsed) && parsed > 0 ? parsed : BODY_CAPTURE_MAX_DEFAULT
+  if (limit === appliedBodyCaptureMax) return
+  appliedBodyCaptureMax = limit
+  saveSetting(StorageKey.NETWORK_BODY_CAPTURE_MAX, limit)
+  forwardToAllContentScripts({ type: SettingName.NETWORK_BODY_CAPTURE_MAX, limit }, debugLog)
+}
+
+/**
+ * Push the server's binary_capture_max override to every page, persisting it so newly
+ * injected pages start with it. An absent override turns binary capture off.
+ */
+function syncBinaryCaptureMax(overrides: Record<string, string>, debugLog: DebugLogFn): void {
+  const parsed = Number(overrides.binary_capture_max)
+  const limit = Number.isInteger(parsed) && parsed > 0 ? parsed : 0
+  if (limit === appliedBinaryCaptureMax) return
+  appliedBinaryCaptureMax = limit
+  saveSetting(StorageKey.NETWORK_BINARY_CAPTURE_MAX, limit)
+  forwardToAllContentScripts({ type: SettingName.NETWORK_BINARY_CAPTURE_MAX, limit }, debugLog)
+}
+
+/**
+ * Persist the server's multi_tab_capture override. Content scripts watch the storage key,
+ * so every open tab starts or stops forwarding telemetry without a reload.
+ */
+function syncMultiTabCapture(overrides: Record<string, string>, debugLog: DebugLogFn): void {
+  const enabled = overrides.multi_tab_capture === 'true'
+  if (enabled === appliedMultiTabCapture) return
+  appliedMultiTabCapture = enabled
+  saveSetting(StorageKey.MULTI_TAB_CAPTURE, enabled)
+  debugLog(DebugCategory.CONNECTION, 'Multi-tab capture ' + (enabled ? 'enabled' : 'disabled'))
+}
+
+/**
+ * Push the server's request mocks to every page, persisting them so newly injected
+ * pages fulfill matching requests from the start. An absent override clears them.
+ */
+function syncRequestMocks(raw: string, debugLog: DebugLogFn): void {
+  if (raw === appliedRequestMocks) return
+  let mocks: unknown[] = []
+  try {
+    const parsed: unknown = raw ? JSON.parse(raw) : []
+    if (Array.isArray(parsed)) mocks = parsed
+  } catch {
+    debugLog(DebugCategory.CONNECTION, 'Ignoring malformed request_mocks override')
+  }
+  appliedRequestMocks = raw
+  saveSetting(StorageKey.REQUEST_MOCKS, mocks)
+  forwardToAllContentScripts({ type: SettingName.REQUEST_MOCKS, mocks }, debugLog)
+}
+
+/**
+ * Apply the server's region_override (geolocation, timezone, locale) to the tracked tab,
+ * moving it when the tracked tab changes. An absent override clears it. A failure is
+ * logged and not retried until the override or the tracked tab changes.
+ */
+function syncRegionOverride(raw: string, debugLog: DebugLogFn): Promise<void> {
+  regionOverrideQueue = regionOverrideQueue.then(() => applyRegionOverrideToTrackedTab(raw, debugLog))
+  return regionOverrideQueue
+}
+
+async function applyRegionOverrideToTrackedTab(raw: string, debugLog: DebugLogFn): Promise<void> {
+  if (!raw && !regionOverrideTabId) {
+    appliedRegionOverride = raw
+    return
+  }
+  try {
+    const tabId = raw ? (await getTrackedTabInfo()).trackedTabId || 0 : 0
+    if (raw === appliedRegionOverride && tabId === regionOverrideTabId) return
+    appliedRegionOverride = raw
+    let region: RegionOverride | null = null
+    try {
+      region = raw ? (JSON.parse(raw) as RegionOverride) : null
+    } catch {
+      debugLog(DebugCategory.CONNECTION, 'Ignoring malformed region_override override')
+    }
+    const previous = regionOverrideTabId
+    regionOverrideTabId = 0
+    if (previous && (previous !== tabId || !region)) await applyRegionOverride(previous, null)
+    if (tabId && region) {
+      await applyRegionOverride(tabId, region)
+      regionOverrideTabId = tabId
+    }
+  } catch (err) {
+    debugLog(DebugCategory.CONNECTION, 'Region override failed', { error: errorMessage(err) })
+  }
+}
+
 // =============================================================================
 // SYNC CLIENT LIFECYCLE
 // =============================================================================
@@ -126,6 +240,12 @@ export function startSyncClient(deps: SyncManagerDeps): void {
         deps.setConnectionStatus({ connected })
         updateBadge(deps.getConnectionStatus())
         deps.debugLog(DebugCategory.CONNECTION, connected ? 'Sync connected' : 'Sync disconnected')
+        // Mocks, multi-tab capture, and region overrides live only as long as the daemon that set them.
+        if (!connected) {
+          syncRequestMocks('', deps.debugLog)
+          syncMultiTabCapture({}, deps.debugLog)
+          void syncRegionOverride('', deps.debugLog)
+        }
 
         // Notify popup
         if (typeof chrome !== 'undefined' && chrome.runtime) {
@@ -143,6 +263,11 @@ export function startSyncClient(deps: SyncManagerDeps): void {
       // Handle capture overrides from server
       onCaptureOverrides: (overrides: Record<string, string>) => {
         deps.applyCaptureOverrides(overrides)
+        syncBodyCaptureMax(overrides, deps.debugLog)
+        syncBinaryCaptureMax(overrides, deps.debugLog)
+        syncMultiTabCapture(overrides, deps.debugLog)
+        syncRequestMocks(overrides.request_mocks || '', deps.debugLog)
+        void syncRegionOverride(overrides.region_override || '', deps.debugLog)
         if (typeof chrome !== 'undefined' && chrome.runtime) {
           chrome.runtime
             .sendMessage({
@@ -195,7 +320,12 @@ export function startSyncClient(deps: SyncManagerDeps): void {
           capture_websocket: true,
           capture_actions: true,
           csp_restricted: csp.csp_restricted,
-          csp_level: csp.csp_level
+          csp_level: csp.csp_level,
+          environment: await getBrowserEnvironment(
+            trackingInfo.trackedTabId,
+            trackingInfo.trackedTabUrl,
+            getExtensionVersion()
+          )
         }
       },
 
@@ -49,8 +49,8 @@ isDomainCloaked().then((cloaked) => {
   let scriptsInjected = false
 
   // Initialize tab tracking first, with callback for injection
-  initTabTracking((tracked) => {
-    if (tracked && !scriptsInjected) {
+  initTabTracking((tracked, capturing) => {
+    if (capturing && !scriptsInjected) {
       initScriptInjection()
       scriptsInjected = true
     }
@@ -15,6 +15,7 @@ import { getReloadedExtensionWarning } from '../lib/brand.js'
 export const MESSAGE_MAP: Record<string, string> = {
   kaboom_log: 'log',
   kaboom_ws: 'ws_event',
+  kaboom_sse: 'sse_event',
   kaboom_network_body: 'network_body',
   kaboom_enhanced_action: 'enhanced_action',
   kaboom_performance_snapshot: 'performance_snapshot'
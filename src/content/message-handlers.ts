@@ -171,7 +171,13 @@ export function handlePing(sendResponse: (response: ContentPingResponse) => void
  * Handle toggle messages
  */
 export function handleToggleMessage(
-  message: ContentMessage & { enabled?: boolean; mode?: WebSocketCaptureMode; url?: string }
+  message: ContentMessage & {
+    enabled?: boolean
+    mode?: WebSocketCaptureMode
+    url?: string
+    limit?: number
+    mocks?: unknown[]
+  }
 ): void {
   if (!TOGGLE_MESSAGES.has(message.type)) return
 
@@ -180,6 +186,13 @@ export function handleToggleMessage(
     payload.mode = message.mode
   } else if (message.type === SettingName.SERVER_URL) {
     payload.url = message.url
+  } else if (
+    message.type === SettingName.NETWORK_BODY_CAPTURE_MAX ||
+    message.type === SettingName.NETWORK_BINARY_CAPTURE_MAX
+  ) {
+    payload.limit = message.limit
+  } else if (message.type === SettingName.REQUEST_MOCKS) {
+    payload.mocks = message.mocks
   } else {
     payload.enabled = message.enabled
   }
@@ -49,13 +49,18 @@ const SYNC_SETTINGS: readonly {
   storageKey: string
   messageType: string
   isMode?: boolean
+  isLimit?: boolean
+  isMocks?: boolean
 }[] = [
   { storageKey: 'webSocketCaptureEnabled', messageType: SettingName.WEBSOCKET_CAPTURE },
   { storageKey: 'webSocketCaptureMode', messageType: SettingName.WEBSOCKET_CAPTURE_MODE, isMode: true },
   { storageKey: 'networkWaterfallEnabled', messageType: SettingName.NETWORK_WATERFALL },
   { storageKey: 'performanceMarksEnabled', messageType: SettingName.PERFORMANCE_MARKS },
   { storageKey: 'actionReplayEnabled', messageType: SettingName.ACTION_REPLAY },
-  { storageKey: 'networkBodyCaptureEnabled', messageType: SettingName.NETWORK_BODY_CAPTURE }
+  { storageKey: 'networkBodyCaptureEnabled', messageType: SettingName.NETWORK_BODY_CAPTURE },
+  { storageKey: 'networkBodyCaptureMax', messageType: SettingName.NETWORK_BODY_CAPTURE_MAX, isLimit: true },
+  { storageKey: 'networkBinaryCaptureMax', messageType: SettingName.NETWORK_BINARY_CAPTURE_MAX, isLimit: true },
+  { storageKey: 'requestMocks', messageType: SettingName.REQUEST_MOCKS, isMocks: true }
 ]
 
 /**
@@ -80,6 +85,16 @@ async function syncStoredSettings(): Promise<void> {
         },
         window.location.origin
       )
+    } else if (setting.isLimit) {
+      window.postMessage(
+        { type: 'kaboom_setting', setting: setting.messageType, limit: value as number, _nonce: pageNonce },
+        window.location.origin
+      )
+    } else if (setting.isMocks) {
+      window.postMessage(
+        { type: 'kaboom_setting', setting: setting.messageType, mocks: value as unknown[], _nonce: pageNonce },
+        window.location.origin
+      )
     } else {
       window.postMessage(
         { type: 'kaboom_setting', setting: setting.messageType, enabled: value as boolean, _nonce: pageNonce },
@@ -15,6 +15,8 @@ import { getLocal, onStorageChanged } from '../lib/storage-utils.js'
 let isTrackedTab = false
 // The tab ID of this content script's tab
 let currentTabId: number | null = null
+// Whether the server asked every tab to capture, not only the tracked one (configure multi_tab_capture)
+let multiTabCapture = false
 
 /**
  * Update tracking status by checking storage and current tab ID.
@@ -23,6 +25,7 @@ let currentTabId: number | null = null
 async function updateTrackingStatus(): Promise<void> {
   try {
     const trackedTabId = (await getLocal(StorageKey.TRACKED_TAB_ID)) as number | undefined
+    multiTabCapture = (await getLocal(StorageKey.MULTI_TAB_CAPTURE)) === true
 
     // Request tab ID from background script (content scripts can't access chrome.tabs)
     const response = (await chrome.runtime.sendMessage({ type: 'get_tab_id' })) as { tabId?: number } | undefined
@@ -42,6 +45,14 @@ export function getIsTrackedTab(): boolean {
   return isTrackedTab
 }
 
+/**
+ * Whether this tab forwards captured telemetry: the tracked tab always does, and every
+ * other tab does while multi-tab capture is on.
+ */
+export function getIsCapturingTab(): boolean {
+  return isTrackedTab || (multiTabCapture && currentTabId !== null)
+}
+
 /**
  * Get the current tab ID
  */
@@ -54,15 +65,15 @@ export function getCurrentTabId(): number | null {
  * Returns a promise that resolves when initial tracking status is known.
  * The onChange callback fires after each status update (initial + storage changes).
  */
-export function initTabTracking(onChange?: (tracked: boolean) => void): Promise<void> {
+export function initTabTracking(onChange?: (tracked: boolean, capturing: boolean) => void): Promise<void> {
   const ready = updateTrackingStatus().then(() => {
-    onChange?.(isTrackedTab)
+    onChange?.(isTrackedTab, getIsCapturingTab())
   })
 
   onStorageChanged(async (changes) => {
-    if (changes[StorageKey.TRACKED_TAB_ID]) {
+    if (changes[StorageKey.TRACKED_TAB_ID] || changes[StorageKey.MULTI_TAB_CAPTURE]) {
       await updateTrackingStatus()
-      onChange?.(isTrackedTab)
+      onChange?.(isTrackedTab, getIsCapturingTab())
     }
   })
 
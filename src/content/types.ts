@@ -15,6 +15,7 @@ import type {
   ContentToPageMessageType,
   LogEntry,
   WebSocketEvent,
+  WireSSEEvent,
   NetworkBodyPayload,
   EnhancedAction,
   PerformanceSnapshot
@@ -49,6 +50,8 @@ export interface SettingMessage {
   enabled?: boolean
   mode?: WebSocketCaptureMode
   url?: string
+  limit?: number
+  mocks?: unknown[]
 }
 
 /**
@@ -138,6 +141,12 @@ export interface WsEventMessageToBackground {
   tabId: number | null
 }
 
+export interface SseEventMessageToBackground {
+  type: 'sse_event'
+  payload: WireSSEEvent
+  tabId: number | null
+}
+
 export interface NetworkBodyMessageToBackground {
   type: 'network_body'
   payload: NetworkBodyPayload
@@ -159,6 +168,7 @@ export interface PerformanceSnapshotMessageToBackground {
 export type BackgroundMessageFromContent =
   | LogMessageToBackground
   | WsEventMessageToBackground
+  | SseEventMessageToBackground
   | NetworkBodyMessageToBackground
   | EnhancedActionMessageToBackground
   | PerformanceSnapshotMessageToBackground
@@ -17,7 +17,7 @@ import {
   resolveDomRequest
 } from './request-tracking.js'
 import { MESSAGE_MAP, safeSendMessage } from './message-forwarding.js'
-import { getIsTrackedTab, getCurrentTabId } from './tab-tracking.js'
+import { getIsCapturingTab, getCurrentTabId } from './tab-tracking.js'
 import { getPageNonce } from './script-injection.js'
 
 /**
@@ -49,10 +49,11 @@ export function initWindowMessageListener(): void {
       return
     }
 
-    // Tab isolation filter: only forward captured data from the tracked tab.
-    // Response messages (highlight, execute JS, a11y) are NOT filtered because
-    // they are responses to explicit commands from the background script.
-    if (!getIsTrackedTab()) return
+    // Tab isolation filter: only forward captured data from the tracked tab, or from
+    // every tab while multi-tab capture is on. Response messages (highlight, execute JS,
+    // a11y) are NOT filtered because they are responses to explicit commands from the
+    // background script.
+    if (!getIsCapturingTab()) return
 
     if (messageType && messageType in MESSAGE_MAP && payload && typeof payload === 'object') {
       const mappedType = MESSAGE_MAP[messageType]
@@ -127,6 +127,7 @@ export {
   getLCP,
   getCLS,
   getINP,
+  getINPInteractions,
   sendPerformanceSnapshot,
   isPerformanceSnapshotEnabled,
   setPerformanceSnapshotEnabled,
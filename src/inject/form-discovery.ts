@@ -1,5 +1,5 @@
 /**
- * Purpose: Scans page forms to extract field metadata (name, type, required, validation) and optionally runs client-side validation.
+ * Purpose: Scans page forms to extract field metadata (name, type, required, validation), live validity state, and optionally runs client-side validation.
  * Docs: docs/features/feature/form-filling/index.md
  */
 
@@ -8,7 +8,7 @@
 
 interface FormDiscoveryParams {
   selector?: string
-  mode?: 'discover' | 'validate'
+  mode?: 'discover' | 'validate' | 'state'
 }
 
 interface FormFieldInfo {
@@ -22,6 +22,10 @@ interface FormFieldInfo {
   validation_constraints: Record<string, string | number | boolean>
   options?: Array<{ value: string; text: string; selected: boolean }>
   validation_message?: string
+  checked?: boolean
+  valid?: boolean
+  validity?: string[]
+  redacted?: boolean
 }
 
 interface FormInfo {
@@ -64,6 +68,42 @@ function findLabel(el: HTMLElement): string {
   return ''
 }
 
+const REDACTED_FIELD_VALUE = '[REDACTED]'
+
+// Autocomplete tokens whose values must never leave the page.
+const SENSITIVE_AUTOCOMPLETE = ['current-password', 'new-password', 'one-time-code', 'cc-number', 'cc-csc', 'cc-exp']
+
+/**
+ * Report whether a field holds a secret by type or autocomplete hint.
+ * Name-based rules are applied by the server's redaction engine.
+ */
+function isSensitiveField(el: HTMLInputElement | HTMLSelectElement | HTMLTextAreaElement): boolean {
+  if ((el.getAttribute('type') || '').toLowerCase() === 'password') return true
+  const autocomplete = (el.getAttribute('autocomplete') || '').toLowerCase()
+  return SENSITIVE_AUTOCOMPLETE.some((token) => autocomplete.includes(token))
+}
+
+const VALIDITY_FLAGS: Array<[keyof ValidityState, string]> = [
+  ['valueMissing', 'value_missing'],
+  ['typeMismatch', 'type_mismatch'],
+  ['patternMismatch', 'pattern_mismatch'],
+  ['tooLong', 'too_long'],
+  ['tooShort', 'too_short'],
+  ['rangeUnderflow', 'range_underflow'],
+  ['rangeOverflow', 'range_overflow'],
+  ['stepMismatch', 'step_mismatch'],
+  ['badInput', 'bad_input'],
+  ['customError', 'custom_error']
+]
+
+/**
+ * List the failing ValidityState flags. Reads the live state without
+ * calling checkValidity(), so no invalid events fire on the page.
+ */
+function validityFailures(validity: ValidityState): string[] {
+  return VALIDITY_FLAGS.filter(([flag]) => validity[flag]).map(([, name]) => name)
+}
+
 /**
  * Build a minimal CSS selector for a form element.
  */
@@ -137,16 +177,18 @@ export function discoverForms(params: FormDiscoveryParams): FormInfo[] {
       // Skip hidden inputs
       if (fieldType === 'hidden') continue
 
+      const sensitive = isSensitiveField(field)
       const fieldInfo: FormFieldInfo = {
         name: field.name || '',
         type: fieldType,
         required: field.required,
-        value: field.value || '',
+        value: sensitive && field.value ? REDACTED_FIELD_VALUE : field.value || '',
         label: findLabel(field),
         selector: buildFieldSelector(field),
         tag: field.tagName.toLowerCase(),
         validation_constraints: getValidationConstraints(field)
       }
+      if (sensitive && field.value) fieldInfo.redacted = true
 
       // Add options for select elements
       if (field.tagName === 'SELECT') {
@@ -166,6 +208,17 @@ export function discoverForms(params: FormDiscoveryParams): FormInfo[] {
         }
       }
 
+      // Add live validity in state mode
+      if (params.mode === 'state') {
+        if (fieldType === 'checkbox' || fieldType === 'radio') {
+          fieldInfo.checked = (field as HTMLInputElement).checked
+        }
+        fieldInfo.valid = field.validity.valid
+        const failures = validityFailures(field.validity)
+        if (failures.length > 0) fieldInfo.validity = failures
+        if (field.validationMessage) fieldInfo.validation_message = field.validationMessage
+      }
+
       fields.push(fieldInfo)
     }
 
@@ -204,6 +257,10 @@ export function discoverForms(params: FormDiscoveryParams): FormInfo[] {
       }
     }
 
+    if (params.mode === 'state') {
+      formInfo.valid = fields.every((f) => f.valid !== false)
+    }
+
     results.push(formInfo)
   }
 
@@ -126,6 +126,7 @@ export {
   getLCP,
   getCLS,
   getINP,
+  getINPInteractions,
   sendPerformanceSnapshot,
   isPerformanceSnapshotEnabled,
   setPerformanceSnapshotEnabled
@@ -291,7 +291,7 @@ function handleFormStateMessage(data: FormStateQueryRequestMessageData): void {
     const params = (data.params || {}) as { selector?: string }
     const forms = discoverForms({
       selector: params.selector,
-      mode: 'discover'
+      mode: 'state'
     })
     postResponse({
       type: 'kaboom_form_state_response',
@@ -1,16 +1,17 @@
 /**
- * Purpose: Registers and manages runtime observers for DOM mutations, network requests, performance entries, and WebSocket events in the page context.
+ * Purpose: Registers and manages runtime observers for DOM mutations, network requests, performance entries, and WebSocket and SSE events in the page context.
  * Docs: docs/features/feature/observe/index.md
  */
 
 /**
  * @fileoverview Observers - Observer registration and management for DOM, network,
- * performance, and WebSocket events.
+ * performance, WebSocket, and Server-Sent Events.
  */
 
 import { installPerformanceCapture, uninstallPerformanceCapture } from '../lib/performance.js'
 import { installPerfObservers } from '../lib/perf-snapshot.js'
 import { installWebSocketCapture, uninstallWebSocketCapture } from '../lib/websocket.js'
+import { installSSECapture, uninstallSSECapture } from '../lib/sse.js'
 import { wrapFetchWithBodies, wrapXHRWithBodies, unwrapXHR, adoptEarlyBodies, sanitizeHeaders } from '../lib/network.js'
 import { installConsoleCapture, uninstallConsoleCapture } from '../lib/console.js'
 import { installExceptionCapture, uninstallExceptionCapture } from '../lib/exceptions.js'
@@ -21,6 +22,7 @@ import {
   uninstallNavigationCapture
 } from '../lib/actions.js'
 import { installTransientCapture, uninstallTransientCapture } from '../lib/transient-capture.js'
+import { installListenerTracking, uninstallListenerTracking } from '../lib/memory-sample.js'
 import { postLog } from '../lib/bridge.js'
 import { MAX_RESPONSE_LENGTH, MEMORY_SOFT_LIMIT_MB, MEMORY_HARD_LIMIT_MB } from '../lib/constants.js'
 import { errorMessage } from '../lib/error-utils.js'
@@ -180,8 +182,10 @@ export function install(): void {
   installActionCapture()
   installNavigationCapture()
   installWebSocketCapture()
+  installSSECapture()
   installPerformanceCapture()
   installTransientCapture()
+  installListenerTracking()
 }
 
 /**
@@ -195,8 +199,10 @@ export function uninstall(): void {
   uninstallActionCapture()
   uninstallNavigationCapture()
   uninstallWebSocketCapture()
+  uninstallSSECapture()
   uninstallPerformanceCapture()
   uninstallTransientCapture()
+  uninstallListenerTracking()
 }
 
 /**
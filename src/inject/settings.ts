@@ -7,7 +7,14 @@
 
 import type { BrowserStateSnapshot, StateAction, WebSocketCaptureMode } from '../types/index.js'
 
-import { setNetworkWaterfallEnabled, setNetworkBodyCaptureEnabled, setServerUrl } from '../lib/network.js'
+import {
+  setNetworkWaterfallEnabled,
+  setNetworkBodyCaptureEnabled,
+  setNetworkBodyCaptureMax,
+  setNetworkBinaryCaptureMax,
+  setServerUrl
+} from '../lib/network.js'
+import { setRequestMocks } from '../lib/request-mocks.js'
 import {
   setPerformanceMarksEnabled,
   installPerformanceCapture,
@@ -20,6 +27,7 @@ import {
   installWebSocketCapture,
   uninstallWebSocketCapture
 } from '../lib/websocket.js'
+import { setSSECaptureEnabled, installSSECapture, uninstallSSECapture } from '../lib/sse.js'
 import { setPerformanceSnapshotEnabled } from '../lib/perf-snapshot.js'
 import { setDeferralEnabled } from './observers.js'
 import { INJECT_FORWARDED_SETTINGS, SettingName } from '../lib/constants.js'
@@ -41,6 +49,8 @@ export interface SettingMessageData {
   enabled?: boolean
   mode?: string
   url?: string
+  limit?: number
+  mocks?: unknown[]
 }
 
 /**
@@ -61,6 +71,9 @@ export function isValidSettingPayload(data: SettingMessageData): boolean {
   }
   if (data.setting === SettingName.WEBSOCKET_CAPTURE_MODE) return typeof data.mode === 'string'
   if (data.setting === SettingName.SERVER_URL) return typeof data.url === 'string'
+  if (data.setting === SettingName.NETWORK_BODY_CAPTURE_MAX) return typeof data.limit === 'number'
+  if (data.setting === SettingName.NETWORK_BINARY_CAPTURE_MAX) return typeof data.limit === 'number'
+  if (data.setting === SettingName.REQUEST_MOCKS) return Array.isArray(data.mocks)
   // Boolean settings
   if (typeof data.enabled !== 'boolean') {
     console.warn('[KaBOOM!] Invalid enabled value type')
@@ -80,15 +93,25 @@ const SETTING_HANDLERS: Record<string, SettingHandler> = {
   },
   [SettingName.ACTION_REPLAY]: (data) => setActionCaptureEnabled(data.enabled!),
   [SettingName.WEBSOCKET_CAPTURE]: (data) => {
+    // Streaming capture covers both WebSocket and EventSource connections.
     setWebSocketCaptureEnabled(data.enabled!)
-    if (data.enabled) installWebSocketCapture()
-    else uninstallWebSocketCapture()
+    setSSECaptureEnabled(data.enabled!)
+    if (data.enabled) {
+      installWebSocketCapture()
+      installSSECapture()
+    } else {
+      uninstallWebSocketCapture()
+      uninstallSSECapture()
+    }
   },
   [SettingName.WEBSOCKET_CAPTURE_MODE]: (data) =>
     setWebSocketCaptureMode((data.mode || 'medium') as WebSocketCaptureMode),
   [SettingName.PERFORMANCE_SNAPSHOT]: (data) => setPerformanceSnapshotEnabled(data.enabled!),
   [SettingName.DEFERRAL]: (data) => setDeferralEnabled(data.enabled!),
   [SettingName.NETWORK_BODY_CAPTURE]: (data) => setNetworkBodyCaptureEnabled(data.enabled!),
+  [SettingName.NETWORK_BODY_CAPTURE_MAX]: (data) => setNetworkBodyCaptureMax(data.limit!),
+  [SettingName.NETWORK_BINARY_CAPTURE_MAX]: (data) => setNetworkBinaryCaptureMax(data.limit!),
+  [SettingName.REQUEST_MOCKS]: (data) => setRequestMocks(data.mocks),
   [SettingName.SERVER_URL]: (data) => setServerUrl(data.url!)
 }
 
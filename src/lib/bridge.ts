@@ -21,6 +21,23 @@ export interface BridgePayload {
   [key: string]: unknown
 }
 
+// Objects in the message are JSON previews; the full structure stays in args
+const MAX_MESSAGE_JSON_LENGTH = 1000
+
+/**
+ * Message text for a first console argument: objects and arrays read as JSON
+ * instead of "[object Object]".
+ */
+function messageFromArg(arg: unknown): string {
+  if (arg === null || arg === undefined) return ''
+  if (typeof arg !== 'object') return String(arg)
+  try {
+    return JSON.stringify(arg).slice(0, MAX_MESSAGE_JSON_LENGTH)
+  } catch {
+    return String(arg)
+  }
+}
+
 /**
  * Post a log message to the content script
  */
@@ -45,10 +62,7 @@ export function postLog(payload: BridgePayload): void {
         // Enriched fields (these are the source of truth)
         ts: new Date().toISOString(),
         url: window.location.href,
-        message:
-          payload.message ||
-          payload.error ||
-          (payload.args?.[0] !== null && payload.args?.[0] !== undefined ? String(payload.args[0]) : ''),
+        message: payload.message || payload.error || messageFromArg(payload.args?.[0]),
         source: payload.filename ? `${payload.filename}:${payload.lineno || 0}` : '',
         // Core fields from payload
         level,
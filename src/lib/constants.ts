@@ -50,8 +50,11 @@ export const WS_MAX_BODY_SIZE = 4096 // 4KB truncation limit
 export const WS_PREVIEW_LIMIT = 200 // Preview character limit
 
 // Network body capture settings
-export const REQUEST_BODY_MAX = 8192 // 8KB
-export const RESPONSE_BODY_MAX = 16384 // 16KB
+// Default per-body capture ceiling. The server keeps a shorter inline copy and holds the
+// rest for full-body fetch; configure(what="body_limits", capture_body_max) overrides it.
+export const BODY_CAPTURE_MAX_DEFAULT = 65536 // 64KB
+export const BODY_CAPTURE_MAX_LIMIT = 1048576 // 1MB
+export const BINARY_CAPTURE_MAX_LIMIT = 262144 // 256KB
 // Intentionally aggressive (5ms) to avoid blocking the main thread during fetch body reads.
 // Network body capture uses this as a race timeout - if the body isn't available nearly
 // instantly, we skip it rather than degrade page performance.
@@ -59,6 +62,8 @@ export const BODY_READ_TIMEOUT_MS = 5
 export const SENSITIVE_HEADER_PATTERNS =
   /^(authorization|cookie|set-cookie|x-api-key|x-auth-token|x-secret|x-password|.*token.*|.*secret.*|.*key.*|.*password.*)$/i
 export const BINARY_CONTENT_TYPES = /^(image|video|audio|font)\/|^application\/(wasm|octet-stream|zip|gzip|pdf)/
+// Binary wire formats otherwise read as text; captured as bytes only when binary capture is on.
+export const BINARY_WIRE_CONTENT_TYPES = /^application\/(x-protobuf|protobuf|vnd\.google\.protobuf|grpc|x-msgpack|msgpack|cbor)/
 
 // DOM query settings
 export const DOM_QUERY_MAX_ELEMENTS = 50
@@ -109,6 +114,7 @@ export const ACTIONABLE_KEYS: ReadonlySet<string> = new Set([
 export const MAX_LONG_TASKS = 50
 export const MAX_SLOWEST_REQUESTS = 3
 export const MAX_URL_LENGTH = 80
+export const MAX_INP_INTERACTIONS = 10 // Slowest interactions kept for the INP breakdown
 
 // =============================================================================
 // SETTING NAMES — Single source of truth for all toggle/setting message types.
@@ -132,6 +138,9 @@ export const SettingName = {
   PERFORMANCE_SNAPSHOT: 'set_performance_snapshot_enabled',
   DEFERRAL: 'set_deferral_enabled',
   NETWORK_BODY_CAPTURE: 'set_network_body_capture_enabled',
+  NETWORK_BODY_CAPTURE_MAX: 'set_network_body_capture_max',
+  NETWORK_BINARY_CAPTURE_MAX: 'set_network_binary_capture_max',
+  REQUEST_MOCKS: 'set_request_mocks',
   ACTION_TOASTS: 'set_action_toasts_enabled',
   SUBTITLES: 'set_subtitles_enabled',
   SERVER_URL: 'set_server_url'
@@ -165,6 +174,9 @@ export const INJECT_FORWARDED_SETTINGS: ReadonlySet<string> = new Set<string>([
   SettingName.PERFORMANCE_SNAPSHOT,
   SettingName.DEFERRAL,
   SettingName.NETWORK_BODY_CAPTURE,
+  SettingName.NETWORK_BODY_CAPTURE_MAX,
+  SettingName.NETWORK_BINARY_CAPTURE_MAX,
+  SettingName.REQUEST_MOCKS,
   SettingName.SERVER_URL
 ])
 
@@ -194,6 +206,10 @@ export const StorageKey = {
   PERFORMANCE_MARKS_ENABLED: 'performanceMarksEnabled',
   ACTION_REPLAY_ENABLED: 'actionReplayEnabled',
   NETWORK_BODY_CAPTURE_ENABLED: 'networkBodyCaptureEnabled',
+  NETWORK_BODY_CAPTURE_MAX: 'networkBodyCaptureMax',
+  NETWORK_BINARY_CAPTURE_MAX: 'networkBinaryCaptureMax',
+  REQUEST_MOCKS: 'requestMocks',
+  MULTI_TAB_CAPTURE: 'multiTabCapture',
   ACTION_TOASTS_ENABLED: 'actionToastsEnabled',
   SUBTITLES_ENABLED: 'subtitlesEnabled',
   ACTION_RECORDING: 'kaboom_action_recording',
@@ -212,5 +228,6 @@ export const StorageKey = {
   TERMINAL_WORKSPACE_GROUP_ID: 'kaboom_terminal_workspace_group_id',
   TERMINAL_WORKSPACE_MAIN_TAB_ID: 'kaboom_terminal_workspace_main_tab_id',
   CLOAKED_DOMAINS: 'kaboom_cloaked_domains',
-  ERROR_GROUPS: 'kaboom_error_groups'
+  ERROR_GROUPS: 'kaboom_error_groups',
+  IDEMPOTENCY_KEYS: 'kaboom_idempotency_keys'
 } as const
@@ -0,0 +1,163 @@
+/**
+ * Purpose: Samples JS heap size, DOM node count, and listeners on detached nodes for performance snapshots.
+ * Why: The server spots leaks from heap and DOM growth across repeated navigations of the same route.
+ * Docs: docs/features/feature/memory-telemetry/index.md
+ */
+
+/**
+ * @fileoverview Memory sample capture.
+ * Reads performance.memory (Chrome) and, in cross-origin isolated pages,
+ * performance.measureUserAgentSpecificMemory(). Listener counts come from a
+ * lightweight addEventListener/removeEventListener hook installed in Phase 2.
+ */
+
+// Max listener targets tracked; older targets stop being counted beyond this
+const MAX_TRACKED_TARGETS = 5000
+
+interface MemorySampleData {
+  time_origin: number
+  used_js_heap_size?: number
+  total_js_heap_size?: number
+  js_heap_size_limit?: number
+  ua_specific_bytes?: number
+  dom_nodes: number
+  event_listeners: number
+  detached_listeners: number
+}
+
+// Chrome-only performance.memory
+interface PerformanceMemory {
+  usedJSHeapSize: number
+  totalJSHeapSize: number
+  jsHeapSizeLimit: number
+}
+
+type MeasureMemoryFn = () => Promise<{ bytes: number }>
+
+// Listener tracking state
+let originalAddEventListener: typeof EventTarget.prototype.addEventListener | null = null
+let originalRemoveEventListener: typeof EventTarget.prototype.removeEventListener | null = null
+let listenerCounts = new WeakMap<object, number>()
+let trackedTargets: WeakRef<Node>[] = []
+
+// Latest measureUserAgentSpecificMemory() result for this document
+let uaSpecificBytes: number | null = null
+let uaMeasurePending = false
+
+function isNode(target: unknown): target is Node {
+  return typeof Node !== 'undefined' && target instanceof Node
+}
+
+function adjustListenerCount(target: unknown, delta: number): void {
+  if (!isNode(target)) return
+  const count = listenerCounts.get(target)
+  if (count === undefined) {
+    if (delta < 0 || trackedTargets.length >= MAX_TRACKED_TARGETS) return
+    trackedTargets.push(new WeakRef(target))
+  }
+  listenerCounts.set(target, Math.max(0, (count || 0) + delta))
+}
+
+/**
+ * Hook EventTarget add/removeEventListener to count listeners per DOM node.
+ */
+export function installListenerTracking(): void {
+  if (originalAddEventListener || typeof EventTarget === 'undefined' || typeof WeakRef === 'undefined') return
+  originalAddEventListener = EventTarget.prototype.addEventListener
+  originalRemoveEventListener = EventTarget.prototype.removeEventListener
+  const add = originalAddEventListener
+  const remove = originalRemoveEventListener
+
+  EventTarget.prototype.addEventListener = function (
+    this: EventTarget,
+    ...args: Parameters<EventTarget['addEventListener']>
+  ): void {
+    if (args[1]) adjustListenerCount(this, 1)
+    return add.apply(this, args)
+  }
+  EventTarget.prototype.removeEventListener = function (
+    this: EventTarget,
+    ...args: Parameters<EventTarget['removeEventListener']>
+  ): void {
+    if (args[1]) adjustListenerCount(this, -1)
+    return remove.apply(this, args)
+  }
+}
+
+/**
+ * Restore the original EventTarget methods and drop tracked targets.
+ */
+export function uninstallListenerTracking(): void {
+  if (originalAddEventListener) EventTarget.prototype.addEventListener = originalAddEventListener
+  if (originalRemoveEventListener) EventTarget.prototype.removeEventListener = originalRemoveEventListener
+  originalAddEventListener = null
+  originalRemoveEventListener = null
+  listenerCounts = new WeakMap()
+  trackedTargets = []
+}
+
+function countDOMNodes(): number {
+  if (typeof document === 'undefined' || typeof document.getElementsByTagName !== 'function') return 0
+  return document.getElementsByTagName('*').length
+}
+
+/**
+ * Count listeners on live tracked nodes, and on those no longer connected to the document.
+ * Collected targets are pruned as a side effect.
+ */
+export function countListeners(): { event_listeners: number; detached_listeners: number } {
+  let total = 0
+  let detached = 0
+  const live: WeakRef<Node>[] = []
+  for (const ref of trackedTargets) {
+    const node = ref.deref()
+    if (!node) continue
+    live.push(ref)
+    const count = listenerCounts.get(node) || 0
+    total += count
+    if (!node.isConnected) detached += count
+  }
+  trackedTargets = live
+  return { event_listeners: total, detached_listeners: detached }
+}
+
+/**
+ * Capture a memory sample for the current document.
+ */
+export function captureMemorySample(): MemorySampleData {
+  const sample: MemorySampleData = {
+    time_origin: performance.timeOrigin || 0,
+    dom_nodes: countDOMNodes(),
+    ...countListeners()
+  }
+  const memory = (performance as Performance & { memory?: PerformanceMemory }).memory
+  if (memory) {
+    sample.used_js_heap_size = memory.usedJSHeapSize
+    sample.total_js_heap_size = memory.totalJSHeapSize
+    sample.js_heap_size_limit = memory.jsHeapSizeLimit
+  }
+  if (uaSpecificBytes !== null) sample.ua_specific_bytes = uaSpecificBytes
+  return sample
+}
+
+/**
+ * Start performance.measureUserAgentSpecificMemory() when the page is cross-origin isolated.
+ * Resolves with the measured bytes once the browser reports them (it may defer up to ~20s),
+ * or null when the API is unavailable, a measurement is already running, or it fails.
+ */
+export async function measureUASpecificMemory(): Promise<number | null> {
+  const measure = (performance as Performance & { measureUserAgentSpecificMemory?: MeasureMemoryFn })
+    .measureUserAgentSpecificMemory
+  if (uaMeasurePending || typeof measure !== 'function') return null
+  if (typeof crossOriginIsolated === 'undefined' || !crossOriginIsolated) return null
+  uaMeasurePending = true
+  try {
+    const result = await measure.call(performance)
+    uaSpecificBytes = result.bytes
+    return uaSpecificBytes
+  } catch {
+    return null
+  } finally {
+    uaMeasurePending = false
+  }
+}
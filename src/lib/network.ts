@@ -14,12 +14,15 @@ import type { WaterfallEntry, PendingRequest } from '../types/index.js'
 import {
   MAX_WATERFALL_ENTRIES,
   WATERFALL_TIME_WINDOW_MS,
-  REQUEST_BODY_MAX,
-  RESPONSE_BODY_MAX,
+  BODY_CAPTURE_MAX_DEFAULT,
+  BODY_CAPTURE_MAX_LIMIT,
+  BINARY_CAPTURE_MAX_LIMIT,
   BODY_READ_TIMEOUT_MS,
   SENSITIVE_HEADER_PATTERNS,
-  BINARY_CONTENT_TYPES
+  BINARY_CONTENT_TYPES,
+  BINARY_WIRE_CONTENT_TYPES
 } from './constants.js'
+import { findRequestMock, mockFetchResponse, fulfillXHRWithMock } from './request-mocks.js'
 
 // =============================================================================
 // TYPE DEFINITIONS
@@ -73,10 +76,21 @@ interface NetworkBodyPostMessage {
     request_body?: string
     response_body?: string
     response_truncated?: boolean
+    response_encoding?: 'base64'
+    response_size?: number
     duration: number
   }
 }
 
+/**
+ * Head of a binary response, base64-encoded
+ */
+interface BinaryCapture {
+  body: string
+  size: number
+  truncated: boolean
+}
+
 // =============================================================================
 // MODULE STATE
 // =============================================================================
@@ -91,6 +105,8 @@ let requestIdCounter = 0
 
 // Network body capture state
 let networkBodyCaptureEnabled = true // Default: capture request/response bodies
+let networkBodyCaptureMax = BODY_CAPTURE_MAX_DEFAULT // Characters kept per body; the server spills past its inline limits
+let networkBinaryCaptureMax = 0 // Bytes of a binary response sent base64-encoded; 0 sends a size placeholder
 
 /** URL patterns for auth endpoints whose response bodies should be redacted */
 // nosemgrep: javascript.lang.security.audit.detect-non-literal-regexp.detect-non-literal-regexp -- static literal regex, no ReDoS risk (linear alternation of fixed strings)
@@ -272,6 +288,41 @@ export function isNetworkBodyCaptureEnabled(): boolean {
   return networkBodyCaptureEnabled
 }
 
+/**
+ * Set the per-body capture ceiling pushed by the server's body_capture_max override.
+ * Out-of-range values restore the default.
+ * @param limit - Maximum characters kept per request or response body
+ */
+export function setNetworkBodyCaptureMax(limit: number): void {
+  networkBodyCaptureMax =
+    Number.isInteger(limit) && limit > 0 && limit <= BODY_CAPTURE_MAX_LIMIT ? limit : BODY_CAPTURE_MAX_DEFAULT
+}
+
+/**
+ * Get the per-body capture ceiling
+ * @returns Maximum characters kept per body
+ */
+export function getNetworkBodyCaptureMax(): number {
+  return networkBodyCaptureMax
+}
+
+/**
+ * Set the binary capture cap pushed by the server's binary_capture_max override.
+ * Out-of-range values turn binary capture off.
+ * @param limit - Bytes of each binary response to capture, 0 for none
+ */
+export function setNetworkBinaryCaptureMax(limit: number): void {
+  networkBinaryCaptureMax = Number.isInteger(limit) && limit > 0 && limit <= BINARY_CAPTURE_MAX_LIMIT ? limit : 0
+}
+
+/**
+ * Get the binary capture cap
+ * @returns Bytes captured per binary response, 0 when off
+ */
+export function getNetworkBinaryCaptureMax(): number {
+  return networkBinaryCaptureMax
+}
+
 /**
  * Set the configured server URL for capture filtering.
  * Called when the server URL is loaded from settings.
@@ -342,25 +393,25 @@ export function sanitizeHeaders(
 }
 
 /**
- * Truncate request body at 8KB limit
+ * Truncate request body at the capture ceiling (64KB by default)
  * @param body - The request body
  * @returns Truncation result
  */
 export function truncateRequestBody(body: string | null | undefined): TruncationResult {
   if (body === null || body === undefined) return { body: null, truncated: false }
-  if (body.length <= REQUEST_BODY_MAX) return { body, truncated: false }
-  return { body: body.slice(0, REQUEST_BODY_MAX), truncated: true }
+  if (body.length <= networkBodyCaptureMax) return { body, truncated: false }
+  return { body: body.slice(0, networkBodyCaptureMax), truncated: true }
 }
 
 /**
- * Truncate response body at 16KB limit
+ * Truncate response body at the capture ceiling (64KB by default)
  * @param body - The response body
  * @returns Truncation result
  */
 export function truncateResponseBody(body: string | null | undefined): TruncationResult {
   if (body === null || body === undefined) return { body: null, truncated: false }
-  if (body.length <= RESPONSE_BODY_MAX) return { body, truncated: false }
-  return { body: body.slice(0, RESPONSE_BODY_MAX), truncated: true }
+  if (body.length <= networkBodyCaptureMax) return { body, truncated: false }
+  return { body: body.slice(0, networkBodyCaptureMax), truncated: true }
 }
 
 /**
@@ -409,6 +460,8 @@ function resetForTesting(): void {
   pendingRequests.clear()
   requestIdCounter = 0
   networkBodyCaptureEnabled = true
+  networkBodyCaptureMax = BODY_CAPTURE_MAX_DEFAULT
+  networkBinaryCaptureMax = 0
   unwrapXHR()
   // Clean up early-patch globals if present
   if (typeof window !== 'undefined') {
@@ -457,6 +510,31 @@ async function readCapturedBody(url: string, cloned: Response | null, contentTyp
   return readResponseBodyWithTimeout(cloned)
 }
 
+/**
+ * Encode bytes as base64 in chunks, keeping String.fromCharCode under the argument limit
+ * @param bytes - The bytes to encode
+ * @returns Base64 string
+ */
+function encodeBase64(bytes: Uint8Array): string {
+  let binary = ''
+  for (let i = 0; i < bytes.length; i += 0x8000) {
+    binary += String.fromCharCode(...bytes.subarray(i, i + 0x8000))
+  }
+  return btoa(binary)
+}
+
+/**
+ * Read the head of a binary response when binary capture is on.
+ * @returns The captured bytes, or null to fall back to the text/placeholder path
+ */
+async function readBinaryCapture(url: string, cloned: Response | null, contentType: string): Promise<BinaryCapture | null> {
+  if (networkBinaryCaptureMax === 0 || !cloned || SENSITIVE_URL_PATTERNS.test(url)) return null
+  if (!BINARY_CONTENT_TYPES.test(contentType) && !BINARY_WIRE_CONTENT_TYPES.test(contentType)) return null
+  const buffer = await cloned.arrayBuffer()
+  const head = new Uint8Array(buffer, 0, Math.min(buffer.byteLength, networkBinaryCaptureMax))
+  return { body: encodeBase64(head), size: buffer.byteLength, truncated: buffer.byteLength > head.length }
+}
+
 function postNetworkBody(
   win: Window,
   url: string,
@@ -467,7 +545,8 @@ function postNetworkBody(
   duration: number,
   truncResp: string,
   truncReq: string | null,
-  responseTruncated: boolean
+  responseTruncated: boolean,
+  binarySize?: number
 ): void {
   const message: NetworkBodyPostMessage = {
     type: 'kaboom_network_body',
@@ -479,6 +558,7 @@ function postNetworkBody(
       request_body: truncReq || (typeof requestBody === 'string' ? requestBody : undefined),
       response_body: truncResp,
       ...(responseTruncated ? { response_truncated: true } : {}),
+      ...(binarySize !== undefined ? { response_encoding: 'base64' as const, response_size: binarySize } : {}),
       duration
     }
   }
@@ -565,6 +645,8 @@ export function wrapXHRWithBodies(): void {
       })
     }
 
+    const mock = findRequestMock(url, method)
+    if (mock) return fulfillXHRWithMock(this, mock)
     return originalXHRSend!.call(this, body as XMLHttpRequestBodyInit | null | undefined)
   }
 }
@@ -643,10 +725,12 @@ export function adoptEarlyBodies(): void {
 export function wrapFetchWithBodies(fetchFn: FetchLike): FetchLike {
   return async function (input: RequestInfo | URL, init?: RequestInit): Promise<Response> {
     const { url, method, requestBody } = extractFetchInfo(input, init)
-    if (!shouldCaptureUrl(url)) return fetchFn(input, init)
+    const mock = findRequestMock(url, method)
+    const send = () => (mock ? mockFetchResponse(mock) : fetchFn(input, init))
+    if (!shouldCaptureUrl(url)) return send()
 
     const startTime = Date.now()
-    const response = await fetchFn(input, init)
+    const response = await send()
     const duration = Date.now() - startTime
     const contentType = response.headers?.get?.('content-type') || ''
     const cloned = response.clone ? response.clone() : null
@@ -655,8 +739,11 @@ export function wrapFetchWithBodies(fetchFn: FetchLike): FetchLike {
     Promise.resolve()
       .then(async () => {
         try {
-          const responseBody = await readCapturedBody(url, cloned, contentType)
-          const { body: truncResp, truncated: respTruncated } = truncateResponseBody(responseBody)
+          const binary = await readBinaryCapture(url, cloned, contentType)
+          const responseBody = binary ? binary.body : await readCapturedBody(url, cloned, contentType)
+          const { body: truncResp, truncated: respTruncated } = binary
+            ? { body: binary.body, truncated: binary.truncated }
+            : truncateResponseBody(responseBody)
           const rawReq = SENSITIVE_URL_PATTERNS.test(url)
             ? '[REDACTED: auth endpoint]'
             : typeof requestBody === 'string'
@@ -674,7 +761,8 @@ export function wrapFetchWithBodies(fetchFn: FetchLike): FetchLike {
               duration,
               truncResp || responseBody,
               truncReq,
-              respTruncated
+              respTruncated,
+              binary?.size
             )
           }
         } catch {
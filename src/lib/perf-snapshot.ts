@@ -9,7 +9,9 @@
  * to build comprehensive performance snapshots.
  */
 
-import { MAX_LONG_TASKS, MAX_SLOWEST_REQUESTS, MAX_URL_LENGTH } from './constants.js'
+import { MAX_INP_INTERACTIONS, MAX_LONG_TASKS, MAX_SLOWEST_REQUESTS, MAX_URL_LENGTH } from './constants.js'
+import { captureMemorySample, measureUASpecificMemory } from './memory-sample.js'
+import { getElementSelector } from './serialize.js'
 
 interface ResourceByType {
   count: number
@@ -52,6 +54,26 @@ interface UserTimingEntry {
   duration?: number
 }
 
+// Slowest event timing entry of one interaction, split into INP phases (ms)
+interface InteractionTiming {
+  interaction_id: number
+  name: string
+  target: string
+  start_time: number
+  duration: number
+  input_delay: number
+  processing_time: number
+  presentation_delay: number
+}
+
+// Event timing entry fields beyond PerformanceEntry
+type EventTimingEntry = PerformanceEntry & {
+  interactionId?: number
+  processingStart?: number
+  processingEnd?: number
+  target?: Node | null
+}
+
 interface PerformanceSnapshotData {
   url: string
   timestamp: string
@@ -63,6 +85,8 @@ interface PerformanceSnapshotData {
     marks: UserTimingEntry[]
     measures: UserTimingEntry[]
   }
+  memory?: ReturnType<typeof captureMemorySample>
+  interactions?: InteractionTiming[]
 }
 
 // Performance snapshot state
@@ -77,6 +101,7 @@ let fcpValue: number | null = null
 let lcpValue: number | null = null
 let clsValue = 0
 let inpValue: number | null = null
+let interactions = new Map<number, InteractionTiming>()
 
 /**
  * Map resource initiator types to standard categories
@@ -163,6 +188,7 @@ export function capturePerformanceSnapshot(): PerformanceSnapshotData | null {
 
   const network = aggregateResourceTiming()
   const longTasks = getLongTaskMetrics()
+  const slowInteractions = getINPInteractions()
 
   // Capture user timing marks and measures
   const marks = (performance.getEntriesByType('mark') as PerformanceEntry[]) || []
@@ -182,7 +208,9 @@ export function capturePerformanceSnapshot(): PerformanceSnapshotData | null {
     network,
     long_tasks: longTasks,
     cumulative_layout_shift: getCLS(),
-    user_timing: userTiming
+    user_timing: userTiming,
+    memory: captureMemorySample(),
+    interactions: slowInteractions.length > 0 ? slowInteractions : undefined
   }
 }
 
@@ -195,6 +223,7 @@ export function installPerfObservers(): void {
   lcpValue = null
   clsValue = 0
   inpValue = null
+  interactions = new Map()
 
   // Long task observer
   // #lizard forgives
@@ -246,11 +275,12 @@ export function installPerfObservers(): void {
   // Event timing entries have interactionId and duration properties
   inpObserver = new PerformanceObserver((list: PerformanceObserverEntryList): void => {
     for (const entry of list.getEntries()) {
-      const inpEntry = entry as PerformanceEntry & { interactionId?: number }
+      const inpEntry = entry as EventTimingEntry
       if (inpEntry.interactionId) {
         if (inpValue === null || inpEntry.duration > inpValue) {
           inpValue = inpEntry.duration
         }
+        recordInteraction(inpEntry)
       }
     }
   })
@@ -282,6 +312,7 @@ export function uninstallPerfObservers(): void {
     inpObserver = null
   }
   longTaskEntries = []
+  interactions = new Map()
 }
 
 /**
@@ -332,6 +363,47 @@ export function getINP(): number | null {
   return inpValue
 }
 
+/**
+ * Keep the slowest entry per interaction, and only the MAX_INP_INTERACTIONS slowest interactions.
+ * The breakdown follows the INP phases: input delay until handlers start, handler processing,
+ * and presentation delay until the next frame is painted.
+ */
+export function recordInteraction(entry: EventTimingEntry): void {
+  const id = entry.interactionId
+  if (!id) return
+  const existing = interactions.get(id)
+  if (existing && existing.duration >= entry.duration) return
+
+  const processingStart = entry.processingStart ?? entry.startTime
+  const processingEnd = entry.processingEnd ?? processingStart
+  const target = entry.target && (entry.target as Element).tagName ? (entry.target as Element) : null
+  interactions.set(id, {
+    interaction_id: id,
+    name: entry.name,
+    target: getElementSelector(target),
+    start_time: Math.round(entry.startTime),
+    duration: Math.round(entry.duration),
+    input_delay: Math.round(Math.max(0, processingStart - entry.startTime)),
+    processing_time: Math.round(Math.max(0, processingEnd - processingStart)),
+    presentation_delay: Math.round(Math.max(0, entry.startTime + entry.duration - processingEnd))
+  })
+
+  if (interactions.size > MAX_INP_INTERACTIONS) {
+    let fastest: InteractionTiming | null = null
+    for (const interaction of interactions.values()) {
+      if (!fastest || interaction.duration < fastest.duration) fastest = interaction
+    }
+    if (fastest) interactions.delete(fastest.interaction_id)
+  }
+}
+
+/**
+ * Get the slowest recorded interactions, slowest first
+ */
+export function getINPInteractions(): InteractionTiming[] {
+  return [...interactions.values()].sort((a, b) => b.duration - a.duration)
+}
+
 /**
  * Send performance snapshot via postMessage to content script
  */
@@ -342,6 +414,13 @@ export function sendPerformanceSnapshot(): void {
   if (!snapshot) return
 
   window.postMessage({ type: 'kaboom_performance_snapshot', payload: snapshot }, window.location.origin)
+
+  // UA-specific memory resolves late; re-send so the server replaces this load's sample.
+  void measureUASpecificMemory().then((bytes) => {
+    if (bytes === null || !perfSnapshotEnabled) return
+    const updated = capturePerformanceSnapshot()
+    if (updated) window.postMessage({ type: 'kaboom_performance_snapshot', payload: updated }, window.location.origin)
+  })
 }
 
 // Debounce timer for snapshot re-sends triggered by user timing changes
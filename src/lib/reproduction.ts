@@ -54,6 +54,7 @@ interface EnhancedActionRecord {
   classification?: string
   duration_ms?: number
   role?: string
+  locale?: string
 }
 
 // Script generation options
@@ -320,6 +321,17 @@ const ACTION_DATA_ENRICHERS: Record<string, ActionDataEnricher> = {
   }
 }
 
+/**
+ * Page UI language: <html lang>, falling back to the browser language.
+ * Text selectors only match in this locale, so test generation records it.
+ */
+function pageLocale(): string {
+  if (typeof document !== 'undefined' && document.documentElement && document.documentElement.lang) {
+    return document.documentElement.lang
+  }
+  return typeof navigator !== 'undefined' && navigator.language ? navigator.language : ''
+}
+
 /**
  * Record an enhanced action with multi-strategy selectors
  */
@@ -334,6 +346,9 @@ export function recordEnhancedAction(
     url: typeof window !== 'undefined' && window.location ? window.location.href : ''
   }
 
+  const locale = pageLocale()
+  if (locale) action.locale = locale
+
   if (element) {
     action.selectors = computeSelectors(element)
   }
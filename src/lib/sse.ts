@@ -0,0 +1,180 @@
+/**
+ * Purpose: Wraps the EventSource constructor to capture Server-Sent Events lifecycle (open, retry, error, close) and messages, including named event types.
+ * Docs: docs/features/feature/sse-tracking/index.md
+ */
+
+// sse.ts — EventSource constructor instrumentation and capture installation.
+
+/**
+ * @fileoverview Server-Sent Events capture.
+ * Wraps the EventSource constructor so every stream the page opens reports its
+ * lifecycle and messages. The browser does not expose the `retry:` field, so a
+ * reconnect is recognized by an error event that leaves the stream CONNECTING;
+ * an error that leaves it CLOSED means the browser gave up.
+ *
+ * Named events (`event: update` lines) are only dispatched to listeners for that
+ * name, so capture follows the page: the first addEventListener for a new name
+ * also subscribes the capture listener for it.
+ */
+
+import type { WireSSEEvent } from '../types/index.js'
+import { getSize, truncateWsMessage } from './websocket-tracking.js'
+
+// =============================================================================
+// MODULE STATE
+// =============================================================================
+
+let originalEventSource: typeof EventSource | null = null
+let sseCaptureEnabled = true
+
+/** Events that are lifecycle notifications, not named message types */
+const LIFECYCLE_EVENTS = new Set(['open', 'error'])
+
+/** EventSource readyState values */
+const READY_STATE_CONNECTING = 0
+const READY_STATE_CLOSED = 2
+
+/** PostMessage payload type */
+interface KaboomSseMessage {
+  type: 'kaboom_sse'
+  payload: WireSSEEvent
+}
+
+// =============================================================================
+// CAPTURE EVENT HELPERS
+// =============================================================================
+
+function postSSEEvent(payload: WireSSEEvent): void {
+  window.postMessage({ type: 'kaboom_sse', payload } as KaboomSseMessage, window.location.origin)
+}
+
+/** Post a lifecycle event (open/retry/error/close) */
+function postLifecycleEvent(event: WireSSEEvent['event'], connectionId: string, source: EventSource): void {
+  postSSEEvent({
+    event,
+    id: connectionId,
+    url: source.url,
+    ts: new Date().toISOString(),
+    ...(source.withCredentials && { with_credentials: true })
+  })
+}
+
+/** Post a message event, keeping the event name and Last-Event-ID */
+function postMessageEvent(connectionId: string, source: EventSource, message: MessageEvent<string>): void {
+  const raw = typeof message.data === 'string' ? message.data : String(message.data)
+  const { data, truncated } = truncateWsMessage(raw)
+  postSSEEvent({
+    event: 'message',
+    id: connectionId,
+    url: source.url,
+    ts: new Date().toISOString(),
+    event_type: message.type,
+    ...(message.lastEventId && { last_event_id: message.lastEventId }),
+    data,
+    size: getSize(raw),
+    ...(truncated && { truncated: true })
+  })
+}
+
+/** Attach lifecycle and message capture to an EventSource instance */
+function attachCapture(source: EventSource, connectionId: string): void {
+  const originalAddEventListener = source.addEventListener
+  const captured = new Set<string>()
+  const captureType = (type: string): void => {
+    if (captured.has(type) || LIFECYCLE_EVENTS.has(type)) return
+    captured.add(type)
+    originalAddEventListener.call(source, type, (event: Event) => {
+      if (!sseCaptureEnabled) return
+      postMessageEvent(connectionId, source, event as MessageEvent<string>)
+    })
+  }
+
+  source.addEventListener = function (
+    type: string,
+    listener: EventListenerOrEventListenerObject | null,
+    options?: boolean | AddEventListenerOptions
+  ): void {
+    captureType(type)
+    originalAddEventListener.call(source, type, listener, options)
+  } as EventSource['addEventListener']
+
+  captureType('message')
+
+  originalAddEventListener.call(source, 'open', () => {
+    if (!sseCaptureEnabled) return
+    postLifecycleEvent('open', connectionId, source)
+  })
+  originalAddEventListener.call(source, 'error', () => {
+    if (!sseCaptureEnabled) return
+    const reconnecting = source.readyState === READY_STATE_CONNECTING
+    postLifecycleEvent(reconnecting ? 'retry' : 'error', connectionId, source)
+  })
+
+  const originalClose = source.close.bind(source)
+  source.close = function (): void {
+    const wasClosed = source.readyState === READY_STATE_CLOSED
+    originalClose()
+    if (sseCaptureEnabled && !wasClosed) postLifecycleEvent('close', connectionId, source)
+  }
+}
+
+// =============================================================================
+// INSTALLATION
+// =============================================================================
+
+/**
+ * Install SSE capture by wrapping the EventSource constructor.
+ */
+export function installSSECapture(): void {
+  if (typeof window === 'undefined') return
+  if (!window.EventSource) return // No EventSource support
+  if (originalEventSource) return // Already installed
+  sseCaptureEnabled = true
+
+  originalEventSource = window.EventSource
+  const OriginalES = originalEventSource
+
+  function KaboomEventSource(this: EventSource, url: string | URL, init?: EventSourceInit): EventSource {
+    const source = new OriginalES(url, init)
+    attachCapture(source, crypto.randomUUID())
+    return source
+  }
+
+  KaboomEventSource.prototype = OriginalES.prototype
+  Object.defineProperty(KaboomEventSource, 'CONNECTING', { value: OriginalES.CONNECTING, writable: false })
+  Object.defineProperty(KaboomEventSource, 'OPEN', { value: OriginalES.OPEN, writable: false })
+  Object.defineProperty(KaboomEventSource, 'CLOSED', { value: OriginalES.CLOSED, writable: false })
+
+  window.EventSource = KaboomEventSource as unknown as typeof EventSource
+}
+
+// =============================================================================
+// CONFIGURATION
+// =============================================================================
+
+/**
+ * Set SSE capture enabled state
+ */
+export function setSSECaptureEnabled(enabled: boolean): void {
+  sseCaptureEnabled = enabled
+}
+
+/**
+ * Uninstall SSE capture, restoring the original constructor
+ */
+export function uninstallSSECapture(): void {
+  if (typeof window === 'undefined') return
+  if (originalEventSource) {
+    window.EventSource = originalEventSource
+    originalEventSource = null
+  }
+}
+
+/**
+ * Reset all module state for testing purposes
+ */
+export function resetForTesting(): void {
+  uninstallSSECapture()
+  sseCaptureEnabled = false
+  originalEventSource = null
+}
@@ -71,6 +71,7 @@ export type {
   GetTabIdMessage,
   GetTabIdResponse,
   WsEventMessage,
+  SseEventMessage,
   EnhancedActionMessage,
   NetworkBodyMessage,
   PerformanceSnapshotMessage,
@@ -150,6 +151,8 @@ export type { WireNetworkBody, WireNetworkWaterfallEntry, WireNetworkWaterfallPa
 
 export type { WireWebSocketEvent } from './wire-websocket-event.js'
 
+export type { WireSSEEvent } from './wire-sse-event.js'
+
 export type {
   WirePerformanceTiming,
   WireTypeSummary,
@@ -11,6 +11,7 @@
 
 import type { LogEntry, ScreenshotLogEntry } from './telemetry.js'
 import type { WebSocketEvent, WebSocketCaptureMode } from './websocket.js'
+import type { WireSSEEvent } from './wire-sse-event.js'
 import type { NetworkBodyPayload, WaterfallEntry } from './network.js'
 import type { EnhancedAction } from './actions.js'
 import type { PerformanceSnapshot } from './performance.js'
@@ -45,6 +46,15 @@ export interface WsEventMessage {
   readonly tabId?: number
 }
 
+/**
+ * Server-Sent Events (EventSource) event message from content script
+ */
+export interface SseEventMessage {
+  readonly type: 'sse_event'
+  readonly payload: WireSSEEvent
+  readonly tabId?: number
+}
+
 /**
  * Enhanced action message from content script
  */
@@ -220,6 +230,7 @@ export interface VersionMismatchMessage {
 export type BackgroundMessage =
   | GetTabIdMessage
   | WsEventMessage
+  | SseEventMessage
   | EnhancedActionMessage
   | NetworkBodyMessage
   | PerformanceSnapshotMessage
@@ -610,6 +621,7 @@ export type ContentMessage =
 export type PageMessageType =
   | 'kaboom_log'
   | 'kaboom_ws'
+  | 'kaboom_sse'
   | 'kaboom_network_body'
   | 'kaboom_enhanced_action'
   | 'kaboom_performance_snapshot'
@@ -22,9 +22,16 @@ export interface WireNetworkBody {
   readonly duration?: number
   readonly request_truncated?: boolean
   readonly response_truncated?: boolean
+  readonly response_encoding?: string
+  readonly response_size?: number
+  readonly request_headers?: Readonly<Record<string, string>>
   readonly tab_id?: number
+  readonly frame_id?: number
   // server-only: ts — server-side timestamp
   // server-only: response_headers, has_auth_header, binary_format, format_confidence, test_ids
+  // server-only: request_id, full_body_ref — full_body_ref is set when a body spills past the inline limit
+  // server-only: body_evicted — set when memory pressure shed the bodies
+  // server-only: binary_preview — summary of a captured binary response
 }
 
 /**
@@ -50,4 +57,5 @@ export interface WireNetworkWaterfallEntry {
 export interface WireNetworkWaterfallPayload {
   readonly entries: readonly WireNetworkWaterfallEntry[]
   readonly page_url: string
+  readonly tab_id?: number
 }
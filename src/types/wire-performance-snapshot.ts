@@ -76,6 +76,34 @@ export interface WireUserTimingData {
   readonly measures: readonly WireUserTimingEntry[]
 }
 
+/**
+ * WireMemorySample holds heap and DOM size readings taken with the snapshot.
+ */
+export interface WireMemorySample {
+  readonly time_origin: number
+  readonly used_js_heap_size?: number
+  readonly total_js_heap_size?: number
+  readonly js_heap_size_limit?: number
+  readonly ua_specific_bytes?: number | null
+  readonly dom_nodes: number
+  readonly event_listeners: number
+  readonly detached_listeners: number
+}
+
+/**
+ * WireInteractionTiming is the slowest event timing entry of one user interaction.
+ */
+export interface WireInteractionTiming {
+  readonly interaction_id: number
+  readonly name: string
+  readonly target: string
+  readonly start_time: number
+  readonly duration: number
+  readonly input_delay: number
+  readonly processing_time: number
+  readonly presentation_delay: number
+}
+
 /**
  * WirePerformanceSnapshot is the JSON shape sent over HTTP for performance data.
  */
@@ -87,5 +115,7 @@ export interface WirePerformanceSnapshot {
   readonly long_tasks: WireLongTaskMetrics
   readonly cumulative_layout_shift?: number | null
   readonly user_timing?: WireUserTimingData
+  readonly memory?: WireMemorySample
+  readonly interactions?: readonly WireInteractionTiming[]
   // server-only: resources — added by Go daemon for causal diffing
 }
@@ -23,5 +23,8 @@ export interface WireWebSocketEvent {
   readonly size?: number
   readonly code?: number
   readonly reason?: string
-  // server-only: sampled, binary_format, format_confidence, tab_id, test_ids
+  readonly tab_id?: number
+  readonly frame_id?: number
+  // server-only: sampled, binary_format, format_confidence, test_ids
+  // server-only: data_evicted — set when memory pressure shed the payload
 }
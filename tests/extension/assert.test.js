@@ -0,0 +1,128 @@
+// @ts-nocheck
+/**
+ * @fileoverview assert.test.js — The page snapshot behind observe(what="assert"): selector
+ * counts, visibility, text matches, and invalid selectors, read in one injected pass.
+ */
+
+import { beforeEach, describe, mock, test } from 'node:test'
+import assert from 'node:assert'
+import { createMockChrome } from './helpers.js'
+
+if (typeof globalThis.navigator === 'undefined') globalThis.navigator = { platform: 'Linux' }
+
+function element(text, { hidden = false } = {}) {
+  return {
+    innerText: text,
+    textContent: text,
+    checkVisibility: () => !hidden
+  }
+}
+
+function installPage(elementsBySelector) {
+  globalThis.location = { href: 'https://shop.test/orders/42' }
+  globalThis.document = {
+    title: 'Order 42',
+    querySelectorAll: (selector) => {
+      if (selector.startsWith('[')) throw new SyntaxError(`'${selector}' is not a valid selector`)
+      return elementsBySelector[selector] || []
+    }
+  }
+}
+
+describe('assertPageProbe', () => {
+  test('reports count, visible count, texts, and the first text match per check', async () => {
+    const { assertPageProbe } = await import('../../extension/background/commands/assert.js')
+    installPage({
+      '.toast': [element('  Draft   kept '), element('Order saved', { hidden: true })],
+      '.row': [element('a'), element('b'), element('c'), element('d')]
+    })
+
+    const snapshot = assertPageProbe([
+      { selector: '.toast', text_contains: 'saved' },
+      { selector: '.row' },
+      { selector: '#missing', text_contains: 'x' },
+      { selector: '[broken' }
+    ])
+
+    assert.strictEqual(snapshot.url, 'https://shop.test/orders/42')
+    assert.strictEqual(snapshot.title, 'Order 42')
+    const [toast, rows, missing, broken] = snapshot.elements
+    assert.deepStrictEqual(toast, {
+      selector: '.toast',
+      count: 2,
+      visible_count: 1,
+      texts: ['Draft kept', 'Order saved'],
+      text_match: 'Order saved'
+    })
+    assert.strictEqual(rows.count, 4)
+    assert.strictEqual(rows.texts.length, 3, 'texts are capped')
+    assert.deepStrictEqual(missing, { selector: '#missing', count: 0, visible_count: 0 })
+    assert.strictEqual(broken.count, 0)
+    assert.match(broken.error, /not a valid selector/)
+  })
+})
+
+describe('assert command', () => {
+  const trackedTabId = 5151
+
+  beforeEach(async () => {
+    mock.reset()
+    globalThis.chrome = createMockChrome({
+      storage: {
+        local: {
+          get: mock.fn((keys, callback) => {
+            const data = { serverUrl: 'http://localhost:7890', trackedTabId, trackedTabUrl: 'https://shop.test/orders/42' }
+            if (callback) callback(data)
+            return Promise.resolve(data)
+          }),
+          set: mock.fn((data, callback) => (callback ? callback() : Promise.resolve())),
+          remove: mock.fn((keys, callback) => (callback ? callback() : Promise.resolve()))
+        },
+        sync: { get: mock.fn((keys, callback) => callback && callback({})), set: mock.fn() },
+        session: { get: mock.fn((keys, callback) => callback && callback({})), set: mock.fn() },
+        onChanged: { addListener: mock.fn() }
+      },
+      tabs: {
+        get: mock.fn((tabId) => Promise.resolve({ id: tabId, windowId: 1, url: 'https://shop.test/orders/42', status: 'complete' })),
+        query: mock.fn((query, callback) => {
+          const result = [{ id: trackedTabId, windowId: 1, url: 'https://shop.test/orders/42' }]
+          if (callback) callback(result)
+          return Promise.resolve(result)
+        }),
+        sendMessage: mock.fn(() => Promise.resolve({ success: true })),
+        onRemoved: { addListener: mock.fn() },
+        onUpdated: { addListener: mock.fn() }
+      },
+      scripting: {
+        executeScript: mock.fn(({ func, args }) => Promise.resolve([{ result: func(...args) }]))
+      },
+      alarms: { create: mock.fn(), onAlarm: { addListener: mock.fn() } }
+    })
+    globalThis.fetch = mock.fn(() => Promise.resolve({ ok: true, json: () => Promise.resolve({ queries: [] }) }))
+    const bgModule = await import('../../extension/background.js')
+    bgModule.markInitComplete()
+  })
+
+  test('runs every check in the tracked tab in a single injection', async () => {
+    installPage({ '#total': [element('Total: $42')] })
+    const bgModule = await import('../../extension/background.js')
+    const syncClient = { queueCommandResult: mock.fn() }
+
+    await bgModule.handlePendingQuery(
+      {
+        id: 'q-assert',
+        type: 'assert',
+        correlation_id: 'corr-assert',
+        params: JSON.stringify({ checks: [{ selector: '#total', text_contains: '$42' }, { selector: '.spinner' }] })
+      },
+      syncClient
+    )
+
+    assert.strictEqual(globalThis.chrome.scripting.executeScript.mock.calls.length, 1)
+    assert.strictEqual(globalThis.chrome.scripting.executeScript.mock.calls[0].arguments[0].target.tabId, trackedTabId)
+    const result = syncClient.queueCommandResult.mock.calls[0].arguments[0].result
+    assert.strictEqual(result.title, 'Order 42')
+    assert.strictEqual(result.elements[0].text_match, 'Total: $42')
+    assert.strictEqual(result.elements[1].count, 0)
+  })
+})
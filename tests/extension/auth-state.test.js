@@ -0,0 +1,209 @@
+// @ts-nocheck
+/**
+ * @fileoverview auth-state.test.js — save_state/load_state vault=true in the extension:
+ * capturing HttpOnly cookies and unredacted storage, and restoring them on the same origin.
+ */
+
+import { beforeEach, describe, mock, test } from 'node:test'
+import assert from 'node:assert'
+import { MANIFEST_VERSION } from './helpers.js'
+
+if (typeof globalThis.navigator === 'undefined') globalThis.navigator = { platform: 'Linux' }
+
+function createMockChrome(trackedTabId = 1830196419) {
+  return {
+    runtime: {
+      onMessage: { addListener: mock.fn() },
+      onInstalled: { addListener: mock.fn() },
+      sendMessage: mock.fn(() => Promise.resolve()),
+      getManifest: () => ({ version: MANIFEST_VERSION })
+    },
+    action: {
+      setBadgeText: mock.fn(),
+      setBadgeBackgroundColor: mock.fn()
+    },
+    tabs: {
+      query: mock.fn((query, callback) => {
+        const result = query?.active
+          ? [{ id: trackedTabId, windowId: 1, url: 'https://app.test/dashboard' }]
+          : [{ id: trackedTabId, windowId: 1, url: 'https://app.test/dashboard' }]
+        if (callback) callback(result)
+        return Promise.resolve(result)
+      }),
+      sendMessage: mock.fn(() => Promise.resolve({ success: true })),
+      get: mock.fn((tabId) => Promise.resolve({ id: tabId, windowId: 1, url: 'https://app.test/dashboard', status: 'complete' })),
+      reload: mock.fn(() => Promise.resolve()),
+      update: mock.fn(() => Promise.resolve()),
+      onRemoved: { addListener: mock.fn() }
+    },
+    scripting: {
+      executeScript: mock.fn(({ func }) =>
+        Promise.resolve([
+          {
+            result:
+              func.name === 'readWebStorageProbe'
+                ? { local_storage: { access_token: 'eyJ.secret' }, session_storage: { csrf: 'c1' } }
+                : { local_storage: 1, session_storage: 1 }
+          }
+        ])
+      )
+    },
+    cookies: {
+      getAll: mock.fn(({ url }) =>
+        Promise.resolve(
+          url
+            ? [{ name: 'sid', value: 's3cret', domain: 'app.test', path: '/', secure: true, httpOnly: true, hostOnly: true, sameSite: 'lax' }]
+            : [
+                { name: 'sid', value: 's3cret', domain: 'app.test', path: '/', secure: true, httpOnly: true, hostOnly: true, sameSite: 'lax' },
+                { name: 'api', value: 'a1', domain: '.app.test', path: '/api', secure: true, httpOnly: false, hostOnly: false, sameSite: 'no_restriction', expirationDate: 4102444800 }
+              ]
+        )
+      ),
+      set: mock.fn((details) => Promise.resolve(details))
+    },
+    storage: {
+      local: {
+        get: mock.fn((keys, callback) => {
+          const data = {
+            serverUrl: 'http://localhost:7890',
+            aiWebPilotEnabled: true,
+            trackedTabId,
+            trackedTabUrl: 'https://app.test/dashboard',
+            trackedTabTitle: 'Tracked Tab'
+          }
+          if (callback) callback(data)
+          return Promise.resolve(data)
+        }),
+        set: mock.fn((data, callback) => {
+          if (callback) callback()
+          return Promise.resolve()
+        }),
+        remove: mock.fn((keys, callback) => {
+          if (callback) callback()
+          return Promise.resolve()
+        })
+      },
+      sync: {
+        get: mock.fn((keys, callback) => {
+          if (callback) callback({})
+          return Promise.resolve({})
+        }),
+        set: mock.fn((data, callback) => {
+          if (callback) callback()
+          return Promise.resolve()
+        })
+      },
+      session: {
+        get: mock.fn((keys, callback) => {
+          if (callback) callback({})
+          return Promise.resolve({})
+        }),
+        set: mock.fn((data, callback) => {
+          if (callback) callback()
+          return Promise.resolve()
+        })
+      },
+      onChanged: { addListener: mock.fn() }
+    },
+    alarms: {
+      create: mock.fn(),
+      onAlarm: { addListener: mock.fn() }
+    }
+  }
+}
+
+async function runAuthState(params) {
+  const bgModule = await import('../../extension/background.js')
+  const mockSyncClient = { queueCommandResult: mock.fn() }
+  await bgModule.handlePendingQuery(
+    { id: 'q-auth', type: 'auth_state', correlation_id: 'corr-auth', params: JSON.stringify(params) },
+    mockSyncClient
+  )
+  return mockSyncClient.queueCommandResult.mock.calls[0].arguments[0].result
+}
+
+describe('auth_state command', () => {
+  const trackedTabId = 6161
+
+  beforeEach(async () => {
+    mock.reset()
+    globalThis.chrome = createMockChrome(trackedTabId)
+    globalThis.fetch = mock.fn(() => Promise.resolve({ ok: true, json: () => Promise.resolve({ queries: [] }) }))
+    const bgModule = await import('../../extension/background.js')
+    bgModule.markInitComplete()
+  })
+
+  test('capture returns HttpOnly cookies from every path and unredacted storage', async () => {
+    const result = await runAuthState({ action: 'capture' })
+
+    assert.strictEqual(result.origin, 'https://app.test')
+    assert.strictEqual(result.url, 'https://app.test/dashboard')
+    assert.deepStrictEqual(
+      result.cookies.map((c) => [c.name, c.path, c.http_only]),
+      [
+        ['sid', '/', true],
+        ['api', '/api', false]
+      ]
+    )
+    assert.deepStrictEqual(result.local_storage, { access_token: 'eyJ.secret' })
+    assert.deepStrictEqual(result.session_storage, { csrf: 'c1' })
+    assert.strictEqual(globalThis.chrome.scripting.executeScript.mock.calls[0].arguments[0].world, 'MAIN')
+  })
+
+  test('restore sets cookies and storage, then reloads the page', async () => {
+    const result = await runAuthState({
+      action: 'restore',
+      origin: 'https://app.test',
+      url: 'https://app.test/dashboard',
+      cookies: [
+        { name: 'sid', value: 's3cret', domain: 'app.test', path: '/', secure: true, http_only: true, host_only: true },
+        { name: 'old', value: 'x', domain: 'app.test', path: '/', expiration_date: 1 }
+      ],
+      local_storage: { access_token: 'eyJ.secret' },
+      session_storage: {}
+    })
+
+    const set = globalThis.chrome.cookies.set.mock.calls.map((c) => c.arguments[0])
+    assert.strictEqual(set.length, 1, 'expired cookies are skipped')
+    assert.strictEqual(set[0].httpOnly, true)
+    assert.strictEqual(set[0].domain, undefined, 'host-only cookies are set without a domain')
+    assert.deepStrictEqual(result.restored, {
+      cookies: 1,
+      cookies_skipped: 1,
+      local_storage: 1,
+      session_storage: 1,
+      url: 'https://app.test/dashboard'
+    })
+    const write = globalThis.chrome.scripting.executeScript.mock.calls[0].arguments[0]
+    assert.deepStrictEqual(write.args, [{ access_token: 'eyJ.secret' }, {}])
+    assert.strictEqual(globalThis.chrome.tabs.reload.mock.callCount(), 1)
+  })
+
+  test('restore refuses a tab on another origin', async () => {
+    const result = await runAuthState({ action: 'restore', origin: 'https://other.test', cookies: [] })
+
+    assert.strictEqual(result.error, 'origin_mismatch')
+    assert.strictEqual(globalThis.chrome.cookies.set.mock.callCount(), 0)
+  })
+})
+
+describe('toCookieDetails', () => {
+  test('keeps the domain of domain cookies and maps same_site', async () => {
+    const { toCookieDetails } = await import('../../extension/background/commands/auth-state.js')
+    const details = toCookieDetails(
+      { name: 'api', value: 'a1', domain: '.app.test', path: '/api', secure: true, same_site: 'no_restriction', expiration_date: 4102444800 },
+      1000
+    )
+    assert.deepStrictEqual(details, {
+      url: 'https://app.test/api',
+      name: 'api',
+      value: 'a1',
+      path: '/api',
+      domain: '.app.test',
+      secure: true,
+      httpOnly: false,
+      sameSite: 'no_restriction',
+      expirationDate: 4102444800
+    })
+  })
+})
@@ -387,6 +387,19 @@ describe('formatLogEntry', () => {
     assert.ok(entry.args[0].includes('[truncated]'))
   })
 
+  test('should keep large object args structured so fields stay addressable', () => {
+    const entry = formatLogEntry({
+      level: 'log',
+      type: 'console',
+      args: [{ requestId: 'req-42', payload: 'x'.repeat(20000), after: 1 }, ['a', 'x'.repeat(20000), 'c']]
+    })
+
+    assert.strictEqual(entry.args[0].requestId, 'req-42')
+    assert.strictEqual(entry.args[0].payload, '[truncated]')
+    assert.strictEqual(entry.args[0].after, 1)
+    assert.deepStrictEqual(entry.args[1], ['a', '[truncated]'])
+  })
+
   test('should handle circular references', () => {
     const obj = { name: 'test' }
     obj.self = obj // circular
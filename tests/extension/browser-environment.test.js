@@ -0,0 +1,56 @@
+// @ts-nocheck
+/**
+ * @fileoverview browser-environment.test.js — the environment reported with each sync:
+ * page measurement, the per-tab cache, and the service-worker fallback.
+ */
+
+import { beforeEach, describe, mock, test } from 'node:test'
+import assert from 'node:assert'
+
+if (typeof globalThis.navigator === 'undefined') {
+  globalThis.navigator = { userAgent: 'worker-ua', language: 'en-US' }
+}
+
+const pageEnv = {
+  user_agent: 'Mozilla/5.0 (page)',
+  locale: 'de-DE',
+  timezone: 'Europe/Berlin',
+  viewport_width: 1280,
+  viewport_height: 720,
+  device_pixel_ratio: 2
+}
+
+const { getBrowserEnvironment, resetBrowserEnvironmentCache } = await import(
+  '../../extension/background/browser-environment.js'
+)
+
+describe('getBrowserEnvironment', () => {
+  beforeEach(() => {
+    resetBrowserEnvironmentCache()
+    globalThis.chrome = {
+      scripting: { executeScript: mock.fn(() => Promise.resolve([{ result: pageEnv }])) }
+    }
+  })
+
+  test('measures the tracked page and adds the extension version', async () => {
+    const env = await getBrowserEnvironment(7, 'https://shop.example.com/', '0.7.12')
+    assert.deepStrictEqual(env, { ...pageEnv, extension_version: '0.7.12' })
+    assert.strictEqual(globalThis.chrome.scripting.executeScript.mock.calls[0].arguments[0].target.tabId, 7)
+  })
+
+  test('reuses the measurement for the same tab and URL', async () => {
+    await getBrowserEnvironment(7, 'https://shop.example.com/', '0.7.12')
+    await getBrowserEnvironment(7, 'https://shop.example.com/', '0.7.12')
+    assert.strictEqual(globalThis.chrome.scripting.executeScript.mock.callCount(), 1)
+    await getBrowserEnvironment(7, 'https://shop.example.com/cart', '0.7.12')
+    assert.strictEqual(globalThis.chrome.scripting.executeScript.mock.callCount(), 2)
+  })
+
+  test('falls back to the worker view when the page cannot be scripted', async () => {
+    globalThis.chrome.scripting.executeScript = mock.fn(() => Promise.reject(new Error('Cannot access chrome:// URL')))
+    const env = await getBrowserEnvironment(7, 'chrome://settings', '0.7.12')
+    assert.strictEqual(env.user_agent, globalThis.navigator.userAgent)
+    assert.strictEqual(env.viewport_width, undefined)
+    assert.strictEqual(env.extension_version, '0.7.12')
+  })
+})
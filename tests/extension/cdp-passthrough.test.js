@@ -0,0 +1,111 @@
+// @ts-nocheck
+/**
+ * @fileoverview cdp-passthrough.test.js — interact(what="cdp"): the debugger session is held
+ * between passthrough calls so state they set persists, and ends on an explicit release.
+ */
+
+import { beforeEach, describe, mock, test } from 'node:test'
+import assert from 'node:assert'
+
+if (typeof globalThis.navigator === 'undefined') globalThis.navigator = { userAgent: 'worker-ua' }
+
+const { executeCDPAction } = await import('../../extension/background/cdp-dispatch.js')
+const { applyDeviceEmulation, resetDeviceEmulationForTesting } = await import(
+  '../../extension/background/device-emulation.js'
+)
+
+/**
+ * Fake chrome.debugger with one session per attach. Session state (network conditions)
+ * lives on the session, so a detach drops it just as Chrome does.
+ */
+function fakeDebugger() {
+  let session = null
+  let sessions = 0
+  return {
+    attach: mock.fn(() => {
+      if (session) return Promise.reject(new Error('Another debugger is already attached to the tab'))
+      session = { id: ++sessions, network: null }
+      return Promise.resolve()
+    }),
+    detach: mock.fn(() => {
+      session = null
+      return Promise.resolve()
+    }),
+    sendCommand: mock.fn((_target, method, params) => {
+      if (!session) return Promise.reject(new Error('Debugger is not attached to the tab'))
+      if (method === 'Network.emulateNetworkConditions') session.network = params
+      return Promise.resolve({ session: session.id, network: session.network })
+    }),
+    onDetach: { addListener: mock.fn() }
+  }
+}
+
+function query(params) {
+  return { id: 'q1', correlation_id: 'cdp_1', type: 'cdp_action', params: JSON.stringify(params) }
+}
+
+async function run(params) {
+  let sent
+  const sendAsyncResult = (_client, _id, _corr, status, result, error) => (sent = { status, result, error })
+  await executeCDPAction(query(params), 7, {}, sendAsyncResult, () => {})
+  return sent
+}
+
+const slow3G = { offline: false, latency: 400, downloadThroughput: 50000, uploadThroughput: 20000 }
+
+describe('cdp passthrough session', () => {
+  beforeEach(() => {
+    resetDeviceEmulationForTesting()
+    globalThis.chrome = { debugger: fakeDebugger() }
+  })
+
+  test('network conditions set by one call are still in effect on a later call', async () => {
+    const set = await run({ action: 'passthrough', method: 'Network.emulateNetworkConditions', params: slow3G })
+    assert.strictEqual(set.status, 'complete')
+
+    const later = await run({ action: 'passthrough', method: 'Network.enable', params: {} })
+    assert.strictEqual(later.status, 'complete')
+    assert.deepStrictEqual(later.result.result.network, slow3G)
+    assert.strictEqual(later.result.result.session, set.result.result.session)
+    assert.strictEqual(globalThis.chrome.debugger.attach.mock.callCount(), 1)
+    assert.strictEqual(globalThis.chrome.debugger.detach.mock.callCount(), 0)
+  })
+
+  test('hardware clicks reuse the held session without detaching it', async () => {
+    await run({ action: 'passthrough', method: 'Network.emulateNetworkConditions', params: slow3G })
+    const click = await run({ action: 'click', x: 10, y: 20 })
+    assert.strictEqual(click.status, 'complete')
+    assert.strictEqual(globalThis.chrome.debugger.detach.mock.callCount(), 0)
+
+    const later = await run({ action: 'passthrough', method: 'Network.enable', params: {} })
+    assert.deepStrictEqual(later.result.result.network, slow3G)
+  })
+
+  test('release detaches and ends the passthrough state', async () => {
+    await run({ action: 'passthrough', method: 'Network.emulateNetworkConditions', params: slow3G })
+    const released = await run({ action: 'release' })
+    assert.deepStrictEqual(released.result, { success: true, action: 'cdp', released: true })
+    assert.strictEqual(globalThis.chrome.debugger.detach.mock.callCount(), 1)
+
+    const later = await run({ action: 'passthrough', method: 'Network.enable', params: {} })
+    assert.strictEqual(later.result.result.network, null)
+    assert.strictEqual(globalThis.chrome.debugger.attach.mock.callCount(), 2)
+
+    assert.strictEqual((await run({ action: 'release' })).result.released, true)
+    assert.strictEqual((await run({ action: 'release' })).result.released, false)
+  })
+
+  test('release on an emulated tab restarts the session and re-applies emulation', async () => {
+    await applyDeviceEmulation(7, { width: 390, height: 844, device_scale_factor: 3, touch: true, mobile: true })
+    await run({ action: 'passthrough', method: 'Network.emulateNetworkConditions', params: slow3G })
+    assert.strictEqual(globalThis.chrome.debugger.attach.mock.callCount(), 1, 'passthrough shares the emulation session')
+
+    await run({ action: 'release' })
+    assert.strictEqual(globalThis.chrome.debugger.attach.mock.callCount(), 2)
+    const methods = globalThis.chrome.debugger.sendCommand.mock.calls.map((c) => c.arguments[1])
+    assert.strictEqual(methods.at(-1), 'Emulation.setTouchEmulationEnabled')
+
+    const later = await run({ action: 'passthrough', method: 'Network.enable', params: {} })
+    assert.strictEqual(later.result.result.network, null)
+  })
+})
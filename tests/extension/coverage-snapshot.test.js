@@ -0,0 +1,75 @@
+// @ts-nocheck
+/**
+ * @fileoverview coverage-snapshot.test.js — observe(coverage, collect=true): converting CDP
+ * block coverage and CSS rule usage into the used byte ranges posted to /coverage-snapshots.
+ */
+
+import { describe, test } from 'node:test'
+import assert from 'node:assert'
+
+if (typeof globalThis.navigator === 'undefined') globalThis.navigator = { platform: 'Linux' }
+if (typeof globalThis.chrome === 'undefined') globalThis.chrome = { runtime: { onMessage: { addListener() {} } } }
+
+describe('jsCoverageResources', () => {
+  test('lets nested unexecuted blocks carve unused bytes out of executed functions', async () => {
+    const { jsCoverageResources } = await import('../../extension/background/commands/observe-coverage.js')
+    const resources = jsCoverageResources([
+      {
+        scriptId: '1',
+        url: 'https://shop.test/app.js',
+        functions: [
+          { ranges: [{ startOffset: 0, endOffset: 100, count: 1 }] },
+          { ranges: [{ startOffset: 10, endOffset: 50, count: 1 }, { startOffset: 20, endOffset: 30, count: 0 }] },
+          { ranges: [{ startOffset: 60, endOffset: 90, count: 0 }] }
+        ]
+      },
+      { scriptId: '2', url: '', functions: [{ ranges: [{ startOffset: 0, endOffset: 10, count: 1 }] }] },
+      { scriptId: '3', url: 'chrome-extension://abc/content.js', functions: [{ ranges: [{ startOffset: 0, endOffset: 10, count: 1 }] }] }
+    ])
+    assert.deepStrictEqual(resources, [
+      {
+        url: 'https://shop.test/app.js',
+        type: 'js',
+        total_bytes: 100,
+        used_bytes: 60,
+        ranges: [
+          [0, 20],
+          [30, 60],
+          [90, 100]
+        ]
+      }
+    ])
+  })
+
+  test('merges inline scripts sharing the page URL into byte counts only', async () => {
+    const { jsCoverageResources } = await import('../../extension/background/commands/observe-coverage.js')
+    const inline = (id, end, count) => ({
+      scriptId: id,
+      url: 'https://shop.test/',
+      functions: [{ ranges: [{ startOffset: 0, endOffset: end, count }] }]
+    })
+    assert.deepStrictEqual(jsCoverageResources([inline('1', 40, 1), inline('2', 60, 0)]), [
+      { url: 'https://shop.test/', type: 'js', total_bytes: 100, used_bytes: 40 }
+    ])
+  })
+})
+
+describe('cssCoverageResources', () => {
+  test('marks matched rules used and skips sheets without a URL', async () => {
+    const { cssCoverageResources } = await import('../../extension/background/commands/observe-coverage.js')
+    const resources = cssCoverageResources(
+      [
+        { styleSheetId: 's1', startOffset: 0, endOffset: 30, used: true },
+        { styleSheetId: 's1', startOffset: 30, endOffset: 80, used: false },
+        { styleSheetId: 's2', startOffset: 0, endOffset: 10, used: true }
+      ],
+      [
+        { styleSheetId: 's1', sourceURL: 'https://shop.test/site.css', length: 100 },
+        { styleSheetId: 's2', sourceURL: '', length: 10 }
+      ]
+    )
+    assert.deepStrictEqual(resources, [
+      { url: 'https://shop.test/site.css', type: 'css', total_bytes: 100, used_bytes: 30, ranges: [[0, 30]] }
+    ])
+  })
+})
@@ -0,0 +1,140 @@
+// @ts-nocheck
+/**
+ * @fileoverview device-emulation.test.js — interact(emulate) and configure(override): CDP
+ * overrides, the held debugger attachment, and one-shot CDP actions sharing it.
+ */
+
+import { beforeEach, describe, mock, test } from 'node:test'
+import assert from 'node:assert'
+
+if (typeof globalThis.navigator === 'undefined') globalThis.navigator = { userAgent: 'worker-ua' }
+
+const {
+  applyDeviceEmulation,
+  clearDeviceEmulation,
+  applyRegionOverride,
+  attachDebugger,
+  detachDebugger,
+  resetDeviceEmulationForTesting
+} = await import('../../extension/background/device-emulation.js')
+
+const iPhone = {
+  device: 'iPhone 14',
+  width: 390,
+  height: 844,
+  device_scale_factor: 3,
+  user_agent: 'Mozilla/5.0 (iPhone; CPU iPhone OS 16_0 like Mac OS X)',
+  touch: true,
+  mobile: true
+}
+
+function commands() {
+  return globalThis.chrome.debugger.sendCommand.mock.calls.map((c) => [c.arguments[1], c.arguments[2]])
+}
+
+describe('device emulation', () => {
+  let detachListener
+  beforeEach(() => {
+    resetDeviceEmulationForTesting()
+    detachListener = null
+    globalThis.chrome = {
+      debugger: {
+        attach: mock.fn(() => Promise.resolve()),
+        detach: mock.fn(() => Promise.resolve()),
+        sendCommand: mock.fn(() => Promise.resolve({})),
+        onDetach: { addListener: mock.fn((fn) => (detachListener = fn)) }
+      }
+    }
+  })
+
+  test('applies metrics, touch, and user agent overrides', async () => {
+    const result = await applyDeviceEmulation(7, iPhone)
+    assert.strictEqual(globalThis.chrome.debugger.attach.mock.callCount(), 1)
+    assert.deepStrictEqual(commands(), [
+      ['Emulation.setDeviceMetricsOverride', { width: 390, height: 844, deviceScaleFactor: 3, mobile: true }],
+      ['Emulation.setTouchEmulationEnabled', { enabled: true, maxTouchPoints: 5 }],
+      ['Emulation.setUserAgentOverride', { userAgent: iPhone.user_agent }]
+    ])
+    assert.strictEqual(result.emulating, true)
+    assert.strictEqual(result.device, 'iPhone 14')
+  })
+
+  test('custom devices without a user agent keep the browser UA', async () => {
+    const result = await applyDeviceEmulation(7, { width: 800, height: 600, device_scale_factor: 1, touch: false, mobile: false })
+    assert.ok(!commands().some(([method]) => method === 'Emulation.setUserAgentOverride'))
+    assert.strictEqual(result.device, 'custom')
+  })
+
+  test('one-shot CDP actions reuse the held attachment', async () => {
+    await applyDeviceEmulation(7, iPhone)
+    await attachDebugger(7)
+    await detachDebugger(7)
+    assert.strictEqual(globalThis.chrome.debugger.attach.mock.callCount(), 1)
+    assert.strictEqual(globalThis.chrome.debugger.detach.mock.callCount(), 0)
+
+    await attachDebugger(8)
+    await detachDebugger(8)
+    assert.strictEqual(globalThis.chrome.debugger.attach.mock.callCount(), 2)
+    assert.strictEqual(globalThis.chrome.debugger.detach.mock.callCount(), 1)
+  })
+
+  test('switching devices re-attaches so earlier overrides are dropped', async () => {
+    await applyDeviceEmulation(7, iPhone)
+    await applyDeviceEmulation(7, { ...iPhone, device: 'Pixel 7', user_agent: undefined })
+    assert.strictEqual(globalThis.chrome.debugger.detach.mock.callCount(), 1)
+    assert.strictEqual(globalThis.chrome.debugger.attach.mock.callCount(), 2)
+  })
+
+  test('clear releases the debugger and reports whether emulation was active', async () => {
+    await applyDeviceEmulation(7, iPhone)
+    assert.deepStrictEqual(await clearDeviceEmulation(7), { success: true, emulating: false, was_emulating: true })
+    assert.strictEqual(globalThis.chrome.debugger.detach.mock.callCount(), 1)
+    assert.deepStrictEqual(await clearDeviceEmulation(7), { success: true, emulating: false, was_emulating: false })
+    assert.strictEqual(globalThis.chrome.debugger.detach.mock.callCount(), 1)
+  })
+
+  test('an external detach ends emulation', async () => {
+    await applyDeviceEmulation(7, iPhone)
+    detachListener({ tabId: 7 })
+    await attachDebugger(7)
+    assert.strictEqual(globalThis.chrome.debugger.attach.mock.callCount(), 2)
+  })
+
+  test('a failed override releases the attachment', async () => {
+    globalThis.chrome.debugger.sendCommand = mock.fn(() => Promise.reject(new Error('Not allowed')))
+    await assert.rejects(applyDeviceEmulation(7, iPhone), /Not allowed/)
+    assert.strictEqual(globalThis.chrome.debugger.detach.mock.callCount(), 1)
+    await attachDebugger(7)
+    assert.strictEqual(globalThis.chrome.debugger.attach.mock.callCount(), 2)
+  })
+
+  test('region overrides set geolocation, timezone, locale, and Accept-Language', async () => {
+    await applyRegionOverride(7, {
+      geolocation: { latitude: 52.52, longitude: 13.405 },
+      timezone: 'Europe/Berlin',
+      locale: 'de-DE'
+    })
+    assert.deepStrictEqual(commands(), [
+      ['Emulation.setGeolocationOverride', { latitude: 52.52, longitude: 13.405, accuracy: 100 }],
+      ['Emulation.setTimezoneOverride', { timezoneId: 'Europe/Berlin' }],
+      ['Emulation.setLocaleOverride', { locale: 'de-DE' }],
+      ['Emulation.setUserAgentOverride', { userAgent: globalThis.navigator.userAgent, acceptLanguage: 'de-DE' }]
+    ])
+  })
+
+  test('device emulation and region overrides share one attachment', async () => {
+    await applyRegionOverride(7, { locale: 'de-DE' })
+    await applyDeviceEmulation(7, iPhone)
+    const ua = commands().filter(([method]) => method === 'Emulation.setUserAgentOverride').pop()
+    assert.deepStrictEqual(ua[1], { userAgent: iPhone.user_agent, acceptLanguage: 'de-DE' })
+
+    const cleared = await clearDeviceEmulation(7)
+    assert.strictEqual(cleared.was_emulating, true)
+    await attachDebugger(7)
+    assert.strictEqual(globalThis.chrome.debugger.attach.mock.callCount(), 3, 'region overrides keep the tab held')
+
+    await applyRegionOverride(7, null)
+    await attachDebugger(7)
+    assert.strictEqual(globalThis.chrome.debugger.attach.mock.callCount(), 4)
+  })
+})
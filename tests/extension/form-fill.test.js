@@ -0,0 +1,221 @@
+// @ts-nocheck
+/**
+ * @fileoverview form-fill.test.js — interact(fill_form, values) single round-trip fill:
+ * command wiring and the self-contained page function that resolves and sets fields.
+ */
+
+import { beforeEach, describe, mock, test } from 'node:test'
+import assert from 'node:assert'
+import { MANIFEST_VERSION } from './helpers.js'
+
+if (typeof globalThis.navigator === 'undefined') globalThis.navigator = { platform: 'Linux' }
+
+function createMockChrome(trackedTabId = 1830196419) {
+  return {
+    runtime: {
+      onMessage: { addListener: mock.fn() },
+      onInstalled: { addListener: mock.fn() },
+      sendMessage: mock.fn(() => Promise.resolve()),
+      getManifest: () => ({ version: MANIFEST_VERSION })
+    },
+    action: {
+      setBadgeText: mock.fn(),
+      setBadgeBackgroundColor: mock.fn()
+    },
+    tabs: {
+      query: mock.fn((query, callback) => {
+        const result = query?.active
+          ? [{ id: trackedTabId, windowId: 1, url: `https://tracked/${trackedTabId}` }]
+          : [{ id: trackedTabId, windowId: 1, url: `https://tracked/${trackedTabId}` }]
+        if (callback) callback(result)
+        return Promise.resolve(result)
+      }),
+      sendMessage: mock.fn(() => Promise.resolve({ success: true })),
+      get: mock.fn((tabId) => Promise.resolve({ id: tabId, windowId: 1, url: `https://tracked/${tabId}`, status: 'complete' })),
+      onRemoved: { addListener: mock.fn() }
+    },
+    scripting: {
+      executeScript: mock.fn(() =>
+        Promise.resolve([
+          {
+            result: {
+              success: true,
+              form: 'form#checkout',
+              filled: 1,
+              not_found: [],
+              errors: 0,
+              fields: [{ key: 'email', status: 'filled', selector: '#email', matched_by: 'name', valid: true }]
+            }
+          }
+        ])
+      )
+    },
+    storage: {
+      local: {
+        get: mock.fn((keys, callback) => {
+          const data = {
+            serverUrl: 'http://localhost:7890',
+            aiWebPilotEnabled: true,
+            trackedTabId,
+            trackedTabUrl: `https://tracked/${trackedTabId}`,
+            trackedTabTitle: 'Tracked Tab'
+          }
+          if (callback) callback(data)
+          return Promise.resolve(data)
+        }),
+        set: mock.fn((data, callback) => {
+          if (callback) callback()
+          return Promise.resolve()
+        }),
+        remove: mock.fn((keys, callback) => {
+          if (callback) callback()
+          return Promise.resolve()
+        })
+      },
+      sync: {
+        get: mock.fn((keys, callback) => {
+          if (callback) callback({})
+          return Promise.resolve({})
+        }),
+        set: mock.fn((data, callback) => {
+          if (callback) callback()
+          return Promise.resolve()
+        })
+      },
+      session: {
+        get: mock.fn((keys, callback) => {
+          if (callback) callback({})
+          return Promise.resolve({})
+        }),
+        set: mock.fn((data, callback) => {
+          if (callback) callback()
+          return Promise.resolve()
+        })
+      },
+      onChanged: { addListener: mock.fn() }
+    },
+    alarms: {
+      create: mock.fn(),
+      onAlarm: { addListener: mock.fn() }
+    }
+  }
+}
+
+function fakeField(tag, { type = '', name = '', id = '', label = '', value = '', options = null, valid = true } = {}) {
+  const el = {
+    tagName: tag.toUpperCase(),
+    type: type || (tag === 'select' ? 'select-one' : tag),
+    id,
+    value,
+    checked: false,
+    events: [],
+    labels: label ? [{ textContent: label }] : [],
+    validity: { valid },
+    validationMessage: valid ? '' : 'Please fill out this field.',
+    getAttribute: (attr) => ({ name, type, id })[attr] || null,
+    dispatchEvent: (ev) => el.events.push(ev.type),
+    focus: () => {}
+  }
+  if (options) el.options = options.map(([v, text]) => ({ value: v, text, selected: false }))
+  return el
+}
+
+describe('form_fill command', () => {
+  const trackedTabId = 4343
+
+  beforeEach(async () => {
+    mock.reset()
+    globalThis.chrome = createMockChrome(trackedTabId)
+    globalThis.fetch = mock.fn(() =>
+      Promise.resolve({
+        ok: true,
+        json: () => Promise.resolve({ queries: [] })
+      })
+    )
+    const bgModule = await import('../../extension/background.js')
+    bgModule.markInitComplete()
+  })
+
+  test('runs the filler in the tracked tab with the form selector and values', async () => {
+    const bgModule = await import('../../extension/background.js')
+    const mockSyncClient = { queueCommandResult: mock.fn() }
+
+    await bgModule.handlePendingQuery(
+      {
+        id: 'q-fill',
+        type: 'form_fill',
+        correlation_id: 'corr-fill',
+        params: JSON.stringify({ selector: 'form#checkout', values: { email: 'a@b.co' } })
+      },
+      mockSyncClient
+    )
+
+    const queued = mockSyncClient.queueCommandResult.mock.calls[0].arguments[0]
+    const call = globalThis.chrome.scripting.executeScript.mock.calls[0].arguments[0]
+    assert.strictEqual(call.target.tabId, trackedTabId)
+    assert.strictEqual(call.world, 'ISOLATED')
+    assert.deepStrictEqual(call.args, ['form#checkout', { email: 'a@b.co' }])
+    assert.strictEqual(queued.status, 'complete')
+    assert.strictEqual(queued.result.filled, 1)
+  })
+})
+
+describe('formFillProbe', () => {
+  test('resolves keys by name, id, and label and sets each field type', async () => {
+    const { formFillProbe } = await import('../../extension/background/commands/interact-form-fill.js')
+    const email = fakeField('input', { type: 'email', name: 'email' })
+    const zip = fakeField('input', { type: 'text', id: 'zip', valid: false })
+    const country = fakeField('select', { name: 'country', label: 'Country', options: [['us', 'United States'], ['de', 'Germany']] })
+    const terms = fakeField('input', { type: 'checkbox', name: 'terms' })
+    const shipFast = fakeField('input', { type: 'radio', name: 'ship', value: 'fast' })
+    const shipSlow = fakeField('input', { type: 'radio', name: 'ship', value: 'slow' })
+    const note = fakeField('textarea', { label: 'Delivery note *' })
+    const hidden = fakeField('input', { type: 'hidden', name: 'csrf' })
+    const form = { querySelectorAll: () => [email, zip, country, terms, shipFast, shipSlow, note, hidden] }
+    globalThis.Event = class { constructor(type) { this.type = type } }
+    globalThis.document = { querySelector: (sel) => (sel === 'form#checkout' ? form : null) }
+
+    const result = formFillProbe('form#checkout', {
+      email: 'a@b.co',
+      zip: '10115',
+      Country: 'Germany',
+      terms: true,
+      ship: 'slow',
+      'delivery note': 'Leave at door',
+      csrf: 'x',
+      missing: 'y'
+    })
+
+    assert.strictEqual(email.value, 'a@b.co')
+    assert.deepStrictEqual(email.events, ['input', 'change'])
+    assert.strictEqual(zip.value, '10115')
+    assert.strictEqual(country.value, 'de')
+    assert.strictEqual(terms.checked, true)
+    assert.strictEqual(shipSlow.checked, true)
+    assert.strictEqual(shipFast.checked, false)
+    assert.strictEqual(note.value, 'Leave at door')
+
+    const byKey = Object.fromEntries(result.fields.map((f) => [f.key, f]))
+    assert.strictEqual(byKey.Country.matched_by, 'label')
+    assert.strictEqual(byKey.zip.matched_by, 'id')
+    assert.strictEqual(byKey.zip.valid, false)
+    assert.strictEqual(byKey.zip.validation_message, 'Please fill out this field.')
+    assert.deepStrictEqual(result.not_found, ['csrf', 'missing'])
+    assert.strictEqual(result.filled, 6)
+    assert.strictEqual(result.success, false)
+    assert.strictEqual(result.error, 'form_fill_incomplete')
+    assert.strictEqual(JSON.stringify(result).includes('a@b.co'), false)
+  })
+
+  test('reports a missing form and unmatched options', async () => {
+    const { formFillProbe } = await import('../../extension/background/commands/interact-form-fill.js')
+    globalThis.document = { querySelector: () => null }
+    assert.strictEqual(formFillProbe('form#nope', { a: 'b' }).error, 'form_not_found')
+
+    const size = fakeField('select', { name: 'size', options: [['s', 'Small']] })
+    globalThis.document = { querySelectorAll: () => [size] }
+    const result = formFillProbe('', { size: 'XL' })
+    assert.strictEqual(result.errors, 1)
+    assert.match(result.fields[0].message, /No option matches "XL"/)
+  })
+})
@@ -0,0 +1,61 @@
+// @ts-nocheck
+/**
+ * @fileoverview form-state.test.js — discoverForms state mode: live validity,
+ * checked state, and in-page masking of password and payment values.
+ */
+
+import { describe, test } from 'node:test'
+import assert from 'node:assert'
+
+function fakeInput({ type = 'text', name = '', value = '', autocomplete = '', checked = false, validity = {}, message = '' }) {
+  const attrs = { type, name, autocomplete }
+  return {
+    tagName: 'INPUT',
+    id: '',
+    name,
+    value,
+    checked,
+    required: false,
+    classList: [],
+    validity: { valid: Object.keys(validity).length === 0, ...validity },
+    validationMessage: message,
+    getAttribute: (attr) => attrs[attr] || null,
+    closest: () => null,
+    checkValidity: () => {
+      throw new Error('state mode must not call checkValidity')
+    }
+  }
+}
+
+describe('discoverForms state mode', () => {
+  test('reports validity and masks secrets without firing invalid events', async () => {
+    const { discoverForms } = await import('../../extension/inject/form-discovery.js')
+    const email = fakeInput({ type: 'email', name: 'email', value: 'nope', validity: { typeMismatch: true }, message: 'Enter an email.' })
+    const password = fakeInput({ type: 'password', name: 'pw', value: 'hunter2' })
+    const card = fakeInput({ name: 'cc', value: '4111111111111111', autocomplete: 'cc-number' })
+    const terms = fakeInput({ type: 'checkbox', name: 'terms', value: 'on', checked: true })
+    const form = {
+      tagName: 'FORM',
+      id: 'checkout',
+      action: '/pay',
+      method: 'post',
+      name: '',
+      getAttribute: () => null,
+      querySelector: () => null,
+      querySelectorAll: () => [email, password, card, terms]
+    }
+    globalThis.document = { querySelectorAll: () => [form] }
+
+    const [state] = discoverForms({ selector: 'form#checkout', mode: 'state' })
+    const [e, pw, cc, t] = state.fields
+    assert.strictEqual(state.valid, false)
+    assert.strictEqual(e.valid, false)
+    assert.deepStrictEqual(e.validity, ['type_mismatch'])
+    assert.strictEqual(e.validation_message, 'Enter an email.')
+    assert.strictEqual(pw.value, '[REDACTED]')
+    assert.strictEqual(pw.redacted, true)
+    assert.strictEqual(cc.value, '[REDACTED]')
+    assert.strictEqual(t.checked, true)
+    assert.strictEqual(t.valid, true)
+  })
+})
@@ -0,0 +1,91 @@
+// @ts-nocheck
+/**
+ * @fileoverview i18n-scan.test.js — analyze(i18n) probe: the self-contained page function
+ * that collects visible text, translatable attributes, lang/dir context, and marker coverage.
+ */
+
+import { describe, test } from 'node:test'
+import assert from 'node:assert'
+
+if (typeof globalThis.navigator === 'undefined') globalThis.navigator = { platform: 'Linux' }
+if (typeof globalThis.chrome === 'undefined') globalThis.chrome = { runtime: { onMessage: { addListener() {} } } }
+
+// matches supports the selector shapes the probe uses: tag, .class, [attr], and [attr="value"].
+function matches(el, selector) {
+  return selector.split(',').some((part) => {
+    part = part.trim()
+    if (part.startsWith('.')) return el._classes.includes(part.slice(1))
+    const attr = part.match(/^\[([^=\]]+)(?:="([^"]*)")?\]$/)
+    if (attr) {
+      const value = el.getAttribute(attr[1])
+      return attr[2] === undefined ? value !== null : value === attr[2]
+    }
+    return el.tagName.toLowerCase() === part
+  })
+}
+
+function fakeElement(tag, { id = '', classes = [], attrs = {}, text = '', styles = {}, hidden = false, parent = null } = {}) {
+  const el = {
+    id,
+    tagName: tag.toUpperCase(),
+    classList: classes,
+    _classes: classes,
+    _styles: { direction: 'ltr', textAlign: 'start', ...styles },
+    parentElement: parent,
+    childNodes: text ? [{ nodeType: 3, textContent: text }] : [],
+    getAttribute: (name) => (name in attrs ? attrs[name] : null),
+    getClientRects: () => (hidden ? [] : [{}]),
+    closest(selector) {
+      for (let e = el; e; e = e.parentElement) if (matches(e, selector)) return e
+      return null
+    }
+  }
+  return el
+}
+
+describe('i18nScanProbe', () => {
+  test('collects text, attributes, lang/dir context, and marker coverage', async () => {
+    const { i18nScanProbe } = await import('../../extension/background/commands/analyze-i18n.js')
+    const html = fakeElement('html', { attrs: { lang: 'ar' } })
+    const body = fakeElement('body', { parent: html })
+    const title = fakeElement('h1', { attrs: { 'data-i18n': 'home.title' }, text: 'مرحبا', parent: body })
+    const input = fakeElement('input', { attrs: { placeholder: 'Search' }, parent: body })
+    const brand = fakeElement('span', { classes: ['notranslate'], text: '  Kaboom\n  Labs ', parent: body })
+    const quote = fakeElement('p', { attrs: { lang: 'en', dir: 'ltr' }, text: 'Hello', styles: { textAlign: 'left' }, parent: body })
+    const hidden = fakeElement('div', { text: 'secret', hidden: true, parent: body })
+    const code = fakeElement('code', { text: 'const x = 1', parent: body })
+    const all = [title, input, brand, quote, hidden, code]
+
+    globalThis.location = { href: 'https://shop.test/ar/' }
+    globalThis.Node = { TEXT_NODE: 3 }
+    globalThis.window = { getComputedStyle: (el) => ({ direction: el._styles.direction, textAlign: el._styles.textAlign }) }
+    globalThis.document = {
+      title: 'Shop',
+      documentElement: html,
+      body: { ...body, querySelectorAll: () => all },
+      querySelectorAll: (sel) => all.filter((el) => matches(el, sel))
+    }
+
+    const result = i18nScanProbe(['data-i18n', 'bad selector]'])
+    assert.strictEqual(result.html_lang, 'ar')
+    assert.strictEqual(result.html_dir, '')
+    assert.strictEqual(result.marker_count, 1)
+    assert.strictEqual(result.total_nodes, 4)
+    assert.deepStrictEqual(
+      result.text_nodes.map((n) => [n.selector, n.text, n.attr ?? '']),
+      [
+        ['h1', 'مرحبا', ''],
+        ['input', 'Search', 'placeholder'],
+        ['span.notranslate', 'Kaboom Labs', ''],
+        ['p', 'Hello', '']
+      ]
+    )
+    assert.strictEqual(result.text_nodes[0].marked, true)
+    assert.strictEqual(result.text_nodes[0].lang, 'ar')
+    assert.strictEqual(result.text_nodes[1].marked, false)
+    assert.strictEqual(result.text_nodes[2].no_translate, true)
+    assert.strictEqual(result.text_nodes[3].lang, 'en')
+    assert.strictEqual(result.text_nodes[3].dir_attr, 'ltr')
+    assert.strictEqual(result.text_nodes[3].text_align, 'left')
+  })
+})
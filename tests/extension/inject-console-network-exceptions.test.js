@@ -52,6 +52,19 @@ describe('Console Capture', () => {
     uninstallConsoleCapture()
   })
 
+  test('should keep object args structured and preview them as JSON in message', async () => {
+    const { installConsoleCapture, uninstallConsoleCapture } = await import('../../extension/inject.js')
+    installConsoleCapture()
+
+    globalThis.console.info({ requestId: 'req-42', items: [1, 2] }, 'checkout')
+
+    const [message] = globalThis.window.postMessage.mock.calls[0].arguments
+    assert.deepStrictEqual(message.payload.args, [{ requestId: 'req-42', items: [1, 2] }, 'checkout'])
+    assert.strictEqual(message.payload.message, '{"requestId":"req-42","items":[1,2]}')
+
+    uninstallConsoleCapture()
+  })
+
   test('should intercept console.error', async () => {
     const { installConsoleCapture, uninstallConsoleCapture } = await import('../../extension/inject.js')
 
@@ -32,6 +32,9 @@ describe('VALID_SETTINGS', () => {
       SettingName.PERFORMANCE_SNAPSHOT,
       SettingName.DEFERRAL,
       SettingName.NETWORK_BODY_CAPTURE,
+      SettingName.NETWORK_BODY_CAPTURE_MAX,
+      SettingName.NETWORK_BINARY_CAPTURE_MAX,
+      SettingName.REQUEST_MOCKS,
       SettingName.SERVER_URL,
     ]
 
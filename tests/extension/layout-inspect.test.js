@@ -0,0 +1,205 @@
+// @ts-nocheck
+/**
+ * @fileoverview layout-inspect.test.js — analyze(styles) layout probe: command wiring
+ * and the self-contained page function that collects element and ancestor styles.
+ */
+
+import { beforeEach, describe, mock, test } from 'node:test'
+import assert from 'node:assert'
+import { MANIFEST_VERSION } from './helpers.js'
+
+if (typeof globalThis.navigator === 'undefined') globalThis.navigator = { platform: 'Linux' }
+
+function createMockChrome(trackedTabId = 1830196419) {
+  return {
+    runtime: {
+      onMessage: { addListener: mock.fn() },
+      onInstalled: { addListener: mock.fn() },
+      sendMessage: mock.fn(() => Promise.resolve()),
+      getManifest: () => ({ version: MANIFEST_VERSION })
+    },
+    action: {
+      setBadgeText: mock.fn(),
+      setBadgeBackgroundColor: mock.fn()
+    },
+    tabs: {
+      query: mock.fn((query, callback) => {
+        const result = query?.active
+          ? [{ id: trackedTabId, windowId: 1, url: `https://tracked/${trackedTabId}` }]
+          : [{ id: trackedTabId, windowId: 1, url: `https://tracked/${trackedTabId}` }]
+        if (callback) callback(result)
+        return Promise.resolve(result)
+      }),
+      sendMessage: mock.fn(() => Promise.resolve({ success: true })),
+      get: mock.fn((tabId) => Promise.resolve({ id: tabId, windowId: 1, url: `https://tracked/${tabId}`, status: 'complete' })),
+      onRemoved: { addListener: mock.fn() }
+    },
+    scripting: {
+      executeScript: mock.fn(() =>
+        Promise.resolve([
+          {
+            result: {
+              match_count: 1,
+              viewport: { width: 800, height: 600, scroll_x: 0, scroll_y: 0 },
+              element: { tag: 'button', selector: '#save', styles: {}, rect: { x: 0, y: 0, width: 10, height: 10 } },
+              ancestors: []
+            }
+          }
+        ])
+      )
+    },
+    storage: {
+      local: {
+        get: mock.fn((keys, callback) => {
+          const data = {
+            serverUrl: 'http://localhost:7890',
+            aiWebPilotEnabled: true,
+            trackedTabId,
+            trackedTabUrl: `https://tracked/${trackedTabId}`,
+            trackedTabTitle: 'Tracked Tab'
+          }
+          if (callback) callback(data)
+          return Promise.resolve(data)
+        }),
+        set: mock.fn((data, callback) => {
+          if (callback) callback()
+          return Promise.resolve()
+        }),
+        remove: mock.fn((keys, callback) => {
+          if (callback) callback()
+          return Promise.resolve()
+        })
+      },
+      sync: {
+        get: mock.fn((keys, callback) => {
+          if (callback) callback({})
+          return Promise.resolve({})
+        }),
+        set: mock.fn((data, callback) => {
+          if (callback) callback()
+          return Promise.resolve()
+        })
+      },
+      session: {
+        get: mock.fn((keys, callback) => {
+          if (callback) callback({})
+          return Promise.resolve({})
+        }),
+        set: mock.fn((data, callback) => {
+          if (callback) callback()
+          return Promise.resolve()
+        })
+      },
+      onChanged: { addListener: mock.fn() }
+    },
+    alarms: {
+      create: mock.fn(),
+      onAlarm: { addListener: mock.fn() }
+    }
+  }
+}
+
+function fakeElement(tag, { id = '', classes = [], styles = {}, rect = {}, parent = null } = {}) {
+  return {
+    tagName: tag.toUpperCase(),
+    id,
+    classList: classes,
+    parentElement: parent,
+    _styles: styles,
+    getBoundingClientRect: () => ({ x: 0, y: 0, width: 0, height: 0, ...rect })
+  }
+}
+
+describe('layout_inspect command', () => {
+  const trackedTabId = 4242
+
+  beforeEach(async () => {
+    mock.reset()
+    globalThis.chrome = createMockChrome(trackedTabId)
+    globalThis.fetch = mock.fn(() =>
+      Promise.resolve({
+        ok: true,
+        json: () => Promise.resolve({ queries: [] })
+      })
+    )
+    const bgModule = await import('../../extension/background.js')
+    bgModule.markInitComplete()
+  })
+
+  test('runs the probe in the tracked tab with selector and properties', async () => {
+    const bgModule = await import('../../extension/background.js')
+    const mockSyncClient = { queueCommandResult: mock.fn() }
+
+    await bgModule.handlePendingQuery(
+      {
+        id: 'q-styles',
+        type: 'layout_inspect',
+        correlation_id: 'corr-styles',
+        params: JSON.stringify({ selector: '#save', properties: ['color'] })
+      },
+      mockSyncClient
+    )
+
+    const call = globalThis.chrome.scripting.executeScript.mock.calls[0].arguments[0]
+    assert.strictEqual(call.target.tabId, trackedTabId)
+    assert.strictEqual(call.world, 'ISOLATED')
+    assert.deepStrictEqual(call.args, ['#save', ['color']])
+
+    const queued = mockSyncClient.queueCommandResult.mock.calls[0].arguments[0]
+    assert.strictEqual(queued.status, 'complete')
+    assert.strictEqual(queued.result.element.selector, '#save')
+  })
+})
+
+describe('layoutInspectProbe', () => {
+  test('collects the first match, requested properties, and the ancestor chain', async () => {
+    const { layoutInspectProbe } = await import('../../extension/background/commands/analyze-styles.js')
+    const html = fakeElement('html', { styles: { display: 'block' } })
+    const panel = fakeElement('div', { classes: ['panel', 'open'], styles: { 'overflow-y': 'hidden' }, parent: html })
+    const button = fakeElement('button', {
+      id: 'save',
+      styles: { display: 'none', 'text-transform': 'uppercase' },
+      rect: { x: 5, y: 6, width: 70, height: 20 },
+      parent: panel
+    })
+
+    globalThis.window = {
+      innerWidth: 1024,
+      innerHeight: 768,
+      scrollX: 0,
+      scrollY: 40,
+      getComputedStyle: (el) => ({ getPropertyValue: (prop) => el._styles[prop] ?? '' })
+    }
+    globalThis.document = { querySelectorAll: () => [button, panel] }
+
+    const result = layoutInspectProbe('button', ['text-transform'])
+    assert.strictEqual(result.match_count, 2)
+    assert.deepStrictEqual(result.viewport, { width: 1024, height: 768, scroll_x: 0, scroll_y: 40 })
+    assert.strictEqual(result.element.selector, '#save')
+    assert.strictEqual(result.element.styles.display, 'none')
+    assert.strictEqual(result.element.styles['text-transform'], 'uppercase')
+    assert.deepStrictEqual(result.element.rect, { x: 5, y: 6, width: 70, height: 20 })
+    assert.deepStrictEqual(
+      result.ancestors.map((a) => a.selector),
+      ['div.panel.open', 'html']
+    )
+    assert.strictEqual(result.ancestors[0].styles['overflow-y'], 'hidden')
+    assert.strictEqual('text-transform' in result.ancestors[0].styles, false)
+  })
+
+  test('reports invalid selectors and missing matches without throwing', async () => {
+    const { layoutInspectProbe } = await import('../../extension/background/commands/analyze-styles.js')
+    globalThis.window = { innerWidth: 1, innerHeight: 1, scrollX: 0, scrollY: 0, getComputedStyle: () => ({}) }
+    globalThis.document = {
+      querySelectorAll: (sel) => {
+        if (sel === '[') throw new Error("'[' is not a valid selector")
+        return []
+      }
+    }
+
+    assert.strictEqual(layoutInspectProbe('[', []).error, 'invalid_selector')
+    const empty = layoutInspectProbe('.ghost', [])
+    assert.strictEqual(empty.match_count, 0)
+    assert.strictEqual(empty.element, null)
+  })
+})
@@ -0,0 +1,98 @@
+// @ts-nocheck
+/**
+ * @fileoverview memory-sample.test.js — Tests for memory samples attached to performance snapshots.
+ * Verifies heap fields from performance.memory, DOM node counts, and listener counts
+ * on connected vs detached nodes via the EventTarget hook.
+ */
+
+import { test, describe, beforeEach, afterEach } from 'node:test'
+import assert from 'node:assert'
+
+// Minimal DOM node: Node.js has EventTarget but no Node.
+class FakeNode extends EventTarget {
+  constructor() {
+    super()
+    this.isConnected = true
+  }
+}
+
+let originalNode, originalDocument, originalPerformance
+
+describe('Memory sample capture', () => {
+  beforeEach(() => {
+    originalNode = globalThis.Node
+    originalDocument = globalThis.document
+    originalPerformance = globalThis.performance
+    globalThis.Node = FakeNode
+    globalThis.document = { getElementsByTagName: (tag) => (tag === '*' ? { length: 1234 } : { length: 0 }) }
+    Object.defineProperty(globalThis, 'performance', {
+      configurable: true,
+      writable: true,
+      value: {
+        timeOrigin: 1714550400000.5,
+        memory: { usedJSHeapSize: 20e6, totalJSHeapSize: 30e6, jsHeapSizeLimit: 4e9 }
+      }
+    })
+  })
+
+  afterEach(async () => {
+    const { uninstallListenerTracking } = await import('../../extension/lib/memory-sample.js')
+    uninstallListenerTracking()
+    globalThis.Node = originalNode
+    globalThis.document = originalDocument
+    Object.defineProperty(globalThis, 'performance', { configurable: true, writable: true, value: originalPerformance })
+  })
+
+  test('captureMemorySample reads heap sizes and DOM node count', async () => {
+    const { captureMemorySample } = await import('../../extension/lib/memory-sample.js')
+    const sample = captureMemorySample()
+
+    assert.strictEqual(sample.time_origin, 1714550400000.5)
+    assert.strictEqual(sample.used_js_heap_size, 20e6)
+    assert.strictEqual(sample.total_js_heap_size, 30e6)
+    assert.strictEqual(sample.js_heap_size_limit, 4e9)
+    assert.strictEqual(sample.dom_nodes, 1234)
+    assert.ok(!('ua_specific_bytes' in sample), 'no UA-specific measurement outside cross-origin isolation')
+  })
+
+  test('captureMemorySample omits heap fields without performance.memory', async () => {
+    const { captureMemorySample } = await import('../../extension/lib/memory-sample.js')
+    delete globalThis.performance.memory
+    const sample = captureMemorySample()
+
+    assert.ok(!('used_js_heap_size' in sample))
+    assert.strictEqual(sample.dom_nodes, 1234)
+  })
+
+  test('listener tracking counts listeners left on detached nodes', async () => {
+    const { installListenerTracking, countListeners } = await import('../../extension/lib/memory-sample.js')
+    installListenerTracking()
+
+    const kept = new FakeNode()
+    const removed = new FakeNode()
+    const handler = () => {}
+    kept.addEventListener('click', handler)
+    removed.addEventListener('click', handler)
+    removed.addEventListener('scroll', () => {})
+    kept.addEventListener('input', handler)
+    kept.removeEventListener('input', handler)
+    new EventTarget().addEventListener('message', handler) // not a DOM node: ignored
+
+    removed.isConnected = false
+    assert.deepStrictEqual(countListeners(), { event_listeners: 3, detached_listeners: 2 })
+  })
+
+  test('uninstallListenerTracking restores EventTarget methods', async () => {
+    const { installListenerTracking, uninstallListenerTracking, countListeners } = await import(
+      '../../extension/lib/memory-sample.js'
+    )
+    const original = EventTarget.prototype.addEventListener
+    installListenerTracking()
+    assert.notStrictEqual(EventTarget.prototype.addEventListener, original)
+    uninstallListenerTracking()
+    assert.strictEqual(EventTarget.prototype.addEventListener, original)
+
+    new FakeNode().addEventListener('click', () => {})
+    assert.deepStrictEqual(countListeners(), { event_listeners: 0, detached_listeners: 0 })
+  })
+})
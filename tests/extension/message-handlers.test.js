@@ -27,6 +27,7 @@ function getInstalledHandler(depsOverrides = {}) {
   const defaultDeps = {
     debugLog: mock.fn(),
     addToWsBatcher: mock.fn(),
+    addToSseBatcher: mock.fn(),
     addToEnhancedActionBatcher: mock.fn(),
     addToNetworkBodyBatcher: mock.fn(),
     addToPerfBatcher: mock.fn(),
@@ -124,7 +125,9 @@ describe('message routing', () => {
     const payload = { event: 'message', data: 'hello' }
     handler({ type: 'ws_event', payload }, contentScriptSender, mock.fn())
     assert.strictEqual(deps.addToWsBatcher.mock.calls.length, 1)
-    assert.deepStrictEqual(deps.addToWsBatcher.mock.calls[0].arguments[0], payload)
+    const routed = deps.addToWsBatcher.mock.calls[0].arguments[0]
+    assert.strictEqual(routed.data, 'hello')
+    assert.strictEqual(routed.tab_id, 1, 'ws_event should carry the sender tab')
   })
 
   test('enhanced_action routes to action batcher', () => {
@@ -134,6 +137,20 @@ describe('message routing', () => {
     assert.strictEqual(deps.addToEnhancedActionBatcher.mock.calls.length, 1)
   })
 
+  test('captured entries carry the sender tab and frame', () => {
+    const { handler, deps } = getInstalledHandler()
+    const frameSender = { tab: { id: 7, url: 'http://localhost:3000' }, frameId: 3 }
+    handler({ type: 'sse_event', payload: { event: 'message', id: 'sse-1' } }, frameSender, mock.fn())
+    handler({ type: 'enhanced_action', payload: { type: 'click', timestamp: 1000 } }, frameSender, mock.fn())
+    handler({ type: 'network_body', payload: { url: 'https://api.example.com', status: 200 } }, frameSender, mock.fn())
+
+    for (const batcher of [deps.addToSseBatcher, deps.addToEnhancedActionBatcher, deps.addToNetworkBodyBatcher]) {
+      const entry = batcher.mock.calls[0].arguments[0]
+      assert.strictEqual(entry.tab_id, 7)
+      assert.strictEqual(entry.frame_id, 3)
+    }
+  })
+
   test('network_body routes to body batcher with tab_id', () => {
     const { handler, deps } = getInstalledHandler()
     handler({
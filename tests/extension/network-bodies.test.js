@@ -327,6 +327,44 @@ describe('Network Body Capture - Fetch Wrapper', () => {
 
     assert.ok(bodyEvent.arguments[0].payload.duration >= 15, 'Expected duration >= 15ms')
   })
+
+  test('captures the head of binary responses base64-encoded when binary capture is on', async () => {
+    const { wrapFetchWithBodies } = await import('../../extension/inject.js')
+    const { setNetworkBinaryCaptureMax } = await import('../../extension/lib/network.js')
+    const bytes = Uint8Array.from([0x89, 0x50, 0x4e, 0x47, 0x0d, 0x0a, 0x1a, 0x0a, 1, 2, 3, 4])
+    const mockResponse = {
+      ...createMockResponse({ contentType: 'image/png' }),
+      clone: () => ({ arrayBuffer: () => Promise.resolve(bytes.buffer) })
+    }
+
+    setNetworkBinaryCaptureMax(8)
+    try {
+      await wrapFetchWithBodies(mock.fn(() => Promise.resolve(mockResponse)))('/api/logo.png')
+      await new Promise((r) => setTimeout(r, 10))
+    } finally {
+      setNetworkBinaryCaptureMax(0)
+    }
+
+    const bodyEvent = globalThis.window.postMessage.mock.calls.find((c) => c.arguments[0].type === 'kaboom_network_body')
+    const payload = bodyEvent.arguments[0].payload
+    assert.strictEqual(payload.response_body, btoa(String.fromCharCode(...bytes.subarray(0, 8))))
+    assert.strictEqual(payload.response_encoding, 'base64')
+    assert.strictEqual(payload.response_size, 12)
+    assert.strictEqual(payload.response_truncated, true)
+  })
+
+  test('keeps the size placeholder for binary responses when binary capture is off', async () => {
+    const { wrapFetchWithBodies } = await import('../../extension/inject.js')
+    const mockResponse = createMockResponse({ body: 'xxxx', contentType: 'image/png' })
+
+    await wrapFetchWithBodies(mock.fn(() => Promise.resolve(mockResponse)))('/api/logo.png')
+    await new Promise((r) => setTimeout(r, 10))
+
+    const bodyEvent = globalThis.window.postMessage.mock.calls.find((c) => c.arguments[0].type === 'kaboom_network_body')
+    const payload = bodyEvent.arguments[0].payload
+    assert.ok(payload.response_body.startsWith('[Binary: 4 bytes'), payload.response_body)
+    assert.strictEqual(payload.response_encoding, undefined)
+  })
 })
 
 describe('Header Sanitization', () => {
@@ -447,42 +485,57 @@ describe('Header Sanitization', () => {
 })
 
 describe('Body Truncation', () => {
-  test('should truncate request body at 8KB', async () => {
+  afterEach(async () => {
+    const { setNetworkBodyCaptureMax } = await import('../../extension/lib/network.js')
+    setNetworkBodyCaptureMax(65536)
+  })
+
+  test('should truncate request body at the 64KB default ceiling', async () => {
     const { truncateRequestBody } = await import('../../extension/inject.js')
 
-    const largeBody = 'x'.repeat(10000)
+    const largeBody = 'x'.repeat(70000)
     const result = truncateRequestBody(largeBody)
 
-    assert.ok(result.body.length <= 8192)
+    assert.strictEqual(result.body.length, 65536)
     assert.strictEqual(result.truncated, true)
     assert.strictEqual(typeof result.body, 'string')
     // Verify the result object has exactly these two properties
     assert.deepStrictEqual(Object.keys(result).sort(), ['body', 'truncated'])
   })
 
-  test('should not truncate request body under 8KB', async () => {
+  test('should not truncate request body under the ceiling', async () => {
     const { truncateRequestBody } = await import('../../extension/inject.js')
 
-    const smallBody = '{"name":"Alice"}'
-    const result = truncateRequestBody(smallBody)
+    const mediumBody = 'x'.repeat(20000)
+    const result = truncateRequestBody(mediumBody)
 
-    assert.strictEqual(result.body, smallBody)
+    assert.strictEqual(result.body, mediumBody)
     assert.strictEqual(result.truncated, false)
   })
 
-  test('should truncate response body at 16KB', async () => {
+  test('should truncate response body at the configured ceiling', async () => {
     const { truncateResponseBody } = await import('../../extension/inject.js')
+    const { setNetworkBodyCaptureMax } = await import('../../extension/lib/network.js')
+    setNetworkBodyCaptureMax(16384)
 
     const largeBody = 'y'.repeat(20000)
     const result = truncateResponseBody(largeBody)
 
-    assert.ok(result.body.length <= 16384)
+    assert.strictEqual(result.body.length, 16384)
     assert.strictEqual(result.truncated, true)
     assert.strictEqual(typeof result.body, 'string')
     assert.deepStrictEqual(Object.keys(result).sort(), ['body', 'truncated'])
   })
 
-  test('should not truncate response body under 16KB', async () => {
+  test('should ignore an out-of-range ceiling', async () => {
+    const { setNetworkBodyCaptureMax, getNetworkBodyCaptureMax } = await import('../../extension/lib/network.js')
+    setNetworkBodyCaptureMax(16384)
+    setNetworkBodyCaptureMax(2 * 1024 * 1024)
+
+    assert.strictEqual(getNetworkBodyCaptureMax(), 65536)
+  })
+
+  test('should not truncate response body under the ceiling', async () => {
     const { truncateResponseBody } = await import('../../extension/inject.js')
 
     const smallBody = '{"items":[1,2,3]}'
@@ -181,7 +181,19 @@ describe('Network Body E2E Tests', async () => {
   })
 
   describe('Large Body Truncation', () => {
-    test('should truncate large JSON response to 16KB limit', async (t) => {
+    // Pin the capture ceiling to the sizes these fixtures were built around.
+    beforeEach(async () => {
+      const { setNetworkBodyCaptureMax } = await import('../../extension/lib/network.js')
+      setNetworkBodyCaptureMax(16384)
+    })
+
+    afterEach(async () => {
+      const { setNetworkBodyCaptureMax, getNetworkBodyCaptureMax } = await import('../../extension/lib/network.js')
+      setNetworkBodyCaptureMax(0)
+      assert.strictEqual(getNetworkBodyCaptureMax(), 65536, 'Invalid ceiling should restore the default')
+    })
+
+    test('should truncate large JSON response at a 16KB ceiling', async (t) => {
       if (!serverAvailable) {
         t.skip('Test server not running')
         return
@@ -202,7 +214,7 @@ describe('Network Body E2E Tests', async () => {
       assert.strictEqual(result.truncated, true)
     })
 
-    test('should truncate large text response to 16KB limit', async (t) => {
+    test('should truncate large text response at a 16KB ceiling', async (t) => {
       if (!serverAvailable) {
         t.skip('Test server not running')
         return
@@ -236,13 +248,14 @@ describe('Network Body E2E Tests', async () => {
       assert.strictEqual(result.truncated, false)
     })
 
-    test('should truncate large request body to 8KB limit', async (t) => {
+    test('should truncate large request body at an 8KB ceiling', async (t) => {
       if (!serverAvailable) {
         t.skip('Test server not running')
         return
       }
 
-      const { truncateRequestBody } = await import('../../extension/lib/network.js')
+      const { truncateRequestBody, setNetworkBodyCaptureMax } = await import('../../extension/lib/network.js')
+      setNetworkBodyCaptureMax(8192)
 
       // Create large request body
       const largeBody = JSON.stringify({ data: 'x'.repeat(10000) })
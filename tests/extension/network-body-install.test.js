@@ -264,14 +264,14 @@ describe('Bug #4 Fix: installFetchCapture uses wrapFetchWithBodies', () => {
     uninstallFetchCapture()
   })
 
-  test('request bodies are truncated at 8KB limit', async () => {
+  test('request bodies are truncated at the 64KB default ceiling', async () => {
     const { installFetchCapture, uninstallFetchCapture, setNetworkBodyCaptureEnabled } =
       await import('../../extension/inject.js')
 
     setNetworkBodyCaptureEnabled(true)
 
-    // Request body larger than 8KB
-    const hugeRequestBody = 'x'.repeat(10000)
+    // Request body larger than 64KB
+    const hugeRequestBody = 'x'.repeat(70000)
     const mockResponse = createMockResponse({ status: 201, body: '{"id":1}' })
     globalThis.window.fetch = mock.fn(() => Promise.resolve(mockResponse))
 
@@ -286,22 +286,24 @@ describe('Bug #4 Fix: installFetchCapture uses wrapFetchWithBodies', () => {
 
     assert.ok(capturedBodyEvents.length > 0, 'Expected body capture')
     const event = capturedBodyEvents[0]
-    // 8KB = 8192 bytes
-    assert.ok(
-      event.request_body.length <= 8192,
-      `Request body should be truncated at 8KB, got ${event.request_body.length}`
+    assert.strictEqual(
+      event.request_body.length,
+      65536,
+      `Request body should be truncated at 64KB, got ${event.request_body.length}`
     )
 
     uninstallFetchCapture()
   })
 
-  test('response bodies are truncated at 16KB limit', async () => {
+  test('response bodies are truncated at the configured ceiling', async () => {
     const { installFetchCapture, uninstallFetchCapture, setNetworkBodyCaptureEnabled } =
       await import('../../extension/inject.js')
+    const { setNetworkBodyCaptureMax } = await import('../../extension/lib/network.js')
 
     setNetworkBodyCaptureEnabled(true)
+    setNetworkBodyCaptureMax(16384)
 
-    // Response body larger than 16KB
+    // Response body larger than the 16KB ceiling
     const hugeResponseBody = 'y'.repeat(20000)
     const mockResponse = createMockResponse({ status: 200, body: hugeResponseBody })
     globalThis.window.fetch = mock.fn(() => Promise.resolve(mockResponse))
@@ -314,12 +316,12 @@ describe('Bug #4 Fix: installFetchCapture uses wrapFetchWithBodies', () => {
 
     assert.ok(capturedBodyEvents.length > 0, 'Expected body capture')
     const event = capturedBodyEvents[0]
-    // 16KB = 16384 bytes
     assert.ok(
       event.response_body.length <= 16384,
       `Response body should be truncated at 16KB, got ${event.response_body.length}`
     )
 
+    setNetworkBodyCaptureMax(65536)
     uninstallFetchCapture()
   })
 
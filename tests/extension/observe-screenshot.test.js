@@ -41,7 +41,8 @@ mock.module('../../extension/background/index.js', {
 
 mock.module('../../extension/background/state.js', {
   namedExports: {
-    getServerUrl: () => 'http://localhost:7890'
+    getServerUrl: () => 'http://localhost:7890',
+    getScreenshotRedactSelectors: () => []
   }
 })
 
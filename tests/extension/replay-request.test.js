@@ -0,0 +1,228 @@
+// @ts-nocheck
+/**
+ * @fileoverview replay-request.test.js — interact(replay_request): command wiring,
+ * the page-side fetch probe, and the original-vs-replay diff.
+ */
+
+import { beforeEach, describe, mock, test } from 'node:test'
+import assert from 'node:assert'
+import { MANIFEST_VERSION } from './helpers.js'
+
+if (typeof globalThis.navigator === 'undefined') globalThis.navigator = { platform: 'Linux' }
+
+function createMockChrome(trackedTabId = 1830196419) {
+  return {
+    runtime: {
+      onMessage: { addListener: mock.fn() },
+      onInstalled: { addListener: mock.fn() },
+      sendMessage: mock.fn(() => Promise.resolve()),
+      getManifest: () => ({ version: MANIFEST_VERSION })
+    },
+    action: {
+      setBadgeText: mock.fn(),
+      setBadgeBackgroundColor: mock.fn()
+    },
+    tabs: {
+      query: mock.fn((query, callback) => {
+        const result = query?.active
+          ? [{ id: trackedTabId, windowId: 1, url: `https://tracked/${trackedTabId}` }]
+          : [{ id: trackedTabId, windowId: 1, url: `https://tracked/${trackedTabId}` }]
+        if (callback) callback(result)
+        return Promise.resolve(result)
+      }),
+      sendMessage: mock.fn(() => Promise.resolve({ success: true })),
+      get: mock.fn((tabId) => Promise.resolve({ id: tabId, windowId: 1, url: `https://tracked/${tabId}`, status: 'complete' })),
+      onRemoved: { addListener: mock.fn() }
+    },
+    scripting: {
+      executeScript: mock.fn(() =>
+        Promise.resolve([
+          {
+            result: {
+              status: 200,
+              status_text: 'OK',
+              url: 'https://shop.test/api/checkout',
+              headers: { 'content-type': 'application/json' },
+              content_type: 'application/json',
+              body: '{"order":7,"total":12}',
+              body_truncated: false,
+              duration_ms: 120
+            }
+          }
+        ])
+      )
+    },
+    storage: {
+      local: {
+        get: mock.fn((keys, callback) => {
+          const data = {
+            serverUrl: 'http://localhost:7890',
+            aiWebPilotEnabled: true,
+            trackedTabId,
+            trackedTabUrl: `https://tracked/${trackedTabId}`,
+            trackedTabTitle: 'Tracked Tab'
+          }
+          if (callback) callback(data)
+          return Promise.resolve(data)
+        }),
+        set: mock.fn((data, callback) => {
+          if (callback) callback()
+          return Promise.resolve()
+        }),
+        remove: mock.fn((keys, callback) => {
+          if (callback) callback()
+          return Promise.resolve()
+        })
+      },
+      sync: {
+        get: mock.fn((keys, callback) => {
+          if (callback) callback({})
+          return Promise.resolve({})
+        }),
+        set: mock.fn((data, callback) => {
+          if (callback) callback()
+          return Promise.resolve()
+        })
+      },
+      session: {
+        get: mock.fn((keys, callback) => {
+          if (callback) callback({})
+          return Promise.resolve({})
+        }),
+        set: mock.fn((data, callback) => {
+          if (callback) callback()
+          return Promise.resolve()
+        })
+      },
+      onChanged: { addListener: mock.fn() }
+    },
+    alarms: {
+      create: mock.fn(),
+      onAlarm: { addListener: mock.fn() }
+    }
+  }
+}
+
+describe('replay_request command', () => {
+  const trackedTabId = 5151
+
+  beforeEach(async () => {
+    mock.reset()
+    globalThis.chrome = createMockChrome(trackedTabId)
+    globalThis.fetch = mock.fn(() =>
+      Promise.resolve({
+        ok: true,
+        json: () => Promise.resolve({ queries: [] })
+      })
+    )
+    const bgModule = await import('../../extension/background.js')
+    bgModule.markInitComplete()
+  })
+
+  test('replays in the MAIN world and returns the original, replay, and diff', async () => {
+    const bgModule = await import('../../extension/background.js')
+    const mockSyncClient = { queueCommandResult: mock.fn() }
+
+    await bgModule.handlePendingQuery(
+      {
+        id: 'q-replay',
+        type: 'replay_request',
+        correlation_id: 'corr-replay',
+        params: JSON.stringify({
+          request_id: 'req-3',
+          url: 'https://shop.test/api/checkout',
+          method: 'post',
+          headers: { 'Content-Type': 'application/json' },
+          body: '{"cart":42}',
+          max_body: 65536,
+          original: { status: 502, duration_ms: 840, body: '{"error":"upstream"}' }
+        })
+      },
+      mockSyncClient
+    )
+
+    const call = globalThis.chrome.scripting.executeScript.mock.calls[0].arguments[0]
+    assert.strictEqual(call.target.tabId, trackedTabId)
+    assert.strictEqual(call.world, 'MAIN')
+    assert.deepStrictEqual(call.args, [
+      'https://shop.test/api/checkout',
+      'post',
+      { 'Content-Type': 'application/json' },
+      '{"cart":42}',
+      65536
+    ])
+
+    const queued = mockSyncClient.queueCommandResult.mock.calls[0].arguments[0]
+    assert.strictEqual(queued.status, 'complete')
+    assert.strictEqual(queued.result.request_id, 'req-3')
+    assert.strictEqual(queued.result.method, 'POST')
+    assert.strictEqual(queued.result.replay.status, 200)
+    assert.strictEqual(queued.result.diff.status_changed, true)
+    assert.strictEqual(queued.result.diff.duration_delta_ms, -720)
+  })
+})
+
+describe('replayRequestProbe', () => {
+  test('sends the request with credentials and truncates the body', async () => {
+    const { replayRequestProbe } = await import('../../extension/background/commands/replay-request.js')
+    globalThis.fetch = mock.fn(() =>
+      Promise.resolve({
+        status: 500,
+        statusText: 'Internal Server Error',
+        url: 'https://shop.test/api/items',
+        headers: new Headers({ 'content-type': 'text/plain' }),
+        text: () => Promise.resolve('0123456789')
+      })
+    )
+
+    const result = await replayRequestProbe('https://shop.test/api/items', 'get', { 'X-Debug': '1' }, 'ignored', 4)
+
+    const [url, init] = globalThis.fetch.mock.calls[0].arguments
+    assert.strictEqual(url, 'https://shop.test/api/items')
+    assert.strictEqual(init.method, 'GET')
+    assert.strictEqual(init.credentials, 'include')
+    assert.strictEqual(init.body, undefined, 'GET must not carry a body')
+    assert.strictEqual(result.status, 500)
+    assert.strictEqual(result.content_type, 'text/plain')
+    assert.strictEqual(result.body, '0123')
+    assert.strictEqual(result.body_truncated, true)
+  })
+
+  test('reports network failures instead of throwing', async () => {
+    const { replayRequestProbe } = await import('../../extension/background/commands/replay-request.js')
+    globalThis.fetch = mock.fn(() => Promise.reject(new TypeError('Failed to fetch')))
+
+    const result = await replayRequestProbe('https://down.test/', 'POST', {}, '{}', 0)
+
+    assert.deepStrictEqual(result, { error: 'replay_failed', message: 'Failed to fetch' })
+  })
+})
+
+describe('diffReplay', () => {
+  test('reports top-level JSON key changes', async () => {
+    const { diffReplay } = await import('../../extension/background/commands/replay-request.js')
+    const diff = diffReplay(
+      { status: 200, body: '{"id":1,"name":"a","stale":true}' },
+      { status: 200, body: '{"id":1,"name":"b","fresh":true}', duration_ms: 10 }
+    )
+
+    assert.strictEqual(diff.status_changed, false)
+    assert.strictEqual(diff.body_changed, true)
+    assert.strictEqual(diff.duration_delta_ms, undefined)
+    assert.deepStrictEqual(diff.keys_added, ['fresh'])
+    assert.deepStrictEqual(diff.keys_removed, ['stale'])
+    assert.deepStrictEqual(diff.keys_changed, ['name'])
+  })
+
+  test('skips key diffs for identical or non-JSON bodies', async () => {
+    const { diffReplay } = await import('../../extension/background/commands/replay-request.js')
+
+    assert.deepStrictEqual(diffReplay({ status: 200, body: 'ok' }, { status: 200, body: 'ok', duration_ms: 5 }), {
+      status_changed: false,
+      body_changed: false
+    })
+    const text = diffReplay({ status: 200, body: 'a' }, { status: 404, body: 'b', duration_ms: 5 })
+    assert.strictEqual(text.body_changed, true)
+    assert.strictEqual(text.keys_added, undefined)
+  })
+})
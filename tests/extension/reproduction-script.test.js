@@ -543,6 +543,19 @@ describe('Enhanced Action Recording', () => {
     assert.strictEqual(action.url, 'http://localhost:3000/page')
   })
 
+  test('should record the page locale from html lang', async () => {
+    const { recordEnhancedAction } = await import('../../extension/inject.js')
+
+    const originalDocument = globalThis.document
+    globalThis.document = { documentElement: { lang: 'de-DE' } }
+    try {
+      const action = recordEnhancedAction('click', createElement('button', {}))
+      assert.strictEqual(action.locale, 'de-DE')
+    } finally {
+      globalThis.document = originalDocument
+    }
+  })
+
   test('should buffer up to 50 actions', async () => {
     const { recordEnhancedAction, getEnhancedActionBuffer } = await import('../../extension/inject.js')
 
@@ -0,0 +1,148 @@
+// @ts-nocheck
+/**
+ * @fileoverview request-mocks.test.js — Tests configure(what="mock_request") fulfillment in the page.
+ * Covers URL pattern and method matching, mocked fetch responses and network errors,
+ * delays, XHR fulfillment, and the inject setting that installs the mock list.
+ */
+
+import { test, describe, mock, beforeEach, afterEach } from 'node:test'
+import assert from 'node:assert'
+
+if (typeof globalThis.ProgressEvent === 'undefined') {
+  globalThis.ProgressEvent = class ProgressEvent extends Event {}
+}
+globalThis.location = { href: 'https://app.test/dashboard' }
+
+const { setRequestMocks, findRequestMock } = await import('../../extension/lib/request-mocks.js')
+const { wrapFetchWithBodies, wrapXHRWithBodies, unwrapXHR } = await import('../../extension/lib/network.js')
+
+const MOCKS = [
+  { id: 'mock-1', url_pattern: '/api/users', method: 'POST', status: 503, body: '{"error":"down"}', headers: { 'Content-Type': 'application/json' } },
+  { id: 'mock-2', url_pattern: 'https://cdn.test/*.js', status: 0 },
+  { id: 'mock-3', url_pattern: '/api/slow', status: 200, body: 'late', delay_ms: 40 }
+]
+
+describe('findRequestMock', () => {
+  beforeEach(() => setRequestMocks(MOCKS))
+  afterEach(() => setRequestMocks([]))
+
+  test('matches substrings, whole-URL globs, and methods', () => {
+    assert.strictEqual(findRequestMock('/api/users?page=2', 'post')?.id, 'mock-1')
+    assert.strictEqual(findRequestMock('/api/users', 'GET'), null)
+    assert.strictEqual(findRequestMock('https://cdn.test/app.js', 'GET')?.id, 'mock-2')
+    assert.strictEqual(findRequestMock('https://cdn.test/app.js?v=1', 'GET'), null)
+    assert.strictEqual(findRequestMock('/other', 'GET'), null)
+  })
+
+  test('ignores malformed mock lists', () => {
+    setRequestMocks([{ id: 'x' }, null, ...MOCKS.slice(0, 1)])
+    assert.strictEqual(findRequestMock('/api/users', 'POST')?.id, 'mock-1')
+    setRequestMocks('nope')
+    assert.strictEqual(findRequestMock('/api/users', 'POST'), null)
+  })
+})
+
+describe('mocked fetch', () => {
+  beforeEach(() => setRequestMocks(MOCKS))
+  afterEach(() => setRequestMocks([]))
+
+  test('serves the stub instead of calling the network', async () => {
+    const realFetch = mock.fn(() => Promise.resolve(new Response('real')))
+    const fetchFn = wrapFetchWithBodies(realFetch)
+
+    const res = await fetchFn('/api/users', { method: 'POST', body: '{}' })
+    assert.strictEqual(res.status, 503)
+    assert.strictEqual(res.headers.get('content-type'), 'application/json')
+    assert.deepStrictEqual(await res.json(), { error: 'down' })
+
+    assert.strictEqual(await (await fetchFn('/api/users')).text(), 'real')
+    assert.strictEqual(realFetch.mock.calls.length, 1, 'only the unmocked GET reaches the network')
+  })
+
+  test('status 0 rejects like a network failure', async () => {
+    const fetchFn = wrapFetchWithBodies(mock.fn())
+    await assert.rejects(fetchFn('https://cdn.test/app.js'), TypeError)
+  })
+
+  test('delay_ms holds the response back', async () => {
+    const fetchFn = wrapFetchWithBodies(mock.fn())
+    const start = Date.now()
+    const res = await fetchFn('/api/slow')
+    assert.ok(Date.now() - start >= 35, 'response should wait for delay_ms')
+    assert.strictEqual(await res.text(), 'late')
+  })
+})
+
+describe('mocked XMLHttpRequest', () => {
+  let realSend
+
+  beforeEach(() => {
+    realSend = mock.fn()
+    globalThis.XMLHttpRequest = class extends EventTarget {
+      open() {}
+    }
+    globalThis.XMLHttpRequest.prototype.send = realSend
+    wrapXHRWithBodies()
+    setRequestMocks(MOCKS)
+  })
+
+  afterEach(() => {
+    setRequestMocks([])
+    unwrapXHR()
+    delete globalThis.XMLHttpRequest
+  })
+
+  function settle(xhr) {
+    return new Promise((resolve) => xhr.addEventListener('loadend', resolve))
+  }
+
+  test('fulfills a matching request without sending it', async () => {
+    const xhr = new XMLHttpRequest()
+    xhr.responseType = 'json'
+    const events = []
+    for (const type of ['readystatechange', 'load', 'error']) xhr.addEventListener(type, () => events.push(type))
+    xhr.open('POST', '/api/users')
+    xhr.send('{}')
+    await settle(xhr)
+
+    assert.strictEqual(realSend.mock.calls.length, 0)
+    assert.deepStrictEqual(events, ['readystatechange', 'load'])
+    assert.strictEqual(xhr.readyState, 4)
+    assert.strictEqual(xhr.status, 503)
+    assert.deepStrictEqual(xhr.response, { error: 'down' })
+    assert.strictEqual(xhr.getResponseHeader('Content-Type'), 'application/json')
+  })
+
+  test('status 0 fires error', async () => {
+    const xhr = new XMLHttpRequest()
+    const events = []
+    xhr.addEventListener('error', () => events.push('error'))
+    xhr.open('GET', 'https://cdn.test/app.js')
+    xhr.send()
+    await settle(xhr)
+    assert.deepStrictEqual(events, ['error'])
+    assert.strictEqual(xhr.status, 0)
+  })
+
+  test('unmatched requests are sent normally', () => {
+    const xhr = new XMLHttpRequest()
+    xhr.open('GET', '/api/users')
+    xhr.send()
+    assert.strictEqual(realSend.mock.calls.length, 1)
+  })
+})
+
+describe('request mocks inject setting', () => {
+  afterEach(() => setRequestMocks([]))
+
+  test('validates and installs the mock list', async () => {
+    const { isValidSettingPayload, handleSetting } = await import('../../extension/inject/settings.js')
+    const { SettingName } = await import('../../extension/lib/constants.js')
+
+    assert.strictEqual(isValidSettingPayload({ type: 'kaboom_setting', setting: SettingName.REQUEST_MOCKS, mocks: 'x' }), false)
+    const data = { type: 'kaboom_setting', setting: SettingName.REQUEST_MOCKS, mocks: MOCKS }
+    assert.strictEqual(isValidSettingPayload(data), true)
+    handleSetting(data)
+    assert.strictEqual(findRequestMock('/api/slow', 'GET')?.id, 'mock-3')
+  })
+})
@@ -0,0 +1,60 @@
+// @ts-nocheck
+/**
+ * @fileoverview screenshot-redaction.test.js — Tests for screenshot redaction and highlight
+ * region measurement, and for reading the redaction selectors from capture_overrides.
+ */
+
+import { beforeEach, describe, mock, test } from 'node:test'
+import assert from 'node:assert'
+
+import { measureScreenshotRegions } from '../../extension/background/tab-state.js'
+import { applyCaptureOverrides, getScreenshotRedactSelectors } from '../../extension/background/state.js'
+
+function el(left, top, width, height) {
+  return { getBoundingClientRect: () => ({ left, top, width, height }) }
+}
+
+describe('screenshot redaction', () => {
+  beforeEach(() => {
+    globalThis.window = { innerWidth: 1280, devicePixelRatio: 2 }
+    globalThis.document = {
+      querySelectorAll: (selector) => {
+        if (selector === '[bad') throw new Error('SyntaxError')
+        if (selector === '.ssn') return [el(10, 20, 100, 16), el(0, 0, 0, 0)]
+        return []
+      },
+      querySelector: (selector) => (selector === '#buy' ? el(300, 400, 80, 32) : null)
+    }
+    // Run the injected function in-process, as chrome.scripting would in the page.
+    globalThis.chrome = {
+      scripting: {
+        executeScript: mock.fn(async ({ func, args }) => [{ result: func(...args) }])
+      }
+    }
+  })
+
+  test('measures every visible redact match and the first highlight match', async () => {
+    const regions = await measureScreenshotRegions(7, ['.ssn', '[bad'], '#buy')
+    assert.deepStrictEqual(regions.redact, [
+      { x: 10, y: 20, width: 100, height: 16, viewport_width: 1280, device_pixel_ratio: 2 }
+    ])
+    assert.deepStrictEqual(regions.highlight, [
+      { x: 300, y: 400, width: 80, height: 32, viewport_width: 1280, device_pixel_ratio: 2 }
+    ])
+    assert.deepStrictEqual(globalThis.chrome.scripting.executeScript.mock.calls[0].arguments[0].target, { tabId: 7 })
+  })
+
+  test('throws when the page returns no result so callers can refuse to upload', async () => {
+    globalThis.chrome.scripting.executeScript = mock.fn(async () => [])
+    await assert.rejects(() => measureScreenshotRegions(7, ['.ssn'], ''), /no result/)
+  })
+
+  test('reads redaction selectors from capture_overrides', () => {
+    applyCaptureOverrides({ screenshot_redact_selectors: '[".ssn","#card"]' })
+    assert.deepStrictEqual(getScreenshotRedactSelectors(), ['.ssn', '#card'])
+    applyCaptureOverrides({ screenshot_redact_selectors: 'not json' })
+    assert.deepStrictEqual(getScreenshotRedactSelectors(), [])
+    applyCaptureOverrides({})
+    assert.deepStrictEqual(getScreenshotRedactSelectors(), [])
+  })
+})
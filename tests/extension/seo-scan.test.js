@@ -0,0 +1,53 @@
+// @ts-nocheck
+/**
+ * @fileoverview seo-scan.test.js — observe(seo) probe: the self-contained page function that
+ * collects titles, meta descriptions, canonicals, robots directives, JSON-LD, and same-origin links.
+ */
+
+import { describe, test } from 'node:test'
+import assert from 'node:assert'
+
+if (typeof globalThis.navigator === 'undefined') globalThis.navigator = { platform: 'Linux' }
+if (typeof globalThis.chrome === 'undefined') globalThis.chrome = { runtime: { onMessage: { addListener() {} } } }
+
+function fakeElement(attrs = {}, text = '', inSvg = false) {
+  return {
+    textContent: text,
+    getAttribute: (name) => (name in attrs ? attrs[name] : null),
+    closest: (sel) => (sel === 'svg' && inSvg ? {} : null)
+  }
+}
+
+describe('seoScanProbe', () => {
+  test('collects head metadata, JSON-LD, and unique same-origin links', async () => {
+    const { seoScanProbe } = await import('../../extension/background/commands/observe-seo.js')
+    const bySelector = {
+      title: [fakeElement({}, '  Running   shoes '), fakeElement({}, 'icon', true)],
+      'meta[name]': [
+        fakeElement({ name: 'Description', content: 'Shoes for every run' }),
+        fakeElement({ name: 'robots', content: 'noindex, nofollow' }),
+        fakeElement({ name: 'viewport', content: 'width=device-width' })
+      ],
+      'link[rel]': [fakeElement({ rel: 'Canonical', href: '/shoes' }), fakeElement({ rel: 'stylesheet', href: '/a.css' })],
+      'script[type]': [fakeElement({ type: 'application/ld+json' }, '{"@type":"Product"}'), fakeElement({ type: 'module' }, 'x')],
+      'a[href]': [
+        fakeElement({ href: '/sale#top' }, 'Sale'),
+        fakeElement({ href: '/sale' }, 'Sale again'),
+        fakeElement({ href: 'https://other.test/' }, 'Partner'),
+        fakeElement({ href: 'http://[bad' }, 'Broken')
+      ]
+    }
+    globalThis.location = { href: 'https://shop.test/shoes?x=1', origin: 'https://shop.test' }
+    globalThis.document = { querySelectorAll: (sel) => bySelector[sel] || [] }
+
+    const result = seoScanProbe()
+    assert.deepStrictEqual(result.titles, ['Running shoes'])
+    assert.deepStrictEqual(result.descriptions, ['Shoes for every run'])
+    assert.deepStrictEqual(result.canonicals, ['/shoes'])
+    assert.deepStrictEqual(result.robots, [{ name: 'robots', content: 'noindex, nofollow' }])
+    assert.deepStrictEqual(result.json_ld, [{ text: '{"@type":"Product"}' }])
+    assert.deepStrictEqual(result.links, [{ url: 'https://shop.test/sale', text: 'Sale' }])
+    assert.strictEqual(result.link_count, 1)
+    assert.strictEqual(result.links_truncated, false)
+  })
+})
@@ -0,0 +1,133 @@
+// @ts-nocheck
+/**
+ * @fileoverview sse-capture.test.js — Tests for Server-Sent Events capture.
+ * Covers EventSource constructor wrapping, open/message/retry/error/close
+ * forwarding, named event types subscribed by the page, and the enable toggle.
+ */
+
+import { test, describe, beforeEach, afterEach } from 'node:test'
+import assert from 'node:assert'
+import { createMockWindow, createMockCrypto } from './helpers.js'
+
+// Minimal EventSource: real dispatch via EventTarget, readyState driven by the test.
+class MockEventSource extends EventTarget {
+  constructor(url, init) {
+    super()
+    this.url = String(url)
+    this.withCredentials = !!init?.withCredentials
+    this.readyState = 0
+  }
+  close() {
+    this.readyState = 2
+  }
+  _message(type, data, lastEventId = '') {
+    this.dispatchEvent(new MessageEvent(type, { data, lastEventId }))
+  }
+}
+MockEventSource.CONNECTING = 0
+MockEventSource.OPEN = 1
+MockEventSource.CLOSED = 2
+
+let originalWindow, originalCrypto
+
+function posted() {
+  return globalThis.window.postMessage.mock.calls
+    .map((c) => c.arguments[0])
+    .filter((m) => m.type === 'kaboom_sse')
+    .map((m) => m.payload)
+}
+
+describe('Server-Sent Events capture', () => {
+  beforeEach(() => {
+    originalWindow = globalThis.window
+    originalCrypto = globalThis.crypto
+    globalThis.window = createMockWindow()
+    globalThis.window.EventSource = MockEventSource
+    Object.defineProperty(globalThis, 'crypto', { value: createMockCrypto(), writable: true, configurable: true })
+  })
+
+  afterEach(async () => {
+    const { resetForTesting } = await import('../../extension/lib/sse.js')
+    resetForTesting()
+    globalThis.window = originalWindow
+    Object.defineProperty(globalThis, 'crypto', { value: originalCrypto, writable: true, configurable: true })
+  })
+
+  test('installs and restores the EventSource constructor', async () => {
+    const { installSSECapture, uninstallSSECapture } = await import('../../extension/lib/sse.js')
+    installSSECapture()
+    assert.notStrictEqual(globalThis.window.EventSource, MockEventSource)
+    assert.strictEqual(globalThis.window.EventSource.CLOSED, 2)
+    uninstallSSECapture()
+    assert.strictEqual(globalThis.window.EventSource, MockEventSource)
+  })
+
+  test('forwards lifecycle events and default messages', async () => {
+    const { installSSECapture } = await import('../../extension/lib/sse.js')
+    installSSECapture()
+    const source = new globalThis.window.EventSource('https://app.test/feed', { withCredentials: true })
+
+    source.readyState = 1
+    source.dispatchEvent(new Event('open'))
+    source._message('message', 'hello', '7')
+    source.readyState = 0
+    source.dispatchEvent(new Event('error'))
+    source.close()
+
+    const events = posted()
+    assert.deepStrictEqual(
+      events.map((e) => e.event),
+      ['open', 'message', 'retry', 'close']
+    )
+    const ids = new Set(events.map((e) => e.id))
+    assert.strictEqual(ids.size, 1, 'one connection ID per EventSource')
+    assert.strictEqual(events[0].with_credentials, true)
+    assert.strictEqual(events[1].event_type, 'message')
+    assert.strictEqual(events[1].data, 'hello')
+    assert.strictEqual(events[1].last_event_id, '7')
+    assert.strictEqual(events[1].size, 5)
+  })
+
+  test('reports error when the browser gives up, and no close after it', async () => {
+    const { installSSECapture } = await import('../../extension/lib/sse.js')
+    installSSECapture()
+    const source = new globalThis.window.EventSource('https://app.test/feed')
+
+    source.readyState = 2
+    source.dispatchEvent(new Event('error'))
+    source.close()
+
+    assert.deepStrictEqual(
+      posted().map((e) => e.event),
+      ['error']
+    )
+  })
+
+  test('captures named event types once the page listens for them', async () => {
+    const { installSSECapture } = await import('../../extension/lib/sse.js')
+    installSSECapture()
+    const source = new globalThis.window.EventSource('https://app.test/feed')
+    const received = []
+    source.addEventListener('price', (e) => received.push(e.data))
+    source.addEventListener('price', () => {})
+
+    source._message('price', '{"p":3}')
+
+    assert.deepStrictEqual(received, ['{"p":3}'], 'page listener still fires')
+    const messages = posted().filter((e) => e.event === 'message')
+    assert.strictEqual(messages.length, 1, 'capture listener attached once per type')
+    assert.strictEqual(messages[0].event_type, 'price')
+  })
+
+  test('posts nothing while capture is disabled', async () => {
+    const { installSSECapture, setSSECaptureEnabled } = await import('../../extension/lib/sse.js')
+    installSSECapture()
+    setSSECaptureEnabled(false)
+    const source = new globalThis.window.EventSource('https://app.test/feed')
+
+    source._message('message', 'hello')
+    source.close()
+
+    assert.strictEqual(posted().length, 0)
+  })
+})
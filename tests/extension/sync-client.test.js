@@ -676,6 +676,48 @@ describe('SyncClient — Command dispatch', () => {
     assert.strictEqual(callbacks.onCommand.mock.calls.length, 1)
   })
 
+  test('should not re-execute an idempotency key after a service-worker restart', async () => {
+    const { resetIdempotencyKeysForTest } = await import('../../extension/background/idempotency-keys.js')
+    const sessionData = {}
+    const originalSession = globalThis.chrome.storage.session
+    globalThis.chrome.storage.session = {
+      get: mock.fn((key, cb) => cb({ [key]: sessionData[key] })),
+      set: mock.fn((items, cb) => {
+        Object.assign(sessionData, items)
+        if (cb) cb()
+      })
+    }
+    const command = { id: 'q-7', type: 'dom_action', params: { action: 'click' }, idempotency_key: 'k1-q-7', attempt: 1 }
+    try {
+      resetIdempotencyKeysForTest()
+      installFetchMock(makeSyncResponse({ commands: [command], next_poll_ms: 60000 }))
+      const beforeRestart = new SyncClient('http://localhost:7777', 'sess-1', callbacks)
+      beforeRestart.start()
+      await tick(50)
+      beforeRestart.stop()
+      assert.strictEqual(callbacks.onCommand.mock.calls.length, 1)
+
+      // A restarted worker loses receipt dedup and in-memory keys; session storage survives.
+      resetIdempotencyKeysForTest()
+      const mockFetch = installFetchMock(makeSyncResponse({ commands: [command], next_poll_ms: 60000 }))
+      client = new SyncClient('http://localhost:7777', 'sess-2', callbacks)
+      client.start()
+      await tick(50)
+
+      assert.strictEqual(callbacks.onCommand.mock.calls.length, 1, 'the click must not run twice')
+      const reported = mockFetch.mock.calls
+        .flatMap((c) => JSON.parse(c.arguments[1].body).command_results || [])
+        .find((r) => r.id === 'q-7')
+      assert.ok(reported, 'the duplicate delivery should be reported back')
+      assert.strictEqual(reported.status, 'error')
+      assert.strictEqual(reported.result.error, 'duplicate_delivery')
+      assert.strictEqual(client.getState().lastCommandAck, 'q-7')
+    } finally {
+      globalThis.chrome.storage.session = originalSession
+      resetIdempotencyKeysForTest()
+    }
+  })
+
   test('should keep syncing while async command handlers are still running', async () => {
     let fetchCalls = 0
     globalThis.fetch = mock.fn(() => {
@@ -51,6 +51,7 @@ mock.module('../../extension/background/communication.js', {
     createBatcherWithCircuitBreaker: mock.fn(() => ({ push: mock.fn(), flush: mock.fn() })),
     sendLogsToServer: mock.fn(async () => ({ ok: true })),
     sendWSEventsToServer: mock.fn(async () => ({ ok: true })),
+    sendSSEEventsToServer: mock.fn(async () => ({ ok: true })),
     sendEnhancedActionsToServer: mock.fn(async () => ({ ok: true })),
     sendNetworkBodiesToServer: mock.fn(async () => ({ ok: true })),
     sendPerformanceSnapshotsToServer: mock.fn(async () => ({ ok: true }))
@@ -74,12 +75,25 @@ mock.module('../../extension/background/state-manager.js', {
   }
 })
 
+const mockForwardToAllContentScripts = mock.fn(() => Promise.resolve())
+const mockSaveSetting = mock.fn()
+const mockGetTrackedTabInfo = mock.fn(() => Promise.resolve({
+  trackedTabId: 0, trackedTabUrl: '', trackedTabTitle: ''
+}))
+const mockApplyRegionOverride = mock.fn(() => Promise.resolve())
+
+mock.module('../../extension/background/device-emulation.js', {
+  namedExports: {
+    applyRegionOverride: mockApplyRegionOverride
+  }
+})
+
 mock.module('../../extension/background/event-listeners.js', {
   namedExports: {
+    forwardToAllContentScripts: mockForwardToAllContentScripts,
+    saveSetting: mockSaveSetting,
     getActiveTab: mock.fn(() => Promise.resolve({ id: 1, windowId: 1, url: 'http://localhost:3000' })),
-    getTrackedTabInfo: mock.fn(() => Promise.resolve({
-      trackedTabId: 0, trackedTabUrl: '', trackedTabTitle: ''
-    })),
+    getTrackedTabInfo: mockGetTrackedTabInfo,
     clearTrackedTab: mock.fn(() => Promise.resolve()),
     waitForTabLoad: mock.fn(() => Promise.resolve()),
     pingContentScript: mock.fn(() => Promise.resolve({ ok: true }))
@@ -244,3 +258,146 @@ describe('resetSyncClientConnection', () => {
     assert.strictEqual(debugLog.mock.calls.length, 0)
   })
 })
+
+describe('onCaptureOverrides body_capture_max', () => {
+  beforeEach(() => {
+    mockCreateSyncClient.mock.resetCalls()
+    mockForwardToAllContentScripts.mock.resetCalls()
+    mockSaveSetting.mock.resetCalls()
+  })
+
+  test('pushes the capture ceiling to pages only when it changes', async () => {
+    const { startSyncClient } = await freshImport()
+    const deps = createMockDeps()
+    startSyncClient(deps)
+    const { onCaptureOverrides } = mockCreateSyncClient.mock.calls[0].arguments[2]
+
+    onCaptureOverrides({ body_capture_max: '200000' })
+    onCaptureOverrides({ body_capture_max: '200000' })
+    onCaptureOverrides({})
+
+    const forwarded = mockForwardToAllContentScripts.mock.calls
+      .map((c) => c.arguments[0])
+      .filter((m) => m.type === 'set_network_body_capture_max')
+    assert.deepStrictEqual(forwarded, [
+      { type: 'set_network_body_capture_max', limit: 200000 },
+      { type: 'set_network_body_capture_max', limit: 65536 }
+    ])
+    assert.deepStrictEqual(mockSaveSetting.mock.calls[0].arguments, ['networkBodyCaptureMax', 200000])
+    assert.strictEqual(deps.applyCaptureOverrides.mock.calls.length, 3)
+  })
+})
+
+describe('onCaptureOverrides binary_capture_max', () => {
+  beforeEach(() => {
+    mockCreateSyncClient.mock.resetCalls()
+    mockForwardToAllContentScripts.mock.resetCalls()
+    mockSaveSetting.mock.resetCalls()
+  })
+
+  test('pushes the binary cap to pages and turns it off when the override goes away', async () => {
+    const { startSyncClient } = await freshImport()
+    const deps = createMockDeps()
+    startSyncClient(deps)
+    const { onCaptureOverrides } = mockCreateSyncClient.mock.calls[0].arguments[2]
+
+    onCaptureOverrides({ binary_capture_max: '4096' })
+    onCaptureOverrides({ binary_capture_max: '4096' })
+    onCaptureOverrides({})
+
+    const forwarded = mockForwardToAllContentScripts.mock.calls
+      .map((c) => c.arguments[0])
+      .filter((m) => m.type === 'set_network_binary_capture_max')
+    assert.deepStrictEqual(forwarded, [
+      { type: 'set_network_binary_capture_max', limit: 4096 },
+      { type: 'set_network_binary_capture_max', limit: 0 }
+    ])
+    assert.ok(mockSaveSetting.mock.calls.some((c) => c.arguments[0] === 'networkBinaryCaptureMax' && c.arguments[1] === 4096))
+  })
+})
+
+describe('onCaptureOverrides request_mocks', () => {
+  beforeEach(() => {
+    mockCreateSyncClient.mock.resetCalls()
+    mockForwardToAllContentScripts.mock.resetCalls()
+    mockSaveSetting.mock.resetCalls()
+  })
+
+  test('pushes mocks to pages on change and clears them on disconnect', async () => {
+    const { startSyncClient } = await freshImport()
+    const deps = createMockDeps()
+    startSyncClient(deps)
+    const { onCaptureOverrides, onConnectionChange } = mockCreateSyncClient.mock.calls[0].arguments[2]
+    const mocks = [{ id: 'mock-1', url_pattern: '/api/users', status: 500 }]
+
+    onCaptureOverrides({ request_mocks: JSON.stringify(mocks) })
+    onCaptureOverrides({ request_mocks: JSON.stringify(mocks) })
+    onConnectionChange(false)
+
+    const forwarded = mockForwardToAllContentScripts.mock.calls
+      .map((c) => c.arguments[0])
+      .filter((m) => m.type === 'set_request_mocks')
+    assert.deepStrictEqual(forwarded, [
+      { type: 'set_request_mocks', mocks },
+      { type: 'set_request_mocks', mocks: [] }
+    ])
+    const saved = mockSaveSetting.mock.calls.filter((c) => c.arguments[0] === 'requestMocks').map((c) => c.arguments[1])
+    assert.deepStrictEqual(saved, [mocks, []])
+  })
+})
+
+describe('onCaptureOverrides multi_tab_capture', () => {
+  beforeEach(() => {
+    mockCreateSyncClient.mock.resetCalls()
+    mockSaveSetting.mock.resetCalls()
+  })
+
+  test('persists the flag for content scripts and turns it off on disconnect', async () => {
+    const { startSyncClient } = await freshImport()
+    const deps = createMockDeps()
+    startSyncClient(deps)
+    const { onCaptureOverrides, onConnectionChange } = mockCreateSyncClient.mock.calls[0].arguments[2]
+
+    onCaptureOverrides({ multi_tab_capture: 'true' })
+    onCaptureOverrides({ multi_tab_capture: 'true' })
+    onConnectionChange(false)
+
+    const saved = mockSaveSetting.mock.calls.filter((c) => c.arguments[0] === 'multiTabCapture').map((c) => c.arguments[1])
+    assert.deepStrictEqual(saved, [true, false])
+  })
+})
+
+describe('onCaptureOverrides region_override', () => {
+  beforeEach(() => {
+    mockCreateSyncClient.mock.resetCalls()
+    mockApplyRegionOverride.mock.resetCalls()
+  })
+
+  test('applies overrides to the tracked tab, follows it, and clears them on disconnect', async () => {
+    let trackedTabId = 7
+    mockGetTrackedTabInfo.mock.mockImplementation(() => Promise.resolve({ trackedTabId, trackedTabUrl: '', trackedTabTitle: '' }))
+    const { startSyncClient } = await freshImport()
+    startSyncClient(createMockDeps())
+    const { onCaptureOverrides, onConnectionChange } = mockCreateSyncClient.mock.calls[0].arguments[2]
+    const region = { timezone: 'Europe/Berlin', locale: 'de-DE' }
+    const settle = () => new Promise((resolve) => setTimeout(resolve, 0))
+
+    onCaptureOverrides({ region_override: JSON.stringify(region) })
+    onCaptureOverrides({ region_override: JSON.stringify(region) })
+    await settle()
+    trackedTabId = 9
+    onCaptureOverrides({ region_override: JSON.stringify(region) })
+    await settle()
+    onConnectionChange(false)
+    await settle()
+
+    const calls = mockApplyRegionOverride.mock.calls.map((c) => c.arguments)
+    assert.deepStrictEqual(calls, [
+      [7, region],
+      [7, null],
+      [9, region],
+      [9, null]
+    ])
+    mockGetTrackedTabInfo.mock.restore()
+  })
+})
@@ -293,6 +293,54 @@ describe('Web Vitals Capture', () => {
     mod.installPerfObservers()
     assert.strictEqual(mod.getINP(), null)
   })
+
+  test('getINPInteractions breaks down the slowest entry per interaction', async () => {
+    const mod = await import('../../extension/inject.js')
+    mod.installPerfObservers()
+
+    const eventObs = MockPerformanceObserver._instances.find((obs) => obs._types.includes('event'))
+    assert.ok(eventObs, 'Event observer should exist')
+
+    const button = { tagName: 'BUTTON', id: 'checkout', className: 'btn primary', getAttribute: () => null }
+    eventObs._emit([
+      // pointerdown and click belong to the same interaction; the slower click wins
+      { name: 'pointerdown', interactionId: 7, startTime: 1000, duration: 96, processingStart: 1010, processingEnd: 1020, target: button },
+      { name: 'click', interactionId: 7, startTime: 1000, duration: 480, processingStart: 1012, processingEnd: 1422, target: button },
+      { name: 'keydown', interactionId: 9, startTime: 2000, duration: 120, processingStart: 2030, processingEnd: 2100, target: null }
+    ])
+
+    const interactions = mod.getINPInteractions()
+    assert.strictEqual(interactions.length, 2)
+    assert.deepStrictEqual(interactions[0], {
+      interaction_id: 7,
+      name: 'click',
+      target: 'button#checkout.btn.primary',
+      start_time: 1000,
+      duration: 480,
+      input_delay: 12,
+      processing_time: 410,
+      presentation_delay: 58
+    })
+    assert.strictEqual(interactions[1].name, 'keydown')
+    assert.strictEqual(interactions[1].target, '')
+  })
+
+  test('getINPInteractions keeps only the slowest interactions', async () => {
+    const mod = await import('../../extension/inject.js')
+    mod.installPerfObservers()
+
+    const eventObs = MockPerformanceObserver._instances.find((obs) => obs._types.includes('event'))
+    const entries = []
+    for (let i = 1; i <= 15; i++) {
+      entries.push({ name: 'click', interactionId: i, startTime: i * 100, duration: 40 + i })
+    }
+    eventObs._emit(entries)
+
+    const interactions = mod.getINPInteractions()
+    assert.strictEqual(interactions.length, 10)
+    assert.strictEqual(interactions[0].interaction_id, 15)
+    assert.strictEqual(interactions[9].interaction_id, 6)
+  })
 })
 
 describe('Performance Snapshot Message Flow', () => {
@@ -413,6 +461,8 @@ describe('Performance Snapshot Message Flow', () => {
       175,
       'Payload timing should include interaction_to_next_paint'
     )
+    const interactions = snapshotMessage.arguments[0].payload.interactions
+    assert.strictEqual(interactions?.[0]?.duration, 175, 'Payload should include the per-interaction breakdown')
   })
 
   test('sendPerformanceSnapshot does nothing when disabled', async () => {